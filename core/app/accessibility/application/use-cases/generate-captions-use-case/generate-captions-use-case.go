@@ -0,0 +1,119 @@
+package generate_captions_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+
+	cockroachErrors "github.com/cockroachdb/errors"
+)
+
+// BatchSize is how many files are transcribed per batch, transcriptions are slow
+const BatchSize = 5
+
+type GenerateCaptionsUseCase struct {
+	captions ports.CaptionRepository
+	provider ports.CaptionProvider
+	files    storage.FileStorage
+}
+
+func NewGenerateCaptionsUseCase(captions ports.CaptionRepository, provider ports.CaptionProvider,
+	files storage.FileStorage) *GenerateCaptionsUseCase {
+	return &GenerateCaptionsUseCase{
+		captions: captions,
+		provider: provider,
+		files:    files,
+	}
+}
+
+// Execute generates the pending captions and transcripts in batches and returns how many it recorded.
+// Files the provider cannot transcribe, or transcribes into captions that are not WebVTT, are recorded
+// as failed and not retried. Files that cannot be read, the provider fails on or whose captions cannot
+// be stored stay pending for the next run, the first failure is returned for logging after the rest of
+// the batch is generated.
+func (uc *GenerateCaptionsUseCase) Execute(ctx context.Context) (int, error) {
+	generated := 0
+	for {
+		pending, err := uc.captions.ListPending(ctx, BatchSize)
+		if err != nil {
+			return generated, errors.PropagateError(err)
+		}
+
+		var failed error
+		for _, source := range pending {
+			recorded, err := uc.generate(ctx, source)
+			if err != nil {
+				if failed == nil {
+					failed = err
+				}
+				continue
+			}
+			if recorded {
+				generated++
+			}
+		}
+
+		if failed != nil {
+			return generated, failed
+		}
+		if len(pending) < BatchSize {
+			return generated, nil
+		}
+	}
+}
+
+func (uc *GenerateCaptionsUseCase) generate(ctx context.Context, source *entities.CaptionSource) (bool, error) {
+	status, err := uc.transcribe(ctx, source)
+	if err != nil {
+		return false, err
+	}
+
+	captions, err := entities.NewFileCaptions(source, status, time.Now().UTC())
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+
+	recorded, err := uc.captions.Record(ctx, captions)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+	return recorded, nil
+}
+
+// transcribe stores the captions and transcript of the file and returns the status it is recorded with
+func (uc *GenerateCaptionsUseCase) transcribe(ctx context.Context, source *entities.CaptionSource) (storage.CaptionStatus, error) {
+	if !uc.provider.Supports(source.ContentType) {
+		return storage.CaptionUnsupported, nil
+	}
+
+	content, err := uc.files.Get(ctx, source.StorageKey)
+	if err != nil {
+		return "", errors.PropagateError(err)
+	}
+	if content == nil {
+		return storage.CaptionFailed, nil
+	}
+
+	transcription, err := uc.provider.Transcribe(ctx, source.ContentType, content)
+	if err != nil {
+		if cockroachErrors.Is(err, entities.ErrUntranscribable) {
+			return storage.CaptionFailed, nil
+		}
+		return "", errors.PropagateError(err)
+	}
+	if !transcription.Valid() {
+		return storage.CaptionFailed, nil
+	}
+
+	if err := uc.files.Put(ctx, storage.AlternativeCaptions.Key(source.StorageKey), transcription.Captions); err != nil {
+		return "", errors.PropagateError(err)
+	}
+	if err := uc.files.Put(ctx, storage.AlternativeTranscript.Key(source.StorageKey), []byte(transcription.Transcript)); err != nil {
+		return "", errors.PropagateError(err)
+	}
+	return storage.CaptionReady, nil
+}
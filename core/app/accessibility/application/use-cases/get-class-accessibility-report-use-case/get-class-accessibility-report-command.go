@@ -0,0 +1,28 @@
+package get_class_accessibility_report_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetClassAccessibilityReportCommand struct {
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"required,uuid"`
+}
+
+func NewGetClassAccessibilityReportCommand(tenantID string, classID string) (*GetClassAccessibilityReportCommand, error) {
+	command := &GetClassAccessibilityReportCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
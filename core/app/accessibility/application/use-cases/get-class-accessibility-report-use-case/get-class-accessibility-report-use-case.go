@@ -0,0 +1,31 @@
+package get_class_accessibility_report_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetClassAccessibilityReportUseCase struct {
+	materials ports.ClassMaterials
+}
+
+func NewGetClassAccessibilityReportUseCase(materials ports.ClassMaterials) *GetClassAccessibilityReportUseCase {
+	return &GetClassAccessibilityReportUseCase{
+		materials: materials,
+	}
+}
+
+// Execute reports on the materials shared in the class thread as they are now, a class without
+// materials complies
+func (uc *GetClassAccessibilityReportUseCase) Execute(ctx context.Context, cmd *GetClassAccessibilityReportCommand) (*entities.AccessibilityReport, error) {
+	materials, err := uc.materials.ListMaterials(ctx, cmd.TenantID, cmd.ClassID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return entities.NewAccessibilityReport(cmd.ClassID, materials, time.Now().UTC()), nil
+}
@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+)
+
+// Issue is what keeps a material of a class from meeting accessibility mandates
+type Issue string
+
+const (
+	// IssueMissingAltText images were shared without a text alternative, before it was asked for
+	IssueMissingAltText Issue = "missing_alt_text"
+	// IssueCaptionsPending videos and audio are waiting for the caption provider
+	IssueCaptionsPending Issue = "captions_pending"
+	// IssueMissingCaptions videos and audio could not be captioned, they need captions made by hand
+	IssueMissingCaptions Issue = "missing_captions"
+)
+
+// ClassMaterial is a file shared with the students of a class in its class thread
+type ClassMaterial struct {
+	ID            string
+	MessageID     string
+	FileName      string
+	ContentType   string
+	AltText       string
+	CaptionStatus storage.CaptionStatus
+	UploadedBy    string
+	UploadedAt    time.Time
+}
+
+// Issue returns what the material lacks, empty when it complies
+func (m ClassMaterial) Issue() Issue {
+	switch {
+	case storage.NeedsAltText(m.ContentType) && m.AltText == "":
+		return IssueMissingAltText
+	case !storage.NeedsCaptions(m.ContentType):
+		return ""
+	case m.CaptionStatus == storage.CaptionReady:
+		return ""
+	case m.CaptionStatus == storage.CaptionPending:
+		return IssueCaptionsPending
+	default:
+		return IssueMissingCaptions
+	}
+}
+
+// MaterialCount counts the materials of a kind and how many of them comply
+type MaterialCount struct {
+	Total     int
+	Compliant int
+}
+
+// AccessibilityReport tells how far the materials of a class meet accessibility mandates: images
+// with a text alternative, videos and audio with captions and a transcript
+type AccessibilityReport struct {
+	ClassID string
+	Images  MaterialCount
+	Media   MaterialCount
+	// Issues are the materials that do not comply, in the order they were listed
+	Issues      []ClassMaterial
+	GeneratedAt time.Time
+}
+
+func NewAccessibilityReport(classID string, materials []ClassMaterial, generatedAt time.Time) *AccessibilityReport {
+	report := &AccessibilityReport{ClassID: classID, Issues: []ClassMaterial{}, GeneratedAt: generatedAt}
+	for _, material := range materials {
+		var count *MaterialCount
+		switch {
+		case storage.NeedsAltText(material.ContentType):
+			count = &report.Images
+		case storage.NeedsCaptions(material.ContentType):
+			count = &report.Media
+		default:
+			continue
+		}
+		count.Total++
+		if material.Issue() == "" {
+			count.Compliant++
+		} else {
+			report.Issues = append(report.Issues, material)
+		}
+	}
+	return report
+}
+
+// Compliant reports whether every material of the class complies
+func (r *AccessibilityReport) Compliant() bool {
+	return len(r.Issues) == 0
+}
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"bytes"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// ErrUntranscribable is returned by caption providers for files they cannot read, such files are not
+// retried
+var ErrUntranscribable = errors.New("file cannot be transcribed")
+
+// FileKind is the record an uploaded file belongs to
+type FileKind string
+
+const (
+	FileKindResourceVersion FileKind = "resource_version"
+	FileKindAttachment      FileKind = "attachment"
+)
+
+// CaptionSource is a video or audio file scanned clean that has no captions yet
+type CaptionSource struct {
+	Kind        FileKind
+	ID          string
+	TenantID    string
+	StorageKey  string
+	ContentType string
+}
+
+// Transcription is what the caption provider made of a file
+type Transcription struct {
+	// Captions are WebVTT cues timed to the media
+	Captions   []byte
+	Transcript string
+}
+
+// Valid reports whether the captions are a WebVTT file, a byte order mark is allowed before the header
+func (t *Transcription) Valid() bool {
+	return bytes.HasPrefix(bytes.TrimPrefix(t.Captions, []byte("\xef\xbb\xbf")), []byte("WEBVTT"))
+}
+
+// FileCaptions is the outcome of the generation of the captions of a file, the captions and transcript
+// themselves are in the file storage once they are ready
+type FileCaptions struct {
+	Source      *CaptionSource        `validate:"required"`
+	Status      storage.CaptionStatus `validate:"required,oneof=ready unsupported failed"`
+	GeneratedAt time.Time             `validate:"required"`
+}
+
+func NewFileCaptions(source *CaptionSource, status storage.CaptionStatus, generatedAt time.Time) (*FileCaptions, error) {
+	captions := &FileCaptions{
+		Source:      source,
+		Status:      status,
+		GeneratedAt: generatedAt,
+	}
+
+	if err := validate.Struct(captions); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("FileCaptions domain model instance not valid", errorMap, err)
+	}
+
+	return captions, nil
+}
@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/entities"
+)
+
+// CaptionRepository tracks the captions of the uploaded videos and audio of every tenant
+type CaptionRepository interface {
+	// ListPending returns the videos and audio scanned clean whose captions were not generated yet,
+	// oldest upload first
+	ListPending(ctx context.Context, limit int) ([]*entities.CaptionSource, error)
+	// Record stores the status of the captions on the file. Returns false when the file was no longer
+	// pending.
+	Record(ctx context.Context, captions *entities.FileCaptions) (bool, error)
+}
+
+// CaptionProvider transcribes videos and audio into captions and a transcript
+type CaptionProvider interface {
+	// Supports tells whether files of the content type can be transcribed
+	Supports(contentType string) bool
+	// Transcribe returns the captions and transcript of the file. It fails with
+	// entities.ErrUntranscribable on files that are damaged or have no speech it can read, other
+	// failures are retried.
+	Transcribe(ctx context.Context, contentType string, content []byte) (*entities.Transcription, error)
+}
+
+// ClassMaterials reads the files shared in the class thread of a class, which the messaging module
+// keeps
+type ClassMaterials interface {
+	// ListMaterials returns the attachments scanned clean of the messages of the class thread that are
+	// not hidden, newest first
+	ListMaterials(ctx context.Context, tenantID string, classID string) ([]entities.ClassMaterial, error)
+}
@@ -0,0 +1,52 @@
+package list_activity_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/activity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListActivityCommand struct {
+	TenantID   string                      `validate:"required"`
+	UserID     string                      `validate:"max=255"`
+	Categories []entities.ActivityCategory `validate:"max=3,dive,oneof=audit authorization auth"`
+	Types      []string                    `validate:"max=20,dive,required,max=100"`
+	PageSize   int                         `validate:"min=1,max=100"`
+	After      *entities.ActivityCursor
+}
+
+// NewListActivityCommand lists the timeline of the tenant, or of one of its users when userID is set
+func NewListActivityCommand(tenantID string, userID string, categories []string, types []string, pageSize int, cursor string) (*ListActivityCommand, error) {
+	if pageSize == 0 {
+		pageSize = pagination.DefaultPageSize
+	}
+
+	command := &ListActivityCommand{
+		TenantID:   tenantID,
+		UserID:     userID,
+		Categories: make([]entities.ActivityCategory, len(categories)),
+		Types:      types,
+		PageSize:   pageSize,
+	}
+	for i, category := range categories {
+		command.Categories[i] = entities.ActivityCategory(category)
+	}
+	if cursor != "" {
+		after, err := entities.ParseActivityCursor(cursor)
+		if err != nil {
+			return nil, errors.NewValidationError("Invalid list parameters", map[string]any{"cursor": "Invalid cursor"}, err)
+		}
+		command.After = after
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,43 @@
+package list_activity_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/activity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/activity/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type ListActivityUseCase struct {
+	activityRepo ports.ActivityRepository
+}
+
+func NewListActivityUseCase(activityRepo ports.ActivityRepository) *ListActivityUseCase {
+	return &ListActivityUseCase{
+		activityRepo: activityRepo,
+	}
+}
+
+// Execute returns a page of the timeline, newest first. The cursor of the next page points at the
+// last event of the page.
+func (uc *ListActivityUseCase) Execute(ctx context.Context, cmd *ListActivityCommand) (*pagination.Page[*entities.ActivityEvent], error) {
+	filter := ports.ActivityFilter{
+		TenantID:   cmd.TenantID,
+		UserID:     cmd.UserID,
+		Categories: cmd.Categories,
+		Types:      cmd.Types,
+		After:      cmd.After,
+	}
+	fetched, total, err := uc.activityRepo.List(ctx, filter, cmd.PageSize+1)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	page := &pagination.Page[*entities.ActivityEvent]{Items: fetched, TotalEstimate: total}
+	if len(fetched) > cmd.PageSize {
+		page.Items = fetched[:cmd.PageSize]
+		page.NextCursor = page.Items[cmd.PageSize-1].Cursor().Encode()
+	}
+	return page, nil
+}
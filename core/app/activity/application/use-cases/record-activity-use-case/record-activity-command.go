@@ -0,0 +1,27 @@
+package record_activity_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/activity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RecordActivityCommand struct {
+	Events []*entities.ActivityEvent `validate:"required,min=1,dive,required"`
+}
+
+func NewRecordActivityCommand(events []*entities.ActivityEvent) (*RecordActivityCommand, error) {
+	command := &RecordActivityCommand{
+		Events: events,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,47 @@
+package record_activity_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/activity/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// ActivityRetention is how long timeline events are kept. The audit log keeps audit events for good.
+const ActivityRetention = 180 * 24 * time.Hour
+
+type RecordActivityUseCase struct {
+	activityRepo ports.ActivityRepository
+	clock        clock.Clock
+}
+
+func NewRecordActivityUseCase(activityRepo ports.ActivityRepository) *RecordActivityUseCase {
+	return &RecordActivityUseCase{
+		activityRepo: activityRepo,
+		clock:        clock.System,
+	}
+}
+
+// WithClock changes the clock the retention window of PurgeExpired ends at
+func (uc *RecordActivityUseCase) WithClock(clock clock.Clock) *RecordActivityUseCase {
+	uc.clock = clock
+	return uc
+}
+
+func (uc *RecordActivityUseCase) Execute(ctx context.Context, cmd *RecordActivityCommand) error {
+	if err := uc.activityRepo.Append(ctx, cmd.Events); err != nil {
+		return errors.PropagateError(err)
+	}
+	return nil
+}
+
+// PurgeExpired deletes the events older than ActivityRetention
+func (uc *RecordActivityUseCase) PurgeExpired(ctx context.Context) (int64, error) {
+	deleted, err := uc.activityRepo.DeleteBefore(ctx, uc.clock.Now().UTC().Add(-ActivityRetention))
+	if err != nil {
+		return 0, errors.PropagateError(err)
+	}
+	return deleted, nil
+}
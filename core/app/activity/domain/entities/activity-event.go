@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ActivityCategory is the source of an activity event
+type ActivityCategory string
+
+const (
+	// ActivityCategoryAudit events are the outbox events of the tenant, the ones chained in the audit log
+	ActivityCategoryAudit ActivityCategory = "audit"
+	// ActivityCategoryAuthorization events are the permission checks of identified requests
+	ActivityCategoryAuthorization ActivityCategory = "authorization"
+	// ActivityCategoryAuth events are sign ups and requests without a user or tenant
+	ActivityCategoryAuth ActivityCategory = "auth"
+)
+
+var ActivityCategories = []ActivityCategory{ActivityCategoryAudit, ActivityCategoryAuthorization, ActivityCategoryAuth}
+
+// Types of the authorization and auth events, audit events keep the type of their outbox event
+const (
+	// ActivityAuthorizationDenied is a request refused for lack of permission
+	ActivityAuthorizationDenied = "authorization.denied"
+	// ActivityAuthorizationGranted is a write that was allowed, reads are not recorded
+	ActivityAuthorizationGranted = "authorization.granted"
+	// ActivityAuthUnauthenticated is a request to a protected endpoint without a user or tenant
+	ActivityAuthUnauthenticated = "auth.unauthenticated"
+	// ActivityAuthSignedUp is a user created through sign up
+	ActivityAuthSignedUp = "auth.signed_up"
+)
+
+// ActivityEvent is an entry of the activity timeline of a tenant. UserID is the user the event is
+// about: the caller of a request, or the actor or subject of an audit event. It is empty when the
+// event names no user, such events are only in the timeline of the tenant.
+type ActivityEvent struct {
+	ID       int64
+	TenantID string
+	UserID   string
+	Category ActivityCategory
+	Type     string
+	// Resource is the operation of a request and the aggregate of an audit event
+	Resource   string
+	Detail     map[string]any
+	OccurredAt time.Time
+}
+
+// Cursor is the position of the event in the timeline, newest first
+func (e *ActivityEvent) Cursor() ActivityCursor {
+	return ActivityCursor{OccurredAt: e.OccurredAt, ID: e.ID}
+}
+
+// ActivityCursor points after an event of the timeline. Events recorded later than the first page
+// was read never shift the pages after it.
+type ActivityCursor struct {
+	OccurredAt time.Time
+	ID         int64
+}
+
+// Encode returns the opaque form of the cursor sent to clients
+func (c ActivityCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("a:%d:%d", c.OccurredAt.UnixMicro(), c.ID)))
+}
+
+// ParseActivityCursor reads a cursor returned by Encode
+func ParseActivityCursor(cursor string) (*ActivityCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 || parts[0] != "a" {
+		return nil, fmt.Errorf("unknown cursor format")
+	}
+	micros, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &ActivityCursor{OccurredAt: time.UnixMicro(micros).UTC(), ID: id}, nil
+}
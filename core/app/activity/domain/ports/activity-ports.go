@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/activity/domain/entities"
+)
+
+// ActivityFilter selects the events of a timeline. Empty fields match every event.
+type ActivityFilter struct {
+	TenantID   string
+	UserID     string
+	Categories []entities.ActivityCategory
+	Types      []string
+	// After skips the events up to the cursor, included
+	After *entities.ActivityCursor
+}
+
+type ActivityRepository interface {
+	Append(ctx context.Context, events []*entities.ActivityEvent) error
+	// List returns up to limit events matching the filter, newest first, and an estimate of how many
+	// match in total
+	List(ctx context.Context, filter ActivityFilter, limit int) ([]*entities.ActivityEvent, int64, error)
+	// DeleteBefore drops the events that occurred before `before` and returns how many it deleted
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
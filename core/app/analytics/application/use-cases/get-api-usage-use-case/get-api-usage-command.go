@@ -0,0 +1,57 @@
+package get_api_usage_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// MaxRangeDays bounds the range of one report
+	MaxRangeDays = 92
+	// DefaultConsumers is how many consumers a report lists when the request does not say
+	DefaultConsumers = 50
+)
+
+var validate = validator.New()
+
+type GetAPIUsageCommand struct {
+	TenantID string    `validate:"required"`
+	From     time.Time `validate:"required"`
+	// To is the first instant after the range
+	To        time.Time `validate:"required,gtfield=From"`
+	Consumers int       `validate:"min=1,max=500"`
+}
+
+// NewGetAPIUsageCommand takes both days of the range, included, as dates like 2026-10-01 in UTC
+func NewGetAPIUsageCommand(tenantID string, from string, to string, consumers int) (*GetAPIUsageCommand, error) {
+	fromDay, fromErr := time.Parse(time.DateOnly, from)
+	toDay, toErr := time.Parse(time.DateOnly, to)
+	if fromErr != nil || toErr != nil {
+		return nil, errors.NewValidationError("Invalid usage range",
+			map[string]any{"from": "must be a date like 2026-10-01", "to": "must be a date like 2026-10-31"}, nil)
+	}
+	if consumers == 0 {
+		consumers = DefaultConsumers
+	}
+
+	command := &GetAPIUsageCommand{
+		TenantID:  tenantID,
+		From:      fromDay,
+		To:        toDay.AddDate(0, 0, 1),
+		Consumers: consumers,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if command.To.After(command.From.AddDate(0, 0, MaxRangeDays)) {
+		return nil, errors.NewValidationError("Invalid usage range",
+			map[string]any{"to": "at most 92 days can be reported at once"}, nil)
+	}
+
+	return command, nil
+}
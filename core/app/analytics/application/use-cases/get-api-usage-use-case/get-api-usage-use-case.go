@@ -0,0 +1,45 @@
+package get_api_usage_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/analytics/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/analytics/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// TopEndpoints is how many operations are listed per consumer
+const TopEndpoints = 5
+
+type GetAPIUsageUseCase struct {
+	usageRepo  ports.UsageRepository
+	thresholds entities.AbuseThresholds
+}
+
+func NewGetAPIUsageUseCase(usageRepo ports.UsageRepository, thresholds entities.AbuseThresholds) *GetAPIUsageUseCase {
+	return &GetAPIUsageUseCase{
+		usageRepo:  usageRepo,
+		thresholds: thresholds,
+	}
+}
+
+// Execute reports the busiest consumers of the tenant with their top endpoints and abuse flags.
+// Flagged consumers are listed past the busiest ones too, the total counts every consumer.
+func (uc *GetAPIUsageUseCase) Execute(ctx context.Context, cmd *GetAPIUsageCommand) (*entities.UsageReport, error) {
+	counters, err := uc.usageRepo.SumByOperation(ctx, cmd.TenantID, cmd.From, cmd.To)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	report := &entities.UsageReport{TenantID: cmd.TenantID, From: cmd.From, To: cmd.To}
+	hours := int64(cmd.To.Sub(cmd.From).Hours())
+	for _, usage := range entities.SummarizeUsage(counters, TopEndpoints) {
+		report.TotalRequests += usage.Requests
+		usage.Flag(uc.thresholds, hours)
+		if len(report.Consumers) < cmd.Consumers || len(usage.Flags) > 0 {
+			report.Consumers = append(report.Consumers, usage)
+		}
+	}
+
+	return report, nil
+}
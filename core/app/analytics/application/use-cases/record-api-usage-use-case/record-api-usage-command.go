@@ -0,0 +1,27 @@
+package record_api_usage_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/analytics/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RecordAPIUsageCommand struct {
+	Counters []*entities.UsageCounter `validate:"required,min=1,dive,required"`
+}
+
+func NewRecordAPIUsageCommand(counters []*entities.UsageCounter) (*RecordAPIUsageCommand, error) {
+	command := &RecordAPIUsageCommand{
+		Counters: counters,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
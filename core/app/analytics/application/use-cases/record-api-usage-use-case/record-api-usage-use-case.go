@@ -0,0 +1,47 @@
+package record_api_usage_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/analytics/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// UsageRetention is how long hourly counters are kept, enough to compare a quarter with the last
+const UsageRetention = 180 * 24 * time.Hour
+
+type RecordAPIUsageUseCase struct {
+	usageRepo ports.UsageRepository
+	clock     clock.Clock
+}
+
+func NewRecordAPIUsageUseCase(usageRepo ports.UsageRepository) *RecordAPIUsageUseCase {
+	return &RecordAPIUsageUseCase{
+		usageRepo: usageRepo,
+		clock:     clock.System,
+	}
+}
+
+// WithClock changes the clock the retention window of PurgeExpired ends at
+func (uc *RecordAPIUsageUseCase) WithClock(clock clock.Clock) *RecordAPIUsageUseCase {
+	uc.clock = clock
+	return uc
+}
+
+func (uc *RecordAPIUsageUseCase) Execute(ctx context.Context, cmd *RecordAPIUsageCommand) error {
+	if err := uc.usageRepo.Add(ctx, cmd.Counters); err != nil {
+		return errors.PropagateError(err)
+	}
+	return nil
+}
+
+// PurgeExpired deletes the counters older than UsageRetention
+func (uc *RecordAPIUsageUseCase) PurgeExpired(ctx context.Context) (int64, error) {
+	deleted, err := uc.usageRepo.DeleteBefore(ctx, uc.clock.Now().UTC().Add(-UsageRetention))
+	if err != nil {
+		return 0, errors.PropagateError(err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,177 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// ConsumerKind tells what authenticated the requests of a consumer
+type ConsumerKind string
+
+const (
+	ConsumerUser ConsumerKind = "user"
+	// ConsumerServiceAccount consumers are counted by their subject, sa:<id>
+	ConsumerServiceAccount ConsumerKind = "service_account"
+)
+
+// Abuse flags raised on consumers
+const (
+	FlagHighVolume    = "high_volume"
+	FlagHighErrorRate = "high_error_rate"
+)
+
+// UsageCounter counts the requests of a consumer to an operation within the hour starting at Hour.
+// Latency is the total time spent serving them.
+type UsageCounter struct {
+	TenantID     string       `validate:"required"`
+	ConsumerKind ConsumerKind `validate:"required"`
+	ConsumerID   string       `validate:"required"`
+	OperationID  string       `validate:"required"`
+	Hour         time.Time    `validate:"required"`
+	Requests     int64
+	ClientErrors int64
+	ServerErrors int64
+	Latency      time.Duration
+}
+
+// Record counts one request answered with status after latency
+func (c *UsageCounter) Record(status int, latency time.Duration) {
+	c.Requests++
+	c.Latency += latency
+	switch {
+	case status >= 500:
+		c.ServerErrors++
+	case status >= 400:
+		c.ClientErrors++
+	}
+}
+
+// EndpointUsage sums the requests of a consumer to one operation
+type EndpointUsage struct {
+	OperationID  string
+	Requests     int64
+	ClientErrors int64
+	ServerErrors int64
+	Latency      time.Duration
+}
+
+// ConsumerUsage sums the requests of a consumer over a range, Endpoints holds its most called
+// operations first
+type ConsumerUsage struct {
+	Kind         ConsumerKind
+	ConsumerID   string
+	Requests     int64
+	ClientErrors int64
+	ServerErrors int64
+	Latency      time.Duration
+	Endpoints    []*EndpointUsage
+	Flags        []string
+}
+
+// ErrorRate is the share of requests that failed, client errors included
+func (u *ConsumerUsage) ErrorRate() float64 {
+	if u.Requests == 0 {
+		return 0
+	}
+	return float64(u.ClientErrors+u.ServerErrors) / float64(u.Requests)
+}
+
+// AverageLatency is zero for consumers without requests
+func (u *ConsumerUsage) AverageLatency() time.Duration {
+	if u.Requests == 0 {
+		return 0
+	}
+	return u.Latency / time.Duration(u.Requests)
+}
+
+// AbuseThresholds flag consumers whose traffic looks automated or like probing. MinRequests keeps
+// a few failed calls from flagging a consumer for its error rate.
+type AbuseThresholds struct {
+	MaxRequestsPerHour int64
+	MaxErrorRate       float64
+	MinRequests        int64
+}
+
+// Flag raises the abuse flags of the consumer over a range of hours
+func (u *ConsumerUsage) Flag(thresholds AbuseThresholds, hours int64) {
+	u.Flags = nil
+	if hours < 1 {
+		hours = 1
+	}
+	if thresholds.MaxRequestsPerHour > 0 && u.Requests > thresholds.MaxRequestsPerHour*hours {
+		u.Flags = append(u.Flags, FlagHighVolume)
+	}
+	if thresholds.MaxErrorRate > 0 && u.Requests >= thresholds.MinRequests && u.ErrorRate() > thresholds.MaxErrorRate {
+		u.Flags = append(u.Flags, FlagHighErrorRate)
+	}
+}
+
+// UsageReport is the API usage of a tenant between From and To, busiest consumers first
+type UsageReport struct {
+	TenantID      string
+	From          time.Time
+	To            time.Time
+	TotalRequests int64
+	Consumers     []*ConsumerUsage
+}
+
+// SummarizeUsage groups counters per consumer, keeping the topEndpoints most called operations of
+// each. Consumers and endpoints are ordered by requests, most first.
+func SummarizeUsage(counters []*UsageCounter, topEndpoints int) []*ConsumerUsage {
+	type consumerKey struct {
+		kind ConsumerKind
+		id   string
+	}
+	byConsumer := make(map[consumerKey]*ConsumerUsage)
+	var consumers []*ConsumerUsage
+	for _, counter := range counters {
+		key := consumerKey{kind: counter.ConsumerKind, id: counter.ConsumerID}
+		usage, ok := byConsumer[key]
+		if !ok {
+			usage = &ConsumerUsage{Kind: counter.ConsumerKind, ConsumerID: counter.ConsumerID}
+			byConsumer[key] = usage
+			consumers = append(consumers, usage)
+		}
+
+		usage.Requests += counter.Requests
+		usage.ClientErrors += counter.ClientErrors
+		usage.ServerErrors += counter.ServerErrors
+		usage.Latency += counter.Latency
+		usage.Endpoints = appendEndpoint(usage.Endpoints, counter)
+	}
+
+	for _, usage := range consumers {
+		sort.SliceStable(usage.Endpoints, func(i, j int) bool {
+			return usage.Endpoints[i].Requests > usage.Endpoints[j].Requests
+		})
+		if len(usage.Endpoints) > topEndpoints {
+			usage.Endpoints = usage.Endpoints[:topEndpoints]
+		}
+	}
+	sort.SliceStable(consumers, func(i, j int) bool {
+		if consumers[i].Requests != consumers[j].Requests {
+			return consumers[i].Requests > consumers[j].Requests
+		}
+		return consumers[i].ConsumerID < consumers[j].ConsumerID
+	})
+	return consumers
+}
+
+func appendEndpoint(endpoints []*EndpointUsage, counter *UsageCounter) []*EndpointUsage {
+	for _, endpoint := range endpoints {
+		if endpoint.OperationID == counter.OperationID {
+			endpoint.Requests += counter.Requests
+			endpoint.ClientErrors += counter.ClientErrors
+			endpoint.ServerErrors += counter.ServerErrors
+			endpoint.Latency += counter.Latency
+			return endpoints
+		}
+	}
+	return append(endpoints, &EndpointUsage{
+		OperationID:  counter.OperationID,
+		Requests:     counter.Requests,
+		ClientErrors: counter.ClientErrors,
+		ServerErrors: counter.ServerErrors,
+		Latency:      counter.Latency,
+	})
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/analytics/domain/entities"
+)
+
+type UsageRepository interface {
+	// Add adds the counters to the stored ones of the same consumer, operation and hour, every
+	// instance adds its own counts
+	Add(ctx context.Context, counters []*entities.UsageCounter) error
+	// SumByOperation returns the counters of the tenant for the hours from `from` up to `to`, summed
+	// per consumer and operation. Hour is left zero.
+	SumByOperation(ctx context.Context, tenantID string, from time.Time, to time.Time) ([]*entities.UsageCounter, error)
+	// DeleteBefore drops the counters of the hours before `before` and returns how many it deleted
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
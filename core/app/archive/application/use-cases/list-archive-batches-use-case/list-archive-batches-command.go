@@ -0,0 +1,28 @@
+package list_archive_batches_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListArchiveBatchesCommand struct {
+	TenantID string `validate:"required"`
+	Kind     string `validate:"omitempty,oneof=submissions messages audit"`
+}
+
+func NewListArchiveBatchesCommand(tenantID string, kind string) (*ListArchiveBatchesCommand, error) {
+	command := &ListArchiveBatchesCommand{
+		TenantID: tenantID,
+		Kind:     kind,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,28 @@
+package list_archive_batches_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/archive/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/archive/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListArchiveBatchesUseCase struct {
+	store ports.ArchiveStore
+}
+
+func NewListArchiveBatchesUseCase(store ports.ArchiveStore) *ListArchiveBatchesUseCase {
+	return &ListArchiveBatchesUseCase{
+		store: store,
+	}
+}
+
+func (uc *ListArchiveBatchesUseCase) Execute(ctx context.Context, cmd *ListArchiveBatchesCommand) ([]*entities.ArchiveBatch, error) {
+	batches, err := uc.store.ListBatches(ctx, cmd.TenantID, entities.ArchiveKind(cmd.Kind))
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return batches, nil
+}
@@ -0,0 +1,30 @@
+package restore_archive_batch_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RestoreArchiveBatchCommand struct {
+	TenantID string `validate:"required"`
+	BatchID  string `validate:"required,uuid"`
+	ActorID  string `validate:"required"`
+}
+
+func NewRestoreArchiveBatchCommand(tenantID string, batchID string, actorID string) (*RestoreArchiveBatchCommand, error) {
+	command := &RestoreArchiveBatchCommand{
+		TenantID: tenantID,
+		BatchID:  batchID,
+		ActorID:  actorID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,49 @@
+package restore_archive_batch_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/archive/domain/entities"
+	archiveErrors "github.com/nahualventure/class-backend/core/app/archive/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/archive/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type RestoreArchiveBatchUseCase struct {
+	store ports.ArchiveStore
+}
+
+func NewRestoreArchiveBatchUseCase(store ports.ArchiveStore) *RestoreArchiveBatchUseCase {
+	return &RestoreArchiveBatchUseCase{
+		store: store,
+	}
+}
+
+// Execute moves the records of the batch back into the hot tables. Records that clash with newer
+// ones stay archived and the batch is left partially restored, so it can be restored again once
+// the clash is resolved.
+func (uc *RestoreArchiveBatchUseCase) Execute(ctx context.Context, cmd *RestoreArchiveBatchCommand) (*entities.ArchiveBatch, error) {
+	batch, err := uc.store.FindBatch(ctx, cmd.TenantID, cmd.BatchID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if batch == nil {
+		return nil, archiveErrors.NewArchiveBatchNotFoundError(cmd.BatchID)
+	}
+	if !batch.CanRestore() {
+		return nil, archiveErrors.NewArchiveBatchAlreadyRestoredError(batch.ID, *batch.RestoredAt)
+	}
+
+	restored, remaining, err := uc.store.Restore(ctx, batch)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	batch.MarkRestored(restored, remaining, cmd.ActorID, time.Now().UTC())
+	if err := uc.store.SaveBatch(ctx, batch); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return batch, nil
+}
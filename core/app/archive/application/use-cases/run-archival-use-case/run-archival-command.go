@@ -0,0 +1,34 @@
+package run_archival_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RunArchivalCommand struct {
+	TenantID        string    `validate:"required"`
+	Now             time.Time `validate:"required"`
+	SchoolYearStart int       `validate:"required,min=1,max=12"`
+}
+
+// NewRunArchivalCommand archives the records of the school years before the one now falls in,
+// schoolYearStart is the month school years start in
+func NewRunArchivalCommand(tenantID string, now time.Time, schoolYearStart int) (*RunArchivalCommand, error) {
+	command := &RunArchivalCommand{
+		TenantID:        tenantID,
+		Now:             now,
+		SchoolYearStart: schoolYearStart,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,56 @@
+package run_archival_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/archive/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/archive/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+// DefaultBatchSize keeps each archive transaction short so hot tables are never locked for long
+const DefaultBatchSize = 5000
+
+type RunArchivalUseCase struct {
+	store     ports.ArchiveStore
+	batchSize int
+}
+
+func NewRunArchivalUseCase(store ports.ArchiveStore, batchSize int) *RunArchivalUseCase {
+	return &RunArchivalUseCase{
+		store:     store,
+		batchSize: batchSize,
+	}
+}
+
+// Execute moves the prior-year records of every kind into the archive, batchSize records per batch,
+// and returns the batches it created. Running it again only picks up what is left.
+func (uc *RunArchivalUseCase) Execute(ctx context.Context, cmd *RunArchivalCommand) ([]*entities.ArchiveBatch, error) {
+	cutoff := entities.PriorYearCutoff(cmd.Now, time.Month(cmd.SchoolYearStart))
+
+	batches := make([]*entities.ArchiveBatch, 0)
+	for _, kind := range entities.ArchiveKinds {
+		for {
+			batch, err := entities.NewArchiveBatch(uuid.New().String(), cmd.TenantID, kind, cutoff, cmd.Now.UTC())
+			if err != nil {
+				return batches, errors.PropagateError(err)
+			}
+
+			moved, err := uc.store.Archive(ctx, batch, uc.batchSize)
+			if err != nil {
+				return batches, errors.PropagateError(err)
+			}
+			if moved > 0 {
+				batches = append(batches, batch)
+			}
+			if moved < uc.batchSize {
+				break
+			}
+		}
+	}
+
+	return batches, nil
+}
@@ -0,0 +1,102 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// ArchiveKind names the records an archive batch holds
+type ArchiveKind string
+
+const (
+	// ArchiveKindSubmissions are the grades of turned in work
+	ArchiveKindSubmissions ArchiveKind = "submissions"
+	// ArchiveKindMessages are messages with their attachment metadata, the files stay in the file storage
+	ArchiveKindMessages ArchiveKind = "messages"
+	// ArchiveKindAudit are outbox events every projection already applied
+	ArchiveKindAudit ArchiveKind = "audit"
+)
+
+// ArchiveKinds is the order the archival job moves records in
+var ArchiveKinds = []ArchiveKind{ArchiveKindSubmissions, ArchiveKindMessages, ArchiveKindAudit}
+
+type ArchiveBatchStatus string
+
+const (
+	ArchiveBatchStatusArchived ArchiveBatchStatus = "archived"
+	ArchiveBatchStatusRestored ArchiveBatchStatus = "restored"
+	// ArchiveBatchStatusPartiallyRestored batches kept records that clash with newer ones, such as a grade
+	// recorded again for the same student and assignment. Restoring again retries them.
+	ArchiveBatchStatusPartiallyRestored ArchiveBatchStatus = "partially_restored"
+)
+
+// ArchiveBatch is one run of the archival job over one kind of record of a tenant, records are restored
+// by batch
+type ArchiveBatch struct {
+	ID            string      `validate:"required,uuid"`
+	TenantID      string      `validate:"required"`
+	Kind          ArchiveKind `validate:"required,oneof=submissions messages audit"`
+	Cutoff        time.Time   `validate:"required"`
+	RecordCount   int         `validate:"gte=0"`
+	RestoredCount int         `validate:"gte=0"`
+	Status        ArchiveBatchStatus
+	ArchivedAt    time.Time `validate:"required"`
+	RestoredBy    string
+	RestoredAt    *time.Time
+}
+
+// NewArchiveBatch starts a batch for the records older than cutoff, the archive store sets RecordCount
+func NewArchiveBatch(id string, tenantID string, kind ArchiveKind, cutoff time.Time, archivedAt time.Time) (*ArchiveBatch, error) {
+	batch := &ArchiveBatch{
+		ID:         id,
+		TenantID:   tenantID,
+		Kind:       kind,
+		Cutoff:     cutoff,
+		Status:     ArchiveBatchStatusArchived,
+		ArchivedAt: archivedAt,
+	}
+
+	if err := validate.Struct(batch); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("ArchiveBatch domain model instance not valid", errorMap, err)
+	}
+
+	return batch, nil
+}
+
+func (b *ArchiveBatch) CanRestore() bool {
+	return b.Status != ArchiveBatchStatusRestored
+}
+
+// MarkRestored records that restored records went back to the hot tables and remaining stayed archived
+func (b *ArchiveBatch) MarkRestored(restored int, remaining int, actorID string, now time.Time) {
+	b.RestoredCount += restored
+	b.RestoredBy = actorID
+	b.RestoredAt = &now
+	b.Status = ArchiveBatchStatusRestored
+	if remaining > 0 {
+		b.Status = ArchiveBatchStatusPartiallyRestored
+	}
+}
+
+// PriorYearCutoff is the start of the school year now falls in, records older than it belong to prior
+// years. School years start on the first day of schoolYearStart, in UTC.
+func PriorYearCutoff(now time.Time, schoolYearStart time.Month) time.Time {
+	now = now.UTC()
+	year := now.Year()
+	if now.Month() < schoolYearStart {
+		year--
+	}
+	return time.Date(year, schoolYearStart, 1, 0, 0, 0, 0, time.UTC)
+}
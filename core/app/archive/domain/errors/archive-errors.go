@@ -0,0 +1,42 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ArchiveBatchNotFoundError        errors2.ErrorCode = "ARCHIVE_BATCH_NOT_FOUND"
+	ArchiveBatchAlreadyRestoredError errors2.ErrorCode = "ARCHIVE_BATCH_ALREADY_RESTORED"
+)
+
+func NewArchiveBatchNotFoundError(batchID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ArchiveBatchNotFoundError.String(),
+			Message: "Archive batch not found",
+			Context: map[string]any{
+				"batchId": batchID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ArchiveBatchNotFoundError.String()),
+		},
+	}
+}
+
+func NewArchiveBatchAlreadyRestoredError(batchID string, restoredAt time.Time) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ArchiveBatchAlreadyRestoredError.String(),
+			Message: "Every record of the archive batch was already restored",
+			Context: map[string]any{
+				"batchId":    batchID,
+				"restoredAt": restoredAt,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ArchiveBatchAlreadyRestoredError.String()),
+		},
+	}
+}
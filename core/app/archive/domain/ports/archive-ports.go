@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/archive/domain/entities"
+)
+
+type ArchiveStore interface {
+	// Archive moves up to limit records of the batch kind older than its cutoff, oldest first, into the
+	// archive and saves the batch with RecordCount set, in one transaction. It returns how many records
+	// moved, the batch is not saved when none did.
+	Archive(ctx context.Context, batch *entities.ArchiveBatch, limit int) (int, error)
+	// Restore moves the records of the batch back in one transaction. Records that clash with a newer
+	// record stay archived, it returns how many were restored and how many remain.
+	Restore(ctx context.Context, batch *entities.ArchiveBatch) (int, int, error)
+	// SaveBatch updates the restore fields of the batch
+	SaveBatch(ctx context.Context, batch *entities.ArchiveBatch) error
+	// FindBatch returns nil when the tenant has no such batch
+	FindBatch(ctx context.Context, tenantID string, batchID string) (*entities.ArchiveBatch, error)
+	// ListBatches returns the batches of the tenant newest first, of every kind when kind is empty
+	ListBatches(ctx context.Context, tenantID string, kind entities.ArchiveKind) ([]*entities.ArchiveBatch, error)
+}
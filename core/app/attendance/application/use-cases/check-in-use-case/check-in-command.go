@@ -0,0 +1,39 @@
+package check_in_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// CheckInCommand checks the student in with the code scanned from the kiosk. The coordinates are
+// those of the device of the student, nil when it did not share them.
+type CheckInCommand struct {
+	TenantID  string   `validate:"required"`
+	SessionID string   `validate:"required,uuid"`
+	StudentID string   `validate:"required"`
+	Code      string   `validate:"required,max=16"`
+	Latitude  *float64 `validate:"omitnil,gte=-90,lte=90,required_with=Longitude"`
+	Longitude *float64 `validate:"omitnil,gte=-180,lte=180,required_with=Latitude"`
+}
+
+func NewCheckInCommand(tenantID string, sessionID string, studentID string, code string, latitude *float64,
+	longitude *float64) (*CheckInCommand, error) {
+	command := &CheckInCommand{
+		TenantID:  tenantID,
+		SessionID: sessionID,
+		StudentID: studentID,
+		Code:      code,
+		Latitude:  latitude,
+		Longitude: longitude,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
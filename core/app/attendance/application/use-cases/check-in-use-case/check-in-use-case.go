@@ -0,0 +1,70 @@
+package check_in_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/entities"
+	attendanceErrors "github.com/nahualventure/class-backend/core/app/attendance/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type CheckInUseCase struct {
+	sessions   ports.CheckInSessionRepository
+	roster     ports.ClassRoster
+	attendance ports.AttendanceRepository
+}
+
+func NewCheckInUseCase(sessions ports.CheckInSessionRepository, roster ports.ClassRoster, attendance ports.AttendanceRepository) *CheckInUseCase {
+	return &CheckInUseCase{
+		sessions:   sessions,
+		roster:     roster,
+		attendance: attendance,
+	}
+}
+
+// Execute records the student present once the code, the time, the location and their enrollment
+// check out
+func (uc *CheckInUseCase) Execute(ctx context.Context, cmd *CheckInCommand) (*entities.AttendanceRecord, error) {
+	session, err := uc.sessions.Find(ctx, cmd.TenantID, cmd.SessionID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if session == nil {
+		return nil, attendanceErrors.NewCheckInSessionNotFoundError(cmd.SessionID)
+	}
+
+	now := time.Now().UTC()
+	if !session.IsOpen(now) {
+		return nil, attendanceErrors.NewCheckInClosedError(session.ID, session.EndsAt)
+	}
+	if !session.Verify(cmd.Code, now) {
+		return nil, attendanceErrors.NewInvalidCheckInCodeError(session.ID)
+	}
+	if session.Fence != nil && (cmd.Latitude == nil || !session.Fence.Contains(*cmd.Latitude, *cmd.Longitude)) {
+		return nil, attendanceErrors.NewOutsideCheckInLocationError(session.ID)
+	}
+
+	enrolled, err := uc.roster.IsEnrolled(ctx, cmd.TenantID, session.ClassID, cmd.StudentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !enrolled {
+		return nil, attendanceErrors.NewNotEnrolledInClassError(session.ClassID, cmd.StudentID)
+	}
+
+	record, err := uc.attendance.Record(ctx, &entities.AttendanceRecord{
+		TenantID:   cmd.TenantID,
+		ClassID:    session.ClassID,
+		SessionID:  session.ID,
+		StudentID:  cmd.StudentID,
+		Present:    true,
+		Method:     entities.CheckInMethodQR,
+		RecordedAt: now,
+	})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return record, nil
+}
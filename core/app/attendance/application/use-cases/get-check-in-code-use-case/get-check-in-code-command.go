@@ -0,0 +1,28 @@
+package get_check_in_code_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetCheckInCodeCommand struct {
+	TenantID  string `validate:"required"`
+	SessionID string `validate:"required,uuid"`
+}
+
+func NewGetCheckInCodeCommand(tenantID string, sessionID string) (*GetCheckInCodeCommand, error) {
+	command := &GetCheckInCodeCommand{
+		TenantID:  tenantID,
+		SessionID: sessionID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
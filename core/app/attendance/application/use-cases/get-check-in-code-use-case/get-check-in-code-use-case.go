@@ -0,0 +1,47 @@
+package get_check_in_code_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/entities"
+	attendanceErrors "github.com/nahualventure/class-backend/core/app/attendance/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// CheckInCode is the code the kiosk shows until ExpiresAt
+type CheckInCode struct {
+	Session   *entities.CheckInSession
+	Code      string
+	ExpiresAt time.Time
+}
+
+type GetCheckInCodeUseCase struct {
+	sessions ports.CheckInSessionRepository
+}
+
+func NewGetCheckInCodeUseCase(sessions ports.CheckInSessionRepository) *GetCheckInCodeUseCase {
+	return &GetCheckInCodeUseCase{
+		sessions: sessions,
+	}
+}
+
+// Execute returns the current code of the session, kiosks ask for the next one when it expires
+func (uc *GetCheckInCodeUseCase) Execute(ctx context.Context, cmd *GetCheckInCodeCommand) (*CheckInCode, error) {
+	session, err := uc.sessions.Find(ctx, cmd.TenantID, cmd.SessionID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if session == nil {
+		return nil, attendanceErrors.NewCheckInSessionNotFoundError(cmd.SessionID)
+	}
+
+	now := time.Now().UTC()
+	if !session.IsOpen(now) {
+		return nil, attendanceErrors.NewCheckInClosedError(session.ID, session.EndsAt)
+	}
+
+	code, expiresAt := session.Code(now)
+	return &CheckInCode{Session: session, Code: code, ExpiresAt: expiresAt}, nil
+}
@@ -0,0 +1,41 @@
+package open_check_in_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// OpenCheckInCommand opens check-in to the current session of the class, Location is the zone of the
+// school the schedule is kept in
+type OpenCheckInCommand struct {
+	TenantID string         `validate:"required"`
+	ClassID  string         `validate:"required,uuid"`
+	OpenedBy string         `validate:"required"`
+	Location *time.Location `validate:"required"`
+	// Fence is nil when students check in from anywhere
+	Fence *entities.GeoFence `validate:"omitnil"`
+}
+
+func NewOpenCheckInCommand(tenantID string, classID string, openedBy string, loc *time.Location,
+	fence *entities.GeoFence) (*OpenCheckInCommand, error) {
+	command := &OpenCheckInCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+		OpenedBy: openedBy,
+		Location: loc,
+		Fence:    fence,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
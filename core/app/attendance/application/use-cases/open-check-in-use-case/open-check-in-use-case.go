@@ -0,0 +1,56 @@
+package open_check_in_use_case
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/entities"
+	attendanceErrors "github.com/nahualventure/class-backend/core/app/attendance/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type OpenCheckInUseCase struct {
+	sessions      ports.CheckInSessionRepository
+	classSessions ports.ClassSessions
+}
+
+func NewOpenCheckInUseCase(sessions ports.CheckInSessionRepository, classSessions ports.ClassSessions) *OpenCheckInUseCase {
+	return &OpenCheckInUseCase{
+		sessions:      sessions,
+		classSessions: classSessions,
+	}
+}
+
+// Execute opens check-in to the session of the class whose window holds now. Opening it again for the
+// same session returns the session opened first, with its location.
+func (uc *OpenCheckInUseCase) Execute(ctx context.Context, cmd *OpenCheckInCommand) (*entities.CheckInSession, error) {
+	now := time.Now().UTC()
+	sessions, err := uc.classSessions.SessionsOn(ctx, cmd.TenantID, cmd.ClassID, now, cmd.Location)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	current := entities.CurrentSession(sessions, now)
+	if current == nil {
+		return nil, attendanceErrors.NewNoClassSessionError(cmd.ClassID)
+	}
+
+	secret := make([]byte, entities.SecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.NewInfrastructureError("failed to generate the check-in secret", err)
+	}
+
+	session, err := entities.NewCheckInSession(uuid.New().String(), cmd.TenantID, current, secret, cmd.Fence, cmd.OpenedBy, now)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	session, err = uc.sessions.Create(ctx, session)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return session, nil
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+)
+
+// CheckInMethod is how the attendance of a student was taken
+type CheckInMethod string
+
+const (
+	CheckInMethodQR CheckInMethod = "qr"
+)
+
+// AttendanceRecord is the attendance of a student to a session of a class
+type AttendanceRecord struct {
+	TenantID   string
+	ClassID    string
+	SessionID  string
+	StudentID  string
+	Present    bool
+	Method     CheckInMethod
+	RecordedAt time.Time
+}
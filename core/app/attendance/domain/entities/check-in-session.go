@@ -0,0 +1,142 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"math"
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+const (
+	// CodePeriod is how often the check-in code shown by the kiosk changes
+	CodePeriod = 30 * time.Second
+	// EarlyCheckIn is how long before its session starts a class can be checked in to
+	EarlyCheckIn = 15 * time.Minute
+	// SecretSize is the size in bytes of the secret the codes of a session are derived from
+	SecretSize = 32
+	// codeLength is the number of base32 characters of a code, 40 bits
+	codeLength = 8
+)
+
+// ClassSession is one meeting of a class on a school day
+type ClassSession struct {
+	ClassID  string
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// CurrentSession returns the session whose check-in window holds at, nil when there is none. The
+// window opens EarlyCheckIn before the session starts and closes when it ends.
+func CurrentSession(sessions []ClassSession, at time.Time) *ClassSession {
+	for _, session := range sessions {
+		if !at.Before(session.StartsAt.Add(-EarlyCheckIn)) && at.Before(session.EndsAt) {
+			return &session
+		}
+	}
+	return nil
+}
+
+// GeoFence is the circle students check in from, the classroom of a hybrid class
+type GeoFence struct {
+	Latitude     float64 `validate:"gte=-90,lte=90"`
+	Longitude    float64 `validate:"gte=-180,lte=180"`
+	RadiusMeters int     `validate:"gte=10,lte=5000"`
+}
+
+// earthRadiusMeters is the mean radius of the Earth
+const earthRadiusMeters = 6_371_000
+
+// Contains tells whether the point is within the radius of the center, by the haversine distance
+func (f *GeoFence) Contains(latitude float64, longitude float64) bool {
+	lat1, lat2 := f.Latitude*math.Pi/180, latitude*math.Pi/180
+	dLat, dLng := lat2-lat1, (longitude-f.Longitude)*math.Pi/180
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	distance := 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+	return distance <= float64(f.RadiusMeters)
+}
+
+// CheckInSession lets the students of a class check in to one of its sessions by scanning the QR
+// code the kiosk shows. Codes are derived from the secret and change every CodePeriod, so a code
+// sent to a student at home stops working shortly after.
+type CheckInSession struct {
+	ID       string `validate:"required,uuid"`
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"required,uuid"`
+	StartsAt time.Time
+	EndsAt   time.Time `validate:"gtfield=StartsAt"`
+	Secret   []byte    `validate:"len=32"`
+	// Fence is nil when students check in from anywhere
+	Fence    *GeoFence `validate:"omitnil"`
+	OpenedBy string    `validate:"required"`
+	OpenedAt time.Time
+}
+
+func NewCheckInSession(id string, tenantID string, session *ClassSession, secret []byte, fence *GeoFence, openedBy string,
+	openedAt time.Time) (*CheckInSession, error) {
+	checkIn := &CheckInSession{
+		ID:       id,
+		TenantID: tenantID,
+		ClassID:  session.ClassID,
+		StartsAt: session.StartsAt,
+		EndsAt:   session.EndsAt,
+		Secret:   secret,
+		Fence:    fence,
+		OpenedBy: openedBy,
+		OpenedAt: openedAt,
+	}
+
+	if err := validate.Struct(checkIn); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("CheckInSession domain model instance not valid", errorMap, err)
+	}
+
+	return checkIn, nil
+}
+
+// IsOpen tells whether students can check in at the instant
+func (s *CheckInSession) IsOpen(at time.Time) bool {
+	return CurrentSession([]ClassSession{{ClassID: s.ClassID, StartsAt: s.StartsAt, EndsAt: s.EndsAt}}, at) != nil
+}
+
+// Code returns the code shown at the instant and when it changes
+func (s *CheckInSession) Code(at time.Time) (string, time.Time) {
+	step := at.UnixNano() / int64(CodePeriod)
+	return s.code(step), time.Unix(0, (step+1)*int64(CodePeriod)).UTC()
+}
+
+// Verify accepts the code shown at the instant and the one shown before it, a student scanning just
+// as the code changes is not turned away
+func (s *CheckInSession) Verify(code string, at time.Time) bool {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	step := at.UnixNano() / int64(CodePeriod)
+	valid := 0
+	for _, candidate := range []int64{step, step - 1} {
+		valid |= subtle.ConstantTimeCompare([]byte(code), []byte(s.code(candidate)))
+	}
+	return valid == 1
+}
+
+func (s *CheckInSession) code(step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha256.New, s.Secret)
+	mac.Write(counter[:])
+	return base32.StdEncoding.EncodeToString(mac.Sum(nil))[:codeLength]
+}
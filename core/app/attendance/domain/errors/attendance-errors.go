@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// CheckInSessionNotFoundError is also returned for sessions of another tenant
+	CheckInSessionNotFoundError errors2.ErrorCode = "CHECK_IN_SESSION_NOT_FOUND"
+	// NoClassSessionError is returned when check-in is opened outside the window of every session of
+	// the class, or on a non-instructional day
+	NoClassSessionError         errors2.ErrorCode = "NO_CLASS_SESSION"
+	CheckInClosedError          errors2.ErrorCode = "CHECK_IN_CLOSED"
+	InvalidCheckInCodeError     errors2.ErrorCode = "INVALID_CHECK_IN_CODE"
+	OutsideCheckInLocationError errors2.ErrorCode = "OUTSIDE_CHECK_IN_LOCATION"
+	NotEnrolledInClassError     errors2.ErrorCode = "NOT_ENROLLED_IN_CLASS"
+)
+
+func NewCheckInSessionNotFoundError(sessionID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    CheckInSessionNotFoundError.String(),
+			Message: "Check-in session not found",
+			Context: map[string]any{
+				"session_id": sessionID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(CheckInSessionNotFoundError.String()),
+		},
+	}
+}
+
+func NewNoClassSessionError(classID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    NoClassSessionError.String(),
+			Message: "The class has no session to check in to now",
+			Context: map[string]any{
+				"class_id": classID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(NoClassSessionError.String()),
+		},
+	}
+}
+
+func NewCheckInClosedError(sessionID string, endsAt time.Time) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    CheckInClosedError.String(),
+			Message: "Check-in to the session is closed",
+			Context: map[string]any{
+				"session_id": sessionID,
+				"ends_at":    endsAt,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(CheckInClosedError.String()),
+		},
+	}
+}
+
+func NewInvalidCheckInCodeError(sessionID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    InvalidCheckInCodeError.String(),
+			Message: "The check-in code is wrong or expired, scan the code shown now",
+			Context: map[string]any{
+				"session_id": sessionID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidCheckInCodeError.String()),
+		},
+	}
+}
+
+func NewOutsideCheckInLocationError(sessionID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    OutsideCheckInLocationError.String(),
+			Message: "Students check in to the session from the classroom",
+			Context: map[string]any{
+				"session_id": sessionID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(OutsideCheckInLocationError.String()),
+		},
+	}
+}
+
+func NewNotEnrolledInClassError(classID string, userID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    NotEnrolledInClassError.String(),
+			Message: "The user is not enrolled in the class",
+			Context: map[string]any{
+				"class_id": classID,
+				"user_id":  userID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(NotEnrolledInClassError.String()),
+		},
+	}
+}
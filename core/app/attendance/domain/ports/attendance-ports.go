@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/entities"
+)
+
+type CheckInSessionRepository interface {
+	// Create stores the session unless the class session already has one, the stored one is returned
+	// either way so kiosks opening check-in together show the same codes
+	Create(ctx context.Context, session *entities.CheckInSession) (*entities.CheckInSession, error)
+	// Find returns nil when the session does not exist in the tenant
+	Find(ctx context.Context, tenantID string, sessionID string) (*entities.CheckInSession, error)
+}
+
+// ClassSessions reads the weekly schedule of the classes against the school calendar
+type ClassSessions interface {
+	// SessionsOn returns the sessions of the class on the day of the instant in the zone of the
+	// school, none on non-instructional days
+	SessionsOn(ctx context.Context, tenantID string, classID string, day time.Time, loc *time.Location) ([]entities.ClassSession, error)
+}
+
+// ClassRoster tells who takes a class
+type ClassRoster interface {
+	IsEnrolled(ctx context.Context, tenantID string, classID string, userID string) (bool, error)
+}
+
+type AttendanceRepository interface {
+	// Record stores the attendance with its attendance.recorded event. A student checking in twice
+	// gets the record of their first check-in and no second event.
+	Record(ctx context.Context, record *entities.AttendanceRecord) (*entities.AttendanceRecord, error)
+}
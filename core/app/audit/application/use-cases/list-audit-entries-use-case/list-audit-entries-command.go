@@ -0,0 +1,30 @@
+package list_audit_entries_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListAuditEntriesCommand struct {
+	TenantID      string `validate:"required"`
+	AfterSequence int64  `validate:"gte=0"`
+	Limit         int    `validate:"min=1,max=1000"`
+}
+
+func NewListAuditEntriesCommand(tenantID string, afterSequence int64, limit int) (*ListAuditEntriesCommand, error) {
+	command := &ListAuditEntriesCommand{
+		TenantID:      tenantID,
+		AfterSequence: afterSequence,
+		Limit:         limit,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
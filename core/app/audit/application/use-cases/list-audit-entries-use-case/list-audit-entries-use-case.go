@@ -0,0 +1,29 @@
+package list_audit_entries_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/audit/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/audit/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListAuditEntriesUseCase struct {
+	auditLog ports.AuditLogRepository
+}
+
+func NewListAuditEntriesUseCase(auditLog ports.AuditLogRepository) *ListAuditEntriesUseCase {
+	return &ListAuditEntriesUseCase{
+		auditLog: auditLog,
+	}
+}
+
+// Execute returns the next entries of the chain of the tenant, exports page through it with it
+func (uc *ListAuditEntriesUseCase) Execute(ctx context.Context, cmd *ListAuditEntriesCommand) ([]*entities.AuditEntry, error) {
+	entries, err := uc.auditLog.ListAfter(ctx, cmd.TenantID, cmd.AfterSequence, cmd.Limit)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,26 @@
+package verify_audit_log_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type VerifyAuditLogCommand struct {
+	TenantID string `validate:"required"`
+}
+
+func NewVerifyAuditLogCommand(tenantID string) (*VerifyAuditLogCommand, error) {
+	command := &VerifyAuditLogCommand{
+		TenantID: tenantID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,42 @@
+package verify_audit_log_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/audit/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/audit/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// pageSize is how many entries are read at once while walking the chain
+const pageSize = 1000
+
+type VerifyAuditLogUseCase struct {
+	auditLog ports.AuditLogRepository
+}
+
+func NewVerifyAuditLogUseCase(auditLog ports.AuditLogRepository) *VerifyAuditLogUseCase {
+	return &VerifyAuditLogUseCase{
+		auditLog: auditLog,
+	}
+}
+
+// Execute walks the whole chain of the tenant from its first entry and stops at the first break. The
+// head hash of a whole chain is what exports of the tenant must end with.
+func (uc *VerifyAuditLogUseCase) Execute(ctx context.Context, cmd *VerifyAuditLogCommand) (*entities.ChainVerifier, error) {
+	verifier := entities.NewChainVerifier(cmd.TenantID, 0, entities.GenesisHash)
+	for {
+		entries, err := uc.auditLog.ListAfter(ctx, cmd.TenantID, verifier.LastSequence, pageSize)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		for _, entry := range entries {
+			if !verifier.Add(entry) {
+				return verifier, nil
+			}
+		}
+		if len(entries) < pageSize {
+			return verifier, nil
+		}
+	}
+}
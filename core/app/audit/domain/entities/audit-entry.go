@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GenesisHash is the previous hash of the first entry of every tenant
+var GenesisHash = strings.Repeat("0", 64)
+
+// AuditEntry is an outbox event of a tenant in its audit chain. Hash covers every other field,
+// PrevHash included, so changing, removing or reordering an entry breaks every hash after it.
+type AuditEntry struct {
+	TenantID string
+	// Sequence numbers the entries of a tenant from 1 without gaps
+	Sequence int64
+	// Position is the outbox position of the event
+	Position    int64
+	EventType   string
+	AggregateID string
+	// Payload is the JSON of the event with sorted keys, kept byte for byte as it was hashed
+	Payload    string
+	OccurredAt time.Time
+	PrevHash   string
+	Hash       string
+}
+
+// NewAuditEntry chains an event after prev, the last entry of the tenant or nil for its first one
+func NewAuditEntry(prev *AuditEntry, tenantID string, position int64, eventType string, aggregateID string,
+	payload map[string]any, occurredAt time.Time) (*AuditEntry, error) {
+	// Keys of maps are sorted when marshalled, the payload is the same whatever order it was read in
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &AuditEntry{
+		TenantID:    tenantID,
+		Sequence:    1,
+		Position:    position,
+		EventType:   eventType,
+		AggregateID: aggregateID,
+		Payload:     string(encoded),
+		// Stored with microsecond precision, the hash has to survive the round trip
+		OccurredAt: occurredAt.UTC().Truncate(time.Microsecond),
+		PrevHash:   GenesisHash,
+	}
+	if prev != nil {
+		entry.Sequence = prev.Sequence + 1
+		entry.PrevHash = prev.Hash
+	}
+	entry.Hash = entry.ComputeHash()
+	return entry, nil
+}
+
+// ComputeHash is the SHA-256 of the fields of the entry, every field prefixed with its length so no
+// two entries share an encoding
+func (e *AuditEntry) ComputeHash() string {
+	hash := sha256.New()
+	for _, field := range []string{
+		e.TenantID,
+		fmt.Sprint(e.Sequence),
+		fmt.Sprint(e.Position),
+		e.EventType,
+		e.AggregateID,
+		e.Payload,
+		e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		e.PrevHash,
+	} {
+		fmt.Fprintf(hash, "%d:%s", len(field), field)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ChainBreak is the first entry that does not follow from the ones before it
+type ChainBreak struct {
+	Sequence int64
+	Reason   string
+}
+
+// ChainVerifier checks entries of one tenant in sequence order, possibly in several pages. A verifier
+// started after sequence 0 trusts the hash of the entry it starts after.
+type ChainVerifier struct {
+	TenantID string
+	// Entries is the number of entries checked so far
+	Entries      int64
+	LastSequence int64
+	HeadHash     string
+	Break        *ChainBreak
+}
+
+// NewChainVerifier starts checking after the entry with afterSequence and afterHash, 0 and GenesisHash
+// for a whole chain
+func NewChainVerifier(tenantID string, afterSequence int64, afterHash string) *ChainVerifier {
+	return &ChainVerifier{TenantID: tenantID, LastSequence: afterSequence, HeadHash: afterHash}
+}
+
+// Add checks the next entry and reports whether the chain is still whole, entries after a break are
+// not checked
+func (v *ChainVerifier) Add(entry *AuditEntry) bool {
+	if v.Break != nil {
+		return false
+	}
+
+	switch {
+	case entry.TenantID != v.TenantID:
+		v.Break = &ChainBreak{Sequence: entry.Sequence, Reason: "entry of another tenant"}
+	case entry.Sequence != v.LastSequence+1:
+		v.Break = &ChainBreak{Sequence: v.LastSequence + 1, Reason: fmt.Sprintf("entry missing, next entry is %d", entry.Sequence)}
+	case entry.PrevHash != v.HeadHash:
+		v.Break = &ChainBreak{Sequence: entry.Sequence, Reason: "previous hash does not match the previous entry"}
+	case entry.Hash != entry.ComputeHash():
+		v.Break = &ChainBreak{Sequence: entry.Sequence, Reason: "entry was changed after it was chained"}
+	default:
+		v.Entries++
+		v.LastSequence = entry.Sequence
+		v.HeadHash = entry.Hash
+		return true
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/audit/domain/entities"
+)
+
+// AuditLogRepository stores the audit chain of every tenant, entries are only ever appended
+type AuditLogRepository interface {
+	// ListAfter returns up to limit entries of the tenant after afterSequence in sequence order
+	ListAfter(ctx context.Context, tenantID string, afterSequence int64, limit int) ([]*entities.AuditEntry, error)
+}
@@ -0,0 +1,45 @@
+package batch_signup_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	MaxBatchSize     = 500
+	DefaultChunkSize = 50
+)
+
+var validate = validator.New()
+
+// BatchSignupItem is a single signup request inside a batch, validated individually by the use case
+type BatchSignupItem struct {
+	Name     string
+	Email    string
+	Password string
+}
+
+// BatchSignupCommand only validates the batch envelope, item level validation is reported per item
+type BatchSignupCommand struct {
+	Items     []BatchSignupItem `validate:"required,min=1,max=500"`
+	ChunkSize int               `validate:"min=1,max=500"`
+}
+
+func NewBatchSignupCommand(items []BatchSignupItem, chunkSize int) (*BatchSignupCommand, error) {
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	command := &BatchSignupCommand{
+		Items:     items,
+		ChunkSize: chunkSize,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,122 @@
+package batch_signup_use_case
+
+import (
+	"strings"
+	"time"
+
+	signup_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/signup-use-case"
+	"github.com/nahualventure/class-backend/core/app/shared/bulk"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	userErrors "github.com/nahualventure/class-backend/core/app/user/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+// ItemCreated is the status of the items that were created, the others are bulk.Failed
+const ItemCreated bulk.Status = "created"
+
+type BatchSignupUseCase struct {
+	userRepo ports.UserRepository
+}
+
+func NewBatchSignupUseCase(userRepo ports.UserRepository) *BatchSignupUseCase {
+	return &BatchSignupUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute creates every valid item of the batch. Items are persisted in chunks, each chunk in its own
+// transaction, so a failing chunk only marks its own items as failed. Created items hold their user.
+func (uc *BatchSignupUseCase) Execute(cmd *BatchSignupCommand) (*bulk.Result[*entities.User], error) {
+	results := bulk.NewResult[*entities.User](len(cmd.Items))
+	pending := make([]int, 0, len(cmd.Items))
+	seen := make(map[string]int, len(cmd.Items))
+
+	// Validate each item and detect duplicates inside the batch itself
+	for i, item := range cmd.Items {
+		if _, err := signup_use_case.NewCreateUserCommand(item.Name, item.Email, item.Password); err != nil {
+			results.Fail(i, err)
+			continue
+		}
+
+		normalizedEmail := strings.ToLower(item.Email)
+		if firstIndex, duplicated := seen[normalizedEmail]; duplicated {
+			results.Fail(i, userErrors.NewDuplicateEmailInBatchError(item.Email, firstIndex))
+			continue
+		}
+		seen[normalizedEmail] = i
+		pending = append(pending, i)
+	}
+
+	// Reject emails that already belong to an existing user
+	if len(pending) > 0 {
+		emails := make([]string, len(pending))
+		for i, index := range pending {
+			emails[i] = cmd.Items[index].Email
+		}
+
+		existingEmails, err := uc.userRepo.FindExistingEmails(emails)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+
+		existing := make(map[string]bool, len(existingEmails))
+		for _, email := range existingEmails {
+			existing[strings.ToLower(email)] = true
+		}
+
+		remaining := pending[:0]
+		for _, index := range pending {
+			email := cmd.Items[index].Email
+			if existing[strings.ToLower(email)] {
+				results.Fail(index, userErrors.NewEmailAlreadyExistsError(email))
+				continue
+			}
+			remaining = append(remaining, index)
+		}
+		pending = remaining
+	}
+
+	// Persist remaining items chunk by chunk
+	for start := 0; start < len(pending); start += cmd.ChunkSize {
+		end := min(start+cmd.ChunkSize, len(pending))
+		uc.createChunk(cmd, pending[start:end], results)
+	}
+
+	return results, nil
+}
+
+func (uc *BatchSignupUseCase) createChunk(cmd *BatchSignupCommand, indexes []int, results *bulk.Result[*entities.User]) {
+	credentials := make([]ports.NewUserCredentials, 0, len(indexes))
+	chunkIndexes := make([]int, 0, len(indexes))
+
+	for _, index := range indexes {
+		item := cmd.Items[index]
+		user, err := entities.NewUser(uuid.NewString(), item.Name, item.Email, time.Now(), time.Now())
+		if err != nil {
+			results.Fail(index, errors.PropagateError(err))
+			continue
+		}
+		credentials = append(credentials, ports.NewUserCredentials{User: user, Password: item.Password})
+		chunkIndexes = append(chunkIndexes, index)
+	}
+
+	if len(credentials) == 0 {
+		return
+	}
+
+	createdUsers, err := uc.userRepo.CreateMany(credentials)
+	if err != nil {
+		propagated := errors.PropagateError(err)
+		for _, index := range chunkIndexes {
+			results.Fail(index, propagated)
+		}
+		return
+	}
+
+	for i, index := range chunkIndexes {
+		results.Succeed(index, ItemCreated, createdUsers[i])
+	}
+}
@@ -0,0 +1,39 @@
+package import_users_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const MaxImportSize = 500
+
+var validate = validator.New()
+
+// ImportUserItem is a user of another system with the password hash it stored, validated
+// individually by the use case
+type ImportUserItem struct {
+	Name         string `validate:"required"`
+	Email        string `validate:"required,email"`
+	PasswordHash string `validate:"required,max=255"`
+	// ExternalID is the ID of the user in the former system, users sharing one are flagged as duplicates
+	ExternalID string `validate:"omitempty,max=255"`
+}
+
+// ImportUsersCommand only validates the import envelope, item level validation is reported per item
+type ImportUsersCommand struct {
+	Items []ImportUserItem `validate:"required,min=1,max=500"`
+}
+
+func NewImportUsersCommand(items []ImportUserItem) (*ImportUsersCommand, error) {
+	command := &ImportUsersCommand{
+		Items: items,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,119 @@
+package import_users_use_case
+
+import (
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/bulk"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	userErrors "github.com/nahualventure/class-backend/core/app/user/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+// ItemImported is the status of the items that were imported, the others are bulk.Failed
+const ItemImported bulk.Status = "imported"
+
+type ImportUsersUseCase struct {
+	userRepo ports.UserRepository
+	hasher   ports.PasswordHasher
+}
+
+func NewImportUsersUseCase(userRepo ports.UserRepository, hasher ports.PasswordHasher) *ImportUsersUseCase {
+	return &ImportUsersUseCase{
+		userRepo: userRepo,
+		hasher:   hasher,
+	}
+}
+
+// Execute imports every valid item with its password hash as is, the hash is replaced by one of the
+// current scheme the first time the password is verified. Hashes in a scheme the hasher does not
+// know fail their item. Valid items are persisted in a single transaction.
+func (uc *ImportUsersUseCase) Execute(cmd *ImportUsersCommand) (*bulk.Result[*entities.User], error) {
+	results := bulk.NewResult[*entities.User](len(cmd.Items))
+	pending := make([]int, 0, len(cmd.Items))
+	seen := make(map[string]int, len(cmd.Items))
+
+	// Validate each item and detect duplicates inside the import itself
+	for i, item := range cmd.Items {
+		if err := utils.ValidateStruct(validate, &item); err != nil {
+			results.Fail(i, errors.PropagateError(err))
+			continue
+		}
+		if !uc.hasher.Recognizes(item.PasswordHash) {
+			results.Fail(i, errors.NewValidationError("Unsupported password hash",
+				map[string]any{"password_hash": "is not in a supported scheme"}, nil))
+			continue
+		}
+
+		normalizedEmail := strings.ToLower(item.Email)
+		if firstIndex, duplicated := seen[normalizedEmail]; duplicated {
+			results.Fail(i, userErrors.NewDuplicateEmailInBatchError(item.Email, firstIndex))
+			continue
+		}
+		seen[normalizedEmail] = i
+		pending = append(pending, i)
+	}
+
+	// Reject emails that already belong to an existing user
+	if len(pending) > 0 {
+		emails := make([]string, len(pending))
+		for i, index := range pending {
+			emails[i] = cmd.Items[index].Email
+		}
+
+		existingEmails, err := uc.userRepo.FindExistingEmails(emails)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+
+		existing := make(map[string]bool, len(existingEmails))
+		for _, email := range existingEmails {
+			existing[strings.ToLower(email)] = true
+		}
+
+		remaining := pending[:0]
+		for _, index := range pending {
+			email := cmd.Items[index].Email
+			if existing[strings.ToLower(email)] {
+				results.Fail(index, userErrors.NewEmailAlreadyExistsError(email))
+				continue
+			}
+			remaining = append(remaining, index)
+		}
+		pending = remaining
+	}
+
+	if len(pending) > 0 {
+		if err := uc.importItems(cmd, pending, results); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+func (uc *ImportUsersUseCase) importItems(cmd *ImportUsersCommand, indexes []int, results *bulk.Result[*entities.User]) error {
+	credentials := make([]ports.ImportedUserCredentials, len(indexes))
+	for i, index := range indexes {
+		item := cmd.Items[index]
+		user, err := entities.NewUser(uuid.NewString(), item.Name, item.Email, time.Now(), time.Now())
+		if err != nil {
+			return errors.PropagateError(err)
+		}
+		credentials[i] = ports.ImportedUserCredentials{User: user, PasswordHash: item.PasswordHash, ExternalID: item.ExternalID}
+	}
+
+	importedUsers, err := uc.userRepo.ImportMany(credentials)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+
+	for i, index := range indexes {
+		results.Succeed(index, ItemImported, importedUsers[i])
+	}
+	return nil
+}
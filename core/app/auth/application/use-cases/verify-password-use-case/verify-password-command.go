@@ -0,0 +1,29 @@
+package verify_password_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type VerifyPasswordCommand struct {
+	Email string `validate:"required,email"`
+	// Password is not checked against the signup rules, imported users may have shorter ones
+	Password string `validate:"required,max=128"`
+}
+
+func NewVerifyPasswordCommand(email string, password string) (*VerifyPasswordCommand, error) {
+	command := &VerifyPasswordCommand{
+		Email:    email,
+		Password: password,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,65 @@
+package verify_password_use_case
+
+import (
+	"log"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+)
+
+type VerifyPasswordUseCase struct {
+	userRepo ports.UserRepository
+	hasher   ports.PasswordHasher
+}
+
+func NewVerifyPasswordUseCase(userRepo ports.UserRepository, hasher ports.PasswordHasher) *VerifyPasswordUseCase {
+	return &VerifyPasswordUseCase{
+		userRepo: userRepo,
+		hasher:   hasher,
+	}
+}
+
+// Execute returns the user whose email and password were given. Unknown emails, users that removed
+// their password to sign in through linked identities only and wrong passwords are refused with the
+// same error. A password that matches a legacy or weaker hash is hashed again with the current
+// scheme, failing to store the new hash does not fail the verification, it is retried on the next
+// one.
+func (uc *VerifyPasswordUseCase) Execute(cmd *VerifyPasswordCommand) (*entities.User, error) {
+	user, hash, err := uc.userRepo.FindCredentials(cmd.Email)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if user == nil || hash == "" {
+		// Hashing takes as long as a verification, unknown emails cannot be told apart by the delay
+		if _, err := uc.hasher.Hash(cmd.Password); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		return nil, errors.NewUnauthorizedError("Invalid email or password")
+	}
+
+	matches, needsRehash, err := uc.hasher.Verify(cmd.Password, hash)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !matches {
+		return nil, errors.NewUnauthorizedError("Invalid email or password")
+	}
+
+	if needsRehash {
+		uc.rehash(user, cmd.Password, hash)
+	}
+
+	return user, nil
+}
+
+func (uc *VerifyPasswordUseCase) rehash(user *entities.User, password string, oldHash string) {
+	newHash, err := uc.hasher.Hash(password)
+	if err != nil {
+		log.Printf("failed to rehash the password of user %s: %v", user.ID, err)
+		return
+	}
+	if _, err := uc.userRepo.ReplacePasswordHash(user.ID, oldHash, newHash); err != nil {
+		log.Printf("failed to store the rehashed password of user %s: %v", user.ID, err)
+	}
+}
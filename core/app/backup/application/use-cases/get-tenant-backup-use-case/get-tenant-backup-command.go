@@ -0,0 +1,28 @@
+package get_tenant_backup_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetTenantBackupCommand struct {
+	TenantID string `validate:"required"`
+	BackupID string `validate:"required,uuid"`
+}
+
+func NewGetTenantBackupCommand(tenantID string, backupID string) (*GetTenantBackupCommand, error) {
+	command := &GetTenantBackupCommand{
+		TenantID: tenantID,
+		BackupID: backupID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,32 @@
+package get_tenant_backup_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/backup/domain/entities"
+	backupErrors "github.com/nahualventure/class-backend/core/app/backup/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/backup/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetTenantBackupUseCase struct {
+	backupRepo ports.TenantBackupRepository
+}
+
+func NewGetTenantBackupUseCase(backupRepo ports.TenantBackupRepository) *GetTenantBackupUseCase {
+	return &GetTenantBackupUseCase{
+		backupRepo: backupRepo,
+	}
+}
+
+func (uc *GetTenantBackupUseCase) Execute(ctx context.Context, cmd *GetTenantBackupCommand) (*entities.TenantBackup, error) {
+	backup, err := uc.backupRepo.FindByID(ctx, cmd.TenantID, cmd.BackupID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if backup == nil {
+		return nil, backupErrors.NewTenantBackupNotFoundError(cmd.BackupID)
+	}
+
+	return backup, nil
+}
@@ -0,0 +1,28 @@
+package list_tenant_backups_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListTenantBackupsCommand struct {
+	TenantID string `validate:"required"`
+	Limit    int    `validate:"min=1,max=100"`
+}
+
+func NewListTenantBackupsCommand(tenantID string, limit int) (*ListTenantBackupsCommand, error) {
+	command := &ListTenantBackupsCommand{
+		TenantID: tenantID,
+		Limit:    limit,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
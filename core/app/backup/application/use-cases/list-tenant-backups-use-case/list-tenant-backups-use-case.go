@@ -0,0 +1,28 @@
+package list_tenant_backups_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/backup/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/backup/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListTenantBackupsUseCase struct {
+	backupRepo ports.TenantBackupRepository
+}
+
+func NewListTenantBackupsUseCase(backupRepo ports.TenantBackupRepository) *ListTenantBackupsUseCase {
+	return &ListTenantBackupsUseCase{
+		backupRepo: backupRepo,
+	}
+}
+
+func (uc *ListTenantBackupsUseCase) Execute(ctx context.Context, cmd *ListTenantBackupsCommand) ([]*entities.TenantBackup, error) {
+	backups, err := uc.backupRepo.List(ctx, cmd.TenantID, cmd.Limit)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return backups, nil
+}
@@ -0,0 +1,28 @@
+package request_tenant_backup_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RequestTenantBackupCommand struct {
+	TenantID    string `validate:"required"`
+	RequestedBy string `validate:"required"`
+}
+
+func NewRequestTenantBackupCommand(tenantID string, requestedBy string) (*RequestTenantBackupCommand, error) {
+	command := &RequestTenantBackupCommand{
+		TenantID:    tenantID,
+		RequestedBy: requestedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
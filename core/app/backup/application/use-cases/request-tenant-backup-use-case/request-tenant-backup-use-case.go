@@ -0,0 +1,36 @@
+package request_tenant_backup_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/backup/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/backup/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type RequestTenantBackupUseCase struct {
+	backupRepo ports.TenantBackupRepository
+}
+
+func NewRequestTenantBackupUseCase(backupRepo ports.TenantBackupRepository) *RequestTenantBackupUseCase {
+	return &RequestTenantBackupUseCase{
+		backupRepo: backupRepo,
+	}
+}
+
+// Execute queues the backup, the worker takes and verifies it asynchronously
+func (uc *RequestTenantBackupUseCase) Execute(ctx context.Context, cmd *RequestTenantBackupCommand) (*entities.TenantBackup, error) {
+	backup, err := entities.NewTenantBackup(uuid.New().String(), cmd.TenantID, cmd.RequestedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.backupRepo.Create(ctx, backup); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return backup, nil
+}
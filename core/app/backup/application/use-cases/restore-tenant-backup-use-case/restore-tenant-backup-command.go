@@ -0,0 +1,31 @@
+package restore_tenant_backup_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RestoreTenantBackupCommand struct {
+	TenantID string `validate:"required"`
+	BackupID string `validate:"required,uuid"`
+	// Schema is created for the restored tables, the live tables in public are never written
+	Schema string `validate:"required,max=63,ne=public"`
+}
+
+func NewRestoreTenantBackupCommand(tenantID string, backupID string, schema string) (*RestoreTenantBackupCommand, error) {
+	command := &RestoreTenantBackupCommand{
+		TenantID: tenantID,
+		BackupID: backupID,
+		Schema:   schema,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
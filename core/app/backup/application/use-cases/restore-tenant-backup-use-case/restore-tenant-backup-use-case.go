@@ -0,0 +1,60 @@
+package restore_tenant_backup_use_case
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	backupErrors "github.com/nahualventure/class-backend/core/app/backup/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/backup/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+)
+
+type RestoreTenantBackupUseCase struct {
+	backupRepo ports.TenantBackupRepository
+	restorer   ports.TenantDataRestorer
+	files      storage.FileStorage
+}
+
+func NewRestoreTenantBackupUseCase(backupRepo ports.TenantBackupRepository, restorer ports.TenantDataRestorer,
+	files storage.FileStorage) *RestoreTenantBackupUseCase {
+	return &RestoreTenantBackupUseCase{
+		backupRepo: backupRepo,
+		restorer:   restorer,
+		files:      files,
+	}
+}
+
+// Execute loads a verified backup into a schema of its own and returns the rows restored per table.
+// Operators copy what the school needs back into the live tables from there, so a restore never
+// overwrites the data of other schools or newer changes.
+func (uc *RestoreTenantBackupUseCase) Execute(ctx context.Context, cmd *RestoreTenantBackupCommand) (map[string]int, error) {
+	backup, err := uc.backupRepo.FindByID(ctx, cmd.TenantID, cmd.BackupID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if backup == nil {
+		return nil, backupErrors.NewTenantBackupNotFoundError(cmd.BackupID)
+	}
+	if !backup.CanRestore() {
+		return nil, backupErrors.NewTenantBackupNotVerifiedError(backup.ID, string(backup.Status))
+	}
+
+	dump, err := uc.files.Get(ctx, backup.StorageKey)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	checksum := sha256.Sum256(dump)
+	if dump == nil || hex.EncodeToString(checksum[:]) != backup.Checksum {
+		return nil, errors.NewInfrastructureError(fmt.Sprintf("dump of backup %s is missing or corrupted", backup.ID), nil)
+	}
+
+	restored, err := uc.restorer.RestoreInto(ctx, cmd.Schema, bytes.NewReader(dump))
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return restored, nil
+}
@@ -0,0 +1,106 @@
+package run_tenant_backup_use_case
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/backup/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/backup/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+)
+
+// StaleAfter is how long a backup can stay running before another worker reclaims it
+const StaleAfter = time.Hour
+
+type RunTenantBackupUseCase struct {
+	queue    ports.TenantBackupJobQueue
+	exporter ports.TenantDataExporter
+	restorer ports.TenantDataRestorer
+	files    storage.FileStorage
+}
+
+func NewRunTenantBackupUseCase(queue ports.TenantBackupJobQueue, exporter ports.TenantDataExporter,
+	restorer ports.TenantDataRestorer, files storage.FileStorage) *RunTenantBackupUseCase {
+	return &RunTenantBackupUseCase{
+		queue:    queue,
+		exporter: exporter,
+		restorer: restorer,
+		files:    files,
+	}
+}
+
+// Execute takes the next pending backup: the tenant is dumped, the dump is stored and the stored copy
+// is restored into a scratch schema that is dropped afterwards. The backup is verified only when every
+// row came back. It returns false when the queue is empty. A backup that fails is marked failed and
+// its error is returned for logging.
+func (uc *RunTenantBackupUseCase) Execute(ctx context.Context) (bool, error) {
+	backup, err := uc.queue.ClaimNextPending(ctx, StaleAfter)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+	if backup == nil {
+		return false, nil
+	}
+
+	if err := uc.run(ctx, backup); err != nil {
+		if markErr := uc.queue.MarkFailed(ctx, backup.ID, err.Error()); markErr != nil {
+			return true, errors.PropagateError(markErr)
+		}
+		return true, errors.NewInfrastructureError(fmt.Sprintf("failed to back up tenant %s in backup %s", backup.TenantID, backup.ID), err)
+	}
+
+	if err := uc.queue.MarkVerified(ctx, backup); err != nil {
+		return true, errors.PropagateError(err)
+	}
+	return true, nil
+}
+
+func (uc *RunTenantBackupUseCase) run(ctx context.Context, backup *entities.TenantBackup) error {
+	var dump bytes.Buffer
+	tables, err := uc.exporter.Export(ctx, backup.TenantID, &dump)
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	checksum := sha256.Sum256(dump.Bytes())
+
+	key := entities.StorageKeyFor(backup.TenantID, backup.ID)
+	if err := uc.files.Put(ctx, key, dump.Bytes()); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	stored, err := uc.files.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("read back: %w", err)
+	}
+	if sha256.Sum256(stored) != checksum {
+		return fmt.Errorf("read back: the stored dump differs from the exported one")
+	}
+
+	backup.StorageKey = key
+	backup.Tables = tables
+	backup.SizeBytes = int64(len(stored))
+	backup.Checksum = hex.EncodeToString(checksum[:])
+
+	schema := ScratchSchema(backup.ID)
+	restored, err := uc.restorer.RestoreInto(ctx, schema, bytes.NewReader(stored))
+	if dropErr := uc.restorer.DropSchema(ctx, schema); dropErr != nil && err == nil {
+		err = dropErr
+	}
+	if err != nil {
+		return fmt.Errorf("restore check: %w", err)
+	}
+	if mismatched := backup.MismatchedTables(restored); len(mismatched) > 0 {
+		return fmt.Errorf("restore check: rows differ in %s", strings.Join(mismatched, ", "))
+	}
+	return nil
+}
+
+// ScratchSchema is where the restore check of a backup loads its dump
+func ScratchSchema(backupID string) string {
+	return "backup_check_" + strings.ReplaceAll(backupID, "-", "")
+}
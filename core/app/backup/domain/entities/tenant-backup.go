@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"fmt"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type TenantBackupStatus string
+
+const (
+	TenantBackupStatusPending TenantBackupStatus = "pending"
+	TenantBackupStatusRunning TenantBackupStatus = "running"
+	// TenantBackupStatusVerified backups were restored into a scratch schema with every row
+	TenantBackupStatusVerified TenantBackupStatus = "verified"
+	// TenantBackupStatusFailed backups could not be taken, stored or restored, FailureReason says which
+	TenantBackupStatusFailed TenantBackupStatus = "failed"
+)
+
+// TenantBackup is a logical backup of the rows of one tenant in every tenant table, taken from one
+// snapshot. The dump is kept in the backup storage under StorageKey, this is its metadata.
+type TenantBackup struct {
+	ID          string             `validate:"required,uuid"`
+	TenantID    string             `validate:"required"`
+	Status      TenantBackupStatus `validate:"required,oneof=pending running verified failed"`
+	RequestedBy string             `validate:"required"`
+	StorageKey  string
+	// Tables counts the rows of every table in the dump
+	Tables        map[string]int
+	SizeBytes     int64
+	Checksum      string
+	FailureReason string
+	CreatedAt     time.Time `validate:"required"`
+	UpdatedAt     time.Time `validate:"required"`
+	CompletedAt   *time.Time
+}
+
+func NewTenantBackup(id string, tenantID string, requestedBy string, now time.Time) (*TenantBackup, error) {
+	backup := &TenantBackup{
+		ID:          id,
+		TenantID:    tenantID,
+		Status:      TenantBackupStatusPending,
+		RequestedBy: requestedBy,
+		Tables:      map[string]int{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := validate.Struct(backup); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("TenantBackup domain model instance not valid", errorMap, err)
+	}
+
+	return backup, nil
+}
+
+// StorageKeyFor is where the dump of a backup is stored
+func StorageKeyFor(tenantID string, backupID string) string {
+	return fmt.Sprintf("backups/%s/%s.jsonl.gz", tenantID, backupID)
+}
+
+// RowCount is the number of rows in the dump
+func (b *TenantBackup) RowCount() int {
+	total := 0
+	for _, rows := range b.Tables {
+		total += rows
+	}
+	return total
+}
+
+// CanRestore reports whether the dump was verified, only verified backups are restored
+func (b *TenantBackup) CanRestore() bool {
+	return b.Status == TenantBackupStatusVerified
+}
+
+// MismatchedTables compares the rows restored per table with the dump and returns the tables where
+// they differ, sorted
+func (b *TenantBackup) MismatchedTables(restored map[string]int) []string {
+	var mismatched []string
+	for table, rows := range b.Tables {
+		if restored[table] != rows {
+			mismatched = append(mismatched, fmt.Sprintf("%s (%d of %d rows)", table, restored[table], rows))
+		}
+	}
+	for table, rows := range restored {
+		if _, dumped := b.Tables[table]; !dumped {
+			mismatched = append(mismatched, fmt.Sprintf("%s (%d rows not in the dump)", table, rows))
+		}
+	}
+	sort.Strings(mismatched)
+	return mismatched
+}
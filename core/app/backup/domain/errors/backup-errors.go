@@ -0,0 +1,42 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	TenantBackupNotFoundError    errors2.ErrorCode = "TENANT_BACKUP_NOT_FOUND"
+	TenantBackupNotVerifiedError errors2.ErrorCode = "TENANT_BACKUP_NOT_VERIFIED"
+)
+
+func NewTenantBackupNotFoundError(backupID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TenantBackupNotFoundError.String(),
+			Message: "Tenant backup not found",
+			Context: map[string]any{
+				"backup_id": backupID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TenantBackupNotFoundError.String()),
+		},
+	}
+}
+
+func NewTenantBackupNotVerifiedError(backupID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TenantBackupNotVerifiedError.String(),
+			Message: "Only backups verified by a restore check can be restored",
+			Context: map[string]any{
+				"backup_id": backupID,
+				"status":    status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TenantBackupNotVerifiedError.String()),
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/backup/domain/entities"
+)
+
+type TenantBackupRepository interface {
+	Create(ctx context.Context, backup *entities.TenantBackup) error
+	// FindByID returns nil when the tenant has no such backup
+	FindByID(ctx context.Context, tenantID string, backupID string) (*entities.TenantBackup, error)
+	// List returns the backups of the tenant newest first
+	List(ctx context.Context, tenantID string, limit int) ([]*entities.TenantBackup, error)
+	TenantBackupJobQueue
+}
+
+// TenantBackupJobQueue is the worker side of the repository. ClaimNextPending also reclaims backups
+// left running by a worker that died more than staleAfter ago; it returns nil when there is nothing to do.
+type TenantBackupJobQueue interface {
+	ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.TenantBackup, error)
+	// MarkVerified saves the storage key, tables, size and checksum of the backup
+	MarkVerified(ctx context.Context, backup *entities.TenantBackup) error
+	MarkFailed(ctx context.Context, backupID string, reason string) error
+}
+
+// TenantDataExporter writes a logical dump of the rows of a tenant in every tenant table
+type TenantDataExporter interface {
+	// Export writes the dump to w from one snapshot and returns the rows written per table
+	Export(ctx context.Context, tenantID string, w io.Writer) (map[string]int, error)
+}
+
+// TenantDataRestorer loads dumps written by the exporter into a schema apart from the live tables
+type TenantDataRestorer interface {
+	// RestoreInto creates schema with the tables of the dump and loads its rows, in one transaction.
+	// It returns the rows restored per table and fails when schema exists.
+	RestoreInto(ctx context.Context, schema string, dump io.Reader) (map[string]int, error)
+	DropSchema(ctx context.Context, schema string) error
+}
@@ -0,0 +1,30 @@
+package acknowledge_incident_notification_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type AcknowledgeIncidentNotificationCommand struct {
+	TenantID       string `validate:"required"`
+	NotificationID string `validate:"required,uuid"`
+	GuardianID     string `validate:"required"`
+}
+
+func NewAcknowledgeIncidentNotificationCommand(tenantID string, notificationID string, guardianID string) (*AcknowledgeIncidentNotificationCommand, error) {
+	command := &AcknowledgeIncidentNotificationCommand{
+		TenantID:       tenantID,
+		NotificationID: notificationID,
+		GuardianID:     guardianID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,42 @@
+package acknowledge_incident_notification_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	behaviorErrors "github.com/nahualventure/class-backend/core/app/behavior/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type AcknowledgeIncidentNotificationUseCase struct {
+	notificationRepo ports.GuardianNotificationRepository
+}
+
+func NewAcknowledgeIncidentNotificationUseCase(notificationRepo ports.GuardianNotificationRepository) *AcknowledgeIncidentNotificationUseCase {
+	return &AcknowledgeIncidentNotificationUseCase{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Execute records that the guardian read the notification, acknowledging twice keeps the first time
+func (uc *AcknowledgeIncidentNotificationUseCase) Execute(ctx context.Context, cmd *AcknowledgeIncidentNotificationCommand) (*entities.GuardianNotification, error) {
+	notification, err := uc.notificationRepo.FindByID(ctx, cmd.TenantID, cmd.NotificationID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if notification == nil || notification.GuardianID != cmd.GuardianID {
+		return nil, behaviorErrors.NewGuardianNotificationNotFoundError(cmd.NotificationID)
+	}
+	if notification.IsAcknowledged() {
+		return notification, nil
+	}
+
+	notification.Acknowledge(time.Now().UTC())
+	if err := uc.notificationRepo.Acknowledge(ctx, notification); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return notification, nil
+}
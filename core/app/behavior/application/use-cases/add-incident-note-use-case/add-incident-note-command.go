@@ -0,0 +1,34 @@
+package add_incident_note_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type AddIncidentNoteCommand struct {
+	TenantID   string `validate:"required"`
+	IncidentID string `validate:"required,uuid"`
+	AuthorID   string `validate:"required"`
+	Body       string `validate:"required,max=5000"`
+	Restricted bool
+}
+
+func NewAddIncidentNoteCommand(tenantID string, incidentID string, authorID string, body string, restricted bool) (*AddIncidentNoteCommand, error) {
+	command := &AddIncidentNoteCommand{
+		TenantID:   tenantID,
+		IncidentID: incidentID,
+		AuthorID:   authorID,
+		Body:       body,
+		Restricted: restricted,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
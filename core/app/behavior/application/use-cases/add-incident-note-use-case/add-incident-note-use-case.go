@@ -0,0 +1,56 @@
+package add_incident_note_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	behaviorErrors "github.com/nahualventure/class-backend/core/app/behavior/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type AddIncidentNoteUseCase struct {
+	incidentRepo ports.IncidentRepository
+	noteRepo     ports.IncidentNoteRepository
+	viewers      ports.ViewerDirectory
+}
+
+func NewAddIncidentNoteUseCase(incidentRepo ports.IncidentRepository, noteRepo ports.IncidentNoteRepository,
+	viewers ports.ViewerDirectory) *AddIncidentNoteUseCase {
+	return &AddIncidentNoteUseCase{
+		incidentRepo: incidentRepo,
+		noteRepo:     noteRepo,
+		viewers:      viewers,
+	}
+}
+
+// Execute adds a staff note. Any staff member may write a restricted note, reading it back is what
+// takes the restricted permission.
+func (uc *AddIncidentNoteUseCase) Execute(ctx context.Context, cmd *AddIncidentNoteCommand) (*entities.IncidentNote, error) {
+	viewer, err := uc.viewers.Viewer(ctx, cmd.TenantID, cmd.AuthorID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	incident, err := uc.incidentRepo.FindByID(ctx, cmd.TenantID, cmd.IncidentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if viewer == nil || !viewer.IsStaff() || incident == nil {
+		return nil, behaviorErrors.NewIncidentNotFoundError(cmd.IncidentID)
+	}
+
+	note, err := entities.NewIncidentNote(uuid.New().String(), cmd.TenantID, incident.ID, cmd.AuthorID, cmd.Body, cmd.Restricted,
+		time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.noteRepo.Create(ctx, note); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return note, nil
+}
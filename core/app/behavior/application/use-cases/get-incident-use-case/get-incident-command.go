@@ -0,0 +1,30 @@
+package get_incident_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetIncidentCommand struct {
+	TenantID   string `validate:"required"`
+	IncidentID string `validate:"required,uuid"`
+	UserID     string `validate:"required"`
+}
+
+func NewGetIncidentCommand(tenantID string, incidentID string, userID string) (*GetIncidentCommand, error) {
+	command := &GetIncidentCommand{
+		TenantID:   tenantID,
+		IncidentID: incidentID,
+		UserID:     userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,73 @@
+package get_incident_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	behaviorErrors "github.com/nahualventure/class-backend/core/app/behavior/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetIncidentUseCase struct {
+	incidentRepo     ports.IncidentRepository
+	noteRepo         ports.IncidentNoteRepository
+	notificationRepo ports.GuardianNotificationRepository
+	viewers          ports.ViewerDirectory
+}
+
+func NewGetIncidentUseCase(incidentRepo ports.IncidentRepository, noteRepo ports.IncidentNoteRepository,
+	notificationRepo ports.GuardianNotificationRepository, viewers ports.ViewerDirectory) *GetIncidentUseCase {
+	return &GetIncidentUseCase{
+		incidentRepo:     incidentRepo,
+		noteRepo:         noteRepo,
+		notificationRepo: notificationRepo,
+		viewers:          viewers,
+	}
+}
+
+// Execute returns the incident with the notes the user may read. Guardians get no notes and only
+// their own notifications.
+func (uc *GetIncidentUseCase) Execute(ctx context.Context, cmd *GetIncidentCommand) (*entities.IncidentDetails, error) {
+	viewer, err := uc.viewers.Viewer(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	incident, err := uc.incidentRepo.FindByID(ctx, cmd.TenantID, cmd.IncidentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if viewer == nil || incident == nil || !viewer.CanSee(incident) {
+		return nil, behaviorErrors.NewIncidentNotFoundError(cmd.IncidentID)
+	}
+
+	details := &entities.IncidentDetails{
+		Incident:      incident.ViewFor(*viewer),
+		Notes:         []*entities.IncidentNote{},
+		Notifications: []*entities.GuardianNotification{},
+	}
+
+	if viewer.IsStaff() {
+		notes, err := uc.noteRepo.ListByIncident(ctx, cmd.TenantID, incident.ID)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		for _, note := range notes {
+			if viewer.CanReadNote(note) {
+				details.Notes = append(details.Notes, note)
+			}
+		}
+	}
+
+	notifications, err := uc.notificationRepo.ListByIncident(ctx, cmd.TenantID, incident.ID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	for _, notification := range notifications {
+		if viewer.IsStaff() || notification.GuardianID == viewer.UserID {
+			details.Notifications = append(details.Notifications, notification)
+		}
+	}
+
+	return details, nil
+}
@@ -0,0 +1,43 @@
+package list_incidents_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const DefaultLimit = 50
+
+var validate = validator.New()
+
+type ListIncidentsCommand struct {
+	TenantID  string                  `validate:"required"`
+	UserID    string                  `validate:"required"`
+	StudentID string                  `validate:"omitempty"`
+	Status    entities.IncidentStatus `validate:"omitempty,oneof=open resolved"`
+	Limit     int                     `validate:"min=1,max=100"`
+}
+
+// NewListIncidentsCommand lists DefaultLimit incidents when limit is zero, studentID and status are
+// optional filters
+func NewListIncidentsCommand(tenantID string, userID string, studentID string, status string, limit int) (*ListIncidentsCommand, error) {
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	command := &ListIncidentsCommand{
+		TenantID:  tenantID,
+		UserID:    userID,
+		StudentID: studentID,
+		Status:    entities.IncidentStatus(status),
+		Limit:     limit,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
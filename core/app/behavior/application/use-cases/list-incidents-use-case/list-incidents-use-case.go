@@ -0,0 +1,60 @@
+package list_incidents_use_case
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListIncidentsUseCase struct {
+	incidentRepo ports.IncidentRepository
+	viewers      ports.ViewerDirectory
+}
+
+func NewListIncidentsUseCase(incidentRepo ports.IncidentRepository, viewers ports.ViewerDirectory) *ListIncidentsUseCase {
+	return &ListIncidentsUseCase{
+		incidentRepo: incidentRepo,
+		viewers:      viewers,
+	}
+}
+
+// Execute lists the incidents visible to the user, guardians only get those of their linked students
+func (uc *ListIncidentsUseCase) Execute(ctx context.Context, cmd *ListIncidentsCommand) ([]*entities.Incident, error) {
+	viewer, err := uc.viewers.Viewer(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if viewer == nil {
+		return []*entities.Incident{}, nil
+	}
+
+	filter := ports.IncidentFilter{Status: cmd.Status, Limit: cmd.Limit}
+	switch {
+	case !viewer.IsStaff() && cmd.StudentID != "":
+		if !slices.Contains(viewer.StudentIDs, cmd.StudentID) {
+			return []*entities.Incident{}, nil
+		}
+		filter.StudentIDs = []string{cmd.StudentID}
+	case !viewer.IsStaff():
+		if len(viewer.StudentIDs) == 0 {
+			return []*entities.Incident{}, nil
+		}
+		filter.StudentIDs = viewer.StudentIDs
+	case cmd.StudentID != "":
+		filter.StudentIDs = []string{cmd.StudentID}
+	}
+
+	incidents, err := uc.incidentRepo.List(ctx, cmd.TenantID, filter)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	views := make([]*entities.Incident, 0, len(incidents))
+	for _, incident := range incidents {
+		views = append(views, incident.ViewFor(*viewer))
+	}
+	return views, nil
+}
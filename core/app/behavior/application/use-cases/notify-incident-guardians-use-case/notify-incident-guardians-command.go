@@ -0,0 +1,30 @@
+package notify_incident_guardians_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type NotifyIncidentGuardiansCommand struct {
+	TenantID   string `validate:"required"`
+	IncidentID string `validate:"required,uuid"`
+	UserID     string `validate:"required"`
+}
+
+func NewNotifyIncidentGuardiansCommand(tenantID string, incidentID string, userID string) (*NotifyIncidentGuardiansCommand, error) {
+	command := &NotifyIncidentGuardiansCommand{
+		TenantID:   tenantID,
+		IncidentID: incidentID,
+		UserID:     userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,63 @@
+package notify_incident_guardians_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	behaviorErrors "github.com/nahualventure/class-backend/core/app/behavior/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type NotifyIncidentGuardiansUseCase struct {
+	incidentRepo     ports.IncidentRepository
+	notificationRepo ports.GuardianNotificationRepository
+	viewers          ports.ViewerDirectory
+}
+
+func NewNotifyIncidentGuardiansUseCase(incidentRepo ports.IncidentRepository, notificationRepo ports.GuardianNotificationRepository,
+	viewers ports.ViewerDirectory) *NotifyIncidentGuardiansUseCase {
+	return &NotifyIncidentGuardiansUseCase{
+		incidentRepo:     incidentRepo,
+		notificationRepo: notificationRepo,
+		viewers:          viewers,
+	}
+}
+
+// Execute notifies the guardians of every student involved who were not notified yet, so it can be
+// repeated after new guardians are linked. Returns only the notifications queued by this call.
+func (uc *NotifyIncidentGuardiansUseCase) Execute(ctx context.Context, cmd *NotifyIncidentGuardiansCommand) ([]*entities.GuardianNotification, error) {
+	viewer, err := uc.viewers.Viewer(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	incident, err := uc.incidentRepo.FindByID(ctx, cmd.TenantID, cmd.IncidentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if viewer == nil || !viewer.IsStaff() || incident == nil {
+		return nil, behaviorErrors.NewIncidentNotFoundError(cmd.IncidentID)
+	}
+
+	links, err := uc.viewers.GuardiansOf(ctx, cmd.TenantID, incident.StudentIDs)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	notifications, err := incident.GuardianNotifications(links, cmd.UserID, time.Now().UTC(), uuid.NewString)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if len(notifications) == 0 {
+		return notifications, nil
+	}
+
+	queued, err := uc.notificationRepo.Queue(ctx, notifications)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return queued, nil
+}
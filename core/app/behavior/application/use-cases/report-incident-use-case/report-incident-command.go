@@ -0,0 +1,50 @@
+package report_incident_use_case
+
+import (
+	"slices"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ReportIncidentCommand struct {
+	TenantID   string                `validate:"required"`
+	ReporterID string                `validate:"required"`
+	Type       entities.IncidentType `validate:"required,oneof=disruption bullying fighting dishonesty attendance property_damage other"`
+	Severity   entities.Severity     `validate:"required,oneof=low medium high critical"`
+	Summary    string                `validate:"required,max=2000"`
+	Location   string                `validate:"max=255"`
+	StudentIDs []string              `validate:"required,min=1,max=30,dive,required"`
+	OccurredAt time.Time             `validate:"required"`
+}
+
+// NewReportIncidentCommand drops repeated students, the same student can only be involved once
+func NewReportIncidentCommand(tenantID string, reporterID string, incidentType string, severity string, summary string,
+	location string, studentIDs []string, occurredAt time.Time) (*ReportIncidentCommand, error) {
+	command := &ReportIncidentCommand{
+		TenantID:   tenantID,
+		ReporterID: reporterID,
+		Type:       entities.IncidentType(incidentType),
+		Severity:   entities.Severity(severity),
+		Summary:    summary,
+		Location:   location,
+		StudentIDs: slices.Compact(slices.Sorted(slices.Values(studentIDs))),
+		OccurredAt: occurredAt.UTC(),
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if command.OccurredAt.After(time.Now()) {
+		return nil, errors.NewValidationError("Invalid incident",
+			map[string]any{"occurred_at": "incidents cannot be reported ahead of time"}, nil)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,54 @@
+package report_incident_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type ReportIncidentUseCase struct {
+	incidentRepo ports.IncidentRepository
+	viewers      ports.ViewerDirectory
+}
+
+func NewReportIncidentUseCase(incidentRepo ports.IncidentRepository, viewers ports.ViewerDirectory) *ReportIncidentUseCase {
+	return &ReportIncidentUseCase{
+		incidentRepo: incidentRepo,
+		viewers:      viewers,
+	}
+}
+
+// Execute records the incident, guardians of the students involved are notified right away when
+// the severity calls for it
+func (uc *ReportIncidentUseCase) Execute(ctx context.Context, cmd *ReportIncidentCommand) (*entities.IncidentDetails, error) {
+	now := time.Now().UTC()
+
+	incident, err := entities.NewIncident(uuid.New().String(), cmd.TenantID, cmd.Type, cmd.Severity, cmd.Summary, cmd.Location,
+		cmd.StudentIDs, cmd.ReporterID, entities.IncidentStatusOpen, "", "", nil, cmd.OccurredAt, now)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	notifications := []*entities.GuardianNotification{}
+	if incident.Severity.NotifiesGuardians() {
+		links, err := uc.viewers.GuardiansOf(ctx, cmd.TenantID, incident.StudentIDs)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		notifications, err = incident.GuardianNotifications(links, cmd.ReporterID, now, uuid.NewString)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	}
+
+	if err := uc.incidentRepo.Create(ctx, incident, notifications); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return &entities.IncidentDetails{Incident: incident, Notes: []*entities.IncidentNote{}, Notifications: notifications}, nil
+}
@@ -0,0 +1,32 @@
+package resolve_incident_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ResolveIncidentCommand struct {
+	TenantID   string `validate:"required"`
+	IncidentID string `validate:"required,uuid"`
+	UserID     string `validate:"required"`
+	Resolution string `validate:"required,max=2000"`
+}
+
+func NewResolveIncidentCommand(tenantID string, incidentID string, userID string, resolution string) (*ResolveIncidentCommand, error) {
+	command := &ResolveIncidentCommand{
+		TenantID:   tenantID,
+		IncidentID: incidentID,
+		UserID:     userID,
+		Resolution: resolution,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
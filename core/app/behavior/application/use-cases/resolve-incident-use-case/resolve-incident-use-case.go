@@ -0,0 +1,42 @@
+package resolve_incident_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	behaviorErrors "github.com/nahualventure/class-backend/core/app/behavior/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ResolveIncidentUseCase struct {
+	incidentRepo ports.IncidentRepository
+}
+
+func NewResolveIncidentUseCase(incidentRepo ports.IncidentRepository) *ResolveIncidentUseCase {
+	return &ResolveIncidentUseCase{
+		incidentRepo: incidentRepo,
+	}
+}
+
+// Execute closes the incident with the outcome, the resolution is shared with guardians like the summary
+func (uc *ResolveIncidentUseCase) Execute(ctx context.Context, cmd *ResolveIncidentCommand) (*entities.Incident, error) {
+	incident, err := uc.incidentRepo.FindByID(ctx, cmd.TenantID, cmd.IncidentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if incident == nil {
+		return nil, behaviorErrors.NewIncidentNotFoundError(cmd.IncidentID)
+	}
+	if incident.IsResolved() {
+		return nil, behaviorErrors.NewIncidentAlreadyResolvedError(incident.ID)
+	}
+
+	incident.Resolve(cmd.Resolution, cmd.UserID, time.Now().UTC())
+	if err := uc.incidentRepo.Resolve(ctx, incident); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return incident, nil
+}
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+type GuardianLink struct {
+	GuardianID string
+	StudentID  string
+}
+
+// GuardianNotification tells one guardian about an incident of one of their students. It is
+// queued to the outbox for delivery and stays pending until the guardian acknowledges it.
+type GuardianNotification struct {
+	ID             string    `validate:"required,uuid4"`
+	TenantID       string    `validate:"required"`
+	IncidentID     string    `validate:"required,uuid"`
+	StudentID      string    `validate:"required"`
+	GuardianID     string    `validate:"required"`
+	QueuedBy       string    `validate:"required"`
+	QueuedAt       time.Time `validate:"required"`
+	AcknowledgedAt *time.Time
+}
+
+func NewGuardianNotification(id string, tenantID string, incidentID string, studentID string, guardianID string, queuedBy string,
+	queuedAt time.Time, acknowledgedAt *time.Time) (*GuardianNotification, error) {
+	notification := &GuardianNotification{
+		ID:             id,
+		TenantID:       tenantID,
+		IncidentID:     incidentID,
+		StudentID:      studentID,
+		GuardianID:     guardianID,
+		QueuedBy:       queuedBy,
+		QueuedAt:       queuedAt,
+		AcknowledgedAt: acknowledgedAt,
+	}
+
+	if err := validate.Struct(notification); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("GuardianNotification domain model instance not valid", errorMap, err)
+	}
+
+	return notification, nil
+}
+
+func (n *GuardianNotification) IsAcknowledged() bool {
+	return n.AcknowledgedAt != nil
+}
+
+func (n *GuardianNotification) Acknowledge(now time.Time) {
+	n.AcknowledgedAt = &now
+}
+
+// GuardianNotifications builds one notification per link of a student involved in the incident,
+// newID is called once per notification
+func (i *Incident) GuardianNotifications(links []GuardianLink, queuedBy string, now time.Time,
+	newID func() string) ([]*GuardianNotification, error) {
+	notifications := make([]*GuardianNotification, 0, len(links))
+	for _, link := range links {
+		if !i.Involves(link.StudentID) {
+			continue
+		}
+		notification, err := NewGuardianNotification(newID(), i.TenantID, i.ID, link.StudentID, link.GuardianID, queuedBy, now, nil)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
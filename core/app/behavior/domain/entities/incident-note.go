@@ -0,0 +1,44 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// IncidentNote is a staff note on an incident. Restricted notes hold sensitive details (health,
+// family, safeguarding) and are only readable by their author and staff allowed to read them.
+type IncidentNote struct {
+	ID         string    `validate:"required,uuid4"`
+	TenantID   string    `validate:"required"`
+	IncidentID string    `validate:"required,uuid"`
+	AuthorID   string    `validate:"required"`
+	Body       string    `validate:"required,max=5000"`
+	Restricted bool      `validate:"-"`
+	CreatedAt  time.Time `validate:"required"`
+}
+
+func NewIncidentNote(id string, tenantID string, incidentID string, authorID string, body string, restricted bool,
+	createdAt time.Time) (*IncidentNote, error) {
+	note := &IncidentNote{
+		ID:         id,
+		TenantID:   tenantID,
+		IncidentID: incidentID,
+		AuthorID:   authorID,
+		Body:       body,
+		Restricted: restricted,
+		CreatedAt:  createdAt,
+	}
+
+	if err := validate.Struct(note); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("IncidentNote domain model instance not valid", errorMap, err)
+	}
+
+	return note, nil
+}
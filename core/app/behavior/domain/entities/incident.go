@@ -0,0 +1,137 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+const MaxStudentsPerIncident = 30
+
+type IncidentType string
+
+const (
+	IncidentTypeDisruption     IncidentType = "disruption"
+	IncidentTypeBullying       IncidentType = "bullying"
+	IncidentTypeFighting       IncidentType = "fighting"
+	IncidentTypeDishonesty     IncidentType = "dishonesty"
+	IncidentTypeAttendance     IncidentType = "attendance"
+	IncidentTypePropertyDamage IncidentType = "property_damage"
+	IncidentTypeOther          IncidentType = "other"
+)
+
+type Severity string
+
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// NotifiesGuardians reports whether guardians are notified as soon as the incident is reported,
+// lower severities are notified when staff decide to
+func (s Severity) NotifiesGuardians() bool {
+	return s == SeverityHigh || s == SeverityCritical
+}
+
+type IncidentStatus string
+
+const (
+	IncidentStatusOpen     IncidentStatus = "open"
+	IncidentStatusResolved IncidentStatus = "resolved"
+)
+
+// Incident is a behavior or discipline incident involving one or more students. The summary is
+// shared with guardians, anything sensitive belongs in restricted notes.
+type Incident struct {
+	ID         string         `validate:"required,uuid4"`
+	TenantID   string         `validate:"required"`
+	Type       IncidentType   `validate:"required,oneof=disruption bullying fighting dishonesty attendance property_damage other"`
+	Severity   Severity       `validate:"required,oneof=low medium high critical"`
+	Summary    string         `validate:"required,max=2000"`
+	Location   string         `validate:"max=255"`
+	StudentIDs []string       `validate:"required,min=1,max=30,unique,dive,required"`
+	ReportedBy string         `validate:"required"`
+	Status     IncidentStatus `validate:"required,oneof=open resolved"`
+	Resolution string         `validate:"max=2000"`
+	ResolvedBy string
+	ResolvedAt *time.Time
+	OccurredAt time.Time `validate:"required"`
+	CreatedAt  time.Time `validate:"required"`
+}
+
+func NewIncident(id string, tenantID string, incidentType IncidentType, severity Severity, summary string, location string,
+	studentIDs []string, reportedBy string, status IncidentStatus, resolution string, resolvedBy string, resolvedAt *time.Time,
+	occurredAt time.Time, createdAt time.Time) (*Incident, error) {
+	incident := &Incident{
+		ID:         id,
+		TenantID:   tenantID,
+		Type:       incidentType,
+		Severity:   severity,
+		Summary:    summary,
+		Location:   location,
+		StudentIDs: studentIDs,
+		ReportedBy: reportedBy,
+		Status:     status,
+		Resolution: resolution,
+		ResolvedBy: resolvedBy,
+		ResolvedAt: resolvedAt,
+		OccurredAt: occurredAt,
+		CreatedAt:  createdAt,
+	}
+
+	if err := validate.Struct(incident); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("Incident domain model instance not valid", errorMap, err)
+	}
+
+	return incident, nil
+}
+
+func (i *Incident) IsResolved() bool {
+	return i.Status == IncidentStatusResolved
+}
+
+func (i *Incident) Involves(studentID string) bool {
+	return slices.Contains(i.StudentIDs, studentID)
+}
+
+func (i *Incident) Resolve(resolution string, resolvedBy string, now time.Time) {
+	i.Status = IncidentStatusResolved
+	i.Resolution = resolution
+	i.ResolvedBy = resolvedBy
+	i.ResolvedAt = &now
+}
+
+// ViewFor returns the incident as the viewer may see it. Guardians only see the students they are
+// linked to, the other students involved stay private.
+func (i *Incident) ViewFor(viewer Viewer) *Incident {
+	if viewer.IsStaff() {
+		return i
+	}
+
+	view := *i
+	view.StudentIDs = make([]string, 0, len(i.StudentIDs))
+	for _, studentID := range i.StudentIDs {
+		if slices.Contains(viewer.StudentIDs, studentID) {
+			view.StudentIDs = append(view.StudentIDs, studentID)
+		}
+	}
+	return &view
+}
+
+// IncidentDetails is an incident with the notes and guardian notifications the viewer may see
+type IncidentDetails struct {
+	Incident      *Incident
+	Notes         []*IncidentNote
+	Notifications []*GuardianNotification
+}
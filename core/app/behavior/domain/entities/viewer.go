@@ -0,0 +1,41 @@
+package entities
+
+import "slices"
+
+type ViewerRole string
+
+const (
+	ViewerRoleStaff    ViewerRole = "staff"
+	ViewerRoleGuardian ViewerRole = "guardian"
+)
+
+// Viewer is who is reading incidents. StudentIDs are the students a guardian is linked to,
+// CanReadRestricted is only ever set for staff.
+type Viewer struct {
+	UserID            string
+	Role              ViewerRole
+	CanReadRestricted bool
+	StudentIDs        []string
+}
+
+func (v Viewer) IsStaff() bool {
+	return v.Role == ViewerRoleStaff
+}
+
+// CanSee reports whether the incident is visible at all, guardians only see incidents of their
+// linked students
+func (v Viewer) CanSee(incident *Incident) bool {
+	if v.IsStaff() {
+		return true
+	}
+	return slices.ContainsFunc(v.StudentIDs, incident.Involves)
+}
+
+// CanReadNote keeps notes away from guardians and restricted notes away from staff without the
+// restricted permission, authors can always read their own notes
+func (v Viewer) CanReadNote(note *IncidentNote) bool {
+	if !v.IsStaff() {
+		return false
+	}
+	return !note.Restricted || v.CanReadRestricted || note.AuthorID == v.UserID
+}
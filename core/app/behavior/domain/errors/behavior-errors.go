@@ -0,0 +1,58 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	IncidentNotFoundError             errors2.ErrorCode = "INCIDENT_NOT_FOUND"
+	IncidentAlreadyResolvedError      errors2.ErrorCode = "INCIDENT_ALREADY_RESOLVED"
+	GuardianNotificationNotFoundError errors2.ErrorCode = "GUARDIAN_NOTIFICATION_NOT_FOUND"
+)
+
+// NewIncidentNotFoundError is also returned for incidents the user may not see, so their existence
+// is not revealed
+func NewIncidentNotFoundError(incidentID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    IncidentNotFoundError.String(),
+			Message: "The requested incident could not be found",
+			Context: map[string]any{
+				"incident_id": incidentID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(IncidentNotFoundError.String()),
+		},
+	}
+}
+
+func NewIncidentAlreadyResolvedError(incidentID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    IncidentAlreadyResolvedError.String(),
+			Message: "The incident has already been resolved",
+			Context: map[string]any{
+				"incident_id": incidentID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(IncidentAlreadyResolvedError.String()),
+		},
+	}
+}
+
+func NewGuardianNotificationNotFoundError(notificationID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    GuardianNotificationNotFoundError.String(),
+			Message: "The requested guardian notification could not be found",
+			Context: map[string]any{
+				"notification_id": notificationID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(GuardianNotificationNotFoundError.String()),
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+)
+
+type IncidentFilter struct {
+	// StudentIDs limits the incidents to those involving any of the students, nil means no limit
+	StudentIDs []string
+	Status     entities.IncidentStatus
+	Limit      int
+}
+
+type IncidentRepository interface {
+	// Create stores the incident together with its first guardian notifications, see
+	// GuardianNotificationRepository.Queue
+	Create(ctx context.Context, incident *entities.Incident, notifications []*entities.GuardianNotification) error
+	FindByID(ctx context.Context, tenantID string, incidentID string) (*entities.Incident, error)
+	// List returns the matching incidents, most recent first
+	List(ctx context.Context, tenantID string, filter IncidentFilter) ([]*entities.Incident, error)
+	Resolve(ctx context.Context, incident *entities.Incident) error
+}
+
+type IncidentNoteRepository interface {
+	Create(ctx context.Context, note *entities.IncidentNote) error
+	// ListByIncident returns every note of the incident, oldest first. Callers filter them with
+	// Viewer.CanReadNote before handing them out.
+	ListByIncident(ctx context.Context, tenantID string, incidentID string) ([]*entities.IncidentNote, error)
+}
+
+type GuardianNotificationRepository interface {
+	// Queue stores the notifications and appends a delivery event to the outbox for each, in one
+	// transaction. Guardians already notified about the incident for the same student are skipped,
+	// only the newly queued notifications are returned.
+	Queue(ctx context.Context, notifications []*entities.GuardianNotification) ([]*entities.GuardianNotification, error)
+	FindByID(ctx context.Context, tenantID string, notificationID string) (*entities.GuardianNotification, error)
+	ListByIncident(ctx context.Context, tenantID string, incidentID string) ([]*entities.GuardianNotification, error)
+	Acknowledge(ctx context.Context, notification *entities.GuardianNotification) error
+}
+
+type ViewerDirectory interface {
+	// Viewer returns nil when the user is neither staff nor a guardian
+	Viewer(ctx context.Context, tenantID string, userID string) (*entities.Viewer, error)
+	GuardiansOf(ctx context.Context, tenantID string, studentIDs []string) ([]entities.GuardianLink, error)
+}
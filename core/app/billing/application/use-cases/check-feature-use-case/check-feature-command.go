@@ -0,0 +1,29 @@
+package check_feature_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CheckFeatureCommand struct {
+	TenantID string           `validate:"required"`
+	Feature  entities.Feature `validate:"required"`
+}
+
+func NewCheckFeatureCommand(tenantID string, feature entities.Feature) (*CheckFeatureCommand, error) {
+	command := &CheckFeatureCommand{
+		TenantID: tenantID,
+		Feature:  feature,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,35 @@
+package check_feature_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	billingErrors "github.com/nahualventure/class-backend/core/app/billing/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type CheckFeatureUseCase struct {
+	subscriptionRepo ports.SubscriptionReader
+}
+
+func NewCheckFeatureUseCase(subscriptionRepo ports.SubscriptionReader) *CheckFeatureUseCase {
+	return &CheckFeatureUseCase{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// Execute fails with FEATURE_NOT_IN_PLAN when the plan the tenant is entitled to lacks the feature
+func (uc *CheckFeatureUseCase) Execute(ctx context.Context, cmd *CheckFeatureCommand) error {
+	subscription, err := uc.subscriptionRepo.FindByTenant(ctx, cmd.TenantID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+
+	entitlements := entities.NewEntitlements(subscription)
+	if !entitlements.Allows(cmd.Feature) {
+		return billingErrors.NewFeatureNotInPlanError(string(cmd.Feature), string(entitlements.Plan.Code))
+	}
+
+	return nil
+}
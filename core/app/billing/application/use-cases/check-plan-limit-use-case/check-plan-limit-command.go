@@ -0,0 +1,33 @@
+package check_plan_limit_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// CheckPlanLimitCommand checks whether the tenant can consume Requested more of the limit, in the
+// unit usage is measured in. API calls are counted by the check itself, one per command.
+type CheckPlanLimitCommand struct {
+	TenantID  string         `validate:"required"`
+	Limit     entities.Limit `validate:"required,oneof=staff_accounts storage_gb api_calls_per_day"`
+	Requested int64          `validate:"gte=0"`
+}
+
+func NewCheckPlanLimitCommand(tenantID string, limit entities.Limit, requested int64) (*CheckPlanLimitCommand, error) {
+	command := &CheckPlanLimitCommand{
+		TenantID:  tenantID,
+		Limit:     limit,
+		Requested: requested,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
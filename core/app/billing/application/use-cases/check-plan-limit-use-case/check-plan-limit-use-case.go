@@ -0,0 +1,60 @@
+package check_plan_limit_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	billingErrors "github.com/nahualventure/class-backend/core/app/billing/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type CheckPlanLimitUseCase struct {
+	subscriptionRepo ports.SubscriptionReader
+	usageMeter       ports.UsageMeter
+}
+
+func NewCheckPlanLimitUseCase(subscriptionRepo ports.SubscriptionReader, usageMeter ports.UsageMeter) *CheckPlanLimitUseCase {
+	return &CheckPlanLimitUseCase{
+		subscriptionRepo: subscriptionRepo,
+		usageMeter:       usageMeter,
+	}
+}
+
+// Execute fails with PLAN_LIMIT_EXCEEDED when the request would take the tenant past the allowance
+// of its plan. Limits the plan leaves out are not measured.
+func (uc *CheckPlanLimitUseCase) Execute(ctx context.Context, cmd *CheckPlanLimitCommand) error {
+	subscription, err := uc.subscriptionRepo.FindByTenant(ctx, cmd.TenantID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+
+	plan := entities.NewEntitlements(subscription).Plan
+	allowance, limited := plan.Allowance(cmd.Limit)
+	if !limited {
+		return nil
+	}
+
+	var usage int64
+	if cmd.Limit == entities.LimitAPICallsPerDay {
+		// The counted call is already part of the usage
+		usage, err = uc.usageMeter.CountAPICall(ctx, cmd.TenantID, time.Now().UTC())
+	} else {
+		usage, err = uc.usageMeter.Usage(ctx, cmd.TenantID, cmd.Limit)
+		usage += cmd.Requested
+	}
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+
+	if usage <= allowance {
+		return nil
+	}
+
+	upgradePlan := ""
+	if upgrade := plan.UpgradeFor(cmd.Limit, usage); upgrade != nil {
+		upgradePlan = string(upgrade.Code)
+	}
+	return billingErrors.NewPlanLimitExceededError(string(cmd.Limit), allowance, usage, string(plan.Code), upgradePlan)
+}
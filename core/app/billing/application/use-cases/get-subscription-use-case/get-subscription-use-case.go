@@ -0,0 +1,29 @@
+package get_subscription_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetSubscriptionUseCase struct {
+	subscriptionRepo ports.SubscriptionReader
+}
+
+func NewGetSubscriptionUseCase(subscriptionRepo ports.SubscriptionReader) *GetSubscriptionUseCase {
+	return &GetSubscriptionUseCase{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// Execute returns the plan the tenant is on, the free plan when it has no subscription
+func (uc *GetSubscriptionUseCase) Execute(ctx context.Context, cmd *GetSubscriptionCommand) (entities.Entitlements, error) {
+	subscription, err := uc.subscriptionRepo.FindByTenant(ctx, cmd.TenantID)
+	if err != nil {
+		return entities.Entitlements{}, errors.PropagateError(err)
+	}
+
+	return entities.NewEntitlements(subscription), nil
+}
@@ -0,0 +1,29 @@
+package list_invoices_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type ListInvoicesUseCase struct {
+	invoiceRepo ports.InvoiceRepository
+}
+
+func NewListInvoicesUseCase(invoiceRepo ports.InvoiceRepository) *ListInvoicesUseCase {
+	return &ListInvoicesUseCase{
+		invoiceRepo: invoiceRepo,
+	}
+}
+
+func (uc *ListInvoicesUseCase) Execute(ctx context.Context, cmd *ListInvoicesCommand) (*pagination.Page[*entities.Invoice], error) {
+	page, err := uc.invoiceRepo.List(ctx, cmd.TenantID, cmd.Page)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return page, nil
+}
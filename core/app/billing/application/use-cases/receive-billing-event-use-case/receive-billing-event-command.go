@@ -0,0 +1,28 @@
+package receive_billing_event_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// ReceiveBillingEventCommand carries a webhook body the provider signature was verified for when
+// it was received
+type ReceiveBillingEventCommand struct {
+	Payload []byte `validate:"required"`
+}
+
+func NewReceiveBillingEventCommand(payload []byte) (*ReceiveBillingEventCommand, error) {
+	command := &ReceiveBillingEventCommand{
+		Payload: payload,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
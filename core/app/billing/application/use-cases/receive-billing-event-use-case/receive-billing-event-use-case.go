@@ -0,0 +1,95 @@
+package receive_billing_event_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	billingErrors "github.com/nahualventure/class-backend/core/app/billing/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type ReceiveBillingEventUseCase struct {
+	subscriptionRepo ports.SubscriptionRepository
+	invoiceRepo      ports.InvoiceRepository
+	provider         ports.BillingProvider
+	contacts         ports.BillingContacts
+}
+
+func NewReceiveBillingEventUseCase(subscriptionRepo ports.SubscriptionRepository, invoiceRepo ports.InvoiceRepository,
+	provider ports.BillingProvider, contacts ports.BillingContacts) *ReceiveBillingEventUseCase {
+	return &ReceiveBillingEventUseCase{
+		subscriptionRepo: subscriptionRepo,
+		invoiceRepo:      invoiceRepo,
+		provider:         provider,
+		contacts:         contacts,
+	}
+}
+
+// Execute applies a provider webhook, it runs on the webhook worker. Providers may deliver webhooks
+// out of order and a delivery is handled again when its worker stopped, changes older than the
+// stored copy are skipped and each failed payment attempt is notified once. Invoices of customers
+// without a subscription yet fail so the worker handles them again later.
+func (uc *ReceiveBillingEventUseCase) Execute(ctx context.Context, cmd *ReceiveBillingEventCommand) (*entities.BillingEvent, error) {
+	event, err := uc.provider.ParseEvent(cmd.Payload)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	switch event.Type {
+	case entities.BillingEventSubscriptionChanged:
+		if err := uc.subscriptionRepo.Save(ctx, event.Subscription); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	case entities.BillingEventInvoiceChanged, entities.BillingEventPaymentFailed:
+		invoice, err := uc.saveInvoice(ctx, event)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		if event.Type == entities.BillingEventPaymentFailed {
+			if err := uc.queueDunningNotice(ctx, invoice); err != nil {
+				return nil, errors.PropagateError(err)
+			}
+		}
+	}
+
+	return event, nil
+}
+
+func (uc *ReceiveBillingEventUseCase) saveInvoice(ctx context.Context, event *entities.BillingEvent) (*entities.Invoice, error) {
+	subscription, err := uc.subscriptionRepo.FindByCustomer(ctx, event.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, billingErrors.NewBillingCustomerNotFoundError(event.CustomerID)
+	}
+
+	received := event.Invoice
+	invoice, err := entities.NewInvoice(uuid.New().String(), subscription.TenantID, received.ProviderID, received.Number, received.Status,
+		received.Currency, received.AmountDue, received.AmountPaid, received.HostedURL, received.PeriodStart, received.PeriodEnd,
+		received.AttemptCount, received.NextPaymentAttempt, event.OccurredAt, received.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.invoiceRepo.Save(ctx, invoice); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+func (uc *ReceiveBillingEventUseCase) queueDunningNotice(ctx context.Context, invoice *entities.Invoice) error {
+	recipients, err := uc.contacts.Contacts(ctx, invoice.TenantID)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	_, err = uc.invoiceRepo.QueueDunningNotice(ctx, invoice, recipients)
+	return err
+}
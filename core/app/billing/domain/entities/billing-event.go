@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+)
+
+type BillingEventType string
+
+const (
+	BillingEventSubscriptionChanged BillingEventType = "subscription_changed"
+	BillingEventInvoiceChanged      BillingEventType = "invoice_changed"
+	// BillingEventPaymentFailed carries the invoice whose payment attempt failed
+	BillingEventPaymentFailed BillingEventType = "payment_failed"
+	// BillingEventIgnored is any other provider event, acknowledged without changes
+	BillingEventIgnored BillingEventType = "ignored"
+)
+
+// BillingEvent is a billing provider webhook translated to the billing domain. Subscription events
+// carry the tenant in the subscription, invoice events only carry the customer and have no
+// TenantID nor ID until the customer is resolved.
+type BillingEvent struct {
+	ID           string
+	Type         BillingEventType
+	OccurredAt   time.Time
+	CustomerID   string
+	Subscription *Subscription
+	Invoice      *Invoice
+}
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+type InvoiceStatus string
+
+const (
+	InvoiceStatusDraft         InvoiceStatus = "draft"
+	InvoiceStatusOpen          InvoiceStatus = "open"
+	InvoiceStatusPaid          InvoiceStatus = "paid"
+	InvoiceStatusUncollectible InvoiceStatus = "uncollectible"
+	InvoiceStatusVoid          InvoiceStatus = "void"
+)
+
+// Invoice is a copy of an invoice of the billing provider. Amounts are in the minor unit of the
+// currency (e.g. cents). AttemptCount counts the payment attempts, NextPaymentAttempt is unset once
+// the provider stops retrying.
+type Invoice struct {
+	ID                 string        `validate:"required,uuid4"`
+	TenantID           string        `validate:"required"`
+	ProviderID         string        `validate:"required,max=255"`
+	Number             string        `validate:"max=100"`
+	Status             InvoiceStatus `validate:"required,oneof=draft open paid uncollectible void"`
+	Currency           string        `validate:"required,len=3"`
+	AmountDue          int64         `validate:"gte=0"`
+	AmountPaid         int64         `validate:"gte=0"`
+	HostedURL          string        `validate:"omitempty,url,max=2000"`
+	PeriodStart        time.Time     `validate:"required"`
+	PeriodEnd          time.Time     `validate:"required"`
+	AttemptCount       int           `validate:"gte=0"`
+	NextPaymentAttempt *time.Time
+	SyncedAt           time.Time `validate:"required"`
+	CreatedAt          time.Time `validate:"required"`
+}
+
+func NewInvoice(id string, tenantID string, providerID string, number string, status InvoiceStatus, currency string, amountDue int64,
+	amountPaid int64, hostedURL string, periodStart time.Time, periodEnd time.Time, attemptCount int, nextPaymentAttempt *time.Time,
+	syncedAt time.Time, createdAt time.Time) (*Invoice, error) {
+	invoice := &Invoice{
+		ID:                 id,
+		TenantID:           tenantID,
+		ProviderID:         providerID,
+		Number:             number,
+		Status:             status,
+		Currency:           currency,
+		AmountDue:          amountDue,
+		AmountPaid:         amountPaid,
+		HostedURL:          hostedURL,
+		PeriodStart:        periodStart,
+		PeriodEnd:          periodEnd,
+		AttemptCount:       attemptCount,
+		NextPaymentAttempt: nextPaymentAttempt,
+		SyncedAt:           syncedAt,
+		CreatedAt:          createdAt,
+	}
+
+	if err := validate.Struct(invoice); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("Invoice domain model instance not valid", errorMap, err)
+	}
+
+	return invoice, nil
+}
+
+// IsFinalAttempt reports whether the provider gave up collecting the invoice
+func (i *Invoice) IsFinalAttempt() bool {
+	return i.Status != InvoiceStatusPaid && i.NextPaymentAttempt == nil
+}
@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"slices"
+)
+
+// Feature is a capability sold with a plan. Gated endpoints are only served to tenants whose plan
+// includes their feature.
+type Feature string
+
+const (
+	FeatureReports          Feature = "reports"
+	FeatureResourceLibrary  Feature = "resource_library"
+	FeatureSimilarityChecks Feature = "similarity_checks"
+	FeatureSurveys          Feature = "surveys"
+)
+
+// Limit is a usage quota of a plan. Unlike features, every plan grants some usage of each limit
+// unless it leaves the limit out, which makes it unlimited.
+type Limit string
+
+const (
+	LimitStaffAccounts  Limit = "staff_accounts"
+	LimitStorageGB      Limit = "storage_gb"
+	LimitAPICallsPerDay Limit = "api_calls_per_day"
+)
+
+// bytesPerGB converts storage allowances, usage of storage is measured in bytes
+const bytesPerGB = 1 << 30
+
+type PlanCode string
+
+const (
+	PlanFree     PlanCode = "free"
+	PlanStandard PlanCode = "standard"
+	PlanPremium  PlanCode = "premium"
+)
+
+// Plan is a subscription tier. Provider prices point to their plan through the plan code, so
+// prices can change without touching the catalog.
+type Plan struct {
+	Code     PlanCode
+	Name     string
+	Features []Feature
+	// Limits are in the unit named by the limit
+	Limits map[Limit]int64
+}
+
+// Plans lists the plans a tenant can subscribe to from the cheapest up, tenants without a
+// subscription are on the free plan
+var Plans = []*Plan{
+	{
+		Code: PlanFree,
+		Name: "Free",
+		Limits: map[Limit]int64{
+			LimitStaffAccounts:  5,
+			LimitStorageGB:      1,
+			LimitAPICallsPerDay: 1_000,
+		},
+	},
+	{
+		Code:     PlanStandard,
+		Name:     "Standard",
+		Features: []Feature{FeatureReports, FeatureResourceLibrary, FeatureSurveys},
+		Limits: map[Limit]int64{
+			LimitStaffAccounts:  50,
+			LimitStorageGB:      50,
+			LimitAPICallsPerDay: 50_000,
+		},
+	},
+	{
+		Code:     PlanPremium,
+		Name:     "Premium",
+		Features: []Feature{FeatureReports, FeatureResourceLibrary, FeatureSurveys, FeatureSimilarityChecks},
+		Limits: map[Limit]int64{
+			LimitStorageGB:      500,
+			LimitAPICallsPerDay: 500_000,
+		},
+	},
+}
+
+// PlanByCode returns nil for codes outside the catalog
+func PlanByCode(code PlanCode) *Plan {
+	for _, plan := range Plans {
+		if plan.Code == code {
+			return plan
+		}
+	}
+	return nil
+}
+
+func (p *Plan) Includes(feature Feature) bool {
+	return slices.Contains(p.Features, feature)
+}
+
+// Allowance returns how much of the limit the plan grants in the unit usage is measured in, bytes
+// for storage. It returns false when the plan does not limit it.
+func (p *Plan) Allowance(limit Limit) (int64, bool) {
+	allowance, limited := p.Limits[limit]
+	if !limited {
+		return 0, false
+	}
+	if limit == LimitStorageGB {
+		return allowance * bytesPerGB, true
+	}
+	return allowance, true
+}
+
+// UpgradeFor returns the cheapest plan above this one that allows the usage, nil when none does
+func (p *Plan) UpgradeFor(limit Limit, usage int64) *Plan {
+	index := slices.Index(Plans, p)
+	for _, plan := range Plans[index+1:] {
+		allowance, limited := plan.Allowance(limit)
+		if !limited || usage <= allowance {
+			return plan
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusTrialing   SubscriptionStatus = "trialing"
+	SubscriptionStatusActive     SubscriptionStatus = "active"
+	SubscriptionStatusPastDue    SubscriptionStatus = "past_due"
+	SubscriptionStatusUnpaid     SubscriptionStatus = "unpaid"
+	SubscriptionStatusIncomplete SubscriptionStatus = "incomplete"
+	SubscriptionStatusCanceled   SubscriptionStatus = "canceled"
+)
+
+// Subscription is the plan a tenant pays for. It is a copy of the subscription kept by the billing
+// provider, SyncedAt is the time of the provider event it was last updated from so late deliveries
+// of older events are ignored.
+type Subscription struct {
+	TenantID          string             `validate:"required"`
+	PlanCode          PlanCode           `validate:"required"`
+	Status            SubscriptionStatus `validate:"required,oneof=trialing active past_due unpaid incomplete canceled"`
+	CustomerID        string             `validate:"required,max=255"`
+	ProviderID        string             `validate:"required,max=255"`
+	CurrentPeriodEnd  *time.Time
+	CancelAtPeriodEnd bool
+	SyncedAt          time.Time `validate:"required"`
+	UpdatedAt         time.Time `validate:"required"`
+}
+
+func NewSubscription(tenantID string, planCode PlanCode, status SubscriptionStatus, customerID string, providerID string,
+	currentPeriodEnd *time.Time, cancelAtPeriodEnd bool, syncedAt time.Time, updatedAt time.Time) (*Subscription, error) {
+	subscription := &Subscription{
+		TenantID:          tenantID,
+		PlanCode:          planCode,
+		Status:            status,
+		CustomerID:        customerID,
+		ProviderID:        providerID,
+		CurrentPeriodEnd:  currentPeriodEnd,
+		CancelAtPeriodEnd: cancelAtPeriodEnd,
+		SyncedAt:          syncedAt,
+		UpdatedAt:         updatedAt,
+	}
+
+	if err := validate.Struct(subscription); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("Subscription domain model instance not valid", errorMap, err)
+	}
+
+	if PlanByCode(planCode) == nil {
+		errorMap := map[string]any{"plan_code": "not a plan of the catalog"}
+		return nil, appErrors.NewDomainEntityValidationError("Subscription domain model instance not valid", errorMap,
+			errors.Newf("unknown plan %q", planCode))
+	}
+
+	return subscription, nil
+}
+
+// IsEntitled reports whether the tenant gets the features of the plan. Past due subscriptions keep
+// them while the provider retries the payment, they are lost once it gives up.
+func (s *Subscription) IsEntitled() bool {
+	switch s.Status {
+	case SubscriptionStatusTrialing, SubscriptionStatusActive, SubscriptionStatusPastDue:
+		return true
+	default:
+		return false
+	}
+}
+
+// Entitlements are the features a tenant can use, from its subscription when it has one that
+// entitles it and from the free plan otherwise
+type Entitlements struct {
+	Plan         *Plan
+	Subscription *Subscription
+}
+
+func NewEntitlements(subscription *Subscription) Entitlements {
+	if subscription == nil || !subscription.IsEntitled() {
+		return Entitlements{Plan: PlanByCode(PlanFree), Subscription: subscription}
+	}
+	return Entitlements{Plan: PlanByCode(subscription.PlanCode), Subscription: subscription}
+}
+
+func (e Entitlements) Allows(feature Feature) bool {
+	return e.Plan.Includes(feature)
+}
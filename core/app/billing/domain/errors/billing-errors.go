@@ -0,0 +1,82 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	InvalidBillingWebhookError   errors2.ErrorCode = "INVALID_BILLING_WEBHOOK"
+	BillingCustomerNotFoundError errors2.ErrorCode = "BILLING_CUSTOMER_NOT_FOUND"
+	FeatureNotInPlanError        errors2.ErrorCode = "FEATURE_NOT_IN_PLAN"
+	PlanLimitExceededError       errors2.ErrorCode = "PLAN_LIMIT_EXCEEDED"
+)
+
+func NewInvalidBillingWebhookError(reason string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    InvalidBillingWebhookError.String(),
+			Message: "The billing webhook could not be verified",
+			Context: map[string]any{
+				"reason": reason,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidBillingWebhookError.String()),
+		},
+	}
+}
+
+func NewBillingCustomerNotFoundError(customerID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    BillingCustomerNotFoundError.String(),
+			Message: "No tenant is subscribed with the billing customer",
+			Context: map[string]any{
+				"customerId": customerID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(BillingCustomerNotFoundError.String()),
+		},
+	}
+}
+
+func NewFeatureNotInPlanError(feature string, plan string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    FeatureNotInPlanError.String(),
+			Message: "The plan of the tenant does not include this feature",
+			Context: map[string]any{
+				"feature": feature,
+				"plan":    plan,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(FeatureNotInPlanError.String()),
+		},
+	}
+}
+
+// NewPlanLimitExceededError hints the cheapest plan that allows the usage, upgradePlan is empty when
+// no plan does
+func NewPlanLimitExceededError(limit string, allowance int64, usage int64, plan string, upgradePlan string) *errors2.BaseDomainError {
+	errorContext := map[string]any{
+		"limit":     limit,
+		"allowance": allowance,
+		"usage":     usage,
+		"plan":      plan,
+	}
+	if upgradePlan != "" {
+		errorContext["upgradePlan"] = upgradePlan
+	}
+
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:       PlanLimitExceededError.String(),
+			Message:    "The plan of the tenant does not allow this much usage",
+			Context:    errorContext,
+			OccurredAt: time.Now(),
+			Underlying: errors.New(PlanLimitExceededError.String()),
+		},
+	}
+}
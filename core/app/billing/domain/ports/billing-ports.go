@@ -0,0 +1,55 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+// SubscriptionReader is the read side used on every gated request, it returns nil for tenants
+// without a subscription
+type SubscriptionReader interface {
+	FindByTenant(ctx context.Context, tenantID string) (*entities.Subscription, error)
+}
+
+type SubscriptionRepository interface {
+	SubscriptionReader
+	// FindByCustomer looks across tenants, invoice webhooks only carry the customer
+	FindByCustomer(ctx context.Context, customerID string) (*entities.Subscription, error)
+	// Save creates or replaces the subscription of the tenant unless it was synced from a later event
+	Save(ctx context.Context, subscription *entities.Subscription) error
+}
+
+type InvoiceRepository interface {
+	// Save creates or updates the invoice by provider ID unless it was synced from a later event. A new
+	// invoice keeps the given ID, an existing one keeps its own.
+	Save(ctx context.Context, invoice *entities.Invoice) error
+	// QueueDunningNotice notifies the recipients of a failed payment attempt in one transaction. Each
+	// attempt is notified once, it returns false when this attempt already was.
+	QueueDunningNotice(ctx context.Context, invoice *entities.Invoice, recipientIDs []string) (bool, error)
+	// List returns the invoices of the tenant, newest first
+	List(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.Invoice], error)
+}
+
+// BillingProvider is the payment provider, changes arrive through its webhooks
+type BillingProvider interface {
+	// VerifyEvent authenticates a webhook body and returns the ID of the event it carries
+	VerifyEvent(payload []byte, signature string) (string, error)
+	// ParseEvent translates the event of a webhook body VerifyEvent accepted when it was received
+	ParseEvent(payload []byte) (*entities.BillingEvent, error)
+}
+
+// BillingContacts returns the users told about billing problems of the tenant
+type BillingContacts interface {
+	Contacts(ctx context.Context, tenantID string) ([]string, error)
+}
+
+// UsageMeter measures how much of the plan limits a tenant uses
+type UsageMeter interface {
+	// Usage returns the current usage of a staff or storage limit, storage in bytes
+	Usage(ctx context.Context, tenantID string, limit entities.Limit) (int64, error)
+	// CountAPICall records a call of the tenant on the UTC day and returns the calls of that day so far
+	CountAPICall(ctx context.Context, tenantID string, day time.Time) (int64, error)
+}
@@ -0,0 +1,42 @@
+package add_non_instructional_day_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type AddNonInstructionalDayCommand struct {
+	TenantID string    `validate:"required"`
+	ActorID  string    `validate:"required"`
+	Date     time.Time `validate:"required"`
+	Name     string    `validate:"required,max=200"`
+}
+
+// NewAddNonInstructionalDayCommand takes the date as written in DateLayout
+func NewAddNonInstructionalDayCommand(tenantID string, actorID string, date string, name string) (*AddNonInstructionalDayCommand, error) {
+	day, err := time.Parse(entities.DateLayout, date)
+	if err != nil {
+		return nil, errors.NewValidationError("Invalid non-instructional day",
+			map[string]any{"date": "must be a date like 2025-09-15"}, err)
+	}
+
+	command := &AddNonInstructionalDayCommand{
+		TenantID: tenantID,
+		ActorID:  actorID,
+		Date:     day,
+		Name:     name,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
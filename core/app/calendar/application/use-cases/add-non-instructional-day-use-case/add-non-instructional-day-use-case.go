@@ -0,0 +1,34 @@
+package add_non_instructional_day_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type AddNonInstructionalDayUseCase struct {
+	dayRepo ports.NonInstructionalDayRepository
+}
+
+func NewAddNonInstructionalDayUseCase(dayRepo ports.NonInstructionalDayRepository) *AddNonInstructionalDayUseCase {
+	return &AddNonInstructionalDayUseCase{
+		dayRepo: dayRepo,
+	}
+}
+
+// Execute adds the day by hand, a day already in the calendar is renamed
+func (uc *AddNonInstructionalDayUseCase) Execute(ctx context.Context, cmd *AddNonInstructionalDayCommand) (*entities.NonInstructionalDay, error) {
+	day, err := entities.NewNonInstructionalDay(cmd.TenantID, cmd.Date, cmd.Name, "", cmd.ActorID, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.dayRepo.Save(ctx, day); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return day, nil
+}
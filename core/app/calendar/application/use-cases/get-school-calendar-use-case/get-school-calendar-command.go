@@ -0,0 +1,39 @@
+package get_school_calendar_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxWindow bounds the range of one calendar
+const MaxWindow = 366 * 24 * time.Hour
+
+var validate = validator.New()
+
+type GetSchoolCalendarCommand struct {
+	TenantID string    `validate:"required"`
+	From     time.Time `validate:"required"`
+	To       time.Time `validate:"required,gtfield=From"`
+}
+
+func NewGetSchoolCalendarCommand(tenantID string, from time.Time, to time.Time) (*GetSchoolCalendarCommand, error) {
+	command := &GetSchoolCalendarCommand{
+		TenantID: tenantID,
+		From:     from.UTC(),
+		To:       to.UTC(),
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if command.To.Sub(command.From) > MaxWindow {
+		return nil, errors.NewValidationError("Invalid school calendar range",
+			map[string]any{"to": "at most " + MaxWindow.String() + " can be read at once"}, nil)
+	}
+
+	return command, nil
+}
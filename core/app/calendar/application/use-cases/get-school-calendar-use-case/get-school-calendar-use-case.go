@@ -0,0 +1,27 @@
+package get_school_calendar_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetSchoolCalendarUseCase struct {
+	calendarReader ports.SchoolCalendarReader
+}
+
+func NewGetSchoolCalendarUseCase(calendarReader ports.SchoolCalendarReader) *GetSchoolCalendarUseCase {
+	return &GetSchoolCalendarUseCase{
+		calendarReader: calendarReader,
+	}
+}
+
+func (uc *GetSchoolCalendarUseCase) Execute(ctx context.Context, cmd *GetSchoolCalendarCommand) (*entities.SchoolCalendar, error) {
+	calendar, err := uc.calendarReader.SchoolCalendar(ctx, cmd.TenantID, cmd.From, cmd.To)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return calendar, nil
+}
@@ -0,0 +1,34 @@
+package import_holidays_use_case
+
+import (
+	"strings"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ImportHolidaysCommand struct {
+	TenantID string `validate:"required"`
+	ActorID  string `validate:"required"`
+	Country  string `validate:"required,iso3166_1_alpha2"`
+	Year     int    `validate:"required,min=2000,max=2100"`
+}
+
+func NewImportHolidaysCommand(tenantID string, actorID string, country string, year int) (*ImportHolidaysCommand, error) {
+	command := &ImportHolidaysCommand{
+		TenantID: tenantID,
+		ActorID:  actorID,
+		Country:  strings.ToUpper(country),
+		Year:     year,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,52 @@
+package import_holidays_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	calendarErrors "github.com/nahualventure/class-backend/core/app/calendar/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ImportHolidaysUseCase struct {
+	dayRepo ports.NonInstructionalDayRepository
+}
+
+func NewImportHolidaysUseCase(dayRepo ports.NonInstructionalDayRepository) *ImportHolidaysUseCase {
+	return &ImportHolidaysUseCase{
+		dayRepo: dayRepo,
+	}
+}
+
+// Execute adds the public holidays of the country in the year as non-instructional days. Importing
+// again is harmless, days already in the calendar, added by hand or by an earlier import, are kept.
+func (uc *ImportHolidaysUseCase) Execute(ctx context.Context, cmd *ImportHolidaysCommand) (*entities.HolidayImport, error) {
+	holidays, ok := entities.HolidaySet(cmd.Country, cmd.Year)
+	if !ok {
+		return nil, calendarErrors.NewUnsupportedHolidaySetError(cmd.Country, entities.HolidayCountries())
+	}
+
+	now := time.Now().UTC()
+	days := make([]*entities.NonInstructionalDay, 0, len(holidays))
+	for _, holiday := range holidays {
+		day, err := entities.NewNonInstructionalDay(cmd.TenantID, holiday.Date, holiday.Name, cmd.Country, cmd.ActorID, now)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		days = append(days, day)
+	}
+
+	added, err := uc.dayRepo.AddMissing(ctx, days)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return &entities.HolidayImport{
+		Country:  cmd.Country,
+		Year:     cmd.Year,
+		Holidays: holidays,
+		Added:    added,
+	}, nil
+}
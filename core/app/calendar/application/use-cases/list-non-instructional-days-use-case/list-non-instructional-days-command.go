@@ -0,0 +1,48 @@
+package list_non_instructional_days_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxListedYears bounds the range of one listing
+const MaxListedYears = 2
+
+var validate = validator.New()
+
+type ListNonInstructionalDaysCommand struct {
+	TenantID string    `validate:"required"`
+	From     time.Time `validate:"required"`
+	To       time.Time `validate:"required,gtefield=From"`
+}
+
+// NewListNonInstructionalDaysCommand takes both dates, included in the range, as written in DateLayout
+func NewListNonInstructionalDaysCommand(tenantID string, from string, to string) (*ListNonInstructionalDaysCommand, error) {
+	fromDate, fromErr := time.Parse(entities.DateLayout, from)
+	toDate, toErr := time.Parse(entities.DateLayout, to)
+	if fromErr != nil || toErr != nil {
+		return nil, errors.NewValidationError("Invalid school calendar range",
+			map[string]any{"from": "must be a date like 2025-09-15", "to": "must be a date like 2026-06-30"}, nil)
+	}
+
+	command := &ListNonInstructionalDaysCommand{
+		TenantID: tenantID,
+		From:     fromDate,
+		To:       toDate,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if command.To.After(command.From.AddDate(MaxListedYears, 0, 0)) {
+		return nil, errors.NewValidationError("Invalid school calendar range",
+			map[string]any{"to": "at most 2 years can be listed at once"}, nil)
+	}
+
+	return command, nil
+}
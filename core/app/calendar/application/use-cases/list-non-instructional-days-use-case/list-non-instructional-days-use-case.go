@@ -0,0 +1,27 @@
+package list_non_instructional_days_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListNonInstructionalDaysUseCase struct {
+	dayRepo ports.NonInstructionalDayRepository
+}
+
+func NewListNonInstructionalDaysUseCase(dayRepo ports.NonInstructionalDayRepository) *ListNonInstructionalDaysUseCase {
+	return &ListNonInstructionalDaysUseCase{
+		dayRepo: dayRepo,
+	}
+}
+
+func (uc *ListNonInstructionalDaysUseCase) Execute(ctx context.Context, cmd *ListNonInstructionalDaysCommand) ([]*entities.NonInstructionalDay, error) {
+	days, err := uc.dayRepo.List(ctx, cmd.TenantID, cmd.From, cmd.To)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return days, nil
+}
@@ -0,0 +1,38 @@
+package remove_non_instructional_day_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RemoveNonInstructionalDayCommand struct {
+	TenantID string    `validate:"required"`
+	Date     time.Time `validate:"required"`
+}
+
+// NewRemoveNonInstructionalDayCommand takes the date as written in DateLayout
+func NewRemoveNonInstructionalDayCommand(tenantID string, date string) (*RemoveNonInstructionalDayCommand, error) {
+	day, err := time.Parse(entities.DateLayout, date)
+	if err != nil {
+		return nil, errors.NewValidationError("Invalid non-instructional day",
+			map[string]any{"date": "must be a date like 2025-09-15"}, err)
+	}
+
+	command := &RemoveNonInstructionalDayCommand{
+		TenantID: tenantID,
+		Date:     day,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,33 @@
+package remove_non_instructional_day_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	calendarErrors "github.com/nahualventure/class-backend/core/app/calendar/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type RemoveNonInstructionalDayUseCase struct {
+	dayRepo ports.NonInstructionalDayRepository
+}
+
+func NewRemoveNonInstructionalDayUseCase(dayRepo ports.NonInstructionalDayRepository) *RemoveNonInstructionalDayUseCase {
+	return &RemoveNonInstructionalDayUseCase{
+		dayRepo: dayRepo,
+	}
+}
+
+// Execute turns the day back into an instructional day, imported holidays are removed the same way
+func (uc *RemoveNonInstructionalDayUseCase) Execute(ctx context.Context, cmd *RemoveNonInstructionalDayCommand) error {
+	deleted, err := uc.dayRepo.Delete(ctx, cmd.TenantID, cmd.Date)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if !deleted {
+		return calendarErrors.NewNonInstructionalDayNotFoundError(cmd.Date.Format(entities.DateLayout))
+	}
+
+	return nil
+}
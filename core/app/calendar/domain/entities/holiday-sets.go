@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+// Holiday is a day of a country holiday set, tenants import them as non-instructional days
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// HolidayImport is the outcome of importing a holiday set, Added leaves out the holidays that were
+// already in the calendar
+type HolidayImport struct {
+	Country  string
+	Year     int
+	Holidays []Holiday
+	Added    []*NonInstructionalDay
+}
+
+// holidaySets builds the public holidays of a country for a year, keyed by ISO 3166 alpha-2 code
+var holidaySets = map[string]func(year int) []Holiday{
+	"GT": guatemalaHolidays,
+	"MX": mexicoHolidays,
+	"US": unitedStatesHolidays,
+}
+
+// HolidayCountries lists the countries with a built-in holiday set
+func HolidayCountries() []string {
+	countries := make([]string, 0, len(holidaySets))
+	for country := range holidaySets {
+		countries = append(countries, country)
+	}
+	slices.Sort(countries)
+	return countries
+}
+
+// HolidaySet returns the public holidays of the country in the year ordered by date, false when the
+// country has no built-in set
+func HolidaySet(country string, year int) ([]Holiday, bool) {
+	build, ok := holidaySets[country]
+	if !ok {
+		return nil, false
+	}
+
+	holidays := build(year)
+	slices.SortFunc(holidays, func(a, b Holiday) int { return a.Date.Compare(b.Date) })
+	return holidays, true
+}
+
+// guatemalaHolidays follows the Labor Code, half days off on December 24 and 31 are left to each school
+func guatemalaHolidays(year int) []Holiday {
+	easter := easterSunday(year)
+	return []Holiday{
+		{Date: date(year, time.January, 1), Name: "Año Nuevo"},
+		{Date: easter.AddDate(0, 0, -3), Name: "Jueves Santo"},
+		{Date: easter.AddDate(0, 0, -2), Name: "Viernes Santo"},
+		{Date: easter.AddDate(0, 0, -1), Name: "Sábado Santo"},
+		{Date: date(year, time.May, 1), Name: "Día del Trabajo"},
+		{Date: date(year, time.June, 30), Name: "Día del Ejército"},
+		{Date: date(year, time.September, 15), Name: "Día de la Independencia"},
+		{Date: date(year, time.October, 20), Name: "Día de la Revolución"},
+		{Date: date(year, time.November, 1), Name: "Día de Todos los Santos"},
+		{Date: date(year, time.December, 25), Name: "Navidad"},
+	}
+}
+
+// mexicoHolidays follows article 74 of the Federal Labor Law, the change of federal government is a
+// holiday every six years since 2024
+func mexicoHolidays(year int) []Holiday {
+	holidays := []Holiday{
+		{Date: date(year, time.January, 1), Name: "Año Nuevo"},
+		{Date: nthWeekday(year, time.February, time.Monday, 1), Name: "Día de la Constitución"},
+		{Date: nthWeekday(year, time.March, time.Monday, 3), Name: "Natalicio de Benito Juárez"},
+		{Date: date(year, time.May, 1), Name: "Día del Trabajo"},
+		{Date: date(year, time.September, 16), Name: "Día de la Independencia"},
+		{Date: nthWeekday(year, time.November, time.Monday, 3), Name: "Día de la Revolución"},
+		{Date: date(year, time.December, 25), Name: "Navidad"},
+	}
+	if year >= 2024 && (year-2024)%6 == 0 {
+		holidays = append(holidays, Holiday{Date: date(year, time.October, 1), Name: "Transmisión del Poder Ejecutivo Federal"})
+	}
+	return holidays
+}
+
+// unitedStatesHolidays are the federal holidays, fixed dates on a weekend are observed on the closest
+// weekday
+func unitedStatesHolidays(year int) []Holiday {
+	holidays := []Holiday{
+		{Date: observed(date(year, time.January, 1)), Name: "New Year's Day"},
+		{Date: nthWeekday(year, time.January, time.Monday, 3), Name: "Martin Luther King Jr. Day"},
+		{Date: nthWeekday(year, time.February, time.Monday, 3), Name: "Washington's Birthday"},
+		{Date: nthWeekday(year, time.May, time.Monday, -1), Name: "Memorial Day"},
+		{Date: observed(date(year, time.July, 4)), Name: "Independence Day"},
+		{Date: nthWeekday(year, time.September, time.Monday, 1), Name: "Labor Day"},
+		{Date: nthWeekday(year, time.October, time.Monday, 2), Name: "Columbus Day"},
+		{Date: observed(date(year, time.November, 11)), Name: "Veterans Day"},
+		{Date: nthWeekday(year, time.November, time.Thursday, 4), Name: "Thanksgiving Day"},
+		{Date: observed(date(year, time.December, 25)), Name: "Christmas Day"},
+	}
+	if year >= 2021 {
+		holidays = append(holidays, Holiday{Date: observed(date(year, time.June, 19)), Name: "Juneteenth"})
+	}
+	return holidays
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// nthWeekday returns the nth weekday of the month, counting from the end of the month when n is negative
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	if n < 0 {
+		last := date(year, month+1, 0)
+		return last.AddDate(0, 0, -((int(last.Weekday())-int(weekday)+7)%7 + 7*(-n-1)))
+	}
+	first := date(year, month, 1)
+	return first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+7*(n-1))
+}
+
+// observed moves a holiday on a Saturday to the Friday before and one on a Sunday to the Monday after
+func observed(day time.Time) time.Time {
+	switch day.Weekday() {
+	case time.Saturday:
+		return day.AddDate(0, 0, -1)
+	case time.Sunday:
+		return day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// easterSunday computes Western Easter with the anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// DateLayout is how days are written in requests and responses
+const DateLayout = time.DateOnly
+
+// NonInstructionalDay is a day without classes in the calendar of a tenant. Date is a civil date kept at
+// midnight UTC, the whole day is off in the zone of the school. Country is set on days imported from a
+// holiday set and empty on days added by hand.
+type NonInstructionalDay struct {
+	TenantID  string    `validate:"required"`
+	Date      time.Time `validate:"required"`
+	Name      string    `validate:"required,max=200"`
+	Country   string    `validate:"omitempty,iso3166_1_alpha2"`
+	CreatedBy string    `validate:"required"`
+	CreatedAt time.Time `validate:"required"`
+}
+
+func NewNonInstructionalDay(tenantID string, date time.Time, name string, country string, createdBy string,
+	createdAt time.Time) (*NonInstructionalDay, error) {
+	day := &NonInstructionalDay{
+		TenantID:  tenantID,
+		Date:      CivilDate(date),
+		Name:      name,
+		Country:   country,
+		CreatedBy: createdBy,
+		CreatedAt: createdAt,
+	}
+
+	if err := validate.Struct(day); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("NonInstructionalDay domain model instance not valid", errorMap, err)
+	}
+
+	return day, nil
+}
+
+// CivilDate keeps the year, month and day of t as written and drops the time of day
+func CivilDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"time"
+
+	timezoneEntities "github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+)
+
+// maxSkippedDays bounds the search for an instructional day, a calendar that is off for a whole year
+// is treated as having none
+const maxSkippedDays = 366
+
+// SchoolCalendar tells whether classes are held at an instant, from the non-instructional days of a
+// tenant read in the zone of the school. A nil calendar has classes every day.
+type SchoolCalendar struct {
+	days map[string]*NonInstructionalDay
+	loc  *time.Location
+}
+
+func NewSchoolCalendar(days []*NonInstructionalDay, loc *time.Location) *SchoolCalendar {
+	calendar := &SchoolCalendar{
+		days: make(map[string]*NonInstructionalDay, len(days)),
+		loc:  loc,
+	}
+	for _, day := range days {
+		calendar.days[day.Date.Format(DateLayout)] = day
+	}
+	return calendar
+}
+
+func (c *SchoolCalendar) Location() *time.Location {
+	if c == nil {
+		return time.UTC
+	}
+	return c.loc
+}
+
+// NonInstructionalDay returns the day t falls on in the zone of the school when it has no classes
+func (c *SchoolCalendar) NonInstructionalDay(t time.Time) *NonInstructionalDay {
+	if c == nil {
+		return nil
+	}
+	return c.days[t.In(c.loc).Format(DateLayout)]
+}
+
+func (c *SchoolCalendar) IsInstructional(t time.Time) bool {
+	return c.NonInstructionalDay(t) == nil
+}
+
+// NextInstructional moves t to the same wall clock time of the first instructional day at or after it,
+// keeping the location of t
+func (c *SchoolCalendar) NextInstructional(t time.Time) time.Time {
+	local := t.In(c.Location())
+	minuteOfDay := local.Hour()*60 + local.Minute()
+	rest := time.Duration(local.Second())*time.Second + time.Duration(local.Nanosecond())
+
+	next := t
+	for skipped := 1; skipped <= maxSkippedDays && !c.IsInstructional(next); skipped++ {
+		date := time.Date(local.Year(), local.Month(), local.Day()+skipped, 0, 0, 0, 0, time.UTC)
+		next = timezoneEntities.WallClock(date.Year(), date.Month(), date.Day(), minuteOfDay, c.Location()).Add(rest).In(t.Location())
+	}
+	return next
+}
+
+// NextWeekly is the next start of a weekly meeting, kept in the wall clock of the school, strictly after
+// `after` that falls on an instructional day. It is zero when none does within a year.
+func (c *SchoolCalendar) NextWeekly(weekday time.Weekday, minuteOfDay int, after time.Time) time.Time {
+	next := after
+	for week := 0; week <= maxSkippedDays/7; week++ {
+		next = timezoneEntities.NextWeekly(weekday, minuteOfDay, next, c.Location())
+		if c.IsInstructional(next) {
+			return next
+		}
+	}
+	return time.Time{}
+}
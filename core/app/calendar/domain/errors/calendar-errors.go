@@ -0,0 +1,42 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	NonInstructionalDayNotFoundError errors2.ErrorCode = "NON_INSTRUCTIONAL_DAY_NOT_FOUND"
+	UnsupportedHolidaySetError       errors2.ErrorCode = "UNSUPPORTED_HOLIDAY_SET"
+)
+
+func NewNonInstructionalDayNotFoundError(date string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    NonInstructionalDayNotFoundError.String(),
+			Message: "The day is not a non-instructional day of the school calendar",
+			Context: map[string]any{
+				"date": date,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(NonInstructionalDayNotFoundError.String()),
+		},
+	}
+}
+
+func NewUnsupportedHolidaySetError(country string, supported []string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    UnsupportedHolidaySetError.String(),
+			Message: "There is no holiday set for the country",
+			Context: map[string]any{
+				"country":   country,
+				"supported": supported,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(UnsupportedHolidaySetError.String()),
+		},
+	}
+}
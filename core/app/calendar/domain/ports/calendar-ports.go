@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+)
+
+type NonInstructionalDayRepository interface {
+	// Save creates or replaces the day with the same date
+	Save(ctx context.Context, day *entities.NonInstructionalDay) error
+	// AddMissing saves the days whose date is not in the calendar yet, in one transaction, and returns
+	// the ones it added. Days already in the calendar keep their name.
+	AddMissing(ctx context.Context, days []*entities.NonInstructionalDay) ([]*entities.NonInstructionalDay, error)
+	// Delete returns false when the date is not in the calendar
+	Delete(ctx context.Context, tenantID string, date time.Time) (bool, error)
+	// List returns the days from `from` to `to`, both included, ordered by date
+	List(ctx context.Context, tenantID string, from time.Time, to time.Time) ([]*entities.NonInstructionalDay, error)
+}
+
+// SchoolCalendarReader loads the calendar of a tenant in the zone of the school, other modules read it
+// to skip non-instructional days in schedules and deadlines
+type SchoolCalendarReader interface {
+	// SchoolCalendar holds the non-instructional days between the instants from and to
+	SchoolCalendar(ctx context.Context, tenantID string, from time.Time, to time.Time) (*entities.SchoolCalendar, error)
+}
@@ -0,0 +1,28 @@
+package delete_class_template_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type DeleteClassTemplateCommand struct {
+	TenantID   string `validate:"required"`
+	TemplateID string `validate:"required,uuid"`
+}
+
+func NewDeleteClassTemplateCommand(tenantID string, templateID string) (*DeleteClassTemplateCommand, error) {
+	command := &DeleteClassTemplateCommand{
+		TenantID:   tenantID,
+		TemplateID: templateID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
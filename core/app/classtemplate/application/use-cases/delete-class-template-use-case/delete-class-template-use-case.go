@@ -0,0 +1,33 @@
+package delete_class_template_use_case
+
+import (
+	"context"
+
+	classTemplateErrors "github.com/nahualventure/class-backend/core/app/classtemplate/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type DeleteClassTemplateUseCase struct {
+	templateRepo ports.ClassTemplateRepository
+}
+
+func NewDeleteClassTemplateUseCase(templateRepo ports.ClassTemplateRepository) *DeleteClassTemplateUseCase {
+	return &DeleteClassTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute deletes a template of the tenant, classes instantiated from it keep their setup. Templates
+// shared by other tenants are not the tenant's to delete and are reported as not found.
+func (uc *DeleteClassTemplateUseCase) Execute(ctx context.Context, cmd *DeleteClassTemplateCommand) error {
+	deleted, err := uc.templateRepo.Delete(ctx, cmd.TenantID, cmd.TemplateID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if !deleted {
+		return classTemplateErrors.NewClassTemplateNotFoundError(cmd.TemplateID)
+	}
+
+	return nil
+}
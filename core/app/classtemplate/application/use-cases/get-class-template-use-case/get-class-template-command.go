@@ -0,0 +1,28 @@
+package get_class_template_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetClassTemplateCommand struct {
+	TenantID   string `validate:"required"`
+	TemplateID string `validate:"required,uuid"`
+}
+
+func NewGetClassTemplateCommand(tenantID string, templateID string) (*GetClassTemplateCommand, error) {
+	command := &GetClassTemplateCommand{
+		TenantID:   tenantID,
+		TemplateID: templateID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,32 @@
+package get_class_template_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/entities"
+	classTemplateErrors "github.com/nahualventure/class-backend/core/app/classtemplate/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetClassTemplateUseCase struct {
+	templateRepo ports.ClassTemplateRepository
+}
+
+func NewGetClassTemplateUseCase(templateRepo ports.ClassTemplateRepository) *GetClassTemplateUseCase {
+	return &GetClassTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+func (uc *GetClassTemplateUseCase) Execute(ctx context.Context, cmd *GetClassTemplateCommand) (*entities.ClassTemplate, error) {
+	template, err := uc.templateRepo.FindByID(ctx, cmd.TenantID, cmd.TemplateID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if template == nil {
+		return nil, classTemplateErrors.NewClassTemplateNotFoundError(cmd.TemplateID)
+	}
+
+	return template, nil
+}
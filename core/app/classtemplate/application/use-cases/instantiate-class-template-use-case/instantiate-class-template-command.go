@@ -0,0 +1,35 @@
+package instantiate_class_template_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type InstantiateClassTemplateCommand struct {
+	TenantID   string `validate:"required"`
+	TemplateID string `validate:"required,uuid"`
+	ClassID    string `validate:"required,uuid"`
+	TermID     string `validate:"required,uuid"`
+	CreatedBy  string `validate:"required"`
+}
+
+func NewInstantiateClassTemplateCommand(tenantID string, templateID string, classID string, termID string,
+	createdBy string) (*InstantiateClassTemplateCommand, error) {
+	command := &InstantiateClassTemplateCommand{
+		TenantID:   tenantID,
+		TemplateID: templateID,
+		ClassID:    classID,
+		TermID:     termID,
+		CreatedBy:  createdBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
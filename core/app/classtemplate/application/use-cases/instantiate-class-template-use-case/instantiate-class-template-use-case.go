@@ -0,0 +1,73 @@
+package instantiate_class_template_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/entities"
+	classTemplateErrors "github.com/nahualventure/class-backend/core/app/classtemplate/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	transcriptErrors "github.com/nahualventure/class-backend/core/app/transcript/domain/errors"
+	transcriptPorts "github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+type InstantiateClassTemplateUseCase struct {
+	termRepo     transcriptPorts.TermRepository
+	setupStore   ports.ClassSetupStore
+	templateRepo ports.ClassTemplateRepository
+}
+
+func NewInstantiateClassTemplateUseCase(termRepo transcriptPorts.TermRepository, setupStore ports.ClassSetupStore,
+	templateRepo ports.ClassTemplateRepository) *InstantiateClassTemplateUseCase {
+	return &InstantiateClassTemplateUseCase{
+		termRepo:     termRepo,
+		setupStore:   setupStore,
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute sets a new class of the term up from the template. Assignments get new IDs and are due the
+// same time after the start of the term as in the class the template was saved from. Standards are
+// matched by framework and code with the ones of the tenant, the ones the tenant does not have are
+// left off the assignments and returned.
+func (uc *InstantiateClassTemplateUseCase) Execute(ctx context.Context, cmd *InstantiateClassTemplateCommand) (*entities.ClassSnapshot, []entities.StandardRef, error) {
+	template, err := uc.templateRepo.FindByID(ctx, cmd.TenantID, cmd.TemplateID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if template == nil {
+		return nil, nil, classTemplateErrors.NewClassTemplateNotFoundError(cmd.TemplateID)
+	}
+
+	term, err := uc.termRepo.FindByID(ctx, cmd.TenantID, cmd.TermID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if term == nil {
+		return nil, nil, transcriptErrors.NewTermNotFoundError(cmd.TermID)
+	}
+
+	current, err := uc.setupStore.Read(ctx, cmd.TenantID, cmd.ClassID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if !current.IsEmpty() {
+		return nil, nil, classTemplateErrors.NewClassNotEmptyError(cmd.ClassID)
+	}
+
+	standardIDs, err := uc.setupStore.ResolveStandards(ctx, cmd.TenantID, template.Standards())
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+
+	class, unresolved := template.Instantiate(cmd.TenantID, cmd.ClassID, term.StartsOn, func() string {
+		return uuid.New().String()
+	}, standardIDs)
+	if err := uc.setupStore.Write(ctx, class, cmd.CreatedBy); err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+
+	return class, unresolved, nil
+}
@@ -0,0 +1,26 @@
+package list_class_templates_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListClassTemplatesCommand struct {
+	TenantID string `validate:"required"`
+}
+
+func NewListClassTemplatesCommand(tenantID string) (*ListClassTemplatesCommand, error) {
+	command := &ListClassTemplatesCommand{
+		TenantID: tenantID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
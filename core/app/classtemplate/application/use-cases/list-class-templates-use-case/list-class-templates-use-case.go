@@ -0,0 +1,29 @@
+package list_class_templates_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListClassTemplatesUseCase struct {
+	templateRepo ports.ClassTemplateRepository
+}
+
+func NewListClassTemplatesUseCase(templateRepo ports.ClassTemplateRepository) *ListClassTemplatesUseCase {
+	return &ListClassTemplatesUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute lists the templates of the tenant along with the ones shared with every tenant
+func (uc *ListClassTemplatesUseCase) Execute(ctx context.Context, cmd *ListClassTemplatesCommand) ([]*entities.ClassTemplate, error) {
+	templates, err := uc.templateRepo.List(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return templates, nil
+}
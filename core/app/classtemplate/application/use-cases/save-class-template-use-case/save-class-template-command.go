@@ -0,0 +1,37 @@
+package save_class_template_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SaveClassTemplateCommand struct {
+	TenantID    string `validate:"required"`
+	ClassID     string `validate:"required,uuid"`
+	TermID      string `validate:"required,uuid"`
+	Name        string `validate:"required,max=200"`
+	Description string `validate:"max=2000"`
+	CreatedBy   string `validate:"required"`
+}
+
+func NewSaveClassTemplateCommand(tenantID string, classID string, termID string, name string, description string,
+	createdBy string) (*SaveClassTemplateCommand, error) {
+	command := &SaveClassTemplateCommand{
+		TenantID:    tenantID,
+		ClassID:     classID,
+		TermID:      termID,
+		Name:        name,
+		Description: description,
+		CreatedBy:   createdBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,58 @@
+package save_class_template_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	transcriptErrors "github.com/nahualventure/class-backend/core/app/transcript/domain/errors"
+	transcriptPorts "github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+type SaveClassTemplateUseCase struct {
+	termRepo     transcriptPorts.TermRepository
+	setupStore   ports.ClassSetupStore
+	templateRepo ports.ClassTemplateRepository
+}
+
+func NewSaveClassTemplateUseCase(termRepo transcriptPorts.TermRepository, setupStore ports.ClassSetupStore,
+	templateRepo ports.ClassTemplateRepository) *SaveClassTemplateUseCase {
+	return &SaveClassTemplateUseCase{
+		termRepo:     termRepo,
+		setupStore:   setupStore,
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute saves the setup of the class as a template. The class is read as it is now, due dates are
+// kept relative to the start of the term the class was taught in.
+func (uc *SaveClassTemplateUseCase) Execute(ctx context.Context, cmd *SaveClassTemplateCommand) (*entities.ClassTemplate, error) {
+	term, err := uc.termRepo.FindByID(ctx, cmd.TenantID, cmd.TermID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if term == nil {
+		return nil, transcriptErrors.NewTermNotFoundError(cmd.TermID)
+	}
+
+	snapshot, err := uc.setupStore.Read(ctx, cmd.TenantID, cmd.ClassID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	template, err := entities.NewClassTemplate(uuid.New().String(), cmd.TenantID, cmd.Name, cmd.Description,
+		entities.SetupFromSnapshot(snapshot, term.StartsOn), false, cmd.CreatedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.templateRepo.Create(ctx, template); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return template, nil
+}
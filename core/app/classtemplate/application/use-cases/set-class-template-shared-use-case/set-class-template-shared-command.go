@@ -0,0 +1,30 @@
+package set_class_template_shared_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SetClassTemplateSharedCommand struct {
+	TenantID   string `validate:"required"`
+	TemplateID string `validate:"required,uuid"`
+	Shared     bool
+}
+
+func NewSetClassTemplateSharedCommand(tenantID string, templateID string, shared bool) (*SetClassTemplateSharedCommand, error) {
+	command := &SetClassTemplateSharedCommand{
+		TenantID:   tenantID,
+		TemplateID: templateID,
+		Shared:     shared,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,34 @@
+package set_class_template_shared_use_case
+
+import (
+	"context"
+
+	classTemplateErrors "github.com/nahualventure/class-backend/core/app/classtemplate/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetClassTemplateSharedUseCase struct {
+	templateRepo ports.ClassTemplateRepository
+}
+
+func NewSetClassTemplateSharedUseCase(templateRepo ports.ClassTemplateRepository) *SetClassTemplateSharedUseCase {
+	return &SetClassTemplateSharedUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute shares the template of the tenant with every tenant, or stops sharing it. It is run by
+// platform admins from the command line, tenants have no permission to share their templates.
+// Classes already instantiated from a template keep their setup when it stops being shared.
+func (uc *SetClassTemplateSharedUseCase) Execute(ctx context.Context, cmd *SetClassTemplateSharedCommand) error {
+	found, err := uc.templateRepo.SetShared(ctx, cmd.TenantID, cmd.TemplateID, cmd.Shared)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if !found {
+		return classTemplateErrors.NewClassTemplateNotFoundError(cmd.TemplateID)
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// StandardRef names a standard by its framework and code, templates are shared across tenants and
+// every tenant has its own IDs for the same standard
+type StandardRef struct {
+	Framework string
+	Code      string
+}
+
+// TemplateMeeting is a weekly meeting of the class, minutes are counted from midnight in the local
+// time of the tenant
+type TemplateMeeting struct {
+	Weekday     time.Weekday
+	StartMinute int
+	EndMinute   int
+}
+
+// TemplateAssignment keeps the due date of an assignment as the time from the start of the term of the
+// class it was saved from, so it lands on the same week and weekday of the term it is instantiated in
+type TemplateAssignment struct {
+	DueAfter             *time.Duration
+	PenaltyPercentPerDay float64
+	CutoffDays           int
+	Standards            []StandardRef
+}
+
+// ClassSetup is what a template copies from a class: its settings, weekly schedule and assignments.
+// Students, enrollments, submissions and grades are never part of it.
+type ClassSetup struct {
+	RequiresApproval bool
+	Capacity         *int
+	Meetings         []TemplateMeeting
+	Assignments      []TemplateAssignment
+}
+
+// ClassTemplate is the setup of a class saved to start new classes from. Shared templates are listed
+// and instantiated by every tenant, only platform admins share them.
+type ClassTemplate struct {
+	ID          string `validate:"required,uuid"`
+	TenantID    string `validate:"required"`
+	Name        string `validate:"required,max=200"`
+	Description string `validate:"max=2000"`
+	Setup       ClassSetup
+	Shared      bool
+	CreatedBy   string `validate:"required"`
+	CreatedAt   time.Time
+}
+
+func NewClassTemplate(id string, tenantID string, name string, description string, setup ClassSetup, shared bool, createdBy string,
+	createdAt time.Time) (*ClassTemplate, error) {
+	if setup.Meetings == nil {
+		setup.Meetings = []TemplateMeeting{}
+	}
+	if setup.Assignments == nil {
+		setup.Assignments = []TemplateAssignment{}
+	}
+	template := &ClassTemplate{
+		ID:          id,
+		TenantID:    tenantID,
+		Name:        name,
+		Description: description,
+		Setup:       setup,
+		Shared:      shared,
+		CreatedBy:   createdBy,
+		CreatedAt:   createdAt,
+	}
+
+	if err := validate.Struct(template); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("ClassTemplate domain model instance not valid", errorMap, err)
+	}
+
+	return template, nil
+}
+
+// Standards returns every standard the assignments of the template assess, once each
+func (t *ClassTemplate) Standards() []StandardRef {
+	seen := make(map[StandardRef]bool)
+	var refs []StandardRef
+	for _, assignment := range t.Setup.Assignments {
+		for _, ref := range assignment.Standards {
+			if !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
+// ClassAssignment is an assignment of a class as templates read and write it. DueAt is nil when the
+// assignment has no due date.
+type ClassAssignment struct {
+	AssignmentID         string
+	DueAt                *time.Time
+	PenaltyPercentPerDay float64
+	CutoffDays           int
+	Standards            []StandardRef
+	StandardIDs          []string
+}
+
+// ClassSnapshot is the setup of a class as stored by the grading, enrollment and standards modules
+type ClassSnapshot struct {
+	TenantID         string
+	ClassID          string
+	RequiresApproval bool
+	Capacity         *int
+	Meetings         []TemplateMeeting
+	Assignments      []ClassAssignment
+}
+
+// IsEmpty reports whether nothing was set up for the class yet, templates are only instantiated into
+// empty classes
+func (s *ClassSnapshot) IsEmpty() bool {
+	return !s.RequiresApproval && s.Capacity == nil && len(s.Meetings) == 0 && len(s.Assignments) == 0
+}
+
+// SetupFromSnapshot turns the class into a setup, due dates become the time since termStartsOn.
+// Assignments are ordered by due date, the ones without one last.
+func SetupFromSnapshot(snapshot *ClassSnapshot, termStartsOn time.Time) ClassSetup {
+	assignments := append([]ClassAssignment(nil), snapshot.Assignments...)
+	sort.SliceStable(assignments, func(i, j int) bool {
+		if assignments[i].DueAt == nil || assignments[j].DueAt == nil {
+			return assignments[j].DueAt == nil && assignments[i].DueAt != nil
+		}
+		return assignments[i].DueAt.Before(*assignments[j].DueAt)
+	})
+
+	setup := ClassSetup{
+		RequiresApproval: snapshot.RequiresApproval,
+		Capacity:         snapshot.Capacity,
+		Meetings:         append([]TemplateMeeting{}, snapshot.Meetings...),
+		Assignments:      make([]TemplateAssignment, 0, len(assignments)),
+	}
+	for _, assignment := range assignments {
+		templateAssignment := TemplateAssignment{
+			PenaltyPercentPerDay: assignment.PenaltyPercentPerDay,
+			CutoffDays:           assignment.CutoffDays,
+			Standards:            append([]StandardRef{}, assignment.Standards...),
+		}
+		if assignment.DueAt != nil {
+			dueAfter := assignment.DueAt.Sub(termStartsOn)
+			templateAssignment.DueAfter = &dueAfter
+		}
+		setup.Assignments = append(setup.Assignments, templateAssignment)
+	}
+	return setup
+}
+
+// Instantiate lays the template out on a class of the term starting on termStartsOn. Every assignment
+// gets an ID from newID, standardIDs maps the standards of the template to the ones of the tenant
+// and the standards it has no ID for are returned as unresolved.
+func (t *ClassTemplate) Instantiate(tenantID string, classID string, termStartsOn time.Time, newID func() string,
+	standardIDs map[StandardRef]string) (*ClassSnapshot, []StandardRef) {
+	class := &ClassSnapshot{
+		TenantID:         tenantID,
+		ClassID:          classID,
+		RequiresApproval: t.Setup.RequiresApproval,
+		Capacity:         t.Setup.Capacity,
+		Meetings:         append([]TemplateMeeting{}, t.Setup.Meetings...),
+		Assignments:      make([]ClassAssignment, 0, len(t.Setup.Assignments)),
+	}
+
+	unresolved := make(map[StandardRef]bool)
+	var missing []StandardRef
+	for _, templateAssignment := range t.Setup.Assignments {
+		assignment := ClassAssignment{
+			AssignmentID:         newID(),
+			PenaltyPercentPerDay: templateAssignment.PenaltyPercentPerDay,
+			CutoffDays:           templateAssignment.CutoffDays,
+			Standards:            []StandardRef{},
+			StandardIDs:          []string{},
+		}
+		if templateAssignment.DueAfter != nil {
+			dueAt := termStartsOn.Add(*templateAssignment.DueAfter)
+			assignment.DueAt = &dueAt
+		}
+		for _, ref := range templateAssignment.Standards {
+			standardID, ok := standardIDs[ref]
+			if !ok {
+				if !unresolved[ref] {
+					unresolved[ref] = true
+					missing = append(missing, ref)
+				}
+				continue
+			}
+			assignment.Standards = append(assignment.Standards, ref)
+			assignment.StandardIDs = append(assignment.StandardIDs, standardID)
+		}
+		class.Assignments = append(class.Assignments, assignment)
+	}
+
+	return class, missing
+}
@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ClassTemplateNotFoundError errors2.ErrorCode = "CLASS_TEMPLATE_NOT_FOUND"
+	// ClassNotEmptyError is returned when a template is instantiated into a class that already has
+	// settings, meetings or assignments
+	ClassNotEmptyError errors2.ErrorCode = "CLASS_NOT_EMPTY"
+)
+
+func NewClassTemplateNotFoundError(templateID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ClassTemplateNotFoundError.String(),
+			Message: "Class template not found",
+			Context: map[string]any{
+				"template_id": templateID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ClassTemplateNotFoundError.String()),
+		},
+	}
+}
+
+func NewClassNotEmptyError(classID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ClassNotEmptyError.String(),
+			Message: "The class was already set up, templates are only instantiated into new classes",
+			Context: map[string]any{
+				"class_id": classID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ClassNotEmptyError.String()),
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/entities"
+)
+
+type ClassTemplateRepository interface {
+	Create(ctx context.Context, template *entities.ClassTemplate) error
+	// FindByID returns the template of the tenant or a shared template of any tenant, nil otherwise
+	FindByID(ctx context.Context, tenantID string, templateID string) (*entities.ClassTemplate, error)
+	// List returns the templates of the tenant and the ones shared by every tenant, newest first
+	List(ctx context.Context, tenantID string) ([]*entities.ClassTemplate, error)
+	// Delete returns false when the tenant has no such template, shared templates of other tenants
+	// are never deleted
+	Delete(ctx context.Context, tenantID string, templateID string) (bool, error)
+	// SetShared returns false when the tenant has no such template
+	SetShared(ctx context.Context, tenantID string, templateID string, shared bool) (bool, error)
+}
+
+// ClassSetupStore reads and writes the setup of a class where the grading, enrollment and standards
+// modules keep it
+type ClassSetupStore interface {
+	// Read returns the setup of the class, empty when nothing was set up for it
+	Read(ctx context.Context, tenantID string, classID string) (*entities.ClassSnapshot, error)
+	// ResolveStandards returns the IDs of the standards of the tenant among refs, unknown standards are
+	// left out
+	ResolveStandards(ctx context.Context, tenantID string, refs []entities.StandardRef) (map[entities.StandardRef]string, error)
+	// Write sets the class up in one transaction, it fails with CLASS_NOT_EMPTY when the class was set
+	// up in the meantime
+	Write(ctx context.Context, class *entities.ClassSnapshot, createdBy string) error
+}
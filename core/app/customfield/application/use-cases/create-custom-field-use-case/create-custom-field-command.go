@@ -0,0 +1,53 @@
+package create_custom_field_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CreateCustomFieldCommand struct {
+	TenantID string             `validate:"required"`
+	ActorID  string             `validate:"required"`
+	Entity   entities.Entity    `validate:"required,oneof=user class"`
+	Key      string             `validate:"required"`
+	Label    string             `validate:"required,max=200"`
+	Type     entities.FieldType `validate:"required,oneof=text number boolean date select"`
+	Options  []string           `validate:"max=100,unique,dive,required,max=200"`
+	Required bool
+}
+
+func NewCreateCustomFieldCommand(tenantID string, actorID string, entity string, key string, label string,
+	fieldType string, options []string, required bool) (*CreateCustomFieldCommand, error) {
+	command := &CreateCustomFieldCommand{
+		TenantID: tenantID,
+		ActorID:  actorID,
+		Entity:   entities.Entity(entity),
+		Key:      key,
+		Label:    label,
+		Type:     entities.FieldType(fieldType),
+		Options:  options,
+		Required: required,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	errorMap := make(map[string]any)
+	if err := entities.CheckKey(key); err != nil {
+		errorMap["key"] = err.Error()
+	}
+	if err := command.Type.CheckOptions(options); err != nil {
+		errorMap["options"] = err.Error()
+	}
+	if len(errorMap) > 0 {
+		return nil, errors.NewValidationError("Invalid custom field", errorMap, nil)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,36 @@
+package create_custom_field_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	customFieldErrors "github.com/nahualventure/class-backend/core/app/customfield/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type CreateCustomFieldUseCase struct {
+	fields ports.CustomFieldRepository
+}
+
+func NewCreateCustomFieldUseCase(fields ports.CustomFieldRepository) *CreateCustomFieldUseCase {
+	return &CreateCustomFieldUseCase{
+		fields: fields,
+	}
+}
+
+// Execute defines the field, records get its values the next time their attributes are set
+func (uc *CreateCustomFieldUseCase) Execute(ctx context.Context, cmd *CreateCustomFieldCommand) (*entities.CustomField, error) {
+	field := entities.NewCustomField(cmd.TenantID, cmd.Entity, cmd.Key, cmd.Label, cmd.Type, cmd.Options, cmd.Required,
+		cmd.ActorID, time.Now().UTC())
+
+	created, err := uc.fields.Create(ctx, field)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !created {
+		return nil, customFieldErrors.NewCustomFieldKeyTakenError(string(cmd.Entity), cmd.Key)
+	}
+	return field, nil
+}
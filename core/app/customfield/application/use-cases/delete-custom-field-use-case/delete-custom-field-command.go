@@ -0,0 +1,28 @@
+package delete_custom_field_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type DeleteCustomFieldCommand struct {
+	TenantID string `validate:"required"`
+	FieldID  string `validate:"required,uuid"`
+}
+
+func NewDeleteCustomFieldCommand(tenantID string, fieldID string) (*DeleteCustomFieldCommand, error) {
+	command := &DeleteCustomFieldCommand{
+		TenantID: tenantID,
+		FieldID:  fieldID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,31 @@
+package delete_custom_field_use_case
+
+import (
+	"context"
+
+	customFieldErrors "github.com/nahualventure/class-backend/core/app/customfield/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type DeleteCustomFieldUseCase struct {
+	fields ports.CustomFieldRepository
+}
+
+func NewDeleteCustomFieldUseCase(fields ports.CustomFieldRepository) *DeleteCustomFieldUseCase {
+	return &DeleteCustomFieldUseCase{
+		fields: fields,
+	}
+}
+
+// Execute deletes the field with its values, a field defined again with the same key starts empty
+func (uc *DeleteCustomFieldUseCase) Execute(ctx context.Context, cmd *DeleteCustomFieldCommand) error {
+	deleted, err := uc.fields.Delete(ctx, cmd.TenantID, cmd.FieldID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if !deleted {
+		return customFieldErrors.NewCustomFieldNotFoundError(cmd.FieldID)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package get_attributes_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetAttributesCommand struct {
+	TenantID string          `validate:"required"`
+	Entity   entities.Entity `validate:"required,oneof=user class"`
+	EntityID string          `validate:"required,uuid"`
+}
+
+func NewGetAttributesCommand(tenantID string, entity entities.Entity, entityID string) (*GetAttributesCommand, error) {
+	command := &GetAttributesCommand{
+		TenantID: tenantID,
+		Entity:   entity,
+		EntityID: entityID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
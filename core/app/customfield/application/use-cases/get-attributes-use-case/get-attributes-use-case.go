@@ -0,0 +1,31 @@
+package get_attributes_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetAttributesUseCase struct {
+	attributes ports.AttributeRepository
+}
+
+func NewGetAttributesUseCase(attributes ports.AttributeRepository) *GetAttributesUseCase {
+	return &GetAttributesUseCase{
+		attributes: attributes,
+	}
+}
+
+// Execute returns the attributes of the record, records without attributes have an empty map
+func (uc *GetAttributesUseCase) Execute(ctx context.Context, cmd *GetAttributesCommand) (entities.Attributes, error) {
+	attributes, err := uc.attributes.Get(ctx, cmd.TenantID, cmd.Entity, cmd.EntityID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if attributes == nil {
+		attributes = entities.Attributes{}
+	}
+	return attributes, nil
+}
@@ -0,0 +1,30 @@
+package list_custom_fields_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListCustomFieldsCommand struct {
+	TenantID string `validate:"required"`
+	// Entity keeps the fields of one entity, every field when empty
+	Entity entities.Entity `validate:"omitempty,oneof=user class"`
+}
+
+func NewListCustomFieldsCommand(tenantID string, entity string) (*ListCustomFieldsCommand, error) {
+	command := &ListCustomFieldsCommand{
+		TenantID: tenantID,
+		Entity:   entities.Entity(entity),
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,27 @@
+package list_custom_fields_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListCustomFieldsUseCase struct {
+	fields ports.CustomFieldRepository
+}
+
+func NewListCustomFieldsUseCase(fields ports.CustomFieldRepository) *ListCustomFieldsUseCase {
+	return &ListCustomFieldsUseCase{
+		fields: fields,
+	}
+}
+
+func (uc *ListCustomFieldsUseCase) Execute(ctx context.Context, cmd *ListCustomFieldsCommand) ([]*entities.CustomField, error) {
+	fields, err := uc.fields.List(ctx, cmd.TenantID, cmd.Entity)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return fields, nil
+}
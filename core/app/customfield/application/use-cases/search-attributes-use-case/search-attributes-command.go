@@ -0,0 +1,43 @@
+package search_attributes_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SearchAttributesCommand struct {
+	TenantID string          `validate:"required"`
+	Entity   entities.Entity `validate:"required,oneof=user class"`
+	// Match holds the values to look for by key as sent, they are parsed with the type of their field
+	Match map[string]string `validate:"required,min=1,max=10"`
+	Page  *pagination.PageRequest
+}
+
+func NewSearchAttributesCommand(tenantID string, entity entities.Entity, match map[string]string, pageSize int,
+	cursor string) (*SearchAttributesCommand, error) {
+	page, err := pagination.NewPageRequest(pageSize, cursor, "", nil, pagination.OrderBy{
+		Field:     "updated_at",
+		Direction: pagination.Descending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	command := &SearchAttributesCommand{
+		TenantID: tenantID,
+		Entity:   entity,
+		Match:    match,
+		Page:     page,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,60 @@
+package search_attributes_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type SearchAttributesUseCase struct {
+	fields     ports.CustomFieldRepository
+	attributes ports.AttributeRepository
+}
+
+func NewSearchAttributesUseCase(fields ports.CustomFieldRepository, attributes ports.AttributeRepository) *SearchAttributesUseCase {
+	return &SearchAttributesUseCase{
+		fields:     fields,
+		attributes: attributes,
+	}
+}
+
+// Execute pages the records holding every value of the command, values are compared once parsed
+// with the type of their field so "7" finds the number 7
+func (uc *SearchAttributesUseCase) Execute(ctx context.Context, cmd *SearchAttributesCommand) (*pagination.Page[*entities.Record], error) {
+	fields, err := uc.fields.List(ctx, cmd.TenantID, cmd.Entity)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	byKey := make(map[string]*entities.CustomField, len(fields))
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	match := make(entities.Attributes, len(cmd.Match))
+	problems := make(map[string]any)
+	for key, text := range cmd.Match {
+		field, ok := byKey[key]
+		if !ok {
+			problems[key] = "Unknown custom field"
+			continue
+		}
+		value, err := field.Parse(text)
+		if err != nil {
+			problems[key] = err.Error()
+			continue
+		}
+		match[key] = value
+	}
+	if len(problems) > 0 {
+		return nil, errors.NewValidationError("Invalid attribute search", problems, nil)
+	}
+
+	page, err := uc.attributes.Search(ctx, cmd.TenantID, cmd.Entity, match, cmd.Page)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return page, nil
+}
@@ -0,0 +1,33 @@
+package set_attributes_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SetAttributesCommand struct {
+	TenantID   string          `validate:"required"`
+	Entity     entities.Entity `validate:"required,oneof=user class"`
+	EntityID   string          `validate:"required,uuid"`
+	Attributes map[string]any  `validate:"max=100"`
+}
+
+func NewSetAttributesCommand(tenantID string, entity entities.Entity, entityID string, attributes map[string]any) (*SetAttributesCommand, error) {
+	command := &SetAttributesCommand{
+		TenantID:   tenantID,
+		Entity:     entity,
+		EntityID:   entityID,
+		Attributes: attributes,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,41 @@
+package set_attributes_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetAttributesUseCase struct {
+	fields     ports.CustomFieldRepository
+	attributes ports.AttributeRepository
+}
+
+func NewSetAttributesUseCase(fields ports.CustomFieldRepository, attributes ports.AttributeRepository) *SetAttributesUseCase {
+	return &SetAttributesUseCase{
+		fields:     fields,
+		attributes: attributes,
+	}
+}
+
+// Execute replaces the attributes of the record once every value suits its field, the error lists
+// the problem of each attribute that does not
+func (uc *SetAttributesUseCase) Execute(ctx context.Context, cmd *SetAttributesCommand) (*entities.Record, error) {
+	fields, err := uc.fields.List(ctx, cmd.TenantID, cmd.Entity)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	attributes, problems := entities.CheckAttributes(fields, cmd.Attributes)
+	if len(problems) > 0 {
+		return nil, errors.NewValidationError("Invalid attributes", problems, nil)
+	}
+
+	record, err := uc.attributes.Set(ctx, cmd.TenantID, cmd.Entity, cmd.EntityID, attributes)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return record, nil
+}
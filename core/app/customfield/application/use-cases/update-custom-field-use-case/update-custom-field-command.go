@@ -0,0 +1,35 @@
+package update_custom_field_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type UpdateCustomFieldCommand struct {
+	TenantID string   `validate:"required"`
+	FieldID  string   `validate:"required,uuid"`
+	Label    string   `validate:"required,max=200"`
+	Options  []string `validate:"max=100,unique,dive,required,max=200"`
+	Required bool
+}
+
+func NewUpdateCustomFieldCommand(tenantID string, fieldID string, label string, options []string,
+	required bool) (*UpdateCustomFieldCommand, error) {
+	command := &UpdateCustomFieldCommand{
+		TenantID: tenantID,
+		FieldID:  fieldID,
+		Label:    label,
+		Options:  options,
+		Required: required,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,41 @@
+package update_custom_field_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	customFieldErrors "github.com/nahualventure/class-backend/core/app/customfield/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type UpdateCustomFieldUseCase struct {
+	fields ports.CustomFieldRepository
+}
+
+func NewUpdateCustomFieldUseCase(fields ports.CustomFieldRepository) *UpdateCustomFieldUseCase {
+	return &UpdateCustomFieldUseCase{
+		fields: fields,
+	}
+}
+
+// Execute replaces the label, options and requirement of the field, its key and type stay
+func (uc *UpdateCustomFieldUseCase) Execute(ctx context.Context, cmd *UpdateCustomFieldCommand) (*entities.CustomField, error) {
+	field, err := uc.fields.FindByID(ctx, cmd.TenantID, cmd.FieldID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if field == nil {
+		return nil, customFieldErrors.NewCustomFieldNotFoundError(cmd.FieldID)
+	}
+	if err := field.Type.CheckOptions(cmd.Options); err != nil {
+		return nil, errors.NewValidationError("Invalid custom field", map[string]any{"options": err.Error()}, nil)
+	}
+
+	field.Update(cmd.Label, cmd.Options, cmd.Required, time.Now().UTC())
+	if err := uc.fields.Save(ctx, field); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return field, nil
+}
@@ -0,0 +1,226 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Entity is the kind of record custom fields are defined on
+type Entity string
+
+const (
+	EntityUser  Entity = "user"
+	EntityClass Entity = "class"
+)
+
+// FieldType is the type of the values of a custom field
+type FieldType string
+
+const (
+	TypeText    FieldType = "text"
+	TypeNumber  FieldType = "number"
+	TypeBoolean FieldType = "boolean"
+	// TypeDate values are calendar days, "2025-03-01"
+	TypeDate FieldType = "date"
+	// TypeSelect values are one of the options of the field
+	TypeSelect FieldType = "select"
+)
+
+// MaxTextLength bounds the values of text fields, in characters
+const MaxTextLength = 1000
+
+// keyPattern keeps keys usable as JSON names and query parameters without escaping
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// CheckKey tells why key cannot name a field, nil when it can
+func CheckKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return errors.New("Must start with a lowercase letter followed by up to 63 lowercase letters, digits or underscores")
+	}
+	return nil
+}
+
+// CheckOptions tells why options do not suit a field of the type, select fields need options and the
+// other types take none
+func (t FieldType) CheckOptions(options []string) error {
+	if t == TypeSelect && len(options) == 0 {
+		return errors.New("Select fields need at least one option")
+	}
+	if t != TypeSelect && len(options) > 0 {
+		return errors.New("Only select fields take options")
+	}
+	return nil
+}
+
+// CustomField is a field a tenant adds to its users or classes, such as the district ID of students.
+// Keys are unique per tenant and entity, and their values are kept in the attributes of the records.
+type CustomField struct {
+	ID       string
+	TenantID string
+	Entity   Entity
+	// Key is the name of the field in the attributes, it never changes
+	Key   string
+	Label string
+	// Type never changes, values stored with another type would no longer be valid
+	Type     FieldType
+	Options  []string
+	Required bool
+	// CreatedBy is the user who defined the field
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewCustomField(tenantID string, entity Entity, key string, label string, fieldType FieldType, options []string,
+	required bool, createdBy string, now time.Time) *CustomField {
+	return &CustomField{
+		ID:        uuid.NewString(),
+		TenantID:  tenantID,
+		Entity:    entity,
+		Key:       key,
+		Label:     label,
+		Type:      fieldType,
+		Options:   options,
+		Required:  required,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Update replaces how the field is shown and checked. Stored values the new options or requirement
+// no longer allow are kept until the attributes of their record are set again.
+func (f *CustomField) Update(label string, options []string, required bool, now time.Time) {
+	f.Label = label
+	f.Options = options
+	f.Required = required
+	f.UpdatedAt = now
+}
+
+// Normalize checks a value of the field as decoded from JSON and returns it the way it is stored:
+// numbers as float64, dates as "2006-01-02" and the rest as they are. The error message says what
+// the value should be.
+func (f *CustomField) Normalize(value any) (any, error) {
+	switch f.Type {
+	case TypeText:
+		text, ok := value.(string)
+		if !ok {
+			return nil, errors.New("Must be a string")
+		}
+		if utf8.RuneCountInString(text) > MaxTextLength {
+			return nil, fmt.Errorf("Must be at most %d characters long", MaxTextLength)
+		}
+		return text, nil
+	case TypeNumber:
+		var number float64
+		switch typed := value.(type) {
+		case float64:
+			number = typed
+		case int:
+			number = float64(typed)
+		default:
+			return nil, errors.New("Must be a number")
+		}
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, errors.New("Must be a number")
+		}
+		return number, nil
+	case TypeBoolean:
+		boolean, ok := value.(bool)
+		if !ok {
+			return nil, errors.New("Must be true or false")
+		}
+		return boolean, nil
+	case TypeDate:
+		text, ok := value.(string)
+		if !ok {
+			return nil, errors.New("Must be a date such as 2025-03-01")
+		}
+		day, err := time.Parse(time.DateOnly, text)
+		if err != nil {
+			return nil, errors.New("Must be a date such as 2025-03-01")
+		}
+		return day.Format(time.DateOnly), nil
+	case TypeSelect:
+		text, ok := value.(string)
+		if !ok || !slices.Contains(f.Options, text) {
+			return nil, fmt.Errorf("Must be one of %s", strings.Join(f.Options, ", "))
+		}
+		return text, nil
+	}
+	return nil, fmt.Errorf("Field of unknown type %s", f.Type)
+}
+
+// Parse reads a value of the field from text, such as a query parameter, and normalizes it
+func (f *CustomField) Parse(text string) (any, error) {
+	switch f.Type {
+	case TypeNumber:
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, errors.New("Must be a number")
+		}
+		return f.Normalize(number)
+	case TypeBoolean:
+		boolean, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, errors.New("Must be true or false")
+		}
+		return boolean, nil
+	}
+	return f.Normalize(text)
+}
+
+// Attributes are the values of the custom fields of a record by key
+type Attributes map[string]any
+
+// CheckAttributes normalizes the attributes of a record against the fields of its entity. The
+// returned map has an error message per key that is unknown, of the wrong type or required and
+// missing, it is empty when the attributes are valid. Null values are left out like missing ones.
+func CheckAttributes(fields []*CustomField, attributes map[string]any) (Attributes, map[string]any) {
+	normalized := make(Attributes, len(attributes))
+	problems := make(map[string]any)
+	byKey := make(map[string]*CustomField, len(fields))
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	for key, value := range attributes {
+		field, ok := byKey[key]
+		if !ok {
+			problems[key] = "Unknown custom field"
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		stored, err := field.Normalize(value)
+		if err != nil {
+			problems[key] = err.Error()
+			continue
+		}
+		normalized[key] = stored
+	}
+
+	for _, field := range fields {
+		if _, set := normalized[field.Key]; field.Required && !set && problems[field.Key] == nil {
+			problems[field.Key] = "Required"
+		}
+	}
+	return normalized, problems
+}
+
+// Record is the attributes of a user or class
+type Record struct {
+	EntityID   string
+	Attributes Attributes
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	CustomFieldNotFoundError errors2.ErrorCode = "CUSTOM_FIELD_NOT_FOUND"
+	// CustomFieldKeyTakenError refuses a second field with the key of a field of the same entity
+	CustomFieldKeyTakenError errors2.ErrorCode = "CUSTOM_FIELD_KEY_TAKEN"
+)
+
+func NewCustomFieldNotFoundError(fieldID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    CustomFieldNotFoundError.String(),
+			Message: "Custom field not found",
+			Context: map[string]any{
+				"field_id": fieldID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(CustomFieldNotFoundError.String()),
+		},
+	}
+}
+
+func NewCustomFieldKeyTakenError(entity string, key string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    CustomFieldKeyTakenError.String(),
+			Message: "The entity already has a custom field with this key",
+			Context: map[string]any{
+				"entity": entity,
+				"key":    key,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(CustomFieldKeyTakenError.String()),
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type CustomFieldRepository interface {
+	// Create stores a new field, it reports false without storing it when the entity of the tenant
+	// already has a field with its key
+	Create(ctx context.Context, field *entities.CustomField) (bool, error)
+	// Save replaces a stored field
+	Save(ctx context.Context, field *entities.CustomField) error
+	// FindByID returns the field, nil when the tenant has no such field
+	FindByID(ctx context.Context, tenantID string, fieldID string) (*entities.CustomField, error)
+	// List returns the fields of the entity ordered by key, of every entity when entity is empty
+	List(ctx context.Context, tenantID string, entity entities.Entity) ([]*entities.CustomField, error)
+	// Delete removes the field and its values from the attributes of every record of the tenant, it
+	// reports whether there was such a field
+	Delete(ctx context.Context, tenantID string, fieldID string) (bool, error)
+}
+
+// AttributeRepository keeps the attributes of the users and classes of every tenant
+type AttributeRepository interface {
+	// Get returns the attributes of the record, empty when none were set
+	Get(ctx context.Context, tenantID string, entity entities.Entity, entityID string) (entities.Attributes, error)
+	// Set replaces the attributes of the record
+	Set(ctx context.Context, tenantID string, entity entities.Entity, entityID string, attributes entities.Attributes) (*entities.Record, error)
+	// Search pages the records of the entity whose attributes hold every value of match, the
+	// records updated last first
+	Search(ctx context.Context, tenantID string, entity entities.Entity, match entities.Attributes,
+		page *pagination.PageRequest) (*pagination.Page[*entities.Record], error)
+}
@@ -0,0 +1,28 @@
+package get_class_summary_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetClassSummaryCommand struct {
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"required,uuid"`
+}
+
+func NewGetClassSummaryCommand(tenantID string, classID string) (*GetClassSummaryCommand, error) {
+	command := &GetClassSummaryCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,33 @@
+package get_class_summary_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/entities"
+	dashboardErrors "github.com/nahualventure/class-backend/core/app/dashboard/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetClassSummaryUseCase struct {
+	summaries ports.ClassSummaryReader
+}
+
+func NewGetClassSummaryUseCase(summaries ports.ClassSummaryReader) *GetClassSummaryUseCase {
+	return &GetClassSummaryUseCase{
+		summaries: summaries,
+	}
+}
+
+func (uc *GetClassSummaryUseCase) Execute(ctx context.Context, cmd *GetClassSummaryCommand) (*entities.ClassSummary, error) {
+	summary, err := uc.summaries.FindClassSummary(ctx, cmd.TenantID, cmd.ClassID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if summary == nil {
+		return nil, dashboardErrors.NewClassSummaryNotFoundError(cmd.ClassID)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,47 @@
+package get_metric_series_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	MaxDailyPeriods  = 90
+	MaxWeeklyPeriods = 52
+)
+
+var validate = validator.New()
+
+type GetMetricSeriesCommand struct {
+	TenantID    string `validate:"required"`
+	Metric      string `validate:"required,oneof=daily_active_users signups enrollments"`
+	Granularity string `validate:"required,oneof=day week"`
+	Periods     int    `validate:"required,min=1"`
+}
+
+func NewGetMetricSeriesCommand(tenantID string, metric string, granularity string, periods int) (*GetMetricSeriesCommand, error) {
+	command := &GetMetricSeriesCommand{
+		TenantID:    tenantID,
+		Metric:      metric,
+		Granularity: granularity,
+		Periods:     periods,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	limit := MaxDailyPeriods
+	if granularity == "week" {
+		limit = MaxWeeklyPeriods
+	}
+	if periods > limit {
+		return nil, errors.NewValidationError("Invalid metric series request", map[string]any{
+			"periods": "Too many periods for this granularity",
+		}, nil)
+	}
+
+	return command, nil
+}
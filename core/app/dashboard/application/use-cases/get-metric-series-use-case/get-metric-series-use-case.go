@@ -0,0 +1,35 @@
+package get_metric_series_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetMetricSeriesUseCase struct {
+	metrics ports.MetricsReader
+}
+
+func NewGetMetricSeriesUseCase(metrics ports.MetricsReader) *GetMetricSeriesUseCase {
+	return &GetMetricSeriesUseCase{
+		metrics: metrics,
+	}
+}
+
+func (uc *GetMetricSeriesUseCase) Execute(ctx context.Context, cmd *GetMetricSeriesCommand) (*entities.MetricSeries, error) {
+	definition := entities.MetricDefinitions[cmd.Metric]
+	granularity := entities.Granularity(cmd.Granularity)
+
+	until := time.Now()
+	from := entities.SeriesStart(granularity, cmd.Periods, until)
+
+	values, err := uc.metrics.DailyValues(ctx, cmd.TenantID, cmd.Metric, from, entities.TruncateDay(until))
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return entities.NewMetricSeries(definition, granularity, cmd.Periods, until, values), nil
+}
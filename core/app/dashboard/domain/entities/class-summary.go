@@ -0,0 +1,35 @@
+package entities
+
+import "time"
+
+// ClassSummary is the dashboard read model of a class, maintained by the class summary projection
+type ClassSummary struct {
+	ClassID        string
+	TenantID       string
+	RosterCount    int
+	AverageGrade   *float64 // nil until the first grade is recorded
+	AttendanceRate *float64 // nil until the first attendance is recorded, 0..1
+	UpdatedAt      time.Time
+}
+
+func NewClassSummary(classID string, tenantID string, rosterCount int, gradeSum float64, gradeCount int,
+	attendancePresent int, attendanceTotal int, updatedAt time.Time) *ClassSummary {
+	summary := &ClassSummary{
+		ClassID:     classID,
+		TenantID:    tenantID,
+		RosterCount: rosterCount,
+		UpdatedAt:   updatedAt,
+	}
+
+	if gradeCount > 0 {
+		average := gradeSum / float64(gradeCount)
+		summary.AverageGrade = &average
+	}
+
+	if attendanceTotal > 0 {
+		rate := float64(attendancePresent) / float64(attendanceTotal)
+		summary.AttendanceRate = &rate
+	}
+
+	return summary
+}
@@ -0,0 +1,106 @@
+package entities
+
+import "time"
+
+const (
+	DailyActiveUsersMetric = "daily_active_users"
+	SignupsMetric          = "signups"
+	EnrollmentsMetric      = "enrollments"
+)
+
+type Granularity string
+
+const (
+	DailyGranularity  Granularity = "day"
+	WeeklyGranularity Granularity = "week" // weeks start on Monday
+)
+
+// MetricDefinition tells how daily values roll up into weeks: counters are summed, gauges such as
+// daily active users are averaged.
+type MetricDefinition struct {
+	Name          string
+	AverageWeekly bool
+}
+
+var MetricDefinitions = map[string]MetricDefinition{
+	DailyActiveUsersMetric: {Name: DailyActiveUsersMetric, AverageWeekly: true},
+	SignupsMetric:          {Name: SignupsMetric},
+	EnrollmentsMetric:      {Name: EnrollmentsMetric}, // net enrollments, removals are negative
+}
+
+// DailyValue is a metric value for a UTC day as stored by the tenant metrics projection
+type DailyValue struct {
+	Day   time.Time
+	Value int64
+}
+
+type SeriesPoint struct {
+	PeriodStart time.Time
+	Value       float64
+}
+
+type MetricSeries struct {
+	Metric      string
+	Granularity Granularity
+	Points      []SeriesPoint
+}
+
+// NewMetricSeries builds a gap-free series of periods ending with the period containing until.
+// Days without a stored value count as zero.
+func NewMetricSeries(definition MetricDefinition, granularity Granularity, periods int, until time.Time, values []DailyValue) *MetricSeries {
+	byDay := make(map[time.Time]int64, len(values))
+	for _, value := range values {
+		byDay[TruncateDay(value.Day)] += value.Value
+	}
+
+	series := &MetricSeries{
+		Metric:      definition.Name,
+		Granularity: granularity,
+		Points:      make([]SeriesPoint, 0, periods),
+	}
+
+	first := SeriesStart(granularity, periods, until)
+	for i := 0; i < periods; i++ {
+		start := first.AddDate(0, 0, i*granularity.days())
+
+		var total int64
+		for d := 0; d < granularity.days(); d++ {
+			total += byDay[start.AddDate(0, 0, d)]
+		}
+
+		value := float64(total)
+		if definition.AverageWeekly && granularity == WeeklyGranularity {
+			value /= float64(granularity.days())
+		}
+		series.Points = append(series.Points, SeriesPoint{PeriodStart: start, Value: value})
+	}
+
+	return series
+}
+
+// SeriesStart returns the start of the first of periods periods ending with the one containing until
+func SeriesStart(granularity Granularity, periods int, until time.Time) time.Time {
+	return PeriodStart(granularity, until).AddDate(0, 0, -(periods-1)*granularity.days())
+}
+
+// PeriodStart returns the UTC start of the day or Monday-based week containing t
+func PeriodStart(granularity Granularity, t time.Time) time.Time {
+	day := TruncateDay(t)
+	if granularity == WeeklyGranularity {
+		sinceMonday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -sinceMonday)
+	}
+	return day
+}
+
+func TruncateDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (g Granularity) days() int {
+	if g == WeeklyGranularity {
+		return 7
+	}
+	return 1
+}
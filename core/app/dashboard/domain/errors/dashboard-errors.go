@@ -0,0 +1,26 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ClassSummaryNotFoundError errors2.ErrorCode = "CLASS_SUMMARY_NOT_FOUND"
+)
+
+func NewClassSummaryNotFoundError(classID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ClassSummaryNotFoundError.String(),
+			Message: "No dashboard data has been recorded for this class yet",
+			Context: map[string]any{
+				"class_id": classID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ClassSummaryNotFoundError.String()),
+		},
+	}
+}
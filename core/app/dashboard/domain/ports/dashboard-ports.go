@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/entities"
+)
+
+// ClassSummaryReader reads the class summary projection, never the OLTP tables
+type ClassSummaryReader interface {
+	FindClassSummary(ctx context.Context, tenantID string, classID string) (*entities.ClassSummary, error)
+}
+
+// MetricsReader reads the tenant daily metrics projection. Implementations may cache results for a
+// short time, dashboards tolerate slightly stale numbers.
+type MetricsReader interface {
+	DailyValues(ctx context.Context, tenantID string, metric string, from time.Time, to time.Time) ([]entities.DailyValue, error)
+}
@@ -0,0 +1,33 @@
+package delete_mapping_template_use_case
+
+import (
+	"context"
+
+	importErrors "github.com/nahualventure/class-backend/core/app/dataimport/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type DeleteMappingTemplateUseCase struct {
+	templateRepo ports.MappingTemplateRepository
+}
+
+func NewDeleteMappingTemplateUseCase(templateRepo ports.MappingTemplateRepository) *DeleteMappingTemplateUseCase {
+	return &DeleteMappingTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute deletes the template, imports started with it keep the mapping they were started with
+func (uc *DeleteMappingTemplateUseCase) Execute(ctx context.Context, cmd *DeleteMappingTemplateCommand) error {
+	deleted, err := uc.templateRepo.Delete(ctx, cmd.TenantID, cmd.TemplateID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+
+	if !deleted {
+		return importErrors.NewMappingTemplateNotFoundError(cmd.TemplateID)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package get_import_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetImportCommand struct {
+	TenantID string `validate:"required"`
+	ImportID string `validate:"required,uuid"`
+}
+
+func NewGetImportCommand(tenantID string, importID string) (*GetImportCommand, error) {
+	command := &GetImportCommand{
+		TenantID: tenantID,
+		ImportID: importID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
+
+type ListImportRowsCommand struct {
+	GetImportCommand
+	Status entities.RowStatus `validate:"omitempty,oneof=imported failed"`
+	Page   *pagination.PageRequest
+}
+
+func NewListImportRowsCommand(tenantID string, importID string, status string, pageSize int, cursor string) (*ListImportRowsCommand, error) {
+	page, err := pagination.NewPageRequest(pageSize, cursor, "", nil, pagination.OrderBy{
+		Field:     "line",
+		Direction: pagination.Ascending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	command := &ListImportRowsCommand{
+		GetImportCommand: GetImportCommand{TenantID: tenantID, ImportID: importID},
+		Status:           entities.RowStatus(status),
+		Page:             page,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
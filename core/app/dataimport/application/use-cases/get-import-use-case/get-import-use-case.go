@@ -0,0 +1,104 @@
+package get_import_use_case
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	importErrors "github.com/nahualventure/class-backend/core/app/dataimport/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+// ErrorReport is a CSV file with the failed rows of an import, as they were in the file followed by
+// their line and error. Fixed rows can be imported again with the same mapping.
+type ErrorReport struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+type GetImportUseCase struct {
+	importRepo ports.ImportJobRepository
+}
+
+func NewGetImportUseCase(importRepo ports.ImportJobRepository) *GetImportUseCase {
+	return &GetImportUseCase{
+		importRepo: importRepo,
+	}
+}
+
+func (uc *GetImportUseCase) Execute(ctx context.Context, cmd *GetImportCommand) (*entities.ImportJob, error) {
+	job, err := uc.importRepo.FindByID(ctx, cmd.TenantID, cmd.ImportID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if job == nil {
+		return nil, importErrors.NewImportNotFoundError(cmd.ImportID)
+	}
+
+	return job, nil
+}
+
+// Rows pages the outcome of the rows of an import, they are saved once the import completes
+func (uc *GetImportUseCase) Rows(ctx context.Context, cmd *ListImportRowsCommand) (*pagination.Page[*entities.RowResult], error) {
+	if _, err := uc.Execute(ctx, &cmd.GetImportCommand); err != nil {
+		return nil, err
+	}
+
+	page, err := uc.importRepo.ListRows(ctx, cmd.TenantID, cmd.ImportID, cmd.Status, cmd.Page)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return page, nil
+}
+
+// ErrorReport builds the error report of a finished import
+func (uc *GetImportUseCase) ErrorReport(ctx context.Context, cmd *GetImportCommand) (*ErrorReport, error) {
+	job, err := uc.Execute(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if !job.IsFinished() {
+		return nil, importErrors.NewImportNotFinishedError(job.ID, string(job.Status))
+	}
+
+	rows, err := uc.importRepo.FailedRows(ctx, cmd.TenantID, cmd.ImportID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(slices.Concat(job.Header, []string{"import_line", "import_error_code", "import_error"})); err != nil {
+		return nil, errors.NewInfrastructureError("failed to write the error report", err)
+	}
+	for _, row := range rows {
+		// Short rows are padded so the error columns stay aligned with the header
+		cells := make([]string, len(job.Header), len(job.Header)+3)
+		copy(cells, row.Cells)
+		cells = append(cells, strconv.Itoa(row.Line), row.ErrorCode, row.ErrorMessage)
+		if err := writer.Write(cells); err != nil {
+			return nil, errors.NewInfrastructureError("failed to write the error report", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, errors.NewInfrastructureError("failed to write the error report", err)
+	}
+
+	return &ErrorReport{
+		FileName:    fmt.Sprintf("%s-errors.csv", strings.TrimSuffix(job.FileName, ".csv")),
+		ContentType: "text/csv",
+		Content:     buffer.Bytes(),
+	}, nil
+}
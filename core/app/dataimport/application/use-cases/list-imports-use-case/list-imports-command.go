@@ -0,0 +1,22 @@
+package list_imports_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type ListImportsCommand struct {
+	TenantID string
+	Page     *pagination.PageRequest
+}
+
+func NewListImportsCommand(tenantID string, pageSize int, cursor string) (*ListImportsCommand, error) {
+	page, err := pagination.NewPageRequest(pageSize, cursor, "", nil, pagination.OrderBy{
+		Field:     "created_at",
+		Direction: pagination.Descending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListImportsCommand{TenantID: tenantID, Page: page}, nil
+}
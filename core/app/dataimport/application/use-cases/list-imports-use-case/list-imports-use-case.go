@@ -0,0 +1,29 @@
+package list_imports_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type ListImportsUseCase struct {
+	importRepo ports.ImportJobRepository
+}
+
+func NewListImportsUseCase(importRepo ports.ImportJobRepository) *ListImportsUseCase {
+	return &ListImportsUseCase{
+		importRepo: importRepo,
+	}
+}
+
+func (uc *ListImportsUseCase) Execute(ctx context.Context, cmd *ListImportsCommand) (*pagination.Page[*entities.ImportJob], error) {
+	page, err := uc.importRepo.List(ctx, cmd.TenantID, cmd.Page)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,29 @@
+package list_mapping_templates_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListMappingTemplatesCommand struct {
+	TenantID string `validate:"required"`
+	// Entity filters the templates, all of them are listed when empty
+	Entity string
+}
+
+func NewListMappingTemplatesCommand(tenantID string, entity string) (*ListMappingTemplatesCommand, error) {
+	command := &ListMappingTemplatesCommand{
+		TenantID: tenantID,
+		Entity:   entity,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
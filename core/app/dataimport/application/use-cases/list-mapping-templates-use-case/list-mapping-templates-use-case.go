@@ -0,0 +1,28 @@
+package list_mapping_templates_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListMappingTemplatesUseCase struct {
+	templateRepo ports.MappingTemplateRepository
+}
+
+func NewListMappingTemplatesUseCase(templateRepo ports.MappingTemplateRepository) *ListMappingTemplatesUseCase {
+	return &ListMappingTemplatesUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+func (uc *ListMappingTemplatesUseCase) Execute(ctx context.Context, cmd *ListMappingTemplatesCommand) ([]*entities.MappingTemplate, error) {
+	templates, err := uc.templateRepo.List(ctx, cmd.TenantID, cmd.Entity)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return templates, nil
+}
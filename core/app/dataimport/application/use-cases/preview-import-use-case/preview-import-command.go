@@ -0,0 +1,39 @@
+package preview_import_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type PreviewImportCommand struct {
+	TenantID string `validate:"required"`
+	ActorID  string `validate:"required"`
+	Entity   string `validate:"required"`
+	Content  []byte `validate:"required,min=1"`
+	// TemplateID names a saved mapping, the fields of Mapping override its columns
+	TemplateID string `validate:"omitempty,uuid"`
+	Mapping    entities.Mapping
+}
+
+func NewPreviewImportCommand(tenantID string, actorID string, entity string, content []byte, templateID string,
+	mapping map[string]string) (*PreviewImportCommand, error) {
+	command := &PreviewImportCommand{
+		TenantID:   tenantID,
+		ActorID:    actorID,
+		Entity:     entity,
+		Content:    content,
+		TemplateID: templateID,
+		Mapping:    mapping,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,142 @@
+package preview_import_use_case
+
+import (
+	"context"
+	"maps"
+
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	importErrors "github.com/nahualventure/class-backend/core/app/dataimport/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+const (
+	// SampleRows is how many rows of the file a preview returns as they would be imported
+	SampleRows = 10
+	// MaxFailures bounds the failed rows a preview returns, the counts cover the whole file
+	MaxFailures = 100
+)
+
+// PreparedImport is a file read through the columns of a definition
+type PreparedImport struct {
+	Definition entities.Definition
+	Sheet      *entities.Sheet
+	// Mapping is the mapping the file was read with, after applying the template
+	Mapping entities.Mapping
+	Columns map[string]int
+}
+
+type ImportPreview struct {
+	Entity    string
+	Header    []string
+	Mapping   entities.Mapping
+	TotalRows int
+	ValidRows int
+	// FailedRows counts the failed rows, Failures lists the first of them
+	FailedRows int
+	Sample     []entities.Record
+	Failures   []entities.RowResult
+}
+
+type PreviewImportUseCase struct {
+	templateRepo ports.MappingTemplateRepository
+	importers    ports.EntityImporters
+	permissions  ports.ImportPermissions
+}
+
+func NewPreviewImportUseCase(templateRepo ports.MappingTemplateRepository, importers ports.EntityImporters,
+	permissions ports.ImportPermissions) *PreviewImportUseCase {
+	return &PreviewImportUseCase{
+		templateRepo: templateRepo,
+		importers:    importers,
+		permissions:  permissions,
+	}
+}
+
+// Execute checks every row of the file the way the import would, nothing is written
+func (uc *PreviewImportUseCase) Execute(ctx context.Context, cmd *PreviewImportCommand) (*ImportPreview, error) {
+	prepared, err := uc.Prepare(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	importer := uc.importers[cmd.Entity]
+	records := prepared.Sheet.Records(prepared.Columns)
+	results := prepared.Definition.ProcessRecords(records, prepared.Columns, entities.RowStatusValid, func(record entities.Record) error {
+		return importer.Check(ctx, cmd.TenantID, cmd.ActorID, record.Values)
+	})
+
+	preview := &ImportPreview{
+		Entity:    cmd.Entity,
+		Header:    prepared.Sheet.Header,
+		Mapping:   prepared.Mapping,
+		TotalRows: len(results),
+	}
+	for i, result := range results {
+		if result.Status == entities.RowStatusFailed {
+			preview.FailedRows++
+			if len(preview.Failures) < MaxFailures {
+				preview.Failures = append(preview.Failures, result)
+			}
+			continue
+		}
+		preview.ValidRows++
+		if len(preview.Sample) < SampleRows {
+			preview.Sample = append(preview.Sample, redactValues(prepared.Definition, records[i]))
+		}
+	}
+
+	return preview, nil
+}
+
+// Prepare resolves the definition, the mapping and the columns of a file, after checking the user
+// holds the permission of the definition. Imports are started with the same checks.
+func (uc *PreviewImportUseCase) Prepare(ctx context.Context, cmd *PreviewImportCommand) (*PreparedImport, error) {
+	definition, ok := entities.Definitions[cmd.Entity]
+	if _, registered := uc.importers[cmd.Entity]; !ok || !registered {
+		return nil, importErrors.NewUnknownImportEntityError(cmd.Entity)
+	}
+
+	allowed, err := uc.permissions.CanImport(cmd.TenantID, cmd.ActorID, definition)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !allowed {
+		return nil, errors.NewForbiddenError(definition.Resource, definition.Action)
+	}
+
+	mapping := make(entities.Mapping)
+	if cmd.TemplateID != "" {
+		template, err := uc.templateRepo.FindByID(ctx, cmd.TenantID, cmd.TemplateID)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		if template == nil || template.Entity != cmd.Entity {
+			return nil, importErrors.NewMappingTemplateNotFoundError(cmd.TemplateID)
+		}
+		maps.Copy(mapping, template.Mapping)
+	}
+	maps.Copy(mapping, cmd.Mapping)
+
+	sheet, err := entities.ParseSheet(cmd.Content)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	columns, err := definition.ResolveColumns(mapping, sheet.Header)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return &PreparedImport{Definition: definition, Sheet: sheet, Mapping: mapping, Columns: columns}, nil
+}
+
+func redactValues(definition entities.Definition, record entities.Record) entities.Record {
+	values := maps.Clone(record.Values)
+	for _, field := range definition.Fields {
+		if _, ok := values[field.Name]; ok && field.Sensitive {
+			values[field.Name] = ""
+		}
+	}
+	return entities.Record{Line: record.Line, Values: values}
+}
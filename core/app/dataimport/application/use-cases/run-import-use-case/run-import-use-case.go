@@ -0,0 +1,90 @@
+package run_import_use_case
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	cockroach "github.com/cockroachdb/errors"
+)
+
+// StaleAfter is how long an import can stay running before another worker reclaims it. A reclaimed
+// import starts over, the rows imported before write the same values again or fail as existing.
+const StaleAfter = 30 * time.Minute
+
+type RunImportUseCase struct {
+	queue     ports.ImportJobQueue
+	importers ports.EntityImporters
+}
+
+func NewRunImportUseCase(queue ports.ImportJobQueue, importers ports.EntityImporters) *RunImportUseCase {
+	return &RunImportUseCase{
+		queue:     queue,
+		importers: importers,
+	}
+}
+
+// Execute imports the rows of the next pending import one at a time and saves the outcome of each.
+// It returns false when the queue is empty. An import whose file cannot be read anymore is marked
+// failed and its error is returned for logging.
+func (uc *RunImportUseCase) Execute(ctx context.Context) (bool, error) {
+	job, content, err := uc.queue.ClaimNextPending(ctx, StaleAfter)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+
+	if job == nil {
+		return false, nil
+	}
+
+	results, err := uc.run(ctx, job, content)
+	if err != nil {
+		if markErr := uc.queue.MarkFailed(ctx, job.ID, err.Error()); markErr != nil {
+			return true, errors.PropagateError(markErr)
+		}
+		return true, errors.NewInfrastructureError(fmt.Sprintf("failed to run import %s", job.ID), err)
+	}
+
+	if err := uc.queue.Complete(ctx, job.ID, results); err != nil {
+		return true, errors.PropagateError(err)
+	}
+
+	return true, nil
+}
+
+func (uc *RunImportUseCase) run(ctx context.Context, job *entities.ImportJob, content []byte) ([]entities.RowResult, error) {
+	definition, ok := entities.Definitions[job.Entity]
+	importer, registered := uc.importers[job.Entity]
+	if !ok || !registered {
+		return nil, fmt.Errorf("no importer for entity %s", job.Entity)
+	}
+
+	if content == nil {
+		return nil, fmt.Errorf("the file of import %s is no longer stored", job.ID)
+	}
+
+	sheet, err := entities.ParseSheet(content)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := definition.ResolveColumns(job.Mapping, sheet.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	return definition.ProcessRecords(sheet.Records(columns), columns, entities.RowStatusImported, func(record entities.Record) error {
+		err := importer.Import(ctx, job.TenantID, job.RequestedBy, record.Values)
+		// The row only keeps a generic message for infrastructure errors
+		var appErr errors.ApplicationError
+		if err != nil && (!cockroach.As(err, &appErr) || !appErr.IsDomainError()) {
+			log.Printf("Failed to import line %d of import %s: %v", record.Line, job.ID, err)
+		}
+		return err
+	}), nil
+}
@@ -0,0 +1,36 @@
+package save_mapping_template_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SaveMappingTemplateCommand struct {
+	TenantID string           `validate:"required"`
+	ActorID  string           `validate:"required"`
+	Entity   string           `validate:"required"`
+	Name     string           `validate:"required,max=100"`
+	Mapping  entities.Mapping `validate:"required,min=1"`
+}
+
+func NewSaveMappingTemplateCommand(tenantID string, actorID string, entity string, name string,
+	mapping map[string]string) (*SaveMappingTemplateCommand, error) {
+	command := &SaveMappingTemplateCommand{
+		TenantID: tenantID,
+		ActorID:  actorID,
+		Entity:   entity,
+		Name:     name,
+		Mapping:  mapping,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
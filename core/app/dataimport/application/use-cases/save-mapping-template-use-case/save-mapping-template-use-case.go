@@ -0,0 +1,49 @@
+package save_mapping_template_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	importErrors "github.com/nahualventure/class-backend/core/app/dataimport/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type SaveMappingTemplateUseCase struct {
+	templateRepo ports.MappingTemplateRepository
+}
+
+func NewSaveMappingTemplateUseCase(templateRepo ports.MappingTemplateRepository) *SaveMappingTemplateUseCase {
+	return &SaveMappingTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute saves the mapping under its name, the template of the entity with the same name is replaced
+func (uc *SaveMappingTemplateUseCase) Execute(ctx context.Context, cmd *SaveMappingTemplateCommand) (*entities.MappingTemplate, error) {
+	definition, ok := entities.Definitions[cmd.Entity]
+	if !ok {
+		return nil, importErrors.NewUnknownImportEntityError(cmd.Entity)
+	}
+
+	if err := definition.CheckMapping(cmd.Mapping); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	now := time.Now().UTC()
+	template, err := entities.NewMappingTemplate(uuid.New().String(), cmd.TenantID, cmd.Entity, cmd.Name, cmd.Mapping,
+		cmd.ActorID, now, now)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	saved, err := uc.templateRepo.Save(ctx, template)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return saved, nil
+}
@@ -0,0 +1,37 @@
+package start_import_use_case
+
+import (
+	preview_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/preview-import-use-case"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type StartImportCommand struct {
+	preview_import_use_case.PreviewImportCommand
+	FileName string `validate:"required,max=255"`
+}
+
+func NewStartImportCommand(tenantID string, actorID string, entity string, fileName string, content []byte,
+	templateID string, mapping map[string]string) (*StartImportCommand, error) {
+	command := &StartImportCommand{
+		PreviewImportCommand: preview_import_use_case.PreviewImportCommand{
+			TenantID:   tenantID,
+			ActorID:    actorID,
+			Entity:     entity,
+			Content:    content,
+			TemplateID: templateID,
+			Mapping:    mapping,
+		},
+		FileName: fileName,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
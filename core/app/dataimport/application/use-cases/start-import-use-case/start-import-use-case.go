@@ -0,0 +1,55 @@
+package start_import_use_case
+
+import (
+	"context"
+	"time"
+
+	preview_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/preview-import-use-case"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type StartImportUseCase struct {
+	importRepo ports.ImportJobRepository
+	preview    *preview_import_use_case.PreviewImportUseCase
+}
+
+func NewStartImportUseCase(importRepo ports.ImportJobRepository, preview *preview_import_use_case.PreviewImportUseCase) *StartImportUseCase {
+	return &StartImportUseCase{
+		importRepo: importRepo,
+		preview:    preview,
+	}
+}
+
+// Execute queues the import once the file and its mapping are readable, the worker imports the rows
+// asynchronously. The mapping is saved with the import, later changes to the template do not apply.
+func (uc *StartImportUseCase) Execute(ctx context.Context, cmd *StartImportCommand) (*entities.ImportJob, error) {
+	prepared, err := uc.preview.Prepare(ctx, &cmd.PreviewImportCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := entities.NewImportJob(
+		uuid.New().String(),
+		cmd.TenantID,
+		cmd.Entity,
+		cmd.FileName,
+		prepared.Sheet.Header,
+		prepared.Mapping,
+		len(prepared.Sheet.Rows),
+		cmd.ActorID,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.importRepo.Create(ctx, job, cmd.Content); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return job, nil
+}
@@ -0,0 +1,219 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	importErrors "github.com/nahualventure/class-backend/core/app/dataimport/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+const (
+	UsersImport                = "users"
+	GradesImport               = "grades"
+	NonInstructionalDaysImport = "non_instructional_days"
+	StandardsImport            = "standards"
+)
+
+type FieldSpec struct {
+	Name        string
+	Required    bool
+	Description string
+	// Sensitive values are blanked in the stored rows and the error report
+	Sensitive bool
+}
+
+// Definition is an entity that can be imported from a CSV file. Its rows are imported one at a time
+// through the use cases of the module owning the entity, with the permission those use cases need.
+type Definition struct {
+	Entity      string
+	Description string
+	Fields      []FieldSpec
+	// Key fields identify a record, a row repeating the key of a previous row of the file fails
+	Key []string
+	// Resource and Action are the permission the importing user needs besides running imports
+	Resource string
+	Action   string
+}
+
+// Definitions lists the entities a tenant can import
+var Definitions = map[string]Definition{
+	UsersImport: {
+		Entity:      UsersImport,
+		Description: "Accounts with an initial password, the email must not belong to an existing user",
+		Fields: []FieldSpec{
+			{Name: "name", Required: true, Description: "Full name"},
+			{Name: "email", Required: true, Description: "Email used to sign in"},
+			{Name: "password", Required: true, Description: "Initial password, 8 to 128 characters", Sensitive: true},
+		},
+		Key:      []string{"email"},
+		Resource: "user",
+		Action:   "create",
+	},
+	GradesImport: {
+		Entity:      GradesImport,
+		Description: "Draft scores of students, the score of a draft already recorded is changed",
+		Fields: []FieldSpec{
+			{Name: "class_id", Required: true, Description: "Class ID"},
+			{Name: "assignment_id", Required: true, Description: "Assignment ID"},
+			{Name: "student_id", Required: true, Description: "Student ID"},
+			{Name: "score", Required: true, Description: "Score from 0 to 100"},
+			{Name: "reason", Description: "Why a recorded score changed, kept in the history of the grade"},
+		},
+		Key:      []string{"class_id", "assignment_id", "student_id"},
+		Resource: "grade",
+		Action:   "assign",
+	},
+	NonInstructionalDaysImport: {
+		Entity:      NonInstructionalDaysImport,
+		Description: "Days without classes, a day already in the calendar is renamed",
+		Fields: []FieldSpec{
+			{Name: "date", Required: true, Description: "Day formatted as YYYY-MM-DD"},
+			{Name: "name", Required: true, Description: "Name shown in the calendar"},
+		},
+		Key:      []string{"date"},
+		Resource: "calendar",
+		Action:   "manage",
+	},
+	StandardsImport: {
+		Entity:      StandardsImport,
+		Description: "Learning standards such as the published state standards, a standard already in the framework is updated",
+		Fields: []FieldSpec{
+			{Name: "framework", Required: true, Description: "Framework the standard belongs to, such as CCSS.Math"},
+			{Name: "code", Required: true, Description: "Code of the standard within its framework"},
+			{Name: "description", Required: true, Description: "What the student is expected to know or do"},
+			{Name: "parent_code", Description: "Code of the broader standard of the same framework"},
+			{Name: "subject", Description: "Subject of the standard"},
+			{Name: "grade_level", Description: "Grade level of the standard, such as 6 or K"},
+		},
+		Key:      []string{"framework", "code"},
+		Resource: "standard",
+		Action:   "manage",
+	},
+}
+
+// Mapping maps fields of a definition to the header of the column holding them in the file
+type Mapping map[string]string
+
+// ResolveColumns returns the index in header of the column of every mapped field. A field the mapping
+// leaves out is read from the column named like it, if there is one. Headers are matched ignoring
+// case and surrounding spaces.
+func (d Definition) ResolveColumns(mapping Mapping, header []string) (map[string]int, error) {
+	errorMap := make(map[string]any)
+	columns := make(map[string]int, len(d.Fields))
+
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[normalizeHeader(name)] = i
+	}
+
+	known := make(map[string]bool, len(d.Fields))
+	for _, field := range d.Fields {
+		known[field.Name] = true
+
+		column, mapped := mapping[field.Name]
+		if !mapped {
+			column = field.Name
+		}
+
+		index, found := positions[normalizeHeader(column)]
+		switch {
+		case found:
+			columns[field.Name] = index
+		case mapped:
+			errorMap[field.Name] = fmt.Sprintf("Column %q is not in the file", column)
+		case field.Required:
+			errorMap[field.Name] = "Required, map it to a column of the file"
+		}
+	}
+
+	for field := range mapping {
+		if !known[field] {
+			errorMap[field] = "Unknown field"
+		}
+	}
+
+	if len(errorMap) > 0 {
+		return nil, appErrors.NewValidationError(fmt.Sprintf("Invalid mapping for %s", d.Entity), errorMap, nil)
+	}
+
+	return columns, nil
+}
+
+// CheckMapping validates a mapping without a file, as mapping templates are saved
+func (d Definition) CheckMapping(mapping Mapping) error {
+	errorMap := make(map[string]any)
+
+	known := make(map[string]bool, len(d.Fields))
+	for _, field := range d.Fields {
+		known[field.Name] = true
+	}
+	for field, column := range mapping {
+		if !known[field] {
+			errorMap[field] = "Unknown field"
+		} else if strings.TrimSpace(column) == "" {
+			errorMap[field] = "Column cannot be empty"
+		}
+	}
+
+	if len(errorMap) > 0 {
+		return appErrors.NewValidationError(fmt.Sprintf("Invalid mapping for %s", d.Entity), errorMap, nil)
+	}
+
+	return nil
+}
+
+// ProcessRecords runs process on the records in order and returns the outcome of each, success on
+// the ones process accepts. A record repeating the key of an earlier record fails without being
+// processed. The cells of the outcomes are redacted.
+func (d Definition) ProcessRecords(records []Record, columns map[string]int, success RowStatus,
+	process func(Record) error) []RowResult {
+	results := make([]RowResult, len(records))
+	firstLines := make(map[string]int, len(records))
+
+	for i, record := range records {
+		results[i] = RowResult{Line: record.Line, Status: success, Cells: d.Redact(record.Cells, columns)}
+
+		if key := d.KeyOf(record.Values); key != "" {
+			if firstLine, duplicated := firstLines[key]; duplicated {
+				results[i].Fail(importErrors.NewDuplicateImportRowError(firstLine))
+				continue
+			}
+			firstLines[key] = record.Line
+		}
+
+		if err := process(record); err != nil {
+			results[i].Fail(err)
+		}
+	}
+
+	return results
+}
+
+// KeyOf returns the key of the record built from values, empty when a key field is missing since the
+// record will not pass validation anyway
+func (d Definition) KeyOf(values map[string]string) string {
+	parts := make([]string, len(d.Key))
+	for i, field := range d.Key {
+		parts[i] = strings.ToLower(strings.TrimSpace(values[field]))
+		if parts[i] == "" {
+			return ""
+		}
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// Redact blanks the cells of the sensitive fields in a copy of cells
+func (d Definition) Redact(cells []string, columns map[string]int) []string {
+	redacted := append([]string(nil), cells...)
+	for _, field := range d.Fields {
+		if index, ok := columns[field.Name]; ok && field.Sensitive && index < len(redacted) {
+			redacted[index] = ""
+		}
+	}
+	return redacted
+}
+
+func normalizeHeader(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
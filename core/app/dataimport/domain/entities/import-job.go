@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ImportJobStatus string
+
+const (
+	ImportJobStatusPending ImportJobStatus = "pending"
+	ImportJobStatusRunning ImportJobStatus = "running"
+	// ImportJobStatusCompleted imports went through every row, some of them may have failed
+	ImportJobStatusCompleted ImportJobStatus = "completed"
+	// ImportJobStatusFailed imports could not run at all, no row was imported
+	ImportJobStatusFailed ImportJobStatus = "failed"
+)
+
+type RowStatus string
+
+const (
+	// RowStatusValid rows passed the checks of a preview, nothing was written
+	RowStatusValid    RowStatus = "valid"
+	RowStatusImported RowStatus = "imported"
+	RowStatusFailed   RowStatus = "failed"
+)
+
+// ImportJob is an async import of a CSV file. The file is stored apart until the worker runs the
+// import, then the outcome of every row is kept instead.
+type ImportJob struct {
+	ID            string `validate:"required,uuid"`
+	TenantID      string `validate:"required"`
+	Entity        string `validate:"required"`
+	FileName      string `validate:"required,max=255"`
+	Header        []string
+	Mapping       Mapping
+	Status        ImportJobStatus `validate:"required,oneof=pending running completed failed"`
+	TotalRows     int
+	ImportedRows  int
+	FailedRows    int
+	FailureReason string
+	RequestedBy   string    `validate:"required"`
+	CreatedAt     time.Time `validate:"required"`
+	UpdatedAt     time.Time `validate:"required"`
+	CompletedAt   *time.Time
+}
+
+// NewImportJob returns a pending import of totalRows rows
+func NewImportJob(id string, tenantID string, entity string, fileName string, header []string, mapping Mapping,
+	totalRows int, requestedBy string, now time.Time) (*ImportJob, error) {
+	job := &ImportJob{
+		ID:          id,
+		TenantID:    tenantID,
+		Entity:      entity,
+		FileName:    fileName,
+		Header:      header,
+		Mapping:     mapping,
+		Status:      ImportJobStatusPending,
+		TotalRows:   totalRows,
+		RequestedBy: requestedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := validate.Struct(job); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("ImportJob domain model instance not valid", errorMap, err)
+	}
+
+	return job, nil
+}
+
+// IsFinished reports whether the import will not change anymore
+func (j *ImportJob) IsFinished() bool {
+	return j.Status == ImportJobStatusCompleted || j.Status == ImportJobStatusFailed
+}
+
+// RowResult is the outcome of a row of a file, the error is set only when the row failed
+type RowResult struct {
+	Line         int
+	Status       RowStatus
+	Cells        []string
+	ErrorCode    string
+	ErrorMessage string
+}
+
+// Fail records why the row was rejected, with the code of application errors
+func (r *RowResult) Fail(err error) {
+	r.Status = RowStatusFailed
+
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) && appErr.IsDomainError() {
+		r.ErrorCode = appErr.GetCode()
+		r.ErrorMessage = appErr.GetMessage()
+		if r.ErrorCode != appErrors.ValidationError.String() {
+			return
+		}
+		// The fields of validation errors are what the user has to fix in the file
+		context := appErr.GetContext()
+		for _, field := range slices.Sorted(maps.Keys(context)) {
+			if reason, ok := context[field].(string); ok {
+				r.ErrorMessage += "; " + field + ": " + reason
+			}
+		}
+		return
+	}
+
+	// Infrastructure errors keep their cause in the logs of the worker, the row can be imported again
+	r.ErrorCode = appErrors.InternalError.String()
+	r.ErrorMessage = "The row could not be imported, try again later"
+}
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// MappingTemplate is a mapping saved by a tenant under a name, for files exported by the same system
+// every time. Names are unique per tenant and entity.
+type MappingTemplate struct {
+	ID        string `validate:"required,uuid"`
+	TenantID  string `validate:"required"`
+	Entity    string `validate:"required"`
+	Name      string `validate:"required,max=100"`
+	Mapping   Mapping
+	CreatedBy string    `validate:"required"`
+	CreatedAt time.Time `validate:"required"`
+	UpdatedAt time.Time `validate:"required"`
+}
+
+func NewMappingTemplate(id string, tenantID string, entity string, name string, mapping Mapping, createdBy string,
+	createdAt time.Time, updatedAt time.Time) (*MappingTemplate, error) {
+	template := &MappingTemplate{
+		ID:        id,
+		TenantID:  tenantID,
+		Entity:    entity,
+		Name:      name,
+		Mapping:   mapping,
+		CreatedBy: createdBy,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+
+	if err := validate.Struct(template); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("MappingTemplate domain model instance not valid", errorMap, err)
+	}
+
+	return template, nil
+}
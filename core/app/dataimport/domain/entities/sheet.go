@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+const (
+	// MaxImportBytes bounds the size of an imported file
+	MaxImportBytes = 5 << 20
+	// MaxImportRows bounds the rows of an imported file, not counting the header
+	MaxImportRows = 10000
+)
+
+// Sheet is a parsed CSV file, the first line is the header
+type Sheet struct {
+	Header []string
+	Rows   []SheetRow
+}
+
+type SheetRow struct {
+	// Line is the line of the row in the file, the header is line 1
+	Line  int
+	Cells []string
+}
+
+// Record is a row of a sheet read through the columns of a definition
+type Record struct {
+	Line   int
+	Cells  []string
+	Values map[string]string
+}
+
+// ParseSheet reads a CSV file exported by a spreadsheet. Rows may have fewer cells than the header,
+// the missing ones are empty. A byte order mark before the header is ignored.
+func ParseSheet(content []byte) (*Sheet, error) {
+	if len(content) > MaxImportBytes {
+		return nil, appErrors.NewValidationError("The file is too large",
+			map[string]any{"content": fmt.Sprintf("Must be at most %d bytes", MaxImportBytes)}, nil)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, appErrors.NewValidationError("The file is empty",
+			map[string]any{"content": "Must have a header line"}, nil)
+	}
+	if err != nil {
+		return nil, invalidCSVError(err)
+	}
+
+	seen := make(map[string]bool, len(header))
+	for _, name := range header {
+		normalized := normalizeHeader(name)
+		if normalized == "" {
+			return nil, appErrors.NewValidationError("The header has an empty column",
+				map[string]any{"content": "Every column of the header needs a name"}, nil)
+		}
+		if seen[normalized] {
+			return nil, appErrors.NewValidationError("The header repeats a column",
+				map[string]any{"content": fmt.Sprintf("Column %q appears more than once", name)}, nil)
+		}
+		seen[normalized] = true
+	}
+
+	sheet := &Sheet{Header: header}
+	for {
+		cells, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, invalidCSVError(err)
+		}
+		if len(sheet.Rows) == MaxImportRows {
+			return nil, appErrors.NewValidationError("The file has too many rows",
+				map[string]any{"content": fmt.Sprintf("Must have at most %d rows", MaxImportRows)}, nil)
+		}
+
+		line, _ := reader.FieldPos(0)
+		sheet.Rows = append(sheet.Rows, SheetRow{Line: line, Cells: cells})
+	}
+
+	return sheet, nil
+}
+
+// Records reads the rows through the columns ResolveColumns returned
+func (s *Sheet) Records(columns map[string]int) []Record {
+	records := make([]Record, len(s.Rows))
+	for i, row := range s.Rows {
+		values := make(map[string]string, len(columns))
+		for field, index := range columns {
+			if index < len(row.Cells) {
+				values[field] = row.Cells[index]
+			}
+		}
+		records[i] = Record{Line: row.Line, Cells: row.Cells, Values: values}
+	}
+	return records
+}
+
+func invalidCSVError(err error) error {
+	return appErrors.NewValidationError("The file is not a valid CSV file",
+		map[string]any{"content": err.Error()}, err)
+}
@@ -0,0 +1,87 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ImportNotFoundError          errors2.ErrorCode = "IMPORT_NOT_FOUND"
+	ImportNotFinishedError       errors2.ErrorCode = "IMPORT_NOT_FINISHED"
+	UnknownImportEntityError     errors2.ErrorCode = "UNKNOWN_IMPORT_ENTITY"
+	MappingTemplateNotFoundError errors2.ErrorCode = "MAPPING_TEMPLATE_NOT_FOUND"
+	DuplicateImportRowError      errors2.ErrorCode = "DUPLICATE_IMPORT_ROW"
+)
+
+func NewImportNotFoundError(importID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ImportNotFoundError.String(),
+			Message: "The requested import could not be found",
+			Context: map[string]any{
+				"import_id": importID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ImportNotFoundError.String()),
+		},
+	}
+}
+
+func NewImportNotFinishedError(importID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ImportNotFinishedError.String(),
+			Message: "The import is still running",
+			Context: map[string]any{
+				"import_id": importID,
+				"status":    status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ImportNotFinishedError.String()),
+		},
+	}
+}
+
+func NewUnknownImportEntityError(entity string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    UnknownImportEntityError.String(),
+			Message: "The entity cannot be imported",
+			Context: map[string]any{
+				"entity": entity,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(UnknownImportEntityError.String()),
+		},
+	}
+}
+
+func NewMappingTemplateNotFoundError(templateID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    MappingTemplateNotFoundError.String(),
+			Message: "The requested mapping template could not be found",
+			Context: map[string]any{
+				"template_id": templateID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(MappingTemplateNotFoundError.String()),
+		},
+	}
+}
+
+func NewDuplicateImportRowError(firstLine int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    DuplicateImportRowError.String(),
+			Message: "An earlier row of the file has the same key",
+			Context: map[string]any{
+				"first_line": firstLine,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(DuplicateImportRowError.String()),
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type ImportJobRepository interface {
+	// Create saves a pending import with its file, the worker reads the file back
+	Create(ctx context.Context, job *entities.ImportJob, content []byte) error
+	FindByID(ctx context.Context, tenantID string, importID string) (*entities.ImportJob, error)
+	List(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.ImportJob], error)
+	// ListRows pages the outcome of the rows by line, only those with status when it is not empty
+	ListRows(ctx context.Context, tenantID string, importID string, status entities.RowStatus, page *pagination.PageRequest) (*pagination.Page[*entities.RowResult], error)
+	// FailedRows returns every failed row of an import by line, for the error report
+	FailedRows(ctx context.Context, tenantID string, importID string) ([]*entities.RowResult, error)
+	ImportJobQueue
+}
+
+// ImportJobQueue is the worker side of the repository. ClaimNextPending also reclaims imports left
+// running by a worker that died more than staleAfter ago, with their file; it returns a nil job when
+// there is nothing to do.
+type ImportJobQueue interface {
+	ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.ImportJob, []byte, error)
+	// Complete saves the outcome of every row, counts them in the job and deletes the file
+	Complete(ctx context.Context, importID string, rows []entities.RowResult) error
+	MarkFailed(ctx context.Context, importID string, reason string) error
+}
+
+type MappingTemplateRepository interface {
+	// Save creates the template or replaces the mapping of the template of the entity with its name,
+	// it returns the saved template
+	Save(ctx context.Context, template *entities.MappingTemplate) (*entities.MappingTemplate, error)
+	FindByID(ctx context.Context, tenantID string, templateID string) (*entities.MappingTemplate, error)
+	// List returns the templates of the tenant by name, of entity when it is not empty
+	List(ctx context.Context, tenantID string, entity string) ([]*entities.MappingTemplate, error)
+	Delete(ctx context.Context, tenantID string, templateID string) (bool, error)
+}
+
+// EntityImporter writes the records of a definition through the use cases of the module owning the
+// entity. Check runs the validation Import would without writing, for previews.
+type EntityImporter interface {
+	Check(ctx context.Context, tenantID string, actorID string, values map[string]string) error
+	Import(ctx context.Context, tenantID string, actorID string, values map[string]string) error
+}
+
+// EntityImporters holds the importer of every definition by entity
+type EntityImporters map[string]EntityImporter
+
+// ImportPermissions tells whether a user holds the permission of a definition in a tenant
+type ImportPermissions interface {
+	CanImport(tenantID string, userID string, definition entities.Definition) (bool, error)
+}
@@ -0,0 +1,33 @@
+package check_suppressions_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const MaxCheckSize = 1000
+
+var validate = validator.New()
+
+type CheckSuppressionsCommand struct {
+	Emails []string `validate:"required,min=1,max=1000,dive,required,max=255"`
+}
+
+func NewCheckSuppressionsCommand(emails []string) (*CheckSuppressionsCommand, error) {
+	normalized := make([]string, len(emails))
+	for i, email := range emails {
+		normalized[i] = entities.NormalizeEmail(email)
+	}
+	command := &CheckSuppressionsCommand{
+		Emails: normalized,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
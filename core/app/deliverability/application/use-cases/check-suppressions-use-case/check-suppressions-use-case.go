@@ -0,0 +1,29 @@
+package check_suppressions_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type CheckSuppressionsUseCase struct {
+	suppressions ports.SuppressionRepository
+}
+
+func NewCheckSuppressionsUseCase(suppressions ports.SuppressionRepository) *CheckSuppressionsUseCase {
+	return &CheckSuppressionsUseCase{
+		suppressions: suppressions,
+	}
+}
+
+// Execute returns the suppressed addresses among the recipients of an email, which senders skip.
+// Addresses are suppressed for every tenant, whichever tenant's emails bounced.
+func (uc *CheckSuppressionsUseCase) Execute(ctx context.Context, cmd *CheckSuppressionsCommand) ([]*entities.Suppression, error) {
+	suppressed, err := uc.suppressions.Suppressed(ctx, cmd.Emails)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return suppressed, nil
+}
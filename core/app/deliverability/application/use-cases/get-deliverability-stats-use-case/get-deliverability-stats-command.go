@@ -0,0 +1,35 @@
+package get_deliverability_stats_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultDays is the window of the stats when none is given, providers review the rates of about a
+// month of sending
+const DefaultDays = 30
+
+var validate = validator.New()
+
+type GetDeliverabilityStatsCommand struct {
+	TenantID string `validate:"required"`
+	Days     int    `validate:"min=1,max=90"`
+}
+
+func NewGetDeliverabilityStatsCommand(tenantID string, days int) (*GetDeliverabilityStatsCommand, error) {
+	if days == 0 {
+		days = DefaultDays
+	}
+	command := &GetDeliverabilityStatsCommand{
+		TenantID: tenantID,
+		Days:     days,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,30 @@
+package get_deliverability_stats_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetDeliverabilityStatsUseCase struct {
+	events ports.DeliveryEventRepository
+}
+
+func NewGetDeliverabilityStatsUseCase(events ports.DeliveryEventRepository) *GetDeliverabilityStatsUseCase {
+	return &GetDeliverabilityStatsUseCase{
+		events: events,
+	}
+}
+
+// Execute counts the delivery events of the last days of the tenant
+func (uc *GetDeliverabilityStatsUseCase) Execute(ctx context.Context, cmd *GetDeliverabilityStatsCommand) (*entities.DeliverabilityStats, error) {
+	from := time.Now().UTC().AddDate(0, 0, -cmd.Days)
+	stats, err := uc.events.Stats(ctx, cmd.TenantID, from)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,28 @@
+package list_suppressions_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type ListSuppressionsUseCase struct {
+	suppressions ports.SuppressionRepository
+}
+
+func NewListSuppressionsUseCase(suppressions ports.SuppressionRepository) *ListSuppressionsUseCase {
+	return &ListSuppressionsUseCase{
+		suppressions: suppressions,
+	}
+}
+
+func (uc *ListSuppressionsUseCase) Execute(ctx context.Context, cmd *ListSuppressionsCommand) (*pagination.Page[*entities.Suppression], error) {
+	page, err := uc.suppressions.List(ctx, cmd.TenantID, cmd.Page)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return page, nil
+}
@@ -0,0 +1,31 @@
+package receive_delivery_events_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// ReceiveDeliveryEventsCommand carries a webhook body the provider signature was verified for when
+// it was received
+type ReceiveDeliveryEventsCommand struct {
+	Provider entities.EmailProvider `validate:"required,oneof=ses sendgrid"`
+	Payload  []byte                 `validate:"required"`
+}
+
+func NewReceiveDeliveryEventsCommand(provider entities.EmailProvider, payload []byte) (*ReceiveDeliveryEventsCommand, error) {
+	command := &ReceiveDeliveryEventsCommand{
+		Provider: provider,
+		Payload:  payload,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,54 @@
+package receive_delivery_events_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	deliverabilityErrors "github.com/nahualventure/class-backend/core/app/deliverability/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// ReceiveDeliveryEventsResult counts the events of the webhook, Recorded the ones not received before
+type ReceiveDeliveryEventsResult struct {
+	Received int
+	Recorded int
+}
+
+type ReceiveDeliveryEventsUseCase struct {
+	webhooks map[entities.EmailProvider]ports.DeliveryWebhook
+	events   ports.DeliveryEventRepository
+}
+
+// NewReceiveDeliveryEventsUseCase refuses the webhooks of providers without an entry in webhooks
+func NewReceiveDeliveryEventsUseCase(webhooks map[entities.EmailProvider]ports.DeliveryWebhook, events ports.DeliveryEventRepository) *ReceiveDeliveryEventsUseCase {
+	return &ReceiveDeliveryEventsUseCase{
+		webhooks: webhooks,
+		events:   events,
+	}
+}
+
+// Execute records the delivery events of a provider webhook, it runs on the webhook worker. Bounced
+// and complaining addresses are suppressed with the events, so the next check before a send already
+// skips them.
+func (uc *ReceiveDeliveryEventsUseCase) Execute(ctx context.Context, cmd *ReceiveDeliveryEventsCommand) (*ReceiveDeliveryEventsResult, error) {
+	webhook, ok := uc.webhooks[cmd.Provider]
+	if !ok {
+		return nil, deliverabilityErrors.NewInvalidEmailWebhookError(string(cmd.Provider), "provider not configured")
+	}
+
+	events, err := webhook.ParseEvents(ctx, cmd.Payload)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	result := &ReceiveDeliveryEventsResult{Received: len(events)}
+	if len(events) == 0 {
+		return result, nil
+	}
+	result.Recorded, err = uc.events.Record(ctx, events)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,29 @@
+package remove_suppression_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RemoveSuppressionCommand struct {
+	TenantID string `validate:"required"`
+	Email    string `validate:"required,max=255"`
+}
+
+func NewRemoveSuppressionCommand(tenantID string, email string) (*RemoveSuppressionCommand, error) {
+	command := &RemoveSuppressionCommand{
+		TenantID: tenantID,
+		Email:    entities.NormalizeEmail(email),
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
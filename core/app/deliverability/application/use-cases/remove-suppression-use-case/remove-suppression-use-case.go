@@ -0,0 +1,43 @@
+package remove_suppression_use_case
+
+import (
+	"context"
+
+	deliverabilityErrors "github.com/nahualventure/class-backend/core/app/deliverability/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type RemoveSuppressionUseCase struct {
+	suppressions ports.SuppressionRepository
+}
+
+func NewRemoveSuppressionUseCase(suppressions ports.SuppressionRepository) *RemoveSuppressionUseCase {
+	return &RemoveSuppressionUseCase{
+		suppressions: suppressions,
+	}
+}
+
+// Execute lets the tenant send to a bounced address again, once the mailbox works. The address stays
+// suppressed while another tenant has it suppressed.
+func (uc *RemoveSuppressionUseCase) Execute(ctx context.Context, cmd *RemoveSuppressionCommand) error {
+	suppression, err := uc.suppressions.Find(ctx, cmd.TenantID, cmd.Email)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if suppression == nil {
+		return deliverabilityErrors.NewSuppressionNotFoundError(cmd.Email)
+	}
+	if !suppression.Removable() {
+		return deliverabilityErrors.NewSuppressionNotRemovableError(cmd.Email, string(suppression.Reason))
+	}
+
+	removed, err := uc.suppressions.Remove(ctx, cmd.TenantID, cmd.Email)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if !removed {
+		return deliverabilityErrors.NewSuppressionNotFoundError(cmd.Email)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"time"
+)
+
+const (
+	// BounceRateLimit and ComplaintRateLimit are the rates email providers review or pause sending
+	// accounts over
+	BounceRateLimit    = 0.05
+	ComplaintRateLimit = 0.001
+)
+
+type DeliverabilityStatus string
+
+const (
+	DeliverabilityHealthy DeliverabilityStatus = "healthy"
+	// DeliverabilityAtRisk tenants bounce or get complaints over the limits, their emails put the
+	// sending domain at risk of being blocked
+	DeliverabilityAtRisk DeliverabilityStatus = "at_risk"
+)
+
+// DeliverabilityStats counts the delivery events of a tenant since From, and the addresses its
+// events suppressed in that time
+type DeliverabilityStats struct {
+	TenantID    string
+	From        time.Time
+	Delivered   int64
+	Bounced     int64
+	SoftBounced int64
+	Complained  int64
+	Suppressed  int64
+}
+
+// BounceRate is the share of the emails with an outcome that bounced permanently
+func (s *DeliverabilityStats) BounceRate() float64 {
+	total := s.Delivered + s.Bounced + s.SoftBounced
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Bounced) / float64(total)
+}
+
+// ComplaintRate is the share of the delivered emails marked as spam
+func (s *DeliverabilityStats) ComplaintRate() float64 {
+	if s.Delivered == 0 {
+		return 0
+	}
+	return float64(s.Complained) / float64(s.Delivered)
+}
+
+func (s *DeliverabilityStats) Status() DeliverabilityStatus {
+	if s.BounceRate() > BounceRateLimit || s.ComplaintRate() > ComplaintRateLimit {
+		return DeliverabilityAtRisk
+	}
+	return DeliverabilityHealthy
+}
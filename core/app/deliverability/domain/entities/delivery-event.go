@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// EmailProvider is the service senders deliver emails through, its webhooks report what happened to
+// them
+type EmailProvider string
+
+const (
+	// EmailProviderSES reports through an SNS topic subscribed to the webhook
+	EmailProviderSES      EmailProvider = "ses"
+	EmailProviderSendGrid EmailProvider = "sendgrid"
+)
+
+type DeliveryEventType string
+
+const (
+	DeliveryEventDelivered DeliveryEventType = "delivered"
+	// DeliveryEventBounced is a permanent bounce, the address does not exist or refuses mail for good
+	DeliveryEventBounced DeliveryEventType = "bounced"
+	// DeliveryEventSoftBounced is a transient bounce such as a full mailbox, later emails may arrive
+	DeliveryEventSoftBounced DeliveryEventType = "soft_bounced"
+	// DeliveryEventComplained is the recipient marking the email as spam
+	DeliveryEventComplained DeliveryEventType = "complained"
+)
+
+// DeliveryEvent is what happened to an email sent to one recipient. Senders tag emails with the
+// tenant they are sent for, events of untagged emails have no TenantID.
+type DeliveryEvent struct {
+	Provider EmailProvider     `validate:"required,oneof=ses sendgrid"`
+	ID       string            `validate:"required,max=255"`
+	TenantID string            `validate:"max=255"`
+	Email    string            `validate:"required,max=255"`
+	Type     DeliveryEventType `validate:"required,oneof=delivered bounced soft_bounced complained"`
+	// Detail is the reason the provider gives, such as the SMTP diagnostic of a bounce
+	Detail     string
+	OccurredAt time.Time `validate:"required"`
+}
+
+// NewDeliveryEvent lowercases the email, providers report the address as the sender wrote it
+func NewDeliveryEvent(provider EmailProvider, id string, tenantID string, email string, eventType DeliveryEventType,
+	detail string, occurredAt time.Time) (*DeliveryEvent, error) {
+	event := &DeliveryEvent{
+		Provider:   provider,
+		ID:         id,
+		TenantID:   tenantID,
+		Email:      NormalizeEmail(email),
+		Type:       eventType,
+		Detail:     detail,
+		OccurredAt: occurredAt,
+	}
+
+	if err := validate.Struct(event); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("DeliveryEvent domain model instance not valid", errorMap, err)
+	}
+
+	return event, nil
+}
+
+// Suppresses reports whether the address must not be sent to anymore after the event
+func (e *DeliveryEvent) Suppresses() bool {
+	return e.Type == DeliveryEventBounced || e.Type == DeliveryEventComplained
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
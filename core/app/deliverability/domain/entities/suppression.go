@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+)
+
+type SuppressionReason string
+
+const (
+	SuppressionReasonBounce SuppressionReason = "bounce"
+	// SuppressionReasonComplaint is kept over a later bounce and cannot be removed, the recipient
+	// asked not to receive the emails
+	SuppressionReasonComplaint SuppressionReason = "complaint"
+)
+
+// Suppression is an address no email is sent to, added by the event of a tenant. An address is
+// suppressed while any tenant has it suppressed, emails of every tenant share the sending domain.
+type Suppression struct {
+	TenantID     string
+	Email        string
+	Reason       SuppressionReason
+	Provider     EmailProvider
+	Detail       string
+	SuppressedAt time.Time
+}
+
+// NewSuppression returns nil for events that do not suppress the address
+func NewSuppression(event *DeliveryEvent) *Suppression {
+	if !event.Suppresses() {
+		return nil
+	}
+
+	reason := SuppressionReasonBounce
+	if event.Type == DeliveryEventComplained {
+		reason = SuppressionReasonComplaint
+	}
+	return &Suppression{
+		TenantID:     event.TenantID,
+		Email:        event.Email,
+		Reason:       reason,
+		Provider:     event.Provider,
+		Detail:       event.Detail,
+		SuppressedAt: event.OccurredAt,
+	}
+}
+
+// Removable reports whether an admin may send to the address again, once a bouncing mailbox works
+func (s *Suppression) Removable() bool {
+	return s.Reason != SuppressionReasonComplaint
+}
@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	InvalidEmailWebhookError errors2.ErrorCode = "INVALID_EMAIL_WEBHOOK"
+	SuppressionNotFoundError errors2.ErrorCode = "SUPPRESSION_NOT_FOUND"
+	// SuppressionNotRemovableError is returned when removing the suppression of a recipient who
+	// complained
+	SuppressionNotRemovableError errors2.ErrorCode = "SUPPRESSION_NOT_REMOVABLE"
+)
+
+func NewInvalidEmailWebhookError(provider string, reason string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    InvalidEmailWebhookError.String(),
+			Message: "The email webhook could not be verified",
+			Context: map[string]any{
+				"provider": provider,
+				"reason":   reason,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidEmailWebhookError.String()),
+		},
+	}
+}
+
+func NewSuppressionNotFoundError(email string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SuppressionNotFoundError.String(),
+			Message: "The address is not suppressed by the tenant",
+			Context: map[string]any{
+				"email": email,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SuppressionNotFoundError.String()),
+		},
+	}
+}
+
+func NewSuppressionNotRemovableError(email string, reason string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SuppressionNotRemovableError.String(),
+			Message: "The recipient complained about the emails, the suppression cannot be removed",
+			Context: map[string]any{
+				"email":  email,
+				"reason": reason,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SuppressionNotRemovableError.String()),
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+// DeliveryWebhook reads the webhooks of an email provider
+type DeliveryWebhook interface {
+	// Verify authenticates a webhook body with the signature and timestamp headers of the provider,
+	// when it has them, and returns the ID of the message it carries, empty when the provider gives
+	// its messages none
+	Verify(ctx context.Context, payload []byte, signature string, timestamp string) (string, error)
+	// ParseEvents translates the events of a webhook body Verify accepted when it was received.
+	// Requests of the provider that carry no events, such as subscription confirmations, return none.
+	ParseEvents(ctx context.Context, payload []byte) ([]*entities.DeliveryEvent, error)
+}
+
+type DeliveryEventRepository interface {
+	// Record saves the events not recorded before and suppresses the addresses of the new ones that
+	// suppress, in one transaction. Providers retry webhooks, it returns the number of new events.
+	Record(ctx context.Context, events []*entities.DeliveryEvent) (int, error)
+	// Stats counts the events of the tenant since from
+	Stats(ctx context.Context, tenantID string, from time.Time) (*entities.DeliverabilityStats, error)
+}
+
+type SuppressionRepository interface {
+	// Suppressed returns the suppressions of any tenant among emails, a complaint over bounces of the
+	// same address
+	Suppressed(ctx context.Context, emails []string) ([]*entities.Suppression, error)
+	// Find returns nil when the tenant did not suppress the address
+	Find(ctx context.Context, tenantID string, email string) (*entities.Suppression, error)
+	// List returns the suppressions of the tenant, last suppressed first
+	List(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.Suppression], error)
+	// Remove returns false when the tenant does not have the address suppressed anymore
+	Remove(ctx context.Context, tenantID string, email string) (bool, error)
+}
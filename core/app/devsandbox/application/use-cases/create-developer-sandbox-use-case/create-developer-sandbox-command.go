@@ -0,0 +1,28 @@
+package create_developer_sandbox_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CreateDeveloperSandboxCommand struct {
+	Name  string `validate:"required,max=100"`
+	Email string `validate:"required,email,max=255"`
+}
+
+func NewCreateDeveloperSandboxCommand(name string, email string) (*CreateDeveloperSandboxCommand, error) {
+	command := &CreateDeveloperSandboxCommand{
+		Name:  name,
+		Email: email,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
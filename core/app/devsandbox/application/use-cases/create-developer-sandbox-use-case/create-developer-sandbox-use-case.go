@@ -0,0 +1,103 @@
+package create_developer_sandbox_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/entities"
+	devSandboxErrors "github.com/nahualventure/class-backend/core/app/devsandbox/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/ports"
+	serviceAccountEntities "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	serviceAccountPorts "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+
+	"github.com/google/uuid"
+)
+
+type CreateDeveloperSandboxUseCase struct {
+	sandboxRepo        ports.DeveloperSandboxRepository
+	data               ports.SyntheticData
+	policies           ports.DeveloperSandboxPolicies
+	serviceAccountRepo serviceAccountPorts.ServiceAccountRepository
+	clock              clock.Clock
+}
+
+func NewCreateDeveloperSandboxUseCase(sandboxRepo ports.DeveloperSandboxRepository, data ports.SyntheticData,
+	policies ports.DeveloperSandboxPolicies, serviceAccountRepo serviceAccountPorts.ServiceAccountRepository) *CreateDeveloperSandboxUseCase {
+	return &CreateDeveloperSandboxUseCase{
+		sandboxRepo:        sandboxRepo,
+		data:               data,
+		policies:           policies,
+		serviceAccountRepo: serviceAccountRepo,
+		clock:              clock.System,
+	}
+}
+
+// WithClock changes the clock the sandbox is seeded at, the dates of its data follow from it
+func (uc *CreateDeveloperSandboxUseCase) WithClock(clock clock.Clock) *CreateDeveloperSandboxUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute seeds a synthetic tenant and creates its service account, holding entities.SandboxRole in
+// it, with a key that never expires. The token of the key is returned once. The sandbox is only
+// recorded once everything else is done, a failure leaves rows under a tenant no key works in.
+func (uc *CreateDeveloperSandboxUseCase) Execute(ctx context.Context, cmd *CreateDeveloperSandboxCommand) (*entities.DeveloperSandbox,
+	*entities.SyntheticData, string, error) {
+	now := uc.clock.Now().UTC()
+	sandbox, err := entities.NewDeveloperSandbox(uuid.NewString(), cmd.Name, cmd.Email, now)
+	if err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	requested, err := uc.sandboxRepo.CountByEmail(ctx, sandbox.Email)
+	if err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	if requested >= entities.MaxSandboxesPerEmail {
+		return nil, nil, "", devSandboxErrors.NewDeveloperSandboxLimitReachedError(sandbox.Email, entities.MaxSandboxesPerEmail)
+	}
+
+	// The developer owns the account, they are not a member of any tenant
+	account, err := serviceAccountEntities.NewServiceAccount(uuid.NewString(), sandbox.TenantID, sandbox.Name,
+		"Key of the developer sandbox", sandbox.Email, sandbox.Email, serviceAccountEntities.ClientTypeServer, now)
+	if err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	account.Roles = []string{entities.SandboxRole}
+	key, token, err := account.IssueKey(now, serviceAccountEntities.DefaultTokenPolicy(sandbox.TenantID, account.ClientType), "", nil)
+	if err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	sandbox.ServiceAccountID = account.ID
+
+	data := entities.NewSyntheticData(sandbox.TenantID, now)
+	sandbox.Tables, err = uc.data.Replace(ctx, sandbox.TenantID, data)
+	if err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	if err := uc.policies.ReplaceRoles(ctx, sandbox.TenantID, sandbox.Roles(data)); err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	if err := uc.serviceAccountRepo.Create(ctx, account, serviceAccountEntities.ServiceAccountChange{
+		Type:    events.ServiceAccountCreated,
+		ActorID: sandbox.Email,
+		KeyID:   key.ID,
+	}); err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	if err := uc.sandboxRepo.Create(ctx, sandbox); err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+
+	// The policies apply on this instance right away, the others pick the sandbox up on their next sync
+	tenantIDs, err := uc.sandboxRepo.ListTenantIDs(ctx)
+	if err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	if err := uc.policies.Activate(ctx, tenantIDs); err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+
+	return sandbox, data, token, nil
+}
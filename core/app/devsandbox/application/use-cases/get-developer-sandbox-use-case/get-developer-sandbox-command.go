@@ -0,0 +1,26 @@
+package get_developer_sandbox_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetDeveloperSandboxCommand struct {
+	TenantID string `validate:"required"`
+}
+
+func NewGetDeveloperSandboxCommand(tenantID string) (*GetDeveloperSandboxCommand, error) {
+	command := &GetDeveloperSandboxCommand{
+		TenantID: tenantID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
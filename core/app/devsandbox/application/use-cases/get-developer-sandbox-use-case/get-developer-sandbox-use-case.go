@@ -0,0 +1,35 @@
+package get_developer_sandbox_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/entities"
+	devSandboxErrors "github.com/nahualventure/class-backend/core/app/devsandbox/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetDeveloperSandboxUseCase struct {
+	sandboxRepo ports.DeveloperSandboxRepository
+}
+
+func NewGetDeveloperSandboxUseCase(sandboxRepo ports.DeveloperSandboxRepository) *GetDeveloperSandboxUseCase {
+	return &GetDeveloperSandboxUseCase{
+		sandboxRepo: sandboxRepo,
+	}
+}
+
+// Execute returns the sandbox of the tenant with the synthetic data it was last seeded with, changes
+// made in it since are not reflected
+func (uc *GetDeveloperSandboxUseCase) Execute(ctx context.Context, cmd *GetDeveloperSandboxCommand) (*entities.DeveloperSandbox,
+	*entities.SyntheticData, error) {
+	sandbox, err := uc.sandboxRepo.FindByTenant(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if sandbox == nil {
+		return nil, nil, devSandboxErrors.NewDeveloperSandboxNotFoundError(cmd.TenantID)
+	}
+
+	return sandbox, entities.NewSyntheticData(sandbox.TenantID, sandbox.SeededAt()), nil
+}
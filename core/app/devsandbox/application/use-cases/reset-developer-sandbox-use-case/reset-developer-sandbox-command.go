@@ -0,0 +1,26 @@
+package reset_developer_sandbox_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ResetDeveloperSandboxCommand struct {
+	TenantID string `validate:"required"`
+}
+
+func NewResetDeveloperSandboxCommand(tenantID string) (*ResetDeveloperSandboxCommand, error) {
+	command := &ResetDeveloperSandboxCommand{
+		TenantID: tenantID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
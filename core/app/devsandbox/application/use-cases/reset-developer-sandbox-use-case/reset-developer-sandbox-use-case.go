@@ -0,0 +1,64 @@
+package reset_developer_sandbox_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/entities"
+	devSandboxErrors "github.com/nahualventure/class-backend/core/app/devsandbox/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ResetDeveloperSandboxUseCase struct {
+	sandboxRepo ports.DeveloperSandboxRepository
+	data        ports.SyntheticData
+	policies    ports.DeveloperSandboxPolicies
+	clock       clock.Clock
+}
+
+func NewResetDeveloperSandboxUseCase(sandboxRepo ports.DeveloperSandboxRepository, data ports.SyntheticData,
+	policies ports.DeveloperSandboxPolicies) *ResetDeveloperSandboxUseCase {
+	return &ResetDeveloperSandboxUseCase{
+		sandboxRepo: sandboxRepo,
+		data:        data,
+		policies:    policies,
+		clock:       clock.System,
+	}
+}
+
+// WithClock changes the clock the sandbox is seeded at, the dates of its data follow from it
+func (uc *ResetDeveloperSandboxUseCase) WithClock(clock clock.Clock) *ResetDeveloperSandboxUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute throws away everything written in the sandbox of the tenant and seeds it again with the
+// same people and classes. The roles given in it are replaced as well, the key of the sandbox keeps
+// working. Tenants other than developer sandboxes are not found.
+func (uc *ResetDeveloperSandboxUseCase) Execute(ctx context.Context, cmd *ResetDeveloperSandboxCommand) (*entities.DeveloperSandbox,
+	*entities.SyntheticData, error) {
+	sandbox, err := uc.sandboxRepo.FindByTenant(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if sandbox == nil {
+		return nil, nil, devSandboxErrors.NewDeveloperSandboxNotFoundError(cmd.TenantID)
+	}
+
+	now := uc.clock.Now().UTC()
+	data := entities.NewSyntheticData(sandbox.TenantID, now)
+	tables, err := uc.data.Replace(ctx, sandbox.TenantID, data)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if err := uc.policies.ReplaceRoles(ctx, sandbox.TenantID, sandbox.Roles(data)); err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+
+	sandbox.Reset(tables, now)
+	if err := uc.sandboxRepo.SaveReset(ctx, sandbox); err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	return sandbox, data, nil
+}
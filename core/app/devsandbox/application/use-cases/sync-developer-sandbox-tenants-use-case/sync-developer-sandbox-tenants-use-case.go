@@ -0,0 +1,34 @@
+package sync_developer_sandbox_tenants_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SyncDeveloperSandboxTenantsUseCase struct {
+	sandboxRepo ports.DeveloperSandboxRepository
+	policies    ports.DeveloperSandboxPolicies
+}
+
+func NewSyncDeveloperSandboxTenantsUseCase(sandboxRepo ports.DeveloperSandboxRepository,
+	policies ports.DeveloperSandboxPolicies) *SyncDeveloperSandboxTenantsUseCase {
+	return &SyncDeveloperSandboxTenantsUseCase{
+		sandboxRepo: sandboxRepo,
+		policies:    policies,
+	}
+}
+
+// Execute makes the policies of the roles apply to every developer sandbox. Every instance runs it,
+// creating a sandbox runs it right away on the instance it runs on.
+func (uc *SyncDeveloperSandboxTenantsUseCase) Execute(ctx context.Context) error {
+	tenantIDs, err := uc.sandboxRepo.ListTenantIDs(ctx)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if err := uc.policies.Activate(ctx, tenantIDs); err != nil {
+		return errors.PropagateError(err)
+	}
+	return nil
+}
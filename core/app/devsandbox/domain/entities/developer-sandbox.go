@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	serviceAccountEntities "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// TenantPrefix starts the tenant ID of every developer sandbox, onboarded tenants cannot use it
+const TenantPrefix = "dev-"
+
+// MaxSandboxesPerEmail bounds the developer sandboxes requested with one email
+const MaxSandboxesPerEmail = 3
+
+// SandboxRole is the role the service account of a developer sandbox holds in its tenant
+const SandboxRole = "admin"
+
+// DeveloperSandbox is a synthetic tenant for integrators to build against, seeded with fake classes
+// and students. They call the API in it with the key of its service account, which only works in
+// its tenant, and no data of a school is ever copied into it.
+type DeveloperSandbox struct {
+	ID       string `validate:"required,uuid"`
+	TenantID string `validate:"required"`
+	Name     string `validate:"required,max=100"`
+	Email    string `validate:"required,email,max=255"`
+	// ServiceAccountID is the account the key of the sandbox was issued for
+	ServiceAccountID string
+	// Tables counts the rows the last seed wrote per table
+	Tables     map[string]int
+	ResetCount int
+	CreatedAt  time.Time `validate:"required"`
+	ResetAt    *time.Time
+}
+
+// DeveloperTenantID returns the tenant ID of a developer sandbox
+func DeveloperTenantID(sandboxID string) string {
+	hex := strings.ReplaceAll(sandboxID, "-", "")
+	return TenantPrefix + hex[:min(len(hex), 16)]
+}
+
+// IsDeveloperTenant reports whether a tenant ID names a developer sandbox
+func IsDeveloperTenant(tenantID string) bool {
+	return strings.HasPrefix(tenantID, TenantPrefix)
+}
+
+func NewDeveloperSandbox(id string, name string, email string, now time.Time) (*DeveloperSandbox, error) {
+	sandbox := &DeveloperSandbox{
+		ID:        id,
+		TenantID:  DeveloperTenantID(id),
+		Name:      strings.TrimSpace(name),
+		Email:     strings.ToLower(strings.TrimSpace(email)),
+		Tables:    map[string]int{},
+		CreatedAt: now,
+	}
+
+	if err := validate.Struct(sandbox); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("DeveloperSandbox domain model instance not valid", errorMap, err)
+	}
+
+	return sandbox, nil
+}
+
+// SeededAt is when the synthetic data of the sandbox was last written, its dates follow from it
+func (s *DeveloperSandbox) SeededAt() time.Time {
+	if s.ResetAt != nil {
+		return *s.ResetAt
+	}
+	return s.CreatedAt
+}
+
+// Reset records that the sandbox was seeded again, with tables rows written per table
+func (s *DeveloperSandbox) Reset(tables map[string]int, now time.Time) {
+	s.Tables = tables
+	s.ResetCount++
+	s.ResetAt = &now
+}
+
+// Roles returns the subjects holding every role in the sandbox seeded with data: its service account
+// and the synthetic people
+func (s *DeveloperSandbox) Roles(data *SyntheticData) map[string][]string {
+	roles := map[string][]string{SandboxRole: {serviceAccountEntities.SubjectPrefix + s.ServiceAccountID}}
+	for _, person := range data.People {
+		roles[person.Role] = append(roles[person.Role], person.ID)
+	}
+	return roles
+}
@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sizes of the synthetic data of a developer sandbox
+const (
+	syntheticClasses          = 4
+	syntheticStudents         = 24
+	syntheticStudentsPerClass = 12
+	syntheticInstructors      = 2
+	// The last assignment of every class is due after the seed and has no grades yet
+	syntheticAssignmentsPerClass = 3
+)
+
+var (
+	syntheticFirstNames = []string{"Ana", "Bruno", "Carla", "Diego", "Elena", "Felipe", "Gabriela", "Hugo", "Isabel", "Javier", "Lucia", "Mateo"}
+	syntheticLastNames  = []string{"Alvarez", "Barrios", "Castillo", "Duarte", "Estrada", "Fuentes", "Galvez", "Herrera"}
+	syntheticClassNames = []string{"Algebra I", "Biology", "World History", "English Literature"}
+)
+
+// SyntheticPerson is a fake member of a developer sandbox, they hold Role in its tenant but have no
+// user to sign in with
+type SyntheticPerson struct {
+	ID   string
+	Name string
+	Role string
+}
+
+type SyntheticMeeting struct {
+	// Weekday is 0 for sunday
+	Weekday     int
+	StartMinute int
+	EndMinute   int
+}
+
+type SyntheticAssignment struct {
+	ID    string
+	Name  string
+	DueAt time.Time
+}
+
+// SyntheticGrade is a released grade of a student, turned in before the assignment was due
+type SyntheticGrade struct {
+	ID           string
+	AssignmentID string
+	StudentID    string
+	Score        float64
+}
+
+type SyntheticClass struct {
+	ID           string
+	Name         string
+	InstructorID string
+	Capacity     int
+	Meetings     []SyntheticMeeting
+	StudentIDs   []string
+	Assignments  []SyntheticAssignment
+	Grades       []SyntheticGrade
+}
+
+// SyntheticData is what a developer sandbox is seeded with. It is the same for a tenant every time
+// but for its dates, which follow from when it is seeded, so integrators can keep its IDs in their
+// tests across resets.
+type SyntheticData struct {
+	People  []SyntheticPerson
+	Classes []SyntheticClass
+	// SeededAt is when the data was seeded, the first assignments were due before and the last
+	// ones are due after
+	SeededAt time.Time
+}
+
+// NewSyntheticData generates the synthetic data of the tenant of a developer sandbox seeded at now
+func NewSyntheticData(tenantID string, now time.Time) *SyntheticData {
+	id := func(kind string, index int) string {
+		return uuid.NewSHA1(uuid.NameSpaceURL, fmt.Appendf(nil, "%s/%s/%d", tenantID, kind, index)).String()
+	}
+	day := now.UTC().Truncate(24 * time.Hour)
+
+	data := &SyntheticData{SeededAt: now}
+	for i := range syntheticInstructors {
+		data.People = append(data.People, SyntheticPerson{
+			ID:   id("instructor", i),
+			Name: syntheticName(len(syntheticFirstNames) - 1 - i),
+			Role: "instructor",
+		})
+	}
+	students := make([]string, 0, syntheticStudents)
+	for i := range syntheticStudents {
+		students = append(students, id("student", i))
+		data.People = append(data.People, SyntheticPerson{ID: students[i], Name: syntheticName(i), Role: "student"})
+	}
+
+	for c := range syntheticClasses {
+		class := SyntheticClass{
+			ID:           id("class", c),
+			Name:         syntheticClassNames[c],
+			InstructorID: data.People[c%syntheticInstructors].ID,
+			Capacity:     syntheticStudentsPerClass + 4,
+		}
+		// Classes meet monday and wednesday or tuesday and thursday, an hour each from 8:00
+		for _, weekday := range []int{1 + c%2, 3 + c%2} {
+			start := (8 + c/2) * 60
+			class.Meetings = append(class.Meetings, SyntheticMeeting{Weekday: weekday, StartMinute: start, EndMinute: start + 60})
+		}
+		// Classes share half their students with the next one
+		for s := range syntheticStudentsPerClass {
+			class.StudentIDs = append(class.StudentIDs, students[(c*syntheticStudentsPerClass/2+s)%syntheticStudents])
+		}
+		// Assignments were due a week apart until the last one, due in a week
+		for a := range syntheticAssignmentsPerClass {
+			last := a == syntheticAssignmentsPerClass-1
+			dueInDays := -7 * (syntheticAssignmentsPerClass - 1 - a)
+			if last {
+				dueInDays = 7
+			}
+			assignment := SyntheticAssignment{
+				ID:    id(fmt.Sprintf("class/%d/assignment", c), a),
+				Name:  fmt.Sprintf("%s assignment %d", class.Name, a+1),
+				DueAt: day.AddDate(0, 0, dueInDays),
+			}
+			class.Assignments = append(class.Assignments, assignment)
+			if last {
+				continue
+			}
+			for s, studentID := range class.StudentIDs {
+				class.Grades = append(class.Grades, SyntheticGrade{
+					ID:           id(fmt.Sprintf("class/%d/assignment/%d/grade", c, a), s),
+					AssignmentID: assignment.ID,
+					StudentID:    studentID,
+					Score:        float64(55 + (s*17+a*11+c*5)%46),
+				})
+			}
+		}
+		data.Classes = append(data.Classes, class)
+	}
+	return data
+}
+
+func syntheticName(index int) string {
+	return syntheticFirstNames[index%len(syntheticFirstNames)] + " " +
+		syntheticLastNames[(index+index/len(syntheticFirstNames))%len(syntheticLastNames)]
+}
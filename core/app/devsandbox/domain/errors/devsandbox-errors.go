@@ -0,0 +1,45 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// DeveloperSandboxNotFoundError is returned for tenants that are not developer sandboxes, schools
+	// cannot be reset
+	DeveloperSandboxNotFoundError     errors2.ErrorCode = "DEVELOPER_SANDBOX_NOT_FOUND"
+	DeveloperSandboxLimitReachedError errors2.ErrorCode = "DEVELOPER_SANDBOX_LIMIT_REACHED"
+)
+
+func NewDeveloperSandboxNotFoundError(tenantID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    DeveloperSandboxNotFoundError.String(),
+			Message: "The tenant is not a developer sandbox",
+			Context: map[string]any{
+				"tenant_id": tenantID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(DeveloperSandboxNotFoundError.String()),
+		},
+	}
+}
+
+func NewDeveloperSandboxLimitReachedError(email string, limit int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    DeveloperSandboxLimitReachedError.String(),
+			Message: "The email requested as many developer sandboxes as it may, reset one of them instead",
+			Context: map[string]any{
+				"email": email,
+				"limit": limit,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(DeveloperSandboxLimitReachedError.String()),
+		},
+	}
+}
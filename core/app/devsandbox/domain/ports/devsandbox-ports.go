@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/entities"
+)
+
+type DeveloperSandboxRepository interface {
+	Create(ctx context.Context, sandbox *entities.DeveloperSandbox) error
+	// FindByTenant returns nil when the tenant is not a developer sandbox
+	FindByTenant(ctx context.Context, tenantID string) (*entities.DeveloperSandbox, error)
+	// CountByEmail counts the sandboxes requested with the email
+	CountByEmail(ctx context.Context, email string) (int, error)
+	// SaveReset saves the tables and reset count and time of the sandbox
+	SaveReset(ctx context.Context, sandbox *entities.DeveloperSandbox) error
+	// ListTenantIDs returns the tenant IDs of every developer sandbox
+	ListTenantIDs(ctx context.Context) ([]string, error)
+}
+
+// SyntheticData writes the synthetic data of developer sandboxes
+type SyntheticData interface {
+	// Replace deletes every row of tenantID other than its sandbox and service accounts and writes
+	// data instead, in one transaction. It refuses tenants that are not developer sandboxes and
+	// returns the rows written per table.
+	Replace(ctx context.Context, tenantID string, data *entities.SyntheticData) (map[string]int, error)
+}
+
+// DeveloperSandboxPolicies gives developer sandboxes policies of their own
+type DeveloperSandboxPolicies interface {
+	// ReplaceRoles removes every role assignment of tenantID and gives roles to the subjects instead,
+	// subjects keyed by role
+	ReplaceRoles(ctx context.Context, tenantID string, roles map[string][]string) error
+	// Activate makes the policies of the roles apply to tenantIDs, and to no other developer sandbox
+	Activate(ctx context.Context, tenantIDs []string) error
+}
@@ -0,0 +1,28 @@
+package get_sync_conflict_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetSyncConflictCommand struct {
+	TenantID   string `validate:"required"`
+	ConflictID string `validate:"required,uuid"`
+}
+
+func NewGetSyncConflictCommand(tenantID string, conflictID string) (*GetSyncConflictCommand, error) {
+	command := &GetSyncConflictCommand{
+		TenantID:   tenantID,
+		ConflictID: conflictID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
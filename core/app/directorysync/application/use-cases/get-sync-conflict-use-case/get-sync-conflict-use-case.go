@@ -0,0 +1,31 @@
+package get_sync_conflict_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	directorySyncErrors "github.com/nahualventure/class-backend/core/app/directorysync/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetSyncConflictUseCase struct {
+	conflicts ports.SyncConflictRepository
+}
+
+func NewGetSyncConflictUseCase(conflicts ports.SyncConflictRepository) *GetSyncConflictUseCase {
+	return &GetSyncConflictUseCase{
+		conflicts: conflicts,
+	}
+}
+
+func (uc *GetSyncConflictUseCase) Execute(ctx context.Context, cmd *GetSyncConflictCommand) (*entities.SyncConflict, error) {
+	conflict, err := uc.conflicts.FindByID(ctx, cmd.TenantID, cmd.ConflictID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if conflict == nil {
+		return nil, directorySyncErrors.NewSyncConflictNotFoundError(cmd.ConflictID)
+	}
+	return conflict, nil
+}
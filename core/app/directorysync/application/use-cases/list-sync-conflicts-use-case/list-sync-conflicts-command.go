@@ -0,0 +1,39 @@
+package list_sync_conflicts_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListSyncConflictsCommand struct {
+	TenantID string                      `validate:"required"`
+	Status   entities.SyncConflictStatus `validate:"required,oneof=open resolved"`
+	Page     *pagination.PageRequest
+}
+
+// NewListSyncConflictsCommand lists the open conflicts when status is empty
+func NewListSyncConflictsCommand(tenantID string, status string, pageSize int, cursor string) (*ListSyncConflictsCommand, error) {
+	if status == "" {
+		status = string(entities.SyncConflictStatusOpen)
+	}
+	page, err := pagination.NewPageRequest(pageSize, cursor, "", nil, pagination.OrderBy{
+		Field:     "detected_at",
+		Direction: pagination.Descending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	command := &ListSyncConflictsCommand{TenantID: tenantID, Status: entities.SyncConflictStatus(status), Page: page}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
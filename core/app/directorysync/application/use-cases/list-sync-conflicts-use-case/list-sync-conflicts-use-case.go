@@ -0,0 +1,28 @@
+package list_sync_conflicts_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type ListSyncConflictsUseCase struct {
+	conflicts ports.SyncConflictRepository
+}
+
+func NewListSyncConflictsUseCase(conflicts ports.SyncConflictRepository) *ListSyncConflictsUseCase {
+	return &ListSyncConflictsUseCase{
+		conflicts: conflicts,
+	}
+}
+
+func (uc *ListSyncConflictsUseCase) Execute(ctx context.Context, cmd *ListSyncConflictsCommand) (*pagination.Page[*entities.SyncConflict], error) {
+	page, err := uc.conflicts.List(ctx, cmd.TenantID, cmd.Status, cmd.Page)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return page, nil
+}
@@ -0,0 +1,28 @@
+package list_sync_policies_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListSyncPoliciesUseCase struct {
+	policies ports.SyncPolicyRepository
+}
+
+func NewListSyncPoliciesUseCase(policies ports.SyncPolicyRepository) *ListSyncPoliciesUseCase {
+	return &ListSyncPoliciesUseCase{
+		policies: policies,
+	}
+}
+
+// Execute returns the policy of every synced field, the default of the fields the tenant did not set
+func (uc *ListSyncPoliciesUseCase) Execute(ctx context.Context, cmd *ListSyncPoliciesCommand) ([]*entities.SyncPolicy, error) {
+	stored, err := uc.policies.List(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return entities.NewSyncPolicies(cmd.TenantID, stored).Ordered(), nil
+}
@@ -0,0 +1,33 @@
+package resolve_sync_conflict_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ResolveSyncConflictCommand struct {
+	TenantID   string                  `validate:"required"`
+	ConflictID string                  `validate:"required,uuid"`
+	Resolution entities.SyncResolution `validate:"required,oneof=upstream local"`
+	ResolvedBy string                  `validate:"required"`
+}
+
+func NewResolveSyncConflictCommand(tenantID string, conflictID string, resolution string, resolvedBy string) (*ResolveSyncConflictCommand, error) {
+	command := &ResolveSyncConflictCommand{
+		TenantID:   tenantID,
+		ConflictID: conflictID,
+		Resolution: entities.SyncResolution(resolution),
+		ResolvedBy: resolvedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,46 @@
+package resolve_sync_conflict_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	directorySyncErrors "github.com/nahualventure/class-backend/core/app/directorysync/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ResolveSyncConflictUseCase struct {
+	conflicts ports.SyncConflictRepository
+}
+
+func NewResolveSyncConflictUseCase(conflicts ports.SyncConflictRepository) *ResolveSyncConflictUseCase {
+	return &ResolveSyncConflictUseCase{
+		conflicts: conflicts,
+	}
+}
+
+// Execute gives the field the side the admin picked. Either way the directory value is recorded as
+// synced, so the conflict is not reported again until the directory changes the field.
+func (uc *ResolveSyncConflictUseCase) Execute(ctx context.Context, cmd *ResolveSyncConflictCommand) (*entities.SyncConflict, error) {
+	conflict, err := uc.conflicts.FindByID(ctx, cmd.TenantID, cmd.ConflictID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if conflict == nil {
+		return nil, directorySyncErrors.NewSyncConflictNotFoundError(cmd.ConflictID)
+	}
+	if conflict.Status != entities.SyncConflictStatusOpen {
+		return nil, directorySyncErrors.NewSyncConflictNotOpenError(conflict.ID, string(conflict.Status))
+	}
+
+	conflict.Resolve(cmd.Resolution, cmd.ResolvedBy, time.Now().UTC())
+	resolved, err := uc.conflicts.Resolve(ctx, conflict)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !resolved {
+		return nil, directorySyncErrors.NewSyncConflictNotOpenError(conflict.ID, string(entities.SyncConflictStatusResolved))
+	}
+	return conflict, nil
+}
@@ -0,0 +1,33 @@
+package set_sync_policy_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SetSyncPolicyCommand struct {
+	TenantID   string                  `validate:"required"`
+	Field      string                  `validate:"required,oneof=name email"`
+	OnConflict entities.ConflictPolicy `validate:"required,oneof=upstream_wins local_wins manual"`
+	UpdatedBy  string                  `validate:"required"`
+}
+
+func NewSetSyncPolicyCommand(tenantID string, field string, onConflict string, updatedBy string) (*SetSyncPolicyCommand, error) {
+	command := &SetSyncPolicyCommand{
+		TenantID:   tenantID,
+		Field:      field,
+		OnConflict: entities.ConflictPolicy(onConflict),
+		UpdatedBy:  updatedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
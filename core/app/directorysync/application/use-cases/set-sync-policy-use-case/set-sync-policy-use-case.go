@@ -0,0 +1,34 @@
+package set_sync_policy_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetSyncPolicyUseCase struct {
+	policies ports.SyncPolicyRepository
+}
+
+func NewSetSyncPolicyUseCase(policies ports.SyncPolicyRepository) *SetSyncPolicyUseCase {
+	return &SetSyncPolicyUseCase{
+		policies: policies,
+	}
+}
+
+// Execute replaces the policy of a field. Syncs from now on follow it, the conflicts already queued
+// stay open until an admin resolves them.
+func (uc *SetSyncPolicyUseCase) Execute(ctx context.Context, cmd *SetSyncPolicyCommand) (*entities.SyncPolicy, error) {
+	policy, err := entities.NewSyncPolicy(cmd.TenantID, cmd.Field, cmd.OnConflict, cmd.UpdatedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.policies.Save(ctx, policy); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return policy, nil
+}
@@ -0,0 +1,40 @@
+package sync_directory_users_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const MaxSyncSize = 1000
+
+var validate = validator.New()
+
+// SyncDirectoryUserItem is a user as the directory sends it, validated individually by the use case
+type SyncDirectoryUserItem struct {
+	ExternalID string
+	Name       string
+	Email      string
+}
+
+// SyncDirectoryUsersCommand only validates the envelope, item level validation is reported per item
+type SyncDirectoryUsersCommand struct {
+	TenantID string                  `validate:"required"`
+	Items    []SyncDirectoryUserItem `validate:"required,min=1,max=1000"`
+	SyncedBy string                  `validate:"required"`
+}
+
+func NewSyncDirectoryUsersCommand(tenantID string, items []SyncDirectoryUserItem, syncedBy string) (*SyncDirectoryUsersCommand, error) {
+	command := &SyncDirectoryUsersCommand{
+		TenantID: tenantID,
+		Items:    items,
+		SyncedBy: syncedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,177 @@
+package sync_directory_users_use_case
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	directorySyncErrors "github.com/nahualventure/class-backend/core/app/directorysync/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type ItemStatus string
+
+const (
+	// ItemUnchanged users took nothing from the directory
+	ItemUnchanged ItemStatus = "unchanged"
+	// ItemUpdated users took at least one value of the directory
+	ItemUpdated ItemStatus = "updated"
+	// ItemUnmatched users of the directory match no member of the tenant imported with their external ID
+	ItemUnmatched ItemStatus = "unmatched"
+	ItemFailed    ItemStatus = "failed"
+)
+
+// ItemResult is the outcome of a user of the sync, Err is set only when Status is ItemFailed
+type ItemResult struct {
+	Index      int
+	ExternalID string
+	UserID     string
+	Status     ItemStatus
+	Fields     []entities.FieldSync
+	Err        error
+}
+
+func (r *ItemResult) fail(err error) {
+	r.Status = ItemFailed
+	r.Err = err
+}
+
+// SyncDirectoryUsersResult counts the users by status. Conflicts counts the fields changed on both
+// sides, Queued the ones of them waiting for an admin.
+type SyncDirectoryUsersResult struct {
+	Items     []ItemResult
+	Updated   int
+	Unchanged int
+	Unmatched int
+	Failed    int
+	Conflicts int
+	Queued    int
+}
+
+type SyncDirectoryUsersUseCase struct {
+	users    ports.DirectoryUserRepository
+	policies ports.SyncPolicyRepository
+}
+
+func NewSyncDirectoryUsersUseCase(users ports.DirectoryUserRepository, policies ports.SyncPolicyRepository) *SyncDirectoryUsersUseCase {
+	return &SyncDirectoryUsersUseCase{
+		users:    users,
+		policies: policies,
+	}
+}
+
+// Execute takes the names and emails of the directory for the members imported with the same
+// external ID, settling the fields changed on both sides by the policies of the tenant. Every user
+// is saved in its own transaction, so a failing user only marks itself as failed.
+func (uc *SyncDirectoryUsersUseCase) Execute(ctx context.Context, cmd *SyncDirectoryUsersCommand) (*SyncDirectoryUsersResult, error) {
+	results := make([]ItemResult, len(cmd.Items))
+	upstream := make(map[int]*entities.DirectoryUser, len(cmd.Items))
+	externalIDs := make([]string, 0, len(cmd.Items))
+	seen := make(map[string]int, len(cmd.Items))
+
+	for i, item := range cmd.Items {
+		results[i] = ItemResult{Index: i, ExternalID: item.ExternalID}
+
+		user, err := entities.NewDirectoryUser(item.ExternalID, item.Name, item.Email)
+		if err != nil {
+			results[i].fail(err)
+			continue
+		}
+
+		normalizedID := strings.ToLower(user.ExternalID)
+		if firstIndex, duplicated := seen[normalizedID]; duplicated {
+			results[i].fail(directorySyncErrors.NewDuplicateDirectoryUserError(user.ExternalID, firstIndex))
+			continue
+		}
+		seen[normalizedID] = i
+		upstream[i] = user
+		externalIDs = append(externalIDs, user.ExternalID)
+	}
+
+	stored, err := uc.policies.List(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	policies := entities.NewSyncPolicies(cmd.TenantID, stored)
+
+	locals, err := uc.users.FindByExternalIDs(ctx, cmd.TenantID, externalIDs)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	byExternalID := make(map[string]*entities.LocalUser, len(locals))
+	for _, local := range locals {
+		byExternalID[strings.ToLower(local.ExternalID)] = local
+	}
+
+	now := time.Now().UTC()
+	for i := range results {
+		directoryUser, ok := upstream[i]
+		if !ok {
+			continue
+		}
+		local, ok := byExternalID[strings.ToLower(directoryUser.ExternalID)]
+		if !ok {
+			results[i].Status = ItemUnmatched
+			continue
+		}
+
+		results[i].UserID = local.UserID
+		if err := uc.sync(ctx, cmd.TenantID, local, directoryUser, policies, now, &results[i]); err != nil {
+			results[i].fail(err)
+		}
+	}
+
+	result := &SyncDirectoryUsersResult{Items: results}
+	for _, item := range results {
+		switch item.Status {
+		case ItemUpdated:
+			result.Updated++
+		case ItemUnchanged:
+			result.Unchanged++
+		case ItemUnmatched:
+			result.Unmatched++
+		case ItemFailed:
+			result.Failed++
+			continue
+		}
+		for _, field := range item.Fields {
+			if field.Conflict {
+				result.Conflicts++
+			}
+			if field.Outcome == entities.SyncOutcomeQueued {
+				result.Queued++
+			}
+		}
+	}
+	return result, nil
+}
+
+func (uc *SyncDirectoryUsersUseCase) sync(ctx context.Context, tenantID string, local *entities.LocalUser,
+	directoryUser *entities.DirectoryUser, policies entities.SyncPolicies, now time.Time, result *ItemResult) error {
+	syncs := local.Sync(directoryUser, policies)
+
+	result.Status = ItemUnchanged
+	var conflicts []*entities.SyncConflict
+	for _, sync := range syncs {
+		switch sync.Outcome {
+		case entities.SyncOutcomeUpdated:
+			result.Status = ItemUpdated
+		case entities.SyncOutcomeQueued:
+			conflict, err := entities.NewSyncConflict(uuid.New().String(), tenantID, local, sync, now)
+			if err != nil {
+				return errors.PropagateError(err)
+			}
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	if err := uc.users.SaveSync(ctx, tenantID, local, syncs, conflicts); err != nil {
+		return errors.PropagateError(err)
+	}
+	result.Fields = syncs
+	return nil
+}
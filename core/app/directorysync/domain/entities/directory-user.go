@@ -0,0 +1,150 @@
+package entities
+
+import (
+	"strings"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// Fields of the users the sync keeps in step with the directory
+const (
+	FieldName  = "name"
+	FieldEmail = "email"
+)
+
+// Fields lists every synced field, in the order policies are listed
+var Fields = []string{FieldName, FieldEmail}
+
+// DirectoryUser is a user as the roster or SCIM directory of the tenant sends it. It is matched to
+// the member of the tenant imported with the same external ID.
+type DirectoryUser struct {
+	ExternalID string `validate:"required,max=255"`
+	Name       string `validate:"required,max=255"`
+	Email      string `validate:"required,email,max=255"`
+}
+
+func NewDirectoryUser(externalID string, name string, email string) (*DirectoryUser, error) {
+	user := &DirectoryUser{
+		ExternalID: strings.TrimSpace(externalID),
+		Name:       strings.TrimSpace(name),
+		Email:      strings.TrimSpace(email),
+	}
+
+	if err := validate.Struct(user); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewValidationError("Invalid directory user", errorMap, err)
+	}
+
+	return user, nil
+}
+
+// Value returns the value the directory has for field
+func (u *DirectoryUser) Value(field string) string {
+	if field == FieldEmail {
+		return u.Email
+	}
+	return u.Name
+}
+
+// LocalUser is a member of the tenant imported with an external ID. Synced holds the value of every
+// field as the last sync took it from the directory, a field missing from it was never synced.
+type LocalUser struct {
+	UserID     string
+	ExternalID string
+	Name       string
+	Email      string
+	Synced     map[string]string
+}
+
+// Value returns the value the user has for field
+func (u *LocalUser) Value(field string) string {
+	if field == FieldEmail {
+		return u.Email
+	}
+	return u.Name
+}
+
+func (u *LocalUser) set(field string, value string) {
+	if field == FieldEmail {
+		u.Email = value
+	} else {
+		u.Name = value
+	}
+}
+
+// SyncOutcome is what a sync did with a field of a user
+type SyncOutcome string
+
+const (
+	// SyncOutcomeUnchanged the field already had the value of the directory, or only changed locally
+	SyncOutcomeUnchanged SyncOutcome = "unchanged"
+	// SyncOutcomeUpdated the field took the value of the directory
+	SyncOutcomeUpdated SyncOutcome = "updated"
+	// SyncOutcomeKeptLocal the field changed on both sides and local_wins kept the local value
+	SyncOutcomeKeptLocal SyncOutcome = "kept_local"
+	// SyncOutcomeQueued the field changed on both sides and waits for an admin to resolve the conflict
+	SyncOutcomeQueued SyncOutcome = "queued"
+)
+
+// FieldSync is what a sync did with a field of a user. Local is the value the user had before it.
+type FieldSync struct {
+	Field    string
+	Outcome  SyncOutcome
+	Local    string
+	Upstream string
+	// Conflict is set when the field changed both locally and in the directory since the last sync
+	Conflict bool
+}
+
+// Sync takes the values of the directory user the policies allow and records them as synced. A field
+// changed only in the directory takes its value, a field changed only locally keeps it. A field
+// changed on both sides to different values is a conflict settled by the policy of the field; queued
+// ones keep their last synced value so they are reported again until an admin resolves them. A field
+// never synced counts as changed only in the directory.
+func (u *LocalUser) Sync(upstream *DirectoryUser, policies SyncPolicies) []FieldSync {
+	if u.Synced == nil {
+		u.Synced = make(map[string]string, len(Fields))
+	}
+
+	syncs := make([]FieldSync, 0, len(Fields))
+	for _, field := range Fields {
+		sync := FieldSync{Field: field, Outcome: SyncOutcomeUnchanged, Local: u.Value(field), Upstream: upstream.Value(field)}
+		synced, wasSynced := u.Synced[field]
+		changedLocally := wasSynced && sync.Local != synced
+		changedUpstream := !wasSynced || sync.Upstream != synced
+
+		switch {
+		case sync.Local == sync.Upstream || !changedUpstream:
+		case !changedLocally:
+			sync.Outcome = SyncOutcomeUpdated
+		default:
+			sync.Conflict = true
+			switch policies[field].OnConflict {
+			case ConflictPolicyUpstreamWins:
+				sync.Outcome = SyncOutcomeUpdated
+			case ConflictPolicyLocalWins:
+				sync.Outcome = SyncOutcomeKeptLocal
+			default:
+				sync.Outcome = SyncOutcomeQueued
+			}
+		}
+
+		if sync.Outcome == SyncOutcomeUpdated {
+			u.set(field, sync.Upstream)
+		}
+		if sync.Outcome != SyncOutcomeQueued {
+			u.Synced[field] = sync.Upstream
+		}
+		syncs = append(syncs, sync)
+	}
+	return syncs
+}
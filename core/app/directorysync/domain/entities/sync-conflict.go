@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+type SyncConflictStatus string
+
+const (
+	SyncConflictStatusOpen     SyncConflictStatus = "open"
+	SyncConflictStatusResolved SyncConflictStatus = "resolved"
+)
+
+// SyncResolution is the side an admin picked for a conflict
+type SyncResolution string
+
+const (
+	// SyncResolutionUpstream gives the field the value of the directory
+	SyncResolutionUpstream SyncResolution = "upstream"
+	// SyncResolutionLocal keeps the local value
+	SyncResolutionLocal SyncResolution = "local"
+)
+
+// SyncConflict is a field of a user changed both locally and in the directory, queued by the manual
+// policy. A user has at most one open conflict per field, syncs update it with the latest values.
+type SyncConflict struct {
+	ID            string `validate:"required,uuid"`
+	TenantID      string `validate:"required"`
+	UserID        string `validate:"required"`
+	ExternalID    string `validate:"required"`
+	Field         string `validate:"required,oneof=name email"`
+	LocalValue    string
+	UpstreamValue string
+	Status        SyncConflictStatus `validate:"required,oneof=open resolved"`
+	DetectedAt    time.Time          `validate:"required"`
+	Resolution    SyncResolution
+	ResolvedBy    string
+	ResolvedAt    *time.Time
+}
+
+func NewSyncConflict(id string, tenantID string, user *LocalUser, sync FieldSync, now time.Time) (*SyncConflict, error) {
+	conflict := &SyncConflict{
+		ID:            id,
+		TenantID:      tenantID,
+		UserID:        user.UserID,
+		ExternalID:    user.ExternalID,
+		Field:         sync.Field,
+		LocalValue:    sync.Local,
+		UpstreamValue: sync.Upstream,
+		Status:        SyncConflictStatusOpen,
+		DetectedAt:    now,
+	}
+
+	if err := validate.Struct(conflict); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("SyncConflict domain model instance not valid", errorMap, err)
+	}
+
+	return conflict, nil
+}
+
+// Resolve records the side the field keeps
+func (c *SyncConflict) Resolve(resolution SyncResolution, resolvedBy string, now time.Time) {
+	c.Status = SyncConflictStatusResolved
+	c.Resolution = resolution
+	c.ResolvedBy = resolvedBy
+	c.ResolvedAt = &now
+}
+
+// Value is the value the field has once the conflict is resolved
+func (c *SyncConflict) Value() string {
+	if c.Resolution == SyncResolutionUpstream {
+		return c.UpstreamValue
+	}
+	return c.LocalValue
+}
@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// ConflictPolicy settles a field changed both locally and in the directory since the last sync
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyUpstreamWins replaces the local value with the value of the directory
+	ConflictPolicyUpstreamWins ConflictPolicy = "upstream_wins"
+	// ConflictPolicyLocalWins keeps the local value, the directory value is not reported again until
+	// it changes
+	ConflictPolicyLocalWins ConflictPolicy = "local_wins"
+	// ConflictPolicyManual keeps the local value and queues the conflict for an admin to resolve
+	ConflictPolicyManual ConflictPolicy = "manual"
+)
+
+// DefaultConflictPolicy applies to the fields a tenant did not set a policy for, nothing changed
+// locally is overwritten without an admin looking at it
+const DefaultConflictPolicy = ConflictPolicyManual
+
+// SyncPolicy is how a tenant settles the conflicts of a synced field
+type SyncPolicy struct {
+	TenantID   string         `validate:"required"`
+	Field      string         `validate:"required,oneof=name email"`
+	OnConflict ConflictPolicy `validate:"required,oneof=upstream_wins local_wins manual"`
+	// UpdatedBy is empty for the defaults, which were never set
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// DefaultSyncPolicy returns the policy of a field the tenant did not configure
+func DefaultSyncPolicy(tenantID string, field string) *SyncPolicy {
+	return &SyncPolicy{
+		TenantID:   tenantID,
+		Field:      field,
+		OnConflict: DefaultConflictPolicy,
+	}
+}
+
+func NewSyncPolicy(tenantID string, field string, onConflict ConflictPolicy, updatedBy string, now time.Time) (*SyncPolicy, error) {
+	policy := &SyncPolicy{
+		TenantID:   tenantID,
+		Field:      field,
+		OnConflict: onConflict,
+		UpdatedBy:  updatedBy,
+		UpdatedAt:  now,
+	}
+
+	if err := validate.Struct(policy); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("SyncPolicy domain model instance not valid", errorMap, err)
+	}
+
+	return policy, nil
+}
+
+// IsDefault reports whether the tenant never configured the policy
+func (p *SyncPolicy) IsDefault() bool {
+	return p.UpdatedBy == ""
+}
+
+// SyncPolicies holds the policy of every synced field of a tenant by field
+type SyncPolicies map[string]*SyncPolicy
+
+// NewSyncPolicies fills the fields missing from stored with their default
+func NewSyncPolicies(tenantID string, stored []*SyncPolicy) SyncPolicies {
+	policies := make(SyncPolicies, len(Fields))
+	for _, field := range Fields {
+		policies[field] = DefaultSyncPolicy(tenantID, field)
+	}
+	for _, policy := range stored {
+		policies[policy.Field] = policy
+	}
+	return policies
+}
+
+// Ordered returns the policies in the order of Fields
+func (p SyncPolicies) Ordered() []*SyncPolicy {
+	ordered := make([]*SyncPolicy, 0, len(Fields))
+	for _, field := range Fields {
+		ordered = append(ordered, p[field])
+	}
+	return ordered
+}
@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	SyncConflictNotFoundError errors2.ErrorCode = "SYNC_CONFLICT_NOT_FOUND"
+	// SyncConflictNotOpenError is returned when resolving a conflict already resolved
+	SyncConflictNotOpenError errors2.ErrorCode = "SYNC_CONFLICT_NOT_OPEN"
+	// DuplicateDirectoryUserError is returned for a user of a sync repeating the external ID of an
+	// earlier user of the same sync
+	DuplicateDirectoryUserError errors2.ErrorCode = "DUPLICATE_DIRECTORY_USER"
+)
+
+func NewSyncConflictNotFoundError(conflictID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SyncConflictNotFoundError.String(),
+			Message: "Sync conflict not found",
+			Context: map[string]any{
+				"conflict_id": conflictID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SyncConflictNotFoundError.String()),
+		},
+	}
+}
+
+func NewSyncConflictNotOpenError(conflictID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SyncConflictNotOpenError.String(),
+			Message: "Only open sync conflicts can be resolved",
+			Context: map[string]any{
+				"conflict_id": conflictID,
+				"status":      status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SyncConflictNotOpenError.String()),
+		},
+	}
+}
+
+func NewDuplicateDirectoryUserError(externalID string, firstIndex int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    DuplicateDirectoryUserError.String(),
+			Message: "External ID repeats an earlier user of the sync",
+			Context: map[string]any{
+				"external_id": externalID,
+				"first_index": firstIndex,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(DuplicateDirectoryUserError.String()),
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+// DirectoryUserRepository reads the members of a tenant imported with an external ID and writes what
+// a sync took from the directory
+type DirectoryUserRepository interface {
+	// FindByExternalIDs returns the members of the tenant imported with one of externalIDs, with the
+	// values last synced. IDs no member was imported with are skipped.
+	FindByExternalIDs(ctx context.Context, tenantID string, externalIDs []string) ([]*entities.LocalUser, error)
+	// SaveSync writes the fields the sync updated and the synced values of the user, saves the queued
+	// conflicts over the open ones of the same fields and removes the open conflicts of the other
+	// fields, all in one transaction. It fails with EMAIL_ALREADY_EXISTS when the directory gives the
+	// user the email of another user.
+	SaveSync(ctx context.Context, tenantID string, user *entities.LocalUser, syncs []entities.FieldSync, conflicts []*entities.SyncConflict) error
+}
+
+type SyncConflictRepository interface {
+	// FindByID returns nil when the tenant has no such conflict
+	FindByID(ctx context.Context, tenantID string, conflictID string) (*entities.SyncConflict, error)
+	// List returns the conflicts of the tenant with status, last detected first
+	List(ctx context.Context, tenantID string, status entities.SyncConflictStatus, page *pagination.PageRequest) (*pagination.Page[*entities.SyncConflict], error)
+	// Resolve saves the resolution of an open conflict, gives the field of the user the value of the
+	// conflict and records the upstream value as synced in one transaction. It returns false without
+	// changing anything when the conflict is no longer open.
+	Resolve(ctx context.Context, conflict *entities.SyncConflict) (bool, error)
+}
+
+type SyncPolicyRepository interface {
+	// List returns the policies the tenant set
+	List(ctx context.Context, tenantID string) ([]*entities.SyncPolicy, error)
+	Save(ctx context.Context, policy *entities.SyncPolicy) error
+}
@@ -0,0 +1,26 @@
+package authenticate_district_key_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type AuthenticateDistrictKeyCommand struct {
+	Key string `validate:"required,max=100"`
+}
+
+func NewAuthenticateDistrictKeyCommand(key string) (*AuthenticateDistrictKeyCommand, error) {
+	command := &AuthenticateDistrictKeyCommand{
+		Key: key,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
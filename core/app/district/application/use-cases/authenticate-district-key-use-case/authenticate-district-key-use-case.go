@@ -0,0 +1,33 @@
+package authenticate_district_key_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	districtErrors "github.com/nahualventure/class-backend/core/app/district/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/district/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type AuthenticateDistrictKeyUseCase struct {
+	districts ports.DistrictRepository
+}
+
+func NewAuthenticateDistrictKeyUseCase(districts ports.DistrictRepository) *AuthenticateDistrictKeyUseCase {
+	return &AuthenticateDistrictKeyUseCase{
+		districts: districts,
+	}
+}
+
+// Execute returns the key when it is active. Dashboard requests are served for the district of the
+// key.
+func (uc *AuthenticateDistrictKeyUseCase) Execute(ctx context.Context, cmd *AuthenticateDistrictKeyCommand) (*entities.DistrictKey, error) {
+	key, err := uc.districts.FindKeyByHash(ctx, entities.HashKey(cmd.Key))
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if key == nil || !key.Active() {
+		return nil, districtErrors.NewInvalidDistrictKeyError()
+	}
+	return key, nil
+}
@@ -0,0 +1,28 @@
+package create_district_key_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CreateDistrictKeyCommand struct {
+	DistrictID string `validate:"required,uuid"`
+	Name       string `validate:"required,max=100"`
+}
+
+func NewCreateDistrictKeyCommand(districtID string, name string) (*CreateDistrictKeyCommand, error) {
+	command := &CreateDistrictKeyCommand{
+		DistrictID: districtID,
+		Name:       name,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,43 @@
+package create_district_key_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	districtErrors "github.com/nahualventure/class-backend/core/app/district/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/district/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type CreateDistrictKeyUseCase struct {
+	districts ports.DistrictRepository
+}
+
+func NewCreateDistrictKeyUseCase(districts ports.DistrictRepository) *CreateDistrictKeyUseCase {
+	return &CreateDistrictKeyUseCase{
+		districts: districts,
+	}
+}
+
+// Execute creates a key for the district and returns it along with the key itself, which is not
+// stored and cannot be read again
+func (uc *CreateDistrictKeyUseCase) Execute(ctx context.Context, cmd *CreateDistrictKeyCommand) (*entities.DistrictKey, string, error) {
+	district, err := uc.districts.FindByID(ctx, cmd.DistrictID)
+	if err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+	if district == nil {
+		return nil, "", districtErrors.NewDistrictNotFoundError(cmd.DistrictID)
+	}
+
+	key, token, err := entities.NewDistrictKey(district.ID, cmd.Name, time.Now().UTC())
+	if err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+
+	if err := uc.districts.CreateKey(ctx, key); err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+	return key, token, nil
+}
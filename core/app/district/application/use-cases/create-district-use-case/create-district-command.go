@@ -0,0 +1,31 @@
+package create_district_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CreateDistrictCommand struct {
+	Name      string   `validate:"required,max=200"`
+	TenantIDs []string `validate:"required,min=1,max=500,unique,dive,required,max=100"`
+	// MinCohortSize is 0 for the default k
+	MinCohortSize int `validate:"omitempty,gte=5,lte=100"`
+}
+
+func NewCreateDistrictCommand(name string, tenantIDs []string, minCohortSize int) (*CreateDistrictCommand, error) {
+	command := &CreateDistrictCommand{
+		Name:          name,
+		TenantIDs:     tenantIDs,
+		MinCohortSize: minCohortSize,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
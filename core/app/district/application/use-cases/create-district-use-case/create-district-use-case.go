@@ -0,0 +1,42 @@
+package create_district_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	districtErrors "github.com/nahualventure/class-backend/core/app/district/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/district/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type CreateDistrictUseCase struct {
+	districts ports.DistrictRepository
+}
+
+func NewCreateDistrictUseCase(districts ports.DistrictRepository) *CreateDistrictUseCase {
+	return &CreateDistrictUseCase{
+		districts: districts,
+	}
+}
+
+// Execute creates the district, schools that belong to another district are refused
+func (uc *CreateDistrictUseCase) Execute(ctx context.Context, cmd *CreateDistrictCommand) (*entities.District, error) {
+	district, err := entities.NewDistrict(cmd.Name, cmd.TenantIDs, cmd.MinCohortSize, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	claimed, err := uc.districts.TenantsInOtherDistricts(ctx, district.ID, district.TenantIDs)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if len(claimed) > 0 {
+		return nil, districtErrors.NewTenantInOtherDistrictError(claimed)
+	}
+
+	if err := uc.districts.Save(ctx, district); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return district, nil
+}
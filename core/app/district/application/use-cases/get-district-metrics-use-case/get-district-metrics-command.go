@@ -0,0 +1,53 @@
+package get_district_metrics_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// DefaultPeriod is how many days of attendance are counted when the request names no dates
+const DefaultPeriod = 30
+
+// MaxPeriod bounds the days of attendance counted in one request
+const MaxPeriod = 366
+
+// GetDistrictMetricsCommand covers the civil dates From to To, both included, kept at midnight UTC
+type GetDistrictMetricsCommand struct {
+	DistrictID string    `validate:"required,uuid"`
+	From       time.Time `validate:"required"`
+	To         time.Time `validate:"required,gtefield=From"`
+}
+
+// NewGetDistrictMetricsCommand defaults a zero to to today and a zero from to DefaultPeriod days
+// before to
+func NewGetDistrictMetricsCommand(districtID string, from time.Time, to time.Time, today time.Time) (*GetDistrictMetricsCommand, error) {
+	if to.IsZero() {
+		to = today
+	}
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	if from.IsZero() {
+		from = to.AddDate(0, 0, 1-DefaultPeriod)
+	}
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+
+	command := &GetDistrictMetricsCommand{
+		DistrictID: districtID,
+		From:       from,
+		To:         to,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if to.Sub(from) >= MaxPeriod*24*time.Hour {
+		return nil, errors.NewValidationError("The period covers at most 366 days", map[string]any{"To": "more than 366 days after From"}, nil)
+	}
+
+	return command, nil
+}
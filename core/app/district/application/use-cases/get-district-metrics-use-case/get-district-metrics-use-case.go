@@ -0,0 +1,41 @@
+package get_district_metrics_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	districtErrors "github.com/nahualventure/class-backend/core/app/district/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/district/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetDistrictMetricsUseCase struct {
+	districts ports.DistrictRepository
+	source    ports.DistrictMetricsSource
+}
+
+func NewGetDistrictMetricsUseCase(districts ports.DistrictRepository, source ports.DistrictMetricsSource) *GetDistrictMetricsUseCase {
+	return &GetDistrictMetricsUseCase{
+		districts: districts,
+		source:    source,
+	}
+}
+
+// Execute aggregates the metrics of the schools of the district, suppressing the ones with fewer
+// students than its k
+func (uc *GetDistrictMetricsUseCase) Execute(ctx context.Context, cmd *GetDistrictMetricsCommand) (*entities.DistrictMetrics, error) {
+	district, err := uc.districts.FindByID(ctx, cmd.DistrictID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if district == nil {
+		return nil, districtErrors.NewDistrictNotFoundError(cmd.DistrictID)
+	}
+
+	counts, err := uc.source.SchoolCounts(ctx, district.TenantIDs, cmd.From, cmd.To)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return entities.Aggregate(district, counts, cmd.From, cmd.To), nil
+}
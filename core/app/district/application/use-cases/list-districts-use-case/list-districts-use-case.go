@@ -0,0 +1,27 @@
+package list_districts_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/district/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListDistrictsUseCase struct {
+	districts ports.DistrictRepository
+}
+
+func NewListDistrictsUseCase(districts ports.DistrictRepository) *ListDistrictsUseCase {
+	return &ListDistrictsUseCase{
+		districts: districts,
+	}
+}
+
+func (uc *ListDistrictsUseCase) Execute(ctx context.Context) ([]*entities.District, error) {
+	districts, err := uc.districts.List(ctx)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return districts, nil
+}
@@ -0,0 +1,28 @@
+package revoke_district_key_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RevokeDistrictKeyCommand struct {
+	DistrictID string `validate:"required,uuid"`
+	KeyID      string `validate:"required,uuid"`
+}
+
+func NewRevokeDistrictKeyCommand(districtID string, keyID string) (*RevokeDistrictKeyCommand, error) {
+	command := &RevokeDistrictKeyCommand{
+		DistrictID: districtID,
+		KeyID:      keyID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
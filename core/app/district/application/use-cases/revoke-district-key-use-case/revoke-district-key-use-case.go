@@ -0,0 +1,41 @@
+package revoke_district_key_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	districtErrors "github.com/nahualventure/class-backend/core/app/district/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/district/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type RevokeDistrictKeyUseCase struct {
+	districts ports.DistrictRepository
+}
+
+func NewRevokeDistrictKeyUseCase(districts ports.DistrictRepository) *RevokeDistrictKeyUseCase {
+	return &RevokeDistrictKeyUseCase{
+		districts: districts,
+	}
+}
+
+// Execute revokes the key of the district, revoking a revoked key keeps its first revocation
+func (uc *RevokeDistrictKeyUseCase) Execute(ctx context.Context, cmd *RevokeDistrictKeyCommand) (*entities.DistrictKey, error) {
+	key, err := uc.districts.FindKey(ctx, cmd.DistrictID, cmd.KeyID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if key == nil {
+		return nil, districtErrors.NewDistrictKeyNotFoundError(cmd.KeyID)
+	}
+	if !key.Active() {
+		return key, nil
+	}
+
+	key.Revoke(time.Now().UTC())
+	if err := uc.districts.RevokeKey(ctx, key); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return key, nil
+}
@@ -0,0 +1,50 @@
+package update_district_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	districtErrors "github.com/nahualventure/class-backend/core/app/district/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/district/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type UpdateDistrictUseCase struct {
+	districts ports.DistrictRepository
+}
+
+func NewUpdateDistrictUseCase(districts ports.DistrictRepository) *UpdateDistrictUseCase {
+	return &UpdateDistrictUseCase{
+		districts: districts,
+	}
+}
+
+// Execute replaces the name, the schools and the k of the district. Its keys read the new schools
+// from the next request on.
+func (uc *UpdateDistrictUseCase) Execute(ctx context.Context, cmd *UpdateDistrictCommand) (*entities.District, error) {
+	district, err := uc.districts.FindByID(ctx, cmd.DistrictID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if district == nil {
+		return nil, districtErrors.NewDistrictNotFoundError(cmd.DistrictID)
+	}
+
+	if err := district.Update(cmd.Name, cmd.TenantIDs, cmd.MinCohortSize, time.Now().UTC()); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	claimed, err := uc.districts.TenantsInOtherDistricts(ctx, district.ID, district.TenantIDs)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if len(claimed) > 0 {
+		return nil, districtErrors.NewTenantInOtherDistrictError(claimed)
+	}
+
+	if err := uc.districts.Save(ctx, district); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return district, nil
+}
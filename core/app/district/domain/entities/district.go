@@ -0,0 +1,140 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+var validate = validator.New()
+
+// KeyPrefix starts every district key. Unlike widget keys they are secrets, only their SHA-256 is
+// stored.
+const KeyPrefix = "dk_"
+
+const (
+	// DefaultMinCohortSize is the k of districts created without one
+	DefaultMinCohortSize = 10
+	// MinCohortSizeFloor is the smallest k a district can be given, smaller cohorts are too easy to
+	// single students out of
+	MinCohortSizeFloor = 5
+)
+
+// District groups the tenants of the schools a district oversees. District dashboards read
+// aggregated metrics of those schools, never a record of a student. Schools with fewer students
+// than MinCohortSize are suppressed from the metrics.
+type District struct {
+	ID   string `validate:"required,uuid"`
+	Name string `validate:"required,max=200"`
+	// TenantIDs are the schools of the district, a tenant belongs to one district at most
+	TenantIDs     []string  `validate:"required,min=1,max=500,unique,dive,required,max=100"`
+	MinCohortSize int       `validate:"gte=5,lte=100"`
+	CreatedAt     time.Time `validate:"required"`
+	UpdatedAt     time.Time `validate:"required"`
+}
+
+// NewDistrict creates a district, a minCohortSize of 0 takes DefaultMinCohortSize
+func NewDistrict(name string, tenantIDs []string, minCohortSize int, now time.Time) (*District, error) {
+	district := &District{
+		ID:        uuid.New().String(),
+		CreatedAt: now,
+	}
+	if err := district.Update(name, tenantIDs, minCohortSize, now); err != nil {
+		return nil, err
+	}
+	return district, nil
+}
+
+// Update replaces the name, the schools and the k of the district. Keys keep working and read the
+// new schools from then on.
+func (d *District) Update(name string, tenantIDs []string, minCohortSize int, now time.Time) error {
+	if minCohortSize == 0 {
+		minCohortSize = DefaultMinCohortSize
+	}
+	d.Name = strings.TrimSpace(name)
+	d.TenantIDs = slices.Sorted(slices.Values(tenantIDs))
+	d.MinCohortSize = minCohortSize
+	d.UpdatedAt = now
+
+	if err := validate.Struct(d); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return appErrors.NewDomainEntityValidationError("District domain model instance not valid", errorMap, err)
+	}
+	return nil
+}
+
+// DistrictKey authenticates the dashboards of a district. It only reads the aggregated metrics of
+// the district, so it is no credential of any of its tenants.
+type DistrictKey struct {
+	ID         string `validate:"required,uuid"`
+	DistrictID string `validate:"required,uuid"`
+	Name       string `validate:"required,max=100"`
+	// Hash is the hex SHA-256 of the key, the key itself is only shown when it is created
+	Hash      string    `validate:"required,len=64"`
+	CreatedAt time.Time `validate:"required"`
+	RevokedAt *time.Time
+}
+
+// NewDistrictKey generates a key for the district, it returns the key along with the secret to
+// hand to the district once
+func NewDistrictKey(districtID string, name string, now time.Time) (*DistrictKey, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", appErrors.NewInfrastructureError("generate district key", err)
+	}
+	token := KeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	key := &DistrictKey{
+		ID:         uuid.New().String(),
+		DistrictID: districtID,
+		Name:       strings.TrimSpace(name),
+		Hash:       HashKey(token),
+		CreatedAt:  now,
+	}
+
+	if err := validate.Struct(key); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, "", appErrors.NewDomainEntityValidationError("DistrictKey domain model instance not valid", errorMap, err)
+	}
+
+	return key, token, nil
+}
+
+func (k *DistrictKey) Active() bool {
+	return k.RevokedAt == nil
+}
+
+// Revoke stops the key from working, revoking a revoked key keeps its first revocation
+func (k *DistrictKey) Revoke(now time.Time) {
+	if k.RevokedAt == nil {
+		k.RevokedAt = &now
+	}
+}
+
+// HashKey is how keys are stored and looked up
+func HashKey(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// DateLayout is how the days metrics cover are written in requests and responses
+const DateLayout = time.DateOnly
+
+// SchoolCounts are the raw counts of a school the metrics are made of. They never leave the
+// module, only the metrics aggregated from them do.
+type SchoolCounts struct {
+	TenantID string
+	// Students are the distinct students enrolled in a class of the school
+	Students int
+	// Enrollments are the seats taken in the classes of the school
+	Enrollments int
+	// ExpectedAttendances are the students enrolled in the class of each check-in session of the
+	// period when it started, Attendances the ones that checked in
+	ExpectedAttendances int
+	Attendances         int
+}
+
+// SchoolMetrics are the metrics of a school of the district. Suppressed schools have fewer students
+// than the k of the district and carry no number.
+type SchoolMetrics struct {
+	TenantID       string
+	Suppressed     bool
+	Students       *int
+	Enrollments    *int
+	AttendanceRate *float64
+}
+
+// DistrictMetrics are the metrics of the schools of a district from one civil date to the other, both
+// included. Totals only add up the schools shown, suppressed schools cannot be found by subtracting
+// them from the totals.
+type DistrictMetrics struct {
+	DistrictID        string
+	From              time.Time
+	To                time.Time
+	MinCohortSize     int
+	Schools           []SchoolMetrics
+	Students          int
+	Enrollments       int
+	AttendanceRate    *float64
+	SuppressedSchools int
+}
+
+// Aggregate builds the metrics of the district from the counts of its schools. Schools without
+// counts have no students and are suppressed like any other small cohort.
+func Aggregate(district *District, counts []SchoolCounts, from time.Time, to time.Time) *DistrictMetrics {
+	bySchool := make(map[string]SchoolCounts, len(counts))
+	for _, count := range counts {
+		bySchool[count.TenantID] = count
+	}
+
+	metrics := &DistrictMetrics{
+		DistrictID:    district.ID,
+		From:          from,
+		To:            to,
+		MinCohortSize: district.MinCohortSize,
+		Schools:       make([]SchoolMetrics, 0, len(district.TenantIDs)),
+	}
+	expected, attended := 0, 0
+	for _, tenantID := range district.TenantIDs {
+		count := bySchool[tenantID]
+		if count.Students < district.MinCohortSize {
+			metrics.Schools = append(metrics.Schools, SchoolMetrics{TenantID: tenantID, Suppressed: true})
+			metrics.SuppressedSchools++
+			continue
+		}
+
+		metrics.Schools = append(metrics.Schools, SchoolMetrics{
+			TenantID:       tenantID,
+			Students:       &count.Students,
+			Enrollments:    &count.Enrollments,
+			AttendanceRate: rate(count.Attendances, count.ExpectedAttendances),
+		})
+		metrics.Students += count.Students
+		metrics.Enrollments += count.Enrollments
+		expected += count.ExpectedAttendances
+		attended += count.Attendances
+	}
+	metrics.AttendanceRate = rate(attended, expected)
+
+	return metrics
+}
+
+// rate is the share of attended sessions rounded to a tenth of a percent, nil without sessions
+func rate(attended int, expected int) *float64 {
+	if expected == 0 {
+		return nil
+	}
+	value := math.Round(float64(attended)/float64(expected)*1000) / 1000
+	return &value
+}
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	DistrictNotFoundError    errors2.ErrorCode = "DISTRICT_NOT_FOUND"
+	DistrictKeyNotFoundError errors2.ErrorCode = "DISTRICT_KEY_NOT_FOUND"
+	// InvalidDistrictKeyError refuses a dashboard request with an unknown or revoked key
+	InvalidDistrictKeyError errors2.ErrorCode = "INVALID_DISTRICT_KEY"
+	// TenantInOtherDistrictError refuses to add a school to a second district
+	TenantInOtherDistrictError errors2.ErrorCode = "TENANT_IN_OTHER_DISTRICT"
+)
+
+func NewDistrictNotFoundError(districtID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    DistrictNotFoundError.String(),
+			Message: "District not found",
+			Context: map[string]any{
+				"district_id": districtID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(DistrictNotFoundError.String()),
+		},
+	}
+}
+
+func NewDistrictKeyNotFoundError(keyID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    DistrictKeyNotFoundError.String(),
+			Message: "District key not found",
+			Context: map[string]any{
+				"key_id": keyID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(DistrictKeyNotFoundError.String()),
+		},
+	}
+}
+
+// NewInvalidDistrictKeyError leaves the key out, keys are secrets and are not echoed back
+func NewInvalidDistrictKeyError() *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:       InvalidDistrictKeyError.String(),
+			Message:    "The district key is not valid or was revoked",
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidDistrictKeyError.String()),
+		},
+	}
+}
+
+func NewTenantInOtherDistrictError(tenantIDs []string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TenantInOtherDistrictError.String(),
+			Message: "Some schools already belong to another district",
+			Context: map[string]any{
+				"tenant_ids": tenantIDs,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TenantInOtherDistrictError.String()),
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+)
+
+// DistrictRepository keeps the districts and their keys, they are not tenant scoped
+type DistrictRepository interface {
+	// Save creates or replaces the district along with its schools
+	Save(ctx context.Context, district *entities.District) error
+	// FindByID returns nil when there is no such district
+	FindByID(ctx context.Context, districtID string) (*entities.District, error)
+	// List returns every district ordered by name
+	List(ctx context.Context) ([]*entities.District, error)
+	// TenantsInOtherDistricts returns the tenants of tenantIDs that belong to a district other than
+	// districtID
+	TenantsInOtherDistricts(ctx context.Context, districtID string, tenantIDs []string) ([]string, error)
+
+	CreateKey(ctx context.Context, key *entities.DistrictKey) error
+	// FindKey returns the key of the district, nil when the district has no such key
+	FindKey(ctx context.Context, districtID string, keyID string) (*entities.DistrictKey, error)
+	// FindKeyByHash returns the key, revoked or not, nil when there is no such key
+	FindKeyByHash(ctx context.Context, hash string) (*entities.DistrictKey, error)
+	RevokeKey(ctx context.Context, key *entities.DistrictKey) error
+}
+
+// DistrictMetricsSource counts the enrollments and attendance of schools from the enrollment and
+// attendance modules
+type DistrictMetricsSource interface {
+	// SchoolCounts returns the counts of the tenants that have any, attendance of the sessions that
+	// started from one civil date to the other, both included
+	SchoolCounts(ctx context.Context, tenantIDs []string, from time.Time, to time.Time) ([]entities.SchoolCounts, error)
+}
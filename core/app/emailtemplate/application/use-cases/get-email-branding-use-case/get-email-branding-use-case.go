@@ -0,0 +1,31 @@
+package get_email_branding_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetEmailBrandingUseCase struct {
+	branding ports.EmailBrandingRepository
+}
+
+func NewGetEmailBrandingUseCase(branding ports.EmailBrandingRepository) *GetEmailBrandingUseCase {
+	return &GetEmailBrandingUseCase{
+		branding: branding,
+	}
+}
+
+// Execute returns the branding of the tenant, the default when it never set one
+func (uc *GetEmailBrandingUseCase) Execute(ctx context.Context, cmd *GetEmailBrandingCommand) (*entities.EmailBranding, error) {
+	branding, err := uc.branding.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if branding == nil {
+		return entities.DefaultEmailBranding(cmd.TenantID), nil
+	}
+	return branding, nil
+}
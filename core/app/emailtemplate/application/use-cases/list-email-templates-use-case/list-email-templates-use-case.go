@@ -0,0 +1,28 @@
+package list_email_templates_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListEmailTemplatesUseCase struct {
+	templates ports.EmailTemplateRepository
+}
+
+func NewListEmailTemplatesUseCase(templates ports.EmailTemplateRepository) *ListEmailTemplatesUseCase {
+	return &ListEmailTemplatesUseCase{
+		templates: templates,
+	}
+}
+
+// Execute returns the template of every flow, the default of the flows the tenant did not customize
+func (uc *ListEmailTemplatesUseCase) Execute(ctx context.Context, cmd *ListEmailTemplatesCommand) ([]*entities.EmailTemplate, error) {
+	stored, err := uc.templates.List(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return entities.NewEmailTemplates(cmd.TenantID, stored).Ordered(), nil
+}
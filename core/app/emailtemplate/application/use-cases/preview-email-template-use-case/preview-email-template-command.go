@@ -0,0 +1,37 @@
+package preview_email_template_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// PreviewEmailTemplateCommand renders the saved template of the flow, the parts of the draft that are
+// set replace the saved ones
+type PreviewEmailTemplateCommand struct {
+	TenantID string             `validate:"required"`
+	Flow     entities.EmailFlow `validate:"required,oneof=invitation password_reset enrollment"`
+	Subject  string             `validate:"max=255"`
+	HTMLBody string             `validate:"max=100000"`
+	TextBody string             `validate:"max=100000"`
+}
+
+func NewPreviewEmailTemplateCommand(tenantID string, flow string, subject string, htmlBody string, textBody string) (*PreviewEmailTemplateCommand, error) {
+	command := &PreviewEmailTemplateCommand{
+		TenantID: tenantID,
+		Flow:     entities.EmailFlow(flow),
+		Subject:  subject,
+		HTMLBody: htmlBody,
+		TextBody: textBody,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,47 @@
+package preview_email_template_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type PreviewEmailTemplateUseCase struct {
+	templates ports.EmailTemplateRepository
+	branding  ports.EmailBrandingRepository
+}
+
+func NewPreviewEmailTemplateUseCase(templates ports.EmailTemplateRepository, branding ports.EmailBrandingRepository) *PreviewEmailTemplateUseCase {
+	return &PreviewEmailTemplateUseCase{
+		templates: templates,
+		branding:  branding,
+	}
+}
+
+// Execute renders the template of a flow, or a draft of it, with the branding of the tenant and the
+// sample variables of the flow
+func (uc *PreviewEmailTemplateUseCase) Execute(ctx context.Context, cmd *PreviewEmailTemplateCommand) (*entities.RenderedEmail, error) {
+	template, err := uc.templates.Find(ctx, cmd.TenantID, cmd.Flow)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if template == nil {
+		template = entities.DefaultEmailTemplate(cmd.TenantID, cmd.Flow)
+	}
+
+	branding, err := uc.branding.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if branding == nil {
+		branding = entities.DefaultEmailBranding(cmd.TenantID)
+	}
+
+	rendered, err := template.WithDraft(cmd.Subject, cmd.HTMLBody, cmd.TextBody).Render(branding, entities.SampleVariables(cmd.Flow))
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return rendered, nil
+}
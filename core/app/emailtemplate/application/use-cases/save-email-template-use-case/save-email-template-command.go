@@ -0,0 +1,40 @@
+package save_email_template_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SaveEmailTemplateCommand struct {
+	TenantID  string             `validate:"required"`
+	Flow      entities.EmailFlow `validate:"required,oneof=invitation password_reset enrollment"`
+	Subject   string             `validate:"required,max=255"`
+	HTMLBody  string             `validate:"required,max=100000"`
+	TextBody  string             `validate:"required,max=100000"`
+	Enabled   bool
+	UpdatedBy string `validate:"required"`
+}
+
+func NewSaveEmailTemplateCommand(tenantID string, flow string, subject string, htmlBody string, textBody string,
+	enabled bool, updatedBy string) (*SaveEmailTemplateCommand, error) {
+	command := &SaveEmailTemplateCommand{
+		TenantID:  tenantID,
+		Flow:      entities.EmailFlow(flow),
+		Subject:   subject,
+		HTMLBody:  htmlBody,
+		TextBody:  textBody,
+		Enabled:   enabled,
+		UpdatedBy: updatedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
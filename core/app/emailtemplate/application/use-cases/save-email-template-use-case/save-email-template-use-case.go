@@ -0,0 +1,35 @@
+package save_email_template_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SaveEmailTemplateUseCase struct {
+	templates ports.EmailTemplateRepository
+}
+
+func NewSaveEmailTemplateUseCase(templates ports.EmailTemplateRepository) *SaveEmailTemplateUseCase {
+	return &SaveEmailTemplateUseCase{
+		templates: templates,
+	}
+}
+
+// Execute replaces the template of a flow once it renders with the variables of the flow. Senders
+// use it from the next email when it is enabled, and keep the default while it is not.
+func (uc *SaveEmailTemplateUseCase) Execute(ctx context.Context, cmd *SaveEmailTemplateCommand) (*entities.EmailTemplate, error) {
+	template, err := entities.NewEmailTemplate(cmd.TenantID, cmd.Flow, cmd.Subject, cmd.HTMLBody, cmd.TextBody, cmd.Enabled,
+		cmd.UpdatedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.templates.Save(ctx, template); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return template, nil
+}
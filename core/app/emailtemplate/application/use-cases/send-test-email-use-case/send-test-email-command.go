@@ -0,0 +1,40 @@
+package send_test_email_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// SendTestEmailCommand sends the saved template of the flow, the parts of the draft that are set
+// replace the saved ones
+type SendTestEmailCommand struct {
+	TenantID    string             `validate:"required"`
+	Flow        entities.EmailFlow `validate:"required,oneof=invitation password_reset enrollment"`
+	Subject     string             `validate:"max=255"`
+	HTMLBody    string             `validate:"max=100000"`
+	TextBody    string             `validate:"max=100000"`
+	RequestedBy string             `validate:"required"`
+}
+
+func NewSendTestEmailCommand(tenantID string, flow string, subject string, htmlBody string, textBody string,
+	requestedBy string) (*SendTestEmailCommand, error) {
+	command := &SendTestEmailCommand{
+		TenantID:    tenantID,
+		Flow:        entities.EmailFlow(flow),
+		Subject:     subject,
+		HTMLBody:    htmlBody,
+		TextBody:    textBody,
+		RequestedBy: requestedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,69 @@
+package send_test_email_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	emailTemplateErrors "github.com/nahualventure/class-backend/core/app/emailtemplate/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SendTestEmailUseCase struct {
+	templates  ports.EmailTemplateRepository
+	branding   ports.EmailBrandingRepository
+	recipients ports.RecipientDirectory
+	queue      ports.TestEmailQueue
+}
+
+func NewSendTestEmailUseCase(templates ports.EmailTemplateRepository, branding ports.EmailBrandingRepository,
+	recipients ports.RecipientDirectory, queue ports.TestEmailQueue) *SendTestEmailUseCase {
+	return &SendTestEmailUseCase{
+		templates:  templates,
+		branding:   branding,
+		recipients: recipients,
+		queue:      queue,
+	}
+}
+
+// Execute renders the template of a flow, or a draft of it, with the sample variables of the flow and
+// queues it to the address of the caller. Test emails only go to the caller, so the endpoint cannot
+// send tenant-branded emails to anyone else.
+func (uc *SendTestEmailUseCase) Execute(ctx context.Context, cmd *SendTestEmailCommand) (*entities.TestEmail, error) {
+	to, err := uc.recipients.EmailOf(ctx, cmd.RequestedBy)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if to == "" {
+		return nil, emailTemplateErrors.NewTestRecipientNotFoundError(cmd.RequestedBy)
+	}
+
+	template, err := uc.templates.Find(ctx, cmd.TenantID, cmd.Flow)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if template == nil {
+		template = entities.DefaultEmailTemplate(cmd.TenantID, cmd.Flow)
+	}
+
+	branding, err := uc.branding.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if branding == nil {
+		branding = entities.DefaultEmailBranding(cmd.TenantID)
+	}
+
+	rendered, err := template.WithDraft(cmd.Subject, cmd.HTMLBody, cmd.TextBody).Render(branding, entities.SampleVariables(cmd.Flow))
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	rendered.Subject = "[Test] " + rendered.Subject
+
+	email := entities.NewTestEmail(cmd.TenantID, cmd.Flow, to, rendered, cmd.RequestedBy, time.Now().UTC())
+	if err := uc.queue.Queue(ctx, email); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return email, nil
+}
@@ -0,0 +1,37 @@
+package set_email_branding_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SetEmailBrandingCommand struct {
+	TenantID     string `validate:"required"`
+	SenderName   string `validate:"required,max=100"`
+	LogoURL      string `validate:"omitempty,max=2048,url,startswith=https://"`
+	PrimaryColor string `validate:"required,hexcolor"`
+	Footer       string `validate:"max=1000"`
+	UpdatedBy    string `validate:"required"`
+}
+
+func NewSetEmailBrandingCommand(tenantID string, senderName string, logoURL string, primaryColor string, footer string,
+	updatedBy string) (*SetEmailBrandingCommand, error) {
+	command := &SetEmailBrandingCommand{
+		TenantID:     tenantID,
+		SenderName:   senderName,
+		LogoURL:      logoURL,
+		PrimaryColor: primaryColor,
+		Footer:       footer,
+		UpdatedBy:    updatedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,34 @@
+package set_email_branding_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetEmailBrandingUseCase struct {
+	branding ports.EmailBrandingRepository
+}
+
+func NewSetEmailBrandingUseCase(branding ports.EmailBrandingRepository) *SetEmailBrandingUseCase {
+	return &SetEmailBrandingUseCase{
+		branding: branding,
+	}
+}
+
+// Execute replaces the branding of the tenant, every template renders with it from the next email
+func (uc *SetEmailBrandingUseCase) Execute(ctx context.Context, cmd *SetEmailBrandingCommand) (*entities.EmailBranding, error) {
+	branding, err := entities.NewEmailBranding(cmd.TenantID, cmd.SenderName, cmd.LogoURL, cmd.PrimaryColor, cmd.Footer,
+		cmd.UpdatedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.branding.Save(ctx, branding); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return branding, nil
+}
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	DefaultSenderName   = "Class"
+	DefaultPrimaryColor = "#1a73e8"
+)
+
+// EmailBranding is how the emails of a tenant look, every template renders with it
+type EmailBranding struct {
+	TenantID   string `validate:"required"`
+	SenderName string `validate:"required,max=100"`
+	// LogoURL is shown at the top of the emails, it must be served over HTTPS for mail clients to load it
+	LogoURL      string `validate:"omitempty,max=2048,url,startswith=https://"`
+	PrimaryColor string `validate:"required,hexcolor"`
+	Footer       string `validate:"max=1000"`
+	// UpdatedBy is empty for the default branding, which was never set
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// DefaultEmailBranding returns the branding of a tenant that did not set one
+func DefaultEmailBranding(tenantID string) *EmailBranding {
+	return &EmailBranding{
+		TenantID:     tenantID,
+		SenderName:   DefaultSenderName,
+		PrimaryColor: DefaultPrimaryColor,
+	}
+}
+
+func NewEmailBranding(tenantID string, senderName string, logoURL string, primaryColor string, footer string,
+	updatedBy string, now time.Time) (*EmailBranding, error) {
+	branding := &EmailBranding{
+		TenantID:     tenantID,
+		SenderName:   senderName,
+		LogoURL:      logoURL,
+		PrimaryColor: primaryColor,
+		Footer:       footer,
+		UpdatedBy:    updatedBy,
+		UpdatedAt:    now,
+	}
+
+	if err := validate.Struct(branding); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("EmailBranding domain model instance not valid", errorMap, err)
+	}
+
+	return branding, nil
+}
+
+// IsDefault reports whether the tenant never set its branding
+func (b *EmailBranding) IsDefault() bool {
+	return b.UpdatedBy == ""
+}
+
+// Variables are the branding variables every template can use besides the ones of its flow
+func (b *EmailBranding) Variables() map[string]any {
+	return map[string]any{
+		"sender_name":   b.SenderName,
+		"logo_url":      b.LogoURL,
+		"primary_color": b.PrimaryColor,
+		"footer":        b.Footer,
+	}
+}
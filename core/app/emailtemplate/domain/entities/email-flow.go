@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"maps"
+	"slices"
+)
+
+// EmailFlow is an email the platform sends on behalf of a tenant, senders deliver it from the outbox
+// with the template of the tenant
+type EmailFlow string
+
+const (
+	// EmailFlowInvitation invites a member to the tenant
+	EmailFlowInvitation    EmailFlow = "invitation"
+	EmailFlowPasswordReset EmailFlow = "password_reset"
+	// EmailFlowEnrollment tells a student about an enrollment confirmed or canceled
+	EmailFlowEnrollment EmailFlow = "enrollment"
+)
+
+// Flows lists the flows tenants can brand, in the order they are listed
+var Flows = []EmailFlow{EmailFlowInvitation, EmailFlowPasswordReset, EmailFlowEnrollment}
+
+// SampleVariables returns the variables senders fill when they deliver an email of the flow, with
+// sample values previews and test emails are rendered with
+func SampleVariables(flow EmailFlow) map[string]any {
+	switch flow {
+	case EmailFlowInvitation:
+		return map[string]any{
+			"recipient_name": "Ana López",
+			"inviter_name":   "Luis Pérez",
+			"role":           "instructor",
+			"accept_url":     "https://class.example.com/invitations/sample",
+			"expires_at":     "March 8, 2025",
+		}
+	case EmailFlowPasswordReset:
+		return map[string]any{
+			"recipient_name":     "Ana López",
+			"reset_url":          "https://class.example.com/password/reset/sample",
+			"expires_in_minutes": 30,
+		}
+	case EmailFlowEnrollment:
+		return map[string]any{
+			"recipient_name": "Ana López",
+			"class_name":     "Algebra I",
+			"enrolled":       true,
+		}
+	}
+	return map[string]any{}
+}
+
+// VariableNames returns the names of the variables of the flow in alphabetical order
+func VariableNames(flow EmailFlow) []string {
+	return slices.Sorted(maps.Keys(SampleVariables(flow)))
+}
+
+// defaultTemplates are the templates of the flows a tenant did not customize
+var defaultTemplates = map[EmailFlow]struct{ subject, html, text string }{
+	EmailFlowInvitation: {
+		subject: "{{.inviter_name}} invited you to {{.sender_name}}",
+		html: `<p>Hi {{.recipient_name}},</p>
+<p>{{.inviter_name}} invited you to join {{.sender_name}} as {{.role}}.</p>
+<p><a href="{{.accept_url}}" style="color: {{.primary_color}}">Accept the invitation</a> before {{.expires_at}}.</p>`,
+		text: `Hi {{.recipient_name}},
+
+{{.inviter_name}} invited you to join {{.sender_name}} as {{.role}}. Accept the invitation before {{.expires_at}}:
+{{.accept_url}}`,
+	},
+	EmailFlowPasswordReset: {
+		subject: "Reset your {{.sender_name}} password",
+		html: `<p>Hi {{.recipient_name}},</p>
+<p><a href="{{.reset_url}}" style="color: {{.primary_color}}">Choose a new password</a> within {{.expires_in_minutes}} minutes.</p>
+<p>If you did not ask to reset your password, ignore this email.</p>`,
+		text: `Hi {{.recipient_name}},
+
+Choose a new password within {{.expires_in_minutes}} minutes:
+{{.reset_url}}
+
+If you did not ask to reset your password, ignore this email.`,
+	},
+	EmailFlowEnrollment: {
+		subject: "{{if .enrolled}}You are enrolled in{{else}}Your enrollment was canceled in{{end}} {{.class_name}}",
+		html: `<p>Hi {{.recipient_name}},</p>
+<p>{{if .enrolled}}You are enrolled in {{.class_name}}.{{else}}Your enrollment in {{.class_name}} was canceled.{{end}}</p>`,
+		text: `Hi {{.recipient_name}},
+
+{{if .enrolled}}You are enrolled in {{.class_name}}.{{else}}Your enrollment in {{.class_name}} was canceled.{{end}}`,
+	},
+}
@@ -0,0 +1,117 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// EmailTemplate is the subject and bodies of the emails of a flow, Go templates over the variables
+// of the flow and the branding of the tenant. Senders use the template of the tenant once Enabled,
+// and the default template until then.
+type EmailTemplate struct {
+	TenantID string    `validate:"required"`
+	Flow     EmailFlow `validate:"required,oneof=invitation password_reset enrollment"`
+	Subject  string    `validate:"required,max=255"`
+	HTMLBody string    `validate:"required,max=100000"`
+	TextBody string    `validate:"required,max=100000"`
+	Enabled  bool
+	// UpdatedBy is empty for the defaults, which were never saved
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// DefaultEmailTemplate returns the template of a flow the tenant did not customize
+func DefaultEmailTemplate(tenantID string, flow EmailFlow) *EmailTemplate {
+	defaults := defaultTemplates[flow]
+	return &EmailTemplate{
+		TenantID: tenantID,
+		Flow:     flow,
+		Subject:  defaults.subject,
+		HTMLBody: defaults.html,
+		TextBody: defaults.text,
+	}
+}
+
+// NewEmailTemplate checks the template renders with the sample variables of its flow, so a saved
+// template never fails once senders deliver with it
+func NewEmailTemplate(tenantID string, flow EmailFlow, subject string, htmlBody string, textBody string, enabled bool,
+	updatedBy string, now time.Time) (*EmailTemplate, error) {
+	template := &EmailTemplate{
+		TenantID:  tenantID,
+		Flow:      flow,
+		Subject:   subject,
+		HTMLBody:  htmlBody,
+		TextBody:  textBody,
+		Enabled:   enabled,
+		UpdatedBy: updatedBy,
+		UpdatedAt: now,
+	}
+
+	if err := validate.Struct(template); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("EmailTemplate domain model instance not valid", errorMap, err)
+	}
+
+	if _, err := template.Render(DefaultEmailBranding(tenantID), SampleVariables(flow)); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// IsDefault reports whether the tenant never saved the template
+func (t *EmailTemplate) IsDefault() bool {
+	return t.UpdatedBy == ""
+}
+
+// WithDraft returns a copy of the template with the parts of a draft that are set, for previewing
+// changes before saving them
+func (t *EmailTemplate) WithDraft(subject string, htmlBody string, textBody string) *EmailTemplate {
+	draft := *t
+	if subject != "" {
+		draft.Subject = subject
+	}
+	if htmlBody != "" {
+		draft.HTMLBody = htmlBody
+	}
+	if textBody != "" {
+		draft.TextBody = textBody
+	}
+	return &draft
+}
+
+// EmailTemplates holds the template of every flow of a tenant by flow
+type EmailTemplates map[EmailFlow]*EmailTemplate
+
+// NewEmailTemplates fills the flows missing from stored with their default
+func NewEmailTemplates(tenantID string, stored []*EmailTemplate) EmailTemplates {
+	templates := make(EmailTemplates, len(Flows))
+	for _, flow := range Flows {
+		templates[flow] = DefaultEmailTemplate(tenantID, flow)
+	}
+	for _, template := range stored {
+		templates[template.Flow] = template
+	}
+	return templates
+}
+
+// Ordered returns the templates in the order of Flows
+func (t EmailTemplates) Ordered() []*EmailTemplate {
+	ordered := make([]*EmailTemplate, 0, len(Flows))
+	for _, flow := range Flows {
+		ordered = append(ordered, t[flow])
+	}
+	return ordered
+}
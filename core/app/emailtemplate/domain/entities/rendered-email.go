@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"bytes"
+	htmlTemplate "html/template"
+	"maps"
+	"strings"
+	textTemplate "text/template"
+
+	emailTemplateErrors "github.com/nahualventure/class-backend/core/app/emailtemplate/domain/errors"
+)
+
+// RenderedEmail is an email ready to send
+type RenderedEmail struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// layout frames the HTML body with the logo and the footer of the branding
+var layout = htmlTemplate.Must(htmlTemplate.New("layout").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #202124">
+{{if .logo_url}}<img src="{{.logo_url}}" alt="{{.sender_name}}" style="max-height: 48px">
+{{end}}{{.body}}
+{{if .footer}}<p style="color: #5f6368; font-size: 12px">{{.footer}}</p>
+{{end}}</body>
+</html>`))
+
+// Render fills the template with the variables of its flow and the branding. Variables the template
+// uses but the flow does not have fail the render instead of leaving a blank. Values are escaped in
+// the HTML body, and the subject keeps to one line.
+func (t *EmailTemplate) Render(branding *EmailBranding, variables map[string]any) (*RenderedEmail, error) {
+	data := branding.Variables()
+	maps.Copy(data, variables)
+
+	subject, err := t.renderText("subject", t.Subject, data)
+	if err != nil {
+		return nil, err
+	}
+	text, err := t.renderText("text_body", t.TextBody, data)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := htmlTemplate.New("html_body").Option("missingkey=error").Parse(t.HTMLBody)
+	if err != nil {
+		return nil, t.invalid("html_body", err)
+	}
+	var body bytes.Buffer
+	if err := parsed.Execute(&body, data); err != nil {
+		return nil, t.invalid("html_body", err)
+	}
+
+	framed := maps.Clone(data)
+	framed["body"] = htmlTemplate.HTML(body.String())
+	var html bytes.Buffer
+	if err := layout.Execute(&html, framed); err != nil {
+		return nil, t.invalid("html_body", err)
+	}
+
+	if branding.Footer != "" {
+		text += "\n\n--\n" + branding.Footer
+	}
+
+	return &RenderedEmail{
+		Subject: strings.Join(strings.Fields(subject), " "),
+		HTML:    html.String(),
+		Text:    text,
+	}, nil
+}
+
+func (t *EmailTemplate) renderText(part string, source string, data map[string]any) (string, error) {
+	parsed, err := textTemplate.New(part).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", t.invalid(part, err)
+	}
+	var rendered bytes.Buffer
+	if err := parsed.Execute(&rendered, data); err != nil {
+		return "", t.invalid(part, err)
+	}
+	return rendered.String(), nil
+}
+
+func (t *EmailTemplate) invalid(part string, err error) error {
+	return emailTemplateErrors.NewInvalidEmailTemplateError(string(t.Flow), part, err.Error())
+}
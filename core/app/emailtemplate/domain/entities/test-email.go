@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestEmail is a rendered template an admin sends to their own address before enabling it, senders
+// deliver it from the outbox like the emails of the flow
+type TestEmail struct {
+	ID          string
+	TenantID    string
+	Flow        EmailFlow
+	To          string
+	Email       *RenderedEmail
+	RequestedBy string
+	RequestedAt time.Time
+}
+
+func NewTestEmail(tenantID string, flow EmailFlow, to string, email *RenderedEmail, requestedBy string, now time.Time) *TestEmail {
+	return &TestEmail{
+		ID:          uuid.NewString(),
+		TenantID:    tenantID,
+		Flow:        flow,
+		To:          to,
+		Email:       email,
+		RequestedBy: requestedBy,
+		RequestedAt: now,
+	}
+}
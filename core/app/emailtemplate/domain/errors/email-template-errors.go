@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// InvalidEmailTemplateError is returned for a template that does not parse or does not render
+	// with the variables of its flow
+	InvalidEmailTemplateError errors2.ErrorCode = "INVALID_EMAIL_TEMPLATE"
+	// TestRecipientNotFoundError is returned when the caller has no address to send a test email to,
+	// such as a service account
+	TestRecipientNotFoundError errors2.ErrorCode = "TEST_RECIPIENT_NOT_FOUND"
+)
+
+func NewInvalidEmailTemplateError(flow string, part string, reason string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    InvalidEmailTemplateError.String(),
+			Message: "Email template does not render",
+			Context: map[string]any{
+				"flow":   flow,
+				"part":   part,
+				"reason": reason,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidEmailTemplateError.String()),
+		},
+	}
+}
+
+func NewTestRecipientNotFoundError(userID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TestRecipientNotFoundError.String(),
+			Message: "Caller has no email address to send the test email to",
+			Context: map[string]any{
+				"user_id": userID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TestRecipientNotFoundError.String()),
+		},
+	}
+}
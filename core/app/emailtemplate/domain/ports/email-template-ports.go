@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+)
+
+type EmailTemplateRepository interface {
+	// List returns the templates the tenant saved, the flows it did not customize are left out
+	List(ctx context.Context, tenantID string) ([]*entities.EmailTemplate, error)
+	// Find returns nil when the tenant did not save a template for the flow
+	Find(ctx context.Context, tenantID string, flow entities.EmailFlow) (*entities.EmailTemplate, error)
+	Save(ctx context.Context, template *entities.EmailTemplate) error
+}
+
+type EmailBrandingRepository interface {
+	// Find returns nil when the tenant never set its branding
+	Find(ctx context.Context, tenantID string) (*entities.EmailBranding, error)
+	Save(ctx context.Context, branding *entities.EmailBranding) error
+}
+
+// RecipientDirectory finds the address test emails are sent to
+type RecipientDirectory interface {
+	// EmailOf returns "" when no user has the ID
+	EmailOf(ctx context.Context, userID string) (string, error)
+}
+
+// TestEmailQueue queues test emails to the outbox for senders to deliver
+type TestEmailQueue interface {
+	Queue(ctx context.Context, email *entities.TestEmail) error
+}
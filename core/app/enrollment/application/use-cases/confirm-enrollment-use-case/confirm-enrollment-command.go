@@ -0,0 +1,36 @@
+package confirm_enrollment_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ConfirmEnrollmentCommand struct {
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"required,uuid"`
+	HoldID   string `validate:"required,uuid"`
+	UserID   string `validate:"required"`
+	// OverrideScheduleConflicts skips the schedule conflict check, callers must hold the override permission
+	OverrideScheduleConflicts bool
+}
+
+func NewConfirmEnrollmentCommand(tenantID string, classID string, holdID string, userID string, overrideScheduleConflicts bool) (*ConfirmEnrollmentCommand, error) {
+	command := &ConfirmEnrollmentCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+		HoldID:   holdID,
+		UserID:   userID,
+
+		OverrideScheduleConflicts: overrideScheduleConflicts,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
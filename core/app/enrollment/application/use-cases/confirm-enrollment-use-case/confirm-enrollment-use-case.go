@@ -0,0 +1,91 @@
+package confirm_enrollment_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	enrollmentErrors "github.com/nahualventure/class-backend/core/app/enrollment/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ConfirmEnrollmentUseCase struct {
+	inventory ports.SeatInventory
+	schedules ports.ScheduleRepository
+	clock     clock.Clock
+}
+
+func NewConfirmEnrollmentUseCase(inventory ports.SeatInventory, schedules ports.ScheduleRepository) *ConfirmEnrollmentUseCase {
+	return &ConfirmEnrollmentUseCase{
+		inventory: inventory,
+		schedules: schedules,
+		clock:     clock.System,
+	}
+}
+
+// WithClock changes the clock holds are checked for expiry with
+func (uc *ConfirmEnrollmentUseCase) WithClock(clock clock.Clock) *ConfirmEnrollmentUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute turns the user's hold into an enrollment. The held seat was already counted against the
+// capacity, so the enrollment is kept even if the capacity was lowered in the meantime. Schedule
+// conflicts are checked again since the student may have enrolled elsewhere while holding the seat.
+func (uc *ConfirmEnrollmentUseCase) Execute(ctx context.Context, cmd *ConfirmEnrollmentCommand) (*entities.Enrollment, error) {
+	if !cmd.OverrideScheduleConflicts {
+		conflicts, err := entities.FindEnrollmentConflicts(ctx, uc.schedules, cmd.TenantID, cmd.ClassID, cmd.UserID)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		if len(conflicts) > 0 {
+			return nil, enrollmentErrors.NewScheduleConflictError(cmd.ClassID, conflicts)
+		}
+	}
+
+	now := uc.clock.Now().UTC()
+
+	var enrollment *entities.Enrollment
+	err := uc.inventory.WithClassLocked(ctx, cmd.TenantID, cmd.ClassID, now, func(ledger ports.SeatLedger, capacity *entities.ClassCapacity) error {
+		if capacity == nil {
+			return enrollmentErrors.NewClassCapacityNotFoundError(cmd.ClassID)
+		}
+
+		hold, err := ledger.FindHold(ctx, cmd.HoldID)
+		if err != nil {
+			return errors.PropagateError(err)
+		}
+		if hold == nil || hold.UserID != cmd.UserID {
+			return enrollmentErrors.NewSeatHoldNotFoundError(cmd.HoldID)
+		}
+		if hold.IsExpired(now) {
+			return enrollmentErrors.NewSeatHoldExpiredError(hold.ID, hold.ExpiresAt)
+		}
+
+		enrolled, err := ledger.IsEnrolled(ctx, cmd.UserID)
+		if err != nil {
+			return errors.PropagateError(err)
+		}
+		if enrolled {
+			return enrollmentErrors.NewAlreadyEnrolledError(cmd.ClassID, cmd.UserID)
+		}
+
+		enrollment = &entities.Enrollment{
+			TenantID:   cmd.TenantID,
+			ClassID:    cmd.ClassID,
+			UserID:     cmd.UserID,
+			EnrolledAt: now,
+		}
+		if err := ledger.CreateEnrollment(ctx, enrollment); err != nil {
+			return errors.PropagateError(err)
+		}
+
+		return ledger.DeleteHold(ctx, hold.ID)
+	})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return enrollment, nil
+}
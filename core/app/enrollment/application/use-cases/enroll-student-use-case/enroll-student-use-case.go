@@ -0,0 +1,176 @@
+package enroll_student_use_case
+
+import (
+	"context"
+	"time"
+
+	confirm_enrollment_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/confirm-enrollment-use-case"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	enrollmentErrors "github.com/nahualventure/class-backend/core/app/enrollment/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/saga"
+
+	"github.com/google/uuid"
+)
+
+// SagaName is the saga confirming enrollments
+const SagaName = "enrollment"
+
+type EnrollStudentUseCase struct {
+	coordinator   *saga.Coordinator
+	confirm       *confirm_enrollment_use_case.ConfirmEnrollmentUseCase
+	enrollments   ports.EnrollmentRepository
+	notifications ports.EnrollmentNotificationQueue
+	sis           ports.StudentInformationSystem
+	clock         clock.Clock
+}
+
+// NewEnrollStudentUseCase registers the enrollment saga, it must be built before the coordinator
+// resumes unfinished sagas
+func NewEnrollStudentUseCase(
+	coordinator *saga.Coordinator,
+	confirm *confirm_enrollment_use_case.ConfirmEnrollmentUseCase,
+	enrollments ports.EnrollmentRepository,
+	notifications ports.EnrollmentNotificationQueue,
+	sis ports.StudentInformationSystem,
+) *EnrollStudentUseCase {
+	uc := &EnrollStudentUseCase{
+		coordinator:   coordinator,
+		confirm:       confirm,
+		enrollments:   enrollments,
+		notifications: notifications,
+		sis:           sis,
+		clock:         clock.System,
+	}
+
+	coordinator.Register(saga.Definition{Name: SagaName, Steps: []saga.Step{
+		{Name: "enroll", Execute: uc.enroll, Compensate: uc.unenroll},
+		{Name: "notify", Execute: uc.notify, Compensate: uc.notifyCanceled},
+		{Name: "sync_sis", Execute: uc.syncSIS},
+	}})
+
+	return uc
+}
+
+// WithClock changes the clock the saga records times with
+func (uc *EnrollStudentUseCase) WithClock(clock clock.Clock) *EnrollStudentUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute turns the hold into an enrollment, tells the student and pushes the enrollment to the
+// student information system. When a later step fails the enrollment is removed and the student is
+// told it was canceled.
+func (uc *EnrollStudentUseCase) Execute(ctx context.Context, cmd *confirm_enrollment_use_case.ConfirmEnrollmentCommand) (*entities.Enrollment, error) {
+	existing, err := uc.enrollments.Find(ctx, cmd.TenantID, cmd.ClassID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if existing != nil {
+		return nil, enrollmentErrors.NewAlreadyEnrolledError(cmd.ClassID, cmd.UserID)
+	}
+
+	// The notification IDs are chosen up front so queueing them again on resume writes nothing.
+	// started_at is truncated to the precision enrollments are stored with.
+	data := map[string]any{
+		"tenant_id":                   cmd.TenantID,
+		"class_id":                    cmd.ClassID,
+		"hold_id":                     cmd.HoldID,
+		"user_id":                     cmd.UserID,
+		"override_schedule_conflicts": cmd.OverrideScheduleConflicts,
+		"started_at":                  uc.clock.Now().UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		"notification_id":             uuid.NewString(),
+		"cancel_notification_id":      uuid.NewString(),
+	}
+
+	if _, err := uc.coordinator.Start(ctx, SagaName, data); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return enrollmentFromData(data), nil
+}
+
+// enroll confirms the hold. A crash after the enrollment was committed runs the step again, an
+// enrollment made since the saga started is the one it made.
+func (uc *EnrollStudentUseCase) enroll(ctx context.Context, data map[string]any) error {
+	cmd := commandFromData(data)
+
+	existing, err := uc.enrollments.Find(ctx, cmd.TenantID, cmd.ClassID, cmd.UserID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if existing != nil && !existing.EnrolledAt.Before(timeFromData(data, "started_at")) {
+		data["enrolled_at"] = existing.EnrolledAt.Format(time.RFC3339Nano)
+		return nil
+	}
+
+	enrollment, err := uc.confirm.Execute(ctx, cmd)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+
+	data["enrolled_at"] = enrollment.EnrolledAt.Format(time.RFC3339Nano)
+	return nil
+}
+
+func (uc *EnrollStudentUseCase) unenroll(ctx context.Context, data map[string]any) error {
+	enrollment := enrollmentFromData(data)
+	return uc.enrollments.Remove(ctx, enrollment.TenantID, enrollment.ClassID, enrollment.UserID, uc.clock.Now().UTC())
+}
+
+func (uc *EnrollStudentUseCase) notify(ctx context.Context, data map[string]any) error {
+	return uc.queueNotification(ctx, data, stringFromData(data, "notification_id"), entities.EnrollmentNotificationEnrolled)
+}
+
+func (uc *EnrollStudentUseCase) notifyCanceled(ctx context.Context, data map[string]any) error {
+	return uc.queueNotification(ctx, data, stringFromData(data, "cancel_notification_id"), entities.EnrollmentNotificationCanceled)
+}
+
+func (uc *EnrollStudentUseCase) queueNotification(ctx context.Context, data map[string]any, id string, kind entities.EnrollmentNotificationKind) error {
+	enrollment := enrollmentFromData(data)
+	return uc.notifications.Queue(ctx, &entities.EnrollmentNotification{
+		ID:        id,
+		TenantID:  enrollment.TenantID,
+		ClassID:   enrollment.ClassID,
+		StudentID: enrollment.UserID,
+		Kind:      kind,
+		CreatedAt: uc.clock.Now().UTC(),
+	})
+}
+
+func (uc *EnrollStudentUseCase) syncSIS(ctx context.Context, data map[string]any) error {
+	return uc.sis.PushEnrollment(ctx, enrollmentFromData(data))
+}
+
+// The saga data went through JSON, strings and booleans keep their type
+func commandFromData(data map[string]any) *confirm_enrollment_use_case.ConfirmEnrollmentCommand {
+	override, _ := data["override_schedule_conflicts"].(bool)
+	return &confirm_enrollment_use_case.ConfirmEnrollmentCommand{
+		TenantID:                  stringFromData(data, "tenant_id"),
+		ClassID:                   stringFromData(data, "class_id"),
+		HoldID:                    stringFromData(data, "hold_id"),
+		UserID:                    stringFromData(data, "user_id"),
+		OverrideScheduleConflicts: override,
+	}
+}
+
+func enrollmentFromData(data map[string]any) *entities.Enrollment {
+	return &entities.Enrollment{
+		TenantID:   stringFromData(data, "tenant_id"),
+		ClassID:    stringFromData(data, "class_id"),
+		UserID:     stringFromData(data, "user_id"),
+		EnrolledAt: timeFromData(data, "enrolled_at"),
+	}
+}
+
+func stringFromData(data map[string]any, key string) string {
+	value, _ := data[key].(string)
+	return value
+}
+
+func timeFromData(data map[string]any, key string) time.Time {
+	value, _ := time.Parse(time.RFC3339Nano, stringFromData(data, key))
+	return value
+}
@@ -0,0 +1,28 @@
+package get_class_capacity_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetClassCapacityCommand struct {
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"required,uuid"`
+}
+
+func NewGetClassCapacityCommand(tenantID string, classID string) (*GetClassCapacityCommand, error) {
+	command := &GetClassCapacityCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
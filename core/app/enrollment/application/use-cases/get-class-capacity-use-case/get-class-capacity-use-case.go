@@ -0,0 +1,34 @@
+package get_class_capacity_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	enrollmentErrors "github.com/nahualventure/class-backend/core/app/enrollment/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetClassCapacityUseCase struct {
+	inventory ports.SeatInventory
+}
+
+func NewGetClassCapacityUseCase(inventory ports.SeatInventory) *GetClassCapacityUseCase {
+	return &GetClassCapacityUseCase{
+		inventory: inventory,
+	}
+}
+
+func (uc *GetClassCapacityUseCase) Execute(ctx context.Context, cmd *GetClassCapacityCommand) (*entities.ClassCapacity, error) {
+	capacity, err := uc.inventory.GetCapacity(ctx, cmd.TenantID, cmd.ClassID, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if capacity == nil {
+		return nil, enrollmentErrors.NewClassCapacityNotFoundError(cmd.ClassID)
+	}
+
+	return capacity, nil
+}
@@ -0,0 +1,29 @@
+package get_class_schedule_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetClassScheduleUseCase struct {
+	schedules ports.ScheduleRepository
+}
+
+func NewGetClassScheduleUseCase(schedules ports.ScheduleRepository) *GetClassScheduleUseCase {
+	return &GetClassScheduleUseCase{
+		schedules: schedules,
+	}
+}
+
+// Execute returns an empty schedule for classes without meetings
+func (uc *GetClassScheduleUseCase) Execute(ctx context.Context, cmd *GetClassScheduleCommand) ([]entities.MeetingTime, error) {
+	meetings, err := uc.schedules.ListMeetings(ctx, cmd.TenantID, cmd.ClassID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return meetings, nil
+}
@@ -0,0 +1,32 @@
+package release_seat_hold_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ReleaseSeatHoldCommand struct {
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"required,uuid"`
+	HoldID   string `validate:"required,uuid"`
+	UserID   string `validate:"required"`
+}
+
+func NewReleaseSeatHoldCommand(tenantID string, classID string, holdID string, userID string) (*ReleaseSeatHoldCommand, error) {
+	command := &ReleaseSeatHoldCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+		HoldID:   holdID,
+		UserID:   userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
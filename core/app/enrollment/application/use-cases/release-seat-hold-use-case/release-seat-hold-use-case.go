@@ -0,0 +1,60 @@
+package release_seat_hold_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	enrollmentErrors "github.com/nahualventure/class-backend/core/app/enrollment/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ReleaseSeatHoldUseCase struct {
+	inventory ports.SeatInventory
+	clock     clock.Clock
+}
+
+func NewReleaseSeatHoldUseCase(inventory ports.SeatInventory) *ReleaseSeatHoldUseCase {
+	return &ReleaseSeatHoldUseCase{
+		inventory: inventory,
+		clock:     clock.System,
+	}
+}
+
+// WithClock changes the clock PurgeExpired decides which holds expired by
+func (uc *ReleaseSeatHoldUseCase) WithClock(clock clock.Clock) *ReleaseSeatHoldUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute gives the seat back when the user abandons the checkout
+func (uc *ReleaseSeatHoldUseCase) Execute(ctx context.Context, cmd *ReleaseSeatHoldCommand) error {
+	err := uc.inventory.WithClassLocked(ctx, cmd.TenantID, cmd.ClassID, uc.clock.Now().UTC(), func(ledger ports.SeatLedger, _ *entities.ClassCapacity) error {
+		hold, err := ledger.FindHold(ctx, cmd.HoldID)
+		if err != nil {
+			return errors.PropagateError(err)
+		}
+		if hold == nil || hold.UserID != cmd.UserID {
+			return enrollmentErrors.NewSeatHoldNotFoundError(cmd.HoldID)
+		}
+
+		return ledger.DeleteHold(ctx, hold.ID)
+	})
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+
+	return nil
+}
+
+// PurgeExpired deletes holds past their expiry. Expired holds already stop counting against the
+// capacity, this only keeps the table small.
+func (uc *ReleaseSeatHoldUseCase) PurgeExpired(ctx context.Context) (int64, error) {
+	deleted, err := uc.inventory.DeleteExpiredHolds(ctx, uc.clock.Now().UTC())
+	if err != nil {
+		return 0, errors.PropagateError(err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,34 @@
+package reserve_seat_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ReserveSeatCommand struct {
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"required,uuid"`
+	UserID   string `validate:"required"`
+	// OverrideScheduleConflicts skips the schedule conflict check, callers must hold the override permission
+	OverrideScheduleConflicts bool
+}
+
+func NewReserveSeatCommand(tenantID string, classID string, userID string, overrideScheduleConflicts bool) (*ReserveSeatCommand, error) {
+	command := &ReserveSeatCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+		UserID:   userID,
+
+		OverrideScheduleConflicts: overrideScheduleConflicts,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,92 @@
+package reserve_seat_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	enrollmentErrors "github.com/nahualventure/class-backend/core/app/enrollment/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type ReserveSeatUseCase struct {
+	inventory ports.SeatInventory
+	schedules ports.ScheduleRepository
+	holdTTL   time.Duration
+	clock     clock.Clock
+}
+
+func NewReserveSeatUseCase(inventory ports.SeatInventory, schedules ports.ScheduleRepository, holdTTL time.Duration) *ReserveSeatUseCase {
+	return &ReserveSeatUseCase{
+		inventory: inventory,
+		schedules: schedules,
+		holdTTL:   holdTTL,
+		clock:     clock.System,
+	}
+}
+
+// WithClock changes the clock holds are created and expire by
+func (uc *ReserveSeatUseCase) WithClock(clock clock.Clock) *ReserveSeatUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute holds a seat for the user until the checkout is confirmed or the hold expires. Retrying
+// while a hold is active returns that same hold, its expiry is not extended.
+func (uc *ReserveSeatUseCase) Execute(ctx context.Context, cmd *ReserveSeatCommand) (*entities.SeatHold, error) {
+	if !cmd.OverrideScheduleConflicts {
+		conflicts, err := entities.FindEnrollmentConflicts(ctx, uc.schedules, cmd.TenantID, cmd.ClassID, cmd.UserID)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		if len(conflicts) > 0 {
+			return nil, enrollmentErrors.NewScheduleConflictError(cmd.ClassID, conflicts)
+		}
+	}
+
+	now := uc.clock.Now().UTC()
+
+	var hold *entities.SeatHold
+	err := uc.inventory.WithClassLocked(ctx, cmd.TenantID, cmd.ClassID, now, func(ledger ports.SeatLedger, capacity *entities.ClassCapacity) error {
+		if capacity == nil {
+			return enrollmentErrors.NewClassCapacityNotFoundError(cmd.ClassID)
+		}
+
+		enrolled, err := ledger.IsEnrolled(ctx, cmd.UserID)
+		if err != nil {
+			return errors.PropagateError(err)
+		}
+		if enrolled {
+			return enrollmentErrors.NewAlreadyEnrolledError(cmd.ClassID, cmd.UserID)
+		}
+
+		existing, err := ledger.FindActiveHold(ctx, cmd.UserID, now)
+		if err != nil {
+			return errors.PropagateError(err)
+		}
+		if existing != nil {
+			hold = existing
+			return nil
+		}
+
+		if capacity.Available() == 0 {
+			return enrollmentErrors.NewClassFullError(cmd.ClassID, capacity.Capacity)
+		}
+
+		hold, err = entities.NewSeatHold(uuid.New().String(), cmd.TenantID, cmd.ClassID, cmd.UserID, now.Add(uc.holdTTL), now)
+		if err != nil {
+			return errors.PropagateError(err)
+		}
+
+		return ledger.CreateHold(ctx, hold)
+	})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return hold, nil
+}
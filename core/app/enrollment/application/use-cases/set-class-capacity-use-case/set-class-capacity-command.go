@@ -0,0 +1,30 @@
+package set_class_capacity_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SetClassCapacityCommand struct {
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"required,uuid"`
+	Capacity int    `validate:"gte=0,lte=10000"`
+}
+
+func NewSetClassCapacityCommand(tenantID string, classID string, capacity int) (*SetClassCapacityCommand, error) {
+	command := &SetClassCapacityCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+		Capacity: capacity,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
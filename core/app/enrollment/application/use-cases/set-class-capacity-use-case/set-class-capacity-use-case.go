@@ -0,0 +1,35 @@
+package set_class_capacity_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetClassCapacityUseCase struct {
+	inventory ports.SeatInventory
+}
+
+func NewSetClassCapacityUseCase(inventory ports.SeatInventory) *SetClassCapacityUseCase {
+	return &SetClassCapacityUseCase{
+		inventory: inventory,
+	}
+}
+
+// Execute creates or replaces the capacity of the class. Lowering it below the seats already taken
+// keeps existing enrollments and holds, it only blocks new reservations.
+func (uc *SetClassCapacityUseCase) Execute(ctx context.Context, cmd *SetClassCapacityCommand) (*entities.ClassCapacity, error) {
+	if err := uc.inventory.SetCapacity(ctx, cmd.TenantID, cmd.ClassID, cmd.Capacity); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	capacity, err := uc.inventory.GetCapacity(ctx, cmd.TenantID, cmd.ClassID, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return capacity, nil
+}
@@ -0,0 +1,36 @@
+package set_class_schedule_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type MeetingInput struct {
+	Weekday  string `validate:"required,oneof=monday tuesday wednesday thursday friday saturday sunday"`
+	StartsAt string `validate:"required,datetime=15:04"`
+	EndsAt   string `validate:"required,datetime=15:04"`
+}
+
+type SetClassScheduleCommand struct {
+	TenantID string         `validate:"required"`
+	ClassID  string         `validate:"required,uuid"`
+	Meetings []MeetingInput `validate:"max=21,dive"`
+}
+
+func NewSetClassScheduleCommand(tenantID string, classID string, meetings []MeetingInput) (*SetClassScheduleCommand, error) {
+	command := &SetClassScheduleCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+		Meetings: meetings,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
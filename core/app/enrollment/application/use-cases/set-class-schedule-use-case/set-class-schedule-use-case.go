@@ -0,0 +1,38 @@
+package set_class_schedule_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetClassScheduleUseCase struct {
+	schedules ports.ScheduleRepository
+}
+
+func NewSetClassScheduleUseCase(schedules ports.ScheduleRepository) *SetClassScheduleUseCase {
+	return &SetClassScheduleUseCase{
+		schedules: schedules,
+	}
+}
+
+// Execute replaces the weekly meetings of the class. Students already enrolled are not rechecked,
+// conflicts are only detected for new enrollments.
+func (uc *SetClassScheduleUseCase) Execute(ctx context.Context, cmd *SetClassScheduleCommand) ([]entities.MeetingTime, error) {
+	meetings := make([]entities.MeetingTime, 0, len(cmd.Meetings))
+	for _, input := range cmd.Meetings {
+		meeting, err := entities.ParseMeetingTime(input.Weekday, input.StartsAt, input.EndsAt)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		meetings = append(meetings, *meeting)
+	}
+
+	if err := uc.schedules.ReplaceMeetings(ctx, cmd.TenantID, cmd.ClassID, meetings); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return meetings, nil
+}
@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// ClassCapacity is the seat inventory of a class. Held only counts holds that have not expired.
+type ClassCapacity struct {
+	TenantID string
+	ClassID  string
+	Capacity int
+	Enrolled int
+	Held     int
+}
+
+// Available is never negative, the capacity can be lowered below the seats already taken
+func (c *ClassCapacity) Available() int {
+	return max(0, c.Capacity-c.Enrolled-c.Held)
+}
+
+type Enrollment struct {
+	TenantID   string
+	ClassID    string
+	UserID     string
+	EnrolledAt time.Time
+}
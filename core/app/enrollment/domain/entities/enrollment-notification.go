@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+type EnrollmentNotificationKind string
+
+const (
+	EnrollmentNotificationEnrolled EnrollmentNotificationKind = "enrolled"
+	// EnrollmentNotificationCanceled follows an enrolled notification when the enrollment was undone
+	EnrollmentNotificationCanceled EnrollmentNotificationKind = "enrollment_canceled"
+)
+
+// EnrollmentNotification tells a student about their enrollment, senders deliver it from the outbox
+type EnrollmentNotification struct {
+	ID        string
+	TenantID  string
+	ClassID   string
+	StudentID string
+	Kind      EnrollmentNotificationKind
+	CreatedAt time.Time
+}
@@ -0,0 +1,142 @@
+package entities
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// MeetingTime is a weekly recurring class meeting, minutes are counted from midnight in the tenant's
+// local time
+type MeetingTime struct {
+	Weekday     time.Weekday `validate:"gte=0,lte=6"`
+	StartMinute int          `validate:"gte=0,lt=1440"`
+	EndMinute   int          `validate:"gtfield=StartMinute,lte=1440"`
+}
+
+func NewMeetingTime(weekday time.Weekday, startMinute int, endMinute int) (*MeetingTime, error) {
+	meeting := &MeetingTime{
+		Weekday:     weekday,
+		StartMinute: startMinute,
+		EndMinute:   endMinute,
+	}
+
+	if err := validate.Struct(meeting); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("MeetingTime domain model instance not valid", errorMap, err)
+	}
+
+	return meeting, nil
+}
+
+// ParseMeetingTime builds a meeting from a lowercase weekday name and "15:04" clock times
+func ParseMeetingTime(weekday string, startsAt string, endsAt string) (*MeetingTime, error) {
+	day := -1
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), weekday) {
+			day = int(d)
+		}
+	}
+	start, startErr := time.Parse("15:04", startsAt)
+	end, endErr := time.Parse("15:04", endsAt)
+	if day < 0 || startErr != nil || endErr != nil {
+		return nil, appErrors.NewDomainEntityValidationError("Invalid meeting time", map[string]any{
+			"weekday":   weekday,
+			"starts_at": startsAt,
+			"ends_at":   endsAt,
+		}, errors.New("invalid meeting time"))
+	}
+
+	return NewMeetingTime(time.Weekday(day), start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute())
+}
+
+// Overlaps reports whether both meetings share some time, back to back meetings do not overlap
+func (m MeetingTime) Overlaps(other MeetingTime) bool {
+	return m.Weekday == other.Weekday && m.StartMinute < other.EndMinute && other.StartMinute < m.EndMinute
+}
+
+func (m MeetingTime) StartsAt() string {
+	return clock(m.StartMinute)
+}
+
+func (m MeetingTime) EndsAt() string {
+	return clock(m.EndMinute)
+}
+
+func clock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// ScheduleConflict is a meeting of the class being enrolled that overlaps a meeting of ClassID
+type ScheduleConflict struct {
+	ClassID            string
+	Meeting            MeetingTime
+	ConflictingMeeting MeetingTime
+}
+
+// FindScheduleConflicts checks the meetings of a class against the meetings of the classes the student
+// already attends, keyed by class ID. Results are ordered by class, then by the time of both meetings,
+// so the same schedules always give the same conflicts.
+func FindScheduleConflicts(meetings []MeetingTime, existing map[string][]MeetingTime) []ScheduleConflict {
+	var conflicts []ScheduleConflict
+	for classID, classMeetings := range existing {
+		for _, meeting := range meetings {
+			for _, other := range classMeetings {
+				if meeting.Overlaps(other) {
+					conflicts = append(conflicts, ScheduleConflict{ClassID: classID, Meeting: meeting, ConflictingMeeting: other})
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(conflicts, func(a, b ScheduleConflict) int {
+		return cmp.Or(
+			cmp.Compare(a.ClassID, b.ClassID),
+			compareMeetings(a.Meeting, b.Meeting),
+			compareMeetings(a.ConflictingMeeting, b.ConflictingMeeting),
+		)
+	})
+
+	return conflicts
+}
+
+func compareMeetings(a, b MeetingTime) int {
+	return cmp.Or(cmp.Compare(a.Weekday, b.Weekday), cmp.Compare(a.StartMinute, b.StartMinute), cmp.Compare(a.EndMinute, b.EndMinute))
+}
+
+// ScheduleSource reads the meetings enrollments are checked against
+type ScheduleSource interface {
+	ListMeetings(ctx context.Context, tenantID string, classID string) ([]MeetingTime, error)
+	// ListEnrolledMeetings returns the meetings of every class the user is enrolled in, keyed by class ID
+	ListEnrolledMeetings(ctx context.Context, tenantID string, userID string) (map[string][]MeetingTime, error)
+}
+
+// FindEnrollmentConflicts compares the meetings of a class with the classes the user is already
+// enrolled in, the class itself left out
+func FindEnrollmentConflicts(ctx context.Context, schedules ScheduleSource, tenantID string, classID string, userID string) ([]ScheduleConflict, error) {
+	meetings, err := schedules.ListMeetings(ctx, tenantID, classID)
+	if err != nil || len(meetings) == 0 {
+		return nil, err
+	}
+
+	enrolled, err := schedules.ListEnrolledMeetings(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	delete(enrolled, classID)
+
+	return FindScheduleConflicts(meetings, enrolled), nil
+}
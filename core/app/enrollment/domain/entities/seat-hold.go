@@ -0,0 +1,50 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// DefaultHoldTTL is how long a seat stays held while the student completes the enrollment checkout
+const DefaultHoldTTL = 10 * time.Minute
+
+// SeatHold reserves one seat of a class for a user until ExpiresAt. Active holds count against the
+// class capacity the same way enrollments do.
+type SeatHold struct {
+	ID        string    `validate:"required,uuid4"`
+	TenantID  string    `validate:"required"`
+	ClassID   string    `validate:"required,uuid"`
+	UserID    string    `validate:"required"`
+	ExpiresAt time.Time `validate:"required"`
+	CreatedAt time.Time `validate:"required"`
+}
+
+func NewSeatHold(id string, tenantID string, classID string, userID string, expiresAt time.Time, createdAt time.Time) (*SeatHold, error) {
+	hold := &SeatHold{
+		ID:        id,
+		TenantID:  tenantID,
+		ClassID:   classID,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		CreatedAt: createdAt,
+	}
+
+	if err := validate.Struct(hold); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("SeatHold domain model instance not valid", errorMap, err)
+	}
+
+	return hold, nil
+}
+
+func (h *SeatHold) IsExpired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ClassCapacityNotFoundError errors2.ErrorCode = "CLASS_CAPACITY_NOT_FOUND"
+	ClassFullError             errors2.ErrorCode = "CLASS_FULL"
+	AlreadyEnrolledError       errors2.ErrorCode = "ALREADY_ENROLLED"
+	SeatHoldNotFoundError      errors2.ErrorCode = "SEAT_HOLD_NOT_FOUND"
+	SeatHoldExpiredError       errors2.ErrorCode = "SEAT_HOLD_EXPIRED"
+	ScheduleConflictError      errors2.ErrorCode = "SCHEDULE_CONFLICT"
+)
+
+func NewClassCapacityNotFoundError(classID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ClassCapacityNotFoundError.String(),
+			Message: "The class has no seat capacity configured",
+			Context: map[string]any{
+				"class_id": classID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ClassCapacityNotFoundError.String()),
+		},
+	}
+}
+
+func NewClassFullError(classID string, capacity int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ClassFullError.String(),
+			Message: "There are no seats left in the class",
+			Context: map[string]any{
+				"class_id": classID,
+				"capacity": capacity,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ClassFullError.String()),
+		},
+	}
+}
+
+func NewAlreadyEnrolledError(classID string, userID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    AlreadyEnrolledError.String(),
+			Message: "The user is already enrolled in the class",
+			Context: map[string]any{
+				"class_id": classID,
+				"user_id":  userID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(AlreadyEnrolledError.String()),
+		},
+	}
+}
+
+func NewSeatHoldNotFoundError(holdID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SeatHoldNotFoundError.String(),
+			Message: "The requested seat hold could not be found",
+			Context: map[string]any{
+				"hold_id": holdID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SeatHoldNotFoundError.String()),
+		},
+	}
+}
+
+func NewSeatHoldExpiredError(holdID string, expiredAt time.Time) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SeatHoldExpiredError.String(),
+			Message: "The seat hold has expired, reserve a seat again",
+			Context: map[string]any{
+				"hold_id":    holdID,
+				"expired_at": expiredAt,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SeatHoldExpiredError.String()),
+		},
+	}
+}
+
+// NewScheduleConflictError lists every overlap so the client can show which classes clash
+func NewScheduleConflictError(classID string, conflicts []entities.ScheduleConflict) *errors2.BaseDomainError {
+	details := make([]map[string]any, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		details = append(details, map[string]any{
+			"class_id":  conflict.ClassID,
+			"weekday":   strings.ToLower(conflict.ConflictingMeeting.Weekday.String()),
+			"starts_at": conflict.ConflictingMeeting.StartsAt(),
+			"ends_at":   conflict.ConflictingMeeting.EndsAt(),
+		})
+	}
+
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ScheduleConflictError.String(),
+			Message: "The class meets at the same time as classes the student is already enrolled in",
+			Context: map[string]any{
+				"class_id":  classID,
+				"conflicts": details,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ScheduleConflictError.String()),
+		},
+	}
+}
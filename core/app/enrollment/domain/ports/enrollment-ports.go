@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+)
+
+// EnrollmentRepository reads and removes enrollments outside of the checkout
+type EnrollmentRepository interface {
+	// Find returns nil when the user is not enrolled in the class
+	Find(ctx context.Context, tenantID string, classID string, userID string) (*entities.Enrollment, error)
+	// Remove also appends the enrollment removed event to the outbox, nothing is written when the
+	// user is not enrolled
+	Remove(ctx context.Context, tenantID string, classID string, userID string, at time.Time) error
+}
+
+// EnrollmentNotificationQueue queues notifications to the outbox, queueing one twice writes nothing
+type EnrollmentNotificationQueue interface {
+	Queue(ctx context.Context, notification *entities.EnrollmentNotification) error
+}
+
+// StudentInformationSystem is the system of record of the school, confirmed enrollments are pushed
+// to it. Pushing an enrollment twice must not duplicate it.
+type StudentInformationSystem interface {
+	PushEnrollment(ctx context.Context, enrollment *entities.Enrollment) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+)
+
+type ScheduleRepository interface {
+	// ReplaceMeetings swaps the whole weekly schedule of the class
+	ReplaceMeetings(ctx context.Context, tenantID string, classID string, meetings []entities.MeetingTime) error
+	entities.ScheduleSource
+}
@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+)
+
+type SeatInventory interface {
+	SetCapacity(ctx context.Context, tenantID string, classID string, capacity int) error
+	GetCapacity(ctx context.Context, tenantID string, classID string, now time.Time) (*entities.ClassCapacity, error)
+	// WithClassLocked runs fn while holding the lock of the class seat inventory, so holds and
+	// enrollments of one class change one checkout at a time. Writes made through the ledger are
+	// committed together when fn returns nil and discarded otherwise. capacity is nil when the class
+	// has no capacity configured.
+	WithClassLocked(ctx context.Context, tenantID string, classID string, now time.Time,
+		fn func(ledger SeatLedger, capacity *entities.ClassCapacity) error) error
+	DeleteExpiredHolds(ctx context.Context, now time.Time) (int64, error)
+}
+
+// SeatLedger reads and writes the holds and enrollments of the locked class
+type SeatLedger interface {
+	FindHold(ctx context.Context, holdID string) (*entities.SeatHold, error)
+	FindActiveHold(ctx context.Context, userID string, now time.Time) (*entities.SeatHold, error)
+	IsEnrolled(ctx context.Context, userID string) (bool, error)
+	CreateHold(ctx context.Context, hold *entities.SeatHold) error
+	DeleteHold(ctx context.Context, holdID string) error
+	// CreateEnrollment also appends the enrollment event to the outbox
+	CreateEnrollment(ctx context.Context, enrollment *entities.Enrollment) error
+}
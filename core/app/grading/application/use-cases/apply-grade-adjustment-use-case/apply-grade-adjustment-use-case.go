@@ -0,0 +1,77 @@
+package apply_grade_adjustment_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type ApplyGradeAdjustmentUseCase struct {
+	gradeRepo ports.GradeRepository
+}
+
+func NewApplyGradeAdjustmentUseCase(gradeRepo ports.GradeRepository) *ApplyGradeAdjustmentUseCase {
+	return &ApplyGradeAdjustmentUseCase{
+		gradeRepo: gradeRepo,
+	}
+}
+
+// Execute makes the changes the preview of the adjustment shows, all of them or none. Skipped grades are
+// left as they are. Grades changed since they were planned fail with GRADE_CHANGED_CONCURRENTLY, so the
+// adjustment never applies to grades the instructor did not preview. The changes keep the grades as
+// they were before.
+func (uc *ApplyGradeAdjustmentUseCase) Execute(ctx context.Context, cmd *ApplyGradeAdjustmentCommand) (*entities.GradeAdjustment, error) {
+	grades, err := uc.gradeRepo.ListByClass(ctx, cmd.TenantID, cmd.ClassID, cmd.AssignmentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	adjustment := &entities.GradeAdjustment{
+		ID:      uuid.New().String(),
+		Kind:    cmd.Adjuster.Kind(),
+		Changes: cmd.Adjuster.Plan(grades),
+	}
+
+	var gradeIDs []string
+	for _, change := range adjustment.Changes {
+		if !change.Skipped {
+			gradeIDs = append(gradeIDs, change.Grade.ID)
+		}
+	}
+	if len(gradeIDs) == 0 {
+		return adjustment, nil
+	}
+
+	err = uc.gradeRepo.WithGradesLocked(ctx, cmd.TenantID, cmd.ClassID, gradeIDs, func(locked []*entities.Grade) error {
+		byID := make(map[string]*entities.Grade, len(locked))
+		for _, grade := range locked {
+			byID[grade.ID] = grade
+		}
+
+		now := time.Now().UTC()
+		for _, change := range adjustment.Changes {
+			if change.Skipped {
+				continue
+			}
+			grade := byID[change.Grade.ID]
+			if grade == nil || grade.Version != change.Grade.Version {
+				return gradingErrors.NewGradeChangedConcurrentlyError(change.Grade.ID, change.Grade.Version)
+			}
+			if err := grade.Adjust(change, adjustment.Kind, adjustment.ID, cmd.ActorID, cmd.Reason, now); err != nil {
+				return errors.PropagateError(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return adjustment, nil
+}
@@ -0,0 +1,40 @@
+package change_grade_status_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// MaxGradesPerAction bounds bulk actions so a single transaction stays short
+const MaxGradesPerAction = 500
+
+type ChangeGradeStatusCommand struct {
+	TenantID string               `validate:"required"`
+	ClassID  string               `validate:"required,uuid"`
+	Action   entities.GradeAction `validate:"required,oneof=submit approve return release"`
+	GradeIDs []string             `validate:"required,min=1,max=500,unique,dive,uuid"`
+	ActorID  string               `validate:"required"`
+	Reason   string               `validate:"required_if=Action return,max=1000"`
+}
+
+func NewChangeGradeStatusCommand(tenantID string, classID string, action entities.GradeAction, gradeIDs []string, actorID string, reason string) (*ChangeGradeStatusCommand, error) {
+	command := &ChangeGradeStatusCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+		Action:   action,
+		GradeIDs: gradeIDs,
+		ActorID:  actorID,
+		Reason:   reason,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
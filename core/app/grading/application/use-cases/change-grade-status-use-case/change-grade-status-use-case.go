@@ -0,0 +1,116 @@
+package change_grade_status_use_case
+
+import (
+	"context"
+	"time"
+
+	calendarEntities "github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	calendarPorts "github.com/nahualventure/class-backend/core/app/calendar/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ChangeGradeStatusUseCase struct {
+	gradeRepo      ports.GradeRepository
+	policyRepo     ports.GradingPolicyRepository
+	deadlineRepo   ports.DeadlineRepository
+	calendarReader calendarPorts.SchoolCalendarReader
+}
+
+func NewChangeGradeStatusUseCase(gradeRepo ports.GradeRepository, policyRepo ports.GradingPolicyRepository, deadlineRepo ports.DeadlineRepository,
+	calendarReader calendarPorts.SchoolCalendarReader) *ChangeGradeStatusUseCase {
+	return &ChangeGradeStatusUseCase{
+		gradeRepo:      gradeRepo,
+		policyRepo:     policyRepo,
+		deadlineRepo:   deadlineRepo,
+		calendarReader: calendarReader,
+	}
+}
+
+// Execute applies the action to every grade or to none of them, the error lists all the grades that
+// block the action. Releasing freezes the late penalty of each grade.
+func (uc *ChangeGradeStatusUseCase) Execute(ctx context.Context, cmd *ChangeGradeStatusCommand) ([]*entities.Grade, error) {
+	policy, err := uc.policyRepo.Get(ctx, cmd.TenantID, cmd.ClassID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	requiresApproval := policy != nil && policy.RequiresApproval
+
+	gradebook := entities.NewGradebook(nil, nil, nil)
+	if cmd.Action == entities.GradeActionRelease {
+		if gradebook, err = uc.loadGradebook(ctx, cmd.TenantID, cmd.ClassID); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	}
+
+	var changed []*entities.Grade
+	err = uc.gradeRepo.WithGradesLocked(ctx, cmd.TenantID, cmd.ClassID, cmd.GradeIDs, func(grades []*entities.Grade) error {
+		if missing := missingIDs(cmd.GradeIDs, grades); len(missing) > 0 {
+			return gradingErrors.NewGradeNotFoundError(missing)
+		}
+
+		var rejected []map[string]any
+		for _, grade := range grades {
+			if !grade.CanApply(cmd.Action, requiresApproval) {
+				rejected = append(rejected, map[string]any{"grade_id": grade.ID, "status": string(grade.Status)})
+			}
+		}
+		if len(rejected) > 0 {
+			return gradingErrors.NewInvalidGradeTransitionError(string(cmd.Action), rejected)
+		}
+
+		now := time.Now().UTC()
+		for _, grade := range grades {
+			lateness := gradebook.Entry(grade).Lateness
+			if err := grade.Apply(cmd.Action, cmd.ActorID, cmd.Reason, requiresApproval, lateness, now); err != nil {
+				return errors.PropagateError(err)
+			}
+		}
+
+		changed = grades
+		return nil
+	})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return changed, nil
+}
+
+func (uc *ChangeGradeStatusUseCase) loadGradebook(ctx context.Context, tenantID string, classID string) (*entities.Gradebook, error) {
+	deadlines, err := uc.deadlineRepo.ListDeadlines(ctx, tenantID, classID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	extensions, err := uc.deadlineRepo.ListExtensions(ctx, tenantID, classID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	var calendar *calendarEntities.SchoolCalendar
+	if from, to, ok := entities.CalendarRange(deadlines, time.Now()); ok {
+		if calendar, err = uc.calendarReader.SchoolCalendar(ctx, tenantID, from, to); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	}
+
+	return entities.NewGradebook(deadlines, extensions, calendar), nil
+}
+
+func missingIDs(requested []string, grades []*entities.Grade) []string {
+	found := make(map[string]bool, len(grades))
+	for _, grade := range grades {
+		found[grade.ID] = true
+	}
+
+	var missing []string
+	for _, id := range requested {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
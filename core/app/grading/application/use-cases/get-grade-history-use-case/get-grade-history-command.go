@@ -0,0 +1,30 @@
+package get_grade_history_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetGradeHistoryCommand struct {
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"required,uuid"`
+	GradeID  string `validate:"required,uuid"`
+}
+
+func NewGetGradeHistoryCommand(tenantID string, classID string, gradeID string) (*GetGradeHistoryCommand, error) {
+	command := &GetGradeHistoryCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+		GradeID:  gradeID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
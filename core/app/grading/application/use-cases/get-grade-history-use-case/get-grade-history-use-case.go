@@ -0,0 +1,33 @@
+package get_grade_history_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetGradeHistoryUseCase struct {
+	gradeRepo ports.GradeRepository
+}
+
+func NewGetGradeHistoryUseCase(gradeRepo ports.GradeRepository) *GetGradeHistoryUseCase {
+	return &GetGradeHistoryUseCase{
+		gradeRepo: gradeRepo,
+	}
+}
+
+// Execute returns every change of the grade in order, archived grades keep their history
+func (uc *GetGradeHistoryUseCase) Execute(ctx context.Context, cmd *GetGradeHistoryCommand) ([]*entities.GradeEvent, error) {
+	events, err := uc.gradeRepo.ListEvents(ctx, cmd.TenantID, cmd.ClassID, cmd.GradeID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if len(events) == 0 {
+		return nil, gradingErrors.NewGradeNotFoundError([]string{cmd.GradeID})
+	}
+
+	return events, nil
+}
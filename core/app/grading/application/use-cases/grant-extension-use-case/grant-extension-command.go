@@ -0,0 +1,41 @@
+package grant_extension_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GrantExtensionCommand struct {
+	TenantID     string    `validate:"required"`
+	ClassID      string    `validate:"required,uuid"`
+	AssignmentID string    `validate:"required,uuid"`
+	StudentID    string    `validate:"required"`
+	DueAt        time.Time `validate:"required"`
+	Reason       string    `validate:"max=1000"`
+	GrantedBy    string    `validate:"required"`
+}
+
+func NewGrantExtensionCommand(tenantID string, classID string, assignmentID string, studentID string, dueAt time.Time,
+	reason string, grantedBy string) (*GrantExtensionCommand, error) {
+	command := &GrantExtensionCommand{
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+		StudentID:    studentID,
+		DueAt:        dueAt,
+		Reason:       reason,
+		GrantedBy:    grantedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,52 @@
+package grant_extension_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GrantExtensionUseCase struct {
+	deadlineRepo ports.DeadlineRepository
+}
+
+func NewGrantExtensionUseCase(deadlineRepo ports.DeadlineRepository) *GrantExtensionUseCase {
+	return &GrantExtensionUseCase{
+		deadlineRepo: deadlineRepo,
+	}
+}
+
+// Execute gives the student a later due date, granting again replaces the previous extension
+func (uc *GrantExtensionUseCase) Execute(ctx context.Context, cmd *GrantExtensionCommand) (*entities.Extension, error) {
+	deadline, err := uc.deadlineRepo.GetDeadline(ctx, cmd.TenantID, cmd.ClassID, cmd.AssignmentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if deadline == nil {
+		return nil, gradingErrors.NewDeadlineNotFoundError(cmd.AssignmentID)
+	}
+	if !cmd.DueAt.After(deadline.DueAt) {
+		return nil, gradingErrors.NewInvalidExtensionError(cmd.AssignmentID, deadline.DueAt, cmd.DueAt)
+	}
+
+	extension := &entities.Extension{
+		TenantID:     cmd.TenantID,
+		ClassID:      cmd.ClassID,
+		AssignmentID: cmd.AssignmentID,
+		StudentID:    cmd.StudentID,
+		DueAt:        cmd.DueAt.UTC(),
+		GrantedBy:    cmd.GrantedBy,
+		Reason:       cmd.Reason,
+		GrantedAt:    time.Now().UTC(),
+	}
+
+	if err := uc.deadlineRepo.SetExtension(ctx, extension); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return extension, nil
+}
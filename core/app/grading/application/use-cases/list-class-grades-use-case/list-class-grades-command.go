@@ -0,0 +1,30 @@
+package list_class_grades_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListClassGradesCommand struct {
+	TenantID     string `validate:"required"`
+	ClassID      string `validate:"required,uuid"`
+	AssignmentID string `validate:"omitempty,uuid"`
+}
+
+func NewListClassGradesCommand(tenantID string, classID string, assignmentID string) (*ListClassGradesCommand, error) {
+	command := &ListClassGradesCommand{
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
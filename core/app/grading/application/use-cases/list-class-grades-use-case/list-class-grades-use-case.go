@@ -0,0 +1,55 @@
+package list_class_grades_use_case
+
+import (
+	"context"
+	"time"
+
+	calendarEntities "github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	calendarPorts "github.com/nahualventure/class-backend/core/app/calendar/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListClassGradesUseCase struct {
+	gradeRepo      ports.GradeRepository
+	deadlineRepo   ports.DeadlineRepository
+	calendarReader calendarPorts.SchoolCalendarReader
+}
+
+func NewListClassGradesUseCase(gradeRepo ports.GradeRepository, deadlineRepo ports.DeadlineRepository,
+	calendarReader calendarPorts.SchoolCalendarReader) *ListClassGradesUseCase {
+	return &ListClassGradesUseCase{
+		gradeRepo:      gradeRepo,
+		deadlineRepo:   deadlineRepo,
+		calendarReader: calendarReader,
+	}
+}
+
+// Execute returns the grades of the class in every status with late penalties applied, it backs the
+// staff gradebook
+func (uc *ListClassGradesUseCase) Execute(ctx context.Context, cmd *ListClassGradesCommand) ([]entities.GradebookEntry, error) {
+	grades, err := uc.gradeRepo.ListByClass(ctx, cmd.TenantID, cmd.ClassID, cmd.AssignmentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	deadlines, err := uc.deadlineRepo.ListDeadlines(ctx, cmd.TenantID, cmd.ClassID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	extensions, err := uc.deadlineRepo.ListExtensions(ctx, cmd.TenantID, cmd.ClassID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	var calendar *calendarEntities.SchoolCalendar
+	if from, to, ok := entities.CalendarRange(deadlines, time.Now()); ok {
+		if calendar, err = uc.calendarReader.SchoolCalendar(ctx, cmd.TenantID, from, to); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	}
+
+	return entities.NewGradebook(deadlines, extensions, calendar).Entries(grades), nil
+}
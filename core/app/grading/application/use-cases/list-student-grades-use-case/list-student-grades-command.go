@@ -0,0 +1,30 @@
+package list_student_grades_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListStudentGradesCommand struct {
+	TenantID  string `validate:"required"`
+	StudentID string `validate:"required"`
+	ClassID   string `validate:"omitempty,uuid"`
+}
+
+func NewListStudentGradesCommand(tenantID string, studentID string, classID string) (*ListStudentGradesCommand, error) {
+	command := &ListStudentGradesCommand{
+		TenantID:  tenantID,
+		StudentID: studentID,
+		ClassID:   classID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
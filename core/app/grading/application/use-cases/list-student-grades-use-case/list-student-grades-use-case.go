@@ -0,0 +1,38 @@
+package list_student_grades_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListStudentGradesUseCase struct {
+	gradeRepo ports.GradeRepository
+}
+
+func NewListStudentGradesUseCase(gradeRepo ports.GradeRepository) *ListStudentGradesUseCase {
+	return &ListStudentGradesUseCase{
+		gradeRepo: gradeRepo,
+	}
+}
+
+// Execute returns the grades the student is allowed to see. The repository already filters by status,
+// the check is repeated here so a query change cannot leak unreleased grades. Released grades carry
+// their frozen late penalty, so no deadlines are needed.
+func (uc *ListStudentGradesUseCase) Execute(ctx context.Context, cmd *ListStudentGradesCommand) ([]entities.GradebookEntry, error) {
+	grades, err := uc.gradeRepo.ListReleasedForStudent(ctx, cmd.TenantID, cmd.StudentID, cmd.ClassID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	visible := make([]entities.GradebookEntry, 0, len(grades))
+	for _, grade := range grades {
+		if grade.StudentID == cmd.StudentID && grade.VisibleToStudent() {
+			visible = append(visible, entities.NewGradebookEntry(grade, nil, nil, nil))
+		}
+	}
+
+	return visible, nil
+}
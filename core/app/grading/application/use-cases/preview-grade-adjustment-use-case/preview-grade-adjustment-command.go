@@ -0,0 +1,44 @@
+package preview_grade_adjustment_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type PreviewGradeAdjustmentCommand struct {
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"required,uuid"`
+	// AssignmentID is the assignment whose grades are adjusted, drops span every assignment of the class
+	AssignmentID string                  `validate:"required_unless=Kind drop_lowest,excluded_if=Kind drop_lowest,omitempty,uuid"`
+	Kind         entities.AdjustmentKind `validate:"required,oneof=curve_points curve_percent drop_lowest regrade_question"`
+	Adjuster     entities.GradeAdjuster  `validate:"-"`
+}
+
+// NewPreviewGradeAdjustmentCommand takes the parameters of every kind of adjustment, the ones kind
+// does not use are ignored
+func NewPreviewGradeAdjustmentCommand(tenantID string, classID string, assignmentID string, kind entities.AdjustmentKind,
+	points float64, percent float64, count int, studentIDs []string) (*PreviewGradeAdjustmentCommand, error) {
+	command := &PreviewGradeAdjustmentCommand{
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+		Kind:         kind,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	adjuster, err := entities.NewGradeAdjuster(kind, points, percent, count, studentIDs)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	command.Adjuster = adjuster
+
+	return command, nil
+}
@@ -0,0 +1,29 @@
+package preview_grade_adjustment_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type PreviewGradeAdjustmentUseCase struct {
+	gradeRepo ports.GradeRepository
+}
+
+func NewPreviewGradeAdjustmentUseCase(gradeRepo ports.GradeRepository) *PreviewGradeAdjustmentUseCase {
+	return &PreviewGradeAdjustmentUseCase{
+		gradeRepo: gradeRepo,
+	}
+}
+
+// Execute returns the changes the adjustment would make without making them
+func (uc *PreviewGradeAdjustmentUseCase) Execute(ctx context.Context, cmd *PreviewGradeAdjustmentCommand) ([]entities.GradeChange, error) {
+	grades, err := uc.gradeRepo.ListByClass(ctx, cmd.TenantID, cmd.ClassID, cmd.AssignmentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return cmd.Adjuster.Plan(grades), nil
+}
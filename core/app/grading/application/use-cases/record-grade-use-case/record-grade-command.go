@@ -0,0 +1,43 @@
+package record_grade_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RecordGradeCommand struct {
+	TenantID     string  `validate:"required"`
+	ClassID      string  `validate:"required,uuid"`
+	AssignmentID string  `validate:"required,uuid"`
+	StudentID    string  `validate:"required"`
+	Score        float64 `validate:"gte=0,lte=100"`
+	TurnedInAt   *time.Time
+	RecordedBy   string `validate:"required"`
+	// Reason explains a change of score, it is kept in the history of the grade
+	Reason string `validate:"max=1000"`
+}
+
+func NewRecordGradeCommand(tenantID string, classID string, assignmentID string, studentID string, score float64, turnedInAt *time.Time, recordedBy string, reason string) (*RecordGradeCommand, error) {
+	command := &RecordGradeCommand{
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+		StudentID:    studentID,
+		Score:        score,
+		TurnedInAt:   turnedInAt,
+		RecordedBy:   recordedBy,
+		Reason:       reason,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,49 @@
+package record_grade_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type RecordGradeUseCase struct {
+	gradeRepo ports.GradeRepository
+}
+
+func NewRecordGradeUseCase(gradeRepo ports.GradeRepository) *RecordGradeUseCase {
+	return &RecordGradeUseCase{
+		gradeRepo: gradeRepo,
+	}
+}
+
+// Execute creates the draft grade of the student for the assignment or changes its score while it is
+// still a draft
+func (uc *RecordGradeUseCase) Execute(ctx context.Context, cmd *RecordGradeCommand) (*entities.Grade, error) {
+	now := time.Now().UTC()
+
+	grade, err := uc.gradeRepo.FindByStudent(ctx, cmd.TenantID, cmd.ClassID, cmd.AssignmentID, cmd.StudentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if grade == nil {
+		grade, err = entities.RecordGrade(uuid.New().String(), cmd.TenantID, cmd.ClassID, cmd.AssignmentID, cmd.StudentID,
+			cmd.Score, cmd.TurnedInAt, cmd.RecordedBy, now)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	} else if err := grade.SetScore(cmd.Score, cmd.TurnedInAt, cmd.RecordedBy, cmd.Reason, now); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.gradeRepo.Save(ctx, grade); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return grade, nil
+}
@@ -0,0 +1,39 @@
+package set_assignment_deadline_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SetAssignmentDeadlineCommand struct {
+	TenantID             string    `validate:"required"`
+	ClassID              string    `validate:"required,uuid"`
+	AssignmentID         string    `validate:"required,uuid"`
+	DueAt                time.Time `validate:"required"`
+	PenaltyPercentPerDay float64   `validate:"gte=0,lte=100"`
+	CutoffDays           int       `validate:"gte=0,lte=365"`
+}
+
+func NewSetAssignmentDeadlineCommand(tenantID string, classID string, assignmentID string, dueAt time.Time,
+	penaltyPercentPerDay float64, cutoffDays int) (*SetAssignmentDeadlineCommand, error) {
+	command := &SetAssignmentDeadlineCommand{
+		TenantID:             tenantID,
+		ClassID:              classID,
+		AssignmentID:         assignmentID,
+		DueAt:                dueAt,
+		PenaltyPercentPerDay: penaltyPercentPerDay,
+		CutoffDays:           cutoffDays,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
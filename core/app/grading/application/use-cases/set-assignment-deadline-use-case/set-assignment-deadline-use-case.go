@@ -0,0 +1,37 @@
+package set_assignment_deadline_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetAssignmentDeadlineUseCase struct {
+	deadlineRepo ports.DeadlineRepository
+}
+
+func NewSetAssignmentDeadlineUseCase(deadlineRepo ports.DeadlineRepository) *SetAssignmentDeadlineUseCase {
+	return &SetAssignmentDeadlineUseCase{
+		deadlineRepo: deadlineRepo,
+	}
+}
+
+// Execute creates or replaces the due date and late policy of the assignment. Released grades keep
+// the penalty they were released with.
+func (uc *SetAssignmentDeadlineUseCase) Execute(ctx context.Context, cmd *SetAssignmentDeadlineCommand) (*entities.AssignmentDeadline, error) {
+	deadline, err := entities.NewAssignmentDeadline(cmd.TenantID, cmd.ClassID, cmd.AssignmentID, cmd.DueAt.UTC(), entities.LatePolicy{
+		PenaltyPercentPerDay: cmd.PenaltyPercentPerDay,
+		CutoffDays:           cmd.CutoffDays,
+	})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.deadlineRepo.SetDeadline(ctx, deadline); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return deadline, nil
+}
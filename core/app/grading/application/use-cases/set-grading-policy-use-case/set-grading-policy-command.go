@@ -0,0 +1,30 @@
+package set_grading_policy_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SetGradingPolicyCommand struct {
+	TenantID         string `validate:"required"`
+	ClassID          string `validate:"required,uuid"`
+	RequiresApproval bool
+}
+
+func NewSetGradingPolicyCommand(tenantID string, classID string, requiresApproval bool) (*SetGradingPolicyCommand, error) {
+	command := &SetGradingPolicyCommand{
+		TenantID:         tenantID,
+		ClassID:          classID,
+		RequiresApproval: requiresApproval,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,34 @@
+package set_grading_policy_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetGradingPolicyUseCase struct {
+	policyRepo ports.GradingPolicyRepository
+}
+
+func NewSetGradingPolicyUseCase(policyRepo ports.GradingPolicyRepository) *SetGradingPolicyUseCase {
+	return &SetGradingPolicyUseCase{
+		policyRepo: policyRepo,
+	}
+}
+
+// Execute only affects future releases, grades already approved stay approved when approval is turned off
+func (uc *SetGradingPolicyUseCase) Execute(ctx context.Context, cmd *SetGradingPolicyCommand) (*entities.GradingPolicy, error) {
+	policy := &entities.GradingPolicy{
+		TenantID:         cmd.TenantID,
+		ClassID:          cmd.ClassID,
+		RequiresApproval: cmd.RequiresApproval,
+	}
+
+	if err := uc.policyRepo.Set(ctx, policy); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,32 @@
+package undo_grade_adjustment_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type UndoGradeAdjustmentCommand struct {
+	TenantID     string `validate:"required"`
+	ClassID      string `validate:"required,uuid"`
+	AdjustmentID string `validate:"required,uuid"`
+	ActorID      string `validate:"required"`
+}
+
+func NewUndoGradeAdjustmentCommand(tenantID string, classID string, adjustmentID string, actorID string) (*UndoGradeAdjustmentCommand, error) {
+	command := &UndoGradeAdjustmentCommand{
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AdjustmentID: adjustmentID,
+		ActorID:      actorID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
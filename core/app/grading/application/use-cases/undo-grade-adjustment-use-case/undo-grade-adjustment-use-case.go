@@ -0,0 +1,79 @@
+package undo_grade_adjustment_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type UndoGradeAdjustmentUseCase struct {
+	gradeRepo ports.GradeRepository
+}
+
+func NewUndoGradeAdjustmentUseCase(gradeRepo ports.GradeRepository) *UndoGradeAdjustmentUseCase {
+	return &UndoGradeAdjustmentUseCase{
+		gradeRepo: gradeRepo,
+	}
+}
+
+// Execute puts every grade the adjustment changed back as it was, or none of them. The error lists the
+// grades changed or moved past draft since the adjustment.
+func (uc *UndoGradeAdjustmentUseCase) Execute(ctx context.Context, cmd *UndoGradeAdjustmentCommand) ([]*entities.Grade, error) {
+	events, err := uc.gradeRepo.ListAdjustmentEvents(ctx, cmd.TenantID, cmd.ClassID, cmd.AdjustmentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if len(events) == 0 {
+		return nil, gradingErrors.NewGradeAdjustmentNotFoundError(cmd.AdjustmentID)
+	}
+
+	changes := make(map[string]*entities.GradeEvent, len(events))
+	gradeIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		if event.Type == entities.GradeEventAdjustmentUndone {
+			return nil, gradingErrors.NewGradeAdjustmentUndoneError(cmd.AdjustmentID)
+		}
+		changes[event.GradeID] = event
+		gradeIDs = append(gradeIDs, event.GradeID)
+	}
+
+	var undone []*entities.Grade
+	err = uc.gradeRepo.WithGradesLocked(ctx, cmd.TenantID, cmd.ClassID, gradeIDs, func(grades []*entities.Grade) error {
+		found := make(map[string]bool, len(grades))
+		var rejected []map[string]any
+		for _, grade := range grades {
+			found[grade.ID] = true
+			if !grade.CanUndoAdjustment(changes[grade.ID]) {
+				rejected = append(rejected, map[string]any{"grade_id": grade.ID, "status": string(grade.Status)})
+			}
+		}
+		// Grades archived since the adjustment were released, they cannot change either
+		for _, gradeID := range gradeIDs {
+			if !found[gradeID] {
+				rejected = append(rejected, map[string]any{"grade_id": gradeID, "status": string(entities.GradeStatusReleased)})
+			}
+		}
+		if len(rejected) > 0 {
+			return gradingErrors.NewGradeAdjustmentNotUndoableError(cmd.AdjustmentID, rejected)
+		}
+
+		now := time.Now().UTC()
+		for _, grade := range grades {
+			if err := grade.UndoAdjustment(changes[grade.ID], cmd.ActorID, now); err != nil {
+				return errors.PropagateError(err)
+			}
+		}
+
+		undone = grades
+		return nil
+	})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return undone, nil
+}
@@ -0,0 +1,243 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+type AdjustmentKind string
+
+const (
+	AdjustmentCurvePoints     AdjustmentKind = "curve_points"
+	AdjustmentCurvePercent    AdjustmentKind = "curve_percent"
+	AdjustmentDropLowest      AdjustmentKind = "drop_lowest"
+	AdjustmentRegradeQuestion AdjustmentKind = "regrade_question"
+)
+
+// eventType is the event a change of the adjustment is recorded with
+func (k AdjustmentKind) eventType() GradeEventType {
+	switch k {
+	case AdjustmentDropLowest:
+		return GradeEventDropped
+	case AdjustmentRegradeQuestion:
+		return GradeEventRegraded
+	default:
+		return GradeEventCurved
+	}
+}
+
+// GradeAdjuster is a bulk operation on the grades of a class. Plan works out what it changes without
+// changing anything, the same plan is previewed and applied.
+type GradeAdjuster interface {
+	Kind() AdjustmentKind
+	// Plan returns the grades the adjustment changes, grades it leaves as they are are left out
+	Plan(grades []*Grade) []GradeChange
+}
+
+// GradeChange is what an adjustment makes of a grade. Skipped grades are past draft and are left as
+// they are, they have to be returned to draft to be adjusted.
+type GradeChange struct {
+	Grade   *Grade
+	Score   float64
+	Dropped bool
+	Skipped bool
+}
+
+func newGradeChange(grade *Grade, score float64, dropped bool) GradeChange {
+	return GradeChange{
+		Grade:   grade,
+		Score:   score,
+		Dropped: dropped,
+		Skipped: grade.Status != GradeStatusDraft,
+	}
+}
+
+// GradeAdjustment is an applied adjustment, it is undone through its ID
+type GradeAdjustment struct {
+	ID      string
+	Kind    AdjustmentKind
+	Changes []GradeChange
+}
+
+// CurveByPoints adds Points to every grade, up to 100
+type CurveByPoints struct {
+	Points float64 `validate:"gt=0,lte=100"`
+}
+
+func (c CurveByPoints) Kind() AdjustmentKind {
+	return AdjustmentCurvePoints
+}
+
+func (c CurveByPoints) Plan(grades []*Grade) []GradeChange {
+	return raise(grades, func(score float64) float64 { return score + c.Points })
+}
+
+// CurveByPercent raises every grade by Percent of its score, up to 100
+type CurveByPercent struct {
+	Percent float64 `validate:"gt=0,lte=100"`
+}
+
+func (c CurveByPercent) Kind() AdjustmentKind {
+	return AdjustmentCurvePercent
+}
+
+func (c CurveByPercent) Plan(grades []*Grade) []GradeChange {
+	return raise(grades, func(score float64) float64 { return math.Round(score*(100+c.Percent)) / 100 })
+}
+
+// RegradeQuestion gives the Points of a question back to the students who lost them, every student
+// when StudentIDs is empty
+type RegradeQuestion struct {
+	Points     float64  `validate:"gt=0,lte=100"`
+	StudentIDs []string `validate:"max=500,dive,required"`
+}
+
+func (r RegradeQuestion) Kind() AdjustmentKind {
+	return AdjustmentRegradeQuestion
+}
+
+func (r RegradeQuestion) Plan(grades []*Grade) []GradeChange {
+	if len(r.StudentIDs) > 0 {
+		students := make(map[string]bool, len(r.StudentIDs))
+		for _, studentID := range r.StudentIDs {
+			students[studentID] = true
+		}
+
+		regraded := make([]*Grade, 0, len(grades))
+		for _, grade := range grades {
+			if students[grade.StudentID] {
+				regraded = append(regraded, grade)
+			}
+		}
+		grades = regraded
+	}
+	return raise(grades, func(score float64) float64 { return score + r.Points })
+}
+
+// DropLowest drops the Count lowest grades of every student that are not dropped yet. Every student
+// keeps at least one grade that counts, ties drop the earliest recorded grade.
+type DropLowest struct {
+	Count int `validate:"gte=1,lte=10"`
+}
+
+func (d DropLowest) Kind() AdjustmentKind {
+	return AdjustmentDropLowest
+}
+
+func (d DropLowest) Plan(grades []*Grade) []GradeChange {
+	counted := make(map[string][]*Grade)
+	var students []string
+	for _, grade := range grades {
+		if grade.Dropped {
+			continue
+		}
+		if _, ok := counted[grade.StudentID]; !ok {
+			students = append(students, grade.StudentID)
+		}
+		counted[grade.StudentID] = append(counted[grade.StudentID], grade)
+	}
+
+	var changes []GradeChange
+	for _, studentID := range students {
+		studentGrades := counted[studentID]
+		sort.SliceStable(studentGrades, func(i, j int) bool {
+			if studentGrades[i].Score != studentGrades[j].Score {
+				return studentGrades[i].Score < studentGrades[j].Score
+			}
+			return studentGrades[i].CreatedAt.Before(studentGrades[j].CreatedAt)
+		})
+		for _, grade := range studentGrades[:min(d.Count, len(studentGrades)-1)] {
+			changes = append(changes, newGradeChange(grade, grade.Score, true))
+		}
+	}
+	return changes
+}
+
+// raise changes the score of every grade by fn, capped at 100
+func raise(grades []*Grade, fn func(score float64) float64) []GradeChange {
+	var changes []GradeChange
+	for _, grade := range grades {
+		score := math.Min(100, fn(grade.Score))
+		if score != grade.Score {
+			changes = append(changes, newGradeChange(grade, score, grade.Dropped))
+		}
+	}
+	return changes
+}
+
+// NewGradeAdjuster builds the adjuster of kind from the parameters it takes, the others are ignored
+func NewGradeAdjuster(kind AdjustmentKind, points float64, percent float64, count int, studentIDs []string) (GradeAdjuster, error) {
+	var adjuster GradeAdjuster
+	switch kind {
+	case AdjustmentCurvePoints:
+		adjuster = CurveByPoints{Points: points}
+	case AdjustmentCurvePercent:
+		adjuster = CurveByPercent{Percent: percent}
+	case AdjustmentDropLowest:
+		adjuster = DropLowest{Count: count}
+	case AdjustmentRegradeQuestion:
+		adjuster = RegradeQuestion{Points: points, StudentIDs: studentIDs}
+	default:
+		return nil, appErrors.NewDomainEntityValidationError("GradeAdjuster domain model instance not valid",
+			map[string]any{"Kind": "unknown adjustment " + string(kind)}, nil)
+	}
+
+	if err := validate.Struct(adjuster); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("GradeAdjuster domain model instance not valid", errorMap, err)
+	}
+
+	return adjuster, nil
+}
+
+// Adjust records the change an adjustment makes of the grade, only draft grades can be adjusted
+func (g *Grade) Adjust(change GradeChange, kind AdjustmentKind, adjustmentID string, actorID string, reason string, now time.Time) error {
+	if g.Status != GradeStatusDraft {
+		return gradingErrors.NewGradeNotEditableError(g.ID, string(g.Status))
+	}
+
+	event := g.newEvent(kind.eventType(), actorID, reason, change.Score, g.Status, g.TurnedInAt, g.LatePenaltyPercent, now)
+	event.Dropped = change.Dropped
+	event.AdjustmentID = adjustmentID
+	g.commit(event)
+	return nil
+}
+
+// CanUndoAdjustment reports whether the grade is still as the adjustment left it with event
+func (g *Grade) CanUndoAdjustment(event *GradeEvent) bool {
+	return g.Status == GradeStatusDraft && g.Version == event.Version
+}
+
+// UndoAdjustment puts the score and drop of the grade back as they were before event, the change an
+// adjustment made of it
+func (g *Grade) UndoAdjustment(event *GradeEvent, actorID string, now time.Time) error {
+	if !g.CanUndoAdjustment(event) {
+		return gradingErrors.NewGradeAdjustmentNotUndoableError(event.AdjustmentID, []map[string]any{
+			{"grade_id": g.ID, "status": string(g.Status)},
+		})
+	}
+
+	score := g.Score
+	if event.OldScore != nil {
+		score = *event.OldScore
+	}
+	undo := g.newEvent(GradeEventAdjustmentUndone, actorID, "", score, g.Status, g.TurnedInAt, g.LatePenaltyPercent, now)
+	// Only drops change whether a grade is dropped, and only grades that were not dropped are dropped
+	undo.Dropped = g.Dropped && event.Type != GradeEventDropped
+	undo.AdjustmentID = event.AdjustmentID
+	g.commit(undo)
+	return nil
+}
@@ -0,0 +1,165 @@
+package entities
+
+import (
+	"time"
+)
+
+type GradeEventType string
+
+const (
+	GradeEventRecorded     GradeEventType = "recorded"
+	GradeEventScoreChanged GradeEventType = "score_changed"
+	GradeEventSubmitted    GradeEventType = "submitted"
+	GradeEventApproved     GradeEventType = "approved"
+	GradeEventReturned     GradeEventType = "returned"
+	GradeEventReleased     GradeEventType = "released"
+	// GradeEventImported starts the stream of grades recorded before changes were kept, it holds the
+	// grade as it was then
+	GradeEventImported GradeEventType = "imported"
+	// GradeEventCurved, GradeEventRegraded and GradeEventDropped are the changes of a bulk adjustment,
+	// GradeEventAdjustmentUndone puts the grade back as it was before one
+	GradeEventCurved           GradeEventType = "curved"
+	GradeEventRegraded         GradeEventType = "regraded"
+	GradeEventDropped          GradeEventType = "dropped"
+	GradeEventAdjustmentUndone GradeEventType = "adjustment_undone"
+	// GradeEventMoved re-homes the grade in another section of the course when sections are merged or
+	// split, ClassID is the class it moved to
+	GradeEventMoved GradeEventType = "moved"
+)
+
+// GradeEvent is one change of a grade. Events are only ever appended, the stream of a grade is its
+// legal history and the grade itself is rebuilt from it.
+type GradeEvent struct {
+	TenantID     string
+	GradeID      string
+	ClassID      string
+	AssignmentID string
+	StudentID    string
+	// Version numbers the events of a grade from 1, two changes from the same version conflict
+	Version int
+	Type    GradeEventType
+	ActorID string
+	Reason  string
+	// OldScore is nil on the first event of a grade
+	OldScore  *float64
+	NewScore  float64
+	OldStatus GradeStatus
+	NewStatus GradeStatus
+	// TurnedInAt, LatePenaltyPercent and Dropped are the values after the event
+	TurnedInAt         *time.Time
+	LatePenaltyPercent float64
+	Dropped            bool
+	// AdjustmentID is the bulk adjustment the event is part of, or the one it undoes
+	AdjustmentID string
+	OccurredAt   time.Time
+}
+
+// ReplayGrade rebuilds a grade from its events in version order, it returns nil without events
+func ReplayGrade(events []*GradeEvent) *Grade {
+	if len(events) == 0 {
+		return nil
+	}
+	grade := &Grade{}
+	for _, event := range events {
+		grade.apply(event)
+	}
+	return grade
+}
+
+// apply changes the grade as the event says, every change of a grade goes through here so the grade
+// is always what its stream replays to
+func (g *Grade) apply(event *GradeEvent) {
+	switch event.Type {
+	case GradeEventRecorded, GradeEventImported:
+		g.ID = event.GradeID
+		g.TenantID = event.TenantID
+		g.ClassID = event.ClassID
+		g.AssignmentID = event.AssignmentID
+		g.StudentID = event.StudentID
+		g.RecordedBy = event.ActorID
+		g.CreatedAt = event.OccurredAt
+		g.Score = event.NewScore
+		g.TurnedInAt = event.TurnedInAt
+		g.LatePenaltyPercent = event.LatePenaltyPercent
+		if event.NewStatus != GradeStatusDraft {
+			g.SubmittedAt = &event.OccurredAt
+		}
+		if event.NewStatus == GradeStatusReleased {
+			g.ReleasedAt = &event.OccurredAt
+		}
+	case GradeEventScoreChanged:
+		g.Score = event.NewScore
+		g.TurnedInAt = event.TurnedInAt
+		g.RecordedBy = event.ActorID
+	case GradeEventSubmitted:
+		g.SubmittedAt = &event.OccurredAt
+		g.ReturnReason = ""
+	case GradeEventApproved:
+		g.ReviewedBy = event.ActorID
+	case GradeEventReturned:
+		g.ReviewedBy = event.ActorID
+		g.ReturnReason = event.Reason
+		g.SubmittedAt = nil
+	case GradeEventReleased:
+		g.ReleasedAt = &event.OccurredAt
+		g.LatePenaltyPercent = event.LatePenaltyPercent
+	case GradeEventCurved, GradeEventRegraded, GradeEventDropped, GradeEventAdjustmentUndone:
+		g.Score = event.NewScore
+		g.RecordedBy = event.ActorID
+	case GradeEventMoved:
+		g.ClassID = event.ClassID
+	}
+	g.Dropped = event.Dropped
+	g.Status = event.NewStatus
+	g.Version = event.Version
+	g.UpdatedAt = event.OccurredAt
+}
+
+// record applies a new event of the grade and keeps it until the repository appends it
+func (g *Grade) record(eventType GradeEventType, actorID string, reason string, score float64, status GradeStatus,
+	turnedInAt *time.Time, latePenaltyPercent float64, now time.Time) {
+	g.commit(g.newEvent(eventType, actorID, reason, score, status, turnedInAt, latePenaltyPercent, now))
+}
+
+// newEvent is the next event of the grade, the grade keeps whether it is dropped
+func (g *Grade) newEvent(eventType GradeEventType, actorID string, reason string, score float64, status GradeStatus,
+	turnedInAt *time.Time, latePenaltyPercent float64, now time.Time) *GradeEvent {
+	event := &GradeEvent{
+		TenantID:           g.TenantID,
+		GradeID:            g.ID,
+		ClassID:            g.ClassID,
+		AssignmentID:       g.AssignmentID,
+		StudentID:          g.StudentID,
+		Version:            g.Version + 1,
+		Type:               eventType,
+		ActorID:            actorID,
+		Reason:             reason,
+		NewScore:           score,
+		OldStatus:          g.Status,
+		NewStatus:          status,
+		TurnedInAt:         turnedInAt,
+		LatePenaltyPercent: latePenaltyPercent,
+		Dropped:            g.Dropped,
+		OccurredAt:         now,
+	}
+	if g.Version > 0 {
+		oldScore := g.Score
+		event.OldScore = &oldScore
+	}
+	return event
+}
+
+func (g *Grade) commit(event *GradeEvent) {
+	g.apply(event)
+	g.pending = append(g.pending, event)
+}
+
+// PendingEvents returns the events recorded since the grade was loaded, in version order
+func (g *Grade) PendingEvents() []*GradeEvent {
+	return g.pending
+}
+
+// ClearPendingEvents is called by the repository once the events are appended
+func (g *Grade) ClearPendingEvents() {
+	g.pending = nil
+}
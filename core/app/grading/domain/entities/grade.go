@@ -0,0 +1,182 @@
+package entities
+
+import (
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GradeStatus string
+
+const (
+	GradeStatusDraft     GradeStatus = "draft"
+	GradeStatusSubmitted GradeStatus = "submitted"
+	GradeStatusApproved  GradeStatus = "approved"
+	GradeStatusReleased  GradeStatus = "released"
+)
+
+// GradeAction moves a grade through draft → submitted → (approved →) released. Return sends a
+// submitted or approved grade back to draft during moderation.
+type GradeAction string
+
+const (
+	GradeActionSubmit  GradeAction = "submit"
+	GradeActionApprove GradeAction = "approve"
+	GradeActionReturn  GradeAction = "return"
+	GradeActionRelease GradeAction = "release"
+)
+
+// Grade is the score of a student on one assignment. Only released grades are visible to the student.
+type Grade struct {
+	ID           string      `validate:"required,uuid4"`
+	TenantID     string      `validate:"required"`
+	ClassID      string      `validate:"required,uuid"`
+	AssignmentID string      `validate:"required,uuid"`
+	StudentID    string      `validate:"required"`
+	Score        float64     `validate:"gte=0,lte=100"`
+	Status       GradeStatus `validate:"required,oneof=draft submitted approved released"`
+	RecordedBy   string      `validate:"required"`
+	ReviewedBy   string
+	ReturnReason string
+	// TurnedInAt is when the student handed in the work, late policies are evaluated against it
+	TurnedInAt *time.Time
+	// LatePenaltyPercent is frozen on release, later deadline or extension changes do not alter it
+	LatePenaltyPercent float64 `validate:"gte=0,lte=100"`
+	// Dropped grades do not count toward the average of the student, see DropLowest
+	Dropped     bool
+	SubmittedAt *time.Time
+	ReleasedAt  *time.Time
+	CreatedAt   time.Time `validate:"required"`
+	UpdatedAt   time.Time `validate:"required"`
+	// Version is the number of events in the stream of the grade
+	Version int `validate:"gte=0"`
+
+	pending []*GradeEvent
+}
+
+func NewGrade(id string, tenantID string, classID string, assignmentID string, studentID string, score float64, status GradeStatus,
+	recordedBy string, reviewedBy string, returnReason string, turnedInAt *time.Time, latePenaltyPercent float64, dropped bool,
+	submittedAt *time.Time, releasedAt *time.Time, createdAt time.Time, updatedAt time.Time, version int) (*Grade, error) {
+	grade := &Grade{
+		ID:           id,
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+		StudentID:    studentID,
+		Score:        score,
+		Status:       status,
+		RecordedBy:   recordedBy,
+		ReviewedBy:   reviewedBy,
+		ReturnReason: returnReason,
+		TurnedInAt:   turnedInAt,
+
+		LatePenaltyPercent: latePenaltyPercent,
+		Dropped:            dropped,
+		SubmittedAt:        submittedAt,
+		ReleasedAt:         releasedAt,
+		CreatedAt:          createdAt,
+		UpdatedAt:          updatedAt,
+		Version:            version,
+	}
+
+	if err := validate.Struct(grade); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("Grade domain model instance not valid", errorMap, err)
+	}
+
+	return grade, nil
+}
+
+// RecordGrade creates the draft grade of a student with the first event of its stream
+func RecordGrade(id string, tenantID string, classID string, assignmentID string, studentID string, score float64,
+	turnedInAt *time.Time, recordedBy string, now time.Time) (*Grade, error) {
+	grade := &Grade{
+		ID:           id,
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+		StudentID:    studentID,
+	}
+	grade.record(GradeEventRecorded, recordedBy, "", score, GradeStatusDraft, turnedInAt, 0, now)
+
+	if err := validate.Struct(grade); err != nil {
+		return nil, appErrors.NewDomainEntityValidationError("Grade domain model instance not valid", map[string]any{}, err)
+	}
+
+	return grade, nil
+}
+
+// SetScore changes the score of a draft grade, anything past draft has to be returned first. reason
+// is kept in the history of the grade.
+func (g *Grade) SetScore(score float64, turnedInAt *time.Time, recordedBy string, reason string, now time.Time) error {
+	if g.Status != GradeStatusDraft {
+		return gradingErrors.NewGradeNotEditableError(g.ID, string(g.Status))
+	}
+
+	g.record(GradeEventScoreChanged, recordedBy, reason, score, g.Status, turnedInAt, g.LatePenaltyPercent, now)
+	return nil
+}
+
+// FinalScore is the score after the late penalty frozen on release
+func (g *Grade) FinalScore() float64 {
+	return AdjustScore(g.Score, g.LatePenaltyPercent)
+}
+
+// CanApply reports whether action is allowed from the current status. Release skips approval only when
+// the class does not require it.
+func (g *Grade) CanApply(action GradeAction, requiresApproval bool) bool {
+	switch action {
+	case GradeActionSubmit:
+		return g.Status == GradeStatusDraft
+	case GradeActionApprove:
+		return g.Status == GradeStatusSubmitted
+	case GradeActionReturn:
+		return g.Status == GradeStatusSubmitted || g.Status == GradeStatusApproved
+	case GradeActionRelease:
+		return g.Status == GradeStatusApproved || (g.Status == GradeStatusSubmitted && !requiresApproval)
+	default:
+		return false
+	}
+}
+
+// Apply runs action on the grade. actor is recorded as reviewer on approve and return, reason is only
+// kept on return and the lateness penalty is only frozen on release.
+func (g *Grade) Apply(action GradeAction, actor string, reason string, requiresApproval bool, lateness Lateness, now time.Time) error {
+	if !g.CanApply(action, requiresApproval) {
+		return gradingErrors.NewInvalidGradeTransitionError(string(action), []map[string]any{
+			{"grade_id": g.ID, "status": string(g.Status)},
+		})
+	}
+
+	switch action {
+	case GradeActionSubmit:
+		g.record(GradeEventSubmitted, actor, "", g.Score, GradeStatusSubmitted, g.TurnedInAt, g.LatePenaltyPercent, now)
+	case GradeActionApprove:
+		g.record(GradeEventApproved, actor, "", g.Score, GradeStatusApproved, g.TurnedInAt, g.LatePenaltyPercent, now)
+	case GradeActionReturn:
+		g.record(GradeEventReturned, actor, reason, g.Score, GradeStatusDraft, g.TurnedInAt, g.LatePenaltyPercent, now)
+	case GradeActionRelease:
+		g.record(GradeEventReleased, actor, "", g.Score, GradeStatusReleased, g.TurnedInAt, lateness.PenaltyPercent, now)
+	}
+
+	return nil
+}
+
+func (g *Grade) VisibleToStudent() bool {
+	return g.Status == GradeStatusReleased
+}
+
+// GradingPolicy holds the moderation settings of a class, classes without one release without approval
+type GradingPolicy struct {
+	TenantID         string
+	ClassID          string
+	RequiresApproval bool
+}
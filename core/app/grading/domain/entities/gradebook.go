@@ -0,0 +1,57 @@
+package entities
+
+import (
+	calendarEntities "github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"time"
+)
+
+// Gradebook evaluates late policies for many grades of a class at once, skipping the non-instructional
+// days of the school calendar
+type Gradebook struct {
+	deadlines  map[string]*AssignmentDeadline
+	extensions map[string]*Extension
+	calendar   *calendarEntities.SchoolCalendar
+}
+
+func NewGradebook(deadlines []*AssignmentDeadline, extensions []*Extension, calendar *calendarEntities.SchoolCalendar) *Gradebook {
+	gradebook := &Gradebook{
+		deadlines:  make(map[string]*AssignmentDeadline, len(deadlines)),
+		extensions: make(map[string]*Extension, len(extensions)),
+		calendar:   calendar,
+	}
+	for _, deadline := range deadlines {
+		gradebook.deadlines[deadline.AssignmentID] = deadline
+	}
+	for _, extension := range extensions {
+		gradebook.extensions[extension.AssignmentID+"/"+extension.StudentID] = extension
+	}
+	return gradebook
+}
+
+func (g *Gradebook) Entry(grade *Grade) GradebookEntry {
+	return NewGradebookEntry(grade, g.deadlines[grade.AssignmentID], g.extensions[grade.AssignmentID+"/"+grade.StudentID], g.calendar)
+}
+
+func (g *Gradebook) Entries(grades []*Grade) []GradebookEntry {
+	entries := make([]GradebookEntry, 0, len(grades))
+	for _, grade := range grades {
+		entries = append(entries, g.Entry(grade))
+	}
+	return entries
+}
+
+// CalendarRange is the part of the school calendar late policies of the deadlines read, from the earliest
+// due date to a year past now so due dates can move past a long break. It is false without deadlines.
+func CalendarRange(deadlines []*AssignmentDeadline, now time.Time) (time.Time, time.Time, bool) {
+	if len(deadlines) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	from := deadlines[0].DueAt
+	for _, deadline := range deadlines[1:] {
+		if deadline.DueAt.Before(from) {
+			from = deadline.DueAt
+		}
+	}
+	return from, now.AddDate(1, 0, 0), true
+}
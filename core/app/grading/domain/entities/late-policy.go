@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	calendarEntities "github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// LatePolicy deducts PenaltyPercentPerDay of the score for every started instructional day past the due
+// date. Work turned in more than CutoffDays late scores zero, a CutoffDays of 0 means there is no cutoff.
+type LatePolicy struct {
+	PenaltyPercentPerDay float64 `validate:"gte=0,lte=100"`
+	CutoffDays           int     `validate:"gte=0,lte=365"`
+}
+
+type AssignmentDeadline struct {
+	TenantID     string    `validate:"required"`
+	ClassID      string    `validate:"required,uuid"`
+	AssignmentID string    `validate:"required,uuid"`
+	DueAt        time.Time `validate:"required"`
+	Policy       LatePolicy
+}
+
+func NewAssignmentDeadline(tenantID string, classID string, assignmentID string, dueAt time.Time, policy LatePolicy) (*AssignmentDeadline, error) {
+	deadline := &AssignmentDeadline{
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+		DueAt:        dueAt,
+		Policy:       policy,
+	}
+
+	if err := validate.Struct(deadline); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("AssignmentDeadline domain model instance not valid", errorMap, err)
+	}
+
+	return deadline, nil
+}
+
+// Extension moves the due date of one assignment for one student, the cutoff moves with it
+type Extension struct {
+	TenantID     string
+	ClassID      string
+	AssignmentID string
+	StudentID    string
+	DueAt        time.Time
+	GrantedBy    string
+	Reason       string
+	GrantedAt    time.Time
+}
+
+// Lateness is the outcome of a late policy for one turned in piece of work
+type Lateness struct {
+	EffectiveDueAt time.Time
+	DaysLate       int
+	PenaltyPercent float64
+	PastCutoff     bool
+}
+
+// Evaluate applies the policy to work turned in at turnedInAt. Work that was not turned in yet, or
+// was turned in right at the due date, is on time. Only extensions past the due date are honored.
+// A due date on a non-instructional day of the calendar moves to the next instructional day, and days
+// late that start on a non-instructional day are not counted. A nil calendar counts every day.
+func (d *AssignmentDeadline) Evaluate(extension *Extension, turnedInAt *time.Time, calendar *calendarEntities.SchoolCalendar) Lateness {
+	lateness := Lateness{EffectiveDueAt: d.DueAt}
+	if extension != nil && extension.DueAt.After(d.DueAt) {
+		lateness.EffectiveDueAt = extension.DueAt
+	}
+	lateness.EffectiveDueAt = calendar.NextInstructional(lateness.EffectiveDueAt)
+
+	if turnedInAt == nil || !turnedInAt.After(lateness.EffectiveDueAt) {
+		return lateness
+	}
+
+	late := turnedInAt.Sub(lateness.EffectiveDueAt)
+	started := int(late / (24 * time.Hour))
+	if late%(24*time.Hour) > 0 {
+		started++
+	}
+	for day := 0; day < started; day++ {
+		if calendar.IsInstructional(lateness.EffectiveDueAt.Add(time.Duration(day) * 24 * time.Hour)) {
+			lateness.DaysLate++
+		}
+	}
+
+	if d.Policy.CutoffDays > 0 && lateness.DaysLate > d.Policy.CutoffDays {
+		lateness.PastCutoff = true
+		lateness.PenaltyPercent = 100
+		return lateness
+	}
+
+	lateness.PenaltyPercent = math.Min(100, float64(lateness.DaysLate)*d.Policy.PenaltyPercentPerDay)
+	return lateness
+}
+
+// AdjustScore deducts penaltyPercent of the score, rounded to two decimals so every reader agrees
+func AdjustScore(score float64, penaltyPercent float64) float64 {
+	return math.Round(score*(100-penaltyPercent)) / 100
+}
+
+// GradebookEntry is a grade with its late policy applied. Released grades keep the penalty frozen
+// when they were released, other grades preview the penalty under the current deadline and extension.
+type GradebookEntry struct {
+	Grade    *Grade
+	Lateness Lateness
+}
+
+func NewGradebookEntry(grade *Grade, deadline *AssignmentDeadline, extension *Extension, calendar *calendarEntities.SchoolCalendar) GradebookEntry {
+	var lateness Lateness
+	if deadline != nil {
+		lateness = deadline.Evaluate(extension, grade.TurnedInAt, calendar)
+	}
+	if grade.Status == GradeStatusReleased {
+		lateness.PenaltyPercent = grade.LatePenaltyPercent
+	}
+
+	return GradebookEntry{Grade: grade, Lateness: lateness}
+}
+
+func (e GradebookEntry) FinalScore() float64 {
+	return AdjustScore(e.Grade.Score, e.Lateness.PenaltyPercent)
+}
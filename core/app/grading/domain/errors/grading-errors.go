@@ -0,0 +1,158 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	GradeNotFoundError          errors2.ErrorCode = "GRADE_NOT_FOUND"
+	GradeNotEditableError       errors2.ErrorCode = "GRADE_NOT_EDITABLE"
+	InvalidGradeTransitionError errors2.ErrorCode = "INVALID_GRADE_TRANSITION"
+	DeadlineNotFoundError       errors2.ErrorCode = "ASSIGNMENT_DEADLINE_NOT_FOUND"
+	InvalidExtensionError       errors2.ErrorCode = "INVALID_EXTENSION"
+	GradeChangedConcurrently    errors2.ErrorCode = "GRADE_CHANGED_CONCURRENTLY"
+	// GradeAdjustmentNotFoundError is also returned for adjustments of another class
+	GradeAdjustmentNotFoundError    errors2.ErrorCode = "GRADE_ADJUSTMENT_NOT_FOUND"
+	GradeAdjustmentUndoneError      errors2.ErrorCode = "GRADE_ADJUSTMENT_UNDONE"
+	GradeAdjustmentNotUndoableError errors2.ErrorCode = "GRADE_ADJUSTMENT_NOT_UNDOABLE"
+)
+
+func NewGradeNotFoundError(gradeIDs []string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    GradeNotFoundError.String(),
+			Message: "Some of the requested grades could not be found in the class",
+			Context: map[string]any{
+				"grade_ids": gradeIDs,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(GradeNotFoundError.String()),
+		},
+	}
+}
+
+func NewGradeNotEditableError(gradeID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    GradeNotEditableError.String(),
+			Message: "Only draft grades can be changed, return the grade to draft first",
+			Context: map[string]any{
+				"grade_id": gradeID,
+				"status":   status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(GradeNotEditableError.String()),
+		},
+	}
+}
+
+// NewInvalidGradeTransitionError lists every grade whose status does not allow the action
+func NewInvalidGradeTransitionError(action string, grades []map[string]any) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    InvalidGradeTransitionError.String(),
+			Message: "The action is not allowed for the current status of some grades",
+			Context: map[string]any{
+				"action": action,
+				"grades": grades,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidGradeTransitionError.String()),
+		},
+	}
+}
+
+func NewDeadlineNotFoundError(assignmentID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    DeadlineNotFoundError.String(),
+			Message: "The assignment has no due date configured",
+			Context: map[string]any{
+				"assignment_id": assignmentID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(DeadlineNotFoundError.String()),
+		},
+	}
+}
+
+func NewInvalidExtensionError(assignmentID string, dueAt time.Time, requestedDueAt time.Time) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    InvalidExtensionError.String(),
+			Message: "An extension must move the due date later",
+			Context: map[string]any{
+				"assignment_id":    assignmentID,
+				"due_at":           dueAt,
+				"requested_due_at": requestedDueAt,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidExtensionError.String()),
+		},
+	}
+}
+
+// NewGradeChangedConcurrentlyError is returned when another change of the grade was stored since it was
+// read, retrying reads the new version
+func NewGradeChangedConcurrentlyError(gradeID string, version int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    GradeChangedConcurrently.String(),
+			Message: "The grade was changed by someone else in the meantime, try again",
+			Context: map[string]any{
+				"grade_id": gradeID,
+				"version":  version,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(GradeChangedConcurrently.String()),
+		},
+	}
+}
+
+func NewGradeAdjustmentNotFoundError(adjustmentID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    GradeAdjustmentNotFoundError.String(),
+			Message: "The grade adjustment could not be found in the class",
+			Context: map[string]any{
+				"adjustment_id": adjustmentID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(GradeAdjustmentNotFoundError.String()),
+		},
+	}
+}
+
+func NewGradeAdjustmentUndoneError(adjustmentID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    GradeAdjustmentUndoneError.String(),
+			Message: "The grade adjustment was already undone",
+			Context: map[string]any{
+				"adjustment_id": adjustmentID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(GradeAdjustmentUndoneError.String()),
+		},
+	}
+}
+
+// NewGradeAdjustmentNotUndoableError lists every grade changed or moved past draft since the
+// adjustment, they have to be returned to draft or changed back by hand
+func NewGradeAdjustmentNotUndoableError(adjustmentID string, grades []map[string]any) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    GradeAdjustmentNotUndoableError.String(),
+			Message: "Some grades changed since the adjustment, it cannot be undone",
+			Context: map[string]any{
+				"adjustment_id": adjustmentID,
+				"grades":        grades,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(GradeAdjustmentNotUndoableError.String()),
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+)
+
+// GradeRepository stores grades as their event streams with the current value of every grade next to
+// them. Saving appends the pending events of a grade and updates its current value in one transaction,
+// and fails with GRADE_CHANGED_CONCURRENTLY when another change of the grade was appended first.
+type GradeRepository interface {
+	Save(ctx context.Context, grade *entities.Grade) error
+	FindByStudent(ctx context.Context, tenantID string, classID string, assignmentID string, studentID string) (*entities.Grade, error)
+	// WithGradesLocked locks the requested grades of the class and calls fn with the ones found, missing
+	// IDs are left out. Changes made by fn are persisted atomically when it returns nil; grades that
+	// became released are appended to the outbox so read models only ever see released scores, dropped
+	// grades are left out of them.
+	WithGradesLocked(ctx context.Context, tenantID string, classID string, gradeIDs []string, fn func(grades []*entities.Grade) error) error
+	ListByClass(ctx context.Context, tenantID string, classID string, assignmentID string) ([]*entities.Grade, error)
+	ListReleasedForStudent(ctx context.Context, tenantID string, studentID string, classID string) ([]*entities.Grade, error)
+	// ListEvents returns the stream of a grade of the class in version order, empty for unknown grades.
+	// Grades moved to or from the class are returned with the events recorded in the other section.
+	ListEvents(ctx context.Context, tenantID string, classID string, gradeID string) ([]*entities.GradeEvent, error)
+	// ListAdjustmentEvents returns the events of a bulk adjustment of the class and of its undo, empty
+	// for unknown adjustments
+	ListAdjustmentEvents(ctx context.Context, tenantID string, classID string, adjustmentID string) ([]*entities.GradeEvent, error)
+}
+
+type GradingPolicyRepository interface {
+	// Get returns nil when the class has no policy
+	Get(ctx context.Context, tenantID string, classID string) (*entities.GradingPolicy, error)
+	Set(ctx context.Context, policy *entities.GradingPolicy) error
+}
+
+type DeadlineRepository interface {
+	SetDeadline(ctx context.Context, deadline *entities.AssignmentDeadline) error
+	// GetDeadline returns nil when the assignment has no due date
+	GetDeadline(ctx context.Context, tenantID string, classID string, assignmentID string) (*entities.AssignmentDeadline, error)
+	ListDeadlines(ctx context.Context, tenantID string, classID string) ([]*entities.AssignmentDeadline, error)
+	SetExtension(ctx context.Context, extension *entities.Extension) error
+	ListExtensions(ctx context.Context, tenantID string, classID string) ([]*entities.Extension, error)
+}
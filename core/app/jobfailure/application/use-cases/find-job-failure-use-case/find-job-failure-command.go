@@ -0,0 +1,30 @@
+package find_job_failure_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type FindJobFailureCommand struct {
+	TenantID string `validate:"required"`
+	Job      string `validate:"required"`
+	Subject  string `validate:"required"`
+}
+
+func NewFindJobFailureCommand(tenantID string, job string, subject string) (*FindJobFailureCommand, error) {
+	command := &FindJobFailureCommand{
+		TenantID: tenantID,
+		Job:      job,
+		Subject:  subject,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
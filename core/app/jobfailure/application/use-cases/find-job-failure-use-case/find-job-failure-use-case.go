@@ -0,0 +1,29 @@
+package find_job_failure_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type FindJobFailureUseCase struct {
+	failures ports.JobFailureRepository
+}
+
+func NewFindJobFailureUseCase(failures ports.JobFailureRepository) *FindJobFailureUseCase {
+	return &FindJobFailureUseCase{
+		failures: failures,
+	}
+}
+
+// Execute returns the failure of the work, nil when its last run succeeded. Jobs skip the work of
+// escalated failures.
+func (uc *FindJobFailureUseCase) Execute(ctx context.Context, cmd *FindJobFailureCommand) (*entities.JobFailure, error) {
+	failure, err := uc.failures.Find(ctx, cmd.TenantID, cmd.Job, cmd.Subject)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return failure, nil
+}
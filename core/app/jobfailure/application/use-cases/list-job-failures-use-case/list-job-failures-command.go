@@ -0,0 +1,30 @@
+package list_job_failures_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListJobFailuresCommand struct {
+	TenantID string                    `validate:"required"`
+	Status   entities.JobFailureStatus `validate:"omitempty,oneof=failing escalated"`
+}
+
+// NewListJobFailuresCommand lists every failure of the tenant when status is empty
+func NewListJobFailuresCommand(tenantID string, status string) (*ListJobFailuresCommand, error) {
+	command := &ListJobFailuresCommand{
+		TenantID: tenantID,
+		Status:   entities.JobFailureStatus(status),
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,27 @@
+package list_job_failures_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListJobFailuresUseCase struct {
+	failures ports.JobFailureRepository
+}
+
+func NewListJobFailuresUseCase(failures ports.JobFailureRepository) *ListJobFailuresUseCase {
+	return &ListJobFailuresUseCase{
+		failures: failures,
+	}
+}
+
+func (uc *ListJobFailuresUseCase) Execute(ctx context.Context, cmd *ListJobFailuresCommand) ([]*entities.JobFailure, error) {
+	failures, err := uc.failures.List(ctx, cmd.TenantID, cmd.Status)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return failures, nil
+}
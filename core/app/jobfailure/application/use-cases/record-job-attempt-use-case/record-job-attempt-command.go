@@ -0,0 +1,38 @@
+package record_job_attempt_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RecordJobAttemptCommand struct {
+	TenantID string `validate:"required"`
+	Job      string `validate:"required,max=100"`
+	Subject  string `validate:"required,max=255"`
+	Payload  map[string]string
+	// Error is why the run failed, empty when it succeeded
+	Error string
+}
+
+// NewRecordJobAttemptCommand records the outcome of a run of the work, runErr is nil when it succeeded
+func NewRecordJobAttemptCommand(tenantID string, job string, subject string, payload map[string]string, runErr error) (*RecordJobAttemptCommand, error) {
+	command := &RecordJobAttemptCommand{
+		TenantID: tenantID,
+		Job:      job,
+		Subject:  subject,
+		Payload:  payload,
+	}
+	if runErr != nil {
+		command.Error = runErr.Error()
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,60 @@
+package record_job_attempt_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type RecordJobAttemptUseCase struct {
+	failures ports.JobFailureRepository
+	notifier ports.IncidentNotifier
+}
+
+func NewRecordJobAttemptUseCase(failures ports.JobFailureRepository, notifier ports.IncidentNotifier) *RecordJobAttemptUseCase {
+	return &RecordJobAttemptUseCase{
+		failures: failures,
+		notifier: notifier,
+	}
+}
+
+// Execute counts a failed run of the work and returns its failure, a successful run drops the
+// failure and returns nil. The run that reaches entities.MaxAttempts escalates the failure once the
+// admins were notified, when notifying fails the next failed run tries again.
+func (uc *RecordJobAttemptUseCase) Execute(ctx context.Context, cmd *RecordJobAttemptCommand) (*entities.JobFailure, error) {
+	if cmd.Error == "" {
+		if err := uc.failures.Delete(ctx, cmd.TenantID, cmd.Job, cmd.Subject); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		return nil, nil
+	}
+
+	now := time.Now().UTC()
+	failure, err := uc.failures.Find(ctx, cmd.TenantID, cmd.Job, cmd.Subject)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if failure == nil {
+		failure = entities.NewJobFailure(cmd.TenantID, cmd.Job, cmd.Subject, cmd.Payload, now)
+	}
+
+	var notifyErr error
+	if failure.Fail(cmd.Error, cmd.Payload, now) {
+		failure.Escalate(now)
+		if notifyErr = uc.notifier.NotifyJobFailure(ctx, failure); notifyErr != nil {
+			failure.Status = entities.JobFailureStatusFailing
+			failure.EscalatedAt = nil
+		}
+	}
+
+	if err := uc.failures.Save(ctx, failure); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if notifyErr != nil {
+		return failure, errors.PropagateError(notifyErr)
+	}
+	return failure, nil
+}
@@ -0,0 +1,30 @@
+package requeue_job_failure_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RequeueJobFailureCommand struct {
+	TenantID   string `validate:"required"`
+	FailureID  string `validate:"required,uuid"`
+	RequeuedBy string `validate:"required"`
+}
+
+func NewRequeueJobFailureCommand(tenantID string, failureID string, requeuedBy string) (*RequeueJobFailureCommand, error) {
+	command := &RequeueJobFailureCommand{
+		TenantID:   tenantID,
+		FailureID:  failureID,
+		RequeuedBy: requeuedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
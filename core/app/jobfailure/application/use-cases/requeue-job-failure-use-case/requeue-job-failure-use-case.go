@@ -0,0 +1,42 @@
+package requeue_job_failure_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	jobFailureErrors "github.com/nahualventure/class-backend/core/app/jobfailure/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type RequeueJobFailureUseCase struct {
+	failures ports.JobFailureRepository
+}
+
+func NewRequeueJobFailureUseCase(failures ports.JobFailureRepository) *RequeueJobFailureUseCase {
+	return &RequeueJobFailureUseCase{
+		failures: failures,
+	}
+}
+
+// Execute lets the job run the escalated work again on its next run, with its payload. The failure
+// is dropped once a run succeeds and escalated again after entities.MaxAttempts failed runs.
+func (uc *RequeueJobFailureUseCase) Execute(ctx context.Context, cmd *RequeueJobFailureCommand) (*entities.JobFailure, error) {
+	failure, err := uc.failures.FindByID(ctx, cmd.TenantID, cmd.FailureID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if failure == nil {
+		return nil, jobFailureErrors.NewJobFailureNotFoundError(cmd.FailureID)
+	}
+	if !failure.IsEscalated() {
+		return nil, jobFailureErrors.NewJobFailureNotEscalatedError(failure.ID, failure.Attempts)
+	}
+
+	failure.Requeue(cmd.RequeuedBy, time.Now().UTC())
+	if err := uc.failures.Save(ctx, failure); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return failure, nil
+}
@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxAttempts is how many runs in a row a unit of work may fail before it is escalated
+const MaxAttempts = 5
+
+// maxSummaryLength bounds the payload summary sent in notifications
+const maxSummaryLength = 500
+
+type JobFailureStatus string
+
+const (
+	// JobFailureStatusFailing work is tried again on the next runs of its job
+	JobFailureStatusFailing JobFailureStatus = "failing"
+	// JobFailureStatusEscalated work failed MaxAttempts runs in a row, the admins were notified and the
+	// job skips it until it is requeued
+	JobFailureStatusEscalated JobFailureStatus = "escalated"
+)
+
+// JobFailure tracks a unit of work of a background job, such as the export of a dataset of a tenant,
+// from its first failed run until a run succeeds. Successful runs drop it.
+type JobFailure struct {
+	ID       string
+	TenantID string
+	// Job is the name the job is scheduled with, such as warehouse-exports
+	Job string
+	// Subject names the unit of work within the job and tenant, such as the dataset exported
+	Subject string
+	// Payload is what the job was given for the work, the requeued run gets the same
+	Payload       map[string]string
+	Status        JobFailureStatus
+	Attempts      int
+	LastError     string
+	FirstFailedAt time.Time
+	LastFailedAt  time.Time
+	EscalatedAt   *time.Time
+	RequeuedAt    *time.Time
+	RequeuedBy    string
+}
+
+func NewJobFailure(tenantID string, job string, subject string, payload map[string]string, now time.Time) *JobFailure {
+	return &JobFailure{
+		ID:            uuid.NewString(),
+		TenantID:      tenantID,
+		Job:           job,
+		Subject:       subject,
+		Payload:       payload,
+		Status:        JobFailureStatusFailing,
+		FirstFailedAt: now,
+		LastFailedAt:  now,
+	}
+}
+
+// Fail counts a failed run and reports whether the work reached MaxAttempts and should be escalated
+func (f *JobFailure) Fail(reason string, payload map[string]string, now time.Time) bool {
+	f.Attempts++
+	f.LastError = reason
+	f.LastFailedAt = now
+	if payload != nil {
+		f.Payload = payload
+	}
+	return f.Status == JobFailureStatusFailing && f.Attempts >= MaxAttempts
+}
+
+// Escalate parks the work until it is requeued
+func (f *JobFailure) Escalate(now time.Time) {
+	f.Status = JobFailureStatusEscalated
+	f.EscalatedAt = &now
+}
+
+// IsEscalated reports whether the job skips the work
+func (f *JobFailure) IsEscalated() bool {
+	return f.Status == JobFailureStatusEscalated
+}
+
+// Requeue lets the job run the work again, it gets MaxAttempts more runs before it is escalated again
+func (f *JobFailure) Requeue(requeuedBy string, now time.Time) {
+	f.Status = JobFailureStatusFailing
+	f.Attempts = 0
+	f.RequeuedAt = &now
+	f.RequeuedBy = requeuedBy
+}
+
+// PayloadSummary lists the payload as sorted key=value pairs, cut to a length notifications can
+// carry
+func (f *JobFailure) PayloadSummary() string {
+	keys := make([]string, 0, len(f.Payload))
+	for key := range f.Payload {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, f.Payload[key]))
+	}
+	summary := strings.Join(pairs, " ")
+	if len(summary) > maxSummaryLength {
+		summary = strings.ToValidUTF8(summary[:maxSummaryLength], "") + "…"
+	}
+	return summary
+}
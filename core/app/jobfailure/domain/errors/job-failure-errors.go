@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	JobFailureNotFoundError errors2.ErrorCode = "JOB_FAILURE_NOT_FOUND"
+	// JobFailureNotEscalatedError refuses to requeue work the job still tries on its own
+	JobFailureNotEscalatedError errors2.ErrorCode = "JOB_FAILURE_NOT_ESCALATED"
+)
+
+func NewJobFailureNotFoundError(failureID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    JobFailureNotFoundError.String(),
+			Message: "Job failure not found",
+			Context: map[string]any{
+				"failure_id": failureID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(JobFailureNotFoundError.String()),
+		},
+	}
+}
+
+func NewJobFailureNotEscalatedError(failureID string, attempts int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    JobFailureNotEscalatedError.String(),
+			Message: "The job still retries this work, only escalated failures are requeued",
+			Context: map[string]any{
+				"failure_id": failureID,
+				"attempts":   attempts,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(JobFailureNotEscalatedError.String()),
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+)
+
+type JobFailureRepository interface {
+	// Find returns the failure of the work, nil when its last run succeeded
+	Find(ctx context.Context, tenantID string, job string, subject string) (*entities.JobFailure, error)
+	// FindByID returns the failure of the tenant, nil when the tenant has no such failure
+	FindByID(ctx context.Context, tenantID string, failureID string) (*entities.JobFailure, error)
+	// List returns the failures of the tenant with the status, every one when status is empty, the
+	// latest failed first
+	List(ctx context.Context, tenantID string, status entities.JobFailureStatus) ([]*entities.JobFailure, error)
+	// Save creates or replaces the failure
+	Save(ctx context.Context, failure *entities.JobFailure) error
+	// Delete drops the failure of the work once a run succeeded, a no-op when there is none
+	Delete(ctx context.Context, tenantID string, job string, subject string) error
+}
+
+// IncidentNotifier tells the admins about work a job gave up on
+type IncidentNotifier interface {
+	NotifyJobFailure(ctx context.Context, failure *entities.JobFailure) error
+}
@@ -0,0 +1,39 @@
+package change_resource_status_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ResourceAction string
+
+const (
+	ResourceActionPublish ResourceAction = "publish"
+	ResourceActionArchive ResourceAction = "archive"
+)
+
+type ChangeResourceStatusCommand struct {
+	TenantID   string         `validate:"required"`
+	ResourceID string         `validate:"required,uuid"`
+	UserID     string         `validate:"required"`
+	Action     ResourceAction `validate:"required,oneof=publish archive"`
+}
+
+func NewChangeResourceStatusCommand(tenantID string, resourceID string, userID string, action string) (*ChangeResourceStatusCommand, error) {
+	command := &ChangeResourceStatusCommand{
+		TenantID:   tenantID,
+		ResourceID: resourceID,
+		UserID:     userID,
+		Action:     ResourceAction(action),
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,65 @@
+package change_resource_status_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	libraryErrors "github.com/nahualventure/class-backend/core/app/library/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/library/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ChangeResourceStatusUseCase struct {
+	resourceRepo ports.ResourceRepository
+	viewers      ports.ViewerDirectory
+}
+
+func NewChangeResourceStatusUseCase(resourceRepo ports.ResourceRepository, viewers ports.ViewerDirectory) *ChangeResourceStatusUseCase {
+	return &ChangeResourceStatusUseCase{
+		resourceRepo: resourceRepo,
+		viewers:      viewers,
+	}
+}
+
+// Execute publishes or archives the resource, both take the publish permission. Archived resources
+// can be published again.
+func (uc *ChangeResourceStatusUseCase) Execute(ctx context.Context, cmd *ChangeResourceStatusCommand) (*entities.Resource, error) {
+	viewer, err := uc.viewers.Viewer(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	resource, err := uc.resourceRepo.FindByID(ctx, cmd.TenantID, cmd.ResourceID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if resource == nil || !viewer.CanSee(resource) {
+		return nil, libraryErrors.NewResourceNotFoundError(cmd.ResourceID)
+	}
+	if !viewer.CanPublish {
+		return nil, libraryErrors.NewResourceNotEditableError(resource.ID, string(resource.Status))
+	}
+
+	now := time.Now().UTC()
+	switch cmd.Action {
+	case ResourceActionPublish:
+		if resource.IsPublished() {
+			return resource, nil
+		}
+		if !resource.IsPublishable() {
+			return nil, libraryErrors.NewResourceNotPublishableError(resource.ID, string(resource.Kind))
+		}
+		resource.Publish(cmd.UserID, now)
+	case ResourceActionArchive:
+		if resource.IsArchived() {
+			return resource, nil
+		}
+		resource.Archive(now)
+	}
+
+	if err := uc.resourceRepo.Update(ctx, resource); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return resource, nil
+}
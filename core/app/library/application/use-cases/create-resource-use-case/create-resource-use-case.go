@@ -0,0 +1,39 @@
+package create_resource_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/library/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type CreateResourceUseCase struct {
+	resourceRepo ports.ResourceRepository
+}
+
+func NewCreateResourceUseCase(resourceRepo ports.ResourceRepository) *CreateResourceUseCase {
+	return &CreateResourceUseCase{
+		resourceRepo: resourceRepo,
+	}
+}
+
+// Execute creates the resource as a draft, only its owner and publishers see it until it is published
+func (uc *CreateResourceUseCase) Execute(ctx context.Context, cmd *CreateResourceCommand) (*entities.Resource, error) {
+	now := time.Now().UTC()
+
+	resource, err := entities.NewResource(uuid.New().String(), cmd.TenantID, cmd.Kind, cmd.Title, cmd.Description, cmd.Subject,
+		cmd.GradeLevel, cmd.Tags, cmd.URL, entities.ResourceStatusDraft, 0, cmd.OwnerID, "", nil, now, now)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.resourceRepo.Create(ctx, resource); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return resource, nil
+}
@@ -0,0 +1,41 @@
+package download_resource_version_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type DownloadResourceVersionCommand struct {
+	TenantID   string `validate:"required"`
+	ResourceID string `validate:"required,uuid"`
+	UserID     string `validate:"required"`
+	// Version 0 downloads the current version
+	Version int `validate:"gte=0"`
+	// Preview downloads the thumbnail of the version instead of its file
+	Preview bool
+	// Alternative downloads the captions or the transcript of the version instead of its file
+	Alternative storage.AlternativeFormat `validate:"omitempty,oneof=captions transcript,excluded_if=Preview true"`
+}
+
+func NewDownloadResourceVersionCommand(tenantID string, resourceID string, userID string, version int, preview bool,
+	alternative storage.AlternativeFormat) (*DownloadResourceVersionCommand, error) {
+	command := &DownloadResourceVersionCommand{
+		TenantID:    tenantID,
+		ResourceID:  resourceID,
+		UserID:      userID,
+		Version:     version,
+		Preview:     preview,
+		Alternative: alternative,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
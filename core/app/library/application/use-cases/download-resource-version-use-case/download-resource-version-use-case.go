@@ -0,0 +1,86 @@
+package download_resource_version_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	libraryErrors "github.com/nahualventure/class-backend/core/app/library/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/library/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+)
+
+type DownloadResourceVersionUseCase struct {
+	resourceRepo ports.ResourceRepository
+	viewers      ports.ViewerDirectory
+	files        storage.FileStorage
+}
+
+func NewDownloadResourceVersionUseCase(resourceRepo ports.ResourceRepository, viewers ports.ViewerDirectory,
+	files storage.FileStorage) *DownloadResourceVersionUseCase {
+	return &DownloadResourceVersionUseCase{
+		resourceRepo: resourceRepo,
+		viewers:      viewers,
+		files:        files,
+	}
+}
+
+// Execute returns the version with its file content, or with its preview or alternative when the command
+// asks for one.
+// Anyone who can see the resource can download any of its versions that were scanned clean.
+func (uc *DownloadResourceVersionUseCase) Execute(ctx context.Context, cmd *DownloadResourceVersionCommand) (*entities.ResourceVersion, []byte, error) {
+	viewer, err := uc.viewers.Viewer(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	resource, err := uc.resourceRepo.FindByID(ctx, cmd.TenantID, cmd.ResourceID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if resource == nil || !viewer.CanSee(resource) {
+		return nil, nil, libraryErrors.NewResourceNotFoundError(cmd.ResourceID)
+	}
+
+	number := cmd.Version
+	if number == 0 {
+		number = resource.CurrentVersion
+	}
+	if number == 0 {
+		return nil, nil, libraryErrors.NewResourceVersionNotFoundError(resource.ID, number)
+	}
+
+	version, err := uc.resourceRepo.FindVersion(ctx, cmd.TenantID, resource.ID, number)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if version == nil {
+		return nil, nil, libraryErrors.NewResourceVersionNotFoundError(resource.ID, number)
+	}
+	if !version.ScanStatus.IsAvailable() {
+		return nil, nil, errors.NewFileQuarantinedError(version.ID, string(version.ScanStatus))
+	}
+
+	key := version.StorageKey()
+	if cmd.Preview {
+		if version.PreviewStatus != storage.PreviewReady {
+			return nil, nil, errors.NewPreviewNotAvailableError(version.ID, string(version.PreviewStatus))
+		}
+		key = storage.PreviewKey(key)
+	}
+	if cmd.Alternative != "" {
+		if version.CaptionStatus != storage.CaptionReady {
+			return nil, nil, errors.NewAlternativeNotAvailableError(version.ID, string(version.CaptionStatus))
+		}
+		key = cmd.Alternative.Key(key)
+	}
+
+	content, err := uc.files.Get(ctx, key)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if content == nil {
+		return nil, nil, libraryErrors.NewResourceVersionNotFoundError(resource.ID, number)
+	}
+
+	return version, content, nil
+}
@@ -0,0 +1,30 @@
+package get_resource_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetResourceCommand struct {
+	TenantID   string `validate:"required"`
+	ResourceID string `validate:"required,uuid"`
+	UserID     string `validate:"required"`
+}
+
+func NewGetResourceCommand(tenantID string, resourceID string, userID string) (*GetResourceCommand, error) {
+	command := &GetResourceCommand{
+		TenantID:   tenantID,
+		ResourceID: resourceID,
+		UserID:     userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
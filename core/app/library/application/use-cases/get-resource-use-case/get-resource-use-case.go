@@ -0,0 +1,43 @@
+package get_resource_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	libraryErrors "github.com/nahualventure/class-backend/core/app/library/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/library/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetResourceUseCase struct {
+	resourceRepo ports.ResourceRepository
+	viewers      ports.ViewerDirectory
+}
+
+func NewGetResourceUseCase(resourceRepo ports.ResourceRepository, viewers ports.ViewerDirectory) *GetResourceUseCase {
+	return &GetResourceUseCase{
+		resourceRepo: resourceRepo,
+		viewers:      viewers,
+	}
+}
+
+func (uc *GetResourceUseCase) Execute(ctx context.Context, cmd *GetResourceCommand) (*entities.ResourceDetails, error) {
+	viewer, err := uc.viewers.Viewer(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	resource, err := uc.resourceRepo.FindByID(ctx, cmd.TenantID, cmd.ResourceID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if resource == nil || !viewer.CanSee(resource) {
+		return nil, libraryErrors.NewResourceNotFoundError(cmd.ResourceID)
+	}
+
+	versions, err := uc.resourceRepo.ListVersions(ctx, cmd.TenantID, resource.ID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return &entities.ResourceDetails{Resource: resource, Versions: versions}, nil
+}
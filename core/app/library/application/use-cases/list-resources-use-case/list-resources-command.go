@@ -0,0 +1,53 @@
+package list_resources_use_case
+
+import (
+	"strings"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const DefaultLimit = 50
+
+var validate = validator.New()
+
+type ListResourcesCommand struct {
+	TenantID   string                  `validate:"required"`
+	UserID     string                  `validate:"required"`
+	Kind       entities.ResourceKind   `validate:"omitempty,oneof=document link video"`
+	Subject    string                  `validate:"max=100"`
+	GradeLevel string                  `validate:"omitempty,grade_level"`
+	Tag        string                  `validate:"max=40"`
+	Query      string                  `validate:"max=200"`
+	Status     entities.ResourceStatus `validate:"omitempty,oneof=draft published archived"`
+	Limit      int                     `validate:"min=1,max=100"`
+}
+
+// NewListResourcesCommand lists DefaultLimit resources when limit is zero, every filter is optional
+func NewListResourcesCommand(tenantID string, userID string, kind string, subject string, gradeLevel string, tag string, query string,
+	status string, limit int) (*ListResourcesCommand, error) {
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	command := &ListResourcesCommand{
+		TenantID:   tenantID,
+		UserID:     userID,
+		Kind:       entities.ResourceKind(kind),
+		Subject:    subject,
+		GradeLevel: gradeLevel,
+		Tag:        strings.ToLower(strings.TrimSpace(tag)),
+		Query:      strings.TrimSpace(query),
+		Status:     entities.ResourceStatus(status),
+		Limit:      limit,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
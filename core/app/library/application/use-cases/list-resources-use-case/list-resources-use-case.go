@@ -0,0 +1,65 @@
+package list_resources_use_case
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/library/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListResourcesUseCase struct {
+	resourceRepo ports.ResourceRepository
+	viewers      ports.ViewerDirectory
+}
+
+func NewListResourcesUseCase(resourceRepo ports.ResourceRepository, viewers ports.ViewerDirectory) *ListResourcesUseCase {
+	return &ListResourcesUseCase{
+		resourceRepo: resourceRepo,
+		viewers:      viewers,
+	}
+}
+
+// Execute lists the resources the user may see, published ones for everybody plus drafts of
+// contributors and everything for publishers
+func (uc *ListResourcesUseCase) Execute(ctx context.Context, cmd *ListResourcesCommand) ([]*entities.Resource, error) {
+	viewer, err := uc.viewers.Viewer(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	filter := ports.ResourceFilter{
+		Kind:       cmd.Kind,
+		Subject:    cmd.Subject,
+		GradeLevel: cmd.GradeLevel,
+		Tag:        cmd.Tag,
+		Query:      cmd.Query,
+		Statuses:   viewer.VisibleStatuses(),
+		Limit:      cmd.Limit,
+	}
+	if viewer.CanContribute && !viewer.CanPublish {
+		filter.DraftsOf = viewer.UserID
+	}
+
+	if cmd.Status != "" {
+		if !slices.Contains(filter.Statuses, cmd.Status) {
+			filter.Statuses = []entities.ResourceStatus{}
+		} else {
+			filter.Statuses = []entities.ResourceStatus{cmd.Status}
+		}
+		if cmd.Status != entities.ResourceStatusDraft {
+			filter.DraftsOf = ""
+		}
+		if len(filter.Statuses) == 0 && filter.DraftsOf == "" {
+			return []*entities.Resource{}, nil
+		}
+	}
+
+	resources, err := uc.resourceRepo.List(ctx, cmd.TenantID, filter)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,45 @@
+package update_resource_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type UpdateResourceCommand struct {
+	TenantID    string   `validate:"required"`
+	ResourceID  string   `validate:"required,uuid"`
+	UserID      string   `validate:"required"`
+	Title       string   `validate:"required,max=200"`
+	Description string   `validate:"max=2000"`
+	Subject     string   `validate:"max=100"`
+	GradeLevel  string   `validate:"omitempty,grade_level"`
+	Tags        []string `validate:"max=20,dive,required,max=40"`
+	URL         string   `validate:"omitempty,url,max=2000"`
+}
+
+// NewUpdateResourceCommand replaces every metadata field, the kind of a resource cannot change
+func NewUpdateResourceCommand(tenantID string, resourceID string, userID string, title string, description string, subject string,
+	gradeLevel string, tags []string, url string) (*UpdateResourceCommand, error) {
+	command := &UpdateResourceCommand{
+		TenantID:    tenantID,
+		ResourceID:  resourceID,
+		UserID:      userID,
+		Title:       title,
+		Description: description,
+		Subject:     subject,
+		GradeLevel:  gradeLevel,
+		Tags:        entities.NormalizeTags(tags),
+		URL:         url,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,57 @@
+package update_resource_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	libraryErrors "github.com/nahualventure/class-backend/core/app/library/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/library/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type UpdateResourceUseCase struct {
+	resourceRepo ports.ResourceRepository
+	viewers      ports.ViewerDirectory
+}
+
+func NewUpdateResourceUseCase(resourceRepo ports.ResourceRepository, viewers ports.ViewerDirectory) *UpdateResourceUseCase {
+	return &UpdateResourceUseCase{
+		resourceRepo: resourceRepo,
+		viewers:      viewers,
+	}
+}
+
+// Execute changes the metadata and tags of a resource, owners edit their own and publishers any
+func (uc *UpdateResourceUseCase) Execute(ctx context.Context, cmd *UpdateResourceCommand) (*entities.Resource, error) {
+	viewer, err := uc.viewers.Viewer(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	resource, err := uc.resourceRepo.FindByID(ctx, cmd.TenantID, cmd.ResourceID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if resource == nil || !viewer.CanSee(resource) {
+		return nil, libraryErrors.NewResourceNotFoundError(cmd.ResourceID)
+	}
+	if !viewer.CanEdit(resource) {
+		return nil, libraryErrors.NewResourceNotEditableError(resource.ID, string(resource.Status))
+	}
+
+	updated, err := entities.NewResource(resource.ID, resource.TenantID, resource.Kind, cmd.Title, cmd.Description, cmd.Subject,
+		cmd.GradeLevel, cmd.Tags, cmd.URL, resource.Status, resource.CurrentVersion, resource.OwnerID, resource.PublishedBy,
+		resource.PublishedAt, resource.CreatedAt, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if updated.IsPublished() && !updated.IsPublishable() {
+		return nil, libraryErrors.NewResourceNotPublishableError(updated.ID, string(updated.Kind))
+	}
+
+	if err := uc.resourceRepo.Update(ctx, updated); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,47 @@
+package upload_resource_version_use_case
+
+import (
+	"strings"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type UploadResourceVersionCommand struct {
+	TenantID    string `validate:"required"`
+	ResourceID  string `validate:"required,uuid"`
+	UserID      string `validate:"required"`
+	FileName    string `validate:"required,max=255"`
+	ContentType string `validate:"required,max=100"`
+	Content     []byte `validate:"required,min=1,max=26214400"`
+	// AltText is required for images
+	AltText string `validate:"max=1000"`
+}
+
+func NewUploadResourceVersionCommand(tenantID string, resourceID string, userID string, fileName string, contentType string,
+	content []byte, altText string) (*UploadResourceVersionCommand, error) {
+	command := &UploadResourceVersionCommand{
+		TenantID:    tenantID,
+		ResourceID:  resourceID,
+		UserID:      userID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Content:     content,
+		AltText:     strings.TrimSpace(altText),
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if storage.NeedsAltText(command.ContentType) && command.AltText == "" {
+		return nil, errors.NewValidationError("Invalid resource version",
+			map[string]any{"alt_text": "images need a text alternative for screen readers"}, nil)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,75 @@
+package upload_resource_version_use_case
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	libraryErrors "github.com/nahualventure/class-backend/core/app/library/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/library/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+
+	"github.com/google/uuid"
+)
+
+type UploadResourceVersionUseCase struct {
+	resourceRepo ports.ResourceRepository
+	viewers      ports.ViewerDirectory
+	files        storage.FileStorage
+}
+
+func NewUploadResourceVersionUseCase(resourceRepo ports.ResourceRepository, viewers ports.ViewerDirectory,
+	files storage.FileStorage) *UploadResourceVersionUseCase {
+	return &UploadResourceVersionUseCase{
+		resourceRepo: resourceRepo,
+		viewers:      viewers,
+		files:        files,
+	}
+}
+
+// Execute stores the file as a new version of the resource, quarantined until it is scanned for
+// viruses. Videos and audio wait for their captions as well. The file is stored first and removed
+// again when the version cannot be recorded.
+func (uc *UploadResourceVersionUseCase) Execute(ctx context.Context, cmd *UploadResourceVersionCommand) (*entities.ResourceVersion, error) {
+	viewer, err := uc.viewers.Viewer(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	resource, err := uc.resourceRepo.FindByID(ctx, cmd.TenantID, cmd.ResourceID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if resource == nil || !viewer.CanSee(resource) {
+		return nil, libraryErrors.NewResourceNotFoundError(cmd.ResourceID)
+	}
+	if !viewer.CanEdit(resource) {
+		return nil, libraryErrors.NewResourceNotEditableError(resource.ID, string(resource.Status))
+	}
+	if !resource.Kind.AcceptsFiles() {
+		return nil, errors.NewValidationError("Invalid resource version",
+			map[string]any{"content": "links do not take files, update their URL instead"}, nil)
+	}
+
+	checksum := sha256.Sum256(cmd.Content)
+	version, err := entities.NewResourceVersion(uuid.New().String(), resource.TenantID, resource.ID, 0, cmd.FileName,
+		cmd.ContentType, int64(len(cmd.Content)), hex.EncodeToString(checksum[:]), storage.ScanPending,
+		storage.PreviewPending, cmd.AltText, storage.InitialCaptionStatus(cmd.ContentType), cmd.UserID, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.files.Put(ctx, version.StorageKey(), cmd.Content); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	number, err := uc.resourceRepo.AddVersion(ctx, version)
+	if err != nil {
+		_ = uc.files.Delete(ctx, version.StorageKey())
+		return nil, errors.PropagateError(err)
+	}
+	version.Version = number
+
+	return version, nil
+}
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+const MaxResourceFileBytes = 25 << 20
+
+// ResourceVersion is one uploaded file of a resource. Versions are never replaced, a new upload
+// becomes the current version once it is scanned clean and older ones stay downloadable.
+type ResourceVersion struct {
+	ID          string             `validate:"required,uuid4"`
+	TenantID    string             `validate:"required"`
+	ResourceID  string             `validate:"required,uuid"`
+	Version     int                `validate:"gte=0"`
+	FileName    string             `validate:"required,max=255"`
+	ContentType string             `validate:"required,max=100"`
+	Size        int64              `validate:"gt=0,lte=26214400"`
+	Checksum    string             `validate:"required,len=64,hexadecimal"`
+	ScanStatus  storage.ScanStatus `validate:"required,oneof=pending clean infected missing"`
+	// PreviewStatus tells whether the thumbnail of the file can be downloaded
+	PreviewStatus storage.PreviewStatus `validate:"required,oneof=pending ready unsupported failed"`
+	// AltText describes images to screen readers, empty for other files and for images uploaded before
+	// it was asked for
+	AltText string `validate:"max=1000"`
+	// CaptionStatus tells whether the captions and transcript of videos and audio can be downloaded
+	CaptionStatus storage.CaptionStatus `validate:"required,oneof=pending ready not_needed unsupported failed"`
+	UploadedBy    string                `validate:"required"`
+	CreatedAt     time.Time             `validate:"required"`
+}
+
+func NewResourceVersion(id string, tenantID string, resourceID string, version int, fileName string, contentType string, size int64,
+	checksum string, scanStatus storage.ScanStatus, previewStatus storage.PreviewStatus, altText string, captionStatus storage.CaptionStatus,
+	uploadedBy string, createdAt time.Time) (*ResourceVersion, error) {
+	resourceVersion := &ResourceVersion{
+		ID:            id,
+		TenantID:      tenantID,
+		ResourceID:    resourceID,
+		Version:       version,
+		FileName:      fileName,
+		ContentType:   contentType,
+		Size:          size,
+		Checksum:      checksum,
+		ScanStatus:    scanStatus,
+		PreviewStatus: previewStatus,
+		AltText:       altText,
+		CaptionStatus: captionStatus,
+		UploadedBy:    uploadedBy,
+		CreatedAt:     createdAt,
+	}
+
+	if err := validate.Struct(resourceVersion); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("ResourceVersion domain model instance not valid", errorMap, err)
+	}
+
+	return resourceVersion, nil
+}
+
+// StorageKey is where the file of the version is kept, keyed by the version ID so the file can be
+// stored before the version number is assigned
+func (v *ResourceVersion) StorageKey() string {
+	return "library/" + v.TenantID + "/" + v.ResourceID + "/" + v.ID
+}
+
+// ResourceDetails is a resource with its file versions, newest first
+type ResourceDetails struct {
+	Resource *Resource
+	Versions []*ResourceVersion
+}
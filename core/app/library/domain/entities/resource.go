@@ -0,0 +1,140 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+const MaxTagsPerResource = 20
+
+type ResourceKind string
+
+const (
+	ResourceKindDocument ResourceKind = "document"
+	ResourceKindLink     ResourceKind = "link"
+	ResourceKindVideo    ResourceKind = "video"
+)
+
+// AcceptsFiles reports whether versions can be uploaded, links only point elsewhere
+func (k ResourceKind) AcceptsFiles() bool {
+	return k != ResourceKindLink
+}
+
+type ResourceStatus string
+
+const (
+	ResourceStatusDraft     ResourceStatus = "draft"
+	ResourceStatusPublished ResourceStatus = "published"
+	ResourceStatusArchived  ResourceStatus = "archived"
+)
+
+// Resource is a shared teaching resource of the tenant. Documents are files, links are a URL and
+// videos are either. Files are versioned, CurrentVersion is 0 until the first upload is scanned clean.
+type Resource struct {
+	ID             string         `validate:"required,uuid4"`
+	TenantID       string         `validate:"required"`
+	Kind           ResourceKind   `validate:"required,oneof=document link video"`
+	Title          string         `validate:"required,max=200"`
+	Description    string         `validate:"max=2000"`
+	Subject        string         `validate:"max=100"`
+	GradeLevel     string         `validate:"max=20"`
+	Tags           []string       `validate:"max=20,dive,required,max=40"`
+	URL            string         `validate:"omitempty,url,max=2000"`
+	Status         ResourceStatus `validate:"required,oneof=draft published archived"`
+	CurrentVersion int            `validate:"gte=0"`
+	OwnerID        string         `validate:"required"`
+	PublishedBy    string
+	PublishedAt    *time.Time
+	CreatedAt      time.Time `validate:"required"`
+	UpdatedAt      time.Time `validate:"required"`
+}
+
+func NewResource(id string, tenantID string, kind ResourceKind, title string, description string, subject string, gradeLevel string,
+	tags []string, url string, status ResourceStatus, currentVersion int, ownerID string, publishedBy string, publishedAt *time.Time,
+	createdAt time.Time, updatedAt time.Time) (*Resource, error) {
+	resource := &Resource{
+		ID:             id,
+		TenantID:       tenantID,
+		Kind:           kind,
+		Title:          title,
+		Description:    description,
+		Subject:        subject,
+		GradeLevel:     gradeLevel,
+		Tags:           NormalizeTags(tags),
+		URL:            url,
+		Status:         status,
+		CurrentVersion: currentVersion,
+		OwnerID:        ownerID,
+		PublishedBy:    publishedBy,
+		PublishedAt:    publishedAt,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+	}
+
+	if kind == ResourceKindLink && url == "" {
+		return nil, appErrors.NewDomainEntityValidationError("Resource domain model instance not valid",
+			map[string]any{"url": "links need a URL"}, errors.New("link without url"))
+	}
+
+	if err := validate.Struct(resource); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("Resource domain model instance not valid", errorMap, err)
+	}
+
+	return resource, nil
+}
+
+// NormalizeTags lowercases and trims tags, dropping empty and repeated ones, so filtering by tag
+// does not depend on how it was typed
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+func (r *Resource) IsPublished() bool {
+	return r.Status == ResourceStatusPublished
+}
+
+func (r *Resource) IsArchived() bool {
+	return r.Status == ResourceStatusArchived
+}
+
+// IsPublishable reports whether the resource has content to show, a file or a URL depending on its kind
+func (r *Resource) IsPublishable() bool {
+	switch r.Kind {
+	case ResourceKindDocument:
+		return r.CurrentVersion > 0
+	case ResourceKindVideo:
+		return r.CurrentVersion > 0 || r.URL != ""
+	default:
+		return r.URL != ""
+	}
+}
+
+func (r *Resource) Publish(publishedBy string, now time.Time) {
+	r.Status = ResourceStatusPublished
+	r.PublishedBy = publishedBy
+	r.PublishedAt = &now
+	r.UpdatedAt = now
+}
+
+func (r *Resource) Archive(now time.Time) {
+	r.Status = ResourceStatusArchived
+	r.UpdatedAt = now
+}
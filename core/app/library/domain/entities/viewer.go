@@ -0,0 +1,29 @@
+package entities
+
+// Viewer is who is browsing the library. Contributors create resources and upload versions of
+// their own, publishers publish, archive and edit any resource.
+type Viewer struct {
+	UserID        string
+	CanContribute bool
+	CanPublish    bool
+}
+
+// VisibleStatuses are the statuses listed for the viewer on top of their own drafts
+func (v Viewer) VisibleStatuses() []ResourceStatus {
+	if v.CanPublish {
+		return []ResourceStatus{ResourceStatusDraft, ResourceStatusPublished, ResourceStatusArchived}
+	}
+	return []ResourceStatus{ResourceStatusPublished}
+}
+
+func (v Viewer) CanSee(resource *Resource) bool {
+	return resource.IsPublished() || v.CanPublish || (v.CanContribute && resource.OwnerID == v.UserID)
+}
+
+// CanEdit covers metadata changes and new versions, archived resources are read only
+func (v Viewer) CanEdit(resource *Resource) bool {
+	if resource.IsArchived() {
+		return false
+	}
+	return v.CanPublish || (v.CanContribute && resource.OwnerID == v.UserID)
+}
@@ -0,0 +1,74 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ResourceNotFoundError        errors2.ErrorCode = "RESOURCE_NOT_FOUND"
+	ResourceVersionNotFoundError errors2.ErrorCode = "RESOURCE_VERSION_NOT_FOUND"
+	ResourceNotEditableError     errors2.ErrorCode = "RESOURCE_NOT_EDITABLE"
+	ResourceNotPublishableError  errors2.ErrorCode = "RESOURCE_NOT_PUBLISHABLE"
+)
+
+func NewResourceNotFoundError(resourceID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ResourceNotFoundError.String(),
+			Message: "The requested resource could not be found",
+			Context: map[string]any{
+				"resource_id": resourceID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ResourceNotFoundError.String()),
+		},
+	}
+}
+
+func NewResourceVersionNotFoundError(resourceID string, version int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ResourceVersionNotFoundError.String(),
+			Message: "The requested resource version could not be found",
+			Context: map[string]any{
+				"resource_id": resourceID,
+				"version":     version,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ResourceVersionNotFoundError.String()),
+		},
+	}
+}
+
+func NewResourceNotEditableError(resourceID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ResourceNotEditableError.String(),
+			Message: "The resource cannot be changed by the current user",
+			Context: map[string]any{
+				"resource_id": resourceID,
+				"status":      status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ResourceNotEditableError.String()),
+		},
+	}
+}
+
+func NewResourceNotPublishableError(resourceID string, kind string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ResourceNotPublishableError.String(),
+			Message: "The resource has no content to publish yet",
+			Context: map[string]any{
+				"resource_id": resourceID,
+				"kind":        kind,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ResourceNotPublishableError.String()),
+		},
+	}
+}
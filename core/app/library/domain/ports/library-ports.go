@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+)
+
+// ResourceFilter fields are optional, Statuses limits the listing to resources in any of them
+// plus the drafts owned by DraftsOf when set
+type ResourceFilter struct {
+	Kind       entities.ResourceKind
+	Subject    string
+	GradeLevel string
+	Tag        string
+	Query      string
+	Statuses   []entities.ResourceStatus
+	DraftsOf   string
+	Limit      int
+}
+
+type ResourceRepository interface {
+	Create(ctx context.Context, resource *entities.Resource) error
+	Update(ctx context.Context, resource *entities.Resource) error
+	FindByID(ctx context.Context, tenantID string, resourceID string) (*entities.Resource, error)
+	// List returns the matching resources, last updated first
+	List(ctx context.Context, tenantID string, filter ResourceFilter) ([]*entities.Resource, error)
+	// AddVersion numbers the version after the last one of its resource, concurrent uploads get
+	// distinct numbers. The version becomes current once it is scanned clean. Returns the number given.
+	AddVersion(ctx context.Context, version *entities.ResourceVersion) (int, error)
+	// ListVersions returns the versions of the resource, newest first
+	ListVersions(ctx context.Context, tenantID string, resourceID string) ([]*entities.ResourceVersion, error)
+	FindVersion(ctx context.Context, tenantID string, resourceID string, version int) (*entities.ResourceVersion, error)
+}
+
+type ViewerDirectory interface {
+	Viewer(ctx context.Context, tenantID string, userID string) (entities.Viewer, error)
+}
@@ -0,0 +1,32 @@
+package get_credentials_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetCredentialsUseCase struct {
+	identities ports.LinkedIdentityRepository
+}
+
+func NewGetCredentialsUseCase(identities ports.LinkedIdentityRepository) *GetCredentialsUseCase {
+	return &GetCredentialsUseCase{
+		identities: identities,
+	}
+}
+
+// Execute returns the ways the user signs in: the local password and the identities of external
+// providers
+func (uc *GetCredentialsUseCase) Execute(ctx context.Context, cmd *GetCredentialsCommand) (*entities.Credentials, error) {
+	credentials, err := uc.identities.Credentials(ctx, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if credentials == nil {
+		return nil, errors.NewUnauthorizedError("Unknown user")
+	}
+	return credentials, nil
+}
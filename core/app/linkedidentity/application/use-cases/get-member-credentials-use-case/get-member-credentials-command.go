@@ -0,0 +1,28 @@
+package get_member_credentials_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetMemberCredentialsCommand struct {
+	TenantID string `validate:"required"`
+	UserID   string `validate:"required,uuid"`
+}
+
+func NewGetMemberCredentialsCommand(tenantID string, userID string) (*GetMemberCredentialsCommand, error) {
+	command := &GetMemberCredentialsCommand{
+		TenantID: tenantID,
+		UserID:   userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,32 @@
+package get_member_credentials_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	linkedIdentityErrors "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetMemberCredentialsUseCase struct {
+	identities ports.LinkedIdentityRepository
+}
+
+func NewGetMemberCredentialsUseCase(identities ports.LinkedIdentityRepository) *GetMemberCredentialsUseCase {
+	return &GetMemberCredentialsUseCase{
+		identities: identities,
+	}
+}
+
+// Execute lets admins see how a member of their tenant signs in
+func (uc *GetMemberCredentialsUseCase) Execute(ctx context.Context, cmd *GetMemberCredentialsCommand) (*entities.Credentials, error) {
+	credentials, err := uc.identities.MemberCredentials(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if credentials == nil {
+		return nil, linkedIdentityErrors.NewCredentialsUserNotFoundError(cmd.UserID)
+	}
+	return credentials, nil
+}
@@ -0,0 +1,33 @@
+package link_identity_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// LinkIdentityCommand links the identity a token of the provider was issued to, the ID token of
+// Google for instance, to the signed in user
+type LinkIdentityCommand struct {
+	UserID   string            `validate:"required"`
+	Provider entities.Provider `validate:"required,oneof=google saml"`
+	Token    string            `validate:"required,max=16384"`
+}
+
+func NewLinkIdentityCommand(userID string, provider string, token string) (*LinkIdentityCommand, error) {
+	command := &LinkIdentityCommand{
+		UserID:   userID,
+		Provider: entities.Provider(provider),
+		Token:    token,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
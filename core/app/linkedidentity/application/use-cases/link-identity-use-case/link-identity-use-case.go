@@ -0,0 +1,56 @@
+package link_identity_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	linkedIdentityErrors "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type LinkIdentityUseCase struct {
+	identities ports.LinkedIdentityRepository
+	tokens     ports.IdentityTokenVerifier
+}
+
+func NewLinkIdentityUseCase(identities ports.LinkedIdentityRepository, tokens ports.IdentityTokenVerifier) *LinkIdentityUseCase {
+	return &LinkIdentityUseCase{
+		identities: identities,
+		tokens:     tokens,
+	}
+}
+
+// Execute links the identity the token was issued to. The issuer, subject and email are the ones of
+// the verified token, never the caller's, so nobody links the account of someone else.
+func (uc *LinkIdentityUseCase) Execute(ctx context.Context, cmd *LinkIdentityCommand) (*entities.LinkedIdentity, error) {
+	credentials, err := uc.identities.Credentials(ctx, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if credentials == nil {
+		return nil, errors.NewUnauthorizedError("Unknown user")
+	}
+
+	verified, err := uc.tokens.Verify(ctx, cmd.Provider, cmd.Token)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	identity, err := entities.NewLinkedIdentity(uuid.New().String(), cmd.UserID, verified.Provider, verified.Issuer, verified.Subject, verified.Email, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	linked, err := uc.identities.Link(ctx, identity)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !linked {
+		return nil, linkedIdentityErrors.NewIdentityAlreadyLinkedError(string(verified.Provider), verified.Issuer)
+	}
+	return identity, nil
+}
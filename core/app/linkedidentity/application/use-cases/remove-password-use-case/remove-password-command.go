@@ -0,0 +1,26 @@
+package remove_password_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RemovePasswordCommand struct {
+	UserID string `validate:"required"`
+}
+
+func NewRemovePasswordCommand(userID string) (*RemovePasswordCommand, error) {
+	command := &RemovePasswordCommand{
+		UserID: userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
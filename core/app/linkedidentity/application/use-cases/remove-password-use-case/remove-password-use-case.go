@@ -0,0 +1,45 @@
+package remove_password_use_case
+
+import (
+	"context"
+
+	linkedIdentityErrors "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type RemovePasswordUseCase struct {
+	identities ports.LinkedIdentityRepository
+}
+
+func NewRemovePasswordUseCase(identities ports.LinkedIdentityRepository) *RemovePasswordUseCase {
+	return &RemovePasswordUseCase{
+		identities: identities,
+	}
+}
+
+// Execute leaves the user signing in through linked identities only
+func (uc *RemovePasswordUseCase) Execute(ctx context.Context, cmd *RemovePasswordCommand) error {
+	credentials, err := uc.identities.Credentials(ctx, cmd.UserID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if credentials == nil {
+		return errors.NewUnauthorizedError("Unknown user")
+	}
+	if !credentials.HasPassword {
+		return linkedIdentityErrors.NewPasswordNotSetError(cmd.UserID)
+	}
+	if credentials.Count() <= 1 {
+		return linkedIdentityErrors.NewLastCredentialError(cmd.UserID)
+	}
+
+	removed, err := uc.identities.RemovePassword(ctx, cmd.UserID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if !removed {
+		return linkedIdentityErrors.NewLastCredentialError(cmd.UserID)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package resolve_linked_identity_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ResolveLinkedIdentityCommand struct {
+	Provider entities.Provider `validate:"required,oneof=google saml"`
+	Issuer   string            `validate:"required,max=255"`
+	Subject  string            `validate:"required,max=255"`
+}
+
+func NewResolveLinkedIdentityCommand(provider string, issuer string, subject string) (*ResolveLinkedIdentityCommand, error) {
+	command := &ResolveLinkedIdentityCommand{
+		Provider: entities.Provider(provider),
+		Issuer:   issuer,
+		Subject:  subject,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,33 @@
+package resolve_linked_identity_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ResolveLinkedIdentityUseCase struct {
+	identities ports.LinkedIdentityRepository
+}
+
+func NewResolveLinkedIdentityUseCase(identities ports.LinkedIdentityRepository) *ResolveLinkedIdentityUseCase {
+	return &ResolveLinkedIdentityUseCase{
+		identities: identities,
+	}
+}
+
+// Execute returns the identity the gateway signed a user in with. Identities nobody linked are
+// refused like a wrong password.
+func (uc *ResolveLinkedIdentityUseCase) Execute(ctx context.Context, cmd *ResolveLinkedIdentityCommand) (*entities.LinkedIdentity, error) {
+	identity, err := uc.identities.Resolve(ctx, cmd.Provider, cmd.Issuer, cmd.Subject, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if identity == nil {
+		return nil, errors.NewUnauthorizedError("Unknown identity")
+	}
+	return identity, nil
+}
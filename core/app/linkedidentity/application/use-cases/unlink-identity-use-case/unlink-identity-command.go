@@ -0,0 +1,28 @@
+package unlink_identity_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type UnlinkIdentityCommand struct {
+	UserID     string `validate:"required"`
+	IdentityID string `validate:"required,uuid"`
+}
+
+func NewUnlinkIdentityCommand(userID string, identityID string) (*UnlinkIdentityCommand, error) {
+	command := &UnlinkIdentityCommand{
+		UserID:     userID,
+		IdentityID: identityID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,47 @@
+package unlink_identity_use_case
+
+import (
+	"context"
+
+	linkedIdentityErrors "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type UnlinkIdentityUseCase struct {
+	identities ports.LinkedIdentityRepository
+}
+
+func NewUnlinkIdentityUseCase(identities ports.LinkedIdentityRepository) *UnlinkIdentityUseCase {
+	return &UnlinkIdentityUseCase{
+		identities: identities,
+	}
+}
+
+// Execute unlinks the identity while the user keeps another way to sign in, so nobody locks
+// themselves out
+func (uc *UnlinkIdentityUseCase) Execute(ctx context.Context, cmd *UnlinkIdentityCommand) error {
+	credentials, err := uc.identities.Credentials(ctx, cmd.UserID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if credentials == nil {
+		return errors.NewUnauthorizedError("Unknown user")
+	}
+	if credentials.Identity(cmd.IdentityID) == nil {
+		return linkedIdentityErrors.NewLinkedIdentityNotFoundError(cmd.IdentityID)
+	}
+	if credentials.Count() <= 1 {
+		return linkedIdentityErrors.NewLastCredentialError(cmd.UserID)
+	}
+
+	// The repository checks again with the user locked, another removal may have run since
+	unlinked, err := uc.identities.Unlink(ctx, cmd.UserID, cmd.IdentityID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if !unlinked {
+		return linkedIdentityErrors.NewLastCredentialError(cmd.UserID)
+	}
+	return nil
+}
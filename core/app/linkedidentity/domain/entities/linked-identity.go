@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// Provider is the kind of external identity provider the gateway signs users in with
+type Provider string
+
+const (
+	ProviderGoogle Provider = "google"
+	ProviderSAML   Provider = "saml"
+)
+
+// LinkedIdentity is an account of an external provider a user signs in with. Issuer tells apart the
+// providers of a kind, the entity ID of the SAML IdP for instance, and Subject is the ID the provider
+// gives the user, which unlike the email never changes.
+type LinkedIdentity struct {
+	ID         string   `validate:"required,uuid"`
+	UserID     string   `validate:"required"`
+	Provider   Provider `validate:"required,oneof=google saml"`
+	Issuer     string   `validate:"required,max=255"`
+	Subject    string   `validate:"required,max=255"`
+	Email      string   `validate:"omitempty,email"`
+	LinkedAt   time.Time
+	LastUsedAt *time.Time
+}
+
+func NewLinkedIdentity(id string, userID string, provider Provider, issuer string, subject string, email string, now time.Time) (*LinkedIdentity, error) {
+	identity := &LinkedIdentity{
+		ID:       id,
+		UserID:   userID,
+		Provider: provider,
+		Issuer:   issuer,
+		Subject:  subject,
+		Email:    email,
+		LinkedAt: now,
+	}
+
+	if err := validate.Struct(identity); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("LinkedIdentity domain model instance not valid", errorMap, err)
+	}
+
+	return identity, nil
+}
+
+// VerifiedIdentity is who a token of a provider was issued to, read from the claims of a token whose
+// signature, issuer, audience and expiry were checked
+type VerifiedIdentity struct {
+	Provider Provider
+	Issuer   string
+	Subject  string
+	// Email is empty unless the provider verified it
+	Email string
+}
+
+// Credentials are the ways a user signs in: the local password and the linked identities
+type Credentials struct {
+	UserID      string
+	HasPassword bool
+	Identities  []*LinkedIdentity
+}
+
+// Count returns how many ways the user has to sign in
+func (c *Credentials) Count() int {
+	count := len(c.Identities)
+	if c.HasPassword {
+		count++
+	}
+	return count
+}
+
+// Identity returns the linked identity of the user with the ID, nil when there is none
+func (c *Credentials) Identity(identityID string) *LinkedIdentity {
+	for _, identity := range c.Identities {
+		if identity.ID == identityID {
+			return identity
+		}
+	}
+	return nil
+}
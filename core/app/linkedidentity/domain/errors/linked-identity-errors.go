@@ -0,0 +1,111 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	LinkedIdentityNotFoundError errors2.ErrorCode = "LINKED_IDENTITY_NOT_FOUND"
+	// IdentityAlreadyLinkedError is returned when linking an identity linked to a user already, the
+	// same user included
+	IdentityAlreadyLinkedError errors2.ErrorCode = "IDENTITY_ALREADY_LINKED"
+	// LastCredentialError is returned when removing the only way a user has left to sign in
+	LastCredentialError errors2.ErrorCode = "LAST_CREDENTIAL"
+	PasswordNotSetError errors2.ErrorCode = "PASSWORD_NOT_SET"
+	// CredentialsUserNotFoundError is returned when an admin reads the credentials of a user that
+	// holds no role in the tenant
+	CredentialsUserNotFoundError errors2.ErrorCode = "CREDENTIALS_USER_NOT_FOUND"
+	// InvalidIdentityTokenError is returned when linking with a token the provider did not issue for
+	// the application, or that expired
+	InvalidIdentityTokenError errors2.ErrorCode = "INVALID_IDENTITY_TOKEN"
+)
+
+func NewLinkedIdentityNotFoundError(identityID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    LinkedIdentityNotFoundError.String(),
+			Message: "Linked identity not found",
+			Context: map[string]any{
+				"identity_id": identityID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(LinkedIdentityNotFoundError.String()),
+		},
+	}
+}
+
+func NewIdentityAlreadyLinkedError(provider string, issuer string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    IdentityAlreadyLinkedError.String(),
+			Message: "Identity is linked to an account already",
+			Context: map[string]any{
+				"provider": provider,
+				"issuer":   issuer,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(IdentityAlreadyLinkedError.String()),
+		},
+	}
+}
+
+func NewLastCredentialError(userID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    LastCredentialError.String(),
+			Message: "The last way to sign in cannot be removed, link another identity first",
+			Context: map[string]any{
+				"user_id": userID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(LastCredentialError.String()),
+		},
+	}
+}
+
+func NewPasswordNotSetError(userID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    PasswordNotSetError.String(),
+			Message: "User has no password",
+			Context: map[string]any{
+				"user_id": userID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(PasswordNotSetError.String()),
+		},
+	}
+}
+
+func NewCredentialsUserNotFoundError(userID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    CredentialsUserNotFoundError.String(),
+			Message: "User not found in the tenant",
+			Context: map[string]any{
+				"user_id": userID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(CredentialsUserNotFoundError.String()),
+		},
+	}
+}
+
+func NewInvalidIdentityTokenError(provider string, reason string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    InvalidIdentityTokenError.String(),
+			Message: "Identity token is not valid",
+			Context: map[string]any{
+				"provider": provider,
+				"reason":   reason,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidIdentityTokenError.String()),
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+)
+
+type LinkedIdentityRepository interface {
+	// Credentials returns the password state and linked identities of the user, nil when the user
+	// does not exist
+	Credentials(ctx context.Context, userID string) (*entities.Credentials, error)
+	// MemberCredentials is Credentials for a user holding a role in the tenant, nil otherwise
+	MemberCredentials(ctx context.Context, tenantID string, userID string) (*entities.Credentials, error)
+	// Link saves the identity, false when the provider, issuer and subject are linked to a user already
+	Link(ctx context.Context, identity *entities.LinkedIdentity) (bool, error)
+	// Unlink removes the identity of the user while the user keeps another way to sign in, checked
+	// with the user locked so concurrent removals cannot leave it without any. False when it would be
+	// the last one.
+	Unlink(ctx context.Context, userID string, identityID string) (bool, error)
+	// RemovePassword clears the password of the user under the same lock and rule as Unlink
+	RemovePassword(ctx context.Context, userID string) (bool, error)
+	// Resolve returns the identity of provider, issuer and subject and records it was used at, nil
+	// when no user linked it
+	Resolve(ctx context.Context, provider entities.Provider, issuer string, subject string, at time.Time) (*entities.LinkedIdentity, error)
+}
+
+// IdentityTokenVerifier checks the tokens providers issue at the end of their sign in flow
+type IdentityTokenVerifier interface {
+	// Verify returns the identity token was issued to, an InvalidIdentityTokenError when the token is
+	// not one of provider or is not valid anymore
+	Verify(ctx context.Context, provider entities.Provider, token string) (*entities.VerifiedIdentity, error)
+}
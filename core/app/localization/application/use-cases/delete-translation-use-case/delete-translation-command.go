@@ -0,0 +1,33 @@
+package delete_translation_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type DeleteTranslationCommand struct {
+	TenantID   string              `validate:"required"`
+	EntityType entities.EntityType `validate:"required,oneof=resource form"`
+	EntityID   string              `validate:"required,uuid"`
+	Locale     entities.Locale     `validate:"required,bcp47_language_tag"`
+}
+
+func NewDeleteTranslationCommand(tenantID string, entityType string, entityID string, locale string) (*DeleteTranslationCommand, error) {
+	command := &DeleteTranslationCommand{
+		TenantID:   tenantID,
+		EntityType: entities.EntityType(entityType),
+		EntityID:   entityID,
+		Locale:     entities.NewLocale(locale),
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
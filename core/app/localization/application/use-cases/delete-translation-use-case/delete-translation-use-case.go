@@ -0,0 +1,30 @@
+package delete_translation_use_case
+
+import (
+	"context"
+
+	localizationErrors "github.com/nahualventure/class-backend/core/app/localization/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/localization/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type DeleteTranslationUseCase struct {
+	translationRepo ports.TranslationRepository
+}
+
+func NewDeleteTranslationUseCase(translationRepo ports.TranslationRepository) *DeleteTranslationUseCase {
+	return &DeleteTranslationUseCase{
+		translationRepo: translationRepo,
+	}
+}
+
+func (uc *DeleteTranslationUseCase) Execute(ctx context.Context, cmd *DeleteTranslationCommand) error {
+	deleted, err := uc.translationRepo.Delete(ctx, cmd.TenantID, cmd.EntityType, cmd.EntityID, cmd.Locale)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if !deleted {
+		return localizationErrors.NewTranslationNotFoundError(string(cmd.EntityType), cmd.EntityID, string(cmd.Locale))
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package list_translations_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListTranslationsCommand struct {
+	TenantID   string              `validate:"required"`
+	EntityType entities.EntityType `validate:"required,oneof=resource form"`
+	EntityID   string              `validate:"required,uuid"`
+}
+
+func NewListTranslationsCommand(tenantID string, entityType string, entityID string) (*ListTranslationsCommand, error) {
+	command := &ListTranslationsCommand{
+		TenantID:   tenantID,
+		EntityType: entities.EntityType(entityType),
+		EntityID:   entityID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
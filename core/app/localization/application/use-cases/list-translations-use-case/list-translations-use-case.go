@@ -0,0 +1,38 @@
+package list_translations_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	localizationErrors "github.com/nahualventure/class-backend/core/app/localization/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/localization/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListTranslationsUseCase struct {
+	translationRepo ports.TranslationRepository
+	entities        ports.TranslatableEntities
+}
+
+func NewListTranslationsUseCase(translationRepo ports.TranslationRepository, translatableEntities ports.TranslatableEntities) *ListTranslationsUseCase {
+	return &ListTranslationsUseCase{
+		translationRepo: translationRepo,
+		entities:        translatableEntities,
+	}
+}
+
+func (uc *ListTranslationsUseCase) Execute(ctx context.Context, cmd *ListTranslationsCommand) ([]*entities.Translation, error) {
+	exists, err := uc.entities.Exists(ctx, cmd.TenantID, cmd.EntityType, cmd.EntityID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !exists {
+		return nil, localizationErrors.NewTranslatableEntityNotFoundError(string(cmd.EntityType), cmd.EntityID)
+	}
+
+	translations, err := uc.translationRepo.ListByEntity(ctx, cmd.TenantID, cmd.EntityType, cmd.EntityID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return translations, nil
+}
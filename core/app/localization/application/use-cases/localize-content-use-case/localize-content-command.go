@@ -0,0 +1,34 @@
+package localize_content_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// LocalizeContentCommand resolves the fields of entities of one type for the locales a reader accepts
+type LocalizeContentCommand struct {
+	TenantID   string              `validate:"required"`
+	EntityType entities.EntityType `validate:"required,oneof=resource form"`
+	EntityIDs  []string            `validate:"max=500,dive,required"`
+	Chain      []entities.Locale
+}
+
+func NewLocalizeContentCommand(tenantID string, entityType entities.EntityType, entityIDs []string, acceptLanguage string) (*LocalizeContentCommand, error) {
+	command := &LocalizeContentCommand{
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityIDs:  entityIDs,
+		Chain:      entities.FallbackChain(entities.ParseAcceptLanguage(acceptLanguage)),
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,44 @@
+package localize_content_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/localization/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type LocalizeContentUseCase struct {
+	translationRepo ports.TranslationRepository
+}
+
+func NewLocalizeContentUseCase(translationRepo ports.TranslationRepository) *LocalizeContentUseCase {
+	return &LocalizeContentUseCase{
+		translationRepo: translationRepo,
+	}
+}
+
+// Execute returns the translated fields by entity ID, entities without any are left out. Readers
+// that accept no locale get the content as written.
+func (uc *LocalizeContentUseCase) Execute(ctx context.Context, cmd *LocalizeContentCommand) (map[string]map[string]string, error) {
+	localized := make(map[string]map[string]string)
+	if len(cmd.Chain) == 0 || len(cmd.EntityIDs) == 0 {
+		return localized, nil
+	}
+
+	translations, err := uc.translationRepo.ListByEntities(ctx, cmd.TenantID, cmd.EntityType, cmd.EntityIDs, cmd.Chain)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	byEntity := make(map[string][]*entities.Translation)
+	for _, translation := range translations {
+		byEntity[translation.EntityID] = append(byEntity[translation.EntityID], translation)
+	}
+	for entityID, entityTranslations := range byEntity {
+		if fields := entities.Resolve(entityTranslations, cmd.Chain); len(fields) > 0 {
+			localized[entityID] = fields
+		}
+	}
+	return localized, nil
+}
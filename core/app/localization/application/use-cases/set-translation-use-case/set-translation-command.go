@@ -0,0 +1,38 @@
+package set_translation_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SetTranslationCommand struct {
+	TenantID   string              `validate:"required"`
+	UserID     string              `validate:"required"`
+	EntityType entities.EntityType `validate:"required,oneof=resource form"`
+	EntityID   string              `validate:"required,uuid"`
+	Locale     entities.Locale     `validate:"required,bcp47_language_tag"`
+	Fields     map[string]string   `validate:"required,min=1"`
+}
+
+func NewSetTranslationCommand(tenantID string, userID string, entityType string, entityID string, locale string,
+	fields map[string]string) (*SetTranslationCommand, error) {
+	command := &SetTranslationCommand{
+		TenantID:   tenantID,
+		UserID:     userID,
+		EntityType: entities.EntityType(entityType),
+		EntityID:   entityID,
+		Locale:     entities.NewLocale(locale),
+		Fields:     fields,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
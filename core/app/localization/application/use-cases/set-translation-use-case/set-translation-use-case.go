@@ -0,0 +1,46 @@
+package set_translation_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	localizationErrors "github.com/nahualventure/class-backend/core/app/localization/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/localization/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetTranslationUseCase struct {
+	translationRepo ports.TranslationRepository
+	entities        ports.TranslatableEntities
+}
+
+func NewSetTranslationUseCase(translationRepo ports.TranslationRepository, translatableEntities ports.TranslatableEntities) *SetTranslationUseCase {
+	return &SetTranslationUseCase{
+		translationRepo: translationRepo,
+		entities:        translatableEntities,
+	}
+}
+
+// Execute replaces the translation of the entity in the locale, fields left out fall back again
+func (uc *SetTranslationUseCase) Execute(ctx context.Context, cmd *SetTranslationCommand) (*entities.Translation, error) {
+	exists, err := uc.entities.Exists(ctx, cmd.TenantID, cmd.EntityType, cmd.EntityID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !exists {
+		return nil, localizationErrors.NewTranslatableEntityNotFoundError(string(cmd.EntityType), cmd.EntityID)
+	}
+
+	translation, err := entities.NewTranslation(cmd.TenantID, cmd.EntityType, cmd.EntityID, cmd.Locale, cmd.Fields,
+		cmd.UserID, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.translationRepo.Save(ctx, translation); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return translation, nil
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Locale is a BCP 47 language tag in its canonical case, such as es or es-GT
+type Locale string
+
+// NewLocale canonicalizes the case of a tag, the language is lower case, the region upper case and
+// the script title case
+func NewLocale(tag string) Locale {
+	subtags := strings.Split(strings.TrimSpace(tag), "-")
+	for i, subtag := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(subtag)
+		case len(subtag) == 2:
+			subtags[i] = strings.ToUpper(subtag)
+		case len(subtag) == 4:
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+	return Locale(strings.Join(subtags, "-"))
+}
+
+// Parent drops the last subtag, es-GT falls back to es
+func (l Locale) Parent() (Locale, bool) {
+	index := strings.LastIndex(string(l), "-")
+	if index < 0 {
+		return "", false
+	}
+	return l[:index], true
+}
+
+// ParseAcceptLanguage returns the locales of an Accept-Language header by preference. Wildcards and
+// locales weighted zero are dropped, malformed weights count as zero.
+func ParseAcceptLanguage(header string) []Locale {
+	type weighted struct {
+		locale Locale
+		weight float64
+	}
+
+	var ranges []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		weight := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				parsed = 0
+			}
+			weight = parsed
+		}
+		if weight <= 0 {
+			continue
+		}
+		ranges = append(ranges, weighted{locale: NewLocale(tag), weight: weight})
+	}
+
+	// Stable so locales of the same weight keep the order of the header
+	slices.SortStableFunc(ranges, func(a, b weighted) int {
+		switch {
+		case a.weight > b.weight:
+			return -1
+		case a.weight < b.weight:
+			return 1
+		}
+		return 0
+	})
+
+	locales := make([]Locale, 0, len(ranges))
+	for _, r := range ranges {
+		locales = append(locales, r.locale)
+	}
+	return locales
+}
+
+// FallbackChain lists the locales to look translations up in, each preferred locale followed by
+// its parents. A locale only appears at its first position, content without a translation in any
+// of them is shown as written.
+func FallbackChain(preferred []Locale) []Locale {
+	var chain []Locale
+	for _, locale := range preferred {
+		for {
+			if !slices.Contains(chain, locale) {
+				chain = append(chain, locale)
+			}
+			parent, found := locale.Parent()
+			if !found {
+				break
+			}
+			locale = parent
+		}
+	}
+	return chain
+}
@@ -0,0 +1,102 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// EntityType names the kind of content a translation belongs to
+type EntityType string
+
+const (
+	EntityTypeResource EntityType = "resource"
+	EntityTypeForm     EntityType = "form"
+)
+
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	// promptFieldPrefix is followed by the ID of the question, forms translate each prompt
+	promptFieldPrefix = "prompt:"
+)
+
+// PromptField names the translated prompt of a form question
+func PromptField(questionID string) string {
+	return promptFieldPrefix + questionID
+}
+
+// Translates reports whether the field of the entity type can be translated
+func (t EntityType) Translates(field string) bool {
+	switch field {
+	case FieldTitle, FieldDescription:
+		return true
+	}
+	return t == EntityTypeForm && strings.HasPrefix(field, promptFieldPrefix) && len(field) > len(promptFieldPrefix)
+}
+
+// Translation holds the fields of one entity in one locale. Fields without a translation fall back
+// along the chain of the reader, down to the content as written.
+type Translation struct {
+	TenantID   string            `validate:"required"`
+	EntityType EntityType        `validate:"required,oneof=resource form"`
+	EntityID   string            `validate:"required,uuid"`
+	Locale     Locale            `validate:"required,bcp47_language_tag,max=35"`
+	Fields     map[string]string `validate:"required,min=1,max=200,dive,keys,required,max=100,endkeys,required,max=5000"`
+	UpdatedBy  string            `validate:"required"`
+	UpdatedAt  time.Time         `validate:"required"`
+}
+
+func NewTranslation(tenantID string, entityType EntityType, entityID string, locale Locale, fields map[string]string,
+	updatedBy string, updatedAt time.Time) (*Translation, error) {
+	translation := &Translation{
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Locale:     locale,
+		Fields:     fields,
+		UpdatedBy:  updatedBy,
+		UpdatedAt:  updatedAt,
+	}
+
+	if err := validate.Struct(translation); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("Translation domain model instance not valid", errorMap, err)
+	}
+
+	for field := range fields {
+		if !entityType.Translates(field) {
+			return nil, appErrors.NewDomainEntityValidationError("Translation domain model instance not valid",
+				map[string]any{"fields": field + " cannot be translated for " + string(entityType)}, errors.New("untranslatable field"))
+		}
+	}
+
+	return translation, nil
+}
+
+// Resolve picks every field from the first locale of the chain that translates it. Fields missing
+// from the result are shown as written.
+func Resolve(translations []*Translation, chain []Locale) map[string]string {
+	byLocale := make(map[Locale]*Translation, len(translations))
+	for _, translation := range translations {
+		byLocale[translation.Locale] = translation
+	}
+
+	resolved := make(map[string]string)
+	for i := len(chain) - 1; i >= 0; i-- {
+		if translation, found := byLocale[chain[i]]; found {
+			for field, value := range translation.Fields {
+				resolved[field] = value
+			}
+		}
+	}
+	return resolved
+}
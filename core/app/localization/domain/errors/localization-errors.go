@@ -0,0 +1,44 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	TranslatableEntityNotFoundError errors2.ErrorCode = "TRANSLATABLE_ENTITY_NOT_FOUND"
+	TranslationNotFoundError        errors2.ErrorCode = "TRANSLATION_NOT_FOUND"
+)
+
+func NewTranslatableEntityNotFoundError(entityType string, entityID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TranslatableEntityNotFoundError.String(),
+			Message: "The content to translate was not found",
+			Context: map[string]any{
+				"entityType": entityType,
+				"entityId":   entityID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TranslatableEntityNotFoundError.String()),
+		},
+	}
+}
+
+func NewTranslationNotFoundError(entityType string, entityID string, locale string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TranslationNotFoundError.String(),
+			Message: "The content has no translation in this locale",
+			Context: map[string]any{
+				"entityType": entityType,
+				"entityId":   entityID,
+				"locale":     locale,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TranslationNotFoundError.String()),
+		},
+	}
+}
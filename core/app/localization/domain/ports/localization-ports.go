@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+)
+
+type TranslationRepository interface {
+	// Save creates or replaces the translation of the entity in its locale
+	Save(ctx context.Context, translation *entities.Translation) error
+	// Delete returns false when the entity had no translation in the locale
+	Delete(ctx context.Context, tenantID string, entityType entities.EntityType, entityID string, locale entities.Locale) (bool, error)
+	// ListByEntity returns every translation of the entity ordered by locale
+	ListByEntity(ctx context.Context, tenantID string, entityType entities.EntityType, entityID string) ([]*entities.Translation, error)
+	// ListByEntities returns the translations of the entities in any of the locales
+	ListByEntities(ctx context.Context, tenantID string, entityType entities.EntityType, entityIDs []string,
+		locales []entities.Locale) ([]*entities.Translation, error)
+}
+
+// TranslatableEntities looks up the content translations are attached to, which lives in other modules
+type TranslatableEntities interface {
+	Exists(ctx context.Context, tenantID string, entityType entities.EntityType, entityID string) (bool, error)
+}
@@ -0,0 +1,270 @@
+package messaging_service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	DefaultMessagePageSize = 50
+	MaxMessagePageSize     = 100
+)
+
+var validate = validator.New()
+
+type AttachmentUpload struct {
+	FileName    string `validate:"required,max=255"`
+	ContentType string `validate:"required,max=100"`
+	Content     []byte `validate:"required,max=10485760"`
+	// AltText is required for images
+	AltText string `validate:"max=1000"`
+}
+
+// validateAltTexts refuses images uploaded without a text alternative
+func validateAltTexts(uploads []AttachmentUpload) error {
+	for i := range uploads {
+		uploads[i].AltText = strings.TrimSpace(uploads[i].AltText)
+		if storage.NeedsAltText(uploads[i].ContentType) && uploads[i].AltText == "" {
+			return errors.NewValidationError("Invalid attachment",
+				map[string]any{fmt.Sprintf("attachments[%d].alt_text", i): "images need a text alternative for screen readers"}, nil)
+		}
+	}
+	return nil
+}
+
+type SendDirectMessageCommand struct {
+	TenantID    string             `validate:"required"`
+	SenderID    string             `validate:"required"`
+	RecipientID string             `validate:"required,nefield=SenderID"`
+	Body        string             `validate:"max=5000"`
+	Attachments []AttachmentUpload `validate:"max=5,dive"`
+}
+
+func NewSendDirectMessageCommand(tenantID string, senderID string, recipientID string, body string,
+	attachments []AttachmentUpload) (*SendDirectMessageCommand, error) {
+	command := &SendDirectMessageCommand{
+		TenantID:    tenantID,
+		SenderID:    senderID,
+		RecipientID: recipientID,
+		Body:        body,
+		Attachments: attachments,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if err := validateAltTexts(command.Attachments); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+type PostClassMessageCommand struct {
+	TenantID    string             `validate:"required"`
+	SenderID    string             `validate:"required"`
+	ClassID     string             `validate:"required,uuid"`
+	Body        string             `validate:"max=5000"`
+	Attachments []AttachmentUpload `validate:"max=5,dive"`
+}
+
+func NewPostClassMessageCommand(tenantID string, senderID string, classID string, body string,
+	attachments []AttachmentUpload) (*PostClassMessageCommand, error) {
+	command := &PostClassMessageCommand{
+		TenantID:    tenantID,
+		SenderID:    senderID,
+		ClassID:     classID,
+		Body:        body,
+		Attachments: attachments,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if err := validateAltTexts(command.Attachments); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+type ListConversationsCommand struct {
+	TenantID string `validate:"required"`
+	UserID   string `validate:"required"`
+}
+
+func NewListConversationsCommand(tenantID string, userID string) (*ListConversationsCommand, error) {
+	command := &ListConversationsCommand{
+		TenantID: tenantID,
+		UserID:   userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
+
+type ListMessagesCommand struct {
+	TenantID       string `validate:"required"`
+	UserID         string `validate:"required"`
+	ConversationID string `validate:"required,uuid"`
+	Before         *time.Time
+	Limit          int `validate:"min=0,max=100"`
+}
+
+// NewListMessagesCommand pages backwards from before, a zero limit uses DefaultMessagePageSize
+func NewListMessagesCommand(tenantID string, userID string, conversationID string, before *time.Time, limit int) (*ListMessagesCommand, error) {
+	command := &ListMessagesCommand{
+		TenantID:       tenantID,
+		UserID:         userID,
+		ConversationID: conversationID,
+		Before:         before,
+		Limit:          limit,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if command.Limit == 0 {
+		command.Limit = DefaultMessagePageSize
+	}
+
+	return command, nil
+}
+
+type MarkReadCommand struct {
+	TenantID       string `validate:"required"`
+	UserID         string `validate:"required"`
+	ConversationID string `validate:"required,uuid"`
+}
+
+func NewMarkReadCommand(tenantID string, userID string, conversationID string) (*MarkReadCommand, error) {
+	command := &MarkReadCommand{
+		TenantID:       tenantID,
+		UserID:         userID,
+		ConversationID: conversationID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
+
+type GetAttachmentCommand struct {
+	TenantID     string `validate:"required"`
+	UserID       string `validate:"required"`
+	AttachmentID string `validate:"required,uuid"`
+	// Preview gets the thumbnail of the attachment instead of its file
+	Preview bool
+	// Alternative gets the captions or the transcript of the attachment instead of its file
+	Alternative storage.AlternativeFormat `validate:"omitempty,oneof=captions transcript,excluded_if=Preview true"`
+}
+
+func NewGetAttachmentCommand(tenantID string, userID string, attachmentID string, preview bool,
+	alternative storage.AlternativeFormat) (*GetAttachmentCommand, error) {
+	command := &GetAttachmentCommand{
+		TenantID:     tenantID,
+		UserID:       userID,
+		AttachmentID: attachmentID,
+		Preview:      preview,
+		Alternative:  alternative,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
+
+type ReportMessageCommand struct {
+	TenantID   string `validate:"required"`
+	ReporterID string `validate:"required"`
+	MessageID  string `validate:"required,uuid"`
+	Reason     string `validate:"required,max=1000"`
+}
+
+func NewReportMessageCommand(tenantID string, reporterID string, messageID string, reason string) (*ReportMessageCommand, error) {
+	command := &ReportMessageCommand{
+		TenantID:   tenantID,
+		ReporterID: reporterID,
+		MessageID:  messageID,
+		Reason:     reason,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
+
+type ListAbuseReportsCommand struct {
+	TenantID string `validate:"required"`
+}
+
+func NewListAbuseReportsCommand(tenantID string) (*ListAbuseReportsCommand, error) {
+	command := &ListAbuseReportsCommand{
+		TenantID: tenantID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
+
+type ResolveAbuseReportCommand struct {
+	TenantID    string `validate:"required"`
+	ModeratorID string `validate:"required"`
+	ReportID    string `validate:"required,uuid"`
+	Resolution  string `validate:"required,oneof=dismiss hide"`
+}
+
+func NewResolveAbuseReportCommand(tenantID string, moderatorID string, reportID string, resolution string) (*ResolveAbuseReportCommand, error) {
+	command := &ResolveAbuseReportCommand{
+		TenantID:    tenantID,
+		ModeratorID: moderatorID,
+		ReportID:    reportID,
+		Resolution:  resolution,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
+
+type LinkGuardianCommand struct {
+	TenantID   string `validate:"required"`
+	GuardianID string `validate:"required"`
+	StudentID  string `validate:"required,nefield=GuardianID"`
+}
+
+func NewLinkGuardianCommand(tenantID string, guardianID string, studentID string) (*LinkGuardianCommand, error) {
+	command := &LinkGuardianCommand{
+		TenantID:   tenantID,
+		GuardianID: guardianID,
+		StudentID:  studentID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
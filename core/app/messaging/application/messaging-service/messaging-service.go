@@ -0,0 +1,415 @@
+package messaging_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+	messagingErrors "github.com/nahualventure/class-backend/core/app/messaging/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+
+	"github.com/google/uuid"
+)
+
+// MessagingService groups the inbox operations, they share the visibility checks so they live
+// together instead of in one use case each
+type MessagingService struct {
+	conversations ports.ConversationRepository
+	messages      ports.MessageRepository
+	reports       ports.AbuseReportRepository
+	directory     ports.ParticipantDirectory
+	files         storage.FileStorage
+}
+
+func NewMessagingService(conversations ports.ConversationRepository, messages ports.MessageRepository,
+	reports ports.AbuseReportRepository, directory ports.ParticipantDirectory, files storage.FileStorage) *MessagingService {
+	return &MessagingService{
+		conversations: conversations,
+		messages:      messages,
+		reports:       reports,
+		directory:     directory,
+		files:         files,
+	}
+}
+
+func (s *MessagingService) SendDirectMessage(ctx context.Context, cmd *SendDirectMessageCommand) (*entities.Message, error) {
+	sender, err := s.participant(ctx, cmd.TenantID, cmd.SenderID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	recipient, err := s.directory.Participant(ctx, cmd.TenantID, cmd.RecipientID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if recipient == nil {
+		return nil, messagingErrors.NewMessagingNotAllowedError(cmd.RecipientID)
+	}
+
+	linked, err := s.linked(ctx, cmd.TenantID, *sender, *recipient)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !entities.CanMessageDirectly(*sender, *recipient, linked) {
+		return nil, messagingErrors.NewMessagingNotAllowedError(cmd.RecipientID)
+	}
+
+	now := time.Now().UTC()
+	conversation, err := s.conversations.FindOrCreate(ctx,
+		entities.NewDirectConversation(uuid.New().String(), cmd.TenantID, cmd.SenderID, cmd.RecipientID, now))
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return s.send(ctx, conversation, cmd.SenderID, cmd.Body, cmd.Attachments, now)
+}
+
+func (s *MessagingService) PostClassMessage(ctx context.Context, cmd *PostClassMessageCommand) (*entities.Message, error) {
+	sender, err := s.participant(ctx, cmd.TenantID, cmd.SenderID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	access, err := s.directory.ClassAccess(ctx, cmd.TenantID, cmd.ClassID, *sender)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !entities.CanPostToClassThread(*sender, access) {
+		return nil, messagingErrors.NewMessagingNotAllowedError(cmd.ClassID)
+	}
+
+	now := time.Now().UTC()
+	conversation, err := s.conversations.FindOrCreate(ctx,
+		entities.NewClassConversation(uuid.New().String(), cmd.TenantID, cmd.ClassID, now))
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return s.send(ctx, conversation, cmd.SenderID, cmd.Body, cmd.Attachments, now)
+}
+
+func (s *MessagingService) ListConversations(ctx context.Context, cmd *ListConversationsCommand) ([]entities.ConversationSummary, error) {
+	participant, err := s.participant(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	classIDs, err := s.directory.ClassIDs(ctx, cmd.TenantID, *participant)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	summaries, err := s.conversations.ListForUser(ctx, cmd.TenantID, cmd.UserID, classIDs, participant.IsStaff())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return summaries, nil
+}
+
+// ListMessages returns a page of the conversation, newest first, with hidden messages redacted for
+// non staff readers
+func (s *MessagingService) ListMessages(ctx context.Context, cmd *ListMessagesCommand) ([]entities.MessageView, error) {
+	reader, conversation, err := s.openConversation(ctx, cmd.TenantID, cmd.UserID, cmd.ConversationID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	messages, err := s.messages.List(ctx, cmd.TenantID, conversation.ID, cmd.Before, cmd.Limit)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	receipts, err := s.conversations.ListReceipts(ctx, cmd.TenantID, conversation.ID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	views := make([]entities.MessageView, 0, len(messages))
+	for _, message := range messages {
+		views = append(views, entities.NewMessageView(message, receipts, *reader))
+	}
+
+	return views, nil
+}
+
+// MarkRead marks every message of the conversation up to now as read by the user
+func (s *MessagingService) MarkRead(ctx context.Context, cmd *MarkReadCommand) (*entities.ReadReceipt, error) {
+	_, conversation, err := s.openConversation(ctx, cmd.TenantID, cmd.UserID, cmd.ConversationID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	receipt := entities.ReadReceipt{ConversationID: conversation.ID, UserID: cmd.UserID, ReadAt: time.Now().UTC()}
+	if err := s.conversations.MarkRead(ctx, receipt); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return &receipt, nil
+}
+
+// GetAttachment returns the attachment with its file, or with its preview or alternative when the
+// command asks for one
+func (s *MessagingService) GetAttachment(ctx context.Context, cmd *GetAttachmentCommand) (*entities.Attachment, []byte, error) {
+	message, err := s.messages.FindByAttachment(ctx, cmd.TenantID, cmd.AttachmentID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if message == nil {
+		return nil, nil, messagingErrors.NewAttachmentNotFoundError(cmd.AttachmentID)
+	}
+
+	reader, _, err := s.openConversation(ctx, cmd.TenantID, cmd.UserID, message.ConversationID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if message.IsHidden() && !reader.IsStaff() {
+		return nil, nil, messagingErrors.NewAttachmentNotFoundError(cmd.AttachmentID)
+	}
+
+	for _, attachment := range message.Attachments {
+		if attachment.ID != cmd.AttachmentID {
+			continue
+		}
+		if !attachment.ScanStatus.IsAvailable() {
+			return nil, nil, errors.NewFileQuarantinedError(attachment.ID, string(attachment.ScanStatus))
+		}
+		key := message.AttachmentKey(attachment.ID)
+		if cmd.Preview {
+			if attachment.PreviewStatus != storage.PreviewReady {
+				return nil, nil, errors.NewPreviewNotAvailableError(attachment.ID, string(attachment.PreviewStatus))
+			}
+			key = storage.PreviewKey(key)
+		}
+		if cmd.Alternative != "" {
+			if attachment.CaptionStatus != storage.CaptionReady {
+				return nil, nil, errors.NewAlternativeNotAvailableError(attachment.ID, string(attachment.CaptionStatus))
+			}
+			key = cmd.Alternative.Key(key)
+		}
+		content, err := s.files.Get(ctx, key)
+		if err != nil {
+			return nil, nil, errors.PropagateError(err)
+		}
+		if content == nil {
+			return nil, nil, messagingErrors.NewAttachmentNotFoundError(cmd.AttachmentID)
+		}
+		return &attachment, content, nil
+	}
+
+	return nil, nil, messagingErrors.NewAttachmentNotFoundError(cmd.AttachmentID)
+}
+
+// ReportMessage flags a message for moderators, anyone who can read the message can report it
+func (s *MessagingService) ReportMessage(ctx context.Context, cmd *ReportMessageCommand) (*entities.AbuseReport, error) {
+	message, err := s.messages.FindByID(ctx, cmd.TenantID, cmd.MessageID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if message == nil {
+		return nil, messagingErrors.NewMessageNotFoundError(cmd.MessageID)
+	}
+
+	if _, _, err := s.openConversation(ctx, cmd.TenantID, cmd.ReporterID, message.ConversationID); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	report := &entities.AbuseReport{
+		ID:             uuid.New().String(),
+		TenantID:       cmd.TenantID,
+		MessageID:      message.ID,
+		ConversationID: message.ConversationID,
+		ReporterID:     cmd.ReporterID,
+		Reason:         cmd.Reason,
+		Status:         entities.AbuseReportStatusOpen,
+		CreatedAt:      time.Now().UTC(),
+	}
+	if err := s.reports.Create(ctx, report); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return report, nil
+}
+
+func (s *MessagingService) ListAbuseReports(ctx context.Context, cmd *ListAbuseReportsCommand) ([]*entities.AbuseReport, error) {
+	reports, err := s.reports.ListOpen(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return reports, nil
+}
+
+// ResolveAbuseReport dismisses the report or hides the reported message
+func (s *MessagingService) ResolveAbuseReport(ctx context.Context, cmd *ResolveAbuseReportCommand) (*entities.AbuseReport, error) {
+	report, err := s.reports.FindByID(ctx, cmd.TenantID, cmd.ReportID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if report == nil {
+		return nil, messagingErrors.NewAbuseReportNotFoundError(cmd.ReportID)
+	}
+	if !report.IsOpen() {
+		return nil, messagingErrors.NewAbuseReportAlreadyResolvedError(report.ID, string(report.Status))
+	}
+
+	now := time.Now().UTC()
+	resolution := entities.AbuseReportResolution(cmd.Resolution)
+	if resolution == entities.AbuseReportResolutionHide {
+		message, err := s.messages.FindByID(ctx, cmd.TenantID, report.MessageID)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		if message == nil {
+			return nil, messagingErrors.NewMessageNotFoundError(report.MessageID)
+		}
+		message.Hide(cmd.ModeratorID, now)
+		if err := s.messages.Hide(ctx, message); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	}
+
+	report.Resolve(resolution, cmd.ModeratorID, now)
+	if err := s.reports.Resolve(ctx, report); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return report, nil
+}
+
+// LinkGuardian records that a guardian is responsible for a student, the link opens direct messages
+// between them and the class threads of the student to the guardian
+func (s *MessagingService) LinkGuardian(ctx context.Context, cmd *LinkGuardianCommand) error {
+	guardian, err := s.directory.Participant(ctx, cmd.TenantID, cmd.GuardianID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	student, err := s.directory.Participant(ctx, cmd.TenantID, cmd.StudentID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+
+	errorMap := make(map[string]any)
+	if guardian == nil || guardian.Role != entities.ParticipantRoleGuardian {
+		errorMap["guardian_id"] = "the user does not have the guardian role"
+	}
+	if student == nil || student.Role != entities.ParticipantRoleStudent {
+		errorMap["student_id"] = "the user does not have the student role"
+	}
+	if len(errorMap) > 0 {
+		return errors.NewValidationError("Invalid guardian link", errorMap, nil)
+	}
+
+	if err := s.directory.LinkGuardian(ctx, cmd.TenantID, cmd.GuardianID, cmd.StudentID); err != nil {
+		return errors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (s *MessagingService) participant(ctx context.Context, tenantID string, userID string) (*entities.Participant, error) {
+	participant, err := s.directory.Participant(ctx, tenantID, userID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if participant == nil {
+		return nil, messagingErrors.NewMessagingNotAllowedError(userID)
+	}
+
+	return participant, nil
+}
+
+func (s *MessagingService) linked(ctx context.Context, tenantID string, a entities.Participant, b entities.Participant) (bool, error) {
+	switch {
+	case a.Role == entities.ParticipantRoleGuardian && b.Role == entities.ParticipantRoleStudent:
+		return s.directory.IsGuardianOf(ctx, tenantID, a.UserID, b.UserID)
+	case a.Role == entities.ParticipantRoleStudent && b.Role == entities.ParticipantRoleGuardian:
+		return s.directory.IsGuardianOf(ctx, tenantID, b.UserID, a.UserID)
+	default:
+		return false, nil
+	}
+}
+
+// openConversation loads a conversation the user can read. Conversations the user cannot read are
+// reported as missing so their existence does not leak.
+func (s *MessagingService) openConversation(ctx context.Context, tenantID string, userID string,
+	conversationID string) (*entities.Participant, *entities.Conversation, error) {
+	reader, err := s.participant(ctx, tenantID, userID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+
+	conversation, err := s.conversations.FindByID(ctx, tenantID, conversationID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if conversation == nil {
+		return nil, nil, messagingErrors.NewConversationNotFoundError(conversationID)
+	}
+
+	switch conversation.Kind {
+	case entities.ConversationKindDirect:
+		if !conversation.HasParticipant(userID) {
+			return nil, nil, messagingErrors.NewConversationNotFoundError(conversationID)
+		}
+	case entities.ConversationKindClass:
+		access, err := s.directory.ClassAccess(ctx, tenantID, conversation.ClassID, *reader)
+		if err != nil {
+			return nil, nil, errors.PropagateError(err)
+		}
+		if !entities.CanReadClassThread(*reader, access) {
+			return nil, nil, messagingErrors.NewConversationNotFoundError(conversationID)
+		}
+	}
+
+	return reader, conversation, nil
+}
+
+// send stores the attachments first so a stored message never points at a missing file, files of a
+// message that fails to store are removed again
+func (s *MessagingService) send(ctx context.Context, conversation *entities.Conversation, senderID string, body string,
+	uploads []AttachmentUpload, now time.Time) (*entities.Message, error) {
+	attachments := make([]entities.Attachment, 0, len(uploads))
+	for _, upload := range uploads {
+		attachments = append(attachments, entities.Attachment{
+			ID:            uuid.New().String(),
+			FileName:      upload.FileName,
+			ContentType:   upload.ContentType,
+			Size:          int64(len(upload.Content)),
+			ScanStatus:    storage.ScanPending,
+			PreviewStatus: storage.PreviewPending,
+			AltText:       upload.AltText,
+			CaptionStatus: storage.InitialCaptionStatus(upload.ContentType),
+		})
+	}
+
+	message, err := entities.NewMessage(uuid.New().String(), conversation.TenantID, conversation.ID, senderID, body,
+		attachments, nil, "", now)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	stored := make([]string, 0, len(uploads))
+	cleanup := func() {
+		for _, key := range stored {
+			_ = s.files.Delete(ctx, key)
+		}
+	}
+	for i, upload := range uploads {
+		key := message.AttachmentKey(attachments[i].ID)
+		if err := s.files.Put(ctx, key, upload.Content); err != nil {
+			cleanup()
+			return nil, errors.PropagateError(err)
+		}
+		stored = append(stored, key)
+	}
+
+	if err := s.messages.Append(ctx, message); err != nil {
+		cleanup()
+		return nil, errors.PropagateError(err)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+type AbuseReportStatus string
+
+const (
+	AbuseReportStatusOpen      AbuseReportStatus = "open"
+	AbuseReportStatusDismissed AbuseReportStatus = "dismissed"
+	// AbuseReportStatusActioned reports got the message hidden
+	AbuseReportStatusActioned AbuseReportStatus = "actioned"
+)
+
+type AbuseReportResolution string
+
+const (
+	AbuseReportResolutionDismiss AbuseReportResolution = "dismiss"
+	AbuseReportResolutionHide    AbuseReportResolution = "hide"
+)
+
+type AbuseReport struct {
+	ID             string
+	TenantID       string
+	MessageID      string
+	ConversationID string
+	ReporterID     string
+	Reason         string
+	Status         AbuseReportStatus
+	ResolvedBy     string
+	ResolvedAt     *time.Time
+	CreatedAt      time.Time
+}
+
+func (r *AbuseReport) IsOpen() bool {
+	return r.Status == AbuseReportStatusOpen
+}
+
+func (r *AbuseReport) Resolve(resolution AbuseReportResolution, moderatorID string, now time.Time) {
+	r.Status = AbuseReportStatusDismissed
+	if resolution == AbuseReportResolutionHide {
+		r.Status = AbuseReportStatusActioned
+	}
+	r.ResolvedBy = moderatorID
+	r.ResolvedAt = &now
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+type ConversationKind string
+
+const (
+	ConversationKindDirect ConversationKind = "direct"
+	ConversationKindClass  ConversationKind = "class"
+)
+
+// Conversation is either a direct conversation between two users or the thread of a class. There is
+// at most one of each per pair of users and per class.
+type Conversation struct {
+	ID             string
+	TenantID       string
+	Kind           ConversationKind
+	ClassID        string   // class threads only
+	ParticipantIDs []string // direct conversations only, sorted
+	CreatedAt      time.Time
+	LastMessageAt  *time.Time
+}
+
+func NewDirectConversation(id string, tenantID string, userA string, userB string, now time.Time) *Conversation {
+	participants := []string{userA, userB}
+	slices.Sort(participants)
+	return &Conversation{
+		ID:             id,
+		TenantID:       tenantID,
+		Kind:           ConversationKindDirect,
+		ParticipantIDs: participants,
+		CreatedAt:      now,
+	}
+}
+
+func NewClassConversation(id string, tenantID string, classID string, now time.Time) *Conversation {
+	return &Conversation{
+		ID:        id,
+		TenantID:  tenantID,
+		Kind:      ConversationKindClass,
+		ClassID:   classID,
+		CreatedAt: now,
+	}
+}
+
+func (c *Conversation) HasParticipant(userID string) bool {
+	return slices.Contains(c.ParticipantIDs, userID)
+}
+
+// ConversationSummary is a conversation as listed in a user inbox
+type ConversationSummary struct {
+	Conversation *Conversation
+	LastReadAt   *time.Time
+	UnreadCount  int
+}
+
+// ReadReceipt records up to when a user read a conversation, messages created until ReadAt are read
+type ReadReceipt struct {
+	ConversationID string
+	UserID         string
+	ReadAt         time.Time
+}
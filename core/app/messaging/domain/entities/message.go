@@ -0,0 +1,125 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	MaxAttachmentsPerMessage = 5
+	MaxAttachmentBytes       = 10 << 20
+)
+
+var validate = validator.New()
+
+// Attachment files are quarantined until they are scanned clean, messages are delivered right away
+type Attachment struct {
+	ID            string                `validate:"required,uuid4"`
+	FileName      string                `validate:"required,max=255"`
+	ContentType   string                `validate:"required,max=100"`
+	Size          int64                 `validate:"gt=0,lte=10485760"`
+	ScanStatus    storage.ScanStatus    `validate:"required,oneof=pending clean infected missing"`
+	PreviewStatus storage.PreviewStatus `validate:"required,oneof=pending ready unsupported failed"`
+	// AltText describes images to screen readers
+	AltText       string                `validate:"max=1000"`
+	CaptionStatus storage.CaptionStatus `validate:"required,oneof=pending ready not_needed unsupported failed"`
+}
+
+// Message belongs to a conversation. Moderators hide abusive messages instead of deleting them so
+// reports keep their evidence.
+type Message struct {
+	ID             string       `validate:"required,uuid4"`
+	TenantID       string       `validate:"required"`
+	ConversationID string       `validate:"required,uuid"`
+	SenderID       string       `validate:"required"`
+	Body           string       `validate:"max=5000"`
+	Attachments    []Attachment `validate:"max=5,dive"`
+	HiddenAt       *time.Time
+	HiddenBy       string
+	CreatedAt      time.Time `validate:"required"`
+}
+
+func NewMessage(id string, tenantID string, conversationID string, senderID string, body string, attachments []Attachment,
+	hiddenAt *time.Time, hiddenBy string, createdAt time.Time) (*Message, error) {
+	message := &Message{
+		ID:             id,
+		TenantID:       tenantID,
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		Body:           body,
+		Attachments:    attachments,
+		HiddenAt:       hiddenAt,
+		HiddenBy:       hiddenBy,
+		CreatedAt:      createdAt,
+	}
+
+	if strings.TrimSpace(body) == "" && len(attachments) == 0 {
+		return nil, appErrors.NewDomainEntityValidationError("Message domain model instance not valid",
+			map[string]any{"body": "a message needs a body or an attachment"}, errors.New("empty message"))
+	}
+
+	if err := validate.Struct(message); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("Message domain model instance not valid", errorMap, err)
+	}
+
+	return message, nil
+}
+
+func (m *Message) IsHidden() bool {
+	return m.HiddenAt != nil
+}
+
+func (m *Message) Hide(moderatorID string, now time.Time) {
+	if m.IsHidden() {
+		return
+	}
+	m.HiddenAt = &now
+	m.HiddenBy = moderatorID
+}
+
+// ReadBy returns who read the message according to the receipts of its conversation, the sender is
+// left out
+func (m *Message) ReadBy(receipts []ReadReceipt) []string {
+	readers := make([]string, 0, len(receipts))
+	for _, receipt := range receipts {
+		if receipt.UserID != m.SenderID && !receipt.ReadAt.Before(m.CreatedAt) {
+			readers = append(readers, receipt.UserID)
+		}
+	}
+	return readers
+}
+
+// MessageView is a message as shown to one reader. Hidden messages lose their body and attachments
+// for everyone but staff.
+type MessageView struct {
+	Message *Message
+	ReadBy  []string
+}
+
+func NewMessageView(message *Message, receipts []ReadReceipt, reader Participant) MessageView {
+	view := MessageView{Message: message, ReadBy: message.ReadBy(receipts)}
+	if message.IsHidden() && !reader.IsStaff() {
+		redacted := *message
+		redacted.Body = ""
+		redacted.Attachments = nil
+		view.Message = &redacted
+	}
+	return view
+}
+
+// AttachmentKey is where the file of an attachment of the message is kept
+func (m *Message) AttachmentKey(attachmentID string) string {
+	return "messages/" + m.TenantID + "/" + m.ConversationID + "/" + attachmentID
+}
@@ -0,0 +1,60 @@
+package entities
+
+// ParticipantRole is the messaging view of the tenant roles. Instructors, department heads and
+// admins all act as staff.
+type ParticipantRole string
+
+const (
+	ParticipantRoleStaff    ParticipantRole = "staff"
+	ParticipantRoleStudent  ParticipantRole = "student"
+	ParticipantRoleGuardian ParticipantRole = "guardian"
+)
+
+type Participant struct {
+	UserID string
+	Role   ParticipantRole
+}
+
+func (p Participant) IsStaff() bool {
+	return p.Role == ParticipantRoleStaff
+}
+
+// CanMessageDirectly applies the direct message rules: staff reach everyone, students and guardians
+// reach staff, and guardians and the students they are linked to reach each other. linked tells
+// whether one of the two is a guardian of the other.
+func CanMessageDirectly(sender Participant, recipient Participant, linked bool) bool {
+	if sender.UserID == recipient.UserID {
+		return false
+	}
+	if sender.IsStaff() || recipient.IsStaff() {
+		return true
+	}
+	return linked && sender.Role != recipient.Role
+}
+
+// ClassAccess is how a participant relates to a class
+type ClassAccess struct {
+	// Enrolled is set for students of the class
+	Enrolled bool
+	// GuardianOfEnrolled is set for guardians of a student of the class
+	GuardianOfEnrolled bool
+}
+
+// CanReadClassThread lets staff, students of the class and their guardians read the class thread
+func CanReadClassThread(participant Participant, access ClassAccess) bool {
+	switch participant.Role {
+	case ParticipantRoleStaff:
+		return true
+	case ParticipantRoleStudent:
+		return access.Enrolled
+	case ParticipantRoleGuardian:
+		return access.GuardianOfEnrolled
+	default:
+		return false
+	}
+}
+
+// CanPostToClassThread keeps guardians read only, class threads are for the class itself
+func CanPostToClassThread(participant Participant, access ClassAccess) bool {
+	return participant.IsStaff() || (participant.Role == ParticipantRoleStudent && access.Enrolled)
+}
@@ -0,0 +1,104 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ConversationNotFoundError       errors2.ErrorCode = "CONVERSATION_NOT_FOUND"
+	MessageNotFoundError            errors2.ErrorCode = "MESSAGE_NOT_FOUND"
+	AttachmentNotFoundError         errors2.ErrorCode = "ATTACHMENT_NOT_FOUND"
+	MessagingNotAllowedError        errors2.ErrorCode = "MESSAGING_NOT_ALLOWED"
+	AbuseReportNotFoundError        errors2.ErrorCode = "ABUSE_REPORT_NOT_FOUND"
+	AbuseReportAlreadyResolvedError errors2.ErrorCode = "ABUSE_REPORT_ALREADY_RESOLVED"
+)
+
+func NewConversationNotFoundError(conversationID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ConversationNotFoundError.String(),
+			Message: "The requested conversation could not be found",
+			Context: map[string]any{
+				"conversation_id": conversationID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ConversationNotFoundError.String()),
+		},
+	}
+}
+
+func NewMessageNotFoundError(messageID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    MessageNotFoundError.String(),
+			Message: "The requested message could not be found",
+			Context: map[string]any{
+				"message_id": messageID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(MessageNotFoundError.String()),
+		},
+	}
+}
+
+func NewAttachmentNotFoundError(attachmentID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    AttachmentNotFoundError.String(),
+			Message: "The requested attachment could not be found",
+			Context: map[string]any{
+				"attachment_id": attachmentID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(AttachmentNotFoundError.String()),
+		},
+	}
+}
+
+// NewMessagingNotAllowedError is returned when the visibility rules do not let the user reach the
+// target, target is a user or class ID
+func NewMessagingNotAllowedError(target string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    MessagingNotAllowedError.String(),
+			Message: "You are not allowed to message this user or class",
+			Context: map[string]any{
+				"target": target,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(MessagingNotAllowedError.String()),
+		},
+	}
+}
+
+func NewAbuseReportNotFoundError(reportID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    AbuseReportNotFoundError.String(),
+			Message: "The requested abuse report could not be found",
+			Context: map[string]any{
+				"report_id": reportID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(AbuseReportNotFoundError.String()),
+		},
+	}
+}
+
+func NewAbuseReportAlreadyResolvedError(reportID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    AbuseReportAlreadyResolvedError.String(),
+			Message: "The abuse report was already resolved",
+			Context: map[string]any{
+				"report_id": reportID,
+				"status":    status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(AbuseReportAlreadyResolvedError.String()),
+		},
+	}
+}
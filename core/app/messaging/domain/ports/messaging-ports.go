@@ -0,0 +1,49 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+)
+
+type ConversationRepository interface {
+	// FindOrCreate returns the stored conversation with the same participants or class, creating
+	// conversation when there is none
+	FindOrCreate(ctx context.Context, conversation *entities.Conversation) (*entities.Conversation, error)
+	FindByID(ctx context.Context, tenantID string, conversationID string) (*entities.Conversation, error)
+	// ListForUser returns the direct conversations of the user and the threads of classIDs, or of
+	// every class when allClasses is set, most recent activity first
+	ListForUser(ctx context.Context, tenantID string, userID string, classIDs []string, allClasses bool) ([]entities.ConversationSummary, error)
+	MarkRead(ctx context.Context, receipt entities.ReadReceipt) error
+	ListReceipts(ctx context.Context, tenantID string, conversationID string) ([]entities.ReadReceipt, error)
+}
+
+type MessageRepository interface {
+	// Append stores the message and moves the last activity of its conversation
+	Append(ctx context.Context, message *entities.Message) error
+	FindByID(ctx context.Context, tenantID string, messageID string) (*entities.Message, error)
+	// FindByAttachment returns the message an attachment belongs to
+	FindByAttachment(ctx context.Context, tenantID string, attachmentID string) (*entities.Message, error)
+	// List returns up to limit messages created before before (all when nil), newest first
+	List(ctx context.Context, tenantID string, conversationID string, before *time.Time, limit int) ([]*entities.Message, error)
+	Hide(ctx context.Context, message *entities.Message) error
+}
+
+type AbuseReportRepository interface {
+	Create(ctx context.Context, report *entities.AbuseReport) error
+	FindByID(ctx context.Context, tenantID string, reportID string) (*entities.AbuseReport, error)
+	ListOpen(ctx context.Context, tenantID string) ([]*entities.AbuseReport, error)
+	Resolve(ctx context.Context, report *entities.AbuseReport) error
+}
+
+// ParticipantDirectory answers who users are and how they relate to classes and to each other
+type ParticipantDirectory interface {
+	// Participant returns nil when the user has no role that can use messaging
+	Participant(ctx context.Context, tenantID string, userID string) (*entities.Participant, error)
+	IsGuardianOf(ctx context.Context, tenantID string, guardianID string, studentID string) (bool, error)
+	LinkGuardian(ctx context.Context, tenantID string, guardianID string, studentID string) error
+	ClassAccess(ctx context.Context, tenantID string, classID string, participant entities.Participant) (entities.ClassAccess, error)
+	// ClassIDs returns the classes whose threads the participant can read, nil for staff who read all
+	ClassIDs(ctx context.Context, tenantID string, participant entities.Participant) ([]string, error)
+}
@@ -0,0 +1,28 @@
+package check_module_enabled_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CheckModuleEnabledCommand struct {
+	TenantID string `validate:"required"`
+	Module   string `validate:"required"`
+}
+
+func NewCheckModuleEnabledCommand(tenantID string, module string) (*CheckModuleEnabledCommand, error) {
+	command := &CheckModuleEnabledCommand{
+		TenantID: tenantID,
+		Module:   module,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,32 @@
+package check_module_enabled_use_case
+
+import (
+	"context"
+	"slices"
+
+	moduleToggleErrors "github.com/nahualventure/class-backend/core/app/moduletoggle/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type CheckModuleEnabledUseCase struct {
+	toggles ports.ModuleToggleReader
+}
+
+func NewCheckModuleEnabledUseCase(toggles ports.ModuleToggleReader) *CheckModuleEnabledUseCase {
+	return &CheckModuleEnabledUseCase{
+		toggles: toggles,
+	}
+}
+
+// Execute fails with MODULE_DISABLED when the module is disabled for the tenant
+func (uc *CheckModuleEnabledUseCase) Execute(ctx context.Context, cmd *CheckModuleEnabledCommand) error {
+	disabled, err := uc.toggles.DisabledModules(ctx, cmd.TenantID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if slices.Contains(disabled, cmd.Module) {
+		return moduleToggleErrors.NewModuleDisabledError(cmd.Module)
+	}
+	return nil
+}
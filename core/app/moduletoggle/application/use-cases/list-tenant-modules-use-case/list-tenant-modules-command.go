@@ -0,0 +1,26 @@
+package list_tenant_modules_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListTenantModulesCommand struct {
+	TenantID string `validate:"required"`
+}
+
+func NewListTenantModulesCommand(tenantID string) (*ListTenantModulesCommand, error) {
+	command := &ListTenantModulesCommand{
+		TenantID: tenantID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
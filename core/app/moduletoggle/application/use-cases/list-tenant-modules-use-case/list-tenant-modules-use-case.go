@@ -0,0 +1,43 @@
+package list_tenant_modules_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListTenantModulesUseCase struct {
+	toggles ports.ModuleToggleRepository
+}
+
+func NewListTenantModulesUseCase(toggles ports.ModuleToggleRepository) *ListTenantModulesUseCase {
+	return &ListTenantModulesUseCase{
+		toggles: toggles,
+	}
+}
+
+// Execute returns every toggleable module in the order of entities.ToggleableModules, modules
+// never toggled as enabled
+func (uc *ListTenantModulesUseCase) Execute(ctx context.Context, cmd *ListTenantModulesCommand) ([]*entities.ModuleToggle, error) {
+	toggled, err := uc.toggles.List(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	byModule := make(map[string]*entities.ModuleToggle, len(toggled))
+	for _, toggle := range toggled {
+		byModule[toggle.Module] = toggle
+	}
+	modules := make([]*entities.ModuleToggle, 0, len(entities.ToggleableModules))
+	for _, module := range entities.ToggleableModules {
+		toggle, ok := byModule[module]
+		if !ok {
+			toggle = entities.EnabledModule(cmd.TenantID, module)
+		}
+		modules = append(modules, toggle)
+	}
+
+	return modules, nil
+}
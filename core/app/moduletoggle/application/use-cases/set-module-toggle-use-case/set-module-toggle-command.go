@@ -0,0 +1,33 @@
+package set_module_toggle_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// SetModuleToggleCommand enables or disables the module for the tenant, Reason is kept for support
+type SetModuleToggleCommand struct {
+	TenantID string `validate:"required"`
+	Module   string `validate:"required"`
+	Enabled  bool
+	Reason   string `validate:"max=500"`
+}
+
+func NewSetModuleToggleCommand(tenantID string, module string, enabled bool, reason string) (*SetModuleToggleCommand, error) {
+	command := &SetModuleToggleCommand{
+		TenantID: tenantID,
+		Module:   module,
+		Enabled:  enabled,
+		Reason:   reason,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,35 @@
+package set_module_toggle_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetModuleToggleUseCase struct {
+	toggles ports.ModuleToggleRepository
+}
+
+func NewSetModuleToggleUseCase(toggles ports.ModuleToggleRepository) *SetModuleToggleUseCase {
+	return &SetModuleToggleUseCase{
+		toggles: toggles,
+	}
+}
+
+// Execute toggles the module. The data of a disabled module is kept, enabling it again brings it
+// back as it was.
+func (uc *SetModuleToggleUseCase) Execute(ctx context.Context, cmd *SetModuleToggleCommand) (*entities.ModuleToggle, error) {
+	toggle, err := entities.NewModuleToggle(cmd.TenantID, cmd.Module, cmd.Enabled, cmd.Reason, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.toggles.Save(ctx, toggle); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return toggle, nil
+}
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// ToggleableModules are the modules a tenant can go without, named like the modules instances
+// serve. Signing in, users, roles and the other modules every tenant needs cannot be disabled.
+var ToggleableModules = []string{
+	"attendance",
+	"behavior",
+	"classtemplate",
+	"dataimport",
+	"library",
+	"messaging",
+	"officehours",
+	"report",
+	"sections",
+	"similarity",
+	"standards",
+	"surveys",
+	"transcript",
+}
+
+// ModuleToggle enables or disables a module for a tenant, such as a module the tenant has not
+// purchased. Modules never toggled are enabled.
+type ModuleToggle struct {
+	TenantID string `validate:"required"`
+	Module   string `validate:"required"`
+	Enabled  bool
+	Reason   string `validate:"max=500"`
+	// UpdatedAt is zero for modules never toggled
+	UpdatedAt time.Time
+}
+
+// EnabledModule returns the toggle of a module never toggled
+func EnabledModule(tenantID string, module string) *ModuleToggle {
+	return &ModuleToggle{TenantID: tenantID, Module: module, Enabled: true}
+}
+
+func NewModuleToggle(tenantID string, module string, enabled bool, reason string, now time.Time) (*ModuleToggle, error) {
+	toggle := &ModuleToggle{
+		TenantID:  tenantID,
+		Module:    module,
+		Enabled:   enabled,
+		Reason:    reason,
+		UpdatedAt: now,
+	}
+
+	if err := validate.Struct(toggle); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("ModuleToggle domain model instance not valid", errorMap, err)
+	}
+	if !slices.Contains(ToggleableModules, module) {
+		return nil, appErrors.NewDomainEntityValidationError("ModuleToggle domain model instance not valid",
+			map[string]any{"Module": "must be one of " + strings.Join(ToggleableModules, ", ")},
+			errors.Newf("module %s cannot be toggled", module))
+	}
+
+	return toggle, nil
+}
@@ -0,0 +1,28 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// ModuleDisabledError refuses a request to a module disabled for the tenant
+	ModuleDisabledError errors2.ErrorCode = "MODULE_DISABLED"
+)
+
+func NewModuleDisabledError(module string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ModuleDisabledError.String(),
+			Message: "The module is not enabled for the tenant",
+			Context: map[string]any{
+				"module": module,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ModuleDisabledError.String()),
+		},
+	}
+}
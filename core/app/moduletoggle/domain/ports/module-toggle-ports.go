@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/entities"
+)
+
+// ModuleToggleReader is what every request checks, implementations may cache it
+type ModuleToggleReader interface {
+	// DisabledModules returns the modules disabled for the tenant, empty when every module is enabled
+	DisabledModules(ctx context.Context, tenantID string) ([]string, error)
+}
+
+type ModuleToggleRepository interface {
+	ModuleToggleReader
+	// List returns the modules of the tenant ever toggled
+	List(ctx context.Context, tenantID string) ([]*entities.ModuleToggle, error)
+	// Save stores the toggle with its tenant.module_toggled event
+	Save(ctx context.Context, toggle *entities.ModuleToggle) error
+}
@@ -0,0 +1,31 @@
+package get_digest_preference_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/notification/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/notification/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetDigestPreferenceUseCase struct {
+	preferences ports.DigestPreferenceRepository
+}
+
+func NewGetDigestPreferenceUseCase(preferences ports.DigestPreferenceRepository) *GetDigestPreferenceUseCase {
+	return &GetDigestPreferenceUseCase{
+		preferences: preferences,
+	}
+}
+
+// Execute returns the preference of the user, immediate when they never picked one
+func (uc *GetDigestPreferenceUseCase) Execute(ctx context.Context, cmd *GetDigestPreferenceCommand) (*entities.DigestPreference, error) {
+	preference, err := uc.preferences.Find(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if preference == nil {
+		return entities.DefaultDigestPreference(cmd.TenantID, cmd.UserID), nil
+	}
+	return preference, nil
+}
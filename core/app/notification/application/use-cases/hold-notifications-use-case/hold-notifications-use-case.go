@@ -0,0 +1,55 @@
+package hold_notifications_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/notification/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/notification/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+)
+
+type HoldNotificationsUseCase struct {
+	preferences ports.DigestPreferenceRepository
+	zones       ports.RecipientZones
+	batcher     ports.NotificationBatcher
+}
+
+func NewHoldNotificationsUseCase(preferences ports.DigestPreferenceRepository, zones ports.RecipientZones, batcher ports.NotificationBatcher) *HoldNotificationsUseCase {
+	return &HoldNotificationsUseCase{
+		preferences: preferences,
+		zones:       zones,
+		batcher:     batcher,
+	}
+}
+
+// Execute holds the notification event for each of its recipients until the window of their digest
+// ends. Holding is idempotent, the event can be seen again after a crash. It returns how many
+// notifications were held, none for events that are not notifications.
+func (uc *HoldNotificationsUseCase) Execute(ctx context.Context, event events.Event) (int, error) {
+	notifications := entities.NotificationsOf(event)
+	for _, notification := range notifications {
+		preference, err := uc.preferences.Find(ctx, notification.TenantID, notification.RecipientID)
+		if err != nil {
+			return 0, errors.PropagateError(err)
+		}
+		if preference == nil {
+			preference = entities.DefaultDigestPreference(notification.TenantID, notification.RecipientID)
+		}
+
+		notification.Frequency = preference.Frequency
+		notification.DueAt = notification.OccurredAt
+		if preference.Frequency != entities.DigestImmediate {
+			loc, err := uc.zones.LocationOf(ctx, notification.TenantID, notification.RecipientID)
+			if err != nil {
+				return 0, errors.PropagateError(err)
+			}
+			notification.DueAt = preference.DueAt(notification.OccurredAt, loc)
+		}
+
+		if err := uc.batcher.Hold(ctx, notification); err != nil {
+			return 0, errors.PropagateError(err)
+		}
+	}
+	return len(notifications), nil
+}
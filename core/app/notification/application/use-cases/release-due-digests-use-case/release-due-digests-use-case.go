@@ -0,0 +1,46 @@
+package release_due_digests_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/notification/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/notification/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// BatchSize bounds the recipients sent their digests per transaction
+const BatchSize = 100
+
+type ReleaseDueDigestsUseCase struct {
+	batcher ports.NotificationBatcher
+}
+
+func NewReleaseDueDigestsUseCase(batcher ports.NotificationBatcher) *ReleaseDueDigestsUseCase {
+	return &ReleaseDueDigestsUseCase{
+		batcher: batcher,
+	}
+}
+
+// Execute sends every held notification that is due, coalesced into one digest per recipient and
+// window. It returns how many digests were queued for delivery.
+func (uc *ReleaseDueDigestsUseCase) Execute(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	sent := 0
+	for {
+		due, err := uc.batcher.ListDue(ctx, now, BatchSize)
+		if err != nil {
+			return sent, errors.PropagateError(err)
+		}
+		if len(due) == 0 {
+			return sent, nil
+		}
+
+		digests := entities.NewDigests(due)
+		if err := uc.batcher.Send(ctx, digests, now); err != nil {
+			return sent, errors.PropagateError(err)
+		}
+		sent += len(digests)
+	}
+}
@@ -0,0 +1,30 @@
+package set_digest_preference_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SetDigestPreferenceCommand struct {
+	TenantID  string `validate:"required"`
+	UserID    string `validate:"required"`
+	Frequency string `validate:"required,oneof=immediate hourly daily"`
+}
+
+func NewSetDigestPreferenceCommand(tenantID string, userID string, frequency string) (*SetDigestPreferenceCommand, error) {
+	command := &SetDigestPreferenceCommand{
+		TenantID:  tenantID,
+		UserID:    userID,
+		Frequency: frequency,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,35 @@
+package set_digest_preference_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/notification/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/notification/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetDigestPreferenceUseCase struct {
+	preferences ports.DigestPreferenceRepository
+}
+
+func NewSetDigestPreferenceUseCase(preferences ports.DigestPreferenceRepository) *SetDigestPreferenceUseCase {
+	return &SetDigestPreferenceUseCase{
+		preferences: preferences,
+	}
+}
+
+// Execute picks the frequency of the user. Notifications held already keep the time they were due
+// at, the new frequency applies to the ones that follow.
+func (uc *SetDigestPreferenceUseCase) Execute(ctx context.Context, cmd *SetDigestPreferenceCommand) (*entities.DigestPreference, error) {
+	preference, err := entities.NewDigestPreference(cmd.TenantID, cmd.UserID, entities.DigestFrequency(cmd.Frequency), time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.preferences.Save(ctx, preference); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return preference, nil
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// DigestFrequency is how often a user is sent the notifications they got
+type DigestFrequency string
+
+const (
+	DigestImmediate DigestFrequency = "immediate"
+	DigestHourly    DigestFrequency = "hourly"
+	DigestDaily     DigestFrequency = "daily"
+)
+
+// DailyDigestHour is the hour of the day, in the zone of the user, daily digests are sent at
+const DailyDigestHour = 7
+
+// DigestPreference is how often a user is sent their notifications. Users without one get every
+// notification on its own.
+type DigestPreference struct {
+	TenantID  string          `validate:"required"`
+	UserID    string          `validate:"required,max=255"`
+	Frequency DigestFrequency `validate:"required,oneof=immediate hourly daily"`
+	UpdatedAt time.Time
+}
+
+// DefaultDigestPreference returns the preference of a user that did not pick one
+func DefaultDigestPreference(tenantID string, userID string) *DigestPreference {
+	return &DigestPreference{
+		TenantID:  tenantID,
+		UserID:    userID,
+		Frequency: DigestImmediate,
+	}
+}
+
+func NewDigestPreference(tenantID string, userID string, frequency DigestFrequency, now time.Time) (*DigestPreference, error) {
+	preference := &DigestPreference{
+		TenantID:  tenantID,
+		UserID:    userID,
+		Frequency: frequency,
+		UpdatedAt: now,
+	}
+
+	if err := validate.Struct(preference); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("DigestPreference domain model instance not valid", errorMap, err)
+	}
+
+	return preference, nil
+}
+
+// IsDefault reports whether the user never picked a frequency
+func (p *DigestPreference) IsDefault() bool {
+	return p.UpdatedAt.IsZero()
+}
+
+// DueAt returns when a notification the user got at receivedAt is sent: right away, at the next
+// full hour, or at the next DailyDigestHour. Windows follow the wall clock of loc, so a daily
+// digest goes out in the morning of the user.
+func (p *DigestPreference) DueAt(receivedAt time.Time, loc *time.Location) time.Time {
+	local := receivedAt.In(loc)
+	switch p.Frequency {
+	case DigestHourly:
+		return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, loc).Add(time.Hour).UTC()
+	case DigestDaily:
+		due := time.Date(local.Year(), local.Month(), local.Day(), DailyDigestHour, 0, 0, 0, loc)
+		if !due.After(local) {
+			due = time.Date(local.Year(), local.Month(), local.Day()+1, DailyDigestHour, 0, 0, 0, loc)
+		}
+		return due.UTC()
+	default:
+		return receivedAt.UTC()
+	}
+}
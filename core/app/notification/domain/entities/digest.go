@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Digest is the notifications a recipient is sent at once, a single one for immediate recipients
+type Digest struct {
+	ID            string
+	TenantID      string
+	RecipientID   string
+	Frequency     DigestFrequency
+	Notifications []*Notification
+	DueAt         time.Time
+}
+
+// NewDigests coalesces the due notifications into one digest per recipient and window. Notifications
+// of immediate recipients are not coalesced, each one is a digest of its own.
+func NewDigests(notifications []*Notification) []*Digest {
+	type window struct {
+		tenantID    string
+		recipientID string
+		frequency   DigestFrequency
+		dueAt       time.Time
+	}
+
+	var digests []*Digest
+	windows := make(map[window]*Digest)
+	for _, notification := range notifications {
+		key := window{notification.TenantID, notification.RecipientID, notification.Frequency, notification.DueAt}
+		digest, ok := windows[key]
+		if !ok || notification.Frequency == DigestImmediate {
+			digest = &Digest{
+				ID:          uuid.NewString(),
+				TenantID:    notification.TenantID,
+				RecipientID: notification.RecipientID,
+				Frequency:   notification.Frequency,
+				DueAt:       notification.DueAt,
+			}
+			windows[key] = digest
+			digests = append(digests, digest)
+		}
+		digest.Notifications = append(digest.Notifications, notification)
+	}
+	return digests
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+)
+
+// notificationRecipients are the outbox events users are notified of and the payload keys naming
+// who is notified. Events not listed are not notifications.
+var notificationRecipients = map[string][]string{
+	events.EnrollmentNotificationQueued:       {"student_id"},
+	events.AppointmentReminderDue:             {"teacher_id", "student_id"},
+	events.IncidentGuardianNotificationQueued: {"guardian_id"},
+	events.FormDistributed:                    {"recipient_id"},
+	events.PaymentFailed:                      {"recipient_id"},
+	events.FileRejected:                       {"uploaded_by"},
+	events.ReportCardReady:                    {"guardian_id"},
+}
+
+// Notification is a notification event of the outbox for one of its recipients, it is held until
+// the digest of the recipient is due
+type Notification struct {
+	TenantID    string
+	RecipientID string
+	// Position is the outbox position of the event, with the recipient it identifies the notification
+	Position   int64
+	Type       string
+	Payload    map[string]any
+	Frequency  DigestFrequency
+	OccurredAt time.Time
+	DueAt      time.Time
+}
+
+// NotificationsOf returns a notification for every recipient of the event, none when the event is
+// not a notification
+func NotificationsOf(event events.Event) []*Notification {
+	var notifications []*Notification
+	for _, key := range notificationRecipients[event.Type] {
+		recipientID := event.String(key)
+		if recipientID == "" {
+			continue
+		}
+		notifications = append(notifications, &Notification{
+			TenantID:    event.TenantID,
+			RecipientID: recipientID,
+			Position:    event.Position,
+			Type:        event.Type,
+			Payload:     event.Payload,
+			OccurredAt:  event.OccurredAt,
+		})
+	}
+	return notifications
+}
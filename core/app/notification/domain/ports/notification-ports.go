@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/notification/domain/entities"
+)
+
+type DigestPreferenceRepository interface {
+	// Find returns nil when the user did not pick a frequency
+	Find(ctx context.Context, tenantID string, userID string) (*entities.DigestPreference, error)
+	// Save creates or replaces the preference
+	Save(ctx context.Context, preference *entities.DigestPreference) error
+}
+
+// RecipientZones tells the zone the wall clock of a recipient is in
+type RecipientZones interface {
+	// LocationOf returns the zone of the user, the one of the tenant when they did not pick one
+	LocationOf(ctx context.Context, tenantID string, userID string) (*time.Location, error)
+}
+
+// NotificationBatcher holds notifications until they are due and sends them in digests
+type NotificationBatcher interface {
+	// Hold keeps the notification, holding one already held or sent is a no-op
+	Hold(ctx context.Context, notification *entities.Notification) error
+	// ListDue returns every notification due by now of up to limit recipients, ordered by recipient,
+	// due time and position so a recipient is never split across calls
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*entities.Notification, error)
+	// Send queues the digests for delivery and marks their notifications sent, in one transaction.
+	// Sent notifications are kept for a while so the ones held again after a crash are not sent twice.
+	Send(ctx context.Context, digests []*entities.Digest, now time.Time) error
+}
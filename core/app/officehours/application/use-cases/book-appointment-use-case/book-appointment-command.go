@@ -0,0 +1,32 @@
+package book_appointment_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type BookAppointmentCommand struct {
+	TenantID  string `validate:"required"`
+	SlotID    string `validate:"required,uuid"`
+	StudentID string `validate:"required"`
+	Topic     string `validate:"max=500"`
+}
+
+func NewBookAppointmentCommand(tenantID string, slotID string, studentID string, topic string) (*BookAppointmentCommand, error) {
+	command := &BookAppointmentCommand{
+		TenantID:  tenantID,
+		SlotID:    slotID,
+		StudentID: studentID,
+		Topic:     topic,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
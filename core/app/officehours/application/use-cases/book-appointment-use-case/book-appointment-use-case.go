@@ -0,0 +1,72 @@
+package book_appointment_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	officeHoursErrors "github.com/nahualventure/class-backend/core/app/officehours/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type BookAppointmentUseCase struct {
+	book ports.AppointmentBook
+}
+
+func NewBookAppointmentUseCase(book ports.AppointmentBook) *BookAppointmentUseCase {
+	return &BookAppointmentUseCase{
+		book: book,
+	}
+}
+
+// Execute books the student into the slot while the slot is locked, so the capacity holds under
+// concurrent bookings. Students cannot hold two appointments at the same time.
+func (uc *BookAppointmentUseCase) Execute(ctx context.Context, cmd *BookAppointmentCommand) (*entities.Appointment, error) {
+	now := time.Now().UTC()
+
+	var appointment *entities.Appointment
+	err := uc.book.WithSlotLocked(ctx, cmd.TenantID, cmd.SlotID, func(ledger ports.AppointmentLedger, slot *entities.AvailabilitySlot) error {
+		if slot == nil {
+			return officeHoursErrors.NewSlotNotFoundError(cmd.SlotID)
+		}
+		if !slot.IsBookable(now) || slot.TeacherID == cmd.StudentID {
+			return officeHoursErrors.NewSlotNotBookableError(slot.ID)
+		}
+
+		overlapping, err := ledger.ListStudentAppointments(ctx, cmd.StudentID, slot.StartsAt, slot.EndsAt)
+		if err != nil {
+			return errors.PropagateError(err)
+		}
+		for _, existing := range overlapping {
+			if existing.SlotID == slot.ID {
+				return officeHoursErrors.NewAppointmentAlreadyBookedError(slot.ID, existing.ID)
+			}
+		}
+		if len(overlapping) > 0 {
+			return officeHoursErrors.NewAppointmentConflictError(overlapping[0].ID)
+		}
+
+		booked, err := ledger.CountBooked(ctx)
+		if err != nil {
+			return errors.PropagateError(err)
+		}
+		if booked >= slot.Capacity {
+			return officeHoursErrors.NewSlotFullError(slot.ID, slot.Capacity)
+		}
+
+		appointment, err = entities.BookAppointment(uuid.New().String(), slot, cmd.StudentID, cmd.Topic, now)
+		if err != nil {
+			return errors.PropagateError(err)
+		}
+
+		return ledger.Create(ctx, appointment)
+	})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return appointment, nil
+}
@@ -0,0 +1,30 @@
+package cancel_appointment_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CancelAppointmentCommand struct {
+	TenantID      string `validate:"required"`
+	AppointmentID string `validate:"required,uuid"`
+	UserID        string `validate:"required"`
+}
+
+func NewCancelAppointmentCommand(tenantID string, appointmentID string, userID string) (*CancelAppointmentCommand, error) {
+	command := &CancelAppointmentCommand{
+		TenantID:      tenantID,
+		AppointmentID: appointmentID,
+		UserID:        userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,42 @@
+package cancel_appointment_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	officeHoursErrors "github.com/nahualventure/class-backend/core/app/officehours/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type CancelAppointmentUseCase struct {
+	appointmentRepo ports.AppointmentRepository
+}
+
+func NewCancelAppointmentUseCase(appointmentRepo ports.AppointmentRepository) *CancelAppointmentUseCase {
+	return &CancelAppointmentUseCase{
+		appointmentRepo: appointmentRepo,
+	}
+}
+
+// Execute lets either participant cancel the appointment, the seat goes back to the slot
+func (uc *CancelAppointmentUseCase) Execute(ctx context.Context, cmd *CancelAppointmentCommand) (*entities.Appointment, error) {
+	appointment, err := uc.appointmentRepo.FindByID(ctx, cmd.TenantID, cmd.AppointmentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if appointment == nil || !appointment.HasParticipant(cmd.UserID) {
+		return nil, officeHoursErrors.NewAppointmentNotFoundError(cmd.AppointmentID)
+	}
+	if !appointment.IsBooked() {
+		return nil, officeHoursErrors.NewAppointmentNotActiveError(appointment.ID, string(appointment.Status))
+	}
+
+	appointment.Cancel(cmd.UserID, time.Now().UTC())
+	if err := uc.appointmentRepo.Cancel(ctx, appointment); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return appointment, nil
+}
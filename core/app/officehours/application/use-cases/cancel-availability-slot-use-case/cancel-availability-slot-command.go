@@ -0,0 +1,30 @@
+package cancel_availability_slot_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CancelAvailabilitySlotCommand struct {
+	TenantID  string `validate:"required"`
+	TeacherID string `validate:"required"`
+	SlotID    string `validate:"required,uuid"`
+}
+
+func NewCancelAvailabilitySlotCommand(tenantID string, teacherID string, slotID string) (*CancelAvailabilitySlotCommand, error) {
+	command := &CancelAvailabilitySlotCommand{
+		TenantID:  tenantID,
+		TeacherID: teacherID,
+		SlotID:    slotID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,44 @@
+package cancel_availability_slot_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	officeHoursErrors "github.com/nahualventure/class-backend/core/app/officehours/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type CancelAvailabilitySlotUseCase struct {
+	slotRepo ports.SlotRepository
+}
+
+func NewCancelAvailabilitySlotUseCase(slotRepo ports.SlotRepository) *CancelAvailabilitySlotUseCase {
+	return &CancelAvailabilitySlotUseCase{
+		slotRepo: slotRepo,
+	}
+}
+
+// Execute cancels a slot of the teacher and every appointment booked in it, the students are
+// notified through the cancellation events. Canceling a canceled slot changes nothing.
+func (uc *CancelAvailabilitySlotUseCase) Execute(ctx context.Context, cmd *CancelAvailabilitySlotCommand) (*entities.AvailabilitySlot, []*entities.Appointment, error) {
+	slot, err := uc.slotRepo.FindByID(ctx, cmd.TenantID, cmd.SlotID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if slot == nil || slot.TeacherID != cmd.TeacherID {
+		return nil, nil, officeHoursErrors.NewSlotNotFoundError(cmd.SlotID)
+	}
+	if slot.IsCanceled() {
+		return slot, []*entities.Appointment{}, nil
+	}
+
+	slot.Cancel(time.Now().UTC())
+	canceled, err := uc.slotRepo.Cancel(ctx, slot)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+
+	return slot, canceled, nil
+}
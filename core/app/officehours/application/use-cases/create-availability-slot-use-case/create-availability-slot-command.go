@@ -0,0 +1,39 @@
+package create_availability_slot_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CreateAvailabilitySlotCommand struct {
+	TenantID  string    `validate:"required"`
+	TeacherID string    `validate:"required"`
+	StartsAt  time.Time `validate:"required"`
+	EndsAt    time.Time `validate:"required,gtfield=StartsAt"`
+	Capacity  int       `validate:"min=1,max=20"`
+	Location  string    `validate:"max=255"`
+}
+
+func NewCreateAvailabilitySlotCommand(tenantID string, teacherID string, startsAt time.Time, endsAt time.Time, capacity int,
+	location string) (*CreateAvailabilitySlotCommand, error) {
+	command := &CreateAvailabilitySlotCommand{
+		TenantID:  tenantID,
+		TeacherID: teacherID,
+		StartsAt:  startsAt.UTC(),
+		EndsAt:    endsAt.UTC(),
+		Capacity:  capacity,
+		Location:  location,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
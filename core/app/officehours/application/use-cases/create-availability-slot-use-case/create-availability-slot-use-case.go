@@ -0,0 +1,54 @@
+package create_availability_slot_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	officeHoursErrors "github.com/nahualventure/class-backend/core/app/officehours/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type CreateAvailabilitySlotUseCase struct {
+	slotRepo ports.SlotRepository
+}
+
+func NewCreateAvailabilitySlotUseCase(slotRepo ports.SlotRepository) *CreateAvailabilitySlotUseCase {
+	return &CreateAvailabilitySlotUseCase{
+		slotRepo: slotRepo,
+	}
+}
+
+// Execute opens a new slot for the teacher, slots of one teacher may touch but not overlap
+func (uc *CreateAvailabilitySlotUseCase) Execute(ctx context.Context, cmd *CreateAvailabilitySlotCommand) (*entities.AvailabilitySlot, error) {
+	now := time.Now().UTC()
+	if !cmd.StartsAt.After(now) {
+		return nil, errors.NewValidationError("Invalid availability slot",
+			map[string]any{"starts_at": "slots must start in the future"}, nil)
+	}
+
+	slot, err := entities.NewAvailabilitySlot(uuid.New().String(), cmd.TenantID, cmd.TeacherID, cmd.StartsAt, cmd.EndsAt,
+		cmd.Capacity, cmd.Location, nil, now)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	existing, err := uc.slotRepo.ListByTeacher(ctx, cmd.TenantID, cmd.TeacherID, slot.StartsAt, slot.EndsAt)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	for _, other := range existing {
+		if other.Slot.Overlaps(slot.StartsAt, slot.EndsAt) {
+			return nil, officeHoursErrors.NewSlotOverlapError(other.Slot.ID)
+		}
+	}
+
+	if err := uc.slotRepo.Create(ctx, slot); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return slot, nil
+}
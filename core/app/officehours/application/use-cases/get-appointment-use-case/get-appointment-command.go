@@ -0,0 +1,30 @@
+package get_appointment_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetAppointmentCommand struct {
+	TenantID      string `validate:"required"`
+	AppointmentID string `validate:"required,uuid"`
+	UserID        string `validate:"required"`
+}
+
+func NewGetAppointmentCommand(tenantID string, appointmentID string, userID string) (*GetAppointmentCommand, error) {
+	command := &GetAppointmentCommand{
+		TenantID:      tenantID,
+		AppointmentID: appointmentID,
+		UserID:        userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,33 @@
+package get_appointment_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	officeHoursErrors "github.com/nahualventure/class-backend/core/app/officehours/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetAppointmentUseCase struct {
+	appointmentRepo ports.AppointmentRepository
+}
+
+func NewGetAppointmentUseCase(appointmentRepo ports.AppointmentRepository) *GetAppointmentUseCase {
+	return &GetAppointmentUseCase{
+		appointmentRepo: appointmentRepo,
+	}
+}
+
+// Execute returns the appointment to its student or teacher only
+func (uc *GetAppointmentUseCase) Execute(ctx context.Context, cmd *GetAppointmentCommand) (*entities.Appointment, error) {
+	appointment, err := uc.appointmentRepo.FindByID(ctx, cmd.TenantID, cmd.AppointmentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if appointment == nil || !appointment.HasParticipant(cmd.UserID) {
+		return nil, officeHoursErrors.NewAppointmentNotFoundError(cmd.AppointmentID)
+	}
+
+	return appointment, nil
+}
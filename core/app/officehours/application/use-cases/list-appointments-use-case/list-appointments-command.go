@@ -0,0 +1,28 @@
+package list_appointments_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListAppointmentsCommand struct {
+	TenantID string `validate:"required"`
+	UserID   string `validate:"required"`
+}
+
+func NewListAppointmentsCommand(tenantID string, userID string) (*ListAppointmentsCommand, error) {
+	command := &ListAppointmentsCommand{
+		TenantID: tenantID,
+		UserID:   userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
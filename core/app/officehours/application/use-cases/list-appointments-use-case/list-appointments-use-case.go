@@ -0,0 +1,30 @@
+package list_appointments_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListAppointmentsUseCase struct {
+	appointmentRepo ports.AppointmentRepository
+}
+
+func NewListAppointmentsUseCase(appointmentRepo ports.AppointmentRepository) *ListAppointmentsUseCase {
+	return &ListAppointmentsUseCase{
+		appointmentRepo: appointmentRepo,
+	}
+}
+
+// Execute lists the upcoming and ongoing appointments of the user, as student or as teacher
+func (uc *ListAppointmentsUseCase) Execute(ctx context.Context, cmd *ListAppointmentsCommand) ([]*entities.Appointment, error) {
+	appointments, err := uc.appointmentRepo.ListForUser(ctx, cmd.TenantID, cmd.UserID, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return appointments, nil
+}
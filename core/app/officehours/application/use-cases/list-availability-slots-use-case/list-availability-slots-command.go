@@ -0,0 +1,53 @@
+package list_availability_slots_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// DefaultWindow is listed when no end is given
+	DefaultWindow = 14 * 24 * time.Hour
+	MaxWindow     = 62 * 24 * time.Hour
+)
+
+var validate = validator.New()
+
+type ListAvailabilitySlotsCommand struct {
+	TenantID  string    `validate:"required"`
+	TeacherID string    `validate:"required"`
+	From      time.Time `validate:"required"`
+	To        time.Time `validate:"required,gtfield=From"`
+}
+
+// NewListAvailabilitySlotsCommand lists from now when from is zero and DefaultWindow past from when
+// to is zero
+func NewListAvailabilitySlotsCommand(tenantID string, teacherID string, from time.Time, to time.Time) (*ListAvailabilitySlotsCommand, error) {
+	if from.IsZero() {
+		from = time.Now()
+	}
+	if to.IsZero() {
+		to = from.Add(DefaultWindow)
+	}
+
+	command := &ListAvailabilitySlotsCommand{
+		TenantID:  tenantID,
+		TeacherID: teacherID,
+		From:      from.UTC(),
+		To:        to.UTC(),
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if command.To.Sub(command.From) > MaxWindow {
+		return nil, errors.NewValidationError("Invalid availability window",
+			map[string]any{"to": "at most " + MaxWindow.String() + " can be listed at once"}, nil)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,28 @@
+package list_availability_slots_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListAvailabilitySlotsUseCase struct {
+	slotRepo ports.SlotRepository
+}
+
+func NewListAvailabilitySlotsUseCase(slotRepo ports.SlotRepository) *ListAvailabilitySlotsUseCase {
+	return &ListAvailabilitySlotsUseCase{
+		slotRepo: slotRepo,
+	}
+}
+
+func (uc *ListAvailabilitySlotsUseCase) Execute(ctx context.Context, cmd *ListAvailabilitySlotsCommand) ([]entities.SlotAvailability, error) {
+	slots, err := uc.slotRepo.ListByTeacher(ctx, cmd.TenantID, cmd.TeacherID, cmd.From, cmd.To)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return slots, nil
+}
@@ -0,0 +1,42 @@
+package send_appointment_reminders_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// BatchSize bounds the appointments reminded per transaction
+const BatchSize = 100
+
+type SendAppointmentRemindersUseCase struct {
+	appointmentRepo ports.AppointmentRepository
+	lead            time.Duration
+}
+
+func NewSendAppointmentRemindersUseCase(appointmentRepo ports.AppointmentRepository, lead time.Duration) *SendAppointmentRemindersUseCase {
+	return &SendAppointmentRemindersUseCase{
+		appointmentRepo: appointmentRepo,
+		lead:            lead,
+	}
+}
+
+// Execute queues a reminder notification for every booked appointment starting within the lead
+// time, each appointment is reminded once. It returns how many reminders were queued.
+func (uc *SendAppointmentRemindersUseCase) Execute(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	queued := 0
+	for {
+		reminded, err := uc.appointmentRepo.QueueDueReminders(ctx, now, now.Add(uc.lead), BatchSize)
+		if err != nil {
+			return queued, errors.PropagateError(err)
+		}
+		queued += len(reminded)
+		if len(reminded) < BatchSize {
+			return queued, nil
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultReminderLead is how long before an appointment its participants are reminded
+const DefaultReminderLead = time.Hour
+
+type AppointmentStatus string
+
+const (
+	AppointmentStatusBooked   AppointmentStatus = "booked"
+	AppointmentStatusCanceled AppointmentStatus = "canceled"
+)
+
+// Appointment is the booking of one student in an availability slot. The slot times and location
+// are copied so invites and reminders do not need the slot.
+type Appointment struct {
+	ID             string            `validate:"required,uuid4"`
+	TenantID       string            `validate:"required"`
+	SlotID         string            `validate:"required,uuid"`
+	TeacherID      string            `validate:"required"`
+	StudentID      string            `validate:"required,nefield=TeacherID"`
+	StartsAt       time.Time         `validate:"required"`
+	EndsAt         time.Time         `validate:"required,gtfield=StartsAt"`
+	Location       string            `validate:"max=255"`
+	Topic          string            `validate:"max=500"`
+	Status         AppointmentStatus `validate:"required,oneof=booked canceled"`
+	ReminderSentAt *time.Time
+	CanceledAt     *time.Time
+	CanceledBy     string
+	CreatedAt      time.Time `validate:"required"`
+}
+
+func NewAppointment(id string, tenantID string, slotID string, teacherID string, studentID string, startsAt time.Time,
+	endsAt time.Time, location string, topic string, status AppointmentStatus, reminderSentAt *time.Time,
+	canceledAt *time.Time, canceledBy string, createdAt time.Time) (*Appointment, error) {
+	appointment := &Appointment{
+		ID:             id,
+		TenantID:       tenantID,
+		SlotID:         slotID,
+		TeacherID:      teacherID,
+		StudentID:      studentID,
+		StartsAt:       startsAt,
+		EndsAt:         endsAt,
+		Location:       location,
+		Topic:          topic,
+		Status:         status,
+		ReminderSentAt: reminderSentAt,
+		CanceledAt:     canceledAt,
+		CanceledBy:     canceledBy,
+		CreatedAt:      createdAt,
+	}
+
+	if err := validate.Struct(appointment); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("Appointment domain model instance not valid", errorMap, err)
+	}
+
+	return appointment, nil
+}
+
+// BookAppointment creates the appointment of a student in a slot
+func BookAppointment(id string, slot *AvailabilitySlot, studentID string, topic string, now time.Time) (*Appointment, error) {
+	return NewAppointment(id, slot.TenantID, slot.ID, slot.TeacherID, studentID, slot.StartsAt, slot.EndsAt, slot.Location,
+		topic, AppointmentStatusBooked, nil, nil, "", now)
+}
+
+func (a *Appointment) IsBooked() bool {
+	return a.Status == AppointmentStatusBooked
+}
+
+func (a *Appointment) HasParticipant(userID string) bool {
+	return a.TeacherID == userID || a.StudentID == userID
+}
+
+func (a *Appointment) Overlaps(startsAt time.Time, endsAt time.Time) bool {
+	return Overlap(a.StartsAt, a.EndsAt, startsAt, endsAt)
+}
+
+func (a *Appointment) Cancel(userID string, now time.Time) {
+	if !a.IsBooked() {
+		return
+	}
+	a.Status = AppointmentStatusCanceled
+	a.CanceledAt = &now
+	a.CanceledBy = userID
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+const (
+	MaxSlotCapacity = 20
+	MaxSlotDuration = 4 * time.Hour
+)
+
+// AvailabilitySlot is a block of time a teacher offers for office hours. Up to Capacity students
+// can book it, group slots have a capacity above one.
+type AvailabilitySlot struct {
+	ID         string    `validate:"required,uuid4"`
+	TenantID   string    `validate:"required"`
+	TeacherID  string    `validate:"required"`
+	StartsAt   time.Time `validate:"required"`
+	EndsAt     time.Time `validate:"required,gtfield=StartsAt"`
+	Capacity   int       `validate:"min=1,max=20"`
+	Location   string    `validate:"max=255"`
+	CanceledAt *time.Time
+	CreatedAt  time.Time `validate:"required"`
+}
+
+func NewAvailabilitySlot(id string, tenantID string, teacherID string, startsAt time.Time, endsAt time.Time, capacity int,
+	location string, canceledAt *time.Time, createdAt time.Time) (*AvailabilitySlot, error) {
+	slot := &AvailabilitySlot{
+		ID:         id,
+		TenantID:   tenantID,
+		TeacherID:  teacherID,
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+		Capacity:   capacity,
+		Location:   location,
+		CanceledAt: canceledAt,
+		CreatedAt:  createdAt,
+	}
+
+	if err := validate.Struct(slot); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("AvailabilitySlot domain model instance not valid", errorMap, err)
+	}
+
+	if slot.EndsAt.Sub(slot.StartsAt) > MaxSlotDuration {
+		return nil, appErrors.NewDomainEntityValidationError("AvailabilitySlot domain model instance not valid",
+			map[string]any{"ends_at": "slots last at most " + MaxSlotDuration.String()}, errors.New("slot too long"))
+	}
+
+	return slot, nil
+}
+
+func (s *AvailabilitySlot) IsCanceled() bool {
+	return s.CanceledAt != nil
+}
+
+// IsBookable is true for slots that are not canceled and have not started yet
+func (s *AvailabilitySlot) IsBookable(now time.Time) bool {
+	return !s.IsCanceled() && now.Before(s.StartsAt)
+}
+
+func (s *AvailabilitySlot) Overlaps(startsAt time.Time, endsAt time.Time) bool {
+	return Overlap(s.StartsAt, s.EndsAt, startsAt, endsAt)
+}
+
+func (s *AvailabilitySlot) Cancel(now time.Time) {
+	if s.IsCanceled() {
+		return
+	}
+	s.CanceledAt = &now
+}
+
+// SlotAvailability is a slot with the number of appointments booked in it
+type SlotAvailability struct {
+	Slot   *AvailabilitySlot
+	Booked int
+}
+
+func (a SlotAvailability) Remaining() int {
+	return max(a.Slot.Capacity-a.Booked, 0)
+}
+
+// Overlap reports whether the half open ranges [aStart, aEnd) and [bStart, bEnd) intersect, back to
+// back ranges do not overlap
+func Overlap(aStart time.Time, aEnd time.Time, bStart time.Time, bEnd time.Time) bool {
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
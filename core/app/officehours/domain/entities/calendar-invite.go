@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+const icalTimeFormat = "20060102T150405Z"
+
+// CalendarInvite renders the appointment as an iCalendar (RFC 5545) invite. Canceled appointments
+// render as a CANCEL with a higher sequence so calendar clients remove the event they imported.
+func (a *Appointment) CalendarInvite(now time.Time) string {
+	method, status, sequence := "REQUEST", "CONFIRMED", "0"
+	if !a.IsBooked() {
+		method, status, sequence = "CANCEL", "CANCELLED", "1"
+	}
+
+	summary := "Office hours"
+	if a.Topic != "" {
+		summary += ": " + a.Topic
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//class-backend//office hours//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:" + method,
+		"BEGIN:VEVENT",
+		"UID:" + a.ID + "@class-backend",
+		"SEQUENCE:" + sequence,
+		"STATUS:" + status,
+		"DTSTAMP:" + now.UTC().Format(icalTimeFormat),
+		"DTSTART:" + a.StartsAt.UTC().Format(icalTimeFormat),
+		"DTEND:" + a.EndsAt.UTC().Format(icalTimeFormat),
+		"SUMMARY:" + escapeICalText(summary),
+	}
+	if a.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeICalText(a.Location))
+	}
+	if a.IsBooked() {
+		lines = append(lines,
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			"DESCRIPTION:"+escapeICalText(summary),
+			"TRIGGER:-PT15M",
+			"END:VALARM",
+		)
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var builder strings.Builder
+	for _, line := range lines {
+		builder.WriteString(foldICalLine(line))
+		builder.WriteString("\r\n")
+	}
+	return builder.String()
+}
+
+func escapeICalText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// foldICalLine splits lines longer than 75 octets, continuation lines start with a space. Splits
+// never fall inside a multi byte character.
+func foldICalLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var builder strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			builder.WriteString("\r\n ")
+			width = 1
+		}
+		builder.WriteRune(r)
+		width += size
+	}
+	return builder.String()
+}
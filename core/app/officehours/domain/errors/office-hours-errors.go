@@ -0,0 +1,134 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	SlotNotFoundError             errors2.ErrorCode = "SLOT_NOT_FOUND"
+	SlotOverlapError              errors2.ErrorCode = "SLOT_OVERLAP"
+	SlotNotBookableError          errors2.ErrorCode = "SLOT_NOT_BOOKABLE"
+	SlotFullError                 errors2.ErrorCode = "SLOT_FULL"
+	AppointmentNotFoundError      errors2.ErrorCode = "APPOINTMENT_NOT_FOUND"
+	AppointmentConflictError      errors2.ErrorCode = "APPOINTMENT_CONFLICT"
+	AppointmentNotActiveError     errors2.ErrorCode = "APPOINTMENT_NOT_ACTIVE"
+	AppointmentAlreadyBookedError errors2.ErrorCode = "APPOINTMENT_ALREADY_BOOKED"
+)
+
+func NewSlotNotFoundError(slotID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SlotNotFoundError.String(),
+			Message: "The requested availability slot could not be found",
+			Context: map[string]any{
+				"slot_id": slotID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SlotNotFoundError.String()),
+		},
+	}
+}
+
+func NewSlotOverlapError(slotID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SlotOverlapError.String(),
+			Message: "The slot overlaps another availability slot of the teacher",
+			Context: map[string]any{
+				"overlapping_slot_id": slotID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SlotOverlapError.String()),
+		},
+	}
+}
+
+func NewSlotNotBookableError(slotID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SlotNotBookableError.String(),
+			Message: "The slot was canceled or has already started",
+			Context: map[string]any{
+				"slot_id": slotID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SlotNotBookableError.String()),
+		},
+	}
+}
+
+func NewSlotFullError(slotID string, capacity int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SlotFullError.String(),
+			Message: "There is no room left in the slot",
+			Context: map[string]any{
+				"slot_id":  slotID,
+				"capacity": capacity,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SlotFullError.String()),
+		},
+	}
+}
+
+func NewAppointmentNotFoundError(appointmentID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    AppointmentNotFoundError.String(),
+			Message: "The requested appointment could not be found",
+			Context: map[string]any{
+				"appointment_id": appointmentID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(AppointmentNotFoundError.String()),
+		},
+	}
+}
+
+func NewAppointmentConflictError(appointmentID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    AppointmentConflictError.String(),
+			Message: "The student already has an appointment at that time",
+			Context: map[string]any{
+				"conflicting_appointment_id": appointmentID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(AppointmentConflictError.String()),
+		},
+	}
+}
+
+func NewAppointmentNotActiveError(appointmentID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    AppointmentNotActiveError.String(),
+			Message: "The appointment was already canceled",
+			Context: map[string]any{
+				"appointment_id": appointmentID,
+				"status":         status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(AppointmentNotActiveError.String()),
+		},
+	}
+}
+
+func NewAppointmentAlreadyBookedError(slotID string, appointmentID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    AppointmentAlreadyBookedError.String(),
+			Message: "The student already booked this slot",
+			Context: map[string]any{
+				"slot_id":        slotID,
+				"appointment_id": appointmentID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(AppointmentAlreadyBookedError.String()),
+		},
+	}
+}
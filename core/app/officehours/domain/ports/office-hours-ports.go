@@ -0,0 +1,50 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+)
+
+type SlotRepository interface {
+	Create(ctx context.Context, slot *entities.AvailabilitySlot) error
+	FindByID(ctx context.Context, tenantID string, slotID string) (*entities.AvailabilitySlot, error)
+	// ListByTeacher returns the slots of the teacher overlapping [from, to) with their booked counts,
+	// canceled slots are left out
+	ListByTeacher(ctx context.Context, tenantID string, teacherID string, from time.Time, to time.Time) ([]entities.SlotAvailability, error)
+	// Cancel cancels the slot together with its booked appointments and returns those appointments.
+	// Each canceled appointment also appends a cancellation event to the outbox.
+	Cancel(ctx context.Context, slot *entities.AvailabilitySlot) ([]*entities.Appointment, error)
+}
+
+type AppointmentBook interface {
+	// WithSlotLocked runs fn while holding the lock of the slot, so bookings of one slot are checked
+	// against its capacity one at a time. Writes made through the ledger are committed together when
+	// fn returns nil and discarded otherwise. slot is nil when it does not exist.
+	WithSlotLocked(ctx context.Context, tenantID string, slotID string,
+		fn func(ledger AppointmentLedger, slot *entities.AvailabilitySlot) error) error
+}
+
+// AppointmentLedger reads and writes the appointments of the locked slot
+type AppointmentLedger interface {
+	CountBooked(ctx context.Context) (int, error)
+	// ListStudentAppointments returns the booked appointments of the student overlapping [from, to)
+	// in any slot
+	ListStudentAppointments(ctx context.Context, studentID string, from time.Time, to time.Time) ([]*entities.Appointment, error)
+	// Create also appends the booking event to the outbox
+	Create(ctx context.Context, appointment *entities.Appointment) error
+}
+
+type AppointmentRepository interface {
+	FindByID(ctx context.Context, tenantID string, appointmentID string) (*entities.Appointment, error)
+	// ListForUser returns the appointments ending after from where the user is the student or the
+	// teacher, soonest first
+	ListForUser(ctx context.Context, tenantID string, userID string, from time.Time) ([]*entities.Appointment, error)
+	// Cancel also appends the cancellation event to the outbox
+	Cancel(ctx context.Context, appointment *entities.Appointment) error
+	// QueueDueReminders marks up to limit booked appointments starting in (now, dueBefore] as
+	// reminded and appends a reminder event to the outbox for each, in one transaction. Concurrent
+	// callers never pick the same appointment.
+	QueueDueReminders(ctx context.Context, now time.Time, dueBefore time.Time, limit int) ([]*entities.Appointment, error)
+}
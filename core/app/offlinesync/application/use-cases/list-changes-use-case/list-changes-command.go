@@ -0,0 +1,45 @@
+package list_changes_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	DefaultLimit = 200
+	MaxLimit     = 1000
+)
+
+var validate = validator.New()
+
+type ListChangesCommand struct {
+	TenantID   string              `validate:"required"`
+	ClassID    string              `validate:"required,uuid"`
+	EntityType entities.EntityType `validate:"omitempty,oneof=enrollment attendance grade"`
+	// After is the sequence of the last change the device has, 0 for a device syncing from scratch
+	After int64 `validate:"gte=0"`
+	Limit int   `validate:"min=1,max=1000"`
+}
+
+func NewListChangesCommand(tenantID string, classID string, entityType string, after int64, limit int) (*ListChangesCommand, error) {
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	command := &ListChangesCommand{
+		TenantID:   tenantID,
+		ClassID:    classID,
+		EntityType: entities.EntityType(entityType),
+		After:      after,
+		Limit:      limit,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
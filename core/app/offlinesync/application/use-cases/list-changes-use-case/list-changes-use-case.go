@@ -0,0 +1,39 @@
+package list_changes_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListChangesUseCase struct {
+	feed ports.ChangeFeed
+}
+
+func NewListChangesUseCase(feed ports.ChangeFeed) *ListChangesUseCase {
+	return &ListChangesUseCase{
+		feed: feed,
+	}
+}
+
+// Execute returns the next page of changes of the class after the sequence the device has
+func (uc *ListChangesUseCase) Execute(ctx context.Context, cmd *ListChangesCommand) (*entities.ChangePage, error) {
+	// One more than the page tells whether another page follows
+	changes, err := uc.feed.ListAfter(ctx, cmd.TenantID, cmd.ClassID, cmd.EntityType, cmd.After, cmd.Limit+1)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	page := &entities.ChangePage{Changes: changes, Next: cmd.After}
+	if len(changes) > cmd.Limit {
+		page.Changes = changes[:cmd.Limit]
+		page.HasMore = true
+	}
+	if len(page.Changes) > 0 {
+		page.Next = page.Changes[len(page.Changes)-1].Sequence
+	}
+
+	return page, nil
+}
@@ -0,0 +1,37 @@
+package push_mutations_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const MaxBatchSize = 500
+
+var validate = validator.New()
+
+// PushMutationsCommand only validates the batch envelope, every mutation is validated and reported
+// on its own
+type PushMutationsCommand struct {
+	TenantID string                   `validate:"required"`
+	ClassID  string                   `validate:"required,uuid"`
+	UserID   string                   `validate:"required"`
+	Grades   []entities.GradeMutation `validate:"required,min=1,max=500"`
+}
+
+func NewPushMutationsCommand(tenantID string, classID string, userID string, grades []entities.GradeMutation) (*PushMutationsCommand, error) {
+	command := &PushMutationsCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+		UserID:   userID,
+		Grades:   grades,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
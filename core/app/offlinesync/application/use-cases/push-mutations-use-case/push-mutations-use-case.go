@@ -0,0 +1,137 @@
+package push_mutations_use_case
+
+import (
+	"context"
+	"time"
+
+	gradingEntities "github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	gradingPorts "github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/entities"
+	offlineSyncErrors "github.com/nahualventure/class-backend/core/app/offlinesync/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/bulk"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	cockroach "github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type PushMutationsUseCase struct {
+	gradeRepo gradingPorts.GradeRepository
+	mutations ports.MutationLog
+}
+
+func NewPushMutationsUseCase(gradeRepo gradingPorts.GradeRepository, mutations ports.MutationLog) *PushMutationsUseCase {
+	return &PushMutationsUseCase{
+		gradeRepo: gradeRepo,
+		mutations: mutations,
+	}
+}
+
+// rebase is the version a device knew a grade at and the one an earlier mutation of the same upload
+// left it at, so a second offline change of the grade builds on the first instead of conflicting
+type rebase struct {
+	from int
+	to   int
+}
+
+// Execute applies the grade mutations in the order the device recorded them. A mutation of a grade
+// that changed since its base version fails with SYNC_CONFLICT unless it asks for client_wins or sets
+// the score the server has already. Items that succeed hold the applied mutation.
+func (uc *PushMutationsUseCase) Execute(ctx context.Context, cmd *PushMutationsCommand) (*bulk.Result[*entities.AppliedMutation], error) {
+	results := bulk.NewResult[*entities.AppliedMutation](len(cmd.Grades))
+	rebases := make(map[string]rebase)
+
+	for i, mutation := range cmd.Grades {
+		if err := utils.ValidateStruct(validate, &mutation); err != nil {
+			results.Fail(i, err)
+			continue
+		}
+
+		applied, err := uc.mutations.Find(ctx, cmd.TenantID, mutation.ClientMutationID)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		if applied != nil {
+			results.Succeed(i, entities.MutationAlreadyApplied, applied)
+			continue
+		}
+
+		key := mutation.AssignmentID + "/" + mutation.StudentID
+		if previous, ok := rebases[key]; ok && mutation.BaseVersion != nil && *mutation.BaseVersion == previous.from {
+			mutation.BaseVersion = &previous.to
+		}
+
+		grade, err := uc.apply(ctx, cmd, mutation)
+		// Refused mutations fail their item, the upload stops on infrastructure errors
+		var appErr errors.ApplicationError
+		if err != nil && (!cockroach.As(err, &appErr) || !appErr.IsDomainError()) {
+			return nil, errors.PropagateError(err)
+		}
+		if err != nil {
+			results.Fail(i, err)
+			continue
+		}
+
+		applied = &entities.AppliedMutation{
+			TenantID:         cmd.TenantID,
+			ClientMutationID: mutation.ClientMutationID,
+			ClassID:          cmd.ClassID,
+			GradeID:          grade.ID,
+			Version:          grade.Version,
+			Score:            grade.Score,
+			AppliedBy:        cmd.UserID,
+			AppliedAt:        time.Now().UTC(),
+		}
+		if err := uc.mutations.Record(ctx, applied); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		if mutation.BaseVersion != nil {
+			rebases[key] = rebase{from: *cmd.Grades[i].BaseVersion, to: grade.Version}
+		}
+		results.Succeed(i, entities.MutationApplied, applied)
+	}
+
+	return results, nil
+}
+
+// apply records the score of the mutation and returns the grade it left
+func (uc *PushMutationsUseCase) apply(ctx context.Context, cmd *PushMutationsCommand, mutation entities.GradeMutation) (*gradingEntities.Grade, error) {
+	now := time.Now().UTC()
+
+	grade, err := uc.gradeRepo.FindByStudent(ctx, cmd.TenantID, cmd.ClassID, mutation.AssignmentID, mutation.StudentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if grade == nil {
+		grade, err = gradingEntities.RecordGrade(uuid.New().String(), cmd.TenantID, cmd.ClassID, mutation.AssignmentID,
+			mutation.StudentID, mutation.Score, mutation.TurnedInAt, cmd.UserID, now)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	} else {
+		// A device without the grade did not see the score somebody else recorded
+		stale := mutation.BaseVersion == nil || *mutation.BaseVersion != grade.Version
+		if stale && grade.Score == mutation.Score {
+			return grade, nil
+		}
+		if stale && mutation.Resolution != entities.ResolutionClientWins {
+			baseVersion := 0
+			if mutation.BaseVersion != nil {
+				baseVersion = *mutation.BaseVersion
+			}
+			return nil, offlineSyncErrors.NewGradeSyncConflictError(grade.ID, baseVersion, grade.Version, grade.Score, string(grade.Status))
+		}
+		if err := grade.SetScore(mutation.Score, mutation.TurnedInAt, cmd.UserID, mutation.Reason, now); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	}
+
+	if err := uc.gradeRepo.Save(ctx, grade); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return grade, nil
+}
@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+)
+
+type EntityType string
+
+const (
+	// EntityEnrollment is a student of the roster of the class, its ID is the student ID
+	EntityEnrollment EntityType = "enrollment"
+	// EntityAttendance is the attendance of a student to a session, its ID is "<session_id>:<student_id>"
+	EntityAttendance EntityType = "attendance"
+	// EntityGrade is a released grade, its ID is the grade ID
+	EntityGrade EntityType = "grade"
+)
+
+// EntityTypes are the entities devices sync, the oneof of the list changes command must match
+var EntityTypes = []EntityType{EntityEnrollment, EntityAttendance, EntityGrade}
+
+// Change is the latest state of an entity of a class. Sequence grows with every change of the
+// tenant, so a device asks for the changes after the last sequence it saw. Only the latest change of
+// an entity is kept; removed entities are kept as tombstones so devices delete their copy.
+type Change struct {
+	TenantID   string
+	ClassID    string
+	EntityType EntityType
+	EntityID   string
+	Sequence   int64
+	// Position is the outbox event the change comes from, an older event never overwrites a newer one
+	Position int64
+	Deleted  bool
+	// Data is the entity as devices store it, empty for tombstones
+	Data      map[string]any
+	ChangedAt time.Time
+}
+
+// ChangesFromEvent returns the changes an outbox event makes to synced entities, none for the
+// events devices do not sync or that miss the IDs of the entity. Sequence is assigned when the
+// change is stored.
+func ChangesFromEvent(event events.Event) []*Change {
+	change := func(classID string, entityType EntityType, entityID string, deleted bool, data map[string]any) *Change {
+		return &Change{
+			TenantID:   event.TenantID,
+			ClassID:    classID,
+			EntityType: entityType,
+			EntityID:   entityID,
+			Position:   event.Position,
+			Deleted:    deleted,
+			Data:       data,
+			ChangedAt:  event.OccurredAt,
+		}
+	}
+	classID, studentID := event.String("class_id"), event.String("student_id")
+
+	switch event.Type {
+	case events.EnrollmentAdded, events.EnrollmentRemoved:
+		if classID == "" || studentID == "" {
+			return nil
+		}
+		if event.Type == events.EnrollmentRemoved {
+			return []*Change{change(classID, EntityEnrollment, studentID, true, nil)}
+		}
+		return []*Change{change(classID, EntityEnrollment, studentID, false, map[string]any{"student_id": studentID})}
+
+	case events.SectionStudentsMoved:
+		// The students leave the roster of the source class for the one of the target
+		sourceID, targetID := event.String("source_class_id"), event.String("target_class_id")
+		students, _ := event.Payload["students"].([]any)
+		if sourceID == "" || targetID == "" {
+			return nil
+		}
+		changes := make([]*Change, 0, 2*len(students))
+		for _, student := range students {
+			movedID, _ := student.(string)
+			if movedID == "" {
+				continue
+			}
+			changes = append(changes,
+				change(sourceID, EntityEnrollment, movedID, true, nil),
+				change(targetID, EntityEnrollment, movedID, false, map[string]any{"student_id": movedID}))
+		}
+		return changes
+
+	case events.AttendanceRecorded:
+		sessionID := event.String("session_id")
+		if classID == "" || studentID == "" || sessionID == "" {
+			return nil
+		}
+		return []*Change{change(classID, EntityAttendance, sessionID+":"+studentID, false, map[string]any{
+			"session_id": sessionID,
+			"student_id": studentID,
+			"present":    event.Bool("present"),
+		})}
+
+	case events.GradeRecorded:
+		gradeID := event.String("grade_id")
+		score, hasScore := event.Float("score")
+		// Grades released before grade_id was published cannot be told apart
+		if classID == "" || studentID == "" || gradeID == "" || !hasScore {
+			return nil
+		}
+		version, _ := event.Float("version")
+		return []*Change{change(classID, EntityGrade, gradeID, false, map[string]any{
+			"grade_id":      gradeID,
+			"assignment_id": event.String("assignment_id"),
+			"student_id":    studentID,
+			"score":         score,
+			"version":       int(version),
+		})}
+	}
+
+	return nil
+}
+
+// ChangePage is a page of the change feed of a class. Next is the sequence to ask for the following
+// page with, the one of the last change or the requested one when there were none.
+type ChangePage struct {
+	Changes []*Change
+	Next    int64
+	HasMore bool
+}
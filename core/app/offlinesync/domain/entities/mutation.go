@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/bulk"
+)
+
+// Resolution decides a conflict between an offline change and a change the server got meanwhile
+type Resolution string
+
+const (
+	// ResolutionServerWins keeps the change of the server and reports the conflict, the default
+	ResolutionServerWins Resolution = "server_wins"
+	// ResolutionClientWins applies the offline change over the one of the server
+	ResolutionClientWins Resolution = "client_wins"
+)
+
+const (
+	// MutationApplied is the status of a mutation applied by the upload
+	MutationApplied bulk.Status = "applied"
+	// MutationAlreadyApplied is the status of a mutation an earlier upload applied, devices retry
+	// uploads whose answer they never got
+	MutationAlreadyApplied bulk.Status = "already_applied"
+)
+
+// GradeMutation is a score a teacher recorded offline. BaseVersion is the version of the grade the
+// device had when the score was entered, nil when the device had no grade.
+type GradeMutation struct {
+	// ClientMutationID is generated by the device, uploading the mutation again does not apply it twice
+	ClientMutationID string  `validate:"required,uuid"`
+	AssignmentID     string  `validate:"required,uuid"`
+	StudentID        string  `validate:"required"`
+	Score            float64 `validate:"gte=0,lte=100"`
+	TurnedInAt       *time.Time
+	Reason           string     `validate:"max=1000"`
+	BaseVersion      *int       `validate:"omitempty,gte=0"`
+	Resolution       Resolution `validate:"omitempty,oneof=server_wins client_wins"`
+}
+
+// AppliedMutation is a mutation applied to the server, kept so uploading it again answers the same
+type AppliedMutation struct {
+	TenantID         string
+	ClientMutationID string
+	ClassID          string
+	GradeID          string
+	// Version and Score are those of the grade once the mutation was applied
+	Version   int
+	Score     float64
+	AppliedBy string
+	AppliedAt time.Time
+}
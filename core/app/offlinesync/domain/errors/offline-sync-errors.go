@@ -0,0 +1,33 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// SyncConflictError is an offline change of an entity that changed on the server since the device
+	// last synced it, the context has the state of the server to resolve it with
+	SyncConflictError errors2.ErrorCode = "SYNC_CONFLICT"
+)
+
+func NewGradeSyncConflictError(gradeID string, baseVersion int, serverVersion int, serverScore float64, serverStatus string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SyncConflictError.String(),
+			Message: "The grade changed since the device last synced it",
+			Context: map[string]any{
+				"grade_id":       gradeID,
+				"base_version":   baseVersion,
+				"server_version": serverVersion,
+				"server_score":   serverScore,
+				"server_status":  serverStatus,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SyncConflictError.String()),
+		},
+	}
+}
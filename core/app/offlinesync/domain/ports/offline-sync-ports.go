@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/entities"
+)
+
+type ChangeFeed interface {
+	// ListAfter returns the changes of the class with a sequence greater than after, in sequence
+	// order. Changes of every entity type are returned when entityType is empty.
+	ListAfter(ctx context.Context, tenantID string, classID string, entityType entities.EntityType, after int64, limit int) ([]*entities.Change, error)
+}
+
+type MutationLog interface {
+	// Find returns the mutation when it was applied already, nil otherwise
+	Find(ctx context.Context, tenantID string, clientMutationID string) (*entities.AppliedMutation, error)
+	Record(ctx context.Context, mutation *entities.AppliedMutation) error
+}
@@ -0,0 +1,30 @@
+package get_operation_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetOperationCommand struct {
+	TenantID    string `validate:"required"`
+	UserID      string `validate:"required"`
+	OperationID string `validate:"required,uuid"`
+}
+
+func NewGetOperationCommand(tenantID string, userID string, operationID string) (*GetOperationCommand, error) {
+	command := &GetOperationCommand{
+		TenantID:    tenantID,
+		UserID:      userID,
+		OperationID: operationID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,48 @@
+package get_operation_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/operation/domain/entities"
+	operationErrors "github.com/nahualventure/class-backend/core/app/operation/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/operation/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetOperationUseCase struct {
+	sources     []ports.OperationSource
+	permissions ports.OperationPermissions
+}
+
+func NewGetOperationUseCase(permissions ports.OperationPermissions, sources ...ports.OperationSource) *GetOperationUseCase {
+	return &GetOperationUseCase{
+		sources:     sources,
+		permissions: permissions,
+	}
+}
+
+// Execute looks the operation up in the features in turn, IDs are UUIDs so at most one of them has
+// it. Users see the operations of the features they may read, whoever started them.
+func (uc *GetOperationUseCase) Execute(ctx context.Context, cmd *GetOperationCommand) (*entities.Operation, error) {
+	for _, source := range uc.sources {
+		operation, err := source.Find(ctx, cmd.TenantID, cmd.OperationID)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		if operation == nil {
+			continue
+		}
+
+		allowed, err := uc.permissions.CanView(cmd.TenantID, cmd.UserID, operation.Kind)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		if !allowed {
+			return nil, errors.NewForbiddenError(string(operation.Kind), "view")
+		}
+
+		return operation, nil
+	}
+
+	return nil, operationErrors.NewOperationNotFoundError(cmd.OperationID)
+}
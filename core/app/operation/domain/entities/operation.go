@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"time"
+)
+
+// Kind is the feature an operation was started by, operation IDs are the IDs of its resource
+type Kind string
+
+const (
+	KindReport   Kind = "report"
+	KindImport   Kind = "import"
+	KindPrintout Kind = "printout"
+	KindBackup   Kind = "backup"
+)
+
+type OperationStatus string
+
+const (
+	OperationStatusPending   OperationStatus = "pending"
+	OperationStatusRunning   OperationStatus = "running"
+	OperationStatusSucceeded OperationStatus = "succeeded"
+	OperationStatusFailed    OperationStatus = "failed"
+)
+
+// OperationFailedError is the code of the error of a failed operation, the message says why
+const OperationFailedError = "OPERATION_FAILED"
+
+// Operation is the common view of a job a feature runs asynchronously, clients poll it instead of the
+// status of each feature. Progress is a percentage, features that cannot measure it stay at 0 until
+// the operation finishes.
+type Operation struct {
+	ID       string
+	Kind     Kind
+	Status   OperationStatus
+	Progress int
+	// Result is set once the operation succeeded, Error once it failed
+	Result    *Result
+	Error     *OperationError
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Result points to the resource the operation produced, Details are what the feature adds about it
+type Result struct {
+	// Resource is the path of the resource, DownloadPath the path of its file when it has one
+	Resource     string
+	DownloadPath string
+	Details      map[string]any
+}
+
+// OperationError is why an operation failed, in the shape of the errors of the API
+type OperationError struct {
+	Code    string
+	Message string
+	Context map[string]any
+}
+
+// IsFinished reports whether the operation will not change anymore
+func (o *Operation) IsFinished() bool {
+	return o.Status == OperationStatusSucceeded || o.Status == OperationStatusFailed
+}
+
+// Succeed finishes the operation with result
+func (o *Operation) Succeed(result Result) {
+	o.Status, o.Progress, o.Result = OperationStatusSucceeded, 100, &result
+}
+
+// Fail finishes the operation with the reason the feature recorded
+func (o *Operation) Fail(reason string) {
+	if reason == "" {
+		reason = "The operation failed"
+	}
+	o.Status, o.Progress = OperationStatusFailed, 100
+	o.Error = &OperationError{
+		Code:    OperationFailedError,
+		Message: reason,
+		Context: map[string]any{"operation_id": o.ID, "kind": string(o.Kind)},
+	}
+}
+
+// ProgressOf is the percentage of done out of total, bounded to 0 and 99 as the operation is not
+// finished until its feature says so
+func ProgressOf(done int, total int) int {
+	if total <= 0 || done <= 0 {
+		return 0
+	}
+	return min(99, done*100/total)
+}
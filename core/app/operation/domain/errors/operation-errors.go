@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	OperationNotFoundError errors2.ErrorCode = "OPERATION_NOT_FOUND"
+)
+
+func NewOperationNotFoundError(operationID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    OperationNotFoundError.String(),
+			Message: "The requested operation could not be found",
+			Context: map[string]any{
+				"operation_id": operationID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(OperationNotFoundError.String()),
+		},
+	}
+}
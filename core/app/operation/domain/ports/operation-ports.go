@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/operation/domain/entities"
+)
+
+// OperationSource reads the asynchronous jobs of a feature as operations
+type OperationSource interface {
+	Kind() entities.Kind
+	// Find returns nil when the tenant has no job of the feature with that ID
+	Find(ctx context.Context, tenantID string, operationID string) (*entities.Operation, error)
+}
+
+// OperationPermissions checks that a user may see the resource of an operation, with the permission
+// the feature requires to read it
+type OperationPermissions interface {
+	CanView(tenantID string, userID string, kind entities.Kind) (bool, error)
+}
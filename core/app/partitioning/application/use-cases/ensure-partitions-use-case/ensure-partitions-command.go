@@ -0,0 +1,32 @@
+package ensure_partitions_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type EnsurePartitionsCommand struct {
+	Now         time.Time `validate:"required"`
+	MonthsAhead int       `validate:"min=0,max=24"`
+}
+
+// NewEnsurePartitionsCommand ensures every monthly partitioned table has partitions from the month of now
+// to monthsAhead months after it
+func NewEnsurePartitionsCommand(now time.Time, monthsAhead int) (*EnsurePartitionsCommand, error) {
+	command := &EnsurePartitionsCommand{
+		Now:         now,
+		MonthsAhead: monthsAhead,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,60 @@
+package ensure_partitions_use_case
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nahualventure/class-backend/core/app/partitioning/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/partitioning/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// DefaultMonthsAhead leaves room for the maintenance worker to be down for a while before new rows
+// start landing in the default partition
+const DefaultMonthsAhead = 3
+
+type EnsurePartitionsUseCase struct {
+	manager ports.PartitionManager
+}
+
+func NewEnsurePartitionsUseCase(manager ports.PartitionManager) *EnsurePartitionsUseCase {
+	return &EnsurePartitionsUseCase{
+		manager: manager,
+	}
+}
+
+// Execute creates the missing partitions of the coming months, and of past months that have rows in the
+// default partition so those rows are moved where queries on their month prune to. It returns the
+// partitions it created.
+func (uc *EnsurePartitionsUseCase) Execute(ctx context.Context, cmd *EnsurePartitionsCommand) ([]*entities.MonthlyPartition, error) {
+	created := make([]*entities.MonthlyPartition, 0)
+	for _, table := range entities.MonthlyPartitionedTables {
+		existing, err := uc.manager.ListPartitions(ctx, table)
+		if err != nil {
+			return created, errors.PropagateError(err)
+		}
+
+		months, err := uc.manager.DefaultPartitionMonths(ctx, table)
+		if err != nil {
+			return created, errors.PropagateError(err)
+		}
+		for i := 0; i <= cmd.MonthsAhead; i++ {
+			months = append(months, entities.MonthStart(cmd.Now).AddDate(0, i, 0))
+		}
+
+		for _, month := range months {
+			partition := entities.NewMonthlyPartition(table, month)
+			if slices.Contains(existing, partition.Name()) {
+				continue
+			}
+
+			if err := uc.manager.CreatePartition(ctx, &partition); err != nil {
+				return created, errors.PropagateError(err)
+			}
+			existing = append(existing, partition.Name())
+			created = append(created, &partition)
+		}
+	}
+
+	return created, nil
+}
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// PartitionedTable is a table partitioned by month on Column. Each month has its own partition, rows
+// of months without one land in the default partition until the month partition is created.
+type PartitionedTable struct {
+	Name   string
+	Column string
+}
+
+// MonthlyPartitionedTables are the high-volume tables partitioned by month
+var MonthlyPartitionedTables = []PartitionedTable{
+	{Name: "outbox_events", Column: "occurred_at"},
+	{Name: "tenant_daily_active_users", Column: "day"},
+	{Name: "email_delivery_events", Column: "occurred_at"},
+}
+
+// MonthlyPartition is a partition holding the rows of one month, in UTC
+type MonthlyPartition struct {
+	Table PartitionedTable
+	Month time.Time
+	// MovedRows is the number of rows moved from the default partition when the partition was created
+	MovedRows int
+}
+
+func NewMonthlyPartition(table PartitionedTable, t time.Time) MonthlyPartition {
+	return MonthlyPartition{Table: table, Month: MonthStart(t)}
+}
+
+// Name is the table name of the partition, such as outbox_events_2025_10
+func (p MonthlyPartition) Name() string {
+	return fmt.Sprintf("%s_%04d_%02d", p.Table.Name, p.Month.Year(), int(p.Month.Month()))
+}
+
+// From is the first instant of the partition and To the first instant after it
+func (p MonthlyPartition) From() time.Time {
+	return p.Month
+}
+
+func (p MonthlyPartition) To() time.Time {
+	return p.Month.AddDate(0, 1, 0)
+}
+
+// MonthStart is the first instant of the month of t, in UTC
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/partitioning/domain/entities"
+)
+
+type PartitionManager interface {
+	// ListPartitions returns the table names of the partitions of the table, the default partition included
+	ListPartitions(ctx context.Context, table entities.PartitionedTable) ([]string, error)
+	// DefaultPartitionMonths returns the months that have rows in the default partition of the table
+	DefaultPartitionMonths(ctx context.Context, table entities.PartitionedTable) ([]time.Time, error)
+	// CreatePartition creates the partition and moves the rows of its month out of the default partition
+	// in one transaction, setting MovedRows
+	CreatePartition(ctx context.Context, partition *entities.MonthlyPartition) error
+}
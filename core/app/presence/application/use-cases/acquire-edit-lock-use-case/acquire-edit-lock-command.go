@@ -0,0 +1,32 @@
+package acquire_edit_lock_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type AcquireEditLockCommand struct {
+	TenantID   string `validate:"required"`
+	UserID     string `validate:"required"`
+	EntityType string `validate:"required,oneof=assignment class_template rubric"`
+	EntityID   string `validate:"required,uuid"`
+}
+
+func NewAcquireEditLockCommand(tenantID string, userID string, entityType string, entityID string) (*AcquireEditLockCommand, error) {
+	command := &AcquireEditLockCommand{
+		TenantID:   tenantID,
+		UserID:     userID,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
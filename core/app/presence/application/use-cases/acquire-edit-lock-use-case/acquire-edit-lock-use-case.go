@@ -0,0 +1,51 @@
+package acquire_edit_lock_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	presenceErrors "github.com/nahualventure/class-backend/core/app/presence/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type AcquireEditLockUseCase struct {
+	store ports.EditLockStore
+}
+
+func NewAcquireEditLockUseCase(store ports.EditLockStore) *AcquireEditLockUseCase {
+	return &AcquireEditLockUseCase{
+		store: store,
+	}
+}
+
+// Execute takes or extends the lock of the user by EditLockTTL, every call is a heartbeat. It fails
+// with EDIT_LOCK_HELD naming the holder while another user holds it.
+func (uc *AcquireEditLockUseCase) Execute(ctx context.Context, cmd *AcquireEditLockCommand) (*entities.EditLock, error) {
+	lock, err := uc.store.Acquire(ctx, entities.EditLock{
+		TenantID:   cmd.TenantID,
+		EntityType: cmd.EntityType,
+		EntityID:   cmd.EntityID,
+		UserID:     cmd.UserID,
+		ExpiresAt:  time.Now().Add(entities.EditLockTTL),
+	})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !lock.HeldBy(cmd.UserID) {
+		return nil, presenceErrors.NewEditLockHeldError(lock)
+	}
+
+	return &lock, nil
+}
+
+// Release frees the lock of the user right away, used when the editor is closed or its socket
+// disconnects
+func (uc *AcquireEditLockUseCase) Release(ctx context.Context, cmd *AcquireEditLockCommand) error {
+	if err := uc.store.Release(ctx, cmd.TenantID, cmd.EntityType, cmd.EntityID, cmd.UserID); err != nil {
+		return errors.PropagateError(err)
+	}
+
+	return nil
+}
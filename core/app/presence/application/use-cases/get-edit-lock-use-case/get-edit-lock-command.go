@@ -0,0 +1,30 @@
+package get_edit_lock_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetEditLockCommand struct {
+	TenantID   string `validate:"required"`
+	EntityType string `validate:"required,oneof=assignment class_template rubric"`
+	EntityID   string `validate:"required,uuid"`
+}
+
+func NewGetEditLockCommand(tenantID string, entityType string, entityID string) (*GetEditLockCommand, error) {
+	command := &GetEditLockCommand{
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,30 @@
+package get_edit_lock_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetEditLockUseCase struct {
+	store ports.EditLockStore
+}
+
+func NewGetEditLockUseCase(store ports.EditLockStore) *GetEditLockUseCase {
+	return &GetEditLockUseCase{
+		store: store,
+	}
+}
+
+// Execute returns the lock of the entity, nil when nobody is editing it
+func (uc *GetEditLockUseCase) Execute(ctx context.Context, cmd *GetEditLockCommand) (*entities.EditLock, error) {
+	lock, err := uc.store.Get(ctx, cmd.TenantID, cmd.EntityType, cmd.EntityID, time.Now())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return lock, nil
+}
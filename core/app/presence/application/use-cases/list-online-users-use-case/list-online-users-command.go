@@ -0,0 +1,28 @@
+package list_online_users_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListOnlineUsersCommand struct {
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"omitempty,uuid"`
+}
+
+func NewListOnlineUsersCommand(tenantID string, classID string) (*ListOnlineUsersCommand, error) {
+	command := &ListOnlineUsersCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,29 @@
+package list_online_users_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListOnlineUsersUseCase struct {
+	store ports.PresenceStore
+}
+
+func NewListOnlineUsersUseCase(store ports.PresenceStore) *ListOnlineUsersUseCase {
+	return &ListOnlineUsersUseCase{
+		store: store,
+	}
+}
+
+func (uc *ListOnlineUsersUseCase) Execute(ctx context.Context, cmd *ListOnlineUsersCommand) ([]entities.Presence, error) {
+	online, err := uc.store.ListOnline(ctx, cmd.TenantID, cmd.ClassID, time.Now())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return online, nil
+}
@@ -0,0 +1,30 @@
+package record_heartbeat_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RecordHeartbeatCommand struct {
+	TenantID string `validate:"required"`
+	UserID   string `validate:"required"`
+	ClassID  string `validate:"omitempty,uuid"`
+}
+
+func NewRecordHeartbeatCommand(tenantID string, userID string, classID string) (*RecordHeartbeatCommand, error) {
+	command := &RecordHeartbeatCommand{
+		TenantID: tenantID,
+		UserID:   userID,
+		ClassID:  classID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
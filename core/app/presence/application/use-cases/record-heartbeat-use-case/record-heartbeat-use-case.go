@@ -0,0 +1,45 @@
+package record_heartbeat_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type RecordHeartbeatUseCase struct {
+	store ports.PresenceStore
+}
+
+func NewRecordHeartbeatUseCase(store ports.PresenceStore) *RecordHeartbeatUseCase {
+	return &RecordHeartbeatUseCase{
+		store: store,
+	}
+}
+
+// Execute extends the user's presence by PresenceTTL
+func (uc *RecordHeartbeatUseCase) Execute(ctx context.Context, cmd *RecordHeartbeatCommand) (*entities.Presence, error) {
+	presence := entities.Presence{
+		TenantID:  cmd.TenantID,
+		ClassID:   cmd.ClassID,
+		UserID:    cmd.UserID,
+		ExpiresAt: time.Now().Add(entities.PresenceTTL),
+	}
+
+	if err := uc.store.Touch(ctx, presence); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return &presence, nil
+}
+
+// Leave removes the presence right away, used when the client disconnects cleanly
+func (uc *RecordHeartbeatUseCase) Leave(ctx context.Context, cmd *RecordHeartbeatCommand) error {
+	if err := uc.store.Remove(ctx, cmd.TenantID, cmd.ClassID, cmd.UserID); err != nil {
+		return errors.PropagateError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+const (
+	// EditLockTTL is how long a lock is held without heartbeats, editors heartbeat every
+	// HeartbeatInterval like presence
+	EditLockTTL = PresenceTTL
+	// EditLockPollInterval is how often the edit lock WebSocket checks the lock, the delay before
+	// watchers hear it was taken or released
+	EditLockPollInterval = 2 * time.Second
+)
+
+// LockableEntityTypes are the entities teachers edit long enough to warn each other, the oneof of
+// the edit lock commands must match
+var LockableEntityTypes = []string{"assignment", "class_template", "rubric"}
+
+// EditLock is an advisory lock of the user editing an entity. It warns other editors and does not
+// block writes, those still go through the checks of their endpoint.
+type EditLock struct {
+	TenantID   string
+	EntityType string
+	EntityID   string
+	UserID     string
+	ExpiresAt  time.Time
+}
+
+// HeldBy reports whether the user holds the lock
+func (l EditLock) HeldBy(userID string) bool {
+	return l.UserID == userID
+}
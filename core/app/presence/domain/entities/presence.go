@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+const (
+	// HeartbeatInterval is how often connected clients are expected to send a heartbeat
+	HeartbeatInterval = 20 * time.Second
+	// PresenceTTL is how long a user stays online without heartbeats, it tolerates two lost heartbeats
+	PresenceTTL = 3 * HeartbeatInterval
+)
+
+// Presence is an online user. ClassID is empty for tenant wide presence.
+type Presence struct {
+	TenantID  string
+	ClassID   string
+	UserID    string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// EditLockHeldError is returned to a user taking a lock another user holds, with who holds it
+	EditLockHeldError errors2.ErrorCode = "EDIT_LOCK_HELD"
+)
+
+func NewEditLockHeldError(lock entities.EditLock) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    EditLockHeldError.String(),
+			Message: "Currently being edited by another user",
+			Context: map[string]any{
+				"entity_type": lock.EntityType,
+				"entity_id":   lock.EntityID,
+				"locked_by":   lock.UserID,
+				"expires_at":  lock.ExpiresAt,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(EditLockHeldError.String()),
+		},
+	}
+}
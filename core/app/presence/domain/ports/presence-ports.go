@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+)
+
+// PresenceStore keeps online users with an expiry. Entries must be shared by every API instance,
+// a user connected to one instance is online for all of them.
+type PresenceStore interface {
+	// Touch marks the user online in the tenant scope and, when classID is set, in the class scope
+	Touch(ctx context.Context, presence entities.Presence) error
+	Remove(ctx context.Context, tenantID string, classID string, userID string) error
+	// ListOnline returns the unexpired users of the scope, classID empty means the whole tenant
+	ListOnline(ctx context.Context, tenantID string, classID string, now time.Time) ([]entities.Presence, error)
+}
+
+// EditLockStore keeps the holder of each locked entity with an expiry, shared by every API instance
+// like PresenceStore
+type EditLockStore interface {
+	// Acquire takes the lock when it is free or extends it when its user already holds it. It returns
+	// the lock holding the entity afterwards, the one of another user when it was taken.
+	Acquire(ctx context.Context, lock entities.EditLock) (entities.EditLock, error)
+	// Get returns the unexpired lock of the entity, nil when it is free
+	Get(ctx context.Context, tenantID string, entityType string, entityID string, now time.Time) (*entities.EditLock, error)
+	// Release frees the lock when the user holds it, locks of other users are left alone
+	Release(ctx context.Context, tenantID string, entityType string, entityID string, userID string) error
+}
@@ -0,0 +1,110 @@
+package generate_file_previews_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/preview/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/preview/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+)
+
+// BatchSize is how many previews are generated per batch
+const BatchSize = 20
+
+type GenerateFilePreviewsUseCase struct {
+	previews ports.PreviewRepository
+	renderer ports.PreviewRenderer
+	files    storage.FileStorage
+}
+
+func NewGenerateFilePreviewsUseCase(previews ports.PreviewRepository, renderer ports.PreviewRenderer,
+	files storage.FileStorage) *GenerateFilePreviewsUseCase {
+	return &GenerateFilePreviewsUseCase{
+		previews: previews,
+		renderer: renderer,
+		files:    files,
+	}
+}
+
+// Execute generates the pending previews in batches and returns how many it recorded. Files the
+// renderer fails on are recorded as failed and not retried. Files that cannot be read or whose preview
+// cannot be stored stay pending for the next run, the first failure is returned for logging after the
+// rest of the batch is generated.
+func (uc *GenerateFilePreviewsUseCase) Execute(ctx context.Context) (int, error) {
+	generated := 0
+	for {
+		pending, err := uc.previews.ListPending(ctx, BatchSize)
+		if err != nil {
+			return generated, errors.PropagateError(err)
+		}
+
+		var failed error
+		for _, source := range pending {
+			recorded, err := uc.generate(ctx, source)
+			if err != nil {
+				if failed == nil {
+					failed = err
+				}
+				continue
+			}
+			if recorded {
+				generated++
+			}
+		}
+
+		if failed != nil {
+			return generated, failed
+		}
+		if len(pending) < BatchSize {
+			return generated, nil
+		}
+	}
+}
+
+func (uc *GenerateFilePreviewsUseCase) generate(ctx context.Context, source *entities.PreviewSource) (bool, error) {
+	status, err := uc.render(ctx, source)
+	if err != nil {
+		return false, err
+	}
+
+	preview, err := entities.NewFilePreview(source, status, time.Now().UTC())
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+
+	recorded, err := uc.previews.Record(ctx, preview)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+	return recorded, nil
+}
+
+// render stores the preview of the file and returns the status it is recorded with
+func (uc *GenerateFilePreviewsUseCase) render(ctx context.Context, source *entities.PreviewSource) (storage.PreviewStatus, error) {
+	if !uc.renderer.Supports(source.ContentType) {
+		return storage.PreviewUnsupported, nil
+	}
+
+	content, err := uc.files.Get(ctx, source.StorageKey)
+	if err != nil {
+		return "", errors.PropagateError(err)
+	}
+	if content == nil {
+		return storage.PreviewFailed, nil
+	}
+
+	preview, err := uc.renderer.Render(ctx, source.ContentType, content)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", errors.PropagateError(ctx.Err())
+		}
+		return storage.PreviewFailed, nil
+	}
+
+	if err := uc.files.Put(ctx, source.PreviewKey(), preview); err != nil {
+		return "", errors.PropagateError(err)
+	}
+	return storage.PreviewReady, nil
+}
@@ -0,0 +1,63 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// FileKind is the record an uploaded file belongs to
+type FileKind string
+
+const (
+	FileKindResourceVersion FileKind = "resource_version"
+	FileKindAttachment      FileKind = "attachment"
+)
+
+// PreviewSource is a file scanned clean that has no preview yet
+type PreviewSource struct {
+	Kind        FileKind
+	ID          string
+	TenantID    string
+	StorageKey  string
+	ContentType string
+}
+
+// PreviewKey is where the preview of the file is stored
+func (s *PreviewSource) PreviewKey() string {
+	return storage.PreviewKey(s.StorageKey)
+}
+
+// FilePreview is the outcome of the generation of the preview of a file, the preview itself is in the
+// file storage once it is ready
+type FilePreview struct {
+	Source      *PreviewSource        `validate:"required"`
+	Status      storage.PreviewStatus `validate:"required,oneof=ready unsupported failed"`
+	GeneratedAt time.Time             `validate:"required"`
+}
+
+func NewFilePreview(source *PreviewSource, status storage.PreviewStatus, generatedAt time.Time) (*FilePreview, error) {
+	preview := &FilePreview{
+		Source:      source,
+		Status:      status,
+		GeneratedAt: generatedAt,
+	}
+
+	if err := validate.Struct(preview); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("FilePreview domain model instance not valid", errorMap, err)
+	}
+
+	return preview, nil
+}
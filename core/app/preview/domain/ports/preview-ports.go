@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/preview/domain/entities"
+)
+
+// PreviewRepository tracks the previews of the uploaded files of every tenant
+type PreviewRepository interface {
+	// ListPending returns the files scanned clean whose preview was not generated yet, oldest upload
+	// first
+	ListPending(ctx context.Context, limit int) ([]*entities.PreviewSource, error)
+	// Record stores the status of the preview on the file. Returns false when the file was no longer
+	// pending.
+	Record(ctx context.Context, preview *entities.FilePreview) (bool, error)
+}
+
+// PreviewRenderer draws the thumbnail of a file, the first page of documents
+type PreviewRenderer interface {
+	// Supports tells whether files of the content type can be rendered
+	Supports(contentType string) bool
+	// Render returns the PNG preview of the file, it fails on files that are damaged or cannot be read
+	Render(ctx context.Context, contentType string, content []byte) ([]byte, error)
+}
@@ -0,0 +1,28 @@
+package get_printout_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetPrintoutCommand struct {
+	TenantID   string `validate:"required"`
+	PrintoutID string `validate:"required,uuid"`
+}
+
+func NewGetPrintoutCommand(tenantID string, printoutID string) (*GetPrintoutCommand, error) {
+	command := &GetPrintoutCommand{
+		TenantID:   tenantID,
+		PrintoutID: printoutID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
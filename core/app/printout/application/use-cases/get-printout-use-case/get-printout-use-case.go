@@ -0,0 +1,61 @@
+package get_printout_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+	printoutErrors "github.com/nahualventure/class-backend/core/app/printout/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/printout/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetPrintoutUseCase struct {
+	printoutRepo ports.PrintoutRepository
+}
+
+func NewGetPrintoutUseCase(printoutRepo ports.PrintoutRepository) *GetPrintoutUseCase {
+	return &GetPrintoutUseCase{
+		printoutRepo: printoutRepo,
+	}
+}
+
+func (uc *GetPrintoutUseCase) Execute(ctx context.Context, cmd *GetPrintoutCommand) (*entities.Printout, error) {
+	printout, err := uc.printoutRepo.FindByID(ctx, cmd.TenantID, cmd.PrintoutID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if printout == nil {
+		return nil, printoutErrors.NewPrintoutNotFoundError(cmd.PrintoutID)
+	}
+
+	return printout, nil
+}
+
+// Download returns the file of a ready, unexpired printout
+func (uc *GetPrintoutUseCase) Download(ctx context.Context, cmd *GetPrintoutCommand) (*entities.Artifact, error) {
+	printout, err := uc.Execute(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if printout.Status != entities.PrintoutStatusReady {
+		return nil, printoutErrors.NewPrintoutNotReadyError(printout.ID, string(printout.Status))
+	}
+
+	if printout.IsExpired(time.Now()) {
+		return nil, printoutErrors.NewPrintoutExpiredError(printout.ID, *printout.ExpiresAt)
+	}
+
+	artifact, err := uc.printoutRepo.FindArtifact(ctx, cmd.TenantID, cmd.PrintoutID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if artifact == nil {
+		return nil, printoutErrors.NewPrintoutNotFoundError(cmd.PrintoutID)
+	}
+
+	return artifact, nil
+}
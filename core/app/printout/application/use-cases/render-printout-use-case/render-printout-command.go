@@ -0,0 +1,36 @@
+package render_printout_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RenderPrintoutCommand struct {
+	TenantID string        `validate:"required"`
+	Kind     entities.Kind `validate:"required,oneof=roster weekly_schedule attendance_sheet"`
+	ClassID  string        `validate:"required,uuid"`
+	// WeekOf is any day of the week schedules and attendance sheets cover, rosters ignore it
+	WeekOf time.Time `validate:"required"`
+}
+
+func NewRenderPrintoutCommand(tenantID string, kind string, classID string, weekOf time.Time) (*RenderPrintoutCommand, error) {
+	command := &RenderPrintoutCommand{
+		TenantID: tenantID,
+		Kind:     entities.Kind(kind),
+		ClassID:  classID,
+		WeekOf:   entities.WeekOf(weekOf),
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,141 @@
+package render_printout_use_case
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+	printoutErrors "github.com/nahualventure/class-backend/core/app/printout/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/printout/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+const (
+	// DefaultArtifactTTL is how long generated printouts can be downloaded
+	DefaultArtifactTTL = 7 * 24 * time.Hour
+	// StaleAfter is how long a printout can stay running before another worker reclaims it
+	StaleAfter = 15 * time.Minute
+)
+
+// RenderPrintoutUseCase renders printouts right away for downloads and generates the requested ones
+// for the worker, both the same way
+type RenderPrintoutUseCase struct {
+	queue       ports.PrintoutJobQueue
+	source      ports.PrintoutSource
+	renderer    ports.PrintoutRenderer
+	artifactTTL time.Duration
+}
+
+func NewRenderPrintoutUseCase(queue ports.PrintoutJobQueue, source ports.PrintoutSource, renderer ports.PrintoutRenderer,
+	artifactTTL time.Duration) *RenderPrintoutUseCase {
+	return &RenderPrintoutUseCase{
+		queue:       queue,
+		source:      source,
+		renderer:    renderer,
+		artifactTTL: artifactTTL,
+	}
+}
+
+// Execute renders the printout for download. Printouts longer than entities.SyncRowLimit rows fail
+// with PRINTOUT_TOO_LARGE before rendering, they are requested for the worker instead.
+func (uc *RenderPrintoutUseCase) Execute(ctx context.Context, cmd *RenderPrintoutCommand) (*entities.Artifact, error) {
+	document, err := uc.compose(ctx, cmd.TenantID, cmd.Kind, cmd.ClassID, cmd.WeekOf, time.Now())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if len(document.Rows) > entities.SyncRowLimit {
+		return nil, printoutErrors.NewPrintoutTooLargeError(string(cmd.Kind), len(document.Rows), entities.SyncRowLimit)
+	}
+
+	content, err := uc.renderer.Render(ctx, document)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return &entities.Artifact{
+		FileName:    entities.FileName(cmd.Kind, cmd.ClassID, cmd.WeekOf),
+		ContentType: "application/pdf",
+		Content:     content,
+	}, nil
+}
+
+// GenerateNext generates the next pending printout. It returns false when the queue is empty. A
+// printout that fails to generate is marked failed and its error is returned for logging.
+func (uc *RenderPrintoutUseCase) GenerateNext(ctx context.Context) (bool, error) {
+	printout, err := uc.queue.ClaimNextPending(ctx, StaleAfter)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+
+	if printout == nil {
+		return false, nil
+	}
+
+	now := time.Now().UTC()
+	artifact, err := uc.generate(ctx, printout, now)
+	if err != nil {
+		if markErr := uc.queue.MarkFailed(ctx, printout.ID, err.Error()); markErr != nil {
+			return true, errors.PropagateError(markErr)
+		}
+		return true, errors.NewInfrastructureError(fmt.Sprintf("failed to generate printout %s", printout.ID), err)
+	}
+
+	if err := uc.queue.MarkReady(ctx, printout.ID, artifact, now.Add(uc.artifactTTL)); err != nil {
+		return true, errors.PropagateError(err)
+	}
+
+	return true, nil
+}
+
+// PurgeExpired deletes printouts whose file expired and returns how many were deleted
+func (uc *RenderPrintoutUseCase) PurgeExpired(ctx context.Context) (int64, error) {
+	deleted, err := uc.queue.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, errors.PropagateError(err)
+	}
+
+	return deleted, nil
+}
+
+func (uc *RenderPrintoutUseCase) generate(ctx context.Context, printout *entities.Printout, now time.Time) (*entities.Artifact, error) {
+	document, err := uc.compose(ctx, printout.TenantID, printout.Kind, printout.ClassID, printout.WeekOf, now)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := uc.renderer.Render(ctx, document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.Artifact{
+		FileName:    entities.FileName(printout.Kind, printout.ClassID, printout.WeekOf),
+		ContentType: "application/pdf",
+		Content:     content,
+	}, nil
+}
+
+// compose reads only what the kind of printout prints
+func (uc *RenderPrintoutUseCase) compose(ctx context.Context, tenantID string, kind entities.Kind, classID string, weekOf time.Time,
+	now time.Time) (*entities.Document, error) {
+	data := &entities.PrintoutData{}
+	var err error
+
+	if kind.NeedsRoster() {
+		if data.Students, err = uc.source.Roster(ctx, tenantID, classID); err != nil {
+			return nil, err
+		}
+	}
+	if kind.NeedsSchedule() {
+		if data.Meetings, err = uc.source.Meetings(ctx, tenantID, classID); err != nil {
+			return nil, err
+		}
+		if data.DaysOff, err = uc.source.DaysOff(ctx, tenantID, weekOf, weekOf.AddDate(0, 0, 6)); err != nil {
+			return nil, err
+		}
+	}
+
+	return entities.Compose(kind, classID, weekOf, data, now), nil
+}
@@ -0,0 +1,38 @@
+package request_printout_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/printout/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type RequestPrintoutUseCase struct {
+	printoutRepo ports.PrintoutRepository
+}
+
+func NewRequestPrintoutUseCase(printoutRepo ports.PrintoutRepository) *RequestPrintoutUseCase {
+	return &RequestPrintoutUseCase{
+		printoutRepo: printoutRepo,
+	}
+}
+
+// Execute queues the printout, the worker generates it asynchronously
+func (uc *RequestPrintoutUseCase) Execute(ctx context.Context, cmd *RequestPrintoutCommand) (*entities.Printout, error) {
+	now := time.Now().UTC()
+	printout, err := entities.NewPrintout(uuid.New().String(), cmd.TenantID, cmd.Kind, cmd.ClassID, cmd.WeekOf, entities.PrintoutStatusPending, "",
+		cmd.RequestedBy, nil, now, now)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.printoutRepo.Create(ctx, printout); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return printout, nil
+}
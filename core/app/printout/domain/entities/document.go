@@ -0,0 +1,173 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Student is a student of the roster of a class
+type Student struct {
+	ID    string
+	Name  string
+	Email string
+}
+
+// Meeting is a weekly meeting of a class, minutes are counted from midnight in the local time of the
+// school
+type Meeting struct {
+	Weekday     time.Weekday
+	StartMinute int
+	EndMinute   int
+}
+
+// DayOff is a non-instructional day of the school calendar, a civil date at midnight UTC
+type DayOff struct {
+	Date time.Time
+	Name string
+}
+
+// Session is a meeting of a class on a day of the week printed, Cancelled names the day off it falls on
+type Session struct {
+	Date        time.Time
+	StartMinute int
+	EndMinute   int
+	Cancelled   string
+}
+
+// Sessions returns the sessions of the meetings in the week starting on weekOf, ordered by day and
+// start. Sessions on days off are kept and named after the day off.
+func Sessions(weekOf time.Time, meetings []Meeting, daysOff []DayOff) []Session {
+	off := make(map[time.Time]string, len(daysOff))
+	for _, day := range daysOff {
+		off[day.Date] = day.Name
+	}
+
+	var sessions []Session
+	for offset := 0; offset < 7; offset++ {
+		date := weekOf.AddDate(0, 0, offset)
+		for _, meeting := range meetings {
+			if meeting.Weekday != date.Weekday() {
+				continue
+			}
+			sessions = append(sessions, Session{Date: date, StartMinute: meeting.StartMinute, EndMinute: meeting.EndMinute, Cancelled: off[date]})
+		}
+	}
+	return sessions
+}
+
+// Column is a column of the table of a document, Width is its share of the width of the page
+type Column struct {
+	Heading string
+	Width   int
+}
+
+// Document is a printout laid out as a title, a few lines about it and a table, renderers turn it
+// into a file
+type Document struct {
+	Title   string
+	Lines   []string
+	Columns []Column
+	Rows    [][]string
+	// Empty is printed instead of the table when it has no rows
+	Empty  string
+	Footer string
+}
+
+// PrintoutData is what printouts are made of, Students is only read for rosters and attendance sheets
+// and Meetings and DaysOff only for schedules and attendance sheets
+type PrintoutData struct {
+	Students []Student
+	Meetings []Meeting
+	DaysOff  []DayOff
+}
+
+// NeedsRoster tells whether the kind of printout lists the students of the class
+func (k Kind) NeedsRoster() bool {
+	return k == KindRoster || k == KindAttendanceSheet
+}
+
+// NeedsSchedule tells whether the kind of printout lists the sessions of a week
+func (k Kind) NeedsSchedule() bool {
+	return k == KindWeeklySchedule || k == KindAttendanceSheet
+}
+
+// Compose lays out the printout of the kind for the class
+func Compose(kind Kind, classID string, weekOf time.Time, data *PrintoutData, now time.Time) *Document {
+	weekOf = WeekOf(weekOf)
+	week := fmt.Sprintf("Week of %s to %s", weekOf.Format("Mon Jan 2, 2006"), weekOf.AddDate(0, 0, 6).Format("Mon Jan 2, 2006"))
+	document := &Document{
+		Lines:  []string{"Class " + classID},
+		Footer: "Printed on " + now.UTC().Format("January 2, 2006 15:04 MST"),
+	}
+
+	switch kind {
+	case KindRoster:
+		document.Title = "Class roster"
+		document.Lines = append(document.Lines, fmt.Sprintf("%d students", len(data.Students)))
+		document.Columns = []Column{{Heading: "#", Width: 1}, {Heading: "Student", Width: 8}, {Heading: "Email", Width: 8}}
+		for i, student := range data.Students {
+			document.Rows = append(document.Rows, []string{strconv.Itoa(i + 1), studentName(student), student.Email})
+		}
+		document.Empty = "No students are enrolled in the class."
+
+	case KindWeeklySchedule:
+		document.Title = "Weekly schedule"
+		document.Lines = append(document.Lines, week)
+		document.Columns = []Column{{Heading: "Day", Width: 4}, {Heading: "Time", Width: 4}, {Heading: "Notes", Width: 8}}
+		for _, session := range Sessions(weekOf, data.Meetings, data.DaysOff) {
+			notes := ""
+			if session.Cancelled != "" {
+				notes = "Cancelled, " + session.Cancelled
+			}
+			document.Rows = append(document.Rows, []string{session.Date.Format("Mon Jan 2"),
+				clock(session.StartMinute) + " - " + clock(session.EndMinute), notes})
+		}
+		document.Empty = "The class does not meet this week."
+
+	case KindAttendanceSheet:
+		document.Title = "Attendance sheet"
+		document.Lines = append(document.Lines, week)
+		document.Columns = []Column{{Heading: "#", Width: 2}, {Heading: "Student", Width: 12}}
+		var held []Session
+		for _, session := range Sessions(weekOf, data.Meetings, data.DaysOff) {
+			if session.Cancelled == "" {
+				held = append(held, session)
+				document.Columns = append(document.Columns, Column{Heading: session.Date.Format("Mon 2") + " " + clock(session.StartMinute), Width: 5})
+			}
+		}
+		if len(held) == 0 {
+			document.Lines = append(document.Lines, "The class has no sessions this week.")
+		}
+		for i, student := range data.Students {
+			row := []string{strconv.Itoa(i + 1), studentName(student)}
+			for range held {
+				row = append(row, "[   ]")
+			}
+			document.Rows = append(document.Rows, row)
+		}
+		document.Empty = "No students are enrolled in the class."
+	}
+
+	return document
+}
+
+// FileName is the name the printout of the kind is downloaded as
+func FileName(kind Kind, classID string, weekOf time.Time) string {
+	if kind == KindRoster {
+		return fmt.Sprintf("roster-%s.pdf", classID[:8])
+	}
+	return fmt.Sprintf("%s-%s-%s.pdf", strings.ReplaceAll(string(kind), "_", "-"), classID[:8], WeekOf(weekOf).Format("20060102"))
+}
+
+func studentName(student Student) string {
+	if student.Name == "" {
+		return student.ID
+	}
+	return student.Name
+}
+
+func clock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
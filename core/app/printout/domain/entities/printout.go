@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// DateLayout is how the week of a printout is written in requests and responses
+const DateLayout = time.DateOnly
+
+// SyncRowLimit is the largest printout, in table rows, downloaded right away. Longer ones are
+// requested and generated by the worker.
+const SyncRowLimit = 150
+
+type Kind string
+
+const (
+	// KindRoster lists the students enrolled in the class
+	KindRoster Kind = "roster"
+	// KindWeeklySchedule lists the sessions of the class in a week, cancelled ones included
+	KindWeeklySchedule Kind = "weekly_schedule"
+	// KindAttendanceSheet is the roster with a blank box per session of the week, marked by hand
+	KindAttendanceSheet Kind = "attendance_sheet"
+)
+
+// Kinds are the printouts of a class, the oneof of the commands must match
+var Kinds = []Kind{KindRoster, KindWeeklySchedule, KindAttendanceSheet}
+
+type PrintoutStatus string
+
+const (
+	PrintoutStatusPending PrintoutStatus = "pending"
+	PrintoutStatusRunning PrintoutStatus = "running"
+	PrintoutStatusReady   PrintoutStatus = "ready"
+	PrintoutStatusFailed  PrintoutStatus = "failed"
+)
+
+// Printout is a printout of a class requested for asynchronous generation, for classes too large to
+// download it right away. WeekOf is the Monday of the week schedules and attendance sheets cover,
+// a civil date kept at midnight UTC. The generated file is stored apart and only loaded on download.
+type Printout struct {
+	ID            string         `validate:"required,uuid"`
+	TenantID      string         `validate:"required"`
+	Kind          Kind           `validate:"required,oneof=roster weekly_schedule attendance_sheet"`
+	ClassID       string         `validate:"required,uuid"`
+	WeekOf        time.Time      `validate:"required"`
+	Status        PrintoutStatus `validate:"required,oneof=pending running ready failed"`
+	FailureReason string
+	RequestedBy   string `validate:"required"`
+	ExpiresAt     *time.Time
+	CreatedAt     time.Time `validate:"required"`
+	UpdatedAt     time.Time `validate:"required"`
+}
+
+func NewPrintout(id string, tenantID string, kind Kind, classID string, weekOf time.Time, status PrintoutStatus, failureReason string,
+	requestedBy string, expiresAt *time.Time, createdAt time.Time, updatedAt time.Time) (*Printout, error) {
+	printout := &Printout{
+		ID:            id,
+		TenantID:      tenantID,
+		Kind:          kind,
+		ClassID:       classID,
+		WeekOf:        WeekOf(weekOf),
+		Status:        status,
+		FailureReason: failureReason,
+		RequestedBy:   requestedBy,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+	}
+
+	if err := validate.Struct(printout); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("Printout domain model instance not valid", errorMap, err)
+	}
+
+	return printout, nil
+}
+
+func (p *Printout) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && !now.Before(*p.ExpiresAt)
+}
+
+// WeekOf returns the Monday of the week of the civil date of day, at midnight UTC
+func WeekOf(day time.Time) time.Time {
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+}
+
+// Artifact is the generated printout file
+type Artifact struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
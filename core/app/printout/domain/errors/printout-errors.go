@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	PrintoutNotFoundError errors2.ErrorCode = "PRINTOUT_NOT_FOUND"
+	PrintoutNotReadyError errors2.ErrorCode = "PRINTOUT_NOT_READY"
+	PrintoutExpiredError  errors2.ErrorCode = "PRINTOUT_EXPIRED"
+	// PrintoutTooLargeError refuses to render a printout right away when it has more rows than
+	// entities.SyncRowLimit, it is requested for the worker instead
+	PrintoutTooLargeError errors2.ErrorCode = "PRINTOUT_TOO_LARGE"
+)
+
+func NewPrintoutNotFoundError(printoutID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    PrintoutNotFoundError.String(),
+			Message: "The requested printout could not be found",
+			Context: map[string]any{
+				"printout_id": printoutID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(PrintoutNotFoundError.String()),
+		},
+	}
+}
+
+func NewPrintoutNotReadyError(printoutID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    PrintoutNotReadyError.String(),
+			Message: "The printout has not been generated yet",
+			Context: map[string]any{
+				"printout_id": printoutID,
+				"status":      status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(PrintoutNotReadyError.String()),
+		},
+	}
+}
+
+func NewPrintoutExpiredError(printoutID string, expiredAt time.Time) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    PrintoutExpiredError.String(),
+			Message: "The printout file has expired, request the printout again",
+			Context: map[string]any{
+				"printout_id": printoutID,
+				"expired_at":  expiredAt,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(PrintoutExpiredError.String()),
+		},
+	}
+}
+
+func NewPrintoutTooLargeError(kind string, rows int, limit int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    PrintoutTooLargeError.String(),
+			Message: "The printout is too large to download right away, request it with POST /printouts",
+			Context: map[string]any{
+				"kind":  kind,
+				"rows":  rows,
+				"limit": limit,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(PrintoutTooLargeError.String()),
+		},
+	}
+}
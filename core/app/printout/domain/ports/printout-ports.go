@@ -0,0 +1,42 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+)
+
+type PrintoutRepository interface {
+	Create(ctx context.Context, printout *entities.Printout) error
+	// FindByID returns nil when the tenant has no such printout
+	FindByID(ctx context.Context, tenantID string, printoutID string) (*entities.Printout, error)
+	FindArtifact(ctx context.Context, tenantID string, printoutID string) (*entities.Artifact, error)
+	PrintoutJobQueue
+}
+
+// PrintoutJobQueue is the worker side of the repository. ClaimNextPending also reclaims printouts
+// left running by a worker that died more than staleAfter ago; it returns nil when there is nothing
+// to do.
+type PrintoutJobQueue interface {
+	ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.Printout, error)
+	MarkReady(ctx context.Context, printoutID string, artifact *entities.Artifact, expiresAt time.Time) error
+	MarkFailed(ctx context.Context, printoutID string, reason string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// PrintoutSource reads what printouts are made of from the enrollment, user and calendar modules
+type PrintoutSource interface {
+	// Roster returns the students enrolled in the class ordered by name
+	Roster(ctx context.Context, tenantID string, classID string) ([]entities.Student, error)
+	// Meetings returns the weekly meetings of the class ordered by weekday and start
+	Meetings(ctx context.Context, tenantID string, classID string) ([]entities.Meeting, error)
+	// DaysOff returns the non-instructional days of the tenant from one civil date to the other, both
+	// included
+	DaysOff(ctx context.Context, tenantID string, from time.Time, to time.Time) ([]entities.DayOff, error)
+}
+
+// PrintoutRenderer lays out printouts as PDF files
+type PrintoutRenderer interface {
+	Render(ctx context.Context, document *entities.Document) ([]byte, error)
+}
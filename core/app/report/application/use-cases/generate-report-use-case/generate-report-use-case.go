@@ -0,0 +1,98 @@
+package generate_report_use_case
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/report/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+const (
+	DefaultArtifactTTL = 7 * 24 * time.Hour
+	// StaleAfter is how long a report can stay running before another worker reclaims it
+	StaleAfter = 15 * time.Minute
+)
+
+type GenerateReportUseCase struct {
+	queue       ports.ReportJobQueue
+	data        ports.ReportDataSource
+	artifactTTL time.Duration
+}
+
+func NewGenerateReportUseCase(queue ports.ReportJobQueue, data ports.ReportDataSource, artifactTTL time.Duration) *GenerateReportUseCase {
+	return &GenerateReportUseCase{
+		queue:       queue,
+		data:        data,
+		artifactTTL: artifactTTL,
+	}
+}
+
+// Execute generates the next pending report. It returns false when the queue is empty. A report
+// that fails to generate is marked failed and its error is returned for logging.
+func (uc *GenerateReportUseCase) Execute(ctx context.Context) (bool, error) {
+	report, err := uc.queue.ClaimNextPending(ctx, StaleAfter)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+
+	if report == nil {
+		return false, nil
+	}
+
+	now := time.Now().UTC()
+	artifact, err := uc.generate(ctx, report, now)
+	if err != nil {
+		if markErr := uc.queue.MarkFailed(ctx, report.ID, err.Error()); markErr != nil {
+			return true, errors.PropagateError(markErr)
+		}
+		return true, errors.NewInfrastructureError(fmt.Sprintf("failed to generate report %s", report.ID), err)
+	}
+
+	if err := uc.queue.MarkReady(ctx, report.ID, artifact, now.Add(uc.artifactTTL)); err != nil {
+		return true, errors.PropagateError(err)
+	}
+
+	return true, nil
+}
+
+// PurgeExpired deletes reports whose artifact expired and returns how many were deleted
+func (uc *GenerateReportUseCase) PurgeExpired(ctx context.Context) (int64, error) {
+	deleted, err := uc.queue.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, errors.PropagateError(err)
+	}
+
+	return deleted, nil
+}
+
+func (uc *GenerateReportUseCase) generate(ctx context.Context, report *entities.Report, now time.Time) (*entities.Artifact, error) {
+	build, ok := reportBuilders[report.Definition]
+	if !ok {
+		return nil, fmt.Errorf("no builder for report definition %s", report.Definition)
+	}
+
+	header, rows, err := build(ctx, uc.data, report, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return &entities.Artifact{
+		FileName:    fmt.Sprintf("%s-%s.csv", report.Definition, now.Format("20060102")),
+		ContentType: "text/csv",
+		Content:     buffer.Bytes(),
+	}, nil
+}
@@ -0,0 +1,103 @@
+package generate_report_use_case
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/report/domain/ports"
+)
+
+// reportBuilder returns the CSV header and rows of a report definition
+type reportBuilder func(ctx context.Context, data ports.ReportDataSource, report *entities.Report, now time.Time) ([]string, [][]string, error)
+
+var reportBuilders = map[string]reportBuilder{
+	entities.AttendanceSummaryReport: buildAttendanceSummary,
+	entities.GradeDistributionReport: buildGradeDistribution,
+	entities.InactiveUsersReport:     buildInactiveUsers,
+}
+
+func buildAttendanceSummary(ctx context.Context, data ports.ReportDataSource, report *entities.Report, _ time.Time) ([]string, [][]string, error) {
+	classes, err := data.ClassAttendance(ctx, report.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := make([][]string, 0, len(classes))
+	for _, class := range classes {
+		rate := ""
+		if class.AttendanceTotal > 0 {
+			rate = strconv.FormatFloat(float64(class.AttendancePresent)/float64(class.AttendanceTotal), 'f', 4, 64)
+		}
+		rows = append(rows, []string{
+			class.ClassID,
+			strconv.Itoa(class.RosterCount),
+			strconv.Itoa(class.AttendancePresent),
+			strconv.Itoa(class.AttendanceTotal),
+			rate,
+		})
+	}
+
+	return []string{"class_id", "roster_count", "attendance_present", "attendance_total", "attendance_rate"}, rows, nil
+}
+
+func buildGradeDistribution(ctx context.Context, data ports.ReportDataSource, report *entities.Report, _ time.Time) ([]string, [][]string, error) {
+	bucketSize, err := strconv.Atoi(report.Parameters["bucket_size"])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid bucket_size: %w", err)
+	}
+
+	classes, err := data.ClassGrades(ctx, report.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	counts := make(map[int]int)
+	highest := 0
+	for _, class := range classes {
+		if class.GradeCount == 0 {
+			continue
+		}
+		bucket := int(math.Floor(class.GradeSum/float64(class.GradeCount)/float64(bucketSize))) * bucketSize
+		counts[bucket]++
+		highest = max(highest, bucket)
+	}
+
+	// Empty buckets are kept so the distribution is easy to chart
+	rows := make([][]string, 0, highest/bucketSize+1)
+	for bucket := 0; bucket <= highest && len(counts) > 0; bucket += bucketSize {
+		rows = append(rows, []string{
+			strconv.Itoa(bucket),
+			strconv.Itoa(bucket + bucketSize),
+			strconv.Itoa(counts[bucket]),
+		})
+	}
+
+	return []string{"average_from", "average_to", "class_count"}, rows, nil
+}
+
+func buildInactiveUsers(ctx context.Context, data ports.ReportDataSource, report *entities.Report, now time.Time) ([]string, [][]string, error) {
+	inactiveDays, err := strconv.Atoi(report.Parameters["inactive_days"])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid inactive_days: %w", err)
+	}
+
+	users, err := data.InactiveUsers(ctx, report.TenantID, now.AddDate(0, 0, -inactiveDays))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := make([][]string, 0, len(users))
+	for _, user := range users {
+		lastActivityAt := ""
+		if user.LastActivityAt != nil {
+			lastActivityAt = user.LastActivityAt.UTC().Format(time.RFC3339)
+		}
+		rows = append(rows, []string{user.ID, user.Name, user.Email, lastActivityAt})
+	}
+
+	return []string{"user_id", "name", "email", "last_activity_at"}, rows, nil
+}
@@ -0,0 +1,28 @@
+package get_report_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetReportCommand struct {
+	TenantID string `validate:"required"`
+	ReportID string `validate:"required,uuid"`
+}
+
+func NewGetReportCommand(tenantID string, reportID string) (*GetReportCommand, error) {
+	command := &GetReportCommand{
+		TenantID: tenantID,
+		ReportID: reportID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,61 @@
+package get_report_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	reportErrors "github.com/nahualventure/class-backend/core/app/report/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/report/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetReportUseCase struct {
+	reportRepo ports.ReportRepository
+}
+
+func NewGetReportUseCase(reportRepo ports.ReportRepository) *GetReportUseCase {
+	return &GetReportUseCase{
+		reportRepo: reportRepo,
+	}
+}
+
+func (uc *GetReportUseCase) Execute(ctx context.Context, cmd *GetReportCommand) (*entities.Report, error) {
+	report, err := uc.reportRepo.FindByID(ctx, cmd.TenantID, cmd.ReportID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if report == nil {
+		return nil, reportErrors.NewReportNotFoundError(cmd.ReportID)
+	}
+
+	return report, nil
+}
+
+// Download returns the artifact of a ready, unexpired report
+func (uc *GetReportUseCase) Download(ctx context.Context, cmd *GetReportCommand) (*entities.Artifact, error) {
+	report, err := uc.Execute(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Status != entities.ReportStatusReady {
+		return nil, reportErrors.NewReportNotReadyError(report.ID, string(report.Status))
+	}
+
+	if report.IsExpired(time.Now()) {
+		return nil, reportErrors.NewReportExpiredError(report.ID, *report.ExpiresAt)
+	}
+
+	artifact, err := uc.reportRepo.FindArtifact(ctx, cmd.TenantID, cmd.ReportID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if artifact == nil {
+		return nil, reportErrors.NewReportNotFoundError(cmd.ReportID)
+	}
+
+	return artifact, nil
+}
@@ -0,0 +1,22 @@
+package list_reports_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type ListReportsCommand struct {
+	TenantID string
+	Page     *pagination.PageRequest
+}
+
+func NewListReportsCommand(tenantID string, pageSize int, cursor string) (*ListReportsCommand, error) {
+	page, err := pagination.NewPageRequest(pageSize, cursor, "", nil, pagination.OrderBy{
+		Field:     "created_at",
+		Direction: pagination.Descending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListReportsCommand{TenantID: tenantID, Page: page}, nil
+}
@@ -0,0 +1,29 @@
+package list_reports_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/report/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type ListReportsUseCase struct {
+	reportRepo ports.ReportRepository
+}
+
+func NewListReportsUseCase(reportRepo ports.ReportRepository) *ListReportsUseCase {
+	return &ListReportsUseCase{
+		reportRepo: reportRepo,
+	}
+}
+
+func (uc *ListReportsUseCase) Execute(ctx context.Context, cmd *ListReportsCommand) (*pagination.Page[*entities.Report], error) {
+	page, err := uc.reportRepo.List(ctx, cmd.TenantID, cmd.Page)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return page, nil
+}
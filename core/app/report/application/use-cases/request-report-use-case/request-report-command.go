@@ -0,0 +1,32 @@
+package request_report_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RequestReportCommand struct {
+	TenantID    string `validate:"required"`
+	RequestedBy string `validate:"required"`
+	Definition  string `validate:"required"`
+	Parameters  map[string]string
+}
+
+func NewRequestReportCommand(tenantID string, requestedBy string, definition string, parameters map[string]string) (*RequestReportCommand, error) {
+	command := &RequestReportCommand{
+		TenantID:    tenantID,
+		RequestedBy: requestedBy,
+		Definition:  definition,
+		Parameters:  parameters,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,60 @@
+package request_report_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	reportErrors "github.com/nahualventure/class-backend/core/app/report/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/report/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type RequestReportUseCase struct {
+	reportRepo ports.ReportRepository
+}
+
+func NewRequestReportUseCase(reportRepo ports.ReportRepository) *RequestReportUseCase {
+	return &RequestReportUseCase{
+		reportRepo: reportRepo,
+	}
+}
+
+// Execute queues the report, the worker generates it asynchronously
+func (uc *RequestReportUseCase) Execute(ctx context.Context, cmd *RequestReportCommand) (*entities.Report, error) {
+	definition, ok := entities.Definitions[cmd.Definition]
+	if !ok {
+		return nil, reportErrors.NewUnknownReportDefinitionError(cmd.Definition)
+	}
+
+	parameters, err := definition.ResolveParameters(cmd.Parameters)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	now := time.Now().UTC()
+	report, err := entities.NewReport(
+		uuid.New().String(),
+		cmd.TenantID,
+		definition.Name,
+		parameters,
+		entities.ReportStatusPending,
+		"",
+		cmd.RequestedBy,
+		"",
+		nil,
+		now,
+		now,
+	)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.reportRepo.Create(ctx, report); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return report, nil
+}
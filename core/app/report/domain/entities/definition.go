@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+const (
+	AttendanceSummaryReport = "attendance_summary"
+	GradeDistributionReport = "grade_distribution"
+	InactiveUsersReport     = "inactive_users"
+)
+
+type ParameterType string
+
+const (
+	IntParameter  ParameterType = "int"
+	DateParameter ParameterType = "date" // YYYY-MM-DD
+)
+
+type ParameterSpec struct {
+	Name        string
+	Type        ParameterType
+	Default     string // empty means the parameter is required
+	Max         int    // largest value of an int parameter, zero means no limit
+	Description string
+}
+
+type Definition struct {
+	Name        string
+	Description string
+	Parameters  []ParameterSpec
+}
+
+// Definitions lists the reports a tenant can request
+var Definitions = map[string]Definition{
+	AttendanceSummaryReport: {
+		Name:        AttendanceSummaryReport,
+		Description: "Roster size and attendance rate per class",
+	},
+	GradeDistributionReport: {
+		Name:        GradeDistributionReport,
+		Description: "Number of classes per average grade bucket",
+		Parameters: []ParameterSpec{
+			{Name: "bucket_size", Type: IntParameter, Default: "10", Description: "Width of each grade bucket"},
+		},
+	},
+	InactiveUsersReport: {
+		Name:        InactiveUsersReport,
+		Description: "Users without activity for a number of days",
+		Parameters: []ParameterSpec{
+			// Activity is read from the API usage, which is kept for 180 days
+			{Name: "inactive_days", Type: IntParameter, Default: "90", Max: 180, Description: "Days without requests"},
+		},
+	},
+}
+
+// ResolveParameters validates raw parameters against the definition and fills defaults
+func (d Definition) ResolveParameters(raw map[string]string) (map[string]string, error) {
+	errorMap := make(map[string]any)
+	resolved := make(map[string]string, len(d.Parameters))
+
+	known := make(map[string]bool, len(d.Parameters))
+	for _, spec := range d.Parameters {
+		known[spec.Name] = true
+
+		value, ok := raw[spec.Name]
+		if !ok || value == "" {
+			if spec.Default == "" {
+				errorMap[spec.Name] = "Required"
+				continue
+			}
+			value = spec.Default
+		}
+
+		if err := spec.check(value); err != nil {
+			errorMap[spec.Name] = err.Error()
+			continue
+		}
+		resolved[spec.Name] = value
+	}
+
+	for name := range raw {
+		if !known[name] {
+			errorMap[name] = "Unknown parameter"
+		}
+	}
+
+	if len(errorMap) > 0 {
+		return nil, appErrors.NewValidationError(fmt.Sprintf("Invalid parameters for report %s", d.Name), errorMap, nil)
+	}
+
+	return resolved, nil
+}
+
+func (s ParameterSpec) check(value string) error {
+	switch s.Type {
+	case IntParameter:
+		number, err := strconv.Atoi(value)
+		if err != nil || number <= 0 {
+			return fmt.Errorf("Must be a positive integer")
+		}
+		if s.Max > 0 && number > s.Max {
+			return fmt.Errorf("Must be at most %d", s.Max)
+		}
+	case DateParameter:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return fmt.Errorf("Must be a date formatted as YYYY-MM-DD")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+// Rows read by the report generators, they come from projections or narrow queries, never full entities
+
+type ClassAttendanceRow struct {
+	ClassID           string
+	RosterCount       int
+	AttendancePresent int
+	AttendanceTotal   int
+}
+
+type ClassGradeRow struct {
+	ClassID    string
+	GradeSum   float64
+	GradeCount int
+}
+
+type InactiveUserRow struct {
+	ID    string
+	Name  string
+	Email string
+	// LastActivityAt is the hour of the last request of the user in the tenant, nil when the kept API
+	// usage has none
+	LastActivityAt *time.Time
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ReportStatus string
+
+const (
+	ReportStatusPending ReportStatus = "pending"
+	ReportStatusRunning ReportStatus = "running"
+	ReportStatusReady   ReportStatus = "ready"
+	ReportStatusFailed  ReportStatus = "failed"
+)
+
+// Report is an async report request. The generated artifact is stored apart and only loaded on download.
+type Report struct {
+	ID            string `validate:"required,uuid4"`
+	TenantID      string `validate:"required"`
+	Definition    string `validate:"required"`
+	Parameters    map[string]string
+	Status        ReportStatus `validate:"required,oneof=pending running ready failed"`
+	FailureReason string
+	RequestedBy   string `validate:"required"`
+	ArtifactName  string
+	ExpiresAt     *time.Time
+	CreatedAt     time.Time `validate:"required"`
+	UpdatedAt     time.Time `validate:"required"`
+}
+
+func NewReport(id string, tenantID string, definition string, parameters map[string]string, status ReportStatus,
+	failureReason string, requestedBy string, artifactName string, expiresAt *time.Time, createdAt time.Time, updatedAt time.Time) (*Report, error) {
+	report := &Report{
+		ID:            id,
+		TenantID:      tenantID,
+		Definition:    definition,
+		Parameters:    parameters,
+		Status:        status,
+		FailureReason: failureReason,
+		RequestedBy:   requestedBy,
+		ArtifactName:  artifactName,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+	}
+
+	if err := validate.Struct(report); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("Report domain model instance not valid", errorMap, err)
+	}
+
+	return report, nil
+}
+
+func (r *Report) IsExpired(now time.Time) bool {
+	return r.ExpiresAt != nil && !now.Before(*r.ExpiresAt)
+}
+
+// Artifact is the generated report file
+type Artifact struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ReportNotFoundError          errors2.ErrorCode = "REPORT_NOT_FOUND"
+	ReportNotReadyError          errors2.ErrorCode = "REPORT_NOT_READY"
+	ReportExpiredError           errors2.ErrorCode = "REPORT_EXPIRED"
+	UnknownReportDefinitionError errors2.ErrorCode = "UNKNOWN_REPORT_DEFINITION"
+)
+
+func NewReportNotFoundError(reportID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ReportNotFoundError.String(),
+			Message: "The requested report could not be found",
+			Context: map[string]any{
+				"report_id": reportID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ReportNotFoundError.String()),
+		},
+	}
+}
+
+func NewReportNotReadyError(reportID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ReportNotReadyError.String(),
+			Message: "The report has not been generated yet",
+			Context: map[string]any{
+				"report_id": reportID,
+				"status":    status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ReportNotReadyError.String()),
+		},
+	}
+}
+
+func NewReportExpiredError(reportID string, expiredAt time.Time) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ReportExpiredError.String(),
+			Message: "The report artifact has expired, request the report again",
+			Context: map[string]any{
+				"report_id":  reportID,
+				"expired_at": expiredAt,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ReportExpiredError.String()),
+		},
+	}
+}
+
+func NewUnknownReportDefinitionError(definition string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    UnknownReportDefinitionError.String(),
+			Message: "The requested report definition does not exist",
+			Context: map[string]any{
+				"definition": definition,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(UnknownReportDefinitionError.String()),
+		},
+	}
+}
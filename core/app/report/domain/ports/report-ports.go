@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type ReportRepository interface {
+	Create(ctx context.Context, report *entities.Report) error
+	FindByID(ctx context.Context, tenantID string, reportID string) (*entities.Report, error)
+	FindArtifact(ctx context.Context, tenantID string, reportID string) (*entities.Artifact, error)
+	List(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.Report], error)
+	ReportJobQueue
+}
+
+// ReportJobQueue is the worker side of the repository. ClaimNextPending also reclaims reports left
+// running by a worker that died more than staleAfter ago; it returns nil when there is nothing to do.
+type ReportJobQueue interface {
+	ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.Report, error)
+	MarkReady(ctx context.Context, reportID string, artifact *entities.Artifact, expiresAt time.Time) error
+	MarkFailed(ctx context.Context, reportID string, reason string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// ReportDataSource provides the rows each report definition is built from
+type ReportDataSource interface {
+	ClassAttendance(ctx context.Context, tenantID string) ([]entities.ClassAttendanceRow, error)
+	ClassGrades(ctx context.Context, tenantID string) ([]entities.ClassGradeRow, error)
+	InactiveUsers(ctx context.Context, tenantID string, inactiveSince time.Time) ([]entities.InactiveUserRow, error)
+}
@@ -0,0 +1,32 @@
+package confirm_member_deactivation_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ConfirmMemberDeactivationCommand struct {
+	TenantID       string `validate:"required"`
+	DeactivationID string `validate:"required,uuid"`
+	ConfirmedBy    string `validate:"required"`
+	Token          string `validate:"required"`
+}
+
+func NewConfirmMemberDeactivationCommand(tenantID string, deactivationID string, confirmedBy string, token string) (*ConfirmMemberDeactivationCommand, error) {
+	command := &ConfirmMemberDeactivationCommand{
+		TenantID:       tenantID,
+		DeactivationID: deactivationID,
+		ConfirmedBy:    confirmedBy,
+		Token:          token,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,60 @@
+package confirm_member_deactivation_use_case
+
+import (
+	"context"
+	"time"
+
+	preview_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/preview-member-deactivation-use-case"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	roleAssignmentErrors "github.com/nahualventure/class-backend/core/app/roleassignment/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ConfirmMemberDeactivationUseCase struct {
+	deactivations ports.MemberDeactivationRepository
+	preview       *preview_member_deactivation_use_case.PreviewMemberDeactivationUseCase
+}
+
+func NewConfirmMemberDeactivationUseCase(deactivations ports.MemberDeactivationRepository,
+	preview *preview_member_deactivation_use_case.PreviewMemberDeactivationUseCase) *ConfirmMemberDeactivationUseCase {
+	return &ConfirmMemberDeactivationUseCase{
+		deactivations: deactivations,
+		preview:       preview,
+	}
+}
+
+// Execute queues a previewed deactivation, the roles are revoked by the run use case. The members
+// are selected again and must be the ones the preview counted, a class that changed in between needs
+// a new preview.
+func (uc *ConfirmMemberDeactivationUseCase) Execute(ctx context.Context, cmd *ConfirmMemberDeactivationCommand) (*entities.MemberDeactivation, error) {
+	deactivation, err := uc.deactivations.FindByID(ctx, cmd.TenantID, cmd.DeactivationID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if deactivation == nil {
+		return nil, roleAssignmentErrors.NewMemberDeactivationNotFoundError(cmd.DeactivationID)
+	}
+	now := time.Now().UTC()
+	if !deactivation.ConfirmableWith(cmd.Token, now) {
+		return nil, roleAssignmentErrors.NewMemberDeactivationTokenInvalidError(deactivation.ID)
+	}
+
+	subjects, err := uc.preview.Members(ctx, deactivation.TenantID, deactivation.Role, deactivation.ClassID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !deactivation.Matches(subjects) {
+		return nil, roleAssignmentErrors.NewMemberDeactivationStaleError(deactivation.ID, deactivation.Members(), len(subjects))
+	}
+
+	deactivation.Confirm(cmd.ConfirmedBy, now)
+	confirmed, err := uc.deactivations.Confirm(ctx, deactivation)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !confirmed {
+		return nil, roleAssignmentErrors.NewMemberDeactivationTokenInvalidError(deactivation.ID)
+	}
+	return deactivation, nil
+}
@@ -0,0 +1,28 @@
+package get_group_role_assignment_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetGroupRoleAssignmentCommand struct {
+	TenantID     string `validate:"required"`
+	AssignmentID string `validate:"required,uuid"`
+}
+
+func NewGetGroupRoleAssignmentCommand(tenantID string, assignmentID string) (*GetGroupRoleAssignmentCommand, error) {
+	command := &GetGroupRoleAssignmentCommand{
+		TenantID:     tenantID,
+		AssignmentID: assignmentID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,32 @@
+package get_group_role_assignment_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	roleAssignmentErrors "github.com/nahualventure/class-backend/core/app/roleassignment/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetGroupRoleAssignmentUseCase struct {
+	assignmentRepo ports.GroupRoleAssignmentRepository
+}
+
+func NewGetGroupRoleAssignmentUseCase(assignmentRepo ports.GroupRoleAssignmentRepository) *GetGroupRoleAssignmentUseCase {
+	return &GetGroupRoleAssignmentUseCase{
+		assignmentRepo: assignmentRepo,
+	}
+}
+
+func (uc *GetGroupRoleAssignmentUseCase) Execute(ctx context.Context, cmd *GetGroupRoleAssignmentCommand) (*entities.GroupRoleAssignment, error) {
+	assignment, err := uc.assignmentRepo.FindByID(ctx, cmd.TenantID, cmd.AssignmentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if assignment == nil {
+		return nil, roleAssignmentErrors.NewGroupRoleAssignmentNotFoundError(cmd.AssignmentID)
+	}
+
+	return assignment, nil
+}
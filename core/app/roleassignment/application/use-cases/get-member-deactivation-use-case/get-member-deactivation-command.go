@@ -0,0 +1,28 @@
+package get_member_deactivation_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetMemberDeactivationCommand struct {
+	TenantID       string `validate:"required"`
+	DeactivationID string `validate:"required,uuid"`
+}
+
+func NewGetMemberDeactivationCommand(tenantID string, deactivationID string) (*GetMemberDeactivationCommand, error) {
+	command := &GetMemberDeactivationCommand{
+		TenantID:       tenantID,
+		DeactivationID: deactivationID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
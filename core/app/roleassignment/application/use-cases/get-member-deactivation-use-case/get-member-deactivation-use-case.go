@@ -0,0 +1,31 @@
+package get_member_deactivation_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	roleAssignmentErrors "github.com/nahualventure/class-backend/core/app/roleassignment/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetMemberDeactivationUseCase struct {
+	deactivations ports.MemberDeactivationRepository
+}
+
+func NewGetMemberDeactivationUseCase(deactivations ports.MemberDeactivationRepository) *GetMemberDeactivationUseCase {
+	return &GetMemberDeactivationUseCase{
+		deactivations: deactivations,
+	}
+}
+
+func (uc *GetMemberDeactivationUseCase) Execute(ctx context.Context, cmd *GetMemberDeactivationCommand) (*entities.MemberDeactivation, error) {
+	deactivation, err := uc.deactivations.FindByID(ctx, cmd.TenantID, cmd.DeactivationID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if deactivation == nil {
+		return nil, roleAssignmentErrors.NewMemberDeactivationNotFoundError(cmd.DeactivationID)
+	}
+	return deactivation, nil
+}
@@ -0,0 +1,28 @@
+package list_group_role_assignments_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListGroupRoleAssignmentsCommand struct {
+	TenantID string `validate:"required"`
+	Limit    int    `validate:"min=1,max=100"`
+}
+
+func NewListGroupRoleAssignmentsCommand(tenantID string, limit int) (*ListGroupRoleAssignmentsCommand, error) {
+	command := &ListGroupRoleAssignmentsCommand{
+		TenantID: tenantID,
+		Limit:    limit,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
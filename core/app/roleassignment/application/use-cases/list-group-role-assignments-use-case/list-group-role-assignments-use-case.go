@@ -0,0 +1,28 @@
+package list_group_role_assignments_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListGroupRoleAssignmentsUseCase struct {
+	assignmentRepo ports.GroupRoleAssignmentRepository
+}
+
+func NewListGroupRoleAssignmentsUseCase(assignmentRepo ports.GroupRoleAssignmentRepository) *ListGroupRoleAssignmentsUseCase {
+	return &ListGroupRoleAssignmentsUseCase{
+		assignmentRepo: assignmentRepo,
+	}
+}
+
+func (uc *ListGroupRoleAssignmentsUseCase) Execute(ctx context.Context, cmd *ListGroupRoleAssignmentsCommand) ([]*entities.GroupRoleAssignment, error) {
+	assignments, err := uc.assignmentRepo.List(ctx, cmd.TenantID, cmd.Limit)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return assignments, nil
+}
@@ -0,0 +1,32 @@
+package preview_member_deactivation_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type PreviewMemberDeactivationCommand struct {
+	TenantID    string `validate:"required"`
+	RequestedBy string `validate:"required"`
+	Role        string `validate:"required"`
+	ClassID     string `validate:"omitempty,uuid"`
+}
+
+func NewPreviewMemberDeactivationCommand(tenantID string, requestedBy string, role string, classID string) (*PreviewMemberDeactivationCommand, error) {
+	command := &PreviewMemberDeactivationCommand{
+		TenantID:    tenantID,
+		RequestedBy: requestedBy,
+		Role:        role,
+		ClassID:     classID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
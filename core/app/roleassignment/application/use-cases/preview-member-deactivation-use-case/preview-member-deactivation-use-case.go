@@ -0,0 +1,71 @@
+package preview_member_deactivation_use_case
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type PreviewMemberDeactivationUseCase struct {
+	deactivations ports.MemberDeactivationRepository
+	roles         ports.TenantRoles
+	groups        ports.GroupDirectory
+	bulkRoles     ports.BulkRoleAssigner
+}
+
+func NewPreviewMemberDeactivationUseCase(deactivations ports.MemberDeactivationRepository, roles ports.TenantRoles,
+	groups ports.GroupDirectory, bulkRoles ports.BulkRoleAssigner) *PreviewMemberDeactivationUseCase {
+	return &PreviewMemberDeactivationUseCase{
+		deactivations: deactivations,
+		roles:         roles,
+		groups:        groups,
+		bulkRoles:     bulkRoles,
+	}
+}
+
+// Execute counts the members to deactivate and their role assignments, and returns the token that
+// confirms the deactivation. Nothing changes until it is confirmed.
+func (uc *PreviewMemberDeactivationUseCase) Execute(ctx context.Context, cmd *PreviewMemberDeactivationCommand) (*entities.MemberDeactivation, string, error) {
+	if !slices.Contains(uc.bulkRoles.AvailableRoles(), cmd.Role) {
+		return nil, "", errors.NewValidationError("Role not available", map[string]any{"role": "not one of the roles of the policies"}, nil)
+	}
+
+	subjects, err := uc.Members(ctx, cmd.TenantID, cmd.Role, cmd.ClassID)
+	if err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+	roles, err := uc.roles.Of(ctx, subjects, cmd.TenantID)
+	if err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+
+	deactivation, token, err := entities.NewMemberDeactivation(uuid.New().String(), cmd.TenantID, cmd.Role, cmd.ClassID,
+		cmd.RequestedBy, subjects, roles, time.Now().UTC())
+	if err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+	if err := uc.deactivations.Create(ctx, deactivation); err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+	return deactivation, token, nil
+}
+
+// Members returns the holders of role in the tenant, only the ones enrolled in classID when it is
+// set. The confirmation selects them again the same way.
+func (uc *PreviewMemberDeactivationUseCase) Members(ctx context.Context, tenantID string, role string, classID string) ([]string, error) {
+	holders, err := uc.roles.Holders(ctx, role, tenantID)
+	if err != nil || classID == "" {
+		return holders, err
+	}
+	enrolled, err := uc.groups.Members(ctx, tenantID, entities.GroupTypeClass, classID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(holders, func(subject string) bool { return !slices.Contains(enrolled, subject) }), nil
+}
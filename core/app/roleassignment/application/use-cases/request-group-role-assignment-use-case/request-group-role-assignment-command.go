@@ -0,0 +1,35 @@
+package request_group_role_assignment_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RequestGroupRoleAssignmentCommand struct {
+	TenantID    string `validate:"required"`
+	RequestedBy string `validate:"required"`
+	GroupType   string `validate:"required,oneof=class"`
+	GroupID     string `validate:"required,uuid"`
+	Role        string `validate:"required"`
+}
+
+func NewRequestGroupRoleAssignmentCommand(tenantID string, requestedBy string, groupType string, groupID string,
+	role string) (*RequestGroupRoleAssignmentCommand, error) {
+	command := &RequestGroupRoleAssignmentCommand{
+		TenantID:    tenantID,
+		RequestedBy: requestedBy,
+		GroupType:   groupType,
+		GroupID:     groupID,
+		Role:        role,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
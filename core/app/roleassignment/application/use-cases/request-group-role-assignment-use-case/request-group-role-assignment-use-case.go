@@ -0,0 +1,45 @@
+package request_group_role_assignment_use_case
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type RequestGroupRoleAssignmentUseCase struct {
+	assignmentRepo ports.GroupRoleAssignmentRepository
+	roles          ports.BulkRoleAssigner
+}
+
+func NewRequestGroupRoleAssignmentUseCase(assignmentRepo ports.GroupRoleAssignmentRepository,
+	roles ports.BulkRoleAssigner) *RequestGroupRoleAssignmentUseCase {
+	return &RequestGroupRoleAssignmentUseCase{
+		assignmentRepo: assignmentRepo,
+		roles:          roles,
+	}
+}
+
+// Execute queues the assignment, the worker gives the role to the members asynchronously
+func (uc *RequestGroupRoleAssignmentUseCase) Execute(ctx context.Context, cmd *RequestGroupRoleAssignmentCommand) (*entities.GroupRoleAssignment, error) {
+	if !slices.Contains(uc.roles.AvailableRoles(), cmd.Role) {
+		return nil, errors.NewValidationError("Role not valid", map[string]any{"role": "unknown role " + cmd.Role}, nil)
+	}
+
+	assignment, err := entities.NewGroupRoleAssignment(uuid.New().String(), cmd.TenantID, cmd.GroupType, cmd.GroupID, cmd.Role,
+		cmd.RequestedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.assignmentRepo.Create(ctx, assignment); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return assignment, nil
+}
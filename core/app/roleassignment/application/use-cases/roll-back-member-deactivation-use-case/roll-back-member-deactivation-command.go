@@ -0,0 +1,30 @@
+package roll_back_member_deactivation_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RollBackMemberDeactivationCommand struct {
+	TenantID       string `validate:"required"`
+	DeactivationID string `validate:"required,uuid"`
+	RolledBackBy   string `validate:"required"`
+}
+
+func NewRollBackMemberDeactivationCommand(tenantID string, deactivationID string, rolledBackBy string) (*RollBackMemberDeactivationCommand, error) {
+	command := &RollBackMemberDeactivationCommand{
+		TenantID:       tenantID,
+		DeactivationID: deactivationID,
+		RolledBackBy:   rolledBackBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,51 @@
+package roll_back_member_deactivation_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	roleAssignmentErrors "github.com/nahualventure/class-backend/core/app/roleassignment/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type RollBackMemberDeactivationUseCase struct {
+	deactivations ports.MemberDeactivationRepository
+	roles         ports.TenantRoles
+}
+
+func NewRollBackMemberDeactivationUseCase(deactivations ports.MemberDeactivationRepository, roles ports.TenantRoles) *RollBackMemberDeactivationUseCase {
+	return &RollBackMemberDeactivationUseCase{
+		deactivations: deactivations,
+		roles:         roles,
+	}
+}
+
+// Execute gives the members of a completed deactivation the roles it revoked. Roles assigned to
+// them since are kept.
+func (uc *RollBackMemberDeactivationUseCase) Execute(ctx context.Context, cmd *RollBackMemberDeactivationCommand) (*entities.MemberDeactivation, error) {
+	deactivation, err := uc.deactivations.FindByID(ctx, cmd.TenantID, cmd.DeactivationID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if deactivation == nil {
+		return nil, roleAssignmentErrors.NewMemberDeactivationNotFoundError(cmd.DeactivationID)
+	}
+	if deactivation.Status != entities.MemberDeactivationStatusCompleted {
+		return nil, roleAssignmentErrors.NewMemberDeactivationNotCompletedError(deactivation.ID, string(deactivation.Status))
+	}
+
+	if err := uc.roles.Restore(ctx, deactivation.Revoked, deactivation.TenantID); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	deactivation.RollBack(cmd.RolledBackBy, time.Now().UTC())
+	rolledBack, err := uc.deactivations.MarkRolledBack(ctx, deactivation)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !rolledBack {
+		return nil, roleAssignmentErrors.NewMemberDeactivationNotCompletedError(deactivation.ID, string(entities.MemberDeactivationStatusRolledBack))
+	}
+	return deactivation, nil
+}
@@ -0,0 +1,81 @@
+package run_group_role_assignment_use_case
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// StaleAfter is how long an assignment can stay running before another worker reclaims it. The
+// role assignments of a run that died were rolled back, so it starts over.
+const StaleAfter = 30 * time.Minute
+
+type RunGroupRoleAssignmentUseCase struct {
+	queue  ports.GroupRoleAssignmentJobQueue
+	groups ports.GroupDirectory
+	roles  ports.BulkRoleAssigner
+}
+
+func NewRunGroupRoleAssignmentUseCase(queue ports.GroupRoleAssignmentJobQueue, groups ports.GroupDirectory,
+	roles ports.BulkRoleAssigner) *RunGroupRoleAssignmentUseCase {
+	return &RunGroupRoleAssignmentUseCase{
+		queue:  queue,
+		groups: groups,
+		roles:  roles,
+	}
+}
+
+// Execute takes the next pending assignment and gives its role to every member of the group in one
+// transaction, saving the progress as members are written. It returns false when the queue is
+// empty. An assignment that fails is marked failed and its error is returned for logging.
+func (uc *RunGroupRoleAssignmentUseCase) Execute(ctx context.Context) (bool, error) {
+	assignment, err := uc.queue.ClaimNextPending(ctx, StaleAfter)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+	if assignment == nil {
+		return false, nil
+	}
+
+	if err := uc.run(ctx, assignment); err != nil {
+		if markErr := uc.queue.MarkFailed(ctx, assignment.ID, err.Error()); markErr != nil {
+			return true, errors.PropagateError(markErr)
+		}
+		return true, errors.NewInfrastructureError(fmt.Sprintf("failed to assign role %s to %s %s in group role assignment %s",
+			assignment.Role, assignment.GroupType, assignment.GroupID, assignment.ID), err)
+	}
+
+	if err := uc.queue.MarkCompleted(ctx, assignment); err != nil {
+		return true, errors.PropagateError(err)
+	}
+	return true, nil
+}
+
+func (uc *RunGroupRoleAssignmentUseCase) run(ctx context.Context, assignment *entities.GroupRoleAssignment) error {
+	members, err := uc.groups.Members(ctx, assignment.TenantID, assignment.GroupType, assignment.GroupID)
+	if err != nil {
+		return fmt.Errorf("members: %w", err)
+	}
+	assignment.Members, assignment.Processed, assignment.Assigned = len(members), 0, 0
+	if len(members) == 0 {
+		return nil
+	}
+	if err := uc.queue.SaveProgress(ctx, assignment); err != nil {
+		return fmt.Errorf("progress: %w", err)
+	}
+
+	assigned, err := uc.roles.AssignRole(ctx, members, assignment.Role, assignment.TenantID, func(processed int, assigned int) {
+		assignment.Processed, assignment.Assigned = processed, assigned
+		// Progress is only reported, a failed save leaves the last one until the next chunk
+		_ = uc.queue.SaveProgress(ctx, assignment)
+	})
+	if err != nil {
+		return fmt.Errorf("assign: %w", err)
+	}
+	assignment.Processed, assignment.Assigned = len(members), assigned
+	return nil
+}
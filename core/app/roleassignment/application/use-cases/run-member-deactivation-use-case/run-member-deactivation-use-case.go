@@ -0,0 +1,69 @@
+package run_member_deactivation_use_case
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// StaleAfter is how long a deactivation can stay running before another worker takes it back
+const StaleAfter = 10 * time.Minute
+
+type RunMemberDeactivationUseCase struct {
+	queue ports.MemberDeactivationJobQueue
+	roles ports.TenantRoles
+}
+
+func NewRunMemberDeactivationUseCase(queue ports.MemberDeactivationJobQueue, roles ports.TenantRoles) *RunMemberDeactivationUseCase {
+	return &RunMemberDeactivationUseCase{
+		queue: queue,
+		roles: roles,
+	}
+}
+
+// Execute revokes the roles of the next confirmed deactivation and returns false when there is
+// nothing to do. The role assignments are saved before any is removed, so a deactivation taken back
+// from a dead worker revokes what was saved and a completed one can always be rolled back.
+func (uc *RunMemberDeactivationUseCase) Execute(ctx context.Context) (bool, error) {
+	deactivation, err := uc.queue.ClaimNextPending(ctx, StaleAfter)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+	if deactivation == nil {
+		return false, nil
+	}
+
+	fail := func(err error) (bool, error) {
+		if markErr := uc.queue.MarkFailed(ctx, deactivation.ID, err.Error()); markErr != nil {
+			return true, errors.PropagateError(markErr)
+		}
+		return true, errors.NewInfrastructureError(fmt.Sprintf("failed to run member deactivation %s", deactivation.ID), err)
+	}
+
+	if deactivation.Revoked == nil {
+		revoked, err := uc.roles.Of(ctx, deactivation.Subjects, deactivation.TenantID)
+		if err != nil {
+			return fail(err)
+		}
+		if revoked == nil {
+			revoked = []entities.TenantRole{}
+		}
+		deactivation.Revoked = revoked
+		if err := uc.queue.SaveRevoked(ctx, deactivation); err != nil {
+			return fail(err)
+		}
+	}
+
+	// Roles are only gone once they are all revoked, a failure revokes none
+	if err := uc.roles.Revoke(ctx, deactivation.Revoked, deactivation.TenantID); err != nil {
+		return fail(err)
+	}
+	if err := uc.queue.MarkCompleted(ctx, deactivation.ID); err != nil {
+		return true, errors.PropagateError(err)
+	}
+	return true, nil
+}
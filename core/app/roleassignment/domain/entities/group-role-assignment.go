@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// Groups a role can be assigned to. Classes are the only groups for now, their members are the
+// users enrolled in them.
+const (
+	GroupTypeClass = "class"
+)
+
+type GroupRoleAssignmentStatus string
+
+const (
+	GroupRoleAssignmentStatusPending GroupRoleAssignmentStatus = "pending"
+	// GroupRoleAssignmentStatusRunning assignments are being written, Processed tells how far
+	GroupRoleAssignmentStatusRunning GroupRoleAssignmentStatus = "running"
+	// GroupRoleAssignmentStatusCompleted assignments gave the role to every member at once
+	GroupRoleAssignmentStatusCompleted GroupRoleAssignmentStatus = "completed"
+	// GroupRoleAssignmentStatusFailed assignments gave the role to no member, FailureReason says why
+	GroupRoleAssignmentStatusFailed GroupRoleAssignmentStatus = "failed"
+)
+
+// GroupRoleAssignment gives a role in a tenant to every member of a group, as one role assignment
+// per member. The members are read when the assignment runs, members who join the group later do
+// not get the role.
+type GroupRoleAssignment struct {
+	ID          string                    `validate:"required,uuid"`
+	TenantID    string                    `validate:"required"`
+	GroupType   string                    `validate:"required,oneof=class"`
+	GroupID     string                    `validate:"required,uuid"`
+	Role        string                    `validate:"required"`
+	Status      GroupRoleAssignmentStatus `validate:"required,oneof=pending running completed failed"`
+	RequestedBy string                    `validate:"required"`
+	// Members counts the members of the group once the assignment started
+	Members int
+	// Processed counts the members written so far, Assigned those who did not have the role yet
+	Processed     int
+	Assigned      int
+	FailureReason string
+	CreatedAt     time.Time `validate:"required"`
+	UpdatedAt     time.Time `validate:"required"`
+	CompletedAt   *time.Time
+}
+
+func NewGroupRoleAssignment(id string, tenantID string, groupType string, groupID string, role string, requestedBy string,
+	now time.Time) (*GroupRoleAssignment, error) {
+	assignment := &GroupRoleAssignment{
+		ID:          id,
+		TenantID:    tenantID,
+		GroupType:   groupType,
+		GroupID:     groupID,
+		Role:        role,
+		Status:      GroupRoleAssignmentStatusPending,
+		RequestedBy: requestedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := validate.Struct(assignment); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("GroupRoleAssignment domain model instance not valid", errorMap, err)
+	}
+
+	return assignment, nil
+}
+
+// Progress is the share of the members processed, from 0 to 1. Completed assignments are done even
+// when the group had no members.
+func (a *GroupRoleAssignment) Progress() float64 {
+	if a.Status == GroupRoleAssignmentStatusCompleted {
+		return 1
+	}
+	if a.Members == 0 {
+		return 0
+	}
+	return float64(a.Processed) / float64(a.Members)
+}
@@ -0,0 +1,166 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// ConfirmTokenLifetime is how long the preview of a deactivation can be confirmed
+const ConfirmTokenLifetime = 15 * time.Minute
+
+type MemberDeactivationStatus string
+
+const (
+	// MemberDeactivationStatusPreviewed deactivations wait for their confirm token, nothing changed yet
+	MemberDeactivationStatusPreviewed MemberDeactivationStatus = "previewed"
+	MemberDeactivationStatusPending   MemberDeactivationStatus = "pending"
+	// MemberDeactivationStatusRunning deactivations are revoking the roles of the members
+	MemberDeactivationStatusRunning MemberDeactivationStatus = "running"
+	// MemberDeactivationStatusCompleted deactivations revoked every role of the members, Revoked
+	// lists them for a rollback
+	MemberDeactivationStatusCompleted MemberDeactivationStatus = "completed"
+	// MemberDeactivationStatusFailed deactivations revoked no role, FailureReason says why
+	MemberDeactivationStatusFailed MemberDeactivationStatus = "failed"
+	// MemberDeactivationStatusRolledBack deactivations gave the revoked roles back
+	MemberDeactivationStatusRolledBack MemberDeactivationStatus = "rolled_back"
+)
+
+// TenantRole is a role a subject holds in a tenant
+type TenantRole struct {
+	Subject string `json:"subject"`
+	Role    string `json:"role"`
+}
+
+// MemberDeactivation revokes every role in a tenant of the members holding Role, only the ones
+// enrolled in ClassID when it is set, e.g. the students of a graduating class. It is previewed
+// first and only runs once confirmed with the token of the preview, for the members the preview
+// counted.
+type MemberDeactivation struct {
+	ID       string `validate:"required,uuid"`
+	TenantID string `validate:"required"`
+	Role     string `validate:"required"`
+	// ClassID limits the members to the ones enrolled in the class, empty for every holder of Role
+	ClassID     string                   `validate:"omitempty,uuid"`
+	Status      MemberDeactivationStatus `validate:"required,oneof=previewed pending running completed failed rolled_back"`
+	RequestedBy string                   `validate:"required"`
+	// Subjects are the members the preview counted, sorted
+	Subjects []string
+	// Roles counts the role assignments of the members per role when previewed
+	Roles          map[string]int
+	TokenHash      string
+	TokenExpiresAt time.Time
+	ConfirmedBy    string
+	ConfirmedAt    *time.Time
+	// Revoked are the role assignments the deactivation removes, saved before any is removed. Nil
+	// until then, empty when the members had none left.
+	Revoked       []TenantRole
+	FailureReason string
+	CreatedAt     time.Time `validate:"required"`
+	UpdatedAt     time.Time `validate:"required"`
+	CompletedAt   *time.Time
+	RolledBackBy  string
+	RolledBackAt  *time.Time
+}
+
+// NewMemberDeactivation previews the deactivation of subjects, whose role assignments are roles. It
+// returns the confirm token, the only time it is known. The requester is never deactivated.
+func NewMemberDeactivation(id string, tenantID string, role string, classID string, requestedBy string, subjects []string,
+	roles []TenantRole, now time.Time) (*MemberDeactivation, string, error) {
+	deactivation := &MemberDeactivation{
+		ID:          id,
+		TenantID:    tenantID,
+		Role:        role,
+		ClassID:     classID,
+		Status:      MemberDeactivationStatusPreviewed,
+		RequestedBy: requestedBy,
+		Subjects:    withoutSubject(subjects, requestedBy),
+		Roles:       make(map[string]int),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for _, assignment := range roles {
+		if assignment.Subject != requestedBy {
+			deactivation.Roles[assignment.Role]++
+		}
+	}
+
+	if err := validate.Struct(deactivation); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, "", appErrors.NewDomainEntityValidationError("MemberDeactivation domain model instance not valid", errorMap, err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", appErrors.NewInfrastructureError("generate confirm token", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+	deactivation.TokenHash = hashToken(token)
+	deactivation.TokenExpiresAt = now.Add(ConfirmTokenLifetime)
+
+	return deactivation, token, nil
+}
+
+// Members counts the members the deactivation applies to
+func (d *MemberDeactivation) Members() int {
+	return len(d.Subjects)
+}
+
+// ConfirmableWith reports whether token confirms the preview at now
+func (d *MemberDeactivation) ConfirmableWith(token string, now time.Time) bool {
+	return d.Status == MemberDeactivationStatusPreviewed && now.Before(d.TokenExpiresAt) &&
+		subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(d.TokenHash)) == 1
+}
+
+// Matches reports whether subjects, the members selected now, are the ones the preview counted
+func (d *MemberDeactivation) Matches(subjects []string) bool {
+	return slices.Equal(d.Subjects, withoutSubject(subjects, d.RequestedBy))
+}
+
+// Confirm queues the deactivation for the worker
+func (d *MemberDeactivation) Confirm(confirmedBy string, now time.Time) {
+	d.Status = MemberDeactivationStatusPending
+	d.ConfirmedBy = confirmedBy
+	d.ConfirmedAt = &now
+	d.UpdatedAt = now
+}
+
+// RollBack records that the revoked roles were given back
+func (d *MemberDeactivation) RollBack(rolledBackBy string, now time.Time) {
+	d.Status = MemberDeactivationStatusRolledBack
+	d.RolledBackBy = rolledBackBy
+	d.RolledBackAt = &now
+	d.UpdatedAt = now
+}
+
+// withoutSubject returns subjects sorted and without duplicates, empty ones and subject
+func withoutSubject(subjects []string, subject string) []string {
+	kept := make([]string, 0, len(subjects))
+	for _, candidate := range subjects {
+		if candidate != "" && candidate != subject {
+			kept = append(kept, candidate)
+		}
+	}
+	slices.Sort(kept)
+	return slices.Compact(kept)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	MemberDeactivationNotFoundError errors2.ErrorCode = "MEMBER_DEACTIVATION_NOT_FOUND"
+	// MemberDeactivationTokenInvalidError is returned for wrong or expired confirm tokens and for
+	// deactivations already confirmed
+	MemberDeactivationTokenInvalidError errors2.ErrorCode = "MEMBER_DEACTIVATION_TOKEN_INVALID"
+	// MemberDeactivationStaleError is returned when the members changed since the preview, the
+	// deactivation has to be previewed again
+	MemberDeactivationStaleError errors2.ErrorCode = "MEMBER_DEACTIVATION_STALE"
+	// MemberDeactivationNotCompletedError is returned when rolling back a deactivation that did not
+	// revoke anything or was already rolled back
+	MemberDeactivationNotCompletedError errors2.ErrorCode = "MEMBER_DEACTIVATION_NOT_COMPLETED"
+)
+
+func NewMemberDeactivationNotFoundError(deactivationID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    MemberDeactivationNotFoundError.String(),
+			Message: "Member deactivation not found",
+			Context: map[string]any{
+				"deactivation_id": deactivationID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(MemberDeactivationNotFoundError.String()),
+		},
+	}
+}
+
+func NewMemberDeactivationTokenInvalidError(deactivationID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    MemberDeactivationTokenInvalidError.String(),
+			Message: "Confirm token is wrong, expired or already used, preview the deactivation again",
+			Context: map[string]any{
+				"deactivation_id": deactivationID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(MemberDeactivationTokenInvalidError.String()),
+		},
+	}
+}
+
+func NewMemberDeactivationStaleError(deactivationID string, previewed int, current int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    MemberDeactivationStaleError.String(),
+			Message: "Members changed since the preview, preview the deactivation again",
+			Context: map[string]any{
+				"deactivation_id":   deactivationID,
+				"previewed_members": previewed,
+				"current_members":   current,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(MemberDeactivationStaleError.String()),
+		},
+	}
+}
+
+func NewMemberDeactivationNotCompletedError(deactivationID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    MemberDeactivationNotCompletedError.String(),
+			Message: "Only completed deactivations can be rolled back",
+			Context: map[string]any{
+				"deactivation_id": deactivationID,
+				"status":          status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(MemberDeactivationNotCompletedError.String()),
+		},
+	}
+}
@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	GroupRoleAssignmentNotFoundError errors2.ErrorCode = "GROUP_ROLE_ASSIGNMENT_NOT_FOUND"
+)
+
+func NewGroupRoleAssignmentNotFoundError(assignmentID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    GroupRoleAssignmentNotFoundError.String(),
+			Message: "Group role assignment not found",
+			Context: map[string]any{
+				"assignment_id": assignmentID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(GroupRoleAssignmentNotFoundError.String()),
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+)
+
+type GroupRoleAssignmentRepository interface {
+	Create(ctx context.Context, assignment *entities.GroupRoleAssignment) error
+	// FindByID returns nil when the tenant has no such assignment
+	FindByID(ctx context.Context, tenantID string, assignmentID string) (*entities.GroupRoleAssignment, error)
+	// List returns the assignments of the tenant newest first
+	List(ctx context.Context, tenantID string, limit int) ([]*entities.GroupRoleAssignment, error)
+	GroupRoleAssignmentJobQueue
+}
+
+// GroupRoleAssignmentJobQueue is the worker side of the repository. ClaimNextPending also reclaims
+// assignments left running by a worker that died more than staleAfter ago; it returns nil when
+// there is nothing to do.
+type GroupRoleAssignmentJobQueue interface {
+	ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.GroupRoleAssignment, error)
+	// SaveProgress saves the members, processed and assigned counts of a running assignment
+	SaveProgress(ctx context.Context, assignment *entities.GroupRoleAssignment) error
+	// MarkCompleted saves the counts of the assignment and records that the group got the role
+	MarkCompleted(ctx context.Context, assignment *entities.GroupRoleAssignment) error
+	MarkFailed(ctx context.Context, assignmentID string, reason string) error
+}
+
+// GroupDirectory resolves the members of groups
+type GroupDirectory interface {
+	// Members returns the subjects of the members of the group, nil when it has none
+	Members(ctx context.Context, tenantID string, groupType string, groupID string) ([]string, error)
+}
+
+// BulkRoleAssigner assigns roles to many subjects at once
+type BulkRoleAssigner interface {
+	AvailableRoles() []string
+	// AssignRole gives role in tenantID to every subject in one transaction, none of them has it
+	// until every one does. progress is called as subjects are written with how many were processed
+	// and how many did not have the role. It returns the latter.
+	AssignRole(ctx context.Context, subjects []string, role string, tenantID string, progress func(processed int, assigned int)) (int, error)
+}
+
+type MemberDeactivationRepository interface {
+	Create(ctx context.Context, deactivation *entities.MemberDeactivation) error
+	// FindByID returns nil when the tenant has no such deactivation
+	FindByID(ctx context.Context, tenantID string, deactivationID string) (*entities.MemberDeactivation, error)
+	// Confirm saves the confirmation of a previewed deactivation, false when it is no longer previewed
+	Confirm(ctx context.Context, deactivation *entities.MemberDeactivation) (bool, error)
+	// MarkRolledBack saves the rollback of a completed deactivation, false when it is not completed
+	MarkRolledBack(ctx context.Context, deactivation *entities.MemberDeactivation) (bool, error)
+	MemberDeactivationJobQueue
+}
+
+// MemberDeactivationJobQueue is the worker side of the repository. ClaimNextPending also reclaims
+// deactivations left running by a worker that died more than staleAfter ago; it returns nil when
+// there is nothing to do.
+type MemberDeactivationJobQueue interface {
+	ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.MemberDeactivation, error)
+	// SaveRevoked saves the role assignments the deactivation is about to remove
+	SaveRevoked(ctx context.Context, deactivation *entities.MemberDeactivation) error
+	MarkCompleted(ctx context.Context, deactivationID string) error
+	MarkFailed(ctx context.Context, deactivationID string, reason string) error
+}
+
+// TenantRoles reads and changes the role assignments of many subjects of a tenant at once
+type TenantRoles interface {
+	// Holders returns the subjects that have role in tenantID
+	Holders(ctx context.Context, role string, tenantID string) ([]string, error)
+	// Of returns every role assignment of subjects in tenantID
+	Of(ctx context.Context, subjects []string, tenantID string) ([]entities.TenantRole, error)
+	// Revoke removes the role assignments in one transaction, the ones already gone are skipped
+	Revoke(ctx context.Context, assignments []entities.TenantRole, tenantID string) error
+	// Restore writes the role assignments back in one transaction, the ones that exist are skipped
+	Restore(ctx context.Context, assignments []entities.TenantRole, tenantID string) error
+}
@@ -0,0 +1,33 @@
+package create_tenant_sandbox_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CreateTenantSandboxCommand struct {
+	TenantID    string `validate:"required"`
+	RequestedBy string `validate:"required"`
+	// Lifetime is how long the sandbox lives once requested
+	Lifetime time.Duration `validate:"min=1h,max=720h"`
+}
+
+func NewCreateTenantSandboxCommand(tenantID string, requestedBy string, lifetime time.Duration) (*CreateTenantSandboxCommand, error) {
+	command := &CreateTenantSandboxCommand{
+		TenantID:    tenantID,
+		RequestedBy: requestedBy,
+		Lifetime:    lifetime,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
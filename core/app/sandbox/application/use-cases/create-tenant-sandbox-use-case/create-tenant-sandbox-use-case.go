@@ -0,0 +1,45 @@
+package create_tenant_sandbox_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/entities"
+	sandboxErrors "github.com/nahualventure/class-backend/core/app/sandbox/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type CreateTenantSandboxUseCase struct {
+	sandboxRepo ports.TenantSandboxRepository
+}
+
+func NewCreateTenantSandboxUseCase(sandboxRepo ports.TenantSandboxRepository) *CreateTenantSandboxUseCase {
+	return &CreateTenantSandboxUseCase{
+		sandboxRepo: sandboxRepo,
+	}
+}
+
+// Execute queues the sandbox, the worker copies the tenant into it asynchronously
+func (uc *CreateTenantSandboxUseCase) Execute(ctx context.Context, cmd *CreateTenantSandboxCommand) (*entities.TenantSandbox, error) {
+	current, err := uc.sandboxRepo.FindCurrent(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if current != nil {
+		return nil, sandboxErrors.NewTenantSandboxAlreadyExistsError(current.ID, string(current.Status))
+	}
+
+	sandbox, err := entities.NewTenantSandbox(uuid.New().String(), cmd.TenantID, cmd.RequestedBy, cmd.Lifetime, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.sandboxRepo.Create(ctx, sandbox); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return sandbox, nil
+}
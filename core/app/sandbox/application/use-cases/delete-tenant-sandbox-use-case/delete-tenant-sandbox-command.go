@@ -0,0 +1,26 @@
+package delete_tenant_sandbox_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type DeleteTenantSandboxCommand struct {
+	TenantID string `validate:"required"`
+}
+
+func NewDeleteTenantSandboxCommand(tenantID string) (*DeleteTenantSandboxCommand, error) {
+	command := &DeleteTenantSandboxCommand{
+		TenantID: tenantID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,41 @@
+package delete_tenant_sandbox_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/entities"
+	sandboxErrors "github.com/nahualventure/class-backend/core/app/sandbox/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type DeleteTenantSandboxUseCase struct {
+	sandboxRepo ports.TenantSandboxRepository
+}
+
+func NewDeleteTenantSandboxUseCase(sandboxRepo ports.TenantSandboxRepository) *DeleteTenantSandboxUseCase {
+	return &DeleteTenantSandboxUseCase{
+		sandboxRepo: sandboxRepo,
+	}
+}
+
+// Execute expires the sandbox alive of the tenant now, the worker removes it as it removes the
+// sandboxes that reach their expiry. Sandboxes still being copied are removed once copied.
+func (uc *DeleteTenantSandboxUseCase) Execute(ctx context.Context, cmd *DeleteTenantSandboxCommand) (*entities.TenantSandbox, error) {
+	sandbox, err := uc.sandboxRepo.FindCurrent(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if sandbox == nil || !sandbox.Alive() {
+		return nil, sandboxErrors.NewTenantSandboxNotFoundError(cmd.TenantID)
+	}
+
+	now := time.Now().UTC()
+	sandbox.ExpiresAt, sandbox.UpdatedAt = now, now
+	if err := uc.sandboxRepo.Update(ctx, sandbox); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return sandbox, nil
+}
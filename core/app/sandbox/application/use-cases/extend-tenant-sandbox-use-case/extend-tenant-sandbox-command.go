@@ -0,0 +1,31 @@
+package extend_tenant_sandbox_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ExtendTenantSandboxCommand struct {
+	TenantID string `validate:"required"`
+	// Lifetime is how long the sandbox lives from now on
+	Lifetime time.Duration `validate:"min=1h,max=720h"`
+}
+
+func NewExtendTenantSandboxCommand(tenantID string, lifetime time.Duration) (*ExtendTenantSandboxCommand, error) {
+	command := &ExtendTenantSandboxCommand{
+		TenantID: tenantID,
+		Lifetime: lifetime,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
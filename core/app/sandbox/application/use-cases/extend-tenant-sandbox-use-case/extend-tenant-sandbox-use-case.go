@@ -0,0 +1,41 @@
+package extend_tenant_sandbox_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/entities"
+	sandboxErrors "github.com/nahualventure/class-backend/core/app/sandbox/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ExtendTenantSandboxUseCase struct {
+	sandboxRepo ports.TenantSandboxRepository
+}
+
+func NewExtendTenantSandboxUseCase(sandboxRepo ports.TenantSandboxRepository) *ExtendTenantSandboxUseCase {
+	return &ExtendTenantSandboxUseCase{
+		sandboxRepo: sandboxRepo,
+	}
+}
+
+// Execute moves the expiry of the sandbox alive of the tenant, which may also bring it closer
+func (uc *ExtendTenantSandboxUseCase) Execute(ctx context.Context, cmd *ExtendTenantSandboxCommand) (*entities.TenantSandbox, error) {
+	sandbox, err := uc.sandboxRepo.FindCurrent(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if sandbox == nil || !sandbox.Alive() {
+		return nil, sandboxErrors.NewTenantSandboxNotFoundError(cmd.TenantID)
+	}
+
+	if err := sandbox.Extend(cmd.Lifetime, time.Now().UTC()); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if err := uc.sandboxRepo.Update(ctx, sandbox); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return sandbox, nil
+}
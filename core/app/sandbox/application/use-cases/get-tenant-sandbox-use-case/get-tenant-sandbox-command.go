@@ -0,0 +1,26 @@
+package get_tenant_sandbox_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetTenantSandboxCommand struct {
+	TenantID string `validate:"required"`
+}
+
+func NewGetTenantSandboxCommand(tenantID string) (*GetTenantSandboxCommand, error) {
+	command := &GetTenantSandboxCommand{
+		TenantID: tenantID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
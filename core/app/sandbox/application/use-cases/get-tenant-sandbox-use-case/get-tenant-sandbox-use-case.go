@@ -0,0 +1,32 @@
+package get_tenant_sandbox_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/entities"
+	sandboxErrors "github.com/nahualventure/class-backend/core/app/sandbox/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetTenantSandboxUseCase struct {
+	sandboxRepo ports.TenantSandboxRepository
+}
+
+func NewGetTenantSandboxUseCase(sandboxRepo ports.TenantSandboxRepository) *GetTenantSandboxUseCase {
+	return &GetTenantSandboxUseCase{
+		sandboxRepo: sandboxRepo,
+	}
+}
+
+func (uc *GetTenantSandboxUseCase) Execute(ctx context.Context, cmd *GetTenantSandboxCommand) (*entities.TenantSandbox, error) {
+	sandbox, err := uc.sandboxRepo.FindCurrent(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if sandbox == nil {
+		return nil, sandboxErrors.NewTenantSandboxNotFoundError(cmd.TenantID)
+	}
+
+	return sandbox, nil
+}
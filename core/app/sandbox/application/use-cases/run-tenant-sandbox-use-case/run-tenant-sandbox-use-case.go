@@ -0,0 +1,107 @@
+package run_tenant_sandbox_use_case
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sync_sandbox_tenants_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/sync-sandbox-tenants-use-case"
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// StaleAfter is how long a sandbox can stay provisioning or deleting before another worker takes it
+// back. Copies run in one transaction, so a copy that died left nothing behind.
+const StaleAfter = 30 * time.Minute
+
+type RunTenantSandboxUseCase struct {
+	queue    ports.TenantSandboxJobQueue
+	data     ports.SandboxData
+	policies ports.SandboxPolicies
+	sync     *sync_sandbox_tenants_use_case.SyncSandboxTenantsUseCase
+}
+
+func NewRunTenantSandboxUseCase(queue ports.TenantSandboxJobQueue, data ports.SandboxData,
+	policies ports.SandboxPolicies) *RunTenantSandboxUseCase {
+	return &RunTenantSandboxUseCase{
+		queue:    queue,
+		data:     data,
+		policies: policies,
+		sync:     sync_sandbox_tenants_use_case.NewSyncSandboxTenantsUseCase(queue, policies),
+	}
+}
+
+// Execute removes the next sandbox due, or else copies the next pending one. It returns false when
+// there is nothing to do. A sandbox that cannot be copied is removed and marked failed, its error is
+// returned for logging.
+func (uc *RunTenantSandboxUseCase) Execute(ctx context.Context) (bool, error) {
+	due, err := uc.queue.ClaimNextDue(ctx, time.Now().UTC(), StaleAfter)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+	if due != nil {
+		if err := uc.remove(ctx, due.SandboxTenantID); err != nil {
+			return true, errors.NewInfrastructureError(fmt.Sprintf("failed to remove sandbox %s", due.SandboxTenantID), err)
+		}
+		if err := uc.queue.MarkDeleted(ctx, due.ID); err != nil {
+			return true, errors.PropagateError(err)
+		}
+		return true, nil
+	}
+
+	sandbox, err := uc.queue.ClaimNextPending(ctx, StaleAfter)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+	if sandbox == nil {
+		return false, nil
+	}
+
+	if err := uc.provision(ctx, sandbox); err != nil {
+		// The copy is removed before the sandbox is marked failed, a failed removal is retried as
+		// the sandbox stays provisioning until it is stale
+		if removeErr := uc.remove(ctx, sandbox.SandboxTenantID); removeErr != nil {
+			return true, errors.NewInfrastructureError(fmt.Sprintf("failed to remove sandbox %s after %v", sandbox.SandboxTenantID, err), removeErr)
+		}
+		if markErr := uc.queue.MarkFailed(ctx, sandbox.ID, err.Error()); markErr != nil {
+			return true, errors.PropagateError(markErr)
+		}
+		return true, errors.NewInfrastructureError(fmt.Sprintf("failed to copy %s into sandbox %s", sandbox.TenantID, sandbox.SandboxTenantID), err)
+	}
+	return true, nil
+}
+
+func (uc *RunTenantSandboxUseCase) provision(ctx context.Context, sandbox *entities.TenantSandbox) error {
+	staff, roles, err := uc.policies.CopyRoles(ctx, sandbox.TenantID, sandbox.SandboxTenantID, entities.LearnerRoles)
+	if err != nil {
+		return fmt.Errorf("roles: %w", err)
+	}
+	tables, err := uc.data.Copy(ctx, sandbox.TenantID, sandbox.SandboxTenantID, staff)
+	if err != nil {
+		return fmt.Errorf("copy: %w", err)
+	}
+
+	sandbox.Tables, sandbox.Roles = tables, roles
+	if err := uc.queue.MarkReady(ctx, sandbox); err != nil {
+		return fmt.Errorf("ready: %w", err)
+	}
+	if err := uc.sync.Execute(ctx); err != nil {
+		return fmt.Errorf("policies: %w", err)
+	}
+	return nil
+}
+
+// remove takes the policies away from the sandbox before its data, nobody can use a sandbox half gone
+func (uc *RunTenantSandboxUseCase) remove(ctx context.Context, sandboxTenantID string) error {
+	if err := uc.policies.RemoveRoles(ctx, sandboxTenantID); err != nil {
+		return fmt.Errorf("roles: %w", err)
+	}
+	if err := uc.sync.Execute(ctx); err != nil {
+		return fmt.Errorf("policies: %w", err)
+	}
+	if err := uc.data.Remove(ctx, sandboxTenantID); err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	return nil
+}
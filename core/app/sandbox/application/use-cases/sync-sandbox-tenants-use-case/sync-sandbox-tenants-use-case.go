@@ -0,0 +1,33 @@
+package sync_sandbox_tenants_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SyncSandboxTenantsUseCase struct {
+	queue    ports.TenantSandboxJobQueue
+	policies ports.SandboxPolicies
+}
+
+func NewSyncSandboxTenantsUseCase(queue ports.TenantSandboxJobQueue, policies ports.SandboxPolicies) *SyncSandboxTenantsUseCase {
+	return &SyncSandboxTenantsUseCase{
+		queue:    queue,
+		policies: policies,
+	}
+}
+
+// Execute makes the policies apply to the sandboxes ready to use and to no other. Every instance runs
+// it, the worker that readies or removes a sandbox runs it right away on its own.
+func (uc *SyncSandboxTenantsUseCase) Execute(ctx context.Context) error {
+	tenants, err := uc.queue.ListReadyTenants(ctx)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if err := uc.policies.Activate(ctx, tenants); err != nil {
+		return errors.PropagateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// SandboxSuffix is appended to the tenant ID of a school to name its sandbox
+const SandboxSuffix = "-sandbox"
+
+const (
+	// DefaultSandboxLifetime is how long a sandbox lives when its request does not say
+	DefaultSandboxLifetime = 7 * 24 * time.Hour
+	// MaxSandboxLifetime bounds the lifetime of a sandbox from the moment it is requested or extended
+	MaxSandboxLifetime = 30 * 24 * time.Hour
+)
+
+// LearnerRoles are not copied to sandboxes. Their holders only appear in a sandbox under a
+// pseudonym, staff train on the data of students they cannot identify.
+var LearnerRoles = []string{"student", "guardian"}
+
+type TenantSandboxStatus string
+
+const (
+	TenantSandboxStatusPending TenantSandboxStatus = "pending"
+	// TenantSandboxStatusProvisioning sandboxes are being copied, nobody can sign in to them yet
+	TenantSandboxStatusProvisioning TenantSandboxStatus = "provisioning"
+	TenantSandboxStatusReady        TenantSandboxStatus = "ready"
+	// TenantSandboxStatusDeleting sandboxes were deleted or expired, their data is being removed
+	TenantSandboxStatusDeleting TenantSandboxStatus = "deleting"
+	TenantSandboxStatusDeleted  TenantSandboxStatus = "deleted"
+	// TenantSandboxStatusFailed sandboxes could not be copied, whatever was copied is removed
+	TenantSandboxStatusFailed TenantSandboxStatus = "failed"
+)
+
+// TenantSandbox is a copy of a subset of the data of a tenant under a tenant of its own, for staff to
+// train on. A tenant has at most one sandbox alive at a time, it is removed when it expires.
+type TenantSandbox struct {
+	ID              string              `validate:"required,uuid"`
+	TenantID        string              `validate:"required"`
+	SandboxTenantID string              `validate:"required"`
+	Status          TenantSandboxStatus `validate:"required,oneof=pending provisioning ready deleting deleted failed"`
+	RequestedBy     string              `validate:"required"`
+	// Tables counts the rows copied per table
+	Tables map[string]int
+	// Roles counts the role assignments of staff copied from the tenant
+	Roles         int
+	FailureReason string
+	ExpiresAt     time.Time `validate:"required"`
+	CreatedAt     time.Time `validate:"required"`
+	UpdatedAt     time.Time `validate:"required"`
+	ReadyAt       *time.Time
+	DeletedAt     *time.Time
+}
+
+// SandboxTenantID returns the tenant ID of the sandbox of a tenant
+func SandboxTenantID(tenantID string) string {
+	return tenantID + SandboxSuffix
+}
+
+// IsSandboxTenant reports whether a tenant ID names a sandbox
+func IsSandboxTenant(tenantID string) bool {
+	return strings.HasSuffix(tenantID, SandboxSuffix)
+}
+
+func NewTenantSandbox(id string, tenantID string, requestedBy string, lifetime time.Duration, now time.Time) (*TenantSandbox, error) {
+	if IsSandboxTenant(tenantID) {
+		return nil, appErrors.NewValidationError("Sandboxes cannot have sandboxes",
+			map[string]any{"tenant_id": "is a sandbox"}, nil)
+	}
+	if err := validateLifetime(lifetime); err != nil {
+		return nil, err
+	}
+
+	sandbox := &TenantSandbox{
+		ID:              id,
+		TenantID:        tenantID,
+		SandboxTenantID: SandboxTenantID(tenantID),
+		Status:          TenantSandboxStatusPending,
+		RequestedBy:     requestedBy,
+		Tables:          map[string]int{},
+		ExpiresAt:       now.Add(lifetime),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := validate.Struct(sandbox); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("TenantSandbox domain model instance not valid", errorMap, err)
+	}
+
+	return sandbox, nil
+}
+
+// Alive reports whether the sandbox is requested, being copied or ready
+func (s *TenantSandbox) Alive() bool {
+	switch s.Status {
+	case TenantSandboxStatusPending, TenantSandboxStatusProvisioning, TenantSandboxStatusReady:
+		return true
+	}
+	return false
+}
+
+// Expired reports whether the sandbox outlived its lifetime at now
+func (s *TenantSandbox) Expired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// Extend makes the sandbox live for lifetime from now
+func (s *TenantSandbox) Extend(lifetime time.Duration, now time.Time) error {
+	if err := validateLifetime(lifetime); err != nil {
+		return err
+	}
+	s.ExpiresAt = now.Add(lifetime)
+	s.UpdatedAt = now
+	return nil
+}
+
+func validateLifetime(lifetime time.Duration) error {
+	if lifetime <= 0 || lifetime > MaxSandboxLifetime {
+		return appErrors.NewValidationError("Sandbox lifetime not valid",
+			map[string]any{"lifetime": "must be positive and at most " + MaxSandboxLifetime.String()}, nil)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	TenantSandboxNotFoundError      errors2.ErrorCode = "TENANT_SANDBOX_NOT_FOUND"
+	TenantSandboxAlreadyExistsError errors2.ErrorCode = "TENANT_SANDBOX_ALREADY_EXISTS"
+)
+
+func NewTenantSandboxNotFoundError(tenantID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TenantSandboxNotFoundError.String(),
+			Message: "The tenant has no sandbox",
+			Context: map[string]any{
+				"tenant_id": tenantID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TenantSandboxNotFoundError.String()),
+		},
+	}
+}
+
+func NewTenantSandboxAlreadyExistsError(sandboxID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TenantSandboxAlreadyExistsError.String(),
+			Message: "The tenant already has a sandbox, delete it before requesting another",
+			Context: map[string]any{
+				"sandbox_id": sandboxID,
+				"status":     status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TenantSandboxAlreadyExistsError.String()),
+		},
+	}
+}
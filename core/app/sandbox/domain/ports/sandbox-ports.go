@@ -0,0 +1,56 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/entities"
+)
+
+type TenantSandboxRepository interface {
+	// Create fails with TenantSandboxAlreadyExistsError when the tenant has a sandbox alive
+	Create(ctx context.Context, sandbox *entities.TenantSandbox) error
+	// FindCurrent returns the sandbox alive or being deleted of the tenant, nil when it has none
+	FindCurrent(ctx context.Context, tenantID string) (*entities.TenantSandbox, error)
+	// Update saves the expiry of the sandbox
+	Update(ctx context.Context, sandbox *entities.TenantSandbox) error
+	TenantSandboxJobQueue
+}
+
+// TenantSandboxJobQueue is the worker side of the repository. The claims skip sandboxes another worker
+// holds and take back the ones left by a worker that died more than staleAfter ago. They return nil
+// when there is nothing to do.
+type TenantSandboxJobQueue interface {
+	// ClaimNextPending moves the oldest pending sandbox to provisioning
+	ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.TenantSandbox, error)
+	// ClaimNextDue moves the oldest sandbox pending or ready that expired at now to deleting
+	ClaimNextDue(ctx context.Context, now time.Time, staleAfter time.Duration) (*entities.TenantSandbox, error)
+	MarkReady(ctx context.Context, sandbox *entities.TenantSandbox) error
+	// MarkDeleted records that the data of the sandbox is gone
+	MarkDeleted(ctx context.Context, sandboxID string) error
+	MarkFailed(ctx context.Context, sandboxID string, reason string) error
+	// ListReadyTenants returns the tenant IDs of the sandboxes ready to use
+	ListReadyTenants(ctx context.Context) ([]string, error)
+}
+
+// SandboxData copies the data of a tenant into its sandbox and removes it
+type SandboxData interface {
+	// Copy copies a subset of the data of tenantID into sandboxTenantID in one transaction. Users
+	// other than keep appear under pseudonyms and free text is left out. It returns the rows copied
+	// per table.
+	Copy(ctx context.Context, tenantID string, sandboxTenantID string, keep []string) (map[string]int, error)
+	// Remove deletes every row of sandboxTenantID, including the ones written in the sandbox
+	Remove(ctx context.Context, sandboxTenantID string) error
+}
+
+// SandboxPolicies gives sandboxes policies of their own
+type SandboxPolicies interface {
+	// CopyRoles copies the role assignments of users of tenantID, other than the ones of roles
+	// excluded and of service accounts, to sandboxTenantID. It returns the users whose roles were
+	// copied and how many assignments were.
+	CopyRoles(ctx context.Context, tenantID string, sandboxTenantID string, excluded []string) ([]string, int, error)
+	// RemoveRoles removes every role assignment of sandboxTenantID
+	RemoveRoles(ctx context.Context, sandboxTenantID string) error
+	// Activate makes the policies of the roles apply to sandboxTenantIDs, and to no other sandbox
+	Activate(ctx context.Context, sandboxTenantIDs []string) error
+}
@@ -0,0 +1,43 @@
+package create_saved_view_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/filtering"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CreateSavedViewCommand struct {
+	TenantID string `validate:"required"`
+	OwnerID  string `validate:"required"`
+	List     string `validate:"required"`
+	Name     string `validate:"required,max=100"`
+	OrderBy  string `validate:"max=200"`
+	Filter   string
+	Shared   bool
+}
+
+func NewCreateSavedViewCommand(tenantID string, ownerID string, list string, name string, orderBy string, filter string,
+	shared bool) (*CreateSavedViewCommand, error) {
+	command := &CreateSavedViewCommand{
+		TenantID: tenantID,
+		OwnerID:  ownerID,
+		List:     list,
+		Name:     name,
+		OrderBy:  orderBy,
+		Filter:   filter,
+		Shared:   shared,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if len(filter) > filtering.MaxExpressionLength {
+		return nil, errors.NewValidationError("Invalid saved view", map[string]any{"filter": "Filter is too long"}, nil)
+	}
+
+	return command, nil
+}
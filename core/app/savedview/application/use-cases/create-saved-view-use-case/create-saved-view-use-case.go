@@ -0,0 +1,41 @@
+package create_saved_view_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/entities"
+	savedViewErrors "github.com/nahualventure/class-backend/core/app/savedview/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type CreateSavedViewUseCase struct {
+	views ports.SavedViewRepository
+	lists entities.ListDefinitions
+}
+
+func NewCreateSavedViewUseCase(views ports.SavedViewRepository, lists entities.ListDefinitions) *CreateSavedViewUseCase {
+	return &CreateSavedViewUseCase{
+		views: views,
+		lists: lists,
+	}
+}
+
+// Execute saves the view once its order and filter are valid for the list
+func (uc *CreateSavedViewUseCase) Execute(ctx context.Context, cmd *CreateSavedViewCommand) (*entities.SavedView, error) {
+	if err := uc.lists.Check(cmd.List, cmd.OrderBy, cmd.Filter); err != nil {
+		return nil, err
+	}
+
+	view := entities.NewSavedView(cmd.TenantID, cmd.OwnerID, cmd.List, cmd.Name, cmd.OrderBy, cmd.Filter, cmd.Shared,
+		time.Now().UTC())
+	created, err := uc.views.Create(ctx, view)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !created {
+		return nil, savedViewErrors.NewSavedViewNameTakenError(cmd.List, cmd.Name)
+	}
+	return view, nil
+}
@@ -0,0 +1,30 @@
+package delete_saved_view_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type DeleteSavedViewCommand struct {
+	TenantID string `validate:"required"`
+	UserID   string `validate:"required"`
+	ViewID   string `validate:"required,uuid"`
+}
+
+func NewDeleteSavedViewCommand(tenantID string, userID string, viewID string) (*DeleteSavedViewCommand, error) {
+	command := &DeleteSavedViewCommand{
+		TenantID: tenantID,
+		UserID:   userID,
+		ViewID:   viewID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,38 @@
+package delete_saved_view_use_case
+
+import (
+	"context"
+
+	savedViewErrors "github.com/nahualventure/class-backend/core/app/savedview/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type DeleteSavedViewUseCase struct {
+	views ports.SavedViewRepository
+}
+
+func NewDeleteSavedViewUseCase(views ports.SavedViewRepository) *DeleteSavedViewUseCase {
+	return &DeleteSavedViewUseCase{
+		views: views,
+	}
+}
+
+// Execute deletes the view of its owner, shared views are read only for the other users
+func (uc *DeleteSavedViewUseCase) Execute(ctx context.Context, cmd *DeleteSavedViewCommand) error {
+	view, err := uc.views.FindByID(ctx, cmd.TenantID, cmd.ViewID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if view == nil || !view.VisibleTo(cmd.UserID) {
+		return savedViewErrors.NewSavedViewNotFoundError(cmd.ViewID)
+	}
+	if view.OwnerID != cmd.UserID {
+		return errors.NewForbiddenError("saved_view", "manage")
+	}
+
+	if err := uc.views.Delete(ctx, cmd.TenantID, cmd.ViewID); err != nil {
+		return errors.PropagateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package get_saved_view_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetSavedViewCommand struct {
+	TenantID string `validate:"required"`
+	UserID   string `validate:"required"`
+	ViewID   string `validate:"required,uuid"`
+	// List is set by the list endpoints applying the view, views of other lists are not found
+	List string
+}
+
+func NewGetSavedViewCommand(tenantID string, userID string, viewID string, list string) (*GetSavedViewCommand, error) {
+	command := &GetSavedViewCommand{
+		TenantID: tenantID,
+		UserID:   userID,
+		ViewID:   viewID,
+		List:     list,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
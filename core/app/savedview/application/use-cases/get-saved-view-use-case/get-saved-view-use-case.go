@@ -0,0 +1,32 @@
+package get_saved_view_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/entities"
+	savedViewErrors "github.com/nahualventure/class-backend/core/app/savedview/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetSavedViewUseCase struct {
+	views ports.SavedViewRepository
+}
+
+func NewGetSavedViewUseCase(views ports.SavedViewRepository) *GetSavedViewUseCase {
+	return &GetSavedViewUseCase{
+		views: views,
+	}
+}
+
+// Execute returns a view the user saved or one shared in the tenant
+func (uc *GetSavedViewUseCase) Execute(ctx context.Context, cmd *GetSavedViewCommand) (*entities.SavedView, error) {
+	view, err := uc.views.FindByID(ctx, cmd.TenantID, cmd.ViewID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if view == nil || !view.VisibleTo(cmd.UserID) || (cmd.List != "" && view.List != cmd.List) {
+		return nil, savedViewErrors.NewSavedViewNotFoundError(cmd.ViewID)
+	}
+	return view, nil
+}
@@ -0,0 +1,31 @@
+package list_saved_views_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListSavedViewsCommand struct {
+	TenantID string `validate:"required"`
+	UserID   string `validate:"required"`
+	// List only returns the views of this list when set
+	List string
+}
+
+func NewListSavedViewsCommand(tenantID string, userID string, list string) (*ListSavedViewsCommand, error) {
+	command := &ListSavedViewsCommand{
+		TenantID: tenantID,
+		UserID:   userID,
+		List:     list,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,28 @@
+package list_saved_views_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListSavedViewsUseCase struct {
+	views ports.SavedViewRepository
+}
+
+func NewListSavedViewsUseCase(views ports.SavedViewRepository) *ListSavedViewsUseCase {
+	return &ListSavedViewsUseCase{
+		views: views,
+	}
+}
+
+// Execute returns the views the user saved and those shared in the tenant
+func (uc *ListSavedViewsUseCase) Execute(ctx context.Context, cmd *ListSavedViewsCommand) ([]*entities.SavedView, error) {
+	views, err := uc.views.ListVisible(ctx, cmd.TenantID, cmd.UserID, cmd.List)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return views, nil
+}
@@ -0,0 +1,43 @@
+package update_saved_view_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/filtering"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type UpdateSavedViewCommand struct {
+	TenantID string `validate:"required"`
+	UserID   string `validate:"required"`
+	ViewID   string `validate:"required,uuid"`
+	Name     string `validate:"required,max=100"`
+	OrderBy  string `validate:"max=200"`
+	Filter   string
+	Shared   bool
+}
+
+func NewUpdateSavedViewCommand(tenantID string, userID string, viewID string, name string, orderBy string, filter string,
+	shared bool) (*UpdateSavedViewCommand, error) {
+	command := &UpdateSavedViewCommand{
+		TenantID: tenantID,
+		UserID:   userID,
+		ViewID:   viewID,
+		Name:     name,
+		OrderBy:  orderBy,
+		Filter:   filter,
+		Shared:   shared,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if len(filter) > filtering.MaxExpressionLength {
+		return nil, errors.NewValidationError("Invalid saved view", map[string]any{"filter": "Filter is too long"}, nil)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,50 @@
+package update_saved_view_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/entities"
+	savedViewErrors "github.com/nahualventure/class-backend/core/app/savedview/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type UpdateSavedViewUseCase struct {
+	views ports.SavedViewRepository
+	lists entities.ListDefinitions
+}
+
+func NewUpdateSavedViewUseCase(views ports.SavedViewRepository, lists entities.ListDefinitions) *UpdateSavedViewUseCase {
+	return &UpdateSavedViewUseCase{
+		views: views,
+		lists: lists,
+	}
+}
+
+// Execute replaces the view of its owner, shared views are read only for the other users
+func (uc *UpdateSavedViewUseCase) Execute(ctx context.Context, cmd *UpdateSavedViewCommand) (*entities.SavedView, error) {
+	view, err := uc.views.FindByID(ctx, cmd.TenantID, cmd.ViewID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if view == nil || !view.VisibleTo(cmd.UserID) {
+		return nil, savedViewErrors.NewSavedViewNotFoundError(cmd.ViewID)
+	}
+	if view.OwnerID != cmd.UserID {
+		return nil, errors.NewForbiddenError("saved_view", "manage")
+	}
+	if err := uc.lists.Check(view.List, cmd.OrderBy, cmd.Filter); err != nil {
+		return nil, err
+	}
+
+	view.Update(cmd.Name, cmd.OrderBy, cmd.Filter, cmd.Shared, time.Now().UTC())
+	saved, err := uc.views.Save(ctx, view)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !saved {
+		return nil, savedViewErrors.NewSavedViewNameTakenError(view.List, cmd.Name)
+	}
+	return view, nil
+}
@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/filtering"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+
+	"github.com/google/uuid"
+)
+
+// ListDefinition is what a list endpoint can be sorted and filtered by, the same allowlists its
+// requests are checked against
+type ListDefinition struct {
+	OrderFields  []string
+	FilterFields filtering.Fields
+}
+
+// ListDefinitions are the list endpoints views can be saved for, by the name of their list such as
+// "users"
+type ListDefinitions map[string]ListDefinition
+
+// Names returns the names of the lists, sorted
+func (d ListDefinitions) Names() []string {
+	names := make([]string, 0, len(d))
+	for name := range d {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check validates an order and filter for the list with the pagination and filtering rules of its
+// endpoint, so a saved view never fails once applied
+func (d ListDefinitions) Check(list string, orderBy string, filter string) error {
+	definition, ok := d[list]
+	if !ok {
+		return errors.NewValidationError("Invalid saved view", map[string]any{
+			"list": "Must be one of " + strings.Join(d.Names(), ", "),
+		}, nil)
+	}
+	if err := pagination.ValidateOrderBy(orderBy, definition.OrderFields); err != nil {
+		return err
+	}
+	if _, err := filtering.Parse(filter, definition.FilterFields); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SavedView is an order and filter of a list endpoint saved by name
+type SavedView struct {
+	ID       string
+	TenantID string
+	// OwnerID is the user who saved the view, only they change or delete it
+	OwnerID string
+	List    string
+	Name    string
+	OrderBy string
+	Filter  string
+	// Shared views are listed to every user of the tenant, the others to their owner only
+	Shared    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewSavedView(tenantID string, ownerID string, list string, name string, orderBy string, filter string,
+	shared bool, now time.Time) *SavedView {
+	return &SavedView{
+		ID:        uuid.NewString(),
+		TenantID:  tenantID,
+		OwnerID:   ownerID,
+		List:      list,
+		Name:      name,
+		OrderBy:   orderBy,
+		Filter:    filter,
+		Shared:    shared,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func (v *SavedView) Update(name string, orderBy string, filter string, shared bool, now time.Time) {
+	v.Name = name
+	v.OrderBy = orderBy
+	v.Filter = filter
+	v.Shared = shared
+	v.UpdatedAt = now
+}
+
+// VisibleTo reports whether the user may read and apply the view
+func (v *SavedView) VisibleTo(userID string) bool {
+	return v.Shared || v.OwnerID == userID
+}
+
+// Apply returns the order and filter of a request to the list with the view, those of the view fill
+// in the ones the request leaves empty
+func (v *SavedView) Apply(orderBy string, filter string) (string, string) {
+	if strings.TrimSpace(orderBy) == "" {
+		orderBy = v.OrderBy
+	}
+	if strings.TrimSpace(filter) == "" {
+		filter = v.Filter
+	}
+	return orderBy, filter
+}
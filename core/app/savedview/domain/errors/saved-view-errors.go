@@ -0,0 +1,45 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// SavedViewNotFoundError is also returned for views of other users that are not shared
+	SavedViewNotFoundError errors2.ErrorCode = "SAVED_VIEW_NOT_FOUND"
+	// SavedViewNameTakenError refuses a second view with the name of a view of the same user and list
+	SavedViewNameTakenError errors2.ErrorCode = "SAVED_VIEW_NAME_TAKEN"
+)
+
+func NewSavedViewNotFoundError(viewID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SavedViewNotFoundError.String(),
+			Message: "Saved view not found",
+			Context: map[string]any{
+				"view_id": viewID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SavedViewNotFoundError.String()),
+		},
+	}
+}
+
+func NewSavedViewNameTakenError(list string, name string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SavedViewNameTakenError.String(),
+			Message: "You already have a view of this list with this name",
+			Context: map[string]any{
+				"list": list,
+				"name": name,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SavedViewNameTakenError.String()),
+		},
+	}
+}
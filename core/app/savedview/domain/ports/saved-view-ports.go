@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/entities"
+)
+
+type SavedViewRepository interface {
+	// Create stores a new view, it reports false without storing it when the owner already has a
+	// view of the list with its name
+	Create(ctx context.Context, view *entities.SavedView) (bool, error)
+	// Save replaces a stored view, it reports false without saving it when the new name is taken
+	Save(ctx context.Context, view *entities.SavedView) (bool, error)
+	// FindByID returns the view, nil when the tenant has no such view
+	FindByID(ctx context.Context, tenantID string, viewID string) (*entities.SavedView, error)
+	// ListVisible returns the views of the list the user owns and those shared in the tenant, ordered
+	// by name. Views of every list are returned when list is empty.
+	ListVisible(ctx context.Context, tenantID string, userID string, list string) ([]*entities.SavedView, error)
+	Delete(ctx context.Context, tenantID string, viewID string) error
+}
@@ -0,0 +1,97 @@
+package scan_quarantined_files_use_case
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/scanning/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/scanning/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+)
+
+// BatchSize is how many files are scanned per batch
+const BatchSize = 20
+
+type ScanQuarantinedFilesUseCase struct {
+	quarantine ports.QuarantineRepository
+	scanner    ports.VirusScanner
+	files      storage.FileStorage
+}
+
+func NewScanQuarantinedFilesUseCase(quarantine ports.QuarantineRepository, scanner ports.VirusScanner,
+	files storage.FileStorage) *ScanQuarantinedFilesUseCase {
+	return &ScanQuarantinedFilesUseCase{
+		quarantine: quarantine,
+		scanner:    scanner,
+		files:      files,
+	}
+}
+
+// Execute scans the quarantined files in batches and returns how many it recorded. Infected files are
+// deleted from the storage once their result is recorded. Files the scanner fails on stay pending for
+// the next run, the first failure is returned for logging after the rest of the batch is scanned.
+func (uc *ScanQuarantinedFilesUseCase) Execute(ctx context.Context) (int, error) {
+	scanned := 0
+	for {
+		pending, err := uc.quarantine.ListPending(ctx, BatchSize)
+		if err != nil {
+			return scanned, errors.PropagateError(err)
+		}
+
+		var failed error
+		for _, file := range pending {
+			recorded, err := uc.scan(ctx, file)
+			if err != nil {
+				if failed == nil {
+					failed = err
+				}
+				continue
+			}
+			if recorded {
+				scanned++
+			}
+		}
+
+		if failed != nil {
+			return scanned, failed
+		}
+		if len(pending) < BatchSize {
+			return scanned, nil
+		}
+	}
+}
+
+func (uc *ScanQuarantinedFilesUseCase) scan(ctx context.Context, file *entities.QuarantinedFile) (bool, error) {
+	content, err := uc.files.Get(ctx, file.StorageKey)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+
+	var scan *entities.FileScan
+	if content == nil {
+		scan, err = entities.NewFileScan(file, storage.ScanMissing, "", uc.scanner.Name(), time.Now().UTC())
+	} else {
+		verdict, scanErr := uc.scanner.Scan(ctx, content)
+		if scanErr != nil {
+			return false, errors.NewInfrastructureError(fmt.Sprintf("failed to scan %s %s", file.Kind, file.ID), scanErr)
+		}
+		scan, err = entities.ScanOf(file, verdict, uc.scanner.Name(), time.Now().UTC())
+	}
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+
+	recorded, err := uc.quarantine.Record(ctx, scan)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+	if recorded && scan.Status == storage.ScanInfected {
+		if err := uc.files.Delete(ctx, file.StorageKey); err != nil {
+			return true, errors.PropagateError(err)
+		}
+	}
+
+	return recorded, nil
+}
@@ -0,0 +1,82 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// FileKind is the record an uploaded file belongs to
+type FileKind string
+
+const (
+	FileKindResourceVersion FileKind = "resource_version"
+	FileKindAttachment      FileKind = "attachment"
+)
+
+// QuarantinedFile is an uploaded file waiting for its virus scan
+type QuarantinedFile struct {
+	Kind       FileKind
+	ID         string
+	TenantID   string
+	StorageKey string
+	FileName   string
+	UploadedBy string
+	UploadedAt time.Time
+}
+
+// ScanVerdict is what the scanner found in a file, Signature names the virus of infected files
+type ScanVerdict struct {
+	Infected  bool
+	Signature string
+}
+
+// FileScan is the result of the scan of a quarantined file. Clean files are released, infected ones
+// are rejected and deleted.
+type FileScan struct {
+	File      *QuarantinedFile   `validate:"required"`
+	Status    storage.ScanStatus `validate:"required,oneof=clean infected missing"`
+	Signature string             `validate:"required_if=Status infected,max=255"`
+	Scanner   string             `validate:"required,max=50"`
+	ScannedAt time.Time          `validate:"required"`
+}
+
+func NewFileScan(file *QuarantinedFile, status storage.ScanStatus, signature string, scanner string, scannedAt time.Time) (*FileScan, error) {
+	scan := &FileScan{
+		File:      file,
+		Status:    status,
+		Signature: signature,
+		Scanner:   scanner,
+		ScannedAt: scannedAt,
+	}
+
+	if err := validate.Struct(scan); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("FileScan domain model instance not valid", errorMap, err)
+	}
+
+	return scan, nil
+}
+
+// ScanOf returns the scan of the file with the verdict of the scanner
+func ScanOf(file *QuarantinedFile, verdict *ScanVerdict, scanner string, scannedAt time.Time) (*FileScan, error) {
+	if verdict.Infected {
+		signature := verdict.Signature
+		if signature == "" {
+			signature = "unknown"
+		}
+		return NewFileScan(file, storage.ScanInfected, signature, scanner, scannedAt)
+	}
+	return NewFileScan(file, storage.ScanClean, "", scanner, scannedAt)
+}
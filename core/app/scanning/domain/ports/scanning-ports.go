@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/scanning/domain/entities"
+)
+
+// QuarantineRepository holds the uploaded files of every tenant until they are scanned
+type QuarantineRepository interface {
+	// ListPending returns the files waiting for their scan, oldest upload first
+	ListPending(ctx context.Context, limit int) ([]*entities.QuarantinedFile, error)
+	// Record stores the result on the file and queues the file.scanned or file.rejected event, a clean
+	// resource version becomes the current one of its resource. Returns false when the file was no
+	// longer pending.
+	Record(ctx context.Context, scan *entities.FileScan) (bool, error)
+}
+
+// VirusScanner is the antivirus engine files are scanned with
+type VirusScanner interface {
+	// Name identifies the scanner in the scan results
+	Name() string
+	Scan(ctx context.Context, content []byte) (*entities.ScanVerdict, error)
+}
@@ -0,0 +1,28 @@
+package get_section_change_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetSectionChangeCommand struct {
+	TenantID string `validate:"required"`
+	ChangeID string `validate:"required,uuid"`
+}
+
+func NewGetSectionChangeCommand(tenantID string, changeID string) (*GetSectionChangeCommand, error) {
+	command := &GetSectionChangeCommand{
+		TenantID: tenantID,
+		ChangeID: changeID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,31 @@
+package get_section_change_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/sections/domain/entities"
+	sectionErrors "github.com/nahualventure/class-backend/core/app/sections/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/sections/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetSectionChangeUseCase struct {
+	changes ports.SectionChangeRepository
+}
+
+func NewGetSectionChangeUseCase(changes ports.SectionChangeRepository) *GetSectionChangeUseCase {
+	return &GetSectionChangeUseCase{
+		changes: changes,
+	}
+}
+
+func (uc *GetSectionChangeUseCase) Execute(ctx context.Context, cmd *GetSectionChangeCommand) (*entities.SectionChange, error) {
+	change, err := uc.changes.FindByID(ctx, cmd.TenantID, cmd.ChangeID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if change == nil {
+		return nil, sectionErrors.NewSectionChangeNotFoundError(cmd.ChangeID)
+	}
+	return change, nil
+}
@@ -0,0 +1,35 @@
+package list_section_changes_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListSectionChangesCommand struct {
+	TenantID string `validate:"required"`
+	// ClassID keeps the changes the class was the source or target of, all of them when empty
+	ClassID string `validate:"omitempty,uuid"`
+	Page    *pagination.PageRequest
+}
+
+func NewListSectionChangesCommand(tenantID string, classID string, pageSize int, cursor string) (*ListSectionChangesCommand, error) {
+	page, err := pagination.NewPageRequest(pageSize, cursor, "", nil, pagination.OrderBy{
+		Field:     "created_at",
+		Direction: pagination.Descending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	command := &ListSectionChangesCommand{TenantID: tenantID, ClassID: classID, Page: page}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,28 @@
+package list_section_changes_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/sections/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/sections/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type ListSectionChangesUseCase struct {
+	changes ports.SectionChangeRepository
+}
+
+func NewListSectionChangesUseCase(changes ports.SectionChangeRepository) *ListSectionChangesUseCase {
+	return &ListSectionChangesUseCase{
+		changes: changes,
+	}
+}
+
+func (uc *ListSectionChangesUseCase) Execute(ctx context.Context, cmd *ListSectionChangesCommand) (*pagination.Page[*entities.SectionChange], error) {
+	page, err := uc.changes.List(ctx, cmd.TenantID, cmd.ClassID, cmd.Page)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return page, nil
+}
@@ -0,0 +1,36 @@
+package merge_sections_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type MergeSectionsCommand struct {
+	TenantID string `validate:"required"`
+	// SourceClassID is the section merged away, TargetClassID the one its students join
+	SourceClassID string `validate:"required,uuid"`
+	TargetClassID string `validate:"required,uuid,nefield=SourceClassID"`
+	Reason        string `validate:"max=500"`
+	RequestedBy   string `validate:"required"`
+}
+
+func NewMergeSectionsCommand(tenantID string, sourceClassID string, targetClassID string, reason string,
+	requestedBy string) (*MergeSectionsCommand, error) {
+	command := &MergeSectionsCommand{
+		TenantID:      tenantID,
+		SourceClassID: sourceClassID,
+		TargetClassID: targetClassID,
+		Reason:        reason,
+		RequestedBy:   requestedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,36 @@
+package merge_sections_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/sections/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/sections/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type MergeSectionsUseCase struct {
+	changes ports.SectionChangeRepository
+}
+
+func NewMergeSectionsUseCase(changes ports.SectionChangeRepository) *MergeSectionsUseCase {
+	return &MergeSectionsUseCase{
+		changes: changes,
+	}
+}
+
+// Execute moves every student of the source section to the target with their grades, extensions and
+// submissions, and the assignments of the source with them. It fails without moving anything when a
+// student has records in both sections or the target has no room for the enrolled ones.
+func (uc *MergeSectionsUseCase) Execute(ctx context.Context, cmd *MergeSectionsCommand) (*entities.SectionChange, error) {
+	change, err := uc.changes.Apply(ctx, cmd.TenantID, cmd.SourceClassID, cmd.TargetClassID,
+		func(source *entities.Section, target *entities.Section) (*entities.SectionChange, error) {
+			return entities.PlanMerge(uuid.New().String(), cmd.TenantID, source, target, cmd.Reason, cmd.RequestedBy, time.Now().UTC())
+		})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return change, nil
+}
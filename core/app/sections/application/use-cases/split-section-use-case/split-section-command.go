@@ -0,0 +1,38 @@
+package split_section_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SplitSectionCommand struct {
+	TenantID      string `validate:"required"`
+	SourceClassID string `validate:"required,uuid"`
+	TargetClassID string `validate:"required,uuid,nefield=SourceClassID"`
+	// StudentIDs are the students moving to the target, every other student stays
+	StudentIDs  []string `validate:"required,min=1,max=500,unique,dive,required"`
+	Reason      string   `validate:"max=500"`
+	RequestedBy string   `validate:"required"`
+}
+
+func NewSplitSectionCommand(tenantID string, sourceClassID string, targetClassID string, studentIDs []string, reason string,
+	requestedBy string) (*SplitSectionCommand, error) {
+	command := &SplitSectionCommand{
+		TenantID:      tenantID,
+		SourceClassID: sourceClassID,
+		TargetClassID: targetClassID,
+		StudentIDs:    studentIDs,
+		Reason:        reason,
+		RequestedBy:   requestedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,36 @@
+package split_section_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/sections/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/sections/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type SplitSectionUseCase struct {
+	changes ports.SectionChangeRepository
+}
+
+func NewSplitSectionUseCase(changes ports.SectionChangeRepository) *SplitSectionUseCase {
+	return &SplitSectionUseCase{
+		changes: changes,
+	}
+}
+
+// Execute moves the students from the source section to the target with their grades, extensions and
+// submissions. The assignments of the source are copied to the target, both sections keep them.
+func (uc *SplitSectionUseCase) Execute(ctx context.Context, cmd *SplitSectionCommand) (*entities.SectionChange, error) {
+	change, err := uc.changes.Apply(ctx, cmd.TenantID, cmd.SourceClassID, cmd.TargetClassID,
+		func(source *entities.Section, target *entities.Section) (*entities.SectionChange, error) {
+			return entities.PlanSplit(uuid.New().String(), cmd.TenantID, source, target, cmd.StudentIDs, cmd.Reason,
+				cmd.RequestedBy, time.Now().UTC())
+		})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return change, nil
+}
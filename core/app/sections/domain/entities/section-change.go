@@ -0,0 +1,164 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	sectionErrors "github.com/nahualventure/class-backend/core/app/sections/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SectionChangeKind string
+
+const (
+	// SectionChangeMerge moves every student of the source section to the target along with its
+	// assignments, the source is left empty
+	SectionChangeMerge SectionChangeKind = "merge"
+	// SectionChangeSplit moves some students of the source section to the target, the assignments of
+	// the source are copied to the target so their grades keep an assignment to belong to
+	SectionChangeSplit SectionChangeKind = "split"
+)
+
+// Section is a class as merges and splits read it, with its students locked until the change is saved
+type Section struct {
+	ClassID string
+	// Capacity is nil for classes without a seat limit, Held counts the seats held by checkouts
+	Capacity *int
+	Held     int
+	Enrolled []string
+	// Graded are the students with grades in the class, enrolled or not
+	Graded []string
+}
+
+// Students returns the students with records in the section, enrolled or graded, once each
+func (s *Section) Students() []string {
+	students := slices.Clone(s.Enrolled)
+	for _, studentID := range s.Graded {
+		if !slices.Contains(students, studentID) {
+			students = append(students, studentID)
+		}
+	}
+	return students
+}
+
+// MovedRecords counts what a merge or split moved to the target section
+type MovedRecords struct {
+	Enrollments int `json:"enrollments"`
+	Grades      int `json:"grades"`
+	Extensions  int `json:"extensions"`
+	// Submissions are the similarity checks of the work the students turned in
+	Submissions int `json:"submissions"`
+	// Assignments counts the assignment deadlines moved by merges and copied by splits, their standards
+	// tags go with them
+	Assignments int `json:"assignments"`
+}
+
+// SectionChange is the audit record of a merge or split. Attendance stays with the source section,
+// it belongs to the meetings it was taken in.
+type SectionChange struct {
+	ID            string            `validate:"required,uuid"`
+	TenantID      string            `validate:"required"`
+	Kind          SectionChangeKind `validate:"required,oneof=merge split"`
+	SourceClassID string            `validate:"required,uuid"`
+	TargetClassID string            `validate:"required,uuid,nefield=SourceClassID"`
+	// StudentIDs are the students moved to the target
+	StudentIDs  []string
+	Reason      string `validate:"max=500"`
+	Moved       MovedRecords
+	RequestedBy string    `validate:"required"`
+	CreatedAt   time.Time `validate:"required"`
+}
+
+func NewSectionChange(id string, tenantID string, kind SectionChangeKind, sourceClassID string, targetClassID string,
+	studentIDs []string, reason string, requestedBy string, createdAt time.Time) (*SectionChange, error) {
+	if studentIDs == nil {
+		studentIDs = []string{}
+	}
+	change := &SectionChange{
+		ID:            id,
+		TenantID:      tenantID,
+		Kind:          kind,
+		SourceClassID: sourceClassID,
+		TargetClassID: targetClassID,
+		StudentIDs:    studentIDs,
+		Reason:        reason,
+		RequestedBy:   requestedBy,
+		CreatedAt:     createdAt,
+	}
+
+	if err := validate.Struct(change); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("SectionChange domain model instance not valid", errorMap, err)
+	}
+
+	return change, nil
+}
+
+// PlanMerge checks that every student of the source section can move to the target and returns the
+// merge, students enrolled or graded in both sections are never merged
+func PlanMerge(id string, tenantID string, source *Section, target *Section, reason string, requestedBy string,
+	now time.Time) (*SectionChange, error) {
+	if err := checkMove(source, target, source.Students()); err != nil {
+		return nil, err
+	}
+	return NewSectionChange(id, tenantID, SectionChangeMerge, source.ClassID, target.ClassID, source.Students(), reason,
+		requestedBy, now)
+}
+
+// PlanSplit checks that the students are enrolled in the source section and can move to the target
+// and returns the split
+func PlanSplit(id string, tenantID string, source *Section, target *Section, studentIDs []string, reason string,
+	requestedBy string, now time.Time) (*SectionChange, error) {
+	var missing []string
+	for _, studentID := range studentIDs {
+		if !slices.Contains(source.Enrolled, studentID) {
+			missing = append(missing, studentID)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, sectionErrors.NewStudentsNotInSectionError(source.ClassID, missing)
+	}
+	if err := checkMove(source, target, studentIDs); err != nil {
+		return nil, err
+	}
+	return NewSectionChange(id, tenantID, SectionChangeSplit, source.ClassID, target.ClassID, studentIDs, reason,
+		requestedBy, now)
+}
+
+// checkMove refuses students with records in the target, their grades would clash, and moves that
+// seat more students than the target has room for
+func checkMove(source *Section, target *Section, studentIDs []string) error {
+	targetStudents := target.Students()
+	var conflicting []string
+	seated := 0
+	for _, studentID := range studentIDs {
+		if slices.Contains(targetStudents, studentID) {
+			conflicting = append(conflicting, studentID)
+		}
+		if slices.Contains(source.Enrolled, studentID) {
+			seated++
+		}
+	}
+	if len(conflicting) > 0 {
+		return sectionErrors.NewSectionStudentsConflictError(target.ClassID, conflicting)
+	}
+
+	if target.Capacity != nil {
+		available := *target.Capacity - len(target.Enrolled) - target.Held
+		if seated > available {
+			return sectionErrors.NewSectionCapacityExceededError(target.ClassID, *target.Capacity, seated, max(available, 0))
+		}
+	}
+	return nil
+}
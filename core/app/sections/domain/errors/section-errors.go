@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	SectionChangeNotFoundError errors2.ErrorCode = "SECTION_CHANGE_NOT_FOUND"
+	// StudentsNotInSectionError is returned when splitting students that are not enrolled in the section
+	StudentsNotInSectionError errors2.ErrorCode = "STUDENTS_NOT_IN_SECTION"
+	// SectionStudentsConflictError is returned when moving students that are enrolled or graded in the
+	// target section already
+	SectionStudentsConflictError errors2.ErrorCode = "SECTION_STUDENTS_CONFLICT"
+	// SectionCapacityExceededError is returned when the target section has fewer seats left than the
+	// enrolled students to move
+	SectionCapacityExceededError errors2.ErrorCode = "SECTION_CAPACITY_EXCEEDED"
+)
+
+func NewSectionChangeNotFoundError(changeID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SectionChangeNotFoundError.String(),
+			Message: "Section change not found",
+			Context: map[string]any{
+				"change_id": changeID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SectionChangeNotFoundError.String()),
+		},
+	}
+}
+
+func NewStudentsNotInSectionError(classID string, studentIDs []string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    StudentsNotInSectionError.String(),
+			Message: "Only students enrolled in the section can be split from it",
+			Context: map[string]any{
+				"class_id":    classID,
+				"student_ids": studentIDs,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(StudentsNotInSectionError.String()),
+		},
+	}
+}
+
+func NewSectionStudentsConflictError(classID string, studentIDs []string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SectionStudentsConflictError.String(),
+			Message: "Students are enrolled or graded in the target section already",
+			Context: map[string]any{
+				"class_id":    classID,
+				"student_ids": studentIDs,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SectionStudentsConflictError.String()),
+		},
+	}
+}
+
+func NewSectionCapacityExceededError(classID string, capacity int, moving int, available int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SectionCapacityExceededError.String(),
+			Message: "The target section has no room for the students to move",
+			Context: map[string]any{
+				"class_id":  classID,
+				"capacity":  capacity,
+				"moving":    moving,
+				"available": available,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SectionCapacityExceededError.String()),
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/sections/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+type SectionChangeRepository interface {
+	// Apply locks the seats of both classes, so no checkout enrolls students in them meanwhile, reads
+	// them as sections and calls plan. The change plan returns is applied in the same transaction:
+	// the enrollments, grades, extensions and submissions of its students move to the target, merges
+	// move the assignments of the source and splits copy them. Moved grades get a moved event,
+	// the change is saved with the counts of what moved and SectionStudentsMoved is appended to the
+	// outbox. Nothing changes when plan fails, its error is returned as it is.
+	Apply(ctx context.Context, tenantID string, sourceClassID string, targetClassID string,
+		plan func(source *entities.Section, target *entities.Section) (*entities.SectionChange, error)) (*entities.SectionChange, error)
+	// FindByID returns nil when the tenant has no such change
+	FindByID(ctx context.Context, tenantID string, changeID string) (*entities.SectionChange, error)
+	// List returns the changes of the tenant newest first, the ones of a class when classID is set
+	List(ctx context.Context, tenantID string, classID string, page *pagination.PageRequest) (*pagination.Page[*entities.SectionChange], error)
+}
@@ -0,0 +1,32 @@
+package authenticate_service_account_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type AuthenticateServiceAccountCommand struct {
+	Token string `validate:"required"`
+	// TenantID is the tenant the caller claims, empty to act in the tenant of the account
+	TenantID string
+	// ClientIP is checked against keys bound to an address
+	ClientIP string
+}
+
+func NewAuthenticateServiceAccountCommand(token string, tenantID string, clientIP string) (*AuthenticateServiceAccountCommand, error) {
+	command := &AuthenticateServiceAccountCommand{
+		Token:    token,
+		TenantID: tenantID,
+		ClientIP: clientIP,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,62 @@
+package authenticate_service_account_use_case
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// touchInterval bounds how often the last use of a key is written, every request would be a write
+const touchInterval = time.Minute
+
+type AuthenticateServiceAccountUseCase struct {
+	serviceAccountRepo ports.ServiceAccountRepository
+	clock              clock.Clock
+}
+
+func NewAuthenticateServiceAccountUseCase(serviceAccountRepo ports.ServiceAccountRepository) *AuthenticateServiceAccountUseCase {
+	return &AuthenticateServiceAccountUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		clock:              clock.System,
+	}
+}
+
+// WithClock changes the clock key expiry and the last use of keys are checked with
+func (uc *AuthenticateServiceAccountUseCase) WithClock(clock clock.Clock) *AuthenticateServiceAccountUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute returns an active key and its account. Unknown, revoked and expired keys, keys of disabled
+// accounts, keys sent for another tenant and keys sent from an address they are not bound to are all
+// refused with the same error.
+func (uc *AuthenticateServiceAccountUseCase) Execute(ctx context.Context, cmd *AuthenticateServiceAccountCommand) (*entities.ServiceAccount,
+	*entities.ServiceAccountKey, error) {
+	keyID, secret, ok := entities.ParseServiceAccountToken(cmd.Token)
+	if !ok {
+		return nil, nil, errors.NewUnauthorizedError("Invalid service account key")
+	}
+	account, key, err := uc.serviceAccountRepo.FindByKey(ctx, keyID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+
+	now := uc.clock.Now().UTC()
+	if key == nil || !key.Authenticates(account, secret, now) || !key.AllowsIP(cmd.ClientIP) ||
+		(cmd.TenantID != "" && cmd.TenantID != account.TenantID) {
+		return nil, nil, errors.NewUnauthorizedError("Invalid service account key")
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= touchInterval {
+		if err := uc.serviceAccountRepo.TouchKey(ctx, key.ID, now); err != nil {
+			log.Printf("failed to record the use of service account key %s: %v", key.ID, err)
+		}
+	}
+
+	return account, key, nil
+}
@@ -0,0 +1,54 @@
+package create_service_account_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CreateServiceAccountCommand struct {
+	TenantID    string `validate:"required"`
+	CreatedBy   string `validate:"required"`
+	Name        string `validate:"required,max=100"`
+	Description string `validate:"max=500"`
+	// OwnerID defaults to the creator
+	OwnerID string   `validate:"required"`
+	Roles   []string `validate:"required,min=1,dive,required"`
+	// ClientType defaults to server
+	ClientType string `validate:"required,oneof=server web mobile kiosk"`
+	// BoundIP binds the first key to an address, required by policies that bind keys
+	BoundIP string `validate:"omitempty,ip"`
+	// Scopes narrow the first key, it gets the permissions of Roles when empty
+	Scopes []string `validate:"dive,required"`
+}
+
+func NewCreateServiceAccountCommand(tenantID string, createdBy string, name string, description string, ownerID string, roles []string,
+	clientType string, boundIP string, scopes []string) (*CreateServiceAccountCommand, error) {
+	if ownerID == "" {
+		ownerID = createdBy
+	}
+	if clientType == "" {
+		clientType = entities.ClientTypeServer
+	}
+	command := &CreateServiceAccountCommand{
+		TenantID:    tenantID,
+		CreatedBy:   createdBy,
+		Name:        name,
+		Description: description,
+		OwnerID:     ownerID,
+		Roles:       roles,
+		ClientType:  clientType,
+		BoundIP:     boundIP,
+		Scopes:      scopes,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,87 @@
+package create_service_account_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+
+	"github.com/google/uuid"
+)
+
+type CreateServiceAccountUseCase struct {
+	serviceAccountRepo ports.ServiceAccountRepository
+	roles              ports.RoleDirectory
+	tokenPolicies      ports.TokenPolicyRepository
+	clock              clock.Clock
+}
+
+func NewCreateServiceAccountUseCase(serviceAccountRepo ports.ServiceAccountRepository, roles ports.RoleDirectory,
+	tokenPolicies ports.TokenPolicyRepository) *CreateServiceAccountUseCase {
+	return &CreateServiceAccountUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		roles:              roles,
+		tokenPolicies:      tokenPolicies,
+		clock:              clock.System,
+	}
+}
+
+// WithClock changes the clock the first key is issued at, its expiry follows from it
+func (uc *CreateServiceAccountUseCase) WithClock(clock clock.Clock) *CreateServiceAccountUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute creates the account with its roles and a first key issued under the token policy of its
+// client type, the token of the key is returned once
+func (uc *CreateServiceAccountUseCase) Execute(ctx context.Context, cmd *CreateServiceAccountCommand) (*entities.ServiceAccount, string, error) {
+	ownerRoles, err := uc.roles.Roles(ctx, cmd.OwnerID, cmd.TenantID)
+	if err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+	if err := entities.CheckAssignment(cmd.Roles, uc.roles.AvailableRoles(), cmd.OwnerID, ownerRoles); err != nil {
+		return nil, "", err
+	}
+
+	policy, err := uc.tokenPolicies.Find(ctx, cmd.TenantID, cmd.ClientType)
+	if err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+	if policy == nil {
+		policy = entities.DefaultTokenPolicy(cmd.TenantID, cmd.ClientType)
+	}
+
+	now := uc.clock.Now().UTC()
+	account, err := entities.NewServiceAccount(uuid.New().String(), cmd.TenantID, cmd.Name, cmd.Description, cmd.OwnerID, cmd.CreatedBy,
+		cmd.ClientType, now)
+	if err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+	account.Roles, _ = entities.RoleChanges(nil, cmd.Roles)
+	scopes, err := entities.NarrowScopes(uc.roles.Permissions(account.Roles), cmd.Scopes)
+	if err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+	key, token, err := account.IssueKey(now, policy, cmd.BoundIP, scopes)
+	if err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+
+	if err := uc.serviceAccountRepo.Create(ctx, account, entities.ServiceAccountChange{
+		Type:    events.ServiceAccountCreated,
+		ActorID: cmd.CreatedBy,
+		KeyID:   key.ID,
+	}); err != nil {
+		return nil, "", errors.PropagateError(err)
+	}
+	for _, role := range account.Roles {
+		if err := uc.roles.Assign(ctx, account.Subject(), role, account.TenantID); err != nil {
+			return nil, "", errors.PropagateError(err)
+		}
+	}
+
+	return account, token, nil
+}
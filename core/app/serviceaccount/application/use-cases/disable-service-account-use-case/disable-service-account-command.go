@@ -0,0 +1,30 @@
+package disable_service_account_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type DisableServiceAccountCommand struct {
+	TenantID         string `validate:"required"`
+	ServiceAccountID string `validate:"required,uuid"`
+	DisabledBy       string `validate:"required"`
+}
+
+func NewDisableServiceAccountCommand(tenantID string, serviceAccountID string, disabledBy string) (*DisableServiceAccountCommand, error) {
+	command := &DisableServiceAccountCommand{
+		TenantID:         tenantID,
+		ServiceAccountID: serviceAccountID,
+		DisabledBy:       disabledBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
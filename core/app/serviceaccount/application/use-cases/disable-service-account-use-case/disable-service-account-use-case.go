@@ -0,0 +1,58 @@
+package disable_service_account_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	serviceAccountErrors "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+)
+
+type DisableServiceAccountUseCase struct {
+	serviceAccountRepo ports.ServiceAccountRepository
+	roles              ports.RoleDirectory
+}
+
+func NewDisableServiceAccountUseCase(serviceAccountRepo ports.ServiceAccountRepository, roles ports.RoleDirectory) *DisableServiceAccountUseCase {
+	return &DisableServiceAccountUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		roles:              roles,
+	}
+}
+
+// Execute revokes every key of the account and removes its roles. The account is kept for the audit
+// log and cannot be enabled again, disabling it twice changes nothing.
+func (uc *DisableServiceAccountUseCase) Execute(ctx context.Context, cmd *DisableServiceAccountCommand) (*entities.ServiceAccount, error) {
+	account, err := uc.serviceAccountRepo.FindByID(ctx, cmd.TenantID, cmd.ServiceAccountID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if account == nil {
+		return nil, serviceAccountErrors.NewServiceAccountNotFoundError(cmd.ServiceAccountID)
+	}
+	if account.Disabled() {
+		return account, nil
+	}
+
+	if account.Roles, err = uc.roles.Roles(ctx, account.Subject(), account.TenantID); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	revoked := account.Disable(time.Now().UTC())
+	if err := uc.serviceAccountRepo.Save(ctx, account, revoked, entities.ServiceAccountChange{
+		Type:    events.ServiceAccountDisabled,
+		ActorID: cmd.DisabledBy,
+	}); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	for _, role := range account.Roles {
+		if err := uc.roles.Remove(ctx, account.Subject(), role, account.TenantID); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	}
+	account.Roles = nil
+
+	return account, nil
+}
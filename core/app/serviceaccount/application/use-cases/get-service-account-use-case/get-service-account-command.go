@@ -0,0 +1,28 @@
+package get_service_account_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetServiceAccountCommand struct {
+	TenantID         string `validate:"required"`
+	ServiceAccountID string `validate:"required,uuid"`
+}
+
+func NewGetServiceAccountCommand(tenantID string, serviceAccountID string) (*GetServiceAccountCommand, error) {
+	command := &GetServiceAccountCommand{
+		TenantID:         tenantID,
+		ServiceAccountID: serviceAccountID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
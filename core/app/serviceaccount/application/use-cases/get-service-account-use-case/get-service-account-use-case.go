@@ -0,0 +1,38 @@
+package get_service_account_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	serviceAccountErrors "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type GetServiceAccountUseCase struct {
+	serviceAccountRepo ports.ServiceAccountRepository
+	roles              ports.RoleDirectory
+}
+
+func NewGetServiceAccountUseCase(serviceAccountRepo ports.ServiceAccountRepository, roles ports.RoleDirectory) *GetServiceAccountUseCase {
+	return &GetServiceAccountUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		roles:              roles,
+	}
+}
+
+func (uc *GetServiceAccountUseCase) Execute(ctx context.Context, cmd *GetServiceAccountCommand) (*entities.ServiceAccount, error) {
+	account, err := uc.serviceAccountRepo.FindByID(ctx, cmd.TenantID, cmd.ServiceAccountID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if account == nil {
+		return nil, serviceAccountErrors.NewServiceAccountNotFoundError(cmd.ServiceAccountID)
+	}
+
+	if account.Roles, err = uc.roles.Roles(ctx, account.Subject(), account.TenantID); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return account, nil
+}
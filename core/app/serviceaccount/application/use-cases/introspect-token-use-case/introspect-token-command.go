@@ -0,0 +1,29 @@
+package introspect_token_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type IntrospectTokenCommand struct {
+	Token string `validate:"required"`
+	// TenantID is the tenant of the caller, tokens of other tenants are reported inactive
+	TenantID string `validate:"required"`
+}
+
+func NewIntrospectTokenCommand(token string, tenantID string) (*IntrospectTokenCommand, error) {
+	command := &IntrospectTokenCommand{
+		Token:    token,
+		TenantID: tenantID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,55 @@
+package introspect_token_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type IntrospectTokenUseCase struct {
+	serviceAccountRepo ports.ServiceAccountRepository
+	roles              ports.RoleDirectory
+	clock              clock.Clock
+}
+
+func NewIntrospectTokenUseCase(serviceAccountRepo ports.ServiceAccountRepository, roles ports.RoleDirectory) *IntrospectTokenUseCase {
+	return &IntrospectTokenUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		roles:              roles,
+		clock:              clock.System,
+	}
+}
+
+// WithClock changes the clock tokens are reported active or expired by
+func (uc *IntrospectTokenUseCase) WithClock(clock clock.Clock) *IntrospectTokenUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute tells whether a token is active, like RFC 7662. Malformed, unknown, revoked and expired
+// tokens, tokens of disabled accounts and tokens of other tenants are all just inactive.
+func (uc *IntrospectTokenUseCase) Execute(ctx context.Context, cmd *IntrospectTokenCommand) (*entities.TokenIntrospection, error) {
+	keyID, secret, ok := entities.ParseServiceAccountToken(cmd.Token)
+	if !ok {
+		return &entities.TokenIntrospection{}, nil
+	}
+	account, key, err := uc.serviceAccountRepo.FindByKey(ctx, keyID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if key == nil || !key.Authenticates(account, secret, uc.clock.Now().UTC()) || account.TenantID != cmd.TenantID {
+		return &entities.TokenIntrospection{}, nil
+	}
+
+	if account.Roles, err = uc.roles.Roles(ctx, account.Subject(), account.TenantID); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	scopes := key.Scopes
+	if len(scopes) == 0 {
+		scopes = uc.roles.Permissions(account.Roles)
+	}
+	return &entities.TokenIntrospection{Active: true, Account: account, Key: key, Scopes: scopes}, nil
+}
@@ -0,0 +1,37 @@
+package list_service_accounts_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListServiceAccountsUseCase struct {
+	serviceAccountRepo ports.ServiceAccountRepository
+	roles              ports.RoleDirectory
+}
+
+func NewListServiceAccountsUseCase(serviceAccountRepo ports.ServiceAccountRepository, roles ports.RoleDirectory) *ListServiceAccountsUseCase {
+	return &ListServiceAccountsUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		roles:              roles,
+	}
+}
+
+// Execute returns the accounts of the tenant with their keys and roles, disabled ones included
+func (uc *ListServiceAccountsUseCase) Execute(ctx context.Context, cmd *ListServiceAccountsCommand) ([]*entities.ServiceAccount, error) {
+	accounts, err := uc.serviceAccountRepo.List(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	for _, account := range accounts {
+		if account.Roles, err = uc.roles.Roles(ctx, account.Subject(), account.TenantID); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	}
+
+	return accounts, nil
+}
@@ -0,0 +1,41 @@
+package list_token_policies_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type ListTokenPoliciesUseCase struct {
+	tokenPolicies ports.TokenPolicyRepository
+}
+
+func NewListTokenPoliciesUseCase(tokenPolicies ports.TokenPolicyRepository) *ListTokenPoliciesUseCase {
+	return &ListTokenPoliciesUseCase{
+		tokenPolicies: tokenPolicies,
+	}
+}
+
+// Execute returns the policy of every client type, the default of the types the tenant did not set
+func (uc *ListTokenPoliciesUseCase) Execute(ctx context.Context, cmd *ListTokenPoliciesCommand) ([]*entities.TokenPolicy, error) {
+	stored, err := uc.tokenPolicies.List(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	byType := make(map[string]*entities.TokenPolicy, len(stored))
+	for _, policy := range stored {
+		byType[policy.ClientType] = policy
+	}
+
+	policies := make([]*entities.TokenPolicy, 0, len(entities.ClientTypes))
+	for _, clientType := range entities.ClientTypes {
+		policy, ok := byType[clientType]
+		if !ok {
+			policy = entities.DefaultTokenPolicy(cmd.TenantID, clientType)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
@@ -0,0 +1,32 @@
+package revoke_service_account_key_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RevokeServiceAccountKeyCommand struct {
+	TenantID         string `validate:"required"`
+	ServiceAccountID string `validate:"required,uuid"`
+	KeyID            string `validate:"required,uuid"`
+	RevokedBy        string `validate:"required"`
+}
+
+func NewRevokeServiceAccountKeyCommand(tenantID string, serviceAccountID string, keyID string, revokedBy string) (*RevokeServiceAccountKeyCommand, error) {
+	command := &RevokeServiceAccountKeyCommand{
+		TenantID:         tenantID,
+		ServiceAccountID: serviceAccountID,
+		KeyID:            keyID,
+		RevokedBy:        revokedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
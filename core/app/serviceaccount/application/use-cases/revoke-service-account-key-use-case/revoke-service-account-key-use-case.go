@@ -0,0 +1,56 @@
+package revoke_service_account_key_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	serviceAccountErrors "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+)
+
+type RevokeServiceAccountKeyUseCase struct {
+	serviceAccountRepo ports.ServiceAccountRepository
+	roles              ports.RoleDirectory
+}
+
+func NewRevokeServiceAccountKeyUseCase(serviceAccountRepo ports.ServiceAccountRepository, roles ports.RoleDirectory) *RevokeServiceAccountKeyUseCase {
+	return &RevokeServiceAccountKeyUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		roles:              roles,
+	}
+}
+
+// Execute stops a key at once, revoking a revoked key changes nothing
+func (uc *RevokeServiceAccountKeyUseCase) Execute(ctx context.Context, cmd *RevokeServiceAccountKeyCommand) (*entities.ServiceAccount, error) {
+	account, err := uc.serviceAccountRepo.FindByID(ctx, cmd.TenantID, cmd.ServiceAccountID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if account == nil {
+		return nil, serviceAccountErrors.NewServiceAccountNotFoundError(cmd.ServiceAccountID)
+	}
+	if account.Roles, err = uc.roles.Roles(ctx, account.Subject(), account.TenantID); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	key, revoked := account.RevokeKey(cmd.KeyID, time.Now().UTC())
+	if key == nil {
+		return nil, serviceAccountErrors.NewServiceAccountKeyNotFoundError(account.ID, cmd.KeyID)
+	}
+	if !revoked {
+		return account, nil
+	}
+
+	if err := uc.serviceAccountRepo.Save(ctx, account, []*entities.ServiceAccountKey{key}, entities.ServiceAccountChange{
+		Type:    events.ServiceAccountKeyRevoked,
+		ActorID: cmd.RevokedBy,
+		KeyID:   key.ID,
+	}); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return account, nil
+}
@@ -0,0 +1,26 @@
+package revoke_token_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RevokeTokenCommand struct {
+	Token string `validate:"required"`
+}
+
+func NewRevokeTokenCommand(token string) (*RevokeTokenCommand, error) {
+	command := &RevokeTokenCommand{
+		Token: token,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
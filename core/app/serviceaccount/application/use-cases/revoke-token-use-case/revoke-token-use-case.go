@@ -0,0 +1,58 @@
+package revoke_token_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+)
+
+type RevokeTokenUseCase struct {
+	serviceAccountRepo ports.ServiceAccountRepository
+	clock              clock.Clock
+}
+
+func NewRevokeTokenUseCase(serviceAccountRepo ports.ServiceAccountRepository) *RevokeTokenUseCase {
+	return &RevokeTokenUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		clock:              clock.System,
+	}
+}
+
+// WithClock changes the clock tokens are checked and revoked at
+func (uc *RevokeTokenUseCase) WithClock(clock clock.Clock) *RevokeTokenUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute revokes a token on behalf of whoever holds it, like RFC 7009: holding the token is the
+// proof. Tokens that are not active are left as they are and no error tells them apart, so the
+// endpoint reveals nothing about the tokens sent to it.
+func (uc *RevokeTokenUseCase) Execute(ctx context.Context, cmd *RevokeTokenCommand) error {
+	keyID, secret, ok := entities.ParseServiceAccountToken(cmd.Token)
+	if !ok {
+		return nil
+	}
+	account, key, err := uc.serviceAccountRepo.FindByKey(ctx, keyID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	now := uc.clock.Now().UTC()
+	if key == nil || !key.Authenticates(account, secret, now) {
+		return nil
+	}
+
+	account.RevokeKey(key.ID, now)
+	err = uc.serviceAccountRepo.Save(ctx, account, []*entities.ServiceAccountKey{key}, entities.ServiceAccountChange{
+		Type:    events.ServiceAccountKeyRevoked,
+		ActorID: account.Subject(),
+		KeyID:   key.ID,
+	})
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package rotate_service_account_key_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RotateServiceAccountKeyCommand struct {
+	TenantID         string `validate:"required"`
+	ServiceAccountID string `validate:"required,uuid"`
+	RotatedBy        string `validate:"required"`
+	// Grace is how long the replaced keys keep working, 0 stops them at once and nil uses the token
+	// policy of the account
+	Grace *time.Duration `validate:"omitempty,min=0s,max=168h"`
+	// BoundIP binds the new key to an address, the address of the replaced key when empty
+	BoundIP string `validate:"omitempty,ip"`
+	// Scopes narrow the new key, it gets the permissions of the current roles of the account when
+	// empty
+	Scopes []string `validate:"dive,required"`
+}
+
+func NewRotateServiceAccountKeyCommand(tenantID string, serviceAccountID string, rotatedBy string, grace *time.Duration,
+	boundIP string, scopes []string) (*RotateServiceAccountKeyCommand, error) {
+	command := &RotateServiceAccountKeyCommand{
+		TenantID:         tenantID,
+		ServiceAccountID: serviceAccountID,
+		RotatedBy:        rotatedBy,
+		Grace:            grace,
+		BoundIP:          boundIP,
+		Scopes:           scopes,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,86 @@
+package rotate_service_account_key_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	serviceAccountErrors "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+)
+
+type RotateServiceAccountKeyUseCase struct {
+	serviceAccountRepo ports.ServiceAccountRepository
+	roles              ports.RoleDirectory
+	tokenPolicies      ports.TokenPolicyRepository
+	clock              clock.Clock
+}
+
+func NewRotateServiceAccountKeyUseCase(serviceAccountRepo ports.ServiceAccountRepository, roles ports.RoleDirectory,
+	tokenPolicies ports.TokenPolicyRepository) *RotateServiceAccountKeyUseCase {
+	return &RotateServiceAccountKeyUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		roles:              roles,
+		tokenPolicies:      tokenPolicies,
+		clock:              clock.System,
+	}
+}
+
+// WithClock changes the clock new keys are issued and grace periods start at
+func (uc *RotateServiceAccountKeyUseCase) WithClock(clock clock.Clock) *RotateServiceAccountKeyUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute issues a new key under the current token policy of the account and expires the active ones
+// after the grace period, the token of the new key is returned once
+func (uc *RotateServiceAccountKeyUseCase) Execute(ctx context.Context, cmd *RotateServiceAccountKeyCommand) (*entities.ServiceAccount, *entities.ServiceAccountKey, string, error) {
+	account, err := uc.serviceAccountRepo.FindByID(ctx, cmd.TenantID, cmd.ServiceAccountID)
+	if err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	if account == nil {
+		return nil, nil, "", serviceAccountErrors.NewServiceAccountNotFoundError(cmd.ServiceAccountID)
+	}
+	if account.Roles, err = uc.roles.Roles(ctx, account.Subject(), account.TenantID); err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	if account.Disabled() {
+		return nil, nil, "", serviceAccountErrors.NewServiceAccountDisabledError(account.ID)
+	}
+
+	policy, err := uc.tokenPolicies.Find(ctx, account.TenantID, account.ClientType)
+	if err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	if policy == nil {
+		policy = entities.DefaultTokenPolicy(account.TenantID, account.ClientType)
+	}
+	grace := policy.RotationGrace
+	if cmd.Grace != nil {
+		grace = *cmd.Grace
+	}
+
+	scopes, err := entities.NarrowScopes(uc.roles.Permissions(account.Roles), cmd.Scopes)
+	if err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+
+	changed, token, err := account.Rotate(uc.clock.Now().UTC(), policy, grace, cmd.BoundIP, scopes)
+	if err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+	key := changed[len(changed)-1]
+
+	if err := uc.serviceAccountRepo.Save(ctx, account, changed, entities.ServiceAccountChange{
+		Type:    events.ServiceAccountKeyIssued,
+		ActorID: cmd.RotatedBy,
+		KeyID:   key.ID,
+	}); err != nil {
+		return nil, nil, "", errors.PropagateError(err)
+	}
+
+	return account, key, token, nil
+}
@@ -0,0 +1,39 @@
+package set_token_policy_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SetTokenPolicyCommand struct {
+	TenantID      string        `validate:"required"`
+	ClientType    string        `validate:"required,oneof=server web mobile kiosk"`
+	KeyLifetime   time.Duration `validate:"min=0s,max=8760h"`
+	RotationGrace time.Duration `validate:"min=0s,max=168h"`
+	BindIP        bool
+	UpdatedBy     string `validate:"required"`
+}
+
+func NewSetTokenPolicyCommand(tenantID string, clientType string, keyLifetime time.Duration, rotationGrace time.Duration, bindIP bool,
+	updatedBy string) (*SetTokenPolicyCommand, error) {
+	command := &SetTokenPolicyCommand{
+		TenantID:      tenantID,
+		ClientType:    clientType,
+		KeyLifetime:   keyLifetime,
+		RotationGrace: rotationGrace,
+		BindIP:        bindIP,
+		UpdatedBy:     updatedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
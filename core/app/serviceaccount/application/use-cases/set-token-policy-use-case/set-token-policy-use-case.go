@@ -0,0 +1,35 @@
+package set_token_policy_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SetTokenPolicyUseCase struct {
+	tokenPolicies ports.TokenPolicyRepository
+}
+
+func NewSetTokenPolicyUseCase(tokenPolicies ports.TokenPolicyRepository) *SetTokenPolicyUseCase {
+	return &SetTokenPolicyUseCase{
+		tokenPolicies: tokenPolicies,
+	}
+}
+
+// Execute replaces the policy of a client type. Keys issued from now on follow it, the keys already
+// issued keep their expiry and address.
+func (uc *SetTokenPolicyUseCase) Execute(ctx context.Context, cmd *SetTokenPolicyCommand) (*entities.TokenPolicy, error) {
+	policy, err := entities.NewTokenPolicy(cmd.TenantID, cmd.ClientType, cmd.KeyLifetime, cmd.RotationGrace, cmd.BindIP, cmd.UpdatedBy,
+		time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.tokenPolicies.Save(ctx, policy); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return policy, nil
+}
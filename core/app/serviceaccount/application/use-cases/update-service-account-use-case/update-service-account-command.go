@@ -0,0 +1,39 @@
+package update_service_account_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// UpdateServiceAccountCommand replaces the name, description, owner and roles of an account
+type UpdateServiceAccountCommand struct {
+	TenantID         string   `validate:"required"`
+	ServiceAccountID string   `validate:"required,uuid"`
+	UpdatedBy        string   `validate:"required"`
+	Name             string   `validate:"required,max=100"`
+	Description      string   `validate:"max=500"`
+	OwnerID          string   `validate:"required"`
+	Roles            []string `validate:"required,min=1,dive,required"`
+}
+
+func NewUpdateServiceAccountCommand(tenantID string, serviceAccountID string, updatedBy string, name string, description string, ownerID string, roles []string) (*UpdateServiceAccountCommand, error) {
+	command := &UpdateServiceAccountCommand{
+		TenantID:         tenantID,
+		ServiceAccountID: serviceAccountID,
+		UpdatedBy:        updatedBy,
+		Name:             name,
+		Description:      description,
+		OwnerID:          ownerID,
+		Roles:            roles,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,76 @@
+package update_service_account_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	serviceAccountErrors "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+)
+
+type UpdateServiceAccountUseCase struct {
+	serviceAccountRepo ports.ServiceAccountRepository
+	roles              ports.RoleDirectory
+}
+
+func NewUpdateServiceAccountUseCase(serviceAccountRepo ports.ServiceAccountRepository, roles ports.RoleDirectory) *UpdateServiceAccountUseCase {
+	return &UpdateServiceAccountUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		roles:              roles,
+	}
+}
+
+// Execute saves the account and then assigns and removes roles in Casbin until the account holds
+// exactly the roles of the command
+func (uc *UpdateServiceAccountUseCase) Execute(ctx context.Context, cmd *UpdateServiceAccountCommand) (*entities.ServiceAccount, error) {
+	account, err := uc.serviceAccountRepo.FindByID(ctx, cmd.TenantID, cmd.ServiceAccountID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if account == nil {
+		return nil, serviceAccountErrors.NewServiceAccountNotFoundError(cmd.ServiceAccountID)
+	}
+	if account.Disabled() {
+		return nil, serviceAccountErrors.NewServiceAccountDisabledError(account.ID)
+	}
+
+	ownerRoles, err := uc.roles.Roles(ctx, cmd.OwnerID, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if err := entities.CheckAssignment(cmd.Roles, uc.roles.AvailableRoles(), cmd.OwnerID, ownerRoles); err != nil {
+		return nil, err
+	}
+	current, err := uc.roles.Roles(ctx, account.Subject(), account.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := account.Update(cmd.Name, cmd.Description, cmd.OwnerID, time.Now().UTC()); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	assign, remove := entities.RoleChanges(current, cmd.Roles)
+	account.Roles, _ = entities.RoleChanges(nil, cmd.Roles)
+
+	if err := uc.serviceAccountRepo.Save(ctx, account, nil, entities.ServiceAccountChange{
+		Type:    events.ServiceAccountUpdated,
+		ActorID: cmd.UpdatedBy,
+	}); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	for _, role := range assign {
+		if err := uc.roles.Assign(ctx, account.Subject(), role, account.TenantID); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	}
+	for _, role := range remove {
+		if err := uc.roles.Remove(ctx, account.Subject(), role, account.TenantID); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	}
+
+	return account, nil
+}
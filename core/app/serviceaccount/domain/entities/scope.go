@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"slices"
+	"strings"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// ScopeAll stands for every resource or every action in a scope, like "all" in policies.yaml
+const ScopeAll = "all"
+
+// Scope is a permission a key may use, "resource:action" like the permissions of policies.yaml
+func Scope(resource string, action string) string {
+	return resource + ":" + action
+}
+
+// ScopesAllow reports whether one of scopes covers the permission
+func ScopesAllow(scopes []string, resource string, action string) bool {
+	return slices.ContainsFunc(scopes, func(scope string) bool {
+		return scopeCovers(scope, resource, action)
+	})
+}
+
+func scopeCovers(scope string, resource string, action string) bool {
+	scopeResource, scopeAction, ok := strings.Cut(scope, ":")
+	return ok && (scopeResource == ScopeAll || scopeResource == resource) && (scopeAction == ScopeAll || scopeAction == action)
+}
+
+// NarrowScopes returns the scopes of a new key: the permissions granted by the roles of its account,
+// or the requested ones when the client asks for less. Requesting a scope no role grants is refused,
+// a requested wildcard is only granted by the same wildcard.
+func NarrowScopes(granted []string, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return slices.Clone(granted), nil
+	}
+
+	errorMap := make(map[string]any)
+	var scopes []string
+	for _, scope := range requested {
+		resource, action, ok := strings.Cut(scope, ":")
+		switch {
+		case !ok || resource == "" || action == "":
+			errorMap["scopes"] = "scope " + scope + " is not resource:action"
+		case !ScopesAllow(granted, resource, action):
+			errorMap["scopes"] = "no role of the account grants " + scope
+		case !slices.Contains(scopes, scope):
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(errorMap) > 0 {
+		return nil, appErrors.NewValidationError("Scopes not valid", errorMap, nil)
+	}
+	return scopes, nil
+}
+
+// Allows reports whether the key may be used for the permission. Keys issued before keys had scopes
+// have none and are only limited by the roles of their account.
+func (k *ServiceAccountKey) Allows(resource string, action string) bool {
+	return len(k.Scopes) == 0 || ScopesAllow(k.Scopes, resource, action)
+}
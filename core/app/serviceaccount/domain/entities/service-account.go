@@ -0,0 +1,335 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+var validate = validator.New()
+
+// SubjectPrefix starts the subject of every service account, the ID they hold roles as in Casbin and
+// act as in audit entries, so they are never mistaken for users
+const SubjectPrefix = "sa:"
+
+// tokenPrefix starts every key handed out, tokens are "sa_<key ID>_<secret>"
+const tokenPrefix = "sa_"
+
+// DefaultRotationGrace is how long the keys replaced by a rotation keep working, so callers can roll
+// the new key out
+const DefaultRotationGrace = 24 * time.Hour
+
+// Kinds of actors recorded with changes, so audit entries tell integrations apart from people
+const (
+	ActorKindUser           = "user"
+	ActorKindServiceAccount = "service_account"
+)
+
+// ServiceAccount is a machine identity of a tenant, integrations call the API with one of its keys.
+// Its roles are held in Casbin by its subject, scoped to its tenant like the roles of users, and its
+// owner is the member of the tenant accountable for it.
+type ServiceAccount struct {
+	ID          string `validate:"required,uuid"`
+	TenantID    string `validate:"required"`
+	Name        string `validate:"required,max=100"`
+	Description string `validate:"max=500"`
+	OwnerID     string `validate:"required"`
+	CreatedBy   string `validate:"required"`
+	// ClientType picks the token policy its keys are issued under, it never changes
+	ClientType string `validate:"required,oneof=server web mobile kiosk"`
+	// Roles are loaded from Casbin, they are not stored with the account
+	Roles      []string
+	Keys       []*ServiceAccountKey
+	CreatedAt  time.Time `validate:"required"`
+	UpdatedAt  time.Time `validate:"required"`
+	DisabledAt *time.Time
+}
+
+// ServiceAccountKey authenticates a service account. Only the SHA-256 of its secret is stored, the
+// token is shown once when the key is issued.
+type ServiceAccountKey struct {
+	ID               string
+	ServiceAccountID string
+	TenantID         string
+	SecretHash       string
+	// BoundIP is the only address the key works from, empty for keys that work from anywhere
+	BoundIP string
+	// Scopes are the permissions the key may use, checked with the roles of the account
+	Scopes    []string
+	CreatedAt time.Time
+	// ExpiresAt is set on keys with a lifetime and on the keys replaced by a rotation, nil keys never
+	// expire
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+func NewServiceAccount(id string, tenantID string, name string, description string, ownerID string, createdBy string, clientType string,
+	now time.Time) (*ServiceAccount, error) {
+	account := &ServiceAccount{
+		ID:          id,
+		TenantID:    tenantID,
+		Name:        strings.TrimSpace(name),
+		Description: strings.TrimSpace(description),
+		OwnerID:     ownerID,
+		CreatedBy:   createdBy,
+		ClientType:  clientType,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := account.validate(); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (a *ServiceAccount) validate() error {
+	if err := validate.Struct(a); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return appErrors.NewDomainEntityValidationError("ServiceAccount domain model instance not valid", errorMap, err)
+	}
+	return nil
+}
+
+// Subject is the ID the account holds roles and acts as
+func (a *ServiceAccount) Subject() string {
+	return SubjectPrefix + a.ID
+}
+
+// IsServiceAccountSubject reports whether the subject of a request is a service account
+func IsServiceAccountSubject(subject string) bool {
+	return strings.HasPrefix(subject, SubjectPrefix)
+}
+
+// ActorKindOf returns the kind of actor of a subject
+func ActorKindOf(subject string) string {
+	if IsServiceAccountSubject(subject) {
+		return ActorKindServiceAccount
+	}
+	return ActorKindUser
+}
+
+func (a *ServiceAccount) Disabled() bool {
+	return a.DisabledAt != nil
+}
+
+// Update changes the name, description and owner of the account
+func (a *ServiceAccount) Update(name string, description string, ownerID string, now time.Time) error {
+	updated := *a
+	updated.Name = strings.TrimSpace(name)
+	updated.Description = strings.TrimSpace(description)
+	updated.OwnerID = ownerID
+	updated.UpdatedAt = now
+	if err := updated.validate(); err != nil {
+		return err
+	}
+	*a = updated
+	return nil
+}
+
+// IssueKey adds a key to the account under the token policy of its client type and returns it with
+// its token, the only time the token is known. The key is bound to boundIP when it is set and may
+// only use scopes.
+func (a *ServiceAccount) IssueKey(now time.Time, policy *TokenPolicy, boundIP string, scopes []string) (*ServiceAccountKey, string, error) {
+	boundIP, err := policy.boundIP(boundIP)
+	if err != nil {
+		return nil, "", err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", appErrors.NewInfrastructureError("generate service account key", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(secret)
+
+	key := &ServiceAccountKey{
+		ID:               uuid.New().String(),
+		ServiceAccountID: a.ID,
+		TenantID:         a.TenantID,
+		SecretHash:       hashSecret(encoded),
+		BoundIP:          boundIP,
+		Scopes:           scopes,
+		CreatedAt:        now,
+	}
+	if policy.KeyLifetime > 0 {
+		expiresAt := now.Add(policy.KeyLifetime)
+		key.ExpiresAt = &expiresAt
+	}
+	a.Keys = append(a.Keys, key)
+	return key, tokenPrefix + key.ID + "_" + encoded, nil
+}
+
+// Rotate issues a new key and expires the active ones after grace, keys already expiring sooner keep
+// their expiry. Without boundIP the new key keeps the address of the newest active key. The keys
+// changed and the new one are returned for saving.
+func (a *ServiceAccount) Rotate(now time.Time, policy *TokenPolicy, grace time.Duration, boundIP string, scopes []string) ([]*ServiceAccountKey, string, error) {
+	expiresAt := now.Add(grace)
+	var changed []*ServiceAccountKey
+	newestIP := ""
+	for _, key := range a.Keys {
+		if !key.ActiveAt(now) {
+			continue
+		}
+		newestIP = key.BoundIP
+		if key.ExpiresAt == nil || key.ExpiresAt.After(expiresAt) {
+			key.ExpiresAt = &expiresAt
+			changed = append(changed, key)
+		}
+	}
+	if boundIP == "" {
+		boundIP = newestIP
+	}
+
+	key, token, err := a.IssueKey(now, policy, boundIP, scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	return append(changed, key), token, nil
+}
+
+// RevokeKey stops a key at once and reports whether it was active before, the key is nil when the
+// account has no such key
+func (a *ServiceAccount) RevokeKey(keyID string, now time.Time) (*ServiceAccountKey, bool) {
+	for _, key := range a.Keys {
+		if key.ID == keyID {
+			if key.RevokedAt != nil {
+				return key, false
+			}
+			key.RevokedAt = &now
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// Disable revokes every key of the account, disabled accounts cannot be enabled again. The keys
+// revoked are returned for saving.
+func (a *ServiceAccount) Disable(now time.Time) []*ServiceAccountKey {
+	var revoked []*ServiceAccountKey
+	for _, key := range a.Keys {
+		if key.RevokedAt == nil {
+			key.RevokedAt = &now
+			revoked = append(revoked, key)
+		}
+	}
+	a.DisabledAt = &now
+	a.UpdatedAt = now
+	return revoked
+}
+
+// ActiveAt reports whether the key authenticates at now
+func (k *ServiceAccountKey) ActiveAt(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// AllowsIP reports whether the key works from the address ip
+func (k *ServiceAccountKey) AllowsIP(ip string) bool {
+	if k.BoundIP == "" {
+		return true
+	}
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && addr.Unmap().String() == k.BoundIP
+}
+
+// Authenticates reports whether secret is the secret of the key and the key of the account is
+// active at now
+func (k *ServiceAccountKey) Authenticates(account *ServiceAccount, secret string, now time.Time) bool {
+	return k.Matches(secret) && k.ActiveAt(now) && !account.Disabled()
+}
+
+// Matches compares secret with the stored hash in constant time
+func (k *ServiceAccountKey) Matches(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(k.SecretHash)) == 1
+}
+
+// ServiceAccountChange is what happened to an account, saved with it as an outbox event so the
+// audit log records every change with its actor
+type ServiceAccountChange struct {
+	// Type is one of the service account event types of the events package
+	Type    string
+	ActorID string
+	// KeyID is the key issued or revoked, empty for changes of the account itself
+	KeyID string
+}
+
+// CheckAssignment validates the roles and owner of an account. Roles must be defined in the policies
+// and the owner must be a person holding a role in the tenant.
+func CheckAssignment(roles []string, availableRoles []string, ownerID string, ownerRoles []string) error {
+	errorMap := make(map[string]any)
+	for _, role := range roles {
+		if !slices.Contains(availableRoles, role) {
+			errorMap["roles"] = "unknown role " + role
+			break
+		}
+	}
+	if IsServiceAccountSubject(ownerID) || len(ownerRoles) == 0 {
+		errorMap["owner_id"] = "the owner must be a member of the tenant"
+	}
+	if len(errorMap) > 0 {
+		return appErrors.NewValidationError("Service account roles or owner not valid", errorMap, nil)
+	}
+	return nil
+}
+
+// RoleChanges returns the roles to assign and to remove to go from current to wanted
+func RoleChanges(current []string, wanted []string) (assign []string, remove []string) {
+	for _, role := range wanted {
+		if !slices.Contains(current, role) && !slices.Contains(assign, role) {
+			assign = append(assign, role)
+		}
+	}
+	for _, role := range current {
+		if !slices.Contains(wanted, role) {
+			remove = append(remove, role)
+		}
+	}
+	return assign, remove
+}
+
+// ParseServiceAccountToken splits a token into the ID of its key and its secret
+func ParseServiceAccountToken(token string) (keyID string, secret string, ok bool) {
+	rest, found := strings.CutPrefix(strings.TrimSpace(token), tokenPrefix)
+	if !found {
+		return "", "", false
+	}
+	keyID, secret, found = strings.Cut(rest, "_")
+	if !found || secret == "" || uuid.Validate(keyID) != nil {
+		return "", "", false
+	}
+	return keyID, secret, true
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// TokenIntrospection is what introspection answers about a token. Account and Key are only set for
+// active tokens, nothing is said about the others.
+type TokenIntrospection struct {
+	Active  bool
+	Account *ServiceAccount
+	Key     *ServiceAccountKey
+	// Scopes are the scopes of the key, or the permissions of the roles of the account for keys
+	// without scopes
+	Scopes []string
+}
@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"net/netip"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// Client types of service accounts, each tenant sets how the keys of every type are issued
+const (
+	// ClientTypeServer is a backend integration, its keys never expire
+	ClientTypeServer = "server"
+	// ClientTypeWeb is a browser app, its keys are short lived
+	ClientTypeWeb = "web"
+	// ClientTypeMobile is a mobile app, its keys live longer and are rotated before they expire
+	ClientTypeMobile = "mobile"
+	// ClientTypeKiosk is a shared device of a school, its keys only work from the address they are
+	// issued for
+	ClientTypeKiosk = "kiosk"
+)
+
+// ClientTypes lists every client type, in the order policies are listed
+var ClientTypes = []string{ClientTypeServer, ClientTypeWeb, ClientTypeMobile, ClientTypeKiosk}
+
+// defaultTokenPolicies apply to the client types a tenant did not configure
+var defaultTokenPolicies = map[string]TokenPolicy{
+	ClientTypeServer: {KeyLifetime: 0, RotationGrace: DefaultRotationGrace},
+	ClientTypeWeb:    {KeyLifetime: 12 * time.Hour, RotationGrace: 0},
+	ClientTypeMobile: {KeyLifetime: 90 * 24 * time.Hour, RotationGrace: 7 * 24 * time.Hour},
+	ClientTypeKiosk:  {KeyLifetime: 30 * 24 * time.Hour, RotationGrace: time.Hour, BindIP: true},
+}
+
+// TokenPolicy is how a tenant issues the keys of the service accounts of a client type. Policies
+// apply when keys are issued, changing one leaves the keys already issued as they are.
+type TokenPolicy struct {
+	TenantID   string `validate:"required"`
+	ClientType string `validate:"required,oneof=server web mobile kiosk"`
+	// KeyLifetime is how long keys work after they are issued, 0 for keys that never expire
+	KeyLifetime time.Duration `validate:"min=0s,max=8760h"`
+	// RotationGrace is how long the keys replaced by a rotation keep working when the rotation does
+	// not say
+	RotationGrace time.Duration `validate:"min=0s,max=168h"`
+	// BindIP requires every key to be bound to the address of the client it is issued for
+	BindIP bool
+	// UpdatedBy is empty for the defaults, which were never set
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// DefaultTokenPolicy returns the policy of a client type the tenant did not configure
+func DefaultTokenPolicy(tenantID string, clientType string) *TokenPolicy {
+	policy := defaultTokenPolicies[clientType]
+	policy.TenantID = tenantID
+	policy.ClientType = clientType
+	return &policy
+}
+
+func NewTokenPolicy(tenantID string, clientType string, keyLifetime time.Duration, rotationGrace time.Duration, bindIP bool,
+	updatedBy string, now time.Time) (*TokenPolicy, error) {
+	policy := &TokenPolicy{
+		TenantID:      tenantID,
+		ClientType:    clientType,
+		KeyLifetime:   keyLifetime,
+		RotationGrace: rotationGrace,
+		BindIP:        bindIP,
+		UpdatedBy:     updatedBy,
+		UpdatedAt:     now,
+	}
+
+	if err := validate.Struct(policy); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("TokenPolicy domain model instance not valid", errorMap, err)
+	}
+
+	return policy, nil
+}
+
+// IsDefault reports whether the tenant never configured the policy
+func (p *TokenPolicy) IsDefault() bool {
+	return p.UpdatedBy == ""
+}
+
+// boundIP returns the address a key issued under the policy is bound to, empty for unbound keys.
+// Any key may be bound, keys of policies with BindIP must be.
+func (p *TokenPolicy) boundIP(ip string) (string, error) {
+	if ip == "" {
+		if p.BindIP {
+			return "", appErrors.NewValidationError("Keys of this client type are bound to an address",
+				map[string]any{"bound_ip": "required for " + p.ClientType + " accounts"}, nil)
+		}
+		return "", nil
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "", appErrors.NewValidationError("Bound address not valid", map[string]any{"bound_ip": "not an IP address"}, err)
+	}
+	return addr.Unmap().String(), nil
+}
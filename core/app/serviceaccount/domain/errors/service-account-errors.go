@@ -0,0 +1,57 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ServiceAccountNotFoundError    errors2.ErrorCode = "SERVICE_ACCOUNT_NOT_FOUND"
+	ServiceAccountKeyNotFoundError errors2.ErrorCode = "SERVICE_ACCOUNT_KEY_NOT_FOUND"
+	ServiceAccountDisabledError    errors2.ErrorCode = "SERVICE_ACCOUNT_DISABLED"
+)
+
+func NewServiceAccountNotFoundError(serviceAccountID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ServiceAccountNotFoundError.String(),
+			Message: "Service account not found",
+			Context: map[string]any{
+				"service_account_id": serviceAccountID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ServiceAccountNotFoundError.String()),
+		},
+	}
+}
+
+func NewServiceAccountKeyNotFoundError(serviceAccountID string, keyID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ServiceAccountKeyNotFoundError.String(),
+			Message: "Service account key not found",
+			Context: map[string]any{
+				"service_account_id": serviceAccountID,
+				"key_id":             keyID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ServiceAccountKeyNotFoundError.String()),
+		},
+	}
+}
+
+func NewServiceAccountDisabledError(serviceAccountID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ServiceAccountDisabledError.String(),
+			Message: "Disabled service accounts cannot be changed",
+			Context: map[string]any{
+				"service_account_id": serviceAccountID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ServiceAccountDisabledError.String()),
+		},
+	}
+}
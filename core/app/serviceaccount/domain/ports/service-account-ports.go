@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+)
+
+// ServiceAccountRepository saves every change of an account with an outbox event for the audit log
+type ServiceAccountRepository interface {
+	// Create saves the account with its keys
+	Create(ctx context.Context, account *entities.ServiceAccount, change entities.ServiceAccountChange) error
+	// Save saves the name, description, owner and disabled time of the account, creates the new keys
+	// in keys and saves the expiry and revocation of the others
+	Save(ctx context.Context, account *entities.ServiceAccount, keys []*entities.ServiceAccountKey, change entities.ServiceAccountChange) error
+	// FindByID returns the account with its keys, nil when the tenant has no such account
+	FindByID(ctx context.Context, tenantID string, serviceAccountID string) (*entities.ServiceAccount, error)
+	// List returns the accounts of the tenant with their keys, oldest first
+	List(ctx context.Context, tenantID string) ([]*entities.ServiceAccount, error)
+	// FindByKey returns a key and its account, without the other keys. Both are nil when there is no
+	// such key.
+	FindByKey(ctx context.Context, keyID string) (*entities.ServiceAccount, *entities.ServiceAccountKey, error)
+	TouchKey(ctx context.Context, keyID string, usedAt time.Time) error
+}
+
+// TokenPolicyRepository saves every change of a policy with an outbox event for the audit log
+type TokenPolicyRepository interface {
+	// Find returns the policy the tenant set for the client type, nil when it uses the default
+	Find(ctx context.Context, tenantID string, clientType string) (*entities.TokenPolicy, error)
+	// List returns the policies the tenant set
+	List(ctx context.Context, tenantID string) ([]*entities.TokenPolicy, error)
+	Save(ctx context.Context, policy *entities.TokenPolicy) error
+}
+
+// RoleDirectory holds the roles of subjects per tenant, users and service accounts alike
+type RoleDirectory interface {
+	AvailableRoles() []string
+	// Permissions returns the permissions the roles grant as scopes, "all" standing for every
+	// resource or action
+	Permissions(roles []string) []string
+	Roles(ctx context.Context, subject string, tenantID string) ([]string, error)
+	Assign(ctx context.Context, subject string, role string, tenantID string) error
+	Remove(ctx context.Context, subject string, role string, tenantID string) error
+}
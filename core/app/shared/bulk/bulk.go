@@ -0,0 +1,71 @@
+package bulk
+
+// Status is the outcome of an item: Failed, or the status the operation gives to the items that
+// succeeded such as "created"
+type Status string
+
+const Failed Status = "failed"
+
+// Item is the outcome of one item of a batch, Value is set only when it succeeded and Err only when
+// it failed. Items without a status are still being processed.
+type Item[T any] struct {
+	Index  int
+	Status Status
+	Value  T
+	Err    error
+}
+
+func (i Item[T]) Succeeded() bool {
+	return i.Status != "" && i.Status != Failed
+}
+
+// Result is the outcome of every item of a batch in the order of the request, shared by every batch
+// operation. A batch succeeds even when all of its items fail, only the failures of the batch
+// itself are returned as errors.
+type Result[T any] struct {
+	Items []Item[T]
+}
+
+// NewResult returns the result of a batch of size items, none of them processed
+func NewResult[T any](size int) *Result[T] {
+	items := make([]Item[T], size)
+	for i := range items {
+		items[i].Index = i
+	}
+	return &Result[T]{Items: items}
+}
+
+func (r *Result[T]) Succeed(index int, status Status, value T) {
+	r.Items[index].Status = status
+	r.Items[index].Value = value
+	r.Items[index].Err = nil
+}
+
+func (r *Result[T]) Fail(index int, err error) {
+	var zero T
+	r.Items[index].Status = Failed
+	r.Items[index].Value = zero
+	r.Items[index].Err = err
+}
+
+// Succeeded counts the items that succeeded
+func (r *Result[T]) Succeeded() int {
+	count := 0
+	for _, item := range r.Items {
+		if item.Succeeded() {
+			count++
+		}
+	}
+	return count
+}
+
+// Failed counts the items that failed
+func (r *Result[T]) Failed() int {
+	count := 0
+	for _, item := range r.Items {
+		if item.Status == Failed {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,43 @@
+package clock
+
+import "time"
+
+// Clock tells the time to code that expires or schedules things: holds, keys, retention windows and
+// the workers that sweep them. Production uses System, tests a virtual clock they move forward.
+type Clock interface {
+	Now() time.Time
+	// NewTicker ticks every d like time.NewTicker, stop it when done
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is a time.Ticker. C is a method so virtual tickers know when their reader waits for a tick,
+// read it in the select of every iteration.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// System is the clock of the machine
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t systemTicker) Stop() {
+	t.ticker.Stop()
+}
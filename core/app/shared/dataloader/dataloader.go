@@ -0,0 +1,120 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultMaxBatch keeps the key arrays of batch queries well under the parameter limits of Postgres
+const DefaultMaxBatch = 500
+
+// BatchFunc loads the values of keys in one call. Keys without a value are left out of the map.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader loads values by key in batches and remembers them, so composing a list response costs one
+// query per kind of related value instead of one per item. A loader lives as long as a request, see
+// For, values are never refreshed.
+type Loader[K comparable, V any] struct {
+	batch    BatchFunc[K, V]
+	maxBatch int
+
+	mu     sync.Mutex
+	values map[K]V
+	// missing remembers keys the batch had no value for so they are not asked for again
+	missing map[K]bool
+}
+
+func New[K comparable, V any](batch BatchFunc[K, V], maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:    batch,
+		maxBatch: maxBatch,
+		values:   make(map[K]V),
+		missing:  make(map[K]bool),
+	}
+}
+
+// Load returns the value of key, ok is false when there is none
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, bool, error) {
+	values, err := l.LoadMany(ctx, []K{key})
+	value, ok := values[key]
+	return value, ok, err
+}
+
+// LoadMany returns the values of keys, loading the ones not seen yet in batches of at most maxBatch keys
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) (map[K]V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	pending := make([]K, 0, len(keys))
+	queued := make(map[K]bool, len(keys))
+	for _, key := range keys {
+		if _, ok := l.values[key]; ok || l.missing[key] || queued[key] {
+			continue
+		}
+		pending = append(pending, key)
+		queued[key] = true
+	}
+
+	for start := 0; start < len(pending); start += l.maxBatch {
+		chunk := pending[start:min(start+l.maxBatch, len(pending))]
+		loaded, err := l.batch(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range chunk {
+			if value, ok := loaded[key]; ok {
+				l.values[key] = value
+			} else {
+				l.missing[key] = true
+			}
+		}
+	}
+
+	values := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if value, ok := l.values[key]; ok {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// Prime remembers a value already at hand, such as one just saved, so it is not loaded again
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.values[key] = value
+	delete(l.missing, key)
+}
+
+type scopeKey struct{}
+
+type scope struct {
+	mu      sync.Mutex
+	loaders map[string]any
+}
+
+// WithScope starts a request scope, loaders returned by For share their values until the scope ends
+func WithScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeKey{}, &scope{loaders: make(map[string]any)})
+}
+
+// For returns the loader named name of the request scope of ctx, creating it with batch on first use.
+// Without a scope every call gets a new loader, values are still loaded in batches.
+func For[K comparable, V any](ctx context.Context, name string, batch BatchFunc[K, V]) *Loader[K, V] {
+	s, ok := ctx.Value(scopeKey{}).(*scope)
+	if !ok {
+		return New(batch, DefaultMaxBatch)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if loader, ok := s.loaders[name].(*Loader[K, V]); ok {
+		return loader
+	}
+	loader := New(batch, DefaultMaxBatch)
+	s.loaders[name] = loader
+	return loader
+}
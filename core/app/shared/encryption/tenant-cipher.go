@@ -0,0 +1,192 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// prefix marks encrypted column values, values without it were written before the column was
+// encrypted and are read as they are
+const prefix = "enc:v1:"
+
+// keyCacheTTL bounds how long an instance keeps using an unwrapped key, a tenant shredded on another
+// instance can still be read here until it expires
+const keyCacheTTL = 5 * time.Minute
+
+type cachedKey struct {
+	aead     cipher.AEAD
+	loadedAt time.Time
+}
+
+// TenantCipher encrypts column values with the data key of their tenant (AES-256-GCM, the tenant ID
+// as additional data). Keys are created on the first write of a tenant and destroying one with Shred
+// makes every value encrypted with it unreadable, backups included.
+//
+// A nil TenantCipher leaves values in plaintext, column encryption is off without a master key.
+type TenantCipher struct {
+	keys    TenantKeyStore
+	wrapper KeyWrapper
+
+	mu    sync.Mutex
+	cache map[string]cachedKey
+}
+
+func NewTenantCipher(keys TenantKeyStore, wrapper KeyWrapper) *TenantCipher {
+	return &TenantCipher{
+		keys:    keys,
+		wrapper: wrapper,
+		cache:   make(map[string]cachedKey),
+	}
+}
+
+// Encrypt returns the value to store for plaintext
+func (c *TenantCipher) Encrypt(ctx context.Context, tenantID string, plaintext string) (string, error) {
+	if c == nil {
+		return plaintext, nil
+	}
+	aead, err := c.key(ctx, tenantID, true)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", appErrors.NewInfrastructureError("generate nonce", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(tenantID))
+	return prefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of a stored value, values written before encryption are returned as
+// they are
+func (c *TenantCipher) Decrypt(ctx context.Context, tenantID string, value string) (string, error) {
+	encoded, encrypted := strings.CutPrefix(value, prefix)
+	if !encrypted {
+		return value, nil
+	}
+	if c == nil {
+		return "", appErrors.NewInfrastructureError("decrypt column without a master key", nil)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", appErrors.NewInfrastructureError("decode encrypted column", err)
+	}
+	aead, err := c.key(ctx, tenantID, false)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", appErrors.NewInfrastructureError("decrypt column", nil)
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(tenantID))
+	if err != nil {
+		return "", appErrors.NewInfrastructureError("decrypt column", err)
+	}
+	return string(plaintext), nil
+}
+
+// Shred destroys the data key of a tenant, its encrypted values can never be read again. Tenants
+// without a key get a destroyed one so nothing is encrypted for them afterwards.
+func (c *TenantCipher) Shred(ctx context.Context, tenantID string) error {
+	key, err := c.keys.Find(ctx, tenantID)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	now := time.Now()
+	if key == nil {
+		if err := c.keys.Create(ctx, &TenantKey{TenantID: tenantID, CreatedAt: now, DestroyedAt: &now}); err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+	if err := c.keys.Destroy(ctx, tenantID, now); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	c.mu.Lock()
+	delete(c.cache, tenantID)
+	c.mu.Unlock()
+	return nil
+}
+
+// key returns the unwrapped key of the tenant, creating it when create is set and there is none
+func (c *TenantCipher) key(ctx context.Context, tenantID string, create bool) (cipher.AEAD, error) {
+	c.mu.Lock()
+	cached, ok := c.cache[tenantID]
+	c.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < keyCacheTTL {
+		return cached.aead, nil
+	}
+
+	stored, err := c.keys.Find(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if stored == nil && create {
+		if stored, err = c.createKey(ctx, tenantID); err != nil {
+			return nil, err
+		}
+	}
+	if stored == nil {
+		return nil, appErrors.NewInfrastructureError("find tenant key", nil)
+	}
+	if stored.IsDestroyed() {
+		return nil, appErrors.NewTenantKeyDestroyedError(tenantID)
+	}
+
+	raw, err := c.wrapper.Unwrap(ctx, tenantID, stored.WrappedKey)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.cache[tenantID] = cachedKey{aead: aead, loadedAt: time.Now()}
+	c.mu.Unlock()
+	return aead, nil
+}
+
+// createKey stores a new key for the tenant and returns the stored one, which is another instance's
+// when both created a key at the same time
+func (c *TenantCipher) createKey(ctx context.Context, tenantID string) (*TenantKey, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, appErrors.NewInfrastructureError("generate tenant key", err)
+	}
+	wrapped, err := c.wrapper.Wrap(ctx, tenantID, raw)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := c.keys.Create(ctx, &TenantKey{TenantID: tenantID, WrappedKey: wrapped, CreatedAt: time.Now()}); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	stored, err := c.keys.Find(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return stored, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("load tenant key", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("load tenant key", err)
+	}
+	return aead, nil
+}
@@ -0,0 +1,35 @@
+package encryption
+
+import (
+	"context"
+	"time"
+)
+
+// TenantKey is the data key of a tenant as stored, wrapped by the master key. A destroyed key keeps
+// its row without the key material so the tenant is never given a new one.
+type TenantKey struct {
+	TenantID    string
+	WrappedKey  []byte
+	CreatedAt   time.Time
+	DestroyedAt *time.Time
+}
+
+func (k *TenantKey) IsDestroyed() bool {
+	return k.DestroyedAt != nil
+}
+
+// KeyWrapper wraps data keys with the master key, which stays in the secret provider and is never
+// stored next to the data. tenantID is bound to the wrapped key so it cannot be moved to another tenant.
+type KeyWrapper interface {
+	Wrap(ctx context.Context, tenantID string, key []byte) ([]byte, error)
+	Unwrap(ctx context.Context, tenantID string, wrapped []byte) ([]byte, error)
+}
+
+type TenantKeyStore interface {
+	// Find returns nil when the tenant has no key yet
+	Find(ctx context.Context, tenantID string) (*TenantKey, error)
+	// Create stores key unless the tenant already has one, the caller finds the stored key after it
+	Create(ctx context.Context, key *TenantKey) error
+	// Destroy drops the key material of the tenant for good
+	Destroy(ctx context.Context, tenantID string, destroyedAt time.Time) error
+}
@@ -55,10 +55,27 @@ func (e BaseDomainError) IsDomainError() bool { return true }
 // InfrastructureError - technical failures
 type InfrastructureError struct {
 	BaseError
+	Safe *SafeContext // Metadata that can be exposed, causes and stack traces stay in Underlying
 }
 
-func (e InfrastructureError) IsDomainError() bool        { return false }
-func (e InfrastructureError) GetContext() map[string]any { return nil }
+func (e InfrastructureError) IsDomainError() bool { return false }
+
+// GetContext only exposes the safe context, the message and the cause may hold queries, hosts or
+// user data
+func (e InfrastructureError) GetContext() map[string]any {
+	if e.Safe == nil {
+		return nil
+	}
+	return e.Safe.toMap()
+}
+
+// WithSafeContext attaches metadata that clients may see to the error
+func (e *InfrastructureError) WithSafeContext(safe SafeContext) *InfrastructureError {
+	safe.Operation = sanitizeContextValue(safe.Operation)
+	safe.Resource = sanitizeContextValue(safe.Resource)
+	e.Safe = &safe
+	return e
+}
 
 func NewInfrastructureError(operation string, cause error) *InfrastructureError {
 	underlying := cause
@@ -102,3 +119,238 @@ func NewDomainEntityValidationError(message string, errorMap map[string]any, cau
 		},
 	}
 }
+
+func NewUnauthorizedError(message string) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:       Unauthorized.String(),
+			Message:    message,
+			OccurredAt: time.Now(),
+			Underlying: errors.New(Unauthorized.String()),
+		},
+	}
+}
+
+// NewStepUpRequiredError asks for an authentication newer than maxAge with one of the acr values,
+// any acr when acrValues is empty
+func NewStepUpRequiredError(maxAge time.Duration, acrValues []string) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:    StepUpRequired.String(),
+			Message: "Please sign in again to continue",
+			Context: map[string]any{
+				"max_age_seconds": int(maxAge.Seconds()),
+				"acr_values":      acrValues,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(StepUpRequired.String()),
+		},
+	}
+}
+
+// NewSignatureRequiredError refuses a request whose signature is not valid, reason tells why
+func NewSignatureRequiredError(reason string) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:       SignatureRequired.String(),
+			Message:    "This action needs a signed request",
+			Context:    map[string]any{"reason": reason},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SignatureRequired.String()),
+		},
+	}
+}
+
+// NewAuthzConfigError refuses a request of a tenant whose role assignments cannot be loaded
+func NewAuthzConfigError(tenantID string) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:       AuthzConfigError.String(),
+			Message:    "The permissions of this school cannot be loaded, the administrators were alerted",
+			Context:    map[string]any{"tenant_id": tenantID},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(AuthzConfigError.String()),
+		},
+	}
+}
+
+// NewInsufficientScopeError refuses a request whose token does not carry scope
+func NewInsufficientScopeError(scope string) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:       InsufficientScope.String(),
+			Message:    "The key used does not allow this action",
+			Context:    map[string]any{"scope": scope},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InsufficientScope.String()),
+		},
+	}
+}
+
+func NewForbiddenError(resource string, action string) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:    Forbidden.String(),
+			Message: "You are not allowed to perform this action",
+			Context: map[string]any{
+				"resource": resource,
+				"action":   action,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(Forbidden.String()),
+		},
+	}
+}
+
+func NewRateLimitedError(scope string, retryAfter time.Duration) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:    RateLimited.String(),
+			Message: "Too many requests, please retry later",
+			Context: map[string]any{
+				"scope":               scope,
+				"retry_after_seconds": int(retryAfter.Seconds()),
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(RateLimited.String()),
+		},
+	}
+}
+
+// NewUpgradeRequiredError rejects a client app older than the minimum version of its platform
+func NewUpgradeRequiredError(platform string, clientVersion string, minimumVersion string) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:    UpgradeRequired.String(),
+			Message: "This version of the app is no longer supported, please update it",
+			Context: map[string]any{
+				"platform":        platform,
+				"client_version":  clientVersion,
+				"minimum_version": minimumVersion,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(UpgradeRequired.String()),
+		},
+	}
+}
+
+// NewRangeNotSatisfiableError rejects a download resumed from past the end of the file, the file
+// changed or the client counted wrong
+func NewRangeNotSatisfiableError(rangeHeader string, size int64) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:    RangeNotSatisfiable.String(),
+			Message: "The requested range is outside of the file",
+			Context: map[string]any{
+				"range": rangeHeader,
+				"size":  size,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(RangeNotSatisfiable.String()),
+		},
+	}
+}
+
+// NewFileQuarantinedError refuses to serve an uploaded file until a virus scan found it clean
+func NewFileQuarantinedError(fileID string, scanStatus string) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:    FileQuarantined.String(),
+			Message: "The file is not available until it is scanned for viruses",
+			Context: map[string]any{
+				"file_id":     fileID,
+				"scan_status": scanStatus,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(FileQuarantined.String()),
+		},
+	}
+}
+
+// NewPreviewNotAvailableError is returned for the preview of a file while it is generated, or when the
+// file has none
+func NewPreviewNotAvailableError(fileID string, previewStatus string) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:    PreviewNotAvailable.String(),
+			Message: "The file has no preview",
+			Context: map[string]any{
+				"file_id":        fileID,
+				"preview_status": previewStatus,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(PreviewNotAvailable.String()),
+		},
+	}
+}
+
+// NewAlternativeNotAvailableError is returned for the captions or transcript of a file while they
+// are generated, or when the file has none
+func NewAlternativeNotAvailableError(fileID string, captionStatus string) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:    AlternativeNotAvailable.String(),
+			Message: "The file has no captions or transcript",
+			Context: map[string]any{
+				"file_id":        fileID,
+				"caption_status": captionStatus,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(AlternativeNotAvailable.String()),
+		},
+	}
+}
+
+// NewTenantKeyDestroyedError is returned for the encrypted data of a tenant whose key was shredded
+// on offboarding, the data can no longer be read and no new data is accepted
+func NewTenantKeyDestroyedError(tenantID string) *BaseDomainError {
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:    TenantKeyDestroyed.String(),
+			Message: "The data of this tenant was permanently deleted",
+			Context: map[string]any{
+				"tenant_id": tenantID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TenantKeyDestroyed.String()),
+		},
+	}
+}
+
+// NewTenantInOtherRegionError sends the request of a tenant to the region its data lives in, endpoint
+// is the base URL of the deployment of that region when it is known
+func NewTenantInOtherRegionError(tenantID string, region string, endpoint string) *BaseDomainError {
+	details := map[string]any{
+		"tenant_id": tenantID,
+		"region":    region,
+	}
+	if endpoint != "" {
+		details["endpoint"] = endpoint
+	}
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:       TenantInOtherRegion.String(),
+			Message:    "The data of this tenant lives in another region",
+			Context:    details,
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TenantInOtherRegion.String()),
+		},
+	}
+}
+
+func NewServiceUnavailableError(service string, cause error) *BaseDomainError {
+	if cause == nil {
+		cause = errors.New(ServiceUnavailable.String())
+	}
+	return &BaseDomainError{
+		BaseError: BaseError{
+			Code:    ServiceUnavailable.String(),
+			Message: "The service is temporarily unavailable, please retry later",
+			Context: map[string]any{
+				"service": service,
+			},
+			OccurredAt: time.Now(),
+			Underlying: cause,
+		},
+	}
+}
@@ -10,9 +10,43 @@ const (
 	// Authorization Errors
 	Unauthorized ErrorCode = "UNAUTHORIZED"
 	Forbidden    ErrorCode = "FORBIDDEN"
+	// StepUpRequired asks the user to authenticate again, the operation needs a recent or stronger
+	// authentication than the one of the session
+	StepUpRequired ErrorCode = "STEP_UP_REQUIRED"
+	// SignatureRequired refuses a request to an operation that must be signed with a signing key
+	// when its signature is missing, stale, replayed or wrong
+	SignatureRequired ErrorCode = "SIGNATURE_REQUIRED"
+	// InsufficientScope refuses a token whose scopes do not cover the permission, even though the
+	// roles of its holder do
+	InsufficientScope ErrorCode = "INSUFFICIENT_SCOPE"
+	// AuthzConfigError refuses the requests of a tenant whose role assignments cannot be loaded, the
+	// other tenants are still authorized
+	AuthzConfigError ErrorCode = "AUTHZ_CONFIG_ERROR"
+
+	// Throttling Errors
+	RateLimited ErrorCode = "RATE_LIMITED"
+
+	// Client Errors
+	UpgradeRequired ErrorCode = "UPGRADE_REQUIRED"
+	// RangeNotSatisfiable refuses a byte range that starts past the end of the file
+	RangeNotSatisfiable ErrorCode = "RANGE_NOT_SATISFIABLE"
+	// FileQuarantined refuses an uploaded file that is waiting for its virus scan or failed it
+	FileQuarantined ErrorCode = "FILE_QUARANTINED"
+	// PreviewNotAvailable is asked for the preview of a file that has none, yet or at all
+	PreviewNotAvailable ErrorCode = "PREVIEW_NOT_AVAILABLE"
+	// AlternativeNotAvailable is asked for the captions or transcript of a file that has none, yet or
+	// at all
+	AlternativeNotAvailable ErrorCode = "ALTERNATIVE_NOT_AVAILABLE"
+
+	// Tenant Errors
+	TenantKeyDestroyed ErrorCode = "TENANT_KEY_DESTROYED"
+	// TenantInOtherRegion refuses a request for a tenant whose data lives in the database cluster of
+	// another region, the deployment of that region serves it
+	TenantInOtherRegion ErrorCode = "TENANT_IN_OTHER_REGION"
 
 	// Infrastructure Errors
-	InternalError ErrorCode = "INTERNAL_ERROR"
+	InternalError      ErrorCode = "INTERNAL_ERROR"
+	ServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
 )
 
 // String returns the string representation of the error code
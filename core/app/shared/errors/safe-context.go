@@ -0,0 +1,55 @@
+package errors
+
+import (
+	cockroach "errors"
+	"strings"
+)
+
+// maxContextValueLength keeps safe context values to identifiers
+const maxContextValueLength = 64
+
+// SafeContext describes an infrastructure failure without its details: the operation that failed
+// (e.g. "redis.get"), the resource it worked on (e.g. "redis") and whether retrying may succeed
+type SafeContext struct {
+	Operation string
+	Resource  string
+	Retryable bool
+}
+
+func (c SafeContext) toMap() map[string]any {
+	context := map[string]any{"retryable": c.Retryable}
+	if c.Operation != "" {
+		context["operation"] = c.Operation
+	}
+	if c.Resource != "" {
+		context["resource"] = c.Resource
+	}
+	return context
+}
+
+// IsRetryable reports whether err is an infrastructure error marked as retryable
+func IsRetryable(err error) bool {
+	var infraErr *InfrastructureError
+	if !cockroach.As(err, &infraErr) || infraErr.Safe == nil {
+		return false
+	}
+	return infraErr.Safe.Retryable
+}
+
+// sanitizeContextValue keeps letters, digits and . _ - : / so a value built from an error message
+// or user input cannot carry it to the client
+func sanitizeContextValue(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if b.Len() >= maxContextValueLength {
+			break
+		}
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', strings.ContainsRune("._-:/", r):
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
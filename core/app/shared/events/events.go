@@ -0,0 +1,132 @@
+package events
+
+import "time"
+
+// Event types recorded in the outbox. Producers own the payload shape documented next to each type.
+const (
+	// UserSignedUp payload: user_id
+	UserSignedUp = "user.signed_up"
+	// UserActive payload: user_id. Emitted on the first authenticated request of a session.
+	UserActive = "user.active"
+	// EnrollmentAdded payload: class_id, student_id
+	EnrollmentAdded = "class.enrollment_added"
+	// EnrollmentRemoved payload: class_id, student_id
+	EnrollmentRemoved = "class.enrollment_removed"
+	// EnrollmentNotificationQueued payload: notification_id, class_id, student_id, kind (enrolled or
+	// enrollment_canceled)
+	EnrollmentNotificationQueued = "class.enrollment_notification_queued"
+	// GradeRecorded payload: class_id, student_id, score, previous_score (only when a grade is changed),
+	// grade_id, assignment_id, version (the version of the grade once released)
+	GradeRecorded = "grade.recorded"
+	// AttendanceRecorded payload: class_id, student_id, present
+	AttendanceRecorded = "attendance.recorded"
+	// AppointmentBooked payload: appointment_id, slot_id, teacher_id, student_id, starts_at
+	AppointmentBooked = "appointment.booked"
+	// AppointmentCanceled payload: appointment_id, slot_id, teacher_id, student_id, starts_at, canceled_by
+	AppointmentCanceled = "appointment.canceled"
+	// AppointmentReminderDue payload: appointment_id, teacher_id, student_id, starts_at, location.
+	// Notification senders remind both participants.
+	AppointmentReminderDue = "appointment.reminder_due"
+	// IncidentGuardianNotificationQueued payload: notification_id, incident_id, student_id, guardian_id,
+	// type, severity, occurred_at. The summary is left out, senders link guardians to the incident.
+	IncidentGuardianNotificationQueued = "incident.guardian_notification_queued"
+	// FormDistributed payload: form_id, recipient_id, title, closes_at (only when set). One event per
+	// member of the audience when the form is opened.
+	FormDistributed = "survey.form_distributed"
+	// PaymentFailed payload: invoice_id, recipient_id, number, amount_due, currency, attempt_count,
+	// next_payment_attempt (unset once the provider gave up), hosted_url. One event per billing contact
+	// of the tenant and payment attempt.
+	PaymentFailed = "billing.payment_failed"
+	// ServiceAccountCreated payload: service_account_id, name, owner_id, roles, key_id (the first key),
+	// actor_id, actor_kind.
+	// The service account events name the actor, a user or another service account, with actor_kind.
+	ServiceAccountCreated = "service_account.created"
+	// ServiceAccountUpdated payload: service_account_id, name, owner_id, roles, actor_id, actor_kind
+	ServiceAccountUpdated = "service_account.updated"
+	// ServiceAccountKeyIssued payload: service_account_id, key_id, actor_id, actor_kind. Rotations
+	// issue a key, the keys they expire keep working for the grace period.
+	ServiceAccountKeyIssued = "service_account.key_issued"
+	// ServiceAccountKeyRevoked payload: service_account_id, key_id, actor_id, actor_kind
+	ServiceAccountKeyRevoked = "service_account.key_revoked"
+	// ServiceAccountDisabled payload: service_account_id, name, owner_id, roles, actor_id, actor_kind
+	ServiceAccountDisabled = "service_account.disabled"
+	// TokenPolicyUpdated payload: client_type, key_lifetime_seconds, rotation_grace_seconds, bind_ip,
+	// actor_id
+	TokenPolicyUpdated = "token_policy.updated"
+	// RoleAssignedToGroup payload: assignment_id, group_type, group_id, role, members, assigned,
+	// actor_id
+	RoleAssignedToGroup = "role.group_assigned"
+	// TenantAdminInvited payload: invitation_id, email, role, expires_at. Senders deliver the
+	// invitation ID, which the admin accepts the invitation with.
+	TenantAdminInvited = "tenant.admin_invited"
+	// TestEmailQueued payload: test_email_id, flow, to, subject, html, text. The email is rendered
+	// already, senders deliver it as it is to the admin who asked for it.
+	TestEmailQueued = "email.test_queued"
+	// NotificationsDue payload: digest_id, recipient_id, frequency, notifications (type, occurred_at and
+	// payload of each). The notification events above are held per recipient and sent through it,
+	// senders deliver one email or push per event: a single notification for immediate recipients, the
+	// hourly or daily digest of the others.
+	NotificationsDue = "notification.due"
+	// FileScanned payload: file_kind (resource_version or attachment), file_id, file_name, uploaded_by,
+	// status (clean, or missing when the file was gone from the storage), scanner. Clean files are
+	// served from then on.
+	FileScanned = "file.scanned"
+	// FileRejected payload: file_kind, file_id, file_name, uploaded_by, signature, scanner. The file
+	// was infected and is deleted, its uploader is notified.
+	FileRejected = "file.rejected"
+	// StorageQuotaSet payload: tenant_bytes, user_bytes (zero is no limit), updated_by
+	StorageQuotaSet = "storage.quota_set"
+	// StorageDriftDetected payload: recorded_bytes, recorded_files, stored_bytes, stored_files. The
+	// files of the tenant in the file storage differ from the recorded ones, found by the nightly
+	// reconciliation.
+	StorageDriftDetected = "storage.drift_detected"
+	// ReportCardReady payload: report_card_id, student_id, guardian_id, term_id, term_name, download_url,
+	// link_expires_at. One per guardian of the student, download_url is a signed path that works without
+	// signing in until link_expires_at.
+	ReportCardReady = "report_card.ready"
+	// SectionStudentsMoved payload: change_id, kind (merge or split), source_class_id, target_class_id,
+	// students, enrollments, grade_count, grade_sum, actor_id. grade_count and grade_sum are the released
+	// grades that moved, the ones class summaries count, with their late penalty applied.
+	SectionStudentsMoved = "class.section_students_moved"
+	// TenantModuleToggled payload: module, enabled, reason. Requests to a disabled module are refused
+	// with MODULE_DISABLED once the cached toggles of the tenant expire.
+	TenantModuleToggled = "tenant.module_toggled"
+	// WidgetKeyCreated payload: key_id, name, allowed_origins, widgets, created_by. The key itself is
+	// left out, it is listed to the admins of the tenant.
+	WidgetKeyCreated = "widget_key.created"
+	// WidgetKeyRevoked payload: key_id, name, revoked_by
+	WidgetKeyRevoked = "widget_key.revoked"
+	// JobFailureEscalated payload: failure_id, job, subject, attempts, last_error, payload_summary,
+	// first_failed_at. The job gave up on the work until it is requeued, senders email the admins of
+	// the tenant.
+	JobFailureEscalated = "job.failure_escalated"
+)
+
+// Event is a domain event read from the outbox. Position is the outbox sequence number, it only
+// grows so consumers use it as their checkpoint.
+type Event struct {
+	Position    int64
+	Type        string
+	AggregateID string
+	TenantID    string
+	Payload     map[string]any
+	OccurredAt  time.Time
+}
+
+// String returns the payload value for key, or "" when missing or not a string
+func (e Event) String(key string) string {
+	value, _ := e.Payload[key].(string)
+	return value
+}
+
+// Float returns the payload value for key. JSON numbers decode as float64.
+func (e Event) Float(key string) (float64, bool) {
+	value, ok := e.Payload[key].(float64)
+	return value, ok
+}
+
+// Bool returns the payload value for key, or false when missing
+func (e Event) Bool(key string) bool {
+	value, _ := e.Payload[key].(bool)
+	return value
+}
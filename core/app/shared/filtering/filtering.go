@@ -0,0 +1,70 @@
+package filtering
+
+import (
+	"time"
+)
+
+const (
+	MaxExpressionLength = 1000
+	MaxComparisons      = 20
+)
+
+type FieldType string
+
+const (
+	StringField FieldType = "string"
+	NumberField FieldType = "number"
+	BoolField   FieldType = "bool"
+	TimeField   FieldType = "time"
+)
+
+// Fields is the allowlist of filterable fields of an endpoint and their types
+type Fields map[string]FieldType
+
+type Operator string
+
+const (
+	Equal          Operator = "=="
+	NotEqual       Operator = "!="
+	GreaterThan    Operator = ">"
+	GreaterOrEqual Operator = ">="
+	LessThan       Operator = "<"
+	LessOrEqual    Operator = "<="
+)
+
+type LogicalOperator string
+
+const (
+	And LogicalOperator = "AND"
+	Or  LogicalOperator = "OR"
+)
+
+// Expr is a node of a parsed filter expression
+type Expr interface {
+	isExpr()
+}
+
+// Comparison compares an allowed field with a literal already converted to the field type
+// (string, float64, bool or time.Time)
+type Comparison struct {
+	Field    string
+	Operator Operator
+	Value    any
+}
+
+type Logical struct {
+	Operator LogicalOperator
+	Left     Expr
+	Right    Expr
+}
+
+type Not struct {
+	Expr Expr
+}
+
+func (Comparison) isExpr() {}
+func (Logical) isExpr()    {}
+func (Not) isExpr()        {}
+
+// timeLayouts are the accepted literal formats for time fields
+var timeLayouts = []string{time.RFC3339, "2006-01-02"}
@@ -0,0 +1,277 @@
+package filtering
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind     tokenKind
+	value    string
+	position int
+}
+
+// Parse parses a filter expression such as `status == "active" AND created_at > "2024-01-01"`.
+// Only fields in the allowlist are accepted and literals must match the field type.
+// An empty expression returns a nil Expr.
+func Parse(input string, fields Fields) (Expr, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+	if len(input) > MaxExpressionLength {
+		return nil, newFilterError(fmt.Sprintf("Filter is too long (maximum %d characters)", MaxExpressionLength))
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, newFilterError(err.Error())
+	}
+
+	p := &parser{tokens: tokens, fields: fields}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, newFilterError(err.Error())
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, newFilterError(fmt.Sprintf("Unexpected %q at position %d", next.value, next.position))
+	}
+
+	return expr, nil
+}
+
+func newFilterError(message string) error {
+	return appErrors.NewValidationError("Invalid filter expression", map[string]any{"filter": message}, nil)
+}
+
+type parser struct {
+	tokens      []token
+	pos         int
+	fields      Fields
+	comparisons int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenIdent && strings.EqualFold(t.value, keyword)
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword(string(Or)) {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = Logical{Operator: Or, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword(string(And)) {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = Logical{Operator: And, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.isKeyword("NOT") {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: expr}, nil
+	}
+
+	if p.peek().kind == tokenLParen {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, fmt.Errorf("Expected ')' at position %d", closing.position)
+		}
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	fieldToken := p.next()
+	if fieldToken.kind != tokenIdent {
+		return nil, fmt.Errorf("Expected a field name at position %d", fieldToken.position)
+	}
+
+	fieldType, allowed := p.fields[fieldToken.value]
+	if !allowed {
+		return nil, fmt.Errorf("Field %q cannot be used in filters", fieldToken.value)
+	}
+
+	operatorToken := p.next()
+	if operatorToken.kind != tokenOperator {
+		return nil, fmt.Errorf("Expected a comparison operator at position %d", operatorToken.position)
+	}
+	operator := Operator(operatorToken.value)
+
+	valueToken := p.next()
+	value, err := convertLiteral(valueToken, fieldType)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid value for %q: %s", fieldToken.value, err.Error())
+	}
+
+	if fieldType == BoolField && operator != Equal && operator != NotEqual {
+		return nil, fmt.Errorf("Operator %s is not supported for %q", operator, fieldToken.value)
+	}
+
+	p.comparisons++
+	if p.comparisons > MaxComparisons {
+		return nil, fmt.Errorf("Filter has too many conditions (maximum %d)", MaxComparisons)
+	}
+
+	return Comparison{Field: fieldToken.value, Operator: operator, Value: value}, nil
+}
+
+func convertLiteral(t token, fieldType FieldType) (any, error) {
+	switch fieldType {
+	case StringField:
+		if t.kind != tokenString {
+			return nil, fmt.Errorf("expected a quoted string")
+		}
+		return t.value, nil
+	case NumberField:
+		if t.kind != tokenNumber {
+			return nil, fmt.Errorf("expected a number")
+		}
+		return strconv.ParseFloat(t.value, 64)
+	case BoolField:
+		if t.kind != tokenIdent || (t.value != "true" && t.value != "false") {
+			return nil, fmt.Errorf("expected true or false")
+		}
+		return t.value == "true", nil
+	case TimeField:
+		if t.kind != tokenString {
+			return nil, fmt.Errorf("expected a quoted date")
+		}
+		for _, layout := range timeLayouts {
+			if parsed, err := time.Parse(layout, t.value); err == nil {
+				return parsed, nil
+			}
+		}
+		return nil, fmt.Errorf("expected a date like \"2024-01-01\" or RFC 3339 timestamp")
+	default:
+		return nil, fmt.Errorf("unsupported field type %s", fieldType)
+	}
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", position: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", position: i})
+			i++
+		case r == '"':
+			start := i
+			var value strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("Unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, value: value.String(), position: start})
+		case strings.ContainsRune("=!<>", r):
+			start := i
+			operator := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				operator += "="
+			}
+			switch Operator(operator) {
+			case Equal, NotEqual, GreaterThan, GreaterOrEqual, LessThan, LessOrEqual:
+			default:
+				return nil, fmt.Errorf("Unknown operator %q at position %d", operator, start)
+			}
+			i += len(operator)
+			tokens = append(tokens, token{kind: tokenOperator, value: operator, position: start})
+		case r == '-' || unicode.IsDigit(r):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[start:i]), position: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: string(runes[start:i]), position: start})
+		default:
+			return nil, fmt.Errorf("Unexpected character %q at position %d", r, i)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, value: "end of filter", position: len(runes)}), nil
+}
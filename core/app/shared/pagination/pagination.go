@@ -0,0 +1,255 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+type SortDirection string
+
+const (
+	Ascending  SortDirection = "asc"
+	Descending SortDirection = "desc"
+)
+
+// OrderBy is a single validated sort key, Field is always one of the endpoint's allowed fields
+type OrderBy struct {
+	Field     string
+	Direction SortDirection
+}
+
+// PageRequest is the validated list input shared by every list endpoint. Pages are keyset pages,
+// items inserted or deleted before the position of a cursor never shift the pages after it.
+type PageRequest struct {
+	PageSize int
+	OrderBy  []OrderBy
+	// After holds the sort keys of the last item of the previous page, one per OrderBy and the
+	// tie-breaker of the endpoint last. It is nil on the first page.
+	After []string
+}
+
+// Page is the list output shared by every list endpoint. NextCursor is empty on the last page.
+type Page[T any] struct {
+	Items         []T
+	NextCursor    string
+	TotalEstimate int64
+}
+
+// NewPageRequest validates raw list parameters. orderBy is a comma separated list of
+// "field [asc|desc]" terms; only allowedFields may be used, defaultOrder applies when empty.
+func NewPageRequest(pageSize int, cursor string, orderBy string, allowedFields []string, defaultOrder OrderBy) (*PageRequest, error) {
+	errorMap := make(map[string]any)
+
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize < 0 || pageSize > MaxPageSize {
+		errorMap["page_size"] = fmt.Sprintf("Must be between 1 and %d", MaxPageSize)
+	}
+
+	order, err := parseOrderBy(orderBy, allowedFields)
+	if err != nil {
+		errorMap["order_by"] = err.Error()
+	}
+	if len(order) == 0 {
+		order = []OrderBy{defaultOrder}
+	}
+
+	// A cursor only continues the order it was returned for, the keys of another order mean nothing
+	after, err := decodeCursor(cursor, order)
+	if err != nil {
+		errorMap["cursor"] = "Invalid cursor"
+	}
+
+	if len(errorMap) > 0 {
+		return nil, appErrors.NewValidationError("Invalid list parameters", errorMap, nil)
+	}
+
+	return &PageRequest{
+		PageSize: pageSize,
+		OrderBy:  order,
+		After:    after,
+	}, nil
+}
+
+// NewPage builds a page from up to PageSize+1 fetched items, the extra item only signals that more
+// pages exist. keys returns the sort keys of an item the way PageRequest.After holds them, the next
+// cursor carries those of the last item of the page.
+func NewPage[T any](request *PageRequest, fetched []T, totalEstimate int64, keys func(T) []string) *Page[T] {
+	page := &Page[T]{Items: fetched, TotalEstimate: totalEstimate}
+
+	if len(fetched) > request.PageSize {
+		page.Items = fetched[:request.PageSize]
+		page.NextCursor = encodeCursor(request.OrderBy, keys(page.Items[request.PageSize-1]))
+	}
+
+	return page
+}
+
+// MapPage converts the items of a page keeping its pagination metadata
+func MapPage[T any, R any](page *Page[T], mapper func(T) R) *Page[R] {
+	items := make([]R, len(page.Items))
+	for i, item := range page.Items {
+		items[i] = mapper(item)
+	}
+
+	return &Page[R]{
+		Items:         items,
+		NextCursor:    page.NextCursor,
+		TotalEstimate: page.TotalEstimate,
+	}
+}
+
+// ValidateOrderBy checks an order_by value with the rules of NewPageRequest, for orders stored to be
+// applied to later requests
+func ValidateOrderBy(orderBy string, allowedFields []string) error {
+	if _, err := parseOrderBy(orderBy, allowedFields); err != nil {
+		return appErrors.NewValidationError("Invalid list parameters", map[string]any{"order_by": err.Error()}, nil)
+	}
+	return nil
+}
+
+func parseOrderBy(orderBy string, allowedFields []string) ([]OrderBy, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, nil
+	}
+
+	var result []OrderBy
+	for _, term := range strings.Split(orderBy, ",") {
+		parts := strings.Fields(term)
+		if len(parts) == 0 || len(parts) > 2 {
+			return nil, fmt.Errorf("Invalid order term %q", strings.TrimSpace(term))
+		}
+
+		field := parts[0]
+		if !slices.Contains(allowedFields, field) {
+			return nil, fmt.Errorf("Cannot order by %q, allowed fields: %s", field, strings.Join(allowedFields, ", "))
+		}
+
+		direction := Ascending
+		if len(parts) == 2 {
+			switch SortDirection(strings.ToLower(parts[1])) {
+			case Ascending:
+			case Descending:
+				direction = Descending
+			default:
+				return nil, fmt.Errorf("Invalid sort direction %q", parts[1])
+			}
+		}
+
+		result = append(result, OrderBy{Field: field, Direction: direction})
+	}
+
+	return result, nil
+}
+
+// TimeKey is the sort key of a timestamp, precise to the microsecond Postgres keeps
+func TimeKey(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// ParseTimeKey reads a key returned by TimeKey
+func ParseTimeKey(key string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, key)
+}
+
+var cursorKey = struct {
+	sync.RWMutex
+	key []byte
+}{key: randomCursorKey()}
+
+// SetCursorKey sets the key cursors are signed with. Instances serving the same clients need the
+// same key, without it each signs with a random key and rejects the cursors of the others.
+func SetCursorKey(key []byte) {
+	cursorKey.Lock()
+	defer cursorKey.Unlock()
+
+	cursorKey.key = key
+}
+
+func randomCursorKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
+
+// cursorPayload is the content of a cursor: the order it continues and the sort keys it starts after
+type cursorPayload struct {
+	Order string   `json:"o"`
+	Keys  []string `json:"k"`
+}
+
+// encodeCursor signs the payload, a cursor edited by a client is rejected instead of starting a page
+// at an arbitrary position
+func encodeCursor(order []OrderBy, keys []string) string {
+	payload, _ := json.Marshal(cursorPayload{Order: formatOrderBy(order), Keys: keys})
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signCursor(payload))
+}
+
+func decodeCursor(cursor string, order []OrderBy) ([]string, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	encodedPayload, encodedSignature, found := strings.Cut(cursor, ".")
+	if !found {
+		return nil, fmt.Errorf("unknown cursor format")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(signature, signCursor(payload)) {
+		return nil, fmt.Errorf("invalid cursor signature")
+	}
+
+	var decoded cursorPayload
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return nil, err
+	}
+	if decoded.Order != formatOrderBy(order) {
+		return nil, fmt.Errorf("cursor of another order")
+	}
+	// One key per sort field and the tie-breaker
+	if len(decoded.Keys) != len(order)+1 {
+		return nil, fmt.Errorf("invalid cursor keys")
+	}
+
+	return decoded.Keys, nil
+}
+
+func signCursor(payload []byte) []byte {
+	cursorKey.RLock()
+	defer cursorKey.RUnlock()
+
+	mac := hmac.New(sha256.New, cursorKey.key)
+	mac.Write(payload)
+	return mac.Sum(nil)[:16]
+}
+
+func formatOrderBy(order []OrderBy) string {
+	terms := make([]string, len(order))
+	for i, term := range order {
+		terms[i] = term.Field + " " + string(term.Direction)
+	}
+	return strings.Join(terms, ",")
+}
@@ -0,0 +1,27 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+)
+
+// Projector maintains a denormalized read model from outbox events. Apply can see an event more
+// than once after a crash (the checkpoint is saved after the batch), so it must be idempotent.
+type Projector interface {
+	Name() string
+	Apply(ctx context.Context, event events.Event) error
+	// Reset deletes the read model so it can be rebuilt from the first event
+	Reset(ctx context.Context) error
+}
+
+// EventLog reads outbox events in position order
+type EventLog interface {
+	ReadAfter(ctx context.Context, position int64, limit int) ([]events.Event, error)
+}
+
+// CheckpointStore persists the last position applied by every projector
+type CheckpointStore interface {
+	Load(ctx context.Context, projector string) (int64, error)
+	Save(ctx context.Context, projector string, position int64) error
+}
@@ -0,0 +1,111 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+const DefaultBatchSize = 200
+
+// Runner feeds outbox events to projectors, each projector advances its own checkpoint so a slow
+// or failing projector does not hold the others back.
+type Runner struct {
+	eventLog    EventLog
+	checkpoints CheckpointStore
+	projectors  map[string]Projector
+	batchSize   int
+}
+
+func NewRunner(eventLog EventLog, checkpoints CheckpointStore, projectors ...Projector) *Runner {
+	runner := &Runner{
+		eventLog:    eventLog,
+		checkpoints: checkpoints,
+		projectors:  make(map[string]Projector, len(projectors)),
+		batchSize:   DefaultBatchSize,
+	}
+	for _, projector := range projectors {
+		runner.projectors[projector.Name()] = projector
+	}
+	return runner
+}
+
+// CatchUp applies every pending event to every projector
+func (r *Runner) CatchUp(ctx context.Context) error {
+	for _, projector := range r.projectors {
+		if err := r.catchUp(ctx, projector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rebuild resets the projector's read model and replays the outbox from the beginning
+func (r *Runner) Rebuild(ctx context.Context, name string) error {
+	projector, ok := r.projectors[name]
+	if !ok {
+		return appErrors.NewValidationError(fmt.Sprintf("unknown projection %s", name), map[string]any{"projection": name}, nil)
+	}
+
+	if err := projector.Reset(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := r.checkpoints.Save(ctx, name, 0); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return r.catchUp(ctx, projector)
+}
+
+// Run calls CatchUp every interval until ctx is cancelled
+func (r *Runner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.CatchUp(ctx); err != nil {
+			log.Printf("Projection catch-up failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) catchUp(ctx context.Context, projector Projector) error {
+	position, err := r.checkpoints.Load(ctx, projector.Name())
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	for {
+		batch, err := r.eventLog.ReadAfter(ctx, position, r.batchSize)
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+
+		for _, event := range batch {
+			if err := projector.Apply(ctx, event); err != nil {
+				return appErrors.NewInfrastructureError(
+					fmt.Sprintf("projection %s failed at position %d", projector.Name(), event.Position), err)
+			}
+			position = event.Position
+		}
+
+		if len(batch) > 0 {
+			if err := r.checkpoints.Save(ctx, projector.Name(), position); err != nil {
+				return appErrors.PropagateError(err)
+			}
+		}
+
+		if len(batch) < r.batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+// DefaultLease is how long an instance stays with the coordinator running it after its last save
+const DefaultLease = 5 * time.Minute
+
+// Coordinator runs registered saga definitions, persisting progress after every step and running
+// compensations in reverse order when a step fails. Instances are leased to the coordinator that
+// runs them and the lease is renewed on every save, so coordinators on several replicas only
+// resume the instances of one that stopped. Steps must finish within the lease.
+type Coordinator struct {
+	store       Store
+	definitions map[string]Definition
+	owner       string
+	lease       time.Duration
+}
+
+func NewCoordinator(store Store) *Coordinator {
+	return &Coordinator{
+		store:       store,
+		definitions: make(map[string]Definition),
+		owner:       uuid.NewString(),
+		lease:       DefaultLease,
+	}
+}
+
+// WithLease changes how long instances stay leased after their last save
+func (c *Coordinator) WithLease(lease time.Duration) *Coordinator {
+	c.lease = lease
+	return c
+}
+
+// Register makes a definition available to Start and Resume, it must happen before Resume is called
+func (c *Coordinator) Register(definition Definition) {
+	c.definitions[definition.Name] = definition
+}
+
+// Start persists a new instance and runs it to completion or full compensation. The returned error
+// is the step failure (or compensation failure), the instance always reflects the final state.
+func (c *Coordinator) Start(ctx context.Context, sagaName string, data map[string]any) (*Instance, error) {
+	definition, ok := c.definitions[sagaName]
+	if !ok {
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("saga %s is not registered", sagaName), nil)
+	}
+
+	if data == nil {
+		data = make(map[string]any)
+	}
+
+	now := time.Now().UTC()
+	instance := &Instance{
+		ID:        uuid.NewString(),
+		SagaName:  sagaName,
+		Status:    StatusRunning,
+		Data:      data,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := c.save(ctx, instance); err != nil {
+		return nil, err
+	}
+
+	return instance, c.run(ctx, definition, instance)
+}
+
+// Resume claims the unfinished instances whose lease expired and continues them. A failing instance
+// does not stop the others, the failures are returned together.
+func (c *Coordinator) Resume(ctx context.Context) error {
+	now := time.Now().UTC()
+	instances, err := c.store.ClaimUnfinished(ctx, c.owner, now, now.Add(c.lease))
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	var failures []error
+	for _, instance := range instances {
+		if err := c.resume(ctx, instance); err != nil {
+			log.Printf("Failed to resume saga %s %s: %v", instance.SagaName, instance.ID, err)
+			failures = append(failures, err)
+		}
+	}
+
+	return errors.Join(failures...)
+}
+
+// Run calls Resume every interval until ctx is cancelled
+func (c *Coordinator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Resume(ctx); err != nil {
+			log.Printf("Failed to resume unfinished sagas: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Coordinator) resume(ctx context.Context, instance *Instance) error {
+	definition, ok := c.definitions[instance.SagaName]
+	if !ok {
+		return appErrors.NewInfrastructureError(fmt.Sprintf("cannot resume saga %s %s: not registered", instance.SagaName, instance.ID), nil)
+	}
+
+	return c.run(ctx, definition, instance)
+}
+
+func (c *Coordinator) run(ctx context.Context, definition Definition, instance *Instance) error {
+	if instance.Status == StatusRunning {
+		for instance.CompletedSteps < len(definition.Steps) {
+			step := definition.Steps[instance.CompletedSteps]
+
+			if err := step.Execute(ctx, instance.Data); err != nil {
+				instance.Status = StatusCompensating
+				instance.FailureReason = fmt.Sprintf("step %s failed: %v", step.Name, err)
+				if saveErr := c.save(ctx, instance); saveErr != nil {
+					return saveErr
+				}
+				if compensationErr := c.compensate(ctx, definition, instance); compensationErr != nil {
+					return compensationErr
+				}
+				return appErrors.PropagateError(err)
+			}
+
+			instance.CompletedSteps++
+			if err := c.save(ctx, instance); err != nil {
+				return err
+			}
+		}
+
+		instance.Status = StatusCompleted
+		return c.save(ctx, instance)
+	}
+
+	if instance.Status == StatusCompensating {
+		return c.compensate(ctx, definition, instance)
+	}
+
+	return nil
+}
+
+func (c *Coordinator) compensate(ctx context.Context, definition Definition, instance *Instance) error {
+	for instance.CompletedSteps > 0 {
+		step := definition.Steps[instance.CompletedSteps-1]
+
+		if step.Compensate != nil {
+			if err := step.Compensate(ctx, instance.Data); err != nil {
+				instance.Status = StatusFailed
+				instance.FailureReason = fmt.Sprintf("%s; compensation of step %s failed: %v", instance.FailureReason, step.Name, err)
+				if saveErr := c.save(ctx, instance); saveErr != nil {
+					return saveErr
+				}
+				return appErrors.PropagateError(err)
+			}
+		}
+
+		instance.CompletedSteps--
+		if err := c.save(ctx, instance); err != nil {
+			return err
+		}
+	}
+
+	instance.Status = StatusCompensated
+	return c.save(ctx, instance)
+}
+
+// save persists the instance and renews its lease, the saga stops once another coordinator took it over
+func (c *Coordinator) save(ctx context.Context, instance *Instance) error {
+	instance.UpdatedAt = time.Now().UTC()
+	saved, err := c.store.Save(ctx, instance, c.owner, instance.UpdatedAt.Add(c.lease))
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if !saved {
+		return appErrors.NewInfrastructureError(fmt.Sprintf("saga %s %s was taken over by another coordinator", instance.SagaName, instance.ID), nil)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package saga
+
+import (
+	"context"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusRunning      Status = "running"
+	StatusCompleted    Status = "completed"
+	StatusCompensating Status = "compensating"
+	StatusCompensated  Status = "compensated"
+	// StatusFailed means a compensation failed and the saga needs manual intervention
+	StatusFailed Status = "failed"
+)
+
+// Step is a unit of a saga. Execute and Compensate must be idempotent: after a crash the step that
+// was in flight is executed (or compensated) again on resume.
+type Step struct {
+	Name       string
+	Execute    func(ctx context.Context, data map[string]any) error
+	Compensate func(ctx context.Context, data map[string]any) error
+}
+
+// Definition is a named, ordered list of steps. Data shared between steps must be JSON serializable
+// because it is persisted after every step.
+type Definition struct {
+	Name  string
+	Steps []Step
+}
+
+// Instance is the persisted state of a saga execution. CompletedSteps counts the steps whose
+// Execute succeeded and were not compensated yet.
+type Instance struct {
+	ID             string
+	SagaName       string
+	Status         Status
+	CompletedSteps int
+	Data           map[string]any
+	FailureReason  string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (i *Instance) IsFinished() bool {
+	return i.Status == StatusCompleted || i.Status == StatusCompensated || i.Status == StatusFailed
+}
+
+// Store persists saga instances so unfinished sagas can be resumed after a crash. Every instance is
+// leased to the coordinator running it, other coordinators only claim it once the lease expired.
+type Store interface {
+	// Save persists the instance leased to owner until leaseUntil. It returns false without saving
+	// when another owner took the instance over.
+	Save(ctx context.Context, instance *Instance, owner string, leaseUntil time.Time) (bool, error)
+	// ClaimUnfinished leases the unfinished instances whose lease expired at now to owner until
+	// leaseUntil, oldest first
+	ClaimUnfinished(ctx context.Context, owner string, now time.Time, leaseUntil time.Time) ([]*Instance, error)
+}
@@ -0,0 +1,298 @@
+package schemachange
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+const DefaultBatchSize = 500
+
+// DefaultPhaseTTL bounds how long an instance keeps using a cached phase, a phase changed on another
+// instance is seen everywhere once it expires
+const DefaultPhaseTTL = 30 * time.Second
+
+// transitions are the phase changes Advance accepts. Reads can be switched back while the old schema
+// is still written, and a change can be abandoned until reads moved to the new schema.
+var transitions = map[Phase][]Phase{
+	PhaseOld:        {PhaseExpanded},
+	PhaseExpanded:   {PhaseReadNew, PhaseOld},
+	PhaseReadNew:    {PhaseContracted, PhaseExpanded},
+	PhaseContracted: {},
+}
+
+type cachedPhase struct {
+	phase    Phase
+	loadedAt time.Time
+}
+
+// Coordinator tells code which schema to read and write for every change in progress and runs their
+// backfills. Changes are registered in code with the deploy that expands the schema and removed with
+// the one that contracts it.
+type Coordinator struct {
+	store     Store
+	changes   map[string]Change
+	batchSize int
+	phaseTTL  time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedPhase
+}
+
+func NewCoordinator(store Store, changes ...Change) *Coordinator {
+	coordinator := &Coordinator{
+		store:     store,
+		changes:   make(map[string]Change, len(changes)),
+		batchSize: DefaultBatchSize,
+		phaseTTL:  DefaultPhaseTTL,
+		cache:     make(map[string]cachedPhase),
+	}
+	for _, change := range changes {
+		coordinator.changes[change.Name] = change
+	}
+	return coordinator
+}
+
+// WithPhaseTTL changes how long phases are cached, backfills wait as long after a change is expanded
+func (c *Coordinator) WithPhaseTTL(ttl time.Duration) *Coordinator {
+	c.phaseTTL = ttl
+	return c
+}
+
+// WithBatchSize changes how many rows a backfill batch copies
+func (c *Coordinator) WithBatchSize(size int) *Coordinator {
+	c.batchSize = size
+	return c
+}
+
+// Phase returns the current phase of a change. When the store cannot be read the last phase seen is
+// kept, so a database hiccup does not flip writes between schemas.
+func (c *Coordinator) Phase(ctx context.Context, name string) (Phase, error) {
+	c.mu.Lock()
+	cached, ok := c.cache[name]
+	c.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < c.phaseTTL {
+		return cached.phase, nil
+	}
+
+	state, err := c.store.Find(ctx, name)
+	if err != nil {
+		if ok {
+			log.Printf("Schema change %s: keeping phase %s, failed to reload it: %v", name, cached.phase, err)
+			return cached.phase, nil
+		}
+		return "", appErrors.PropagateError(err)
+	}
+	phase := PhaseOld
+	if state != nil {
+		phase = state.Phase
+	}
+
+	c.mu.Lock()
+	c.cache[name] = cachedPhase{phase: phase, loadedAt: time.Now()}
+	c.mu.Unlock()
+	return phase, nil
+}
+
+// ReadsNew tells whether reads of a change use the new schema
+func (c *Coordinator) ReadsNew(ctx context.Context, name string) (bool, error) {
+	phase, err := c.Phase(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return phase.ReadsNew(), nil
+}
+
+// DualWrite calls the writers of the schemas the current phase writes, the old one first. Call it in
+// the transaction of the write so both schemas commit together.
+func (c *Coordinator) DualWrite(ctx context.Context, name string, writeOld func(context.Context) error, writeNew func(context.Context) error) error {
+	phase, err := c.Phase(ctx, name)
+	if err != nil {
+		return err
+	}
+	if phase.WritesOld() {
+		if err := writeOld(ctx); err != nil {
+			return err
+		}
+	}
+	if phase.WritesNew() {
+		if err := writeNew(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Advance moves a change to another phase. Reads only move to the new schema once its backfill is
+// done, and going back to old discards the backfill since the new schema stops being written.
+func (c *Coordinator) Advance(ctx context.Context, name string, to Phase) (*State, error) {
+	change, ok := c.changes[name]
+	if !ok {
+		return nil, appErrors.NewValidationError(fmt.Sprintf("unknown schema change %s", name), map[string]any{"change": name}, nil)
+	}
+	state, err := c.state(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := false
+	for _, next := range transitions[state.Phase] {
+		allowed = allowed || next == to
+	}
+	if !allowed {
+		return nil, appErrors.NewValidationError(
+			fmt.Sprintf("schema change %s cannot move from %s to %s", name, state.Phase, to),
+			map[string]any{"change": name, "from": state.Phase, "to": to}, nil)
+	}
+	if to == PhaseReadNew && change.Backfill != nil && state.BackfilledAt == nil {
+		return nil, appErrors.NewValidationError(
+			fmt.Sprintf("schema change %s is backfilled at %.0f%%, reads move once it is done", name, state.Progress()*100),
+			map[string]any{"change": name, "progress": state.Progress()}, nil)
+	}
+
+	now := time.Now()
+	state.Phase = to
+	state.PhaseChangedAt = now
+	state.UpdatedAt = now
+	if to == PhaseOld {
+		state.Cursor, state.Backfilled, state.Total, state.BackfilledAt = "", 0, 0, nil
+	}
+	if to == PhaseExpanded && change.Backfill == nil && state.BackfilledAt == nil {
+		state.BackfilledAt = &now
+	}
+	if err := c.store.Save(ctx, state); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	c.mu.Lock()
+	c.cache[name] = cachedPhase{phase: to, loadedAt: now}
+	c.mu.Unlock()
+	return state, nil
+}
+
+// States returns the state of every registered change by name, changes never expanded are old
+func (c *Coordinator) States(ctx context.Context) ([]*State, error) {
+	names := make([]string, 0, len(c.changes))
+	for name := range c.changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	states := make([]*State, 0, len(names))
+	for _, name := range names {
+		state, err := c.state(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// Description returns the description of a registered change
+func (c *Coordinator) Description(name string) string {
+	return c.changes[name].Description
+}
+
+// Backfill copies pending rows of every expanded change. A change is only backfilled once its phase
+// is older than the phase cache, when every instance already writes both schemas and no new row can
+// be missed. Progress is saved after every batch so a restarted backfill resumes where it stopped.
+func (c *Coordinator) Backfill(ctx context.Context) error {
+	states, err := c.States(ctx)
+	if err != nil {
+		return err
+	}
+	for _, state := range states {
+		change := c.changes[state.Name]
+		if state.Phase != PhaseExpanded || state.BackfilledAt != nil || change.Backfill == nil {
+			continue
+		}
+		if time.Since(state.PhaseChangedAt) < c.phaseTTL {
+			continue
+		}
+		if err := c.backfill(ctx, change, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run calls Backfill every interval until ctx is cancelled
+func (c *Coordinator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Backfill(ctx); err != nil {
+			log.Printf("Schema change backfill failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Coordinator) backfill(ctx context.Context, change Change, state *State) error {
+	if state.Cursor == "" && state.Backfilled == 0 {
+		total, err := change.Backfill.Total(ctx)
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+		state.Total = total
+	}
+
+	for ctx.Err() == nil {
+		next, copied, err := change.Backfill.Batch(ctx, state.Cursor, c.batchSize)
+		if err != nil {
+			return appErrors.NewInfrastructureError(
+				fmt.Sprintf("schema change %s backfill failed after %q", change.Name, state.Cursor), err)
+		}
+
+		now := time.Now()
+		if copied > 0 {
+			state.Cursor = next
+			state.Backfilled += int64(copied)
+		}
+		if copied < c.batchSize {
+			state.BackfilledAt = &now
+		}
+		state.UpdatedAt = now
+
+		// The phase may have moved back to old meanwhile, only save progress of the phase that ran
+		current, err := c.store.Find(ctx, change.Name)
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+		if current == nil || current.Phase != state.Phase || !current.PhaseChangedAt.Equal(state.PhaseChangedAt) {
+			return nil
+		}
+		if err := c.store.Save(ctx, state); err != nil {
+			return appErrors.PropagateError(err)
+		}
+		if state.BackfilledAt != nil {
+			log.Printf("Schema change %s backfilled, %d rows copied", change.Name, state.Backfilled)
+			return nil
+		}
+	}
+	return nil
+}
+
+// state returns the stored state of a change, or the old phase when it was never expanded
+func (c *Coordinator) state(ctx context.Context, name string) (*State, error) {
+	state, err := c.store.Find(ctx, name)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if state == nil {
+		state = &State{Name: name, Phase: PhaseOld}
+	}
+	return state, nil
+}
@@ -0,0 +1,86 @@
+package schemachange
+
+import (
+	"context"
+	"time"
+)
+
+// Phase is how far an expand/contract schema change went. Code reads and writes through the
+// Coordinator, so each phase is switched at runtime without a deploy:
+//
+//	old -> expanded -> read_new -> contracted
+//
+// The expand migration adds the new schema before the change moves to expanded, and the contract
+// migration drops the old one once every instance runs with the change contracted.
+type Phase string
+
+const (
+	// PhaseOld is the phase of changes that were never expanded, only the old schema is used
+	PhaseOld Phase = "old"
+	// PhaseExpanded writes both schemas and reads the old one, the backfill copies the existing rows
+	PhaseExpanded Phase = "expanded"
+	// PhaseReadNew reads the new schema and still writes both, so reads can be switched back
+	PhaseReadNew Phase = "read_new"
+	// PhaseContracted only uses the new schema, the old one can be dropped
+	PhaseContracted Phase = "contracted"
+)
+
+func (p Phase) WritesOld() bool {
+	return p != PhaseContracted
+}
+
+func (p Phase) WritesNew() bool {
+	return p != PhaseOld
+}
+
+func (p Phase) ReadsNew() bool {
+	return p == PhaseReadNew || p == PhaseContracted
+}
+
+// Backfill copies the rows written before a change was expanded into the new schema, in batches in
+// key order. Batches run again after a crash, so they must be idempotent.
+type Backfill interface {
+	// Total estimates the rows to copy, it only drives the progress
+	Total(ctx context.Context) (int64, error)
+	// Batch copies up to limit rows after cursor, "" before the first batch. It returns the cursor of
+	// the last row copied and how many rows it copied, the backfill is done when fewer than limit.
+	Batch(ctx context.Context, cursor string, limit int) (string, int, error)
+}
+
+// Change is a schema change done expand/contract, Backfill is nil when there are no rows to copy
+type Change struct {
+	Name        string
+	Description string
+	Backfill    Backfill
+}
+
+// State is the persisted phase and backfill progress of a change
+type State struct {
+	Name           string
+	Phase          Phase
+	Cursor         string
+	Backfilled     int64
+	Total          int64
+	PhaseChangedAt time.Time
+	// BackfilledAt is set once every row was copied
+	BackfilledAt *time.Time
+	UpdatedAt    time.Time
+}
+
+// Progress is the share of the rows backfilled, from 0 to 1
+func (s *State) Progress() float64 {
+	if s.BackfilledAt != nil {
+		return 1
+	}
+	if s.Total <= 0 {
+		return 0
+	}
+	return min(1, float64(s.Backfilled)/float64(s.Total))
+}
+
+// Store persists the state of every change
+type Store interface {
+	// Find returns nil for changes that were never expanded
+	Find(ctx context.Context, name string) (*State, error)
+	Save(ctx context.Context, state *State) error
+}
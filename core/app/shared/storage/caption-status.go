@@ -0,0 +1,73 @@
+package storage
+
+import "strings"
+
+// CaptionStatus is where an uploaded video or audio file is in the generation of its captions and
+// transcript. They are generated by the caption provider once the file is scanned clean.
+type CaptionStatus string
+
+const (
+	CaptionPending CaptionStatus = "pending"
+	CaptionReady   CaptionStatus = "ready"
+	// CaptionNotNeeded files are neither video nor audio
+	CaptionNotNeeded CaptionStatus = "not_needed"
+	// CaptionUnsupported files are of a type the caption provider does not transcribe
+	CaptionUnsupported CaptionStatus = "unsupported"
+	// CaptionFailed files could not be transcribed, they are damaged or gone from the storage
+	CaptionFailed CaptionStatus = "failed"
+)
+
+// NeedsCaptions reports whether files of the content type are video or audio, which accessibility
+// mandates want captioned
+func NeedsCaptions(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return strings.HasPrefix(contentType, "video/") || strings.HasPrefix(contentType, "audio/")
+}
+
+// NeedsAltText reports whether files of the content type are images, which are uploaded with a text
+// alternative for screen readers
+func NeedsAltText(contentType string) bool {
+	return strings.HasPrefix(strings.ToLower(contentType), "image/")
+}
+
+// InitialCaptionStatus is the status an upload of the content type starts with
+func InitialCaptionStatus(contentType string) CaptionStatus {
+	if NeedsCaptions(contentType) {
+		return CaptionPending
+	}
+	return CaptionNotNeeded
+}
+
+// AlternativeFormat is a text version of a video or audio file, downloadable once its captions are
+// ready
+type AlternativeFormat string
+
+const (
+	// AlternativeCaptions are WebVTT captions timed to the media
+	AlternativeCaptions AlternativeFormat = "captions"
+	// AlternativeTranscript is the plain text of what is said in the media
+	AlternativeTranscript AlternativeFormat = "transcript"
+)
+
+// Key is where the alternative of the file kept under key is stored
+func (f AlternativeFormat) Key(key string) string {
+	if f == AlternativeTranscript {
+		return key + ".transcript.txt"
+	}
+	return key + ".captions.vtt"
+}
+
+func (f AlternativeFormat) ContentType() string {
+	if f == AlternativeTranscript {
+		return "text/plain; charset=utf-8"
+	}
+	return "text/vtt"
+}
+
+// Extension is the file name extension downloads of the alternative are named with
+func (f AlternativeFormat) Extension() string {
+	if f == AlternativeTranscript {
+		return ".txt"
+	}
+	return ".vtt"
+}
@@ -0,0 +1,13 @@
+package storage
+
+import "context"
+
+// FileStorage keeps uploaded files by key. Callers build keys from IDs they own and keep the file
+// metadata (name, content type, size) next to their own records.
+type FileStorage interface {
+	Put(ctx context.Context, key string, content []byte) error
+	// Get returns nil when nothing is stored under key
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete succeeds when nothing is stored under key
+	Delete(ctx context.Context, key string) error
+}
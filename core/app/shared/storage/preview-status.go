@@ -0,0 +1,23 @@
+package storage
+
+// PreviewStatus is where an uploaded file is in the generation of its preview. Previews are PNG
+// thumbnails of images and of the first page of documents, they are generated once the file is
+// scanned clean.
+type PreviewStatus string
+
+const (
+	PreviewPending PreviewStatus = "pending"
+	PreviewReady   PreviewStatus = "ready"
+	// PreviewUnsupported files are of a type no preview is rendered for
+	PreviewUnsupported PreviewStatus = "unsupported"
+	// PreviewFailed files could not be rendered, they are damaged or gone from the storage
+	PreviewFailed PreviewStatus = "failed"
+)
+
+// PreviewContentType is the content type of every preview
+const PreviewContentType = "image/png"
+
+// PreviewKey is where the preview of the file kept under key is stored
+func PreviewKey(key string) string {
+	return key + ".preview.png"
+}
@@ -0,0 +1,18 @@
+package storage
+
+// ScanStatus is where an uploaded file is in its virus scan. Files are quarantined, they are neither
+// served nor made current, until a scan finds them clean.
+type ScanStatus string
+
+const (
+	ScanPending  ScanStatus = "pending"
+	ScanClean    ScanStatus = "clean"
+	ScanInfected ScanStatus = "infected"
+	// ScanMissing files were gone from the storage when they were scanned
+	ScanMissing ScanStatus = "missing"
+)
+
+// IsAvailable tells whether the file can be served
+func (s ScanStatus) IsAvailable() bool {
+	return s == ScanClean
+}
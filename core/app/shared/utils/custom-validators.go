@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// Domain format tags, registered on every validator passed to ValidateStruct or built by NewValidator
+const (
+	// SchoolEmailTag takes the name of the field holding the tenant, e.g. school_email=TenantID
+	SchoolEmailTag = "school_email"
+	PhoneTag       = "phone"
+	GradeLevelTag  = "grade_level"
+	ClassCodeTag   = "class_code"
+)
+
+// GradeLevels are the grade levels a school can teach, pre-kindergarten to 12th grade
+var GradeLevels = []string{"PK", "K", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}
+
+var (
+	// phonePattern is an E.164 number once spaces, dashes, dots and parentheses are removed
+	phonePattern     = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	phoneSeparators  = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+	classCodePattern = regexp.MustCompile(`^[A-Z0-9]{1,10}(-[A-Z0-9]{1,10}){0,2}$`)
+)
+
+// customMessages are the messages of the domain tags in the translated locales, {0} is the field
+var customMessages = map[string]map[string]string{
+	SchoolEmailTag: {
+		"es": "{0} debe ser un correo de un dominio del colegio",
+		"pt": "{0} deve ser um e-mail de um domínio da escola",
+	},
+	PhoneTag: {
+		"es": "{0} debe ser un número de teléfono internacional, por ejemplo +50212345678",
+		"pt": "{0} deve ser um número de telefone internacional, por exemplo +5511912345678",
+	},
+	GradeLevelTag: {
+		"es": "{0} debe ser un grado válido: PK, K o de 1 a 12",
+		"pt": "{0} deve ser uma série válida: PK, K ou de 1 a 12",
+	},
+	ClassCodeTag: {
+		"es": "{0} debe ser un código de clase como MATH-101",
+		"pt": "{0} deve ser um código de turma como MATH-101",
+	},
+}
+
+var schoolEmailDomains = struct {
+	sync.RWMutex
+	byTenant map[string][]string
+}{}
+
+// SetSchoolEmailDomains sets the email domains of the tenants' schools. Tenants without domains
+// accept any email address.
+func SetSchoolEmailDomains(byTenant map[string][]string) {
+	normalized := make(map[string][]string, len(byTenant))
+	for tenantID, domains := range byTenant {
+		for _, domain := range domains {
+			normalized[tenantID] = append(normalized[tenantID], strings.ToLower(strings.TrimPrefix(domain, "@")))
+		}
+	}
+
+	schoolEmailDomains.Lock()
+	defer schoolEmailDomains.Unlock()
+	schoolEmailDomains.byTenant = normalized
+}
+
+// NewValidator builds a validator that knows the domain format tags, for entities that use them
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+	prepare(validate)
+	return validate
+}
+
+func registerCustomValidations(validate *validator.Validate) {
+	_ = validate.RegisterValidation(SchoolEmailTag, isSchoolEmail)
+	_ = validate.RegisterValidation(PhoneTag, isPhone)
+	_ = validate.RegisterValidation(GradeLevelTag, isGradeLevel)
+	_ = validate.RegisterValidation(ClassCodeTag, isClassCode)
+}
+
+func registerCustomTranslations(validate *validator.Validate, trans ut.Translator) {
+	for tag, messages := range customMessages {
+		message, translated := messages[trans.Locale()]
+		if !translated {
+			continue
+		}
+		_ = validate.RegisterTranslation(tag, trans, func(trans ut.Translator) error {
+			return trans.Add(tag, message, true)
+		}, func(trans ut.Translator, fe validator.FieldError) string {
+			text, _ := trans.T(tag, fe.Field())
+			return text
+		})
+	}
+}
+
+func isSchoolEmail(fl validator.FieldLevel) bool {
+	email := strings.ToLower(fl.Field().String())
+	_, domain, found := strings.Cut(email, "@")
+	if !found || domain == "" {
+		return false
+	}
+
+	tenant := reflect.Indirect(fl.Parent()).FieldByName(fl.Param())
+	if !tenant.IsValid() || tenant.Kind() != reflect.String {
+		return false
+	}
+
+	schoolEmailDomains.RLock()
+	defer schoolEmailDomains.RUnlock()
+	domains := schoolEmailDomains.byTenant[tenant.String()]
+	if len(domains) == 0 {
+		return true
+	}
+	for _, allowed := range domains {
+		if domain == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func isPhone(fl validator.FieldLevel) bool {
+	return phonePattern.MatchString(phoneSeparators.Replace(fl.Field().String()))
+}
+
+func isGradeLevel(fl validator.FieldLevel) bool {
+	level := fl.Field().String()
+	for _, grade := range GradeLevels {
+		if level == grade {
+			return true
+		}
+	}
+	return false
+}
+
+func isClassCode(fl validator.FieldLevel) bool {
+	return classCodePattern.MatchString(fl.Field().String())
+}
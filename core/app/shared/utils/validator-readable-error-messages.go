@@ -2,12 +2,101 @@ package utils
 
 import (
 	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
 	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
 
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/pt"
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	esTranslations "github.com/go-playground/validator/v10/translations/es"
+	ptTranslations "github.com/go-playground/validator/v10/translations/pt"
 )
 
-func MsgForTag(fe validator.FieldError) string {
+// DefaultLocale is used when the request accepts none of the supported locales
+const DefaultLocale = "en"
+
+var translators = ut.New(en.New(), en.New(), es.New(), pt.New())
+
+// prepared holds a *sync.Once per validator instance, commands keep a validator each and it gets
+// the domain tags, translations and field names the first time it validates
+var prepared sync.Map
+
+func prepare(validate *validator.Validate) {
+	once, _ := prepared.LoadOrStore(validate, &sync.Once{})
+	once.(*sync.Once).Do(func() {
+		validate.RegisterTagNameFunc(FieldName)
+		registerCustomValidations(validate)
+
+		register := map[string]func(*validator.Validate, ut.Translator) error{
+			"en": enTranslations.RegisterDefaultTranslations,
+			"es": esTranslations.RegisterDefaultTranslations,
+			"pt": ptTranslations.RegisterDefaultTranslations,
+		}
+		for locale, registerDefaults := range register {
+			trans, _ := translators.GetTranslator(locale)
+			// Registration only fails on duplicate keys, the messages then stay untranslated
+			_ = registerDefaults(validate, trans)
+			registerCustomTranslations(validate, trans)
+		}
+	})
+}
+
+// FieldName is the name of a field in error maps: its JSON name, or the Go name in snake case for
+// commands, which mirror the request fields without JSON tags
+func FieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return SnakeCase(field.Name)
+}
+
+// SnakeCase turns a Go identifier such as TenantID or ClosesAt into tenant_id or closes_at
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			startsWord := i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])))
+			if startsWord {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// TranslatorFor picks the translator of the first supported locale in an Accept-Language header,
+// es-GT falls back to es. Quality values are ignored, clients list locales by preference.
+func TranslatorFor(acceptLanguage string) ut.Translator {
+	for _, tag := range strings.Split(acceptLanguage, ",") {
+		tag, _, _ = strings.Cut(strings.TrimSpace(tag), ";")
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if trans, found := translators.GetTranslator(base); found && base != "" {
+			return trans
+		}
+	}
+	trans, _ := translators.GetTranslator(DefaultLocale)
+	return trans
+}
+
+// MsgForTag describes a failed validation in the locale of trans, in English when trans is nil or
+// has no message for the tag
+func MsgForTag(fe validator.FieldError, trans ut.Translator) string {
+	if trans != nil && trans.Locale() != DefaultLocale {
+		if message := fe.Translate(trans); message != fe.Error() {
+			return message
+		}
+	}
+
 	switch fe.Tag() {
 	case "required":
 		return "This field is required"
@@ -17,12 +106,25 @@ func MsgForTag(fe validator.FieldError) string {
 		return "Too short (minimum " + fe.Param() + " characters)"
 	case "max":
 		return "Too long (maximum " + fe.Param() + " characters)"
+	case SchoolEmailTag:
+		return "Must be an email address of the school's domain"
+	case PhoneTag:
+		return "Invalid phone number (use the international format, e.g. +50212345678)"
+	case GradeLevelTag:
+		return "Invalid grade level (PK, K or 1 to 12)"
+	case ClassCodeTag:
+		return "Invalid class code (e.g. MATH-101)"
 	default:
-		return fe.Error() // fallback to default error
+		if trans == nil {
+			return fe.Error() // fallback to default error
+		}
+		english, _ := translators.GetTranslator(DefaultLocale)
+		return fe.Translate(english)
 	}
 }
 
 func ValidateStruct(validate *validator.Validate, command interface{}) *appErrors.BaseDomainError {
+	prepare(validate)
 	err := validate.Struct(command)
 
 	if err == nil {
@@ -31,14 +133,28 @@ func ValidateStruct(validate *validator.Validate, command interface{}) *appError
 
 	var validationErrors validator.ValidationErrors
 	if errors.As(err, &validationErrors) {
-		errorMap := make(map[string]any)
+		english, _ := translators.GetTranslator(DefaultLocale)
+		return appErrors.NewValidationError("Invalid user creation request", ValidationErrorMap(validationErrors, english), err)
+	}
 
-		for _, fe := range validationErrors {
-			errorMap[fe.Field()] = MsgForTag(fe)
-		}
+	return appErrors.NewValidationError("Invalid user creation request", nil, err)
+}
 
-		return appErrors.NewValidationError("Invalid user creation request", errorMap, err)
+// ValidationErrorMap maps the JSON name of every invalid field to its message in the locale of trans
+func ValidationErrorMap(validationErrors validator.ValidationErrors, trans ut.Translator) map[string]any {
+	errorMap := make(map[string]any, len(validationErrors))
+	for _, fe := range validationErrors {
+		errorMap[fe.Field()] = MsgForTag(fe, trans)
 	}
+	return errorMap
+}
 
-	return appErrors.NewValidationError("Invalid user creation request", nil, err)
+// LocalizeValidationError returns the error map of a validation error raised by ValidateStruct in
+// the first supported locale of acceptLanguage, nil for other errors
+func LocalizeValidationError(err error, acceptLanguage string) map[string]any {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+	return ValidationErrorMap(validationErrors, TranslatorFor(acceptLanguage))
 }
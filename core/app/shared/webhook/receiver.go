@@ -0,0 +1,225 @@
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultLease is how long a delivery stays with the worker handling it, handlers must finish within it
+	DefaultLease = 5 * time.Minute
+	// DefaultMaxAttempts bounds the attempts of a delivery before it is marked failed
+	DefaultMaxAttempts = 10
+	// DefaultRetention is how long processed deliveries are kept, their event IDs are deduplicated
+	// for as long. It outlasts the retries of the providers, Stripe retries for three days.
+	DefaultRetention = 30 * 24 * time.Hour
+
+	batchSize = 20
+	// Failed attempts are retried after retryDelay, doubled on every attempt up to maxRetryDelay
+	retryDelay    = 30 * time.Second
+	maxRetryDelay = time.Hour
+	purgeInterval = time.Hour
+)
+
+// Receiver persists the webhook requests of the registered sources once verified and before they
+// are processed, and processes them on a worker. Providers are answered as soon as the delivery is
+// persisted, the ones they retry with an event ID received before are acknowledged without being
+// processed again. Deliveries are leased to the worker handling them, so workers on several
+// replicas only take over the deliveries of one that stopped.
+type Receiver struct {
+	store       Store
+	sources     map[string]Source
+	clock       clock.Clock
+	lease       time.Duration
+	maxAttempts int
+	retention   time.Duration
+}
+
+func NewReceiver(store Store) *Receiver {
+	return &Receiver{
+		store:       store,
+		sources:     make(map[string]Source),
+		clock:       clock.System,
+		lease:       DefaultLease,
+		maxAttempts: DefaultMaxAttempts,
+		retention:   DefaultRetention,
+	}
+}
+
+func (r *Receiver) WithClock(clock clock.Clock) *Receiver {
+	r.clock = clock
+	return r
+}
+
+// WithLease changes how long deliveries stay with the worker handling them
+func (r *Receiver) WithLease(lease time.Duration) *Receiver {
+	r.lease = lease
+	return r
+}
+
+// WithMaxAttempts changes the attempts of a delivery before it is marked failed
+func (r *Receiver) WithMaxAttempts(maxAttempts int) *Receiver {
+	r.maxAttempts = maxAttempts
+	return r
+}
+
+// WithRetention changes how long processed deliveries are kept
+func (r *Receiver) WithRetention(retention time.Duration) *Receiver {
+	r.retention = retention
+	return r
+}
+
+// Register makes a source available to Receive and Process, it must happen before Run is called.
+// Deliveries of sources an instance did not register are left to the instances that did.
+func (r *Receiver) Register(source Source) {
+	r.sources[source.Name] = source
+}
+
+// Receive verifies a request of the source and persists it for the worker. It returns false when
+// the source delivered the event before, the request is acknowledged without being persisted again.
+// Requests without an event ID are deduplicated by the hash of their payload.
+func (r *Receiver) Receive(ctx context.Context, sourceName string, request Request) (bool, error) {
+	source, ok := r.sources[sourceName]
+	if !ok {
+		return false, appErrors.NewInfrastructureError(fmt.Sprintf("webhook source %s is not registered", sourceName), nil)
+	}
+
+	eventID, err := source.Verify(ctx, request)
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	if eventID == "" {
+		sum := sha256.Sum256(request.Payload)
+		eventID = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	now := r.clock.Now().UTC()
+	created, err := r.store.Create(ctx, &Delivery{
+		ID:            uuid.NewString(),
+		Source:        sourceName,
+		EventID:       eventID,
+		Request:       request,
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		ReceivedAt:    now,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	if !created {
+		log.Printf("Webhook %s %s was delivered again, acknowledged without processing", sourceName, eventID)
+	}
+	return created, nil
+}
+
+// Process claims the due deliveries of the registered sources and handles them, it returns how many
+// it claimed. A failing delivery is retried later and does not stop the others, the failures to
+// save their outcome are returned together.
+func (r *Receiver) Process(ctx context.Context) (int, error) {
+	if len(r.sources) == 0 {
+		return 0, nil
+	}
+
+	sources := make([]string, 0, len(r.sources))
+	for name := range r.sources {
+		sources = append(sources, name)
+	}
+	slices.Sort(sources)
+
+	now := r.clock.Now().UTC()
+	deliveries, err := r.store.ClaimDue(ctx, sources, now, now.Add(r.lease), batchSize)
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	var failures []error
+	for _, delivery := range deliveries {
+		if err := r.handle(ctx, delivery); err != nil {
+			failures = append(failures, err)
+		}
+	}
+
+	return len(deliveries), errors.Join(failures...)
+}
+
+// Run processes the due deliveries every interval until ctx is cancelled, and drops the processed
+// deliveries older than the retention every hour
+func (r *Receiver) Run(ctx context.Context, interval time.Duration) {
+	ticker := r.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	var purgedAt time.Time
+	for {
+		// A full batch means more deliveries are probably due
+		for {
+			claimed, err := r.Process(ctx)
+			if err != nil {
+				log.Printf("Failed to process webhook deliveries: %v", err)
+			}
+			if claimed < batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		if now := r.clock.Now(); now.Sub(purgedAt) >= purgeInterval {
+			purgedAt = now
+			if deleted, err := r.store.DeleteProcessedBefore(ctx, now.UTC().Add(-r.retention)); err != nil {
+				log.Printf("Failed to delete processed webhook deliveries: %v", err)
+			} else if deleted > 0 {
+				log.Printf("Deleted %d processed webhook deliveries", deleted)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+func (r *Receiver) handle(ctx context.Context, delivery *Delivery) error {
+	handleErr := r.sources[delivery.Source].Handle(ctx, delivery.Request)
+
+	now := r.clock.Now().UTC()
+	switch {
+	case handleErr == nil:
+		delivery.Status = StatusProcessed
+		delivery.LastError = ""
+		delivery.ProcessedAt = &now
+	case delivery.Attempts >= r.maxAttempts:
+		delivery.Status = StatusFailed
+		delivery.LastError = handleErr.Error()
+		log.Printf("Webhook %s %s failed %d times, giving up: %v", delivery.Source, delivery.EventID, delivery.Attempts, handleErr)
+	default:
+		delivery.Status = StatusPending
+		delivery.LastError = handleErr.Error()
+		delivery.NextAttemptAt = now.Add(RetryDelay(delivery.Attempts))
+		log.Printf("Webhook %s %s failed, attempt %d: %v", delivery.Source, delivery.EventID, delivery.Attempts, handleErr)
+	}
+
+	if err := r.store.Save(ctx, delivery); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+// RetryDelay is the wait before the attempt that follows the given one
+func RetryDelay(attempt int) time.Duration {
+	delay := retryDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
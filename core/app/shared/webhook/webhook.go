@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusProcessing Status = "processing"
+	StatusProcessed  Status = "processed"
+	// StatusFailed means every attempt failed and the delivery needs manual intervention
+	StatusFailed Status = "failed"
+)
+
+// Request is a webhook request as received, Headers holds the headers the source verifies with
+type Request struct {
+	Payload []byte
+	Headers map[string]string
+}
+
+// Source is a provider sending webhooks to one of the endpoints of the API
+type Source struct {
+	Name string
+	// Verify authenticates a request and returns the ID the provider gave the event it carries, or
+	// an empty ID when it gives none. The error is returned to the provider as is.
+	Verify func(ctx context.Context, request Request) (string, error)
+	// Handle processes a request Verify accepted. It runs on the worker, possibly long after the
+	// signature expired, so it must not verify the request again. It must be idempotent: a delivery
+	// that was in flight when a worker stopped is handled again.
+	Handle func(ctx context.Context, request Request) error
+}
+
+// Delivery is a verified webhook request, persisted before it is processed. EventID is unique per
+// source so the deliveries a provider retries are acknowledged without being processed again.
+type Delivery struct {
+	ID       string
+	Source   string
+	EventID  string
+	Request  Request
+	Status   Status
+	Attempts int
+	// LastError is the failure of the last attempt, empty once the delivery was processed
+	LastError string
+	// NextAttemptAt is when a pending delivery is due, and when the lease of a processing one ends
+	NextAttemptAt time.Time
+	ReceivedAt    time.Time
+	ProcessedAt   *time.Time
+}
+
+func (d *Delivery) IsFinished() bool {
+	return d.Status == StatusProcessed || d.Status == StatusFailed
+}
+
+// Store persists the deliveries of every source, it is the queue the worker processes them from
+type Store interface {
+	// Create persists a new delivery. It returns false without saving when the source already has a
+	// delivery with its event ID.
+	Create(ctx context.Context, delivery *Delivery) (bool, error)
+	// ClaimDue marks up to limit deliveries of the sources processing until leaseUntil and counts an
+	// attempt for each, oldest first: the pending ones due at now and the processing ones whose
+	// lease ended. Deliveries locked by a concurrent claim are skipped.
+	ClaimDue(ctx context.Context, sources []string, now time.Time, leaseUntil time.Time, limit int) ([]*Delivery, error)
+	// Save persists the outcome of an attempt
+	Save(ctx context.Context, delivery *Delivery) error
+	// DeleteProcessedBefore drops the deliveries processed before the time and returns their count
+	DeleteProcessedBefore(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,28 @@
+package get_similarity_check_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetSimilarityCheckCommand struct {
+	TenantID string `validate:"required"`
+	CheckID  string `validate:"required,uuid"`
+}
+
+func NewGetSimilarityCheckCommand(tenantID string, checkID string) (*GetSimilarityCheckCommand, error) {
+	command := &GetSimilarityCheckCommand{
+		TenantID: tenantID,
+		CheckID:  checkID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
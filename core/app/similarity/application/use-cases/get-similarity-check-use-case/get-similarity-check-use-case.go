@@ -0,0 +1,32 @@
+package get_similarity_check_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/entities"
+	similarityErrors "github.com/nahualventure/class-backend/core/app/similarity/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/ports"
+)
+
+type GetSimilarityCheckUseCase struct {
+	checkRepo ports.SimilarityCheckRepository
+}
+
+func NewGetSimilarityCheckUseCase(checkRepo ports.SimilarityCheckRepository) *GetSimilarityCheckUseCase {
+	return &GetSimilarityCheckUseCase{
+		checkRepo: checkRepo,
+	}
+}
+
+func (uc *GetSimilarityCheckUseCase) Execute(ctx context.Context, cmd *GetSimilarityCheckCommand) (*entities.SimilarityCheck, error) {
+	check, err := uc.checkRepo.FindByID(ctx, cmd.TenantID, cmd.CheckID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if check == nil {
+		return nil, similarityErrors.NewSimilarityCheckNotFoundError(cmd.CheckID)
+	}
+
+	return check, nil
+}
@@ -0,0 +1,30 @@
+package list_similarity_checks_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListSimilarityChecksCommand struct {
+	TenantID     string `validate:"required"`
+	ClassID      string `validate:"required,uuid"`
+	AssignmentID string `validate:"required,uuid"`
+}
+
+func NewListSimilarityChecksCommand(tenantID string, classID string, assignmentID string) (*ListSimilarityChecksCommand, error) {
+	command := &ListSimilarityChecksCommand{
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,29 @@
+package list_similarity_checks_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/ports"
+)
+
+type ListSimilarityChecksUseCase struct {
+	checkRepo ports.SimilarityCheckRepository
+}
+
+func NewListSimilarityChecksUseCase(checkRepo ports.SimilarityCheckRepository) *ListSimilarityChecksUseCase {
+	return &ListSimilarityChecksUseCase{
+		checkRepo: checkRepo,
+	}
+}
+
+// Execute returns every check of the assignment, newest first
+func (uc *ListSimilarityChecksUseCase) Execute(ctx context.Context, cmd *ListSimilarityChecksCommand) ([]*entities.SimilarityCheck, error) {
+	checks, err := uc.checkRepo.ListByAssignment(ctx, cmd.TenantID, cmd.ClassID, cmd.AssignmentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return checks, nil
+}
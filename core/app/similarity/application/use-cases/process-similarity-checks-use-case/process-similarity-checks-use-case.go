@@ -0,0 +1,65 @@
+package process_similarity_checks_use_case
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/ports"
+)
+
+const (
+	// MaxAttempts is how many times a document is offered to the provider before the check fails
+	MaxAttempts = 5
+	// StaleAfter is how long a check can stay claimed before another worker reclaims it
+	StaleAfter = 10 * time.Minute
+	// RetryBackoff is the delay before the first retry, it doubles on every attempt
+	RetryBackoff = 30 * time.Second
+)
+
+type ProcessSimilarityChecksUseCase struct {
+	queue   ports.SimilarityJobQueue
+	checker ports.SimilarityChecker
+}
+
+func NewProcessSimilarityChecksUseCase(queue ports.SimilarityJobQueue, checker ports.SimilarityChecker) *ProcessSimilarityChecksUseCase {
+	return &ProcessSimilarityChecksUseCase{
+		queue:   queue,
+		checker: checker,
+	}
+}
+
+// Execute submits the next pending check to the provider. It returns false when the queue is empty.
+// Provider errors are retried with backoff until MaxAttempts, the error is returned for logging.
+func (uc *ProcessSimilarityChecksUseCase) Execute(ctx context.Context) (bool, error) {
+	check, document, err := uc.queue.ClaimNextPending(ctx, StaleAfter)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+
+	if check == nil {
+		return false, nil
+	}
+
+	reference, err := uc.checker.Submit(ctx, check, document)
+	if err != nil {
+		if check.Attempts >= MaxAttempts {
+			if markErr := uc.queue.MarkFailed(ctx, check.ID, err.Error()); markErr != nil {
+				return true, errors.PropagateError(markErr)
+			}
+		} else {
+			retryAt := time.Now().UTC().Add(RetryBackoff << (check.Attempts - 1))
+			if markErr := uc.queue.MarkRetry(ctx, check.ID, err.Error(), retryAt); markErr != nil {
+				return true, errors.PropagateError(markErr)
+			}
+		}
+		return true, errors.NewInfrastructureError(fmt.Sprintf("failed to submit similarity check %s (attempt %d)", check.ID, check.Attempts), err)
+	}
+
+	if err := uc.queue.MarkSubmitted(ctx, check.ID, reference); err != nil {
+		return true, errors.PropagateError(err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,28 @@
+package receive_similarity_result_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// ReceiveSimilarityResultCommand carries a callback body the provider signature was verified for
+// when it was received
+type ReceiveSimilarityResultCommand struct {
+	Payload []byte `validate:"required"`
+}
+
+func NewReceiveSimilarityResultCommand(payload []byte) (*ReceiveSimilarityResultCommand, error) {
+	command := &ReceiveSimilarityResultCommand{
+		Payload: payload,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
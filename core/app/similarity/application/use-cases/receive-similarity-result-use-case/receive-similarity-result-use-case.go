@@ -0,0 +1,51 @@
+package receive_similarity_result_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/entities"
+	similarityErrors "github.com/nahualventure/class-backend/core/app/similarity/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/ports"
+)
+
+type ReceiveSimilarityResultUseCase struct {
+	checkRepo ports.SimilarityCheckRepository
+	checker   ports.SimilarityChecker
+}
+
+func NewReceiveSimilarityResultUseCase(checkRepo ports.SimilarityCheckRepository, checker ports.SimilarityChecker) *ReceiveSimilarityResultUseCase {
+	return &ReceiveSimilarityResultUseCase{
+		checkRepo: checkRepo,
+		checker:   checker,
+	}
+}
+
+// Execute stores the result of a provider callback, it runs on the webhook worker. Providers retry
+// callbacks, a callback for a finished check returns the stored check unchanged.
+func (uc *ReceiveSimilarityResultUseCase) Execute(ctx context.Context, cmd *ReceiveSimilarityResultCommand) (*entities.SimilarityCheck, error) {
+	result, err := uc.checker.ParseCallback(cmd.Payload)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	check, err := uc.checkRepo.FindByProviderReference(ctx, result.ProviderReference)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if check == nil {
+		return nil, similarityErrors.NewSimilarityCheckNotFoundError(result.ProviderReference)
+	}
+
+	if check.IsFinished() {
+		return check, nil
+	}
+
+	check.ApplyResult(result, time.Now().UTC())
+	if err := uc.checkRepo.SaveResult(ctx, check); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return check, nil
+}
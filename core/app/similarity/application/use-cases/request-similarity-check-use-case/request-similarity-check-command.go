@@ -0,0 +1,42 @@
+package request_similarity_check_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxDocumentBytes is the largest document accepted for a similarity check
+const MaxDocumentBytes = 5 << 20
+
+var validate = validator.New()
+
+type RequestSimilarityCheckCommand struct {
+	TenantID     string `validate:"required"`
+	ClassID      string `validate:"required,uuid"`
+	AssignmentID string `validate:"required,uuid"`
+	StudentID    string `validate:"required"`
+	FileName     string `validate:"required,max=255"`
+	ContentType  string `validate:"required,max=100"`
+	Content      []byte `validate:"required,max=5242880"`
+}
+
+func NewRequestSimilarityCheckCommand(tenantID string, classID string, assignmentID string, studentID string, fileName string,
+	contentType string, content []byte) (*RequestSimilarityCheckCommand, error) {
+	command := &RequestSimilarityCheckCommand{
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+		StudentID:    studentID,
+		FileName:     fileName,
+		ContentType:  contentType,
+		Content:      content,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,55 @@
+package request_similarity_check_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+type RequestSimilarityCheckUseCase struct {
+	checkRepo ports.SimilarityCheckRepository
+}
+
+func NewRequestSimilarityCheckUseCase(checkRepo ports.SimilarityCheckRepository) *RequestSimilarityCheckUseCase {
+	return &RequestSimilarityCheckUseCase{
+		checkRepo: checkRepo,
+	}
+}
+
+// Execute queues the submitted document, the worker sends it to the provider asynchronously. Every
+// submission gets its own check so resubmissions are compared again.
+func (uc *RequestSimilarityCheckUseCase) Execute(ctx context.Context, cmd *RequestSimilarityCheckCommand) (*entities.SimilarityCheck, error) {
+	now := time.Now().UTC()
+	check, err := entities.NewSimilarityCheck(
+		uuid.New().String(),
+		cmd.TenantID,
+		cmd.ClassID,
+		cmd.AssignmentID,
+		cmd.StudentID,
+		cmd.FileName,
+		entities.SimilarityCheckStatusPending,
+		0,
+		"",
+		nil,
+		"",
+		"",
+		now,
+		now,
+		nil,
+	)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	document := &entities.Document{FileName: cmd.FileName, ContentType: cmd.ContentType, Content: cmd.Content}
+	if err := uc.checkRepo.Create(ctx, check, document); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return check, nil
+}
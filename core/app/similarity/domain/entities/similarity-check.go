@@ -0,0 +1,114 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SimilarityCheckStatus string
+
+const (
+	// SimilarityCheckStatusPending checks wait for the worker to hand the document to the provider
+	SimilarityCheckStatusPending SimilarityCheckStatus = "pending"
+	// SimilarityCheckStatusSubmitted checks wait for the provider callback
+	SimilarityCheckStatusSubmitted SimilarityCheckStatus = "submitted"
+	SimilarityCheckStatusCompleted SimilarityCheckStatus = "completed"
+	SimilarityCheckStatusFailed    SimilarityCheckStatus = "failed"
+)
+
+// SimilarityCheck tracks one submission through the similarity provider. The document is stored apart
+// and dropped once the provider accepted it.
+type SimilarityCheck struct {
+	ID                string                `validate:"required,uuid4"`
+	TenantID          string                `validate:"required"`
+	ClassID           string                `validate:"required,uuid"`
+	AssignmentID      string                `validate:"required,uuid"`
+	StudentID         string                `validate:"required"`
+	FileName          string                `validate:"required,max=255"`
+	Status            SimilarityCheckStatus `validate:"required,oneof=pending submitted completed failed"`
+	Attempts          int
+	ProviderReference string
+	Score             *float64 `validate:"omitempty,gte=0,lte=100"`
+	ReportURL         string
+	FailureReason     string
+	CreatedAt         time.Time `validate:"required"`
+	UpdatedAt         time.Time `validate:"required"`
+	CompletedAt       *time.Time
+}
+
+func NewSimilarityCheck(id string, tenantID string, classID string, assignmentID string, studentID string, fileName string,
+	status SimilarityCheckStatus, attempts int, providerReference string, score *float64, reportURL string, failureReason string,
+	createdAt time.Time, updatedAt time.Time, completedAt *time.Time) (*SimilarityCheck, error) {
+	check := &SimilarityCheck{
+		ID:                id,
+		TenantID:          tenantID,
+		ClassID:           classID,
+		AssignmentID:      assignmentID,
+		StudentID:         studentID,
+		FileName:          fileName,
+		Status:            status,
+		Attempts:          attempts,
+		ProviderReference: providerReference,
+		Score:             score,
+		ReportURL:         reportURL,
+		FailureReason:     failureReason,
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+		CompletedAt:       completedAt,
+	}
+
+	if err := validate.Struct(check); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("SimilarityCheck domain model instance not valid", errorMap, err)
+	}
+
+	return check, nil
+}
+
+// IsFinished reports whether the provider already answered, callbacks for finished checks are ignored
+func (c *SimilarityCheck) IsFinished() bool {
+	return c.Status == SimilarityCheckStatusCompleted || c.Status == SimilarityCheckStatusFailed
+}
+
+// ApplyResult records the provider outcome
+func (c *SimilarityCheck) ApplyResult(result *SimilarityResult, now time.Time) {
+	if result.Failed {
+		c.Status = SimilarityCheckStatusFailed
+		c.FailureReason = result.FailureReason
+	} else {
+		score := result.Score
+		c.Status = SimilarityCheckStatusCompleted
+		c.Score = &score
+		c.ReportURL = result.ReportURL
+	}
+	c.UpdatedAt = now
+	c.CompletedAt = &now
+}
+
+// Document is the submitted file sent to the provider
+type Document struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// SimilarityResult is a provider callback. Score is the percentage of the document matching other
+// sources.
+type SimilarityResult struct {
+	ProviderReference string
+	Failed            bool
+	FailureReason     string
+	Score             float64
+	ReportURL         string
+}
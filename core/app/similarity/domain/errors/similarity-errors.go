@@ -0,0 +1,41 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	SimilarityCheckNotFoundError   errors2.ErrorCode = "SIMILARITY_CHECK_NOT_FOUND"
+	InvalidSimilarityCallbackError errors2.ErrorCode = "INVALID_SIMILARITY_CALLBACK"
+)
+
+func NewSimilarityCheckNotFoundError(reference string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    SimilarityCheckNotFoundError.String(),
+			Message: "The requested similarity check could not be found",
+			Context: map[string]any{
+				"reference": reference,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(SimilarityCheckNotFoundError.String()),
+		},
+	}
+}
+
+func NewInvalidSimilarityCallbackError(reason string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    InvalidSimilarityCallbackError.String(),
+			Message: "The similarity callback could not be verified",
+			Context: map[string]any{
+				"reason": reason,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidSimilarityCallbackError.String()),
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/entities"
+)
+
+type SimilarityCheckRepository interface {
+	Create(ctx context.Context, check *entities.SimilarityCheck, document *entities.Document) error
+	FindByID(ctx context.Context, tenantID string, checkID string) (*entities.SimilarityCheck, error)
+	// FindByProviderReference looks across tenants, provider callbacks only carry the reference
+	FindByProviderReference(ctx context.Context, providerReference string) (*entities.SimilarityCheck, error)
+	ListByAssignment(ctx context.Context, tenantID string, classID string, assignmentID string) ([]*entities.SimilarityCheck, error)
+	SaveResult(ctx context.Context, check *entities.SimilarityCheck) error
+	SimilarityJobQueue
+}
+
+// SimilarityJobQueue is the worker side of the repository. ClaimNextPending also reclaims checks left
+// claimed by a worker that died more than staleAfter ago; it returns nil when there is nothing to do.
+// Claiming counts an attempt.
+type SimilarityJobQueue interface {
+	ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.SimilarityCheck, *entities.Document, error)
+	// MarkSubmitted drops the document, the provider keeps its own copy
+	MarkSubmitted(ctx context.Context, checkID string, providerReference string) error
+	// MarkRetry puts the check back in the queue, it is not claimed again before retryAt
+	MarkRetry(ctx context.Context, checkID string, reason string, retryAt time.Time) error
+	MarkFailed(ctx context.Context, checkID string, reason string) error
+}
+
+// SimilarityChecker is the similarity provider. Results arrive asynchronously through callbacks.
+type SimilarityChecker interface {
+	// Submit hands the document to the provider and returns the provider reference of the check
+	Submit(ctx context.Context, check *entities.SimilarityCheck, document *entities.Document) (string, error)
+	// VerifyCallback authenticates a callback body and returns the ID of the callback, empty when the
+	// provider gives its callbacks none
+	VerifyCallback(payload []byte, signature string) (string, error)
+	// ParseCallback decodes the result of a callback body VerifyCallback accepted when it was received
+	ParseCallback(payload []byte) (*entities.SimilarityResult, error)
+}
@@ -0,0 +1,30 @@
+package get_assignment_standards_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetAssignmentStandardsCommand struct {
+	TenantID     string `validate:"required"`
+	ClassID      string `validate:"required,uuid"`
+	AssignmentID string `validate:"required,uuid"`
+}
+
+func NewGetAssignmentStandardsCommand(tenantID string, classID string, assignmentID string) (*GetAssignmentStandardsCommand, error) {
+	command := &GetAssignmentStandardsCommand{
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,39 @@
+package get_assignment_standards_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+)
+
+type GetAssignmentStandardsUseCase struct {
+	standardRepo ports.StandardRepository
+	tagRepo      ports.AssignmentStandardRepository
+}
+
+func NewGetAssignmentStandardsUseCase(standardRepo ports.StandardRepository, tagRepo ports.AssignmentStandardRepository) *GetAssignmentStandardsUseCase {
+	return &GetAssignmentStandardsUseCase{
+		standardRepo: standardRepo,
+		tagRepo:      tagRepo,
+	}
+}
+
+// Execute returns the standards the assignment is tagged with, none when it was never tagged
+func (uc *GetAssignmentStandardsUseCase) Execute(ctx context.Context, cmd *GetAssignmentStandardsCommand) ([]*entities.Standard, error) {
+	standardIDs, err := uc.tagRepo.Find(ctx, cmd.TenantID, cmd.ClassID, cmd.AssignmentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if len(standardIDs) == 0 {
+		return []*entities.Standard{}, nil
+	}
+
+	standards, err := uc.standardRepo.FindByIDs(ctx, cmd.TenantID, standardIDs)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return standards, nil
+}
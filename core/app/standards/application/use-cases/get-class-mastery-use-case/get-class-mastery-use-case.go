@@ -0,0 +1,48 @@
+package get_class_mastery_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+)
+
+type GetClassMasteryUseCase struct {
+	standardRepo ports.StandardRepository
+	policyRepo   ports.MasteryPolicyRepository
+	source       ports.EvidenceSource
+}
+
+func NewGetClassMasteryUseCase(standardRepo ports.StandardRepository, policyRepo ports.MasteryPolicyRepository,
+	source ports.EvidenceSource) *GetClassMasteryUseCase {
+	return &GetClassMasteryUseCase{
+		standardRepo: standardRepo,
+		policyRepo:   policyRepo,
+		source:       source,
+	}
+}
+
+// Execute assesses every student of the class on the standards of its tagged assignments, from the
+// grades released in the class only
+func (uc *GetClassMasteryUseCase) Execute(ctx context.Context, cmd *GetClassMasteryCommand) (*entities.MasteryReport, error) {
+	policy, err := uc.policyRepo.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if policy == nil {
+		policy = entities.DefaultMasteryPolicy(cmd.TenantID)
+	}
+
+	evidence, err := uc.source.ClassEvidence(ctx, cmd.TenantID, cmd.ClassID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	standards, err := uc.standardRepo.FindByIDs(ctx, cmd.TenantID, entities.StandardIDsOf(evidence))
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return entities.NewMasteryReport(policy, standards, evidence), nil
+}
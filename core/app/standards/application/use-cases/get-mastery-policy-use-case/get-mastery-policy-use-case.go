@@ -0,0 +1,33 @@
+package get_mastery_policy_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+)
+
+type GetMasteryPolicyUseCase struct {
+	policyRepo ports.MasteryPolicyRepository
+}
+
+func NewGetMasteryPolicyUseCase(policyRepo ports.MasteryPolicyRepository) *GetMasteryPolicyUseCase {
+	return &GetMasteryPolicyUseCase{
+		policyRepo: policyRepo,
+	}
+}
+
+// Execute returns the policy of the tenant, the default one when it never set its own
+func (uc *GetMasteryPolicyUseCase) Execute(ctx context.Context, cmd *GetMasteryPolicyCommand) (*entities.MasteryPolicy, error) {
+	policy, err := uc.policyRepo.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if policy == nil {
+		return entities.DefaultMasteryPolicy(cmd.TenantID), nil
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,48 @@
+package get_student_mastery_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+)
+
+type GetStudentMasteryUseCase struct {
+	standardRepo ports.StandardRepository
+	policyRepo   ports.MasteryPolicyRepository
+	source       ports.EvidenceSource
+}
+
+func NewGetStudentMasteryUseCase(standardRepo ports.StandardRepository, policyRepo ports.MasteryPolicyRepository,
+	source ports.EvidenceSource) *GetStudentMasteryUseCase {
+	return &GetStudentMasteryUseCase{
+		standardRepo: standardRepo,
+		policyRepo:   policyRepo,
+		source:       source,
+	}
+}
+
+// Execute assesses the student on every standard of the assignments they have released grades in,
+// across their classes
+func (uc *GetStudentMasteryUseCase) Execute(ctx context.Context, cmd *GetStudentMasteryCommand) (*entities.MasteryReport, error) {
+	policy, err := uc.policyRepo.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if policy == nil {
+		policy = entities.DefaultMasteryPolicy(cmd.TenantID)
+	}
+
+	evidence, err := uc.source.StudentEvidence(ctx, cmd.TenantID, cmd.StudentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	standards, err := uc.standardRepo.FindByIDs(ctx, cmd.TenantID, entities.StandardIDsOf(evidence))
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return entities.NewMasteryReport(policy, standards, evidence), nil
+}
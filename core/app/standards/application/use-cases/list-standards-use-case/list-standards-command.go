@@ -0,0 +1,29 @@
+package list_standards_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListStandardsCommand struct {
+	TenantID string `validate:"required"`
+	// Framework limits the list to one framework, empty lists them all
+	Framework string `validate:"max=100"`
+}
+
+func NewListStandardsCommand(tenantID string, framework string) (*ListStandardsCommand, error) {
+	command := &ListStandardsCommand{
+		TenantID:  tenantID,
+		Framework: framework,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,28 @@
+package list_standards_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+)
+
+type ListStandardsUseCase struct {
+	standardRepo ports.StandardRepository
+}
+
+func NewListStandardsUseCase(standardRepo ports.StandardRepository) *ListStandardsUseCase {
+	return &ListStandardsUseCase{
+		standardRepo: standardRepo,
+	}
+}
+
+func (uc *ListStandardsUseCase) Execute(ctx context.Context, cmd *ListStandardsCommand) ([]*entities.Standard, error) {
+	standards, err := uc.standardRepo.List(ctx, cmd.TenantID, cmd.Framework)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return standards, nil
+}
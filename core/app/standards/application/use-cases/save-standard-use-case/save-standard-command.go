@@ -0,0 +1,39 @@
+package save_standard_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SaveStandardCommand struct {
+	TenantID    string `validate:"required"`
+	Framework   string `validate:"required,max=100"`
+	Code        string `validate:"required,max=100"`
+	ParentCode  string `validate:"max=100"`
+	Subject     string `validate:"max=100"`
+	GradeLevel  string `validate:"max=20"`
+	Description string `validate:"required,max=2000"`
+}
+
+func NewSaveStandardCommand(tenantID string, framework string, code string, parentCode string, subject string, gradeLevel string,
+	description string) (*SaveStandardCommand, error) {
+	command := &SaveStandardCommand{
+		TenantID:    tenantID,
+		Framework:   framework,
+		Code:        code,
+		ParentCode:  parentCode,
+		Subject:     subject,
+		GradeLevel:  gradeLevel,
+		Description: description,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,40 @@
+package save_standard_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+type SaveStandardUseCase struct {
+	standardRepo ports.StandardRepository
+}
+
+func NewSaveStandardUseCase(standardRepo ports.StandardRepository) *SaveStandardUseCase {
+	return &SaveStandardUseCase{
+		standardRepo: standardRepo,
+	}
+}
+
+// Execute adds the standard, or updates the one of the framework with the same code so imports of a
+// new revision of the framework keep the tags of its assignments
+func (uc *SaveStandardUseCase) Execute(ctx context.Context, cmd *SaveStandardCommand) (*entities.Standard, error) {
+	now := time.Now().UTC()
+	standard, err := entities.NewStandard(uuid.New().String(), cmd.TenantID, cmd.Framework, cmd.Code, cmd.ParentCode, cmd.Subject,
+		cmd.GradeLevel, cmd.Description, now, now)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	saved, err := uc.standardRepo.Save(ctx, standard)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return saved, nil
+}
@@ -0,0 +1,38 @@
+package set_mastery_policy_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SetMasteryPolicyCommand struct {
+	TenantID      string                  `validate:"required"`
+	Method        entities.MasteryMethod  `validate:"required"`
+	DecayWeight   float64                 `validate:"gte=0,lt=1"`
+	Levels        []entities.MasteryLevel `validate:"required"`
+	MasteredLevel string                  `validate:"required"`
+	UpdatedBy     string                  `validate:"required"`
+}
+
+func NewSetMasteryPolicyCommand(tenantID string, method entities.MasteryMethod, decayWeight float64, levels []entities.MasteryLevel,
+	masteredLevel string, updatedBy string) (*SetMasteryPolicyCommand, error) {
+	command := &SetMasteryPolicyCommand{
+		TenantID:      tenantID,
+		Method:        method,
+		DecayWeight:   decayWeight,
+		Levels:        levels,
+		MasteredLevel: masteredLevel,
+		UpdatedBy:     updatedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
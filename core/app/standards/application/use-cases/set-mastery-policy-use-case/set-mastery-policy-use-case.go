@@ -0,0 +1,41 @@
+package set_mastery_policy_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+)
+
+type SetMasteryPolicyUseCase struct {
+	policyRepo ports.MasteryPolicyRepository
+}
+
+func NewSetMasteryPolicyUseCase(policyRepo ports.MasteryPolicyRepository) *SetMasteryPolicyUseCase {
+	return &SetMasteryPolicyUseCase{
+		policyRepo: policyRepo,
+	}
+}
+
+// Execute replaces the policy of the tenant. Mastery is computed when it is read, so every report
+// follows the new policy from then on. The decay weight only matters to the decaying average, it
+// keeps the default otherwise.
+func (uc *SetMasteryPolicyUseCase) Execute(ctx context.Context, cmd *SetMasteryPolicyCommand) (*entities.MasteryPolicy, error) {
+	decayWeight := cmd.DecayWeight
+	if decayWeight == 0 {
+		decayWeight = entities.DefaultMasteryPolicy(cmd.TenantID).DecayWeight
+	}
+
+	policy, err := entities.NewMasteryPolicy(cmd.TenantID, cmd.Method, decayWeight, cmd.Levels, cmd.MasteredLevel, cmd.UpdatedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.policyRepo.Save(ctx, policy); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return policy, nil
+}
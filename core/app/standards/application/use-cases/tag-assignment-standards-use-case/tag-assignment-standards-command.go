@@ -0,0 +1,35 @@
+package tag_assignment_standards_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type TagAssignmentStandardsCommand struct {
+	TenantID     string   `validate:"required"`
+	ClassID      string   `validate:"required,uuid"`
+	AssignmentID string   `validate:"required,uuid"`
+	StandardIDs  []string `validate:"max=20,unique,dive,uuid"`
+	TaggedBy     string   `validate:"required"`
+}
+
+func NewTagAssignmentStandardsCommand(tenantID string, classID string, assignmentID string, standardIDs []string,
+	taggedBy string) (*TagAssignmentStandardsCommand, error) {
+	command := &TagAssignmentStandardsCommand{
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+		StandardIDs:  standardIDs,
+		TaggedBy:     taggedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
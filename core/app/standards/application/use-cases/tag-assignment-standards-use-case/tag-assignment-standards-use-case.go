@@ -0,0 +1,61 @@
+package tag_assignment_standards_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	standardsErrors "github.com/nahualventure/class-backend/core/app/standards/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+)
+
+type TagAssignmentStandardsUseCase struct {
+	standardRepo ports.StandardRepository
+	tagRepo      ports.AssignmentStandardRepository
+}
+
+func NewTagAssignmentStandardsUseCase(standardRepo ports.StandardRepository, tagRepo ports.AssignmentStandardRepository) *TagAssignmentStandardsUseCase {
+	return &TagAssignmentStandardsUseCase{
+		standardRepo: standardRepo,
+		tagRepo:      tagRepo,
+	}
+}
+
+// Execute replaces the standards of the assignment and returns them. Grades already released count
+// toward the new standards right away, mastery is computed when it is read.
+func (uc *TagAssignmentStandardsUseCase) Execute(ctx context.Context, cmd *TagAssignmentStandardsCommand) ([]*entities.Standard, error) {
+	tags, err := entities.NewAssignmentStandards(cmd.TenantID, cmd.ClassID, cmd.AssignmentID, cmd.StandardIDs, cmd.TaggedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	standards, err := uc.standardRepo.FindByIDs(ctx, cmd.TenantID, tags.StandardIDs)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if missing := missingIDs(tags.StandardIDs, standards); len(missing) > 0 {
+		return nil, standardsErrors.NewStandardNotFoundError(missing)
+	}
+
+	if err := uc.tagRepo.Replace(ctx, tags); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return standards, nil
+}
+
+func missingIDs(standardIDs []string, standards []*entities.Standard) []string {
+	found := make(map[string]bool, len(standards))
+	for _, standard := range standards {
+		found[standard.ID] = true
+	}
+
+	var missing []string
+	for _, standardID := range standardIDs {
+		if !found[standardID] {
+			missing = append(missing, standardID)
+		}
+	}
+	return missing
+}
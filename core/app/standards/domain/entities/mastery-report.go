@@ -0,0 +1,75 @@
+package entities
+
+import "sort"
+
+// StandardReport is the mastery of a standard across the students assessed on it
+type StandardReport struct {
+	Standard      *Standard
+	StudentCount  int
+	MasteredCount int
+	// LevelCounts counts the students at each level of the policy, in the order of the levels
+	LevelCounts []int
+	Masteries   []Mastery
+}
+
+// MasteryReport assesses a class or a student on every standard they have evidence of, ordered by
+// framework then code
+type MasteryReport struct {
+	Policy    *MasteryPolicy
+	Standards []StandardReport
+}
+
+// NewMasteryReport assesses the evidence with the policy. Evidence of standards missing from
+// standards is left out.
+func NewMasteryReport(policy *MasteryPolicy, standards []*Standard, evidence []MasteryEvidence) *MasteryReport {
+	levelIndex := make(map[string]int, len(policy.Levels))
+	for i, level := range policy.Levels {
+		levelIndex[level.Name] = i
+	}
+
+	byID := make(map[string]*StandardReport, len(standards))
+	for _, standard := range standards {
+		byID[standard.ID] = &StandardReport{Standard: standard, LevelCounts: make([]int, len(policy.Levels))}
+	}
+	for _, mastery := range policy.Assess(evidence) {
+		report, ok := byID[mastery.StandardID]
+		if !ok {
+			continue
+		}
+		report.StudentCount++
+		report.LevelCounts[levelIndex[mastery.Level]]++
+		if mastery.Mastered {
+			report.MasteredCount++
+		}
+		report.Masteries = append(report.Masteries, mastery)
+	}
+
+	report := &MasteryReport{Policy: policy, Standards: []StandardReport{}}
+	for _, standard := range byID {
+		if standard.StudentCount > 0 {
+			report.Standards = append(report.Standards, *standard)
+		}
+	}
+	sort.Slice(report.Standards, func(i, j int) bool {
+		a, b := report.Standards[i].Standard, report.Standards[j].Standard
+		if a.Framework != b.Framework {
+			return a.Framework < b.Framework
+		}
+		return a.Code < b.Code
+	})
+
+	return report
+}
+
+// StandardIDsOf returns the standards the evidence is about, each once
+func StandardIDsOf(evidence []MasteryEvidence) []string {
+	seen := make(map[string]bool)
+	standardIDs := []string{}
+	for _, piece := range evidence {
+		if !seen[piece.StandardID] {
+			seen[piece.StandardID] = true
+			standardIDs = append(standardIDs, piece.StandardID)
+		}
+	}
+	return standardIDs
+}
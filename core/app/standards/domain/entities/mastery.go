@@ -0,0 +1,220 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+type MasteryMethod string
+
+const (
+	// MasteryMean weighs every piece of evidence the same
+	MasteryMean MasteryMethod = "mean"
+	// MasteryMostRecent takes the latest evidence, what the student can do now
+	MasteryMostRecent MasteryMethod = "most_recent"
+	MasteryHighest    MasteryMethod = "highest"
+	// MasteryDecayingAverage weighs each piece of evidence DecayWeight against the average of the
+	// earlier ones, so recent work counts the most without a single grade deciding
+	MasteryDecayingAverage MasteryMethod = "decaying_average"
+)
+
+// MasteryLevel is reached with a score of MinScore or more, up to the MinScore of the next level
+type MasteryLevel struct {
+	Name     string  `validate:"required,max=50"`
+	MinScore float64 `validate:"min=0,max=100"`
+}
+
+// MasteryPolicy is how a tenant turns the grades of assignments tagged with a standard into a level
+// of mastery of the standard. Scores are the final scores of the grades, from 0 to 100.
+type MasteryPolicy struct {
+	TenantID    string        `validate:"required"`
+	Method      MasteryMethod `validate:"required,oneof=mean most_recent highest decaying_average"`
+	DecayWeight float64       `validate:"gt=0,lt=1"`
+	// Levels go from the lowest to the highest, the first one starts at 0
+	Levels []MasteryLevel `validate:"min=2,max=6,dive"`
+	// MasteredLevel is the lowest level counted as mastering the standard in reports
+	MasteredLevel string `validate:"required"`
+	// UpdatedBy is empty for the default, which was never saved
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// DefaultMasteryPolicy returns the policy of a tenant that did not set its own: the four levels most
+// districts report and a decaying average weighing the latest evidence 65%
+func DefaultMasteryPolicy(tenantID string) *MasteryPolicy {
+	return &MasteryPolicy{
+		TenantID:    tenantID,
+		Method:      MasteryDecayingAverage,
+		DecayWeight: 0.65,
+		Levels: []MasteryLevel{
+			{Name: "beginning", MinScore: 0},
+			{Name: "developing", MinScore: 60},
+			{Name: "proficient", MinScore: 80},
+			{Name: "advanced", MinScore: 90},
+		},
+		MasteredLevel: "proficient",
+	}
+}
+
+func NewMasteryPolicy(tenantID string, method MasteryMethod, decayWeight float64, levels []MasteryLevel, masteredLevel string,
+	updatedBy string, updatedAt time.Time) (*MasteryPolicy, error) {
+	policy := &MasteryPolicy{
+		TenantID:      tenantID,
+		Method:        method,
+		DecayWeight:   decayWeight,
+		Levels:        levels,
+		MasteredLevel: masteredLevel,
+		UpdatedBy:     updatedBy,
+		UpdatedAt:     updatedAt,
+	}
+
+	errorMap := make(map[string]any)
+	err := validate.Struct(policy)
+	if err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+	} else {
+		names := make(map[string]bool, len(levels))
+		for i, level := range levels {
+			switch {
+			case i == 0 && level.MinScore != 0:
+				errorMap["Levels"] = "The first level must start at 0"
+			case i > 0 && level.MinScore <= levels[i-1].MinScore:
+				errorMap["Levels"] = fmt.Sprintf("Level %q must start above level %q", level.Name, levels[i-1].Name)
+			case names[level.Name]:
+				errorMap["Levels"] = fmt.Sprintf("Level %q is repeated", level.Name)
+			}
+			names[level.Name] = true
+		}
+		if !names[masteredLevel] {
+			errorMap["MasteredLevel"] = fmt.Sprintf("%q is not one of the levels", masteredLevel)
+		}
+	}
+
+	if len(errorMap) > 0 {
+		return nil, appErrors.NewDomainEntityValidationError("MasteryPolicy domain model instance not valid", errorMap, err)
+	}
+
+	return policy, nil
+}
+
+// IsDefault reports whether the tenant never set its policy
+func (p *MasteryPolicy) IsDefault() bool {
+	return p.UpdatedBy == ""
+}
+
+// MasteryEvidence is the released grade of a student on an assignment tagged with the standard
+type MasteryEvidence struct {
+	StandardID   string
+	StudentID    string
+	ClassID      string
+	AssignmentID string
+	// Score is the final score, after the late penalty
+	Score      float64
+	ReleasedAt time.Time
+}
+
+// Mastery is the level a student reached on a standard
+type Mastery struct {
+	StudentID     string
+	StandardID    string
+	Score         float64
+	Level         string
+	Mastered      bool
+	EvidenceCount int
+	LastEvidence  time.Time
+}
+
+// Assess computes the mastery of every student and standard the evidence is about, ordered by
+// student then standard
+func (p *MasteryPolicy) Assess(evidence []MasteryEvidence) []Mastery {
+	type key struct{ studentID, standardID string }
+	grouped := make(map[key][]MasteryEvidence)
+	for _, piece := range evidence {
+		k := key{piece.StudentID, piece.StandardID}
+		grouped[k] = append(grouped[k], piece)
+	}
+
+	masteries := make([]Mastery, 0, len(grouped))
+	for k, pieces := range grouped {
+		sort.SliceStable(pieces, func(i, j int) bool { return pieces[i].ReleasedAt.Before(pieces[j].ReleasedAt) })
+		score := p.score(pieces)
+		level := p.LevelOf(score)
+		masteries = append(masteries, Mastery{
+			StudentID:     k.studentID,
+			StandardID:    k.standardID,
+			Score:         score,
+			Level:         level,
+			Mastered:      p.reaches(level, p.MasteredLevel),
+			EvidenceCount: len(pieces),
+			LastEvidence:  pieces[len(pieces)-1].ReleasedAt,
+		})
+	}
+	sort.Slice(masteries, func(i, j int) bool {
+		if masteries[i].StudentID != masteries[j].StudentID {
+			return masteries[i].StudentID < masteries[j].StudentID
+		}
+		return masteries[i].StandardID < masteries[j].StandardID
+	})
+
+	return masteries
+}
+
+// score combines the evidence, oldest first, with the method of the policy
+func (p *MasteryPolicy) score(pieces []MasteryEvidence) float64 {
+	var score float64
+	switch p.Method {
+	case MasteryMostRecent:
+		score = pieces[len(pieces)-1].Score
+	case MasteryHighest:
+		for _, piece := range pieces {
+			score = math.Max(score, piece.Score)
+		}
+	case MasteryDecayingAverage:
+		score = pieces[0].Score
+		for _, piece := range pieces[1:] {
+			score = score*(1-p.DecayWeight) + piece.Score*p.DecayWeight
+		}
+	default:
+		for _, piece := range pieces {
+			score += piece.Score
+		}
+		score /= float64(len(pieces))
+	}
+	return math.Round(score*100) / 100
+}
+
+// LevelOf returns the highest level the score reaches
+func (p *MasteryPolicy) LevelOf(score float64) string {
+	level := p.Levels[0].Name
+	for _, candidate := range p.Levels {
+		if score >= candidate.MinScore {
+			level = candidate.Name
+		}
+	}
+	return level
+}
+
+// reaches tells whether level is target or above it
+func (p *MasteryPolicy) reaches(level string, target string) bool {
+	reached := false
+	for _, candidate := range p.Levels {
+		if candidate.Name == target {
+			reached = true
+		}
+		if candidate.Name == level {
+			return reached
+		}
+	}
+	return false
+}
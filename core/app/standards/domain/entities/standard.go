@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// Standard is a learning standard of a framework, such as a state standard. Codes identify standards
+// within their framework, ParentCode places a standard under a broader one of the same framework.
+type Standard struct {
+	ID          string `validate:"required,uuid"`
+	TenantID    string `validate:"required"`
+	Framework   string `validate:"required,max=100"`
+	Code        string `validate:"required,max=100"`
+	ParentCode  string `validate:"max=100,nefield=Code"`
+	Subject     string `validate:"max=100"`
+	GradeLevel  string `validate:"max=20"`
+	Description string `validate:"required,max=2000"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewStandard(id string, tenantID string, framework string, code string, parentCode string, subject string, gradeLevel string,
+	description string, createdAt time.Time, updatedAt time.Time) (*Standard, error) {
+	standard := &Standard{
+		ID:          id,
+		TenantID:    tenantID,
+		Framework:   framework,
+		Code:        code,
+		ParentCode:  parentCode,
+		Subject:     subject,
+		GradeLevel:  gradeLevel,
+		Description: description,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+	}
+
+	if err := validate.Struct(standard); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("Standard domain model instance not valid", errorMap, err)
+	}
+
+	return standard, nil
+}
+
+// AssignmentStandards are the standards an assignment assesses, its released grades are evidence of
+// the mastery of each of them
+type AssignmentStandards struct {
+	TenantID     string   `validate:"required"`
+	ClassID      string   `validate:"required,uuid"`
+	AssignmentID string   `validate:"required,uuid"`
+	StandardIDs  []string `validate:"max=20,unique,dive,uuid"`
+	TaggedBy     string
+	TaggedAt     time.Time
+}
+
+func NewAssignmentStandards(tenantID string, classID string, assignmentID string, standardIDs []string, taggedBy string,
+	taggedAt time.Time) (*AssignmentStandards, error) {
+	if standardIDs == nil {
+		standardIDs = []string{}
+	}
+	tags := &AssignmentStandards{
+		TenantID:     tenantID,
+		ClassID:      classID,
+		AssignmentID: assignmentID,
+		StandardIDs:  standardIDs,
+		TaggedBy:     taggedBy,
+		TaggedAt:     taggedAt,
+	}
+
+	if err := validate.Struct(tags); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("AssignmentStandards domain model instance not valid", errorMap, err)
+	}
+
+	return tags, nil
+}
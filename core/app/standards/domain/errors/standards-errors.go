@@ -0,0 +1,29 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// StandardNotFoundError is returned when an assignment is tagged with standards the tenant does
+	// not have
+	StandardNotFoundError errors2.ErrorCode = "STANDARD_NOT_FOUND"
+)
+
+func NewStandardNotFoundError(standardIDs []string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    StandardNotFoundError.String(),
+			Message: "Standard not found",
+			Context: map[string]any{
+				"standard_ids": standardIDs,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(StandardNotFoundError.String()),
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+)
+
+type StandardRepository interface {
+	// Save inserts the standard, or updates the standard of the tenant with the same framework and
+	// code, and returns the stored standard
+	Save(ctx context.Context, standard *entities.Standard) (*entities.Standard, error)
+	// FindByIDs returns the standards of the tenant among standardIDs, unknown IDs are left out
+	FindByIDs(ctx context.Context, tenantID string, standardIDs []string) ([]*entities.Standard, error)
+	// List returns the standards of the tenant ordered by framework and code, of every framework when
+	// framework is empty
+	List(ctx context.Context, tenantID string, framework string) ([]*entities.Standard, error)
+}
+
+type AssignmentStandardRepository interface {
+	// Replace sets the standards of the assignment, an empty list untags it
+	Replace(ctx context.Context, tags *entities.AssignmentStandards) error
+	// Find returns the standards of the assignment, none when it was never tagged
+	Find(ctx context.Context, tenantID string, classID string, assignmentID string) ([]string, error)
+}
+
+type MasteryPolicyRepository interface {
+	// Find returns nil when the tenant never set its policy
+	Find(ctx context.Context, tenantID string) (*entities.MasteryPolicy, error)
+	Save(ctx context.Context, policy *entities.MasteryPolicy) error
+}
+
+// EvidenceSource reads the released grades of tagged assignments, one piece of evidence per grade
+// and standard of its assignment. Dropped grades are left out.
+type EvidenceSource interface {
+	StudentEvidence(ctx context.Context, tenantID string, studentID string) ([]entities.MasteryEvidence, error)
+	ClassEvidence(ctx context.Context, tenantID string, classID string) ([]entities.MasteryEvidence, error)
+}
@@ -0,0 +1,34 @@
+package get_status_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/status/domain/ports"
+)
+
+// maxOpenNotes bounds the incidents the status page shows
+const maxOpenNotes = 20
+
+type GetStatusUseCase struct {
+	probe ports.HealthProbe
+	notes ports.StatusNoteRepository
+}
+
+func NewGetStatusUseCase(probe ports.HealthProbe, notes ports.StatusNoteRepository) *GetStatusUseCase {
+	return &GetStatusUseCase{
+		probe: probe,
+		notes: notes,
+	}
+}
+
+// Execute measures the components and raises them to the levels of the open incident notes
+func (uc *GetStatusUseCase) Execute(ctx context.Context) (*entities.Status, error) {
+	notes, err := uc.notes.List(ctx, false, maxOpenNotes)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return entities.NewStatus(uc.probe.Measure(ctx), notes, time.Now().UTC()), nil
+}
@@ -0,0 +1,31 @@
+package list_status_notes_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/status/domain/ports"
+)
+
+// maxNotes bounds the notes listed, the latest ones are the ones still edited
+const maxNotes = 100
+
+type ListStatusNotesUseCase struct {
+	notes ports.StatusNoteRepository
+}
+
+func NewListStatusNotesUseCase(notes ports.StatusNoteRepository) *ListStatusNotesUseCase {
+	return &ListStatusNotesUseCase{
+		notes: notes,
+	}
+}
+
+// Execute lists the latest notes, resolved ones included
+func (uc *ListStatusNotesUseCase) Execute(ctx context.Context) ([]*entities.StatusNote, error) {
+	notes, err := uc.notes.List(ctx, true, maxNotes)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return notes, nil
+}
@@ -0,0 +1,29 @@
+package post_status_note_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/status/domain/ports"
+)
+
+type PostStatusNoteUseCase struct {
+	notes ports.StatusNoteRepository
+}
+
+func NewPostStatusNoteUseCase(notes ports.StatusNoteRepository) *PostStatusNoteUseCase {
+	return &PostStatusNoteUseCase{
+		notes: notes,
+	}
+}
+
+// Execute opens an incident note, the status page shows it until it is resolved
+func (uc *PostStatusNoteUseCase) Execute(ctx context.Context, cmd *PostStatusNoteCommand) (*entities.StatusNote, error) {
+	note := entities.NewStatusNote(cmd.Title, cmd.Message, cmd.Components, cmd.Level, time.Now().UTC())
+	if err := uc.notes.Save(ctx, note); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return note, nil
+}
@@ -0,0 +1,26 @@
+package resolve_status_note_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ResolveStatusNoteCommand struct {
+	NoteID string `validate:"required,uuid"`
+}
+
+func NewResolveStatusNoteCommand(noteID string) (*ResolveStatusNoteCommand, error) {
+	command := &ResolveStatusNoteCommand{
+		NoteID: noteID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
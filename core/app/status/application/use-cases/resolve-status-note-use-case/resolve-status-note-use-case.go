@@ -0,0 +1,42 @@
+package resolve_status_note_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+	statusErrors "github.com/nahualventure/class-backend/core/app/status/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/status/domain/ports"
+)
+
+type ResolveStatusNoteUseCase struct {
+	notes ports.StatusNoteRepository
+}
+
+func NewResolveStatusNoteUseCase(notes ports.StatusNoteRepository) *ResolveStatusNoteUseCase {
+	return &ResolveStatusNoteUseCase{
+		notes: notes,
+	}
+}
+
+// Execute ends the incident of the note, it leaves the status page and its components go back to
+// the level measured
+func (uc *ResolveStatusNoteUseCase) Execute(ctx context.Context, cmd *ResolveStatusNoteCommand) (*entities.StatusNote, error) {
+	note, err := uc.notes.FindByID(ctx, cmd.NoteID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if note == nil {
+		return nil, statusErrors.NewStatusNoteNotFoundError(cmd.NoteID)
+	}
+	if note.IsResolved() {
+		return nil, statusErrors.NewStatusNoteResolvedError(note.ID, *note.ResolvedAt)
+	}
+
+	note.Resolve(time.Now().UTC())
+	if err := uc.notes.Save(ctx, note); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return note, nil
+}
@@ -0,0 +1,37 @@
+package update_status_note_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type UpdateStatusNoteCommand struct {
+	NoteID     string               `validate:"required,uuid"`
+	Title      string               `validate:"required,max=200"`
+	Message    string               `validate:"required,max=5000"`
+	Components []entities.Component `validate:"required,min=1,unique,dive,oneof=api database jobs integrations"`
+	Level      entities.Level       `validate:"required,oneof=operational degraded partial_outage major_outage"`
+}
+
+func NewUpdateStatusNoteCommand(noteID string, title string, message string, components []string, level string) (*UpdateStatusNoteCommand, error) {
+	command := &UpdateStatusNoteCommand{
+		NoteID:  noteID,
+		Title:   title,
+		Message: message,
+		Level:   entities.Level(level),
+	}
+	for _, component := range components {
+		command.Components = append(command.Components, entities.Component(component))
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,41 @@
+package update_status_note_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+	statusErrors "github.com/nahualventure/class-backend/core/app/status/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/status/domain/ports"
+)
+
+type UpdateStatusNoteUseCase struct {
+	notes ports.StatusNoteRepository
+}
+
+func NewUpdateStatusNoteUseCase(notes ports.StatusNoteRepository) *UpdateStatusNoteUseCase {
+	return &UpdateStatusNoteUseCase{
+		notes: notes,
+	}
+}
+
+// Execute replaces what an open note says, notes of resolved incidents are kept as they were
+func (uc *UpdateStatusNoteUseCase) Execute(ctx context.Context, cmd *UpdateStatusNoteCommand) (*entities.StatusNote, error) {
+	note, err := uc.notes.FindByID(ctx, cmd.NoteID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if note == nil {
+		return nil, statusErrors.NewStatusNoteNotFoundError(cmd.NoteID)
+	}
+	if note.IsResolved() {
+		return nil, statusErrors.NewStatusNoteResolvedError(note.ID, *note.ResolvedAt)
+	}
+
+	note.Update(cmd.Title, cmd.Message, cmd.Components, cmd.Level, time.Now().UTC())
+	if err := uc.notes.Save(ctx, note); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return note, nil
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusNote is an incident note of the status page, posted by the on-call engineers while an
+// incident goes on. Open notes put the components they name at least at their level.
+type StatusNote struct {
+	ID         string
+	Title      string
+	Message    string
+	Components []Component
+	Level      Level
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	// ResolvedAt is when the incident ended, the note leaves the status page then
+	ResolvedAt *time.Time
+}
+
+func NewStatusNote(title string, message string, components []Component, level Level, now time.Time) *StatusNote {
+	return &StatusNote{
+		ID:         uuid.NewString(),
+		Title:      title,
+		Message:    message,
+		Components: components,
+		Level:      level,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Update replaces what the note says as the incident goes on, such as a fix being rolled out
+func (n *StatusNote) Update(title string, message string, components []Component, level Level, now time.Time) {
+	n.Title = title
+	n.Message = message
+	n.Components = components
+	n.Level = level
+	n.UpdatedAt = now
+}
+
+func (n *StatusNote) Resolve(now time.Time) {
+	n.ResolvedAt = &now
+	n.UpdatedAt = now
+}
+
+func (n *StatusNote) IsResolved() bool {
+	return n.ResolvedAt != nil
+}
@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+// Level is how degraded a component is, from operational to a major outage
+type Level string
+
+const (
+	LevelOperational Level = "operational"
+	// LevelDegraded components answer, slower than usual or failing now and then
+	LevelDegraded Level = "degraded"
+	// LevelPartialOutage components fail for part of their features or schools
+	LevelPartialOutage Level = "partial_outage"
+	LevelMajorOutage   Level = "major_outage"
+)
+
+// Levels lists the levels from the best to the worst
+var Levels = []Level{LevelOperational, LevelDegraded, LevelPartialOutage, LevelMajorOutage}
+
+// Worst returns the worst of the levels, operational when there are none
+func Worst(levels ...Level) Level {
+	worst := LevelOperational
+	for _, level := range levels {
+		if slices.Index(Levels, level) > slices.Index(Levels, worst) {
+			worst = level
+		}
+	}
+	return worst
+}
+
+// Component is a part of the platform the status page reports on its own
+type Component string
+
+const (
+	ComponentAPI      Component = "api"
+	ComponentDatabase Component = "database"
+	// ComponentJobs are the background jobs, such as exports, reports and notifications
+	ComponentJobs Component = "jobs"
+	// ComponentIntegrations are the services the platform talks to, such as email and payment providers
+	ComponentIntegrations Component = "integrations"
+)
+
+// Components lists the components in the order the status page shows them
+var Components = []Component{ComponentAPI, ComponentDatabase, ComponentJobs, ComponentIntegrations}
+
+type ComponentStatus struct {
+	Component Component
+	Level     Level
+}
+
+// Status is what the status page shows: the level of every component and the incidents going on
+type Status struct {
+	// Level is the worst level of the components
+	Level      Level
+	Components []ComponentStatus
+	// Incidents are the notes not resolved yet, the latest first
+	Incidents []*StatusNote
+	CheckedAt time.Time
+}
+
+// NewStatus puts every component at the worst of the level measured and the levels of the open notes
+// naming it. Components without a measure are operational unless a note says otherwise.
+func NewStatus(measured map[Component]Level, notes []*StatusNote, now time.Time) *Status {
+	status := &Status{
+		Components: make([]ComponentStatus, 0, len(Components)),
+		Incidents:  make([]*StatusNote, 0, len(notes)),
+		CheckedAt:  now,
+	}
+	for _, note := range notes {
+		if !note.IsResolved() {
+			status.Incidents = append(status.Incidents, note)
+		}
+	}
+
+	levels := make([]Level, 0, len(Components))
+	for _, component := range Components {
+		level, ok := measured[component]
+		if !ok {
+			level = LevelOperational
+		}
+		for _, note := range status.Incidents {
+			if slices.Contains(note.Components, component) {
+				level = Worst(level, note.Level)
+			}
+		}
+		status.Components = append(status.Components, ComponentStatus{Component: component, Level: level})
+		levels = append(levels, level)
+	}
+	status.Level = Worst(levels...)
+	return status
+}
@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	StatusNoteNotFoundError errors2.ErrorCode = "STATUS_NOTE_NOT_FOUND"
+	// StatusNoteResolvedError refuses to change notes of incidents that ended, post a new note instead
+	StatusNoteResolvedError errors2.ErrorCode = "STATUS_NOTE_RESOLVED"
+)
+
+func NewStatusNoteNotFoundError(noteID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    StatusNoteNotFoundError.String(),
+			Message: "Status note not found",
+			Context: map[string]any{
+				"note_id": noteID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(StatusNoteNotFoundError.String()),
+		},
+	}
+}
+
+func NewStatusNoteResolvedError(noteID string, resolvedAt time.Time) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    StatusNoteResolvedError.String(),
+			Message: "The incident of the note was resolved, post a new note instead",
+			Context: map[string]any{
+				"note_id":     noteID,
+				"resolved_at": resolvedAt,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(StatusNoteResolvedError.String()),
+		},
+	}
+}
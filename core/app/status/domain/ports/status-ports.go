@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+)
+
+// StatusNoteRepository keeps the incident notes of the status page, they are not tenant scoped
+type StatusNoteRepository interface {
+	// FindByID returns the note, nil when there is no such note
+	FindByID(ctx context.Context, noteID string) (*entities.StatusNote, error)
+	// List returns up to limit notes, the latest created first. Resolved notes are left out unless
+	// includeResolved is set.
+	List(ctx context.Context, includeResolved bool, limit int) ([]*entities.StatusNote, error)
+	// Save creates or replaces the note
+	Save(ctx context.Context, note *entities.StatusNote) error
+}
+
+// HealthProbe measures the level of the components from the checks and metrics of the platform.
+// Components it has no measure of are left out.
+type HealthProbe interface {
+	Measure(ctx context.Context) map[entities.Component]entities.Level
+}
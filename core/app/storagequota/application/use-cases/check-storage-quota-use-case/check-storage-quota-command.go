@@ -0,0 +1,31 @@
+package check_storage_quota_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// CheckStorageQuotaCommand checks whether the user can store Requested more bytes in the tenant
+type CheckStorageQuotaCommand struct {
+	TenantID  string `validate:"required"`
+	UserID    string `validate:"required"`
+	Requested int64  `validate:"gte=0"`
+}
+
+func NewCheckStorageQuotaCommand(tenantID string, userID string, requested int64) (*CheckStorageQuotaCommand, error) {
+	command := &CheckStorageQuotaCommand{
+		TenantID:  tenantID,
+		UserID:    userID,
+		Requested: requested,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,51 @@
+package check_storage_quota_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/entities"
+	storageQuotaErrors "github.com/nahualventure/class-backend/core/app/storagequota/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/ports"
+)
+
+type CheckStorageQuotaUseCase struct {
+	usage  ports.StorageUsageRepository
+	quotas ports.StorageQuotaRepository
+}
+
+func NewCheckStorageQuotaUseCase(usage ports.StorageUsageRepository, quotas ports.StorageQuotaRepository) *CheckStorageQuotaUseCase {
+	return &CheckStorageQuotaUseCase{
+		usage:  usage,
+		quotas: quotas,
+	}
+}
+
+// Execute fails with STORAGE_QUOTA_EXCEEDED when the upload would take the user or the tenant past
+// their quota. Tenants without a quota are not checked.
+func (uc *CheckStorageQuotaUseCase) Execute(ctx context.Context, cmd *CheckStorageQuotaCommand) error {
+	quota, err := uc.quotas.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if quota == nil {
+		return nil
+	}
+
+	tenant, user, err := uc.usage.Usage(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+
+	scope, exceeded := quota.Exceeded(tenant, user, cmd.Requested)
+	if !exceeded {
+		return nil
+	}
+
+	limit, _ := quota.Limit(scope)
+	usage := tenant.Bytes
+	if scope == entities.QuotaScopeUser {
+		usage = user.Bytes
+	}
+	return storageQuotaErrors.NewStorageQuotaExceededError(string(scope), limit, usage, cmd.Requested)
+}
@@ -0,0 +1,28 @@
+package get_storage_usage_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetStorageUsageCommand struct {
+	TenantID string `validate:"required"`
+	UserID   string `validate:"required"`
+}
+
+func NewGetStorageUsageCommand(tenantID string, userID string) (*GetStorageUsageCommand, error) {
+	command := &GetStorageUsageCommand{
+		TenantID: tenantID,
+		UserID:   userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
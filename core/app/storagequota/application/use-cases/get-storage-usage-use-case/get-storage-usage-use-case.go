@@ -0,0 +1,40 @@
+package get_storage_usage_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/ports"
+)
+
+type GetStorageUsageUseCase struct {
+	usage  ports.StorageUsageRepository
+	quotas ports.StorageQuotaRepository
+}
+
+func NewGetStorageUsageUseCase(usage ports.StorageUsageRepository, quotas ports.StorageQuotaRepository) *GetStorageUsageUseCase {
+	return &GetStorageUsageUseCase{
+		usage:  usage,
+		quotas: quotas,
+	}
+}
+
+// Execute returns the usage of the user with the quota of the tenant, users see how much they can
+// still upload
+func (uc *GetStorageUsageUseCase) Execute(ctx context.Context, cmd *GetStorageUsageCommand) (*entities.StorageUsage, *entities.StorageQuota, error) {
+	quota, err := uc.quotas.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if quota == nil {
+		quota = entities.UnlimitedStorageQuota(cmd.TenantID)
+	}
+
+	_, user, err := uc.usage.Usage(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+
+	return user, quota, nil
+}
@@ -0,0 +1,33 @@
+package list_storage_usage_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListStorageUsageCommand struct {
+	TenantID string `validate:"required"`
+	Page     *pagination.PageRequest
+}
+
+func NewListStorageUsageCommand(tenantID string, pageSize int, cursor string) (*ListStorageUsageCommand, error) {
+	page, err := pagination.NewPageRequest(pageSize, cursor, "", nil, pagination.OrderBy{
+		Field:     "bytes",
+		Direction: pagination.Descending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	command := &ListStorageUsageCommand{TenantID: tenantID, Page: page}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,44 @@
+package list_storage_usage_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/ports"
+)
+
+type ListStorageUsageUseCase struct {
+	usage  ports.StorageUsageRepository
+	quotas ports.StorageQuotaRepository
+}
+
+func NewListStorageUsageUseCase(usage ports.StorageUsageRepository, quotas ports.StorageQuotaRepository) *ListStorageUsageUseCase {
+	return &ListStorageUsageUseCase{
+		usage:  usage,
+		quotas: quotas,
+	}
+}
+
+// Execute returns the usage of the tenant against its quota, with the usage of each of its users
+func (uc *ListStorageUsageUseCase) Execute(ctx context.Context, cmd *ListStorageUsageCommand) (*entities.StorageUsageReport, error) {
+	quota, err := uc.quotas.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if quota == nil {
+		quota = entities.UnlimitedStorageQuota(cmd.TenantID)
+	}
+
+	tenant, _, err := uc.usage.Usage(ctx, cmd.TenantID, "")
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	owners, err := uc.usage.List(ctx, cmd.TenantID, cmd.Page)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return &entities.StorageUsageReport{Tenant: tenant, Quota: quota, Owners: owners}, nil
+}
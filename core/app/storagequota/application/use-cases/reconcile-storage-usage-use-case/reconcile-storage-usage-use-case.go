@@ -0,0 +1,81 @@
+package reconcile_storage_usage_use_case
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/ports"
+)
+
+type ReconcileStorageUsageUseCase struct {
+	usage     ports.StorageUsageRepository
+	inventory ports.StorageInventory
+}
+
+func NewReconcileStorageUsageUseCase(usage ports.StorageUsageRepository, inventory ports.StorageInventory) *ReconcileStorageUsageUseCase {
+	return &ReconcileStorageUsageUseCase{
+		usage:     usage,
+		inventory: inventory,
+	}
+}
+
+// Execute recomputes the usage of every tenant from the records of their files, then compares it
+// with the listing of the file storage and queues a storage.drift_detected event for the tenants
+// whose files differ. It returns how many tenants drifted, a failing tenant does not stop the others
+// and the first failure is returned for logging.
+func (uc *ReconcileStorageUsageUseCase) Execute(ctx context.Context) (int, error) {
+	tenants, err := uc.usage.ListTenants(ctx)
+	if err != nil {
+		return 0, errors.PropagateError(err)
+	}
+
+	drifted := 0
+	var failed error
+	for _, tenantID := range tenants {
+		drift, err := uc.reconcile(ctx, tenantID)
+		if err != nil {
+			if failed == nil {
+				failed = errors.NewInfrastructureError(fmt.Sprintf("failed to reconcile the storage of tenant %s", tenantID), err)
+			}
+			continue
+		}
+		if drift {
+			drifted++
+		}
+	}
+
+	return drifted, failed
+}
+
+func (uc *ReconcileStorageUsageUseCase) reconcile(ctx context.Context, tenantID string) (bool, error) {
+	now := time.Now().UTC()
+	recorded, err := uc.usage.Reconcile(ctx, tenantID, now)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+
+	storedBytes, storedFiles, err := uc.inventory.Stored(ctx, tenantID)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+
+	drift := &entities.StorageDrift{
+		TenantID:      tenantID,
+		RecordedBytes: recorded.Bytes,
+		RecordedFiles: recorded.Files,
+		StoredBytes:   storedBytes,
+		StoredFiles:   storedFiles,
+		CheckedAt:     now,
+	}
+	if !drift.Drifted() {
+		return false, nil
+	}
+
+	if err := uc.usage.RecordDrift(ctx, drift); err != nil {
+		return false, errors.PropagateError(err)
+	}
+	return true, nil
+}
@@ -0,0 +1,33 @@
+package set_storage_quota_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// SetStorageQuotaCommand sets the limits in bytes, zero lifts a limit
+type SetStorageQuotaCommand struct {
+	TenantID    string `validate:"required"`
+	TenantBytes int64  `validate:"gte=0"`
+	UserBytes   int64  `validate:"gte=0"`
+	UpdatedBy   string `validate:"required"`
+}
+
+func NewSetStorageQuotaCommand(tenantID string, tenantBytes int64, userBytes int64, updatedBy string) (*SetStorageQuotaCommand, error) {
+	command := &SetStorageQuotaCommand{
+		TenantID:    tenantID,
+		TenantBytes: tenantBytes,
+		UserBytes:   userBytes,
+		UpdatedBy:   updatedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,35 @@
+package set_storage_quota_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/ports"
+)
+
+type SetStorageQuotaUseCase struct {
+	quotas ports.StorageQuotaRepository
+}
+
+func NewSetStorageQuotaUseCase(quotas ports.StorageQuotaRepository) *SetStorageQuotaUseCase {
+	return &SetStorageQuotaUseCase{
+		quotas: quotas,
+	}
+}
+
+// Execute replaces the quota of the tenant. Files already stored over a lowered quota are kept, only
+// new uploads are refused.
+func (uc *SetStorageQuotaUseCase) Execute(ctx context.Context, cmd *SetStorageQuotaCommand) (*entities.StorageQuota, error) {
+	quota, err := entities.NewStorageQuota(cmd.TenantID, cmd.TenantBytes, cmd.UserBytes, cmd.UpdatedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.quotas.Save(ctx, quota); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return quota, nil
+}
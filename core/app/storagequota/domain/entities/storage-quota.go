@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// QuotaScope is what a storage quota limits
+type QuotaScope string
+
+const (
+	QuotaScopeTenant QuotaScope = "tenant"
+	QuotaScopeUser   QuotaScope = "user"
+)
+
+// StorageQuota limits the bytes the files of a tenant take, and the bytes the files each of its users
+// uploaded take. A zero limit is no limit.
+type StorageQuota struct {
+	TenantID    string `validate:"required"`
+	TenantBytes int64  `validate:"gte=0"`
+	UserBytes   int64  `validate:"gte=0"`
+	// UpdatedBy is empty for tenants that never set a quota
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// UnlimitedStorageQuota returns the quota of a tenant that never set one
+func UnlimitedStorageQuota(tenantID string) *StorageQuota {
+	return &StorageQuota{TenantID: tenantID}
+}
+
+func NewStorageQuota(tenantID string, tenantBytes int64, userBytes int64, updatedBy string, now time.Time) (*StorageQuota, error) {
+	quota := &StorageQuota{
+		TenantID:    tenantID,
+		TenantBytes: tenantBytes,
+		UserBytes:   userBytes,
+		UpdatedBy:   updatedBy,
+		UpdatedAt:   now,
+	}
+
+	if err := validate.Struct(quota); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("StorageQuota domain model instance not valid", errorMap, err)
+	}
+
+	return quota, nil
+}
+
+// Limit returns the limit of the scope, false when the scope is not limited
+func (q *StorageQuota) Limit(scope QuotaScope) (int64, bool) {
+	limit := q.TenantBytes
+	if scope == QuotaScopeUser {
+		limit = q.UserBytes
+	}
+	return limit, limit > 0
+}
+
+// Exceeded returns the first scope that storing requested more bytes would take past its limit,
+// false when the upload fits in both
+func (q *StorageQuota) Exceeded(tenant *StorageUsage, user *StorageUsage, requested int64) (QuotaScope, bool) {
+	if limit, limited := q.Limit(QuotaScopeUser); limited && user.Bytes+requested > limit {
+		return QuotaScopeUser, true
+	}
+	if limit, limited := q.Limit(QuotaScopeTenant); limited && tenant.Bytes+requested > limit {
+		return QuotaScopeTenant, true
+	}
+	return "", false
+}
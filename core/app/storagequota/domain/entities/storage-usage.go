@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+// StorageUsage is what the files of an owner take in the file storage, the user who uploaded them.
+// The usage of the whole tenant has no owner.
+type StorageUsage struct {
+	TenantID  string
+	OwnerID   string
+	Bytes     int64
+	Files     int64
+	UpdatedAt time.Time
+}
+
+// StorageUsageReport is the usage of a tenant with its quota, and the usage of each of its users when
+// listed
+type StorageUsageReport struct {
+	Tenant *StorageUsage
+	Quota  *StorageQuota
+	Owners *pagination.Page[*StorageUsage]
+}
+
+// StorageDrift compares the usage recorded for a tenant with the files listed in the file storage.
+// Files stored without a record, or records whose file is gone, make them differ.
+type StorageDrift struct {
+	TenantID      string
+	RecordedBytes int64
+	RecordedFiles int64
+	StoredBytes   int64
+	StoredFiles   int64
+	CheckedAt     time.Time
+}
+
+// Drifted tells whether the storage holds other files than the recorded ones
+func (d *StorageDrift) Drifted() bool {
+	return d.RecordedBytes != d.StoredBytes || d.RecordedFiles != d.StoredFiles
+}
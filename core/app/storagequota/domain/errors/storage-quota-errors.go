@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// StorageQuotaExceededError refuses an upload that takes the tenant or its uploader past their
+	// storage quota
+	StorageQuotaExceededError errors2.ErrorCode = "STORAGE_QUOTA_EXCEEDED"
+)
+
+func NewStorageQuotaExceededError(scope string, limit int64, usage int64, requested int64) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    StorageQuotaExceededError.String(),
+			Message: "The upload does not fit in the storage quota",
+			Context: map[string]any{
+				"scope":     scope,
+				"limit":     limit,
+				"usage":     usage,
+				"requested": requested,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(StorageQuotaExceededError.String()),
+		},
+	}
+}
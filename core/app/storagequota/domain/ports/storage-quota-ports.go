@@ -0,0 +1,39 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/entities"
+)
+
+// StorageUsageRepository reads the bytes stored by each owner. Uploads add to the usage of their
+// uploader as they are recorded, and files deleted by the virus scan take their bytes back.
+type StorageUsageRepository interface {
+	// Usage returns the usage of the whole tenant and of the owner, zero when they store nothing
+	Usage(ctx context.Context, tenantID string, ownerID string) (*entities.StorageUsage, *entities.StorageUsage, error)
+	// List returns the usage of each owner of the tenant, largest first
+	List(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.StorageUsage], error)
+	// ListTenants returns the tenants with recorded files or usage
+	ListTenants(ctx context.Context) ([]string, error)
+	// Reconcile rewrites the usage of the owners of the tenant from the records of their files and
+	// returns the usage of the whole tenant. Uploads of the tenant wait for it.
+	Reconcile(ctx context.Context, tenantID string, now time.Time) (*entities.StorageUsage, error)
+	// RecordDrift queues the storage.drift_detected event
+	RecordDrift(ctx context.Context, drift *entities.StorageDrift) error
+}
+
+type StorageQuotaRepository interface {
+	// Find returns nil when the tenant never set a quota
+	Find(ctx context.Context, tenantID string) (*entities.StorageQuota, error)
+	// Save stores the quota with its storage.quota_set event
+	Save(ctx context.Context, quota *entities.StorageQuota) error
+}
+
+// StorageInventory lists the file storage
+type StorageInventory interface {
+	// Stored returns the bytes and the number of the files of the tenant in the file storage, their
+	// previews left out
+	Stored(ctx context.Context, tenantID string) (int64, int64, error)
+}
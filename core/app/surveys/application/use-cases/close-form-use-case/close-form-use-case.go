@@ -0,0 +1,49 @@
+package close_form_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+)
+
+type CloseFormUseCase struct {
+	formRepo     ports.FormRepository
+	participants ports.ParticipantDirectory
+}
+
+func NewCloseFormUseCase(formRepo ports.FormRepository, participants ports.ParticipantDirectory) *CloseFormUseCase {
+	return &CloseFormUseCase{
+		formRepo:     formRepo,
+		participants: participants,
+	}
+}
+
+// Execute stops collecting responses ahead of the closing time, or records the form as closed once
+// that time has passed
+func (uc *CloseFormUseCase) Execute(ctx context.Context, cmd *CloseFormCommand) (*entities.Form, error) {
+	participant, err := uc.participants.Participant(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	form, err := uc.formRepo.FindByID(ctx, cmd.TenantID, cmd.FormID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if form == nil || !participant.Manages(form) {
+		return nil, surveysErrors.NewFormNotFoundError(cmd.FormID)
+	}
+	if !form.IsOpen() {
+		return nil, surveysErrors.NewInvalidFormTransitionError(form.ID, string(form.Status), string(entities.FormStatusClosed))
+	}
+
+	form.Close(time.Now().UTC())
+	if err := uc.formRepo.Update(ctx, form); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return form, nil
+}
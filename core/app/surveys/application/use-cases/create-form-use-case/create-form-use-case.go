@@ -0,0 +1,39 @@
+package create_form_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+type CreateFormUseCase struct {
+	formRepo ports.FormRepository
+}
+
+func NewCreateFormUseCase(formRepo ports.FormRepository) *CreateFormUseCase {
+	return &CreateFormUseCase{
+		formRepo: formRepo,
+	}
+}
+
+// Execute creates the form as a draft, nobody is asked to answer it until it is opened
+func (uc *CreateFormUseCase) Execute(ctx context.Context, cmd *CreateFormCommand) (*entities.Form, error) {
+	now := time.Now().UTC()
+
+	form, err := entities.NewForm(uuid.New().String(), cmd.TenantID, cmd.Title, cmd.Description, cmd.Anonymous, entities.FormStatusDraft,
+		cmd.Questions, cmd.Audience, cmd.UserID, cmd.ClosesAt, nil, nil, now, now)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.formRepo.Create(ctx, form); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return form, nil
+}
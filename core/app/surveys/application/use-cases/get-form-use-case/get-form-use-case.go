@@ -0,0 +1,50 @@
+package get_form_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+)
+
+type GetFormUseCase struct {
+	formRepo     ports.FormRepository
+	responseRepo ports.ResponseRepository
+	participants ports.ParticipantDirectory
+}
+
+func NewGetFormUseCase(formRepo ports.FormRepository, responseRepo ports.ResponseRepository,
+	participants ports.ParticipantDirectory) *GetFormUseCase {
+	return &GetFormUseCase{
+		formRepo:     formRepo,
+		responseRepo: responseRepo,
+		participants: participants,
+	}
+}
+
+// Execute returns the form to its managers and to the members of its audience once it was opened
+func (uc *GetFormUseCase) Execute(ctx context.Context, cmd *GetFormCommand) (*entities.FormView, error) {
+	participant, err := uc.participants.Participant(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	form, err := uc.formRepo.FindByID(ctx, cmd.TenantID, cmd.FormID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if form == nil || (!participant.Manages(form) && !participant.IsTargetedBy(form)) {
+		return nil, surveysErrors.NewFormNotFoundError(cmd.FormID)
+	}
+
+	responded := false
+	if !form.IsDraft() {
+		responded, err = uc.responseRepo.HasResponded(ctx, cmd.TenantID, form.ID, cmd.UserID)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+	}
+
+	return &entities.FormView{Form: form, Responded: responded}, nil
+}
@@ -0,0 +1,57 @@
+package list_assigned_forms_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+)
+
+// maxOpenForms bounds the open forms scanned for the audience check
+const maxOpenForms = 500
+
+type ListAssignedFormsUseCase struct {
+	formRepo     ports.FormRepository
+	responseRepo ports.ResponseRepository
+	participants ports.ParticipantDirectory
+}
+
+func NewListAssignedFormsUseCase(formRepo ports.FormRepository, responseRepo ports.ResponseRepository,
+	participants ports.ParticipantDirectory) *ListAssignedFormsUseCase {
+	return &ListAssignedFormsUseCase{
+		formRepo:     formRepo,
+		responseRepo: responseRepo,
+		participants: participants,
+	}
+}
+
+// Execute lists the forms accepting responses whose audience includes the user, with whether they
+// already answered each of them
+func (uc *ListAssignedFormsUseCase) Execute(ctx context.Context, cmd *ListAssignedFormsCommand) ([]*entities.FormView, error) {
+	participant, err := uc.participants.Participant(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	forms, err := uc.formRepo.List(ctx, cmd.TenantID, ports.FormFilter{Status: entities.FormStatusOpen, Limit: maxOpenForms})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	now := time.Now().UTC()
+	views := make([]*entities.FormView, 0, len(forms))
+	for _, form := range forms {
+		if !form.AcceptsResponses(now) || !participant.IsTargetedBy(form) {
+			continue
+		}
+		responded, err := uc.responseRepo.HasResponded(ctx, cmd.TenantID, form.ID, cmd.UserID)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		views = append(views, &entities.FormView{Form: form, Responded: responded})
+	}
+
+	return views, nil
+}
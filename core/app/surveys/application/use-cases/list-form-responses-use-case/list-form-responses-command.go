@@ -0,0 +1,41 @@
+package list_form_responses_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListFormResponsesCommand struct {
+	TenantID string `validate:"required"`
+	FormID   string `validate:"required,uuid"`
+	UserID   string `validate:"required"`
+	Page     *pagination.PageRequest
+}
+
+func NewListFormResponsesCommand(tenantID string, formID string, userID string, pageSize int, cursor string) (*ListFormResponsesCommand, error) {
+	page, err := pagination.NewPageRequest(pageSize, cursor, "", nil, pagination.OrderBy{
+		Field:     "submitted_at",
+		Direction: pagination.Ascending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	command := &ListFormResponsesCommand{
+		TenantID: tenantID,
+		FormID:   formID,
+		UserID:   userID,
+		Page:     page,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
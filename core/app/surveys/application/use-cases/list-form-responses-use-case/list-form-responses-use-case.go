@@ -0,0 +1,49 @@
+package list_form_responses_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+)
+
+type ListFormResponsesUseCase struct {
+	formRepo     ports.FormRepository
+	responseRepo ports.ResponseRepository
+	participants ports.ParticipantDirectory
+}
+
+func NewListFormResponsesUseCase(formRepo ports.FormRepository, responseRepo ports.ResponseRepository,
+	participants ports.ParticipantDirectory) *ListFormResponsesUseCase {
+	return &ListFormResponsesUseCase{
+		formRepo:     formRepo,
+		responseRepo: responseRepo,
+		participants: participants,
+	}
+}
+
+// Execute returns a page of the results to the managers of the form, along with the form so
+// callers can lay out the answers
+func (uc *ListFormResponsesUseCase) Execute(ctx context.Context, cmd *ListFormResponsesCommand) (*entities.Form, *pagination.Page[*entities.Response], error) {
+	participant, err := uc.participants.Participant(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	form, err := uc.formRepo.FindByID(ctx, cmd.TenantID, cmd.FormID)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+	if form == nil || !participant.Manages(form) {
+		return nil, nil, surveysErrors.NewFormNotFoundError(cmd.FormID)
+	}
+
+	page, err := uc.responseRepo.List(ctx, cmd.TenantID, form.ID, cmd.Page)
+	if err != nil {
+		return nil, nil, errors.PropagateError(err)
+	}
+
+	return form, page, nil
+}
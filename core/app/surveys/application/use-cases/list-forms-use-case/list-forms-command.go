@@ -0,0 +1,40 @@
+package list_forms_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const DefaultLimit = 50
+
+var validate = validator.New()
+
+type ListFormsCommand struct {
+	TenantID string              `validate:"required"`
+	UserID   string              `validate:"required"`
+	Status   entities.FormStatus `validate:"omitempty,oneof=draft open closed"`
+	Limit    int                 `validate:"min=1,max=100"`
+}
+
+// NewListFormsCommand lists DefaultLimit forms when limit is zero
+func NewListFormsCommand(tenantID string, userID string, status string, limit int) (*ListFormsCommand, error) {
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	command := &ListFormsCommand{
+		TenantID: tenantID,
+		UserID:   userID,
+		Status:   entities.FormStatus(status),
+		Limit:    limit,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
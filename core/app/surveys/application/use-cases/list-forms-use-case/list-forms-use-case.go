@@ -0,0 +1,41 @@
+package list_forms_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+)
+
+type ListFormsUseCase struct {
+	formRepo     ports.FormRepository
+	participants ports.ParticipantDirectory
+}
+
+func NewListFormsUseCase(formRepo ports.FormRepository, participants ports.ParticipantDirectory) *ListFormsUseCase {
+	return &ListFormsUseCase{
+		formRepo:     formRepo,
+		participants: participants,
+	}
+}
+
+// Execute lists the forms the user manages, their own unless they manage all forms of the tenant
+func (uc *ListFormsUseCase) Execute(ctx context.Context, cmd *ListFormsCommand) ([]*entities.Form, error) {
+	participant, err := uc.participants.Participant(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	filter := ports.FormFilter{Status: cmd.Status, Limit: cmd.Limit}
+	if !participant.CanManageAll {
+		filter.CreatedBy = participant.UserID
+	}
+
+	forms, err := uc.formRepo.List(ctx, cmd.TenantID, filter)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return forms, nil
+}
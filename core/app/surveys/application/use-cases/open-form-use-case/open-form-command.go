@@ -0,0 +1,30 @@
+package open_form_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type OpenFormCommand struct {
+	TenantID string `validate:"required"`
+	FormID   string `validate:"required,uuid"`
+	UserID   string `validate:"required"`
+}
+
+func NewOpenFormCommand(tenantID string, formID string, userID string) (*OpenFormCommand, error) {
+	command := &OpenFormCommand{
+		TenantID: tenantID,
+		FormID:   formID,
+		UserID:   userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,60 @@
+package open_form_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+)
+
+type OpenFormUseCase struct {
+	formRepo     ports.FormRepository
+	participants ports.ParticipantDirectory
+}
+
+func NewOpenFormUseCase(formRepo ports.FormRepository, participants ports.ParticipantDirectory) *OpenFormUseCase {
+	return &OpenFormUseCase{
+		formRepo:     formRepo,
+		participants: participants,
+	}
+}
+
+// Execute opens the draft for responses and distributes it to every member of its audience at
+// that moment. Returns the form and the number of recipients.
+func (uc *OpenFormUseCase) Execute(ctx context.Context, cmd *OpenFormCommand) (*entities.Form, int, error) {
+	participant, err := uc.participants.Participant(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, 0, errors.PropagateError(err)
+	}
+	form, err := uc.formRepo.FindByID(ctx, cmd.TenantID, cmd.FormID)
+	if err != nil {
+		return nil, 0, errors.PropagateError(err)
+	}
+	if form == nil || !participant.Manages(form) {
+		return nil, 0, surveysErrors.NewFormNotFoundError(cmd.FormID)
+	}
+	if !form.IsDraft() {
+		return nil, 0, surveysErrors.NewInvalidFormTransitionError(form.ID, string(form.Status), string(entities.FormStatusOpen))
+	}
+
+	now := time.Now().UTC()
+	if form.ClosesAt != nil && !form.ClosesAt.After(now) {
+		return nil, 0, errors.NewValidationError("Invalid form",
+			map[string]any{"closes_at": "the closing time has already passed"}, nil)
+	}
+
+	recipients, err := uc.participants.Members(ctx, cmd.TenantID, form.Audience)
+	if err != nil {
+		return nil, 0, errors.PropagateError(err)
+	}
+
+	form.Open(now)
+	if err := uc.formRepo.Open(ctx, form, recipients); err != nil {
+		return nil, 0, errors.PropagateError(err)
+	}
+
+	return form, len(recipients), nil
+}
@@ -0,0 +1,35 @@
+package submit_response_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SubmitResponseCommand struct {
+	TenantID string            `validate:"required"`
+	FormID   string            `validate:"required,uuid"`
+	UserID   string            `validate:"required"`
+	Answers  []entities.Answer `validate:"max=100"`
+}
+
+// NewSubmitResponseCommand takes the answers as given, they are checked against the questions of
+// the form by the use case
+func NewSubmitResponseCommand(tenantID string, formID string, userID string, answers []entities.Answer) (*SubmitResponseCommand, error) {
+	command := &SubmitResponseCommand{
+		TenantID: tenantID,
+		FormID:   formID,
+		UserID:   userID,
+		Answers:  answers,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,69 @@
+package submit_response_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+type SubmitResponseUseCase struct {
+	formRepo     ports.FormRepository
+	responseRepo ports.ResponseRepository
+	participants ports.ParticipantDirectory
+}
+
+func NewSubmitResponseUseCase(formRepo ports.FormRepository, responseRepo ports.ResponseRepository,
+	participants ports.ParticipantDirectory) *SubmitResponseUseCase {
+	return &SubmitResponseUseCase{
+		formRepo:     formRepo,
+		responseRepo: responseRepo,
+		participants: participants,
+	}
+}
+
+// Execute records the answers of a member of the audience, once per user. Required questions and
+// answers are checked along the path the answers take through the branches.
+func (uc *SubmitResponseUseCase) Execute(ctx context.Context, cmd *SubmitResponseCommand) (*entities.Response, error) {
+	participant, err := uc.participants.Participant(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	form, err := uc.formRepo.FindByID(ctx, cmd.TenantID, cmd.FormID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if form == nil || !participant.IsTargetedBy(form) {
+		return nil, surveysErrors.NewFormNotFoundError(cmd.FormID)
+	}
+
+	now := time.Now().UTC()
+	if !form.AcceptsResponses(now) {
+		return nil, surveysErrors.NewFormNotOpenError(form.ID)
+	}
+
+	answers, problems := form.CheckAnswers(cmd.Answers)
+	if problems != nil {
+		return nil, errors.NewValidationError("Invalid answers", problems, nil)
+	}
+
+	respondentID := cmd.UserID
+	if form.Anonymous {
+		respondentID = ""
+	}
+	response, err := entities.NewResponse(uuid.New().String(), cmd.TenantID, form.ID, respondentID, answers, now)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.responseRepo.Submit(ctx, response, cmd.UserID); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return response, nil
+}
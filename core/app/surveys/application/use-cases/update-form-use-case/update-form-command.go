@@ -0,0 +1,47 @@
+package update_form_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type UpdateFormCommand struct {
+	TenantID    string `validate:"required"`
+	FormID      string `validate:"required,uuid"`
+	UserID      string `validate:"required"`
+	Title       string `validate:"required,max=200"`
+	Description string `validate:"max=2000"`
+	Anonymous   bool
+	Questions   []*entities.Question `validate:"required,min=1,max=100,dive,required"`
+	Audience    entities.Audience
+	ClosesAt    *time.Time
+}
+
+// NewUpdateFormCommand replaces the whole definition of a draft form
+func NewUpdateFormCommand(tenantID string, formID string, userID string, title string, description string, anonymous bool,
+	questions []*entities.Question, audience entities.Audience, closesAt *time.Time) (*UpdateFormCommand, error) {
+	command := &UpdateFormCommand{
+		TenantID:    tenantID,
+		FormID:      formID,
+		UserID:      userID,
+		Title:       title,
+		Description: description,
+		Anonymous:   anonymous,
+		Questions:   questions,
+		Audience:    audience,
+		ClosesAt:    closesAt,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
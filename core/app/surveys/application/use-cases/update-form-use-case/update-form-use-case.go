@@ -0,0 +1,52 @@
+package update_form_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+)
+
+type UpdateFormUseCase struct {
+	formRepo     ports.FormRepository
+	participants ports.ParticipantDirectory
+}
+
+func NewUpdateFormUseCase(formRepo ports.FormRepository, participants ports.ParticipantDirectory) *UpdateFormUseCase {
+	return &UpdateFormUseCase{
+		formRepo:     formRepo,
+		participants: participants,
+	}
+}
+
+func (uc *UpdateFormUseCase) Execute(ctx context.Context, cmd *UpdateFormCommand) (*entities.Form, error) {
+	participant, err := uc.participants.Participant(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	form, err := uc.formRepo.FindByID(ctx, cmd.TenantID, cmd.FormID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if form == nil || !participant.Manages(form) {
+		return nil, surveysErrors.NewFormNotFoundError(cmd.FormID)
+	}
+	if !form.IsDraft() {
+		return nil, surveysErrors.NewFormNotEditableError(form.ID, string(form.Status))
+	}
+
+	updated, err := entities.NewForm(form.ID, form.TenantID, cmd.Title, cmd.Description, cmd.Anonymous, form.Status, cmd.Questions,
+		cmd.Audience, form.CreatedBy, cmd.ClosesAt, nil, nil, form.CreatedAt, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.formRepo.Update(ctx, updated); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"slices"
+)
+
+// Audience targets a form. Respondents need one of Roles and, when ClassIDs is set, to be enrolled
+// in one of the classes or be the guardian of a student who is. An empty audience is the whole
+// tenant.
+type Audience struct {
+	Roles    []string `validate:"max=10,dive,required,max=50"`
+	ClassIDs []string `validate:"max=50,dive,uuid"`
+}
+
+func (a Audience) Includes(participant Participant) bool {
+	if len(a.Roles) > 0 && !slices.ContainsFunc(participant.Roles, func(role string) bool { return slices.Contains(a.Roles, role) }) {
+		return false
+	}
+	if len(a.ClassIDs) > 0 && !slices.ContainsFunc(participant.ClassIDs, func(classID string) bool { return slices.Contains(a.ClassIDs, classID) }) {
+		return false
+	}
+	return true
+}
+
+// Participant is a user working with forms. Managers create forms and read the results of their
+// own, those who manage all forms read every result in the tenant.
+type Participant struct {
+	UserID       string
+	Roles        []string
+	ClassIDs     []string // classes the user is enrolled in or has a child enrolled in
+	CanManage    bool
+	CanManageAll bool
+}
+
+func (p Participant) Manages(form *Form) bool {
+	return p.CanManageAll || (p.CanManage && form.CreatedBy == p.UserID)
+}
+
+// IsTargetedBy reports whether the form was sent to the participant, drafts are sent to nobody
+func (p Participant) IsTargetedBy(form *Form) bool {
+	return !form.IsDraft() && form.Audience.Includes(p)
+}
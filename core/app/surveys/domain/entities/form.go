@@ -0,0 +1,216 @@
+package entities
+
+import (
+	"fmt"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type FormStatus string
+
+const (
+	FormStatusDraft  FormStatus = "draft"
+	FormStatusOpen   FormStatus = "open"
+	FormStatusClosed FormStatus = "closed"
+)
+
+const MaxQuestionsPerForm = 100
+
+// Form is a survey sent to an audience. Questions and audience can only change while it is a
+// draft, once opened the answers collected must keep matching the questions asked.
+type Form struct {
+	ID          string      `validate:"required,uuid4"`
+	TenantID    string      `validate:"required"`
+	Title       string      `validate:"required,max=200"`
+	Description string      `validate:"max=2000"`
+	Anonymous   bool        // responses are stored without the respondent
+	Status      FormStatus  `validate:"required,oneof=draft open closed"`
+	Questions   []*Question `validate:"required,min=1,max=100,dive,required"`
+	Audience    Audience
+	CreatedBy   string `validate:"required"`
+	ClosesAt    *time.Time
+	OpenedAt    *time.Time
+	ClosedAt    *time.Time
+	CreatedAt   time.Time `validate:"required"`
+	UpdatedAt   time.Time `validate:"required"`
+}
+
+func NewForm(id string, tenantID string, title string, description string, anonymous bool, status FormStatus, questions []*Question,
+	audience Audience, createdBy string, closesAt *time.Time, openedAt *time.Time, closedAt *time.Time, createdAt time.Time,
+	updatedAt time.Time) (*Form, error) {
+	form := &Form{
+		ID:          id,
+		TenantID:    tenantID,
+		Title:       title,
+		Description: description,
+		Anonymous:   anonymous,
+		Status:      status,
+		Questions:   questions,
+		Audience:    audience,
+		CreatedBy:   createdBy,
+		ClosesAt:    closesAt,
+		OpenedAt:    openedAt,
+		ClosedAt:    closedAt,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+	}
+
+	if err := validate.Struct(form); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("Form domain model instance not valid", errorMap, err)
+	}
+
+	errorMap := make(map[string]any)
+	for i, question := range questions {
+		key := fmt.Sprintf("Questions[%d]", i)
+		if slices.ContainsFunc(questions[:i], func(other *Question) bool { return other.ID == question.ID }) {
+			errorMap[key] = fmt.Sprintf("question ID %q is repeated", question.ID)
+		} else if message := question.checkStructure(questions[i+1:]); message != "" {
+			errorMap[key] = message
+		}
+	}
+	if len(errorMap) > 0 {
+		return nil, appErrors.NewDomainEntityValidationError("Form domain model instance not valid", errorMap,
+			errors.New("invalid form questions"))
+	}
+
+	return form, nil
+}
+
+func (f *Form) IsDraft() bool {
+	return f.Status == FormStatusDraft
+}
+
+func (f *Form) IsOpen() bool {
+	return f.Status == FormStatusOpen
+}
+
+// AcceptsResponses reports whether the form is open and its closing time has not passed
+func (f *Form) AcceptsResponses(now time.Time) bool {
+	return f.IsOpen() && (f.ClosesAt == nil || now.Before(*f.ClosesAt))
+}
+
+func (f *Form) Open(now time.Time) {
+	f.Status = FormStatusOpen
+	f.OpenedAt = &now
+	f.UpdatedAt = now
+}
+
+func (f *Form) Close(now time.Time) {
+	f.Status = FormStatusClosed
+	f.ClosedAt = &now
+	f.UpdatedAt = now
+}
+
+func (f *Form) Question(questionID string) *Question {
+	for _, question := range f.Questions {
+		if question.ID == questionID {
+			return question
+		}
+	}
+	return nil
+}
+
+// Path returns the questions a respondent is asked given their answers, following branches from
+// the first question
+func (f *Form) Path(answers map[string][]string) []*Question {
+	var path []*Question
+	for i := 0; i < len(f.Questions); {
+		question := f.Questions[i]
+		path = append(path, question)
+
+		branch := question.branchFor(answers[question.ID])
+		if branch == nil {
+			i++
+			continue
+		}
+		if branch.GoTo == "" {
+			break
+		}
+		i = slices.IndexFunc(f.Questions, func(other *Question) bool { return other.ID == branch.GoTo })
+	}
+	return path
+}
+
+// CheckAnswers validates the answers against the questions on the respondent's path. It returns
+// the answers normalized and in question order, or the problems found keyed by question ID.
+func (f *Form) CheckAnswers(answers []Answer) ([]Answer, map[string]any) {
+	errorMap := make(map[string]any)
+	byQuestion := make(map[string][]string, len(answers))
+	for _, answer := range answers {
+		if f.Question(answer.QuestionID) == nil {
+			errorMap[answer.QuestionID] = "not a question of this form"
+			continue
+		}
+		if _, repeated := byQuestion[answer.QuestionID]; repeated {
+			errorMap[answer.QuestionID] = "answered more than once"
+			continue
+		}
+		byQuestion[answer.QuestionID] = normalizeValues(answer.Values)
+	}
+
+	path := f.Path(byQuestion)
+	checked := make([]Answer, 0, len(path))
+	for _, question := range path {
+		values := byQuestion[question.ID]
+		delete(byQuestion, question.ID)
+		if len(values) == 0 {
+			if question.Required {
+				errorMap[question.ID] = "required"
+			}
+			continue
+		}
+		if message := question.checkValues(values); message != "" {
+			errorMap[question.ID] = message
+			continue
+		}
+		checked = append(checked, Answer{QuestionID: question.ID, Values: values})
+	}
+
+	for questionID, values := range byQuestion {
+		if len(values) > 0 {
+			errorMap[questionID] = "not asked given the earlier answers"
+		}
+	}
+
+	if len(errorMap) > 0 {
+		return nil, errorMap
+	}
+	return checked, nil
+}
+
+// ResultsHeader is the header of the results export, one column per question after the response
+// columns. Anonymous forms have no respondent column.
+func (f *Form) ResultsHeader() []string {
+	header := []string{"response_id", "submitted_at"}
+	if !f.Anonymous {
+		header = append(header, "respondent_id")
+	}
+	for _, question := range f.Questions {
+		header = append(header, question.Prompt)
+	}
+	return header
+}
+
+// ResultsRow is the export row of a response matching ResultsHeader, multiple choices are joined
+// with "; " and skipped questions are left empty
+func (f *Form) ResultsRow(response *Response) []string {
+	row := []string{response.ID, response.SubmittedAt.UTC().Format(time.RFC3339)}
+	if !f.Anonymous {
+		row = append(row, response.RespondentID)
+	}
+	for _, question := range f.Questions {
+		row = append(row, strings.Join(response.Values(question.ID), "; "))
+	}
+	return row
+}
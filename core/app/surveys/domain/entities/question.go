@@ -0,0 +1,142 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+type QuestionType string
+
+const (
+	QuestionTypeShortText      QuestionType = "short_text"
+	QuestionTypeLongText       QuestionType = "long_text"
+	QuestionTypeSingleChoice   QuestionType = "single_choice"
+	QuestionTypeMultipleChoice QuestionType = "multiple_choice"
+	QuestionTypeRating         QuestionType = "rating"
+)
+
+const (
+	MaxShortTextLength = 500
+	MaxLongTextLength  = 5000
+	// Ratings go from 1 to MaxRating
+	MaxRating = 5
+)
+
+var questionIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func (t QuestionType) HasOptions() bool {
+	return t == QuestionTypeSingleChoice || t == QuestionTypeMultipleChoice
+}
+
+// Branch sends respondents who pick Option on a single choice question to the question GoTo,
+// an empty GoTo ends the form
+type Branch struct {
+	Option string `validate:"required,max=200"`
+	GoTo   string `validate:"max=50"`
+}
+
+// Question IDs are keys chosen by the form author, branches refer to them
+type Question struct {
+	ID       string       `validate:"required,max=50"`
+	Prompt   string       `validate:"required,max=500"`
+	Type     QuestionType `validate:"required,oneof=short_text long_text single_choice multiple_choice rating"`
+	Required bool
+	Options  []string `validate:"max=50,dive,required,max=200"`
+	Branches []Branch `validate:"max=50,dive"`
+}
+
+// branchFor returns the branch taken for the given answer, or nil to continue with the next question
+func (q *Question) branchFor(values []string) *Branch {
+	if q.Type != QuestionTypeSingleChoice || len(values) != 1 {
+		return nil
+	}
+	for i := range q.Branches {
+		if q.Branches[i].Option == values[0] {
+			return &q.Branches[i]
+		}
+	}
+	return nil
+}
+
+// checkValues returns why the answer is not valid for the question, or "" when it is
+func (q *Question) checkValues(values []string) string {
+	switch q.Type {
+	case QuestionTypeShortText, QuestionTypeLongText:
+		limit := MaxShortTextLength
+		if q.Type == QuestionTypeLongText {
+			limit = MaxLongTextLength
+		}
+		if len(values) != 1 {
+			return "expects a single text"
+		}
+		if utf8.RuneCountInString(values[0]) > limit {
+			return fmt.Sprintf("must be at most %d characters", limit)
+		}
+	case QuestionTypeSingleChoice:
+		if len(values) != 1 || !slices.Contains(q.Options, values[0]) {
+			return "expects one of the options"
+		}
+	case QuestionTypeMultipleChoice:
+		for i, value := range values {
+			if !slices.Contains(q.Options, value) || slices.Contains(values[:i], value) {
+				return "expects distinct options of the question"
+			}
+		}
+	case QuestionTypeRating:
+		if len(values) != 1 || len(values[0]) != 1 || values[0][0] < '1' || values[0][0] > '0'+MaxRating {
+			return fmt.Sprintf("expects a rating from 1 to %d", MaxRating)
+		}
+	}
+	return ""
+}
+
+// checkStructure validates what the struct tags cannot express, later are the questions that
+// follow it in the form
+func (q *Question) checkStructure(later []*Question) string {
+	if !questionIDPattern.MatchString(q.ID) {
+		return "IDs may only contain letters, digits, dashes and underscores"
+	}
+	if q.Type.HasOptions() {
+		if len(q.Options) < 2 {
+			return "choice questions need at least two options"
+		}
+		for i, option := range q.Options {
+			if slices.Contains(q.Options[:i], option) {
+				return fmt.Sprintf("option %q is repeated", option)
+			}
+		}
+	} else if len(q.Options) > 0 {
+		return "only choice questions take options"
+	}
+
+	if len(q.Branches) > 0 && q.Type != QuestionTypeSingleChoice {
+		return "only single choice questions can branch"
+	}
+	for i, branch := range q.Branches {
+		if !slices.Contains(q.Options, branch.Option) {
+			return fmt.Sprintf("branch option %q is not an option of the question", branch.Option)
+		}
+		if slices.ContainsFunc(q.Branches[:i], func(other Branch) bool { return other.Option == branch.Option }) {
+			return fmt.Sprintf("option %q branches twice", branch.Option)
+		}
+		// Branches only jump forward, so every path through the form ends
+		if branch.GoTo != "" && !slices.ContainsFunc(later, func(other *Question) bool { return other.ID == branch.GoTo }) {
+			return fmt.Sprintf("branch target %q is not a later question", branch.GoTo)
+		}
+	}
+	return ""
+}
+
+// normalizeValues trims the values and drops blank ones, a blank answer counts as unanswered
+func normalizeValues(values []string) []string {
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			normalized = append(normalized, value)
+		}
+	}
+	return normalized
+}
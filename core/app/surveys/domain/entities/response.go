@@ -0,0 +1,62 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+type Answer struct {
+	QuestionID string   `validate:"required,max=50"`
+	Values     []string `validate:"required,min=1,max=50"`
+}
+
+// Response holds the answers of one respondent. RespondentID is empty for anonymous forms, who
+// answered is then only recorded apart from the answers to enforce one response per user.
+type Response struct {
+	ID           string `validate:"required,uuid4"`
+	TenantID     string `validate:"required"`
+	FormID       string `validate:"required,uuid"`
+	RespondentID string
+	Answers      []Answer  `validate:"dive"`
+	SubmittedAt  time.Time `validate:"required"`
+}
+
+func NewResponse(id string, tenantID string, formID string, respondentID string, answers []Answer, submittedAt time.Time) (*Response, error) {
+	response := &Response{
+		ID:           id,
+		TenantID:     tenantID,
+		FormID:       formID,
+		RespondentID: respondentID,
+		Answers:      answers,
+		SubmittedAt:  submittedAt,
+	}
+
+	if err := validate.Struct(response); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("Response domain model instance not valid", errorMap, err)
+	}
+
+	return response, nil
+}
+
+// Values returns the answer given to the question, nil when it was skipped
+func (r *Response) Values(questionID string) []string {
+	for _, answer := range r.Answers {
+		if answer.QuestionID == questionID {
+			return answer.Values
+		}
+	}
+	return nil
+}
+
+// FormView is a form as shown to a user, with whether they already responded
+type FormView struct {
+	Form      *Form
+	Responded bool
+}
@@ -0,0 +1,89 @@
+package errors
+
+import (
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	FormNotFoundError          errors2.ErrorCode = "FORM_NOT_FOUND"
+	FormNotEditableError       errors2.ErrorCode = "FORM_NOT_EDITABLE"
+	InvalidFormTransitionError errors2.ErrorCode = "INVALID_FORM_TRANSITION"
+	FormNotOpenError           errors2.ErrorCode = "FORM_NOT_OPEN"
+	FormAlreadyAnsweredError   errors2.ErrorCode = "FORM_ALREADY_ANSWERED"
+)
+
+func NewFormNotFoundError(formID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    FormNotFoundError.String(),
+			Message: "The requested form could not be found",
+			Context: map[string]any{
+				"form_id": formID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(FormNotFoundError.String()),
+		},
+	}
+}
+
+func NewFormNotEditableError(formID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    FormNotEditableError.String(),
+			Message: "Only draft forms can be changed",
+			Context: map[string]any{
+				"form_id": formID,
+				"status":  status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(FormNotEditableError.String()),
+		},
+	}
+}
+
+func NewInvalidFormTransitionError(formID string, from string, to string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    InvalidFormTransitionError.String(),
+			Message: "The form cannot move to the requested status",
+			Context: map[string]any{
+				"form_id": formID,
+				"from":    from,
+				"to":      to,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidFormTransitionError.String()),
+		},
+	}
+}
+
+func NewFormNotOpenError(formID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    FormNotOpenError.String(),
+			Message: "The form is not accepting responses",
+			Context: map[string]any{
+				"form_id": formID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(FormNotOpenError.String()),
+		},
+	}
+}
+
+func NewFormAlreadyAnsweredError(formID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    FormAlreadyAnsweredError.String(),
+			Message: "The form was already answered by this user",
+			Context: map[string]any{
+				"form_id": formID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(FormAlreadyAnsweredError.String()),
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+)
+
+// FormFilter fields are optional, CreatedBy limits the listing to the forms of one author
+type FormFilter struct {
+	Status    entities.FormStatus
+	CreatedBy string
+	Limit     int
+}
+
+type FormRepository interface {
+	Create(ctx context.Context, form *entities.Form) error
+	Update(ctx context.Context, form *entities.Form) error
+	// Open saves the opened form and queues its distribution to the recipients in one transaction
+	Open(ctx context.Context, form *entities.Form, recipientIDs []string) error
+	FindByID(ctx context.Context, tenantID string, formID string) (*entities.Form, error)
+	// List returns the matching forms, last created first
+	List(ctx context.Context, tenantID string, filter FormFilter) ([]*entities.Form, error)
+}
+
+type ResponseRepository interface {
+	// Submit stores the response and records that the user answered the form, it fails with
+	// FORM_ALREADY_ANSWERED on a second response of the same user
+	Submit(ctx context.Context, response *entities.Response, userID string) error
+	HasResponded(ctx context.Context, tenantID string, formID string, userID string) (bool, error)
+	// List returns the responses of the form, oldest first
+	List(ctx context.Context, tenantID string, formID string, page *pagination.PageRequest) (*pagination.Page[*entities.Response], error)
+}
+
+type ParticipantDirectory interface {
+	Participant(ctx context.Context, tenantID string, userID string) (entities.Participant, error)
+	// Members returns the IDs of the users in the audience
+	Members(ctx context.Context, tenantID string, audience entities.Audience) ([]string, error)
+}
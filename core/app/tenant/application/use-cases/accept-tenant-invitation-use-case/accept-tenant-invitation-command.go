@@ -0,0 +1,30 @@
+package accept_tenant_invitation_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type AcceptTenantInvitationCommand struct {
+	TenantID     string `validate:"required"`
+	InvitationID string `validate:"required,uuid"`
+	UserID       string `validate:"required"`
+}
+
+func NewAcceptTenantInvitationCommand(tenantID string, invitationID string, userID string) (*AcceptTenantInvitationCommand, error) {
+	command := &AcceptTenantInvitationCommand{
+		TenantID:     tenantID,
+		InvitationID: invitationID,
+		UserID:       userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,76 @@
+package accept_tenant_invitation_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/entities"
+	tenantErrors "github.com/nahualventure/class-backend/core/app/tenant/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/ports"
+	userPorts "github.com/nahualventure/class-backend/core/app/user/domain/ports"
+)
+
+type AcceptTenantInvitationUseCase struct {
+	invitations ports.TenantInvitationRepository
+	policies    ports.TenantPolicies
+	users       userPorts.UserReader
+	clock       clock.Clock
+}
+
+func NewAcceptTenantInvitationUseCase(invitations ports.TenantInvitationRepository, policies ports.TenantPolicies, users userPorts.UserReader) *AcceptTenantInvitationUseCase {
+	return &AcceptTenantInvitationUseCase{
+		invitations: invitations,
+		policies:    policies,
+		users:       users,
+		clock:       clock.System,
+	}
+}
+
+// WithClock changes the clock invitations are checked for expiry with
+func (uc *AcceptTenantInvitationUseCase) WithClock(clock clock.Clock) *AcceptTenantInvitationUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute gives the role of the invitation to the user, whose email must be the one invited. The
+// role is assigned before the invitation is marked accepted, so a failure in between leaves an
+// invitation that can be accepted again rather than one accepted without the role.
+func (uc *AcceptTenantInvitationUseCase) Execute(ctx context.Context, cmd *AcceptTenantInvitationCommand) (*entities.TenantInvitation, error) {
+	invitation, err := uc.invitations.Find(ctx, cmd.InvitationID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if invitation == nil || invitation.TenantID != cmd.TenantID || !invitation.IsOpen() {
+		return nil, tenantErrors.NewTenantInvitationNotFoundError(cmd.InvitationID)
+	}
+
+	users, err := uc.users.FindByIDs([]string{cmd.UserID})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if len(users) == 0 || !invitation.IsFor(users[0].Email) {
+		return nil, tenantErrors.NewTenantInvitationNotFoundError(cmd.InvitationID)
+	}
+
+	now := uc.clock.Now().UTC()
+	if invitation.IsExpired(now) {
+		return nil, tenantErrors.NewTenantInvitationExpiredError(invitation.ID, invitation.ExpiresAt)
+	}
+
+	if err := uc.policies.AssignRole(ctx, cmd.UserID, invitation.Role, invitation.TenantID); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	accepted, err := uc.invitations.Accept(ctx, invitation.ID, cmd.UserID, now)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !accepted {
+		return nil, tenantErrors.NewTenantInvitationNotFoundError(cmd.InvitationID)
+	}
+
+	invitation.AcceptedBy = cmd.UserID
+	invitation.AcceptedAt = &now
+	return invitation, nil
+}
@@ -0,0 +1,30 @@
+package onboard_tenant_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type OnboardTenantCommand struct {
+	TenantID   string `validate:"required,max=100,excludesall=/ "`
+	Name       string `validate:"required,max=255"`
+	AdminEmail string `validate:"required,email,max=255"`
+}
+
+func NewOnboardTenantCommand(tenantID string, name string, adminEmail string) (*OnboardTenantCommand, error) {
+	command := &OnboardTenantCommand{
+		TenantID:   tenantID,
+		Name:       name,
+		AdminEmail: adminEmail,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
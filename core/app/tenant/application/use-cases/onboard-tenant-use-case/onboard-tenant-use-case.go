@@ -0,0 +1,191 @@
+package onboard_tenant_use_case
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	devSandboxEntities "github.com/nahualventure/class-backend/core/app/devsandbox/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/saga"
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/entities"
+	tenantErrors "github.com/nahualventure/class-backend/core/app/tenant/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+// SagaName is the saga onboarding tenants
+const SagaName = "tenant-onboarding"
+
+type OnboardTenantUseCase struct {
+	coordinator *saga.Coordinator
+	tenants     ports.TenantRepository
+	invitations ports.TenantInvitationRepository
+	policies    ports.TenantPolicies
+	// configured are the tenants of the configuration, they cannot be onboarded
+	configured []string
+	clock      clock.Clock
+}
+
+// NewOnboardTenantUseCase registers the onboarding saga, it must be built before the coordinator
+// resumes unfinished sagas
+func NewOnboardTenantUseCase(
+	coordinator *saga.Coordinator,
+	tenants ports.TenantRepository,
+	invitations ports.TenantInvitationRepository,
+	policies ports.TenantPolicies,
+	configured []string,
+) *OnboardTenantUseCase {
+	uc := &OnboardTenantUseCase{
+		coordinator: coordinator,
+		tenants:     tenants,
+		invitations: invitations,
+		policies:    policies,
+		configured:  configured,
+		clock:       clock.System,
+	}
+
+	coordinator.Register(saga.Definition{Name: SagaName, Steps: []saga.Step{
+		{Name: "create_tenant", Execute: uc.createTenant, Compensate: uc.deleteTenant},
+		{Name: "seed_roles", Execute: uc.seedRoles, Compensate: uc.removeRoles},
+		{Name: "invite_admin", Execute: uc.inviteAdmin, Compensate: uc.revokeInvitation},
+		{Name: "activate_tenant", Execute: uc.activateTenant},
+	}})
+
+	return uc
+}
+
+// WithClock changes the clock the saga records times with
+func (uc *OnboardTenantUseCase) WithClock(clock clock.Clock) *OnboardTenantUseCase {
+	uc.clock = clock
+	return uc
+}
+
+// Execute creates the tenant, makes the roles of the policy file apply to it and invites its admin.
+// When a step fails the ones before are undone, the tenant is only active once every step is done.
+func (uc *OnboardTenantUseCase) Execute(ctx context.Context, cmd *OnboardTenantCommand) (*entities.Tenant, error) {
+	if slices.Contains(uc.configured, cmd.TenantID) {
+		return nil, tenantErrors.NewTenantAlreadyExistsError(cmd.TenantID)
+	}
+	// Schools never share a tenant ID with developer sandboxes, which are reset on demand
+	if devSandboxEntities.IsDeveloperTenant(cmd.TenantID) {
+		return nil, errors.NewValidationError("Tenant ID not valid", map[string]any{
+			"tenant_id": "tenant IDs starting with " + devSandboxEntities.TenantPrefix + " are kept for developer sandboxes",
+		}, nil)
+	}
+	existing, err := uc.tenants.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if existing != nil {
+		return nil, tenantErrors.NewTenantAlreadyExistsError(cmd.TenantID)
+	}
+
+	// The invitation ID is chosen up front so inviting again on resume writes nothing. created_at
+	// tells the tenant this saga created from one created concurrently with the same ID.
+	data := map[string]any{
+		"tenant_id":     cmd.TenantID,
+		"name":          cmd.Name,
+		"admin_email":   cmd.AdminEmail,
+		"created_at":    uc.clock.Now().UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		"invitation_id": uuid.NewString(),
+	}
+
+	if _, err := uc.coordinator.Start(ctx, SagaName, data); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	tenant, err := uc.tenants.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return tenant, nil
+}
+
+func (uc *OnboardTenantUseCase) createTenant(ctx context.Context, data map[string]any) error {
+	tenant, err := entities.NewTenant(stringFromData(data, "tenant_id"), stringFromData(data, "name"),
+		stringFromData(data, "admin_email"), timeFromData(data, "created_at"))
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+
+	created, err := uc.tenants.Create(ctx, tenant)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if created {
+		return nil
+	}
+
+	// Run again after a crash the tenant is the one this saga created
+	existing, err := uc.tenants.Find(ctx, tenant.ID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if existing == nil || !existing.CreatedAt.Equal(tenant.CreatedAt) || existing.AdminEmail != tenant.AdminEmail {
+		return tenantErrors.NewTenantAlreadyExistsError(tenant.ID)
+	}
+	return nil
+}
+
+func (uc *OnboardTenantUseCase) deleteTenant(ctx context.Context, data map[string]any) error {
+	return uc.tenants.Delete(ctx, stringFromData(data, "tenant_id"))
+}
+
+func (uc *OnboardTenantUseCase) seedRoles(ctx context.Context, data map[string]any) error {
+	if err := uc.tenants.SetRolesSeeded(ctx, stringFromData(data, "tenant_id"), true); err != nil {
+		return errors.PropagateError(err)
+	}
+	return uc.syncRoles(ctx)
+}
+
+func (uc *OnboardTenantUseCase) removeRoles(ctx context.Context, data map[string]any) error {
+	tenantID := stringFromData(data, "tenant_id")
+	if err := uc.policies.RemoveRoles(ctx, tenantID); err != nil {
+		return errors.PropagateError(err)
+	}
+	if err := uc.tenants.SetRolesSeeded(ctx, tenantID, false); err != nil {
+		return errors.PropagateError(err)
+	}
+	return uc.syncRoles(ctx)
+}
+
+// syncRoles applies the change on this instance right away, the others pick it up on their next sync
+func (uc *OnboardTenantUseCase) syncRoles(ctx context.Context) error {
+	tenantIDs, err := uc.tenants.ListSeededIDs(ctx)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	return uc.policies.Activate(ctx, tenantIDs)
+}
+
+func (uc *OnboardTenantUseCase) inviteAdmin(ctx context.Context, data map[string]any) error {
+	return uc.invitations.Create(ctx, entities.NewTenantInvitation(
+		stringFromData(data, "invitation_id"),
+		stringFromData(data, "tenant_id"),
+		stringFromData(data, "admin_email"),
+		entities.AdminRole,
+		uc.clock.Now().UTC(),
+	))
+}
+
+func (uc *OnboardTenantUseCase) revokeInvitation(ctx context.Context, data map[string]any) error {
+	return uc.invitations.Revoke(ctx, stringFromData(data, "invitation_id"), uc.clock.Now().UTC())
+}
+
+func (uc *OnboardTenantUseCase) activateTenant(ctx context.Context, data map[string]any) error {
+	return uc.tenants.Activate(ctx, stringFromData(data, "tenant_id"), uc.clock.Now().UTC())
+}
+
+// The saga data went through JSON, strings keep their type
+func stringFromData(data map[string]any, key string) string {
+	value, _ := data[key].(string)
+	return value
+}
+
+func timeFromData(data map[string]any, key string) time.Time {
+	value, _ := time.Parse(time.RFC3339Nano, stringFromData(data, key))
+	return value
+}
@@ -0,0 +1,33 @@
+package sync_tenant_roles_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/ports"
+)
+
+type SyncTenantRolesUseCase struct {
+	tenants  ports.TenantRepository
+	policies ports.TenantPolicies
+}
+
+func NewSyncTenantRolesUseCase(tenants ports.TenantRepository, policies ports.TenantPolicies) *SyncTenantRolesUseCase {
+	return &SyncTenantRolesUseCase{
+		tenants:  tenants,
+		policies: policies,
+	}
+}
+
+// Execute makes the roles apply to the onboarded tenants whose roles were seeded and to no other.
+// Every instance runs it, onboarding runs it right away on the instance it runs on.
+func (uc *SyncTenantRolesUseCase) Execute(ctx context.Context) error {
+	tenantIDs, err := uc.tenants.ListSeededIDs(ctx)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if err := uc.policies.Activate(ctx, tenantIDs); err != nil {
+		return errors.PropagateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// InvitationLifetime is how long an invitation can be accepted
+const InvitationLifetime = 7 * 24 * time.Hour
+
+// AdminRole is the role onboarding invites the admin of the tenant to
+const AdminRole = "admin"
+
+// TenantInvitation grants Role in the tenant to the user with Email. Senders deliver its ID, which
+// the user accepts it with.
+type TenantInvitation struct {
+	ID         string
+	TenantID   string
+	Email      string
+	Role       string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	AcceptedBy string
+	AcceptedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func NewTenantInvitation(id string, tenantID string, email string, role string, now time.Time) *TenantInvitation {
+	return &TenantInvitation{
+		ID:        id,
+		TenantID:  tenantID,
+		Email:     email,
+		Role:      role,
+		ExpiresAt: now.Add(InvitationLifetime),
+		CreatedAt: now,
+	}
+}
+
+// IsOpen reports whether the invitation was neither accepted nor revoked
+func (i *TenantInvitation) IsOpen() bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil
+}
+
+// IsExpired reports whether the invitation can no longer be accepted at now
+func (i *TenantInvitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// IsFor reports whether email is the one invited, emails are compared case insensitively
+func (i *TenantInvitation) IsFor(email string) bool {
+	return strings.EqualFold(i.Email, email)
+}
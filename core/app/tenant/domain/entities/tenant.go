@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type TenantStatus string
+
+const (
+	// TenantStatusProvisioning tenants are being onboarded, their admin was not invited yet
+	TenantStatusProvisioning TenantStatus = "provisioning"
+	TenantStatusActive       TenantStatus = "active"
+)
+
+// Tenant is a school onboarded at runtime, the tenants of the configuration have no row. The roles
+// of the policy file apply to it once RolesSeeded.
+type Tenant struct {
+	ID          string       `validate:"required,max=100,excludesall=/ "`
+	Name        string       `validate:"required,max=255"`
+	AdminEmail  string       `validate:"required,email,max=255"`
+	Status      TenantStatus `validate:"required,oneof=provisioning active"`
+	RolesSeeded bool
+	CreatedAt   time.Time `validate:"required"`
+	ActivatedAt *time.Time
+}
+
+func NewTenant(id string, name string, adminEmail string, now time.Time) (*Tenant, error) {
+	tenant := &Tenant{
+		ID:         id,
+		Name:       name,
+		AdminEmail: adminEmail,
+		Status:     TenantStatusProvisioning,
+		CreatedAt:  now,
+	}
+
+	if err := validate.Struct(tenant); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("Tenant domain model instance not valid", errorMap, err)
+	}
+
+	return tenant, nil
+}
@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// TenantAlreadyExistsError is returned when onboarding a tenant of the configuration or one
+	// onboarded before
+	TenantAlreadyExistsError errors2.ErrorCode = "TENANT_ALREADY_EXISTS"
+	// TenantInvitationNotFoundError is also returned for invitations of another tenant or email and
+	// the ones accepted or revoked already
+	TenantInvitationNotFoundError errors2.ErrorCode = "TENANT_INVITATION_NOT_FOUND"
+	TenantInvitationExpiredError  errors2.ErrorCode = "TENANT_INVITATION_EXPIRED"
+)
+
+func NewTenantAlreadyExistsError(tenantID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TenantAlreadyExistsError.String(),
+			Message: "A tenant with this ID already exists",
+			Context: map[string]any{
+				"tenant_id": tenantID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TenantAlreadyExistsError.String()),
+		},
+	}
+}
+
+func NewTenantInvitationNotFoundError(invitationID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TenantInvitationNotFoundError.String(),
+			Message: "Tenant invitation not found",
+			Context: map[string]any{
+				"invitation_id": invitationID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TenantInvitationNotFoundError.String()),
+		},
+	}
+}
+
+func NewTenantInvitationExpiredError(invitationID string, expiredAt time.Time) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TenantInvitationExpiredError.String(),
+			Message: "The tenant invitation expired",
+			Context: map[string]any{
+				"invitation_id": invitationID,
+				"expired_at":    expiredAt,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TenantInvitationExpiredError.String()),
+		},
+	}
+}
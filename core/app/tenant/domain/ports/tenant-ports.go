@@ -0,0 +1,42 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/entities"
+)
+
+type TenantRepository interface {
+	// Create returns false without writing when a tenant with the ID exists
+	Create(ctx context.Context, tenant *entities.Tenant) (bool, error)
+	// Find returns nil when the tenant does not exist
+	Find(ctx context.Context, tenantID string) (*entities.Tenant, error)
+	// Delete removes the tenant and its invitations
+	Delete(ctx context.Context, tenantID string) error
+	SetRolesSeeded(ctx context.Context, tenantID string, seeded bool) error
+	Activate(ctx context.Context, tenantID string, at time.Time) error
+	// ListSeededIDs returns the IDs of the tenants the roles apply to
+	ListSeededIDs(ctx context.Context) ([]string, error)
+}
+
+type TenantInvitationRepository interface {
+	// Create records the invitation and queues it to the outbox for senders to deliver. Nothing is
+	// written when the invitation exists.
+	Create(ctx context.Context, invitation *entities.TenantInvitation) error
+	// Find returns nil when the invitation does not exist
+	Find(ctx context.Context, invitationID string) (*entities.TenantInvitation, error)
+	// Accept returns false when the invitation was accepted or revoked in the meantime
+	Accept(ctx context.Context, invitationID string, userID string, at time.Time) (bool, error)
+	Revoke(ctx context.Context, invitationID string, at time.Time) error
+}
+
+// TenantPolicies makes the roles of the policy file apply to onboarded tenants
+type TenantPolicies interface {
+	// Activate makes the roles apply to tenantIDs, and to no other onboarded tenant
+	Activate(ctx context.Context, tenantIDs []string) error
+	// AssignRole gives role in tenantID to userID
+	AssignRole(ctx context.Context, userID string, role string, tenantID string) error
+	// RemoveRoles removes every role assignment of tenantID
+	RemoveRoles(ctx context.Context, tenantID string) error
+}
@@ -0,0 +1,32 @@
+package get_time_zone_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/timezone/domain/ports"
+)
+
+type GetTimeZoneUseCase struct {
+	timeZoneRepo ports.TimeZoneRepository
+}
+
+func NewGetTimeZoneUseCase(timeZoneRepo ports.TimeZoneRepository) *GetTimeZoneUseCase {
+	return &GetTimeZoneUseCase{
+		timeZoneRepo: timeZoneRepo,
+	}
+}
+
+func (uc *GetTimeZoneUseCase) Execute(ctx context.Context, cmd *GetTimeZoneCommand) (*entities.TimeZonePreferences, error) {
+	tenant, err := uc.timeZoneRepo.FindSetting(ctx, cmd.TenantID, "")
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	user, err := uc.timeZoneRepo.FindSetting(ctx, cmd.TenantID, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return &entities.TimeZonePreferences{Tenant: tenant, User: user}, nil
+}
@@ -0,0 +1,31 @@
+package set_time_zone_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// SetTimeZoneCommand sets the zone of a user, or the default of the tenant when UserID is empty
+type SetTimeZoneCommand struct {
+	TenantID string `validate:"required"`
+	UserID   string
+	TimeZone string `validate:"required,timezone"`
+}
+
+func NewSetTimeZoneCommand(tenantID string, userID string, timeZone string) (*SetTimeZoneCommand, error) {
+	command := &SetTimeZoneCommand{
+		TenantID: tenantID,
+		UserID:   userID,
+		TimeZone: timeZone,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,33 @@
+package set_time_zone_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/timezone/domain/ports"
+)
+
+type SetTimeZoneUseCase struct {
+	timeZoneRepo ports.TimeZoneRepository
+}
+
+func NewSetTimeZoneUseCase(timeZoneRepo ports.TimeZoneRepository) *SetTimeZoneUseCase {
+	return &SetTimeZoneUseCase{
+		timeZoneRepo: timeZoneRepo,
+	}
+}
+
+func (uc *SetTimeZoneUseCase) Execute(ctx context.Context, cmd *SetTimeZoneCommand) (*entities.TimeZoneSetting, error) {
+	setting, err := entities.NewTimeZoneSetting(cmd.TenantID, cmd.UserID, cmd.TimeZone, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.timeZoneRepo.Save(ctx, setting); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return setting, nil
+}
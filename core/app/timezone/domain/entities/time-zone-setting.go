@@ -0,0 +1,63 @@
+package entities
+
+import (
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// DefaultTimeZone applies to tenants that did not pick one
+const DefaultTimeZone = "UTC"
+
+// TimeZoneSetting is an IANA zone picked by a user or, when UserID is empty, the default of the tenant
+type TimeZoneSetting struct {
+	TenantID  string    `validate:"required"`
+	UserID    string    `validate:"max=255"`
+	TimeZone  string    `validate:"required,timezone,max=64"`
+	UpdatedAt time.Time `validate:"required"`
+}
+
+func NewTimeZoneSetting(tenantID string, userID string, timeZone string, updatedAt time.Time) (*TimeZoneSetting, error) {
+	setting := &TimeZoneSetting{
+		TenantID:  tenantID,
+		UserID:    userID,
+		TimeZone:  timeZone,
+		UpdatedAt: updatedAt,
+	}
+
+	if err := validate.Struct(setting); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+
+		return nil, appErrors.NewDomainEntityValidationError("TimeZoneSetting domain model instance not valid", errorMap, err)
+	}
+
+	return setting, nil
+}
+
+// TimeZonePreferences are the settings that decide the zone a user sees times in, either may be nil
+type TimeZonePreferences struct {
+	Tenant *TimeZoneSetting
+	User   *TimeZoneSetting
+}
+
+// TenantZone is the zone of the school, class schedules are kept in its wall clock
+func (p TimeZonePreferences) TenantZone() string {
+	if p.Tenant != nil {
+		return p.Tenant.TimeZone
+	}
+	return DefaultTimeZone
+}
+
+// Effective is the zone of the user when they picked one, the zone of the tenant otherwise
+func (p TimeZonePreferences) Effective() string {
+	if p.User != nil {
+		return p.User.TimeZone
+	}
+	return p.TenantZone()
+}
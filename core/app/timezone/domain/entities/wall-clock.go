@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"time"
+)
+
+// WallClock returns the instant a local date and time of day happen at in the zone. Times skipped
+// when clocks spring forward are moved past the gap, 02:30 becomes 03:30, and times repeated when
+// clocks fall back resolve to their first occurrence.
+func WallClock(year int, month time.Month, day int, minuteOfDay int, loc *time.Location) time.Time {
+	wall := time.Date(year, month, day, 0, minuteOfDay, 0, 0, time.UTC)
+
+	// The offsets in force a day either side cover any transition on the date
+	_, offsetBefore := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, offsetAfter := wall.Add(24 * time.Hour).In(loc).Zone()
+
+	var first *time.Time
+	for _, offset := range []int{offsetBefore, offsetAfter} {
+		instant := wall.Add(-time.Duration(offset) * time.Second)
+		local := instant.In(loc)
+		if !time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), 0, 0, time.UTC).Equal(wall) {
+			continue
+		}
+		if first == nil || instant.Before(*first) {
+			first = &instant
+		}
+	}
+	if first != nil {
+		return first.In(loc)
+	}
+
+	// Inside a gap, the offset before it lands past the gap
+	return wall.Add(-time.Duration(offsetBefore) * time.Second).In(loc)
+}
+
+// NextWeekly returns the first instant after the given one that falls on the weekday at the time of
+// day in the zone. Weekly times keep their wall clock across transitions, not their UTC time.
+func NextWeekly(weekday time.Weekday, minuteOfDay int, after time.Time, loc *time.Location) time.Time {
+	local := after.In(loc)
+	days := (int(weekday) - int(local.Weekday()) + 7) % 7
+	for {
+		date := local.AddDate(0, 0, days)
+		next := WallClock(date.Year(), date.Month(), date.Day(), minuteOfDay, loc)
+		if next.After(after) {
+			return next
+		}
+		days += 7
+	}
+}
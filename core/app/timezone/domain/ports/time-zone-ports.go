@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+)
+
+type TimeZoneRepository interface {
+	// FindSetting returns nil when the user, or the tenant for an empty user ID, did not pick a zone
+	FindSetting(ctx context.Context, tenantID string, userID string) (*entities.TimeZoneSetting, error)
+	// Save creates or replaces the setting
+	Save(ctx context.Context, setting *entities.TimeZoneSetting) error
+}
@@ -0,0 +1,36 @@
+package create_term_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CreateTermCommand struct {
+	TenantID  string    `validate:"required"`
+	Name      string    `validate:"required,max=100"`
+	StartsOn  time.Time `validate:"required"`
+	EndsOn    time.Time `validate:"required"`
+	CreatedBy string    `validate:"required"`
+}
+
+func NewCreateTermCommand(tenantID string, name string, startsOn time.Time, endsOn time.Time, createdBy string) (*CreateTermCommand, error) {
+	command := &CreateTermCommand{
+		TenantID:  tenantID,
+		Name:      name,
+		StartsOn:  startsOn,
+		EndsOn:    endsOn,
+		CreatedBy: createdBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,48 @@
+package create_term_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	transcriptErrors "github.com/nahualventure/class-backend/core/app/transcript/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+type CreateTermUseCase struct {
+	termRepo ports.TermRepository
+}
+
+func NewCreateTermUseCase(termRepo ports.TermRepository) *CreateTermUseCase {
+	return &CreateTermUseCase{
+		termRepo: termRepo,
+	}
+}
+
+// Execute refuses terms sharing days with another term of the tenant, so every grade falls in one
+// term at most
+func (uc *CreateTermUseCase) Execute(ctx context.Context, cmd *CreateTermCommand) (*entities.Term, error) {
+	term, err := entities.NewTerm(uuid.New().String(), cmd.TenantID, cmd.Name, cmd.StartsOn, cmd.EndsOn, cmd.CreatedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	terms, err := uc.termRepo.List(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	for _, other := range terms {
+		if term.Overlaps(other) {
+			return nil, transcriptErrors.NewTermOverlapError(other.ID, other.Name)
+		}
+	}
+
+	if err := uc.termRepo.Create(ctx, term); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return term, nil
+}
@@ -0,0 +1,31 @@
+package download_report_card_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// DownloadReportCardCommand carries the parts of a download link, no user or tenant is needed
+type DownloadReportCardCommand struct {
+	ReportCardID string `validate:"required,uuid"`
+	Expires      int64  `validate:"required"`
+	Signature    string `validate:"required,max=100"`
+}
+
+func NewDownloadReportCardCommand(reportCardID string, expires int64, signature string) (*DownloadReportCardCommand, error) {
+	command := &DownloadReportCardCommand{
+		ReportCardID: reportCardID,
+		Expires:      expires,
+		Signature:    signature,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
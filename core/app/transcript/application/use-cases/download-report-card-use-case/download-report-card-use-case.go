@@ -0,0 +1,54 @@
+package download_report_card_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	transcriptErrors "github.com/nahualventure/class-backend/core/app/transcript/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+)
+
+type DownloadReportCardUseCase struct {
+	reportCardRepo ports.ReportCardRepository
+}
+
+func NewDownloadReportCardUseCase(reportCardRepo ports.ReportCardRepository) *DownloadReportCardUseCase {
+	return &DownloadReportCardUseCase{
+		reportCardRepo: reportCardRepo,
+	}
+}
+
+// Execute returns the file of a ready, unexpired report card to whoever holds a valid link. Unknown
+// report cards and wrong signatures get the same error, so links cannot be guessed.
+func (uc *DownloadReportCardUseCase) Execute(ctx context.Context, cmd *DownloadReportCardCommand) (*entities.Artifact, error) {
+	reportCard, err := uc.reportCardRepo.FindForDownload(ctx, cmd.ReportCardID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	now := time.Now()
+	if reportCard == nil || !reportCard.VerifyLink(cmd.Expires, cmd.Signature) || !now.Before(time.Unix(cmd.Expires, 0)) {
+		return nil, transcriptErrors.NewInvalidDownloadLinkError(cmd.ReportCardID)
+	}
+
+	if reportCard.Status != entities.ReportCardStatusReady {
+		return nil, transcriptErrors.NewReportCardNotReadyError(reportCard.ID, string(reportCard.Status))
+	}
+
+	if reportCard.IsExpired(now) {
+		return nil, transcriptErrors.NewReportCardExpiredError(reportCard.ID, *reportCard.ExpiresAt)
+	}
+
+	artifact, err := uc.reportCardRepo.FindArtifact(ctx, reportCard.TenantID, reportCard.ID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if artifact == nil {
+		return nil, transcriptErrors.NewReportCardNotFoundError(reportCard.ID)
+	}
+
+	return artifact, nil
+}
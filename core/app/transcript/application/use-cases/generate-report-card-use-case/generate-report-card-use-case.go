@@ -0,0 +1,142 @@
+package generate_report_card_use_case
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+)
+
+const (
+	// DefaultArtifactTTL is how long report cards can be downloaded, guardians get weekly links to them
+	DefaultArtifactTTL = 30 * 24 * time.Hour
+	// StaleAfter is how long a report card can stay running before another worker reclaims it
+	StaleAfter = 15 * time.Minute
+)
+
+type GenerateReportCardUseCase struct {
+	queue        ports.ReportCardJobQueue
+	termRepo     ports.TermRepository
+	templateRepo ports.ReportCardTemplateRepository
+	source       ports.TranscriptSource
+	renderer     ports.ReportCardRenderer
+	artifactTTL  time.Duration
+}
+
+func NewGenerateReportCardUseCase(queue ports.ReportCardJobQueue, termRepo ports.TermRepository, templateRepo ports.ReportCardTemplateRepository,
+	source ports.TranscriptSource, renderer ports.ReportCardRenderer, artifactTTL time.Duration) *GenerateReportCardUseCase {
+	return &GenerateReportCardUseCase{
+		queue:        queue,
+		termRepo:     termRepo,
+		templateRepo: templateRepo,
+		source:       source,
+		renderer:     renderer,
+		artifactTTL:  artifactTTL,
+	}
+}
+
+// Execute generates the next pending report card and sends its link to the guardians of the student
+// when asked to. It returns false when the queue is empty. A report card that fails to generate is
+// marked failed and its error is returned for logging.
+func (uc *GenerateReportCardUseCase) Execute(ctx context.Context) (bool, error) {
+	reportCard, err := uc.queue.ClaimNextPending(ctx, StaleAfter)
+	if err != nil {
+		return false, errors.PropagateError(err)
+	}
+
+	if reportCard == nil {
+		return false, nil
+	}
+
+	now := time.Now().UTC()
+	artifact, deliveries, err := uc.generate(ctx, reportCard, now)
+	if err != nil {
+		if markErr := uc.queue.MarkFailed(ctx, reportCard.ID, err.Error()); markErr != nil {
+			return true, errors.PropagateError(markErr)
+		}
+		return true, errors.NewInfrastructureError(fmt.Sprintf("failed to generate report card %s", reportCard.ID), err)
+	}
+
+	if err := uc.queue.MarkReady(ctx, reportCard, artifact, deliveries); err != nil {
+		return true, errors.PropagateError(err)
+	}
+
+	return true, nil
+}
+
+// PurgeExpired deletes report cards whose file expired and returns how many were deleted
+func (uc *GenerateReportCardUseCase) PurgeExpired(ctx context.Context) (int64, error) {
+	deleted, err := uc.queue.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, errors.PropagateError(err)
+	}
+
+	return deleted, nil
+}
+
+// generate renders the report card and marks it ready with its expiry, so the links of the
+// deliveries are signed as downloads will check them
+func (uc *GenerateReportCardUseCase) generate(ctx context.Context, reportCard *entities.ReportCard,
+	now time.Time) (*entities.Artifact, []*entities.ReportCardDelivery, error) {
+	term, err := uc.termRepo.FindByID(ctx, reportCard.TenantID, reportCard.TermID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if term == nil {
+		return nil, nil, fmt.Errorf("term %s not found", reportCard.TermID)
+	}
+
+	terms, err := uc.termRepo.List(ctx, reportCard.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	grades, err := uc.source.ReleasedGrades(ctx, reportCard.TenantID, reportCard.StudentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	record := entities.NewTranscript(reportCard.TenantID, reportCard.StudentID, terms, grades).Term(term)
+
+	template, err := uc.templateRepo.Find(ctx, reportCard.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if template == nil {
+		template = entities.DefaultReportCardTemplate(reportCard.TenantID)
+	}
+	studentName, err := uc.source.StudentName(ctx, reportCard.StudentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if studentName == "" {
+		studentName = reportCard.StudentID
+	}
+
+	document, err := template.Render(reportCard.StudentID, studentName, record, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := uc.renderer.Render(ctx, document)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	expiresAt := now.Add(uc.artifactTTL)
+	reportCard.Status = entities.ReportCardStatusReady
+	reportCard.ExpiresAt = &expiresAt
+
+	var guardianIDs []string
+	if reportCard.NotifyGuardians {
+		if guardianIDs, err = uc.source.GuardiansOf(ctx, reportCard.TenantID, reportCard.StudentID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return &entities.Artifact{
+		FileName:    fmt.Sprintf("report-card-%s-%s.pdf", term.StartsOn.Format("20060102"), reportCard.ID[:8]),
+		ContentType: "application/pdf",
+		Content:     content,
+	}, reportCard.Deliveries(guardianIDs, term, reportCard.DownloadLink(now)), nil
+}
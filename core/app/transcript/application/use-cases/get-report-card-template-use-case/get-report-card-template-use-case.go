@@ -0,0 +1,33 @@
+package get_report_card_template_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+)
+
+type GetReportCardTemplateUseCase struct {
+	templateRepo ports.ReportCardTemplateRepository
+}
+
+func NewGetReportCardTemplateUseCase(templateRepo ports.ReportCardTemplateRepository) *GetReportCardTemplateUseCase {
+	return &GetReportCardTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute returns the template of the tenant, the default one when it never saved its own
+func (uc *GetReportCardTemplateUseCase) Execute(ctx context.Context, cmd *GetReportCardTemplateCommand) (*entities.ReportCardTemplate, error) {
+	template, err := uc.templateRepo.Find(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if template == nil {
+		return entities.DefaultReportCardTemplate(cmd.TenantID), nil
+	}
+
+	return template, nil
+}
@@ -0,0 +1,28 @@
+package get_report_card_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetReportCardCommand struct {
+	TenantID     string `validate:"required"`
+	ReportCardID string `validate:"required,uuid"`
+}
+
+func NewGetReportCardCommand(tenantID string, reportCardID string) (*GetReportCardCommand, error) {
+	command := &GetReportCardCommand{
+		TenantID:     tenantID,
+		ReportCardID: reportCardID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
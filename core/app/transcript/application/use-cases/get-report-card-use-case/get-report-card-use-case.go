@@ -0,0 +1,34 @@
+package get_report_card_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	transcriptErrors "github.com/nahualventure/class-backend/core/app/transcript/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+)
+
+type GetReportCardUseCase struct {
+	reportCardRepo ports.ReportCardRepository
+}
+
+func NewGetReportCardUseCase(reportCardRepo ports.ReportCardRepository) *GetReportCardUseCase {
+	return &GetReportCardUseCase{
+		reportCardRepo: reportCardRepo,
+	}
+}
+
+// Execute returns the report card, its DownloadLink signs a fresh link once it is ready
+func (uc *GetReportCardUseCase) Execute(ctx context.Context, cmd *GetReportCardCommand) (*entities.ReportCard, error) {
+	reportCard, err := uc.reportCardRepo.FindByID(ctx, cmd.TenantID, cmd.ReportCardID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if reportCard == nil {
+		return nil, transcriptErrors.NewReportCardNotFoundError(cmd.ReportCardID)
+	}
+
+	return reportCard, nil
+}
@@ -0,0 +1,28 @@
+package get_transcript_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetTranscriptCommand struct {
+	TenantID  string `validate:"required"`
+	StudentID string `validate:"required"`
+}
+
+func NewGetTranscriptCommand(tenantID string, studentID string) (*GetTranscriptCommand, error) {
+	command := &GetTranscriptCommand{
+		TenantID:  tenantID,
+		StudentID: studentID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,37 @@
+package get_transcript_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+)
+
+type GetTranscriptUseCase struct {
+	termRepo ports.TermRepository
+	source   ports.TranscriptSource
+}
+
+func NewGetTranscriptUseCase(termRepo ports.TermRepository, source ports.TranscriptSource) *GetTranscriptUseCase {
+	return &GetTranscriptUseCase{
+		termRepo: termRepo,
+		source:   source,
+	}
+}
+
+// Execute aggregates the released grades of the student by term, students without grades get an
+// empty transcript
+func (uc *GetTranscriptUseCase) Execute(ctx context.Context, cmd *GetTranscriptCommand) (*entities.Transcript, error) {
+	terms, err := uc.termRepo.List(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	grades, err := uc.source.ReleasedGrades(ctx, cmd.TenantID, cmd.StudentID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return entities.NewTranscript(cmd.TenantID, cmd.StudentID, terms, grades), nil
+}
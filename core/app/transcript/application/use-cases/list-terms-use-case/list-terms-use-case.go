@@ -0,0 +1,29 @@
+package list_terms_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+)
+
+type ListTermsUseCase struct {
+	termRepo ports.TermRepository
+}
+
+func NewListTermsUseCase(termRepo ports.TermRepository) *ListTermsUseCase {
+	return &ListTermsUseCase{
+		termRepo: termRepo,
+	}
+}
+
+// Execute returns the terms of the tenant, oldest first
+func (uc *ListTermsUseCase) Execute(ctx context.Context, cmd *ListTermsCommand) ([]*entities.Term, error) {
+	terms, err := uc.termRepo.List(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return terms, nil
+}
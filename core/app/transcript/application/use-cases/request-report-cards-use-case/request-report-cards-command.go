@@ -0,0 +1,37 @@
+package request_report_cards_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RequestReportCardsCommand struct {
+	TenantID   string   `validate:"required"`
+	TermID     string   `validate:"required,uuid"`
+	StudentIDs []string `validate:"required,min=1,max=500,unique,dive,required"`
+	// NotifyGuardians sends the download link to the guardians of every student once their report
+	// card is ready
+	NotifyGuardians bool
+	RequestedBy     string `validate:"required"`
+}
+
+func NewRequestReportCardsCommand(tenantID string, termID string, studentIDs []string, notifyGuardians bool,
+	requestedBy string) (*RequestReportCardsCommand, error) {
+	command := &RequestReportCardsCommand{
+		TenantID:        tenantID,
+		TermID:          termID,
+		StudentIDs:      studentIDs,
+		NotifyGuardians: notifyGuardians,
+		RequestedBy:     requestedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
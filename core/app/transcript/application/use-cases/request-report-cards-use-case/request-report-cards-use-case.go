@@ -0,0 +1,59 @@
+package request_report_cards_use_case
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	transcriptErrors "github.com/nahualventure/class-backend/core/app/transcript/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+type RequestReportCardsUseCase struct {
+	termRepo       ports.TermRepository
+	reportCardRepo ports.ReportCardRepository
+}
+
+func NewRequestReportCardsUseCase(termRepo ports.TermRepository, reportCardRepo ports.ReportCardRepository) *RequestReportCardsUseCase {
+	return &RequestReportCardsUseCase{
+		termRepo:       termRepo,
+		reportCardRepo: reportCardRepo,
+	}
+}
+
+// Execute queues a report card per student, the worker generates them asynchronously
+func (uc *RequestReportCardsUseCase) Execute(ctx context.Context, cmd *RequestReportCardsCommand) ([]*entities.ReportCard, error) {
+	term, err := uc.termRepo.FindByID(ctx, cmd.TenantID, cmd.TermID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if term == nil {
+		return nil, transcriptErrors.NewTermNotFoundError(cmd.TermID)
+	}
+
+	now := time.Now().UTC()
+	reportCards := make([]*entities.ReportCard, 0, len(cmd.StudentIDs))
+	for _, studentID := range cmd.StudentIDs {
+		secret := make([]byte, entities.SecretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, errors.NewInfrastructureError("failed to generate report card secret", err)
+		}
+
+		reportCard, err := entities.NewReportCard(uuid.New().String(), cmd.TenantID, studentID, term.ID, entities.ReportCardStatusPending, "",
+			cmd.NotifyGuardians, secret, cmd.RequestedBy, nil, now, now)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		reportCards = append(reportCards, reportCard)
+	}
+
+	if err := uc.reportCardRepo.Create(ctx, reportCards); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return reportCards, nil
+}
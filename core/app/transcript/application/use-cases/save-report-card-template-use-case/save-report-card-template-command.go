@@ -0,0 +1,34 @@
+package save_report_card_template_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type SaveReportCardTemplateCommand struct {
+	TenantID  string `validate:"required"`
+	Title     string `validate:"required,max=255"`
+	Header    string `validate:"max=2000"`
+	Footer    string `validate:"max=2000"`
+	UpdatedBy string `validate:"required"`
+}
+
+func NewSaveReportCardTemplateCommand(tenantID string, title string, header string, footer string, updatedBy string) (*SaveReportCardTemplateCommand, error) {
+	command := &SaveReportCardTemplateCommand{
+		TenantID:  tenantID,
+		Title:     title,
+		Header:    header,
+		Footer:    footer,
+		UpdatedBy: updatedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,34 @@
+package save_report_card_template_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+)
+
+type SaveReportCardTemplateUseCase struct {
+	templateRepo ports.ReportCardTemplateRepository
+}
+
+func NewSaveReportCardTemplateUseCase(templateRepo ports.ReportCardTemplateRepository) *SaveReportCardTemplateUseCase {
+	return &SaveReportCardTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute replaces the template of the tenant, report cards generated from then on use it
+func (uc *SaveReportCardTemplateUseCase) Execute(ctx context.Context, cmd *SaveReportCardTemplateCommand) (*entities.ReportCardTemplate, error) {
+	template, err := entities.NewReportCardTemplate(cmd.TenantID, cmd.Title, cmd.Header, cmd.Footer, cmd.UpdatedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.templateRepo.Save(ctx, template); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return template, nil
+}
@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	transcriptErrors "github.com/nahualventure/class-backend/core/app/transcript/domain/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// ReportCardTemplate is how the report cards of a tenant read. The title, header and footer are Go
+// templates over the report card variables, the table of classes between them is the same for every
+// tenant.
+type ReportCardTemplate struct {
+	TenantID string `validate:"required"`
+	Title    string `validate:"required,max=255"`
+	Header   string `validate:"max=2000"`
+	Footer   string `validate:"max=2000"`
+	// UpdatedBy is empty for the default, which was never saved
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// DefaultReportCardTemplate returns the template of a tenant that did not customize it
+func DefaultReportCardTemplate(tenantID string) *ReportCardTemplate {
+	return &ReportCardTemplate{
+		TenantID: tenantID,
+		Title:    "Report card - {{.term_name}}",
+		Header:   "Student: {{.student_name}}\nTerm: {{.term_name}} ({{.term_starts_on}} to {{.term_ends_on}})",
+		Footer:   "Term average: {{.average}}\nCumulative average: {{.cumulative_average}}\nIssued on {{.issued_on}}",
+	}
+}
+
+// SampleReportCardVariables are the variables templates are checked with before they are saved
+var SampleReportCardVariables = map[string]any{
+	"student_name":       "Ana Lopez",
+	"student_id":         "student-1",
+	"term_name":          "Fall 2025",
+	"term_starts_on":     "2025-08-18",
+	"term_ends_on":       "2025-12-19",
+	"average":            "87.5",
+	"cumulative_average": "85.25",
+	"issued_on":          "2025-12-22",
+}
+
+// ReportCardVariables returns the names of the variables templates can use, sorted
+func ReportCardVariables() []string {
+	names := make([]string, 0, len(SampleReportCardVariables))
+	for name := range SampleReportCardVariables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewReportCardTemplate checks the template renders with the sample variables, so a saved template
+// never fails once report cards are generated with it
+func NewReportCardTemplate(tenantID string, title string, header string, footer string, updatedBy string, now time.Time) (*ReportCardTemplate, error) {
+	reportCardTemplate := &ReportCardTemplate{
+		TenantID:  tenantID,
+		Title:     title,
+		Header:    header,
+		Footer:    footer,
+		UpdatedBy: updatedBy,
+		UpdatedAt: now,
+	}
+
+	if err := validate.Struct(reportCardTemplate); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("ReportCardTemplate domain model instance not valid", errorMap, err)
+	}
+
+	if _, err := reportCardTemplate.render(SampleReportCardVariables); err != nil {
+		return nil, err
+	}
+
+	return reportCardTemplate, nil
+}
+
+// IsDefault reports whether the tenant never saved the template
+func (t *ReportCardTemplate) IsDefault() bool {
+	return t.UpdatedBy == ""
+}
+
+// ReportCardDocument is a report card ready to be laid out, the renderer puts the classes in a table
+// between the header and the footer
+type ReportCardDocument struct {
+	Title   string
+	Header  string
+	Classes []ClassRecord
+	Footer  string
+}
+
+// Render fills the template with the record of the student for the term
+func (t *ReportCardTemplate) Render(studentID string, studentName string, record *TermRecord, issuedAt time.Time) (*ReportCardDocument, error) {
+	return t.render(map[string]any{
+		"student_name":       studentName,
+		"student_id":         studentID,
+		"term_name":          record.Term.Name,
+		"term_starts_on":     record.Term.StartsOn.Format(time.DateOnly),
+		"term_ends_on":       record.Term.EndsOn.Format(time.DateOnly),
+		"average":            strconv.FormatFloat(record.Average, 'f', -1, 64),
+		"cumulative_average": strconv.FormatFloat(record.CumulativeAverage, 'f', -1, 64),
+		"issued_on":          issuedAt.UTC().Format(time.DateOnly),
+	}, record.Classes...)
+}
+
+func (t *ReportCardTemplate) render(variables map[string]any, classes ...ClassRecord) (*ReportCardDocument, error) {
+	title, err := renderPart("title", t.Title, variables)
+	if err != nil {
+		return nil, err
+	}
+	header, err := renderPart("header", t.Header, variables)
+	if err != nil {
+		return nil, err
+	}
+	footer, err := renderPart("footer", t.Footer, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportCardDocument{
+		Title:   strings.Join(strings.Fields(title), " "),
+		Header:  header,
+		Classes: classes,
+		Footer:  footer,
+	}, nil
+}
+
+// renderPart fails on variables the template uses but report cards do not have, instead of leaving a
+// blank
+func renderPart(part string, source string, variables map[string]any) (string, error) {
+	parsed, err := template.New(part).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", transcriptErrors.NewInvalidReportCardTemplateError(part, err.Error())
+	}
+	var rendered bytes.Buffer
+	if err := parsed.Execute(&rendered, variables); err != nil {
+		return "", transcriptErrors.NewInvalidReportCardTemplateError(part, err.Error())
+	}
+	return rendered.String(), nil
+}
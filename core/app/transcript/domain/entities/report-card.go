@@ -0,0 +1,162 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// SecretSize is the size in bytes of the secret the download links of a report card are signed with
+	SecretSize = 32
+	// LinkTTL is how long a download link works, links never outlive the report card
+	LinkTTL = 7 * 24 * time.Hour
+)
+
+type ReportCardStatus string
+
+const (
+	ReportCardStatusPending ReportCardStatus = "pending"
+	ReportCardStatusRunning ReportCardStatus = "running"
+	ReportCardStatusReady   ReportCardStatus = "ready"
+	ReportCardStatusFailed  ReportCardStatus = "failed"
+)
+
+// ReportCard is the PDF report card of a student for a term, generated asynchronously. It is
+// downloaded through signed links, so guardians without an account in the tenant can get it. The
+// generated file is stored apart and only loaded on download.
+type ReportCard struct {
+	ID            string           `validate:"required,uuid"`
+	TenantID      string           `validate:"required"`
+	StudentID     string           `validate:"required"`
+	TermID        string           `validate:"required,uuid"`
+	Status        ReportCardStatus `validate:"required,oneof=pending running ready failed"`
+	FailureReason string
+	// NotifyGuardians sends a download link to the guardians of the student once it is ready
+	NotifyGuardians bool
+	Secret          []byte `validate:"len=32"`
+	RequestedBy     string `validate:"required"`
+	ExpiresAt       *time.Time
+	CreatedAt       time.Time `validate:"required"`
+	UpdatedAt       time.Time `validate:"required"`
+}
+
+func NewReportCard(id string, tenantID string, studentID string, termID string, status ReportCardStatus, failureReason string,
+	notifyGuardians bool, secret []byte, requestedBy string, expiresAt *time.Time, createdAt time.Time, updatedAt time.Time) (*ReportCard, error) {
+	reportCard := &ReportCard{
+		ID:              id,
+		TenantID:        tenantID,
+		StudentID:       studentID,
+		TermID:          termID,
+		Status:          status,
+		FailureReason:   failureReason,
+		NotifyGuardians: notifyGuardians,
+		Secret:          secret,
+		RequestedBy:     requestedBy,
+		ExpiresAt:       expiresAt,
+		CreatedAt:       createdAt,
+		UpdatedAt:       updatedAt,
+	}
+
+	if err := validate.Struct(reportCard); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("ReportCard domain model instance not valid", errorMap, err)
+	}
+
+	return reportCard, nil
+}
+
+func (c *ReportCard) IsExpired(now time.Time) bool {
+	return c.ExpiresAt != nil && !now.Before(*c.ExpiresAt)
+}
+
+// DownloadLink is the path a report card is downloaded from without signing in, until ExpiresAt
+type DownloadLink struct {
+	Path      string
+	ExpiresAt time.Time
+}
+
+// DownloadLink signs a link working for LinkTTL, nil when the report card cannot be downloaded
+func (c *ReportCard) DownloadLink(now time.Time) *DownloadLink {
+	if c.Status != ReportCardStatusReady || c.IsExpired(now) {
+		return nil
+	}
+
+	expiresAt := now.Add(LinkTTL).Truncate(time.Second)
+	if c.ExpiresAt != nil && c.ExpiresAt.Before(expiresAt) {
+		expiresAt = c.ExpiresAt.Truncate(time.Second)
+	}
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expiresAt.Unix(), 10)},
+		"signature": {c.signature(expiresAt.Unix())},
+	}
+	return &DownloadLink{
+		Path:      fmt.Sprintf("/report-cards/%s/download?%s", c.ID, query.Encode()),
+		ExpiresAt: expiresAt.UTC(),
+	}
+}
+
+// VerifyLink tells whether the signature was made for the report card and expires, whether the link
+// expired or not
+func (c *ReportCard) VerifyLink(expires int64, signature string) bool {
+	return subtle.ConstantTimeCompare([]byte(signature), []byte(c.signature(expires))) == 1
+}
+
+func (c *ReportCard) signature(expires int64) string {
+	mac := hmac.New(sha256.New, c.Secret)
+	mac.Write([]byte(c.ID + "\n" + strconv.FormatInt(expires, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Artifact is the generated report card file
+type Artifact struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// ReportCardDelivery sends the download link of a report card to a guardian of the student
+type ReportCardDelivery struct {
+	ReportCardID string
+	StudentID    string
+	GuardianID   string
+	TermID       string
+	TermName     string
+	Link         *DownloadLink
+}
+
+// Deliveries returns a delivery for every guardian, none unless the guardians are to be notified
+func (c *ReportCard) Deliveries(guardianIDs []string, term *Term, link *DownloadLink) []*ReportCardDelivery {
+	if !c.NotifyGuardians || link == nil {
+		return nil
+	}
+
+	deliveries := make([]*ReportCardDelivery, 0, len(guardianIDs))
+	for _, guardianID := range guardianIDs {
+		deliveries = append(deliveries, &ReportCardDelivery{
+			ReportCardID: c.ID,
+			StudentID:    c.StudentID,
+			GuardianID:   guardianID,
+			TermID:       term.ID,
+			TermName:     term.Name,
+			Link:         link,
+		})
+	}
+	return deliveries
+}
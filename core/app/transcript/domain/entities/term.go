@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// Term is a grading period of a tenant, such as a semester. Days are in UTC and EndsOn is the last
+// day of the term.
+type Term struct {
+	ID        string    `validate:"required,uuid"`
+	TenantID  string    `validate:"required"`
+	Name      string    `validate:"required,max=100"`
+	StartsOn  time.Time `validate:"required"`
+	EndsOn    time.Time `validate:"gtefield=StartsOn"`
+	CreatedBy string    `validate:"required"`
+	CreatedAt time.Time
+}
+
+// NewTerm keeps the day of startsOn and endsOn, the time of day is dropped
+func NewTerm(id string, tenantID string, name string, startsOn time.Time, endsOn time.Time, createdBy string, createdAt time.Time) (*Term, error) {
+	term := &Term{
+		ID:        id,
+		TenantID:  tenantID,
+		Name:      name,
+		StartsOn:  day(startsOn),
+		EndsOn:    day(endsOn),
+		CreatedBy: createdBy,
+		CreatedAt: createdAt,
+	}
+
+	if err := validate.Struct(term); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("Term domain model instance not valid", errorMap, err)
+	}
+
+	return term, nil
+}
+
+// Contains tells whether the instant falls on a day of the term
+func (t *Term) Contains(at time.Time) bool {
+	return !at.Before(t.StartsOn) && at.Before(t.EndsOn.AddDate(0, 0, 1))
+}
+
+// Overlaps tells whether the terms share a day, terms of a tenant never do
+func (t *Term) Overlaps(other *Term) bool {
+	return !t.EndsOn.Before(other.StartsOn) && !other.EndsOn.Before(t.StartsOn)
+}
+
+func day(at time.Time) time.Time {
+	at = at.UTC()
+	return time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+}
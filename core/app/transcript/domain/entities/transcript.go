@@ -0,0 +1,126 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// TranscriptGrade is a released grade of a student, the grades archived with prior school years
+// included
+type TranscriptGrade struct {
+	ClassID      string
+	AssignmentID string
+	// FinalScore is the score after the late penalty frozen on release
+	FinalScore float64
+	Dropped    bool
+	ReleasedAt time.Time
+}
+
+// ClassRecord is the average of the grades of a student in a class during a term, dropped grades
+// left out
+type ClassRecord struct {
+	ClassID    string
+	GradeCount int
+	Average    float64
+}
+
+// TermRecord is what a student achieved during a term. The averages weigh every class the same.
+type TermRecord struct {
+	Term    *Term
+	Classes []ClassRecord
+	Average float64
+	// CumulativeAverage covers the classes of the term and of every earlier term
+	CumulativeAverage float64
+}
+
+// Transcript groups the released grades of a student by the term they were released in, across
+// every term of the tenant. Grades released outside every term are left out.
+type Transcript struct {
+	TenantID  string
+	StudentID string
+	// Terms are the terms the student has grades in, oldest first
+	Terms   []TermRecord
+	Average float64
+}
+
+func NewTranscript(tenantID string, studentID string, terms []*Term, grades []TranscriptGrade) *Transcript {
+	ordered := append([]*Term(nil), terms...)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].StartsOn.Before(ordered[j].StartsOn) })
+
+	transcript := &Transcript{TenantID: tenantID, StudentID: studentID}
+	var all []ClassRecord
+	for _, term := range ordered {
+		classes := classRecords(term, grades)
+		if len(classes) == 0 {
+			continue
+		}
+		all = append(all, classes...)
+		transcript.Terms = append(transcript.Terms, TermRecord{
+			Term:              term,
+			Classes:           classes,
+			Average:           averageOf(classes),
+			CumulativeAverage: averageOf(all),
+		})
+	}
+	transcript.Average = averageOf(all)
+
+	return transcript
+}
+
+// Term returns the record of the term, one without classes when the student has no grades in it
+func (t *Transcript) Term(term *Term) *TermRecord {
+	for i := range t.Terms {
+		if t.Terms[i].Term.ID == term.ID {
+			return &t.Terms[i]
+		}
+	}
+
+	var earlier []ClassRecord
+	for _, record := range t.Terms {
+		if record.Term.StartsOn.Before(term.StartsOn) {
+			earlier = append(earlier, record.Classes...)
+		}
+	}
+	return &TermRecord{Term: term, CumulativeAverage: averageOf(earlier)}
+}
+
+// classRecords averages the grades released during the term by class, in class order
+func classRecords(term *Term, grades []TranscriptGrade) []ClassRecord {
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, grade := range grades {
+		if grade.Dropped || !term.Contains(grade.ReleasedAt) {
+			continue
+		}
+		sums[grade.ClassID] += grade.FinalScore
+		counts[grade.ClassID]++
+	}
+
+	classes := make([]ClassRecord, 0, len(counts))
+	for classID, count := range counts {
+		classes = append(classes, ClassRecord{
+			ClassID:    classID,
+			GradeCount: count,
+			Average:    round(sums[classID] / float64(count)),
+		})
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].ClassID < classes[j].ClassID })
+	return classes
+}
+
+func averageOf(classes []ClassRecord) float64 {
+	if len(classes) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, class := range classes {
+		sum += class.Average
+	}
+	return round(sum / float64(len(classes)))
+}
+
+// round keeps two decimals, the precision grades are shown with
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
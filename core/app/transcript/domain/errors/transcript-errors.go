@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	TermNotFoundError errors2.ErrorCode = "TERM_NOT_FOUND"
+	// TermOverlapError is returned for a term sharing days with another term of the tenant
+	TermOverlapError        errors2.ErrorCode = "TERM_OVERLAP"
+	ReportCardNotFoundError errors2.ErrorCode = "REPORT_CARD_NOT_FOUND"
+	ReportCardNotReadyError errors2.ErrorCode = "REPORT_CARD_NOT_READY"
+	ReportCardExpiredError  errors2.ErrorCode = "REPORT_CARD_EXPIRED"
+	// InvalidDownloadLinkError is returned for links whose signature does not match, and for expired
+	// links
+	InvalidDownloadLinkError errors2.ErrorCode = "INVALID_DOWNLOAD_LINK"
+	// InvalidReportCardTemplateError is returned for a template that does not parse or does not render
+	// with the report card variables
+	InvalidReportCardTemplateError errors2.ErrorCode = "INVALID_REPORT_CARD_TEMPLATE"
+)
+
+func NewTermNotFoundError(termID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TermNotFoundError.String(),
+			Message: "Term not found",
+			Context: map[string]any{
+				"term_id": termID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TermNotFoundError.String()),
+		},
+	}
+}
+
+func NewTermOverlapError(termID string, name string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    TermOverlapError.String(),
+			Message: "The term shares days with another term",
+			Context: map[string]any{
+				"term_id": termID,
+				"name":    name,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(TermOverlapError.String()),
+		},
+	}
+}
+
+func NewReportCardNotFoundError(reportCardID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ReportCardNotFoundError.String(),
+			Message: "Report card not found",
+			Context: map[string]any{
+				"report_card_id": reportCardID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ReportCardNotFoundError.String()),
+		},
+	}
+}
+
+func NewReportCardNotReadyError(reportCardID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ReportCardNotReadyError.String(),
+			Message: "The report card has not been generated yet",
+			Context: map[string]any{
+				"report_card_id": reportCardID,
+				"status":         status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ReportCardNotReadyError.String()),
+		},
+	}
+}
+
+func NewReportCardExpiredError(reportCardID string, expiredAt time.Time) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    ReportCardExpiredError.String(),
+			Message: "The report card expired, request it again",
+			Context: map[string]any{
+				"report_card_id": reportCardID,
+				"expired_at":     expiredAt,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(ReportCardExpiredError.String()),
+		},
+	}
+}
+
+func NewInvalidDownloadLinkError(reportCardID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    InvalidDownloadLinkError.String(),
+			Message: "The download link is invalid or expired",
+			Context: map[string]any{
+				"report_card_id": reportCardID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidDownloadLinkError.String()),
+		},
+	}
+}
+
+func NewInvalidReportCardTemplateError(part string, reason string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    InvalidReportCardTemplateError.String(),
+			Message: "Report card template does not render",
+			Context: map[string]any{
+				"part":   part,
+				"reason": reason,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidReportCardTemplateError.String()),
+		},
+	}
+}
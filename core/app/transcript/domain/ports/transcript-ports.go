@@ -0,0 +1,59 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+)
+
+type TermRepository interface {
+	Create(ctx context.Context, term *entities.Term) error
+	// FindByID returns nil when the tenant has no such term
+	FindByID(ctx context.Context, tenantID string, termID string) (*entities.Term, error)
+	// List returns every term of the tenant, oldest first
+	List(ctx context.Context, tenantID string) ([]*entities.Term, error)
+}
+
+type ReportCardTemplateRepository interface {
+	// Find returns nil when the tenant never saved its template
+	Find(ctx context.Context, tenantID string) (*entities.ReportCardTemplate, error)
+	Save(ctx context.Context, template *entities.ReportCardTemplate) error
+}
+
+// TranscriptSource reads what transcripts and report cards are made of from the other modules
+type TranscriptSource interface {
+	// ReleasedGrades returns the released grades of the student, the archived ones included
+	ReleasedGrades(ctx context.Context, tenantID string, studentID string) ([]entities.TranscriptGrade, error)
+	// StudentName returns "" when no user has the ID
+	StudentName(ctx context.Context, studentID string) (string, error)
+	GuardiansOf(ctx context.Context, tenantID string, studentID string) ([]string, error)
+}
+
+type ReportCardRepository interface {
+	Create(ctx context.Context, reportCards []*entities.ReportCard) error
+	// FindByID returns nil when the tenant has no such report card
+	FindByID(ctx context.Context, tenantID string, reportCardID string) (*entities.ReportCard, error)
+	// FindForDownload finds the report card of a download link whatever its tenant, links carry no
+	// tenant and their signature is what authorizes them
+	FindForDownload(ctx context.Context, reportCardID string) (*entities.ReportCard, error)
+	FindArtifact(ctx context.Context, tenantID string, reportCardID string) (*entities.Artifact, error)
+	ReportCardJobQueue
+}
+
+// ReportCardJobQueue is the worker side of the repository. ClaimNextPending also reclaims report
+// cards left running by a worker that died more than staleAfter ago; it returns nil when there is
+// nothing to do.
+type ReportCardJobQueue interface {
+	ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.ReportCard, error)
+	// MarkReady stores the artifact and appends a notification event to the outbox for each delivery,
+	// in one transaction
+	MarkReady(ctx context.Context, reportCard *entities.ReportCard, artifact *entities.Artifact, deliveries []*entities.ReportCardDelivery) error
+	MarkFailed(ctx context.Context, reportCardID string, reason string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// ReportCardRenderer lays out report cards as PDF files
+type ReportCardRenderer interface {
+	Render(ctx context.Context, document *entities.ReportCardDocument) ([]byte, error)
+}
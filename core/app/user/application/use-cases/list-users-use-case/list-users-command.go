@@ -0,0 +1,39 @@
+package list_users_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/filtering"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+)
+
+type ListUsersCommand struct {
+	TenantID string
+	Page     *pagination.PageRequest
+	Filter   filtering.Expr
+}
+
+// DefaultOrder lists the newest users first when a list sets no order
+var DefaultOrder = pagination.OrderBy{
+	Field:     "created_at",
+	Direction: pagination.Descending,
+}
+
+func NewListUsersCommand(tenantID string, pageSize int, cursor string, orderBy string, filter string) (*ListUsersCommand, error) {
+	page, err := pagination.NewPageRequest(pageSize, cursor, orderBy, ports.UserListOrderFields, DefaultOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewListUsersCommandFromPage(tenantID, page, filter)
+}
+
+// NewListUsersCommandFromPage builds the command of a page validated by its transport, such as
+// utils.PageRequestFromProto for gRPC calls
+func NewListUsersCommandFromPage(tenantID string, page *pagination.PageRequest, filter string) (*ListUsersCommand, error) {
+	filterExpr, err := filtering.Parse(filter, ports.UserListFilterFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListUsersCommand{TenantID: tenantID, Page: page, Filter: filterExpr}, nil
+}
@@ -0,0 +1,27 @@
+package list_users_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+)
+
+type ListUsersUseCase struct {
+	userRepo ports.UserLister
+}
+
+func NewListUsersUseCase(userRepo ports.UserLister) *ListUsersUseCase {
+	return &ListUsersUseCase{
+		userRepo: userRepo,
+	}
+}
+
+func (uc *ListUsersUseCase) Execute(cmd *ListUsersCommand) (*pagination.Page[*entities.User], error) {
+	page, err := uc.userRepo.List(cmd.TenantID, cmd.Page, cmd.Filter)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return page, nil
+}
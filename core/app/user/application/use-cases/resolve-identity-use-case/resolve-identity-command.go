@@ -0,0 +1,26 @@
+package resolve_identity_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ResolveIdentityCommand struct {
+	UserID string `validate:"required"`
+}
+
+func NewResolveIdentityCommand(userID string) (*ResolveIdentityCommand, error) {
+	command := &ResolveIdentityCommand{
+		UserID: userID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
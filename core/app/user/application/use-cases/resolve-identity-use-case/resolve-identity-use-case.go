@@ -0,0 +1,32 @@
+package resolve_identity_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+)
+
+type ResolveIdentityUseCase struct {
+	identityRepo ports.IdentityRepository
+}
+
+func NewResolveIdentityUseCase(identityRepo ports.IdentityRepository) *ResolveIdentityUseCase {
+	return &ResolveIdentityUseCase{
+		identityRepo: identityRepo,
+	}
+}
+
+// Execute returns the identity of the caller, requests from users that no longer exist are rejected
+func (uc *ResolveIdentityUseCase) Execute(ctx context.Context, cmd *ResolveIdentityCommand) (*entities.Identity, error) {
+	identity, err := uc.identityRepo.FindIdentity(ctx, cmd.UserID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if identity == nil {
+		return nil, errors.NewUnauthorizedError("Unknown user")
+	}
+
+	return identity, nil
+}
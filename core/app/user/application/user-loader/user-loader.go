@@ -0,0 +1,27 @@
+package user_loader
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/dataloader"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+)
+
+// For returns the user loader of the request scope of ctx, list endpoints load the users of every item
+// with it in one query instead of one per item
+func For(ctx context.Context, reader ports.UserReader) *dataloader.Loader[string, *entities.User] {
+	return dataloader.For(ctx, "users", func(_ context.Context, ids []string) (map[string]*entities.User, error) {
+		users, err := reader.FindByIDs(ids)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+
+		byID := make(map[string]*entities.User, len(users))
+		for _, user := range users {
+			byID[user.ID] = user
+		}
+		return byID, nil
+	})
+}
@@ -0,0 +1,29 @@
+package entities
+
+// TenantMembership lists the roles a user holds in one tenant
+type TenantMembership struct {
+	TenantID string
+	Roles    []string
+}
+
+// Identity is what authenticated requests know about their caller: the user record and the tenants
+// the user belongs to
+type Identity struct {
+	User        *User
+	Memberships []TenantMembership
+}
+
+// MemberOf reports whether the user holds any role in the tenant
+func (i *Identity) MemberOf(tenantID string) bool {
+	return len(i.Roles(tenantID)) > 0
+}
+
+// Roles returns the roles of the user in the tenant, nil when the user is not a member
+func (i *Identity) Roles(tenantID string) []string {
+	for _, membership := range i.Memberships {
+		if membership.TenantID == tenantID {
+			return membership.Roles
+		}
+	}
+	return nil
+}
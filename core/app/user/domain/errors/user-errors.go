@@ -8,8 +8,9 @@ import (
 )
 
 const (
-	UserNotFoundError       errors2.ErrorCode = "USER_NOT_FOUND"
-	EmailAlreadyExistsError errors2.ErrorCode = "EMAIL_ALREADY_EXISTS"
+	UserNotFoundError          errors2.ErrorCode = "USER_NOT_FOUND"
+	EmailAlreadyExistsError    errors2.ErrorCode = "EMAIL_ALREADY_EXISTS"
+	DuplicateEmailInBatchError errors2.ErrorCode = "DUPLICATE_EMAIL_IN_BATCH"
 )
 
 func NewUserNotFoundError(userID string) *errors2.BaseDomainError {
@@ -39,3 +40,18 @@ func NewEmailAlreadyExistsError(email string) *errors2.BaseDomainError {
 		},
 	}
 }
+
+func NewDuplicateEmailInBatchError(email string, firstIndex int) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    DuplicateEmailInBatchError.String(),
+			Message: "The email address appears more than once in the batch",
+			Context: map[string]any{
+				"email":       email,
+				"first_index": firstIndex,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(DuplicateEmailInBatchError.String()),
+		},
+	}
+}
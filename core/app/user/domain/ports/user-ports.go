@@ -1,11 +1,95 @@
 package ports
 
 import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/filtering"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
 	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
 )
 
+// UsersList is the name views of the users list are saved for
+const UsersList = "users"
+
+// UserListOrderFields are the fields users can be sorted by in list endpoints
+var UserListOrderFields = []string{"name", "email", "created_at"}
+
+// UserListFilterFields are the fields users can be filtered by in list endpoints
+var UserListFilterFields = filtering.Fields{
+	"name":       filtering.StringField,
+	"email":      filtering.StringField,
+	"created_at": filtering.TimeField,
+}
+
+// NewUserCredentials pairs a not yet persisted user with the raw password it is created with
+type NewUserCredentials struct {
+	User     *entities.User
+	Password string
+}
+
+// ImportedUserCredentials pairs a not yet persisted user with the password hash another system
+// stored for it, the hash is kept as is until the user signs in. ExternalID is the ID of the user in
+// that system, empty when it has none.
+type ImportedUserCredentials struct {
+	User         *entities.User
+	PasswordHash string
+	ExternalID   string
+}
+
 type UserRepository interface {
 	Create(user *entities.User, password string) (*entities.User, error)
 	ExistsByEmail(email string) (bool, error)
 	FindByEmail(email string) (*entities.User, error)
+	UserBatchWriter
+	UserCredentialStore
+	UserLister
+	UserReader
+}
+
+type UserLister interface {
+	// List pages the users with a role in tenantID, the users table itself is shared by every tenant
+	List(tenantID string, page *pagination.PageRequest, filter filtering.Expr) (*pagination.Page[*entities.User], error)
+}
+
+// UserReader loads the users other modules show next to their records
+type UserReader interface {
+	// FindByIDs returns the users of ids that exist, in no particular order
+	FindByIDs(ids []string) ([]*entities.User, error)
+}
+
+// UserBatchWriter groups the operations used by bulk user creation flows
+type UserBatchWriter interface {
+	// CreateMany persists all users in a single transaction, either every user is created or none is
+	CreateMany(users []NewUserCredentials) ([]*entities.User, error)
+	// FindExistingEmails returns the emails of the users whose email matches one of emails ignoring
+	// case, as they are stored
+	FindExistingEmails(emails []string) ([]string, error)
+}
+
+// UserCredentialStore reads and replaces the password hashes password verification works with
+type UserCredentialStore interface {
+	// FindCredentials returns the user of email with its password hash, a nil user when there is none
+	FindCredentials(email string) (*entities.User, string, error)
+	// ReplacePasswordHash stores newHash only while the user still has oldHash, so a rehash never
+	// overwrites a password changed in between. It reports whether the hash was replaced.
+	ReplacePasswordHash(userID string, oldHash string, newHash string) (bool, error)
+	// ImportMany persists users with their hashes in a single transaction, like CreateMany
+	ImportMany(users []ImportedUserCredentials) ([]*entities.User, error)
+}
+
+// PasswordHasher hashes passwords with the current scheme and verifies them against the hashes of
+// every scheme users were imported with
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify tells whether password matches hash, and whether hash should be replaced by a hash of
+	// the current scheme because it is a legacy one or weaker than the current settings
+	Verify(password string, hash string) (matches bool, needsRehash bool, err error)
+	// Recognizes tells whether hash is in a scheme Verify supports
+	Recognizes(hash string) bool
+}
+
+// IdentityRepository resolves the caller of authenticated requests, it is read on every request
+type IdentityRepository interface {
+	// FindIdentity returns nil when the user does not exist
+	FindIdentity(ctx context.Context, userID string) (*entities.Identity, error)
 }
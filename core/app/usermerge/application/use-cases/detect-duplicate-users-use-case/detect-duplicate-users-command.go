@@ -0,0 +1,24 @@
+package detect_duplicate_users_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type DetectDuplicateUsersCommand struct {
+	TenantID string `validate:"required"`
+}
+
+func NewDetectDuplicateUsersCommand(tenantID string) (*DetectDuplicateUsersCommand, error) {
+	command := &DetectDuplicateUsersCommand{TenantID: tenantID}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,59 @@
+package detect_duplicate_users_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+// DetectionResult counts what a detection of a tenant found
+type DetectionResult struct {
+	Members    int
+	Candidates int
+	// New are the candidates not found by an earlier detection
+	New int
+}
+
+type DetectDuplicateUsersUseCase struct {
+	members    ports.MemberDirectory
+	candidates ports.DuplicateCandidateRepository
+}
+
+func NewDetectDuplicateUsersUseCase(members ports.MemberDirectory, candidates ports.DuplicateCandidateRepository) *DetectDuplicateUsersUseCase {
+	return &DetectDuplicateUsersUseCase{
+		members:    members,
+		candidates: candidates,
+	}
+}
+
+// Execute compares the members of the tenant and saves the likely duplicates as candidates for an
+// admin to merge or dismiss
+func (uc *DetectDuplicateUsersUseCase) Execute(ctx context.Context, cmd *DetectDuplicateUsersCommand) (*DetectionResult, error) {
+	members, err := uc.members.Members(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	now := time.Now().UTC()
+	pairs := entities.FindDuplicates(members)
+	candidates := make([]*entities.DuplicateCandidate, 0, len(pairs))
+	for _, pair := range pairs {
+		candidate, err := entities.NewDuplicateCandidate(uuid.New().String(), cmd.TenantID, pair, now)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	added, err := uc.candidates.SaveDetected(ctx, cmd.TenantID, candidates)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return &DetectionResult{Members: len(members), Candidates: len(candidates), New: added}, nil
+}
@@ -0,0 +1,30 @@
+package dismiss_duplicate_candidate_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type DismissDuplicateCandidateCommand struct {
+	TenantID    string `validate:"required"`
+	CandidateID string `validate:"required,uuid"`
+	DismissedBy string `validate:"required"`
+}
+
+func NewDismissDuplicateCandidateCommand(tenantID string, candidateID string, dismissedBy string) (*DismissDuplicateCandidateCommand, error) {
+	command := &DismissDuplicateCandidateCommand{
+		TenantID:    tenantID,
+		CandidateID: candidateID,
+		DismissedBy: dismissedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,46 @@
+package dismiss_duplicate_candidate_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	userMergeErrors "github.com/nahualventure/class-backend/core/app/usermerge/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/ports"
+)
+
+type DismissDuplicateCandidateUseCase struct {
+	candidates ports.DuplicateCandidateRepository
+}
+
+func NewDismissDuplicateCandidateUseCase(candidates ports.DuplicateCandidateRepository) *DismissDuplicateCandidateUseCase {
+	return &DismissDuplicateCandidateUseCase{
+		candidates: candidates,
+	}
+}
+
+// Execute records that the users of a candidate are different people, detection does not report
+// them again
+func (uc *DismissDuplicateCandidateUseCase) Execute(ctx context.Context, cmd *DismissDuplicateCandidateCommand) (*entities.DuplicateCandidate, error) {
+	candidate, err := uc.candidates.FindByID(ctx, cmd.TenantID, cmd.CandidateID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if candidate == nil {
+		return nil, userMergeErrors.NewDuplicateCandidateNotFoundError(cmd.CandidateID)
+	}
+	if candidate.Status != entities.DuplicateCandidateStatusOpen {
+		return nil, userMergeErrors.NewDuplicateCandidateNotOpenError(candidate.ID, string(candidate.Status))
+	}
+
+	candidate.Dismiss(cmd.DismissedBy, time.Now().UTC())
+	dismissed, err := uc.candidates.Dismiss(ctx, candidate)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !dismissed {
+		return nil, userMergeErrors.NewDuplicateCandidateNotOpenError(candidate.ID, string(entities.DuplicateCandidateStatusDismissed))
+	}
+	return candidate, nil
+}
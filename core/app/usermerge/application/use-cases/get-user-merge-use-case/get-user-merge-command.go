@@ -0,0 +1,28 @@
+package get_user_merge_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type GetUserMergeCommand struct {
+	TenantID string `validate:"required"`
+	MergeID  string `validate:"required,uuid"`
+}
+
+func NewGetUserMergeCommand(tenantID string, mergeID string) (*GetUserMergeCommand, error) {
+	command := &GetUserMergeCommand{
+		TenantID: tenantID,
+		MergeID:  mergeID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
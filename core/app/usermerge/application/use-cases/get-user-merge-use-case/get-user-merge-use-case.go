@@ -0,0 +1,31 @@
+package get_user_merge_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	userMergeErrors "github.com/nahualventure/class-backend/core/app/usermerge/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/ports"
+)
+
+type GetUserMergeUseCase struct {
+	merges ports.UserMergeRepository
+}
+
+func NewGetUserMergeUseCase(merges ports.UserMergeRepository) *GetUserMergeUseCase {
+	return &GetUserMergeUseCase{
+		merges: merges,
+	}
+}
+
+func (uc *GetUserMergeUseCase) Execute(ctx context.Context, cmd *GetUserMergeCommand) (*entities.UserMerge, error) {
+	merge, err := uc.merges.FindByID(ctx, cmd.TenantID, cmd.MergeID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if merge == nil {
+		return nil, userMergeErrors.NewUserMergeNotFoundError(cmd.MergeID)
+	}
+	return merge, nil
+}
@@ -0,0 +1,33 @@
+package list_duplicate_candidates_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ListDuplicateCandidatesCommand struct {
+	TenantID string `validate:"required"`
+	Page     *pagination.PageRequest
+}
+
+func NewListDuplicateCandidatesCommand(tenantID string, pageSize int, cursor string) (*ListDuplicateCandidatesCommand, error) {
+	page, err := pagination.NewPageRequest(pageSize, cursor, "", nil, pagination.OrderBy{
+		Field:     "detected_at",
+		Direction: pagination.Descending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	command := &ListDuplicateCandidatesCommand{TenantID: tenantID, Page: page}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
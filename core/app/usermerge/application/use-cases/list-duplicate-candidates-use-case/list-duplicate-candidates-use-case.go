@@ -0,0 +1,29 @@
+package list_duplicate_candidates_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/ports"
+)
+
+type ListDuplicateCandidatesUseCase struct {
+	candidates ports.DuplicateCandidateRepository
+}
+
+func NewListDuplicateCandidatesUseCase(candidates ports.DuplicateCandidateRepository) *ListDuplicateCandidatesUseCase {
+	return &ListDuplicateCandidatesUseCase{
+		candidates: candidates,
+	}
+}
+
+// Execute lists the candidates the detection found that nobody merged or dismissed yet
+func (uc *ListDuplicateCandidatesUseCase) Execute(ctx context.Context, cmd *ListDuplicateCandidatesCommand) (*pagination.Page[*entities.DuplicateCandidate], error) {
+	page, err := uc.candidates.ListOpen(ctx, cmd.TenantID, cmd.Page)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return page, nil
+}
@@ -0,0 +1,28 @@
+package list_user_merges_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/ports"
+)
+
+type ListUserMergesUseCase struct {
+	merges ports.UserMergeRepository
+}
+
+func NewListUserMergesUseCase(merges ports.UserMergeRepository) *ListUserMergesUseCase {
+	return &ListUserMergesUseCase{
+		merges: merges,
+	}
+}
+
+func (uc *ListUserMergesUseCase) Execute(ctx context.Context, cmd *ListUserMergesCommand) (*pagination.Page[*entities.UserMerge], error) {
+	page, err := uc.merges.List(ctx, cmd.TenantID, cmd.Page)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return page, nil
+}
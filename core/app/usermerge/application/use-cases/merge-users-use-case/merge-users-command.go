@@ -0,0 +1,34 @@
+package merge_users_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type MergeUsersCommand struct {
+	TenantID   string `validate:"required"`
+	SurvivorID string `validate:"required"`
+	// MergedID is the duplicate account, it loses its roles in the tenant. It cannot be the
+	// requester's own account.
+	MergedID    string `validate:"required,nefield=SurvivorID,nefield=RequestedBy"`
+	RequestedBy string `validate:"required"`
+}
+
+func NewMergeUsersCommand(tenantID string, survivorID string, mergedID string, requestedBy string) (*MergeUsersCommand, error) {
+	command := &MergeUsersCommand{
+		TenantID:    tenantID,
+		SurvivorID:  survivorID,
+		MergedID:    mergedID,
+		RequestedBy: requestedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,49 @@
+package merge_users_use_case
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	userMergeErrors "github.com/nahualventure/class-backend/core/app/usermerge/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+type MergeUsersUseCase struct {
+	merges  ports.UserMergeRepository
+	members ports.MemberDirectory
+}
+
+func NewMergeUsersUseCase(merges ports.UserMergeRepository, members ports.MemberDirectory) *MergeUsersUseCase {
+	return &MergeUsersUseCase{
+		merges:  merges,
+		members: members,
+	}
+}
+
+// Execute moves the records of the merged user to the survivor. Both must hold a role in the tenant,
+// which also keeps a user that was merged into another one from being merged again.
+func (uc *MergeUsersUseCase) Execute(ctx context.Context, cmd *MergeUsersCommand) (*entities.UserMerge, error) {
+	members, err := uc.members.Find(ctx, cmd.TenantID, []string{cmd.SurvivorID, cmd.MergedID})
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	for _, userID := range []string{cmd.SurvivorID, cmd.MergedID} {
+		if !slices.ContainsFunc(members, func(member entities.Member) bool { return member.UserID == userID }) {
+			return nil, userMergeErrors.NewMergeUserNotMemberError(userID)
+		}
+	}
+
+	merge, err := entities.NewUserMerge(uuid.New().String(), cmd.TenantID, cmd.SurvivorID, cmd.MergedID, cmd.RequestedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if err := uc.merges.Merge(ctx, merge); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return merge, nil
+}
@@ -0,0 +1,30 @@
+package undo_user_merge_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type UndoUserMergeCommand struct {
+	TenantID string `validate:"required"`
+	MergeID  string `validate:"required,uuid"`
+	UndoneBy string `validate:"required"`
+}
+
+func NewUndoUserMergeCommand(tenantID string, mergeID string, undoneBy string) (*UndoUserMergeCommand, error) {
+	command := &UndoUserMergeCommand{
+		TenantID: tenantID,
+		MergeID:  mergeID,
+		UndoneBy: undoneBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,46 @@
+package undo_user_merge_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	userMergeErrors "github.com/nahualventure/class-backend/core/app/usermerge/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/ports"
+)
+
+type UndoUserMergeUseCase struct {
+	merges ports.UserMergeRepository
+}
+
+func NewUndoUserMergeUseCase(merges ports.UserMergeRepository) *UndoUserMergeUseCase {
+	return &UndoUserMergeUseCase{
+		merges: merges,
+	}
+}
+
+// Execute gives the merged user back what the merge moved. Records the survivor got since are kept.
+func (uc *UndoUserMergeUseCase) Execute(ctx context.Context, cmd *UndoUserMergeCommand) (*entities.UserMerge, error) {
+	merge, err := uc.merges.FindByID(ctx, cmd.TenantID, cmd.MergeID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if merge == nil {
+		return nil, userMergeErrors.NewUserMergeNotFoundError(cmd.MergeID)
+	}
+	now := time.Now().UTC()
+	if !merge.UndoableAt(now) {
+		return nil, userMergeErrors.NewUserMergeNotUndoableError(merge.ID, string(merge.Status), merge.UndoUntil)
+	}
+
+	merge.Undo(cmd.UndoneBy, now)
+	undone, err := uc.merges.Undo(ctx, merge)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if !undone {
+		return nil, userMergeErrors.NewUserMergeNotUndoableError(merge.ID, string(entities.UserMergeStatusUndone), merge.UndoUntil)
+	}
+	return merge, nil
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type DuplicateReason string
+
+const (
+	// DuplicateReasonSameExternalID users were imported with the same ID of the former system
+	DuplicateReasonSameExternalID DuplicateReason = "same_external_id"
+	// DuplicateReasonSimilarEmail users have the same name and emails that differ in a typo, dots,
+	// a +tag or the domain
+	DuplicateReasonSimilarEmail DuplicateReason = "similar_email"
+)
+
+type DuplicateCandidateStatus string
+
+const (
+	DuplicateCandidateStatusOpen DuplicateCandidateStatus = "open"
+	// DuplicateCandidateStatusDismissed candidates are not accounts of the same person, detection
+	// does not report the pair again
+	DuplicateCandidateStatusDismissed DuplicateCandidateStatus = "dismissed"
+	// DuplicateCandidateStatusMerged candidates were merged, undoing the merge opens them again
+	DuplicateCandidateStatusMerged DuplicateCandidateStatus = "merged"
+)
+
+// DuplicateCandidate is a pair of users of a tenant that are likely accounts of the same person
+type DuplicateCandidate struct {
+	ID       string `validate:"required,uuid"`
+	TenantID string `validate:"required"`
+	// UserID sorts before DuplicateUserID, a pair is the same candidate whatever order it is found in
+	UserID          string                   `validate:"required"`
+	DuplicateUserID string                   `validate:"required"`
+	Reason          DuplicateReason          `validate:"required,oneof=same_external_id similar_email"`
+	Status          DuplicateCandidateStatus `validate:"required,oneof=open dismissed merged"`
+	DetectedAt      time.Time                `validate:"required"`
+	ResolvedBy      string
+	ResolvedAt      *time.Time
+}
+
+func NewDuplicateCandidate(id string, tenantID string, pair DuplicatePair, now time.Time) (*DuplicateCandidate, error) {
+	candidate := &DuplicateCandidate{
+		ID:              id,
+		TenantID:        tenantID,
+		UserID:          pair.UserID,
+		DuplicateUserID: pair.DuplicateUserID,
+		Reason:          pair.Reason,
+		Status:          DuplicateCandidateStatusOpen,
+		DetectedAt:      now,
+	}
+
+	if pair.UserID >= pair.DuplicateUserID {
+		return nil, appErrors.NewDomainEntityValidationError("DuplicateCandidate domain model instance not valid",
+			map[string]any{"DuplicateUserID": "must sort after UserID"}, nil)
+	}
+	if err := validate.Struct(candidate); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("DuplicateCandidate domain model instance not valid", errorMap, err)
+	}
+
+	return candidate, nil
+}
+
+// Involves reports whether userID is one of the users of the candidate
+func (c *DuplicateCandidate) Involves(userID string) bool {
+	return c.UserID == userID || c.DuplicateUserID == userID
+}
+
+// Dismiss records that the users are different people
+func (c *DuplicateCandidate) Dismiss(dismissedBy string, now time.Time) {
+	c.Status = DuplicateCandidateStatusDismissed
+	c.ResolvedBy = dismissedBy
+	c.ResolvedAt = &now
+}
@@ -0,0 +1,139 @@
+package entities
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// MaxEmailDistance is how many edits apart the local parts of the emails of two users with the same
+// name can be for them to be reported, e.g. "jlopez" and "jlopes"
+const MaxEmailDistance = 2
+
+// Member is a user holding a role in a tenant
+type Member struct {
+	UserID string
+	Name   string
+	Email  string
+	// ExternalID is the ID of the user in the system it was imported from, empty when not imported
+	ExternalID string
+}
+
+// DuplicatePair is a pair of users FindDuplicates reports, UserID sorts before DuplicateUserID
+type DuplicatePair struct {
+	UserID          string
+	DuplicateUserID string
+	Reason          DuplicateReason
+}
+
+// Key identifies the pair whatever reason it was reported for
+func (p DuplicatePair) Key() string {
+	return p.UserID + ":" + p.DuplicateUserID
+}
+
+// FindDuplicates returns the pairs of members that are likely accounts of the same person: the ones
+// imported with the same external ID, and the ones with the same name and similar emails. Members
+// are only compared within the group sharing the external ID or the name, never every pair. The
+// pairs are sorted, a pair matching both ways is reported for its external ID.
+func FindDuplicates(members []Member) []DuplicatePair {
+	byExternalID := make(map[string][]Member)
+	byName := make(map[string][]Member)
+	for _, member := range members {
+		if externalID := strings.ToLower(strings.TrimSpace(member.ExternalID)); externalID != "" {
+			byExternalID[externalID] = append(byExternalID[externalID], member)
+		}
+		if name := normalizeName(member.Name); name != "" {
+			byName[name] = append(byName[name], member)
+		}
+	}
+
+	found := make(map[string]DuplicatePair)
+	for _, group := range byExternalID {
+		eachPair(group, func(pair DuplicatePair) {
+			pair.Reason = DuplicateReasonSameExternalID
+			found[pair.Key()] = pair
+		})
+	}
+	for _, group := range byName {
+		eachPair(group, func(pair DuplicatePair) {
+			if _, ok := found[pair.Key()]; ok {
+				return
+			}
+			pair.Reason = DuplicateReasonSimilarEmail
+			found[pair.Key()] = pair
+		}, similarEmails)
+	}
+
+	pairs := make([]DuplicatePair, 0, len(found))
+	for _, pair := range found {
+		pairs = append(pairs, pair)
+	}
+	slices.SortFunc(pairs, func(a, b DuplicatePair) int {
+		return cmp.Or(cmp.Compare(a.UserID, b.UserID), cmp.Compare(a.DuplicateUserID, b.DuplicateUserID))
+	})
+	return pairs
+}
+
+// eachPair calls fn with every pair of different users of group that matches all of filters
+func eachPair(group []Member, fn func(DuplicatePair), filters ...func(a, b Member) bool) {
+	for i := range group {
+	next:
+		for j := i + 1; j < len(group); j++ {
+			a, b := group[i], group[j]
+			if a.UserID == b.UserID {
+				continue
+			}
+			for _, filter := range filters {
+				if !filter(a, b) {
+					continue next
+				}
+			}
+			if a.UserID > b.UserID {
+				a, b = b, a
+			}
+			fn(DuplicatePair{UserID: a.UserID, DuplicateUserID: b.UserID})
+		}
+	}
+}
+
+// similarEmails reports whether the local parts of the emails are the same once dots and +tags are
+// dropped, on any domain, or at most MaxEmailDistance edits apart
+func similarEmails(a, b Member) bool {
+	localA, localB := canonicalLocalPart(a.Email), canonicalLocalPart(b.Email)
+	if localA == "" || localB == "" {
+		return false
+	}
+	return localA == localB || editDistance(localA, localB) <= MaxEmailDistance
+}
+
+func canonicalLocalPart(email string) string {
+	local, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	local, _, _ = strings.Cut(local, "+")
+	return strings.ReplaceAll(local, ".", "")
+}
+
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// UndoWindow is how long a merge can be undone
+const UndoWindow = 7 * 24 * time.Hour
+
+type UserMergeStatus string
+
+const (
+	UserMergeStatusCompleted UserMergeStatus = "completed"
+	// UserMergeStatusUndone merges gave the merged user its records back
+	UserMergeStatusUndone UserMergeStatus = "undone"
+)
+
+// Enrollment is a class a user was enrolled in and since when
+type Enrollment struct {
+	ClassID    string    `json:"class_id"`
+	EnrolledAt time.Time `json:"enrolled_at"`
+}
+
+// MergeChanges records what a merge moved so that undoing it moves exactly that back
+type MergeChanges struct {
+	// Enrollments of the merged user moved to the survivor
+	Enrollments []Enrollment `json:"enrollments"`
+	// DroppedEnrollments of the merged user in classes the survivor was enrolled in already
+	DroppedEnrollments []Enrollment `json:"dropped_enrollments"`
+	// Grades are the IDs of the grades moved to the survivor
+	Grades []string `json:"grades"`
+	// KeptGrades are the IDs of the grades left with the merged user because the survivor has a
+	// grade for the same assignment
+	KeptGrades []string `json:"kept_grades"`
+	// Roles the merged user held in the tenant, all of them are revoked
+	Roles []string `json:"roles"`
+	// AddedRoles are the roles of the merged user the survivor did not hold yet
+	AddedRoles []string `json:"added_roles"`
+}
+
+// UserMerge moves the enrollments, grades and role assignments of a tenant from a duplicate account,
+// the merged user, to the survivor. The merged user is left without roles in the tenant, the account
+// itself is kept. It can be undone for UndoWindow.
+type UserMerge struct {
+	ID          string          `validate:"required,uuid"`
+	TenantID    string          `validate:"required"`
+	SurvivorID  string          `validate:"required"`
+	MergedID    string          `validate:"required,nefield=SurvivorID"`
+	Status      UserMergeStatus `validate:"required,oneof=completed undone"`
+	Changes     MergeChanges
+	RequestedBy string    `validate:"required"`
+	CreatedAt   time.Time `validate:"required"`
+	UndoUntil   time.Time `validate:"required"`
+	UndoneBy    string
+	UndoneAt    *time.Time
+}
+
+func NewUserMerge(id string, tenantID string, survivorID string, mergedID string, requestedBy string, now time.Time) (*UserMerge, error) {
+	merge := &UserMerge{
+		ID:          id,
+		TenantID:    tenantID,
+		SurvivorID:  survivorID,
+		MergedID:    mergedID,
+		Status:      UserMergeStatusCompleted,
+		RequestedBy: requestedBy,
+		CreatedAt:   now,
+		UndoUntil:   now.Add(UndoWindow),
+	}
+
+	if err := validate.Struct(merge); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("UserMerge domain model instance not valid", errorMap, err)
+	}
+
+	return merge, nil
+}
+
+// UndoableAt reports whether the merge can still be undone at now
+func (m *UserMerge) UndoableAt(now time.Time) bool {
+	return m.Status == UserMergeStatusCompleted && now.Before(m.UndoUntil)
+}
+
+// Undo records that the merged user got its records back
+func (m *UserMerge) Undo(undoneBy string, now time.Time) {
+	m.Status = UserMergeStatusUndone
+	m.UndoneBy = undoneBy
+	m.UndoneAt = &now
+}
@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	DuplicateCandidateNotFoundError errors2.ErrorCode = "DUPLICATE_CANDIDATE_NOT_FOUND"
+	// DuplicateCandidateNotOpenError is returned when dismissing a candidate already dismissed or merged
+	DuplicateCandidateNotOpenError errors2.ErrorCode = "DUPLICATE_CANDIDATE_NOT_OPEN"
+	UserMergeNotFoundError         errors2.ErrorCode = "USER_MERGE_NOT_FOUND"
+	// MergeUserNotMemberError is returned when a user to merge holds no role in the tenant, users
+	// merged into another one are not members anymore
+	MergeUserNotMemberError errors2.ErrorCode = "MERGE_USER_NOT_MEMBER"
+	// UserMergeNotUndoableError is returned when undoing a merge already undone or past its undo window
+	UserMergeNotUndoableError errors2.ErrorCode = "USER_MERGE_NOT_UNDOABLE"
+)
+
+func NewDuplicateCandidateNotFoundError(candidateID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    DuplicateCandidateNotFoundError.String(),
+			Message: "Duplicate candidate not found",
+			Context: map[string]any{
+				"candidate_id": candidateID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(DuplicateCandidateNotFoundError.String()),
+		},
+	}
+}
+
+func NewDuplicateCandidateNotOpenError(candidateID string, status string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    DuplicateCandidateNotOpenError.String(),
+			Message: "Only open duplicate candidates can be dismissed",
+			Context: map[string]any{
+				"candidate_id": candidateID,
+				"status":       status,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(DuplicateCandidateNotOpenError.String()),
+		},
+	}
+}
+
+func NewUserMergeNotFoundError(mergeID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    UserMergeNotFoundError.String(),
+			Message: "User merge not found",
+			Context: map[string]any{
+				"merge_id": mergeID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(UserMergeNotFoundError.String()),
+		},
+	}
+}
+
+func NewMergeUserNotMemberError(userID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    MergeUserNotMemberError.String(),
+			Message: "User holds no role in the tenant",
+			Context: map[string]any{
+				"user_id": userID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(MergeUserNotMemberError.String()),
+		},
+	}
+}
+
+func NewUserMergeNotUndoableError(mergeID string, status string, undoUntil time.Time) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    UserMergeNotUndoableError.String(),
+			Message: "Merge was already undone or its undo window is over",
+			Context: map[string]any{
+				"merge_id":   mergeID,
+				"status":     status,
+				"undo_until": undoUntil,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(UserMergeNotUndoableError.String()),
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+)
+
+// MemberDirectory reads the users holding a role in a tenant
+type MemberDirectory interface {
+	// Members returns every member of the tenant
+	Members(ctx context.Context, tenantID string) ([]entities.Member, error)
+	// Find returns the members of the tenant among userIDs, the rest are skipped
+	Find(ctx context.Context, tenantID string, userIDs []string) ([]entities.Member, error)
+}
+
+type DuplicateCandidateRepository interface {
+	// SaveDetected records the pairs the last detection of the tenant found. Pairs seen before keep
+	// their status, so dismissed ones stay dismissed, and open candidates that were not found again
+	// are removed. It returns how many pairs are new.
+	SaveDetected(ctx context.Context, tenantID string, candidates []*entities.DuplicateCandidate) (int, error)
+	// FindByID returns nil when the tenant has no such candidate
+	FindByID(ctx context.Context, tenantID string, candidateID string) (*entities.DuplicateCandidate, error)
+	// ListOpen returns the open candidates of the tenant, last detected first
+	ListOpen(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.DuplicateCandidate], error)
+	// Dismiss saves the dismissal of an open candidate, false when it is no longer open
+	Dismiss(ctx context.Context, candidate *entities.DuplicateCandidate) (bool, error)
+}
+
+type UserMergeRepository interface {
+	// Merge moves the enrollments, grades and role assignments of the merged user to the survivor,
+	// fills the changes of merge with what moved, marks the candidates of the pair merged and saves
+	// the merge, all in one transaction
+	Merge(ctx context.Context, merge *entities.UserMerge) error
+	// Undo moves the changes of the merge back, opens the candidates of the pair again and saves the
+	// undo in one transaction. It returns false without changing anything when the merge is no
+	// longer undoable.
+	Undo(ctx context.Context, merge *entities.UserMerge) (bool, error)
+	// FindByID returns nil when the tenant has no such merge
+	FindByID(ctx context.Context, tenantID string, mergeID string) (*entities.UserMerge, error)
+	// List returns the merges of the tenant newest first
+	List(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.UserMerge], error)
+}
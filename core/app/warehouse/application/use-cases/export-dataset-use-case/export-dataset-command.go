@@ -0,0 +1,28 @@
+package export_dataset_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ExportDatasetCommand struct {
+	TenantID string `validate:"required"`
+	Dataset  string `validate:"required,oneof=users class_summaries"`
+}
+
+func NewExportDatasetCommand(tenantID string, dataset string) (*ExportDatasetCommand, error) {
+	command := &ExportDatasetCommand{
+		TenantID: tenantID,
+		Dataset:  dataset,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,132 @@
+package export_dataset_use_case
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/warehouse/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/warehouse/domain/ports"
+)
+
+const DefaultRowsPerFile = 50_000
+
+type ExportDatasetResult struct {
+	Rows    int
+	Objects []string
+}
+
+type ExportDatasetUseCase struct {
+	source       ports.WarehouseSource
+	watermarks   ports.WatermarkStore
+	sink         ports.WarehouseSink
+	pseudonymKey []byte
+	rowsPerFile  int
+}
+
+func NewExportDatasetUseCase(source ports.WarehouseSource, watermarks ports.WatermarkStore, sink ports.WarehouseSink,
+	pseudonymKey []byte, rowsPerFile int) *ExportDatasetUseCase {
+	return &ExportDatasetUseCase{
+		source:       source,
+		watermarks:   watermarks,
+		sink:         sink,
+		pseudonymKey: pseudonymKey,
+		rowsPerFile:  rowsPerFile,
+	}
+}
+
+// Execute exports the records changed since the last run as CSV parts. The watermark is saved after
+// every part, so an interrupted run resumes where it stopped and never exports a record twice.
+func (uc *ExportDatasetUseCase) Execute(ctx context.Context, cmd *ExportDatasetCommand) (*ExportDatasetResult, error) {
+	dataset := entities.Datasets[cmd.Dataset]
+
+	watermark, err := uc.watermarks.Load(ctx, cmd.TenantID, dataset.Name)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	prefix := fmt.Sprintf("tenant=%s/dataset=%s/v%d", cmd.TenantID, dataset.Name, dataset.SchemaVersion)
+	result := &ExportDatasetResult{}
+
+	// First export of this schema version: describe the schema next to the data
+	if watermark == nil || watermark.SchemaVersion != dataset.SchemaVersion {
+		watermark = &entities.Watermark{
+			TenantID:      cmd.TenantID,
+			Dataset:       dataset.Name,
+			SchemaVersion: dataset.SchemaVersion,
+		}
+
+		schema, err := json.Marshal(map[string]any{
+			"dataset":        dataset.Name,
+			"schema_version": dataset.SchemaVersion,
+			"columns":        dataset.ColumnNames(),
+			"format":         "csv",
+		})
+		if err != nil {
+			return nil, errors.NewInfrastructureError("failed to serialize dataset schema", err)
+		}
+
+		key := prefix + "/_schema.json"
+		if err := uc.sink.Put(ctx, key, "application/json", schema); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+		result.Objects = append(result.Objects, key)
+	}
+
+	exportedAt := time.Now().UTC()
+	for part := 0; ; part++ {
+		records, err := uc.source.ReadChanged(ctx, dataset.Name, *watermark, uc.rowsPerFile)
+		if err != nil {
+			return nil, errors.PropagateError(err)
+		}
+
+		if len(records) == 0 {
+			break
+		}
+
+		content, err := uc.encode(dataset, cmd.TenantID, records)
+		if err != nil {
+			return nil, errors.NewInfrastructureError("failed to encode warehouse export", err)
+		}
+
+		key := fmt.Sprintf("%s/dt=%s/part-%s-%04d.csv", prefix, exportedAt.Format(time.DateOnly), exportedAt.Format("150405"), part)
+		if err := uc.sink.Put(ctx, key, "text/csv", content); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+
+		watermark.Advance(records[len(records)-1])
+		if err := uc.watermarks.Save(ctx, watermark); err != nil {
+			return nil, errors.PropagateError(err)
+		}
+
+		result.Rows += len(records)
+		result.Objects = append(result.Objects, key)
+
+		if len(records) < uc.rowsPerFile {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func (uc *ExportDatasetUseCase) encode(dataset entities.Dataset, tenantID string, records []entities.Record) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	if err := writer.Write(dataset.ColumnNames()); err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if err := writer.Write(dataset.Anonymize(uc.pseudonymKey, tenantID, record.Values)); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+
+	return buffer.Bytes(), writer.Error()
+}
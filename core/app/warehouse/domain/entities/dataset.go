@@ -0,0 +1,75 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	UsersDataset          = "users"
+	ClassSummariesDataset = "class_summaries"
+)
+
+type Column struct {
+	Name string
+	// Pseudonymize replaces the value with a keyed hash that is stable per tenant, so districts can
+	// join rows across exports without receiving the real identifier
+	Pseudonymize bool
+}
+
+// Dataset describes an exported table. Bump SchemaVersion on any column change: exports are written
+// under a versioned prefix and a new version restarts from an empty watermark.
+type Dataset struct {
+	Name          string
+	SchemaVersion int
+	Columns       []Column
+}
+
+var Datasets = map[string]Dataset{
+	UsersDataset: {
+		Name:          UsersDataset,
+		SchemaVersion: 1,
+		Columns: []Column{
+			{Name: "user_key", Pseudonymize: true},
+			{Name: "email_domain"},
+			{Name: "created_at"},
+			{Name: "updated_at"},
+		},
+	},
+	ClassSummariesDataset: {
+		Name:          ClassSummariesDataset,
+		SchemaVersion: 1,
+		Columns: []Column{
+			{Name: "class_id"},
+			{Name: "roster_count"},
+			{Name: "average_grade"},
+			{Name: "attendance_rate"},
+			{Name: "updated_at"},
+		},
+	},
+}
+
+func (d Dataset) ColumnNames() []string {
+	names := make([]string, len(d.Columns))
+	for i, column := range d.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// Anonymize returns a copy of values with the pseudonymized columns replaced
+func (d Dataset) Anonymize(key []byte, tenantID string, values []string) []string {
+	anonymized := make([]string, len(values))
+	copy(anonymized, values)
+
+	for i, column := range d.Columns {
+		if column.Pseudonymize && i < len(anonymized) && anonymized[i] != "" {
+			mac := hmac.New(sha256.New, key)
+			mac.Write([]byte(tenantID + ":" + anonymized[i]))
+			anonymized[i] = hex.EncodeToString(mac.Sum(nil))
+		}
+	}
+
+	return anonymized
+}
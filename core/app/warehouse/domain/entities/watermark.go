@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+// Watermark is the position of the last exported record of a tenant dataset. Records are read in
+// (UpdatedAt, Key) order so rows updated in the same instant are not skipped.
+type Watermark struct {
+	TenantID      string
+	Dataset       string
+	SchemaVersion int
+	UpdatedAt     time.Time
+	Key           string
+}
+
+// Record is a changed row of a dataset, Values follow the dataset columns
+type Record struct {
+	Key       string
+	UpdatedAt time.Time
+	Values    []string
+}
+
+func (w *Watermark) Advance(record Record) {
+	w.UpdatedAt = record.UpdatedAt
+	w.Key = record.Key
+}
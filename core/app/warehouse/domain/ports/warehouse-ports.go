@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/warehouse/domain/entities"
+)
+
+// WarehouseSource reads records changed after the watermark, in watermark order
+type WarehouseSource interface {
+	ReadChanged(ctx context.Context, dataset string, after entities.Watermark, limit int) ([]entities.Record, error)
+}
+
+// WatermarkStore returns nil from Load when the dataset was never exported for the tenant
+type WatermarkStore interface {
+	Load(ctx context.Context, tenantID string, dataset string) (*entities.Watermark, error)
+	Save(ctx context.Context, watermark *entities.Watermark) error
+}
+
+// WarehouseSink stores exported objects, keys are relative paths like
+// "tenant=t1/dataset=users/v1/dt=2025-09-12/part-....csv"
+type WarehouseSink interface {
+	Put(ctx context.Context, key string, contentType string, content []byte) error
+}
@@ -0,0 +1,34 @@
+package authenticate_widget_key_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// AuthenticateWidgetKeyCommand is a widget request made with Key from a page of Origin, which is
+// empty when the request has neither Origin nor Referer. ClassID is only set for the calendar widget.
+type AuthenticateWidgetKeyCommand struct {
+	Key     string `validate:"required,max=100"`
+	Origin  string
+	Widget  string `validate:"required,oneof=calendar announcements"`
+	ClassID string `validate:"required_if=Widget calendar,omitempty,uuid"`
+}
+
+func NewAuthenticateWidgetKeyCommand(key string, origin string, widget string, classID string) (*AuthenticateWidgetKeyCommand, error) {
+	command := &AuthenticateWidgetKeyCommand{
+		Key:     key,
+		Origin:  origin,
+		Widget:  widget,
+		ClassID: classID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,39 @@
+package authenticate_widget_key_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	widgetErrors "github.com/nahualventure/class-backend/core/app/widget/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+)
+
+type AuthenticateWidgetKeyUseCase struct {
+	keys ports.WidgetKeyReader
+}
+
+func NewAuthenticateWidgetKeyUseCase(keys ports.WidgetKeyReader) *AuthenticateWidgetKeyUseCase {
+	return &AuthenticateWidgetKeyUseCase{
+		keys: keys,
+	}
+}
+
+// Execute returns the key when it is active, the request comes from one of its origins and it
+// unlocks the widget. Widget requests are served for the tenant of the key.
+func (uc *AuthenticateWidgetKeyUseCase) Execute(ctx context.Context, cmd *AuthenticateWidgetKeyCommand) (*entities.WidgetKey, error) {
+	key, err := uc.keys.FindByKey(ctx, cmd.Key)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if key == nil || !key.Active() {
+		return nil, widgetErrors.NewInvalidWidgetKeyError()
+	}
+	if !key.AllowsOrigin(cmd.Origin) {
+		return nil, widgetErrors.NewWidgetOriginNotAllowedError(cmd.Origin)
+	}
+	if !key.Allows(cmd.Widget, cmd.ClassID) {
+		return nil, widgetErrors.NewWidgetNotAllowedError(cmd.Widget, cmd.ClassID)
+	}
+	return key, nil
+}
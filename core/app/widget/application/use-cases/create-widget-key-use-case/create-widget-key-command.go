@@ -0,0 +1,40 @@
+package create_widget_key_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type CreateWidgetKeyCommand struct {
+	TenantID       string   `validate:"required"`
+	Name           string   `validate:"required,max=100"`
+	AllowedOrigins []string `validate:"required,min=1,max=20"`
+	Widgets        []string `validate:"required,min=1,dive,oneof=calendar announcements"`
+	ClassIDs       []string `validate:"max=50,dive,uuid"`
+	// RateLimitPerMinute is 0 for the default limit
+	RateLimitPerMinute int    `validate:"gte=0,lte=6000"`
+	CreatedBy          string `validate:"required"`
+}
+
+func NewCreateWidgetKeyCommand(tenantID string, name string, allowedOrigins []string, widgets []string, classIDs []string,
+	rateLimitPerMinute int, createdBy string) (*CreateWidgetKeyCommand, error) {
+	command := &CreateWidgetKeyCommand{
+		TenantID:           tenantID,
+		Name:               name,
+		AllowedOrigins:     allowedOrigins,
+		Widgets:            widgets,
+		ClassIDs:           classIDs,
+		RateLimitPerMinute: rateLimitPerMinute,
+		CreatedBy:          createdBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
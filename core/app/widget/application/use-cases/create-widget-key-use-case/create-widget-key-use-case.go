@@ -0,0 +1,34 @@
+package create_widget_key_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+)
+
+type CreateWidgetKeyUseCase struct {
+	keys ports.WidgetKeyRepository
+}
+
+func NewCreateWidgetKeyUseCase(keys ports.WidgetKeyRepository) *CreateWidgetKeyUseCase {
+	return &CreateWidgetKeyUseCase{
+		keys: keys,
+	}
+}
+
+func (uc *CreateWidgetKeyUseCase) Execute(ctx context.Context, cmd *CreateWidgetKeyCommand) (*entities.WidgetKey, error) {
+	key, err := entities.NewWidgetKey(cmd.TenantID, cmd.Name, cmd.AllowedOrigins, cmd.Widgets, cmd.ClassIDs,
+		cmd.RateLimitPerMinute, cmd.CreatedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.keys.Create(ctx, key); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return key, nil
+}
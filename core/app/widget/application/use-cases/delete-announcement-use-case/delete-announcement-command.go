@@ -0,0 +1,28 @@
+package delete_announcement_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type DeleteAnnouncementCommand struct {
+	TenantID       string `validate:"required"`
+	AnnouncementID string `validate:"required,uuid"`
+}
+
+func NewDeleteAnnouncementCommand(tenantID string, announcementID string) (*DeleteAnnouncementCommand, error) {
+	command := &DeleteAnnouncementCommand{
+		TenantID:       tenantID,
+		AnnouncementID: announcementID,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
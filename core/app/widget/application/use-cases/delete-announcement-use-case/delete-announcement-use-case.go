@@ -0,0 +1,30 @@
+package delete_announcement_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	widgetErrors "github.com/nahualventure/class-backend/core/app/widget/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+)
+
+type DeleteAnnouncementUseCase struct {
+	announcements ports.AnnouncementRepository
+}
+
+func NewDeleteAnnouncementUseCase(announcements ports.AnnouncementRepository) *DeleteAnnouncementUseCase {
+	return &DeleteAnnouncementUseCase{
+		announcements: announcements,
+	}
+}
+
+func (uc *DeleteAnnouncementUseCase) Execute(ctx context.Context, cmd *DeleteAnnouncementCommand) error {
+	deleted, err := uc.announcements.Delete(ctx, cmd.TenantID, cmd.AnnouncementID)
+	if err != nil {
+		return errors.PropagateError(err)
+	}
+	if !deleted {
+		return widgetErrors.NewAnnouncementNotFoundError(cmd.AnnouncementID)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package get_class_calendar_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// DefaultWeeks is how far ahead the calendar shows when no number of weeks is given
+const DefaultWeeks = 4
+
+type GetClassCalendarCommand struct {
+	TenantID string `validate:"required"`
+	ClassID  string `validate:"required,uuid"`
+	Weeks    int    `validate:"gte=1,lte=8"`
+}
+
+// NewGetClassCalendarCommand shows DefaultWeeks when weeks is 0
+func NewGetClassCalendarCommand(tenantID string, classID string, weeks int) (*GetClassCalendarCommand, error) {
+	if weeks == 0 {
+		weeks = DefaultWeeks
+	}
+	command := &GetClassCalendarCommand{
+		TenantID: tenantID,
+		ClassID:  classID,
+		Weeks:    weeks,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,47 @@
+package get_class_calendar_use_case
+
+import (
+	"context"
+	"time"
+
+	calendarPorts "github.com/nahualventure/class-backend/core/app/calendar/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	timezoneEntities "github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+)
+
+type GetClassCalendarUseCase struct {
+	meetings ports.ClassMeetings
+	calendar calendarPorts.SchoolCalendarReader
+}
+
+func NewGetClassCalendarUseCase(meetings ports.ClassMeetings, calendar calendarPorts.SchoolCalendarReader) *GetClassCalendarUseCase {
+	return &GetClassCalendarUseCase{
+		meetings: meetings,
+		calendar: calendar,
+	}
+}
+
+// Execute returns the sessions of the class from the start of today, in the zone of the school, for
+// the weeks of the command. Widget requests come from visitors, not users, so the days are always
+// those of the school.
+func (uc *GetClassCalendarUseCase) Execute(ctx context.Context, cmd *GetClassCalendarCommand) (*entities.ClassCalendar, error) {
+	meetings, err := uc.meetings.ListMeetings(ctx, cmd.TenantID, cmd.ClassID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	// The zone of the school comes with its calendar, the window read is padded by a day on each
+	// side to cover the days in any zone
+	now := time.Now()
+	calendar, err := uc.calendar.SchoolCalendar(ctx, cmd.TenantID, now.Add(-24*time.Hour), now.AddDate(0, 0, 7*cmd.Weeks+1))
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	today := now.In(calendar.Location())
+	from := timezoneEntities.WallClock(today.Year(), today.Month(), today.Day(), 0, calendar.Location())
+	to := timezoneEntities.WallClock(today.Year(), today.Month(), today.Day()+7*cmd.Weeks, 0, calendar.Location())
+
+	return entities.NewClassCalendar(cmd.ClassID, meetings, calendar, from, to), nil
+}
@@ -0,0 +1,38 @@
+package list_announcements_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// DefaultLimit is how many announcements are listed when no limit is given
+const DefaultLimit = 20
+
+type ListAnnouncementsCommand struct {
+	TenantID string `validate:"required"`
+	// CurrentOnly leaves the expired announcements out, the widget only shows current ones
+	CurrentOnly bool
+	Limit       int `validate:"gte=1,lte=100"`
+}
+
+// NewListAnnouncementsCommand lists DefaultLimit announcements when limit is 0
+func NewListAnnouncementsCommand(tenantID string, currentOnly bool, limit int) (*ListAnnouncementsCommand, error) {
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	command := &ListAnnouncementsCommand{
+		TenantID:    tenantID,
+		CurrentOnly: currentOnly,
+		Limit:       limit,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,29 @@
+package list_announcements_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+)
+
+type ListAnnouncementsUseCase struct {
+	announcements ports.AnnouncementRepository
+}
+
+func NewListAnnouncementsUseCase(announcements ports.AnnouncementRepository) *ListAnnouncementsUseCase {
+	return &ListAnnouncementsUseCase{
+		announcements: announcements,
+	}
+}
+
+// Execute returns the newest announcements of the tenant first
+func (uc *ListAnnouncementsUseCase) Execute(ctx context.Context, cmd *ListAnnouncementsCommand) ([]*entities.Announcement, error) {
+	announcements, err := uc.announcements.List(ctx, cmd.TenantID, cmd.CurrentOnly, time.Now().UTC(), cmd.Limit)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return announcements, nil
+}
@@ -0,0 +1,27 @@
+package list_widget_keys_use_case
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+)
+
+type ListWidgetKeysUseCase struct {
+	keys ports.WidgetKeyRepository
+}
+
+func NewListWidgetKeysUseCase(keys ports.WidgetKeyRepository) *ListWidgetKeysUseCase {
+	return &ListWidgetKeysUseCase{
+		keys: keys,
+	}
+}
+
+func (uc *ListWidgetKeysUseCase) Execute(ctx context.Context, cmd *ListWidgetKeysCommand) ([]*entities.WidgetKey, error) {
+	keys, err := uc.keys.List(ctx, cmd.TenantID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	return keys, nil
+}
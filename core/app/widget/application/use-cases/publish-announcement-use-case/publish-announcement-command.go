@@ -0,0 +1,36 @@
+package publish_announcement_use_case
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type PublishAnnouncementCommand struct {
+	TenantID    string `validate:"required"`
+	Title       string `validate:"required,max=200"`
+	Body        string `validate:"required,max=5000"`
+	ExpiresAt   *time.Time
+	PublishedBy string `validate:"required"`
+}
+
+func NewPublishAnnouncementCommand(tenantID string, title string, body string, expiresAt *time.Time, publishedBy string) (*PublishAnnouncementCommand, error) {
+	command := &PublishAnnouncementCommand{
+		TenantID:    tenantID,
+		Title:       title,
+		Body:        body,
+		ExpiresAt:   expiresAt,
+		PublishedBy: publishedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,33 @@
+package publish_announcement_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+)
+
+type PublishAnnouncementUseCase struct {
+	announcements ports.AnnouncementRepository
+}
+
+func NewPublishAnnouncementUseCase(announcements ports.AnnouncementRepository) *PublishAnnouncementUseCase {
+	return &PublishAnnouncementUseCase{
+		announcements: announcements,
+	}
+}
+
+func (uc *PublishAnnouncementUseCase) Execute(ctx context.Context, cmd *PublishAnnouncementCommand) (*entities.Announcement, error) {
+	announcement, err := entities.NewAnnouncement(cmd.TenantID, cmd.Title, cmd.Body, cmd.ExpiresAt, cmd.PublishedBy, time.Now().UTC())
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	if err := uc.announcements.Create(ctx, announcement); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return announcement, nil
+}
@@ -0,0 +1,30 @@
+package revoke_widget_key_use_case
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type RevokeWidgetKeyCommand struct {
+	TenantID  string `validate:"required"`
+	KeyID     string `validate:"required,uuid"`
+	RevokedBy string `validate:"required"`
+}
+
+func NewRevokeWidgetKeyCommand(tenantID string, keyID string, revokedBy string) (*RevokeWidgetKeyCommand, error) {
+	command := &RevokeWidgetKeyCommand{
+		TenantID:  tenantID,
+		KeyID:     keyID,
+		RevokedBy: revokedBy,
+	}
+
+	if err := utils.ValidateStruct(validate, command); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return command, nil
+}
@@ -0,0 +1,43 @@
+package revoke_widget_key_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	widgetErrors "github.com/nahualventure/class-backend/core/app/widget/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+)
+
+type RevokeWidgetKeyUseCase struct {
+	keys ports.WidgetKeyRepository
+}
+
+func NewRevokeWidgetKeyUseCase(keys ports.WidgetKeyRepository) *RevokeWidgetKeyUseCase {
+	return &RevokeWidgetKeyUseCase{
+		keys: keys,
+	}
+}
+
+// Execute revokes the key, widgets using it stop loading once the cached keys expire. Revoking a
+// revoked key changes nothing.
+func (uc *RevokeWidgetKeyUseCase) Execute(ctx context.Context, cmd *RevokeWidgetKeyCommand) (*entities.WidgetKey, error) {
+	key, err := uc.keys.Find(ctx, cmd.TenantID, cmd.KeyID)
+	if err != nil {
+		return nil, errors.PropagateError(err)
+	}
+	if key == nil {
+		return nil, widgetErrors.NewWidgetKeyNotFoundError(cmd.KeyID)
+	}
+	if !key.Active() {
+		return key, nil
+	}
+
+	key.Revoke(time.Now().UTC())
+	if err := uc.keys.Revoke(ctx, key, cmd.RevokedBy); err != nil {
+		return nil, errors.PropagateError(err)
+	}
+
+	return key, nil
+}
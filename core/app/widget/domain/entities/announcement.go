@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// Announcement is news of the school for its website, the announcements widget shows the ones not
+// expired yet
+type Announcement struct {
+	ID          string    `validate:"required,uuid"`
+	TenantID    string    `validate:"required"`
+	Title       string    `validate:"required,max=200"`
+	Body        string    `validate:"required,max=5000"`
+	PublishedBy string    `validate:"required"`
+	PublishedAt time.Time `validate:"required"`
+	// ExpiresAt is when the announcement leaves the widget, nil for announcements kept until deleted
+	ExpiresAt *time.Time
+}
+
+func NewAnnouncement(tenantID string, title string, body string, expiresAt *time.Time, publishedBy string, now time.Time) (*Announcement, error) {
+	announcement := &Announcement{
+		ID:          uuid.New().String(),
+		TenantID:    tenantID,
+		Title:       strings.TrimSpace(title),
+		Body:        strings.TrimSpace(body),
+		PublishedBy: publishedBy,
+		PublishedAt: now,
+		ExpiresAt:   expiresAt,
+	}
+
+	if err := validate.Struct(announcement); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("Announcement domain model instance not valid", errorMap, err)
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, appErrors.NewDomainEntityValidationError("Announcement domain model instance not valid",
+			map[string]any{"ExpiresAt": "must be in the future"}, errors.New("announcement already expired"))
+	}
+
+	return announcement, nil
+}
+
+// CurrentAt reports whether the announcement is shown at now
+func (a *Announcement) CurrentAt(now time.Time) bool {
+	return a.ExpiresAt == nil || a.ExpiresAt.After(now)
+}
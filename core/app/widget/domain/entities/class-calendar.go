@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	calendarEntities "github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	enrollmentEntities "github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	timezoneEntities "github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+)
+
+// ClassSession is one occurrence of a weekly meeting of a class
+type ClassSession struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+	// CancelledBy is the name of the non-instructional day the session falls on, empty when the
+	// session is held
+	CancelledBy string
+}
+
+// ClassCalendar is what the calendar widget shows of a class, its sessions in the zone of the school
+type ClassCalendar struct {
+	ClassID  string
+	Location *time.Location
+	Sessions []ClassSession
+}
+
+// NewClassCalendar generates the sessions of the weekly meetings that start from `from` up to, not
+// including, `to`, ordered by start. Sessions on non-instructional days are kept as cancelled, so
+// visitors of the website see why a class is not held.
+func NewClassCalendar(classID string, meetings []enrollmentEntities.MeetingTime, calendar *calendarEntities.SchoolCalendar,
+	from time.Time, to time.Time) *ClassCalendar {
+	loc := calendar.Location()
+	classCalendar := &ClassCalendar{ClassID: classID, Location: loc, Sessions: []ClassSession{}}
+	for _, meeting := range meetings {
+		startsAt := timezoneEntities.NextWeekly(meeting.Weekday, meeting.StartMinute, from.Add(-time.Nanosecond), loc)
+		for ; startsAt.Before(to); startsAt = timezoneEntities.NextWeekly(meeting.Weekday, meeting.StartMinute, startsAt, loc) {
+			session := ClassSession{
+				StartsAt: startsAt,
+				EndsAt:   timezoneEntities.WallClock(startsAt.Year(), startsAt.Month(), startsAt.Day(), meeting.EndMinute, loc),
+			}
+			if day := calendar.NonInstructionalDay(startsAt); day != nil {
+				session.CancelledBy = day.Name
+			}
+			classCalendar.Sessions = append(classCalendar.Sessions, session)
+		}
+	}
+	slices.SortFunc(classCalendar.Sessions, func(a, b ClassSession) int { return a.StartsAt.Compare(b.StartsAt) })
+	return classCalendar
+}
@@ -0,0 +1,177 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+var validate = validator.New()
+
+// KeyPrefix starts every widget key. Keys are publishable, they are embedded in the pages of the
+// school website and only unlock read-only widgets from the origins they list.
+const KeyPrefix = "pk_"
+
+// Widgets a key can unlock
+const (
+	WidgetCalendar      = "calendar"
+	WidgetAnnouncements = "announcements"
+)
+
+// DefaultRateLimitPerMinute is the limit of keys created without one
+const DefaultRateLimitPerMinute = 60
+
+// WidgetKey lets the pages of the origins it lists show widgets of a tenant without signing in.
+// The key is no secret, anyone reading the page has it, so the origin is what restricts it to the
+// websites of the school and the rate limit what bounds the requests made with it.
+type WidgetKey struct {
+	ID       string `validate:"required,uuid"`
+	TenantID string `validate:"required"`
+	Key      string `validate:"required"`
+	Name     string `validate:"required,max=100"`
+	// AllowedOrigins are scheme://host[:port], https://*.school.edu allows every subdomain of
+	// school.edu
+	AllowedOrigins []string `validate:"required,min=1,max=20"`
+	Widgets        []string `validate:"required,min=1,dive,oneof=calendar announcements"`
+	// ClassIDs are the classes whose calendar the key shows, the calendar widget needs at least one
+	ClassIDs           []string  `validate:"max=50,dive,uuid"`
+	RateLimitPerMinute int       `validate:"gte=1,lte=6000"`
+	CreatedBy          string    `validate:"required"`
+	CreatedAt          time.Time `validate:"required"`
+	RevokedAt          *time.Time
+}
+
+// NewWidgetKey generates a key for the widgets. Origins are normalized, a rate limit of 0 takes
+// DefaultRateLimitPerMinute.
+func NewWidgetKey(tenantID string, name string, allowedOrigins []string, widgets []string, classIDs []string,
+	rateLimitPerMinute int, createdBy string, now time.Time) (*WidgetKey, error) {
+	origins := make([]string, 0, len(allowedOrigins))
+	for _, raw := range allowedOrigins {
+		origin, ok := normalizeOrigin(raw, true)
+		if !ok {
+			return nil, appErrors.NewDomainEntityValidationError("WidgetKey domain model instance not valid",
+				map[string]any{"AllowedOrigins": raw + " is not an origin like https://www.school.edu"},
+				errors.Newf("invalid origin %s", raw))
+		}
+		if !slices.Contains(origins, origin) {
+			origins = append(origins, origin)
+		}
+	}
+	if rateLimitPerMinute == 0 {
+		rateLimitPerMinute = DefaultRateLimitPerMinute
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, appErrors.NewInfrastructureError("generate widget key", err)
+	}
+
+	key := &WidgetKey{
+		ID:                 uuid.New().String(),
+		TenantID:           tenantID,
+		Key:                KeyPrefix + base64.RawURLEncoding.EncodeToString(secret),
+		Name:               strings.TrimSpace(name),
+		AllowedOrigins:     origins,
+		Widgets:            slices.Compact(slices.Sorted(slices.Values(widgets))),
+		ClassIDs:           classIDs,
+		RateLimitPerMinute: rateLimitPerMinute,
+		CreatedBy:          createdBy,
+		CreatedAt:          now,
+	}
+
+	if err := validate.Struct(key); err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		errorMap := make(map[string]any)
+		for _, fe := range validationErrors {
+			errorMap[fe.Field()] = fe.Error()
+		}
+
+		return nil, appErrors.NewDomainEntityValidationError("WidgetKey domain model instance not valid", errorMap, err)
+	}
+	if slices.Contains(key.Widgets, WidgetCalendar) && len(key.ClassIDs) == 0 {
+		return nil, appErrors.NewDomainEntityValidationError("WidgetKey domain model instance not valid",
+			map[string]any{"ClassIDs": "the calendar widget needs the classes it shows"},
+			errors.New("calendar widget without classes"))
+	}
+
+	return key, nil
+}
+
+func (k *WidgetKey) Active() bool {
+	return k.RevokedAt == nil
+}
+
+// Revoke stops the key from working, revoking a revoked key keeps its first revocation
+func (k *WidgetKey) Revoke(now time.Time) {
+	if k.RevokedAt == nil {
+		k.RevokedAt = &now
+	}
+}
+
+// AllowsOrigin reports whether a page of the origin may use the key
+func (k *WidgetKey) AllowsOrigin(origin string) bool {
+	origin, ok := normalizeOrigin(origin, false)
+	if !ok {
+		return false
+	}
+	scheme, host, _ := strings.Cut(origin, "://")
+	for _, allowed := range k.AllowedOrigins {
+		if allowed == origin {
+			return true
+		}
+		allowedScheme, allowedHost, _ := strings.Cut(allowed, "://")
+		if suffix, wildcard := strings.CutPrefix(allowedHost, "*"); wildcard && allowedScheme == scheme && strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether the key unlocks the widget, for the calendar of the class
+func (k *WidgetKey) Allows(widget string, classID string) bool {
+	if !slices.Contains(k.Widgets, widget) {
+		return false
+	}
+	return widget != WidgetCalendar || slices.Contains(k.ClassIDs, classID)
+}
+
+// RequestOrigin is the origin of the page a request comes from, from its Origin header or, for the
+// requests browsers send without one, its Referer. It is empty when the request has neither.
+func RequestOrigin(origin string, referer string) string {
+	if origin != "" && origin != "null" {
+		return origin
+	}
+	parsed, err := url.Parse(referer)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return ""
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// normalizeOrigin lowercases an origin and drops the default port of its scheme, browsers send
+// https://school.edu for https://school.edu:443. Only allowed origins may have a *. wildcard.
+func normalizeOrigin(raw string, wildcard bool) (string, bool) {
+	parsed, err := url.Parse(strings.TrimSuffix(strings.TrimSpace(raw), "/"))
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Hostname() == "" ||
+		parsed.Path != "" || parsed.RawQuery != "" || parsed.Fragment != "" || parsed.User != nil {
+		return "", false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if strings.Contains(host, "*") && (!wildcard || !strings.HasPrefix(host, "*.") || strings.Count(host, "*") > 1) {
+		return "", false
+	}
+	if port := parsed.Port(); port != "" && !(parsed.Scheme == "https" && port == "443") && !(parsed.Scheme == "http" && port == "80") {
+		host += ":" + port
+	}
+	return parsed.Scheme + "://" + host, true
+}
@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	WidgetKeyNotFoundError errors2.ErrorCode = "WIDGET_KEY_NOT_FOUND"
+	// InvalidWidgetKeyError refuses a widget request with an unknown or revoked key
+	InvalidWidgetKeyError errors2.ErrorCode = "INVALID_WIDGET_KEY"
+	// WidgetOriginNotAllowedError refuses a widget request from a page of an origin the key does not
+	// list, or without an Origin or Referer
+	WidgetOriginNotAllowedError errors2.ErrorCode = "WIDGET_ORIGIN_NOT_ALLOWED"
+	// WidgetNotAllowedError refuses a widget, or the calendar of a class, the key does not unlock
+	WidgetNotAllowedError     errors2.ErrorCode = "WIDGET_NOT_ALLOWED"
+	AnnouncementNotFoundError errors2.ErrorCode = "ANNOUNCEMENT_NOT_FOUND"
+)
+
+func NewWidgetKeyNotFoundError(keyID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    WidgetKeyNotFoundError.String(),
+			Message: "Widget key not found",
+			Context: map[string]any{
+				"key_id": keyID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(WidgetKeyNotFoundError.String()),
+		},
+	}
+}
+
+// NewInvalidWidgetKeyError leaves the key out, unknown keys are not echoed back
+func NewInvalidWidgetKeyError() *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:       InvalidWidgetKeyError.String(),
+			Message:    "The widget key is not valid or was revoked",
+			OccurredAt: time.Now(),
+			Underlying: errors.New(InvalidWidgetKeyError.String()),
+		},
+	}
+}
+
+func NewWidgetOriginNotAllowedError(origin string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    WidgetOriginNotAllowedError.String(),
+			Message: "The widget key cannot be used from this website",
+			Context: map[string]any{
+				"origin": origin,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(WidgetOriginNotAllowedError.String()),
+		},
+	}
+}
+
+func NewWidgetNotAllowedError(widget string, classID string) *errors2.BaseDomainError {
+	context := map[string]any{
+		"widget": widget,
+	}
+	if classID != "" {
+		context["class_id"] = classID
+	}
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:       WidgetNotAllowedError.String(),
+			Message:    "The widget key does not unlock this widget",
+			Context:    context,
+			OccurredAt: time.Now(),
+			Underlying: errors.New(WidgetNotAllowedError.String()),
+		},
+	}
+}
+
+func NewAnnouncementNotFoundError(announcementID string) *errors2.BaseDomainError {
+	return &errors2.BaseDomainError{
+		BaseError: errors2.BaseError{
+			Code:    AnnouncementNotFoundError.String(),
+			Message: "Announcement not found",
+			Context: map[string]any{
+				"announcement_id": announcementID,
+			},
+			OccurredAt: time.Now(),
+			Underlying: errors.New(AnnouncementNotFoundError.String()),
+		},
+	}
+}
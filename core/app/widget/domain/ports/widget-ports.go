@@ -0,0 +1,42 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	enrollmentEntities "github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+)
+
+// WidgetKeyReader is what every widget request reads, implementations may cache it
+type WidgetKeyReader interface {
+	// FindByKey returns the key, revoked or not, nil when there is no such key
+	FindByKey(ctx context.Context, key string) (*entities.WidgetKey, error)
+}
+
+// WidgetKeyRepository saves the keys with an outbox event for the audit log
+type WidgetKeyRepository interface {
+	WidgetKeyReader
+	// Create stores the key with its widget_key.created event
+	Create(ctx context.Context, key *entities.WidgetKey) error
+	// Find returns the key of the tenant, nil when the tenant has no such key
+	Find(ctx context.Context, tenantID string, keyID string) (*entities.WidgetKey, error)
+	// List returns the keys of the tenant, revoked ones included, newest first
+	List(ctx context.Context, tenantID string) ([]*entities.WidgetKey, error)
+	// Revoke stores the revocation of the key with its widget_key.revoked event
+	Revoke(ctx context.Context, key *entities.WidgetKey, revokedBy string) error
+}
+
+type AnnouncementRepository interface {
+	Create(ctx context.Context, announcement *entities.Announcement) error
+	// List returns up to limit announcements of the tenant, newest first. With currentOnly the ones
+	// expired at now are left out.
+	List(ctx context.Context, tenantID string, currentOnly bool, now time.Time, limit int) ([]*entities.Announcement, error)
+	// Delete returns false when the tenant has no such announcement
+	Delete(ctx context.Context, tenantID string, announcementID string) (bool, error)
+}
+
+// ClassMeetings reads the weekly schedule of a class, which the enrollment module keeps
+type ClassMeetings interface {
+	ListMeetings(ctx context.Context, tenantID string, classID string) ([]enrollmentEntities.MeetingTime, error)
+}
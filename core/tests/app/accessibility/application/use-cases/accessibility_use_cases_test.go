@@ -0,0 +1,189 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	generate_captions_use_case "github.com/nahualventure/class-backend/core/app/accessibility/application/use-cases/generate-captions-use-case"
+	get_class_accessibility_report_use_case "github.com/nahualventure/class-backend/core/app/accessibility/application/use-cases/get-class-accessibility-report-use-case"
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryCaptions keeps the files pending until their captions are recorded, as the Postgres adapter does
+type memoryCaptions struct {
+	pending  []*entities.CaptionSource
+	statuses map[string]storage.CaptionStatus
+}
+
+func (m *memoryCaptions) ListPending(_ context.Context, limit int) ([]*entities.CaptionSource, error) {
+	return m.pending[:min(limit, len(m.pending))], nil
+}
+
+func (m *memoryCaptions) Record(_ context.Context, captions *entities.FileCaptions) (bool, error) {
+	for i, source := range m.pending {
+		if source.ID == captions.Source.ID {
+			m.pending = append(m.pending[:i:i], m.pending[i+1:]...)
+			m.statuses[source.ID] = captions.Status
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type memoryFiles struct {
+	files map[string][]byte
+}
+
+func (m *memoryFiles) Put(_ context.Context, key string, content []byte) error {
+	m.files[key] = content
+	return nil
+}
+
+func (m *memoryFiles) Get(_ context.Context, key string) ([]byte, error) {
+	return m.files[key], nil
+}
+
+func (m *memoryFiles) Delete(_ context.Context, key string) error {
+	delete(m.files, key)
+	return nil
+}
+
+// videoProvider transcribes MP4 videos. It cannot read damaged ones, is unavailable for the ones
+// named "outage" and answers "garbled" ones with captions that are not WebVTT.
+type videoProvider struct{}
+
+func (videoProvider) Supports(contentType string) bool {
+	return contentType == "video/mp4"
+}
+
+func (videoProvider) Transcribe(_ context.Context, _ string, content []byte) (*entities.Transcription, error) {
+	switch string(content) {
+	case "damaged":
+		return nil, errors.Wrap(entities.ErrUntranscribable, "no audio track")
+	case "outage":
+		return nil, errors.New("provider unavailable")
+	case "garbled":
+		return &entities.Transcription{Captions: []byte("1\n00:00:01,000 --> 00:00:02,000\nHello"), Transcript: "Hello"}, nil
+	}
+	return &entities.Transcription{
+		Captions:   []byte("WEBVTT\n\n00:00.000 --> 00:02.000\n" + string(content)),
+		Transcript: string(content),
+	}, nil
+}
+
+func source(id string, contentType string) *entities.CaptionSource {
+	return &entities.CaptionSource{
+		Kind:        entities.FileKindResourceVersion,
+		ID:          id,
+		TenantID:    "tenant1",
+		StorageKey:  "library/tenant1/r1/" + id,
+		ContentType: contentType,
+	}
+}
+
+func TestGenerateCaptions_RecordsEveryOutcome(t *testing.T) {
+	captions := &memoryCaptions{statuses: map[string]storage.CaptionStatus{}, pending: []*entities.CaptionSource{
+		source("lesson", "video/mp4"),
+		source("podcast", "audio/ogg"),
+		source("damaged", "video/mp4"),
+		source("garbled", "video/mp4"),
+		source("gone", "video/mp4"),
+	}}
+	files := &memoryFiles{files: map[string][]byte{
+		"library/tenant1/r1/lesson":  []byte("Fractions"),
+		"library/tenant1/r1/podcast": []byte("podcast"),
+		"library/tenant1/r1/damaged": []byte("damaged"),
+		"library/tenant1/r1/garbled": []byte("garbled"),
+	}}
+
+	generated, err := generate_captions_use_case.NewGenerateCaptionsUseCase(captions, videoProvider{}, files).Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, generated)
+	assert.Equal(t, map[string]storage.CaptionStatus{
+		"lesson":  storage.CaptionReady,
+		"podcast": storage.CaptionUnsupported,
+		"damaged": storage.CaptionFailed,
+		"garbled": storage.CaptionFailed,
+		"gone":    storage.CaptionFailed,
+	}, captions.statuses)
+	assert.Equal(t, "WEBVTT\n\n00:00.000 --> 00:02.000\nFractions", string(files.files[storage.AlternativeCaptions.Key("library/tenant1/r1/lesson")]))
+	assert.Equal(t, "Fractions", string(files.files[storage.AlternativeTranscript.Key("library/tenant1/r1/lesson")]))
+	assert.NotContains(t, files.files, storage.AlternativeCaptions.Key("library/tenant1/r1/garbled"))
+}
+
+func TestGenerateCaptions_RetriesWhenTheProviderFails(t *testing.T) {
+	captions := &memoryCaptions{statuses: map[string]storage.CaptionStatus{}, pending: []*entities.CaptionSource{
+		source("outage", "video/mp4"),
+		source("lesson", "video/mp4"),
+	}}
+	files := &memoryFiles{files: map[string][]byte{
+		"library/tenant1/r1/outage": []byte("outage"),
+		"library/tenant1/r1/lesson": []byte("Fractions"),
+	}}
+
+	generated, err := generate_captions_use_case.NewGenerateCaptionsUseCase(captions, videoProvider{}, files).Execute(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, generated, "the rest of the batch is generated")
+	assert.Len(t, captions.pending, 1)
+	assert.Equal(t, "outage", captions.pending[0].ID)
+}
+
+// memoryMaterials lists the same materials for every class
+type memoryMaterials []entities.ClassMaterial
+
+func (m memoryMaterials) ListMaterials(_ context.Context, _ string, _ string) ([]entities.ClassMaterial, error) {
+	return m, nil
+}
+
+func material(fileName string, contentType string, altText string, captionStatus storage.CaptionStatus) entities.ClassMaterial {
+	return entities.ClassMaterial{
+		ID:            uuid.NewString(),
+		MessageID:     uuid.NewString(),
+		FileName:      fileName,
+		ContentType:   contentType,
+		AltText:       altText,
+		CaptionStatus: captionStatus,
+		UploadedBy:    "teacher1",
+		UploadedAt:    time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestGetClassAccessibilityReport_ListsMaterialsThatDoNotComply(t *testing.T) {
+	materials := memoryMaterials{
+		material("map.png", "image/png", "Map of the field trip route", storage.CaptionNotNeeded),
+		material("old-chart.png", "image/png", "", storage.CaptionNotNeeded),
+		material("lesson.mp4", "video/mp4", "", storage.CaptionReady),
+		material("lab.mp4", "video/mp4", "", storage.CaptionPending),
+		material("interview.ogg", "audio/ogg", "", storage.CaptionUnsupported),
+		material("syllabus.pdf", "application/pdf", "", storage.CaptionNotNeeded),
+	}
+	classID := uuid.NewString()
+	command, err := get_class_accessibility_report_use_case.NewGetClassAccessibilityReportCommand("tenant1", classID)
+	assert.NoError(t, err)
+
+	report, err := get_class_accessibility_report_use_case.NewGetClassAccessibilityReportUseCase(materials).Execute(context.Background(), command)
+
+	assert.NoError(t, err)
+	assert.Equal(t, classID, report.ClassID)
+	assert.Equal(t, entities.MaterialCount{Total: 2, Compliant: 1}, report.Images)
+	assert.Equal(t, entities.MaterialCount{Total: 3, Compliant: 1}, report.Media)
+	assert.False(t, report.Compliant())
+	var issues []entities.Issue
+	for _, material := range report.Issues {
+		issues = append(issues, material.Issue())
+	}
+	assert.Equal(t, []entities.Issue{entities.IssueMissingAltText, entities.IssueCaptionsPending, entities.IssueMissingCaptions}, issues)
+
+	empty, err := get_class_accessibility_report_use_case.NewGetClassAccessibilityReportUseCase(memoryMaterials{}).Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.True(t, empty.Compliant())
+	assert.NotNil(t, empty.Issues)
+}
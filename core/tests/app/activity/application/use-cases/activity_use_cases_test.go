@@ -0,0 +1,147 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	list_activity_use_case "github.com/nahualventure/class-backend/core/app/activity/application/use-cases/list-activity-use-case"
+	record_activity_use_case "github.com/nahualventure/class-backend/core/app/activity/application/use-cases/record-activity-use-case"
+	"github.com/nahualventure/class-backend/core/app/activity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/activity/domain/ports"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryActivity filters and orders like the activity_events queries
+type memoryActivity struct {
+	events []*entities.ActivityEvent
+}
+
+func (m *memoryActivity) Append(_ context.Context, events []*entities.ActivityEvent) error {
+	for _, event := range events {
+		event.ID = int64(len(m.events) + 1)
+		m.events = append(m.events, event)
+	}
+	return nil
+}
+
+func (m *memoryActivity) List(_ context.Context, filter ports.ActivityFilter, limit int) ([]*entities.ActivityEvent, int64, error) {
+	var matching []*entities.ActivityEvent
+	for _, event := range m.events {
+		inTenant := event.TenantID == filter.TenantID || (event.TenantID == "" && filter.UserID != "" && event.UserID == filter.UserID)
+		if !inTenant || (filter.UserID != "" && event.UserID != filter.UserID) {
+			continue
+		}
+		if len(filter.Categories) > 0 && !slices.Contains(filter.Categories, event.Category) {
+			continue
+		}
+		if len(filter.Types) > 0 && !slices.Contains(filter.Types, event.Type) {
+			continue
+		}
+		if after := filter.After; after != nil && !(event.OccurredAt.Before(after.OccurredAt) ||
+			(event.OccurredAt.Equal(after.OccurredAt) && event.ID < after.ID)) {
+			continue
+		}
+		matching = append(matching, event)
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if !matching[i].OccurredAt.Equal(matching[j].OccurredAt) {
+			return matching[i].OccurredAt.After(matching[j].OccurredAt)
+		}
+		return matching[i].ID > matching[j].ID
+	})
+	total := int64(len(matching))
+	if len(matching) > limit {
+		matching = matching[:limit]
+	}
+	return matching, total, nil
+}
+
+func (m *memoryActivity) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+var start = time.Date(2025, 11, 4, 8, 0, 0, 0, time.UTC)
+
+func seed(t *testing.T, repo *memoryActivity) {
+	t.Helper()
+	events := []*entities.ActivityEvent{
+		{TenantID: "", UserID: "user-1", Category: entities.ActivityCategoryAuth, Type: entities.ActivityAuthSignedUp, OccurredAt: start},
+		{TenantID: "tenant1", UserID: "user-1", Category: entities.ActivityCategoryAuthorization, Type: entities.ActivityAuthorizationDenied, Resource: "list-users", OccurredAt: start.Add(time.Minute)},
+		{TenantID: "tenant1", UserID: "user-1", Category: entities.ActivityCategoryAudit, Type: "grade.recorded", Resource: "class-1", OccurredAt: start.Add(2 * time.Minute)},
+		// Two events at the same instant are ordered by ID so no page repeats or skips one
+		{TenantID: "tenant1", UserID: "user-2", Category: entities.ActivityCategoryAuthorization, Type: entities.ActivityAuthorizationGranted, Resource: "record-grade", OccurredAt: start.Add(2 * time.Minute)},
+		{TenantID: "tenant1", Category: entities.ActivityCategoryAuth, Type: entities.ActivityAuthUnauthenticated, Resource: "list-users", OccurredAt: start.Add(3 * time.Minute)},
+		{TenantID: "tenant2", UserID: "user-1", Category: entities.ActivityCategoryAuthorization, Type: entities.ActivityAuthorizationDenied, OccurredAt: start.Add(4 * time.Minute)},
+	}
+	command, err := record_activity_use_case.NewRecordActivityCommand(events)
+	assert.NoError(t, err)
+	assert.NoError(t, record_activity_use_case.NewRecordActivityUseCase(repo).Execute(context.Background(), command))
+}
+
+func list(t *testing.T, repo *memoryActivity, userID string, categories []string, types []string, pageSize int, cursor string) ([]string, string) {
+	t.Helper()
+	command, err := list_activity_use_case.NewListActivityCommand("tenant1", userID, categories, types, pageSize, cursor)
+	assert.NoError(t, err)
+	page, err := list_activity_use_case.NewListActivityUseCase(repo).Execute(context.Background(), command)
+	assert.NoError(t, err)
+	var listed []string
+	for _, event := range page.Items {
+		listed = append(listed, event.Type+"@"+event.Resource)
+	}
+	return listed, page.NextCursor
+}
+
+func TestListActivity_PagesThroughTheTenantNewestFirst(t *testing.T) {
+	repo := &memoryActivity{}
+	seed(t, repo)
+
+	first, cursor := list(t, repo, "", nil, nil, 2, "")
+	assert.Equal(t, []string{"auth.unauthenticated@list-users", "authorization.granted@record-grade"}, first)
+	assert.NotEmpty(t, cursor)
+
+	// Events recorded after the first page do not shift the next ones
+	repo.events = append(repo.events, &entities.ActivityEvent{ID: 100, TenantID: "tenant1", Type: "grade.recorded", OccurredAt: start.Add(time.Hour)})
+
+	second, cursor := list(t, repo, "", nil, nil, 2, cursor)
+	assert.Equal(t, []string{"grade.recorded@class-1", "authorization.denied@list-users"}, second)
+	assert.Empty(t, cursor)
+}
+
+func TestListActivity_FiltersByUserCategoryAndType(t *testing.T) {
+	repo := &memoryActivity{}
+	seed(t, repo)
+
+	user, _ := list(t, repo, "user-1", nil, nil, 20, "")
+	assert.Equal(t, []string{"grade.recorded@class-1", "authorization.denied@list-users", "auth.signed_up@"}, user,
+		"the sign up of the user has no tenant and is listed with it, other tenants are not")
+
+	auth, _ := list(t, repo, "", []string{"auth", "audit"}, nil, 20, "")
+	assert.Equal(t, []string{"auth.unauthenticated@list-users", "grade.recorded@class-1"}, auth)
+
+	denied, _ := list(t, repo, "", nil, []string{entities.ActivityAuthorizationDenied}, 20, "")
+	assert.Equal(t, []string{"authorization.denied@list-users"}, denied)
+}
+
+func TestListActivityCommand_Validation(t *testing.T) {
+	_, err := list_activity_use_case.NewListActivityCommand("tenant1", "", nil, nil, 20, "not a cursor")
+	assert.Error(t, err)
+
+	_, err = list_activity_use_case.NewListActivityCommand("tenant1", "", []string{"billing"}, nil, 20, "")
+	assert.Error(t, err)
+
+	_, err = list_activity_use_case.NewListActivityCommand("tenant1", "", nil, nil, 101, "")
+	assert.Error(t, err)
+
+	command, err := list_activity_use_case.NewListActivityCommand("tenant1", "", nil, nil, 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 20, command.PageSize)
+
+	cursor := entities.ActivityCursor{OccurredAt: start.Add(time.Microsecond), ID: 42}
+	parsed, err := entities.ParseActivityCursor(cursor.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, *parsed)
+}
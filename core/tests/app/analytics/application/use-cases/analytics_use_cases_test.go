@@ -0,0 +1,154 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	get_api_usage_use_case "github.com/nahualventure/class-backend/core/app/analytics/application/use-cases/get-api-usage-use-case"
+	record_api_usage_use_case "github.com/nahualventure/class-backend/core/app/analytics/application/use-cases/record-api-usage-use-case"
+	"github.com/nahualventure/class-backend/core/app/analytics/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+type memoryUsage struct {
+	counters []*entities.UsageCounter
+}
+
+func (m *memoryUsage) Add(_ context.Context, counters []*entities.UsageCounter) error {
+	m.counters = append(m.counters, counters...)
+	return nil
+}
+
+func (m *memoryUsage) SumByOperation(_ context.Context, tenant string, from time.Time, to time.Time) ([]*entities.UsageCounter, error) {
+	var counters []*entities.UsageCounter
+	for _, counter := range m.counters {
+		if counter.TenantID == tenant && !counter.Hour.Before(from) && counter.Hour.Before(to) {
+			counters = append(counters, counter)
+		}
+	}
+	return counters, nil
+}
+
+func (m *memoryUsage) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	var kept []*entities.UsageCounter
+	for _, counter := range m.counters {
+		if !counter.Hour.Before(before) {
+			kept = append(kept, counter)
+		}
+	}
+	deleted := int64(len(m.counters) - len(kept))
+	m.counters = kept
+	return deleted, nil
+}
+
+func counter(consumerID string, operationID string, hour time.Time, requests int64, clientErrors int64) *entities.UsageCounter {
+	return &entities.UsageCounter{
+		TenantID:     tenantID,
+		ConsumerKind: entities.ConsumerUser,
+		ConsumerID:   consumerID,
+		OperationID:  operationID,
+		Hour:         hour,
+		Requests:     requests,
+		ClientErrors: clientErrors,
+		Latency:      time.Duration(requests) * 10 * time.Millisecond,
+	}
+}
+
+func record(t *testing.T, store *memoryUsage, counters ...*entities.UsageCounter) {
+	t.Helper()
+	cmd, err := record_api_usage_use_case.NewRecordAPIUsageCommand(counters)
+	assert.NoError(t, err)
+	assert.NoError(t, record_api_usage_use_case.NewRecordAPIUsageUseCase(store).Execute(context.Background(), cmd))
+}
+
+func TestRecordAPIUsage_RejectsIncompleteCounters(t *testing.T) {
+	_, err := record_api_usage_use_case.NewRecordAPIUsageCommand(nil)
+	assert.Error(t, err)
+
+	_, err = record_api_usage_use_case.NewRecordAPIUsageCommand([]*entities.UsageCounter{{TenantID: tenantID, Requests: 1}})
+	assert.Error(t, err)
+}
+
+func TestGetAPIUsage_ReportsBusiestConsumersWithTopEndpoints(t *testing.T) {
+	store := &memoryUsage{}
+	day := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	record(t, store,
+		counter("teacher-1", "list-class-grades", day, 30, 0),
+		counter("teacher-1", "record-grade", day, 10, 1),
+		counter("teacher-1", "list-class-grades", day.Add(time.Hour), 20, 0),
+		counter("student-1", "list-my-grades", day, 5, 0),
+		counter("student-2", "list-my-grades", day.AddDate(0, 0, 5), 99, 0),
+	)
+
+	cmd, err := get_api_usage_use_case.NewGetAPIUsageCommand(tenantID, "2026-10-01", "2026-10-02", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, get_api_usage_use_case.DefaultConsumers, cmd.Consumers)
+
+	report, err := get_api_usage_use_case.NewGetAPIUsageUseCase(store, entities.AbuseThresholds{}).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(65), report.TotalRequests)
+	assert.Len(t, report.Consumers, 2)
+
+	teacher := report.Consumers[0]
+	assert.Equal(t, "teacher-1", teacher.ConsumerID)
+	assert.Equal(t, int64(60), teacher.Requests)
+	assert.InDelta(t, 1.0/60, teacher.ErrorRate(), 0.0001)
+	assert.Equal(t, 10*time.Millisecond, teacher.AverageLatency())
+	assert.Equal(t, "list-class-grades", teacher.Endpoints[0].OperationID)
+	assert.Equal(t, int64(50), teacher.Endpoints[0].Requests)
+	assert.Empty(t, teacher.Flags)
+}
+
+func TestGetAPIUsage_FlagsAbusiveConsumersPastTheLimit(t *testing.T) {
+	store := &memoryUsage{}
+	hour := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	record(t, store,
+		counter("scraper", "list-users", hour, 5000, 0),
+		counter("quiet", "list-my-grades", hour, 10, 0),
+		counter("prober", "get-form", hour, 40, 35),
+	)
+
+	cmd, err := get_api_usage_use_case.NewGetAPIUsageCommand(tenantID, "2026-10-01", "2026-10-01", 1)
+	assert.NoError(t, err)
+
+	report, err := get_api_usage_use_case.NewGetAPIUsageUseCase(store, entities.AbuseThresholds{
+		MaxRequestsPerHour: 100,
+		MaxErrorRate:       0.5,
+		MinRequests:        20,
+	}).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+
+	var listed []string
+	for _, consumer := range report.Consumers {
+		listed = append(listed, consumer.ConsumerID)
+	}
+	assert.Equal(t, []string{"scraper", "prober"}, listed)
+	assert.Equal(t, []string{entities.FlagHighVolume}, report.Consumers[0].Flags)
+	assert.Equal(t, []string{entities.FlagHighErrorRate}, report.Consumers[1].Flags)
+}
+
+func TestGetAPIUsage_RejectsLongRanges(t *testing.T) {
+	_, err := get_api_usage_use_case.NewGetAPIUsageCommand(tenantID, "2026-01-01", "2026-06-30", 10)
+	assert.Error(t, err)
+
+	_, err = get_api_usage_use_case.NewGetAPIUsageCommand(tenantID, "2026-10-02", "2026-10-01", 10)
+	assert.Error(t, err)
+}
+
+func TestRecordAPIUsage_PurgesExpiredCounters(t *testing.T) {
+	store := &memoryUsage{}
+	now := time.Now().UTC().Truncate(time.Hour)
+	record(t, store,
+		counter("teacher-1", "list-class-grades", now.Add(-record_api_usage_use_case.UsageRetention-time.Hour), 1, 0),
+		counter("teacher-1", "list-class-grades", now, 1, 0),
+	)
+
+	deleted, err := record_api_usage_use_case.NewRecordAPIUsageUseCase(store).PurgeExpired(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.Len(t, store.counters, 1)
+}
@@ -0,0 +1,219 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	list_archive_batches_use_case "github.com/nahualventure/class-backend/core/app/archive/application/use-cases/list-archive-batches-use-case"
+	restore_archive_batch_use_case "github.com/nahualventure/class-backend/core/app/archive/application/use-cases/restore-archive-batch-use-case"
+	run_archival_use_case "github.com/nahualventure/class-backend/core/app/archive/application/use-cases/run-archival-use-case"
+	"github.com/nahualventure/class-backend/core/app/archive/domain/entities"
+	archiveErrors "github.com/nahualventure/class-backend/core/app/archive/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+// memoryArchive keeps the recorded_at of hot records per kind and of archived records per batch.
+// Records in clashing stay archived on restore.
+type memoryArchive struct {
+	hot      map[entities.ArchiveKind][]time.Time
+	archived map[string][]time.Time
+	batches  []*entities.ArchiveBatch
+	clashing int
+}
+
+func newMemoryArchive() *memoryArchive {
+	return &memoryArchive{
+		hot:      make(map[entities.ArchiveKind][]time.Time),
+		archived: make(map[string][]time.Time),
+	}
+}
+
+func (m *memoryArchive) Archive(_ context.Context, batch *entities.ArchiveBatch, limit int) (int, error) {
+	var kept, moved []time.Time
+	for _, recordedAt := range m.hot[batch.Kind] {
+		if recordedAt.Before(batch.Cutoff) && len(moved) < limit {
+			moved = append(moved, recordedAt)
+		} else {
+			kept = append(kept, recordedAt)
+		}
+	}
+	if len(moved) == 0 {
+		return 0, nil
+	}
+
+	m.hot[batch.Kind] = kept
+	m.archived[batch.ID] = moved
+	batch.RecordCount = len(moved)
+	m.batches = append(m.batches, batch)
+	return len(moved), nil
+}
+
+func (m *memoryArchive) Restore(_ context.Context, batch *entities.ArchiveBatch) (int, int, error) {
+	records := m.archived[batch.ID]
+	remaining := min(m.clashing, len(records))
+	restored := records[remaining:]
+	m.hot[batch.Kind] = append(m.hot[batch.Kind], restored...)
+	m.archived[batch.ID] = records[:remaining]
+	return len(restored), remaining, nil
+}
+
+func (m *memoryArchive) SaveBatch(_ context.Context, _ *entities.ArchiveBatch) error {
+	return nil
+}
+
+func (m *memoryArchive) FindBatch(_ context.Context, tenantID string, batchID string) (*entities.ArchiveBatch, error) {
+	for _, batch := range m.batches {
+		if batch.TenantID == tenantID && batch.ID == batchID {
+			return batch, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryArchive) ListBatches(_ context.Context, tenantID string, kind entities.ArchiveKind) ([]*entities.ArchiveBatch, error) {
+	var batches []*entities.ArchiveBatch
+	for _, batch := range m.batches {
+		if batch.TenantID == tenantID && (kind == "" || batch.Kind == kind) {
+			batches = append(batches, batch)
+		}
+	}
+	return batches, nil
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func runArchival(t *testing.T, store *memoryArchive, now time.Time, batchSize int) []*entities.ArchiveBatch {
+	t.Helper()
+	cmd, err := run_archival_use_case.NewRunArchivalCommand(tenantID, now, int(time.August))
+	assert.NoError(t, err)
+	batches, err := run_archival_use_case.NewRunArchivalUseCase(store, batchSize).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return batches
+}
+
+func restoreBatch(store *memoryArchive, batchID string) (*entities.ArchiveBatch, error) {
+	cmd, err := restore_archive_batch_use_case.NewRestoreArchiveBatchCommand(tenantID, batchID, "admin-1")
+	if err != nil {
+		return nil, err
+	}
+	return restore_archive_batch_use_case.NewRestoreArchiveBatchUseCase(store).Execute(context.Background(), cmd)
+}
+
+func TestPriorYearCutoff(t *testing.T) {
+	// School years starting in August, before August the school year started the year before
+	assert.Equal(t, time.Date(2024, time.August, 1, 0, 0, 0, 0, time.UTC),
+		entities.PriorYearCutoff(time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC), time.August))
+	assert.Equal(t, time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC),
+		entities.PriorYearCutoff(time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC), time.August))
+	// School years starting in January follow the calendar year
+	assert.Equal(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		entities.PriorYearCutoff(time.Date(2025, time.December, 31, 23, 0, 0, 0, time.UTC), time.January))
+}
+
+func TestRunArchival_MovesPriorYearRecordsInBatches(t *testing.T) {
+	store := newMemoryArchive()
+	now := time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)
+	lastYear := time.Date(2025, time.May, 20, 0, 0, 0, 0, time.UTC)
+	thisYear := time.Date(2025, time.September, 2, 0, 0, 0, 0, time.UTC)
+	for range 5 {
+		store.hot[entities.ArchiveKindSubmissions] = append(store.hot[entities.ArchiveKindSubmissions], lastYear)
+	}
+	store.hot[entities.ArchiveKindSubmissions] = append(store.hot[entities.ArchiveKindSubmissions], thisYear)
+	store.hot[entities.ArchiveKindMessages] = []time.Time{lastYear, thisYear}
+
+	batches := runArchival(t, store, now, 2)
+
+	counts := make(map[entities.ArchiveKind][]int)
+	for _, batch := range batches {
+		counts[batch.Kind] = append(counts[batch.Kind], batch.RecordCount)
+		assert.Equal(t, time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC), batch.Cutoff)
+		assert.Equal(t, entities.ArchiveBatchStatusArchived, batch.Status)
+	}
+	assert.Equal(t, []int{2, 2, 1}, counts[entities.ArchiveKindSubmissions])
+	assert.Equal(t, []int{1}, counts[entities.ArchiveKindMessages])
+	assert.Empty(t, counts[entities.ArchiveKindAudit])
+	assert.Equal(t, []time.Time{thisYear}, store.hot[entities.ArchiveKindSubmissions])
+
+	// Running again finds nothing left to archive
+	assert.Empty(t, runArchival(t, store, now, 2))
+}
+
+func TestRunArchival_RejectsInvalidSchoolYearStart(t *testing.T) {
+	_, err := run_archival_use_case.NewRunArchivalCommand(tenantID, time.Now(), 13)
+	assert.Error(t, err)
+}
+
+func TestRestoreArchiveBatch(t *testing.T) {
+	store := newMemoryArchive()
+	store.hot[entities.ArchiveKindMessages] = []time.Time{time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)}
+	batches := runArchival(t, store, time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC), 10)
+	assert.Len(t, batches, 1)
+
+	batch, err := restoreBatch(store, batches[0].ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ArchiveBatchStatusRestored, batch.Status)
+	assert.Equal(t, 1, batch.RestoredCount)
+	assert.Equal(t, "admin-1", batch.RestoredBy)
+	assert.NotNil(t, batch.RestoredAt)
+	assert.Len(t, store.hot[entities.ArchiveKindMessages], 1)
+
+	_, err = restoreBatch(store, batches[0].ID)
+	assert.Equal(t, archiveErrors.ArchiveBatchAlreadyRestoredError.String(), codeOf(err))
+}
+
+func TestRestoreArchiveBatch_PartialRestoreCanBeRetried(t *testing.T) {
+	store := newMemoryArchive()
+	lastYear := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	store.hot[entities.ArchiveKindSubmissions] = []time.Time{lastYear, lastYear, lastYear}
+	batches := runArchival(t, store, time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC), 10)
+
+	// A grade recorded again for the same student and assignment blocks its archived copy
+	store.clashing = 1
+	batch, err := restoreBatch(store, batches[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ArchiveBatchStatusPartiallyRestored, batch.Status)
+	assert.Equal(t, 2, batch.RestoredCount)
+
+	store.clashing = 0
+	batch, err = restoreBatch(store, batches[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ArchiveBatchStatusRestored, batch.Status)
+	assert.Equal(t, 3, batch.RestoredCount)
+}
+
+func TestRestoreArchiveBatch_NotFound(t *testing.T) {
+	_, err := restoreBatch(newMemoryArchive(), "5f0f7a52-8d8c-4c53-9a3e-2c7a4c0b1d11")
+	assert.Equal(t, archiveErrors.ArchiveBatchNotFoundError.String(), codeOf(err))
+}
+
+func TestListArchiveBatches_FiltersByKind(t *testing.T) {
+	store := newMemoryArchive()
+	lastYear := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	store.hot[entities.ArchiveKindSubmissions] = []time.Time{lastYear}
+	store.hot[entities.ArchiveKindAudit] = []time.Time{lastYear}
+	runArchival(t, store, time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC), 10)
+
+	cmd, err := list_archive_batches_use_case.NewListArchiveBatchesCommand(tenantID, "audit")
+	assert.NoError(t, err)
+	batches, err := list_archive_batches_use_case.NewListArchiveBatchesUseCase(store).Execute(context.Background(), cmd)
+
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, entities.ArchiveKindAudit, batches[0].Kind)
+
+	_, err = list_archive_batches_use_case.NewListArchiveBatchesCommand(tenantID, "users")
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	check_in_use_case "github.com/nahualventure/class-backend/core/app/attendance/application/use-cases/check-in-use-case"
+	open_check_in_use_case "github.com/nahualventure/class-backend/core/app/attendance/application/use-cases/open-check-in-use-case"
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/entities"
+	attendanceErrors "github.com/nahualventure/class-backend/core/app/attendance/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const classID = "0b7f2c4e-9d1a-4c3e-8f6b-2a5d7e9c1b30"
+
+// memorySessions keeps one check-in session per class session, as the unique key of the table does
+type memorySessions struct {
+	sessions map[string]*entities.CheckInSession
+}
+
+func (m *memorySessions) Create(_ context.Context, session *entities.CheckInSession) (*entities.CheckInSession, error) {
+	for _, existing := range m.sessions {
+		if existing.ClassID == session.ClassID && existing.StartsAt.Equal(session.StartsAt) {
+			return existing, nil
+		}
+	}
+	m.sessions[session.ID] = session
+	return session, nil
+}
+
+func (m *memorySessions) Find(_ context.Context, tenantID string, sessionID string) (*entities.CheckInSession, error) {
+	if session, ok := m.sessions[sessionID]; ok && session.TenantID == tenantID {
+		return session, nil
+	}
+	return nil, nil
+}
+
+// todaySessions has the class meet for two hours from the start of the current hour
+type todaySessions struct{}
+
+func (todaySessions) SessionsOn(_ context.Context, _ string, classID string, _ time.Time, _ *time.Location) ([]entities.ClassSession, error) {
+	startsAt := time.Now().UTC().Truncate(time.Hour)
+	return []entities.ClassSession{{ClassID: classID, StartsAt: startsAt, EndsAt: startsAt.Add(2 * time.Hour)}}, nil
+}
+
+type memoryRoster map[string]bool
+
+func (m memoryRoster) IsEnrolled(_ context.Context, _ string, _ string, userID string) (bool, error) {
+	return m[userID], nil
+}
+
+// memoryAttendance keeps the first record of every student
+type memoryAttendance struct {
+	records map[string]*entities.AttendanceRecord
+}
+
+func (m *memoryAttendance) Record(_ context.Context, record *entities.AttendanceRecord) (*entities.AttendanceRecord, error) {
+	if existing, ok := m.records[record.StudentID]; ok {
+		return existing, nil
+	}
+	m.records[record.StudentID] = record
+	return record, nil
+}
+
+func openCheckIn(t *testing.T, sessions *memorySessions, fence *entities.GeoFence) *entities.CheckInSession {
+	command, err := open_check_in_use_case.NewOpenCheckInCommand("tenant1", classID, "teacher", time.UTC, fence)
+	assert.NoError(t, err)
+	session, err := open_check_in_use_case.NewOpenCheckInUseCase(sessions, todaySessions{}).Execute(context.Background(), command)
+	assert.NoError(t, err)
+	return session
+}
+
+func checkIn(useCase *check_in_use_case.CheckInUseCase, session *entities.CheckInSession, studentID string, code string,
+	latitude *float64, longitude *float64) (*entities.AttendanceRecord, error) {
+	command, err := check_in_use_case.NewCheckInCommand("tenant1", session.ID, studentID, code, latitude, longitude)
+	if err != nil {
+		return nil, err
+	}
+	return useCase.Execute(context.Background(), command)
+}
+
+func assertCode(t *testing.T, code appErrors.ErrorCode, err error) {
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, code.String(), appErr.GetCode())
+	}
+}
+
+func TestOpenCheckIn_ReturnsTheSessionAlreadyOpen(t *testing.T) {
+	sessions := &memorySessions{sessions: map[string]*entities.CheckInSession{}}
+
+	first := openCheckIn(t, sessions, nil)
+	second := openCheckIn(t, sessions, nil)
+
+	assert.Equal(t, first.ID, second.ID)
+	assert.Len(t, sessions.sessions, 1)
+}
+
+func TestCheckIn_RecordsEnrolledStudentsOnce(t *testing.T) {
+	sessions := &memorySessions{sessions: map[string]*entities.CheckInSession{}}
+	attendance := &memoryAttendance{records: map[string]*entities.AttendanceRecord{}}
+	useCase := check_in_use_case.NewCheckInUseCase(sessions, memoryRoster{"alice": true}, attendance)
+	session := openCheckIn(t, sessions, nil)
+	code, _ := session.Code(time.Now().UTC())
+
+	first, err := checkIn(useCase, session, "alice", code, nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, first.Present)
+	assert.Equal(t, entities.CheckInMethodQR, first.Method)
+
+	again, err := checkIn(useCase, session, "alice", code, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, first.RecordedAt, again.RecordedAt, "the first record is kept")
+
+	_, err = checkIn(useCase, session, "mallory", code, nil, nil)
+	assertCode(t, attendanceErrors.NotEnrolledInClassError, err)
+	assert.Len(t, attendance.records, 1)
+}
+
+func TestCheckIn_RejectsExpiredCodes(t *testing.T) {
+	sessions := &memorySessions{sessions: map[string]*entities.CheckInSession{}}
+	useCase := check_in_use_case.NewCheckInUseCase(sessions, memoryRoster{"alice": true},
+		&memoryAttendance{records: map[string]*entities.AttendanceRecord{}})
+	session := openCheckIn(t, sessions, nil)
+	now := time.Now().UTC()
+
+	previous, _ := session.Code(now.Add(-entities.CodePeriod))
+	_, err := checkIn(useCase, session, "alice", previous, nil, nil)
+	assert.NoError(t, err, "the previous code is still accepted")
+
+	expired, _ := session.Code(now.Add(-3 * entities.CodePeriod))
+	_, err = checkIn(useCase, session, "bob", expired, nil, nil)
+	assertCode(t, attendanceErrors.InvalidCheckInCodeError, err)
+}
+
+func TestCheckIn_RequiresTheLocationInsideTheFence(t *testing.T) {
+	sessions := &memorySessions{sessions: map[string]*entities.CheckInSession{}}
+	useCase := check_in_use_case.NewCheckInUseCase(sessions, memoryRoster{"alice": true},
+		&memoryAttendance{records: map[string]*entities.AttendanceRecord{}})
+	session := openCheckIn(t, sessions, &entities.GeoFence{Latitude: 14.6349, Longitude: -90.5069, RadiusMeters: 100})
+	code, _ := session.Code(time.Now().UTC())
+
+	_, err := checkIn(useCase, session, "alice", code, nil, nil)
+	assertCode(t, attendanceErrors.OutsideCheckInLocationError, err)
+
+	farLatitude, farLongitude := 14.6449, -90.5069
+	_, err = checkIn(useCase, session, "alice", code, &farLatitude, &farLongitude)
+	assertCode(t, attendanceErrors.OutsideCheckInLocationError, err)
+
+	nearLatitude, nearLongitude := 14.6352, -90.5070
+	_, err = checkIn(useCase, session, "alice", code, &nearLatitude, &nearLongitude)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,128 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	list_audit_entries_use_case "github.com/nahualventure/class-backend/core/app/audit/application/use-cases/list-audit-entries-use-case"
+	verify_audit_log_use_case "github.com/nahualventure/class-backend/core/app/audit/application/use-cases/verify-audit-log-use-case"
+	"github.com/nahualventure/class-backend/core/app/audit/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryAuditLog keeps the chains of the tenants in sequence order and counts the pages read
+type memoryAuditLog struct {
+	entries []*entities.AuditEntry
+	pages   int
+	err     error
+}
+
+func (l *memoryAuditLog) ListAfter(_ context.Context, tenantID string, afterSequence int64, limit int) ([]*entities.AuditEntry, error) {
+	l.pages++
+	if l.err != nil {
+		return nil, l.err
+	}
+	var page []*entities.AuditEntry
+	for _, entry := range l.entries {
+		if entry.TenantID == tenantID && entry.Sequence > afterSequence && len(page) < limit {
+			page = append(page, entry)
+		}
+	}
+	return page, nil
+}
+
+func chain(t *testing.T, tenantID string, length int) []*entities.AuditEntry {
+	t.Helper()
+	occurredAt := time.Date(2025, 11, 2, 9, 0, 0, 0, time.UTC)
+	var entries []*entities.AuditEntry
+	var prev *entities.AuditEntry
+	for i := range length {
+		entry, err := entities.NewAuditEntry(prev, tenantID, int64(i+1), "grade.recorded", "class-1",
+			map[string]any{"score": float64(i)}, occurredAt.Add(time.Duration(i)*time.Second))
+		assert.NoError(t, err)
+		entries = append(entries, entry)
+		prev = entry
+	}
+	return entries
+}
+
+func TestListAuditEntries_PagesThroughTheChainOfTheTenant(t *testing.T) {
+	log := &memoryAuditLog{entries: append(chain(t, "tenant1", 5), chain(t, "tenant2", 3)...)}
+	uc := list_audit_entries_use_case.NewListAuditEntriesUseCase(log)
+
+	cmd, err := list_audit_entries_use_case.NewListAuditEntriesCommand("tenant1", 2, 2)
+	assert.NoError(t, err)
+	entries, err := uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, int64(3), entries[0].Sequence)
+		assert.Equal(t, int64(4), entries[1].Sequence)
+		assert.Equal(t, "tenant1", entries[1].TenantID)
+	}
+
+	log.err = errors.New("connection refused")
+	_, err = uc.Execute(context.Background(), cmd)
+	assert.Error(t, err)
+}
+
+func TestNewListAuditEntriesCommand_Validates(t *testing.T) {
+	tests := []struct {
+		name          string
+		tenantID      string
+		afterSequence int64
+		limit         int
+		valid         bool
+	}{
+		{"first page", "tenant1", 0, 100, true},
+		{"largest page", "tenant1", 10, 1000, true},
+		{"without tenant", "", 0, 100, false},
+		{"negative sequence", "tenant1", -1, 100, false},
+		{"empty page", "tenant1", 0, 0, false},
+		{"page too large", "tenant1", 0, 1001, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := list_audit_entries_use_case.NewListAuditEntriesCommand(tt.tenantID, tt.afterSequence, tt.limit)
+			assert.Equal(t, tt.valid, err == nil, err)
+		})
+	}
+}
+
+func TestVerifyAuditLog_WalksEveryPage(t *testing.T) {
+	log := &memoryAuditLog{entries: chain(t, "tenant1", 1500)}
+	uc := verify_audit_log_use_case.NewVerifyAuditLogUseCase(log)
+	cmd, err := verify_audit_log_use_case.NewVerifyAuditLogCommand("tenant1")
+	assert.NoError(t, err)
+
+	verifier, err := uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Nil(t, verifier.Break)
+	assert.Equal(t, int64(1500), verifier.Entries)
+	assert.Equal(t, log.entries[1499].Hash, verifier.HeadHash)
+	assert.Equal(t, 2, log.pages)
+}
+
+func TestVerifyAuditLog_StopsAtTheFirstBreak(t *testing.T) {
+	entries := chain(t, "tenant1", 5)
+	entries[2].Payload = `{"score":100}`
+	log := &memoryAuditLog{entries: entries}
+	uc := verify_audit_log_use_case.NewVerifyAuditLogUseCase(log)
+	cmd, _ := verify_audit_log_use_case.NewVerifyAuditLogCommand("tenant1")
+
+	verifier, err := uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	if assert.NotNil(t, verifier.Break) {
+		assert.Equal(t, int64(3), verifier.Break.Sequence)
+	}
+	assert.Equal(t, int64(2), verifier.Entries)
+
+	log.err = errors.New("connection refused")
+	_, err = uc.Execute(context.Background(), cmd)
+	assert.Error(t, err)
+
+	_, err = verify_audit_log_use_case.NewVerifyAuditLogCommand("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/audit/domain/entities"
+	auditHandlers "github.com/nahualventure/class-backend/infra/audit/handlers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var occurredAt = time.Date(2025, 11, 2, 9, 14, 17, 123456789, time.UTC)
+
+func chain(t *testing.T, tenantID string, length int) []*entities.AuditEntry {
+	t.Helper()
+	var entries []*entities.AuditEntry
+	var prev *entities.AuditEntry
+	for i := 0; i < length; i++ {
+		entry, err := entities.NewAuditEntry(prev, tenantID, int64(10+i), "grade.recorded", "class-1",
+			map[string]any{"student_id": "student-1", "score": float64(70 + i)}, occurredAt.Add(time.Duration(i)*time.Minute))
+		assert.NoError(t, err)
+		entries = append(entries, entry)
+		prev = entry
+	}
+	return entries
+}
+
+func verify(entries []*entities.AuditEntry) *entities.ChainVerifier {
+	verifier := entities.NewChainVerifier("tenant1", 0, entities.GenesisHash)
+	for _, entry := range entries {
+		verifier.Add(entry)
+	}
+	return verifier
+}
+
+func TestAuditEntry_ChainsEveryEntryToThePreviousOne(t *testing.T) {
+	entries := chain(t, "tenant1", 3)
+
+	assert.Equal(t, entities.GenesisHash, entries[0].PrevHash)
+	assert.Equal(t, entries[1].Hash, entries[2].PrevHash)
+	assert.Equal(t, int64(3), entries[2].Sequence)
+	assert.Equal(t, `{"score":72,"student_id":"student-1"}`, entries[2].Payload, "payload keys are sorted")
+	assert.Equal(t, occurredAt.Truncate(time.Microsecond), entries[0].OccurredAt)
+
+	verifier := verify(entries)
+	assert.Nil(t, verifier.Break)
+	assert.Equal(t, int64(3), verifier.Entries)
+	assert.Equal(t, entries[2].Hash, verifier.HeadHash)
+}
+
+func TestChainVerifier_FindsTheFirstTamperedEntry(t *testing.T) {
+	changed := chain(t, "tenant1", 3)
+	changed[1].Payload = `{"score":100,"student_id":"student-1"}`
+	verifier := verify(changed)
+	assert.Equal(t, int64(2), verifier.Break.Sequence)
+	assert.Equal(t, int64(1), verifier.Entries)
+
+	// Recomputing the hash of a changed entry breaks the link of the next one
+	rehashed := chain(t, "tenant1", 3)
+	rehashed[1].Payload = `{"score":100,"student_id":"student-1"}`
+	rehashed[1].Hash = rehashed[1].ComputeHash()
+	assert.Equal(t, int64(3), verify(rehashed).Break.Sequence)
+
+	removed := chain(t, "tenant1", 3)
+	assert.Equal(t, int64(2), verify([]*entities.AuditEntry{removed[0], removed[2]}).Break.Sequence)
+
+	reordered := chain(t, "tenant1", 3)
+	assert.NotNil(t, verify([]*entities.AuditEntry{reordered[0], reordered[2], reordered[1]}).Break)
+
+	assert.NotNil(t, verify(chain(t, "tenant2", 1)).Break, "entries of another tenant do not belong to the chain")
+}
+
+func TestVerifyAuditExport(t *testing.T) {
+	entries := chain(t, "tenant1", 4)
+	var export bytes.Buffer
+	writer, err := auditHandlers.NewAuditExportWriter(&export, "tenant1")
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Write(entries))
+
+	verifier, err := auditHandlers.VerifyAuditExport(bytes.NewReader(export.Bytes()))
+	assert.NoError(t, err)
+	assert.Nil(t, verifier.Break)
+	assert.Equal(t, entries[3].Hash, verifier.HeadHash)
+
+	// An export that starts later is checked from the previous hash of its first entry
+	var partial bytes.Buffer
+	writer, _ = auditHandlers.NewAuditExportWriter(&partial, "tenant1")
+	assert.NoError(t, writer.Write(entries[2:]))
+	verifier, err = auditHandlers.VerifyAuditExport(&partial)
+	assert.NoError(t, err)
+	assert.Nil(t, verifier.Break)
+	assert.Equal(t, int64(2), verifier.Entries)
+
+	tampered := strings.Replace(export.String(), `\"score\":71`, `\"score\":99`, 1)
+	assert.NotEqual(t, export.String(), tampered)
+	verifier, err = auditHandlers.VerifyAuditExport(strings.NewReader(tampered))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), verifier.Break.Sequence)
+
+	_, err = auditHandlers.VerifyAuditExport(strings.NewReader(`{"format":"something-else"}`))
+	assert.Error(t, err)
+}
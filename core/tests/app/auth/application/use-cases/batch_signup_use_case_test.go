@@ -0,0 +1,131 @@
+package use_cases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/auth/application/use-cases/batch-signup-use-case"
+	"github.com/nahualventure/class-backend/core/app/shared/bulk"
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	userErrors "github.com/nahualventure/class-backend/core/app/user/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	"github.com/nahualventure/class-backend/core/tests/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBatchSignupUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockUserRepository{}
+	useCase := batch_signup_use_case.NewBatchSignupUseCase(mockRepo)
+
+	command, err := batch_signup_use_case.NewBatchSignupCommand([]batch_signup_use_case.BatchSignupItem{
+		{Name: "John Doe", Email: "john@example.com", Password: "password123"},
+		{Name: "Jane Doe", Email: "jane@example.com", Password: "password123"},
+	}, 0)
+	assert.NoError(t, err)
+
+	mockRepo.On("FindExistingEmails", []string{"john@example.com", "jane@example.com"}).Return([]string{}, nil)
+	mockRepo.On("CreateMany", mock.AnythingOfType("[]ports.NewUserCredentials")).
+		Return([]*entities.User{mustUser(t, "John Doe", "john@example.com"), mustUser(t, "Jane Doe", "jane@example.com")}, nil).Once()
+
+	// Act
+	result, err := useCase.Execute(command)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Succeeded())
+	assert.Equal(t, 0, result.Failed())
+	assert.Equal(t, "john@example.com", result.Items[0].Value.Email)
+	assert.Equal(t, "jane@example.com", result.Items[1].Value.Email)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBatchSignupUseCase_Execute_PerItemFailures(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockUserRepository{}
+	useCase := batch_signup_use_case.NewBatchSignupUseCase(mockRepo)
+
+	command, err := batch_signup_use_case.NewBatchSignupCommand([]batch_signup_use_case.BatchSignupItem{
+		{Name: "John Doe", Email: "john@example.com", Password: "password123"},
+		{Name: "Invalid", Email: "not-an-email", Password: "password123"},
+		{Name: "John Again", Email: "JOHN@example.com", Password: "password123"},
+		{Name: "Existing", Email: "existing@example.com", Password: "password123"},
+	}, 0)
+	assert.NoError(t, err)
+
+	mockRepo.On("FindExistingEmails", []string{"john@example.com", "existing@example.com"}).Return([]string{"existing@example.com"}, nil)
+	mockRepo.On("CreateMany", mock.MatchedBy(func(c []ports.NewUserCredentials) bool { return len(c) == 1 })).
+		Return([]*entities.User{mustUser(t, "John Doe", "john@example.com")}, nil).Once()
+
+	// Act
+	result, err := useCase.Execute(command)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded())
+	assert.Equal(t, 3, result.Failed())
+
+	assert.Equal(t, batch_signup_use_case.ItemCreated, result.Items[0].Status)
+
+	var appErr errors2.ApplicationError
+	assert.ErrorAs(t, result.Items[1].Err, &appErr)
+	assert.Equal(t, string(errors2.ValidationError), appErr.GetCode())
+
+	assert.ErrorAs(t, result.Items[2].Err, &appErr)
+	assert.Equal(t, string(userErrors.DuplicateEmailInBatchError), appErr.GetCode())
+	assert.Equal(t, 0, appErr.GetContext()["first_index"])
+
+	assert.ErrorAs(t, result.Items[3].Err, &appErr)
+	assert.Equal(t, string(userErrors.EmailAlreadyExistsError), appErr.GetCode())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBatchSignupUseCase_Execute_FailedChunkDoesNotAffectOthers(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockUserRepository{}
+	useCase := batch_signup_use_case.NewBatchSignupUseCase(mockRepo)
+
+	command, err := batch_signup_use_case.NewBatchSignupCommand([]batch_signup_use_case.BatchSignupItem{
+		{Name: "User One", Email: "one@example.com", Password: "password123"},
+		{Name: "User Two", Email: "two@example.com", Password: "password123"},
+		{Name: "User Three", Email: "three@example.com", Password: "password123"},
+	}, 2)
+	assert.NoError(t, err)
+
+	mockRepo.On("FindExistingEmails", mock.Anything).Return([]string{}, nil)
+	mockRepo.On("CreateMany", mock.MatchedBy(func(c []ports.NewUserCredentials) bool { return len(c) == 2 })).
+		Return(nil, errors2.NewInfrastructureError("database write failed", nil)).Once()
+	mockRepo.On("CreateMany", mock.MatchedBy(func(c []ports.NewUserCredentials) bool { return len(c) == 1 })).
+		Return([]*entities.User{mustUser(t, "User Three", "three@example.com")}, nil).Once()
+
+	// Act
+	result, err := useCase.Execute(command)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded())
+	assert.Equal(t, 2, result.Failed())
+	assert.Equal(t, bulk.Failed, result.Items[0].Status)
+	assert.Equal(t, bulk.Failed, result.Items[1].Status)
+	assert.Equal(t, batch_signup_use_case.ItemCreated, result.Items[2].Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBatchSignupUseCase_NewCommand_RejectsOversizedBatch(t *testing.T) {
+	items := make([]batch_signup_use_case.BatchSignupItem, batch_signup_use_case.MaxBatchSize+1)
+
+	command, err := batch_signup_use_case.NewBatchSignupCommand(items, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, command)
+}
+
+func mustUser(t *testing.T, name string, email string) *entities.User {
+	user, err := entities.NewUser(uuid.NewString(), name, email, time.Now(), time.Now())
+	assert.NoError(t, err)
+	return user
+}
@@ -0,0 +1,91 @@
+package use_cases
+
+import (
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/auth/application/use-cases/import-users-use-case"
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	userErrors "github.com/nahualventure/class-backend/core/app/user/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	"github.com/nahualventure/class-backend/core/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportUsersUseCase_Execute_KeepsHashesAsIs(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockUserRepository{}
+	hasher := &mocks.MockPasswordHasher{}
+	useCase := import_users_use_case.NewImportUsersUseCase(mockRepo, hasher)
+
+	command, err := import_users_use_case.NewImportUsersCommand([]import_users_use_case.ImportUserItem{
+		{Name: "John Doe", Email: "john@example.com", PasswordHash: legacyHash},
+		{Name: "Jane Doe", Email: "jane@example.com", PasswordHash: "$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0"},
+	})
+	assert.NoError(t, err)
+
+	hasher.On("Recognizes", mock.Anything).Return(true)
+	mockRepo.On("FindExistingEmails", []string{"john@example.com", "jane@example.com"}).Return([]string{}, nil)
+	mockRepo.On("ImportMany", mock.MatchedBy(func(c []ports.ImportedUserCredentials) bool {
+		return len(c) == 2 && c[0].PasswordHash == legacyHash && c[1].PasswordHash == "$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0"
+	})).Return([]*entities.User{mustUser(t, "John Doe", "john@example.com"), mustUser(t, "Jane Doe", "jane@example.com")}, nil).Once()
+
+	// Act
+	result, err := useCase.Execute(command)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Succeeded())
+	assert.Equal(t, 0, result.Failed())
+	assert.Equal(t, "jane@example.com", result.Items[1].Value.Email)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportUsersUseCase_Execute_PerItemFailures(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockUserRepository{}
+	hasher := &mocks.MockPasswordHasher{}
+	useCase := import_users_use_case.NewImportUsersUseCase(mockRepo, hasher)
+
+	command, err := import_users_use_case.NewImportUsersCommand([]import_users_use_case.ImportUserItem{
+		{Name: "John Doe", Email: "john@example.com", PasswordHash: legacyHash},
+		{Name: "Plain", Email: "plain@example.com", PasswordHash: "hunter2"},
+		{Name: "John Again", Email: "JOHN@example.com", PasswordHash: legacyHash},
+		{Name: "Existing", Email: "existing@example.com", PasswordHash: legacyHash},
+		{Name: "No Hash", Email: "nohash@example.com"},
+	})
+	assert.NoError(t, err)
+
+	hasher.On("Recognizes", legacyHash).Return(true)
+	hasher.On("Recognizes", "hunter2").Return(false)
+	mockRepo.On("FindExistingEmails", []string{"john@example.com", "existing@example.com"}).Return([]string{"existing@example.com"}, nil)
+	mockRepo.On("ImportMany", mock.MatchedBy(func(c []ports.ImportedUserCredentials) bool { return len(c) == 1 })).
+		Return([]*entities.User{mustUser(t, "John Doe", "john@example.com")}, nil).Once()
+
+	// Act
+	result, err := useCase.Execute(command)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded())
+	assert.Equal(t, 4, result.Failed())
+	assert.Equal(t, import_users_use_case.ItemImported, result.Items[0].Status)
+
+	var appErr errors2.ApplicationError
+	assert.ErrorAs(t, result.Items[1].Err, &appErr)
+	assert.Equal(t, string(errors2.ValidationError), appErr.GetCode())
+	assert.Equal(t, "is not in a supported scheme", appErr.GetContext()["password_hash"])
+
+	assert.ErrorAs(t, result.Items[2].Err, &appErr)
+	assert.Equal(t, string(userErrors.DuplicateEmailInBatchError), appErr.GetCode())
+
+	assert.ErrorAs(t, result.Items[3].Err, &appErr)
+	assert.Equal(t, string(userErrors.EmailAlreadyExistsError), appErr.GetCode())
+
+	assert.ErrorAs(t, result.Items[4].Err, &appErr)
+	assert.Equal(t, string(errors2.ValidationError), appErr.GetCode())
+	hasher.AssertNotCalled(t, "Recognizes", "")
+	mockRepo.AssertExpectations(t)
+}
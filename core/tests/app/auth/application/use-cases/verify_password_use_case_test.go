@@ -0,0 +1,139 @@
+package use_cases
+
+import (
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/auth/application/use-cases/verify-password-use-case"
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const legacyHash = "pbkdf2_sha256$1000$Wq5Ry2mNxT8b$BUt6uPOSZKme+jLdu1GnGxkbTkRqgNx5G9mBdFrQlmU="
+
+func TestVerifyPasswordUseCase_Execute_RehashesLegacyHash(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockUserRepository{}
+	hasher := &mocks.MockPasswordHasher{}
+	useCase := verify_password_use_case.NewVerifyPasswordUseCase(mockRepo, hasher)
+	user := mustUser(t, "John Doe", "john@example.com")
+
+	mockRepo.On("FindCredentials", "john@example.com").Return(user, legacyHash, nil)
+	hasher.On("Verify", "correct horse", legacyHash).Return(true, true, nil)
+	hasher.On("Hash", "correct horse").Return("$2a$10$rehashed", nil)
+	mockRepo.On("ReplacePasswordHash", user.ID, legacyHash, "$2a$10$rehashed").Return(true, nil).Once()
+
+	command, err := verify_password_use_case.NewVerifyPasswordCommand("john@example.com", "correct horse")
+	assert.NoError(t, err)
+
+	// Act
+	verified, err := useCase.Execute(command)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, user, verified)
+	mockRepo.AssertExpectations(t)
+	hasher.AssertExpectations(t)
+}
+
+func TestVerifyPasswordUseCase_Execute_KeepsCurrentHash(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockUserRepository{}
+	hasher := &mocks.MockPasswordHasher{}
+	useCase := verify_password_use_case.NewVerifyPasswordUseCase(mockRepo, hasher)
+	user := mustUser(t, "John Doe", "john@example.com")
+
+	mockRepo.On("FindCredentials", "john@example.com").Return(user, "$2a$10$current", nil)
+	hasher.On("Verify", "correct horse", "$2a$10$current").Return(true, false, nil)
+
+	command, err := verify_password_use_case.NewVerifyPasswordCommand("john@example.com", "correct horse")
+	assert.NoError(t, err)
+
+	// Act
+	verified, err := useCase.Execute(command)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, user, verified)
+	mockRepo.AssertNotCalled(t, "ReplacePasswordHash")
+}
+
+func TestVerifyPasswordUseCase_Execute_FailedRehashStillVerifies(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockUserRepository{}
+	hasher := &mocks.MockPasswordHasher{}
+	useCase := verify_password_use_case.NewVerifyPasswordUseCase(mockRepo, hasher)
+	user := mustUser(t, "John Doe", "john@example.com")
+
+	mockRepo.On("FindCredentials", "john@example.com").Return(user, legacyHash, nil)
+	hasher.On("Verify", "correct horse", legacyHash).Return(true, true, nil)
+	hasher.On("Hash", "correct horse").Return("$2a$10$rehashed", nil)
+	mockRepo.On("ReplacePasswordHash", user.ID, legacyHash, "$2a$10$rehashed").
+		Return(false, errors2.NewInfrastructureError("database write failed", nil))
+
+	command, err := verify_password_use_case.NewVerifyPasswordCommand("john@example.com", "correct horse")
+	assert.NoError(t, err)
+
+	// Act
+	verified, err := useCase.Execute(command)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, user, verified)
+}
+
+func TestVerifyPasswordUseCase_Execute_RefusesWrongPasswordsAndUnknownEmailsAlike(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockUserRepository{}
+	hasher := &mocks.MockPasswordHasher{}
+	useCase := verify_password_use_case.NewVerifyPasswordUseCase(mockRepo, hasher)
+
+	mockRepo.On("FindCredentials", "john@example.com").Return(mustUser(t, "John Doe", "john@example.com"), legacyHash, nil)
+	mockRepo.On("FindCredentials", "nobody@example.com").Return(nil, "", nil)
+	hasher.On("Verify", "wrong horse", legacyHash).Return(false, false, nil)
+	hasher.On("Hash", "wrong horse").Return("$2a$10$discarded", nil).Once()
+
+	for _, email := range []string{"john@example.com", "nobody@example.com"} {
+		command, err := verify_password_use_case.NewVerifyPasswordCommand(email, "wrong horse")
+		assert.NoError(t, err)
+
+		// Act
+		verified, err := useCase.Execute(command)
+
+		// Assert
+		assert.Nil(t, verified)
+		var appErr errors2.ApplicationError
+		if assert.ErrorAs(t, err, &appErr) {
+			assert.Equal(t, string(errors2.Unauthorized), appErr.GetCode())
+			assert.Equal(t, "Invalid email or password", appErr.GetMessage())
+		}
+	}
+	mockRepo.AssertNotCalled(t, "ReplacePasswordHash")
+	hasher.AssertExpectations(t)
+}
+
+func TestVerifyPasswordUseCase_Execute_RefusesUsersWithoutPassword(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockUserRepository{}
+	hasher := &mocks.MockPasswordHasher{}
+	useCase := verify_password_use_case.NewVerifyPasswordUseCase(mockRepo, hasher)
+
+	mockRepo.On("FindCredentials", "john@example.com").Return(mustUser(t, "John Doe", "john@example.com"), "", nil)
+	hasher.On("Hash", "any password").Return("$2a$10$discarded", nil).Once()
+
+	command, err := verify_password_use_case.NewVerifyPasswordCommand("john@example.com", "any password")
+	assert.NoError(t, err)
+
+	// Act
+	verified, err := useCase.Execute(command)
+
+	// Assert
+	assert.Nil(t, verified)
+	var appErr errors2.ApplicationError
+	if assert.ErrorAs(t, err, &appErr) {
+		assert.Equal(t, "Invalid email or password", appErr.GetMessage())
+	}
+	hasher.AssertNotCalled(t, "Verify")
+	hasher.AssertExpectations(t)
+}
@@ -0,0 +1,219 @@
+package use_cases
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	request_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/request-tenant-backup-use-case"
+	restore_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/restore-tenant-backup-use-case"
+	run_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/run-tenant-backup-use-case"
+	"github.com/nahualventure/class-backend/core/app/backup/domain/entities"
+	backupErrors "github.com/nahualventure/class-backend/core/app/backup/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+type memoryBackups struct {
+	backups []*entities.TenantBackup
+}
+
+func (m *memoryBackups) Create(_ context.Context, backup *entities.TenantBackup) error {
+	m.backups = append(m.backups, backup)
+	return nil
+}
+
+func (m *memoryBackups) FindByID(_ context.Context, tenantID string, backupID string) (*entities.TenantBackup, error) {
+	for _, backup := range m.backups {
+		if backup.TenantID == tenantID && backup.ID == backupID {
+			return backup, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryBackups) List(_ context.Context, tenantID string, limit int) ([]*entities.TenantBackup, error) {
+	return m.backups, nil
+}
+
+func (m *memoryBackups) ClaimNextPending(_ context.Context, _ time.Duration) (*entities.TenantBackup, error) {
+	for _, backup := range m.backups {
+		if backup.Status == entities.TenantBackupStatusPending {
+			backup.Status = entities.TenantBackupStatusRunning
+			return backup, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryBackups) MarkVerified(_ context.Context, backup *entities.TenantBackup) error {
+	backup.Status = entities.TenantBackupStatusVerified
+	return nil
+}
+
+func (m *memoryBackups) MarkFailed(_ context.Context, backupID string, reason string) error {
+	for _, backup := range m.backups {
+		if backup.ID == backupID {
+			backup.Status = entities.TenantBackupStatusFailed
+			backup.FailureReason = reason
+		}
+	}
+	return nil
+}
+
+// fakeExporter writes one line per row, the restorer counts them back per table
+type fakeExporter struct {
+	tables map[string]int
+}
+
+func (e *fakeExporter) Export(_ context.Context, _ string, w io.Writer) (map[string]int, error) {
+	for table, rows := range e.tables {
+		for i := 0; i < rows; i++ {
+			if _, err := io.WriteString(w, table+"\n"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return e.tables, nil
+}
+
+type fakeRestorer struct {
+	schemas  map[string]bool
+	dropRows string
+}
+
+func (r *fakeRestorer) RestoreInto(_ context.Context, schema string, dump io.Reader) (map[string]int, error) {
+	content, err := io.ReadAll(dump)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	start := 0
+	for i, b := range content {
+		if b == '\n' {
+			if table := string(content[start:i]); table != r.dropRows {
+				counts[table]++
+			}
+			start = i + 1
+		}
+	}
+	r.schemas[schema] = true
+	return counts, nil
+}
+
+func (r *fakeRestorer) DropSchema(_ context.Context, schema string) error {
+	delete(r.schemas, schema)
+	return nil
+}
+
+type memoryFiles struct {
+	files map[string][]byte
+}
+
+func (f *memoryFiles) Put(_ context.Context, key string, content []byte) error {
+	f.files[key] = content
+	return nil
+}
+
+func (f *memoryFiles) Get(_ context.Context, key string) ([]byte, error) {
+	return f.files[key], nil
+}
+
+func (f *memoryFiles) Delete(_ context.Context, key string) error {
+	delete(f.files, key)
+	return nil
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func requestBackup(t *testing.T, repo *memoryBackups) *entities.TenantBackup {
+	t.Helper()
+	cmd, err := request_tenant_backup_use_case.NewRequestTenantBackupCommand(tenantID, "admin-1")
+	assert.NoError(t, err)
+	backup, err := request_tenant_backup_use_case.NewRequestTenantBackupUseCase(repo).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return backup
+}
+
+func TestRunTenantBackup_StoresAndVerifiesTheDump(t *testing.T) {
+	repo := &memoryBackups{}
+	files := &memoryFiles{files: map[string][]byte{}}
+	restorer := &fakeRestorer{schemas: map[string]bool{}}
+	backup := requestBackup(t, repo)
+	assert.Equal(t, entities.TenantBackupStatusPending, backup.Status)
+
+	useCase := run_tenant_backup_use_case.NewRunTenantBackupUseCase(repo,
+		&fakeExporter{tables: map[string]int{"grades": 3, "messages": 2, "incidents": 0}}, restorer, files)
+	processed, err := useCase.Execute(context.Background())
+
+	assert.True(t, processed)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TenantBackupStatusVerified, backup.Status)
+	assert.Equal(t, entities.StorageKeyFor(tenantID, backup.ID), backup.StorageKey)
+	assert.Equal(t, 5, backup.RowCount())
+	assert.Equal(t, int64(len("grades\n")*3+len("messages\n")*2), backup.SizeBytes)
+	assert.Len(t, backup.Checksum, 64)
+	assert.Contains(t, files.files, backup.StorageKey)
+	assert.Empty(t, restorer.schemas, "the scratch schema is dropped")
+
+	processed, err = useCase.Execute(context.Background())
+	assert.False(t, processed)
+	assert.NoError(t, err)
+}
+
+func TestRunTenantBackup_FailsWhenRowsAreMissingOnRestore(t *testing.T) {
+	repo := &memoryBackups{}
+	restorer := &fakeRestorer{schemas: map[string]bool{}, dropRows: "messages"}
+	backup := requestBackup(t, repo)
+
+	processed, err := run_tenant_backup_use_case.NewRunTenantBackupUseCase(repo,
+		&fakeExporter{tables: map[string]int{"grades": 1, "messages": 2}}, restorer, &memoryFiles{files: map[string][]byte{}},
+	).Execute(context.Background())
+
+	assert.True(t, processed)
+	assert.Error(t, err)
+	assert.Equal(t, entities.TenantBackupStatusFailed, backup.Status)
+	assert.Contains(t, backup.FailureReason, "messages (0 of 2 rows)")
+	assert.Empty(t, restorer.schemas)
+}
+
+func TestRestoreTenantBackup(t *testing.T) {
+	repo := &memoryBackups{}
+	files := &memoryFiles{files: map[string][]byte{}}
+	restorer := &fakeRestorer{schemas: map[string]bool{}}
+	backup := requestBackup(t, repo)
+	restore := restore_tenant_backup_use_case.NewRestoreTenantBackupUseCase(repo, restorer, files)
+
+	cmd, err := restore_tenant_backup_use_case.NewRestoreTenantBackupCommand(tenantID, backup.ID, "restore_school1")
+	assert.NoError(t, err)
+	_, err = restore.Execute(context.Background(), cmd)
+	assert.Equal(t, backupErrors.TenantBackupNotVerifiedError.String(), codeOf(err))
+
+	_, err = run_tenant_backup_use_case.NewRunTenantBackupUseCase(repo,
+		&fakeExporter{tables: map[string]int{"grades": 2}}, restorer, files).Execute(context.Background())
+	assert.NoError(t, err)
+
+	restored, err := restore.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"grades": 2}, restored)
+	assert.True(t, restorer.schemas["restore_school1"], "restored schemas are kept for operators")
+
+	// A dump changed after it was verified is refused
+	files.files[backup.StorageKey] = []byte("grades\n")
+	_, err = restore.Execute(context.Background(), cmd)
+	assert.Error(t, err)
+
+	_, err = restore_tenant_backup_use_case.NewRestoreTenantBackupCommand(tenantID, backup.ID, "public")
+	assert.Error(t, err, "the live schema is never a restore target")
+}
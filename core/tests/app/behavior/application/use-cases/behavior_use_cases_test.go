@@ -0,0 +1,276 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	acknowledge_incident_notification_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/acknowledge-incident-notification-use-case"
+	add_incident_note_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/add-incident-note-use-case"
+	get_incident_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/get-incident-use-case"
+	list_incidents_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/list-incidents-use-case"
+	notify_incident_guardians_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/notify-incident-guardians-use-case"
+	report_incident_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/report-incident-use-case"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	behaviorErrors "github.com/nahualventure/class-backend/core/app/behavior/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+type memoryBehavior struct {
+	incidents     map[string]*entities.Incident
+	notes         []*entities.IncidentNote
+	notifications []*entities.GuardianNotification
+	viewers       map[string]*entities.Viewer
+	links         []entities.GuardianLink
+}
+
+func newMemoryBehavior() *memoryBehavior {
+	return &memoryBehavior{
+		incidents: make(map[string]*entities.Incident),
+		viewers: map[string]*entities.Viewer{
+			"teacher-1": {UserID: "teacher-1", Role: entities.ViewerRoleStaff},
+			"teacher-2": {UserID: "teacher-2", Role: entities.ViewerRoleStaff},
+			"head-1":    {UserID: "head-1", Role: entities.ViewerRoleStaff, CanReadRestricted: true},
+			"parent-1":  {UserID: "parent-1", Role: entities.ViewerRoleGuardian, StudentIDs: []string{"student-1"}},
+			"parent-2":  {UserID: "parent-2", Role: entities.ViewerRoleGuardian, StudentIDs: []string{"student-2"}},
+		},
+		links: []entities.GuardianLink{
+			{GuardianID: "parent-1", StudentID: "student-1"},
+			{GuardianID: "parent-2", StudentID: "student-2"},
+		},
+	}
+}
+
+func (m *memoryBehavior) Create(ctx context.Context, incident *entities.Incident, notifications []*entities.GuardianNotification) error {
+	m.incidents[incident.ID] = incident
+	_, err := m.Queue(ctx, notifications)
+	return err
+}
+
+func (m *memoryBehavior) FindByID(_ context.Context, _ string, incidentID string) (*entities.Incident, error) {
+	return m.incidents[incidentID], nil
+}
+
+func (m *memoryBehavior) List(_ context.Context, _ string, filter ports.IncidentFilter) ([]*entities.Incident, error) {
+	var incidents []*entities.Incident
+	for _, incident := range m.incidents {
+		if filter.StudentIDs == nil || slices.ContainsFunc(filter.StudentIDs, incident.Involves) {
+			incidents = append(incidents, incident)
+		}
+	}
+	return incidents, nil
+}
+
+func (m *memoryBehavior) Resolve(context.Context, *entities.Incident) error {
+	return nil
+}
+
+// memoryNotes and memoryNotifications split the store by port, both repositories have a FindByID
+type memoryNotes struct{ *memoryBehavior }
+
+func (m memoryNotes) Create(_ context.Context, note *entities.IncidentNote) error {
+	m.notes = append(m.notes, note)
+	return nil
+}
+
+func (m memoryNotes) ListByIncident(_ context.Context, _ string, incidentID string) ([]*entities.IncidentNote, error) {
+	var notes []*entities.IncidentNote
+	for _, note := range m.notes {
+		if note.IncidentID == incidentID {
+			notes = append(notes, note)
+		}
+	}
+	return notes, nil
+}
+
+type memoryNotifications struct{ *memoryBehavior }
+
+func (m *memoryBehavior) Queue(_ context.Context, notifications []*entities.GuardianNotification) ([]*entities.GuardianNotification, error) {
+	queued := []*entities.GuardianNotification{}
+	for _, notification := range notifications {
+		exists := slices.ContainsFunc(m.notifications, func(existing *entities.GuardianNotification) bool {
+			return existing.IncidentID == notification.IncidentID && existing.StudentID == notification.StudentID &&
+				existing.GuardianID == notification.GuardianID
+		})
+		if !exists {
+			m.notifications = append(m.notifications, notification)
+			queued = append(queued, notification)
+		}
+	}
+	return queued, nil
+}
+
+func (m memoryNotifications) FindByID(_ context.Context, _ string, notificationID string) (*entities.GuardianNotification, error) {
+	for _, notification := range m.notifications {
+		if notification.ID == notificationID {
+			return notification, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m memoryNotifications) ListByIncident(_ context.Context, _ string, incidentID string) ([]*entities.GuardianNotification, error) {
+	var notifications []*entities.GuardianNotification
+	for _, notification := range m.notifications {
+		if notification.IncidentID == incidentID {
+			notifications = append(notifications, notification)
+		}
+	}
+	return notifications, nil
+}
+
+func (m memoryNotifications) Acknowledge(context.Context, *entities.GuardianNotification) error {
+	return nil
+}
+
+func (m *memoryBehavior) Viewer(_ context.Context, _ string, userID string) (*entities.Viewer, error) {
+	return m.viewers[userID], nil
+}
+
+func (m *memoryBehavior) GuardiansOf(_ context.Context, _ string, studentIDs []string) ([]entities.GuardianLink, error) {
+	var links []entities.GuardianLink
+	for _, link := range m.links {
+		if slices.Contains(studentIDs, link.StudentID) {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+func report(t *testing.T, store *memoryBehavior, severity entities.Severity, studentIDs ...string) *entities.IncidentDetails {
+	t.Helper()
+	cmd, err := report_incident_use_case.NewReportIncidentCommand(tenantID, "teacher-1", "disruption", string(severity),
+		"Disrupted the lesson", "Room 204", studentIDs, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	details, err := report_incident_use_case.NewReportIncidentUseCase(store, store).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return details
+}
+
+func getIncident(store *memoryBehavior, incidentID string, userID string) (*entities.IncidentDetails, error) {
+	cmd, err := get_incident_use_case.NewGetIncidentCommand(tenantID, incidentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return get_incident_use_case.NewGetIncidentUseCase(store, memoryNotes{store}, memoryNotifications{store}, store).
+		Execute(context.Background(), cmd)
+}
+
+func hasCode(err error, code string) bool {
+	var appErr appErrors.ApplicationError
+	return errors.As(err, &appErr) && appErr.GetCode() == code
+}
+
+func TestGetIncident_RestrictedNotesNeedThePermission(t *testing.T) {
+	store := newMemoryBehavior()
+	incident := report(t, store, entities.SeverityLow, "student-1").Incident
+	addNote := add_incident_note_use_case.NewAddIncidentNoteUseCase(store, memoryNotes{store}, store)
+	for _, restricted := range []bool{false, true} {
+		cmd, err := add_incident_note_use_case.NewAddIncidentNoteCommand(tenantID, incident.ID, "teacher-1", "Note", restricted)
+		assert.NoError(t, err)
+		_, err = addNote.Execute(context.Background(), cmd)
+		assert.NoError(t, err)
+	}
+
+	readable := map[string]int{"teacher-1": 2, "head-1": 2, "teacher-2": 1, "parent-1": 0}
+	for userID, expected := range readable {
+		details, err := getIncident(store, incident.ID, userID)
+		assert.NoError(t, err)
+		assert.Len(t, details.Notes, expected, userID)
+	}
+}
+
+func TestAddIncidentNote_GuardiansCannotWriteNotes(t *testing.T) {
+	store := newMemoryBehavior()
+	incident := report(t, store, entities.SeverityLow, "student-1").Incident
+
+	cmd, err := add_incident_note_use_case.NewAddIncidentNoteCommand(tenantID, incident.ID, "parent-1", "Note", false)
+	assert.NoError(t, err)
+	_, err = add_incident_note_use_case.NewAddIncidentNoteUseCase(store, memoryNotes{store}, store).Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, behaviorErrors.IncidentNotFoundError.String()))
+}
+
+func TestGuardians_OnlySeeIncidentsOfTheirStudents(t *testing.T) {
+	store := newMemoryBehavior()
+	shared := report(t, store, entities.SeverityLow, "student-1", "student-2").Incident
+	other := report(t, store, entities.SeverityLow, "student-2").Incident
+
+	details, err := getIncident(store, shared.ID, "parent-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"student-1"}, details.Incident.StudentIDs, "other students involved stay private")
+	assert.Len(t, shared.StudentIDs, 2, "the stored incident is not redacted")
+
+	_, err = getIncident(store, other.ID, "parent-1")
+	assert.True(t, hasCode(err, behaviorErrors.IncidentNotFoundError.String()))
+
+	cmd, err := list_incidents_use_case.NewListIncidentsCommand(tenantID, "parent-1", "", "", 0)
+	assert.NoError(t, err)
+	incidents, err := list_incidents_use_case.NewListIncidentsUseCase(store, store).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Len(t, incidents, 1)
+
+	cmd, err = list_incidents_use_case.NewListIncidentsCommand(tenantID, "parent-1", "student-2", "", 0)
+	assert.NoError(t, err)
+	incidents, err = list_incidents_use_case.NewListIncidentsUseCase(store, store).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Empty(t, incidents)
+}
+
+func TestReportIncident_NotifiesGuardiansOfSevereIncidents(t *testing.T) {
+	store := newMemoryBehavior()
+
+	assert.Empty(t, report(t, store, entities.SeverityMedium, "student-1").Notifications)
+
+	details := report(t, store, entities.SeverityHigh, "student-1", "student-2")
+	assert.Len(t, details.Notifications, 2)
+}
+
+func TestNotifyIncidentGuardians_OnlyQueuesGuardiansNotNotifiedYet(t *testing.T) {
+	store := newMemoryBehavior()
+	incident := report(t, store, entities.SeverityLow, "student-1", "student-2").Incident
+	uc := notify_incident_guardians_use_case.NewNotifyIncidentGuardiansUseCase(store, memoryNotifications{store}, store)
+	cmd, err := notify_incident_guardians_use_case.NewNotifyIncidentGuardiansCommand(tenantID, incident.ID, "teacher-1")
+	assert.NoError(t, err)
+
+	queued, err := uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Len(t, queued, 2)
+
+	store.links = append(store.links, entities.GuardianLink{GuardianID: "parent-3", StudentID: "student-1"})
+	queued, err = uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Len(t, queued, 1)
+	assert.Equal(t, "parent-3", queued[0].GuardianID)
+
+	details, err := getIncident(store, incident.ID, "parent-1")
+	assert.NoError(t, err)
+	assert.Len(t, details.Notifications, 1, "guardians only see their own notifications")
+}
+
+func TestAcknowledgeIncidentNotification_OnlyByTheGuardian(t *testing.T) {
+	store := newMemoryBehavior()
+	notification := report(t, store, entities.SeverityCritical, "student-1").Notifications[0]
+	uc := acknowledge_incident_notification_use_case.NewAcknowledgeIncidentNotificationUseCase(memoryNotifications{store})
+
+	cmd, err := acknowledge_incident_notification_use_case.NewAcknowledgeIncidentNotificationCommand(tenantID, notification.ID, "parent-2")
+	assert.NoError(t, err)
+	_, err = uc.Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, behaviorErrors.GuardianNotificationNotFoundError.String()))
+
+	cmd, err = acknowledge_incident_notification_use_case.NewAcknowledgeIncidentNotificationCommand(tenantID, notification.ID, "parent-1")
+	assert.NoError(t, err)
+	acknowledged, err := uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	first := *acknowledged.AcknowledgedAt
+
+	acknowledged, err = uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, first, *acknowledged.AcknowledgedAt)
+}
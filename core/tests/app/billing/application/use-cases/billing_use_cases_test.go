@@ -0,0 +1,289 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	check_feature_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/check-feature-use-case"
+	check_plan_limit_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/check-plan-limit-use-case"
+	get_subscription_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/get-subscription-use-case"
+	receive_billing_event_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/receive-billing-event-use-case"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	billingErrors "github.com/nahualventure/class-backend/core/app/billing/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+type memoryBilling struct {
+	subscriptions map[string]*entities.Subscription
+	invoices      map[string]*entities.Invoice
+	dunned        map[string]int
+	notices       map[string][]string
+	admins        []string
+	next          *entities.BillingEvent
+	usage         map[entities.Limit]int64
+}
+
+func newMemoryBilling() *memoryBilling {
+	return &memoryBilling{
+		subscriptions: make(map[string]*entities.Subscription),
+		invoices:      make(map[string]*entities.Invoice),
+		dunned:        make(map[string]int),
+		notices:       make(map[string][]string),
+		admins:        []string{"admin-1", "admin-2"},
+		usage:         make(map[entities.Limit]int64),
+	}
+}
+
+func (m *memoryBilling) FindByTenant(_ context.Context, tenantID string) (*entities.Subscription, error) {
+	return m.subscriptions[tenantID], nil
+}
+
+func (m *memoryBilling) FindByCustomer(_ context.Context, customerID string) (*entities.Subscription, error) {
+	for _, subscription := range m.subscriptions {
+		if subscription.CustomerID == customerID {
+			return subscription, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryBilling) Save(_ context.Context, subscription *entities.Subscription) error {
+	m.subscriptions[subscription.TenantID] = subscription
+	return nil
+}
+
+type memoryInvoices struct {
+	*memoryBilling
+}
+
+func (m memoryInvoices) Save(_ context.Context, invoice *entities.Invoice) error {
+	m.invoices[invoice.ProviderID] = invoice
+	return nil
+}
+
+func (m memoryInvoices) QueueDunningNotice(_ context.Context, invoice *entities.Invoice, recipientIDs []string) (bool, error) {
+	if m.dunned[invoice.ProviderID] >= invoice.AttemptCount {
+		return false, nil
+	}
+	m.dunned[invoice.ProviderID] = invoice.AttemptCount
+	m.notices[invoice.ProviderID] = append(m.notices[invoice.ProviderID], recipientIDs...)
+	return true, nil
+}
+
+func (m memoryInvoices) List(_ context.Context, _ string, page *pagination.PageRequest) (*pagination.Page[*entities.Invoice], error) {
+	return pagination.NewPage(page, []*entities.Invoice{}, 0, func(invoice *entities.Invoice) []string {
+		return []string{pagination.TimeKey(invoice.CreatedAt), invoice.ID}
+	}), nil
+}
+
+func (m *memoryBilling) VerifyEvent(_ []byte, signature string) (string, error) {
+	if signature != "valid" {
+		return "", billingErrors.NewInvalidBillingWebhookError("signature mismatch")
+	}
+	return m.next.ID, nil
+}
+
+func (m *memoryBilling) ParseEvent(_ []byte) (*entities.BillingEvent, error) {
+	return m.next, nil
+}
+
+func (m *memoryBilling) Contacts(_ context.Context, _ string) ([]string, error) {
+	return m.admins, nil
+}
+
+func subscription(t *testing.T, plan entities.PlanCode, status entities.SubscriptionStatus) *entities.Subscription {
+	t.Helper()
+	now := time.Now().UTC()
+	subscription, err := entities.NewSubscription(tenantID, plan, status, "cus_1", "sub_1", nil, false, now, now)
+	assert.NoError(t, err)
+	return subscription
+}
+
+func failedPayment(attempt int) *entities.BillingEvent {
+	next := time.Now().Add(72 * time.Hour)
+	return &entities.BillingEvent{
+		ID:         "evt_1",
+		Type:       entities.BillingEventPaymentFailed,
+		OccurredAt: time.Now().UTC(),
+		CustomerID: "cus_1",
+		Invoice: &entities.Invoice{
+			ProviderID:         "in_1",
+			Status:             entities.InvoiceStatusOpen,
+			Currency:           "USD",
+			AmountDue:          4900,
+			PeriodStart:        time.Now().AddDate(0, -1, 0),
+			PeriodEnd:          time.Now(),
+			AttemptCount:       attempt,
+			NextPaymentAttempt: &next,
+			CreatedAt:          time.Now(),
+		},
+	}
+}
+
+func receive(store *memoryBilling, event *entities.BillingEvent) error {
+	store.next = event
+	cmd, err := receive_billing_event_use_case.NewReceiveBillingEventCommand([]byte("{}"))
+	if err != nil {
+		return err
+	}
+	_, err = receive_billing_event_use_case.NewReceiveBillingEventUseCase(store, memoryInvoices{store}, store, store).
+		Execute(context.Background(), cmd)
+	return err
+}
+
+func checkFeature(store *memoryBilling, feature entities.Feature) error {
+	cmd, err := check_feature_use_case.NewCheckFeatureCommand(tenantID, feature)
+	if err != nil {
+		return err
+	}
+	return check_feature_use_case.NewCheckFeatureUseCase(store).Execute(context.Background(), cmd)
+}
+
+func (m *memoryBilling) Usage(_ context.Context, _ string, limit entities.Limit) (int64, error) {
+	return m.usage[limit], nil
+}
+
+func (m *memoryBilling) CountAPICall(_ context.Context, _ string, _ time.Time) (int64, error) {
+	m.usage[entities.LimitAPICallsPerDay]++
+	return m.usage[entities.LimitAPICallsPerDay], nil
+}
+
+func checkPlanLimit(store *memoryBilling, limit entities.Limit, requested int64) error {
+	cmd, err := check_plan_limit_use_case.NewCheckPlanLimitCommand(tenantID, limit, requested)
+	if err != nil {
+		return err
+	}
+	return check_plan_limit_use_case.NewCheckPlanLimitUseCase(store, store).Execute(context.Background(), cmd)
+}
+
+func hasCode(err error, code string) bool {
+	var appErr appErrors.ApplicationError
+	return errors.As(err, &appErr) && appErr.GetCode() == code
+}
+
+func TestNewSubscription_RejectsPlansOutsideTheCatalog(t *testing.T) {
+	_, err := entities.NewSubscription(tenantID, "enterprise", entities.SubscriptionStatusActive, "cus_1", "sub_1", nil, false,
+		time.Now(), time.Now())
+	assert.Error(t, err)
+}
+
+func TestGetSubscription_TenantsWithoutOneAreOnTheFreePlan(t *testing.T) {
+	cmd, err := get_subscription_use_case.NewGetSubscriptionCommand(tenantID)
+	assert.NoError(t, err)
+	entitlements, err := get_subscription_use_case.NewGetSubscriptionUseCase(newMemoryBilling()).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PlanFree, entitlements.Plan.Code)
+	assert.Nil(t, entitlements.Subscription)
+}
+
+func TestCheckFeature_FollowsThePlanAndSubscriptionStatus(t *testing.T) {
+	store := newMemoryBilling()
+	assert.True(t, hasCode(checkFeature(store, entities.FeatureSurveys), billingErrors.FeatureNotInPlanError.String()))
+
+	cases := []struct {
+		plan    entities.PlanCode
+		status  entities.SubscriptionStatus
+		allowed bool
+	}{
+		{entities.PlanStandard, entities.SubscriptionStatusActive, true},
+		{entities.PlanStandard, entities.SubscriptionStatusTrialing, true},
+		{entities.PlanStandard, entities.SubscriptionStatusPastDue, true},
+		{entities.PlanStandard, entities.SubscriptionStatusUnpaid, false},
+		{entities.PlanStandard, entities.SubscriptionStatusCanceled, false},
+	}
+	for _, c := range cases {
+		store.subscriptions[tenantID] = subscription(t, c.plan, c.status)
+		assert.Equal(t, c.allowed, checkFeature(store, entities.FeatureSurveys) == nil, string(c.status))
+	}
+
+	store.subscriptions[tenantID] = subscription(t, entities.PlanStandard, entities.SubscriptionStatusActive)
+	assert.Error(t, checkFeature(store, entities.FeatureSimilarityChecks))
+	store.subscriptions[tenantID] = subscription(t, entities.PlanPremium, entities.SubscriptionStatusActive)
+	assert.NoError(t, checkFeature(store, entities.FeatureSimilarityChecks))
+}
+
+func TestCheckPlanLimit_HintsTheCheapestPlanThatAllowsTheUsage(t *testing.T) {
+	store := newMemoryBilling()
+	store.usage[entities.LimitStaffAccounts] = 4
+	assert.NoError(t, checkPlanLimit(store, entities.LimitStaffAccounts, 1))
+
+	store.usage[entities.LimitStaffAccounts] = 5
+	err := checkPlanLimit(store, entities.LimitStaffAccounts, 1)
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, billingErrors.PlanLimitExceededError.String(), appErr.GetCode())
+		assert.Equal(t, "standard", appErr.GetContext()["upgradePlan"])
+	}
+
+	store.usage[entities.LimitStorageGB] = 100 << 30
+	store.subscriptions[tenantID] = subscription(t, entities.PlanStandard, entities.SubscriptionStatusActive)
+	err = checkPlanLimit(store, entities.LimitStorageGB, 1)
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, "premium", appErr.GetContext()["upgradePlan"])
+	}
+
+	store.usage[entities.LimitStorageGB] = 1000 << 30
+	store.subscriptions[tenantID] = subscription(t, entities.PlanPremium, entities.SubscriptionStatusActive)
+	err = checkPlanLimit(store, entities.LimitStorageGB, 1)
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.NotContains(t, appErr.GetContext(), "upgradePlan")
+	}
+}
+
+func TestCheckPlanLimit_LimitsLeftOutOfThePlanAreUnlimited(t *testing.T) {
+	store := newMemoryBilling()
+	store.subscriptions[tenantID] = subscription(t, entities.PlanPremium, entities.SubscriptionStatusActive)
+	store.usage[entities.LimitStaffAccounts] = 10_000
+
+	assert.NoError(t, checkPlanLimit(store, entities.LimitStaffAccounts, 1))
+}
+
+func TestCheckPlanLimit_CountsAPICallsAgainstTheDailyAllowance(t *testing.T) {
+	store := newMemoryBilling()
+	store.usage[entities.LimitAPICallsPerDay] = 999
+
+	assert.NoError(t, checkPlanLimit(store, entities.LimitAPICallsPerDay, 1))
+	err := checkPlanLimit(store, entities.LimitAPICallsPerDay, 1)
+	assert.True(t, hasCode(err, billingErrors.PlanLimitExceededError.String()))
+	assert.Equal(t, int64(1001), store.usage[entities.LimitAPICallsPerDay])
+}
+
+func TestReceiveBillingEvent_SavesSubscriptions(t *testing.T) {
+	store := newMemoryBilling()
+	err := receive(store, &entities.BillingEvent{
+		ID:           "evt_1",
+		Type:         entities.BillingEventSubscriptionChanged,
+		CustomerID:   "cus_1",
+		Subscription: subscription(t, entities.PlanPremium, entities.SubscriptionStatusActive),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PlanPremium, store.subscriptions[tenantID].PlanCode)
+}
+
+func TestReceiveBillingEvent_InvoicesOfUnknownCustomersAreRetried(t *testing.T) {
+	store := newMemoryBilling()
+	err := receive(store, failedPayment(1))
+	assert.True(t, hasCode(err, billingErrors.BillingCustomerNotFoundError.String()))
+	assert.Empty(t, store.invoices)
+}
+
+func TestReceiveBillingEvent_NotifiesEachFailedAttemptOnce(t *testing.T) {
+	store := newMemoryBilling()
+	store.subscriptions[tenantID] = subscription(t, entities.PlanStandard, entities.SubscriptionStatusPastDue)
+
+	assert.NoError(t, receive(store, failedPayment(1)))
+	assert.NoError(t, receive(store, failedPayment(1)))
+	assert.Equal(t, []string{"admin-1", "admin-2"}, store.notices["in_1"])
+	assert.Equal(t, tenantID, store.invoices["in_1"].TenantID)
+	assert.NotEmpty(t, store.invoices["in_1"].ID)
+
+	assert.NoError(t, receive(store, failedPayment(2)))
+	assert.Len(t, store.notices["in_1"], 4)
+}
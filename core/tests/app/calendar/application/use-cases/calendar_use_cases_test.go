@@ -0,0 +1,144 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	add_non_instructional_day_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/add-non-instructional-day-use-case"
+	import_holidays_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/import-holidays-use-case"
+	list_non_instructional_days_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/list-non-instructional-days-use-case"
+	remove_non_instructional_day_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/remove-non-instructional-day-use-case"
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	calendarErrors "github.com/nahualventure/class-backend/core/app/calendar/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+type memoryDays struct {
+	days map[string]*entities.NonInstructionalDay
+}
+
+func newMemoryDays() *memoryDays {
+	return &memoryDays{days: make(map[string]*entities.NonInstructionalDay)}
+}
+
+func (m *memoryDays) Save(_ context.Context, day *entities.NonInstructionalDay) error {
+	m.days[day.Date.Format(entities.DateLayout)] = day
+	return nil
+}
+
+func (m *memoryDays) AddMissing(_ context.Context, days []*entities.NonInstructionalDay) ([]*entities.NonInstructionalDay, error) {
+	var added []*entities.NonInstructionalDay
+	for _, day := range days {
+		if _, ok := m.days[day.Date.Format(entities.DateLayout)]; !ok {
+			m.days[day.Date.Format(entities.DateLayout)] = day
+			added = append(added, day)
+		}
+	}
+	return added, nil
+}
+
+func (m *memoryDays) Delete(_ context.Context, _ string, date time.Time) (bool, error) {
+	key := date.Format(entities.DateLayout)
+	_, ok := m.days[key]
+	delete(m.days, key)
+	return ok, nil
+}
+
+func (m *memoryDays) List(_ context.Context, _ string, from time.Time, to time.Time) ([]*entities.NonInstructionalDay, error) {
+	var days []*entities.NonInstructionalDay
+	for _, day := range m.days {
+		if !day.Date.Before(from) && !day.Date.After(to) {
+			days = append(days, day)
+		}
+	}
+	slices.SortFunc(days, func(a, b *entities.NonInstructionalDay) int { return a.Date.Compare(b.Date) })
+	return days, nil
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func importHolidays(t *testing.T, store *memoryDays, country string, year int) (*entities.HolidayImport, error) {
+	t.Helper()
+	cmd, err := import_holidays_use_case.NewImportHolidaysCommand(tenantID, "admin-1", country, year)
+	if err != nil {
+		return nil, err
+	}
+	return import_holidays_use_case.NewImportHolidaysUseCase(store).Execute(context.Background(), cmd)
+}
+
+func TestImportHolidays_KeepsExistingDays(t *testing.T) {
+	store := newMemoryDays()
+	addCmd, err := add_non_instructional_day_use_case.NewAddNonInstructionalDayCommand(tenantID, "admin-1", "2025-09-15", "Desfile escolar")
+	assert.NoError(t, err)
+	_, err = add_non_instructional_day_use_case.NewAddNonInstructionalDayUseCase(store).Execute(context.Background(), addCmd)
+	assert.NoError(t, err)
+
+	result, err := importHolidays(t, store, "gt", 2025)
+	assert.NoError(t, err)
+	assert.Equal(t, "GT", result.Country)
+	assert.Len(t, result.Holidays, 10)
+	assert.Len(t, result.Added, 9, "the day added by hand is kept")
+	assert.Equal(t, "Desfile escolar", store.days["2025-09-15"].Name)
+	assert.Equal(t, "GT", store.days["2025-04-18"].Country)
+
+	again, err := importHolidays(t, store, "GT", 2025)
+	assert.NoError(t, err)
+	assert.Empty(t, again.Added)
+}
+
+func TestImportHolidays_UnsupportedCountry(t *testing.T) {
+	_, err := importHolidays(t, newMemoryDays(), "FR", 2025)
+	assert.Equal(t, calendarErrors.UnsupportedHolidaySetError.String(), codeOf(err))
+
+	_, err = importHolidays(t, newMemoryDays(), "GTM", 2025)
+	assert.Error(t, err)
+}
+
+func TestRemoveNonInstructionalDay(t *testing.T) {
+	store := newMemoryDays()
+	_, err := importHolidays(t, store, "US", 2025)
+	assert.NoError(t, err)
+
+	cmd, err := remove_non_instructional_day_use_case.NewRemoveNonInstructionalDayCommand(tenantID, "2025-11-27")
+	assert.NoError(t, err)
+	useCase := remove_non_instructional_day_use_case.NewRemoveNonInstructionalDayUseCase(store)
+	assert.NoError(t, useCase.Execute(context.Background(), cmd))
+	assert.NotContains(t, store.days, "2025-11-27")
+
+	err = useCase.Execute(context.Background(), cmd)
+	assert.Equal(t, calendarErrors.NonInstructionalDayNotFoundError.String(), codeOf(err))
+
+	_, err = remove_non_instructional_day_use_case.NewRemoveNonInstructionalDayCommand(tenantID, "27/11/2025")
+	assert.Error(t, err)
+}
+
+func TestListNonInstructionalDays(t *testing.T) {
+	store := newMemoryDays()
+	_, err := importHolidays(t, store, "MX", 2025)
+	assert.NoError(t, err)
+
+	cmd, err := list_non_instructional_days_use_case.NewListNonInstructionalDaysCommand(tenantID, "2025-09-01", "2025-12-31")
+	assert.NoError(t, err)
+	days, err := list_non_instructional_days_use_case.NewListNonInstructionalDaysUseCase(store).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Len(t, days, 3)
+	assert.Equal(t, "2025-09-16", days[0].Date.Format(entities.DateLayout))
+
+	_, err = list_non_instructional_days_use_case.NewListNonInstructionalDaysCommand(tenantID, "2025-12-31", "2025-09-01")
+	assert.Error(t, err)
+	_, err = list_non_instructional_days_use_case.NewListNonInstructionalDaysCommand(tenantID, "2025-01-01", "2028-01-01")
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func holidayDates(t *testing.T, country string, year int) map[string]string {
+	t.Helper()
+	holidays, ok := entities.HolidaySet(country, year)
+	assert.True(t, ok)
+
+	dates := make(map[string]string, len(holidays))
+	for _, holiday := range holidays {
+		dates[holiday.Date.Format(entities.DateLayout)] = holiday.Name
+	}
+	return dates
+}
+
+func TestHolidaySet_Guatemala(t *testing.T) {
+	dates := holidayDates(t, "GT", 2025)
+
+	// Easter Sunday 2025 is April 20
+	assert.Equal(t, "Jueves Santo", dates["2025-04-17"])
+	assert.Equal(t, "Viernes Santo", dates["2025-04-18"])
+	assert.Equal(t, "Sábado Santo", dates["2025-04-19"])
+	assert.Equal(t, "Día de la Independencia", dates["2025-09-15"])
+	assert.Len(t, dates, 10)
+}
+
+func TestHolidaySet_MovableDays(t *testing.T) {
+	us := holidayDates(t, "US", 2025)
+	assert.Equal(t, "Martin Luther King Jr. Day", us["2025-01-20"])
+	assert.Equal(t, "Memorial Day", us["2025-05-26"])
+	assert.Equal(t, "Thanksgiving Day", us["2025-11-27"])
+
+	mx := holidayDates(t, "MX", 2025)
+	assert.Equal(t, "Día de la Constitución", mx["2025-02-03"])
+	assert.Equal(t, "Día de la Revolución", mx["2025-11-17"])
+	assert.NotContains(t, mx, "2025-10-01")
+	assert.Contains(t, holidayDates(t, "MX", 2030), "2030-10-01", "change of federal government")
+}
+
+func TestHolidaySet_ObservesWeekendHolidays(t *testing.T) {
+	// July 4 2026 is a Saturday and November 11 2029 a Sunday
+	assert.Equal(t, "Independence Day", holidayDates(t, "US", 2026)["2026-07-03"])
+	assert.Equal(t, "Veterans Day", holidayDates(t, "US", 2029)["2029-11-12"])
+}
+
+func TestHolidaySet_UnknownCountry(t *testing.T) {
+	_, ok := entities.HolidaySet("ZZ", 2025)
+	assert.False(t, ok)
+	assert.Equal(t, []string{"GT", "MX", "US"}, entities.HolidayCountries())
+}
+
+func TestSchoolCalendar_NextWeeklySkipsNonInstructionalDays(t *testing.T) {
+	loc, err := time.LoadLocation("America/Guatemala")
+	assert.NoError(t, err)
+	day, err := entities.NewNonInstructionalDay("tenant1", time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC), "Día de la Independencia", "GT",
+		"admin-1", time.Now())
+	assert.NoError(t, err)
+	calendar := entities.NewSchoolCalendar([]*entities.NonInstructionalDay{day}, loc)
+
+	// Friday September 12, the Monday class of the 15th is skipped
+	after := time.Date(2025, 9, 12, 12, 0, 0, 0, loc)
+	next := calendar.NextWeekly(time.Monday, 8*60, after)
+	assert.Equal(t, time.Date(2025, 9, 22, 8, 0, 0, 0, loc), next)
+	assert.False(t, calendar.IsInstructional(time.Date(2025, 9, 15, 8, 0, 0, 0, loc)))
+
+	// 02:00 UTC on the 16th is still the 15th at the school
+	assert.False(t, calendar.IsInstructional(time.Date(2025, 9, 16, 2, 0, 0, 0, time.UTC)))
+
+	var none *entities.SchoolCalendar
+	assert.True(t, none.IsInstructional(after), "a nil calendar has classes every day")
+}
@@ -0,0 +1,278 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	delete_class_template_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/delete-class-template-use-case"
+	instantiate_class_template_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/instantiate-class-template-use-case"
+	list_class_templates_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/list-class-templates-use-case"
+	save_class_template_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/save-class-template-use-case"
+	set_class_template_shared_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/set-class-template-shared-use-case"
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/entities"
+	classTemplateErrors "github.com/nahualventure/class-backend/core/app/classtemplate/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	transcriptEntities "github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	transcriptErrors "github.com/nahualventure/class-backend/core/app/transcript/domain/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryTerms struct {
+	terms []*transcriptEntities.Term
+}
+
+func (m *memoryTerms) Create(_ context.Context, term *transcriptEntities.Term) error {
+	m.terms = append(m.terms, term)
+	return nil
+}
+
+func (m *memoryTerms) FindByID(_ context.Context, tenantID string, termID string) (*transcriptEntities.Term, error) {
+	for _, term := range m.terms {
+		if term.TenantID == tenantID && term.ID == termID {
+			return term, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryTerms) List(_ context.Context, tenantID string) ([]*transcriptEntities.Term, error) {
+	var found []*transcriptEntities.Term
+	for _, term := range m.terms {
+		if term.TenantID == tenantID {
+			found = append(found, term)
+		}
+	}
+	return found, nil
+}
+
+// memoryTemplates lets every tenant read shared templates like the class_templates queries
+type memoryTemplates struct {
+	templates []*entities.ClassTemplate
+}
+
+func (m *memoryTemplates) Create(_ context.Context, template *entities.ClassTemplate) error {
+	m.templates = append(m.templates, template)
+	return nil
+}
+
+func (m *memoryTemplates) FindByID(_ context.Context, tenantID string, templateID string) (*entities.ClassTemplate, error) {
+	for _, template := range m.templates {
+		if template.ID == templateID && (template.TenantID == tenantID || template.Shared) {
+			return template, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryTemplates) List(_ context.Context, tenantID string) ([]*entities.ClassTemplate, error) {
+	var found []*entities.ClassTemplate
+	for _, template := range m.templates {
+		if template.TenantID == tenantID || template.Shared {
+			found = append(found, template)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryTemplates) Delete(_ context.Context, tenantID string, templateID string) (bool, error) {
+	for i, template := range m.templates {
+		if template.ID == templateID && template.TenantID == tenantID {
+			m.templates = append(m.templates[:i], m.templates[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memoryTemplates) SetShared(_ context.Context, tenantID string, templateID string, shared bool) (bool, error) {
+	for _, template := range m.templates {
+		if template.ID == templateID && template.TenantID == tenantID {
+			template.Shared = shared
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// memorySetups keeps classes by tenant and class ID, standards by tenant
+type memorySetups struct {
+	classes   map[string]*entities.ClassSnapshot
+	standards map[string]map[entities.StandardRef]string
+}
+
+func newMemorySetups() *memorySetups {
+	return &memorySetups{
+		classes:   make(map[string]*entities.ClassSnapshot),
+		standards: make(map[string]map[entities.StandardRef]string),
+	}
+}
+
+func (m *memorySetups) Read(_ context.Context, tenantID string, classID string) (*entities.ClassSnapshot, error) {
+	if class, ok := m.classes[tenantID+"/"+classID]; ok {
+		return class, nil
+	}
+	return &entities.ClassSnapshot{TenantID: tenantID, ClassID: classID}, nil
+}
+
+func (m *memorySetups) ResolveStandards(_ context.Context, tenantID string, refs []entities.StandardRef) (map[entities.StandardRef]string, error) {
+	resolved := make(map[entities.StandardRef]string)
+	for _, ref := range refs {
+		if standardID, ok := m.standards[tenantID][ref]; ok {
+			resolved[ref] = standardID
+		}
+	}
+	return resolved, nil
+}
+
+func (m *memorySetups) Write(_ context.Context, class *entities.ClassSnapshot, _ string) error {
+	m.classes[class.TenantID+"/"+class.ClassID] = class
+	return nil
+}
+
+func newTerm(t *testing.T, tenantID string, startsOn string) *transcriptEntities.Term {
+	starts, err := time.Parse(time.DateOnly, startsOn)
+	assert.NoError(t, err)
+	term, err := transcriptEntities.NewTerm(uuid.New().String(), tenantID, "Term "+startsOn, starts, starts.AddDate(0, 4, 0), "admin-1",
+		time.Now())
+	assert.NoError(t, err)
+	return term
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, code, appErr.GetCode())
+	}
+}
+
+var ratios = entities.StandardRef{Framework: "CCSS.Math", Code: "6.RP.A.1"}
+var expressions = entities.StandardRef{Framework: "CCSS.Math", Code: "6.EE.A.1"}
+
+// fallClass is a class of the term starting on 2025-08-18 with one assignment due a week and a day
+// later and one tagged assignment without a due date
+func fallClass(setups *memorySetups, tenantID string, classID string) {
+	capacity := 30
+	dueAt := time.Date(2025, 8, 26, 23, 59, 0, 0, time.UTC)
+	setups.classes[tenantID+"/"+classID] = &entities.ClassSnapshot{
+		TenantID:         tenantID,
+		ClassID:          classID,
+		RequiresApproval: true,
+		Capacity:         &capacity,
+		Meetings:         []entities.TemplateMeeting{{Weekday: time.Monday, StartMinute: 540, EndMinute: 630}},
+		Assignments: []entities.ClassAssignment{
+			{AssignmentID: uuid.New().String(), Standards: []entities.StandardRef{expressions}},
+			{AssignmentID: uuid.New().String(), DueAt: &dueAt, PenaltyPercentPerDay: 10, CutoffDays: 3,
+				Standards: []entities.StandardRef{ratios, expressions}},
+		},
+	}
+}
+
+func TestSaveAndInstantiateClassTemplate_LaysDueDatesOutFromTheTermStart(t *testing.T) {
+	ctx := context.Background()
+	fall := newTerm(t, "north", "2025-08-18")
+	spring := newTerm(t, "north", "2026-01-12")
+	terms := &memoryTerms{terms: []*transcriptEntities.Term{fall, spring}}
+	templates := &memoryTemplates{}
+	setups := newMemorySetups()
+	setups.standards["north"] = map[entities.StandardRef]string{ratios: "ratios-id", expressions: "expressions-id"}
+	sourceID := uuid.New().String()
+	fallClass(setups, "north", sourceID)
+
+	saveCommand, err := save_class_template_use_case.NewSaveClassTemplateCommand("north", sourceID, fall.ID, "Math 6", "", "head-1")
+	assert.NoError(t, err)
+	template, err := save_class_template_use_case.NewSaveClassTemplateUseCase(terms, setups, templates).Execute(ctx, saveCommand)
+	assert.NoError(t, err)
+	if assert.Len(t, template.Setup.Assignments, 2) {
+		assert.Equal(t, 8*24*time.Hour+23*time.Hour+59*time.Minute, *template.Setup.Assignments[0].DueAfter)
+		assert.Nil(t, template.Setup.Assignments[1].DueAfter)
+	}
+
+	newClassID := uuid.New().String()
+	command, err := instantiate_class_template_use_case.NewInstantiateClassTemplateCommand("north", template.ID, newClassID, spring.ID,
+		"instructor-1")
+	assert.NoError(t, err)
+	class, unresolved, err := instantiate_class_template_use_case.NewInstantiateClassTemplateUseCase(terms, setups, templates).Execute(ctx, command)
+	assert.NoError(t, err)
+	assert.Empty(t, unresolved)
+	assert.True(t, class.RequiresApproval)
+	assert.Equal(t, 30, *class.Capacity)
+	assert.Equal(t, []entities.TemplateMeeting{{Weekday: time.Monday, StartMinute: 540, EndMinute: 630}}, class.Meetings)
+	if assert.Len(t, class.Assignments, 2) {
+		due := class.Assignments[0]
+		assert.Equal(t, time.Date(2026, 1, 20, 23, 59, 0, 0, time.UTC), *due.DueAt)
+		assert.Equal(t, 10.0, due.PenaltyPercentPerDay)
+		assert.Equal(t, []string{"ratios-id", "expressions-id"}, due.StandardIDs)
+		assert.NotEqual(t, setups.classes["north/"+sourceID].Assignments[1].AssignmentID, due.AssignmentID)
+	}
+	assert.Same(t, class, setups.classes["north/"+newClassID])
+}
+
+func TestInstantiateClassTemplateUseCase_Execute_RefusesAClassAlreadySetUp(t *testing.T) {
+	fall := newTerm(t, "north", "2025-08-18")
+	terms := &memoryTerms{terms: []*transcriptEntities.Term{fall}}
+	setups := newMemorySetups()
+	classID := uuid.New().String()
+	fallClass(setups, "north", classID)
+	template, err := entities.NewClassTemplate(uuid.New().String(), "north", "Math 6", "", entities.ClassSetup{}, false, "head-1", time.Now())
+	assert.NoError(t, err)
+	templates := &memoryTemplates{templates: []*entities.ClassTemplate{template}}
+
+	command, err := instantiate_class_template_use_case.NewInstantiateClassTemplateCommand("north", template.ID, classID, fall.ID, "instructor-1")
+	assert.NoError(t, err)
+	_, _, err = instantiate_class_template_use_case.NewInstantiateClassTemplateUseCase(terms, setups, templates).Execute(context.Background(), command)
+	assertCode(t, err, string(classTemplateErrors.ClassNotEmptyError))
+
+	command.TermID = uuid.New().String()
+	command.ClassID = uuid.New().String()
+	_, _, err = instantiate_class_template_use_case.NewInstantiateClassTemplateUseCase(terms, setups, templates).Execute(context.Background(), command)
+	assertCode(t, err, string(transcriptErrors.TermNotFoundError))
+}
+
+func TestSharedClassTemplate_InstantiatedByOtherTenantsWithTheirStandards(t *testing.T) {
+	ctx := context.Background()
+	north := newTerm(t, "north", "2025-08-18")
+	south := newTerm(t, "south", "2025-09-01")
+	terms := &memoryTerms{terms: []*transcriptEntities.Term{north, south}}
+	templates := &memoryTemplates{}
+	setups := newMemorySetups()
+	setups.standards["south"] = map[entities.StandardRef]string{expressions: "south-expressions-id"}
+	sourceID := uuid.New().String()
+	fallClass(setups, "north", sourceID)
+
+	saveCommand, err := save_class_template_use_case.NewSaveClassTemplateCommand("north", sourceID, north.ID, "Math 6", "", "head-1")
+	assert.NoError(t, err)
+	template, err := save_class_template_use_case.NewSaveClassTemplateUseCase(terms, setups, templates).Execute(ctx, saveCommand)
+	assert.NoError(t, err)
+
+	listCommand, err := list_class_templates_use_case.NewListClassTemplatesCommand("south")
+	assert.NoError(t, err)
+	listed, err := list_class_templates_use_case.NewListClassTemplatesUseCase(templates).Execute(ctx, listCommand)
+	assert.NoError(t, err)
+	assert.Empty(t, listed)
+
+	shareCommand, err := set_class_template_shared_use_case.NewSetClassTemplateSharedCommand("north", template.ID, true)
+	assert.NoError(t, err)
+	assert.NoError(t, set_class_template_shared_use_case.NewSetClassTemplateSharedUseCase(templates).Execute(ctx, shareCommand))
+
+	listed, err = list_class_templates_use_case.NewListClassTemplatesUseCase(templates).Execute(ctx, listCommand)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	command, err := instantiate_class_template_use_case.NewInstantiateClassTemplateCommand("south", template.ID, uuid.New().String(), south.ID,
+		"instructor-9")
+	assert.NoError(t, err)
+	class, unresolved, err := instantiate_class_template_use_case.NewInstantiateClassTemplateUseCase(terms, setups, templates).Execute(ctx, command)
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.StandardRef{ratios}, unresolved)
+	assert.Equal(t, "south", class.TenantID)
+	assert.Equal(t, []string{"south-expressions-id"}, class.Assignments[0].StandardIDs)
+
+	deleteCommand, err := delete_class_template_use_case.NewDeleteClassTemplateCommand("south", template.ID)
+	assert.NoError(t, err)
+	err = delete_class_template_use_case.NewDeleteClassTemplateUseCase(templates).Execute(ctx, deleteCommand)
+	assertCode(t, err, string(classTemplateErrors.ClassTemplateNotFoundError))
+}
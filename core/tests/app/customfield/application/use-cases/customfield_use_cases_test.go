@@ -0,0 +1,310 @@
+package use_cases
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	create_custom_field_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/create-custom-field-use-case"
+	delete_custom_field_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/delete-custom-field-use-case"
+	get_attributes_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/get-attributes-use-case"
+	search_attributes_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/search-attributes-use-case"
+	set_attributes_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/set-attributes-use-case"
+	update_custom_field_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/update-custom-field-use-case"
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	customFieldErrors "github.com/nahualventure/class-backend/core/app/customfield/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant-1"
+
+type memoryFields struct {
+	fields []*entities.CustomField
+	// attributes loses the values of deleted fields like the database does
+	attributes *memoryAttributes
+}
+
+func (m *memoryFields) Create(_ context.Context, field *entities.CustomField) (bool, error) {
+	for _, stored := range m.fields {
+		if stored.TenantID == field.TenantID && stored.Entity == field.Entity && stored.Key == field.Key {
+			return false, nil
+		}
+	}
+	copied := *field
+	m.fields = append(m.fields, &copied)
+	return true, nil
+}
+
+func (m *memoryFields) Save(_ context.Context, field *entities.CustomField) error {
+	for i, stored := range m.fields {
+		if stored.ID == field.ID {
+			copied := *field
+			m.fields[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (m *memoryFields) FindByID(_ context.Context, tenantID string, fieldID string) (*entities.CustomField, error) {
+	for _, stored := range m.fields {
+		if stored.TenantID == tenantID && stored.ID == fieldID {
+			copied := *stored
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryFields) List(_ context.Context, tenantID string, entity entities.Entity) ([]*entities.CustomField, error) {
+	var fields []*entities.CustomField
+	for _, stored := range m.fields {
+		if stored.TenantID == tenantID && (entity == "" || stored.Entity == entity) {
+			fields = append(fields, stored)
+		}
+	}
+	return fields, nil
+}
+
+func (m *memoryFields) Delete(_ context.Context, tenantID string, fieldID string) (bool, error) {
+	for i, stored := range m.fields {
+		if stored.TenantID == tenantID && stored.ID == fieldID {
+			m.fields = append(m.fields[:i], m.fields[i+1:]...)
+			if m.attributes != nil {
+				for _, record := range m.attributes.records[stored.Entity] {
+					delete(record.Attributes, stored.Key)
+				}
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// memoryAttributes keeps the records of a single tenant by entity
+type memoryAttributes struct {
+	records map[entities.Entity]map[string]*entities.Record
+}
+
+func newMemoryAttributes() *memoryAttributes {
+	return &memoryAttributes{records: make(map[entities.Entity]map[string]*entities.Record)}
+}
+
+func (m *memoryAttributes) Get(_ context.Context, _ string, entity entities.Entity, entityID string) (entities.Attributes, error) {
+	if record, ok := m.records[entity][entityID]; ok {
+		return record.Attributes, nil
+	}
+	return entities.Attributes{}, nil
+}
+
+func (m *memoryAttributes) Set(_ context.Context, _ string, entity entities.Entity, entityID string,
+	attributes entities.Attributes) (*entities.Record, error) {
+	if m.records[entity] == nil {
+		m.records[entity] = make(map[string]*entities.Record)
+	}
+	record := &entities.Record{EntityID: entityID, Attributes: attributes, UpdatedAt: time.Now()}
+	m.records[entity][entityID] = record
+	return record, nil
+}
+
+func (m *memoryAttributes) Search(_ context.Context, _ string, entity entities.Entity, match entities.Attributes,
+	page *pagination.PageRequest) (*pagination.Page[*entities.Record], error) {
+	var records []*entities.Record
+	for _, record := range m.records[entity] {
+		matches := true
+		for key, value := range match {
+			matches = matches && reflect.DeepEqual(record.Attributes[key], value)
+		}
+		if matches {
+			records = append(records, record)
+		}
+	}
+	return pagination.NewPage(page, records, int64(len(records)), func(record *entities.Record) []string {
+		return []string{pagination.TimeKey(record.UpdatedAt), record.EntityID}
+	}), nil
+}
+
+func assertCode(t *testing.T, err error, code appErrors.ErrorCode) appErrors.ApplicationError {
+	t.Helper()
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr), "expected %s, got %v", code, err) {
+		assert.Equal(t, code.String(), appErr.GetCode())
+	}
+	return appErr
+}
+
+func createField(fields *memoryFields, entity string, key string, fieldType string, options []string,
+	required bool) (*entities.CustomField, error) {
+	command, err := create_custom_field_use_case.NewCreateCustomFieldCommand(tenantID, "admin-1", entity, key, "Label",
+		fieldType, options, required)
+	if err != nil {
+		return nil, err
+	}
+	return create_custom_field_use_case.NewCreateCustomFieldUseCase(fields).Execute(context.Background(), command)
+}
+
+func setAttributes(fields *memoryFields, attributes *memoryAttributes, entityID string,
+	values map[string]any) (*entities.Record, error) {
+	command, err := set_attributes_use_case.NewSetAttributesCommand(tenantID, entities.EntityUser, entityID, values)
+	if err != nil {
+		return nil, err
+	}
+	return set_attributes_use_case.NewSetAttributesUseCase(fields, attributes).Execute(context.Background(), command)
+}
+
+func TestCreateCustomField_RefusesTakenKeysAndInvalidDefinitions(t *testing.T) {
+	fields := &memoryFields{}
+
+	field, err := createField(fields, "user", "district", "select", []string{"north", "south"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TypeSelect, field.Type)
+	assert.Equal(t, "admin-1", field.CreatedBy)
+
+	_, err = createField(fields, "user", "district", "text", nil, false)
+	assertCode(t, err, customFieldErrors.CustomFieldKeyTakenError)
+
+	// Keys are per entity
+	_, err = createField(fields, "class", "district", "text", nil, false)
+	assert.NoError(t, err)
+
+	_, err = createField(fields, "user", "Bus Route", "text", nil, false)
+	assertCode(t, err, appErrors.ValidationError)
+	_, err = createField(fields, "user", "house", "select", nil, false)
+	assertCode(t, err, appErrors.ValidationError)
+	_, err = createField(fields, "user", "nickname", "text", []string{"a"}, false)
+	assertCode(t, err, appErrors.ValidationError)
+	_, err = createField(fields, "course", "room", "text", nil, false)
+	assertCode(t, err, appErrors.ValidationError)
+}
+
+func TestSetAttributes_ChecksEveryValueAgainstItsField(t *testing.T) {
+	attributes := newMemoryAttributes()
+	fields := &memoryFields{attributes: attributes}
+	_, err := createField(fields, "user", "district", "select", []string{"north", "south"}, true)
+	assert.NoError(t, err)
+	_, err = createField(fields, "user", "grade", "number", nil, false)
+	assert.NoError(t, err)
+	_, err = createField(fields, "user", "enrolled_on", "date", nil, false)
+	assert.NoError(t, err)
+	userID := uuid.NewString()
+
+	_, err = setAttributes(fields, attributes, userID, map[string]any{
+		"grade":       "seven",
+		"enrolled_on": "03/01/2025",
+		"nickname":    "Ada",
+	})
+	appErr := assertCode(t, err, appErrors.ValidationError)
+	assert.Equal(t, map[string]any{
+		"district":    "Required",
+		"grade":       "Must be a number",
+		"enrolled_on": "Must be a date such as 2025-03-01",
+		"nickname":    "Unknown custom field",
+	}, appErr.GetContext())
+
+	_, err = setAttributes(fields, attributes, userID, map[string]any{"district": "east"})
+	appErr = assertCode(t, err, appErrors.ValidationError)
+	assert.Equal(t, "Must be one of north, south", appErr.GetContext()["district"])
+
+	record, err := setAttributes(fields, attributes, userID, map[string]any{"district": "north", "grade": 7, "enrolled_on": nil})
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Attributes{"district": "north", "grade": float64(7)}, record.Attributes)
+
+	get, err := get_attributes_use_case.NewGetAttributesCommand(tenantID, entities.EntityUser, userID)
+	assert.NoError(t, err)
+	stored, err := get_attributes_use_case.NewGetAttributesUseCase(attributes).Execute(context.Background(), get)
+	assert.NoError(t, err)
+	assert.Equal(t, record.Attributes, stored)
+
+	get, err = get_attributes_use_case.NewGetAttributesCommand(tenantID, entities.EntityUser, uuid.NewString())
+	assert.NoError(t, err)
+	stored, err = get_attributes_use_case.NewGetAttributesUseCase(attributes).Execute(context.Background(), get)
+	assert.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func TestSearchAttributes_ParsesValuesWithTheTypeOfTheirField(t *testing.T) {
+	attributes := newMemoryAttributes()
+	fields := &memoryFields{attributes: attributes}
+	_, err := createField(fields, "user", "grade", "number", nil, false)
+	assert.NoError(t, err)
+	_, err = createField(fields, "user", "bus", "boolean", nil, false)
+	assert.NoError(t, err)
+	seventh := uuid.NewString()
+	_, err = setAttributes(fields, attributes, seventh, map[string]any{"grade": 7, "bus": true})
+	assert.NoError(t, err)
+	_, err = setAttributes(fields, attributes, uuid.NewString(), map[string]any{"grade": 8, "bus": true})
+	assert.NoError(t, err)
+	useCase := search_attributes_use_case.NewSearchAttributesUseCase(fields, attributes)
+
+	search := func(match map[string]string) (*pagination.Page[*entities.Record], error) {
+		command, err := search_attributes_use_case.NewSearchAttributesCommand(tenantID, entities.EntityUser, match, 20, "")
+		if err != nil {
+			return nil, err
+		}
+		return useCase.Execute(context.Background(), command)
+	}
+
+	page, err := search(map[string]string{"grade": "7", "bus": "true"})
+	assert.NoError(t, err)
+	if assert.Len(t, page.Items, 1) {
+		assert.Equal(t, seventh, page.Items[0].EntityID)
+	}
+
+	page, err = search(map[string]string{"bus": "true"})
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+
+	_, err = search(map[string]string{"grade": "seventh", "district": "north"})
+	appErr := assertCode(t, err, appErrors.ValidationError)
+	assert.Equal(t, map[string]any{"grade": "Must be a number", "district": "Unknown custom field"}, appErr.GetContext())
+
+	_, err = search(map[string]string{})
+	assertCode(t, err, appErrors.ValidationError)
+}
+
+func TestUpdateAndDeleteCustomField_KeepTheTypeAndRemoveTheValues(t *testing.T) {
+	attributes := newMemoryAttributes()
+	fields := &memoryFields{attributes: attributes}
+	district, err := createField(fields, "user", "district", "select", []string{"north"}, false)
+	assert.NoError(t, err)
+	userID := uuid.NewString()
+	_, err = setAttributes(fields, attributes, userID, map[string]any{"district": "north"})
+	assert.NoError(t, err)
+
+	update := func(fieldID string, options []string) (*entities.CustomField, error) {
+		command, err := update_custom_field_use_case.NewUpdateCustomFieldCommand(tenantID, fieldID, "District", options, true)
+		if err != nil {
+			return nil, err
+		}
+		return update_custom_field_use_case.NewUpdateCustomFieldUseCase(fields).Execute(context.Background(), command)
+	}
+
+	updated, err := update(district.ID, []string{"north", "south"})
+	assert.NoError(t, err)
+	assert.Equal(t, "District", updated.Label)
+	assert.Equal(t, []string{"north", "south"}, updated.Options)
+	assert.True(t, updated.Required)
+
+	// A select field keeps needing options
+	_, err = update(district.ID, nil)
+	assertCode(t, err, appErrors.ValidationError)
+	_, err = update(uuid.NewString(), []string{"north"})
+	assertCode(t, err, customFieldErrors.CustomFieldNotFoundError)
+
+	remove := func(fieldID string) error {
+		command, err := delete_custom_field_use_case.NewDeleteCustomFieldCommand(tenantID, fieldID)
+		if err != nil {
+			return err
+		}
+		return delete_custom_field_use_case.NewDeleteCustomFieldUseCase(fields).Execute(context.Background(), command)
+	}
+
+	assert.NoError(t, remove(district.ID))
+	assert.Empty(t, attributes.records[entities.EntityUser][userID].Attributes)
+	assertCode(t, remove(district.ID), customFieldErrors.CustomFieldNotFoundError)
+}
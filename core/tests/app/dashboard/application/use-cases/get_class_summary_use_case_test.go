@@ -0,0 +1,55 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	get_class_summary_use_case "github.com/nahualventure/class-backend/core/app/dashboard/application/use-cases/get-class-summary-use-case"
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/entities"
+	dashboardErrors "github.com/nahualventure/class-backend/core/app/dashboard/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const classID = "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f"
+
+type memorySummaries struct {
+	summaries map[string]*entities.ClassSummary
+	err       error
+}
+
+func (s *memorySummaries) FindClassSummary(_ context.Context, tenantID string, classID string) (*entities.ClassSummary, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.summaries[tenantID+"/"+classID], nil
+}
+
+func TestGetClassSummary(t *testing.T) {
+	summary := entities.NewClassSummary(classID, "tenant1", 24, 850, 10, 18, 20, time.Now())
+	summaries := &memorySummaries{summaries: map[string]*entities.ClassSummary{"tenant1/" + classID: summary}}
+	uc := get_class_summary_use_case.NewGetClassSummaryUseCase(summaries)
+
+	cmd, err := get_class_summary_use_case.NewGetClassSummaryCommand("tenant1", classID)
+	assert.NoError(t, err)
+	found, err := uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, summary, found)
+
+	cmd, _ = get_class_summary_use_case.NewGetClassSummaryCommand("tenant2", classID)
+	_, err = uc.Execute(context.Background(), cmd)
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr), "the summaries of other tenants are not found") {
+		assert.Equal(t, dashboardErrors.ClassSummaryNotFoundError.String(), appErr.GetCode())
+	}
+
+	summaries.err = errors.New("connection refused")
+	_, err = uc.Execute(context.Background(), cmd)
+	assert.Error(t, err)
+
+	_, err = get_class_summary_use_case.NewGetClassSummaryCommand("tenant1", "not-a-uuid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func day(value string) time.Time {
+	parsed, _ := time.Parse(time.DateOnly, value)
+	return parsed
+}
+
+func TestNewMetricSeries_DailyFillsGapsWithZero(t *testing.T) {
+	until := day("2025-09-10").Add(15 * time.Hour)
+
+	series := entities.NewMetricSeries(entities.MetricDefinitions[entities.SignupsMetric], entities.DailyGranularity, 3, until, []entities.DailyValue{
+		{Day: day("2025-09-08"), Value: 4},
+		{Day: day("2025-09-10"), Value: 2},
+	})
+
+	assert.Equal(t, []entities.SeriesPoint{
+		{PeriodStart: day("2025-09-08"), Value: 4},
+		{PeriodStart: day("2025-09-09"), Value: 0},
+		{PeriodStart: day("2025-09-10"), Value: 2},
+	}, series.Points)
+}
+
+func TestNewMetricSeries_WeeklyStartsOnMonday(t *testing.T) {
+	// 2025-09-10 is a Wednesday
+	until := day("2025-09-10")
+
+	series := entities.NewMetricSeries(entities.MetricDefinitions[entities.EnrollmentsMetric], entities.WeeklyGranularity, 2, until, []entities.DailyValue{
+		{Day: day("2025-09-01"), Value: 5},
+		{Day: day("2025-09-07"), Value: -1},
+		{Day: day("2025-09-08"), Value: 3},
+	})
+
+	assert.Equal(t, []entities.SeriesPoint{
+		{PeriodStart: day("2025-09-01"), Value: 4},
+		{PeriodStart: day("2025-09-08"), Value: 3},
+	}, series.Points)
+}
+
+func TestNewMetricSeries_WeeklyAveragesGauges(t *testing.T) {
+	series := entities.NewMetricSeries(entities.MetricDefinitions[entities.DailyActiveUsersMetric], entities.WeeklyGranularity, 1, day("2025-09-14"), []entities.DailyValue{
+		{Day: day("2025-09-08"), Value: 10},
+		{Day: day("2025-09-09"), Value: 4},
+	})
+
+	assert.Equal(t, 2.0, series.Points[0].Value)
+}
+
+func TestSeriesStart(t *testing.T) {
+	assert.Equal(t, day("2025-08-25"), entities.SeriesStart(entities.WeeklyGranularity, 3, day("2025-09-14")))
+	assert.Equal(t, day("2025-09-12"), entities.SeriesStart(entities.DailyGranularity, 3, day("2025-09-14")))
+}
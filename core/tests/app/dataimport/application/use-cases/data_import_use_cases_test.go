@@ -0,0 +1,311 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	get_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/get-import-use-case"
+	preview_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/preview-import-use-case"
+	run_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/run-import-use-case"
+	start_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/start-import-use-case"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	importErrors "github.com/nahualventure/class-backend/core/app/dataimport/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantID   = "tenant1"
+	actorID    = "admin-1"
+	templateID = "4f6a1c2e-8a0b-4c3d-9e5f-0a1b2c3d4e5f"
+)
+
+// memoryImports keeps the imports with their files and the outcome of their rows
+type memoryImports struct {
+	jobs  []*entities.ImportJob
+	files map[string][]byte
+	rows  map[string][]entities.RowResult
+}
+
+func newMemoryImports() *memoryImports {
+	return &memoryImports{files: map[string][]byte{}, rows: map[string][]entities.RowResult{}}
+}
+
+func (m *memoryImports) Create(_ context.Context, job *entities.ImportJob, content []byte) error {
+	m.jobs = append(m.jobs, job)
+	m.files[job.ID] = content
+	return nil
+}
+
+func (m *memoryImports) FindByID(_ context.Context, tenantID string, importID string) (*entities.ImportJob, error) {
+	for _, job := range m.jobs {
+		if job.TenantID == tenantID && job.ID == importID {
+			return job, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryImports) List(_ context.Context, _ string, _ *pagination.PageRequest) (*pagination.Page[*entities.ImportJob], error) {
+	return &pagination.Page[*entities.ImportJob]{Items: m.jobs}, nil
+}
+
+func (m *memoryImports) ListRows(_ context.Context, _ string, importID string, _ entities.RowStatus, _ *pagination.PageRequest) (*pagination.Page[*entities.RowResult], error) {
+	page := &pagination.Page[*entities.RowResult]{}
+	for i := range m.rows[importID] {
+		page.Items = append(page.Items, &m.rows[importID][i])
+	}
+	return page, nil
+}
+
+func (m *memoryImports) FailedRows(_ context.Context, _ string, importID string) ([]*entities.RowResult, error) {
+	var failed []*entities.RowResult
+	for i, row := range m.rows[importID] {
+		if row.Status == entities.RowStatusFailed {
+			failed = append(failed, &m.rows[importID][i])
+		}
+	}
+	return failed, nil
+}
+
+func (m *memoryImports) ClaimNextPending(_ context.Context, _ time.Duration) (*entities.ImportJob, []byte, error) {
+	for _, job := range m.jobs {
+		if job.Status == entities.ImportJobStatusPending {
+			job.Status = entities.ImportJobStatusRunning
+			return job, m.files[job.ID], nil
+		}
+	}
+	return nil, nil, nil
+}
+
+func (m *memoryImports) Complete(_ context.Context, importID string, rows []entities.RowResult) error {
+	job, _ := m.FindByID(context.Background(), tenantID, importID)
+	job.Status = entities.ImportJobStatusCompleted
+	for _, row := range rows {
+		if row.Status == entities.RowStatusFailed {
+			job.FailedRows++
+		} else {
+			job.ImportedRows++
+		}
+	}
+	m.rows[importID] = rows
+	delete(m.files, importID)
+	return nil
+}
+
+func (m *memoryImports) MarkFailed(_ context.Context, importID string, reason string) error {
+	job, _ := m.FindByID(context.Background(), tenantID, importID)
+	job.Status = entities.ImportJobStatusFailed
+	job.FailureReason = reason
+	return nil
+}
+
+type memoryTemplates struct {
+	templates []*entities.MappingTemplate
+}
+
+func (m *memoryTemplates) Save(_ context.Context, template *entities.MappingTemplate) (*entities.MappingTemplate, error) {
+	m.templates = append(m.templates, template)
+	return template, nil
+}
+
+func (m *memoryTemplates) FindByID(_ context.Context, tenantID string, templateID string) (*entities.MappingTemplate, error) {
+	for _, template := range m.templates {
+		if template.TenantID == tenantID && template.ID == templateID {
+			return template, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryTemplates) List(_ context.Context, _ string, _ string) ([]*entities.MappingTemplate, error) {
+	return m.templates, nil
+}
+
+func (m *memoryTemplates) Delete(_ context.Context, _ string, _ string) (bool, error) {
+	return false, nil
+}
+
+// memoryImporter keeps the imported values, rows without a name fail validation and rows named
+// "outage" fail as the database would
+type memoryImporter struct {
+	imported []map[string]string
+}
+
+func (i *memoryImporter) Check(_ context.Context, _ string, _ string, values map[string]string) error {
+	if values["name"] == "" {
+		return appErrors.NewValidationError("Invalid user", map[string]any{"name": "required"}, nil)
+	}
+	return nil
+}
+
+func (i *memoryImporter) Import(ctx context.Context, tenantID string, actorID string, values map[string]string) error {
+	if err := i.Check(ctx, tenantID, actorID, values); err != nil {
+		return err
+	}
+	if values["name"] == "outage" {
+		return appErrors.NewInfrastructureError("failed to create user", errors.New("connection refused"))
+	}
+	i.imported = append(i.imported, values)
+	return nil
+}
+
+type allowed bool
+
+func (a allowed) CanImport(_ string, _ string, _ entities.Definition) (bool, error) {
+	return bool(a), nil
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+const usersFile = "Full Name,E-mail,Password\n" +
+	"Ana Lopez,ana@example.com,secret-1\n" +
+	",nameless@example.com,secret-2\n" +
+	"Ana Again,ANA@example.com ,secret-3\n" +
+	"Luis Perez,luis@example.com,secret-4\n"
+
+func newPreview(templates *memoryTemplates, importer *memoryImporter, canImport bool) *preview_import_use_case.PreviewImportUseCase {
+	return preview_import_use_case.NewPreviewImportUseCase(templates,
+		ports.EntityImporters{entities.UsersImport: importer}, allowed(canImport))
+}
+
+func TestPreviewImport_ChecksEveryRowThroughTheTemplateMapping(t *testing.T) {
+	template, err := entities.NewMappingTemplate(templateID, tenantID, entities.UsersImport, "HR export",
+		entities.Mapping{"name": "full name", "email": "Email"}, actorID, time.Now(), time.Now())
+	assert.NoError(t, err)
+	importer := &memoryImporter{}
+
+	cmd, err := preview_import_use_case.NewPreviewImportCommand(tenantID, actorID, entities.UsersImport, []byte(usersFile),
+		templateID, map[string]string{"email": "E-mail"})
+	assert.NoError(t, err)
+	preview, err := newPreview(&memoryTemplates{templates: []*entities.MappingTemplate{template}}, importer, true).
+		Execute(context.Background(), cmd)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Mapping{"name": "full name", "email": "E-mail"}, preview.Mapping, "the request overrides the template")
+	assert.Equal(t, 4, preview.TotalRows)
+	assert.Equal(t, 2, preview.ValidRows)
+	assert.Equal(t, 2, preview.FailedRows)
+	assert.Equal(t, appErrors.ValidationError.String(), preview.Failures[0].ErrorCode)
+	assert.Equal(t, 3, preview.Failures[0].Line)
+	assert.Contains(t, preview.Failures[0].ErrorMessage, "name: required")
+	assert.Equal(t, importErrors.DuplicateImportRowError.String(), preview.Failures[1].ErrorCode, "emails are compared ignoring case")
+	assert.Equal(t, []string{"Ana Again", "ANA@example.com ", ""}, preview.Failures[1].Cells, "passwords are blanked")
+	assert.Equal(t, map[string]string{"name": "Luis Perez", "email": "luis@example.com", "password": ""}, preview.Sample[1].Values)
+	assert.Empty(t, importer.imported, "previews write nothing")
+}
+
+func TestPreviewImport_RejectsUnknownTemplatesAndUsersWithoutThePermission(t *testing.T) {
+	cmd, err := preview_import_use_case.NewPreviewImportCommand(tenantID, actorID, entities.UsersImport, []byte(usersFile),
+		templateID, nil)
+	assert.NoError(t, err)
+
+	_, err = newPreview(&memoryTemplates{}, &memoryImporter{}, true).Execute(context.Background(), cmd)
+	assert.Equal(t, importErrors.MappingTemplateNotFoundError.String(), codeOf(err))
+
+	_, err = newPreview(&memoryTemplates{}, &memoryImporter{}, false).Execute(context.Background(), cmd)
+	assert.Equal(t, appErrors.Forbidden.String(), codeOf(err))
+
+	cmd.TemplateID = ""
+	cmd.Mapping = entities.Mapping{"name": "Full Name", "phone": "Phone"}
+	_, err = newPreview(&memoryTemplates{}, &memoryImporter{}, true).Execute(context.Background(), cmd)
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err), "unknown fields and missing required columns")
+}
+
+func startImport(t *testing.T, imports *memoryImports, importer *memoryImporter, content string) *entities.ImportJob {
+	t.Helper()
+	cmd, err := start_import_use_case.NewStartImportCommand(tenantID, actorID, entities.UsersImport, "staff.csv", []byte(content),
+		"", map[string]string{"name": "Full Name", "email": "E-mail"})
+	assert.NoError(t, err)
+	job, err := start_import_use_case.NewStartImportUseCase(imports, newPreview(&memoryTemplates{}, importer, true)).
+		Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return job
+}
+
+func TestRunImport_ImportsTheValidRowsAndKeepsTheOutcomeOfEach(t *testing.T) {
+	imports := newMemoryImports()
+	importer := &memoryImporter{}
+	job := startImport(t, imports, importer, usersFile+"outage,outage@example.com,secret-5\n")
+	assert.Equal(t, entities.ImportJobStatusPending, job.Status)
+	assert.Equal(t, 5, job.TotalRows)
+	assert.Empty(t, importer.imported, "rows are imported by the worker")
+
+	runImport := run_import_use_case.NewRunImportUseCase(imports, ports.EntityImporters{entities.UsersImport: importer})
+	processed, err := runImport.Execute(context.Background())
+
+	assert.True(t, processed)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ImportJobStatusCompleted, job.Status)
+	assert.Equal(t, 2, job.ImportedRows)
+	assert.Equal(t, 3, job.FailedRows)
+	assert.Len(t, importer.imported, 2)
+	assert.NotContains(t, imports.files, job.ID, "the file is deleted")
+	outage := imports.rows[job.ID][4]
+	assert.Equal(t, appErrors.InternalError.String(), outage.ErrorCode)
+	assert.NotContains(t, outage.ErrorMessage, "connection refused")
+
+	processed, err = runImport.Execute(context.Background())
+	assert.False(t, processed)
+	assert.NoError(t, err)
+}
+
+func TestRunImport_FailsImportsWhoseFileIsGone(t *testing.T) {
+	imports := newMemoryImports()
+	importer := &memoryImporter{}
+	job := startImport(t, imports, importer, usersFile)
+	delete(imports.files, job.ID)
+
+	processed, err := run_import_use_case.NewRunImportUseCase(imports, ports.EntityImporters{entities.UsersImport: importer}).
+		Execute(context.Background())
+
+	assert.True(t, processed)
+	assert.Error(t, err)
+	assert.Equal(t, entities.ImportJobStatusFailed, job.Status)
+	assert.Contains(t, job.FailureReason, "no longer stored")
+	assert.Empty(t, importer.imported)
+}
+
+func TestErrorReport_ListsTheFailedRowsOnceTheImportFinished(t *testing.T) {
+	imports := newMemoryImports()
+	importer := &memoryImporter{}
+	job := startImport(t, imports, importer, usersFile)
+	getImport := get_import_use_case.NewGetImportUseCase(imports)
+	cmd, err := get_import_use_case.NewGetImportCommand(tenantID, job.ID)
+	assert.NoError(t, err)
+
+	_, err = getImport.ErrorReport(context.Background(), cmd)
+	assert.Equal(t, importErrors.ImportNotFinishedError.String(), codeOf(err))
+
+	_, err = run_import_use_case.NewRunImportUseCase(imports, ports.EntityImporters{entities.UsersImport: importer}).
+		Execute(context.Background())
+	assert.NoError(t, err)
+	report, err := getImport.ErrorReport(context.Background(), cmd)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "staff-errors.csv", report.FileName)
+	lines, err := csv.NewReader(strings.NewReader(string(report.Content))).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Full Name", "E-mail", "Password", "import_line", "import_error_code", "import_error"}, lines[0])
+	assert.Len(t, lines, 3)
+	assert.Equal(t, []string{"", "nameless@example.com", "", "3"}, lines[1][:4])
+	assert.Equal(t, importErrors.DuplicateImportRowError.String(), lines[2][4])
+
+	cmd, err = get_import_use_case.NewGetImportCommand("tenant2", job.ID)
+	assert.NoError(t, err)
+	_, err = getImport.Execute(context.Background(), cmd)
+	assert.Equal(t, importErrors.ImportNotFoundError.String(), codeOf(err))
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSheet_KeepsTheLinesOfTheFile(t *testing.T) {
+	content := "\xef\xbb\xbfdate,name\n" +
+		"2025-09-15,\"Independence\nDay\"\n" +
+		"\n" +
+		"2025-10-20\n"
+
+	sheet, err := entities.ParseSheet([]byte(content))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"date", "name"}, sheet.Header, "the byte order mark is dropped")
+	assert.Len(t, sheet.Rows, 2)
+	assert.Equal(t, 2, sheet.Rows[0].Line)
+	assert.Equal(t, 5, sheet.Rows[1].Line, "multiline cells and blank lines are counted")
+
+	records := sheet.Records(map[string]int{"date": 0, "name": 1})
+	assert.Equal(t, map[string]string{"date": "2025-09-15", "name": "Independence\nDay"}, records[0].Values)
+	assert.Equal(t, map[string]string{"date": "2025-10-20"}, records[1].Values, "short rows leave the missing fields out")
+}
+
+func TestParseSheet_RejectsUnreadableHeaders(t *testing.T) {
+	for name, content := range map[string]string{
+		"empty file":      "",
+		"empty column":    "date,,name\n",
+		"repeated column": "Date,name, date \n",
+		"invalid CSV":     "date,\"name\n",
+	} {
+		_, err := entities.ParseSheet([]byte(content))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestResolveColumns_FallsBackToColumnsNamedLikeTheFields(t *testing.T) {
+	definition := entities.Definitions[entities.GradesImport]
+	header := []string{"Class_ID", "Assignment", "student_id", "Score"}
+
+	columns, err := definition.ResolveColumns(entities.Mapping{"assignment_id": "assignment"}, header)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"class_id": 0, "assignment_id": 1, "student_id": 2, "score": 3}, columns,
+		"the optional reason has no column")
+
+	_, err = definition.ResolveColumns(entities.Mapping{}, header)
+	assert.Error(t, err, "assignment_id has no column of its name")
+}
@@ -0,0 +1,245 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	check_suppressions_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/check-suppressions-use-case"
+	get_deliverability_stats_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/get-deliverability-stats-use-case"
+	receive_delivery_events_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/receive-delivery-events-use-case"
+	remove_suppression_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/remove-suppression-use-case"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	deliverabilityErrors "github.com/nahualventure/class-backend/core/app/deliverability/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tenantID = "tenant1"
+
+// memoryDeliverability keeps the events and suppressions as the Postgres adapters do, events are
+// recorded once by provider and ID
+type memoryDeliverability struct {
+	events       []*entities.DeliveryEvent
+	suppressions []*entities.Suppression
+}
+
+func (m *memoryDeliverability) Record(_ context.Context, events []*entities.DeliveryEvent) (int, error) {
+	recorded := 0
+	for _, event := range events {
+		if slices.ContainsFunc(m.events, func(saved *entities.DeliveryEvent) bool {
+			return saved.Provider == event.Provider && saved.ID == event.ID
+		}) {
+			continue
+		}
+		m.events = append(m.events, event)
+		recorded++
+
+		suppression := entities.NewSuppression(event)
+		if suppression == nil {
+			continue
+		}
+		saved, _ := m.Find(context.Background(), suppression.TenantID, suppression.Email)
+		if saved == nil {
+			m.suppressions = append(m.suppressions, suppression)
+		} else if suppression.Reason == entities.SuppressionReasonComplaint {
+			*saved = *suppression
+		}
+	}
+	return recorded, nil
+}
+
+func (m *memoryDeliverability) Stats(_ context.Context, tenantID string, from time.Time) (*entities.DeliverabilityStats, error) {
+	stats := &entities.DeliverabilityStats{TenantID: tenantID, From: from}
+	for _, event := range m.events {
+		if event.TenantID != tenantID || event.OccurredAt.Before(from) {
+			continue
+		}
+		switch event.Type {
+		case entities.DeliveryEventDelivered:
+			stats.Delivered++
+		case entities.DeliveryEventBounced:
+			stats.Bounced++
+		case entities.DeliveryEventSoftBounced:
+			stats.SoftBounced++
+		case entities.DeliveryEventComplained:
+			stats.Complained++
+		}
+	}
+	for _, suppression := range m.suppressions {
+		if suppression.TenantID == tenantID && !suppression.SuppressedAt.Before(from) {
+			stats.Suppressed++
+		}
+	}
+	return stats, nil
+}
+
+func (m *memoryDeliverability) Suppressed(_ context.Context, emails []string) ([]*entities.Suppression, error) {
+	var found []*entities.Suppression
+	for _, suppression := range m.suppressions {
+		if slices.Contains(emails, suppression.Email) {
+			found = append(found, suppression)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryDeliverability) Find(_ context.Context, tenantID string, email string) (*entities.Suppression, error) {
+	for _, suppression := range m.suppressions {
+		if suppression.TenantID == tenantID && suppression.Email == email {
+			return suppression, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryDeliverability) List(_ context.Context, _ string, page *pagination.PageRequest) (*pagination.Page[*entities.Suppression], error) {
+	return pagination.NewPage(page, m.suppressions, int64(len(m.suppressions)), func(suppression *entities.Suppression) []string {
+		return []string{pagination.TimeKey(suppression.SuppressedAt), suppression.Email}
+	}), nil
+}
+
+func (m *memoryDeliverability) Remove(_ context.Context, tenantID string, email string) (bool, error) {
+	before := len(m.suppressions)
+	m.suppressions = slices.DeleteFunc(m.suppressions, func(suppression *entities.Suppression) bool {
+		return suppression.TenantID == tenantID && suppression.Email == email
+	})
+	return len(m.suppressions) < before, nil
+}
+
+// replayWebhook returns the events it was built with, as a provider webhook with a valid signature
+type replayWebhook struct {
+	events []*entities.DeliveryEvent
+}
+
+func (w *replayWebhook) Verify(_ context.Context, _ []byte, _ string, _ string) (string, error) {
+	return "", nil
+}
+
+func (w *replayWebhook) ParseEvents(_ context.Context, _ []byte) ([]*entities.DeliveryEvent, error) {
+	return w.events, nil
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func event(t *testing.T, id string, email string, eventType entities.DeliveryEventType) *entities.DeliveryEvent {
+	t.Helper()
+	event, err := entities.NewDeliveryEvent(entities.EmailProviderSES, id, tenantID, email, eventType, "", time.Now().UTC())
+	require.NoError(t, err)
+	return event
+}
+
+func receive(t *testing.T, store *memoryDeliverability, events ...*entities.DeliveryEvent) *receive_delivery_events_use_case.ReceiveDeliveryEventsResult {
+	t.Helper()
+	webhooks := map[entities.EmailProvider]ports.DeliveryWebhook{entities.EmailProviderSES: &replayWebhook{events: events}}
+	cmd, err := receive_delivery_events_use_case.NewReceiveDeliveryEventsCommand(entities.EmailProviderSES, []byte("{}"))
+	require.NoError(t, err)
+	result, err := receive_delivery_events_use_case.NewReceiveDeliveryEventsUseCase(webhooks, store).Execute(context.Background(), cmd)
+	require.NoError(t, err)
+	return result
+}
+
+func check(t *testing.T, store *memoryDeliverability, emails ...string) []*entities.Suppression {
+	t.Helper()
+	cmd, err := check_suppressions_use_case.NewCheckSuppressionsCommand(emails)
+	require.NoError(t, err)
+	suppressed, err := check_suppressions_use_case.NewCheckSuppressionsUseCase(store).Execute(context.Background(), cmd)
+	require.NoError(t, err)
+	return suppressed
+}
+
+func TestReceiveDeliveryEvents_SuppressesBouncesAndComplaintsOnce(t *testing.T) {
+	store := &memoryDeliverability{}
+	events := []*entities.DeliveryEvent{
+		event(t, "m1/0", "Ana@School.edu", entities.DeliveryEventBounced),
+		event(t, "m2/0", "luis@school.edu", entities.DeliveryEventSoftBounced),
+		event(t, "m3/0", "marta@school.edu", entities.DeliveryEventComplained),
+		event(t, "m4/0", "pedro@school.edu", entities.DeliveryEventDelivered),
+	}
+
+	result := receive(t, store, events...)
+	assert.Equal(t, 4, result.Received)
+	assert.Equal(t, 4, result.Recorded)
+
+	again := receive(t, store, events...)
+	assert.Equal(t, 0, again.Recorded, "providers retry webhooks, events are recorded once")
+	assert.Len(t, store.suppressions, 2)
+
+	suppressed := check(t, store, "ANA@school.edu", "luis@school.edu", "marta@school.edu", "pedro@school.edu")
+	var emails []string
+	for _, suppression := range suppressed {
+		emails = append(emails, suppression.Email)
+	}
+	assert.ElementsMatch(t, []string{"ana@school.edu", "marta@school.edu"}, emails, "soft bounces and deliveries do not suppress")
+}
+
+func TestReceiveDeliveryEvents_RefusesProvidersNotConfigured(t *testing.T) {
+	cmd, err := receive_delivery_events_use_case.NewReceiveDeliveryEventsCommand(entities.EmailProviderSendGrid, []byte("[]"))
+	require.NoError(t, err)
+
+	_, err = receive_delivery_events_use_case.NewReceiveDeliveryEventsUseCase(nil, &memoryDeliverability{}).Execute(context.Background(), cmd)
+	assert.Equal(t, deliverabilityErrors.InvalidEmailWebhookError.String(), codeOf(err))
+}
+
+func TestRemoveSuppression_KeepsComplaints(t *testing.T) {
+	store := &memoryDeliverability{}
+	receive(t, store,
+		event(t, "m1/0", "ana@school.edu", entities.DeliveryEventBounced),
+		event(t, "m2/0", "marta@school.edu", entities.DeliveryEventComplained),
+	)
+	remove := remove_suppression_use_case.NewRemoveSuppressionUseCase(store)
+
+	cmd, err := remove_suppression_use_case.NewRemoveSuppressionCommand(tenantID, "marta@school.edu")
+	require.NoError(t, err)
+	assert.Equal(t, deliverabilityErrors.SuppressionNotRemovableError.String(), codeOf(remove.Execute(context.Background(), cmd)))
+
+	cmd, err = remove_suppression_use_case.NewRemoveSuppressionCommand(tenantID, "Ana@School.edu")
+	require.NoError(t, err)
+	assert.NoError(t, remove.Execute(context.Background(), cmd))
+	assert.Empty(t, check(t, store, "ana@school.edu"))
+	assert.Equal(t, deliverabilityErrors.SuppressionNotFoundError.String(), codeOf(remove.Execute(context.Background(), cmd)))
+}
+
+func TestGetDeliverabilityStats_ReportsTenantsOverTheLimits(t *testing.T) {
+	store := &memoryDeliverability{}
+	for i := range 95 {
+		receive(t, store, event(t, fmt.Sprintf("d%d", i), "ok@school.edu", entities.DeliveryEventDelivered))
+	}
+	stats := func() *entities.DeliverabilityStats {
+		cmd, err := get_deliverability_stats_use_case.NewGetDeliverabilityStatsCommand(tenantID, 0)
+		require.NoError(t, err)
+		stats, err := get_deliverability_stats_use_case.NewGetDeliverabilityStatsUseCase(store).Execute(context.Background(), cmd)
+		require.NoError(t, err)
+		return stats
+	}
+	assert.Equal(t, entities.DeliverabilityHealthy, stats().Status())
+
+	receive(t, store,
+		event(t, "b1", "a@school.edu", entities.DeliveryEventBounced),
+		event(t, "b2", "b@school.edu", entities.DeliveryEventBounced),
+		event(t, "b3", "c@school.edu", entities.DeliveryEventBounced),
+		event(t, "b4", "d@school.edu", entities.DeliveryEventBounced),
+		event(t, "b5", "e@school.edu", entities.DeliveryEventBounced),
+	)
+	got := stats()
+	assert.Equal(t, int64(95), got.Delivered)
+	assert.Equal(t, int64(5), got.Suppressed)
+	assert.InDelta(t, 0.05, got.BounceRate(), 1e-9)
+	assert.Equal(t, entities.DeliverabilityHealthy, got.Status(), "the limit itself is still healthy")
+
+	receive(t, store, event(t, "c1", "f@school.edu", entities.DeliveryEventComplained))
+	assert.Equal(t, entities.DeliverabilityAtRisk, stats().Status(), "one complaint in 95 deliveries is over 0.1%")
+}
@@ -0,0 +1,276 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	create_developer_sandbox_use_case "github.com/nahualventure/class-backend/core/app/devsandbox/application/use-cases/create-developer-sandbox-use-case"
+	get_developer_sandbox_use_case "github.com/nahualventure/class-backend/core/app/devsandbox/application/use-cases/get-developer-sandbox-use-case"
+	reset_developer_sandbox_use_case "github.com/nahualventure/class-backend/core/app/devsandbox/application/use-cases/reset-developer-sandbox-use-case"
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/entities"
+	devSandboxErrors "github.com/nahualventure/class-backend/core/app/devsandbox/domain/errors"
+	serviceAccountEntities "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/tests/clocktest"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type memorySandboxes struct {
+	sandboxes []*entities.DeveloperSandbox
+}
+
+func (m *memorySandboxes) Create(_ context.Context, sandbox *entities.DeveloperSandbox) error {
+	m.sandboxes = append(m.sandboxes, sandbox)
+	return nil
+}
+
+func (m *memorySandboxes) FindByTenant(_ context.Context, tenantID string) (*entities.DeveloperSandbox, error) {
+	for _, sandbox := range m.sandboxes {
+		if sandbox.TenantID == tenantID {
+			return sandbox, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySandboxes) CountByEmail(_ context.Context, email string) (int, error) {
+	count := 0
+	for _, sandbox := range m.sandboxes {
+		if sandbox.Email == email {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *memorySandboxes) SaveReset(_ context.Context, _ *entities.DeveloperSandbox) error {
+	return nil
+}
+
+func (m *memorySandboxes) ListTenantIDs(_ context.Context) ([]string, error) {
+	var tenantIDs []string
+	for _, sandbox := range m.sandboxes {
+		tenantIDs = append(tenantIDs, sandbox.TenantID)
+	}
+	return tenantIDs, nil
+}
+
+// memoryData holds the rows of every tenant, a row per class, enrollment and grade
+type memoryData struct {
+	rows map[string][]string
+}
+
+func (d *memoryData) Replace(_ context.Context, tenantID string, data *entities.SyntheticData) (map[string]int, error) {
+	if !entities.IsDeveloperTenant(tenantID) {
+		return nil, errors.New("not a developer sandbox")
+	}
+	tables := map[string]int{}
+	d.rows[tenantID] = nil
+	for _, class := range data.Classes {
+		d.rows[tenantID] = append(d.rows[tenantID], "class "+class.ID)
+		d.rows[tenantID] = append(d.rows[tenantID], class.StudentIDs...)
+		tables["class_capacities"]++
+		tables["class_enrollments"] += len(class.StudentIDs)
+		tables["grades"] += len(class.Grades)
+	}
+	return tables, nil
+}
+
+// memoryPolicies holds the subjects of every role per tenant and the sandboxes active
+type memoryPolicies struct {
+	roles  map[string]map[string][]string
+	active []string
+}
+
+func (p *memoryPolicies) ReplaceRoles(_ context.Context, tenantID string, roles map[string][]string) error {
+	p.roles[tenantID] = roles
+	return nil
+}
+
+func (p *memoryPolicies) Activate(_ context.Context, tenantIDs []string) error {
+	p.active = tenantIDs
+	return nil
+}
+
+type memoryServiceAccounts struct {
+	accounts []*serviceAccountEntities.ServiceAccount
+}
+
+func (m *memoryServiceAccounts) Create(_ context.Context, account *serviceAccountEntities.ServiceAccount, _ serviceAccountEntities.ServiceAccountChange) error {
+	m.accounts = append(m.accounts, account)
+	return nil
+}
+
+func (m *memoryServiceAccounts) Save(_ context.Context, _ *serviceAccountEntities.ServiceAccount, _ []*serviceAccountEntities.ServiceAccountKey,
+	_ serviceAccountEntities.ServiceAccountChange) error {
+	return nil
+}
+
+func (m *memoryServiceAccounts) FindByID(_ context.Context, _ string, _ string) (*serviceAccountEntities.ServiceAccount, error) {
+	return nil, nil
+}
+
+func (m *memoryServiceAccounts) List(_ context.Context, _ string) ([]*serviceAccountEntities.ServiceAccount, error) {
+	return m.accounts, nil
+}
+
+func (m *memoryServiceAccounts) FindByKey(_ context.Context, _ string) (*serviceAccountEntities.ServiceAccount, *serviceAccountEntities.ServiceAccountKey, error) {
+	return nil, nil, nil
+}
+
+func (m *memoryServiceAccounts) TouchKey(_ context.Context, _ string, _ time.Time) error {
+	return nil
+}
+
+type devSandboxFixture struct {
+	clock     *clocktest.Clock
+	sandboxes *memorySandboxes
+	data      *memoryData
+	policies  *memoryPolicies
+	accounts  *memoryServiceAccounts
+	create    *create_developer_sandbox_use_case.CreateDeveloperSandboxUseCase
+	reset     *reset_developer_sandbox_use_case.ResetDeveloperSandboxUseCase
+	get       *get_developer_sandbox_use_case.GetDeveloperSandboxUseCase
+}
+
+func newDevSandboxFixture() *devSandboxFixture {
+	f := &devSandboxFixture{
+		clock:     clocktest.New(time.Date(2025, 3, 5, 15, 0, 0, 0, time.UTC)),
+		sandboxes: &memorySandboxes{},
+		data:      &memoryData{rows: make(map[string][]string)},
+		policies:  &memoryPolicies{roles: make(map[string]map[string][]string)},
+		accounts:  &memoryServiceAccounts{},
+	}
+	f.create = create_developer_sandbox_use_case.NewCreateDeveloperSandboxUseCase(f.sandboxes, f.data, f.policies, f.accounts).WithClock(f.clock)
+	f.reset = reset_developer_sandbox_use_case.NewResetDeveloperSandboxUseCase(f.sandboxes, f.data, f.policies).WithClock(f.clock)
+	f.get = get_developer_sandbox_use_case.NewGetDeveloperSandboxUseCase(f.sandboxes)
+	return f
+}
+
+func (f *devSandboxFixture) createSandbox(t *testing.T, email string) (*entities.DeveloperSandbox, *entities.SyntheticData, string, error) {
+	t.Helper()
+	cmd, err := create_developer_sandbox_use_case.NewCreateDeveloperSandboxCommand("Acme SIS sync", email)
+	assert.NoError(t, err)
+	return f.create.Execute(context.Background(), cmd)
+}
+
+func (f *devSandboxFixture) resetSandbox(t *testing.T, tenantID string) (*entities.DeveloperSandbox, *entities.SyntheticData, error) {
+	t.Helper()
+	cmd, err := reset_developer_sandbox_use_case.NewResetDeveloperSandboxCommand(tenantID)
+	assert.NoError(t, err)
+	return f.reset.Execute(context.Background(), cmd)
+}
+
+func assertCode(t *testing.T, err error, code appErrors.ErrorCode) {
+	t.Helper()
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr), "expected %s, got %v", code, err) {
+		assert.Equal(t, code.String(), appErr.GetCode())
+	}
+}
+
+func TestCreateDeveloperSandbox_SeedsATenantWithAKey(t *testing.T) {
+	f := newDevSandboxFixture()
+
+	sandbox, data, token, err := f.createSandbox(t, "Dev@Acme.io")
+
+	assert.NoError(t, err)
+	assert.True(t, entities.IsDeveloperTenant(sandbox.TenantID))
+	assert.Equal(t, "dev@acme.io", sandbox.Email)
+	assert.Equal(t, []string{sandbox.TenantID}, f.policies.active)
+	assert.Equal(t, 4, sandbox.Tables["class_capacities"])
+	assert.NotEmpty(t, f.data.rows[sandbox.TenantID])
+
+	assert.Len(t, f.accounts.accounts, 1)
+	account := f.accounts.accounts[0]
+	assert.Equal(t, sandbox.ServiceAccountID, account.ID)
+	assert.Equal(t, sandbox.TenantID, account.TenantID, "the key only works in the sandbox")
+	assert.Nil(t, account.Keys[0].ExpiresAt)
+	assert.Contains(t, token, account.Keys[0].ID)
+
+	roles := f.policies.roles[sandbox.TenantID]
+	assert.Equal(t, []string{account.Subject()}, roles[entities.SandboxRole])
+	assert.Len(t, roles["student"], 24)
+	assert.Len(t, roles["instructor"], 2)
+	for _, class := range data.Classes {
+		assert.Contains(t, roles["instructor"], class.InstructorID)
+		assert.Len(t, class.StudentIDs, 12)
+	}
+}
+
+func TestCreateDeveloperSandbox_LimitsSandboxesPerEmail(t *testing.T) {
+	f := newDevSandboxFixture()
+	for range entities.MaxSandboxesPerEmail {
+		_, _, _, err := f.createSandbox(t, "dev@acme.io")
+		assert.NoError(t, err)
+	}
+
+	_, _, _, err := f.createSandbox(t, "DEV@acme.io")
+
+	assertCode(t, err, devSandboxErrors.DeveloperSandboxLimitReachedError)
+	assert.Len(t, f.accounts.accounts, entities.MaxSandboxesPerEmail)
+	assert.Len(t, f.policies.active, entities.MaxSandboxesPerEmail)
+}
+
+func TestResetDeveloperSandbox_SeedsTheSameDataAgain(t *testing.T) {
+	f := newDevSandboxFixture()
+	sandbox, created, _, err := f.createSandbox(t, "dev@acme.io")
+	assert.NoError(t, err)
+	f.data.rows[sandbox.TenantID] = append(f.data.rows[sandbox.TenantID], "written by the integration")
+	f.policies.roles[sandbox.TenantID]["instructor"] = append(f.policies.roles[sandbox.TenantID]["instructor"], "user-9")
+	f.clock.Advance(10 * 24 * time.Hour)
+
+	reset, data, err := f.resetSandbox(t, sandbox.TenantID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reset.ResetCount)
+	assert.Equal(t, f.clock.Now(), *reset.ResetAt)
+	assert.NotContains(t, f.data.rows[sandbox.TenantID], "written by the integration")
+	assert.NotContains(t, f.policies.roles[sandbox.TenantID]["instructor"], "user-9")
+	assert.Equal(t, []string{"sa:" + sandbox.ServiceAccountID}, f.policies.roles[sandbox.TenantID][entities.SandboxRole],
+		"the key keeps working")
+	assert.Equal(t, created.People, data.People)
+	assert.Equal(t, created.Classes[0].ID, data.Classes[0].ID)
+	assert.Equal(t, created.Classes[0].Assignments[0].DueAt.AddDate(0, 0, 10), data.Classes[0].Assignments[0].DueAt,
+		"the dates follow the reset")
+
+	getCmd, err := get_developer_sandbox_use_case.NewGetDeveloperSandboxCommand(sandbox.TenantID)
+	assert.NoError(t, err)
+	_, current, err := f.get.Execute(context.Background(), getCmd)
+	assert.NoError(t, err)
+	assert.Equal(t, data, current)
+}
+
+func TestResetDeveloperSandbox_RefusesOtherTenants(t *testing.T) {
+	f := newDevSandboxFixture()
+	_, _, _, err := f.createSandbox(t, "dev@acme.io")
+	assert.NoError(t, err)
+
+	_, _, err = f.resetSandbox(t, "tenant1")
+
+	assertCode(t, err, devSandboxErrors.DeveloperSandboxNotFoundError)
+	assert.False(t, slices.ContainsFunc(f.sandboxes.sandboxes, func(sandbox *entities.DeveloperSandbox) bool {
+		return sandbox.ResetCount > 0
+	}))
+}
+
+func TestSyntheticData_IsTheSamePerTenant(t *testing.T) {
+	now := time.Date(2025, 3, 5, 15, 0, 0, 0, time.UTC)
+
+	first := entities.NewSyntheticData("dev-0123456789abcdef", now)
+	again := entities.NewSyntheticData("dev-0123456789abcdef", now)
+	other := entities.NewSyntheticData("dev-fedcba9876543210", now)
+
+	assert.Equal(t, first, again)
+	assert.NotEqual(t, first.Classes[0].ID, other.Classes[0].ID)
+	for _, class := range first.Classes {
+		assert.Len(t, class.Assignments, 3)
+		assert.True(t, class.Assignments[0].DueAt.Before(now))
+		assert.True(t, class.Assignments[2].DueAt.After(now))
+		assert.Len(t, class.Grades, 2*len(class.StudentIDs), "the assignment not due yet has no grades")
+	}
+}
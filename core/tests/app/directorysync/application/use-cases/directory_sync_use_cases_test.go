@@ -0,0 +1,206 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	resolve_sync_conflict_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/resolve-sync-conflict-use-case"
+	set_sync_policy_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/set-sync-policy-use-case"
+	sync_directory_users_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/sync-directory-users-use-case"
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	directorySyncErrors "github.com/nahualventure/class-backend/core/app/directorysync/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+// memoryDirectory keeps the members and the conflicts of the tenant, as the Postgres adapters do
+type memoryDirectory struct {
+	users     []*entities.LocalUser
+	conflicts []*entities.SyncConflict
+}
+
+func (m *memoryDirectory) FindByExternalIDs(_ context.Context, _ string, externalIDs []string) ([]*entities.LocalUser, error) {
+	var found []*entities.LocalUser
+	for _, user := range m.users {
+		if slices.Contains(externalIDs, user.ExternalID) {
+			found = append(found, user)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryDirectory) SaveSync(_ context.Context, _ string, user *entities.LocalUser, _ []entities.FieldSync, conflicts []*entities.SyncConflict) error {
+	m.conflicts = slices.DeleteFunc(m.conflicts, func(saved *entities.SyncConflict) bool {
+		return saved.UserID == user.UserID && saved.Status == entities.SyncConflictStatusOpen
+	})
+	m.conflicts = append(m.conflicts, conflicts...)
+	return nil
+}
+
+func (m *memoryDirectory) FindByID(_ context.Context, tenantID string, conflictID string) (*entities.SyncConflict, error) {
+	for _, conflict := range m.conflicts {
+		if conflict.TenantID == tenantID && conflict.ID == conflictID {
+			return conflict, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryDirectory) List(_ context.Context, _ string, status entities.SyncConflictStatus, page *pagination.PageRequest) (*pagination.Page[*entities.SyncConflict], error) {
+	var listed []*entities.SyncConflict
+	for _, conflict := range m.conflicts {
+		if conflict.Status == status {
+			listed = append(listed, conflict)
+		}
+	}
+	return pagination.NewPage(page, listed, int64(len(listed)), func(conflict *entities.SyncConflict) []string {
+		return []string{pagination.TimeKey(conflict.DetectedAt), conflict.ID}
+	}), nil
+}
+
+func (m *memoryDirectory) Resolve(_ context.Context, conflict *entities.SyncConflict) (bool, error) {
+	for _, user := range m.users {
+		if user.UserID != conflict.UserID {
+			continue
+		}
+		if conflict.Field == entities.FieldName {
+			user.Name = conflict.Value()
+		} else {
+			user.Email = conflict.Value()
+		}
+		user.Synced[conflict.Field] = conflict.UpstreamValue
+	}
+	return true, nil
+}
+
+type memoryPolicies struct {
+	policies []*entities.SyncPolicy
+}
+
+func (m *memoryPolicies) List(_ context.Context, _ string) ([]*entities.SyncPolicy, error) {
+	return m.policies, nil
+}
+
+func (m *memoryPolicies) Save(_ context.Context, policy *entities.SyncPolicy) error {
+	m.policies = slices.DeleteFunc(m.policies, func(saved *entities.SyncPolicy) bool { return saved.Field == policy.Field })
+	m.policies = append(m.policies, policy)
+	return nil
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+// renamed is a member whose name an admin changed after the last sync
+func renamed() *memoryDirectory {
+	return &memoryDirectory{users: []*entities.LocalUser{{
+		UserID:     "u1",
+		ExternalID: "S-1",
+		Name:       "Ana María López",
+		Email:      "ana@school.edu",
+		Synced:     map[string]string{entities.FieldName: "Ana López", entities.FieldEmail: "ana@school.edu"},
+	}}}
+}
+
+func setPolicy(t *testing.T, policies *memoryPolicies, field string, onConflict entities.ConflictPolicy) {
+	t.Helper()
+	cmd, err := set_sync_policy_use_case.NewSetSyncPolicyCommand(tenantID, field, string(onConflict), "admin-1")
+	assert.NoError(t, err)
+	_, err = set_sync_policy_use_case.NewSetSyncPolicyUseCase(policies).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+}
+
+func sync(t *testing.T, directory *memoryDirectory, policies *memoryPolicies, items ...sync_directory_users_use_case.SyncDirectoryUserItem) *sync_directory_users_use_case.SyncDirectoryUsersResult {
+	t.Helper()
+	cmd, err := sync_directory_users_use_case.NewSyncDirectoryUsersCommand(tenantID, items, "connector-1")
+	assert.NoError(t, err)
+	result, err := sync_directory_users_use_case.NewSyncDirectoryUsersUseCase(directory, policies).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return result
+}
+
+var upstream = sync_directory_users_use_case.SyncDirectoryUserItem{ExternalID: "S-1", Name: "Ana Lopez", Email: "ana@school.edu"}
+
+func TestSyncDirectoryUsers_AppliesThePolicyOfTheField(t *testing.T) {
+	tests := []struct {
+		policy  entities.ConflictPolicy
+		outcome entities.SyncOutcome
+		name    string
+	}{
+		{entities.ConflictPolicyUpstreamWins, entities.SyncOutcomeUpdated, "Ana Lopez"},
+		{entities.ConflictPolicyLocalWins, entities.SyncOutcomeKeptLocal, "Ana María López"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			directory := renamed()
+			policies := &memoryPolicies{}
+			setPolicy(t, policies, entities.FieldName, tt.policy)
+
+			result := sync(t, directory, policies, upstream)
+
+			assert.Equal(t, 1, result.Conflicts)
+			assert.Equal(t, 0, result.Queued)
+			assert.Equal(t, tt.outcome, result.Items[0].Fields[0].Outcome)
+			assert.Equal(t, tt.name, directory.users[0].Name)
+			assert.Empty(t, directory.conflicts)
+
+			again := sync(t, directory, policies, upstream)
+			assert.Equal(t, 0, again.Conflicts, "the directory value is the synced one now")
+		})
+	}
+}
+
+func TestSyncDirectoryUsers_QueuesConflictsUntilResolved(t *testing.T) {
+	directory := renamed()
+	policies := &memoryPolicies{}
+
+	result := sync(t, directory, policies, upstream)
+	assert.Equal(t, 1, result.Queued, "conflicts wait for an admin by default")
+	assert.Equal(t, sync_directory_users_use_case.ItemUnchanged, result.Items[0].Status)
+	assert.Len(t, directory.conflicts, 1)
+
+	sync(t, directory, policies, upstream)
+	assert.Len(t, directory.conflicts, 1, "a conflict synced again is not queued twice")
+
+	conflict := directory.conflicts[0]
+	resolve := resolve_sync_conflict_use_case.NewResolveSyncConflictUseCase(directory)
+	cmd, err := resolve_sync_conflict_use_case.NewResolveSyncConflictCommand(tenantID, conflict.ID, string(entities.SyncResolutionUpstream), "admin-1")
+	assert.NoError(t, err)
+	resolved, err := resolve.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.SyncConflictStatusResolved, resolved.Status)
+	assert.Equal(t, "Ana Lopez", directory.users[0].Name)
+
+	_, err = resolve.Execute(context.Background(), cmd)
+	assert.Equal(t, directorySyncErrors.SyncConflictNotOpenError.String(), codeOf(err))
+	assert.Equal(t, 0, sync(t, directory, policies, upstream).Conflicts)
+}
+
+func TestSyncDirectoryUsers_ReportsUsersIndividually(t *testing.T) {
+	directory := renamed()
+	directory.users[0].Synced = nil
+
+	result := sync(t, directory, &memoryPolicies{},
+		upstream,
+		sync_directory_users_use_case.SyncDirectoryUserItem{ExternalID: "s-1", Name: "Ana", Email: "ana@school.edu"},
+		sync_directory_users_use_case.SyncDirectoryUserItem{ExternalID: "S-2", Name: "Luis", Email: "luis@school.edu"},
+		sync_directory_users_use_case.SyncDirectoryUserItem{ExternalID: "S-3", Name: "Marta", Email: "not-an-email"},
+	)
+
+	assert.Equal(t, sync_directory_users_use_case.ItemUpdated, result.Items[0].Status, "fields never synced take the directory value")
+	assert.Equal(t, directorySyncErrors.DuplicateDirectoryUserError.String(), codeOf(result.Items[1].Err))
+	assert.Equal(t, sync_directory_users_use_case.ItemUnmatched, result.Items[2].Status)
+	assert.Equal(t, sync_directory_users_use_case.ItemFailed, result.Items[3].Status)
+	assert.Equal(t, 2, result.Failed)
+	assert.Equal(t, "Ana Lopez", directory.users[0].Name)
+}
@@ -0,0 +1,202 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	authenticate_district_key_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/authenticate-district-key-use-case"
+	create_district_key_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/create-district-key-use-case"
+	create_district_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/create-district-use-case"
+	get_district_metrics_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/get-district-metrics-use-case"
+	revoke_district_key_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/revoke-district-key-use-case"
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	districtErrors "github.com/nahualventure/class-backend/core/app/district/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryDistricts struct {
+	districts []*entities.District
+	keys      []*entities.DistrictKey
+}
+
+func (m *memoryDistricts) Save(_ context.Context, district *entities.District) error {
+	for i, saved := range m.districts {
+		if saved.ID == district.ID {
+			m.districts[i] = district
+			return nil
+		}
+	}
+	m.districts = append(m.districts, district)
+	return nil
+}
+
+func (m *memoryDistricts) FindByID(_ context.Context, districtID string) (*entities.District, error) {
+	for _, district := range m.districts {
+		if district.ID == districtID {
+			return district, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryDistricts) List(_ context.Context) ([]*entities.District, error) {
+	return m.districts, nil
+}
+
+func (m *memoryDistricts) TenantsInOtherDistricts(_ context.Context, districtID string, tenantIDs []string) ([]string, error) {
+	var claimed []string
+	for _, district := range m.districts {
+		if district.ID == districtID {
+			continue
+		}
+		for _, tenantID := range tenantIDs {
+			if slices.Contains(district.TenantIDs, tenantID) {
+				claimed = append(claimed, tenantID)
+			}
+		}
+	}
+	return claimed, nil
+}
+
+func (m *memoryDistricts) CreateKey(_ context.Context, key *entities.DistrictKey) error {
+	m.keys = append(m.keys, key)
+	return nil
+}
+
+func (m *memoryDistricts) FindKey(_ context.Context, districtID string, keyID string) (*entities.DistrictKey, error) {
+	for _, key := range m.keys {
+		if key.DistrictID == districtID && key.ID == keyID {
+			return key, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryDistricts) FindKeyByHash(_ context.Context, hash string) (*entities.DistrictKey, error) {
+	for _, key := range m.keys {
+		if key.Hash == hash {
+			return key, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryDistricts) RevokeKey(_ context.Context, _ *entities.DistrictKey) error {
+	return nil
+}
+
+type memoryCounts []entities.SchoolCounts
+
+func (m memoryCounts) SchoolCounts(_ context.Context, tenantIDs []string, _ time.Time, _ time.Time) ([]entities.SchoolCounts, error) {
+	var counts []entities.SchoolCounts
+	for _, count := range m {
+		if slices.Contains(tenantIDs, count.TenantID) {
+			counts = append(counts, count)
+		}
+	}
+	return counts, nil
+}
+
+func newDistrict(t *testing.T, districts *memoryDistricts, tenantIDs ...string) *entities.District {
+	command, err := create_district_use_case.NewCreateDistrictCommand("North district", tenantIDs, 0)
+	assert.NoError(t, err)
+	district, err := create_district_use_case.NewCreateDistrictUseCase(districts).Execute(context.Background(), command)
+	assert.NoError(t, err)
+	return district
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, code, appErr.GetCode())
+	}
+}
+
+func TestGetDistrictMetricsUseCase_Execute_SuppressesSmallCohorts(t *testing.T) {
+	districts := &memoryDistricts{}
+	district := newDistrict(t, districts, "north-high", "north-middle", "north-elementary", "north-annex")
+	source := memoryCounts{
+		{TenantID: "north-high", Students: 400, Enrollments: 1800, ExpectedAttendances: 1000, Attendances: 910},
+		{TenantID: "north-middle", Students: 10, Enrollments: 40, ExpectedAttendances: 0},
+		{TenantID: "north-elementary", Students: 9, Enrollments: 27, ExpectedAttendances: 100, Attendances: 50},
+		{TenantID: "south-high", Students: 500, Enrollments: 2000},
+	}
+	useCase := get_district_metrics_use_case.NewGetDistrictMetricsUseCase(districts, source)
+
+	command, err := get_district_metrics_use_case.NewGetDistrictMetricsCommand(district.ID, time.Time{}, time.Time{},
+		time.Date(2025, 11, 30, 18, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	metrics, err := useCase.Execute(context.Background(), command)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), metrics.From)
+	assert.Equal(t, entities.DefaultMinCohortSize, metrics.MinCohortSize)
+	if assert.Len(t, metrics.Schools, 4) {
+		// Schools are ordered by tenant, the annex has no enrollment at all
+		assert.Equal(t, entities.SchoolMetrics{TenantID: "north-annex", Suppressed: true}, metrics.Schools[0])
+		assert.Equal(t, entities.SchoolMetrics{TenantID: "north-elementary", Suppressed: true}, metrics.Schools[1])
+		assert.Equal(t, 910.0/1000, *metrics.Schools[2].AttendanceRate)
+		assert.Equal(t, 10, *metrics.Schools[3].Students)
+		assert.Nil(t, metrics.Schools[3].AttendanceRate)
+	}
+	assert.Equal(t, 410, metrics.Students)
+	assert.Equal(t, 1840, metrics.Enrollments)
+	assert.Equal(t, 0.91, *metrics.AttendanceRate)
+	assert.Equal(t, 2, metrics.SuppressedSchools)
+}
+
+func TestGetDistrictMetricsCommand_RejectsLongPeriods(t *testing.T) {
+	from := time.Date(2024, 11, 30, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC)
+
+	_, err := get_district_metrics_use_case.NewGetDistrictMetricsCommand("2f1c6a8e-3b7d-4e9a-8c5f-1d2e3f4a5b6c", from, to, to)
+	assert.NoError(t, err)
+
+	_, err = get_district_metrics_use_case.NewGetDistrictMetricsCommand("2f1c6a8e-3b7d-4e9a-8c5f-1d2e3f4a5b6c", from.AddDate(0, 0, -1), to, to)
+	assertCode(t, err, appErrors.ValidationError.String())
+}
+
+func TestCreateDistrictUseCase_Execute_RejectsTenantOfOtherDistrict(t *testing.T) {
+	districts := &memoryDistricts{}
+	newDistrict(t, districts, "north-high", "north-middle")
+
+	command, err := create_district_use_case.NewCreateDistrictCommand("South district", []string{"south-high", "north-middle"}, 20)
+	assert.NoError(t, err)
+
+	_, err = create_district_use_case.NewCreateDistrictUseCase(districts).Execute(context.Background(), command)
+
+	assertCode(t, err, districtErrors.TenantInOtherDistrictError.String())
+	assert.Len(t, districts.districts, 1)
+}
+
+func TestAuthenticateDistrictKeyUseCase_Execute_RevokedKey(t *testing.T) {
+	districts := &memoryDistricts{}
+	district := newDistrict(t, districts, "north-high")
+
+	createCommand, err := create_district_key_use_case.NewCreateDistrictKeyCommand(district.ID, "State dashboard")
+	assert.NoError(t, err)
+	key, token, err := create_district_key_use_case.NewCreateDistrictKeyUseCase(districts).Execute(context.Background(), createCommand)
+	assert.NoError(t, err)
+	assert.NotContains(t, key.Hash, token)
+
+	authenticate := authenticate_district_key_use_case.NewAuthenticateDistrictKeyUseCase(districts)
+	command, err := authenticate_district_key_use_case.NewAuthenticateDistrictKeyCommand(token)
+	assert.NoError(t, err)
+	authenticated, err := authenticate.Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.Equal(t, district.ID, authenticated.DistrictID)
+
+	revokeCommand, err := revoke_district_key_use_case.NewRevokeDistrictKeyCommand(district.ID, key.ID)
+	assert.NoError(t, err)
+	_, err = revoke_district_key_use_case.NewRevokeDistrictKeyUseCase(districts).Execute(context.Background(), revokeCommand)
+	assert.NoError(t, err)
+
+	_, err = authenticate.Execute(context.Background(), command)
+	assertCode(t, err, districtErrors.InvalidDistrictKeyError.String())
+}
@@ -0,0 +1,163 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+
+	preview_email_template_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/preview-email-template-use-case"
+	save_email_template_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/save-email-template-use-case"
+	send_test_email_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/send-test-email-use-case"
+	set_email_branding_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/set-email-branding-use-case"
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	emailTemplateErrors "github.com/nahualventure/class-backend/core/app/emailtemplate/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantID = "tenant1"
+	adminID  = "admin-1"
+)
+
+// memoryEmails keeps the templates and branding of the tenant, the addresses of the users and the
+// test emails queued
+type memoryEmails struct {
+	templates map[entities.EmailFlow]*entities.EmailTemplate
+	branding  *entities.EmailBranding
+	addresses map[string]string
+	queued    []*entities.TestEmail
+}
+
+func newMemoryEmails() *memoryEmails {
+	return &memoryEmails{
+		templates: map[entities.EmailFlow]*entities.EmailTemplate{},
+		addresses: map[string]string{adminID: "admin@school.edu"},
+	}
+}
+
+func (m *memoryEmails) List(_ context.Context, _ string) ([]*entities.EmailTemplate, error) {
+	var templates []*entities.EmailTemplate
+	for _, template := range m.templates {
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func (m *memoryEmails) Find(_ context.Context, _ string, flow entities.EmailFlow) (*entities.EmailTemplate, error) {
+	return m.templates[flow], nil
+}
+
+func (m *memoryEmails) Save(_ context.Context, template *entities.EmailTemplate) error {
+	m.templates[template.Flow] = template
+	return nil
+}
+
+func (m *memoryEmails) EmailOf(_ context.Context, userID string) (string, error) {
+	return m.addresses[userID], nil
+}
+
+func (m *memoryEmails) Queue(_ context.Context, email *entities.TestEmail) error {
+	m.queued = append(m.queued, email)
+	return nil
+}
+
+// memoryBranding is separate from memoryEmails, both repositories have a Find and a Save
+type memoryBranding struct {
+	branding *entities.EmailBranding
+}
+
+func (m *memoryBranding) Find(_ context.Context, _ string) (*entities.EmailBranding, error) {
+	return m.branding, nil
+}
+
+func (m *memoryBranding) Save(_ context.Context, branding *entities.EmailBranding) error {
+	m.branding = branding
+	return nil
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func save(emails *memoryEmails, flow entities.EmailFlow, subject string, html string) error {
+	cmd, err := save_email_template_use_case.NewSaveEmailTemplateCommand(tenantID, string(flow), subject, html, "Hi {{.recipient_name}}", true, adminID)
+	if err != nil {
+		return err
+	}
+	_, err = save_email_template_use_case.NewSaveEmailTemplateUseCase(emails).Execute(context.Background(), cmd)
+	return err
+}
+
+func TestSaveEmailTemplate_RefusesTemplatesThatDoNotRender(t *testing.T) {
+	emails := newMemoryEmails()
+
+	tests := map[string]string{
+		"unknown variable":  "<p>{{.reset_url}}</p>",
+		"unclosed action":   "<p>{{.recipient_name</p>",
+		"unterminated else": "<p>{{if .role}}{{.role}}</p>",
+	}
+	for name, html := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := save(emails, entities.EmailFlowInvitation, "Welcome", html)
+			assert.Equal(t, emailTemplateErrors.InvalidEmailTemplateError.String(), codeOf(err))
+		})
+	}
+	assert.Empty(t, emails.templates)
+
+	assert.NoError(t, save(emails, entities.EmailFlowInvitation, "Join {{.sender_name}}", "<p>{{.inviter_name}} invited you</p>"))
+	assert.True(t, emails.templates[entities.EmailFlowInvitation].Enabled)
+}
+
+func TestPreviewEmailTemplate_RendersDraftsWithTheBranding(t *testing.T) {
+	emails := newMemoryEmails()
+	branding := &memoryBranding{}
+	brandingCmd, err := set_email_branding_use_case.NewSetEmailBrandingCommand(tenantID, "Colegio Central", "https://cdn.example.com/logo.png", "#004d40", "Av. Reforma 1", adminID)
+	assert.NoError(t, err)
+	_, err = set_email_branding_use_case.NewSetEmailBrandingUseCase(branding).Execute(context.Background(), brandingCmd)
+	assert.NoError(t, err)
+
+	preview := preview_email_template_use_case.NewPreviewEmailTemplateUseCase(emails, branding)
+	cmd, err := preview_email_template_use_case.NewPreviewEmailTemplateCommand(tenantID, string(entities.EmailFlowPasswordReset), "", "", "")
+	assert.NoError(t, err)
+	rendered, err := preview.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Reset your Colegio Central password", rendered.Subject, "flows not customized preview their default")
+	assert.Contains(t, rendered.HTML, `<img src="https://cdn.example.com/logo.png"`)
+	assert.Contains(t, rendered.HTML, "Av. Reforma 1")
+	assert.Contains(t, rendered.Text, "https://class.example.com/password/reset/sample")
+
+	cmd, err = preview_email_template_use_case.NewPreviewEmailTemplateCommand(tenantID, string(entities.EmailFlowPasswordReset),
+		"", "<p>{{.recipient_name}} <script>{{.reset_url}}</script></p>", "")
+	assert.NoError(t, err)
+	rendered, err = preview.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Reset your Colegio Central password", rendered.Subject, "parts left out of the draft keep the saved ones")
+	assert.Contains(t, rendered.HTML, `<script>"https://class.example.com/password/reset/sample"</script>`, "values are escaped for their context")
+	assert.Empty(t, emails.templates, "previews save nothing")
+}
+
+func TestSendTestEmail_QueuesToTheCaller(t *testing.T) {
+	emails := newMemoryEmails()
+	assert.NoError(t, save(emails, entities.EmailFlowEnrollment, "{{.class_name}}", "<p>{{.class_name}}</p>"))
+	send := send_test_email_use_case.NewSendTestEmailUseCase(emails, &memoryBranding{}, emails, emails)
+
+	cmd, err := send_test_email_use_case.NewSendTestEmailCommand(tenantID, string(entities.EmailFlowEnrollment), "", "", "", adminID)
+	assert.NoError(t, err)
+	email, err := send.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin@school.edu", email.To)
+	assert.Equal(t, "[Test] Algebra I", email.Email.Subject)
+	assert.Len(t, emails.queued, 1)
+
+	cmd, err = send_test_email_use_case.NewSendTestEmailCommand(tenantID, string(entities.EmailFlowEnrollment), "", "", "", "service-account-1")
+	assert.NoError(t, err)
+	_, err = send.Execute(context.Background(), cmd)
+	assert.Equal(t, emailTemplateErrors.TestRecipientNotFoundError.String(), codeOf(err))
+	assert.Len(t, emails.queued, 1)
+}
@@ -0,0 +1,174 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	confirm_enrollment_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/confirm-enrollment-use-case"
+	enroll_student_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/enroll-student-use-case"
+	reserve_seat_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/reserve-seat-use-case"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	enrollmentErrors "github.com/nahualventure/class-backend/core/app/enrollment/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/saga"
+	"github.com/nahualventure/class-backend/core/tests/clocktest"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type sagaStore struct {
+	instances map[string]saga.Instance
+}
+
+func (s *sagaStore) Save(_ context.Context, instance *saga.Instance, _ string, _ time.Time) (bool, error) {
+	s.instances[instance.ID] = *instance
+	return true, nil
+}
+
+func (s *sagaStore) ClaimUnfinished(context.Context, string, time.Time, time.Time) ([]*saga.Instance, error) {
+	return nil, nil
+}
+
+// memoryEnrollments reads the enrollments of the inventory, they were all made at enrolledAt
+type memoryEnrollments struct {
+	inventory  *memoryInventory
+	enrolledAt func() time.Time
+}
+
+func (e *memoryEnrollments) Find(_ context.Context, tenantID string, classID string, userID string) (*entities.Enrollment, error) {
+	e.inventory.mu.Lock()
+	defer e.inventory.mu.Unlock()
+	if !e.inventory.enrolled[userID] {
+		return nil, nil
+	}
+	return &entities.Enrollment{TenantID: tenantID, ClassID: classID, UserID: userID, EnrolledAt: e.enrolledAt()}, nil
+}
+
+func (e *memoryEnrollments) Remove(_ context.Context, _ string, _ string, userID string, _ time.Time) error {
+	e.inventory.mu.Lock()
+	defer e.inventory.mu.Unlock()
+	delete(e.inventory.enrolled, userID)
+	return nil
+}
+
+type memoryNotifications struct {
+	queued map[string]entities.EnrollmentNotificationKind
+}
+
+func (n *memoryNotifications) Queue(_ context.Context, notification *entities.EnrollmentNotification) error {
+	n.queued[notification.ID] = notification.Kind
+	return nil
+}
+
+type fakeSIS struct {
+	pushed []entities.Enrollment
+	err    error
+}
+
+func (s *fakeSIS) PushEnrollment(_ context.Context, enrollment *entities.Enrollment) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.pushed = append(s.pushed, *enrollment)
+	return nil
+}
+
+type enrollFixture struct {
+	inventory     *memoryInventory
+	store         *sagaStore
+	notifications *memoryNotifications
+	sis           *fakeSIS
+	useCase       *enroll_student_use_case.EnrollStudentUseCase
+	hold          *entities.SeatHold
+}
+
+func newEnrollFixture(t *testing.T) *enrollFixture {
+	t.Helper()
+	clk := clocktest.New(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC))
+	inventory := newMemoryInventory(1)
+	hold, err := reserve(t, reserve_seat_use_case.NewReserveSeatUseCase(inventory, noSchedules{}, entities.DefaultHoldTTL).WithClock(clk), "user-1")
+	assert.NoError(t, err)
+
+	f := &enrollFixture{
+		inventory:     inventory,
+		store:         &sagaStore{instances: make(map[string]saga.Instance)},
+		notifications: &memoryNotifications{queued: make(map[string]entities.EnrollmentNotificationKind)},
+		sis:           &fakeSIS{},
+		hold:          hold,
+	}
+	f.useCase = enroll_student_use_case.NewEnrollStudentUseCase(
+		saga.NewCoordinator(f.store),
+		confirm_enrollment_use_case.NewConfirmEnrollmentUseCase(inventory, noSchedules{}).WithClock(clk),
+		&memoryEnrollments{inventory: inventory, enrolledAt: clk.Now},
+		f.notifications,
+		f.sis,
+	).WithClock(clk)
+	return f
+}
+
+func (f *enrollFixture) enroll(t *testing.T) (*entities.Enrollment, error) {
+	t.Helper()
+	cmd, err := confirm_enrollment_use_case.NewConfirmEnrollmentCommand("tenant1", classID, f.hold.ID, "user-1", false)
+	assert.NoError(t, err)
+	return f.useCase.Execute(context.Background(), cmd)
+}
+
+func (f *enrollFixture) kinds() []entities.EnrollmentNotificationKind {
+	var kinds []entities.EnrollmentNotificationKind
+	for _, kind := range f.notifications.queued {
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+func TestEnrollStudent_EnrollsNotifiesAndSyncs(t *testing.T) {
+	f := newEnrollFixture(t)
+
+	enrollment, err := f.enroll(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", enrollment.UserID)
+	assert.True(t, f.inventory.enrolled["user-1"])
+	assert.Equal(t, []entities.EnrollmentNotificationKind{entities.EnrollmentNotificationEnrolled}, f.kinds())
+	assert.Len(t, f.sis.pushed, 1)
+	assert.Equal(t, enrollment.EnrolledAt, f.sis.pushed[0].EnrolledAt)
+}
+
+func TestEnrollStudent_SISFailureUndoesTheEnrollment(t *testing.T) {
+	f := newEnrollFixture(t)
+	f.sis.err = errors.New("sis unavailable")
+
+	_, err := f.enroll(t)
+
+	assert.Error(t, err)
+	assert.False(t, f.inventory.enrolled["user-1"])
+	assert.ElementsMatch(t, []entities.EnrollmentNotificationKind{
+		entities.EnrollmentNotificationEnrolled, entities.EnrollmentNotificationCanceled,
+	}, f.kinds())
+	for _, instance := range f.store.instances {
+		assert.Equal(t, saga.StatusCompensated, instance.Status)
+	}
+}
+
+func TestEnrollStudent_KeepsTheCheckoutError(t *testing.T) {
+	f := newEnrollFixture(t)
+	f.hold.ID = "2b1f0a6e-6f3c-4b0e-9a47-0c5e3f1d2a4b"
+
+	_, err := f.enroll(t)
+
+	assert.True(t, hasCode(err, enrollmentErrors.SeatHoldNotFoundError.String()))
+	assert.Empty(t, f.notifications.queued)
+	assert.Empty(t, f.sis.pushed)
+}
+
+func TestEnrollStudent_AlreadyEnrolled(t *testing.T) {
+	f := newEnrollFixture(t)
+	_, err := f.enroll(t)
+	assert.NoError(t, err)
+
+	_, err = f.enroll(t)
+
+	assert.True(t, hasCode(err, enrollmentErrors.AlreadyEnrolledError.String()))
+	assert.Len(t, f.store.instances, 1, "no saga is started for a student already enrolled")
+}
@@ -0,0 +1,293 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	confirm_enrollment_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/confirm-enrollment-use-case"
+	release_seat_hold_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/release-seat-hold-use-case"
+	reserve_seat_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/reserve-seat-use-case"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	enrollmentErrors "github.com/nahualventure/class-backend/core/app/enrollment/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/tests/clocktest"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const classID = "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f"
+
+// memoryInventory mimics the row lock of the Postgres adapter with a mutex, writes of a failed
+// callback are discarded by working on a copy
+type memoryInventory struct {
+	mu       sync.Mutex
+	capacity map[string]int
+	holds    map[string]entities.SeatHold
+	enrolled map[string]bool
+}
+
+func newMemoryInventory(capacity int) *memoryInventory {
+	return &memoryInventory{
+		capacity: map[string]int{classID: capacity},
+		holds:    make(map[string]entities.SeatHold),
+		enrolled: make(map[string]bool),
+	}
+}
+
+func (m *memoryInventory) SetCapacity(_ context.Context, _ string, classID string, capacity int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.capacity[classID] = capacity
+	return nil
+}
+
+func (m *memoryInventory) GetCapacity(_ context.Context, tenantID string, classID string, now time.Time) (*entities.ClassCapacity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.count(tenantID, classID, now), nil
+}
+
+func (m *memoryInventory) count(tenantID string, classID string, now time.Time) *entities.ClassCapacity {
+	capacity, ok := m.capacity[classID]
+	if !ok {
+		return nil
+	}
+	held := 0
+	for _, hold := range m.holds {
+		if !hold.IsExpired(now) {
+			held++
+		}
+	}
+	return &entities.ClassCapacity{TenantID: tenantID, ClassID: classID, Capacity: capacity, Enrolled: len(m.enrolled), Held: held}
+}
+
+func (m *memoryInventory) WithClassLocked(_ context.Context, tenantID string, classID string, now time.Time,
+	fn func(ledger ports.SeatLedger, capacity *entities.ClassCapacity) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ledger := &memoryLedger{holds: make(map[string]entities.SeatHold), enrolled: make(map[string]bool)}
+	for id, hold := range m.holds {
+		ledger.holds[id] = hold
+	}
+	for userID := range m.enrolled {
+		ledger.enrolled[userID] = true
+	}
+
+	if err := fn(ledger, m.count(tenantID, classID, now)); err != nil {
+		return err
+	}
+	m.holds, m.enrolled = ledger.holds, ledger.enrolled
+	return nil
+}
+
+func (m *memoryInventory) DeleteExpiredHolds(_ context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deleted int64
+	for id, hold := range m.holds {
+		if hold.IsExpired(now) {
+			delete(m.holds, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+type memoryLedger struct {
+	holds    map[string]entities.SeatHold
+	enrolled map[string]bool
+}
+
+func (l *memoryLedger) FindHold(_ context.Context, holdID string) (*entities.SeatHold, error) {
+	hold, ok := l.holds[holdID]
+	if !ok {
+		return nil, nil
+	}
+	return &hold, nil
+}
+
+func (l *memoryLedger) FindActiveHold(_ context.Context, userID string, now time.Time) (*entities.SeatHold, error) {
+	for _, hold := range l.holds {
+		if hold.UserID == userID && !hold.IsExpired(now) {
+			return &hold, nil
+		}
+	}
+	return nil, nil
+}
+
+func (l *memoryLedger) IsEnrolled(_ context.Context, userID string) (bool, error) {
+	return l.enrolled[userID], nil
+}
+
+func (l *memoryLedger) CreateHold(_ context.Context, hold *entities.SeatHold) error {
+	l.holds[hold.ID] = *hold
+	return nil
+}
+
+func (l *memoryLedger) DeleteHold(_ context.Context, holdID string) error {
+	delete(l.holds, holdID)
+	return nil
+}
+
+func (l *memoryLedger) CreateEnrollment(_ context.Context, enrollment *entities.Enrollment) error {
+	l.enrolled[enrollment.UserID] = true
+	return nil
+}
+
+type noSchedules struct{}
+
+func (noSchedules) ReplaceMeetings(context.Context, string, string, []entities.MeetingTime) error {
+	return nil
+}
+
+func (noSchedules) ListMeetings(context.Context, string, string) ([]entities.MeetingTime, error) {
+	return nil, nil
+}
+
+func (noSchedules) ListEnrolledMeetings(context.Context, string, string) (map[string][]entities.MeetingTime, error) {
+	return nil, nil
+}
+
+func reserve(t *testing.T, uc *reserve_seat_use_case.ReserveSeatUseCase, userID string) (*entities.SeatHold, error) {
+	t.Helper()
+	cmd, err := reserve_seat_use_case.NewReserveSeatCommand("tenant1", classID, userID, false)
+	assert.NoError(t, err)
+	return uc.Execute(context.Background(), cmd)
+}
+
+func hasCode(err error, code string) bool {
+	var appErr appErrors.ApplicationError
+	return errors.As(err, &appErr) && appErr.GetCode() == code
+}
+
+func TestReserveSeat_ConcurrentCheckoutsDoNotOversubscribe(t *testing.T) {
+	inventory := newMemoryInventory(3)
+	uc := reserve_seat_use_case.NewReserveSeatUseCase(inventory, noSchedules{}, entities.DefaultHoldTTL)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved, full := 0, 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(userID string) {
+			defer wg.Done()
+			_, err := reserve(t, uc, userID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				reserved++
+			} else if hasCode(err, enrollmentErrors.ClassFullError.String()) {
+				full++
+			}
+		}(fmt.Sprintf("user-%d", i))
+	}
+	wg.Wait()
+
+	assert.Equal(t, 3, reserved)
+	assert.Equal(t, 17, full)
+}
+
+func TestReserveSeat_RetryReturnsSameHold(t *testing.T) {
+	uc := reserve_seat_use_case.NewReserveSeatUseCase(newMemoryInventory(1), noSchedules{}, entities.DefaultHoldTTL)
+
+	first, err := reserve(t, uc, "user-1")
+	assert.NoError(t, err)
+	second, err := reserve(t, uc, "user-1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, first.ExpiresAt, second.ExpiresAt)
+}
+
+func TestReserveSeat_ExpiredHoldsFreeTheSeat(t *testing.T) {
+	inventory := newMemoryInventory(1)
+	expiring := reserve_seat_use_case.NewReserveSeatUseCase(inventory, noSchedules{}, -time.Second)
+	_, err := reserve(t, expiring, "user-1")
+	assert.NoError(t, err)
+
+	_, err = reserve(t, reserve_seat_use_case.NewReserveSeatUseCase(inventory, noSchedules{}, entities.DefaultHoldTTL), "user-2")
+	assert.NoError(t, err)
+
+	deleted, err := release_seat_hold_use_case.NewReleaseSeatHoldUseCase(inventory).PurgeExpired(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestSeatHolds_ExpireAfterTheirTTL(t *testing.T) {
+	clk := clocktest.New(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC))
+	inventory := newMemoryInventory(1)
+	reserveUseCase := reserve_seat_use_case.NewReserveSeatUseCase(inventory, noSchedules{}, entities.DefaultHoldTTL).WithClock(clk)
+	confirmUseCase := confirm_enrollment_use_case.NewConfirmEnrollmentUseCase(inventory, noSchedules{}).WithClock(clk)
+	releaseUseCase := release_seat_hold_use_case.NewReleaseSeatHoldUseCase(inventory).WithClock(clk)
+
+	hold, err := reserve(t, reserveUseCase, "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, clk.Now().Add(entities.DefaultHoldTTL), hold.ExpiresAt)
+
+	clk.Advance(entities.DefaultHoldTTL - time.Second)
+	_, err = reserve(t, reserveUseCase, "user-2")
+	assert.True(t, hasCode(err, enrollmentErrors.ClassFullError.String()), "the hold still counts a second before it expires")
+	deleted, err := releaseUseCase.PurgeExpired(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	clk.Advance(time.Second)
+	cmd, _ := confirm_enrollment_use_case.NewConfirmEnrollmentCommand("tenant1", classID, hold.ID, "user-1", false)
+	_, err = confirmUseCase.Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, enrollmentErrors.SeatHoldExpiredError.String()))
+	deleted, err = releaseUseCase.PurgeExpired(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestConfirmEnrollment_ConvertsHold(t *testing.T) {
+	inventory := newMemoryInventory(1)
+	hold, err := reserve(t, reserve_seat_use_case.NewReserveSeatUseCase(inventory, noSchedules{}, entities.DefaultHoldTTL), "user-1")
+	assert.NoError(t, err)
+
+	cmd, err := confirm_enrollment_use_case.NewConfirmEnrollmentCommand("tenant1", classID, hold.ID, "user-1", false)
+	assert.NoError(t, err)
+	enrollment, err := confirm_enrollment_use_case.NewConfirmEnrollmentUseCase(inventory, noSchedules{}).Execute(context.Background(), cmd)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", enrollment.UserID)
+	capacity, _ := inventory.GetCapacity(context.Background(), "tenant1", classID, time.Now())
+	assert.Equal(t, 1, capacity.Enrolled)
+	assert.Equal(t, 0, capacity.Held)
+
+	_, err = reserve(t, reserve_seat_use_case.NewReserveSeatUseCase(inventory, noSchedules{}, entities.DefaultHoldTTL), "user-1")
+	assert.True(t, hasCode(err, enrollmentErrors.AlreadyEnrolledError.String()))
+}
+
+func TestConfirmEnrollment_RejectsExpiredAndForeignHolds(t *testing.T) {
+	inventory := newMemoryInventory(2)
+	expired, _ := reserve(t, reserve_seat_use_case.NewReserveSeatUseCase(inventory, noSchedules{}, -time.Second), "user-1")
+	active, _ := reserve(t, reserve_seat_use_case.NewReserveSeatUseCase(inventory, noSchedules{}, entities.DefaultHoldTTL), "user-2")
+	uc := confirm_enrollment_use_case.NewConfirmEnrollmentUseCase(inventory, noSchedules{})
+
+	cmd, _ := confirm_enrollment_use_case.NewConfirmEnrollmentCommand("tenant1", classID, expired.ID, "user-1", false)
+	_, err := uc.Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, enrollmentErrors.SeatHoldExpiredError.String()))
+
+	cmd, _ = confirm_enrollment_use_case.NewConfirmEnrollmentCommand("tenant1", classID, active.ID, "user-1", false)
+	_, err = uc.Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, enrollmentErrors.SeatHoldNotFoundError.String()))
+}
+
+func TestReleaseSeatHold_GivesSeatBack(t *testing.T) {
+	inventory := newMemoryInventory(1)
+	reserveUseCase := reserve_seat_use_case.NewReserveSeatUseCase(inventory, noSchedules{}, entities.DefaultHoldTTL)
+	hold, _ := reserve(t, reserveUseCase, "user-1")
+
+	cmd, _ := release_seat_hold_use_case.NewReleaseSeatHoldCommand("tenant1", classID, hold.ID, "user-1")
+	assert.NoError(t, release_seat_hold_use_case.NewReleaseSeatHoldUseCase(inventory).Execute(context.Background(), cmd))
+
+	_, err := reserve(t, reserveUseCase, "user-2")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,120 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	reserve_seat_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/reserve-seat-use-case"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	enrollmentErrors "github.com/nahualventure/class-backend/core/app/enrollment/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const otherClassID = "0b1c2d3e-4f50-4a6b-8c7d-9e0f1a2b3c4d"
+
+type memorySchedules struct {
+	meetings map[string][]entities.MeetingTime
+	enrolled []string
+}
+
+func (s memorySchedules) ReplaceMeetings(_ context.Context, _ string, classID string, meetings []entities.MeetingTime) error {
+	s.meetings[classID] = meetings
+	return nil
+}
+
+func (s memorySchedules) ListMeetings(_ context.Context, _ string, classID string) ([]entities.MeetingTime, error) {
+	return s.meetings[classID], nil
+}
+
+func (s memorySchedules) ListEnrolledMeetings(context.Context, string, string) (map[string][]entities.MeetingTime, error) {
+	enrolled := make(map[string][]entities.MeetingTime)
+	for _, classID := range s.enrolled {
+		enrolled[classID] = s.meetings[classID]
+	}
+	return enrolled, nil
+}
+
+func meeting(t *testing.T, weekday string, startsAt string, endsAt string) entities.MeetingTime {
+	t.Helper()
+	m, err := entities.ParseMeetingTime(weekday, startsAt, endsAt)
+	assert.NoError(t, err)
+	return *m
+}
+
+func TestMeetingTime_Overlaps(t *testing.T) {
+	nine := meeting(t, "monday", "09:00", "10:30")
+
+	assert.True(t, nine.Overlaps(meeting(t, "monday", "10:00", "11:00")))
+	assert.True(t, nine.Overlaps(meeting(t, "monday", "09:15", "09:45")))
+	assert.False(t, nine.Overlaps(meeting(t, "monday", "10:30", "12:00")), "back to back meetings do not overlap")
+	assert.False(t, nine.Overlaps(meeting(t, "tuesday", "09:00", "10:30")))
+}
+
+func TestParseMeetingTime_RejectsInvalidRanges(t *testing.T) {
+	_, err := entities.ParseMeetingTime("monday", "10:00", "09:00")
+	assert.Error(t, err)
+
+	_, err = entities.ParseMeetingTime("someday", "09:00", "10:00")
+	assert.Error(t, err)
+}
+
+func TestReserveSeat_ReportsScheduleConflicts(t *testing.T) {
+	schedules := memorySchedules{
+		meetings: map[string][]entities.MeetingTime{
+			classID:      {meeting(t, "monday", "09:00", "10:30"), meeting(t, "wednesday", "09:00", "10:30")},
+			otherClassID: {meeting(t, "wednesday", "10:00", "11:00"), meeting(t, "friday", "09:00", "10:00")},
+		},
+		enrolled: []string{otherClassID},
+	}
+	uc := reserve_seat_use_case.NewReserveSeatUseCase(newMemoryInventory(5), schedules, entities.DefaultHoldTTL)
+
+	cmd, _ := reserve_seat_use_case.NewReserveSeatCommand("tenant1", classID, "user-1", false)
+	_, err := uc.Execute(context.Background(), cmd)
+
+	var appErr appErrors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, enrollmentErrors.ScheduleConflictError.String(), appErr.GetCode())
+	conflicts := appErr.GetContext()["conflicts"].([]map[string]any)
+	assert.Len(t, conflicts, 1)
+	assert.Equal(t, otherClassID, conflicts[0]["class_id"])
+	assert.Equal(t, "wednesday", conflicts[0]["weekday"])
+	assert.Equal(t, "10:00", conflicts[0]["starts_at"])
+}
+
+func TestReserveSeat_OverrideSkipsScheduleConflicts(t *testing.T) {
+	schedules := memorySchedules{
+		meetings: map[string][]entities.MeetingTime{
+			classID:      {meeting(t, "monday", "09:00", "10:30")},
+			otherClassID: {meeting(t, "monday", "09:00", "10:30")},
+		},
+		enrolled: []string{otherClassID},
+	}
+	uc := reserve_seat_use_case.NewReserveSeatUseCase(newMemoryInventory(5), schedules, entities.DefaultHoldTTL)
+
+	cmd, _ := reserve_seat_use_case.NewReserveSeatCommand("tenant1", classID, "user-1", true)
+	hold, err := uc.Execute(context.Background(), cmd)
+
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(entities.DefaultHoldTTL), hold.ExpiresAt, time.Second)
+}
+
+func TestFindScheduleConflicts_OrdersEveryConflict(t *testing.T) {
+	monday := meeting(t, "monday", "09:00", "12:00")
+	existing := map[string][]entities.MeetingTime{
+		"class-b": {meeting(t, "monday", "11:00", "12:00"), meeting(t, "monday", "09:00", "10:00")},
+		"class-a": {meeting(t, "monday", "10:00", "11:00")},
+	}
+
+	for range 20 {
+		conflicts := entities.FindScheduleConflicts([]entities.MeetingTime{monday}, existing)
+		assert.Equal(t, []entities.ScheduleConflict{
+			{ClassID: "class-a", Meeting: monday, ConflictingMeeting: meeting(t, "monday", "10:00", "11:00")},
+			{ClassID: "class-b", Meeting: monday, ConflictingMeeting: meeting(t, "monday", "09:00", "10:00")},
+			{ClassID: "class-b", Meeting: monday, ConflictingMeeting: meeting(t, "monday", "11:00", "12:00")},
+		}, conflicts)
+	}
+}
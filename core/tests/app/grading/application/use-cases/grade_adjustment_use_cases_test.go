@@ -0,0 +1,129 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+
+	apply_grade_adjustment_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/apply-grade-adjustment-use-case"
+	preview_grade_adjustment_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/preview-grade-adjustment-use-case"
+	record_grade_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/record-grade-use-case"
+	undo_grade_adjustment_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/undo-grade-adjustment-use-case"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const otherAssignmentID = "0b1c2d3e-4f50-4a6b-8c7d-9e0f1a2b3c4d"
+
+func applyAdjustment(repo *memoryGrades, kind entities.AdjustmentKind, assignment string, points float64, count int) (*entities.GradeAdjustment, error) {
+	cmd, err := apply_grade_adjustment_use_case.NewApplyGradeAdjustmentCommand("tenant1", classID, assignment, kind, points, 0, count, nil, "teacher-1", "")
+	if err != nil {
+		return nil, err
+	}
+	return apply_grade_adjustment_use_case.NewApplyGradeAdjustmentUseCase(repo).Execute(context.Background(), cmd)
+}
+
+func undoAdjustment(repo *memoryGrades, adjustmentID string) ([]*entities.Grade, error) {
+	cmd, err := undo_grade_adjustment_use_case.NewUndoGradeAdjustmentCommand("tenant1", classID, adjustmentID, "teacher-1")
+	if err != nil {
+		return nil, err
+	}
+	return undo_grade_adjustment_use_case.NewUndoGradeAdjustmentUseCase(repo).Execute(context.Background(), cmd)
+}
+
+func TestPreviewGradeAdjustment_ChangesNothing(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	low := record(t, repo, "student-1", 60)
+	high := record(t, repo, "student-2", 95)
+
+	cmd, err := preview_grade_adjustment_use_case.NewPreviewGradeAdjustmentCommand("tenant1", classID, assignmentID,
+		entities.AdjustmentCurvePoints, 10, 0, 0, nil)
+	assert.NoError(t, err)
+	changes, err := preview_grade_adjustment_use_case.NewPreviewGradeAdjustmentUseCase(repo).Execute(context.Background(), cmd)
+
+	assert.NoError(t, err)
+	assert.Len(t, changes, 2)
+	for _, change := range changes {
+		if change.Grade.ID == low.ID {
+			assert.Equal(t, 70.0, change.Score)
+		} else {
+			assert.Equal(t, 100.0, change.Score, "scores are capped at 100")
+		}
+	}
+	assert.Equal(t, 60.0, repo.grades[low.ID].Score)
+	assert.Equal(t, 95.0, repo.grades[high.ID].Score)
+}
+
+func TestApplyGradeAdjustment_SkipsGradesPastDraft(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	draft := record(t, repo, "student-1", 60)
+	submitted := record(t, repo, "student-2", 60)
+	_, err := change(repo, &memoryPolicies{}, entities.GradeActionSubmit, "", submitted.ID)
+	assert.NoError(t, err)
+
+	adjustment, err := applyAdjustment(repo, entities.AdjustmentCurvePoints, assignmentID, 5, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, adjustment.Changes, 2)
+	assert.Equal(t, 65.0, repo.grades[draft.ID].Score)
+	assert.Equal(t, 60.0, repo.grades[submitted.ID].Score)
+	last := repo.events[len(repo.events)-1]
+	assert.Equal(t, entities.GradeEventCurved, last.Type)
+	assert.Equal(t, adjustment.ID, last.AdjustmentID)
+}
+
+func TestApplyGradeAdjustment_DropLowestKeepsOneGrade(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	lowest := record(t, repo, "student-1", 40)
+	cmd, err := record_grade_use_case.NewRecordGradeCommand("tenant1", classID, otherAssignmentID, "student-1", 90, nil, "teacher-1", "")
+	assert.NoError(t, err)
+	kept, err := record_grade_use_case.NewRecordGradeUseCase(repo).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	only := record(t, repo, "student-2", 30)
+
+	adjustment, err := applyAdjustment(repo, entities.AdjustmentDropLowest, "", 0, 3)
+
+	assert.NoError(t, err)
+	assert.Len(t, adjustment.Changes, 1)
+	assert.True(t, repo.grades[lowest.ID].Dropped)
+	assert.False(t, repo.grades[kept.ID].Dropped)
+	assert.False(t, repo.grades[only.ID].Dropped, "the only grade of a student is kept")
+}
+
+func TestUndoGradeAdjustment_RestoresScoresAndDrops(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	a := record(t, repo, "student-1", 60)
+	b := record(t, repo, "student-2", 80)
+	curve, err := applyAdjustment(repo, entities.AdjustmentCurvePoints, assignmentID, 10, 0)
+	assert.NoError(t, err)
+
+	undone, err := undoAdjustment(repo, curve.ID)
+
+	assert.NoError(t, err)
+	assert.Len(t, undone, 2)
+	assert.Equal(t, 60.0, repo.grades[a.ID].Score)
+	assert.Equal(t, 80.0, repo.grades[b.ID].Score)
+	assert.Equal(t, entities.GradeEventAdjustmentUndone, repo.events[len(repo.events)-1].Type)
+
+	_, err = undoAdjustment(repo, curve.ID)
+	assert.Equal(t, gradingErrors.GradeAdjustmentUndoneError.String(), codeOf(err))
+
+	_, err = undoAdjustment(repo, "9c1d2e3f-4a5b-4c6d-8e7f-0a1b2c3d4e5f")
+	assert.Equal(t, gradingErrors.GradeAdjustmentNotFoundError.String(), codeOf(err))
+}
+
+func TestUndoGradeAdjustment_IsAllOrNothing(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	a := record(t, repo, "student-1", 60)
+	b := record(t, repo, "student-2", 80)
+	curve, err := applyAdjustment(repo, entities.AdjustmentCurvePoints, assignmentID, 10, 0)
+	assert.NoError(t, err)
+	record(t, repo, "student-2", 75)
+
+	_, err = undoAdjustment(repo, curve.ID)
+
+	assert.Equal(t, gradingErrors.GradeAdjustmentNotUndoableError.String(), codeOf(err))
+	assert.Equal(t, 70.0, repo.grades[a.ID].Score, "no grade changes when one is rejected")
+	assert.Equal(t, 75.0, repo.grades[b.ID].Score)
+}
@@ -0,0 +1,409 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	calendarEntities "github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	change_grade_status_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/change-grade-status-use-case"
+	get_grade_history_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/get-grade-history-use-case"
+	grant_extension_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/grant-extension-use-case"
+	list_class_grades_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/list-class-grades-use-case"
+	list_student_grades_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/list-student-grades-use-case"
+	record_grade_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/record-grade-use-case"
+	set_assignment_deadline_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/set-assignment-deadline-use-case"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	classID      = "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f"
+	assignmentID = "5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e"
+)
+
+type memoryGrades struct {
+	grades map[string]entities.Grade
+	events []*entities.GradeEvent
+}
+
+func (m *memoryGrades) Save(_ context.Context, grade *entities.Grade) error {
+	m.events = append(m.events, grade.PendingEvents()...)
+	grade.ClearPendingEvents()
+	m.grades[grade.ID] = *grade
+	return nil
+}
+
+func (m *memoryGrades) FindByStudent(_ context.Context, _ string, classID string, assignmentID string, studentID string) (*entities.Grade, error) {
+	for _, grade := range m.grades {
+		if grade.ClassID == classID && grade.AssignmentID == assignmentID && grade.StudentID == studentID {
+			return &grade, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryGrades) WithGradesLocked(_ context.Context, _ string, classID string, gradeIDs []string, fn func(grades []*entities.Grade) error) error {
+	var grades []*entities.Grade
+	for _, id := range gradeIDs {
+		if grade, ok := m.grades[id]; ok && grade.ClassID == classID {
+			grades = append(grades, &grade)
+		}
+	}
+	if err := fn(grades); err != nil {
+		return err
+	}
+	for _, grade := range grades {
+		m.events = append(m.events, grade.PendingEvents()...)
+		grade.ClearPendingEvents()
+		m.grades[grade.ID] = *grade
+	}
+	return nil
+}
+
+func (m *memoryGrades) ListByClass(_ context.Context, _ string, classID string, _ string) ([]*entities.Grade, error) {
+	var grades []*entities.Grade
+	for _, grade := range m.grades {
+		if grade.ClassID == classID {
+			grades = append(grades, &grade)
+		}
+	}
+	return grades, nil
+}
+
+// ListReleasedForStudent ignores the status on purpose, the use case has to filter on its own
+func (m *memoryGrades) ListReleasedForStudent(_ context.Context, _ string, studentID string, _ string) ([]*entities.Grade, error) {
+	var grades []*entities.Grade
+	for _, grade := range m.grades {
+		if grade.StudentID == studentID {
+			grades = append(grades, &grade)
+		}
+	}
+	return grades, nil
+}
+
+func (m *memoryGrades) ListEvents(_ context.Context, _ string, classID string, gradeID string) ([]*entities.GradeEvent, error) {
+	var events []*entities.GradeEvent
+	for _, event := range m.events {
+		if event.ClassID == classID && event.GradeID == gradeID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (m *memoryGrades) ListAdjustmentEvents(_ context.Context, _ string, classID string, adjustmentID string) ([]*entities.GradeEvent, error) {
+	var events []*entities.GradeEvent
+	for _, event := range m.events {
+		if event.ClassID == classID && event.AdjustmentID == adjustmentID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+type memoryPolicies struct {
+	requiresApproval bool
+}
+
+func (m *memoryPolicies) Get(_ context.Context, tenantID string, classID string) (*entities.GradingPolicy, error) {
+	return &entities.GradingPolicy{TenantID: tenantID, ClassID: classID, RequiresApproval: m.requiresApproval}, nil
+}
+
+func (m *memoryPolicies) Set(_ context.Context, policy *entities.GradingPolicy) error {
+	m.requiresApproval = policy.RequiresApproval
+	return nil
+}
+
+type memoryDeadlines struct {
+	deadlines  []*entities.AssignmentDeadline
+	extensions []*entities.Extension
+}
+
+func (m *memoryDeadlines) SetDeadline(_ context.Context, deadline *entities.AssignmentDeadline) error {
+	m.deadlines = append(m.deadlines, deadline)
+	return nil
+}
+
+func (m *memoryDeadlines) GetDeadline(_ context.Context, _ string, _ string, assignmentID string) (*entities.AssignmentDeadline, error) {
+	for _, deadline := range m.deadlines {
+		if deadline.AssignmentID == assignmentID {
+			return deadline, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryDeadlines) ListDeadlines(context.Context, string, string) ([]*entities.AssignmentDeadline, error) {
+	return m.deadlines, nil
+}
+
+func (m *memoryDeadlines) SetExtension(_ context.Context, extension *entities.Extension) error {
+	m.extensions = append(m.extensions, extension)
+	return nil
+}
+
+func (m *memoryDeadlines) ListExtensions(context.Context, string, string) ([]*entities.Extension, error) {
+	return m.extensions, nil
+}
+
+var dueAt = time.Date(2025, 9, 22, 23, 59, 0, 0, time.UTC)
+
+type memoryCalendar struct {
+	days []*calendarEntities.NonInstructionalDay
+}
+
+func (m *memoryCalendar) SchoolCalendar(_ context.Context, _ string, _ time.Time, _ time.Time) (*calendarEntities.SchoolCalendar, error) {
+	return calendarEntities.NewSchoolCalendar(m.days, time.UTC), nil
+}
+
+func record(t *testing.T, repo *memoryGrades, studentID string, score float64) *entities.Grade {
+	t.Helper()
+	return recordTurnedIn(t, repo, studentID, score, nil)
+}
+
+func recordTurnedIn(t *testing.T, repo *memoryGrades, studentID string, score float64, turnedInAt *time.Time) *entities.Grade {
+	t.Helper()
+	cmd, err := record_grade_use_case.NewRecordGradeCommand("tenant1", classID, assignmentID, studentID, score, turnedInAt, "teacher-1", "")
+	assert.NoError(t, err)
+	grade, err := record_grade_use_case.NewRecordGradeUseCase(repo).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return grade
+}
+
+func change(repo *memoryGrades, policies *memoryPolicies, action entities.GradeAction, reason string, ids ...string) ([]*entities.Grade, error) {
+	return changeWithDeadlines(repo, policies, &memoryDeadlines{}, action, reason, ids...)
+}
+
+func changeWithDeadlines(repo *memoryGrades, policies *memoryPolicies, deadlines *memoryDeadlines, action entities.GradeAction,
+	reason string, ids ...string) ([]*entities.Grade, error) {
+	cmd, err := change_grade_status_use_case.NewChangeGradeStatusCommand("tenant1", classID, action, ids, "actor-1", reason)
+	if err != nil {
+		return nil, err
+	}
+	return change_grade_status_use_case.NewChangeGradeStatusUseCase(repo, policies, deadlines, &memoryCalendar{}).Execute(context.Background(), cmd)
+}
+
+func setDeadline(t *testing.T, deadlines *memoryDeadlines, penaltyPerDay float64, cutoffDays int) {
+	t.Helper()
+	cmd, err := set_assignment_deadline_use_case.NewSetAssignmentDeadlineCommand("tenant1", classID, assignmentID, dueAt, penaltyPerDay, cutoffDays)
+	assert.NoError(t, err)
+	_, err = set_assignment_deadline_use_case.NewSetAssignmentDeadlineUseCase(deadlines).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+}
+
+func grantExtension(deadlines *memoryDeadlines, studentID string, extendedDueAt time.Time) error {
+	cmd, err := grant_extension_use_case.NewGrantExtensionCommand("tenant1", classID, assignmentID, studentID, extendedDueAt, "medical leave", "teacher-1")
+	if err != nil {
+		return err
+	}
+	_, err = grant_extension_use_case.NewGrantExtensionUseCase(deadlines).Execute(context.Background(), cmd)
+	return err
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func TestRecordGrade_UpdatesDraftInPlace(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	first := record(t, repo, "student-1", 70)
+	second := record(t, repo, "student-1", 85)
+
+	assert.Equal(t, first.ID, second.ID)
+	assert.Len(t, repo.grades, 1)
+	assert.Equal(t, 85.0, repo.grades[first.ID].Score)
+}
+
+func TestGetGradeHistory_ListsEveryChange(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	policies := &memoryPolicies{}
+	grade := record(t, repo, "student-1", 70)
+	_, err := change(repo, policies, entities.GradeActionSubmit, "", grade.ID)
+	assert.NoError(t, err)
+	_, err = change(repo, policies, entities.GradeActionReturn, "rubric not applied", grade.ID)
+	assert.NoError(t, err)
+	cmd, err := record_grade_use_case.NewRecordGradeCommand("tenant1", classID, assignmentID, "student-1", 75, nil, "teacher-1", "rubric applied")
+	assert.NoError(t, err)
+	_, err = record_grade_use_case.NewRecordGradeUseCase(repo).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+
+	history := get_grade_history_use_case.NewGetGradeHistoryUseCase(repo)
+	query, err := get_grade_history_use_case.NewGetGradeHistoryCommand("tenant1", classID, grade.ID)
+	assert.NoError(t, err)
+	events, err := history.Execute(context.Background(), query)
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 4)
+	assert.Equal(t, entities.GradeEventReturned, events[2].Type)
+	assert.Equal(t, "actor-1", events[2].ActorID)
+	assert.Equal(t, "rubric not applied", events[2].Reason)
+	assert.Equal(t, entities.GradeEventScoreChanged, events[3].Type)
+	assert.Equal(t, 70.0, *events[3].OldScore)
+	assert.Equal(t, 75.0, events[3].NewScore)
+	assert.Equal(t, "rubric applied", events[3].Reason)
+	assert.Equal(t, 4, repo.grades[grade.ID].Version)
+
+	query, err = get_grade_history_use_case.NewGetGradeHistoryCommand("tenant1", classID, "9c1d2e3f-4a5b-4c6d-8e7f-0a1b2c3d4e5f")
+	assert.NoError(t, err)
+	_, err = history.Execute(context.Background(), query)
+	assert.Equal(t, gradingErrors.GradeNotFoundError.String(), codeOf(err))
+}
+
+func TestChangeGradeStatus_BulkIsAllOrNothing(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	policies := &memoryPolicies{}
+	a := record(t, repo, "student-1", 70)
+	b := record(t, repo, "student-2", 90)
+	_, err := change(repo, policies, entities.GradeActionSubmit, "", a.ID)
+	assert.NoError(t, err)
+
+	_, err = change(repo, policies, entities.GradeActionRelease, "", a.ID, b.ID)
+
+	assert.Equal(t, gradingErrors.InvalidGradeTransitionError.String(), codeOf(err))
+	assert.Equal(t, entities.GradeStatusSubmitted, repo.grades[a.ID].Status, "no grade changes when one is rejected")
+}
+
+func TestChangeGradeStatus_ApprovalGatesRelease(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	policies := &memoryPolicies{requiresApproval: true}
+	grade := record(t, repo, "student-1", 70)
+
+	_, err := change(repo, policies, entities.GradeActionSubmit, "", grade.ID)
+	assert.NoError(t, err)
+	_, err = change(repo, policies, entities.GradeActionRelease, "", grade.ID)
+	assert.Equal(t, gradingErrors.InvalidGradeTransitionError.String(), codeOf(err))
+
+	_, err = change(repo, policies, entities.GradeActionApprove, "", grade.ID)
+	assert.NoError(t, err)
+	released, err := change(repo, policies, entities.GradeActionRelease, "", grade.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.GradeStatusReleased, released[0].Status)
+}
+
+func TestChangeGradeStatus_ReportsMissingGrades(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+
+	_, err := change(repo, &memoryPolicies{}, entities.GradeActionSubmit, "", "0b1c2d3e-4f50-4a6b-8c7d-9e0f1a2b3c4d")
+
+	assert.Equal(t, gradingErrors.GradeNotFoundError.String(), codeOf(err))
+}
+
+func TestNewChangeGradeStatusCommand_ReturnNeedsReason(t *testing.T) {
+	_, err := change_grade_status_use_case.NewChangeGradeStatusCommand("tenant1", classID, entities.GradeActionReturn,
+		[]string{"0b1c2d3e-4f50-4a6b-8c7d-9e0f1a2b3c4d"}, "actor-1", "")
+
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err))
+}
+
+func TestListStudentGrades_HidesUnreleasedGrades(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	policies := &memoryPolicies{}
+	released := record(t, repo, "student-1", 70)
+	_, _ = change(repo, policies, entities.GradeActionSubmit, "", released.ID)
+	_, _ = change(repo, policies, entities.GradeActionRelease, "", released.ID)
+
+	cmd, _ := record_grade_use_case.NewRecordGradeCommand("tenant1", classID, "0b1c2d3e-4f50-4a6b-8c7d-9e0f1a2b3c4d", "student-1", 50, nil, "teacher-1", "")
+	_, err := record_grade_use_case.NewRecordGradeUseCase(repo).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+
+	listCmd, _ := list_student_grades_use_case.NewListStudentGradesCommand("tenant1", "student-1", "")
+	grades, err := list_student_grades_use_case.NewListStudentGradesUseCase(repo).Execute(context.Background(), listCmd)
+
+	assert.NoError(t, err)
+	assert.Len(t, grades, 1)
+	assert.Equal(t, released.ID, grades[0].Grade.ID)
+}
+
+func TestChangeGradeStatus_ReleaseFreezesLatePenalty(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	policies := &memoryPolicies{}
+	deadlines := &memoryDeadlines{}
+	setDeadline(t, deadlines, 10, 0)
+	turnedInAt := dueAt.Add(30 * time.Hour)
+	grade := recordTurnedIn(t, repo, "student-1", 80, &turnedInAt)
+
+	_, err := changeWithDeadlines(repo, policies, deadlines, entities.GradeActionSubmit, "", grade.ID)
+	assert.NoError(t, err)
+	assert.Zero(t, repo.grades[grade.ID].LatePenaltyPercent)
+
+	released, err := changeWithDeadlines(repo, policies, deadlines, entities.GradeActionRelease, "", grade.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, released[0].LatePenaltyPercent, "two started days late")
+
+	// Later policy changes do not touch released grades
+	deadlines.deadlines = nil
+	setDeadline(t, deadlines, 50, 0)
+	listCmd, _ := list_student_grades_use_case.NewListStudentGradesCommand("tenant1", "student-1", "")
+	entries, err := list_student_grades_use_case.NewListStudentGradesUseCase(repo).Execute(context.Background(), listCmd)
+	assert.NoError(t, err)
+	assert.Equal(t, 64.0, entries[0].FinalScore())
+}
+
+func TestListClassGrades_PreviewsPenaltyWithExtensions(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	deadlines := &memoryDeadlines{}
+	setDeadline(t, deadlines, 10, 3)
+	turnedInAt := dueAt.Add(5 * 24 * time.Hour)
+	extended := recordTurnedIn(t, repo, "student-1", 90, &turnedInAt)
+	recordTurnedIn(t, repo, "student-2", 90, &turnedInAt)
+	assert.NoError(t, grantExtension(deadlines, "student-1", dueAt.Add(4*24*time.Hour)))
+
+	cmd, err := list_class_grades_use_case.NewListClassGradesCommand("tenant1", classID, "")
+	assert.NoError(t, err)
+	entries, err := list_class_grades_use_case.NewListClassGradesUseCase(repo, deadlines, &memoryCalendar{}).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	for _, entry := range entries {
+		if entry.Grade.ID == extended.ID {
+			assert.Equal(t, 1, entry.Lateness.DaysLate)
+			assert.Equal(t, 81.0, entry.FinalScore())
+		} else {
+			assert.True(t, entry.Lateness.PastCutoff)
+			assert.Zero(t, entry.FinalScore())
+		}
+	}
+}
+
+func TestListClassGrades_SkipsNonInstructionalDays(t *testing.T) {
+	repo := &memoryGrades{grades: map[string]entities.Grade{}}
+	deadlines := &memoryDeadlines{}
+	setDeadline(t, deadlines, 10, 0)
+	turnedInAt := dueAt.Add(48 * time.Hour)
+	recordTurnedIn(t, repo, "student-1", 90, &turnedInAt)
+	holiday, err := calendarEntities.NewNonInstructionalDay("tenant1", time.Date(2025, 9, 23, 0, 0, 0, 0, time.UTC), "Holiday", "", "admin-1", dueAt)
+	assert.NoError(t, err)
+
+	cmd, err := list_class_grades_use_case.NewListClassGradesCommand("tenant1", classID, "")
+	assert.NoError(t, err)
+	entries, err := list_class_grades_use_case.NewListClassGradesUseCase(repo, deadlines,
+		&memoryCalendar{days: []*calendarEntities.NonInstructionalDay{holiday}}).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, 1, entries[0].Lateness.DaysLate, "the day starting on the holiday is not counted")
+	assert.Equal(t, 81.0, entries[0].FinalScore())
+}
+
+func TestGrantExtension_RequiresLaterDueDate(t *testing.T) {
+	deadlines := &memoryDeadlines{}
+
+	err := grantExtension(deadlines, "student-1", dueAt.Add(24*time.Hour))
+	assert.Equal(t, gradingErrors.DeadlineNotFoundError.String(), codeOf(err))
+
+	setDeadline(t, deadlines, 10, 0)
+	err = grantExtension(deadlines, "student-1", dueAt)
+	assert.Equal(t, gradingErrors.InvalidExtensionError.String(), codeOf(err))
+	assert.Empty(t, deadlines.extensions)
+
+	assert.NoError(t, grantExtension(deadlines, "student-1", dueAt.Add(24*time.Hour)))
+	assert.Len(t, deadlines.extensions, 1)
+}
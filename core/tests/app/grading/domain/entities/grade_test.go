@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2025, 9, 19, 12, 0, 0, 0, time.UTC)
+
+func draftGrade(t *testing.T) *entities.Grade {
+	t.Helper()
+	grade, err := entities.NewGrade("3f0c9a52-8f6e-4d3a-9b1c-2e7d5a4b6c8d", "tenant1", "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f",
+		"5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e", "student-1", 80, entities.GradeStatusDraft, "teacher-1", "", "", nil, 0, false, nil, nil, now, now, 0)
+	assert.NoError(t, err)
+	return grade
+}
+
+func TestGrade_ReleaseWithoutApproval(t *testing.T) {
+	grade := draftGrade(t)
+
+	assert.NoError(t, grade.Apply(entities.GradeActionSubmit, "teacher-1", "", false, entities.Lateness{}, now))
+	assert.False(t, grade.VisibleToStudent())
+	assert.NoError(t, grade.Apply(entities.GradeActionRelease, "teacher-1", "", false, entities.Lateness{}, now))
+
+	assert.Equal(t, entities.GradeStatusReleased, grade.Status)
+	assert.True(t, grade.VisibleToStudent())
+	assert.Equal(t, now, *grade.ReleasedAt)
+}
+
+func TestGrade_ReleaseRequiresApprovalWhenConfigured(t *testing.T) {
+	grade := draftGrade(t)
+	assert.NoError(t, grade.Apply(entities.GradeActionSubmit, "teacher-1", "", true, entities.Lateness{}, now))
+
+	err := grade.Apply(entities.GradeActionRelease, "teacher-1", "", true, entities.Lateness{}, now)
+	var appErr appErrors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, gradingErrors.InvalidGradeTransitionError.String(), appErr.GetCode())
+
+	assert.NoError(t, grade.Apply(entities.GradeActionApprove, "head-1", "", true, entities.Lateness{}, now))
+	assert.NoError(t, grade.Apply(entities.GradeActionRelease, "teacher-1", "", true, entities.Lateness{}, now))
+	assert.Equal(t, "head-1", grade.ReviewedBy)
+}
+
+func TestGrade_ReturnSendsBackToDraft(t *testing.T) {
+	grade := draftGrade(t)
+	assert.NoError(t, grade.Apply(entities.GradeActionSubmit, "teacher-1", "", true, entities.Lateness{}, now))
+	assert.Error(t, grade.SetScore(90, nil, "teacher-1", "", now), "submitted grades are locked")
+
+	assert.NoError(t, grade.Apply(entities.GradeActionReturn, "head-1", "rubric not applied", true, entities.Lateness{}, now))
+
+	assert.Equal(t, entities.GradeStatusDraft, grade.Status)
+	assert.Equal(t, "rubric not applied", grade.ReturnReason)
+	assert.Nil(t, grade.SubmittedAt)
+	assert.NoError(t, grade.SetScore(90, nil, "teacher-1", "", now))
+}
+
+func TestGrade_ReleasedGradesAreFinal(t *testing.T) {
+	grade := draftGrade(t)
+	assert.NoError(t, grade.Apply(entities.GradeActionSubmit, "teacher-1", "", false, entities.Lateness{}, now))
+	assert.NoError(t, grade.Apply(entities.GradeActionRelease, "teacher-1", "", false, entities.Lateness{}, now))
+
+	for _, action := range []entities.GradeAction{entities.GradeActionSubmit, entities.GradeActionApprove, entities.GradeActionReturn, entities.GradeActionRelease} {
+		assert.False(t, grade.CanApply(action, false), action)
+	}
+	assert.Error(t, grade.SetScore(10, nil, "teacher-1", "", now))
+}
+
+func TestGrade_ReleaseFreezesLatePenalty(t *testing.T) {
+	grade := draftGrade(t)
+	assert.NoError(t, grade.Apply(entities.GradeActionSubmit, "teacher-1", "", false, entities.Lateness{PenaltyPercent: 50}, now))
+	assert.Zero(t, grade.LatePenaltyPercent, "only release freezes the penalty")
+
+	assert.NoError(t, grade.Apply(entities.GradeActionRelease, "teacher-1", "", false, entities.Lateness{PenaltyPercent: 25}, now))
+
+	assert.Equal(t, 25.0, grade.LatePenaltyPercent)
+	assert.Equal(t, 60.0, grade.FinalScore())
+}
+
+func TestGrade_ReplayRebuildsTheGradeFromItsEvents(t *testing.T) {
+	grade, err := entities.RecordGrade("3f0c9a52-8f6e-4d3a-9b1c-2e7d5a4b6c8d", "tenant1", "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f",
+		"5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e", "student-1", 70, nil, "teacher-1", now)
+	assert.NoError(t, err)
+	assert.NoError(t, grade.SetScore(80, nil, "teacher-1", "regraded", now.Add(time.Hour)))
+	assert.NoError(t, grade.Apply(entities.GradeActionSubmit, "teacher-1", "", true, entities.Lateness{}, now.Add(2*time.Hour)))
+	assert.NoError(t, grade.Apply(entities.GradeActionReturn, "head-1", "rubric not applied", true, entities.Lateness{}, now.Add(3*time.Hour)))
+	assert.NoError(t, grade.Apply(entities.GradeActionSubmit, "teacher-1", "", true, entities.Lateness{}, now.Add(4*time.Hour)))
+	assert.NoError(t, grade.Apply(entities.GradeActionApprove, "head-1", "", true, entities.Lateness{}, now.Add(5*time.Hour)))
+	assert.NoError(t, grade.Apply(entities.GradeActionRelease, "teacher-1", "", true, entities.Lateness{PenaltyPercent: 10}, now.Add(6*time.Hour)))
+
+	events := grade.PendingEvents()
+	assert.Len(t, events, 7)
+	assert.Equal(t, 7, grade.Version)
+	assert.Nil(t, events[0].OldScore, "the first event has no previous score")
+	assert.Equal(t, 70.0, *events[1].OldScore)
+	assert.Equal(t, entities.GradeStatusApproved, events[6].OldStatus)
+	assert.Equal(t, entities.GradeStatusReleased, events[6].NewStatus)
+
+	replayed := entities.ReplayGrade(events)
+	grade.ClearPendingEvents()
+	assert.Equal(t, grade, replayed)
+}
@@ -0,0 +1,176 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	calendarEntities "github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var dueAt = time.Date(2025, 9, 22, 23, 59, 0, 0, time.UTC)
+
+func deadline(t *testing.T, penaltyPerDay float64, cutoffDays int) *entities.AssignmentDeadline {
+	t.Helper()
+	d, err := entities.NewAssignmentDeadline("tenant1", "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f", "5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e", dueAt,
+		entities.LatePolicy{PenaltyPercentPerDay: penaltyPerDay, CutoffDays: cutoffDays})
+	assert.NoError(t, err)
+	return d
+}
+
+func at(offset time.Duration) *time.Time {
+	value := dueAt.Add(offset)
+	return &value
+}
+
+func TestNewAssignmentDeadline_RejectsInvalidPolicy(t *testing.T) {
+	_, err := entities.NewAssignmentDeadline("tenant1", "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f", "5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e", dueAt,
+		entities.LatePolicy{PenaltyPercentPerDay: 120})
+	assert.Error(t, err)
+
+	_, err = entities.NewAssignmentDeadline("tenant1", "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f", "5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e", dueAt,
+		entities.LatePolicy{CutoffDays: -1})
+	assert.Error(t, err)
+}
+
+func TestAssignmentDeadline_Evaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     [2]float64
+		turnedInAt *time.Time
+		want       entities.Lateness
+	}{
+		{"not turned in", [2]float64{10, 0}, nil, entities.Lateness{EffectiveDueAt: dueAt}},
+		{"early", [2]float64{10, 0}, at(-time.Hour), entities.Lateness{EffectiveDueAt: dueAt}},
+		{"exactly on time", [2]float64{10, 0}, at(0), entities.Lateness{EffectiveDueAt: dueAt}},
+		{"one second late counts a day", [2]float64{10, 0}, at(time.Second), entities.Lateness{EffectiveDueAt: dueAt, DaysLate: 1, PenaltyPercent: 10}},
+		{"exactly one day", [2]float64{10, 0}, at(24 * time.Hour), entities.Lateness{EffectiveDueAt: dueAt, DaysLate: 1, PenaltyPercent: 10}},
+		{"started second day", [2]float64{10, 0}, at(25 * time.Hour), entities.Lateness{EffectiveDueAt: dueAt, DaysLate: 2, PenaltyPercent: 20}},
+		{"penalty caps at 100", [2]float64{30, 0}, at(96 * time.Hour), entities.Lateness{EffectiveDueAt: dueAt, DaysLate: 4, PenaltyPercent: 100}},
+		{"no penalty policy", [2]float64{0, 0}, at(96 * time.Hour), entities.Lateness{EffectiveDueAt: dueAt, DaysLate: 4}},
+		{"on the cutoff day", [2]float64{10, 2}, at(48 * time.Hour), entities.Lateness{EffectiveDueAt: dueAt, DaysLate: 2, PenaltyPercent: 20}},
+		{"past the cutoff", [2]float64{10, 2}, at(49 * time.Hour), entities.Lateness{EffectiveDueAt: dueAt, DaysLate: 3, PenaltyPercent: 100, PastCutoff: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := deadline(t, tt.policy[0], int(tt.policy[1]))
+			assert.Equal(t, tt.want, d.Evaluate(nil, tt.turnedInAt, nil))
+		})
+	}
+}
+
+func TestAssignmentDeadline_EvaluateWithExtension(t *testing.T) {
+	d := deadline(t, 10, 1)
+	extension := &entities.Extension{StudentID: "student-1", DueAt: dueAt.Add(72 * time.Hour)}
+
+	lateness := d.Evaluate(extension, at(80*time.Hour), nil)
+
+	assert.Equal(t, extension.DueAt, lateness.EffectiveDueAt)
+	assert.Equal(t, 1, lateness.DaysLate, "the cutoff moves with the extension")
+	assert.Equal(t, 10.0, lateness.PenaltyPercent)
+}
+
+func TestAssignmentDeadline_IgnoresEarlierExtension(t *testing.T) {
+	d := deadline(t, 10, 0)
+	extension := &entities.Extension{StudentID: "student-1", DueAt: dueAt.Add(-24 * time.Hour)}
+
+	lateness := d.Evaluate(extension, at(time.Hour), nil)
+
+	assert.Equal(t, dueAt, lateness.EffectiveDueAt)
+	assert.Equal(t, 10.0, lateness.PenaltyPercent)
+}
+
+// schoolCalendar is kept in Guatemala, where the due date is Monday 2025-09-22 at 17:59
+func schoolCalendar(t *testing.T, dates ...string) *calendarEntities.SchoolCalendar {
+	t.Helper()
+	loc, err := time.LoadLocation("America/Guatemala")
+	assert.NoError(t, err)
+
+	days := make([]*calendarEntities.NonInstructionalDay, 0, len(dates))
+	for _, date := range dates {
+		parsed, err := time.Parse(calendarEntities.DateLayout, date)
+		assert.NoError(t, err)
+		day, err := calendarEntities.NewNonInstructionalDay("tenant1", parsed, "Holiday", "", "admin-1", dueAt)
+		assert.NoError(t, err)
+		days = append(days, day)
+	}
+	return calendarEntities.NewSchoolCalendar(days, loc)
+}
+
+func TestAssignmentDeadline_EvaluateSkipsNonInstructionalDays(t *testing.T) {
+	d := deadline(t, 10, 2)
+	calendar := schoolCalendar(t, "2025-09-23", "2025-09-24")
+
+	lateness := d.Evaluate(nil, at(72*time.Hour+time.Second), calendar)
+
+	assert.Equal(t, dueAt, lateness.EffectiveDueAt)
+	assert.Equal(t, 2, lateness.DaysLate, "the days starting on Tuesday and Wednesday are not counted")
+	assert.Equal(t, 20.0, lateness.PenaltyPercent)
+	assert.False(t, lateness.PastCutoff)
+}
+
+func TestAssignmentDeadline_EvaluateMovesDueDateOffNonInstructionalDays(t *testing.T) {
+	d := deadline(t, 10, 0)
+	calendar := schoolCalendar(t, "2025-09-22", "2025-09-23")
+
+	lateness := d.Evaluate(nil, at(48*time.Hour), calendar)
+
+	assert.Equal(t, dueAt.Add(48*time.Hour), lateness.EffectiveDueAt, "moves to Wednesday at the same time")
+	assert.Zero(t, lateness.DaysLate)
+
+	// Turned in on Tuesday in UTC but still on Monday at the school, the started day counts
+	lateness = d.Evaluate(nil, at(time.Hour), schoolCalendar(t, "2025-09-23"))
+	assert.Equal(t, dueAt, lateness.EffectiveDueAt)
+	assert.Equal(t, 1, lateness.DaysLate)
+}
+
+func TestAdjustScore(t *testing.T) {
+	assert.Equal(t, 72.0, entities.AdjustScore(80, 10))
+	assert.Equal(t, 58.34, entities.AdjustScore(87.5, 33.33))
+	assert.Equal(t, 0.0, entities.AdjustScore(95, 100))
+	assert.Equal(t, 95.0, entities.AdjustScore(95, 0))
+}
+
+func TestGradebook_Entries(t *testing.T) {
+	d := deadline(t, 10, 0)
+	onTime := draftGrade(t)
+	onTime.TurnedInAt = at(-time.Hour)
+	late := draftGrade(t)
+	late.StudentID = "student-2"
+	late.TurnedInAt = at(24 * time.Hour)
+	released := draftGrade(t)
+	released.StudentID = "student-3"
+	released.TurnedInAt = at(72 * time.Hour)
+	released.Status = entities.GradeStatusReleased
+	released.LatePenaltyPercent = 5
+	unscheduled := draftGrade(t)
+	unscheduled.AssignmentID = "0b1c2d3e-4f50-4a6b-8c7d-9e0f1a2b3c4d"
+	unscheduled.TurnedInAt = at(72 * time.Hour)
+
+	gradebook := entities.NewGradebook([]*entities.AssignmentDeadline{d}, nil, nil)
+	entries := gradebook.Entries([]*entities.Grade{onTime, late, released, unscheduled})
+
+	assert.Equal(t, 80.0, entries[0].FinalScore())
+	assert.Equal(t, 72.0, entries[1].FinalScore())
+	assert.Equal(t, 76.0, entries[2].FinalScore(), "released grades keep the frozen penalty")
+	assert.Equal(t, 3, entries[2].Lateness.DaysLate)
+	assert.Equal(t, 80.0, entries[3].FinalScore(), "assignments without a deadline are never late")
+	assert.True(t, entries[3].Lateness.EffectiveDueAt.IsZero())
+}
+
+func TestGradebook_MatchesExtensionsPerStudent(t *testing.T) {
+	d := deadline(t, 10, 0)
+	grade := draftGrade(t)
+	grade.TurnedInAt = at(24 * time.Hour)
+	other := &entities.Extension{AssignmentID: d.AssignmentID, StudentID: "student-2", DueAt: dueAt.Add(48 * time.Hour)}
+
+	entry := entities.NewGradebook([]*entities.AssignmentDeadline{d}, []*entities.Extension{other}, nil).Entry(grade)
+	assert.Equal(t, 10.0, entry.Lateness.PenaltyPercent)
+
+	own := &entities.Extension{AssignmentID: d.AssignmentID, StudentID: grade.StudentID, DueAt: dueAt.Add(48 * time.Hour)}
+	entry = entities.NewGradebook([]*entities.AssignmentDeadline{d}, []*entities.Extension{other, own}, nil).Entry(grade)
+	assert.Zero(t, entry.Lateness.PenaltyPercent)
+}
@@ -0,0 +1,227 @@
+package use_cases
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	list_job_failures_use_case "github.com/nahualventure/class-backend/core/app/jobfailure/application/use-cases/list-job-failures-use-case"
+	record_job_attempt_use_case "github.com/nahualventure/class-backend/core/app/jobfailure/application/use-cases/record-job-attempt-use-case"
+	requeue_job_failure_use_case "github.com/nahualventure/class-backend/core/app/jobfailure/application/use-cases/requeue-job-failure-use-case"
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	jobFailureErrors "github.com/nahualventure/class-backend/core/app/jobfailure/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryFailures keeps the failures by ID
+type memoryFailures struct {
+	failures map[string]*entities.JobFailure
+}
+
+func newMemoryFailures() *memoryFailures {
+	return &memoryFailures{failures: make(map[string]*entities.JobFailure)}
+}
+
+func (m *memoryFailures) Find(_ context.Context, tenantID string, job string, subject string) (*entities.JobFailure, error) {
+	for _, failure := range m.failures {
+		if failure.TenantID == tenantID && failure.Job == job && failure.Subject == subject {
+			copied := *failure
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryFailures) FindByID(_ context.Context, tenantID string, failureID string) (*entities.JobFailure, error) {
+	if failure, ok := m.failures[failureID]; ok && failure.TenantID == tenantID {
+		copied := *failure
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryFailures) List(_ context.Context, tenantID string, status entities.JobFailureStatus) ([]*entities.JobFailure, error) {
+	var failures []*entities.JobFailure
+	for _, failure := range m.failures {
+		if failure.TenantID == tenantID && (status == "" || failure.Status == status) {
+			failures = append(failures, failure)
+		}
+	}
+	return failures, nil
+}
+
+func (m *memoryFailures) Save(_ context.Context, failure *entities.JobFailure) error {
+	copied := *failure
+	m.failures[failure.ID] = &copied
+	return nil
+}
+
+func (m *memoryFailures) Delete(_ context.Context, tenantID string, job string, subject string) error {
+	for id, failure := range m.failures {
+		if failure.TenantID == tenantID && failure.Job == job && failure.Subject == subject {
+			delete(m.failures, id)
+		}
+	}
+	return nil
+}
+
+// recordingNotifier keeps the failures it was told about, and fails while err is set
+type recordingNotifier struct {
+	notified []*entities.JobFailure
+	err      error
+}
+
+func (n *recordingNotifier) NotifyJobFailure(_ context.Context, failure *entities.JobFailure) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.notified = append(n.notified, failure)
+	return nil
+}
+
+func assertCode(t *testing.T, err error, code appErrors.ErrorCode) {
+	t.Helper()
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr), "expected %s, got %v", code, err) {
+		assert.Equal(t, code.String(), appErr.GetCode())
+	}
+}
+
+func recordAttempt(t *testing.T, useCase *record_job_attempt_use_case.RecordJobAttemptUseCase, runErr error) (*entities.JobFailure, error) {
+	command, err := record_job_attempt_use_case.NewRecordJobAttemptCommand("tenant1", "warehouse-exports", "grades",
+		map[string]string{"dataset": "grades"}, runErr)
+	assert.NoError(t, err)
+	return useCase.Execute(context.Background(), command)
+}
+
+func TestRecordJobAttempt_EscalatesWorkFailingMaxAttemptsRunsInARow(t *testing.T) {
+	failures := newMemoryFailures()
+	notifier := &recordingNotifier{}
+	useCase := record_job_attempt_use_case.NewRecordJobAttemptUseCase(failures, notifier)
+
+	for i := 1; i < entities.MaxAttempts; i++ {
+		failure, err := recordAttempt(t, useCase, errors.New("sink unreachable"))
+		assert.NoError(t, err)
+		assert.Equal(t, i, failure.Attempts)
+		assert.False(t, failure.IsEscalated())
+	}
+	assert.Empty(t, notifier.notified)
+
+	failure, err := recordAttempt(t, useCase, errors.New("bucket denied"))
+	assert.NoError(t, err)
+	assert.True(t, failure.IsEscalated())
+	assert.NotNil(t, failure.EscalatedAt)
+	if assert.Len(t, notifier.notified, 1) {
+		assert.Equal(t, "bucket denied", notifier.notified[0].LastError)
+		assert.Equal(t, "dataset=grades", notifier.notified[0].PayloadSummary())
+	}
+
+	stored, _ := failures.FindByID(context.Background(), "tenant1", failure.ID)
+	assert.Equal(t, entities.JobFailureStatusEscalated, stored.Status)
+	assert.Equal(t, entities.MaxAttempts, stored.Attempts)
+}
+
+func TestRecordJobAttempt_SuccessDropsTheFailure(t *testing.T) {
+	failures := newMemoryFailures()
+	useCase := record_job_attempt_use_case.NewRecordJobAttemptUseCase(failures, &recordingNotifier{})
+
+	_, err := recordAttempt(t, useCase, errors.New("sink unreachable"))
+	assert.NoError(t, err)
+	assert.Len(t, failures.failures, 1)
+
+	failure, err := recordAttempt(t, useCase, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, failure)
+	assert.Empty(t, failures.failures)
+}
+
+func TestRecordJobAttempt_NotifiesAgainOnTheNextFailureWhenNotifyingFailed(t *testing.T) {
+	failures := newMemoryFailures()
+	notifier := &recordingNotifier{err: errors.New("webhook down")}
+	useCase := record_job_attempt_use_case.NewRecordJobAttemptUseCase(failures, notifier)
+
+	for i := 1; i < entities.MaxAttempts; i++ {
+		_, err := recordAttempt(t, useCase, errors.New("sink unreachable"))
+		assert.NoError(t, err)
+	}
+	failure, err := recordAttempt(t, useCase, errors.New("sink unreachable"))
+	assert.Error(t, err)
+	assert.False(t, failure.IsEscalated())
+
+	notifier.err = nil
+	failure, err = recordAttempt(t, useCase, errors.New("sink unreachable"))
+	assert.NoError(t, err)
+	assert.True(t, failure.IsEscalated())
+	assert.Equal(t, entities.MaxAttempts+1, failure.Attempts)
+	assert.Len(t, notifier.notified, 1)
+}
+
+func TestRequeueJobFailure(t *testing.T) {
+	failures := newMemoryFailures()
+	useCase := requeue_job_failure_use_case.NewRequeueJobFailureUseCase(failures)
+	record := record_job_attempt_use_case.NewRecordJobAttemptUseCase(failures, &recordingNotifier{})
+
+	failure, err := recordAttempt(t, record, errors.New("sink unreachable"))
+	assert.NoError(t, err)
+
+	command, err := requeue_job_failure_use_case.NewRequeueJobFailureCommand("tenant1", failure.ID, "admin1")
+	assert.NoError(t, err)
+	_, err = useCase.Execute(context.Background(), command)
+	assertCode(t, err, jobFailureErrors.JobFailureNotEscalatedError)
+
+	for i := 1; i < entities.MaxAttempts; i++ {
+		_, err := recordAttempt(t, record, errors.New("sink unreachable"))
+		assert.NoError(t, err)
+	}
+	requeued, err := useCase.Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.False(t, requeued.IsEscalated())
+	assert.Equal(t, 0, requeued.Attempts)
+	assert.Equal(t, "admin1", requeued.RequeuedBy)
+	assert.Equal(t, map[string]string{"dataset": "grades"}, requeued.Payload)
+
+	otherTenant, err := requeue_job_failure_use_case.NewRequeueJobFailureCommand("tenant2", failure.ID, "admin2")
+	assert.NoError(t, err)
+	_, err = useCase.Execute(context.Background(), otherTenant)
+	assertCode(t, err, jobFailureErrors.JobFailureNotFoundError)
+
+	missing, err := requeue_job_failure_use_case.NewRequeueJobFailureCommand("tenant1", uuid.NewString(), "admin1")
+	assert.NoError(t, err)
+	_, err = useCase.Execute(context.Background(), missing)
+	assertCode(t, err, jobFailureErrors.JobFailureNotFoundError)
+}
+
+func TestListJobFailures_FiltersByStatus(t *testing.T) {
+	failures := newMemoryFailures()
+	useCase := list_job_failures_use_case.NewListJobFailuresUseCase(failures)
+	record := record_job_attempt_use_case.NewRecordJobAttemptUseCase(failures, &recordingNotifier{})
+	_, err := recordAttempt(t, record, errors.New("sink unreachable"))
+	assert.NoError(t, err)
+
+	command, err := list_job_failures_use_case.NewListJobFailuresCommand("tenant1", "escalated")
+	assert.NoError(t, err)
+	listed, err := useCase.Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.Empty(t, listed)
+
+	command, err = list_job_failures_use_case.NewListJobFailuresCommand("tenant1", "")
+	assert.NoError(t, err)
+	listed, err = useCase.Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	_, err = list_job_failures_use_case.NewListJobFailuresCommand("tenant1", "resolved")
+	assertCode(t, err, appErrors.ValidationError)
+}
+
+func TestJobFailure_PayloadSummaryIsSortedAndBounded(t *testing.T) {
+	failure := &entities.JobFailure{Payload: map[string]string{"dataset": "grades", "cursor": strings.Repeat("é", 400)}}
+	summary := failure.PayloadSummary()
+	assert.True(t, strings.HasPrefix(summary, "cursor=éé"))
+	assert.True(t, strings.HasSuffix(summary, "…"))
+	assert.LessOrEqual(t, len(summary), 500+len("…"))
+}
@@ -0,0 +1,343 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	change_resource_status_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/change-resource-status-use-case"
+	create_resource_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/create-resource-use-case"
+	download_resource_version_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/download-resource-version-use-case"
+	get_resource_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/get-resource-use-case"
+	list_resources_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/list-resources-use-case"
+	update_resource_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/update-resource-use-case"
+	upload_resource_version_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/upload-resource-version-use-case"
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	libraryErrors "github.com/nahualventure/class-backend/core/app/library/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/library/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+type memoryLibrary struct {
+	resources map[string]*entities.Resource
+	versions  []*entities.ResourceVersion
+	viewers   map[string]entities.Viewer
+}
+
+func newMemoryLibrary() *memoryLibrary {
+	return &memoryLibrary{
+		resources: make(map[string]*entities.Resource),
+		viewers: map[string]entities.Viewer{
+			"teacher-1": {UserID: "teacher-1", CanContribute: true},
+			"teacher-2": {UserID: "teacher-2", CanContribute: true},
+			"head-1":    {UserID: "head-1", CanContribute: true, CanPublish: true},
+			"student-1": {UserID: "student-1"},
+		},
+	}
+}
+
+func (m *memoryLibrary) Create(_ context.Context, resource *entities.Resource) error {
+	m.resources[resource.ID] = resource
+	return nil
+}
+
+func (m *memoryLibrary) Update(_ context.Context, resource *entities.Resource) error {
+	m.resources[resource.ID] = resource
+	return nil
+}
+
+func (m *memoryLibrary) FindByID(_ context.Context, _ string, resourceID string) (*entities.Resource, error) {
+	return m.resources[resourceID], nil
+}
+
+func (m *memoryLibrary) List(_ context.Context, _ string, filter ports.ResourceFilter) ([]*entities.Resource, error) {
+	var resources []*entities.Resource
+	for _, resource := range m.resources {
+		visible := slices.Contains(filter.Statuses, resource.Status) ||
+			(resource.Status == entities.ResourceStatusDraft && resource.OwnerID == filter.DraftsOf)
+		if visible && (filter.Tag == "" || slices.Contains(resource.Tags, filter.Tag)) {
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+func (m *memoryLibrary) AddVersion(_ context.Context, version *entities.ResourceVersion) (int, error) {
+	version.Version = 1
+	for _, added := range m.versions {
+		if added.ResourceID == version.ResourceID {
+			version.Version = max(version.Version, added.Version+1)
+		}
+	}
+	m.versions = append(m.versions, version)
+	return version.Version, nil
+}
+
+// scanClean releases the version as the scan of a clean file does
+func (m *memoryLibrary) scanClean(version *entities.ResourceVersion) {
+	version.ScanStatus = storage.ScanClean
+	resource := m.resources[version.ResourceID]
+	resource.CurrentVersion = max(resource.CurrentVersion, version.Version)
+}
+
+func (m *memoryLibrary) ListVersions(_ context.Context, _ string, resourceID string) ([]*entities.ResourceVersion, error) {
+	var versions []*entities.ResourceVersion
+	for _, version := range slices.Backward(m.versions) {
+		if version.ResourceID == resourceID {
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+func (m *memoryLibrary) FindVersion(_ context.Context, _ string, resourceID string, number int) (*entities.ResourceVersion, error) {
+	for _, version := range m.versions {
+		if version.ResourceID == resourceID && version.Version == number {
+			return version, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryLibrary) Viewer(_ context.Context, _ string, userID string) (entities.Viewer, error) {
+	return m.viewers[userID], nil
+}
+
+type memoryFiles struct {
+	files map[string][]byte
+}
+
+func (m *memoryFiles) Put(_ context.Context, key string, content []byte) error {
+	m.files[key] = content
+	return nil
+}
+
+func (m *memoryFiles) Get(_ context.Context, key string) ([]byte, error) {
+	return m.files[key], nil
+}
+
+func (m *memoryFiles) Delete(_ context.Context, key string) error {
+	delete(m.files, key)
+	return nil
+}
+
+func createResource(t *testing.T, store *memoryLibrary, ownerID string, kind entities.ResourceKind, url string) *entities.Resource {
+	t.Helper()
+	cmd, err := create_resource_use_case.NewCreateResourceCommand(tenantID, ownerID, string(kind), "Fractions", "Worksheet",
+		"Mathematics", "7", []string{"Fractions", " practice ", "fractions"}, url)
+	assert.NoError(t, err)
+	resource, err := create_resource_use_case.NewCreateResourceUseCase(store).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return resource
+}
+
+func upload(store *memoryLibrary, files *memoryFiles, resourceID string, userID string, content string) (*entities.ResourceVersion, error) {
+	cmd, err := upload_resource_version_use_case.NewUploadResourceVersionCommand(tenantID, resourceID, userID, "fractions.pdf",
+		"application/pdf", []byte(content), "")
+	if err != nil {
+		return nil, err
+	}
+	return upload_resource_version_use_case.NewUploadResourceVersionUseCase(store, store, files).Execute(context.Background(), cmd)
+}
+
+func changeStatus(store *memoryLibrary, resourceID string, userID string, action change_resource_status_use_case.ResourceAction) (*entities.Resource, error) {
+	cmd, err := change_resource_status_use_case.NewChangeResourceStatusCommand(tenantID, resourceID, userID, string(action))
+	if err != nil {
+		return nil, err
+	}
+	return change_resource_status_use_case.NewChangeResourceStatusUseCase(store, store).Execute(context.Background(), cmd)
+}
+
+func listResources(t *testing.T, store *memoryLibrary, userID string, tag string) []*entities.Resource {
+	t.Helper()
+	cmd, err := list_resources_use_case.NewListResourcesCommand(tenantID, userID, "", "", "", tag, "", "", 0)
+	assert.NoError(t, err)
+	resources, err := list_resources_use_case.NewListResourcesUseCase(store, store).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return resources
+}
+
+func hasCode(err error, code string) bool {
+	var appErr appErrors.ApplicationError
+	return errors.As(err, &appErr) && appErr.GetCode() == code
+}
+
+func TestCreateResource_NormalizesTagsAndStartsAsDraft(t *testing.T) {
+	store := newMemoryLibrary()
+	resource := createResource(t, store, "teacher-1", entities.ResourceKindDocument, "")
+
+	assert.Equal(t, entities.ResourceStatusDraft, resource.Status)
+	assert.Equal(t, 0, resource.CurrentVersion)
+	assert.Equal(t, []string{"fractions", "practice"}, resource.Tags)
+}
+
+func TestCreateResource_LinksNeedAURL(t *testing.T) {
+	cmd, err := create_resource_use_case.NewCreateResourceCommand(tenantID, "teacher-1", "link", "Khan Academy", "", "", "", nil, "")
+	assert.NoError(t, err)
+	_, err = create_resource_use_case.NewCreateResourceUseCase(newMemoryLibrary()).Execute(context.Background(), cmd)
+	assert.Error(t, err)
+}
+
+func TestListResources_DraftsAreVisibleToOwnersAndPublishers(t *testing.T) {
+	store := newMemoryLibrary()
+	createResource(t, store, "teacher-1", entities.ResourceKindLink, "https://example.com/fractions")
+
+	visible := map[string]int{"teacher-1": 1, "head-1": 1, "teacher-2": 0, "student-1": 0}
+	for userID, expected := range visible {
+		assert.Len(t, listResources(t, store, userID, ""), expected, userID)
+	}
+}
+
+func TestPublishResource_DocumentsNeedAFile(t *testing.T) {
+	store := newMemoryLibrary()
+	files := &memoryFiles{files: map[string][]byte{}}
+	resource := createResource(t, store, "teacher-1", entities.ResourceKindDocument, "")
+
+	_, err := changeStatus(store, resource.ID, "head-1", change_resource_status_use_case.ResourceActionPublish)
+	assert.True(t, hasCode(err, libraryErrors.ResourceNotPublishableError.String()))
+
+	version, err := upload(store, files, resource.ID, "teacher-1", "v1")
+	assert.NoError(t, err)
+	_, err = changeStatus(store, resource.ID, "head-1", change_resource_status_use_case.ResourceActionPublish)
+	assert.True(t, hasCode(err, libraryErrors.ResourceNotPublishableError.String()), "the file is not scanned yet")
+
+	store.scanClean(version)
+	published, err := changeStatus(store, resource.ID, "head-1", change_resource_status_use_case.ResourceActionPublish)
+	assert.NoError(t, err)
+	assert.True(t, published.IsPublished())
+	assert.Equal(t, "head-1", published.PublishedBy)
+	assert.Len(t, listResources(t, store, "student-1", "fractions"), 1)
+}
+
+func TestPublishResource_ContributorsCannotPublish(t *testing.T) {
+	store := newMemoryLibrary()
+	resource := createResource(t, store, "teacher-1", entities.ResourceKindLink, "https://example.com/fractions")
+
+	_, err := changeStatus(store, resource.ID, "teacher-1", change_resource_status_use_case.ResourceActionPublish)
+	assert.True(t, hasCode(err, libraryErrors.ResourceNotEditableError.String()))
+}
+
+func TestUploadResourceVersion_NumbersVersionsAndDownloadsCurrent(t *testing.T) {
+	store := newMemoryLibrary()
+	files := &memoryFiles{files: map[string][]byte{}}
+	resource := createResource(t, store, "teacher-1", entities.ResourceKindDocument, "")
+
+	first, err := upload(store, files, resource.ID, "teacher-1", "v1")
+	assert.NoError(t, err)
+	second, err := upload(store, files, resource.ID, "teacher-1", "v2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, first.Version)
+	assert.Equal(t, 2, second.Version)
+	assert.Len(t, first.Checksum, 64)
+	assert.NotEqual(t, first.Checksum, second.Checksum)
+
+	download := download_resource_version_use_case.NewDownloadResourceVersionUseCase(store, store, files)
+	cmd, err := download_resource_version_use_case.NewDownloadResourceVersionCommand(tenantID, resource.ID, "teacher-1", 2, false, "")
+	assert.NoError(t, err)
+	_, _, err = download.Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, appErrors.FileQuarantined.String()))
+
+	store.scanClean(first)
+	store.scanClean(second)
+	for version, expected := range map[int]string{0: "v2", 1: "v1"} {
+		cmd, err := download_resource_version_use_case.NewDownloadResourceVersionCommand(tenantID, resource.ID, "teacher-1", version, false, "")
+		assert.NoError(t, err)
+		_, content, err := download.Execute(context.Background(), cmd)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(content))
+	}
+
+	getCmd, err := get_resource_use_case.NewGetResourceCommand(tenantID, resource.ID, "head-1")
+	assert.NoError(t, err)
+	details, err := get_resource_use_case.NewGetResourceUseCase(store, store).Execute(context.Background(), getCmd)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, []int{details.Versions[0].Version, details.Versions[1].Version})
+}
+
+func TestUploadResourceVersion_OnlyTheOwnerOrPublishers(t *testing.T) {
+	store := newMemoryLibrary()
+	files := &memoryFiles{files: map[string][]byte{}}
+	resource := createResource(t, store, "teacher-1", entities.ResourceKindDocument, "")
+
+	_, err := upload(store, files, resource.ID, "teacher-2", "v1")
+	assert.True(t, hasCode(err, libraryErrors.ResourceNotFoundError.String()))
+	_, err = upload(store, files, resource.ID, "head-1", "v1")
+	assert.NoError(t, err)
+}
+
+func TestUploadResourceVersion_ImagesNeedAltTextAndVideosWaitForCaptions(t *testing.T) {
+	store := newMemoryLibrary()
+	files := &memoryFiles{files: map[string][]byte{}}
+	resource := createResource(t, store, "teacher-1", entities.ResourceKindVideo, "")
+	uploadAs := func(fileName string, contentType string, altText string) (*entities.ResourceVersion, error) {
+		cmd, err := upload_resource_version_use_case.NewUploadResourceVersionCommand(tenantID, resource.ID, "teacher-1", fileName,
+			contentType, []byte("content"), altText)
+		if err != nil {
+			return nil, err
+		}
+		return upload_resource_version_use_case.NewUploadResourceVersionUseCase(store, store, files).Execute(context.Background(), cmd)
+	}
+
+	_, err := uploadAs("chart.png", "image/png", "  ")
+	assert.True(t, hasCode(err, appErrors.ValidationError.String()))
+	image, err := uploadAs("chart.png", "image/png", "Bar chart of the class grades")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bar chart of the class grades", image.AltText)
+	assert.Equal(t, storage.CaptionNotNeeded, image.CaptionStatus)
+
+	video, err := uploadAs("lesson.mp4", "video/mp4", "")
+	assert.NoError(t, err)
+	assert.Equal(t, storage.CaptionPending, video.CaptionStatus)
+	store.scanClean(video)
+
+	download := download_resource_version_use_case.NewDownloadResourceVersionUseCase(store, store, files)
+	cmd, err := download_resource_version_use_case.NewDownloadResourceVersionCommand(tenantID, resource.ID, "teacher-1", 0, false,
+		storage.AlternativeTranscript)
+	assert.NoError(t, err)
+	_, _, err = download.Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, appErrors.AlternativeNotAvailable.String()), "captions are generated after the scan")
+
+	video.CaptionStatus = storage.CaptionReady
+	files.files[storage.AlternativeTranscript.Key(video.StorageKey())] = []byte("Today we add fractions")
+	_, content, err := download.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Today we add fractions", string(content))
+
+	_, err = download_resource_version_use_case.NewDownloadResourceVersionCommand(tenantID, resource.ID, "teacher-1", 0, true,
+		storage.AlternativeCaptions)
+	assert.True(t, hasCode(err, appErrors.ValidationError.String()), "a download is either the preview or an alternative")
+}
+
+func TestUploadResourceVersion_LinksDoNotTakeFiles(t *testing.T) {
+	store := newMemoryLibrary()
+	files := &memoryFiles{files: map[string][]byte{}}
+	resource := createResource(t, store, "teacher-1", entities.ResourceKindLink, "https://example.com/fractions")
+
+	_, err := upload(store, files, resource.ID, "teacher-1", "v1")
+	assert.Error(t, err)
+	assert.Empty(t, files.files)
+}
+
+func TestArchivedResources_AreReadOnlyAndHidden(t *testing.T) {
+	store := newMemoryLibrary()
+	resource := createResource(t, store, "teacher-1", entities.ResourceKindLink, "https://example.com/fractions")
+	_, err := changeStatus(store, resource.ID, "head-1", change_resource_status_use_case.ResourceActionPublish)
+	assert.NoError(t, err)
+	_, err = changeStatus(store, resource.ID, "head-1", change_resource_status_use_case.ResourceActionArchive)
+	assert.NoError(t, err)
+
+	cmd, err := update_resource_use_case.NewUpdateResourceCommand(tenantID, resource.ID, "head-1", "Fractions II", "", "", "", nil,
+		"https://example.com/fractions")
+	assert.NoError(t, err)
+	_, err = update_resource_use_case.NewUpdateResourceUseCase(store, store).Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, libraryErrors.ResourceNotEditableError.String()))
+
+	assert.Empty(t, listResources(t, store, "student-1", ""))
+	assert.Len(t, listResources(t, store, "head-1", ""), 1)
+}
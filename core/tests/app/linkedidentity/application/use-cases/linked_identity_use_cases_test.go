@@ -0,0 +1,209 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	link_identity_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/link-identity-use-case"
+	remove_password_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/remove-password-use-case"
+	resolve_linked_identity_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/resolve-linked-identity-use-case"
+	unlink_identity_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/unlink-identity-use-case"
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	linkedIdentityErrors "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const userID = "0b7e2c1a-3f4d-4e5a-8b6c-7d8e9f0a1b2c"
+
+// memoryIdentities keeps the credentials of the users it knows, stale is what Unlink and
+// RemovePassword see when another removal committed after the service read the credentials
+type memoryIdentities struct {
+	credentials map[string]*entities.Credentials
+	stale       bool
+}
+
+func (m *memoryIdentities) Credentials(_ context.Context, userID string) (*entities.Credentials, error) {
+	credentials, ok := m.credentials[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *credentials
+	return &copied, nil
+}
+
+func (m *memoryIdentities) MemberCredentials(ctx context.Context, _ string, userID string) (*entities.Credentials, error) {
+	return m.Credentials(ctx, userID)
+}
+
+func (m *memoryIdentities) Link(_ context.Context, identity *entities.LinkedIdentity) (bool, error) {
+	for _, credentials := range m.credentials {
+		if slices.ContainsFunc(credentials.Identities, func(linked *entities.LinkedIdentity) bool {
+			return linked.Provider == identity.Provider && linked.Issuer == identity.Issuer && linked.Subject == identity.Subject
+		}) {
+			return false, nil
+		}
+	}
+	credentials := m.credentials[identity.UserID]
+	credentials.Identities = append(credentials.Identities, identity)
+	return true, nil
+}
+
+func (m *memoryIdentities) Unlink(_ context.Context, userID string, identityID string) (bool, error) {
+	credentials := m.credentials[userID]
+	if m.stale || credentials.Count() <= 1 {
+		return false, nil
+	}
+	credentials.Identities = slices.DeleteFunc(credentials.Identities, func(identity *entities.LinkedIdentity) bool {
+		return identity.ID == identityID
+	})
+	return true, nil
+}
+
+func (m *memoryIdentities) RemovePassword(_ context.Context, userID string) (bool, error) {
+	credentials := m.credentials[userID]
+	if m.stale || credentials.Count() <= 1 {
+		return false, nil
+	}
+	credentials.HasPassword = false
+	return true, nil
+}
+
+func (m *memoryIdentities) Resolve(_ context.Context, provider entities.Provider, issuer string, subject string, at time.Time) (*entities.LinkedIdentity, error) {
+	for _, credentials := range m.credentials {
+		for _, identity := range credentials.Identities {
+			if identity.Provider == provider && identity.Issuer == issuer && identity.Subject == subject {
+				identity.LastUsedAt = &at
+				return identity, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// fakeTokens verifies tokens "valid:<subject>" as issued by https://idp.example.com to the subject
+type fakeTokens struct{}
+
+func (fakeTokens) Verify(_ context.Context, provider entities.Provider, token string) (*entities.VerifiedIdentity, error) {
+	subject, ok := strings.CutPrefix(token, "valid:")
+	if !ok {
+		return nil, linkedIdentityErrors.NewInvalidIdentityTokenError(string(provider), "signature mismatch")
+	}
+	return &entities.VerifiedIdentity{Provider: provider, Issuer: "https://idp.example.com", Subject: subject, Email: subject + "@example.com"}, nil
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func newIdentities(hasPassword bool) *memoryIdentities {
+	return &memoryIdentities{credentials: map[string]*entities.Credentials{
+		userID: {UserID: userID, HasPassword: hasPassword},
+	}}
+}
+
+func link(t *testing.T, identities *memoryIdentities, provider string, subject string) *entities.LinkedIdentity {
+	t.Helper()
+	command, err := link_identity_use_case.NewLinkIdentityCommand(userID, provider, "valid:"+subject)
+	assert.NoError(t, err)
+	identity, err := link_identity_use_case.NewLinkIdentityUseCase(identities, fakeTokens{}).Execute(context.Background(), command)
+	assert.NoError(t, err)
+	return identity
+}
+
+func TestLinkIdentity_LinksEachIdentityToOneUser(t *testing.T) {
+	identities := newIdentities(true)
+	identities.credentials["other-user"] = &entities.Credentials{UserID: "other-user", HasPassword: true}
+	linked := link(t, identities, "google", "sub-1")
+	assert.Equal(t, "https://idp.example.com", linked.Issuer)
+	assert.Equal(t, "sub-1@example.com", linked.Email)
+
+	command, err := link_identity_use_case.NewLinkIdentityCommand("other-user", "google", "valid:sub-1")
+	assert.NoError(t, err)
+	_, err = link_identity_use_case.NewLinkIdentityUseCase(identities, fakeTokens{}).Execute(context.Background(), command)
+	assert.Equal(t, linkedIdentityErrors.IdentityAlreadyLinkedError.String(), codeOf(err))
+
+	_, err = link_identity_use_case.NewLinkIdentityCommand(userID, "github", "valid:sub-2")
+	assert.Error(t, err)
+}
+
+func TestLinkIdentity_LinksOnlyVerifiedTokens(t *testing.T) {
+	identities := newIdentities(true)
+
+	command, err := link_identity_use_case.NewLinkIdentityCommand(userID, "google", "forged")
+	assert.NoError(t, err)
+	_, err = link_identity_use_case.NewLinkIdentityUseCase(identities, fakeTokens{}).Execute(context.Background(), command)
+	assert.Equal(t, linkedIdentityErrors.InvalidIdentityTokenError.String(), codeOf(err))
+	assert.Empty(t, identities.credentials[userID].Identities)
+}
+
+func TestUnlinkIdentity_KeepsTheLastCredential(t *testing.T) {
+	identities := newIdentities(false)
+	google := link(t, identities, "google", "sub-1")
+	saml := link(t, identities, "saml", "sub-2")
+	unlink := unlink_identity_use_case.NewUnlinkIdentityUseCase(identities)
+
+	command, err := unlink_identity_use_case.NewUnlinkIdentityCommand(userID, google.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, unlink.Execute(context.Background(), command))
+
+	command, err = unlink_identity_use_case.NewUnlinkIdentityCommand(userID, saml.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, linkedIdentityErrors.LastCredentialError.String(), codeOf(unlink.Execute(context.Background(), command)))
+
+	command, err = unlink_identity_use_case.NewUnlinkIdentityCommand(userID, google.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, linkedIdentityErrors.LinkedIdentityNotFoundError.String(), codeOf(unlink.Execute(context.Background(), command)))
+}
+
+func TestRemovePassword_NeedsALinkedIdentity(t *testing.T) {
+	identities := newIdentities(true)
+	removePassword := remove_password_use_case.NewRemovePasswordUseCase(identities)
+
+	command, err := remove_password_use_case.NewRemovePasswordCommand(userID)
+	assert.NoError(t, err)
+	assert.Equal(t, linkedIdentityErrors.LastCredentialError.String(), codeOf(removePassword.Execute(context.Background(), command)))
+
+	link(t, identities, "saml", "sub-1")
+	assert.NoError(t, removePassword.Execute(context.Background(), command))
+	assert.False(t, identities.credentials[userID].HasPassword)
+	assert.Equal(t, linkedIdentityErrors.PasswordNotSetError.String(), codeOf(removePassword.Execute(context.Background(), command)))
+}
+
+func TestRemovePassword_RefusedWhenAConcurrentRemovalLeftItLast(t *testing.T) {
+	identities := newIdentities(true)
+	link(t, identities, "google", "sub-1")
+	identities.stale = true
+
+	command, err := remove_password_use_case.NewRemovePasswordCommand(userID)
+	assert.NoError(t, err)
+	err = remove_password_use_case.NewRemovePasswordUseCase(identities).Execute(context.Background(), command)
+	assert.Equal(t, linkedIdentityErrors.LastCredentialError.String(), codeOf(err))
+}
+
+func TestResolveLinkedIdentity_RefusesUnknownIdentities(t *testing.T) {
+	identities := newIdentities(true)
+	linked := link(t, identities, "google", "sub-1")
+	resolve := resolve_linked_identity_use_case.NewResolveLinkedIdentityUseCase(identities)
+
+	command, err := resolve_linked_identity_use_case.NewResolveLinkedIdentityCommand("google", "https://idp.example.com", "sub-1")
+	assert.NoError(t, err)
+	identity, err := resolve.Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.Equal(t, linked.ID, identity.ID)
+	assert.NotNil(t, identity.LastUsedAt)
+
+	command, err = resolve_linked_identity_use_case.NewResolveLinkedIdentityCommand("google", "https://idp.example.com", "sub-2")
+	assert.NoError(t, err)
+	_, err = resolve.Execute(context.Background(), command)
+	assert.Equal(t, string(appErrors.Unauthorized), codeOf(err))
+}
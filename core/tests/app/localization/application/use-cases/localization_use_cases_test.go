@@ -0,0 +1,149 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	delete_translation_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/delete-translation-use-case"
+	localize_content_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/localize-content-use-case"
+	set_translation_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/set-translation-use-case"
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	localizationErrors "github.com/nahualventure/class-backend/core/app/localization/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantID   = "tenant1"
+	resourceID = "5b0f3a8e-2c1d-4c7e-9a51-0d6f1e2b3c4d"
+	formID     = "8e2d1c4b-7a6f-4e3d-b2c1-a0f9e8d7c6b5"
+	editorID   = "editor-1"
+)
+
+type memoryTranslations struct {
+	translations []*entities.Translation
+	existing     map[string]bool
+}
+
+func newMemoryTranslations() *memoryTranslations {
+	return &memoryTranslations{existing: map[string]bool{resourceID: true, formID: true}}
+}
+
+func (m *memoryTranslations) Save(_ context.Context, translation *entities.Translation) error {
+	m.translations = slices.DeleteFunc(m.translations, func(t *entities.Translation) bool {
+		return t.EntityID == translation.EntityID && t.Locale == translation.Locale
+	})
+	m.translations = append(m.translations, translation)
+	return nil
+}
+
+func (m *memoryTranslations) Delete(_ context.Context, _ string, _ entities.EntityType, entityID string, locale entities.Locale) (bool, error) {
+	before := len(m.translations)
+	m.translations = slices.DeleteFunc(m.translations, func(t *entities.Translation) bool {
+		return t.EntityID == entityID && t.Locale == locale
+	})
+	return len(m.translations) < before, nil
+}
+
+func (m *memoryTranslations) ListByEntity(_ context.Context, _ string, _ entities.EntityType, entityID string) ([]*entities.Translation, error) {
+	var translations []*entities.Translation
+	for _, translation := range m.translations {
+		if translation.EntityID == entityID {
+			translations = append(translations, translation)
+		}
+	}
+	return translations, nil
+}
+
+func (m *memoryTranslations) ListByEntities(_ context.Context, _ string, _ entities.EntityType, entityIDs []string,
+	locales []entities.Locale) ([]*entities.Translation, error) {
+	var translations []*entities.Translation
+	for _, translation := range m.translations {
+		if slices.Contains(entityIDs, translation.EntityID) && slices.Contains(locales, translation.Locale) {
+			translations = append(translations, translation)
+		}
+	}
+	return translations, nil
+}
+
+func (m *memoryTranslations) Exists(_ context.Context, _ string, _ entities.EntityType, entityID string) (bool, error) {
+	return m.existing[entityID], nil
+}
+
+func setTranslation(store *memoryTranslations, entityType string, entityID string, locale string, fields map[string]string) error {
+	cmd, err := set_translation_use_case.NewSetTranslationCommand(tenantID, editorID, entityType, entityID, locale, fields)
+	if err != nil {
+		return err
+	}
+	_, err = set_translation_use_case.NewSetTranslationUseCase(store, store).Execute(context.Background(), cmd)
+	return err
+}
+
+func localize(t *testing.T, store *memoryTranslations, entityType entities.EntityType, entityID string, acceptLanguage string) map[string]string {
+	cmd, err := localize_content_use_case.NewLocalizeContentCommand(tenantID, entityType, []string{entityID}, acceptLanguage)
+	assert.NoError(t, err)
+	localized, err := localize_content_use_case.NewLocalizeContentUseCase(store).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return localized[entityID]
+}
+
+func hasCode(err error, code string) bool {
+	var appErr appErrors.ApplicationError
+	return errors.As(err, &appErr) && appErr.GetCode() == code
+}
+
+func TestParseAcceptLanguage_OrdersByWeight(t *testing.T) {
+	locales := entities.ParseAcceptLanguage("en;q=0.5, ES-gt, fr;q=0, *;q=0.1, es;q=0.8")
+
+	assert.Equal(t, []entities.Locale{"es-GT", "es", "en"}, locales)
+}
+
+func TestFallbackChain_AddsParentsAfterEachLocale(t *testing.T) {
+	chain := entities.FallbackChain([]entities.Locale{"es-GT", "en-US", "es"})
+
+	assert.Equal(t, []entities.Locale{"es-GT", "es", "en-US", "en"}, chain)
+}
+
+func TestSetTranslation_RejectsFieldsTheEntityDoesNotTranslate(t *testing.T) {
+	store := newMemoryTranslations()
+
+	assert.Error(t, setTranslation(store, "resource", resourceID, "es", map[string]string{"prompt:q1": "¿Cómo estás?"}))
+	assert.Error(t, setTranslation(store, "form", formID, "es", map[string]string{"owner": "nadie"}))
+	assert.NoError(t, setTranslation(store, "form", formID, "es", map[string]string{"prompt:q1": "¿Cómo estás?"}))
+}
+
+func TestSetTranslation_RequiresTheEntity(t *testing.T) {
+	store := newMemoryTranslations()
+	err := setTranslation(store, "resource", "0c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f", "es", map[string]string{"title": "Álgebra"})
+
+	assert.True(t, hasCode(err, localizationErrors.TranslatableEntityNotFoundError.String()))
+}
+
+func TestLocalizeContent_FallsBackAlongTheChain(t *testing.T) {
+	store := newMemoryTranslations()
+	assert.NoError(t, setTranslation(store, "resource", resourceID, "es", map[string]string{"title": "Álgebra", "description": "Ejercicios"}))
+	assert.NoError(t, setTranslation(store, "resource", resourceID, "es-GT", map[string]string{"title": "Álgebra básica"}))
+
+	assert.Equal(t, map[string]string{"title": "Álgebra básica", "description": "Ejercicios"},
+		localize(t, store, entities.EntityTypeResource, resourceID, "es-GT, en;q=0.5"))
+	assert.Equal(t, map[string]string{"title": "Álgebra", "description": "Ejercicios"},
+		localize(t, store, entities.EntityTypeResource, resourceID, "es-MX"))
+	assert.Empty(t, localize(t, store, entities.EntityTypeResource, resourceID, "en"))
+	assert.Empty(t, localize(t, store, entities.EntityTypeResource, resourceID, ""))
+}
+
+func TestDeleteTranslation_ReportsMissingLocales(t *testing.T) {
+	store := newMemoryTranslations()
+	assert.NoError(t, setTranslation(store, "form", formID, "en", map[string]string{"title": "Feedback"}))
+
+	cmd, err := delete_translation_use_case.NewDeleteTranslationCommand(tenantID, "form", formID, "EN")
+	assert.NoError(t, err)
+	useCase := delete_translation_use_case.NewDeleteTranslationUseCase(store)
+	assert.NoError(t, useCase.Execute(context.Background(), cmd))
+
+	err = useCase.Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, localizationErrors.TranslationNotFoundError.String()))
+}
@@ -0,0 +1,432 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	messaging_service "github.com/nahualventure/class-backend/core/app/messaging/application/messaging-service"
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+	messagingErrors "github.com/nahualventure/class-backend/core/app/messaging/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantID = "tenant1"
+	classID  = "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f"
+)
+
+type memoryConversations struct {
+	conversations map[string]*entities.Conversation
+	receipts      map[string][]entities.ReadReceipt
+}
+
+func (m *memoryConversations) FindOrCreate(_ context.Context, conversation *entities.Conversation) (*entities.Conversation, error) {
+	for _, existing := range m.conversations {
+		if existing.Kind == conversation.Kind && existing.ClassID == conversation.ClassID &&
+			slices.Equal(existing.ParticipantIDs, conversation.ParticipantIDs) {
+			return existing, nil
+		}
+	}
+	m.conversations[conversation.ID] = conversation
+	return conversation, nil
+}
+
+func (m *memoryConversations) FindByID(_ context.Context, _ string, conversationID string) (*entities.Conversation, error) {
+	return m.conversations[conversationID], nil
+}
+
+func (m *memoryConversations) ListForUser(context.Context, string, string, []string, bool) ([]entities.ConversationSummary, error) {
+	return nil, nil
+}
+
+func (m *memoryConversations) MarkRead(_ context.Context, receipt entities.ReadReceipt) error {
+	m.receipts[receipt.ConversationID] = append(m.receipts[receipt.ConversationID], receipt)
+	return nil
+}
+
+func (m *memoryConversations) ListReceipts(_ context.Context, _ string, conversationID string) ([]entities.ReadReceipt, error) {
+	return m.receipts[conversationID], nil
+}
+
+type memoryMessages struct {
+	messages []*entities.Message
+}
+
+func (m *memoryMessages) Append(_ context.Context, message *entities.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func (m *memoryMessages) FindByID(_ context.Context, _ string, messageID string) (*entities.Message, error) {
+	for _, message := range m.messages {
+		if message.ID == messageID {
+			return message, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryMessages) FindByAttachment(_ context.Context, _ string, attachmentID string) (*entities.Message, error) {
+	for _, message := range m.messages {
+		for _, attachment := range message.Attachments {
+			if attachment.ID == attachmentID {
+				return message, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryMessages) List(_ context.Context, _ string, conversationID string, _ *time.Time, _ int) ([]*entities.Message, error) {
+	var messages []*entities.Message
+	for _, message := range m.messages {
+		if message.ConversationID == conversationID {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+func (m *memoryMessages) Hide(context.Context, *entities.Message) error {
+	return nil
+}
+
+type memoryReports struct {
+	reports map[string]*entities.AbuseReport
+}
+
+func (m *memoryReports) Create(_ context.Context, report *entities.AbuseReport) error {
+	m.reports[report.ID] = report
+	return nil
+}
+
+func (m *memoryReports) FindByID(_ context.Context, _ string, reportID string) (*entities.AbuseReport, error) {
+	return m.reports[reportID], nil
+}
+
+func (m *memoryReports) ListOpen(context.Context, string) ([]*entities.AbuseReport, error) {
+	return nil, nil
+}
+
+func (m *memoryReports) Resolve(context.Context, *entities.AbuseReport) error {
+	return nil
+}
+
+// memoryDirectory knows the role of each user, the students enrolled in the class and the guardian
+// links as guardian -> student
+type memoryDirectory struct {
+	roles     map[string]entities.ParticipantRole
+	enrolled  map[string]bool
+	guardians map[string]string
+}
+
+func (m *memoryDirectory) Participant(_ context.Context, _ string, userID string) (*entities.Participant, error) {
+	role, ok := m.roles[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &entities.Participant{UserID: userID, Role: role}, nil
+}
+
+func (m *memoryDirectory) IsGuardianOf(_ context.Context, _ string, guardianID string, studentID string) (bool, error) {
+	return m.guardians[guardianID] == studentID, nil
+}
+
+func (m *memoryDirectory) LinkGuardian(_ context.Context, _ string, guardianID string, studentID string) error {
+	m.guardians[guardianID] = studentID
+	return nil
+}
+
+func (m *memoryDirectory) ClassAccess(_ context.Context, _ string, _ string, participant entities.Participant) (entities.ClassAccess, error) {
+	return entities.ClassAccess{
+		Enrolled:           m.enrolled[participant.UserID],
+		GuardianOfEnrolled: m.enrolled[m.guardians[participant.UserID]],
+	}, nil
+}
+
+func (m *memoryDirectory) ClassIDs(context.Context, string, entities.Participant) ([]string, error) {
+	return nil, nil
+}
+
+type memoryFiles struct {
+	files map[string][]byte
+}
+
+func (m *memoryFiles) Put(_ context.Context, key string, content []byte) error {
+	m.files[key] = content
+	return nil
+}
+
+func (m *memoryFiles) Get(_ context.Context, key string) ([]byte, error) {
+	return m.files[key], nil
+}
+
+func (m *memoryFiles) Delete(_ context.Context, key string) error {
+	delete(m.files, key)
+	return nil
+}
+
+// newService sets up a teacher, two students of the class, an outsider student and the guardian of
+// the first student
+func newService() (*messaging_service.MessagingService, *memoryFiles) {
+	files := &memoryFiles{files: map[string][]byte{}}
+	directory := &memoryDirectory{
+		roles: map[string]entities.ParticipantRole{
+			"teacher":  entities.ParticipantRoleStaff,
+			"ana":      entities.ParticipantRoleStudent,
+			"luis":     entities.ParticipantRoleStudent,
+			"outsider": entities.ParticipantRoleStudent,
+			"mom":      entities.ParticipantRoleGuardian,
+		},
+		enrolled:  map[string]bool{"ana": true, "luis": true},
+		guardians: map[string]string{"mom": "ana"},
+	}
+	service := messaging_service.NewMessagingService(
+		&memoryConversations{conversations: map[string]*entities.Conversation{}, receipts: map[string][]entities.ReadReceipt{}},
+		&memoryMessages{},
+		&memoryReports{reports: map[string]*entities.AbuseReport{}},
+		directory,
+		files,
+	)
+	return service, files
+}
+
+func sendDirect(service *messaging_service.MessagingService, sender string, recipient string, body string,
+	attachments ...messaging_service.AttachmentUpload) (*entities.Message, error) {
+	cmd, err := messaging_service.NewSendDirectMessageCommand(tenantID, sender, recipient, body, attachments)
+	if err != nil {
+		return nil, err
+	}
+	return service.SendDirectMessage(context.Background(), cmd)
+}
+
+func postToClass(service *messaging_service.MessagingService, sender string, body string) (*entities.Message, error) {
+	cmd, err := messaging_service.NewPostClassMessageCommand(tenantID, sender, classID, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	return service.PostClassMessage(context.Background(), cmd)
+}
+
+func listMessages(service *messaging_service.MessagingService, reader string, conversationID string) ([]entities.MessageView, error) {
+	cmd, err := messaging_service.NewListMessagesCommand(tenantID, reader, conversationID, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return service.ListMessages(context.Background(), cmd)
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func TestSendDirectMessage_AppliesVisibilityRules(t *testing.T) {
+	tests := []struct {
+		sender, recipient string
+		allowed           bool
+	}{
+		{"teacher", "outsider", true},
+		{"ana", "teacher", true},
+		{"mom", "teacher", true},
+		{"mom", "ana", true},
+		{"ana", "mom", true},
+		{"mom", "luis", false},
+		{"ana", "luis", false},
+		{"ana", "stranger", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sender+" to "+tt.recipient, func(t *testing.T) {
+			service, _ := newService()
+			_, err := sendDirect(service, tt.sender, tt.recipient, "hello")
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, messagingErrors.MessagingNotAllowedError.String(), codeOf(err))
+			}
+		})
+	}
+}
+
+func TestSendDirectMessage_ReusesTheConversationOfThePair(t *testing.T) {
+	service, _ := newService()
+
+	first, err := sendDirect(service, "teacher", "ana", "hello")
+	assert.NoError(t, err)
+	reply, err := sendDirect(service, "ana", "teacher", "hi")
+	assert.NoError(t, err)
+
+	assert.Equal(t, first.ConversationID, reply.ConversationID)
+}
+
+func TestClassThread_GuardiansReadButDoNotPost(t *testing.T) {
+	service, _ := newService()
+
+	message, err := postToClass(service, "ana", "when is the exam?")
+	assert.NoError(t, err)
+
+	views, err := listMessages(service, "mom", message.ConversationID)
+	assert.NoError(t, err)
+	assert.Len(t, views, 1)
+
+	_, err = postToClass(service, "mom", "hello class")
+	assert.Equal(t, messagingErrors.MessagingNotAllowedError.String(), codeOf(err))
+
+	_, err = listMessages(service, "outsider", message.ConversationID)
+	assert.Equal(t, messagingErrors.ConversationNotFoundError.String(), codeOf(err))
+}
+
+func TestMarkRead_AddsReadReceipts(t *testing.T) {
+	service, _ := newService()
+	message, err := sendDirect(service, "teacher", "ana", "see you tomorrow")
+	assert.NoError(t, err)
+
+	views, err := listMessages(service, "teacher", message.ConversationID)
+	assert.NoError(t, err)
+	assert.Empty(t, views[0].ReadBy)
+
+	cmd, err := messaging_service.NewMarkReadCommand(tenantID, "ana", message.ConversationID)
+	assert.NoError(t, err)
+	_, err = service.MarkRead(context.Background(), cmd)
+	assert.NoError(t, err)
+
+	views, err = listMessages(service, "teacher", message.ConversationID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ana"}, views[0].ReadBy)
+
+	// a third user cannot mark a direct conversation they are not part of
+	cmd, err = messaging_service.NewMarkReadCommand(tenantID, "luis", message.ConversationID)
+	assert.NoError(t, err)
+	_, err = service.MarkRead(context.Background(), cmd)
+	assert.Equal(t, messagingErrors.ConversationNotFoundError.String(), codeOf(err))
+}
+
+func TestAttachments_AreStoredAndOnlyServedToReaders(t *testing.T) {
+	service, files := newService()
+	upload := messaging_service.AttachmentUpload{FileName: "homework.pdf", ContentType: "application/pdf", Content: []byte("%PDF")}
+
+	message, err := sendDirect(service, "ana", "teacher", "", upload)
+	assert.NoError(t, err)
+	assert.Len(t, message.Attachments, 1)
+	assert.Len(t, files.files, 1)
+
+	cmd, err := messaging_service.NewGetAttachmentCommand(tenantID, "teacher", message.Attachments[0].ID, false, "")
+	assert.NoError(t, err)
+	_, _, err = service.GetAttachment(context.Background(), cmd)
+	assert.Equal(t, appErrors.FileQuarantined.String(), codeOf(err), "attachments wait for their virus scan")
+
+	message.Attachments[0].ScanStatus = storage.ScanClean
+	attachment, content, err := service.GetAttachment(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "homework.pdf", attachment.FileName)
+	assert.Equal(t, []byte("%PDF"), content)
+
+	previewCmd, err := messaging_service.NewGetAttachmentCommand(tenantID, "teacher", message.Attachments[0].ID, true, "")
+	assert.NoError(t, err)
+	_, _, err = service.GetAttachment(context.Background(), previewCmd)
+	assert.Equal(t, appErrors.PreviewNotAvailable.String(), codeOf(err))
+
+	message.Attachments[0].PreviewStatus = storage.PreviewReady
+	files.files[storage.PreviewKey(message.AttachmentKey(message.Attachments[0].ID))] = []byte("PNG")
+	_, content, err = service.GetAttachment(context.Background(), previewCmd)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("PNG"), content)
+
+	cmd, err = messaging_service.NewGetAttachmentCommand(tenantID, "luis", message.Attachments[0].ID, false, "")
+	assert.NoError(t, err)
+	_, _, err = service.GetAttachment(context.Background(), cmd)
+	assert.Equal(t, messagingErrors.ConversationNotFoundError.String(), codeOf(err))
+}
+
+func TestAttachments_ImagesNeedAltTextAndVideosGetCaptions(t *testing.T) {
+	service, files := newService()
+	image := messaging_service.AttachmentUpload{FileName: "map.png", ContentType: "image/png", Content: []byte("PNG")}
+	video := messaging_service.AttachmentUpload{FileName: "lab.mp4", ContentType: "video/mp4", Content: []byte("MP4")}
+
+	_, err := sendDirect(service, "teacher", "ana", "", video, image)
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err))
+	assert.Empty(t, files.files)
+
+	image.AltText = "Map of the field trip route"
+	message, err := sendDirect(service, "teacher", "ana", "", video, image)
+	assert.NoError(t, err)
+	assert.Equal(t, storage.CaptionPending, message.Attachments[0].CaptionStatus)
+	assert.Equal(t, storage.CaptionNotNeeded, message.Attachments[1].CaptionStatus)
+	assert.Equal(t, "Map of the field trip route", message.Attachments[1].AltText)
+
+	message.Attachments[0].ScanStatus = storage.ScanClean
+	cmd, err := messaging_service.NewGetAttachmentCommand(tenantID, "ana", message.Attachments[0].ID, false, storage.AlternativeCaptions)
+	assert.NoError(t, err)
+	_, _, err = service.GetAttachment(context.Background(), cmd)
+	assert.Equal(t, appErrors.AlternativeNotAvailable.String(), codeOf(err))
+
+	message.Attachments[0].CaptionStatus = storage.CaptionReady
+	files.files[storage.AlternativeCaptions.Key(message.AttachmentKey(message.Attachments[0].ID))] = []byte("WEBVTT")
+	_, content, err := service.GetAttachment(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("WEBVTT"), content)
+}
+
+func TestSendDirectMessage_RejectsEmptyMessages(t *testing.T) {
+	service, _ := newService()
+
+	_, err := sendDirect(service, "teacher", "ana", "   ")
+
+	assert.Equal(t, appErrors.DomainEntityValidationError.String(), codeOf(err))
+}
+
+func TestResolveAbuseReport_HidesTheMessageForNonStaff(t *testing.T) {
+	service, _ := newService()
+	message, err := postToClass(service, "luis", "something rude")
+	assert.NoError(t, err)
+
+	reportCmd, err := messaging_service.NewReportMessageCommand(tenantID, "ana", message.ID, "insulting")
+	assert.NoError(t, err)
+	report, err := service.ReportMessage(context.Background(), reportCmd)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.AbuseReportStatusOpen, report.Status)
+
+	resolveCmd, err := messaging_service.NewResolveAbuseReportCommand(tenantID, "teacher", report.ID, "hide")
+	assert.NoError(t, err)
+	report, err = service.ResolveAbuseReport(context.Background(), resolveCmd)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.AbuseReportStatusActioned, report.Status)
+
+	views, err := listMessages(service, "ana", message.ConversationID)
+	assert.NoError(t, err)
+	assert.True(t, views[0].Message.IsHidden())
+	assert.Empty(t, views[0].Message.Body)
+
+	views, err = listMessages(service, "teacher", message.ConversationID)
+	assert.NoError(t, err)
+	assert.Equal(t, "something rude", views[0].Message.Body)
+
+	_, err = service.ResolveAbuseReport(context.Background(), resolveCmd)
+	assert.Equal(t, messagingErrors.AbuseReportAlreadyResolvedError.String(), codeOf(err))
+}
+
+func TestLinkGuardian_RequiresGuardianAndStudentRoles(t *testing.T) {
+	service, _ := newService()
+
+	cmd, err := messaging_service.NewLinkGuardianCommand(tenantID, "teacher", "luis")
+	assert.NoError(t, err)
+	err = service.LinkGuardian(context.Background(), cmd)
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err))
+
+	cmd, err = messaging_service.NewLinkGuardianCommand(tenantID, "mom", "luis")
+	assert.NoError(t, err)
+	assert.NoError(t, service.LinkGuardian(context.Background(), cmd))
+
+	_, err = sendDirect(service, "mom", "luis", "dinner at seven")
+	assert.NoError(t, err)
+}
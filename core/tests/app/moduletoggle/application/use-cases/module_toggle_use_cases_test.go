@@ -0,0 +1,101 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+
+	check_module_enabled_use_case "github.com/nahualventure/class-backend/core/app/moduletoggle/application/use-cases/check-module-enabled-use-case"
+	list_tenant_modules_use_case "github.com/nahualventure/class-backend/core/app/moduletoggle/application/use-cases/list-tenant-modules-use-case"
+	set_module_toggle_use_case "github.com/nahualventure/class-backend/core/app/moduletoggle/application/use-cases/set-module-toggle-use-case"
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/entities"
+	moduleToggleErrors "github.com/nahualventure/class-backend/core/app/moduletoggle/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryToggles keeps the toggles of each tenant by module
+type memoryToggles map[string]map[string]*entities.ModuleToggle
+
+func (m memoryToggles) DisabledModules(_ context.Context, tenantID string) ([]string, error) {
+	var disabled []string
+	for module, toggle := range m[tenantID] {
+		if !toggle.Enabled {
+			disabled = append(disabled, module)
+		}
+	}
+	return disabled, nil
+}
+
+func (m memoryToggles) List(_ context.Context, tenantID string) ([]*entities.ModuleToggle, error) {
+	var toggles []*entities.ModuleToggle
+	for _, toggle := range m[tenantID] {
+		toggles = append(toggles, toggle)
+	}
+	return toggles, nil
+}
+
+func (m memoryToggles) Save(_ context.Context, toggle *entities.ModuleToggle) error {
+	if m[toggle.TenantID] == nil {
+		m[toggle.TenantID] = make(map[string]*entities.ModuleToggle)
+	}
+	m[toggle.TenantID][toggle.Module] = toggle
+	return nil
+}
+
+func toggle(t *testing.T, toggles memoryToggles, tenantID string, module string, enabled bool) error {
+	command, err := set_module_toggle_use_case.NewSetModuleToggleCommand(tenantID, module, enabled, "not purchased")
+	assert.NoError(t, err)
+	_, err = set_module_toggle_use_case.NewSetModuleToggleUseCase(toggles).Execute(context.Background(), command)
+	return err
+}
+
+func checkModule(t *testing.T, toggles memoryToggles, tenantID string, module string) error {
+	command, err := check_module_enabled_use_case.NewCheckModuleEnabledCommand(tenantID, module)
+	assert.NoError(t, err)
+	return check_module_enabled_use_case.NewCheckModuleEnabledUseCase(toggles).Execute(context.Background(), command)
+}
+
+func TestCheckModuleEnabled_RefusesModulesDisabledForTheTenant(t *testing.T) {
+	toggles := memoryToggles{}
+	assert.NoError(t, toggle(t, toggles, "tenant1", "surveys", false))
+
+	err := checkModule(t, toggles, "tenant1", "surveys")
+	var appErr appErrors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, moduleToggleErrors.ModuleDisabledError.String(), appErr.GetCode())
+	assert.Equal(t, "surveys", appErr.GetContext()["module"])
+
+	assert.NoError(t, checkModule(t, toggles, "tenant1", "library"), "modules never toggled are enabled")
+	assert.NoError(t, checkModule(t, toggles, "tenant2", "surveys"), "toggles belong to their tenant")
+
+	assert.NoError(t, toggle(t, toggles, "tenant1", "surveys", true))
+	assert.NoError(t, checkModule(t, toggles, "tenant1", "surveys"))
+}
+
+func TestSetModuleToggle_RefusesModulesEveryTenantNeeds(t *testing.T) {
+	toggles := memoryToggles{}
+
+	err := toggle(t, toggles, "tenant1", "auth", false)
+	var appErr appErrors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, appErrors.DomainEntityValidationError.String(), appErr.GetCode())
+	assert.Empty(t, toggles)
+}
+
+func TestListTenantModules_ListsEveryToggleableModule(t *testing.T) {
+	toggles := memoryToggles{}
+	assert.NoError(t, toggle(t, toggles, "tenant1", "messaging", false))
+
+	command, err := list_tenant_modules_use_case.NewListTenantModulesCommand("tenant1")
+	assert.NoError(t, err)
+	modules, err := list_tenant_modules_use_case.NewListTenantModulesUseCase(toggles).Execute(context.Background(), command)
+	assert.NoError(t, err)
+
+	assert.Len(t, modules, len(entities.ToggleableModules))
+	for i, module := range modules {
+		assert.Equal(t, entities.ToggleableModules[i], module.Module)
+		assert.Equal(t, module.Module != "messaging", module.Enabled, module.Module)
+	}
+}
@@ -0,0 +1,173 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	hold_notifications_use_case "github.com/nahualventure/class-backend/core/app/notification/application/use-cases/hold-notifications-use-case"
+	release_due_digests_use_case "github.com/nahualventure/class-backend/core/app/notification/application/use-cases/release-due-digests-use-case"
+	set_digest_preference_use_case "github.com/nahualventure/class-backend/core/app/notification/application/use-cases/set-digest-preference-use-case"
+	"github.com/nahualventure/class-backend/core/app/notification/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+// guatemala is six hours behind UTC all year
+var guatemala = time.FixedZone("America/Guatemala", -6*60*60)
+
+// memoryNotifications keeps the preferences and the held notifications, and records the digests sent
+type memoryNotifications struct {
+	preferences map[string]*entities.DigestPreference
+	held        []*entities.Notification
+	sent        map[*entities.Notification]bool
+	digests     []*entities.Digest
+}
+
+func newMemoryNotifications() *memoryNotifications {
+	return &memoryNotifications{
+		preferences: map[string]*entities.DigestPreference{},
+		sent:        map[*entities.Notification]bool{},
+	}
+}
+
+func (m *memoryNotifications) Find(_ context.Context, _ string, userID string) (*entities.DigestPreference, error) {
+	return m.preferences[userID], nil
+}
+
+func (m *memoryNotifications) Save(_ context.Context, preference *entities.DigestPreference) error {
+	m.preferences[preference.UserID] = preference
+	return nil
+}
+
+func (m *memoryNotifications) LocationOf(_ context.Context, _ string, _ string) (*time.Location, error) {
+	return guatemala, nil
+}
+
+func (m *memoryNotifications) Hold(_ context.Context, notification *entities.Notification) error {
+	for _, held := range m.held {
+		if held.RecipientID == notification.RecipientID && held.Position == notification.Position {
+			return nil
+		}
+	}
+	m.held = append(m.held, notification)
+	return nil
+}
+
+func (m *memoryNotifications) ListDue(_ context.Context, now time.Time, limit int) ([]*entities.Notification, error) {
+	var recipients []string
+	var due []*entities.Notification
+	for _, held := range m.held {
+		if m.sent[held] || held.DueAt.After(now) {
+			continue
+		}
+		if !slices.Contains(recipients, held.RecipientID) {
+			if len(recipients) == limit {
+				continue
+			}
+			recipients = append(recipients, held.RecipientID)
+		}
+		due = append(due, held)
+	}
+	return due, nil
+}
+
+func (m *memoryNotifications) Send(_ context.Context, digests []*entities.Digest, _ time.Time) error {
+	for _, digest := range digests {
+		for _, notification := range digest.Notifications {
+			m.sent[notification] = true
+		}
+	}
+	m.digests = append(m.digests, digests...)
+	return nil
+}
+
+func setFrequency(t *testing.T, notifications *memoryNotifications, userID string, frequency entities.DigestFrequency) {
+	t.Helper()
+	cmd, err := set_digest_preference_use_case.NewSetDigestPreferenceCommand(tenantID, userID, string(frequency))
+	assert.NoError(t, err)
+	_, err = set_digest_preference_use_case.NewSetDigestPreferenceUseCase(notifications).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+}
+
+func reminder(position int64, occurredAt time.Time) events.Event {
+	return events.Event{
+		Position:    position,
+		Type:        events.AppointmentReminderDue,
+		AggregateID: "appointment-1",
+		TenantID:    tenantID,
+		Payload:     map[string]any{"appointment_id": "appointment-1", "teacher_id": "teacher-1", "student_id": "student-1"},
+		OccurredAt:  occurredAt,
+	}
+}
+
+func hold(t *testing.T, notifications *memoryNotifications, event events.Event) int {
+	t.Helper()
+	held, err := hold_notifications_use_case.NewHoldNotificationsUseCase(notifications, notifications, notifications).Execute(context.Background(), event)
+	assert.NoError(t, err)
+	return held
+}
+
+func TestDigestPreference_DueAtTheEndOfTheWindowOfTheUser(t *testing.T) {
+	// 16:20 in Guatemala
+	receivedAt := time.Date(2025, 10, 6, 22, 20, 0, 0, time.UTC)
+
+	tests := map[entities.DigestFrequency]time.Time{
+		entities.DigestImmediate: receivedAt,
+		entities.DigestHourly:    time.Date(2025, 10, 6, 23, 0, 0, 0, time.UTC),
+		entities.DigestDaily:     time.Date(2025, 10, 7, 13, 0, 0, 0, time.UTC),
+	}
+	for frequency, due := range tests {
+		t.Run(string(frequency), func(t *testing.T) {
+			preference, err := entities.NewDigestPreference(tenantID, "student-1", frequency, receivedAt)
+			assert.NoError(t, err)
+			assert.Equal(t, due, preference.DueAt(receivedAt, guatemala))
+		})
+	}
+
+	daily := entities.DefaultDigestPreference(tenantID, "student-1")
+	daily.Frequency = entities.DigestDaily
+	early := time.Date(2025, 10, 6, 11, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 10, 6, 13, 0, 0, 0, time.UTC), daily.DueAt(early, guatemala), "notifications before 7:00 go out the same morning")
+}
+
+func TestReleaseDueDigests_CoalescesTheNotificationsOfAWindow(t *testing.T) {
+	notifications := newMemoryNotifications()
+	setFrequency(t, notifications, "student-1", entities.DigestDaily)
+	monday := time.Date(2025, 10, 6, 15, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 2, hold(t, notifications, reminder(1, monday)), "the teacher and the student are notified")
+	hold(t, notifications, reminder(2, monday.Add(2*time.Hour)))
+	hold(t, notifications, reminder(3, monday.Add(24*time.Hour)))
+	hold(t, notifications, reminder(3, monday.Add(24*time.Hour)))
+	hold(t, notifications, reminder(4, time.Now()))
+	hold(t, notifications, events.Event{Position: 5, Type: events.AppointmentBooked, TenantID: tenantID,
+		Payload: map[string]any{"student_id": "student-1"}, OccurredAt: monday})
+	assert.Len(t, notifications.held, 8, "events seen again are held once, other events are not notifications")
+
+	sent, err := release_due_digests_use_case.NewReleaseDueDigestsUseCase(notifications).Execute(context.Background())
+	assert.NoError(t, err)
+
+	var teacher, student []*entities.Digest
+	for _, digest := range notifications.digests {
+		if digest.RecipientID == "teacher-1" {
+			teacher = append(teacher, digest)
+		} else {
+			student = append(student, digest)
+		}
+	}
+	assert.Len(t, teacher, 4, "immediate recipients get every notification on its own")
+	assert.Len(t, student, 2, "one digest per day, the one of today is not due yet")
+	assert.Equal(t, sent, len(notifications.digests))
+	assert.Equal(t, entities.DigestDaily, student[0].Frequency)
+	assert.Len(t, student[0].Notifications, 2)
+	assert.Equal(t, time.Date(2025, 10, 7, 13, 0, 0, 0, time.UTC), student[0].DueAt)
+
+	sent, err = release_due_digests_use_case.NewReleaseDueDigestsUseCase(notifications).Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent, "digests are sent once")
+}
@@ -0,0 +1,279 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	book_appointment_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/book-appointment-use-case"
+	cancel_appointment_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/cancel-appointment-use-case"
+	create_availability_slot_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/create-availability-slot-use-case"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	officeHoursErrors "github.com/nahualventure/class-backend/core/app/officehours/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantID  = "tenant1"
+	teacherID = "teacher-1"
+)
+
+// memoryOfficeHours mimics the slot row lock of the Postgres adapter with a mutex, appointments
+// of a failed callback are discarded
+type memoryOfficeHours struct {
+	mu           sync.Mutex
+	slots        map[string]*entities.AvailabilitySlot
+	appointments map[string]*entities.Appointment
+}
+
+func newMemoryOfficeHours() *memoryOfficeHours {
+	return &memoryOfficeHours{
+		slots:        make(map[string]*entities.AvailabilitySlot),
+		appointments: make(map[string]*entities.Appointment),
+	}
+}
+
+func (m *memoryOfficeHours) addSlot(t *testing.T, id string, startsAt time.Time, capacity int) *entities.AvailabilitySlot {
+	t.Helper()
+	slot, err := entities.NewAvailabilitySlot(id, tenantID, teacherID, startsAt, startsAt.Add(30*time.Minute), capacity, "",
+		nil, time.Now().UTC())
+	assert.NoError(t, err)
+	m.slots[id] = slot
+	return slot
+}
+
+func (m *memoryOfficeHours) Create(_ context.Context, slot *entities.AvailabilitySlot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.slots[slot.ID] = slot
+	return nil
+}
+
+func (m *memoryOfficeHours) FindByID(_ context.Context, _ string, slotID string) (*entities.AvailabilitySlot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.slots[slotID], nil
+}
+
+func (m *memoryOfficeHours) ListByTeacher(_ context.Context, _ string, teacherID string, from time.Time,
+	to time.Time) ([]entities.SlotAvailability, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var slots []entities.SlotAvailability
+	for _, slot := range m.slots {
+		if slot.TeacherID == teacherID && !slot.IsCanceled() && slot.Overlaps(from, to) {
+			slots = append(slots, entities.SlotAvailability{Slot: slot, Booked: m.booked(slot.ID)})
+		}
+	}
+	return slots, nil
+}
+
+func (m *memoryOfficeHours) Cancel(context.Context, *entities.AvailabilitySlot) ([]*entities.Appointment, error) {
+	return nil, nil
+}
+
+func (m *memoryOfficeHours) booked(slotID string) int {
+	booked := 0
+	for _, appointment := range m.appointments {
+		if appointment.SlotID == slotID && appointment.IsBooked() {
+			booked++
+		}
+	}
+	return booked
+}
+
+func (m *memoryOfficeHours) WithSlotLocked(_ context.Context, _ string, slotID string,
+	fn func(ledger ports.AppointmentLedger, slot *entities.AvailabilitySlot) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ledger := &memoryLedger{store: m, slotID: slotID}
+	if err := fn(ledger, m.slots[slotID]); err != nil {
+		return err
+	}
+	for _, appointment := range ledger.created {
+		m.appointments[appointment.ID] = appointment
+	}
+	return nil
+}
+
+type memoryLedger struct {
+	store   *memoryOfficeHours
+	slotID  string
+	created []*entities.Appointment
+}
+
+func (l *memoryLedger) CountBooked(context.Context) (int, error) {
+	return l.store.booked(l.slotID), nil
+}
+
+func (l *memoryLedger) ListStudentAppointments(_ context.Context, studentID string, from time.Time,
+	to time.Time) ([]*entities.Appointment, error) {
+	var overlapping []*entities.Appointment
+	for _, appointment := range l.store.appointments {
+		if appointment.StudentID == studentID && appointment.IsBooked() && appointment.Overlaps(from, to) {
+			overlapping = append(overlapping, appointment)
+		}
+	}
+	return overlapping, nil
+}
+
+func (l *memoryLedger) Create(_ context.Context, appointment *entities.Appointment) error {
+	l.created = append(l.created, appointment)
+	return nil
+}
+
+func (m *memoryOfficeHours) FindAppointment(appointmentID string) *entities.Appointment {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.appointments[appointmentID]
+}
+
+// memoryAppointments adapts the store to the appointment repository
+type memoryAppointments struct {
+	store *memoryOfficeHours
+}
+
+func (r memoryAppointments) FindByID(_ context.Context, _ string, appointmentID string) (*entities.Appointment, error) {
+	return r.store.FindAppointment(appointmentID), nil
+}
+
+func (r memoryAppointments) ListForUser(context.Context, string, string, time.Time) ([]*entities.Appointment, error) {
+	return nil, nil
+}
+
+func (r memoryAppointments) Cancel(context.Context, *entities.Appointment) error {
+	return nil
+}
+
+func (r memoryAppointments) QueueDueReminders(context.Context, time.Time, time.Time, int) ([]*entities.Appointment, error) {
+	return nil, nil
+}
+
+func book(t *testing.T, uc *book_appointment_use_case.BookAppointmentUseCase, slotID string, studentID string) (*entities.Appointment, error) {
+	t.Helper()
+	cmd, err := book_appointment_use_case.NewBookAppointmentCommand(tenantID, slotID, studentID, "")
+	assert.NoError(t, err)
+	return uc.Execute(context.Background(), cmd)
+}
+
+func hasCode(err error, code string) bool {
+	var appErr appErrors.ApplicationError
+	return errors.As(err, &appErr) && appErr.GetCode() == code
+}
+
+const (
+	slotID      = "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f"
+	otherSlotID = "0b1c2d3e-4f50-4a6b-8c7d-9e0f1a2b3c4d"
+)
+
+func TestBookAppointment_ConcurrentBookingsDoNotExceedCapacity(t *testing.T) {
+	store := newMemoryOfficeHours()
+	store.addSlot(t, slotID, time.Now().Add(24*time.Hour), 3)
+	uc := book_appointment_use_case.NewBookAppointmentUseCase(store)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	booked, full := 0, 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(studentID string) {
+			defer wg.Done()
+			_, err := book(t, uc, slotID, studentID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				booked++
+			} else if hasCode(err, officeHoursErrors.SlotFullError.String()) {
+				full++
+			}
+		}(fmt.Sprintf("student-%d", i))
+	}
+	wg.Wait()
+
+	assert.Equal(t, 3, booked)
+	assert.Equal(t, 7, full)
+}
+
+func TestBookAppointment_RejectsDoubleBookingsOfTheStudent(t *testing.T) {
+	store := newMemoryOfficeHours()
+	startsAt := time.Now().Add(24 * time.Hour)
+	store.addSlot(t, slotID, startsAt, 2)
+	other := store.addSlot(t, otherSlotID, startsAt.Add(15*time.Minute), 2)
+	other.TeacherID = "teacher-2"
+	uc := book_appointment_use_case.NewBookAppointmentUseCase(store)
+
+	_, err := book(t, uc, slotID, "student-1")
+	assert.NoError(t, err)
+
+	_, err = book(t, uc, slotID, "student-1")
+	assert.True(t, hasCode(err, officeHoursErrors.AppointmentAlreadyBookedError.String()))
+
+	_, err = book(t, uc, otherSlotID, "student-1")
+	assert.True(t, hasCode(err, officeHoursErrors.AppointmentConflictError.String()))
+}
+
+func TestBookAppointment_RejectsPastAndCanceledSlots(t *testing.T) {
+	store := newMemoryOfficeHours()
+	store.addSlot(t, slotID, time.Now().Add(-time.Hour), 1)
+	canceled := store.addSlot(t, otherSlotID, time.Now().Add(time.Hour), 1)
+	canceled.Cancel(time.Now())
+	uc := book_appointment_use_case.NewBookAppointmentUseCase(store)
+
+	_, err := book(t, uc, slotID, "student-1")
+	assert.True(t, hasCode(err, officeHoursErrors.SlotNotBookableError.String()))
+
+	_, err = book(t, uc, otherSlotID, "student-1")
+	assert.True(t, hasCode(err, officeHoursErrors.SlotNotBookableError.String()))
+
+	_, err = book(t, uc, "5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e", "student-1")
+	assert.True(t, hasCode(err, officeHoursErrors.SlotNotFoundError.String()))
+}
+
+func TestCreateAvailabilitySlot_RejectsOverlappingSlots(t *testing.T) {
+	store := newMemoryOfficeHours()
+	startsAt := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	store.addSlot(t, slotID, startsAt, 1)
+	uc := create_availability_slot_use_case.NewCreateAvailabilitySlotUseCase(store)
+
+	cmd, err := create_availability_slot_use_case.NewCreateAvailabilitySlotCommand(tenantID, teacherID,
+		startsAt.Add(15*time.Minute), startsAt.Add(45*time.Minute), 1, "")
+	assert.NoError(t, err)
+	_, err = uc.Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, officeHoursErrors.SlotOverlapError.String()))
+
+	cmd, err = create_availability_slot_use_case.NewCreateAvailabilitySlotCommand(tenantID, teacherID,
+		startsAt.Add(30*time.Minute), startsAt.Add(time.Hour), 1, "")
+	assert.NoError(t, err)
+	_, err = uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err, "adjacent slots may touch")
+}
+
+func TestCancelAppointment_OnlyParticipantsCanCancel(t *testing.T) {
+	store := newMemoryOfficeHours()
+	store.addSlot(t, slotID, time.Now().Add(24*time.Hour), 1)
+	appointment, err := book(t, book_appointment_use_case.NewBookAppointmentUseCase(store), slotID, "student-1")
+	assert.NoError(t, err)
+	uc := cancel_appointment_use_case.NewCancelAppointmentUseCase(memoryAppointments{store: store})
+
+	cmd, err := cancel_appointment_use_case.NewCancelAppointmentCommand(tenantID, appointment.ID, "student-2")
+	assert.NoError(t, err)
+	_, err = uc.Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, officeHoursErrors.AppointmentNotFoundError.String()))
+
+	cmd, err = cancel_appointment_use_case.NewCancelAppointmentCommand(tenantID, appointment.ID, teacherID)
+	assert.NoError(t, err)
+	canceled, err := uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.AppointmentStatusCanceled, canceled.Status)
+	assert.Equal(t, teacherID, canceled.CanceledBy)
+
+	_, err = uc.Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, officeHoursErrors.AppointmentNotActiveError.String()))
+}
@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var startsAt = time.Date(2025, 10, 6, 15, 0, 0, 0, time.UTC)
+
+func appointment(t *testing.T, topic string, location string) *entities.Appointment {
+	t.Helper()
+	slot, err := entities.NewAvailabilitySlot("0b1c2d3e-4f50-4a6b-8c7d-9e0f1a2b3c4d", "tenant1", "teacher-1", startsAt,
+		startsAt.Add(30*time.Minute), 1, location, nil, startsAt.Add(-48*time.Hour))
+	assert.NoError(t, err)
+	a, err := entities.BookAppointment("7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f", slot, "student-1", topic, startsAt.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	return a
+}
+
+func TestCalendarInvite_RendersABookedAppointment(t *testing.T) {
+	invite := appointment(t, "Essay, draft 2; intro", "Room 204").CalendarInvite(startsAt.Add(-time.Hour))
+
+	assert.True(t, strings.HasSuffix(invite, "END:VCALENDAR\r\n"))
+	assert.Contains(t, invite, "METHOD:REQUEST\r\n")
+	assert.Contains(t, invite, "UID:7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f@class-backend\r\n")
+	assert.Contains(t, invite, "DTSTART:20251006T150000Z\r\nDTEND:20251006T153000Z\r\n")
+	assert.Contains(t, invite, `SUMMARY:Office hours: Essay\, draft 2\; intro`+"\r\n")
+	assert.Contains(t, invite, "LOCATION:Room 204\r\n")
+	assert.Contains(t, invite, "TRIGGER:-PT15M\r\n")
+}
+
+func TestCalendarInvite_FoldsLongLines(t *testing.T) {
+	invite := appointment(t, strings.Repeat("é", 60), "").CalendarInvite(startsAt)
+
+	for _, line := range strings.Split(strings.TrimSuffix(invite, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+	unfolded := strings.ReplaceAll(invite, "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:Office hours: "+strings.Repeat("é", 60)+"\r\n")
+	assert.NotContains(t, invite, "LOCATION")
+}
+
+func TestCalendarInvite_CanceledAppointmentsRenderAsCancel(t *testing.T) {
+	a := appointment(t, "", "")
+	a.Cancel("student-1", startsAt.Add(-2*time.Hour))
+
+	invite := a.CalendarInvite(startsAt)
+
+	assert.Contains(t, invite, "METHOD:CANCEL\r\n")
+	assert.Contains(t, invite, "STATUS:CANCELLED\r\n")
+	assert.Contains(t, invite, "SEQUENCE:1\r\n")
+	assert.NotContains(t, invite, "VALARM")
+}
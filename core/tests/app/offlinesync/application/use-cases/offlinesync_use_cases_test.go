@@ -0,0 +1,264 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gradingEntities "github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	gradingPorts "github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	list_changes_use_case "github.com/nahualventure/class-backend/core/app/offlinesync/application/use-cases/list-changes-use-case"
+	push_mutations_use_case "github.com/nahualventure/class-backend/core/app/offlinesync/application/use-cases/push-mutations-use-case"
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/entities"
+	offlineSyncErrors "github.com/nahualventure/class-backend/core/app/offlinesync/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/bulk"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantID     = "tenant-1"
+	classID      = "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f"
+	assignmentID = "5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e"
+)
+
+// memoryGrades only implements what the upload uses, the rest of the repository panics
+type memoryGrades struct {
+	gradingPorts.GradeRepository
+	grades map[string]gradingEntities.Grade
+	saves  int
+}
+
+func (m *memoryGrades) Save(_ context.Context, grade *gradingEntities.Grade) error {
+	grade.ClearPendingEvents()
+	m.grades[grade.ID] = *grade
+	m.saves++
+	return nil
+}
+
+func (m *memoryGrades) FindByStudent(_ context.Context, _ string, classID string, assignmentID string, studentID string) (*gradingEntities.Grade, error) {
+	for _, grade := range m.grades {
+		if grade.ClassID == classID && grade.AssignmentID == assignmentID && grade.StudentID == studentID {
+			return &grade, nil
+		}
+	}
+	return nil, nil
+}
+
+type memoryMutations map[string]entities.AppliedMutation
+
+func (m memoryMutations) Find(_ context.Context, tenantID string, clientMutationID string) (*entities.AppliedMutation, error) {
+	applied, ok := m[tenantID+"/"+clientMutationID]
+	if !ok {
+		return nil, nil
+	}
+	return &applied, nil
+}
+
+func (m memoryMutations) Record(_ context.Context, mutation *entities.AppliedMutation) error {
+	m[mutation.TenantID+"/"+mutation.ClientMutationID] = *mutation
+	return nil
+}
+
+type memoryFeed []*entities.Change
+
+func (f memoryFeed) ListAfter(_ context.Context, _ string, classID string, entityType entities.EntityType, after int64, limit int) ([]*entities.Change, error) {
+	var changes []*entities.Change
+	for _, change := range f {
+		if change.ClassID == classID && change.Sequence > after && (entityType == "" || change.EntityType == entityType) &&
+			len(changes) < limit {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func version(v int) *int {
+	return &v
+}
+
+func gradeMutation(studentID string, score float64, baseVersion *int) entities.GradeMutation {
+	return entities.GradeMutation{
+		ClientMutationID: uuid.NewString(),
+		AssignmentID:     assignmentID,
+		StudentID:        studentID,
+		Score:            score,
+		BaseVersion:      baseVersion,
+	}
+}
+
+func push(t *testing.T, grades *memoryGrades, mutations memoryMutations, batch ...entities.GradeMutation) *bulk.Result[*entities.AppliedMutation] {
+	t.Helper()
+	cmd, err := push_mutations_use_case.NewPushMutationsCommand(tenantID, classID, "teacher-1", batch)
+	assert.NoError(t, err)
+	result, err := push_mutations_use_case.NewPushMutationsUseCase(grades, mutations).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return result
+}
+
+func assertCode(t *testing.T, err error, code appErrors.ErrorCode) appErrors.ApplicationError {
+	t.Helper()
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr), "expected %s, got %v", code, err) {
+		assert.Equal(t, code.String(), appErr.GetCode())
+	}
+	return appErr
+}
+
+func TestPushMutations_AppliesEachMutationOnce(t *testing.T) {
+	grades := &memoryGrades{grades: map[string]gradingEntities.Grade{}}
+	mutations := memoryMutations{}
+
+	mutation := gradeMutation("student-1", 88, nil)
+	result := push(t, grades, mutations, mutation)
+	if assert.Len(t, result.Items, 1) {
+		assert.Equal(t, entities.MutationApplied, result.Items[0].Status)
+		assert.Equal(t, 88.0, result.Items[0].Value.Score)
+	}
+	assert.Equal(t, 1, grades.saves)
+
+	// A device retrying an upload whose answer it lost gets the same mutation back
+	result = push(t, grades, mutations, mutation)
+	if assert.Len(t, result.Items, 1) {
+		assert.Equal(t, entities.MutationAlreadyApplied, result.Items[0].Status)
+		assert.Equal(t, 88.0, result.Items[0].Value.Score)
+	}
+	assert.Equal(t, 1, grades.saves)
+}
+
+func TestPushMutations_ReportsConflictsWithChangesOfTheServer(t *testing.T) {
+	grades := &memoryGrades{grades: map[string]gradingEntities.Grade{}}
+	mutations := memoryMutations{}
+
+	result := push(t, grades, mutations, gradeMutation("student-1", 70, nil))
+	known := result.Items[0].Value.Version
+	result = push(t, grades, mutations, gradeMutation("student-1", 75, version(known)))
+	assert.Equal(t, entities.MutationApplied, result.Items[0].Status)
+	server := result.Items[0].Value.Version
+
+	// Another device still had the first version
+	stale := gradeMutation("student-1", 90, version(known))
+	result = push(t, grades, mutations, stale)
+	appErr := assertCode(t, result.Items[0].Err, offlineSyncErrors.SyncConflictError)
+	if appErr != nil {
+		assert.Equal(t, server, appErr.GetContext()["server_version"])
+		assert.Equal(t, 75.0, appErr.GetContext()["server_score"])
+	}
+
+	// Entering the score the server has already is no conflict
+	result = push(t, grades, mutations, gradeMutation("student-1", 75, version(known)))
+	assert.Equal(t, entities.MutationApplied, result.Items[0].Status)
+
+	stale.ClientMutationID = uuid.NewString()
+	stale.Resolution = entities.ResolutionClientWins
+	result = push(t, grades, mutations, stale)
+	if assert.Equal(t, entities.MutationApplied, result.Items[0].Status) {
+		assert.Equal(t, 90.0, result.Items[0].Value.Score)
+		assert.Greater(t, result.Items[0].Value.Version, server)
+	}
+}
+
+func TestPushMutations_RebasesLaterEditsOfTheSameGrade(t *testing.T) {
+	grades := &memoryGrades{grades: map[string]gradingEntities.Grade{}}
+	mutations := memoryMutations{}
+	result := push(t, grades, mutations, gradeMutation("student-1", 60, nil))
+	known := result.Items[0].Value.Version
+
+	invalid := gradeMutation("student-2", 140, nil)
+	result = push(t, grades, mutations,
+		gradeMutation("student-1", 65, version(known)),
+		invalid,
+		gradeMutation("student-1", 68, version(known)))
+
+	assert.Equal(t, entities.MutationApplied, result.Items[0].Status)
+	assertCode(t, result.Items[1].Err, appErrors.ValidationError)
+	if assert.Equal(t, entities.MutationApplied, result.Items[2].Status) {
+		assert.Equal(t, 68.0, result.Items[2].Value.Score)
+	}
+	assert.Equal(t, 1, result.Failed())
+}
+
+func TestListChanges_PagesThroughTheFeedOfTheClass(t *testing.T) {
+	feed := memoryFeed{
+		{ClassID: classID, EntityType: entities.EntityEnrollment, EntityID: "student-1", Sequence: 3},
+		{ClassID: classID, EntityType: entities.EntityGrade, EntityID: "grade-1", Sequence: 5},
+		{ClassID: classID, EntityType: entities.EntityEnrollment, EntityID: "student-2", Sequence: 8},
+	}
+	uc := list_changes_use_case.NewListChangesUseCase(feed)
+
+	cmd, err := list_changes_use_case.NewListChangesCommand(tenantID, classID, "", 0, 2)
+	assert.NoError(t, err)
+	page, err := uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Len(t, page.Changes, 2)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, int64(5), page.Next)
+
+	cmd, err = list_changes_use_case.NewListChangesCommand(tenantID, classID, "", page.Next, 2)
+	assert.NoError(t, err)
+	page, err = uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Len(t, page.Changes, 1)
+	assert.False(t, page.HasMore)
+	assert.Equal(t, int64(8), page.Next)
+
+	// A device that is up to date keeps its sequence
+	cmd, err = list_changes_use_case.NewListChangesCommand(tenantID, classID, "enrollment", 8, 0)
+	assert.NoError(t, err)
+	page, err = uc.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Empty(t, page.Changes)
+	assert.Equal(t, int64(8), page.Next)
+
+	_, err = list_changes_use_case.NewListChangesCommand(tenantID, classID, "rubric", 0, 0)
+	assert.Error(t, err)
+}
+
+func TestChangesFromEvent_ProjectsRostersAttendanceAndGrades(t *testing.T) {
+	event := func(eventType string, payload map[string]any) events.Event {
+		return events.Event{Position: 42, Type: eventType, TenantID: tenantID, Payload: payload, OccurredAt: time.Now()}
+	}
+
+	changes := entities.ChangesFromEvent(event(events.EnrollmentRemoved, map[string]any{"class_id": classID, "student_id": "student-1"}))
+	if assert.Len(t, changes, 1) {
+		assert.True(t, changes[0].Deleted)
+		assert.Equal(t, "student-1", changes[0].EntityID)
+		assert.Equal(t, int64(42), changes[0].Position)
+	}
+
+	changes = entities.ChangesFromEvent(event(events.SectionStudentsMoved, map[string]any{
+		"source_class_id": classID, "target_class_id": "target", "students": []any{"student-1", "student-2"},
+	}))
+	if assert.Len(t, changes, 4) {
+		assert.Equal(t, classID, changes[0].ClassID)
+		assert.True(t, changes[0].Deleted)
+		assert.Equal(t, "target", changes[1].ClassID)
+		assert.False(t, changes[1].Deleted)
+	}
+
+	changes = entities.ChangesFromEvent(event(events.AttendanceRecorded, map[string]any{
+		"class_id": classID, "session_id": "session-1", "student_id": "student-1", "present": true,
+	}))
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, "session-1:student-1", changes[0].EntityID)
+		assert.Equal(t, true, changes[0].Data["present"])
+	}
+
+	changes = entities.ChangesFromEvent(event(events.GradeRecorded, map[string]any{
+		"class_id": classID, "student_id": "student-1", "grade_id": "grade-1", "assignment_id": assignmentID,
+		"score": 91.5, "version": 4.0,
+	}))
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, entities.EntityGrade, changes[0].EntityType)
+		assert.Equal(t, 4, changes[0].Data["version"])
+	}
+
+	// Grades released before the payload had the grade cannot be synced
+	assert.Empty(t, entities.ChangesFromEvent(event(events.GradeRecorded, map[string]any{
+		"class_id": classID, "student_id": "student-1", "score": 91.5,
+	})))
+}
@@ -0,0 +1,106 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+
+	get_operation_use_case "github.com/nahualventure/class-backend/core/app/operation/application/use-cases/get-operation-use-case"
+	"github.com/nahualventure/class-backend/core/app/operation/domain/entities"
+	operationErrors "github.com/nahualventure/class-backend/core/app/operation/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memorySource keeps the operations of one kind per tenant
+type memorySource struct {
+	kind       entities.Kind
+	operations map[string]map[string]*entities.Operation
+}
+
+func (m *memorySource) Kind() entities.Kind {
+	return m.kind
+}
+
+func (m *memorySource) Find(_ context.Context, tenantID string, operationID string) (*entities.Operation, error) {
+	return m.operations[tenantID][operationID], nil
+}
+
+// memoryPermissions lets users view the kinds they were granted
+type memoryPermissions map[string][]entities.Kind
+
+func (m memoryPermissions) CanView(_ string, userID string, kind entities.Kind) (bool, error) {
+	for _, granted := range m[userID] {
+		if granted == kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, code, appErr.GetCode())
+	}
+}
+
+func newOperation(kind entities.Kind) *entities.Operation {
+	return &entities.Operation{ID: uuid.NewString(), Kind: kind, Status: entities.OperationStatusRunning}
+}
+
+func TestGetOperation_FindsTheOperationOfAnyFeature(t *testing.T) {
+	report, backup := newOperation(entities.KindReport), newOperation(entities.KindBackup)
+	useCase := get_operation_use_case.NewGetOperationUseCase(
+		memoryPermissions{"admin": {entities.KindReport, entities.KindBackup}, "teacher": {entities.KindReport}},
+		&memorySource{kind: entities.KindReport, operations: map[string]map[string]*entities.Operation{"school-a": {report.ID: report}}},
+		&memorySource{kind: entities.KindBackup, operations: map[string]map[string]*entities.Operation{"school-a": {backup.ID: backup}}},
+	)
+	get := func(tenantID string, userID string, operationID string) (*entities.Operation, error) {
+		command, err := get_operation_use_case.NewGetOperationCommand(tenantID, userID, operationID)
+		assert.NoError(t, err)
+		return useCase.Execute(context.Background(), command)
+	}
+
+	operation, err := get("school-a", "admin", backup.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.KindBackup, operation.Kind)
+
+	_, err = get("school-a", "teacher", backup.ID)
+	assertCode(t, err, appErrors.Forbidden.String())
+
+	// Operations of other tenants are not found
+	_, err = get("school-b", "admin", report.ID)
+	assertCode(t, err, operationErrors.OperationNotFoundError.String())
+
+	_, err = get_operation_use_case.NewGetOperationCommand("school-a", "admin", "report-1")
+	assertCode(t, err, appErrors.ValidationError.String())
+}
+
+func TestOperation_Finishes(t *testing.T) {
+	operation := newOperation(entities.KindImport)
+	operation.Progress = entities.ProgressOf(250, 1000)
+	assert.Equal(t, 25, operation.Progress)
+	assert.False(t, operation.IsFinished())
+
+	operation.Succeed(entities.Result{Resource: "/imports/" + operation.ID})
+	assert.Equal(t, entities.OperationStatusSucceeded, operation.Status)
+	assert.Equal(t, 100, operation.Progress)
+	assert.Nil(t, operation.Error)
+
+	failed := newOperation(entities.KindPrintout)
+	failed.Fail("")
+	assert.True(t, failed.IsFinished())
+	assert.Equal(t, entities.OperationFailedError, failed.Error.Code)
+	assert.NotEmpty(t, failed.Error.Message)
+	assert.Nil(t, failed.Result)
+}
+
+func TestProgressOf(t *testing.T) {
+	assert.Equal(t, 0, entities.ProgressOf(0, 0))
+	assert.Equal(t, 0, entities.ProgressOf(5, 0))
+	// A running operation never reports 100, only its feature finishes it
+	assert.Equal(t, 99, entities.ProgressOf(1000, 1000))
+}
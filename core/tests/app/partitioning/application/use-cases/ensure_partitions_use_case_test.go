@@ -0,0 +1,100 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ensure_partitions_use_case "github.com/nahualventure/class-backend/core/app/partitioning/application/use-cases/ensure-partitions-use-case"
+	"github.com/nahualventure/class-backend/core/app/partitioning/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryPartitions keeps the partition names per table and the months of rows in each default partition
+type memoryPartitions struct {
+	partitions    map[string][]string
+	defaultMonths map[string][]time.Time
+}
+
+func newMemoryPartitions() *memoryPartitions {
+	return &memoryPartitions{
+		partitions:    make(map[string][]string),
+		defaultMonths: make(map[string][]time.Time),
+	}
+}
+
+func (m *memoryPartitions) ListPartitions(_ context.Context, table entities.PartitionedTable) ([]string, error) {
+	return append([]string{table.Name + "_default"}, m.partitions[table.Name]...), nil
+}
+
+func (m *memoryPartitions) DefaultPartitionMonths(_ context.Context, table entities.PartitionedTable) ([]time.Time, error) {
+	return m.defaultMonths[table.Name], nil
+}
+
+func (m *memoryPartitions) CreatePartition(_ context.Context, partition *entities.MonthlyPartition) error {
+	var kept []time.Time
+	for _, month := range m.defaultMonths[partition.Table.Name] {
+		if month.Equal(partition.Month) {
+			partition.MovedRows++
+		} else {
+			kept = append(kept, month)
+		}
+	}
+	m.defaultMonths[partition.Table.Name] = kept
+	m.partitions[partition.Table.Name] = append(m.partitions[partition.Table.Name], partition.Name())
+	return nil
+}
+
+func ensurePartitions(t *testing.T, manager *memoryPartitions, now time.Time) []*entities.MonthlyPartition {
+	t.Helper()
+	cmd, err := ensure_partitions_use_case.NewEnsurePartitionsCommand(now, 2)
+	assert.NoError(t, err)
+	partitions, err := ensure_partitions_use_case.NewEnsurePartitionsUseCase(manager).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return partitions
+}
+
+func TestMonthlyPartition_NameAndBounds(t *testing.T) {
+	// Months are UTC, late on Dec 31 in Guatemala is already January
+	partition := entities.NewMonthlyPartition(entities.MonthlyPartitionedTables[0],
+		time.Date(2025, time.December, 31, 20, 0, 0, 0, time.FixedZone("CST", -6*60*60)))
+
+	assert.Equal(t, "outbox_events_2026_01", partition.Name())
+	assert.Equal(t, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), partition.From())
+	assert.Equal(t, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), partition.To())
+}
+
+func TestEnsurePartitions_CreatesComingMonths(t *testing.T) {
+	manager := newMemoryPartitions()
+	now := time.Date(2025, time.November, 15, 0, 0, 0, 0, time.UTC)
+
+	created := ensurePartitions(t, manager, now)
+
+	assert.Len(t, created, 3*len(entities.MonthlyPartitionedTables))
+	assert.Equal(t, []string{"outbox_events_2025_11", "outbox_events_2025_12", "outbox_events_2026_01"}, manager.partitions["outbox_events"])
+	assert.Equal(t, []string{"tenant_daily_active_users_2025_11", "tenant_daily_active_users_2025_12", "tenant_daily_active_users_2026_01"},
+		manager.partitions["tenant_daily_active_users"])
+
+	// A month later only the new last month is missing
+	created = ensurePartitions(t, manager, now.AddDate(0, 1, 0))
+	assert.Len(t, created, len(entities.MonthlyPartitionedTables))
+	assert.Equal(t, "outbox_events_2026_02", created[0].Name())
+}
+
+func TestEnsurePartitions_MovesRowsOutOfTheDefaultPartition(t *testing.T) {
+	manager := newMemoryPartitions()
+	september := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	manager.defaultMonths["outbox_events"] = []time.Time{september, september, september.AddDate(0, 1, 0)}
+
+	created := ensurePartitions(t, manager, time.Date(2025, time.October, 3, 0, 0, 0, 0, time.UTC))
+
+	moved := make(map[string]int)
+	for _, partition := range created {
+		moved[partition.Name()] = partition.MovedRows
+	}
+	assert.Equal(t, 2, moved["outbox_events_2025_09"])
+	assert.Equal(t, 1, moved["outbox_events_2025_10"])
+	assert.Equal(t, 0, moved["outbox_events_2025_11"])
+	assert.Empty(t, manager.defaultMonths["outbox_events"])
+}
@@ -0,0 +1,109 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	acquire_edit_lock_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/acquire-edit-lock-use-case"
+	get_edit_lock_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/get-edit-lock-use-case"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	presenceErrors "github.com/nahualventure/class-backend/core/app/presence/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryLocks map[string]entities.EditLock
+
+func (s memoryLocks) Acquire(_ context.Context, lock entities.EditLock) (entities.EditLock, error) {
+	key := lock.TenantID + "/" + lock.EntityType + "/" + lock.EntityID
+	if held, ok := s[key]; ok && !held.HeldBy(lock.UserID) && held.ExpiresAt.After(time.Now()) {
+		return held, nil
+	}
+	s[key] = lock
+	return lock, nil
+}
+
+func (s memoryLocks) Get(_ context.Context, tenantID string, entityType string, entityID string, now time.Time) (*entities.EditLock, error) {
+	held, ok := s[tenantID+"/"+entityType+"/"+entityID]
+	if !ok || !held.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	return &held, nil
+}
+
+func (s memoryLocks) Release(_ context.Context, tenantID string, entityType string, entityID string, userID string) error {
+	key := tenantID + "/" + entityType + "/" + entityID
+	if held, ok := s[key]; ok && held.HeldBy(userID) {
+		delete(s, key)
+	}
+	return nil
+}
+
+const assignmentID = "3f1e2d4c-5b6a-4798-8a9b-0c1d2e3f4a5b"
+
+func acquireCommand(t *testing.T, userID string) *acquire_edit_lock_use_case.AcquireEditLockCommand {
+	cmd, err := acquire_edit_lock_use_case.NewAcquireEditLockCommand("tenant1", userID, "assignment", assignmentID)
+	assert.NoError(t, err)
+	return cmd
+}
+
+func TestAcquireEditLock_NamesTheTeacherAlreadyEditing(t *testing.T) {
+	locks := memoryLocks{}
+	uc := acquire_edit_lock_use_case.NewAcquireEditLockUseCase(locks)
+
+	before := time.Now()
+	lock, err := uc.Execute(context.Background(), acquireCommand(t, "teacher-1"))
+	assert.NoError(t, err)
+	assert.WithinDuration(t, before.Add(entities.EditLockTTL), lock.ExpiresAt, time.Second)
+
+	_, err = uc.Execute(context.Background(), acquireCommand(t, "teacher-2"))
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, presenceErrors.EditLockHeldError.String(), appErr.GetCode())
+		assert.Equal(t, "teacher-1", appErr.GetContext()["locked_by"])
+	}
+
+	// The holder renews with the same call
+	_, err = uc.Execute(context.Background(), acquireCommand(t, "teacher-1"))
+	assert.NoError(t, err)
+}
+
+func TestReleaseEditLock_OnlyFreesTheLockOfItsHolder(t *testing.T) {
+	locks := memoryLocks{}
+	uc := acquire_edit_lock_use_case.NewAcquireEditLockUseCase(locks)
+	_, err := uc.Execute(context.Background(), acquireCommand(t, "teacher-1"))
+	assert.NoError(t, err)
+
+	get := get_edit_lock_use_case.NewGetEditLockUseCase(locks)
+	getCmd, err := get_edit_lock_use_case.NewGetEditLockCommand("tenant1", "assignment", assignmentID)
+	assert.NoError(t, err)
+
+	assert.NoError(t, uc.Release(context.Background(), acquireCommand(t, "teacher-2")))
+	lock, err := get.Execute(context.Background(), getCmd)
+	assert.NoError(t, err)
+	if assert.NotNil(t, lock) {
+		assert.Equal(t, "teacher-1", lock.UserID)
+	}
+
+	assert.NoError(t, uc.Release(context.Background(), acquireCommand(t, "teacher-1")))
+	lock, err = get.Execute(context.Background(), getCmd)
+	assert.NoError(t, err)
+	assert.Nil(t, lock)
+
+	_, err = uc.Execute(context.Background(), acquireCommand(t, "teacher-2"))
+	assert.NoError(t, err)
+}
+
+func TestEditLockCommands_RejectUnknownEntityTypes(t *testing.T) {
+	_, err := acquire_edit_lock_use_case.NewAcquireEditLockCommand("tenant1", "teacher-1", "gradebook", assignmentID)
+	assert.Error(t, err)
+	_, err = get_edit_lock_use_case.NewGetEditLockCommand("tenant1", "rubric", "not-a-uuid")
+	assert.Error(t, err)
+	for _, entityType := range entities.LockableEntityTypes {
+		_, err = get_edit_lock_use_case.NewGetEditLockCommand("tenant1", entityType, assignmentID)
+		assert.NoError(t, err, entityType)
+	}
+}
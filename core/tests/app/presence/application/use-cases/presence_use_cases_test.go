@@ -0,0 +1,100 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	list_online_users_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/list-online-users-use-case"
+	record_heartbeat_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/record-heartbeat-use-case"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryStore map[string]map[string]time.Time
+
+func scope(tenantID string, classID string) string {
+	return tenantID + "/" + classID
+}
+
+func (s memoryStore) Touch(_ context.Context, presence entities.Presence) error {
+	for _, key := range []string{scope(presence.TenantID, ""), scope(presence.TenantID, presence.ClassID)} {
+		if s[key] == nil {
+			s[key] = make(map[string]time.Time)
+		}
+		s[key][presence.UserID] = presence.ExpiresAt
+	}
+	return nil
+}
+
+func (s memoryStore) Remove(_ context.Context, tenantID string, classID string, userID string) error {
+	delete(s[scope(tenantID, "")], userID)
+	delete(s[scope(tenantID, classID)], userID)
+	return nil
+}
+
+func (s memoryStore) ListOnline(_ context.Context, tenantID string, classID string, now time.Time) ([]entities.Presence, error) {
+	var online []entities.Presence
+	for userID, expiresAt := range s[scope(tenantID, classID)] {
+		if expiresAt.After(now) {
+			online = append(online, entities.Presence{TenantID: tenantID, ClassID: classID, UserID: userID, ExpiresAt: expiresAt})
+		}
+	}
+	return online, nil
+}
+
+const classID = "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f"
+
+func TestRecordHeartbeat_ExtendsPresenceByTTL(t *testing.T) {
+	store := memoryStore{}
+	uc := record_heartbeat_use_case.NewRecordHeartbeatUseCase(store)
+	cmd, err := record_heartbeat_use_case.NewRecordHeartbeatCommand("tenant1", "user-1", classID)
+	assert.NoError(t, err)
+
+	before := time.Now()
+	presence, err := uc.Execute(context.Background(), cmd)
+
+	assert.NoError(t, err)
+	assert.WithinDuration(t, before.Add(entities.PresenceTTL), presence.ExpiresAt, time.Second)
+}
+
+func TestListOnlineUsers_ScopesByClassAndTenant(t *testing.T) {
+	store := memoryStore{}
+	heartbeat := record_heartbeat_use_case.NewRecordHeartbeatUseCase(store)
+	inClass, _ := record_heartbeat_use_case.NewRecordHeartbeatCommand("tenant1", "user-1", classID)
+	tenantOnly, _ := record_heartbeat_use_case.NewRecordHeartbeatCommand("tenant1", "user-2", "")
+	_, _ = heartbeat.Execute(context.Background(), inClass)
+	_, _ = heartbeat.Execute(context.Background(), tenantOnly)
+
+	uc := list_online_users_use_case.NewListOnlineUsersUseCase(store)
+
+	classCmd, _ := list_online_users_use_case.NewListOnlineUsersCommand("tenant1", classID)
+	online, err := uc.Execute(context.Background(), classCmd)
+	assert.NoError(t, err)
+	assert.Len(t, online, 1)
+	assert.Equal(t, "user-1", online[0].UserID)
+
+	tenantCmd, _ := list_online_users_use_case.NewListOnlineUsersCommand("tenant1", "")
+	online, err = uc.Execute(context.Background(), tenantCmd)
+	assert.NoError(t, err)
+	assert.Len(t, online, 2)
+}
+
+func TestLeave_RemovesPresence(t *testing.T) {
+	store := memoryStore{}
+	uc := record_heartbeat_use_case.NewRecordHeartbeatUseCase(store)
+	cmd, _ := record_heartbeat_use_case.NewRecordHeartbeatCommand("tenant1", "user-1", classID)
+	_, _ = uc.Execute(context.Background(), cmd)
+
+	assert.NoError(t, uc.Leave(context.Background(), cmd))
+
+	listCmd, _ := list_online_users_use_case.NewListOnlineUsersCommand("tenant1", classID)
+	online, _ := list_online_users_use_case.NewListOnlineUsersUseCase(store).Execute(context.Background(), listCmd)
+	assert.Empty(t, online)
+}
+
+func TestNewRecordHeartbeatCommand_RejectsInvalidClassID(t *testing.T) {
+	_, err := record_heartbeat_use_case.NewRecordHeartbeatCommand("tenant1", "user-1", "not-a-uuid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+
+	generate_file_previews_use_case "github.com/nahualventure/class-backend/core/app/preview/application/use-cases/generate-file-previews-use-case"
+	"github.com/nahualventure/class-backend/core/app/preview/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryPreviews keeps the files pending until their preview is recorded, as the Postgres adapter does
+type memoryPreviews struct {
+	pending  []*entities.PreviewSource
+	statuses map[string]storage.PreviewStatus
+}
+
+func (m *memoryPreviews) ListPending(_ context.Context, limit int) ([]*entities.PreviewSource, error) {
+	return m.pending[:min(limit, len(m.pending))], nil
+}
+
+func (m *memoryPreviews) Record(_ context.Context, preview *entities.FilePreview) (bool, error) {
+	for i, source := range m.pending {
+		if source.ID == preview.Source.ID {
+			m.pending = append(m.pending[:i:i], m.pending[i+1:]...)
+			m.statuses[source.ID] = preview.Status
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type memoryFiles struct {
+	files   map[string][]byte
+	failing string
+}
+
+func (m *memoryFiles) Put(_ context.Context, key string, content []byte) error {
+	m.files[key] = content
+	return nil
+}
+
+func (m *memoryFiles) Get(_ context.Context, key string) ([]byte, error) {
+	if key == m.failing {
+		return nil, errors.New("storage unavailable")
+	}
+	return m.files[key], nil
+}
+
+func (m *memoryFiles) Delete(_ context.Context, key string) error {
+	delete(m.files, key)
+	return nil
+}
+
+// pngRenderer previews PNG images and fails on damaged ones
+type pngRenderer struct{}
+
+func (pngRenderer) Supports(contentType string) bool {
+	return contentType == "image/png"
+}
+
+func (pngRenderer) Render(_ context.Context, _ string, content []byte) ([]byte, error) {
+	if string(content) == "damaged" {
+		return nil, errors.New("not a PNG")
+	}
+	return append([]byte("thumbnail of "), content...), nil
+}
+
+func source(id string, contentType string) *entities.PreviewSource {
+	return &entities.PreviewSource{
+		Kind:        entities.FileKindAttachment,
+		ID:          id,
+		TenantID:    "tenant1",
+		StorageKey:  "messages/tenant1/c1/" + id,
+		ContentType: contentType,
+	}
+}
+
+func TestGenerateFilePreviews_RecordsEveryOutcome(t *testing.T) {
+	previews := &memoryPreviews{statuses: map[string]storage.PreviewStatus{}, pending: []*entities.PreviewSource{
+		source("photo", "image/png"),
+		source("video", "video/mp4"),
+		source("damaged", "image/png"),
+		source("gone", "image/png"),
+	}}
+	files := &memoryFiles{files: map[string][]byte{
+		"messages/tenant1/c1/photo":   []byte("photo"),
+		"messages/tenant1/c1/video":   []byte("video"),
+		"messages/tenant1/c1/damaged": []byte("damaged"),
+	}}
+
+	generated, err := generate_file_previews_use_case.NewGenerateFilePreviewsUseCase(previews, pngRenderer{}, files).Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, generated)
+	assert.Equal(t, map[string]storage.PreviewStatus{
+		"photo":   storage.PreviewReady,
+		"video":   storage.PreviewUnsupported,
+		"damaged": storage.PreviewFailed,
+		"gone":    storage.PreviewFailed,
+	}, previews.statuses)
+	assert.Equal(t, "thumbnail of photo", string(files.files[storage.PreviewKey("messages/tenant1/c1/photo")]))
+	assert.NotContains(t, files.files, storage.PreviewKey("messages/tenant1/c1/damaged"))
+}
+
+func TestGenerateFilePreviews_RetriesFilesThatCannotBeRead(t *testing.T) {
+	previews := &memoryPreviews{statuses: map[string]storage.PreviewStatus{}, pending: []*entities.PreviewSource{
+		source("unreadable", "image/png"),
+		source("photo", "image/png"),
+	}}
+	files := &memoryFiles{failing: "messages/tenant1/c1/unreadable", files: map[string][]byte{
+		"messages/tenant1/c1/photo": []byte("photo"),
+	}}
+
+	generated, err := generate_file_previews_use_case.NewGenerateFilePreviewsUseCase(previews, pngRenderer{}, files).Execute(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, generated, "the rest of the batch is generated")
+	assert.Len(t, previews.pending, 1)
+	assert.Equal(t, "unreadable", previews.pending[0].ID)
+}
@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/preview/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFilePreview(t *testing.T) {
+	source := &entities.PreviewSource{Kind: entities.FileKindAttachment, ID: "file1", TenantID: "tenant1",
+		StorageKey: "tenant1/attachments/file1.pdf", ContentType: "application/pdf"}
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		source *entities.PreviewSource
+		status storage.PreviewStatus
+		valid  bool
+	}{
+		{"ready", source, storage.PreviewReady, true},
+		{"unsupported", source, storage.PreviewUnsupported, true},
+		{"failed", source, storage.PreviewFailed, true},
+		{"still pending", source, storage.PreviewPending, false},
+		{"without source", nil, storage.PreviewReady, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview, err := entities.NewFilePreview(tt.source, tt.status, now)
+			if tt.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.status, preview.Status)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	assert.Equal(t, "tenant1/attachments/file1.pdf.preview.png", source.PreviewKey())
+}
@@ -0,0 +1,236 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	get_printout_use_case "github.com/nahualventure/class-backend/core/app/printout/application/use-cases/get-printout-use-case"
+	render_printout_use_case "github.com/nahualventure/class-backend/core/app/printout/application/use-cases/render-printout-use-case"
+	request_printout_use_case "github.com/nahualventure/class-backend/core/app/printout/application/use-cases/request-printout-use-case"
+	"github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+	printoutErrors "github.com/nahualventure/class-backend/core/app/printout/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryPrintouts claims printouts in the order they were requested
+type memoryPrintouts struct {
+	printouts []*entities.Printout
+	artifacts map[string]*entities.Artifact
+}
+
+func (m *memoryPrintouts) Create(_ context.Context, printout *entities.Printout) error {
+	m.printouts = append(m.printouts, printout)
+	return nil
+}
+
+func (m *memoryPrintouts) FindByID(_ context.Context, tenantID string, printoutID string) (*entities.Printout, error) {
+	for _, printout := range m.printouts {
+		if printout.TenantID == tenantID && printout.ID == printoutID {
+			return printout, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryPrintouts) FindArtifact(_ context.Context, _ string, printoutID string) (*entities.Artifact, error) {
+	return m.artifacts[printoutID], nil
+}
+
+func (m *memoryPrintouts) ClaimNextPending(_ context.Context, _ time.Duration) (*entities.Printout, error) {
+	for _, printout := range m.printouts {
+		if printout.Status == entities.PrintoutStatusPending {
+			printout.Status = entities.PrintoutStatusRunning
+			return printout, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryPrintouts) MarkReady(_ context.Context, printoutID string, artifact *entities.Artifact, expiresAt time.Time) error {
+	for _, printout := range m.printouts {
+		if printout.ID == printoutID {
+			printout.Status = entities.PrintoutStatusReady
+			printout.ExpiresAt = &expiresAt
+		}
+	}
+	m.artifacts[printoutID] = artifact
+	return nil
+}
+
+func (m *memoryPrintouts) MarkFailed(_ context.Context, printoutID string, reason string) error {
+	for _, printout := range m.printouts {
+		if printout.ID == printoutID {
+			printout.Status = entities.PrintoutStatusFailed
+			printout.FailureReason = reason
+		}
+	}
+	return nil
+}
+
+func (m *memoryPrintouts) DeleteExpired(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+type memorySource struct {
+	students []entities.Student
+	meetings []entities.Meeting
+	daysOff  []entities.DayOff
+}
+
+func (m *memorySource) Roster(_ context.Context, _ string, _ string) ([]entities.Student, error) {
+	return m.students, nil
+}
+
+func (m *memorySource) Meetings(_ context.Context, _ string, _ string) ([]entities.Meeting, error) {
+	return m.meetings, nil
+}
+
+func (m *memorySource) DaysOff(_ context.Context, _ string, _ time.Time, _ time.Time) ([]entities.DayOff, error) {
+	return m.daysOff, nil
+}
+
+// rowsRenderer stands in for the PDF layout, the file is the table of the document one row per line
+type rowsRenderer struct{}
+
+func (rowsRenderer) Render(_ context.Context, document *entities.Document) ([]byte, error) {
+	lines := []string{document.Title}
+	for _, row := range document.Rows {
+		lines = append(lines, strings.Join(row, " | "))
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+func day(value string) time.Time {
+	at, _ := time.Parse(time.DateOnly, value)
+	return at
+}
+
+func students(count int) []entities.Student {
+	roster := make([]entities.Student, 0, count)
+	for i := 0; i < count; i++ {
+		roster = append(roster, entities.Student{ID: fmt.Sprintf("student-%d", i), Name: fmt.Sprintf("Student %03d", i)})
+	}
+	return roster
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, code, appErr.GetCode())
+	}
+}
+
+func TestRenderPrintoutUseCase_Execute_WeeklyScheduleKeepsCancelledSessions(t *testing.T) {
+	source := &memorySource{
+		meetings: []entities.Meeting{
+			{Weekday: time.Monday, StartMinute: 8 * 60, EndMinute: 9 * 60},
+			{Weekday: time.Wednesday, StartMinute: 13*60 + 30, EndMinute: 15 * 60},
+		},
+		daysOff: []entities.DayOff{{Date: day("2025-11-26"), Name: "Thanksgiving break"}},
+	}
+	useCase := render_printout_use_case.NewRenderPrintoutUseCase(&memoryPrintouts{}, source, rowsRenderer{},
+		render_printout_use_case.DefaultArtifactTTL)
+	classID := uuid.New().String()
+
+	// Any day of the week prints the week from its Monday
+	command, err := render_printout_use_case.NewRenderPrintoutCommand("tenant1", "weekly_schedule", classID, day("2025-11-28"))
+	assert.NoError(t, err)
+
+	artifact, err := useCase.Execute(context.Background(), command)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "weekly-schedule-"+classID[:8]+"-20251124.pdf", artifact.FileName)
+	assert.Equal(t, "Weekly schedule\n"+
+		"Mon Nov 24 | 08:00 - 09:00 | \n"+
+		"Wed Nov 26 | 13:30 - 15:00 | Cancelled, Thanksgiving break", string(artifact.Content))
+}
+
+func TestRenderPrintoutUseCase_Execute_AttendanceSheetSkipsCancelledSessions(t *testing.T) {
+	source := &memorySource{
+		students: students(2),
+		meetings: []entities.Meeting{
+			{Weekday: time.Tuesday, StartMinute: 10 * 60, EndMinute: 11 * 60},
+			{Weekday: time.Thursday, StartMinute: 10 * 60, EndMinute: 11 * 60},
+		},
+		daysOff: []entities.DayOff{{Date: day("2025-11-27"), Name: "Thanksgiving"}},
+	}
+	useCase := render_printout_use_case.NewRenderPrintoutUseCase(&memoryPrintouts{}, source, rowsRenderer{},
+		render_printout_use_case.DefaultArtifactTTL)
+
+	command, err := render_printout_use_case.NewRenderPrintoutCommand("tenant1", "attendance_sheet", uuid.New().String(), day("2025-11-24"))
+	assert.NoError(t, err)
+
+	artifact, err := useCase.Execute(context.Background(), command)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Attendance sheet\n"+
+		"1 | Student 000 | [   ]\n"+
+		"2 | Student 001 | [   ]", string(artifact.Content))
+}
+
+func TestRenderPrintoutUseCase_Execute_TooLargeRoster(t *testing.T) {
+	source := &memorySource{students: students(entities.SyncRowLimit + 1)}
+	useCase := render_printout_use_case.NewRenderPrintoutUseCase(&memoryPrintouts{}, source, rowsRenderer{},
+		render_printout_use_case.DefaultArtifactTTL)
+
+	command, err := render_printout_use_case.NewRenderPrintoutCommand("tenant1", "roster", uuid.New().String(), day("2025-11-24"))
+	assert.NoError(t, err)
+
+	_, err = useCase.Execute(context.Background(), command)
+
+	assertCode(t, err, printoutErrors.PrintoutTooLargeError.String())
+}
+
+func TestRenderPrintoutUseCase_GenerateNext_GeneratesRequestedPrintout(t *testing.T) {
+	printouts := &memoryPrintouts{artifacts: map[string]*entities.Artifact{}}
+	source := &memorySource{students: students(entities.SyncRowLimit + 1)}
+
+	command, err := request_printout_use_case.NewRequestPrintoutCommand("tenant1", "instructor-1", "roster", uuid.New().String(), day("2025-11-24"))
+	assert.NoError(t, err)
+	requested, err := request_printout_use_case.NewRequestPrintoutUseCase(printouts).Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PrintoutStatusPending, requested.Status)
+
+	get := get_printout_use_case.NewGetPrintoutUseCase(printouts)
+	getCommand, err := get_printout_use_case.NewGetPrintoutCommand("tenant1", requested.ID)
+	assert.NoError(t, err)
+	_, err = get.Download(context.Background(), getCommand)
+	assertCode(t, err, printoutErrors.PrintoutNotReadyError.String())
+
+	// The worker generates printouts over the limit of downloads
+	generate := render_printout_use_case.NewRenderPrintoutUseCase(printouts, source, rowsRenderer{}, render_printout_use_case.DefaultArtifactTTL)
+	processed, err := generate.GenerateNext(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	artifact, err := get.Download(context.Background(), getCommand)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/pdf", artifact.ContentType)
+	assert.Len(t, strings.Split(string(artifact.Content), "\n"), entities.SyncRowLimit+2)
+
+	processed, err = generate.GenerateNext(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, processed)
+}
+
+func TestGetPrintoutUseCase_Download_Expired(t *testing.T) {
+	expiresAt := time.Now().Add(-time.Minute)
+	printout, err := entities.NewPrintout(uuid.New().String(), "tenant1", entities.KindRoster, uuid.New().String(), day("2025-11-24"),
+		entities.PrintoutStatusReady, "", "instructor-1", &expiresAt, time.Now(), time.Now())
+	assert.NoError(t, err)
+	printouts := &memoryPrintouts{printouts: []*entities.Printout{printout}, artifacts: map[string]*entities.Artifact{}}
+
+	command, err := get_printout_use_case.NewGetPrintoutCommand("tenant1", printout.ID)
+	assert.NoError(t, err)
+
+	_, err = get_printout_use_case.NewGetPrintoutUseCase(printouts).Download(context.Background(), command)
+
+	assertCode(t, err, printoutErrors.PrintoutExpiredError.String())
+}
@@ -0,0 +1,187 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	generate_report_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/generate-report-use-case"
+	get_report_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/get-report-use-case"
+	request_report_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/request-report-use-case"
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	reportErrors "github.com/nahualventure/class-backend/core/app/report/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/tests/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newReport(t *testing.T, definition string, parameters map[string]string, status entities.ReportStatus, expiresAt *time.Time) *entities.Report {
+	report, err := entities.NewReport(uuid.New().String(), "tenant1", definition, parameters, status, "", "user-1", "", expiresAt, time.Now(), time.Now())
+	assert.NoError(t, err)
+	return report
+}
+
+func TestRequestReportUseCase_Execute_FillsDefaultParameters(t *testing.T) {
+	repo := &mocks.MockReportRepository{}
+	useCase := request_report_use_case.NewRequestReportUseCase(repo)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Report")).Return(nil)
+
+	command, err := request_report_use_case.NewRequestReportCommand("tenant1", "user-1", entities.InactiveUsersReport, nil)
+	assert.NoError(t, err)
+
+	report, err := useCase.Execute(context.Background(), command)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ReportStatusPending, report.Status)
+	assert.Equal(t, map[string]string{"inactive_days": "90"}, report.Parameters)
+	repo.AssertExpectations(t)
+}
+
+func TestRequestReportUseCase_Execute_RejectsInvalidParameters(t *testing.T) {
+	useCase := request_report_use_case.NewRequestReportUseCase(&mocks.MockReportRepository{})
+
+	command, err := request_report_use_case.NewRequestReportCommand("tenant1", "user-1", entities.GradeDistributionReport,
+		map[string]string{"bucket_size": "zero", "color": "blue"})
+	assert.NoError(t, err)
+
+	_, err = useCase.Execute(context.Background(), command)
+
+	var appErr appErrors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, appErrors.ValidationError.String(), appErr.GetCode())
+	assert.Contains(t, appErr.GetContext(), "bucket_size")
+	assert.Contains(t, appErr.GetContext(), "color")
+}
+
+func TestRequestReportUseCase_Execute_UnknownDefinition(t *testing.T) {
+	useCase := request_report_use_case.NewRequestReportUseCase(&mocks.MockReportRepository{})
+
+	command, err := request_report_use_case.NewRequestReportCommand("tenant1", "user-1", "payroll", nil)
+	assert.NoError(t, err)
+
+	_, err = useCase.Execute(context.Background(), command)
+
+	var appErr appErrors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, reportErrors.UnknownReportDefinitionError.String(), appErr.GetCode())
+}
+
+func TestGenerateReportUseCase_Execute_EmptyQueue(t *testing.T) {
+	repo := &mocks.MockReportRepository{}
+	useCase := generate_report_use_case.NewGenerateReportUseCase(repo, &mocks.MockReportDataSource{}, time.Hour)
+	repo.On("ClaimNextPending", mock.Anything, generate_report_use_case.StaleAfter).Return(nil, nil)
+
+	processed, err := useCase.Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.False(t, processed)
+}
+
+func TestGenerateReportUseCase_Execute_GradeDistribution(t *testing.T) {
+	repo := &mocks.MockReportRepository{}
+	data := &mocks.MockReportDataSource{}
+	useCase := generate_report_use_case.NewGenerateReportUseCase(repo, data, time.Hour)
+
+	report := newReport(t, entities.GradeDistributionReport, map[string]string{"bucket_size": "10"}, entities.ReportStatusRunning, nil)
+	repo.On("ClaimNextPending", mock.Anything, generate_report_use_case.StaleAfter).Return(report, nil)
+	data.On("ClassGrades", mock.Anything, "tenant1").Return([]entities.ClassGradeRow{
+		{ClassID: "a", GradeSum: 170, GradeCount: 2}, // 85
+		{ClassID: "b", GradeSum: 88, GradeCount: 1},  // 88
+		{ClassID: "c", GradeSum: 65, GradeCount: 1},  // 65
+	}, nil)
+
+	var artifact *entities.Artifact
+	repo.On("MarkReady", mock.Anything, report.ID, mock.AnythingOfType("*entities.Artifact"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { artifact = args.Get(2).(*entities.Artifact) }).
+		Return(nil)
+
+	processed, err := useCase.Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, processed)
+	assert.Equal(t, "text/csv", artifact.ContentType)
+	assert.Contains(t, string(artifact.Content), "average_from,average_to,class_count\n")
+	assert.Contains(t, string(artifact.Content), "60,70,1\n70,80,0\n80,90,2\n")
+	repo.AssertExpectations(t)
+}
+
+func TestGenerateReportUseCase_Execute_InactiveUsersOfTheTenant(t *testing.T) {
+	repo := &mocks.MockReportRepository{}
+	data := &mocks.MockReportDataSource{}
+	useCase := generate_report_use_case.NewGenerateReportUseCase(repo, data, time.Hour)
+
+	lastActivityAt := time.Date(2026, 1, 5, 14, 0, 0, 0, time.UTC)
+	report := newReport(t, entities.InactiveUsersReport, map[string]string{"inactive_days": "30"}, entities.ReportStatusRunning, nil)
+	repo.On("ClaimNextPending", mock.Anything, generate_report_use_case.StaleAfter).Return(report, nil)
+	data.On("InactiveUsers", mock.Anything, "tenant1", mock.AnythingOfType("time.Time")).Return([]entities.InactiveUserRow{
+		{ID: "user-1", Name: "Ada", Email: "ada@example.com"},
+		{ID: "user-2", Name: "Grace", Email: "grace@example.com", LastActivityAt: &lastActivityAt},
+	}, nil)
+
+	var artifact *entities.Artifact
+	repo.On("MarkReady", mock.Anything, report.ID, mock.AnythingOfType("*entities.Artifact"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { artifact = args.Get(2).(*entities.Artifact) }).
+		Return(nil)
+
+	processed, err := useCase.Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, processed)
+	assert.Contains(t, string(artifact.Content), "user_id,name,email,last_activity_at\n")
+	assert.Contains(t, string(artifact.Content), "user-1,Ada,ada@example.com,\nuser-2,Grace,grace@example.com,2026-01-05T14:00:00Z\n")
+	data.AssertExpectations(t)
+}
+
+func TestRequestReportUseCase_Execute_RejectsInactiveDaysBeyondTheKeptActivity(t *testing.T) {
+	useCase := request_report_use_case.NewRequestReportUseCase(&mocks.MockReportRepository{})
+
+	command, err := request_report_use_case.NewRequestReportCommand("tenant1", "user-1", entities.InactiveUsersReport,
+		map[string]string{"inactive_days": "365"})
+	assert.NoError(t, err)
+
+	_, err = useCase.Execute(context.Background(), command)
+
+	var appErr appErrors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "Must be at most 180", appErr.GetContext()["inactive_days"])
+}
+
+func TestGenerateReportUseCase_Execute_MarksFailedReports(t *testing.T) {
+	repo := &mocks.MockReportRepository{}
+	data := &mocks.MockReportDataSource{}
+	useCase := generate_report_use_case.NewGenerateReportUseCase(repo, data, time.Hour)
+
+	report := newReport(t, entities.AttendanceSummaryReport, map[string]string{}, entities.ReportStatusRunning, nil)
+	repo.On("ClaimNextPending", mock.Anything, generate_report_use_case.StaleAfter).Return(report, nil)
+	data.On("ClassAttendance", mock.Anything, "tenant1").Return(nil, errors.New("connection reset"))
+	repo.On("MarkFailed", mock.Anything, report.ID, "connection reset").Return(nil)
+
+	processed, err := useCase.Execute(context.Background())
+
+	assert.Error(t, err)
+	assert.True(t, processed)
+	repo.AssertExpectations(t)
+}
+
+func TestGetReportUseCase_Download_Expired(t *testing.T) {
+	repo := &mocks.MockReportRepository{}
+	useCase := get_report_use_case.NewGetReportUseCase(repo)
+
+	expiredAt := time.Now().Add(-time.Minute)
+	report := newReport(t, entities.AttendanceSummaryReport, map[string]string{}, entities.ReportStatusReady, &expiredAt)
+	repo.On("FindByID", mock.Anything, "tenant1", report.ID).Return(report, nil)
+
+	command, err := get_report_use_case.NewGetReportCommand("tenant1", report.ID)
+	assert.NoError(t, err)
+
+	_, err = useCase.Download(context.Background(), command)
+
+	var appErr appErrors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, reportErrors.ReportExpiredError.String(), appErr.GetCode())
+	repo.AssertNotCalled(t, "FindArtifact", mock.Anything, mock.Anything, mock.Anything)
+}
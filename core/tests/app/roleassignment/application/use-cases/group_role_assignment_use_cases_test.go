@@ -0,0 +1,210 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	get_group_role_assignment_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/get-group-role-assignment-use-case"
+	request_group_role_assignment_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/request-group-role-assignment-use-case"
+	run_group_role_assignment_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/run-group-role-assignment-use-case"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	roleAssignmentErrors "github.com/nahualventure/class-backend/core/app/roleassignment/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+type memoryAssignments struct {
+	assignments []*entities.GroupRoleAssignment
+	// saved records the processed count of every progress save
+	saved []int
+}
+
+func (m *memoryAssignments) Create(_ context.Context, assignment *entities.GroupRoleAssignment) error {
+	m.assignments = append(m.assignments, assignment)
+	return nil
+}
+
+func (m *memoryAssignments) FindByID(_ context.Context, tenantID string, assignmentID string) (*entities.GroupRoleAssignment, error) {
+	for _, assignment := range m.assignments {
+		if assignment.TenantID == tenantID && assignment.ID == assignmentID {
+			return assignment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAssignments) List(_ context.Context, _ string, _ int) ([]*entities.GroupRoleAssignment, error) {
+	return m.assignments, nil
+}
+
+func (m *memoryAssignments) ClaimNextPending(_ context.Context, _ time.Duration) (*entities.GroupRoleAssignment, error) {
+	for _, assignment := range m.assignments {
+		if assignment.Status == entities.GroupRoleAssignmentStatusPending {
+			assignment.Status = entities.GroupRoleAssignmentStatusRunning
+			return assignment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAssignments) SaveProgress(_ context.Context, assignment *entities.GroupRoleAssignment) error {
+	m.saved = append(m.saved, assignment.Processed)
+	return nil
+}
+
+func (m *memoryAssignments) MarkCompleted(_ context.Context, assignment *entities.GroupRoleAssignment) error {
+	assignment.Status = entities.GroupRoleAssignmentStatusCompleted
+	return nil
+}
+
+func (m *memoryAssignments) MarkFailed(_ context.Context, assignmentID string, reason string) error {
+	for _, assignment := range m.assignments {
+		if assignment.ID == assignmentID {
+			assignment.Status = entities.GroupRoleAssignmentStatusFailed
+			assignment.FailureReason = reason
+		}
+	}
+	return nil
+}
+
+type memoryGroups struct {
+	members map[string][]string
+}
+
+func (g *memoryGroups) Members(_ context.Context, _ string, _ string, groupID string) ([]string, error) {
+	return g.members[groupID], nil
+}
+
+// memoryRoles writes the roles in chunks of two, and keeps none of them when fail is set
+type memoryRoles struct {
+	assigned map[string]bool
+	fail     bool
+}
+
+func (r *memoryRoles) AvailableRoles() []string {
+	return []string{"admin", "student"}
+}
+
+func (r *memoryRoles) AssignRole(_ context.Context, subjects []string, role string, tenantID string,
+	progress func(processed int, assigned int)) (int, error) {
+	written := make(map[string]bool)
+	for start := 0; start < len(subjects); start += 2 {
+		end := min(start+2, len(subjects))
+		for _, subject := range subjects[start:end] {
+			if key := subject + "/" + role + "/" + tenantID; !r.assigned[key] {
+				written[key] = true
+			}
+		}
+		progress(end, len(written))
+	}
+	if r.fail {
+		return 0, errors.New("connection reset")
+	}
+	for key := range written {
+		r.assigned[key] = true
+	}
+	return len(written), nil
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func requestAssignment(t *testing.T, repo *memoryAssignments, classID string, role string) (*entities.GroupRoleAssignment, error) {
+	t.Helper()
+	cmd, err := request_group_role_assignment_use_case.NewRequestGroupRoleAssignmentCommand(tenantID, "admin-1",
+		entities.GroupTypeClass, classID, role)
+	assert.NoError(t, err)
+	return request_group_role_assignment_use_case.NewRequestGroupRoleAssignmentUseCase(repo, &memoryRoles{}).Execute(context.Background(), cmd)
+}
+
+func TestRequestGroupRoleAssignment_RefusesUnknownRoles(t *testing.T) {
+	repo := &memoryAssignments{}
+
+	_, err := requestAssignment(t, repo, uuid.NewString(), "janitor")
+
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err))
+	assert.Empty(t, repo.assignments)
+
+	_, err = request_group_role_assignment_use_case.NewRequestGroupRoleAssignmentCommand(tenantID, "admin-1", "org_unit",
+		uuid.NewString(), "student")
+	assert.Error(t, err)
+}
+
+func TestRunGroupRoleAssignment_AssignsEveryMemberWithProgress(t *testing.T) {
+	repo := &memoryAssignments{}
+	classID := uuid.NewString()
+	roles := &memoryRoles{assigned: map[string]bool{"user-2/student/" + tenantID: true}}
+	groups := &memoryGroups{members: map[string][]string{classID: {"user-1", "user-2", "user-3", "user-4", "user-5"}}}
+	assignment, err := requestAssignment(t, repo, classID, "student")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.GroupRoleAssignmentStatusPending, assignment.Status)
+
+	useCase := run_group_role_assignment_use_case.NewRunGroupRoleAssignmentUseCase(repo, groups, roles)
+	processed, err := useCase.Execute(context.Background())
+
+	assert.True(t, processed)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.GroupRoleAssignmentStatusCompleted, assignment.Status)
+	assert.Equal(t, 5, assignment.Members)
+	assert.Equal(t, 5, assignment.Processed)
+	assert.Equal(t, 4, assignment.Assigned, "user-2 already had the role")
+	assert.Equal(t, 1.0, assignment.Progress())
+	assert.Equal(t, []int{0, 2, 4, 5}, repo.saved)
+	assert.Len(t, roles.assigned, 5)
+
+	processed, err = useCase.Execute(context.Background())
+	assert.False(t, processed)
+	assert.NoError(t, err)
+}
+
+func TestRunGroupRoleAssignment_AssignsNobodyWhenTheWriteFails(t *testing.T) {
+	repo := &memoryAssignments{}
+	classID := uuid.NewString()
+	roles := &memoryRoles{assigned: map[string]bool{}, fail: true}
+	groups := &memoryGroups{members: map[string][]string{classID: {"user-1", "user-2", "user-3"}}}
+	assignment, err := requestAssignment(t, repo, classID, "student")
+	assert.NoError(t, err)
+
+	processed, err := run_group_role_assignment_use_case.NewRunGroupRoleAssignmentUseCase(repo, groups, roles).
+		Execute(context.Background())
+
+	assert.True(t, processed)
+	assert.Error(t, err)
+	assert.Equal(t, entities.GroupRoleAssignmentStatusFailed, assignment.Status)
+	assert.Contains(t, assignment.FailureReason, "connection reset")
+	assert.Empty(t, roles.assigned)
+}
+
+func TestRunGroupRoleAssignment_CompletesEmptyGroups(t *testing.T) {
+	repo := &memoryAssignments{}
+	assignment, err := requestAssignment(t, repo, uuid.NewString(), "student")
+	assert.NoError(t, err)
+
+	_, err = run_group_role_assignment_use_case.NewRunGroupRoleAssignmentUseCase(repo, &memoryGroups{},
+		&memoryRoles{assigned: map[string]bool{}}).Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.GroupRoleAssignmentStatusCompleted, assignment.Status)
+	assert.Equal(t, 0, assignment.Members)
+	assert.Equal(t, 1.0, assignment.Progress())
+}
+
+func TestGetGroupRoleAssignment_NotFound(t *testing.T) {
+	cmd, err := get_group_role_assignment_use_case.NewGetGroupRoleAssignmentCommand(tenantID, uuid.NewString())
+	assert.NoError(t, err)
+
+	_, err = get_group_role_assignment_use_case.NewGetGroupRoleAssignmentUseCase(&memoryAssignments{}).Execute(context.Background(), cmd)
+
+	assert.Equal(t, roleAssignmentErrors.GroupRoleAssignmentNotFoundError.String(), codeOf(err))
+}
@@ -0,0 +1,247 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	confirm_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/confirm-member-deactivation-use-case"
+	preview_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/preview-member-deactivation-use-case"
+	roll_back_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/roll-back-member-deactivation-use-case"
+	run_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/run-member-deactivation-use-case"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	roleAssignmentErrors "github.com/nahualventure/class-backend/core/app/roleassignment/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryDeactivations struct {
+	deactivations []*entities.MemberDeactivation
+}
+
+func (m *memoryDeactivations) Create(_ context.Context, deactivation *entities.MemberDeactivation) error {
+	m.deactivations = append(m.deactivations, deactivation)
+	return nil
+}
+
+func (m *memoryDeactivations) FindByID(_ context.Context, tenantID string, deactivationID string) (*entities.MemberDeactivation, error) {
+	for _, deactivation := range m.deactivations {
+		if deactivation.TenantID == tenantID && deactivation.ID == deactivationID {
+			return deactivation, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryDeactivations) Confirm(_ context.Context, _ *entities.MemberDeactivation) (bool, error) {
+	return true, nil
+}
+
+func (m *memoryDeactivations) MarkRolledBack(_ context.Context, _ *entities.MemberDeactivation) (bool, error) {
+	return true, nil
+}
+
+func (m *memoryDeactivations) ClaimNextPending(_ context.Context, _ time.Duration) (*entities.MemberDeactivation, error) {
+	for _, deactivation := range m.deactivations {
+		if deactivation.Status == entities.MemberDeactivationStatusPending {
+			deactivation.Status = entities.MemberDeactivationStatusRunning
+			return deactivation, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryDeactivations) SaveRevoked(_ context.Context, _ *entities.MemberDeactivation) error {
+	return nil
+}
+
+func (m *memoryDeactivations) MarkCompleted(_ context.Context, deactivationID string) error {
+	return m.mark(deactivationID, entities.MemberDeactivationStatusCompleted, "")
+}
+
+func (m *memoryDeactivations) MarkFailed(_ context.Context, deactivationID string, reason string) error {
+	return m.mark(deactivationID, entities.MemberDeactivationStatusFailed, reason)
+}
+
+func (m *memoryDeactivations) mark(deactivationID string, status entities.MemberDeactivationStatus, reason string) error {
+	for _, deactivation := range m.deactivations {
+		if deactivation.ID == deactivationID {
+			deactivation.Status = status
+			deactivation.FailureReason = reason
+		}
+	}
+	return nil
+}
+
+// memoryTenantRoles holds "subject/role" assignments of one tenant, a revocation fails without
+// changing any of them when fail is set
+type memoryTenantRoles struct {
+	assignments []string
+	fail        bool
+}
+
+func (r *memoryTenantRoles) Holders(_ context.Context, role string, _ string) ([]string, error) {
+	var holders []string
+	for _, assignment := range r.assignments {
+		if subject, held, _ := strings.Cut(assignment, "/"); held == role {
+			holders = append(holders, subject)
+		}
+	}
+	return holders, nil
+}
+
+func (r *memoryTenantRoles) Of(_ context.Context, subjects []string, _ string) ([]entities.TenantRole, error) {
+	var roles []entities.TenantRole
+	for _, assignment := range r.assignments {
+		if subject, role, _ := strings.Cut(assignment, "/"); slices.Contains(subjects, subject) {
+			roles = append(roles, entities.TenantRole{Subject: subject, Role: role})
+		}
+	}
+	return roles, nil
+}
+
+func (r *memoryTenantRoles) Revoke(_ context.Context, assignments []entities.TenantRole, _ string) error {
+	if r.fail {
+		return errors.New("connection reset")
+	}
+	for _, assignment := range assignments {
+		r.assignments = slices.DeleteFunc(r.assignments, func(held string) bool { return held == assignment.Subject+"/"+assignment.Role })
+	}
+	return nil
+}
+
+func (r *memoryTenantRoles) Restore(_ context.Context, assignments []entities.TenantRole, _ string) error {
+	for _, assignment := range assignments {
+		if held := assignment.Subject + "/" + assignment.Role; !slices.Contains(r.assignments, held) {
+			r.assignments = append(r.assignments, held)
+		}
+	}
+	return nil
+}
+
+func previewDeactivation(t *testing.T, preview *preview_member_deactivation_use_case.PreviewMemberDeactivationUseCase, role string,
+	classID string) (*entities.MemberDeactivation, string) {
+	t.Helper()
+	cmd, err := preview_member_deactivation_use_case.NewPreviewMemberDeactivationCommand(tenantID, "admin-1", role, classID)
+	assert.NoError(t, err)
+	deactivation, token, err := preview.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return deactivation, token
+}
+
+func confirmDeactivation(repo *memoryDeactivations, preview *preview_member_deactivation_use_case.PreviewMemberDeactivationUseCase,
+	deactivationID string, token string) (*entities.MemberDeactivation, error) {
+	cmd, err := confirm_member_deactivation_use_case.NewConfirmMemberDeactivationCommand(tenantID, deactivationID, "admin-1", token)
+	if err != nil {
+		return nil, err
+	}
+	return confirm_member_deactivation_use_case.NewConfirmMemberDeactivationUseCase(repo, preview).Execute(context.Background(), cmd)
+}
+
+func TestMemberDeactivation_PreviewConfirmRunAndRollBack(t *testing.T) {
+	repo := &memoryDeactivations{}
+	classID := uuid.NewString()
+	roles := &memoryTenantRoles{assignments: []string{"user-1/student", "user-1/guardian", "user-2/student", "user-3/student",
+		"admin-1/student", "admin-1/admin"}}
+	groups := &memoryGroups{members: map[string][]string{classID: {"user-1", "user-2", "admin-1"}}}
+	preview := preview_member_deactivation_use_case.NewPreviewMemberDeactivationUseCase(repo, roles, groups, &memoryRoles{})
+
+	deactivation, token := previewDeactivation(t, preview, "student", classID)
+
+	assert.Equal(t, entities.MemberDeactivationStatusPreviewed, deactivation.Status)
+	assert.Equal(t, []string{"user-1", "user-2"}, deactivation.Subjects, "user-3 is not in the class, the requester is kept")
+	assert.Equal(t, map[string]int{"student": 2, "guardian": 1}, deactivation.Roles)
+	assert.NotEmpty(t, token)
+	assert.NotContains(t, deactivation.TokenHash, token)
+	assert.Len(t, roles.assignments, 6, "a preview changes nothing")
+
+	confirmed, err := confirmDeactivation(repo, preview, deactivation.ID, token)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.MemberDeactivationStatusPending, confirmed.Status)
+
+	processed, err := run_member_deactivation_use_case.NewRunMemberDeactivationUseCase(repo, roles).Execute(context.Background())
+	assert.True(t, processed)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.MemberDeactivationStatusCompleted, deactivation.Status)
+	assert.Len(t, deactivation.Revoked, 3)
+	assert.ElementsMatch(t, []string{"user-3/student", "admin-1/student", "admin-1/admin"}, roles.assignments)
+
+	roles.assignments = append(roles.assignments, "user-2/instructor")
+	rollBack, err := roll_back_member_deactivation_use_case.NewRollBackMemberDeactivationCommand(tenantID, deactivation.ID, "admin-1")
+	assert.NoError(t, err)
+	rollBackUseCase := roll_back_member_deactivation_use_case.NewRollBackMemberDeactivationUseCase(repo, roles)
+	rolledBack, err := rollBackUseCase.Execute(context.Background(), rollBack)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.MemberDeactivationStatusRolledBack, rolledBack.Status)
+	assert.Len(t, roles.assignments, 7, "the revoked roles are back and the new one is kept")
+	assert.Contains(t, roles.assignments, "user-1/guardian")
+
+	_, err = rollBackUseCase.Execute(context.Background(), rollBack)
+	assert.Equal(t, roleAssignmentErrors.MemberDeactivationNotCompletedError.String(), codeOf(err))
+}
+
+func TestConfirmMemberDeactivation_NeedsTheTokenOfThePreview(t *testing.T) {
+	repo := &memoryDeactivations{}
+	roles := &memoryTenantRoles{assignments: []string{"user-1/student"}}
+	preview := preview_member_deactivation_use_case.NewPreviewMemberDeactivationUseCase(repo, roles, &memoryGroups{}, &memoryRoles{})
+	deactivation, token := previewDeactivation(t, preview, "student", "")
+
+	_, err := confirmDeactivation(repo, preview, deactivation.ID, token+"x")
+	assert.Equal(t, roleAssignmentErrors.MemberDeactivationTokenInvalidError.String(), codeOf(err))
+
+	deactivation.TokenExpiresAt = time.Now().Add(-time.Second)
+	_, err = confirmDeactivation(repo, preview, deactivation.ID, token)
+	assert.Equal(t, roleAssignmentErrors.MemberDeactivationTokenInvalidError.String(), codeOf(err))
+	assert.Equal(t, entities.MemberDeactivationStatusPreviewed, deactivation.Status)
+
+	_, err = confirmDeactivation(repo, preview, uuid.NewString(), token)
+	assert.Equal(t, roleAssignmentErrors.MemberDeactivationNotFoundError.String(), codeOf(err))
+}
+
+func TestConfirmMemberDeactivation_RefusesWhenTheMembersChanged(t *testing.T) {
+	repo := &memoryDeactivations{}
+	roles := &memoryTenantRoles{assignments: []string{"user-1/student", "user-2/student"}}
+	preview := preview_member_deactivation_use_case.NewPreviewMemberDeactivationUseCase(repo, roles, &memoryGroups{}, &memoryRoles{})
+	deactivation, token := previewDeactivation(t, preview, "student", "")
+
+	roles.assignments = append(roles.assignments, "user-3/student")
+	_, err := confirmDeactivation(repo, preview, deactivation.ID, token)
+
+	assert.Equal(t, roleAssignmentErrors.MemberDeactivationStaleError.String(), codeOf(err))
+	assert.Equal(t, entities.MemberDeactivationStatusPreviewed, deactivation.Status)
+}
+
+func TestRunMemberDeactivation_RevokesNothingWhenTheWriteFails(t *testing.T) {
+	repo := &memoryDeactivations{}
+	roles := &memoryTenantRoles{assignments: []string{"user-1/student", "user-2/student"}}
+	preview := preview_member_deactivation_use_case.NewPreviewMemberDeactivationUseCase(repo, roles, &memoryGroups{}, &memoryRoles{})
+	deactivation, token := previewDeactivation(t, preview, "student", "")
+	_, err := confirmDeactivation(repo, preview, deactivation.ID, token)
+	assert.NoError(t, err)
+	roles.fail = true
+
+	processed, err := run_member_deactivation_use_case.NewRunMemberDeactivationUseCase(repo, roles).Execute(context.Background())
+
+	assert.True(t, processed)
+	assert.Error(t, err)
+	assert.Equal(t, entities.MemberDeactivationStatusFailed, deactivation.Status)
+	assert.Contains(t, deactivation.FailureReason, "connection reset")
+	assert.Len(t, roles.assignments, 2)
+}
+
+func TestPreviewMemberDeactivation_RefusesUnknownRoles(t *testing.T) {
+	repo := &memoryDeactivations{}
+	preview := preview_member_deactivation_use_case.NewPreviewMemberDeactivationUseCase(repo, &memoryTenantRoles{}, &memoryGroups{}, &memoryRoles{})
+	cmd, err := preview_member_deactivation_use_case.NewPreviewMemberDeactivationCommand(tenantID, "admin-1", "janitor", "")
+	assert.NoError(t, err)
+
+	_, _, err = preview.Execute(context.Background(), cmd)
+
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err))
+	assert.Empty(t, repo.deactivations)
+}
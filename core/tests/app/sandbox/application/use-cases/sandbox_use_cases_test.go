@@ -0,0 +1,257 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	create_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/create-tenant-sandbox-use-case"
+	delete_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/delete-tenant-sandbox-use-case"
+	run_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/run-tenant-sandbox-use-case"
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/entities"
+	sandboxErrors "github.com/nahualventure/class-backend/core/app/sandbox/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+type memorySandboxes struct {
+	sandboxes []*entities.TenantSandbox
+}
+
+func (m *memorySandboxes) Create(_ context.Context, sandbox *entities.TenantSandbox) error {
+	m.sandboxes = append(m.sandboxes, sandbox)
+	return nil
+}
+
+func (m *memorySandboxes) FindCurrent(_ context.Context, tenantID string) (*entities.TenantSandbox, error) {
+	for _, sandbox := range m.sandboxes {
+		if sandbox.TenantID == tenantID && (sandbox.Alive() || sandbox.Status == entities.TenantSandboxStatusDeleting) {
+			return sandbox, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySandboxes) Update(_ context.Context, _ *entities.TenantSandbox) error {
+	return nil
+}
+
+func (m *memorySandboxes) ClaimNextPending(_ context.Context, _ time.Duration) (*entities.TenantSandbox, error) {
+	for _, sandbox := range m.sandboxes {
+		if sandbox.Status == entities.TenantSandboxStatusPending {
+			sandbox.Status = entities.TenantSandboxStatusProvisioning
+			return sandbox, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySandboxes) ClaimNextDue(_ context.Context, now time.Time, _ time.Duration) (*entities.TenantSandbox, error) {
+	for _, sandbox := range m.sandboxes {
+		if (sandbox.Status == entities.TenantSandboxStatusPending || sandbox.Status == entities.TenantSandboxStatusReady) &&
+			sandbox.Expired(now) {
+			sandbox.Status = entities.TenantSandboxStatusDeleting
+			return sandbox, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySandboxes) MarkReady(_ context.Context, sandbox *entities.TenantSandbox) error {
+	sandbox.Status = entities.TenantSandboxStatusReady
+	return nil
+}
+
+func (m *memorySandboxes) MarkDeleted(_ context.Context, sandboxID string) error {
+	return m.mark(sandboxID, entities.TenantSandboxStatusDeleted, "")
+}
+
+func (m *memorySandboxes) MarkFailed(_ context.Context, sandboxID string, reason string) error {
+	return m.mark(sandboxID, entities.TenantSandboxStatusFailed, reason)
+}
+
+func (m *memorySandboxes) mark(sandboxID string, status entities.TenantSandboxStatus, reason string) error {
+	for _, sandbox := range m.sandboxes {
+		if sandbox.ID == sandboxID {
+			sandbox.Status, sandbox.FailureReason = status, reason
+		}
+	}
+	return nil
+}
+
+func (m *memorySandboxes) ListReadyTenants(_ context.Context) ([]string, error) {
+	var tenants []string
+	for _, sandbox := range m.sandboxes {
+		if sandbox.Status == entities.TenantSandboxStatusReady {
+			tenants = append(tenants, sandbox.SandboxTenantID)
+		}
+	}
+	return tenants, nil
+}
+
+// memoryData holds the tenants that have data, copies fail when fail is set
+type memoryData struct {
+	tenants map[string]bool
+	kept    []string
+	fail    bool
+}
+
+func (d *memoryData) Copy(_ context.Context, _ string, sandboxTenantID string, keep []string) (map[string]int, error) {
+	d.tenants[sandboxTenantID] = true
+	d.kept = keep
+	if d.fail {
+		return nil, errors.New("serialization failure")
+	}
+	return map[string]int{"class_enrollments": 40, "grades": 120}, nil
+}
+
+func (d *memoryData) Remove(_ context.Context, sandboxTenantID string) error {
+	delete(d.tenants, sandboxTenantID)
+	return nil
+}
+
+// memoryPolicies holds the roles of users per tenant and the sandboxes active
+type memoryPolicies struct {
+	roles  map[string]map[string]string
+	active []string
+}
+
+func (p *memoryPolicies) CopyRoles(_ context.Context, tenantID string, sandboxTenantID string, excluded []string) ([]string, int, error) {
+	var users []string
+	p.roles[sandboxTenantID] = map[string]string{}
+	for user, role := range p.roles[tenantID] {
+		if !slices.Contains(excluded, role) {
+			p.roles[sandboxTenantID][user] = role
+			users = append(users, user)
+		}
+	}
+	slices.Sort(users)
+	return users, len(users), nil
+}
+
+func (p *memoryPolicies) RemoveRoles(_ context.Context, sandboxTenantID string) error {
+	delete(p.roles, sandboxTenantID)
+	return nil
+}
+
+func (p *memoryPolicies) Activate(_ context.Context, sandboxTenantIDs []string) error {
+	p.active = sandboxTenantIDs
+	return nil
+}
+
+func newPolicies() *memoryPolicies {
+	return &memoryPolicies{roles: map[string]map[string]string{
+		tenantID: {"admin-1": "admin", "teacher-1": "instructor", "student-1": "student", "parent-1": "guardian"},
+	}}
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func createSandbox(t *testing.T, repo *memorySandboxes, tenantID string) (*entities.TenantSandbox, error) {
+	t.Helper()
+	cmd, err := create_tenant_sandbox_use_case.NewCreateTenantSandboxCommand(tenantID, "admin-1", entities.DefaultSandboxLifetime)
+	assert.NoError(t, err)
+	return create_tenant_sandbox_use_case.NewCreateTenantSandboxUseCase(repo).Execute(context.Background(), cmd)
+}
+
+func TestCreateTenantSandbox_AllowsOneSandboxPerTenant(t *testing.T) {
+	repo := &memorySandboxes{}
+
+	sandbox, err := createSandbox(t, repo, tenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant1-sandbox", sandbox.SandboxTenantID)
+	assert.Equal(t, entities.TenantSandboxStatusPending, sandbox.Status)
+
+	_, err = createSandbox(t, repo, tenantID)
+	assert.Equal(t, sandboxErrors.TenantSandboxAlreadyExistsError.String(), codeOf(err))
+
+	_, err = createSandbox(t, repo, sandbox.SandboxTenantID)
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err), "sandboxes have no sandbox")
+	assert.Len(t, repo.sandboxes, 1)
+
+	_, err = create_tenant_sandbox_use_case.NewCreateTenantSandboxCommand(tenantID, "admin-1", entities.MaxSandboxLifetime+time.Hour)
+	assert.Error(t, err)
+}
+
+func TestRunTenantSandbox_CopiesStaffRolesAndActivatesTheSandbox(t *testing.T) {
+	repo := &memorySandboxes{}
+	data := &memoryData{tenants: map[string]bool{}}
+	policies := newPolicies()
+	sandbox, err := createSandbox(t, repo, tenantID)
+	assert.NoError(t, err)
+
+	processed, err := run_tenant_sandbox_use_case.NewRunTenantSandboxUseCase(repo, data, policies).Execute(context.Background())
+
+	assert.True(t, processed)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TenantSandboxStatusReady, sandbox.Status)
+	assert.Equal(t, []string{"admin-1", "teacher-1"}, data.kept, "learners are pseudonymized")
+	assert.Equal(t, map[string]string{"admin-1": "admin", "teacher-1": "instructor"}, policies.roles[sandbox.SandboxTenantID])
+	assert.Equal(t, 2, sandbox.Roles)
+	assert.Equal(t, 120, sandbox.Tables["grades"])
+	assert.Equal(t, []string{"tenant1-sandbox"}, policies.active)
+}
+
+func TestRunTenantSandbox_RemovesWhatWasCopiedWhenTheCopyFails(t *testing.T) {
+	repo := &memorySandboxes{}
+	data := &memoryData{tenants: map[string]bool{}, fail: true}
+	policies := newPolicies()
+	sandbox, err := createSandbox(t, repo, tenantID)
+	assert.NoError(t, err)
+
+	processed, err := run_tenant_sandbox_use_case.NewRunTenantSandboxUseCase(repo, data, policies).Execute(context.Background())
+
+	assert.True(t, processed)
+	assert.Error(t, err)
+	assert.Equal(t, entities.TenantSandboxStatusFailed, sandbox.Status)
+	assert.Contains(t, sandbox.FailureReason, "serialization failure")
+	assert.Empty(t, data.tenants)
+	assert.NotContains(t, policies.roles, sandbox.SandboxTenantID)
+	assert.Empty(t, policies.active)
+
+	_, err = createSandbox(t, repo, tenantID)
+	assert.NoError(t, err, "failed sandboxes can be requested again")
+}
+
+func TestRunTenantSandbox_RemovesDeletedSandboxes(t *testing.T) {
+	repo := &memorySandboxes{}
+	data := &memoryData{tenants: map[string]bool{}}
+	policies := newPolicies()
+	useCase := run_tenant_sandbox_use_case.NewRunTenantSandboxUseCase(repo, data, policies)
+	sandbox, err := createSandbox(t, repo, tenantID)
+	assert.NoError(t, err)
+	_, err = useCase.Execute(context.Background())
+	assert.NoError(t, err)
+
+	deleteCmd, err := delete_tenant_sandbox_use_case.NewDeleteTenantSandboxCommand(tenantID)
+	assert.NoError(t, err)
+	_, err = delete_tenant_sandbox_use_case.NewDeleteTenantSandboxUseCase(repo).Execute(context.Background(), deleteCmd)
+	assert.NoError(t, err)
+
+	processed, err := useCase.Execute(context.Background())
+
+	assert.True(t, processed)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TenantSandboxStatusDeleted, sandbox.Status)
+	assert.Empty(t, data.tenants)
+	assert.NotContains(t, policies.roles, sandbox.SandboxTenantID)
+	assert.Empty(t, policies.active)
+
+	_, err = delete_tenant_sandbox_use_case.NewDeleteTenantSandboxUseCase(repo).Execute(context.Background(), deleteCmd)
+	assert.Equal(t, sandboxErrors.TenantSandboxNotFoundError.String(), codeOf(err))
+
+	processed, err = useCase.Execute(context.Background())
+	assert.False(t, processed)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,226 @@
+package use_cases
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	create_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/create-saved-view-use-case"
+	delete_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/delete-saved-view-use-case"
+	get_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/get-saved-view-use-case"
+	list_saved_views_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/list-saved-views-use-case"
+	update_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/update-saved-view-use-case"
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/entities"
+	savedViewErrors "github.com/nahualventure/class-backend/core/app/savedview/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant-1"
+
+var lists = entities.ListDefinitions{
+	ports.UsersList: {OrderFields: ports.UserListOrderFields, FilterFields: ports.UserListFilterFields},
+}
+
+type memoryViews struct {
+	views []*entities.SavedView
+}
+
+func (m *memoryViews) nameTaken(view *entities.SavedView) bool {
+	for _, stored := range m.views {
+		if stored.ID != view.ID && stored.TenantID == view.TenantID && stored.OwnerID == view.OwnerID &&
+			stored.List == view.List && stored.Name == view.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *memoryViews) Create(_ context.Context, view *entities.SavedView) (bool, error) {
+	if m.nameTaken(view) {
+		return false, nil
+	}
+	copied := *view
+	m.views = append(m.views, &copied)
+	return true, nil
+}
+
+func (m *memoryViews) Save(_ context.Context, view *entities.SavedView) (bool, error) {
+	if m.nameTaken(view) {
+		return false, nil
+	}
+	for i, stored := range m.views {
+		if stored.ID == view.ID {
+			copied := *view
+			m.views[i] = &copied
+		}
+	}
+	return true, nil
+}
+
+func (m *memoryViews) FindByID(_ context.Context, tenantID string, viewID string) (*entities.SavedView, error) {
+	for _, stored := range m.views {
+		if stored.TenantID == tenantID && stored.ID == viewID {
+			copied := *stored
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryViews) ListVisible(_ context.Context, tenantID string, userID string, list string) ([]*entities.SavedView, error) {
+	var views []*entities.SavedView
+	for _, stored := range m.views {
+		if stored.TenantID == tenantID && stored.VisibleTo(userID) && (list == "" || stored.List == list) {
+			views = append(views, stored)
+		}
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].Name < views[j].Name })
+	return views, nil
+}
+
+func (m *memoryViews) Delete(_ context.Context, tenantID string, viewID string) error {
+	for i, stored := range m.views {
+		if stored.TenantID == tenantID && stored.ID == viewID {
+			m.views = append(m.views[:i], m.views[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func assertCode(t *testing.T, err error, code appErrors.ErrorCode) appErrors.ApplicationError {
+	t.Helper()
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr), "expected %s, got %v", code, err) {
+		assert.Equal(t, code.String(), appErr.GetCode())
+	}
+	return appErr
+}
+
+func createView(views *memoryViews, ownerID string, list string, name string, orderBy string, filter string,
+	shared bool) (*entities.SavedView, error) {
+	command, err := create_saved_view_use_case.NewCreateSavedViewCommand(tenantID, ownerID, list, name, orderBy, filter, shared)
+	if err != nil {
+		return nil, err
+	}
+	return create_saved_view_use_case.NewCreateSavedViewUseCase(views, lists).Execute(context.Background(), command)
+}
+
+func getView(views *memoryViews, userID string, viewID string, list string) (*entities.SavedView, error) {
+	command, err := get_saved_view_use_case.NewGetSavedViewCommand(tenantID, userID, viewID, list)
+	if err != nil {
+		return nil, err
+	}
+	return get_saved_view_use_case.NewGetSavedViewUseCase(views).Execute(context.Background(), command)
+}
+
+func TestCreateSavedView_ChecksTheOrderAndFilterWithTheRulesOfTheList(t *testing.T) {
+	views := &memoryViews{}
+
+	view, err := createView(views, "admin-1", "users", "Recent signups", "created_at desc", `created_at > "2025-01-01"`, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin-1", view.OwnerID)
+
+	_, err = createView(views, "admin-1", "users", "Recent signups", "", "", false)
+	assertCode(t, err, savedViewErrors.SavedViewNameTakenError)
+
+	// Names are per user
+	_, err = createView(views, "admin-2", "users", "Recent signups", "", "", false)
+	assert.NoError(t, err)
+
+	_, err = createView(views, "admin-1", "users", "By password", "password", "", false)
+	appErr := assertCode(t, err, appErrors.ValidationError)
+	assert.Contains(t, appErr.GetContext()["order_by"], "Cannot order by \"password\"")
+
+	_, err = createView(views, "admin-1", "users", "Active", "", `status == "active"`, false)
+	appErr = assertCode(t, err, appErrors.ValidationError)
+	assert.Contains(t, appErr.GetContext(), "filter")
+
+	_, err = createView(views, "admin-1", "invoices", "Unpaid", "", "", false)
+	appErr = assertCode(t, err, appErrors.ValidationError)
+	assert.Equal(t, "Must be one of users", appErr.GetContext()["list"])
+}
+
+func TestSavedViews_AreVisibleToTheirOwnerUnlessShared(t *testing.T) {
+	views := &memoryViews{}
+	private, err := createView(views, "admin-1", "users", "Mine", "name", "", false)
+	assert.NoError(t, err)
+	shared, err := createView(views, "admin-1", "users", "Everyone's", "email", "", true)
+	assert.NoError(t, err)
+
+	list := func(userID string) []string {
+		command, err := list_saved_views_use_case.NewListSavedViewsCommand(tenantID, userID, "users")
+		assert.NoError(t, err)
+		found, err := list_saved_views_use_case.NewListSavedViewsUseCase(views).Execute(context.Background(), command)
+		assert.NoError(t, err)
+		var names []string
+		for _, view := range found {
+			names = append(names, view.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"Everyone's", "Mine"}, list("admin-1"))
+	assert.Equal(t, []string{"Everyone's"}, list("admin-2"))
+
+	_, err = getView(views, "admin-2", private.ID, "")
+	assertCode(t, err, savedViewErrors.SavedViewNotFoundError)
+	found, err := getView(views, "admin-2", shared.ID, "users")
+	assert.NoError(t, err)
+	assert.Equal(t, shared.ID, found.ID)
+
+	// A list applying a view of another list does not find it
+	_, err = getView(views, "admin-1", shared.ID, "classes")
+	assertCode(t, err, savedViewErrors.SavedViewNotFoundError)
+
+	update, err := update_saved_view_use_case.NewUpdateSavedViewCommand(tenantID, "admin-2", shared.ID, "Renamed", "", "", true)
+	assert.NoError(t, err)
+	_, err = update_saved_view_use_case.NewUpdateSavedViewUseCase(views, lists).Execute(context.Background(), update)
+	assertCode(t, err, appErrors.Forbidden)
+
+	remove, err := delete_saved_view_use_case.NewDeleteSavedViewCommand(tenantID, "admin-2", private.ID)
+	assert.NoError(t, err)
+	err = delete_saved_view_use_case.NewDeleteSavedViewUseCase(views).Execute(context.Background(), remove)
+	assertCode(t, err, savedViewErrors.SavedViewNotFoundError)
+
+	remove, err = delete_saved_view_use_case.NewDeleteSavedViewCommand(tenantID, "admin-1", private.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, delete_saved_view_use_case.NewDeleteSavedViewUseCase(views).Execute(context.Background(), remove))
+	assert.Equal(t, []string{"Everyone's"}, list("admin-1"))
+}
+
+func TestUpdateSavedView_RenamesWithinTheNamesOfTheOwner(t *testing.T) {
+	views := &memoryViews{}
+	_, err := createView(views, "admin-1", "users", "By name", "name", "", false)
+	assert.NoError(t, err)
+	view, err := createView(views, "admin-1", "users", "By email", "email", "", false)
+	assert.NoError(t, err)
+
+	update := func(viewID string, name string, orderBy string) (*entities.SavedView, error) {
+		command, err := update_saved_view_use_case.NewUpdateSavedViewCommand(tenantID, "admin-1", viewID, name, orderBy, "", true)
+		if err != nil {
+			return nil, err
+		}
+		return update_saved_view_use_case.NewUpdateSavedViewUseCase(views, lists).Execute(context.Background(), command)
+	}
+
+	_, err = update(view.ID, "By name", "email")
+	assertCode(t, err, savedViewErrors.SavedViewNameTakenError)
+	_, err = update(view.ID, "By email", "password")
+	assertCode(t, err, appErrors.ValidationError)
+	_, err = update(uuid.NewString(), "Missing", "")
+	assertCode(t, err, savedViewErrors.SavedViewNotFoundError)
+
+	updated, err := update(view.ID, "Newest email first", "email desc")
+	assert.NoError(t, err)
+	assert.Equal(t, "email desc", updated.OrderBy)
+	assert.True(t, updated.Shared)
+
+	orderBy, filter := updated.Apply("", `name == "Ada"`)
+	assert.Equal(t, "email desc", orderBy)
+	assert.Equal(t, `name == "Ada"`, filter)
+}
@@ -0,0 +1,119 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+
+	scan_quarantined_files_use_case "github.com/nahualventure/class-backend/core/app/scanning/application/use-cases/scan-quarantined-files-use-case"
+	"github.com/nahualventure/class-backend/core/app/scanning/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryQuarantine keeps the files pending until their scan is recorded, as the Postgres adapter does
+type memoryQuarantine struct {
+	pending []*entities.QuarantinedFile
+	scans   []*entities.FileScan
+}
+
+func (m *memoryQuarantine) ListPending(_ context.Context, limit int) ([]*entities.QuarantinedFile, error) {
+	return m.pending[:min(limit, len(m.pending))], nil
+}
+
+func (m *memoryQuarantine) Record(_ context.Context, scan *entities.FileScan) (bool, error) {
+	for i, file := range m.pending {
+		if file.ID == scan.File.ID {
+			m.pending = append(m.pending[:i:i], m.pending[i+1:]...)
+			m.scans = append(m.scans, scan)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type memoryFiles struct {
+	files map[string][]byte
+}
+
+func (m *memoryFiles) Put(_ context.Context, key string, content []byte) error {
+	m.files[key] = content
+	return nil
+}
+
+func (m *memoryFiles) Get(_ context.Context, key string) ([]byte, error) {
+	return m.files[key], nil
+}
+
+func (m *memoryFiles) Delete(_ context.Context, key string) error {
+	delete(m.files, key)
+	return nil
+}
+
+// eicarScanner finds the EICAR test string and fails on files it cannot read
+type eicarScanner struct{}
+
+func (eicarScanner) Name() string {
+	return "eicar"
+}
+
+func (eicarScanner) Scan(_ context.Context, content []byte) (*entities.ScanVerdict, error) {
+	switch string(content) {
+	case "EICAR":
+		return &entities.ScanVerdict{Infected: true, Signature: "Eicar-Test-Signature"}, nil
+	case "encrypted":
+		return nil, errors.New("cannot scan encrypted archives")
+	}
+	return &entities.ScanVerdict{}, nil
+}
+
+func quarantined(id string) *entities.QuarantinedFile {
+	return &entities.QuarantinedFile{
+		Kind:       entities.FileKindAttachment,
+		ID:         id,
+		TenantID:   "tenant1",
+		StorageKey: "messages/tenant1/c1/" + id,
+		FileName:   id + ".pdf",
+		UploadedBy: "teacher-1",
+	}
+}
+
+func TestScanQuarantinedFiles_ReleasesCleanFilesAndDeletesInfectedOnes(t *testing.T) {
+	quarantine := &memoryQuarantine{pending: []*entities.QuarantinedFile{quarantined("clean"), quarantined("virus"), quarantined("gone")}}
+	files := &memoryFiles{files: map[string][]byte{
+		"messages/tenant1/c1/clean": []byte("%PDF"),
+		"messages/tenant1/c1/virus": []byte("EICAR"),
+	}}
+
+	scanned, err := scan_quarantined_files_use_case.NewScanQuarantinedFilesUseCase(quarantine, eicarScanner{}, files).Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, scanned)
+	assert.Empty(t, quarantine.pending)
+	statuses := map[string]storage.ScanStatus{}
+	for _, scan := range quarantine.scans {
+		statuses[scan.File.ID] = scan.Status
+		assert.Equal(t, "eicar", scan.Scanner)
+	}
+	assert.Equal(t, map[string]storage.ScanStatus{"clean": storage.ScanClean, "virus": storage.ScanInfected, "gone": storage.ScanMissing}, statuses)
+	assert.Equal(t, "Eicar-Test-Signature", quarantine.scans[1].Signature)
+	assert.Contains(t, files.files, "messages/tenant1/c1/clean")
+	assert.NotContains(t, files.files, "messages/tenant1/c1/virus", "infected files are deleted")
+}
+
+func TestScanQuarantinedFiles_KeepsFilesTheScannerFailsOn(t *testing.T) {
+	quarantine := &memoryQuarantine{pending: []*entities.QuarantinedFile{quarantined("archive"), quarantined("clean")}}
+	files := &memoryFiles{files: map[string][]byte{
+		"messages/tenant1/c1/archive": []byte("encrypted"),
+		"messages/tenant1/c1/clean":   []byte("%PDF"),
+	}}
+
+	scanned, err := scan_quarantined_files_use_case.NewScanQuarantinedFilesUseCase(quarantine, eicarScanner{}, files).Execute(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, scanned, "the rest of the batch is scanned")
+	assert.Len(t, quarantine.pending, 1)
+	assert.Equal(t, "archive", quarantine.pending[0].ID)
+	assert.Contains(t, files.files, "messages/tenant1/c1/archive")
+}
@@ -0,0 +1,179 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	get_section_change_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/get-section-change-use-case"
+	list_section_changes_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/list-section-changes-use-case"
+	merge_sections_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/merge-sections-use-case"
+	split_section_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/split-section-use-case"
+	"github.com/nahualventure/class-backend/core/app/sections/domain/entities"
+	sectionErrors "github.com/nahualventure/class-backend/core/app/sections/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memorySections moves the students of a change between sections like the transaction of the
+// Postgres repository, sections are left as they were when plan fails
+type memorySections struct {
+	sections map[string]*entities.Section
+	changes  []*entities.SectionChange
+}
+
+func (m *memorySections) Apply(_ context.Context, tenantID string, sourceClassID string, targetClassID string,
+	plan func(source *entities.Section, target *entities.Section) (*entities.SectionChange, error)) (*entities.SectionChange, error) {
+	source, target := m.section(sourceClassID), m.section(targetClassID)
+	change, err := plan(source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	moves := func(studentID string) bool {
+		return change.Kind == entities.SectionChangeMerge || slices.Contains(change.StudentIDs, studentID)
+	}
+	for _, studentID := range source.Enrolled {
+		if moves(studentID) {
+			target.Enrolled = append(target.Enrolled, studentID)
+			change.Moved.Enrollments++
+		}
+	}
+	for _, studentID := range source.Graded {
+		if moves(studentID) {
+			target.Graded = append(target.Graded, studentID)
+			change.Moved.Grades++
+		}
+	}
+	source.Enrolled = slices.DeleteFunc(source.Enrolled, moves)
+	source.Graded = slices.DeleteFunc(source.Graded, moves)
+	m.changes = append(m.changes, change)
+	return change, nil
+}
+
+func (m *memorySections) section(classID string) *entities.Section {
+	if m.sections[classID] == nil {
+		m.sections[classID] = &entities.Section{ClassID: classID}
+	}
+	return m.sections[classID]
+}
+
+func (m *memorySections) FindByID(_ context.Context, tenantID string, changeID string) (*entities.SectionChange, error) {
+	for _, change := range m.changes {
+		if change.TenantID == tenantID && change.ID == changeID {
+			return change, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySections) List(_ context.Context, tenantID string, classID string, page *pagination.PageRequest) (*pagination.Page[*entities.SectionChange], error) {
+	var found []*entities.SectionChange
+	for i := len(m.changes) - 1; i >= 0; i-- {
+		change := m.changes[i]
+		if change.TenantID == tenantID && (classID == "" || change.SourceClassID == classID || change.TargetClassID == classID) {
+			found = append(found, change)
+		}
+	}
+	return pagination.NewPage(page, found, int64(len(found)), func(change *entities.SectionChange) []string {
+		return []string{pagination.TimeKey(change.CreatedAt), change.ID}
+	}), nil
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, code, appErr.GetCode())
+	}
+}
+
+func TestMergeSectionsUseCase_Execute_MovesEveryStudentAndRecordsTheChange(t *testing.T) {
+	ctx := context.Background()
+	sourceID, targetID := uuid.New().String(), uuid.New().String()
+	capacity := 4
+	repo := &memorySections{sections: map[string]*entities.Section{
+		// student-3 dropped the class but keeps their grades, merges move them too
+		sourceID: {ClassID: sourceID, Enrolled: []string{"student-1", "student-2"}, Graded: []string{"student-1", "student-3"}},
+		targetID: {ClassID: targetID, Capacity: &capacity, Held: 1, Enrolled: []string{"student-4"}},
+	}}
+
+	command, err := merge_sections_use_case.NewMergeSectionsCommand("tenant-1", sourceID, targetID, "Low enrollment", "admin-1")
+	assert.NoError(t, err)
+	change, err := merge_sections_use_case.NewMergeSectionsUseCase(repo).Execute(ctx, command)
+	assert.NoError(t, err)
+
+	assert.Equal(t, entities.SectionChangeMerge, change.Kind)
+	assert.ElementsMatch(t, []string{"student-1", "student-2", "student-3"}, change.StudentIDs)
+	assert.Equal(t, 2, change.Moved.Enrollments)
+	assert.Empty(t, repo.sections[sourceID].Students())
+	assert.ElementsMatch(t, []string{"student-1", "student-2", "student-3", "student-4"}, repo.sections[targetID].Students())
+
+	listCommand, err := list_section_changes_use_case.NewListSectionChangesCommand("tenant-1", targetID, 20, "")
+	assert.NoError(t, err)
+	page, err := list_section_changes_use_case.NewListSectionChangesUseCase(repo).Execute(ctx, listCommand)
+	assert.NoError(t, err)
+	if assert.Len(t, page.Items, 1) {
+		assert.Equal(t, change.ID, page.Items[0].ID)
+	}
+
+	getCommand, err := get_section_change_use_case.NewGetSectionChangeCommand("tenant-2", change.ID)
+	assert.NoError(t, err)
+	_, err = get_section_change_use_case.NewGetSectionChangeUseCase(repo).Execute(ctx, getCommand)
+	assertCode(t, err, sectionErrors.SectionChangeNotFoundError.String())
+}
+
+func TestSplitSectionUseCase_Execute_MovesOnlyTheSelectedStudents(t *testing.T) {
+	ctx := context.Background()
+	sourceID, targetID := uuid.New().String(), uuid.New().String()
+	capacity := 1
+	repo := &memorySections{sections: map[string]*entities.Section{
+		sourceID: {ClassID: sourceID, Enrolled: []string{"student-1", "student-2"}, Graded: []string{"student-1", "student-2"}},
+		targetID: {ClassID: targetID, Capacity: &capacity},
+	}}
+
+	command, err := split_section_use_case.NewSplitSectionCommand("tenant-1", sourceID, targetID, []string{"student-2"}, "", "admin-1")
+	assert.NoError(t, err)
+	change, err := split_section_use_case.NewSplitSectionUseCase(repo).Execute(ctx, command)
+	assert.NoError(t, err)
+
+	assert.Equal(t, entities.SectionChangeSplit, change.Kind)
+	assert.Equal(t, entities.MovedRecords{Enrollments: 1, Grades: 1}, change.Moved)
+	assert.Equal(t, []string{"student-1"}, repo.sections[sourceID].Enrolled)
+	assert.Equal(t, []string{"student-2"}, repo.sections[targetID].Enrolled)
+}
+
+func TestSplitSectionUseCase_Execute_ChecksPreconditionsBeforeMovingAnything(t *testing.T) {
+	ctx := context.Background()
+	sourceID, targetID := uuid.New().String(), uuid.New().String()
+	capacity := 2
+	repo := &memorySections{sections: map[string]*entities.Section{
+		sourceID: {ClassID: sourceID, Enrolled: []string{"student-1", "student-2", "student-3"}},
+		// student-3 was in the target before and still has a grade there
+		targetID: {ClassID: targetID, Capacity: &capacity, Enrolled: []string{"student-4"}, Graded: []string{"student-3"}},
+	}}
+	useCase := split_section_use_case.NewSplitSectionUseCase(repo)
+
+	cases := []struct {
+		students []string
+		code     string
+	}{
+		{[]string{"student-1", "student-9"}, sectionErrors.StudentsNotInSectionError.String()},
+		{[]string{"student-3"}, sectionErrors.SectionStudentsConflictError.String()},
+		{[]string{"student-1", "student-2"}, sectionErrors.SectionCapacityExceededError.String()},
+	}
+	for _, c := range cases {
+		command, err := split_section_use_case.NewSplitSectionCommand("tenant-1", sourceID, targetID, c.students, "", "admin-1")
+		assert.NoError(t, err)
+		_, err = useCase.Execute(ctx, command)
+		assertCode(t, err, c.code)
+	}
+	assert.Len(t, repo.sections[sourceID].Enrolled, 3)
+	assert.Empty(t, repo.changes)
+
+	_, err := split_section_use_case.NewSplitSectionCommand("tenant-1", sourceID, sourceID, []string{"student-1"}, "", "admin-1")
+	assert.Error(t, err)
+}
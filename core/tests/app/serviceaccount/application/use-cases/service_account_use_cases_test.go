@@ -0,0 +1,576 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	authenticate_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/authenticate-service-account-use-case"
+	create_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/create-service-account-use-case"
+	disable_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/disable-service-account-use-case"
+	introspect_token_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/introspect-token-use-case"
+	list_token_policies_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/list-token-policies-use-case"
+	revoke_service_account_key_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/revoke-service-account-key-use-case"
+	revoke_token_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/revoke-token-use-case"
+	rotate_service_account_key_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/rotate-service-account-key-use-case"
+	set_token_policy_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/set-token-policy-use-case"
+	update_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/update-service-account-use-case"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	serviceAccountErrors "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/tests/clocktest"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+// memoryServiceAccounts keeps accounts like the tables, Save only writes the keys it is given
+type memoryServiceAccounts struct {
+	accounts map[string]*entities.ServiceAccount
+	keys     map[string]entities.ServiceAccountKey
+	changes  []entities.ServiceAccountChange
+}
+
+func newMemoryServiceAccounts() *memoryServiceAccounts {
+	return &memoryServiceAccounts{accounts: map[string]*entities.ServiceAccount{}, keys: map[string]entities.ServiceAccountKey{}}
+}
+
+func (m *memoryServiceAccounts) Create(_ context.Context, account *entities.ServiceAccount, change entities.ServiceAccountChange) error {
+	stored := *account
+	stored.Keys, stored.Roles = nil, nil
+	m.accounts[account.ID] = &stored
+	for _, key := range account.Keys {
+		m.keys[key.ID] = *key
+	}
+	m.changes = append(m.changes, change)
+	return nil
+}
+
+func (m *memoryServiceAccounts) Save(_ context.Context, account *entities.ServiceAccount, keys []*entities.ServiceAccountKey, change entities.ServiceAccountChange) error {
+	stored := *account
+	stored.Keys, stored.Roles = nil, nil
+	m.accounts[account.ID] = &stored
+	for _, key := range keys {
+		m.keys[key.ID] = *key
+	}
+	m.changes = append(m.changes, change)
+	return nil
+}
+
+func (m *memoryServiceAccounts) FindByID(_ context.Context, tenantID string, serviceAccountID string) (*entities.ServiceAccount, error) {
+	stored, ok := m.accounts[serviceAccountID]
+	if !ok || stored.TenantID != tenantID {
+		return nil, nil
+	}
+	account := *stored
+	for _, key := range m.keys {
+		if key.ServiceAccountID == account.ID {
+			key := key
+			account.Keys = append(account.Keys, &key)
+		}
+	}
+	slices.SortFunc(account.Keys, func(a, b *entities.ServiceAccountKey) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return &account, nil
+}
+
+func (m *memoryServiceAccounts) List(ctx context.Context, tenantID string) ([]*entities.ServiceAccount, error) {
+	var accounts []*entities.ServiceAccount
+	for id := range m.accounts {
+		account, _ := m.FindByID(ctx, tenantID, id)
+		if account != nil {
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts, nil
+}
+
+func (m *memoryServiceAccounts) FindByKey(_ context.Context, keyID string) (*entities.ServiceAccount, *entities.ServiceAccountKey, error) {
+	key, ok := m.keys[keyID]
+	if !ok {
+		return nil, nil, nil
+	}
+	account := *m.accounts[key.ServiceAccountID]
+	account.Keys = []*entities.ServiceAccountKey{&key}
+	return &account, &key, nil
+}
+
+func (m *memoryServiceAccounts) TouchKey(_ context.Context, keyID string, usedAt time.Time) error {
+	key := m.keys[keyID]
+	key.LastUsedAt = &usedAt
+	m.keys[keyID] = key
+	return nil
+}
+
+// memoryRoles holds roles per subject and tenant like the Casbin grouping policies
+type memoryRoles struct {
+	roles map[string][]string
+}
+
+func newMemoryRoles() *memoryRoles {
+	return &memoryRoles{roles: map[string][]string{
+		"admin-1|" + tenantID:   {"admin"},
+		"teacher-1|" + tenantID: {"instructor"},
+	}}
+}
+
+func (r *memoryRoles) AvailableRoles() []string {
+	return []string{"admin", "instructor", "student"}
+}
+
+func (r *memoryRoles) Roles(_ context.Context, subject string, tenantID string) ([]string, error) {
+	return r.roles[subject+"|"+tenantID], nil
+}
+
+// Permissions grants what policies.yaml grants the roles, as resource:action
+func (r *memoryRoles) Permissions(roles []string) []string {
+	granted := map[string][]string{
+		"admin":      {"all:all"},
+		"instructor": {"grade:assign", "grade:view"},
+		"student":    {"grade:view"},
+	}
+	var permissions []string
+	for _, role := range roles {
+		permissions = append(permissions, granted[role]...)
+	}
+	return permissions
+}
+
+func (r *memoryRoles) Assign(_ context.Context, subject string, role string, tenantID string) error {
+	r.roles[subject+"|"+tenantID] = append(r.roles[subject+"|"+tenantID], role)
+	return nil
+}
+
+func (r *memoryRoles) Remove(_ context.Context, subject string, role string, tenantID string) error {
+	key := subject + "|" + tenantID
+	r.roles[key] = slices.DeleteFunc(r.roles[key], func(held string) bool { return held == role })
+	return nil
+}
+
+// memoryTokenPolicies holds the policies a tenant set, Find returns nil for the others
+type memoryTokenPolicies struct {
+	policies map[string]*entities.TokenPolicy
+}
+
+func newMemoryTokenPolicies() *memoryTokenPolicies {
+	return &memoryTokenPolicies{policies: map[string]*entities.TokenPolicy{}}
+}
+
+func (p *memoryTokenPolicies) Find(_ context.Context, tenantID string, clientType string) (*entities.TokenPolicy, error) {
+	return p.policies[tenantID+"|"+clientType], nil
+}
+
+func (p *memoryTokenPolicies) List(_ context.Context, tenantID string) ([]*entities.TokenPolicy, error) {
+	var policies []*entities.TokenPolicy
+	for _, policy := range p.policies {
+		if policy.TenantID == tenantID {
+			policies = append(policies, policy)
+		}
+	}
+	return policies, nil
+}
+
+func (p *memoryTokenPolicies) Save(_ context.Context, policy *entities.TokenPolicy) error {
+	p.policies[policy.TenantID+"|"+policy.ClientType] = policy
+	return nil
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func grace(d time.Duration) *time.Duration {
+	return &d
+}
+
+func createAccount(t *testing.T, repo *memoryServiceAccounts, roles *memoryRoles) (*entities.ServiceAccount, string) {
+	t.Helper()
+	cmd, err := create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "SIS sync", "Nightly roster import", "teacher-1", []string{"instructor"},
+		"", "", nil)
+	assert.NoError(t, err)
+	account, token, err := create_service_account_use_case.NewCreateServiceAccountUseCase(repo, roles, newMemoryTokenPolicies()).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return account, token
+}
+
+func authenticate(repo *memoryServiceAccounts, token string, tenantID string) (*entities.ServiceAccount, error) {
+	return authenticateFrom(repo, token, tenantID, "203.0.113.7")
+}
+
+func authenticateFrom(repo *memoryServiceAccounts, token string, tenantID string, clientIP string) (*entities.ServiceAccount, error) {
+	cmd, err := authenticate_service_account_use_case.NewAuthenticateServiceAccountCommand(token, tenantID, clientIP)
+	if err != nil {
+		return nil, err
+	}
+	account, _, err := authenticate_service_account_use_case.NewAuthenticateServiceAccountUseCase(repo).Execute(context.Background(), cmd)
+	return account, err
+}
+
+func TestCreateServiceAccount_AssignsRolesToItsSubject(t *testing.T) {
+	repo, roles := newMemoryServiceAccounts(), newMemoryRoles()
+
+	account, token := createAccount(t, repo, roles)
+
+	assert.Equal(t, "sa:"+account.ID, account.Subject())
+	assert.Equal(t, "teacher-1", account.OwnerID)
+	assert.Equal(t, "admin-1", account.CreatedBy)
+	assert.Equal(t, []string{"instructor"}, roles.roles[account.Subject()+"|"+tenantID])
+	assert.Len(t, account.Keys, 1)
+	assert.NotContains(t, account.Keys[0].SecretHash, token, "only the hash of the secret is stored")
+	assert.Equal(t, []entities.ServiceAccountChange{{Type: events.ServiceAccountCreated, ActorID: "admin-1", KeyID: account.Keys[0].ID}}, repo.changes)
+
+	authenticated, err := authenticate(repo, token, "")
+	assert.NoError(t, err)
+	assert.Equal(t, account.ID, authenticated.ID)
+	assert.NotNil(t, repo.keys[account.Keys[0].ID].LastUsedAt)
+}
+
+func TestCreateServiceAccount_ValidatesRolesAndOwner(t *testing.T) {
+	repo, roles := newMemoryServiceAccounts(), newMemoryRoles()
+	useCase := create_service_account_use_case.NewCreateServiceAccountUseCase(repo, roles, newMemoryTokenPolicies())
+
+	cmd, _ := create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "Sync", "", "", []string{"superuser"}, "", "", nil)
+	_, _, err := useCase.Execute(context.Background(), cmd)
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err))
+
+	cmd, _ = create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "Sync", "", "stranger", []string{"instructor"}, "", "", nil)
+	_, _, err = useCase.Execute(context.Background(), cmd)
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err), "owners must be members of the tenant")
+
+	owned, _ := createAccount(t, repo, roles)
+	cmd, _ = create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "Sync", "", owned.Subject(), []string{"instructor"}, "", "", nil)
+	_, _, err = useCase.Execute(context.Background(), cmd)
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err), "service accounts cannot own service accounts")
+}
+
+func TestAuthenticateServiceAccount_RefusesBadKeys(t *testing.T) {
+	repo, roles := newMemoryServiceAccounts(), newMemoryRoles()
+	account, token := createAccount(t, repo, roles)
+
+	for name, attempt := range map[string]string{
+		"malformed":    "not-a-token",
+		"wrong secret": "sa_" + account.Keys[0].ID + "_guessed",
+		"unknown key":  "sa_9b2f1c1e-1d2a-4c4e-9c57-2f0e5b8d7a10_secret",
+	} {
+		_, err := authenticate(repo, attempt, "")
+		assert.Equal(t, appErrors.Unauthorized.String(), codeOf(err), name)
+	}
+
+	_, err := authenticate(repo, token, "tenant2")
+	assert.Equal(t, appErrors.Unauthorized.String(), codeOf(err), "keys only act in the tenant of their account")
+	_, err = authenticate(repo, token, tenantID)
+	assert.NoError(t, err, "the tenant of the account may be sent")
+}
+
+func TestRotateServiceAccountKey_KeepsOldKeysForTheGracePeriod(t *testing.T) {
+	repo, roles := newMemoryServiceAccounts(), newMemoryRoles()
+	account, oldToken := createAccount(t, repo, roles)
+	rotate := rotate_service_account_key_use_case.NewRotateServiceAccountKeyUseCase(repo, roles, newMemoryTokenPolicies())
+
+	cmd, err := rotate_service_account_key_use_case.NewRotateServiceAccountKeyCommand(tenantID, account.ID, "admin-1", grace(time.Hour), "", nil)
+	assert.NoError(t, err)
+	rotated, key, newToken, err := rotate.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Len(t, rotated.Keys, 2)
+	assert.Equal(t, []string{"instructor"}, rotated.Roles)
+	assert.NotNil(t, repo.keys[account.Keys[0].ID].ExpiresAt)
+	assert.Nil(t, repo.keys[key.ID].ExpiresAt)
+
+	_, err = authenticate(repo, oldToken, "")
+	assert.NoError(t, err, "replaced keys work until the grace period ends")
+	_, err = authenticate(repo, newToken, "")
+	assert.NoError(t, err)
+
+	// Rotating without grace stops every other key at once
+	cmd, _ = rotate_service_account_key_use_case.NewRotateServiceAccountKeyCommand(tenantID, account.ID, "admin-1", grace(0), "", nil)
+	_, _, latestToken, err := rotate.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	_, err = authenticate(repo, oldToken, "")
+	assert.Error(t, err)
+	_, err = authenticate(repo, newToken, "")
+	assert.Error(t, err)
+	_, err = authenticate(repo, latestToken, "")
+	assert.NoError(t, err)
+
+	_, err = rotate_service_account_key_use_case.NewRotateServiceAccountKeyCommand(tenantID, account.ID, "admin-1", grace(8*24*time.Hour), "", nil)
+	assert.Error(t, err, "grace periods are at most a week")
+}
+
+func TestRevokeServiceAccountKey(t *testing.T) {
+	repo, roles := newMemoryServiceAccounts(), newMemoryRoles()
+	account, token := createAccount(t, repo, roles)
+	revoke := revoke_service_account_key_use_case.NewRevokeServiceAccountKeyUseCase(repo, roles)
+
+	cmd, err := revoke_service_account_key_use_case.NewRevokeServiceAccountKeyCommand(tenantID, account.ID, account.Keys[0].ID, "admin-1")
+	assert.NoError(t, err)
+	_, err = revoke.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	_, err = authenticate(repo, token, "")
+	assert.Equal(t, appErrors.Unauthorized.String(), codeOf(err))
+
+	// Revoking twice records nothing more
+	_, err = revoke.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Len(t, repo.changes, 2)
+
+	cmd, _ = revoke_service_account_key_use_case.NewRevokeServiceAccountKeyCommand(tenantID, account.ID, "9b2f1c1e-1d2a-4c4e-9c57-2f0e5b8d7a10", "admin-1")
+	_, err = revoke.Execute(context.Background(), cmd)
+	assert.Equal(t, serviceAccountErrors.ServiceAccountKeyNotFoundError.String(), codeOf(err))
+}
+
+func TestUpdateServiceAccount_ReplacesRolesAndOwner(t *testing.T) {
+	repo, roles := newMemoryServiceAccounts(), newMemoryRoles()
+	account, _ := createAccount(t, repo, roles)
+
+	cmd, err := update_service_account_use_case.NewUpdateServiceAccountCommand(tenantID, account.ID, "admin-1", "SIS sync v2", "", "admin-1", []string{"student"})
+	assert.NoError(t, err)
+	updated, err := update_service_account_use_case.NewUpdateServiceAccountUseCase(repo, roles).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SIS sync v2", updated.Name)
+	assert.Equal(t, "admin-1", updated.OwnerID)
+	assert.Equal(t, []string{"student"}, roles.roles[account.Subject()+"|"+tenantID])
+}
+
+func TestDisableServiceAccount_RevokesKeysAndRoles(t *testing.T) {
+	repo, roles := newMemoryServiceAccounts(), newMemoryRoles()
+	account, token := createAccount(t, repo, roles)
+
+	cmd, err := disable_service_account_use_case.NewDisableServiceAccountCommand(tenantID, account.ID, "admin-1")
+	assert.NoError(t, err)
+	disabled, err := disable_service_account_use_case.NewDisableServiceAccountUseCase(repo, roles).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+
+	assert.True(t, disabled.Disabled())
+	assert.Empty(t, roles.roles[account.Subject()+"|"+tenantID])
+	assert.NotNil(t, repo.keys[account.Keys[0].ID].RevokedAt)
+	_, err = authenticate(repo, token, "")
+	assert.Error(t, err)
+
+	rotateCmd, _ := rotate_service_account_key_use_case.NewRotateServiceAccountKeyCommand(tenantID, account.ID, "admin-1", grace(time.Hour), "", nil)
+	_, _, _, err = rotate_service_account_key_use_case.NewRotateServiceAccountKeyUseCase(repo, roles, newMemoryTokenPolicies()).Execute(context.Background(), rotateCmd)
+	assert.Equal(t, serviceAccountErrors.ServiceAccountDisabledError.String(), codeOf(err))
+}
+
+func TestTokenPolicies_ListsDefaultsAndTenantSettings(t *testing.T) {
+	policies := newMemoryTokenPolicies()
+
+	cmd, err := set_token_policy_use_case.NewSetTokenPolicyCommand(tenantID, entities.ClientTypeWeb, 2*time.Hour, 0, true, "admin-1")
+	assert.NoError(t, err)
+	_, err = set_token_policy_use_case.NewSetTokenPolicyUseCase(policies).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+
+	listCmd, _ := list_token_policies_use_case.NewListTokenPoliciesCommand(tenantID)
+	listed, err := list_token_policies_use_case.NewListTokenPoliciesUseCase(policies).Execute(context.Background(), listCmd)
+	assert.NoError(t, err)
+	assert.Len(t, listed, len(entities.ClientTypes))
+	for _, policy := range listed {
+		assert.Equal(t, policy.ClientType != entities.ClientTypeWeb, policy.IsDefault(), policy.ClientType)
+	}
+	assert.Equal(t, 2*time.Hour, listed[1].KeyLifetime)
+	assert.True(t, listed[3].BindIP, "kiosk keys are bound by default")
+
+	_, err = set_token_policy_use_case.NewSetTokenPolicyCommand(tenantID, "watch", time.Hour, 0, false, "admin-1")
+	assert.Error(t, err)
+}
+
+func TestCreateServiceAccount_IssuesKeysUnderTheTokenPolicy(t *testing.T) {
+	repo, roles, policies := newMemoryServiceAccounts(), newMemoryRoles(), newMemoryTokenPolicies()
+	create := create_service_account_use_case.NewCreateServiceAccountUseCase(repo, roles, policies)
+	policy, _ := entities.NewTokenPolicy(tenantID, entities.ClientTypeWeb, time.Hour, 0, false, "admin-1", time.Now())
+	policies.policies[tenantID+"|"+entities.ClientTypeWeb] = policy
+
+	cmd, _ := create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "Portal", "", "", []string{"instructor"}, entities.ClientTypeWeb, "", nil)
+	web, _, err := create.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *web.Keys[0].ExpiresAt, time.Minute)
+
+	cmd, _ = create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "Library kiosk", "", "", []string{"instructor"}, entities.ClientTypeKiosk, "", nil)
+	_, _, err = create.Execute(context.Background(), cmd)
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err), "kiosk keys need an address")
+
+	cmd, _ = create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "Library kiosk", "", "", []string{"instructor"}, entities.ClientTypeKiosk, "198.51.100.20", nil)
+	kiosk, token, err := create.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "198.51.100.20", kiosk.Keys[0].BoundIP)
+
+	_, err = authenticateFrom(repo, token, "", "198.51.100.20")
+	assert.NoError(t, err)
+	_, err = authenticateFrom(repo, token, "", "198.51.100.21")
+	assert.Equal(t, appErrors.Unauthorized.String(), codeOf(err), "bound keys only work from their address")
+
+	// Rotations keep the address and use the grace of the policy
+	rotateCmd, _ := rotate_service_account_key_use_case.NewRotateServiceAccountKeyCommand(tenantID, kiosk.ID, "admin-1", nil, "", nil)
+	_, key, _, err := rotate_service_account_key_use_case.NewRotateServiceAccountKeyUseCase(repo, roles, policies).Execute(context.Background(), rotateCmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "198.51.100.20", key.BoundIP)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *repo.keys[kiosk.Keys[0].ID].ExpiresAt, time.Minute)
+}
+
+func TestServiceAccountKeys_ExpireAfterTheKeyLifetime(t *testing.T) {
+	clk := clocktest.New(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC))
+	repo, roles := newMemoryServiceAccounts(), newMemoryRoles()
+	cmd, _ := create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "Portal", "", "", []string{"instructor"}, entities.ClientTypeWeb, "", nil)
+	_, token, err := create_service_account_use_case.NewCreateServiceAccountUseCase(repo, roles, newMemoryTokenPolicies()).WithClock(clk).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+
+	authenticate := authenticate_service_account_use_case.NewAuthenticateServiceAccountUseCase(repo).WithClock(clk)
+	authenticateCmd, _ := authenticate_service_account_use_case.NewAuthenticateServiceAccountCommand(token, tenantID, "203.0.113.7")
+	introspect := introspect_token_use_case.NewIntrospectTokenUseCase(repo, roles).WithClock(clk)
+	introspectCmd, _ := introspect_token_use_case.NewIntrospectTokenCommand(token, tenantID)
+	lifetime := entities.DefaultTokenPolicy(tenantID, entities.ClientTypeWeb).KeyLifetime
+
+	clk.Advance(lifetime - time.Second)
+	_, _, err = authenticate.Execute(context.Background(), authenticateCmd)
+	assert.NoError(t, err, "the key works until the end of its lifetime")
+	introspection, err := introspect.Execute(context.Background(), introspectCmd)
+	assert.NoError(t, err)
+	assert.True(t, introspection.Active)
+
+	clk.Advance(time.Second)
+	_, _, err = authenticate.Execute(context.Background(), authenticateCmd)
+	assert.Equal(t, appErrors.Unauthorized.String(), codeOf(err))
+	introspection, err = introspect.Execute(context.Background(), introspectCmd)
+	assert.NoError(t, err)
+	assert.False(t, introspection.Active)
+}
+
+func introspect(t *testing.T, repo *memoryServiceAccounts, roles *memoryRoles, token string, tenantID string) *entities.TokenIntrospection {
+	t.Helper()
+	cmd, err := introspect_token_use_case.NewIntrospectTokenCommand(token, tenantID)
+	assert.NoError(t, err)
+	introspection, err := introspect_token_use_case.NewIntrospectTokenUseCase(repo, roles).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return introspection
+}
+
+func TestIntrospectToken(t *testing.T) {
+	repo, roles := newMemoryServiceAccounts(), newMemoryRoles()
+	account, token := createAccount(t, repo, roles)
+
+	introspection := introspect(t, repo, roles, token, tenantID)
+	assert.True(t, introspection.Active)
+	assert.Equal(t, account.ID, introspection.Account.ID)
+	assert.Equal(t, roles.roles[account.Subject()+"|"+tenantID], introspection.Account.Roles)
+	assert.Equal(t, account.Keys[0].ID, introspection.Key.ID)
+
+	for name, candidate := range map[string]string{
+		"malformed":    "not-a-token",
+		"wrong secret": token[:len(token)-2] + "xx",
+		"unknown key":  "sa_9b2f1c1e-1d2a-4c4e-9c57-2f0e5b8d7a10_secret",
+	} {
+		assert.False(t, introspect(t, repo, roles, candidate, tenantID).Active, name)
+	}
+	assert.False(t, introspect(t, repo, roles, token, "tenant2").Active, "tokens of other tenants are not disclosed")
+}
+
+func TestRevokeToken_DeactivatesTheToken(t *testing.T) {
+	repo, roles := newMemoryServiceAccounts(), newMemoryRoles()
+	account, token := createAccount(t, repo, roles)
+	revoke := revoke_token_use_case.NewRevokeTokenUseCase(repo)
+
+	cmd, err := revoke_token_use_case.NewRevokeTokenCommand(token)
+	assert.NoError(t, err)
+	assert.NoError(t, revoke.Execute(context.Background(), cmd))
+
+	assert.False(t, introspect(t, repo, roles, token, tenantID).Active)
+	last := repo.changes[len(repo.changes)-1]
+	assert.Equal(t, events.ServiceAccountKeyRevoked, last.Type)
+	assert.Equal(t, account.Subject(), last.ActorID, "the token revoked itself")
+
+	// Revoking again or revoking garbage answers the same
+	changes := len(repo.changes)
+	assert.NoError(t, revoke.Execute(context.Background(), cmd))
+	cmd, _ = revoke_token_use_case.NewRevokeTokenCommand("not-a-token")
+	assert.NoError(t, revoke.Execute(context.Background(), cmd))
+	assert.Len(t, repo.changes, changes)
+}
+
+func TestRevokeToken_RevokesAtTheTimeOfTheClock(t *testing.T) {
+	clk := clocktest.New(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC))
+	repo, roles := newMemoryServiceAccounts(), newMemoryRoles()
+	cmd, _ := create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "Portal", "", "", []string{"instructor"}, entities.ClientTypeWeb, "", nil)
+	account, token, err := create_service_account_use_case.NewCreateServiceAccountUseCase(repo, roles, newMemoryTokenPolicies()).WithClock(clk).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	revoke := revoke_token_use_case.NewRevokeTokenUseCase(repo).WithClock(clk)
+	revokeCmd, _ := revoke_token_use_case.NewRevokeTokenCommand(token)
+
+	// By the system clock the key has long expired and would be left as it is
+	clk.Advance(entities.DefaultTokenPolicy(tenantID, entities.ClientTypeWeb).KeyLifetime - time.Hour)
+	assert.NoError(t, revoke.Execute(context.Background(), revokeCmd))
+
+	revokedAt := repo.keys[account.Keys[0].ID].RevokedAt
+	if assert.NotNil(t, revokedAt) {
+		assert.Equal(t, clk.Now().UTC(), *revokedAt)
+	}
+	introspectCmd, _ := introspect_token_use_case.NewIntrospectTokenCommand(token, tenantID)
+	introspection, err := introspect_token_use_case.NewIntrospectTokenUseCase(repo, roles).WithClock(clk).Execute(context.Background(), introspectCmd)
+	assert.NoError(t, err)
+	assert.False(t, introspection.Active, "introspection agrees with the revocation")
+}
+
+func TestParseServiceAccountToken(t *testing.T) {
+	keyID, secret, ok := entities.ParseServiceAccountToken("sa_9b2f1c1e-1d2a-4c4e-9c57-2f0e5b8d7a10_ab_c-d")
+	assert.True(t, ok)
+	assert.Equal(t, "9b2f1c1e-1d2a-4c4e-9c57-2f0e5b8d7a10", keyID)
+	assert.Equal(t, "ab_c-d", secret, "secrets may contain underscores")
+
+	for _, token := range []string{"", "sa_", "sa_not-a-uuid_secret", "sa_9b2f1c1e-1d2a-4c4e-9c57-2f0e5b8d7a10_", "key_9b2f1c1e-1d2a-4c4e-9c57-2f0e5b8d7a10_x"} {
+		_, _, ok := entities.ParseServiceAccountToken(token)
+		assert.False(t, ok, token)
+	}
+}
+
+func TestServiceAccountKeys_CarryScopesNarrowedFromTheRoles(t *testing.T) {
+	repo, roles, policies := newMemoryServiceAccounts(), newMemoryRoles(), newMemoryTokenPolicies()
+	create := create_service_account_use_case.NewCreateServiceAccountUseCase(repo, roles, policies)
+
+	account, token := createAccount(t, repo, roles)
+	assert.Equal(t, []string{"grade:assign", "grade:view"}, account.Keys[0].Scopes, "keys get every permission of the roles")
+	assert.Equal(t, "grade:assign grade:view", strings.Join(introspect(t, repo, roles, token, tenantID).Scopes, " "))
+
+	cmd, _ := create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "Gradebook reader", "", "", []string{"instructor"}, "", "",
+		[]string{"grade:view", "grade:view"})
+	reader, _, err := create.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	key := reader.Keys[0]
+	assert.Equal(t, []string{"grade:view"}, key.Scopes)
+	assert.True(t, key.Allows("grade", "view"))
+	assert.False(t, key.Allows("grade", "assign"))
+
+	cmd, _ = create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "Sync", "", "", []string{"instructor"}, "", "",
+		[]string{"user:manage"})
+	_, _, err = create.Execute(context.Background(), cmd)
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err), "keys never get more than the roles")
+
+	cmd, _ = create_service_account_use_case.NewCreateServiceAccountCommand(tenantID, "admin-1", "Ops", "", "", []string{"admin"}, "", "",
+		[]string{"audit_log:export"})
+	ops, _, err := create.Execute(context.Background(), cmd)
+	assert.NoError(t, err, "all:all covers any permission")
+	assert.True(t, ops.Keys[0].Allows("audit_log", "export"))
+	assert.False(t, ops.Keys[0].Allows("user", "manage"))
+
+	rotateCmd, _ := rotate_service_account_key_use_case.NewRotateServiceAccountKeyCommand(tenantID, reader.ID, "admin-1", nil, "", []string{"grade:assign"})
+	_, rotated, _, err := rotate_service_account_key_use_case.NewRotateServiceAccountKeyUseCase(repo, roles, policies).Execute(context.Background(), rotateCmd)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"grade:assign"}, rotated.Scopes, "rotations may pick other permissions of the roles")
+
+	legacy := &entities.ServiceAccountKey{}
+	assert.True(t, legacy.Allows("user", "manage"), "keys issued before scopes are only limited by the roles")
+}
+
+func TestScopesAllow(t *testing.T) {
+	assert.True(t, entities.ScopesAllow([]string{"grade:view"}, "grade", "view"))
+	assert.True(t, entities.ScopesAllow([]string{"grade:all"}, "grade", "assign"))
+	assert.True(t, entities.ScopesAllow([]string{"all:view"}, "incident", "view"))
+	assert.False(t, entities.ScopesAllow([]string{"all:view"}, "incident", "manage"))
+	assert.False(t, entities.ScopesAllow(nil, "grade", "view"))
+}
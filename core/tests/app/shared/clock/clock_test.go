@@ -0,0 +1,118 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/core/tests/clocktest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+// job mimics the workers, it works once and then on every tick until ctx is cancelled
+func job(ctx context.Context, c clock.Clock, interval time.Duration, work func(now time.Time)) {
+	ticker := c.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		work(c.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// runs records the times job worked at
+type runs struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (r *runs) record(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.times = append(r.times, now)
+}
+
+func (r *runs) get() []time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]time.Time(nil), r.times...)
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	assert.False(t, clock.System.Now().Before(before))
+
+	ticker := clock.System.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	assert.False(t, (<-ticker.C()).Before(before))
+}
+
+func TestVirtualClock_AdvanceRunsEveryDueTickInOrder(t *testing.T) {
+	clk := clocktest.New(start)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var worked runs
+	go job(ctx, clk, time.Minute, worked.record)
+
+	clk.WaitForTickers(1)
+	assert.Equal(t, []time.Time{start}, worked.get(), "the first run happens before any tick")
+
+	clk.Advance(3*time.Minute + 30*time.Second)
+	assert.Equal(t, []time.Time{start, start.Add(time.Minute), start.Add(2 * time.Minute), start.Add(3 * time.Minute)},
+		worked.get(), "every tick is handled at its own time before Advance returns")
+	assert.Equal(t, start.Add(3*time.Minute+30*time.Second), clk.Now())
+
+	clk.Advance(30 * time.Second)
+	assert.Len(t, worked.get(), 5)
+	assert.Equal(t, start.Add(4*time.Minute), worked.get()[4])
+}
+
+func TestVirtualClock_TickersOfSeveralJobsInterleave(t *testing.T) {
+	clk := clocktest.New(start)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var worked runs
+	go job(ctx, clk, 2*time.Hour, worked.record)
+	go job(ctx, clk, 3*time.Hour, worked.record)
+	clk.WaitForTickers(2)
+
+	clk.Advance(6 * time.Hour)
+
+	times := worked.get()
+	if assert.Len(t, times, 7) {
+		// Both first runs, then the ticks at 2h, 3h, 4h and the ones of both jobs at 6h
+		for i := 1; i < len(times); i++ {
+			assert.False(t, times[i].Before(times[i-1]), "%v ran before %v", times[i], times[i-1])
+		}
+		assert.Equal(t, start.Add(6*time.Hour), times[6])
+	}
+}
+
+func TestVirtualClock_StoppedTickersDoNotBlock(t *testing.T) {
+	clk := clocktest.New(start)
+	ctx, cancel := context.WithCancel(context.Background())
+	var worked runs
+	done := make(chan struct{})
+	go func() {
+		job(ctx, clk, time.Minute, worked.record)
+		close(done)
+	}()
+	clk.WaitForTickers(1)
+
+	cancel()
+	<-done
+	clk.Advance(time.Hour)
+
+	assert.Len(t, worked.get(), 1)
+	assert.Equal(t, start.Add(time.Hour), clk.Now())
+}
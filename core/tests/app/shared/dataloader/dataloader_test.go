@@ -0,0 +1,106 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/shared/dataloader"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingBatch loads keys starting with "user" and records every batch it is called with
+type countingBatch struct {
+	batches [][]string
+}
+
+func (b *countingBatch) load(_ context.Context, keys []string) (map[string]string, error) {
+	b.batches = append(b.batches, keys)
+	values := make(map[string]string)
+	for _, key := range keys {
+		if strings.HasPrefix(key, "user") {
+			values[key] = strings.ToUpper(key)
+		}
+	}
+	return values, nil
+}
+
+func TestLoader_LoadsEachKeyOnce(t *testing.T) {
+	batch := &countingBatch{}
+	loader := dataloader.New(batch.load, 10)
+
+	values, err := loader.LoadMany(context.Background(), []string{"user-1", "user-2", "user-1", "ghost"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"user-1": "USER-1", "user-2": "USER-2"}, values)
+
+	// Known keys and keys without a value are not loaded again
+	value, ok, err := loader.Load(context.Background(), "user-2")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "USER-2", value)
+	_, ok, err = loader.Load(context.Background(), "ghost")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Equal(t, [][]string{{"user-1", "user-2", "ghost"}}, batch.batches)
+}
+
+func TestLoader_SplitsLargeBatches(t *testing.T) {
+	batch := &countingBatch{}
+	loader := dataloader.New(batch.load, 2)
+
+	values, err := loader.LoadMany(context.Background(), []string{"user-1", "user-2", "user-3", "user-4", "user-5"})
+
+	assert.NoError(t, err)
+	assert.Len(t, values, 5)
+	assert.Equal(t, [][]string{{"user-1", "user-2"}, {"user-3", "user-4"}, {"user-5"}}, batch.batches)
+}
+
+func TestLoader_PrimedValuesAreNotLoaded(t *testing.T) {
+	batch := &countingBatch{}
+	loader := dataloader.New(batch.load, 10)
+	loader.Prime("user-1", "Primed")
+
+	values, err := loader.LoadMany(context.Background(), []string{"user-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Primed", values["user-1"])
+	assert.Empty(t, batch.batches)
+}
+
+func TestLoader_ErrorsAreNotCached(t *testing.T) {
+	calls := 0
+	loader := dataloader.New(func(_ context.Context, keys []string) (map[string]string, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("connection reset")
+		}
+		return map[string]string{"user-1": "USER-1"}, nil
+	}, 10)
+
+	_, err := loader.LoadMany(context.Background(), []string{"user-1"})
+	assert.Error(t, err)
+
+	values, err := loader.LoadMany(context.Background(), []string{"user-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "USER-1", values["user-1"])
+}
+
+func TestFor_SharesLoadersWithinAScope(t *testing.T) {
+	batch := &countingBatch{}
+	ctx := dataloader.WithScope(context.Background())
+
+	_, err := dataloader.For(ctx, "users", batch.load).LoadMany(ctx, []string{"user-1"})
+	assert.NoError(t, err)
+	_, err = dataloader.For(ctx, "users", batch.load).LoadMany(ctx, []string{"user-1"})
+	assert.NoError(t, err)
+	assert.Len(t, batch.batches, 1)
+
+	// Another request starts with an empty loader
+	other := dataloader.WithScope(context.Background())
+	_, err = dataloader.For(other, "users", batch.load).LoadMany(other, []string{"user-1"})
+	assert.NoError(t, err)
+	assert.Len(t, batch.batches, 2)
+}
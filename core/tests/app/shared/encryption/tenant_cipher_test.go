@@ -0,0 +1,141 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/encryption"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryKeyStore keeps keys like the tenant_keys table, creating an existing key does nothing
+type memoryKeyStore struct {
+	keys map[string]encryption.TenantKey
+}
+
+func newMemoryKeyStore() *memoryKeyStore {
+	return &memoryKeyStore{keys: make(map[string]encryption.TenantKey)}
+}
+
+func (s *memoryKeyStore) Find(_ context.Context, tenantID string) (*encryption.TenantKey, error) {
+	key, ok := s.keys[tenantID]
+	if !ok {
+		return nil, nil
+	}
+	return &key, nil
+}
+
+func (s *memoryKeyStore) Create(_ context.Context, key *encryption.TenantKey) error {
+	if _, ok := s.keys[key.TenantID]; !ok {
+		s.keys[key.TenantID] = *key
+	}
+	return nil
+}
+
+func (s *memoryKeyStore) Destroy(_ context.Context, tenantID string, destroyedAt time.Time) error {
+	key := s.keys[tenantID]
+	key.WrappedKey = nil
+	if key.DestroyedAt == nil {
+		key.DestroyedAt = &destroyedAt
+	}
+	s.keys[tenantID] = key
+	return nil
+}
+
+// prefixKeyWrapper binds keys to their tenant with a prefix, the master key wrapper of infra has its
+// own tests
+type prefixKeyWrapper struct{}
+
+func (prefixKeyWrapper) Wrap(_ context.Context, tenantID string, key []byte) ([]byte, error) {
+	return append([]byte(tenantID+":"), key...), nil
+}
+
+func (prefixKeyWrapper) Unwrap(_ context.Context, tenantID string, wrapped []byte) ([]byte, error) {
+	key, ok := bytes.CutPrefix(wrapped, []byte(tenantID+":"))
+	if !ok {
+		return nil, errors.New("key wrapped for another tenant")
+	}
+	return key, nil
+}
+
+func newCipher(store encryption.TenantKeyStore) *encryption.TenantCipher {
+	return encryption.NewTenantCipher(store, prefixKeyWrapper{})
+}
+
+func TestTenantCipher_RoundTripsWithAKeyPerTenant(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryKeyStore()
+	cipher := newCipher(store)
+
+	first, err := cipher.Encrypt(ctx, "tenant1", "Student was sent home")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first, "enc:v1:"))
+	assert.NotContains(t, first, "sent home")
+	second, err := cipher.Encrypt(ctx, "tenant2", "Student was sent home")
+	assert.NoError(t, err)
+	assert.NotEqual(t, store.keys["tenant1"].WrappedKey, store.keys["tenant2"].WrappedKey)
+
+	plaintext, err := cipher.Decrypt(ctx, "tenant1", first)
+	assert.NoError(t, err)
+	assert.Equal(t, "Student was sent home", plaintext)
+
+	// Values are bound to their tenant, another tenant's key cannot open them
+	_, err = cipher.Decrypt(ctx, "tenant1", second)
+	assert.Error(t, err)
+
+	// A restarted instance unwraps the stored key
+	plaintext, err = newCipher(store).Decrypt(ctx, "tenant2", second)
+	assert.NoError(t, err)
+	assert.Equal(t, "Student was sent home", plaintext)
+}
+
+func TestTenantCipher_ReadsValuesWrittenBeforeEncryption(t *testing.T) {
+	plaintext, err := newCipher(newMemoryKeyStore()).Decrypt(context.Background(), "tenant1", "Written in plaintext")
+	assert.NoError(t, err)
+	assert.Equal(t, "Written in plaintext", plaintext)
+
+	var disabled *encryption.TenantCipher
+	stored, err := disabled.Encrypt(context.Background(), "tenant1", "Not encrypted")
+	assert.NoError(t, err)
+	assert.Equal(t, "Not encrypted", stored)
+}
+
+func TestTenantCipher_ShredMakesTheTenantUnreadable(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryKeyStore()
+	cipher := newCipher(store)
+	stored, _ := cipher.Encrypt(ctx, "tenant1", "Sensitive note")
+	other, _ := cipher.Encrypt(ctx, "tenant2", "Other note")
+
+	assert.NoError(t, cipher.Shred(ctx, "tenant1"))
+
+	assert.Nil(t, store.keys["tenant1"].WrappedKey)
+	_, err := cipher.Decrypt(ctx, "tenant1", stored)
+	var applicationError appErrors.ApplicationError
+	assert.ErrorAs(t, err, &applicationError)
+	assert.Equal(t, appErrors.TenantKeyDestroyed.String(), applicationError.GetCode())
+	_, err = cipher.Encrypt(ctx, "tenant1", "New note")
+	assert.Error(t, err, "a shredded tenant never gets a new key")
+
+	plaintext, err := cipher.Decrypt(ctx, "tenant2", other)
+	assert.NoError(t, err)
+	assert.Equal(t, "Other note", plaintext)
+}
+
+func TestTenantCipher_ShredTenantWithoutKey(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryKeyStore()
+	cipher := newCipher(store)
+
+	assert.NoError(t, cipher.Shred(ctx, "tenant1"))
+
+	assert.NotNil(t, store.keys["tenant1"].DestroyedAt)
+	_, err := cipher.Encrypt(ctx, "tenant1", "New note")
+	assert.Error(t, err)
+}
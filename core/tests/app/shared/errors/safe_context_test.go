@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	cockroach "github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfrastructureError_HidesContextByDefault(t *testing.T) {
+	err := errors.NewInfrastructureError("SELECT * FROM users WHERE email = 'ana@colegio.edu.gt'", cockroach.New("timeout"))
+
+	assert.Nil(t, err.GetContext())
+	assert.False(t, errors.IsRetryable(err))
+}
+
+func TestInfrastructureError_ExposesSanitizedSafeContext(t *testing.T) {
+	err := errors.NewInfrastructureError("redis GET failed", cockroach.New("dial tcp 10.0.0.7:6379: i/o timeout")).
+		WithSafeContext(errors.SafeContext{Operation: "redis.GET", Resource: "cache <script>", Retryable: true})
+
+	assert.Equal(t, map[string]any{
+		"operation": "redis.get",
+		"resource":  "cache_script",
+		"retryable": true,
+	}, err.GetContext())
+	assert.True(t, errors.IsRetryable(errors.PropagateError(cockroach.Wrap(err, "loading session"))))
+}
+
+func TestInfrastructureError_HTTPResponseKeepsDetailsInternal(t *testing.T) {
+	err := errors.NewInfrastructureError("failed to connect to redis at 10.0.0.7", cockroach.New("connection refused")).
+		WithSafeContext(errors.SafeContext{Operation: "redis.connect", Resource: "redis", Retryable: true})
+
+	response := utils.ApplicationErrorToHTTPResponse(err)
+
+	assert.Equal(t, http.StatusInternalServerError, response.Status)
+	assert.Equal(t, "Internal server error", response.Error.Message)
+	assert.Equal(t, map[string]any{"operation": "redis.connect", "resource": "redis", "retryable": true}, response.Error.Context)
+}
@@ -0,0 +1,80 @@
+package filtering
+
+import (
+	"testing"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/filtering"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var fields = filtering.Fields{
+	"status":     filtering.StringField,
+	"created_at": filtering.TimeField,
+	"score":      filtering.NumberField,
+	"active":     filtering.BoolField,
+}
+
+func TestParse_ComparisonsAndPrecedence(t *testing.T) {
+	expr, err := filtering.Parse(`status == "active" OR score >= 90 AND NOT active == false`, fields)
+
+	assert.NoError(t, err)
+	assert.Equal(t, filtering.Logical{
+		Operator: filtering.Or,
+		Left:     filtering.Comparison{Field: "status", Operator: filtering.Equal, Value: "active"},
+		Right: filtering.Logical{
+			Operator: filtering.And,
+			Left:     filtering.Comparison{Field: "score", Operator: filtering.GreaterOrEqual, Value: float64(90)},
+			Right:    filtering.Not{Expr: filtering.Comparison{Field: "active", Operator: filtering.Equal, Value: false}},
+		},
+	}, expr)
+}
+
+func TestParse_ParenthesesAndDates(t *testing.T) {
+	expr, err := filtering.Parse(`(status != "archived") and created_at > "2024-01-01"`, fields)
+
+	assert.NoError(t, err)
+	logical, ok := expr.(filtering.Logical)
+	assert.True(t, ok)
+	assert.Equal(t, filtering.Comparison{
+		Field:    "created_at",
+		Operator: filtering.GreaterThan,
+		Value:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, logical.Right)
+}
+
+func TestParse_Empty(t *testing.T) {
+	expr, err := filtering.Parse("   ", fields)
+
+	assert.NoError(t, err)
+	assert.Nil(t, expr)
+}
+
+func TestParse_Rejections(t *testing.T) {
+	cases := map[string]string{
+		"unknown field":      `password == "secret"`,
+		"type mismatch":      `score == "high"`,
+		"bad date":           `created_at > "yesterday"`,
+		"unterminated":       `status == "active`,
+		"missing operand":    `status ==`,
+		"trailing tokens":    `status == "a" "b"`,
+		"unbalanced":         `(status == "a"`,
+		"bool ordering":      `active > true`,
+		"sql injection":      `status == "a"; DROP TABLE users`,
+		"unsupported symbol": `status = "a"`,
+	}
+
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			expr, err := filtering.Parse(input, fields)
+
+			assert.Nil(t, expr)
+			var appErr appErrors.ApplicationError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, string(appErrors.ValidationError), appErr.GetCode())
+			assert.Contains(t, appErr.GetContext(), "filter")
+		})
+	}
+}
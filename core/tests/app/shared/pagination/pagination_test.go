@@ -0,0 +1,201 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var allowedFields = []string{"name", "created_at"}
+var defaultOrder = pagination.OrderBy{Field: "created_at", Direction: pagination.Descending}
+
+func TestNewPageRequest_Defaults(t *testing.T) {
+	page, err := pagination.NewPageRequest(0, "", "", allowedFields, defaultOrder)
+
+	assert.NoError(t, err)
+	assert.Equal(t, pagination.DefaultPageSize, page.PageSize)
+	assert.Nil(t, page.After)
+	assert.Equal(t, []pagination.OrderBy{defaultOrder}, page.OrderBy)
+}
+
+func TestNewPageRequest_ParsesOrderBy(t *testing.T) {
+	page, err := pagination.NewPageRequest(10, "", "name desc, created_at", allowedFields, defaultOrder)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []pagination.OrderBy{
+		{Field: "name", Direction: pagination.Descending},
+		{Field: "created_at", Direction: pagination.Ascending},
+	}, page.OrderBy)
+}
+
+func TestNewPageRequest_RejectsInvalidParameters(t *testing.T) {
+	page, err := pagination.NewPageRequest(pagination.MaxPageSize+1, "not-a-cursor", "password", allowedFields, defaultOrder)
+
+	assert.Nil(t, page)
+	var appErr appErrors.ApplicationError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, string(appErrors.ValidationError), appErr.GetCode())
+	assert.Contains(t, appErr.GetContext(), "page_size")
+	assert.Contains(t, appErr.GetContext(), "cursor")
+	assert.Contains(t, appErr.GetContext(), "order_by")
+}
+
+// item is a listed row, ordered newest first with its id breaking ties
+type item struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+func itemKeys(i item) []string {
+	return []string{pagination.TimeKey(i.CreatedAt), i.ID}
+}
+
+// store lists its items by keyset like the sqlc queries do, items are inserted while pages are read
+type store struct {
+	mu    sync.Mutex
+	items []item
+}
+
+func (s *store) insert(i item) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = append(s.items, i)
+}
+
+func (s *store) list(page *pagination.PageRequest) *pagination.Page[item] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sorted := slices.Clone(s.items)
+	slices.SortFunc(sorted, func(a, b item) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+
+	fetched := make([]item, 0, page.PageSize+1)
+	for _, i := range sorted {
+		if page.After != nil {
+			after, _ := pagination.ParseTimeKey(page.After[0])
+			if i.CreatedAt.After(after) || (i.CreatedAt.Equal(after) && i.ID >= page.After[1]) {
+				continue
+			}
+		}
+		fetched = append(fetched, i)
+		if len(fetched) == page.PageSize+1 {
+			break
+		}
+	}
+	return pagination.NewPage(page, fetched, int64(len(s.items)), itemKeys)
+}
+
+func TestNewPage_CursorRoundTrip(t *testing.T) {
+	first, err := pagination.NewPageRequest(2, "", "", allowedFields, defaultOrder)
+	assert.NoError(t, err)
+
+	base := time.Date(2025, 3, 1, 8, 0, 0, 123456000, time.UTC)
+	items := []item{{CreatedAt: base.Add(2 * time.Minute), ID: "c"}, {CreatedAt: base.Add(time.Minute), ID: "b"}, {CreatedAt: base, ID: "a"}}
+	page := pagination.NewPage(first, items, 5, itemKeys)
+	assert.Equal(t, items[:2], page.Items)
+	assert.NotEmpty(t, page.NextCursor)
+
+	second, err := pagination.NewPageRequest(2, page.NextCursor, "", allowedFields, defaultOrder)
+	assert.NoError(t, err)
+	assert.Equal(t, itemKeys(items[1]), second.After, "the cursor starts after the last item of the page")
+
+	last := pagination.NewPage(second, items[2:], 5, itemKeys)
+	assert.Empty(t, last.NextCursor)
+}
+
+func TestNewPageRequest_RejectsTamperedCursors(t *testing.T) {
+	first, err := pagination.NewPageRequest(1, "", "", allowedFields, defaultOrder)
+	assert.NoError(t, err)
+	cursor := pagination.NewPage(first, []item{{ID: "b"}, {ID: "a"}}, 2, itemKeys).NextCursor
+
+	payload, signature, _ := strings.Cut(cursor, ".")
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	assert.NoError(t, err)
+	edited := base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(raw), `"b"`, `"z"`, 1))) + "." + signature
+
+	for name, tampered := range map[string]string{
+		"edited keys":   edited,
+		"no signature":  payload,
+		"offset cursor": base64.RawURLEncoding.EncodeToString([]byte("o:20")),
+		"other key":     payload + "." + base64.RawURLEncoding.EncodeToString(make([]byte, 16)),
+		"not base64":    "not-a-cursor.!!",
+	} {
+		_, err := pagination.NewPageRequest(1, tampered, "", allowedFields, defaultOrder)
+		assert.Error(t, err, name)
+	}
+
+	_, err = pagination.NewPageRequest(1, cursor, "name asc", allowedFields, defaultOrder)
+	assert.Error(t, err, "a cursor only continues the order it was returned for")
+
+	_, err = pagination.NewPageRequest(1, cursor, "", allowedFields, defaultOrder)
+	assert.NoError(t, err)
+}
+
+func TestKeysetPages_StableUnderConcurrentInserts(t *testing.T) {
+	base := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	listed := &store{}
+	var existing []string
+	for i := 0; i < 50; i++ {
+		id := fmt.Sprintf("existing-%02d", i)
+		// Pairs share a timestamp, the id breaks the tie
+		listed.insert(item{CreatedAt: base.Add(time.Duration(i/2) * time.Second), ID: id})
+		existing = append(existing, id)
+	}
+
+	// Items keep arriving, newer than every page and at the times of the pages already read
+	stop := make(chan struct{})
+	var inserts sync.WaitGroup
+	inserts.Add(1)
+	go func() {
+		defer inserts.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			listed.insert(item{CreatedAt: base.Add(time.Hour + time.Duration(i)*time.Millisecond), ID: fmt.Sprintf("new-%04d", i)})
+			listed.insert(item{CreatedAt: base.Add(time.Duration(i%25) * time.Second), ID: fmt.Sprintf("zz-%04d", i)})
+			time.Sleep(time.Microsecond)
+		}
+	}()
+
+	seen := make(map[string]int)
+	cursor := ""
+	for pages := 0; pages < 1000; pages++ {
+		request, err := pagination.NewPageRequest(7, cursor, "", allowedFields, defaultOrder)
+		if !assert.NoError(t, err) {
+			break
+		}
+		page := listed.list(request)
+		for _, i := range page.Items {
+			seen[i.ID]++
+		}
+		if cursor = page.NextCursor; cursor == "" {
+			break
+		}
+	}
+	close(stop)
+	inserts.Wait()
+
+	for _, id := range existing {
+		assert.Equal(t, 1, seen[id], "%s is listed exactly once", id)
+	}
+	for id, count := range seen {
+		assert.Equal(t, 1, count, "%s is listed once", id)
+	}
+}
@@ -0,0 +1,126 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/app/shared/projection"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryEventLog struct {
+	events []events.Event
+}
+
+func (l *memoryEventLog) ReadAfter(_ context.Context, position int64, limit int) ([]events.Event, error) {
+	var batch []events.Event
+	for _, event := range l.events {
+		if event.Position > position && len(batch) < limit {
+			batch = append(batch, event)
+		}
+	}
+	return batch, nil
+}
+
+type memoryCheckpoints map[string]int64
+
+func (c memoryCheckpoints) Load(_ context.Context, projector string) (int64, error) {
+	return c[projector], nil
+}
+
+func (c memoryCheckpoints) Save(_ context.Context, projector string, position int64) error {
+	c[projector] = position
+	return nil
+}
+
+// rosterProjector counts enrollments per class
+type rosterProjector struct {
+	rosters map[string]int
+	failAt  int64
+}
+
+func (p *rosterProjector) Name() string { return "roster" }
+
+func (p *rosterProjector) Apply(_ context.Context, event events.Event) error {
+	if event.Position == p.failAt {
+		return errors.New("boom")
+	}
+	if event.Type == events.EnrollmentAdded {
+		p.rosters[event.String("class_id")]++
+	}
+	return nil
+}
+
+func (p *rosterProjector) Reset(_ context.Context) error {
+	p.rosters = make(map[string]int)
+	return nil
+}
+
+func enrollments(count int) *memoryEventLog {
+	eventLog := &memoryEventLog{}
+	for i := 1; i <= count; i++ {
+		eventLog.events = append(eventLog.events, events.Event{
+			Position: int64(i),
+			Type:     events.EnrollmentAdded,
+			Payload:  map[string]any{"class_id": "class-1"},
+		})
+	}
+	return eventLog
+}
+
+func TestRunner_CatchUp_AppliesPendingEventsAcrossBatches(t *testing.T) {
+	checkpoints := memoryCheckpoints{}
+	projector := &rosterProjector{rosters: map[string]int{}}
+	runner := projection.NewRunner(enrollments(projection.DefaultBatchSize+5), checkpoints, projector)
+
+	err := runner.CatchUp(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, projection.DefaultBatchSize+5, projector.rosters["class-1"])
+	assert.Equal(t, int64(projection.DefaultBatchSize+5), checkpoints["roster"])
+}
+
+func TestRunner_CatchUp_StartsFromCheckpoint(t *testing.T) {
+	checkpoints := memoryCheckpoints{"roster": 3}
+	projector := &rosterProjector{rosters: map[string]int{}}
+	runner := projection.NewRunner(enrollments(5), checkpoints, projector)
+
+	err := runner.CatchUp(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, projector.rosters["class-1"])
+}
+
+func TestRunner_CatchUp_StopsAtFailingEvent(t *testing.T) {
+	checkpoints := memoryCheckpoints{}
+	projector := &rosterProjector{rosters: map[string]int{}, failAt: 3}
+	runner := projection.NewRunner(enrollments(5), checkpoints, projector)
+
+	err := runner.CatchUp(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), checkpoints["roster"])
+}
+
+func TestRunner_Rebuild_ReplaysFromTheBeginning(t *testing.T) {
+	checkpoints := memoryCheckpoints{"roster": 5}
+	projector := &rosterProjector{rosters: map[string]int{"class-1": 99}}
+	runner := projection.NewRunner(enrollments(5), checkpoints, projector)
+
+	err := runner.Rebuild(context.Background(), "roster")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, projector.rosters["class-1"])
+	assert.Equal(t, int64(5), checkpoints["roster"])
+}
+
+func TestRunner_Rebuild_UnknownProjection(t *testing.T) {
+	runner := projection.NewRunner(enrollments(0), memoryCheckpoints{}, &rosterProjector{})
+
+	err := runner.Rebuild(context.Background(), "missing")
+
+	assert.Error(t, err)
+}
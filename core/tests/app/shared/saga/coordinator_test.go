@@ -0,0 +1,211 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/saga"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore keeps a snapshot of every saved instance and its lease, like the database would
+type memoryStore struct {
+	instances map[string]saga.Instance
+	leases    map[string]lease
+	saves     int
+}
+
+type lease struct {
+	owner string
+	until time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{instances: make(map[string]saga.Instance), leases: make(map[string]lease)}
+}
+
+func (s *memoryStore) Save(_ context.Context, instance *saga.Instance, owner string, leaseUntil time.Time) (bool, error) {
+	if current, ok := s.leases[instance.ID]; ok && current.owner != owner {
+		return false, nil
+	}
+	s.instances[instance.ID] = *instance
+	s.leases[instance.ID] = lease{owner: owner, until: leaseUntil}
+	s.saves++
+	return true, nil
+}
+
+func (s *memoryStore) ClaimUnfinished(_ context.Context, owner string, now time.Time, leaseUntil time.Time) ([]*saga.Instance, error) {
+	var unfinished []*saga.Instance
+	for id, instance := range s.instances {
+		if instance.IsFinished() || s.leases[id].until.After(now) {
+			continue
+		}
+		s.leases[id] = lease{owner: owner, until: leaseUntil}
+		copied := instance
+		unfinished = append(unfinished, &copied)
+	}
+	sort.Slice(unfinished, func(i, j int) bool { return unfinished[i].ID < unfinished[j].ID })
+	return unfinished, nil
+}
+
+func recordingStep(name string, calls *[]string, failOn string) saga.Step {
+	return saga.Step{
+		Name: name,
+		Execute: func(_ context.Context, data map[string]any) error {
+			*calls = append(*calls, "execute:"+name)
+			if name == failOn {
+				return errors.New("boom")
+			}
+			data[name] = true
+			return nil
+		},
+		Compensate: func(_ context.Context, _ map[string]any) error {
+			*calls = append(*calls, "compensate:"+name)
+			return nil
+		},
+	}
+}
+
+func TestCoordinator_Start_CompletesAllSteps(t *testing.T) {
+	store := newMemoryStore()
+	coordinator := saga.NewCoordinator(store)
+	var calls []string
+	coordinator.Register(saga.Definition{Name: "onboarding", Steps: []saga.Step{
+		recordingStep("create_tenant", &calls, ""),
+		recordingStep("seed_roles", &calls, ""),
+	}})
+
+	instance, err := coordinator.Start(context.Background(), "onboarding", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, saga.StatusCompleted, instance.Status)
+	assert.Equal(t, 2, instance.CompletedSteps)
+	assert.Equal(t, []string{"execute:create_tenant", "execute:seed_roles"}, calls)
+	assert.Equal(t, saga.StatusCompleted, store.instances[instance.ID].Status)
+}
+
+func TestCoordinator_Start_CompensatesInReverseOrder(t *testing.T) {
+	store := newMemoryStore()
+	coordinator := saga.NewCoordinator(store)
+	var calls []string
+	coordinator.Register(saga.Definition{Name: "onboarding", Steps: []saga.Step{
+		recordingStep("create_tenant", &calls, ""),
+		recordingStep("seed_roles", &calls, ""),
+		recordingStep("send_invite", &calls, "send_invite"),
+	}})
+
+	instance, err := coordinator.Start(context.Background(), "onboarding", nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, saga.StatusCompensated, instance.Status)
+	assert.Equal(t, 0, instance.CompletedSteps)
+	assert.Contains(t, instance.FailureReason, "send_invite")
+	assert.Equal(t, []string{
+		"execute:create_tenant", "execute:seed_roles", "execute:send_invite",
+		"compensate:seed_roles", "compensate:create_tenant",
+	}, calls)
+}
+
+func TestCoordinator_Resume_ContinuesFromLastCompletedStep(t *testing.T) {
+	store := newMemoryStore()
+	store.instances["crashed"] = saga.Instance{
+		ID:             "crashed",
+		SagaName:       "onboarding",
+		Status:         saga.StatusRunning,
+		CompletedSteps: 1,
+		Data:           map[string]any{"create_tenant": true},
+	}
+
+	coordinator := saga.NewCoordinator(store)
+	var calls []string
+	coordinator.Register(saga.Definition{Name: "onboarding", Steps: []saga.Step{
+		recordingStep("create_tenant", &calls, ""),
+		recordingStep("seed_roles", &calls, ""),
+	}})
+
+	err := coordinator.Resume(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"execute:seed_roles"}, calls)
+	assert.Equal(t, saga.StatusCompleted, store.instances["crashed"].Status)
+}
+
+func TestCoordinator_Resume_ContinuesAfterAFailingInstance(t *testing.T) {
+	store := newMemoryStore()
+	store.instances["a-unknown"] = saga.Instance{ID: "a-unknown", SagaName: "retired", Status: saga.StatusRunning, Data: map[string]any{}}
+	store.instances["b-failing"] = saga.Instance{ID: "b-failing", SagaName: "failing", Status: saga.StatusRunning, Data: map[string]any{}}
+	store.instances["c-crashed"] = saga.Instance{ID: "c-crashed", SagaName: "onboarding", Status: saga.StatusRunning, Data: map[string]any{}}
+
+	coordinator := saga.NewCoordinator(store)
+	var calls []string
+	coordinator.Register(saga.Definition{Name: "failing", Steps: []saga.Step{
+		recordingStep("sync_sis", &calls, "sync_sis"),
+	}})
+	coordinator.Register(saga.Definition{Name: "onboarding", Steps: []saga.Step{
+		recordingStep("create_tenant", &calls, ""),
+	}})
+
+	err := coordinator.Resume(context.Background())
+
+	assert.Error(t, err)
+	assert.ErrorContains(t, err, "a-unknown")
+	assert.ErrorContains(t, err, "boom")
+	assert.Equal(t, saga.StatusCompensated, store.instances["b-failing"].Status)
+	assert.Equal(t, saga.StatusCompleted, store.instances["c-crashed"].Status)
+}
+
+func TestCoordinator_Resume_SkipsInstancesLeasedToAnotherCoordinator(t *testing.T) {
+	store := newMemoryStore()
+	store.instances["running"] = saga.Instance{ID: "running", SagaName: "onboarding", Status: saga.StatusRunning, Data: map[string]any{}}
+	store.leases["running"] = lease{owner: "other", until: time.Now().Add(time.Minute)}
+
+	coordinator := saga.NewCoordinator(store)
+	var calls []string
+	coordinator.Register(saga.Definition{Name: "onboarding", Steps: []saga.Step{
+		recordingStep("create_tenant", &calls, ""),
+	}})
+
+	err := coordinator.Resume(context.Background())
+
+	assert.NoError(t, err)
+	assert.Empty(t, calls)
+	assert.Equal(t, saga.StatusRunning, store.instances["running"].Status)
+}
+
+func TestCoordinator_Start_StopsWhenTakenOver(t *testing.T) {
+	store := newMemoryStore()
+	coordinator := saga.NewCoordinator(store)
+	var calls []string
+	coordinator.Register(saga.Definition{Name: "onboarding", Steps: []saga.Step{
+		{
+			Name: "create_tenant",
+			Execute: func(_ context.Context, _ map[string]any) error {
+				// another coordinator claimed the instance while the step outlived the lease
+				for id := range store.leases {
+					store.leases[id] = lease{owner: "other", until: time.Now().Add(time.Minute)}
+				}
+				return nil
+			},
+		},
+		recordingStep("seed_roles", &calls, ""),
+	}})
+
+	instance, err := coordinator.Start(context.Background(), "onboarding", nil)
+
+	assert.ErrorContains(t, err, "taken over")
+	assert.Empty(t, calls)
+	assert.Equal(t, 0, store.instances[instance.ID].CompletedSteps)
+}
+
+func TestCoordinator_Start_UnknownSaga(t *testing.T) {
+	coordinator := saga.NewCoordinator(newMemoryStore())
+
+	instance, err := coordinator.Start(context.Background(), "missing", nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, instance)
+}
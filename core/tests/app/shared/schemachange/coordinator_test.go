@@ -0,0 +1,169 @@
+package schemachange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/schemachange"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryStore struct {
+	states map[string]schemachange.State
+	err    error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{states: make(map[string]schemachange.State)}
+}
+
+func (s *memoryStore) Find(_ context.Context, name string) (*schemachange.State, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	state, ok := s.states[name]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (s *memoryStore) Save(_ context.Context, state *schemachange.State) error {
+	s.states[state.Name] = *state
+	return nil
+}
+
+// rowsBackfill copies rows keyed 1..rows, keys are zero padded so they sort as text
+type rowsBackfill struct {
+	rows   int
+	copied []int
+}
+
+func (b *rowsBackfill) Total(_ context.Context) (int64, error) {
+	return int64(b.rows), nil
+}
+
+func (b *rowsBackfill) Batch(_ context.Context, cursor string, limit int) (string, int, error) {
+	start := 0
+	if cursor != "" {
+		fmt.Sscanf(cursor, "%d", &start)
+	}
+	copied := 0
+	for key := start + 1; key <= b.rows && copied < limit; key++ {
+		b.copied = append(b.copied, key)
+		cursor = fmt.Sprintf("%06d", key)
+		copied++
+	}
+	return cursor, copied, nil
+}
+
+func TestCoordinator_MovesThroughThePhases(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	backfill := &rowsBackfill{rows: 5}
+	coordinator := schemachange.NewCoordinator(store,
+		schemachange.Change{Name: "users-tenant-id", Backfill: backfill}).WithPhaseTTL(0).WithBatchSize(2)
+
+	var writes []string
+	write := func() {
+		err := coordinator.DualWrite(ctx, "users-tenant-id",
+			func(context.Context) error { writes = append(writes, "old"); return nil },
+			func(context.Context) error { writes = append(writes, "new"); return nil })
+		assert.NoError(t, err)
+	}
+
+	write()
+	assert.Equal(t, []string{"old"}, writes, "changes start in the old phase")
+
+	_, err := coordinator.Advance(ctx, "users-tenant-id", schemachange.PhaseExpanded)
+	assert.NoError(t, err)
+	writes = nil
+	write()
+	assert.Equal(t, []string{"old", "new"}, writes)
+
+	_, err = coordinator.Advance(ctx, "users-tenant-id", schemachange.PhaseReadNew)
+	assert.Error(t, err, "reads do not move before the backfill is done")
+
+	assert.NoError(t, coordinator.Backfill(ctx))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, backfill.copied)
+	state := store.states["users-tenant-id"]
+	assert.Equal(t, int64(5), state.Backfilled)
+	assert.Equal(t, 1.0, state.Progress())
+
+	_, err = coordinator.Advance(ctx, "users-tenant-id", schemachange.PhaseReadNew)
+	assert.NoError(t, err)
+	readsNew, err := coordinator.ReadsNew(ctx, "users-tenant-id")
+	assert.NoError(t, err)
+	assert.True(t, readsNew)
+
+	_, err = coordinator.Advance(ctx, "users-tenant-id", schemachange.PhaseContracted)
+	assert.NoError(t, err)
+	writes = nil
+	write()
+	assert.Equal(t, []string{"new"}, writes)
+
+	_, err = coordinator.Advance(ctx, "users-tenant-id", schemachange.PhaseExpanded)
+	assert.Error(t, err, "the old schema is stale once contracted")
+}
+
+func TestCoordinator_BackfillResumesFromTheSavedCursor(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	store.states["users-tenant-id"] = schemachange.State{
+		Name: "users-tenant-id", Phase: schemachange.PhaseExpanded, Cursor: "000003", Backfilled: 3, Total: 5,
+	}
+	backfill := &rowsBackfill{rows: 5}
+	coordinator := schemachange.NewCoordinator(store,
+		schemachange.Change{Name: "users-tenant-id", Backfill: backfill}).WithPhaseTTL(0)
+
+	resumed := store.states["users-tenant-id"]
+	assert.Equal(t, 0.6, resumed.Progress())
+	assert.NoError(t, coordinator.Backfill(ctx))
+
+	assert.Equal(t, []int{4, 5}, backfill.copied)
+	assert.NotNil(t, store.states["users-tenant-id"].BackfilledAt)
+}
+
+func TestCoordinator_BackfillWaitsForEveryInstanceToDualWrite(t *testing.T) {
+	ctx := context.Background()
+	backfill := &rowsBackfill{rows: 1}
+	coordinator := schemachange.NewCoordinator(newMemoryStore(),
+		schemachange.Change{Name: "users-tenant-id", Backfill: backfill}).WithPhaseTTL(time.Hour)
+
+	_, err := coordinator.Advance(ctx, "users-tenant-id", schemachange.PhaseExpanded)
+	assert.NoError(t, err)
+	assert.NoError(t, coordinator.Backfill(ctx))
+
+	assert.Empty(t, backfill.copied, "instances may still write only the old schema")
+}
+
+func TestCoordinator_KeepsThePhaseWhenTheStoreFails(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	store.states["users-tenant-id"] = schemachange.State{Name: "users-tenant-id", Phase: schemachange.PhaseReadNew}
+	coordinator := schemachange.NewCoordinator(store, schemachange.Change{Name: "users-tenant-id"}).WithPhaseTTL(0)
+
+	phase, err := coordinator.Phase(ctx, "users-tenant-id")
+	assert.NoError(t, err)
+	assert.Equal(t, schemachange.PhaseReadNew, phase)
+
+	store.err = errors.New("connection refused")
+	phase, err = coordinator.Phase(ctx, "users-tenant-id")
+	assert.NoError(t, err)
+	assert.Equal(t, schemachange.PhaseReadNew, phase)
+
+	_, err = coordinator.Phase(ctx, "other-change")
+	assert.Error(t, err)
+}
+
+func TestCoordinator_RejectsUnknownChanges(t *testing.T) {
+	coordinator := schemachange.NewCoordinator(newMemoryStore())
+
+	_, err := coordinator.Advance(context.Background(), "users-tenant-id", schemachange.PhaseExpanded)
+
+	assert.Error(t, err)
+}
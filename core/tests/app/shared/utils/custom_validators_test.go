@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/shared/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type enrollGuardianCommand struct {
+	TenantID   string
+	Email      string `validate:"required,email,school_email=TenantID"`
+	Phone      string `validate:"omitempty,phone"`
+	GradeLevel string `validate:"required,grade_level"`
+	ClassCode  string `validate:"required,class_code"`
+}
+
+var validate = validator.New()
+
+func validCommand() enrollGuardianCommand {
+	return enrollGuardianCommand{
+		TenantID:   "tenant-a",
+		Email:      "ana@colegio.edu.gt",
+		Phone:      "+502 1234-5678",
+		GradeLevel: "K",
+		ClassCode:  "MATH-101",
+	}
+}
+
+func TestCustomValidators_AcceptDomainFormats(t *testing.T) {
+	utils.SetSchoolEmailDomains(map[string][]string{"tenant-a": {"@Colegio.edu.gt"}})
+	t.Cleanup(func() { utils.SetSchoolEmailDomains(nil) })
+
+	command := validCommand()
+	assert.Nil(t, utils.ValidateStruct(validate, &command))
+
+	command.GradeLevel, command.ClassCode, command.Phone = "12", "7A", ""
+	assert.Nil(t, utils.ValidateStruct(validate, &command))
+}
+
+func TestCustomValidators_RejectWithReadableMessages(t *testing.T) {
+	utils.SetSchoolEmailDomains(map[string][]string{"tenant-a": {"colegio.edu.gt"}})
+	t.Cleanup(func() { utils.SetSchoolEmailDomains(nil) })
+
+	command := enrollGuardianCommand{
+		TenantID:   "tenant-a",
+		Email:      "ana@gmail.com",
+		Phone:      "1234",
+		GradeLevel: "13",
+		ClassCode:  "math 101",
+	}
+	err := utils.ValidateStruct(validate, &command)
+
+	assert.Equal(t, map[string]any{
+		"email":       "Must be an email address of the school's domain",
+		"phone":       "Invalid phone number (use the international format, e.g. +50212345678)",
+		"grade_level": "Invalid grade level (PK, K or 1 to 12)",
+		"class_code":  "Invalid class code (e.g. MATH-101)",
+	}, err.Context)
+}
+
+func TestCustomValidators_SchoolEmailAcceptsAnyDomainForUnlistedTenants(t *testing.T) {
+	utils.SetSchoolEmailDomains(map[string][]string{"tenant-a": {"colegio.edu.gt"}})
+	t.Cleanup(func() { utils.SetSchoolEmailDomains(nil) })
+
+	command := validCommand()
+	command.TenantID, command.Email = "tenant-b", "ana@gmail.com"
+
+	assert.Nil(t, utils.ValidateStruct(validate, &command))
+}
+
+func TestCustomValidators_TranslateMessages(t *testing.T) {
+	command := validCommand()
+	command.GradeLevel = "kinder"
+	err := utils.NewValidator().Struct(&command)
+
+	errorMap := utils.LocalizeValidationError(err, "es")
+	assert.Equal(t, "grade_level debe ser un grado válido: PK, K o de 1 a 12", errorMap["grade_level"])
+
+	errorMap = utils.LocalizeValidationError(err, "pt-BR")
+	assert.Equal(t, "grade_level deve ser uma série válida: PK, K ou de 1 a 12", errorMap["grade_level"])
+}
@@ -0,0 +1,251 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/webhook"
+	"github.com/nahualventure/class-backend/core/tests/clocktest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore keeps a snapshot of every delivery, like the database would
+type memoryStore struct {
+	deliveries map[string]webhook.Delivery
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{deliveries: make(map[string]webhook.Delivery)}
+}
+
+func (s *memoryStore) Create(_ context.Context, delivery *webhook.Delivery) (bool, error) {
+	for _, existing := range s.deliveries {
+		if existing.Source == delivery.Source && existing.EventID == delivery.EventID {
+			return false, nil
+		}
+	}
+	s.deliveries[delivery.ID] = *delivery
+	return true, nil
+}
+
+func (s *memoryStore) ClaimDue(_ context.Context, sources []string, now time.Time, leaseUntil time.Time, limit int) ([]*webhook.Delivery, error) {
+	var due []*webhook.Delivery
+	for id, delivery := range s.deliveries {
+		if delivery.IsFinished() || delivery.NextAttemptAt.After(now) || !slices.Contains(sources, delivery.Source) {
+			continue
+		}
+		delivery.Status = webhook.StatusProcessing
+		delivery.Attempts++
+		delivery.NextAttemptAt = leaseUntil
+		s.deliveries[id] = delivery
+		due = append(due, &delivery)
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ReceivedAt.Before(due[j].ReceivedAt) })
+	return due[:min(len(due), limit)], nil
+}
+
+func (s *memoryStore) Save(_ context.Context, delivery *webhook.Delivery) error {
+	s.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (s *memoryStore) DeleteProcessedBefore(_ context.Context, before time.Time) (int, error) {
+	deleted := 0
+	for id, delivery := range s.deliveries {
+		if delivery.Status == webhook.StatusProcessed && delivery.ProcessedAt.Before(before) {
+			delete(s.deliveries, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (s *memoryStore) only(t *testing.T) webhook.Delivery {
+	t.Helper()
+	require.Len(t, s.deliveries, 1)
+	for _, delivery := range s.deliveries {
+		return delivery
+	}
+	return webhook.Delivery{}
+}
+
+// recordingSource accepts requests signed "valid" and takes their event ID from the header, the
+// payloads it handled are recorded and handling fails while failures is positive
+type recordingSource struct {
+	handled  []string
+	failures int
+}
+
+func (r *recordingSource) source(name string) webhook.Source {
+	return webhook.Source{
+		Name: name,
+		Verify: func(_ context.Context, request webhook.Request) (string, error) {
+			if request.Headers["Signature"] != "valid" {
+				return "", appErrors.NewUnauthorizedError("signature mismatch")
+			}
+			return request.Headers["Event-Id"], nil
+		},
+		Handle: func(_ context.Context, request webhook.Request) error {
+			if r.failures > 0 {
+				r.failures--
+				return errors.New("provider state not there yet")
+			}
+			r.handled = append(r.handled, string(request.Payload))
+			return nil
+		},
+	}
+}
+
+func signed(payload string, eventID string) webhook.Request {
+	return webhook.Request{Payload: []byte(payload), Headers: map[string]string{"Signature": "valid", "Event-Id": eventID}}
+}
+
+var start = time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+func TestReceiver_PersistsBeforeProcessingAndOnlyOnce(t *testing.T) {
+	store := newMemoryStore()
+	source := &recordingSource{}
+	receiver := webhook.NewReceiver(store).WithClock(clocktest.New(start))
+	receiver.Register(source.source("stripe"))
+
+	created, err := receiver.Receive(context.Background(), "stripe", signed("first", "evt_1"))
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Empty(t, source.handled, "requests are processed by the worker, not while the provider waits")
+	assert.Equal(t, webhook.StatusPending, store.only(t).Status)
+
+	created, err = receiver.Receive(context.Background(), "stripe", signed("redelivered", "evt_1"))
+	require.NoError(t, err)
+	assert.False(t, created, "a redelivered event is acknowledged without a second delivery")
+
+	claimed, err := receiver.Process(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+	assert.Equal(t, []string{"first"}, source.handled)
+	delivery := store.only(t)
+	assert.Equal(t, webhook.StatusProcessed, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, start, *delivery.ProcessedAt)
+
+	claimed, err = receiver.Process(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, claimed)
+	assert.Len(t, source.handled, 1)
+}
+
+func TestReceiver_RefusesUnverifiedRequests(t *testing.T) {
+	store := newMemoryStore()
+	receiver := webhook.NewReceiver(store)
+	receiver.Register((&recordingSource{}).source("stripe"))
+
+	_, err := receiver.Receive(context.Background(), "stripe", webhook.Request{Payload: []byte("forged"), Headers: map[string]string{"Signature": "forged"}})
+	var appErr appErrors.ApplicationError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, appErrors.Unauthorized.String(), appErr.GetCode())
+	assert.Empty(t, store.deliveries)
+
+	_, err = receiver.Receive(context.Background(), "ses", signed("{}", "msg-1"))
+	assert.Error(t, err, "sources must be registered")
+}
+
+func TestReceiver_DeduplicatesRequestsWithoutIDByPayload(t *testing.T) {
+	store := newMemoryStore()
+	receiver := webhook.NewReceiver(store)
+	receiver.Register((&recordingSource{}).source("sendgrid"))
+
+	for _, payload := range []string{"batch-1", "batch-1", "batch-2"} {
+		_, err := receiver.Receive(context.Background(), "sendgrid", signed(payload, ""))
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, store.deliveries, 2)
+	for _, delivery := range store.deliveries {
+		assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, delivery.EventID)
+	}
+}
+
+func TestReceiver_RetriesFailuresWithBackoffThenGivesUp(t *testing.T) {
+	store := newMemoryStore()
+	clk := clocktest.New(start)
+	source := &recordingSource{failures: 5}
+	receiver := webhook.NewReceiver(store).WithClock(clk).WithMaxAttempts(3)
+	receiver.Register(source.source("similarity"))
+	_, err := receiver.Receive(context.Background(), "similarity", signed("result", "cb-1"))
+	require.NoError(t, err)
+
+	_, err = receiver.Process(context.Background())
+	require.NoError(t, err, "handling failures are recorded on the delivery")
+	delivery := store.only(t)
+	assert.Equal(t, webhook.StatusPending, delivery.Status)
+	assert.Equal(t, "provider state not there yet", delivery.LastError)
+	assert.Equal(t, start.Add(30*time.Second), delivery.NextAttemptAt)
+
+	claimed, err := receiver.Process(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, claimed, "the retry is not due yet")
+
+	clk.Advance(30 * time.Second)
+	_, err = receiver.Process(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, clk.Now().Add(time.Minute), store.only(t).NextAttemptAt, "the delay doubles")
+
+	clk.Advance(time.Minute)
+	_, err = receiver.Process(context.Background())
+	require.NoError(t, err)
+	delivery = store.only(t)
+	assert.Equal(t, webhook.StatusFailed, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Empty(t, source.handled)
+}
+
+func TestReceiver_TakesOverDeliveriesOfStoppedWorkers(t *testing.T) {
+	store := newMemoryStore()
+	clk := clocktest.New(start)
+	source := &recordingSource{}
+	receiver := webhook.NewReceiver(store).WithClock(clk).WithLease(time.Minute)
+	receiver.Register(source.source("ses"))
+	_, err := receiver.Receive(context.Background(), "ses", signed("bounce", "msg-1"))
+	require.NoError(t, err)
+
+	// A worker claimed the delivery and stopped before saving its outcome
+	_, err = store.ClaimDue(context.Background(), []string{"ses"}, start, start.Add(time.Minute), 20)
+	require.NoError(t, err)
+
+	claimed, err := receiver.Process(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, claimed, "the delivery is leased")
+
+	clk.Advance(time.Minute)
+	_, err = receiver.Process(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bounce"}, source.handled)
+	assert.Equal(t, 2, store.only(t).Attempts)
+}
+
+func TestReceiver_LeavesSourcesItDidNotRegister(t *testing.T) {
+	store := newMemoryStore()
+	billing := webhook.NewReceiver(store)
+	billing.Register((&recordingSource{}).source("stripe"))
+	_, err := billing.Receive(context.Background(), "stripe", signed("invoice", "evt_1"))
+	require.NoError(t, err)
+
+	other := webhook.NewReceiver(store)
+	other.Register((&recordingSource{}).source("ses"))
+	claimed, err := other.Process(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, claimed)
+	assert.Equal(t, webhook.StatusPending, store.only(t).Status)
+}
+
+func TestRetryDelay_DoublesUpToAnHour(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhook.RetryDelay(1))
+	assert.Equal(t, 4*time.Minute, webhook.RetryDelay(4))
+	assert.Equal(t, time.Hour, webhook.RetryDelay(20))
+}
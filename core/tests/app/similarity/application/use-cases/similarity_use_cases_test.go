@@ -0,0 +1,221 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	process_similarity_checks_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/process-similarity-checks-use-case"
+	receive_similarity_result_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/receive-similarity-result-use-case"
+	request_similarity_check_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/request-similarity-check-use-case"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/entities"
+	similarityErrors "github.com/nahualventure/class-backend/core/app/similarity/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	classID      = "7d8f6c1e-2a4b-4c3d-9e8f-1a2b3c4d5e6f"
+	assignmentID = "5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e"
+)
+
+// memoryChecks keeps checks and documents apart like the Postgres adapter
+type memoryChecks struct {
+	checks    map[string]*entities.SimilarityCheck
+	documents map[string]*entities.Document
+	retryAt   map[string]time.Time
+}
+
+func newMemoryChecks() *memoryChecks {
+	return &memoryChecks{
+		checks:    map[string]*entities.SimilarityCheck{},
+		documents: map[string]*entities.Document{},
+		retryAt:   map[string]time.Time{},
+	}
+}
+
+func (m *memoryChecks) Create(_ context.Context, check *entities.SimilarityCheck, document *entities.Document) error {
+	m.checks[check.ID] = check
+	m.documents[check.ID] = document
+	return nil
+}
+
+func (m *memoryChecks) FindByID(_ context.Context, _ string, checkID string) (*entities.SimilarityCheck, error) {
+	return m.checks[checkID], nil
+}
+
+func (m *memoryChecks) FindByProviderReference(_ context.Context, providerReference string) (*entities.SimilarityCheck, error) {
+	for _, check := range m.checks {
+		if check.ProviderReference == providerReference {
+			return check, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryChecks) ListByAssignment(context.Context, string, string, string) ([]*entities.SimilarityCheck, error) {
+	return nil, nil
+}
+
+func (m *memoryChecks) SaveResult(_ context.Context, check *entities.SimilarityCheck) error {
+	m.checks[check.ID] = check
+	return nil
+}
+
+// ClaimNextPending ignores retryAt, tests advance the queue by claiming again
+func (m *memoryChecks) ClaimNextPending(context.Context, time.Duration) (*entities.SimilarityCheck, *entities.Document, error) {
+	for id, check := range m.checks {
+		if check.Status == entities.SimilarityCheckStatusPending {
+			check.Attempts++
+			return check, m.documents[id], nil
+		}
+	}
+	return nil, nil, nil
+}
+
+func (m *memoryChecks) MarkSubmitted(_ context.Context, checkID string, providerReference string) error {
+	m.checks[checkID].Status = entities.SimilarityCheckStatusSubmitted
+	m.checks[checkID].ProviderReference = providerReference
+	delete(m.documents, checkID)
+	return nil
+}
+
+func (m *memoryChecks) MarkRetry(_ context.Context, checkID string, reason string, retryAt time.Time) error {
+	m.checks[checkID].FailureReason = reason
+	m.retryAt[checkID] = retryAt
+	return nil
+}
+
+func (m *memoryChecks) MarkFailed(_ context.Context, checkID string, reason string) error {
+	m.checks[checkID].Status = entities.SimilarityCheckStatusFailed
+	m.checks[checkID].FailureReason = reason
+	delete(m.documents, checkID)
+	return nil
+}
+
+type fakeChecker struct {
+	submitErr error
+	submitted []*entities.Document
+	result    *entities.SimilarityResult
+}
+
+func (f *fakeChecker) Submit(_ context.Context, check *entities.SimilarityCheck, document *entities.Document) (string, error) {
+	if f.submitErr != nil {
+		return "", f.submitErr
+	}
+	f.submitted = append(f.submitted, document)
+	return "provider-" + check.ID, nil
+}
+
+func (f *fakeChecker) VerifyCallback(_ []byte, _ string) (string, error) {
+	return "", nil
+}
+
+func (f *fakeChecker) ParseCallback(_ []byte) (*entities.SimilarityResult, error) {
+	return f.result, nil
+}
+
+func requestCheck(t *testing.T, repo *memoryChecks) *entities.SimilarityCheck {
+	t.Helper()
+	cmd, err := request_similarity_check_use_case.NewRequestSimilarityCheckCommand("tenant1", classID, assignmentID, "student-1",
+		"essay.txt", "text/plain", []byte("It was the best of times"))
+	assert.NoError(t, err)
+	check, err := request_similarity_check_use_case.NewRequestSimilarityCheckUseCase(repo).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return check
+}
+
+func receive(repo *memoryChecks, checker *fakeChecker) (*entities.SimilarityCheck, error) {
+	cmd, err := receive_similarity_result_use_case.NewReceiveSimilarityResultCommand([]byte(`{}`))
+	if err != nil {
+		return nil, err
+	}
+	return receive_similarity_result_use_case.NewReceiveSimilarityResultUseCase(repo, checker).Execute(context.Background(), cmd)
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func TestRequestSimilarityCheck_RejectsOversizedDocuments(t *testing.T) {
+	_, err := request_similarity_check_use_case.NewRequestSimilarityCheckCommand("tenant1", classID, assignmentID, "student-1",
+		"essay.txt", "text/plain", make([]byte, request_similarity_check_use_case.MaxDocumentBytes+1))
+
+	assert.Equal(t, appErrors.ValidationError.String(), codeOf(err))
+}
+
+func TestProcessSimilarityChecks_SubmitsAndDropsDocument(t *testing.T) {
+	repo := newMemoryChecks()
+	checker := &fakeChecker{}
+	check := requestCheck(t, repo)
+	useCase := process_similarity_checks_use_case.NewProcessSimilarityChecksUseCase(repo, checker)
+
+	processed, err := useCase.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, processed)
+	assert.Equal(t, entities.SimilarityCheckStatusSubmitted, check.Status)
+	assert.Equal(t, "provider-"+check.ID, check.ProviderReference)
+	assert.Equal(t, "It was the best of times", string(checker.submitted[0].Content))
+	assert.Empty(t, repo.documents)
+
+	processed, err = useCase.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, processed, "the queue is empty")
+}
+
+func TestProcessSimilarityChecks_RetriesThenFails(t *testing.T) {
+	repo := newMemoryChecks()
+	checker := &fakeChecker{submitErr: errors.New("provider unavailable")}
+	check := requestCheck(t, repo)
+	useCase := process_similarity_checks_use_case.NewProcessSimilarityChecksUseCase(repo, checker)
+
+	var lastRetry time.Time
+	for attempt := 1; attempt < process_similarity_checks_use_case.MaxAttempts; attempt++ {
+		_, err := useCase.Execute(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, entities.SimilarityCheckStatusPending, check.Status)
+		assert.True(t, repo.retryAt[check.ID].After(lastRetry), "backoff grows on every attempt")
+		lastRetry = repo.retryAt[check.ID]
+	}
+
+	_, err := useCase.Execute(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, entities.SimilarityCheckStatusFailed, check.Status)
+	assert.Equal(t, "provider unavailable", check.FailureReason)
+}
+
+func TestReceiveSimilarityResult_StoresScoreOnce(t *testing.T) {
+	repo := newMemoryChecks()
+	checker := &fakeChecker{}
+	check := requestCheck(t, repo)
+	_, err := process_similarity_checks_use_case.NewProcessSimilarityChecksUseCase(repo, checker).Execute(context.Background())
+	assert.NoError(t, err)
+
+	checker.result = &entities.SimilarityResult{ProviderReference: check.ProviderReference, Score: 42.5, ReportURL: "https://provider/report/1"}
+	received, err := receive(repo, checker)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.SimilarityCheckStatusCompleted, received.Status)
+	assert.Equal(t, 42.5, *received.Score)
+	assert.NotNil(t, received.CompletedAt)
+
+	// A redelivered callback with another outcome does not overwrite the result
+	checker.result = &entities.SimilarityResult{ProviderReference: check.ProviderReference, Failed: true, FailureReason: "late failure"}
+	received, err = receive(repo, checker)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.SimilarityCheckStatusCompleted, received.Status)
+	assert.Equal(t, 42.5, *received.Score)
+}
+
+func TestReceiveSimilarityResult_RejectsUnknownChecks(t *testing.T) {
+	repo := newMemoryChecks()
+	checker := &fakeChecker{result: &entities.SimilarityResult{ProviderReference: "unknown", Score: 10}}
+
+	_, err := receive(repo, checker)
+	assert.Equal(t, similarityErrors.SimilarityCheckNotFoundError.String(), codeOf(err))
+}
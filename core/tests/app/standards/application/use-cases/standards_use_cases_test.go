@@ -0,0 +1,248 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	get_class_mastery_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/get-class-mastery-use-case"
+	get_student_mastery_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/get-student-mastery-use-case"
+	save_standard_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/save-standard-use-case"
+	set_mastery_policy_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/set-mastery-policy-use-case"
+	tag_assignment_standards_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/tag-assignment-standards-use-case"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	standardsErrors "github.com/nahualventure/class-backend/core/app/standards/domain/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStandards keeps the framework and code of a standard unique like the standards table
+type memoryStandards struct {
+	standards []*entities.Standard
+}
+
+func (m *memoryStandards) Save(_ context.Context, standard *entities.Standard) (*entities.Standard, error) {
+	for _, existing := range m.standards {
+		if existing.TenantID == standard.TenantID && existing.Framework == standard.Framework && existing.Code == standard.Code {
+			existing.Description = standard.Description
+			existing.ParentCode = standard.ParentCode
+			existing.UpdatedAt = standard.UpdatedAt
+			return existing, nil
+		}
+	}
+	m.standards = append(m.standards, standard)
+	return standard, nil
+}
+
+func (m *memoryStandards) FindByIDs(_ context.Context, tenantID string, standardIDs []string) ([]*entities.Standard, error) {
+	var found []*entities.Standard
+	for _, standard := range m.standards {
+		for _, standardID := range standardIDs {
+			if standard.TenantID == tenantID && standard.ID == standardID {
+				found = append(found, standard)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryStandards) List(_ context.Context, tenantID string, framework string) ([]*entities.Standard, error) {
+	var found []*entities.Standard
+	for _, standard := range m.standards {
+		if standard.TenantID == tenantID && (framework == "" || standard.Framework == framework) {
+			found = append(found, standard)
+		}
+	}
+	return found, nil
+}
+
+type memoryTags map[string]*entities.AssignmentStandards
+
+func (m memoryTags) Replace(_ context.Context, tags *entities.AssignmentStandards) error {
+	m[tags.AssignmentID] = tags
+	return nil
+}
+
+func (m memoryTags) Find(_ context.Context, _ string, _ string, assignmentID string) ([]string, error) {
+	if tags, ok := m[assignmentID]; ok {
+		return tags.StandardIDs, nil
+	}
+	return nil, nil
+}
+
+type memoryPolicies map[string]*entities.MasteryPolicy
+
+func (m memoryPolicies) Find(_ context.Context, tenantID string) (*entities.MasteryPolicy, error) {
+	return m[tenantID], nil
+}
+
+func (m memoryPolicies) Save(_ context.Context, policy *entities.MasteryPolicy) error {
+	m[policy.TenantID] = policy
+	return nil
+}
+
+type memoryEvidence []entities.MasteryEvidence
+
+func (m memoryEvidence) StudentEvidence(_ context.Context, _ string, studentID string) ([]entities.MasteryEvidence, error) {
+	var evidence []entities.MasteryEvidence
+	for _, piece := range m {
+		if piece.StudentID == studentID {
+			evidence = append(evidence, piece)
+		}
+	}
+	return evidence, nil
+}
+
+func (m memoryEvidence) ClassEvidence(_ context.Context, _ string, classID string) ([]entities.MasteryEvidence, error) {
+	var evidence []entities.MasteryEvidence
+	for _, piece := range m {
+		if piece.ClassID == classID {
+			evidence = append(evidence, piece)
+		}
+	}
+	return evidence, nil
+}
+
+func saveStandard(t *testing.T, standards *memoryStandards, code string, description string) *entities.Standard {
+	command, err := save_standard_use_case.NewSaveStandardCommand("tenant1", "CCSS.Math", code, "", "Math", "6", description)
+	assert.NoError(t, err)
+	standard, err := save_standard_use_case.NewSaveStandardUseCase(standards).Execute(context.Background(), command)
+	assert.NoError(t, err)
+	return standard
+}
+
+var classID = uuid.New().String()
+
+func evidence(standardID string, studentID string, score float64, day int) entities.MasteryEvidence {
+	return entities.MasteryEvidence{
+		StandardID:   standardID,
+		StudentID:    studentID,
+		ClassID:      classID,
+		AssignmentID: uuid.New().String(),
+		Score:        score,
+		ReleasedAt:   time.Date(2025, 9, day, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, code, appErr.GetCode())
+	}
+}
+
+func TestSaveStandardUseCase_Execute_UpdatesTheSameCode(t *testing.T) {
+	standards := &memoryStandards{}
+	first := saveStandard(t, standards, "6.RP.A.1", "Understand ratios")
+
+	second := saveStandard(t, standards, "6.RP.A.1", "Understand the concept of a ratio")
+
+	assert.Equal(t, first.ID, second.ID)
+	assert.Len(t, standards.standards, 1)
+	assert.Equal(t, "Understand the concept of a ratio", standards.standards[0].Description)
+}
+
+func TestTagAssignmentStandardsUseCase_Execute_RejectsUnknownStandard(t *testing.T) {
+	standards := &memoryStandards{}
+	known := saveStandard(t, standards, "6.RP.A.1", "Understand ratios")
+	unknown := uuid.New().String()
+	tags := memoryTags{}
+
+	command, err := tag_assignment_standards_use_case.NewTagAssignmentStandardsCommand("tenant1", uuid.New().String(), uuid.New().String(),
+		[]string{known.ID, unknown}, "instructor-1")
+	assert.NoError(t, err)
+
+	_, err = tag_assignment_standards_use_case.NewTagAssignmentStandardsUseCase(standards, tags).Execute(context.Background(), command)
+
+	assertCode(t, err, standardsErrors.StandardNotFoundError.String())
+	assert.Empty(t, tags)
+}
+
+func TestSetMasteryPolicyUseCase_Execute_RejectsUnorderedLevels(t *testing.T) {
+	policies := memoryPolicies{}
+	command, err := set_mastery_policy_use_case.NewSetMasteryPolicyCommand("tenant1", entities.MasteryMean, 0, []entities.MasteryLevel{
+		{Name: "not_yet", MinScore: 0},
+		{Name: "met", MinScore: 70},
+		{Name: "exceeded", MinScore: 60},
+	}, "met", "head-1")
+	assert.NoError(t, err)
+
+	_, err = set_mastery_policy_use_case.NewSetMasteryPolicyUseCase(policies).Execute(context.Background(), command)
+
+	assertCode(t, err, appErrors.DomainEntityValidationError.String())
+	assert.Empty(t, policies)
+}
+
+func TestGetClassMasteryUseCase_Execute_DecayingAverageByDefault(t *testing.T) {
+	standards := &memoryStandards{}
+	ratios := saveStandard(t, standards, "6.RP.A.1", "Understand ratios")
+	rates := saveStandard(t, standards, "6.RP.A.2", "Understand unit rates")
+	source := memoryEvidence{
+		// Out of order, the latest evidence weighs the most whatever order it is read in
+		evidence(ratios.ID, "student-1", 100, 20),
+		evidence(ratios.ID, "student-1", 50, 1),
+		evidence(ratios.ID, "student-2", 95, 3),
+		evidence(rates.ID, "student-1", 70, 5),
+	}
+
+	command, err := get_class_mastery_use_case.NewGetClassMasteryCommand("tenant1", classID)
+	assert.NoError(t, err)
+
+	report, err := get_class_mastery_use_case.NewGetClassMasteryUseCase(standards, memoryPolicies{}, source).Execute(context.Background(), command)
+
+	assert.NoError(t, err)
+	assert.True(t, report.Policy.IsDefault())
+	if !assert.Len(t, report.Standards, 2) {
+		return
+	}
+	assert.Equal(t, ratios.ID, report.Standards[0].Standard.ID)
+	assert.Equal(t, 2, report.Standards[0].StudentCount)
+	assert.Equal(t, 2, report.Standards[0].MasteredCount)
+	assert.Equal(t, []int{0, 0, 1, 1}, report.Standards[0].LevelCounts)
+	assert.Equal(t, 82.5, report.Standards[0].Masteries[0].Score)
+	assert.Equal(t, "proficient", report.Standards[0].Masteries[0].Level)
+	assert.Equal(t, 2, report.Standards[0].Masteries[0].EvidenceCount)
+	assert.Equal(t, "advanced", report.Standards[0].Masteries[1].Level)
+
+	assert.Equal(t, rates.ID, report.Standards[1].Standard.ID)
+	assert.Equal(t, 0, report.Standards[1].MasteredCount)
+	assert.Equal(t, "developing", report.Standards[1].Masteries[0].Level)
+}
+
+func TestGetStudentMasteryUseCase_Execute_FollowsTheTenantPolicy(t *testing.T) {
+	standards := &memoryStandards{}
+	ratios := saveStandard(t, standards, "6.RP.A.1", "Understand ratios")
+	source := memoryEvidence{
+		evidence(ratios.ID, "student-1", 95, 1),
+		evidence(ratios.ID, "student-1", 40, 20),
+		evidence(ratios.ID, "student-2", 30, 2),
+	}
+	policies := memoryPolicies{}
+
+	setCommand, err := set_mastery_policy_use_case.NewSetMasteryPolicyCommand("tenant1", entities.MasteryHighest, 0, []entities.MasteryLevel{
+		{Name: "not_yet", MinScore: 0},
+		{Name: "met", MinScore: 75},
+	}, "met", "head-1")
+	assert.NoError(t, err)
+	policy, err := set_mastery_policy_use_case.NewSetMasteryPolicyUseCase(policies).Execute(context.Background(), setCommand)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.65, policy.DecayWeight, "the decay weight keeps the default when it is not given")
+
+	command, err := get_student_mastery_use_case.NewGetStudentMasteryCommand("tenant1", "student-1")
+	assert.NoError(t, err)
+
+	report, err := get_student_mastery_use_case.NewGetStudentMasteryUseCase(standards, policies, source).Execute(context.Background(), command)
+
+	assert.NoError(t, err)
+	if assert.Len(t, report.Standards, 1) && assert.Len(t, report.Standards[0].Masteries, 1) {
+		mastery := report.Standards[0].Masteries[0]
+		assert.Equal(t, "student-1", mastery.StudentID)
+		assert.Equal(t, 95.0, mastery.Score)
+		assert.Equal(t, "met", mastery.Level)
+		assert.True(t, mastery.Mastered)
+		assert.Equal(t, time.Date(2025, 9, 20, 10, 0, 0, 0, time.UTC), mastery.LastEvidence)
+	}
+}
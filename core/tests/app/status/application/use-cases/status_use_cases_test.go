@@ -0,0 +1,173 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	get_status_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/get-status-use-case"
+	post_status_note_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/post-status-note-use-case"
+	resolve_status_note_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/resolve-status-note-use-case"
+	update_status_note_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/update-status-note-use-case"
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+	statusErrors "github.com/nahualventure/class-backend/core/app/status/domain/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryNotes keeps the notes in the order they were created
+type memoryNotes struct {
+	notes []*entities.StatusNote
+}
+
+func (m *memoryNotes) FindByID(_ context.Context, noteID string) (*entities.StatusNote, error) {
+	for _, note := range m.notes {
+		if note.ID == noteID {
+			copied := *note
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryNotes) List(_ context.Context, includeResolved bool, limit int) ([]*entities.StatusNote, error) {
+	var notes []*entities.StatusNote
+	for _, note := range slices.Backward(m.notes) {
+		if (includeResolved || !note.IsResolved()) && len(notes) < limit {
+			notes = append(notes, note)
+		}
+	}
+	return notes, nil
+}
+
+func (m *memoryNotes) Save(_ context.Context, note *entities.StatusNote) error {
+	copied := *note
+	for i, stored := range m.notes {
+		if stored.ID == note.ID {
+			m.notes[i] = &copied
+			return nil
+		}
+	}
+	m.notes = append(m.notes, &copied)
+	return nil
+}
+
+type fixedProbe map[entities.Component]entities.Level
+
+func (p fixedProbe) Measure(_ context.Context) map[entities.Component]entities.Level {
+	return p
+}
+
+func assertCode(t *testing.T, err error, code appErrors.ErrorCode) {
+	t.Helper()
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr), "expected %s, got %v", code, err) {
+		assert.Equal(t, code.String(), appErr.GetCode())
+	}
+}
+
+func postNote(t *testing.T, notes *memoryNotes, components []string, level string) *entities.StatusNote {
+	command, err := post_status_note_use_case.NewPostStatusNoteCommand("Emails delayed", "Our email provider queues messages.", components, level)
+	assert.NoError(t, err)
+	note, err := post_status_note_use_case.NewPostStatusNoteUseCase(notes).Execute(context.Background(), command)
+	assert.NoError(t, err)
+	return note
+}
+
+func levels(status *entities.Status) map[entities.Component]entities.Level {
+	levels := make(map[entities.Component]entities.Level)
+	for _, component := range status.Components {
+		levels[component.Component] = component.Level
+	}
+	return levels
+}
+
+func TestGetStatus_RaisesComponentsToTheLevelOfTheirOpenNotes(t *testing.T) {
+	notes := &memoryNotes{}
+	probe := fixedProbe{entities.ComponentDatabase: entities.LevelOperational, entities.ComponentJobs: entities.LevelDegraded}
+	useCase := get_status_use_case.NewGetStatusUseCase(probe, notes)
+
+	status, err := useCase.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, entities.LevelDegraded, status.Level)
+	assert.Equal(t, map[entities.Component]entities.Level{
+		entities.ComponentAPI:          entities.LevelOperational,
+		entities.ComponentDatabase:     entities.LevelOperational,
+		entities.ComponentJobs:         entities.LevelDegraded,
+		entities.ComponentIntegrations: entities.LevelOperational,
+	}, levels(status))
+	assert.Empty(t, status.Incidents)
+
+	outage := postNote(t, notes, []string{"integrations", "jobs"}, "partial_outage")
+	postNote(t, notes, []string{"jobs"}, "operational")
+
+	status, err = useCase.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, entities.LevelPartialOutage, status.Level)
+	assert.Equal(t, entities.LevelPartialOutage, levels(status)[entities.ComponentIntegrations])
+	assert.Equal(t, entities.LevelPartialOutage, levels(status)[entities.ComponentJobs])
+	assert.Equal(t, entities.LevelOperational, levels(status)[entities.ComponentAPI])
+	assert.Len(t, status.Incidents, 2)
+
+	resolve, err := resolve_status_note_use_case.NewResolveStatusNoteCommand(outage.ID)
+	assert.NoError(t, err)
+	_, err = resolve_status_note_use_case.NewResolveStatusNoteUseCase(notes).Execute(context.Background(), resolve)
+	assert.NoError(t, err)
+
+	// The measure stands once the incident is resolved
+	status, err = useCase.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, entities.LevelDegraded, status.Level)
+	assert.Equal(t, entities.LevelOperational, levels(status)[entities.ComponentIntegrations])
+	assert.Len(t, status.Incidents, 1)
+}
+
+func TestPostStatusNote_ValidatesComponentsAndLevel(t *testing.T) {
+	for _, invalid := range []struct {
+		components []string
+		level      string
+	}{
+		{nil, "degraded"},
+		{[]string{"payments"}, "degraded"},
+		{[]string{"api", "api"}, "degraded"},
+		{[]string{"api"}, "down"},
+	} {
+		_, err := post_status_note_use_case.NewPostStatusNoteCommand("Outage", "Investigating.", invalid.components, invalid.level)
+		assertCode(t, err, appErrors.ValidationError)
+	}
+}
+
+func TestUpdateStatusNote_ChangesOpenNotesOnly(t *testing.T) {
+	notes := &memoryNotes{}
+	note := postNote(t, notes, []string{"integrations"}, "major_outage")
+	useCase := update_status_note_use_case.NewUpdateStatusNoteUseCase(notes)
+
+	command, err := update_status_note_use_case.NewUpdateStatusNoteCommand(note.ID, "Emails delayed", "A fix is rolling out.",
+		[]string{"integrations"}, "degraded")
+	assert.NoError(t, err)
+	updated, err := useCase.Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.Equal(t, "A fix is rolling out.", updated.Message)
+	assert.Equal(t, entities.LevelDegraded, updated.Level)
+	assert.Equal(t, note.CreatedAt, updated.CreatedAt)
+
+	resolve, err := resolve_status_note_use_case.NewResolveStatusNoteCommand(note.ID)
+	assert.NoError(t, err)
+	resolveUseCase := resolve_status_note_use_case.NewResolveStatusNoteUseCase(notes)
+	_, err = resolveUseCase.Execute(context.Background(), resolve)
+	assert.NoError(t, err)
+
+	_, err = useCase.Execute(context.Background(), command)
+	assertCode(t, err, statusErrors.StatusNoteResolvedError)
+	_, err = resolveUseCase.Execute(context.Background(), resolve)
+	assertCode(t, err, statusErrors.StatusNoteResolvedError)
+
+	missing, err := update_status_note_use_case.NewUpdateStatusNoteCommand(uuid.NewString(), "Emails delayed", "Fixed.",
+		[]string{"integrations"}, "operational")
+	assert.NoError(t, err)
+	_, err = useCase.Execute(context.Background(), missing)
+	assertCode(t, err, statusErrors.StatusNoteNotFoundError)
+}
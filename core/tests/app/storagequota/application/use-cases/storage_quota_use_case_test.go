@@ -0,0 +1,113 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	check_storage_quota_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/check-storage-quota-use-case"
+	reconcile_storage_usage_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/reconcile-storage-usage-use-case"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/entities"
+	storageQuotaErrors "github.com/nahualventure/class-backend/core/app/storagequota/domain/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryUsage keeps the bytes of each owner by tenant, reconciled tenants get their recomputed usage
+type memoryUsage struct {
+	owners     map[string]map[string]int64
+	recomputed map[string]int64
+	drifts     []*entities.StorageDrift
+}
+
+func (m *memoryUsage) Usage(_ context.Context, tenantID string, ownerID string) (*entities.StorageUsage, *entities.StorageUsage, error) {
+	tenant := &entities.StorageUsage{TenantID: tenantID}
+	for _, bytes := range m.owners[tenantID] {
+		tenant.Bytes += bytes
+	}
+	return tenant, &entities.StorageUsage{TenantID: tenantID, OwnerID: ownerID, Bytes: m.owners[tenantID][ownerID]}, nil
+}
+
+func (m *memoryUsage) List(context.Context, string, *pagination.PageRequest) (*pagination.Page[*entities.StorageUsage], error) {
+	return nil, nil
+}
+
+func (m *memoryUsage) ListTenants(context.Context) ([]string, error) {
+	return []string{"tenant1", "tenant2", "tenant3"}, nil
+}
+
+func (m *memoryUsage) Reconcile(_ context.Context, tenantID string, now time.Time) (*entities.StorageUsage, error) {
+	bytes, ok := m.recomputed[tenantID]
+	if !ok {
+		return nil, errors.New("database unavailable")
+	}
+	return &entities.StorageUsage{TenantID: tenantID, Bytes: bytes, Files: 1, UpdatedAt: now}, nil
+}
+
+func (m *memoryUsage) RecordDrift(_ context.Context, drift *entities.StorageDrift) error {
+	m.drifts = append(m.drifts, drift)
+	return nil
+}
+
+type memoryQuotas map[string]*entities.StorageQuota
+
+func (m memoryQuotas) Find(_ context.Context, tenantID string) (*entities.StorageQuota, error) {
+	return m[tenantID], nil
+}
+
+func (m memoryQuotas) Save(_ context.Context, quota *entities.StorageQuota) error {
+	m[quota.TenantID] = quota
+	return nil
+}
+
+type memoryInventory map[string]int64
+
+func (m memoryInventory) Stored(_ context.Context, tenantID string) (int64, int64, error) {
+	return m[tenantID], 1, nil
+}
+
+func checkQuota(t *testing.T, useCase *check_storage_quota_use_case.CheckStorageQuotaUseCase, tenantID string, userID string, requested int64) error {
+	command, err := check_storage_quota_use_case.NewCheckStorageQuotaCommand(tenantID, userID, requested)
+	assert.NoError(t, err)
+	return useCase.Execute(context.Background(), command)
+}
+
+func TestCheckStorageQuota_RefusesUploadsPastEitherLimit(t *testing.T) {
+	usage := &memoryUsage{owners: map[string]map[string]int64{
+		"tenant1": {"alice": 800, "bob": 100},
+		"tenant2": {"alice": 5000},
+	}}
+	quotas := memoryQuotas{"tenant1": {TenantID: "tenant1", TenantBytes: 1000, UserBytes: 850}}
+	useCase := check_storage_quota_use_case.NewCheckStorageQuotaUseCase(usage, quotas)
+
+	assert.NoError(t, checkQuota(t, useCase, "tenant1", "alice", 50))
+	assert.NoError(t, checkQuota(t, useCase, "tenant2", "alice", 1<<30), "tenants without a quota are not limited")
+
+	err := checkQuota(t, useCase, "tenant1", "alice", 51)
+	var appErr appErrors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, storageQuotaErrors.StorageQuotaExceededError.String(), appErr.GetCode())
+	assert.Equal(t, "user", appErr.GetContext()["scope"])
+
+	err = checkQuota(t, useCase, "tenant1", "bob", 101)
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "tenant", appErr.GetContext()["scope"])
+	assert.Equal(t, int64(900), appErr.GetContext()["usage"])
+}
+
+func TestReconcileStorageUsage_RecordsDriftedTenants(t *testing.T) {
+	usage := &memoryUsage{recomputed: map[string]int64{"tenant1": 500, "tenant3": 700}}
+	inventory := memoryInventory{"tenant1": 500, "tenant3": 900}
+
+	drifted, err := reconcile_storage_usage_use_case.NewReconcileStorageUsageUseCase(usage, inventory).Execute(context.Background())
+
+	assert.Error(t, err, "the tenant that failed is reported")
+	assert.Equal(t, 1, drifted, "the other tenants are reconciled")
+	assert.Len(t, usage.drifts, 1)
+	assert.Equal(t, "tenant3", usage.drifts[0].TenantID)
+	assert.Equal(t, int64(700), usage.drifts[0].RecordedBytes)
+	assert.Equal(t, int64(900), usage.drifts[0].StoredBytes)
+}
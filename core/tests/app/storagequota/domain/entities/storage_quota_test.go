@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStorageQuota_RejectsNegativeLimits(t *testing.T) {
+	_, err := entities.NewStorageQuota("tenant1", 1000, 100, "admin1", time.Now())
+	assert.NoError(t, err)
+
+	_, err = entities.NewStorageQuota("tenant1", -1, 100, "admin1", time.Now())
+	assert.Error(t, err)
+	_, err = entities.NewStorageQuota("tenant1", 1000, -1, "admin1", time.Now())
+	assert.Error(t, err)
+	_, err = entities.NewStorageQuota("", 1000, 100, "admin1", time.Now())
+	assert.Error(t, err)
+}
+
+func TestStorageQuota_Exceeded(t *testing.T) {
+	quota, err := entities.NewStorageQuota("tenant1", 1000, 100, "admin1", time.Now())
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		quota     *entities.StorageQuota
+		tenant    int64
+		user      int64
+		requested int64
+		scope     entities.QuotaScope
+		exceeded  bool
+	}{
+		{"fits both", quota, 500, 10, 50, "", false},
+		{"user limit reached exactly", quota, 500, 50, 50, "", false},
+		{"past the user limit", quota, 500, 50, 51, entities.QuotaScopeUser, true},
+		{"past the tenant limit", quota, 990, 0, 20, entities.QuotaScopeTenant, true},
+		{"past both, the user is reported", quota, 990, 90, 20, entities.QuotaScopeUser, true},
+		{"unlimited", entities.UnlimitedStorageQuota("tenant1"), 1 << 40, 1 << 40, 1 << 30, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, exceeded := tt.quota.Exceeded(&entities.StorageUsage{Bytes: tt.tenant}, &entities.StorageUsage{Bytes: tt.user}, tt.requested)
+			assert.Equal(t, tt.exceeded, exceeded)
+			assert.Equal(t, tt.scope, scope)
+		})
+	}
+}
+
+func TestStorageDrift_Drifted(t *testing.T) {
+	drift := &entities.StorageDrift{RecordedBytes: 100, RecordedFiles: 2, StoredBytes: 100, StoredFiles: 2}
+	assert.False(t, drift.Drifted())
+
+	drift.StoredFiles = 3
+	assert.True(t, drift.Drifted(), "a file stored without a record")
+	drift.StoredFiles, drift.StoredBytes = 2, 90
+	assert.True(t, drift.Drifted())
+}
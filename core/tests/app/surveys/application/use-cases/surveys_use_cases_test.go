@@ -0,0 +1,286 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	create_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/create-form-use-case"
+	list_assigned_forms_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/list-assigned-forms-use-case"
+	list_form_responses_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/list-form-responses-use-case"
+	open_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/open-form-use-case"
+	submit_response_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/submit-response-use-case"
+	update_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/update-form-use-case"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantID = "tenant1"
+	classA   = "5f0c1f7e-3a57-4b8e-9d43-0e6a7c2b9a10"
+	classB   = "a3d9e2c4-61b0-4f7d-8c25-7e1b4f6d2c83"
+)
+
+type memorySurveys struct {
+	forms        map[string]*entities.Form
+	responses    []*entities.Response
+	answered     map[string]bool
+	participants map[string]entities.Participant
+	distributed  map[string][]string
+}
+
+func newMemorySurveys() *memorySurveys {
+	return &memorySurveys{
+		forms:    make(map[string]*entities.Form),
+		answered: make(map[string]bool),
+		participants: map[string]entities.Participant{
+			"teacher-1":  {UserID: "teacher-1", Roles: []string{"instructor"}, CanManage: true},
+			"teacher-2":  {UserID: "teacher-2", Roles: []string{"instructor"}, CanManage: true},
+			"student-1":  {UserID: "student-1", Roles: []string{"student"}, ClassIDs: []string{classA}},
+			"student-2":  {UserID: "student-2", Roles: []string{"student"}, ClassIDs: []string{classB}},
+			"guardian-1": {UserID: "guardian-1", Roles: []string{"guardian"}, ClassIDs: []string{classA}},
+		},
+		distributed: make(map[string][]string),
+	}
+}
+
+func (m *memorySurveys) Create(_ context.Context, form *entities.Form) error {
+	m.forms[form.ID] = form
+	return nil
+}
+
+func (m *memorySurveys) Update(_ context.Context, form *entities.Form) error {
+	m.forms[form.ID] = form
+	return nil
+}
+
+func (m *memorySurveys) Open(_ context.Context, form *entities.Form, recipientIDs []string) error {
+	m.forms[form.ID] = form
+	m.distributed[form.ID] = recipientIDs
+	return nil
+}
+
+func (m *memorySurveys) FindByID(_ context.Context, _ string, formID string) (*entities.Form, error) {
+	return m.forms[formID], nil
+}
+
+func (m *memorySurveys) List(_ context.Context, _ string, filter ports.FormFilter) ([]*entities.Form, error) {
+	var forms []*entities.Form
+	for _, form := range m.forms {
+		if (filter.Status == "" || form.Status == filter.Status) && (filter.CreatedBy == "" || form.CreatedBy == filter.CreatedBy) {
+			forms = append(forms, form)
+		}
+	}
+	return forms, nil
+}
+
+func (m *memorySurveys) Submit(_ context.Context, response *entities.Response, userID string) error {
+	key := response.FormID + "/" + userID
+	if m.answered[key] {
+		return surveysErrors.NewFormAlreadyAnsweredError(response.FormID)
+	}
+	m.answered[key] = true
+	m.responses = append(m.responses, response)
+	return nil
+}
+
+func (m *memorySurveys) HasResponded(_ context.Context, _ string, formID string, userID string) (bool, error) {
+	return m.answered[formID+"/"+userID], nil
+}
+
+func (m *memorySurveys) ListResponses(formID string) []*entities.Response {
+	var responses []*entities.Response
+	for _, response := range m.responses {
+		if response.FormID == formID {
+			responses = append(responses, response)
+		}
+	}
+	return responses
+}
+
+func (m *memorySurveys) Participant(_ context.Context, _ string, userID string) (entities.Participant, error) {
+	return m.participants[userID], nil
+}
+
+func (m *memorySurveys) Members(_ context.Context, _ string, audience entities.Audience) ([]string, error) {
+	var members []string
+	for userID, participant := range m.participants {
+		if audience.Includes(participant) {
+			members = append(members, userID)
+		}
+	}
+	slices.Sort(members)
+	return members, nil
+}
+
+// memoryResponses adapts the store to ResponseRepository, whose List clashes with the form listing
+type memoryResponses struct {
+	*memorySurveys
+}
+
+func (m memoryResponses) List(_ context.Context, _ string, formID string, page *pagination.PageRequest) (*pagination.Page[*entities.Response], error) {
+	responses := m.ListResponses(formID)
+	return pagination.NewPage(page, responses, int64(len(responses)), func(response *entities.Response) []string {
+		return []string{pagination.TimeKey(response.SubmittedAt), response.ID}
+	}), nil
+}
+
+func questions() []*entities.Question {
+	return []*entities.Question{
+		{ID: "attended", Prompt: "Did you attend the workshop?", Type: entities.QuestionTypeSingleChoice, Required: true,
+			Options: []string{"yes", "no"}, Branches: []entities.Branch{{Option: "no", GoTo: "reason"}}},
+		{ID: "rating", Prompt: "How useful was it?", Type: entities.QuestionTypeRating, Required: true},
+		{ID: "topics", Prompt: "Which topics helped?", Type: entities.QuestionTypeMultipleChoice, Options: []string{"grading", "feedback", "planning"}},
+		{ID: "reason", Prompt: "Why not?", Type: entities.QuestionTypeShortText},
+	}
+}
+
+func createForm(t *testing.T, store *memorySurveys, anonymous bool, audience entities.Audience) *entities.Form {
+	t.Helper()
+	cmd, err := create_form_use_case.NewCreateFormCommand(tenantID, "teacher-1", "Workshop feedback", "", anonymous, questions(), audience, nil)
+	assert.NoError(t, err)
+	form, err := create_form_use_case.NewCreateFormUseCase(store).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return form
+}
+
+func openForm(t *testing.T, store *memorySurveys, formID string) int {
+	t.Helper()
+	cmd, err := open_form_use_case.NewOpenFormCommand(tenantID, formID, "teacher-1")
+	assert.NoError(t, err)
+	_, recipients, err := open_form_use_case.NewOpenFormUseCase(store, store).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return recipients
+}
+
+func submit(store *memorySurveys, formID string, userID string, answers ...entities.Answer) (*entities.Response, error) {
+	cmd, err := submit_response_use_case.NewSubmitResponseCommand(tenantID, formID, userID, answers)
+	if err != nil {
+		return nil, err
+	}
+	return submit_response_use_case.NewSubmitResponseUseCase(store, memoryResponses{store}, store).Execute(context.Background(), cmd)
+}
+
+func answer(questionID string, values ...string) entities.Answer {
+	return entities.Answer{QuestionID: questionID, Values: values}
+}
+
+func hasCode(err error, code string) bool {
+	var appErr appErrors.ApplicationError
+	return errors.As(err, &appErr) && appErr.GetCode() == code
+}
+
+func TestCreateForm_RejectsBackwardBranches(t *testing.T) {
+	invalid := questions()
+	invalid[0].Branches = nil
+	invalid[3] = &entities.Question{ID: "reason", Prompt: "Why not?", Type: entities.QuestionTypeSingleChoice,
+		Options: []string{"time", "topic"}, Branches: []entities.Branch{{Option: "time", GoTo: "attended"}}}
+
+	cmd, err := create_form_use_case.NewCreateFormCommand(tenantID, "teacher-1", "Workshop feedback", "", false, invalid, entities.Audience{}, nil)
+	assert.NoError(t, err)
+	_, err = create_form_use_case.NewCreateFormUseCase(newMemorySurveys()).Execute(context.Background(), cmd)
+	assert.Error(t, err)
+}
+
+func TestUpdateForm_OnlyDrafts(t *testing.T) {
+	store := newMemorySurveys()
+	form := createForm(t, store, false, entities.Audience{})
+	openForm(t, store, form.ID)
+
+	cmd, err := update_form_use_case.NewUpdateFormCommand(tenantID, form.ID, "teacher-1", "Workshop feedback II", "", false,
+		questions(), entities.Audience{}, nil)
+	assert.NoError(t, err)
+	_, err = update_form_use_case.NewUpdateFormUseCase(store, store).Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, surveysErrors.FormNotEditableError.String()))
+}
+
+func TestOpenForm_DistributesToTheAudience(t *testing.T) {
+	store := newMemorySurveys()
+	form := createForm(t, store, false, entities.Audience{Roles: []string{"student", "guardian"}, ClassIDs: []string{classA}})
+
+	assert.Equal(t, 2, openForm(t, store, form.ID))
+	assert.Equal(t, []string{"guardian-1", "student-1"}, store.distributed[form.ID])
+
+	cmd, err := list_assigned_forms_use_case.NewListAssignedFormsCommand(tenantID, "student-2")
+	assert.NoError(t, err)
+	views, err := list_assigned_forms_use_case.NewListAssignedFormsUseCase(store, memoryResponses{store}, store).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Empty(t, views)
+
+	_, err = submit(store, form.ID, "student-2", answer("attended", "yes"), answer("rating", "4"))
+	assert.True(t, hasCode(err, surveysErrors.FormNotFoundError.String()))
+}
+
+func TestSubmitResponse_DraftsDoNotAcceptResponses(t *testing.T) {
+	store := newMemorySurveys()
+	form := createForm(t, store, false, entities.Audience{})
+
+	_, err := submit(store, form.ID, "student-1", answer("attended", "yes"), answer("rating", "4"))
+	assert.True(t, hasCode(err, surveysErrors.FormNotFoundError.String()))
+}
+
+func TestSubmitResponse_FollowsBranches(t *testing.T) {
+	store := newMemorySurveys()
+	form := createForm(t, store, false, entities.Audience{})
+	openForm(t, store, form.ID)
+
+	_, err := submit(store, form.ID, "student-1", answer("attended", "yes"))
+	assert.Error(t, err, "rating is required when attending")
+
+	_, err = submit(store, form.ID, "student-1", answer("attended", "no"), answer("rating", "4"))
+	assert.Error(t, err, "rating is skipped when not attending")
+
+	_, err = submit(store, form.ID, "student-1", answer("attended", "yes"), answer("rating", "6"))
+	assert.Error(t, err)
+
+	response, err := submit(store, form.ID, "student-1", answer("attended", "no"), answer("reason", " schedule "))
+	assert.NoError(t, err)
+	assert.Equal(t, "student-1", response.RespondentID)
+	assert.Equal(t, []string{"schedule"}, response.Values("reason"))
+}
+
+func TestSubmitResponse_OncePerUser(t *testing.T) {
+	store := newMemorySurveys()
+	form := createForm(t, store, false, entities.Audience{})
+	openForm(t, store, form.ID)
+
+	_, err := submit(store, form.ID, "student-1", answer("attended", "yes"), answer("rating", "4"))
+	assert.NoError(t, err)
+	_, err = submit(store, form.ID, "student-1", answer("attended", "yes"), answer("rating", "5"))
+	assert.True(t, hasCode(err, surveysErrors.FormAlreadyAnsweredError.String()))
+}
+
+func TestAnonymousForms_ResultsLeaveOutTheRespondent(t *testing.T) {
+	store := newMemorySurveys()
+	form := createForm(t, store, true, entities.Audience{})
+	openForm(t, store, form.ID)
+
+	response, err := submit(store, form.ID, "student-1", answer("attended", "yes"), answer("rating", "4"),
+		answer("topics", "grading", "planning"))
+	assert.NoError(t, err)
+	assert.Empty(t, response.RespondentID)
+
+	cmd, err := list_form_responses_use_case.NewListFormResponsesCommand(tenantID, form.ID, "student-1", 20, "")
+	assert.NoError(t, err)
+	listResponses := list_form_responses_use_case.NewListFormResponsesUseCase(store, memoryResponses{store}, store)
+	_, _, err = listResponses.Execute(context.Background(), cmd)
+	assert.True(t, hasCode(err, surveysErrors.FormNotFoundError.String()))
+
+	cmd, err = list_form_responses_use_case.NewListFormResponsesCommand(tenantID, form.ID, "teacher-1", 20, "")
+	assert.NoError(t, err)
+	results, page, err := listResponses.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+
+	header := results.ResultsHeader()
+	assert.NotContains(t, header, "respondent_id")
+	row := results.ResultsRow(page.Items[0])
+	assert.Len(t, row, len(header))
+	assert.Equal(t, []string{"yes", "4", "grading; planning", ""}, row[2:])
+}
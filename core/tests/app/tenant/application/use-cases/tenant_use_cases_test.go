@@ -0,0 +1,307 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/saga"
+	accept_tenant_invitation_use_case "github.com/nahualventure/class-backend/core/app/tenant/application/use-cases/accept-tenant-invitation-use-case"
+	onboard_tenant_use_case "github.com/nahualventure/class-backend/core/app/tenant/application/use-cases/onboard-tenant-use-case"
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/entities"
+	tenantErrors "github.com/nahualventure/class-backend/core/app/tenant/domain/errors"
+	userEntities "github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/tests/clocktest"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type sagaStore struct {
+	instances map[string]saga.Instance
+}
+
+func (s *sagaStore) Save(_ context.Context, instance *saga.Instance, _ string, _ time.Time) (bool, error) {
+	s.instances[instance.ID] = *instance
+	return true, nil
+}
+
+func (s *sagaStore) ClaimUnfinished(context.Context, string, time.Time, time.Time) ([]*saga.Instance, error) {
+	return nil, nil
+}
+
+type memoryTenants struct {
+	tenants map[string]entities.Tenant
+}
+
+func (r *memoryTenants) Create(_ context.Context, tenant *entities.Tenant) (bool, error) {
+	if _, exists := r.tenants[tenant.ID]; exists {
+		return false, nil
+	}
+	r.tenants[tenant.ID] = *tenant
+	return true, nil
+}
+
+func (r *memoryTenants) Find(_ context.Context, tenantID string) (*entities.Tenant, error) {
+	tenant, ok := r.tenants[tenantID]
+	if !ok {
+		return nil, nil
+	}
+	return &tenant, nil
+}
+
+func (r *memoryTenants) Delete(_ context.Context, tenantID string) error {
+	delete(r.tenants, tenantID)
+	return nil
+}
+
+func (r *memoryTenants) SetRolesSeeded(_ context.Context, tenantID string, seeded bool) error {
+	tenant := r.tenants[tenantID]
+	tenant.RolesSeeded = seeded
+	r.tenants[tenantID] = tenant
+	return nil
+}
+
+func (r *memoryTenants) Activate(_ context.Context, tenantID string, at time.Time) error {
+	tenant := r.tenants[tenantID]
+	tenant.Status = entities.TenantStatusActive
+	tenant.ActivatedAt = &at
+	r.tenants[tenantID] = tenant
+	return nil
+}
+
+func (r *memoryTenants) ListSeededIDs(context.Context) ([]string, error) {
+	var tenantIDs []string
+	for id, tenant := range r.tenants {
+		if tenant.RolesSeeded {
+			tenantIDs = append(tenantIDs, id)
+		}
+	}
+	slices.Sort(tenantIDs)
+	return tenantIDs, nil
+}
+
+type memoryInvitations struct {
+	invitations map[string]entities.TenantInvitation
+	err         error
+}
+
+func (r *memoryInvitations) Create(_ context.Context, invitation *entities.TenantInvitation) error {
+	if r.err != nil {
+		return r.err
+	}
+	if _, exists := r.invitations[invitation.ID]; !exists {
+		r.invitations[invitation.ID] = *invitation
+	}
+	return nil
+}
+
+func (r *memoryInvitations) Find(_ context.Context, invitationID string) (*entities.TenantInvitation, error) {
+	invitation, ok := r.invitations[invitationID]
+	if !ok {
+		return nil, nil
+	}
+	return &invitation, nil
+}
+
+func (r *memoryInvitations) Accept(_ context.Context, invitationID string, userID string, at time.Time) (bool, error) {
+	invitation, ok := r.invitations[invitationID]
+	if !ok || !invitation.IsOpen() {
+		return false, nil
+	}
+	invitation.AcceptedBy = userID
+	invitation.AcceptedAt = &at
+	r.invitations[invitationID] = invitation
+	return true, nil
+}
+
+func (r *memoryInvitations) Revoke(_ context.Context, invitationID string, at time.Time) error {
+	invitation := r.invitations[invitationID]
+	invitation.RevokedAt = &at
+	r.invitations[invitationID] = invitation
+	return nil
+}
+
+// memoryPolicies records the tenants the roles apply to and the roles given in them
+type memoryPolicies struct {
+	active []string
+	roles  map[string][]string
+}
+
+func (p *memoryPolicies) Activate(_ context.Context, tenantIDs []string) error {
+	p.active = slices.Clone(tenantIDs)
+	return nil
+}
+
+func (p *memoryPolicies) AssignRole(_ context.Context, userID string, role string, tenantID string) error {
+	p.roles[tenantID] = append(p.roles[tenantID], userID+":"+role)
+	return nil
+}
+
+func (p *memoryPolicies) RemoveRoles(_ context.Context, tenantID string) error {
+	delete(p.roles, tenantID)
+	return nil
+}
+
+type fakeUsers map[string]string
+
+func (u fakeUsers) FindByIDs(ids []string) ([]*userEntities.User, error) {
+	var users []*userEntities.User
+	for _, id := range ids {
+		if email, ok := u[id]; ok {
+			users = append(users, &userEntities.User{ID: id, Email: email})
+		}
+	}
+	return users, nil
+}
+
+type tenantFixture struct {
+	clock       *clocktest.Clock
+	store       *sagaStore
+	tenants     *memoryTenants
+	invitations *memoryInvitations
+	policies    *memoryPolicies
+	onboard     *onboard_tenant_use_case.OnboardTenantUseCase
+	accept      *accept_tenant_invitation_use_case.AcceptTenantInvitationUseCase
+}
+
+func newTenantFixture() *tenantFixture {
+	f := &tenantFixture{
+		clock:       clocktest.New(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)),
+		store:       &sagaStore{instances: make(map[string]saga.Instance)},
+		tenants:     &memoryTenants{tenants: make(map[string]entities.Tenant)},
+		invitations: &memoryInvitations{invitations: make(map[string]entities.TenantInvitation)},
+		policies:    &memoryPolicies{roles: make(map[string][]string)},
+	}
+	f.onboard = onboard_tenant_use_case.NewOnboardTenantUseCase(
+		saga.NewCoordinator(f.store), f.tenants, f.invitations, f.policies, []string{"tenant1"},
+	).WithClock(f.clock)
+	f.accept = accept_tenant_invitation_use_case.NewAcceptTenantInvitationUseCase(
+		f.invitations, f.policies, fakeUsers{"user-1": "Admin@School.edu", "user-2": "other@school.edu"},
+	).WithClock(f.clock)
+	return f
+}
+
+func (f *tenantFixture) onboardTenant(t *testing.T, tenantID string) (*entities.Tenant, error) {
+	t.Helper()
+	cmd, err := onboard_tenant_use_case.NewOnboardTenantCommand(tenantID, "North School", "admin@school.edu")
+	assert.NoError(t, err)
+	return f.onboard.Execute(context.Background(), cmd)
+}
+
+func (f *tenantFixture) invitation(t *testing.T) entities.TenantInvitation {
+	t.Helper()
+	assert.Len(t, f.invitations.invitations, 1)
+	for _, invitation := range f.invitations.invitations {
+		return invitation
+	}
+	return entities.TenantInvitation{}
+}
+
+func (f *tenantFixture) acceptInvitation(t *testing.T, tenantID string, invitationID string, userID string) (*entities.TenantInvitation, error) {
+	t.Helper()
+	cmd, err := accept_tenant_invitation_use_case.NewAcceptTenantInvitationCommand(tenantID, invitationID, userID)
+	assert.NoError(t, err)
+	return f.accept.Execute(context.Background(), cmd)
+}
+
+func hasCode(err error, code string) bool {
+	var appErr appErrors.ApplicationError
+	return errors.As(err, &appErr) && appErr.GetCode() == code
+}
+
+func TestOnboardTenant_CreatesSeedsAndInvites(t *testing.T) {
+	f := newTenantFixture()
+
+	tenant, err := f.onboardTenant(t, "north")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TenantStatusActive, tenant.Status)
+	assert.True(t, tenant.RolesSeeded)
+	assert.Equal(t, []string{"north"}, f.policies.active)
+	invitation := f.invitation(t)
+	assert.Equal(t, "north", invitation.TenantID)
+	assert.Equal(t, entities.AdminRole, invitation.Role)
+	assert.Equal(t, f.clock.Now().Add(entities.InvitationLifetime), invitation.ExpiresAt)
+}
+
+func TestOnboardTenant_InviteFailureUndoesTheTenant(t *testing.T) {
+	f := newTenantFixture()
+	f.invitations.err = errors.New("outbox unavailable")
+
+	_, err := f.onboardTenant(t, "north")
+
+	assert.Error(t, err)
+	assert.Empty(t, f.tenants.tenants)
+	assert.Empty(t, f.policies.active, "the roles no longer apply to the tenant")
+	for _, instance := range f.store.instances {
+		assert.Equal(t, saga.StatusCompensated, instance.Status)
+	}
+}
+
+func TestOnboardTenant_RejectsExistingTenants(t *testing.T) {
+	f := newTenantFixture()
+	_, err := f.onboardTenant(t, "north")
+	assert.NoError(t, err)
+
+	_, err = f.onboardTenant(t, "north")
+	assert.True(t, hasCode(err, tenantErrors.TenantAlreadyExistsError.String()))
+
+	_, err = f.onboardTenant(t, "tenant1")
+	assert.True(t, hasCode(err, tenantErrors.TenantAlreadyExistsError.String()), "tenants of the configuration cannot be onboarded")
+	assert.Len(t, f.store.instances, 1)
+}
+
+func TestOnboardTenant_RejectsDeveloperSandboxTenantIDs(t *testing.T) {
+	f := newTenantFixture()
+
+	_, err := f.onboardTenant(t, "dev-north")
+
+	assert.True(t, hasCode(err, appErrors.ValidationError.String()))
+	assert.Empty(t, f.store.instances)
+}
+
+func TestAcceptTenantInvitation_GivesTheRole(t *testing.T) {
+	f := newTenantFixture()
+	_, err := f.onboardTenant(t, "north")
+	assert.NoError(t, err)
+	invitation := f.invitation(t)
+
+	accepted, err := f.acceptInvitation(t, "north", invitation.ID, "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", accepted.AcceptedBy)
+	assert.Equal(t, []string{"user-1:admin"}, f.policies.roles["north"])
+
+	_, err = f.acceptInvitation(t, "north", invitation.ID, "user-1")
+	assert.True(t, hasCode(err, tenantErrors.TenantInvitationNotFoundError.String()), "invitations are accepted once")
+}
+
+func TestAcceptTenantInvitation_RejectsOtherUsersAndTenants(t *testing.T) {
+	f := newTenantFixture()
+	_, err := f.onboardTenant(t, "north")
+	assert.NoError(t, err)
+	invitation := f.invitation(t)
+
+	_, err = f.acceptInvitation(t, "north", invitation.ID, "user-2")
+	assert.True(t, hasCode(err, tenantErrors.TenantInvitationNotFoundError.String()))
+
+	_, err = f.acceptInvitation(t, "tenant1", invitation.ID, "user-1")
+	assert.True(t, hasCode(err, tenantErrors.TenantInvitationNotFoundError.String()))
+	assert.Empty(t, f.policies.roles)
+}
+
+func TestAcceptTenantInvitation_Expired(t *testing.T) {
+	f := newTenantFixture()
+	_, err := f.onboardTenant(t, "north")
+	assert.NoError(t, err)
+	invitation := f.invitation(t)
+	f.clock.Advance(entities.InvitationLifetime)
+
+	_, err = f.acceptInvitation(t, "north", invitation.ID, "user-1")
+
+	assert.True(t, hasCode(err, tenantErrors.TenantInvitationExpiredError.String()))
+	assert.Empty(t, f.policies.roles)
+}
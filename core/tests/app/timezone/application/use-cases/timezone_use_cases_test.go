@@ -0,0 +1,123 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	get_time_zone_use_case "github.com/nahualventure/class-backend/core/app/timezone/application/use-cases/get-time-zone-use-case"
+	set_time_zone_use_case "github.com/nahualventure/class-backend/core/app/timezone/application/use-cases/set-time-zone-use-case"
+	"github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantID = "tenant1"
+	userID   = "user-1"
+)
+
+type memoryTimeZones struct {
+	settings map[[2]string]*entities.TimeZoneSetting
+}
+
+func newMemoryTimeZones() *memoryTimeZones {
+	return &memoryTimeZones{settings: make(map[[2]string]*entities.TimeZoneSetting)}
+}
+
+func (m *memoryTimeZones) FindSetting(_ context.Context, tenantID string, userID string) (*entities.TimeZoneSetting, error) {
+	return m.settings[[2]string{tenantID, userID}], nil
+}
+
+func (m *memoryTimeZones) Save(_ context.Context, setting *entities.TimeZoneSetting) error {
+	m.settings[[2]string{setting.TenantID, setting.UserID}] = setting
+	return nil
+}
+
+func setTimeZone(store *memoryTimeZones, userID string, timeZone string) error {
+	cmd, err := set_time_zone_use_case.NewSetTimeZoneCommand(tenantID, userID, timeZone)
+	if err != nil {
+		return err
+	}
+	_, err = set_time_zone_use_case.NewSetTimeZoneUseCase(store).Execute(context.Background(), cmd)
+	return err
+}
+
+func preferences(t *testing.T, store *memoryTimeZones) *entities.TimeZonePreferences {
+	cmd, err := get_time_zone_use_case.NewGetTimeZoneCommand(tenantID, userID)
+	assert.NoError(t, err)
+	result, err := get_time_zone_use_case.NewGetTimeZoneUseCase(store).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return result
+}
+
+func newYork(t *testing.T) *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	return loc
+}
+
+func TestSetTimeZone_RejectsUnknownZones(t *testing.T) {
+	store := newMemoryTimeZones()
+
+	assert.Error(t, setTimeZone(store, userID, "Mars/Olympus_Mons"))
+	assert.Error(t, setTimeZone(store, userID, "Local"))
+	assert.NoError(t, setTimeZone(store, userID, "America/Guatemala"))
+}
+
+func TestGetTimeZone_UsersFollowTheTenantUntilTheyPickOne(t *testing.T) {
+	store := newMemoryTimeZones()
+	assert.Equal(t, entities.DefaultTimeZone, preferences(t, store).Effective())
+
+	assert.NoError(t, setTimeZone(store, "", "America/Guatemala"))
+	assert.Equal(t, "America/Guatemala", preferences(t, store).Effective())
+
+	assert.NoError(t, setTimeZone(store, userID, "Europe/Madrid"))
+	result := preferences(t, store)
+	assert.Equal(t, "Europe/Madrid", result.Effective())
+	assert.Equal(t, "America/Guatemala", result.TenantZone())
+}
+
+func TestWallClock_MovesTimesSkippedBySpringForwardPastTheGap(t *testing.T) {
+	loc := newYork(t)
+
+	// Clocks jump from 02:00 to 03:00 on 2025-03-09
+	skipped := entities.WallClock(2025, time.March, 9, 2*60+30, loc)
+	assert.Equal(t, time.Date(2025, time.March, 9, 7, 30, 0, 0, time.UTC), skipped.UTC())
+	assert.Equal(t, "03:30", skipped.Format("15:04"))
+
+	after := entities.WallClock(2025, time.March, 9, 9*60, loc)
+	assert.Equal(t, time.Date(2025, time.March, 9, 13, 0, 0, 0, time.UTC), after.UTC())
+}
+
+func TestWallClock_PicksTheFirstOfTimesRepeatedByFallBack(t *testing.T) {
+	loc := newYork(t)
+
+	// Clocks go back from 02:00 to 01:00 on 2025-11-02, 01:30 happens twice
+	repeated := entities.WallClock(2025, time.November, 2, 60+30, loc)
+	assert.Equal(t, time.Date(2025, time.November, 2, 5, 30, 0, 0, time.UTC), repeated.UTC())
+
+	after := entities.WallClock(2025, time.November, 2, 9*60, loc)
+	assert.Equal(t, time.Date(2025, time.November, 2, 14, 0, 0, 0, time.UTC), after.UTC())
+}
+
+func TestNextWeekly_KeepsTheWallClockAcrossTransitions(t *testing.T) {
+	loc := newYork(t)
+	friday := time.Date(2025, time.March, 7, 15, 0, 0, 0, time.UTC)
+
+	// A monday 09:00 class is 14:00 UTC before the switch and 13:00 UTC after it
+	next := entities.NextWeekly(time.Monday, 9*60, friday, loc)
+	assert.Equal(t, time.Date(2025, time.March, 10, 13, 0, 0, 0, time.UTC), next.UTC())
+	assert.Equal(t, "09:00", next.Format("15:04"))
+
+	before := entities.NextWeekly(time.Monday, 9*60, friday.AddDate(0, 0, -7), loc)
+	assert.Equal(t, time.Date(2025, time.March, 3, 14, 0, 0, 0, time.UTC), before.UTC())
+}
+
+func TestNextWeekly_SkipsToNextWeekOnceTheMeetingStarted(t *testing.T) {
+	loc := newYork(t)
+	mondayMorning := time.Date(2025, time.March, 10, 13, 0, 0, 0, time.UTC)
+
+	next := entities.NextWeekly(time.Monday, 9*60, mondayMorning, loc)
+	assert.Equal(t, time.Date(2025, time.March, 17, 13, 0, 0, 0, time.UTC), next.UTC())
+}
@@ -0,0 +1,315 @@
+package use_cases
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	create_term_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/create-term-use-case"
+	download_report_card_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/download-report-card-use-case"
+	generate_report_card_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/generate-report-card-use-case"
+	get_transcript_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/get-transcript-use-case"
+	request_report_cards_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/request-report-cards-use-case"
+	save_report_card_template_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/save-report-card-template-use-case"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	transcriptErrors "github.com/nahualventure/class-backend/core/app/transcript/domain/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryTerms struct {
+	terms []*entities.Term
+}
+
+func (m *memoryTerms) Create(_ context.Context, term *entities.Term) error {
+	m.terms = append(m.terms, term)
+	return nil
+}
+
+func (m *memoryTerms) FindByID(_ context.Context, tenantID string, termID string) (*entities.Term, error) {
+	for _, term := range m.terms {
+		if term.TenantID == tenantID && term.ID == termID {
+			return term, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryTerms) List(_ context.Context, tenantID string) ([]*entities.Term, error) {
+	var terms []*entities.Term
+	for _, term := range m.terms {
+		if term.TenantID == tenantID {
+			terms = append(terms, term)
+		}
+	}
+	return terms, nil
+}
+
+type memoryTemplates map[string]*entities.ReportCardTemplate
+
+func (m memoryTemplates) Find(_ context.Context, tenantID string) (*entities.ReportCardTemplate, error) {
+	return m[tenantID], nil
+}
+
+func (m memoryTemplates) Save(_ context.Context, template *entities.ReportCardTemplate) error {
+	m[template.TenantID] = template
+	return nil
+}
+
+type memorySource struct {
+	grades    []entities.TranscriptGrade
+	guardians []string
+}
+
+func (m *memorySource) ReleasedGrades(_ context.Context, _ string, _ string) ([]entities.TranscriptGrade, error) {
+	return m.grades, nil
+}
+
+func (m *memorySource) StudentName(_ context.Context, _ string) (string, error) {
+	return "Ana Lopez", nil
+}
+
+func (m *memorySource) GuardiansOf(_ context.Context, _ string, _ string) ([]string, error) {
+	return m.guardians, nil
+}
+
+// memoryReportCards claims report cards in the order they were requested
+type memoryReportCards struct {
+	reportCards []*entities.ReportCard
+	artifacts   map[string]*entities.Artifact
+	deliveries  []*entities.ReportCardDelivery
+}
+
+func (m *memoryReportCards) Create(_ context.Context, reportCards []*entities.ReportCard) error {
+	m.reportCards = append(m.reportCards, reportCards...)
+	return nil
+}
+
+func (m *memoryReportCards) FindByID(_ context.Context, tenantID string, reportCardID string) (*entities.ReportCard, error) {
+	for _, reportCard := range m.reportCards {
+		if reportCard.TenantID == tenantID && reportCard.ID == reportCardID {
+			return reportCard, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryReportCards) FindForDownload(_ context.Context, reportCardID string) (*entities.ReportCard, error) {
+	for _, reportCard := range m.reportCards {
+		if reportCard.ID == reportCardID {
+			return reportCard, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryReportCards) FindArtifact(_ context.Context, _ string, reportCardID string) (*entities.Artifact, error) {
+	return m.artifacts[reportCardID], nil
+}
+
+func (m *memoryReportCards) ClaimNextPending(_ context.Context, _ time.Duration) (*entities.ReportCard, error) {
+	for _, reportCard := range m.reportCards {
+		if reportCard.Status == entities.ReportCardStatusPending {
+			reportCard.Status = entities.ReportCardStatusRunning
+			return reportCard, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryReportCards) MarkReady(_ context.Context, reportCard *entities.ReportCard, artifact *entities.Artifact,
+	deliveries []*entities.ReportCardDelivery) error {
+	m.artifacts[reportCard.ID] = artifact
+	m.deliveries = append(m.deliveries, deliveries...)
+	return nil
+}
+
+func (m *memoryReportCards) MarkFailed(_ context.Context, reportCardID string, reason string) error {
+	for _, reportCard := range m.reportCards {
+		if reportCard.ID == reportCardID {
+			reportCard.Status = entities.ReportCardStatusFailed
+			reportCard.FailureReason = reason
+		}
+	}
+	return nil
+}
+
+func (m *memoryReportCards) DeleteExpired(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+// titleRenderer stands in for the PDF layout, the file is the title of the report card
+type titleRenderer struct{}
+
+func (titleRenderer) Render(_ context.Context, document *entities.ReportCardDocument) ([]byte, error) {
+	return []byte(document.Title), nil
+}
+
+func newTerm(t *testing.T, name string, startsOn string, endsOn string) *entities.Term {
+	starts, _ := time.Parse(time.DateOnly, startsOn)
+	ends, _ := time.Parse(time.DateOnly, endsOn)
+	term, err := entities.NewTerm(uuid.New().String(), "tenant1", name, starts, ends, "head-1", time.Now())
+	assert.NoError(t, err)
+	return term
+}
+
+func releasedOn(day string) time.Time {
+	at, _ := time.Parse(time.DateOnly, day)
+	return at.Add(10 * time.Hour)
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, code, appErr.GetCode())
+	}
+}
+
+func TestCreateTermUseCase_Execute_RejectsOverlappingTerm(t *testing.T) {
+	terms := &memoryTerms{terms: []*entities.Term{newTerm(t, "Fall 2025", "2025-08-18", "2025-12-19")}}
+	useCase := create_term_use_case.NewCreateTermUseCase(terms)
+
+	starts, _ := time.Parse(time.DateOnly, "2025-12-19")
+	ends, _ := time.Parse(time.DateOnly, "2026-05-29")
+	command, err := create_term_use_case.NewCreateTermCommand("tenant1", "Spring 2026", starts, ends, "head-1")
+	assert.NoError(t, err)
+
+	_, err = useCase.Execute(context.Background(), command)
+
+	assertCode(t, err, transcriptErrors.TermOverlapError.String())
+	assert.Len(t, terms.terms, 1)
+}
+
+func TestGetTranscriptUseCase_Execute_AveragesClassesByTerm(t *testing.T) {
+	fall := newTerm(t, "Fall 2025", "2025-08-18", "2025-12-19")
+	spring := newTerm(t, "Spring 2026", "2026-01-12", "2026-05-29")
+	summer := newTerm(t, "Summer 2026", "2026-06-15", "2026-07-31")
+	source := &memorySource{grades: []entities.TranscriptGrade{
+		{ClassID: "math", FinalScore: 80, ReleasedAt: releasedOn("2025-09-01")},
+		{ClassID: "math", FinalScore: 90, ReleasedAt: releasedOn("2025-12-19")},
+		{ClassID: "math", FinalScore: 10, Dropped: true, ReleasedAt: releasedOn("2025-10-01")},
+		{ClassID: "art", FinalScore: 70, ReleasedAt: releasedOn("2025-11-01")},
+		{ClassID: "math", FinalScore: 100, ReleasedAt: releasedOn("2026-02-01")},
+		{ClassID: "art", FinalScore: 50, ReleasedAt: releasedOn("2026-01-02")}, // between terms
+	}}
+	useCase := get_transcript_use_case.NewGetTranscriptUseCase(&memoryTerms{terms: []*entities.Term{spring, summer, fall}}, source)
+
+	command, err := get_transcript_use_case.NewGetTranscriptCommand("tenant1", "student-1")
+	assert.NoError(t, err)
+
+	transcript, err := useCase.Execute(context.Background(), command)
+
+	assert.NoError(t, err)
+	if assert.Len(t, transcript.Terms, 2) {
+		assert.Equal(t, fall.ID, transcript.Terms[0].Term.ID)
+		assert.Equal(t, []entities.ClassRecord{
+			{ClassID: "art", GradeCount: 1, Average: 70},
+			{ClassID: "math", GradeCount: 2, Average: 85},
+		}, transcript.Terms[0].Classes)
+		assert.Equal(t, 77.5, transcript.Terms[0].Average)
+		assert.Equal(t, 77.5, transcript.Terms[0].CumulativeAverage)
+
+		assert.Equal(t, spring.ID, transcript.Terms[1].Term.ID)
+		assert.Equal(t, 100.0, transcript.Terms[1].Average)
+		assert.Equal(t, 85.0, transcript.Terms[1].CumulativeAverage)
+	}
+	assert.Equal(t, 85.0, transcript.Average)
+}
+
+func TestSaveReportCardTemplateUseCase_Execute_RejectsUnknownVariable(t *testing.T) {
+	templates := memoryTemplates{}
+	useCase := save_report_card_template_use_case.NewSaveReportCardTemplateUseCase(templates)
+
+	command, err := save_report_card_template_use_case.NewSaveReportCardTemplateCommand("tenant1", "Report card - {{.term_name}}",
+		"Homeroom: {{.homeroom}}", "", "head-1")
+	assert.NoError(t, err)
+
+	_, err = useCase.Execute(context.Background(), command)
+
+	assertCode(t, err, transcriptErrors.InvalidReportCardTemplateError.String())
+	assert.Empty(t, templates)
+}
+
+func TestRequestReportCardsUseCase_Execute_UnknownTerm(t *testing.T) {
+	reportCards := &memoryReportCards{artifacts: map[string]*entities.Artifact{}}
+	useCase := request_report_cards_use_case.NewRequestReportCardsUseCase(&memoryTerms{}, reportCards)
+
+	command, err := request_report_cards_use_case.NewRequestReportCardsCommand("tenant1", uuid.New().String(), []string{"student-1"}, true, "head-1")
+	assert.NoError(t, err)
+
+	_, err = useCase.Execute(context.Background(), command)
+
+	assertCode(t, err, transcriptErrors.TermNotFoundError.String())
+	assert.Empty(t, reportCards.reportCards)
+}
+
+func TestGenerateReportCardUseCase_Execute_SendsSignedLinkToGuardians(t *testing.T) {
+	fall := newTerm(t, "Fall 2025", "2025-08-18", "2025-12-19")
+	terms := &memoryTerms{terms: []*entities.Term{fall}}
+	reportCards := &memoryReportCards{artifacts: map[string]*entities.Artifact{}}
+	source := &memorySource{
+		grades:    []entities.TranscriptGrade{{ClassID: "math", FinalScore: 88, ReleasedAt: releasedOn("2025-10-01")}},
+		guardians: []string{"guardian-1", "guardian-2"},
+	}
+
+	request := request_report_cards_use_case.NewRequestReportCardsUseCase(terms, reportCards)
+	command, err := request_report_cards_use_case.NewRequestReportCardsCommand("tenant1", fall.ID, []string{"student-1"}, true, "head-1")
+	assert.NoError(t, err)
+	requested, err := request.Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.Len(t, requested, 1)
+
+	generate := generate_report_card_use_case.NewGenerateReportCardUseCase(reportCards, terms, memoryTemplates{}, source, titleRenderer{},
+		generate_report_card_use_case.DefaultArtifactTTL)
+	processed, err := generate.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	reportCard := requested[0]
+	assert.Equal(t, entities.ReportCardStatusReady, reportCard.Status)
+	assert.Equal(t, "Report card - Fall 2025", string(reportCards.artifacts[reportCard.ID].Content))
+	if !assert.Len(t, reportCards.deliveries, 2) {
+		return
+	}
+	assert.Equal(t, "guardian-1", reportCards.deliveries[0].GuardianID)
+	assert.Equal(t, "Fall 2025", reportCards.deliveries[0].TermName)
+
+	// The link of the delivery downloads the file without signing in, a tampered one does not
+	link, err := url.Parse(reportCards.deliveries[0].Link.Path)
+	assert.NoError(t, err)
+	expires, err := strconv.ParseInt(link.Query().Get("expires"), 10, 64)
+	assert.NoError(t, err)
+	download := download_report_card_use_case.NewDownloadReportCardUseCase(reportCards)
+
+	downloadCommand, err := download_report_card_use_case.NewDownloadReportCardCommand(reportCard.ID, expires, link.Query().Get("signature"))
+	assert.NoError(t, err)
+	artifact, err := download.Execute(context.Background(), downloadCommand)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/pdf", artifact.ContentType)
+
+	tampered, err := download_report_card_use_case.NewDownloadReportCardCommand(reportCard.ID, expires+3600, link.Query().Get("signature"))
+	assert.NoError(t, err)
+	_, err = download.Execute(context.Background(), tampered)
+	assertCode(t, err, transcriptErrors.InvalidDownloadLinkError.String())
+}
+
+func TestDownloadReportCardUseCase_Execute_ExpiredLink(t *testing.T) {
+	reportCard, err := entities.NewReportCard(uuid.New().String(), "tenant1", "student-1", uuid.New().String(), entities.ReportCardStatusReady,
+		"", false, make([]byte, entities.SecretSize), "head-1", nil, time.Now(), time.Now())
+	assert.NoError(t, err)
+	link := reportCard.DownloadLink(time.Now().Add(-entities.LinkTTL - time.Minute))
+	parsed, _ := url.Parse(link.Path)
+
+	reportCards := &memoryReportCards{reportCards: []*entities.ReportCard{reportCard}, artifacts: map[string]*entities.Artifact{}}
+	command, err := download_report_card_use_case.NewDownloadReportCardCommand(reportCard.ID, link.ExpiresAt.Unix(), parsed.Query().Get("signature"))
+	assert.NoError(t, err)
+
+	_, err = download_report_card_use_case.NewDownloadReportCardUseCase(reportCards).Execute(context.Background(), command)
+
+	assertCode(t, err, transcriptErrors.InvalidDownloadLinkError.String())
+}
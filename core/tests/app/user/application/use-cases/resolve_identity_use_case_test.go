@@ -0,0 +1,55 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	resolve_identity_use_case "github.com/nahualventure/class-backend/core/app/user/application/use-cases/resolve-identity-use-case"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryIdentities struct {
+	identities map[string]*entities.Identity
+}
+
+func (m *memoryIdentities) FindIdentity(_ context.Context, userID string) (*entities.Identity, error) {
+	return m.identities[userID], nil
+}
+
+func resolve(store *memoryIdentities, userID string) (*entities.Identity, error) {
+	cmd, err := resolve_identity_use_case.NewResolveIdentityCommand(userID)
+	if err != nil {
+		return nil, err
+	}
+	return resolve_identity_use_case.NewResolveIdentityUseCase(store).Execute(context.Background(), cmd)
+}
+
+func TestResolveIdentity_ReturnsTheMembershipsOfTheUser(t *testing.T) {
+	store := &memoryIdentities{identities: map[string]*entities.Identity{
+		"user-1": {
+			User:        &entities.User{ID: "user-1", Name: "Ana"},
+			Memberships: []entities.TenantMembership{{TenantID: "tenant1", Roles: []string{"teacher"}}},
+		},
+	}}
+
+	identity, err := resolve(store, "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Ana", identity.User.Name)
+	assert.True(t, identity.MemberOf("tenant1"))
+	assert.Equal(t, []string{"teacher"}, identity.Roles("tenant1"))
+	assert.False(t, identity.MemberOf("tenant2"))
+}
+
+func TestResolveIdentity_RejectsUnknownUsers(t *testing.T) {
+	store := &memoryIdentities{identities: map[string]*entities.Identity{}}
+
+	_, err := resolve(store, "ghost")
+
+	var domainErr *appErrors.BaseDomainError
+	assert.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, appErrors.Unauthorized.String(), domainErr.Code)
+}
@@ -0,0 +1,252 @@
+package use_cases
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	detect_duplicate_users_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/detect-duplicate-users-use-case"
+	dismiss_duplicate_candidate_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/dismiss-duplicate-candidate-use-case"
+	get_user_merge_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/get-user-merge-use-case"
+	list_duplicate_candidates_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/list-duplicate-candidates-use-case"
+	merge_users_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/merge-users-use-case"
+	undo_user_merge_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/undo-user-merge-use-case"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	userMergeErrors "github.com/nahualventure/class-backend/core/app/usermerge/domain/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+type memoryMembers struct {
+	members []entities.Member
+}
+
+func (m *memoryMembers) Members(_ context.Context, _ string) ([]entities.Member, error) {
+	return m.members, nil
+}
+
+func (m *memoryMembers) Find(_ context.Context, _ string, userIDs []string) ([]entities.Member, error) {
+	var found []entities.Member
+	for _, member := range m.members {
+		if slices.Contains(userIDs, member.UserID) {
+			found = append(found, member)
+		}
+	}
+	return found, nil
+}
+
+type memoryCandidates struct {
+	candidates []*entities.DuplicateCandidate
+}
+
+func (m *memoryCandidates) SaveDetected(_ context.Context, _ string, candidates []*entities.DuplicateCandidate) (int, error) {
+	m.candidates = slices.DeleteFunc(m.candidates, func(saved *entities.DuplicateCandidate) bool {
+		return saved.Status == entities.DuplicateCandidateStatusOpen && !slices.ContainsFunc(candidates, func(found *entities.DuplicateCandidate) bool {
+			return samePair(saved, found)
+		})
+	})
+	added := 0
+	for _, candidate := range candidates {
+		if !slices.ContainsFunc(m.candidates, func(saved *entities.DuplicateCandidate) bool { return samePair(saved, candidate) }) {
+			m.candidates = append(m.candidates, candidate)
+			added++
+		}
+	}
+	return added, nil
+}
+
+func (m *memoryCandidates) FindByID(_ context.Context, tenantID string, candidateID string) (*entities.DuplicateCandidate, error) {
+	for _, candidate := range m.candidates {
+		if candidate.TenantID == tenantID && candidate.ID == candidateID {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryCandidates) ListOpen(_ context.Context, _ string, page *pagination.PageRequest) (*pagination.Page[*entities.DuplicateCandidate], error) {
+	var open []*entities.DuplicateCandidate
+	for _, candidate := range m.candidates {
+		if candidate.Status == entities.DuplicateCandidateStatusOpen {
+			open = append(open, candidate)
+		}
+	}
+	return pagination.NewPage(page, open, int64(len(open)), func(candidate *entities.DuplicateCandidate) []string {
+		return []string{pagination.TimeKey(candidate.DetectedAt), candidate.ID}
+	}), nil
+}
+
+func (m *memoryCandidates) Dismiss(_ context.Context, _ *entities.DuplicateCandidate) (bool, error) {
+	return true, nil
+}
+
+func samePair(a, b *entities.DuplicateCandidate) bool {
+	return a.UserID == b.UserID && a.DuplicateUserID == b.DuplicateUserID
+}
+
+// memoryMerges moves the enrollments of the users of the tenant, a "user/class" entry each
+type memoryMerges struct {
+	merges      []*entities.UserMerge
+	enrollments []string
+}
+
+func (m *memoryMerges) Merge(_ context.Context, merge *entities.UserMerge) error {
+	for i, enrollment := range m.enrollments {
+		if user, classID, _ := strings.Cut(enrollment, "/"); user == merge.MergedID {
+			m.enrollments[i] = merge.SurvivorID + "/" + classID
+			merge.Changes.Enrollments = append(merge.Changes.Enrollments, entities.Enrollment{ClassID: classID})
+		}
+	}
+	m.merges = append(m.merges, merge)
+	return nil
+}
+
+func (m *memoryMerges) Undo(_ context.Context, merge *entities.UserMerge) (bool, error) {
+	for _, moved := range merge.Changes.Enrollments {
+		if i := slices.Index(m.enrollments, merge.SurvivorID+"/"+moved.ClassID); i >= 0 {
+			m.enrollments[i] = merge.MergedID + "/" + moved.ClassID
+		}
+	}
+	return true, nil
+}
+
+func (m *memoryMerges) FindByID(_ context.Context, tenantID string, mergeID string) (*entities.UserMerge, error) {
+	for _, merge := range m.merges {
+		if merge.TenantID == tenantID && merge.ID == mergeID {
+			return merge, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryMerges) List(_ context.Context, _ string, page *pagination.PageRequest) (*pagination.Page[*entities.UserMerge], error) {
+	return pagination.NewPage(page, m.merges, int64(len(m.merges)), func(merge *entities.UserMerge) []string {
+		return []string{pagination.TimeKey(merge.CreatedAt), merge.ID}
+	}), nil
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func duplicates() *memoryMembers {
+	return &memoryMembers{members: []entities.Member{
+		{UserID: "u1", Name: "Ana López", Email: "ana.lopez@school.edu"},
+		{UserID: "u2", Name: "Ana López", Email: "analopez@gmail.com"},
+		{UserID: "u3", Name: "Luis Pérez", Email: "luis@school.edu", ExternalID: "S-1"},
+		{UserID: "u4", Name: "Luis Perez", Email: "lperez@school.edu", ExternalID: "S-1"},
+	}}
+}
+
+func detect(t *testing.T, members *memoryMembers, candidates *memoryCandidates) *detect_duplicate_users_use_case.DetectionResult {
+	t.Helper()
+	cmd, err := detect_duplicate_users_use_case.NewDetectDuplicateUsersCommand(tenantID)
+	assert.NoError(t, err)
+	result, err := detect_duplicate_users_use_case.NewDetectDuplicateUsersUseCase(members, candidates).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	return result
+}
+
+func TestDetectDuplicateUsers_SavesThePairsOnce(t *testing.T) {
+	members := duplicates()
+	candidates := &memoryCandidates{}
+
+	first := detect(t, members, candidates)
+	second := detect(t, members, candidates)
+
+	assert.Equal(t, &detect_duplicate_users_use_case.DetectionResult{Members: 4, Candidates: 2, New: 2}, first)
+	assert.Equal(t, 0, second.New, "pairs found before are not new")
+	assert.Len(t, candidates.candidates, 2)
+	assert.Equal(t, entities.DuplicateReasonSimilarEmail, candidates.candidates[0].Reason)
+	assert.Equal(t, entities.DuplicateReasonSameExternalID, candidates.candidates[1].Reason)
+}
+
+func TestDetectDuplicateUsers_DismissedPairsStayDismissed(t *testing.T) {
+	members := duplicates()
+	candidates := &memoryCandidates{}
+	dismiss := dismiss_duplicate_candidate_use_case.NewDismissDuplicateCandidateUseCase(candidates)
+	detect(t, members, candidates)
+
+	cmd, err := dismiss_duplicate_candidate_use_case.NewDismissDuplicateCandidateCommand(tenantID, candidates.candidates[0].ID, "admin-1")
+	assert.NoError(t, err)
+	dismissed, err := dismiss.Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.DuplicateCandidateStatusDismissed, dismissed.Status)
+
+	result := detect(t, members, candidates)
+	assert.Equal(t, 0, result.New)
+	listCmd, err := list_duplicate_candidates_use_case.NewListDuplicateCandidatesCommand(tenantID, 20, "")
+	assert.NoError(t, err)
+	page, err := list_duplicate_candidates_use_case.NewListDuplicateCandidatesUseCase(candidates).Execute(context.Background(), listCmd)
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1, "the dismissed pair is not listed again")
+
+	_, err = dismiss.Execute(context.Background(), cmd)
+	assert.Equal(t, userMergeErrors.DuplicateCandidateNotOpenError.String(), codeOf(err))
+}
+
+func TestMergeUsers_RejectsUsersThatCannotBeMerged(t *testing.T) {
+	merge := merge_users_use_case.NewMergeUsersUseCase(&memoryMerges{}, duplicates())
+
+	_, err := merge_users_use_case.NewMergeUsersCommand(tenantID, "u1", "u1", "admin-1")
+	assert.Error(t, err, "a user cannot be merged into itself")
+	_, err = merge_users_use_case.NewMergeUsersCommand(tenantID, "u1", "admin-1", "admin-1")
+	assert.Error(t, err, "the requester cannot merge their own account away")
+
+	cmd, err := merge_users_use_case.NewMergeUsersCommand(tenantID, "u1", "u9", "admin-1")
+	assert.NoError(t, err)
+	_, err = merge.Execute(context.Background(), cmd)
+	assert.Equal(t, userMergeErrors.MergeUserNotMemberError.String(), codeOf(err))
+}
+
+func TestMergeUsers_CanBeUndoneOnce(t *testing.T) {
+	merges := &memoryMerges{enrollments: []string{"u1/math", "u2/history"}}
+	undo := undo_user_merge_use_case.NewUndoUserMergeUseCase(merges)
+
+	cmd, err := merge_users_use_case.NewMergeUsersCommand(tenantID, "u1", "u2", "admin-1")
+	assert.NoError(t, err)
+	merge, err := merge_users_use_case.NewMergeUsersUseCase(merges, duplicates()).Execute(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.UserMergeStatusCompleted, merge.Status)
+	assert.Equal(t, []string{"u1/math", "u1/history"}, merges.enrollments)
+	assert.WithinDuration(t, time.Now().Add(entities.UndoWindow), merge.UndoUntil, time.Minute)
+
+	undoCmd, err := undo_user_merge_use_case.NewUndoUserMergeCommand(tenantID, merge.ID, "admin-2")
+	assert.NoError(t, err)
+	undone, err := undo.Execute(context.Background(), undoCmd)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.UserMergeStatusUndone, undone.Status)
+	assert.Equal(t, "admin-2", undone.UndoneBy)
+	assert.Equal(t, []string{"u1/math", "u2/history"}, merges.enrollments)
+
+	_, err = undo.Execute(context.Background(), undoCmd)
+	assert.Equal(t, userMergeErrors.UserMergeNotUndoableError.String(), codeOf(err))
+}
+
+func TestUndoUserMerge_FailsAfterTheUndoWindow(t *testing.T) {
+	merge, err := entities.NewUserMerge("6f1c3f6e-4d4b-4b8e-9a4c-1c2d3e4f5a6b", tenantID, "u1", "u2", "admin-1",
+		time.Now().Add(-entities.UndoWindow-time.Minute))
+	assert.NoError(t, err)
+	merges := &memoryMerges{merges: []*entities.UserMerge{merge}}
+
+	cmd, err := undo_user_merge_use_case.NewUndoUserMergeCommand(tenantID, merge.ID, "admin-1")
+	assert.NoError(t, err)
+	_, err = undo_user_merge_use_case.NewUndoUserMergeUseCase(merges).Execute(context.Background(), cmd)
+	assert.Equal(t, userMergeErrors.UserMergeNotUndoableError.String(), codeOf(err))
+
+	getCmd, err := get_user_merge_use_case.NewGetUserMergeCommand(tenantID, "0b7e3c1a-2f4d-4c6e-8a9b-1d2e3f4a5b6c")
+	assert.NoError(t, err)
+	_, err = get_user_merge_use_case.NewGetUserMergeUseCase(merges).Execute(context.Background(), getCmd)
+	assert.Equal(t, userMergeErrors.UserMergeNotFoundError.String(), codeOf(err))
+}
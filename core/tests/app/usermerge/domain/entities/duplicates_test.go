@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDuplicates_SameNameAndSimilarEmail(t *testing.T) {
+	members := []entities.Member{
+		{UserID: "u1", Name: "Ana López", Email: "ana.lopez@school.edu"},
+		{UserID: "u2", Name: " ana  LÓPEZ", Email: "analopez+old@gmail.com"},
+		{UserID: "u3", Name: "Ana López", Email: "anlopes@school.edu"},
+		{UserID: "u4", Name: "Ana López", Email: "maria@school.edu"},
+		{UserID: "u5", Name: "Luis Pérez", Email: "ana.lopez@school.edu"},
+	}
+
+	pairs := entities.FindDuplicates(members)
+
+	assert.Equal(t, []entities.DuplicatePair{
+		{UserID: "u1", DuplicateUserID: "u2", Reason: entities.DuplicateReasonSimilarEmail},
+		{UserID: "u1", DuplicateUserID: "u3", Reason: entities.DuplicateReasonSimilarEmail},
+		{UserID: "u2", DuplicateUserID: "u3", Reason: entities.DuplicateReasonSimilarEmail},
+	}, pairs, "dots, +tags and domains are ignored, two typos are tolerated, other names are never compared")
+}
+
+func TestFindDuplicates_SameExternalID(t *testing.T) {
+	members := []entities.Member{
+		{UserID: "u2", Name: "Ana López", Email: "ana@school.edu", ExternalID: "S-100"},
+		{UserID: "u1", Name: "Ana Lopez", Email: "a.lopez@school.edu", ExternalID: " s-100"},
+		{UserID: "u3", Name: "Ana López", Email: "ana@school.edu", ExternalID: "S-200"},
+		{UserID: "u4", Name: "Luis Pérez", Email: "luis@school.edu"},
+		{UserID: "u5", Name: "Marta Ruiz", Email: "marta@school.edu"},
+	}
+
+	pairs := entities.FindDuplicates(members)
+
+	assert.Equal(t, []entities.DuplicatePair{
+		{UserID: "u1", DuplicateUserID: "u2", Reason: entities.DuplicateReasonSameExternalID},
+		{UserID: "u2", DuplicateUserID: "u3", Reason: entities.DuplicateReasonSimilarEmail},
+	}, pairs, "users without an external ID are not paired by it")
+}
+
+func TestFindDuplicates_MatchingBothWaysIsReportedOnce(t *testing.T) {
+	members := []entities.Member{
+		{UserID: "u1", Name: "Ana López", Email: "ana@school.edu", ExternalID: "S-100"},
+		{UserID: "u2", Name: "Ana López", Email: "ana@gmail.com", ExternalID: "S-100"},
+		{UserID: "u2", Name: "Ana López", Email: "ana@gmail.com", ExternalID: "S-100"},
+	}
+
+	pairs := entities.FindDuplicates(members)
+
+	assert.Equal(t, []entities.DuplicatePair{
+		{UserID: "u1", DuplicateUserID: "u2", Reason: entities.DuplicateReasonSameExternalID},
+	}, pairs)
+}
+
+func TestNewUserMerge_CannotMergeAUserIntoItself(t *testing.T) {
+	_, err := entities.NewUserMerge("6f1c3f6e-4d4b-4b8e-9a4c-1c2d3e4f5a6b", "tenant1", "u1", "u1", "admin-1", time.Now())
+
+	assert.Error(t, err)
+}
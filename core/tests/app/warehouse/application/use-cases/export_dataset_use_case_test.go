@@ -0,0 +1,128 @@
+package use_cases
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	export_dataset_use_case "github.com/nahualventure/class-backend/core/app/warehouse/application/use-cases/export-dataset-use-case"
+	"github.com/nahualventure/class-backend/core/app/warehouse/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memorySource struct {
+	records []entities.Record
+}
+
+func (s *memorySource) ReadChanged(_ context.Context, _ string, after entities.Watermark, limit int) ([]entities.Record, error) {
+	var batch []entities.Record
+	for _, record := range s.records {
+		newer := record.UpdatedAt.After(after.UpdatedAt) ||
+			(record.UpdatedAt.Equal(after.UpdatedAt) && record.Key > after.Key)
+		if newer && len(batch) < limit {
+			batch = append(batch, record)
+		}
+	}
+	return batch, nil
+}
+
+type memoryWatermarks map[string]entities.Watermark
+
+func (w memoryWatermarks) Load(_ context.Context, tenantID string, dataset string) (*entities.Watermark, error) {
+	watermark, ok := w[tenantID+"/"+dataset]
+	if !ok {
+		return nil, nil
+	}
+	return &watermark, nil
+}
+
+func (w memoryWatermarks) Save(_ context.Context, watermark *entities.Watermark) error {
+	w[watermark.TenantID+"/"+watermark.Dataset] = *watermark
+	return nil
+}
+
+type memorySink map[string]string
+
+func (s memorySink) Put(_ context.Context, key string, _ string, content []byte) error {
+	s[key] = string(content)
+	return nil
+}
+
+func userRecord(key string, updatedAt time.Time) entities.Record {
+	stamp := updatedAt.Format(time.RFC3339)
+	return entities.Record{Key: key, UpdatedAt: updatedAt, Values: []string{key, "school.edu", stamp, stamp}}
+}
+
+func TestExportDatasetUseCase_Execute_IncrementalAndAnonymized(t *testing.T) {
+	base := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	source := &memorySource{records: []entities.Record{
+		userRecord("u1", base),
+		userRecord("u2", base), // same instant, must not be skipped
+		userRecord("u3", base.Add(time.Hour)),
+	}}
+	watermarks := memoryWatermarks{}
+	sink := memorySink{}
+	useCase := export_dataset_use_case.NewExportDatasetUseCase(source, watermarks, sink, []byte("secret"), 2)
+
+	command, err := export_dataset_use_case.NewExportDatasetCommand("tenant1", entities.UsersDataset)
+	assert.NoError(t, err)
+
+	result, err := useCase.Execute(context.Background(), command)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Rows)
+	assert.Len(t, result.Objects, 3) // schema + 2 parts
+	assert.Contains(t, sink, "tenant=tenant1/dataset=users/v1/_schema.json")
+	for key, content := range sink {
+		if strings.HasSuffix(key, ".csv") {
+			assert.True(t, strings.HasPrefix(content, "user_key,email_domain,created_at,updated_at\n"))
+			assert.NotContains(t, content, "u1,")
+		}
+	}
+	assert.Equal(t, "u3", watermarks["tenant1/users"].Key)
+
+	// Nothing changed: no new objects
+	result, err = useCase.Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Rows)
+	assert.Empty(t, result.Objects)
+
+	// A later change is exported alone
+	source.records = append(source.records, userRecord("u1", base.Add(2*time.Hour)))
+	result, err = useCase.Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Rows)
+}
+
+func TestExportDatasetUseCase_Execute_SchemaVersionChangeRestarts(t *testing.T) {
+	base := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	source := &memorySource{records: []entities.Record{userRecord("u1", base)}}
+	watermarks := memoryWatermarks{"tenant1/users": {
+		TenantID: "tenant1", Dataset: entities.UsersDataset, SchemaVersion: 0, UpdatedAt: base.Add(time.Hour),
+	}}
+	useCase := export_dataset_use_case.NewExportDatasetUseCase(source, watermarks, memorySink{}, []byte("secret"), 10)
+
+	command, err := export_dataset_use_case.NewExportDatasetCommand("tenant1", entities.UsersDataset)
+	assert.NoError(t, err)
+
+	result, err := useCase.Execute(context.Background(), command)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Rows)
+	assert.Equal(t, entities.Datasets[entities.UsersDataset].SchemaVersion, watermarks["tenant1/users"].SchemaVersion)
+}
+
+func TestDataset_Anonymize_IsStablePerTenant(t *testing.T) {
+	dataset := entities.Datasets[entities.UsersDataset]
+	key := []byte("secret")
+
+	first := dataset.Anonymize(key, "tenant1", []string{"u1", "school.edu"})
+	again := dataset.Anonymize(key, "tenant1", []string{"u1", "school.edu"})
+	otherTenant := dataset.Anonymize(key, "tenant2", []string{"u1", "school.edu"})
+
+	assert.Equal(t, first, again)
+	assert.NotEqual(t, first[0], otherTenant[0])
+	assert.Equal(t, "school.edu", first[1])
+}
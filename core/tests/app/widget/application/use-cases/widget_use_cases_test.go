@@ -0,0 +1,214 @@
+package use_cases
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	calendarEntities "github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	enrollmentEntities "github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	authenticate_widget_key_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/authenticate-widget-key-use-case"
+	create_widget_key_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/create-widget-key-use-case"
+	revoke_widget_key_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/revoke-widget-key-use-case"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	widgetErrors "github.com/nahualventure/class-backend/core/app/widget/domain/errors"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryKeys keeps the widget keys by ID, with the revokers of the revoked ones
+type memoryKeys struct {
+	keys     map[string]*entities.WidgetKey
+	revokers map[string]string
+}
+
+func newMemoryKeys() *memoryKeys {
+	return &memoryKeys{keys: make(map[string]*entities.WidgetKey), revokers: make(map[string]string)}
+}
+
+func (m *memoryKeys) FindByKey(_ context.Context, key string) (*entities.WidgetKey, error) {
+	for _, widgetKey := range m.keys {
+		if widgetKey.Key == key {
+			return widgetKey, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryKeys) Create(_ context.Context, key *entities.WidgetKey) error {
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *memoryKeys) Find(_ context.Context, tenantID string, keyID string) (*entities.WidgetKey, error) {
+	if key, ok := m.keys[keyID]; ok && key.TenantID == tenantID {
+		return key, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryKeys) List(_ context.Context, tenantID string) ([]*entities.WidgetKey, error) {
+	var keys []*entities.WidgetKey
+	for _, key := range m.keys {
+		if key.TenantID == tenantID {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m *memoryKeys) Revoke(_ context.Context, key *entities.WidgetKey, revokedBy string) error {
+	m.keys[key.ID] = key
+	m.revokers[key.ID] = revokedBy
+	return nil
+}
+
+var classID = uuid.NewString()
+
+func createKey(t *testing.T, keys *memoryKeys, origins []string, widgets []string) *entities.WidgetKey {
+	command, err := create_widget_key_use_case.NewCreateWidgetKeyCommand("tenant1", "School website", origins, widgets,
+		[]string{classID}, 0, "admin1")
+	assert.NoError(t, err)
+	key, err := create_widget_key_use_case.NewCreateWidgetKeyUseCase(keys).Execute(context.Background(), command)
+	assert.NoError(t, err)
+	return key
+}
+
+func authenticate(t *testing.T, keys *memoryKeys, key string, origin string, widget string, classID string) (*entities.WidgetKey, error) {
+	command, err := authenticate_widget_key_use_case.NewAuthenticateWidgetKeyCommand(key, origin, widget, classID)
+	assert.NoError(t, err)
+	return authenticate_widget_key_use_case.NewAuthenticateWidgetKeyUseCase(keys).Execute(context.Background(), command)
+}
+
+func assertCode(t *testing.T, err error, code appErrors.ErrorCode) {
+	t.Helper()
+	var appErr appErrors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr), "expected %s, got %v", code, err) {
+		assert.Equal(t, code.String(), appErr.GetCode())
+	}
+}
+
+func TestCreateWidgetKey_NormalizesOriginsAndDefaultsTheRateLimit(t *testing.T) {
+	keys := newMemoryKeys()
+
+	key := createKey(t, keys, []string{"HTTPS://North.edu:443", "https://*.north.edu"}, []string{"calendar", "announcements", "calendar"})
+
+	assert.True(t, strings.HasPrefix(key.Key, entities.KeyPrefix))
+	assert.Equal(t, []string{"https://north.edu", "https://*.north.edu"}, key.AllowedOrigins)
+	assert.Equal(t, []string{"announcements", "calendar"}, key.Widgets)
+	assert.Equal(t, entities.DefaultRateLimitPerMinute, key.RateLimitPerMinute)
+	assert.True(t, key.Active())
+	assert.Same(t, key, keys.keys[key.ID])
+}
+
+func TestCreateWidgetKey_RefusesOriginsWithPathsAndCalendarsWithoutClasses(t *testing.T) {
+	keys := newMemoryKeys()
+
+	command, err := create_widget_key_use_case.NewCreateWidgetKeyCommand("tenant1", "School website",
+		[]string{"https://north.edu/news"}, []string{"announcements"}, nil, 0, "admin1")
+	assert.NoError(t, err)
+	_, err = create_widget_key_use_case.NewCreateWidgetKeyUseCase(keys).Execute(context.Background(), command)
+	assertCode(t, err, appErrors.DomainEntityValidationError)
+
+	command, err = create_widget_key_use_case.NewCreateWidgetKeyCommand("tenant1", "School website",
+		[]string{"https://north.edu"}, []string{"calendar"}, nil, 0, "admin1")
+	assert.NoError(t, err)
+	_, err = create_widget_key_use_case.NewCreateWidgetKeyUseCase(keys).Execute(context.Background(), command)
+	assertCode(t, err, appErrors.DomainEntityValidationError)
+	assert.Empty(t, keys.keys)
+}
+
+func TestAuthenticateWidgetKey_ChecksOriginWidgetAndClass(t *testing.T) {
+	keys := newMemoryKeys()
+	key := createKey(t, keys, []string{"https://north.edu", "https://*.north.edu"}, []string{"calendar"})
+
+	authenticated, err := authenticate(t, keys, key.Key, "https://north.edu", "calendar", classID)
+	assert.NoError(t, err)
+	assert.Equal(t, key.ID, authenticated.ID)
+	_, err = authenticate(t, keys, key.Key, "https://www.north.edu", "calendar", classID)
+	assert.NoError(t, err, "wildcards match subdomains")
+
+	_, err = authenticate(t, keys, key.Key, "http://www.north.edu", "calendar", classID)
+	assertCode(t, err, widgetErrors.WidgetOriginNotAllowedError)
+	_, err = authenticate(t, keys, key.Key, "https://north.edu.evil.com", "calendar", classID)
+	assertCode(t, err, widgetErrors.WidgetOriginNotAllowedError)
+	_, err = authenticate(t, keys, key.Key, "", "calendar", classID)
+	assertCode(t, err, widgetErrors.WidgetOriginNotAllowedError)
+
+	_, err = authenticate(t, keys, key.Key, "https://north.edu", "announcements", "")
+	assertCode(t, err, widgetErrors.WidgetNotAllowedError)
+	_, err = authenticate(t, keys, key.Key, "https://north.edu", "calendar", uuid.NewString())
+	assertCode(t, err, widgetErrors.WidgetNotAllowedError)
+
+	_, err = authenticate(t, keys, "pk_unknown", "https://north.edu", "calendar", classID)
+	assertCode(t, err, widgetErrors.InvalidWidgetKeyError)
+}
+
+func TestRevokeWidgetKey_RefusesTheKeyFromThenOn(t *testing.T) {
+	keys := newMemoryKeys()
+	key := createKey(t, keys, []string{"https://north.edu"}, []string{"announcements"})
+
+	command, err := revoke_widget_key_use_case.NewRevokeWidgetKeyCommand("tenant2", key.ID, "admin2")
+	assert.NoError(t, err)
+	_, err = revoke_widget_key_use_case.NewRevokeWidgetKeyUseCase(keys).Execute(context.Background(), command)
+	assertCode(t, err, widgetErrors.WidgetKeyNotFoundError)
+
+	command, err = revoke_widget_key_use_case.NewRevokeWidgetKeyCommand("tenant1", key.ID, "admin1")
+	assert.NoError(t, err)
+	revoked, err := revoke_widget_key_use_case.NewRevokeWidgetKeyUseCase(keys).Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.False(t, revoked.Active())
+	assert.Equal(t, "admin1", keys.revokers[key.ID])
+
+	_, err = authenticate(t, keys, key.Key, "https://north.edu", "announcements", "")
+	assertCode(t, err, widgetErrors.InvalidWidgetKeyError)
+
+	// Revoking again keeps the first revocation
+	delete(keys.revokers, key.ID)
+	command, err = revoke_widget_key_use_case.NewRevokeWidgetKeyCommand("tenant1", key.ID, "admin3")
+	assert.NoError(t, err)
+	again, err := revoke_widget_key_use_case.NewRevokeWidgetKeyUseCase(keys).Execute(context.Background(), command)
+	assert.NoError(t, err)
+	assert.Equal(t, revoked.RevokedAt, again.RevokedAt)
+	assert.Empty(t, keys.revokers)
+}
+
+func TestRequestOrigin_FallsBackToTheReferer(t *testing.T) {
+	assert.Equal(t, "https://north.edu", entities.RequestOrigin("https://north.edu", "https://other.edu/page"))
+	assert.Equal(t, "https://north.edu:8443", entities.RequestOrigin("", "https://north.edu:8443/news?page=2"))
+	assert.Equal(t, "https://north.edu", entities.RequestOrigin("null", "https://north.edu/"))
+	assert.Equal(t, "", entities.RequestOrigin("", ""))
+}
+
+func TestNewClassCalendar_MarksSessionsOnNonInstructionalDays(t *testing.T) {
+	loc, err := time.LoadLocation("America/Guatemala")
+	assert.NoError(t, err)
+	// Monday 2025-03-03 to Monday 2025-03-17 in Guatemala, the second Wednesday is off
+	from := time.Date(2025, 3, 3, 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, 14)
+	holiday, err := calendarEntities.NewNonInstructionalDay("tenant1", time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC),
+		"Teacher training", "", "admin1", from)
+	assert.NoError(t, err)
+	calendar := calendarEntities.NewSchoolCalendar([]*calendarEntities.NonInstructionalDay{holiday}, loc)
+	meetings := []enrollmentEntities.MeetingTime{
+		{Weekday: time.Wednesday, StartMinute: 10 * 60, EndMinute: 11 * 60},
+		{Weekday: time.Monday, StartMinute: 8 * 60, EndMinute: 9*60 + 30},
+	}
+
+	classCalendar := entities.NewClassCalendar(classID, meetings, calendar, from, to)
+
+	assert.Equal(t, loc, classCalendar.Location)
+	if assert.Len(t, classCalendar.Sessions, 4) {
+		assert.Equal(t, time.Date(2025, 3, 3, 8, 0, 0, 0, loc), classCalendar.Sessions[0].StartsAt, "meetings on from are included")
+		assert.Equal(t, time.Date(2025, 3, 3, 9, 30, 0, 0, loc), classCalendar.Sessions[0].EndsAt)
+		assert.Equal(t, time.Date(2025, 3, 5, 10, 0, 0, 0, loc), classCalendar.Sessions[1].StartsAt)
+		assert.Equal(t, time.Date(2025, 3, 10, 8, 0, 0, 0, loc), classCalendar.Sessions[2].StartsAt)
+		assert.Equal(t, time.Date(2025, 3, 12, 10, 0, 0, 0, loc), classCalendar.Sessions[3].StartsAt)
+		assert.Empty(t, classCalendar.Sessions[2].CancelledBy)
+		assert.Equal(t, "Teacher training", classCalendar.Sessions[3].CancelledBy)
+	}
+}
@@ -0,0 +1,155 @@
+// Package authztest declares the roles, permissions and role assignments of a test inline and
+// serves them from an in-memory Casbin service, with the model of the application:
+//
+//	authz := authztest.New(t)
+//	authz.Grant("teacher", "grade", "update").For("tenant1")
+//	authz.Assign("user1", "teacher").In("tenant1")
+//	service := authz.Service()
+package authztest
+
+import (
+	"io/fs"
+	"slices"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/configs"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// Scenario collects the declarations of a test, Service builds them once
+type Scenario struct {
+	t           *testing.T
+	grants      []*Grant
+	assignments []*Assignment
+	tenants     []string
+	service     *authorization.CasbinService
+}
+
+// Grant is a permission of a role, in every tenant of the scenario unless For names some
+type Grant struct {
+	role     string
+	resource string
+	action   string
+	tenants  []string
+}
+
+// Assignment gives a role to a subject in the tenants In names
+type Assignment struct {
+	subject string
+	role    string
+	tenants []string
+}
+
+func New(t *testing.T) *Scenario {
+	t.Helper()
+	return &Scenario{t: t}
+}
+
+// Grant gives role the action on resource, "all" standing for every resource or action as in
+// policies.yaml. A role exists once it is granted something.
+func (s *Scenario) Grant(role, resource, action string) *Grant {
+	s.t.Helper()
+	s.declaring()
+	grant := &Grant{role: role, resource: resource, action: action}
+	s.grants = append(s.grants, grant)
+	return grant
+}
+
+// For limits the permission to tenants
+func (g *Grant) For(tenants ...string) *Grant {
+	g.tenants = append(g.tenants, tenants...)
+	return g
+}
+
+// Assign gives role to a user or service account subject, In names the tenants
+func (s *Scenario) Assign(subject, role string) *Assignment {
+	s.t.Helper()
+	s.declaring()
+	assignment := &Assignment{subject: subject, role: role}
+	s.assignments = append(s.assignments, assignment)
+	return assignment
+}
+
+// In gives the role in tenants
+func (a *Assignment) In(tenants ...string) *Assignment {
+	a.tenants = append(a.tenants, tenants...)
+	return a
+}
+
+// Tenants adds tenants the scenario serves without naming them in a declaration, the permissions
+// granted without For apply in them as well
+func (s *Scenario) Tenants(tenants ...string) *Scenario {
+	s.t.Helper()
+	s.declaring()
+	s.tenants = append(s.tenants, tenants...)
+	return s
+}
+
+// Service builds the declarations into a Casbin service on the first call and returns the same
+// service afterwards. Role changes made through the service stay in memory.
+func (s *Scenario) Service() *authorization.CasbinService {
+	s.t.Helper()
+	if s.service != nil {
+		return s.service
+	}
+
+	tenants := slices.Clone(s.tenants)
+	for _, grant := range s.grants {
+		tenants = append(tenants, grant.tenants...)
+	}
+	for _, assignment := range s.assignments {
+		if len(assignment.tenants) == 0 {
+			s.t.Fatalf("role %s of %s is assigned in no tenant, add In", assignment.role, assignment.subject)
+		}
+		tenants = append(tenants, assignment.tenants...)
+	}
+	slices.Sort(tenants)
+	tenants = slices.Compact(tenants)
+
+	loader := authorization.NewPolicyLoader()
+	for _, grant := range s.grants {
+		loader.Grant(grant.role, grant.resource, grant.action, grant.tenants...)
+	}
+	if len(s.grants) == 0 {
+		// A scenario without grants authorizes nothing, the loader still needs a config
+		if err := loader.LoadFromBytes([]byte("roles: {}\n")); err != nil {
+			s.t.Fatalf("failed to load the empty policies: %v", err)
+		}
+	}
+
+	model, err := fs.ReadFile(configs.Assets(""), configs.RBACModelFile)
+	if err != nil {
+		s.t.Fatalf("failed to read the Casbin model: %v", err)
+	}
+	service, authzErr := authorization.NewInMemoryCasbinService(string(model), loader, tenants)
+	if authzErr != nil {
+		s.t.Fatalf("failed to build the authorization service: %v", authzErr)
+	}
+	for _, assignment := range s.assignments {
+		for _, tenantID := range assignment.tenants {
+			if err := service.AssignRole(assignment.subject, assignment.role, tenantID); err != nil {
+				s.t.Fatalf("failed to assign %s to %s in %s: %v", assignment.role, assignment.subject, tenantID, err)
+			}
+		}
+	}
+
+	s.service = service
+	return service
+}
+
+// Can reports whether subject may do action on resource in tenantID
+func (s *Scenario) Can(subject, resource, action, tenantID string) bool {
+	s.t.Helper()
+	allowed, err := s.Service().CanDo(subject, resource, action, tenantID)
+	if err != nil {
+		s.t.Fatalf("failed to check %s:%s of %s in %s: %v", resource, action, subject, tenantID, err)
+	}
+	return allowed
+}
+
+func (s *Scenario) declaring() {
+	s.t.Helper()
+	if s.service != nil {
+		s.t.Fatalf("declarations must come before Service, change roles through the service instead")
+	}
+}
@@ -0,0 +1,160 @@
+// Package clocktest is a virtual clock.Clock for tests of expiries and scheduled jobs. Time stands
+// still until the test advances it. Advance delivers every tick due on the way and returns once the
+// job reading each ticker handled its tick and waits for the next one, so tests need no sleeps:
+//
+//	clk := clocktest.New(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC))
+//	go workers.RunSeatHoldCleanup(ctx, useCase.WithClock(clk), clk, time.Minute)
+//	clk.WaitForTickers(1) // the first sweep ran at 08:00
+//	clk.Advance(time.Minute) // and the second at 08:01
+package clocktest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+)
+
+// Clock is a clock.Clock whose time only moves with Advance
+type Clock struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	tickers []*Ticker
+}
+
+// Ticker is a ticker of a virtual clock. Ticks are not dropped like those of a time.Ticker, Advance
+// hands each one over and waits for the reader.
+type Ticker struct {
+	clock  *Clock
+	period time.Duration
+	next   time.Time
+	c      chan time.Time
+	// waits counts the calls to C, the reader waits for a tick while it asked more often than it
+	// was handed one
+	waits     int
+	delivered int
+	stopped   bool
+	done      chan struct{}
+}
+
+func New(start time.Time) *Clock {
+	c := &Clock{now: start}
+	c.changed = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker ticks every d of virtual time from now on, it panics when d is not positive like
+// time.NewTicker
+func (c *Clock) NewTicker(d time.Duration) clock.Ticker {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ticker := &Ticker{clock: c, period: d, next: c.now.Add(d), c: make(chan time.Time), done: make(chan struct{})}
+	c.tickers = append(c.tickers, ticker)
+	c.changed.Broadcast()
+	return ticker
+}
+
+// Advance moves time forward by d. Every tick due on the way is handed to its reader in order, with
+// the clock set to the time of the tick, and Advance waits until the reader asks for the next tick
+// or stops the ticker. A ticker whose reader never asks for a tick blocks Advance.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target := c.now.Add(d)
+	for {
+		ticker := c.nextDue(target)
+		if ticker == nil {
+			break
+		}
+		c.now = ticker.next
+		ticker.next = ticker.next.Add(ticker.period)
+
+		ticker.waitForReader()
+		if ticker.stopped {
+			continue
+		}
+		tick := c.now
+		c.mu.Unlock()
+		select {
+		case ticker.c <- tick:
+		case <-ticker.done:
+		}
+		c.mu.Lock()
+		ticker.delivered++
+		ticker.waitForReader()
+	}
+	c.now = target
+}
+
+// WaitForTickers blocks until n running tickers have a reader waiting for a tick. Call it after
+// starting a job in a goroutine, the job ran its first iteration once it returns.
+func (c *Clock) WaitForTickers(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.waiting() < n {
+		c.changed.Wait()
+	}
+}
+
+// nextDue returns the running ticker with the earliest tick up to target, nil when there is none
+func (c *Clock) nextDue(target time.Time) *Ticker {
+	var due *Ticker
+	for _, ticker := range c.tickers {
+		if ticker.stopped || ticker.next.After(target) {
+			continue
+		}
+		if due == nil || ticker.next.Before(due.next) {
+			due = ticker
+		}
+	}
+	return due
+}
+
+func (c *Clock) waiting() int {
+	waiting := 0
+	for _, ticker := range c.tickers {
+		if !ticker.stopped && ticker.waits > ticker.delivered {
+			waiting++
+		}
+	}
+	return waiting
+}
+
+// waitForReader waits with the lock of the clock held until the reader asks for a tick it was not
+// handed yet or stops the ticker
+func (t *Ticker) waitForReader() {
+	for !t.stopped && t.waits <= t.delivered {
+		t.clock.changed.Wait()
+	}
+}
+
+// C returns the channel of the ticks, read it from the select of every iteration so Advance knows
+// the previous tick was handled
+func (t *Ticker) C() <-chan time.Time {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.waits++
+	t.clock.changed.Broadcast()
+	return t.c
+}
+
+func (t *Ticker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	if !t.stopped {
+		t.stopped = true
+		close(t.done)
+		t.clock.changed.Broadcast()
+	}
+}
@@ -0,0 +1,37 @@
+package e2e
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// BenchmarkSignup signs up over HTTP through the router, the middlewares and the use case, with the
+// in-memory repository. Each request comes from another forwarded IP to stay under the signup limit.
+func BenchmarkSignup(b *testing.B) {
+	s := newServer(b, &memoryUsers{})
+	client := s.Client()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		body := fmt.Sprintf(`{"name": "Ada Lovelace", "email": "ada%d@example.com", "password": "correct horse battery"}`, i)
+		request, err := http.NewRequest(http.MethodPost, s.URL+"/auth/signup", strings.NewReader(body))
+		if err != nil {
+			b.Fatal(err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-Forwarded-For", fmt.Sprintf("10.%d.%d.%d", i>>16&255, i>>8&255, i&255))
+
+		response, err := client.Do(request)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, _ = io.Copy(io.Discard, response.Body)
+		response.Body.Close()
+		if response.StatusCode != http.StatusCreated {
+			b.Fatalf("signup %d answered %d", i, response.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Package e2e boots the HTTP stack of main on a real listener, the Gin router with its middlewares,
+// Huma with the application error envelope and the modules of the container, and calls it over
+// HTTP. Adapters are in-memory fakes and roles are served by an in-memory Casbin service loaded
+// with policies.yaml, so no database is needed.
+package e2e
+
+import (
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/shared/filtering"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	"github.com/nahualventure/class-backend/infra/configs"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/container"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humagin"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantID = "tenant1"
+
+// memoryUsers keeps the users signed up through the server
+type memoryUsers struct {
+	ports.UserRepository
+	mu     sync.Mutex
+	users  []*entities.User
+	emails map[string]bool
+}
+
+func (r *memoryUsers) ExistsByEmail(email string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.emails[email], nil
+}
+
+func (r *memoryUsers) Create(user *entities.User, password string) (*entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.emails == nil {
+		r.emails = make(map[string]bool)
+	}
+	r.emails[user.Email] = true
+	r.users = append(r.users, user)
+	return user, nil
+}
+
+func (r *memoryUsers) List(tenantID string, page *pagination.PageRequest, filter filtering.Expr) (*pagination.Page[*entities.User], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &pagination.Page[*entities.User]{Items: append([]*entities.User(nil), r.users...), TotalEstimate: int64(len(r.users))}, nil
+}
+
+// server is the HTTP stack of main serving the container modules
+type server struct {
+	*httptest.Server
+	authz *authorization.CasbinService
+}
+
+// newServer wires the router as main does, minus the middlewares that need Postgres or Redis
+func newServer(t testing.TB, users *memoryUsers) *server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	previous := huma.NewError
+	huma.NewError = utils.NewHumaError
+	t.Cleanup(func() { huma.NewError = previous })
+
+	loader := authorization.NewPolicyLoader()
+	if err := loader.LoadFromFS(configs.Assets(""), configs.PoliciesFile); err != nil {
+		t.Fatalf("failed to load the policies: %v", err)
+	}
+	model, err := fs.ReadFile(configs.Assets(""), configs.RBACModelFile)
+	if err != nil {
+		t.Fatalf("failed to read the Casbin model: %v", err)
+	}
+	authz, authzErr := authorization.NewInMemoryCasbinService(string(model), loader, []string{tenantID})
+	if authzErr != nil {
+		t.Fatalf("failed to build the authorization service: %v", authzErr)
+	}
+
+	router := gin.New()
+	router.Use(utils.ClientIPMiddleware)
+	router.Use(requestmeta.Middleware)
+
+	humaConfig := huma.DefaultConfig("Class Backend API", "test")
+	humaConfig.Transformers = append(humaConfig.Transformers, utils.LocalizeErrors)
+	humaConfig.Transformers = append(humaConfig.Transformers, authorization.NewRedactor(authz).Transformer)
+	api := humagin.New(router, humaConfig)
+	api.UseMiddleware(authorization.NewAuthorizationMiddleware(authz))
+	container.New(container.Adapters{Users: users}, container.Config{}).RegisterRoutes(api)
+
+	s := &server{Server: httptest.NewServer(router), authz: authz}
+	t.Cleanup(s.Close)
+	return s
+}
+
+// do sends a request with the identity headers of userID, none when it is empty
+func (s *server) do(t *testing.T, method, path, userID string, body string) (*http.Response, map[string]any) {
+	t.Helper()
+	request, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to build the request: %v", err)
+	}
+	if body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if userID != "" {
+		request.Header.Set(requestmeta.UserIDHeader, userID)
+		request.Header.Set(requestmeta.TenantIDHeader, tenantID)
+	}
+
+	response, err := s.Client().Do(request)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer response.Body.Close()
+	raw, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("failed to read the response: %v", err)
+	}
+	var decoded map[string]any
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			t.Fatalf("%s %s answered %d with a body that is not JSON: %s", method, path, response.StatusCode, raw)
+		}
+	}
+	return response, decoded
+}
+
+func (s *server) signup(t *testing.T, email string) string {
+	t.Helper()
+	response, body := s.do(t, http.MethodPost, "/auth/signup", "", `{"name": "Ada Lovelace", "email": "`+email+`", "password": "correct horse battery"}`)
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("signup of %s answered %d: %v", email, response.StatusCode, body)
+	}
+	return body["id"].(string)
+}
+
+func errorCode(body map[string]any) any {
+	envelope, _ := body["error"].(map[string]any)
+	return envelope["code"]
+}
+
+func TestHTTP_Signup(t *testing.T) {
+	users := &memoryUsers{}
+	s := newServer(t, users)
+
+	response, body := s.do(t, http.MethodPost, "/auth/signup", "", `{"name": "Ada Lovelace", "email": "ada@example.com", "password": "correct horse battery"}`)
+
+	assert.Equal(t, http.StatusCreated, response.StatusCode, body)
+	assert.Contains(t, response.Header.Get("Content-Type"), "application/json")
+	assert.Len(t, response.Header.Get(requestmeta.TraceIDHeader), 32, "every response carries the trace ID of the request")
+	assert.NotEmpty(t, body["id"])
+	assert.Equal(t, "Ada Lovelace", body["name"])
+	assert.NotContains(t, body, "email", "anonymous callers lack user:view_contact")
+	assert.NotContains(t, body, "password")
+	assert.Len(t, users.users, 1)
+
+	response, body = s.do(t, http.MethodPost, "/auth/signup", "", `{"name": "Ada", "email": "ada@example.com", "password": "correct horse battery"}`)
+	assert.Equal(t, http.StatusConflict, response.StatusCode, body)
+	assert.Equal(t, "EMAIL_ALREADY_EXISTS", errorCode(body))
+}
+
+func TestHTTP_SignupValidation(t *testing.T) {
+	s := newServer(t, &memoryUsers{})
+
+	response, body := s.do(t, http.MethodPost, "/auth/signup", "", `{"name": "Ada", "email": "not an email", "password": "short"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, response.StatusCode, body)
+	assert.Contains(t, response.Header.Get("Content-Type"), "json")
+	assert.Equal(t, "VALIDATION_ERROR", errorCode(body))
+}
+
+func TestHTTP_TraceIDIsPropagated(t *testing.T) {
+	s := newServer(t, &memoryUsers{})
+	request, _ := http.NewRequest(http.MethodGet, s.URL+"/users", nil)
+	request.Header.Set(requestmeta.TraceIDHeader, "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	response, err := s.Client().Do(request)
+	assert.Nil(t, err)
+	response.Body.Close()
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", response.Header.Get(requestmeta.TraceIDHeader))
+}
+
+// RFC 7009 answers 200 whatever the token was, an empty body must not turn it into a 204
+func TestHTTP_RevokeTokenAnswers200(t *testing.T) {
+	s := newServer(t, &memoryUsers{})
+	request, _ := http.NewRequest(http.MethodPost, s.URL+"/oauth/revoke", strings.NewReader("token=not-a-token"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := s.Client().Do(request)
+	assert.Nil(t, err)
+	response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestHTTP_Authorization(t *testing.T) {
+	s := newServer(t, &memoryUsers{})
+	adminID := s.signup(t, "admin@example.com")
+	studentID := s.signup(t, "student@example.com")
+	assert.Nil(t, s.authz.AssignRole(adminID, "admin", tenantID))
+	assert.Nil(t, s.authz.AssignRole(studentID, "student", tenantID))
+
+	t.Run("missing identity", func(t *testing.T) {
+		response, body := s.do(t, http.MethodGet, "/users", "", "")
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode, body)
+		assert.Equal(t, "UNAUTHORIZED", errorCode(body))
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		response, body := s.do(t, http.MethodGet, "/users", studentID, "")
+		assert.Equal(t, http.StatusForbidden, response.StatusCode, body)
+		assert.Contains(t, response.Header.Get("Content-Type"), "json")
+		assert.Equal(t, "FORBIDDEN", errorCode(body))
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		response, body := s.do(t, http.MethodGet, "/users", adminID, "")
+		assert.Equal(t, http.StatusOK, response.StatusCode, body)
+		items, _ := body["items"].([]any)
+		if assert.Len(t, items, 2) {
+			assert.Equal(t, "admin@example.com", items[0].(map[string]any)["email"], "admins see contact details")
+		}
+	})
+
+	t.Run("role revoked", func(t *testing.T) {
+		assert.Nil(t, s.authz.RemoveRole(adminID, "admin", tenantID))
+		response, body := s.do(t, http.MethodGet, "/users", adminID, "")
+		assert.Equal(t, http.StatusForbidden, response.StatusCode, body)
+	})
+}
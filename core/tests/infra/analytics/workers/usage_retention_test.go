@@ -0,0 +1,64 @@
+package workers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	record_api_usage_use_case "github.com/nahualventure/class-backend/core/app/analytics/application/use-cases/record-api-usage-use-case"
+	"github.com/nahualventure/class-backend/core/app/analytics/domain/ports"
+	"github.com/nahualventure/class-backend/core/tests/clocktest"
+	"github.com/nahualventure/class-backend/infra/analytics/workers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// purgedUsage records the cutoffs of the purges, the worker calls it from its goroutine
+type purgedUsage struct {
+	ports.UsageRepository
+	mu      sync.Mutex
+	cutoffs []time.Time
+}
+
+func (p *purgedUsage) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cutoffs = append(p.cutoffs, before)
+	return 0, nil
+}
+
+func (p *purgedUsage) purges() []time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]time.Time(nil), p.cutoffs...)
+}
+
+func TestRunUsageRetention_PurgesEveryIntervalOfTheClock(t *testing.T) {
+	start := time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC)
+	clk := clocktest.New(start)
+	store := &purgedUsage{}
+	useCase := record_api_usage_use_case.NewRecordAPIUsageUseCase(store).WithClock(clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		workers.RunUsageRetention(ctx, useCase, clk, 24*time.Hour)
+		close(done)
+	}()
+	clk.WaitForTickers(1)
+	assert.Equal(t, []time.Time{start.Add(-record_api_usage_use_case.UsageRetention)}, store.purges(), "the worker purges when it starts")
+
+	clk.Advance(23 * time.Hour)
+	assert.Len(t, store.purges(), 1)
+
+	clk.Advance(48 * time.Hour)
+	assert.Equal(t, []time.Time{
+		start.Add(-record_api_usage_use_case.UsageRetention),
+		start.Add(24*time.Hour - record_api_usage_use_case.UsageRetention),
+		start.Add(48*time.Hour - record_api_usage_use_case.UsageRetention),
+	}, store.purges(), "every purge drops what left the retention window by the time of its tick")
+
+	cancel()
+	<-done
+}
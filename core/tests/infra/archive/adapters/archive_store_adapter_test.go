@@ -0,0 +1,88 @@
+package adapters
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/archive/domain/entities"
+	"github.com/nahualventure/class-backend/infra/archive/adapters"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPostgresArchiveStore_RestoresArchivedGrades runs against the migrated database in
+// TEST_DATABASE_URL. Released grades of prior years move to archived_records and back, a grade
+// recorded again since archival is kept and its archived copy stays in the batch.
+func TestPostgresArchiveStore_RestoresArchivedGrades(t *testing.T) {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer pool.Close()
+
+	tenantID := "archive-" + uuid.NewString()[:8]
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM grades WHERE tenant_id = $1", tenantID)
+		pool.Exec(ctx, "DELETE FROM archived_records WHERE tenant_id = $1", tenantID)
+		pool.Exec(ctx, "DELETE FROM archive_batches WHERE tenant_id = $1", tenantID)
+	})
+
+	classID, kept, regraded := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	releasedAt := time.Date(2020, time.March, 2, 9, 0, 0, 0, time.UTC)
+	for _, id := range []string{kept, regraded} {
+		_, err := pool.Exec(ctx, `INSERT INTO grades (id, tenant_id, class_id, assignment_id, student_id, score, status, recorded_by, released_at)
+			VALUES ($1, $2, $3, $4, 'student1', 87.5, 'released', 'teacher1', $5)`, id, tenantID, classID, uuid.NewString(), releasedAt)
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+
+	store := adapters.NewPostgresArchiveStore(pool)
+	batch, err := entities.NewArchiveBatch(uuid.NewString(), tenantID, entities.ArchiveKindSubmissions,
+		time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC), time.Now())
+	if !assert.NoError(t, err) {
+		return
+	}
+	moved, err := store.Archive(ctx, batch, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, moved)
+
+	var hot int
+	assert.NoError(t, pool.QueryRow(ctx, "SELECT count(*) FROM grades WHERE tenant_id = $1", tenantID).Scan(&hot))
+	assert.Zero(t, hot, "archived grades leave the hot table")
+
+	// The student turns in the work again, the new grade wins over the archived one
+	_, err = pool.Exec(ctx, `INSERT INTO grades (id, tenant_id, class_id, assignment_id, student_id, score, status, recorded_by)
+		SELECT id, tenant_id, class_id, assignment_id, student_id, 95, 'draft', recorded_by FROM (
+			SELECT (payload->>'id')::uuid AS id, tenant_id, (payload->>'class_id')::uuid AS class_id,
+			       (payload->>'assignment_id')::uuid AS assignment_id, payload->>'student_id' AS student_id,
+			       payload->>'recorded_by' AS recorded_by
+			FROM archived_records WHERE kind = 'submissions' AND tenant_id = $1 AND record_id = $2
+		) archived`, tenantID, regraded)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	restored, remaining, err := store.Restore(ctx, batch)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, restored)
+	assert.Equal(t, 1, remaining, "the archived copy of the regraded grade stays in the batch")
+
+	var score float64
+	var status string
+	assert.NoError(t, pool.QueryRow(ctx, "SELECT score, status FROM grades WHERE id = $1", kept).Scan(&score, &status))
+	assert.Equal(t, 87.5, score)
+	assert.Equal(t, "released", status)
+	assert.NoError(t, pool.QueryRow(ctx, "SELECT score FROM grades WHERE id = $1", regraded).Scan(&score))
+	assert.Equal(t, 95.0, score, "restoring does not overwrite a newer grade")
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	batch_signup_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/batch-signup-use-case"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	userErrors "github.com/nahualventure/class-backend/core/app/user/domain/errors"
+	"github.com/nahualventure/class-backend/core/tests/authztest"
+	"github.com/nahualventure/class-backend/core/tests/mocks"
+	authHandlers "github.com/nahualventure/class-backend/infra/auth/handlers"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/grpcserver"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	authv1 "github.com/nahualventure/class-backend/proto/gen/auth/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func dialAuth(t *testing.T, repo *mocks.MockUserRepository, onSignUp func(ctx context.Context, user *entities.User)) authv1.AuthServiceClient {
+	authz := authztest.New(t)
+	authz.Grant("admin", "user", "create")
+	authz.Assign("admin-1", "admin").In("tenant1")
+	service := authz.Service()
+
+	auth := authHandlers.NewAuthHandlers(nil, batch_signup_use_case.NewBatchSignupUseCase(repo), nil, nil, nil)
+	auth.OnSignUp(onSignUp)
+	server := grpcserver.New(service, grpcserver.Options{}, authHandlers.NewAuthGRPCHandlers(auth, authorization.NewRedactor(service)))
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return authv1.NewAuthServiceClient(conn)
+}
+
+func TestAuthGRPCHandlers_BatchSignupReportsEveryUser(t *testing.T) {
+	createdAt := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	repo := &mocks.MockUserRepository{}
+	repo.On("FindExistingEmails", []string{"ada@example.com", "grace@example.com"}).Return([]string{"grace@example.com"}, nil)
+	repo.On("CreateMany", mock.Anything).Return([]*entities.User{
+		{ID: "user-1", Name: "Ada", Email: "ada@example.com", CreatedAt: createdAt, UpdatedAt: createdAt},
+	}, nil).Once()
+	var signedUp []string
+	client := dialAuth(t, repo, func(ctx context.Context, user *entities.User) { signedUp = append(signedUp, user.ID) })
+
+	ctx := requestmeta.Meta{UserID: "admin-1", TenantID: "tenant1"}.AppendToOutgoingGRPC(context.Background())
+	response, err := client.BatchSignup(ctx, &authv1.BatchSignupRequest{Users: []*authv1.BatchSignupUser{
+		{Name: "Ada", Email: "ada@example.com", Password: "password123"},
+		{Name: "Grace", Email: "grace@example.com", Password: "password123"},
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), response.Summary.Succeeded)
+	assert.Equal(t, int32(1), response.Summary.Failed)
+	assert.Equal(t, int32(1), response.Created, "the deprecated counts are still set")
+	require.Len(t, response.Results, 2)
+
+	created := response.Results[0]
+	assert.Equal(t, "created", created.Outcome.Status)
+	assert.Equal(t, "created", created.Status)
+	assert.Equal(t, "user-1", created.User.Id)
+	assert.Equal(t, "admin-1", created.User.Audit.CreatedBy, "the caller created the user")
+	assert.Empty(t, created.User.Email, "the email needs user:view_contact")
+	assert.Nil(t, created.Outcome.Error)
+	assert.Equal(t, []string{"user-1"}, signedUp)
+
+	failed := response.Results[1]
+	assert.Equal(t, "failed", failed.Outcome.Status)
+	assert.Equal(t, int32(1), failed.Outcome.Index)
+	assert.Equal(t, "grace@example.com", failed.Email)
+	assert.Nil(t, failed.User)
+	assert.Equal(t, string(userErrors.EmailAlreadyExistsError), failed.Outcome.Error.Code)
+	assert.Equal(t, failed.Outcome.Error.Code, failed.Error.Code)
+}
+
+func TestAuthGRPCHandlers_BatchSignupNeedsThePermissionOfTheOperation(t *testing.T) {
+	client := dialAuth(t, &mocks.MockUserRepository{}, func(ctx context.Context, user *entities.User) {})
+
+	ctx := requestmeta.Meta{UserID: "teacher-1", TenantID: "tenant1"}.AppendToOutgoingGRPC(context.Background())
+	_, err := client.BatchSignup(ctx, &authv1.BatchSignupRequest{Users: []*authv1.BatchSignupUser{
+		{Name: "Ada", Email: "ada@example.com", Password: "password123"},
+	}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
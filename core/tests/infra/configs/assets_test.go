@@ -0,0 +1,43 @@
+package configs
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/configs"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssets_BundlesTheRBACFiles(t *testing.T) {
+	assets := configs.Assets("")
+
+	model, err := fs.ReadFile(assets, configs.RBACModelFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(model), "[request_definition]")
+
+	loader := authorization.NewPolicyLoader()
+	assert.Nil(t, loader.LoadFromFS(assets, configs.PoliciesFile))
+	assert.Nil(t, loader.ValidateYAMLConfig())
+	assert.NotEmpty(t, loader.GetRoles())
+	access, err := loader.EndpointAccess()
+	assert.Nil(t, err)
+	assert.True(t, access.IsPublic("health"))
+}
+
+func TestAssets_OverrideDirReplacesSomeFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, configs.PoliciesFile), []byte("roles: {}\n"), 0o644))
+	assets := configs.Assets(dir)
+
+	policies, err := fs.ReadFile(assets, configs.PoliciesFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "roles: {}\n", string(policies))
+
+	model, err := fs.ReadFile(assets, configs.RBACModelFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(model), "[request_definition]")
+}
@@ -0,0 +1,93 @@
+package adapters
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	deliverabilityErrors "github.com/nahualventure/class-backend/core/app/deliverability/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	deliverabilityAdapters "github.com/nahualventure/class-backend/infra/deliverability/adapters"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const payload = `[
+	{"sg_event_id":"e1","email":"Ana@School.edu","event":"bounce","type":"bounce","reason":"550 no such user","timestamp":1763450000,"tenant_id":"tenant1"},
+	{"sg_event_id":"e2","email":"luis@school.edu","event":"bounce","type":"blocked","reason":"ip blocklisted","timestamp":1763450000,"tenant_id":"tenant1"},
+	{"sg_event_id":"e3","email":"marta@school.edu","event":"spamreport","timestamp":1763450000,"tenant_id":"tenant1"},
+	{"sg_event_id":"e4","email":"pedro@school.edu","event":"open","timestamp":1763450000,"tenant_id":"tenant1"}
+]`
+
+// signedWebhook returns the webhook of a SendGrid account and the signer of its events
+func signedWebhook(t *testing.T) (func(timestamp string, body string) string, *deliverabilityAdapters.SendGridConfig) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	sign := func(timestamp string, body string) string {
+		digest := sha256.Sum256([]byte(timestamp + body))
+		signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(signature)
+	}
+	return sign, &deliverabilityAdapters.SendGridConfig{VerificationKey: base64.StdEncoding.EncodeToString(der)}
+}
+
+func codeOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.GetCode()
+	}
+	return ""
+}
+
+func TestSendGridDeliveryWebhook_ParsesSignedEvents(t *testing.T) {
+	sign, config := signedWebhook(t)
+	webhook, err := deliverabilityAdapters.NewSendGridDeliveryWebhook(*config)
+	require.NoError(t, err)
+
+	eventID, err := webhook.Verify(context.Background(), []byte(payload), sign("1763450001", payload), "1763450001")
+	require.NoError(t, err)
+	assert.Empty(t, eventID, "batches are deduplicated by their body")
+
+	events, err := webhook.ParseEvents(context.Background(), []byte(payload))
+	require.NoError(t, err)
+
+	require.Len(t, events, 3, "opens are not delivery events")
+	assert.Equal(t, entities.DeliveryEventBounced, events[0].Type)
+	assert.Equal(t, "ana@school.edu", events[0].Email)
+	assert.Equal(t, "tenant1", events[0].TenantID)
+	assert.Equal(t, entities.DeliveryEventSoftBounced, events[1].Type, "blocked emails say nothing of the address")
+	assert.Equal(t, entities.DeliveryEventComplained, events[2].Type)
+}
+
+func TestSendGridDeliveryWebhook_RefusesUnsignedBodies(t *testing.T) {
+	sign, config := signedWebhook(t)
+	webhook, err := deliverabilityAdapters.NewSendGridDeliveryWebhook(*config)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		signature string
+		timestamp string
+	}{
+		"other timestamp": {sign("1763450001", payload), "1763450002"},
+		"other body":      {sign("1763450001", "[]"), "1763450001"},
+		"not base64":      {"%%%", "1763450001"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := webhook.Verify(context.Background(), []byte(payload), tt.signature, tt.timestamp)
+			assert.Equal(t, deliverabilityErrors.InvalidEmailWebhookError.String(), codeOf(err))
+		})
+	}
+}
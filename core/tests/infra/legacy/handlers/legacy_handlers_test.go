@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/tests/mocks"
+	"github.com/nahualventure/class-backend/infra/shared/container"
+	"github.com/nahualventure/class-backend/infra/shared/deprecation"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newAPI serves the container behind the deprecation tracker of main
+func newAPI(t *testing.T, adapters container.Adapters) (humatest.TestAPI, *deprecation.Tracker) {
+	previous := huma.NewError
+	huma.NewError = utils.NewHumaError
+	t.Cleanup(func() { huma.NewError = previous })
+
+	tracker := deprecation.NewTracker(deprecation.Endpoints, time.Hour)
+	_, api := humatest.New(t)
+	api.OpenAPI().OnAddOperation = append(api.OpenAPI().OnAddOperation, tracker.DocumentOperation)
+	api.UseMiddleware(tracker.Middleware)
+	container.New(adapters, container.Config{}).RegisterRoutes(api)
+	return api, tracker
+}
+
+func TestLegacy_CreateUserTranslatesTheOldPayload(t *testing.T) {
+	created, err := entities.NewUser("6f1f0a52-3a3e-4b8e-9d43-0f7f1c1b2a10", "Ada Lovelace", "ada@example.com", time.Now(), time.Now())
+	assert.NoError(t, err)
+	users := &mocks.MockUserRepository{}
+	users.On("ExistsByEmail", "ada@example.com").Return(false, nil)
+	users.On("Create", mock.MatchedBy(func(user *entities.User) bool { return user.Name == "Ada Lovelace" }), "correct horse battery").
+		Return(created, nil)
+	api, tracker := newAPI(t, container.Adapters{Users: users})
+
+	response := api.Post("/api/v1/users", map[string]any{
+		"fullName": "Ada Lovelace",
+		"email":    "ada@example.com",
+		"password": "correct horse battery",
+	})
+
+	assert.Equal(t, http.StatusCreated, response.Code, response.Body.String())
+	assert.Contains(t, response.Body.String(), `"data":{"id":"6f1f0a52-3a3e-4b8e-9d43-0f7f1c1b2a10"`)
+	assert.Contains(t, response.Body.String(), `"fullName":"Ada Lovelace"`)
+	assert.Equal(t, "Sat, 31 Jul 2027 00:00:00 GMT", response.Header().Get("Sunset"))
+	assert.Equal(t, `</auth/signup/batch>; rel="successor-version"`, response.Header().Get("Link"))
+
+	var metrics bytes.Buffer
+	assert.NoError(t, tracker.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `deprecated_endpoint_requests_total{operation="legacy-create-user"} 1`)
+	assert.Contains(t, metrics.String(), `deprecated_endpoint_requests_total{operation="legacy-list-users"} 0`)
+	users.AssertExpectations(t)
+}
+
+func TestLegacy_ListUsersPagesWithTheNextToken(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	users := &mocks.MockUserRepository{}
+	users.On("List", "", mock.MatchedBy(func(page *pagination.PageRequest) bool { return page.PageSize == 2 }), mock.Anything).
+		Return(&pagination.Page[*entities.User]{
+			Items: []*entities.User{{
+				ID: "6f1f0a52-3a3e-4b8e-9d43-0f7f1c1b2a10", Name: "Ada Lovelace", Email: "ada@example.com",
+				CreatedAt: createdAt, UpdatedAt: createdAt,
+			}},
+			NextCursor: "eyJhZnRlciI6WyIyMDI1Il19",
+		}, nil)
+	api, _ := newAPI(t, container.Adapters{Users: users})
+
+	response := api.Get("/api/v1/users?limit=2")
+
+	assert.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	assert.JSONEq(t, `{
+		"data": [{"id": "6f1f0a52-3a3e-4b8e-9d43-0f7f1c1b2a10", "fullName": "Ada Lovelace", "email": "ada@example.com", "createdAt": "2025-03-01T08:00:00Z"}],
+		"next": "eyJhZnRlciI6WyIyMDI1Il19"
+	}`, strings.TrimSpace(response.Body.String()))
+}
+
+func TestLegacy_EveryRouteHasASunsetAndAnExistingSuccessor(t *testing.T) {
+	api, _ := newAPI(t, container.Adapters{})
+
+	legacy := 0
+	for path, item := range api.OpenAPI().Paths {
+		if !strings.HasPrefix(path, "/api/v1/") {
+			continue
+		}
+		for _, operation := range []*huma.Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+			if operation == nil {
+				continue
+			}
+			legacy++
+			notice, deprecated := deprecation.Endpoints[operation.OperationID]
+			if assert.True(t, deprecated, "%s %s is not deprecated", path, operation.OperationID) {
+				assert.False(t, notice.Sunset.IsZero(), "%s has no sunset", operation.OperationID)
+				assert.Contains(t, api.OpenAPI().Paths, notice.Successor, "%s points to a missing successor", operation.OperationID)
+			}
+			assert.True(t, operation.Deprecated)
+		}
+	}
+	assert.Equal(t, 4, legacy)
+}
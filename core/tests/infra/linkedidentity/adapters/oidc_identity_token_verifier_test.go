@@ -0,0 +1,139 @@
+package adapters
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	linkedIdentityErrors "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	linkedIdentityAdapters "github.com/nahualventure/class-backend/infra/linkedidentity/adapters"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	issuer   = "https://idp.example.com"
+	clientID = "class-backend"
+)
+
+// provider signs tokens with key and serves its public half as a JWKS
+type provider struct {
+	key     *rsa.PrivateKey
+	fetches int
+}
+
+func newProvider(t *testing.T) (*provider, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := &provider{key: key}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.fetches++
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(server.Close)
+	return p, server.URL
+}
+
+func (p *provider) sign(t *testing.T, kid string, claims map[string]any) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": kid})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func claims(overrides map[string]any) map[string]any {
+	claims := map[string]any{
+		"iss":            issuer,
+		"sub":            "subject-1",
+		"aud":            clientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"email":          "ada@example.com",
+		"email_verified": true,
+	}
+	for claim, value := range overrides {
+		claims[claim] = value
+	}
+	return claims
+}
+
+func reasonOf(err error) string {
+	var appErr appErrors.ApplicationError
+	if errors.As(err, &appErr) && appErr.GetCode() == linkedIdentityErrors.InvalidIdentityTokenError.String() {
+		reason, _ := appErr.GetContext()["reason"].(string)
+		return reason
+	}
+	return ""
+}
+
+func TestOIDCIdentityTokenVerifier_ReadsTheIdentityOfValidTokens(t *testing.T) {
+	p, jwksURL := newProvider(t)
+	verifier := linkedIdentityAdapters.NewOIDCIdentityTokenVerifier(map[entities.Provider]linkedIdentityAdapters.IdentityProviderConfig{
+		entities.ProviderGoogle: {Issuers: []string{issuer, "idp.example.com"}, JWKSURL: jwksURL, Audience: clientID},
+	})
+
+	identity, err := verifier.Verify(context.Background(), entities.ProviderGoogle, p.sign(t, "key-1", claims(map[string]any{
+		"iss": "idp.example.com",
+		"aud": []string{"another-client", clientID},
+	})))
+	require.NoError(t, err)
+	assert.Equal(t, &entities.VerifiedIdentity{
+		Provider: entities.ProviderGoogle,
+		Issuer:   issuer,
+		Subject:  "subject-1",
+		Email:    "ada@example.com",
+	}, identity, "the identity is linked with the first issuer")
+
+	identity, err = verifier.Verify(context.Background(), entities.ProviderGoogle, p.sign(t, "key-1", claims(map[string]any{"email_verified": false})))
+	require.NoError(t, err)
+	assert.Empty(t, identity.Email, "unverified emails are left out")
+	assert.Equal(t, 1, p.fetches, "the keys are cached")
+}
+
+func TestOIDCIdentityTokenVerifier_RefusesTokensItCannotTrust(t *testing.T) {
+	p, jwksURL := newProvider(t)
+	other, _ := newProvider(t)
+	verifier := linkedIdentityAdapters.NewOIDCIdentityTokenVerifier(map[entities.Provider]linkedIdentityAdapters.IdentityProviderConfig{
+		entities.ProviderGoogle: {Issuers: []string{issuer}, JWKSURL: jwksURL, Audience: clientID},
+	})
+
+	refused := map[string]string{
+		"signature mismatch":            other.sign(t, "key-1", claims(nil)),
+		"unknown signing key":           p.sign(t, "key-2", claims(nil)),
+		"unexpected issuer":             p.sign(t, "key-1", claims(map[string]any{"iss": "https://evil.example.com"})),
+		"issued to another application": p.sign(t, "key-1", claims(map[string]any{"aud": "another-client"})),
+		"expired":                       p.sign(t, "key-1", claims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})),
+		"malformed token":               "not-a-token",
+	}
+	for reason, token := range refused {
+		_, err := verifier.Verify(context.Background(), entities.ProviderGoogle, token)
+		assert.Equal(t, reason, reasonOf(err))
+	}
+
+	_, err := verifier.Verify(context.Background(), entities.ProviderSAML, p.sign(t, "key-1", claims(nil)))
+	assert.Equal(t, "provider not configured", reasonOf(err))
+}
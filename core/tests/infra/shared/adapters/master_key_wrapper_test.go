@@ -0,0 +1,41 @@
+package adapters
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	sharedAdapters "github.com/nahualventure/class-backend/infra/shared/adapters"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMasterKeyWrapper_RoundTripsKeysOfTheirTenant(t *testing.T) {
+	ctx := context.Background()
+	wrapper, err := sharedAdapters.NewMasterKeyWrapper(base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	assert.NoError(t, err)
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	wrapped, err := wrapper.Wrap(ctx, "tenant1", key)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(wrapped), string(key))
+	again, err := wrapper.Wrap(ctx, "tenant1", key)
+	assert.NoError(t, err)
+	assert.NotEqual(t, wrapped, again, "every wrap uses a new nonce")
+
+	unwrapped, err := wrapper.Unwrap(ctx, "tenant1", wrapped)
+	assert.NoError(t, err)
+	assert.Equal(t, key, unwrapped)
+
+	_, err = wrapper.Unwrap(ctx, "tenant2", wrapped)
+	assert.Error(t, err, "a wrapped key cannot be moved to another tenant")
+	_, err = wrapper.Unwrap(ctx, "tenant1", wrapped[:4])
+	assert.Error(t, err)
+}
+
+func TestMasterKeyWrapper_NeedsA256BitKey(t *testing.T) {
+	_, err := sharedAdapters.NewMasterKeyWrapper(base64.StdEncoding.EncodeToString(make([]byte, 16)))
+	assert.Error(t, err)
+	_, err = sharedAdapters.NewMasterKeyWrapper("not base64")
+	assert.Error(t, err)
+}
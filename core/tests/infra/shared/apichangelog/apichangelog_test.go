@@ -0,0 +1,128 @@
+package apichangelog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/apichangelog"
+	_ "github.com/nahualventure/class-backend/proto/gen/common/v1"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+type Item struct {
+	ID    string   `json:"id"`
+	Tags  []string `json:"tags,omitempty"`
+	Count int      `json:"count"`
+}
+
+type itemsResponse struct {
+	Body []Item
+}
+
+func TestNewSurface_ReadsEndpointsSchemasAndMessages(t *testing.T) {
+	_, api := humatest.New(t)
+	huma.Register(api, huma.Operation{
+		OperationID: "list-items",
+		Method:      http.MethodGet,
+		Path:        "/items",
+	}, func(ctx context.Context, input *struct {
+		Limit int `query:"limit"`
+	}) (*itemsResponse, error) {
+		return nil, nil
+	})
+
+	surface := apichangelog.NewSurface("1.0.0", api.OpenAPI(), protoregistry.GlobalFiles)
+
+	assert.Equal(t, []apichangelog.Operation{{
+		ID:         "list-items",
+		Method:     http.MethodGet,
+		Path:       "/items",
+		Parameters: []apichangelog.Field{{Name: "query limit", Type: "int64"}},
+	}}, surface.Operations)
+	assert.Contains(t, surface.Schemas["Item"], apichangelog.Field{Name: "tags", Type: "[]string"})
+	assert.Contains(t, surface.Schemas["Item"], apichangelog.Field{Name: "count", Type: "int64", Required: true})
+	assert.Contains(t, surface.Messages["class.common.v1.ErrorDetail"],
+		apichangelog.Field{Name: "field_violations", Type: "5 repeated class.common.v1.FieldViolation"})
+	assert.Contains(t, surface.Messages["class.common.v1.ErrorDetail"],
+		apichangelog.Field{Name: "metadata", Type: "6 map<string, string>"})
+	assert.NotContains(t, surface.Messages, "google.protobuf.Timestamp", "only the packages of the API are listed")
+}
+
+func surface(version string, operations []apichangelog.Operation, fields []apichangelog.Field) *apichangelog.Surface {
+	return &apichangelog.Surface{
+		Version:    version,
+		Operations: operations,
+		Schemas:    map[string][]apichangelog.Field{"Item": fields},
+		Messages:   map[string][]apichangelog.Field{},
+	}
+}
+
+func TestDiff_FlagsBreakingChanges(t *testing.T) {
+	from := surface("1.0.0", []apichangelog.Operation{
+		{ID: "list-items", Method: "GET", Path: "/items"},
+		{ID: "old-items", Method: "GET", Path: "/old-items"},
+	}, []apichangelog.Field{{Name: "count", Type: "int64"}, {Name: "id", Type: "string"}, {Name: "legacy", Type: "string"}})
+	to := surface("1.1.0", []apichangelog.Operation{
+		{ID: "list-items", Method: "GET", Path: "/items", Deprecated: true,
+			Parameters: []apichangelog.Field{{Name: "query cursor", Type: "string"}}},
+		{ID: "create-item", Method: "POST", Path: "/items"},
+	}, []apichangelog.Field{{Name: "count", Type: "string"}, {Name: "id", Type: "string"}, {Name: "name", Type: "string"}})
+
+	assert.Equal(t, []apichangelog.Change{
+		{Kind: apichangelog.ChangeDeprecated, Target: "endpoint", Name: "GET /items", Detail: "list-items"},
+		{Kind: apichangelog.ChangeAdded, Target: "parameter", Name: "GET /items query cursor", Detail: "string"},
+		{Kind: apichangelog.ChangeAdded, Target: "endpoint", Name: "POST /items", Detail: "create-item"},
+		{Kind: apichangelog.ChangeRemoved, Target: "endpoint", Name: "GET /old-items", Detail: "old-items", Breaking: true},
+		{Kind: apichangelog.ChangeChanged, Target: "field", Name: "Item.count", Detail: "int64 to string", Breaking: true},
+		{Kind: apichangelog.ChangeAdded, Target: "field", Name: "Item.name", Detail: "string"},
+		{Kind: apichangelog.ChangeRemoved, Target: "field", Name: "Item.legacy", Detail: "string", Breaking: true},
+	}, apichangelog.Diff(from, to))
+}
+
+func TestBuildChangelog_ComparesEveryVersionWithThePreviousOne(t *testing.T) {
+	fields := []apichangelog.Field{{Name: "id", Type: "string"}}
+	v100 := surface("1.0.0", nil, fields)
+	v110 := surface("1.1.0", nil, append(fields, apichangelog.Field{Name: "name", Type: "string"}))
+	stale := surface("1.2.0", nil, fields)
+	current := surface("1.2.0", nil, nil)
+
+	releases := apichangelog.BuildChangelog([]*apichangelog.Surface{v100, v110, stale}, current)
+
+	assert.Len(t, releases, 3)
+	assert.Equal(t, "1.2.0", releases[0].Version)
+	assert.Equal(t, "1.1.0", releases[0].Previous)
+	assert.True(t, releases[0].Breaking, "the served surface replaces the snapshot of its version")
+	assert.Len(t, releases[0].Changes, 2)
+	assert.Equal(t, []apichangelog.Change{{Kind: apichangelog.ChangeAdded, Target: "field", Name: "Item.name", Detail: "string"}},
+		releases[1].Changes)
+	assert.False(t, releases[1].Breaking)
+	assert.Equal(t, apichangelog.Release{Version: "1.0.0", Changes: []apichangelog.Change{}}, releases[2])
+}
+
+func TestChangelog_ServesTheCurrentVersion(t *testing.T) {
+	_, api := humatest.New(t)
+	apichangelog.NewChangelog(api).RegisterRoutes(api)
+
+	resp := api.Get("/api/changelog")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var body struct {
+		Current  string                 `json:"current"`
+		Releases []apichangelog.Release `json:"releases"`
+	}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, apichangelog.Version, body.Current)
+	assert.Equal(t, apichangelog.Version, body.Releases[0].Version)
+
+	resp = api.Get("/api/changelog?since=" + apichangelog.Version)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Empty(t, body.Releases)
+
+	assert.Equal(t, http.StatusBadRequest, api.Get("/api/changelog?since=latest").Code)
+}
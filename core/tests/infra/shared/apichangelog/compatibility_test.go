@@ -0,0 +1,97 @@
+package apichangelog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/apichangelog"
+	"github.com/nahualventure/class-backend/infra/shared/clientversion"
+	"github.com/nahualventure/class-backend/infra/shared/container"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// surfaceSnapshot is the surface of the container modules at the last accepted change,
+// UPDATE_GOLDEN=1 rewrites it unless the change breaks clients of the recorded version. Modules
+// disabled until their optional configuration is set are covered by the snapshots of api-snapshot
+// only.
+const surfaceSnapshot = "testdata/surface.golden.json"
+
+func containerSurface(t *testing.T) *apichangelog.Surface {
+	previous := huma.NewError
+	huma.NewError = utils.NewHumaError
+	t.Cleanup(func() { huma.NewError = previous })
+
+	_, api := humatest.New(t)
+	container.New(container.Adapters{}, container.Config{}).RegisterRoutes(api)
+	apichangelog.NewChangelog(api).RegisterRoutes(api)
+	return apichangelog.NewSurface(apichangelog.Version, api.OpenAPI(), protoregistry.GlobalFiles)
+}
+
+// bumped reports whether apichangelog.Version is past the version of the snapshot, the marker of
+// a release allowed to break its clients
+func bumped(t *testing.T, recorded string) bool {
+	from, err := clientversion.Parse(recorded)
+	assert.NoError(t, err)
+	to, err := clientversion.Parse(apichangelog.Version)
+	assert.NoError(t, err)
+	return from.Less(to)
+}
+
+func TestSurface_CompatibleWithSnapshot(t *testing.T) {
+	current := containerSurface(t)
+
+	data, err := os.ReadFile(surfaceSnapshot)
+	if os.IsNotExist(err) && os.Getenv("UPDATE_GOLDEN") == "1" {
+		data, err = json.Marshal(current)
+	}
+	if !assert.NoError(t, err, "run with UPDATE_GOLDEN=1 to record the surface") {
+		return
+	}
+	recorded := &apichangelog.Surface{}
+	assert.NoError(t, json.Unmarshal(data, recorded))
+
+	changes := apichangelog.Diff(recorded, current)
+	var breaking []string
+	for _, change := range changes {
+		if change.Breaking {
+			breaking = append(breaking, change.Kind+" "+change.Target+" "+change.Name+" "+change.Detail)
+		}
+	}
+	if len(breaking) > 0 && !bumped(t, recorded.Version) {
+		t.Fatalf("the API breaks clients of version %s, keep the old fields and endpoints or bump apichangelog.Version:\n  %s",
+			recorded.Version, strings.Join(breaking, "\n  "))
+	}
+
+	if os.Getenv("UPDATE_GOLDEN") == "1" {
+		got, err := json.MarshalIndent(current, "", "  ")
+		assert.NoError(t, err)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(surfaceSnapshot), 0o755))
+		assert.NoError(t, os.WriteFile(surfaceSnapshot, append(got, '\n'), 0o644))
+		return
+	}
+	assert.Empty(t, changes, "the API changed compatibly, run with UPDATE_GOLDEN=1 to record it")
+	assert.Equal(t, recorded.Version, current.Version, "apichangelog.Version changed, run with UPDATE_GOLDEN=1 to record it")
+}
+
+func TestSurface_BreakingChangesNeedAVersionBump(t *testing.T) {
+	from := surface("1.0.0", []apichangelog.Operation{{ID: "list-items", Method: "GET", Path: "/items"}},
+		[]apichangelog.Field{{Name: "id", Type: "string"}})
+	to := surface(apichangelog.Version, nil, []apichangelog.Field{{Name: "id", Type: "int64"}})
+
+	changes := apichangelog.Diff(from, to)
+
+	assert.Len(t, changes, 2)
+	for _, change := range changes {
+		assert.True(t, change.Breaking, "%s %s", change.Kind, change.Name)
+	}
+	assert.False(t, bumped(t, apichangelog.Version), "the snapshot of the served version needs no bump marker")
+	assert.True(t, bumped(t, "0.9.0"))
+}
@@ -0,0 +1,76 @@
+package authorization
+
+import (
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/configs"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+var benchTenants = []string{"tenant1", "tenant2", "tenant3"}
+
+// newPoliciesService serves policies.yaml from memory, user1 is an instructor of every tenant
+func newPoliciesService(b *testing.B) *authorization.CasbinService {
+	b.Helper()
+	loader := authorization.NewPolicyLoader()
+	if err := loader.LoadFromFS(configs.Assets(""), configs.PoliciesFile); err != nil {
+		b.Fatal(err)
+	}
+	model, err := fs.ReadFile(configs.Assets(""), configs.RBACModelFile)
+	if err != nil {
+		b.Fatal(err)
+	}
+	service, authzErr := authorization.NewInMemoryCasbinService(string(model), loader, benchTenants)
+	if authzErr != nil {
+		b.Fatal(authzErr)
+	}
+	for _, tenantID := range benchTenants {
+		if err := service.AssignRole("user1", "instructor", tenantID); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return service
+}
+
+// BenchmarkCanDo checks a permission the role grants. Cold is the first check of a service that
+// just loaded the policies, warm a service that answered before.
+func BenchmarkCanDo(b *testing.B) {
+	// Role assignments log a line each, it would split the result lines benchstat reads
+	log.SetOutput(io.Discard)
+	b.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	b.Run("cold", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			service := newPoliciesService(b)
+			b.StartTimer()
+			if allowed, err := service.CanDo("user1", "grade", "assign", "tenant2"); err != nil || !allowed {
+				b.Fatalf("allowed %v, error %v", allowed, err)
+			}
+		}
+	})
+
+	b.Run("warm", func(b *testing.B) {
+		service := newPoliciesService(b)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if allowed, err := service.CanDo("user1", "grade", "assign", "tenant2"); err != nil || !allowed {
+				b.Fatalf("allowed %v, error %v", allowed, err)
+			}
+		}
+	})
+
+	b.Run("denied", func(b *testing.B) {
+		service := newPoliciesService(b)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if allowed, err := service.CanDo("user1", "grade", "approve", "tenant2"); err != nil || allowed {
+				b.Fatalf("allowed %v, error %v", allowed, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package authorization
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/tests/authztest"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFailureMode(t *testing.T) {
+	mode, err := authorization.ParseFailureMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, authorization.FailClosed, mode)
+
+	mode, err = authorization.ParseFailureMode("read-only")
+	assert.NoError(t, err)
+	assert.Equal(t, authorization.FailOpenReadOnly, mode)
+
+	_, err = authorization.ParseFailureMode("open")
+	assert.Error(t, err)
+}
+
+func TestPolicyStoreHealth_AdmitsEverythingWhileHealthy(t *testing.T) {
+	health := authorization.NewPolicyStoreHealth(authorization.FailClosed)
+
+	assert.NoError(t, health.Admit("tenant1", true))
+	assert.NoError(t, health.Admit("tenant1", false))
+}
+
+func TestPolicyStoreHealth_FailClosedRejectsEverythingWhileDegraded(t *testing.T) {
+	health := authorization.NewPolicyStoreHealth(authorization.FailClosed)
+	health.MarkFailed(errors.New("connection refused"))
+
+	assert.True(t, health.Degraded())
+	err := health.Admit("tenant1", true)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, utils.ApplicationErrorToHTTPResponse(err).Status)
+	assert.Error(t, health.Admit("tenant1", false))
+}
+
+func TestPolicyStoreHealth_ReadOnlyKeepsServingReads(t *testing.T) {
+	health := authorization.NewPolicyStoreHealth(authorization.FailOpenReadOnly)
+	health.MarkFailed(errors.New("connection refused"))
+
+	assert.NoError(t, health.Admit("tenant1", true))
+	err := health.Admit("tenant1", false)
+	var domainErr *appErrors.BaseDomainError
+	assert.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, appErrors.ServiceUnavailable.String(), domainErr.Code)
+}
+
+func TestPolicyStoreHealth_RecoversOnSuccessfulLoad(t *testing.T) {
+	health := authorization.NewPolicyStoreHealth(authorization.FailClosed)
+	health.MarkFailed(errors.New("connection refused"))
+	health.MarkFailed(errors.New("connection refused"))
+	health.MarkRecovered()
+
+	assert.False(t, health.Degraded())
+	assert.NoError(t, health.Admit("tenant1", false))
+
+	var metrics bytes.Buffer
+	assert.NoError(t, health.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), "authz_policy_store_degraded 0\n")
+	assert.Contains(t, metrics.String(), "authz_policy_store_failures_total 2\n")
+}
+
+func TestIsReadOnlyMethod(t *testing.T) {
+	assert.True(t, authorization.IsReadOnlyMethod(http.MethodGet))
+	assert.True(t, authorization.IsReadOnlyMethod(http.MethodHead))
+	assert.False(t, authorization.IsReadOnlyMethod(http.MethodPost))
+	assert.False(t, authorization.IsReadOnlyMethod(http.MethodDelete))
+}
+
+func TestPolicyStoreHealth_CheckFailsReadinessOnlyWhenRejectingEverything(t *testing.T) {
+	closed := authorization.NewPolicyStoreHealth(authorization.FailClosed)
+	assert.NoError(t, closed.Check(context.Background()))
+	closed.MarkFailed(errors.New("connection refused"))
+	assert.ErrorContains(t, closed.Check(context.Background()), "connection refused")
+
+	readOnly := authorization.NewPolicyStoreHealth(authorization.FailOpenReadOnly)
+	readOnly.MarkFailed(errors.New("connection refused"))
+	assert.NoError(t, readOnly.Check(context.Background()))
+}
+
+func TestPolicyStoreHealth_DegradedTenantsAreRefusedAlone(t *testing.T) {
+	health := authorization.NewPolicyStoreHealth(authorization.FailOpenReadOnly)
+	health.MarkTenants(map[string]error{"tenant2": errors.New("invalid role assignment")}, false)
+
+	assert.False(t, health.Degraded())
+	assert.NoError(t, health.Admit("tenant1", false))
+	err := health.Admit("tenant2", true)
+	assert.Equal(t, http.StatusServiceUnavailable, utils.ApplicationErrorToHTTPResponse(err).Status)
+	var domainErr *appErrors.BaseDomainError
+	assert.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, appErrors.AuthzConfigError.String(), domainErr.Code)
+	assert.Equal(t, "tenant2", domainErr.Context["tenant_id"])
+	assert.ErrorContains(t, health.CheckTenants(context.Background()), "1 tenants")
+	assert.NoError(t, health.Check(context.Background()), "the other tenants are served")
+
+	var metrics bytes.Buffer
+	assert.NoError(t, health.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), "authz_degraded_tenants 1\n")
+	assert.Contains(t, metrics.String(), "authz_tenant_degraded{tenant=\"tenant2\"} 1\n")
+
+	health.MarkTenants(nil, true)
+	assert.NoError(t, health.Admit("tenant2", false))
+	assert.Empty(t, health.Status().DegradedTenants)
+}
+
+func TestPolicyStoreHealth_ReadOnlyServesTheLastKnownAssignmentsOfATenant(t *testing.T) {
+	health := authorization.NewPolicyStoreHealth(authorization.FailOpenReadOnly)
+	health.MarkTenants(map[string]error{"tenant2": errors.New("invalid role assignment")}, true)
+
+	assert.NoError(t, health.Admit("tenant2", true))
+	assert.Error(t, health.Admit("tenant2", false))
+
+	closed := authorization.NewPolicyStoreHealth(authorization.FailClosed)
+	closed.MarkTenants(map[string]error{"tenant2": errors.New("invalid role assignment")}, true)
+	assert.Error(t, closed.Admit("tenant2", true))
+}
+
+func TestCasbinService_InvalidRoleAssignmentDegradesItsTenantOnly(t *testing.T) {
+	authz := authztest.New(t)
+	authz.Grant("teacher", "grade", "view")
+	authz.Assign("user1", "teacher").In("tenant1", "tenant2")
+	service := authz.Service()
+
+	// A role assignment without a role, as a bad row of casbin_rule would load
+	_, err := service.RestoreSubjectRoles(context.Background(), []string{"user2"}, []string{""}, "tenant2")
+	assert.Nil(t, err)
+
+	assert.False(t, service.Health().Degraded())
+	assert.NoError(t, service.Admit("tenant1", false))
+	var domainErr *appErrors.BaseDomainError
+	assert.True(t, errors.As(service.Admit("tenant2", false), &domainErr))
+	assert.Equal(t, appErrors.AuthzConfigError.String(), domainErr.Code)
+	degraded := service.Health().Status().DegradedTenants
+	assert.Len(t, degraded, 1)
+	assert.Equal(t, "tenant2", degraded[0].TenantID)
+	assert.True(t, degraded[0].LastKnown)
+	assert.True(t, authz.Can("user1", "grade", "view", "tenant2"), "the tenant keeps the role assignments it had")
+
+	assert.Nil(t, service.AssignRole("user3", "teacher", "tenant1"))
+	assert.Nil(t, service.RefreshPolicies(), "the other tenants still load")
+	assert.True(t, authz.Can("user3", "grade", "view", "tenant1"))
+
+	_, err = service.RevokeSubjectRoles(context.Background(), []string{"user2"}, []string{""}, "tenant2")
+	assert.Nil(t, err)
+	assert.NoError(t, service.Admit("tenant2", false))
+	assert.Empty(t, service.Health().DegradedTenants())
+}
@@ -0,0 +1,85 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var permissions = map[string]authorization.ResourceAction{
+	"list-users":   {Resource: "user", Action: "view"},
+	"batch-signup": {Resource: "user", Action: "create"},
+}
+
+func TestEndpointAccess_Modes(t *testing.T) {
+	access, err := authorization.NewEndpointAccess(authorization.EndpointAccessConfig{
+		Public:        []string{"health", "signup"},
+		Authenticated: []string{"get-time-zone"},
+	}, permissions)
+	assert.Nil(t, err)
+
+	assert.Equal(t, authorization.AccessPublic, access.Mode("signup"))
+	assert.True(t, access.IsPublic("health"))
+	assert.Equal(t, authorization.AccessAuthenticated, access.Mode("get-time-zone"))
+	assert.False(t, access.IsPublic("get-time-zone"))
+	assert.Equal(t, authorization.AccessPermission, access.Mode("batch-signup"))
+	assert.Equal(t, authorization.AccessDenied, access.Mode("unknown"))
+
+	permission, ok := access.Permission("batch-signup")
+	assert.True(t, ok)
+	assert.Equal(t, "create", permission.Action)
+	_, ok = access.Permission("get-time-zone")
+	assert.False(t, ok, "authenticated operations check no permission")
+}
+
+func TestEndpointAccess_RefusesOperationsInTwoModes(t *testing.T) {
+	_, err := authorization.NewEndpointAccess(authorization.EndpointAccessConfig{
+		Public: []string{"batch-signup"},
+	}, permissions)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "batch-signup is public but requires user:create")
+
+	_, err = authorization.NewEndpointAccess(authorization.EndpointAccessConfig{
+		Authenticated: []string{"list-users"},
+	}, permissions)
+	assert.NotNil(t, err, "an authenticated operation would skip its permission too")
+
+	_, err = authorization.NewEndpointAccess(authorization.EndpointAccessConfig{
+		Public:        []string{"health"},
+		Authenticated: []string{"health"},
+	}, permissions)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "health is both public and authenticated")
+}
+
+func TestEndpointAccess_RetainRefusesOperationsNotServed(t *testing.T) {
+	access, err := authorization.NewEndpointAccess(authorization.EndpointAccessConfig{
+		Public: []string{"health", "signup"},
+	}, map[string]authorization.ResourceAction{
+		"list-users":   {Resource: "user", Action: "view"},
+		"batch-signup": {Resource: "user", Action: "create"},
+	})
+	assert.Nil(t, err)
+
+	served := map[string]bool{"health": true, "list-users": true}
+	access.Retain(func(operationID string) bool { return served[operationID] })
+
+	assert.True(t, access.IsPublic("health"))
+	assert.Equal(t, authorization.AccessPermission, access.Mode("list-users"))
+	assert.Equal(t, authorization.AccessDenied, access.Mode("signup"))
+	_, ok := access.Permission("batch-signup")
+	assert.False(t, ok)
+}
+
+func TestPolicyLoader_EndpointAccess(t *testing.T) {
+	loader := authorization.NewPolicyLoader()
+	assert.Nil(t, loader.LoadFromBytes([]byte("roles:\n  admin:\n    permissions:\n      all: [all]\nendpoints:\n  public: [health]\n  authenticated: [get-time-zone]\n")))
+
+	access, err := loader.EndpointAccess()
+	assert.Nil(t, err)
+	assert.True(t, access.IsPublic("health"))
+	assert.Equal(t, authorization.AccessAuthenticated, access.Mode("get-time-zone"))
+	assert.Equal(t, authorization.AccessPermission, access.Mode("list-users"))
+}
@@ -0,0 +1,75 @@
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/tests/authztest"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryCasbinService_GrantsPerTenant(t *testing.T) {
+	authz := authztest.New(t)
+	authz.Grant("teacher", "grade", "update").For("tenant1")
+	authz.Grant("teacher", "grade", "view")
+	authz.Grant("admin", "all", "all")
+	authz.Assign("user1", "teacher").In("tenant1", "tenant2")
+	authz.Assign("user2", "admin").In("tenant2")
+
+	assert.True(t, authz.Can("user1", "grade", "update", "tenant1"))
+	assert.False(t, authz.Can("user1", "grade", "update", "tenant2"), "the grant is limited to tenant1")
+	assert.True(t, authz.Can("user1", "grade", "view", "tenant2"), "grants without For apply in every tenant")
+	assert.True(t, authz.Can("user2", "report", "delete", "tenant2"), "all stands for every resource and action")
+	assert.False(t, authz.Can("user2", "report", "delete", "tenant1"), "roles only apply in their tenant")
+
+	err := authorization.Authorize(authz.Service(), "user1", "tenant2", authorization.ResourceAction{Resource: "grade", Action: "update"})
+	assert.Error(t, err)
+}
+
+func TestInMemoryCasbinService_ChangesRoles(t *testing.T) {
+	authz := authztest.New(t)
+	authz.Grant("teacher", "grade", "update").For("tenant1")
+	authz.Grant("student", "grade", "view")
+	authz.Tenants("tenant1")
+	service := authz.Service()
+
+	changed := map[string]bool{}
+	service.OnRoleChange(func(userID string) { changed[userID] = true })
+
+	assigned, err := service.AssignRoleToSubjects(context.Background(), []string{"user1", "user2", "user1"}, "teacher", "tenant1", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, assigned)
+	assert.True(t, authz.Can("user2", "grade", "update", "tenant1"), "tenant grants survive the reload of the role assignments")
+	assert.True(t, changed["user2"])
+
+	revoked, err := service.RevokeSubjectRoles(context.Background(), []string{"user1"}, []string{"teacher"}, "tenant1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, revoked)
+	assert.False(t, authz.Can("user1", "grade", "update", "tenant1"))
+
+	assert.Nil(t, service.AssignRole("user3", "student", "tenant1"))
+	roles, err := service.GetUserRoles("user3", "tenant1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"student"}, roles)
+	assert.NotNil(t, service.AssignRole("user3", "principal", "tenant1"), "roles exist once granted something")
+
+	assert.Nil(t, service.RemoveTenantRoles(context.Background(), "tenant1"))
+	assert.False(t, authz.Can("user2", "grade", "update", "tenant1"))
+	assert.False(t, authz.Can("user3", "grade", "view", "tenant1"))
+}
+
+func TestInMemoryCasbinService_ActivatesTenantsPerSource(t *testing.T) {
+	authz := authztest.New(t)
+	authz.Grant("teacher", "grade", "update").For("tenant1")
+	authz.Tenants("tenant1")
+	service := authz.Service()
+
+	assert.Nil(t, service.ActivateTenants("sandboxes", []string{"sandbox1"}))
+	assert.Nil(t, service.ActivateTenants("tenants", []string{"north"}))
+	assert.Equal(t, []string{"tenant1", "sandbox1", "north"}, service.Tenants(), "sources are merged in name order")
+
+	assert.Nil(t, service.ActivateTenants("sandboxes", nil))
+	assert.Equal(t, []string{"tenant1", "north"}, service.Tenants(), "each source only replaces its own tenants")
+}
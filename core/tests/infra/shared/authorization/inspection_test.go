@@ -0,0 +1,106 @@
+package authorization
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyDump_CountsAndFiltersPerTenant(t *testing.T) {
+	dump := &authorization.PolicyDump{
+		Policies: [][]string{
+			{"admin", "*", "*", "tenant1"},
+			{"admin", "*", "*", "tenant2"},
+			{"student", "grade", "view", "tenant1"},
+		},
+		Groupings: [][]string{
+			{"user-1", "admin", "tenant1"},
+			{"user-2", "student", "tenant1"},
+			{"sa:42", "admin", "tenant2"},
+		},
+	}
+
+	assert.Equal(t, map[string]int{"tenant1": 2, "tenant2": 1}, dump.PoliciesPerTenant())
+	assert.Equal(t, map[string]int{"tenant1": 2, "tenant2": 1}, dump.GroupingsPerTenant())
+
+	tenant2 := dump.ForTenant("tenant2")
+	assert.Equal(t, [][]string{{"admin", "*", "*", "tenant2"}}, tenant2.Policies)
+	assert.Equal(t, [][]string{{"sa:42", "admin", "tenant2"}}, tenant2.Groupings)
+	assert.Empty(t, dump.ForTenant("tenant3").Groupings)
+	assert.NotNil(t, dump.ForTenant("tenant3").Groupings, "an empty tenant is listed as [] rather than null")
+}
+
+func TestPolicyStoreHealth_Status(t *testing.T) {
+	health := authorization.NewPolicyStoreHealth(authorization.FailOpenReadOnly)
+	assert.Equal(t, authorization.PolicyStoreStatus{FailureMode: authorization.FailOpenReadOnly}, health.Status())
+
+	health.MarkFailed(errors.New("connection refused"))
+	status := health.Status()
+	assert.True(t, status.Degraded)
+	assert.Equal(t, "connection refused", status.LastError)
+	assert.Equal(t, int64(1), status.Failures)
+
+	health.MarkRecovered()
+	assert.Equal(t, authorization.PolicyStoreStatus{FailureMode: authorization.FailOpenReadOnly, Failures: 1}, health.Status())
+}
+
+func TestDiffPolicies(t *testing.T) {
+	expected := [][]string{{"admin", "*", "*", "tenant1"}, {"student", "grade", "view", "tenant1"}}
+	loaded := [][]string{{"admin", "*", "*", "tenant1"}, {"student", "grade", "assign", "tenant1"}}
+
+	missing, extra := authorization.DiffPolicies(expected, loaded)
+	assert.Equal(t, [][]string{{"student", "grade", "view", "tenant1"}}, missing)
+	assert.Equal(t, [][]string{{"student", "grade", "assign", "tenant1"}}, extra)
+
+	missing, extra = authorization.DiffPolicies(expected, expected)
+	assert.Empty(t, missing)
+	assert.Empty(t, extra)
+}
+
+func TestUnknownRoles(t *testing.T) {
+	groupings := [][]string{{"user-1", "admin", "tenant1"}, {"user-2", "registrar", "tenant1"}, {"user-3", "registrar", "tenant2"}, {"user-4", "auditor", "tenant1"}}
+
+	assert.Equal(t, []string{"auditor", "registrar"}, authorization.UnknownRoles(groupings, []string{"admin", "student"}))
+	assert.Empty(t, authorization.UnknownRoles(groupings, []string{"admin", "auditor", "registrar"}))
+}
+
+func TestPolicyDriftMonitor_WritesMetricsOfTheLastCheck(t *testing.T) {
+	monitor := authorization.NewPolicyDriftMonitor()
+	var metrics bytes.Buffer
+	assert.NoError(t, monitor.WriteMetrics(&metrics))
+	assert.Empty(t, metrics.String(), "nothing is reported before the first check")
+
+	drift := &authorization.PolicyDrift{CheckedAt: time.Unix(1700000000, 0), LoadedHash: "abc", FileHash: "abc"}
+	assert.False(t, drift.Drifted())
+	assert.Empty(t, drift.String())
+
+	drift = &authorization.PolicyDrift{CheckedAt: time.Unix(1700000000, 0), LoadedHash: "abc", FileHash: "def",
+		MissingPolicies: [][]string{{"student", "grade", "view", "tenant1"}}, StoreOverrides: 2}
+	monitor.Record(drift)
+	assert.True(t, drift.FileChanged())
+	assert.Contains(t, drift.String(), "policy file changed")
+
+	assert.NoError(t, monitor.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), "authz_policy_drift 1\n")
+	assert.Contains(t, metrics.String(), "authz_policy_file_changed 1\n")
+	assert.Contains(t, metrics.String(), `authz_policy_drift_rules{kind="missing"} 1`+"\n")
+	assert.Contains(t, metrics.String(), `authz_policy_drift_rules{kind="store_override"} 2`+"\n")
+	assert.Contains(t, metrics.String(), "authz_policy_drift_checked_timestamp_seconds 1700000000\n")
+}
+
+func TestPolicyLoader_HashesThePolicyFile(t *testing.T) {
+	loader := authorization.NewPolicyLoader()
+	assert.Nil(t, loader.LoadFromBytes([]byte("roles:\n  student:\n    permissions:\n      grade: [view]\n")))
+	assert.Len(t, loader.Hash(), 64)
+	assert.Equal(t, [][]string{{"student", "grade", "view", "tenant1"}}, loader.Policies([]string{"tenant1"}))
+
+	other := authorization.NewPolicyLoader()
+	assert.Nil(t, other.LoadFromBytes([]byte("roles:\n  student:\n    permissions:\n      grade: [all]\n")))
+	assert.NotEqual(t, loader.Hash(), other.Hash())
+	assert.Equal(t, [][]string{{"student", "grade", "*", "tenant1"}}, other.Policies([]string{"tenant1"}))
+}
@@ -0,0 +1,132 @@
+package authorization
+
+import (
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/configs"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"pgregory.net/rapid"
+)
+
+// names draws identifiers as policies.yaml spells them, "all" included
+var names = rapid.SampledFrom([]string{"all", "view", "edit", "grade", "user", "course", "view_all", "report"})
+
+// policyConfig draws roles with resources and actions, actions may repeat within a resource
+func policyConfig(t *rapid.T) authorization.PolicyConfig {
+	roles := rapid.MapOfN(rapid.SampledFrom([]string{"admin", "instructor", "student", "guardian"}),
+		rapid.MapOfN(names, rapid.SliceOfN(names, 1, 4), 1, 4), 1, 4).Draw(t, "roles")
+	config := authorization.PolicyConfig{Roles: map[string]authorization.RoleConfig{}}
+	for role, permissions := range roles {
+		config.Roles[role] = authorization.RoleConfig{Permissions: permissions}
+	}
+	return config
+}
+
+func wildcard(value string) string {
+	if value == "all" {
+		return "*"
+	}
+	return value
+}
+
+// requested is a value a request may carry that value grants, anything at all for "all"
+func requested(value string) string {
+	if value == "all" {
+		return "anything"
+	}
+	return value
+}
+
+func newEnforcer(t *rapid.T) *casbin.Enforcer {
+	text, err := fs.ReadFile(configs.Assets(""), configs.RBACModelFile)
+	if err != nil {
+		t.Fatalf("failed to read the Casbin model: %v", err)
+	}
+	rbacModel, err := model.NewModelFromString(string(text))
+	if err != nil {
+		t.Fatalf("failed to parse the Casbin model: %v", err)
+	}
+	enforcer, err := casbin.NewEnforcer(rbacModel)
+	if err != nil {
+		t.Fatalf("failed to build the enforcer: %v", err)
+	}
+	return enforcer
+}
+
+func TestPolicyLoader_PropertyEveryPermissionRoundTrips(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		config := policyConfig(t)
+		tenants := rapid.SliceOfNDistinct(rapid.SampledFrom([]string{"tenant1", "tenant2", "tenant3"}), 1, 3, rapid.ID[string]).Draw(t, "tenants")
+		data, err := yaml.Marshal(config)
+		assert.NoError(t, err)
+
+		loader := authorization.NewPolicyLoader()
+		assert.Nil(t, loader.LoadFromBytes(data))
+		enforcer := newEnforcer(t)
+		assert.Nil(t, loader.LoadPoliciesIntoEnforcer(enforcer, tenants))
+
+		for role, roleConfig := range config.Roles {
+			for _, tenantID := range tenants {
+				subject := role + "-user"
+				_, err := enforcer.AddRoleForUserInDomain(subject, role, tenantID)
+				assert.NoError(t, err)
+				for resource, actions := range roleConfig.Permissions {
+					for _, action := range actions {
+						has, err := enforcer.HasPolicy(role, wildcard(resource), wildcard(action), tenantID)
+						assert.NoError(t, err)
+						assert.True(t, has, "%s %s:%s in %s", role, resource, action, tenantID)
+
+						// "all" grants any resource or action
+						allowed, err := enforcer.Enforce(subject, requested(resource), requested(action), tenantID)
+						assert.NoError(t, err)
+						assert.True(t, allowed, "%s may %s %s in %s", subject, action, resource, tenantID)
+					}
+				}
+			}
+		}
+
+		loaded, err := enforcer.GetPolicy()
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, loader.Policies(tenants), loaded, "the enforcer holds the policies of the loader, no more")
+	})
+}
+
+func TestPolicyLoader_PropertyPoliciesAreUniqueAndWellFormed(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		config := policyConfig(t)
+		// Tenants may repeat, as they do when listed from several sources
+		tenants := rapid.SliceOfN(rapid.SampledFrom([]string{"tenant1", "tenant2"}), 1, 4).Draw(t, "tenants")
+		data, err := yaml.Marshal(config)
+		assert.NoError(t, err)
+
+		loader := authorization.NewPolicyLoader()
+		assert.Nil(t, loader.LoadFromBytes(data))
+		for _, grant := range rapid.SliceOfN(names, 0, 3).Draw(t, "grants") {
+			loader.Grant("guardian", grant, "view", tenants[0])
+		}
+
+		policies := loader.Policies(tenants)
+		seen := map[string]bool{}
+		for _, policy := range policies {
+			if !assert.Len(t, policy, 4) {
+				continue
+			}
+			for _, value := range policy {
+				assert.NotEmpty(t, value, "%v", policy)
+				assert.NotEqual(t, "all", value, "%v keeps the human readable wildcard", policy)
+			}
+			assert.True(t, slices.Contains(tenants, policy[3]), "%v is in a tenant that was not asked for", policy)
+			key := strings.Join(policy, ",")
+			assert.False(t, seen[key], "%v is generated twice", policy)
+			seen[key] = true
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/tests/authztest"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviewPolicies_ComparesWhatGrantsAllow(t *testing.T) {
+	loaded := [][]string{
+		{"teacher", "grade", "view", "tenant1"},
+		{"teacher", "grade", "update", "tenant1"},
+		{"teacher", "grade", "view", "tenant2"},
+		{"teacher", "grade", "update", "tenant2"},
+		{"student", "grade", "view", "tenant1"},
+	}
+	candidate := [][]string{
+		{"teacher", "grade", "*", "tenant1"},
+		{"teacher", "grade", "view", "tenant2"},
+		{"student", "grade", "view", "tenant1"},
+		{"student", "report", "view", "tenant1"},
+	}
+
+	preview := authorization.PreviewPolicies(loaded, candidate)
+	assert.Equal(t, []authorization.GrantChange{
+		{Role: "student", Tenants: []string{"tenant1"}, Added: []authorization.Grant{{Resource: "report", Action: "view"}}},
+		{Role: "teacher", Tenants: []string{"tenant1"}, Added: []authorization.Grant{{Resource: "grade", Action: "*"}}},
+		{Role: "teacher", Tenants: []string{"tenant2"}, Removed: []authorization.Grant{{Resource: "grade", Action: "update"}}},
+	}, preview.Changes)
+	assert.Equal(t, []string{"teacher"}, preview.LosingAccess, "grades stay updatable in tenant1 through the wildcard")
+	assert.True(t, preview.NeedsConfirmation())
+
+	unchanged := authorization.PreviewPolicies(loaded, loaded)
+	assert.Empty(t, unchanged.Changes)
+	assert.False(t, unchanged.NeedsConfirmation())
+	assert.True(t, unchanged.Confirmed(""))
+}
+
+func TestCasbinService_ApplyPolicies_ConfirmsRevocations(t *testing.T) {
+	authz := authztest.New(t)
+	authz.Grant("teacher", "grade", "view")
+	authz.Grant("teacher", "grade", "update")
+	authz.Grant("student", "grade", "view")
+	authz.Assign("user1", "teacher").In("tenant1", "tenant2")
+	service := authz.Service()
+
+	candidate := []byte("roles:\n  teacher:\n    permissions:\n      grade: [view]\n" +
+		"  student:\n    permissions:\n      grade: [view]\n      report: [view]\n")
+	preview, err := service.PreviewPolicies(candidate)
+	assert.Nil(t, err)
+	assert.Equal(t, []authorization.GrantChange{
+		{Role: "student", Tenants: []string{"tenant1", "tenant2"}, Added: []authorization.Grant{{Resource: "report", Action: "view"}}},
+		{Role: "teacher", Tenants: []string{"tenant1", "tenant2"}, Removed: []authorization.Grant{{Resource: "grade", Action: "update"}}},
+	}, preview.Changes)
+	assert.Equal(t, []string{"teacher"}, preview.LosingAccess)
+	assert.Len(t, preview.CandidateHash, 64)
+
+	_, applied, err := service.ApplyPolicies(candidate, "")
+	assert.Nil(t, err)
+	assert.False(t, applied, "a role losing access needs a confirmation")
+	assert.True(t, authz.Can("user1", "grade", "update", "tenant1"))
+
+	_, applied, err = service.ApplyPolicies(candidate, "0123abc")
+	assert.Nil(t, err)
+	assert.False(t, applied, "the confirmation is the hash of the file previewed")
+
+	_, applied, err = service.ApplyPolicies(candidate, preview.CandidateHash)
+	assert.Nil(t, err)
+	assert.True(t, applied)
+	assert.False(t, authz.Can("user1", "grade", "update", "tenant1"))
+	assert.True(t, authz.Can("user1", "grade", "view", "tenant2"), "role assignments are kept")
+
+	after, err := service.PreviewPolicies(candidate)
+	assert.Nil(t, err)
+	assert.Empty(t, after.Changes)
+	assert.Equal(t, preview.CandidateHash, after.LoadedHash)
+}
+
+func TestCasbinService_ApplyPolicies_RefusesFilesNeedingARestart(t *testing.T) {
+	authz := authztest.New(t)
+	authz.Grant("student", "grade", "view")
+	authz.Tenants("tenant1")
+	service := authz.Service()
+
+	candidate := []byte("roles:\n  student:\n    permissions:\n      grade: [view, export]\n" +
+		"endpoints:\n  public: [health-check]\n")
+	preview, applied, err := service.ApplyPolicies(candidate, "")
+	assert.Nil(t, err)
+	assert.False(t, applied)
+	assert.True(t, preview.RestartRequired)
+	assert.False(t, preview.NeedsConfirmation(), "only grants are added")
+
+	_, _, err = service.ApplyPolicies([]byte("roles: {}\n"), "")
+	assert.NotNil(t, err, "files without roles are invalid")
+}
@@ -0,0 +1,77 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type contact struct {
+	Email string `json:"email,omitempty" visibility:"student:view_contact"`
+	Phone string `json:"phone,omitempty" visibility:"student:view_phone"`
+}
+
+type student struct {
+	ID      string   `json:"id"`
+	Contact *contact `json:"contact,omitempty"`
+}
+
+type roster struct {
+	Students []student          `json:"students"`
+	ByID     map[string]student `json:"by_id"`
+	Extra    any                `json:"extra,omitempty"`
+}
+
+type untagged struct {
+	ID string `json:"id"`
+}
+
+func allowOnly(permissions ...string) func(string) bool {
+	return func(permission string) bool {
+		for _, allowed := range permissions {
+			if allowed == permission {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func TestRedactor_RemovesFieldsWithoutPermission(t *testing.T) {
+	redactor := authorization.NewRedactor(nil)
+	ada := student{ID: "1", Contact: &contact{Email: "ada@example.com", Phone: "555"}}
+	body := roster{
+		Students: []student{ada},
+		ByID:     map[string]student{"1": ada},
+		Extra:    &student{ID: "2", Contact: &contact{Email: "alan@example.com"}},
+	}
+
+	redacted := redactor.Redact(body, allowOnly("student:view_contact")).(roster)
+
+	assert.Equal(t, contact{Email: "ada@example.com"}, *redacted.Students[0].Contact)
+	assert.Equal(t, contact{Email: "ada@example.com"}, *redacted.ByID["1"].Contact)
+	assert.Equal(t, "alan@example.com", redacted.Extra.(*student).Contact.Email)
+	assert.Equal(t, "555", body.Students[0].Contact.Phone, "the response of the handler is not changed")
+	assert.Equal(t, "555", ada.Contact.Phone)
+
+	redactedPtr := redactor.Redact(&body, allowOnly()).(*roster)
+	assert.Equal(t, contact{}, *redactedPtr.Students[0].Contact)
+	assert.Equal(t, contact{}, *redactedPtr.Extra.(*student).Contact)
+	assert.Equal(t, "ada@example.com", body.Students[0].Contact.Email)
+}
+
+func TestRedactor_ReturnsResponsesWithoutHiddenFieldsAsTheyAre(t *testing.T) {
+	redactor := authorization.NewRedactor(nil)
+	checked := 0
+	allowed := func(string) bool { checked++; return false }
+
+	body := &untagged{ID: "1"}
+	assert.Same(t, body, redactor.Redact(body, allowed))
+
+	visible := &student{ID: "1", Contact: &contact{}}
+	assert.Same(t, visible, redactor.Redact(visible, allowed), "empty fields need no check")
+	assert.Equal(t, 0, checked)
+	assert.Nil(t, redactor.Redact(nil, allowed))
+}
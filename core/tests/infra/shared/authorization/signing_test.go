@@ -0,0 +1,226 @@
+package authorization
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	"github.com/nahualventure/class-backend/proto/client"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+)
+
+const signingSecret = "0123456789abcdef0123456789abcdef"
+
+func newSigning(t *testing.T) *authorization.RequestSigning {
+	access, err := authorization.NewEndpointAccess(authorization.EndpointAccessConfig{Public: []string{"health"}}, permissions)
+	assert.Nil(t, err)
+	signing, err := authorization.NewRequestSigning(authorization.SigningConfig{
+		MaxAge:     5 * time.Minute,
+		Operations: []string{"batch-signup"},
+	}, access)
+	assert.Nil(t, err)
+	return signing
+}
+
+func TestNewRequestSigning_RefusesInvalidOperations(t *testing.T) {
+	access, err := authorization.NewEndpointAccess(authorization.EndpointAccessConfig{Public: []string{"health"}}, permissions)
+	assert.Nil(t, err)
+
+	_, err = authorization.NewRequestSigning(authorization.SigningConfig{MaxAge: time.Minute, Operations: []string{"health"}}, access)
+	assert.NotNil(t, err, "public operations have no token to protect")
+	_, err = authorization.NewRequestSigning(authorization.SigningConfig{MaxAge: time.Minute, Operations: []string{"batch-signpu"}}, access)
+	assert.NotNil(t, err)
+	_, err = authorization.NewRequestSigning(authorization.SigningConfig{Operations: []string{"batch-signup"}}, access)
+	assert.NotNil(t, err, "max_age is required")
+}
+
+func TestNewSigningKeys(t *testing.T) {
+	_, err := authorization.NewSigningKeys([]string{"k1:short"}, nil)
+	assert.Error(t, err)
+	_, err = authorization.NewSigningKeys([]string{signingSecret}, nil)
+	assert.Error(t, err)
+
+	keys, err := authorization.NewSigningKeys(nil, nil)
+	assert.NoError(t, err)
+	assert.False(t, keys.Enabled())
+}
+
+func sign(signedAt time.Time, body string) authorization.SignedRequest {
+	base := client.SignatureBase(http.MethodPost, "/users/merge", "tenant1", signedAt.Unix(), []byte(body))
+	return authorization.SignedRequest{
+		Method: http.MethodPost, RequestURI: "/users/merge", TenantID: "tenant1", KeyID: "k1",
+		Timestamp: strconv.FormatInt(signedAt.Unix(), 10), Signature: client.Signature([]byte(signingSecret), base), Body: []byte(body),
+	}
+}
+
+func TestSigningKeys_Verify(t *testing.T) {
+	keys, err := authorization.NewSigningKeys([]string{"k1:" + signingSecret}, nil)
+	assert.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	ctx := context.Background()
+
+	request := sign(now, `{"from":"a"}`)
+	assert.NoError(t, keys.Verify(ctx, request, time.Minute, now))
+
+	tampered := sign(now, `{"from":"a"}`)
+	tampered.Body = []byte(`{"from":"b"}`)
+	unknownKey := sign(now, "")
+	unknownKey.KeyID = "k2"
+	for reason, request := range map[string]authorization.SignedRequest{
+		"replayed":    request,
+		"invalid":     tampered,
+		"unknown key": unknownKey,
+		"missing":     {Method: http.MethodPost, RequestURI: "/users/merge"},
+	} {
+		err := keys.Verify(ctx, request, time.Minute, now)
+		var applicationError appErrors.ApplicationError
+		assert.ErrorAs(t, err, &applicationError, reason)
+		assert.Equal(t, appErrors.SignatureRequired.String(), applicationError.GetCode(), reason)
+		assert.Equal(t, reason, applicationError.GetContext()["reason"])
+	}
+
+	assert.Error(t, keys.Verify(ctx, sign(now, "other"), time.Minute, now.Add(2*time.Minute)), "signatures expire")
+	assert.Error(t, keys.Verify(ctx, sign(now, `{"from":"a"}`), time.Minute, now.Add(30*time.Second)),
+		"a replay is refused until the signature expires")
+}
+
+// memorySignatures is a signature store shared by the instances of a test
+type memorySignatures struct {
+	mu         sync.Mutex
+	signatures map[string]time.Time
+	err        error
+}
+
+func (s *memorySignatures) Claim(_ context.Context, signature string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return false, s.err
+	}
+	if _, found := s.signatures[signature]; found {
+		return false, nil
+	}
+	s.signatures[signature] = expiresAt
+	return true, nil
+}
+
+func (s *memorySignatures) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted int64
+	for signature, expiresAt := range s.signatures {
+		if expiresAt.Before(now) {
+			delete(s.signatures, signature)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestSigningKeys_RefusesReplaysToOtherInstances(t *testing.T) {
+	store := &memorySignatures{signatures: make(map[string]time.Time)}
+	first, err := authorization.NewSigningKeys([]string{"k1:" + signingSecret}, store)
+	assert.NoError(t, err)
+	second, err := authorization.NewSigningKeys([]string{"k1:" + signingSecret}, store)
+	assert.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	ctx := context.Background()
+
+	request := sign(now, `{"from":"a"}`)
+	assert.NoError(t, first.Verify(ctx, request, 5*time.Minute, now))
+	for name, keys := range map[string]*authorization.SigningKeys{"same instance": first, "other instance": second} {
+		err := keys.Verify(ctx, request, 5*time.Minute, now.Add(time.Minute))
+		var applicationError appErrors.ApplicationError
+		if assert.ErrorAs(t, err, &applicationError, name) {
+			assert.Equal(t, "replayed", applicationError.GetContext()["reason"], name)
+		}
+	}
+
+	// The store cannot tell a replay apart while it is down, the request is refused
+	store.err = errors.New("connection refused")
+	err = second.Verify(ctx, sign(now, `{"from":"b"}`), 5*time.Minute, now)
+	var applicationError appErrors.ApplicationError
+	if assert.ErrorAs(t, err, &applicationError) {
+		assert.Equal(t, appErrors.ServiceUnavailable.String(), applicationError.GetCode())
+	}
+	store.err = nil
+	assert.NoError(t, second.Verify(ctx, sign(now, `{"from":"b"}`), 5*time.Minute, now), "a refused signature can be sent again")
+}
+
+func TestSigningKeys_Prune(t *testing.T) {
+	store := &memorySignatures{signatures: make(map[string]time.Time)}
+	keys, err := authorization.NewSigningKeys([]string{"k1:" + signingSecret}, store)
+	assert.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	ctx := context.Background()
+
+	assert.NoError(t, keys.Verify(ctx, sign(now, "old"), 5*time.Minute, now))
+	assert.NoError(t, keys.Verify(ctx, sign(now.Add(4*time.Minute), "recent"), 5*time.Minute, now.Add(4*time.Minute)))
+
+	assert.NoError(t, keys.Prune(ctx, now.Add(6*time.Minute)))
+	assert.Len(t, store.signatures, 1, "only the signature still within max_age is kept")
+	assert.Error(t, keys.Verify(ctx, sign(now.Add(4*time.Minute), "recent"), 5*time.Minute, now.Add(6*time.Minute)))
+}
+
+func TestSigningMiddleware_ChecksSignedOperations(t *testing.T) {
+	previous := huma.NewError
+	huma.NewError = utils.NewHumaError
+	t.Cleanup(func() { huma.NewError = previous })
+
+	keys, err := authorization.NewSigningKeys([]string{"k1:" + signingSecret}, nil)
+	assert.NoError(t, err)
+	_, api := humatest.New(t)
+	api.UseMiddleware(authorization.NewSigningMiddleware(newSigning(t), keys))
+
+	var received string
+	type input struct {
+		Body struct {
+			Users []string `json:"users"`
+		}
+	}
+	huma.Register(api, huma.Operation{OperationID: "batch-signup", Method: http.MethodPost, Path: "/users/batch"},
+		func(ctx context.Context, input *input) (*struct{}, error) {
+			received = strings.Join(input.Body.Users, ",")
+			return nil, nil
+		})
+	huma.Register(api, huma.Operation{OperationID: "list-users", Method: http.MethodGet, Path: "/users"},
+		func(ctx context.Context, input *struct{}) (*struct{}, error) { return nil, nil })
+
+	signer := &client.Signer{KeyID: "k1", Secret: []byte(signingSecret)}
+	send := func(sign bool) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/users/batch", strings.NewReader(`{"users":["ana","luis"]}`))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-Tenant-Id", "tenant1")
+		if sign {
+			assert.NoError(t, signer.Sign(request))
+		}
+		response := httptest.NewRecorder()
+		api.Adapter().ServeHTTP(response, request)
+		return response
+	}
+
+	response := send(false)
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+	var body utils.HTTPErrorResponse
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, "SIGNATURE_REQUIRED", body.Error.Code)
+	assert.Equal(t, "missing", body.Error.Context["reason"])
+
+	assert.Equal(t, http.StatusNoContent, send(true).Code)
+	assert.Equal(t, "ana,luis", received, "the handler reads the body that was signed")
+
+	assert.Equal(t, http.StatusNoContent, api.Get("/users").Code, "other operations are not signed")
+}
@@ -0,0 +1,74 @@
+package authorization
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newStepUp(t *testing.T, acr []string) *authorization.StepUp {
+	access, err := authorization.NewEndpointAccess(authorization.EndpointAccessConfig{Public: []string{"health"}}, permissions)
+	assert.Nil(t, err)
+	stepUp, err := authorization.NewStepUp(authorization.StepUpConfig{
+		MaxAge:     15 * time.Minute,
+		ACR:        acr,
+		Operations: []string{"batch-signup"},
+	}, access)
+	assert.Nil(t, err)
+	return stepUp
+}
+
+func signedIn(at time.Time, acr string) requestmeta.Meta {
+	return requestmeta.Meta{UserID: "admin-1", TenantID: "tenant1", AuthTime: strconv.FormatInt(at.Unix(), 10), AuthACR: acr}
+}
+
+func TestStepUp_NeedsARecentAuthentication(t *testing.T) {
+	stepUp := newStepUp(t, nil)
+	now := time.Now()
+
+	assert.True(t, stepUp.Requires("batch-signup"))
+	assert.False(t, stepUp.Requires("list-users"))
+
+	assert.NoError(t, stepUp.Check(signedIn(now.Add(-5*time.Minute), ""), now))
+	for name, meta := range map[string]requestmeta.Meta{
+		"no auth_time":  {UserID: "admin-1", TenantID: "tenant1"},
+		"stale session": signedIn(now.Add(-time.Hour), ""),
+		"future":        signedIn(now.Add(time.Hour), ""),
+		"not a time":    {UserID: "admin-1", TenantID: "tenant1", AuthTime: "yesterday"},
+	} {
+		err := stepUp.Check(meta, now)
+		var applicationError appErrors.ApplicationError
+		assert.ErrorAs(t, err, &applicationError, name)
+		assert.Equal(t, appErrors.StepUpRequired.String(), applicationError.GetCode(), name)
+	}
+
+	assert.NoError(t, stepUp.Check(requestmeta.Meta{UserID: "sa:9b2f1c1e-1d2a-4c4e-9c57-2f0e5b8d7a10"}, now),
+		"service accounts have no session to step up")
+}
+
+func TestStepUp_ChecksTheAuthenticationClass(t *testing.T) {
+	stepUp := newStepUp(t, []string{"mfa"})
+	now := time.Now()
+
+	assert.NoError(t, stepUp.Check(signedIn(now, "mfa"), now))
+	assert.Error(t, stepUp.Check(signedIn(now, "pwd"), now), "a recent password sign in is not enough")
+}
+
+func TestStepUp_RefusesUnknownAndPublicOperations(t *testing.T) {
+	access, err := authorization.NewEndpointAccess(authorization.EndpointAccessConfig{Public: []string{"health"}}, permissions)
+	assert.Nil(t, err)
+
+	_, err = authorization.NewStepUp(authorization.StepUpConfig{MaxAge: time.Minute, Operations: []string{"health", "batch-sigup"}}, access)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "batch-sigup is not an operation")
+	assert.Contains(t, err.Error(), "health is public")
+
+	_, err = authorization.NewStepUp(authorization.StepUpConfig{Operations: []string{"batch-signup"}}, access)
+	assert.NotNil(t, err, "a step up needs a max age")
+}
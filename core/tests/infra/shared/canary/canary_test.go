@@ -0,0 +1,113 @@
+package canary
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/canary"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRollouts(t *testing.T) {
+	rollouts, err := canary.ParseRollouts([]string{"grading-engine=10%|north| south ", "search=pilot"})
+	assert.NoError(t, err)
+	assert.Equal(t, canary.Rollout{Name: "grading-engine", Percent: 10, Pilots: []string{"north", "south"}}, rollouts["grading-engine"])
+	assert.Equal(t, canary.Rollout{Name: "search", Pilots: []string{"pilot"}}, rollouts["search"])
+
+	for _, invalid := range [][]string{{"10%"}, {"=10%"}, {"engine=101%"}, {"engine=ten%"}, {"engine=5%", "engine=north"}} {
+		_, err := canary.ParseRollouts(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRollout_CohortOf(t *testing.T) {
+	rollout := canary.Rollout{Name: "grading-engine", Percent: 20, Pilots: []string{"pilot"}}
+	assert.Equal(t, canary.CohortCanary, rollout.CohortOf("pilot"))
+	assert.Equal(t, canary.CohortStable, rollout.CohortOf(""))
+	assert.Equal(t, canary.CohortStable, canary.Rollout{Name: "grading-engine"}.CohortOf("north"))
+	assert.Equal(t, canary.CohortCanary, canary.Rollout{Name: "grading-engine", Percent: 100}.CohortOf("north"))
+
+	canaries := 0
+	for i := 0; i < 1000; i++ {
+		tenantID := fmt.Sprintf("tenant-%d", i)
+		if rollout.CohortOf(tenantID) == canary.CohortCanary {
+			canaries++
+			// Growing the share keeps the tenants already on the canary
+			wider := canary.Rollout{Name: rollout.Name, Percent: 50}
+			assert.Equal(t, canary.CohortCanary, wider.CohortOf(tenantID))
+		}
+	}
+	assert.InDelta(t, 200, canaries, 50)
+}
+
+type engine interface {
+	Average(scores []float64) (float64, error)
+}
+
+type engineFunc func(scores []float64) (float64, error)
+
+func (f engineFunc) Average(scores []float64) (float64, error) {
+	return f(scores)
+}
+
+func TestSwitch_CallsTheImplementationOfTheCohortAndComparesThem(t *testing.T) {
+	canaries := canary.NewRouter(map[string]canary.Rollout{
+		"grading-engine": {Name: "grading-engine", Pilots: []string{"pilot"}},
+		"mistyped":       {Name: "mistyped", Percent: 10},
+	})
+	stable := engineFunc(func(scores []float64) (float64, error) { return 80, nil })
+	candidate := engineFunc(func(scores []float64) (float64, error) { return 0, errors.New("rewrite failed") })
+	switched := canary.NewSwitch[engine](canaries, "grading-engine", stable, candidate)
+	assert.Equal(t, []string{"mistyped"}, canaries.Unswitched())
+
+	var average float64
+	err := switched.Run("north", func(implementation engine) (err error) {
+		average, err = implementation.Average([]float64{80})
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 80.0, average)
+
+	_, cohort := switched.Pick("pilot")
+	assert.Equal(t, canary.CohortCanary, cohort)
+
+	for i := 0; i < canary.MinCalls; i++ {
+		_ = switched.Run("north", func(implementation engine) error { _, err := implementation.Average(nil); return err })
+		_ = switched.Run("pilot", func(implementation engine) error { _, err := implementation.Average(nil); return err })
+	}
+
+	report := canaries.Report()
+	assert.Equal(t, canary.StatusRegressed, report.Status)
+	assert.Len(t, report.Rollouts, 1)
+	assert.Equal(t, 1, report.Rollouts[0].Pilots)
+	assert.Equal(t, canary.CohortStatus{Cohort: canary.CohortStable, Calls: canary.MinCalls + 1}, withoutLatency(report.Rollouts[0].Cohorts[0]))
+	assert.Equal(t, canary.CohortStatus{Cohort: canary.CohortCanary, Calls: canary.MinCalls, Errors: canary.MinCalls, ErrorRate: 1}, withoutLatency(report.Rollouts[0].Cohorts[1]))
+
+	var metrics strings.Builder
+	assert.NoError(t, canaries.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `canary_calls_total{rollout="grading-engine",cohort="stable",outcome="ok"} 101`+"\n")
+	assert.Contains(t, metrics.String(), `canary_calls_total{rollout="grading-engine",cohort="canary",outcome="error"} 100`+"\n")
+	assert.NotContains(t, metrics.String(), "mistyped")
+}
+
+func TestRouter_DoesNotCompareCohortsWithFewCalls(t *testing.T) {
+	canaries := canary.NewRouter(map[string]canary.Rollout{"grading-engine": {Name: "grading-engine", Percent: 100}})
+	failing := engineFunc(func(scores []float64) (float64, error) { return 0, errors.New("rewrite failed") })
+	switched := canary.NewSwitch[engine](canaries, "grading-engine", failing, failing)
+
+	for i := 0; i < canary.MinCalls; i++ {
+		_ = switched.Run("north", func(implementation engine) error { _, err := implementation.Average(nil); return err })
+	}
+
+	report := canaries.Report()
+	assert.Equal(t, canary.StatusOK, report.Status)
+	assert.Equal(t, int64(0), report.Rollouts[0].Cohorts[0].Calls)
+}
+
+func withoutLatency(status canary.CohortStatus) canary.CohortStatus {
+	status.MeanLatencyMs = 0
+	return status
+}
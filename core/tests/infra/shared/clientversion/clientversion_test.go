@@ -0,0 +1,95 @@
+package clientversion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/clientversion"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	version, err := clientversion.Parse("iOS/4.2.1")
+	assert.NoError(t, err)
+	assert.Equal(t, clientversion.Version{Platform: "ios", Major: 4, Minor: 2, Patch: 1}, version)
+
+	version, err = clientversion.Parse("android/v5.1-beta+42")
+	assert.NoError(t, err)
+	assert.Equal(t, clientversion.Version{Platform: "android", Major: 5, Minor: 1}, version)
+
+	version, err = clientversion.Parse("3")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.0.0", version.Number())
+
+	for _, invalid := range []string{"", "ios/", "ios/four", "1.2.3.4", "ios/-1.0"} {
+		_, err := clientversion.Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestParseMinimums(t *testing.T) {
+	minimums, err := clientversion.ParseMinimums([]string{"ios=4.2.0", " Android = 5.0 "})
+	assert.NoError(t, err)
+	assert.Equal(t, "4.2.0", minimums["ios"].Number())
+	assert.Equal(t, "5.0.0", minimums["android"].Number())
+
+	_, err = clientversion.ParseMinimums([]string{"4.2.0"})
+	assert.Error(t, err)
+}
+
+func newAPI(t *testing.T, enforcer *clientversion.Enforcer) humatest.TestAPI {
+	previous := huma.NewError
+	huma.NewError = utils.NewHumaError
+	t.Cleanup(func() { huma.NewError = previous })
+
+	_, api := humatest.New(t)
+	api.UseMiddleware(enforcer.Middleware)
+	huma.Register(api, huma.Operation{OperationID: "list-items", Method: http.MethodGet, Path: "/items"},
+		func(ctx context.Context, input *struct{}) (*struct{}, error) { return nil, nil })
+	return api
+}
+
+func TestEnforcer_RejectsClientsBelowTheMinimumOfTheirPlatform(t *testing.T) {
+	minimums, _ := clientversion.ParseMinimums([]string{"ios=4.2.0"})
+	enforcer := clientversion.NewEnforcer(minimums)
+	api := newAPI(t, enforcer)
+
+	response := api.Get("/items", "X-Client-Version: ios/4.1.9")
+	assert.Equal(t, http.StatusUpgradeRequired, response.Code)
+	assert.Equal(t, "4.2.0", response.Header().Get(clientversion.MinimumVersionHeader))
+	var body utils.HTTPErrorResponse
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, "UPGRADE_REQUIRED", body.Error.Code)
+	assert.Equal(t, "4.2.0", body.Error.Context["minimum_version"])
+
+	current := api.Get("/items", "X-Client-Version: ios/4.2.0")
+	assert.Equal(t, http.StatusNoContent, current.Code)
+	assert.Equal(t, "4.2.0", current.Header().Get(clientversion.MinimumVersionHeader))
+
+	// Platforms without a minimum and clients without a version are served
+	assert.Equal(t, http.StatusNoContent, api.Get("/items", "X-Client-Version: android/1.0.0").Code)
+	assert.Equal(t, http.StatusNoContent, api.Get("/items").Code)
+
+	var metrics strings.Builder
+	assert.NoError(t, enforcer.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `http_client_requests_total{platform="ios",version="4.1.9"} 1`)
+	assert.Contains(t, metrics.String(), `http_client_requests_total{platform="unknown",version="unknown"} 1`)
+	assert.Contains(t, metrics.String(), `http_client_upgrade_required_total{platform="ios"} 1`)
+}
+
+func TestEnforcer_ServesEveryoneWithoutMinimums(t *testing.T) {
+	enforcer := clientversion.NewEnforcer(nil)
+	api := newAPI(t, enforcer)
+
+	assert.False(t, enforcer.Enforcing())
+	response := api.Get("/items", "X-Client-Version: ios/0.0.1")
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Empty(t, response.Header().Get(clientversion.MinimumVersionHeader))
+}
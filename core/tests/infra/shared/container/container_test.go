@@ -0,0 +1,131 @@
+package container
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	"github.com/nahualventure/class-backend/infra/configs"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/container"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeUserRepository keeps created users in memory, the other methods are not reached by signup
+type fakeUserRepository struct {
+	ports.UserRepository
+	created []*entities.User
+}
+
+func (r *fakeUserRepository) ExistsByEmail(email string) (bool, error) {
+	for _, user := range r.created {
+		if user.Email == email {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeUserRepository) Create(user *entities.User, password string) (*entities.User, error) {
+	r.created = append(r.created, user)
+	return user, nil
+}
+
+// newAPI uses the application error envelope like main, Huma's own error details clash with the
+// batch signup response
+func newAPI(t *testing.T) humatest.TestAPI {
+	previous := huma.NewError
+	huma.NewError = utils.NewHumaError
+	t.Cleanup(func() { huma.NewError = previous })
+
+	_, api := humatest.New(t)
+	return api
+}
+
+func TestContainer_EveryOperationIsAuthorized(t *testing.T) {
+	api := newAPI(t)
+	container.New(container.Adapters{}, container.Config{}).RegisterRoutes(api)
+	loader := authorization.NewPolicyLoader()
+	assert.Nil(t, loader.LoadFromFS(configs.Assets(""), configs.PoliciesFile))
+	access, err := loader.EndpointAccess()
+	assert.Nil(t, err)
+	_, err = loader.StepUp(access)
+	assert.Nil(t, err, "every step up operation exists and has a user")
+
+	operations := 0
+	for path, item := range api.OpenAPI().Paths {
+		for _, operationID := range operationIDs(item.Get, item.Post, item.Put, item.Patch, item.Delete) {
+			operations++
+			assert.NotEqual(t, authorization.AccessDenied, access.Mode(operationID), "%s %s has no access mode", path, operationID)
+		}
+	}
+	assert.Greater(t, operations, 50)
+}
+
+func operationIDs(operations ...*huma.Operation) []string {
+	var ids []string
+	for _, operation := range operations {
+		if operation != nil {
+			ids = append(ids, operation.OperationID)
+		}
+	}
+	return ids
+}
+
+func TestParseModuleSelection(t *testing.T) {
+	every, err := container.ParseModuleSelection(nil)
+	assert.Nil(t, err)
+	assert.True(t, every.All())
+	assert.True(t, every.Serves("grading"))
+
+	selection, err := container.ParseModuleSelection([]string{" Auth", "user", "auth", "dashboard"})
+	assert.Nil(t, err)
+	assert.False(t, selection.All())
+	assert.Equal(t, []string{"auth", "user", "dashboard"}, selection.Names())
+	assert.False(t, selection.Serves("grading"))
+
+	_, err = container.ParseModuleSelection([]string{"auth", "grades"})
+	assert.ErrorContains(t, err, "grades")
+}
+
+func TestContainer_RegistersTheRoutesOfTheSelectedModules(t *testing.T) {
+	selection, err := container.ParseModuleSelection([]string{"auth"})
+	assert.Nil(t, err)
+	api := newAPI(t)
+	operations := container.NewOperationModules()
+	api.OpenAPI().OnAddOperation = append(api.OpenAPI().OnAddOperation, operations.OnAddOperation)
+	container.New(container.Adapters{}, container.Config{}).RegisterRoutesOf(api, selection, operations)
+
+	assert.NotNil(t, api.OpenAPI().Paths["/auth/signup"])
+	for path := range api.OpenAPI().Paths {
+		assert.True(t, strings.HasPrefix(path, "/auth/"), "%s is not a route of auth", path)
+	}
+	module, ok := operations.Module("signup")
+	assert.True(t, ok)
+	assert.Equal(t, "auth", module)
+	_, ok = operations.Module("health")
+	assert.False(t, ok)
+}
+
+func TestContainer_HandlersUseTheGivenAdapters(t *testing.T) {
+	users := &fakeUserRepository{}
+	api := newAPI(t)
+	container.New(container.Adapters{Users: users}, container.Config{}).RegisterRoutes(api)
+
+	response := api.Post("/auth/signup", map[string]any{
+		"name":     "Ada Lovelace",
+		"email":    "ada@example.com",
+		"password": "correct horse battery",
+	})
+
+	assert.Equal(t, http.StatusCreated, response.Code, response.Body.String())
+	if assert.Len(t, users.created, 1) {
+		assert.Equal(t, "ada@example.com", users.created[0].Email)
+	}
+}
@@ -0,0 +1,125 @@
+package cost
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/cache"
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humagin"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type noopTracer struct{}
+
+func (noopTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return ctx
+}
+
+func (noopTracer) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+func newRouter(t *testing.T, accounting *cost.Accounting, external *httptest.Server) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(accounting.Middleware)
+	api := humagin.New(router, huma.DefaultConfig("test", "1.0.0"))
+	api.UseMiddleware(accounting.OperationMiddleware)
+
+	tracer := cost.Trace(noopTracer{})
+	names := cache.NewTTLCache[string, string](time.Minute, 10)
+	names.Set("known", "name")
+	client := &http.Client{Transport: cost.Transport("sis", nil)}
+
+	huma.Register(api, huma.Operation{OperationID: "get-item", Method: http.MethodGet, Path: "/items"},
+		func(ctx context.Context, input *struct{}) (*struct{ Body string }, error) {
+			for range 2 {
+				queryCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+				tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{})
+			}
+			names.Lookup(ctx, "known")
+			names.Lookup(ctx, "unknown")
+
+			request, err := http.NewRequestWithContext(ctx, http.MethodGet, external.URL, nil)
+			assert.NoError(t, err)
+			response, err := client.Do(request)
+			assert.NoError(t, err)
+			response.Body.Close()
+			return &struct{ Body string }{Body: "item"}, nil
+		})
+	huma.Register(api, huma.Operation{OperationID: "delete-item", Method: http.MethodDelete, Path: "/items"},
+		func(ctx context.Context, input *struct{}) (*struct{}, error) {
+			cost.RecordQuery(ctx, time.Millisecond)
+			return nil, nil
+		})
+	return router
+}
+
+func serve(router *gin.Engine, method string, header string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, "/items", nil)
+	if header != "" {
+		request.Header.Set(cost.Header, header)
+	}
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	return response
+}
+
+func TestAccounting_ReturnsTheCostToTrustedCallers(t *testing.T) {
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer external.Close()
+	router := newRouter(t, cost.NewAccounting("secret"), external)
+
+	response := serve(router, http.MethodGet, "secret")
+	assert.Equal(t, http.StatusOK, response.Code)
+	header := response.Header().Get(cost.Header)
+	assert.Contains(t, header, "queries=2")
+	assert.Contains(t, header, "cache_hits=1, cache_misses=1")
+	assert.Contains(t, header, "external_calls=1")
+
+	// Responses without a body carry it too
+	response = serve(router, http.MethodDelete, "secret")
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Contains(t, response.Header().Get(cost.Header), "queries=1")
+
+	assert.Empty(t, serve(router, http.MethodGet, "guess").Header().Get(cost.Header))
+	assert.Empty(t, serve(router, http.MethodGet, "").Header().Get(cost.Header))
+}
+
+func TestAccounting_WriteMetrics(t *testing.T) {
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer external.Close()
+	accounting := cost.NewAccounting("")
+	router := newRouter(t, accounting, external)
+
+	serve(router, http.MethodGet, "")
+	assert.Empty(t, serve(router, http.MethodGet, "").Header().Get(cost.Header))
+	serve(router, http.MethodDelete, "")
+
+	var metrics strings.Builder
+	assert.NoError(t, accounting.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `http_request_cost_requests_total{operation="get-item"} 2`)
+	assert.Contains(t, metrics.String(), `http_request_db_queries_total{operation="get-item"} 4`)
+	assert.Contains(t, metrics.String(), `http_request_db_queries_total{operation="delete-item"} 1`)
+	assert.Contains(t, metrics.String(), `http_request_cache_lookups_total{operation="get-item",result="miss"} 2`)
+	assert.Contains(t, metrics.String(), `http_request_external_calls_total{operation="get-item",service="sis"} 2`)
+}
+
+func TestRecord_OutsideRequestsIsIgnored(t *testing.T) {
+	ctx := context.Background()
+	cost.RecordQuery(ctx, time.Second)
+	cost.RecordCache(ctx, true)
+	cost.RecordExternal(ctx, "sis", time.Second)
+	assert.Nil(t, cost.FromContext(ctx))
+
+	ctx, meter := cost.WithMeter(ctx)
+	cost.RecordQuery(ctx, 1500*time.Microsecond)
+	assert.Equal(t, "db_ms=1.5, queries=1, cache_hits=0, cache_misses=0, external_calls=0, external_ms=0.0", meter.Cost().String())
+}
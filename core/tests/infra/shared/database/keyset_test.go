@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+var keysetColumns = map[string]database.KeysetColumn{
+	"name":       {Column: "name", Type: "varchar"},
+	"created_at": {Column: "created_at", Type: "timestamptz"},
+}
+
+var keysetTieBreaker = database.KeysetColumn{Column: "id", Type: "uuid"}
+
+func TestCompileKeyset(t *testing.T) {
+	order := []pagination.OrderBy{{Field: "name", Direction: pagination.Descending}, {Field: "created_at", Direction: pagination.Ascending}}
+
+	predicate, args, err := database.CompileKeyset(order, keysetColumns, keysetTieBreaker, nil, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUE", predicate, "the first page keeps every row")
+	assert.Empty(t, args)
+
+	predicate, args, err = database.CompileKeyset(order, keysetColumns, keysetTieBreaker, []string{"Ada", "2025-03-01T08:00:00Z", "0b0c6f5e-8d7c-4a51-9f43-1f7c5a0e2d11"}, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "((name < $3::text::varchar)"+
+		" OR (name = $3::text::varchar AND created_at > $4::text::timestamptz)"+
+		" OR (name = $3::text::varchar AND created_at = $4::text::timestamptz AND id > $5::text::uuid))", predicate)
+	assert.Equal(t, []any{"Ada", "2025-03-01T08:00:00Z", "0b0c6f5e-8d7c-4a51-9f43-1f7c5a0e2d11"}, args)
+
+	_, _, err = database.CompileKeyset(order, keysetColumns, keysetTieBreaker, []string{"Ada"}, 3)
+	assert.Error(t, err, "keys of another order are refused")
+	_, _, err = database.CompileKeyset([]pagination.OrderBy{{Field: "password"}}, keysetColumns, keysetTieBreaker, []string{"x", "y"}, 1)
+	assert.Error(t, err, "fields without a column are refused")
+}
+
+// TestCompileKeyset_PagesStayStable pages through a temporary table in Postgres in TEST_DATABASE_URL
+// while rows are inserted before and after the pages already read. Every row present at the start is
+// listed exactly once.
+func TestCompileKeyset_PagesStayStable(t *testing.T) {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, databaseURL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.Begin(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `CREATE TEMP TABLE keyset_rows (id UUID PRIMARY KEY, name VARCHAR(255) NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL) ON COMMIT DROP`)
+	if !assert.NoError(t, err) {
+		return
+	}
+	base := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	insert := func(name string, createdAt time.Time) string {
+		var id pgtype.UUID
+		err := tx.QueryRow(ctx, "INSERT INTO keyset_rows VALUES (gen_random_uuid(), $1, $2) RETURNING id", name, createdAt).Scan(&id)
+		assert.NoError(t, err)
+		return id.String()
+	}
+	existing := make(map[string]bool)
+	for i := 0; i < 40; i++ {
+		// Names repeat, created_at and then the id break the ties
+		existing[insert(fmt.Sprintf("name-%d", i%5), base.Add(time.Duration(i%3)*time.Microsecond))] = true
+	}
+
+	order := []pagination.OrderBy{{Field: "name", Direction: pagination.Descending}, {Field: "created_at", Direction: pagination.Ascending}}
+	seen := make(map[string]int)
+	var after []string
+	for pages := 0; pages < 100; pages++ {
+		predicate, args, err := database.CompileKeyset(order, keysetColumns, keysetTieBreaker, after, 2)
+		if !assert.NoError(t, err) {
+			return
+		}
+		rows, err := tx.Query(ctx, "SELECT id::text, name, created_at FROM keyset_rows WHERE "+predicate+
+			" ORDER BY name DESC, created_at ASC, id ASC LIMIT $1", append([]any{6}, args...)...)
+		if !assert.NoError(t, err) {
+			return
+		}
+		type row struct {
+			id        string
+			name      string
+			createdAt time.Time
+		}
+		page, err := pgx.CollectRows(rows, func(r pgx.CollectableRow) (row, error) {
+			var listed row
+			err := r.Scan(&listed.id, &listed.name, &listed.createdAt)
+			return listed, err
+		})
+		if !assert.NoError(t, err) || len(page) == 0 {
+			break
+		}
+		for _, listed := range page {
+			seen[listed.id]++
+		}
+		last := page[len(page)-1]
+		after = []string{last.name, pagination.TimeKey(last.createdAt), last.id}
+
+		// Rows arrive at the start of the order and among the rows already listed
+		insert("name-9", base)
+		insert(last.name, base)
+	}
+
+	for id := range existing {
+		assert.Equal(t, 1, seen[id], "%s is listed exactly once", id)
+	}
+	for id, count := range seen {
+		assert.Equal(t, 1, count, "%s is listed once", id)
+	}
+}
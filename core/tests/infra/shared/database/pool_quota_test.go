@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+type quotaTracer interface {
+	pgxpool.AcquireTracer
+	pgxpool.ReleaseTracer
+}
+
+func tracedQuota(maxWait time.Duration) (*database.PoolQuota, quotaTracer) {
+	quota := database.NewPoolQuota(database.PoolQuotaConfig{BackgroundPercent: 25, ReportsPercent: 10, MaxWait: maxWait}, 8)
+	return quota, quota.Trace(database.NewQueryTracer(database.QueryTracerConfig{})).(quotaTracer)
+}
+
+// acquire runs the tracer hooks of a pool acquisition, false when the wait ended with ctx
+func acquire(tracer quotaTracer, ctx context.Context, conn *pgx.Conn) bool {
+	ctx = tracer.TraceAcquireStart(ctx, nil, pgxpool.TraceAcquireStartData{})
+	if ctx.Err() != nil {
+		tracer.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{Err: ctx.Err()})
+		return false
+	}
+	tracer.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{Conn: conn})
+	return true
+}
+
+func TestPoolQuota_LimitsAreSharesOfThePool(t *testing.T) {
+	quota, _ := tracedQuota(time.Second)
+
+	assert.Equal(t, 2, quota.Limit(database.PoolClassBackground))
+	assert.Equal(t, 1, quota.Limit(database.PoolClassReports), "a class with a share has at least one connection")
+	assert.Equal(t, 0, quota.Limit(database.PoolClassInteractive))
+	assert.Equal(t, database.PoolClassBackground, database.PoolClassOf(context.Background()))
+}
+
+func TestPoolQuota_ReportsWaitForTheirOwnConnections(t *testing.T) {
+	quota, tracer := tracedQuota(time.Minute)
+	reports := database.WithPoolClass(context.Background(), database.PoolClassReports)
+	interactive := database.WithPoolClass(context.Background(), database.PoolClassInteractive)
+	held := &pgx.Conn{}
+
+	assert.True(t, acquire(tracer, reports, held))
+	for range 5 {
+		assert.True(t, acquire(tracer, interactive, &pgx.Conn{}), "requests are not held back by reports")
+	}
+
+	waiting, cancel := context.WithTimeout(reports, 20*time.Millisecond)
+	defer cancel()
+	assert.False(t, acquire(tracer, waiting, &pgx.Conn{}), "the second report waits until its context ends")
+
+	done := make(chan bool)
+	go func() { done <- acquire(tracer, reports, &pgx.Conn{}) }()
+	time.Sleep(10 * time.Millisecond)
+	tracer.TraceRelease(nil, pgxpool.TraceReleaseData{Conn: held})
+	assert.True(t, <-done, "a released connection lets the next report in")
+
+	var metrics strings.Builder
+	assert.NoError(t, quota.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `db_pool_class_in_use{class="interactive"} 5`)
+	assert.Contains(t, metrics.String(), `db_pool_class_in_use{class="reports"} 1`)
+	assert.Contains(t, metrics.String(), `db_pool_class_waits_total{class="reports"} 2`)
+	assert.Contains(t, metrics.String(), `db_pool_class_rejected_total{class="reports"} 1`)
+}
+
+func TestPoolQuota_GoesOverTheLimitAfterMaxWait(t *testing.T) {
+	quota, tracer := tracedQuota(10 * time.Millisecond)
+	background := context.Background()
+	first, second, third := &pgx.Conn{}, &pgx.Conn{}, &pgx.Conn{}
+
+	assert.True(t, acquire(tracer, background, first))
+	assert.True(t, acquire(tracer, background, second))
+	assert.True(t, acquire(tracer, background, third), "the quota is soft")
+
+	// The connection over the limit holds no slot, releasing it frees none
+	tracer.TraceRelease(nil, pgxpool.TraceReleaseData{Conn: third})
+	tracer.TraceRelease(nil, pgxpool.TraceReleaseData{Conn: third})
+	var metrics strings.Builder
+	assert.NoError(t, quota.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `db_pool_class_in_use{class="background"} 2`)
+	assert.Contains(t, metrics.String(), `db_pool_class_overflows_total{class="background"} 1`)
+	assert.Contains(t, metrics.String(), `db_pool_class_limit{class="background"} 2`)
+}
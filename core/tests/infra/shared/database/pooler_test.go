@@ -0,0 +1,161 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolerConfig_Apply(t *testing.T) {
+	connConfig, err := pgx.ParseConfig("postgres://localhost/class?timezone=UTC&application_name=class")
+	assert.NoError(t, err)
+
+	assert.NoError(t, database.PoolerConfig{TransactionPooling: false}.Apply(connConfig))
+	assert.Equal(t, pgx.QueryExecModeCacheStatement, connConfig.DefaultQueryExecMode, "session pooling keeps the mode")
+
+	assert.NoError(t, database.PoolerConfig{TransactionPooling: true}.Apply(connConfig))
+	assert.Equal(t, pgx.QueryExecModeCacheDescribe, connConfig.DefaultQueryExecMode)
+	assert.Equal(t, 0, connConfig.StatementCacheCapacity)
+
+	connConfig.DefaultQueryExecMode = pgx.QueryExecModeDescribeExec
+	assert.Error(t, database.PoolerConfig{TransactionPooling: true}.Apply(connConfig))
+
+	connConfig, err = pgx.ParseConfig("postgres://localhost/class?search_path=tenant1")
+	assert.NoError(t, err)
+	assert.Error(t, database.PoolerConfig{TransactionPooling: true}.Apply(connConfig), "search_path is not tracked by PgBouncer")
+}
+
+func TestPoolerConfig_SessionConnConfig(t *testing.T) {
+	poolConfig, err := pgx.ParseConfig("postgres://pgbouncer:6432/class")
+	assert.NoError(t, err)
+	poolConfig.RuntimeParams["timezone"] = "UTC"
+
+	connConfig, err := database.PoolerConfig{SessionURL: "postgres://postgres:5432/class?application_name=locks"}.SessionConnConfig(poolConfig)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "postgres", connConfig.Host)
+	assert.Equal(t, uint16(5432), connConfig.Port)
+	assert.Equal(t, "UTC", connConfig.RuntimeParams["timezone"])
+	assert.Equal(t, "locks", connConfig.RuntimeParams["application_name"])
+
+	connConfig, err = database.PoolerConfig{}.SessionConnConfig(poolConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "pgbouncer", connConfig.Host, "without a session URL the pool settings are used")
+}
+
+func TestSessionStatement(t *testing.T) {
+	assert.True(t, database.SessionStatement("SET statement_timeout = '5s'"))
+	assert.True(t, database.SessionStatement("set search_path to tenant1"))
+	assert.True(t, database.SessionStatement("RESET ALL"))
+	assert.True(t, database.SessionStatement("LISTEN casbin_role_changes"))
+	assert.True(t, database.SessionStatement("SELECT pg_try_advisory_lock(hashtext($1))"))
+
+	assert.False(t, database.SessionStatement("SET LOCAL statement_timeout = '5s'"))
+	assert.False(t, database.SessionStatement("SET TRANSACTION ISOLATION LEVEL SERIALIZABLE"))
+	assert.False(t, database.SessionStatement("SELECT pg_advisory_xact_lock(hashtext($1))"))
+	assert.False(t, database.SessionStatement("SELECT set_config($1, $2, true)"))
+	assert.False(t, database.SessionStatement("-- name: GetSettings :one\nSELECT * FROM settings"))
+}
+
+// TestTransactionPooling runs the pool the way the server does against PgBouncer in transaction mode in
+// TEST_PGBOUNCER_URL: queries are not prepared, jsonb is written and SetLocal does not reach the
+// transactions of other clients. It writes nothing.
+func TestTransactionPooling(t *testing.T) {
+	databaseURL := os.Getenv("TEST_PGBOUNCER_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_PGBOUNCER_URL is not set")
+	}
+
+	ctx := context.Background()
+	poolConfig, err := pgxpool.ParseConfig(databaseURL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	poolConfig.ConnConfig.RuntimeParams["timezone"] = "UTC"
+	if !assert.NoError(t, database.PoolerConfig{TransactionPooling: true}.Apply(poolConfig.ConnConfig)) {
+		return
+	}
+	// More clients than PgBouncer has server connections, so they take turns on the same servers
+	poolConfig.MaxConns = 8
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer pool.Close()
+
+	t.Run("queries", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for client := 0; client < 8; client++ {
+			wg.Add(1)
+			go func(client int) {
+				defer wg.Done()
+				for i := 0; i < 20; i++ {
+					var sum int
+					err := pool.QueryRow(ctx, "SELECT $1::int + $2::int", client, i).Scan(&sum)
+					assert.NoError(t, err)
+					assert.Equal(t, client+i, sum)
+				}
+			}(client)
+		}
+		wg.Wait()
+	})
+
+	t.Run("jsonb", func(t *testing.T) {
+		document, err := json.Marshal(map[string]string{"pooler": "transaction"})
+		assert.NoError(t, err)
+
+		var pooler string
+		err = pool.QueryRow(ctx, "SELECT $1::jsonb ->> 'pooler'", document).Scan(&pooler)
+		assert.NoError(t, err)
+		assert.Equal(t, "transaction", pooler)
+	})
+
+	t.Run("time zone", func(t *testing.T) {
+		var timezone string
+		assert.NoError(t, pool.QueryRow(ctx, "SHOW timezone").Scan(&timezone))
+		assert.Equal(t, "UTC", timezone)
+	})
+
+	t.Run("set local", func(t *testing.T) {
+		var defaultTimeout string
+		if !assert.NoError(t, pool.QueryRow(ctx, "SHOW statement_timeout").Scan(&defaultTimeout)) {
+			return
+		}
+
+		var wg sync.WaitGroup
+		for client := 0; client < 8; client++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+					if err := database.SetLocal(ctx, tx, "statement_timeout", "4321"); err != nil {
+						return err
+					}
+					var timeout string
+					if err := tx.QueryRow(ctx, "SHOW statement_timeout").Scan(&timeout); err != nil {
+						return err
+					}
+					assert.Equal(t, "4321ms", timeout)
+					return nil
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		// The transactions are over, every server connection is back to the default
+		for i := 0; i < 16; i++ {
+			var timeout string
+			assert.NoError(t, pool.QueryRow(ctx, "SHOW statement_timeout").Scan(&timeout))
+			assert.Equal(t, defaultTimeout, timeout)
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryName(t *testing.T) {
+	assert.Equal(t, "GetGrade", database.QueryName("-- name: GetGrade :one\nSELECT * FROM grades WHERE id = $1"))
+	assert.Equal(t, database.UnnamedQuery, database.QueryName("SELECT 1"))
+}
+
+func traceQuery(tracer *database.QueryTracer, sql string, elapsed time.Duration, err error) {
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql})
+	time.Sleep(elapsed)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: err})
+}
+
+func TestQueryTracer_KeepsMetricsPerQuery(t *testing.T) {
+	tracer := database.NewQueryTracer(database.QueryTracerConfig{SlowThreshold: 5 * time.Millisecond})
+
+	traceQuery(tracer, "-- name: ListGrades :many\nSELECT 1", 0, nil)
+	traceQuery(tracer, "-- name: ListGrades :many\nSELECT 1", 10*time.Millisecond, nil)
+	traceQuery(tracer, "-- name: GetGrade :one\nSELECT 1", 0, errors.New("no rows"))
+
+	stats := tracer.Stats()
+	assert.Len(t, stats, 2)
+	assert.Equal(t, "GetGrade", stats[0].Name)
+	assert.Equal(t, int64(1), stats[0].Errors)
+	assert.Equal(t, "ListGrades", stats[1].Name)
+	assert.Equal(t, int64(2), stats[1].Calls)
+	assert.Equal(t, int64(1), stats[1].Slow)
+	assert.GreaterOrEqual(t, stats[1].Max, 10*time.Millisecond)
+
+	var metrics strings.Builder
+	assert.NoError(t, tracer.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `db_query_duration_seconds_count{query="ListGrades"} 2`)
+	assert.Contains(t, metrics.String(), `db_query_slow_total{query="ListGrades"} 1`)
+	assert.Contains(t, metrics.String(), `db_query_errors_total{query="GetGrade"} 1`)
+}
+
+type tenantKey struct{}
+
+func TestQueryTracer_AttributesQueriesToTenants(t *testing.T) {
+	tracer := database.NewQueryTracer(database.QueryTracerConfig{SlowThreshold: 5 * time.Millisecond, TenantSeries: 2})
+	tracer.AttributeWith(func(ctx context.Context) string {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		return tenant
+	})
+	traceTenantQuery := func(tenant string, sql string, elapsed time.Duration) {
+		ctx := tracer.TraceQueryStart(context.WithValue(context.Background(), tenantKey{}, tenant), nil, pgx.TraceQueryStartData{SQL: sql})
+		time.Sleep(elapsed)
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	}
+
+	traceTenantQuery("school1", "-- name: ListGrades :many\nSELECT 1", 0)
+	traceTenantQuery("school1", "-- name: ExportGrades :many\nSELECT 1", 10*time.Millisecond)
+	traceTenantQuery("", "-- name: ListOutbox :many\nSELECT 1", 0)
+	traceTenantQuery("school2", "-- name: ListGrades :many\nSELECT 1", 0)
+	traceTenantQuery("school3", "-- name: ListGrades :many\nSELECT 1", 0)
+
+	stats := tracer.TenantStats()
+	assert.Len(t, stats, 3, "tenants over the series limit share one")
+	assert.Equal(t, "school1", stats[0].Tenant, "the tenant spending the most time comes first")
+	assert.Equal(t, int64(2), stats[0].Calls)
+	assert.Equal(t, int64(1), stats[0].Slow)
+	assert.Equal(t, "ExportGrades", stats[0].SlowestName)
+
+	var metrics strings.Builder
+	assert.NoError(t, tracer.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `db_tenant_query_duration_seconds_count{tenant="school1"} 2`)
+	assert.Contains(t, metrics.String(), `db_tenant_query_duration_seconds_count{tenant="none"} 1`)
+	assert.Contains(t, metrics.String(), `db_tenant_query_duration_seconds_count{tenant="other"} 2`)
+	assert.Contains(t, metrics.String(), `db_tenant_query_slow_total{tenant="school1"} 1`)
+	assert.Contains(t, metrics.String(), `db_tenant_query_slowest_seconds{tenant="school1",query="ExportGrades"}`)
+}
+
+func TestPositionalSQL(t *testing.T) {
+	sql := database.PositionalSQL(`-- Only open incidents
+SELECT * FROM incidents
+WHERE tenant_id = @tenant_id AND (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status'))
+  AND occurred_at >= @since AND tenant_id <> ''`)
+
+	assert.Contains(t, sql, "WHERE tenant_id = $1 AND ($2::varchar IS NULL OR status = $2)")
+	assert.Contains(t, sql, "occurred_at >= $3")
+	assert.NotContains(t, sql, "Only open incidents")
+}
+
+func TestParseQueryFile(t *testing.T) {
+	queries := database.ParseQueryFile(`-- name: GetGrade :one
+SELECT * FROM grades WHERE id = @id;
+
+-- name: DeleteGrade :exec
+DELETE FROM grades WHERE id = @id;
+`)
+
+	assert.Len(t, queries, 2)
+	assert.Equal(t, database.NamedQuery{Name: "GetGrade", Kind: ":one", SQL: "SELECT * FROM grades WHERE id = @id;"}, queries[0])
+	assert.Equal(t, ":exec", queries[1].Kind)
+}
+
+func TestSequentialScans(t *testing.T) {
+	plan := []byte(`[{"Plan": {"Node Type": "Nested Loop", "Plans": [
+		{"Node Type": "Seq Scan", "Relation Name": "outbox_events_2025_10"},
+		{"Node Type": "Index Scan", "Relation Name": "grades"},
+		{"Node Type": "Seq Scan", "Relation Name": "class_capacities"}
+	]}}]`)
+
+	scans, err := database.SequentialScans(plan, []string{"grades", "outbox_events"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"outbox_events_2025_10"}, scans)
+}
+
+// largeFixtures fill the high-volume tables so the planner prefers an index whenever one fits the query,
+// a sequential scan left on them means the query has no usable index
+var largeFixtures = []string{
+	`INSERT INTO users (id, name, email, password_hash)
+	 SELECT gen_random_uuid(), 'User ' || i, 'user' || i || '@example.com', 'x' FROM generate_series(1, 50000) i`,
+	`INSERT INTO grades (id, tenant_id, class_id, assignment_id, student_id, score, status, recorded_by)
+	 SELECT gen_random_uuid(), 'tenant' || (i % 20), gen_random_uuid(), gen_random_uuid(), 'student-' || i, 90, 'released', 'teacher'
+	 FROM generate_series(1, 50000) i`,
+	`INSERT INTO conversations (id, tenant_id, kind, conversation_key)
+	 SELECT gen_random_uuid(), 'tenant' || (i % 20), 'direct', 'direct:' || i FROM generate_series(1, 5000) i`,
+	`INSERT INTO messages (id, tenant_id, conversation_id, sender_id, body)
+	 SELECT gen_random_uuid(), c.tenant_id, c.id, 'user-' || i, 'Hello' FROM conversations c, generate_series(1, 10) i`,
+	`INSERT INTO outbox_events (event_type, aggregate_id, tenant_id, payload)
+	 SELECT 'grade.recorded', 'grade-' || i, 'tenant' || (i % 20), '{}' FROM generate_series(1, 50000) i`,
+	`INSERT INTO incidents (id, tenant_id, type, severity, summary, reported_by, status, occurred_at)
+	 SELECT gen_random_uuid(), 'tenant' || (i % 20), 'other', 'low', 'Incident', 'teacher', 'open', NOW() FROM generate_series(1, 50000) i`,
+}
+
+var largeTables = []string{"users", "grades", "conversations", "messages", "outbox_events", "incidents"}
+
+// TestQueriesUseIndexesOnLargeTables explains every read query of the sqlc query files against the large
+// fixtures. It needs a migrated Postgres 16 or later in TEST_DATABASE_URL, fixtures are rolled back.
+func TestQueriesUseIndexesOnLargeTables(t *testing.T) {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, databaseURL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.Begin(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	for _, fixture := range largeFixtures {
+		if _, err := tx.Exec(ctx, fixture); !assert.NoError(t, err) {
+			return
+		}
+	}
+	if _, err := tx.Exec(ctx, "ANALYZE"); !assert.NoError(t, err) {
+		return
+	}
+
+	files, err := filepath.Glob(filepath.Join(moduleRoot(t), "infra", "*", "sql", "queries", "*.sql"))
+	assert.NoError(t, err)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		assert.NoError(t, err)
+
+		for _, query := range database.ParseQueryFile(string(content)) {
+			if query.Kind != ":one" && query.Kind != ":many" {
+				continue
+			}
+
+			// Each explain runs in a savepoint, a query the planner cannot type leaves the transaction usable
+			savepoint, err := tx.Begin(ctx)
+			assert.NoError(t, err)
+			var plan []byte
+			err = savepoint.QueryRow(ctx, "EXPLAIN (GENERIC_PLAN, FORMAT JSON) "+database.PositionalSQL(query.SQL)).Scan(&plan)
+			savepoint.Rollback(ctx)
+			if err != nil {
+				t.Logf("Skipped %s: %v", query.Name, err)
+				continue
+			}
+
+			scans, err := database.SequentialScans(plan, largeTables)
+			assert.NoError(t, err)
+			assert.Empty(t, scans, "%s in %s scans large tables without an index", query.Name, filepath.Base(file))
+		}
+	}
+}
+
+func moduleRoot(t *testing.T) string {
+	t.Helper()
+	dir, err := os.Getwd()
+	assert.NoError(t, err)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			t.Fatal("go.mod not found")
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryModeConfig_Apply(t *testing.T) {
+	connConfig, err := pgx.ParseConfig("postgres://localhost/class?default_query_exec_mode=exec&statement_cache_capacity=64")
+	assert.NoError(t, err)
+
+	err = database.QueryModeConfig{ExecMode: "", StatementCacheCapacity: -1, DescriptionCacheCapacity: 128}.Apply(connConfig)
+
+	assert.NoError(t, err)
+	assert.Equal(t, pgx.QueryExecModeExec, connConfig.DefaultQueryExecMode, "an empty mode keeps the one of the URL")
+	assert.Equal(t, 64, connConfig.StatementCacheCapacity)
+	assert.Equal(t, 128, connConfig.DescriptionCacheCapacity)
+	assert.False(t, database.Describes(connConfig.DefaultQueryExecMode))
+
+	assert.NoError(t, database.QueryModeConfig{ExecMode: "cache_describe", StatementCacheCapacity: 0, DescriptionCacheCapacity: -1}.Apply(connConfig))
+	assert.Equal(t, pgx.QueryExecModeCacheDescribe, connConfig.DefaultQueryExecMode)
+	assert.True(t, database.Describes(connConfig.DefaultQueryExecMode))
+
+	assert.Error(t, database.QueryModeConfig{ExecMode: "cache_statement", StatementCacheCapacity: 0, DescriptionCacheCapacity: -1}.Apply(connConfig))
+	assert.Error(t, database.QueryModeConfig{ExecMode: "prepared", StatementCacheCapacity: -1, DescriptionCacheCapacity: -1}.Apply(connConfig))
+}
+
+// BenchmarkQueryExecModes runs a read shaped like the sqlc queries and a query taking a jsonb []byte in
+// every exec mode, against Postgres in BENCH_DATABASE_URL and PgBouncer in transaction mode in
+// BENCH_PGBOUNCER_URL, each when set. Modes a server refuses are skipped with the error.
+func BenchmarkQueryExecModes(b *testing.B) {
+	targets := map[string]string{
+		"postgres":  os.Getenv("BENCH_DATABASE_URL"),
+		"pgbouncer": os.Getenv("BENCH_PGBOUNCER_URL"),
+	}
+	if targets["postgres"] == "" && targets["pgbouncer"] == "" {
+		b.Skip("BENCH_DATABASE_URL and BENCH_PGBOUNCER_URL are not set")
+	}
+
+	modes := make([]string, 0, len(database.QueryExecModes))
+	for mode := range database.QueryExecModes {
+		modes = append(modes, mode)
+	}
+	slices.Sort(modes)
+
+	for _, target := range []string{"postgres", "pgbouncer"} {
+		if targets[target] == "" {
+			continue
+		}
+		for _, mode := range modes {
+			b.Run(target+"/"+mode, func(b *testing.B) {
+				benchmarkQueryExecMode(b, targets[target], mode)
+			})
+		}
+	}
+}
+
+func benchmarkQueryExecMode(b *testing.B, databaseURL string, mode string) {
+	ctx := context.Background()
+	poolConfig, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		b.Fatal(err)
+	}
+	poolConfig.MaxConns = 8
+	if err := (database.QueryModeConfig{ExecMode: mode, StatementCacheCapacity: -1, DescriptionCacheCapacity: -1}).Apply(poolConfig.ConnConfig); err != nil {
+		b.Fatal(err)
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer pool.Close()
+
+	const read = "-- name: ListTables :many\nSELECT relname, reltuples FROM pg_class WHERE relnamespace = $1::regnamespace AND relkind = $2 ORDER BY relname LIMIT $3"
+	const jsonbParam = "-- name: EchoPayload :one\nSELECT $1::jsonb ->> 'kind'"
+	if _, err := pool.Exec(ctx, read, "pg_catalog", "r", 20); err != nil {
+		b.Skipf("read refused: %v", err)
+	}
+
+	b.Run("read", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				rows, err := pool.Query(ctx, read, "pg_catalog", "r", 20)
+				if err == nil {
+					_, err = pgx.CollectRows(rows, pgx.RowToMap)
+				}
+				if err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+
+	b.Run("jsonb", func(b *testing.B) {
+		var kind string
+		if err := pool.QueryRow(ctx, jsonbParam, []byte(`{"kind":"report"}`)).Scan(&kind); err != nil {
+			b.Skipf("jsonb from []byte refused: %v", strings.SplitN(err.Error(), "\n", 2)[0])
+		}
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if err := pool.QueryRow(ctx, jsonbParam, []byte(`{"kind":"report"}`)).Scan(&kind); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyQuerier fails the first failures queries with err, then returns one row
+type flakyQuerier struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (q *flakyQuerier) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	q.calls++
+	return pgconn.CommandTag{}, q.fail()
+}
+
+func (q *flakyQuerier) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	q.calls++
+	return &fakeRows{err: q.fail(), rows: 1}, nil
+}
+
+func (q *flakyQuerier) QueryRow(context.Context, string, ...any) pgx.Row {
+	q.calls++
+	return fakeRow{err: q.fail()}
+}
+
+func (q *flakyQuerier) fail() error {
+	if q.calls <= q.failures {
+		return q.err
+	}
+	return nil
+}
+
+type fakeRow struct{ err error }
+
+func (r fakeRow) Scan(...any) error { return r.err }
+
+// fakeRows fails on the first Next with err, or yields rows rows
+type fakeRows struct {
+	pgx.Rows
+	err  error
+	rows int
+}
+
+func (r *fakeRows) Next() bool {
+	if r.err != nil || r.rows == 0 {
+		return false
+	}
+	r.rows--
+	return true
+}
+
+func (r *fakeRows) Err() error { return r.err }
+
+func (r *fakeRows) Close() {}
+
+var failover = &pgconn.PgError{Code: "57P01", Message: "terminating connection due to administrator command"}
+
+func TestTransient(t *testing.T) {
+	assert.True(t, database.Transient(failover))
+	assert.True(t, database.Transient(&pgconn.PgError{Code: "08006"}))
+	assert.True(t, database.Transient(&pgconn.PgError{Code: "25006"}), "the old primary was demoted")
+	assert.True(t, database.Transient(io.ErrUnexpectedEOF))
+
+	assert.False(t, database.Transient(nil))
+	assert.False(t, database.Transient(&pgconn.PgError{Code: "23505"}), "a unique violation is not retried")
+	assert.False(t, database.Transient(pgx.ErrNoRows))
+	assert.False(t, database.Transient(context.DeadlineExceeded))
+}
+
+func TestReadOnly(t *testing.T) {
+	assert.True(t, database.ReadOnly("-- name: GetGrade :one\nSELECT id, updated_at FROM grades WHERE id = $1"))
+	assert.True(t, database.ReadOnly("WITH recent AS (SELECT * FROM messages) SELECT count(*) FROM recent"))
+
+	assert.False(t, database.ReadOnly("-- name: CreateGrade :one\nINSERT INTO grades (id) VALUES ($1) RETURNING *"))
+	assert.False(t, database.ReadOnly("WITH moved AS (DELETE FROM outbox_events RETURNING *) SELECT count(*) FROM moved"))
+	assert.False(t, database.ReadOnly("SELECT * FROM member_deactivations WHERE status = 'pending' FOR UPDATE SKIP LOCKED"))
+	assert.False(t, database.ReadOnly("SELECT nextval('invoice_numbers')"))
+}
+
+func TestReadRetry_QueryRow(t *testing.T) {
+	retry := database.NewReadRetry(database.ReadRetryConfig{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	ctx := context.Background()
+
+	db := &flakyQuerier{failures: 2, err: failover}
+	assert.NoError(t, retry.Wrap(db).QueryRow(ctx, "SELECT 1").Scan())
+	assert.Equal(t, 3, db.calls)
+
+	db = &flakyQuerier{failures: 3, err: failover}
+	assert.ErrorIs(t, retry.Wrap(db).QueryRow(ctx, "SELECT 1").Scan(), failover)
+	assert.Equal(t, 3, db.calls, "the attempts are bounded")
+
+	db = &flakyQuerier{failures: 1, err: failover}
+	assert.ErrorIs(t, retry.Wrap(db).QueryRow(ctx, "INSERT INTO grades (id) VALUES ($1) RETURNING id").Scan(), failover)
+	assert.Equal(t, 1, db.calls, "writes run once")
+
+	db = &flakyQuerier{failures: 1, err: pgx.ErrNoRows}
+	assert.ErrorIs(t, retry.Wrap(db).QueryRow(ctx, "SELECT 1").Scan(), pgx.ErrNoRows)
+	assert.Equal(t, 1, db.calls, "only connection errors are retried")
+
+	var metrics bytes.Buffer
+	assert.NoError(t, retry.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), "db_read_retries_total 4\n")
+	assert.Contains(t, metrics.String(), "db_read_retries_recovered_total 1\n")
+	assert.Contains(t, metrics.String(), "db_read_retries_exhausted_total 1\n")
+}
+
+func TestReadRetry_Query(t *testing.T) {
+	retry := database.NewReadRetry(database.ReadRetryConfig{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	ctx := context.Background()
+
+	// The connection fails before the first row, the caller gets the row of the retry
+	db := &flakyQuerier{failures: 1, err: failover}
+	rows, err := retry.Wrap(db).Query(ctx, "SELECT id FROM grades")
+	assert.NoError(t, err)
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 2, db.calls)
+
+	db = &flakyQuerier{failures: 5, err: failover}
+	rows, err = retry.Wrap(db).Query(ctx, "SELECT id FROM grades")
+	assert.NoError(t, err)
+	assert.False(t, rows.Next())
+	assert.True(t, errors.Is(rows.Err(), failover))
+	assert.Equal(t, 3, db.calls)
+}
+
+func TestReadRetry_StopsWithContext(t *testing.T) {
+	retry := database.NewReadRetry(database.ReadRetryConfig{Attempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	db := &flakyQuerier{failures: 5, err: failover}
+	assert.ErrorIs(t, retry.Wrap(db).QueryRow(ctx, "SELECT 1").Scan(), failover)
+	assert.Equal(t, 1, db.calls)
+}
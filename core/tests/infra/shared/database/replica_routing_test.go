@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// walQuerier answers the WAL position queries with position and counts the other statements
+type walQuerier struct {
+	position string
+	reads    int
+}
+
+func (q *walQuerier) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	q.reads++
+	return pgconn.CommandTag{}, nil
+}
+
+func (q *walQuerier) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	q.reads++
+	return &fakeRows{}, nil
+}
+
+func (q *walQuerier) QueryRow(_ context.Context, sql string, _ ...any) pgx.Row {
+	if strings.Contains(sql, "_wal_") {
+		return positionRow{position: q.position}
+	}
+	q.reads++
+	return fakeRow{}
+}
+
+type positionRow struct{ position string }
+
+func (r positionRow) Scan(dest ...any) error {
+	switch dest := dest[0].(type) {
+	case **string:
+		position := r.position
+		*dest = &position
+	case *string:
+		*dest = r.position
+	}
+	return nil
+}
+
+func TestParseLSN(t *testing.T) {
+	lsn, err := database.ParseLSN("16/B374D848")
+	assert.NoError(t, err)
+	assert.Equal(t, database.LSN(0x16B374D848), lsn)
+	assert.Equal(t, "16/B374D848", lsn.String())
+
+	for _, invalid := range []string{"", "16B374D848", "16/", "X/1", "1/100000000"} {
+		_, err := database.ParseLSN(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestConsistencyTokens(t *testing.T) {
+	tokens := database.NewConsistencyTokens([]byte("key"), time.Minute)
+	now := time.Now()
+
+	token := tokens.Issue(0x16B374D848, now)
+	lsn, err := tokens.Read(token, now.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, database.LSN(0x16B374D848), lsn)
+
+	_, err = tokens.Read(token, now.Add(2*time.Minute))
+	assert.ErrorContains(t, err, "expired")
+
+	_, err = database.NewConsistencyTokens([]byte("other key"), time.Minute).Read(token, now)
+	assert.ErrorContains(t, err, "signature", "tokens of another key are refused")
+
+	forged := database.NewConsistencyTokens([]byte("other key"), time.Minute).Issue(0xFFFFFFFF, now)
+	_, err = tokens.Read(strings.Split(forged, ".")[0]+"."+strings.Split(token, ".")[1], now)
+	assert.Error(t, err, "the position cannot be changed")
+
+	_, err = tokens.Read("garbage", now)
+	assert.Error(t, err)
+}
+
+func TestReplicaRouter_RoutesReadsOfMarkedRequests(t *testing.T) {
+	primary := &walQuerier{position: "0/300"}
+	replica := &walQuerier{position: "0/200"}
+	router := database.NewReplicaRouter(primary, replica, database.NewConsistencyTokens([]byte("key"), time.Minute),
+		database.ReplicaConfig{PollInterval: time.Minute, TokenMaxAge: time.Minute})
+	reads := database.NewReadRetry(database.DefaultReadRetryConfig)
+	reads.Route(router)
+	db := reads.Wrap(primary)
+
+	marked := database.WithReplicaReads(context.Background(), 0)
+	assert.NoError(t, db.QueryRow(marked, "SELECT 1").Scan())
+	assert.Equal(t, 1, primary.reads, "the replica is not used before its position is known")
+
+	assert.NoError(t, router.Poll(context.Background()))
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		sql     string
+		replica bool
+	}{
+		{"request without a token", marked, "SELECT 1", true},
+		{"replica caught up with the token", database.WithReplicaReads(context.Background(), 0x200), "SELECT 1", true},
+		{"replica behind the token", database.WithReplicaReads(context.Background(), 0x201), "SELECT 1", false},
+		{"write", marked, "UPDATE grades SET score = 1", false},
+		{"locking read", marked, "SELECT * FROM grades FOR UPDATE", false},
+		{"request not marked", context.Background(), "SELECT 1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary.reads, replica.reads = 0, 0
+			assert.NoError(t, db.QueryRow(tt.ctx, tt.sql).Scan())
+			rows, err := db.Query(tt.ctx, tt.sql)
+			assert.NoError(t, err)
+			rows.Next()
+			if tt.replica {
+				assert.Equal(t, 2, replica.reads)
+				assert.Zero(t, primary.reads)
+			} else {
+				assert.Equal(t, 2, primary.reads)
+				assert.Zero(t, replica.reads)
+			}
+		})
+	}
+
+	other := &walQuerier{}
+	assert.NoError(t, reads.Wrap(other).QueryRow(marked, "SELECT 1").Scan())
+	assert.Equal(t, 1, other.reads, "pools other than the primary of the router keep their reads")
+}
+
+func TestReplicaRouter_Middleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	primary := &walQuerier{position: "0/300"}
+	replica := &walQuerier{position: "0/300"}
+	tokens := database.NewConsistencyTokens([]byte("key"), time.Minute)
+	router := database.NewReplicaRouter(primary, replica, tokens,
+		database.ReplicaConfig{PollInterval: time.Minute, TokenMaxAge: time.Minute})
+	assert.NoError(t, router.Poll(context.Background()))
+	reads := database.NewReadRetry(database.DefaultReadRetryConfig)
+	reads.Route(router)
+	db := reads.Wrap(primary)
+
+	engine := gin.New()
+	engine.Use(router.Middleware)
+	engine.POST("/classes", func(c *gin.Context) { c.JSON(http.StatusCreated, gin.H{"id": "class1"}) })
+	engine.POST("/invalid", func(c *gin.Context) { c.JSON(http.StatusBadRequest, gin.H{"error": "invalid"}) })
+	engine.DELETE("/classes", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	engine.GET("/classes", func(c *gin.Context) {
+		db.QueryRow(c.Request.Context(), "SELECT 1").Scan()
+		c.Status(http.StatusOK)
+	})
+	serve := func(method, path, token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			request.Header.Set(database.ConsistencyTokenHeader, token)
+		}
+		response := httptest.NewRecorder()
+		engine.ServeHTTP(response, request)
+		return response
+	}
+
+	token := serve(http.MethodPost, "/classes", "").Header().Get(database.ConsistencyTokenHeader)
+	lsn, err := tokens.Read(token, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, database.LSN(0x300), lsn, "the token carries the position of the primary")
+
+	assert.NotEmpty(t, serve(http.MethodDelete, "/classes", "").Header().Get(database.ConsistencyTokenHeader), "responses without a body get one")
+	assert.Empty(t, serve(http.MethodPost, "/invalid", "").Header().Get(database.ConsistencyTokenHeader), "failed requests wrote nothing")
+
+	serve(http.MethodGet, "/classes", token)
+	assert.Equal(t, 1, replica.reads, "the replica replayed the write of the token")
+
+	primary.position = "0/400"
+	token = serve(http.MethodPost, "/classes", "").Header().Get(database.ConsistencyTokenHeader)
+	serve(http.MethodGet, "/classes", token)
+	assert.Equal(t, 1, primary.reads, "the replica is behind the write of the token")
+	assert.Empty(t, serve(http.MethodGet, "/classes", token).Header().Get(database.ConsistencyTokenHeader))
+}
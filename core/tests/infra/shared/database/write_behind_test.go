@@ -0,0 +1,105 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingSave keeps the batches it saves, failing while err is set
+type recordingSave struct {
+	mu      sync.Mutex
+	batches [][]int
+	err     error
+}
+
+func (s *recordingSave) save(_ context.Context, writes []int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	batch := slices.Clone(writes)
+	slices.Sort(batch)
+	s.batches = append(s.batches, batch)
+	return nil
+}
+
+func (s *recordingSave) saved() [][]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.batches
+}
+
+func TestWriteBehind_MergesAndBatches(t *testing.T) {
+	saves := &recordingSave{}
+	writes := database.NewWriteBehind[string]("counters", database.WriteBehindConfig{
+		FlushInterval: time.Hour, BatchSize: 2, MaxPending: 10, Attempts: 1,
+	}, func(pending int, write int) int { return pending + write }, saves.save)
+
+	assert.True(t, writes.Write("a", 1))
+	assert.True(t, writes.Write("a", 2))
+	assert.True(t, writes.Write("b", 5))
+	assert.True(t, writes.Write("c", 7))
+	assert.ElementsMatch(t, []int{3}, writes.Pending(func(key string) bool { return key == "a" }))
+
+	writes.Flush(context.Background())
+
+	var saved []int
+	for _, batch := range saves.saved() {
+		assert.LessOrEqual(t, len(batch), 2)
+		saved = append(saved, batch...)
+	}
+	assert.ElementsMatch(t, []int{3, 5, 7}, saved, "writes of the same key are saved once")
+	assert.Empty(t, writes.Pending(func(string) bool { return true }))
+}
+
+func TestWriteBehind_LossTolerance(t *testing.T) {
+	saves := &recordingSave{err: errors.New("database is down")}
+	writes := database.NewWriteBehind[string]("receipts", database.WriteBehindConfig{
+		FlushInterval: time.Hour, BatchSize: 10, MaxPending: 2, Attempts: 2,
+	}, func(_ int, write int) int { return write }, saves.save)
+
+	assert.True(t, writes.Write("a", 1))
+	assert.True(t, writes.Write("b", 2))
+	assert.False(t, writes.Write("c", 3), "writes over MaxPending are dropped")
+
+	// The first failure keeps the writes, the second one drops them
+	writes.Flush(context.Background())
+	assert.Len(t, writes.Pending(func(string) bool { return true }), 2)
+	writes.Flush(context.Background())
+	assert.Empty(t, writes.Pending(func(string) bool { return true }))
+
+	var metrics bytes.Buffer
+	assert.NoError(t, writes.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), "write_behind_dropped_total{writes=\"receipts\"} 3\n")
+	assert.Contains(t, metrics.String(), "write_behind_failed_flushes_total{writes=\"receipts\"} 2\n")
+}
+
+func TestWriteBehind_FlushesOnShutdown(t *testing.T) {
+	saves := &recordingSave{}
+	writes := database.NewWriteBehind[string]("receipts", database.WriteBehindConfig{
+		FlushInterval: time.Hour, BatchSize: 10, MaxPending: 10, Attempts: 1,
+	}, func(_ int, write int) int { return write }, saves.save)
+	writes.Write("a", 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		writes.Run(ctx)
+		close(done)
+	}()
+	cancel()
+	<-done
+
+	assert.Equal(t, [][]int{{1}}, saves.saved())
+}
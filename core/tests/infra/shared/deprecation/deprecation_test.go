@@ -0,0 +1,81 @@
+package deprecation
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/deprecation"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	since  = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	sunset = time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func newTrackedAPI(t *testing.T, tracker *deprecation.Tracker) humatest.TestAPI {
+	_, api := humatest.New(t)
+	api.OpenAPI().OnAddOperation = append(api.OpenAPI().OnAddOperation, tracker.DocumentOperation)
+	api.UseMiddleware(tracker.Middleware)
+
+	for _, operationID := range []string{"old-items", "items"} {
+		huma.Register(api, huma.Operation{
+			OperationID: operationID,
+			Method:      http.MethodGet,
+			Path:        "/" + operationID,
+		}, func(ctx context.Context, input *struct{}) (*struct{}, error) {
+			return nil, nil
+		})
+	}
+	return api
+}
+
+func TestTracker_AnnouncesDeprecationOnResponses(t *testing.T) {
+	tracker := deprecation.NewTracker(map[string]deprecation.Notice{
+		"old-items": {Since: since, Sunset: sunset, Successor: "/items"},
+	}, time.Hour)
+	api := newTrackedAPI(t, tracker)
+
+	resp := api.Get("/old-items", authorization.UserIDHeader+": user-1")
+	assert.Equal(t, "@1772323200", resp.Header().Get("Deprecation"))
+	assert.Equal(t, "Tue, 01 Sep 2026 00:00:00 GMT", resp.Header().Get("Sunset"))
+	assert.Equal(t, `</items>; rel="successor-version"`, resp.Header().Get("Link"))
+
+	resp = api.Get("/items")
+	assert.Empty(t, resp.Header().Get("Deprecation"))
+}
+
+func TestTracker_MarksOperationsDeprecatedInTheSpec(t *testing.T) {
+	tracker := deprecation.NewTracker(map[string]deprecation.Notice{"old-items": {Since: since}}, time.Hour)
+	api := newTrackedAPI(t, tracker)
+
+	operation := api.OpenAPI().Paths["/old-items"].Get
+	assert.True(t, operation.Deprecated)
+	assert.Equal(t, "Deprecated since 2026-03-01.", operation.Description)
+	assert.False(t, api.OpenAPI().Paths["/items"].Get.Deprecated)
+}
+
+func TestTracker_CountsCallsPerEndpoint(t *testing.T) {
+	tracker := deprecation.NewTracker(map[string]deprecation.Notice{
+		"old-items":  {Since: since},
+		"older-item": {Since: since},
+	}, time.Hour)
+	api := newTrackedAPI(t, tracker)
+
+	api.Get("/old-items")
+	api.Get("/old-items")
+
+	var metrics bytes.Buffer
+	assert.NoError(t, tracker.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), "deprecated_endpoint_requests_total{operation=\"old-items\"} 2\n")
+	assert.Contains(t, metrics.String(), "deprecated_endpoint_requests_total{operation=\"older-item\"} 0\n")
+	assert.Contains(t, metrics.String(), "deprecated_endpoint_last_request_timestamp_seconds{operation=\"old-items\"}")
+	assert.NotContains(t, metrics.String(), "deprecated_endpoint_last_request_timestamp_seconds{operation=\"older-item\"}")
+}
@@ -0,0 +1,112 @@
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/diagnostics"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/cockroachdb/errors"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRecordedAPI(t *testing.T, recorder *diagnostics.ErrorRecorder) humatest.TestAPI {
+	config := huma.DefaultConfig("Test", "1.0.0")
+	config.Transformers = append(config.Transformers, recorder.Transformer)
+	_, api := humatest.New(t, config)
+
+	huma.Register(api, huma.Operation{OperationID: "get-report", Method: http.MethodGet, Path: "/reports/{id}"},
+		func(ctx context.Context, input *struct {
+			ID string `path:"id"`
+		}) (*struct{}, error) {
+			if input.ID == "missing" {
+				return nil, utils.ApplicationErrorToHumaError(appErrors.NewValidationError("Invalid report", nil, nil))
+			}
+			cause := errors.Wrap(errors.New("connection reset by peer"), "reading report")
+			return nil, utils.ApplicationErrorToHumaError(appErrors.PropagateError(cause))
+		})
+	return api
+}
+
+func TestErrorRecorder_RecordsServerErrorChains(t *testing.T) {
+	recorder := diagnostics.NewErrorRecorder(10)
+	api := newRecordedAPI(t, recorder)
+
+	resp := api.Get("/reports/r-1", "X-Tenant-Id: tenant-a")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	api.Get("/reports/missing")
+
+	recent := recorder.Recent(0)
+	assert.Len(t, recent, 1)
+	entry := recent[0]
+	assert.Equal(t, "get-report", entry.OperationID)
+	assert.Equal(t, "/reports/r-1", entry.Path)
+	assert.Equal(t, "tenant-a", entry.TenantID)
+	assert.Equal(t, http.StatusInternalServerError, entry.Status)
+
+	assert.Equal(t, appErrors.InternalError.String(), entry.Chain[0].Code)
+	root := entry.Chain[len(entry.Chain)-1]
+	assert.Equal(t, "connection reset by peer", root.Message)
+	if assert.NotEmpty(t, root.Frames) {
+		assert.Contains(t, root.Frames[0].Function, "newRecordedAPI")
+	}
+}
+
+func TestErrorRecorder_KeepsNewestEntries(t *testing.T) {
+	recorder := diagnostics.NewErrorRecorder(2)
+	for _, path := range []string{"/a", "/b", "/c"} {
+		recorder.Record(diagnostics.RecordedError{Path: path})
+	}
+
+	recent := recorder.Recent(0)
+	assert.Equal(t, []string{"/c", "/b"}, []string{recent[0].Path, recent[1].Path})
+	assert.Len(t, recorder.Recent(1), 1)
+}
+
+func TestErrorRecorder_HandlerRequiresToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := diagnostics.NewErrorRecorder(2)
+	recorder.Record(diagnostics.RecordedError{Path: "/a", Status: http.StatusInternalServerError})
+	router := gin.New()
+	router.GET("/debug/errors/recent", recorder.Handler("s3cret"))
+
+	for token, status := range map[string]int{"": http.StatusUnauthorized, "wrong": http.StatusUnauthorized, "s3cret": http.StatusOK} {
+		req := httptest.NewRequest(http.MethodGet, "/debug/errors/recent", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, status, resp.Code, token)
+
+		if status == http.StatusOK {
+			var body struct {
+				Errors []diagnostics.RecordedError `json:"errors"`
+			}
+			assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+			assert.Len(t, body.Errors, 1)
+		}
+	}
+}
+
+func TestRequireToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	group := router.Group("/debug/authz", diagnostics.RequireToken("s3cret"))
+	group.GET("", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for header, status := range map[string]int{"": http.StatusUnauthorized, "Bearer wrong": http.StatusUnauthorized, "s3cret": http.StatusUnauthorized,
+		"Bearer s3cret": http.StatusOK} {
+		req := httptest.NewRequest(http.MethodGet, "/debug/authz", nil)
+		req.Header.Set("Authorization", header)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, status, resp.Code, header)
+	}
+}
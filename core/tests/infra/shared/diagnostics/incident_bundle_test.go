@@ -0,0 +1,148 @@
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/diagnostics"
+	"github.com/nahualventure/class-backend/infra/shared/slo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryBundleStore struct {
+	bundles map[string][]byte
+	err     error
+}
+
+func (s *memoryBundleStore) Put(_ context.Context, key string, _ string, content []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.bundles[key] = content
+	return nil
+}
+
+func reportWithAlerts(alerts map[string]slo.Severity) slo.Report {
+	report := slo.Report{Status: slo.StatusOK}
+	for _, name := range []string{"read-availability", "read-latency"} {
+		objective := slo.ObjectiveStatus{Name: name, Kind: slo.KindAvailability}
+		if name == "read-latency" {
+			objective.Kind = slo.KindLatency
+		}
+		if severity, firing := alerts[name]; firing {
+			objective.Alerts = []slo.FiringAlert{{Severity: severity}}
+			if severity == slo.SeverityPage {
+				report.Status = slo.StatusBurning
+			}
+		}
+		report.Objectives = append(report.Objectives, objective)
+	}
+	return report
+}
+
+func TestIncidentBundler_StoresABundleWhenAnAvailabilityPageAlertStarts(t *testing.T) {
+	store := &memoryBundleStore{bundles: map[string][]byte{}}
+	recorder := diagnostics.NewErrorRecorder(10)
+	sources := diagnostics.BundleSources{
+		Instance: "api-7f9c",
+		Errors:   recorder,
+		Pools:    func() any { return map[string]int{"acquired_conns": 3} },
+		Authorization: func(ctx context.Context) (any, error) {
+			return map[string]string{"policy_hash": "abc123"}, nil
+		},
+		Config: map[string]any{"DatabaseURL": "postgres://app:[REDACTED]@db/class"},
+	}
+	bundler := diagnostics.NewIncidentBundler(sources, store, func(key string) string { return "s3://incidents/" + key }, "")
+	now := time.Date(2025, 12, 14, 9, 30, 15, 0, time.UTC)
+
+	assert.Nil(t, bundler.Check(context.Background(), reportWithAlerts(map[string]slo.Severity{"read-latency": slo.SeverityPage}), now))
+	assert.Nil(t, bundler.Check(context.Background(), reportWithAlerts(map[string]slo.Severity{"read-availability": slo.SeverityTicket}), now))
+	assert.Empty(t, store.bundles)
+
+	alert := bundler.Check(context.Background(), reportWithAlerts(map[string]slo.Severity{"read-availability": slo.SeverityPage}), now)
+	if assert.NotNil(t, alert) {
+		assert.Equal(t, "s3://incidents/incidents/2025-12-14/093015-api-7f9c.json", alert.BundleURL)
+		assert.Equal(t, []string{"read-availability"}, alert.Objectives)
+		assert.Equal(t, slo.StatusBurning, alert.Status)
+	}
+
+	var bundle diagnostics.Bundle
+	assert.NoError(t, json.Unmarshal(store.bundles["incidents/2025-12-14/093015-api-7f9c.json"], &bundle))
+	assert.Equal(t, "api-7f9c", bundle.Instance)
+	assert.Contains(t, bundle.GoroutineDump, "goroutine")
+	assert.Equal(t, map[string]any{"acquired_conns": 3.0}, bundle.Pools)
+	assert.Equal(t, map[string]any{"policy_hash": "abc123"}, bundle.Authorization)
+	assert.Equal(t, "postgres://app:[REDACTED]@db/class", bundle.Config["DatabaseURL"])
+
+	// The alert keeps firing, then fires again within the cooldown
+	assert.Nil(t, bundler.Check(context.Background(), reportWithAlerts(map[string]slo.Severity{"read-availability": slo.SeverityPage}), now.Add(time.Minute)))
+	assert.Nil(t, bundler.Check(context.Background(), reportWithAlerts(nil), now.Add(2*time.Minute)))
+	assert.Nil(t, bundler.Check(context.Background(), reportWithAlerts(map[string]slo.Severity{"read-availability": slo.SeverityPage}), now.Add(3*time.Minute)))
+	assert.Len(t, store.bundles, 1)
+
+	assert.Nil(t, bundler.Check(context.Background(), reportWithAlerts(nil), now.Add(diagnostics.BundleCooldown)))
+	assert.NotNil(t, bundler.Check(context.Background(), reportWithAlerts(map[string]slo.Severity{"read-availability": slo.SeverityPage}), now.Add(diagnostics.BundleCooldown+time.Minute)))
+	assert.Len(t, store.bundles, 2)
+}
+
+func TestIncidentBundler_PostsTheAlertWithTheBundleOrWhyItWasNotStored(t *testing.T) {
+	var posted []diagnostics.IncidentAlert
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert diagnostics.IncidentAlert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		posted = append(posted, alert)
+	}))
+	defer webhook.Close()
+
+	store := &memoryBundleStore{bundles: map[string][]byte{}, err: errors.New("bucket unreachable")}
+	bundler := diagnostics.NewIncidentBundler(diagnostics.BundleSources{}, store, func(key string) string { return key }, webhook.URL)
+
+	alert := bundler.Check(context.Background(), reportWithAlerts(map[string]slo.Severity{"read-availability": slo.SeverityPage}), time.Now())
+	if assert.NotNil(t, alert) {
+		assert.Empty(t, alert.BundleURL)
+		assert.Equal(t, "bucket unreachable", alert.BundleError)
+	}
+	if assert.Len(t, posted, 1) {
+		assert.Equal(t, "error_rate_spike", posted[0].Alert)
+		assert.Equal(t, "bucket unreachable", posted[0].BundleError)
+	}
+}
+
+func TestRedactConfig(t *testing.T) {
+	type storage struct {
+		Bucket          string
+		SecretAccessKey string
+	}
+	config := struct {
+		DatabaseURL   string
+		RedisPassword string
+		CursorKey     string
+		Timeout       time.Duration
+		Storage       storage
+		Tenants       []string
+		unexported    string
+	}{
+		DatabaseURL:   "postgres://app:hunter2@db:5432/class?sslmode=require",
+		RedisPassword: "",
+		CursorKey:     "signing-key",
+		Timeout:       30 * time.Second,
+		Storage:       storage{Bucket: "exports", SecretAccessKey: "s3-secret"},
+		Tenants:       []string{"north"},
+		unexported:    "hidden",
+	}
+
+	assert.Equal(t, map[string]any{
+		"DatabaseURL":   "postgres://app:[REDACTED]@db:5432/class?sslmode=require",
+		"RedisPassword": "",
+		"CursorKey":     diagnostics.Redacted,
+		"Timeout":       "30s",
+		"Storage":       map[string]any{"Bucket": "exports", "SecretAccessKey": diagnostics.Redacted},
+		"Tenants":       []any{"north"},
+	}, diagnostics.RedactConfig(&config))
+}
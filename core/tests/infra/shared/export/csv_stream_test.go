@@ -0,0 +1,113 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+)
+
+const utf8BOM = "\uFEFF"
+
+// serve registers an export of values split in pages of pageSize and returns the response to it
+func serve(t *testing.T, filename string, values []string, pageSize int) *http.Response {
+	_, api := humatest.New(t)
+
+	pageAt := func(start int) *pagination.Page[string] {
+		end := min(start+pageSize, len(values))
+		page := &pagination.Page[string]{Items: values[start:end]}
+		if end < len(values) {
+			page.NextCursor = strconv.Itoa(end)
+		}
+		return page
+	}
+	huma.Register(api, huma.Operation{
+		OperationID: "export",
+		Method:      http.MethodGet,
+		Path:        "/export",
+	}, func(ctx context.Context, input *struct{}) (*huma.StreamResponse, error) {
+		return export.CSVStream[string]{
+			Filename:  filename,
+			Header:    []string{"length", "value"},
+			ToRow:     func(value string) []string { return []string{strconv.Itoa(len(value)), value} },
+			FirstPage: pageAt(0),
+			NextPage: func(cursor string) (*pagination.Page[string], error) {
+				start, err := strconv.Atoi(cursor)
+				if err != nil {
+					return nil, err
+				}
+				return pageAt(start), nil
+			},
+		}.Response(), nil
+	})
+
+	return api.Get("/export").Result()
+}
+
+func readRows(t *testing.T, resp *http.Response) [][]string {
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(body), utf8BOM), "the file starts with a BOM")
+
+	rows, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(body), utf8BOM))).ReadAll()
+	assert.NoError(t, err)
+	return rows
+}
+
+func TestCSVStream_WritesTheHeaderAndEveryPage(t *testing.T) {
+	values := make([]string, 25)
+	for i := range values {
+		values[i] = "value " + strconv.Itoa(i)
+	}
+
+	resp := serve(t, "users.csv", values, 10)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+	rows := readRows(t, resp)
+	if assert.Len(t, rows, 26, "the header and one row per value") {
+		assert.Equal(t, []string{"length", "value"}, rows[0])
+		assert.Equal(t, "value 0", rows[1][1])
+		assert.Equal(t, "value 24", rows[25][1])
+	}
+}
+
+func TestCSVStream_NeutralizesFormulas(t *testing.T) {
+	values := []string{"=HYPERLINK(\"http://x\")", "+1", "-1", "@SUM(A1)", "\tcell", "\rcell", "plain", "a=b", ""}
+
+	rows := readRows(t, serve(t, "values.csv", values, 4))
+
+	expected := []string{"'=HYPERLINK(\"http://x\")", "'+1", "'-1", "'@SUM(A1)", "'\tcell", "'\rcell", "plain", "a=b", ""}
+	if assert.Len(t, rows, len(values)+1) {
+		for i, value := range expected {
+			assert.Equal(t, value, rows[i+1][1], values[i])
+		}
+	}
+}
+
+func TestCSVStream_QuotesTheFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		expected string
+	}{
+		{"users.csv", `attachment; filename="users.csv"; filename*=UTF-8''users.csv`},
+		{`say "hi"\.csv`, `attachment; filename="say _hi__.csv"; filename*=UTF-8''say%20%22hi%22%5C.csv`},
+		{"alumnos-año.csv", `attachment; filename="alumnos-a_o.csv"; filename*=UTF-8''alumnos-a%C3%B1o.csv`},
+	}
+
+	for _, test := range tests {
+		resp := serve(t, test.filename, nil, 10)
+		assert.Equal(t, test.expected, resp.Header.Get("Content-Disposition"), test.filename)
+	}
+}
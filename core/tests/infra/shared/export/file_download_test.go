@@ -0,0 +1,89 @@
+package export
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/export"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+)
+
+const content = "0123456789"
+
+// download registers a download of content and returns the response to a request with headers
+func download(t *testing.T, headers ...any) *http.Response {
+	_, api := humatest.New(t)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "download",
+		Method:      http.MethodGet,
+		Path:        "/download",
+	}, func(ctx context.Context, input *export.RangeRequest) (*huma.StreamResponse, error) {
+		return export.FileDownload{
+			Filename:    "digits.txt",
+			ContentType: "text/plain",
+			Content:     []byte(content),
+		}.Response(*input), nil
+	})
+
+	return api.Get("/download", headers...).Result()
+}
+
+func bodyOf(t *testing.T, resp *http.Response) string {
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func TestFileDownload_ServesTheWholeFile(t *testing.T) {
+	resp := download(t)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "bytes", resp.Header.Get("Accept-Ranges"))
+	assert.Equal(t, "10", resp.Header.Get("Content-Length"))
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+	assert.Equal(t, content, bodyOf(t, resp))
+}
+
+func TestFileDownload_ResumesFromARange(t *testing.T) {
+	etag := download(t).Header.Get("ETag")
+
+	tests := []struct {
+		name    string
+		headers []any
+		status  int
+		body    string
+		content string
+	}{
+		{"open range", []any{"Range: bytes=4-"}, http.StatusPartialContent, "456789", "bytes 4-9/10"},
+		{"closed range", []any{"Range: bytes=2-4"}, http.StatusPartialContent, "234", "bytes 2-4/10"},
+		{"suffix range", []any{"Range: bytes=-3"}, http.StatusPartialContent, "789", "bytes 7-9/10"},
+		{"end past the size", []any{"Range: bytes=8-20"}, http.StatusPartialContent, "89", "bytes 8-9/10"},
+		{"same file", []any{"Range: bytes=4-", "If-Range: " + etag}, http.StatusPartialContent, "456789", "bytes 4-9/10"},
+		{"changed file", []any{"Range: bytes=4-", `If-Range: "stale"`}, http.StatusOK, content, ""},
+		{"several ranges", []any{"Range: bytes=0-1,4-5"}, http.StatusOK, content, ""},
+		{"invalid range", []any{"Range: bytes=5-2"}, http.StatusOK, content, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := download(t, tt.headers...)
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.content, resp.Header.Get("Content-Range"))
+			assert.Equal(t, tt.body, bodyOf(t, resp))
+		})
+	}
+}
+
+func TestFileDownload_RefusesARangePastTheEnd(t *testing.T) {
+	resp := download(t, "Range: bytes=10-")
+
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+	assert.Equal(t, "bytes */10", resp.Header.Get("Content-Range"))
+	assert.Contains(t, bodyOf(t, resp), "RANGE_NOT_SATISFIABLE")
+}
@@ -0,0 +1,191 @@
+package grpcclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/grpcclient"
+	"github.com/nahualventure/class-backend/infra/shared/internalauth"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// peer is a gRPC server that records the calls it gets, failing the first ones with Unavailable
+type peer struct {
+	address  string
+	calls    int
+	failures int
+	metadata metadata.MD
+	deadline time.Duration
+}
+
+func startPeer(t *testing.T, creds credentials.TransportCredentials, failures int) *peer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	p := &peer{address: listener.Addr().String(), failures: failures}
+
+	options := []grpc.ServerOption{grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+		p.calls++
+		p.metadata, _ = metadata.FromIncomingContext(ctx)
+		if deadline, ok := ctx.Deadline(); ok {
+			p.deadline = time.Until(deadline)
+		}
+		if p.calls <= p.failures {
+			return nil, status.Error(codes.Unavailable, "starting")
+		}
+		return handler(ctx, req)
+	})}
+	if creds != nil {
+		options = append(options, grpc.Creds(creds))
+	}
+	server := grpc.NewServer(options...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return p
+}
+
+func newFactory(t *testing.T, config grpcclient.Config, keys *internalauth.Keys) *grpcclient.Factory {
+	factory, err := grpcclient.NewFactory(config, keys)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = factory.Close() })
+	return factory
+}
+
+func check(t *testing.T, factory *grpcclient.Factory, ctx context.Context) error {
+	conn, err := factory.Conn("grades")
+	require.NoError(t, err)
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestTarget_ResolvesPeersThenTheTemplate(t *testing.T) {
+	factory := newFactory(t, grpcclient.Config{
+		Peers:          map[string]string{"grades": "dns:///grades.internal:8080"},
+		TargetTemplate: "dns:///%s.class.svc.cluster.local:8080",
+	}, nil)
+
+	target, err := factory.Target("grades")
+	assert.NoError(t, err)
+	assert.Equal(t, "dns:///grades.internal:8080", target)
+	target, err = factory.Target("classes")
+	assert.NoError(t, err)
+	assert.Equal(t, "dns:///classes.class.svc.cluster.local:8080", target)
+
+	unlisted := newFactory(t, grpcclient.Config{}, nil)
+	_, err = unlisted.Target("classes")
+	assert.Error(t, err)
+}
+
+func TestConn_RetriesUnavailableAndPropagatesMetadata(t *testing.T) {
+	p := startPeer(t, nil, 2)
+	keys, err := internalauth.NewKeys(internalauth.Config{Service: "classes", Keys: []string{"k1:0123456789abcdef0123456789abcdef"}})
+	require.NoError(t, err)
+	factory := newFactory(t, grpcclient.Config{
+		Peers:       map[string]string{"grades": "passthrough:///" + p.address},
+		Timeout:     2 * time.Second,
+		MaxAttempts: 3,
+	}, keys)
+
+	ctx := requestmeta.WithMeta(context.Background(), requestmeta.Meta{TraceID: "trace-1", Locale: "es-GT"})
+	require.NoError(t, check(t, factory, ctx))
+	assert.Equal(t, 3, p.calls)
+	assert.Equal(t, []string{"trace-1"}, p.metadata.Get("x-trace-id"))
+	assert.Equal(t, []string{"es-GT"}, p.metadata.Get("accept-language"))
+	// Calls without a deadline get the timeout of the config
+	assert.InDelta(t, float64(2*time.Second), float64(p.deadline), float64(500*time.Millisecond))
+
+	// Connections are shared, and calls are not retried past MaxAttempts
+	first, _ := factory.Conn("grades")
+	again, _ := factory.Conn("grades")
+	assert.Same(t, first, again)
+	p.calls, p.failures = 0, 5
+	assert.Equal(t, codes.Unavailable, status.Code(check(t, factory, ctx)))
+	assert.Equal(t, 3, p.calls)
+}
+
+// writeCertificates writes a CA and a certificate for 127.0.0.1 signed by it to dir
+func writeCertificates(t *testing.T, dir string) grpcclient.TLSConfig {
+	writePEM := func(name string, kind string, der []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: kind, Bytes: der}), 0o600))
+		return path
+	}
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "services"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, _ := x509.ParseCertificate(caDER)
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "classes"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	return grpcclient.TLSConfig{
+		CertFile: writePEM("service.pem", "CERTIFICATE", der),
+		KeyFile:  writePEM("service-key.pem", "EC PRIVATE KEY", keyDER),
+		CAFile:   writePEM("ca.pem", "CERTIFICATE", caDER),
+	}
+}
+
+func TestConn_CallsPeersOverMutualTLS(t *testing.T) {
+	tlsConfig := writeCertificates(t, t.TempDir())
+	serverCreds, err := tlsConfig.ServerCredentials()
+	require.NoError(t, err)
+	p := startPeer(t, serverCreds, 0)
+
+	factory := newFactory(t, grpcclient.Config{
+		Peers: map[string]string{"grades": "passthrough:///" + p.address},
+		TLS:   tlsConfig,
+	}, nil)
+	assert.NoError(t, check(t, factory, context.Background()))
+
+	// Clients without a certificate are refused during the handshake
+	plain := newFactory(t, grpcclient.Config{
+		Peers:       map[string]string{"grades": "passthrough:///" + p.address},
+		Timeout:     time.Second,
+		MaxAttempts: 1,
+	}, nil)
+	assert.Error(t, check(t, plain, context.Background()))
+
+	_, err = grpcclient.NewFactory(grpcclient.Config{TLS: grpcclient.TLSConfig{CAFile: tlsConfig.CAFile}}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+package internalauth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/internalauth"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	oldKey = "k1:0123456789abcdef0123456789abcdef"
+	newKey = "k2:fedcba9876543210fedcba9876543210"
+)
+
+var now = time.Date(2025, 12, 8, 9, 0, 0, 0, time.UTC)
+
+func newKeys(t *testing.T, config internalauth.Config) *internalauth.Keys {
+	keys, err := internalauth.NewKeys(config)
+	require.NoError(t, err)
+	return keys
+}
+
+func TestNewKeys_RefusesWeakOrIncompleteConfigs(t *testing.T) {
+	_, err := internalauth.NewKeys(internalauth.Config{Service: "grades", Keys: []string{"k1:short"}})
+	assert.Error(t, err)
+	_, err = internalauth.NewKeys(internalauth.Config{Service: "grades", Keys: []string{"0123456789abcdef0123456789abcdef"}})
+	assert.Error(t, err)
+	_, err = internalauth.NewKeys(internalauth.Config{Keys: []string{oldKey}})
+	assert.Error(t, err)
+	_, err = internalauth.NewKeys(internalauth.Config{Service: "grades", Required: true})
+	assert.Error(t, err)
+
+	disabled := newKeys(t, internalauth.Config{Service: "grades"})
+	assert.False(t, disabled.Enabled())
+}
+
+func TestVerify_AcceptsTokensOfPeersForThisService(t *testing.T) {
+	classes := newKeys(t, internalauth.Config{Service: "classes", Keys: []string{oldKey}})
+	grades := newKeys(t, internalauth.Config{Service: "grades", Keys: []string{oldKey}, Peers: []string{"classes"}})
+
+	token, err := classes.Sign(requestmeta.Meta{UserID: "user-1", TenantID: "tenant1", ImpersonatorID: "support-1",
+		AuthTime: "1765184400", AuthACR: "mfa"}, []string{"grades:read"}, "grades", now)
+	require.NoError(t, err)
+
+	claims, err := grades.Verify(token, now.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, "classes", claims.Issuer)
+	assert.Equal(t, []string{"grades:read"}, claims.Scopes)
+	assert.Equal(t, requestmeta.Meta{UserID: "user-1", TenantID: "tenant1", ImpersonatorID: "support-1",
+		AuthTime: "1765184400", AuthACR: "mfa", TraceID: "trace-1"}, claims.Meta(requestmeta.Meta{UserID: "forged", TraceID: "trace-1"}))
+
+	// The token is for grades only and expires with its TTL
+	_, err = classes.Verify(token, now)
+	assert.Error(t, err)
+	_, err = grades.Verify(token, now.Add(2*time.Minute))
+	assert.Error(t, err)
+
+	// Tokens of services that are not peers are refused even when signed with the shared key
+	reports := newKeys(t, internalauth.Config{Service: "reports", Keys: []string{oldKey}})
+	foreign, _ := reports.Sign(requestmeta.Meta{UserID: "user-1", TenantID: "tenant1"}, nil, "grades", now)
+	_, err = grades.Verify(foreign, now)
+	assert.Error(t, err)
+}
+
+func TestVerify_RefusesTamperedTokensAndRotatesKeys(t *testing.T) {
+	signer := newKeys(t, internalauth.Config{Service: "classes", Keys: []string{oldKey}})
+	token, _ := signer.Sign(requestmeta.Meta{UserID: "user-1", TenantID: "tenant1"}, nil, "grades", now)
+
+	// Verifiers with the new key first still accept the old one while it is rotated out
+	rotating := newKeys(t, internalauth.Config{Service: "grades", Keys: []string{newKey, oldKey}})
+	_, err := rotating.Verify(token, now)
+	assert.NoError(t, err)
+	rotated := newKeys(t, internalauth.Config{Service: "grades", Keys: []string{newKey}})
+	_, err = rotated.Verify(token, now)
+	assert.Error(t, err)
+
+	forger := newKeys(t, internalauth.Config{Service: "classes", Keys: []string{"k1:not-the-shared-secret-of-services"}})
+	forged, _ := forger.Sign(requestmeta.Meta{UserID: "admin-1", TenantID: "tenant1"}, nil, "grades", now)
+	_, err = rotating.Verify(forged, now)
+	assert.Error(t, err)
+	_, err = rotating.Verify("not.a.token", now)
+	assert.Error(t, err)
+}
+
+func newAPI(t *testing.T, keys *internalauth.Keys) humatest.TestAPI {
+	previous := huma.NewError
+	huma.NewError = utils.NewHumaError
+	t.Cleanup(func() { huma.NewError = previous })
+
+	type output struct {
+		Body struct {
+			UserID   string   `json:"user_id"`
+			Verified bool     `json:"verified"`
+			Scopes   []string `json:"scopes"`
+		}
+	}
+	_, api := humatest.New(t)
+	// Stands in for requestmeta.Middleware, which the Gin router runs before Huma
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		next(requestmeta.WithHuma(ctx, requestmeta.FromHuma(ctx)))
+	})
+	api.UseMiddleware(internalauth.NewMiddleware(keys))
+	huma.Register(api, huma.Operation{OperationID: "list-items", Method: http.MethodGet, Path: "/items"},
+		func(ctx context.Context, input *struct{}) (*output, error) {
+			out := &output{}
+			out.Body.UserID = requestmeta.UserID(ctx)
+			out.Body.Verified = internalauth.Verified(ctx)
+			out.Body.Scopes, _ = authorization.ScopesFromContext(ctx)
+			return out, nil
+		})
+	return api
+}
+
+func TestMiddleware_ActsAsTheVouchedUser(t *testing.T) {
+	keys := newKeys(t, internalauth.Config{Service: "grades", Keys: []string{oldKey}})
+	api := newAPI(t, keys)
+	token, _ := keys.Sign(requestmeta.Meta{UserID: "user-1", TenantID: "tenant1"}, []string{"grades:read"}, "grades", time.Now())
+
+	response := api.Get("/items", "X-User-Id: admin-1", "X-Tenant-Id: tenant1", "X-Internal-Identity: "+token)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"user_id":"user-1","verified":true,"scopes":["grades:read"]}`, response.Body.String())
+
+	response = api.Get("/items", "X-User-Id: admin-1", "X-Internal-Identity: "+token+"x")
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+
+	// Without a token the request is served as sent, unless tokens are required
+	response = api.Get("/items", "X-User-Id: user-2")
+	assert.JSONEq(t, `{"user_id":"user-2","verified":false,"scopes":null}`, response.Body.String())
+	required := newAPI(t, newKeys(t, internalauth.Config{Service: "grades", Keys: []string{oldKey}, Required: true}))
+	assert.Equal(t, http.StatusUnauthorized, required.Get("/items", "X-User-Id: user-2").Code)
+}
+
+func TestGRPC_PropagatesTheVerifiedIdentity(t *testing.T) {
+	keys := newKeys(t, internalauth.Config{Service: "grades", Keys: []string{oldKey}})
+
+	// The token is minted from the authorized caller of the request being served, not the one it claims
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	ctx := requestmeta.WithMeta(context.Background(), requestmeta.Meta{UserID: "user-1", TenantID: "tenant1", TraceID: "trace-1"})
+	require.NoError(t, keys.UnaryClientInterceptor("grades")(ctx, "/grades.Grades/List", nil, nil, nil, invoker))
+	assert.Empty(t, outgoing.Get("x-internal-identity"))
+	assert.Equal(t, []string{"trace-1"}, outgoing.Get("x-trace-id"))
+
+	var served requestmeta.Meta
+	var verified bool
+	handler := func(ctx context.Context, req any) (any, error) {
+		served, verified = requestmeta.FromIncomingGRPC(ctx), internalauth.Verified(ctx)
+		return nil, nil
+	}
+	token, _ := keys.Sign(requestmeta.Meta{UserID: "user-1", TenantID: "tenant1"}, nil, "grades", time.Now())
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-user-id", "admin-1", "x-tenant-id", "tenant1", "x-auth-acr", "mfa", "x-trace-id", "trace-1",
+		"x-internal-identity", token))
+	_, err := internalauth.UnaryServerInterceptor(keys)(incoming, nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, requestmeta.Meta{UserID: "user-1", TenantID: "tenant1", TraceID: "trace-1"}, served)
+
+	scoped, _ := keys.Sign(requestmeta.Meta{UserID: "user-1", TenantID: "tenant1"}, []string{"grades:read"}, "grades", time.Now())
+	_, err = internalauth.UnaryServerInterceptor(keys)(metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("x-internal-identity", scoped)), nil, &grpc.UnaryServerInfo{}, handler)
+	assert.Error(t, err)
+}
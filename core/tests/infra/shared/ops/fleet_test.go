@@ -0,0 +1,89 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/ops"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectShards(t *testing.T) {
+	shards := ops.ParseShards(map[string]string{"us": "postgres://us", "eu": "postgres://eu", "ap": "postgres://ap"})
+	assert.Equal(t, []string{"ap", "eu", "us"}, []string{shards[0].Name, shards[1].Name, shards[2].Name})
+
+	selected, err := ops.SelectShards(shards, []string{"us"})
+	assert.NoError(t, err)
+	assert.Equal(t, []ops.Shard{{Name: "us", DatabaseURL: "postgres://us"}}, selected)
+
+	_, err = ops.SelectShards(shards, []string{"uk"})
+	assert.Error(t, err)
+}
+
+func TestFleet_IsolatesFailingShards(t *testing.T) {
+	shards := ops.ParseShards(map[string]string{"us": "postgres://us", "eu": "postgres://eu", "ap": "postgres://ap"})
+	fleet := ops.NewFleet(shards, ops.FleetConfig{Concurrency: 2})
+
+	var running, mostRunning atomic.Int32
+	var partitioned atomic.Int32
+	fleet.Run(context.Background(),
+		ops.FleetStep{Name: "migrate", Run: func(_ context.Context, shard ops.Shard) error {
+			now := running.Add(1)
+			defer running.Add(-1)
+			for {
+				most := mostRunning.Load()
+				if now <= most || mostRunning.CompareAndSwap(most, now) {
+					break
+				}
+			}
+			if shard.Name == "eu" {
+				return errors.New("relation already exists")
+			}
+			return nil
+		}},
+		ops.FleetStep{Name: "ensure-partitions", Run: func(_ context.Context, shard ops.Shard) error {
+			if shard.Name == "ap" {
+				panic("nil pool")
+			}
+			partitioned.Add(1)
+			return nil
+		}},
+	)
+
+	assert.LessOrEqual(t, mostRunning.Load(), int32(2))
+	assert.Equal(t, int32(1), partitioned.Load())
+	assert.Equal(t, []string{"ap", "eu"}, fleet.Failed())
+
+	progress := fleet.Progress()
+	assert.Equal(t, ops.ShardFailed, progress[0].Status)
+	assert.Equal(t, "ensure-partitions", progress[0].Step)
+	assert.Equal(t, 1, progress[0].Steps)
+	assert.Equal(t, "migrate", progress[1].Step)
+	assert.Equal(t, ops.ShardSucceeded, progress[2].Status)
+	assert.Equal(t, 2, progress[2].Steps)
+
+	var report strings.Builder
+	assert.NoError(t, fleet.WriteReport(&report))
+	assert.Contains(t, report.String(), "at migrate: relation already exists")
+	assert.Contains(t, report.String(), "at ensure-partitions: panic: nil pool")
+}
+
+func TestFleet_LeavesShardsPendingOnceCancelled(t *testing.T) {
+	shards := ops.ParseShards(map[string]string{"us": "postgres://us", "eu": "postgres://eu"})
+	fleet := ops.NewFleet(shards, ops.FleetConfig{Concurrency: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	fleet.Run(ctx, ops.FleetStep{Name: "migrate", Run: func(context.Context, ops.Shard) error {
+		cancel()
+		return nil
+	}})
+
+	progress := fleet.Progress()
+	assert.Equal(t, ops.ShardSucceeded, progress[0].Status)
+	assert.Equal(t, ops.ShardPending, progress[1].Status)
+	assert.Equal(t, []string{"us"}, fleet.Failed())
+}
@@ -0,0 +1,111 @@
+package ops
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/ops"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouter(lameDuck *ops.LameDuck, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(lameDuck.Middleware())
+	router.GET("/ready", lameDuck.Readiness)
+	router.GET("/work", handler)
+	return router
+}
+
+func get(router http.Handler, path string, header http.Header) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestLameDuck_ReadyUntilSignalled(t *testing.T) {
+	lameDuck := ops.NewLameDuck(ops.NewDependencies())
+	router := newRouter(lameDuck, func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	assert.Equal(t, http.StatusOK, get(router, "/ready", nil).Code)
+	response := get(router, "/work", nil)
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Empty(t, response.Header().Get("Connection"))
+}
+
+func TestLameDuck_DrainsRequestsThenWorkersThenDependencies(t *testing.T) {
+	dependencies := ops.NewDependencies()
+	dependencyClosed := make(chan time.Time, 1)
+	dependencies.Register(ops.Dependency{Name: "database", Close: func() error {
+		dependencyClosed <- time.Now()
+		return nil
+	}})
+	lameDuck := ops.NewLameDuck(dependencies)
+	requestStarted := make(chan struct{})
+	releaseRequest := make(chan struct{})
+	router := newRouter(lameDuck, func(c *gin.Context) {
+		close(requestStarted)
+		<-releaseRequest
+		c.Status(http.StatusNoContent)
+	})
+
+	workerStopped := make(chan time.Time, 1)
+	lameDuck.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		workerStopped <- time.Now()
+	})
+
+	server := &http.Server{Addr: "127.0.0.1:18931", Handler: router}
+	served := make(chan error, 1)
+	go func() {
+		served <- lameDuck.ListenAndServe(server, ops.ShutdownConfig{
+			LameDuckDelay: 200 * time.Millisecond,
+			GracePeriod:   2 * time.Second,
+		})
+	}()
+
+	var inFlight *http.Response
+	inFlightDone := make(chan error, 1)
+	assert.Eventually(t, func() bool {
+		_, err := http.Get("http://127.0.0.1:18931/ready")
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	go func() {
+		var err error
+		inFlight, err = http.Get("http://127.0.0.1:18931/work")
+		inFlightDone <- err
+	}()
+	<-requestStarted
+
+	assert.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGTERM))
+	assert.Eventually(t, lameDuck.Draining, time.Second, 5*time.Millisecond)
+
+	// While draining, readiness fails and connections are not kept alive
+	response := get(router, "/ready", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	assert.Equal(t, "close", response.Header().Get("Connection"))
+	assert.Equal(t, http.StatusServiceUnavailable, get(router, "/work", http.Header{"Upgrade": {"websocket"}}).Code)
+
+	// Workers keep running until in-flight requests are done
+	time.Sleep(300 * time.Millisecond)
+	assert.Len(t, workerStopped, 0)
+	released := time.Now()
+	close(releaseRequest)
+
+	assert.NoError(t, <-inFlightDone)
+	assert.Equal(t, http.StatusNoContent, inFlight.StatusCode)
+	assert.NoError(t, <-served)
+	stopped := <-workerStopped
+	assert.True(t, stopped.After(released))
+	assert.True(t, (<-dependencyClosed).After(stopped))
+}
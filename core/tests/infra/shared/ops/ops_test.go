@@ -0,0 +1,130 @@
+package ops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/ops"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadiness_FailsWhileADependencyIsDown(t *testing.T) {
+	dependencies := ops.NewDependencies()
+	dependencies.Register(ops.Dependency{Name: "database", Check: func(context.Context) error { return nil }})
+	redisErr := errors.New("connection refused")
+	dependencies.Register(ops.Dependency{Name: "redis", Check: func(context.Context) error { return redisErr }})
+	dependencies.Register(ops.Dependency{Name: "job locks", SelfTestOnly: true, Check: func(context.Context) error {
+		return errors.New("not probed")
+	}})
+	lameDuck := ops.NewLameDuck(dependencies)
+	router := newRouter(lameDuck, func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	response := get(router, "/ready", nil)
+	var body struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks"`
+	}
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	assert.Equal(t, "NOT_READY", body.Status)
+	assert.Equal(t, map[string]string{"database": "ok", "redis": "connection refused"}, body.Checks)
+
+	redisErr = nil
+	assert.Equal(t, http.StatusOK, get(router, "/ready", nil).Code)
+}
+
+func TestDependencies_SelfTestRunsEveryCheck(t *testing.T) {
+	dependencies := ops.NewDependencies()
+	dependencies.Register(ops.Dependency{Name: "database", Check: func(context.Context) error { return nil }})
+	dependencies.Register(ops.Dependency{Name: "job locks", SelfTestOnly: true, Check: func(context.Context) error {
+		return errors.New("connection refused")
+	}})
+	dependencies.Register(ops.Dependency{Name: "storage"})
+
+	var out strings.Builder
+	err := dependencies.SelfTest(context.Background(), &out)
+
+	assert.EqualError(t, err, "1 of 2 dependencies failed: job locks")
+	assert.Contains(t, out.String(), "database             ok\n")
+	assert.Contains(t, out.String(), "job locks            FAILED: connection refused\n")
+	assert.Contains(t, out.String(), "storage              skipped")
+	assert.Equal(t, map[string]string{"database": "ok"}, dependencies.Check(context.Background()))
+}
+
+func TestDependencies_CloseInReverseOrderOnce(t *testing.T) {
+	dependencies := ops.NewDependencies()
+	var closed []string
+	for _, name := range []string{"database", "casbin", "redis"} {
+		dependencies.Register(ops.Dependency{Name: name, Close: func() error {
+			closed = append(closed, name)
+			return errors.New("already closed")
+		}})
+	}
+	dependencies.Register(ops.Dependency{Name: "job locks"})
+
+	dependencies.Close()
+	dependencies.Close()
+
+	assert.Equal(t, []string{"redis", "casbin", "database"}, closed)
+}
+
+func TestShutdownConfig_FitsTheTerminationGracePeriod(t *testing.T) {
+	config := ops.ShutdownConfig{LameDuckDelay: 10 * time.Second, GracePeriod: 20 * time.Second}
+	assert.Equal(t, config, config.Fit(), "unknown termination grace period")
+
+	config.TerminationGracePeriod = 60 * time.Second
+	assert.Equal(t, config, config.Fit(), "budget of 50s fits")
+
+	config.TerminationGracePeriod = 30 * time.Second
+	fitted := config.Fit()
+	assert.Equal(t, 10*time.Second, fitted.LameDuckDelay)
+	assert.Equal(t, 9*time.Second, fitted.GracePeriod)
+	assert.LessOrEqual(t, fitted.Budget(), config.TerminationGracePeriod)
+
+	config.TerminationGracePeriod = 8 * time.Second
+	fitted = config.Fit()
+	assert.Equal(t, 6*time.Second, fitted.LameDuckDelay)
+	assert.Equal(t, time.Duration(0), fitted.GracePeriod)
+}
+
+func TestMetadata_LogPrefix(t *testing.T) {
+	assert.Equal(t, "", ops.Metadata{}.LogPrefix())
+	assert.Equal(t, "namespace=prod pod=api-7d9f node=node-3 ",
+		ops.Metadata{Pod: "api-7d9f", Namespace: "prod", Node: "node-3"}.LogPrefix())
+}
+
+func TestScheduler_WithoutLocksRunsEveryJob(t *testing.T) {
+	scheduler := ops.NewScheduler(nil, true)
+	var ran atomic.Int32
+	scheduler.Add("projections", func(context.Context) { ran.Add(1) })
+	scheduler.Add("archival", func(context.Context) { ran.Add(1) })
+
+	scheduler.Run(context.Background())
+
+	assert.Equal(t, int32(2), ran.Load())
+	assert.True(t, scheduler.IsLeader())
+}
+
+func TestScheduler_RestartsAPanickingJobWithoutStoppingTheOthers(t *testing.T) {
+	scheduler := ops.NewScheduler(nil, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	var ran atomic.Int32
+	scheduler.Add("archival", func(context.Context) {
+		defer cancel()
+		panic("bad row")
+	})
+	scheduler.Add("projections", func(context.Context) { ran.Add(1) })
+
+	scheduler.Run(ctx)
+
+	assert.Equal(t, int32(1), ran.Load())
+	assert.Equal(t, int64(1), scheduler.Restarts())
+}
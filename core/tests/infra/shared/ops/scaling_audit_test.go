@@ -0,0 +1,38 @@
+package ops
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/database"
+	"github.com/nahualventure/class-backend/infra/shared/ops"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScalingAudit_AllowsASingleReplicaWithBlockingFindings(t *testing.T) {
+	audit := ops.NewScalingAudit()
+	audit.Require("job locks", false, "workers run on every instance")
+
+	assert.NoError(t, audit.Verify(1))
+	assert.ErrorContains(t, audit.Verify(3), "job locks")
+}
+
+func TestScalingAudit_WarningsDoNotBlockReplicas(t *testing.T) {
+	audit := ops.NewScalingAudit()
+	audit.Require("casbin", true, "role changes only reach the instance that made them")
+	audit.Flag("time zone cache", "settings are cached in memory")
+
+	assert.NoError(t, audit.Verify(3))
+	assert.Equal(t, []ops.ScalingFinding{{Component: "time zone cache", Reason: "settings are cached in memory"}}, audit.Findings())
+}
+
+func TestJobLocks_DisabledRunsJobsRightAway(t *testing.T) {
+	var jobLocks *database.JobLocks
+	ran := false
+
+	jobLocks.Run(context.Background(), "projections", func(context.Context) { ran = true })
+
+	assert.False(t, jobLocks.Enabled())
+	assert.True(t, ran)
+}
@@ -0,0 +1,38 @@
+package pdf
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/pdf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayout_Header_RepeatsOnEveryPage(t *testing.T) {
+	layout := &pdf.Layout{}
+	columns := []int{0, 40}
+	layout.Header(columns, "#", "Student")
+	for i := 0; i < 100; i++ {
+		layout.Row(false, columns, strconv.Itoa(i+1), "Student")
+	}
+	layout.EndTable()
+
+	document := layout.Bytes()
+
+	pages := bytes.Count(document, []byte("/Type /Page /Parent"))
+	assert.Greater(t, pages, 1)
+	assert.Equal(t, pages, bytes.Count(document, []byte("(#) Tj")))
+	assert.True(t, bytes.HasSuffix(document, []byte("%%EOF\n")))
+}
+
+func TestLayout_Row_CutsCellsToTheirColumn(t *testing.T) {
+	layout := &pdf.Layout{}
+	layout.Row(false, []int{0, 40}, "A name far too long for the column", "(x)")
+
+	document := layout.Bytes()
+
+	assert.Contains(t, string(document), "(A n...) Tj")
+	assert.Contains(t, string(document), `(\(x\)) Tj`)
+}
@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrencyLimiter_ShedsOverTheTenantLimit(t *testing.T) {
+	limiter := ratelimit.NewConcurrencyLimiter(10, 2)
+
+	first, _ := limiter.Acquire("tenant1")
+	_, shed := limiter.Acquire("tenant1")
+	assert.Empty(t, shed)
+	_, shed = limiter.Acquire("tenant1")
+	assert.Equal(t, ratelimit.ScopeTenant, shed)
+
+	// Other tenants and public endpoints are not affected
+	_, shed = limiter.Acquire("tenant2")
+	assert.Empty(t, shed)
+	_, shed = limiter.Acquire("")
+	assert.Empty(t, shed)
+
+	first()
+	first()
+	_, shed = limiter.Acquire("tenant1")
+	assert.Empty(t, shed, "releasing twice frees a single slot")
+	_, shed = limiter.Acquire("tenant1")
+	assert.Equal(t, ratelimit.ScopeTenant, shed)
+}
+
+func TestConcurrencyLimiter_ShedsOverTheServerLimit(t *testing.T) {
+	limiter := ratelimit.NewConcurrencyLimiter(2, 0)
+
+	release, _ := limiter.Acquire("tenant1")
+	limiter.Acquire("tenant2")
+	_, shed := limiter.Acquire("")
+	assert.Equal(t, ratelimit.ScopeServer, shed)
+
+	release()
+	_, shed = limiter.Acquire("")
+	assert.Empty(t, shed)
+
+	var metrics strings.Builder
+	assert.NoError(t, limiter.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), "http_requests_in_flight 2\n")
+	assert.Contains(t, metrics.String(), `http_requests_shed_total{scope="server"} 1`)
+	assert.Contains(t, metrics.String(), `http_requests_shed_total{scope="tenant"} 0`)
+}
+
+func TestConcurrencyLimiter_TenantOverrides(t *testing.T) {
+	limiter := ratelimit.NewConcurrencyLimiter(10, 2)
+	limiter.LimitTenants(map[string]int{"noisy": 1, "large": 0})
+
+	limiter.Acquire("noisy")
+	_, shed := limiter.Acquire("noisy")
+	assert.Equal(t, ratelimit.ScopeTenant, shed, "the override holds the tenant back")
+
+	for i := 0; i < 3; i++ {
+		_, shed = limiter.Acquire("large")
+		assert.Empty(t, shed, "a limit of 0 lifts the tenant limit")
+	}
+
+	limiter.Acquire("tenant1")
+	limiter.Acquire("tenant1")
+	_, shed = limiter.Acquire("tenant1")
+	assert.Equal(t, ratelimit.ScopeTenant, shed, "other tenants keep the default limit")
+}
+
+func TestConcurrencyMiddleware_ShedsByTheClaimedTenantBeforeLaterMiddlewares(t *testing.T) {
+	limiter := ratelimit.NewConcurrencyLimiter(10, 1)
+	_, api := humatest.New(t)
+	api.UseMiddleware(ratelimit.NewConcurrencyMiddleware(limiter))
+	// Stands for the service account and authorization middlewares, which query the database
+	lookups := 0
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		lookups++
+		next(ctx)
+	})
+	huma.Register(api, huma.Operation{OperationID: "list-items", Method: http.MethodGet, Path: "/items"},
+		func(ctx context.Context, input *struct{}) (*struct{}, error) { return nil, nil })
+
+	release, _ := limiter.Acquire("tenant1")
+	response := api.Get("/items", requestmeta.TenantIDHeader+": tenant1")
+	assert.Equal(t, http.StatusTooManyRequests, response.Code)
+	assert.Equal(t, "1", response.Header().Get("Retry-After"))
+	assert.Zero(t, lookups)
+
+	release()
+	response = api.Get("/items", requestmeta.TenantIDHeader+": tenant1")
+	assert.Less(t, response.Code, 300)
+	assert.Equal(t, 1, lookups)
+}
@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/tests/clocktest"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadLimiter_ShedsOverTheUserAndTenantLimits(t *testing.T) {
+	limiter := ratelimit.NewDownloadLimiter(ratelimit.DownloadLimits{PerUser: 1, PerTenant: 2})
+
+	first, shed := limiter.Acquire("tenant1", "u1")
+	assert.Empty(t, shed)
+	_, shed = limiter.Acquire("tenant1", "u1")
+	assert.Equal(t, ratelimit.ScopeUser, shed)
+
+	_, shed = limiter.Acquire("tenant1", "u2")
+	assert.Empty(t, shed)
+	_, shed = limiter.Acquire("tenant1", "u3")
+	assert.Equal(t, ratelimit.ScopeTenant, shed)
+	_, shed = limiter.Acquire("tenant2", "u1")
+	assert.Empty(t, shed, "users are counted per tenant")
+
+	first()
+	first()
+	_, shed = limiter.Acquire("tenant1", "u3")
+	assert.Empty(t, shed, "releasing twice frees a single slot")
+	_, shed = limiter.Acquire("tenant1", "u1")
+	assert.Equal(t, ratelimit.ScopeTenant, shed)
+}
+
+func TestDownloadLimiter_PacesWritesToTheBandwidth(t *testing.T) {
+	clock := clocktest.New(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC))
+	limiter := ratelimit.NewDownloadLimiter(ratelimit.DownloadLimits{
+		UserBytesPerSecond:   1000,
+		TenantBytesPerSecond: 1000,
+	}).WithClock(clock)
+
+	assert.Zero(t, limiter.Take("tenant1", "u1", 1000), "a second of bandwidth is available at once")
+	assert.Equal(t, 500*time.Millisecond, limiter.Take("tenant1", "u1", 500))
+
+	assert.Equal(t, 1500*time.Millisecond, limiter.Take("tenant1", "u2", 1000), "the tenant bandwidth is shared by its users")
+
+	clock.Advance(2 * time.Second)
+	assert.Zero(t, limiter.Take("tenant1", "u1", 500), "waiting pays the debt back")
+	assert.Zero(t, limiter.Take("tenant2", "u1", 1000), "tenants do not share bandwidth")
+}
@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/tests/clocktest"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyRateLimiter_LimitsEachKeyAtItsOwnRate(t *testing.T) {
+	clk := clocktest.New(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC))
+	limiter := ratelimit.NewKeyRateLimiter().WithClock(clk)
+
+	// A key spends its minute at once
+	for range 2 {
+		allowed, _ := limiter.Allow("pk_small", 2)
+		assert.True(t, allowed)
+	}
+	allowed, wait := limiter.Allow("pk_small", 2)
+	assert.False(t, allowed)
+	assert.Equal(t, 30*time.Second, wait)
+
+	for range 10 {
+		allowed, _ = limiter.Allow("pk_large", 10)
+		assert.True(t, allowed, "other keys have their own bucket")
+	}
+	allowed, _ = limiter.Allow("pk_other_small", 2)
+	assert.True(t, allowed, "keys with the same limit have their own bucket")
+
+	clk.Advance(30 * time.Second)
+	allowed, _ = limiter.Allow("pk_small", 2)
+	assert.True(t, allowed)
+}
@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/tests/clocktest"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantRateLimiter_LimitsPerTenant(t *testing.T) {
+	clk := clocktest.New(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC))
+	limiter := ratelimit.NewTenantRateLimiter(60, 2).WithClock(clk)
+
+	allowed, _ := limiter.Allow("tenant1")
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow("tenant1")
+	assert.True(t, allowed)
+	allowed, wait := limiter.Allow("tenant1")
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, wait)
+
+	allowed, _ = limiter.Allow("tenant2")
+	assert.True(t, allowed, "other tenants have their own bucket")
+
+	clk.Advance(time.Second)
+	allowed, _ = limiter.Allow("tenant1")
+	assert.True(t, allowed)
+}
+
+func TestTenantRateLimiter_EvictsIdleBuckets(t *testing.T) {
+	clk := clocktest.New(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC))
+	limiter := ratelimit.NewTenantRateLimiter(60, 5).WithClock(clk)
+
+	for i := range 100 {
+		limiter.Allow(fmt.Sprintf("10.0.0.%d", i))
+	}
+	assert.Equal(t, 100, limiter.Tracked())
+
+	// Buckets refill in 5 seconds, the ones unused since are full and dropped
+	clk.Advance(5 * time.Second)
+	limiter.Allow("10.0.0.1")
+	assert.Equal(t, 1, limiter.Tracked())
+
+	for range 5 {
+		limiter.Allow("10.0.0.1")
+	}
+	allowed, _ := limiter.Allow("10.0.0.1")
+	assert.False(t, allowed, "the bucket in use keeps its tokens across sweeps")
+}
@@ -0,0 +1,79 @@
+package requestmeta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestFromHTTP_ReadsEveryHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-User-Id", "user-1")
+	header.Set("X-Tenant-Id", "tenant1")
+	header.Set("Accept-Language", "es-GT, en;q=0.5")
+	header.Set("X-Trace-Id", "trace-1")
+	header.Set("X-Impersonator-Id", "support-1")
+	header.Set("X-Client-Version", "ios/4.2.0")
+
+	meta := requestmeta.FromHTTP(header)
+
+	assert.Equal(t, requestmeta.Meta{
+		UserID:         "user-1",
+		TenantID:       "tenant1",
+		Locale:         "es-GT, en;q=0.5",
+		TraceID:        "trace-1",
+		ImpersonatorID: "support-1",
+		ClientVersion:  "ios/4.2.0",
+	}, meta)
+	assert.True(t, meta.Impersonated())
+}
+
+func TestFromHTTP_TraceIDFallsBackToTraceParent(t *testing.T) {
+	header := http.Header{}
+	header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", requestmeta.FromHTTP(header).TraceID)
+
+	header.Set("Traceparent", "not a trace context")
+	assert.Empty(t, requestmeta.FromHTTP(header).TraceID)
+}
+
+func TestGRPC_RoundTripsThroughMetadata(t *testing.T) {
+	sent := requestmeta.Meta{UserID: "user-1", TenantID: "tenant1", TraceID: "trace-1", ClientVersion: "web/1.0"}
+
+	outgoing := sent.AppendToOutgoingGRPC(context.Background())
+	md, _ := metadata.FromOutgoingContext(outgoing)
+	assert.Equal(t, []string{"user-1"}, md.Get("x-user-id"))
+	assert.Empty(t, md.Get("x-impersonator-id"))
+
+	received := requestmeta.FromIncomingGRPC(metadata.NewIncomingContext(context.Background(), md))
+	assert.Equal(t, sent, received)
+	assert.False(t, received.Impersonated())
+}
+
+func TestMiddleware_StoresMetaAndEchoesTraceID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(requestmeta.Middleware)
+	var seen requestmeta.Meta
+	router.GET("/", func(c *gin.Context) {
+		seen = requestmeta.FromContext(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("X-Tenant-Id", "tenant1")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, "tenant1", seen.TenantID)
+	assert.Len(t, seen.TraceID, 32, "generated when the client sends none")
+	assert.Equal(t, seen.TraceID, recorder.Header().Get("X-Trace-Id"))
+	assert.Empty(t, requestmeta.TenantID(context.Background()))
+}
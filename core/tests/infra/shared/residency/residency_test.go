@@ -0,0 +1,114 @@
+package residency
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/residency"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouter(t *testing.T, region string) *residency.Router {
+	tenants, err := residency.ParseAssignments([]string{"lycee-lyon=eu", " colegio-madrid = eu "})
+	assert.NoError(t, err)
+	router, err := residency.NewRouter(residency.Config{
+		Region:        region,
+		DefaultRegion: "us",
+		Tenants:       tenants,
+		Endpoints:     map[string]string{"eu": "https://eu.api.example.com"},
+	})
+	assert.NoError(t, err)
+	return router
+}
+
+func TestParseAssignments(t *testing.T) {
+	for _, invalid := range []string{"lycee-lyon", "=eu", "lycee-lyon="} {
+		_, err := residency.ParseAssignments([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNewRouter_RefusesIncompleteConfigs(t *testing.T) {
+	_, err := residency.NewRouter(residency.Config{Tenants: map[string]string{"lycee-lyon": "eu"}})
+	assert.Error(t, err)
+
+	_, err = residency.NewRouter(residency.Config{Region: "eu"})
+	assert.Error(t, err)
+
+	router, err := residency.NewRouter(residency.Config{})
+	assert.NoError(t, err)
+	assert.False(t, router.Routing())
+	assert.True(t, router.Local("lycee-lyon"))
+}
+
+func TestRouter_RegionOf(t *testing.T) {
+	router := newRouter(t, "us")
+
+	assert.Equal(t, "eu", router.RegionOf("lycee-lyon"))
+	assert.Equal(t, "eu", router.RegionOf("lycee-lyon-sandbox"))
+	assert.Equal(t, "us", router.RegionOf("springfield-high"))
+	assert.Equal(t, "us", router.RegionOf("dev-4f2a9c"))
+	assert.True(t, router.ServesDefaultRegion())
+	assert.Equal(t, []string{"lycee-lyon", "colegio-madrid"}, router.Remote([]string{"lycee-lyon", "springfield-high", "colegio-madrid"}))
+}
+
+func newAPI(t *testing.T, router *residency.Router) humatest.TestAPI {
+	previous := huma.NewError
+	huma.NewError = utils.NewHumaError
+	t.Cleanup(func() { huma.NewError = previous })
+
+	_, api := humatest.New(t)
+	api.UseMiddleware(router.Middleware)
+	huma.Register(api, huma.Operation{OperationID: "list-items", Method: http.MethodGet, Path: "/items"},
+		func(ctx context.Context, input *struct{}) (*struct{}, error) { return nil, nil })
+	return api
+}
+
+func TestRouter_Middleware_SendsTenantsToTheirRegion(t *testing.T) {
+	router := newRouter(t, "us")
+	api := newAPI(t, router)
+
+	response := api.Get("/items", "X-Tenant-Id: lycee-lyon")
+	assert.Equal(t, http.StatusMisdirectedRequest, response.Code)
+	assert.Equal(t, "eu", response.Header().Get(residency.RegionHeader))
+	var body utils.HTTPErrorResponse
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, "TENANT_IN_OTHER_REGION", body.Error.Code)
+	assert.Equal(t, "https://eu.api.example.com", body.Error.Context["endpoint"])
+
+	// Tenants of the region and requests naming no tenant are served
+	assert.Equal(t, http.StatusNoContent, api.Get("/items", "X-Tenant-Id: springfield-high").Code)
+	assert.Equal(t, http.StatusNoContent, api.Get("/items").Code)
+
+	var metrics strings.Builder
+	assert.NoError(t, router.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `http_misdirected_requests_total{region="eu"} 1`)
+}
+
+type memoryClusterStore struct {
+	region string
+}
+
+func (s *memoryClusterStore) ClaimRegion(_ context.Context, region string, _ time.Time) (string, error) {
+	if s.region == "" {
+		s.region = region
+	}
+	return s.region, nil
+}
+
+func TestRouter_VerifyCluster(t *testing.T) {
+	store := &memoryClusterStore{}
+
+	assert.NoError(t, newRouter(t, "eu").VerifyCluster(context.Background(), store))
+	assert.NoError(t, newRouter(t, "eu").VerifyCluster(context.Background(), store))
+	assert.Error(t, newRouter(t, "us").VerifyCluster(context.Background(), store))
+	assert.Equal(t, "eu", store.region)
+}
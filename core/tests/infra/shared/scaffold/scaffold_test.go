@@ -0,0 +1,162 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/scaffold"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const middlewareFixture = `package authorization
+
+var EndpointMapping = map[string]ResourceAction{
+	"list-users": {Resource: "user", Action: "view"},
+}
+`
+
+const policiesFixture = `roles:
+  admin:
+    permissions:
+      all: [all]
+
+  instructor:
+    permissions:
+      library: [view, contribute]
+
+  department_head:
+    permissions:
+      library: [view, contribute, publish]`
+
+const sqlcFixture = `version: "2"
+sql:
+  - engine: "postgresql"
+    schema:
+      - "infra/user/sql/schema.sql"
+      - "infra/library/sql/schema.sql"
+    queries: "infra/*/sql/queries"
+`
+
+func newRepository(t *testing.T) string {
+	root := t.TempDir()
+	for path, content := range map[string]string{
+		scaffold.EndpointMappingPath: middlewareFixture,
+		scaffold.PoliciesPath:        policiesFixture,
+		scaffold.SqlcConfigPath:      sqlcFixture,
+	} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(content), 0o644))
+	}
+	return root
+}
+
+func read(t *testing.T, root string, path string) string {
+	content, err := os.ReadFile(filepath.Join(root, path))
+	assert.NoError(t, err)
+	return string(content)
+}
+
+func TestNewNames_SpellsCompoundAndAcronymNames(t *testing.T) {
+	names, err := scaffold.NewNames(scaffold.Spec{Module: "fieldtrips", Entity: "FieldTrip"})
+	assert.NoError(t, err)
+	assert.Equal(t, "FieldTrips", names.EntityPlural)
+	assert.Equal(t, "field-trips", names.KebabPlural)
+	assert.Equal(t, "field_trips", names.Table)
+	assert.Equal(t, "fieldTrip", names.EntityVar)
+
+	names, err = scaffold.NewNames(scaffold.Spec{Module: "outreach", Entity: "Activity"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Activities", names.EntityPlural)
+	assert.Equal(t, "activities", names.Table)
+
+	names, err = scaffold.NewNames(scaffold.Spec{Module: "links", Entity: "SharedURL"})
+	assert.NoError(t, err)
+	assert.Equal(t, "SharedURLs", names.EntityPlural)
+	assert.Equal(t, "shared-url", names.Kebab)
+
+	_, err = scaffold.NewNames(scaffold.Spec{Module: "Field-Trips", Entity: "FieldTrip"})
+	assert.Error(t, err)
+}
+
+func TestGenerate_WritesTheModuleAndRegistersIt(t *testing.T) {
+	root := newRepository(t)
+
+	written, err := scaffold.Generate(root, scaffold.Spec{Module: "fieldtrips", Entity: "FieldTrip"})
+	assert.NoError(t, err)
+
+	for _, path := range []string{
+		"core/app/fieldtrips/domain/entities/field-trip.go",
+		"core/app/fieldtrips/application/use-cases/list-field-trips-use-case/list-field-trips-use-case.go",
+		"infra/fieldtrips/handlers/fieldtrips-handlers.go",
+		"infra/fieldtrips/sql/queries/fieldtrips.sql",
+		"proto/fieldtrips/v1/fieldtrips.proto",
+		"core/tests/app/fieldtrips/application/use-cases/fieldtrips_use_cases_test.go",
+	} {
+		assert.Contains(t, written, path)
+		assert.FileExists(t, filepath.Join(root, path))
+	}
+	assert.Contains(t, read(t, root, "infra/fieldtrips/handlers/fieldtrips-handlers.go"), `Path:        "/fieldtrips/field-trips/{fieldTripId}"`)
+
+	middleware := read(t, root, scaffold.EndpointMappingPath)
+	assert.Contains(t, middleware, `"create-field-trip": {Resource: "fieldtrips", Action: "manage"},`)
+	assert.Contains(t, middleware, `"list-field-trips":  {Resource: "fieldtrips", Action: "view"},`)
+
+	policies := read(t, root, scaffold.PoliciesPath)
+	assert.Contains(t, policies, "      library: [view, contribute]\n      fieldtrips: [view]\n")
+	assert.True(t, strings.HasSuffix(policies, "      library: [view, contribute, publish]\n      fieldtrips: [view, manage]\n"))
+
+	assert.Contains(t, read(t, root, scaffold.SqlcConfigPath), "      - \"infra/library/sql/schema.sql\"\n      - \"infra/fieldtrips/sql/schema.sql\"\n")
+}
+
+func TestGenerate_RefusesExistingModules(t *testing.T) {
+	root := newRepository(t)
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "infra", "library"), 0o755))
+
+	_, err := scaffold.Generate(root, scaffold.Spec{Module: "library", Entity: "Resource"})
+
+	assert.ErrorContains(t, err, "already exists")
+	assert.NoFileExists(t, filepath.Join(root, "core/app/library/domain/entities/resource.go"))
+	assert.Equal(t, middlewareFixture, read(t, root, scaffold.EndpointMappingPath))
+}
+
+// TestGenerate_WritesAModuleThatCompiles builds the generated Go files and their tests in place of
+// the real repository through an overlay, the tree is left untouched. The adapter is left out, it
+// needs the sqlc output of the new queries.
+func TestGenerate_WritesAModuleThatCompiles(t *testing.T) {
+	repository, err := filepath.Abs(filepath.Join("..", "..", "..", "..", ".."))
+	assert.NoError(t, err)
+	if _, err := os.Stat(filepath.Join(repository, "go.work")); err != nil {
+		t.Skip("the repository workspace is not available")
+	}
+
+	root := newRepository(t)
+	written, err := scaffold.Generate(root, scaffold.Spec{Module: "fieldtrips", Entity: "FieldTrip"})
+	assert.NoError(t, err)
+
+	overlay := map[string]string{}
+	for _, path := range written {
+		if filepath.Ext(path) != ".go" || strings.Contains(path, "adapters") || strings.HasPrefix(path, "infra/shared/") {
+			continue
+		}
+		overlay[filepath.Join(repository, path)] = filepath.Join(root, path)
+	}
+	replace, err := json.Marshal(map[string]any{"Replace": overlay})
+	assert.NoError(t, err)
+	overlayFile := filepath.Join(root, "overlay.json")
+	assert.NoError(t, os.WriteFile(overlayFile, replace, 0o644))
+
+	for _, args := range [][]string{
+		{"build", "-overlay", overlayFile, "./core/app/fieldtrips/...", "./infra/fieldtrips/handlers"},
+		{"test", "-c", "-vet=off", "-o", t.TempDir(), "-overlay", overlayFile, "./core/tests/app/fieldtrips/..."},
+	} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = repository
+		output, err := cmd.CombinedOutput()
+		assert.NoError(t, err, "go %s\n%s", args[0], output)
+	}
+}
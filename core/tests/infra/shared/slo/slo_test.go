@@ -0,0 +1,118 @@
+package slo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/slo"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+var objectives = []slo.Objective{
+	{Name: "read-availability", Class: slo.ClassRead, Kind: slo.KindAvailability, Target: 0.99},
+	{Name: "read-latency", Class: slo.ClassRead, Kind: slo.KindLatency, Target: 0.9, Threshold: 100 * time.Millisecond},
+}
+
+func objective(report slo.Report, name string) slo.ObjectiveStatus {
+	for _, status := range report.Objectives {
+		if status.Name == name {
+			return status
+		}
+	}
+	return slo.ObjectiveStatus{}
+}
+
+func TestClassOf(t *testing.T) {
+	assert.Equal(t, slo.ClassRead, slo.ClassOf(http.MethodGet, "list-users"))
+	assert.Equal(t, slo.ClassWrite, slo.ClassOf(http.MethodPost, "record-grade"))
+	assert.Equal(t, slo.ClassBulk, slo.ClassOf(http.MethodGet, "export-users"))
+}
+
+func TestTracker_ComputesSLIAndBudget(t *testing.T) {
+	tracker := slo.NewTracker(objectives, slo.BurnRateAlerts)
+	// Spread over two days, outside the alert short windows
+	at := now.Add(-48 * time.Hour)
+	for i := 0; i < 1000; i++ {
+		tracker.Record(slo.ClassRead, http.StatusOK, 20*time.Millisecond, at)
+	}
+	for i := 0; i < 5; i++ {
+		tracker.Record(slo.ClassRead, http.StatusBadGateway, 20*time.Millisecond, at)
+	}
+	for i := 0; i < 50; i++ {
+		tracker.Record(slo.ClassRead, http.StatusNotFound, 500*time.Millisecond, at)
+	}
+
+	report := tracker.Report(now)
+
+	availability := objective(report, "read-availability")
+	assert.Equal(t, int64(1055), availability.Total)
+	assert.Equal(t, int64(1050), availability.Good)
+	assert.InDelta(t, 1-(5.0/1055)/0.01, availability.ErrorBudgetRemaining, 0.0001)
+
+	latency := objective(report, "read-latency")
+	assert.Equal(t, int64(1050), latency.Total)
+	assert.Equal(t, int64(1000), latency.Good)
+	assert.Equal(t, slo.StatusOK, report.Status)
+}
+
+func TestTracker_PagesOnFastBurn(t *testing.T) {
+	tracker := slo.NewTracker(objectives, slo.BurnRateAlerts)
+	for minute := 0; minute < 60; minute++ {
+		at := now.Add(-time.Duration(minute) * time.Minute)
+		for i := 0; i < 80; i++ {
+			tracker.Record(slo.ClassRead, http.StatusOK, 10*time.Millisecond, at)
+		}
+		for i := 0; i < 20; i++ {
+			tracker.Record(slo.ClassRead, http.StatusInternalServerError, 10*time.Millisecond, at)
+		}
+	}
+
+	report := tracker.Report(now)
+
+	availability := objective(report, "read-availability")
+	assert.Equal(t, slo.StatusExhausted, report.Status)
+	if assert.NotEmpty(t, availability.Alerts) {
+		assert.Equal(t, slo.SeverityPage, availability.Alerts[0].Severity)
+		assert.Equal(t, "1h", availability.Alerts[0].LongWindow)
+	}
+}
+
+func TestTracker_ForgetsRequestsOutsideTheWindow(t *testing.T) {
+	tracker := slo.NewTracker(objectives, slo.BurnRateAlerts)
+	tracker.Record(slo.ClassRead, http.StatusInternalServerError, 0, now.Add(-slo.Window-time.Minute))
+
+	report := tracker.Report(now)
+
+	assert.Equal(t, int64(0), objective(report, "read-availability").Total)
+	assert.Equal(t, slo.StatusOK, report.Status)
+}
+
+func TestTracker_HandlerGatesDeploys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tracker := slo.NewTracker(objectives, slo.BurnRateAlerts)
+	router := gin.New()
+	router.GET("/slo", tracker.Handler)
+
+	serve := func() *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/slo", nil))
+		return resp
+	}
+	assert.Equal(t, http.StatusOK, serve().Code)
+
+	tracker.Record(slo.ClassRead, http.StatusInternalServerError, 0, time.Now())
+	resp := serve()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Contains(t, resp.Body.String(), slo.StatusExhausted)
+
+	var metrics strings.Builder
+	assert.NoError(t, tracker.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `slo_requests_total{objective="read-availability",outcome="bad"} 1`)
+}
@@ -0,0 +1,82 @@
+package strictvalidation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/strictvalidation"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type createItemInput struct {
+	Limit  int               `query:"limit"`
+	Filter map[string]string `query:"filter" style:"deepObject"`
+	Body   struct {
+		Email string `json:"email"`
+		Age   int    `json:"age,omitempty"`
+	}
+}
+
+func newAPI(t *testing.T, strict bool) humatest.TestAPI {
+	previous, previousCasing := huma.NewError, huma.ValidateStrictCasing
+	huma.NewError = utils.NewHumaError
+	t.Cleanup(func() { huma.NewError, huma.ValidateStrictCasing = previous, previousCasing })
+
+	_, api := humatest.New(t)
+	if strict {
+		strictvalidation.Enable(api)
+	}
+	huma.Register(api, huma.Operation{OperationID: "create-item", Method: http.MethodPost, Path: "/items"},
+		func(ctx context.Context, input *createItemInput) (*struct{}, error) { return nil, nil })
+	return api
+}
+
+// locations returns the location of every field error of a validation error response
+func locations(t *testing.T, body []byte) []string {
+	t.Helper()
+	var response utils.HTTPErrorResponse
+	require.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, "VALIDATION_ERROR", response.Error.Code)
+	errs, _ := response.Error.Context["errors"].([]any)
+	var found []string
+	for _, err := range errs {
+		found = append(found, err.(map[string]any)["location"].(string))
+	}
+	return found
+}
+
+func TestStrictMode_RejectsUnknownQueryParameters(t *testing.T) {
+	api := newAPI(t, true)
+
+	response := api.Post("/items?limt=10&sort=name&limit=5", map[string]any{"email": "ada@example.com"})
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+	assert.Equal(t, []string{"query.limt", "query.sort"}, locations(t, response.Body.Bytes()))
+
+	assert.Equal(t, http.StatusNoContent, api.Post("/items?limit=5&filter[grade]=7", map[string]any{"email": "ada@example.com"}).Code)
+}
+
+func TestStrictMode_RejectsBodyFieldsInAnotherCasing(t *testing.T) {
+	api := newAPI(t, true)
+
+	response := api.Post("/items", map[string]any{"email": "ada@example.com", "Age": 12})
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+	assert.Equal(t, []string{"body.Age"}, locations(t, response.Body.Bytes()))
+}
+
+func TestDefaultMode_IgnoresUnknownQueryParametersAndCasing(t *testing.T) {
+	api := newAPI(t, false)
+
+	assert.Equal(t, http.StatusNoContent, api.Post("/items?limt=10", map[string]any{"email": "ada@example.com", "Age": 12}).Code)
+
+	// Unknown fields and type mismatches fail either way
+	response := api.Post("/items", map[string]any{"emial": "ada@example.com", "age": "12"})
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+	assert.ElementsMatch(t, []string{"body", "body.emial", "body.age"}, locations(t, response.Body.Bytes()))
+}
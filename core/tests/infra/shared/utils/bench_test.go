@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	userHandlers "github.com/nahualventure/class-backend/infra/user/handlers"
+)
+
+// BenchmarkApplicationErrorToHTTPResponse converts the errors handlers return most, a domain error
+// with context, an infrastructure error with a cause chain and an error from outside the application
+func BenchmarkApplicationErrorToHTTPResponse(b *testing.B) {
+	// Server errors are logged with their cause chain, it would split the result lines benchstat reads
+	log.SetOutput(io.Discard)
+	b.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	cases := map[string]error{
+		"domain": errors2.NewValidationError("Invalid grade", map[string]any{"score": "must be between 0 and 100"}, nil),
+		"infrastructure": errors2.NewInfrastructureError("failed to read grades",
+			fmt.Errorf("query grades: %w", errors.New("connection refused"))),
+		"foreign": errors.New("connection refused"),
+	}
+	for name, err := range cases {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				utils.ApplicationErrorToHTTPResponse(err)
+			}
+		})
+	}
+}
+
+// BenchmarkListResponseMarshal maps a page of users to the list envelope and encodes it as Huma does
+func BenchmarkListResponseMarshal(b *testing.B) {
+	createdAt := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	for _, size := range []int{100, 1000} {
+		page := &pagination.Page[*entities.User]{TotalEstimate: int64(size), NextCursor: "eyJhZnRlciI6WyIyMDI1Il19"}
+		for i := 0; i < size; i++ {
+			page.Items = append(page.Items, &entities.User{
+				ID:        fmt.Sprintf("00000000-0000-0000-0000-%012d", i),
+				Name:      fmt.Sprintf("Student %d", i),
+				Email:     fmt.Sprintf("student%d@example.com", i),
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+			})
+		}
+
+		b.Run(fmt.Sprintf("items=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				body := utils.NewListResponseBody(page, userHandlers.NewUserResponse)
+				if _, err := json.Marshal(body); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/shared/bulk"
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	coreUtils "github.com/nahualventure/class-backend/core/app/shared/utils"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchOfThree has a created item between an invalid one and one that failed to persist
+func batchOfThree() *bulk.Result[string] {
+	result := bulk.NewResult[string](3)
+	result.Fail(0, errors.PropagateError(coreUtils.ValidateStruct(validate, &enrollCommand{Email: "not-an-email"})))
+	result.Succeed(1, "created", "user-1")
+	result.Fail(2, errors.NewInfrastructureError("insert users", nil))
+	return result
+}
+
+func TestNewBulkItemResponse_TranslatesErrorsLikeTheEnvelope(t *testing.T) {
+	result := batchOfThree()
+
+	invalid := utils.NewBulkItemResponse(result.Items[0], "es-GT")
+	assert.Equal(t, 0, invalid.Index)
+	assert.Equal(t, "failed", invalid.Status)
+	assert.Equal(t, errors.ValidationError.String(), invalid.Error.Code)
+	assert.Equal(t, "tenant_id es un campo requerido", invalid.Error.Context["tenant_id"])
+
+	assert.Equal(t, "Internal server error", utils.NewBulkItemResponse(result.Items[2], "").Error.Message)
+
+	encoded, err := json.Marshal(utils.NewBulkItemResponse(result.Items[1], ""))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"index": 1, "status": "created"}`, string(encoded))
+}
+
+func TestNewBulkSummary_CountsEveryStatusButFailedAsSucceeded(t *testing.T) {
+	result := batchOfThree()
+	result.Succeed(2, "unchanged", "user-2")
+
+	assert.Equal(t, utils.BulkSummary{Succeeded: 2, Failed: 1}, utils.NewBulkSummary(result))
+	assert.Equal(t, utils.BulkSummary{}, utils.NewBulkSummary(bulk.NewResult[string](2)), "items being processed are not counted")
+}
+
+func TestBulkItemOutcomeToProto_MatchesTheHTTPEnvelope(t *testing.T) {
+	result := batchOfThree()
+
+	for _, item := range result.Items {
+		response := utils.NewBulkItemResponse(item, "")
+		outcome := utils.BulkItemOutcomeToProto(item, "")
+		assert.Equal(t, int32(response.Index), outcome.Index)
+		assert.Equal(t, response.Status, outcome.Status)
+		if response.Error == nil {
+			assert.Nil(t, outcome.Error)
+			continue
+		}
+		assert.Equal(t, response.Error.Code, outcome.Error.Code)
+		assert.Equal(t, response.Error.Message, outcome.Error.Message)
+	}
+
+	summary := utils.BulkSummaryToProto(result)
+	assert.Equal(t, int32(1), summary.Succeeded)
+	assert.Equal(t, int32(2), summary.Failed)
+}
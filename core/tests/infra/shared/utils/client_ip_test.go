@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func clientIP(t *testing.T, trustedProxies []string, remoteAddr string, header http.Header) string {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	assert.NoError(t, router.SetTrustedProxies(trustedProxies))
+	router.Use(utils.ClientIPMiddleware)
+
+	var resolved string
+	router.GET("/", func(c *gin.Context) { resolved = utils.ClientIPFromContext(c.Request.Context()) })
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.RemoteAddr = remoteAddr
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	router.ServeHTTP(httptest.NewRecorder(), request)
+	return resolved
+}
+
+func TestClientIP_BelievesTrustedProxies(t *testing.T) {
+	forwarded := http.Header{"X-Forwarded-For": {"203.0.113.7, 10.0.0.5"}}
+
+	assert.Equal(t, "203.0.113.7", clientIP(t, []string{"10.0.0.0/8"}, "10.0.0.9:4312", forwarded))
+	assert.Equal(t, "203.0.113.7", clientIP(t, []string{"10.0.0.0/8"}, "10.0.0.9:4312", http.Header{"X-Real-Ip": {"203.0.113.7"}}))
+}
+
+func TestClientIP_IgnoresHeadersFromOtherPeers(t *testing.T) {
+	forwarded := http.Header{"X-Forwarded-For": {"203.0.113.7"}}
+
+	assert.Equal(t, "198.51.100.1", clientIP(t, []string{"10.0.0.0/8"}, "198.51.100.1:4312", forwarded))
+	assert.Equal(t, "198.51.100.1", clientIP(t, nil, "198.51.100.1:4312", forwarded))
+}
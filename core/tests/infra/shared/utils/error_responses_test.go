@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// errorResponsesGolden holds the status and body of every registered error code, UPDATE_GOLDEN=1
+// rewrites it. A diff in review is a change clients see.
+const errorResponsesGolden = "testdata/error_responses.golden.json"
+
+type renderedError struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+func renderError(t *testing.T, err error) renderedError {
+	response := utils.ApplicationErrorToHTTPResponse(err)
+	body, marshalErr := json.Marshal(response)
+	assert.NoError(t, marshalErr)
+	return renderedError{Status: response.Status, Body: body}
+}
+
+// goldenErrors returns an error of every registered code, keyed like the golden file
+func goldenErrors(occurredAt time.Time) map[string]error {
+	goldenErrs := make(map[string]error)
+	for code := range utils.ErrorCodeToHTTPStatus {
+		goldenErrs[code.String()] = &errors2.BaseDomainError{BaseError: errors2.BaseError{
+			Code:       code.String(),
+			Message:    "Message of " + code.String(),
+			Context:    map[string]any{"field": "value"},
+			OccurredAt: occurredAt,
+		}}
+	}
+
+	// Errors without a mapping, infrastructure errors and errors that are not application errors
+	// answer 500 without their message
+	goldenErrs["(unmapped)"] = &errors2.BaseDomainError{BaseError: errors2.BaseError{
+		Code: "NOT_REGISTERED", Message: "Details of the failure", OccurredAt: occurredAt,
+	}}
+	infrastructureErr := errors2.NewInfrastructureError("failed to read grades", errors.New("connection refused"))
+	infrastructureErr.OccurredAt = occurredAt
+	goldenErrs["(infrastructure)"] = infrastructureErr
+	goldenErrs["(not an application error)"] = errors.New("connection refused")
+	return goldenErrs
+}
+
+func TestErrorResponses_MatchGolden(t *testing.T) {
+	occurredAt := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	rendered := make(map[string]renderedError)
+	for key, err := range goldenErrors(occurredAt) {
+		rendered[key] = renderError(t, err)
+	}
+	fallback := utils.ApplicationErrorToHTTPResponse(errors.New("connection refused"))
+	fallback.Error.Timestamp = occurredAt.Format(time.RFC3339)
+	body, err := json.Marshal(fallback)
+	assert.NoError(t, err)
+	rendered["(not an application error)"] = renderedError{Status: fallback.Status, Body: body}
+
+	got, err := json.MarshalIndent(rendered, "", "  ")
+	assert.NoError(t, err)
+	got = append(got, '\n')
+
+	if os.Getenv("UPDATE_GOLDEN") == "1" {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(errorResponsesGolden), 0o755))
+		assert.NoError(t, os.WriteFile(errorResponsesGolden, got, 0o644))
+	}
+	want, err := os.ReadFile(errorResponsesGolden)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(want), string(got), "error responses changed, run with UPDATE_GOLDEN=1 when intended")
+}
+
+func TestErrorResponses_EveryDocumentedCodeIsMapped(t *testing.T) {
+	for operationID, codes := range utils.OperationErrors {
+		for _, code := range codes {
+			_, ok := utils.ErrorCodeToHTTPStatus[code]
+			assert.True(t, ok, "%s of %s has no HTTP status and would answer 500", code, operationID)
+		}
+	}
+}
+
+// TestErrorResponses_ProtoMatchesGolden checks that gRPC callers get the code, message and context
+// HTTP callers get, the proto error detail has no golden file of its own
+func TestErrorResponses_ProtoMatchesGolden(t *testing.T) {
+	content, err := os.ReadFile(errorResponsesGolden)
+	assert.NoError(t, err)
+	var golden map[string]struct {
+		Body utils.HTTPErrorResponse `json:"body"`
+	}
+	assert.NoError(t, json.Unmarshal(content, &golden))
+
+	goldenErrs := goldenErrors(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC))
+	assert.Len(t, golden, len(goldenErrs))
+	for key, err := range goldenErrs {
+		want := golden[key].Body.Error
+		detail := utils.ErrorDetailToProto(err, "")
+
+		assert.Equal(t, want.Code, detail.Code, key)
+		assert.Equal(t, want.Message, detail.Message, key)
+		context := make(map[string]string)
+		for field, value := range detail.Metadata {
+			context[field] = value
+		}
+		for _, violation := range detail.FieldViolations {
+			context[violation.Field] = violation.Description
+		}
+		wantContext := make(map[string]string)
+		for field, value := range want.Context {
+			wantContext[field] = fmt.Sprint(value)
+		}
+		assert.Equal(t, wantContext, context, key)
+
+		status := utils.ApplicationErrorToGRPCStatus(err, "")
+		assert.Equal(t, want.Message, status.Message(), key)
+		assert.Len(t, status.Details(), 1, key)
+	}
+}
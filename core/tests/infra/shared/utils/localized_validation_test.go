@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	coreUtils "github.com/nahualventure/class-backend/core/app/shared/utils"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type enrollCommand struct {
+	TenantID string `validate:"required"`
+	Email    string `validate:"required,email"`
+	Nickname string `json:"nick" validate:"max=5"`
+}
+
+var validate = validator.New()
+
+func newLocalizedAPI(t *testing.T) humatest.TestAPI {
+	config := huma.DefaultConfig("Test", "1.0.0")
+	config.Transformers = append(config.Transformers, utils.LocalizeErrors)
+	_, api := humatest.New(t, config)
+
+	huma.Post(api, "/enroll", func(ctx context.Context, input *struct{}) (*struct{}, error) {
+		err := coreUtils.ValidateStruct(validate, &enrollCommand{Email: "not-an-email", Nickname: "too long"})
+		return nil, utils.ApplicationErrorToHumaError(errors.PropagateError(err))
+	})
+	return api
+}
+
+func errorContext(t *testing.T, body []byte) map[string]any {
+	var envelope utils.HTTPErrorResponse
+	assert.NoError(t, json.Unmarshal(body, &envelope))
+	assert.Equal(t, errors.ValidationError.String(), envelope.Error.Code)
+	return envelope.Error.Context
+}
+
+func TestValidateStruct_KeysErrorsByJSONName(t *testing.T) {
+	err := coreUtils.ValidateStruct(validate, &enrollCommand{Email: "not-an-email", Nickname: "too long"})
+
+	assert.Equal(t, map[string]any{
+		"tenant_id": "This field is required",
+		"email":     "Invalid email address",
+		"nick":      "Too long (maximum 5 characters)",
+	}, err.Context)
+}
+
+func TestLocalizeErrors_TranslatesToRequestLocale(t *testing.T) {
+	api := newLocalizedAPI(t)
+
+	spanish := api.Post("/enroll", "Accept-Language: es-GT,es;q=0.9")
+	assert.Equal(t, http.StatusBadRequest, spanish.Code)
+	assert.Equal(t, "tenant_id es un campo requerido", errorContext(t, spanish.Body.Bytes())["tenant_id"])
+
+	portuguese := api.Post("/enroll", "Accept-Language: pt-BR")
+	assert.Equal(t, "tenant_id é obrigatório", errorContext(t, portuguese.Body.Bytes())["tenant_id"])
+}
+
+func TestLocalizeErrors_FallsBackToEnglish(t *testing.T) {
+	api := newLocalizedAPI(t)
+
+	for _, header := range []string{"Accept-Language: fr-FR", "Accept-Language: en-US"} {
+		resp := api.Post("/enroll", header)
+		context := errorContext(t, resp.Body.Bytes())
+		assert.Equal(t, "This field is required", context["tenant_id"])
+		assert.Equal(t, "Invalid email address", context["email"])
+	}
+}
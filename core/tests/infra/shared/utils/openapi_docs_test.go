@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+)
+
+type createItemInput struct {
+	Body struct {
+		Name  string `json:"name" minLength:"3" example:"Algebra"`
+		Count int    `json:"count" minimum:"1"`
+	}
+}
+
+type createItemOutput struct {
+	Body struct {
+		ID string `json:"id" format:"uuid"`
+	}
+}
+
+func newDocumentedAPI(t *testing.T, public string) humatest.TestAPI {
+	previous := huma.NewError
+	huma.NewError = utils.NewHumaError
+	t.Cleanup(func() { huma.NewError = previous })
+
+	_, api := humatest.New(t)
+	api.OpenAPI().OnAddOperation = append(api.OpenAPI().OnAddOperation, utils.DocumentOperation(func(operationID string) bool {
+		return operationID == public
+	}))
+	return api
+}
+
+func registerCreateItem(api huma.API, operationID string) {
+	huma.Register(api, huma.Operation{
+		OperationID: operationID,
+		Method:      http.MethodPost,
+		Path:        "/" + operationID,
+	}, func(ctx context.Context, input *createItemInput) (*createItemOutput, error) {
+		return &createItemOutput{}, nil
+	})
+}
+
+func TestDocumentOperation_DocumentsErrorCodesPerStatus(t *testing.T) {
+	api := newDocumentedAPI(t, "")
+	registerCreateItem(api, "get-form")
+
+	responses := api.OpenAPI().Paths["/get-form"].Post.Responses
+	assert.NotContains(t, responses, "default")
+	for _, status := range []string{"400", "401", "403", "404", "422", "429", "500", "503"} {
+		assert.Contains(t, responses, status)
+	}
+
+	notFound := responses["404"].Content["application/json"]
+	assert.Contains(t, notFound.Examples, surveysErrors.FormNotFoundError.String())
+	assert.Contains(t, responses["404"].Description, "`FORM_NOT_FOUND`")
+	assert.Equal(t, "#/components/schemas/HTTPErrorResponse", notFound.Schema.Ref)
+}
+
+func TestDocumentOperation_PublicOperationsSkipAuthorizationErrors(t *testing.T) {
+	api := newDocumentedAPI(t, "public-item")
+	registerCreateItem(api, "public-item")
+
+	responses := api.OpenAPI().Paths["/public-item"].Post.Responses
+	assert.NotContains(t, responses, "401")
+	assert.NotContains(t, responses, "403")
+	assert.Contains(t, responses, "422")
+}
+
+func TestDocumentOperation_InjectsBodyExamples(t *testing.T) {
+	api := newDocumentedAPI(t, "")
+	registerCreateItem(api, "create-item")
+
+	operation := api.OpenAPI().Paths["/create-item"].Post
+	request, ok := operation.RequestBody.Content["application/json"].Example.(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, "Algebra", request["name"])
+	assert.Equal(t, float64(1), request["count"])
+
+	response, ok := operation.Responses["200"].Content["application/json"].Example.(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, "3fa85f64-5717-4562-b3fc-2c963f66afa6", response["id"])
+}
+
+func TestNewHumaError_ValidationUsesTheEnvelope(t *testing.T) {
+	api := newDocumentedAPI(t, "")
+	registerCreateItem(api, "create-item")
+
+	resp := api.Post("/create-item", strings.NewReader(`{"name":"ab","count":1}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+
+	var body utils.HTTPErrorResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, errors2.ValidationError.String(), body.Error.Code)
+	assert.NotEmpty(t, body.Error.Context["errors"])
+}
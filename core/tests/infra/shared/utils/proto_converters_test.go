@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	coreUtils "github.com/nahualventure/class-backend/core/app/shared/utils"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	commonv1 "github.com/nahualventure/class-backend/proto/gen/common/v1"
+
+	cockroachErrors "github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestPageRequestFromProto_ValidatesLikeQueryParams(t *testing.T) {
+	defaultOrder := pagination.OrderBy{Field: "created_at", Direction: pagination.Descending}
+
+	request, err := utils.PageRequestFromProto(&commonv1.PageRequest{PageSize: 10, OrderBy: "name desc"}, []string{"name", "created_at"}, defaultOrder)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, request.PageSize)
+
+	_, err = utils.PageRequestFromProto(&commonv1.PageRequest{OrderBy: "password"}, []string{"name"}, defaultOrder)
+	assert.Error(t, err)
+}
+
+func TestErrorDetailToProto_ValidationErrorsBecomeFieldViolations(t *testing.T) {
+	err := coreUtils.ValidateStruct(validate, &enrollCommand{Email: "not-an-email", Nickname: "too long"})
+
+	detail := utils.ErrorDetailToProto(errors.PropagateError(err), "es-GT")
+
+	assert.Equal(t, errors.ValidationError.String(), detail.Code)
+	assert.False(t, detail.Retryable)
+	assert.Len(t, detail.FieldViolations, 3)
+	assert.Equal(t, "email", detail.FieldViolations[0].Field)
+	assert.Equal(t, "tenant_id", detail.FieldViolations[2].Field)
+	assert.Equal(t, "tenant_id es un campo requerido", detail.FieldViolations[2].Description)
+	assert.Equal(t, "es", detail.LocalizedMessage.GetLocale())
+	assert.NotNil(t, detail.OccurredAt)
+}
+
+func TestErrorDetailToProto_InfrastructureErrorsHideTheCause(t *testing.T) {
+	err := errors.NewInfrastructureError("cache get", cockroachErrors.New("dial tcp 10.0.0.3:6379: refused")).
+		WithSafeContext(errors.SafeContext{Operation: "get", Resource: "redis", Retryable: true})
+
+	detail := utils.ErrorDetailToProto(err, "")
+
+	assert.True(t, detail.Retryable)
+	assert.NotContains(t, detail.Message, "10.0.0.3")
+	assert.Equal(t, "redis", detail.Metadata["resource"])
+	assert.Empty(t, detail.FieldViolations)
+	assert.Nil(t, detail.LocalizedMessage)
+}
+
+func TestAuditInfoToProto_UsesSnakeCaseJSONNames(t *testing.T) {
+	createdAt := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+
+	audit := utils.AuditInfoToProto("teacher-1", createdAt, "", time.Time{})
+
+	assert.Equal(t, "teacher-1", audit.UpdatedBy)
+	assert.True(t, audit.UpdatedAt.AsTime().Equal(createdAt))
+	encoded, err := protojson.Marshal(audit)
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"created_by"`)
+	assert.Contains(t, string(encoded), `"updated_at"`)
+}
@@ -0,0 +1,92 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/nahualventure/class-backend/infra/user/adapters"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Hashes as the former systems store them: Django with its default layout, phpass from the test
+// vectors of its reference implementation
+const (
+	djangoSHA256 = "pbkdf2_sha256$1000$Wq5Ry2mNxT8b$BUt6uPOSZKme+jLdu1GnGxkbTkRqgNx5G9mBdFrQlmU="
+	djangoSHA1   = "pbkdf2_sha1$1000$Wq5Ry2mNxT8b$mhOPkdyAGiNxvFmIwSZiuvUlppw="
+	phpass       = "$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0"
+)
+
+func TestMultiSchemePasswordHasher_VerifiesLegacyHashes(t *testing.T) {
+	hasher := adapters.NewMultiSchemePasswordHasher()
+	cases := map[string]struct {
+		hash     string
+		password string
+	}{
+		"django pbkdf2_sha256": {djangoSHA256, "correct horse"},
+		"django pbkdf2_sha1":   {djangoSHA1, "correct horse"},
+		"phpass":               {phpass, "test12345"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, hasher.Recognizes(tc.hash))
+
+			matches, needsRehash, err := hasher.Verify(tc.password, tc.hash)
+			assert.NoError(t, err)
+			assert.True(t, matches)
+			assert.True(t, needsRehash, "legacy hashes are replaced once the password matches")
+
+			matches, needsRehash, err = hasher.Verify(tc.password+"!", tc.hash)
+			assert.NoError(t, err)
+			assert.False(t, matches)
+			assert.False(t, needsRehash)
+		})
+	}
+}
+
+func TestMultiSchemePasswordHasher_HashesWithBcrypt(t *testing.T) {
+	hasher := adapters.NewMultiSchemePasswordHasher()
+
+	hash, err := hasher.Hash("correct horse")
+	assert.NoError(t, err)
+	assert.True(t, hasher.Recognizes(hash))
+
+	matches, needsRehash, err := hasher.Verify("correct horse", hash)
+	assert.NoError(t, err)
+	assert.True(t, matches)
+	assert.False(t, needsRehash)
+
+	matches, _, err = hasher.Verify("wrong horse", hash)
+	assert.NoError(t, err)
+	assert.False(t, matches)
+}
+
+func TestMultiSchemePasswordHasher_RehashesWeakerBcrypt(t *testing.T) {
+	hasher := adapters.NewMultiSchemePasswordHasher()
+	weak, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	matches, needsRehash, err := hasher.Verify("correct horse", string(weak))
+	assert.NoError(t, err)
+	assert.True(t, matches)
+	assert.True(t, needsRehash)
+}
+
+func TestMultiSchemePasswordHasher_RejectsUnknownHashes(t *testing.T) {
+	hasher := adapters.NewMultiSchemePasswordHasher()
+
+	for _, hash := range []string{
+		"",
+		"plaintext",
+		"md5$salt$5f4dcc3b5aa765d61d8327deb882cf99",
+		"pbkdf2_sha512$1000$salt$c2VjcmV0",
+		"pbkdf2_sha256$many$salt$c2VjcmV0",
+		"$P$9IQRaTwmf",
+		"$P$!IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0",
+	} {
+		assert.False(t, hasher.Recognizes(hash), hash)
+		_, _, err := hasher.Verify("password", hash)
+		assert.Error(t, err, hash)
+	}
+}
@@ -0,0 +1,50 @@
+package adapters
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/infra/user/adapters"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPostgresUserRepository_FindExistingEmailsIgnoresCase runs against the migrated database in
+// TEST_DATABASE_URL. Batch sign up dedupes lowercased emails, an existing user must be found
+// whatever the case of the email in the batch.
+func TestPostgresUserRepository_FindExistingEmailsIgnoresCase(t *testing.T) {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer pool.Close()
+
+	email := "Mixed.Case-" + uuid.NewString()[:8] + "@Example.com"
+	user, err := entities.NewUser(uuid.NewString(), "Mixed Case", email, time.Now(), time.Now())
+	if !assert.NoError(t, err) {
+		return
+	}
+	repo := adapters.NewPostgresUserRepository(pool)
+	_, err = repo.Create(user, "$2a$10$placeholder")
+	if !assert.NoError(t, err) {
+		return
+	}
+	t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM users WHERE id = $1", user.ID) })
+
+	for _, candidate := range []string{email, "mixed.case" + email[len("Mixed.Case"):], "MIXED.CASE" + email[len("Mixed.Case"):]} {
+		existing, err := repo.FindExistingEmails([]string{candidate, "nobody@example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{email}, existing, candidate)
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/filtering"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	list_users_use_case "github.com/nahualventure/class-backend/core/app/user/application/use-cases/list-users-use-case"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/tests/authztest"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/grpcserver"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	userHandlers "github.com/nahualventure/class-backend/infra/user/handlers"
+	commonv1 "github.com/nahualventure/class-backend/proto/gen/common/v1"
+	userv1 "github.com/nahualventure/class-backend/proto/gen/user/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeUserLister only has users in tenant1, the tenant of the calls
+type fakeUserLister struct {
+	page *pagination.Page[*entities.User]
+}
+
+func (f *fakeUserLister) List(tenantID string, page *pagination.PageRequest, filter filtering.Expr) (*pagination.Page[*entities.User], error) {
+	if tenantID != "tenant1" {
+		return &pagination.Page[*entities.User]{}, nil
+	}
+	return f.page, nil
+}
+
+// dialUsers serves the user service of authz on an in-memory listener
+func dialUsers(t *testing.T, authz *authztest.Scenario) userv1.UserServiceClient {
+	createdAt := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	lister := &fakeUserLister{page: &pagination.Page[*entities.User]{
+		Items:         []*entities.User{{ID: "user-1", Name: "Ada", Email: "ada@example.com", CreatedAt: createdAt, UpdatedAt: createdAt}},
+		NextCursor:    "next",
+		TotalEstimate: 1,
+	}}
+	service := authz.Service()
+	server := grpcserver.New(service, grpcserver.Options{}, userHandlers.NewUserGRPCHandlers(
+		list_users_use_case.NewListUsersUseCase(lister), authorization.NewRedactor(service)))
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return userv1.NewUserServiceClient(conn)
+}
+
+func as(userID string) context.Context {
+	return requestmeta.Meta{UserID: userID, TenantID: "tenant1"}.AppendToOutgoingGRPC(context.Background())
+}
+
+func errorDetail(t *testing.T, err error) (codes.Code, *commonv1.ErrorDetail) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Len(t, st.Details(), 1)
+	detail, ok := st.Details()[0].(*commonv1.ErrorDetail)
+	require.True(t, ok)
+	return st.Code(), detail
+}
+
+func TestUserGRPCHandlers_ListsUsersWithThePermissionsOfTheOperation(t *testing.T) {
+	authz := authztest.New(t)
+	authz.Grant("teacher", "user", "view")
+	authz.Grant("admin", "user", "view")
+	authz.Grant("admin", "user", "view_contact")
+	authz.Assign("teacher-1", "teacher").In("tenant1")
+	authz.Assign("admin-1", "admin").In("tenant1")
+	client := dialUsers(t, authz)
+
+	response, err := client.ListUsers(as("teacher-1"), &userv1.ListUsersRequest{Page: &commonv1.PageRequest{PageSize: 10}})
+	require.NoError(t, err)
+	require.Len(t, response.Users, 1)
+	assert.Equal(t, "Ada", response.Users[0].Name)
+	assert.Empty(t, response.Users[0].Email, "the email needs user:view_contact")
+	assert.Equal(t, "2026-02-01T09:00:00Z", response.Users[0].Audit.CreatedAt.AsTime().Format(time.RFC3339))
+	assert.Equal(t, "next", response.Page.NextCursor)
+	assert.Equal(t, int64(1), response.Page.TotalEstimate)
+
+	response, err = client.ListUsers(as("admin-1"), &userv1.ListUsersRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com", response.Users[0].Email)
+
+	_, err = client.ListUsers(as("student-1"), &userv1.ListUsersRequest{})
+	code, detail := errorDetail(t, err)
+	assert.Equal(t, codes.PermissionDenied, code)
+	assert.Equal(t, errors.Forbidden.String(), detail.Code)
+
+	_, err = client.ListUsers(context.Background(), &userv1.ListUsersRequest{})
+	code, detail = errorDetail(t, err)
+	assert.Equal(t, codes.Unauthenticated, code)
+	assert.Equal(t, errors.Unauthorized.String(), detail.Code)
+}
+
+func TestUserGRPCHandlers_ValidatesThePageLikeTheHTTPList(t *testing.T) {
+	authz := authztest.New(t)
+	authz.Grant("teacher", "user", "view")
+	authz.Assign("teacher-1", "teacher").In("tenant1")
+	client := dialUsers(t, authz)
+
+	_, err := client.ListUsers(as("teacher-1"), &userv1.ListUsersRequest{Page: &commonv1.PageRequest{OrderBy: "password"}})
+	code, detail := errorDetail(t, err)
+	assert.Equal(t, codes.InvalidArgument, code)
+	assert.Equal(t, errors.ValidationError.String(), detail.Code)
+
+	_, err = client.ListUsers(as("teacher-1"), &userv1.ListUsersRequest{Page: &commonv1.PageRequest{Filter: "password == \"x\""}})
+	code, _ = errorDetail(t, err)
+	assert.Equal(t, codes.InvalidArgument, code)
+}
@@ -0,0 +1,645 @@
+// Package isolation seeds two tenants with overlapping data through the API backed by the Postgres
+// repositories, then reads every GET endpoint of the container as an admin of the first tenant. No
+// record of the second tenant may come back, whether it is looked up by the IDs of the first tenant
+// or by its own. Each tenant also gets a member of its own in the users table, the lists of users
+// may only show the members of the tenant asking. Needs a migrated database in TEST_DATABASE_URL.
+package isolation
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	generate_report_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/generate-report-use-case"
+	"github.com/nahualventure/class-backend/infra/configs"
+	reportAdapters "github.com/nahualventure/class-backend/infra/report/adapters"
+	"github.com/nahualventure/class-backend/infra/shared/adapters"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/container"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humagin"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+// notSeeded lists the GET endpoints whose path parameters no seed fills, with the reason. Any other
+// endpoint without a value for its parameters fails the test, so new endpoints are checked or
+// explicitly excused here.
+var notSeeded = map[string]string{
+	"get-user-merge":              "merges read the members from casbin_rule, the roles of the harness are kept in memory",
+	"get-directory-sync-conflict": "conflicts are found among the members, which the sync reads from casbin_rule; the roles of the harness are kept in memory",
+}
+
+// shared values are the same in both tenants, as IDs coming from another system would be
+var (
+	adminID      = uuid.NewString()
+	studentID    = uuid.NewString()
+	classID      = uuid.NewString()
+	assignmentID = uuid.NewString()
+	day          = time.Now().UTC().AddDate(0, 1, 0).Format(time.DateOnly)
+)
+
+// tenant keeps what was seeded in a tenant, the path parameter values pointing at its records and
+// the values that must not show up anywhere in the responses of the other tenant
+type tenant struct {
+	id     string
+	marker string
+	// memberID is the user holding a role in this tenant only
+	memberID string
+	params   map[string]string
+	leaks    []string
+}
+
+// seed creates a record through the API, its text fields carry the marker of the tenant
+type seed struct {
+	name   string
+	method string
+	path   string
+	body   func(marker string) string
+	status int
+	// as sends the request, the admin when empty
+	as string
+	// captures maps the path parameters the record fills to the dotted path of their value in the
+	// response, the values are also looked for in the responses of the other tenant
+	captures map[string]string
+	// visibleAt is a GET path showing the record to the user who created it, to catch a seed that
+	// silently stored nothing and a harness that only checks empty responses
+	visibleAt string
+}
+
+var seeds = []seed{
+	{
+		name:   "grade",
+		method: http.MethodPut,
+		path:   "/classes/{classId}/assignments/{assignmentId}/grades/{studentId}",
+		body: func(marker string) string {
+			return `{"score": 90, "reason": "` + marker + `"}`
+		},
+		status:    http.StatusOK,
+		captures:  map[string]string{"gradeId": "id"},
+		visibleAt: "/classes/{classId}/grades",
+	},
+	{
+		name:   "incident",
+		method: http.MethodPost,
+		path:   "/incidents",
+		body: func(marker string) string {
+			return fmt.Sprintf(`{"type": "other", "severity": "low", "summary": %q, "student_ids": [%q], "occurred_at": %q}`,
+				marker, studentID, time.Now().UTC().Add(-time.Hour).Format(time.RFC3339))
+		},
+		status:    http.StatusCreated,
+		captures:  map[string]string{"incidentId": "id"},
+		visibleAt: "/incidents/{incidentId}",
+	},
+	{
+		name:   "resource",
+		method: http.MethodPost,
+		path:   "/library/resources",
+		body: func(marker string) string {
+			return `{"kind": "link", "title": "` + marker + `", "url": "https://example.com/` + marker + `"}`
+		},
+		status:    http.StatusCreated,
+		captures:  map[string]string{"resourceId": "id"},
+		visibleAt: "/library/resources/{resourceId}",
+	},
+	{
+		name:   "translation",
+		method: http.MethodPut,
+		path:   "/translations/resource/{resourceId}/es-GT",
+		body: func(marker string) string {
+			return `{"fields": {"title": "` + marker + ` (es)"}}`
+		},
+		status:    http.StatusOK,
+		visibleAt: "/translations/resource/{resourceId}",
+	},
+	{
+		name:   "form",
+		method: http.MethodPost,
+		path:   "/forms",
+		body: func(marker string) string {
+			return `{"title": "` + marker + `", "questions": [{"id": "q1", "prompt": "How was the week?", "type": "short_text"}]}`
+		},
+		status:    http.StatusCreated,
+		captures:  map[string]string{"formId": "id"},
+		visibleAt: "/forms/{formId}",
+	},
+	{
+		name:   "availability slot",
+		method: http.MethodPost,
+		path:   "/office-hours/slots",
+		body: func(marker string) string {
+			startsAt := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Hour)
+			return fmt.Sprintf(`{"starts_at": %q, "ends_at": %q, "capacity": 1, "location": %q}`,
+				startsAt.Format(time.RFC3339), startsAt.Add(time.Hour).Format(time.RFC3339), marker)
+		},
+		status:    http.StatusCreated,
+		captures:  map[string]string{"slotId": "id"},
+		visibleAt: "/teachers/{teacherId}/office-hours",
+	},
+	{
+		name:   "appointment",
+		method: http.MethodPost,
+		path:   "/office-hours/slots/{slotId}/appointments",
+		body: func(marker string) string {
+			return `{"topic": "` + marker + `"}`
+		},
+		status:    http.StatusCreated,
+		as:        studentID,
+		captures:  map[string]string{"appointmentId": "id"},
+		visibleAt: "/appointments",
+	},
+	{
+		name:   "direct message",
+		method: http.MethodPost,
+		path:   "/messages/direct/{studentId}",
+		body: func(marker string) string {
+			return `{"body": "` + marker + `", "attachments": [{"file_name": "` + marker + `.txt", "content_type": "text/plain", "content": "aGVsbG8="}]}`
+		},
+		status:    http.StatusCreated,
+		captures:  map[string]string{"messageId": "id", "conversationId": "conversation_id", "attachmentId": "attachments.0.id"},
+		visibleAt: "/conversations/{conversationId}/messages",
+	},
+	{
+		name:   "non-instructional day",
+		method: http.MethodPut,
+		path:   "/calendar/non-instructional-days/{date}",
+		body: func(marker string) string {
+			return `{"name": "` + marker + `"}`
+		},
+		status:    http.StatusOK,
+		visibleAt: "/calendar/non-instructional-days",
+	},
+	{
+		name:   "report",
+		method: http.MethodPost,
+		path:   "/reports",
+		body: func(string) string {
+			return `{"definition": "attendance_summary"}`
+		},
+		status:    http.StatusAccepted,
+		captures:  map[string]string{"reportId": "id"},
+		visibleAt: "/reports/{reportId}",
+	},
+	{
+		name:   "service account",
+		method: http.MethodPost,
+		path:   "/service-accounts",
+		body: func(marker string) string {
+			return `{"name": "` + marker + `", "roles": ["instructor"]}`
+		},
+		status: http.StatusCreated,
+		captures: map[string]string{"serviceAccountId": "service_account.id", "serviceAccountSubject": "service_account.subject",
+			"keyId": "key_id"},
+		visibleAt: "/service-accounts/{serviceAccountId}",
+	},
+	{
+		name:   "group role assignment",
+		method: http.MethodPost,
+		path:   "/role-assignments/groups",
+		body: func(string) string {
+			return `{"group_type": "class", "group_id": "` + classID + `", "role": "student"}`
+		},
+		status: http.StatusAccepted,
+		// The grade was recorded under the shared assignment before, assignmentId of the group paths
+		// is the ID of the group assignment
+		captures:  map[string]string{"assignmentId": "id"},
+		visibleAt: "/role-assignments/groups/{assignmentId}",
+	},
+	{
+		name:   "member deactivation",
+		method: http.MethodPost,
+		path:   "/role-assignments/deactivations",
+		body: func(string) string {
+			return `{"role": "student"}`
+		},
+		status:    http.StatusCreated,
+		captures:  map[string]string{"deactivationId": "id"},
+		visibleAt: "/role-assignments/deactivations/{deactivationId}",
+	},
+	{
+		name:   "import",
+		method: http.MethodPost,
+		path:   "/imports",
+		body: func(marker string) string {
+			content := base64.StdEncoding.EncodeToString([]byte("date,name\n" + day + "," + marker + "\n"))
+			return `{"entity": "non_instructional_days", "file_name": "` + marker + `.csv", "content": "` + content + `"}`
+		},
+		status:    http.StatusAccepted,
+		captures:  map[string]string{"importId": "id"},
+		visibleAt: "/imports/{importId}",
+	},
+	{
+		name:   "mapping template",
+		method: http.MethodPost,
+		path:   "/imports/templates",
+		body: func(marker string) string {
+			return `{"entity": "non_instructional_days", "name": "` + marker + `", "mapping": {"name": "` + marker + `"}}`
+		},
+		status:    http.StatusOK,
+		captures:  map[string]string{"templateId": "id"},
+		visibleAt: "/imports/templates",
+	},
+	{
+		name:      "backup",
+		method:    http.MethodPost,
+		path:      "/backups",
+		body:      func(string) string { return "" },
+		status:    http.StatusAccepted,
+		captures:  map[string]string{"backupId": "id"},
+		visibleAt: "/backups/{backupId}",
+	},
+	{
+		name:   "widget key",
+		method: http.MethodPost,
+		path:   "/widget-keys",
+		body: func(marker string) string {
+			return `{"name": "` + marker + `", "allowed_origins": ["https://` + marker + `.example.com"], ` +
+				`"widgets": ["announcements", "calendar"], "class_ids": ["` + classID + `"]}`
+		},
+		status: http.StatusCreated,
+		// The key is public on the website of the tenant, but no other tenant lists it
+		captures:  map[string]string{"widgetKeyId": "id", "widgetKey": "key"},
+		visibleAt: "/widget-keys",
+	},
+	{
+		name:   "announcement",
+		method: http.MethodPost,
+		path:   "/announcements",
+		body: func(marker string) string {
+			return `{"title": "` + marker + `", "body": "` + marker + `"}`
+		},
+		status:    http.StatusCreated,
+		captures:  map[string]string{"announcementId": "id"},
+		visibleAt: "/announcements",
+	},
+}
+
+// queries fills the required query parameters of the GET endpoints, the range covers the seeded day
+var queries = map[string]url.Values{
+	"/calendar/non-instructional-days": {
+		"from": {time.Now().UTC().Format(time.DateOnly)},
+		"to":   {time.Now().UTC().AddDate(0, 2, 0).Format(time.DateOnly)},
+	},
+}
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+type server struct {
+	*httptest.Server
+	api huma.API
+}
+
+// newServer wires the router of main over the Postgres adapters. The roles of both tenants are
+// served by an in-memory Casbin service loaded with policies.yaml, the same admin and student are
+// members of both.
+func newServer(t *testing.T, pool *pgxpool.Pool, tenantIDs ...string) *server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	previous := huma.NewError
+	huma.NewError = utils.NewHumaError
+	t.Cleanup(func() { huma.NewError = previous })
+
+	loader := authorization.NewPolicyLoader()
+	if err := loader.LoadFromFS(configs.Assets(""), configs.PoliciesFile); err != nil {
+		t.Fatalf("failed to load the policies: %v", err)
+	}
+	model, err := fs.ReadFile(configs.Assets(""), configs.RBACModelFile)
+	if err != nil {
+		t.Fatalf("failed to read the Casbin model: %v", err)
+	}
+	authz, authzErr := authorization.NewInMemoryCasbinService(string(model), loader, tenantIDs)
+	if authzErr != nil {
+		t.Fatalf("failed to build the authorization service: %v", authzErr)
+	}
+	for _, tenantID := range tenantIDs {
+		for userID, role := range map[string]string{adminID: "admin", studentID: "student"} {
+			if err := authz.AssignRole(userID, role, tenantID); err != nil {
+				t.Fatalf("failed to give %s the %s role in %s: %v", userID, role, tenantID, err)
+			}
+		}
+	}
+	files, err := adapters.NewFilesystemStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create the file storage: %v", err)
+	}
+
+	router := gin.New()
+	router.Use(utils.ClientIPMiddleware)
+	router.Use(requestmeta.Middleware)
+
+	humaConfig := huma.DefaultConfig("Class Backend API", "test")
+	humaConfig.Transformers = append(humaConfig.Transformers, utils.LocalizeErrors)
+	humaConfig.Transformers = append(humaConfig.Transformers, authorization.NewRedactor(authz).Transformer)
+	api := humagin.New(router, humaConfig)
+	api.UseMiddleware(authorization.NewAuthorizationMiddleware(authz))
+	container.New(container.NewPostgresAdapters(pool, authz, files, nil, database.WriteBehindConfig{}), container.Config{}).RegisterRoutes(api)
+
+	s := &server{Server: httptest.NewServer(router), api: api}
+	t.Cleanup(s.Close)
+	return s
+}
+
+// do sends a request as userID in tenantID and returns the raw body, downloads are not JSON. Paths
+// are templates, their parameters are filled with params and their required query parameters added.
+func (s *server) do(t *testing.T, method, template string, params map[string]string, userID, tenantID, body string) (int, string) {
+	t.Helper()
+	path, ok := expand(template, params)
+	if !ok {
+		t.Fatalf("%s has a parameter without a value", template)
+	}
+	if values, found := queries[template]; found {
+		path += "?" + values.Encode()
+	}
+	request, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to build the request: %v", err)
+	}
+	if body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set(requestmeta.UserIDHeader, userID)
+	request.Header.Set(requestmeta.TenantIDHeader, tenantID)
+	// Translated fields are returned too
+	request.Header.Set("Accept-Language", "es-GT")
+
+	response, err := s.Client().Do(request)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer response.Body.Close()
+	raw, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("failed to read the response of %s %s: %v", method, path, err)
+	}
+	return response.StatusCode, string(raw)
+}
+
+// expand fills the path parameters with the values of params, ok is false when one is missing
+func expand(template string, params map[string]string) (string, bool) {
+	ok := true
+	path := pathParam.ReplaceAllStringFunc(template, func(match string) string {
+		value, found := params[match[1:len(match)-1]]
+		if !found {
+			ok = false
+		}
+		return url.PathEscape(value)
+	})
+	return path, ok
+}
+
+// lookup reads the value at a dotted path of a JSON document, array elements are picked by index
+func lookup(document any, path string) string {
+	for _, key := range strings.Split(path, ".") {
+		switch node := document.(type) {
+		case map[string]any:
+			document = node[key]
+		case []any:
+			index, err := strconv.Atoi(key)
+			if err != nil || index >= len(node) {
+				return ""
+			}
+			document = node[index]
+		default:
+			return ""
+		}
+	}
+	value, _ := document.(string)
+	return value
+}
+
+func (s seed) user() string {
+	if s.as == "" {
+		return adminID
+	}
+	return s.as
+}
+
+// seedTenant creates every seed in the tenant and records the IDs the server generated
+func seedTenant(t *testing.T, s *server, tenant *tenant) {
+	t.Helper()
+	for _, seed := range seeds {
+		status, body := s.do(t, seed.method, seed.path, tenant.params, seed.user(), tenant.id, seed.body(tenant.marker))
+		if status != seed.status {
+			t.Fatalf("seeding the %s of %s answered %d: %s", seed.name, tenant.id, status, body)
+		}
+		var document any
+		if err := json.Unmarshal([]byte(body), &document); err != nil {
+			t.Fatalf("seeding the %s of %s answered a body that is not JSON: %s", seed.name, tenant.id, body)
+		}
+		for param, path := range seed.captures {
+			value := lookup(document, path)
+			if value == "" {
+				t.Fatalf("seeding the %s of %s answered without %s: %s", seed.name, tenant.id, path, body)
+			}
+			tenant.params[param] = value
+			tenant.leaks = append(tenant.leaks, value)
+		}
+	}
+}
+
+// seedMember creates the member of the tenant the way sign up and role assignment store it: a row of
+// the users table, which every tenant shares, and a Casbin g rule giving it a role in the tenant
+func seedMember(t *testing.T, pool *pgxpool.Pool, tenant *tenant) {
+	t.Helper()
+	ctx := context.Background()
+	tenant.memberID = uuid.NewString()
+	email := tenant.memberID + "@isolation.example.com"
+	if _, err := pool.Exec(ctx, `INSERT INTO users (id, name, email, password_hash) VALUES ($1, $2, $3, '')`,
+		tenant.memberID, tenant.marker, email); err != nil {
+		t.Fatalf("failed to create the member of %s: %v", tenant.id, err)
+	}
+	if _, err := pool.Exec(ctx, `INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES ('g', $1, 'student', $2)`,
+		tenant.memberID, tenant.id); err != nil {
+		t.Fatalf("failed to give the member of %s a role: %v", tenant.id, err)
+	}
+	tenant.leaks = append(tenant.leaks, tenant.memberID, email)
+}
+
+// cleanup deletes the rows of the tenants from every table with a tenant_id column, then their
+// members and role assignments
+func cleanup(t *testing.T, pool *pgxpool.Pool, tenants []*tenant) {
+	ctx := context.Background()
+	tenantIDs := make([]string, 0, len(tenants))
+	memberIDs := make([]string, 0, len(tenants))
+	for _, tenant := range tenants {
+		tenantIDs = append(tenantIDs, tenant.id)
+		if tenant.memberID != "" {
+			memberIDs = append(memberIDs, tenant.memberID)
+		}
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM casbin_rule WHERE ptype = 'g' AND v2 = ANY($1)`, tenantIDs); err != nil {
+		t.Errorf("failed to delete the role assignments of the test tenants: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM users WHERE id = ANY($1::uuid[])`, memberIDs); err != nil {
+		t.Errorf("failed to delete the members of the test tenants: %v", err)
+	}
+
+	rows, err := pool.Query(ctx, `SELECT c.table_name FROM information_schema.columns c
+		JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		WHERE c.table_schema = 'public' AND c.column_name = 'tenant_id' AND t.table_type = 'BASE TABLE'`)
+	if err != nil {
+		t.Errorf("failed to list the tenant tables: %v", err)
+		return
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		t.Errorf("failed to read the tenant tables: %v", err)
+		return
+	}
+	for _, table := range tables {
+		if _, err := pool.Exec(ctx, fmt.Sprintf(`DELETE FROM %q WHERE tenant_id = ANY($1)`, table), tenantIDs); err != nil {
+			t.Errorf("failed to delete the rows of the test tenants from %s: %v", table, err)
+		}
+	}
+}
+
+func newTenant(name, suffix string) *tenant {
+	id := "isolation-" + name + "-" + suffix
+	return &tenant{
+		id:     id,
+		marker: "marker-" + id,
+		params: map[string]string{
+			"classId":      classID,
+			"assignmentId": assignmentID,
+			"studentId":    studentID,
+			"teacherId":    adminID,
+			"userId":       studentID,
+			"date":         day,
+			"metric":       "enrollments",
+		},
+	}
+}
+
+// visible reports whether body shows the record a seed created in the tenant
+func visible(body string, tenant *tenant, seed seed) bool {
+	if strings.Contains(body, tenant.marker) {
+		return true
+	}
+	for param := range seed.captures {
+		if strings.Contains(body, tenant.params[param]) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTenantIsolation(t *testing.T) {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	pool, err := pgxpool.New(context.Background(), databaseURL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer pool.Close()
+
+	suffix := uuid.NewString()[:8]
+	a, b := newTenant("a", suffix), newTenant("b", suffix)
+	t.Cleanup(func() { cleanup(t, pool, []*tenant{a, b}) })
+
+	s := newServer(t, pool, a.id, b.id)
+	for _, tenant := range []*tenant{a, b} {
+		seedMember(t, pool, tenant)
+		seedTenant(t, s, tenant)
+		tenant.leaks = append(tenant.leaks, tenant.marker)
+		// Translations are read for the resource of the tenant
+		tenant.params["entityType"] = "resource"
+		tenant.params["entityId"] = tenant.params["resourceId"]
+	}
+
+	t.Run("seeds are visible to their tenant", func(t *testing.T) {
+		for _, seed := range seeds {
+			status, body := s.do(t, http.MethodGet, seed.visibleAt, a.params, seed.user(), a.id, "")
+			assert.Equal(t, http.StatusOK, status, "%s: %s", seed.visibleAt, body)
+			assert.True(t, visible(body, a, seed), "%s does not show the %s of %s: %s", seed.visibleAt, seed.name, a.id, body)
+		}
+	})
+
+	// The users table is shared by every tenant, lists only show the members of the tenant asking
+	t.Run("users of the other tenant are not listed", func(t *testing.T) {
+		for _, path := range []string{"/users", "/users/export", "/api/v1/users"} {
+			status, body := s.do(t, http.MethodGet, path, a.params, adminID, a.id, "")
+			assert.Equal(t, http.StatusOK, status, "%s: %s", path, body)
+			assert.Contains(t, body, a.memberID, "%s does not list the member of %s", path, a.id)
+			assert.NotContains(t, body, b.memberID, "%s as %s lists the member of %s", path, a.id, b.id)
+		}
+	})
+
+	t.Run("inactive users report only has the users of the tenant", func(t *testing.T) {
+		status, body := s.do(t, http.MethodPost, "/reports", a.params, adminID, a.id, `{"definition": "inactive_users"}`)
+		if !assert.Equal(t, http.StatusAccepted, status, body) {
+			return
+		}
+		var accepted any
+		if !assert.NoError(t, json.Unmarshal([]byte(body), &accepted)) {
+			return
+		}
+		params := map[string]string{"reportId": lookup(accepted, "id")}
+
+		// The report worker is not running, the queue is drained here
+		generate := generate_report_use_case.NewGenerateReportUseCase(reportAdapters.NewPostgresReportRepository(pool),
+			reportAdapters.NewPostgresReportDataSource(pool), generate_report_use_case.DefaultArtifactTTL)
+		for {
+			processed, err := generate.Execute(context.Background())
+			if err != nil {
+				t.Logf("a queued report failed: %v", err)
+			}
+			if !processed {
+				break
+			}
+		}
+
+		status, body = s.do(t, http.MethodGet, "/reports/{reportId}/download", params, adminID, a.id, "")
+		assert.Equal(t, http.StatusOK, status, body)
+		assert.Contains(t, body, a.memberID, "the report does not list the member of %s", a.id)
+		assert.NotContains(t, body, b.memberID, "the report of %s lists the member of %s", a.id, b.id)
+	})
+
+	var operations []*huma.Operation
+	for _, item := range s.api.OpenAPI().Paths {
+		if item.Get != nil {
+			operations = append(operations, item.Get)
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool { return operations[i].Path < operations[j].Path })
+
+	for _, operation := range operations {
+		t.Run(operation.OperationID, func(t *testing.T) {
+			if _, ok := expand(operation.Path, a.params); !ok {
+				if reason, excused := notSeeded[operation.OperationID]; excused {
+					t.Skip(reason)
+				}
+				t.Fatalf("no seed fills the parameters of %s, add one or list the endpoint in notSeeded", operation.Path)
+			}
+			// The records of the own tenant, then those of the other tenant by their IDs
+			for _, params := range []map[string]string{a.params, b.params} {
+				status, body := s.do(t, http.MethodGet, operation.Path, params, adminID, a.id, "")
+				assert.Less(t, status, http.StatusInternalServerError, "%s: %s", operation.Path, body)
+				for _, leak := range b.leaks {
+					assert.NotContains(t, body, leak, "%s as %s returns a record of %s", operation.Path, a.id, b.id)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPasswordHasher is a mock implementation of ports.PasswordHasher
+type MockPasswordHasher struct {
+	mock.Mock
+}
+
+func (m *MockPasswordHasher) Hash(password string) (string, error) {
+	args := m.Called(password)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPasswordHasher) Verify(password string, hash string) (bool, bool, error) {
+	args := m.Called(password, hash)
+	return args.Bool(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockPasswordHasher) Recognizes(hash string) bool {
+	args := m.Called(hash)
+	return args.Bool(0)
+}
@@ -0,0 +1,97 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportRepository is a mock implementation of ports.ReportRepository
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) Create(ctx context.Context, report *entities.Report) error {
+	args := m.Called(ctx, report)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) FindByID(ctx context.Context, tenantID string, reportID string) (*entities.Report, error) {
+	args := m.Called(ctx, tenantID, reportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Report), args.Error(1)
+}
+
+func (m *MockReportRepository) FindArtifact(ctx context.Context, tenantID string, reportID string) (*entities.Artifact, error) {
+	args := m.Called(ctx, tenantID, reportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Artifact), args.Error(1)
+}
+
+func (m *MockReportRepository) List(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.Report], error) {
+	args := m.Called(ctx, tenantID, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*entities.Report]), args.Error(1)
+}
+
+func (m *MockReportRepository) ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.Report, error) {
+	args := m.Called(ctx, staleAfter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Report), args.Error(1)
+}
+
+func (m *MockReportRepository) MarkReady(ctx context.Context, reportID string, artifact *entities.Artifact, expiresAt time.Time) error {
+	args := m.Called(ctx, reportID, artifact, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) MarkFailed(ctx context.Context, reportID string, reason string) error {
+	args := m.Called(ctx, reportID, reason)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockReportDataSource is a mock implementation of ports.ReportDataSource
+type MockReportDataSource struct {
+	mock.Mock
+}
+
+func (m *MockReportDataSource) ClassAttendance(ctx context.Context, tenantID string) ([]entities.ClassAttendanceRow, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ClassAttendanceRow), args.Error(1)
+}
+
+func (m *MockReportDataSource) ClassGrades(ctx context.Context, tenantID string) ([]entities.ClassGradeRow, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ClassGradeRow), args.Error(1)
+}
+
+func (m *MockReportDataSource) InactiveUsers(ctx context.Context, tenantID string, inactiveSince time.Time) ([]entities.InactiveUserRow, error) {
+	args := m.Called(ctx, tenantID, inactiveSince)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.InactiveUserRow), args.Error(1)
+}
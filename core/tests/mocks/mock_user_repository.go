@@ -1,7 +1,10 @@
 package mocks
 
 import (
+	"github.com/nahualventure/class-backend/core/app/shared/filtering"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
 	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -31,3 +34,56 @@ func (m *MockUserRepository) FindByEmail(email string) (*entities.User, error) {
 	}
 	return args.Get(0).(*entities.User), args.Error(1)
 }
+
+func (m *MockUserRepository) CreateMany(users []ports.NewUserCredentials) ([]*entities.User, error) {
+	args := m.Called(users)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindExistingEmails(emails []string) ([]string, error) {
+	args := m.Called(emails)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserRepository) List(tenantID string, page *pagination.PageRequest, filter filtering.Expr) (*pagination.Page[*entities.User], error) {
+	args := m.Called(tenantID, page, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*entities.User]), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByIDs(ids []string) ([]*entities.User, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindCredentials(email string) (*entities.User, string, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).(*entities.User), args.String(1), args.Error(2)
+}
+
+func (m *MockUserRepository) ReplacePasswordHash(userID string, oldHash string, newHash string) (bool, error) {
+	args := m.Called(userID, oldHash, newHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) ImportMany(users []ports.ImportedUserCredentials) ([]*entities.User, error) {
+	args := m.Called(users)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.User), args.Error(1)
+}
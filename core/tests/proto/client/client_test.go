@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nahualventure/class-backend/proto/client"
+	commonv1 "github.com/nahualventure/class-backend/proto/gen/common/v1"
+	userv1 "github.com/nahualventure/class-backend/proto/gen/user/v1"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// memoryUsers serves users pageSize at a time, the cursor is the index of the next user
+type memoryUsers struct {
+	users    []*userv1.User
+	pageSize int
+	calls    int
+}
+
+func (m *memoryUsers) ListUsers(_ context.Context, in *userv1.ListUsersRequest, _ ...grpc.CallOption) (*userv1.ListUsersResponse, error) {
+	m.calls++
+	start, _ := strconv.Atoi(in.GetPage().GetCursor())
+	end := min(start+m.pageSize, len(m.users))
+	response := &userv1.ListUsersResponse{Users: m.users[start:end], Page: &commonv1.PageResponse{}}
+	if end < len(m.users) {
+		response.Page.NextCursor = strconv.Itoa(end)
+	}
+	return response, nil
+}
+
+func newUsers(count int) *memoryUsers {
+	users := &memoryUsers{pageSize: 3}
+	for i := range count {
+		users.users = append(users.users, &userv1.User{Id: fmt.Sprintf("user-%d", i)})
+	}
+	return users
+}
+
+func TestListUsers_FetchesEveryPage(t *testing.T) {
+	users := newUsers(8)
+
+	listed, err := client.Collect(client.ListUsers(context.Background(), users, nil), 0)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 8)
+	assert.Equal(t, "user-7", listed[7].GetId())
+	assert.Equal(t, 3, users.calls)
+
+	// Pages past what the loop needs are not fetched
+	users.calls = 0
+	listed, err = client.Collect(client.ListUsers(context.Background(), users, &commonv1.PageRequest{Cursor: "3"}), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-3", "user-4"}, []string{listed[0].GetId(), listed[1].GetId()})
+	assert.Equal(t, 1, users.calls)
+}
+
+func TestAll_StopsOnErrorsAndRepeatedCursors(t *testing.T) {
+	failing := func(_ context.Context, page *commonv1.PageRequest) ([]string, *commonv1.PageResponse, error) {
+		if page.GetCursor() != "" {
+			return nil, nil, errors.New("unavailable")
+		}
+		return []string{"a"}, &commonv1.PageResponse{NextCursor: "next"}, nil
+	}
+	listed, err := client.Collect(client.All(context.Background(), nil, failing), 0)
+	assert.EqualError(t, err, "unavailable")
+	assert.Equal(t, []string{"a"}, listed)
+
+	repeating := func(_ context.Context, _ *commonv1.PageRequest) ([]string, *commonv1.PageResponse, error) {
+		return []string{"a"}, &commonv1.PageResponse{NextCursor: "same"}, nil
+	}
+	_, err = client.Collect(client.All(context.Background(), &commonv1.PageRequest{Cursor: "same"}, repeating), 0)
+	assert.ErrorIs(t, err, client.ErrCursorRepeated)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.Collect(client.ListUsers(ctx, newUsers(2), nil), 0)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestStream_DeliversTheListInOrder(t *testing.T) {
+	items, wait := client.Stream(context.Background(), nil, client.UserPages(newUsers(7)), 4)
+	var ids []string
+	for user := range items {
+		ids = append(ids, user.GetId())
+	}
+	assert.NoError(t, wait())
+	assert.Len(t, ids, 7)
+	assert.Equal(t, "user-0", ids[0])
+
+	// A reader that stops early cancels, the fetching stops without blocking
+	ctx, cancel := context.WithCancel(context.Background())
+	items, wait = client.Stream(ctx, nil, client.UserPages(newUsers(50)), 0)
+	<-items
+	cancel()
+	for range items {
+	}
+	assert.ErrorIs(t, wait(), context.Canceled)
+}
+
+func newOperationsServer(t *testing.T, polls int, final map[string]any) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if r.URL.Path != "/operations/op-1" {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "OPERATION_NOT_FOUND", "message": "Not found"}})
+			return
+		}
+		operation := map[string]any{"id": "op-1", "kind": "import", "status": "running", "progress": 40}
+		if int(calls.Add(1)) >= polls {
+			operation = final
+		}
+		_ = json.NewEncoder(w).Encode(operation)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newOperationsClient(server *httptest.Server) *client.OperationsClient {
+	operations := client.NewOperationsClient(server.URL+"/", server.Client(), http.Header{"Authorization": {"Bearer token"}})
+	operations.PollInterval = time.Millisecond
+	return operations
+}
+
+func TestOperationsClient_Wait(t *testing.T) {
+	server, calls := newOperationsServer(t, 3, map[string]any{"id": "op-1", "kind": "import", "status": "succeeded",
+		"progress": 100, "done": true, "result": map[string]any{"resource": "/imports/op-1"}})
+	operations := newOperationsClient(server)
+
+	operation, err := operations.Wait(context.Background(), "op-1", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "/imports/op-1", operation.Result.Resource)
+	assert.Equal(t, int32(3), calls.Load())
+
+	_, err = operations.Get(context.Background(), "op-2")
+	assert.True(t, client.IsNotFound(err))
+}
+
+func TestOperationsClient_Wait_ReturnsFailuresAndTimesOut(t *testing.T) {
+	server, _ := newOperationsServer(t, 1, map[string]any{"id": "op-1", "kind": "report", "status": "failed",
+		"progress": 100, "done": true, "error": map[string]any{"code": "OPERATION_FAILED", "message": "disk full"}})
+	operation, err := newOperationsClient(server).Wait(context.Background(), "op-1", time.Second)
+	var apiErr *client.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "disk full", apiErr.Message)
+	assert.Equal(t, client.OperationFailed, operation.Status)
+
+	server, _ = newOperationsServer(t, 1000, nil)
+	operation, err = newOperationsClient(server).Wait(context.Background(), "op-1", 20*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 40, operation.Progress)
+}
+
+func TestOperationID(t *testing.T) {
+	accepted := &http.Response{StatusCode: http.StatusAccepted, Header: http.Header{"Location": {"/operations/op-1"}}}
+	id, found := client.OperationID(accepted)
+	assert.True(t, found)
+	assert.Equal(t, "op-1", id)
+
+	_, found = client.OperationID(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}})
+	assert.False(t, found)
+}
+
+func TestSigner_Transport_SignsTheRequestSent(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	var signature, base string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(client.SignatureTimestampHeader), 10, 64)
+		base = client.SignatureBase(r.Method, r.URL.RequestURI(), r.Header.Get("X-Tenant-Id"), timestamp, body)
+		signature = r.Header.Get(client.SignatureHeader)
+		assert.Equal(t, "k1", r.Header.Get(client.SignatureKeyHeader))
+		assert.Equal(t, `{"from":"a"}`, string(body), "the body is sent after being signed")
+	}))
+	defer server.Close()
+
+	signer := &client.Signer{KeyID: "k1", Secret: secret, Now: func() time.Time { return time.Unix(1700000000, 0) }}
+	httpClient := &http.Client{Transport: signer.Transport(nil)}
+	request, err := http.NewRequest(http.MethodPost, server.URL+"/users/merge?dry_run=false", strings.NewReader(`{"from":"a"}`))
+	assert.NoError(t, err)
+	request.Header.Set("X-Tenant-Id", "tenant1")
+	response, err := httpClient.Do(request)
+	assert.NoError(t, err)
+	response.Body.Close()
+
+	assert.Equal(t, client.Signature(secret, base), signature)
+	assert.Contains(t, base, "/users/merge?dry_run=false\ntenant1\n1700000000\n")
+	assert.Empty(t, request.Header.Get(client.SignatureHeader), "the request given is left as it was")
+}
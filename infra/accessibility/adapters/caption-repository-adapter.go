@@ -0,0 +1,80 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/ports"
+	libraryEntities "github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	messagingEntities "github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresCaptionRepository reads the caption status kept on resource_versions and
+// message_attachments
+type PostgresCaptionRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresCaptionRepository(dbInstance *pgxpool.Pool) ports.CaptionRepository {
+	return &PostgresCaptionRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresCaptionRepository) ListPending(ctx context.Context, limit int) ([]*entities.CaptionSource, error) {
+	rows, err := r.queries.ListPendingCaptions(ctx, int32(limit))
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	sources := make([]*entities.CaptionSource, 0, len(rows))
+	for _, row := range rows {
+		source := &entities.CaptionSource{
+			Kind:        entities.FileKind(row.FileKind),
+			ID:          row.ID.String(),
+			TenantID:    row.TenantID,
+			ContentType: row.ContentType,
+		}
+		// Keys are built by the modules owning the files
+		if source.Kind == entities.FileKindResourceVersion {
+			version := &libraryEntities.ResourceVersion{ID: source.ID, TenantID: source.TenantID, ResourceID: row.ParentID.String()}
+			source.StorageKey = version.StorageKey()
+		} else {
+			message := &messagingEntities.Message{TenantID: source.TenantID, ConversationID: row.ParentID.String()}
+			source.StorageKey = message.AttachmentKey(source.ID)
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+func (r *PostgresCaptionRepository) Record(ctx context.Context, captions *entities.FileCaptions) (bool, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(captions.Source.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	var updated int64
+	var err error
+	if captions.Source.Kind == entities.FileKindResourceVersion {
+		updated, err = r.queries.SetResourceVersionCaptionStatus(ctx, db.SetResourceVersionCaptionStatusParams{
+			CaptionStatus: string(captions.Status),
+			ID:            pgID,
+		})
+	} else {
+		updated, err = r.queries.SetAttachmentCaptionStatus(ctx, db.SetAttachmentCaptionStatusParams{
+			CaptionStatus: string(captions.Status),
+			ID:            pgID,
+		})
+	}
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return updated > 0, nil
+}
@@ -0,0 +1,53 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresClassMaterials reads the attachments of the class threads the messaging module keeps
+type PostgresClassMaterials struct {
+	queries *db.Queries
+}
+
+func NewPostgresClassMaterials(dbInstance *pgxpool.Pool) ports.ClassMaterials {
+	return &PostgresClassMaterials{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (m *PostgresClassMaterials) ListMaterials(ctx context.Context, tenantID string, classID string) ([]entities.ClassMaterial, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := m.queries.ListClassMaterials(ctx, db.ListClassMaterialsParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	materials := make([]entities.ClassMaterial, 0, len(rows))
+	for _, row := range rows {
+		materials = append(materials, entities.ClassMaterial{
+			ID:            row.ID.String(),
+			MessageID:     row.MessageID.String(),
+			FileName:      row.FileName,
+			ContentType:   row.ContentType,
+			AltText:       row.AltText,
+			CaptionStatus: storage.CaptionStatus(row.CaptionStatus),
+			UploadedBy:    row.SenderID,
+			UploadedAt:    row.CreatedAt.Time,
+		})
+	}
+	return materials, nil
+}
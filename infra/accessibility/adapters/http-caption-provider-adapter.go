@@ -0,0 +1,87 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+)
+
+type CaptionProviderConfig struct {
+	BaseURL string // e.g. https://api.caption-provider.example/v1
+	APIKey  string
+}
+
+// HTTPCaptionProvider talks to a REST transcription provider. Files are posted as JSON with a base64
+// body and the captions come back in the response, transcriptions of long videos take minutes.
+type HTTPCaptionProvider struct {
+	config CaptionProviderConfig
+	client *http.Client
+}
+
+func NewHTTPCaptionProvider(config CaptionProviderConfig) ports.CaptionProvider {
+	return &HTTPCaptionProvider{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Minute, Transport: cost.Transport("captions", nil)},
+	}
+}
+
+type providerTranscriptionRequest struct {
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
+
+type providerTranscriptionResponse struct {
+	VTT        string `json:"vtt"`
+	Transcript string `json:"transcript"`
+}
+
+func (p *HTTPCaptionProvider) Supports(contentType string) bool {
+	return storage.NeedsCaptions(contentType)
+}
+
+func (p *HTTPCaptionProvider) Transcribe(ctx context.Context, contentType string, content []byte) (*entities.Transcription, error) {
+	body, err := json.Marshal(providerTranscriptionRequest{ContentType: contentType, Content: content})
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to serialize transcription request", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(p.config.BaseURL, "/")+"/transcriptions", bytes.NewReader(body))
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to build transcription request", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to reach caption provider", err)
+	}
+	defer response.Body.Close()
+
+	// The provider answers 422 to files it cannot decode or that have no speech
+	if response.StatusCode == http.StatusUnprocessableEntity {
+		return nil, entities.ErrUntranscribable
+	}
+	if response.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("caption provider answered %d: %s", response.StatusCode, message), nil)
+	}
+
+	var transcription providerTranscriptionResponse
+	if err := json.NewDecoder(response.Body).Decode(&transcription); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to decode caption provider response", err)
+	}
+
+	return &entities.Transcription{Captions: []byte(transcription.VTT), Transcript: transcription.Transcript}, nil
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/accessibility/domain/entities"
+)
+
+type GetClassAccessibilityReportRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+}
+
+type MaterialCountResponse struct {
+	Total     int `json:"total"`
+	Compliant int `json:"compliant"`
+}
+
+type AccessibilityIssueResponse struct {
+	AttachmentID  string    `json:"attachment_id"`
+	MessageID     string    `json:"message_id"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	Issue         string    `json:"issue" enum:"missing_alt_text,captions_pending,missing_captions" doc:"Images shared before alt text was required, videos and audio waiting for captions or that could not be captioned"`
+	CaptionStatus string    `json:"caption_status" enum:"pending,ready,not_needed,unsupported,failed"`
+	UploadedBy    string    `json:"uploaded_by"`
+	UploadedAt    time.Time `json:"uploaded_at"`
+}
+
+type ClassAccessibilityReportResponse struct {
+	Body struct {
+		ClassID     string                       `json:"class_id"`
+		Compliant   bool                         `json:"compliant" doc:"Every image has alt text and every video and audio file has captions"`
+		Images      MaterialCountResponse        `json:"images"`
+		Media       MaterialCountResponse        `json:"media" doc:"Videos and audio"`
+		Issues      []AccessibilityIssueResponse `json:"issues" doc:"Materials that do not comply, newest first"`
+		GeneratedAt time.Time                    `json:"generated_at"`
+	}
+}
+
+func NewClassAccessibilityReportResponse(report *entities.AccessibilityReport) *ClassAccessibilityReportResponse {
+	response := &ClassAccessibilityReportResponse{}
+	response.Body.ClassID = report.ClassID
+	response.Body.Compliant = report.Compliant()
+	response.Body.Images = MaterialCountResponse{Total: report.Images.Total, Compliant: report.Images.Compliant}
+	response.Body.Media = MaterialCountResponse{Total: report.Media.Total, Compliant: report.Media.Compliant}
+	response.Body.Issues = make([]AccessibilityIssueResponse, 0, len(report.Issues))
+	for _, material := range report.Issues {
+		response.Body.Issues = append(response.Body.Issues, AccessibilityIssueResponse{
+			AttachmentID:  material.ID,
+			MessageID:     material.MessageID,
+			FileName:      material.FileName,
+			ContentType:   material.ContentType,
+			Issue:         string(material.Issue()),
+			CaptionStatus: string(material.CaptionStatus),
+			UploadedBy:    material.UploadedBy,
+			UploadedAt:    material.UploadedAt,
+		})
+	}
+	response.Body.GeneratedAt = report.GeneratedAt
+	return response
+}
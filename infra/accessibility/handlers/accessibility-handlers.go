@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_class_accessibility_report_use_case "github.com/nahualventure/class-backend/core/app/accessibility/application/use-cases/get-class-accessibility-report-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type AccessibilityHandlers struct {
+	getClassAccessibilityReportUseCase *get_class_accessibility_report_use_case.GetClassAccessibilityReportUseCase
+}
+
+func NewAccessibilityHandlers(getClassAccessibilityReportUseCase *get_class_accessibility_report_use_case.GetClassAccessibilityReportUseCase) *AccessibilityHandlers {
+	return &AccessibilityHandlers{
+		getClassAccessibilityReportUseCase: getClassAccessibilityReportUseCase,
+	}
+}
+
+func (h *AccessibilityHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-class-accessibility-report",
+		Method:      http.MethodGet,
+		Path:        "/classes/{classId}/accessibility-report",
+		Summary:     "Report whether the materials of a class meet accessibility mandates",
+		Description: "Covers the files shared in the class thread: images need alt text, videos and audio need captions. Hidden messages and files not scanned clean are left out.",
+		Tags:        []string{"Accessibility"},
+	}, h.GetClassAccessibilityReport)
+}
+
+func (h *AccessibilityHandlers) GetClassAccessibilityReport(ctx context.Context, input *GetClassAccessibilityReportRequest) (*ClassAccessibilityReportResponse, error) {
+	command, err := get_class_accessibility_report_use_case.NewGetClassAccessibilityReportCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	report, err := h.getClassAccessibilityReportUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewClassAccessibilityReportResponse(report), nil
+}
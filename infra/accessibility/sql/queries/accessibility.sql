@@ -0,0 +1,31 @@
+-- name: ListPendingCaptions :many
+SELECT 'resource_version'::text AS file_kind, v.id, v.tenant_id, v.resource_id AS parent_id, v.content_type,
+       v.created_at AS uploaded_at
+FROM resource_versions v
+WHERE v.caption_status = 'pending' AND v.scan_status = 'clean'
+UNION ALL
+SELECT 'attachment'::text, a.id, m.tenant_id, m.conversation_id, a.content_type, m.created_at
+FROM message_attachments a
+JOIN messages m ON m.id = a.message_id
+WHERE a.caption_status = 'pending' AND a.scan_status = 'clean'
+ORDER BY uploaded_at
+LIMIT @max_files;
+
+-- name: SetResourceVersionCaptionStatus :execrows
+UPDATE resource_versions
+SET caption_status = @caption_status
+WHERE id = @id AND caption_status = 'pending';
+
+-- name: SetAttachmentCaptionStatus :execrows
+UPDATE message_attachments
+SET caption_status = @caption_status
+WHERE id = @id AND caption_status = 'pending';
+
+-- name: ListClassMaterials :many
+SELECT a.id, a.message_id, a.file_name, a.content_type, a.alt_text, a.caption_status, m.sender_id, m.created_at
+FROM message_attachments a
+JOIN messages m ON m.id = a.message_id
+JOIN conversations c ON c.id = m.conversation_id
+WHERE c.tenant_id = @tenant_id AND c.kind = 'class' AND c.class_id = @class_id
+  AND m.hidden_at IS NULL AND a.scan_status = 'clean'
+ORDER BY m.created_at DESC, a.id;
@@ -0,0 +1,30 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	generate_captions_use_case "github.com/nahualventure/class-backend/core/app/accessibility/application/use-cases/generate-captions-use-case"
+)
+
+// RunCaptions generates the captions and transcripts of the videos and audio scanned clean every
+// interval, it returns when ctx is cancelled
+func RunCaptions(ctx context.Context, useCase *generate_captions_use_case.GenerateCaptionsUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if generated, err := useCase.Execute(ctx); err != nil {
+			log.Printf("Failed to generate captions: %v", err)
+		} else if generated > 0 {
+			log.Printf("Generated the captions of %d files", generated)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/activity/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const ActivityProjectionName = "activity_timeline"
+
+// activityUserKeys are the payload keys naming the user of an outbox event, in order of preference:
+// the user who acted first, then the user the event is about
+var activityUserKeys = []string{"actor_id", "canceled_by", "user_id", "student_id", "recipient_id", "guardian_id"}
+
+// PostgresActivityProjection adds every outbox event to the activity timeline of its tenant as an
+// audit event
+type PostgresActivityProjection struct {
+	queries *db.Queries
+}
+
+func NewPostgresActivityProjection(dbInstance *pgxpool.Pool) *PostgresActivityProjection {
+	return &PostgresActivityProjection{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (p *PostgresActivityProjection) Name() string {
+	return ActivityProjectionName
+}
+
+// Apply is idempotent, an event already in the timeline conflicts on its outbox position
+func (p *PostgresActivityProjection) Apply(ctx context.Context, event events.Event) error {
+	activity := &entities.ActivityEvent{
+		TenantID:   event.TenantID,
+		Category:   entities.ActivityCategoryAudit,
+		Type:       event.Type,
+		Resource:   event.AggregateID,
+		Detail:     event.Payload,
+		OccurredAt: event.OccurredAt,
+	}
+	for _, key := range activityUserKeys {
+		if userID := event.String(key); userID != "" {
+			activity.UserID = userID
+			break
+		}
+	}
+
+	params, err := toInsertActivityEventParams(activity)
+	if err != nil {
+		return err
+	}
+	params.Position = &event.Position
+	if err := p.queries.InsertActivityEvent(ctx, params); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+// Reset deletes the audit events of the timeline, request events are not in the outbox and stay
+func (p *PostgresActivityProjection) Reset(ctx context.Context) error {
+	if err := p.queries.DeleteAuditActivityEvents(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
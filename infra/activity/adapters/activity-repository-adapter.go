@@ -0,0 +1,129 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/activity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/activity/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresActivityRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresActivityRepository(dbInstance *pgxpool.Pool) ports.ActivityRepository {
+	return &PostgresActivityRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+// Append inserts the events in one transaction
+func (r *PostgresActivityRepository) Append(ctx context.Context, events []*entities.ActivityEvent) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	for _, event := range events {
+		params, err := toInsertActivityEventParams(event)
+		if err != nil {
+			return err
+		}
+		if err := qtx.InsertActivityEvent(ctx, params); err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresActivityRepository) List(ctx context.Context, filter ports.ActivityFilter, limit int) ([]*entities.ActivityEvent, int64, error) {
+	params := db.ListActivityEventsParams{
+		TenantID:   filter.TenantID,
+		Categories: make([]string, len(filter.Categories)),
+		Types:      filter.Types,
+		RowLimit:   int32(limit),
+	}
+	if params.Types == nil {
+		params.Types = []string{}
+	}
+	for i, category := range filter.Categories {
+		params.Categories[i] = string(category)
+	}
+	if filter.UserID != "" {
+		params.UserID = &filter.UserID
+	}
+	if filter.After != nil {
+		params.AfterOccurredAt = pgtype.Timestamptz{Time: filter.After.OccurredAt, Valid: true}
+		params.AfterID = &filter.After.ID
+	}
+
+	rows, err := r.queries.ListActivityEvents(ctx, params)
+	if err != nil {
+		return nil, 0, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	events := make([]*entities.ActivityEvent, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		var detail map[string]any
+		if err := json.Unmarshal(row.Detail, &detail); err != nil {
+			return nil, 0, appErrors.NewInfrastructureError("failed to read activity detail", err)
+		}
+		events = append(events, &entities.ActivityEvent{
+			ID:         row.ID,
+			TenantID:   row.TenantID,
+			UserID:     row.UserID,
+			Category:   entities.ActivityCategory(row.Category),
+			Type:       row.Type,
+			Resource:   row.Resource,
+			Detail:     detail,
+			OccurredAt: row.OccurredAt.Time,
+		})
+	}
+	return events, total, nil
+}
+
+func (r *PostgresActivityRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := r.queries.DeleteActivityEventsBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	return deleted, nil
+}
+
+func toInsertActivityEventParams(event *entities.ActivityEvent) (db.InsertActivityEventParams, error) {
+	detail := event.Detail
+	if detail == nil {
+		detail = map[string]any{}
+	}
+	encoded, err := json.Marshal(detail)
+	if err != nil {
+		return db.InsertActivityEventParams{}, appErrors.NewInfrastructureError("failed to serialize activity detail", err)
+	}
+	return db.InsertActivityEventParams{
+		TenantID:   event.TenantID,
+		UserID:     event.UserID,
+		Category:   string(event.Category),
+		Type:       event.Type,
+		Resource:   event.Resource,
+		Detail:     encoded,
+		OccurredAt: pgtype.Timestamptz{Time: event.OccurredAt, Valid: true},
+	}, nil
+}
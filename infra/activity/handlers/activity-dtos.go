@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/activity/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type ActivityFilterParams struct {
+	PageSize   int      `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of events to return"`
+	Cursor     string   `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+	Categories []string `query:"categories" enum:"audit,authorization,auth" doc:"Comma separated categories to list, every category when absent"`
+	Types      []string `query:"types" maxItems:"20" doc:"Comma separated event types such as 'authorization.denied,grade.recorded', every type when absent"`
+}
+
+type ListActivityRequest struct {
+	ActivityFilterParams
+}
+
+type ListUserActivityRequest struct {
+	UserID string `path:"userId" maxLength:"255"`
+	ActivityFilterParams
+}
+
+type ActivityEventResponse struct {
+	Category   string         `json:"category" enum:"audit,authorization,auth"`
+	Type       string         `json:"type"`
+	UserID     string         `json:"user_id,omitempty" doc:"User the event is about, absent when it names none"`
+	Resource   string         `json:"resource,omitempty" doc:"Operation of a request or aggregate of an audit event"`
+	Detail     map[string]any `json:"detail"`
+	OccurredAt time.Time      `json:"occurred_at"`
+}
+
+type ListActivityResponse struct {
+	Body utils.ListResponseBody[ActivityEventResponse]
+}
+
+func NewActivityEventResponse(event *entities.ActivityEvent, loc *time.Location) ActivityEventResponse {
+	detail := event.Detail
+	if detail == nil {
+		detail = map[string]any{}
+	}
+	return ActivityEventResponse{
+		Category:   string(event.Category),
+		Type:       event.Type,
+		UserID:     event.UserID,
+		Resource:   event.Resource,
+		Detail:     detail,
+		OccurredAt: utils.InLocation(event.OccurredAt, loc),
+	}
+}
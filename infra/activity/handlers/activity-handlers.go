@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	list_activity_use_case "github.com/nahualventure/class-backend/core/app/activity/application/use-cases/list-activity-use-case"
+	"github.com/nahualventure/class-backend/core/app/activity/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ActivityHandlers struct {
+	listActivityUseCase *list_activity_use_case.ListActivityUseCase
+}
+
+func NewActivityHandlers(listActivityUseCase *list_activity_use_case.ListActivityUseCase) *ActivityHandlers {
+	return &ActivityHandlers{
+		listActivityUseCase: listActivityUseCase,
+	}
+}
+
+func (h *ActivityHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-activity",
+		Method:      http.MethodGet,
+		Path:        "/activity",
+		Summary:     "Activity timeline of the tenant",
+		Description: "Audit, authorization and auth events of the tenant, newest first. Request events are saved every few seconds, the latest ones may not be listed yet.",
+		Tags:        []string{"Activity"},
+	}, h.ListActivity)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-user-activity",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/activity",
+		Summary:     "Activity timeline of a user",
+		Description: "Events of the tenant about the user, newest first, with the sign up of the user.",
+		Tags:        []string{"Activity"},
+	}, h.ListUserActivity)
+}
+
+func (h *ActivityHandlers) ListActivity(ctx context.Context, input *ListActivityRequest) (*ListActivityResponse, error) {
+	return h.list(ctx, "", input.ActivityFilterParams)
+}
+
+func (h *ActivityHandlers) ListUserActivity(ctx context.Context, input *ListUserActivityRequest) (*ListActivityResponse, error) {
+	return h.list(ctx, input.UserID, input.ActivityFilterParams)
+}
+
+func (h *ActivityHandlers) list(ctx context.Context, userID string, params ActivityFilterParams) (*ListActivityResponse, error) {
+	command, err := list_activity_use_case.NewListActivityCommand(
+		authorization.TenantIDFromContext(ctx),
+		userID,
+		params.Categories,
+		params.Types,
+		params.PageSize,
+		params.Cursor,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listActivityUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	return &ListActivityResponse{
+		Body: utils.NewListResponseBody(page, func(event *entities.ActivityEvent) ActivityEventResponse {
+			return NewActivityEventResponse(event, loc)
+		}),
+	}, nil
+}
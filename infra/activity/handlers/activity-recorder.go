@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/activity/domain/entities"
+	serviceAccountEntities "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	userEntities "github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// maxBufferedActivity bounds the events kept between flushes, events past it are dropped and
+// counted so a database outage cannot grow the buffer without limit
+const maxBufferedActivity = 10000
+
+// ActivityRecorder keeps the authorization and auth events of requests in memory until the flush
+// worker saves them
+type ActivityRecorder struct {
+	access  *authorization.EndpointAccess
+	mu      sync.Mutex
+	events  []*entities.ActivityEvent
+	dropped int
+}
+
+func NewActivityRecorder(access *authorization.EndpointAccess) *ActivityRecorder {
+	return &ActivityRecorder{access: access}
+}
+
+// Middleware records the outcome of the permission check of every protected request. It runs
+// before the authorization middleware and reads the response status: 401 is an unauthenticated
+// request, 403 a denied one, and any other status of a write was granted. Reads that were allowed
+// are not recorded, they would drown the timeline.
+func (r *ActivityRecorder) Middleware(ctx huma.Context, next func(huma.Context)) {
+	operationID := ctx.Operation().OperationID
+	if r.access.IsPublic(operationID) {
+		next(ctx)
+		return
+	}
+
+	next(ctx)
+
+	meta := requestmeta.FromHuma(ctx)
+	event := &entities.ActivityEvent{
+		TenantID:   meta.TenantID,
+		UserID:     meta.UserID,
+		Resource:   operationID,
+		Detail:     map[string]any{"method": ctx.Method(), "path": ctx.URL().Path, "status": ctx.Status()},
+		OccurredAt: time.Now().UTC(),
+	}
+	if permission, ok := r.access.Permission(operationID); ok {
+		event.Detail["permission"] = permission.Resource + ":" + permission.Action
+	}
+	if meta.Impersonated() {
+		event.Detail["impersonator_id"] = meta.ImpersonatorID
+	}
+	if serviceAccountEntities.IsServiceAccountSubject(meta.UserID) {
+		event.Detail["actor_kind"] = serviceAccountEntities.ActorKindServiceAccount
+	}
+
+	switch status := ctx.Status(); {
+	case status == http.StatusUnauthorized:
+		event.Category, event.Type = entities.ActivityCategoryAuth, entities.ActivityAuthUnauthenticated
+	case status == http.StatusForbidden:
+		event.Category, event.Type = entities.ActivityCategoryAuthorization, entities.ActivityAuthorizationDenied
+	case !authorization.IsReadOnlyMethod(ctx.Method()) && meta.UserID != "" && meta.TenantID != "":
+		event.Category, event.Type = entities.ActivityCategoryAuthorization, entities.ActivityAuthorizationGranted
+	default:
+		return
+	}
+	r.record(event)
+}
+
+// RecordSignUp records a user created through sign up, in the tenant of the request when it names one
+func (r *ActivityRecorder) RecordSignUp(ctx context.Context, user *userEntities.User) {
+	r.record(&entities.ActivityEvent{
+		TenantID:   requestmeta.TenantID(ctx),
+		UserID:     user.ID,
+		Category:   entities.ActivityCategoryAuth,
+		Type:       entities.ActivityAuthSignedUp,
+		Resource:   user.ID,
+		OccurredAt: user.CreatedAt.UTC(),
+	})
+}
+
+func (r *ActivityRecorder) record(event *entities.ActivityEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.events) >= maxBufferedActivity {
+		r.dropped++
+		return
+	}
+	r.events = append(r.events, event)
+}
+
+// Drain returns the events recorded since the last drain and how many were dropped since, and
+// starts over
+func (r *ActivityRecorder) Drain() ([]*entities.ActivityEvent, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events, dropped := r.events, r.dropped
+	r.events, r.dropped = nil, 0
+	return events, dropped
+}
+
+// Restore adds back events that could not be saved, the next flush retries them
+func (r *ActivityRecorder) Restore(events []*entities.ActivityEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room := maxBufferedActivity - len(r.events)
+	if room < len(events) {
+		r.dropped += len(events) - room
+		events = events[:room]
+	}
+	r.events = append(events, r.events...)
+}
@@ -0,0 +1,25 @@
+-- name: InsertActivityEvent :exec
+INSERT INTO activity_events (tenant_id, user_id, category, type, resource, detail, position, occurred_at)
+VALUES (@tenant_id, @user_id, @category, @type, @resource, @detail, sqlc.narg('position'), @occurred_at)
+ON CONFLICT (position) DO NOTHING;
+
+-- name: ListActivityEvents :many
+SELECT id, tenant_id, user_id, category, type, resource, detail, occurred_at,
+       count(*) OVER () AS total
+FROM activity_events
+WHERE (tenant_id = @tenant_id OR (tenant_id = '' AND user_id = sqlc.narg('user_id')::varchar))
+  AND (sqlc.narg('user_id')::varchar IS NULL OR user_id = sqlc.narg('user_id'))
+  AND (cardinality(@categories::varchar[]) = 0 OR category = ANY(@categories::varchar[]))
+  AND (cardinality(@types::varchar[]) = 0 OR type = ANY(@types::varchar[]))
+  AND (sqlc.narg('after_occurred_at')::timestamptz IS NULL
+       OR (occurred_at, id) < (sqlc.narg('after_occurred_at')::timestamptz, sqlc.narg('after_id')::bigint))
+ORDER BY occurred_at DESC, id DESC
+LIMIT @row_limit;
+
+-- name: DeleteActivityEventsBefore :execrows
+DELETE FROM activity_events
+WHERE occurred_at < @before;
+
+-- name: DeleteAuditActivityEvents :exec
+DELETE FROM activity_events
+WHERE category = 'audit';
@@ -0,0 +1,19 @@
+-- Activity timeline of every tenant for the support console: its outbox events (audit), the
+-- permission checks of its requests (authorization), and sign ups and unauthenticated requests
+-- (auth). Audit events keep their outbox position so replays do not add them twice. Sign ups
+-- without a tenant are stored with an empty one and only show in the timeline of their user.
+CREATE TABLE activity_events (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL DEFAULT '',
+    category VARCHAR(20) NOT NULL,
+    type VARCHAR(100) NOT NULL,
+    resource VARCHAR(255) NOT NULL DEFAULT '',
+    detail JSONB NOT NULL DEFAULT '{}',
+    position BIGINT UNIQUE,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_activity_events_tenant ON activity_events (tenant_id, occurred_at DESC, id DESC);
+CREATE INDEX idx_activity_events_user ON activity_events (user_id, occurred_at DESC, id DESC);
+CREATE INDEX idx_activity_events_occurred_at ON activity_events (occurred_at);
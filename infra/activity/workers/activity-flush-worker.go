@@ -0,0 +1,74 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	record_activity_use_case "github.com/nahualventure/class-backend/core/app/activity/application/use-cases/record-activity-use-case"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/infra/activity/handlers"
+)
+
+// finalFlushTimeout bounds the flush on shutdown, after ctx is cancelled
+const finalFlushTimeout = 5 * time.Second
+
+// RunActivityFlush saves the events of the recorder every interval and once more when ctx is
+// cancelled. Events that fail to save are kept for the next flush.
+func RunActivityFlush(ctx context.Context, recorder *handlers.ActivityRecorder, useCase *record_activity_use_case.RecordActivityUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+			flushActivity(flushCtx, recorder, useCase)
+			cancel()
+			return
+		case <-ticker.C:
+			flushActivity(ctx, recorder, useCase)
+		}
+	}
+}
+
+func flushActivity(ctx context.Context, recorder *handlers.ActivityRecorder, useCase *record_activity_use_case.RecordActivityUseCase) {
+	events, dropped := recorder.Drain()
+	if dropped > 0 {
+		log.Printf("Dropped %d activity events, the buffer was full", dropped)
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	command, err := record_activity_use_case.NewRecordActivityCommand(events)
+	if err != nil {
+		log.Printf("Dropped invalid activity events: %v", err)
+		return
+	}
+	if err := useCase.Execute(ctx, command); err != nil {
+		log.Printf("Failed to save activity events, retrying on the next flush: %v", err)
+		recorder.Restore(events)
+	}
+}
+
+// RunActivityRetention deletes expired activity events every interval, it returns when ctx is
+// cancelled
+func RunActivityRetention(ctx context.Context, useCase *record_activity_use_case.RecordActivityUseCase, clock clock.Clock, interval time.Duration) {
+	ticker := clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := useCase.PurgeExpired(ctx); err != nil {
+			log.Printf("Failed to purge expired activity events: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Purged %d expired activity events", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package adapters
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/analytics/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/analytics/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresUsageRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresUsageRepository(dbInstance *pgxpool.Pool) ports.UsageRepository {
+	return &PostgresUsageRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+// Add upserts the counters in one transaction. They are written in key order so instances flushing
+// the same hour at once take the row locks in the same order.
+func (r *PostgresUsageRepository) Add(ctx context.Context, counters []*entities.UsageCounter) error {
+	sorted := make([]*entities.UsageCounter, len(counters))
+	copy(sorted, counters)
+	sort.Slice(sorted, func(i, j int) bool { return usageKey(sorted[i]) < usageKey(sorted[j]) })
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	for _, counter := range sorted {
+		err := qtx.AddAPIConsumerUsage(ctx, db.AddAPIConsumerUsageParams{
+			TenantID:     counter.TenantID,
+			ConsumerKind: string(counter.ConsumerKind),
+			ConsumerID:   counter.ConsumerID,
+			OperationID:  counter.OperationID,
+			Hour:         pgtype.Timestamptz{Time: counter.Hour, Valid: true},
+			Requests:     counter.Requests,
+			ClientErrors: counter.ClientErrors,
+			ServerErrors: counter.ServerErrors,
+			LatencyMs:    counter.Latency.Milliseconds(),
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func usageKey(counter *entities.UsageCounter) string {
+	return counter.TenantID + "\x00" + counter.Hour.UTC().Format(time.RFC3339) + "\x00" + string(counter.ConsumerKind) +
+		"\x00" + counter.ConsumerID + "\x00" + counter.OperationID
+}
+
+func (r *PostgresUsageRepository) SumByOperation(ctx context.Context, tenantID string, from time.Time, to time.Time) ([]*entities.UsageCounter, error) {
+	rows, err := r.queries.SumAPIConsumerUsageByOperation(ctx, db.SumAPIConsumerUsageByOperationParams{
+		TenantID: tenantID,
+		FromHour: pgtype.Timestamptz{Time: from, Valid: true},
+		ToHour:   pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	counters := make([]*entities.UsageCounter, 0, len(rows))
+	for _, row := range rows {
+		counters = append(counters, &entities.UsageCounter{
+			TenantID:     tenantID,
+			ConsumerKind: entities.ConsumerKind(row.ConsumerKind),
+			ConsumerID:   row.ConsumerID,
+			OperationID:  row.OperationID,
+			Requests:     row.Requests,
+			ClientErrors: row.ClientErrors,
+			ServerErrors: row.ServerErrors,
+			Latency:      time.Duration(row.LatencyMs) * time.Millisecond,
+		})
+	}
+	return counters, nil
+}
+
+func (r *PostgresUsageRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := r.queries.DeleteAPIConsumerUsageBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	return deleted, nil
+}
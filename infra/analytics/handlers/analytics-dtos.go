@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/analytics/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type GetAPIUsageRequest struct {
+	From      string `query:"from" format:"date" required:"true" doc:"First day of the range, in UTC"`
+	To        string `query:"to" format:"date" required:"true" doc:"Last day of the range, at most 92 days after from"`
+	Consumers int    `query:"consumers" minimum:"0" maximum:"500" doc:"Busiest consumers to list, 50 when absent. Flagged consumers are always listed."`
+}
+
+type EndpointUsageResponse struct {
+	OperationID      string  `json:"operation_id"`
+	Requests         int64   `json:"requests"`
+	ClientErrors     int64   `json:"client_errors"`
+	ServerErrors     int64   `json:"server_errors"`
+	AverageLatencyMs float64 `json:"average_latency_ms"`
+}
+
+type ConsumerUsageResponse struct {
+	Kind             string                  `json:"kind" enum:"user,service_account"`
+	ConsumerID       string                  `json:"consumer_id"`
+	Requests         int64                   `json:"requests"`
+	ClientErrors     int64                   `json:"client_errors"`
+	ServerErrors     int64                   `json:"server_errors"`
+	ErrorRate        float64                 `json:"error_rate" doc:"Share of the requests that failed, from 0 to 1"`
+	AverageLatencyMs float64                 `json:"average_latency_ms"`
+	TopEndpoints     []EndpointUsageResponse `json:"top_endpoints"`
+	Flags            []string                `json:"flags" doc:"Abuse signals: high_volume, high_error_rate"`
+}
+
+type APIUsageResponse struct {
+	Body struct {
+		From          time.Time               `json:"from"`
+		To            time.Time               `json:"to" doc:"End of the range, excluded"`
+		TotalRequests int64                   `json:"total_requests"`
+		Consumers     []ConsumerUsageResponse `json:"consumers"`
+	}
+}
+
+func NewAPIUsageResponse(report *entities.UsageReport, loc *time.Location) *APIUsageResponse {
+	response := &APIUsageResponse{}
+	response.Body.From = utils.InLocation(report.From, loc)
+	response.Body.To = utils.InLocation(report.To, loc)
+	response.Body.TotalRequests = report.TotalRequests
+	response.Body.Consumers = make([]ConsumerUsageResponse, 0, len(report.Consumers))
+	for _, usage := range report.Consumers {
+		consumer := ConsumerUsageResponse{
+			Kind:             string(usage.Kind),
+			ConsumerID:       usage.ConsumerID,
+			Requests:         usage.Requests,
+			ClientErrors:     usage.ClientErrors,
+			ServerErrors:     usage.ServerErrors,
+			ErrorRate:        usage.ErrorRate(),
+			AverageLatencyMs: milliseconds(usage.AverageLatency()),
+			TopEndpoints:     make([]EndpointUsageResponse, 0, len(usage.Endpoints)),
+			Flags:            append([]string{}, usage.Flags...),
+		}
+		for _, endpoint := range usage.Endpoints {
+			var average time.Duration
+			if endpoint.Requests > 0 {
+				average = endpoint.Latency / time.Duration(endpoint.Requests)
+			}
+			consumer.TopEndpoints = append(consumer.TopEndpoints, EndpointUsageResponse{
+				OperationID:      endpoint.OperationID,
+				Requests:         endpoint.Requests,
+				ClientErrors:     endpoint.ClientErrors,
+				ServerErrors:     endpoint.ServerErrors,
+				AverageLatencyMs: milliseconds(average),
+			})
+		}
+		response.Body.Consumers = append(response.Body.Consumers, consumer)
+	}
+	return response
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_api_usage_use_case "github.com/nahualventure/class-backend/core/app/analytics/application/use-cases/get-api-usage-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type AnalyticsHandlers struct {
+	getAPIUsageUseCase *get_api_usage_use_case.GetAPIUsageUseCase
+}
+
+func NewAnalyticsHandlers(getAPIUsageUseCase *get_api_usage_use_case.GetAPIUsageUseCase) *AnalyticsHandlers {
+	return &AnalyticsHandlers{
+		getAPIUsageUseCase: getAPIUsageUseCase,
+	}
+}
+
+func (h *AnalyticsHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-api-usage",
+		Method:      http.MethodGet,
+		Path:        "/analytics/usage",
+		Summary:     "API usage of the tenant per consumer",
+		Description: "Requests, error rates and top endpoints of the busiest consumers, with abuse flags. Counts are saved every minute, the last minute is not reported yet.",
+		Tags:        []string{"Analytics"},
+	}, h.GetAPIUsage)
+}
+
+func (h *AnalyticsHandlers) GetAPIUsage(ctx context.Context, input *GetAPIUsageRequest) (*APIUsageResponse, error) {
+	command, err := get_api_usage_use_case.NewGetAPIUsageCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.From,
+		input.To,
+		input.Consumers,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	report, err := h.getAPIUsageUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewAPIUsageResponse(report, utils.LocationFromContext(ctx)), nil
+}
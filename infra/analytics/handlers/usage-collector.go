@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/analytics/domain/entities"
+	serviceAccountEntities "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type counterKey struct {
+	tenantID    string
+	kind        entities.ConsumerKind
+	consumerID  string
+	operationID string
+	hour        time.Time
+}
+
+// UsageCollector counts the requests of each consumer in memory until the flush worker saves them
+type UsageCollector struct {
+	mu       sync.Mutex
+	counters map[counterKey]*entities.UsageCounter
+}
+
+func NewUsageCollector() *UsageCollector {
+	return &UsageCollector{counters: make(map[counterKey]*entities.UsageCounter)}
+}
+
+// Middleware counts the authorized requests, with their status and latency, per consumer and
+// operation. It runs right after the authorization middleware, which identifies the consumer, so
+// requests rejected by load shedding, plan gating or the handler are counted as errors.
+func (c *UsageCollector) Middleware(ctx huma.Context, next func(huma.Context)) {
+	userID := authorization.UserIDFromContext(ctx.Context())
+	tenantID := authorization.TenantIDFromContext(ctx.Context())
+	if userID == "" || tenantID == "" {
+		next(ctx)
+		return
+	}
+
+	start := time.Now()
+	next(ctx)
+
+	kind := entities.ConsumerUser
+	if serviceAccountEntities.IsServiceAccountSubject(userID) {
+		kind = entities.ConsumerServiceAccount
+	}
+	key := counterKey{
+		tenantID:    tenantID,
+		kind:        kind,
+		consumerID:  userID,
+		operationID: ctx.Operation().OperationID,
+		hour:        start.UTC().Truncate(time.Hour),
+	}
+	c.mu.Lock()
+	c.counter(key).Record(ctx.Status(), time.Since(start))
+	c.mu.Unlock()
+}
+
+func (c *UsageCollector) counter(key counterKey) *entities.UsageCounter {
+	counter, ok := c.counters[key]
+	if !ok {
+		counter = &entities.UsageCounter{
+			TenantID:     key.tenantID,
+			ConsumerKind: key.kind,
+			ConsumerID:   key.consumerID,
+			OperationID:  key.operationID,
+			Hour:         key.hour,
+		}
+		c.counters[key] = counter
+	}
+	return counter
+}
+
+// Drain returns the counters collected since the last drain and starts over
+func (c *UsageCollector) Drain() []*entities.UsageCounter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counters := make([]*entities.UsageCounter, 0, len(c.counters))
+	for _, counter := range c.counters {
+		counters = append(counters, counter)
+	}
+	c.counters = make(map[counterKey]*entities.UsageCounter)
+	return counters
+}
+
+// Restore adds back counters that could not be saved, the next flush retries them
+func (c *UsageCollector) Restore(counters []*entities.UsageCounter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, restored := range counters {
+		counter := c.counter(counterKey{
+			tenantID:    restored.TenantID,
+			kind:        restored.ConsumerKind,
+			consumerID:  restored.ConsumerID,
+			operationID: restored.OperationID,
+			hour:        restored.Hour,
+		})
+		counter.Requests += restored.Requests
+		counter.ClientErrors += restored.ClientErrors
+		counter.ServerErrors += restored.ServerErrors
+		counter.Latency += restored.Latency
+	}
+}
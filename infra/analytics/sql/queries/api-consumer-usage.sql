@@ -0,0 +1,22 @@
+-- name: AddAPIConsumerUsage :exec
+INSERT INTO api_consumer_usage (tenant_id, consumer_kind, consumer_id, operation_id, hour, requests, client_errors, server_errors, latency_ms)
+VALUES (@tenant_id, @consumer_kind, @consumer_id, @operation_id, @hour, @requests, @client_errors, @server_errors, @latency_ms)
+ON CONFLICT (tenant_id, hour, consumer_kind, consumer_id, operation_id) DO UPDATE SET
+    requests = api_consumer_usage.requests + EXCLUDED.requests,
+    client_errors = api_consumer_usage.client_errors + EXCLUDED.client_errors,
+    server_errors = api_consumer_usage.server_errors + EXCLUDED.server_errors,
+    latency_ms = api_consumer_usage.latency_ms + EXCLUDED.latency_ms;
+
+-- name: SumAPIConsumerUsageByOperation :many
+SELECT consumer_kind, consumer_id, operation_id,
+    SUM(requests)::BIGINT AS requests,
+    SUM(client_errors)::BIGINT AS client_errors,
+    SUM(server_errors)::BIGINT AS server_errors,
+    SUM(latency_ms)::BIGINT AS latency_ms
+FROM api_consumer_usage
+WHERE tenant_id = @tenant_id AND hour >= @from_hour AND hour < @to_hour
+GROUP BY consumer_kind, consumer_id, operation_id;
+
+-- name: DeleteAPIConsumerUsageBefore :execrows
+DELETE FROM api_consumer_usage
+WHERE hour < @before;
@@ -0,0 +1,16 @@
+-- Requests of each consumer to each operation per hour, instances add their counts to the row of
+-- the hour. Latency is the total time spent serving the requests.
+CREATE TABLE api_consumer_usage (
+    tenant_id VARCHAR(255) NOT NULL,
+    consumer_kind VARCHAR(20) NOT NULL,
+    consumer_id VARCHAR(255) NOT NULL,
+    operation_id VARCHAR(100) NOT NULL,
+    hour TIMESTAMP WITH TIME ZONE NOT NULL,
+    requests BIGINT NOT NULL DEFAULT 0,
+    client_errors BIGINT NOT NULL DEFAULT 0,
+    server_errors BIGINT NOT NULL DEFAULT 0,
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant_id, hour, consumer_kind, consumer_id, operation_id)
+);
+
+CREATE INDEX idx_api_consumer_usage_hour ON api_consumer_usage (hour);
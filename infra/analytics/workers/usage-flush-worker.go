@@ -0,0 +1,70 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	record_api_usage_use_case "github.com/nahualventure/class-backend/core/app/analytics/application/use-cases/record-api-usage-use-case"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	"github.com/nahualventure/class-backend/infra/analytics/handlers"
+)
+
+// finalFlushTimeout bounds the flush on shutdown, after ctx is cancelled
+const finalFlushTimeout = 5 * time.Second
+
+// RunUsageFlush saves the counters of the collector every interval and once more when ctx is
+// cancelled. Counters that fail to save are kept for the next flush.
+func RunUsageFlush(ctx context.Context, collector *handlers.UsageCollector, useCase *record_api_usage_use_case.RecordAPIUsageUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+			flushUsage(flushCtx, collector, useCase)
+			cancel()
+			return
+		case <-ticker.C:
+			flushUsage(ctx, collector, useCase)
+		}
+	}
+}
+
+func flushUsage(ctx context.Context, collector *handlers.UsageCollector, useCase *record_api_usage_use_case.RecordAPIUsageUseCase) {
+	counters := collector.Drain()
+	if len(counters) == 0 {
+		return
+	}
+
+	command, err := record_api_usage_use_case.NewRecordAPIUsageCommand(counters)
+	if err != nil {
+		log.Printf("Dropped invalid API usage counters: %v", err)
+		return
+	}
+	if err := useCase.Execute(ctx, command); err != nil {
+		log.Printf("Failed to save API usage, retrying on the next flush: %v", err)
+		collector.Restore(counters)
+	}
+}
+
+// RunUsageRetention deletes expired API usage every interval, it returns when ctx is cancelled
+func RunUsageRetention(ctx context.Context, useCase *record_api_usage_use_case.RecordAPIUsageUseCase, clock clock.Clock, interval time.Duration) {
+	ticker := clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := useCase.PurgeExpired(ctx); err != nil {
+			log.Printf("Failed to purge expired API usage: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Purged %d expired API usage counters", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/archive/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/archive/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresArchiveStore keeps archived records in the archived_records table, partitioned by kind, next
+// to the hot tables they came from. Moves in both directions run in one transaction so a record is
+// never in both places or in neither.
+type PostgresArchiveStore struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresArchiveStore(dbInstance *pgxpool.Pool) ports.ArchiveStore {
+	return &PostgresArchiveStore{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresArchiveStore) Archive(ctx context.Context, batch *entities.ArchiveBatch, limit int) (int, error) {
+	var pgBatchID pgtype.UUID
+	if err := pgBatchID.Scan(batch.ID); err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	cutoff := pgtype.Timestamptz{Time: batch.Cutoff, Valid: true}
+	archivedAt := pgtype.Timestamptz{Time: batch.ArchivedAt, Valid: true}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+	var moved int64
+	switch batch.Kind {
+	case entities.ArchiveKindSubmissions:
+		moved, err = qtx.ArchiveGrades(ctx, db.ArchiveGradesParams{
+			TenantID: batch.TenantID, Cutoff: cutoff, BatchSize: int32(limit), BatchID: pgBatchID, ArchivedAt: archivedAt,
+		})
+	case entities.ArchiveKindMessages:
+		moved, err = qtx.ArchiveMessages(ctx, db.ArchiveMessagesParams{
+			TenantID: batch.TenantID, Cutoff: cutoff, BatchSize: int32(limit), BatchID: pgBatchID, ArchivedAt: archivedAt,
+		})
+	case entities.ArchiveKindAudit:
+		moved, err = qtx.ArchiveOutboxEvents(ctx, db.ArchiveOutboxEventsParams{
+			TenantID: batch.TenantID, Cutoff: cutoff, BatchSize: int32(limit), BatchID: pgBatchID, ArchivedAt: archivedAt,
+		})
+	default:
+		return 0, appErrors.PropagateError(errors.New("unknown archive kind " + string(batch.Kind)))
+	}
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	if moved == 0 {
+		return 0, nil
+	}
+
+	batch.RecordCount = int(moved)
+	err = qtx.InsertArchiveBatch(ctx, db.InsertArchiveBatchParams{
+		ID:            pgBatchID,
+		TenantID:      batch.TenantID,
+		Kind:          string(batch.Kind),
+		Cutoff:        cutoff,
+		RecordCount:   int32(batch.RecordCount),
+		RestoredCount: int32(batch.RestoredCount),
+		Status:        string(batch.Status),
+		ArchivedAt:    archivedAt,
+	})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	return batch.RecordCount, nil
+}
+
+func (s *PostgresArchiveStore) Restore(ctx context.Context, batch *entities.ArchiveBatch) (int, int, error) {
+	var pgBatchID pgtype.UUID
+	if err := pgBatchID.Scan(batch.ID); err != nil {
+		return 0, 0, appErrors.PropagateError(err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, 0, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+	var restored []string
+	switch batch.Kind {
+	case entities.ArchiveKindSubmissions:
+		restored, err = qtx.RestoreGrades(ctx, db.RestoreGradesParams{TenantID: batch.TenantID, BatchID: pgBatchID})
+	case entities.ArchiveKindMessages:
+		restored, err = qtx.RestoreMessages(ctx, db.RestoreMessagesParams{TenantID: batch.TenantID, BatchID: pgBatchID})
+		if err == nil && len(restored) > 0 {
+			err = qtx.RestoreMessageAttachments(ctx, db.RestoreMessageAttachmentsParams{TenantID: batch.TenantID, MessageIds: restored})
+		}
+	case entities.ArchiveKindAudit:
+		restored, err = qtx.RestoreOutboxEvents(ctx, db.RestoreOutboxEventsParams{TenantID: batch.TenantID, BatchID: pgBatchID})
+	default:
+		return 0, 0, appErrors.PropagateError(errors.New("unknown archive kind " + string(batch.Kind)))
+	}
+	if err != nil {
+		return 0, 0, appErrors.PropagateError(err)
+	}
+
+	if len(restored) > 0 {
+		err = qtx.DeleteArchivedRecords(ctx, db.DeleteArchivedRecordsParams{
+			Kind:      string(batch.Kind),
+			TenantID:  batch.TenantID,
+			RecordIds: restored,
+		})
+		if err != nil {
+			return 0, 0, appErrors.PropagateError(err)
+		}
+	}
+
+	remaining, err := qtx.CountArchivedRecordsInBatch(ctx, db.CountArchivedRecordsInBatchParams{
+		Kind:    string(batch.Kind),
+		BatchID: pgBatchID,
+	})
+	if err != nil {
+		return 0, 0, appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, appErrors.PropagateError(err)
+	}
+	return len(restored), int(remaining), nil
+}
+
+func (s *PostgresArchiveStore) SaveBatch(ctx context.Context, batch *entities.ArchiveBatch) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(batch.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := s.queries.UpdateArchiveBatchRestore(ctx, db.UpdateArchiveBatchRestoreParams{
+		RestoredCount: int32(batch.RestoredCount),
+		Status:        string(batch.Status),
+		RestoredBy:    emptyToNil(batch.RestoredBy),
+		RestoredAt:    timestamptz(batch.RestoredAt),
+		TenantID:      batch.TenantID,
+		ID:            pgID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (s *PostgresArchiveStore) FindBatch(ctx context.Context, tenantID string, batchID string) (*entities.ArchiveBatch, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(batchID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := s.queries.GetArchiveBatch(ctx, db.GetArchiveBatchParams{TenantID: tenantID, ID: pgID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toArchiveBatch(row)
+}
+
+func (s *PostgresArchiveStore) ListBatches(ctx context.Context, tenantID string, kind entities.ArchiveKind) ([]*entities.ArchiveBatch, error) {
+	rows, err := s.queries.ListArchiveBatches(ctx, db.ListArchiveBatchesParams{TenantID: tenantID, Kind: string(kind)})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	batches := make([]*entities.ArchiveBatch, 0, len(rows))
+	for _, row := range rows {
+		batch, err := toArchiveBatch(row)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+func toArchiveBatch(row db.ArchiveBatch) (*entities.ArchiveBatch, error) {
+	batch, err := entities.NewArchiveBatch(row.ID.String(), row.TenantID, entities.ArchiveKind(row.Kind), row.Cutoff.Time, row.ArchivedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	batch.RecordCount = int(row.RecordCount)
+	batch.RestoredCount = int(row.RestoredCount)
+	batch.Status = entities.ArchiveBatchStatus(row.Status)
+	if row.RestoredBy != nil {
+		batch.RestoredBy = *row.RestoredBy
+	}
+	if row.RestoredAt.Valid {
+		restoredAt := row.RestoredAt.Time
+		batch.RestoredAt = &restoredAt
+	}
+	return batch, nil
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func timestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/archive/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type ArchiveBatchResponse struct {
+	ID            string     `json:"id"`
+	Kind          string     `json:"kind" enum:"submissions,messages,audit"`
+	Cutoff        time.Time  `json:"cutoff" doc:"Records older than the cutoff were archived"`
+	RecordCount   int        `json:"record_count"`
+	RestoredCount int        `json:"restored_count"`
+	Status        string     `json:"status" enum:"archived,restored,partially_restored"`
+	ArchivedAt    time.Time  `json:"archived_at"`
+	RestoredBy    string     `json:"restored_by,omitempty"`
+	RestoredAt    *time.Time `json:"restored_at,omitempty"`
+}
+
+func NewArchiveBatchResponse(batch *entities.ArchiveBatch, loc *time.Location) ArchiveBatchResponse {
+	return ArchiveBatchResponse{
+		ID:            batch.ID,
+		Kind:          string(batch.Kind),
+		Cutoff:        utils.InLocation(batch.Cutoff, loc),
+		RecordCount:   batch.RecordCount,
+		RestoredCount: batch.RestoredCount,
+		Status:        string(batch.Status),
+		ArchivedAt:    utils.InLocation(batch.ArchivedAt, loc),
+		RestoredBy:    batch.RestoredBy,
+		RestoredAt:    utils.InLocationPtr(batch.RestoredAt, loc),
+	}
+}
+
+type ListArchiveBatchesRequest struct {
+	Kind string `query:"kind" enum:"submissions,messages,audit" doc:"Only batches of this kind, every kind when absent"`
+}
+
+type ArchiveBatchesResponse struct {
+	Body struct {
+		Batches []ArchiveBatchResponse `json:"batches"`
+	}
+}
+
+type RestoreArchiveBatchRequest struct {
+	BatchID string `path:"batchId" format:"uuid"`
+}
+
+type ArchiveBatchEnvelope struct {
+	Body ArchiveBatchResponse
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	list_archive_batches_use_case "github.com/nahualventure/class-backend/core/app/archive/application/use-cases/list-archive-batches-use-case"
+	restore_archive_batch_use_case "github.com/nahualventure/class-backend/core/app/archive/application/use-cases/restore-archive-batch-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ArchiveHandlers struct {
+	listArchiveBatchesUseCase  *list_archive_batches_use_case.ListArchiveBatchesUseCase
+	restoreArchiveBatchUseCase *restore_archive_batch_use_case.RestoreArchiveBatchUseCase
+}
+
+func NewArchiveHandlers(
+	listArchiveBatchesUseCase *list_archive_batches_use_case.ListArchiveBatchesUseCase,
+	restoreArchiveBatchUseCase *restore_archive_batch_use_case.RestoreArchiveBatchUseCase,
+) *ArchiveHandlers {
+	return &ArchiveHandlers{
+		listArchiveBatchesUseCase:  listArchiveBatchesUseCase,
+		restoreArchiveBatchUseCase: restoreArchiveBatchUseCase,
+	}
+}
+
+func (h *ArchiveHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-archive-batches",
+		Method:      http.MethodGet,
+		Path:        "/archive/batches",
+		Summary:     "List the batches of prior-year records moved to the archive",
+		Tags:        []string{"Archive"},
+	}, h.ListArchiveBatches)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-archive-batch",
+		Method:      http.MethodPost,
+		Path:        "/archive/batches/{batchId}/restore",
+		Summary:     "Move the records of an archive batch back",
+		Description: "Records that clash with newer ones, such as a grade recorded again for the same student and assignment, stay archived and the batch is left partially restored.",
+		Tags:        []string{"Archive"},
+	}, h.RestoreArchiveBatch)
+}
+
+func (h *ArchiveHandlers) ListArchiveBatches(ctx context.Context, input *ListArchiveBatchesRequest) (*ArchiveBatchesResponse, error) {
+	command, err := list_archive_batches_use_case.NewListArchiveBatchesCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Kind,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	batches, err := h.listArchiveBatchesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &ArchiveBatchesResponse{}
+	response.Body.Batches = make([]ArchiveBatchResponse, 0, len(batches))
+	for _, batch := range batches {
+		response.Body.Batches = append(response.Body.Batches, NewArchiveBatchResponse(batch, loc))
+	}
+	return response, nil
+}
+
+func (h *ArchiveHandlers) RestoreArchiveBatch(ctx context.Context, input *RestoreArchiveBatchRequest) (*ArchiveBatchEnvelope, error) {
+	command, err := restore_archive_batch_use_case.NewRestoreArchiveBatchCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.BatchID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	batch, err := h.restoreArchiveBatchUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ArchiveBatchEnvelope{Body: NewArchiveBatchResponse(batch, utils.LocationFromContext(ctx))}, nil
+}
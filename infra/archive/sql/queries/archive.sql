@@ -0,0 +1,131 @@
+-- name: ArchiveGrades :execrows
+-- Moves released grades of prior years, grades still in review stay in the hot table
+WITH moved AS (
+    DELETE FROM grades
+    WHERE id IN (
+        SELECT g.id FROM grades g
+        WHERE g.tenant_id = @tenant_id AND g.status = 'released' AND g.released_at < @cutoff
+        ORDER BY g.released_at, g.id
+        LIMIT @batch_size
+    )
+    RETURNING *
+)
+INSERT INTO archived_records (kind, tenant_id, record_id, batch_id, recorded_at, payload, archived_at)
+SELECT 'submissions', moved.tenant_id, moved.id::text, @batch_id::uuid, moved.released_at, to_jsonb(moved), @archived_at::timestamptz
+FROM moved;
+
+-- name: ArchiveMessages :execrows
+-- Moves messages of prior years with their attachment metadata. Reported messages are kept as evidence
+-- while the report exists.
+WITH moved AS (
+    DELETE FROM messages
+    WHERE id IN (
+        SELECT m.id FROM messages m
+        WHERE m.tenant_id = @tenant_id AND m.created_at < @cutoff
+          AND NOT EXISTS (SELECT 1 FROM abuse_reports r WHERE r.message_id = m.id)
+        ORDER BY m.created_at, m.id
+        LIMIT @batch_size
+    )
+    RETURNING *
+)
+INSERT INTO archived_records (kind, tenant_id, record_id, batch_id, recorded_at, payload, archived_at)
+SELECT 'messages', moved.tenant_id, moved.id::text, @batch_id::uuid, moved.created_at,
+       to_jsonb(moved) || jsonb_build_object('attachments', COALESCE(
+           (SELECT jsonb_agg(to_jsonb(a)) FROM message_attachments a WHERE a.message_id = moved.id),
+           '[]'::jsonb
+       )),
+       @archived_at::timestamptz
+FROM moved;
+
+-- name: ArchiveOutboxEvents :execrows
+-- Moves outbox events of prior years every projection already applied
+WITH moved AS (
+    DELETE FROM outbox_events o
+    WHERE o.occurred_at < @cutoff AND o.position IN (
+        SELECT e.position FROM outbox_events e
+        WHERE e.tenant_id = @tenant_id AND e.occurred_at < @cutoff
+          AND e.position <= (SELECT COALESCE(MIN(c.position), 0) FROM projection_checkpoints c)
+        ORDER BY e.position
+        LIMIT @batch_size
+    )
+    RETURNING *
+)
+INSERT INTO archived_records (kind, tenant_id, record_id, batch_id, recorded_at, payload, archived_at)
+SELECT 'audit', moved.tenant_id, moved.position::text, @batch_id::uuid, moved.occurred_at, to_jsonb(moved), @archived_at::timestamptz
+FROM moved;
+
+-- name: RestoreGrades :many
+-- Grades recorded again for the same student and assignment since archival are not overwritten
+INSERT INTO grades (
+    id, tenant_id, class_id, assignment_id, student_id, score, status, recorded_by, reviewed_by, return_reason,
+    turned_in_at, late_penalty_percent, submitted_at, released_at, created_at, updated_at, version, dropped
+)
+SELECT (a.payload->>'id')::uuid, a.tenant_id, (a.payload->>'class_id')::uuid, (a.payload->>'assignment_id')::uuid,
+       a.payload->>'student_id', (a.payload->>'score')::double precision, a.payload->>'status', a.payload->>'recorded_by',
+       a.payload->>'reviewed_by', a.payload->>'return_reason', (a.payload->>'turned_in_at')::timestamptz,
+       (a.payload->>'late_penalty_percent')::double precision, (a.payload->>'submitted_at')::timestamptz,
+       (a.payload->>'released_at')::timestamptz, (a.payload->>'created_at')::timestamptz, (a.payload->>'updated_at')::timestamptz,
+       COALESCE((a.payload->>'version')::integer, 0), COALESCE((a.payload->>'dropped')::boolean, false)
+FROM archived_records a
+WHERE a.kind = 'submissions' AND a.tenant_id = @tenant_id AND a.batch_id = @batch_id
+ON CONFLICT DO NOTHING
+RETURNING id::text AS record_id;
+
+-- name: RestoreMessages :many
+-- Messages of conversations deleted since archival stay archived
+INSERT INTO messages (id, tenant_id, conversation_id, sender_id, body, hidden_at, hidden_by, created_at)
+SELECT (a.payload->>'id')::uuid, a.tenant_id, c.id, a.payload->>'sender_id', a.payload->>'body',
+       (a.payload->>'hidden_at')::timestamptz, a.payload->>'hidden_by', (a.payload->>'created_at')::timestamptz
+FROM archived_records a
+JOIN conversations c ON c.id = (a.payload->>'conversation_id')::uuid
+WHERE a.kind = 'messages' AND a.tenant_id = @tenant_id AND a.batch_id = @batch_id
+ON CONFLICT DO NOTHING
+RETURNING id::text AS record_id;
+
+-- name: RestoreMessageAttachments :exec
+INSERT INTO message_attachments (id, message_id, file_name, content_type, size_bytes)
+SELECT (att->>'id')::uuid, (att->>'message_id')::uuid, att->>'file_name', att->>'content_type', (att->>'size_bytes')::bigint
+FROM archived_records a, jsonb_array_elements(a.payload->'attachments') att
+WHERE a.kind = 'messages' AND a.tenant_id = @tenant_id AND a.record_id = ANY(@message_ids::varchar[])
+ON CONFLICT DO NOTHING;
+
+-- name: RestoreOutboxEvents :many
+-- Events keep their position, projections past it do not apply them again
+INSERT INTO outbox_events (position, event_type, aggregate_id, tenant_id, payload, occurred_at)
+SELECT (a.payload->>'position')::bigint, a.payload->>'event_type', a.payload->>'aggregate_id', a.tenant_id,
+       a.payload->'payload', (a.payload->>'occurred_at')::timestamptz
+FROM archived_records a
+WHERE a.kind = 'audit' AND a.tenant_id = @tenant_id AND a.batch_id = @batch_id
+ON CONFLICT DO NOTHING
+RETURNING position::text AS record_id;
+
+-- name: DeleteArchivedRecords :exec
+DELETE FROM archived_records
+WHERE kind = @kind AND tenant_id = @tenant_id AND record_id = ANY(@record_ids::varchar[]);
+
+-- name: CountArchivedRecordsInBatch :one
+SELECT COUNT(*) FROM archived_records
+WHERE kind = @kind AND batch_id = @batch_id;
+
+-- name: InsertArchiveBatch :exec
+INSERT INTO archive_batches (id, tenant_id, kind, cutoff, record_count, restored_count, status, archived_at)
+VALUES (@id, @tenant_id, @kind, @cutoff, @record_count, @restored_count, @status, @archived_at);
+
+-- name: UpdateArchiveBatchRestore :exec
+UPDATE archive_batches
+SET restored_count = @restored_count,
+    status = @status,
+    restored_by = @restored_by,
+    restored_at = @restored_at
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: GetArchiveBatch :one
+SELECT id, tenant_id, kind, cutoff, record_count, restored_count, status, archived_at, restored_by, restored_at
+FROM archive_batches
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: ListArchiveBatches :many
+SELECT id, tenant_id, kind, cutoff, record_count, restored_count, status, archived_at, restored_by, restored_at
+FROM archive_batches
+WHERE tenant_id = @tenant_id AND (@kind::varchar = '' OR kind = @kind)
+ORDER BY archived_at DESC, id;
@@ -0,0 +1,36 @@
+-- One run of the archival job over one kind of record of a tenant, records are restored by batch
+CREATE TABLE archive_batches (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL,             -- submissions, messages, audit
+    cutoff TIMESTAMP WITH TIME ZONE NOT NULL,
+    record_count INTEGER NOT NULL,
+    restored_count INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,           -- archived, restored, partially_restored
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    restored_by VARCHAR(255),
+    restored_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_archive_batches_tenant ON archive_batches(tenant_id, archived_at);
+
+-- Cold copies of prior-year records moved out of the hot tables, one partition per kind. payload keeps
+-- every column of the original row so it can be restored as it was.
+CREATE TABLE archived_records (
+    kind VARCHAR(20) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,
+    record_id VARCHAR(255) NOT NULL,       -- grade or message ID, outbox position for audit entries
+    batch_id UUID NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    payload JSONB NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (kind, tenant_id, record_id)
+) PARTITION BY LIST (kind);
+
+CREATE TABLE archived_submissions PARTITION OF archived_records FOR VALUES IN ('submissions');
+CREATE TABLE archived_messages PARTITION OF archived_records FOR VALUES IN ('messages');
+CREATE TABLE archived_audit_entries PARTITION OF archived_records FOR VALUES IN ('audit');
+
+CREATE INDEX idx_archived_records_batch ON archived_records(batch_id);
+-- Transcripts read the archived grades of one student
+CREATE INDEX idx_archived_submissions_student ON archived_submissions(tenant_id, (payload->>'student_id'));
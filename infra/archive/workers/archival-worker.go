@@ -0,0 +1,40 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	run_archival_use_case "github.com/nahualventure/class-backend/core/app/archive/application/use-cases/run-archival-use-case"
+)
+
+// RunArchival moves the prior-year records of every tenant into the archive on each tick until ctx is
+// cancelled. Once a school year is archived the runs only pick up records that aged past the cutoff.
+func RunArchival(ctx context.Context, useCase *run_archival_use_case.RunArchivalUseCase, tenants []string, schoolYearStart int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, tenantID := range tenants {
+			command, err := run_archival_use_case.NewRunArchivalCommand(tenantID, time.Now(), schoolYearStart)
+			if err != nil {
+				log.Printf("Invalid archival of %s: %v", tenantID, err)
+				continue
+			}
+
+			batches, err := useCase.Execute(ctx, command)
+			if err != nil {
+				log.Printf("Archival of %s failed: %v", tenantID, err)
+			}
+			for _, batch := range batches {
+				log.Printf("Archived %d %s records of %s in batch %s", batch.RecordCount, batch.Kind, tenantID, batch.ID)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
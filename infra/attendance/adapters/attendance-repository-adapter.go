@@ -0,0 +1,103 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresAttendanceRepository records attendance with its outbox event in one transaction, the class
+// summaries count it from there
+type PostgresAttendanceRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresAttendanceRepository(dbInstance *pgxpool.Pool) ports.AttendanceRepository {
+	return &PostgresAttendanceRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresAttendanceRepository) Record(ctx context.Context, record *entities.AttendanceRecord) (*entities.AttendanceRecord, error) {
+	var pgSessionID, pgClassID pgtype.UUID
+	if err := pgSessionID.Scan(record.SessionID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := pgClassID.Scan(record.ClassID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	recordedAt := pgtype.Timestamptz{Time: record.RecordedAt, Valid: true}
+
+	payload, err := json.Marshal(map[string]any{
+		"class_id":   record.ClassID,
+		"student_id": record.StudentID,
+		"present":    record.Present,
+		"session_id": record.SessionID,
+		"method":     string(record.Method),
+	})
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to serialize attendance event", err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	inserted, err := qtx.InsertAttendanceRecord(ctx, db.InsertAttendanceRecordParams{
+		SessionID:  pgSessionID,
+		StudentID:  record.StudentID,
+		TenantID:   record.TenantID,
+		ClassID:    pgClassID,
+		Present:    record.Present,
+		Method:     string(record.Method),
+		RecordedAt: recordedAt,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if inserted == 0 {
+		row, err := qtx.GetAttendanceRecord(ctx, db.GetAttendanceRecordParams{SessionID: pgSessionID, StudentID: record.StudentID})
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		return &entities.AttendanceRecord{
+			TenantID:   row.TenantID,
+			ClassID:    row.ClassID.String(),
+			SessionID:  row.SessionID.String(),
+			StudentID:  row.StudentID,
+			Present:    row.Present,
+			Method:     entities.CheckInMethod(row.Method),
+			RecordedAt: row.RecordedAt.Time,
+		}, nil
+	}
+
+	err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.AttendanceRecorded,
+		AggregateID: record.SessionID,
+		TenantID:    record.TenantID,
+		Payload:     payload,
+		OccurredAt:  recordedAt,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return record, nil
+}
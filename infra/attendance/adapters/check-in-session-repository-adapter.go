@@ -0,0 +1,94 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresCheckInSessionRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresCheckInSessionRepository(dbInstance *pgxpool.Pool) ports.CheckInSessionRepository {
+	return &PostgresCheckInSessionRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresCheckInSessionRepository) Create(ctx context.Context, session *entities.CheckInSession) (*entities.CheckInSession, error) {
+	var pgID, pgClassID pgtype.UUID
+	if err := pgID.Scan(session.ID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := pgClassID.Scan(session.ClassID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	params := db.CreateCheckInSessionParams{
+		ID:       pgID,
+		TenantID: session.TenantID,
+		ClassID:  pgClassID,
+		StartsAt: pgtype.Timestamptz{Time: session.StartsAt, Valid: true},
+		EndsAt:   pgtype.Timestamptz{Time: session.EndsAt, Valid: true},
+		Secret:   session.Secret,
+		OpenedBy: session.OpenedBy,
+		OpenedAt: pgtype.Timestamptz{Time: session.OpenedAt, Valid: true},
+	}
+	if fence := session.Fence; fence != nil {
+		radius := int32(fence.RadiusMeters)
+		params.FenceLatitude, params.FenceLongitude, params.FenceRadiusMeters = &fence.Latitude, &fence.Longitude, &radius
+	}
+
+	row, err := r.queries.CreateCheckInSession(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return toCheckInSession(row), nil
+}
+
+func (r *PostgresCheckInSessionRepository) Find(ctx context.Context, tenantID string, sessionID string) (*entities.CheckInSession, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(sessionID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetCheckInSession(ctx, db.GetCheckInSessionParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toCheckInSession(row), nil
+}
+
+func toCheckInSession(row db.CheckInSession) *entities.CheckInSession {
+	session := &entities.CheckInSession{
+		ID:       row.ID.String(),
+		TenantID: row.TenantID,
+		ClassID:  row.ClassID.String(),
+		StartsAt: row.StartsAt.Time,
+		EndsAt:   row.EndsAt.Time,
+		Secret:   row.Secret,
+		OpenedBy: row.OpenedBy,
+		OpenedAt: row.OpenedAt.Time,
+	}
+	if row.FenceLatitude != nil && row.FenceLongitude != nil && row.FenceRadiusMeters != nil {
+		session.Fence = &entities.GeoFence{
+			Latitude:     *row.FenceLatitude,
+			Longitude:    *row.FenceLongitude,
+			RadiusMeters: int(*row.FenceRadiusMeters),
+		}
+	}
+	return session
+}
@@ -0,0 +1,41 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresClassRoster reads the enrollments of the enrollment module
+type PostgresClassRoster struct {
+	queries *db.Queries
+}
+
+func NewPostgresClassRoster(dbInstance *pgxpool.Pool) ports.ClassRoster {
+	return &PostgresClassRoster{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresClassRoster) IsEnrolled(ctx context.Context, tenantID string, classID string, userID string) (bool, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	enrolled, err := r.queries.ClassEnrollmentExists(ctx, db.ClassEnrollmentExistsParams{
+		TenantID: tenantID,
+		ClassID:  pgClassID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return enrolled, nil
+}
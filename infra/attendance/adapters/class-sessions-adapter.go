@@ -0,0 +1,68 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	timezoneEntities "github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresClassSessions reads the weekly meetings of the enrollment module and the non-instructional
+// days of the calendar module
+type PostgresClassSessions struct {
+	queries *db.Queries
+}
+
+func NewPostgresClassSessions(dbInstance *pgxpool.Pool) ports.ClassSessions {
+	return &PostgresClassSessions{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresClassSessions) SessionsOn(ctx context.Context, tenantID string, classID string, day time.Time,
+	loc *time.Location) ([]entities.ClassSession, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	local := day.In(loc)
+	date := pgtype.Date{Time: time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+	closed, err := s.queries.ListNonInstructionalDays(ctx, db.ListNonInstructionalDaysParams{
+		TenantID: tenantID,
+		FromDay:  date,
+		ToDay:    date,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if len(closed) > 0 {
+		return nil, nil
+	}
+
+	meetings, err := s.queries.ListClassMeetings(ctx, db.ListClassMeetingsParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var sessions []entities.ClassSession
+	for _, meeting := range meetings {
+		if time.Weekday(meeting.Weekday) != local.Weekday() {
+			continue
+		}
+		sessions = append(sessions, entities.ClassSession{
+			ClassID:  classID,
+			StartsAt: timezoneEntities.WallClock(local.Year(), local.Month(), local.Day(), int(meeting.StartMinute), loc),
+			EndsAt:   timezoneEntities.WallClock(local.Year(), local.Month(), local.Day(), int(meeting.EndMinute), loc),
+		})
+	}
+	return sessions, nil
+}
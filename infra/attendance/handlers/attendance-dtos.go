@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"time"
+
+	get_check_in_code_use_case "github.com/nahualventure/class-backend/core/app/attendance/application/use-cases/get-check-in-code-use-case"
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/entities"
+)
+
+type OpenCheckInRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	Body    struct {
+		Latitude     *float64 `json:"latitude,omitempty" minimum:"-90" maximum:"90" doc:"Center of the area students check in from, absent to accept any location"`
+		Longitude    *float64 `json:"longitude,omitempty" minimum:"-180" maximum:"180"`
+		RadiusMeters int      `json:"radius_meters,omitempty" minimum:"0" maximum:"5000" default:"100" doc:"Radius of the area around the center"`
+	}
+}
+
+type CheckInSessionResponse struct {
+	ID        string    `json:"id"`
+	ClassID   string    `json:"class_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at" doc:"Check-in closes when the class ends"`
+	Latitude  *float64  `json:"latitude,omitempty"`
+	Longitude *float64  `json:"longitude,omitempty"`
+	Radius    *int      `json:"radius_meters,omitempty"`
+	OpenedBy  string    `json:"opened_by"`
+	OpenedAt  time.Time `json:"opened_at"`
+}
+
+func NewCheckInSessionResponse(session *entities.CheckInSession) CheckInSessionResponse {
+	response := CheckInSessionResponse{
+		ID:       session.ID,
+		ClassID:  session.ClassID,
+		StartsAt: session.StartsAt,
+		EndsAt:   session.EndsAt,
+		OpenedBy: session.OpenedBy,
+		OpenedAt: session.OpenedAt,
+	}
+	if fence := session.Fence; fence != nil {
+		response.Latitude, response.Longitude, response.Radius = &fence.Latitude, &fence.Longitude, &fence.RadiusMeters
+	}
+	return response
+}
+
+type CheckInSessionEnvelope struct {
+	Body CheckInSessionResponse
+}
+
+type GetCheckInCodeRequest struct {
+	SessionID string `path:"sessionId" format:"uuid"`
+}
+
+type CheckInCodeResponse struct {
+	Session   CheckInSessionResponse `json:"session"`
+	Code      string                 `json:"code" doc:"Code to show as a QR on the kiosk"`
+	ExpiresAt time.Time              `json:"expires_at" doc:"When the kiosk fetches the next code"`
+}
+
+type CheckInCodeEnvelope struct {
+	Body CheckInCodeResponse
+}
+
+func NewCheckInCodeEnvelope(code *get_check_in_code_use_case.CheckInCode) *CheckInCodeEnvelope {
+	return &CheckInCodeEnvelope{Body: CheckInCodeResponse{
+		Session:   NewCheckInSessionResponse(code.Session),
+		Code:      code.Code,
+		ExpiresAt: code.ExpiresAt,
+	}}
+}
+
+type CheckInRequest struct {
+	SessionID string `path:"sessionId" format:"uuid"`
+	Body      struct {
+		Code      string   `json:"code" maxLength:"16" doc:"Code scanned from the kiosk"`
+		Latitude  *float64 `json:"latitude,omitempty" minimum:"-90" maximum:"90" doc:"Location of the device, required when the session has an area"`
+		Longitude *float64 `json:"longitude,omitempty" minimum:"-180" maximum:"180"`
+	}
+}
+
+type AttendanceRecordResponse struct {
+	SessionID  string    `json:"session_id"`
+	ClassID    string    `json:"class_id"`
+	StudentID  string    `json:"student_id"`
+	Present    bool      `json:"present"`
+	Method     string    `json:"method"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+type AttendanceRecordEnvelope struct {
+	Body AttendanceRecordResponse
+}
+
+func NewAttendanceRecordEnvelope(record *entities.AttendanceRecord) *AttendanceRecordEnvelope {
+	return &AttendanceRecordEnvelope{Body: AttendanceRecordResponse{
+		SessionID:  record.SessionID,
+		ClassID:    record.ClassID,
+		StudentID:  record.StudentID,
+		Present:    record.Present,
+		Method:     string(record.Method),
+		RecordedAt: record.RecordedAt,
+	}}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	check_in_use_case "github.com/nahualventure/class-backend/core/app/attendance/application/use-cases/check-in-use-case"
+	get_check_in_code_use_case "github.com/nahualventure/class-backend/core/app/attendance/application/use-cases/get-check-in-code-use-case"
+	open_check_in_use_case "github.com/nahualventure/class-backend/core/app/attendance/application/use-cases/open-check-in-use-case"
+	"github.com/nahualventure/class-backend/core/app/attendance/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type AttendanceHandlers struct {
+	openCheckInUseCase    *open_check_in_use_case.OpenCheckInUseCase
+	getCheckInCodeUseCase *get_check_in_code_use_case.GetCheckInCodeUseCase
+	checkInUseCase        *check_in_use_case.CheckInUseCase
+}
+
+func NewAttendanceHandlers(
+	openCheckInUseCase *open_check_in_use_case.OpenCheckInUseCase,
+	getCheckInCodeUseCase *get_check_in_code_use_case.GetCheckInCodeUseCase,
+	checkInUseCase *check_in_use_case.CheckInUseCase,
+) *AttendanceHandlers {
+	return &AttendanceHandlers{
+		openCheckInUseCase:    openCheckInUseCase,
+		getCheckInCodeUseCase: getCheckInCodeUseCase,
+		checkInUseCase:        checkInUseCase,
+	}
+}
+
+func (h *AttendanceHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "open-check-in",
+		Method:      http.MethodPost,
+		Path:        "/classes/{classId}/check-in-sessions",
+		Summary:     "Open check-in to the current session of a class",
+		Description: "Check-in opens 15 minutes before the session starts and closes when it ends. Opening it again returns the same check-in session.",
+		Tags:        []string{"Attendance"},
+	}, h.OpenCheckIn)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-check-in-code",
+		Method:      http.MethodGet,
+		Path:        "/check-in-sessions/{sessionId}/code",
+		Summary:     "Get the code the kiosk shows as a QR",
+		Description: "Codes rotate every 30 seconds, the previous code is still accepted for one more period.",
+		Tags:        []string{"Attendance"},
+	}, h.GetCheckInCode)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "check-in",
+		Method:      http.MethodPost,
+		Path:        "/check-in-sessions/{sessionId}/check-ins",
+		Summary:     "Check the current student in with the code scanned from the kiosk",
+		Description: "The student is recorded as present. Checking in again returns the first record.",
+		Tags:        []string{"Attendance"},
+	}, h.CheckIn)
+}
+
+func (h *AttendanceHandlers) OpenCheckIn(ctx context.Context, input *OpenCheckInRequest) (*CheckInSessionEnvelope, error) {
+	var fence *entities.GeoFence
+	if input.Body.Latitude != nil && input.Body.Longitude != nil {
+		fence = &entities.GeoFence{
+			Latitude:     *input.Body.Latitude,
+			Longitude:    *input.Body.Longitude,
+			RadiusMeters: input.Body.RadiusMeters,
+		}
+	}
+
+	command, err := open_check_in_use_case.NewOpenCheckInCommand(authorization.TenantIDFromContext(ctx), input.ClassID,
+		authorization.UserIDFromContext(ctx), utils.TenantLocationFromContext(ctx), fence)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	session, err := h.openCheckInUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &CheckInSessionEnvelope{Body: NewCheckInSessionResponse(session)}, nil
+}
+
+func (h *AttendanceHandlers) GetCheckInCode(ctx context.Context, input *GetCheckInCodeRequest) (*CheckInCodeEnvelope, error) {
+	command, err := get_check_in_code_use_case.NewGetCheckInCodeCommand(authorization.TenantIDFromContext(ctx), input.SessionID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	code, err := h.getCheckInCodeUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewCheckInCodeEnvelope(code), nil
+}
+
+func (h *AttendanceHandlers) CheckIn(ctx context.Context, input *CheckInRequest) (*AttendanceRecordEnvelope, error) {
+	command, err := check_in_use_case.NewCheckInCommand(authorization.TenantIDFromContext(ctx), input.SessionID,
+		authorization.UserIDFromContext(ctx), input.Body.Code, input.Body.Latitude, input.Body.Longitude)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	record, err := h.checkInUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewAttendanceRecordEnvelope(record), nil
+}
@@ -0,0 +1,22 @@
+-- name: CreateCheckInSession :one
+INSERT INTO check_in_sessions (id, tenant_id, class_id, starts_at, ends_at, secret, fence_latitude,
+                               fence_longitude, fence_radius_meters, opened_by, opened_at)
+VALUES (@id, @tenant_id, @class_id, @starts_at, @ends_at, @secret, sqlc.narg('fence_latitude'),
+        sqlc.narg('fence_longitude'), sqlc.narg('fence_radius_meters'), @opened_by, @opened_at)
+ON CONFLICT (tenant_id, class_id, starts_at) DO UPDATE SET tenant_id = check_in_sessions.tenant_id
+RETURNING *;
+
+-- name: GetCheckInSession :one
+SELECT *
+FROM check_in_sessions
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: InsertAttendanceRecord :execrows
+INSERT INTO attendance_records (session_id, student_id, tenant_id, class_id, present, method, recorded_at)
+VALUES (@session_id, @student_id, @tenant_id, @class_id, @present, @method, @recorded_at)
+ON CONFLICT (session_id, student_id) DO NOTHING;
+
+-- name: GetAttendanceRecord :one
+SELECT *
+FROM attendance_records
+WHERE session_id = @session_id AND student_id = @student_id;
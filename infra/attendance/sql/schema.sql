@@ -0,0 +1,31 @@
+-- Check-in opened to a session of a class, at most one per session. The codes the kiosk shows are
+-- derived from the secret and change every 30 seconds. The fence is unset when students check in
+-- from anywhere.
+CREATE TABLE check_in_sessions (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    secret BYTEA NOT NULL,
+    fence_latitude DOUBLE PRECISION,
+    fence_longitude DOUBLE PRECISION,
+    fence_radius_meters INTEGER,
+    opened_by VARCHAR(255) NOT NULL,
+    opened_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (tenant_id, class_id, starts_at)
+);
+
+-- Attendance of the students to the sessions of their classes, one row per session and student
+CREATE TABLE attendance_records (
+    session_id UUID NOT NULL REFERENCES check_in_sessions(id) ON DELETE CASCADE,
+    student_id VARCHAR(255) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    present BOOLEAN NOT NULL,
+    method VARCHAR(20) NOT NULL,           -- qr
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (session_id, student_id)
+);
+
+CREATE INDEX idx_attendance_records_class ON attendance_records(tenant_id, class_id, recorded_at);
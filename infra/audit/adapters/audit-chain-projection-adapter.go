@@ -0,0 +1,96 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/audit/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const AuditChainProjectionName = "audit_chain"
+
+// PostgresAuditChainProjection chains every outbox event into the audit log of its tenant. The
+// projection runner applies events one at a time in position order, which is what keeps the chain
+// of a tenant linear.
+type PostgresAuditChainProjection struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresAuditChainProjection(dbInstance *pgxpool.Pool) *PostgresAuditChainProjection {
+	return &PostgresAuditChainProjection{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (p *PostgresAuditChainProjection) Name() string {
+	return AuditChainProjectionName
+}
+
+// Apply skips events that are already chained, replays after a crash must not chain them twice
+func (p *PostgresAuditChainProjection) Apply(ctx context.Context, event events.Event) error {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := p.queries.WithTx(tx)
+
+	chained, err := qtx.AuditEntryExistsForPosition(ctx, event.Position)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if chained {
+		return nil
+	}
+
+	var prev *entities.AuditEntry
+	last, err := qtx.GetLastAuditEntry(ctx, event.TenantID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return appErrors.PropagateError(err)
+	}
+	if err == nil {
+		prev = toAuditEntry(last)
+	}
+
+	entry, err := entities.NewAuditEntry(prev, event.TenantID, event.Position, event.Type, event.AggregateID, event.Payload, event.OccurredAt)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize audit entry", err)
+	}
+
+	// Two chains writing the same sequence fail on the primary key, the losing one retries later
+	err = qtx.InsertAuditEntry(ctx, db.InsertAuditEntryParams{
+		TenantID:    entry.TenantID,
+		Sequence:    entry.Sequence,
+		Position:    entry.Position,
+		EventType:   entry.EventType,
+		AggregateID: entry.AggregateID,
+		Payload:     entry.Payload,
+		OccurredAt:  pgtype.Timestamptz{Time: entry.OccurredAt, Valid: true},
+		PrevHash:    entry.PrevHash,
+		Hash:        entry.Hash,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+// Reset refuses to run, the audit log is append-only and rebuilding it would hide tampering
+func (p *PostgresAuditChainProjection) Reset(ctx context.Context) error {
+	return appErrors.NewValidationError("the audit chain is append-only and cannot be rebuilt",
+		map[string]any{"projection": AuditChainProjectionName}, nil)
+}
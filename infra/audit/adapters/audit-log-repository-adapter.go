@@ -0,0 +1,54 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/audit/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/audit/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresAuditLogRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresAuditLogRepository(dbInstance *pgxpool.Pool) ports.AuditLogRepository {
+	return &PostgresAuditLogRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresAuditLogRepository) ListAfter(ctx context.Context, tenantID string, afterSequence int64, limit int) ([]*entities.AuditEntry, error) {
+	rows, err := r.queries.ListAuditEntriesAfter(ctx, db.ListAuditEntriesAfterParams{
+		TenantID:      tenantID,
+		AfterSequence: afterSequence,
+		BatchSize:     int32(limit),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	entries := make([]*entities.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, toAuditEntry(row))
+	}
+	return entries, nil
+}
+
+func toAuditEntry(row db.AuditEntry) *entities.AuditEntry {
+	return &entities.AuditEntry{
+		TenantID:    row.TenantID,
+		Sequence:    row.Sequence,
+		Position:    row.Position,
+		EventType:   row.EventType,
+		AggregateID: row.AggregateID,
+		Payload:     row.Payload,
+		OccurredAt:  row.OccurredAt.Time,
+		PrevHash:    row.PrevHash,
+		Hash:        row.Hash,
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"github.com/nahualventure/class-backend/core/app/audit/domain/entities"
+)
+
+type ExportAuditLogRequest struct {
+	AfterSequence int64 `query:"after_sequence" minimum:"0" doc:"Only entries after this sequence, 0 exports the whole chain"`
+}
+
+type AuditVerificationResponse struct {
+	Body struct {
+		TenantID     string `json:"tenant_id"`
+		Verified     bool   `json:"verified" doc:"Every entry follows from the ones before it"`
+		Entries      int64  `json:"entries" doc:"Entries checked before the first break"`
+		LastSequence int64  `json:"last_sequence"`
+		HeadHash     string `json:"head_hash" doc:"Hash of the last entry checked, a verified export of the whole chain ends with it"`
+		BrokenAt     int64  `json:"broken_at,omitempty" doc:"Sequence of the first entry that does not follow"`
+		Reason       string `json:"reason,omitempty"`
+	}
+}
+
+func NewAuditVerificationResponse(verifier *entities.ChainVerifier) *AuditVerificationResponse {
+	response := &AuditVerificationResponse{}
+	response.Body.TenantID = verifier.TenantID
+	response.Body.Verified = verifier.Break == nil
+	response.Body.Entries = verifier.Entries
+	response.Body.LastSequence = verifier.LastSequence
+	response.Body.HeadHash = verifier.HeadHash
+	if verifier.Break != nil {
+		response.Body.BrokenAt = verifier.Break.Sequence
+		response.Body.Reason = verifier.Break.Reason
+	}
+	return response
+}
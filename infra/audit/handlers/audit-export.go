@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/audit/domain/entities"
+)
+
+// auditExportFormat is written in the first line of every export, verification refuses other formats
+const auditExportFormat = "audit-export/v1"
+
+// exportHeader is the first line of an export, every other line is an exportEntry
+type exportHeader struct {
+	Format   string `json:"format"`
+	TenantID string `json:"tenant_id"`
+}
+
+// exportEntry carries every hashed field as stored so the export can be checked without the database
+type exportEntry struct {
+	Sequence    int64     `json:"sequence"`
+	Position    int64     `json:"position"`
+	EventType   string    `json:"event_type"`
+	AggregateID string    `json:"aggregate_id"`
+	Payload     string    `json:"payload"`
+	OccurredAt  time.Time `json:"occurred_at"`
+	PrevHash    string    `json:"prev_hash"`
+	Hash        string    `json:"hash"`
+}
+
+// AuditExportWriter writes the entries of one tenant as JSON lines
+type AuditExportWriter struct {
+	encoder *json.Encoder
+}
+
+func NewAuditExportWriter(w io.Writer, tenantID string) (*AuditExportWriter, error) {
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(exportHeader{Format: auditExportFormat, TenantID: tenantID}); err != nil {
+		return nil, err
+	}
+	return &AuditExportWriter{encoder: encoder}, nil
+}
+
+func (w *AuditExportWriter) Write(entries []*entities.AuditEntry) error {
+	for _, entry := range entries {
+		err := w.encoder.Encode(exportEntry{
+			Sequence:    entry.Sequence,
+			Position:    entry.Position,
+			EventType:   entry.EventType,
+			AggregateID: entry.AggregateID,
+			Payload:     entry.Payload,
+			OccurredAt:  entry.OccurredAt,
+			PrevHash:    entry.PrevHash,
+			Hash:        entry.Hash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyAuditExport checks an export without the database. An export of the whole chain starts from
+// the genesis hash, one that starts later trusts the previous hash of its first entry, compare it with
+// the entry of that sequence from a verified export.
+func VerifyAuditExport(r io.Reader) (*entities.ChainVerifier, error) {
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	var header exportHeader
+	if err := decoder.Decode(&header); err != nil || header.Format != auditExportFormat {
+		return nil, fmt.Errorf("export is not in the %s format", auditExportFormat)
+	}
+
+	var verifier *entities.ChainVerifier
+	for decoder.More() {
+		var line exportEntry
+		if err := decoder.Decode(&line); err != nil {
+			return nil, fmt.Errorf("read export: %w", err)
+		}
+		entry := &entities.AuditEntry{
+			TenantID:    header.TenantID,
+			Sequence:    line.Sequence,
+			Position:    line.Position,
+			EventType:   line.EventType,
+			AggregateID: line.AggregateID,
+			Payload:     line.Payload,
+			OccurredAt:  line.OccurredAt,
+			PrevHash:    line.PrevHash,
+			Hash:        line.Hash,
+		}
+
+		if verifier == nil {
+			anchor := entities.GenesisHash
+			if entry.Sequence > 1 {
+				anchor = entry.PrevHash
+			}
+			verifier = entities.NewChainVerifier(header.TenantID, entry.Sequence-1, anchor)
+		}
+		if !verifier.Add(entry) {
+			return verifier, nil
+		}
+	}
+
+	if verifier == nil {
+		verifier = entities.NewChainVerifier(header.TenantID, 0, entities.GenesisHash)
+	}
+	return verifier, nil
+}
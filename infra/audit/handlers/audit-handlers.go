@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	list_audit_entries_use_case "github.com/nahualventure/class-backend/core/app/audit/application/use-cases/list-audit-entries-use-case"
+	verify_audit_log_use_case "github.com/nahualventure/class-backend/core/app/audit/application/use-cases/verify-audit-log-use-case"
+	"github.com/nahualventure/class-backend/core/app/audit/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// exportPageSize is how many entries are read per query while streaming an export
+const exportPageSize = 1000
+
+type AuditHandlers struct {
+	listAuditEntriesUseCase *list_audit_entries_use_case.ListAuditEntriesUseCase
+	verifyAuditLogUseCase   *verify_audit_log_use_case.VerifyAuditLogUseCase
+}
+
+func NewAuditHandlers(
+	listAuditEntriesUseCase *list_audit_entries_use_case.ListAuditEntriesUseCase,
+	verifyAuditLogUseCase *verify_audit_log_use_case.VerifyAuditLogUseCase,
+) *AuditHandlers {
+	return &AuditHandlers{
+		listAuditEntriesUseCase: listAuditEntriesUseCase,
+		verifyAuditLogUseCase:   verifyAuditLogUseCase,
+	}
+}
+
+func (h *AuditHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "export-audit-log",
+		Method:      http.MethodGet,
+		Path:        "/audit/export",
+		Summary:     "Export the audit chain of the tenant",
+		Description: "JSON lines, a header and then every entry with its hash and the hash of the previous entry. `make verify-audit-export` checks an export without access to the server.",
+		Tags:        []string{"Audit"},
+	}, h.ExportAuditLog)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "verify-audit-log",
+		Method:      http.MethodGet,
+		Path:        "/audit/verification",
+		Summary:     "Verify the audit chain of the tenant",
+		Description: "Recomputes every hash from the first entry and reports the first entry that does not follow from the ones before it.",
+		Tags:        []string{"Audit"},
+	}, h.VerifyAuditLog)
+}
+
+func (h *AuditHandlers) ExportAuditLog(ctx context.Context, input *ExportAuditLogRequest) (*huma.StreamResponse, error) {
+	tenantID := authorization.TenantIDFromContext(ctx)
+	fetchPage := func(afterSequence int64) ([]*entities.AuditEntry, error) {
+		command, err := list_audit_entries_use_case.NewListAuditEntriesCommand(tenantID, afterSequence, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		return h.listAuditEntriesUseCase.Execute(ctx, command)
+	}
+
+	// The first page is read before the response starts so its errors keep the standard envelope
+	page, err := fetchPage(input.AfterSequence)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &huma.StreamResponse{
+		Body: func(humaCtx huma.Context) {
+			humaCtx.SetHeader("Content-Type", "application/x-ndjson")
+			humaCtx.SetHeader("Content-Disposition", export.ContentDisposition("audit-"+tenantID+".jsonl"))
+			humaCtx.SetHeader("Cache-Control", "no-store")
+			humaCtx.SetStatus(http.StatusOK)
+
+			if err := writeExport(humaCtx, tenantID, page, fetchPage); err != nil {
+				// Headers are already sent, a truncated export fails verification of its last line
+				log.Printf("audit export of %s aborted: %v", tenantID, err)
+			}
+		},
+	}, nil
+}
+
+func writeExport(ctx huma.Context, tenantID string, page []*entities.AuditEntry, fetchPage func(int64) ([]*entities.AuditEntry, error)) error {
+	body := ctx.BodyWriter()
+	flusher, _ := body.(http.Flusher)
+	writer, err := NewAuditExportWriter(body, tenantID)
+	if err != nil {
+		return err
+	}
+
+	for {
+		if err := writer.Write(page); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if len(page) < exportPageSize {
+			return nil
+		}
+		if err := ctx.Context().Err(); err != nil {
+			return err
+		}
+
+		if page, err = fetchPage(page[len(page)-1].Sequence); err != nil {
+			return err
+		}
+	}
+}
+
+func (h *AuditHandlers) VerifyAuditLog(ctx context.Context, input *struct{}) (*AuditVerificationResponse, error) {
+	command, err := verify_audit_log_use_case.NewVerifyAuditLogCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	verifier, err := h.verifyAuditLogUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewAuditVerificationResponse(verifier), nil
+}
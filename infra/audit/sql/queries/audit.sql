@@ -0,0 +1,20 @@
+-- name: GetLastAuditEntry :one
+SELECT *
+FROM audit_entries
+WHERE tenant_id = @tenant_id
+ORDER BY sequence DESC
+LIMIT 1;
+
+-- name: AuditEntryExistsForPosition :one
+SELECT EXISTS (SELECT 1 FROM audit_entries WHERE position = @position);
+
+-- name: InsertAuditEntry :exec
+INSERT INTO audit_entries (tenant_id, sequence, position, event_type, aggregate_id, payload, occurred_at, prev_hash, hash)
+VALUES (@tenant_id, @sequence, @position, @event_type, @aggregate_id, @payload, @occurred_at, @prev_hash, @hash);
+
+-- name: ListAuditEntriesAfter :many
+SELECT *
+FROM audit_entries
+WHERE tenant_id = @tenant_id AND sequence > @after_sequence
+ORDER BY sequence
+LIMIT @batch_size;
@@ -0,0 +1,16 @@
+-- Hash chained copy of the outbox per tenant. Every entry includes the hash of the previous entry of
+-- its tenant, so changing, removing or reordering one breaks the chain from there on. Rows are never
+-- updated or deleted and stay when the outbox is archived.
+CREATE TABLE audit_entries (
+    tenant_id VARCHAR(255) NOT NULL,
+    sequence BIGINT NOT NULL,
+    position BIGINT NOT NULL,              -- outbox position of the event
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    payload TEXT NOT NULL,                 -- kept as text, the exact bytes that were hashed
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    prev_hash CHAR(64) NOT NULL,
+    hash CHAR(64) NOT NULL,
+    PRIMARY KEY (tenant_id, sequence),
+    UNIQUE (position)
+);
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	userHandlers "github.com/nahualventure/class-backend/infra/user/handlers"
+)
+
+type SignupRequest struct {
+	Body struct {
+		Name     string `json:"name" minLength:"1"`
+		Email    string `json:"email" format:"email"`
+		Password string `json:"password" minLength:"8" maxLength:"128"`
+	}
+}
+
+type SignupResponse struct {
+	Body userHandlers.UserResponse
+}
+
+type BatchSignupItemRequest struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type BatchSignupRequest struct {
+	Body struct {
+		Users     []BatchSignupItemRequest `json:"users" minItems:"1" maxItems:"500"`
+		ChunkSize int                      `json:"chunk_size,omitempty" minimum:"0" maximum:"500" doc:"Users persisted per transaction, defaults to 50"`
+	}
+}
+
+// BatchSignupItemResponse has the status created when the user was created
+type BatchSignupItemResponse struct {
+	utils.BulkItemResponse
+	Email string                     `json:"email"`
+	User  *userHandlers.UserResponse `json:"user,omitempty"`
+}
+
+type BatchSignupResponse struct {
+	Body struct {
+		utils.BulkSummary
+		Results []BatchSignupItemResponse `json:"results"`
+		Created int                       `json:"created" deprecated:"true" doc:"Same as succeeded"`
+	}
+}
+
+type VerifyPasswordRequest struct {
+	Body struct {
+		Email    string `json:"email" format:"email"`
+		Password string `json:"password" minLength:"1" maxLength:"128"`
+	}
+}
+
+type VerifyPasswordResponse struct {
+	Body userHandlers.UserResponse
+}
+
+type ImportUserItemRequest struct {
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	PasswordHash string `json:"password_hash" doc:"Hash stored by the former system, e.g. pbkdf2_sha256$...$...$... or $P$..."`
+	ExternalID   string `json:"external_id,omitempty" maxLength:"255" doc:"ID of the user in the former system"`
+}
+
+type ImportUsersRequest struct {
+	Body struct {
+		Users []ImportUserItemRequest `json:"users" minItems:"1" maxItems:"500"`
+	}
+}
+
+// ImportUserItemResponse has the status imported when the user was imported
+type ImportUserItemResponse struct {
+	utils.BulkItemResponse
+	Email string                     `json:"email"`
+	User  *userHandlers.UserResponse `json:"user,omitempty"`
+}
+
+type ImportUsersResponse struct {
+	Body struct {
+		utils.BulkSummary
+		Results  []ImportUserItemResponse `json:"results"`
+		Imported int                      `json:"imported" deprecated:"true" doc:"Same as succeeded"`
+	}
+}
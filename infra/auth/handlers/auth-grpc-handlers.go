@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+
+	batch_signup_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/batch-signup-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	userHandlers "github.com/nahualventure/class-backend/infra/user/handlers"
+	authv1 "github.com/nahualventure/class-backend/proto/gen/auth/v1"
+
+	"google.golang.org/grpc"
+)
+
+// AuthGRPCHandlers serves the auth operations over gRPC with the use cases and sign up listeners of
+// the HTTP handlers, errors are converted by the server
+type AuthGRPCHandlers struct {
+	authv1.UnimplementedAuthServiceServer
+	auth     *AuthHandlers
+	redactor *authorization.Redactor
+}
+
+func NewAuthGRPCHandlers(auth *AuthHandlers, redactor *authorization.Redactor) *AuthGRPCHandlers {
+	return &AuthGRPCHandlers{
+		auth:     auth,
+		redactor: redactor,
+	}
+}
+
+func (h *AuthGRPCHandlers) Register(server *grpc.Server) {
+	authv1.RegisterAuthServiceServer(server, h)
+}
+
+func (h *AuthGRPCHandlers) Operations() map[string]authorization.GRPCOperation {
+	return map[string]authorization.GRPCOperation{
+		authv1.AuthService_BatchSignup_FullMethodName: {OperationID: "batch-signup"},
+	}
+}
+
+func (h *AuthGRPCHandlers) BatchSignup(ctx context.Context, request *authv1.BatchSignupRequest) (*authv1.BatchSignupResponse, error) {
+	items := make([]batch_signup_use_case.BatchSignupItem, len(request.GetUsers()))
+	for i, user := range request.GetUsers() {
+		items[i] = batch_signup_use_case.BatchSignupItem{
+			Name:     user.GetName(),
+			Email:    user.GetEmail(),
+			Password: user.GetPassword(),
+		}
+	}
+
+	command, err := batch_signup_use_case.NewBatchSignupCommand(items, int(request.GetChunkSize()))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.auth.batchSignupUseCase.Execute(command)
+	if err != nil {
+		return nil, err
+	}
+
+	callerID := authorization.UserIDFromContext(ctx)
+	locale := requestmeta.FromContext(ctx).Locale
+	showEmail := h.redactor.Visible(ctx, userHandlers.ViewContactVisibility)
+	summary := utils.BulkSummaryToProto(result)
+	response := &authv1.BatchSignupResponse{
+		Results: make([]*authv1.BatchSignupResult, len(result.Items)),
+		Created: summary.Succeeded,
+		Failed:  summary.Failed,
+		Summary: summary,
+	}
+	for i, item := range result.Items {
+		outcome := utils.BulkItemOutcomeToProto(item, locale)
+		itemResult := &authv1.BatchSignupResult{
+			Index:   outcome.Index,
+			Email:   request.GetUsers()[item.Index].GetEmail(),
+			Status:  outcome.Status,
+			Error:   outcome.Error,
+			Outcome: outcome,
+		}
+
+		if item.Succeeded() {
+			h.auth.notifySignUp(ctx, item.Value)
+			itemResult.User = userHandlers.NewUserProto(item.Value, callerID, showEmail)
+		}
+
+		response.Results[i] = itemResult
+	}
+
+	return response, nil
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	batch_signup_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/batch-signup-use-case"
+	import_users_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/import-users-use-case"
+	signup_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/signup-use-case"
+	verify_password_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/verify-password-use-case"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	userEntities "github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	userHandlers "github.com/nahualventure/class-backend/infra/user/handlers"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type AuthHandlers struct {
+	signupUseCase      *signup_use_case.CreateUserUseCase
+	batchSignupUseCase *batch_signup_use_case.BatchSignupUseCase
+	verifyPassword     *verify_password_use_case.VerifyPasswordUseCase
+	importUsers        *import_users_use_case.ImportUsersUseCase
+	// signupLimiter is keyed by client IP, signup is public
+	signupLimiter *ratelimit.TenantRateLimiter
+	// signUpListeners are called with every user created through sign up
+	signUpListeners []func(ctx context.Context, user *userEntities.User)
+}
+
+func NewAuthHandlers(signupUseCase *signup_use_case.CreateUserUseCase, batchSignupUseCase *batch_signup_use_case.BatchSignupUseCase,
+	verifyPassword *verify_password_use_case.VerifyPasswordUseCase, importUsers *import_users_use_case.ImportUsersUseCase,
+	signupLimiter *ratelimit.TenantRateLimiter) *AuthHandlers {
+	return &AuthHandlers{
+		signupUseCase:      signupUseCase,
+		batchSignupUseCase: batchSignupUseCase,
+		verifyPassword:     verifyPassword,
+		importUsers:        importUsers,
+		signupLimiter:      signupLimiter,
+	}
+}
+
+// OnSignUp registers a listener called with every user created through sign up or batch sign up
+func (h *AuthHandlers) OnSignUp(listener func(ctx context.Context, user *userEntities.User)) {
+	h.signUpListeners = append(h.signUpListeners, listener)
+}
+
+func (h *AuthHandlers) notifySignUp(ctx context.Context, user *userEntities.User) {
+	for _, listener := range h.signUpListeners {
+		listener(ctx, user)
+	}
+}
+
+func (h *AuthHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "signup",
+		Method:        http.MethodPost,
+		Path:          "/auth/signup",
+		Summary:       "Sign up a new user",
+		Tags:          []string{"Auth"},
+		DefaultStatus: http.StatusCreated,
+	}, h.Signup)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "batch-signup",
+		Method:      http.MethodPost,
+		Path:        "/auth/signup/batch",
+		Summary:     "Sign up multiple users",
+		Description: "Creates users in transactional chunks and reports a result per item. Used by roster imports and admin tools.",
+		Tags:        []string{"Auth"},
+	}, h.BatchSignup)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "verify-password",
+		Method:      http.MethodPost,
+		Path:        "/auth/password/verify",
+		Summary:     "Verify the password of a user",
+		Description: "Checks an email and password for the gateway, which signs users in and throttles the attempts. Passwords of imported users are checked against the hash of their former system and stored again with the current scheme once they match.",
+		Tags:        []string{"Auth"},
+	}, h.VerifyPassword)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "import-users",
+		Method:      http.MethodPost,
+		Path:        "/auth/import",
+		Summary:     "Import users with their password hashes",
+		Description: "Creates users of another system keeping the password hashes it stored, so they sign in with their current password. Supports bcrypt, Django PBKDF2 (pbkdf2_sha256, pbkdf2_sha1) and phpass ($P$, $H$) hashes, and reports a result per item.",
+		Tags:        []string{"Auth"},
+	}, h.ImportUsers)
+}
+
+func (h *AuthHandlers) Signup(ctx context.Context, input *SignupRequest) (*SignupResponse, error) {
+	if allowed, retryAfter := h.signupLimiter.Allow(utils.ClientIPFromContext(ctx)); !allowed {
+		return nil, huma.ErrorWithHeaders(
+			utils.ApplicationErrorToHumaError(appErrors.NewRateLimitedError("signup", retryAfter)),
+			http.Header{"Retry-After": []string{strconv.Itoa(int(retryAfter.Seconds()) + 1)}},
+		)
+	}
+
+	command, err := signup_use_case.NewCreateUserCommand(input.Body.Name, input.Body.Email, input.Body.Password)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	user, err := h.signupUseCase.Execute(command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+	h.notifySignUp(ctx, user)
+
+	return &SignupResponse{Body: userHandlers.NewUserResponse(user)}, nil
+}
+
+func (h *AuthHandlers) BatchSignup(ctx context.Context, input *BatchSignupRequest) (*BatchSignupResponse, error) {
+	items := make([]batch_signup_use_case.BatchSignupItem, len(input.Body.Users))
+	for i, user := range input.Body.Users {
+		items[i] = batch_signup_use_case.BatchSignupItem{
+			Name:     user.Name,
+			Email:    user.Email,
+			Password: user.Password,
+		}
+	}
+
+	command, err := batch_signup_use_case.NewBatchSignupCommand(items, input.Body.ChunkSize)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	result, err := h.batchSignupUseCase.Execute(command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	locale := requestmeta.Locale(ctx)
+	response := &BatchSignupResponse{}
+	response.Body.BulkSummary = utils.NewBulkSummary(result)
+	response.Body.Created = response.Body.Succeeded
+	response.Body.Results = make([]BatchSignupItemResponse, len(result.Items))
+
+	for i, item := range result.Items {
+		itemResponse := BatchSignupItemResponse{
+			BulkItemResponse: utils.NewBulkItemResponse(item, locale),
+			Email:            input.Body.Users[item.Index].Email,
+		}
+
+		if item.Succeeded() {
+			h.notifySignUp(ctx, item.Value)
+			user := userHandlers.NewUserResponse(item.Value)
+			itemResponse.User = &user
+		}
+
+		response.Body.Results[i] = itemResponse
+	}
+
+	return response, nil
+}
+
+func (h *AuthHandlers) VerifyPassword(ctx context.Context, input *VerifyPasswordRequest) (*VerifyPasswordResponse, error) {
+	command, err := verify_password_use_case.NewVerifyPasswordCommand(input.Body.Email, input.Body.Password)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	user, err := h.verifyPassword.Execute(command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &VerifyPasswordResponse{Body: userHandlers.NewUserResponse(user)}, nil
+}
+
+func (h *AuthHandlers) ImportUsers(ctx context.Context, input *ImportUsersRequest) (*ImportUsersResponse, error) {
+	items := make([]import_users_use_case.ImportUserItem, len(input.Body.Users))
+	for i, user := range input.Body.Users {
+		items[i] = import_users_use_case.ImportUserItem{
+			Name:         user.Name,
+			Email:        user.Email,
+			PasswordHash: user.PasswordHash,
+			ExternalID:   user.ExternalID,
+		}
+	}
+
+	command, err := import_users_use_case.NewImportUsersCommand(items)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	result, err := h.importUsers.Execute(command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	locale := requestmeta.Locale(ctx)
+	response := &ImportUsersResponse{}
+	response.Body.BulkSummary = utils.NewBulkSummary(result)
+	response.Body.Imported = response.Body.Succeeded
+	response.Body.Results = make([]ImportUserItemResponse, len(result.Items))
+
+	for i, item := range result.Items {
+		itemResponse := ImportUserItemResponse{
+			BulkItemResponse: utils.NewBulkItemResponse(item, locale),
+			Email:            input.Body.Users[item.Index].Email,
+		}
+
+		if item.Succeeded() {
+			user := userHandlers.NewUserResponse(item.Value)
+			itemResponse.User = &user
+		}
+
+		response.Body.Results[i] = itemResponse
+	}
+
+	return response, nil
+}
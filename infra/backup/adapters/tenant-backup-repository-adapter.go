@@ -0,0 +1,163 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/backup/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/backup/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTenantBackupRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresTenantBackupRepository(dbInstance *pgxpool.Pool) ports.TenantBackupRepository {
+	return &PostgresTenantBackupRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresTenantBackupRepository) Create(ctx context.Context, backup *entities.TenantBackup) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(backup.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.CreateTenantBackup(ctx, db.CreateTenantBackupParams{
+		ID:          pgUUID,
+		TenantID:    backup.TenantID,
+		Status:      string(backup.Status),
+		RequestedBy: backup.RequestedBy,
+		CreatedAt:   pgtype.Timestamptz{Time: backup.CreatedAt, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: backup.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresTenantBackupRepository) FindByID(ctx context.Context, tenantID string, backupID string) (*entities.TenantBackup, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(backupID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetTenantBackup(ctx, db.GetTenantBackupParams{TenantID: tenantID, ID: pgUUID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toTenantBackup(row)
+}
+
+func (r *PostgresTenantBackupRepository) List(ctx context.Context, tenantID string, limit int) ([]*entities.TenantBackup, error) {
+	rows, err := r.queries.ListTenantBackups(ctx, db.ListTenantBackupsParams{TenantID: tenantID, RowLimit: int32(limit)})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	backups := make([]*entities.TenantBackup, 0, len(rows))
+	for _, row := range rows {
+		backup, err := toTenantBackup(row)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, backup)
+	}
+	return backups, nil
+}
+
+func (r *PostgresTenantBackupRepository) ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.TenantBackup, error) {
+	row, err := r.queries.ClaimNextPendingTenantBackup(ctx, pgtype.Timestamptz{Time: time.Now().Add(-staleAfter), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toTenantBackup(row)
+}
+
+func (r *PostgresTenantBackupRepository) MarkVerified(ctx context.Context, backup *entities.TenantBackup) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(backup.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tables, err := json.Marshal(backup.Tables)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize backup tables", err)
+	}
+
+	err = r.queries.MarkTenantBackupVerified(ctx, db.MarkTenantBackupVerifiedParams{
+		ID:         pgUUID,
+		StorageKey: &backup.StorageKey,
+		Tables:     tables,
+		SizeBytes:  backup.SizeBytes,
+		Checksum:   &backup.Checksum,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresTenantBackupRepository) MarkFailed(ctx context.Context, backupID string, reason string) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(backupID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := r.queries.MarkTenantBackupFailed(ctx, db.MarkTenantBackupFailedParams{ID: pgUUID, FailureReason: &reason}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func toTenantBackup(row db.TenantBackup) (*entities.TenantBackup, error) {
+	backup, err := entities.NewTenantBackup(row.ID.String(), row.TenantID, row.RequestedBy, row.CreatedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	backup.Status = entities.TenantBackupStatus(row.Status)
+	backup.UpdatedAt = row.UpdatedAt.Time
+	backup.SizeBytes = row.SizeBytes
+	if len(row.Tables) > 0 {
+		if err := json.Unmarshal(row.Tables, &backup.Tables); err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to deserialize backup tables", err)
+		}
+	}
+	if row.StorageKey != nil {
+		backup.StorageKey = *row.StorageKey
+	}
+	if row.Checksum != nil {
+		backup.Checksum = *row.Checksum
+	}
+	if row.FailureReason != nil {
+		backup.FailureReason = *row.FailureReason
+	}
+	if row.CompletedAt.Valid {
+		completedAt := row.CompletedAt.Time
+		backup.CompletedAt = &completedAt
+	}
+	return backup, nil
+}
@@ -0,0 +1,186 @@
+package adapters
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/nahualventure/class-backend/core/app/backup/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// dumpFormat is written in the first line of every dump, restores refuse other formats
+const dumpFormat = "tenant-dump/v1"
+
+// excludedTables hold no school data: the backups themselves and the tenant data keys, which must
+// never be copied so shredding a tenant also makes its backups unreadable
+var excludedTables = map[string]bool{
+	"tenant_backups": true,
+	"tenant_keys":    true,
+}
+
+// dumpLine is a line of a dump, gzipped JSON lines. The first line has the format and tenant, every
+// other line a row of a table with every column of the row.
+type dumpLine struct {
+	Format   string          `json:"format,omitempty"`
+	TenantID string          `json:"tenant_id,omitempty"`
+	Table    string          `json:"table,omitempty"`
+	Row      json.RawMessage `json:"row,omitempty"`
+}
+
+// PostgresTenantDataExporter dumps the rows of a tenant from every table of the public schema with a
+// tenant_id column. Partitioned tables are read through their parent. Tables without a tenant, such
+// as users, are not part of tenant backups.
+type PostgresTenantDataExporter struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresTenantDataExporter(dbInstance *pgxpool.Pool) ports.TenantDataExporter {
+	return &PostgresTenantDataExporter{db: dbInstance}
+}
+
+// Export reads every table in one repeatable read transaction, so rows that reference each other
+// are consistent even while the tenant keeps writing
+func (e *PostgresTenantDataExporter) Export(ctx context.Context, tenantID string, w io.Writer) (map[string]int, error) {
+	tx, err := e.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	tables, err := tenantTables(ctx, tx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	gz := gzip.NewWriter(w)
+	encoder := json.NewEncoder(gz)
+	if err := encoder.Encode(dumpLine{Format: dumpFormat, TenantID: tenantID}); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	counts := make(map[string]int, len(tables))
+	for _, table := range tables {
+		counts[table] = 0
+		rows, err := tx.Query(ctx, "SELECT to_jsonb(t) FROM "+pgx.Identifier{"public", table}.Sanitize()+" t WHERE t.tenant_id = $1", tenantID)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		for rows.Next() {
+			var row json.RawMessage
+			if err := rows.Scan(&row); err != nil {
+				rows.Close()
+				return nil, appErrors.PropagateError(err)
+			}
+			if err := encoder.Encode(dumpLine{Table: table, Row: row}); err != nil {
+				rows.Close()
+				return nil, appErrors.PropagateError(err)
+			}
+			counts[table]++
+		}
+		if err := rows.Err(); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return counts, nil
+}
+
+func tenantTables(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT c.relname
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_attribute a ON a.attrelid = c.oid
+		WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p') AND NOT c.relispartition
+		  AND a.attname = 'tenant_id' AND NOT a.attisdropped
+		ORDER BY c.relname`)
+	if err != nil {
+		return nil, err
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]string, 0, len(names))
+	for _, name := range names {
+		if !excludedTables[name] {
+			tables = append(tables, name)
+		}
+	}
+	return tables, nil
+}
+
+// PostgresTenantDataRestorer loads dumps into a schema of their own. Every table is created like its
+// live counterpart with its defaults and check constraints, so a dump that no longer fits the live
+// tables fails to restore. Columns dropped since the dump was taken are ignored.
+type PostgresTenantDataRestorer struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresTenantDataRestorer(dbInstance *pgxpool.Pool) ports.TenantDataRestorer {
+	return &PostgresTenantDataRestorer{db: dbInstance}
+}
+
+func (r *PostgresTenantDataRestorer) RestoreInto(ctx context.Context, schema string, dump io.Reader) (map[string]int, error) {
+	gz, err := gzip.NewReader(dump)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("read dump", err)
+	}
+	decoder := json.NewDecoder(gz)
+	var header dumpLine
+	if err := decoder.Decode(&header); err != nil || header.Format != dumpFormat {
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("dump is not in the %s format", dumpFormat), err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "CREATE SCHEMA "+pgx.Identifier{schema}.Sanitize()); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	counts := make(map[string]int)
+	for decoder.More() {
+		var line dumpLine
+		if err := decoder.Decode(&line); err != nil {
+			return nil, appErrors.NewInfrastructureError("read dump", err)
+		}
+
+		target := pgx.Identifier{schema, line.Table}.Sanitize()
+		if _, created := counts[line.Table]; !created {
+			live := pgx.Identifier{"public", line.Table}.Sanitize()
+			if _, err := tx.Exec(ctx, "CREATE TABLE "+target+" (LIKE "+live+" INCLUDING DEFAULTS INCLUDING CONSTRAINTS)"); err != nil {
+				return nil, appErrors.PropagateError(err)
+			}
+		}
+		if _, err := tx.Exec(ctx, "INSERT INTO "+target+" SELECT * FROM jsonb_populate_record(NULL::"+target+", $1)", []byte(line.Row)); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		counts[line.Table]++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return counts, nil
+}
+
+func (r *PostgresTenantDataRestorer) DropSchema(ctx context.Context, schema string) error {
+	if _, err := r.db.Exec(ctx, "DROP SCHEMA IF EXISTS "+pgx.Identifier{schema}.Sanitize()+" CASCADE"); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/backup/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type TenantBackupResponse struct {
+	ID            string         `json:"id"`
+	Status        string         `json:"status" enum:"pending,running,verified,failed" doc:"Verified backups were restored into a scratch schema with every row"`
+	RequestedBy   string         `json:"requested_by"`
+	Tables        map[string]int `json:"tables" doc:"Rows in the backup per table"`
+	RowCount      int            `json:"row_count"`
+	SizeBytes     int64          `json:"size_bytes"`
+	Checksum      string         `json:"checksum,omitempty" doc:"SHA-256 of the stored dump"`
+	FailureReason string         `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	CompletedAt   *time.Time     `json:"completed_at,omitempty"`
+}
+
+func NewTenantBackupResponse(backup *entities.TenantBackup, loc *time.Location) TenantBackupResponse {
+	return TenantBackupResponse{
+		ID:            backup.ID,
+		Status:        string(backup.Status),
+		RequestedBy:   backup.RequestedBy,
+		Tables:        backup.Tables,
+		RowCount:      backup.RowCount(),
+		SizeBytes:     backup.SizeBytes,
+		Checksum:      backup.Checksum,
+		FailureReason: backup.FailureReason,
+		CreatedAt:     utils.InLocation(backup.CreatedAt, loc),
+		CompletedAt:   utils.InLocationPtr(backup.CompletedAt, loc),
+	}
+}
+
+type ListTenantBackupsRequest struct {
+	Limit int `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Newest backups first"`
+}
+
+type TenantBackupsResponse struct {
+	Body struct {
+		Backups []TenantBackupResponse `json:"backups"`
+	}
+}
+
+type GetTenantBackupRequest struct {
+	BackupID string `path:"backupId" format:"uuid"`
+}
+
+type TenantBackupEnvelope struct {
+	Body TenantBackupResponse
+}
+
+type TenantBackupAcceptedEnvelope struct {
+	Location string `header:"Location" doc:"Operation to poll until the backup is verified"`
+	Body     TenantBackupResponse
+}
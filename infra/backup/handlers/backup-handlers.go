@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/get-tenant-backup-use-case"
+	list_tenant_backups_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/list-tenant-backups-use-case"
+	request_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/request-tenant-backup-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type BackupHandlers struct {
+	requestTenantBackupUseCase *request_tenant_backup_use_case.RequestTenantBackupUseCase
+	listTenantBackupsUseCase   *list_tenant_backups_use_case.ListTenantBackupsUseCase
+	getTenantBackupUseCase     *get_tenant_backup_use_case.GetTenantBackupUseCase
+}
+
+func NewBackupHandlers(
+	requestTenantBackupUseCase *request_tenant_backup_use_case.RequestTenantBackupUseCase,
+	listTenantBackupsUseCase *list_tenant_backups_use_case.ListTenantBackupsUseCase,
+	getTenantBackupUseCase *get_tenant_backup_use_case.GetTenantBackupUseCase,
+) *BackupHandlers {
+	return &BackupHandlers{
+		requestTenantBackupUseCase: requestTenantBackupUseCase,
+		listTenantBackupsUseCase:   listTenantBackupsUseCase,
+		getTenantBackupUseCase:     getTenantBackupUseCase,
+	}
+}
+
+func (h *BackupHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "request-tenant-backup",
+		Method:        http.MethodPost,
+		Path:          "/backups",
+		Summary:       "Back up the data of the tenant",
+		Description:   "Queues a logical backup of every row of the tenant. The backup is restored into a scratch schema before it is marked verified, poll the operation in the Location header until it is done.",
+		Tags:          []string{"Backups"},
+		DefaultStatus: http.StatusAccepted,
+	}, h.RequestTenantBackup)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-tenant-backups",
+		Method:      http.MethodGet,
+		Path:        "/backups",
+		Summary:     "List the backups of the tenant",
+		Tags:        []string{"Backups"},
+	}, h.ListTenantBackups)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-tenant-backup",
+		Method:      http.MethodGet,
+		Path:        "/backups/{backupId}",
+		Summary:     "Get a backup of the tenant",
+		Tags:        []string{"Backups"},
+	}, h.GetTenantBackup)
+}
+
+func (h *BackupHandlers) RequestTenantBackup(ctx context.Context, input *struct{}) (*TenantBackupAcceptedEnvelope, error) {
+	command, err := request_tenant_backup_use_case.NewRequestTenantBackupCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	backup, err := h.requestTenantBackupUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &TenantBackupAcceptedEnvelope{
+		Location: utils.OperationLocation(backup.ID),
+		Body:     NewTenantBackupResponse(backup, utils.LocationFromContext(ctx)),
+	}, nil
+}
+
+func (h *BackupHandlers) ListTenantBackups(ctx context.Context, input *ListTenantBackupsRequest) (*TenantBackupsResponse, error) {
+	command, err := list_tenant_backups_use_case.NewListTenantBackupsCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Limit,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	backups, err := h.listTenantBackupsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &TenantBackupsResponse{}
+	response.Body.Backups = make([]TenantBackupResponse, 0, len(backups))
+	for _, backup := range backups {
+		response.Body.Backups = append(response.Body.Backups, NewTenantBackupResponse(backup, loc))
+	}
+	return response, nil
+}
+
+func (h *BackupHandlers) GetTenantBackup(ctx context.Context, input *GetTenantBackupRequest) (*TenantBackupEnvelope, error) {
+	command, err := get_tenant_backup_use_case.NewGetTenantBackupCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.BackupID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	backup, err := h.getTenantBackupUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &TenantBackupEnvelope{Body: NewTenantBackupResponse(backup, utils.LocationFromContext(ctx))}, nil
+}
@@ -0,0 +1,45 @@
+-- name: CreateTenantBackup :exec
+INSERT INTO tenant_backups (id, tenant_id, status, requested_by, created_at, updated_at)
+VALUES (@id, @tenant_id, @status, @requested_by, @created_at, @updated_at);
+
+-- name: GetTenantBackup :one
+SELECT *
+FROM tenant_backups
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: ListTenantBackups :many
+SELECT *
+FROM tenant_backups
+WHERE tenant_id = @tenant_id
+ORDER BY created_at DESC
+LIMIT @row_limit;
+
+-- name: ClaimNextPendingTenantBackup :one
+UPDATE tenant_backups
+SET status = 'running', updated_at = NOW()
+WHERE id = (
+    SELECT b.id
+    FROM tenant_backups b
+    WHERE b.status = 'pending' OR (b.status = 'running' AND b.updated_at < @stale_before)
+    ORDER BY b.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkTenantBackupVerified :exec
+UPDATE tenant_backups
+SET status = 'verified',
+    storage_key = @storage_key,
+    tables = @tables,
+    size_bytes = @size_bytes,
+    checksum = @checksum,
+    failure_reason = NULL,
+    updated_at = NOW(),
+    completed_at = NOW()
+WHERE id = @id;
+
+-- name: MarkTenantBackupFailed :exec
+UPDATE tenant_backups
+SET status = 'failed', failure_reason = @failure_reason, updated_at = NOW(), completed_at = NOW()
+WHERE id = @id;
@@ -0,0 +1,18 @@
+-- Logical backups of the rows of one tenant, the dumps are kept in the backup storage under storage_key
+CREATE TABLE tenant_backups (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,           -- pending, running, verified, failed
+    requested_by VARCHAR(255) NOT NULL,
+    storage_key VARCHAR(500),
+    tables JSONB NOT NULL DEFAULT '{}',    -- rows in the dump per table
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    checksum VARCHAR(64),                  -- SHA-256 of the dump
+    failure_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_tenant_backups_tenant ON tenant_backups(tenant_id, created_at);
+CREATE INDEX idx_tenant_backups_status ON tenant_backups(status);
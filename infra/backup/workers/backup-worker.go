@@ -0,0 +1,83 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	list_tenant_backups_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/list-tenant-backups-use-case"
+	request_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/request-tenant-backup-use-case"
+	run_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/run-tenant-backup-use-case"
+)
+
+// ScheduledBy is the requester of the backups the schedule queues
+const ScheduledBy = "scheduler"
+
+// RunBackupWorker takes the queued backups every interval, it returns when ctx is cancelled. Several
+// instances can run concurrently, backups are claimed with SKIP LOCKED.
+func RunBackupWorker(ctx context.Context, useCase *run_tenant_backup_use_case.RunTenantBackupUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			processed, err := useCase.Execute(ctx)
+			if err != nil {
+				log.Printf("Tenant backup failed: %v", err)
+			}
+			if !processed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunBackupSchedule queues a backup of every tenant whose last backup is older than interval, checking
+// every hour until ctx is cancelled. The backup worker takes them, restarts do not queue them again.
+func RunBackupSchedule(ctx context.Context, listUseCase *list_tenant_backups_use_case.ListTenantBackupsUseCase,
+	requestUseCase *request_tenant_backup_use_case.RequestTenantBackupUseCase, tenants []string, interval time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		for _, tenantID := range tenants {
+			if err := queueBackupIfDue(ctx, listUseCase, requestUseCase, tenantID, interval); err != nil {
+				log.Printf("Failed to queue the backup of %s: %v", tenantID, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func queueBackupIfDue(ctx context.Context, listUseCase *list_tenant_backups_use_case.ListTenantBackupsUseCase,
+	requestUseCase *request_tenant_backup_use_case.RequestTenantBackupUseCase, tenantID string, interval time.Duration) error {
+	listCommand, err := list_tenant_backups_use_case.NewListTenantBackupsCommand(tenantID, 1)
+	if err != nil {
+		return err
+	}
+	latest, err := listUseCase.Execute(ctx, listCommand)
+	if err != nil {
+		return err
+	}
+	if len(latest) > 0 && time.Since(latest[0].CreatedAt) < interval {
+		return nil
+	}
+
+	command, err := request_tenant_backup_use_case.NewRequestTenantBackupCommand(tenantID, ScheduledBy)
+	if err != nil {
+		return err
+	}
+	_, err = requestUseCase.Execute(ctx, command)
+	return err
+}
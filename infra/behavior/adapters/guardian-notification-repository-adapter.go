@@ -0,0 +1,148 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresGuardianNotificationRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresGuardianNotificationRepository(dbInstance *pgxpool.Pool) ports.GuardianNotificationRepository {
+	return &PostgresGuardianNotificationRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+// Queue expects notifications of a single incident, the incident is read again inside the
+// transaction for the event payload
+func (r *PostgresGuardianNotificationRepository) Queue(ctx context.Context,
+	notifications []*entities.GuardianNotification) ([]*entities.GuardianNotification, error) {
+	if len(notifications) == 0 {
+		return notifications, nil
+	}
+
+	var pgIncidentID pgtype.UUID
+	if err := pgIncidentID.Scan(notifications[0].IncidentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	row, err := qtx.GetIncident(ctx, db.GetIncidentParams{ID: pgIncidentID, TenantID: notifications[0].TenantID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	incident, err := toIncident(db.ListIncidentsRow(row))
+	if err != nil {
+		return nil, err
+	}
+
+	queued, err := queueNotifications(ctx, qtx, incident, notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return queued, nil
+}
+
+func (r *PostgresGuardianNotificationRepository) FindByID(ctx context.Context, tenantID string,
+	notificationID string) (*entities.GuardianNotification, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(notificationID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetGuardianNotification(ctx, db.GetGuardianNotificationParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toGuardianNotification(row)
+}
+
+func (r *PostgresGuardianNotificationRepository) ListByIncident(ctx context.Context, tenantID string,
+	incidentID string) ([]*entities.GuardianNotification, error) {
+	var pgIncidentID pgtype.UUID
+	if err := pgIncidentID.Scan(incidentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListIncidentGuardianNotifications(ctx, db.ListIncidentGuardianNotificationsParams{
+		IncidentID: pgIncidentID,
+		TenantID:   tenantID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	notifications := make([]*entities.GuardianNotification, 0, len(rows))
+	for _, row := range rows {
+		notification, err := toGuardianNotification(row)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+func (r *PostgresGuardianNotificationRepository) Acknowledge(ctx context.Context, notification *entities.GuardianNotification) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(notification.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.AcknowledgeGuardianNotification(ctx, db.AcknowledgeGuardianNotificationParams{
+		AcknowledgedAt: pgtype.Timestamptz{Time: *notification.AcknowledgedAt, Valid: true},
+		ID:             pgID,
+		TenantID:       notification.TenantID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func toGuardianNotification(row db.IncidentGuardianNotification) (*entities.GuardianNotification, error) {
+	var acknowledgedAt *time.Time
+	if row.AcknowledgedAt.Valid {
+		acknowledgedAt = &row.AcknowledgedAt.Time
+	}
+
+	notification, err := entities.NewGuardianNotification(row.ID.String(), row.TenantID, row.IncidentID.String(), row.StudentID,
+		row.GuardianID, row.QueuedBy, row.QueuedAt.Time, acknowledgedAt)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return notification, nil
+}
@@ -0,0 +1,87 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/encryption"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresIncidentNoteRepository encrypts note bodies with the key of their tenant, notes of an
+// offboarded tenant are unreadable once its key is shredded
+type PostgresIncidentNoteRepository struct {
+	queries *db.Queries
+	cipher  *encryption.TenantCipher
+}
+
+func NewPostgresIncidentNoteRepository(dbInstance *pgxpool.Pool, cipher *encryption.TenantCipher) ports.IncidentNoteRepository {
+	return &PostgresIncidentNoteRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+		cipher:  cipher,
+	}
+}
+
+func (r *PostgresIncidentNoteRepository) Create(ctx context.Context, note *entities.IncidentNote) error {
+	var pgID, pgIncidentID pgtype.UUID
+	if err := pgID.Scan(note.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgIncidentID.Scan(note.IncidentID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	body, err := r.cipher.Encrypt(ctx, note.TenantID, note.Body)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err = r.queries.CreateIncidentNote(ctx, db.CreateIncidentNoteParams{
+		ID:         pgID,
+		TenantID:   note.TenantID,
+		IncidentID: pgIncidentID,
+		AuthorID:   note.AuthorID,
+		Body:       body,
+		Restricted: note.Restricted,
+		CreatedAt:  pgtype.Timestamptz{Time: note.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresIncidentNoteRepository) ListByIncident(ctx context.Context, tenantID string, incidentID string) ([]*entities.IncidentNote, error) {
+	var pgIncidentID pgtype.UUID
+	if err := pgIncidentID.Scan(incidentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListIncidentNotes(ctx, db.ListIncidentNotesParams{IncidentID: pgIncidentID, TenantID: tenantID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	notes := make([]*entities.IncidentNote, 0, len(rows))
+	for _, row := range rows {
+		body, err := r.cipher.Decrypt(ctx, row.TenantID, row.Body)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		note, err := entities.NewIncidentNote(row.ID.String(), row.TenantID, row.IncidentID.String(), row.AuthorID, body,
+			row.Restricted, row.CreatedAt.Time)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		notes = append(notes, note)
+	}
+
+	return notes, nil
+}
@@ -0,0 +1,260 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	behaviorErrors "github.com/nahualventure/class-backend/core/app/behavior/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresIncidentRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresIncidentRepository(dbInstance *pgxpool.Pool) ports.IncidentRepository {
+	return &PostgresIncidentRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresIncidentRepository) Create(ctx context.Context, incident *entities.Incident,
+	notifications []*entities.GuardianNotification) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(incident.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.CreateIncident(ctx, db.CreateIncidentParams{
+		ID:         pgID,
+		TenantID:   incident.TenantID,
+		Type:       string(incident.Type),
+		Severity:   string(incident.Severity),
+		Summary:    incident.Summary,
+		Location:   emptyToNil(incident.Location),
+		ReportedBy: incident.ReportedBy,
+		Status:     string(incident.Status),
+		OccurredAt: pgtype.Timestamptz{Time: incident.OccurredAt, Valid: true},
+		CreatedAt:  pgtype.Timestamptz{Time: incident.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	for _, studentID := range incident.StudentIDs {
+		err := qtx.AddIncidentStudent(ctx, db.AddIncidentStudentParams{
+			IncidentID: pgID,
+			TenantID:   incident.TenantID,
+			StudentID:  studentID,
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if _, err := queueNotifications(ctx, qtx, incident, notifications); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresIncidentRepository) FindByID(ctx context.Context, tenantID string, incidentID string) (*entities.Incident, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(incidentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetIncident(ctx, db.GetIncidentParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toIncident(db.ListIncidentsRow(row))
+}
+
+func (r *PostgresIncidentRepository) List(ctx context.Context, tenantID string, filter ports.IncidentFilter) ([]*entities.Incident, error) {
+	params := db.ListIncidentsParams{
+		TenantID:    tenantID,
+		AllStudents: filter.StudentIDs == nil,
+		StudentIds:  filter.StudentIDs,
+		RowLimit:    int32(filter.Limit),
+	}
+	if filter.Status != "" {
+		status := string(filter.Status)
+		params.Status = &status
+	}
+	if params.StudentIds == nil {
+		params.StudentIds = []string{}
+	}
+
+	rows, err := r.queries.ListIncidents(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	incidents := make([]*entities.Incident, 0, len(rows))
+	for _, row := range rows {
+		incident, err := toIncident(row)
+		if err != nil {
+			return nil, err
+		}
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, nil
+}
+
+func (r *PostgresIncidentRepository) Resolve(ctx context.Context, incident *entities.Incident) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(incident.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	resolved, err := r.queries.ResolveIncident(ctx, db.ResolveIncidentParams{
+		Resolution: &incident.Resolution,
+		ResolvedBy: &incident.ResolvedBy,
+		ResolvedAt: pgtype.Timestamptz{Time: *incident.ResolvedAt, Valid: true},
+		ID:         pgID,
+		TenantID:   incident.TenantID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	// Resolved concurrently by someone else
+	if resolved == 0 {
+		return behaviorErrors.NewIncidentAlreadyResolvedError(incident.ID)
+	}
+
+	return nil
+}
+
+// queueNotifications inserts the notifications that do not exist yet and appends a delivery event
+// for each of them, it returns the ones inserted
+func queueNotifications(ctx context.Context, queries *db.Queries, incident *entities.Incident,
+	notifications []*entities.GuardianNotification) ([]*entities.GuardianNotification, error) {
+	queued := make([]*entities.GuardianNotification, 0, len(notifications))
+	for _, notification := range notifications {
+		var pgID, pgIncidentID pgtype.UUID
+		if err := pgID.Scan(notification.ID); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		if err := pgIncidentID.Scan(notification.IncidentID); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+
+		inserted, err := queries.InsertGuardianNotification(ctx, db.InsertGuardianNotificationParams{
+			ID:         pgID,
+			TenantID:   notification.TenantID,
+			IncidentID: pgIncidentID,
+			StudentID:  notification.StudentID,
+			GuardianID: notification.GuardianID,
+			QueuedBy:   notification.QueuedBy,
+			QueuedAt:   pgtype.Timestamptz{Time: notification.QueuedAt, Valid: true},
+		})
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		if inserted == 0 {
+			continue
+		}
+
+		payload, err := json.Marshal(map[string]any{
+			"notification_id": notification.ID,
+			"incident_id":     incident.ID,
+			"student_id":      notification.StudentID,
+			"guardian_id":     notification.GuardianID,
+			"type":            string(incident.Type),
+			"severity":        string(incident.Severity),
+			"occurred_at":     incident.OccurredAt.Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to serialize guardian notification event", err)
+		}
+
+		err = queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+			EventType:   events.IncidentGuardianNotificationQueued,
+			AggregateID: incident.ID,
+			TenantID:    incident.TenantID,
+			Payload:     payload,
+			OccurredAt:  pgtype.Timestamptz{Time: notification.QueuedAt, Valid: true},
+		})
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+
+		queued = append(queued, notification)
+	}
+
+	return queued, nil
+}
+
+func toIncident(row db.ListIncidentsRow) (*entities.Incident, error) {
+	var resolvedAt *time.Time
+	if row.ResolvedAt.Valid {
+		resolvedAt = &row.ResolvedAt.Time
+	}
+
+	incident, err := entities.NewIncident(
+		row.ID.String(),
+		row.TenantID,
+		entities.IncidentType(row.Type),
+		entities.Severity(row.Severity),
+		row.Summary,
+		valueOrEmpty(row.Location),
+		row.StudentIds,
+		row.ReportedBy,
+		entities.IncidentStatus(row.Status),
+		valueOrEmpty(row.Resolution),
+		valueOrEmpty(row.ResolvedBy),
+		resolvedAt,
+		row.OccurredAt.Time,
+		row.CreatedAt.Time,
+	)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return incident, nil
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,74 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresViewerDirectory derives viewers from the casbin permissions, anyone allowed to report
+// incidents is staff, and reads guardian links from the database
+type PostgresViewerDirectory struct {
+	queries       *db.Queries
+	casbinService *authorization.CasbinService
+}
+
+func NewPostgresViewerDirectory(dbInstance *pgxpool.Pool, casbinService *authorization.CasbinService) ports.ViewerDirectory {
+	return &PostgresViewerDirectory{
+		queries:       db.New(database.Reads.Wrap(dbInstance)),
+		casbinService: casbinService,
+	}
+}
+
+func (d *PostgresViewerDirectory) Viewer(ctx context.Context, tenantID string, userID string) (*entities.Viewer, error) {
+	staff, authzErr := d.casbinService.CanDo(userID, "incident", "report", tenantID)
+	if authzErr != nil {
+		return nil, authzErr
+	}
+	if staff {
+		restricted, authzErr := d.casbinService.CanDo(userID, "incident", "view_restricted", tenantID)
+		if authzErr != nil {
+			return nil, authzErr
+		}
+		return &entities.Viewer{UserID: userID, Role: entities.ViewerRoleStaff, CanReadRestricted: restricted}, nil
+	}
+
+	guardian, authzErr := d.casbinService.HasRole(userID, "guardian", tenantID)
+	if authzErr != nil {
+		return nil, authzErr
+	}
+	if !guardian {
+		return nil, nil
+	}
+
+	studentIDs, err := d.queries.ListGuardianStudentIDs(ctx, db.ListGuardianStudentIDsParams{TenantID: tenantID, GuardianID: userID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &entities.Viewer{UserID: userID, Role: entities.ViewerRoleGuardian, StudentIDs: studentIDs}, nil
+}
+
+func (d *PostgresViewerDirectory) GuardiansOf(ctx context.Context, tenantID string, studentIDs []string) ([]entities.GuardianLink, error) {
+	rows, err := d.queries.ListGuardianLinksOfStudents(ctx, db.ListGuardianLinksOfStudentsParams{
+		TenantID:   tenantID,
+		StudentIds: studentIDs,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	links := make([]entities.GuardianLink, 0, len(rows))
+	for _, row := range rows {
+		links = append(links, entities.GuardianLink{GuardianID: row.GuardianID, StudentID: row.StudentID})
+	}
+
+	return links, nil
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/behavior/domain/entities"
+)
+
+type ReportIncidentRequest struct {
+	Body struct {
+		Type       string    `json:"type" enum:"disruption,bullying,fighting,dishonesty,attendance,property_damage,other"`
+		Severity   string    `json:"severity" enum:"low,medium,high,critical" doc:"Guardians are notified right away for high and critical"`
+		Summary    string    `json:"summary" minLength:"1" maxLength:"2000" doc:"Shared with guardians, put sensitive details in a restricted note"`
+		Location   string    `json:"location,omitempty" maxLength:"255"`
+		StudentIDs []string  `json:"student_ids" minItems:"1" maxItems:"30"`
+		OccurredAt time.Time `json:"occurred_at"`
+	}
+}
+
+type IncidentResponse struct {
+	ID         string     `json:"id"`
+	Type       string     `json:"type"`
+	Severity   string     `json:"severity"`
+	Summary    string     `json:"summary"`
+	Location   string     `json:"location,omitempty"`
+	StudentIDs []string   `json:"student_ids" doc:"Guardians only see their own students"`
+	ReportedBy string     `json:"reported_by"`
+	Status     string     `json:"status" enum:"open,resolved"`
+	Resolution string     `json:"resolution,omitempty"`
+	ResolvedBy string     `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	OccurredAt time.Time  `json:"occurred_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func NewIncidentResponse(incident *entities.Incident) IncidentResponse {
+	return IncidentResponse{
+		ID:         incident.ID,
+		Type:       string(incident.Type),
+		Severity:   string(incident.Severity),
+		Summary:    incident.Summary,
+		Location:   incident.Location,
+		StudentIDs: incident.StudentIDs,
+		ReportedBy: incident.ReportedBy,
+		Status:     string(incident.Status),
+		Resolution: incident.Resolution,
+		ResolvedBy: incident.ResolvedBy,
+		ResolvedAt: incident.ResolvedAt,
+		OccurredAt: incident.OccurredAt,
+		CreatedAt:  incident.CreatedAt,
+	}
+}
+
+type IncidentEnvelope struct {
+	Body IncidentResponse
+}
+
+type IncidentNoteResponse struct {
+	ID         string    `json:"id"`
+	AuthorID   string    `json:"author_id"`
+	Body       string    `json:"body"`
+	Restricted bool      `json:"restricted"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewIncidentNoteResponse(note *entities.IncidentNote) IncidentNoteResponse {
+	return IncidentNoteResponse{
+		ID:         note.ID,
+		AuthorID:   note.AuthorID,
+		Body:       note.Body,
+		Restricted: note.Restricted,
+		CreatedAt:  note.CreatedAt,
+	}
+}
+
+type GuardianNotificationResponse struct {
+	ID             string     `json:"id"`
+	IncidentID     string     `json:"incident_id"`
+	StudentID      string     `json:"student_id"`
+	GuardianID     string     `json:"guardian_id"`
+	QueuedBy       string     `json:"queued_by"`
+	QueuedAt       time.Time  `json:"queued_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+}
+
+func NewGuardianNotificationResponse(notification *entities.GuardianNotification) GuardianNotificationResponse {
+	return GuardianNotificationResponse{
+		ID:             notification.ID,
+		IncidentID:     notification.IncidentID,
+		StudentID:      notification.StudentID,
+		GuardianID:     notification.GuardianID,
+		QueuedBy:       notification.QueuedBy,
+		QueuedAt:       notification.QueuedAt,
+		AcknowledgedAt: notification.AcknowledgedAt,
+	}
+}
+
+func NewGuardianNotificationResponses(notifications []*entities.GuardianNotification) []GuardianNotificationResponse {
+	responses := make([]GuardianNotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		responses = append(responses, NewGuardianNotificationResponse(notification))
+	}
+	return responses
+}
+
+type IncidentDetailsResponse struct {
+	Body struct {
+		IncidentResponse
+		Notes         []IncidentNoteResponse         `json:"notes" doc:"Staff only, restricted notes need the restricted permission"`
+		Notifications []GuardianNotificationResponse `json:"guardian_notifications"`
+	}
+}
+
+func NewIncidentDetailsResponse(details *entities.IncidentDetails) *IncidentDetailsResponse {
+	response := &IncidentDetailsResponse{}
+	response.Body.IncidentResponse = NewIncidentResponse(details.Incident)
+	response.Body.Notes = make([]IncidentNoteResponse, 0, len(details.Notes))
+	for _, note := range details.Notes {
+		response.Body.Notes = append(response.Body.Notes, NewIncidentNoteResponse(note))
+	}
+	response.Body.Notifications = NewGuardianNotificationResponses(details.Notifications)
+	return response
+}
+
+type IncidentRequest struct {
+	IncidentID string `path:"incidentId" format:"uuid"`
+}
+
+type ListIncidentsRequest struct {
+	StudentID string `query:"student_id" doc:"Only incidents involving this student"`
+	Status    string `query:"status" enum:"open,resolved"`
+	Limit     int    `query:"limit" minimum:"0" maximum:"100" doc:"Page size, 50 when omitted"`
+}
+
+type IncidentListResponse struct {
+	Body struct {
+		Items []IncidentResponse `json:"items"`
+	}
+}
+
+type AddIncidentNoteRequest struct {
+	IncidentID string `path:"incidentId" format:"uuid"`
+	Body       struct {
+		Body       string `json:"body" minLength:"1" maxLength:"5000"`
+		Restricted bool   `json:"restricted,omitempty" doc:"Only readable by the author and staff with the restricted permission"`
+	}
+}
+
+type IncidentNoteEnvelope struct {
+	Body IncidentNoteResponse
+}
+
+type GuardianNotificationListResponse struct {
+	Body struct {
+		Items []GuardianNotificationResponse `json:"items" doc:"Notifications queued by this request"`
+	}
+}
+
+type GuardianNotificationRequest struct {
+	NotificationID string `path:"notificationId" format:"uuid"`
+}
+
+type GuardianNotificationEnvelope struct {
+	Body GuardianNotificationResponse
+}
+
+type ResolveIncidentRequest struct {
+	IncidentID string `path:"incidentId" format:"uuid"`
+	Body       struct {
+		Resolution string `json:"resolution" minLength:"1" maxLength:"2000" doc:"Outcome of the incident, shared with guardians"`
+	}
+}
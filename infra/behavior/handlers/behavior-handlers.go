@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	acknowledge_incident_notification_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/acknowledge-incident-notification-use-case"
+	add_incident_note_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/add-incident-note-use-case"
+	get_incident_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/get-incident-use-case"
+	list_incidents_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/list-incidents-use-case"
+	notify_incident_guardians_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/notify-incident-guardians-use-case"
+	report_incident_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/report-incident-use-case"
+	resolve_incident_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/resolve-incident-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type BehaviorHandlers struct {
+	reportIncidentUseCase          *report_incident_use_case.ReportIncidentUseCase
+	listIncidentsUseCase           *list_incidents_use_case.ListIncidentsUseCase
+	getIncidentUseCase             *get_incident_use_case.GetIncidentUseCase
+	addIncidentNoteUseCase         *add_incident_note_use_case.AddIncidentNoteUseCase
+	notifyIncidentGuardiansUseCase *notify_incident_guardians_use_case.NotifyIncidentGuardiansUseCase
+	acknowledgeNotificationUseCase *acknowledge_incident_notification_use_case.AcknowledgeIncidentNotificationUseCase
+	resolveIncidentUseCase         *resolve_incident_use_case.ResolveIncidentUseCase
+}
+
+func NewBehaviorHandlers(
+	reportIncidentUseCase *report_incident_use_case.ReportIncidentUseCase,
+	listIncidentsUseCase *list_incidents_use_case.ListIncidentsUseCase,
+	getIncidentUseCase *get_incident_use_case.GetIncidentUseCase,
+	addIncidentNoteUseCase *add_incident_note_use_case.AddIncidentNoteUseCase,
+	notifyIncidentGuardiansUseCase *notify_incident_guardians_use_case.NotifyIncidentGuardiansUseCase,
+	acknowledgeNotificationUseCase *acknowledge_incident_notification_use_case.AcknowledgeIncidentNotificationUseCase,
+	resolveIncidentUseCase *resolve_incident_use_case.ResolveIncidentUseCase,
+) *BehaviorHandlers {
+	return &BehaviorHandlers{
+		reportIncidentUseCase:          reportIncidentUseCase,
+		listIncidentsUseCase:           listIncidentsUseCase,
+		getIncidentUseCase:             getIncidentUseCase,
+		addIncidentNoteUseCase:         addIncidentNoteUseCase,
+		notifyIncidentGuardiansUseCase: notifyIncidentGuardiansUseCase,
+		acknowledgeNotificationUseCase: acknowledgeNotificationUseCase,
+		resolveIncidentUseCase:         resolveIncidentUseCase,
+	}
+}
+
+func (h *BehaviorHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "report-incident",
+		Method:        http.MethodPost,
+		Path:          "/incidents",
+		Summary:       "Report a behavior incident",
+		Description:   "Guardians of the students involved are notified right away for high and critical incidents.",
+		Tags:          []string{"Behavior"},
+		DefaultStatus: http.StatusCreated,
+	}, h.ReportIncident)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-incidents",
+		Method:      http.MethodGet,
+		Path:        "/incidents",
+		Summary:     "List incidents, guardians only get those of their students",
+		Tags:        []string{"Behavior"},
+	}, h.ListIncidents)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-incident",
+		Method:      http.MethodGet,
+		Path:        "/incidents/{incidentId}",
+		Summary:     "Get an incident with the notes the user may read",
+		Tags:        []string{"Behavior"},
+	}, h.GetIncident)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "add-incident-note",
+		Method:        http.MethodPost,
+		Path:          "/incidents/{incidentId}/notes",
+		Summary:       "Add a staff note to an incident",
+		Tags:          []string{"Behavior"},
+		DefaultStatus: http.StatusCreated,
+	}, h.AddIncidentNote)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "notify-incident-guardians",
+		Method:      http.MethodPost,
+		Path:        "/incidents/{incidentId}/guardian-notifications",
+		Summary:     "Notify the guardians of the students involved who were not notified yet",
+		Tags:        []string{"Behavior"},
+	}, h.NotifyIncidentGuardians)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "acknowledge-incident-notification",
+		Method:      http.MethodPost,
+		Path:        "/incident-notifications/{notificationId}/acknowledge",
+		Summary:     "Acknowledge an incident notification as the guardian",
+		Tags:        []string{"Behavior"},
+	}, h.AcknowledgeIncidentNotification)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "resolve-incident",
+		Method:      http.MethodPost,
+		Path:        "/incidents/{incidentId}/resolve",
+		Summary:     "Resolve an incident with its outcome",
+		Tags:        []string{"Behavior"},
+	}, h.ResolveIncident)
+}
+
+func (h *BehaviorHandlers) ReportIncident(ctx context.Context, input *ReportIncidentRequest) (*IncidentDetailsResponse, error) {
+	command, err := report_incident_use_case.NewReportIncidentCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Type,
+		input.Body.Severity,
+		input.Body.Summary,
+		input.Body.Location,
+		input.Body.StudentIDs,
+		input.Body.OccurredAt,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	details, err := h.reportIncidentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewIncidentDetailsResponse(details), nil
+}
+
+func (h *BehaviorHandlers) ListIncidents(ctx context.Context, input *ListIncidentsRequest) (*IncidentListResponse, error) {
+	command, err := list_incidents_use_case.NewListIncidentsCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.StudentID,
+		input.Status,
+		input.Limit,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	incidents, err := h.listIncidentsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &IncidentListResponse{}
+	response.Body.Items = make([]IncidentResponse, 0, len(incidents))
+	for _, incident := range incidents {
+		response.Body.Items = append(response.Body.Items, NewIncidentResponse(incident))
+	}
+	return response, nil
+}
+
+func (h *BehaviorHandlers) GetIncident(ctx context.Context, input *IncidentRequest) (*IncidentDetailsResponse, error) {
+	command, err := get_incident_use_case.NewGetIncidentCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.IncidentID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	details, err := h.getIncidentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewIncidentDetailsResponse(details), nil
+}
+
+func (h *BehaviorHandlers) AddIncidentNote(ctx context.Context, input *AddIncidentNoteRequest) (*IncidentNoteEnvelope, error) {
+	command, err := add_incident_note_use_case.NewAddIncidentNoteCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.IncidentID,
+		authorization.UserIDFromContext(ctx),
+		input.Body.Body,
+		input.Body.Restricted,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	note, err := h.addIncidentNoteUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &IncidentNoteEnvelope{Body: NewIncidentNoteResponse(note)}, nil
+}
+
+func (h *BehaviorHandlers) NotifyIncidentGuardians(ctx context.Context, input *IncidentRequest) (*GuardianNotificationListResponse, error) {
+	command, err := notify_incident_guardians_use_case.NewNotifyIncidentGuardiansCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.IncidentID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	notifications, err := h.notifyIncidentGuardiansUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &GuardianNotificationListResponse{}
+	response.Body.Items = NewGuardianNotificationResponses(notifications)
+	return response, nil
+}
+
+func (h *BehaviorHandlers) AcknowledgeIncidentNotification(ctx context.Context,
+	input *GuardianNotificationRequest) (*GuardianNotificationEnvelope, error) {
+	command, err := acknowledge_incident_notification_use_case.NewAcknowledgeIncidentNotificationCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.NotificationID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	notification, err := h.acknowledgeNotificationUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &GuardianNotificationEnvelope{Body: NewGuardianNotificationResponse(notification)}, nil
+}
+
+func (h *BehaviorHandlers) ResolveIncident(ctx context.Context, input *ResolveIncidentRequest) (*IncidentEnvelope, error) {
+	command, err := resolve_incident_use_case.NewResolveIncidentCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.IncidentID,
+		authorization.UserIDFromContext(ctx),
+		input.Body.Resolution,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	incident, err := h.resolveIncidentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &IncidentEnvelope{Body: NewIncidentResponse(incident)}, nil
+}
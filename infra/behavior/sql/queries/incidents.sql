@@ -0,0 +1,85 @@
+-- name: CreateIncident :exec
+INSERT INTO incidents (id, tenant_id, type, severity, summary, location, reported_by, status, occurred_at, created_at)
+VALUES (@id, @tenant_id, @type, @severity, @summary, @location, @reported_by, @status, @occurred_at, @created_at);
+
+-- name: AddIncidentStudent :exec
+INSERT INTO incident_students (incident_id, tenant_id, student_id)
+VALUES (@incident_id, @tenant_id, @student_id);
+
+-- name: GetIncident :one
+SELECT i.id, i.tenant_id, i.type, i.severity, i.summary, i.location, i.reported_by, i.status, i.resolution,
+       i.resolved_by, i.resolved_at, i.occurred_at, i.created_at,
+       ARRAY(
+           SELECT s.student_id FROM incident_students s
+           WHERE s.incident_id = i.id ORDER BY s.student_id
+       )::text[] AS student_ids
+FROM incidents i
+WHERE i.id = @id AND i.tenant_id = @tenant_id;
+
+-- name: ListIncidents :many
+SELECT i.id, i.tenant_id, i.type, i.severity, i.summary, i.location, i.reported_by, i.status, i.resolution,
+       i.resolved_by, i.resolved_at, i.occurred_at, i.created_at,
+       ARRAY(
+           SELECT s.student_id FROM incident_students s
+           WHERE s.incident_id = i.id ORDER BY s.student_id
+       )::text[] AS student_ids
+FROM incidents i
+WHERE i.tenant_id = @tenant_id
+  AND (sqlc.narg('status')::varchar IS NULL OR i.status = sqlc.narg('status'))
+  AND (
+      @all_students::boolean
+      OR EXISTS (
+          SELECT 1 FROM incident_students s
+          WHERE s.incident_id = i.id AND s.student_id = ANY(@student_ids::varchar[])
+      )
+  )
+ORDER BY i.occurred_at DESC
+LIMIT @row_limit;
+
+-- name: ResolveIncident :execrows
+UPDATE incidents
+SET status = 'resolved', resolution = @resolution, resolved_by = @resolved_by, resolved_at = @resolved_at
+WHERE id = @id AND tenant_id = @tenant_id AND status = 'open';
+
+-- name: CreateIncidentNote :exec
+INSERT INTO incident_notes (id, tenant_id, incident_id, author_id, body, restricted, created_at)
+VALUES (@id, @tenant_id, @incident_id, @author_id, @body, @restricted, @created_at);
+
+-- name: ListIncidentNotes :many
+SELECT id, tenant_id, incident_id, author_id, body, restricted, created_at
+FROM incident_notes
+WHERE incident_id = @incident_id AND tenant_id = @tenant_id
+ORDER BY created_at;
+
+-- name: InsertGuardianNotification :execrows
+INSERT INTO incident_guardian_notifications (id, tenant_id, incident_id, student_id, guardian_id, queued_by, queued_at)
+VALUES (@id, @tenant_id, @incident_id, @student_id, @guardian_id, @queued_by, @queued_at)
+ON CONFLICT (tenant_id, incident_id, student_id, guardian_id) DO NOTHING;
+
+-- name: GetGuardianNotification :one
+SELECT id, tenant_id, incident_id, student_id, guardian_id, queued_by, queued_at, acknowledged_at
+FROM incident_guardian_notifications
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: ListIncidentGuardianNotifications :many
+SELECT id, tenant_id, incident_id, student_id, guardian_id, queued_by, queued_at, acknowledged_at
+FROM incident_guardian_notifications
+WHERE incident_id = @incident_id AND tenant_id = @tenant_id
+ORDER BY queued_at, guardian_id;
+
+-- name: AcknowledgeGuardianNotification :exec
+UPDATE incident_guardian_notifications
+SET acknowledged_at = @acknowledged_at
+WHERE id = @id AND tenant_id = @tenant_id AND acknowledged_at IS NULL;
+
+-- name: ListGuardianLinksOfStudents :many
+SELECT guardian_id, student_id
+FROM guardian_links
+WHERE tenant_id = @tenant_id AND student_id = ANY(@student_ids::varchar[])
+ORDER BY student_id, guardian_id;
+
+-- name: ListGuardianStudentIDs :many
+SELECT student_id
+FROM guardian_links
+WHERE tenant_id = @tenant_id AND guardian_id = @guardian_id
+ORDER BY student_id;
@@ -0,0 +1,65 @@
+-- Behavior and discipline incidents. The summary and resolution are shared with guardians,
+-- sensitive details go to restricted notes
+CREATE TABLE incidents (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    severity VARCHAR(20) NOT NULL,          -- low, medium, high, critical
+    summary TEXT NOT NULL,
+    location VARCHAR(255),
+    reported_by VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,            -- open, resolved
+    resolution TEXT,
+    resolved_by VARCHAR(255),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_incidents_tenant ON incidents(tenant_id, occurred_at DESC);
+
+CREATE TABLE incident_students (
+    incident_id UUID NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(255) NOT NULL,
+    student_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (incident_id, student_id)
+);
+
+CREATE INDEX idx_incident_students_student ON incident_students(tenant_id, student_id);
+
+-- Restricted notes are only returned to their author and to staff with incident:view_restricted
+CREATE TABLE incident_notes (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    incident_id UUID NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    author_id VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    restricted BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_incident_notes_incident ON incident_notes(incident_id, created_at);
+
+-- One notification per guardian and student of an incident, delivery goes through the outbox.
+-- Hash partitioned by tenant, every query is scoped to a tenant and only reads its partition.
+CREATE TABLE incident_guardian_notifications (
+    id UUID NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,
+    incident_id UUID NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    student_id VARCHAR(255) NOT NULL,
+    guardian_id VARCHAR(255) NOT NULL,
+    queued_by VARCHAR(255) NOT NULL,
+    queued_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (tenant_id, id),
+    UNIQUE (tenant_id, incident_id, student_id, guardian_id)
+) PARTITION BY HASH (tenant_id);
+
+CREATE TABLE incident_guardian_notifications_0 PARTITION OF incident_guardian_notifications FOR VALUES WITH (MODULUS 8, REMAINDER 0);
+CREATE TABLE incident_guardian_notifications_1 PARTITION OF incident_guardian_notifications FOR VALUES WITH (MODULUS 8, REMAINDER 1);
+CREATE TABLE incident_guardian_notifications_2 PARTITION OF incident_guardian_notifications FOR VALUES WITH (MODULUS 8, REMAINDER 2);
+CREATE TABLE incident_guardian_notifications_3 PARTITION OF incident_guardian_notifications FOR VALUES WITH (MODULUS 8, REMAINDER 3);
+CREATE TABLE incident_guardian_notifications_4 PARTITION OF incident_guardian_notifications FOR VALUES WITH (MODULUS 8, REMAINDER 4);
+CREATE TABLE incident_guardian_notifications_5 PARTITION OF incident_guardian_notifications FOR VALUES WITH (MODULUS 8, REMAINDER 5);
+CREATE TABLE incident_guardian_notifications_6 PARTITION OF incident_guardian_notifications FOR VALUES WITH (MODULUS 8, REMAINDER 6);
+CREATE TABLE incident_guardian_notifications_7 PARTITION OF incident_guardian_notifications FOR VALUES WITH (MODULUS 8, REMAINDER 7);
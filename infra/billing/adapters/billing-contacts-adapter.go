@@ -0,0 +1,29 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// billingContactRole is the role told about payment problems, admins manage the subscription
+const billingContactRole = "admin"
+
+type CasbinBillingContacts struct {
+	casbinService *authorization.CasbinService
+}
+
+func NewCasbinBillingContacts(casbinService *authorization.CasbinService) ports.BillingContacts {
+	return &CasbinBillingContacts{
+		casbinService: casbinService,
+	}
+}
+
+func (c *CasbinBillingContacts) Contacts(_ context.Context, tenantID string) ([]string, error) {
+	users, err := c.casbinService.GetRoleUsers(billingContactRole, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
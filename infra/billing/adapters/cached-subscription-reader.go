@@ -0,0 +1,38 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/cache"
+)
+
+// CachedSubscriptionReader caches subscriptions per tenant for the feature gate, which reads them on
+// every gated request. Plan changes take up to the TTL to reach gated endpoints.
+type CachedSubscriptionReader struct {
+	next  ports.SubscriptionReader
+	cache *cache.TTLCache[string, *entities.Subscription]
+}
+
+func NewCachedSubscriptionReader(next ports.SubscriptionReader, ttl time.Duration) ports.SubscriptionReader {
+	return &CachedSubscriptionReader{
+		next:  next,
+		cache: cache.NewTTLCache[string, *entities.Subscription](ttl, 10_000),
+	}
+}
+
+func (r *CachedSubscriptionReader) FindByTenant(ctx context.Context, tenantID string) (*entities.Subscription, error) {
+	if subscription, ok := r.cache.Lookup(ctx, tenantID); ok {
+		return subscription, nil
+	}
+
+	subscription, err := r.next.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(tenantID, subscription)
+	return subscription, nil
+}
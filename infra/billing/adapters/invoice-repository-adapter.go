@@ -0,0 +1,154 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresInvoiceRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresInvoiceRepository(dbInstance *pgxpool.Pool) ports.InvoiceRepository {
+	return &PostgresInvoiceRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresInvoiceRepository) Save(ctx context.Context, invoice *entities.Invoice) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(invoice.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.UpsertInvoice(ctx, db.UpsertInvoiceParams{
+		ID:                 pgID,
+		TenantID:           invoice.TenantID,
+		ProviderID:         invoice.ProviderID,
+		Number:             invoice.Number,
+		Status:             string(invoice.Status),
+		Currency:           invoice.Currency,
+		AmountDue:          invoice.AmountDue,
+		AmountPaid:         invoice.AmountPaid,
+		HostedUrl:          emptyToNil(invoice.HostedURL),
+		PeriodStart:        pgtype.Timestamptz{Time: invoice.PeriodStart, Valid: true},
+		PeriodEnd:          pgtype.Timestamptz{Time: invoice.PeriodEnd, Valid: true},
+		AttemptCount:       int32(invoice.AttemptCount),
+		NextPaymentAttempt: timestamptz(invoice.NextPaymentAttempt),
+		SyncedAt:           pgtype.Timestamptz{Time: invoice.SyncedAt, Valid: true},
+		CreatedAt:          pgtype.Timestamptz{Time: invoice.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+// QueueDunningNotice claims the attempt on the stored invoice and writes one outbox event per
+// recipient, notification senders pick them up from the outbox
+func (r *PostgresInvoiceRepository) QueueDunningNotice(ctx context.Context, invoice *entities.Invoice, recipientIDs []string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	storedID, err := qtx.ClaimInvoiceDunning(ctx, db.ClaimInvoiceDunningParams{
+		AttemptCount: int32(invoice.AttemptCount),
+		ProviderID:   invoice.ProviderID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, appErrors.PropagateError(err)
+	}
+
+	for _, recipientID := range recipientIDs {
+		event := map[string]any{
+			"invoice_id":    storedID.String(),
+			"recipient_id":  recipientID,
+			"number":        invoice.Number,
+			"amount_due":    invoice.AmountDue,
+			"currency":      invoice.Currency,
+			"attempt_count": invoice.AttemptCount,
+			"hosted_url":    invoice.HostedURL,
+		}
+		if invoice.NextPaymentAttempt != nil {
+			event["next_payment_attempt"] = invoice.NextPaymentAttempt.Format(time.RFC3339)
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return false, appErrors.NewInfrastructureError("failed to serialize dunning event", err)
+		}
+
+		err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+			EventType:   events.PaymentFailed,
+			AggregateID: storedID.String(),
+			TenantID:    invoice.TenantID,
+			Payload:     payload,
+			OccurredAt:  pgtype.Timestamptz{Time: invoice.SyncedAt, Valid: true},
+		})
+		if err != nil {
+			return false, appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return true, nil
+}
+
+func (r *PostgresInvoiceRepository) List(ctx context.Context, tenantID string,
+	page *pagination.PageRequest) (*pagination.Page[*entities.Invoice], error) {
+	afterCreatedAt, afterID, err := database.TimeIDKeyset(page.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.queries.ListInvoices(ctx, db.ListInvoicesParams{
+		TenantID:       tenantID,
+		PageLimit:      int32(page.PageSize + 1),
+		AfterCreatedAt: afterCreatedAt,
+		AfterID:        afterID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	invoices := make([]*entities.Invoice, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		invoice, err := entities.NewInvoice(row.ID.String(), row.TenantID, row.ProviderID, row.Number, entities.InvoiceStatus(row.Status),
+			row.Currency, row.AmountDue, row.AmountPaid, valueOrEmpty(row.HostedUrl), row.PeriodStart.Time, row.PeriodEnd.Time,
+			int(row.AttemptCount), timeOrNil(row.NextPaymentAttempt), row.SyncedAt.Time, row.CreatedAt.Time)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	return pagination.NewPage(page, invoices, total, func(invoice *entities.Invoice) []string {
+		return []string{pagination.TimeKey(invoice.CreatedAt), invoice.ID}
+	}), nil
+}
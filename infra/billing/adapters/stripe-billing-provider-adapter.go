@@ -0,0 +1,235 @@
+package adapters
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	billingErrors "github.com/nahualventure/class-backend/core/app/billing/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// stripeSignatureTolerance bounds the age of a signed webhook, older ones are rejected as replays
+const stripeSignatureTolerance = 5 * time.Minute
+
+type StripeConfig struct {
+	WebhookSecret string // signing secret of the webhook endpoint, whsec_...
+}
+
+// StripeBillingProvider reads Stripe webhooks. Subscriptions carry the tenant in their tenant_id
+// metadata and prices name their plan through their lookup key, both are set when the subscription
+// is created in Stripe.
+type StripeBillingProvider struct {
+	config StripeConfig
+	now    func() time.Time
+}
+
+func NewStripeBillingProvider(config StripeConfig) ports.BillingProvider {
+	return &StripeBillingProvider{
+		config: config,
+		now:    time.Now,
+	}
+}
+
+type stripeEvent struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+type stripeSubscription struct {
+	ID                string            `json:"id"`
+	Customer          string            `json:"customer"`
+	Status            string            `json:"status"`
+	CancelAtPeriodEnd bool              `json:"cancel_at_period_end"`
+	CurrentPeriodEnd  int64             `json:"current_period_end"`
+	Metadata          map[string]string `json:"metadata"`
+	Items             struct {
+		Data []struct {
+			CurrentPeriodEnd int64 `json:"current_period_end"`
+			Price            struct {
+				LookupKey string `json:"lookup_key"`
+			} `json:"price"`
+		} `json:"data"`
+	} `json:"items"`
+}
+
+type stripeInvoice struct {
+	ID                 string `json:"id"`
+	Customer           string `json:"customer"`
+	Number             string `json:"number"`
+	Status             string `json:"status"`
+	Currency           string `json:"currency"`
+	AmountDue          int64  `json:"amount_due"`
+	AmountPaid         int64  `json:"amount_paid"`
+	HostedInvoiceURL   string `json:"hosted_invoice_url"`
+	PeriodStart        int64  `json:"period_start"`
+	PeriodEnd          int64  `json:"period_end"`
+	AttemptCount       int    `json:"attempt_count"`
+	NextPaymentAttempt *int64 `json:"next_payment_attempt"`
+	Created            int64  `json:"created"`
+}
+
+// VerifyEvent checks the signature, the body must carry the ID of the event Stripe retries it with
+func (p *StripeBillingProvider) VerifyEvent(payload []byte, signature string) (string, error) {
+	if err := p.verifySignature(payload, signature); err != nil {
+		return "", err
+	}
+
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil || event.ID == "" {
+		return "", billingErrors.NewInvalidBillingWebhookError("malformed body")
+	}
+	return event.ID, nil
+}
+
+func (p *StripeBillingProvider) ParseEvent(payload []byte) (*entities.BillingEvent, error) {
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil || event.ID == "" {
+		return nil, billingErrors.NewInvalidBillingWebhookError("malformed body")
+	}
+
+	billingEvent := &entities.BillingEvent{
+		ID:         event.ID,
+		Type:       entities.BillingEventIgnored,
+		OccurredAt: time.Unix(event.Created, 0).UTC(),
+	}
+
+	switch {
+	case strings.HasPrefix(event.Type, "customer.subscription."):
+		var object stripeSubscription
+		if err := json.Unmarshal(event.Data.Object, &object); err != nil {
+			return nil, billingErrors.NewInvalidBillingWebhookError("malformed subscription")
+		}
+		subscription, err := p.toSubscription(object, billingEvent.OccurredAt)
+		if err != nil {
+			return nil, err
+		}
+		billingEvent.Type = entities.BillingEventSubscriptionChanged
+		billingEvent.CustomerID = object.Customer
+		billingEvent.Subscription = subscription
+	case strings.HasPrefix(event.Type, "invoice."):
+		var object stripeInvoice
+		if err := json.Unmarshal(event.Data.Object, &object); err != nil {
+			return nil, billingErrors.NewInvalidBillingWebhookError("malformed invoice")
+		}
+		billingEvent.Type = entities.BillingEventInvoiceChanged
+		if event.Type == "invoice.payment_failed" {
+			billingEvent.Type = entities.BillingEventPaymentFailed
+		}
+		billingEvent.CustomerID = object.Customer
+		billingEvent.Invoice = toInvoice(object)
+	}
+
+	return billingEvent, nil
+}
+
+// verifySignature checks the Stripe-Signature header, "t=<unix time>,v1=<hex HMAC-SHA256 of
+// t.body>". Several v1 signatures are sent while the endpoint secret is being rolled.
+func (p *StripeBillingProvider) verifySignature(payload []byte, header string) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return billingErrors.NewInvalidBillingWebhookError("malformed signature header")
+	}
+	if age := p.now().Sub(time.Unix(seconds, 0)); age > stripeSignatureTolerance || age < -stripeSignatureTolerance {
+		return billingErrors.NewInvalidBillingWebhookError("signature timestamp outside the tolerance")
+	}
+
+	mac := hmac.New(sha256.New, []byte(p.config.WebhookSecret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, signature := range signatures {
+		received, err := hex.DecodeString(signature)
+		if err == nil && hmac.Equal(expected, received) {
+			return nil
+		}
+	}
+	return billingErrors.NewInvalidBillingWebhookError("signature mismatch")
+}
+
+func (p *StripeBillingProvider) toSubscription(object stripeSubscription, occurredAt time.Time) (*entities.Subscription, error) {
+	tenantID := object.Metadata["tenant_id"]
+	if tenantID == "" {
+		return nil, billingErrors.NewInvalidBillingWebhookError(fmt.Sprintf("subscription %s has no tenant_id metadata", object.ID))
+	}
+
+	var planCode string
+	periodEnd := object.CurrentPeriodEnd
+	if len(object.Items.Data) > 0 {
+		planCode = object.Items.Data[0].Price.LookupKey
+		// Newer API versions only report the period on the items
+		if periodEnd == 0 {
+			periodEnd = object.Items.Data[0].CurrentPeriodEnd
+		}
+	}
+
+	var currentPeriodEnd *time.Time
+	if periodEnd > 0 {
+		end := time.Unix(periodEnd, 0).UTC()
+		currentPeriodEnd = &end
+	}
+
+	subscription, err := entities.NewSubscription(tenantID, entities.PlanCode(planCode), toSubscriptionStatus(object.Status), object.Customer,
+		object.ID, currentPeriodEnd, object.CancelAtPeriodEnd, occurredAt, p.now().UTC())
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return subscription, nil
+}
+
+func toSubscriptionStatus(status string) entities.SubscriptionStatus {
+	switch status {
+	case "incomplete_expired":
+		return entities.SubscriptionStatusCanceled
+	case "paused":
+		return entities.SubscriptionStatusUnpaid
+	default:
+		return entities.SubscriptionStatus(status)
+	}
+}
+
+// toInvoice leaves the ID and tenant unset, they are only known once the customer is resolved
+func toInvoice(object stripeInvoice) *entities.Invoice {
+	invoice := &entities.Invoice{
+		ProviderID:   object.ID,
+		Number:       object.Number,
+		Status:       entities.InvoiceStatus(object.Status),
+		Currency:     strings.ToUpper(object.Currency),
+		AmountDue:    object.AmountDue,
+		AmountPaid:   object.AmountPaid,
+		HostedURL:    object.HostedInvoiceURL,
+		PeriodStart:  time.Unix(object.PeriodStart, 0).UTC(),
+		PeriodEnd:    time.Unix(object.PeriodEnd, 0).UTC(),
+		AttemptCount: object.AttemptCount,
+		CreatedAt:    time.Unix(object.Created, 0).UTC(),
+	}
+	if object.NextPaymentAttempt != nil {
+		next := time.Unix(*object.NextPaymentAttempt, 0).UTC()
+		invoice.NextPaymentAttempt = &next
+	}
+	return invoice
+}
@@ -0,0 +1,109 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSubscriptionRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresSubscriptionRepository(dbInstance *pgxpool.Pool) ports.SubscriptionRepository {
+	return &PostgresSubscriptionRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresSubscriptionRepository) FindByTenant(ctx context.Context, tenantID string) (*entities.Subscription, error) {
+	row, err := r.queries.GetSubscription(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toSubscription(row)
+}
+
+func (r *PostgresSubscriptionRepository) FindByCustomer(ctx context.Context, customerID string) (*entities.Subscription, error) {
+	row, err := r.queries.GetSubscriptionByCustomer(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toSubscription(row)
+}
+
+func (r *PostgresSubscriptionRepository) Save(ctx context.Context, subscription *entities.Subscription) error {
+	err := r.queries.UpsertSubscription(ctx, db.UpsertSubscriptionParams{
+		TenantID:          subscription.TenantID,
+		PlanCode:          string(subscription.PlanCode),
+		Status:            string(subscription.Status),
+		CustomerID:        subscription.CustomerID,
+		ProviderID:        subscription.ProviderID,
+		CurrentPeriodEnd:  timestamptz(subscription.CurrentPeriodEnd),
+		CancelAtPeriodEnd: subscription.CancelAtPeriodEnd,
+		SyncedAt:          pgtype.Timestamptz{Time: subscription.SyncedAt, Valid: true},
+		UpdatedAt:         pgtype.Timestamptz{Time: subscription.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func toSubscription(row db.Subscription) (*entities.Subscription, error) {
+	subscription, err := entities.NewSubscription(row.TenantID, entities.PlanCode(row.PlanCode), entities.SubscriptionStatus(row.Status),
+		row.CustomerID, row.ProviderID, timeOrNil(row.CurrentPeriodEnd), row.CancelAtPeriodEnd, row.SyncedAt.Time, row.UpdatedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return subscription, nil
+}
+
+func timestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
+
+func timeOrNil(value pgtype.Timestamptz) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
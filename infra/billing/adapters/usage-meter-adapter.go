@@ -0,0 +1,73 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// staffRoles are the roles that take a staff seat, students and guardians are not limited
+var staffRoles = []string{"admin", "department_head", "instructor"}
+
+// PostgresUsageMeter reads storage and API usage from the database and staff seats from the role
+// assignments
+type PostgresUsageMeter struct {
+	queries       *db.Queries
+	casbinService *authorization.CasbinService
+}
+
+func NewPostgresUsageMeter(dbInstance *pgxpool.Pool, casbinService *authorization.CasbinService) ports.UsageMeter {
+	return &PostgresUsageMeter{
+		queries:       db.New(database.Reads.Wrap(dbInstance)),
+		casbinService: casbinService,
+	}
+}
+
+func (m *PostgresUsageMeter) Usage(ctx context.Context, tenantID string, limit entities.Limit) (int64, error) {
+	switch limit {
+	case entities.LimitStaffAccounts:
+		return m.staffAccounts(tenantID)
+	case entities.LimitStorageGB:
+		bytes, err := m.queries.GetStorageUsage(ctx, tenantID)
+		if err != nil {
+			return 0, appErrors.PropagateError(err)
+		}
+		return bytes, nil
+	}
+	return 0, appErrors.NewInfrastructureError("usage of limit "+string(limit)+" is not measured", nil)
+}
+
+func (m *PostgresUsageMeter) CountAPICall(ctx context.Context, tenantID string, day time.Time) (int64, error) {
+	calls, err := m.queries.IncrementAPIUsage(ctx, db.IncrementAPIUsageParams{
+		TenantID: tenantID,
+		Day:      pgtype.Date{Time: day.Truncate(24 * time.Hour), Valid: true},
+	})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	return calls, nil
+}
+
+// staffAccounts counts users once even when they hold several staff roles
+func (m *PostgresUsageMeter) staffAccounts(tenantID string) (int64, error) {
+	users := map[string]bool{}
+	for _, role := range staffRoles {
+		roleUsers, err := m.casbinService.GetRoleUsers(role, tenantID)
+		if err != nil {
+			return 0, err
+		}
+		for _, user := range roleUsers {
+			users[user] = true
+		}
+	}
+	return int64(len(users)), nil
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type PlanResponse struct {
+	Code     string           `json:"code" enum:"free,standard,premium"`
+	Name     string           `json:"name"`
+	Features []string         `json:"features"`
+	Limits   map[string]int64 `json:"limits" doc:"Usage limits by name in the unit the name ends with, limits left out are unlimited"`
+}
+
+func NewPlanResponse(plan *entities.Plan) PlanResponse {
+	features := make([]string, 0, len(plan.Features))
+	for _, feature := range plan.Features {
+		features = append(features, string(feature))
+	}
+	limits := make(map[string]int64, len(plan.Limits))
+	for limit, allowance := range plan.Limits {
+		limits[string(limit)] = allowance
+	}
+	return PlanResponse{Code: string(plan.Code), Name: plan.Name, Features: features, Limits: limits}
+}
+
+type PlanListResponse struct {
+	Body struct {
+		Items []PlanResponse `json:"items"`
+	}
+}
+
+type SubscriptionResponse struct {
+	Plan              PlanResponse `json:"plan" doc:"Plan whose features the tenant gets"`
+	SubscribedPlan    string       `json:"subscribed_plan,omitempty" doc:"Plan paid for, absent without a subscription. Differs from plan once an unpaid subscription stops entitling the tenant."`
+	Status            string       `json:"status,omitempty" enum:"trialing,active,past_due,unpaid,incomplete,canceled"`
+	CurrentPeriodEnd  *time.Time   `json:"current_period_end,omitempty"`
+	CancelAtPeriodEnd bool         `json:"cancel_at_period_end"`
+}
+
+func NewSubscriptionResponse(entitlements entities.Entitlements) SubscriptionResponse {
+	response := SubscriptionResponse{Plan: NewPlanResponse(entitlements.Plan)}
+	if subscription := entitlements.Subscription; subscription != nil {
+		response.SubscribedPlan = string(subscription.PlanCode)
+		response.Status = string(subscription.Status)
+		response.CurrentPeriodEnd = subscription.CurrentPeriodEnd
+		response.CancelAtPeriodEnd = subscription.CancelAtPeriodEnd
+	}
+	return response
+}
+
+type SubscriptionEnvelope struct {
+	Body SubscriptionResponse
+}
+
+type InvoiceResponse struct {
+	ID                 string     `json:"id"`
+	Number             string     `json:"number,omitempty"`
+	Status             string     `json:"status" enum:"draft,open,paid,uncollectible,void"`
+	Currency           string     `json:"currency" example:"USD"`
+	AmountDue          int64      `json:"amount_due" doc:"In the minor unit of the currency"`
+	AmountPaid         int64      `json:"amount_paid" doc:"In the minor unit of the currency"`
+	HostedURL          string     `json:"hosted_url,omitempty" doc:"Page of the provider to see and pay the invoice"`
+	PeriodStart        time.Time  `json:"period_start"`
+	PeriodEnd          time.Time  `json:"period_end"`
+	AttemptCount       int        `json:"attempt_count"`
+	NextPaymentAttempt *time.Time `json:"next_payment_attempt,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+func NewInvoiceResponse(invoice *entities.Invoice) InvoiceResponse {
+	return InvoiceResponse{
+		ID:                 invoice.ID,
+		Number:             invoice.Number,
+		Status:             string(invoice.Status),
+		Currency:           invoice.Currency,
+		AmountDue:          invoice.AmountDue,
+		AmountPaid:         invoice.AmountPaid,
+		HostedURL:          invoice.HostedURL,
+		PeriodStart:        invoice.PeriodStart,
+		PeriodEnd:          invoice.PeriodEnd,
+		AttemptCount:       invoice.AttemptCount,
+		NextPaymentAttempt: invoice.NextPaymentAttempt,
+		CreatedAt:          invoice.CreatedAt,
+	}
+}
+
+type ListInvoicesRequest struct {
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+}
+
+type ListInvoicesResponse struct {
+	Body utils.ListResponseBody[InvoiceResponse]
+}
+
+func NewListInvoicesResponse(page *pagination.Page[*entities.Invoice]) *ListInvoicesResponse {
+	return &ListInvoicesResponse{Body: utils.NewListResponseBody(page, NewInvoiceResponse)}
+}
+
+type StripeWebhookRequest struct {
+	Signature string `header:"Stripe-Signature" required:"true" doc:"t=<unix time>,v1=<hex HMAC-SHA256 of t.body with the endpoint secret>"`
+	RawBody   []byte
+}
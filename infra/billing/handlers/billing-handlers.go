@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_subscription_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/get-subscription-use-case"
+	list_invoices_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/list-invoices-use-case"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/webhook"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type BillingHandlers struct {
+	getSubscriptionUseCase *get_subscription_use_case.GetSubscriptionUseCase
+	listInvoicesUseCase    *list_invoices_use_case.ListInvoicesUseCase
+	webhooks               *webhook.Receiver
+}
+
+func NewBillingHandlers(
+	getSubscriptionUseCase *get_subscription_use_case.GetSubscriptionUseCase,
+	listInvoicesUseCase *list_invoices_use_case.ListInvoicesUseCase,
+	webhooks *webhook.Receiver,
+) *BillingHandlers {
+	return &BillingHandlers{
+		getSubscriptionUseCase: getSubscriptionUseCase,
+		listInvoicesUseCase:    listInvoicesUseCase,
+		webhooks:               webhooks,
+	}
+}
+
+func (h *BillingHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-plans",
+		Method:      http.MethodGet,
+		Path:        "/billing/plans",
+		Summary:     "List the subscription plans and their features",
+		Tags:        []string{"Billing"},
+	}, h.ListPlans)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-subscription",
+		Method:      http.MethodGet,
+		Path:        "/billing/subscription",
+		Summary:     "Get the plan and subscription of the tenant",
+		Tags:        []string{"Billing"},
+	}, h.GetSubscription)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-invoices",
+		Method:      http.MethodGet,
+		Path:        "/billing/invoices",
+		Summary:     "List the invoices of the tenant",
+		Tags:        []string{"Billing"},
+	}, h.ListInvoices)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "stripe-webhook",
+		Method:        http.MethodPost,
+		Path:          "/webhooks/stripe",
+		Summary:       "Receive subscription and invoice changes from Stripe",
+		Description:   "Public endpoint authenticated by the Stripe-Signature header. Events are applied asynchronously, events received before are acknowledged without being applied again.",
+		Tags:          []string{"Billing"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.ReceiveStripeEvent)
+}
+
+func (h *BillingHandlers) ListPlans(ctx context.Context, input *struct{}) (*PlanListResponse, error) {
+	response := &PlanListResponse{}
+	response.Body.Items = make([]PlanResponse, 0, len(entities.Plans))
+	for _, plan := range entities.Plans {
+		response.Body.Items = append(response.Body.Items, NewPlanResponse(plan))
+	}
+	return response, nil
+}
+
+func (h *BillingHandlers) GetSubscription(ctx context.Context, input *struct{}) (*SubscriptionEnvelope, error) {
+	command, err := get_subscription_use_case.NewGetSubscriptionCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	entitlements, err := h.getSubscriptionUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SubscriptionEnvelope{Body: NewSubscriptionResponse(entitlements)}, nil
+}
+
+func (h *BillingHandlers) ListInvoices(ctx context.Context, input *ListInvoicesRequest) (*ListInvoicesResponse, error) {
+	command, err := list_invoices_use_case.NewListInvoicesCommand(authorization.TenantIDFromContext(ctx), input.PageSize, input.Cursor)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listInvoicesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListInvoicesResponse(page), nil
+}
+
+func (h *BillingHandlers) ReceiveStripeEvent(ctx context.Context, input *StripeWebhookRequest) (*struct{}, error) {
+	request := webhook.Request{Payload: input.RawBody, Headers: map[string]string{stripeSignatureHeader: input.Signature}}
+	if _, err := h.webhooks.Receive(ctx, StripeWebhookSource, request); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+
+	receive_billing_event_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/receive-billing-event-use-case"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/webhook"
+)
+
+// StripeWebhookSource names the deliveries of the Stripe webhook
+const StripeWebhookSource = "stripe"
+
+const stripeSignatureHeader = "Stripe-Signature"
+
+// NewStripeWebhookSource verifies the Stripe webhook with the provider when it is received, its event
+// is applied on the webhook worker
+func NewStripeWebhookSource(provider ports.BillingProvider, receiveBillingEventUseCase *receive_billing_event_use_case.ReceiveBillingEventUseCase) webhook.Source {
+	return webhook.Source{
+		Name: StripeWebhookSource,
+		Verify: func(_ context.Context, request webhook.Request) (string, error) {
+			return provider.VerifyEvent(request.Payload, request.Headers[stripeSignatureHeader])
+		},
+		Handle: func(ctx context.Context, request webhook.Request) error {
+			command, err := receive_billing_event_use_case.NewReceiveBillingEventCommand(request.Payload)
+			if err != nil {
+				return err
+			}
+			_, err = receiveBillingEventUseCase.Execute(ctx, command)
+			return err
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	check_feature_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/check-feature-use-case"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// EndpointFeatures maps Huma operation IDs to the plan feature they require. Operations left out
+// are part of every plan.
+var EndpointFeatures = map[string]entities.Feature{
+	"list-report-definitions": entities.FeatureReports,
+	"request-report":          entities.FeatureReports,
+	"list-reports":            entities.FeatureReports,
+	"get-report":              entities.FeatureReports,
+	"download-report":         entities.FeatureReports,
+
+	"request-similarity-check": entities.FeatureSimilarityChecks,
+	"list-similarity-checks":   entities.FeatureSimilarityChecks,
+	"get-similarity-check":     entities.FeatureSimilarityChecks,
+
+	"create-resource":           entities.FeatureResourceLibrary,
+	"list-resources":            entities.FeatureResourceLibrary,
+	"get-resource":              entities.FeatureResourceLibrary,
+	"update-resource":           entities.FeatureResourceLibrary,
+	"upload-resource-version":   entities.FeatureResourceLibrary,
+	"download-resource-version": entities.FeatureResourceLibrary,
+	"publish-resource":          entities.FeatureResourceLibrary,
+	"archive-resource":          entities.FeatureResourceLibrary,
+
+	"create-form":           entities.FeatureSurveys,
+	"list-forms":            entities.FeatureSurveys,
+	"list-assigned-forms":   entities.FeatureSurveys,
+	"get-form":              entities.FeatureSurveys,
+	"update-form":           entities.FeatureSurveys,
+	"open-form":             entities.FeatureSurveys,
+	"close-form":            entities.FeatureSurveys,
+	"submit-form-response":  entities.FeatureSurveys,
+	"list-form-responses":   entities.FeatureSurveys,
+	"export-form-responses": entities.FeatureSurveys,
+}
+
+// NewFeatureGateMiddleware returns a Huma middleware that enforces EndpointFeatures against the plan
+// of the tenant. It runs after the authorization middleware, which puts the tenant in the context.
+func NewFeatureGateMiddleware(checkFeatureUseCase *check_feature_use_case.CheckFeatureUseCase) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		feature, gated := EndpointFeatures[ctx.Operation().OperationID]
+		if !gated {
+			next(ctx)
+			return
+		}
+
+		command, err := check_feature_use_case.NewCheckFeatureCommand(authorization.TenantIDFromContext(ctx.Context()), feature)
+		if err == nil {
+			err = checkFeatureUseCase.Execute(ctx.Context(), command)
+		}
+		if err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+
+		next(ctx)
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"strconv"
+
+	check_plan_limit_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/check-plan-limit-use-case"
+	"github.com/nahualventure/class-backend/core/app/billing/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// EndpointLimits maps Huma operation IDs to the plan limit they consume. Uploads consume storage
+// by the size of the request, account creation consumes a staff seat since accounts are made
+// before they get a role. API calls are counted on every tenant request.
+var EndpointLimits = map[string]entities.Limit{
+	"batch-signup":       entities.LimitStaffAccounts,
+	"import-users":       entities.LimitStaffAccounts,
+	"legacy-create-user": entities.LimitStaffAccounts,
+
+	"upload-resource-version": entities.LimitStorageGB,
+	"send-direct-message":     entities.LimitStorageGB,
+	"post-class-message":      entities.LimitStorageGB,
+}
+
+// NewPlanLimitMiddleware returns a Huma middleware that enforces the usage limits of the plan of the
+// tenant. Like the feature gate, it runs after the authorization middleware.
+func NewPlanLimitMiddleware(checkPlanLimitUseCase *check_plan_limit_use_case.CheckPlanLimitUseCase) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		tenantID := authorization.TenantIDFromContext(ctx.Context())
+		if tenantID == "" {
+			next(ctx)
+			return
+		}
+
+		if err := checkPlanLimit(ctx, checkPlanLimitUseCase, tenantID, entities.LimitAPICallsPerDay, 1); err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+
+		if limit, limited := EndpointLimits[ctx.Operation().OperationID]; limited {
+			requested := int64(1)
+			if limit == entities.LimitStorageGB {
+				requested, _ = strconv.ParseInt(ctx.Header("Content-Length"), 10, 64)
+			}
+			if err := checkPlanLimit(ctx, checkPlanLimitUseCase, tenantID, limit, requested); err != nil {
+				utils.WriteApplicationError(ctx, err)
+				return
+			}
+		}
+
+		next(ctx)
+	}
+}
+
+func checkPlanLimit(ctx huma.Context, useCase *check_plan_limit_use_case.CheckPlanLimitUseCase, tenantID string, limit entities.Limit, requested int64) error {
+	command, err := check_plan_limit_use_case.NewCheckPlanLimitCommand(tenantID, limit, requested)
+	if err != nil {
+		return err
+	}
+	return useCase.Execute(ctx.Context(), command)
+}
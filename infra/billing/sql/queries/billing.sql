@@ -0,0 +1,81 @@
+-- name: GetSubscription :one
+SELECT * FROM subscriptions
+WHERE tenant_id = @tenant_id;
+
+-- name: GetSubscriptionByCustomer :one
+SELECT * FROM subscriptions
+WHERE customer_id = @customer_id
+ORDER BY synced_at DESC
+LIMIT 1;
+
+-- Events older than the stored copy leave it untouched
+-- name: UpsertSubscription :exec
+INSERT INTO subscriptions (
+    tenant_id, plan_code, status, customer_id, provider_id, current_period_end, cancel_at_period_end, synced_at, updated_at
+) VALUES (
+    @tenant_id, @plan_code, @status, @customer_id, @provider_id, @current_period_end, @cancel_at_period_end, @synced_at, @updated_at
+)
+ON CONFLICT (tenant_id) DO UPDATE SET
+    plan_code = EXCLUDED.plan_code,
+    status = EXCLUDED.status,
+    customer_id = EXCLUDED.customer_id,
+    provider_id = EXCLUDED.provider_id,
+    current_period_end = EXCLUDED.current_period_end,
+    cancel_at_period_end = EXCLUDED.cancel_at_period_end,
+    synced_at = EXCLUDED.synced_at,
+    updated_at = EXCLUDED.updated_at
+WHERE subscriptions.synced_at <= EXCLUDED.synced_at;
+
+-- name: UpsertInvoice :exec
+INSERT INTO invoices (
+    id, tenant_id, provider_id, number, status, currency, amount_due, amount_paid, hosted_url,
+    period_start, period_end, attempt_count, next_payment_attempt, synced_at, created_at
+) VALUES (
+    @id, @tenant_id, @provider_id, @number, @status, @currency, @amount_due, @amount_paid, @hosted_url,
+    @period_start, @period_end, @attempt_count, @next_payment_attempt, @synced_at, @created_at
+)
+ON CONFLICT (provider_id) DO UPDATE SET
+    number = EXCLUDED.number,
+    status = EXCLUDED.status,
+    amount_due = EXCLUDED.amount_due,
+    amount_paid = EXCLUDED.amount_paid,
+    hosted_url = EXCLUDED.hosted_url,
+    attempt_count = EXCLUDED.attempt_count,
+    next_payment_attempt = EXCLUDED.next_payment_attempt,
+    synced_at = EXCLUDED.synced_at
+WHERE invoices.synced_at <= EXCLUDED.synced_at;
+
+-- Claims the dunning notice of a payment attempt, no row comes back when it was already sent
+-- name: ClaimInvoiceDunning :one
+UPDATE invoices
+SET dunning_attempt = @attempt_count
+WHERE provider_id = @provider_id AND dunning_attempt < @attempt_count
+RETURNING id;
+
+-- Keyset page of the invoices newest first, the total counts every invoice of the tenant
+-- name: ListInvoices :many
+SELECT id, tenant_id, provider_id, number, status, currency, amount_due, amount_paid, hosted_url,
+       period_start, period_end, attempt_count, next_payment_attempt, synced_at, created_at,
+       (SELECT count(*) FROM invoices t WHERE t.tenant_id = @tenant_id) AS total
+FROM invoices
+WHERE tenant_id = @tenant_id
+  AND (sqlc.narg('after_created_at')::timestamptz IS NULL
+       OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT @page_limit;
+
+-- name: IncrementAPIUsage :one
+INSERT INTO api_usage (tenant_id, day, calls)
+VALUES (@tenant_id, @day, 1)
+ON CONFLICT (tenant_id, day) DO UPDATE SET calls = api_usage.calls + 1
+RETURNING calls;
+
+-- Library versions and message attachments are the files a tenant stores
+-- name: GetStorageUsage :one
+SELECT (
+    (SELECT COALESCE(SUM(rv.size), 0) FROM resource_versions rv WHERE rv.tenant_id = @tenant_id)
+    + (SELECT COALESCE(SUM(ma.size_bytes), 0)
+       FROM message_attachments ma
+       JOIN messages m ON m.id = ma.message_id
+       WHERE m.tenant_id = @tenant_id)
+)::BIGINT AS bytes;
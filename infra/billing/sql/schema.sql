@@ -0,0 +1,45 @@
+-- Billing. Subscriptions and invoices are copies of the billing provider records kept in sync from
+-- its webhooks, synced_at is the time of the provider event a row was last updated from.
+CREATE TABLE subscriptions (
+    tenant_id VARCHAR(255) PRIMARY KEY,
+    plan_code VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,            -- trialing, active, past_due, unpaid, incomplete, canceled
+    customer_id VARCHAR(255) NOT NULL,
+    provider_id VARCHAR(255) NOT NULL,
+    current_period_end TIMESTAMP WITH TIME ZONE,
+    cancel_at_period_end BOOLEAN NOT NULL DEFAULT FALSE,
+    synced_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_subscriptions_customer ON subscriptions(customer_id);
+
+-- Amounts are in the minor unit of the currency
+CREATE TABLE invoices (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    provider_id VARCHAR(255) NOT NULL UNIQUE,
+    number VARCHAR(100) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,            -- draft, open, paid, uncollectible, void
+    currency VARCHAR(3) NOT NULL,
+    amount_due BIGINT NOT NULL,
+    amount_paid BIGINT NOT NULL,
+    hosted_url TEXT,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    next_payment_attempt TIMESTAMP WITH TIME ZONE,
+    dunning_attempt INTEGER NOT NULL DEFAULT 0,   -- last failed payment attempt the contacts were told about
+    synced_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_invoices_tenant ON invoices(tenant_id, created_at DESC);
+
+-- API calls counted against the daily allowance of the plan, days are UTC
+CREATE TABLE api_usage (
+    tenant_id VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    calls BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant_id, day)
+);
@@ -0,0 +1,106 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresNonInstructionalDayRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresNonInstructionalDayRepository(dbInstance *pgxpool.Pool) ports.NonInstructionalDayRepository {
+	return &PostgresNonInstructionalDayRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresNonInstructionalDayRepository) Save(ctx context.Context, day *entities.NonInstructionalDay) error {
+	err := r.queries.UpsertNonInstructionalDay(ctx, db.UpsertNonInstructionalDayParams{
+		TenantID:  day.TenantID,
+		Day:       pgtype.Date{Time: day.Date, Valid: true},
+		Name:      day.Name,
+		Country:   day.Country,
+		CreatedBy: day.CreatedBy,
+		CreatedAt: pgtype.Timestamptz{Time: day.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresNonInstructionalDayRepository) AddMissing(ctx context.Context, days []*entities.NonInstructionalDay) ([]*entities.NonInstructionalDay, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	added := make([]*entities.NonInstructionalDay, 0, len(days))
+	for _, day := range days {
+		inserted, err := qtx.InsertNonInstructionalDayIfMissing(ctx, db.InsertNonInstructionalDayIfMissingParams{
+			TenantID:  day.TenantID,
+			Day:       pgtype.Date{Time: day.Date, Valid: true},
+			Name:      day.Name,
+			Country:   day.Country,
+			CreatedBy: day.CreatedBy,
+			CreatedAt: pgtype.Timestamptz{Time: day.CreatedAt, Valid: true},
+		})
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		if inserted > 0 {
+			added = append(added, day)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return added, nil
+}
+
+func (r *PostgresNonInstructionalDayRepository) Delete(ctx context.Context, tenantID string, date time.Time) (bool, error) {
+	deleted, err := r.queries.DeleteNonInstructionalDay(ctx, db.DeleteNonInstructionalDayParams{
+		TenantID: tenantID,
+		Day:      pgtype.Date{Time: entities.CivilDate(date), Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return deleted > 0, nil
+}
+
+func (r *PostgresNonInstructionalDayRepository) List(ctx context.Context, tenantID string, from time.Time, to time.Time) ([]*entities.NonInstructionalDay, error) {
+	rows, err := r.queries.ListNonInstructionalDays(ctx, db.ListNonInstructionalDaysParams{
+		TenantID: tenantID,
+		FromDay:  pgtype.Date{Time: entities.CivilDate(from), Valid: true},
+		ToDay:    pgtype.Date{Time: entities.CivilDate(to), Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	days := make([]*entities.NonInstructionalDay, 0, len(rows))
+	for _, row := range rows {
+		day, err := entities.NewNonInstructionalDay(row.TenantID, row.Day.Time, row.Name, row.Country, row.CreatedBy, row.CreatedAt.Time)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
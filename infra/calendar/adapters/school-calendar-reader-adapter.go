@@ -0,0 +1,45 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	timezonePorts "github.com/nahualventure/class-backend/core/app/timezone/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+// SchoolCalendarReader reads the non-instructional days in the time zone the tenant picked, the days a
+// range of instants touches depend on it
+type SchoolCalendarReader struct {
+	dayRepo      ports.NonInstructionalDayRepository
+	timeZoneRepo timezonePorts.TimeZoneRepository
+}
+
+func NewSchoolCalendarReader(dayRepo ports.NonInstructionalDayRepository, timeZoneRepo timezonePorts.TimeZoneRepository) ports.SchoolCalendarReader {
+	return &SchoolCalendarReader{
+		dayRepo:      dayRepo,
+		timeZoneRepo: timeZoneRepo,
+	}
+}
+
+func (r *SchoolCalendarReader) SchoolCalendar(ctx context.Context, tenantID string, from time.Time, to time.Time) (*entities.SchoolCalendar, error) {
+	setting, err := r.timeZoneRepo.FindSetting(ctx, tenantID, "")
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	loc := time.UTC
+	if setting != nil {
+		if loc, err = utils.LoadLocation(setting.TimeZone); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+	}
+
+	days, err := r.dayRepo.List(ctx, tenantID, from.In(loc), to.In(loc))
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return entities.NewSchoolCalendar(days, loc), nil
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type NonInstructionalDayResponse struct {
+	Date      string    `json:"date" format:"date"`
+	Name      string    `json:"name"`
+	Country   string    `json:"country,omitempty" doc:"Country of the holiday set the day was imported from, absent for days added by hand"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewNonInstructionalDayResponse(day *entities.NonInstructionalDay, loc *time.Location) NonInstructionalDayResponse {
+	return NonInstructionalDayResponse{
+		Date:      day.Date.Format(entities.DateLayout),
+		Name:      day.Name,
+		Country:   day.Country,
+		CreatedBy: day.CreatedBy,
+		CreatedAt: utils.InLocation(day.CreatedAt, loc),
+	}
+}
+
+func NewNonInstructionalDayResponses(days []*entities.NonInstructionalDay, loc *time.Location) []NonInstructionalDayResponse {
+	responses := make([]NonInstructionalDayResponse, 0, len(days))
+	for _, day := range days {
+		responses = append(responses, NewNonInstructionalDayResponse(day, loc))
+	}
+	return responses
+}
+
+type ListNonInstructionalDaysRequest struct {
+	From string `query:"from" format:"date" required:"true" doc:"First day of the range"`
+	To   string `query:"to" format:"date" required:"true" doc:"Last day of the range, at most 2 years after from"`
+}
+
+type NonInstructionalDaysResponse struct {
+	Body struct {
+		Days []NonInstructionalDayResponse `json:"days"`
+	}
+}
+
+type AddNonInstructionalDayRequest struct {
+	Date string `path:"date" format:"date"`
+	Body struct {
+		Name string `json:"name" minLength:"1" maxLength:"200" example:"Teacher training day"`
+	}
+}
+
+type NonInstructionalDayEnvelope struct {
+	Body NonInstructionalDayResponse
+}
+
+type RemoveNonInstructionalDayRequest struct {
+	Date string `path:"date" format:"date"`
+}
+
+type ImportHolidaysRequest struct {
+	Body struct {
+		Country string `json:"country" minLength:"2" maxLength:"2" example:"GT" doc:"ISO 3166 alpha-2 code of a country with a built-in holiday set"`
+		Year    int    `json:"year" minimum:"2000" maximum:"2100" example:"2026"`
+	}
+}
+
+type HolidayResponse struct {
+	Date string `json:"date" format:"date"`
+	Name string `json:"name"`
+}
+
+type HolidayImportResponse struct {
+	Body struct {
+		Country  string                        `json:"country"`
+		Year     int                           `json:"year"`
+		Holidays []HolidayResponse             `json:"holidays" doc:"Every holiday of the set"`
+		Added    []NonInstructionalDayResponse `json:"added" doc:"Holidays that were not in the calendar yet"`
+	}
+}
+
+func NewHolidayImportResponse(result *entities.HolidayImport, loc *time.Location) *HolidayImportResponse {
+	response := &HolidayImportResponse{}
+	response.Body.Country = result.Country
+	response.Body.Year = result.Year
+	response.Body.Holidays = make([]HolidayResponse, 0, len(result.Holidays))
+	for _, holiday := range result.Holidays {
+		response.Body.Holidays = append(response.Body.Holidays, HolidayResponse{
+			Date: holiday.Date.Format(entities.DateLayout),
+			Name: holiday.Name,
+		})
+	}
+	response.Body.Added = NewNonInstructionalDayResponses(result.Added, loc)
+	return response
+}
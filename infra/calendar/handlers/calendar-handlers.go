@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	add_non_instructional_day_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/add-non-instructional-day-use-case"
+	import_holidays_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/import-holidays-use-case"
+	list_non_instructional_days_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/list-non-instructional-days-use-case"
+	remove_non_instructional_day_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/remove-non-instructional-day-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type CalendarHandlers struct {
+	addNonInstructionalDayUseCase    *add_non_instructional_day_use_case.AddNonInstructionalDayUseCase
+	removeNonInstructionalDayUseCase *remove_non_instructional_day_use_case.RemoveNonInstructionalDayUseCase
+	listNonInstructionalDaysUseCase  *list_non_instructional_days_use_case.ListNonInstructionalDaysUseCase
+	importHolidaysUseCase            *import_holidays_use_case.ImportHolidaysUseCase
+}
+
+func NewCalendarHandlers(
+	addNonInstructionalDayUseCase *add_non_instructional_day_use_case.AddNonInstructionalDayUseCase,
+	removeNonInstructionalDayUseCase *remove_non_instructional_day_use_case.RemoveNonInstructionalDayUseCase,
+	listNonInstructionalDaysUseCase *list_non_instructional_days_use_case.ListNonInstructionalDaysUseCase,
+	importHolidaysUseCase *import_holidays_use_case.ImportHolidaysUseCase,
+) *CalendarHandlers {
+	return &CalendarHandlers{
+		addNonInstructionalDayUseCase:    addNonInstructionalDayUseCase,
+		removeNonInstructionalDayUseCase: removeNonInstructionalDayUseCase,
+		listNonInstructionalDaysUseCase:  listNonInstructionalDaysUseCase,
+		importHolidaysUseCase:            importHolidaysUseCase,
+	}
+}
+
+func (h *CalendarHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-non-instructional-days",
+		Method:      http.MethodGet,
+		Path:        "/calendar/non-instructional-days",
+		Summary:     "List the days without classes in a range",
+		Tags:        []string{"School calendar"},
+	}, h.ListNonInstructionalDays)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "add-non-instructional-day",
+		Method:      http.MethodPut,
+		Path:        "/calendar/non-instructional-days/{date}",
+		Summary:     "Mark a day as without classes",
+		Description: "Class sessions on the day are cancelled, no attendance is expected and late penalties do not count it.",
+		Tags:        []string{"School calendar"},
+	}, h.AddNonInstructionalDay)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "remove-non-instructional-day",
+		Method:        http.MethodDelete,
+		Path:          "/calendar/non-instructional-days/{date}",
+		Summary:       "Hold classes on a day again",
+		Tags:          []string{"School calendar"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.RemoveNonInstructionalDay)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "import-holidays",
+		Method:      http.MethodPost,
+		Path:        "/calendar/holiday-imports",
+		Summary:     "Add the public holidays of a country for a year",
+		Description: "Days already in the calendar are kept, importing the same year again adds nothing.",
+		Tags:        []string{"School calendar"},
+	}, h.ImportHolidays)
+}
+
+func (h *CalendarHandlers) ListNonInstructionalDays(ctx context.Context, input *ListNonInstructionalDaysRequest) (*NonInstructionalDaysResponse, error) {
+	command, err := list_non_instructional_days_use_case.NewListNonInstructionalDaysCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.From,
+		input.To,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	days, err := h.listNonInstructionalDaysUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &NonInstructionalDaysResponse{}
+	response.Body.Days = NewNonInstructionalDayResponses(days, utils.LocationFromContext(ctx))
+	return response, nil
+}
+
+func (h *CalendarHandlers) AddNonInstructionalDay(ctx context.Context, input *AddNonInstructionalDayRequest) (*NonInstructionalDayEnvelope, error) {
+	command, err := add_non_instructional_day_use_case.NewAddNonInstructionalDayCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Date,
+		input.Body.Name,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	day, err := h.addNonInstructionalDayUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &NonInstructionalDayEnvelope{Body: NewNonInstructionalDayResponse(day, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *CalendarHandlers) RemoveNonInstructionalDay(ctx context.Context, input *RemoveNonInstructionalDayRequest) (*struct{}, error) {
+	command, err := remove_non_instructional_day_use_case.NewRemoveNonInstructionalDayCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Date,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.removeNonInstructionalDayUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
+
+func (h *CalendarHandlers) ImportHolidays(ctx context.Context, input *ImportHolidaysRequest) (*HolidayImportResponse, error) {
+	command, err := import_holidays_use_case.NewImportHolidaysCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Country,
+		input.Body.Year,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	result, err := h.importHolidaysUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewHolidayImportResponse(result, utils.LocationFromContext(ctx)), nil
+}
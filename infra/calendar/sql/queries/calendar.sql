@@ -0,0 +1,23 @@
+-- name: UpsertNonInstructionalDay :exec
+INSERT INTO non_instructional_days (tenant_id, day, name, country, created_by, created_at)
+VALUES (@tenant_id, @day, @name, @country, @created_by, @created_at)
+ON CONFLICT (tenant_id, day) DO UPDATE SET
+    name = EXCLUDED.name,
+    country = EXCLUDED.country,
+    created_by = EXCLUDED.created_by,
+    created_at = EXCLUDED.created_at;
+
+-- name: InsertNonInstructionalDayIfMissing :execrows
+INSERT INTO non_instructional_days (tenant_id, day, name, country, created_by, created_at)
+VALUES (@tenant_id, @day, @name, @country, @created_by, @created_at)
+ON CONFLICT (tenant_id, day) DO NOTHING;
+
+-- name: DeleteNonInstructionalDay :execrows
+DELETE FROM non_instructional_days
+WHERE tenant_id = @tenant_id AND day = @day;
+
+-- name: ListNonInstructionalDays :many
+SELECT tenant_id, day, name, country, created_by, created_at
+FROM non_instructional_days
+WHERE tenant_id = @tenant_id AND day BETWEEN @from_day AND @to_day
+ORDER BY day;
@@ -0,0 +1,11 @@
+-- Days without classes in the calendar of a tenant. Dates are civil dates in the zone of the school,
+-- country is set on holidays imported from a country holiday set.
+CREATE TABLE non_instructional_days (
+    tenant_id VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    country VARCHAR(2) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, day)
+);
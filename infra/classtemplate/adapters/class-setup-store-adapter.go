@@ -0,0 +1,240 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/entities"
+	classTemplateErrors "github.com/nahualventure/class-backend/core/app/classtemplate/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresClassSetupStore reads and writes the tables of the grading, enrollment and standards modules
+// a class is set up in. Assignments have no table of their own, a class has the assignments it has
+// due dates or standards for.
+type PostgresClassSetupStore struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresClassSetupStore(dbInstance *pgxpool.Pool) ports.ClassSetupStore {
+	return &PostgresClassSetupStore{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresClassSetupStore) Read(ctx context.Context, tenantID string, classID string) (*entities.ClassSnapshot, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	snapshot := &entities.ClassSnapshot{TenantID: tenantID, ClassID: classID}
+
+	requiresApproval, err := s.queries.GetGradingPolicy(ctx, db.GetGradingPolicyParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, appErrors.PropagateError(err)
+	}
+	snapshot.RequiresApproval = requiresApproval
+
+	capacity, err := s.queries.GetTemplateClassCapacity(ctx, db.GetTemplateClassCapacityParams{TenantID: tenantID, ClassID: pgClassID})
+	if err == nil {
+		value := int(capacity)
+		snapshot.Capacity = &value
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	meetings, err := s.queries.ListClassMeetings(ctx, db.ListClassMeetingsParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	for _, meeting := range meetings {
+		snapshot.Meetings = append(snapshot.Meetings, entities.TemplateMeeting{
+			Weekday:     time.Weekday(meeting.Weekday),
+			StartMinute: int(meeting.StartMinute),
+			EndMinute:   int(meeting.EndMinute),
+		})
+	}
+
+	assignments := make(map[string]*entities.ClassAssignment)
+	var order []string
+	assignment := func(assignmentID string) *entities.ClassAssignment {
+		if _, ok := assignments[assignmentID]; !ok {
+			assignments[assignmentID] = &entities.ClassAssignment{AssignmentID: assignmentID}
+			order = append(order, assignmentID)
+		}
+		return assignments[assignmentID]
+	}
+
+	deadlines, err := s.queries.ListAssignmentDeadlines(ctx, db.ListAssignmentDeadlinesParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	for _, deadline := range deadlines {
+		dueAt := deadline.DueAt.Time
+		a := assignment(deadline.AssignmentID.String())
+		a.DueAt = &dueAt
+		a.PenaltyPercentPerDay = deadline.PenaltyPercentPerDay
+		a.CutoffDays = int(deadline.CutoffDays)
+	}
+
+	tags, err := s.queries.ListTemplateClassStandards(ctx, db.ListTemplateClassStandardsParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	for _, tag := range tags {
+		a := assignment(tag.AssignmentID)
+		a.Standards = append(a.Standards, entities.StandardRef{Framework: tag.Framework, Code: tag.Code})
+	}
+
+	for _, assignmentID := range order {
+		snapshot.Assignments = append(snapshot.Assignments, *assignments[assignmentID])
+	}
+
+	return snapshot, nil
+}
+
+func (s *PostgresClassSetupStore) ResolveStandards(ctx context.Context, tenantID string, refs []entities.StandardRef) (map[entities.StandardRef]string, error) {
+	resolved := make(map[entities.StandardRef]string, len(refs))
+	if len(refs) == 0 {
+		return resolved, nil
+	}
+
+	wanted := make(map[entities.StandardRef]bool, len(refs))
+	frameworks := make([]string, 0, len(refs))
+	codes := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		wanted[ref] = true
+		frameworks = append(frameworks, ref.Framework)
+		codes = append(codes, ref.Code)
+	}
+
+	rows, err := s.queries.ResolveTemplateStandards(ctx, db.ResolveTemplateStandardsParams{
+		TenantID:   tenantID,
+		Frameworks: frameworks,
+		Codes:      codes,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	for _, row := range rows {
+		ref := entities.StandardRef{Framework: row.Framework, Code: row.Code}
+		if wanted[ref] {
+			resolved[ref] = row.ID
+		}
+	}
+
+	return resolved, nil
+}
+
+func (s *PostgresClassSetupStore) Write(ctx context.Context, class *entities.ClassSnapshot, createdBy string) error {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(class.ClassID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+	exists, err := qtx.ClassSetupExists(ctx, db.ClassSetupExistsParams{TenantID: class.TenantID, ClassID: pgClassID})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if exists {
+		return classTemplateErrors.NewClassNotEmptyError(class.ClassID)
+	}
+
+	if class.RequiresApproval {
+		err := qtx.UpsertGradingPolicy(ctx, db.UpsertGradingPolicyParams{
+			TenantID:         class.TenantID,
+			ClassID:          pgClassID,
+			RequiresApproval: true,
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if class.Capacity != nil {
+		err := qtx.UpsertClassCapacity(ctx, db.UpsertClassCapacityParams{
+			TenantID: class.TenantID,
+			ClassID:  pgClassID,
+			Capacity: int32(*class.Capacity),
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	for _, meeting := range class.Meetings {
+		err := qtx.CreateClassMeeting(ctx, db.CreateClassMeetingParams{
+			TenantID:    class.TenantID,
+			ClassID:     pgClassID,
+			Weekday:     int16(meeting.Weekday),
+			StartMinute: int32(meeting.StartMinute),
+			EndMinute:   int32(meeting.EndMinute),
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	now := pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true}
+	for _, assignment := range class.Assignments {
+		var pgAssignmentID pgtype.UUID
+		if err := pgAssignmentID.Scan(assignment.AssignmentID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+
+		if assignment.DueAt != nil {
+			err := qtx.UpsertAssignmentDeadline(ctx, db.UpsertAssignmentDeadlineParams{
+				TenantID:             class.TenantID,
+				ClassID:              pgClassID,
+				AssignmentID:         pgAssignmentID,
+				DueAt:                pgtype.Timestamptz{Time: *assignment.DueAt, Valid: true},
+				PenaltyPercentPerDay: assignment.PenaltyPercentPerDay,
+				CutoffDays:           int32(assignment.CutoffDays),
+			})
+			if err != nil {
+				return appErrors.PropagateError(err)
+			}
+		}
+
+		for _, standardID := range assignment.StandardIDs {
+			var pgStandardID pgtype.UUID
+			if err := pgStandardID.Scan(standardID); err != nil {
+				return appErrors.PropagateError(err)
+			}
+			err := qtx.InsertAssignmentStandard(ctx, db.InsertAssignmentStandardParams{
+				TenantID:     class.TenantID,
+				ClassID:      pgClassID,
+				AssignmentID: pgAssignmentID,
+				StandardID:   pgStandardID,
+				TaggedBy:     createdBy,
+				TaggedAt:     now,
+			})
+			if err != nil {
+				return appErrors.PropagateError(err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// storedSetup is the JSONB layout of the setup of a template, due dates are kept in minutes after the
+// start of the term
+type storedSetup struct {
+	RequiresApproval bool               `json:"requires_approval"`
+	Capacity         *int               `json:"capacity,omitempty"`
+	Meetings         []storedMeeting    `json:"meetings"`
+	Assignments      []storedAssignment `json:"assignments"`
+}
+
+type storedMeeting struct {
+	Weekday     int `json:"weekday"`
+	StartMinute int `json:"start_minute"`
+	EndMinute   int `json:"end_minute"`
+}
+
+type storedAssignment struct {
+	DueAfterMinutes      *int64           `json:"due_after_minutes,omitempty"`
+	PenaltyPercentPerDay float64          `json:"penalty_percent_per_day"`
+	CutoffDays           int              `json:"cutoff_days"`
+	Standards            []storedStandard `json:"standards"`
+}
+
+type storedStandard struct {
+	Framework string `json:"framework"`
+	Code      string `json:"code"`
+}
+
+type PostgresClassTemplateRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresClassTemplateRepository(dbInstance *pgxpool.Pool) ports.ClassTemplateRepository {
+	return &PostgresClassTemplateRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresClassTemplateRepository) Create(ctx context.Context, template *entities.ClassTemplate) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(template.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	setup, err := json.Marshal(toStoredSetup(template.Setup))
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize class template setup", err)
+	}
+
+	err = r.queries.CreateClassTemplate(ctx, db.CreateClassTemplateParams{
+		ID:          pgID,
+		TenantID:    template.TenantID,
+		Name:        template.Name,
+		Description: emptyToNil(template.Description),
+		Setup:       setup,
+		Shared:      template.Shared,
+		CreatedBy:   template.CreatedBy,
+		CreatedAt:   pgtype.Timestamptz{Time: template.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresClassTemplateRepository) FindByID(ctx context.Context, tenantID string, templateID string) (*entities.ClassTemplate, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(templateID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetClassTemplate(ctx, db.GetClassTemplateParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toClassTemplate(row)
+}
+
+func (r *PostgresClassTemplateRepository) List(ctx context.Context, tenantID string) ([]*entities.ClassTemplate, error) {
+	rows, err := r.queries.ListClassTemplates(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	templates := make([]*entities.ClassTemplate, 0, len(rows))
+	for _, row := range rows {
+		template, err := toClassTemplate(row)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+func (r *PostgresClassTemplateRepository) Delete(ctx context.Context, tenantID string, templateID string) (bool, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(templateID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	deleted, err := r.queries.DeleteClassTemplate(ctx, db.DeleteClassTemplateParams{TenantID: tenantID, ID: pgID})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return deleted > 0, nil
+}
+
+func (r *PostgresClassTemplateRepository) SetShared(ctx context.Context, tenantID string, templateID string, shared bool) (bool, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(templateID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	updated, err := r.queries.SetClassTemplateShared(ctx, db.SetClassTemplateSharedParams{Shared: shared, TenantID: tenantID, ID: pgID})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return updated > 0, nil
+}
+
+func toClassTemplate(row db.ClassTemplate) (*entities.ClassTemplate, error) {
+	var stored storedSetup
+	if err := json.Unmarshal(row.Setup, &stored); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to deserialize class template setup", err)
+	}
+
+	return entities.NewClassTemplate(row.ID.String(), row.TenantID, row.Name, valueOrEmpty(row.Description), fromStoredSetup(stored),
+		row.Shared, row.CreatedBy, row.CreatedAt.Time)
+}
+
+func toStoredSetup(setup entities.ClassSetup) storedSetup {
+	stored := storedSetup{
+		RequiresApproval: setup.RequiresApproval,
+		Capacity:         setup.Capacity,
+		Meetings:         make([]storedMeeting, len(setup.Meetings)),
+		Assignments:      make([]storedAssignment, len(setup.Assignments)),
+	}
+	for i, meeting := range setup.Meetings {
+		stored.Meetings[i] = storedMeeting{Weekday: int(meeting.Weekday), StartMinute: meeting.StartMinute, EndMinute: meeting.EndMinute}
+	}
+	for i, assignment := range setup.Assignments {
+		storedAssignment := storedAssignment{
+			PenaltyPercentPerDay: assignment.PenaltyPercentPerDay,
+			CutoffDays:           assignment.CutoffDays,
+			Standards:            make([]storedStandard, len(assignment.Standards)),
+		}
+		if assignment.DueAfter != nil {
+			minutes := int64(*assignment.DueAfter / time.Minute)
+			storedAssignment.DueAfterMinutes = &minutes
+		}
+		for j, ref := range assignment.Standards {
+			storedAssignment.Standards[j] = storedStandard{Framework: ref.Framework, Code: ref.Code}
+		}
+		stored.Assignments[i] = storedAssignment
+	}
+	return stored
+}
+
+func fromStoredSetup(stored storedSetup) entities.ClassSetup {
+	setup := entities.ClassSetup{
+		RequiresApproval: stored.RequiresApproval,
+		Capacity:         stored.Capacity,
+		Meetings:         make([]entities.TemplateMeeting, len(stored.Meetings)),
+		Assignments:      make([]entities.TemplateAssignment, len(stored.Assignments)),
+	}
+	for i, meeting := range stored.Meetings {
+		setup.Meetings[i] = entities.TemplateMeeting{
+			Weekday:     time.Weekday(meeting.Weekday),
+			StartMinute: meeting.StartMinute,
+			EndMinute:   meeting.EndMinute,
+		}
+	}
+	for i, storedAssignment := range stored.Assignments {
+		assignment := entities.TemplateAssignment{
+			PenaltyPercentPerDay: storedAssignment.PenaltyPercentPerDay,
+			CutoffDays:           storedAssignment.CutoffDays,
+			Standards:            make([]entities.StandardRef, len(storedAssignment.Standards)),
+		}
+		if storedAssignment.DueAfterMinutes != nil {
+			dueAfter := time.Duration(*storedAssignment.DueAfterMinutes) * time.Minute
+			assignment.DueAfter = &dueAfter
+		}
+		for j, ref := range storedAssignment.Standards {
+			assignment.Standards[j] = entities.StandardRef{Framework: ref.Framework, Code: ref.Code}
+		}
+		setup.Assignments[i] = assignment
+	}
+	return setup
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
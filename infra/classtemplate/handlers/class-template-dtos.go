@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/classtemplate/domain/entities"
+)
+
+type StandardRefBody struct {
+	Framework string `json:"framework"`
+	Code      string `json:"code"`
+}
+
+func NewStandardRefBodies(refs []entities.StandardRef) []StandardRefBody {
+	bodies := make([]StandardRefBody, 0, len(refs))
+	for _, ref := range refs {
+		bodies = append(bodies, StandardRefBody{Framework: ref.Framework, Code: ref.Code})
+	}
+	return bodies
+}
+
+type TemplateMeetingResponse struct {
+	Weekday  string `json:"weekday" enum:"monday,tuesday,wednesday,thursday,friday,saturday,sunday"`
+	StartsAt string `json:"starts_at" example:"09:00"`
+	EndsAt   string `json:"ends_at" example:"10:30"`
+}
+
+func NewTemplateMeetingResponses(meetings []entities.TemplateMeeting) []TemplateMeetingResponse {
+	responses := make([]TemplateMeetingResponse, 0, len(meetings))
+	for _, meeting := range meetings {
+		responses = append(responses, TemplateMeetingResponse{
+			Weekday:  strings.ToLower(meeting.Weekday.String()),
+			StartsAt: clock(meeting.StartMinute),
+			EndsAt:   clock(meeting.EndMinute),
+		})
+	}
+	return responses
+}
+
+func clock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+type TemplateAssignmentResponse struct {
+	DueAfterMinutes      *int64            `json:"due_after_minutes,omitempty" doc:"Minutes from the start of the term to the due date, absent when the assignment has no due date"`
+	PenaltyPercentPerDay float64           `json:"penalty_percent_per_day"`
+	CutoffDays           int               `json:"cutoff_days"`
+	Standards            []StandardRefBody `json:"standards"`
+}
+
+type ClassTemplateResponse struct {
+	ID               string                       `json:"id"`
+	Name             string                       `json:"name"`
+	Description      string                       `json:"description,omitempty"`
+	Shared           bool                         `json:"shared" doc:"Listed and instantiated by every tenant"`
+	Owned            bool                         `json:"owned" doc:"Saved by the tenant, only owned templates can be deleted"`
+	CreatedBy        string                       `json:"created_by,omitempty" doc:"Absent on templates shared by other tenants"`
+	CreatedAt        time.Time                    `json:"created_at"`
+	RequiresApproval bool                         `json:"requires_approval"`
+	Capacity         *int                         `json:"capacity,omitempty"`
+	Meetings         []TemplateMeetingResponse    `json:"meetings"`
+	Assignments      []TemplateAssignmentResponse `json:"assignments"`
+}
+
+func NewClassTemplateResponse(template *entities.ClassTemplate, tenantID string) ClassTemplateResponse {
+	response := ClassTemplateResponse{
+		ID:               template.ID,
+		Name:             template.Name,
+		Description:      template.Description,
+		Shared:           template.Shared,
+		Owned:            template.TenantID == tenantID,
+		CreatedAt:        template.CreatedAt,
+		RequiresApproval: template.Setup.RequiresApproval,
+		Capacity:         template.Setup.Capacity,
+		Meetings:         NewTemplateMeetingResponses(template.Setup.Meetings),
+		Assignments:      make([]TemplateAssignmentResponse, 0, len(template.Setup.Assignments)),
+	}
+	if response.Owned {
+		response.CreatedBy = template.CreatedBy
+	}
+	for _, assignment := range template.Setup.Assignments {
+		assignmentResponse := TemplateAssignmentResponse{
+			PenaltyPercentPerDay: assignment.PenaltyPercentPerDay,
+			CutoffDays:           assignment.CutoffDays,
+			Standards:            NewStandardRefBodies(assignment.Standards),
+		}
+		if assignment.DueAfter != nil {
+			minutes := int64(*assignment.DueAfter / time.Minute)
+			assignmentResponse.DueAfterMinutes = &minutes
+		}
+		response.Assignments = append(response.Assignments, assignmentResponse)
+	}
+	return response
+}
+
+type SaveClassTemplateRequest struct {
+	Body struct {
+		ClassID     string `json:"class_id" format:"uuid" doc:"Class whose settings, schedule and assignments are saved, its students are not"`
+		TermID      string `json:"term_id" format:"uuid" doc:"Term the class is taught in, due dates are saved relative to its start"`
+		Name        string `json:"name" maxLength:"200"`
+		Description string `json:"description,omitempty" maxLength:"2000"`
+	}
+}
+
+type ClassTemplateEnvelope struct {
+	Body ClassTemplateResponse
+}
+
+type ListClassTemplatesResponse struct {
+	Body struct {
+		Items []ClassTemplateResponse `json:"items"`
+	}
+}
+
+type GetClassTemplateRequest struct {
+	TemplateID string `path:"templateId" format:"uuid"`
+}
+
+type DeleteClassTemplateRequest struct {
+	TemplateID string `path:"templateId" format:"uuid"`
+}
+
+type InstantiateClassTemplateRequest struct {
+	TemplateID string `path:"templateId" format:"uuid"`
+	Body       struct {
+		ClassID string `json:"class_id" format:"uuid" doc:"New class to set up, classes already set up are refused"`
+		TermID  string `json:"term_id" format:"uuid" doc:"Term of the new class, due dates are laid out from its start"`
+	}
+}
+
+type InstantiatedAssignmentResponse struct {
+	AssignmentID         string            `json:"assignment_id"`
+	DueAt                *time.Time        `json:"due_at,omitempty"`
+	PenaltyPercentPerDay float64           `json:"penalty_percent_per_day"`
+	CutoffDays           int               `json:"cutoff_days"`
+	Standards            []StandardRefBody `json:"standards"`
+}
+
+type InstantiatedClassResponse struct {
+	ClassID             string                           `json:"class_id"`
+	RequiresApproval    bool                             `json:"requires_approval"`
+	Capacity            *int                             `json:"capacity,omitempty"`
+	Meetings            []TemplateMeetingResponse        `json:"meetings"`
+	Assignments         []InstantiatedAssignmentResponse `json:"assignments"`
+	UnresolvedStandards []StandardRefBody                `json:"unresolved_standards" doc:"Standards of the template the tenant does not have, they were left off the assignments"`
+}
+
+type InstantiatedClassEnvelope struct {
+	Body InstantiatedClassResponse
+}
+
+func NewInstantiatedClassEnvelope(class *entities.ClassSnapshot, unresolved []entities.StandardRef) *InstantiatedClassEnvelope {
+	response := InstantiatedClassResponse{
+		ClassID:             class.ClassID,
+		RequiresApproval:    class.RequiresApproval,
+		Capacity:            class.Capacity,
+		Meetings:            NewTemplateMeetingResponses(class.Meetings),
+		Assignments:         make([]InstantiatedAssignmentResponse, 0, len(class.Assignments)),
+		UnresolvedStandards: NewStandardRefBodies(unresolved),
+	}
+	for _, assignment := range class.Assignments {
+		response.Assignments = append(response.Assignments, InstantiatedAssignmentResponse{
+			AssignmentID:         assignment.AssignmentID,
+			DueAt:                assignment.DueAt,
+			PenaltyPercentPerDay: assignment.PenaltyPercentPerDay,
+			CutoffDays:           assignment.CutoffDays,
+			Standards:            NewStandardRefBodies(assignment.Standards),
+		})
+	}
+	return &InstantiatedClassEnvelope{Body: response}
+}
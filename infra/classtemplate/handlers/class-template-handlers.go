@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	delete_class_template_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/delete-class-template-use-case"
+	get_class_template_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/get-class-template-use-case"
+	instantiate_class_template_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/instantiate-class-template-use-case"
+	list_class_templates_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/list-class-templates-use-case"
+	save_class_template_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/save-class-template-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ClassTemplateHandlers struct {
+	saveClassTemplateUseCase        *save_class_template_use_case.SaveClassTemplateUseCase
+	listClassTemplatesUseCase       *list_class_templates_use_case.ListClassTemplatesUseCase
+	getClassTemplateUseCase         *get_class_template_use_case.GetClassTemplateUseCase
+	deleteClassTemplateUseCase      *delete_class_template_use_case.DeleteClassTemplateUseCase
+	instantiateClassTemplateUseCase *instantiate_class_template_use_case.InstantiateClassTemplateUseCase
+}
+
+func NewClassTemplateHandlers(
+	saveClassTemplateUseCase *save_class_template_use_case.SaveClassTemplateUseCase,
+	listClassTemplatesUseCase *list_class_templates_use_case.ListClassTemplatesUseCase,
+	getClassTemplateUseCase *get_class_template_use_case.GetClassTemplateUseCase,
+	deleteClassTemplateUseCase *delete_class_template_use_case.DeleteClassTemplateUseCase,
+	instantiateClassTemplateUseCase *instantiate_class_template_use_case.InstantiateClassTemplateUseCase,
+) *ClassTemplateHandlers {
+	return &ClassTemplateHandlers{
+		saveClassTemplateUseCase:        saveClassTemplateUseCase,
+		listClassTemplatesUseCase:       listClassTemplatesUseCase,
+		getClassTemplateUseCase:         getClassTemplateUseCase,
+		deleteClassTemplateUseCase:      deleteClassTemplateUseCase,
+		instantiateClassTemplateUseCase: instantiateClassTemplateUseCase,
+	}
+}
+
+func (h *ClassTemplateHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "save-class-template",
+		Method:        http.MethodPost,
+		Path:          "/class-templates",
+		Summary:       "Save a class as a template",
+		Description:   "Saves the grading policy, capacity, weekly schedule and assignments of the class with their late policies and standards. Students, submissions and grades are not saved.",
+		Tags:          []string{"Class templates"},
+		DefaultStatus: http.StatusCreated,
+	}, h.SaveClassTemplate)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-class-templates",
+		Method:      http.MethodGet,
+		Path:        "/class-templates",
+		Summary:     "List class templates",
+		Description: "Lists the templates of the tenant and the ones shared with every tenant.",
+		Tags:        []string{"Class templates"},
+	}, h.ListClassTemplates)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-class-template",
+		Method:      http.MethodGet,
+		Path:        "/class-templates/{templateId}",
+		Summary:     "Get a class template",
+		Tags:        []string{"Class templates"},
+	}, h.GetClassTemplate)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-class-template",
+		Method:        http.MethodDelete,
+		Path:          "/class-templates/{templateId}",
+		Summary:       "Delete a class template",
+		Description:   "Classes instantiated from the template keep their setup.",
+		Tags:          []string{"Class templates"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.DeleteClassTemplate)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "instantiate-class-template",
+		Method:        http.MethodPost,
+		Path:          "/class-templates/{templateId}/instantiate",
+		Summary:       "Set up a new class from a template",
+		Description:   "Sets the class up in one go with new assignment IDs, due dates fall the same time after the start of the term as in the class the template was saved from. Standards are matched by framework and code, the ones the tenant does not have are reported and left off.",
+		Tags:          []string{"Class templates"},
+		DefaultStatus: http.StatusCreated,
+	}, h.InstantiateClassTemplate)
+}
+
+func (h *ClassTemplateHandlers) SaveClassTemplate(ctx context.Context, input *SaveClassTemplateRequest) (*ClassTemplateEnvelope, error) {
+	tenantID := authorization.TenantIDFromContext(ctx)
+	command, err := save_class_template_use_case.NewSaveClassTemplateCommand(
+		tenantID,
+		input.Body.ClassID,
+		input.Body.TermID,
+		input.Body.Name,
+		input.Body.Description,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	template, err := h.saveClassTemplateUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ClassTemplateEnvelope{Body: NewClassTemplateResponse(template, tenantID)}, nil
+}
+
+func (h *ClassTemplateHandlers) ListClassTemplates(ctx context.Context, input *struct{}) (*ListClassTemplatesResponse, error) {
+	tenantID := authorization.TenantIDFromContext(ctx)
+	command, err := list_class_templates_use_case.NewListClassTemplatesCommand(tenantID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	templates, err := h.listClassTemplatesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ListClassTemplatesResponse{}
+	response.Body.Items = make([]ClassTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		response.Body.Items = append(response.Body.Items, NewClassTemplateResponse(template, tenantID))
+	}
+	return response, nil
+}
+
+func (h *ClassTemplateHandlers) GetClassTemplate(ctx context.Context, input *GetClassTemplateRequest) (*ClassTemplateEnvelope, error) {
+	tenantID := authorization.TenantIDFromContext(ctx)
+	command, err := get_class_template_use_case.NewGetClassTemplateCommand(tenantID, input.TemplateID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	template, err := h.getClassTemplateUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ClassTemplateEnvelope{Body: NewClassTemplateResponse(template, tenantID)}, nil
+}
+
+func (h *ClassTemplateHandlers) DeleteClassTemplate(ctx context.Context, input *DeleteClassTemplateRequest) (*struct{}, error) {
+	command, err := delete_class_template_use_case.NewDeleteClassTemplateCommand(authorization.TenantIDFromContext(ctx), input.TemplateID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.deleteClassTemplateUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
+
+func (h *ClassTemplateHandlers) InstantiateClassTemplate(ctx context.Context, input *InstantiateClassTemplateRequest) (*InstantiatedClassEnvelope, error) {
+	command, err := instantiate_class_template_use_case.NewInstantiateClassTemplateCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.TemplateID,
+		input.Body.ClassID,
+		input.Body.TermID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	class, unresolved, err := h.instantiateClassTemplateUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewInstantiatedClassEnvelope(class, unresolved), nil
+}
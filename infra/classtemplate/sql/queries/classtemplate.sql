@@ -0,0 +1,52 @@
+-- name: CreateClassTemplate :exec
+INSERT INTO class_templates (id, tenant_id, name, description, setup, shared, created_by, created_at)
+VALUES (@id, @tenant_id, @name, @description, @setup, @shared, @created_by, @created_at);
+
+-- name: GetClassTemplate :one
+SELECT id, tenant_id, name, description, setup, shared, created_by, created_at
+FROM class_templates
+WHERE id = @id AND (tenant_id = @tenant_id OR shared);
+
+-- name: ListClassTemplates :many
+SELECT id, tenant_id, name, description, setup, shared, created_by, created_at
+FROM class_templates
+WHERE tenant_id = @tenant_id OR shared
+ORDER BY created_at DESC, id;
+
+-- name: DeleteClassTemplate :execrows
+DELETE FROM class_templates
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: SetClassTemplateShared :execrows
+UPDATE class_templates
+SET shared = @shared
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- The setup of a class is kept by the grading, enrollment and standards modules, templates read it
+-- and write it back with their queries and the ones below
+
+-- name: GetTemplateClassCapacity :one
+SELECT capacity
+FROM class_capacities
+WHERE tenant_id = @tenant_id AND class_id = @class_id;
+
+-- name: ListTemplateClassStandards :many
+SELECT a.assignment_id::text AS assignment_id, s.framework, s.code
+FROM assignment_standards a
+JOIN standards s ON s.id = a.standard_id
+WHERE a.tenant_id = @tenant_id AND a.class_id = @class_id
+ORDER BY a.assignment_id, s.framework, s.code;
+
+-- name: ResolveTemplateStandards :many
+SELECT id::text AS id, framework, code
+FROM standards
+WHERE tenant_id = @tenant_id AND framework = ANY(@frameworks::text[]) AND code = ANY(@codes::text[]);
+
+-- name: ClassSetupExists :one
+SELECT (
+    EXISTS (SELECT 1 FROM class_grading_policies p WHERE p.tenant_id = @tenant_id AND p.class_id = @class_id)
+    OR EXISTS (SELECT 1 FROM class_capacities c WHERE c.tenant_id = @tenant_id AND c.class_id = @class_id)
+    OR EXISTS (SELECT 1 FROM class_meetings m WHERE m.tenant_id = @tenant_id AND m.class_id = @class_id)
+    OR EXISTS (SELECT 1 FROM assignment_deadlines d WHERE d.tenant_id = @tenant_id AND d.class_id = @class_id)
+    OR EXISTS (SELECT 1 FROM assignment_standards s WHERE s.tenant_id = @tenant_id AND s.class_id = @class_id)
+)::boolean AS exists;
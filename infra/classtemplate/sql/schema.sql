@@ -0,0 +1,16 @@
+-- Setups of classes saved to start new classes from: settings, weekly meetings and assignments with
+-- their due dates relative to the start of the term. Shared templates are listed by every tenant, only
+-- platform admins share them.
+CREATE TABLE class_templates (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    setup JSONB NOT NULL,
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_class_templates_tenant ON class_templates(tenant_id, created_at);
+CREATE INDEX idx_class_templates_shared ON class_templates(created_at) WHERE shared;
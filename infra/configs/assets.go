@@ -0,0 +1,41 @@
+package configs
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"os"
+)
+
+const (
+	RBACModelFile = "rbac_model.conf"
+	PoliciesFile  = "policies.yaml"
+)
+
+// embedded bundles the files read at startup into the binary, so the server starts from any
+// working directory
+//
+//go:embed rbac_model.conf policies.yaml
+var embedded embed.FS
+
+// Assets returns the bundled files. Files present in overrideDir replace the bundled ones, so a
+// deployment can change policies without a rebuild; an empty overrideDir serves the bundle only.
+func Assets(overrideDir string) fs.FS {
+	if overrideDir == "" {
+		return embedded
+	}
+	return overlay{override: os.DirFS(overrideDir), base: embedded}
+}
+
+type overlay struct {
+	override fs.FS
+	base     fs.FS
+}
+
+func (o overlay) Open(name string) (fs.File, error) {
+	file, err := o.override.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return o.base.Open(name)
+	}
+	return file, err
+}
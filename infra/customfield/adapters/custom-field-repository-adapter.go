@@ -0,0 +1,174 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresCustomFieldRepository keeps the custom fields of tenants, deleting a field removes its
+// values from the attributes of the records in the same transaction
+type PostgresCustomFieldRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresCustomFieldRepository(dbInstance *pgxpool.Pool) ports.CustomFieldRepository {
+	return &PostgresCustomFieldRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresCustomFieldRepository) Create(ctx context.Context, field *entities.CustomField) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(field.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	created, err := r.queries.CreateCustomField(ctx, db.CreateCustomFieldParams{
+		ID:        pgUUID,
+		TenantID:  field.TenantID,
+		Entity:    string(field.Entity),
+		Key:       field.Key,
+		Label:     field.Label,
+		Type:      string(field.Type),
+		Options:   options(field.Options),
+		Required:  field.Required,
+		CreatedBy: field.CreatedBy,
+		CreatedAt: pgtype.Timestamptz{Time: field.CreatedAt, Valid: true},
+		UpdatedAt: pgtype.Timestamptz{Time: field.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return created > 0, nil
+}
+
+func (r *PostgresCustomFieldRepository) Save(ctx context.Context, field *entities.CustomField) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(field.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.UpdateCustomField(ctx, db.UpdateCustomFieldParams{
+		ID:        pgUUID,
+		TenantID:  field.TenantID,
+		Label:     field.Label,
+		Options:   options(field.Options),
+		Required:  field.Required,
+		UpdatedAt: pgtype.Timestamptz{Time: field.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresCustomFieldRepository) FindByID(ctx context.Context, tenantID string, fieldID string) (*entities.CustomField, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(fieldID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetCustomField(ctx, db.GetCustomFieldParams{ID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toCustomField(row), nil
+}
+
+func (r *PostgresCustomFieldRepository) List(ctx context.Context, tenantID string, entity entities.Entity) ([]*entities.CustomField, error) {
+	params := db.ListCustomFieldsParams{TenantID: tenantID}
+	if entity != "" {
+		name := string(entity)
+		params.Entity = &name
+	}
+
+	rows, err := r.queries.ListCustomFields(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	fields := make([]*entities.CustomField, 0, len(rows))
+	for _, row := range rows {
+		fields = append(fields, toCustomField(row))
+	}
+
+	return fields, nil
+}
+
+func (r *PostgresCustomFieldRepository) Delete(ctx context.Context, tenantID string, fieldID string) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(fieldID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	deleted, err := qtx.DeleteCustomField(ctx, db.DeleteCustomFieldParams{ID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, appErrors.PropagateError(err)
+	}
+
+	err = qtx.RemoveEntityAttribute(ctx, db.RemoveEntityAttributeParams{
+		TenantID: tenantID,
+		Entity:   deleted.Entity,
+		Key:      deleted.Key,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return true, nil
+}
+
+// options stores fields without options as an empty array, the column is not nullable
+func options(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func toCustomField(row db.CustomField) *entities.CustomField {
+	return &entities.CustomField{
+		ID:        row.ID.String(),
+		TenantID:  row.TenantID,
+		Entity:    entities.Entity(row.Entity),
+		Key:       row.Key,
+		Label:     row.Label,
+		Type:      entities.FieldType(row.Type),
+		Options:   row.Options,
+		Required:  row.Required,
+		CreatedBy: row.CreatedBy,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,129 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresAttributeRepository keeps the attributes of every record in a JSONB column, searches look
+// for the values with the containment operator served by its GIN index
+type PostgresAttributeRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresAttributeRepository(dbInstance *pgxpool.Pool) ports.AttributeRepository {
+	return &PostgresAttributeRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresAttributeRepository) Get(ctx context.Context, tenantID string, entity entities.Entity, entityID string) (entities.Attributes, error) {
+	var pgEntityID pgtype.UUID
+	if err := pgEntityID.Scan(entityID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	stored, err := r.queries.GetEntityAttributes(ctx, db.GetEntityAttributesParams{
+		TenantID: tenantID,
+		Entity:   string(entity),
+		EntityID: pgEntityID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Attributes{}, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toAttributes(stored)
+}
+
+func (r *PostgresAttributeRepository) Set(ctx context.Context, tenantID string, entity entities.Entity, entityID string,
+	attributes entities.Attributes) (*entities.Record, error) {
+	var pgEntityID pgtype.UUID
+	if err := pgEntityID.Scan(entityID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to serialize attributes", err)
+	}
+
+	row, err := r.queries.UpsertEntityAttributes(ctx, db.UpsertEntityAttributesParams{
+		TenantID:   tenantID,
+		Entity:     string(entity),
+		EntityID:   pgEntityID,
+		Attributes: encoded,
+		UpdatedAt:  pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	stored, err := toAttributes(row.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.Record{EntityID: row.EntityID.String(), Attributes: stored, UpdatedAt: row.UpdatedAt.Time}, nil
+}
+
+func (r *PostgresAttributeRepository) Search(ctx context.Context, tenantID string, entity entities.Entity, match entities.Attributes,
+	page *pagination.PageRequest) (*pagination.Page[*entities.Record], error) {
+	encoded, err := json.Marshal(match)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to serialize attribute search", err)
+	}
+	afterUpdatedAt, afterID, err := database.TimeIDKeyset(page.After)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.queries.SearchEntityAttributes(ctx, db.SearchEntityAttributesParams{
+		TenantID:       tenantID,
+		Entity:         string(entity),
+		Match:          encoded,
+		AfterUpdatedAt: afterUpdatedAt,
+		AfterID:        afterID,
+		PageLimit:      int32(page.PageSize + 1),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	records := make([]*entities.Record, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		attributes, err := toAttributes(row.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &entities.Record{EntityID: row.EntityID.String(), Attributes: attributes, UpdatedAt: row.UpdatedAt.Time})
+	}
+
+	return pagination.NewPage(page, records, total, func(record *entities.Record) []string {
+		return []string{pagination.TimeKey(record.UpdatedAt), record.EntityID}
+	}), nil
+}
+
+func toAttributes(stored []byte) (entities.Attributes, error) {
+	attributes := make(entities.Attributes)
+	if err := json.Unmarshal(stored, &attributes); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to deserialize attributes", err)
+	}
+	return attributes, nil
+}
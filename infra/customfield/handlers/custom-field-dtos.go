@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type CustomFieldResponse struct {
+	ID        string    `json:"id"`
+	Entity    string    `json:"entity" enum:"user,class"`
+	Key       string    `json:"key"`
+	Label     string    `json:"label"`
+	Type      string    `json:"type" enum:"text,number,boolean,date,select"`
+	Options   []string  `json:"options"`
+	Required  bool      `json:"required"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewCustomFieldResponse(field *entities.CustomField) CustomFieldResponse {
+	options := field.Options
+	if options == nil {
+		options = []string{}
+	}
+	return CustomFieldResponse{
+		ID:        field.ID,
+		Entity:    string(field.Entity),
+		Key:       field.Key,
+		Label:     field.Label,
+		Type:      string(field.Type),
+		Options:   options,
+		Required:  field.Required,
+		CreatedBy: field.CreatedBy,
+		CreatedAt: field.CreatedAt,
+		UpdatedAt: field.UpdatedAt,
+	}
+}
+
+type CustomFieldEnvelope struct {
+	Body CustomFieldResponse
+}
+
+type ListCustomFieldsRequest struct {
+	Entity string `query:"entity" enum:"user,class" doc:"Only return the fields of this entity"`
+}
+
+type ListCustomFieldsResponse struct {
+	Body struct {
+		Items []CustomFieldResponse `json:"items"`
+	}
+}
+
+type CreateCustomFieldRequest struct {
+	Body struct {
+		Entity   string   `json:"entity" enum:"user,class"`
+		Key      string   `json:"key" maxLength:"64" doc:"Name of the field in the attributes, lowercase letters, digits and underscores. It cannot change later."`
+		Label    string   `json:"label" maxLength:"100"`
+		Type     string   `json:"type" enum:"text,number,boolean,date,select" doc:"Type of the values, it cannot change later. Dates are YYYY-MM-DD."`
+		Options  []string `json:"options,omitempty" maxItems:"100" doc:"Values a select field accepts, required for select fields only"`
+		Required bool     `json:"required,omitempty" doc:"Attributes of a record cannot be saved without a value for the field"`
+	}
+}
+
+type UpdateCustomFieldRequest struct {
+	FieldID string `path:"fieldId" format:"uuid" doc:"Custom field ID"`
+	Body    struct {
+		Label    string   `json:"label" maxLength:"100"`
+		Options  []string `json:"options,omitempty" maxItems:"100" doc:"Values a select field accepts, values already saved are kept"`
+		Required bool     `json:"required,omitempty"`
+	}
+}
+
+type DeleteCustomFieldRequest struct {
+	FieldID string `path:"fieldId" format:"uuid" doc:"Custom field ID"`
+}
+
+type AttributesResponse struct {
+	Attributes map[string]any `json:"attributes" doc:"Value of each custom field of the record by key"`
+}
+
+type AttributesEnvelope struct {
+	Body AttributesResponse
+}
+
+type GetUserAttributesRequest struct {
+	UserID string `path:"userId" format:"uuid" doc:"User ID"`
+}
+
+type SetUserAttributesRequest struct {
+	UserID string `path:"userId" format:"uuid" doc:"User ID"`
+	Body   AttributesResponse
+}
+
+type GetClassAttributesRequest struct {
+	ClassID string `path:"classId" format:"uuid" doc:"Class ID"`
+}
+
+type SetClassAttributesRequest struct {
+	ClassID string `path:"classId" format:"uuid" doc:"Class ID"`
+	Body    AttributesResponse
+}
+
+type SearchAttributesRequest struct {
+	Attributes map[string]string `query:"attributes,deepObject" doc:"Values to look for by key, e.g. attributes[grade]=7. Records match when they hold every value."`
+	PageSize   int               `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor     string            `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+}
+
+type AttributeRecordResponse struct {
+	ID         string         `json:"id" doc:"ID of the user or class"`
+	Attributes map[string]any `json:"attributes"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+type SearchAttributesResponse struct {
+	Body utils.ListResponseBody[AttributeRecordResponse]
+}
+
+func NewSearchAttributesResponse(page *pagination.Page[*entities.Record]) *SearchAttributesResponse {
+	return &SearchAttributesResponse{Body: utils.NewListResponseBody(page, func(record *entities.Record) AttributeRecordResponse {
+		return AttributeRecordResponse{ID: record.EntityID, Attributes: record.Attributes, UpdatedAt: record.UpdatedAt}
+	})}
+}
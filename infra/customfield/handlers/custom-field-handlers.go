@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	create_custom_field_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/create-custom-field-use-case"
+	delete_custom_field_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/delete-custom-field-use-case"
+	get_attributes_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/get-attributes-use-case"
+	list_custom_fields_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/list-custom-fields-use-case"
+	search_attributes_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/search-attributes-use-case"
+	set_attributes_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/set-attributes-use-case"
+	update_custom_field_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/update-custom-field-use-case"
+	"github.com/nahualventure/class-backend/core/app/customfield/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type CustomFieldHandlers struct {
+	listCustomFieldsUseCase  *list_custom_fields_use_case.ListCustomFieldsUseCase
+	createCustomFieldUseCase *create_custom_field_use_case.CreateCustomFieldUseCase
+	updateCustomFieldUseCase *update_custom_field_use_case.UpdateCustomFieldUseCase
+	deleteCustomFieldUseCase *delete_custom_field_use_case.DeleteCustomFieldUseCase
+	getAttributesUseCase     *get_attributes_use_case.GetAttributesUseCase
+	setAttributesUseCase     *set_attributes_use_case.SetAttributesUseCase
+	searchAttributesUseCase  *search_attributes_use_case.SearchAttributesUseCase
+}
+
+func NewCustomFieldHandlers(
+	listCustomFieldsUseCase *list_custom_fields_use_case.ListCustomFieldsUseCase,
+	createCustomFieldUseCase *create_custom_field_use_case.CreateCustomFieldUseCase,
+	updateCustomFieldUseCase *update_custom_field_use_case.UpdateCustomFieldUseCase,
+	deleteCustomFieldUseCase *delete_custom_field_use_case.DeleteCustomFieldUseCase,
+	getAttributesUseCase *get_attributes_use_case.GetAttributesUseCase,
+	setAttributesUseCase *set_attributes_use_case.SetAttributesUseCase,
+	searchAttributesUseCase *search_attributes_use_case.SearchAttributesUseCase,
+) *CustomFieldHandlers {
+	return &CustomFieldHandlers{
+		listCustomFieldsUseCase:  listCustomFieldsUseCase,
+		createCustomFieldUseCase: createCustomFieldUseCase,
+		updateCustomFieldUseCase: updateCustomFieldUseCase,
+		deleteCustomFieldUseCase: deleteCustomFieldUseCase,
+		getAttributesUseCase:     getAttributesUseCase,
+		setAttributesUseCase:     setAttributesUseCase,
+		searchAttributesUseCase:  searchAttributesUseCase,
+	}
+}
+
+func (h *CustomFieldHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-custom-fields",
+		Method:      http.MethodGet,
+		Path:        "/custom-fields",
+		Summary:     "List the custom fields of the tenant",
+		Tags:        []string{"Custom Fields"},
+	}, h.ListCustomFields)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-custom-field",
+		Method:        http.MethodPost,
+		Path:          "/custom-fields",
+		Summary:       "Define a custom field on users or classes",
+		Tags:          []string{"Custom Fields"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateCustomField)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-custom-field",
+		Method:      http.MethodPut,
+		Path:        "/custom-fields/{fieldId}",
+		Summary:     "Update the label, options or requirement of a custom field",
+		Tags:        []string{"Custom Fields"},
+	}, h.UpdateCustomField)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-custom-field",
+		Method:        http.MethodDelete,
+		Path:          "/custom-fields/{fieldId}",
+		Summary:       "Delete a custom field and its values",
+		Tags:          []string{"Custom Fields"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.DeleteCustomField)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "search-user-attributes",
+		Method:      http.MethodGet,
+		Path:        "/users/attributes/search",
+		Summary:     "Find users by the values of their custom fields",
+		Tags:        []string{"Custom Fields"},
+	}, h.SearchUserAttributes)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-user-attributes",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/attributes",
+		Summary:     "Get the custom field values of a user",
+		Tags:        []string{"Custom Fields"},
+	}, h.GetUserAttributes)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-user-attributes",
+		Method:      http.MethodPut,
+		Path:        "/users/{userId}/attributes",
+		Summary:     "Replace the custom field values of a user",
+		Description: "Every value is checked against the custom fields of the tenant, the attributes are saved only when all of them are valid.",
+		Tags:        []string{"Custom Fields"},
+	}, h.SetUserAttributes)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "search-class-attributes",
+		Method:      http.MethodGet,
+		Path:        "/classes/attributes/search",
+		Summary:     "Find classes by the values of their custom fields",
+		Tags:        []string{"Custom Fields"},
+	}, h.SearchClassAttributes)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-class-attributes",
+		Method:      http.MethodGet,
+		Path:        "/classes/{classId}/attributes",
+		Summary:     "Get the custom field values of a class",
+		Tags:        []string{"Custom Fields"},
+	}, h.GetClassAttributes)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-class-attributes",
+		Method:      http.MethodPut,
+		Path:        "/classes/{classId}/attributes",
+		Summary:     "Replace the custom field values of a class",
+		Description: "Every value is checked against the custom fields of the tenant, the attributes are saved only when all of them are valid.",
+		Tags:        []string{"Custom Fields"},
+	}, h.SetClassAttributes)
+}
+
+func (h *CustomFieldHandlers) ListCustomFields(ctx context.Context, input *ListCustomFieldsRequest) (*ListCustomFieldsResponse, error) {
+	command, err := list_custom_fields_use_case.NewListCustomFieldsCommand(authorization.TenantIDFromContext(ctx), input.Entity)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	fields, err := h.listCustomFieldsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ListCustomFieldsResponse{}
+	response.Body.Items = make([]CustomFieldResponse, 0, len(fields))
+	for _, field := range fields {
+		response.Body.Items = append(response.Body.Items, NewCustomFieldResponse(field))
+	}
+
+	return response, nil
+}
+
+func (h *CustomFieldHandlers) CreateCustomField(ctx context.Context, input *CreateCustomFieldRequest) (*CustomFieldEnvelope, error) {
+	command, err := create_custom_field_use_case.NewCreateCustomFieldCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Entity,
+		input.Body.Key,
+		input.Body.Label,
+		input.Body.Type,
+		input.Body.Options,
+		input.Body.Required,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	field, err := h.createCustomFieldUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &CustomFieldEnvelope{Body: NewCustomFieldResponse(field)}, nil
+}
+
+func (h *CustomFieldHandlers) UpdateCustomField(ctx context.Context, input *UpdateCustomFieldRequest) (*CustomFieldEnvelope, error) {
+	command, err := update_custom_field_use_case.NewUpdateCustomFieldCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.FieldID,
+		input.Body.Label,
+		input.Body.Options,
+		input.Body.Required,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	field, err := h.updateCustomFieldUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &CustomFieldEnvelope{Body: NewCustomFieldResponse(field)}, nil
+}
+
+func (h *CustomFieldHandlers) DeleteCustomField(ctx context.Context, input *DeleteCustomFieldRequest) (*struct{}, error) {
+	command, err := delete_custom_field_use_case.NewDeleteCustomFieldCommand(authorization.TenantIDFromContext(ctx), input.FieldID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.deleteCustomFieldUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
+
+func (h *CustomFieldHandlers) GetUserAttributes(ctx context.Context, input *GetUserAttributesRequest) (*AttributesEnvelope, error) {
+	return h.getAttributes(ctx, entities.EntityUser, input.UserID)
+}
+
+func (h *CustomFieldHandlers) SetUserAttributes(ctx context.Context, input *SetUserAttributesRequest) (*AttributesEnvelope, error) {
+	return h.setAttributes(ctx, entities.EntityUser, input.UserID, input.Body.Attributes)
+}
+
+func (h *CustomFieldHandlers) SearchUserAttributes(ctx context.Context, input *SearchAttributesRequest) (*SearchAttributesResponse, error) {
+	return h.searchAttributes(ctx, entities.EntityUser, input)
+}
+
+func (h *CustomFieldHandlers) GetClassAttributes(ctx context.Context, input *GetClassAttributesRequest) (*AttributesEnvelope, error) {
+	return h.getAttributes(ctx, entities.EntityClass, input.ClassID)
+}
+
+func (h *CustomFieldHandlers) SetClassAttributes(ctx context.Context, input *SetClassAttributesRequest) (*AttributesEnvelope, error) {
+	return h.setAttributes(ctx, entities.EntityClass, input.ClassID, input.Body.Attributes)
+}
+
+func (h *CustomFieldHandlers) SearchClassAttributes(ctx context.Context, input *SearchAttributesRequest) (*SearchAttributesResponse, error) {
+	return h.searchAttributes(ctx, entities.EntityClass, input)
+}
+
+func (h *CustomFieldHandlers) getAttributes(ctx context.Context, entity entities.Entity, entityID string) (*AttributesEnvelope, error) {
+	command, err := get_attributes_use_case.NewGetAttributesCommand(authorization.TenantIDFromContext(ctx), entity, entityID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	attributes, err := h.getAttributesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &AttributesEnvelope{Body: AttributesResponse{Attributes: attributes}}, nil
+}
+
+func (h *CustomFieldHandlers) setAttributes(ctx context.Context, entity entities.Entity, entityID string,
+	attributes map[string]any) (*AttributesEnvelope, error) {
+	command, err := set_attributes_use_case.NewSetAttributesCommand(authorization.TenantIDFromContext(ctx), entity, entityID, attributes)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	record, err := h.setAttributesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &AttributesEnvelope{Body: AttributesResponse{Attributes: record.Attributes}}, nil
+}
+
+func (h *CustomFieldHandlers) searchAttributes(ctx context.Context, entity entities.Entity,
+	input *SearchAttributesRequest) (*SearchAttributesResponse, error) {
+	command, err := search_attributes_use_case.NewSearchAttributesCommand(authorization.TenantIDFromContext(ctx), entity,
+		input.Attributes, input.PageSize, input.Cursor)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.searchAttributesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewSearchAttributesResponse(page), nil
+}
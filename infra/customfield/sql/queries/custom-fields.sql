@@ -0,0 +1,53 @@
+-- name: CreateCustomField :execrows
+INSERT INTO custom_fields (id, tenant_id, entity, key, label, type, options, required, created_by, created_at, updated_at)
+VALUES (@id, @tenant_id, @entity, @key, @label, @type, @options, @required, @created_by, @created_at, @updated_at)
+ON CONFLICT (tenant_id, entity, key) DO NOTHING;
+
+-- name: UpdateCustomField :exec
+UPDATE custom_fields
+SET label = @label, options = @options, required = @required, updated_at = @updated_at
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: GetCustomField :one
+SELECT *
+FROM custom_fields
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: ListCustomFields :many
+SELECT *
+FROM custom_fields
+WHERE tenant_id = @tenant_id AND (sqlc.narg('entity')::text IS NULL OR entity = sqlc.narg('entity'))
+ORDER BY entity, key;
+
+-- name: DeleteCustomField :one
+DELETE FROM custom_fields
+WHERE id = @id AND tenant_id = @tenant_id
+RETURNING entity, key;
+
+-- name: RemoveEntityAttribute :exec
+UPDATE entity_attributes
+SET attributes = attributes - @key::text
+WHERE tenant_id = @tenant_id AND entity = @entity AND jsonb_exists(attributes, @key::text);
+
+-- name: GetEntityAttributes :one
+SELECT attributes
+FROM entity_attributes
+WHERE tenant_id = @tenant_id AND entity = @entity AND entity_id = @entity_id;
+
+-- name: UpsertEntityAttributes :one
+INSERT INTO entity_attributes (tenant_id, entity, entity_id, attributes, updated_at)
+VALUES (@tenant_id, @entity, @entity_id, @attributes, @updated_at)
+ON CONFLICT (tenant_id, entity, entity_id) DO UPDATE
+SET attributes = EXCLUDED.attributes, updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: SearchEntityAttributes :many
+SELECT entity_id, attributes, updated_at,
+       (SELECT count(*) FROM entity_attributes t
+        WHERE t.tenant_id = @tenant_id AND t.entity = @entity AND t.attributes @> @match::jsonb) AS total
+FROM entity_attributes
+WHERE tenant_id = @tenant_id AND entity = @entity AND attributes @> @match::jsonb
+  AND (sqlc.narg('after_updated_at')::timestamptz IS NULL
+       OR (updated_at, entity_id) < (sqlc.narg('after_updated_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY updated_at DESC, entity_id DESC
+LIMIT @page_limit;
@@ -0,0 +1,31 @@
+-- Fields tenants add to their users and classes, the values of the records are kept in
+-- entity_attributes under the key of their field
+CREATE TABLE custom_fields (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    entity VARCHAR(20) NOT NULL,          -- user, class
+    key VARCHAR(64) NOT NULL,
+    label VARCHAR(200) NOT NULL,
+    type VARCHAR(20) NOT NULL,            -- text, number, boolean, date, select
+    options TEXT[] NOT NULL DEFAULT '{}', -- values of select fields
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_custom_fields_key ON custom_fields(tenant_id, entity, key);
+
+-- Values of the custom fields of a user or class by key. The GIN index serves the searches by
+-- attribute, which look for the rows containing the values searched (attributes @> '{"district": "north"}')
+CREATE TABLE entity_attributes (
+    tenant_id VARCHAR(255) NOT NULL,
+    entity VARCHAR(20) NOT NULL,          -- user, class
+    entity_id UUID NOT NULL,
+    attributes JSONB NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, entity, entity_id)
+);
+
+CREATE INDEX idx_entity_attributes_attributes ON entity_attributes USING GIN (attributes jsonb_path_ops);
+CREATE INDEX idx_entity_attributes_updated_at ON entity_attributes(tenant_id, entity, updated_at, entity_id);
@@ -0,0 +1,40 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/cache"
+)
+
+// CachedMetricsReader caches metric reads per tenant and range. The projection itself only updates
+// every few seconds, so a short TTL hides the repeated loads of the admin home screen.
+type CachedMetricsReader struct {
+	next  ports.MetricsReader
+	cache *cache.TTLCache[string, []entities.DailyValue]
+}
+
+func NewCachedMetricsReader(next ports.MetricsReader, ttl time.Duration) ports.MetricsReader {
+	return &CachedMetricsReader{
+		next:  next,
+		cache: cache.NewTTLCache[string, []entities.DailyValue](ttl, 10_000),
+	}
+}
+
+func (r *CachedMetricsReader) DailyValues(ctx context.Context, tenantID string, metric string, from time.Time, to time.Time) ([]entities.DailyValue, error) {
+	key := fmt.Sprintf("%s|%s|%s|%s", tenantID, metric, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if values, ok := r.cache.Lookup(ctx, key); ok {
+		return values, nil
+	}
+
+	values, err := r.next.DailyValues(ctx, tenantID, metric, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(key, values)
+	return values, nil
+}
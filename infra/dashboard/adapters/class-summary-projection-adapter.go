@@ -0,0 +1,148 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const ClassSummaryProjectionName = "class_summary"
+
+// PostgresClassSummaryProjection is both the projector that maintains class_summaries and the
+// reader the dashboard use cases query.
+type PostgresClassSummaryProjection struct {
+	queries *db.Queries
+}
+
+func NewPostgresClassSummaryProjection(dbInstance *pgxpool.Pool) *PostgresClassSummaryProjection {
+	return &PostgresClassSummaryProjection{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (p *PostgresClassSummaryProjection) Name() string {
+	return ClassSummaryProjectionName
+}
+
+func (p *PostgresClassSummaryProjection) Apply(ctx context.Context, event events.Event) error {
+	if event.Type == events.SectionStudentsMoved {
+		return p.applySectionMove(ctx, event)
+	}
+
+	delta := db.ApplyClassSummaryDeltaParams{
+		TenantID: event.TenantID,
+		Position: event.Position,
+	}
+
+	switch event.Type {
+	case events.EnrollmentAdded:
+		delta.RosterDelta = 1
+	case events.EnrollmentRemoved:
+		delta.RosterDelta = -1
+	case events.GradeRecorded:
+		score, ok := event.Float("score")
+		if !ok {
+			log.Printf("Skipping %s event at position %d: missing score", event.Type, event.Position)
+			return nil
+		}
+		if previous, changed := event.Float("previous_score"); changed {
+			delta.GradeSumDelta = score - previous
+		} else {
+			delta.GradeSumDelta = score
+			delta.GradeCountDelta = 1
+		}
+	case events.AttendanceRecorded:
+		delta.AttendanceTotalDelta = 1
+		if event.Bool("present") {
+			delta.AttendancePresentDelta = 1
+		}
+	default:
+		return nil
+	}
+
+	// A malformed event would otherwise block the projection forever
+	if err := delta.ClassID.Scan(event.String("class_id")); err != nil {
+		log.Printf("Skipping %s event at position %d: invalid class_id", event.Type, event.Position)
+		return nil
+	}
+
+	if err := p.queries.ApplyClassSummaryDelta(ctx, delta); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+// applySectionMove takes the moved students and released grades off the source class and adds them
+// to the target
+func (p *PostgresClassSummaryProjection) applySectionMove(ctx context.Context, event events.Event) error {
+	var sourceID, targetID pgtype.UUID
+	if sourceID.Scan(event.String("source_class_id")) != nil || targetID.Scan(event.String("target_class_id")) != nil {
+		log.Printf("Skipping %s event at position %d: invalid class IDs", event.Type, event.Position)
+		return nil
+	}
+	enrollments, _ := event.Float("enrollments")
+	gradeCount, _ := event.Float("grade_count")
+	gradeSum, _ := event.Float("grade_sum")
+
+	deltas := []db.ApplyClassSummaryDeltaParams{
+		{ClassID: sourceID, RosterDelta: -int32(enrollments), GradeSumDelta: -gradeSum, GradeCountDelta: -int32(gradeCount)},
+		{ClassID: targetID, RosterDelta: int32(enrollments), GradeSumDelta: gradeSum, GradeCountDelta: int32(gradeCount)},
+	}
+	for _, delta := range deltas {
+		delta.TenantID = event.TenantID
+		delta.Position = event.Position
+		if err := p.queries.ApplyClassSummaryDelta(ctx, delta); err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	return nil
+}
+
+func (p *PostgresClassSummaryProjection) Reset(ctx context.Context) error {
+	if err := p.queries.DeleteAllClassSummaries(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresClassSummaryProjection) FindClassSummary(ctx context.Context, tenantID string, classID string) (*entities.ClassSummary, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := p.queries.GetClassSummary(ctx, db.GetClassSummaryParams{
+		ClassID:  pgUUID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return entities.NewClassSummary(
+		row.ClassID.String(),
+		row.TenantID,
+		int(row.RosterCount),
+		row.GradeSum,
+		int(row.GradeCount),
+		int(row.AttendancePresent),
+		int(row.AttendanceTotal),
+		row.UpdatedAt.Time,
+	), nil
+}
@@ -0,0 +1,135 @@
+package adapters
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const TenantMetricsProjectionName = "tenant_daily_metrics"
+
+// PostgresTenantMetricsProjection maintains per tenant daily counters for the admin home screen
+// and reads them back for the metrics use cases.
+type PostgresTenantMetricsProjection struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresTenantMetricsProjection(dbInstance *pgxpool.Pool) *PostgresTenantMetricsProjection {
+	return &PostgresTenantMetricsProjection{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (p *PostgresTenantMetricsProjection) Name() string {
+	return TenantMetricsProjectionName
+}
+
+func (p *PostgresTenantMetricsProjection) Apply(ctx context.Context, event events.Event) error {
+	day := pgtype.Date{Time: entities.TruncateDay(event.OccurredAt), Valid: true}
+	increment := db.IncrementTenantDailyMetricParams{
+		TenantID: event.TenantID,
+		Day:      day,
+		Delta:    1,
+		Position: event.Position,
+	}
+
+	switch event.Type {
+	case events.UserSignedUp:
+		increment.Metric = entities.SignupsMetric
+	case events.EnrollmentAdded:
+		increment.Metric = entities.EnrollmentsMetric
+	case events.EnrollmentRemoved:
+		increment.Metric = entities.EnrollmentsMetric
+		increment.Delta = -1
+	case events.UserActive:
+		return p.applyActiveUser(ctx, event, increment)
+	default:
+		return nil
+	}
+
+	if err := p.queries.IncrementTenantDailyMetric(ctx, increment); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+// applyActiveUser counts a user once per day, the insert and the increment share a transaction so
+// a replay cannot skip the increment
+func (p *PostgresTenantMetricsProjection) applyActiveUser(ctx context.Context, event events.Event, increment db.IncrementTenantDailyMetricParams) error {
+	userID := event.String("user_id")
+	if userID == "" {
+		log.Printf("Skipping %s event at position %d: missing user_id", event.Type, event.Position)
+		return nil
+	}
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := p.queries.WithTx(tx)
+	inserted, err := qtx.InsertTenantDailyActiveUser(ctx, db.InsertTenantDailyActiveUserParams{
+		TenantID: event.TenantID,
+		Day:      increment.Day,
+		UserID:   userID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if inserted > 0 {
+		increment.Metric = entities.DailyActiveUsersMetric
+		if err := qtx.IncrementTenantDailyMetric(ctx, increment); err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresTenantMetricsProjection) Reset(ctx context.Context) error {
+	if err := p.queries.DeleteAllTenantDailyMetrics(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := p.queries.DeleteAllTenantDailyActiveUsers(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresTenantMetricsProjection) DailyValues(ctx context.Context, tenantID string, metric string, from time.Time, to time.Time) ([]entities.DailyValue, error) {
+	rows, err := p.queries.ListTenantDailyMetric(ctx, db.ListTenantDailyMetricParams{
+		TenantID: tenantID,
+		Metric:   metric,
+		FromDay:  pgtype.Date{Time: from, Valid: true},
+		ToDay:    pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	values := make([]entities.DailyValue, 0, len(rows))
+	for _, row := range rows {
+		values = append(values, entities.DailyValue{Day: row.Day.Time, Value: row.Value})
+	}
+
+	return values, nil
+}
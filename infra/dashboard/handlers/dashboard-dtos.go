@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/dashboard/domain/entities"
+)
+
+type GetClassSummaryRequest struct {
+	ClassID string `path:"classId" format:"uuid" doc:"Class ID"`
+}
+
+type ClassSummaryResponse struct {
+	ClassID        string    `json:"class_id"`
+	RosterCount    int       `json:"roster_count"`
+	AverageGrade   *float64  `json:"average_grade" doc:"Null until the first grade is recorded"`
+	AttendanceRate *float64  `json:"attendance_rate" doc:"Between 0 and 1, null until the first attendance is recorded"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type GetClassSummaryResponse struct {
+	Body ClassSummaryResponse
+}
+
+func NewGetClassSummaryResponse(summary *entities.ClassSummary) *GetClassSummaryResponse {
+	return &GetClassSummaryResponse{Body: ClassSummaryResponse{
+		ClassID:        summary.ClassID,
+		RosterCount:    summary.RosterCount,
+		AverageGrade:   summary.AverageGrade,
+		AttendanceRate: summary.AttendanceRate,
+		UpdatedAt:      summary.UpdatedAt,
+	}}
+}
+
+type GetMetricSeriesRequest struct {
+	Metric      string `path:"metric" enum:"daily_active_users,signups,enrollments"`
+	Granularity string `query:"granularity" enum:"day,week" default:"day"`
+	Periods     int    `query:"periods" minimum:"1" maximum:"90" default:"30" doc:"Number of periods ending with the current one, at most 52 weeks"`
+}
+
+type MetricPointResponse struct {
+	PeriodStart string  `json:"period_start" format:"date"`
+	Value       float64 `json:"value"`
+}
+
+type GetMetricSeriesResponse struct {
+	Body struct {
+		Metric      string                `json:"metric"`
+		Granularity string                `json:"granularity"`
+		Points      []MetricPointResponse `json:"points"`
+	}
+}
+
+func NewGetMetricSeriesResponse(series *entities.MetricSeries) *GetMetricSeriesResponse {
+	response := &GetMetricSeriesResponse{}
+	response.Body.Metric = series.Metric
+	response.Body.Granularity = string(series.Granularity)
+	response.Body.Points = make([]MetricPointResponse, 0, len(series.Points))
+	for _, point := range series.Points {
+		response.Body.Points = append(response.Body.Points, MetricPointResponse{
+			PeriodStart: point.PeriodStart.Format(time.DateOnly),
+			Value:       point.Value,
+		})
+	}
+	return response
+}
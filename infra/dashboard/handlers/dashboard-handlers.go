@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_class_summary_use_case "github.com/nahualventure/class-backend/core/app/dashboard/application/use-cases/get-class-summary-use-case"
+	get_metric_series_use_case "github.com/nahualventure/class-backend/core/app/dashboard/application/use-cases/get-metric-series-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type DashboardHandlers struct {
+	getClassSummaryUseCase *get_class_summary_use_case.GetClassSummaryUseCase
+	getMetricSeriesUseCase *get_metric_series_use_case.GetMetricSeriesUseCase
+}
+
+func NewDashboardHandlers(
+	getClassSummaryUseCase *get_class_summary_use_case.GetClassSummaryUseCase,
+	getMetricSeriesUseCase *get_metric_series_use_case.GetMetricSeriesUseCase,
+) *DashboardHandlers {
+	return &DashboardHandlers{
+		getClassSummaryUseCase: getClassSummaryUseCase,
+		getMetricSeriesUseCase: getMetricSeriesUseCase,
+	}
+}
+
+func (h *DashboardHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-class-summary",
+		Method:      http.MethodGet,
+		Path:        "/dashboards/classes/{classId}/summary",
+		Summary:     "Get class dashboard summary",
+		Description: "Served from the class summary projection, it can lag behind the latest changes by a few seconds.",
+		Tags:        []string{"Dashboards"},
+	}, h.GetClassSummary)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-dashboard-metric",
+		Method:      http.MethodGet,
+		Path:        "/dashboards/metrics/{metric}",
+		Summary:     "Get a tenant metric series",
+		Description: "Aggregates for the admin home screen, computed from projections and cached for a minute.",
+		Tags:        []string{"Dashboards"},
+	}, h.GetMetricSeries)
+}
+
+func (h *DashboardHandlers) GetClassSummary(ctx context.Context, input *GetClassSummaryRequest) (*GetClassSummaryResponse, error) {
+	command, err := get_class_summary_use_case.NewGetClassSummaryCommand(authorization.TenantIDFromContext(ctx), input.ClassID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	summary, err := h.getClassSummaryUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewGetClassSummaryResponse(summary), nil
+}
+
+func (h *DashboardHandlers) GetMetricSeries(ctx context.Context, input *GetMetricSeriesRequest) (*GetMetricSeriesResponse, error) {
+	command, err := get_metric_series_use_case.NewGetMetricSeriesCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Metric,
+		input.Granularity,
+		input.Periods,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	series, err := h.getMetricSeriesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewGetMetricSeriesResponse(series), nil
+}
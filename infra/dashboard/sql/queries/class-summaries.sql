@@ -0,0 +1,26 @@
+-- name: GetClassSummary :one
+SELECT *
+FROM class_summaries
+WHERE class_id = @class_id AND tenant_id = @tenant_id;
+
+-- name: ApplyClassSummaryDelta :exec
+INSERT INTO class_summaries (
+    class_id, tenant_id, roster_count, grade_sum, grade_count,
+    attendance_present, attendance_total, last_position, updated_at
+)
+VALUES (
+    @class_id, @tenant_id, @roster_delta, @grade_sum_delta, @grade_count_delta,
+    @attendance_present_delta, @attendance_total_delta, @position, NOW()
+)
+ON CONFLICT (class_id) DO UPDATE
+SET roster_count = class_summaries.roster_count + EXCLUDED.roster_count,
+    grade_sum = class_summaries.grade_sum + EXCLUDED.grade_sum,
+    grade_count = class_summaries.grade_count + EXCLUDED.grade_count,
+    attendance_present = class_summaries.attendance_present + EXCLUDED.attendance_present,
+    attendance_total = class_summaries.attendance_total + EXCLUDED.attendance_total,
+    last_position = EXCLUDED.last_position,
+    updated_at = EXCLUDED.updated_at
+WHERE class_summaries.last_position < EXCLUDED.last_position;
+
+-- name: DeleteAllClassSummaries :exec
+DELETE FROM class_summaries;
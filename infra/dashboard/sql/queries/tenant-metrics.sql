@@ -0,0 +1,24 @@
+-- name: IncrementTenantDailyMetric :exec
+INSERT INTO tenant_daily_metrics (tenant_id, day, metric, value, last_position)
+VALUES (@tenant_id, @day, @metric, @delta, @position)
+ON CONFLICT (tenant_id, metric, day) DO UPDATE
+SET value = tenant_daily_metrics.value + EXCLUDED.value,
+    last_position = EXCLUDED.last_position
+WHERE tenant_daily_metrics.last_position < EXCLUDED.last_position;
+
+-- name: InsertTenantDailyActiveUser :execrows
+INSERT INTO tenant_daily_active_users (tenant_id, day, user_id)
+VALUES (@tenant_id, @day, @user_id)
+ON CONFLICT DO NOTHING;
+
+-- name: ListTenantDailyMetric :many
+SELECT day, value
+FROM tenant_daily_metrics
+WHERE tenant_id = @tenant_id AND metric = @metric AND day BETWEEN @from_day AND @to_day
+ORDER BY day;
+
+-- name: DeleteAllTenantDailyMetrics :exec
+DELETE FROM tenant_daily_metrics;
+
+-- name: DeleteAllTenantDailyActiveUsers :exec
+DELETE FROM tenant_daily_active_users;
@@ -0,0 +1,37 @@
+-- Class summary read model, maintained by the class_summary projection from outbox events.
+-- Sums and counts are stored instead of averages so every event is a cheap delta.
+CREATE TABLE class_summaries (
+    class_id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    roster_count INTEGER NOT NULL DEFAULT 0,
+    grade_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    grade_count INTEGER NOT NULL DEFAULT 0,
+    attendance_present INTEGER NOT NULL DEFAULT 0,
+    attendance_total INTEGER NOT NULL DEFAULT 0,
+    last_position BIGINT NOT NULL,         -- outbox position of the last applied event, makes replays idempotent
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_class_summaries_tenant_id ON class_summaries(tenant_id);
+
+
+-- Per tenant daily counters maintained by the tenant_daily_metrics projection
+CREATE TABLE tenant_daily_metrics (
+    tenant_id VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    metric VARCHAR(50) NOT NULL,
+    value BIGINT NOT NULL DEFAULT 0,
+    last_position BIGINT NOT NULL,         -- outbox position of the last applied event, makes replays idempotent
+    PRIMARY KEY (tenant_id, metric, day)
+);
+
+-- Users seen active per day, used to count each user once in daily_active_users. Partitioned by month
+-- of day like outbox_events, one row per active user and day is the largest table of the read models.
+CREATE TABLE tenant_daily_active_users (
+    tenant_id VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (tenant_id, day, user_id)
+) PARTITION BY RANGE (day);
+
+CREATE TABLE tenant_daily_active_users_default PARTITION OF tenant_daily_active_users DEFAULT;
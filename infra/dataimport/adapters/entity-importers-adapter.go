@@ -0,0 +1,146 @@
+package adapters
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	signup_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/signup-use-case"
+	add_non_instructional_day_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/add-non-instructional-day-use-case"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	record_grade_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/record-grade-use-case"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	save_standard_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/save-standard-use-case"
+	userErrors "github.com/nahualventure/class-backend/core/app/user/domain/errors"
+	userPorts "github.com/nahualventure/class-backend/core/app/user/domain/ports"
+)
+
+// NewEntityImporters wraps the use cases that write every importable entity, rows go through the
+// same validation as requests to their endpoints
+func NewEntityImporters(users userPorts.UserRepository, signup *signup_use_case.CreateUserUseCase,
+	recordGrade *record_grade_use_case.RecordGradeUseCase,
+	addNonInstructionalDay *add_non_instructional_day_use_case.AddNonInstructionalDayUseCase,
+	saveStandard *save_standard_use_case.SaveStandardUseCase) ports.EntityImporters {
+	return ports.EntityImporters{
+		entities.UsersImport:                &userImporter{users: users, signup: signup},
+		entities.GradesImport:               &gradeImporter{recordGrade: recordGrade},
+		entities.NonInstructionalDaysImport: &nonInstructionalDayImporter{addNonInstructionalDay: addNonInstructionalDay},
+		entities.StandardsImport:            &standardImporter{saveStandard: saveStandard},
+	}
+}
+
+type userImporter struct {
+	users  userPorts.UserRepository
+	signup *signup_use_case.CreateUserUseCase
+}
+
+func (i *userImporter) command(values map[string]string) (*signup_use_case.CreateUserCommand, error) {
+	// Passwords are taken as written, spaces included
+	return signup_use_case.NewCreateUserCommand(trimmed(values, "name"), trimmed(values, "email"), values["password"])
+}
+
+// Check also rejects emails of existing users, which signup only finds when it runs
+func (i *userImporter) Check(ctx context.Context, tenantID string, actorID string, values map[string]string) error {
+	command, err := i.command(values)
+	if err != nil {
+		return err
+	}
+
+	exists, err := i.users.ExistsByEmail(command.Email)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if exists {
+		return userErrors.NewEmailAlreadyExistsError(command.Email)
+	}
+	return nil
+}
+
+func (i *userImporter) Import(ctx context.Context, tenantID string, actorID string, values map[string]string) error {
+	command, err := i.command(values)
+	if err != nil {
+		return err
+	}
+
+	_, err = i.signup.Execute(command)
+	return err
+}
+
+type gradeImporter struct {
+	recordGrade *record_grade_use_case.RecordGradeUseCase
+}
+
+func (i *gradeImporter) command(tenantID string, actorID string, values map[string]string) (*record_grade_use_case.RecordGradeCommand, error) {
+	score, err := strconv.ParseFloat(trimmed(values, "score"), 64)
+	if err != nil {
+		return nil, appErrors.NewValidationError("Invalid grade", map[string]any{"score": "must be a number"}, err)
+	}
+
+	return record_grade_use_case.NewRecordGradeCommand(tenantID, trimmed(values, "class_id"), trimmed(values, "assignment_id"),
+		trimmed(values, "student_id"), score, nil, actorID, trimmed(values, "reason"))
+}
+
+func (i *gradeImporter) Check(ctx context.Context, tenantID string, actorID string, values map[string]string) error {
+	_, err := i.command(tenantID, actorID, values)
+	return err
+}
+
+func (i *gradeImporter) Import(ctx context.Context, tenantID string, actorID string, values map[string]string) error {
+	command, err := i.command(tenantID, actorID, values)
+	if err != nil {
+		return err
+	}
+
+	_, err = i.recordGrade.Execute(ctx, command)
+	return err
+}
+
+type nonInstructionalDayImporter struct {
+	addNonInstructionalDay *add_non_instructional_day_use_case.AddNonInstructionalDayUseCase
+}
+
+func (i *nonInstructionalDayImporter) Check(ctx context.Context, tenantID string, actorID string, values map[string]string) error {
+	_, err := add_non_instructional_day_use_case.NewAddNonInstructionalDayCommand(tenantID, actorID, trimmed(values, "date"),
+		trimmed(values, "name"))
+	return err
+}
+
+func (i *nonInstructionalDayImporter) Import(ctx context.Context, tenantID string, actorID string, values map[string]string) error {
+	command, err := add_non_instructional_day_use_case.NewAddNonInstructionalDayCommand(tenantID, actorID, trimmed(values, "date"),
+		trimmed(values, "name"))
+	if err != nil {
+		return err
+	}
+
+	_, err = i.addNonInstructionalDay.Execute(ctx, command)
+	return err
+}
+
+type standardImporter struct {
+	saveStandard *save_standard_use_case.SaveStandardUseCase
+}
+
+func (i *standardImporter) command(tenantID string, values map[string]string) (*save_standard_use_case.SaveStandardCommand, error) {
+	return save_standard_use_case.NewSaveStandardCommand(tenantID, trimmed(values, "framework"), trimmed(values, "code"),
+		trimmed(values, "parent_code"), trimmed(values, "subject"), trimmed(values, "grade_level"), trimmed(values, "description"))
+}
+
+func (i *standardImporter) Check(ctx context.Context, tenantID string, actorID string, values map[string]string) error {
+	_, err := i.command(tenantID, values)
+	return err
+}
+
+func (i *standardImporter) Import(ctx context.Context, tenantID string, actorID string, values map[string]string) error {
+	command, err := i.command(tenantID, values)
+	if err != nil {
+		return err
+	}
+
+	_, err = i.saveStandard.Execute(ctx, command)
+	return err
+}
+
+func trimmed(values map[string]string, field string) string {
+	return strings.TrimSpace(values[field])
+}
@@ -0,0 +1,364 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresImportJobRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresImportJobRepository(dbInstance *pgxpool.Pool) ports.ImportJobRepository {
+	return &PostgresImportJobRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresImportJobRepository) Create(ctx context.Context, job *entities.ImportJob, content []byte) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(job.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	header, err := json.Marshal(job.Header)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize import header", err)
+	}
+	mapping, err := json.Marshal(job.Mapping)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize import mapping", err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.CreateDataImport(ctx, db.CreateDataImportParams{
+		ID:          pgUUID,
+		TenantID:    job.TenantID,
+		Entity:      job.Entity,
+		FileName:    job.FileName,
+		Header:      header,
+		Mapping:     mapping,
+		Status:      string(job.Status),
+		TotalRows:   int32(job.TotalRows),
+		RequestedBy: job.RequestedBy,
+		CreatedAt:   pgtype.Timestamptz{Time: job.CreatedAt, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: job.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := qtx.CreateDataImportFile(ctx, db.CreateDataImportFileParams{ImportID: pgUUID, Content: content}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresImportJobRepository) FindByID(ctx context.Context, tenantID string, importID string) (*entities.ImportJob, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(importID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetDataImport(ctx, db.GetDataImportParams{ID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toImportJob(row)
+}
+
+func (r *PostgresImportJobRepository) List(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.ImportJob], error) {
+	afterCreatedAt, afterID, err := database.TimeIDKeyset(page.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.queries.ListDataImports(ctx, db.ListDataImportsParams{
+		TenantID:       tenantID,
+		PageLimit:      int32(page.PageSize + 1),
+		AfterCreatedAt: afterCreatedAt,
+		AfterID:        afterID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	jobs := make([]*entities.ImportJob, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		job, err := toImportJob(db.DataImport{
+			ID:            row.ID,
+			TenantID:      row.TenantID,
+			Entity:        row.Entity,
+			FileName:      row.FileName,
+			Header:        row.Header,
+			Mapping:       row.Mapping,
+			Status:        row.Status,
+			TotalRows:     row.TotalRows,
+			ImportedRows:  row.ImportedRows,
+			FailedRows:    row.FailedRows,
+			FailureReason: row.FailureReason,
+			RequestedBy:   row.RequestedBy,
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+			CompletedAt:   row.CompletedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return pagination.NewPage(page, jobs, total, func(job *entities.ImportJob) []string {
+		return []string{pagination.TimeKey(job.CreatedAt), job.ID}
+	}), nil
+}
+
+func (r *PostgresImportJobRepository) ListRows(ctx context.Context, tenantID string, importID string, status entities.RowStatus,
+	page *pagination.PageRequest) (*pagination.Page[*entities.RowResult], error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(importID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	params := db.ListDataImportRowsParams{
+		ImportID:  pgUUID,
+		TenantID:  tenantID,
+		PageLimit: int32(page.PageSize + 1),
+	}
+	if status != "" {
+		value := string(status)
+		params.Status = &value
+	}
+	if page.After != nil {
+		line, err := strconv.Atoi(page.After[0])
+		if err != nil || len(page.After) != 1 {
+			return nil, appErrors.NewInfrastructureError("invalid cursor line key", err)
+		}
+		afterLine := int32(line)
+		params.AfterLine = &afterLine
+	}
+
+	rows, err := r.queries.ListDataImportRows(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	results := make([]*entities.RowResult, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		result, err := toRowResult(db.ListFailedDataImportRowsRow{
+			Line:         row.Line,
+			Status:       row.Status,
+			Cells:        row.Cells,
+			ErrorCode:    row.ErrorCode,
+			ErrorMessage: row.ErrorMessage,
+		})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return pagination.NewPage(page, results, total, func(result *entities.RowResult) []string {
+		return []string{strconv.Itoa(result.Line)}
+	}), nil
+}
+
+func (r *PostgresImportJobRepository) FailedRows(ctx context.Context, tenantID string, importID string) ([]*entities.RowResult, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(importID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListFailedDataImportRows(ctx, db.ListFailedDataImportRowsParams{ImportID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	results := make([]*entities.RowResult, 0, len(rows))
+	for _, row := range rows {
+		result, err := toRowResult(row)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (r *PostgresImportJobRepository) ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.ImportJob, []byte, error) {
+	row, err := r.queries.ClaimNextPendingDataImport(ctx, pgtype.Timestamptz{Time: time.Now().Add(-staleAfter), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, nil
+		}
+		return nil, nil, appErrors.PropagateError(err)
+	}
+
+	job, err := toImportJob(row)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A job without its file is returned without content, the use case fails it
+	content, err := r.queries.GetDataImportFile(ctx, row.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, appErrors.PropagateError(err)
+	}
+
+	return job, content, nil
+}
+
+func (r *PostgresImportJobRepository) Complete(ctx context.Context, importID string, rows []entities.RowResult) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(importID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	params := db.InsertDataImportRowsParams{
+		ImportID:      pgUUID,
+		Lines:         make([]int32, len(rows)),
+		Statuses:      make([]string, len(rows)),
+		Cells:         make([][]byte, len(rows)),
+		ErrorCodes:    make([]string, len(rows)),
+		ErrorMessages: make([]string, len(rows)),
+	}
+	var imported, failed int32
+	for i, row := range rows {
+		cells, err := json.Marshal(row.Cells)
+		if err != nil {
+			return appErrors.NewInfrastructureError("failed to serialize import row", err)
+		}
+		params.Lines[i] = int32(row.Line)
+		params.Statuses[i] = string(row.Status)
+		params.Cells[i] = cells
+		params.ErrorCodes[i] = row.ErrorCode
+		params.ErrorMessages[i] = row.ErrorMessage
+		if row.Status == entities.RowStatusFailed {
+			failed++
+		} else {
+			imported++
+		}
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	if len(rows) > 0 {
+		if err := qtx.InsertDataImportRows(ctx, params); err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+	err = qtx.MarkDataImportCompleted(ctx, db.MarkDataImportCompletedParams{ID: pgUUID, ImportedRows: imported, FailedRows: failed})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := qtx.DeleteDataImportFile(ctx, pgUUID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresImportJobRepository) MarkFailed(ctx context.Context, importID string, reason string) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(importID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := r.queries.MarkDataImportFailed(ctx, db.MarkDataImportFailedParams{ID: pgUUID, FailureReason: &reason}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func toImportJob(row db.DataImport) (*entities.ImportJob, error) {
+	var header []string
+	if err := json.Unmarshal(row.Header, &header); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to deserialize import header", err)
+	}
+	mapping := make(entities.Mapping)
+	if err := json.Unmarshal(row.Mapping, &mapping); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to deserialize import mapping", err)
+	}
+
+	job, err := entities.NewImportJob(row.ID.String(), row.TenantID, row.Entity, row.FileName, header, mapping,
+		int(row.TotalRows), row.RequestedBy, row.CreatedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	job.Status = entities.ImportJobStatus(row.Status)
+	job.ImportedRows = int(row.ImportedRows)
+	job.FailedRows = int(row.FailedRows)
+	job.FailureReason = valueOrEmpty(row.FailureReason)
+	job.UpdatedAt = row.UpdatedAt.Time
+	if row.CompletedAt.Valid {
+		job.CompletedAt = &row.CompletedAt.Time
+	}
+
+	return job, nil
+}
+
+// toRowResult maps the rows of both row queries, they select the same columns
+func toRowResult(row db.ListFailedDataImportRowsRow) (*entities.RowResult, error) {
+	var cells []string
+	if err := json.Unmarshal(row.Cells, &cells); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to deserialize import row", err)
+	}
+
+	return &entities.RowResult{
+		Line:         int(row.Line),
+		Status:       entities.RowStatus(row.Status),
+		Cells:        cells,
+		ErrorCode:    valueOrEmpty(row.ErrorCode),
+		ErrorMessage: valueOrEmpty(row.ErrorMessage),
+	}, nil
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,27 @@
+package adapters
+
+import (
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// CasbinImportPermissions checks the permission of a definition in Casbin, the rows of an import are
+// written with what the importing user could write by hand
+type CasbinImportPermissions struct {
+	casbinService *authorization.CasbinService
+}
+
+func NewCasbinImportPermissions(casbinService *authorization.CasbinService) ports.ImportPermissions {
+	return &CasbinImportPermissions{
+		casbinService: casbinService,
+	}
+}
+
+func (p *CasbinImportPermissions) CanImport(tenantID string, userID string, definition entities.Definition) (bool, error) {
+	allowed, authzErr := p.casbinService.CanDo(userID, definition.Resource, definition.Action, tenantID)
+	if authzErr != nil {
+		return false, authzErr
+	}
+	return allowed, nil
+}
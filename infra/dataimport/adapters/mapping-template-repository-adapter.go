@@ -0,0 +1,119 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresMappingTemplateRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresMappingTemplateRepository(dbInstance *pgxpool.Pool) ports.MappingTemplateRepository {
+	return &PostgresMappingTemplateRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresMappingTemplateRepository) Save(ctx context.Context, template *entities.MappingTemplate) (*entities.MappingTemplate, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(template.ID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	mapping, err := json.Marshal(template.Mapping)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to serialize mapping template", err)
+	}
+
+	row, err := r.queries.UpsertImportMappingTemplate(ctx, db.UpsertImportMappingTemplateParams{
+		ID:        pgUUID,
+		TenantID:  template.TenantID,
+		Entity:    template.Entity,
+		Name:      template.Name,
+		Mapping:   mapping,
+		CreatedBy: template.CreatedBy,
+		CreatedAt: pgtype.Timestamptz{Time: template.CreatedAt, Valid: true},
+		UpdatedAt: pgtype.Timestamptz{Time: template.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toMappingTemplate(row)
+}
+
+func (r *PostgresMappingTemplateRepository) FindByID(ctx context.Context, tenantID string, templateID string) (*entities.MappingTemplate, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(templateID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetImportMappingTemplate(ctx, db.GetImportMappingTemplateParams{ID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toMappingTemplate(row)
+}
+
+func (r *PostgresMappingTemplateRepository) List(ctx context.Context, tenantID string, entity string) ([]*entities.MappingTemplate, error) {
+	params := db.ListImportMappingTemplatesParams{TenantID: tenantID}
+	if entity != "" {
+		params.Entity = &entity
+	}
+
+	rows, err := r.queries.ListImportMappingTemplates(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	templates := make([]*entities.MappingTemplate, 0, len(rows))
+	for _, row := range rows {
+		template, err := toMappingTemplate(row)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+func (r *PostgresMappingTemplateRepository) Delete(ctx context.Context, tenantID string, templateID string) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(templateID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	deleted, err := r.queries.DeleteImportMappingTemplate(ctx, db.DeleteImportMappingTemplateParams{ID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return deleted > 0, nil
+}
+
+func toMappingTemplate(row db.ImportMappingTemplate) (*entities.MappingTemplate, error) {
+	mapping := make(entities.Mapping)
+	if err := json.Unmarshal(row.Mapping, &mapping); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to deserialize mapping template", err)
+	}
+
+	return entities.NewMappingTemplate(row.ID.String(), row.TenantID, row.Entity, row.Name, mapping, row.CreatedBy,
+		row.CreatedAt.Time, row.UpdatedAt.Time)
+}
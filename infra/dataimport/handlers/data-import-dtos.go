@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"time"
+
+	preview_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/preview-import-use-case"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type ImportFieldResponse struct {
+	Name        string `json:"name"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+	Sensitive   bool   `json:"sensitive" doc:"Sensitive values are not returned by previews nor kept with the import rows"`
+}
+
+type ImportDefinitionResponse struct {
+	Entity      string                `json:"entity"`
+	Description string                `json:"description"`
+	Fields      []ImportFieldResponse `json:"fields"`
+	Key         []string              `json:"key" doc:"Fields identifying a row, a file cannot repeat them"`
+	Permission  string                `json:"permission" doc:"Permission the user needs besides running imports"`
+}
+
+type ListImportDefinitionsResponse struct {
+	Body struct {
+		Items []ImportDefinitionResponse `json:"items"`
+	}
+}
+
+type PreviewImportRequest struct {
+	Body struct {
+		Entity     string            `json:"entity" doc:"One of the entities returned by the import definitions endpoint"`
+		Content    []byte            `json:"content" doc:"Base64 encoded CSV file with a header row, at most 5 MiB"`
+		TemplateID string            `json:"template_id,omitempty" format:"uuid" doc:"Mapping template to read the file with"`
+		Mapping    map[string]string `json:"mapping,omitempty" doc:"Column of the file for each field, overrides the template. Unmapped fields are read from the column of the same name."`
+	}
+}
+
+type ImportRecordResponse struct {
+	Line   int               `json:"line"`
+	Values map[string]string `json:"values"`
+}
+
+type ImportRowResponse struct {
+	Line         int                `json:"line"`
+	Status       string             `json:"status" enum:"valid,imported,failed"`
+	Cells        []string           `json:"cells" doc:"Cells of the row as read from the file, sensitive cells are blank"`
+	Error        *utils.ErrorDetail `json:"error,omitempty" doc:"Set when the row failed, the message lists the fields to fix"`
+	ErrorCode    string             `json:"error_code,omitempty" deprecated:"true" doc:"Same as error.code"`
+	ErrorMessage string             `json:"error_message,omitempty" deprecated:"true" doc:"Same as error.message"`
+}
+
+func NewImportRowResponse(row entities.RowResult) ImportRowResponse {
+	response := ImportRowResponse{
+		Line:         row.Line,
+		Status:       string(row.Status),
+		Cells:        row.Cells,
+		ErrorCode:    row.ErrorCode,
+		ErrorMessage: row.ErrorMessage,
+	}
+	if row.ErrorCode != "" {
+		response.Error = &utils.ErrorDetail{Code: row.ErrorCode, Message: row.ErrorMessage}
+	}
+	return response
+}
+
+type PreviewImportResponse struct {
+	Body struct {
+		Entity     string                 `json:"entity"`
+		Header     []string               `json:"header"`
+		Mapping    map[string]string      `json:"mapping" doc:"Mapping the file was read with, after applying the template"`
+		TotalRows  int                    `json:"total_rows"`
+		ValidRows  int                    `json:"valid_rows"`
+		FailedRows int                    `json:"failed_rows"`
+		Sample     []ImportRecordResponse `json:"sample" doc:"First valid rows as they would be imported"`
+		Failures   []ImportRowResponse    `json:"failures" doc:"First failed rows"`
+	}
+}
+
+func NewPreviewImportResponse(preview *preview_import_use_case.ImportPreview) *PreviewImportResponse {
+	response := &PreviewImportResponse{}
+	response.Body.Entity = preview.Entity
+	response.Body.Header = preview.Header
+	response.Body.Mapping = preview.Mapping
+	response.Body.TotalRows = preview.TotalRows
+	response.Body.ValidRows = preview.ValidRows
+	response.Body.FailedRows = preview.FailedRows
+	response.Body.Sample = make([]ImportRecordResponse, 0, len(preview.Sample))
+	for _, record := range preview.Sample {
+		response.Body.Sample = append(response.Body.Sample, ImportRecordResponse{Line: record.Line, Values: record.Values})
+	}
+	response.Body.Failures = make([]ImportRowResponse, 0, len(preview.Failures))
+	for _, row := range preview.Failures {
+		response.Body.Failures = append(response.Body.Failures, NewImportRowResponse(row))
+	}
+	return response
+}
+
+type StartImportRequest struct {
+	Body struct {
+		Entity     string            `json:"entity" doc:"One of the entities returned by the import definitions endpoint"`
+		FileName   string            `json:"file_name" maxLength:"255"`
+		Content    []byte            `json:"content" doc:"Base64 encoded CSV file with a header row, at most 5 MiB"`
+		TemplateID string            `json:"template_id,omitempty" format:"uuid" doc:"Mapping template to read the file with"`
+		Mapping    map[string]string `json:"mapping,omitempty" doc:"Column of the file for each field, overrides the template. Unmapped fields are read from the column of the same name."`
+	}
+}
+
+type ImportResponse struct {
+	ID            string            `json:"id"`
+	Entity        string            `json:"entity"`
+	FileName      string            `json:"file_name"`
+	Header        []string          `json:"header"`
+	Mapping       map[string]string `json:"mapping"`
+	Status        string            `json:"status" enum:"pending,running,completed,failed"`
+	TotalRows     int               `json:"total_rows"`
+	ImportedRows  int               `json:"imported_rows"`
+	FailedRows    int               `json:"failed_rows"`
+	FailureReason string            `json:"failure_reason,omitempty"`
+	RequestedBy   string            `json:"requested_by"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	CompletedAt   *time.Time        `json:"completed_at,omitempty"`
+}
+
+func NewImportResponse(job *entities.ImportJob) ImportResponse {
+	return ImportResponse{
+		ID:            job.ID,
+		Entity:        job.Entity,
+		FileName:      job.FileName,
+		Header:        job.Header,
+		Mapping:       job.Mapping,
+		Status:        string(job.Status),
+		TotalRows:     job.TotalRows,
+		ImportedRows:  job.ImportedRows,
+		FailedRows:    job.FailedRows,
+		FailureReason: job.FailureReason,
+		RequestedBy:   job.RequestedBy,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+		CompletedAt:   job.CompletedAt,
+	}
+}
+
+type ImportEnvelope struct {
+	Body ImportResponse
+}
+
+type ImportAcceptedEnvelope struct {
+	Location string `header:"Location" doc:"Operation to poll until the import is done"`
+	Body     ImportResponse
+}
+
+type ListImportsRequest struct {
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+}
+
+type ListImportsResponse struct {
+	Body utils.ListResponseBody[ImportResponse]
+}
+
+func NewListImportsResponse(page *pagination.Page[*entities.ImportJob]) *ListImportsResponse {
+	return &ListImportsResponse{Body: utils.NewListResponseBody(page, NewImportResponse)}
+}
+
+type GetImportRequest struct {
+	ImportID string `path:"importId" format:"uuid" doc:"Import ID"`
+}
+
+type ListImportRowsRequest struct {
+	ImportID string `path:"importId" format:"uuid" doc:"Import ID"`
+	Status   string `query:"status" enum:"imported,failed" doc:"Only return rows with this status"`
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+}
+
+type ListImportRowsResponse struct {
+	Body utils.ListResponseBody[ImportRowResponse]
+}
+
+func NewListImportRowsResponse(page *pagination.Page[*entities.RowResult]) *ListImportRowsResponse {
+	return &ListImportRowsResponse{Body: utils.NewListResponseBody(page, func(row *entities.RowResult) ImportRowResponse {
+		return NewImportRowResponse(*row)
+	})}
+}
+
+type DownloadImportErrorsResponse struct {
+	ContentType        string `header:"Content-Type"`
+	ContentDisposition string `header:"Content-Disposition"`
+	Body               []byte
+}
+
+type MappingTemplateResponse struct {
+	ID        string            `json:"id"`
+	Entity    string            `json:"entity"`
+	Name      string            `json:"name"`
+	Mapping   map[string]string `json:"mapping"`
+	CreatedBy string            `json:"created_by"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+func NewMappingTemplateResponse(template *entities.MappingTemplate) MappingTemplateResponse {
+	return MappingTemplateResponse{
+		ID:        template.ID,
+		Entity:    template.Entity,
+		Name:      template.Name,
+		Mapping:   template.Mapping,
+		CreatedBy: template.CreatedBy,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+}
+
+type SaveMappingTemplateRequest struct {
+	Body struct {
+		Entity  string            `json:"entity" doc:"One of the entities returned by the import definitions endpoint"`
+		Name    string            `json:"name" maxLength:"100" doc:"Saving a template with the name of an existing one of the entity replaces its mapping"`
+		Mapping map[string]string `json:"mapping" doc:"Column of the file for each field"`
+	}
+}
+
+type MappingTemplateEnvelope struct {
+	Body MappingTemplateResponse
+}
+
+type ListMappingTemplatesRequest struct {
+	Entity string `query:"entity" doc:"Only return the templates of this entity"`
+}
+
+type ListMappingTemplatesResponse struct {
+	Body struct {
+		Items []MappingTemplateResponse `json:"items"`
+	}
+}
+
+type DeleteMappingTemplateRequest struct {
+	TemplateID string `path:"templateId" format:"uuid" doc:"Mapping template ID"`
+}
@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	delete_mapping_template_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/delete-mapping-template-use-case"
+	get_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/get-import-use-case"
+	list_imports_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/list-imports-use-case"
+	list_mapping_templates_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/list-mapping-templates-use-case"
+	preview_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/preview-import-use-case"
+	save_mapping_template_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/save-mapping-template-use-case"
+	start_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/start-import-use-case"
+	"github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type DataImportHandlers struct {
+	previewImportUseCase         *preview_import_use_case.PreviewImportUseCase
+	startImportUseCase           *start_import_use_case.StartImportUseCase
+	listImportsUseCase           *list_imports_use_case.ListImportsUseCase
+	getImportUseCase             *get_import_use_case.GetImportUseCase
+	saveMappingTemplateUseCase   *save_mapping_template_use_case.SaveMappingTemplateUseCase
+	listMappingTemplatesUseCase  *list_mapping_templates_use_case.ListMappingTemplatesUseCase
+	deleteMappingTemplateUseCase *delete_mapping_template_use_case.DeleteMappingTemplateUseCase
+}
+
+func NewDataImportHandlers(
+	previewImportUseCase *preview_import_use_case.PreviewImportUseCase,
+	startImportUseCase *start_import_use_case.StartImportUseCase,
+	listImportsUseCase *list_imports_use_case.ListImportsUseCase,
+	getImportUseCase *get_import_use_case.GetImportUseCase,
+	saveMappingTemplateUseCase *save_mapping_template_use_case.SaveMappingTemplateUseCase,
+	listMappingTemplatesUseCase *list_mapping_templates_use_case.ListMappingTemplatesUseCase,
+	deleteMappingTemplateUseCase *delete_mapping_template_use_case.DeleteMappingTemplateUseCase,
+) *DataImportHandlers {
+	return &DataImportHandlers{
+		previewImportUseCase:         previewImportUseCase,
+		startImportUseCase:           startImportUseCase,
+		listImportsUseCase:           listImportsUseCase,
+		getImportUseCase:             getImportUseCase,
+		saveMappingTemplateUseCase:   saveMappingTemplateUseCase,
+		listMappingTemplatesUseCase:  listMappingTemplatesUseCase,
+		deleteMappingTemplateUseCase: deleteMappingTemplateUseCase,
+	}
+}
+
+func (h *DataImportHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-import-definitions",
+		Method:      http.MethodGet,
+		Path:        "/imports/definitions",
+		Summary:     "List the entities that can be imported",
+		Tags:        []string{"Imports"},
+	}, h.ListImportDefinitions)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "preview-import",
+		Method:      http.MethodPost,
+		Path:        "/imports/preview",
+		Summary:     "Preview a CSV import",
+		Description: "Checks every row of the file the way the import would, without writing anything.",
+		Tags:        []string{"Imports"},
+	}, h.PreviewImport)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "start-import",
+		Method:        http.MethodPost,
+		Path:          "/imports",
+		Summary:       "Start a CSV import",
+		Description:   "Queues the file for asynchronous import. Poll the operation in the Location header until it is done, then list the rows of the import or download the failed ones.",
+		Tags:          []string{"Imports"},
+		DefaultStatus: http.StatusAccepted,
+	}, h.StartImport)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-imports",
+		Method:      http.MethodGet,
+		Path:        "/imports",
+		Summary:     "List imports of the tenant",
+		Tags:        []string{"Imports"},
+	}, h.ListImports)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-mapping-templates",
+		Method:      http.MethodGet,
+		Path:        "/imports/templates",
+		Summary:     "List saved column mappings",
+		Tags:        []string{"Imports"},
+	}, h.ListMappingTemplates)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "save-mapping-template",
+		Method:      http.MethodPost,
+		Path:        "/imports/templates",
+		Summary:     "Save a column mapping for later imports",
+		Tags:        []string{"Imports"},
+	}, h.SaveMappingTemplate)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-mapping-template",
+		Method:        http.MethodDelete,
+		Path:          "/imports/templates/{templateId}",
+		Summary:       "Delete a saved column mapping",
+		Tags:          []string{"Imports"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.DeleteMappingTemplate)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-import",
+		Method:      http.MethodGet,
+		Path:        "/imports/{importId}",
+		Summary:     "Get import status",
+		Tags:        []string{"Imports"},
+	}, h.GetImport)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-import-rows",
+		Method:      http.MethodGet,
+		Path:        "/imports/{importId}/rows",
+		Summary:     "List the outcome of every row of an import",
+		Tags:        []string{"Imports"},
+	}, h.ListImportRows)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "download-import-errors",
+		Method:      http.MethodGet,
+		Path:        "/imports/{importId}/errors",
+		Summary:     "Download the failed rows of a finished import",
+		Description: "The file keeps the columns of the imported file, followed by the line and the error of each failed row.",
+		Tags:        []string{"Imports"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Failed rows of the import",
+				Content:     map[string]*huma.MediaType{"text/csv": {}},
+			},
+		},
+	}, h.DownloadImportErrors)
+}
+
+func (h *DataImportHandlers) ListImportDefinitions(ctx context.Context, input *struct{}) (*ListImportDefinitionsResponse, error) {
+	response := &ListImportDefinitionsResponse{}
+	for _, definition := range entities.Definitions {
+		fields := make([]ImportFieldResponse, 0, len(definition.Fields))
+		for _, spec := range definition.Fields {
+			fields = append(fields, ImportFieldResponse{
+				Name:        spec.Name,
+				Required:    spec.Required,
+				Description: spec.Description,
+				Sensitive:   spec.Sensitive,
+			})
+		}
+		response.Body.Items = append(response.Body.Items, ImportDefinitionResponse{
+			Entity:      definition.Entity,
+			Description: definition.Description,
+			Fields:      fields,
+			Key:         definition.Key,
+			Permission:  fmt.Sprintf("%s:%s", definition.Resource, definition.Action),
+		})
+	}
+
+	slices.SortFunc(response.Body.Items, func(a, b ImportDefinitionResponse) int {
+		return strings.Compare(a.Entity, b.Entity)
+	})
+
+	return response, nil
+}
+
+func (h *DataImportHandlers) PreviewImport(ctx context.Context, input *PreviewImportRequest) (*PreviewImportResponse, error) {
+	command, err := preview_import_use_case.NewPreviewImportCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Entity,
+		input.Body.Content,
+		input.Body.TemplateID,
+		input.Body.Mapping,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	preview, err := h.previewImportUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewPreviewImportResponse(preview), nil
+}
+
+func (h *DataImportHandlers) StartImport(ctx context.Context, input *StartImportRequest) (*ImportAcceptedEnvelope, error) {
+	command, err := start_import_use_case.NewStartImportCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Entity,
+		input.Body.FileName,
+		input.Body.Content,
+		input.Body.TemplateID,
+		input.Body.Mapping,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	job, err := h.startImportUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ImportAcceptedEnvelope{Location: utils.OperationLocation(job.ID), Body: NewImportResponse(job)}, nil
+}
+
+func (h *DataImportHandlers) ListImports(ctx context.Context, input *ListImportsRequest) (*ListImportsResponse, error) {
+	command, err := list_imports_use_case.NewListImportsCommand(authorization.TenantIDFromContext(ctx), input.PageSize, input.Cursor)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listImportsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListImportsResponse(page), nil
+}
+
+func (h *DataImportHandlers) GetImport(ctx context.Context, input *GetImportRequest) (*ImportEnvelope, error) {
+	command, err := get_import_use_case.NewGetImportCommand(authorization.TenantIDFromContext(ctx), input.ImportID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	job, err := h.getImportUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ImportEnvelope{Body: NewImportResponse(job)}, nil
+}
+
+func (h *DataImportHandlers) ListImportRows(ctx context.Context, input *ListImportRowsRequest) (*ListImportRowsResponse, error) {
+	command, err := get_import_use_case.NewListImportRowsCommand(authorization.TenantIDFromContext(ctx), input.ImportID,
+		input.Status, input.PageSize, input.Cursor)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.getImportUseCase.Rows(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListImportRowsResponse(page), nil
+}
+
+func (h *DataImportHandlers) DownloadImportErrors(ctx context.Context, input *GetImportRequest) (*DownloadImportErrorsResponse, error) {
+	command, err := get_import_use_case.NewGetImportCommand(authorization.TenantIDFromContext(ctx), input.ImportID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	report, err := h.getImportUseCase.ErrorReport(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DownloadImportErrorsResponse{
+		ContentType:        report.ContentType,
+		ContentDisposition: fmt.Sprintf("attachment; filename=%q", report.FileName),
+		Body:               report.Content,
+	}, nil
+}
+
+func (h *DataImportHandlers) SaveMappingTemplate(ctx context.Context, input *SaveMappingTemplateRequest) (*MappingTemplateEnvelope, error) {
+	command, err := save_mapping_template_use_case.NewSaveMappingTemplateCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Entity,
+		input.Body.Name,
+		input.Body.Mapping,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	template, err := h.saveMappingTemplateUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &MappingTemplateEnvelope{Body: NewMappingTemplateResponse(template)}, nil
+}
+
+func (h *DataImportHandlers) ListMappingTemplates(ctx context.Context, input *ListMappingTemplatesRequest) (*ListMappingTemplatesResponse, error) {
+	command, err := list_mapping_templates_use_case.NewListMappingTemplatesCommand(authorization.TenantIDFromContext(ctx), input.Entity)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	templates, err := h.listMappingTemplatesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ListMappingTemplatesResponse{}
+	response.Body.Items = make([]MappingTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		response.Body.Items = append(response.Body.Items, NewMappingTemplateResponse(template))
+	}
+
+	return response, nil
+}
+
+func (h *DataImportHandlers) DeleteMappingTemplate(ctx context.Context, input *DeleteMappingTemplateRequest) (*struct{}, error) {
+	command, err := delete_mapping_template_use_case.NewDeleteMappingTemplateCommand(authorization.TenantIDFromContext(ctx), input.TemplateID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.deleteMappingTemplateUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,114 @@
+-- name: CreateDataImport :exec
+INSERT INTO data_imports (id, tenant_id, entity, file_name, header, mapping, status, total_rows, requested_by, created_at, updated_at)
+VALUES (@id, @tenant_id, @entity, @file_name, @header, @mapping, @status, @total_rows, @requested_by, @created_at, @updated_at);
+
+-- name: CreateDataImportFile :exec
+INSERT INTO data_import_files (import_id, content)
+VALUES (@import_id, @content);
+
+-- name: GetDataImport :one
+SELECT *
+FROM data_imports
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- Keyset page of the imports newest first, the total counts every import of the tenant
+-- name: ListDataImports :many
+SELECT id, tenant_id, entity, file_name, header, mapping, status, total_rows, imported_rows, failed_rows, failure_reason,
+       requested_by, created_at, updated_at, completed_at,
+       (SELECT count(*) FROM data_imports t WHERE t.tenant_id = @tenant_id) AS total
+FROM data_imports
+WHERE tenant_id = @tenant_id
+  AND (sqlc.narg('after_created_at')::timestamptz IS NULL
+       OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT @page_limit;
+
+-- name: ClaimNextPendingDataImport :one
+UPDATE data_imports
+SET status = 'running', updated_at = NOW()
+WHERE id = (
+    SELECT i.id
+    FROM data_imports i
+    WHERE i.status = 'pending' OR (i.status = 'running' AND i.updated_at < @stale_before)
+    ORDER BY i.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: GetDataImportFile :one
+SELECT content
+FROM data_import_files
+WHERE import_id = @import_id;
+
+-- A reclaimed import saves its rows again, the outcome of the last run wins
+-- name: InsertDataImportRows :exec
+INSERT INTO data_import_rows (import_id, line, status, cells, error_code, error_message)
+SELECT @import_id, r.line, r.status, r.cells, NULLIF(r.error_code, ''), NULLIF(r.error_message, '')
+FROM ROWS FROM (
+    unnest(@lines::int[]), unnest(@statuses::text[]), unnest(@cells::jsonb[]),
+    unnest(@error_codes::text[]), unnest(@error_messages::text[])
+) AS r(line, status, cells, error_code, error_message)
+ON CONFLICT (import_id, line) DO UPDATE
+SET status = EXCLUDED.status, cells = EXCLUDED.cells, error_code = EXCLUDED.error_code, error_message = EXCLUDED.error_message;
+
+-- name: MarkDataImportCompleted :exec
+UPDATE data_imports
+SET status = 'completed',
+    imported_rows = @imported_rows,
+    failed_rows = @failed_rows,
+    failure_reason = NULL,
+    updated_at = NOW(),
+    completed_at = NOW()
+WHERE id = @id;
+
+-- name: MarkDataImportFailed :exec
+UPDATE data_imports
+SET status = 'failed', failure_reason = @failure_reason, updated_at = NOW(), completed_at = NOW()
+WHERE id = @id;
+
+-- name: DeleteDataImportFile :exec
+DELETE FROM data_import_files
+WHERE import_id = @import_id;
+
+-- Keyset page of the rows of an import by line, of a status when it is set
+-- name: ListDataImportRows :many
+SELECT r.line, r.status, r.cells, r.error_code, r.error_message,
+       (SELECT count(*) FROM data_import_rows t
+        WHERE t.import_id = @import_id AND (sqlc.narg('status')::text IS NULL OR t.status = sqlc.narg('status'))) AS total
+FROM data_import_rows r
+JOIN data_imports i ON i.id = r.import_id
+WHERE r.import_id = @import_id AND i.tenant_id = @tenant_id
+  AND (sqlc.narg('status')::text IS NULL OR r.status = sqlc.narg('status'))
+  AND (sqlc.narg('after_line')::int IS NULL OR r.line > sqlc.narg('after_line')::int)
+ORDER BY r.line
+LIMIT @page_limit;
+
+-- name: ListFailedDataImportRows :many
+SELECT r.line, r.status, r.cells, r.error_code, r.error_message
+FROM data_import_rows r
+JOIN data_imports i ON i.id = r.import_id
+WHERE r.import_id = @import_id AND i.tenant_id = @tenant_id AND r.status = 'failed'
+ORDER BY r.line;
+
+-- name: UpsertImportMappingTemplate :one
+INSERT INTO import_mapping_templates (id, tenant_id, entity, name, mapping, created_by, created_at, updated_at)
+VALUES (@id, @tenant_id, @entity, @name, @mapping, @created_by, @created_at, @updated_at)
+ON CONFLICT (tenant_id, entity, name) DO UPDATE
+SET mapping = EXCLUDED.mapping, updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: GetImportMappingTemplate :one
+SELECT *
+FROM import_mapping_templates
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: ListImportMappingTemplates :many
+SELECT *
+FROM import_mapping_templates
+WHERE tenant_id = @tenant_id AND (sqlc.narg('entity')::text IS NULL OR entity = sqlc.narg('entity'))
+ORDER BY entity, name;
+
+-- name: DeleteImportMappingTemplate :execrows
+DELETE FROM import_mapping_templates
+WHERE id = @id AND tenant_id = @tenant_id;
@@ -0,0 +1,52 @@
+-- CSV imports of an entity, read through a mapping of the fields of the entity to columns of the file
+CREATE TABLE data_imports (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    entity VARCHAR(100) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    header JSONB NOT NULL,                 -- columns of the file in order
+    mapping JSONB NOT NULL,                -- field of the entity to column of the file
+    status VARCHAR(20) NOT NULL,           -- pending, running, completed, failed
+    total_rows INTEGER NOT NULL,
+    imported_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    failure_reason TEXT,
+    requested_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_data_imports_tenant_id_created_at ON data_imports(tenant_id, created_at);
+CREATE INDEX idx_data_imports_status ON data_imports(status);
+
+-- Files of the imports the worker has not run yet, deleted once the rows are imported
+CREATE TABLE data_import_files (
+    import_id UUID PRIMARY KEY REFERENCES data_imports(id) ON DELETE CASCADE,
+    content BYTEA NOT NULL
+);
+
+-- Outcome of every row of a completed import, sensitive cells are blank
+CREATE TABLE data_import_rows (
+    import_id UUID NOT NULL REFERENCES data_imports(id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,                 -- line in the file, the header is line 1
+    status VARCHAR(20) NOT NULL,           -- imported, failed
+    cells JSONB NOT NULL,
+    error_code VARCHAR(100),
+    error_message TEXT,
+    PRIMARY KEY (import_id, line)
+);
+
+-- Mappings a tenant saved by name, for files exported by the same system every time
+CREATE TABLE import_mapping_templates (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    entity VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    mapping JSONB NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_import_mapping_templates_name ON import_mapping_templates(tenant_id, entity, name);
@@ -0,0 +1,35 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	run_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/run-import-use-case"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+)
+
+// RunDataImportWorker drains the import queue every interval, it returns when ctx is cancelled.
+// Several instances can run concurrently, imports are claimed with SKIP LOCKED.
+func RunDataImportWorker(ctx context.Context, useCase *run_import_use_case.RunImportUseCase, clock clock.Clock, interval time.Duration) {
+	ticker := clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			processed, err := useCase.Execute(ctx)
+			if err != nil {
+				log.Printf("Data import failed: %v", err)
+			}
+			if !processed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
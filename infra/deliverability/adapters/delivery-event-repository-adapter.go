@@ -0,0 +1,120 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresDeliveryEventRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresDeliveryEventRepository(dbInstance *pgxpool.Pool) ports.DeliveryEventRepository {
+	return &PostgresDeliveryEventRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+type eventKey struct {
+	provider string
+	id       string
+}
+
+func (r *PostgresDeliveryEventRepository) Record(ctx context.Context, events []*entities.DeliveryEvent) (int, error) {
+	params := db.InsertDeliveryEventsParams{
+		Providers:   make([]string, len(events)),
+		EventIds:    make([]string, len(events)),
+		TenantIds:   make([]string, len(events)),
+		Emails:      make([]string, len(events)),
+		Types:       make([]string, len(events)),
+		Details:     make([]string, len(events)),
+		OccurredAts: make([]pgtype.Timestamptz, len(events)),
+	}
+	for i, event := range events {
+		params.Providers[i] = string(event.Provider)
+		params.EventIds[i] = event.ID
+		params.TenantIds[i] = event.TenantID
+		params.Emails[i] = event.Email
+		params.Types[i] = string(event.Type)
+		params.Details[i] = event.Detail
+		params.OccurredAts[i] = pgtype.Timestamptz{Time: event.OccurredAt, Valid: true}
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	inserted, err := qtx.InsertDeliveryEvents(ctx, params)
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	recorded := make(map[eventKey]bool, len(inserted))
+	for _, row := range inserted {
+		recorded[eventKey{provider: row.Provider, id: row.EventID}] = true
+	}
+	var suppressions db.UpsertSuppressionsParams
+	for _, event := range events {
+		suppression := entities.NewSuppression(event)
+		if suppression == nil || !recorded[eventKey{provider: string(event.Provider), id: event.ID}] {
+			continue
+		}
+		suppressions.TenantIds = append(suppressions.TenantIds, suppression.TenantID)
+		suppressions.Emails = append(suppressions.Emails, suppression.Email)
+		suppressions.Reasons = append(suppressions.Reasons, string(suppression.Reason))
+		suppressions.Providers = append(suppressions.Providers, string(suppression.Provider))
+		suppressions.Details = append(suppressions.Details, suppression.Detail)
+		suppressions.SuppressedAts = append(suppressions.SuppressedAts, pgtype.Timestamptz{Time: suppression.SuppressedAt, Valid: true})
+	}
+	if len(suppressions.Emails) > 0 {
+		if err := qtx.UpsertSuppressions(ctx, suppressions); err != nil {
+			return 0, appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	return len(inserted), nil
+}
+
+func (r *PostgresDeliveryEventRepository) Stats(ctx context.Context, tenantID string, from time.Time) (*entities.DeliverabilityStats, error) {
+	since := pgtype.Timestamptz{Time: from, Valid: true}
+	rows, err := r.queries.CountDeliveryEvents(ctx, db.CountDeliveryEventsParams{TenantID: tenantID, Since: since})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	suppressed, err := r.queries.CountSuppressionsSince(ctx, db.CountSuppressionsSinceParams{TenantID: tenantID, Since: since})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	stats := &entities.DeliverabilityStats{TenantID: tenantID, From: from, Suppressed: suppressed}
+	for _, row := range rows {
+		switch entities.DeliveryEventType(row.Type) {
+		case entities.DeliveryEventDelivered:
+			stats.Delivered = row.Count
+		case entities.DeliveryEventBounced:
+			stats.Bounced = row.Count
+		case entities.DeliveryEventSoftBounced:
+			stats.SoftBounced = row.Count
+		case entities.DeliveryEventComplained:
+			stats.Complained = row.Count
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,109 @@
+package adapters
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	deliverabilityErrors "github.com/nahualventure/class-backend/core/app/deliverability/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+type SendGridConfig struct {
+	// VerificationKey is the public key of the signed event webhook, base64 of its DER encoding as
+	// the SendGrid settings show it
+	VerificationKey string
+}
+
+// SendGridDeliveryWebhook reads the signed event webhook of SendGrid. Senders tag emails with the
+// tenant in a tenant_id custom argument, which SendGrid adds to every event of the email.
+type SendGridDeliveryWebhook struct {
+	key *ecdsa.PublicKey
+}
+
+func NewSendGridDeliveryWebhook(config SendGridConfig) (ports.DeliveryWebhook, error) {
+	der, err := base64.StdEncoding.DecodeString(config.VerificationKey)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("SendGrid verification key is not base64", err)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to parse SendGrid verification key", err)
+	}
+	key, ok := parsed.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, appErrors.NewInfrastructureError("SendGrid verification key is not an ECDSA key", nil)
+	}
+	return &SendGridDeliveryWebhook{key: key}, nil
+}
+
+type sendGridEvent struct {
+	ID        string `json:"sg_event_id"`
+	Email     string `json:"email"`
+	Event     string `json:"event"`
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Response  string `json:"response"`
+	Timestamp int64  `json:"timestamp"`
+	TenantID  string `json:"tenant_id"`
+}
+
+// Verify checks the ECDSA signature of the timestamp followed by the body. Batches have no ID, the
+// events of a batch SendGrid retries keep their sg_event_id and are recorded once.
+func (w *SendGridDeliveryWebhook) Verify(_ context.Context, payload []byte, signature string, timestamp string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || timestamp == "" {
+		return "", w.invalid("malformed signature")
+	}
+	digest := sha256.Sum256(append([]byte(timestamp), payload...))
+	if !ecdsa.VerifyASN1(w.key, digest[:], decoded) {
+		return "", w.invalid("signature mismatch")
+	}
+	return "", nil
+}
+
+func (w *SendGridDeliveryWebhook) ParseEvents(_ context.Context, payload []byte) ([]*entities.DeliveryEvent, error) {
+	var received []sendGridEvent
+	if err := json.Unmarshal(payload, &received); err != nil {
+		return nil, w.invalid("malformed body")
+	}
+
+	events := make([]*entities.DeliveryEvent, 0, len(received))
+	for _, item := range received {
+		var eventType entities.DeliveryEventType
+		detail := item.Reason
+		switch item.Event {
+		case "delivered":
+			eventType = entities.DeliveryEventDelivered
+			detail = item.Response
+		case "bounce":
+			// Blocked emails were refused for the content or the sending IP, not for the address
+			eventType = entities.DeliveryEventBounced
+			if item.Type == "blocked" {
+				eventType = entities.DeliveryEventSoftBounced
+			}
+		case "spamreport":
+			eventType = entities.DeliveryEventComplained
+		default:
+			continue
+		}
+
+		event, err := entities.NewDeliveryEvent(entities.EmailProviderSendGrid, item.ID, item.TenantID, item.Email, eventType,
+			detail, time.Unix(item.Timestamp, 0).UTC())
+		// An event without its ID or address cannot be recorded once, the rest of the batch is
+		if err == nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (w *SendGridDeliveryWebhook) invalid(reason string) error {
+	return deliverabilityErrors.NewInvalidEmailWebhookError(string(entities.EmailProviderSendGrid), reason)
+}
@@ -0,0 +1,339 @@
+package adapters
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	deliverabilityErrors "github.com/nahualventure/class-backend/core/app/deliverability/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+)
+
+// snsHost matches the hosts SNS serves its signing certificates and subscription confirmations from,
+// links to any other host in a message are not followed
+var snsHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+type SESConfig struct {
+	// TopicARNs are the SNS topics the SES notifications of the senders are published to, messages
+	// of other topics are refused
+	TopicARNs []string
+}
+
+// SESDeliveryWebhook reads the SES notifications an SNS topic publishes to the webhook. SNS signs
+// every message with a certificate it serves, subscriptions to the topic are confirmed when SNS asks.
+// Senders tag emails with the tenant in a tenant_id message tag.
+type SESDeliveryWebhook struct {
+	config SESConfig
+	client *http.Client
+	now    func() time.Time
+
+	mu           sync.Mutex
+	certificates map[string]*x509.Certificate
+}
+
+func NewSESDeliveryWebhook(config SESConfig) ports.DeliveryWebhook {
+	return &SESDeliveryWebhook{
+		config:       config,
+		client:       &http.Client{Timeout: 10 * time.Second, Transport: cost.Transport("ses", nil)},
+		now:          time.Now,
+		certificates: make(map[string]*x509.Certificate),
+	}
+}
+
+type snsMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL"`
+}
+
+// sesNotification is both a notification of the identity, with notificationType, and an event of a
+// configuration set, with eventType
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Mail             struct {
+		Tags map[string][]string `json:"tags"`
+	} `json:"mail"`
+	Bounce struct {
+		BounceType        string `json:"bounceType"`
+		BounceSubType     string `json:"bounceSubType"`
+		Timestamp         string `json:"timestamp"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint struct {
+		ComplaintFeedbackType string `json:"complaintFeedbackType"`
+		Timestamp             string `json:"timestamp"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+	Delivery struct {
+		Timestamp    string   `json:"timestamp"`
+		Recipients   []string `json:"recipients"`
+		SMTPResponse string   `json:"smtpResponse"`
+	} `json:"delivery"`
+}
+
+// Verify checks the message comes from one of the topics and is signed by SNS, SNS delivers the
+// same MessageId again when it retries
+func (w *SESDeliveryWebhook) Verify(ctx context.Context, payload []byte, _ string, _ string) (string, error) {
+	message, err := w.decode(payload)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(w.config.TopicARNs, message.TopicArn) {
+		return "", w.invalid("unexpected topic")
+	}
+	if err := w.verifySignature(ctx, message); err != nil {
+		return "", err
+	}
+	return message.MessageID, nil
+}
+
+func (w *SESDeliveryWebhook) ParseEvents(ctx context.Context, payload []byte) ([]*entities.DeliveryEvent, error) {
+	message, err := w.decode(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	switch message.Type {
+	case "SubscriptionConfirmation":
+		return nil, w.confirmSubscription(ctx, message.SubscribeURL)
+	case "Notification":
+		return w.toEvents(message)
+	default:
+		return nil, nil
+	}
+}
+
+func (w *SESDeliveryWebhook) decode(payload []byte) (snsMessage, error) {
+	var message snsMessage
+	if err := json.Unmarshal(payload, &message); err != nil || message.MessageID == "" {
+		return snsMessage{}, w.invalid("malformed body")
+	}
+	return message, nil
+}
+
+// verifySignature checks the signature of the message with the certificate of SNS, SHA1 with RSA in
+// version 1 and SHA256 with RSA in version 2
+func (w *SESDeliveryWebhook) verifySignature(ctx context.Context, message snsMessage) error {
+	var hash crypto.Hash
+	switch message.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return w.invalid("unsupported signature version")
+	}
+	signature, err := base64.StdEncoding.DecodeString(message.Signature)
+	if err != nil {
+		return w.invalid("malformed signature")
+	}
+
+	certificate, err := w.certificate(ctx, message.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	key, ok := certificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return w.invalid("unexpected signing certificate key")
+	}
+	if now := w.now(); now.Before(certificate.NotBefore) || now.After(certificate.NotAfter) {
+		return w.invalid("signing certificate expired")
+	}
+
+	canonical := []byte(canonicalSNSMessage(message))
+	var digest []byte
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum(canonical)
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256(canonical)
+		digest = sum[:]
+	}
+	if err := rsa.VerifyPKCS1v15(key, hash, digest, signature); err != nil {
+		return w.invalid("signature mismatch")
+	}
+	return nil
+}
+
+// canonicalSNSMessage is the string SNS signs, the name and value of the signed keys of the message
+// type in alphabetical order, one per line
+func canonicalSNSMessage(message snsMessage) string {
+	fields := [][2]string{{"Message", message.Message}, {"MessageId", message.MessageID}}
+	if message.Type == "Notification" {
+		if message.Subject != "" {
+			fields = append(fields, [2]string{"Subject", message.Subject})
+		}
+	} else {
+		fields = append(fields, [2]string{"SubscribeURL", message.SubscribeURL})
+	}
+	fields = append(fields, [2]string{"Timestamp", message.Timestamp})
+	if message.Type != "Notification" {
+		fields = append(fields, [2]string{"Token", message.Token})
+	}
+	fields = append(fields, [2]string{"TopicArn", message.TopicArn}, [2]string{"Type", message.Type})
+
+	var canonical strings.Builder
+	for _, field := range fields {
+		canonical.WriteString(field[0] + "\n" + field[1] + "\n")
+	}
+	return canonical.String()
+}
+
+// certificate returns the signing certificate at the URL, certificates are fetched once per instance
+func (w *SESDeliveryWebhook) certificate(ctx context.Context, certificateURL string) (*x509.Certificate, error) {
+	if !isSNSURL(certificateURL) || !strings.HasSuffix(certificateURL, ".pem") {
+		return nil, w.invalid("unexpected signing certificate URL")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if certificate, ok := w.certificates[certificateURL]; ok {
+		return certificate, nil
+	}
+
+	body, err := w.get(ctx, certificateURL)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(body)
+	if block == nil {
+		return nil, appErrors.NewInfrastructureError("SNS signing certificate is not PEM", nil)
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to parse SNS signing certificate", err)
+	}
+	w.certificates[certificateURL] = certificate
+	return certificate, nil
+}
+
+// confirmSubscription visits the confirmation link SNS sends once the webhook is subscribed to the
+// topic, notifications are only published to confirmed subscriptions
+func (w *SESDeliveryWebhook) confirmSubscription(ctx context.Context, subscribeURL string) error {
+	if !isSNSURL(subscribeURL) {
+		return w.invalid("unexpected subscription URL")
+	}
+	_, err := w.get(ctx, subscribeURL)
+	return err
+}
+
+func (w *SESDeliveryWebhook) get(ctx context.Context, target string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to build SNS request", err)
+	}
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to reach SNS", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to read SNS response", err)
+	}
+	if response.StatusCode/100 != 2 {
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("SNS answered %d: %.1024s", response.StatusCode, body), nil)
+	}
+	return body, nil
+}
+
+// toEvents translates the notification to an event per recipient. Recipients are numbered in the
+// message, SNS delivers the same MessageId again when it retries.
+func (w *SESDeliveryWebhook) toEvents(message snsMessage) ([]*entities.DeliveryEvent, error) {
+	var notification sesNotification
+	if err := json.Unmarshal([]byte(message.Message), &notification); err != nil {
+		return nil, w.invalid("malformed notification")
+	}
+
+	var tenantID string
+	if tags := notification.Mail.Tags["tenant_id"]; len(tags) > 0 {
+		tenantID = tags[0]
+	}
+	fallback, _ := time.Parse(time.RFC3339, message.Timestamp)
+
+	var events []*entities.DeliveryEvent
+	add := func(i int, email string, eventType entities.DeliveryEventType, detail string, timestamp string) {
+		occurredAt, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			occurredAt = fallback
+		}
+		event, err := entities.NewDeliveryEvent(entities.EmailProviderSES, message.MessageID+"/"+strconv.Itoa(i), tenantID, email,
+			eventType, detail, occurredAt.UTC())
+		// A recipient without an address cannot be suppressed nor counted, the rest of the message is
+		if err == nil {
+			events = append(events, event)
+		}
+	}
+
+	notificationType := notification.NotificationType
+	if notificationType == "" {
+		notificationType = notification.EventType
+	}
+	switch notificationType {
+	case "Bounce":
+		bounce := notification.Bounce
+		eventType := entities.DeliveryEventSoftBounced
+		if bounce.BounceType == "Permanent" {
+			eventType = entities.DeliveryEventBounced
+		}
+		for i, recipient := range bounce.BouncedRecipients {
+			detail := strings.TrimSpace(bounce.BounceSubType + " " + recipient.DiagnosticCode)
+			add(i, recipient.EmailAddress, eventType, detail, bounce.Timestamp)
+		}
+	case "Complaint":
+		complaint := notification.Complaint
+		for i, recipient := range complaint.ComplainedRecipients {
+			add(i, recipient.EmailAddress, entities.DeliveryEventComplained, complaint.ComplaintFeedbackType, complaint.Timestamp)
+		}
+	case "Delivery":
+		delivery := notification.Delivery
+		for i, recipient := range delivery.Recipients {
+			add(i, recipient, entities.DeliveryEventDelivered, delivery.SMTPResponse, delivery.Timestamp)
+		}
+	}
+	return events, nil
+}
+
+func (w *SESDeliveryWebhook) invalid(reason string) error {
+	return deliverabilityErrors.NewInvalidEmailWebhookError(string(entities.EmailProviderSES), reason)
+}
+
+func isSNSURL(target string) bool {
+	parsed, err := url.Parse(target)
+	return err == nil && parsed.Scheme == "https" && snsHost.MatchString(parsed.Host)
+}
@@ -0,0 +1,111 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSuppressionRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresSuppressionRepository(dbInstance *pgxpool.Pool) ports.SuppressionRepository {
+	return &PostgresSuppressionRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresSuppressionRepository) Suppressed(ctx context.Context, emails []string) ([]*entities.Suppression, error) {
+	rows, err := r.queries.FindSuppressed(ctx, emails)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	suppressions := make([]*entities.Suppression, 0, len(rows))
+	for _, row := range rows {
+		suppressions = append(suppressions, toSuppression(row))
+	}
+	return suppressions, nil
+}
+
+func (r *PostgresSuppressionRepository) Find(ctx context.Context, tenantID string, email string) (*entities.Suppression, error) {
+	row, err := r.queries.GetSuppression(ctx, db.GetSuppressionParams{TenantID: tenantID, Email: email})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toSuppression(row), nil
+}
+
+func (r *PostgresSuppressionRepository) List(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.Suppression], error) {
+	params := db.ListSuppressionsParams{
+		TenantID:  tenantID,
+		PageLimit: int32(page.PageSize + 1),
+	}
+	if page.After != nil {
+		if len(page.After) != 2 {
+			return nil, appErrors.NewInfrastructureError("cursor keys do not match the order", nil)
+		}
+		at, err := pagination.ParseTimeKey(page.After[0])
+		if err != nil {
+			return nil, appErrors.NewInfrastructureError("invalid cursor time key", err)
+		}
+		params.AfterSuppressedAt = pgtype.Timestamptz{Time: at, Valid: true}
+		params.AfterEmail = &page.After[1]
+	}
+
+	rows, err := r.queries.ListSuppressions(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	suppressions := make([]*entities.Suppression, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		suppressions = append(suppressions, toSuppression(db.EmailSuppression{
+			TenantID:     row.TenantID,
+			Email:        row.Email,
+			Reason:       row.Reason,
+			Provider:     row.Provider,
+			Detail:       row.Detail,
+			SuppressedAt: row.SuppressedAt,
+		}))
+	}
+
+	return pagination.NewPage(page, suppressions, total, func(suppression *entities.Suppression) []string {
+		return []string{pagination.TimeKey(suppression.SuppressedAt), suppression.Email}
+	}), nil
+}
+
+func (r *PostgresSuppressionRepository) Remove(ctx context.Context, tenantID string, email string) (bool, error) {
+	removed, err := r.queries.DeleteSuppression(ctx, db.DeleteSuppressionParams{TenantID: tenantID, Email: email})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return removed > 0, nil
+}
+
+func toSuppression(row db.EmailSuppression) *entities.Suppression {
+	return &entities.Suppression{
+		TenantID:     row.TenantID,
+		Email:        row.Email,
+		Reason:       entities.SuppressionReason(row.Reason),
+		Provider:     entities.EmailProvider(row.Provider),
+		Detail:       row.Detail,
+		SuppressedAt: row.SuppressedAt.Time,
+	}
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type SESWebhookRequest struct {
+	RawBody []byte
+}
+
+type SendGridWebhookRequest struct {
+	Signature string `header:"X-Twilio-Email-Event-Webhook-Signature" required:"true" doc:"base64 ECDSA signature of the timestamp followed by the body"`
+	Timestamp string `header:"X-Twilio-Email-Event-Webhook-Timestamp" required:"true"`
+	RawBody   []byte
+}
+
+type CheckSuppressionsRequest struct {
+	Body struct {
+		Emails []string `json:"emails" minItems:"1" maxItems:"1000" doc:"Recipients of the email about to be sent"`
+	}
+}
+
+type SuppressionResponse struct {
+	Email        string    `json:"email"`
+	Reason       string    `json:"reason" enum:"bounce,complaint"`
+	Provider     string    `json:"provider" enum:"ses,sendgrid"`
+	Detail       string    `json:"detail,omitempty" doc:"Reason the provider gave, such as the SMTP diagnostic of the bounce"`
+	SuppressedAt time.Time `json:"suppressed_at"`
+}
+
+func NewSuppressionResponse(suppression *entities.Suppression, loc *time.Location) SuppressionResponse {
+	return SuppressionResponse{
+		Email:        suppression.Email,
+		Reason:       string(suppression.Reason),
+		Provider:     string(suppression.Provider),
+		Detail:       suppression.Detail,
+		SuppressedAt: utils.InLocation(suppression.SuppressedAt, loc),
+	}
+}
+
+// CheckSuppressionsResponse leaves out the detail, the suppression may be of another tenant
+type CheckSuppressionsResponse struct {
+	Body struct {
+		Suppressed []CheckedSuppressionResponse `json:"suppressed" doc:"Recipients the email must not be sent to, the others are not listed"`
+	}
+}
+
+type CheckedSuppressionResponse struct {
+	Email  string `json:"email"`
+	Reason string `json:"reason" enum:"bounce,complaint"`
+}
+
+func NewCheckSuppressionsResponse(suppressions []*entities.Suppression) *CheckSuppressionsResponse {
+	response := &CheckSuppressionsResponse{}
+	response.Body.Suppressed = make([]CheckedSuppressionResponse, 0, len(suppressions))
+	for _, suppression := range suppressions {
+		response.Body.Suppressed = append(response.Body.Suppressed, CheckedSuppressionResponse{
+			Email:  suppression.Email,
+			Reason: string(suppression.Reason),
+		})
+	}
+	return response
+}
+
+type ListSuppressionsRequest struct {
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+}
+
+type ListSuppressionsResponse struct {
+	Body utils.ListResponseBody[SuppressionResponse]
+}
+
+func NewListSuppressionsResponse(page *pagination.Page[*entities.Suppression], loc *time.Location) *ListSuppressionsResponse {
+	return &ListSuppressionsResponse{Body: utils.NewListResponseBody(page, func(suppression *entities.Suppression) SuppressionResponse {
+		return NewSuppressionResponse(suppression, loc)
+	})}
+}
+
+type SuppressionPathRequest struct {
+	Email string `path:"email" maxLength:"255" doc:"Suppressed address"`
+}
+
+type GetDeliverabilityRequest struct {
+	Days int `query:"days" minimum:"1" maximum:"90" default:"30" doc:"Number of days counted back from now"`
+}
+
+type DeliverabilityResponse struct {
+	From          time.Time `json:"from" doc:"Start of the counted days"`
+	Delivered     int64     `json:"delivered"`
+	Bounced       int64     `json:"bounced" doc:"Permanent bounces, their addresses are suppressed"`
+	SoftBounced   int64     `json:"soft_bounced" doc:"Transient bounces such as full mailboxes"`
+	Complained    int64     `json:"complained" doc:"Emails marked as spam, their addresses are suppressed"`
+	Suppressed    int64     `json:"suppressed" doc:"Addresses suppressed in the counted days"`
+	BounceRate    float64   `json:"bounce_rate" doc:"Permanent bounces over the emails delivered or bounced"`
+	ComplaintRate float64   `json:"complaint_rate" doc:"Complaints over the delivered emails"`
+	Status        string    `json:"status" enum:"healthy,at_risk" doc:"at_risk over a bounce rate of 5% or a complaint rate of 0.1%, the rates providers review sending accounts over"`
+}
+
+type DeliverabilityEnvelope struct {
+	Body DeliverabilityResponse
+}
+
+func NewDeliverabilityResponse(stats *entities.DeliverabilityStats, loc *time.Location) DeliverabilityResponse {
+	return DeliverabilityResponse{
+		From:          utils.InLocation(stats.From, loc),
+		Delivered:     stats.Delivered,
+		Bounced:       stats.Bounced,
+		SoftBounced:   stats.SoftBounced,
+		Complained:    stats.Complained,
+		Suppressed:    stats.Suppressed,
+		BounceRate:    stats.BounceRate(),
+		ComplaintRate: stats.ComplaintRate(),
+		Status:        string(stats.Status()),
+	}
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	check_suppressions_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/check-suppressions-use-case"
+	get_deliverability_stats_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/get-deliverability-stats-use-case"
+	list_suppressions_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/list-suppressions-use-case"
+	remove_suppression_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/remove-suppression-use-case"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/webhook"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type DeliverabilityHandlers struct {
+	webhooks                      *webhook.Receiver
+	checkSuppressionsUseCase      *check_suppressions_use_case.CheckSuppressionsUseCase
+	listSuppressionsUseCase       *list_suppressions_use_case.ListSuppressionsUseCase
+	removeSuppressionUseCase      *remove_suppression_use_case.RemoveSuppressionUseCase
+	getDeliverabilityStatsUseCase *get_deliverability_stats_use_case.GetDeliverabilityStatsUseCase
+}
+
+func NewDeliverabilityHandlers(
+	webhooks *webhook.Receiver,
+	checkSuppressionsUseCase *check_suppressions_use_case.CheckSuppressionsUseCase,
+	listSuppressionsUseCase *list_suppressions_use_case.ListSuppressionsUseCase,
+	removeSuppressionUseCase *remove_suppression_use_case.RemoveSuppressionUseCase,
+	getDeliverabilityStatsUseCase *get_deliverability_stats_use_case.GetDeliverabilityStatsUseCase,
+) *DeliverabilityHandlers {
+	return &DeliverabilityHandlers{
+		webhooks:                      webhooks,
+		checkSuppressionsUseCase:      checkSuppressionsUseCase,
+		listSuppressionsUseCase:       listSuppressionsUseCase,
+		removeSuppressionUseCase:      removeSuppressionUseCase,
+		getDeliverabilityStatsUseCase: getDeliverabilityStatsUseCase,
+	}
+}
+
+func (h *DeliverabilityHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "ses-webhook",
+		Method:        http.MethodPost,
+		Path:          "/webhooks/ses",
+		Summary:       "Receive the bounces, complaints and deliveries of SES",
+		Description:   "Public endpoint for the SNS topic SES notifications are published to, authenticated by the SNS signature of the message. Subscriptions of the topic are confirmed when SNS asks. Messages are recorded asynchronously, messages received before are acknowledged without being recorded again.",
+		Tags:          []string{"Email Deliverability"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.ReceiveSESEvents)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "sendgrid-webhook",
+		Method:        http.MethodPost,
+		Path:          "/webhooks/sendgrid",
+		Summary:       "Receive the bounces, complaints and deliveries of SendGrid",
+		Description:   "Public endpoint for the signed event webhook of SendGrid, authenticated by its ECDSA signature. Events are recorded asynchronously, batches received before are acknowledged without being recorded again.",
+		Tags:          []string{"Email Deliverability"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.ReceiveSendGridEvents)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "check-email-suppressions",
+		Method:      http.MethodPost,
+		Path:        "/email/suppressions/check",
+		Summary:     "Check the recipients of an email against the suppression list",
+		Description: "Senders call it before every send and skip the suppressed recipients. An address is suppressed once the emails of any tenant bounced permanently or got a complaint.",
+		Tags:        []string{"Email Deliverability"},
+	}, h.CheckSuppressions)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-email-suppressions",
+		Method:      http.MethodGet,
+		Path:        "/email/suppressions",
+		Summary:     "List the addresses the emails of the tenant suppressed",
+		Tags:        []string{"Email Deliverability"},
+	}, h.ListSuppressions)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "remove-email-suppression",
+		Method:        http.MethodDelete,
+		Path:          "/email/suppressions/{email}",
+		Summary:       "Send to a bounced address again",
+		Description:   "For mailboxes that work again. Complaints cannot be removed, and the address stays suppressed while another tenant has it suppressed.",
+		Tags:          []string{"Email Deliverability"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.RemoveSuppression)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-email-deliverability",
+		Method:      http.MethodGet,
+		Path:        "/email/deliverability",
+		Summary:     "Get the delivery, bounce and complaint rates of the emails of the tenant",
+		Tags:        []string{"Email Deliverability"},
+	}, h.GetDeliverability)
+}
+
+func (h *DeliverabilityHandlers) ReceiveSESEvents(ctx context.Context, input *SESWebhookRequest) (*struct{}, error) {
+	return h.receive(ctx, entities.EmailProviderSES, webhook.Request{Payload: input.RawBody})
+}
+
+func (h *DeliverabilityHandlers) ReceiveSendGridEvents(ctx context.Context, input *SendGridWebhookRequest) (*struct{}, error) {
+	return h.receive(ctx, entities.EmailProviderSendGrid, webhook.Request{
+		Payload: input.RawBody,
+		Headers: map[string]string{sendGridSignatureHeader: input.Signature, sendGridTimestampHeader: input.Timestamp},
+	})
+}
+
+func (h *DeliverabilityHandlers) receive(ctx context.Context, provider entities.EmailProvider, request webhook.Request) (*struct{}, error) {
+	if _, err := h.webhooks.Receive(ctx, string(provider), request); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
+
+func (h *DeliverabilityHandlers) CheckSuppressions(ctx context.Context, input *CheckSuppressionsRequest) (*CheckSuppressionsResponse, error) {
+	command, err := check_suppressions_use_case.NewCheckSuppressionsCommand(input.Body.Emails)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	suppressions, err := h.checkSuppressionsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewCheckSuppressionsResponse(suppressions), nil
+}
+
+func (h *DeliverabilityHandlers) ListSuppressions(ctx context.Context, input *ListSuppressionsRequest) (*ListSuppressionsResponse, error) {
+	command, err := list_suppressions_use_case.NewListSuppressionsCommand(authorization.TenantIDFromContext(ctx), input.PageSize, input.Cursor)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listSuppressionsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListSuppressionsResponse(page, utils.LocationFromContext(ctx)), nil
+}
+
+func (h *DeliverabilityHandlers) RemoveSuppression(ctx context.Context, input *SuppressionPathRequest) (*struct{}, error) {
+	command, err := remove_suppression_use_case.NewRemoveSuppressionCommand(authorization.TenantIDFromContext(ctx), input.Email)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.removeSuppressionUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
+
+func (h *DeliverabilityHandlers) GetDeliverability(ctx context.Context, input *GetDeliverabilityRequest) (*DeliverabilityEnvelope, error) {
+	command, err := get_deliverability_stats_use_case.NewGetDeliverabilityStatsCommand(authorization.TenantIDFromContext(ctx), input.Days)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	stats, err := h.getDeliverabilityStatsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DeliverabilityEnvelope{Body: NewDeliverabilityResponse(stats, utils.LocationFromContext(ctx))}, nil
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+
+	receive_delivery_events_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/receive-delivery-events-use-case"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	deliverabilityErrors "github.com/nahualventure/class-backend/core/app/deliverability/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/webhook"
+)
+
+// Headers the signed event webhook of SendGrid is verified with, SES signs its messages in the body
+const (
+	sendGridSignatureHeader = "X-Twilio-Email-Event-Webhook-Signature"
+	sendGridTimestampHeader = "X-Twilio-Email-Event-Webhook-Timestamp"
+)
+
+// NewDeliveryWebhookSource verifies the webhook of the email provider when it is received, its
+// events are recorded on the webhook worker. The source is named after the provider, requests of
+// providers without an entry in webhooks are refused.
+func NewDeliveryWebhookSource(provider entities.EmailProvider, webhooks map[entities.EmailProvider]ports.DeliveryWebhook,
+	receiveDeliveryEventsUseCase *receive_delivery_events_use_case.ReceiveDeliveryEventsUseCase) webhook.Source {
+	return webhook.Source{
+		Name: string(provider),
+		Verify: func(ctx context.Context, request webhook.Request) (string, error) {
+			deliveryWebhook, ok := webhooks[provider]
+			if !ok {
+				return "", deliverabilityErrors.NewInvalidEmailWebhookError(string(provider), "provider not configured")
+			}
+			return deliveryWebhook.Verify(ctx, request.Payload, request.Headers[sendGridSignatureHeader], request.Headers[sendGridTimestampHeader])
+		},
+		Handle: func(ctx context.Context, request webhook.Request) error {
+			command, err := receive_delivery_events_use_case.NewReceiveDeliveryEventsCommand(provider, request.Payload)
+			if err != nil {
+				return err
+			}
+			_, err = receiveDeliveryEventsUseCase.Execute(ctx, command)
+			return err
+		},
+	}
+}
@@ -0,0 +1,55 @@
+-- name: InsertDeliveryEvents :many
+INSERT INTO email_delivery_events (provider, event_id, tenant_id, email, type, detail, occurred_at)
+SELECT unnest(@providers::text[]), unnest(@event_ids::text[]), unnest(@tenant_ids::text[]), unnest(@emails::text[]),
+       unnest(@types::text[]), unnest(@details::text[]), unnest(@occurred_ats::timestamptz[])
+ON CONFLICT (provider, event_id, occurred_at) DO NOTHING
+RETURNING provider, event_id;
+
+-- A complaint is kept over a later bounce of the address
+-- name: UpsertSuppressions :exec
+INSERT INTO email_suppressions (tenant_id, email, reason, provider, detail, suppressed_at)
+SELECT unnest(@tenant_ids::text[]), unnest(@emails::text[]), unnest(@reasons::text[]), unnest(@providers::text[]),
+       unnest(@details::text[]), unnest(@suppressed_ats::timestamptz[])
+ON CONFLICT (tenant_id, email) DO UPDATE
+SET reason = CASE WHEN email_suppressions.reason = 'complaint' THEN email_suppressions.reason ELSE EXCLUDED.reason END,
+    provider = EXCLUDED.provider,
+    detail = EXCLUDED.detail,
+    suppressed_at = GREATEST(email_suppressions.suppressed_at, EXCLUDED.suppressed_at);
+
+-- name: CountDeliveryEvents :many
+SELECT type, count(*) AS count
+FROM email_delivery_events
+WHERE tenant_id = @tenant_id AND occurred_at >= @since
+GROUP BY type;
+
+-- name: CountSuppressionsSince :one
+SELECT count(*)
+FROM email_suppressions
+WHERE tenant_id = @tenant_id AND suppressed_at >= @since;
+
+-- A complaint first, the recipient asked not to receive the emails
+-- name: FindSuppressed :many
+SELECT DISTINCT ON (email) tenant_id, email, reason, provider, detail, suppressed_at
+FROM email_suppressions
+WHERE email = ANY(@emails::text[])
+ORDER BY email, reason = 'complaint' DESC, suppressed_at DESC;
+
+-- name: GetSuppression :one
+SELECT tenant_id, email, reason, provider, detail, suppressed_at
+FROM email_suppressions
+WHERE tenant_id = @tenant_id AND email = @email;
+
+-- name: ListSuppressions :many
+SELECT tenant_id, email, reason, provider, detail, suppressed_at,
+       (SELECT count(*) FROM email_suppressions t WHERE t.tenant_id = @tenant_id) AS total
+FROM email_suppressions
+WHERE tenant_id = @tenant_id
+  AND (sqlc.narg('after_suppressed_at')::timestamptz IS NULL
+       OR (suppressed_at, email) < (sqlc.narg('after_suppressed_at')::timestamptz, sqlc.narg('after_email')::text))
+ORDER BY suppressed_at DESC, email DESC
+LIMIT @page_limit;
+
+-- Complaints are never removed
+-- name: DeleteSuppression :execrows
+DELETE FROM email_suppressions
+WHERE tenant_id = @tenant_id AND email = @email AND reason <> 'complaint';
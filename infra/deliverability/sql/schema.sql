@@ -0,0 +1,32 @@
+-- What happened to the emails of every tenant as the email providers report it, one row per event
+-- and recipient. Providers retry webhooks, an event is recorded once by its provider ID. Partitioned
+-- by month of occurred_at like outbox_events, every delivered email has a row.
+CREATE TABLE email_delivery_events (
+    provider VARCHAR(20) NOT NULL,         -- ses, sendgrid
+    event_id VARCHAR(255) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,       -- empty for emails sent without the tenant tag
+    email VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL,             -- delivered, bounced, soft_bounced, complained
+    detail TEXT NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (provider, event_id, occurred_at)
+) PARTITION BY RANGE (occurred_at);
+
+CREATE TABLE email_delivery_events_default PARTITION OF email_delivery_events DEFAULT;
+
+CREATE INDEX idx_email_delivery_events_tenant ON email_delivery_events(tenant_id, occurred_at);
+
+-- Addresses no email is sent to, by the tenant whose emails bounced or got the complaint. Senders
+-- skip an address any tenant suppressed.
+CREATE TABLE email_suppressions (
+    tenant_id VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    reason VARCHAR(20) NOT NULL,           -- bounce, complaint
+    provider VARCHAR(20) NOT NULL,
+    detail TEXT NOT NULL,
+    suppressed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, email)
+);
+
+CREATE INDEX idx_email_suppressions_email ON email_suppressions(email);
+CREATE INDEX idx_email_suppressions_tenant ON email_suppressions(tenant_id, suppressed_at);
@@ -0,0 +1,52 @@
+package adapters
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// developerSandboxTenantSource names the developer sandboxes among the tenants the policies are
+// activated for
+const developerSandboxTenantSource = "developer-sandboxes"
+
+// CasbinDeveloperSandboxPolicies generates the policies of the roles for developer sandboxes as for
+// the tenants of the configuration. Role assignments are per tenant, so the ones of a sandbox only
+// apply in it.
+type CasbinDeveloperSandboxPolicies struct {
+	casbinService *authorization.CasbinService
+}
+
+func NewCasbinDeveloperSandboxPolicies(casbinService *authorization.CasbinService) ports.DeveloperSandboxPolicies {
+	return &CasbinDeveloperSandboxPolicies{
+		casbinService: casbinService,
+	}
+}
+
+func (p *CasbinDeveloperSandboxPolicies) ReplaceRoles(ctx context.Context, tenantID string, roles map[string][]string) error {
+	if authzErr := p.casbinService.RemoveTenantRoles(ctx, tenantID); authzErr != nil {
+		return authzErr
+	}
+
+	names := make([]string, 0, len(roles))
+	for role := range roles {
+		names = append(names, role)
+	}
+	slices.Sort(names)
+	for _, role := range names {
+		if _, authzErr := p.casbinService.AssignRoleToSubjects(ctx, roles[role], role, tenantID, nil); authzErr != nil {
+			return authzErr
+		}
+	}
+	return nil
+}
+
+// Activate reloads the policies only when the developer sandboxes changed
+func (p *CasbinDeveloperSandboxPolicies) Activate(_ context.Context, tenantIDs []string) error {
+	if authzErr := p.casbinService.ActivateTenants(developerSandboxTenantSource, tenantIDs); authzErr != nil {
+		return authzErr
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresDeveloperSandboxRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresDeveloperSandboxRepository(dbInstance *pgxpool.Pool) ports.DeveloperSandboxRepository {
+	return &PostgresDeveloperSandboxRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresDeveloperSandboxRepository) Create(ctx context.Context, sandbox *entities.DeveloperSandbox) error {
+	id, err := pgUUID(sandbox.ID)
+	if err != nil {
+		return err
+	}
+	serviceAccountID, err := pgUUID(sandbox.ServiceAccountID)
+	if err != nil {
+		return err
+	}
+	tables, err := json.Marshal(sandbox.Tables)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize developer sandbox tables", err)
+	}
+
+	err = r.queries.CreateDeveloperSandbox(ctx, db.CreateDeveloperSandboxParams{
+		ID:               id,
+		TenantID:         sandbox.TenantID,
+		Name:             sandbox.Name,
+		Email:            sandbox.Email,
+		ServiceAccountID: serviceAccountID,
+		Tables:           tables,
+		ResetCount:       int32(sandbox.ResetCount),
+		CreatedAt:        pgtype.Timestamptz{Time: sandbox.CreatedAt, Valid: true},
+		ResetAt:          timestamptz(sandbox.ResetAt),
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresDeveloperSandboxRepository) FindByTenant(ctx context.Context, tenantID string) (*entities.DeveloperSandbox, error) {
+	row, err := r.queries.GetDeveloperSandboxByTenant(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toDeveloperSandbox(row)
+}
+
+func (r *PostgresDeveloperSandboxRepository) CountByEmail(ctx context.Context, email string) (int, error) {
+	count, err := r.queries.CountDeveloperSandboxesByEmail(ctx, email)
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	return int(count), nil
+}
+
+func (r *PostgresDeveloperSandboxRepository) SaveReset(ctx context.Context, sandbox *entities.DeveloperSandbox) error {
+	id, err := pgUUID(sandbox.ID)
+	if err != nil {
+		return err
+	}
+	tables, err := json.Marshal(sandbox.Tables)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize developer sandbox tables", err)
+	}
+
+	err = r.queries.SaveDeveloperSandboxReset(ctx, db.SaveDeveloperSandboxResetParams{
+		ID:         id,
+		Tables:     tables,
+		ResetCount: int32(sandbox.ResetCount),
+		ResetAt:    timestamptz(sandbox.ResetAt),
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresDeveloperSandboxRepository) ListTenantIDs(ctx context.Context) ([]string, error) {
+	tenantIDs, err := r.queries.ListDeveloperSandboxTenants(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return tenantIDs, nil
+}
+
+func toDeveloperSandbox(row db.DeveloperSandbox) (*entities.DeveloperSandbox, error) {
+	sandbox := &entities.DeveloperSandbox{
+		ID:               row.ID.String(),
+		TenantID:         row.TenantID,
+		Name:             row.Name,
+		Email:            row.Email,
+		ServiceAccountID: row.ServiceAccountID.String(),
+		Tables:           map[string]int{},
+		ResetCount:       int(row.ResetCount),
+		CreatedAt:        row.CreatedAt.Time,
+	}
+	if len(row.Tables) > 0 {
+		if err := json.Unmarshal(row.Tables, &sandbox.Tables); err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to deserialize developer sandbox tables", err)
+		}
+	}
+	if row.ResetAt.Valid {
+		resetAt := row.ResetAt.Time
+		sandbox.ResetAt = &resetAt
+	}
+	return sandbox, nil
+}
+
+func pgUUID(id string) (pgtype.UUID, error) {
+	var value pgtype.UUID
+	if err := value.Scan(id); err != nil {
+		return value, appErrors.PropagateError(err)
+	}
+	return value, nil
+}
+
+func timestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
@@ -0,0 +1,118 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// keptTables survive a reset: the sandbox itself and its service account, so its key keeps working
+var keptTables = []string{"developer_sandboxes", "service_accounts", "service_account_keys"}
+
+const (
+	// syntheticPenaltyPercentPerDay and syntheticCutoffDays are the late policy of every assignment
+	syntheticPenaltyPercentPerDay = 10
+	syntheticCutoffDays           = 3
+	// Students turn in an hour before the assignment is due, grades are released two days after
+	syntheticTurnedInBefore = time.Hour
+	syntheticReleasedAfter  = 48 * time.Hour
+)
+
+// PostgresSyntheticData writes the synthetic data of developer sandboxes to the tables the API reads
+type PostgresSyntheticData struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresSyntheticData(dbInstance *pgxpool.Pool) ports.SyntheticData {
+	return &PostgresSyntheticData{db: dbInstance}
+}
+
+// Replace deletes the rows of the tenant from every table of the public schema with a tenant_id
+// column but keptTables, then inserts the data, in one transaction
+func (d *PostgresSyntheticData) Replace(ctx context.Context, tenantID string, data *entities.SyntheticData) (map[string]int, error) {
+	if !entities.IsDeveloperTenant(tenantID) {
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("%s is not a developer sandbox", tenantID), nil)
+	}
+
+	tx, err := d.db.Begin(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT c.relname
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_attribute a ON a.attrelid = c.oid
+		WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p') AND NOT c.relispartition
+		  AND a.attname = 'tenant_id' AND NOT a.attisdropped
+		ORDER BY c.relname`)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	for _, table := range tables {
+		if slices.Contains(keptTables, table) {
+			continue
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM "+pgx.Identifier{"public", table}.Sanitize()+" WHERE tenant_id = $1", tenantID); err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to remove the developer sandbox rows of "+table, err)
+		}
+	}
+
+	written := make(map[string]int)
+	batch := &pgx.Batch{}
+	queue := func(table string, statement string, args ...any) {
+		batch.Queue(statement, args...)
+		written[table]++
+	}
+	for _, class := range data.Classes {
+		queue("class_capacities", `INSERT INTO class_capacities (tenant_id, class_id, capacity, updated_at) VALUES ($1, $2, $3, $4)`,
+			tenantID, class.ID, class.Capacity, data.SeededAt)
+		for _, meeting := range class.Meetings {
+			queue("class_meetings", `INSERT INTO class_meetings (tenant_id, class_id, weekday, start_minute, end_minute) VALUES ($1, $2, $3, $4, $5)`,
+				tenantID, class.ID, meeting.Weekday, meeting.StartMinute, meeting.EndMinute)
+		}
+		for _, studentID := range class.StudentIDs {
+			queue("class_enrollments", `INSERT INTO class_enrollments (tenant_id, class_id, user_id, enrolled_at) VALUES ($1, $2, $3, $4)`,
+				tenantID, class.ID, studentID, data.SeededAt)
+		}
+		dueAt := make(map[string]time.Time, len(class.Assignments))
+		for _, assignment := range class.Assignments {
+			dueAt[assignment.ID] = assignment.DueAt
+			queue("assignment_deadlines", `
+				INSERT INTO assignment_deadlines (tenant_id, class_id, assignment_id, due_at, penalty_percent_per_day, cutoff_days, updated_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+				tenantID, class.ID, assignment.ID, assignment.DueAt, syntheticPenaltyPercentPerDay, syntheticCutoffDays, data.SeededAt)
+		}
+		for _, grade := range class.Grades {
+			turnedInAt := dueAt[grade.AssignmentID].Add(-syntheticTurnedInBefore)
+			releasedAt := dueAt[grade.AssignmentID].Add(syntheticReleasedAfter)
+			queue("grades", `
+				INSERT INTO grades (id, tenant_id, class_id, assignment_id, student_id, score, status, recorded_by, turned_in_at,
+					submitted_at, released_at, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, $5, $6, 'released', $7, $8, $9, $9, $9, $9)`,
+				grade.ID, tenantID, class.ID, grade.AssignmentID, grade.StudentID, grade.Score, class.InstructorID, turnedInAt, releasedAt)
+		}
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to seed the developer sandbox", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return written, nil
+}
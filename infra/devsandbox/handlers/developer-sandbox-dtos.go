@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/devsandbox/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type SyntheticPersonResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role" enum:"instructor,student"`
+}
+
+type SyntheticMeetingResponse struct {
+	Weekday     int `json:"weekday" doc:"0 for sunday"`
+	StartMinute int `json:"start_minute" doc:"Minutes since midnight"`
+	EndMinute   int `json:"end_minute"`
+}
+
+type SyntheticAssignmentResponse struct {
+	ID    string    `json:"id"`
+	Name  string    `json:"name"`
+	DueAt time.Time `json:"due_at"`
+}
+
+type SyntheticClassResponse struct {
+	ID           string                        `json:"id"`
+	Name         string                        `json:"name"`
+	InstructorID string                        `json:"instructor_id"`
+	Capacity     int                           `json:"capacity"`
+	Meetings     []SyntheticMeetingResponse    `json:"meetings"`
+	StudentIDs   []string                      `json:"student_ids"`
+	Assignments  []SyntheticAssignmentResponse `json:"assignments" doc:"The last one is not due yet and has no grades"`
+}
+
+type DeveloperSandboxResponse struct {
+	ID         string                    `json:"id"`
+	TenantID   string                    `json:"tenant_id" doc:"The tenant the key of the sandbox works in"`
+	Name       string                    `json:"name"`
+	Email      string                    `json:"email"`
+	Tables     map[string]int            `json:"tables" doc:"Rows the last seed wrote per table"`
+	ResetCount int                       `json:"reset_count"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	ResetAt    *time.Time                `json:"reset_at,omitempty"`
+	People     []SyntheticPersonResponse `json:"people" doc:"Fake members of the sandbox, the same after every reset"`
+	Classes    []SyntheticClassResponse  `json:"classes" doc:"Fake classes of the sandbox, the same after every reset but for their dates"`
+}
+
+func NewDeveloperSandboxResponse(sandbox *entities.DeveloperSandbox, data *entities.SyntheticData, loc *time.Location) DeveloperSandboxResponse {
+	response := DeveloperSandboxResponse{
+		ID:         sandbox.ID,
+		TenantID:   sandbox.TenantID,
+		Name:       sandbox.Name,
+		Email:      sandbox.Email,
+		Tables:     sandbox.Tables,
+		ResetCount: sandbox.ResetCount,
+		CreatedAt:  utils.InLocation(sandbox.CreatedAt, loc),
+		ResetAt:    utils.InLocationPtr(sandbox.ResetAt, loc),
+		People:     make([]SyntheticPersonResponse, 0, len(data.People)),
+		Classes:    make([]SyntheticClassResponse, 0, len(data.Classes)),
+	}
+	for _, person := range data.People {
+		response.People = append(response.People, SyntheticPersonResponse{ID: person.ID, Name: person.Name, Role: person.Role})
+	}
+	for _, class := range data.Classes {
+		classResponse := SyntheticClassResponse{
+			ID:           class.ID,
+			Name:         class.Name,
+			InstructorID: class.InstructorID,
+			Capacity:     class.Capacity,
+			Meetings:     make([]SyntheticMeetingResponse, 0, len(class.Meetings)),
+			StudentIDs:   class.StudentIDs,
+			Assignments:  make([]SyntheticAssignmentResponse, 0, len(class.Assignments)),
+		}
+		for _, meeting := range class.Meetings {
+			classResponse.Meetings = append(classResponse.Meetings, SyntheticMeetingResponse{
+				Weekday:     meeting.Weekday,
+				StartMinute: meeting.StartMinute,
+				EndMinute:   meeting.EndMinute,
+			})
+		}
+		for _, assignment := range class.Assignments {
+			classResponse.Assignments = append(classResponse.Assignments, SyntheticAssignmentResponse{
+				ID:    assignment.ID,
+				Name:  assignment.Name,
+				DueAt: utils.InLocation(assignment.DueAt, loc),
+			})
+		}
+		response.Classes = append(response.Classes, classResponse)
+	}
+	return response
+}
+
+type CreateDeveloperSandboxRequest struct {
+	Body struct {
+		Name  string `json:"name" minLength:"1" maxLength:"100" doc:"What the integration is, the service account of the sandbox is named after it"`
+		Email string `json:"email" format:"email" maxLength:"255" doc:"Contact of the developer, a few sandboxes may be requested per email"`
+	}
+}
+
+type CreateDeveloperSandboxResponse struct {
+	Body struct {
+		DeveloperSandboxResponse
+		Key string `json:"key" doc:"Send it in the X-Service-Account-Key header, it is only shown now and only works in the tenant of the sandbox"`
+	}
+}
+
+type DeveloperSandboxEnvelope struct {
+	Body DeveloperSandboxResponse
+}
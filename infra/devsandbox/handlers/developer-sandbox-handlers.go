@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	create_developer_sandbox_use_case "github.com/nahualventure/class-backend/core/app/devsandbox/application/use-cases/create-developer-sandbox-use-case"
+	get_developer_sandbox_use_case "github.com/nahualventure/class-backend/core/app/devsandbox/application/use-cases/get-developer-sandbox-use-case"
+	reset_developer_sandbox_use_case "github.com/nahualventure/class-backend/core/app/devsandbox/application/use-cases/reset-developer-sandbox-use-case"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type DeveloperSandboxHandlers struct {
+	createDeveloperSandboxUseCase *create_developer_sandbox_use_case.CreateDeveloperSandboxUseCase
+	getDeveloperSandboxUseCase    *get_developer_sandbox_use_case.GetDeveloperSandboxUseCase
+	resetDeveloperSandboxUseCase  *reset_developer_sandbox_use_case.ResetDeveloperSandboxUseCase
+	// limiter allows every client IP its own rate of sandboxes created
+	limiter *ratelimit.TenantRateLimiter
+}
+
+func NewDeveloperSandboxHandlers(
+	createDeveloperSandboxUseCase *create_developer_sandbox_use_case.CreateDeveloperSandboxUseCase,
+	getDeveloperSandboxUseCase *get_developer_sandbox_use_case.GetDeveloperSandboxUseCase,
+	resetDeveloperSandboxUseCase *reset_developer_sandbox_use_case.ResetDeveloperSandboxUseCase,
+	limiter *ratelimit.TenantRateLimiter,
+) *DeveloperSandboxHandlers {
+	return &DeveloperSandboxHandlers{
+		createDeveloperSandboxUseCase: createDeveloperSandboxUseCase,
+		getDeveloperSandboxUseCase:    getDeveloperSandboxUseCase,
+		resetDeveloperSandboxUseCase:  resetDeveloperSandboxUseCase,
+		limiter:                       limiter,
+	}
+}
+
+func (h *DeveloperSandboxHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-developer-sandbox",
+		Method:        http.MethodPost,
+		Path:          "/developer-sandboxes",
+		Summary:       "Create a developer sandbox",
+		Description:   "Public endpoint for integrators, rate limited per client IP. Creates a synthetic tenant seeded with fake instructors, students, classes and grades, and returns the key of its service account, which holds the admin role in it and works nowhere else.",
+		Tags:          []string{"Developer sandboxes"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateDeveloperSandbox)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-developer-sandbox",
+		Method:      http.MethodGet,
+		Path:        "/developer-sandbox",
+		Summary:     "Get the developer sandbox of the key",
+		Description: "Returns the people and classes the sandbox was last seeded with.",
+		Tags:        []string{"Developer sandboxes"},
+	}, h.GetDeveloperSandbox)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "reset-developer-sandbox",
+		Method:      http.MethodPost,
+		Path:        "/developer-sandbox/reset",
+		Summary:     "Reset the developer sandbox of the key",
+		Description: "Removes everything written in the sandbox and seeds it again with the same people and classes, dated from now. The key keeps working.",
+		Tags:        []string{"Developer sandboxes"},
+	}, h.ResetDeveloperSandbox)
+}
+
+func (h *DeveloperSandboxHandlers) CreateDeveloperSandbox(ctx context.Context, input *CreateDeveloperSandboxRequest) (*CreateDeveloperSandboxResponse, error) {
+	if allowed, retryAfter := h.limiter.Allow(utils.ClientIPFromContext(ctx)); !allowed {
+		return nil, huma.ErrorWithHeaders(
+			utils.ApplicationErrorToHumaError(appErrors.NewRateLimitedError("developer_sandboxes", retryAfter)),
+			http.Header{"Retry-After": []string{strconv.Itoa(int(retryAfter.Seconds()) + 1)}},
+		)
+	}
+
+	command, err := create_developer_sandbox_use_case.NewCreateDeveloperSandboxCommand(input.Body.Name, input.Body.Email)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	sandbox, data, token, err := h.createDeveloperSandboxUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &CreateDeveloperSandboxResponse{}
+	response.Body.DeveloperSandboxResponse = NewDeveloperSandboxResponse(sandbox, data, utils.LocationFromContext(ctx))
+	response.Body.Key = token
+	return response, nil
+}
+
+func (h *DeveloperSandboxHandlers) GetDeveloperSandbox(ctx context.Context, _ *struct{}) (*DeveloperSandboxEnvelope, error) {
+	command, err := get_developer_sandbox_use_case.NewGetDeveloperSandboxCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	sandbox, data, err := h.getDeveloperSandboxUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DeveloperSandboxEnvelope{Body: NewDeveloperSandboxResponse(sandbox, data, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *DeveloperSandboxHandlers) ResetDeveloperSandbox(ctx context.Context, _ *struct{}) (*DeveloperSandboxEnvelope, error) {
+	command, err := reset_developer_sandbox_use_case.NewResetDeveloperSandboxCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	sandbox, data, err := h.resetDeveloperSandboxUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DeveloperSandboxEnvelope{Body: NewDeveloperSandboxResponse(sandbox, data, utils.LocationFromContext(ctx))}, nil
+}
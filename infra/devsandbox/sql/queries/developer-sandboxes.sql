@@ -0,0 +1,23 @@
+-- name: CreateDeveloperSandbox :exec
+INSERT INTO developer_sandboxes (id, tenant_id, name, email, service_account_id, tables, reset_count, created_at, reset_at)
+VALUES (@id, @tenant_id, @name, @email, @service_account_id, @tables, @reset_count, @created_at, @reset_at);
+
+-- name: GetDeveloperSandboxByTenant :one
+SELECT *
+FROM developer_sandboxes
+WHERE tenant_id = @tenant_id;
+
+-- name: CountDeveloperSandboxesByEmail :one
+SELECT COUNT(*)
+FROM developer_sandboxes
+WHERE email = @email;
+
+-- name: SaveDeveloperSandboxReset :exec
+UPDATE developer_sandboxes
+SET tables = @tables, reset_count = @reset_count, reset_at = @reset_at
+WHERE id = @id;
+
+-- name: ListDeveloperSandboxTenants :many
+SELECT tenant_id
+FROM developer_sandboxes
+ORDER BY tenant_id;
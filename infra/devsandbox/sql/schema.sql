@@ -0,0 +1,15 @@
+-- Developer sandboxes, synthetic tenants integrators build against with the key of their service
+-- account. No data of a school is ever copied into them.
+CREATE TABLE developer_sandboxes (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL UNIQUE,      -- starts with dev-
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL,                 -- lowercased
+    service_account_id UUID NOT NULL,
+    tables JSONB NOT NULL DEFAULT '{}',          -- rows the last seed wrote per table
+    reset_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reset_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_developer_sandboxes_email ON developer_sandboxes(email);
@@ -0,0 +1,30 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	sync_developer_sandbox_tenants_use_case "github.com/nahualventure/class-backend/core/app/devsandbox/application/use-cases/sync-developer-sandbox-tenants-use-case"
+)
+
+// RunDeveloperSandboxTenantSync applies the policies to the developer sandboxes created on any
+// instance every interval, until ctx is cancelled. The first sync runs right away so restarts serve
+// the sandboxes again.
+func RunDeveloperSandboxTenantSync(ctx context.Context, useCase *sync_developer_sandbox_tenants_use_case.SyncDeveloperSandboxTenantsUseCase,
+	interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := useCase.Execute(ctx); err != nil {
+			log.Printf("Failed to sync developer sandbox tenants: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	userErrors "github.com/nahualventure/class-backend/core/app/user/domain/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// uniqueViolation is the SQLSTATE of a write breaking a unique constraint, the email of users here
+const uniqueViolation = "23505"
+
+// PostgresDirectoryUserRepository reads the members of a tenant from casbin_rule joined with users,
+// like the member directory of user merges, and writes the synced fields to users
+type PostgresDirectoryUserRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresDirectoryUserRepository(dbInstance *pgxpool.Pool) ports.DirectoryUserRepository {
+	return &PostgresDirectoryUserRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresDirectoryUserRepository) FindByExternalIDs(ctx context.Context, tenantID string, externalIDs []string) ([]*entities.LocalUser, error) {
+	if len(externalIDs) == 0 {
+		return nil, nil
+	}
+	lowered := make([]string, len(externalIDs))
+	for i, externalID := range externalIDs {
+		lowered[i] = strings.ToLower(externalID)
+	}
+
+	rows, err := r.queries.FindDirectoryUsers(ctx, db.FindDirectoryUsersParams{TenantID: tenantID, ExternalIds: lowered})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	users := make([]*entities.LocalUser, 0, len(rows))
+	byID := make(map[string]*entities.LocalUser, len(rows))
+	userIDs := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		user := &entities.LocalUser{
+			UserID: row.ID.String(),
+			Name:   row.Name,
+			Email:  row.Email,
+			Synced: make(map[string]string, len(entities.Fields)),
+		}
+		if row.ExternalID != nil {
+			user.ExternalID = *row.ExternalID
+		}
+		users = append(users, user)
+		byID[user.UserID] = user
+		userIDs = append(userIDs, row.ID)
+	}
+
+	synced, err := r.queries.ListSyncedValues(ctx, db.ListSyncedValuesParams{TenantID: tenantID, UserIds: userIDs})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	for _, row := range synced {
+		if user, ok := byID[row.UserID.String()]; ok {
+			user.Synced[row.Field] = row.Value
+		}
+	}
+	return users, nil
+}
+
+func (r *PostgresDirectoryUserRepository) SaveSync(ctx context.Context, tenantID string, user *entities.LocalUser,
+	syncs []entities.FieldSync, conflicts []*entities.SyncConflict) error {
+	var userID pgtype.UUID
+	if err := userID.Scan(user.UserID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	now := time.Now().UTC()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	for _, sync := range syncs {
+		if sync.Outcome == entities.SyncOutcomeUpdated {
+			if err := updateUserField(ctx, qtx, userID, sync.Field, sync.Upstream); err != nil {
+				return err
+			}
+		}
+	}
+
+	fields := make([]string, 0, len(user.Synced))
+	values := make([]string, 0, len(user.Synced))
+	for _, field := range entities.Fields {
+		if value, ok := user.Synced[field]; ok {
+			fields = append(fields, field)
+			values = append(values, value)
+		}
+	}
+	if len(fields) > 0 {
+		err = qtx.UpsertSyncedValues(ctx, db.UpsertSyncedValuesParams{
+			TenantID: tenantID,
+			UserID:   userID,
+			Fields:   fields,
+			Values:   values,
+			SyncedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	queued := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		var conflictID pgtype.UUID
+		if err := conflictID.Scan(conflict.ID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+		err = qtx.UpsertOpenSyncConflict(ctx, db.UpsertOpenSyncConflictParams{
+			ID:            conflictID,
+			TenantID:      tenantID,
+			UserID:        userID,
+			ExternalID:    conflict.ExternalID,
+			Field:         conflict.Field,
+			LocalValue:    conflict.LocalValue,
+			UpstreamValue: conflict.UpstreamValue,
+			DetectedAt:    pgtype.Timestamptz{Time: conflict.DetectedAt, Valid: true},
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+		queued = append(queued, conflict.Field)
+	}
+	err = qtx.DeleteOpenSyncConflicts(ctx, db.DeleteOpenSyncConflictsParams{TenantID: tenantID, UserID: userID, QueuedFields: queued})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+// updateUserField writes a synced field to the user, an email taken by another user fails with
+// EMAIL_ALREADY_EXISTS
+func updateUserField(ctx context.Context, qtx *db.Queries, userID pgtype.UUID, field string, value string) error {
+	var err error
+	if field == entities.FieldEmail {
+		err = qtx.UpdateDirectoryUserEmail(ctx, db.UpdateDirectoryUserEmailParams{Email: value, ID: userID})
+	} else {
+		err = qtx.UpdateDirectoryUserName(ctx, db.UpdateDirectoryUserNameParams{Name: value, ID: userID})
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return userErrors.NewEmailAlreadyExistsError(value)
+	}
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
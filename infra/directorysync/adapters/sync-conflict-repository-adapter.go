@@ -0,0 +1,171 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSyncConflictRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresSyncConflictRepository(dbInstance *pgxpool.Pool) ports.SyncConflictRepository {
+	return &PostgresSyncConflictRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresSyncConflictRepository) FindByID(ctx context.Context, tenantID string, conflictID string) (*entities.SyncConflict, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(conflictID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetSyncConflict(ctx, db.GetSyncConflictParams{TenantID: tenantID, ID: pgUUID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toSyncConflict(row)
+}
+
+func (r *PostgresSyncConflictRepository) List(ctx context.Context, tenantID string, status entities.SyncConflictStatus,
+	page *pagination.PageRequest) (*pagination.Page[*entities.SyncConflict], error) {
+	afterDetectedAt, afterID, err := database.TimeIDKeyset(page.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.queries.ListSyncConflicts(ctx, db.ListSyncConflictsParams{
+		TenantID:        tenantID,
+		Status:          string(status),
+		AfterDetectedAt: afterDetectedAt,
+		AfterID:         afterID,
+		PageLimit:       int32(page.PageSize + 1),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	conflicts := make([]*entities.SyncConflict, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		conflict, err := toSyncConflict(db.DirectorySyncConflict{
+			ID:            row.ID,
+			TenantID:      row.TenantID,
+			UserID:        row.UserID,
+			ExternalID:    row.ExternalID,
+			Field:         row.Field,
+			LocalValue:    row.LocalValue,
+			UpstreamValue: row.UpstreamValue,
+			Status:        row.Status,
+			DetectedAt:    row.DetectedAt,
+			Resolution:    row.Resolution,
+			ResolvedBy:    row.ResolvedBy,
+			ResolvedAt:    row.ResolvedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	return pagination.NewPage(page, conflicts, total, func(conflict *entities.SyncConflict) []string {
+		return []string{pagination.TimeKey(conflict.DetectedAt), conflict.ID}
+	}), nil
+}
+
+func (r *PostgresSyncConflictRepository) Resolve(ctx context.Context, conflict *entities.SyncConflict) (bool, error) {
+	var conflictID, userID pgtype.UUID
+	if err := conflictID.Scan(conflict.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	if err := userID.Scan(conflict.UserID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	resolution := string(conflict.Resolution)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	resolved, err := qtx.ResolveSyncConflict(ctx, db.ResolveSyncConflictParams{
+		Resolution: &resolution,
+		ResolvedBy: &conflict.ResolvedBy,
+		ResolvedAt: pgtype.Timestamptz{Time: *conflict.ResolvedAt, Valid: true},
+		TenantID:   conflict.TenantID,
+		ID:         conflictID,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	if resolved == 0 {
+		return false, nil
+	}
+
+	if conflict.Resolution == entities.SyncResolutionUpstream {
+		if err := updateUserField(ctx, qtx, userID, conflict.Field, conflict.UpstreamValue); err != nil {
+			return false, err
+		}
+	}
+	err = qtx.UpsertSyncedValues(ctx, db.UpsertSyncedValuesParams{
+		TenantID: conflict.TenantID,
+		UserID:   userID,
+		Fields:   []string{conflict.Field},
+		Values:   []string{conflict.UpstreamValue},
+		SyncedAt: pgtype.Timestamptz{Time: *conflict.ResolvedAt, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return true, nil
+}
+
+func toSyncConflict(row db.DirectorySyncConflict) (*entities.SyncConflict, error) {
+	conflict, err := entities.NewSyncConflict(row.ID.String(), row.TenantID, &entities.LocalUser{
+		UserID:     row.UserID.String(),
+		ExternalID: row.ExternalID,
+	}, entities.FieldSync{
+		Field:    row.Field,
+		Local:    row.LocalValue,
+		Upstream: row.UpstreamValue,
+	}, row.DetectedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	conflict.Status = entities.SyncConflictStatus(row.Status)
+	if row.Resolution != nil {
+		conflict.Resolution = entities.SyncResolution(*row.Resolution)
+	}
+	if row.ResolvedBy != nil {
+		conflict.ResolvedBy = *row.ResolvedBy
+	}
+	if row.ResolvedAt.Valid {
+		conflict.ResolvedAt = &row.ResolvedAt.Time
+	}
+	return conflict, nil
+}
@@ -0,0 +1,57 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSyncPolicyRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresSyncPolicyRepository(dbInstance *pgxpool.Pool) ports.SyncPolicyRepository {
+	return &PostgresSyncPolicyRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresSyncPolicyRepository) List(ctx context.Context, tenantID string) ([]*entities.SyncPolicy, error) {
+	rows, err := r.queries.ListSyncPolicies(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	policies := make([]*entities.SyncPolicy, 0, len(rows))
+	for _, row := range rows {
+		policies = append(policies, &entities.SyncPolicy{
+			TenantID:   row.TenantID,
+			Field:      row.Field,
+			OnConflict: entities.ConflictPolicy(row.OnConflict),
+			UpdatedBy:  row.UpdatedBy,
+			UpdatedAt:  row.UpdatedAt.Time,
+		})
+	}
+	return policies, nil
+}
+
+func (r *PostgresSyncPolicyRepository) Save(ctx context.Context, policy *entities.SyncPolicy) error {
+	err := r.queries.UpsertSyncPolicy(ctx, db.UpsertSyncPolicyParams{
+		TenantID:   policy.TenantID,
+		Field:      policy.Field,
+		OnConflict: string(policy.OnConflict),
+		UpdatedBy:  policy.UpdatedBy,
+		UpdatedAt:  pgtype.Timestamptz{Time: policy.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/directorysync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type SyncDirectoryUserRequest struct {
+	ExternalID string `json:"external_id" doc:"ID of the user in the directory, matched to the external_id the user was imported with"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+}
+
+type SyncDirectoryUsersRequest struct {
+	Body struct {
+		Users []SyncDirectoryUserRequest `json:"users" minItems:"1" maxItems:"1000"`
+	}
+}
+
+type FieldSyncResponse struct {
+	Field    string `json:"field" enum:"name,email"`
+	Outcome  string `json:"outcome" enum:"unchanged,updated,kept_local,queued" doc:"updated when the field took the value of the directory, kept_local and queued when it changed both locally and in the directory and the policy of the field kept the local value or queued the conflict"`
+	Conflict bool   `json:"conflict" doc:"The field changed both locally and in the directory since the last sync"`
+}
+
+// SyncDirectoryUserResponse has the status unchanged or updated when the user was synced, and
+// unmatched when no member of the tenant was imported with the external ID
+type SyncDirectoryUserResponse struct {
+	utils.BulkItemResponse
+	ExternalID   string              `json:"external_id"`
+	UserID       string              `json:"user_id,omitempty"`
+	Fields       []FieldSyncResponse `json:"fields,omitempty"`
+	ErrorCode    string              `json:"error_code,omitempty" deprecated:"true" doc:"Same as error.code"`
+	ErrorMessage string              `json:"error_message,omitempty" deprecated:"true" doc:"Same as error.message"`
+}
+
+type SyncDirectoryUsersResponse struct {
+	Body struct {
+		utils.BulkSummary
+		Results   []SyncDirectoryUserResponse `json:"results"`
+		Updated   int                         `json:"updated"`
+		Unchanged int                         `json:"unchanged"`
+		Unmatched int                         `json:"unmatched"`
+		Conflicts int                         `json:"conflicts" doc:"Fields changed both locally and in the directory"`
+		Queued    int                         `json:"queued" doc:"Conflicts waiting for an admin to resolve them"`
+	}
+}
+
+type SyncPolicyResponse struct {
+	Field      string     `json:"field" enum:"name,email"`
+	OnConflict string     `json:"on_conflict" enum:"upstream_wins,local_wins,manual"`
+	Default    bool       `json:"default" doc:"The tenant never set the policy of this field"`
+	UpdatedBy  string     `json:"updated_by,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+func NewSyncPolicyResponse(policy *entities.SyncPolicy, loc *time.Location) SyncPolicyResponse {
+	response := SyncPolicyResponse{
+		Field:      policy.Field,
+		OnConflict: string(policy.OnConflict),
+		Default:    policy.IsDefault(),
+		UpdatedBy:  policy.UpdatedBy,
+	}
+	if !policy.IsDefault() {
+		updatedAt := utils.InLocation(policy.UpdatedAt, loc)
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
+
+type SyncPoliciesResponse struct {
+	Body struct {
+		Policies []SyncPolicyResponse `json:"policies"`
+	}
+}
+
+type SyncPolicyEnvelope struct {
+	Body SyncPolicyResponse
+}
+
+type SetSyncPolicyRequest struct {
+	Field string `path:"field" enum:"name,email"`
+	Body  struct {
+		OnConflict string `json:"on_conflict" enum:"upstream_wins,local_wins,manual" doc:"upstream_wins takes the value of the directory, local_wins keeps the local value, manual keeps it and queues the conflict for an admin"`
+	}
+}
+
+type SyncConflictResponse struct {
+	ID            string     `json:"id"`
+	UserID        string     `json:"user_id"`
+	ExternalID    string     `json:"external_id"`
+	Field         string     `json:"field" enum:"name,email"`
+	LocalValue    string     `json:"local_value"`
+	UpstreamValue string     `json:"upstream_value" doc:"Value of the directory"`
+	Status        string     `json:"status" enum:"open,resolved"`
+	DetectedAt    time.Time  `json:"detected_at" doc:"Last sync that found the conflict"`
+	Resolution    string     `json:"resolution,omitempty" enum:"upstream,local"`
+	ResolvedBy    string     `json:"resolved_by,omitempty"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+}
+
+func NewSyncConflictResponse(conflict *entities.SyncConflict, loc *time.Location) SyncConflictResponse {
+	return SyncConflictResponse{
+		ID:            conflict.ID,
+		UserID:        conflict.UserID,
+		ExternalID:    conflict.ExternalID,
+		Field:         conflict.Field,
+		LocalValue:    conflict.LocalValue,
+		UpstreamValue: conflict.UpstreamValue,
+		Status:        string(conflict.Status),
+		DetectedAt:    utils.InLocation(conflict.DetectedAt, loc),
+		Resolution:    string(conflict.Resolution),
+		ResolvedBy:    conflict.ResolvedBy,
+		ResolvedAt:    utils.InLocationPtr(conflict.ResolvedAt, loc),
+	}
+}
+
+type SyncConflictEnvelope struct {
+	Body SyncConflictResponse
+}
+
+type ListSyncConflictsRequest struct {
+	Status   string `query:"status" enum:"open,resolved" doc:"Defaults to open"`
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+}
+
+type ListSyncConflictsResponse struct {
+	Body utils.ListResponseBody[SyncConflictResponse]
+}
+
+func NewListSyncConflictsResponse(page *pagination.Page[*entities.SyncConflict], loc *time.Location) *ListSyncConflictsResponse {
+	return &ListSyncConflictsResponse{Body: utils.NewListResponseBody(page, func(conflict *entities.SyncConflict) SyncConflictResponse {
+		return NewSyncConflictResponse(conflict, loc)
+	})}
+}
+
+type SyncConflictPathRequest struct {
+	ConflictID string `path:"conflictId" format:"uuid" doc:"Sync conflict ID"`
+}
+
+type ResolveSyncConflictRequest struct {
+	ConflictID string `path:"conflictId" format:"uuid" doc:"Sync conflict ID"`
+	Body       struct {
+		Resolution string `json:"resolution" enum:"upstream,local" doc:"upstream gives the field the value of the directory, local keeps the local value"`
+	}
+}
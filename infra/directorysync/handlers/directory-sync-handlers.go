@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_sync_conflict_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/get-sync-conflict-use-case"
+	list_sync_conflicts_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/list-sync-conflicts-use-case"
+	list_sync_policies_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/list-sync-policies-use-case"
+	resolve_sync_conflict_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/resolve-sync-conflict-use-case"
+	set_sync_policy_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/set-sync-policy-use-case"
+	sync_directory_users_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/sync-directory-users-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type DirectorySyncHandlers struct {
+	syncDirectoryUsersUseCase  *sync_directory_users_use_case.SyncDirectoryUsersUseCase
+	listSyncPoliciesUseCase    *list_sync_policies_use_case.ListSyncPoliciesUseCase
+	setSyncPolicyUseCase       *set_sync_policy_use_case.SetSyncPolicyUseCase
+	listSyncConflictsUseCase   *list_sync_conflicts_use_case.ListSyncConflictsUseCase
+	getSyncConflictUseCase     *get_sync_conflict_use_case.GetSyncConflictUseCase
+	resolveSyncConflictUseCase *resolve_sync_conflict_use_case.ResolveSyncConflictUseCase
+}
+
+func NewDirectorySyncHandlers(
+	syncDirectoryUsersUseCase *sync_directory_users_use_case.SyncDirectoryUsersUseCase,
+	listSyncPoliciesUseCase *list_sync_policies_use_case.ListSyncPoliciesUseCase,
+	setSyncPolicyUseCase *set_sync_policy_use_case.SetSyncPolicyUseCase,
+	listSyncConflictsUseCase *list_sync_conflicts_use_case.ListSyncConflictsUseCase,
+	getSyncConflictUseCase *get_sync_conflict_use_case.GetSyncConflictUseCase,
+	resolveSyncConflictUseCase *resolve_sync_conflict_use_case.ResolveSyncConflictUseCase,
+) *DirectorySyncHandlers {
+	return &DirectorySyncHandlers{
+		syncDirectoryUsersUseCase:  syncDirectoryUsersUseCase,
+		listSyncPoliciesUseCase:    listSyncPoliciesUseCase,
+		setSyncPolicyUseCase:       setSyncPolicyUseCase,
+		listSyncConflictsUseCase:   listSyncConflictsUseCase,
+		getSyncConflictUseCase:     getSyncConflictUseCase,
+		resolveSyncConflictUseCase: resolveSyncConflictUseCase,
+	}
+}
+
+func (h *DirectorySyncHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "sync-directory-users",
+		Method:      http.MethodPost,
+		Path:        "/directory-sync/users",
+		Summary:     "Sync users from the roster or SCIM directory",
+		Description: "Gives the members of the tenant imported with the external ID of a directory user its name and email. A field changed only in the directory takes its value, a field changed only locally keeps it. A field changed on both sides since the last sync is settled by the policy of the field. Every user is saved on its own, the results report each one.",
+		Tags:        []string{"Directory Sync"},
+	}, h.SyncDirectoryUsers)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-directory-sync-policies",
+		Method:      http.MethodGet,
+		Path:        "/directory-sync/policies",
+		Summary:     "List the conflict policies of the synced fields",
+		Description: "Returns how conflicts of every synced field are settled, manual for the fields the tenant did not set.",
+		Tags:        []string{"Directory Sync"},
+	}, h.ListSyncPolicies)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-directory-sync-policy",
+		Method:      http.MethodPut,
+		Path:        "/directory-sync/policies/{field}",
+		Summary:     "Set the conflict policy of a synced field",
+		Description: "Syncs from now on follow it, conflicts already queued stay open until they are resolved.",
+		Tags:        []string{"Directory Sync"},
+	}, h.SetSyncPolicy)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-directory-sync-conflicts",
+		Method:      http.MethodGet,
+		Path:        "/directory-sync/conflicts",
+		Summary:     "List the sync conflicts of the tenant",
+		Description: "Fields changed both locally and in the directory that the manual policy queued, last detected first.",
+		Tags:        []string{"Directory Sync"},
+	}, h.ListSyncConflicts)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-directory-sync-conflict",
+		Method:      http.MethodGet,
+		Path:        "/directory-sync/conflicts/{conflictId}",
+		Summary:     "Get a sync conflict",
+		Tags:        []string{"Directory Sync"},
+	}, h.GetSyncConflict)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "resolve-directory-sync-conflict",
+		Method:      http.MethodPost,
+		Path:        "/directory-sync/conflicts/{conflictId}/resolve",
+		Summary:     "Resolve a sync conflict",
+		Description: "Gives the field the value of the directory or keeps the local one. Either way the conflict is not reported again until the directory changes the field.",
+		Tags:        []string{"Directory Sync"},
+	}, h.ResolveSyncConflict)
+}
+
+func (h *DirectorySyncHandlers) SyncDirectoryUsers(ctx context.Context, input *SyncDirectoryUsersRequest) (*SyncDirectoryUsersResponse, error) {
+	items := make([]sync_directory_users_use_case.SyncDirectoryUserItem, len(input.Body.Users))
+	for i, user := range input.Body.Users {
+		items[i] = sync_directory_users_use_case.SyncDirectoryUserItem{
+			ExternalID: user.ExternalID,
+			Name:       user.Name,
+			Email:      user.Email,
+		}
+	}
+
+	command, err := sync_directory_users_use_case.NewSyncDirectoryUsersCommand(
+		authorization.TenantIDFromContext(ctx),
+		items,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	result, err := h.syncDirectoryUsersUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	locale := requestmeta.Locale(ctx)
+	response := &SyncDirectoryUsersResponse{}
+	response.Body.BulkSummary = utils.BulkSummary{Succeeded: len(result.Items) - result.Failed, Failed: result.Failed}
+	response.Body.Updated = result.Updated
+	response.Body.Unchanged = result.Unchanged
+	response.Body.Unmatched = result.Unmatched
+	response.Body.Conflicts = result.Conflicts
+	response.Body.Queued = result.Queued
+	response.Body.Results = make([]SyncDirectoryUserResponse, len(result.Items))
+
+	for i, item := range result.Items {
+		itemResponse := SyncDirectoryUserResponse{
+			BulkItemResponse: utils.BulkItemResponse{Index: item.Index, Status: string(item.Status)},
+			ExternalID:       item.ExternalID,
+			UserID:           item.UserID,
+		}
+		for _, field := range item.Fields {
+			itemResponse.Fields = append(itemResponse.Fields, FieldSyncResponse{
+				Field:    field.Field,
+				Outcome:  string(field.Outcome),
+				Conflict: field.Conflict,
+			})
+		}
+		if item.Err != nil {
+			itemResponse.Error = utils.NewErrorDetail(item.Err, locale)
+			itemResponse.ErrorCode = itemResponse.Error.Code
+			itemResponse.ErrorMessage = itemResponse.Error.Message
+		}
+		response.Body.Results[i] = itemResponse
+	}
+
+	return response, nil
+}
+
+func (h *DirectorySyncHandlers) ListSyncPolicies(ctx context.Context, input *struct{}) (*SyncPoliciesResponse, error) {
+	command, err := list_sync_policies_use_case.NewListSyncPoliciesCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	policies, err := h.listSyncPoliciesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &SyncPoliciesResponse{}
+	response.Body.Policies = make([]SyncPolicyResponse, 0, len(policies))
+	for _, policy := range policies {
+		response.Body.Policies = append(response.Body.Policies, NewSyncPolicyResponse(policy, loc))
+	}
+	return response, nil
+}
+
+func (h *DirectorySyncHandlers) SetSyncPolicy(ctx context.Context, input *SetSyncPolicyRequest) (*SyncPolicyEnvelope, error) {
+	command, err := set_sync_policy_use_case.NewSetSyncPolicyCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Field,
+		input.Body.OnConflict,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	policy, err := h.setSyncPolicyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SyncPolicyEnvelope{Body: NewSyncPolicyResponse(policy, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *DirectorySyncHandlers) ListSyncConflicts(ctx context.Context, input *ListSyncConflictsRequest) (*ListSyncConflictsResponse, error) {
+	command, err := list_sync_conflicts_use_case.NewListSyncConflictsCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Status,
+		input.PageSize,
+		input.Cursor,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listSyncConflictsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListSyncConflictsResponse(page, utils.LocationFromContext(ctx)), nil
+}
+
+func (h *DirectorySyncHandlers) GetSyncConflict(ctx context.Context, input *SyncConflictPathRequest) (*SyncConflictEnvelope, error) {
+	command, err := get_sync_conflict_use_case.NewGetSyncConflictCommand(authorization.TenantIDFromContext(ctx), input.ConflictID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	conflict, err := h.getSyncConflictUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SyncConflictEnvelope{Body: NewSyncConflictResponse(conflict, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *DirectorySyncHandlers) ResolveSyncConflict(ctx context.Context, input *ResolveSyncConflictRequest) (*SyncConflictEnvelope, error) {
+	command, err := resolve_sync_conflict_use_case.NewResolveSyncConflictCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ConflictID,
+		input.Body.Resolution,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	conflict, err := h.resolveSyncConflictUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SyncConflictEnvelope{Body: NewSyncConflictResponse(conflict, utils.LocationFromContext(ctx))}, nil
+}
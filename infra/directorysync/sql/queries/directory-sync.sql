@@ -0,0 +1,78 @@
+-- Members of the tenant imported with one of the external IDs, which are lower case
+-- name: FindDirectoryUsers :many
+SELECT DISTINCT u.id, u.name, u.email, u.external_id
+FROM casbin_rule c
+JOIN users u ON u.id::text = c.v0
+WHERE c.ptype = 'g' AND c.v2 = @tenant_id::text AND lower(u.external_id) = ANY(@external_ids::text[])
+ORDER BY u.id;
+
+-- name: ListSyncedValues :many
+SELECT user_id, field, value
+FROM directory_synced_values
+WHERE tenant_id = @tenant_id AND user_id = ANY(@user_ids::uuid[]);
+
+-- name: UpsertSyncedValues :exec
+INSERT INTO directory_synced_values (tenant_id, user_id, field, value, synced_at)
+SELECT @tenant_id, @user_id, unnest(@fields::text[]), unnest(@values::text[]), @synced_at
+ON CONFLICT (tenant_id, user_id, field) DO UPDATE
+SET value = EXCLUDED.value, synced_at = EXCLUDED.synced_at;
+
+-- name: UpdateDirectoryUserName :exec
+UPDATE users
+SET name = @name, updated_at = NOW()
+WHERE id = @id;
+
+-- name: UpdateDirectoryUserEmail :exec
+UPDATE users
+SET email = @email, updated_at = NOW()
+WHERE id = @id;
+
+-- A conflict queued again keeps its ID and takes the latest values
+-- name: UpsertOpenSyncConflict :exec
+INSERT INTO directory_sync_conflicts (id, tenant_id, user_id, external_id, field, local_value, upstream_value, status, detected_at)
+VALUES (@id, @tenant_id, @user_id, @external_id, @field, @local_value, @upstream_value, 'open', @detected_at)
+ON CONFLICT (tenant_id, user_id, field) WHERE status = 'open' DO UPDATE
+SET external_id = EXCLUDED.external_id,
+    local_value = EXCLUDED.local_value,
+    upstream_value = EXCLUDED.upstream_value,
+    detected_at = EXCLUDED.detected_at;
+
+-- Open conflicts of the user the last sync did not queue again
+-- name: DeleteOpenSyncConflicts :exec
+DELETE FROM directory_sync_conflicts
+WHERE tenant_id = @tenant_id AND user_id = @user_id AND status = 'open'
+  AND field <> ALL(@queued_fields::text[]);
+
+-- name: GetSyncConflict :one
+SELECT * FROM directory_sync_conflicts
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: ListSyncConflicts :many
+SELECT id, tenant_id, user_id, external_id, field, local_value, upstream_value, status, detected_at,
+       resolution, resolved_by, resolved_at,
+       (SELECT count(*) FROM directory_sync_conflicts t WHERE t.tenant_id = @tenant_id AND t.status = @status) AS total
+FROM directory_sync_conflicts
+WHERE tenant_id = @tenant_id AND status = @status
+  AND (sqlc.narg('after_detected_at')::timestamptz IS NULL
+       OR (detected_at, id) < (sqlc.narg('after_detected_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY detected_at DESC, id DESC
+LIMIT @page_limit;
+
+-- name: ResolveSyncConflict :execrows
+UPDATE directory_sync_conflicts
+SET status = 'resolved', resolution = @resolution, resolved_by = @resolved_by, resolved_at = @resolved_at
+WHERE tenant_id = @tenant_id AND id = @id AND status = 'open';
+
+-- name: UpsertSyncPolicy :exec
+INSERT INTO directory_sync_policies (tenant_id, field, on_conflict, updated_by, updated_at)
+VALUES (@tenant_id, @field, @on_conflict, @updated_by, @updated_at)
+ON CONFLICT (tenant_id, field) DO UPDATE
+SET on_conflict = EXCLUDED.on_conflict,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: ListSyncPolicies :many
+SELECT *
+FROM directory_sync_policies
+WHERE tenant_id = @tenant_id
+ORDER BY field;
@@ -0,0 +1,41 @@
+-- How every tenant settles the fields changed both locally and in its directory, fields without a
+-- row use manual
+CREATE TABLE directory_sync_policies (
+    tenant_id VARCHAR(255) NOT NULL,
+    field VARCHAR(20) NOT NULL,            -- name, email
+    on_conflict VARCHAR(20) NOT NULL,      -- upstream_wins, local_wins, manual
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, field)
+);
+
+-- Values of the fields of a user as the last sync of the tenant took them from the directory, a
+-- local value that differs from it was changed locally
+CREATE TABLE directory_synced_values (
+    tenant_id VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL,
+    field VARCHAR(20) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    synced_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, user_id, field)
+);
+
+-- Fields changed on both sides the manual policy queued for an admin, a user has at most one open
+-- conflict per field
+CREATE TABLE directory_sync_conflicts (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    field VARCHAR(20) NOT NULL,            -- name, email
+    local_value VARCHAR(255) NOT NULL,
+    upstream_value VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,           -- open, resolved
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    resolution VARCHAR(20),                -- upstream, local
+    resolved_by VARCHAR(255),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX idx_directory_sync_conflicts_open ON directory_sync_conflicts(tenant_id, user_id, field) WHERE status = 'open';
+CREATE INDEX idx_directory_sync_conflicts_status ON directory_sync_conflicts(tenant_id, status, detected_at);
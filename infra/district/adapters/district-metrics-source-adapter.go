@@ -0,0 +1,59 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/district/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresDistrictMetricsSource counts the enrollments and check-ins of the schools across tenants,
+// the counts only leave the district module aggregated
+type PostgresDistrictMetricsSource struct {
+	queries *db.Queries
+}
+
+func NewPostgresDistrictMetricsSource(dbInstance *pgxpool.Pool) ports.DistrictMetricsSource {
+	return &PostgresDistrictMetricsSource{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresDistrictMetricsSource) SchoolCounts(ctx context.Context, tenantIDs []string, from time.Time,
+	to time.Time) ([]entities.SchoolCounts, error) {
+	enrollments, err := s.queries.CountDistrictEnrollments(ctx, tenantIDs)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	attendance, err := s.queries.CountDistrictAttendance(ctx, db.CountDistrictAttendanceParams{
+		TenantIds:    tenantIDs,
+		StartsFrom:   pgtype.Timestamptz{Time: from, Valid: true},
+		StartsBefore: pgtype.Timestamptz{Time: to.AddDate(0, 0, 1), Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	bySchool := make(map[string]*entities.SchoolCounts, len(enrollments))
+	counts := make([]entities.SchoolCounts, 0, len(enrollments))
+	for _, row := range enrollments {
+		counts = append(counts, entities.SchoolCounts{TenantID: row.TenantID, Students: int(row.Students), Enrollments: int(row.Enrollments)})
+	}
+	for i := range counts {
+		bySchool[counts[i].TenantID] = &counts[i]
+	}
+	for _, row := range attendance {
+		if count, ok := bySchool[row.TenantID]; ok {
+			count.ExpectedAttendances = int(row.Expected)
+			count.Attendances = int(row.Attended)
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,213 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/district/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresDistrictRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresDistrictRepository(dbInstance *pgxpool.Pool) ports.DistrictRepository {
+	return &PostgresDistrictRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresDistrictRepository) Save(ctx context.Context, district *entities.District) error {
+	id, err := pgUUID(district.ID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.SaveDistrict(ctx, db.SaveDistrictParams{
+		ID:            id,
+		Name:          district.Name,
+		MinCohortSize: int32(district.MinCohortSize),
+		CreatedAt:     pgtype.Timestamptz{Time: district.CreatedAt, Valid: true},
+		UpdatedAt:     pgtype.Timestamptz{Time: district.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := qtx.DeleteDistrictTenants(ctx, id); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := qtx.AddDistrictTenants(ctx, db.AddDistrictTenantsParams{TenantIds: district.TenantIDs, DistrictID: id}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresDistrictRepository) FindByID(ctx context.Context, districtID string) (*entities.District, error) {
+	id, err := pgUUID(districtID)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.GetDistrict(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toDistrict(db.ListDistrictsRow(row)), nil
+}
+
+func (r *PostgresDistrictRepository) List(ctx context.Context) ([]*entities.District, error) {
+	rows, err := r.queries.ListDistricts(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	districts := make([]*entities.District, 0, len(rows))
+	for _, row := range rows {
+		districts = append(districts, toDistrict(row))
+	}
+	return districts, nil
+}
+
+func (r *PostgresDistrictRepository) TenantsInOtherDistricts(ctx context.Context, districtID string, tenantIDs []string) ([]string, error) {
+	id, err := pgUUID(districtID)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := r.queries.ListTenantsInOtherDistricts(ctx, db.ListTenantsInOtherDistrictsParams{TenantIds: tenantIDs, DistrictID: id})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return claimed, nil
+}
+
+func (r *PostgresDistrictRepository) CreateKey(ctx context.Context, key *entities.DistrictKey) error {
+	id, err := pgUUID(key.ID)
+	if err != nil {
+		return err
+	}
+	districtID, err := pgUUID(key.DistrictID)
+	if err != nil {
+		return err
+	}
+
+	err = r.queries.CreateDistrictKey(ctx, db.CreateDistrictKeyParams{
+		ID:         id,
+		DistrictID: districtID,
+		Name:       key.Name,
+		KeyHash:    key.Hash,
+		CreatedAt:  pgtype.Timestamptz{Time: key.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresDistrictRepository) FindKey(ctx context.Context, districtID string, keyID string) (*entities.DistrictKey, error) {
+	district, err := pgUUID(districtID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := pgUUID(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.GetDistrictKey(ctx, db.GetDistrictKeyParams{DistrictID: district, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toDistrictKey(row), nil
+}
+
+func (r *PostgresDistrictRepository) FindKeyByHash(ctx context.Context, hash string) (*entities.DistrictKey, error) {
+	row, err := r.queries.GetDistrictKeyByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toDistrictKey(row), nil
+}
+
+func (r *PostgresDistrictRepository) RevokeKey(ctx context.Context, key *entities.DistrictKey) error {
+	id, err := pgUUID(key.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.queries.RevokeDistrictKey(ctx, db.RevokeDistrictKeyParams{RevokedAt: timestamptz(key.RevokedAt), ID: id}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func toDistrict(row db.ListDistrictsRow) *entities.District {
+	return &entities.District{
+		ID:            row.ID.String(),
+		Name:          row.Name,
+		TenantIDs:     row.TenantIds,
+		MinCohortSize: int(row.MinCohortSize),
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+}
+
+func toDistrictKey(row db.DistrictKey) *entities.DistrictKey {
+	key := &entities.DistrictKey{
+		ID:         row.ID.String(),
+		DistrictID: row.DistrictID.String(),
+		Name:       row.Name,
+		Hash:       row.KeyHash,
+		CreatedAt:  row.CreatedAt.Time,
+	}
+	if row.RevokedAt.Valid {
+		key.RevokedAt = &row.RevokedAt.Time
+	}
+	return key
+}
+
+func pgUUID(id string) (pgtype.UUID, error) {
+	var value pgtype.UUID
+	if err := value.Scan(id); err != nil {
+		return value, appErrors.PropagateError(err)
+	}
+	return value, nil
+}
+
+func timestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
@@ -0,0 +1,33 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/district/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/residency"
+)
+
+// ResidentDistrictRepository keeps districts to the schools of the region of the instance. The
+// metrics of a district are counted in one query on the cluster of the instance, schools whose data
+// lives in another region cannot be part of it.
+type ResidentDistrictRepository struct {
+	ports.DistrictRepository
+	router *residency.Router
+}
+
+func NewResidentDistrictRepository(next ports.DistrictRepository, router *residency.Router) ports.DistrictRepository {
+	if !router.Routing() {
+		return next
+	}
+	return &ResidentDistrictRepository{DistrictRepository: next, router: router}
+}
+
+func (r *ResidentDistrictRepository) Save(ctx context.Context, district *entities.District) error {
+	if remote := r.router.Remote(district.TenantIDs); len(remote) > 0 {
+		return appErrors.NewValidationError("Schools whose data lives in another region cannot join the district",
+			map[string]any{"tenant_ids": remote, "region": r.router.Region()}, nil)
+	}
+	return r.DistrictRepository.Save(ctx, district)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+)
+
+type DistrictAdminRequest struct {
+	Authorization string `header:"Authorization" required:"true" doc:"Bearer followed by the district admin token of the platform"`
+}
+
+type DistrictRequest struct {
+	DistrictAdminRequest
+	DistrictID string `path:"districtId" format:"uuid" doc:"District ID"`
+}
+
+type DistrictBody struct {
+	Name          string   `json:"name" maxLength:"200"`
+	TenantIDs     []string `json:"tenant_ids" minItems:"1" maxItems:"500" uniqueItems:"true" doc:"Tenants of the schools of the district, a tenant belongs to one district at most"`
+	MinCohortSize int      `json:"min_cohort_size,omitempty" minimum:"5" maximum:"100" doc:"Schools with fewer students are suppressed from the metrics, 10 by default"`
+}
+
+type CreateDistrictRequest struct {
+	DistrictAdminRequest
+	Body DistrictBody
+}
+
+type UpdateDistrictRequest struct {
+	DistrictRequest
+	Body DistrictBody
+}
+
+type DistrictResponse struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	TenantIDs     []string  `json:"tenant_ids"`
+	MinCohortSize int       `json:"min_cohort_size"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func NewDistrictResponse(district *entities.District) DistrictResponse {
+	return DistrictResponse{
+		ID:            district.ID,
+		Name:          district.Name,
+		TenantIDs:     district.TenantIDs,
+		MinCohortSize: district.MinCohortSize,
+		CreatedAt:     district.CreatedAt,
+		UpdatedAt:     district.UpdatedAt,
+	}
+}
+
+type DistrictEnvelope struct {
+	Body DistrictResponse
+}
+
+type ListDistrictsResponse struct {
+	Body struct {
+		Items []DistrictResponse `json:"items"`
+	}
+}
+
+type CreateDistrictKeyRequest struct {
+	DistrictRequest
+	Body struct {
+		Name string `json:"name" maxLength:"100" doc:"What the key is for, such as the dashboard it is configured in"`
+	}
+}
+
+type DistrictKeyRequest struct {
+	DistrictRequest
+	KeyID string `path:"keyId" format:"uuid" doc:"District key ID"`
+}
+
+type DistrictKeyResponse struct {
+	ID         string     `json:"id"`
+	DistrictID string     `json:"district_id"`
+	Name       string     `json:"name"`
+	Key        string     `json:"key,omitempty" doc:"The key, only returned when it is created"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func NewDistrictKeyResponse(key *entities.DistrictKey, token string) DistrictKeyResponse {
+	return DistrictKeyResponse{
+		ID:         key.ID,
+		DistrictID: key.DistrictID,
+		Name:       key.Name,
+		Key:        token,
+		CreatedAt:  key.CreatedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
+
+type DistrictKeyEnvelope struct {
+	Body DistrictKeyResponse
+}
+
+type GetDistrictMetricsRequest struct {
+	Authorization string `header:"Authorization" required:"true" doc:"Bearer followed by a key of the district"`
+	From          string `query:"from" doc:"First day of attendance counted as YYYY-MM-DD in UTC, 30 days before to by default"`
+	To            string `query:"to" doc:"Last day of attendance counted as YYYY-MM-DD in UTC, today by default"`
+}
+
+type SchoolMetricsResponse struct {
+	TenantID       string   `json:"tenant_id"`
+	Suppressed     bool     `json:"suppressed" doc:"The school has fewer students than the minimum cohort size of the district, its numbers are left out"`
+	Students       *int     `json:"students,omitempty" doc:"Students enrolled in a class of the school"`
+	Enrollments    *int     `json:"enrollments,omitempty" doc:"Seats taken in the classes of the school"`
+	AttendanceRate *float64 `json:"attendance_rate,omitempty" doc:"Share of the students expected at the check-in sessions of the period that checked in, from 0 to 1. Left out without sessions."`
+}
+
+type DistrictMetricsResponse struct {
+	Body struct {
+		DistrictID        string                  `json:"district_id"`
+		From              string                  `json:"from"`
+		To                string                  `json:"to"`
+		MinCohortSize     int                     `json:"min_cohort_size"`
+		Schools           []SchoolMetricsResponse `json:"schools"`
+		Students          int                     `json:"students" doc:"Students of the schools not suppressed"`
+		Enrollments       int                     `json:"enrollments" doc:"Enrollments of the schools not suppressed"`
+		AttendanceRate    *float64                `json:"attendance_rate,omitempty" doc:"Attendance rate of the schools not suppressed"`
+		SuppressedSchools int                     `json:"suppressed_schools"`
+	}
+}
+
+func NewDistrictMetricsResponse(metrics *entities.DistrictMetrics) *DistrictMetricsResponse {
+	response := &DistrictMetricsResponse{}
+	response.Body.DistrictID = metrics.DistrictID
+	response.Body.From = metrics.From.Format(entities.DateLayout)
+	response.Body.To = metrics.To.Format(entities.DateLayout)
+	response.Body.MinCohortSize = metrics.MinCohortSize
+	response.Body.Schools = make([]SchoolMetricsResponse, 0, len(metrics.Schools))
+	for _, school := range metrics.Schools {
+		response.Body.Schools = append(response.Body.Schools, SchoolMetricsResponse{
+			TenantID:       school.TenantID,
+			Suppressed:     school.Suppressed,
+			Students:       school.Students,
+			Enrollments:    school.Enrollments,
+			AttendanceRate: school.AttendanceRate,
+		})
+	}
+	response.Body.Students = metrics.Students
+	response.Body.Enrollments = metrics.Enrollments
+	response.Body.AttendanceRate = metrics.AttendanceRate
+	response.Body.SuppressedSchools = metrics.SuppressedSchools
+	return response
+}
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	authenticate_district_key_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/authenticate-district-key-use-case"
+	create_district_key_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/create-district-key-use-case"
+	create_district_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/create-district-use-case"
+	get_district_metrics_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/get-district-metrics-use-case"
+	list_districts_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/list-districts-use-case"
+	revoke_district_key_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/revoke-district-key-use-case"
+	update_district_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/update-district-use-case"
+	"github.com/nahualventure/class-backend/core/app/district/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type DistrictHandlers struct {
+	createDistrictUseCase          *create_district_use_case.CreateDistrictUseCase
+	listDistrictsUseCase           *list_districts_use_case.ListDistrictsUseCase
+	updateDistrictUseCase          *update_district_use_case.UpdateDistrictUseCase
+	createDistrictKeyUseCase       *create_district_key_use_case.CreateDistrictKeyUseCase
+	revokeDistrictKeyUseCase       *revoke_district_key_use_case.RevokeDistrictKeyUseCase
+	authenticateDistrictKeyUseCase *authenticate_district_key_use_case.AuthenticateDistrictKeyUseCase
+	getDistrictMetricsUseCase      *get_district_metrics_use_case.GetDistrictMetricsUseCase
+	// limiter allows every district key its own rate of metrics requests
+	limiter *ratelimit.TenantRateLimiter
+	// adminToken authorizes the requests managing the districts, which are not served without it
+	adminToken string
+}
+
+func NewDistrictHandlers(
+	createDistrictUseCase *create_district_use_case.CreateDistrictUseCase,
+	listDistrictsUseCase *list_districts_use_case.ListDistrictsUseCase,
+	updateDistrictUseCase *update_district_use_case.UpdateDistrictUseCase,
+	createDistrictKeyUseCase *create_district_key_use_case.CreateDistrictKeyUseCase,
+	revokeDistrictKeyUseCase *revoke_district_key_use_case.RevokeDistrictKeyUseCase,
+	authenticateDistrictKeyUseCase *authenticate_district_key_use_case.AuthenticateDistrictKeyUseCase,
+	getDistrictMetricsUseCase *get_district_metrics_use_case.GetDistrictMetricsUseCase,
+	limiter *ratelimit.TenantRateLimiter,
+	adminToken string,
+) *DistrictHandlers {
+	return &DistrictHandlers{
+		createDistrictUseCase:          createDistrictUseCase,
+		listDistrictsUseCase:           listDistrictsUseCase,
+		updateDistrictUseCase:          updateDistrictUseCase,
+		createDistrictKeyUseCase:       createDistrictKeyUseCase,
+		revokeDistrictKeyUseCase:       revokeDistrictKeyUseCase,
+		authenticateDistrictKeyUseCase: authenticateDistrictKeyUseCase,
+		getDistrictMetricsUseCase:      getDistrictMetricsUseCase,
+		limiter:                        limiter,
+		adminToken:                     adminToken,
+	}
+}
+
+func (h *DistrictHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-district-metrics",
+		Method:      http.MethodGet,
+		Path:        "/district-metrics",
+		Summary:     "Aggregated metrics of the schools of a district",
+		Description: "Authorized by a key of the district, rate limited per key. Counts the students and enrollments of every school of the district and their attendance rate over the period. Schools with fewer students than the minimum cohort size of the district are suppressed, and the totals only add up the other ones.",
+		Tags:        []string{"Districts"},
+	}, h.GetDistrictMetrics)
+
+	if h.adminToken == "" {
+		return
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-district",
+		Method:        http.MethodPost,
+		Path:          "/districts",
+		Summary:       "Create a district of schools",
+		Description:   "Authorized by the district admin token of the platform. Schools that already belong to another district are refused.",
+		Tags:          []string{"Districts"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateDistrict)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-districts",
+		Method:      http.MethodGet,
+		Path:        "/districts",
+		Summary:     "List the districts",
+		Description: "Authorized by the district admin token of the platform.",
+		Tags:        []string{"Districts"},
+	}, h.ListDistricts)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-district",
+		Method:      http.MethodPut,
+		Path:        "/districts/{districtId}",
+		Summary:     "Update the schools and the minimum cohort size of a district",
+		Description: "Authorized by the district admin token of the platform. The keys of the district read the new schools from the next request on.",
+		Tags:        []string{"Districts"},
+	}, h.UpdateDistrict)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-district-key",
+		Method:        http.MethodPost,
+		Path:          "/districts/{districtId}/keys",
+		Summary:       "Create a key for the dashboards of a district",
+		Description:   "Authorized by the district admin token of the platform. The key is only returned now, it is stored hashed.",
+		Tags:          []string{"Districts"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateDistrictKey)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-district-key",
+		Method:      http.MethodDelete,
+		Path:        "/districts/{districtId}/keys/{keyId}",
+		Summary:     "Revoke a district key",
+		Description: "Authorized by the district admin token of the platform.",
+		Tags:        []string{"Districts"},
+	}, h.RevokeDistrictKey)
+}
+
+func (h *DistrictHandlers) CreateDistrict(ctx context.Context, input *CreateDistrictRequest) (*DistrictEnvelope, error) {
+	if err := h.authorizeAdmin(input.Authorization); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	command, err := create_district_use_case.NewCreateDistrictCommand(input.Body.Name, input.Body.TenantIDs, input.Body.MinCohortSize)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	district, err := h.createDistrictUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DistrictEnvelope{Body: NewDistrictResponse(district)}, nil
+}
+
+func (h *DistrictHandlers) ListDistricts(ctx context.Context, input *DistrictAdminRequest) (*ListDistrictsResponse, error) {
+	if err := h.authorizeAdmin(input.Authorization); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	districts, err := h.listDistrictsUseCase.Execute(ctx)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ListDistrictsResponse{}
+	response.Body.Items = make([]DistrictResponse, 0, len(districts))
+	for _, district := range districts {
+		response.Body.Items = append(response.Body.Items, NewDistrictResponse(district))
+	}
+	return response, nil
+}
+
+func (h *DistrictHandlers) UpdateDistrict(ctx context.Context, input *UpdateDistrictRequest) (*DistrictEnvelope, error) {
+	if err := h.authorizeAdmin(input.Authorization); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	command, err := update_district_use_case.NewUpdateDistrictCommand(input.DistrictID, input.Body.Name, input.Body.TenantIDs,
+		input.Body.MinCohortSize)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	district, err := h.updateDistrictUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DistrictEnvelope{Body: NewDistrictResponse(district)}, nil
+}
+
+func (h *DistrictHandlers) CreateDistrictKey(ctx context.Context, input *CreateDistrictKeyRequest) (*DistrictKeyEnvelope, error) {
+	if err := h.authorizeAdmin(input.Authorization); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	command, err := create_district_key_use_case.NewCreateDistrictKeyCommand(input.DistrictID, input.Body.Name)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	key, token, err := h.createDistrictKeyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DistrictKeyEnvelope{Body: NewDistrictKeyResponse(key, token)}, nil
+}
+
+func (h *DistrictHandlers) RevokeDistrictKey(ctx context.Context, input *DistrictKeyRequest) (*DistrictKeyEnvelope, error) {
+	if err := h.authorizeAdmin(input.Authorization); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	command, err := revoke_district_key_use_case.NewRevokeDistrictKeyCommand(input.DistrictID, input.KeyID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	key, err := h.revokeDistrictKeyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DistrictKeyEnvelope{Body: NewDistrictKeyResponse(key, "")}, nil
+}
+
+func (h *DistrictHandlers) GetDistrictMetrics(ctx context.Context, input *GetDistrictMetricsRequest) (*DistrictMetricsResponse, error) {
+	bearer, _ := strings.CutPrefix(input.Authorization, "Bearer ")
+	authenticate, err := authenticate_district_key_use_case.NewAuthenticateDistrictKeyCommand(bearer)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	key, err := h.authenticateDistrictKeyUseCase.Execute(ctx, authenticate)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if allowed, retryAfter := h.limiter.Allow(key.ID); !allowed {
+		return nil, huma.ErrorWithHeaders(
+			utils.ApplicationErrorToHumaError(appErrors.NewRateLimitedError("district_key", retryAfter)),
+			http.Header{"Retry-After": []string{strconv.Itoa(int(retryAfter.Seconds()) + 1)}},
+		)
+	}
+
+	from, err := day("from", input.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := day("to", input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := get_district_metrics_use_case.NewGetDistrictMetricsCommand(key.DistrictID, from, to, time.Now().UTC())
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	metrics, err := h.getDistrictMetricsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewDistrictMetricsResponse(metrics), nil
+}
+
+// authorizeAdmin checks the bearer token of a request managing the districts, districts span tenants
+// so no role of a tenant grants it
+func (h *DistrictHandlers) authorizeAdmin(authorization string) error {
+	bearer, found := strings.CutPrefix(authorization, "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(bearer), []byte(h.adminToken)) != 1 {
+		return appErrors.NewUnauthorizedError("Invalid district admin token")
+	}
+	return nil
+}
+
+// day parses a date of the query, the zero time when it is left out
+func day(name string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse(entities.DateLayout, value)
+	if err != nil {
+		return time.Time{}, huma.Error400BadRequest(name + " must be a date like 2025-09-15")
+	}
+	return parsed, nil
+}
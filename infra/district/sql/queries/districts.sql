@@ -0,0 +1,73 @@
+-- name: SaveDistrict :exec
+INSERT INTO districts (id, name, min_cohort_size, created_at, updated_at)
+VALUES (@id, @name, @min_cohort_size, @created_at, @updated_at)
+ON CONFLICT (id) DO UPDATE
+SET name = EXCLUDED.name,
+    min_cohort_size = EXCLUDED.min_cohort_size,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteDistrictTenants :exec
+DELETE FROM district_tenants
+WHERE district_id = @district_id;
+
+-- name: AddDistrictTenants :exec
+INSERT INTO district_tenants (tenant_id, district_id)
+SELECT unnest(@tenant_ids::text[]), @district_id;
+
+-- name: GetDistrict :one
+SELECT d.id, d.name, d.min_cohort_size, d.created_at, d.updated_at,
+       ARRAY(SELECT t.tenant_id FROM district_tenants t WHERE t.district_id = d.id ORDER BY t.tenant_id)::text[] AS tenant_ids
+FROM districts d
+WHERE d.id = @id;
+
+-- name: ListDistricts :many
+SELECT d.id, d.name, d.min_cohort_size, d.created_at, d.updated_at,
+       ARRAY(SELECT t.tenant_id FROM district_tenants t WHERE t.district_id = d.id ORDER BY t.tenant_id)::text[] AS tenant_ids
+FROM districts d
+ORDER BY d.name, d.id;
+
+-- name: ListTenantsInOtherDistricts :many
+SELECT tenant_id FROM district_tenants
+WHERE tenant_id = ANY(@tenant_ids::text[]) AND district_id <> @district_id
+ORDER BY tenant_id;
+
+-- name: CreateDistrictKey :exec
+INSERT INTO district_keys (id, district_id, name, key_hash, created_at)
+VALUES (@id, @district_id, @name, @key_hash, @created_at);
+
+-- name: GetDistrictKey :one
+SELECT * FROM district_keys
+WHERE district_id = @district_id AND id = @id;
+
+-- name: GetDistrictKeyByHash :one
+SELECT * FROM district_keys
+WHERE key_hash = @key_hash;
+
+-- name: RevokeDistrictKey :exec
+UPDATE district_keys
+SET revoked_at = @revoked_at
+WHERE id = @id AND revoked_at IS NULL;
+
+-- name: CountDistrictEnrollments :many
+-- Students and seats of the schools, as enrolled now
+SELECT tenant_id,
+       COUNT(DISTINCT user_id)::int AS students,
+       COUNT(*)::int AS enrollments
+FROM class_enrollments
+WHERE tenant_id = ANY(@tenant_ids::text[])
+GROUP BY tenant_id;
+
+-- name: CountDistrictAttendance :many
+-- Students expected at the check-in sessions that started in the period, the ones enrolled in the
+-- class when the session started, and the ones that checked in. Absences are not recorded, students
+-- that did not check in were absent.
+SELECT s.tenant_id,
+       COUNT(*)::int AS expected,
+       COUNT(r.student_id)::int AS attended
+FROM check_in_sessions s
+JOIN class_enrollments e ON e.tenant_id = s.tenant_id AND e.class_id = s.class_id AND e.enrolled_at <= s.starts_at
+LEFT JOIN attendance_records r ON r.session_id = s.id AND r.student_id = e.user_id AND r.present
+WHERE s.tenant_id = ANY(@tenant_ids::text[])
+  AND s.starts_at >= @starts_from
+  AND s.starts_at < @starts_before
+GROUP BY s.tenant_id;
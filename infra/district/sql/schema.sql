@@ -0,0 +1,28 @@
+-- Districts overseeing the schools of several tenants, managed with the district admin token of the
+-- platform. They are not tenant scoped. Schools with fewer students than min_cohort_size are
+-- suppressed from the metrics of the district.
+CREATE TABLE districts (
+    id UUID PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    min_cohort_size INTEGER NOT NULL CHECK (min_cohort_size >= 5),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Schools of the districts, a tenant belongs to one district at most
+CREATE TABLE district_tenants (
+    tenant_id VARCHAR(100) PRIMARY KEY,
+    district_id UUID NOT NULL REFERENCES districts(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_district_tenants_district ON district_tenants(district_id);
+
+-- Keys of the dashboards of the districts, only their SHA-256 is stored
+CREATE TABLE district_keys (
+    id UUID PRIMARY KEY,
+    district_id UUID NOT NULL REFERENCES districts(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
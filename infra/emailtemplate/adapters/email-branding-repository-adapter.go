@@ -0,0 +1,62 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresEmailBrandingRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresEmailBrandingRepository(dbInstance *pgxpool.Pool) ports.EmailBrandingRepository {
+	return &PostgresEmailBrandingRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresEmailBrandingRepository) Find(ctx context.Context, tenantID string) (*entities.EmailBranding, error) {
+	row, err := r.queries.GetEmailBranding(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &entities.EmailBranding{
+		TenantID:     row.TenantID,
+		SenderName:   row.SenderName,
+		LogoURL:      row.LogoUrl,
+		PrimaryColor: row.PrimaryColor,
+		Footer:       row.Footer,
+		UpdatedBy:    row.UpdatedBy,
+		UpdatedAt:    row.UpdatedAt.Time,
+	}, nil
+}
+
+func (r *PostgresEmailBrandingRepository) Save(ctx context.Context, branding *entities.EmailBranding) error {
+	err := r.queries.UpsertEmailBranding(ctx, db.UpsertEmailBrandingParams{
+		TenantID:     branding.TenantID,
+		SenderName:   branding.SenderName,
+		LogoUrl:      branding.LogoURL,
+		PrimaryColor: branding.PrimaryColor,
+		Footer:       branding.Footer,
+		UpdatedBy:    branding.UpdatedBy,
+		UpdatedAt:    pgtype.Timestamptz{Time: branding.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
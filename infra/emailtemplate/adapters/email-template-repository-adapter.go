@@ -0,0 +1,80 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresEmailTemplateRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresEmailTemplateRepository(dbInstance *pgxpool.Pool) ports.EmailTemplateRepository {
+	return &PostgresEmailTemplateRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresEmailTemplateRepository) List(ctx context.Context, tenantID string) ([]*entities.EmailTemplate, error) {
+	rows, err := r.queries.ListEmailTemplates(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	templates := make([]*entities.EmailTemplate, 0, len(rows))
+	for _, row := range rows {
+		templates = append(templates, toEmailTemplate(row))
+	}
+	return templates, nil
+}
+
+func (r *PostgresEmailTemplateRepository) Find(ctx context.Context, tenantID string, flow entities.EmailFlow) (*entities.EmailTemplate, error) {
+	row, err := r.queries.GetEmailTemplate(ctx, db.GetEmailTemplateParams{TenantID: tenantID, Flow: string(flow)})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toEmailTemplate(row), nil
+}
+
+func (r *PostgresEmailTemplateRepository) Save(ctx context.Context, template *entities.EmailTemplate) error {
+	err := r.queries.UpsertEmailTemplate(ctx, db.UpsertEmailTemplateParams{
+		TenantID:  template.TenantID,
+		Flow:      string(template.Flow),
+		Subject:   template.Subject,
+		HtmlBody:  template.HTMLBody,
+		TextBody:  template.TextBody,
+		Enabled:   template.Enabled,
+		UpdatedBy: template.UpdatedBy,
+		UpdatedAt: pgtype.Timestamptz{Time: template.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func toEmailTemplate(row db.EmailTemplate) *entities.EmailTemplate {
+	return &entities.EmailTemplate{
+		TenantID:  row.TenantID,
+		Flow:      entities.EmailFlow(row.Flow),
+		Subject:   row.Subject,
+		HTMLBody:  row.HtmlBody,
+		TextBody:  row.TextBody,
+		Enabled:   row.Enabled,
+		UpdatedBy: row.UpdatedBy,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,42 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresRecipientDirectory struct {
+	queries *db.Queries
+}
+
+func NewPostgresRecipientDirectory(dbInstance *pgxpool.Pool) ports.RecipientDirectory {
+	return &PostgresRecipientDirectory{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+// EmailOf returns "" for IDs that are not user IDs, such as the IDs of service accounts
+func (r *PostgresRecipientDirectory) EmailOf(ctx context.Context, userID string) (string, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(userID); err != nil {
+		return "", nil
+	}
+
+	email, err := r.queries.GetRecipientEmail(ctx, pgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", appErrors.PropagateError(err)
+	}
+	return email, nil
+}
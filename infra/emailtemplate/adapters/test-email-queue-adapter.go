@@ -0,0 +1,53 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresTestEmailQueue appends test emails to the outbox, they are not kept anywhere else
+type PostgresTestEmailQueue struct {
+	queries *db.Queries
+}
+
+func NewPostgresTestEmailQueue(dbInstance *pgxpool.Pool) ports.TestEmailQueue {
+	return &PostgresTestEmailQueue{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (q *PostgresTestEmailQueue) Queue(ctx context.Context, email *entities.TestEmail) error {
+	payload, err := json.Marshal(map[string]any{
+		"test_email_id": email.ID,
+		"flow":          email.Flow,
+		"to":            email.To,
+		"subject":       email.Email.Subject,
+		"html":          email.Email.HTML,
+		"text":          email.Email.Text,
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize test email event", err)
+	}
+
+	err = q.queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.TestEmailQueued,
+		AggregateID: email.ID,
+		TenantID:    email.TenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: email.RequestedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
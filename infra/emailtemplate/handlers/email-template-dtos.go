@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/emailtemplate/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type EmailTemplateResponse struct {
+	Flow      string     `json:"flow" enum:"invitation,password_reset,enrollment"`
+	Subject   string     `json:"subject"`
+	HTMLBody  string     `json:"html_body"`
+	TextBody  string     `json:"text_body"`
+	Enabled   bool       `json:"enabled" doc:"Senders use the template of the tenant, and the default template while it is disabled"`
+	Default   bool       `json:"default" doc:"The tenant never saved the template of this flow"`
+	Variables []string   `json:"variables" doc:"Variables of the flow the template can use besides sender_name, logo_url, primary_color and footer"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+func NewEmailTemplateResponse(template *entities.EmailTemplate, loc *time.Location) EmailTemplateResponse {
+	response := EmailTemplateResponse{
+		Flow:      string(template.Flow),
+		Subject:   template.Subject,
+		HTMLBody:  template.HTMLBody,
+		TextBody:  template.TextBody,
+		Enabled:   template.Enabled,
+		Default:   template.IsDefault(),
+		Variables: entities.VariableNames(template.Flow),
+		UpdatedBy: template.UpdatedBy,
+	}
+	if !template.IsDefault() {
+		updatedAt := utils.InLocation(template.UpdatedAt, loc)
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
+
+type EmailTemplatesResponse struct {
+	Body struct {
+		Templates []EmailTemplateResponse `json:"templates"`
+	}
+}
+
+type EmailTemplateEnvelope struct {
+	Body EmailTemplateResponse
+}
+
+type SaveEmailTemplateRequest struct {
+	Flow string `path:"flow" enum:"invitation,password_reset,enrollment"`
+	Body struct {
+		Subject  string `json:"subject" maxLength:"255" doc:"Go template of the subject, such as {{.inviter_name}} invited you to {{.sender_name}}"`
+		HTMLBody string `json:"html_body" maxLength:"100000" doc:"Go template of the HTML body, the variables are escaped. The logo and the footer of the branding frame it."`
+		TextBody string `json:"text_body" maxLength:"100000" doc:"Go template of the plain text body, the footer of the branding is appended"`
+		Enabled  bool   `json:"enabled" doc:"Send the emails of the flow with this template, preview it and send a test email before enabling it"`
+	}
+}
+
+type EmailTemplateDraft struct {
+	Subject  string `json:"subject,omitempty" maxLength:"255"`
+	HTMLBody string `json:"html_body,omitempty" maxLength:"100000"`
+	TextBody string `json:"text_body,omitempty" maxLength:"100000"`
+}
+
+// EmailTemplateDraftRequest renders the saved template of the flow, the parts of the draft that are
+// set replace the saved ones
+type EmailTemplateDraftRequest struct {
+	Flow string              `path:"flow" enum:"invitation,password_reset,enrollment"`
+	Body *EmailTemplateDraft `required:"false"`
+}
+
+// draft returns the parts of the draft, empty when the request has no body
+func (r *EmailTemplateDraftRequest) draft() EmailTemplateDraft {
+	if r.Body == nil {
+		return EmailTemplateDraft{}
+	}
+	return *r.Body
+}
+
+type RenderedEmailResponse struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
+
+type RenderedEmailEnvelope struct {
+	Body RenderedEmailResponse
+}
+
+func NewRenderedEmailResponse(email *entities.RenderedEmail) RenderedEmailResponse {
+	return RenderedEmailResponse{
+		Subject: email.Subject,
+		HTML:    email.HTML,
+		Text:    email.Text,
+	}
+}
+
+type TestEmailResponse struct {
+	Body struct {
+		ID          string                `json:"id"`
+		To          string                `json:"to" doc:"Address of the caller, test emails are only sent to it"`
+		Email       RenderedEmailResponse `json:"email"`
+		RequestedAt time.Time             `json:"requested_at"`
+	}
+}
+
+func NewTestEmailResponse(email *entities.TestEmail, loc *time.Location) *TestEmailResponse {
+	response := &TestEmailResponse{}
+	response.Body.ID = email.ID
+	response.Body.To = email.To
+	response.Body.Email = NewRenderedEmailResponse(email.Email)
+	response.Body.RequestedAt = utils.InLocation(email.RequestedAt, loc)
+	return response
+}
+
+type EmailBrandingResponse struct {
+	SenderName   string     `json:"sender_name"`
+	LogoURL      string     `json:"logo_url,omitempty"`
+	PrimaryColor string     `json:"primary_color"`
+	Footer       string     `json:"footer,omitempty"`
+	Default      bool       `json:"default" doc:"The tenant never set its branding"`
+	UpdatedBy    string     `json:"updated_by,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+func NewEmailBrandingResponse(branding *entities.EmailBranding, loc *time.Location) EmailBrandingResponse {
+	response := EmailBrandingResponse{
+		SenderName:   branding.SenderName,
+		LogoURL:      branding.LogoURL,
+		PrimaryColor: branding.PrimaryColor,
+		Footer:       branding.Footer,
+		Default:      branding.IsDefault(),
+		UpdatedBy:    branding.UpdatedBy,
+	}
+	if !branding.IsDefault() {
+		updatedAt := utils.InLocation(branding.UpdatedAt, loc)
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
+
+type EmailBrandingEnvelope struct {
+	Body EmailBrandingResponse
+}
+
+type SetEmailBrandingRequest struct {
+	Body struct {
+		SenderName   string `json:"sender_name" maxLength:"100" doc:"Name the emails are sent as"`
+		LogoURL      string `json:"logo_url,omitempty" maxLength:"2048" doc:"HTTPS URL of the logo shown at the top of the emails"`
+		PrimaryColor string `json:"primary_color" pattern:"^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$" doc:"Color of the links and buttons, such as #1a73e8"`
+		Footer       string `json:"footer,omitempty" maxLength:"1000" doc:"Text at the bottom of every email, such as the address of the school"`
+	}
+}
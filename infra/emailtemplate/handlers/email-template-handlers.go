@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_email_branding_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/get-email-branding-use-case"
+	list_email_templates_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/list-email-templates-use-case"
+	preview_email_template_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/preview-email-template-use-case"
+	save_email_template_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/save-email-template-use-case"
+	send_test_email_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/send-test-email-use-case"
+	set_email_branding_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/set-email-branding-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type EmailTemplateHandlers struct {
+	listEmailTemplatesUseCase   *list_email_templates_use_case.ListEmailTemplatesUseCase
+	saveEmailTemplateUseCase    *save_email_template_use_case.SaveEmailTemplateUseCase
+	previewEmailTemplateUseCase *preview_email_template_use_case.PreviewEmailTemplateUseCase
+	sendTestEmailUseCase        *send_test_email_use_case.SendTestEmailUseCase
+	getEmailBrandingUseCase     *get_email_branding_use_case.GetEmailBrandingUseCase
+	setEmailBrandingUseCase     *set_email_branding_use_case.SetEmailBrandingUseCase
+}
+
+func NewEmailTemplateHandlers(
+	listEmailTemplatesUseCase *list_email_templates_use_case.ListEmailTemplatesUseCase,
+	saveEmailTemplateUseCase *save_email_template_use_case.SaveEmailTemplateUseCase,
+	previewEmailTemplateUseCase *preview_email_template_use_case.PreviewEmailTemplateUseCase,
+	sendTestEmailUseCase *send_test_email_use_case.SendTestEmailUseCase,
+	getEmailBrandingUseCase *get_email_branding_use_case.GetEmailBrandingUseCase,
+	setEmailBrandingUseCase *set_email_branding_use_case.SetEmailBrandingUseCase,
+) *EmailTemplateHandlers {
+	return &EmailTemplateHandlers{
+		listEmailTemplatesUseCase:   listEmailTemplatesUseCase,
+		saveEmailTemplateUseCase:    saveEmailTemplateUseCase,
+		previewEmailTemplateUseCase: previewEmailTemplateUseCase,
+		sendTestEmailUseCase:        sendTestEmailUseCase,
+		getEmailBrandingUseCase:     getEmailBrandingUseCase,
+		setEmailBrandingUseCase:     setEmailBrandingUseCase,
+	}
+}
+
+func (h *EmailTemplateHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-email-templates",
+		Method:      http.MethodGet,
+		Path:        "/email/templates",
+		Summary:     "List the email templates of every flow",
+		Description: "Flows the tenant did not customize list their default template.",
+		Tags:        []string{"Email Templates"},
+	}, h.ListEmailTemplates)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "save-email-template",
+		Method:      http.MethodPut,
+		Path:        "/email/templates/{flow}",
+		Summary:     "Save the email template of a flow",
+		Description: "The template must render with the variables of the flow. Senders use it once it is enabled.",
+		Tags:        []string{"Email Templates"},
+	}, h.SaveEmailTemplate)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "preview-email-template",
+		Method:      http.MethodPost,
+		Path:        "/email/templates/{flow}/preview",
+		Summary:     "Render an email template with sample data",
+		Description: "Renders the saved template, or the default one, with the branding of the tenant. The parts of the draft in the body replace the saved ones, so changes can be previewed before saving them.",
+		Tags:        []string{"Email Templates"},
+	}, h.PreviewEmailTemplate)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "send-test-email",
+		Method:      http.MethodPost,
+		Path:        "/email/templates/{flow}/test",
+		Summary:     "Send an email template with sample data to yourself",
+		Description: "Renders the template as the preview does and queues it to the address of the caller, with the subject prefixed by [Test].",
+		Tags:        []string{"Email Templates"},
+	}, h.SendTestEmail)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-email-branding",
+		Method:      http.MethodGet,
+		Path:        "/email/branding",
+		Summary:     "Get the branding of the emails of the tenant",
+		Tags:        []string{"Email Templates"},
+	}, h.GetEmailBranding)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-email-branding",
+		Method:      http.MethodPut,
+		Path:        "/email/branding",
+		Summary:     "Set the branding of the emails of the tenant",
+		Description: "Every template renders with it from the next email.",
+		Tags:        []string{"Email Templates"},
+	}, h.SetEmailBranding)
+}
+
+func (h *EmailTemplateHandlers) ListEmailTemplates(ctx context.Context, input *struct{}) (*EmailTemplatesResponse, error) {
+	command, err := list_email_templates_use_case.NewListEmailTemplatesCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	templates, err := h.listEmailTemplatesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &EmailTemplatesResponse{}
+	response.Body.Templates = make([]EmailTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		response.Body.Templates = append(response.Body.Templates, NewEmailTemplateResponse(template, loc))
+	}
+	return response, nil
+}
+
+func (h *EmailTemplateHandlers) SaveEmailTemplate(ctx context.Context, input *SaveEmailTemplateRequest) (*EmailTemplateEnvelope, error) {
+	command, err := save_email_template_use_case.NewSaveEmailTemplateCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Flow,
+		input.Body.Subject,
+		input.Body.HTMLBody,
+		input.Body.TextBody,
+		input.Body.Enabled,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	template, err := h.saveEmailTemplateUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &EmailTemplateEnvelope{Body: NewEmailTemplateResponse(template, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *EmailTemplateHandlers) PreviewEmailTemplate(ctx context.Context, input *EmailTemplateDraftRequest) (*RenderedEmailEnvelope, error) {
+	draft := input.draft()
+	command, err := preview_email_template_use_case.NewPreviewEmailTemplateCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Flow,
+		draft.Subject,
+		draft.HTMLBody,
+		draft.TextBody,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	rendered, err := h.previewEmailTemplateUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &RenderedEmailEnvelope{Body: NewRenderedEmailResponse(rendered)}, nil
+}
+
+func (h *EmailTemplateHandlers) SendTestEmail(ctx context.Context, input *EmailTemplateDraftRequest) (*TestEmailResponse, error) {
+	draft := input.draft()
+	command, err := send_test_email_use_case.NewSendTestEmailCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Flow,
+		draft.Subject,
+		draft.HTMLBody,
+		draft.TextBody,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	email, err := h.sendTestEmailUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewTestEmailResponse(email, utils.LocationFromContext(ctx)), nil
+}
+
+func (h *EmailTemplateHandlers) GetEmailBranding(ctx context.Context, input *struct{}) (*EmailBrandingEnvelope, error) {
+	command, err := get_email_branding_use_case.NewGetEmailBrandingCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	branding, err := h.getEmailBrandingUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &EmailBrandingEnvelope{Body: NewEmailBrandingResponse(branding, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *EmailTemplateHandlers) SetEmailBranding(ctx context.Context, input *SetEmailBrandingRequest) (*EmailBrandingEnvelope, error) {
+	command, err := set_email_branding_use_case.NewSetEmailBrandingCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Body.SenderName,
+		input.Body.LogoURL,
+		input.Body.PrimaryColor,
+		input.Body.Footer,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	branding, err := h.setEmailBrandingUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &EmailBrandingEnvelope{Body: NewEmailBrandingResponse(branding, utils.LocationFromContext(ctx))}, nil
+}
@@ -0,0 +1,42 @@
+-- name: ListEmailTemplates :many
+SELECT *
+FROM email_templates
+WHERE tenant_id = @tenant_id
+ORDER BY flow;
+
+-- name: GetEmailTemplate :one
+SELECT *
+FROM email_templates
+WHERE tenant_id = @tenant_id AND flow = @flow;
+
+-- name: UpsertEmailTemplate :exec
+INSERT INTO email_templates (tenant_id, flow, subject, html_body, text_body, enabled, updated_by, updated_at)
+VALUES (@tenant_id, @flow, @subject, @html_body, @text_body, @enabled, @updated_by, @updated_at)
+ON CONFLICT (tenant_id, flow) DO UPDATE
+SET subject = EXCLUDED.subject,
+    html_body = EXCLUDED.html_body,
+    text_body = EXCLUDED.text_body,
+    enabled = EXCLUDED.enabled,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetEmailBranding :one
+SELECT *
+FROM email_brandings
+WHERE tenant_id = @tenant_id;
+
+-- name: UpsertEmailBranding :exec
+INSERT INTO email_brandings (tenant_id, sender_name, logo_url, primary_color, footer, updated_by, updated_at)
+VALUES (@tenant_id, @sender_name, @logo_url, @primary_color, @footer, @updated_by, @updated_at)
+ON CONFLICT (tenant_id) DO UPDATE
+SET sender_name = EXCLUDED.sender_name,
+    logo_url = EXCLUDED.logo_url,
+    primary_color = EXCLUDED.primary_color,
+    footer = EXCLUDED.footer,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetRecipientEmail :one
+SELECT email
+FROM users
+WHERE id = @id;
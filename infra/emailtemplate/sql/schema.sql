@@ -0,0 +1,23 @@
+-- Templates tenants customized, flows without a row use the default template
+CREATE TABLE email_templates (
+    tenant_id VARCHAR(255) NOT NULL,
+    flow VARCHAR(30) NOT NULL,             -- invitation, password_reset, enrollment
+    subject VARCHAR(255) NOT NULL,
+    html_body TEXT NOT NULL,
+    text_body TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL,              -- senders use the default template until the tenant enables its own
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, flow)
+);
+
+-- How the emails of a tenant look, tenants without a row use the default branding
+CREATE TABLE email_brandings (
+    tenant_id VARCHAR(255) PRIMARY KEY,
+    sender_name VARCHAR(100) NOT NULL,
+    logo_url VARCHAR(2048) NOT NULL,       -- empty when the emails have no logo
+    primary_color VARCHAR(7) NOT NULL,
+    footer VARCHAR(1000) NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,90 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresEnrollmentNotificationQueue records the notification and its outbox event in one
+// transaction, the event is only written with the row
+type PostgresEnrollmentNotificationQueue struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresEnrollmentNotificationQueue(dbInstance *pgxpool.Pool) ports.EnrollmentNotificationQueue {
+	return &PostgresEnrollmentNotificationQueue{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (q *PostgresEnrollmentNotificationQueue) Queue(ctx context.Context, notification *entities.EnrollmentNotification) error {
+	var pgID, pgClassID pgtype.UUID
+	if err := pgID.Scan(notification.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgClassID.Scan(notification.ClassID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := q.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := q.queries.WithTx(tx)
+
+	created, err := qtx.CreateEnrollmentNotification(ctx, db.CreateEnrollmentNotificationParams{
+		ID:        pgID,
+		TenantID:  notification.TenantID,
+		ClassID:   pgClassID,
+		StudentID: notification.StudentID,
+		Kind:      string(notification.Kind),
+		CreatedAt: pgtype.Timestamptz{Time: notification.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if created == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"notification_id": notification.ID,
+		"class_id":        notification.ClassID,
+		"student_id":      notification.StudentID,
+		"kind":            string(notification.Kind),
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize enrollment notification event", err)
+	}
+
+	err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.EnrollmentNotificationQueued,
+		AggregateID: notification.ClassID,
+		TenantID:    notification.TenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: notification.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresEnrollmentRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresEnrollmentRepository(dbInstance *pgxpool.Pool) ports.EnrollmentRepository {
+	return &PostgresEnrollmentRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresEnrollmentRepository) Find(ctx context.Context, tenantID string, classID string, userID string) (*entities.Enrollment, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetClassEnrollment(ctx, db.GetClassEnrollmentParams{
+		TenantID: tenantID,
+		ClassID:  pgClassID,
+		UserID:   userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &entities.Enrollment{
+		TenantID:   row.TenantID,
+		ClassID:    row.ClassID.String(),
+		UserID:     row.UserID,
+		EnrolledAt: row.EnrolledAt.Time,
+	}, nil
+}
+
+func (r *PostgresEnrollmentRepository) Remove(ctx context.Context, tenantID string, classID string, userID string, at time.Time) error {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	deleted, err := qtx.DeleteClassEnrollment(ctx, db.DeleteClassEnrollmentParams{
+		TenantID: tenantID,
+		ClassID:  pgClassID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if deleted == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"class_id":   classID,
+		"student_id": userID,
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize enrollment event", err)
+	}
+
+	err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.EnrollmentRemoved,
+		AggregateID: classID,
+		TenantID:    tenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
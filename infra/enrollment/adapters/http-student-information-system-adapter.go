@@ -0,0 +1,81 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+)
+
+type StudentInformationSystemConfig struct {
+	BaseURL string // e.g. https://sis.example.edu/api
+	APIKey  string
+}
+
+// HTTPStudentInformationSystem puts enrollments to a REST student information system. The URL names
+// the enrollment, so pushing it again replaces it instead of adding one.
+type HTTPStudentInformationSystem struct {
+	config StudentInformationSystemConfig
+	client *http.Client
+}
+
+func NewHTTPStudentInformationSystem(config StudentInformationSystemConfig) ports.StudentInformationSystem {
+	return &HTTPStudentInformationSystem{
+		config: config,
+		client: &http.Client{Timeout: 30 * time.Second, Transport: cost.Transport("sis", nil)},
+	}
+}
+
+type sisEnrollmentRequest struct {
+	EnrolledAt time.Time `json:"enrolled_at"`
+}
+
+func (s *HTTPStudentInformationSystem) PushEnrollment(ctx context.Context, enrollment *entities.Enrollment) error {
+	body, err := json.Marshal(sisEnrollmentRequest{EnrolledAt: enrollment.EnrolledAt})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize SIS enrollment", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/tenants/%s/classes/%s/enrollments/%s", strings.TrimSuffix(s.config.BaseURL, "/"),
+		url.PathEscape(enrollment.TenantID), url.PathEscape(enrollment.ClassID), url.PathEscape(enrollment.UserID))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to build SIS request", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+s.config.APIKey)
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to reach the SIS", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return appErrors.NewInfrastructureError(fmt.Sprintf("SIS answered %d: %s", response.StatusCode, message), nil)
+	}
+
+	return nil
+}
+
+// DisabledStudentInformationSystem is used when no SIS is configured, enrollments stay in this service
+type DisabledStudentInformationSystem struct{}
+
+func NewDisabledStudentInformationSystem() ports.StudentInformationSystem {
+	return DisabledStudentInformationSystem{}
+}
+
+func (DisabledStudentInformationSystem) PushEnrollment(_ context.Context, _ *entities.Enrollment) error {
+	return nil
+}
@@ -0,0 +1,107 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresScheduleRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresScheduleRepository(dbInstance *pgxpool.Pool) ports.ScheduleRepository {
+	return &PostgresScheduleRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresScheduleRepository) ReplaceMeetings(ctx context.Context, tenantID string, classID string, meetings []entities.MeetingTime) error {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	if err := qtx.DeleteClassMeetings(ctx, db.DeleteClassMeetingsParams{TenantID: tenantID, ClassID: pgClassID}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	for _, meeting := range meetings {
+		err := qtx.CreateClassMeeting(ctx, db.CreateClassMeetingParams{
+			TenantID:    tenantID,
+			ClassID:     pgClassID,
+			Weekday:     int16(meeting.Weekday),
+			StartMinute: int32(meeting.StartMinute),
+			EndMinute:   int32(meeting.EndMinute),
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresScheduleRepository) ListMeetings(ctx context.Context, tenantID string, classID string) ([]entities.MeetingTime, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListClassMeetings(ctx, db.ListClassMeetingsParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	meetings := make([]entities.MeetingTime, 0, len(rows))
+	for _, row := range rows {
+		meetings = append(meetings, toMeetingTime(row.Weekday, row.StartMinute, row.EndMinute))
+	}
+
+	return meetings, nil
+}
+
+func (r *PostgresScheduleRepository) ListEnrolledMeetings(ctx context.Context, tenantID string, userID string) (map[string][]entities.MeetingTime, error) {
+	rows, err := r.queries.ListEnrolledClassMeetings(ctx, db.ListEnrolledClassMeetingsParams{TenantID: tenantID, UserID: userID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	meetings := make(map[string][]entities.MeetingTime)
+	for _, row := range rows {
+		classID := row.ClassID.String()
+		meetings[classID] = append(meetings[classID], toMeetingTime(row.Weekday, row.StartMinute, row.EndMinute))
+	}
+
+	return meetings, nil
+}
+
+// toMeetingTime trusts the table constraints, rows were validated when the schedule was set
+func toMeetingTime(weekday int16, startMinute int32, endMinute int32) entities.MeetingTime {
+	return entities.MeetingTime{
+		Weekday:     time.Weekday(weekday),
+		StartMinute: int(startMinute),
+		EndMinute:   int(endMinute),
+	}
+}
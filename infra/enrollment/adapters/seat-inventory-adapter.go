@@ -0,0 +1,267 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresSeatInventory serializes checkouts of a class with a row lock on its class_capacities row,
+// checkouts of different classes do not block each other
+type PostgresSeatInventory struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresSeatInventory(dbInstance *pgxpool.Pool) ports.SeatInventory {
+	return &PostgresSeatInventory{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresSeatInventory) SetCapacity(ctx context.Context, tenantID string, classID string, capacity int) error {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := s.queries.UpsertClassCapacity(ctx, db.UpsertClassCapacityParams{
+		TenantID: tenantID,
+		ClassID:  pgClassID,
+		Capacity: int32(capacity),
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (s *PostgresSeatInventory) GetCapacity(ctx context.Context, tenantID string, classID string, now time.Time) (*entities.ClassCapacity, error) {
+	return getCapacity(ctx, s.queries, tenantID, classID, now)
+}
+
+func (s *PostgresSeatInventory) WithClassLocked(ctx context.Context, tenantID string, classID string, now time.Time,
+	fn func(ledger ports.SeatLedger, capacity *entities.ClassCapacity) error) error {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	var capacity *entities.ClassCapacity
+	_, err = qtx.LockClassCapacity(ctx, db.LockClassCapacityParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return appErrors.PropagateError(err)
+	}
+	if err == nil {
+		// Counted after the lock is taken so concurrent checkouts see each other's holds
+		capacity, err = getCapacity(ctx, qtx, tenantID, classID, now)
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	ledger := &postgresSeatLedger{
+		queries:  qtx,
+		tenantID: tenantID,
+		classID:  pgClassID,
+	}
+	if err := fn(ledger, capacity); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (s *PostgresSeatInventory) DeleteExpiredHolds(ctx context.Context, now time.Time) (int64, error) {
+	deleted, err := s.queries.DeleteExpiredSeatHolds(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	return deleted, nil
+}
+
+func getCapacity(ctx context.Context, queries *db.Queries, tenantID string, classID string, now time.Time) (*entities.ClassCapacity, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := queries.GetClassCapacity(ctx, db.GetClassCapacityParams{
+		Now:      pgtype.Timestamptz{Time: now, Valid: true},
+		TenantID: tenantID,
+		ClassID:  pgClassID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &entities.ClassCapacity{
+		TenantID: tenantID,
+		ClassID:  classID,
+		Capacity: int(row.Capacity),
+		Enrolled: int(row.Enrolled),
+		Held:     int(row.Held),
+	}, nil
+}
+
+// postgresSeatLedger runs inside the transaction opened by WithClassLocked
+type postgresSeatLedger struct {
+	queries  *db.Queries
+	tenantID string
+	classID  pgtype.UUID
+}
+
+func (l *postgresSeatLedger) FindHold(ctx context.Context, holdID string) (*entities.SeatHold, error) {
+	var pgHoldID pgtype.UUID
+	if err := pgHoldID.Scan(holdID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := l.queries.GetSeatHold(ctx, db.GetSeatHoldParams{ID: pgHoldID, TenantID: l.tenantID, ClassID: l.classID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toSeatHold(row)
+}
+
+func (l *postgresSeatLedger) FindActiveHold(ctx context.Context, userID string, now time.Time) (*entities.SeatHold, error) {
+	row, err := l.queries.GetActiveSeatHoldForUser(ctx, db.GetActiveSeatHoldForUserParams{
+		TenantID: l.tenantID,
+		ClassID:  l.classID,
+		UserID:   userID,
+		Now:      pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toSeatHold(row)
+}
+
+func (l *postgresSeatLedger) IsEnrolled(ctx context.Context, userID string) (bool, error) {
+	exists, err := l.queries.ClassEnrollmentExists(ctx, db.ClassEnrollmentExistsParams{
+		TenantID: l.tenantID,
+		ClassID:  l.classID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return exists, nil
+}
+
+func (l *postgresSeatLedger) CreateHold(ctx context.Context, hold *entities.SeatHold) error {
+	var pgHoldID pgtype.UUID
+	if err := pgHoldID.Scan(hold.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := l.queries.CreateSeatHold(ctx, db.CreateSeatHoldParams{
+		ID:        pgHoldID,
+		TenantID:  l.tenantID,
+		ClassID:   l.classID,
+		UserID:    hold.UserID,
+		ExpiresAt: pgtype.Timestamptz{Time: hold.ExpiresAt, Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: hold.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (l *postgresSeatLedger) DeleteHold(ctx context.Context, holdID string) error {
+	var pgHoldID pgtype.UUID
+	if err := pgHoldID.Scan(holdID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := l.queries.DeleteSeatHold(ctx, pgHoldID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (l *postgresSeatLedger) CreateEnrollment(ctx context.Context, enrollment *entities.Enrollment) error {
+	err := l.queries.CreateClassEnrollment(ctx, db.CreateClassEnrollmentParams{
+		TenantID:   l.tenantID,
+		ClassID:    l.classID,
+		UserID:     enrollment.UserID,
+		EnrolledAt: pgtype.Timestamptz{Time: enrollment.EnrolledAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"class_id":   enrollment.ClassID,
+		"student_id": enrollment.UserID,
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize enrollment event", err)
+	}
+
+	err = l.queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.EnrollmentAdded,
+		AggregateID: enrollment.ClassID,
+		TenantID:    enrollment.TenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: enrollment.EnrolledAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func toSeatHold(row db.SeatHold) (*entities.SeatHold, error) {
+	return entities.NewSeatHold(
+		row.ID.String(),
+		row.TenantID,
+		row.ClassID.String(),
+		row.UserID,
+		row.ExpiresAt.Time,
+		row.CreatedAt.Time,
+	)
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	calendarEntities "github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	timezoneEntities "github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type ClassCapacityResponse struct {
+	ClassID   string `json:"class_id"`
+	Capacity  int    `json:"capacity"`
+	Enrolled  int    `json:"enrolled"`
+	Held      int    `json:"held" doc:"Seats held by checkouts in progress"`
+	Available int    `json:"available"`
+}
+
+func NewClassCapacityResponse(capacity *entities.ClassCapacity) ClassCapacityResponse {
+	return ClassCapacityResponse{
+		ClassID:   capacity.ClassID,
+		Capacity:  capacity.Capacity,
+		Enrolled:  capacity.Enrolled,
+		Held:      capacity.Held,
+		Available: capacity.Available(),
+	}
+}
+
+type ClassCapacityEnvelope struct {
+	Body ClassCapacityResponse
+}
+
+type GetClassCapacityRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+}
+
+type SetClassCapacityRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	Body    struct {
+		Capacity int `json:"capacity" minimum:"0" maximum:"10000"`
+	}
+}
+
+type SeatHoldResponse struct {
+	ID        string    `json:"id"`
+	ClassID   string    `json:"class_id"`
+	ExpiresAt time.Time `json:"expires_at" doc:"Confirm the enrollment before this time or the seat is released"`
+}
+
+func NewSeatHoldResponse(hold *entities.SeatHold) SeatHoldResponse {
+	return SeatHoldResponse{
+		ID:        hold.ID,
+		ClassID:   hold.ClassID,
+		ExpiresAt: hold.ExpiresAt,
+	}
+}
+
+type SeatHoldEnvelope struct {
+	Body SeatHoldResponse
+}
+
+type OverrideBody struct {
+	OverrideScheduleConflicts bool `json:"override_schedule_conflicts,omitempty" doc:"Enroll despite schedule conflicts, requires the enrollment override_schedule permission"`
+}
+
+type ReserveSeatRequest struct {
+	ClassID string        `path:"classId" format:"uuid"`
+	Body    *OverrideBody `required:"false"`
+}
+
+type ConfirmEnrollmentRequest struct {
+	ClassID string        `path:"classId" format:"uuid"`
+	HoldID  string        `path:"holdId" format:"uuid"`
+	Body    *OverrideBody `required:"false"`
+}
+
+type SeatHoldRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	HoldID  string `path:"holdId" format:"uuid"`
+}
+
+// ClassEnrollmentResponse is named apart from the enrollments user merges move, schema names are
+// shared by the whole API
+type ClassEnrollmentResponse struct {
+	ClassID    string    `json:"class_id"`
+	UserID     string    `json:"user_id"`
+	EnrolledAt time.Time `json:"enrolled_at"`
+}
+
+type EnrollmentEnvelope struct {
+	Body ClassEnrollmentResponse
+}
+
+func NewClassEnrollmentResponse(enrollment *entities.Enrollment) ClassEnrollmentResponse {
+	return ClassEnrollmentResponse{
+		ClassID:    enrollment.ClassID,
+		UserID:     enrollment.UserID,
+		EnrolledAt: enrollment.EnrolledAt,
+	}
+}
+
+type MeetingTimeBody struct {
+	Weekday  string `json:"weekday" enum:"monday,tuesday,wednesday,thursday,friday,saturday,sunday"`
+	StartsAt string `json:"starts_at" pattern:"^([01][0-9]|2[0-3]):[0-5][0-9]$" example:"09:00"`
+	EndsAt   string `json:"ends_at" pattern:"^([01][0-9]|2[0-3]):[0-5][0-9]$" example:"10:30"`
+}
+
+func NewMeetingTimeBody(meeting entities.MeetingTime) MeetingTimeBody {
+	return MeetingTimeBody{
+		Weekday:  strings.ToLower(meeting.Weekday.String()),
+		StartsAt: meeting.StartsAt(),
+		EndsAt:   meeting.EndsAt(),
+	}
+}
+
+// ScheduledMeetingResponse adds the next occurrence of a weekly meeting as an instant, in the zone of
+// the reader
+type ScheduledMeetingResponse struct {
+	MeetingTimeBody
+	NextStartsAt *time.Time `json:"next_starts_at,omitempty" doc:"Next session on an instructional day, absent when there is none within a year"`
+}
+
+type ClassScheduleResponse struct {
+	Body struct {
+		ClassID  string                     `json:"class_id"`
+		TimeZone string                     `json:"time_zone" doc:"Zone of the school, meeting times are its wall clock"`
+		Meetings []ScheduledMeetingResponse `json:"meetings"`
+	}
+}
+
+// NewClassScheduleResponse resolves meetings kept in the wall clock of the school calendar to instants
+// shown in the zone of the reader, loc. Sessions on non-instructional days are skipped.
+func NewClassScheduleResponse(classID string, meetings []entities.MeetingTime, calendar *calendarEntities.SchoolCalendar,
+	loc *time.Location, now time.Time) *ClassScheduleResponse {
+	response := &ClassScheduleResponse{}
+	response.Body.ClassID = classID
+	response.Body.TimeZone = calendar.Location().String()
+	response.Body.Meetings = make([]ScheduledMeetingResponse, 0, len(meetings))
+	for _, meeting := range meetings {
+		scheduled := ScheduledMeetingResponse{MeetingTimeBody: NewMeetingTimeBody(meeting)}
+		if next := calendar.NextWeekly(meeting.Weekday, meeting.StartMinute, now); !next.IsZero() {
+			scheduled.NextStartsAt = utils.InLocationPtr(&next, loc)
+		}
+		response.Body.Meetings = append(response.Body.Meetings, scheduled)
+	}
+	return response
+}
+
+type GetClassScheduleRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+}
+
+type ListClassSessionsRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	From    string `query:"from" format:"date" doc:"First day, in the zone of the school, today when omitted"`
+	To      string `query:"to" format:"date" doc:"Last day, 4 weeks after from when omitted, at most a year after from"`
+}
+
+// ClassSessionResponse is one occurrence of a weekly meeting. Sessions on a non-instructional day are
+// cancelled, no attendance is expected for them.
+type ClassSessionResponse struct {
+	Date        string    `json:"date" format:"date" doc:"Day of the session in the zone of the school"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Cancelled   bool      `json:"cancelled"`
+	CancelledBy string    `json:"cancelled_by,omitempty" doc:"Name of the non-instructional day the session falls on"`
+}
+
+type ClassSessionsResponse struct {
+	Body struct {
+		ClassID          string                 `json:"class_id"`
+		TimeZone         string                 `json:"time_zone" doc:"Zone of the school, dates are days in it"`
+		ExpectedSessions int                    `json:"expected_sessions" doc:"Sessions attendance is expected for, cancelled ones are left out"`
+		Sessions         []ClassSessionResponse `json:"sessions"`
+	}
+}
+
+// NewClassSessionsResponse generates the sessions of the weekly meetings that start from `from` up to,
+// not including, `to`, ordered by start
+func NewClassSessionsResponse(classID string, meetings []entities.MeetingTime, calendar *calendarEntities.SchoolCalendar,
+	loc *time.Location, from time.Time, to time.Time) *ClassSessionsResponse {
+	response := &ClassSessionsResponse{}
+	response.Body.ClassID = classID
+	response.Body.TimeZone = calendar.Location().String()
+	response.Body.Sessions = []ClassSessionResponse{}
+
+	type session struct {
+		startsAt time.Time
+		endsAt   time.Time
+	}
+	var sessions []session
+	for _, meeting := range meetings {
+		startsAt := timezoneEntities.NextWeekly(meeting.Weekday, meeting.StartMinute, from.Add(-time.Nanosecond), calendar.Location())
+		for ; startsAt.Before(to); startsAt = timezoneEntities.NextWeekly(meeting.Weekday, meeting.StartMinute, startsAt, calendar.Location()) {
+			endsAt := timezoneEntities.WallClock(startsAt.Year(), startsAt.Month(), startsAt.Day(), meeting.EndMinute, calendar.Location())
+			sessions = append(sessions, session{startsAt: startsAt, endsAt: endsAt})
+		}
+	}
+	slices.SortFunc(sessions, func(a, b session) int { return a.startsAt.Compare(b.startsAt) })
+
+	for _, s := range sessions {
+		sessionResponse := ClassSessionResponse{
+			Date:     s.startsAt.Format(calendarEntities.DateLayout),
+			StartsAt: utils.InLocation(s.startsAt, loc),
+			EndsAt:   utils.InLocation(s.endsAt, loc),
+		}
+		if day := calendar.NonInstructionalDay(s.startsAt); day != nil {
+			sessionResponse.Cancelled = true
+			sessionResponse.CancelledBy = day.Name
+		} else {
+			response.Body.ExpectedSessions++
+		}
+		response.Body.Sessions = append(response.Body.Sessions, sessionResponse)
+	}
+	return response
+}
+
+type SetClassScheduleRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	Body    struct {
+		Meetings []MeetingTimeBody `json:"meetings" maxItems:"21"`
+	}
+}
@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	get_school_calendar_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/get-school-calendar-use-case"
+	calendarEntities "github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	confirm_enrollment_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/confirm-enrollment-use-case"
+	enroll_student_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/enroll-student-use-case"
+	get_class_capacity_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/get-class-capacity-use-case"
+	get_class_schedule_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/get-class-schedule-use-case"
+	release_seat_hold_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/release-seat-hold-use-case"
+	reserve_seat_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/reserve-seat-use-case"
+	set_class_capacity_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/set-class-capacity-use-case"
+	set_class_schedule_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/set-class-schedule-use-case"
+	"github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	timezoneEntities "github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// overrideSchedulePermission lets admins enroll students into classes that clash with their schedule
+var overrideSchedulePermission = authorization.ResourceAction{Resource: "enrollment", Action: "override_schedule"}
+
+type EnrollmentHandlers struct {
+	setClassCapacityUseCase  *set_class_capacity_use_case.SetClassCapacityUseCase
+	getClassCapacityUseCase  *get_class_capacity_use_case.GetClassCapacityUseCase
+	setClassScheduleUseCase  *set_class_schedule_use_case.SetClassScheduleUseCase
+	getClassScheduleUseCase  *get_class_schedule_use_case.GetClassScheduleUseCase
+	reserveSeatUseCase       *reserve_seat_use_case.ReserveSeatUseCase
+	enrollStudentUseCase     *enroll_student_use_case.EnrollStudentUseCase
+	releaseSeatHoldUseCase   *release_seat_hold_use_case.ReleaseSeatHoldUseCase
+	getSchoolCalendarUseCase *get_school_calendar_use_case.GetSchoolCalendarUseCase
+	authzService             *authorization.CasbinService
+}
+
+func NewEnrollmentHandlers(
+	setClassCapacityUseCase *set_class_capacity_use_case.SetClassCapacityUseCase,
+	getClassCapacityUseCase *get_class_capacity_use_case.GetClassCapacityUseCase,
+	setClassScheduleUseCase *set_class_schedule_use_case.SetClassScheduleUseCase,
+	getClassScheduleUseCase *get_class_schedule_use_case.GetClassScheduleUseCase,
+	reserveSeatUseCase *reserve_seat_use_case.ReserveSeatUseCase,
+	enrollStudentUseCase *enroll_student_use_case.EnrollStudentUseCase,
+	releaseSeatHoldUseCase *release_seat_hold_use_case.ReleaseSeatHoldUseCase,
+	getSchoolCalendarUseCase *get_school_calendar_use_case.GetSchoolCalendarUseCase,
+	authzService *authorization.CasbinService,
+) *EnrollmentHandlers {
+	return &EnrollmentHandlers{
+		setClassCapacityUseCase:  setClassCapacityUseCase,
+		getClassCapacityUseCase:  getClassCapacityUseCase,
+		setClassScheduleUseCase:  setClassScheduleUseCase,
+		getClassScheduleUseCase:  getClassScheduleUseCase,
+		reserveSeatUseCase:       reserveSeatUseCase,
+		enrollStudentUseCase:     enrollStudentUseCase,
+		releaseSeatHoldUseCase:   releaseSeatHoldUseCase,
+		getSchoolCalendarUseCase: getSchoolCalendarUseCase,
+		authzService:             authzService,
+	}
+}
+
+func (h *EnrollmentHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "set-class-capacity",
+		Method:      http.MethodPut,
+		Path:        "/classes/{classId}/capacity",
+		Summary:     "Set the seat capacity of a class",
+		Tags:        []string{"Enrollment"},
+	}, h.SetClassCapacity)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-class-capacity",
+		Method:      http.MethodGet,
+		Path:        "/classes/{classId}/capacity",
+		Summary:     "Get the seat availability of a class",
+		Tags:        []string{"Enrollment"},
+	}, h.GetClassCapacity)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-class-schedule",
+		Method:      http.MethodPut,
+		Path:        "/classes/{classId}/schedule",
+		Summary:     "Replace the weekly meetings of a class",
+		Tags:        []string{"Enrollment"},
+	}, h.SetClassSchedule)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-class-schedule",
+		Method:      http.MethodGet,
+		Path:        "/classes/{classId}/schedule",
+		Summary:     "Get the weekly meetings of a class",
+		Tags:        []string{"Enrollment"},
+	}, h.GetClassSchedule)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-class-sessions",
+		Method:      http.MethodGet,
+		Path:        "/classes/{classId}/sessions",
+		Summary:     "List the sessions of a class in a range of days",
+		Description: "Sessions on non-instructional days of the school calendar are listed as cancelled, attendance is expected for the others.",
+		Tags:        []string{"Enrollment"},
+	}, h.ListClassSessions)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "reserve-seat",
+		Method:        http.MethodPost,
+		Path:          "/classes/{classId}/seat-holds",
+		Summary:       "Hold a seat for the enrollment checkout",
+		Description:   "The seat is held until expires_at. Confirm the hold to enroll, or release it to give the seat back. Fails with SCHEDULE_CONFLICT when the class overlaps classes the student is enrolled in.",
+		Tags:          []string{"Enrollment"},
+		DefaultStatus: http.StatusCreated,
+	}, h.ReserveSeat)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "confirm-enrollment",
+		Method:        http.MethodPost,
+		Path:          "/classes/{classId}/seat-holds/{holdId}/confirm",
+		Summary:       "Confirm a seat hold into an enrollment",
+		Tags:          []string{"Enrollment"},
+		DefaultStatus: http.StatusCreated,
+	}, h.ConfirmEnrollment)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "release-seat-hold",
+		Method:        http.MethodDelete,
+		Path:          "/classes/{classId}/seat-holds/{holdId}",
+		Summary:       "Release a seat hold",
+		Tags:          []string{"Enrollment"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.ReleaseSeatHold)
+}
+
+func (h *EnrollmentHandlers) SetClassCapacity(ctx context.Context, input *SetClassCapacityRequest) (*ClassCapacityEnvelope, error) {
+	command, err := set_class_capacity_use_case.NewSetClassCapacityCommand(authorization.TenantIDFromContext(ctx), input.ClassID, input.Body.Capacity)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	capacity, err := h.setClassCapacityUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ClassCapacityEnvelope{Body: NewClassCapacityResponse(capacity)}, nil
+}
+
+func (h *EnrollmentHandlers) GetClassCapacity(ctx context.Context, input *GetClassCapacityRequest) (*ClassCapacityEnvelope, error) {
+	command, err := get_class_capacity_use_case.NewGetClassCapacityCommand(authorization.TenantIDFromContext(ctx), input.ClassID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	capacity, err := h.getClassCapacityUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ClassCapacityEnvelope{Body: NewClassCapacityResponse(capacity)}, nil
+}
+
+func (h *EnrollmentHandlers) SetClassSchedule(ctx context.Context, input *SetClassScheduleRequest) (*ClassScheduleResponse, error) {
+	meetings := make([]set_class_schedule_use_case.MeetingInput, 0, len(input.Body.Meetings))
+	for _, meeting := range input.Body.Meetings {
+		meetings = append(meetings, set_class_schedule_use_case.MeetingInput{
+			Weekday:  meeting.Weekday,
+			StartsAt: meeting.StartsAt,
+			EndsAt:   meeting.EndsAt,
+		})
+	}
+
+	command, err := set_class_schedule_use_case.NewSetClassScheduleCommand(authorization.TenantIDFromContext(ctx), input.ClassID, meetings)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	saved, err := h.setClassScheduleUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return h.scheduleResponse(ctx, input.ClassID, saved)
+}
+
+func (h *EnrollmentHandlers) GetClassSchedule(ctx context.Context, input *GetClassScheduleRequest) (*ClassScheduleResponse, error) {
+	command, err := get_class_schedule_use_case.NewGetClassScheduleCommand(authorization.TenantIDFromContext(ctx), input.ClassID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	meetings, err := h.getClassScheduleUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return h.scheduleResponse(ctx, input.ClassID, meetings)
+}
+
+func (h *EnrollmentHandlers) ListClassSessions(ctx context.Context, input *ListClassSessionsRequest) (*ClassSessionsResponse, error) {
+	tenantLoc := utils.TenantLocationFromContext(ctx)
+	from := time.Now().In(tenantLoc)
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	if input.From != "" {
+		parsed, err := time.Parse(calendarEntities.DateLayout, input.From)
+		if err != nil {
+			return nil, huma.Error400BadRequest("from must be a date like 2025-09-15")
+		}
+		from = parsed
+	}
+	to := from.AddDate(0, 0, 27)
+	if input.To != "" {
+		parsed, err := time.Parse(calendarEntities.DateLayout, input.To)
+		if err != nil {
+			return nil, huma.Error400BadRequest("to must be a date like 2025-10-12")
+		}
+		to = parsed
+	}
+
+	// Days are read in the wall clock of the school, the range ends when the last day does
+	fromInstant := timezoneEntities.WallClock(from.Year(), from.Month(), from.Day(), 0, tenantLoc)
+	toInstant := timezoneEntities.WallClock(to.Year(), to.Month(), to.Day()+1, 0, tenantLoc)
+
+	command, err := get_class_schedule_use_case.NewGetClassScheduleCommand(authorization.TenantIDFromContext(ctx), input.ClassID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	meetings, err := h.getClassScheduleUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	calendar, err := h.schoolCalendar(ctx, fromInstant, toInstant)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewClassSessionsResponse(input.ClassID, meetings, calendar, utils.LocationFromContext(ctx), fromInstant, toInstant), nil
+}
+
+// scheduleResponse resolves the next sessions of the meetings, skipping non-instructional days within a year
+func (h *EnrollmentHandlers) scheduleResponse(ctx context.Context, classID string, meetings []entities.MeetingTime) (*ClassScheduleResponse, error) {
+	now := time.Now()
+	calendar, err := h.schoolCalendar(ctx, now, now.Add(get_school_calendar_use_case.MaxWindow))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewClassScheduleResponse(classID, meetings, calendar, utils.LocationFromContext(ctx), now), nil
+}
+
+func (h *EnrollmentHandlers) schoolCalendar(ctx context.Context, from time.Time, to time.Time) (*calendarEntities.SchoolCalendar, error) {
+	command, err := get_school_calendar_use_case.NewGetSchoolCalendarCommand(authorization.TenantIDFromContext(ctx), from, to)
+	if err != nil {
+		return nil, err
+	}
+	return h.getSchoolCalendarUseCase.Execute(ctx, command)
+}
+
+func (h *EnrollmentHandlers) ReserveSeat(ctx context.Context, input *ReserveSeatRequest) (*SeatHoldEnvelope, error) {
+	override, err := h.scheduleOverride(ctx, input.Body)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	command, err := reserve_seat_use_case.NewReserveSeatCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		authorization.UserIDFromContext(ctx),
+		override,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	hold, err := h.reserveSeatUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SeatHoldEnvelope{Body: NewSeatHoldResponse(hold)}, nil
+}
+
+func (h *EnrollmentHandlers) ConfirmEnrollment(ctx context.Context, input *ConfirmEnrollmentRequest) (*EnrollmentEnvelope, error) {
+	override, err := h.scheduleOverride(ctx, input.Body)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	command, err := confirm_enrollment_use_case.NewConfirmEnrollmentCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		input.HoldID,
+		authorization.UserIDFromContext(ctx),
+		override,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	enrollment, err := h.enrollStudentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &EnrollmentEnvelope{Body: NewClassEnrollmentResponse(enrollment)}, nil
+}
+
+func (h *EnrollmentHandlers) ReleaseSeatHold(ctx context.Context, input *SeatHoldRequest) (*struct{}, error) {
+	command, err := release_seat_hold_use_case.NewReleaseSeatHoldCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		input.HoldID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.releaseSeatHoldUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
+
+// scheduleOverride returns whether the caller asked to skip the schedule conflict check, asking
+// without the override permission is rejected instead of silently ignored
+func (h *EnrollmentHandlers) scheduleOverride(ctx context.Context, body *OverrideBody) (bool, error) {
+	if body == nil || !body.OverrideScheduleConflicts {
+		return false, nil
+	}
+
+	err := authorization.Authorize(h.authzService, authorization.UserIDFromContext(ctx), authorization.TenantIDFromContext(ctx), overrideSchedulePermission)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,91 @@
+-- name: UpsertClassCapacity :exec
+INSERT INTO class_capacities (tenant_id, class_id, capacity, updated_at)
+VALUES (@tenant_id, @class_id, @capacity, NOW())
+ON CONFLICT (tenant_id, class_id) DO UPDATE
+SET capacity = EXCLUDED.capacity,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: LockClassCapacity :one
+SELECT capacity
+FROM class_capacities
+WHERE tenant_id = @tenant_id AND class_id = @class_id
+FOR UPDATE;
+
+-- name: GetClassCapacity :one
+SELECT c.capacity,
+       (SELECT COUNT(*) FROM class_enrollments e
+        WHERE e.tenant_id = c.tenant_id AND e.class_id = c.class_id) AS enrolled,
+       (SELECT COUNT(*) FROM seat_holds h
+        WHERE h.tenant_id = c.tenant_id AND h.class_id = c.class_id AND h.expires_at > @now) AS held
+FROM class_capacities c
+WHERE c.tenant_id = @tenant_id AND c.class_id = @class_id;
+
+-- name: GetSeatHold :one
+SELECT *
+FROM seat_holds
+WHERE id = @id AND tenant_id = @tenant_id AND class_id = @class_id;
+
+-- name: GetActiveSeatHoldForUser :one
+SELECT *
+FROM seat_holds
+WHERE tenant_id = @tenant_id AND class_id = @class_id AND user_id = @user_id AND expires_at > @now
+ORDER BY expires_at DESC
+LIMIT 1;
+
+-- name: CreateSeatHold :exec
+INSERT INTO seat_holds (id, tenant_id, class_id, user_id, expires_at, created_at)
+VALUES (@id, @tenant_id, @class_id, @user_id, @expires_at, @created_at);
+
+-- name: DeleteSeatHold :exec
+DELETE FROM seat_holds
+WHERE id = @id;
+
+-- name: DeleteExpiredSeatHolds :execrows
+DELETE FROM seat_holds
+WHERE expires_at <= @now;
+
+-- name: ClassEnrollmentExists :one
+SELECT EXISTS (
+    SELECT 1
+    FROM class_enrollments
+    WHERE tenant_id = @tenant_id AND class_id = @class_id AND user_id = @user_id
+);
+
+-- name: CreateClassEnrollment :exec
+INSERT INTO class_enrollments (tenant_id, class_id, user_id, enrolled_at)
+VALUES (@tenant_id, @class_id, @user_id, @enrolled_at);
+
+-- name: GetClassEnrollment :one
+SELECT *
+FROM class_enrollments
+WHERE tenant_id = @tenant_id AND class_id = @class_id AND user_id = @user_id;
+
+-- name: DeleteClassEnrollment :execrows
+DELETE FROM class_enrollments
+WHERE tenant_id = @tenant_id AND class_id = @class_id AND user_id = @user_id;
+
+-- name: CreateEnrollmentNotification :execrows
+INSERT INTO enrollment_notifications (id, tenant_id, class_id, student_id, kind, created_at)
+VALUES (@id, @tenant_id, @class_id, @student_id, @kind, @created_at)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: DeleteClassMeetings :exec
+DELETE FROM class_meetings
+WHERE tenant_id = @tenant_id AND class_id = @class_id;
+
+-- name: CreateClassMeeting :exec
+INSERT INTO class_meetings (tenant_id, class_id, weekday, start_minute, end_minute)
+VALUES (@tenant_id, @class_id, @weekday, @start_minute, @end_minute);
+
+-- name: ListClassMeetings :many
+SELECT weekday, start_minute, end_minute
+FROM class_meetings
+WHERE tenant_id = @tenant_id AND class_id = @class_id
+ORDER BY weekday, start_minute;
+
+-- name: ListEnrolledClassMeetings :many
+SELECT m.class_id, m.weekday, m.start_minute, m.end_minute
+FROM class_meetings m
+JOIN class_enrollments e ON e.tenant_id = m.tenant_id AND e.class_id = m.class_id
+WHERE e.tenant_id = @tenant_id AND e.user_id = @user_id
+ORDER BY m.class_id, m.weekday, m.start_minute;
@@ -0,0 +1,54 @@
+-- Seat inventory per class. The row is locked (FOR UPDATE) while holds and enrollments of the class
+-- change, so concurrent checkouts cannot oversubscribe it
+CREATE TABLE class_capacities (
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, class_id)
+);
+
+CREATE TABLE class_enrollments (
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    enrolled_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, class_id, user_id)
+);
+
+-- Short lived seat holds taken when the enrollment checkout starts, expired rows no longer count
+-- against the capacity and are purged by the cleanup worker
+CREATE TABLE seat_holds (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_seat_holds_class ON seat_holds(tenant_id, class_id, expires_at);
+CREATE INDEX idx_seat_holds_expires_at ON seat_holds(expires_at);
+
+-- Weekly meetings of a class, used to detect schedule conflicts when students enroll
+CREATE TABLE class_meetings (
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),  -- 0 = sunday
+    start_minute INTEGER NOT NULL,                               -- minutes since midnight
+    end_minute INTEGER NOT NULL,
+    CHECK (start_minute >= 0 AND end_minute > start_minute AND end_minute <= 1440)
+);
+
+CREATE INDEX idx_class_meetings_class ON class_meetings(tenant_id, class_id);
+
+-- Notifications about enrollments queued for students, senders deliver them from the outbox. The ID
+-- is chosen by the enrollment saga so a step run again does not queue a second one
+CREATE TABLE enrollment_notifications (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    student_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(30) NOT NULL,             -- enrolled, enrollment_canceled
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
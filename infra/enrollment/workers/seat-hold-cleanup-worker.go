@@ -0,0 +1,30 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	release_seat_hold_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/release-seat-hold-use-case"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+)
+
+// RunSeatHoldCleanup purges expired seat holds every interval, it returns when ctx is cancelled
+func RunSeatHoldCleanup(ctx context.Context, useCase *release_seat_hold_use_case.ReleaseSeatHoldUseCase, clock clock.Clock, interval time.Duration) {
+	ticker := clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := useCase.PurgeExpired(ctx); err != nil {
+			log.Printf("Failed to purge expired seat holds: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Purged %d expired seat holds", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
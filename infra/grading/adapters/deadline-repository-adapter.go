@@ -0,0 +1,158 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresDeadlineRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresDeadlineRepository(dbInstance *pgxpool.Pool) ports.DeadlineRepository {
+	return &PostgresDeadlineRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresDeadlineRepository) SetDeadline(ctx context.Context, deadline *entities.AssignmentDeadline) error {
+	var pgClassID, pgAssignmentID pgtype.UUID
+	if err := pgClassID.Scan(deadline.ClassID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgAssignmentID.Scan(deadline.AssignmentID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.UpsertAssignmentDeadline(ctx, db.UpsertAssignmentDeadlineParams{
+		TenantID:             deadline.TenantID,
+		ClassID:              pgClassID,
+		AssignmentID:         pgAssignmentID,
+		DueAt:                pgtype.Timestamptz{Time: deadline.DueAt, Valid: true},
+		PenaltyPercentPerDay: deadline.Policy.PenaltyPercentPerDay,
+		CutoffDays:           int32(deadline.Policy.CutoffDays),
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresDeadlineRepository) GetDeadline(ctx context.Context, tenantID string, classID string, assignmentID string) (*entities.AssignmentDeadline, error) {
+	var pgClassID, pgAssignmentID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := pgAssignmentID.Scan(assignmentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetAssignmentDeadline(ctx, db.GetAssignmentDeadlineParams{
+		TenantID:     tenantID,
+		ClassID:      pgClassID,
+		AssignmentID: pgAssignmentID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toAssignmentDeadline(row)
+}
+
+func (r *PostgresDeadlineRepository) ListDeadlines(ctx context.Context, tenantID string, classID string) ([]*entities.AssignmentDeadline, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListAssignmentDeadlines(ctx, db.ListAssignmentDeadlinesParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	deadlines := make([]*entities.AssignmentDeadline, 0, len(rows))
+	for _, row := range rows {
+		deadline, err := toAssignmentDeadline(row)
+		if err != nil {
+			return nil, err
+		}
+		deadlines = append(deadlines, deadline)
+	}
+
+	return deadlines, nil
+}
+
+func (r *PostgresDeadlineRepository) SetExtension(ctx context.Context, extension *entities.Extension) error {
+	var pgClassID, pgAssignmentID pgtype.UUID
+	if err := pgClassID.Scan(extension.ClassID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgAssignmentID.Scan(extension.AssignmentID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.UpsertAssignmentExtension(ctx, db.UpsertAssignmentExtensionParams{
+		TenantID:     extension.TenantID,
+		ClassID:      pgClassID,
+		AssignmentID: pgAssignmentID,
+		StudentID:    extension.StudentID,
+		DueAt:        pgtype.Timestamptz{Time: extension.DueAt, Valid: true},
+		GrantedBy:    extension.GrantedBy,
+		Reason:       emptyToNil(extension.Reason),
+		GrantedAt:    pgtype.Timestamptz{Time: extension.GrantedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresDeadlineRepository) ListExtensions(ctx context.Context, tenantID string, classID string) ([]*entities.Extension, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListAssignmentExtensions(ctx, db.ListAssignmentExtensionsParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	extensions := make([]*entities.Extension, 0, len(rows))
+	for _, row := range rows {
+		extensions = append(extensions, &entities.Extension{
+			TenantID:     row.TenantID,
+			ClassID:      row.ClassID.String(),
+			AssignmentID: row.AssignmentID.String(),
+			StudentID:    row.StudentID,
+			DueAt:        row.DueAt.Time,
+			GrantedBy:    row.GrantedBy,
+			Reason:       valueOrEmpty(row.Reason),
+			GrantedAt:    row.GrantedAt.Time,
+		})
+	}
+
+	return extensions, nil
+}
+
+func toAssignmentDeadline(row db.AssignmentDeadline) (*entities.AssignmentDeadline, error) {
+	return entities.NewAssignmentDeadline(row.TenantID, row.ClassID.String(), row.AssignmentID.String(), row.DueAt.Time, entities.LatePolicy{
+		PenaltyPercentPerDay: row.PenaltyPercentPerDay,
+		CutoffDays:           int(row.CutoffDays),
+	})
+}
@@ -0,0 +1,435 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresGradeRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresGradeRepository(dbInstance *pgxpool.Pool) ports.GradeRepository {
+	return &PostgresGradeRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresGradeRepository) Save(ctx context.Context, grade *entities.Grade) error {
+	var pgID, pgClassID, pgAssignmentID pgtype.UUID
+	if err := pgID.Scan(grade.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgClassID.Scan(grade.ClassID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgAssignmentID.Scan(grade.AssignmentID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	if err := appendGradeEvents(ctx, qtx, grade); err != nil {
+		return err
+	}
+
+	err = qtx.UpsertGrade(ctx, db.UpsertGradeParams{
+		ID:           pgID,
+		TenantID:     grade.TenantID,
+		ClassID:      pgClassID,
+		AssignmentID: pgAssignmentID,
+		StudentID:    grade.StudentID,
+		Score:        grade.Score,
+		Status:       string(grade.Status),
+		RecordedBy:   grade.RecordedBy,
+		TurnedInAt:   timestamptz(grade.TurnedInAt),
+		CreatedAt:    pgtype.Timestamptz{Time: grade.CreatedAt, Valid: true},
+		UpdatedAt:    pgtype.Timestamptz{Time: grade.UpdatedAt, Valid: true},
+		Version:      int32(grade.Version),
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	grade.ClearPendingEvents()
+
+	return nil
+}
+
+func (r *PostgresGradeRepository) FindByStudent(ctx context.Context, tenantID string, classID string, assignmentID string, studentID string) (*entities.Grade, error) {
+	var pgClassID, pgAssignmentID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := pgAssignmentID.Scan(assignmentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetGradeByStudent(ctx, db.GetGradeByStudentParams{
+		TenantID:     tenantID,
+		ClassID:      pgClassID,
+		AssignmentID: pgAssignmentID,
+		StudentID:    studentID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toGrade(row)
+}
+
+func (r *PostgresGradeRepository) WithGradesLocked(ctx context.Context, tenantID string, classID string, gradeIDs []string,
+	fn func(grades []*entities.Grade) error) error {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	pgGradeIDs := make([]pgtype.UUID, len(gradeIDs))
+	for i, id := range gradeIDs {
+		if err := pgGradeIDs[i].Scan(id); err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	rows, err := qtx.ListGradesByIDs(ctx, db.ListGradesByIDsParams{TenantID: tenantID, ClassID: pgClassID, GradeIds: pgGradeIDs})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	grades, err := toGrades(rows)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(grades); err != nil {
+		return err
+	}
+
+	for i, grade := range grades {
+		if len(grade.PendingEvents()) == 0 {
+			continue
+		}
+
+		if err := appendGradeEvents(ctx, qtx, grade); err != nil {
+			return err
+		}
+		err := qtx.UpdateLockedGrade(ctx, db.UpdateLockedGradeParams{
+			Score:              grade.Score,
+			RecordedBy:         grade.RecordedBy,
+			Dropped:            grade.Dropped,
+			Status:             string(grade.Status),
+			ReviewedBy:         emptyToNil(grade.ReviewedBy),
+			ReturnReason:       emptyToNil(grade.ReturnReason),
+			LatePenaltyPercent: grade.LatePenaltyPercent,
+			SubmittedAt:        timestamptz(grade.SubmittedAt),
+			ReleasedAt:         timestamptz(grade.ReleasedAt),
+			UpdatedAt:          pgtype.Timestamptz{Time: grade.UpdatedAt, Valid: true},
+			Version:            int32(grade.Version),
+			ID:                 rows[i].ID,
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+
+		// Dropped grades do not count toward any average, read models never see them
+		if grade.Status == entities.GradeStatusReleased && !grade.Dropped {
+			if err := appendGradeRecorded(ctx, qtx, grade); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	for _, grade := range grades {
+		grade.ClearPendingEvents()
+	}
+
+	return nil
+}
+
+func (r *PostgresGradeRepository) ListByClass(ctx context.Context, tenantID string, classID string, assignmentID string) ([]*entities.Grade, error) {
+	var pgClassID, pgAssignmentID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if assignmentID != "" {
+		if err := pgAssignmentID.Scan(assignmentID); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+	}
+
+	rows, err := r.queries.ListClassGrades(ctx, db.ListClassGradesParams{
+		TenantID:     tenantID,
+		ClassID:      pgClassID,
+		AssignmentID: pgAssignmentID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toGrades(rows)
+}
+
+func (r *PostgresGradeRepository) ListReleasedForStudent(ctx context.Context, tenantID string, studentID string, classID string) ([]*entities.Grade, error) {
+	var pgClassID pgtype.UUID
+	if classID != "" {
+		if err := pgClassID.Scan(classID); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+	}
+
+	rows, err := r.queries.ListReleasedGradesForStudent(ctx, db.ListReleasedGradesForStudentParams{
+		TenantID:  tenantID,
+		StudentID: studentID,
+		ClassID:   pgClassID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toGrades(rows)
+}
+
+func (r *PostgresGradeRepository) ListEvents(ctx context.Context, tenantID string, classID string, gradeID string) ([]*entities.GradeEvent, error) {
+	var pgClassID, pgGradeID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := pgGradeID.Scan(gradeID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListGradeEvents(ctx, db.ListGradeEventsParams{TenantID: tenantID, ClassID: pgClassID, GradeID: pgGradeID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toGradeEvents(rows), nil
+}
+
+func (r *PostgresGradeRepository) ListAdjustmentEvents(ctx context.Context, tenantID string, classID string, adjustmentID string) ([]*entities.GradeEvent, error) {
+	var pgClassID, pgAdjustmentID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := pgAdjustmentID.Scan(adjustmentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListAdjustmentEvents(ctx, db.ListAdjustmentEventsParams{TenantID: tenantID, ClassID: pgClassID, AdjustmentID: pgAdjustmentID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toGradeEvents(rows), nil
+}
+
+// appendGradeEvents appends the pending events of the grade, an event whose version is taken means
+// the grade was changed since it was read and nothing is stored
+func appendGradeEvents(ctx context.Context, queries *db.Queries, grade *entities.Grade) error {
+	for _, event := range grade.PendingEvents() {
+		var pgGradeID, pgClassID, pgAssignmentID pgtype.UUID
+		if err := pgGradeID.Scan(event.GradeID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+		if err := pgClassID.Scan(event.ClassID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+		if err := pgAssignmentID.Scan(event.AssignmentID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+		var pgAdjustmentID pgtype.UUID
+		if event.AdjustmentID != "" {
+			if err := pgAdjustmentID.Scan(event.AdjustmentID); err != nil {
+				return appErrors.PropagateError(err)
+			}
+		}
+
+		appended, err := queries.AppendGradeEvent(ctx, db.AppendGradeEventParams{
+			TenantID:           event.TenantID,
+			GradeID:            pgGradeID,
+			Version:            int32(event.Version),
+			ClassID:            pgClassID,
+			AssignmentID:       pgAssignmentID,
+			StudentID:          event.StudentID,
+			EventType:          string(event.Type),
+			ActorID:            event.ActorID,
+			Reason:             emptyToNil(event.Reason),
+			OldScore:           event.OldScore,
+			NewScore:           event.NewScore,
+			OldStatus:          emptyToNil(string(event.OldStatus)),
+			NewStatus:          string(event.NewStatus),
+			TurnedInAt:         timestamptz(event.TurnedInAt),
+			LatePenaltyPercent: event.LatePenaltyPercent,
+			OccurredAt:         pgtype.Timestamptz{Time: event.OccurredAt, Valid: true},
+			Dropped:            event.Dropped,
+			AdjustmentID:       pgAdjustmentID,
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+		if appended == 0 {
+			return gradingErrors.NewGradeChangedConcurrentlyError(event.GradeID, event.Version-1)
+		}
+	}
+	return nil
+}
+
+// appendGradeRecorded publishes a released grade with its late penalty applied, released grades cannot
+// change so there is never a previous_score
+func appendGradeRecorded(ctx context.Context, queries *db.Queries, grade *entities.Grade) error {
+	payload, err := json.Marshal(map[string]any{
+		"class_id":      grade.ClassID,
+		"student_id":    grade.StudentID,
+		"score":         grade.FinalScore(),
+		"grade_id":      grade.ID,
+		"assignment_id": grade.AssignmentID,
+		"version":       grade.Version,
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize grade event", err)
+	}
+
+	err = queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.GradeRecorded,
+		AggregateID: grade.ClassID,
+		TenantID:    grade.TenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: grade.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func toGradeEvents(rows []db.GradeEvent) []*entities.GradeEvent {
+	events := make([]*entities.GradeEvent, 0, len(rows))
+	for _, row := range rows {
+		event := &entities.GradeEvent{
+			TenantID:           row.TenantID,
+			GradeID:            row.GradeID.String(),
+			ClassID:            row.ClassID.String(),
+			AssignmentID:       row.AssignmentID.String(),
+			StudentID:          row.StudentID,
+			Version:            int(row.Version),
+			Type:               entities.GradeEventType(row.EventType),
+			ActorID:            row.ActorID,
+			Reason:             valueOrEmpty(row.Reason),
+			OldScore:           row.OldScore,
+			NewScore:           row.NewScore,
+			OldStatus:          entities.GradeStatus(valueOrEmpty(row.OldStatus)),
+			NewStatus:          entities.GradeStatus(row.NewStatus),
+			TurnedInAt:         timePtr(row.TurnedInAt),
+			LatePenaltyPercent: row.LatePenaltyPercent,
+			Dropped:            row.Dropped,
+			OccurredAt:         row.OccurredAt.Time,
+		}
+		if row.AdjustmentID.Valid {
+			event.AdjustmentID = row.AdjustmentID.String()
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func toGrades(rows []db.Grade) ([]*entities.Grade, error) {
+	grades := make([]*entities.Grade, 0, len(rows))
+	for _, row := range rows {
+		grade, err := toGrade(row)
+		if err != nil {
+			return nil, err
+		}
+		grades = append(grades, grade)
+	}
+	return grades, nil
+}
+
+func toGrade(row db.Grade) (*entities.Grade, error) {
+	return entities.NewGrade(
+		row.ID.String(),
+		row.TenantID,
+		row.ClassID.String(),
+		row.AssignmentID.String(),
+		row.StudentID,
+		row.Score,
+		entities.GradeStatus(row.Status),
+		row.RecordedBy,
+		valueOrEmpty(row.ReviewedBy),
+		valueOrEmpty(row.ReturnReason),
+		timePtr(row.TurnedInAt),
+		row.LatePenaltyPercent,
+		row.Dropped,
+		timePtr(row.SubmittedAt),
+		timePtr(row.ReleasedAt),
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+		int(row.Version),
+	)
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func timePtr(value pgtype.Timestamptz) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+func timestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
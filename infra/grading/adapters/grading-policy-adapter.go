@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresGradingPolicyRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresGradingPolicyRepository(dbInstance *pgxpool.Pool) ports.GradingPolicyRepository {
+	return &PostgresGradingPolicyRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresGradingPolicyRepository) Get(ctx context.Context, tenantID string, classID string) (*entities.GradingPolicy, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	requiresApproval, err := r.queries.GetGradingPolicy(ctx, db.GetGradingPolicyParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &entities.GradingPolicy{TenantID: tenantID, ClassID: classID, RequiresApproval: requiresApproval}, nil
+}
+
+func (r *PostgresGradingPolicyRepository) Set(ctx context.Context, policy *entities.GradingPolicy) error {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(policy.ClassID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.UpsertGradingPolicy(ctx, db.UpsertGradingPolicyParams{
+		TenantID:         policy.TenantID,
+		ClassID:          pgClassID,
+		RequiresApproval: policy.RequiresApproval,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type GradeResponse struct {
+	ID                 string     `json:"id"`
+	ClassID            string     `json:"class_id"`
+	AssignmentID       string     `json:"assignment_id"`
+	StudentID          string     `json:"student_id"`
+	Score              float64    `json:"score"`
+	LatePenaltyPercent float64    `json:"late_penalty_percent"`
+	FinalScore         float64    `json:"final_score" doc:"Score after the late penalty"`
+	Dropped            bool       `json:"dropped" doc:"Left out of the average of the student"`
+	Status             string     `json:"status" enum:"draft,submitted,approved,released"`
+	RecordedBy         string     `json:"recorded_by"`
+	ReviewedBy         string     `json:"reviewed_by,omitempty"`
+	ReturnReason       string     `json:"return_reason,omitempty" doc:"Why the grade was sent back to draft during moderation"`
+	TurnedInAt         *time.Time `json:"turned_in_at,omitempty"`
+	SubmittedAt        *time.Time `json:"submitted_at,omitempty"`
+	ReleasedAt         *time.Time `json:"released_at,omitempty"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+func NewGradeResponse(grade *entities.Grade) GradeResponse {
+	return GradeResponse{
+		ID:                 grade.ID,
+		ClassID:            grade.ClassID,
+		AssignmentID:       grade.AssignmentID,
+		StudentID:          grade.StudentID,
+		Score:              grade.Score,
+		LatePenaltyPercent: grade.LatePenaltyPercent,
+		FinalScore:         grade.FinalScore(),
+		Dropped:            grade.Dropped,
+		Status:             string(grade.Status),
+		RecordedBy:         grade.RecordedBy,
+		ReviewedBy:         grade.ReviewedBy,
+		ReturnReason:       grade.ReturnReason,
+		TurnedInAt:         grade.TurnedInAt,
+		SubmittedAt:        grade.SubmittedAt,
+		ReleasedAt:         grade.ReleasedAt,
+		UpdatedAt:          grade.UpdatedAt,
+	}
+}
+
+// GradebookEntryResponse is a grade with the late policy applied. Released grades show the penalty
+// frozen on release, other grades preview it under the current deadline and extension.
+type GradebookEntryResponse struct {
+	GradeResponse
+	EffectiveDueAt *time.Time `json:"effective_due_at,omitempty" doc:"Due date after extensions, empty when the assignment has no deadline"`
+	DaysLate       int        `json:"days_late"`
+	PastCutoff     bool       `json:"past_cutoff"`
+}
+
+// NewGradebookEntryResponse shows the due date in the zone of the reader
+func NewGradebookEntryResponse(entry entities.GradebookEntry, loc *time.Location) GradebookEntryResponse {
+	response := GradebookEntryResponse{
+		GradeResponse: NewGradeResponse(entry.Grade),
+		DaysLate:      entry.Lateness.DaysLate,
+		PastCutoff:    entry.Lateness.PastCutoff,
+	}
+	response.LatePenaltyPercent = entry.Lateness.PenaltyPercent
+	response.FinalScore = entry.FinalScore()
+	if !entry.Lateness.EffectiveDueAt.IsZero() {
+		response.EffectiveDueAt = utils.InLocationPtr(&entry.Lateness.EffectiveDueAt, loc)
+	}
+	return response
+}
+
+// StudentGradeResponse leaves out the moderation details, students only see released grades
+type StudentGradeResponse struct {
+	ClassID            string    `json:"class_id"`
+	AssignmentID       string    `json:"assignment_id"`
+	Score              float64   `json:"score"`
+	LatePenaltyPercent float64   `json:"late_penalty_percent"`
+	FinalScore         float64   `json:"final_score" doc:"Score after the late penalty"`
+	Dropped            bool      `json:"dropped" doc:"Left out of the average of the student"`
+	ReleasedAt         time.Time `json:"released_at"`
+}
+
+func NewStudentGradeResponse(entry entities.GradebookEntry) StudentGradeResponse {
+	response := StudentGradeResponse{
+		ClassID:            entry.Grade.ClassID,
+		AssignmentID:       entry.Grade.AssignmentID,
+		Score:              entry.Grade.Score,
+		LatePenaltyPercent: entry.Lateness.PenaltyPercent,
+		FinalScore:         entry.FinalScore(),
+		Dropped:            entry.Grade.Dropped,
+	}
+	if entry.Grade.ReleasedAt != nil {
+		response.ReleasedAt = *entry.Grade.ReleasedAt
+	}
+	return response
+}
+
+type GradeEnvelope struct {
+	Body GradeResponse
+}
+
+type GradeListResponse struct {
+	Body struct {
+		Items []GradeResponse `json:"items"`
+	}
+}
+
+func NewGradeListResponse(grades []*entities.Grade) *GradeListResponse {
+	response := &GradeListResponse{}
+	response.Body.Items = make([]GradeResponse, 0, len(grades))
+	for _, grade := range grades {
+		response.Body.Items = append(response.Body.Items, NewGradeResponse(grade))
+	}
+	return response
+}
+
+type GradebookResponse struct {
+	Body struct {
+		Items []GradebookEntryResponse `json:"items"`
+	}
+}
+
+func NewGradebookResponse(entries []entities.GradebookEntry, loc *time.Location) *GradebookResponse {
+	response := &GradebookResponse{}
+	response.Body.Items = make([]GradebookEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		response.Body.Items = append(response.Body.Items, NewGradebookEntryResponse(entry, loc))
+	}
+	return response
+}
+
+type StudentGradeListResponse struct {
+	Body struct {
+		Items []StudentGradeResponse `json:"items"`
+	}
+}
+
+type RecordGradeRequest struct {
+	ClassID      string `path:"classId" format:"uuid"`
+	AssignmentID string `path:"assignmentId" format:"uuid"`
+	StudentID    string `path:"studentId"`
+	Body         struct {
+		Score      float64    `json:"score" minimum:"0" maximum:"100"`
+		TurnedInAt *time.Time `json:"turned_in_at,omitempty" doc:"When the student turned the work in, late penalties are computed from it"`
+		Reason     string     `json:"reason,omitempty" maxLength:"1000" doc:"Why the score changed, kept in the history of the grade"`
+	}
+}
+
+type ListClassGradesRequest struct {
+	ClassID      string `path:"classId" format:"uuid"`
+	AssignmentID string `query:"assignment_id" format:"uuid" doc:"Only grades of this assignment"`
+}
+
+type ListMyGradesRequest struct {
+	ClassID string `query:"class_id" format:"uuid" doc:"Only grades of this class"`
+}
+
+type ChangeGradeStatusRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	Body    struct {
+		GradeIDs []string `json:"grade_ids" minItems:"1" maxItems:"500" uniqueItems:"true"`
+	}
+}
+
+type ReturnGradesRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	Body    struct {
+		GradeIDs []string `json:"grade_ids" minItems:"1" maxItems:"500" uniqueItems:"true"`
+		Reason   string   `json:"reason" minLength:"1" maxLength:"1000" doc:"Shown to the instructor who recorded the grades"`
+	}
+}
+
+type SetGradingPolicyRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	Body    GradingPolicyBody
+}
+
+type GradingPolicyBody struct {
+	RequiresApproval bool `json:"requires_approval" doc:"Submitted grades need department head approval before release"`
+}
+
+type GradingPolicyResponse struct {
+	Body GradingPolicyBody
+}
+
+type SetAssignmentDeadlineRequest struct {
+	ClassID      string `path:"classId" format:"uuid"`
+	AssignmentID string `path:"assignmentId" format:"uuid"`
+	Body         AssignmentDeadlineBody
+}
+
+type AssignmentDeadlineBody struct {
+	DueAt                time.Time `json:"due_at"`
+	PenaltyPercentPerDay float64   `json:"penalty_percent_per_day" minimum:"0" maximum:"100" doc:"Deducted for every started day past the due date"`
+	CutoffDays           int       `json:"cutoff_days" minimum:"0" maximum:"365" doc:"Work turned in more days late than this scores zero, 0 disables the cutoff"`
+}
+
+type AssignmentDeadlineResponse struct {
+	Body AssignmentDeadlineBody
+}
+
+type GrantExtensionRequest struct {
+	ClassID      string `path:"classId" format:"uuid"`
+	AssignmentID string `path:"assignmentId" format:"uuid"`
+	StudentID    string `path:"studentId"`
+	Body         struct {
+		DueAt  time.Time `json:"due_at" doc:"Must be later than the assignment due date"`
+		Reason string    `json:"reason,omitempty" maxLength:"1000"`
+	}
+}
+
+type ExtensionResponse struct {
+	Body struct {
+		AssignmentID string    `json:"assignment_id"`
+		StudentID    string    `json:"student_id"`
+		DueAt        time.Time `json:"due_at"`
+		Reason       string    `json:"reason,omitempty"`
+		GrantedBy    string    `json:"granted_by"`
+		GrantedAt    time.Time `json:"granted_at"`
+	}
+}
+
+type GetGradeHistoryRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	GradeID string `path:"gradeId" format:"uuid"`
+}
+
+type GradeEventResponse struct {
+	Version            int        `json:"version"`
+	Type               string     `json:"type" enum:"recorded,score_changed,submitted,approved,returned,released,imported,curved,regraded,dropped,adjustment_undone"`
+	ActorID            string     `json:"actor_id"`
+	Reason             string     `json:"reason,omitempty"`
+	OldScore           *float64   `json:"old_score,omitempty"`
+	NewScore           float64    `json:"new_score"`
+	OldStatus          string     `json:"old_status,omitempty"`
+	NewStatus          string     `json:"new_status"`
+	TurnedInAt         *time.Time `json:"turned_in_at,omitempty"`
+	LatePenaltyPercent float64    `json:"late_penalty_percent"`
+	Dropped            bool       `json:"dropped"`
+	AdjustmentID       string     `json:"adjustment_id,omitempty" doc:"Bulk adjustment the change is part of or undoes"`
+	OccurredAt         time.Time  `json:"occurred_at"`
+}
+
+type GradeHistoryResponse struct {
+	Body struct {
+		GradeID      string               `json:"grade_id"`
+		ClassID      string               `json:"class_id"`
+		AssignmentID string               `json:"assignment_id"`
+		StudentID    string               `json:"student_id"`
+		Items        []GradeEventResponse `json:"items"`
+	}
+}
+
+// NewGradeHistoryResponse shows the times in the zone of the reader
+func NewGradeHistoryResponse(events []*entities.GradeEvent, loc *time.Location) *GradeHistoryResponse {
+	response := &GradeHistoryResponse{}
+	response.Body.Items = make([]GradeEventResponse, 0, len(events))
+	for _, event := range events {
+		response.Body.GradeID = event.GradeID
+		response.Body.ClassID = event.ClassID
+		response.Body.AssignmentID = event.AssignmentID
+		response.Body.StudentID = event.StudentID
+		response.Body.Items = append(response.Body.Items, GradeEventResponse{
+			Version:            event.Version,
+			Type:               string(event.Type),
+			ActorID:            event.ActorID,
+			Reason:             event.Reason,
+			OldScore:           event.OldScore,
+			NewScore:           event.NewScore,
+			OldStatus:          string(event.OldStatus),
+			NewStatus:          string(event.NewStatus),
+			TurnedInAt:         utils.InLocationPtr(event.TurnedInAt, loc),
+			LatePenaltyPercent: event.LatePenaltyPercent,
+			Dropped:            event.Dropped,
+			AdjustmentID:       event.AdjustmentID,
+			OccurredAt:         utils.InLocation(event.OccurredAt, loc),
+		})
+	}
+	return response
+}
+
+// GradeAdjustmentBody takes the parameters of every kind of adjustment, the ones the kind does not use
+// are ignored
+type GradeAdjustmentBody struct {
+	Kind         string   `json:"kind" enum:"curve_points,curve_percent,drop_lowest,regrade_question"`
+	AssignmentID string   `json:"assignment_id,omitempty" format:"uuid" doc:"Assignment whose grades are adjusted, drop_lowest spans every assignment and takes none"`
+	Points       float64  `json:"points,omitempty" minimum:"0" maximum:"100" doc:"Points curve_points adds to every grade, or the points of the question regrade_question gives back"`
+	Percent      float64  `json:"percent,omitempty" minimum:"0" maximum:"100" doc:"Percent of their score curve_percent raises every grade by"`
+	Count        int      `json:"count,omitempty" minimum:"0" maximum:"10" doc:"Lowest grades of every student drop_lowest drops, every student keeps one"`
+	StudentIDs   []string `json:"student_ids,omitempty" maxItems:"500" uniqueItems:"true" doc:"Students who lost the points of the regraded question, every student when empty"`
+}
+
+type PreviewGradeAdjustmentRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	Body    GradeAdjustmentBody
+}
+
+type ApplyGradeAdjustmentRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	Body    struct {
+		GradeAdjustmentBody
+		Reason string `json:"reason,omitempty" maxLength:"1000" doc:"Kept in the history of every adjusted grade"`
+	}
+}
+
+type UndoGradeAdjustmentRequest struct {
+	ClassID      string `path:"classId" format:"uuid"`
+	AdjustmentID string `path:"adjustmentId" format:"uuid"`
+}
+
+type GradeChangeResponse struct {
+	GradeID      string  `json:"grade_id"`
+	AssignmentID string  `json:"assignment_id"`
+	StudentID    string  `json:"student_id"`
+	Status       string  `json:"status" enum:"draft,submitted,approved,released"`
+	OldScore     float64 `json:"old_score"`
+	NewScore     float64 `json:"new_score"`
+	Dropped      bool    `json:"dropped"`
+	Skipped      bool    `json:"skipped" doc:"Past draft, the grade is left as it is"`
+}
+
+type GradeAdjustmentResponse struct {
+	Body struct {
+		AdjustmentID string                `json:"adjustment_id,omitempty" doc:"Undoes the adjustment, absent in previews and when nothing changed"`
+		Kind         string                `json:"kind"`
+		Items        []GradeChangeResponse `json:"items"`
+	}
+}
+
+func NewGradeAdjustmentResponse(adjustment *entities.GradeAdjustment) *GradeAdjustmentResponse {
+	response := &GradeAdjustmentResponse{}
+	response.Body.Kind = string(adjustment.Kind)
+	response.Body.Items = make([]GradeChangeResponse, 0, len(adjustment.Changes))
+	for _, change := range adjustment.Changes {
+		if !change.Skipped {
+			response.Body.AdjustmentID = adjustment.ID
+		}
+		response.Body.Items = append(response.Body.Items, GradeChangeResponse{
+			GradeID:      change.Grade.ID,
+			AssignmentID: change.Grade.AssignmentID,
+			StudentID:    change.Grade.StudentID,
+			Status:       string(change.Grade.Status),
+			OldScore:     change.Grade.Score,
+			NewScore:     change.Score,
+			Dropped:      change.Dropped,
+			Skipped:      change.Skipped,
+		})
+	}
+	return response
+}
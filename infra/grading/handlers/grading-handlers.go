@@ -0,0 +1,416 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	apply_grade_adjustment_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/apply-grade-adjustment-use-case"
+	change_grade_status_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/change-grade-status-use-case"
+	get_grade_history_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/get-grade-history-use-case"
+	grant_extension_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/grant-extension-use-case"
+	list_class_grades_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/list-class-grades-use-case"
+	list_student_grades_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/list-student-grades-use-case"
+	preview_grade_adjustment_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/preview-grade-adjustment-use-case"
+	record_grade_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/record-grade-use-case"
+	set_assignment_deadline_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/set-assignment-deadline-use-case"
+	set_grading_policy_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/set-grading-policy-use-case"
+	undo_grade_adjustment_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/undo-grade-adjustment-use-case"
+	"github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type GradingHandlers struct {
+	recordGradeUseCase       *record_grade_use_case.RecordGradeUseCase
+	changeGradeStatusUseCase *change_grade_status_use_case.ChangeGradeStatusUseCase
+	listClassGradesUseCase   *list_class_grades_use_case.ListClassGradesUseCase
+	listStudentGradesUseCase *list_student_grades_use_case.ListStudentGradesUseCase
+	setGradingPolicyUseCase  *set_grading_policy_use_case.SetGradingPolicyUseCase
+	setDeadlineUseCase       *set_assignment_deadline_use_case.SetAssignmentDeadlineUseCase
+	grantExtensionUseCase    *grant_extension_use_case.GrantExtensionUseCase
+	getGradeHistoryUseCase   *get_grade_history_use_case.GetGradeHistoryUseCase
+	previewAdjustmentUseCase *preview_grade_adjustment_use_case.PreviewGradeAdjustmentUseCase
+	applyAdjustmentUseCase   *apply_grade_adjustment_use_case.ApplyGradeAdjustmentUseCase
+	undoAdjustmentUseCase    *undo_grade_adjustment_use_case.UndoGradeAdjustmentUseCase
+}
+
+func NewGradingHandlers(
+	recordGradeUseCase *record_grade_use_case.RecordGradeUseCase,
+	changeGradeStatusUseCase *change_grade_status_use_case.ChangeGradeStatusUseCase,
+	listClassGradesUseCase *list_class_grades_use_case.ListClassGradesUseCase,
+	listStudentGradesUseCase *list_student_grades_use_case.ListStudentGradesUseCase,
+	setGradingPolicyUseCase *set_grading_policy_use_case.SetGradingPolicyUseCase,
+	setDeadlineUseCase *set_assignment_deadline_use_case.SetAssignmentDeadlineUseCase,
+	grantExtensionUseCase *grant_extension_use_case.GrantExtensionUseCase,
+	getGradeHistoryUseCase *get_grade_history_use_case.GetGradeHistoryUseCase,
+	previewAdjustmentUseCase *preview_grade_adjustment_use_case.PreviewGradeAdjustmentUseCase,
+	applyAdjustmentUseCase *apply_grade_adjustment_use_case.ApplyGradeAdjustmentUseCase,
+	undoAdjustmentUseCase *undo_grade_adjustment_use_case.UndoGradeAdjustmentUseCase,
+) *GradingHandlers {
+	return &GradingHandlers{
+		recordGradeUseCase:       recordGradeUseCase,
+		changeGradeStatusUseCase: changeGradeStatusUseCase,
+		listClassGradesUseCase:   listClassGradesUseCase,
+		listStudentGradesUseCase: listStudentGradesUseCase,
+		setGradingPolicyUseCase:  setGradingPolicyUseCase,
+		setDeadlineUseCase:       setDeadlineUseCase,
+		grantExtensionUseCase:    grantExtensionUseCase,
+		getGradeHistoryUseCase:   getGradeHistoryUseCase,
+		previewAdjustmentUseCase: previewAdjustmentUseCase,
+		applyAdjustmentUseCase:   applyAdjustmentUseCase,
+		undoAdjustmentUseCase:    undoAdjustmentUseCase,
+	}
+}
+
+func (h *GradingHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "record-grade",
+		Method:      http.MethodPut,
+		Path:        "/classes/{classId}/assignments/{assignmentId}/grades/{studentId}",
+		Summary:     "Record a draft grade",
+		Description: "Creates the grade as a draft or changes its score while it is still a draft.",
+		Tags:        []string{"Grading"},
+	}, h.RecordGrade)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-class-grades",
+		Method:      http.MethodGet,
+		Path:        "/classes/{classId}/grades",
+		Summary:     "List the grades of a class in every status",
+		Description: "Each grade shows the late penalty, frozen for released grades and previewed for the rest.",
+		Tags:        []string{"Grading"},
+	}, h.ListClassGrades)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-my-grades",
+		Method:      http.MethodGet,
+		Path:        "/grades/me",
+		Summary:     "List the released grades of the current student",
+		Tags:        []string{"Grading"},
+	}, h.ListMyGrades)
+
+	h.registerStatusChange(api, "submit-grades", "submit", "Submit draft grades for release", entities.GradeActionSubmit)
+	h.registerStatusChange(api, "approve-grades", "approve", "Approve submitted grades", entities.GradeActionApprove)
+	h.registerStatusChange(api, "release-grades", "release", "Release grades to students", entities.GradeActionRelease)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "return-grades",
+		Method:      http.MethodPost,
+		Path:        "/classes/{classId}/grades/return",
+		Summary:     "Send submitted or approved grades back to draft",
+		Tags:        []string{"Grading"},
+	}, h.ReturnGrades)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-grading-policy",
+		Method:      http.MethodPut,
+		Path:        "/classes/{classId}/grading-policy",
+		Summary:     "Configure grade moderation of a class",
+		Tags:        []string{"Grading"},
+	}, h.SetGradingPolicy)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-assignment-deadline",
+		Method:      http.MethodPut,
+		Path:        "/classes/{classId}/assignments/{assignmentId}/deadline",
+		Summary:     "Set the due date and late policy of an assignment",
+		Tags:        []string{"Grading"},
+	}, h.SetAssignmentDeadline)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "grant-extension",
+		Method:      http.MethodPut,
+		Path:        "/classes/{classId}/assignments/{assignmentId}/extensions/{studentId}",
+		Summary:     "Give a student a later due date",
+		Description: "Replaces any previous extension of the student for the assignment.",
+		Tags:        []string{"Grading"},
+	}, h.GrantExtension)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-grade-history",
+		Method:      http.MethodGet,
+		Path:        "/classes/{classId}/grades/{gradeId}/history",
+		Summary:     "List every change of a grade",
+		Description: "Who changed the grade, when, the score and status before and after, and why. Grades recorded before changes were kept start with an imported entry.",
+		Tags:        []string{"Grading"},
+	}, h.GetGradeHistory)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "preview-grade-adjustment",
+		Method:      http.MethodPost,
+		Path:        "/classes/{classId}/grade-adjustments/preview",
+		Summary:     "Preview a curve, drop or regrade of the grades of a class",
+		Description: "Lists every grade the adjustment changes with its score before and after, nothing is changed.",
+		Tags:        []string{"Grading"},
+	}, h.PreviewGradeAdjustment)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "apply-grade-adjustment",
+		Method:      http.MethodPost,
+		Path:        "/classes/{classId}/grade-adjustments",
+		Summary:     "Curve, drop or regrade the grades of a class",
+		Description: "Changes the draft grades the preview lists, grades past draft are skipped. Fails when a grade changed since the preview.",
+		Tags:        []string{"Grading"},
+	}, h.ApplyGradeAdjustment)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "undo-grade-adjustment",
+		Method:      http.MethodPost,
+		Path:        "/classes/{classId}/grade-adjustments/{adjustmentId}/undo",
+		Summary:     "Undo a grade adjustment",
+		Description: "Puts every grade back as it was before the adjustment, or none when some changed since. The history of the grades keeps both.",
+		Tags:        []string{"Grading"},
+	}, h.UndoGradeAdjustment)
+}
+
+// registerStatusChange registers one bulk endpoint per action so each maps to its own permission
+func (h *GradingHandlers) registerStatusChange(api huma.API, operationID string, path string, summary string, action entities.GradeAction) {
+	huma.Register(api, huma.Operation{
+		OperationID: operationID,
+		Method:      http.MethodPost,
+		Path:        "/classes/{classId}/grades/" + path,
+		Summary:     summary,
+		Description: "All grades change or none do, the error lists every grade whose status does not allow the action.",
+		Tags:        []string{"Grading"},
+	}, func(ctx context.Context, input *ChangeGradeStatusRequest) (*GradeListResponse, error) {
+		return h.changeStatus(ctx, input.ClassID, action, input.Body.GradeIDs, "")
+	})
+}
+
+func (h *GradingHandlers) RecordGrade(ctx context.Context, input *RecordGradeRequest) (*GradeEnvelope, error) {
+	command, err := record_grade_use_case.NewRecordGradeCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		input.AssignmentID,
+		input.StudentID,
+		input.Body.Score,
+		input.Body.TurnedInAt,
+		authorization.UserIDFromContext(ctx),
+		input.Body.Reason,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	grade, err := h.recordGradeUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &GradeEnvelope{Body: NewGradeResponse(grade)}, nil
+}
+
+func (h *GradingHandlers) ListClassGrades(ctx context.Context, input *ListClassGradesRequest) (*GradebookResponse, error) {
+	command, err := list_class_grades_use_case.NewListClassGradesCommand(authorization.TenantIDFromContext(ctx), input.ClassID, input.AssignmentID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	entries, err := h.listClassGradesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewGradebookResponse(entries, utils.LocationFromContext(ctx)), nil
+}
+
+func (h *GradingHandlers) ListMyGrades(ctx context.Context, input *ListMyGradesRequest) (*StudentGradeListResponse, error) {
+	command, err := list_student_grades_use_case.NewListStudentGradesCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.ClassID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	grades, err := h.listStudentGradesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &StudentGradeListResponse{}
+	response.Body.Items = make([]StudentGradeResponse, 0, len(grades))
+	for _, grade := range grades {
+		response.Body.Items = append(response.Body.Items, NewStudentGradeResponse(grade))
+	}
+
+	return response, nil
+}
+
+func (h *GradingHandlers) ReturnGrades(ctx context.Context, input *ReturnGradesRequest) (*GradeListResponse, error) {
+	return h.changeStatus(ctx, input.ClassID, entities.GradeActionReturn, input.Body.GradeIDs, input.Body.Reason)
+}
+
+func (h *GradingHandlers) changeStatus(ctx context.Context, classID string, action entities.GradeAction, gradeIDs []string, reason string) (*GradeListResponse, error) {
+	command, err := change_grade_status_use_case.NewChangeGradeStatusCommand(
+		authorization.TenantIDFromContext(ctx),
+		classID,
+		action,
+		gradeIDs,
+		authorization.UserIDFromContext(ctx),
+		reason,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	grades, err := h.changeGradeStatusUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewGradeListResponse(grades), nil
+}
+
+func (h *GradingHandlers) SetGradingPolicy(ctx context.Context, input *SetGradingPolicyRequest) (*GradingPolicyResponse, error) {
+	command, err := set_grading_policy_use_case.NewSetGradingPolicyCommand(authorization.TenantIDFromContext(ctx), input.ClassID, input.Body.RequiresApproval)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	policy, err := h.setGradingPolicyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &GradingPolicyResponse{Body: GradingPolicyBody{RequiresApproval: policy.RequiresApproval}}, nil
+}
+
+func (h *GradingHandlers) SetAssignmentDeadline(ctx context.Context, input *SetAssignmentDeadlineRequest) (*AssignmentDeadlineResponse, error) {
+	command, err := set_assignment_deadline_use_case.NewSetAssignmentDeadlineCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		input.AssignmentID,
+		input.Body.DueAt,
+		input.Body.PenaltyPercentPerDay,
+		input.Body.CutoffDays,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	deadline, err := h.setDeadlineUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &AssignmentDeadlineResponse{Body: AssignmentDeadlineBody{
+		DueAt:                utils.InLocation(deadline.DueAt, utils.LocationFromContext(ctx)),
+		PenaltyPercentPerDay: deadline.Policy.PenaltyPercentPerDay,
+		CutoffDays:           deadline.Policy.CutoffDays,
+	}}, nil
+}
+
+func (h *GradingHandlers) GrantExtension(ctx context.Context, input *GrantExtensionRequest) (*ExtensionResponse, error) {
+	command, err := grant_extension_use_case.NewGrantExtensionCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		input.AssignmentID,
+		input.StudentID,
+		input.Body.DueAt,
+		input.Body.Reason,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	extension, err := h.grantExtensionUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ExtensionResponse{}
+	response.Body.AssignmentID = extension.AssignmentID
+	response.Body.StudentID = extension.StudentID
+	response.Body.DueAt = utils.InLocation(extension.DueAt, utils.LocationFromContext(ctx))
+	response.Body.Reason = extension.Reason
+	response.Body.GrantedBy = extension.GrantedBy
+	response.Body.GrantedAt = extension.GrantedAt
+
+	return response, nil
+}
+
+func (h *GradingHandlers) GetGradeHistory(ctx context.Context, input *GetGradeHistoryRequest) (*GradeHistoryResponse, error) {
+	command, err := get_grade_history_use_case.NewGetGradeHistoryCommand(authorization.TenantIDFromContext(ctx), input.ClassID, input.GradeID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	events, err := h.getGradeHistoryUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewGradeHistoryResponse(events, utils.LocationFromContext(ctx)), nil
+}
+
+func (h *GradingHandlers) PreviewGradeAdjustment(ctx context.Context, input *PreviewGradeAdjustmentRequest) (*GradeAdjustmentResponse, error) {
+	command, err := preview_grade_adjustment_use_case.NewPreviewGradeAdjustmentCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		input.Body.AssignmentID,
+		entities.AdjustmentKind(input.Body.Kind),
+		input.Body.Points,
+		input.Body.Percent,
+		input.Body.Count,
+		input.Body.StudentIDs,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	changes, err := h.previewAdjustmentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewGradeAdjustmentResponse(&entities.GradeAdjustment{Kind: command.Kind, Changes: changes}), nil
+}
+
+func (h *GradingHandlers) ApplyGradeAdjustment(ctx context.Context, input *ApplyGradeAdjustmentRequest) (*GradeAdjustmentResponse, error) {
+	command, err := apply_grade_adjustment_use_case.NewApplyGradeAdjustmentCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		input.Body.AssignmentID,
+		entities.AdjustmentKind(input.Body.Kind),
+		input.Body.Points,
+		input.Body.Percent,
+		input.Body.Count,
+		input.Body.StudentIDs,
+		authorization.UserIDFromContext(ctx),
+		input.Body.Reason,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	adjustment, err := h.applyAdjustmentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewGradeAdjustmentResponse(adjustment), nil
+}
+
+func (h *GradingHandlers) UndoGradeAdjustment(ctx context.Context, input *UndoGradeAdjustmentRequest) (*GradeListResponse, error) {
+	command, err := undo_grade_adjustment_use_case.NewUndoGradeAdjustmentCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		input.AdjustmentID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	grades, err := h.undoAdjustmentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewGradeListResponse(grades), nil
+}
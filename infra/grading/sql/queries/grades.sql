@@ -0,0 +1,127 @@
+-- name: UpsertGrade :exec
+INSERT INTO grades (id, tenant_id, class_id, assignment_id, student_id, score, status, recorded_by, turned_in_at, created_at, updated_at, version)
+VALUES (@id, @tenant_id, @class_id, @assignment_id, @student_id, @score, @status, @recorded_by, @turned_in_at, @created_at, @updated_at, @version)
+ON CONFLICT (id) DO UPDATE
+SET score = EXCLUDED.score,
+    recorded_by = EXCLUDED.recorded_by,
+    turned_in_at = EXCLUDED.turned_in_at,
+    updated_at = EXCLUDED.updated_at,
+    version = EXCLUDED.version;
+
+-- name: GetGradeByStudent :one
+SELECT *
+FROM grades
+WHERE tenant_id = @tenant_id AND class_id = @class_id AND assignment_id = @assignment_id AND student_id = @student_id;
+
+-- name: ListGradesByIDs :many
+SELECT *
+FROM grades
+WHERE tenant_id = @tenant_id AND class_id = @class_id AND id = ANY(@grade_ids::uuid[])
+ORDER BY id
+FOR UPDATE;  -- locked in id order so concurrent bulk actions cannot deadlock
+
+-- name: UpdateLockedGrade :exec
+UPDATE grades
+SET score = @score,
+    recorded_by = @recorded_by,
+    dropped = @dropped,
+    status = @status,
+    reviewed_by = @reviewed_by,
+    return_reason = @return_reason,
+    late_penalty_percent = @late_penalty_percent,
+    submitted_at = @submitted_at,
+    released_at = @released_at,
+    updated_at = @updated_at,
+    version = @version
+WHERE id = @id;
+
+-- name: AppendGradeEvent :execrows
+-- Appends nothing when the version is taken, the grade was changed since it was read
+INSERT INTO grade_events (
+    tenant_id, grade_id, version, class_id, assignment_id, student_id, event_type, actor_id, reason,
+    old_score, new_score, old_status, new_status, turned_in_at, late_penalty_percent, occurred_at, dropped, adjustment_id
+)
+VALUES (
+    @tenant_id, @grade_id, @version, @class_id, @assignment_id, @student_id, @event_type, @actor_id, @reason,
+    @old_score, @new_score, @old_status, @new_status, @turned_in_at, @late_penalty_percent, @occurred_at, @dropped, @adjustment_id
+)
+ON CONFLICT (tenant_id, grade_id, version) DO NOTHING;
+
+-- The whole stream of a grade that was ever in the class, grades moved between sections keep the
+-- events recorded in the section they came from
+-- name: ListGradeEvents :many
+SELECT e.*
+FROM grade_events e
+WHERE e.tenant_id = @tenant_id AND e.grade_id = @grade_id
+  AND EXISTS (
+      SELECT 1 FROM grade_events c
+      WHERE c.tenant_id = @tenant_id AND c.grade_id = @grade_id AND c.class_id = @class_id
+  )
+ORDER BY e.version;
+
+-- name: ListAdjustmentEvents :many
+SELECT *
+FROM grade_events
+WHERE tenant_id = @tenant_id AND class_id = @class_id AND adjustment_id = @adjustment_id
+ORDER BY grade_id, version;
+
+-- name: ListClassGrades :many
+SELECT *
+FROM grades
+WHERE tenant_id = @tenant_id AND class_id = @class_id
+  AND (sqlc.narg('assignment_id')::uuid IS NULL OR assignment_id = sqlc.narg('assignment_id'))
+ORDER BY assignment_id, student_id;
+
+-- name: ListReleasedGradesForStudent :many
+SELECT *
+FROM grades
+WHERE tenant_id = @tenant_id AND student_id = @student_id AND status = 'released'
+  AND (sqlc.narg('class_id')::uuid IS NULL OR class_id = sqlc.narg('class_id'))
+ORDER BY class_id, released_at;
+
+-- name: GetGradingPolicy :one
+SELECT requires_approval
+FROM class_grading_policies
+WHERE tenant_id = @tenant_id AND class_id = @class_id;
+
+-- name: UpsertGradingPolicy :exec
+INSERT INTO class_grading_policies (tenant_id, class_id, requires_approval, updated_at)
+VALUES (@tenant_id, @class_id, @requires_approval, NOW())
+ON CONFLICT (tenant_id, class_id) DO UPDATE
+SET requires_approval = EXCLUDED.requires_approval,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: UpsertAssignmentDeadline :exec
+INSERT INTO assignment_deadlines (tenant_id, class_id, assignment_id, due_at, penalty_percent_per_day, cutoff_days, updated_at)
+VALUES (@tenant_id, @class_id, @assignment_id, @due_at, @penalty_percent_per_day, @cutoff_days, NOW())
+ON CONFLICT (tenant_id, class_id, assignment_id) DO UPDATE
+SET due_at = EXCLUDED.due_at,
+    penalty_percent_per_day = EXCLUDED.penalty_percent_per_day,
+    cutoff_days = EXCLUDED.cutoff_days,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetAssignmentDeadline :one
+SELECT *
+FROM assignment_deadlines
+WHERE tenant_id = @tenant_id AND class_id = @class_id AND assignment_id = @assignment_id;
+
+-- name: ListAssignmentDeadlines :many
+SELECT *
+FROM assignment_deadlines
+WHERE tenant_id = @tenant_id AND class_id = @class_id
+ORDER BY assignment_id;
+
+-- name: UpsertAssignmentExtension :exec
+INSERT INTO assignment_extensions (tenant_id, class_id, assignment_id, student_id, due_at, granted_by, reason, granted_at)
+VALUES (@tenant_id, @class_id, @assignment_id, @student_id, @due_at, @granted_by, @reason, @granted_at)
+ON CONFLICT (tenant_id, class_id, assignment_id, student_id) DO UPDATE
+SET due_at = EXCLUDED.due_at,
+    granted_by = EXCLUDED.granted_by,
+    reason = EXCLUDED.reason,
+    granted_at = EXCLUDED.granted_at;
+
+-- name: ListAssignmentExtensions :many
+SELECT *
+FROM assignment_extensions
+WHERE tenant_id = @tenant_id AND class_id = @class_id
+ORDER BY assignment_id, student_id;
@@ -0,0 +1,86 @@
+-- Grades of students per assignment, moving draft -> submitted -> (approved ->) released.
+-- Students only see released grades
+CREATE TABLE grades (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    assignment_id UUID NOT NULL,
+    student_id VARCHAR(255) NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    status VARCHAR(20) NOT NULL,           -- draft, submitted, approved, released
+    recorded_by VARCHAR(255) NOT NULL,
+    reviewed_by VARCHAR(255),
+    return_reason TEXT,
+    turned_in_at TIMESTAMP WITH TIME ZONE,
+    late_penalty_percent DOUBLE PRECISION NOT NULL DEFAULT 0, -- frozen on release
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    released_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version INTEGER NOT NULL DEFAULT 0,    -- last event of the grade in grade_events
+    dropped BOOLEAN NOT NULL DEFAULT false, -- left out of the average of the student
+    UNIQUE (tenant_id, class_id, assignment_id, student_id)
+);
+
+CREATE INDEX idx_grades_student ON grades(tenant_id, student_id, status);
+
+-- Append-only history of every grade, grades holds the current value and is written in the same
+-- transaction. Rows are never updated or deleted, they stay when the grade is archived.
+CREATE TABLE grade_events (
+    tenant_id VARCHAR(255) NOT NULL,
+    grade_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    class_id UUID NOT NULL,                -- class of the grade after the event, moved events change it
+    assignment_id UUID NOT NULL,
+    student_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(20) NOT NULL,       -- recorded, score_changed, submitted, approved, returned, released, imported,
+                                           -- curved, regraded, dropped, adjustment_undone, moved
+    actor_id VARCHAR(255) NOT NULL,
+    reason TEXT,
+    old_score DOUBLE PRECISION,
+    new_score DOUBLE PRECISION NOT NULL,
+    old_status VARCHAR(20),
+    new_status VARCHAR(20) NOT NULL,
+    turned_in_at TIMESTAMP WITH TIME ZONE,
+    late_penalty_percent DOUBLE PRECISION NOT NULL DEFAULT 0,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    dropped BOOLEAN NOT NULL DEFAULT false,
+    adjustment_id UUID,                    -- bulk adjustment the event is part of or undoes
+    PRIMARY KEY (tenant_id, grade_id, version)
+);
+
+CREATE INDEX idx_grade_events_adjustment ON grade_events(tenant_id, adjustment_id) WHERE adjustment_id IS NOT NULL;
+
+-- Moderation settings per class, classes without a row release grades without approval
+CREATE TABLE class_grading_policies (
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    requires_approval BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, class_id)
+);
+
+-- Due date and late policy per assignment, assignments without a row are never late
+CREATE TABLE assignment_deadlines (
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    assignment_id UUID NOT NULL,
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    penalty_percent_per_day DOUBLE PRECISION NOT NULL,
+    cutoff_days INTEGER NOT NULL,          -- 0 means no cutoff
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, class_id, assignment_id)
+);
+
+-- Per student due dates that replace the assignment due date
+CREATE TABLE assignment_extensions (
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    assignment_id UUID NOT NULL,
+    student_id VARCHAR(255) NOT NULL,
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    granted_by VARCHAR(255) NOT NULL,
+    reason TEXT,
+    granted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, class_id, assignment_id, student_id)
+);
@@ -0,0 +1,22 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/ports"
+)
+
+// IncidentNotifiers notifies through every notifier, one failing does not stop the others
+type IncidentNotifiers []ports.IncidentNotifier
+
+func (n IncidentNotifiers) NotifyJobFailure(ctx context.Context, failure *entities.JobFailure) error {
+	var errs []error
+	for _, notifier := range n {
+		if err := notifier.NotifyJobFailure(ctx, failure); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,178 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+type PostgresJobFailureRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresJobFailureRepository(dbInstance *pgxpool.Pool) ports.JobFailureRepository {
+	return &PostgresJobFailureRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresJobFailureRepository) Find(ctx context.Context, tenantID string, job string, subject string) (*entities.JobFailure, error) {
+	row, err := r.queries.GetJobFailure(ctx, db.GetJobFailureParams{TenantID: tenantID, Job: job, Subject: subject})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toJobFailure(row)
+}
+
+func (r *PostgresJobFailureRepository) FindByID(ctx context.Context, tenantID string, failureID string) (*entities.JobFailure, error) {
+	id, err := pgUUID(failureID)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.GetJobFailureByID(ctx, db.GetJobFailureByIDParams{TenantID: tenantID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toJobFailure(row)
+}
+
+func (r *PostgresJobFailureRepository) List(ctx context.Context, tenantID string, status entities.JobFailureStatus) ([]*entities.JobFailure, error) {
+	params := db.ListJobFailuresParams{TenantID: tenantID}
+	if status != "" {
+		value := string(status)
+		params.Status = &value
+	}
+
+	rows, err := r.queries.ListJobFailures(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	failures := make([]*entities.JobFailure, 0, len(rows))
+	for _, row := range rows {
+		failure, err := toJobFailure(row)
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, failure)
+	}
+	return failures, nil
+}
+
+func (r *PostgresJobFailureRepository) Save(ctx context.Context, failure *entities.JobFailure) error {
+	id, err := pgUUID(failure.ID)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(storedPayload(failure.Payload))
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize job failure payload", err)
+	}
+
+	var requeuedBy *string
+	if failure.RequeuedBy != "" {
+		requeuedBy = &failure.RequeuedBy
+	}
+
+	err = r.queries.SaveJobFailure(ctx, db.SaveJobFailureParams{
+		ID:            id,
+		TenantID:      failure.TenantID,
+		Job:           failure.Job,
+		Subject:       failure.Subject,
+		Payload:       payload,
+		Status:        string(failure.Status),
+		Attempts:      int32(failure.Attempts),
+		LastError:     failure.LastError,
+		FirstFailedAt: pgtype.Timestamptz{Time: failure.FirstFailedAt, Valid: true},
+		LastFailedAt:  pgtype.Timestamptz{Time: failure.LastFailedAt, Valid: true},
+		EscalatedAt:   timestamptz(failure.EscalatedAt),
+		RequeuedAt:    timestamptz(failure.RequeuedAt),
+		RequeuedBy:    requeuedBy,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresJobFailureRepository) Delete(ctx context.Context, tenantID string, job string, subject string) error {
+	err := r.queries.DeleteJobFailure(ctx, db.DeleteJobFailureParams{TenantID: tenantID, Job: job, Subject: subject})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func toJobFailure(row db.JobFailure) (*entities.JobFailure, error) {
+	payload := map[string]string{}
+	if err := json.Unmarshal(row.Payload, &payload); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to read job failure payload", err)
+	}
+
+	failure := &entities.JobFailure{
+		ID:            row.ID.String(),
+		TenantID:      row.TenantID,
+		Job:           row.Job,
+		Subject:       row.Subject,
+		Payload:       payload,
+		Status:        entities.JobFailureStatus(row.Status),
+		Attempts:      int(row.Attempts),
+		LastError:     row.LastError,
+		FirstFailedAt: row.FirstFailedAt.Time,
+		LastFailedAt:  row.LastFailedAt.Time,
+		EscalatedAt:   timePtr(row.EscalatedAt),
+		RequeuedAt:    timePtr(row.RequeuedAt),
+	}
+	if row.RequeuedBy != nil {
+		failure.RequeuedBy = *row.RequeuedBy
+	}
+	return failure, nil
+}
+
+// storedPayload stores nil payloads as an empty object, the column is not nullable
+func storedPayload(payload map[string]string) map[string]string {
+	if payload == nil {
+		return map[string]string{}
+	}
+	return payload
+}
+
+func pgUUID(id string) (pgtype.UUID, error) {
+	var value pgtype.UUID
+	if err := value.Scan(id); err != nil {
+		return value, appErrors.PropagateError(err)
+	}
+	return value, nil
+}
+
+func timePtr(value pgtype.Timestamptz) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+func timestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
@@ -0,0 +1,55 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxIncidentNotifier emails the admins of the tenant, it records a job.failure_escalated event
+// the notification senders deliver
+type OutboxIncidentNotifier struct {
+	queries *db.Queries
+}
+
+func NewOutboxIncidentNotifier(dbInstance *pgxpool.Pool) ports.IncidentNotifier {
+	return &OutboxIncidentNotifier{
+		queries: db.New(dbInstance),
+	}
+}
+
+func (n *OutboxIncidentNotifier) NotifyJobFailure(ctx context.Context, failure *entities.JobFailure) error {
+	payload, err := json.Marshal(map[string]any{
+		"failure_id":      failure.ID,
+		"job":             failure.Job,
+		"subject":         failure.Subject,
+		"attempts":        failure.Attempts,
+		"last_error":      failure.LastError,
+		"payload_summary": failure.PayloadSummary(),
+		"first_failed_at": failure.FirstFailedAt,
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize job failure event", err)
+	}
+
+	err = n.queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.JobFailureEscalated,
+		AggregateID: failure.ID,
+		TenantID:    failure.TenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+)
+
+// SlackIncidentNotifier posts the failure to a channel through a Slack incoming webhook
+type SlackIncidentNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func NewSlackIncidentNotifier(webhookURL string) ports.IncidentNotifier {
+	return &SlackIncidentNotifier{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 10 * time.Second, Transport: cost.Transport("slack", nil)},
+	}
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func (n *SlackIncidentNotifier) NotifyJobFailure(ctx context.Context, failure *entities.JobFailure) error {
+	text := fmt.Sprintf(":rotating_light: *%s* gave up on `%s` of tenant `%s` after %d failed runs since %s\n"+
+		"> %s\nPayload: `%s`\nRequeue with `POST /job-failures/%s/requeue` once the cause is fixed.",
+		failure.Job, failure.Subject, failure.TenantID, failure.Attempts, failure.FirstFailedAt.Format(time.RFC3339),
+		failure.LastError, failure.PayloadSummary(), failure.ID)
+	return postJSON(ctx, n.client, n.webhookURL, slackMessage{Text: text})
+}
@@ -0,0 +1,82 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+)
+
+// WebhookIncidentNotifier posts the failure as JSON, for incident tools of the operators
+type WebhookIncidentNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookIncidentNotifier(url string) ports.IncidentNotifier {
+	return &WebhookIncidentNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second, Transport: cost.Transport("incident-webhook", nil)},
+	}
+}
+
+type jobFailureIncident struct {
+	Alert          string    `json:"alert"`
+	FailureID      string    `json:"failure_id"`
+	TenantID       string    `json:"tenant_id"`
+	Job            string    `json:"job"`
+	Subject        string    `json:"subject"`
+	Attempts       int       `json:"attempts"`
+	LastError      string    `json:"last_error"`
+	PayloadSummary string    `json:"payload_summary"`
+	FirstFailedAt  time.Time `json:"first_failed_at"`
+	LastFailedAt   time.Time `json:"last_failed_at"`
+}
+
+func (n *WebhookIncidentNotifier) NotifyJobFailure(ctx context.Context, failure *entities.JobFailure) error {
+	return postJSON(ctx, n.client, n.url, jobFailureIncident{
+		Alert:          "job_failure_escalated",
+		FailureID:      failure.ID,
+		TenantID:       failure.TenantID,
+		Job:            failure.Job,
+		Subject:        failure.Subject,
+		Attempts:       failure.Attempts,
+		LastError:      failure.LastError,
+		PayloadSummary: failure.PayloadSummary(),
+		FirstFailedAt:  failure.FirstFailedAt,
+		LastFailedAt:   failure.LastFailedAt,
+	})
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, message any) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize incident notification", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to build incident notification", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to reach incident webhook", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return appErrors.NewInfrastructureError(fmt.Sprintf("incident webhook answered %d: %s", response.StatusCode, message), nil)
+	}
+	return nil
+}
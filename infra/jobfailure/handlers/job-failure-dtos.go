@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/jobfailure/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type ListJobFailuresRequest struct {
+	Status string `query:"status" enum:"failing,escalated" doc:"Failures with the status, every one when omitted"`
+}
+
+type JobFailureRequest struct {
+	FailureID string `path:"failureId" format:"uuid"`
+}
+
+type JobFailureResponse struct {
+	ID             string            `json:"id"`
+	Job            string            `json:"job" doc:"Background job, such as warehouse-exports"`
+	Subject        string            `json:"subject" doc:"Work within the job, such as the dataset exported"`
+	Payload        map[string]string `json:"payload" doc:"What the job gives the work, the requeued run gets the same"`
+	PayloadSummary string            `json:"payload_summary"`
+	Status         string            `json:"status" enum:"failing,escalated" doc:"Escalated work is skipped by its job until it is requeued"`
+	Attempts       int               `json:"attempts" doc:"Failed runs in a row since the first one or the last requeue"`
+	MaxAttempts    int               `json:"max_attempts"`
+	LastError      string            `json:"last_error"`
+	FirstFailedAt  time.Time         `json:"first_failed_at"`
+	LastFailedAt   time.Time         `json:"last_failed_at"`
+	EscalatedAt    *time.Time        `json:"escalated_at,omitempty"`
+	RequeuedAt     *time.Time        `json:"requeued_at,omitempty"`
+	RequeuedBy     string            `json:"requeued_by,omitempty"`
+}
+
+func NewJobFailureResponse(failure *entities.JobFailure, loc *time.Location) JobFailureResponse {
+	response := JobFailureResponse{
+		ID:             failure.ID,
+		Job:            failure.Job,
+		Subject:        failure.Subject,
+		Payload:        failure.Payload,
+		PayloadSummary: failure.PayloadSummary(),
+		Status:         string(failure.Status),
+		Attempts:       failure.Attempts,
+		MaxAttempts:    entities.MaxAttempts,
+		LastError:      failure.LastError,
+		FirstFailedAt:  utils.InLocation(failure.FirstFailedAt, loc),
+		LastFailedAt:   utils.InLocation(failure.LastFailedAt, loc),
+		EscalatedAt:    utils.InLocationPtr(failure.EscalatedAt, loc),
+		RequeuedAt:     utils.InLocationPtr(failure.RequeuedAt, loc),
+		RequeuedBy:     failure.RequeuedBy,
+	}
+	if response.Payload == nil {
+		response.Payload = map[string]string{}
+	}
+	return response
+}
+
+type JobFailureEnvelope struct {
+	Body JobFailureResponse
+}
+
+type ListJobFailuresResponse struct {
+	Body struct {
+		Items []JobFailureResponse `json:"items"`
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	list_job_failures_use_case "github.com/nahualventure/class-backend/core/app/jobfailure/application/use-cases/list-job-failures-use-case"
+	requeue_job_failure_use_case "github.com/nahualventure/class-backend/core/app/jobfailure/application/use-cases/requeue-job-failure-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type JobFailureHandlers struct {
+	listJobFailuresUseCase   *list_job_failures_use_case.ListJobFailuresUseCase
+	requeueJobFailureUseCase *requeue_job_failure_use_case.RequeueJobFailureUseCase
+}
+
+func NewJobFailureHandlers(
+	listJobFailuresUseCase *list_job_failures_use_case.ListJobFailuresUseCase,
+	requeueJobFailureUseCase *requeue_job_failure_use_case.RequeueJobFailureUseCase,
+) *JobFailureHandlers {
+	return &JobFailureHandlers{
+		listJobFailuresUseCase:   listJobFailuresUseCase,
+		requeueJobFailureUseCase: requeueJobFailureUseCase,
+	}
+}
+
+func (h *JobFailureHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-job-failures",
+		Method:      http.MethodGet,
+		Path:        "/job-failures",
+		Summary:     "List the work of background jobs that is failing",
+		Description: "Exports and other jobs of the tenant retry failed work on their next runs. Work failing five runs in a row is escalated: the admins are notified and the job skips it until it is requeued.",
+		Tags:        []string{"Jobs"},
+	}, h.ListJobFailures)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "requeue-job-failure",
+		Method:      http.MethodPost,
+		Path:        "/job-failures/{failureId}/requeue",
+		Summary:     "Requeue escalated work",
+		Description: "The job runs the work again on its next run, with the same payload. The failure is dropped once a run succeeds.",
+		Tags:        []string{"Jobs"},
+	}, h.RequeueJobFailure)
+}
+
+func (h *JobFailureHandlers) ListJobFailures(ctx context.Context, input *ListJobFailuresRequest) (*ListJobFailuresResponse, error) {
+	command, err := list_job_failures_use_case.NewListJobFailuresCommand(authorization.TenantIDFromContext(ctx), input.Status)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	failures, err := h.listJobFailuresUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &ListJobFailuresResponse{}
+	response.Body.Items = make([]JobFailureResponse, 0, len(failures))
+	for _, failure := range failures {
+		response.Body.Items = append(response.Body.Items, NewJobFailureResponse(failure, loc))
+	}
+	return response, nil
+}
+
+func (h *JobFailureHandlers) RequeueJobFailure(ctx context.Context, input *JobFailureRequest) (*JobFailureEnvelope, error) {
+	command, err := requeue_job_failure_use_case.NewRequeueJobFailureCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.FailureID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	failure, err := h.requeueJobFailureUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &JobFailureEnvelope{Body: NewJobFailureResponse(failure, utils.LocationFromContext(ctx))}, nil
+}
@@ -0,0 +1,32 @@
+-- name: SaveJobFailure :exec
+INSERT INTO job_failures (id, tenant_id, job, subject, payload, status, attempts, last_error, first_failed_at,
+                          last_failed_at, escalated_at, requeued_at, requeued_by)
+VALUES (@id, @tenant_id, @job, @subject, @payload, @status, @attempts, @last_error, @first_failed_at,
+        @last_failed_at, @escalated_at, @requeued_at, @requeued_by)
+ON CONFLICT (id) DO UPDATE
+SET payload = EXCLUDED.payload,
+    status = EXCLUDED.status,
+    attempts = EXCLUDED.attempts,
+    last_error = EXCLUDED.last_error,
+    last_failed_at = EXCLUDED.last_failed_at,
+    escalated_at = EXCLUDED.escalated_at,
+    requeued_at = EXCLUDED.requeued_at,
+    requeued_by = EXCLUDED.requeued_by;
+
+-- name: GetJobFailure :one
+SELECT * FROM job_failures
+WHERE tenant_id = @tenant_id AND job = @job AND subject = @subject;
+
+-- name: GetJobFailureByID :one
+SELECT * FROM job_failures
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: ListJobFailures :many
+SELECT * FROM job_failures
+WHERE tenant_id = @tenant_id
+  AND (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status'))
+ORDER BY last_failed_at DESC, id;
+
+-- name: DeleteJobFailure :exec
+DELETE FROM job_failures
+WHERE tenant_id = @tenant_id AND job = @job AND subject = @subject;
@@ -0,0 +1,20 @@
+-- Work of background jobs whose last run failed, such as the export of a dataset of a tenant. The
+-- row is dropped once a run succeeds, escalated work is skipped by its job until it is requeued.
+CREATE TABLE job_failures (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    job VARCHAR(100) NOT NULL,               -- scheduled name of the job, e.g. warehouse-exports
+    subject VARCHAR(255) NOT NULL,           -- unit of work within the job, e.g. the dataset
+    payload JSONB NOT NULL DEFAULT '{}',     -- what the job was given for the work
+    status VARCHAR(20) NOT NULL,             -- failing, escalated
+    attempts INTEGER NOT NULL,               -- failed runs in a row since the first one or the requeue
+    last_error TEXT NOT NULL,
+    first_failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    escalated_at TIMESTAMP WITH TIME ZONE,
+    requeued_at TIMESTAMP WITH TIME ZONE,
+    requeued_by VARCHAR(255),
+    UNIQUE (tenant_id, job, subject)
+);
+
+CREATE INDEX idx_job_failures_tenant ON job_failures(tenant_id, last_failed_at);
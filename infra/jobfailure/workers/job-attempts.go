@@ -0,0 +1,66 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	find_job_failure_use_case "github.com/nahualventure/class-backend/core/app/jobfailure/application/use-cases/find-job-failure-use-case"
+	record_job_attempt_use_case "github.com/nahualventure/class-backend/core/app/jobfailure/application/use-cases/record-job-attempt-use-case"
+)
+
+// ErrEscalated is returned for work that is skipped until it is requeued
+var ErrEscalated = errors.New("work escalated after failing too many runs, skipped until requeued")
+
+// JobAttempts runs the work of background jobs and records its outcome. Work failing
+// entities.MaxAttempts runs in a row is escalated to the admins and skipped until it is requeued.
+type JobAttempts struct {
+	findUseCase   *find_job_failure_use_case.FindJobFailureUseCase
+	recordUseCase *record_job_attempt_use_case.RecordJobAttemptUseCase
+}
+
+func NewJobAttempts(findUseCase *find_job_failure_use_case.FindJobFailureUseCase,
+	recordUseCase *record_job_attempt_use_case.RecordJobAttemptUseCase) *JobAttempts {
+	return &JobAttempts{
+		findUseCase:   findUseCase,
+		recordUseCase: recordUseCase,
+	}
+}
+
+// Run calls work unless it was escalated, then returns its error. The subject names the work within
+// the job and tenant, the payload is what the job gives the work and what the admins are shown.
+// Failures to read or record attempts are logged, they do not stop the job.
+func (a *JobAttempts) Run(ctx context.Context, tenantID string, job string, subject string, payload map[string]string,
+	work func(ctx context.Context) error) error {
+	findCommand, err := find_job_failure_use_case.NewFindJobFailureCommand(tenantID, job, subject)
+	if err != nil {
+		return err
+	}
+	failure, err := a.findUseCase.Execute(ctx, findCommand)
+	if err != nil {
+		log.Printf("Failed to read the failures of %s %s/%s: %v", job, tenantID, subject, err)
+	}
+	if failure != nil && failure.IsEscalated() {
+		return ErrEscalated
+	}
+
+	workErr := work(ctx)
+	if ctx.Err() != nil {
+		// Runs interrupted by a shutdown are not failures of the work
+		return workErr
+	}
+
+	recordCommand, err := record_job_attempt_use_case.NewRecordJobAttemptCommand(tenantID, job, subject, payload, workErr)
+	if err != nil {
+		return err
+	}
+	recorded, err := a.recordUseCase.Execute(ctx, recordCommand)
+	if err != nil {
+		log.Printf("Failed to record the attempt of %s %s/%s: %v", job, tenantID, subject, err)
+	}
+	if recorded != nil && recorded.IsEscalated() {
+		log.Printf("ALERT %s gave up on %s/%s after %d failed runs, requeue it with POST /job-failures/%s/requeue",
+			job, tenantID, subject, recorded.Attempts, recorded.ID)
+	}
+	return workErr
+}
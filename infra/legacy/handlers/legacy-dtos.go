@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"time"
+
+	gradingEntities "github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	userEntities "github.com/nahualventure/class-backend/core/app/user/domain/entities"
+)
+
+// The old platform wrapped every payload in data, named fields in camelCase and called scores grades
+
+type LegacyUser struct {
+	ID        string    `json:"id"`
+	FullName  string    `json:"fullName"`
+	Email     string    `json:"email,omitempty" visibility:"user:view_contact" doc:"Left out for callers without user:view_contact"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewLegacyUser(user *userEntities.User) LegacyUser {
+	return LegacyUser{
+		ID:        user.ID,
+		FullName:  user.Name,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+type LegacyCreateUserRequest struct {
+	Body struct {
+		FullName string `json:"fullName" minLength:"1"`
+		Email    string `json:"email" format:"email"`
+		Password string `json:"password" minLength:"8" maxLength:"128"`
+	}
+}
+
+type LegacyUserResponse struct {
+	Body struct {
+		Data LegacyUser `json:"data"`
+	}
+}
+
+type LegacyListUsersRequest struct {
+	Limit int    `query:"limit" minimum:"1" maximum:"100" default:"20"`
+	Next  string `query:"next" doc:"Token returned as next by the previous page"`
+}
+
+type LegacyUserListResponse struct {
+	Body struct {
+		Data []LegacyUser `json:"data"`
+		Next string       `json:"next,omitempty" doc:"Token of the next page, absent on the last page"`
+	}
+}
+
+type LegacyGrade struct {
+	ID           string    `json:"id"`
+	ClassID      string    `json:"classId"`
+	AssignmentID string    `json:"assignmentId"`
+	StudentID    string    `json:"studentId"`
+	Grade        float64   `json:"grade"`
+	FinalGrade   float64   `json:"finalGrade" doc:"Grade after the late penalty"`
+	Status       string    `json:"status" enum:"draft,submitted,approved,released"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func NewLegacyGrade(grade *gradingEntities.Grade, finalGrade float64) LegacyGrade {
+	return LegacyGrade{
+		ID:           grade.ID,
+		ClassID:      grade.ClassID,
+		AssignmentID: grade.AssignmentID,
+		StudentID:    grade.StudentID,
+		Grade:        grade.Score,
+		FinalGrade:   finalGrade,
+		Status:       string(grade.Status),
+		UpdatedAt:    grade.UpdatedAt,
+	}
+}
+
+type LegacyRecordGradeRequest struct {
+	Body struct {
+		ClassID      string     `json:"classId" format:"uuid"`
+		AssignmentID string     `json:"assignmentId" format:"uuid"`
+		StudentID    string     `json:"studentId" minLength:"1"`
+		Grade        float64    `json:"grade" minimum:"0" maximum:"100"`
+		SubmittedAt  *time.Time `json:"submittedAt,omitempty" doc:"When the student turned the work in"`
+		Comment      string     `json:"comment,omitempty" maxLength:"1000" doc:"Why the grade changed"`
+	}
+}
+
+type LegacyGradeResponse struct {
+	Body struct {
+		Data LegacyGrade `json:"data"`
+	}
+}
+
+type LegacyListClassGradesRequest struct {
+	ClassID      string `path:"classId" format:"uuid"`
+	AssignmentID string `query:"assignmentId" format:"uuid"`
+}
+
+type LegacyGradeListResponse struct {
+	Body struct {
+		Data []LegacyGrade `json:"data"`
+	}
+}
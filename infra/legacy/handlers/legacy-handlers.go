@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	signup_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/signup-use-case"
+	list_class_grades_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/list-class-grades-use-case"
+	record_grade_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/record-grade-use-case"
+	list_users_use_case "github.com/nahualventure/class-backend/core/app/user/application/use-cases/list-users-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// LegacyHandlers serve the REST paths and payloads of the old platform on top of the current use
+// cases, so school integrations keep working while they migrate. Every route is deprecated with a
+// sunset in deprecation.Endpoints, which also counts who still calls them.
+type LegacyHandlers struct {
+	createUserUseCase      *signup_use_case.CreateUserUseCase
+	listUsersUseCase       *list_users_use_case.ListUsersUseCase
+	recordGradeUseCase     *record_grade_use_case.RecordGradeUseCase
+	listClassGradesUseCase *list_class_grades_use_case.ListClassGradesUseCase
+}
+
+func NewLegacyHandlers(
+	createUserUseCase *signup_use_case.CreateUserUseCase,
+	listUsersUseCase *list_users_use_case.ListUsersUseCase,
+	recordGradeUseCase *record_grade_use_case.RecordGradeUseCase,
+	listClassGradesUseCase *list_class_grades_use_case.ListClassGradesUseCase,
+) *LegacyHandlers {
+	return &LegacyHandlers{
+		createUserUseCase:      createUserUseCase,
+		listUsersUseCase:       listUsersUseCase,
+		recordGradeUseCase:     recordGradeUseCase,
+		listClassGradesUseCase: listClassGradesUseCase,
+	}
+}
+
+func (h *LegacyHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "legacy-create-user",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/users",
+		Summary:       "Create a user (old platform)",
+		Tags:          []string{"Legacy"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateUser)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "legacy-list-users",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/users",
+		Summary:     "List users (old platform)",
+		Tags:        []string{"Legacy"},
+	}, h.ListUsers)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "legacy-record-grade",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/grades",
+		Summary:     "Record a grade (old platform)",
+		Tags:        []string{"Legacy"},
+	}, h.RecordGrade)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "legacy-list-class-grades",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/classes/{classId}/grades",
+		Summary:     "List the grades of a class (old platform)",
+		Tags:        []string{"Legacy"},
+	}, h.ListClassGrades)
+}
+
+func (h *LegacyHandlers) CreateUser(ctx context.Context, input *LegacyCreateUserRequest) (*LegacyUserResponse, error) {
+	command, err := signup_use_case.NewCreateUserCommand(input.Body.FullName, input.Body.Email, input.Body.Password)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	user, err := h.createUserUseCase.Execute(command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &LegacyUserResponse{}
+	response.Body.Data = NewLegacyUser(user)
+	return response, nil
+}
+
+// ListUsers pages with the cursors of the list endpoint, the old next token was just as opaque
+func (h *LegacyHandlers) ListUsers(ctx context.Context, input *LegacyListUsersRequest) (*LegacyUserListResponse, error) {
+	command, err := list_users_use_case.NewListUsersCommand(authorization.TenantIDFromContext(ctx), input.Limit, input.Next, "", "")
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listUsersUseCase.Execute(command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &LegacyUserListResponse{}
+	response.Body.Data = make([]LegacyUser, 0, len(page.Items))
+	for _, user := range page.Items {
+		response.Body.Data = append(response.Body.Data, NewLegacyUser(user))
+	}
+	response.Body.Next = page.NextCursor
+	return response, nil
+}
+
+func (h *LegacyHandlers) RecordGrade(ctx context.Context, input *LegacyRecordGradeRequest) (*LegacyGradeResponse, error) {
+	command, err := record_grade_use_case.NewRecordGradeCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Body.ClassID,
+		input.Body.AssignmentID,
+		input.Body.StudentID,
+		input.Body.Grade,
+		input.Body.SubmittedAt,
+		authorization.UserIDFromContext(ctx),
+		input.Body.Comment,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	grade, err := h.recordGradeUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &LegacyGradeResponse{}
+	response.Body.Data = NewLegacyGrade(grade, grade.FinalScore())
+	return response, nil
+}
+
+func (h *LegacyHandlers) ListClassGrades(ctx context.Context, input *LegacyListClassGradesRequest) (*LegacyGradeListResponse, error) {
+	command, err := list_class_grades_use_case.NewListClassGradesCommand(authorization.TenantIDFromContext(ctx), input.ClassID, input.AssignmentID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	entries, err := h.listClassGradesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &LegacyGradeListResponse{}
+	response.Body.Data = make([]LegacyGrade, 0, len(entries))
+	for _, entry := range entries {
+		response.Body.Data = append(response.Body.Data, NewLegacyGrade(entry.Grade, entry.FinalScore()))
+	}
+	return response, nil
+}
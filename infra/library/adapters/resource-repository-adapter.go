@@ -0,0 +1,297 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	libraryErrors "github.com/nahualventure/class-backend/core/app/library/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/library/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresResourceRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresResourceRepository(dbInstance *pgxpool.Pool) ports.ResourceRepository {
+	return &PostgresResourceRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresResourceRepository) Create(ctx context.Context, resource *entities.Resource) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(resource.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.CreateResource(ctx, db.CreateResourceParams{
+		ID:             pgID,
+		TenantID:       resource.TenantID,
+		Kind:           string(resource.Kind),
+		Title:          resource.Title,
+		Description:    resource.Description,
+		Subject:        emptyToNil(resource.Subject),
+		GradeLevel:     emptyToNil(resource.GradeLevel),
+		Tags:           resource.Tags,
+		Url:            emptyToNil(resource.URL),
+		Status:         string(resource.Status),
+		CurrentVersion: int32(resource.CurrentVersion),
+		OwnerID:        resource.OwnerID,
+		CreatedAt:      pgtype.Timestamptz{Time: resource.CreatedAt, Valid: true},
+		UpdatedAt:      pgtype.Timestamptz{Time: resource.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresResourceRepository) Update(ctx context.Context, resource *entities.Resource) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(resource.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	params := db.UpdateResourceParams{
+		Title:       resource.Title,
+		Description: resource.Description,
+		Subject:     emptyToNil(resource.Subject),
+		GradeLevel:  emptyToNil(resource.GradeLevel),
+		Tags:        resource.Tags,
+		Url:         emptyToNil(resource.URL),
+		Status:      string(resource.Status),
+		PublishedBy: emptyToNil(resource.PublishedBy),
+		UpdatedAt:   pgtype.Timestamptz{Time: resource.UpdatedAt, Valid: true},
+		ID:          pgID,
+		TenantID:    resource.TenantID,
+	}
+	if resource.PublishedAt != nil {
+		params.PublishedAt = pgtype.Timestamptz{Time: *resource.PublishedAt, Valid: true}
+	}
+
+	updated, err := r.queries.UpdateResource(ctx, params)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if updated == 0 {
+		return libraryErrors.NewResourceNotFoundError(resource.ID)
+	}
+
+	return nil
+}
+
+func (r *PostgresResourceRepository) FindByID(ctx context.Context, tenantID string, resourceID string) (*entities.Resource, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(resourceID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetResource(ctx, db.GetResourceParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toResource(row)
+}
+
+func (r *PostgresResourceRepository) List(ctx context.Context, tenantID string, filter ports.ResourceFilter) ([]*entities.Resource, error) {
+	statuses := make([]string, 0, len(filter.Statuses))
+	for _, status := range filter.Statuses {
+		statuses = append(statuses, string(status))
+	}
+
+	rows, err := r.queries.ListResources(ctx, db.ListResourcesParams{
+		TenantID:   tenantID,
+		Statuses:   statuses,
+		DraftsOf:   emptyToNil(filter.DraftsOf),
+		Kind:       emptyToNil(string(filter.Kind)),
+		Subject:    emptyToNil(filter.Subject),
+		GradeLevel: emptyToNil(filter.GradeLevel),
+		Tag:        emptyToNil(filter.Tag),
+		Query:      emptyToNil(filter.Query),
+		RowLimit:   int32(filter.Limit),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	resources := make([]*entities.Resource, 0, len(rows))
+	for _, row := range rows {
+		resource, err := toResource(row)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
+
+// AddVersion locks the resource row so concurrent uploads are numbered one after the other. The
+// current version is left as it is, the scan of the file releases it.
+func (r *PostgresResourceRepository) AddVersion(ctx context.Context, version *entities.ResourceVersion) (int, error) {
+	var pgID, pgResourceID pgtype.UUID
+	if err := pgID.Scan(version.ID); err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	if err := pgResourceID.Scan(version.ResourceID); err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	if _, err := qtx.LockResourceVersion(ctx, db.LockResourceVersionParams{ID: pgResourceID, TenantID: version.TenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, libraryErrors.NewResourceNotFoundError(version.ResourceID)
+		}
+		return 0, appErrors.PropagateError(err)
+	}
+	last, err := qtx.GetLastResourceVersion(ctx, pgResourceID)
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	next := last + 1
+
+	err = qtx.CreateResourceVersion(ctx, db.CreateResourceVersionParams{
+		ID:            pgID,
+		TenantID:      version.TenantID,
+		ResourceID:    pgResourceID,
+		Version:       next,
+		FileName:      version.FileName,
+		ContentType:   version.ContentType,
+		Size:          version.Size,
+		Checksum:      version.Checksum,
+		ScanStatus:    string(version.ScanStatus),
+		AltText:       version.AltText,
+		CaptionStatus: string(version.CaptionStatus),
+		UploadedBy:    version.UploadedBy,
+		CreatedAt:     pgtype.Timestamptz{Time: version.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	err = qtx.AddStorageUsage(ctx, db.AddStorageUsageParams{
+		TenantID:  version.TenantID,
+		OwnerID:   version.UploadedBy,
+		Bytes:     version.Size,
+		Files:     1,
+		UpdatedAt: pgtype.Timestamptz{Time: version.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	return int(next), nil
+}
+
+func (r *PostgresResourceRepository) ListVersions(ctx context.Context, tenantID string, resourceID string) ([]*entities.ResourceVersion, error) {
+	var pgResourceID pgtype.UUID
+	if err := pgResourceID.Scan(resourceID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListResourceVersions(ctx, db.ListResourceVersionsParams{ResourceID: pgResourceID, TenantID: tenantID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	versions := make([]*entities.ResourceVersion, 0, len(rows))
+	for _, row := range rows {
+		version, err := toResourceVersion(row)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+func (r *PostgresResourceRepository) FindVersion(ctx context.Context, tenantID string, resourceID string, version int) (*entities.ResourceVersion, error) {
+	var pgResourceID pgtype.UUID
+	if err := pgResourceID.Scan(resourceID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetResourceVersion(ctx, db.GetResourceVersionParams{
+		ResourceID: pgResourceID,
+		TenantID:   tenantID,
+		Version:    int32(version),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toResourceVersion(row)
+}
+
+func toResource(row db.Resource) (*entities.Resource, error) {
+	resource, err := entities.NewResource(row.ID.String(), row.TenantID, entities.ResourceKind(row.Kind), row.Title, row.Description,
+		valueOrEmpty(row.Subject), valueOrEmpty(row.GradeLevel), row.Tags, valueOrEmpty(row.Url), entities.ResourceStatus(row.Status),
+		int(row.CurrentVersion), row.OwnerID, valueOrEmpty(row.PublishedBy), timeOrNil(row.PublishedAt), row.CreatedAt.Time,
+		row.UpdatedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return resource, nil
+}
+
+func toResourceVersion(row db.ResourceVersion) (*entities.ResourceVersion, error) {
+	version, err := entities.NewResourceVersion(row.ID.String(), row.TenantID, row.ResourceID.String(), int(row.Version), row.FileName,
+		row.ContentType, row.Size, row.Checksum, storage.ScanStatus(row.ScanStatus), storage.PreviewStatus(row.PreviewStatus),
+		row.AltText, storage.CaptionStatus(row.CaptionStatus), row.UploadedBy, row.CreatedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return version, nil
+}
+
+func timeOrNil(value pgtype.Timestamptz) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
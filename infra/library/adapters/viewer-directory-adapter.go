@@ -0,0 +1,34 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/library/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// CasbinViewerDirectory derives what a viewer may do in the library from the library:contribute
+// and library:publish permissions
+type CasbinViewerDirectory struct {
+	casbinService *authorization.CasbinService
+}
+
+func NewCasbinViewerDirectory(casbinService *authorization.CasbinService) ports.ViewerDirectory {
+	return &CasbinViewerDirectory{
+		casbinService: casbinService,
+	}
+}
+
+func (d *CasbinViewerDirectory) Viewer(ctx context.Context, tenantID string, userID string) (entities.Viewer, error) {
+	contribute, authzErr := d.casbinService.CanDo(userID, "library", "contribute", tenantID)
+	if authzErr != nil {
+		return entities.Viewer{}, authzErr
+	}
+	publish, authzErr := d.casbinService.CanDo(userID, "library", "publish", tenantID)
+	if authzErr != nil {
+		return entities.Viewer{}, authzErr
+	}
+
+	return entities.Viewer{UserID: userID, CanContribute: contribute, CanPublish: publish}, nil
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	localizationEntities "github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+)
+
+// maxVersionBodyBytes fits a file at full size, base64 grows it by a third
+const maxVersionBodyBytes = entities.MaxResourceFileBytes*4/3 + 64*1024
+
+type ResourceBodyRequest struct {
+	Title       string   `json:"title" minLength:"1" maxLength:"200"`
+	Description string   `json:"description,omitempty" maxLength:"2000"`
+	Subject     string   `json:"subject,omitempty" maxLength:"100" example:"Mathematics"`
+	GradeLevel  string   `json:"grade_level,omitempty" maxLength:"20" example:"7" doc:"PK, K or 1 to 12"`
+	Tags        []string `json:"tags,omitempty" maxItems:"20" doc:"Stored lower case without duplicates"`
+	URL         string   `json:"url,omitempty" format:"uri" doc:"Required for links, optional for videos"`
+}
+
+type CreateResourceRequest struct {
+	Body struct {
+		Kind string `json:"kind" enum:"document,link,video"`
+		ResourceBodyRequest
+	}
+}
+
+type UpdateResourceRequest struct {
+	ResourceID string `path:"resourceId" format:"uuid"`
+	Body       ResourceBodyRequest
+}
+
+type ResourceRequest struct {
+	ResourceID string `path:"resourceId" format:"uuid"`
+}
+
+type GetResourceRequest struct {
+	ResourceID     string `path:"resourceId" format:"uuid"`
+	AcceptLanguage string `header:"Accept-Language" doc:"Locales to show the title and description in, falls back to the content as written"`
+}
+
+type ListResourcesRequest struct {
+	Kind       string `query:"kind" enum:"document,link,video"`
+	Subject    string `query:"subject"`
+	GradeLevel string `query:"grade_level"`
+	Tag        string `query:"tag"`
+	Query      string `query:"q" doc:"Matches part of the title, case insensitive"`
+	Status     string `query:"status" enum:"draft,published,archived"`
+	Limit      int    `query:"limit" minimum:"0" maximum:"100" doc:"Page size, 50 when omitted"`
+	// Titles are matched as written, not as translated
+	AcceptLanguage string `header:"Accept-Language" doc:"Locales to show titles and descriptions in, falls back to the content as written"`
+}
+
+type UploadResourceVersionRequest struct {
+	ResourceID string `path:"resourceId" format:"uuid"`
+	Body       struct {
+		FileName    string `json:"file_name" minLength:"1" maxLength:"255"`
+		ContentType string `json:"content_type" minLength:"1" maxLength:"100" example:"application/pdf"`
+		Content     []byte `json:"content" doc:"Base64 encoded file, at most 25 MiB"`
+		AltText     string `json:"alt_text,omitempty" maxLength:"1000" doc:"Text alternative read by screen readers, required for images"`
+	}
+}
+
+type DownloadResourceVersionRequest struct {
+	ResourceID string `path:"resourceId" format:"uuid"`
+	Version    int    `query:"version" minimum:"0" doc:"Current version when omitted"`
+	Preview    bool   `query:"preview" doc:"Download the PNG preview of the version instead of its file"`
+	// Alternative cannot be combined with preview
+	Alternative string `query:"alternative" enum:"captions,transcript" doc:"Download the WebVTT captions or the transcript of a video or audio version once its captions are ready"`
+	export.RangeRequest
+}
+
+type ResourceResponse struct {
+	ID             string     `json:"id"`
+	Kind           string     `json:"kind"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description,omitempty"`
+	Subject        string     `json:"subject,omitempty"`
+	GradeLevel     string     `json:"grade_level,omitempty"`
+	Tags           []string   `json:"tags"`
+	URL            string     `json:"url,omitempty"`
+	Status         string     `json:"status" enum:"draft,published,archived"`
+	CurrentVersion int        `json:"current_version" doc:"0 until a file is uploaded"`
+	OwnerID        string     `json:"owner_id"`
+	PublishedBy    string     `json:"published_by,omitempty"`
+	PublishedAt    *time.Time `json:"published_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func NewResourceResponse(resource *entities.Resource) ResourceResponse {
+	tags := resource.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return ResourceResponse{
+		ID:             resource.ID,
+		Kind:           string(resource.Kind),
+		Title:          resource.Title,
+		Description:    resource.Description,
+		Subject:        resource.Subject,
+		GradeLevel:     resource.GradeLevel,
+		Tags:           tags,
+		URL:            resource.URL,
+		Status:         string(resource.Status),
+		CurrentVersion: resource.CurrentVersion,
+		OwnerID:        resource.OwnerID,
+		PublishedBy:    resource.PublishedBy,
+		PublishedAt:    resource.PublishedAt,
+		CreatedAt:      resource.CreatedAt,
+		UpdatedAt:      resource.UpdatedAt,
+	}
+}
+
+// localize overlays the translated fields of the resource
+func (r *ResourceResponse) localize(fields map[string]string) {
+	if title, found := fields[localizationEntities.FieldTitle]; found {
+		r.Title = title
+	}
+	if description, found := fields[localizationEntities.FieldDescription]; found {
+		r.Description = description
+	}
+}
+
+type ResourceEnvelope struct {
+	Body ResourceResponse
+}
+
+type ResourceListResponse struct {
+	Body struct {
+		Items []ResourceResponse `json:"items"`
+	}
+}
+
+type ResourceVersionResponse struct {
+	ID            string    `json:"id"`
+	Version       int       `json:"version"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	Size          int64     `json:"size"`
+	Checksum      string    `json:"checksum" doc:"SHA-256 of the content, hex encoded"`
+	ScanStatus    string    `json:"scan_status" enum:"pending,clean,infected,missing" doc:"Virus scan of the file, only clean versions are downloadable"`
+	PreviewStatus string    `json:"preview_status" enum:"pending,ready,unsupported,failed" doc:"Thumbnail of images and of the first page of documents, downloadable with preview=true once ready"`
+	AltText       string    `json:"alt_text,omitempty"`
+	CaptionStatus string    `json:"caption_status" enum:"pending,ready,not_needed,unsupported,failed" doc:"Captions and transcript of videos and audio, downloadable with alternative once ready"`
+	UploadedBy    string    `json:"uploaded_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func NewResourceVersionResponse(version *entities.ResourceVersion) ResourceVersionResponse {
+	return ResourceVersionResponse{
+		ID:            version.ID,
+		Version:       version.Version,
+		FileName:      version.FileName,
+		ContentType:   version.ContentType,
+		Size:          version.Size,
+		Checksum:      version.Checksum,
+		ScanStatus:    string(version.ScanStatus),
+		PreviewStatus: string(version.PreviewStatus),
+		AltText:       version.AltText,
+		CaptionStatus: string(version.CaptionStatus),
+		UploadedBy:    version.UploadedBy,
+		CreatedAt:     version.CreatedAt,
+	}
+}
+
+type ResourceVersionEnvelope struct {
+	Body ResourceVersionResponse
+}
+
+type ResourceDetailsResponse struct {
+	Body struct {
+		ResourceResponse
+		Versions []ResourceVersionResponse `json:"versions" doc:"Newest first"`
+	}
+}
+
+func NewResourceDetailsResponse(details *entities.ResourceDetails) *ResourceDetailsResponse {
+	response := &ResourceDetailsResponse{}
+	response.Body.ResourceResponse = NewResourceResponse(details.Resource)
+	response.Body.Versions = make([]ResourceVersionResponse, 0, len(details.Versions))
+	for _, version := range details.Versions {
+		response.Body.Versions = append(response.Body.Versions, NewResourceVersionResponse(version))
+	}
+	return response
+}
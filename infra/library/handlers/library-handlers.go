@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	change_resource_status_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/change-resource-status-use-case"
+	create_resource_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/create-resource-use-case"
+	download_resource_version_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/download-resource-version-use-case"
+	get_resource_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/get-resource-use-case"
+	list_resources_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/list-resources-use-case"
+	update_resource_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/update-resource-use-case"
+	upload_resource_version_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/upload-resource-version-use-case"
+	localize_content_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/localize-content-use-case"
+	localizationEntities "github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type LibraryHandlers struct {
+	createResourceUseCase          *create_resource_use_case.CreateResourceUseCase
+	listResourcesUseCase           *list_resources_use_case.ListResourcesUseCase
+	getResourceUseCase             *get_resource_use_case.GetResourceUseCase
+	updateResourceUseCase          *update_resource_use_case.UpdateResourceUseCase
+	uploadResourceVersionUseCase   *upload_resource_version_use_case.UploadResourceVersionUseCase
+	downloadResourceVersionUseCase *download_resource_version_use_case.DownloadResourceVersionUseCase
+	changeResourceStatusUseCase    *change_resource_status_use_case.ChangeResourceStatusUseCase
+	localizeContentUseCase         *localize_content_use_case.LocalizeContentUseCase
+}
+
+func NewLibraryHandlers(
+	createResourceUseCase *create_resource_use_case.CreateResourceUseCase,
+	listResourcesUseCase *list_resources_use_case.ListResourcesUseCase,
+	getResourceUseCase *get_resource_use_case.GetResourceUseCase,
+	updateResourceUseCase *update_resource_use_case.UpdateResourceUseCase,
+	uploadResourceVersionUseCase *upload_resource_version_use_case.UploadResourceVersionUseCase,
+	downloadResourceVersionUseCase *download_resource_version_use_case.DownloadResourceVersionUseCase,
+	changeResourceStatusUseCase *change_resource_status_use_case.ChangeResourceStatusUseCase,
+	localizeContentUseCase *localize_content_use_case.LocalizeContentUseCase,
+) *LibraryHandlers {
+	return &LibraryHandlers{
+		createResourceUseCase:          createResourceUseCase,
+		listResourcesUseCase:           listResourcesUseCase,
+		getResourceUseCase:             getResourceUseCase,
+		updateResourceUseCase:          updateResourceUseCase,
+		uploadResourceVersionUseCase:   uploadResourceVersionUseCase,
+		downloadResourceVersionUseCase: downloadResourceVersionUseCase,
+		changeResourceStatusUseCase:    changeResourceStatusUseCase,
+		localizeContentUseCase:         localizeContentUseCase,
+	}
+}
+
+func (h *LibraryHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-resource",
+		Method:        http.MethodPost,
+		Path:          "/library/resources",
+		Summary:       "Create a library resource as a draft",
+		Tags:          []string{"Library"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateResource)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-resources",
+		Method:      http.MethodGet,
+		Path:        "/library/resources",
+		Summary:     "Browse the library by subject, grade, tag or title",
+		Description: "Published resources for everybody, drafts for their owners and publishers, archived ones for publishers.",
+		Tags:        []string{"Library"},
+	}, h.ListResources)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-resource",
+		Method:      http.MethodGet,
+		Path:        "/library/resources/{resourceId}",
+		Summary:     "Get a resource with its file versions",
+		Tags:        []string{"Library"},
+	}, h.GetResource)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-resource",
+		Method:      http.MethodPut,
+		Path:        "/library/resources/{resourceId}",
+		Summary:     "Update the metadata and tags of a resource",
+		Tags:        []string{"Library"},
+	}, h.UpdateResource)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "upload-resource-version",
+		Method:        http.MethodPost,
+		Path:          "/library/resources/{resourceId}/versions",
+		Summary:       "Upload a new file version of a document or video",
+		Tags:          []string{"Library"},
+		DefaultStatus: http.StatusCreated,
+		MaxBodyBytes:  maxVersionBodyBytes,
+	}, h.UploadResourceVersion)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "download-resource-version",
+		Method:      http.MethodGet,
+		Path:        "/library/resources/{resourceId}/content",
+		Summary:     "Download the file of a resource version",
+		Tags:        []string{"Library"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "File content",
+				Content:     map[string]*huma.MediaType{"application/octet-stream": {}},
+			},
+		},
+	}, h.DownloadResourceVersion)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "publish-resource",
+		Method:      http.MethodPost,
+		Path:        "/library/resources/{resourceId}/publish",
+		Summary:     "Publish a resource to the whole tenant",
+		Tags:        []string{"Library"},
+	}, h.PublishResource)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "archive-resource",
+		Method:      http.MethodPost,
+		Path:        "/library/resources/{resourceId}/archive",
+		Summary:     "Archive a resource, it is hidden from the library and read only",
+		Tags:        []string{"Library"},
+	}, h.ArchiveResource)
+}
+
+func (h *LibraryHandlers) CreateResource(ctx context.Context, input *CreateResourceRequest) (*ResourceEnvelope, error) {
+	command, err := create_resource_use_case.NewCreateResourceCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Kind,
+		input.Body.Title,
+		input.Body.Description,
+		input.Body.Subject,
+		input.Body.GradeLevel,
+		input.Body.Tags,
+		input.Body.URL,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	resource, err := h.createResourceUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ResourceEnvelope{Body: NewResourceResponse(resource)}, nil
+}
+
+func (h *LibraryHandlers) ListResources(ctx context.Context, input *ListResourcesRequest) (*ResourceListResponse, error) {
+	command, err := list_resources_use_case.NewListResourcesCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Kind,
+		input.Subject,
+		input.GradeLevel,
+		input.Tag,
+		input.Query,
+		input.Status,
+		input.Limit,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	resources, err := h.listResourcesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	resourceIDs := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		resourceIDs = append(resourceIDs, resource.ID)
+	}
+	localized, err := h.localize(ctx, resourceIDs, input.AcceptLanguage)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ResourceListResponse{}
+	response.Body.Items = make([]ResourceResponse, 0, len(resources))
+	for _, resource := range resources {
+		item := NewResourceResponse(resource)
+		item.localize(localized[resource.ID])
+		response.Body.Items = append(response.Body.Items, item)
+	}
+	return response, nil
+}
+
+func (h *LibraryHandlers) GetResource(ctx context.Context, input *GetResourceRequest) (*ResourceDetailsResponse, error) {
+	command, err := get_resource_use_case.NewGetResourceCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ResourceID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	details, err := h.getResourceUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	localized, err := h.localize(ctx, []string{details.Resource.ID}, input.AcceptLanguage)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := NewResourceDetailsResponse(details)
+	response.Body.localize(localized[details.Resource.ID])
+	return response, nil
+}
+
+func (h *LibraryHandlers) UpdateResource(ctx context.Context, input *UpdateResourceRequest) (*ResourceEnvelope, error) {
+	command, err := update_resource_use_case.NewUpdateResourceCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ResourceID,
+		authorization.UserIDFromContext(ctx),
+		input.Body.Title,
+		input.Body.Description,
+		input.Body.Subject,
+		input.Body.GradeLevel,
+		input.Body.Tags,
+		input.Body.URL,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	resource, err := h.updateResourceUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ResourceEnvelope{Body: NewResourceResponse(resource)}, nil
+}
+
+func (h *LibraryHandlers) UploadResourceVersion(ctx context.Context, input *UploadResourceVersionRequest) (*ResourceVersionEnvelope, error) {
+	command, err := upload_resource_version_use_case.NewUploadResourceVersionCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ResourceID,
+		authorization.UserIDFromContext(ctx),
+		input.Body.FileName,
+		input.Body.ContentType,
+		input.Body.Content,
+		input.Body.AltText,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	version, err := h.uploadResourceVersionUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ResourceVersionEnvelope{Body: NewResourceVersionResponse(version)}, nil
+}
+
+func (h *LibraryHandlers) DownloadResourceVersion(ctx context.Context, input *DownloadResourceVersionRequest) (*huma.StreamResponse, error) {
+	command, err := download_resource_version_use_case.NewDownloadResourceVersionCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ResourceID,
+		authorization.UserIDFromContext(ctx),
+		input.Version,
+		input.Preview,
+		storage.AlternativeFormat(input.Alternative),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	version, content, err := h.downloadResourceVersionUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	download := export.FileDownload{
+		Filename:    version.FileName,
+		ContentType: version.ContentType,
+		Content:     content,
+	}
+	if input.Preview {
+		download = download.AsPreview()
+	}
+	if input.Alternative != "" {
+		download = download.AsAlternative(storage.AlternativeFormat(input.Alternative))
+	}
+	return download.Response(input.RangeRequest), nil
+}
+
+func (h *LibraryHandlers) PublishResource(ctx context.Context, input *ResourceRequest) (*ResourceEnvelope, error) {
+	return h.changeStatus(ctx, input.ResourceID, string(change_resource_status_use_case.ResourceActionPublish))
+}
+
+func (h *LibraryHandlers) ArchiveResource(ctx context.Context, input *ResourceRequest) (*ResourceEnvelope, error) {
+	return h.changeStatus(ctx, input.ResourceID, string(change_resource_status_use_case.ResourceActionArchive))
+}
+
+func (h *LibraryHandlers) changeStatus(ctx context.Context, resourceID string, action string) (*ResourceEnvelope, error) {
+	command, err := change_resource_status_use_case.NewChangeResourceStatusCommand(
+		authorization.TenantIDFromContext(ctx),
+		resourceID,
+		authorization.UserIDFromContext(ctx),
+		action,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	resource, err := h.changeResourceStatusUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ResourceEnvelope{Body: NewResourceResponse(resource)}, nil
+}
+
+// localize returns the translated fields by resource ID in the locales the reader accepts
+func (h *LibraryHandlers) localize(ctx context.Context, resourceIDs []string, acceptLanguage string) (map[string]map[string]string, error) {
+	command, err := localize_content_use_case.NewLocalizeContentCommand(
+		authorization.TenantIDFromContext(ctx),
+		localizationEntities.EntityTypeResource,
+		resourceIDs,
+		acceptLanguage,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return h.localizeContentUseCase.Execute(ctx, command)
+}
@@ -0,0 +1,64 @@
+-- name: CreateResource :exec
+INSERT INTO resources (id, tenant_id, kind, title, description, subject, grade_level, tags, url, status, current_version,
+                       owner_id, created_at, updated_at)
+VALUES (@id, @tenant_id, @kind, @title, @description, @subject, @grade_level, @tags, @url, @status, @current_version,
+        @owner_id, @created_at, @updated_at);
+
+-- name: UpdateResource :execrows
+UPDATE resources
+SET title = @title, description = @description, subject = @subject, grade_level = @grade_level, tags = @tags,
+    url = @url, status = @status, published_by = @published_by, published_at = @published_at, updated_at = @updated_at
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: GetResource :one
+SELECT id, tenant_id, kind, title, description, subject, grade_level, tags, url, status, current_version, owner_id,
+       published_by, published_at, created_at, updated_at
+FROM resources
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: ListResources :many
+SELECT id, tenant_id, kind, title, description, subject, grade_level, tags, url, status, current_version, owner_id,
+       published_by, published_at, created_at, updated_at
+FROM resources
+WHERE tenant_id = @tenant_id
+  AND (
+      status = ANY(@statuses::varchar[])
+      OR (owner_id = sqlc.narg('drafts_of') AND status = 'draft')
+  )
+  AND (sqlc.narg('kind')::varchar IS NULL OR kind = sqlc.narg('kind'))
+  AND (sqlc.narg('subject')::varchar IS NULL OR subject = sqlc.narg('subject'))
+  AND (sqlc.narg('grade_level')::varchar IS NULL OR grade_level = sqlc.narg('grade_level'))
+  AND (sqlc.narg('tag')::text IS NULL OR sqlc.narg('tag')::text = ANY(tags))
+  AND (sqlc.narg('query')::text IS NULL OR position(lower(sqlc.narg('query')::text) IN lower(title)) > 0)
+ORDER BY updated_at DESC
+LIMIT @row_limit;
+
+-- name: LockResourceVersion :one
+SELECT current_version
+FROM resources
+WHERE id = @id AND tenant_id = @tenant_id
+FOR UPDATE;
+
+-- name: GetLastResourceVersion :one
+SELECT COALESCE(MAX(version), 0)::integer
+FROM resource_versions
+WHERE resource_id = @resource_id;
+
+-- name: CreateResourceVersion :exec
+INSERT INTO resource_versions (id, tenant_id, resource_id, version, file_name, content_type, size, checksum,
+                               scan_status, alt_text, caption_status, uploaded_by, created_at)
+VALUES (@id, @tenant_id, @resource_id, @version, @file_name, @content_type, @size, @checksum, @scan_status,
+        @alt_text, @caption_status, @uploaded_by, @created_at);
+
+-- name: ListResourceVersions :many
+SELECT id, tenant_id, resource_id, version, file_name, content_type, size, checksum, uploaded_by, created_at, scan_status,
+       preview_status, alt_text, caption_status
+FROM resource_versions
+WHERE resource_id = @resource_id AND tenant_id = @tenant_id
+ORDER BY version DESC;
+
+-- name: GetResourceVersion :one
+SELECT id, tenant_id, resource_id, version, file_name, content_type, size, checksum, uploaded_by, created_at, scan_status,
+       preview_status, alt_text, caption_status
+FROM resource_versions
+WHERE resource_id = @resource_id AND tenant_id = @tenant_id AND version = @version;
@@ -0,0 +1,47 @@
+-- Shared teaching resources of a tenant. Drafts are visible to their owner and publishers only,
+-- archived resources stay readable but cannot change.
+CREATE TABLE resources (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL,              -- document, link, video
+    title VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    subject VARCHAR(100),
+    grade_level VARCHAR(20),
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    url TEXT,
+    status VARCHAR(20) NOT NULL,            -- draft, published, archived
+    current_version INTEGER NOT NULL DEFAULT 0,
+    owner_id VARCHAR(255) NOT NULL,
+    published_by VARCHAR(255),
+    published_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_resources_tenant ON resources(tenant_id, status, updated_at DESC);
+CREATE INDEX idx_resources_tags ON resources USING GIN (tags);
+
+-- File versions of documents and videos, the content lives in the file storage under
+-- library/<tenant>/<resource>/<version id>. A version becomes current once it is scanned clean.
+CREATE TABLE resource_versions (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    resource_id UUID NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,          -- sha256, hex encoded
+    uploaded_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    scan_status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, clean, infected, missing
+    preview_status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, ready, unsupported, failed
+    alt_text TEXT NOT NULL DEFAULT '',                      -- required for images since accessibility reports
+    caption_status VARCHAR(20) NOT NULL DEFAULT 'not_needed', -- pending, ready, not_needed, unsupported, failed
+    UNIQUE (resource_id, version)
+);
+
+CREATE INDEX idx_resource_versions_pending_scan ON resource_versions(created_at) WHERE scan_status = 'pending';
+CREATE INDEX idx_resource_versions_pending_preview ON resource_versions(created_at) WHERE preview_status = 'pending';
+CREATE INDEX idx_resource_versions_pending_captions ON resource_versions(created_at) WHERE caption_status = 'pending';
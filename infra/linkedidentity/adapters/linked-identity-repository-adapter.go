@@ -0,0 +1,197 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresLinkedIdentityRepository keeps linked identities in their own table and the password in
+// users, where an empty hash means the user has none
+type PostgresLinkedIdentityRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresLinkedIdentityRepository(dbInstance *pgxpool.Pool) ports.LinkedIdentityRepository {
+	return &PostgresLinkedIdentityRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresLinkedIdentityRepository) Credentials(ctx context.Context, userID string) (*entities.Credentials, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(userID); err != nil {
+		// Callers that are not users, such as service accounts, have no credentials
+		return nil, nil
+	}
+
+	hasPassword, err := r.queries.GetPasswordState(ctx, pgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return r.credentials(ctx, pgUUID, userID, hasPassword)
+}
+
+func (r *PostgresLinkedIdentityRepository) MemberCredentials(ctx context.Context, tenantID string, userID string) (*entities.Credentials, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(userID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	hasPassword, err := r.queries.GetMemberPasswordState(ctx, db.GetMemberPasswordStateParams{UserID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return r.credentials(ctx, pgUUID, userID, hasPassword)
+}
+
+func (r *PostgresLinkedIdentityRepository) credentials(ctx context.Context, pgUUID pgtype.UUID, userID string, hasPassword bool) (*entities.Credentials, error) {
+	rows, err := r.queries.ListLinkedIdentities(ctx, pgUUID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	credentials := &entities.Credentials{UserID: userID, HasPassword: hasPassword}
+	for _, row := range rows {
+		identity, err := toLinkedIdentity(row)
+		if err != nil {
+			return nil, err
+		}
+		credentials.Identities = append(credentials.Identities, identity)
+	}
+	return credentials, nil
+}
+
+func (r *PostgresLinkedIdentityRepository) Link(ctx context.Context, identity *entities.LinkedIdentity) (bool, error) {
+	var pgUUID, userUUID pgtype.UUID
+	if err := pgUUID.Scan(identity.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	if err := userUUID.Scan(identity.UserID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	var email *string
+	if identity.Email != "" {
+		email = &identity.Email
+	}
+
+	rows, err := r.queries.LinkIdentity(ctx, db.LinkIdentityParams{
+		ID:       pgUUID,
+		UserID:   userUUID,
+		Provider: string(identity.Provider),
+		Issuer:   identity.Issuer,
+		Subject:  identity.Subject,
+		Email:    email,
+		LinkedAt: pgtype.Timestamptz{Time: identity.LinkedAt, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return rows == 1, nil
+}
+
+func (r *PostgresLinkedIdentityRepository) Unlink(ctx context.Context, userID string, identityID string) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(identityID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return r.removeCredential(ctx, userID, func(qtx *db.Queries, userUUID pgtype.UUID) error {
+		_, err := qtx.DeleteLinkedIdentity(ctx, db.DeleteLinkedIdentityParams{ID: pgUUID, UserID: userUUID})
+		return err
+	})
+}
+
+func (r *PostgresLinkedIdentityRepository) RemovePassword(ctx context.Context, userID string) (bool, error) {
+	return r.removeCredential(ctx, userID, func(qtx *db.Queries, userUUID pgtype.UUID) error {
+		return qtx.ClearPasswordHash(ctx, userUUID)
+	})
+}
+
+// removeCredential runs remove with the user locked while the user has another way to sign in
+func (r *PostgresLinkedIdentityRepository) removeCredential(ctx context.Context, userID string, remove func(qtx *db.Queries, userUUID pgtype.UUID) error) (bool, error) {
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+
+	locked, err := qtx.LockUserCredentials(ctx, userUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, appErrors.PropagateError(err)
+	}
+	count := locked.Identities
+	if locked.HasPassword {
+		count++
+	}
+	if count <= 1 {
+		return false, nil
+	}
+
+	if err := remove(qtx, userUUID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return true, nil
+}
+
+func (r *PostgresLinkedIdentityRepository) Resolve(ctx context.Context, provider entities.Provider, issuer string, subject string, at time.Time) (*entities.LinkedIdentity, error) {
+	row, err := r.queries.TouchLinkedIdentity(ctx, db.TouchLinkedIdentityParams{
+		UsedAt:   pgtype.Timestamptz{Time: at, Valid: true},
+		Provider: string(provider),
+		Issuer:   issuer,
+		Subject:  subject,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toLinkedIdentity(row)
+}
+
+func toLinkedIdentity(row db.LinkedIdentity) (*entities.LinkedIdentity, error) {
+	email := ""
+	if row.Email != nil {
+		email = *row.Email
+	}
+	identity, err := entities.NewLinkedIdentity(row.ID.String(), row.UserID.String(), entities.Provider(row.Provider), row.Issuer, row.Subject, email, row.LinkedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if row.LastUsedAt.Valid {
+		identity.LastUsedAt = &row.LastUsedAt.Time
+	}
+	return identity, nil
+}
@@ -0,0 +1,251 @@
+package adapters
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	linkedIdentityErrors "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+)
+
+const (
+	// GoogleIssuer is the issuer the identities of Google are linked with, the ID tokens of Google
+	// carry it with or without the scheme
+	GoogleIssuer  = "https://accounts.google.com"
+	GoogleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+	// jwksMaxAge is how long the keys of a provider are used before they are fetched again, tokens
+	// signed with a key not seen yet fetch them right away at most once per jwksMinRefresh
+	jwksMaxAge     = time.Hour
+	jwksMinRefresh = time.Minute
+	// clockSkew is the leeway given to the expiry and not-before claims
+	clockSkew = time.Minute
+)
+
+type IdentityProviderConfig struct {
+	// Issuers are the iss claims tokens of the provider carry, the identity is linked with the first
+	Issuers []string
+	// JWKSURL serves the keys the provider signs tokens with
+	JWKSURL string
+	// Audience is the client ID of the application at the provider, tokens issued to other
+	// applications are refused
+	Audience string
+}
+
+// OIDCIdentityTokenVerifier verifies OpenID Connect ID tokens signed with RS256. SAML identities are
+// linked with the ID token of the broker the gateway signs SAML users in through.
+type OIDCIdentityTokenVerifier struct {
+	providers map[entities.Provider]IdentityProviderConfig
+	client    *http.Client
+	now       func() time.Time
+
+	mu   sync.Mutex
+	keys map[string]*jwks
+}
+
+type jwks struct {
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewOIDCIdentityTokenVerifier verifies the tokens of providers, tokens of providers without a
+// config are refused
+func NewOIDCIdentityTokenVerifier(providers map[entities.Provider]IdentityProviderConfig) ports.IdentityTokenVerifier {
+	return &OIDCIdentityTokenVerifier{
+		providers: providers,
+		client:    &http.Client{Timeout: 10 * time.Second, Transport: cost.Transport("oidc", nil)},
+		now:       time.Now,
+		keys:      make(map[string]*jwks),
+	}
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type tokenClaims struct {
+	Issuer        string          `json:"iss"`
+	Subject       string          `json:"sub"`
+	Audience      json.RawMessage `json:"aud"`
+	ExpiresAt     int64           `json:"exp"`
+	NotBefore     int64           `json:"nbf"`
+	Email         string          `json:"email"`
+	EmailVerified any             `json:"email_verified"`
+}
+
+func (v *OIDCIdentityTokenVerifier) Verify(ctx context.Context, provider entities.Provider, token string) (*entities.VerifiedIdentity, error) {
+	config, ok := v.providers[provider]
+	if !ok {
+		return nil, linkedIdentityErrors.NewInvalidIdentityTokenError(string(provider), "provider not configured")
+	}
+	invalid := func(reason string) error {
+		return linkedIdentityErrors.NewInvalidIdentityTokenError(string(provider), reason)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalid("malformed token")
+	}
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, invalid("malformed header")
+	}
+	if header.Alg != "RS256" {
+		return nil, invalid("unsupported algorithm")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid("malformed signature")
+	}
+
+	key, err := v.key(ctx, config.JWKSURL, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, invalid("unknown signing key")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, invalid("signature mismatch")
+	}
+
+	var claims tokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, invalid("malformed claims")
+	}
+	if !slices.Contains(config.Issuers, claims.Issuer) {
+		return nil, invalid("unexpected issuer")
+	}
+	if !audienceContains(claims.Audience, config.Audience) {
+		return nil, invalid("issued to another application")
+	}
+	now := v.now()
+	if claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, invalid("expired")
+	}
+	if claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, invalid("not valid yet")
+	}
+	if claims.Subject == "" {
+		return nil, invalid("missing subject")
+	}
+
+	identity := &entities.VerifiedIdentity{
+		Provider: provider,
+		Issuer:   config.Issuers[0],
+		Subject:  claims.Subject,
+	}
+	// Google sends email_verified as a boolean and some brokers as a string
+	if claims.EmailVerified == true || claims.EmailVerified == "true" {
+		identity.Email = claims.Email
+	}
+	return identity, nil
+}
+
+// key returns the key of the provider with the ID, nil when the provider has none
+func (v *OIDCIdentityTokenVerifier) key(ctx context.Context, url string, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	cached := v.keys[url]
+	now := v.now()
+	if cached != nil {
+		if key, ok := cached.keys[kid]; ok && now.Sub(cached.fetchedAt) < jwksMaxAge {
+			return key, nil
+		}
+		if now.Sub(cached.fetchedAt) < jwksMinRefresh {
+			return cached.keys[kid], nil
+		}
+	}
+
+	keys, err := v.fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	v.keys[url] = &jwks{keys: keys, fetchedAt: now}
+	return keys[kid], nil
+}
+
+type jsonWebKeySet struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+func (v *OIDCIdentityTokenVerifier) fetch(ctx context.Context, url string) (map[string]*rsa.PublicKey, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to build identity provider keys request", err)
+	}
+
+	response, err := v.client.Do(request)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to reach identity provider", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("identity provider answered %d: %s", response.StatusCode, message), nil)
+	}
+
+	var set jsonWebKeySet
+	if err := json.NewDecoder(response.Body).Decode(&set); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to decode identity provider keys", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(key.N)
+		e, errE := base64.RawURLEncoding.DecodeString(key.E)
+		if errN != nil || errE != nil || len(e) > 4 {
+			continue
+		}
+		keys[key.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// audienceContains tells whether the aud claim, a string or an array of them, has audience
+func audienceContains(claim json.RawMessage, audience string) bool {
+	var single string
+	if err := json.Unmarshal(claim, &single); err == nil {
+		return single == audience
+	}
+	var many []string
+	if err := json.Unmarshal(claim, &many); err == nil {
+		return slices.Contains(many, audience)
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	userHandlers "github.com/nahualventure/class-backend/infra/user/handlers"
+)
+
+type LinkedIdentityResponse struct {
+	ID         string     `json:"id"`
+	Provider   string     `json:"provider" enum:"google,saml"`
+	Issuer     string     `json:"issuer"`
+	Subject    string     `json:"subject"`
+	Email      string     `json:"email,omitempty"`
+	LinkedAt   time.Time  `json:"linked_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" doc:"Last time the user signed in with it"`
+}
+
+func NewLinkedIdentityResponse(identity *entities.LinkedIdentity, loc *time.Location) LinkedIdentityResponse {
+	return LinkedIdentityResponse{
+		ID:         identity.ID,
+		Provider:   string(identity.Provider),
+		Issuer:     identity.Issuer,
+		Subject:    identity.Subject,
+		Email:      identity.Email,
+		LinkedAt:   utils.InLocation(identity.LinkedAt, loc),
+		LastUsedAt: utils.InLocationPtr(identity.LastUsedAt, loc),
+	}
+}
+
+type LinkedIdentityEnvelope struct {
+	Body LinkedIdentityResponse
+}
+
+type CredentialsResponse struct {
+	UserID      string                   `json:"user_id"`
+	HasPassword bool                     `json:"has_password" doc:"Whether the user can sign in with a password"`
+	Identities  []LinkedIdentityResponse `json:"identities"`
+}
+
+func NewCredentialsResponse(credentials *entities.Credentials, loc *time.Location) CredentialsResponse {
+	response := CredentialsResponse{
+		UserID:      credentials.UserID,
+		HasPassword: credentials.HasPassword,
+		Identities:  make([]LinkedIdentityResponse, 0, len(credentials.Identities)),
+	}
+	for _, identity := range credentials.Identities {
+		response.Identities = append(response.Identities, NewLinkedIdentityResponse(identity, loc))
+	}
+	return response
+}
+
+type CredentialsEnvelope struct {
+	Body CredentialsResponse
+}
+
+type LinkIdentityRequest struct {
+	Body struct {
+		Provider string `json:"provider" enum:"google,saml"`
+		Token    string `json:"token" maxLength:"16384" doc:"ID token the provider issued at the end of its sign in flow, the broker's for SAML"`
+		// Sent by clients of 1.0.0, the identity is read from the verified token instead
+		Issuer  string `json:"issuer,omitempty" maxLength:"255" deprecated:"true" doc:"Ignored, read from the token"`
+		Subject string `json:"subject,omitempty" maxLength:"255" deprecated:"true" doc:"Ignored, read from the token"`
+		Email   string `json:"email,omitempty" deprecated:"true" doc:"Ignored, read from the token"`
+	}
+}
+
+type LinkedIdentityPathRequest struct {
+	IdentityID string `path:"identityId" format:"uuid" doc:"Linked identity ID"`
+}
+
+type ResolveLinkedIdentityRequest struct {
+	Body struct {
+		Provider string `json:"provider" enum:"google,saml"`
+		Issuer   string `json:"issuer" maxLength:"255"`
+		Subject  string `json:"subject" maxLength:"255"`
+	}
+}
+
+type ResolveLinkedIdentityResponse struct {
+	Body userHandlers.UserResponse
+}
+
+type UserCredentialsPathRequest struct {
+	UserID string `path:"userId" format:"uuid" doc:"User ID"`
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_credentials_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/get-credentials-use-case"
+	get_member_credentials_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/get-member-credentials-use-case"
+	link_identity_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/link-identity-use-case"
+	remove_password_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/remove-password-use-case"
+	resolve_linked_identity_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/resolve-linked-identity-use-case"
+	unlink_identity_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/unlink-identity-use-case"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	userPorts "github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	userHandlers "github.com/nahualventure/class-backend/infra/user/handlers"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type LinkedIdentityHandlers struct {
+	getCredentialsUseCase        *get_credentials_use_case.GetCredentialsUseCase
+	getMemberCredentialsUseCase  *get_member_credentials_use_case.GetMemberCredentialsUseCase
+	linkIdentityUseCase          *link_identity_use_case.LinkIdentityUseCase
+	unlinkIdentityUseCase        *unlink_identity_use_case.UnlinkIdentityUseCase
+	removePasswordUseCase        *remove_password_use_case.RemovePasswordUseCase
+	resolveLinkedIdentityUseCase *resolve_linked_identity_use_case.ResolveLinkedIdentityUseCase
+	userReader                   userPorts.UserReader
+}
+
+func NewLinkedIdentityHandlers(
+	getCredentialsUseCase *get_credentials_use_case.GetCredentialsUseCase,
+	getMemberCredentialsUseCase *get_member_credentials_use_case.GetMemberCredentialsUseCase,
+	linkIdentityUseCase *link_identity_use_case.LinkIdentityUseCase,
+	unlinkIdentityUseCase *unlink_identity_use_case.UnlinkIdentityUseCase,
+	removePasswordUseCase *remove_password_use_case.RemovePasswordUseCase,
+	resolveLinkedIdentityUseCase *resolve_linked_identity_use_case.ResolveLinkedIdentityUseCase,
+	userReader userPorts.UserReader,
+) *LinkedIdentityHandlers {
+	return &LinkedIdentityHandlers{
+		getCredentialsUseCase:        getCredentialsUseCase,
+		getMemberCredentialsUseCase:  getMemberCredentialsUseCase,
+		linkIdentityUseCase:          linkIdentityUseCase,
+		unlinkIdentityUseCase:        unlinkIdentityUseCase,
+		removePasswordUseCase:        removePasswordUseCase,
+		resolveLinkedIdentityUseCase: resolveLinkedIdentityUseCase,
+		userReader:                   userReader,
+	}
+}
+
+func (h *LinkedIdentityHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-linked-identities",
+		Method:      http.MethodGet,
+		Path:        "/auth/identities",
+		Summary:     "List the ways you sign in",
+		Description: "Whether you have a password and the Google and SAML identities linked to your account.",
+		Tags:        []string{"Auth"},
+	}, h.ListLinkedIdentities)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "link-identity",
+		Method:        http.MethodPost,
+		Path:          "/auth/identities",
+		Summary:       "Link an external identity to your account",
+		Description:   "Called once you completed the sign in flow of the provider, with the ID token it issued. The token is verified with the keys of the provider and the identity it was issued to is linked. An identity is linked to a single account.",
+		Tags:          []string{"Auth"},
+		DefaultStatus: http.StatusCreated,
+	}, h.LinkIdentity)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "unlink-identity",
+		Method:        http.MethodDelete,
+		Path:          "/auth/identities/{identityId}",
+		Summary:       "Unlink an external identity from your account",
+		Description:   "Refused with LAST_CREDENTIAL when it is the only way you have left to sign in.",
+		Tags:          []string{"Auth"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.UnlinkIdentity)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "remove-password",
+		Method:        http.MethodDelete,
+		Path:          "/auth/password",
+		Summary:       "Remove your password",
+		Description:   "You sign in through your linked identities only afterwards. Refused with LAST_CREDENTIAL when you have none.",
+		Tags:          []string{"Auth"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.RemovePassword)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "resolve-linked-identity",
+		Method:      http.MethodPost,
+		Path:        "/auth/identities/resolve",
+		Summary:     "Find the user of an external identity",
+		Description: "Used by the gateway to sign in users through Google or SAML, like verify-password for passwords. Identities nobody linked are refused.",
+		Tags:        []string{"Auth"},
+	}, h.ResolveLinkedIdentity)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-user-identities",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/identities",
+		Summary:     "List the ways a member signs in",
+		Description: "Whether the member has a password and the identities linked to the account. Only members of the tenant are found.",
+		Tags:        []string{"Users"},
+	}, h.ListUserIdentities)
+}
+
+func (h *LinkedIdentityHandlers) ListLinkedIdentities(ctx context.Context, input *struct{}) (*CredentialsEnvelope, error) {
+	command, err := get_credentials_use_case.NewGetCredentialsCommand(authorization.UserIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	credentials, err := h.getCredentialsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &CredentialsEnvelope{Body: NewCredentialsResponse(credentials, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *LinkedIdentityHandlers) LinkIdentity(ctx context.Context, input *LinkIdentityRequest) (*LinkedIdentityEnvelope, error) {
+	command, err := link_identity_use_case.NewLinkIdentityCommand(authorization.UserIDFromContext(ctx), input.Body.Provider, input.Body.Token)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	identity, err := h.linkIdentityUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &LinkedIdentityEnvelope{Body: NewLinkedIdentityResponse(identity, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *LinkedIdentityHandlers) UnlinkIdentity(ctx context.Context, input *LinkedIdentityPathRequest) (*struct{}, error) {
+	command, err := unlink_identity_use_case.NewUnlinkIdentityCommand(authorization.UserIDFromContext(ctx), input.IdentityID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.unlinkIdentityUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
+
+func (h *LinkedIdentityHandlers) RemovePassword(ctx context.Context, input *struct{}) (*struct{}, error) {
+	command, err := remove_password_use_case.NewRemovePasswordCommand(authorization.UserIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.removePasswordUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
+
+func (h *LinkedIdentityHandlers) ResolveLinkedIdentity(ctx context.Context, input *ResolveLinkedIdentityRequest) (*ResolveLinkedIdentityResponse, error) {
+	command, err := resolve_linked_identity_use_case.NewResolveLinkedIdentityCommand(input.Body.Provider, input.Body.Issuer, input.Body.Subject)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	identity, err := h.resolveLinkedIdentityUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	users, err := h.userReader.FindByIDs([]string{identity.UserID})
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+	if len(users) == 0 {
+		return nil, utils.ApplicationErrorToHumaError(appErrors.NewUnauthorizedError("Unknown identity"))
+	}
+
+	return &ResolveLinkedIdentityResponse{Body: userHandlers.NewUserResponse(users[0])}, nil
+}
+
+func (h *LinkedIdentityHandlers) ListUserIdentities(ctx context.Context, input *UserCredentialsPathRequest) (*CredentialsEnvelope, error) {
+	command, err := get_member_credentials_use_case.NewGetMemberCredentialsCommand(authorization.TenantIDFromContext(ctx), input.UserID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	credentials, err := h.getMemberCredentialsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &CredentialsEnvelope{Body: NewCredentialsResponse(credentials, utils.LocationFromContext(ctx))}, nil
+}
@@ -0,0 +1,44 @@
+-- An empty password hash means the user signs in through linked identities only
+-- name: GetPasswordState :one
+SELECT password_hash <> '' AS has_password
+FROM users
+WHERE id = @id;
+
+-- name: GetMemberPasswordState :one
+SELECT u.password_hash <> '' AS has_password
+FROM users u
+WHERE u.id = @user_id
+  AND EXISTS (SELECT 1 FROM casbin_rule c WHERE c.ptype = 'g' AND c.v0 = u.id::text AND c.v2 = @tenant_id::text);
+
+-- name: ListLinkedIdentities :many
+SELECT * FROM linked_identities
+WHERE user_id = @user_id
+ORDER BY linked_at, id;
+
+-- name: LinkIdentity :execrows
+INSERT INTO linked_identities (id, user_id, provider, issuer, subject, email, linked_at)
+VALUES (@id, @user_id, @provider, @issuer, @subject, sqlc.narg('email'), @linked_at)
+ON CONFLICT ON CONSTRAINT linked_identities_subject_key DO NOTHING;
+
+-- Locks the user so the credentials counted stay the same until the removal commits
+-- name: LockUserCredentials :one
+SELECT password_hash <> '' AS has_password,
+       (SELECT count(*) FROM linked_identities i WHERE i.user_id = users.id) AS identities
+FROM users
+WHERE users.id = @id
+FOR UPDATE;
+
+-- name: DeleteLinkedIdentity :execrows
+DELETE FROM linked_identities
+WHERE id = @id AND user_id = @user_id;
+
+-- name: ClearPasswordHash :exec
+UPDATE users
+SET password_hash = '', updated_at = NOW()
+WHERE id = @id;
+
+-- name: TouchLinkedIdentity :one
+UPDATE linked_identities
+SET last_used_at = @used_at
+WHERE provider = @provider AND issuer = @issuer AND subject = @subject
+RETURNING *;
@@ -0,0 +1,15 @@
+-- Accounts of external providers users sign in with through the gateway, next to the password in
+-- users. An identity is linked to a single user, issuer tells apart providers of a kind.
+CREATE TABLE linked_identities (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    provider VARCHAR(20) NOT NULL,         -- google, saml
+    issuer VARCHAR(255) NOT NULL,          -- e.g. https://accounts.google.com or the entity ID of the IdP
+    subject VARCHAR(255) NOT NULL,         -- ID of the user at the provider
+    email VARCHAR(255),
+    linked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT linked_identities_subject_key UNIQUE (provider, issuer, subject)
+);
+
+CREATE INDEX idx_linked_identities_user_id ON linked_identities(user_id, linked_at);
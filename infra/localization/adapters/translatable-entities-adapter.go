@@ -0,0 +1,45 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/localization/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresTranslatableEntities reads the tables of the library and surveys modules
+type PostgresTranslatableEntities struct {
+	queries *db.Queries
+}
+
+func NewPostgresTranslatableEntities(dbInstance *pgxpool.Pool) ports.TranslatableEntities {
+	return &PostgresTranslatableEntities{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (e *PostgresTranslatableEntities) Exists(ctx context.Context, tenantID string, entityType entities.EntityType, entityID string) (bool, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(entityID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	var exists bool
+	var err error
+	switch entityType {
+	case entities.EntityTypeResource:
+		exists, err = e.queries.ResourceExists(ctx, db.ResourceExistsParams{TenantID: tenantID, ID: pgID})
+	case entities.EntityTypeForm:
+		exists, err = e.queries.FormExists(ctx, db.FormExistsParams{TenantID: tenantID, ID: pgID})
+	}
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,130 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/localization/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTranslationRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresTranslationRepository(dbInstance *pgxpool.Pool) ports.TranslationRepository {
+	return &PostgresTranslationRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresTranslationRepository) Save(ctx context.Context, translation *entities.Translation) error {
+	var pgEntityID pgtype.UUID
+	if err := pgEntityID.Scan(translation.EntityID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	fields, err := json.Marshal(translation.Fields)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err = r.queries.UpsertTranslation(ctx, db.UpsertTranslationParams{
+		TenantID:   translation.TenantID,
+		EntityType: string(translation.EntityType),
+		EntityID:   pgEntityID,
+		Locale:     string(translation.Locale),
+		Fields:     fields,
+		UpdatedBy:  translation.UpdatedBy,
+		UpdatedAt:  pgtype.Timestamptz{Time: translation.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresTranslationRepository) Delete(ctx context.Context, tenantID string, entityType entities.EntityType, entityID string,
+	locale entities.Locale) (bool, error) {
+	var pgEntityID pgtype.UUID
+	if err := pgEntityID.Scan(entityID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	deleted, err := r.queries.DeleteTranslation(ctx, db.DeleteTranslationParams{
+		TenantID:   tenantID,
+		EntityType: string(entityType),
+		EntityID:   pgEntityID,
+		Locale:     string(locale),
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return deleted > 0, nil
+}
+
+func (r *PostgresTranslationRepository) ListByEntity(ctx context.Context, tenantID string, entityType entities.EntityType,
+	entityID string) ([]*entities.Translation, error) {
+	var pgEntityID pgtype.UUID
+	if err := pgEntityID.Scan(entityID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListEntityTranslations(ctx, db.ListEntityTranslationsParams{
+		TenantID:   tenantID,
+		EntityType: string(entityType),
+		EntityID:   pgEntityID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return toTranslations(rows)
+}
+
+func (r *PostgresTranslationRepository) ListByEntities(ctx context.Context, tenantID string, entityType entities.EntityType,
+	entityIDs []string, locales []entities.Locale) ([]*entities.Translation, error) {
+	pgEntityIDs := make([]pgtype.UUID, len(entityIDs))
+	for i, entityID := range entityIDs {
+		if err := pgEntityIDs[i].Scan(entityID); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+	}
+	localeValues := make([]string, len(locales))
+	for i, locale := range locales {
+		localeValues[i] = string(locale)
+	}
+
+	rows, err := r.queries.ListTranslationsForEntities(ctx, db.ListTranslationsForEntitiesParams{
+		TenantID:   tenantID,
+		EntityType: string(entityType),
+		EntityIds:  pgEntityIDs,
+		Locales:    localeValues,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return toTranslations(rows)
+}
+
+func toTranslations(rows []db.Translation) ([]*entities.Translation, error) {
+	translations := make([]*entities.Translation, 0, len(rows))
+	for _, row := range rows {
+		var fields map[string]string
+		if err := json.Unmarshal(row.Fields, &fields); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+
+		translation, err := entities.NewTranslation(row.TenantID, entities.EntityType(row.EntityType), row.EntityID.String(),
+			entities.Locale(row.Locale), fields, row.UpdatedBy, row.UpdatedAt.Time)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		translations = append(translations, translation)
+	}
+	return translations, nil
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+)
+
+type EntityRequest struct {
+	EntityType string `path:"entityType" enum:"resource,form"`
+	EntityID   string `path:"entityId" format:"uuid"`
+}
+
+type TranslationRequest struct {
+	EntityType string `path:"entityType" enum:"resource,form"`
+	EntityID   string `path:"entityId" format:"uuid"`
+	Locale     string `path:"locale" maxLength:"35" example:"es-GT" doc:"BCP 47 language tag"`
+}
+
+type SetTranslationRequest struct {
+	EntityType string `path:"entityType" enum:"resource,form"`
+	EntityID   string `path:"entityId" format:"uuid"`
+	Locale     string `path:"locale" maxLength:"35" example:"es-GT" doc:"BCP 47 language tag"`
+	Body       struct {
+		Fields map[string]string `json:"fields" minProperties:"1" maxProperties:"200" doc:"Translated value by field. Resources translate title and description, forms also prompt:<question id>."`
+	}
+}
+
+type TranslationResponse struct {
+	Locale    string            `json:"locale"`
+	Fields    map[string]string `json:"fields"`
+	UpdatedBy string            `json:"updated_by"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+func NewTranslationResponse(translation *entities.Translation) TranslationResponse {
+	return TranslationResponse{
+		Locale:    string(translation.Locale),
+		Fields:    translation.Fields,
+		UpdatedBy: translation.UpdatedBy,
+		UpdatedAt: translation.UpdatedAt,
+	}
+}
+
+type TranslationEnvelope struct {
+	Body TranslationResponse
+}
+
+type TranslationListResponse struct {
+	Body struct {
+		Items []TranslationResponse `json:"items"`
+	}
+}
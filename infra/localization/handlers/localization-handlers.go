@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	delete_translation_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/delete-translation-use-case"
+	list_translations_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/list-translations-use-case"
+	set_translation_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/set-translation-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type LocalizationHandlers struct {
+	setTranslationUseCase    *set_translation_use_case.SetTranslationUseCase
+	deleteTranslationUseCase *delete_translation_use_case.DeleteTranslationUseCase
+	listTranslationsUseCase  *list_translations_use_case.ListTranslationsUseCase
+}
+
+func NewLocalizationHandlers(
+	setTranslationUseCase *set_translation_use_case.SetTranslationUseCase,
+	deleteTranslationUseCase *delete_translation_use_case.DeleteTranslationUseCase,
+	listTranslationsUseCase *list_translations_use_case.ListTranslationsUseCase,
+) *LocalizationHandlers {
+	return &LocalizationHandlers{
+		setTranslationUseCase:    setTranslationUseCase,
+		deleteTranslationUseCase: deleteTranslationUseCase,
+		listTranslationsUseCase:  listTranslationsUseCase,
+	}
+}
+
+func (h *LocalizationHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-translations",
+		Method:      http.MethodGet,
+		Path:        "/translations/{entityType}/{entityId}",
+		Summary:     "List the translations of a resource or form",
+		Tags:        []string{"Localization"},
+	}, h.ListTranslations)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-translation",
+		Method:      http.MethodPut,
+		Path:        "/translations/{entityType}/{entityId}/{locale}",
+		Summary:     "Create or replace the translation of a resource or form in a locale",
+		Description: "Readers get it through Accept-Language, es-GT falls back to es and then to the content as written.",
+		Tags:        []string{"Localization"},
+	}, h.SetTranslation)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-translation",
+		Method:        http.MethodDelete,
+		Path:          "/translations/{entityType}/{entityId}/{locale}",
+		Summary:       "Delete the translation of a resource or form in a locale",
+		Tags:          []string{"Localization"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.DeleteTranslation)
+}
+
+func (h *LocalizationHandlers) ListTranslations(ctx context.Context, input *EntityRequest) (*TranslationListResponse, error) {
+	command, err := list_translations_use_case.NewListTranslationsCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.EntityType,
+		input.EntityID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	translations, err := h.listTranslationsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &TranslationListResponse{}
+	response.Body.Items = make([]TranslationResponse, 0, len(translations))
+	for _, translation := range translations {
+		response.Body.Items = append(response.Body.Items, NewTranslationResponse(translation))
+	}
+	return response, nil
+}
+
+func (h *LocalizationHandlers) SetTranslation(ctx context.Context, input *SetTranslationRequest) (*TranslationEnvelope, error) {
+	command, err := set_translation_use_case.NewSetTranslationCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.EntityType,
+		input.EntityID,
+		input.Locale,
+		input.Body.Fields,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	translation, err := h.setTranslationUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &TranslationEnvelope{Body: NewTranslationResponse(translation)}, nil
+}
+
+func (h *LocalizationHandlers) DeleteTranslation(ctx context.Context, input *TranslationRequest) (*struct{}, error) {
+	command, err := delete_translation_use_case.NewDeleteTranslationCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.EntityType,
+		input.EntityID,
+		input.Locale,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.deleteTranslationUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+	return nil, nil
+}
@@ -0,0 +1,30 @@
+-- name: UpsertTranslation :exec
+INSERT INTO translations (tenant_id, entity_type, entity_id, locale, fields, updated_by, updated_at)
+VALUES (@tenant_id, @entity_type, @entity_id, @locale, @fields, @updated_by, @updated_at)
+ON CONFLICT (tenant_id, entity_type, entity_id, locale) DO UPDATE SET
+    fields = EXCLUDED.fields,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteTranslation :execrows
+DELETE FROM translations
+WHERE tenant_id = @tenant_id AND entity_type = @entity_type AND entity_id = @entity_id AND locale = @locale;
+
+-- name: ListEntityTranslations :many
+SELECT tenant_id, entity_type, entity_id, locale, fields, updated_by, updated_at
+FROM translations
+WHERE tenant_id = @tenant_id AND entity_type = @entity_type AND entity_id = @entity_id
+ORDER BY locale;
+
+-- name: ListTranslationsForEntities :many
+SELECT tenant_id, entity_type, entity_id, locale, fields, updated_by, updated_at
+FROM translations
+WHERE tenant_id = @tenant_id AND entity_type = @entity_type
+  AND entity_id = ANY(@entity_ids::uuid[])
+  AND locale = ANY(@locales::varchar[]);
+
+-- name: ResourceExists :one
+SELECT EXISTS (SELECT 1 FROM resources WHERE tenant_id = @tenant_id AND id = @id);
+
+-- name: FormExists :one
+SELECT EXISTS (SELECT 1 FROM forms WHERE tenant_id = @tenant_id AND id = @id);
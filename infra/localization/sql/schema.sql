@@ -0,0 +1,12 @@
+-- Translations of tenant content, one row per entity and locale. The content as written stays in
+-- the table of its module and is what readers fall back to.
+CREATE TABLE translations (
+    tenant_id VARCHAR(255) NOT NULL,
+    entity_type VARCHAR(20) NOT NULL,       -- resource, form
+    entity_id UUID NOT NULL,
+    locale VARCHAR(35) NOT NULL,            -- BCP 47 tag, es or es-GT
+    fields JSONB NOT NULL,                  -- translated value by field name
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, entity_type, entity_id, locale)
+);
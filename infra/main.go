@@ -2,49 +2,580 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
-	"os/signal"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
 	"strings"
-	"syscall"
 	"time"
+	// Embeds the zone database, time zone preferences must load in images without one
+	_ "time/tzdata"
 
+	accessibilityPorts "github.com/nahualventure/class-backend/core/app/accessibility/domain/ports"
+	analyticsEntities "github.com/nahualventure/class-backend/core/app/analytics/domain/entities"
+	billingPorts "github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	deliverabilityEntities "github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	deliverabilityPorts "github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	enrollmentPorts "github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	jobFailurePorts "github.com/nahualventure/class-backend/core/app/jobfailure/domain/ports"
+	linkedIdentityEntities "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/entities"
+	linkedIdentityPorts "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/ports"
+	ensure_partitions_use_case "github.com/nahualventure/class-backend/core/app/partitioning/application/use-cases/ensure-partitions-use-case"
+	presencePorts "github.com/nahualventure/class-backend/core/app/presence/domain/ports"
+	previewPorts "github.com/nahualventure/class-backend/core/app/preview/domain/ports"
+	scanningPorts "github.com/nahualventure/class-backend/core/app/scanning/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/encryption"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	coreUtils "github.com/nahualventure/class-backend/core/app/shared/utils"
+	"github.com/nahualventure/class-backend/core/app/shared/webhook"
+	similarityPorts "github.com/nahualventure/class-backend/core/app/similarity/domain/ports"
+	warehousePorts "github.com/nahualventure/class-backend/core/app/warehouse/domain/ports"
+	accessibilityAdapters "github.com/nahualventure/class-backend/infra/accessibility/adapters"
+	auditHandlers "github.com/nahualventure/class-backend/infra/audit/handlers"
+	billingAdapters "github.com/nahualventure/class-backend/infra/billing/adapters"
+	"github.com/nahualventure/class-backend/infra/configs"
+	deliverabilityAdapters "github.com/nahualventure/class-backend/infra/deliverability/adapters"
+	enrollmentAdapters "github.com/nahualventure/class-backend/infra/enrollment/adapters"
+	jobFailureAdapters "github.com/nahualventure/class-backend/infra/jobfailure/adapters"
+	linkedIdentityAdapters "github.com/nahualventure/class-backend/infra/linkedidentity/adapters"
+	moduleToggleHandlers "github.com/nahualventure/class-backend/infra/moduletoggle/handlers"
+	partitioningAdapters "github.com/nahualventure/class-backend/infra/partitioning/adapters"
+	presenceAdapters "github.com/nahualventure/class-backend/infra/presence/adapters"
+	previewAdapters "github.com/nahualventure/class-backend/infra/preview/adapters"
+	scanningAdapters "github.com/nahualventure/class-backend/infra/scanning/adapters"
+	serviceAccountHandlers "github.com/nahualventure/class-backend/infra/serviceaccount/handlers"
+	sharedAdapters "github.com/nahualventure/class-backend/infra/shared/adapters"
+	"github.com/nahualventure/class-backend/infra/shared/apichangelog"
 	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/canary"
+	"github.com/nahualventure/class-backend/infra/shared/clientversion"
+	"github.com/nahualventure/class-backend/infra/shared/container"
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+	"github.com/nahualventure/class-backend/infra/shared/deprecation"
+	"github.com/nahualventure/class-backend/infra/shared/diagnostics"
+	"github.com/nahualventure/class-backend/infra/shared/grpcclient"
+	"github.com/nahualventure/class-backend/infra/shared/grpcserver"
+	"github.com/nahualventure/class-backend/infra/shared/internalauth"
+	"github.com/nahualventure/class-backend/infra/shared/ops"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+	"github.com/nahualventure/class-backend/infra/shared/redis"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/residency"
+	"github.com/nahualventure/class-backend/infra/shared/scaffold"
+	"github.com/nahualventure/class-backend/infra/shared/slo"
+	"github.com/nahualventure/class-backend/infra/shared/strictvalidation"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	similarityAdapters "github.com/nahualventure/class-backend/infra/similarity/adapters"
+	storageQuotaAdapters "github.com/nahualventure/class-backend/infra/storagequota/adapters"
+	warehouseAdapters "github.com/nahualventure/class-backend/infra/warehouse/adapters"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humagin"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 )
 
 func main() {
+	replicas := flag.Int("replicas", 1, "instances the deployment runs, startup fails when more than one and the configuration assumes a single instance")
+	flag.Parse()
+	args := flag.Args()
+
+	// "gen <module> <Entity>" scaffolds a module from the templates and exits, it needs no configuration
+	if len(args) == 3 && args[0] == "gen" {
+		spec := scaffold.Spec{Module: args[1], Entity: args[2]}
+		written, err := scaffold.Generate(".", spec)
+		if err != nil {
+			log.Fatalf("Failed to scaffold module %s: %v", args[1], err)
+		}
+		for _, path := range written {
+			log.Printf("Wrote %s", path)
+		}
+		fmt.Print(scaffold.NextSteps(spec))
+		return
+	}
+
+	// "verify-audit-export <file>" checks an audit export offline and exits, it needs no configuration
+	if len(args) == 2 && args[0] == "verify-audit-export" {
+		file, err := os.Open(args[1])
+		if err != nil {
+			log.Fatalf("Failed to open audit export: %v", err)
+		}
+		defer file.Close()
+		verifier, err := auditHandlers.VerifyAuditExport(file)
+		if err != nil {
+			log.Fatalf("Failed to verify audit export: %v", err)
+		}
+		if verifier.Break != nil {
+			log.Fatalf("Audit export of %s is broken at entry %d: %s", verifier.TenantID, verifier.Break.Sequence, verifier.Break.Reason)
+		}
+		log.Printf("Audit export of %s verified: %d entries up to %d, head hash %s",
+			verifier.TenantID, verifier.Entries, verifier.LastSequence, verifier.HeadHash)
+		return
+	}
+
+	// Tag logs with the pod and node when running in Kubernetes
+	log.SetPrefix(ops.MetadataFromEnv().LogPrefix())
+
 	// Load configuration
 	config := loadConfig()
+	if err := auditScaling(config).Verify(*replicas); err != nil {
+		log.Fatalf("Scaling audit failed: %v", err)
+	}
+	if config.Pooler.TransactionPooling && config.Pooler.SessionURL == "" &&
+		(config.JobLocksEnabled || config.LeaderElectionEnabled || config.CasbinWatcherEnabled) {
+		log.Fatal("Job locks and the role change watcher need a session, set DATABASE_SESSION_URL with DB_TRANSACTION_POOLING")
+	}
+	coreUtils.SetSchoolEmailDomains(config.SchoolEmailDomains)
+	residencyRouter, err := setupResidency(config)
+	if err != nil {
+		log.Fatalf("Invalid data residency configuration: %v", err)
+	}
+
+	// "migrate-shards [shard...]" migrates every shard of SHARD_DATABASE_URLS, or the ones named, and
+	// exits. It connects to the shards itself.
+	if len(args) >= 1 && args[0] == "migrate-shards" {
+		if err := migrateShards(config, args[1:]); err != nil {
+			log.Fatalf("Failed to migrate shards: %v", err)
+		}
+		return
+	}
+
+	// Subsystems register a health check and a shutdown hook. Readiness and the self-test run the
+	// checks, the lame duck closes them once requests and workers are drained.
+	dependencies := ops.NewDependencies()
+	defer dependencies.Close()
+
+	// Background workers run under the lame duck, which stops them after draining requests on SIGTERM
+	lameDuck := ops.NewLameDuck(dependencies)
 
 	// Setup database connection pool
-	pool, err := setupDatabase(config.DatabaseURL)
+	queryTracer := database.NewQueryTracer(config.QueryTracer)
+	queryTracer.AttributeWith(authorization.TenantIDFromContext)
+	pool, poolQuota, err := setupDatabase(config.DatabaseURL, cost.Trace(queryTracer), config.QueryMode, config.Pooler, config.PoolQuota)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
-	defer pool.Close()
+	// Job locks and the role change watcher hold a session, behind a transaction pooler it comes from DATABASE_SESSION_URL
+	sessionConnConfig, err := config.Pooler.SessionConnConfig(pool.Config().ConnConfig)
+	if err != nil {
+		log.Fatalf("Failed to configure session connections: %v", err)
+	}
+	queryTracer.ExplainWith(pool)
+	// The cluster is claimed for the region of the instance, one of another region refuses to start
+	if err := residencyRouter.VerifyCluster(context.Background(), sharedAdapters.NewPostgresClusterRegionStore(pool)); err != nil {
+		log.Fatalf("Failed to verify the region of the database cluster: %v", err)
+	}
+	database.Reads.Configure(config.ReadRetry)
+	if config.CursorKey != "" {
+		pagination.SetCursorKey([]byte(config.CursorKey))
+	}
+	// Reads of GET requests go to the replica once it replayed the writes of their consistency token
+	var replicaRouter *database.ReplicaRouter
+	if config.Replica.URL != "" {
+		replica, err := setupReplica(config.Replica.URL, cost.Trace(queryTracer), config.QueryMode, config.Pooler)
+		if err != nil {
+			log.Fatalf("Failed to connect to the replica database: %v", err)
+		}
+		replicaRouter = database.NewReplicaRouter(pool, replica,
+			database.NewConsistencyTokens([]byte(config.CursorKey), config.Replica.TokenMaxAge), config.Replica)
+		database.Reads.Route(replicaRouter)
+		dependencies.Register(ops.Dependency{
+			Name:  "replica database",
+			Check: replica.Ping,
+			// Reads go to the primary while the replica is down, the instance stays ready
+			SelfTestOnly: true,
+			Close: func() error {
+				replica.Close()
+				return nil
+			},
+		})
+	}
+	dependencies.Register(ops.Dependency{
+		Name:  "database",
+		Check: pool.Ping,
+		Close: func() error {
+			pool.Close()
+			return nil
+		},
+	})
+
+	// Singleton jobs take a Postgres lock when several instances run, otherwise they run right away.
+	// The scheduler starts once every job is added, right before serving.
+	var jobLocks *database.JobLocks
+	if config.JobLocksEnabled || config.LeaderElectionEnabled {
+		jobLocks = database.NewJobLocks(sessionConnConfig, 10*time.Second)
+		dependencies.Register(ops.Dependency{Name: "job locks", Check: jobLocks.Ping, SelfTestOnly: true})
+	}
+	scheduler := ops.NewScheduler(jobLocks, config.LeaderElectionEnabled)
 
 	// Setup authorization service
-	authzService, err := setupAuthorization(pool, config.Tenants)
+	authzService, err := setupAuthorization(pool, sessionConnConfig, config)
 	if err != nil {
 		log.Fatalf("Failed to setup authorization: %v", err)
 	}
-	defer authzService.Close()
+	dependencies.Register(ops.Dependency{Name: "casbin", Check: authzService.Health().Check, Close: authzService.Close})
+	// Every instance refreshes its own copy of the role assignments, it is not a scheduled job
+	if config.AuthzPolicyRefresh > 0 {
+		lameDuck.Go(func(ctx context.Context) {
+			authzService.WatchPolicyStore(ctx, config.AuthzPolicyRefresh, 5*time.Second)
+		})
+	}
+	// Every instance compares what it enforces with its policy file, stale ones show on /metrics
+	if config.AuthzDriftCheck > 0 {
+		lameDuck.Go(func(ctx context.Context) {
+			authzService.WatchPolicyDrift(ctx, config.AuthzDriftCheck)
+		})
+	}
+
+	// Setup column encryption, sensitive columns are encrypted with a data key per tenant when a
+	// master key is set
+	var tenantCipher *encryption.TenantCipher
+	if config.EncryptionMasterKey != "" {
+		masterKey, err := sharedAdapters.NewMasterKeyWrapper(config.EncryptionMasterKey)
+		if err != nil {
+			log.Fatalf("Failed to setup column encryption: %v", err)
+		}
+		tenantCipher = encryption.NewTenantCipher(sharedAdapters.NewPostgresTenantKeyStore(pool), masterKey)
+	} else {
+		log.Println("Column encryption disabled: ENCRYPTION_MASTER_KEY is not set")
+	}
+
+	// "shred-tenant <tenant>" destroys the data key of an offboarded tenant and exits, its encrypted
+	// columns can never be read again, backups included
+	if len(args) == 2 && args[0] == "shred-tenant" {
+		if tenantCipher == nil {
+			log.Fatalf("Failed to shred tenant %s: ENCRYPTION_MASTER_KEY is not set", args[1])
+		}
+		if err := tenantCipher.Shred(context.Background(), args[1]); err != nil {
+			log.Fatalf("Failed to shred tenant %s: %v", args[1], err)
+		}
+		log.Printf("Tenant %s shredded", args[1])
+		return
+	}
+
+	// Setup the adapters and the modules built from them, library versions and message attachments
+	// are kept in the file storage
+	fileStorage, err := sharedAdapters.NewFilesystemStorage(config.FileStorageDir)
+	if err != nil {
+		log.Fatalf("Failed to setup file storage: %v", err)
+	}
+	postgresAdapters := container.NewPostgresAdapters(pool, authzService, fileStorage, tenantCipher, config.WriteBehind)
+	postgresAdapters.IdentityTokens = setupIdentityTokenVerifier(config)
+	postgresAdapters.DeliveryWebhooks = setupDeliveryWebhooks(config)
+	// Provider webhooks are persisted once verified and processed by the worker of the container, the
+	// modules register the sources they serve
+	postgresAdapters.Webhooks = webhook.NewReceiver(sharedAdapters.NewPostgresWebhookDeliveryStore(pool))
+	postgresAdapters.StudentInformationSystem = setupStudentInformationSystem(config)
+	postgresAdapters.IncidentNotifier = setupIncidentNotifier(pool, config)
+	// Uploads are scanned and previewed before they are served, captions, billing, similarity checks
+	// and warehouse exports are disabled until their provider is configured
+	postgresAdapters.VirusScanner = setupVirusScanner(config)
+	postgresAdapters.PreviewRenderer = setupPreviewRenderer()
+	postgresAdapters.CaptionProvider = setupCaptionProvider(config)
+	postgresAdapters.BillingProvider = setupBilling(config)
+	postgresAdapters.SimilarityChecker = setupSimilarityChecker(config)
+	postgresAdapters.WarehouseSink = setupWarehouseSink(config)
+	// The recorded storage usage is reconciled with the files of the file storage, tenant backups
+	// go to a storage of their own
+	if postgresAdapters.StorageInventory, err = storageQuotaAdapters.NewFilesystemStorageInventory(config.FileStorageDir); err != nil {
+		log.Fatalf("Failed to setup storage inventory: %v", err)
+	}
+	if postgresAdapters.BackupStorage, err = sharedAdapters.NewFilesystemStorage(config.BackupStorageDir); err != nil {
+		log.Fatalf("Failed to setup backup storage: %v", err)
+	}
+
+	// Setup Redis, optional while only presence and edit locks use it
+	var redisClient *redis.Client
+	if config.RedisAddr != "" {
+		redisClient = redis.NewClient(config.RedisAddr, config.RedisPassword, 0)
+		dependencies.Register(ops.Dependency{
+			Name: "redis",
+			Check: func(ctx context.Context) error {
+				return redis.Error("PING", redisClient.Ping(ctx).Err())
+			},
+			Close: redisClient.Close,
+		})
+	}
+	postgresAdapters.Presence = presenceStore(redisClient)
+	postgresAdapters.EditLocks = editLockStore(redisClient)
+
+	// Service level objectives per endpoint class, the status page measures the API by its error
+	// budget
+	sloTracker := slo.NewTracker(slo.Objectives, slo.BurnRateAlerts)
+	postgresAdapters.StatusProbe = container.NewStatusProbe(pool, authzService, func() slo.Report { return sloTracker.Report(time.Now()) })
+
+	// Rewrites of a port are rolled out to some tenants first, switches replace the adapter with one
+	// that calls the stable or the candidate implementation by the cohort of the tenant
+	canaryRollouts, err := canary.ParseRollouts(config.CanaryRollouts)
+	if err != nil {
+		log.Fatalf("Invalid CANARY_ROLLOUTS: %v", err)
+	}
+	canaries := canary.NewRouter(canaryRollouts)
+	modules := container.New(postgresAdapters, container.Config{
+		Tenants:                            config.Tenants,
+		Residency:                          residencyRouter,
+		StatusRateLimitPerMinute:           config.StatusRateLimitPerMinute,
+		StatusAdminToken:                   config.StatusAdminToken,
+		DistrictRateLimitPerMinute:         config.DistrictRateLimitPerMinute,
+		DistrictAdminToken:                 config.DistrictAdminToken,
+		DeveloperSandboxRateLimitPerMinute: config.DeveloperSandboxRateLimitPerMinute,
+		AbuseThresholds: analyticsEntities.AbuseThresholds{
+			MaxRequestsPerHour: int64(config.AnalyticsMaxRequestsPerHour),
+			MaxErrorRate:       float64(config.AnalyticsMaxErrorRatePercent) / 100,
+			MinRequests:        20,
+		},
+		WarehousePseudonymKey:       config.WarehousePseudonymKey,
+		ArchiveSchoolYearStartMonth: config.ArchiveSchoolYearStartMonth,
+		BackupInterval:              config.BackupInterval,
+	})
+	for _, name := range canaries.Unswitched() {
+		log.Printf("Canary rollout %s has no switch, every tenant stays on the stable implementation", name)
+	}
+	// Services split off the monolith serve some of the modules, every module is served by default
+	services, err := container.ParseModuleSelection(config.Modules)
+	if err != nil {
+		log.Fatalf("Failed to select the modules to serve: %v", err)
+	}
+
+	// "self-test" runs every dependency check once, including the ones readiness skips, and exits
+	// non-zero when one fails
+	if len(args) == 1 && args[0] == "self-test" {
+		if err := dependencies.SelfTest(context.Background(), os.Stdout); err != nil {
+			log.Fatalf("Self-test failed: %v", err)
+		}
+		log.Println("Self-test passed")
+		return
+	}
+
+	// Admin commands of the modules, such as "ensure-partitions" or "onboard-tenant", run once and
+	// exit, see Container.RunCommand
+	if ran, err := modules.RunCommand(context.Background(), args); err != nil {
+		log.Fatalf("Command %s failed: %v", args[0], err)
+	} else if ran {
+		return
+	}
+
+	// Calls of peer services carry the identity they verified in a signed token
+	internalKeys, err := internalauth.NewKeys(config.InternalAuth)
+	if err != nil {
+		log.Fatalf("Failed to setup internal identity tokens: %v", err)
+	}
+	// Destructive admin operations are refused unsigned once signing keys are set, the signatures
+	// accepted are shared through the database so each is accepted once across instances
+	signingKeys, err := authorization.NewSigningKeys(config.RequestSigningKeys, sharedAdapters.NewPostgresSignatureStore(pool))
+	if err != nil {
+		log.Fatalf("Failed to setup request signing: %v", err)
+	}
+	if signingKeys.Enabled() {
+		lameDuck.Go(signingKeys.Run)
+	}
+	// Connections to peer services, modules split into services of their own call them through it
+	peers, err := grpcclient.NewFactory(config.GRPCClient, internalKeys)
+	if err != nil {
+		log.Fatalf("Failed to setup gRPC clients: %v", err)
+	}
+	dependencies.Register(ops.Dependency{Name: "grpc-peers", Close: peers.Close})
+
+	// Requests over the in-flight limits are shed before they wait on the database pool
+	concurrencyLimiter := ratelimit.NewConcurrencyLimiter(config.MaxInFlightRequests, config.MaxInFlightRequestsPerTenant)
+	concurrencyLimiter.LimitTenants(config.MaxInFlightRequestsByTenant)
+	downloadLimiter := ratelimit.NewDownloadLimiter(config.Downloads)
 
 	// Setup Gin router
 	router := gin.Default()
+	// Forwarded client IPs are only believed from the configured proxies
+	if err := router.SetTrustedProxies(config.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	router.Use(utils.ClientIPMiddleware)
+	// User, tenant, locale, trace ID and client version of every request, Huma and Gin routes alike
+	router.Use(requestmeta.Middleware)
+	router.Use(lameDuck.Middleware())
+	// Requests take database connections as interactive work, bulk endpoints as reports below
+	router.Use(database.InteractiveRequests)
+	if replicaRouter != nil {
+		router.Use(replicaRouter.Middleware)
+		lameDuck.Go(replicaRouter.Watch)
+	}
+	// Queries, cache lookups and calls to other services of every request, per operation in /metrics
+	costAccounting := cost.NewAccounting(config.DebugCostToken)
+	router.Use(costAccounting.Middleware)
+	// Readiness probe, it fails while the instance drains before a deploy replaces it
+	router.GET("/ready", lameDuck.Readiness)
+	// Recent server errors with their cause chains, for on-call debugging without shipping logs
+	errorRecorder := diagnostics.NewErrorRecorder(config.DebugErrorsCapacity)
+	if config.DebugErrorsToken != "" {
+		router.GET("/debug/errors/recent", errorRecorder.Handler(config.DebugErrorsToken))
+	}
+	// What the authorization service of the instance has loaded and whether its role store answers
+	if config.DebugAuthzToken != "" {
+		debugAuthz := router.Group("/debug/authz", diagnostics.RequireToken(config.DebugAuthzToken))
+		debugAuthz.GET("", authzService.SummaryHandler)
+		debugAuthz.GET("/policies", authzService.PoliciesHandler)
+		// A new policy file is previewed against what the instance enforces before it is applied
+		debugAuthz.GET("/preview", authzService.PreviewHandler)
+		debugAuthz.POST("/preview", authzService.PreviewHandler)
+		debugAuthz.POST("/apply", authzService.ApplyHandler)
+	}
+	// Service level objectives per endpoint class, /slo gates deploys on the error budget
+	router.GET("/slo", sloTracker.Handler)
+	// A spike of errors stores the state of the instance and links it in the alert, disabled unless a
+	// store is configured
+	incidentStore, incidentLocation := setupIncidentBundles(config)
+	if incidentStore != nil {
+		bundler := diagnostics.NewIncidentBundler(diagnostics.BundleSources{
+			Instance: ops.MetadataFromEnv().Pod,
+			Errors:   errorRecorder,
+			Pools:    func() any { return poolStats(pool, poolQuota) },
+			Authorization: func(ctx context.Context) (any, error) {
+				summary, err := authzService.Summary(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return summary, nil
+			},
+			Config: diagnostics.RedactConfig(config),
+		}, incidentStore, incidentLocation, config.IncidentWebhookURL)
+		lameDuck.Go(func(ctx context.Context) {
+			bundler.Watch(ctx, func() slo.Report { return sloTracker.Report(time.Now()) }, 30*time.Second)
+		})
+	}
+	// Deprecated endpoints announce their sunset and log who still calls them, once a day per caller
+	deprecations := deprecation.NewTracker(deprecation.Endpoints, 24*time.Hour)
+	// Requests are counted per client app version, apps older than the minimum of their platform
+	// are told to update
+	minClientVersions, err := clientversion.ParseMinimums(config.MinClientVersions)
+	if err != nil {
+		log.Fatalf("Invalid MIN_CLIENT_VERSIONS: %v", err)
+	}
+	clientVersions := clientversion.NewEnforcer(minClientVersions)
+	if clientVersions.Enforcing() {
+		utils.AddCommonErrors(appErrors.UpgradeRequired)
+	}
+	if residencyRouter.Routing() {
+		utils.AddCommonErrors(appErrors.TenantInOtherRegion)
+	}
+	// Query metrics keyed by sqlc query name, in the Prometheus text format
+	router.GET("/metrics", func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain; version=0.0.4")
+		if err := queryTracer.WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+		if err := concurrencyLimiter.WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+		if err := poolQuota.WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+		if err := database.Reads.WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+		if replicaRouter != nil {
+			if err := replicaRouter.WriteMetrics(c.Writer); err != nil {
+				log.Printf("Failed to write metrics: %v", err)
+			}
+		}
+		if postgresAdapters.ReadReceipts != nil {
+			if err := postgresAdapters.ReadReceipts.WriteMetrics(c.Writer); err != nil {
+				log.Printf("Failed to write metrics: %v", err)
+			}
+		}
+		if err := authzService.Health().WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+		if err := authzService.PolicyDrift().WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+		if err := deprecations.WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+		if err := sloTracker.WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+		if err := clientVersions.WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+		if err := residencyRouter.WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+		if err := canaries.WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+		if err := costAccounting.WriteMetrics(c.Writer); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+	})
+	// Cohorts of the canary rollouts compared, /canary stops rollouts whose canary regressed
+	router.GET("/canary", canaries.Handler)
 
 	// Setup Huma API with Gin adapter
-	humaConfig := huma.DefaultConfig("Class Backend API", "1.0.0")
+	humaConfig := huma.DefaultConfig("Class Backend API", apichangelog.Version)
 	humaConfig.Info.Description = "A Go-based backend system with clean architecture and RBAC authorization"
+	// Validation errors raised by Huma use the application error envelope, the spec documents it
+	huma.NewError = utils.NewHumaError
+	humaConfig.Transformers = append(humaConfig.Transformers, utils.LocalizeErrors)
+	// Fields tagged with a visibility are left out of the responses of callers without its permission
+	redactor := authorization.NewRedactor(authzService)
+	humaConfig.Transformers = append(humaConfig.Transformers, redactor.Transformer)
+	if config.DebugErrorsToken != "" || incidentStore != nil {
+		humaConfig.Transformers = append(humaConfig.Transformers, errorRecorder.Transformer)
+	}
+	// Tenants get the spec without the operations of their disabled modules, the spec routes are
+	// registered by humagin.New so the filter goes first
+	operationModules := container.NewOperationModules()
+	specFilter := moduleToggleHandlers.NewSpecFilter(modules.DisabledModules, operationModules.Module, humaConfig.OpenAPIPath)
+	router.Use(specFilter.Middleware)
 	api := humagin.New(router, humaConfig)
+	specFilter.Filter(api.OpenAPI())
+	api.OpenAPI().OnAddOperation = append(api.OpenAPI().OnAddOperation,
+		operationModules.OnAddOperation, moduleToggleHandlers.DocumentOperation(operationModules.Module),
+		utils.DocumentOperation(authzService.EndpointAccess().IsPublic), deprecations.DocumentOperation)
+	api.UseMiddleware(sloTracker.Middleware)
+	api.UseMiddleware(costAccounting.OperationMiddleware)
+	api.UseMiddleware(database.NewReportsMiddleware(func(operationID string) bool { return slo.BulkOperations[operationID] }))
+	api.UseMiddleware(deprecations.Middleware)
+	api.UseMiddleware(clientVersions.Middleware)
+	// Requests for tenants whose data lives in another region are sent to the deployment of that region
+	api.UseMiddleware(residencyRouter.Middleware)
+	// Requests over the in-flight limits are shed before any of them looks up a key or a permission
+	api.UseMiddleware(ratelimit.NewConcurrencyMiddleware(concurrencyLimiter))
+	// Requests of peer services act as the user their identity token vouches for
+	api.UseMiddleware(internalauth.NewMiddleware(internalKeys))
+	// Requests sent with a service account key act as the account, every middleware after it sees it
+	api.UseMiddleware(serviceAccountHandlers.NewServiceAccountMiddleware(modules.AuthenticateServiceAccount))
+	// Records the permission checks of requests for the activity timeline, flushed every 5 seconds
+	api.UseMiddleware(modules.ActivityRecorder.Middleware)
+	api.UseMiddleware(authorization.NewAuthorizationMiddleware(authzService))
+	// Sensitive operations need the user to have signed in recently, see step_up in policies.yaml
+	api.UseMiddleware(authorization.NewStepUpMiddleware(authzService.StepUp()))
+	// Destructive admin operations also need the request signed with a signing key, see signing in
+	// policies.yaml
+	api.UseMiddleware(authorization.NewSigningMiddleware(authzService.Signing(), signingKeys))
+	// Operations of the modules disabled for the tenant are refused with MODULE_DISABLED
+	api.UseMiddleware(authorization.NewModuleMiddleware(operationModules.Module, modules.CheckModuleEnabled))
+	// Downloads are limited per verified user and tenant, their bandwidth is paced as they are written
+	api.UseMiddleware(ratelimit.NewDownloadMiddleware(downloadLimiter))
+	// Counts the requests of each consumer, flushed to Postgres every minute by every instance
+	api.UseMiddleware(modules.UsageCollector.Middleware)
+	api.UseMiddleware(utils.DataLoaderMiddleware)
+	// Identities, time zones, plan gates and storage quotas of the modules
+	modules.UseMiddlewares(api)
+
+	// Strict mode also rejects unknown query parameters and body fields in another casing, added last
+	// so callers without access are refused before their request is validated
+	if config.StrictRequestValidation {
+		strictvalidation.Enable(api)
+	}
 
 	type HealthResponse struct {
 		Body struct {
@@ -54,10 +585,11 @@ func main() {
 	}
 
 	huma.Register(api, huma.Operation{
-		Method:  http.MethodGet,
-		Path:    "/health",
-		Summary: "Health endpoint",
-		Tags:    []string{"Health"},
+		OperationID: "health",
+		Method:      http.MethodGet,
+		Path:        "/health",
+		Summary:     "Health endpoint",
+		Tags:        []string{"Health"},
 	}, func(ctx context.Context, i *struct{}) (*HealthResponse, error) {
 		return &HealthResponse{
 			Body: struct {
@@ -69,18 +601,74 @@ func main() {
 			},
 		}, nil
 	})
-	// TODO: Register routes here
-	// registerAuthRoutes(api, pool, authzService)
 
-	// Setup graceful shutdown
-	setupGracefulShutdown()
+	// The changelog compares the spec served with the snapshots of the versions released before it
+	changelog := apichangelog.NewChangelog(api)
+	changelog.RegisterRoutes(api)
+
+	// Register module routes, the container builds the modules of the instance. Modules whose optional
+	// configuration is not set are not registered.
+	modules.RegisterRoutesOf(api, services, operationModules)
+	modules.RegisterWebSocketsOf(router, services)
+
+	// "api-snapshot" records the API of this version for the changelog of the next one and exits, run
+	// it with every optional module configured so their endpoints are part of the snapshot
+	if len(args) == 1 && args[0] == "api-snapshot" {
+		if !services.All() {
+			log.Fatalf("Record the API with every module, SERVICE_MODULES selects %v", services.Names())
+		}
+		file, err := changelog.WriteSnapshot(apichangelog.SnapshotDir)
+		if err != nil {
+			log.Fatalf("Failed to record the API of version %s: %v", apichangelog.Version, err)
+		}
+		log.Printf("Recorded the API of version %s in %s", apichangelog.Version, file)
+		return
+	}
+
+	// Services split off the monolith refuse the operations of the modules they do not serve, like
+	// unmapped ones, so the policies of the other modules grant nothing here
+	if !services.All() {
+		served := make(map[string]bool)
+		for _, item := range api.OpenAPI().Paths {
+			for _, operation := range []*huma.Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+				if operation != nil {
+					served[operation.OperationID] = true
+				}
+			}
+		}
+		authzService.EndpointAccess().Retain(func(operationID string) bool { return served[operationID] })
+		log.Printf("Serving the modules %v", services.Names())
+	}
+
+	// Workers of the modules run under the lame duck, their singleton jobs start with the scheduler
+	modules.StartWorkers(services, lameDuck, scheduler)
+
+	// gRPC serves the same use cases and permissions, it stops once HTTP requests are drained
+	grpcListener, err := net.Listen("tcp", ":"+config.GRPCPort)
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC: %v", err)
+	}
+	grpcOptions := grpcserver.Options{Keys: internalKeys}
+	if config.GRPCClient.TLS.Enabled() {
+		if grpcOptions.Credentials, err = config.GRPCClient.TLS.ServerCredentials(); err != nil {
+			log.Fatalf("Failed to setup gRPC TLS: %v", err)
+		}
+	}
+	grpcServer := grpcserver.New(authzService, grpcOptions, modules.GRPCServicesOf(services, redactor)...)
+	lameDuck.Go(func(ctx context.Context) {
+		grpcserver.Serve(ctx, grpcServer, grpcListener, config.Shutdown.GracePeriod)
+	})
 
 	log.Println("Server started successfully!")
 	log.Printf("HTTP API: http://localhost:%s", config.HTTPPort)
+	log.Printf("gRPC API: localhost:%s", config.GRPCPort)
 	log.Printf("API Documentation: http://localhost:%s/docs", config.HTTPPort)
 
-	// Start HTTP server
-	if err := router.Run(":" + config.HTTPPort); err != nil {
+	lameDuck.Go(scheduler.Run)
+
+	// Start HTTP server, it drains on SIGTERM before returning
+	server := &http.Server{Addr: ":" + config.HTTPPort, Handler: router}
+	if err := lameDuck.ListenAndServe(server, config.Shutdown); err != nil {
 		log.Fatalf("Server failed to start: %v", err)
 	}
 }
@@ -90,6 +678,171 @@ type Config struct {
 	GRPCPort    string
 	HTTPPort    string
 	Tenants     []string
+	// Load balancers and proxies, IPs or CIDRs, whose X-Forwarded-For and X-Real-IP headers are believed
+	TrustedProxies []string
+
+	// Requests in flight above these limits are shed with a 429, 0 disables a limit
+	MaxInFlightRequests          int
+	MaxInFlightRequestsPerTenant int
+	// Per tenant limits replacing MaxInFlightRequestsPerTenant for the tenants listed
+	MaxInFlightRequestsByTenant map[string]int
+	// Downloads of files and reports over the concurrent limits are shed with a 429, the bandwidth
+	// limits slow them down. 0 disables a limit.
+	Downloads ratelimit.DownloadLimits
+
+	// Queries over the slow threshold are logged, plans of repeat offenders too when ExplainAfter is set
+	QueryTracer database.QueryTracerConfig
+	// How queries are sent, cache_describe behind PgBouncer in transaction mode
+	QueryMode database.QueryModeConfig
+	// Shares of the database pool background jobs and reports can hold, requests get the rest
+	PoolQuota database.PoolQuotaConfig
+	// Compatibility with PgBouncer in transaction mode, and the URL of the connections needing a session
+	Pooler database.PoolerConfig
+	// Reads of repositories outside transactions are retried on connection errors, a failover lasts seconds
+	ReadRetry database.ReadRetryConfig
+	// Streaming replica the reads of GET requests go to once it replayed the write of their consistency token
+	Replica database.ReplicaConfig
+	// Region of the cluster of DATABASE_URL, empty serves every tenant from it. Tenants are pinned to
+	// regions with tenant=region entries and the others live in DefaultDataRegion, the deployment of
+	// every region is named with region=url entries.
+	DataRegion        string
+	DefaultDataRegion string
+	TenantRegions     []string
+	RegionEndpoints   []string
+	// Cluster of every region, region=url entries, and how many of them migrate-shards runs at once
+	ShardDatabaseURLs []string
+	Fleet             ops.FleetConfig
+	// Non-critical writes, read receipts, are buffered and saved in batches, a MaxPending of 0 writes them right away
+	WriteBehind database.WriteBehindConfig
+	// Key list cursors are signed with, instances without one sign with a random key of their own
+	CursorKey string
+
+	RedisAddr     string
+	RedisPassword string
+
+	// Warehouse exports go to S3 when a bucket is set, otherwise to WarehouseExportDir when set
+	WarehouseExportDir    string
+	WarehouseS3           warehouseAdapters.S3Config
+	WarehousePseudonymKey string
+
+	// Similarity checks run when a provider URL is set
+	SimilarityProvider similarityAdapters.SimilarityProviderConfig
+
+	// Escalated job failures are emailed to the admins of the tenant, and posted to the webhook and
+	// the Slack incoming webhook when set
+	JobFailureWebhookURL      string
+	JobFailureSlackWebhookURL string
+
+	// Uploads are scanned by the clamd at this address, such as localhost:3310. Without it they are
+	// released unscanned.
+	ClamAVAddress string
+
+	// Videos and audio are captioned when a provider URL is set
+	CaptionProvider accessibilityAdapters.CaptionProviderConfig
+
+	// Google identities can be linked when the client ID of the application at Google is set, SAML
+	// identities when the broker the gateway signs SAML users in through is
+	GoogleClientID string
+	SAMLBroker     linkedIdentityAdapters.IdentityProviderConfig
+
+	// Bounces and complaints are taken from the providers whose webhook is configured
+	SES      deliverabilityAdapters.SESConfig
+	SendGrid deliverabilityAdapters.SendGridConfig
+
+	// Confirmed enrollments are pushed to the student information system when a URL is set
+	StudentInformationSystem enrollmentAdapters.StudentInformationSystemConfig
+
+	// Uploaded files such as message attachments
+	FileStorageDir string
+
+	// Master key wrapping the data key of every tenant, base64 of 32 bytes. Column encryption is off
+	// without it.
+	EncryptionMasterKey string
+
+	// Files here replace the RBAC model and policies bundled in the binary
+	ConfigOverrideDir string
+
+	// Billing and plan feature gating are enabled when a Stripe webhook secret is set
+	Stripe billingAdapters.StripeConfig
+
+	// Archival runs when the month school years start in is set
+	ArchiveSchoolYearStartMonth int
+
+	// Tenant backup dumps, every tenant is backed up once per BackupInterval when it is set
+	BackupStorageDir string
+	BackupInterval   time.Duration
+
+	// What is authorized while role assignments cannot be loaded, "closed" or "read-only", and how
+	// often every instance reloads them
+	AuthzFailureMode   string
+	AuthzPolicyRefresh time.Duration
+	// How often every instance compares its policies with the policy file, 0 disables the check
+	AuthzDriftCheck time.Duration
+
+	// Consumers of the API over these are flagged in the usage analytics, 0 disables a flag
+	AnalyticsMaxRequestsPerHour  int
+	AnalyticsMaxErrorRatePercent int
+
+	// Oldest client app version served per platform, platform=version entries such as ios=4.2.0
+	MinClientVersions []string
+
+	// Reject query parameters the operations do not declare and body fields in another casing,
+	// meant for staging so typos of clients fail loudly
+	StrictRequestValidation bool
+
+	// Tenants routed to the candidate implementations of ports, name=percent%|tenant entries such as
+	// grading-engine=10%|north
+	CanaryRollouts []string
+
+	// Bearer token of /debug/errors/recent, which is not served without it, and how many errors
+	// every instance keeps
+	DebugErrorsToken    string
+	DebugErrorsCapacity int
+	// Bearer token of /debug/authz, which is not served without it
+	DebugAuthzToken string
+	// Token requests send in X-Debug-Cost to get what they cost back in it, never sent when empty
+	DebugCostToken string
+	// Bearer token of the requests managing the incident notes of /status, which are not served
+	// without it, and how many times a minute every client IP may read /status
+	StatusAdminToken         string
+	StatusRateLimitPerMinute int
+	// Bearer token of the requests managing the districts, which are not served without it, and how
+	// many times a minute every district key may read the metrics of its district
+	DistrictAdminToken         string
+	DistrictRateLimitPerMinute int
+	// How many developer sandboxes every client IP may create a minute
+	DeveloperSandboxRateLimitPerMinute int
+	// Incident bundles go to S3 when a bucket is set, otherwise to IncidentBundleDir when set, and
+	// the alert linking them is posted to IncidentWebhookURL when set
+	IncidentBundleDir  string
+	IncidentBundleS3   warehouseAdapters.S3Config
+	IncidentWebhookURL string
+
+	// Email domains of the schools per tenant, the school_email validation accepts any domain for
+	// tenants not listed
+	SchoolEmailDomains map[string][]string
+
+	// Multi-instance prerequisites, the scaling audit requires them when running several replicas
+	CasbinWatcherEnabled bool
+	JobLocksEnabled      bool
+	// With leader election one instance runs every scheduled job, it implies job locks
+	LeaderElectionEnabled bool
+
+	// Modules the instance serves, named after their directory in infra. Every module is served when
+	// empty, services split off the monolith list theirs.
+	Modules []string
+
+	// Identity tokens of calls between services, the X-User-Id of peers is only believed with one once
+	// keys are set
+	InternalAuth internalauth.Config
+	// kid:secret keys requests to the operations of the signing section of the policies are signed
+	// with, they are not checked without any
+	RequestSigningKeys []string
+	// Peer services called over gRPC, and the mutual TLS the gRPC server also serves with once set
+	GRPCClient grpcclient.Config
+
+	// On SIGTERM the instance stays up for the lame-duck delay, then drains within the grace period
+	Shutdown ops.ShutdownConfig
 }
 
 func loadConfig() *Config {
@@ -98,6 +851,201 @@ func loadConfig() *Config {
 		GRPCPort:    getEnv("GRPC_PORT", "8080"),
 		HTTPPort:    getEnv("HTTP_PORT", "8081"),
 		Tenants:     []string{"tenant1", "tenant2"}, // TODO: Load from environment or database
+
+		TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+
+		MaxInFlightRequests:          getEnvInt("MAX_IN_FLIGHT_REQUESTS", 200),
+		MaxInFlightRequestsPerTenant: getEnvInt("MAX_IN_FLIGHT_REQUESTS_PER_TENANT", 50),
+		MaxInFlightRequestsByTenant:  parseTenantLimits(getEnvList("MAX_IN_FLIGHT_REQUESTS_BY_TENANT")),
+		Downloads: ratelimit.DownloadLimits{
+			PerUser:              getEnvInt("MAX_DOWNLOADS_PER_USER", 3),
+			PerTenant:            getEnvInt("MAX_DOWNLOADS_PER_TENANT", 50),
+			UserBytesPerSecond:   int64(getEnvInt("DOWNLOAD_USER_BYTES_PER_SECOND", 2<<20)),
+			TenantBytesPerSecond: int64(getEnvInt("DOWNLOAD_TENANT_BYTES_PER_SECOND", 20<<20)),
+		},
+
+		QueryTracer: database.QueryTracerConfig{
+			SlowThreshold: time.Duration(getEnvInt("DB_SLOW_QUERY_MS", 250)) * time.Millisecond,
+			ExplainAfter:  getEnvInt("DB_EXPLAIN_SLOW_QUERIES_AFTER", 0),
+			TenantSeries:  getEnvInt("DB_METRICS_TENANT_SERIES", 500),
+		},
+		QueryMode: database.QueryModeConfig{
+			ExecMode:                 getEnv("DB_QUERY_EXEC_MODE", ""),
+			StatementCacheCapacity:   getEnvInt("DB_STATEMENT_CACHE_CAPACITY", -1),
+			DescriptionCacheCapacity: getEnvInt("DB_DESCRIPTION_CACHE_CAPACITY", -1),
+		},
+		PoolQuota: database.PoolQuotaConfig{
+			BackgroundPercent: getEnvInt("DB_POOL_BACKGROUND_PERCENT", 25),
+			ReportsPercent:    getEnvInt("DB_POOL_REPORTS_PERCENT", 25),
+			MaxWait:           time.Duration(getEnvInt("DB_POOL_QUOTA_MAX_WAIT_MS", 5000)) * time.Millisecond,
+		},
+		Pooler: database.PoolerConfig{
+			TransactionPooling: getEnv("DB_TRANSACTION_POOLING", "") == "true",
+			SessionURL:         getEnv("DATABASE_SESSION_URL", ""),
+		},
+		ReadRetry: database.ReadRetryConfig{
+			Attempts:  getEnvInt("DB_READ_RETRY_ATTEMPTS", database.DefaultReadRetryConfig.Attempts),
+			BaseDelay: time.Duration(getEnvInt("DB_READ_RETRY_BASE_DELAY_MS", 100)) * time.Millisecond,
+			MaxDelay:  time.Duration(getEnvInt("DB_READ_RETRY_MAX_DELAY_MS", 1000)) * time.Millisecond,
+		},
+		Replica: database.ReplicaConfig{
+			URL:          getEnv("DATABASE_REPLICA_URL", ""),
+			PollInterval: time.Duration(getEnvInt("DB_REPLICA_POLL_MS", 200)) * time.Millisecond,
+			TokenMaxAge:  database.DefaultReplicaConfig.TokenMaxAge,
+		},
+		DataRegion:        getEnv("DATA_REGION", ""),
+		DefaultDataRegion: getEnv("DEFAULT_DATA_REGION", ""),
+		TenantRegions:     getEnvList("TENANT_REGIONS"),
+		RegionEndpoints:   getEnvList("DATA_REGION_ENDPOINTS"),
+		ShardDatabaseURLs: getEnvList("SHARD_DATABASE_URLS"),
+		Fleet: ops.FleetConfig{
+			Concurrency: getEnvInt("SHARD_CONCURRENCY", 2),
+			Timeout:     time.Duration(getEnvInt("SHARD_TIMEOUT_MINUTES", 30)) * time.Minute,
+		},
+		WriteBehind: database.WriteBehindConfig{
+			FlushInterval: time.Duration(getEnvInt("WRITE_BEHIND_FLUSH_MS", 1000)) * time.Millisecond,
+			BatchSize:     getEnvInt("WRITE_BEHIND_BATCH_SIZE", database.DefaultWriteBehindConfig.BatchSize),
+			MaxPending:    getEnvInt("WRITE_BEHIND_MAX_PENDING", database.DefaultWriteBehindConfig.MaxPending),
+			Attempts:      getEnvInt("WRITE_BEHIND_ATTEMPTS", database.DefaultWriteBehindConfig.Attempts),
+		},
+		CursorKey: getEnv("PAGINATION_CURSOR_KEY", ""),
+
+		RedisAddr:     getEnv("REDIS_ADDR", ""),
+		RedisPassword: getEnv("REDIS_PASSWORD", ""),
+
+		WarehouseExportDir: getEnv("WAREHOUSE_EXPORT_DIR", ""),
+		WarehouseS3: warehouseAdapters.S3Config{
+			Endpoint:        getEnv("WAREHOUSE_S3_ENDPOINT", "https://s3.amazonaws.com"),
+			Region:          getEnv("WAREHOUSE_S3_REGION", "us-east-1"),
+			Bucket:          getEnv("WAREHOUSE_S3_BUCKET", ""),
+			Prefix:          getEnv("WAREHOUSE_S3_PREFIX", ""),
+			AccessKeyID:     getEnv("WAREHOUSE_S3_ACCESS_KEY_ID", ""),
+			SecretAccessKey: getEnv("WAREHOUSE_S3_SECRET_ACCESS_KEY", ""),
+		},
+		WarehousePseudonymKey: getEnv("WAREHOUSE_PSEUDONYM_KEY", ""),
+
+		SimilarityProvider: similarityAdapters.SimilarityProviderConfig{
+			BaseURL:       getEnv("SIMILARITY_API_URL", ""),
+			APIKey:        getEnv("SIMILARITY_API_KEY", ""),
+			WebhookSecret: getEnv("SIMILARITY_WEBHOOK_SECRET", ""),
+			CallbackURL:   getEnv("SIMILARITY_CALLBACK_URL", ""),
+		},
+
+		JobFailureWebhookURL:      getEnv("JOB_FAILURE_WEBHOOK_URL", ""),
+		JobFailureSlackWebhookURL: getEnv("JOB_FAILURE_SLACK_WEBHOOK_URL", ""),
+
+		ClamAVAddress: getEnv("CLAMAV_ADDRESS", ""),
+
+		CaptionProvider: accessibilityAdapters.CaptionProviderConfig{
+			BaseURL: getEnv("CAPTION_API_URL", ""),
+			APIKey:  getEnv("CAPTION_API_KEY", ""),
+		},
+
+		GoogleClientID: getEnv("LINKED_IDENTITY_GOOGLE_CLIENT_ID", ""),
+		SAMLBroker: linkedIdentityAdapters.IdentityProviderConfig{
+			Issuers:  getEnvList("LINKED_IDENTITY_SAML_ISSUER"),
+			JWKSURL:  getEnv("LINKED_IDENTITY_SAML_JWKS_URL", ""),
+			Audience: getEnv("LINKED_IDENTITY_SAML_CLIENT_ID", ""),
+		},
+
+		SES: deliverabilityAdapters.SESConfig{
+			TopicARNs: getEnvList("EMAIL_SES_TOPIC_ARNS"),
+		},
+		SendGrid: deliverabilityAdapters.SendGridConfig{
+			VerificationKey: getEnv("EMAIL_SENDGRID_VERIFICATION_KEY", ""),
+		},
+
+		StudentInformationSystem: enrollmentAdapters.StudentInformationSystemConfig{
+			BaseURL: getEnv("SIS_API_URL", ""),
+			APIKey:  getEnv("SIS_API_KEY", ""),
+		},
+
+		FileStorageDir: getEnv("FILE_STORAGE_DIR", "data/files"),
+
+		EncryptionMasterKey: getEnv("ENCRYPTION_MASTER_KEY", ""),
+
+		ConfigOverrideDir: getEnv("CONFIG_OVERRIDE_DIR", ""),
+
+		Stripe: billingAdapters.StripeConfig{
+			WebhookSecret: getEnv("STRIPE_WEBHOOK_SECRET", ""),
+		},
+
+		ArchiveSchoolYearStartMonth: getEnvInt("ARCHIVE_SCHOOL_YEAR_START_MONTH", 0),
+
+		BackupStorageDir: getEnv("BACKUP_STORAGE_DIR", "data/backups"),
+		BackupInterval:   time.Duration(getEnvInt("BACKUP_INTERVAL_HOURS", 0)) * time.Hour,
+
+		AuthzFailureMode:   getEnv("AUTHZ_FAILURE_MODE", string(authorization.FailClosed)),
+		AuthzPolicyRefresh: time.Duration(getEnvInt("AUTHZ_POLICY_REFRESH_SECONDS", 60)) * time.Second,
+		AuthzDriftCheck:    time.Duration(getEnvInt("AUTHZ_DRIFT_CHECK_SECONDS", 300)) * time.Second,
+
+		AnalyticsMaxRequestsPerHour:  getEnvInt("ANALYTICS_MAX_REQUESTS_PER_HOUR", 3600),
+		AnalyticsMaxErrorRatePercent: getEnvInt("ANALYTICS_MAX_ERROR_RATE_PERCENT", 50),
+
+		MinClientVersions: getEnvList("MIN_CLIENT_VERSIONS"),
+		CanaryRollouts:    getEnvList("CANARY_ROLLOUTS"),
+
+		StrictRequestValidation: getEnv("STRICT_REQUEST_VALIDATION", "") == "true",
+
+		DebugErrorsToken:    getEnv("DEBUG_ERRORS_TOKEN", ""),
+		DebugErrorsCapacity: getEnvInt("DEBUG_ERRORS_CAPACITY", 100),
+		DebugAuthzToken:     getEnv("DEBUG_AUTHZ_TOKEN", ""),
+		DebugCostToken:      getEnv("DEBUG_COST_TOKEN", ""),
+
+		StatusAdminToken:         getEnv("STATUS_ADMIN_TOKEN", ""),
+		StatusRateLimitPerMinute: getEnvInt("STATUS_RATE_LIMIT_PER_MINUTE", 30),
+
+		DistrictAdminToken:         getEnv("DISTRICT_ADMIN_TOKEN", ""),
+		DistrictRateLimitPerMinute: getEnvInt("DISTRICT_RATE_LIMIT_PER_MINUTE", 60),
+
+		DeveloperSandboxRateLimitPerMinute: getEnvInt("DEVELOPER_SANDBOX_RATE_LIMIT_PER_MINUTE", 2),
+
+		IncidentBundleDir: getEnv("INCIDENT_BUNDLE_DIR", ""),
+		IncidentBundleS3: warehouseAdapters.S3Config{
+			Endpoint:        getEnv("INCIDENT_BUNDLE_S3_ENDPOINT", "https://s3.amazonaws.com"),
+			Region:          getEnv("INCIDENT_BUNDLE_S3_REGION", "us-east-1"),
+			Bucket:          getEnv("INCIDENT_BUNDLE_S3_BUCKET", ""),
+			Prefix:          getEnv("INCIDENT_BUNDLE_S3_PREFIX", ""),
+			AccessKeyID:     getEnv("INCIDENT_BUNDLE_S3_ACCESS_KEY_ID", ""),
+			SecretAccessKey: getEnv("INCIDENT_BUNDLE_S3_SECRET_ACCESS_KEY", ""),
+		},
+		IncidentWebhookURL: getEnv("INCIDENT_WEBHOOK_URL", ""),
+
+		SchoolEmailDomains: parseSchoolEmailDomains(getEnvList("SCHOOL_EMAIL_DOMAINS")),
+
+		CasbinWatcherEnabled:  getEnv("CASBIN_WATCHER_ENABLED", "") == "true",
+		JobLocksEnabled:       getEnv("JOB_LOCKS_ENABLED", "") == "true",
+		LeaderElectionEnabled: getEnv("LEADER_ELECTION_ENABLED", "") == "true",
+
+		Modules: getEnvList("SERVICE_MODULES"),
+
+		InternalAuth: internalauth.Config{
+			Service:  getEnv("INTERNAL_AUTH_SERVICE", "class-backend"),
+			Keys:     getEnvList("INTERNAL_AUTH_KEYS"),
+			Peers:    getEnvList("INTERNAL_AUTH_PEERS"),
+			TTL:      time.Duration(getEnvInt("INTERNAL_AUTH_TOKEN_TTL_SECONDS", 60)) * time.Second,
+			Required: getEnv("INTERNAL_AUTH_REQUIRED", "") == "true",
+		},
+		RequestSigningKeys: getEnvList("REQUEST_SIGNING_KEYS"),
+		GRPCClient: grpcclient.Config{
+			Peers:          parsePeers(getEnvList("GRPC_PEERS")),
+			TargetTemplate: getEnv("GRPC_PEER_TARGET_TEMPLATE", ""),
+			TLS: grpcclient.TLSConfig{
+				CertFile:   getEnv("GRPC_TLS_CERT_FILE", ""),
+				KeyFile:    getEnv("GRPC_TLS_KEY_FILE", ""),
+				CAFile:     getEnv("GRPC_TLS_CA_FILE", ""),
+				ServerName: getEnv("GRPC_TLS_SERVER_NAME", ""),
+			},
+			Timeout:     time.Duration(getEnvInt("GRPC_CLIENT_TIMEOUT_MS", 5000)) * time.Millisecond,
+			MaxAttempts: getEnvInt("GRPC_CLIENT_MAX_ATTEMPTS", 3),
+		},
+
+		Shutdown: ops.ShutdownConfig{
+			LameDuckDelay: time.Duration(getEnvInt("SHUTDOWN_LAME_DUCK_SECONDS", 10)) * time.Second,
+			GracePeriod:   time.Duration(getEnvInt("SHUTDOWN_GRACE_SECONDS", 20)) * time.Second,
+			// Set from the pod spec, the downward API does not expose it
+			TerminationGracePeriod: time.Duration(getEnvInt("TERMINATION_GRACE_PERIOD_SECONDS", 0)) * time.Second,
+		},
 	}
 }
 
@@ -108,45 +1056,509 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
-func setupDatabase(databaseURL string) (*pgxpool.Pool, error) {
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvList splits a comma separated variable, nil when unset
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseSchoolEmailDomains reads tenant=domain|domain entries, entries without a tenant are skipped
+func parseSchoolEmailDomains(entries []string) map[string][]string {
+	domains := make(map[string][]string, len(entries))
+	for _, entry := range entries {
+		tenantID, list, found := strings.Cut(entry, "=")
+		if tenantID = strings.TrimSpace(tenantID); !found || tenantID == "" {
+			log.Printf("Skipping school email domains %q, expected tenant=domain|domain", entry)
+			continue
+		}
+		for _, domain := range strings.Split(list, "|") {
+			if domain = strings.TrimSpace(domain); domain != "" {
+				domains[tenantID] = append(domains[tenantID], domain)
+			}
+		}
+	}
+	return domains
+}
+
+// parseTenantLimits reads tenant=limit entries, entries without a tenant or a number are skipped
+func parseTenantLimits(entries []string) map[string]int {
+	limits := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		tenantID, value, found := strings.Cut(entry, "=")
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if tenantID = strings.TrimSpace(tenantID); !found || tenantID == "" || err != nil || limit < 0 {
+			log.Printf("Skipping tenant limit %q, expected tenant=limit", entry)
+			continue
+		}
+		limits[tenantID] = limit
+	}
+	return limits
+}
+
+// parsePeers reads service=target entries, such as grades=dns:///grades.internal:8080
+func parsePeers(entries []string) map[string]string {
+	peers := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		service, target, found := strings.Cut(entry, "=")
+		service, target = strings.TrimSpace(service), strings.TrimSpace(target)
+		if !found || service == "" || target == "" {
+			log.Printf("Skipping gRPC peer %q, expected service=target", entry)
+			continue
+		}
+		peers[service] = target
+	}
+	return peers
+}
+
+func setupDatabase(databaseURL string, tracer pgx.QueryTracer, queryMode database.QueryModeConfig,
+	pooler database.PoolerConfig, quotaConfig database.PoolQuotaConfig) (*pgxpool.Pool, *database.PoolQuota, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	log.Printf("Connecting to database: %s", maskPassword(databaseURL))
 
+	poolConfig, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse database URL: %w", err)
+	}
+	// Timestamps are stored and compared in UTC, responses convert them to the zone of the reader
+	poolConfig.ConnConfig.RuntimeParams["timezone"] = "UTC"
+	if err := queryMode.Apply(poolConfig.ConnConfig); err != nil {
+		return nil, nil, fmt.Errorf("invalid query mode: %w", err)
+	}
+	if err := pooler.Apply(poolConfig.ConnConfig); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration for a transaction pooler: %w", err)
+	}
+	if !database.Describes(poolConfig.ConnConfig.DefaultQueryExecMode) {
+		log.Println("The query exec mode does not describe statements, queries writing jsonb columns fail")
+	}
+	// Background jobs and reports hold a share of the connections at most, the rest is left to requests
+	quota := database.NewPoolQuota(quotaConfig, poolConfig.MaxConns)
+	poolConfig.ConnConfig.Tracer = quota.Trace(pooler.Trace(tracer))
+
 	// Create connection pool
-	pool, err := pgxpool.New(ctx, databaseURL)
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+		return nil, nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
 
 	// Test the connection
 	if err := pool.Ping(ctx); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+		return nil, nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	log.Printf("Successfully connected to database with connection pool of %d connections, background jobs limited to %d and reports to %d",
+		poolConfig.MaxConns, quota.Limit(database.PoolClassBackground), quota.Limit(database.PoolClassReports))
+	return pool, quota, nil
+}
+
+// setupReplica connects to the streaming replica, with the settings of the primary pool
+func setupReplica(databaseURL string, tracer pgx.QueryTracer, queryMode database.QueryModeConfig,
+	pooler database.PoolerConfig) (*pgxpool.Pool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	log.Printf("Connecting to replica database: %s", maskPassword(databaseURL))
+
+	poolConfig, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse replica database URL: %w", err)
+	}
+	poolConfig.ConnConfig.RuntimeParams["timezone"] = "UTC"
+	if err := queryMode.Apply(poolConfig.ConnConfig); err != nil {
+		return nil, fmt.Errorf("invalid query mode: %w", err)
+	}
+	if err := pooler.Apply(poolConfig.ConnConfig); err != nil {
+		return nil, fmt.Errorf("invalid configuration for a transaction pooler: %w", err)
 	}
+	poolConfig.ConnConfig.Tracer = tracer
 
-	log.Println("Successfully connected to database with connection pool")
-	return pool, nil
+	replica, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replica connection pool: %w", err)
+	}
+	if err := replica.Ping(ctx); err != nil {
+		replica.Close()
+		return nil, fmt.Errorf("failed to ping replica database: %w", err)
+	}
+	return replica, nil
 }
 
-func setupAuthorization(pool *pgxpool.Pool, tenants []string) (*authorization.CasbinService, error) {
+// setupResidency routes the tenants to the region of their data, the instance serves the ones of
+// the region of its cluster
+func setupResidency(config *Config) (*residency.Router, error) {
+	tenants, err := residency.ParseAssignments(config.TenantRegions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TENANT_REGIONS: %w", err)
+	}
+	endpoints, err := residency.ParseAssignments(config.RegionEndpoints)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DATA_REGION_ENDPOINTS: %w", err)
+	}
+	router, err := residency.NewRouter(residency.Config{
+		Region:        config.DataRegion,
+		DefaultRegion: config.DefaultDataRegion,
+		Tenants:       tenants,
+		Endpoints:     endpoints,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if router.Routing() {
+		log.Printf("Serving the tenants of region %s, %d tenants pinned to regions", router.Region(), len(tenants))
+	}
+	return router, nil
+}
+
+// migrateShards applies the migrations, claims the cluster for its region and creates the partitions
+// of the coming months on every shard. A failing shard stops there and the others go on, the error
+// lists the shards to run again.
+func migrateShards(config *Config, names []string) error {
+	urls, err := residency.ParseAssignments(config.ShardDatabaseURLs)
+	if err != nil {
+		return fmt.Errorf("invalid SHARD_DATABASE_URLS: %w", err)
+	}
+	shards, err := ops.SelectShards(ops.ParseShards(urls), names)
+	if err != nil {
+		return err
+	}
+	if len(shards) == 0 {
+		return fmt.Errorf("no shard is configured, set SHARD_DATABASE_URLS")
+	}
+
+	fleet := ops.NewFleet(shards, config.Fleet)
+	fleet.Run(context.Background(),
+		ops.FleetStep{Name: "migrate", Run: func(ctx context.Context, shard ops.Shard) error {
+			// Atlas keeps the revision of every cluster, a shard run again resumes at its first pending migration
+			cmd := exec.CommandContext(ctx, "atlas", "migrate", "apply", "--env", "dev")
+			cmd.Env = append(os.Environ(), "DATABASE_URL="+shard.DatabaseURL)
+			if output, err := cmd.CombinedOutput(); err != nil {
+				return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+			}
+			return nil
+		}},
+		ops.FleetStep{Name: "claim-region", Run: withShardPool(func(ctx context.Context, shard ops.Shard, pool *pgxpool.Pool) error {
+			return residency.VerifyCluster(ctx, sharedAdapters.NewPostgresClusterRegionStore(pool), shard.Name)
+		})},
+		ops.FleetStep{Name: "ensure-partitions", Run: withShardPool(func(ctx context.Context, shard ops.Shard, pool *pgxpool.Pool) error {
+			command, err := ensure_partitions_use_case.NewEnsurePartitionsCommand(time.Now(), ensure_partitions_use_case.DefaultMonthsAhead)
+			if err != nil {
+				return err
+			}
+			_, err = ensure_partitions_use_case.NewEnsurePartitionsUseCase(partitioningAdapters.NewPostgresPartitionManager(pool)).Execute(ctx, command)
+			return err
+		})},
+	)
+
+	if err := fleet.WriteReport(os.Stdout); err != nil {
+		log.Printf("Failed to write the shard report: %v", err)
+	}
+	if failed := fleet.Failed(); len(failed) > 0 {
+		return fmt.Errorf("%d of %d shards did not finish, run them again with make migrate-shards SHARDS=\"%s\"",
+			len(failed), len(shards), strings.Join(failed, " "))
+	}
+	return nil
+}
+
+// withShardPool runs step with a pool of the shard, closed once the step returns
+func withShardPool(step func(ctx context.Context, shard ops.Shard, pool *pgxpool.Pool) error) func(ctx context.Context, shard ops.Shard) error {
+	return func(ctx context.Context, shard ops.Shard) error {
+		poolConfig, err := pgxpool.ParseConfig(shard.DatabaseURL)
+		if err != nil {
+			return fmt.Errorf("failed to parse database URL: %w", err)
+		}
+		poolConfig.ConnConfig.RuntimeParams["timezone"] = "UTC"
+		pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create connection pool: %w", err)
+		}
+		defer pool.Close()
+		return step(ctx, shard, pool)
+	}
+}
+
+func setupAuthorization(pool *pgxpool.Pool, sessionConnConfig *pgx.ConnConfig, config *Config) (*authorization.CasbinService, error) {
+	failureMode, err := authorization.ParseFailureMode(config.AuthzFailureMode)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert pgxpool to database/sql for Casbin adapter
 	sqlDB := stdlib.OpenDBFromPool(pool)
 
-	authzService, err := authorization.NewCasbinService(
+	authzService, authzErr := authorization.NewCasbinService(
 		sqlDB,
-		"infra/configs/rbac_model.conf",
-		"policies.yaml",
-		tenants, // should be loaded from database
+		configs.Assets(config.ConfigOverrideDir),
+		configs.RBACModelFile,
+		configs.PoliciesFile,
+		config.Tenants, // should be loaded from database
 	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create authorization service: %w", err)
+	if authzErr != nil {
+		return nil, fmt.Errorf("failed to create authorization service: %w", authzErr)
+	}
+	authzService.SetFailureMode(failureMode)
+
+	if config.CasbinWatcherEnabled {
+		watcher, err := authorization.NewPostgresWatcher(pool, sessionConnConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create role change watcher: %w", err)
+		}
+		if err := authzService.SetWatcher(watcher); err != nil {
+			return nil, fmt.Errorf("failed to set role change watcher: %w", err)
+		}
+		log.Println("Role changes are propagated to other instances")
 	}
 
-	log.Printf("Authorization service initialized for tenants: %v", tenants)
+	log.Printf("Authorization service initialized for tenants: %v, fails %s when role assignments cannot be loaded", config.Tenants, failureMode)
 	return authzService, nil
 }
 
+// auditScaling lists what the configuration keeps on a single instance
+func auditScaling(config *Config) *ops.ScalingAudit {
+	audit := ops.NewScalingAudit()
+	audit.Require("casbin", config.CasbinWatcherEnabled,
+		"role changes only reach the instance that made them, set CASBIN_WATCHER_ENABLED=true")
+	audit.Require("job locks", config.JobLocksEnabled || config.LeaderElectionEnabled,
+		"projections, schema change backfills, partition maintenance, warehouse exports, archival and backup scheduling run on every instance, set JOB_LOCKS_ENABLED=true or LEADER_ELECTION_ENABLED=true")
+	audit.Require("presence", config.RedisAddr != "",
+		"online users and edit locks are kept in the memory of each instance, set REDIS_ADDR")
+	audit.Require("pagination cursors", config.CursorKey != "",
+		"list cursors are signed with a random key of each instance, the next page fails on another one, set PAGINATION_CURSOR_KEY")
+	audit.Require("consistency tokens", config.Replica.URL == "" || config.CursorKey != "",
+		"consistency tokens are signed with a random key of each instance, reads after a write served by another one can be stale, set PAGINATION_CURSOR_KEY")
+
+	audit.Flag("identity cache", "identities are cached in memory for 30s, role changes on other instances show up after it")
+	audit.Flag("time zone cache", "settings are cached in memory for 1m, changes on other instances show up after it")
+	audit.Flag("dashboard metrics cache", "metrics are cached in memory for 1m")
+	if config.Stripe.WebhookSecret != "" {
+		audit.Flag("subscription cache", "subscriptions are cached in memory for 1m, plan changes reach gated endpoints after it")
+	}
+	audit.Flag("tenant rate limiter", "limits are counted in the memory of each instance, the effective limit grows with the replicas")
+	audit.Flag("file storage", fmt.Sprintf("attachments are written to %s, it must be a volume shared by every instance", config.FileStorageDir))
+	audit.Flag("backup storage", fmt.Sprintf("backups are written to %s by the instance that takes them, it must be a volume shared by every instance", config.BackupStorageDir))
+	if config.WarehouseS3.Bucket == "" && config.WarehouseExportDir != "" {
+		audit.Flag("warehouse exports", fmt.Sprintf("exports are written to %s on the instance holding the job lock", config.WarehouseExportDir))
+	}
+	return audit
+}
+
+func setupWarehouseSink(config *Config) warehousePorts.WarehouseSink {
+	if config.WarehouseS3.Bucket == "" && config.WarehouseExportDir == "" {
+		return nil
+	}
+
+	if config.WarehousePseudonymKey == "" {
+		log.Println("Warehouse exports disabled: WAREHOUSE_PSEUDONYM_KEY is required to anonymize identifiers")
+		return nil
+	}
+
+	if config.WarehouseS3.Bucket != "" {
+		log.Printf("Warehouse exports enabled to bucket %s", config.WarehouseS3.Bucket)
+		return warehouseAdapters.NewS3Sink(config.WarehouseS3)
+	}
+
+	log.Printf("Warehouse exports enabled to directory %s", config.WarehouseExportDir)
+	return warehouseAdapters.NewFilesystemSink(config.WarehouseExportDir)
+}
+
+// setupIncidentBundles returns where incident bundles are stored and how their keys are linked,
+// nil when no store is configured
+func setupIncidentBundles(config *Config) (diagnostics.BundleStore, func(key string) string) {
+	if bucket := config.IncidentBundleS3; bucket.Bucket != "" {
+		log.Printf("Incident bundles enabled to bucket %s", bucket.Bucket)
+		prefix := strings.Trim(bucket.Prefix, "/")
+		return warehouseAdapters.NewS3Sink(bucket), func(key string) string {
+			return strings.TrimSuffix(bucket.Endpoint, "/") + "/" + path.Join(bucket.Bucket, prefix, key)
+		}
+	}
+
+	if config.IncidentBundleDir != "" {
+		log.Printf("Incident bundles enabled to directory %s", config.IncidentBundleDir)
+		return warehouseAdapters.NewFilesystemSink(config.IncidentBundleDir), func(key string) string {
+			return "file://" + filepath.Join(config.IncidentBundleDir, filepath.FromSlash(key))
+		}
+	}
+
+	log.Println("Incident bundles disabled: INCIDENT_BUNDLE_S3_BUCKET or INCIDENT_BUNDLE_DIR not set")
+	return nil, nil
+}
+
+// poolStats reads the database pool and the share of it every class of work holds
+func poolStats(pool *pgxpool.Pool, poolQuota *database.PoolQuota) any {
+	stat := pool.Stat()
+	var quota strings.Builder
+	if poolQuota != nil {
+		if err := poolQuota.WriteMetrics(&quota); err != nil {
+			quota.WriteString(err.Error())
+		}
+	}
+	return map[string]any{
+		"total_conns":            stat.TotalConns(),
+		"acquired_conns":         stat.AcquiredConns(),
+		"idle_conns":             stat.IdleConns(),
+		"max_conns":              stat.MaxConns(),
+		"acquire_count":          stat.AcquireCount(),
+		"acquire_duration":       stat.AcquireDuration().String(),
+		"empty_acquire_count":    stat.EmptyAcquireCount(),
+		"canceled_acquire_count": stat.CanceledAcquireCount(),
+		"quota":                  quota.String(),
+	}
+}
+
+// setupIncidentNotifier emails escalated job failures through the outbox, and posts them to the
+// webhooks that are set
+func setupIncidentNotifier(pool *pgxpool.Pool, config *Config) jobFailurePorts.IncidentNotifier {
+	notifiers := jobFailureAdapters.IncidentNotifiers{jobFailureAdapters.NewOutboxIncidentNotifier(pool)}
+	if config.JobFailureWebhookURL != "" {
+		notifiers = append(notifiers, jobFailureAdapters.NewWebhookIncidentNotifier(config.JobFailureWebhookURL))
+	}
+	if config.JobFailureSlackWebhookURL != "" {
+		notifiers = append(notifiers, jobFailureAdapters.NewSlackIncidentNotifier(config.JobFailureSlackWebhookURL))
+	}
+	return notifiers
+}
+
+func setupStudentInformationSystem(config *Config) enrollmentPorts.StudentInformationSystem {
+	if config.StudentInformationSystem.BaseURL == "" {
+		return enrollmentAdapters.NewDisabledStudentInformationSystem()
+	}
+
+	log.Printf("Enrollments are pushed to the student information system at %s", config.StudentInformationSystem.BaseURL)
+	return enrollmentAdapters.NewHTTPStudentInformationSystem(config.StudentInformationSystem)
+}
+
+// setupIdentityTokenVerifier verifies the tokens identities are linked with, providers that are not
+// configured cannot be linked
+func setupIdentityTokenVerifier(config *Config) linkedIdentityPorts.IdentityTokenVerifier {
+	providers := make(map[linkedIdentityEntities.Provider]linkedIdentityAdapters.IdentityProviderConfig)
+	if config.GoogleClientID != "" {
+		providers[linkedIdentityEntities.ProviderGoogle] = linkedIdentityAdapters.IdentityProviderConfig{
+			Issuers:  []string{linkedIdentityAdapters.GoogleIssuer, "accounts.google.com"},
+			JWKSURL:  linkedIdentityAdapters.GoogleJWKSURL,
+			Audience: config.GoogleClientID,
+		}
+	} else {
+		log.Println("Google identities cannot be linked until LINKED_IDENTITY_GOOGLE_CLIENT_ID is set")
+	}
+
+	broker := config.SAMLBroker
+	if len(broker.Issuers) > 0 && broker.JWKSURL != "" && broker.Audience != "" {
+		providers[linkedIdentityEntities.ProviderSAML] = broker
+	} else {
+		log.Println("SAML identities cannot be linked until LINKED_IDENTITY_SAML_ISSUER, LINKED_IDENTITY_SAML_JWKS_URL and LINKED_IDENTITY_SAML_CLIENT_ID are set")
+	}
+
+	return linkedIdentityAdapters.NewOIDCIdentityTokenVerifier(providers)
+}
+
+// setupDeliveryWebhooks verifies the webhooks email providers report bounces and complaints through,
+// the webhooks of providers that are not configured are refused
+func setupDeliveryWebhooks(config *Config) map[deliverabilityEntities.EmailProvider]deliverabilityPorts.DeliveryWebhook {
+	webhooks := make(map[deliverabilityEntities.EmailProvider]deliverabilityPorts.DeliveryWebhook)
+	if len(config.SES.TopicARNs) > 0 {
+		webhooks[deliverabilityEntities.EmailProviderSES] = deliverabilityAdapters.NewSESDeliveryWebhook(config.SES)
+	} else {
+		log.Println("SES bounces and complaints are refused until EMAIL_SES_TOPIC_ARNS is set")
+	}
+
+	if config.SendGrid.VerificationKey != "" {
+		webhook, err := deliverabilityAdapters.NewSendGridDeliveryWebhook(config.SendGrid)
+		if err != nil {
+			log.Fatalf("Failed to setup the SendGrid webhook: %v", err)
+		}
+		webhooks[deliverabilityEntities.EmailProviderSendGrid] = webhook
+	} else {
+		log.Println("SendGrid bounces and complaints are refused until EMAIL_SENDGRID_VERIFICATION_KEY is set")
+	}
+
+	return webhooks
+}
+
+func setupSimilarityChecker(config *Config) similarityPorts.SimilarityChecker {
+	provider := config.SimilarityProvider
+	if provider.BaseURL == "" {
+		return nil
+	}
+
+	if provider.WebhookSecret == "" || provider.CallbackURL == "" {
+		log.Println("Similarity checks disabled: SIMILARITY_WEBHOOK_SECRET and SIMILARITY_CALLBACK_URL are required to receive results")
+		return nil
+	}
+
+	log.Printf("Similarity checks enabled with provider %s", provider.BaseURL)
+	return similarityAdapters.NewHTTPSimilarityChecker(provider)
+}
+
+func setupVirusScanner(config *Config) scanningPorts.VirusScanner {
+	if config.ClamAVAddress == "" {
+		log.Println("Virus scanning disabled: uploads are released unscanned until CLAMAV_ADDRESS is set")
+		return scanningAdapters.NewUnscannedScanner()
+	}
+
+	log.Printf("Virus scanning enabled with clamd at %s", config.ClamAVAddress)
+	return scanningAdapters.NewClamAVScanner(config.ClamAVAddress)
+}
+
+func setupCaptionProvider(config *Config) accessibilityPorts.CaptionProvider {
+	if config.CaptionProvider.BaseURL == "" {
+		log.Println("Captions disabled: videos and audio stay without captions until CAPTION_API_URL is set")
+		return nil
+	}
+
+	log.Printf("Captions enabled with provider %s", config.CaptionProvider.BaseURL)
+	return accessibilityAdapters.NewHTTPCaptionProvider(config.CaptionProvider)
+}
+
+func setupPreviewRenderer() previewPorts.PreviewRenderer {
+	documents, ok := previewAdapters.NewDocumentPreviewRenderer()
+	if !ok {
+		log.Println("Document previews disabled: only images get previews until pdftoppm is installed")
+		return previewAdapters.NewImagePreviewRenderer()
+	}
+
+	log.Println("Document previews enabled, Office documents need soffice in the PATH as well")
+	return previewAdapters.NewPreviewRenderers(previewAdapters.NewImagePreviewRenderer(), documents)
+}
+
+func setupBilling(config *Config) billingPorts.BillingProvider {
+	if config.Stripe.WebhookSecret == "" {
+		log.Println("Billing disabled: every tenant gets every feature without usage limits until STRIPE_WEBHOOK_SECRET is set")
+		return nil
+	}
+
+	log.Println("Billing enabled, endpoints are gated by the plan of the tenant")
+	return billingAdapters.NewStripeBillingProvider(config.Stripe)
+}
+
+func presenceStore(redisClient *redis.Client) presencePorts.PresenceStore {
+	if redisClient == nil {
+		log.Println("REDIS_ADDR not set: presence is tracked in memory and not shared between instances")
+		return presenceAdapters.NewMemoryPresenceStore()
+	}
+	return presenceAdapters.NewRedisPresenceStore(redisClient)
+}
+
+func editLockStore(redisClient *redis.Client) presencePorts.EditLockStore {
+	if redisClient == nil {
+		log.Println("REDIS_ADDR not set: edit locks are kept in memory and not shared between instances")
+		return presenceAdapters.NewMemoryEditLockStore()
+	}
+	return presenceAdapters.NewRedisEditLockStore(redisClient)
+}
+
 func maskPassword(databaseURL string) string {
 	// Mask password in log output for security
 	parts := strings.Split(databaseURL, "@")
@@ -163,14 +1575,3 @@ func maskPassword(databaseURL string) string {
 	maskedURL := strings.Join(userParts[:len(userParts)-1], ":") + ":***@" + strings.Join(parts[1:], "@")
 	return maskedURL
 }
-
-func setupGracefulShutdown() {
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		<-c
-		log.Println("Shutting down gracefully...")
-		os.Exit(0)
-	}()
-}
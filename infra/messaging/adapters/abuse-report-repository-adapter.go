@@ -0,0 +1,121 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresAbuseReportRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresAbuseReportRepository(dbInstance *pgxpool.Pool) ports.AbuseReportRepository {
+	return &PostgresAbuseReportRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresAbuseReportRepository) Create(ctx context.Context, report *entities.AbuseReport) error {
+	var pgID, pgMessageID, pgConversationID pgtype.UUID
+	if err := pgID.Scan(report.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgMessageID.Scan(report.MessageID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgConversationID.Scan(report.ConversationID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.InsertAbuseReport(ctx, db.InsertAbuseReportParams{
+		ID:             pgID,
+		TenantID:       report.TenantID,
+		MessageID:      pgMessageID,
+		ConversationID: pgConversationID,
+		ReporterID:     report.ReporterID,
+		Reason:         report.Reason,
+		Status:         string(report.Status),
+		CreatedAt:      pgtype.Timestamptz{Time: report.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresAbuseReportRepository) FindByID(ctx context.Context, tenantID string, reportID string) (*entities.AbuseReport, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(reportID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetAbuseReport(ctx, db.GetAbuseReportParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toAbuseReport(row), nil
+}
+
+func (r *PostgresAbuseReportRepository) ListOpen(ctx context.Context, tenantID string) ([]*entities.AbuseReport, error) {
+	rows, err := r.queries.ListOpenAbuseReports(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	reports := make([]*entities.AbuseReport, 0, len(rows))
+	for _, row := range rows {
+		reports = append(reports, toAbuseReport(row))
+	}
+
+	return reports, nil
+}
+
+func (r *PostgresAbuseReportRepository) Resolve(ctx context.Context, report *entities.AbuseReport) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(report.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.ResolveAbuseReport(ctx, db.ResolveAbuseReportParams{
+		Status:     string(report.Status),
+		ResolvedBy: &report.ResolvedBy,
+		ResolvedAt: pgtype.Timestamptz{Time: *report.ResolvedAt, Valid: true},
+		ID:         pgID,
+		TenantID:   report.TenantID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func toAbuseReport(row db.AbuseReport) *entities.AbuseReport {
+	return &entities.AbuseReport{
+		ID:             row.ID.String(),
+		TenantID:       row.TenantID,
+		MessageID:      row.MessageID.String(),
+		ConversationID: row.ConversationID.String(),
+		ReporterID:     row.ReporterID,
+		Reason:         row.Reason,
+		Status:         entities.AbuseReportStatus(row.Status),
+		ResolvedBy:     valueOrEmpty(row.ResolvedBy),
+		ResolvedAt:     timeOrNil(row.ResolvedAt),
+		CreatedAt:      row.CreatedAt.Time,
+	}
+}
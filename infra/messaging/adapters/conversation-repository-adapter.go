@@ -0,0 +1,238 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresConversationRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresConversationRepository(dbInstance *pgxpool.Pool) *PostgresConversationRepository {
+	return &PostgresConversationRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+// FindOrCreate relies on the unique conversation key, concurrent first messages between the same
+// users end up in the same conversation
+func (r *PostgresConversationRepository) FindOrCreate(ctx context.Context, conversation *entities.Conversation) (*entities.Conversation, error) {
+	var pgID, pgClassID pgtype.UUID
+	if err := pgID.Scan(conversation.ID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if conversation.ClassID != "" {
+		if err := pgClassID.Scan(conversation.ClassID); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+	}
+	key := conversationKey(conversation)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.InsertConversation(ctx, db.InsertConversationParams{
+		ID:              pgID,
+		TenantID:        conversation.TenantID,
+		Kind:            string(conversation.Kind),
+		ClassID:         pgClassID,
+		ConversationKey: key,
+		CreatedAt:       pgtype.Timestamptz{Time: conversation.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := qtx.GetConversationByKey(ctx, db.GetConversationByKeyParams{TenantID: conversation.TenantID, ConversationKey: key})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	for _, userID := range conversation.ParticipantIDs {
+		err := qtx.InsertConversationParticipant(ctx, db.InsertConversationParticipantParams{ConversationID: row.ID, UserID: userID})
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toConversation(row, conversation.ParticipantIDs), nil
+}
+
+func (r *PostgresConversationRepository) FindByID(ctx context.Context, tenantID string, conversationID string) (*entities.Conversation, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(conversationID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetConversation(ctx, db.GetConversationParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	participants, err := r.queries.ListConversationParticipants(ctx, row.ID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toConversation(row, participants), nil
+}
+
+func (r *PostgresConversationRepository) ListForUser(ctx context.Context, tenantID string, userID string, classIDs []string,
+	allClasses bool) ([]entities.ConversationSummary, error) {
+	pgClassIDs := make([]pgtype.UUID, len(classIDs))
+	for i, classID := range classIDs {
+		if err := pgClassIDs[i].Scan(classID); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+	}
+
+	rows, err := r.queries.ListUserConversations(ctx, db.ListUserConversationsParams{
+		UserID:     userID,
+		TenantID:   tenantID,
+		AllClasses: allClasses,
+		ClassIds:   pgClassIDs,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	summaries := make([]entities.ConversationSummary, 0, len(rows))
+	for _, row := range rows {
+		conversation := &entities.Conversation{
+			ID:             row.ID.String(),
+			TenantID:       row.TenantID,
+			Kind:           entities.ConversationKind(row.Kind),
+			ParticipantIDs: row.ParticipantIds,
+			CreatedAt:      row.CreatedAt.Time,
+			LastMessageAt:  timeOrNil(row.LastMessageAt),
+		}
+		if row.ClassID.Valid {
+			conversation.ClassID = row.ClassID.String()
+		}
+		summaries = append(summaries, entities.ConversationSummary{
+			Conversation: conversation,
+			LastReadAt:   timeOrNil(row.LastReadAt),
+			UnreadCount:  int(row.UnreadCount),
+		})
+	}
+
+	return summaries, nil
+}
+
+func (r *PostgresConversationRepository) MarkRead(ctx context.Context, receipt entities.ReadReceipt) error {
+	var pgConversationID pgtype.UUID
+	if err := pgConversationID.Scan(receipt.ConversationID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.UpsertReadReceipt(ctx, db.UpsertReadReceiptParams{
+		ConversationID: pgConversationID,
+		UserID:         receipt.UserID,
+		ReadAt:         pgtype.Timestamptz{Time: receipt.ReadAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+// SaveReceipts upserts receipts in one statement, a receipt never moves read_at back
+func (r *PostgresConversationRepository) SaveReceipts(ctx context.Context, receipts []entities.ReadReceipt) error {
+	params := db.UpsertReadReceiptsParams{
+		ConversationIds: make([]pgtype.UUID, len(receipts)),
+		UserIds:         make([]string, len(receipts)),
+		ReadAts:         make([]pgtype.Timestamptz, len(receipts)),
+	}
+	for i, receipt := range receipts {
+		if err := params.ConversationIds[i].Scan(receipt.ConversationID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+		params.UserIds[i] = receipt.UserID
+		params.ReadAts[i] = pgtype.Timestamptz{Time: receipt.ReadAt, Valid: true}
+	}
+
+	if err := r.queries.UpsertReadReceipts(ctx, params); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresConversationRepository) ListReceipts(ctx context.Context, tenantID string, conversationID string) ([]entities.ReadReceipt, error) {
+	var pgConversationID pgtype.UUID
+	if err := pgConversationID.Scan(conversationID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListReadReceipts(ctx, db.ListReadReceiptsParams{ConversationID: pgConversationID, TenantID: tenantID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	receipts := make([]entities.ReadReceipt, 0, len(rows))
+	for _, row := range rows {
+		receipts = append(receipts, entities.ReadReceipt{
+			ConversationID: row.ConversationID.String(),
+			UserID:         row.UserID,
+			ReadAt:         row.ReadAt.Time,
+		})
+	}
+
+	return receipts, nil
+}
+
+func conversationKey(conversation *entities.Conversation) string {
+	if conversation.Kind == entities.ConversationKindClass {
+		return "class:" + conversation.ClassID
+	}
+	return "direct:" + strings.Join(conversation.ParticipantIDs, "|")
+}
+
+func toConversation(row db.Conversation, participantIDs []string) *entities.Conversation {
+	conversation := &entities.Conversation{
+		ID:             row.ID.String(),
+		TenantID:       row.TenantID,
+		Kind:           entities.ConversationKind(row.Kind),
+		ParticipantIDs: participantIDs,
+		CreatedAt:      row.CreatedAt.Time,
+		LastMessageAt:  timeOrNil(row.LastMessageAt),
+	}
+	if row.ClassID.Valid {
+		conversation.ClassID = row.ClassID.String()
+	}
+
+	return conversation
+}
+
+func timeOrNil(value pgtype.Timestamptz) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
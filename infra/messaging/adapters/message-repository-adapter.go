@@ -0,0 +1,247 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresMessageRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresMessageRepository(dbInstance *pgxpool.Pool) ports.MessageRepository {
+	return &PostgresMessageRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresMessageRepository) Append(ctx context.Context, message *entities.Message) error {
+	var pgID, pgConversationID pgtype.UUID
+	if err := pgID.Scan(message.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgConversationID.Scan(message.ConversationID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	createdAt := pgtype.Timestamptz{Time: message.CreatedAt, Valid: true}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.InsertMessage(ctx, db.InsertMessageParams{
+		ID:             pgID,
+		TenantID:       message.TenantID,
+		ConversationID: pgConversationID,
+		SenderID:       message.SenderID,
+		Body:           message.Body,
+		CreatedAt:      createdAt,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	var attachedBytes int64
+	for _, attachment := range message.Attachments {
+		attachedBytes += attachment.Size
+		var pgAttachmentID pgtype.UUID
+		if err := pgAttachmentID.Scan(attachment.ID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+		err := qtx.InsertMessageAttachment(ctx, db.InsertMessageAttachmentParams{
+			ID:            pgAttachmentID,
+			MessageID:     pgID,
+			FileName:      attachment.FileName,
+			ContentType:   attachment.ContentType,
+			SizeBytes:     attachment.Size,
+			ScanStatus:    string(attachment.ScanStatus),
+			AltText:       attachment.AltText,
+			CaptionStatus: string(attachment.CaptionStatus),
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+	if len(message.Attachments) > 0 {
+		err = qtx.AddStorageUsage(ctx, db.AddStorageUsageParams{
+			TenantID:  message.TenantID,
+			OwnerID:   message.SenderID,
+			Bytes:     attachedBytes,
+			Files:     int64(len(message.Attachments)),
+			UpdatedAt: createdAt,
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if err := qtx.TouchConversation(ctx, db.TouchConversationParams{LastMessageAt: createdAt, ID: pgConversationID}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresMessageRepository) FindByID(ctx context.Context, tenantID string, messageID string) (*entities.Message, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(messageID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetMessage(ctx, db.GetMessageParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	messages, err := r.withAttachments(ctx, []db.Message{row})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages[0], nil
+}
+
+func (r *PostgresMessageRepository) FindByAttachment(ctx context.Context, tenantID string, attachmentID string) (*entities.Message, error) {
+	var pgAttachmentID pgtype.UUID
+	if err := pgAttachmentID.Scan(attachmentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetMessageByAttachment(ctx, db.GetMessageByAttachmentParams{AttachmentID: pgAttachmentID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	messages, err := r.withAttachments(ctx, []db.Message{row})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages[0], nil
+}
+
+func (r *PostgresMessageRepository) List(ctx context.Context, tenantID string, conversationID string, before *time.Time,
+	limit int) ([]*entities.Message, error) {
+	var pgConversationID pgtype.UUID
+	if err := pgConversationID.Scan(conversationID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	params := db.ListMessagesParams{TenantID: tenantID, ConversationID: pgConversationID, PageSize: int32(limit)}
+	if before != nil {
+		params.Before = pgtype.Timestamptz{Time: *before, Valid: true}
+	}
+
+	rows, err := r.queries.ListMessages(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return r.withAttachments(ctx, rows)
+}
+
+func (r *PostgresMessageRepository) Hide(ctx context.Context, message *entities.Message) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(message.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.HideMessage(ctx, db.HideMessageParams{
+		HiddenAt: pgtype.Timestamptz{Time: *message.HiddenAt, Valid: true},
+		HiddenBy: &message.HiddenBy,
+		ID:       pgID,
+		TenantID: message.TenantID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+// withAttachments loads the attachments of all rows in one query
+func (r *PostgresMessageRepository) withAttachments(ctx context.Context, rows []db.Message) ([]*entities.Message, error) {
+	if len(rows) == 0 {
+		return []*entities.Message{}, nil
+	}
+
+	ids := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	attachmentRows, err := r.queries.ListMessageAttachments(ctx, ids)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	attachments := make(map[string][]entities.Attachment, len(rows))
+	for _, row := range attachmentRows {
+		messageID := row.MessageID.String()
+		attachments[messageID] = append(attachments[messageID], entities.Attachment{
+			ID:            row.ID.String(),
+			FileName:      row.FileName,
+			ContentType:   row.ContentType,
+			Size:          row.SizeBytes,
+			ScanStatus:    storage.ScanStatus(row.ScanStatus),
+			PreviewStatus: storage.PreviewStatus(row.PreviewStatus),
+			AltText:       row.AltText,
+			CaptionStatus: storage.CaptionStatus(row.CaptionStatus),
+		})
+	}
+
+	messages := make([]*entities.Message, 0, len(rows))
+	for _, row := range rows {
+		message, err := entities.NewMessage(
+			row.ID.String(),
+			row.TenantID,
+			row.ConversationID.String(),
+			row.SenderID,
+			row.Body,
+			attachments[row.ID.String()],
+			timeOrNil(row.HiddenAt),
+			valueOrEmpty(row.HiddenBy),
+			row.CreatedAt.Time,
+		)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
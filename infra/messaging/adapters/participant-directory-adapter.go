@@ -0,0 +1,136 @@
+package adapters
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// staffRoles act as staff in messaging, the first matching role wins so a user who is also a
+// guardian is still treated as staff
+var staffRoles = []string{"admin", "instructor", "department_head"}
+
+// PostgresParticipantDirectory reads roles from casbin and class membership and guardian links from
+// the database
+type PostgresParticipantDirectory struct {
+	queries       *db.Queries
+	casbinService *authorization.CasbinService
+}
+
+func NewPostgresParticipantDirectory(dbInstance *pgxpool.Pool, casbinService *authorization.CasbinService) ports.ParticipantDirectory {
+	return &PostgresParticipantDirectory{
+		queries:       db.New(database.Reads.Wrap(dbInstance)),
+		casbinService: casbinService,
+	}
+}
+
+func (d *PostgresParticipantDirectory) Participant(ctx context.Context, tenantID string, userID string) (*entities.Participant, error) {
+	roles, rolesErr := d.casbinService.GetUserRoles(userID, tenantID)
+	if rolesErr != nil {
+		return nil, rolesErr
+	}
+
+	switch {
+	case slices.ContainsFunc(roles, func(role string) bool { return slices.Contains(staffRoles, role) }):
+		return &entities.Participant{UserID: userID, Role: entities.ParticipantRoleStaff}, nil
+	case slices.Contains(roles, "guardian"):
+		return &entities.Participant{UserID: userID, Role: entities.ParticipantRoleGuardian}, nil
+	case slices.Contains(roles, "student"):
+		return &entities.Participant{UserID: userID, Role: entities.ParticipantRoleStudent}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (d *PostgresParticipantDirectory) IsGuardianOf(ctx context.Context, tenantID string, guardianID string, studentID string) (bool, error) {
+	linked, err := d.queries.GuardianLinkExists(ctx, db.GuardianLinkExistsParams{
+		TenantID:   tenantID,
+		GuardianID: guardianID,
+		StudentID:  studentID,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return linked, nil
+}
+
+func (d *PostgresParticipantDirectory) LinkGuardian(ctx context.Context, tenantID string, guardianID string, studentID string) error {
+	err := d.queries.InsertGuardianLink(ctx, db.InsertGuardianLinkParams{
+		TenantID:   tenantID,
+		GuardianID: guardianID,
+		StudentID:  studentID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (d *PostgresParticipantDirectory) ClassAccess(ctx context.Context, tenantID string, classID string,
+	participant entities.Participant) (entities.ClassAccess, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return entities.ClassAccess{}, appErrors.PropagateError(err)
+	}
+
+	switch participant.Role {
+	case entities.ParticipantRoleStudent:
+		enrolled, err := d.queries.ClassEnrollmentExists(ctx, db.ClassEnrollmentExistsParams{
+			TenantID: tenantID,
+			ClassID:  pgClassID,
+			UserID:   participant.UserID,
+		})
+		if err != nil {
+			return entities.ClassAccess{}, appErrors.PropagateError(err)
+		}
+		return entities.ClassAccess{Enrolled: enrolled}, nil
+	case entities.ParticipantRoleGuardian:
+		guardian, err := d.queries.GuardianOfClassStudent(ctx, db.GuardianOfClassStudentParams{
+			TenantID:   tenantID,
+			GuardianID: participant.UserID,
+			ClassID:    pgClassID,
+		})
+		if err != nil {
+			return entities.ClassAccess{}, appErrors.PropagateError(err)
+		}
+		return entities.ClassAccess{GuardianOfEnrolled: guardian}, nil
+	default:
+		return entities.ClassAccess{}, nil
+	}
+}
+
+func (d *PostgresParticipantDirectory) ClassIDs(ctx context.Context, tenantID string, participant entities.Participant) ([]string, error) {
+	var (
+		rows []pgtype.UUID
+		err  error
+	)
+	switch participant.Role {
+	case entities.ParticipantRoleStudent:
+		rows, err = d.queries.ListStudentClassIDs(ctx, db.ListStudentClassIDsParams{TenantID: tenantID, UserID: participant.UserID})
+	case entities.ParticipantRoleGuardian:
+		rows, err = d.queries.ListGuardianClassIDs(ctx, db.ListGuardianClassIDsParams{TenantID: tenantID, GuardianID: participant.UserID})
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	classIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		classIDs = append(classIDs, row.String())
+	}
+
+	return classIDs, nil
+}
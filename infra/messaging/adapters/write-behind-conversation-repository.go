@@ -0,0 +1,67 @@
+package adapters
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+)
+
+// WriteBehindConversationRepository saves read receipts in the background, opening a conversation
+// does not wait for the write. Receipts listed on this instance include the pending ones, unread
+// counts and other instances catch up within the flush interval. Receipts of a crash are lost, the
+// next read marks the conversation again.
+type WriteBehindConversationRepository struct {
+	*PostgresConversationRepository
+	receipts *database.WriteBehind[receiptKey, entities.ReadReceipt]
+}
+
+type receiptKey struct {
+	conversationID string
+	userID         string
+}
+
+func NewWriteBehindConversationRepository(next *PostgresConversationRepository, config database.WriteBehindConfig) *WriteBehindConversationRepository {
+	return &WriteBehindConversationRepository{
+		PostgresConversationRepository: next,
+		receipts: database.NewWriteBehind[receiptKey](
+			"read_receipts", config, laterReceipt, next.SaveReceipts),
+	}
+}
+
+// MarkRead queues the receipt, a full buffer drops it rather than slowing the request down
+func (r *WriteBehindConversationRepository) MarkRead(_ context.Context, receipt entities.ReadReceipt) error {
+	r.receipts.Write(receiptKey{conversationID: receipt.ConversationID, userID: receipt.UserID}, receipt)
+	return nil
+}
+
+func (r *WriteBehindConversationRepository) ListReceipts(ctx context.Context, tenantID string, conversationID string) ([]entities.ReadReceipt, error) {
+	receipts, err := r.PostgresConversationRepository.ListReceipts(ctx, tenantID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := r.receipts.Pending(func(key receiptKey) bool { return key.conversationID == conversationID })
+	for _, queued := range pending {
+		i := slices.IndexFunc(receipts, func(receipt entities.ReadReceipt) bool { return receipt.UserID == queued.UserID })
+		if i < 0 {
+			receipts = append(receipts, queued)
+			continue
+		}
+		receipts[i] = laterReceipt(receipts[i], queued)
+	}
+	return receipts, nil
+}
+
+// Writer saves the queued receipts, main runs it
+func (r *WriteBehindConversationRepository) Writer() database.BackgroundWriter {
+	return r.receipts
+}
+
+func laterReceipt(pending entities.ReadReceipt, write entities.ReadReceipt) entities.ReadReceipt {
+	if write.ReadAt.After(pending.ReadAt) {
+		return write
+	}
+	return pending
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"time"
+
+	messaging_service "github.com/nahualventure/class-backend/core/app/messaging/application/messaging-service"
+	"github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+)
+
+// maxMessageBodyBytes leaves room for every attachment at full size, base64 grows them by a third
+const maxMessageBodyBytes = entities.MaxAttachmentsPerMessage*entities.MaxAttachmentBytes*4/3 + 64*1024
+
+type AttachmentUploadRequest struct {
+	FileName    string `json:"file_name" minLength:"1" maxLength:"255"`
+	ContentType string `json:"content_type" minLength:"1" maxLength:"100" example:"image/png"`
+	Content     []byte `json:"content" doc:"Base64 encoded file, at most 10 MiB"`
+	AltText     string `json:"alt_text,omitempty" maxLength:"1000" doc:"Text alternative read by screen readers, required for images"`
+}
+
+type MessageBodyRequest struct {
+	Body        string                    `json:"body,omitempty" maxLength:"5000"`
+	Attachments []AttachmentUploadRequest `json:"attachments,omitempty" maxItems:"5"`
+}
+
+func (b MessageBodyRequest) uploads() []messaging_service.AttachmentUpload {
+	uploads := make([]messaging_service.AttachmentUpload, 0, len(b.Attachments))
+	for _, attachment := range b.Attachments {
+		uploads = append(uploads, messaging_service.AttachmentUpload{
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			Content:     attachment.Content,
+			AltText:     attachment.AltText,
+		})
+	}
+	return uploads
+}
+
+type SendDirectMessageRequest struct {
+	RecipientID string `path:"recipientId" doc:"User ID of the recipient"`
+	Body        MessageBodyRequest
+}
+
+type PostClassMessageRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	Body    MessageBodyRequest
+}
+
+type AttachmentResponse struct {
+	ID            string `json:"id"`
+	FileName      string `json:"file_name"`
+	ContentType   string `json:"content_type"`
+	Size          int64  `json:"size"`
+	ScanStatus    string `json:"scan_status" enum:"pending,clean,infected,missing" doc:"Virus scan of the file, only clean attachments are downloadable"`
+	PreviewStatus string `json:"preview_status" enum:"pending,ready,unsupported,failed" doc:"Thumbnail of images and of the first page of documents, downloadable with preview=true once ready"`
+	AltText       string `json:"alt_text,omitempty"`
+	CaptionStatus string `json:"caption_status" enum:"pending,ready,not_needed,unsupported,failed" doc:"Captions and transcript of videos and audio, downloadable with alternative once ready"`
+}
+
+type MessageResponse struct {
+	ID             string               `json:"id"`
+	ConversationID string               `json:"conversation_id"`
+	SenderID       string               `json:"sender_id"`
+	SenderName     string               `json:"sender_name,omitempty" doc:"Name of the sender, absent when the sender is not a user of the directory"`
+	Body           string               `json:"body"`
+	Attachments    []AttachmentResponse `json:"attachments"`
+	Hidden         bool                 `json:"hidden" doc:"Hidden by a moderator, body and attachments are only shown to staff"`
+	ReadBy         []string             `json:"read_by,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+}
+
+func NewMessageResponse(message *entities.Message, readBy []string) MessageResponse {
+	attachments := make([]AttachmentResponse, 0, len(message.Attachments))
+	for _, attachment := range message.Attachments {
+		attachments = append(attachments, AttachmentResponse{
+			ID:            attachment.ID,
+			FileName:      attachment.FileName,
+			ContentType:   attachment.ContentType,
+			Size:          attachment.Size,
+			ScanStatus:    string(attachment.ScanStatus),
+			PreviewStatus: string(attachment.PreviewStatus),
+			AltText:       attachment.AltText,
+			CaptionStatus: string(attachment.CaptionStatus),
+		})
+	}
+
+	return MessageResponse{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		SenderID:       message.SenderID,
+		Body:           message.Body,
+		Attachments:    attachments,
+		Hidden:         message.IsHidden(),
+		ReadBy:         readBy,
+		CreatedAt:      message.CreatedAt,
+	}
+}
+
+type MessageEnvelope struct {
+	Body MessageResponse
+}
+
+type ConversationResponse struct {
+	ID             string     `json:"id"`
+	Kind           string     `json:"kind" enum:"direct,class"`
+	ClassID        string     `json:"class_id,omitempty"`
+	ParticipantIDs []string   `json:"participant_ids,omitempty"`
+	LastMessageAt  *time.Time `json:"last_message_at,omitempty"`
+	LastReadAt     *time.Time `json:"last_read_at,omitempty"`
+	UnreadCount    int        `json:"unread_count"`
+}
+
+type ConversationListResponse struct {
+	Body struct {
+		Items []ConversationResponse `json:"items"`
+	}
+}
+
+type ListConversationMessagesRequest struct {
+	ConversationID string    `path:"conversationId" format:"uuid"`
+	Before         time.Time `query:"before" doc:"Only messages sent before this time, for paging backwards"`
+	Limit          int       `query:"limit" minimum:"0" maximum:"100" doc:"Page size, 50 when omitted"`
+}
+
+type MessageListResponse struct {
+	Body struct {
+		Items []MessageResponse `json:"items"`
+	}
+}
+
+type ConversationRequest struct {
+	ConversationID string `path:"conversationId" format:"uuid"`
+}
+
+type ReadReceiptResponse struct {
+	Body struct {
+		ConversationID string    `json:"conversation_id"`
+		ReadAt         time.Time `json:"read_at"`
+	}
+}
+
+type DownloadAttachmentRequest struct {
+	AttachmentID string `path:"attachmentId" format:"uuid"`
+	Preview      bool   `query:"preview" doc:"Download the PNG preview of the attachment instead of its file"`
+	// Alternative cannot be combined with preview
+	Alternative string `query:"alternative" enum:"captions,transcript" doc:"Download the WebVTT captions or the transcript of a video or audio attachment once its captions are ready"`
+	export.RangeRequest
+}
+
+type ReportMessageRequest struct {
+	MessageID string `path:"messageId" format:"uuid"`
+	Body      struct {
+		Reason string `json:"reason" minLength:"1" maxLength:"1000"`
+	}
+}
+
+type AbuseReportResponse struct {
+	ID             string     `json:"id"`
+	MessageID      string     `json:"message_id"`
+	ConversationID string     `json:"conversation_id"`
+	ReporterID     string     `json:"reporter_id"`
+	Reason         string     `json:"reason"`
+	Status         string     `json:"status" enum:"open,dismissed,actioned"`
+	ResolvedBy     string     `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func NewAbuseReportResponse(report *entities.AbuseReport) AbuseReportResponse {
+	return AbuseReportResponse{
+		ID:             report.ID,
+		MessageID:      report.MessageID,
+		ConversationID: report.ConversationID,
+		ReporterID:     report.ReporterID,
+		Reason:         report.Reason,
+		Status:         string(report.Status),
+		ResolvedBy:     report.ResolvedBy,
+		ResolvedAt:     report.ResolvedAt,
+		CreatedAt:      report.CreatedAt,
+	}
+}
+
+type AbuseReportEnvelope struct {
+	Body AbuseReportResponse
+}
+
+type AbuseReportListResponse struct {
+	Body struct {
+		Items []AbuseReportResponse `json:"items"`
+	}
+}
+
+type ResolveAbuseReportRequest struct {
+	ReportID string `path:"reportId" format:"uuid"`
+	Body     struct {
+		Resolution string `json:"resolution" enum:"dismiss,hide" doc:"hide also hides the reported message"`
+	}
+}
+
+type LinkGuardianRequest struct {
+	GuardianID string `path:"guardianId"`
+	StudentID  string `path:"studentId"`
+}
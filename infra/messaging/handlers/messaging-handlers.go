@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	messaging_service "github.com/nahualventure/class-backend/core/app/messaging/application/messaging-service"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	user_loader "github.com/nahualventure/class-backend/core/app/user/application/user-loader"
+	userPorts "github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type MessagingHandlers struct {
+	messagingService *messaging_service.MessagingService
+	userReader       userPorts.UserReader
+}
+
+func NewMessagingHandlers(messagingService *messaging_service.MessagingService, userReader userPorts.UserReader) *MessagingHandlers {
+	return &MessagingHandlers{
+		messagingService: messagingService,
+		userReader:       userReader,
+	}
+}
+
+func (h *MessagingHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "send-direct-message",
+		Method:        http.MethodPost,
+		Path:          "/messages/direct/{recipientId}",
+		Summary:       "Send a direct message",
+		Description:   "Students and guardians can message staff and each other when linked, staff can message anyone.",
+		Tags:          []string{"Messaging"},
+		DefaultStatus: http.StatusCreated,
+		MaxBodyBytes:  maxMessageBodyBytes,
+	}, h.SendDirectMessage)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "post-class-message",
+		Method:        http.MethodPost,
+		Path:          "/classes/{classId}/messages",
+		Summary:       "Post to the class thread",
+		Description:   "Staff and enrolled students can post, guardians of enrolled students can only read.",
+		Tags:          []string{"Messaging"},
+		DefaultStatus: http.StatusCreated,
+		MaxBodyBytes:  maxMessageBodyBytes,
+	}, h.PostClassMessage)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-conversations",
+		Method:      http.MethodGet,
+		Path:        "/conversations",
+		Summary:     "List the conversations of the current user with unread counts",
+		Tags:        []string{"Messaging"},
+	}, h.ListConversations)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-conversation-messages",
+		Method:      http.MethodGet,
+		Path:        "/conversations/{conversationId}/messages",
+		Summary:     "List the messages of a conversation, newest first",
+		Tags:        []string{"Messaging"},
+	}, h.ListConversationMessages)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "mark-conversation-read",
+		Method:      http.MethodPost,
+		Path:        "/conversations/{conversationId}/read",
+		Summary:     "Mark the conversation as read up to now",
+		Tags:        []string{"Messaging"},
+	}, h.MarkConversationRead)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "download-attachment",
+		Method:      http.MethodGet,
+		Path:        "/attachments/{attachmentId}",
+		Summary:     "Download a message attachment",
+		Tags:        []string{"Messaging"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Attachment content",
+				Content:     map[string]*huma.MediaType{"application/octet-stream": {}},
+			},
+		},
+	}, h.DownloadAttachment)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "report-message",
+		Method:        http.MethodPost,
+		Path:          "/messages/{messageId}/reports",
+		Summary:       "Report an abusive message to the moderators",
+		Tags:          []string{"Messaging"},
+		DefaultStatus: http.StatusCreated,
+	}, h.ReportMessage)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-abuse-reports",
+		Method:      http.MethodGet,
+		Path:        "/abuse-reports",
+		Summary:     "List the open abuse reports",
+		Tags:        []string{"Messaging"},
+	}, h.ListAbuseReports)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "resolve-abuse-report",
+		Method:      http.MethodPost,
+		Path:        "/abuse-reports/{reportId}/resolve",
+		Summary:     "Dismiss an abuse report or hide the reported message",
+		Tags:        []string{"Messaging"},
+	}, h.ResolveAbuseReport)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "link-guardian",
+		Method:        http.MethodPut,
+		Path:          "/guardians/{guardianId}/students/{studentId}",
+		Summary:       "Link a guardian to a student",
+		Tags:          []string{"Messaging"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.LinkGuardian)
+}
+
+func (h *MessagingHandlers) SendDirectMessage(ctx context.Context, input *SendDirectMessageRequest) (*MessageEnvelope, error) {
+	command, err := messaging_service.NewSendDirectMessageCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.RecipientID,
+		input.Body.Body,
+		input.Body.uploads(),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	message, err := h.messagingService.SendDirectMessage(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &MessageEnvelope{Body: NewMessageResponse(message, nil)}, nil
+}
+
+func (h *MessagingHandlers) PostClassMessage(ctx context.Context, input *PostClassMessageRequest) (*MessageEnvelope, error) {
+	command, err := messaging_service.NewPostClassMessageCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.ClassID,
+		input.Body.Body,
+		input.Body.uploads(),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	message, err := h.messagingService.PostClassMessage(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &MessageEnvelope{Body: NewMessageResponse(message, nil)}, nil
+}
+
+func (h *MessagingHandlers) ListConversations(ctx context.Context, input *struct{}) (*ConversationListResponse, error) {
+	command, err := messaging_service.NewListConversationsCommand(authorization.TenantIDFromContext(ctx), authorization.UserIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	summaries, err := h.messagingService.ListConversations(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ConversationListResponse{}
+	response.Body.Items = make([]ConversationResponse, 0, len(summaries))
+	for _, summary := range summaries {
+		response.Body.Items = append(response.Body.Items, ConversationResponse{
+			ID:             summary.Conversation.ID,
+			Kind:           string(summary.Conversation.Kind),
+			ClassID:        summary.Conversation.ClassID,
+			ParticipantIDs: summary.Conversation.ParticipantIDs,
+			LastMessageAt:  summary.Conversation.LastMessageAt,
+			LastReadAt:     summary.LastReadAt,
+			UnreadCount:    summary.UnreadCount,
+		})
+	}
+
+	return response, nil
+}
+
+func (h *MessagingHandlers) ListConversationMessages(ctx context.Context, input *ListConversationMessagesRequest) (*MessageListResponse, error) {
+	var before *time.Time
+	if !input.Before.IsZero() {
+		before = &input.Before
+	}
+
+	command, err := messaging_service.NewListMessagesCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.ConversationID,
+		before,
+		input.Limit,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	views, err := h.messagingService.ListMessages(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	senderIDs := make([]string, 0, len(views))
+	for _, view := range views {
+		senderIDs = append(senderIDs, view.Message.SenderID)
+	}
+	senders, err := user_loader.For(ctx, h.userReader).LoadMany(ctx, senderIDs)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &MessageListResponse{}
+	response.Body.Items = make([]MessageResponse, 0, len(views))
+	for _, view := range views {
+		item := NewMessageResponse(view.Message, view.ReadBy)
+		if sender, ok := senders[view.Message.SenderID]; ok {
+			item.SenderName = sender.Name
+		}
+		response.Body.Items = append(response.Body.Items, item)
+	}
+
+	return response, nil
+}
+
+func (h *MessagingHandlers) MarkConversationRead(ctx context.Context, input *ConversationRequest) (*ReadReceiptResponse, error) {
+	command, err := messaging_service.NewMarkReadCommand(authorization.TenantIDFromContext(ctx), authorization.UserIDFromContext(ctx), input.ConversationID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	receipt, err := h.messagingService.MarkRead(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ReadReceiptResponse{}
+	response.Body.ConversationID = receipt.ConversationID
+	response.Body.ReadAt = receipt.ReadAt
+
+	return response, nil
+}
+
+func (h *MessagingHandlers) DownloadAttachment(ctx context.Context, input *DownloadAttachmentRequest) (*huma.StreamResponse, error) {
+	command, err := messaging_service.NewGetAttachmentCommand(authorization.TenantIDFromContext(ctx), authorization.UserIDFromContext(ctx), input.AttachmentID,
+		input.Preview, storage.AlternativeFormat(input.Alternative))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	attachment, content, err := h.messagingService.GetAttachment(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	download := export.FileDownload{
+		Filename:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Content:     content,
+	}
+	if input.Preview {
+		download = download.AsPreview()
+	}
+	if input.Alternative != "" {
+		download = download.AsAlternative(storage.AlternativeFormat(input.Alternative))
+	}
+	return download.Response(input.RangeRequest), nil
+}
+
+func (h *MessagingHandlers) ReportMessage(ctx context.Context, input *ReportMessageRequest) (*AbuseReportEnvelope, error) {
+	command, err := messaging_service.NewReportMessageCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.MessageID,
+		input.Body.Reason,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	report, err := h.messagingService.ReportMessage(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &AbuseReportEnvelope{Body: NewAbuseReportResponse(report)}, nil
+}
+
+func (h *MessagingHandlers) ListAbuseReports(ctx context.Context, input *struct{}) (*AbuseReportListResponse, error) {
+	command, err := messaging_service.NewListAbuseReportsCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	reports, err := h.messagingService.ListAbuseReports(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &AbuseReportListResponse{}
+	response.Body.Items = make([]AbuseReportResponse, 0, len(reports))
+	for _, report := range reports {
+		response.Body.Items = append(response.Body.Items, NewAbuseReportResponse(report))
+	}
+
+	return response, nil
+}
+
+func (h *MessagingHandlers) ResolveAbuseReport(ctx context.Context, input *ResolveAbuseReportRequest) (*AbuseReportEnvelope, error) {
+	command, err := messaging_service.NewResolveAbuseReportCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.ReportID,
+		input.Body.Resolution,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	report, err := h.messagingService.ResolveAbuseReport(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &AbuseReportEnvelope{Body: NewAbuseReportResponse(report)}, nil
+}
+
+func (h *MessagingHandlers) LinkGuardian(ctx context.Context, input *LinkGuardianRequest) (*struct{}, error) {
+	command, err := messaging_service.NewLinkGuardianCommand(authorization.TenantIDFromContext(ctx), input.GuardianID, input.StudentID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.messagingService.LinkGuardian(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
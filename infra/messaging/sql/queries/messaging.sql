@@ -0,0 +1,159 @@
+-- name: InsertConversation :exec
+INSERT INTO conversations (id, tenant_id, kind, class_id, conversation_key, created_at)
+VALUES (@id, @tenant_id, @kind, @class_id, @conversation_key, @created_at)
+ON CONFLICT (tenant_id, conversation_key) DO NOTHING;
+
+-- name: GetConversationByKey :one
+SELECT id, tenant_id, kind, class_id, conversation_key, created_at, last_message_at
+FROM conversations
+WHERE tenant_id = @tenant_id AND conversation_key = @conversation_key;
+
+-- name: GetConversation :one
+SELECT id, tenant_id, kind, class_id, conversation_key, created_at, last_message_at
+FROM conversations
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: InsertConversationParticipant :exec
+INSERT INTO conversation_participants (conversation_id, user_id)
+VALUES (@conversation_id, @user_id)
+ON CONFLICT DO NOTHING;
+
+-- name: ListConversationParticipants :many
+SELECT user_id
+FROM conversation_participants
+WHERE conversation_id = @conversation_id
+ORDER BY user_id;
+
+-- name: ListUserConversations :many
+SELECT c.id, c.tenant_id, c.kind, c.class_id, c.created_at, c.last_message_at,
+       ARRAY(
+           SELECT p.user_id FROM conversation_participants p
+           WHERE p.conversation_id = c.id ORDER BY p.user_id
+       )::text[] AS participant_ids,
+       r.read_at AS last_read_at,
+       (
+           SELECT COUNT(*) FROM messages m
+           WHERE m.conversation_id = c.id AND m.sender_id <> @user_id
+             AND (r.read_at IS NULL OR m.created_at > r.read_at)
+       )::int AS unread_count
+FROM conversations c
+LEFT JOIN read_receipts r ON r.conversation_id = c.id AND r.user_id = @user_id
+WHERE c.tenant_id = @tenant_id
+  AND (
+      EXISTS (SELECT 1 FROM conversation_participants p WHERE p.conversation_id = c.id AND p.user_id = @user_id)
+      OR (c.kind = 'class' AND (@all_classes::boolean OR c.class_id = ANY(@class_ids::uuid[])))
+  )
+ORDER BY COALESCE(c.last_message_at, c.created_at) DESC;
+
+-- name: UpsertReadReceipt :exec
+INSERT INTO read_receipts (conversation_id, user_id, read_at)
+VALUES (@conversation_id, @user_id, @read_at)
+ON CONFLICT (conversation_id, user_id) DO UPDATE
+SET read_at = GREATEST(read_receipts.read_at, EXCLUDED.read_at);
+
+-- Receipts buffered by the write-behind, a conversation and user appear once per batch
+-- name: UpsertReadReceipts :exec
+INSERT INTO read_receipts (conversation_id, user_id, read_at)
+SELECT unnest(@conversation_ids::uuid[]), unnest(@user_ids::text[]), unnest(@read_ats::timestamptz[])
+ON CONFLICT (conversation_id, user_id) DO UPDATE
+SET read_at = GREATEST(read_receipts.read_at, EXCLUDED.read_at);
+
+-- name: ListReadReceipts :many
+SELECT r.conversation_id, r.user_id, r.read_at
+FROM read_receipts r
+JOIN conversations c ON c.id = r.conversation_id
+WHERE r.conversation_id = @conversation_id AND c.tenant_id = @tenant_id;
+
+-- name: InsertMessage :exec
+INSERT INTO messages (id, tenant_id, conversation_id, sender_id, body, created_at)
+VALUES (@id, @tenant_id, @conversation_id, @sender_id, @body, @created_at);
+
+-- name: InsertMessageAttachment :exec
+INSERT INTO message_attachments (id, message_id, file_name, content_type, size_bytes, scan_status, alt_text, caption_status)
+VALUES (@id, @message_id, @file_name, @content_type, @size_bytes, @scan_status, @alt_text, @caption_status);
+
+-- name: TouchConversation :exec
+UPDATE conversations
+SET last_message_at = @last_message_at
+WHERE id = @id AND (last_message_at IS NULL OR last_message_at < @last_message_at);
+
+-- name: GetMessage :one
+SELECT id, tenant_id, conversation_id, sender_id, body, hidden_at, hidden_by, created_at
+FROM messages
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: GetMessageByAttachment :one
+SELECT m.id, m.tenant_id, m.conversation_id, m.sender_id, m.body, m.hidden_at, m.hidden_by, m.created_at
+FROM messages m
+JOIN message_attachments a ON a.message_id = m.id
+WHERE a.id = @attachment_id AND m.tenant_id = @tenant_id;
+
+-- name: ListMessages :many
+SELECT id, tenant_id, conversation_id, sender_id, body, hidden_at, hidden_by, created_at
+FROM messages
+WHERE tenant_id = @tenant_id AND conversation_id = @conversation_id
+  AND (sqlc.narg('before')::timestamptz IS NULL OR created_at < sqlc.narg('before'))
+ORDER BY created_at DESC, id DESC
+LIMIT @page_size;
+
+-- name: ListMessageAttachments :many
+SELECT id, message_id, file_name, content_type, size_bytes, scan_status, preview_status, alt_text, caption_status
+FROM message_attachments
+WHERE message_id = ANY(@message_ids::uuid[])
+ORDER BY message_id, file_name;
+
+-- name: HideMessage :exec
+UPDATE messages
+SET hidden_at = @hidden_at, hidden_by = @hidden_by
+WHERE id = @id AND tenant_id = @tenant_id AND hidden_at IS NULL;
+
+-- name: InsertAbuseReport :exec
+INSERT INTO abuse_reports (id, tenant_id, message_id, conversation_id, reporter_id, reason, status, created_at)
+VALUES (@id, @tenant_id, @message_id, @conversation_id, @reporter_id, @reason, @status, @created_at);
+
+-- name: GetAbuseReport :one
+SELECT id, tenant_id, message_id, conversation_id, reporter_id, reason, status, resolved_by, resolved_at, created_at
+FROM abuse_reports
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: ListOpenAbuseReports :many
+SELECT id, tenant_id, message_id, conversation_id, reporter_id, reason, status, resolved_by, resolved_at, created_at
+FROM abuse_reports
+WHERE tenant_id = @tenant_id AND status = 'open'
+ORDER BY created_at;
+
+-- name: ResolveAbuseReport :exec
+UPDATE abuse_reports
+SET status = @status, resolved_by = @resolved_by, resolved_at = @resolved_at
+WHERE id = @id AND tenant_id = @tenant_id AND status = 'open';
+
+-- name: InsertGuardianLink :exec
+INSERT INTO guardian_links (tenant_id, guardian_id, student_id)
+VALUES (@tenant_id, @guardian_id, @student_id)
+ON CONFLICT DO NOTHING;
+
+-- name: GuardianLinkExists :one
+SELECT EXISTS (
+    SELECT 1
+    FROM guardian_links
+    WHERE tenant_id = @tenant_id AND guardian_id = @guardian_id AND student_id = @student_id
+);
+
+-- name: GuardianOfClassStudent :one
+SELECT EXISTS (
+    SELECT 1
+    FROM guardian_links g
+    JOIN class_enrollments e ON e.tenant_id = g.tenant_id AND e.user_id = g.student_id
+    WHERE g.tenant_id = @tenant_id AND g.guardian_id = @guardian_id AND e.class_id = @class_id
+);
+
+-- name: ListStudentClassIDs :many
+SELECT class_id
+FROM class_enrollments
+WHERE tenant_id = @tenant_id AND user_id = @user_id;
+
+-- name: ListGuardianClassIDs :many
+SELECT DISTINCT e.class_id
+FROM guardian_links g
+JOIN class_enrollments e ON e.tenant_id = g.tenant_id AND e.user_id = g.student_id
+WHERE g.tenant_id = @tenant_id AND g.guardian_id = @guardian_id;
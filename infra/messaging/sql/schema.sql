@@ -0,0 +1,87 @@
+-- Direct conversations and class threads. conversation_key is "direct:<user>|<user>" with sorted user
+-- IDs or "class:<class id>" so each pair of users and each class gets a single conversation
+CREATE TABLE conversations (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL,             -- direct, class
+    class_id UUID,
+    conversation_key VARCHAR(600) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_message_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (tenant_id, conversation_key)
+);
+
+CREATE INDEX idx_conversations_class ON conversations(tenant_id, class_id);
+
+-- Members of direct conversations, class thread members follow the class enrollments
+CREATE TABLE conversation_participants (
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+CREATE INDEX idx_conversation_participants_user ON conversation_participants(user_id);
+
+CREATE TABLE messages (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    sender_id VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    hidden_at TIMESTAMP WITH TIME ZONE,    -- set by moderators, hidden messages are kept as evidence
+    hidden_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_messages_conversation ON messages(conversation_id, created_at);
+
+-- Attachment metadata, the content lives in the file storage and is served once it is scanned clean
+CREATE TABLE message_attachments (
+    id UUID PRIMARY KEY,
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    scan_status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, clean, infected, missing
+    preview_status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, ready, unsupported, failed
+    alt_text TEXT NOT NULL DEFAULT '',                      -- required for images since accessibility reports
+    caption_status VARCHAR(20) NOT NULL DEFAULT 'not_needed' -- pending, ready, not_needed, unsupported, failed
+);
+
+CREATE INDEX idx_message_attachments_message ON message_attachments(message_id);
+CREATE INDEX idx_message_attachments_pending_scan ON message_attachments(message_id) WHERE scan_status = 'pending';
+CREATE INDEX idx_message_attachments_pending_preview ON message_attachments(message_id) WHERE preview_status = 'pending';
+CREATE INDEX idx_message_attachments_pending_captions ON message_attachments(message_id) WHERE caption_status = 'pending';
+
+-- Messages of the conversation created until read_at are read by the user
+CREATE TABLE read_receipts (
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+CREATE TABLE abuse_reports (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL,
+    reporter_id VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,           -- open, dismissed, actioned
+    resolved_by VARCHAR(255),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_abuse_reports_status ON abuse_reports(tenant_id, status, created_at);
+
+CREATE TABLE guardian_links (
+    tenant_id VARCHAR(255) NOT NULL,
+    guardian_id VARCHAR(255) NOT NULL,
+    student_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, guardian_id, student_id)
+);
+
+CREATE INDEX idx_guardian_links_student ON guardian_links(tenant_id, student_id);
@@ -0,0 +1,37 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/cache"
+)
+
+// CachedModuleToggleReader caches the disabled modules per tenant for the module gate and the
+// OpenAPI filter, which read them on every request. Toggles take up to the TTL to reach requests.
+type CachedModuleToggleReader struct {
+	next  ports.ModuleToggleReader
+	cache *cache.TTLCache[string, []string]
+}
+
+func NewCachedModuleToggleReader(next ports.ModuleToggleReader, ttl time.Duration) ports.ModuleToggleReader {
+	return &CachedModuleToggleReader{
+		next:  next,
+		cache: cache.NewTTLCache[string, []string](ttl, 10_000),
+	}
+}
+
+func (r *CachedModuleToggleReader) DisabledModules(ctx context.Context, tenantID string) ([]string, error) {
+	if modules, ok := r.cache.Lookup(ctx, tenantID); ok {
+		return modules, nil
+	}
+
+	modules, err := r.next.DisabledModules(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(tenantID, modules)
+	return modules, nil
+}
@@ -0,0 +1,103 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresModuleToggleRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresModuleToggleRepository(dbInstance *pgxpool.Pool) ports.ModuleToggleRepository {
+	return &PostgresModuleToggleRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresModuleToggleRepository) DisabledModules(ctx context.Context, tenantID string) ([]string, error) {
+	modules, err := r.queries.ListDisabledModules(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return modules, nil
+}
+
+func (r *PostgresModuleToggleRepository) List(ctx context.Context, tenantID string) ([]*entities.ModuleToggle, error) {
+	rows, err := r.queries.ListModuleToggles(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	toggles := make([]*entities.ModuleToggle, 0, len(rows))
+	for _, row := range rows {
+		// Rows are not validated again, a module removed from the toggleable ones is still listed
+		toggles = append(toggles, &entities.ModuleToggle{
+			TenantID:  row.TenantID,
+			Module:    row.Module,
+			Enabled:   row.Enabled,
+			Reason:    row.Reason,
+			UpdatedAt: row.UpdatedAt.Time,
+		})
+	}
+	return toggles, nil
+}
+
+func (r *PostgresModuleToggleRepository) Save(ctx context.Context, toggle *entities.ModuleToggle) error {
+	updatedAt := pgtype.Timestamptz{Time: toggle.UpdatedAt, Valid: true}
+
+	payload, err := json.Marshal(map[string]any{
+		"module":  toggle.Module,
+		"enabled": toggle.Enabled,
+		"reason":  toggle.Reason,
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize module toggle event", err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.UpsertModuleToggle(ctx, db.UpsertModuleToggleParams{
+		TenantID:  toggle.TenantID,
+		Module:    toggle.Module,
+		Enabled:   toggle.Enabled,
+		Reason:    toggle.Reason,
+		UpdatedAt: updatedAt,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.TenantModuleToggled,
+		AggregateID: toggle.TenantID,
+		TenantID:    toggle.TenantID,
+		Payload:     payload,
+		OccurredAt:  updatedAt,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
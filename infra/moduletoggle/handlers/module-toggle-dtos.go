@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/entities"
+)
+
+type TenantModuleResponse struct {
+	Module    string     `json:"module"`
+	Enabled   bool       `json:"enabled"`
+	Reason    string     `json:"reason,omitempty" doc:"Why the module was toggled, set by support"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" doc:"Absent when the module was never toggled"`
+}
+
+func NewTenantModuleResponse(toggle *entities.ModuleToggle) TenantModuleResponse {
+	response := TenantModuleResponse{
+		Module:  toggle.Module,
+		Enabled: toggle.Enabled,
+		Reason:  toggle.Reason,
+	}
+	if !toggle.UpdatedAt.IsZero() {
+		response.UpdatedAt = &toggle.UpdatedAt
+	}
+	return response
+}
+
+type ListTenantModulesResponse struct {
+	Body struct {
+		Items []TenantModuleResponse `json:"items"`
+	}
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	list_tenant_modules_use_case "github.com/nahualventure/class-backend/core/app/moduletoggle/application/use-cases/list-tenant-modules-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ModuleToggleHandlers struct {
+	listTenantModulesUseCase *list_tenant_modules_use_case.ListTenantModulesUseCase
+}
+
+func NewModuleToggleHandlers(listTenantModulesUseCase *list_tenant_modules_use_case.ListTenantModulesUseCase) *ModuleToggleHandlers {
+	return &ModuleToggleHandlers{
+		listTenantModulesUseCase: listTenantModulesUseCase,
+	}
+}
+
+func (h *ModuleToggleHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-tenant-modules",
+		Method:      http.MethodGet,
+		Path:        "/modules",
+		Summary:     "List the modules the tenant can go without and whether each is enabled",
+		Description: "Operations of disabled modules are refused with MODULE_DISABLED and left out of the OpenAPI served to the tenant.",
+		Tags:        []string{"Modules"},
+	}, h.ListTenantModules)
+}
+
+func (h *ModuleToggleHandlers) ListTenantModules(ctx context.Context, input *struct{}) (*ListTenantModulesResponse, error) {
+	command, err := list_tenant_modules_use_case.NewListTenantModulesCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	modules, err := h.listTenantModulesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ListTenantModulesResponse{}
+	response.Body.Items = make([]TenantModuleResponse, 0, len(modules))
+	for _, module := range modules {
+		response.Body.Items = append(response.Body.Items, NewTenantModuleResponse(module))
+	}
+	return response, nil
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/entities"
+	moduleToggleErrors "github.com/nahualventure/class-backend/core/app/moduletoggle/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/moduletoggle/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// DocumentOperation returns the hook of the spec that documents MODULE_DISABLED on the operations
+// of toggleable modules, added after the hook that records the module of operations and before
+// utils.DocumentOperation
+func DocumentOperation(moduleOf func(operationID string) (string, bool)) huma.AddOpFunc {
+	return func(oapi *huma.OpenAPI, op *huma.Operation) {
+		if module, ok := moduleOf(op.OperationID); ok && slices.Contains(entities.ToggleableModules, module) {
+			utils.AddOperationErrors(op.OperationID, moduleToggleErrors.ModuleDisabledError)
+		}
+	}
+}
+
+// SpecFilter serves the OpenAPI spec without the operations of the modules disabled for the tenant
+// named by the X-Tenant-Id header, so generated clients only see what the tenant can call. The spec
+// is public, the header only decides what is listed, the module gate refuses the operations.
+type SpecFilter struct {
+	disabledModules ports.ModuleToggleReader
+	moduleOf        func(operationID string) (string, bool)
+	path            string
+	spec            *huma.OpenAPI
+}
+
+// NewSpecFilter filters the spec Huma serves at openAPIPath, its path without extension
+func NewSpecFilter(disabledModules ports.ModuleToggleReader, moduleOf func(operationID string) (string, bool), openAPIPath string) *SpecFilter {
+	return &SpecFilter{
+		disabledModules: disabledModules,
+		moduleOf:        moduleOf,
+		path:            openAPIPath,
+	}
+}
+
+// Filter sets the spec to filter, once the Huma API is created. The filter serves the spec Huma
+// serves until then.
+func (f *SpecFilter) Filter(spec *huma.OpenAPI) {
+	f.spec = spec
+}
+
+// Middleware is a Gin middleware, the spec routes are served by Huma outside its middlewares. It
+// is added to the router before the Huma API.
+func (f *SpecFilter) Middleware(c *gin.Context) {
+	render, ok := f.renderer(c.Request.URL.Path)
+	tenantID := c.GetHeader(requestmeta.TenantIDHeader)
+	if !ok || f.spec == nil || tenantID == "" || c.Request.Method != http.MethodGet {
+		c.Next()
+		return
+	}
+
+	disabled, err := f.disabledModules.DisabledModules(c.Request.Context(), tenantID)
+	if err != nil {
+		// The spec is documentation, the full one is served rather than none
+		log.Printf("Failed to read the disabled modules of tenant %s: %v", tenantID, err)
+		c.Next()
+		return
+	}
+	if len(disabled) == 0 {
+		c.Next()
+		return
+	}
+
+	contentType, body, err := render(f.without(disabled))
+	if err != nil {
+		log.Printf("Failed to render the OpenAPI of tenant %s: %v", tenantID, err)
+		c.Next()
+		return
+	}
+	c.Data(http.StatusOK, contentType, body)
+	c.Abort()
+}
+
+// renderer returns how the spec at path is rendered, false for other paths
+func (f *SpecFilter) renderer(path string) (func(spec *huma.OpenAPI) (string, []byte, error), bool) {
+	const jsonType, yamlType = "application/vnd.oai.openapi+json", "application/vnd.oai.openapi+yaml"
+	switch path {
+	case f.path + ".json":
+		return func(spec *huma.OpenAPI) (string, []byte, error) {
+			body, err := spec.MarshalJSON()
+			return jsonType, body, err
+		}, true
+	case f.path + "-3.0.json":
+		return func(spec *huma.OpenAPI) (string, []byte, error) {
+			body, err := spec.Downgrade()
+			return jsonType, body, err
+		}, true
+	case f.path + ".yaml":
+		return func(spec *huma.OpenAPI) (string, []byte, error) {
+			body, err := spec.YAML()
+			return yamlType, body, err
+		}, true
+	case f.path + "-3.0.yaml":
+		return func(spec *huma.OpenAPI) (string, []byte, error) {
+			body, err := spec.DowngradeYAML()
+			return yamlType, body, err
+		}, true
+	}
+	return nil, false
+}
+
+// without copies the spec without the operations of the disabled modules, paths left without
+// operations are dropped. The schemas are kept, other operations may share them.
+func (f *SpecFilter) without(disabled []string) *huma.OpenAPI {
+	spec := *f.spec
+	spec.Paths = make(map[string]*huma.PathItem, len(f.spec.Paths))
+	for path, item := range f.spec.Paths {
+		filtered := *item
+		kept := false
+		for _, operation := range []**huma.Operation{&filtered.Get, &filtered.Put, &filtered.Post, &filtered.Delete,
+			&filtered.Options, &filtered.Head, &filtered.Patch, &filtered.Trace} {
+			if *operation == nil {
+				continue
+			}
+			if module, ok := f.moduleOf((*operation).OperationID); ok && slices.Contains(disabled, module) {
+				*operation = nil
+				continue
+			}
+			kept = true
+		}
+		if kept {
+			spec.Paths[path] = &filtered
+		}
+	}
+	return &spec
+}
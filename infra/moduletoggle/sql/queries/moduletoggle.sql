@@ -0,0 +1,19 @@
+-- name: ListModuleToggles :many
+SELECT tenant_id, module, enabled, reason, updated_at
+FROM tenant_module_toggles
+WHERE tenant_id = @tenant_id
+ORDER BY module;
+
+-- name: ListDisabledModules :many
+SELECT module
+FROM tenant_module_toggles
+WHERE tenant_id = @tenant_id AND NOT enabled
+ORDER BY module;
+
+-- name: UpsertModuleToggle :exec
+INSERT INTO tenant_module_toggles (tenant_id, module, enabled, reason, updated_at)
+VALUES (@tenant_id, @module, @enabled, @reason, @updated_at)
+ON CONFLICT (tenant_id, module) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+    reason = EXCLUDED.reason,
+    updated_at = EXCLUDED.updated_at;
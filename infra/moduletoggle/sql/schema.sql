@@ -0,0 +1,9 @@
+-- Modules enabled or disabled per tenant. Modules without a row are enabled.
+CREATE TABLE tenant_module_toggles (
+    tenant_id VARCHAR(255) NOT NULL,
+    module VARCHAR(64) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, module)
+);
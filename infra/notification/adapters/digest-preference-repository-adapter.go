@@ -0,0 +1,55 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/notification/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/notification/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresDigestPreferenceRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresDigestPreferenceRepository(dbInstance *pgxpool.Pool) ports.DigestPreferenceRepository {
+	return &PostgresDigestPreferenceRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresDigestPreferenceRepository) Find(ctx context.Context, tenantID string, userID string) (*entities.DigestPreference, error) {
+	row, err := r.queries.GetDigestPreference(ctx, db.GetDigestPreferenceParams{TenantID: tenantID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	preference, err := entities.NewDigestPreference(row.TenantID, row.UserID, entities.DigestFrequency(row.Frequency), row.UpdatedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return preference, nil
+}
+
+func (r *PostgresDigestPreferenceRepository) Save(ctx context.Context, preference *entities.DigestPreference) error {
+	err := r.queries.UpsertDigestPreference(ctx, db.UpsertDigestPreferenceParams{
+		TenantID:  preference.TenantID,
+		UserID:    preference.UserID,
+		Frequency: string(preference.Frequency),
+		UpdatedAt: pgtype.Timestamptz{Time: preference.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/notification/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/notification/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SentRetention is how long sent notifications are kept, longer than the projection runner can
+// take to replay the batch it crashed in
+const SentRetention = 7 * 24 * time.Hour
+
+// PostgresNotificationBatcher holds notifications in held_notifications and queues each digest as
+// an outbox event in the transaction that marks its notifications sent, a digest another instance
+// sent first is skipped
+type PostgresNotificationBatcher struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresNotificationBatcher(dbInstance *pgxpool.Pool) ports.NotificationBatcher {
+	return &PostgresNotificationBatcher{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (b *PostgresNotificationBatcher) Hold(ctx context.Context, notification *entities.Notification) error {
+	payload, err := json.Marshal(notification.Payload)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize notification payload", err)
+	}
+
+	err = b.queries.HoldNotification(ctx, db.HoldNotificationParams{
+		TenantID:    notification.TenantID,
+		RecipientID: notification.RecipientID,
+		Position:    notification.Position,
+		Type:        notification.Type,
+		Payload:     payload,
+		Frequency:   string(notification.Frequency),
+		OccurredAt:  pgtype.Timestamptz{Time: notification.OccurredAt, Valid: true},
+		DueAt:       pgtype.Timestamptz{Time: notification.DueAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (b *PostgresNotificationBatcher) ListDue(ctx context.Context, now time.Time, limit int) ([]*entities.Notification, error) {
+	rows, err := b.queries.ListDueNotifications(ctx, db.ListDueNotificationsParams{
+		Now:            pgtype.Timestamptz{Time: now, Valid: true},
+		RecipientLimit: int32(limit),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	notifications := make([]*entities.Notification, 0, len(rows))
+	for _, row := range rows {
+		payload := make(map[string]any)
+		if err := json.Unmarshal(row.Payload, &payload); err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to deserialize notification payload", err)
+		}
+		notifications = append(notifications, &entities.Notification{
+			TenantID:    row.TenantID,
+			RecipientID: row.RecipientID,
+			Position:    row.Position,
+			Type:        row.Type,
+			Payload:     payload,
+			Frequency:   entities.DigestFrequency(row.Frequency),
+			OccurredAt:  row.OccurredAt.Time,
+			DueAt:       row.DueAt.Time,
+		})
+	}
+	return notifications, nil
+}
+
+func (b *PostgresNotificationBatcher) Send(ctx context.Context, digests []*entities.Digest, now time.Time) error {
+	tx, err := b.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := b.queries.WithTx(tx)
+
+	for _, digest := range digests {
+		var pgDigestID pgtype.UUID
+		if err := pgDigestID.Scan(digest.ID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+
+		positions := make([]int64, 0, len(digest.Notifications))
+		notifications := make([]map[string]any, 0, len(digest.Notifications))
+		for _, notification := range digest.Notifications {
+			positions = append(positions, notification.Position)
+			notifications = append(notifications, map[string]any{
+				"type":        notification.Type,
+				"occurred_at": notification.OccurredAt,
+				"payload":     notification.Payload,
+			})
+		}
+
+		marked, err := qtx.MarkNotificationsSent(ctx, db.MarkNotificationsSentParams{
+			DigestID:    pgDigestID,
+			SentAt:      pgtype.Timestamptz{Time: now, Valid: true},
+			TenantID:    digest.TenantID,
+			RecipientID: digest.RecipientID,
+			Positions:   positions,
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+		if marked == 0 {
+			// Another instance sent the digest while this one was listing it
+			continue
+		}
+
+		payload, err := json.Marshal(map[string]any{
+			"digest_id":     digest.ID,
+			"recipient_id":  digest.RecipientID,
+			"frequency":     string(digest.Frequency),
+			"notifications": notifications,
+		})
+		if err != nil {
+			return appErrors.NewInfrastructureError("failed to serialize digest event", err)
+		}
+
+		err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+			EventType:   events.NotificationsDue,
+			AggregateID: digest.RecipientID,
+			TenantID:    digest.TenantID,
+			Payload:     payload,
+			OccurredAt:  pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if err := qtx.DeleteSentNotifications(ctx, pgtype.Timestamptz{Time: now.Add(-SentRetention), Valid: true}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package adapters
+
+import (
+	"context"
+
+	hold_notifications_use_case "github.com/nahualventure/class-backend/core/app/notification/application/use-cases/hold-notifications-use-case"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+)
+
+const NotificationDigestProjectionName = "notification_digests"
+
+// NotificationDigestProjection holds the notification events of the outbox until the digests of
+// their recipients are due
+type NotificationDigestProjection struct {
+	holdNotificationsUseCase *hold_notifications_use_case.HoldNotificationsUseCase
+}
+
+func NewNotificationDigestProjection(holdNotificationsUseCase *hold_notifications_use_case.HoldNotificationsUseCase) *NotificationDigestProjection {
+	return &NotificationDigestProjection{
+		holdNotificationsUseCase: holdNotificationsUseCase,
+	}
+}
+
+func (p *NotificationDigestProjection) Name() string {
+	return NotificationDigestProjectionName
+}
+
+// Apply is idempotent, a notification already held conflicts on its position and recipient
+func (p *NotificationDigestProjection) Apply(ctx context.Context, event events.Event) error {
+	if _, err := p.holdNotificationsUseCase.Execute(ctx, event); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+// Reset refuses to rebuild, replaying the outbox would send every notification again
+func (p *NotificationDigestProjection) Reset(ctx context.Context) error {
+	return appErrors.NewValidationError("notification digests are sent, they cannot be rebuilt",
+		map[string]any{"projection": NotificationDigestProjectionName}, nil)
+}
@@ -0,0 +1,39 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/notification/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresRecipientZones reads the zone of a recipient from their time zone setting
+type PostgresRecipientZones struct {
+	queries *db.Queries
+}
+
+func NewPostgresRecipientZones(dbInstance *pgxpool.Pool) ports.RecipientZones {
+	return &PostgresRecipientZones{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (z *PostgresRecipientZones) LocationOf(ctx context.Context, tenantID string, userID string) (*time.Location, error) {
+	timeZone, err := z.queries.GetRecipientTimeZone(ctx, db.GetRecipientTimeZoneParams{TenantID: tenantID, UserID: userID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	// Settings are validated on save, a zone missing from the database falls back to UTC
+	loc, err := utils.LoadLocation(timeZone)
+	if err != nil {
+		return time.UTC, nil
+	}
+	return loc, nil
+}
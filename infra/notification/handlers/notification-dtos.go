@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/notification/domain/entities"
+)
+
+type SetDigestPreferenceRequest struct {
+	Body struct {
+		Frequency string `json:"frequency" enum:"immediate,hourly,daily" doc:"immediate sends every notification on its own, hourly and daily send one digest per window"`
+	}
+}
+
+type DigestPreferenceResponse struct {
+	Frequency       string     `json:"frequency" doc:"How often notifications are sent: immediate, hourly or daily"`
+	DailyDigestHour int        `json:"daily_digest_hour" doc:"Hour of the day, in the time zone of the user, daily digests are sent at"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty" doc:"Absent when the user never picked a frequency"`
+}
+
+func NewDigestPreferenceResponse(preference *entities.DigestPreference, loc *time.Location) DigestPreferenceResponse {
+	response := DigestPreferenceResponse{
+		Frequency:       string(preference.Frequency),
+		DailyDigestHour: entities.DailyDigestHour,
+	}
+	if !preference.IsDefault() {
+		updatedAt := preference.UpdatedAt.In(loc)
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
+
+type DigestPreferenceEnvelope struct {
+	Body DigestPreferenceResponse
+}
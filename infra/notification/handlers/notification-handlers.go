@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_digest_preference_use_case "github.com/nahualventure/class-backend/core/app/notification/application/use-cases/get-digest-preference-use-case"
+	set_digest_preference_use_case "github.com/nahualventure/class-backend/core/app/notification/application/use-cases/set-digest-preference-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type NotificationHandlers struct {
+	getDigestPreferenceUseCase *get_digest_preference_use_case.GetDigestPreferenceUseCase
+	setDigestPreferenceUseCase *set_digest_preference_use_case.SetDigestPreferenceUseCase
+}
+
+func NewNotificationHandlers(
+	getDigestPreferenceUseCase *get_digest_preference_use_case.GetDigestPreferenceUseCase,
+	setDigestPreferenceUseCase *set_digest_preference_use_case.SetDigestPreferenceUseCase,
+) *NotificationHandlers {
+	return &NotificationHandlers{
+		getDigestPreferenceUseCase: getDigestPreferenceUseCase,
+		setDigestPreferenceUseCase: setDigestPreferenceUseCase,
+	}
+}
+
+func (h *NotificationHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-digest-preference",
+		Method:      http.MethodGet,
+		Path:        "/preferences/notifications",
+		Summary:     "Get how often the current user is sent their notifications",
+		Tags:        []string{"Notifications"},
+	}, h.GetDigestPreference)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-digest-preference",
+		Method:      http.MethodPut,
+		Path:        "/preferences/notifications",
+		Summary:     "Pick how often the current user is sent their notifications",
+		Description: "Hourly and daily digests coalesce the notifications of the window into a single email or push. Notifications received already keep the time they were due at.",
+		Tags:        []string{"Notifications"},
+	}, h.SetDigestPreference)
+}
+
+func (h *NotificationHandlers) GetDigestPreference(ctx context.Context, input *struct{}) (*DigestPreferenceEnvelope, error) {
+	command, err := get_digest_preference_use_case.NewGetDigestPreferenceCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	preference, err := h.getDigestPreferenceUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DigestPreferenceEnvelope{Body: NewDigestPreferenceResponse(preference, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *NotificationHandlers) SetDigestPreference(ctx context.Context, input *SetDigestPreferenceRequest) (*DigestPreferenceEnvelope, error) {
+	command, err := set_digest_preference_use_case.NewSetDigestPreferenceCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Frequency,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	preference, err := h.setDigestPreferenceUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DigestPreferenceEnvelope{Body: NewDigestPreferenceResponse(preference, utils.LocationFromContext(ctx))}, nil
+}
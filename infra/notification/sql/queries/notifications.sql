@@ -0,0 +1,49 @@
+-- name: GetDigestPreference :one
+SELECT tenant_id, user_id, frequency, updated_at
+FROM digest_preferences
+WHERE tenant_id = @tenant_id AND user_id = @user_id;
+
+-- name: UpsertDigestPreference :exec
+INSERT INTO digest_preferences (tenant_id, user_id, frequency, updated_at)
+VALUES (@tenant_id, @user_id, @frequency, @updated_at)
+ON CONFLICT (tenant_id, user_id) DO UPDATE SET
+    frequency = EXCLUDED.frequency,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetRecipientTimeZone :one
+-- The zone of the user, or of the tenant when the user did not pick one
+SELECT COALESCE(
+    (SELECT time_zone FROM time_zone_settings u WHERE u.tenant_id = @tenant_id AND u.user_id = @user_id),
+    (SELECT time_zone FROM time_zone_settings t WHERE t.tenant_id = @tenant_id AND t.user_id = ''),
+    'UTC'
+)::TEXT AS time_zone;
+
+-- name: HoldNotification :exec
+INSERT INTO held_notifications (tenant_id, recipient_id, position, type, payload, frequency, occurred_at, due_at)
+VALUES (@tenant_id, @recipient_id, @position, @type, @payload, @frequency, @occurred_at, @due_at)
+ON CONFLICT (tenant_id, recipient_id, position) DO NOTHING;
+
+-- name: ListDueNotifications :many
+-- Every due notification of the first recipients with one, a recipient is never split across pages
+WITH recipients AS (
+    SELECT DISTINCT tenant_id, recipient_id
+    FROM held_notifications
+    WHERE sent_at IS NULL AND due_at <= @now
+    ORDER BY tenant_id, recipient_id
+    LIMIT @recipient_limit
+)
+SELECT h.tenant_id, h.recipient_id, h.position, h.type, h.payload, h.frequency, h.occurred_at, h.due_at
+FROM held_notifications h
+JOIN recipients r ON r.tenant_id = h.tenant_id AND r.recipient_id = h.recipient_id
+WHERE h.sent_at IS NULL AND h.due_at <= @now
+ORDER BY h.tenant_id, h.recipient_id, h.due_at, h.position;
+
+-- name: MarkNotificationsSent :execrows
+UPDATE held_notifications
+SET digest_id = @digest_id, sent_at = @sent_at
+WHERE tenant_id = @tenant_id AND recipient_id = @recipient_id AND position = ANY(@positions::bigint[])
+    AND sent_at IS NULL;
+
+-- name: DeleteSentNotifications :exec
+DELETE FROM held_notifications
+WHERE sent_at < @sent_before;
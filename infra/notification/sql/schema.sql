@@ -0,0 +1,28 @@
+-- How often users are sent their notifications, users without a row get every one on its own
+CREATE TABLE digest_preferences (
+    tenant_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    frequency VARCHAR(20) NOT NULL,        -- immediate, hourly, daily
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+-- Notification events of the outbox held for each recipient until their digest is due. A
+-- notification is identified by its outbox position and recipient, sent ones are kept for a week so
+-- the ones held again after a crash are not sent twice.
+CREATE TABLE held_notifications (
+    tenant_id VARCHAR(255) NOT NULL,
+    recipient_id VARCHAR(255) NOT NULL,
+    position BIGINT NOT NULL,
+    type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    frequency VARCHAR(20) NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    digest_id UUID,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (tenant_id, recipient_id, position)
+);
+
+CREATE INDEX idx_held_notifications_due ON held_notifications(due_at) WHERE sent_at IS NULL;
+CREATE INDEX idx_held_notifications_sent ON held_notifications(sent_at) WHERE sent_at IS NOT NULL;
@@ -0,0 +1,29 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	release_due_digests_use_case "github.com/nahualventure/class-backend/core/app/notification/application/use-cases/release-due-digests-use-case"
+)
+
+// RunDigestRelease queues the due notification digests every interval, it returns when ctx is cancelled
+func RunDigestRelease(ctx context.Context, useCase *release_due_digests_use_case.ReleaseDueDigestsUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if sent, err := useCase.Execute(ctx); err != nil {
+			log.Printf("Failed to send notification digests: %v", err)
+		} else if sent > 0 {
+			log.Printf("Queued %d notification digests", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresAppointmentBook serializes bookings of a slot with a row lock on the slot, bookings of
+// different slots do not block each other
+type PostgresAppointmentBook struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresAppointmentBook(dbInstance *pgxpool.Pool) ports.AppointmentBook {
+	return &PostgresAppointmentBook{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (b *PostgresAppointmentBook) WithSlotLocked(ctx context.Context, tenantID string, slotID string,
+	fn func(ledger ports.AppointmentLedger, slot *entities.AvailabilitySlot) error) error {
+	var pgSlotID pgtype.UUID
+	if err := pgSlotID.Scan(slotID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := b.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := b.queries.WithTx(tx)
+
+	var slot *entities.AvailabilitySlot
+	row, err := qtx.LockAvailabilitySlot(ctx, db.LockAvailabilitySlotParams{ID: pgSlotID, TenantID: tenantID})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return appErrors.PropagateError(err)
+	}
+	if err == nil {
+		slot, err = toAvailabilitySlot(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	ledger := &postgresAppointmentLedger{
+		queries:  qtx,
+		tenantID: tenantID,
+		slotID:   pgSlotID,
+	}
+	if err := fn(ledger, slot); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+type postgresAppointmentLedger struct {
+	queries  *db.Queries
+	tenantID string
+	slotID   pgtype.UUID
+}
+
+func (l *postgresAppointmentLedger) CountBooked(ctx context.Context) (int, error) {
+	booked, err := l.queries.CountBookedAppointments(ctx, l.slotID)
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	return int(booked), nil
+}
+
+func (l *postgresAppointmentLedger) ListStudentAppointments(ctx context.Context, studentID string, from time.Time,
+	to time.Time) ([]*entities.Appointment, error) {
+	rows, err := l.queries.ListStudentAppointmentsBetween(ctx, db.ListStudentAppointmentsBetweenParams{
+		TenantID:   l.tenantID,
+		StudentID:  studentID,
+		RangeEnd:   pgtype.Timestamptz{Time: to, Valid: true},
+		RangeStart: pgtype.Timestamptz{Time: from, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toAppointments(rows)
+}
+
+func (l *postgresAppointmentLedger) Create(ctx context.Context, appointment *entities.Appointment) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(appointment.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := l.queries.CreateAppointment(ctx, db.CreateAppointmentParams{
+		ID:        pgID,
+		TenantID:  appointment.TenantID,
+		SlotID:    l.slotID,
+		TeacherID: appointment.TeacherID,
+		StudentID: appointment.StudentID,
+		StartsAt:  pgtype.Timestamptz{Time: appointment.StartsAt, Valid: true},
+		EndsAt:    pgtype.Timestamptz{Time: appointment.EndsAt, Valid: true},
+		Location:  emptyToNil(appointment.Location),
+		Topic:     emptyToNil(appointment.Topic),
+		Status:    string(appointment.Status),
+		CreatedAt: pgtype.Timestamptz{Time: appointment.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return insertAppointmentEvent(ctx, l.queries, events.AppointmentBooked, appointment, appointment.CreatedAt)
+}
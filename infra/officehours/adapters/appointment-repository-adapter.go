@@ -0,0 +1,230 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	officeHoursErrors "github.com/nahualventure/class-backend/core/app/officehours/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresAppointmentRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresAppointmentRepository(dbInstance *pgxpool.Pool) ports.AppointmentRepository {
+	return &PostgresAppointmentRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresAppointmentRepository) FindByID(ctx context.Context, tenantID string, appointmentID string) (*entities.Appointment, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(appointmentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetAppointment(ctx, db.GetAppointmentParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toAppointment(row)
+}
+
+func (r *PostgresAppointmentRepository) ListForUser(ctx context.Context, tenantID string, userID string, from time.Time) ([]*entities.Appointment, error) {
+	rows, err := r.queries.ListUserAppointments(ctx, db.ListUserAppointmentsParams{
+		TenantID:  tenantID,
+		UserID:    userID,
+		EndsAfter: pgtype.Timestamptz{Time: from, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toAppointments(rows)
+}
+
+func (r *PostgresAppointmentRepository) Cancel(ctx context.Context, appointment *entities.Appointment) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(appointment.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	canceled, err := qtx.CancelAppointment(ctx, db.CancelAppointmentParams{
+		CanceledAt: pgtype.Timestamptz{Time: *appointment.CanceledAt, Valid: true},
+		CanceledBy: &appointment.CanceledBy,
+		ID:         pgID,
+		TenantID:   appointment.TenantID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	// Canceled concurrently, by the other participant or with its slot
+	if canceled == 0 {
+		return officeHoursErrors.NewAppointmentNotActiveError(appointment.ID, string(entities.AppointmentStatusCanceled))
+	}
+
+	if err := insertAppointmentEvent(ctx, qtx, events.AppointmentCanceled, appointment, *appointment.CanceledAt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresAppointmentRepository) QueueDueReminders(ctx context.Context, now time.Time, dueBefore time.Time,
+	limit int) ([]*entities.Appointment, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	rows, err := qtx.ClaimDueReminders(ctx, db.ClaimDueRemindersParams{
+		Now:       pgtype.Timestamptz{Time: now, Valid: true},
+		DueBefore: pgtype.Timestamptz{Time: dueBefore, Valid: true},
+		BatchSize: int32(limit),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	appointments, err := toAppointments(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, appointment := range appointments {
+		if err := insertAppointmentEvent(ctx, qtx, events.AppointmentReminderDue, appointment, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return appointments, nil
+}
+
+// insertAppointmentEvent appends an appointment event to the outbox, notification senders pick
+// them up from there
+func insertAppointmentEvent(ctx context.Context, queries *db.Queries, eventType string, appointment *entities.Appointment,
+	occurredAt time.Time) error {
+	payload := map[string]any{
+		"appointment_id": appointment.ID,
+		"slot_id":        appointment.SlotID,
+		"teacher_id":     appointment.TeacherID,
+		"student_id":     appointment.StudentID,
+		"starts_at":      appointment.StartsAt.Format(time.RFC3339),
+	}
+	switch eventType {
+	case events.AppointmentCanceled:
+		payload["canceled_by"] = appointment.CanceledBy
+	case events.AppointmentReminderDue:
+		payload["location"] = appointment.Location
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize appointment event", err)
+	}
+
+	err = queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   eventType,
+		AggregateID: appointment.ID,
+		TenantID:    appointment.TenantID,
+		Payload:     encoded,
+		OccurredAt:  pgtype.Timestamptz{Time: occurredAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func toAppointments(rows []db.Appointment) ([]*entities.Appointment, error) {
+	appointments := make([]*entities.Appointment, 0, len(rows))
+	for _, row := range rows {
+		appointment, err := toAppointment(row)
+		if err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, appointment)
+	}
+
+	return appointments, nil
+}
+
+func toAppointment(row db.Appointment) (*entities.Appointment, error) {
+	appointment, err := entities.NewAppointment(
+		row.ID.String(),
+		row.TenantID,
+		row.SlotID.String(),
+		row.TeacherID,
+		row.StudentID,
+		row.StartsAt.Time,
+		row.EndsAt.Time,
+		valueOrEmpty(row.Location),
+		valueOrEmpty(row.Topic),
+		entities.AppointmentStatus(row.Status),
+		timeOrNil(row.ReminderSentAt),
+		timeOrNil(row.CanceledAt),
+		valueOrEmpty(row.CanceledBy),
+		row.CreatedAt.Time,
+	)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return appointment, nil
+}
+
+func timeOrNil(value pgtype.Timestamptz) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,170 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSlotRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresSlotRepository(dbInstance *pgxpool.Pool) ports.SlotRepository {
+	return &PostgresSlotRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresSlotRepository) Create(ctx context.Context, slot *entities.AvailabilitySlot) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(slot.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.CreateAvailabilitySlot(ctx, db.CreateAvailabilitySlotParams{
+		ID:        pgID,
+		TenantID:  slot.TenantID,
+		TeacherID: slot.TeacherID,
+		StartsAt:  pgtype.Timestamptz{Time: slot.StartsAt, Valid: true},
+		EndsAt:    pgtype.Timestamptz{Time: slot.EndsAt, Valid: true},
+		Capacity:  int32(slot.Capacity),
+		Location:  emptyToNil(slot.Location),
+		CreatedAt: pgtype.Timestamptz{Time: slot.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresSlotRepository) FindByID(ctx context.Context, tenantID string, slotID string) (*entities.AvailabilitySlot, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(slotID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetAvailabilitySlot(ctx, db.GetAvailabilitySlotParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toAvailabilitySlot(row)
+}
+
+func (r *PostgresSlotRepository) ListByTeacher(ctx context.Context, tenantID string, teacherID string, from time.Time,
+	to time.Time) ([]entities.SlotAvailability, error) {
+	rows, err := r.queries.ListTeacherSlots(ctx, db.ListTeacherSlotsParams{
+		TenantID:   tenantID,
+		TeacherID:  teacherID,
+		RangeEnd:   pgtype.Timestamptz{Time: to, Valid: true},
+		RangeStart: pgtype.Timestamptz{Time: from, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	slots := make([]entities.SlotAvailability, 0, len(rows))
+	for _, row := range rows {
+		slot, err := toAvailabilitySlot(db.AvailabilitySlot{
+			ID:         row.ID,
+			TenantID:   row.TenantID,
+			TeacherID:  row.TeacherID,
+			StartsAt:   row.StartsAt,
+			EndsAt:     row.EndsAt,
+			Capacity:   row.Capacity,
+			Location:   row.Location,
+			CanceledAt: row.CanceledAt,
+			CreatedAt:  row.CreatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, entities.SlotAvailability{Slot: slot, Booked: int(row.Booked)})
+	}
+
+	return slots, nil
+}
+
+func (r *PostgresSlotRepository) Cancel(ctx context.Context, slot *entities.AvailabilitySlot) ([]*entities.Appointment, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(slot.ID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	canceledAt := pgtype.Timestamptz{Time: *slot.CanceledAt, Valid: true}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.CancelAvailabilitySlot(ctx, db.CancelAvailabilitySlotParams{CanceledAt: canceledAt, ID: pgID, TenantID: slot.TenantID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := qtx.CancelSlotAppointments(ctx, db.CancelSlotAppointmentsParams{
+		CanceledAt: canceledAt,
+		CanceledBy: &slot.TeacherID,
+		SlotID:     pgID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	canceled := make([]*entities.Appointment, 0, len(rows))
+	for _, row := range rows {
+		appointment, err := toAppointment(row)
+		if err != nil {
+			return nil, err
+		}
+		if err := insertAppointmentEvent(ctx, qtx, events.AppointmentCanceled, appointment, *slot.CanceledAt); err != nil {
+			return nil, err
+		}
+		canceled = append(canceled, appointment)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return canceled, nil
+}
+
+func toAvailabilitySlot(row db.AvailabilitySlot) (*entities.AvailabilitySlot, error) {
+	slot, err := entities.NewAvailabilitySlot(
+		row.ID.String(),
+		row.TenantID,
+		row.TeacherID,
+		row.StartsAt.Time,
+		row.EndsAt.Time,
+		int(row.Capacity),
+		valueOrEmpty(row.Location),
+		timeOrNil(row.CanceledAt),
+		row.CreatedAt.Time,
+	)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return slot, nil
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type CreateAvailabilitySlotRequest struct {
+	Body struct {
+		StartsAt time.Time `json:"starts_at" doc:"Must be in the future"`
+		EndsAt   time.Time `json:"ends_at" doc:"At most 4 hours after starts_at"`
+		Capacity int       `json:"capacity" minimum:"1" maximum:"20" default:"1" doc:"Students that can book the slot"`
+		Location string    `json:"location,omitempty" maxLength:"255" example:"Room 204"`
+	}
+}
+
+type AvailabilitySlotResponse struct {
+	ID         string     `json:"id"`
+	TeacherID  string     `json:"teacher_id"`
+	StartsAt   time.Time  `json:"starts_at"`
+	EndsAt     time.Time  `json:"ends_at"`
+	Capacity   int        `json:"capacity"`
+	Remaining  *int       `json:"remaining,omitempty" doc:"Seats left, only in listings"`
+	Location   string     `json:"location,omitempty"`
+	CanceledAt *time.Time `json:"canceled_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// NewAvailabilitySlotResponse shows the times of the slot in the zone of the reader
+func NewAvailabilitySlotResponse(slot *entities.AvailabilitySlot, loc *time.Location) AvailabilitySlotResponse {
+	return AvailabilitySlotResponse{
+		ID:         slot.ID,
+		TeacherID:  slot.TeacherID,
+		StartsAt:   utils.InLocation(slot.StartsAt, loc),
+		EndsAt:     utils.InLocation(slot.EndsAt, loc),
+		Capacity:   slot.Capacity,
+		Location:   slot.Location,
+		CanceledAt: slot.CanceledAt,
+		CreatedAt:  slot.CreatedAt,
+	}
+}
+
+type AvailabilitySlotEnvelope struct {
+	Body AvailabilitySlotResponse
+}
+
+type SlotRequest struct {
+	SlotID string `path:"slotId" format:"uuid"`
+}
+
+type CancelAvailabilitySlotResponse struct {
+	Body struct {
+		Slot                 AvailabilitySlotResponse `json:"slot"`
+		CanceledAppointments []AppointmentResponse    `json:"canceled_appointments"`
+	}
+}
+
+type ListAvailabilitySlotsRequest struct {
+	TeacherID string    `path:"teacherId"`
+	From      time.Time `query:"from" doc:"Start of the window, now when omitted"`
+	To        time.Time `query:"to" doc:"End of the window, 14 days after from when omitted, at most 62 days after from"`
+}
+
+type AvailabilitySlotListResponse struct {
+	Body struct {
+		Items []AvailabilitySlotResponse `json:"items"`
+	}
+}
+
+type BookAppointmentRequest struct {
+	SlotID string `path:"slotId" format:"uuid"`
+	Body   struct {
+		Topic string `json:"topic,omitempty" maxLength:"500" doc:"What the student wants to discuss"`
+	}
+}
+
+type AppointmentResponse struct {
+	ID         string     `json:"id"`
+	SlotID     string     `json:"slot_id"`
+	TeacherID  string     `json:"teacher_id"`
+	StudentID  string     `json:"student_id"`
+	StartsAt   time.Time  `json:"starts_at"`
+	EndsAt     time.Time  `json:"ends_at"`
+	Location   string     `json:"location,omitempty"`
+	Topic      string     `json:"topic,omitempty"`
+	Status     string     `json:"status" enum:"booked,canceled"`
+	CanceledAt *time.Time `json:"canceled_at,omitempty"`
+	CanceledBy string     `json:"canceled_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// NewAppointmentResponse shows the times of the appointment in the zone of the reader
+func NewAppointmentResponse(appointment *entities.Appointment, loc *time.Location) AppointmentResponse {
+	return AppointmentResponse{
+		ID:         appointment.ID,
+		SlotID:     appointment.SlotID,
+		TeacherID:  appointment.TeacherID,
+		StudentID:  appointment.StudentID,
+		StartsAt:   utils.InLocation(appointment.StartsAt, loc),
+		EndsAt:     utils.InLocation(appointment.EndsAt, loc),
+		Location:   appointment.Location,
+		Topic:      appointment.Topic,
+		Status:     string(appointment.Status),
+		CanceledAt: appointment.CanceledAt,
+		CanceledBy: appointment.CanceledBy,
+		CreatedAt:  appointment.CreatedAt,
+	}
+}
+
+func NewAppointmentResponses(appointments []*entities.Appointment, loc *time.Location) []AppointmentResponse {
+	responses := make([]AppointmentResponse, 0, len(appointments))
+	for _, appointment := range appointments {
+		responses = append(responses, NewAppointmentResponse(appointment, loc))
+	}
+	return responses
+}
+
+type AppointmentEnvelope struct {
+	Body AppointmentResponse
+}
+
+type AppointmentRequest struct {
+	AppointmentID string `path:"appointmentId" format:"uuid"`
+}
+
+type AppointmentListResponse struct {
+	Body struct {
+		Items []AppointmentResponse `json:"items"`
+	}
+}
+
+type DownloadAppointmentInviteResponse struct {
+	ContentType        string `header:"Content-Type"`
+	ContentDisposition string `header:"Content-Disposition"`
+	Body               []byte
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	book_appointment_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/book-appointment-use-case"
+	cancel_appointment_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/cancel-appointment-use-case"
+	cancel_availability_slot_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/cancel-availability-slot-use-case"
+	create_availability_slot_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/create-availability-slot-use-case"
+	get_appointment_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/get-appointment-use-case"
+	list_appointments_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/list-appointments-use-case"
+	list_availability_slots_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/list-availability-slots-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type OfficeHoursHandlers struct {
+	createSlotUseCase        *create_availability_slot_use_case.CreateAvailabilitySlotUseCase
+	cancelSlotUseCase        *cancel_availability_slot_use_case.CancelAvailabilitySlotUseCase
+	listSlotsUseCase         *list_availability_slots_use_case.ListAvailabilitySlotsUseCase
+	bookAppointmentUseCase   *book_appointment_use_case.BookAppointmentUseCase
+	cancelAppointmentUseCase *cancel_appointment_use_case.CancelAppointmentUseCase
+	getAppointmentUseCase    *get_appointment_use_case.GetAppointmentUseCase
+	listAppointmentsUseCase  *list_appointments_use_case.ListAppointmentsUseCase
+}
+
+func NewOfficeHoursHandlers(
+	createSlotUseCase *create_availability_slot_use_case.CreateAvailabilitySlotUseCase,
+	cancelSlotUseCase *cancel_availability_slot_use_case.CancelAvailabilitySlotUseCase,
+	listSlotsUseCase *list_availability_slots_use_case.ListAvailabilitySlotsUseCase,
+	bookAppointmentUseCase *book_appointment_use_case.BookAppointmentUseCase,
+	cancelAppointmentUseCase *cancel_appointment_use_case.CancelAppointmentUseCase,
+	getAppointmentUseCase *get_appointment_use_case.GetAppointmentUseCase,
+	listAppointmentsUseCase *list_appointments_use_case.ListAppointmentsUseCase,
+) *OfficeHoursHandlers {
+	return &OfficeHoursHandlers{
+		createSlotUseCase:        createSlotUseCase,
+		cancelSlotUseCase:        cancelSlotUseCase,
+		listSlotsUseCase:         listSlotsUseCase,
+		bookAppointmentUseCase:   bookAppointmentUseCase,
+		cancelAppointmentUseCase: cancelAppointmentUseCase,
+		getAppointmentUseCase:    getAppointmentUseCase,
+		listAppointmentsUseCase:  listAppointmentsUseCase,
+	}
+}
+
+func (h *OfficeHoursHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-availability-slot",
+		Method:        http.MethodPost,
+		Path:          "/office-hours/slots",
+		Summary:       "Publish an office hours slot for the current teacher",
+		Description:   "Slots of the same teacher cannot overlap.",
+		Tags:          []string{"Office Hours"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateAvailabilitySlot)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "cancel-availability-slot",
+		Method:      http.MethodDelete,
+		Path:        "/office-hours/slots/{slotId}",
+		Summary:     "Cancel an office hours slot and its appointments",
+		Tags:        []string{"Office Hours"},
+	}, h.CancelAvailabilitySlot)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-availability-slots",
+		Method:      http.MethodGet,
+		Path:        "/teachers/{teacherId}/office-hours",
+		Summary:     "List the office hours slots of a teacher with the seats left",
+		Tags:        []string{"Office Hours"},
+	}, h.ListAvailabilitySlots)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "book-appointment",
+		Method:        http.MethodPost,
+		Path:          "/office-hours/slots/{slotId}/appointments",
+		Summary:       "Book an appointment in an office hours slot",
+		Description:   "Fails when the slot is full or the student has another appointment at the same time.",
+		Tags:          []string{"Office Hours"},
+		DefaultStatus: http.StatusCreated,
+	}, h.BookAppointment)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "cancel-appointment",
+		Method:      http.MethodPost,
+		Path:        "/appointments/{appointmentId}/cancel",
+		Summary:     "Cancel an appointment, either participant can cancel",
+		Tags:        []string{"Office Hours"},
+	}, h.CancelAppointment)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-appointments",
+		Method:      http.MethodGet,
+		Path:        "/appointments",
+		Summary:     "List the upcoming appointments of the current user",
+		Tags:        []string{"Office Hours"},
+	}, h.ListAppointments)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "download-appointment-invite",
+		Method:      http.MethodGet,
+		Path:        "/appointments/{appointmentId}/invite.ics",
+		Summary:     "Download the calendar invite of an appointment",
+		Tags:        []string{"Office Hours"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "iCalendar invite",
+				Content:     map[string]*huma.MediaType{"text/calendar": {}},
+			},
+		},
+	}, h.DownloadAppointmentInvite)
+}
+
+func (h *OfficeHoursHandlers) CreateAvailabilitySlot(ctx context.Context, input *CreateAvailabilitySlotRequest) (*AvailabilitySlotEnvelope, error) {
+	command, err := create_availability_slot_use_case.NewCreateAvailabilitySlotCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.StartsAt,
+		input.Body.EndsAt,
+		input.Body.Capacity,
+		input.Body.Location,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	slot, err := h.createSlotUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &AvailabilitySlotEnvelope{Body: NewAvailabilitySlotResponse(slot, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *OfficeHoursHandlers) CancelAvailabilitySlot(ctx context.Context, input *SlotRequest) (*CancelAvailabilitySlotResponse, error) {
+	command, err := cancel_availability_slot_use_case.NewCancelAvailabilitySlotCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.SlotID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	slot, canceled, err := h.cancelSlotUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &CancelAvailabilitySlotResponse{}
+	response.Body.Slot = NewAvailabilitySlotResponse(slot, loc)
+	response.Body.CanceledAppointments = NewAppointmentResponses(canceled, loc)
+	return response, nil
+}
+
+func (h *OfficeHoursHandlers) ListAvailabilitySlots(ctx context.Context, input *ListAvailabilitySlotsRequest) (*AvailabilitySlotListResponse, error) {
+	command, err := list_availability_slots_use_case.NewListAvailabilitySlotsCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.TeacherID,
+		input.From,
+		input.To,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	slots, err := h.listSlotsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &AvailabilitySlotListResponse{}
+	response.Body.Items = make([]AvailabilitySlotResponse, 0, len(slots))
+	for _, availability := range slots {
+		item := NewAvailabilitySlotResponse(availability.Slot, loc)
+		remaining := availability.Remaining()
+		item.Remaining = &remaining
+		response.Body.Items = append(response.Body.Items, item)
+	}
+	return response, nil
+}
+
+func (h *OfficeHoursHandlers) BookAppointment(ctx context.Context, input *BookAppointmentRequest) (*AppointmentEnvelope, error) {
+	command, err := book_appointment_use_case.NewBookAppointmentCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.SlotID,
+		authorization.UserIDFromContext(ctx),
+		input.Body.Topic,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	appointment, err := h.bookAppointmentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &AppointmentEnvelope{Body: NewAppointmentResponse(appointment, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *OfficeHoursHandlers) CancelAppointment(ctx context.Context, input *AppointmentRequest) (*AppointmentEnvelope, error) {
+	command, err := cancel_appointment_use_case.NewCancelAppointmentCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.AppointmentID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	appointment, err := h.cancelAppointmentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &AppointmentEnvelope{Body: NewAppointmentResponse(appointment, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *OfficeHoursHandlers) ListAppointments(ctx context.Context, input *struct{}) (*AppointmentListResponse, error) {
+	command, err := list_appointments_use_case.NewListAppointmentsCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	appointments, err := h.listAppointmentsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &AppointmentListResponse{}
+	response.Body.Items = NewAppointmentResponses(appointments, utils.LocationFromContext(ctx))
+	return response, nil
+}
+
+func (h *OfficeHoursHandlers) DownloadAppointmentInvite(ctx context.Context, input *AppointmentRequest) (*DownloadAppointmentInviteResponse, error) {
+	command, err := get_appointment_use_case.NewGetAppointmentCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.AppointmentID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	appointment, err := h.getAppointmentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DownloadAppointmentInviteResponse{
+		ContentType:        "text/calendar; charset=utf-8",
+		ContentDisposition: `attachment; filename="appointment.ics"`,
+		Body:               []byte(appointment.CalendarInvite(time.Now())),
+	}, nil
+}
@@ -0,0 +1,84 @@
+-- name: CreateAvailabilitySlot :exec
+INSERT INTO availability_slots (id, tenant_id, teacher_id, starts_at, ends_at, capacity, location, created_at)
+VALUES (@id, @tenant_id, @teacher_id, @starts_at, @ends_at, @capacity, @location, @created_at);
+
+-- name: GetAvailabilitySlot :one
+SELECT id, tenant_id, teacher_id, starts_at, ends_at, capacity, location, canceled_at, created_at
+FROM availability_slots
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: LockAvailabilitySlot :one
+SELECT id, tenant_id, teacher_id, starts_at, ends_at, capacity, location, canceled_at, created_at
+FROM availability_slots
+WHERE id = @id AND tenant_id = @tenant_id
+FOR UPDATE;
+
+-- name: ListTeacherSlots :many
+SELECT s.id, s.tenant_id, s.teacher_id, s.starts_at, s.ends_at, s.capacity, s.location, s.canceled_at, s.created_at,
+       (SELECT COUNT(*) FROM appointments a WHERE a.slot_id = s.id AND a.status = 'booked')::int AS booked
+FROM availability_slots s
+WHERE s.tenant_id = @tenant_id AND s.teacher_id = @teacher_id AND s.canceled_at IS NULL
+  AND s.starts_at < @range_end AND s.ends_at > @range_start
+ORDER BY s.starts_at;
+
+-- name: CancelAvailabilitySlot :exec
+UPDATE availability_slots
+SET canceled_at = @canceled_at
+WHERE id = @id AND tenant_id = @tenant_id AND canceled_at IS NULL;
+
+-- name: CancelSlotAppointments :many
+UPDATE appointments
+SET status = 'canceled', canceled_at = @canceled_at, canceled_by = @canceled_by
+WHERE slot_id = @slot_id AND status = 'booked'
+RETURNING id, tenant_id, slot_id, teacher_id, student_id, starts_at, ends_at, location, topic, status,
+          reminder_sent_at, canceled_at, canceled_by, created_at;
+
+-- name: CountBookedAppointments :one
+SELECT COUNT(*)
+FROM appointments
+WHERE slot_id = @slot_id AND status = 'booked';
+
+-- name: ListStudentAppointmentsBetween :many
+SELECT id, tenant_id, slot_id, teacher_id, student_id, starts_at, ends_at, location, topic, status,
+       reminder_sent_at, canceled_at, canceled_by, created_at
+FROM appointments
+WHERE tenant_id = @tenant_id AND student_id = @student_id AND status = 'booked'
+  AND starts_at < @range_end AND ends_at > @range_start
+ORDER BY starts_at;
+
+-- name: CreateAppointment :exec
+INSERT INTO appointments (id, tenant_id, slot_id, teacher_id, student_id, starts_at, ends_at, location, topic, status, created_at)
+VALUES (@id, @tenant_id, @slot_id, @teacher_id, @student_id, @starts_at, @ends_at, @location, @topic, @status, @created_at);
+
+-- name: GetAppointment :one
+SELECT id, tenant_id, slot_id, teacher_id, student_id, starts_at, ends_at, location, topic, status,
+       reminder_sent_at, canceled_at, canceled_by, created_at
+FROM appointments
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: ListUserAppointments :many
+SELECT id, tenant_id, slot_id, teacher_id, student_id, starts_at, ends_at, location, topic, status,
+       reminder_sent_at, canceled_at, canceled_by, created_at
+FROM appointments
+WHERE tenant_id = @tenant_id AND (student_id = @user_id OR teacher_id = @user_id) AND ends_at > @ends_after
+ORDER BY starts_at;
+
+-- name: CancelAppointment :execrows
+UPDATE appointments
+SET status = 'canceled', canceled_at = @canceled_at, canceled_by = @canceled_by
+WHERE id = @id AND tenant_id = @tenant_id AND status = 'booked';
+
+-- name: ClaimDueReminders :many
+UPDATE appointments
+SET reminder_sent_at = @now
+WHERE id IN (
+    SELECT pending.id
+    FROM appointments pending
+    WHERE pending.status = 'booked' AND pending.reminder_sent_at IS NULL
+      AND pending.starts_at > @now AND pending.starts_at <= @due_before
+    ORDER BY pending.starts_at
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, tenant_id, slot_id, teacher_id, student_id, starts_at, ends_at, location, topic, status,
+          reminder_sent_at, canceled_at, canceled_by, created_at;
@@ -0,0 +1,39 @@
+-- Office hour slots offered by teachers. The slot row is locked (FOR UPDATE) while it is booked so
+-- concurrent bookings cannot exceed its capacity
+CREATE TABLE availability_slots (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    teacher_id VARCHAR(255) NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    location VARCHAR(255),
+    canceled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_availability_slots_teacher ON availability_slots(tenant_id, teacher_id, starts_at);
+
+-- Bookings of students in a slot, times and location are copied from the slot
+CREATE TABLE appointments (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    slot_id UUID NOT NULL REFERENCES availability_slots(id) ON DELETE CASCADE,
+    teacher_id VARCHAR(255) NOT NULL,
+    student_id VARCHAR(255) NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    location VARCHAR(255),
+    topic VARCHAR(500),
+    status VARCHAR(20) NOT NULL,           -- booked, canceled
+    reminder_sent_at TIMESTAMP WITH TIME ZONE,
+    canceled_at TIMESTAMP WITH TIME ZONE,
+    canceled_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_appointments_slot ON appointments(slot_id, status);
+CREATE INDEX idx_appointments_student ON appointments(tenant_id, student_id, starts_at);
+CREATE INDEX idx_appointments_teacher ON appointments(tenant_id, teacher_id, starts_at);
+CREATE INDEX idx_appointments_pending_reminders ON appointments(starts_at) WHERE status = 'booked' AND reminder_sent_at IS NULL;
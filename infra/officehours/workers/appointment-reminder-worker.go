@@ -0,0 +1,29 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	send_appointment_reminders_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/send-appointment-reminders-use-case"
+)
+
+// RunAppointmentReminders queues the due appointment reminders every interval, it returns when ctx is cancelled
+func RunAppointmentReminders(ctx context.Context, useCase *send_appointment_reminders_use_case.SendAppointmentRemindersUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if queued, err := useCase.Execute(ctx); err != nil {
+			log.Printf("Failed to queue appointment reminders: %v", err)
+		} else if queued > 0 {
+			log.Printf("Queued %d appointment reminders", queued)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
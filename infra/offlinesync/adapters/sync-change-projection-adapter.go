@@ -0,0 +1,125 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const SyncChangeProjectionName = "sync_changes"
+
+// PostgresSyncChangeProjection is both the projector that keeps the latest change of every synced
+// entity and the change feed devices read. The projection runner applies one event at a time, so a
+// change is committed before the next one takes a sequence and devices never skip one.
+type PostgresSyncChangeProjection struct {
+	queries *db.Queries
+}
+
+var _ ports.ChangeFeed = (*PostgresSyncChangeProjection)(nil)
+
+func NewPostgresSyncChangeProjection(dbInstance *pgxpool.Pool) *PostgresSyncChangeProjection {
+	return &PostgresSyncChangeProjection{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (p *PostgresSyncChangeProjection) Name() string {
+	return SyncChangeProjectionName
+}
+
+func (p *PostgresSyncChangeProjection) Apply(ctx context.Context, event events.Event) error {
+	for _, change := range entities.ChangesFromEvent(event) {
+		var classID pgtype.UUID
+		// A malformed event would otherwise block the projection forever
+		if err := classID.Scan(change.ClassID); err != nil {
+			log.Printf("Skipping %s event at position %d: invalid class_id", event.Type, event.Position)
+			return nil
+		}
+		// Tombstones have no data
+		data := []byte("{}")
+		if change.Data != nil {
+			encoded, err := json.Marshal(change.Data)
+			if err != nil {
+				return appErrors.NewInfrastructureError("failed to serialize sync change", err)
+			}
+			data = encoded
+		}
+
+		err := p.queries.UpsertSyncChange(ctx, db.UpsertSyncChangeParams{
+			TenantID:   change.TenantID,
+			ClassID:    classID,
+			EntityType: string(change.EntityType),
+			EntityID:   change.EntityID,
+			Position:   change.Position,
+			Deleted:    change.Deleted,
+			Data:       data,
+			ChangedAt:  pgtype.Timestamptz{Time: change.ChangedAt, Valid: true},
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	return nil
+}
+
+// Reset keeps the changes. Replaying the outbox stores them again with new sequences, while deleting
+// them would lose the tombstones of entities whose events were archived, and devices would keep them.
+func (p *PostgresSyncChangeProjection) Reset(context.Context) error {
+	return nil
+}
+
+func (p *PostgresSyncChangeProjection) ListAfter(ctx context.Context, tenantID string, classID string, entityType entities.EntityType,
+	after int64, limit int) ([]*entities.Change, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	params := db.ListSyncChangesAfterParams{
+		TenantID: tenantID,
+		ClassID:  pgClassID,
+		After:    after,
+		PageSize: int32(limit),
+	}
+	if entityType != "" {
+		filter := string(entityType)
+		params.EntityType = &filter
+	}
+
+	rows, err := p.queries.ListSyncChangesAfter(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	changes := make([]*entities.Change, 0, len(rows))
+	for _, row := range rows {
+		change := &entities.Change{
+			TenantID:   row.TenantID,
+			ClassID:    row.ClassID.String(),
+			EntityType: entities.EntityType(row.EntityType),
+			EntityID:   row.EntityID,
+			Sequence:   row.Sequence,
+			Position:   row.Position,
+			Deleted:    row.Deleted,
+			ChangedAt:  row.ChangedAt.Time,
+		}
+		if !row.Deleted {
+			if err := json.Unmarshal(row.Data, &change.Data); err != nil {
+				return nil, appErrors.NewInfrastructureError("failed to deserialize sync change", err)
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
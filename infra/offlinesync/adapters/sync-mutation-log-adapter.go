@@ -0,0 +1,80 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresMutationLog struct {
+	queries *db.Queries
+}
+
+func NewPostgresMutationLog(dbInstance *pgxpool.Pool) ports.MutationLog {
+	return &PostgresMutationLog{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (l *PostgresMutationLog) Find(ctx context.Context, tenantID string, clientMutationID string) (*entities.AppliedMutation, error) {
+	var pgMutationID pgtype.UUID
+	if err := pgMutationID.Scan(clientMutationID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := l.queries.GetSyncMutation(ctx, db.GetSyncMutationParams{TenantID: tenantID, ClientMutationID: pgMutationID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &entities.AppliedMutation{
+		TenantID:         row.TenantID,
+		ClientMutationID: row.ClientMutationID.String(),
+		ClassID:          row.ClassID.String(),
+		GradeID:          row.GradeID.String(),
+		Version:          int(row.Version),
+		Score:            row.Score,
+		AppliedBy:        row.AppliedBy,
+		AppliedAt:        row.AppliedAt.Time,
+	}, nil
+}
+
+func (l *PostgresMutationLog) Record(ctx context.Context, mutation *entities.AppliedMutation) error {
+	var pgMutationID, pgClassID, pgGradeID pgtype.UUID
+	if err := pgMutationID.Scan(mutation.ClientMutationID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgClassID.Scan(mutation.ClassID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgGradeID.Scan(mutation.GradeID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := l.queries.InsertSyncMutation(ctx, db.InsertSyncMutationParams{
+		TenantID:         mutation.TenantID,
+		ClientMutationID: pgMutationID,
+		ClassID:          pgClassID,
+		GradeID:          pgGradeID,
+		Version:          int32(mutation.Version),
+		Score:            mutation.Score,
+		AppliedBy:        mutation.AppliedBy,
+		AppliedAt:        pgtype.Timestamptz{Time: mutation.AppliedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/offlinesync/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type ListChangesRequest struct {
+	ClassID    string `path:"classId" format:"uuid"`
+	EntityType string `query:"entity_type" enum:"enrollment,attendance,grade" doc:"Only the changes of this entity type, omit for every type"`
+	After      int64  `query:"after" minimum:"0" doc:"Sequence of the last change the device has, 0 to sync from scratch"`
+	Limit      int    `query:"limit" minimum:"0" maximum:"1000" doc:"Changes per page, defaults to 200"`
+}
+
+type ChangeResponse struct {
+	EntityType string         `json:"entity_type" enum:"enrollment,attendance,grade"`
+	EntityID   string         `json:"entity_id"`
+	Sequence   int64          `json:"sequence"`
+	Deleted    bool           `json:"deleted" doc:"Tombstone, the device deletes its copy of the entity"`
+	Data       map[string]any `json:"data,omitempty" doc:"The entity, absent for tombstones"`
+	ChangedAt  time.Time      `json:"changed_at"`
+}
+
+type ListChangesResponse struct {
+	Body struct {
+		Items   []ChangeResponse `json:"items"`
+		Next    int64            `json:"next" doc:"Pass as after to get the following changes"`
+		HasMore bool             `json:"has_more" doc:"Another page is ready, ask again right away"`
+	}
+}
+
+func NewListChangesResponse(page *entities.ChangePage) *ListChangesResponse {
+	response := &ListChangesResponse{}
+	response.Body.Items = make([]ChangeResponse, 0, len(page.Changes))
+	for _, change := range page.Changes {
+		response.Body.Items = append(response.Body.Items, ChangeResponse{
+			EntityType: string(change.EntityType),
+			EntityID:   change.EntityID,
+			Sequence:   change.Sequence,
+			Deleted:    change.Deleted,
+			Data:       change.Data,
+			ChangedAt:  change.ChangedAt,
+		})
+	}
+	response.Body.Next = page.Next
+	response.Body.HasMore = page.HasMore
+	return response
+}
+
+type GradeMutationRequest struct {
+	ClientMutationID string     `json:"client_mutation_id" format:"uuid" doc:"Generated by the device, an upload sent again does not apply the mutation twice"`
+	AssignmentID     string     `json:"assignment_id" format:"uuid"`
+	StudentID        string     `json:"student_id"`
+	Score            float64    `json:"score" minimum:"0" maximum:"100"`
+	TurnedInAt       *time.Time `json:"turned_in_at,omitempty"`
+	Reason           string     `json:"reason,omitempty" maxLength:"1000"`
+	BaseVersion      *int       `json:"base_version,omitempty" minimum:"0" doc:"Version of the grade on the device when the score was entered, omit when it had none"`
+	Resolution       string     `json:"resolution,omitempty" enum:"server_wins,client_wins" doc:"server_wins (default) reports a conflict, client_wins overwrites a draft changed meanwhile"`
+}
+
+type PushMutationsRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+	Body    struct {
+		Grades []GradeMutationRequest `json:"grades" minItems:"1" maxItems:"500" doc:"Applied in order"`
+	}
+}
+
+// MutationItemResponse has the status applied or already_applied when the grade has the score
+type MutationItemResponse struct {
+	utils.BulkItemResponse
+	ClientMutationID string   `json:"client_mutation_id"`
+	GradeID          string   `json:"grade_id,omitempty"`
+	Version          *int     `json:"version,omitempty" doc:"Version of the grade after the mutation, the base of the next one"`
+	Score            *float64 `json:"score,omitempty"`
+}
+
+type PushMutationsResponse struct {
+	Body struct {
+		utils.BulkSummary
+		Results []MutationItemResponse `json:"results"`
+	}
+}
+
+func toGradeMutations(requests []GradeMutationRequest) []entities.GradeMutation {
+	mutations := make([]entities.GradeMutation, len(requests))
+	for i, request := range requests {
+		mutations[i] = entities.GradeMutation{
+			ClientMutationID: request.ClientMutationID,
+			AssignmentID:     request.AssignmentID,
+			StudentID:        request.StudentID,
+			Score:            request.Score,
+			TurnedInAt:       request.TurnedInAt,
+			Reason:           request.Reason,
+			BaseVersion:      request.BaseVersion,
+			Resolution:       entities.Resolution(request.Resolution),
+		}
+	}
+	return mutations
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	list_changes_use_case "github.com/nahualventure/class-backend/core/app/offlinesync/application/use-cases/list-changes-use-case"
+	push_mutations_use_case "github.com/nahualventure/class-backend/core/app/offlinesync/application/use-cases/push-mutations-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type OfflineSyncHandlers struct {
+	listChangesUseCase   *list_changes_use_case.ListChangesUseCase
+	pushMutationsUseCase *push_mutations_use_case.PushMutationsUseCase
+}
+
+func NewOfflineSyncHandlers(
+	listChangesUseCase *list_changes_use_case.ListChangesUseCase,
+	pushMutationsUseCase *push_mutations_use_case.PushMutationsUseCase,
+) *OfflineSyncHandlers {
+	return &OfflineSyncHandlers{
+		listChangesUseCase:   listChangesUseCase,
+		pushMutationsUseCase: pushMutationsUseCase,
+	}
+}
+
+func (h *OfflineSyncHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-sync-changes",
+		Method:      http.MethodGet,
+		Path:        "/sync/classes/{classId}/changes",
+		Summary:     "List the changes of the roster, attendance and released grades of a class since a sequence",
+		Description: "Only the latest change of each entity is listed. Removed entities are listed as tombstones with deleted set.",
+		Tags:        []string{"Offline Sync"},
+	}, h.ListChanges)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "push-sync-mutations",
+		Method:      http.MethodPost,
+		Path:        "/sync/classes/{classId}/mutations",
+		Summary:     "Upload the grades recorded offline",
+		Description: "A grade that changed since its base_version fails with SYNC_CONFLICT and the state of the server, unless the resolution is client_wins. " +
+			"Mutations applied by an earlier upload answer already_applied.",
+		Tags: []string{"Offline Sync"},
+	}, h.PushMutations)
+}
+
+func (h *OfflineSyncHandlers) ListChanges(ctx context.Context, input *ListChangesRequest) (*ListChangesResponse, error) {
+	command, err := list_changes_use_case.NewListChangesCommand(authorization.TenantIDFromContext(ctx), input.ClassID, input.EntityType,
+		input.After, input.Limit)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listChangesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListChangesResponse(page), nil
+}
+
+func (h *OfflineSyncHandlers) PushMutations(ctx context.Context, input *PushMutationsRequest) (*PushMutationsResponse, error) {
+	command, err := push_mutations_use_case.NewPushMutationsCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		authorization.UserIDFromContext(ctx),
+		toGradeMutations(input.Body.Grades),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	result, err := h.pushMutationsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	locale := requestmeta.Locale(ctx)
+	response := &PushMutationsResponse{}
+	response.Body.BulkSummary = utils.NewBulkSummary(result)
+	response.Body.Results = make([]MutationItemResponse, len(result.Items))
+	for i, item := range result.Items {
+		itemResponse := MutationItemResponse{
+			BulkItemResponse: utils.NewBulkItemResponse(item, locale),
+			ClientMutationID: input.Body.Grades[item.Index].ClientMutationID,
+		}
+		if item.Succeeded() {
+			itemResponse.GradeID = item.Value.GradeID
+			itemResponse.Version = &item.Value.Version
+			itemResponse.Score = &item.Value.Score
+		}
+		response.Body.Results[i] = itemResponse
+	}
+
+	return response, nil
+}
@@ -0,0 +1,32 @@
+-- name: UpsertSyncChange :exec
+-- An event replayed after a crash or a rebuild stores its change again with a new sequence, an
+-- older event never overwrites the change of a newer one
+INSERT INTO sync_changes (tenant_id, class_id, entity_type, entity_id, position, deleted, data, changed_at)
+VALUES (@tenant_id, @class_id, @entity_type, @entity_id, @position, @deleted, @data, @changed_at)
+ON CONFLICT (tenant_id, class_id, entity_type, entity_id) DO UPDATE
+SET sequence = nextval('sync_change_sequence'),
+    position = EXCLUDED.position,
+    deleted = EXCLUDED.deleted,
+    data = EXCLUDED.data,
+    changed_at = EXCLUDED.changed_at
+WHERE sync_changes.position <= EXCLUDED.position;
+
+-- name: ListSyncChangesAfter :many
+SELECT *
+FROM sync_changes
+WHERE tenant_id = @tenant_id
+  AND class_id = @class_id
+  AND sequence > @after
+  AND (sqlc.narg('entity_type')::text IS NULL OR entity_type = sqlc.narg('entity_type'))
+ORDER BY sequence
+LIMIT @page_size;
+
+-- name: GetSyncMutation :one
+SELECT *
+FROM sync_mutations
+WHERE tenant_id = @tenant_id AND client_mutation_id = @client_mutation_id;
+
+-- name: InsertSyncMutation :exec
+INSERT INTO sync_mutations (tenant_id, client_mutation_id, class_id, grade_id, version, score, applied_by, applied_at)
+VALUES (@tenant_id, @client_mutation_id, @class_id, @grade_id, @version, @score, @applied_by, @applied_at)
+ON CONFLICT (tenant_id, client_mutation_id) DO NOTHING;
@@ -0,0 +1,32 @@
+-- Orders the changes of sync_changes, a change takes the next value every time it is stored
+CREATE SEQUENCE sync_change_sequence;
+
+-- Latest change of every entity devices sync, projected from the outbox. Removed entities stay as
+-- tombstones so devices delete their copy; only the latest change of an entity is kept.
+CREATE TABLE sync_changes (
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    entity_type VARCHAR(20) NOT NULL,      -- enrollment, attendance, grade
+    entity_id VARCHAR(255) NOT NULL,
+    sequence BIGINT NOT NULL DEFAULT nextval('sync_change_sequence'),
+    position BIGINT NOT NULL,              -- outbox event of the change
+    deleted BOOLEAN NOT NULL DEFAULT false,
+    data JSONB NOT NULL DEFAULT '{}',
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, class_id, entity_type, entity_id)
+);
+
+CREATE INDEX idx_sync_changes_feed ON sync_changes(tenant_id, class_id, sequence);
+
+-- Offline mutations applied to the server, an upload retried by the device answers from here
+CREATE TABLE sync_mutations (
+    tenant_id VARCHAR(255) NOT NULL,
+    client_mutation_id UUID NOT NULL,
+    class_id UUID NOT NULL,
+    grade_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    applied_by VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, client_mutation_id)
+);
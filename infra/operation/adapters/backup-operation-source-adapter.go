@@ -0,0 +1,53 @@
+package adapters
+
+import (
+	"context"
+
+	backupEntities "github.com/nahualventure/class-backend/core/app/backup/domain/entities"
+	backupPorts "github.com/nahualventure/class-backend/core/app/backup/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/operation/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/operation/domain/ports"
+)
+
+// BackupOperationSource reads tenant backups as operations, they succeed once the dump was restored
+// and verified
+type BackupOperationSource struct {
+	backups backupPorts.TenantBackupRepository
+}
+
+func NewBackupOperationSource(backups backupPorts.TenantBackupRepository) ports.OperationSource {
+	return &BackupOperationSource{
+		backups: backups,
+	}
+}
+
+func (s *BackupOperationSource) Kind() entities.Kind {
+	return entities.KindBackup
+}
+
+func (s *BackupOperationSource) Find(ctx context.Context, tenantID string, operationID string) (*entities.Operation, error) {
+	backup, err := s.backups.FindByID(ctx, tenantID, operationID)
+	if err != nil || backup == nil {
+		return nil, err
+	}
+
+	operation := &entities.Operation{
+		ID:        backup.ID,
+		Kind:      entities.KindBackup,
+		Status:    entities.OperationStatusPending,
+		CreatedAt: backup.CreatedAt,
+		UpdatedAt: backup.UpdatedAt,
+	}
+	switch backup.Status {
+	case backupEntities.TenantBackupStatusRunning:
+		operation.Status = entities.OperationStatusRunning
+	case backupEntities.TenantBackupStatusVerified:
+		operation.Succeed(entities.Result{
+			Resource: "/backups/" + backup.ID,
+			Details:  map[string]any{"size_bytes": backup.SizeBytes, "checksum": backup.Checksum},
+		})
+	case backupEntities.TenantBackupStatusFailed:
+		operation.Fail(backup.FailureReason)
+	}
+	return operation, nil
+}
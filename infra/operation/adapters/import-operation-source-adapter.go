@@ -0,0 +1,60 @@
+package adapters
+
+import (
+	"context"
+
+	importEntities "github.com/nahualventure/class-backend/core/app/dataimport/domain/entities"
+	importPorts "github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/operation/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/operation/domain/ports"
+)
+
+// ImportOperationSource reads CSV imports as operations. An import that went through every row
+// succeeded even when some rows failed, the result counts them and points to their errors.
+type ImportOperationSource struct {
+	imports importPorts.ImportJobRepository
+}
+
+func NewImportOperationSource(imports importPorts.ImportJobRepository) ports.OperationSource {
+	return &ImportOperationSource{
+		imports: imports,
+	}
+}
+
+func (s *ImportOperationSource) Kind() entities.Kind {
+	return entities.KindImport
+}
+
+func (s *ImportOperationSource) Find(ctx context.Context, tenantID string, operationID string) (*entities.Operation, error) {
+	job, err := s.imports.FindByID(ctx, tenantID, operationID)
+	if err != nil || job == nil {
+		return nil, err
+	}
+
+	operation := &entities.Operation{
+		ID:        job.ID,
+		Kind:      entities.KindImport,
+		Status:    entities.OperationStatusPending,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	}
+	switch job.Status {
+	case importEntities.ImportJobStatusRunning:
+		operation.Status = entities.OperationStatusRunning
+		operation.Progress = entities.ProgressOf(job.ImportedRows+job.FailedRows, job.TotalRows)
+	case importEntities.ImportJobStatusCompleted:
+		details := map[string]any{
+			"entity":        job.Entity,
+			"total_rows":    job.TotalRows,
+			"imported_rows": job.ImportedRows,
+			"failed_rows":   job.FailedRows,
+		}
+		if job.FailedRows > 0 {
+			details["errors_path"] = "/imports/" + job.ID + "/errors"
+		}
+		operation.Succeed(entities.Result{Resource: "/imports/" + job.ID, Details: details})
+	case importEntities.ImportJobStatusFailed:
+		operation.Fail(job.FailureReason)
+	}
+	return operation, nil
+}
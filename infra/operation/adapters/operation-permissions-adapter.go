@@ -0,0 +1,42 @@
+package adapters
+
+import (
+	"fmt"
+
+	"github.com/nahualventure/class-backend/core/app/operation/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/operation/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// viewOperations are the operations that read the resource of each kind, an operation requires the
+// same permission
+var viewOperations = map[entities.Kind]string{
+	entities.KindReport:   "get-report",
+	entities.KindImport:   "get-import",
+	entities.KindPrintout: "get-printout",
+	entities.KindBackup:   "get-tenant-backup",
+}
+
+// CasbinOperationPermissions checks the permission of the endpoint that reads the resource of the
+// operation in Casbin
+type CasbinOperationPermissions struct {
+	casbinService *authorization.CasbinService
+}
+
+func NewCasbinOperationPermissions(casbinService *authorization.CasbinService) ports.OperationPermissions {
+	return &CasbinOperationPermissions{
+		casbinService: casbinService,
+	}
+}
+
+func (p *CasbinOperationPermissions) CanView(tenantID string, userID string, kind entities.Kind) (bool, error) {
+	permission, found := authorization.EndpointMapping[viewOperations[kind]]
+	if !found {
+		return false, fmt.Errorf("no permission reads operations of kind %s", kind)
+	}
+	allowed, authzErr := p.casbinService.CanDo(userID, permission.Resource, permission.Action, tenantID)
+	if authzErr != nil {
+		return false, authzErr
+	}
+	return allowed, nil
+}
@@ -0,0 +1,57 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/operation/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/operation/domain/ports"
+	printoutEntities "github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+	printoutPorts "github.com/nahualventure/class-backend/core/app/printout/domain/ports"
+)
+
+// PrintoutOperationSource reads queued printouts as operations, they succeed once the PDF is ready
+type PrintoutOperationSource struct {
+	printouts printoutPorts.PrintoutRepository
+}
+
+func NewPrintoutOperationSource(printouts printoutPorts.PrintoutRepository) ports.OperationSource {
+	return &PrintoutOperationSource{
+		printouts: printouts,
+	}
+}
+
+func (s *PrintoutOperationSource) Kind() entities.Kind {
+	return entities.KindPrintout
+}
+
+func (s *PrintoutOperationSource) Find(ctx context.Context, tenantID string, operationID string) (*entities.Operation, error) {
+	printout, err := s.printouts.FindByID(ctx, tenantID, operationID)
+	if err != nil || printout == nil {
+		return nil, err
+	}
+
+	operation := &entities.Operation{
+		ID:        printout.ID,
+		Kind:      entities.KindPrintout,
+		Status:    entities.OperationStatusPending,
+		CreatedAt: printout.CreatedAt,
+		UpdatedAt: printout.UpdatedAt,
+	}
+	switch printout.Status {
+	case printoutEntities.PrintoutStatusRunning:
+		operation.Status = entities.OperationStatusRunning
+	case printoutEntities.PrintoutStatusReady:
+		operation.Succeed(entities.Result{
+			Resource:     "/printouts/" + printout.ID,
+			DownloadPath: "/printouts/" + printout.ID + "/download",
+			Details: map[string]any{
+				"kind":       string(printout.Kind),
+				"class_id":   printout.ClassID,
+				"expires_at": printout.ExpiresAt,
+			},
+		})
+	case printoutEntities.PrintoutStatusFailed:
+		operation.Fail(printout.FailureReason)
+	}
+	return operation, nil
+}
@@ -0,0 +1,53 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/operation/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/operation/domain/ports"
+	reportEntities "github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	reportPorts "github.com/nahualventure/class-backend/core/app/report/domain/ports"
+)
+
+// ReportOperationSource reads requested reports as operations, they succeed once the file is ready
+type ReportOperationSource struct {
+	reports reportPorts.ReportRepository
+}
+
+func NewReportOperationSource(reports reportPorts.ReportRepository) ports.OperationSource {
+	return &ReportOperationSource{
+		reports: reports,
+	}
+}
+
+func (s *ReportOperationSource) Kind() entities.Kind {
+	return entities.KindReport
+}
+
+func (s *ReportOperationSource) Find(ctx context.Context, tenantID string, operationID string) (*entities.Operation, error) {
+	report, err := s.reports.FindByID(ctx, tenantID, operationID)
+	if err != nil || report == nil {
+		return nil, err
+	}
+
+	operation := &entities.Operation{
+		ID:        report.ID,
+		Kind:      entities.KindReport,
+		Status:    entities.OperationStatusPending,
+		CreatedAt: report.CreatedAt,
+		UpdatedAt: report.UpdatedAt,
+	}
+	switch report.Status {
+	case reportEntities.ReportStatusRunning:
+		operation.Status = entities.OperationStatusRunning
+	case reportEntities.ReportStatusReady:
+		operation.Succeed(entities.Result{
+			Resource:     "/reports/" + report.ID,
+			DownloadPath: "/reports/" + report.ID + "/download",
+			Details:      map[string]any{"definition": report.Definition, "expires_at": report.ExpiresAt},
+		})
+	case reportEntities.ReportStatusFailed:
+		operation.Fail(report.FailureReason)
+	}
+	return operation, nil
+}
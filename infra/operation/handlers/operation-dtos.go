@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/operation/domain/entities"
+)
+
+type GetOperationRequest struct {
+	OperationID string `path:"operationId" format:"uuid" doc:"Operation ID, the ID of the report, import, printout or backup it runs"`
+}
+
+type OperationResultResponse struct {
+	Resource     string         `json:"resource" doc:"Path of the resource the operation produced"`
+	DownloadPath string         `json:"download_path,omitempty" doc:"Path of the file of the resource, when it has one"`
+	Details      map[string]any `json:"details,omitempty"`
+}
+
+type OperationErrorResponse struct {
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Context map[string]any `json:"context,omitempty"`
+}
+
+type OperationResponse struct {
+	ID       string                   `json:"id"`
+	Kind     string                   `json:"kind" enum:"report,import,printout,backup"`
+	Status   string                   `json:"status" enum:"pending,running,succeeded,failed"`
+	Progress int                      `json:"progress" minimum:"0" maximum:"100" doc:"Percentage done, 0 until the operation finishes when it cannot be measured"`
+	Result   *OperationResultResponse `json:"result,omitempty" doc:"Set once the operation succeeded"`
+	Error    *OperationErrorResponse  `json:"error,omitempty" doc:"Set once the operation failed"`
+	// Done lets clients stop polling without knowing every status
+	Done      bool      `json:"done"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewOperationResponse(operation *entities.Operation) OperationResponse {
+	response := OperationResponse{
+		ID:        operation.ID,
+		Kind:      string(operation.Kind),
+		Status:    string(operation.Status),
+		Progress:  operation.Progress,
+		Done:      operation.IsFinished(),
+		CreatedAt: operation.CreatedAt,
+		UpdatedAt: operation.UpdatedAt,
+	}
+	if operation.Result != nil {
+		response.Result = &OperationResultResponse{
+			Resource:     operation.Result.Resource,
+			DownloadPath: operation.Result.DownloadPath,
+			Details:      operation.Result.Details,
+		}
+	}
+	if operation.Error != nil {
+		response.Error = &OperationErrorResponse{
+			Code:    operation.Error.Code,
+			Message: operation.Error.Message,
+			Context: operation.Error.Context,
+		}
+	}
+	return response
+}
+
+type OperationEnvelope struct {
+	// RetryAfter is set while the operation runs, the seconds to wait before polling again
+	RetryAfter string `header:"Retry-After"`
+	Body       OperationResponse
+}
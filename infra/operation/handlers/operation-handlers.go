@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_operation_use_case "github.com/nahualventure/class-backend/core/app/operation/application/use-cases/get-operation-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// PollInterval is the Retry-After sent while an operation runs, in seconds
+const PollInterval = "2"
+
+type OperationHandlers struct {
+	getOperationUseCase *get_operation_use_case.GetOperationUseCase
+}
+
+func NewOperationHandlers(getOperationUseCase *get_operation_use_case.GetOperationUseCase) *OperationHandlers {
+	return &OperationHandlers{
+		getOperationUseCase: getOperationUseCase,
+	}
+}
+
+func (h *OperationHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-operation",
+		Method:      http.MethodGet,
+		Path:        "/operations/{operationId}",
+		Summary:     "Get the status of an asynchronous operation",
+		Description: "Reports, imports, printouts and backups run asynchronously and answer 202 with the operation in the Location header. " +
+			"Poll it until done is true, the result then points to what the operation produced or the error says why it failed.",
+		Tags: []string{"Operations"},
+	}, h.GetOperation)
+}
+
+func (h *OperationHandlers) GetOperation(ctx context.Context, input *GetOperationRequest) (*OperationEnvelope, error) {
+	command, err := get_operation_use_case.NewGetOperationCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.OperationID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	operation, err := h.getOperationUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &OperationEnvelope{Body: NewOperationResponse(operation)}
+	if !operation.IsFinished() {
+		response.RetryAfter = PollInterval
+	}
+	return response, nil
+}
@@ -0,0 +1,107 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/partitioning/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/partitioning/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresPartitionManager manages native Postgres range partitions. Partitions are DDL, identifiers and
+// bounds cannot be parameterized so statements are built from the names in entities and sanitized.
+type PostgresPartitionManager struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresPartitionManager(dbInstance *pgxpool.Pool) ports.PartitionManager {
+	return &PostgresPartitionManager{
+		db: dbInstance,
+	}
+}
+
+func (m *PostgresPartitionManager) ListPartitions(ctx context.Context, table entities.PartitionedTable) ([]string, error) {
+	rows, err := m.db.Query(ctx, `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = $1::regclass`, table.Name)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return names, nil
+}
+
+func (m *PostgresPartitionManager) DefaultPartitionMonths(ctx context.Context, table entities.PartitionedTable) ([]time.Time, error) {
+	query := fmt.Sprintf(`SELECT DISTINCT date_trunc('month', %s)::date FROM %s`,
+		pgx.Identifier{table.Column}.Sanitize(), defaultPartition(table))
+
+	rows, err := m.db.Query(ctx, query)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	months, err := pgx.CollectRows(rows, pgx.RowTo[time.Time])
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return months, nil
+}
+
+func (m *PostgresPartitionManager) CreatePartition(ctx context.Context, partition *entities.MonthlyPartition) error {
+	parent := pgx.Identifier{partition.Table.Name}.Sanitize()
+	name := pgx.Identifier{partition.Name()}.Sanitize()
+	column := pgx.Identifier{partition.Table.Column}.Sanitize()
+
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING CONSTRAINTS)`, name, parent)); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	// Attaching checks the default partition has no rows of the month, they are moved first
+	moved, err := tx.Exec(ctx, fmt.Sprintf(`
+		WITH moved AS (
+			DELETE FROM %s WHERE %s >= $1 AND %s < $2 RETURNING *
+		)
+		INSERT INTO %s SELECT * FROM moved`, defaultPartition(partition.Table), column, column, name),
+		partition.From(), partition.To())
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	_, err = tx.Exec(ctx, fmt.Sprintf(`ALTER TABLE %s ATTACH PARTITION %s FOR VALUES FROM (%s) TO (%s)`,
+		parent, name, bound(partition.From()), bound(partition.To())))
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	partition.MovedRows = int(moved.RowsAffected())
+	return nil
+}
+
+func defaultPartition(table entities.PartitionedTable) string {
+	return pgx.Identifier{table.Name + "_default"}.Sanitize()
+}
+
+// bound is a literal valid for date and timestamp with time zone columns, month starts are midnight UTC
+func bound(t time.Time) string {
+	return fmt.Sprintf("'%s 00:00:00+00'", t.UTC().Format(time.DateOnly))
+}
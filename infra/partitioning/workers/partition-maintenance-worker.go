@@ -0,0 +1,39 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	ensure_partitions_use_case "github.com/nahualventure/class-backend/core/app/partitioning/application/use-cases/ensure-partitions-use-case"
+)
+
+// RunPartitionMaintenance creates the monthly partitions of the coming months on each tick until ctx is
+// cancelled. The first run also moves rows that sit in default partitions, such as the rows copied there
+// when a table was converted to a partitioned one.
+func RunPartitionMaintenance(ctx context.Context, useCase *ensure_partitions_use_case.EnsurePartitionsUseCase, monthsAhead int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		command, err := ensure_partitions_use_case.NewEnsurePartitionsCommand(time.Now(), monthsAhead)
+		if err != nil {
+			log.Printf("Invalid partition maintenance: %v", err)
+			return
+		}
+
+		partitions, err := useCase.Execute(ctx, command)
+		if err != nil {
+			log.Printf("Partition maintenance failed: %v", err)
+		}
+		for _, partition := range partitions {
+			log.Printf("Created partition %s, moved %d rows from the default partition", partition.Name(), partition.MovedRows)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package adapters
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/ports"
+)
+
+// MemoryEditLockStore is the single instance fallback used when Redis is not configured, editors
+// connected to different API instances do not see each other's locks.
+type MemoryEditLockStore struct {
+	mu    sync.Mutex
+	locks map[string]entities.EditLock
+}
+
+func NewMemoryEditLockStore() ports.EditLockStore {
+	return &MemoryEditLockStore{locks: make(map[string]entities.EditLock)}
+}
+
+func (s *MemoryEditLockStore) Acquire(_ context.Context, lock entities.EditLock) (entities.EditLock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := editLockKey(lock.TenantID, lock.EntityType, lock.EntityID)
+	if held, ok := s.locks[key]; ok && !held.HeldBy(lock.UserID) && held.ExpiresAt.After(time.Now()) {
+		return held, nil
+	}
+	s.locks[key] = lock
+
+	return lock, nil
+}
+
+func (s *MemoryEditLockStore) Get(_ context.Context, tenantID string, entityType string, entityID string, now time.Time) (*entities.EditLock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := editLockKey(tenantID, entityType, entityID)
+	held, ok := s.locks[key]
+	if !ok {
+		return nil, nil
+	}
+	if !held.ExpiresAt.After(now) {
+		delete(s.locks, key)
+		return nil, nil
+	}
+
+	return &held, nil
+}
+
+func (s *MemoryEditLockStore) Release(_ context.Context, tenantID string, entityType string, entityID string, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := editLockKey(tenantID, entityType, entityID)
+	if held, ok := s.locks[key]; ok && held.HeldBy(userID) {
+		delete(s.locks, key)
+	}
+
+	return nil
+}
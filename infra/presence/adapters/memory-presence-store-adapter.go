@@ -0,0 +1,65 @@
+package adapters
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/ports"
+)
+
+// MemoryPresenceStore is the single instance fallback used when Redis is not configured,
+// presence is not shared between API instances.
+type MemoryPresenceStore struct {
+	mu     sync.Mutex
+	scopes map[string]map[string]time.Time
+}
+
+func NewMemoryPresenceStore() ports.PresenceStore {
+	return &MemoryPresenceStore{scopes: make(map[string]map[string]time.Time)}
+}
+
+func (s *MemoryPresenceStore) Touch(_ context.Context, presence entities.Presence) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range presenceKeys(presence.TenantID, presence.ClassID) {
+		if s.scopes[key] == nil {
+			s.scopes[key] = make(map[string]time.Time)
+		}
+		s.scopes[key][presence.UserID] = presence.ExpiresAt
+	}
+
+	return nil
+}
+
+func (s *MemoryPresenceStore) Remove(_ context.Context, tenantID string, classID string, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range presenceKeys(tenantID, classID) {
+		delete(s.scopes[key], userID)
+	}
+
+	return nil
+}
+
+func (s *MemoryPresenceStore) ListOnline(_ context.Context, tenantID string, classID string, now time.Time) ([]entities.Presence, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := presenceKeys(tenantID, classID)[0]
+	online := make([]entities.Presence, 0, len(s.scopes[key]))
+	for userID, expiresAt := range s.scopes[key] {
+		if expiresAt.Before(now) {
+			delete(s.scopes[key], userID)
+			continue
+		}
+		online = append(online, entities.Presence{TenantID: tenantID, ClassID: classID, UserID: userID, ExpiresAt: expiresAt})
+	}
+
+	sort.Slice(online, func(i, j int) bool { return online[i].ExpiresAt.Before(online[j].ExpiresAt) })
+	return online, nil
+}
@@ -0,0 +1,102 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/redis"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// acquireEditLock sets the holder when the key is free or already holds the user, and replies the
+// holder with its remaining milliseconds. Checking and setting in one script keeps two editors from
+// both taking the lock.
+var acquireEditLock = goredis.NewScript(`
+local holder = redis.call('GET', KEYS[1])
+if not holder or holder == ARGV[1] then
+  redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+  return {ARGV[1], tonumber(ARGV[2])}
+end
+return {holder, redis.call('PTTL', KEYS[1])}
+`)
+
+// releaseEditLock deletes the key only while it holds the user
+var releaseEditLock = goredis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// RedisEditLockStore keeps one key per locked entity holding the user id, expiring with the lock
+type RedisEditLockStore struct {
+	client *redis.Client
+}
+
+func NewRedisEditLockStore(client *redis.Client) ports.EditLockStore {
+	return &RedisEditLockStore{client: client}
+}
+
+func (s *RedisEditLockStore) Acquire(ctx context.Context, lock entities.EditLock) (entities.EditLock, error) {
+	now := time.Now()
+	ttl := lock.ExpiresAt.Sub(now).Milliseconds()
+	if ttl <= 0 {
+		ttl = 1
+	}
+
+	reply, err := acquireEditLock.Run(ctx, s.client, []string{editLockKey(lock.TenantID, lock.EntityType, lock.EntityID)},
+		lock.UserID, ttl).Slice()
+	if err != nil {
+		return entities.EditLock{}, redis.Error("EVALSHA", err)
+	}
+
+	holder, _ := reply[0].(string)
+	remaining, _ := reply[1].(int64)
+	lock.UserID = holder
+	lock.ExpiresAt = now.Add(time.Duration(remaining) * time.Millisecond)
+	return lock, nil
+}
+
+func (s *RedisEditLockStore) Get(ctx context.Context, tenantID string, entityType string, entityID string, now time.Time) (*entities.EditLock, error) {
+	key := editLockKey(tenantID, entityType, entityID)
+
+	var holder *goredis.StringCmd
+	var remaining *goredis.DurationCmd
+	_, err := s.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		holder = pipe.Get(ctx, key)
+		remaining = pipe.PTTL(ctx, key)
+		return nil
+	})
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, redis.Error("GET", err)
+	}
+	// The key expired between both commands
+	if remaining.Val() <= 0 {
+		return nil, nil
+	}
+
+	return &entities.EditLock{
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		UserID:     holder.Val(),
+		ExpiresAt:  now.Add(remaining.Val()),
+	}, nil
+}
+
+func (s *RedisEditLockStore) Release(ctx context.Context, tenantID string, entityType string, entityID string, userID string) error {
+	err := releaseEditLock.Run(ctx, s.client, []string{editLockKey(tenantID, entityType, entityID)}, userID).Err()
+	return redis.Error("EVALSHA", err)
+}
+
+func editLockKey(tenantID string, entityType string, entityID string) string {
+	return fmt.Sprintf("edit_lock:{%s}:%s:%s", tenantID, entityType, entityID)
+}
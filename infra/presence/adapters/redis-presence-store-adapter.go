@@ -0,0 +1,85 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/redis"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// RedisPresenceStore keeps one sorted set per scope, scored by expiry in unix milliseconds.
+// Expired members are trimmed on read and the whole key expires when nobody heartbeats.
+type RedisPresenceStore struct {
+	client *redis.Client
+}
+
+func NewRedisPresenceStore(client *redis.Client) ports.PresenceStore {
+	return &RedisPresenceStore{client: client}
+}
+
+func (s *RedisPresenceStore) Touch(ctx context.Context, presence entities.Presence) error {
+	member := goredis.Z{Score: float64(presence.ExpiresAt.UnixMilli()), Member: presence.UserID}
+
+	// One round trip for both keys, the set and its expiry
+	_, err := s.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for _, key := range presenceKeys(presence.TenantID, presence.ClassID) {
+			pipe.ZAdd(ctx, key, member)
+			pipe.PExpire(ctx, key, entities.PresenceTTL)
+		}
+		return nil
+	})
+	return redis.Error("ZADD", err)
+}
+
+func (s *RedisPresenceStore) Remove(ctx context.Context, tenantID string, classID string, userID string) error {
+	for _, key := range presenceKeys(tenantID, classID) {
+		if err := s.client.ZRem(ctx, key, userID).Err(); err != nil {
+			return redis.Error("ZREM", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *RedisPresenceStore) ListOnline(ctx context.Context, tenantID string, classID string, now time.Time) ([]entities.Presence, error) {
+	key := presenceKeys(tenantID, classID)[0]
+	nowScore := strconv.FormatInt(now.UnixMilli(), 10)
+
+	if err := s.client.ZRemRangeByScore(ctx, key, "-inf", "("+nowScore).Err(); err != nil {
+		return nil, redis.Error("ZREMRANGEBYSCORE", err)
+	}
+
+	members, err := s.client.ZRangeByScoreWithScores(ctx, key, &goredis.ZRangeBy{Min: nowScore, Max: "+inf"}).Result()
+	if err != nil {
+		return nil, redis.Error("ZRANGEBYSCORE", err)
+	}
+
+	online := make([]entities.Presence, 0, len(members))
+	for _, member := range members {
+		userID, _ := member.Member.(string)
+		online = append(online, entities.Presence{
+			TenantID:  tenantID,
+			ClassID:   classID,
+			UserID:    userID,
+			ExpiresAt: time.UnixMilli(int64(member.Score)),
+		})
+	}
+
+	return online, nil
+}
+
+// presenceKeys returns the key of the requested scope first, followed by the tenant key when the
+// scope is a class
+func presenceKeys(tenantID string, classID string) []string {
+	tenantKey := fmt.Sprintf("presence:{%s}", tenantID)
+	if classID == "" {
+		return []string{tenantKey}
+	}
+	return []string{fmt.Sprintf("presence:{%s}:class:%s", tenantID, classID), tenantKey}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+)
+
+type EditLockPath struct {
+	EntityType string `path:"entityType" enum:"assignment,class_template,rubric" doc:"Kind of the entity being edited"`
+	EntityID   string `path:"entityId" format:"uuid"`
+}
+
+type GetEditLockRequest struct {
+	EditLockPath
+}
+
+type AcquireEditLockRequest struct {
+	EditLockPath
+}
+
+type ReleaseEditLockRequest struct {
+	EditLockPath
+}
+
+type EditLockResponse struct {
+	Body EditLockBody
+}
+
+// EditLockBody is the state of the lock of an entity, sent by the endpoints and the WebSocket
+type EditLockBody struct {
+	EntityType string     `json:"entity_type"`
+	EntityID   string     `json:"entity_id"`
+	Locked     bool       `json:"locked" doc:"Whether somebody is editing the entity"`
+	LockedBy   string     `json:"locked_by,omitempty" doc:"User editing the entity, absent when it is not locked"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" doc:"When the lock lapses without another heartbeat"`
+	// HeartbeatIntervalMs tells the holder when to renew, like the presence heartbeat
+	HeartbeatIntervalMs int64 `json:"heartbeat_interval_ms" doc:"The holder renews the lock within this interval"`
+}
+
+func NewEditLockBody(entityType string, entityID string, lock *entities.EditLock) EditLockBody {
+	body := EditLockBody{
+		EntityType:          entityType,
+		EntityID:            entityID,
+		HeartbeatIntervalMs: entities.HeartbeatInterval.Milliseconds(),
+	}
+	if lock != nil {
+		body.Locked = true
+		body.LockedBy = lock.UserID
+		body.ExpiresAt = &lock.ExpiresAt
+	}
+	return body
+}
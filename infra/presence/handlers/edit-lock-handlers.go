@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	acquire_edit_lock_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/acquire-edit-lock-use-case"
+	get_edit_lock_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/get-edit-lock-use-case"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	presenceErrors "github.com/nahualventure/class-backend/core/app/presence/domain/errors"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// Messages a client sends on the edit lock WebSocket
+const (
+	editLockAcquireMessage = "acquire"
+	editLockReleaseMessage = "release"
+)
+
+func (h *PresenceHandlers) registerEditLockRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-edit-lock",
+		Method:      http.MethodGet,
+		Path:        "/edit-locks/{entityType}/{entityId}",
+		Summary:     "Get who is editing an entity",
+		Tags:        []string{"Presence"},
+	}, h.GetEditLock)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "acquire-edit-lock",
+		Method:      http.MethodPut,
+		Path:        "/edit-locks/{entityType}/{entityId}",
+		Summary:     "Take or renew the edit lock of an entity",
+		Description: "Repeat within heartbeat_interval_ms while editing. Answers 409 EDIT_LOCK_HELD with the user editing it when somebody else holds the lock. " +
+			"Locks are advisory, writes to the entity are not blocked.",
+		Tags: []string{"Presence"},
+	}, h.AcquireEditLock)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "release-edit-lock",
+		Method:        http.MethodDelete,
+		Path:          "/edit-locks/{entityType}/{entityId}",
+		Summary:       "Release the edit lock of an entity",
+		Description:   "Does nothing when the caller does not hold the lock.",
+		Tags:          []string{"Presence"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.ReleaseEditLock)
+}
+
+// registerEditLockWebSocket serves GET /edit-locks/ws?entity_type=...&entity_id=... on the Gin
+// router. The state of the lock is sent on connect, whenever its holder changes and in reply to every
+// message. The client sends "acquire" to take or renew the lock and "release" to free it; a lock it
+// holds is released when the socket closes.
+func (h *PresenceHandlers) registerEditLockWebSocket(router gin.IRoutes, authzService *authorization.CasbinService) {
+	permission := authorization.ResourceAction{Resource: "edit_lock", Action: "hold"}
+
+	router.GET("/edit-locks/ws", func(c *gin.Context) {
+		meta := requestmeta.FromContext(c.Request.Context())
+		userID, tenantID := meta.UserID, meta.TenantID
+		// Holding a lock writes to the store like PUT /edit-locks, the upgrade is not a read
+		if err := authzService.Admit(tenantID, false); err != nil {
+			response := utils.ApplicationErrorToHTTPResponse(err)
+			c.JSON(response.Status, response)
+			return
+		}
+		if err := authorization.Authorize(authzService, userID, tenantID, permission); err != nil {
+			response := utils.ApplicationErrorToHTTPResponse(err)
+			c.JSON(response.Status, response)
+			return
+		}
+
+		acquire, err := acquire_edit_lock_use_case.NewAcquireEditLockCommand(tenantID, userID, c.Query("entity_type"), c.Query("entity_id"))
+		if err != nil {
+			response := utils.ApplicationErrorToHTTPResponse(err)
+			c.JSON(response.Status, response)
+			return
+		}
+		get, err := get_edit_lock_use_case.NewGetEditLockCommand(tenantID, acquire.EntityType, acquire.EntityID)
+		if err != nil {
+			response := utils.ApplicationErrorToHTTPResponse(err)
+			c.JSON(response.Status, response)
+			return
+		}
+
+		websocket.Server{
+			// Identity comes from headers set by the gateway, not cookies, so any origin is accepted
+			Handshake: func(*websocket.Config, *http.Request) error { return nil },
+			Handler:   func(conn *websocket.Conn) { h.serveEditLock(conn, acquire, get) },
+		}.ServeHTTP(c.Writer, c.Request)
+	})
+}
+
+func (h *PresenceHandlers) serveEditLock(conn *websocket.Conn, acquire *acquire_edit_lock_use_case.AcquireEditLockCommand,
+	get *get_edit_lock_use_case.GetEditLockCommand) {
+	defer conn.Close()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := h.acquireEditLockUseCase.Release(ctx, acquire); err != nil {
+			log.Printf("Failed to release edit lock of user %s: %v", acquire.UserID, err)
+		}
+	}()
+
+	// Messages are read on their own goroutine so the lock is polled while the client is quiet
+	messages := make(chan string)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(messages)
+		for {
+			if err := conn.SetReadDeadline(time.Now().Add(entities.EditLockTTL)); err != nil {
+				return
+			}
+			var message string
+			if err := websocket.Message.Receive(conn, &message); err != nil {
+				return
+			}
+			select {
+			case messages <- message:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	ctx := conn.Request().Context()
+	ticker := time.NewTicker(entities.EditLockPollInterval)
+	defer ticker.Stop()
+
+	var sent *EditLockBody
+	for {
+		reply := false
+		select {
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			reply = true
+			switch message {
+			case editLockAcquireMessage:
+				// A lock held by somebody else is not an error here, the state names them
+				if _, err := h.acquireEditLockUseCase.Execute(ctx, acquire); err != nil && !isEditLockHeld(err) {
+					log.Printf("Failed to acquire edit lock of user %s: %v", acquire.UserID, err)
+					return
+				}
+			case editLockReleaseMessage:
+				if err := h.acquireEditLockUseCase.Release(ctx, acquire); err != nil {
+					log.Printf("Failed to release edit lock of user %s: %v", acquire.UserID, err)
+					return
+				}
+			}
+		case <-ticker.C:
+		}
+
+		lock, err := h.getEditLockUseCase.Execute(ctx, get)
+		if err != nil {
+			log.Printf("Failed to get edit lock for user %s: %v", acquire.UserID, err)
+			return
+		}
+		body := NewEditLockBody(get.EntityType, get.EntityID, lock)
+		// Polls only send when the holder changed, a renewal of the same holder is not news
+		if !reply && sent != nil && sent.Locked == body.Locked && sent.LockedBy == body.LockedBy {
+			continue
+		}
+		if err := websocket.JSON.Send(conn, body); err != nil {
+			return
+		}
+		sent = &body
+	}
+}
+
+func (h *PresenceHandlers) GetEditLock(ctx context.Context, input *GetEditLockRequest) (*EditLockResponse, error) {
+	command, err := get_edit_lock_use_case.NewGetEditLockCommand(authorization.TenantIDFromContext(ctx), input.EntityType, input.EntityID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	lock, err := h.getEditLockUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &EditLockResponse{Body: NewEditLockBody(command.EntityType, command.EntityID, lock)}, nil
+}
+
+func (h *PresenceHandlers) AcquireEditLock(ctx context.Context, input *AcquireEditLockRequest) (*EditLockResponse, error) {
+	command, err := acquire_edit_lock_use_case.NewAcquireEditLockCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.EntityType,
+		input.EntityID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	lock, err := h.acquireEditLockUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &EditLockResponse{Body: NewEditLockBody(command.EntityType, command.EntityID, lock)}, nil
+}
+
+func (h *PresenceHandlers) ReleaseEditLock(ctx context.Context, input *ReleaseEditLockRequest) (*struct{}, error) {
+	command, err := acquire_edit_lock_use_case.NewAcquireEditLockCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.EntityType,
+		input.EntityID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.acquireEditLockUseCase.Release(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
+
+func isEditLockHeld(err error) bool {
+	var appErr appErrors.ApplicationError
+	return errors.As(err, &appErr) && appErr.GetCode() == presenceErrors.EditLockHeldError.String()
+}
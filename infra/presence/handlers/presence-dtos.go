@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+)
+
+type HeartbeatRequest struct {
+	Body struct {
+		ClassID string `json:"class_id,omitempty" format:"uuid" doc:"Class the user is looking at, omit for tenant wide presence"`
+	}
+}
+
+type HeartbeatResponse struct {
+	Body HeartbeatBody
+}
+
+type HeartbeatBody struct {
+	ExpiresAt           time.Time `json:"expires_at"`
+	HeartbeatIntervalMs int64     `json:"heartbeat_interval_ms" doc:"Send the next heartbeat within this interval"`
+}
+
+func NewHeartbeatBody(presence *entities.Presence) HeartbeatBody {
+	return HeartbeatBody{
+		ExpiresAt:           presence.ExpiresAt,
+		HeartbeatIntervalMs: entities.HeartbeatInterval.Milliseconds(),
+	}
+}
+
+type ListOnlineUsersRequest struct {
+	ClassID string `query:"class_id" format:"uuid" doc:"Restrict to a class, omit for the whole tenant"`
+}
+
+type OnlineUserResponse struct {
+	UserID    string    `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type ListOnlineUsersResponse struct {
+	Body struct {
+		Items []OnlineUserResponse `json:"items"`
+	}
+}
+
+func NewListOnlineUsersResponse(online []entities.Presence) *ListOnlineUsersResponse {
+	response := &ListOnlineUsersResponse{}
+	response.Body.Items = make([]OnlineUserResponse, 0, len(online))
+	for _, presence := range online {
+		response.Body.Items = append(response.Body.Items, OnlineUserResponse{
+			UserID:    presence.UserID,
+			ExpiresAt: presence.ExpiresAt,
+		})
+	}
+	return response
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	acquire_edit_lock_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/acquire-edit-lock-use-case"
+	get_edit_lock_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/get-edit-lock-use-case"
+	list_online_users_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/list-online-users-use-case"
+	record_heartbeat_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/record-heartbeat-use-case"
+	"github.com/nahualventure/class-backend/core/app/presence/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+type PresenceHandlers struct {
+	recordHeartbeatUseCase *record_heartbeat_use_case.RecordHeartbeatUseCase
+	listOnlineUsersUseCase *list_online_users_use_case.ListOnlineUsersUseCase
+	acquireEditLockUseCase *acquire_edit_lock_use_case.AcquireEditLockUseCase
+	getEditLockUseCase     *get_edit_lock_use_case.GetEditLockUseCase
+}
+
+func NewPresenceHandlers(
+	recordHeartbeatUseCase *record_heartbeat_use_case.RecordHeartbeatUseCase,
+	listOnlineUsersUseCase *list_online_users_use_case.ListOnlineUsersUseCase,
+	acquireEditLockUseCase *acquire_edit_lock_use_case.AcquireEditLockUseCase,
+	getEditLockUseCase *get_edit_lock_use_case.GetEditLockUseCase,
+) *PresenceHandlers {
+	return &PresenceHandlers{
+		recordHeartbeatUseCase: recordHeartbeatUseCase,
+		listOnlineUsersUseCase: listOnlineUsersUseCase,
+		acquireEditLockUseCase: acquireEditLockUseCase,
+		getEditLockUseCase:     getEditLockUseCase,
+	}
+}
+
+func (h *PresenceHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "presence-heartbeat",
+		Method:      http.MethodPost,
+		Path:        "/presence/heartbeat",
+		Summary:     "Send a presence heartbeat",
+		Description: "Fallback for clients that cannot keep the presence WebSocket (GET /presence/ws) open.",
+		Tags:        []string{"Presence"},
+	}, h.Heartbeat)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-online-users",
+		Method:      http.MethodGet,
+		Path:        "/presence",
+		Summary:     "List online users of the tenant or a class",
+		Tags:        []string{"Presence"},
+	}, h.ListOnlineUsers)
+
+	h.registerEditLockRoutes(api)
+}
+
+// RegisterWebSocket serves GET /presence/ws?class_id=... on the Gin router, Huma does not handle
+// WebSockets. Every message received from the client counts as a heartbeat and is acknowledged
+// with the new expiry; the presence is removed when the socket closes. The edit lock WebSocket is
+// served next to it.
+func (h *PresenceHandlers) RegisterWebSocket(router gin.IRoutes, authzService *authorization.CasbinService) {
+	permission := authorization.ResourceAction{Resource: "presence", Action: "heartbeat"}
+
+	router.GET("/presence/ws", func(c *gin.Context) {
+		meta := requestmeta.FromContext(c.Request.Context())
+		userID, tenantID := meta.UserID, meta.TenantID
+		// Heartbeats record presence like POST /presence/heartbeat, the upgrade is not a read
+		if err := authzService.Admit(tenantID, false); err != nil {
+			response := utils.ApplicationErrorToHTTPResponse(err)
+			c.JSON(response.Status, response)
+			return
+		}
+		if err := authorization.Authorize(authzService, userID, tenantID, permission); err != nil {
+			response := utils.ApplicationErrorToHTTPResponse(err)
+			c.JSON(response.Status, response)
+			return
+		}
+
+		command, err := record_heartbeat_use_case.NewRecordHeartbeatCommand(tenantID, userID, c.Query("class_id"))
+		if err != nil {
+			response := utils.ApplicationErrorToHTTPResponse(err)
+			c.JSON(response.Status, response)
+			return
+		}
+
+		websocket.Server{
+			// Identity comes from headers set by the gateway, not cookies, so any origin is accepted
+			Handshake: func(*websocket.Config, *http.Request) error { return nil },
+			Handler:   func(conn *websocket.Conn) { h.servePresence(conn, command) },
+		}.ServeHTTP(c.Writer, c.Request)
+	})
+
+	h.registerEditLockWebSocket(router, authzService)
+}
+
+func (h *PresenceHandlers) servePresence(conn *websocket.Conn, command *record_heartbeat_use_case.RecordHeartbeatCommand) {
+	defer conn.Close()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := h.recordHeartbeatUseCase.Leave(ctx, command); err != nil {
+			log.Printf("Failed to remove presence of user %s: %v", command.UserID, err)
+		}
+	}()
+
+	for {
+		presence, err := h.recordHeartbeatUseCase.Execute(conn.Request().Context(), command)
+		if err != nil {
+			log.Printf("Failed to record presence of user %s: %v", command.UserID, err)
+			return
+		}
+
+		if err := websocket.JSON.Send(conn, NewHeartbeatBody(presence)); err != nil {
+			return
+		}
+
+		if err := conn.SetReadDeadline(time.Now().Add(entities.PresenceTTL)); err != nil {
+			return
+		}
+		var message string
+		if err := websocket.Message.Receive(conn, &message); err != nil {
+			return
+		}
+	}
+}
+
+func (h *PresenceHandlers) Heartbeat(ctx context.Context, input *HeartbeatRequest) (*HeartbeatResponse, error) {
+	command, err := record_heartbeat_use_case.NewRecordHeartbeatCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.ClassID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	presence, err := h.recordHeartbeatUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &HeartbeatResponse{Body: NewHeartbeatBody(presence)}, nil
+}
+
+func (h *PresenceHandlers) ListOnlineUsers(ctx context.Context, input *ListOnlineUsersRequest) (*ListOnlineUsersResponse, error) {
+	command, err := list_online_users_use_case.NewListOnlineUsersCommand(authorization.TenantIDFromContext(ctx), input.ClassID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	online, err := h.listOnlineUsersUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListOnlineUsersResponse(online), nil
+}
@@ -0,0 +1,104 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/preview/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// documentTimeout bounds the conversion of a document when the context has no deadline
+const documentTimeout = time.Minute
+
+// officeContentTypes are the documents LibreOffice converts to PDF before their first page is drawn
+var officeContentTypes = map[string]bool{
+	"application/msword": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+	"application/vnd.ms-excel": true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         true,
+	"application/vnd.ms-powerpoint":                                             true,
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": true,
+	"application/vnd.oasis.opendocument.text":                                   true,
+	"application/vnd.oasis.opendocument.spreadsheet":                            true,
+	"application/vnd.oasis.opendocument.presentation":                           true,
+}
+
+// DocumentPreviewRenderer draws the first page of PDFs with pdftoppm (poppler-utils), Office documents
+// are converted to PDF with LibreOffice first. Office documents are not supported without soffice.
+type DocumentPreviewRenderer struct {
+	pdftoppm string
+	soffice  string
+}
+
+// NewDocumentPreviewRenderer renders with the pdftoppm and soffice found in the PATH, it returns
+// false when pdftoppm is not installed
+func NewDocumentPreviewRenderer() (ports.PreviewRenderer, bool) {
+	pdftoppm, err := exec.LookPath("pdftoppm")
+	if err != nil {
+		return nil, false
+	}
+	soffice, _ := exec.LookPath("soffice")
+
+	return &DocumentPreviewRenderer{pdftoppm: pdftoppm, soffice: soffice}, true
+}
+
+func (r *DocumentPreviewRenderer) Supports(contentType string) bool {
+	return contentType == "application/pdf" || (r.soffice != "" && officeContentTypes[contentType])
+}
+
+func (r *DocumentPreviewRenderer) Render(ctx context.Context, contentType string, content []byte) ([]byte, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, documentTimeout)
+		defer cancel()
+	}
+
+	dir, err := os.MkdirTemp("", "preview-*")
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pdf := filepath.Join(dir, "document.pdf")
+	if contentType != "application/pdf" {
+		source := filepath.Join(dir, "document")
+		if err := os.WriteFile(source, content, 0o600); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		// Every conversion gets its own profile, LibreOffice refuses to run twice on the same one
+		if err := run(ctx, r.soffice, "-env:UserInstallation=file://"+filepath.Join(dir, "profile"),
+			"--headless", "--convert-to", "pdf", "--outdir", dir, source); err != nil {
+			return nil, err
+		}
+	} else if err := os.WriteFile(pdf, content, 0o600); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	if err := run(ctx, r.pdftoppm, "-png", "-singlefile", "-f", "1", "-l", "1",
+		"-scale-to", strconv.Itoa(PreviewSize), pdf, filepath.Join(dir, "preview")); err != nil {
+		return nil, err
+	}
+
+	preview, err := os.ReadFile(filepath.Join(dir, "preview.png"))
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("the document has no first page", err)
+	}
+	return preview, nil
+}
+
+func run(ctx context.Context, command string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return appErrors.NewInfrastructureError(fmt.Sprintf("%s failed: %s", filepath.Base(command), bytes.TrimSpace(stderr.Bytes())), err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+
+	"github.com/nahualventure/class-backend/core/app/preview/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// PreviewSize is the longest side of a preview in pixels
+const PreviewSize = 320
+
+// maxImagePixels bounds the images that are decoded, a small file can declare a huge image
+const maxImagePixels = 50_000_000
+
+var imageContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+}
+
+// ImagePreviewRenderer scales images down to thumbnails, the first frame of animated GIFs
+type ImagePreviewRenderer struct{}
+
+func NewImagePreviewRenderer() ports.PreviewRenderer {
+	return ImagePreviewRenderer{}
+}
+
+func (ImagePreviewRenderer) Supports(contentType string) bool {
+	return imageContentTypes[contentType]
+}
+
+func (ImagePreviewRenderer) Render(_ context.Context, _ string, content []byte) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to read the image", err)
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("image of %dx%d is too large to preview", config.Width, config.Height), nil)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to decode the image", err)
+	}
+
+	var preview bytes.Buffer
+	if err := png.Encode(&preview, thumbnail(img, PreviewSize)); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to encode the preview", err)
+	}
+	return preview.Bytes(), nil
+}
+
+// thumbnail scales img to fit in a size by size box keeping its aspect, each pixel is the average of
+// the pixels it covers. Smaller images are kept as they are.
+func thumbnail(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	scaledWidth, scaledHeight := size, max(height*size/width, 1)
+	if height > width {
+		scaledWidth, scaledHeight = max(width*size/height, 1), size
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, scaledWidth, scaledHeight))
+	for y := range scaledHeight {
+		top, bottom := bounds.Min.Y+y*height/scaledHeight, bounds.Min.Y+(y+1)*height/scaledHeight
+		for x := range scaledWidth {
+			left, right := bounds.Min.X+x*width/scaledWidth, bounds.Min.X+(x+1)*width/scaledWidth
+
+			var r, g, b, a, count uint64
+			for sy := top; sy < bottom; sy++ {
+				for sx := left; sx < right; sx++ {
+					pixel := color.RGBA64Model.Convert(img.At(sx, sy)).(color.RGBA64)
+					r += uint64(pixel.R)
+					g += uint64(pixel.G)
+					b += uint64(pixel.B)
+					a += uint64(pixel.A)
+					count++
+				}
+			}
+			scaled.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / count >> 8),
+				G: uint8(g / count >> 8),
+				B: uint8(b / count >> 8),
+				A: uint8(a / count >> 8),
+			})
+		}
+	}
+	return scaled
+}
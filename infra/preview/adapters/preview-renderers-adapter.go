@@ -0,0 +1,36 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/preview/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// PreviewRenderers renders each file with the first of the renderers that supports its content type
+type PreviewRenderers []ports.PreviewRenderer
+
+func NewPreviewRenderers(renderers ...ports.PreviewRenderer) ports.PreviewRenderer {
+	return PreviewRenderers(renderers)
+}
+
+func (r PreviewRenderers) Supports(contentType string) bool {
+	return r.renderer(contentType) != nil
+}
+
+func (r PreviewRenderers) Render(ctx context.Context, contentType string, content []byte) ([]byte, error) {
+	renderer := r.renderer(contentType)
+	if renderer == nil {
+		return nil, appErrors.NewInfrastructureError("no preview renderer for "+contentType, nil)
+	}
+	return renderer.Render(ctx, contentType, content)
+}
+
+func (r PreviewRenderers) renderer(contentType string) ports.PreviewRenderer {
+	for _, renderer := range r {
+		if renderer.Supports(contentType) {
+			return renderer
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package adapters
+
+import (
+	"context"
+
+	libraryEntities "github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	messagingEntities "github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/preview/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/preview/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresPreviewRepository reads the preview status kept on resource_versions and
+// message_attachments
+type PostgresPreviewRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresPreviewRepository(dbInstance *pgxpool.Pool) ports.PreviewRepository {
+	return &PostgresPreviewRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresPreviewRepository) ListPending(ctx context.Context, limit int) ([]*entities.PreviewSource, error) {
+	rows, err := r.queries.ListPendingPreviews(ctx, int32(limit))
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	sources := make([]*entities.PreviewSource, 0, len(rows))
+	for _, row := range rows {
+		source := &entities.PreviewSource{
+			Kind:        entities.FileKind(row.FileKind),
+			ID:          row.ID.String(),
+			TenantID:    row.TenantID,
+			ContentType: row.ContentType,
+		}
+		// Keys are built by the modules owning the files
+		if source.Kind == entities.FileKindResourceVersion {
+			version := &libraryEntities.ResourceVersion{ID: source.ID, TenantID: source.TenantID, ResourceID: row.ParentID.String()}
+			source.StorageKey = version.StorageKey()
+		} else {
+			message := &messagingEntities.Message{TenantID: source.TenantID, ConversationID: row.ParentID.String()}
+			source.StorageKey = message.AttachmentKey(source.ID)
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+func (r *PostgresPreviewRepository) Record(ctx context.Context, preview *entities.FilePreview) (bool, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(preview.Source.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	var updated int64
+	var err error
+	if preview.Source.Kind == entities.FileKindResourceVersion {
+		updated, err = r.queries.SetResourceVersionPreviewStatus(ctx, db.SetResourceVersionPreviewStatusParams{
+			PreviewStatus: string(preview.Status),
+			ID:            pgID,
+		})
+	} else {
+		updated, err = r.queries.SetAttachmentPreviewStatus(ctx, db.SetAttachmentPreviewStatusParams{
+			PreviewStatus: string(preview.Status),
+			ID:            pgID,
+		})
+	}
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return updated > 0, nil
+}
@@ -0,0 +1,22 @@
+-- name: ListPendingPreviews :many
+SELECT 'resource_version'::text AS file_kind, v.id, v.tenant_id, v.resource_id AS parent_id, v.content_type,
+       v.created_at AS uploaded_at
+FROM resource_versions v
+WHERE v.preview_status = 'pending' AND v.scan_status = 'clean'
+UNION ALL
+SELECT 'attachment'::text, a.id, m.tenant_id, m.conversation_id, a.content_type, m.created_at
+FROM message_attachments a
+JOIN messages m ON m.id = a.message_id
+WHERE a.preview_status = 'pending' AND a.scan_status = 'clean'
+ORDER BY uploaded_at
+LIMIT @max_files;
+
+-- name: SetResourceVersionPreviewStatus :execrows
+UPDATE resource_versions
+SET preview_status = @preview_status
+WHERE id = @id AND preview_status = 'pending';
+
+-- name: SetAttachmentPreviewStatus :execrows
+UPDATE message_attachments
+SET preview_status = @preview_status
+WHERE id = @id AND preview_status = 'pending';
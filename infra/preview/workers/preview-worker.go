@@ -0,0 +1,30 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	generate_file_previews_use_case "github.com/nahualventure/class-backend/core/app/preview/application/use-cases/generate-file-previews-use-case"
+)
+
+// RunFilePreviews generates the previews of the files scanned clean every interval, it returns when
+// ctx is cancelled
+func RunFilePreviews(ctx context.Context, useCase *generate_file_previews_use_case.GenerateFilePreviewsUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if generated, err := useCase.Execute(ctx); err != nil {
+			log.Printf("Failed to generate file previews: %v", err)
+		} else if generated > 0 {
+			log.Printf("Generated %d file previews", generated)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package adapters
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/printout/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/pdf"
+)
+
+// PDFPrintoutRenderer writes printouts as plain PDF documents, see pdf.Layout. The header of the
+// table is repeated on every page it continues on.
+type PDFPrintoutRenderer struct{}
+
+func NewPDFPrintoutRenderer() ports.PrintoutRenderer {
+	return &PDFPrintoutRenderer{}
+}
+
+func (r *PDFPrintoutRenderer) Render(ctx context.Context, document *entities.Document) ([]byte, error) {
+	layout := &pdf.Layout{}
+	layout.Text(document.Title, true, 18)
+	layout.Skip()
+	for _, line := range document.Lines {
+		layout.Text(line, false, 11)
+	}
+	layout.Skip()
+
+	if len(document.Rows) == 0 {
+		layout.Text(document.Empty, false, 11)
+	} else {
+		columns, headings := columnOffsets(document.Columns)
+		layout.Header(columns, headings...)
+		for _, row := range document.Rows {
+			layout.Row(false, columns, row...)
+		}
+		layout.EndTable()
+	}
+	layout.Skip()
+
+	for _, line := range strings.Split(document.Footer, "\n") {
+		layout.Text(line, false, 11)
+	}
+
+	return layout.Bytes(), nil
+}
+
+// columnOffsets splits the width of the page between the columns by their share
+func columnOffsets(columns []entities.Column) ([]int, []string) {
+	total := 0
+	for _, column := range columns {
+		total += column.Width
+	}
+
+	offsets := make([]int, 0, len(columns))
+	headings := make([]string, 0, len(columns))
+	offset := 0
+	for _, column := range columns {
+		offsets = append(offsets, offset*pdf.ContentWidth/max(total, 1))
+		headings = append(headings, column.Heading)
+		offset += column.Width
+	}
+	return offsets, headings
+}
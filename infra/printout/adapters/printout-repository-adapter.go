@@ -0,0 +1,175 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/printout/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresPrintoutRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresPrintoutRepository(dbInstance *pgxpool.Pool) ports.PrintoutRepository {
+	return &PostgresPrintoutRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresPrintoutRepository) Create(ctx context.Context, printout *entities.Printout) error {
+	var pgUUID, pgClassID pgtype.UUID
+	if err := pgUUID.Scan(printout.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgClassID.Scan(printout.ClassID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.CreatePrintout(ctx, db.CreatePrintoutParams{
+		ID:          pgUUID,
+		TenantID:    printout.TenantID,
+		Kind:        string(printout.Kind),
+		ClassID:     pgClassID,
+		WeekOf:      pgtype.Date{Time: printout.WeekOf, Valid: true},
+		Status:      string(printout.Status),
+		RequestedBy: printout.RequestedBy,
+		CreatedAt:   pgtype.Timestamptz{Time: printout.CreatedAt, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: printout.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresPrintoutRepository) FindByID(ctx context.Context, tenantID string, printoutID string) (*entities.Printout, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(printoutID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetPrintout(ctx, db.GetPrintoutParams{ID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toPrintout(db.ClaimNextPendingPrintoutRow(row))
+}
+
+func (r *PostgresPrintoutRepository) FindArtifact(ctx context.Context, tenantID string, printoutID string) (*entities.Artifact, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(printoutID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetPrintoutArtifact(ctx, db.GetPrintoutArtifactParams{ID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &entities.Artifact{
+		FileName:    valueOrEmpty(row.ArtifactName),
+		ContentType: valueOrEmpty(row.ArtifactContentType),
+		Content:     row.Artifact,
+	}, nil
+}
+
+func (r *PostgresPrintoutRepository) ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.Printout, error) {
+	row, err := r.queries.ClaimNextPendingPrintout(ctx, pgtype.Timestamptz{Time: time.Now().Add(-staleAfter), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toPrintout(row)
+}
+
+func (r *PostgresPrintoutRepository) MarkReady(ctx context.Context, printoutID string, artifact *entities.Artifact, expiresAt time.Time) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(printoutID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.MarkPrintoutReady(ctx, db.MarkPrintoutReadyParams{
+		ID:                  pgUUID,
+		ArtifactName:        &artifact.FileName,
+		ArtifactContentType: &artifact.ContentType,
+		Artifact:            artifact.Content,
+		ExpiresAt:           pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresPrintoutRepository) MarkFailed(ctx context.Context, printoutID string, reason string) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(printoutID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := r.queries.MarkPrintoutFailed(ctx, db.MarkPrintoutFailedParams{ID: pgUUID, FailureReason: &reason}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresPrintoutRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	deleted, err := r.queries.DeleteExpiredPrintouts(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	return deleted, nil
+}
+
+// toPrintout maps any of the printout queries, they all select the same columns
+func toPrintout(row db.ClaimNextPendingPrintoutRow) (*entities.Printout, error) {
+	var expiresAt *time.Time
+	if row.ExpiresAt.Valid {
+		expiresAt = &row.ExpiresAt.Time
+	}
+
+	return entities.NewPrintout(
+		row.ID.String(),
+		row.TenantID,
+		entities.Kind(row.Kind),
+		row.ClassID.String(),
+		row.WeekOf.Time,
+		entities.PrintoutStatus(row.Status),
+		valueOrEmpty(row.FailureReason),
+		row.RequestedBy,
+		expiresAt,
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+	)
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
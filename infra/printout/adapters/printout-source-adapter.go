@@ -0,0 +1,84 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/printout/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresPrintoutSource reads the enrollments and weekly meetings of the enrollment module, the
+// names of the user module and the non-instructional days of the calendar module
+type PostgresPrintoutSource struct {
+	queries *db.Queries
+}
+
+func NewPostgresPrintoutSource(dbInstance *pgxpool.Pool) ports.PrintoutSource {
+	return &PostgresPrintoutSource{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresPrintoutSource) Roster(ctx context.Context, tenantID string, classID string) ([]entities.Student, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := s.queries.ListRosterForPrintout(ctx, db.ListRosterForPrintoutParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	students := make([]entities.Student, 0, len(rows))
+	for _, row := range rows {
+		students = append(students, entities.Student{ID: row.UserID, Name: row.Name, Email: row.Email})
+	}
+	return students, nil
+}
+
+func (s *PostgresPrintoutSource) Meetings(ctx context.Context, tenantID string, classID string) ([]entities.Meeting, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := s.queries.ListClassMeetings(ctx, db.ListClassMeetingsParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	meetings := make([]entities.Meeting, 0, len(rows))
+	for _, row := range rows {
+		meetings = append(meetings, entities.Meeting{
+			Weekday:     time.Weekday(row.Weekday),
+			StartMinute: int(row.StartMinute),
+			EndMinute:   int(row.EndMinute),
+		})
+	}
+	return meetings, nil
+}
+
+func (s *PostgresPrintoutSource) DaysOff(ctx context.Context, tenantID string, from time.Time, to time.Time) ([]entities.DayOff, error) {
+	rows, err := s.queries.ListNonInstructionalDays(ctx, db.ListNonInstructionalDaysParams{
+		TenantID: tenantID,
+		FromDay:  pgtype.Date{Time: from, Valid: true},
+		ToDay:    pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	days := make([]entities.DayOff, 0, len(rows))
+	for _, row := range rows {
+		days = append(days, entities.DayOff{Date: row.Day.Time, Name: row.Name})
+	}
+	return days, nil
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+)
+
+type PrintoutResponse struct {
+	ID            string     `json:"id"`
+	Kind          string     `json:"kind" enum:"roster,weekly_schedule,attendance_sheet"`
+	ClassID       string     `json:"class_id"`
+	WeekOf        string     `json:"week_of" doc:"Monday of the week printed, as YYYY-MM-DD"`
+	Status        string     `json:"status" enum:"pending,running,ready,failed"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	RequestedBy   string     `json:"requested_by"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty" doc:"When the file stops being downloadable, set once the printout is ready"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func NewPrintoutResponse(printout *entities.Printout) PrintoutResponse {
+	return PrintoutResponse{
+		ID:            printout.ID,
+		Kind:          string(printout.Kind),
+		ClassID:       printout.ClassID,
+		WeekOf:        printout.WeekOf.Format(entities.DateLayout),
+		Status:        string(printout.Status),
+		FailureReason: printout.FailureReason,
+		RequestedBy:   printout.RequestedBy,
+		ExpiresAt:     printout.ExpiresAt,
+		CreatedAt:     printout.CreatedAt,
+		UpdatedAt:     printout.UpdatedAt,
+	}
+}
+
+type DownloadClassPrintoutRequest struct {
+	ClassID string `path:"classId" format:"uuid" doc:"Class ID"`
+	Kind    string `path:"kind" enum:"roster,weekly_schedule,attendance_sheet" doc:"Printout to download"`
+	WeekOf  string `query:"week_of" doc:"Any day of the week to print as YYYY-MM-DD, the current week in the zone of the school by default. Rosters ignore it."`
+}
+
+type RequestPrintoutRequest struct {
+	Body struct {
+		Kind    string `json:"kind" enum:"roster,weekly_schedule,attendance_sheet"`
+		ClassID string `json:"class_id" format:"uuid"`
+		WeekOf  string `json:"week_of,omitempty" doc:"Any day of the week to print as YYYY-MM-DD, the current week in the zone of the school by default. Rosters ignore it."`
+	}
+}
+
+type PrintoutEnvelope struct {
+	Body PrintoutResponse
+}
+
+type PrintoutAcceptedEnvelope struct {
+	Location string `header:"Location" doc:"Operation to poll until the printout is generated"`
+	Body     PrintoutResponse
+}
+
+type GetPrintoutRequest struct {
+	PrintoutID string `path:"printoutId" format:"uuid" doc:"Printout ID"`
+}
+
+type DownloadPrintoutRequest struct {
+	PrintoutID string `path:"printoutId" format:"uuid" doc:"Printout ID"`
+	export.RangeRequest
+}
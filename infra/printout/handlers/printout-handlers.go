@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	get_printout_use_case "github.com/nahualventure/class-backend/core/app/printout/application/use-cases/get-printout-use-case"
+	render_printout_use_case "github.com/nahualventure/class-backend/core/app/printout/application/use-cases/render-printout-use-case"
+	request_printout_use_case "github.com/nahualventure/class-backend/core/app/printout/application/use-cases/request-printout-use-case"
+	"github.com/nahualventure/class-backend/core/app/printout/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type PrintoutHandlers struct {
+	renderPrintoutUseCase  *render_printout_use_case.RenderPrintoutUseCase
+	requestPrintoutUseCase *request_printout_use_case.RequestPrintoutUseCase
+	getPrintoutUseCase     *get_printout_use_case.GetPrintoutUseCase
+}
+
+func NewPrintoutHandlers(
+	renderPrintoutUseCase *render_printout_use_case.RenderPrintoutUseCase,
+	requestPrintoutUseCase *request_printout_use_case.RequestPrintoutUseCase,
+	getPrintoutUseCase *get_printout_use_case.GetPrintoutUseCase,
+) *PrintoutHandlers {
+	return &PrintoutHandlers{
+		renderPrintoutUseCase:  renderPrintoutUseCase,
+		requestPrintoutUseCase: requestPrintoutUseCase,
+		getPrintoutUseCase:     getPrintoutUseCase,
+	}
+}
+
+func (h *PrintoutHandlers) RegisterRoutes(api huma.API) {
+	pdfResponse := map[string]*huma.Response{
+		"200": {
+			Description: "Printout as a PDF file",
+			Content:     map[string]*huma.MediaType{"application/pdf": {}},
+		},
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "download-class-printout",
+		Method:      http.MethodGet,
+		Path:        "/classes/{classId}/printouts/{kind}",
+		Summary:     "Download a printout of a class",
+		Description: "Renders the printout right away. Printouts of more than 150 rows fail with PRINTOUT_TOO_LARGE, request them instead.",
+		Tags:        []string{"Printouts"},
+		Responses:   pdfResponse,
+	}, h.DownloadClassPrintout)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "request-printout",
+		Method:        http.MethodPost,
+		Path:          "/printouts",
+		Summary:       "Request a printout of a class",
+		Description:   "Queues the printout for asynchronous generation. Poll the operation in the Location header until it is done, then download the printout.",
+		Tags:          []string{"Printouts"},
+		DefaultStatus: http.StatusAccepted,
+	}, h.RequestPrintout)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-printout",
+		Method:      http.MethodGet,
+		Path:        "/printouts/{printoutId}",
+		Summary:     "Get printout status",
+		Tags:        []string{"Printouts"},
+	}, h.GetPrintout)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "download-printout",
+		Method:      http.MethodGet,
+		Path:        "/printouts/{printoutId}/download",
+		Summary:     "Download a generated printout",
+		Tags:        []string{"Printouts"},
+		Responses:   pdfResponse,
+	}, h.DownloadPrintout)
+}
+
+func (h *PrintoutHandlers) DownloadClassPrintout(ctx context.Context, input *DownloadClassPrintoutRequest) (*huma.StreamResponse, error) {
+	weekOf, err := weekOf(ctx, input.WeekOf)
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := render_printout_use_case.NewRenderPrintoutCommand(authorization.TenantIDFromContext(ctx), input.Kind, input.ClassID, weekOf)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	artifact, err := h.renderPrintoutUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return export.FileDownload{
+		Filename:    artifact.FileName,
+		ContentType: artifact.ContentType,
+		Content:     artifact.Content,
+	}.Response(export.RangeRequest{}), nil
+}
+
+func (h *PrintoutHandlers) RequestPrintout(ctx context.Context, input *RequestPrintoutRequest) (*PrintoutAcceptedEnvelope, error) {
+	weekOf, err := weekOf(ctx, input.Body.WeekOf)
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := request_printout_use_case.NewRequestPrintoutCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Kind,
+		input.Body.ClassID,
+		weekOf,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	printout, err := h.requestPrintoutUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &PrintoutAcceptedEnvelope{Location: utils.OperationLocation(printout.ID), Body: NewPrintoutResponse(printout)}, nil
+}
+
+func (h *PrintoutHandlers) GetPrintout(ctx context.Context, input *GetPrintoutRequest) (*PrintoutEnvelope, error) {
+	command, err := get_printout_use_case.NewGetPrintoutCommand(authorization.TenantIDFromContext(ctx), input.PrintoutID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	printout, err := h.getPrintoutUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &PrintoutEnvelope{Body: NewPrintoutResponse(printout)}, nil
+}
+
+func (h *PrintoutHandlers) DownloadPrintout(ctx context.Context, input *DownloadPrintoutRequest) (*huma.StreamResponse, error) {
+	command, err := get_printout_use_case.NewGetPrintoutCommand(authorization.TenantIDFromContext(ctx), input.PrintoutID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	artifact, err := h.getPrintoutUseCase.Download(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return export.FileDownload{
+		Filename:    artifact.FileName,
+		ContentType: artifact.ContentType,
+		Content:     artifact.Content,
+	}.Response(input.RangeRequest), nil
+}
+
+// weekOf parses the day of the week to print, today in the zone of the school when it is empty
+func weekOf(ctx context.Context, value string) (time.Time, error) {
+	if value == "" {
+		return time.Now().In(utils.TenantLocationFromContext(ctx)), nil
+	}
+
+	day, err := time.Parse(entities.DateLayout, value)
+	if err != nil {
+		return time.Time{}, huma.Error400BadRequest("week_of must be a date like 2025-09-15")
+	}
+	return day, nil
+}
@@ -0,0 +1,53 @@
+-- name: CreatePrintout :exec
+INSERT INTO printouts (id, tenant_id, kind, class_id, week_of, status, requested_by, created_at, updated_at)
+VALUES (@id, @tenant_id, @kind, @class_id, @week_of, @status, @requested_by, @created_at, @updated_at);
+
+-- name: GetPrintout :one
+SELECT id, tenant_id, kind, class_id, week_of, status, failure_reason, requested_by, expires_at, created_at, updated_at
+FROM printouts
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: GetPrintoutArtifact :one
+SELECT artifact_name, artifact_content_type, artifact
+FROM printouts
+WHERE id = @id AND tenant_id = @tenant_id AND status = 'ready';
+
+-- name: ClaimNextPendingPrintout :one
+UPDATE printouts
+SET status = 'running', updated_at = NOW()
+WHERE id = (
+    SELECT p.id
+    FROM printouts p
+    WHERE p.status = 'pending' OR (p.status = 'running' AND p.updated_at < @stale_before)
+    ORDER BY p.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, tenant_id, kind, class_id, week_of, status, failure_reason, requested_by, expires_at, created_at, updated_at;
+
+-- name: MarkPrintoutReady :exec
+UPDATE printouts
+SET status = 'ready',
+    artifact_name = @artifact_name,
+    artifact_content_type = @artifact_content_type,
+    artifact = @artifact,
+    expires_at = @expires_at,
+    updated_at = NOW()
+WHERE id = @id;
+
+-- name: MarkPrintoutFailed :exec
+UPDATE printouts
+SET status = 'failed', failure_reason = @failure_reason, updated_at = NOW()
+WHERE id = @id;
+
+-- name: DeleteExpiredPrintouts :execrows
+DELETE FROM printouts
+WHERE expires_at < @now;
+
+-- Enrolled students by name, students without a user are listed by ID last
+-- name: ListRosterForPrintout :many
+SELECT e.user_id, COALESCE(u.name, '')::text AS name, COALESCE(u.email, '')::text AS email
+FROM class_enrollments e
+LEFT JOIN users u ON u.id::text = e.user_id
+WHERE e.tenant_id = @tenant_id AND e.class_id = @class_id
+ORDER BY u.name NULLS LAST, e.user_id;
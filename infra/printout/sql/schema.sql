@@ -0,0 +1,21 @@
+-- Printouts of classes requested for asynchronous generation and their generated files. Small
+-- printouts are rendered on download and never stored.
+CREATE TABLE printouts (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(30) NOT NULL,             -- roster, weekly_schedule, attendance_sheet
+    class_id UUID NOT NULL,
+    week_of DATE NOT NULL,                 -- monday of the week printed, rosters ignore it
+    status VARCHAR(20) NOT NULL,           -- pending, running, ready, failed
+    failure_reason TEXT,
+    requested_by VARCHAR(255) NOT NULL,
+    artifact_name VARCHAR(255),
+    artifact_content_type VARCHAR(100),
+    artifact BYTEA,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_printouts_status ON printouts(status, created_at);
+CREATE INDEX idx_printouts_expires_at ON printouts(expires_at);
@@ -0,0 +1,40 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	render_printout_use_case "github.com/nahualventure/class-backend/core/app/printout/application/use-cases/render-printout-use-case"
+)
+
+// RunPrintoutWorker drains the printout queue every interval and purges expired files, it returns
+// when ctx is cancelled. Several instances can run concurrently, printouts are claimed with SKIP LOCKED.
+func RunPrintoutWorker(ctx context.Context, useCase *render_printout_use_case.RenderPrintoutUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			processed, err := useCase.GenerateNext(ctx)
+			if err != nil {
+				log.Printf("Printout generation failed: %v", err)
+			}
+			if !processed {
+				break
+			}
+		}
+
+		if deleted, err := useCase.PurgeExpired(ctx); err != nil {
+			log.Printf("Failed to purge expired printouts: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Purged %d expired printouts", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
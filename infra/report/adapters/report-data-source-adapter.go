@@ -0,0 +1,89 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/report/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresReportDataSource reads class figures from the class summary projection so report
+// generation never scans the OLTP tables
+type PostgresReportDataSource struct {
+	queries *db.Queries
+}
+
+func NewPostgresReportDataSource(dbInstance *pgxpool.Pool) ports.ReportDataSource {
+	return &PostgresReportDataSource{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (d *PostgresReportDataSource) ClassAttendance(ctx context.Context, tenantID string) ([]entities.ClassAttendanceRow, error) {
+	rows, err := d.queries.ListClassAttendanceForReport(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	result := make([]entities.ClassAttendanceRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, entities.ClassAttendanceRow{
+			ClassID:           row.ClassID.String(),
+			RosterCount:       int(row.RosterCount),
+			AttendancePresent: int(row.AttendancePresent),
+			AttendanceTotal:   int(row.AttendanceTotal),
+		})
+	}
+
+	return result, nil
+}
+
+func (d *PostgresReportDataSource) ClassGrades(ctx context.Context, tenantID string) ([]entities.ClassGradeRow, error) {
+	rows, err := d.queries.ListClassGradesForReport(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	result := make([]entities.ClassGradeRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, entities.ClassGradeRow{
+			ClassID:    row.ClassID.String(),
+			GradeSum:   row.GradeSum,
+			GradeCount: int(row.GradeCount),
+		})
+	}
+
+	return result, nil
+}
+
+func (d *PostgresReportDataSource) InactiveUsers(ctx context.Context, tenantID string, inactiveSince time.Time) ([]entities.InactiveUserRow, error) {
+	rows, err := d.queries.ListInactiveUsersForReport(ctx, db.ListInactiveUsersForReportParams{
+		TenantID:      tenantID,
+		InactiveSince: pgtype.Timestamptz{Time: inactiveSince, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	result := make([]entities.InactiveUserRow, 0, len(rows))
+	for _, row := range rows {
+		user := entities.InactiveUserRow{
+			ID:    row.ID.String(),
+			Name:  row.Name,
+			Email: row.Email,
+		}
+		if row.LastActivityAt.Valid {
+			user.LastActivityAt = &row.LastActivityAt.Time
+		}
+		result = append(result, user)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,226 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/report/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresReportRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresReportRepository(dbInstance *pgxpool.Pool) ports.ReportRepository {
+	return &PostgresReportRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresReportRepository) Create(ctx context.Context, report *entities.Report) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(report.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	parameters, err := json.Marshal(report.Parameters)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize report parameters", err)
+	}
+
+	err = r.queries.CreateReport(ctx, db.CreateReportParams{
+		ID:          pgUUID,
+		TenantID:    report.TenantID,
+		Definition:  report.Definition,
+		Parameters:  parameters,
+		Status:      string(report.Status),
+		RequestedBy: report.RequestedBy,
+		CreatedAt:   pgtype.Timestamptz{Time: report.CreatedAt, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: report.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresReportRepository) FindByID(ctx context.Context, tenantID string, reportID string) (*entities.Report, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(reportID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetReport(ctx, db.GetReportParams{ID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toReport(db.ClaimNextPendingReportRow(row))
+}
+
+func (r *PostgresReportRepository) FindArtifact(ctx context.Context, tenantID string, reportID string) (*entities.Artifact, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(reportID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetReportArtifact(ctx, db.GetReportArtifactParams{ID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &entities.Artifact{
+		FileName:    valueOrEmpty(row.ArtifactName),
+		ContentType: valueOrEmpty(row.ArtifactContentType),
+		Content:     row.Artifact,
+	}, nil
+}
+
+func (r *PostgresReportRepository) List(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.Report], error) {
+	afterCreatedAt, afterID, err := database.TimeIDKeyset(page.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.queries.ListReports(ctx, db.ListReportsParams{
+		TenantID:       tenantID,
+		PageLimit:      int32(page.PageSize + 1),
+		AfterCreatedAt: afterCreatedAt,
+		AfterID:        afterID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	reports := make([]*entities.Report, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		report, err := toReport(db.ClaimNextPendingReportRow{
+			ID:            row.ID,
+			TenantID:      row.TenantID,
+			Definition:    row.Definition,
+			Parameters:    row.Parameters,
+			Status:        row.Status,
+			FailureReason: row.FailureReason,
+			RequestedBy:   row.RequestedBy,
+			ArtifactName:  row.ArtifactName,
+			ExpiresAt:     row.ExpiresAt,
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return pagination.NewPage(page, reports, total, func(report *entities.Report) []string {
+		return []string{pagination.TimeKey(report.CreatedAt), report.ID}
+	}), nil
+}
+
+func (r *PostgresReportRepository) ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.Report, error) {
+	row, err := r.queries.ClaimNextPendingReport(ctx, pgtype.Timestamptz{Time: time.Now().Add(-staleAfter), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toReport(row)
+}
+
+func (r *PostgresReportRepository) MarkReady(ctx context.Context, reportID string, artifact *entities.Artifact, expiresAt time.Time) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(reportID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.MarkReportReady(ctx, db.MarkReportReadyParams{
+		ID:                  pgUUID,
+		ArtifactName:        &artifact.FileName,
+		ArtifactContentType: &artifact.ContentType,
+		Artifact:            artifact.Content,
+		ExpiresAt:           pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresReportRepository) MarkFailed(ctx context.Context, reportID string, reason string) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(reportID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := r.queries.MarkReportFailed(ctx, db.MarkReportFailedParams{ID: pgUUID, FailureReason: &reason}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresReportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	deleted, err := r.queries.DeleteExpiredReports(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	return deleted, nil
+}
+
+// toReport maps any of the report queries, they all select the same columns
+func toReport(row db.ClaimNextPendingReportRow) (*entities.Report, error) {
+	parameters := make(map[string]string)
+	if err := json.Unmarshal(row.Parameters, &parameters); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to deserialize report parameters", err)
+	}
+
+	var expiresAt *time.Time
+	if row.ExpiresAt.Valid {
+		expiresAt = &row.ExpiresAt.Time
+	}
+
+	return entities.NewReport(
+		row.ID.String(),
+		row.TenantID,
+		row.Definition,
+		parameters,
+		entities.ReportStatus(row.Status),
+		valueOrEmpty(row.FailureReason),
+		row.RequestedBy,
+		valueOrEmpty(row.ArtifactName),
+		expiresAt,
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+	)
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type ReportResponse struct {
+	ID            string            `json:"id"`
+	Definition    string            `json:"definition"`
+	Parameters    map[string]string `json:"parameters"`
+	Status        string            `json:"status" enum:"pending,running,ready,failed"`
+	FailureReason string            `json:"failure_reason,omitempty"`
+	RequestedBy   string            `json:"requested_by"`
+	ExpiresAt     *time.Time        `json:"expires_at,omitempty" doc:"When the artifact stops being downloadable, set once the report is ready"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+func NewReportResponse(report *entities.Report) ReportResponse {
+	return ReportResponse{
+		ID:            report.ID,
+		Definition:    report.Definition,
+		Parameters:    report.Parameters,
+		Status:        string(report.Status),
+		FailureReason: report.FailureReason,
+		RequestedBy:   report.RequestedBy,
+		ExpiresAt:     report.ExpiresAt,
+		CreatedAt:     report.CreatedAt,
+		UpdatedAt:     report.UpdatedAt,
+	}
+}
+
+type ReportParameterResponse struct {
+	Name        string `json:"name"`
+	Type        string `json:"type" enum:"int,date"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description"`
+}
+
+type ReportDefinitionResponse struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Parameters  []ReportParameterResponse `json:"parameters"`
+}
+
+type ListReportDefinitionsResponse struct {
+	Body struct {
+		Items []ReportDefinitionResponse `json:"items"`
+	}
+}
+
+type RequestReportRequest struct {
+	Body struct {
+		Definition string            `json:"definition" doc:"One of the names returned by the report definitions endpoint"`
+		Parameters map[string]string `json:"parameters,omitempty"`
+	}
+}
+
+type ReportEnvelope struct {
+	Body ReportResponse
+}
+
+type ReportAcceptedEnvelope struct {
+	Location string `header:"Location" doc:"Operation to poll until the report is generated"`
+	Body     ReportResponse
+}
+
+type ListReportsRequest struct {
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+}
+
+type ListReportsResponse struct {
+	Body utils.ListResponseBody[ReportResponse]
+}
+
+func NewListReportsResponse(page *pagination.Page[*entities.Report]) *ListReportsResponse {
+	return &ListReportsResponse{Body: utils.NewListResponseBody(page, NewReportResponse)}
+}
+
+type GetReportRequest struct {
+	ReportID string `path:"reportId" format:"uuid" doc:"Report ID"`
+}
+
+type DownloadReportRequest struct {
+	ReportID string `path:"reportId" format:"uuid" doc:"Report ID"`
+	export.RangeRequest
+}
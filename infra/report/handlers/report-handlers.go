@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	get_report_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/get-report-use-case"
+	list_reports_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/list-reports-use-case"
+	request_report_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/request-report-use-case"
+	"github.com/nahualventure/class-backend/core/app/report/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ReportHandlers struct {
+	requestReportUseCase *request_report_use_case.RequestReportUseCase
+	listReportsUseCase   *list_reports_use_case.ListReportsUseCase
+	getReportUseCase     *get_report_use_case.GetReportUseCase
+}
+
+func NewReportHandlers(
+	requestReportUseCase *request_report_use_case.RequestReportUseCase,
+	listReportsUseCase *list_reports_use_case.ListReportsUseCase,
+	getReportUseCase *get_report_use_case.GetReportUseCase,
+) *ReportHandlers {
+	return &ReportHandlers{
+		requestReportUseCase: requestReportUseCase,
+		listReportsUseCase:   listReportsUseCase,
+		getReportUseCase:     getReportUseCase,
+	}
+}
+
+func (h *ReportHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-report-definitions",
+		Method:      http.MethodGet,
+		Path:        "/reports/definitions",
+		Summary:     "List available report definitions",
+		Tags:        []string{"Reports"},
+	}, h.ListReportDefinitions)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "request-report",
+		Method:        http.MethodPost,
+		Path:          "/reports",
+		Summary:       "Request a report",
+		Description:   "Queues the report for asynchronous generation. Poll the operation in the Location header until it is done, then download the report.",
+		Tags:          []string{"Reports"},
+		DefaultStatus: http.StatusAccepted,
+	}, h.RequestReport)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-reports",
+		Method:      http.MethodGet,
+		Path:        "/reports",
+		Summary:     "List reports of the tenant",
+		Tags:        []string{"Reports"},
+	}, h.ListReports)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-report",
+		Method:      http.MethodGet,
+		Path:        "/reports/{reportId}",
+		Summary:     "Get report status",
+		Tags:        []string{"Reports"},
+	}, h.GetReport)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "download-report",
+		Method:      http.MethodGet,
+		Path:        "/reports/{reportId}/download",
+		Summary:     "Download a generated report",
+		Tags:        []string{"Reports"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Generated report file",
+				Content:     map[string]*huma.MediaType{"text/csv": {}},
+			},
+		},
+	}, h.DownloadReport)
+}
+
+func (h *ReportHandlers) ListReportDefinitions(ctx context.Context, input *struct{}) (*ListReportDefinitionsResponse, error) {
+	response := &ListReportDefinitionsResponse{}
+	for _, definition := range entities.Definitions {
+		parameters := make([]ReportParameterResponse, 0, len(definition.Parameters))
+		for _, spec := range definition.Parameters {
+			parameters = append(parameters, ReportParameterResponse{
+				Name:        spec.Name,
+				Type:        string(spec.Type),
+				Default:     spec.Default,
+				Description: spec.Description,
+			})
+		}
+		response.Body.Items = append(response.Body.Items, ReportDefinitionResponse{
+			Name:        definition.Name,
+			Description: definition.Description,
+			Parameters:  parameters,
+		})
+	}
+
+	slices.SortFunc(response.Body.Items, func(a, b ReportDefinitionResponse) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return response, nil
+}
+
+func (h *ReportHandlers) RequestReport(ctx context.Context, input *RequestReportRequest) (*ReportAcceptedEnvelope, error) {
+	command, err := request_report_use_case.NewRequestReportCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Definition,
+		input.Body.Parameters,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	report, err := h.requestReportUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ReportAcceptedEnvelope{Location: utils.OperationLocation(report.ID), Body: NewReportResponse(report)}, nil
+}
+
+func (h *ReportHandlers) ListReports(ctx context.Context, input *ListReportsRequest) (*ListReportsResponse, error) {
+	command, err := list_reports_use_case.NewListReportsCommand(authorization.TenantIDFromContext(ctx), input.PageSize, input.Cursor)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listReportsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListReportsResponse(page), nil
+}
+
+func (h *ReportHandlers) GetReport(ctx context.Context, input *GetReportRequest) (*ReportEnvelope, error) {
+	command, err := get_report_use_case.NewGetReportCommand(authorization.TenantIDFromContext(ctx), input.ReportID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	report, err := h.getReportUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ReportEnvelope{Body: NewReportResponse(report)}, nil
+}
+
+func (h *ReportHandlers) DownloadReport(ctx context.Context, input *DownloadReportRequest) (*huma.StreamResponse, error) {
+	command, err := get_report_use_case.NewGetReportCommand(authorization.TenantIDFromContext(ctx), input.ReportID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	artifact, err := h.getReportUseCase.Download(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return export.FileDownload{
+		Filename:    artifact.FileName,
+		ContentType: artifact.ContentType,
+		Content:     artifact.Content,
+	}.Response(input.RangeRequest), nil
+}
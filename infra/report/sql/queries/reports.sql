@@ -0,0 +1,82 @@
+-- name: CreateReport :exec
+INSERT INTO reports (id, tenant_id, definition, parameters, status, requested_by, created_at, updated_at)
+VALUES (@id, @tenant_id, @definition, @parameters, @status, @requested_by, @created_at, @updated_at);
+
+-- name: GetReport :one
+SELECT id, tenant_id, definition, parameters, status, failure_reason, requested_by, artifact_name, expires_at, created_at, updated_at
+FROM reports
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: GetReportArtifact :one
+SELECT artifact_name, artifact_content_type, artifact
+FROM reports
+WHERE id = @id AND tenant_id = @tenant_id AND status = 'ready';
+
+-- Keyset page of the reports newest first, the total counts every report of the tenant
+-- name: ListReports :many
+SELECT id, tenant_id, definition, parameters, status, failure_reason, requested_by, artifact_name, expires_at, created_at, updated_at,
+       (SELECT count(*) FROM reports t WHERE t.tenant_id = @tenant_id) AS total
+FROM reports
+WHERE tenant_id = @tenant_id
+  AND (sqlc.narg('after_created_at')::timestamptz IS NULL
+       OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT @page_limit;
+
+-- name: ClaimNextPendingReport :one
+UPDATE reports
+SET status = 'running', updated_at = NOW()
+WHERE id = (
+    SELECT r.id
+    FROM reports r
+    WHERE r.status = 'pending' OR (r.status = 'running' AND r.updated_at < @stale_before)
+    ORDER BY r.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, tenant_id, definition, parameters, status, failure_reason, requested_by, artifact_name, expires_at, created_at, updated_at;
+
+-- name: MarkReportReady :exec
+UPDATE reports
+SET status = 'ready',
+    artifact_name = @artifact_name,
+    artifact_content_type = @artifact_content_type,
+    artifact = @artifact,
+    expires_at = @expires_at,
+    updated_at = NOW()
+WHERE id = @id;
+
+-- name: MarkReportFailed :exec
+UPDATE reports
+SET status = 'failed', failure_reason = @failure_reason, updated_at = NOW()
+WHERE id = @id;
+
+-- name: DeleteExpiredReports :execrows
+DELETE FROM reports
+WHERE expires_at < @now;
+
+-- name: ListClassAttendanceForReport :many
+SELECT class_id, roster_count, attendance_present, attendance_total
+FROM class_summaries
+WHERE tenant_id = @tenant_id
+ORDER BY class_id;
+
+-- name: ListClassGradesForReport :many
+SELECT class_id, grade_sum, grade_count
+FROM class_summaries
+WHERE tenant_id = @tenant_id AND grade_count > 0;
+
+-- Users belong to a tenant through their role assignments, their last activity is the latest hour
+-- they made a request in the tenant, NULL when the kept API usage has none
+-- name: ListInactiveUsersForReport :many
+SELECT u.id, u.name, u.email, max(a.hour)::timestamptz AS last_activity_at
+FROM users u
+LEFT JOIN api_consumer_usage a
+    ON a.tenant_id = @tenant_id::text AND a.consumer_kind = 'user' AND a.consumer_id = u.id::text
+WHERE EXISTS (
+        SELECT 1 FROM casbin_rule c
+        WHERE c.ptype = 'g' AND c.v0 = u.id::text AND c.v2 = @tenant_id::text
+    )
+GROUP BY u.id, u.name, u.email
+HAVING max(a.hour) IS NULL OR max(a.hour) < @inactive_since::timestamptz
+ORDER BY last_activity_at NULLS FIRST, u.id;
@@ -0,0 +1,20 @@
+-- Async report requests and their generated artifacts
+CREATE TABLE reports (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    definition VARCHAR(100) NOT NULL,
+    parameters JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL,           -- pending, running, ready, failed
+    failure_reason TEXT,
+    requested_by VARCHAR(255) NOT NULL,
+    artifact_name VARCHAR(255),
+    artifact_content_type VARCHAR(100),
+    artifact BYTEA,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_reports_tenant_id_created_at ON reports(tenant_id, created_at);
+CREATE INDEX idx_reports_status ON reports(status);
+CREATE INDEX idx_reports_expires_at ON reports(expires_at);
@@ -0,0 +1,40 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	generate_report_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/generate-report-use-case"
+)
+
+// RunReportWorker drains the report queue every interval and purges expired artifacts, it returns
+// when ctx is cancelled. Several instances can run concurrently, reports are claimed with SKIP LOCKED.
+func RunReportWorker(ctx context.Context, useCase *generate_report_use_case.GenerateReportUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			processed, err := useCase.Execute(ctx)
+			if err != nil {
+				log.Printf("Report generation failed: %v", err)
+			}
+			if !processed {
+				break
+			}
+		}
+
+		if deleted, err := useCase.PurgeExpired(ctx); err != nil {
+			log.Printf("Failed to purge expired reports: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Purged %d expired reports", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
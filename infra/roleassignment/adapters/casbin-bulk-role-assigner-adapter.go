@@ -0,0 +1,33 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// CasbinBulkRoleAssigner writes the role assignments of a group as Casbin grouping policies, one per
+// member, like the roles assigned to users one at a time
+type CasbinBulkRoleAssigner struct {
+	casbinService *authorization.CasbinService
+}
+
+func NewCasbinBulkRoleAssigner(casbinService *authorization.CasbinService) ports.BulkRoleAssigner {
+	return &CasbinBulkRoleAssigner{
+		casbinService: casbinService,
+	}
+}
+
+func (a *CasbinBulkRoleAssigner) AvailableRoles() []string {
+	return a.casbinService.GetAvailableRoles()
+}
+
+func (a *CasbinBulkRoleAssigner) AssignRole(ctx context.Context, subjects []string, role string, tenantID string,
+	progress func(processed int, assigned int)) (int, error) {
+	assigned, authzErr := a.casbinService.AssignRoleToSubjects(ctx, subjects, role, tenantID, progress)
+	if authzErr != nil {
+		return 0, authzErr
+	}
+	return assigned, nil
+}
@@ -0,0 +1,69 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// CasbinTenantRoles reads the role assignments of a tenant from the enforcer and changes them in the
+// casbin_rule table, one statement per change
+type CasbinTenantRoles struct {
+	casbinService *authorization.CasbinService
+}
+
+func NewCasbinTenantRoles(casbinService *authorization.CasbinService) ports.TenantRoles {
+	return &CasbinTenantRoles{
+		casbinService: casbinService,
+	}
+}
+
+func (r *CasbinTenantRoles) Holders(ctx context.Context, role string, tenantID string) ([]string, error) {
+	holders, authzErr := r.casbinService.GetRoleUsers(role, tenantID)
+	if authzErr != nil {
+		return nil, authzErr
+	}
+	return holders, nil
+}
+
+func (r *CasbinTenantRoles) Of(ctx context.Context, subjects []string, tenantID string) ([]entities.TenantRole, error) {
+	var assignments []entities.TenantRole
+	for _, subject := range subjects {
+		roles, authzErr := r.casbinService.GetUserRoles(subject, tenantID)
+		if authzErr != nil {
+			return nil, authzErr
+		}
+		for _, role := range roles {
+			assignments = append(assignments, entities.TenantRole{Subject: subject, Role: role})
+		}
+	}
+	return assignments, nil
+}
+
+func (r *CasbinTenantRoles) Revoke(ctx context.Context, assignments []entities.TenantRole, tenantID string) error {
+	subjects, roles := pairs(assignments)
+	if _, authzErr := r.casbinService.RevokeSubjectRoles(ctx, subjects, roles, tenantID); authzErr != nil {
+		return authzErr
+	}
+	return nil
+}
+
+func (r *CasbinTenantRoles) Restore(ctx context.Context, assignments []entities.TenantRole, tenantID string) error {
+	subjects, roles := pairs(assignments)
+	if _, authzErr := r.casbinService.RestoreSubjectRoles(ctx, subjects, roles, tenantID); authzErr != nil {
+		return authzErr
+	}
+	return nil
+}
+
+func pairs(assignments []entities.TenantRole) ([]string, []string) {
+	subjects := make([]string, len(assignments))
+	roles := make([]string, len(assignments))
+	for i, assignment := range assignments {
+		subjects[i] = assignment.Subject
+		roles[i] = assignment.Role
+	}
+	return subjects, roles
+}
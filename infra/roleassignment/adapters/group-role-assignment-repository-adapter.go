@@ -0,0 +1,239 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresGroupRoleAssignmentRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresGroupRoleAssignmentRepository(dbInstance *pgxpool.Pool) ports.GroupRoleAssignmentRepository {
+	return &PostgresGroupRoleAssignmentRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresGroupRoleAssignmentRepository) Create(ctx context.Context, assignment *entities.GroupRoleAssignment) error {
+	var pgUUID, groupUUID pgtype.UUID
+	if err := pgUUID.Scan(assignment.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := groupUUID.Scan(assignment.GroupID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.CreateGroupRoleAssignment(ctx, db.CreateGroupRoleAssignmentParams{
+		ID:          pgUUID,
+		TenantID:    assignment.TenantID,
+		GroupType:   assignment.GroupType,
+		GroupID:     groupUUID,
+		Role:        assignment.Role,
+		Status:      string(assignment.Status),
+		RequestedBy: assignment.RequestedBy,
+		CreatedAt:   pgtype.Timestamptz{Time: assignment.CreatedAt, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: assignment.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresGroupRoleAssignmentRepository) FindByID(ctx context.Context, tenantID string, assignmentID string) (*entities.GroupRoleAssignment, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(assignmentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetGroupRoleAssignment(ctx, db.GetGroupRoleAssignmentParams{TenantID: tenantID, ID: pgUUID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toGroupRoleAssignment(row)
+}
+
+func (r *PostgresGroupRoleAssignmentRepository) List(ctx context.Context, tenantID string, limit int) ([]*entities.GroupRoleAssignment, error) {
+	rows, err := r.queries.ListGroupRoleAssignments(ctx, db.ListGroupRoleAssignmentsParams{TenantID: tenantID, RowLimit: int32(limit)})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	assignments := make([]*entities.GroupRoleAssignment, 0, len(rows))
+	for _, row := range rows {
+		assignment, err := toGroupRoleAssignment(row)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, nil
+}
+
+func (r *PostgresGroupRoleAssignmentRepository) ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.GroupRoleAssignment, error) {
+	row, err := r.queries.ClaimNextPendingGroupRoleAssignment(ctx, pgtype.Timestamptz{Time: time.Now().Add(-staleAfter), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toGroupRoleAssignment(row)
+}
+
+func (r *PostgresGroupRoleAssignmentRepository) SaveProgress(ctx context.Context, assignment *entities.GroupRoleAssignment) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(assignment.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.SaveGroupRoleAssignmentProgress(ctx, db.SaveGroupRoleAssignmentProgressParams{
+		ID:        pgUUID,
+		Members:   int32(assignment.Members),
+		Processed: int32(assignment.Processed),
+		Assigned:  int32(assignment.Assigned),
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresGroupRoleAssignmentRepository) MarkCompleted(ctx context.Context, assignment *entities.GroupRoleAssignment) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(assignment.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"assignment_id": assignment.ID,
+		"group_type":    assignment.GroupType,
+		"group_id":      assignment.GroupID,
+		"role":          assignment.Role,
+		"members":       assignment.Members,
+		"assigned":      assignment.Assigned,
+		"actor_id":      assignment.RequestedBy,
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize group role assignment event", err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.MarkGroupRoleAssignmentCompleted(ctx, db.MarkGroupRoleAssignmentCompletedParams{
+		ID:        pgUUID,
+		Members:   int32(assignment.Members),
+		Processed: int32(assignment.Processed),
+		Assigned:  int32(assignment.Assigned),
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.RoleAssignedToGroup,
+		AggregateID: assignment.GroupID,
+		TenantID:    assignment.TenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresGroupRoleAssignmentRepository) MarkFailed(ctx context.Context, assignmentID string, reason string) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(assignmentID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := r.queries.MarkGroupRoleAssignmentFailed(ctx, db.MarkGroupRoleAssignmentFailedParams{ID: pgUUID, FailureReason: &reason}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func toGroupRoleAssignment(row db.GroupRoleAssignment) (*entities.GroupRoleAssignment, error) {
+	assignment, err := entities.NewGroupRoleAssignment(row.ID.String(), row.TenantID, row.GroupType, row.GroupID.String(),
+		row.Role, row.RequestedBy, row.CreatedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	assignment.Status = entities.GroupRoleAssignmentStatus(row.Status)
+	assignment.UpdatedAt = row.UpdatedAt.Time
+	assignment.Members = int(row.Members)
+	assignment.Processed = int(row.Processed)
+	assignment.Assigned = int(row.Assigned)
+	if row.FailureReason != nil {
+		assignment.FailureReason = *row.FailureReason
+	}
+	if row.CompletedAt.Valid {
+		completedAt := row.CompletedAt.Time
+		assignment.CompletedAt = &completedAt
+	}
+	return assignment, nil
+}
+
+// PostgresGroupDirectory reads the members of groups from the tables of the modules that own them
+type PostgresGroupDirectory struct {
+	queries *db.Queries
+}
+
+func NewPostgresGroupDirectory(dbInstance *pgxpool.Pool) ports.GroupDirectory {
+	return &PostgresGroupDirectory{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+// Members returns the users enrolled in a class
+func (d *PostgresGroupDirectory) Members(ctx context.Context, tenantID string, groupType string, groupID string) ([]string, error) {
+	if groupType != entities.GroupTypeClass {
+		return nil, appErrors.NewInfrastructureError("unknown group type "+groupType, nil)
+	}
+
+	var classUUID pgtype.UUID
+	if err := classUUID.Scan(groupID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	members, err := d.queries.ListClassMemberIDs(ctx, db.ListClassMemberIDsParams{TenantID: tenantID, ClassID: classUUID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return members, nil
+}
@@ -0,0 +1,227 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresMemberDeactivationRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresMemberDeactivationRepository(dbInstance *pgxpool.Pool) ports.MemberDeactivationRepository {
+	return &PostgresMemberDeactivationRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresMemberDeactivationRepository) Create(ctx context.Context, deactivation *entities.MemberDeactivation) error {
+	var pgUUID, classUUID pgtype.UUID
+	if err := pgUUID.Scan(deactivation.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if deactivation.ClassID != "" {
+		if err := classUUID.Scan(deactivation.ClassID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+	subjects, err := json.Marshal(deactivation.Subjects)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize member deactivation subjects", err)
+	}
+	roles, err := json.Marshal(deactivation.Roles)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize member deactivation roles", err)
+	}
+
+	err = r.queries.CreateMemberDeactivation(ctx, db.CreateMemberDeactivationParams{
+		ID:             pgUUID,
+		TenantID:       deactivation.TenantID,
+		Role:           deactivation.Role,
+		ClassID:        classUUID,
+		Status:         string(deactivation.Status),
+		RequestedBy:    deactivation.RequestedBy,
+		Subjects:       subjects,
+		Roles:          roles,
+		TokenHash:      deactivation.TokenHash,
+		TokenExpiresAt: pgtype.Timestamptz{Time: deactivation.TokenExpiresAt, Valid: true},
+		CreatedAt:      pgtype.Timestamptz{Time: deactivation.CreatedAt, Valid: true},
+		UpdatedAt:      pgtype.Timestamptz{Time: deactivation.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresMemberDeactivationRepository) FindByID(ctx context.Context, tenantID string, deactivationID string) (*entities.MemberDeactivation, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(deactivationID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetMemberDeactivation(ctx, db.GetMemberDeactivationParams{TenantID: tenantID, ID: pgUUID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toMemberDeactivation(row)
+}
+
+func (r *PostgresMemberDeactivationRepository) Confirm(ctx context.Context, deactivation *entities.MemberDeactivation) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(deactivation.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	confirmed, err := r.queries.ConfirmMemberDeactivation(ctx, db.ConfirmMemberDeactivationParams{
+		ID:          pgUUID,
+		ConfirmedBy: &deactivation.ConfirmedBy,
+		ConfirmedAt: pgtype.Timestamptz{Time: *deactivation.ConfirmedAt, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return confirmed > 0, nil
+}
+
+func (r *PostgresMemberDeactivationRepository) MarkRolledBack(ctx context.Context, deactivation *entities.MemberDeactivation) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(deactivation.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	rolledBack, err := r.queries.MarkMemberDeactivationRolledBack(ctx, db.MarkMemberDeactivationRolledBackParams{
+		ID:           pgUUID,
+		RolledBackBy: &deactivation.RolledBackBy,
+		RolledBackAt: pgtype.Timestamptz{Time: *deactivation.RolledBackAt, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return rolledBack > 0, nil
+}
+
+func (r *PostgresMemberDeactivationRepository) ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.MemberDeactivation, error) {
+	row, err := r.queries.ClaimNextPendingMemberDeactivation(ctx, pgtype.Timestamptz{Time: time.Now().Add(-staleAfter), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toMemberDeactivation(row)
+}
+
+func (r *PostgresMemberDeactivationRepository) SaveRevoked(ctx context.Context, deactivation *entities.MemberDeactivation) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(deactivation.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	revoked, err := json.Marshal(deactivation.Revoked)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize member deactivation revoked roles", err)
+	}
+
+	if err := r.queries.SaveMemberDeactivationRevoked(ctx, db.SaveMemberDeactivationRevokedParams{ID: pgUUID, Revoked: revoked}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresMemberDeactivationRepository) MarkCompleted(ctx context.Context, deactivationID string) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(deactivationID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := r.queries.MarkMemberDeactivationCompleted(ctx, pgUUID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresMemberDeactivationRepository) MarkFailed(ctx context.Context, deactivationID string, reason string) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(deactivationID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := r.queries.MarkMemberDeactivationFailed(ctx, db.MarkMemberDeactivationFailedParams{ID: pgUUID, FailureReason: &reason}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func toMemberDeactivation(row db.MemberDeactivation) (*entities.MemberDeactivation, error) {
+	deactivation := &entities.MemberDeactivation{
+		ID:             row.ID.String(),
+		TenantID:       row.TenantID,
+		Role:           row.Role,
+		Status:         entities.MemberDeactivationStatus(row.Status),
+		RequestedBy:    row.RequestedBy,
+		TokenHash:      row.TokenHash,
+		TokenExpiresAt: row.TokenExpiresAt.Time,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}
+	if row.ClassID.Valid {
+		deactivation.ClassID = row.ClassID.String()
+	}
+	if err := json.Unmarshal(row.Subjects, &deactivation.Subjects); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to read member deactivation subjects", err)
+	}
+	if err := json.Unmarshal(row.Roles, &deactivation.Roles); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to read member deactivation roles", err)
+	}
+	if row.Revoked != nil {
+		deactivation.Revoked = []entities.TenantRole{}
+		if err := json.Unmarshal(row.Revoked, &deactivation.Revoked); err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to read member deactivation revoked roles", err)
+		}
+	}
+	if row.ConfirmedBy != nil {
+		deactivation.ConfirmedBy = *row.ConfirmedBy
+	}
+	if row.ConfirmedAt.Valid {
+		confirmedAt := row.ConfirmedAt.Time
+		deactivation.ConfirmedAt = &confirmedAt
+	}
+	if row.FailureReason != nil {
+		deactivation.FailureReason = *row.FailureReason
+	}
+	if row.CompletedAt.Valid {
+		completedAt := row.CompletedAt.Time
+		deactivation.CompletedAt = &completedAt
+	}
+	if row.RolledBackBy != nil {
+		deactivation.RolledBackBy = *row.RolledBackBy
+	}
+	if row.RolledBackAt.Valid {
+		rolledBackAt := row.RolledBackAt.Time
+		deactivation.RolledBackAt = &rolledBackAt
+	}
+	return deactivation, nil
+}
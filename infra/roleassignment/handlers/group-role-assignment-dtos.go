@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type GroupRoleAssignmentResponse struct {
+	ID            string     `json:"id"`
+	GroupType     string     `json:"group_type" enum:"class"`
+	GroupID       string     `json:"group_id"`
+	Role          string     `json:"role"`
+	Status        string     `json:"status" enum:"pending,running,completed,failed" doc:"No member has the role until the assignment is completed"`
+	RequestedBy   string     `json:"requested_by"`
+	Members       int        `json:"members" doc:"Members of the group when the assignment started"`
+	Processed     int        `json:"processed" doc:"Members written so far"`
+	Assigned      int        `json:"assigned" doc:"Members who did not have the role yet"`
+	Progress      float64    `json:"progress" doc:"Share of the members processed, from 0 to 1"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+func NewGroupRoleAssignmentResponse(assignment *entities.GroupRoleAssignment, loc *time.Location) GroupRoleAssignmentResponse {
+	return GroupRoleAssignmentResponse{
+		ID:            assignment.ID,
+		GroupType:     assignment.GroupType,
+		GroupID:       assignment.GroupID,
+		Role:          assignment.Role,
+		Status:        string(assignment.Status),
+		RequestedBy:   assignment.RequestedBy,
+		Members:       assignment.Members,
+		Processed:     assignment.Processed,
+		Assigned:      assignment.Assigned,
+		Progress:      assignment.Progress(),
+		FailureReason: assignment.FailureReason,
+		CreatedAt:     utils.InLocation(assignment.CreatedAt, loc),
+		CompletedAt:   utils.InLocationPtr(assignment.CompletedAt, loc),
+	}
+}
+
+type AssignRoleToGroupRequest struct {
+	Body struct {
+		GroupType string `json:"group_type" enum:"class" doc:"Classes give the role to the users enrolled in them"`
+		GroupID   string `json:"group_id" format:"uuid"`
+		Role      string `json:"role" minLength:"1"`
+	}
+}
+
+type ListGroupRoleAssignmentsRequest struct {
+	Limit int `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Newest assignments first"`
+}
+
+type GroupRoleAssignmentsResponse struct {
+	Body struct {
+		Assignments []GroupRoleAssignmentResponse `json:"assignments"`
+	}
+}
+
+type GetGroupRoleAssignmentRequest struct {
+	AssignmentID string `path:"assignmentId" format:"uuid"`
+}
+
+type GroupRoleAssignmentEnvelope struct {
+	Body GroupRoleAssignmentResponse
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_group_role_assignment_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/get-group-role-assignment-use-case"
+	list_group_role_assignments_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/list-group-role-assignments-use-case"
+	request_group_role_assignment_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/request-group-role-assignment-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type GroupRoleAssignmentHandlers struct {
+	requestGroupRoleAssignmentUseCase *request_group_role_assignment_use_case.RequestGroupRoleAssignmentUseCase
+	listGroupRoleAssignmentsUseCase   *list_group_role_assignments_use_case.ListGroupRoleAssignmentsUseCase
+	getGroupRoleAssignmentUseCase     *get_group_role_assignment_use_case.GetGroupRoleAssignmentUseCase
+}
+
+func NewGroupRoleAssignmentHandlers(
+	requestGroupRoleAssignmentUseCase *request_group_role_assignment_use_case.RequestGroupRoleAssignmentUseCase,
+	listGroupRoleAssignmentsUseCase *list_group_role_assignments_use_case.ListGroupRoleAssignmentsUseCase,
+	getGroupRoleAssignmentUseCase *get_group_role_assignment_use_case.GetGroupRoleAssignmentUseCase,
+) *GroupRoleAssignmentHandlers {
+	return &GroupRoleAssignmentHandlers{
+		requestGroupRoleAssignmentUseCase: requestGroupRoleAssignmentUseCase,
+		listGroupRoleAssignmentsUseCase:   listGroupRoleAssignmentsUseCase,
+		getGroupRoleAssignmentUseCase:     getGroupRoleAssignmentUseCase,
+	}
+}
+
+func (h *GroupRoleAssignmentHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "assign-role-to-group",
+		Method:        http.MethodPost,
+		Path:          "/role-assignments/groups",
+		Summary:       "Assign a role to every member of a group",
+		Description:   "Queues the assignment of the role to every member of the group. The members get the role all at once in a single transaction, poll the assignment to follow its progress until it is completed or failed.",
+		Tags:          []string{"Roles"},
+		DefaultStatus: http.StatusAccepted,
+	}, h.AssignRoleToGroup)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-group-role-assignments",
+		Method:      http.MethodGet,
+		Path:        "/role-assignments/groups",
+		Summary:     "List the roles assigned to groups of the tenant",
+		Tags:        []string{"Roles"},
+	}, h.ListGroupRoleAssignments)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-group-role-assignment",
+		Method:      http.MethodGet,
+		Path:        "/role-assignments/groups/{assignmentId}",
+		Summary:     "Get a role assignment to a group and its progress",
+		Tags:        []string{"Roles"},
+	}, h.GetGroupRoleAssignment)
+}
+
+func (h *GroupRoleAssignmentHandlers) AssignRoleToGroup(ctx context.Context, input *AssignRoleToGroupRequest) (*GroupRoleAssignmentEnvelope, error) {
+	command, err := request_group_role_assignment_use_case.NewRequestGroupRoleAssignmentCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.GroupType,
+		input.Body.GroupID,
+		input.Body.Role,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	assignment, err := h.requestGroupRoleAssignmentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &GroupRoleAssignmentEnvelope{Body: NewGroupRoleAssignmentResponse(assignment, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *GroupRoleAssignmentHandlers) ListGroupRoleAssignments(ctx context.Context, input *ListGroupRoleAssignmentsRequest) (*GroupRoleAssignmentsResponse, error) {
+	command, err := list_group_role_assignments_use_case.NewListGroupRoleAssignmentsCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Limit,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	assignments, err := h.listGroupRoleAssignmentsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &GroupRoleAssignmentsResponse{}
+	response.Body.Assignments = make([]GroupRoleAssignmentResponse, 0, len(assignments))
+	for _, assignment := range assignments {
+		response.Body.Assignments = append(response.Body.Assignments, NewGroupRoleAssignmentResponse(assignment, loc))
+	}
+	return response, nil
+}
+
+func (h *GroupRoleAssignmentHandlers) GetGroupRoleAssignment(ctx context.Context, input *GetGroupRoleAssignmentRequest) (*GroupRoleAssignmentEnvelope, error) {
+	command, err := get_group_role_assignment_use_case.NewGetGroupRoleAssignmentCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.AssignmentID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	assignment, err := h.getGroupRoleAssignmentUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &GroupRoleAssignmentEnvelope{Body: NewGroupRoleAssignmentResponse(assignment, utils.LocationFromContext(ctx))}, nil
+}
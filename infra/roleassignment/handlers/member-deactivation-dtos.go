@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/roleassignment/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+// memberSampleSize is how many of the members a response lists, the counts cover all of them
+const memberSampleSize = 20
+
+const (
+	previewNote  = "Nothing changed yet. Confirm with the token before it expires to revoke every role of these members in the tenant."
+	rollbackNote = "Rolling back gives the members every role the deactivation revoked. Roles assigned to them since are kept."
+)
+
+type MemberDeactivationResponse struct {
+	ID            string         `json:"id"`
+	Role          string         `json:"role"`
+	ClassID       string         `json:"class_id,omitempty"`
+	Status        string         `json:"status" enum:"previewed,pending,running,completed,failed,rolled_back" doc:"No role is revoked until the deactivation is completed"`
+	RequestedBy   string         `json:"requested_by"`
+	Members       int            `json:"members" doc:"Members the deactivation applies to"`
+	Roles         map[string]int `json:"roles" doc:"Role assignments of the members per role when previewed"`
+	SampleMembers []string       `json:"sample_members" doc:"Some of the members, to check the selection"`
+	Revoked       *int           `json:"revoked,omitempty" doc:"Role assignments revoked, once the worker started"`
+	Note          string         `json:"note" doc:"What confirming or rolling back the deactivation does"`
+	FailureReason string         `json:"failure_reason,omitempty"`
+	ConfirmedBy   string         `json:"confirmed_by,omitempty"`
+	ConfirmedAt   *time.Time     `json:"confirmed_at,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	CompletedAt   *time.Time     `json:"completed_at,omitempty"`
+	RolledBackBy  string         `json:"rolled_back_by,omitempty"`
+	RolledBackAt  *time.Time     `json:"rolled_back_at,omitempty"`
+}
+
+func NewMemberDeactivationResponse(deactivation *entities.MemberDeactivation, loc *time.Location) MemberDeactivationResponse {
+	response := MemberDeactivationResponse{
+		ID:            deactivation.ID,
+		Role:          deactivation.Role,
+		ClassID:       deactivation.ClassID,
+		Status:        string(deactivation.Status),
+		RequestedBy:   deactivation.RequestedBy,
+		Members:       deactivation.Members(),
+		Roles:         deactivation.Roles,
+		SampleMembers: deactivation.Subjects[:min(memberSampleSize, len(deactivation.Subjects))],
+		Note:          rollbackNote,
+		FailureReason: deactivation.FailureReason,
+		ConfirmedBy:   deactivation.ConfirmedBy,
+		ConfirmedAt:   utils.InLocationPtr(deactivation.ConfirmedAt, loc),
+		CreatedAt:     utils.InLocation(deactivation.CreatedAt, loc),
+		CompletedAt:   utils.InLocationPtr(deactivation.CompletedAt, loc),
+		RolledBackBy:  deactivation.RolledBackBy,
+		RolledBackAt:  utils.InLocationPtr(deactivation.RolledBackAt, loc),
+	}
+	if deactivation.Status == entities.MemberDeactivationStatusPreviewed {
+		response.Note = previewNote
+	}
+	if deactivation.Revoked != nil {
+		revoked := len(deactivation.Revoked)
+		response.Revoked = &revoked
+	}
+	return response
+}
+
+type PreviewMemberDeactivationRequest struct {
+	Body struct {
+		Role    string `json:"role" minLength:"1" doc:"Members holding the role are deactivated"`
+		ClassID string `json:"class_id,omitempty" format:"uuid" doc:"Only the members enrolled in the class, e.g. a graduating class"`
+	}
+}
+
+type MemberDeactivationPreviewResponse struct {
+	Body struct {
+		MemberDeactivationResponse
+		ConfirmToken     string    `json:"confirm_token" doc:"Confirms the deactivation, only returned here"`
+		ConfirmExpiresAt time.Time `json:"confirm_expires_at"`
+	}
+}
+
+type ConfirmMemberDeactivationRequest struct {
+	DeactivationID string `path:"deactivationId" format:"uuid"`
+	Body           struct {
+		ConfirmToken string `json:"confirm_token" minLength:"1"`
+	}
+}
+
+type MemberDeactivationPathRequest struct {
+	DeactivationID string `path:"deactivationId" format:"uuid"`
+}
+
+type MemberDeactivationEnvelope struct {
+	Body MemberDeactivationResponse
+}
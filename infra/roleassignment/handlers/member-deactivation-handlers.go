@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	confirm_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/confirm-member-deactivation-use-case"
+	get_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/get-member-deactivation-use-case"
+	preview_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/preview-member-deactivation-use-case"
+	roll_back_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/roll-back-member-deactivation-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type MemberDeactivationHandlers struct {
+	previewMemberDeactivationUseCase  *preview_member_deactivation_use_case.PreviewMemberDeactivationUseCase
+	confirmMemberDeactivationUseCase  *confirm_member_deactivation_use_case.ConfirmMemberDeactivationUseCase
+	getMemberDeactivationUseCase      *get_member_deactivation_use_case.GetMemberDeactivationUseCase
+	rollBackMemberDeactivationUseCase *roll_back_member_deactivation_use_case.RollBackMemberDeactivationUseCase
+}
+
+func NewMemberDeactivationHandlers(
+	previewMemberDeactivationUseCase *preview_member_deactivation_use_case.PreviewMemberDeactivationUseCase,
+	confirmMemberDeactivationUseCase *confirm_member_deactivation_use_case.ConfirmMemberDeactivationUseCase,
+	getMemberDeactivationUseCase *get_member_deactivation_use_case.GetMemberDeactivationUseCase,
+	rollBackMemberDeactivationUseCase *roll_back_member_deactivation_use_case.RollBackMemberDeactivationUseCase,
+) *MemberDeactivationHandlers {
+	return &MemberDeactivationHandlers{
+		previewMemberDeactivationUseCase:  previewMemberDeactivationUseCase,
+		confirmMemberDeactivationUseCase:  confirmMemberDeactivationUseCase,
+		getMemberDeactivationUseCase:      getMemberDeactivationUseCase,
+		rollBackMemberDeactivationUseCase: rollBackMemberDeactivationUseCase,
+	}
+}
+
+func (h *MemberDeactivationHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "preview-member-deactivation",
+		Method:        http.MethodPost,
+		Path:          "/role-assignments/deactivations",
+		Summary:       "Preview the deactivation of the members holding a role",
+		Description:   "Counts the members holding the role, only the ones enrolled in the class when it is given, and their role assignments. Nothing changes until the deactivation is confirmed with the returned token. The caller is never deactivated.",
+		Tags:          []string{"Roles"},
+		DefaultStatus: http.StatusCreated,
+	}, h.PreviewMemberDeactivation)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "confirm-member-deactivation",
+		Method:        http.MethodPost,
+		Path:          "/role-assignments/deactivations/{deactivationId}/confirm",
+		Summary:       "Confirm a previewed deactivation of members",
+		Description:   "Queues the revocation of every role of the previewed members in the tenant, all at once in a single transaction. Fails with a conflict when the members changed since the preview, preview again then. Poll the deactivation until it is completed or failed.",
+		Tags:          []string{"Roles"},
+		DefaultStatus: http.StatusAccepted,
+	}, h.ConfirmMemberDeactivation)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-member-deactivation",
+		Method:      http.MethodGet,
+		Path:        "/role-assignments/deactivations/{deactivationId}",
+		Summary:     "Get a deactivation of members and its status",
+		Tags:        []string{"Roles"},
+	}, h.GetMemberDeactivation)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "roll-back-member-deactivation",
+		Method:      http.MethodPost,
+		Path:        "/role-assignments/deactivations/{deactivationId}/rollback",
+		Summary:     "Give the members of a completed deactivation their roles back",
+		Description: "Restores every role assignment the deactivation revoked, in a single transaction. Roles assigned to the members since are kept.",
+		Tags:        []string{"Roles"},
+	}, h.RollBackMemberDeactivation)
+}
+
+func (h *MemberDeactivationHandlers) PreviewMemberDeactivation(ctx context.Context, input *PreviewMemberDeactivationRequest) (*MemberDeactivationPreviewResponse, error) {
+	command, err := preview_member_deactivation_use_case.NewPreviewMemberDeactivationCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Role,
+		input.Body.ClassID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	deactivation, token, err := h.previewMemberDeactivationUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &MemberDeactivationPreviewResponse{}
+	response.Body.MemberDeactivationResponse = NewMemberDeactivationResponse(deactivation, loc)
+	response.Body.ConfirmToken = token
+	response.Body.ConfirmExpiresAt = utils.InLocation(deactivation.TokenExpiresAt, loc)
+	return response, nil
+}
+
+func (h *MemberDeactivationHandlers) ConfirmMemberDeactivation(ctx context.Context, input *ConfirmMemberDeactivationRequest) (*MemberDeactivationEnvelope, error) {
+	command, err := confirm_member_deactivation_use_case.NewConfirmMemberDeactivationCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.DeactivationID,
+		authorization.UserIDFromContext(ctx),
+		input.Body.ConfirmToken,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	deactivation, err := h.confirmMemberDeactivationUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &MemberDeactivationEnvelope{Body: NewMemberDeactivationResponse(deactivation, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *MemberDeactivationHandlers) GetMemberDeactivation(ctx context.Context, input *MemberDeactivationPathRequest) (*MemberDeactivationEnvelope, error) {
+	command, err := get_member_deactivation_use_case.NewGetMemberDeactivationCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.DeactivationID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	deactivation, err := h.getMemberDeactivationUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &MemberDeactivationEnvelope{Body: NewMemberDeactivationResponse(deactivation, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *MemberDeactivationHandlers) RollBackMemberDeactivation(ctx context.Context, input *MemberDeactivationPathRequest) (*MemberDeactivationEnvelope, error) {
+	command, err := roll_back_member_deactivation_use_case.NewRollBackMemberDeactivationCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.DeactivationID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	deactivation, err := h.rollBackMemberDeactivationUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &MemberDeactivationEnvelope{Body: NewMemberDeactivationResponse(deactivation, utils.LocationFromContext(ctx))}, nil
+}
@@ -0,0 +1,55 @@
+-- name: CreateGroupRoleAssignment :exec
+INSERT INTO group_role_assignments (id, tenant_id, group_type, group_id, role, status, requested_by, created_at, updated_at)
+VALUES (@id, @tenant_id, @group_type, @group_id, @role, @status, @requested_by, @created_at, @updated_at);
+
+-- name: GetGroupRoleAssignment :one
+SELECT *
+FROM group_role_assignments
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: ListGroupRoleAssignments :many
+SELECT *
+FROM group_role_assignments
+WHERE tenant_id = @tenant_id
+ORDER BY created_at DESC
+LIMIT @row_limit;
+
+-- name: ClaimNextPendingGroupRoleAssignment :one
+UPDATE group_role_assignments
+SET status = 'running', members = 0, processed = 0, assigned = 0, updated_at = NOW()
+WHERE id = (
+    SELECT a.id
+    FROM group_role_assignments a
+    WHERE a.status = 'pending' OR (a.status = 'running' AND a.updated_at < @stale_before)
+    ORDER BY a.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: SaveGroupRoleAssignmentProgress :exec
+UPDATE group_role_assignments
+SET members = @members, processed = @processed, assigned = @assigned, updated_at = NOW()
+WHERE id = @id AND status = 'running';
+
+-- name: MarkGroupRoleAssignmentCompleted :exec
+UPDATE group_role_assignments
+SET status = 'completed',
+    members = @members,
+    processed = @processed,
+    assigned = @assigned,
+    failure_reason = NULL,
+    updated_at = NOW(),
+    completed_at = NOW()
+WHERE id = @id;
+
+-- name: MarkGroupRoleAssignmentFailed :exec
+UPDATE group_role_assignments
+SET status = 'failed', processed = 0, assigned = 0, failure_reason = @failure_reason, updated_at = NOW(), completed_at = NOW()
+WHERE id = @id;
+
+-- name: ListClassMemberIDs :many
+SELECT user_id
+FROM class_enrollments
+WHERE tenant_id = @tenant_id AND class_id = @class_id
+ORDER BY user_id;
@@ -0,0 +1,48 @@
+-- name: CreateMemberDeactivation :exec
+INSERT INTO member_deactivations (id, tenant_id, role, class_id, status, requested_by, subjects, roles, token_hash,
+    token_expires_at, created_at, updated_at)
+VALUES (@id, @tenant_id, @role, @class_id, @status, @requested_by, @subjects, @roles, @token_hash,
+    @token_expires_at, @created_at, @updated_at);
+
+-- name: GetMemberDeactivation :one
+SELECT *
+FROM member_deactivations
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: ConfirmMemberDeactivation :execrows
+UPDATE member_deactivations
+SET status = 'pending', confirmed_by = @confirmed_by, confirmed_at = @confirmed_at, updated_at = NOW()
+WHERE id = @id AND status = 'previewed' AND token_expires_at > NOW();
+
+-- name: ClaimNextPendingMemberDeactivation :one
+UPDATE member_deactivations
+SET status = 'running', updated_at = NOW()
+WHERE id = (
+    SELECT d.id
+    FROM member_deactivations d
+    WHERE d.status = 'pending' OR (d.status = 'running' AND d.updated_at < @stale_before)
+    ORDER BY d.confirmed_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: SaveMemberDeactivationRevoked :exec
+UPDATE member_deactivations
+SET revoked = @revoked, updated_at = NOW()
+WHERE id = @id AND status = 'running' AND revoked IS NULL;
+
+-- name: MarkMemberDeactivationCompleted :exec
+UPDATE member_deactivations
+SET status = 'completed', failure_reason = NULL, updated_at = NOW(), completed_at = NOW()
+WHERE id = @id;
+
+-- name: MarkMemberDeactivationFailed :exec
+UPDATE member_deactivations
+SET status = 'failed', failure_reason = @failure_reason, updated_at = NOW(), completed_at = NOW()
+WHERE id = @id;
+
+-- name: MarkMemberDeactivationRolledBack :execrows
+UPDATE member_deactivations
+SET status = 'rolled_back', rolled_back_by = @rolled_back_by, rolled_back_at = @rolled_back_at, updated_at = NOW()
+WHERE id = @id AND status = 'completed';
@@ -0,0 +1,46 @@
+-- Roles given to every member of a group, one casbin_rule row per member written in one transaction
+CREATE TABLE group_role_assignments (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    group_type VARCHAR(20) NOT NULL,       -- class
+    group_id UUID NOT NULL,
+    role VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,           -- pending, running, completed, failed
+    requested_by VARCHAR(255) NOT NULL,
+    members INTEGER NOT NULL DEFAULT 0,    -- members of the group when the assignment started
+    processed INTEGER NOT NULL DEFAULT 0,  -- members written so far, visible once committed
+    assigned INTEGER NOT NULL DEFAULT 0,   -- members who did not have the role yet
+    failure_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_group_role_assignments_tenant ON group_role_assignments(tenant_id, created_at);
+CREATE INDEX idx_group_role_assignments_status ON group_role_assignments(status);
+
+-- Roles revoked from the members holding a role, of a class when class_id is set. Previewed first
+-- and only run once confirmed with the token of the preview.
+CREATE TABLE member_deactivations (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    role VARCHAR(100) NOT NULL,
+    class_id UUID,
+    status VARCHAR(20) NOT NULL,           -- previewed, pending, running, completed, failed, rolled_back
+    requested_by VARCHAR(255) NOT NULL,
+    subjects JSONB NOT NULL,               -- members the preview counted
+    roles JSONB NOT NULL DEFAULT '{}',     -- role assignments of the members per role when previewed
+    token_hash VARCHAR(64) NOT NULL,       -- sha256 of the confirm token, never the token
+    token_expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    confirmed_by VARCHAR(255),
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    revoked JSONB,                         -- role assignments removed, saved before any is removed
+    failure_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    rolled_back_by VARCHAR(255),
+    rolled_back_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_member_deactivations_status ON member_deactivations(status);
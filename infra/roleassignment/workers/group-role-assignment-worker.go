@@ -0,0 +1,36 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	run_group_role_assignment_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/run-group-role-assignment-use-case"
+)
+
+// RunGroupRoleAssignmentWorker takes the queued group role assignments every interval, it returns
+// when ctx is cancelled. Several instances can run concurrently, assignments are claimed with SKIP
+// LOCKED.
+func RunGroupRoleAssignmentWorker(ctx context.Context, useCase *run_group_role_assignment_use_case.RunGroupRoleAssignmentUseCase,
+	interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			processed, err := useCase.Execute(ctx)
+			if err != nil {
+				log.Printf("Group role assignment failed: %v", err)
+			}
+			if !processed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	run_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/run-member-deactivation-use-case"
+)
+
+// RunMemberDeactivationWorker revokes the roles of the confirmed member deactivations every interval,
+// it returns when ctx is cancelled. Deactivations are claimed with SKIP LOCKED.
+func RunMemberDeactivationWorker(ctx context.Context, useCase *run_member_deactivation_use_case.RunMemberDeactivationUseCase,
+	interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			processed, err := useCase.Execute(ctx)
+			if err != nil {
+				log.Printf("Member deactivation failed: %v", err)
+			}
+			if !processed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
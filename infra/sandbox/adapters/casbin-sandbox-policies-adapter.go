@@ -0,0 +1,46 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// sandboxTenantSource names the sandboxes among the tenants the policies are activated for
+const sandboxTenantSource = "sandboxes"
+
+// CasbinSandboxPolicies generates the policies of the roles for sandboxes as for the tenants of the
+// configuration. Role assignments are per tenant, so the ones of a sandbox only apply in it.
+type CasbinSandboxPolicies struct {
+	casbinService *authorization.CasbinService
+}
+
+func NewCasbinSandboxPolicies(casbinService *authorization.CasbinService) ports.SandboxPolicies {
+	return &CasbinSandboxPolicies{
+		casbinService: casbinService,
+	}
+}
+
+func (p *CasbinSandboxPolicies) CopyRoles(ctx context.Context, tenantID string, sandboxTenantID string, excluded []string) ([]string, int, error) {
+	subjects, copied, authzErr := p.casbinService.CopyTenantRoles(ctx, tenantID, sandboxTenantID, excluded)
+	if authzErr != nil {
+		return nil, 0, authzErr
+	}
+	return subjects, copied, nil
+}
+
+func (p *CasbinSandboxPolicies) RemoveRoles(ctx context.Context, sandboxTenantID string) error {
+	if authzErr := p.casbinService.RemoveTenantRoles(ctx, sandboxTenantID); authzErr != nil {
+		return authzErr
+	}
+	return nil
+}
+
+// Activate reloads the policies only when the sandboxes changed
+func (p *CasbinSandboxPolicies) Activate(_ context.Context, sandboxTenantIDs []string) error {
+	if authzErr := p.casbinService.ActivateTenants(sandboxTenantSource, sandboxTenantIDs); authzErr != nil {
+		return authzErr
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sandboxClassLimit is how many classes of the tenant are copied, the ones with the lowest IDs
+const sandboxClassLimit = 25
+
+// sandboxCopy is the statement that copies a table into the sandbox. $1 is the tenant and $2 the
+// sandbox, the classes copied are in sandbox_classes and the users kept as they are in sandbox_keep.
+type sandboxCopy struct {
+	table     string
+	statement string
+}
+
+// remapped gives an ID of the tenant a new ID in the sandbox, the same everywhere so rows that
+// reference each other still do
+func remapped(column string) string {
+	return fmt.Sprintf("md5($2 || %s::text)::uuid", column)
+}
+
+// pseudonymized replaces a user of the tenant by a pseudonym of the sandbox unless the user is kept
+func pseudonymized(column string) string {
+	return fmt.Sprintf("CASE WHEN %[1]s IS NULL OR %[1]s = '' OR %[1]s IN (SELECT user_id FROM sandbox_keep) THEN %[1]s "+
+		"ELSE 'sandbox-' || left(md5($2 || %[1]s), 16) END", column)
+}
+
+// sandboxCopies are the tables a sandbox gets: the schedule, enrollments and grades of a few classes
+// and the calendar of the school. Messages, incidents, surveys, reports and everything else with
+// personal content stay out, and free text such as grade return reasons is left empty.
+var sandboxCopies = []sandboxCopy{
+	{"class_capacities", `
+		INSERT INTO class_capacities (tenant_id, class_id, capacity, updated_at)
+		SELECT $2, ` + remapped("class_id") + `, capacity, updated_at
+		FROM class_capacities WHERE tenant_id = $1 AND class_id IN (SELECT class_id FROM sandbox_classes)`},
+	{"class_meetings", `
+		INSERT INTO class_meetings (tenant_id, class_id, weekday, start_minute, end_minute)
+		SELECT $2, ` + remapped("class_id") + `, weekday, start_minute, end_minute
+		FROM class_meetings WHERE tenant_id = $1 AND class_id IN (SELECT class_id FROM sandbox_classes)`},
+	{"class_enrollments", `
+		INSERT INTO class_enrollments (tenant_id, class_id, user_id, enrolled_at)
+		SELECT $2, ` + remapped("class_id") + `, ` + pseudonymized("user_id") + `, enrolled_at
+		FROM class_enrollments WHERE tenant_id = $1 AND class_id IN (SELECT class_id FROM sandbox_classes)`},
+	{"class_grading_policies", `
+		INSERT INTO class_grading_policies (tenant_id, class_id, requires_approval, updated_at)
+		SELECT $2, ` + remapped("class_id") + `, requires_approval, updated_at
+		FROM class_grading_policies WHERE tenant_id = $1 AND class_id IN (SELECT class_id FROM sandbox_classes)`},
+	{"assignment_deadlines", `
+		INSERT INTO assignment_deadlines (tenant_id, class_id, assignment_id, due_at, penalty_percent_per_day, cutoff_days, updated_at)
+		SELECT $2, ` + remapped("class_id") + `, ` + remapped("assignment_id") + `, due_at, penalty_percent_per_day, cutoff_days, updated_at
+		FROM assignment_deadlines WHERE tenant_id = $1 AND class_id IN (SELECT class_id FROM sandbox_classes)`},
+	{"assignment_extensions", `
+		INSERT INTO assignment_extensions (tenant_id, class_id, assignment_id, student_id, due_at, granted_by, reason, granted_at)
+		SELECT $2, ` + remapped("class_id") + `, ` + remapped("assignment_id") + `, ` + pseudonymized("student_id") + `, due_at,
+			` + pseudonymized("granted_by") + `, NULL, granted_at
+		FROM assignment_extensions WHERE tenant_id = $1 AND class_id IN (SELECT class_id FROM sandbox_classes)`},
+	// The history of the grades stays out, their versions start over
+	{"grades", `
+		INSERT INTO grades (id, tenant_id, class_id, assignment_id, student_id, score, status, recorded_by, reviewed_by,
+			return_reason, turned_in_at, late_penalty_percent, submitted_at, released_at, created_at, updated_at, version)
+		SELECT ` + remapped("id") + `, $2, ` + remapped("class_id") + `, ` + remapped("assignment_id") + `, ` + pseudonymized("student_id") + `,
+			score, status, ` + pseudonymized("recorded_by") + `, ` + pseudonymized("reviewed_by") + `, NULL, turned_in_at,
+			late_penalty_percent, submitted_at, released_at, created_at, updated_at, 0
+		FROM grades WHERE tenant_id = $1 AND class_id IN (SELECT class_id FROM sandbox_classes)`},
+	{"non_instructional_days", `
+		INSERT INTO non_instructional_days (tenant_id, day, name, country, created_by, created_at)
+		SELECT $2, day, name, country, ` + pseudonymized("created_by") + `, created_at
+		FROM non_instructional_days WHERE tenant_id = $1`},
+	// Only the time zone of the school, the ones of users are preferences
+	{"time_zone_settings", `
+		INSERT INTO time_zone_settings (tenant_id, user_id, time_zone, updated_at)
+		SELECT $2, user_id, time_zone, updated_at
+		FROM time_zone_settings WHERE tenant_id = $1 AND user_id = ''`},
+}
+
+// PostgresSandboxData copies the tenant into its sandbox with SQL, the rows never leave the database
+type PostgresSandboxData struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresSandboxData(dbInstance *pgxpool.Pool) ports.SandboxData {
+	return &PostgresSandboxData{db: dbInstance}
+}
+
+// Copy copies the tables of sandboxCopies in one repeatable read transaction, so the copy is
+// consistent while the tenant keeps writing and nothing is left when it fails
+func (d *PostgresSandboxData) Copy(ctx context.Context, tenantID string, sandboxTenantID string, keep []string) (map[string]int, error) {
+	if !entities.IsSandboxTenant(sandboxTenantID) {
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("%s is not a sandbox", sandboxTenantID), nil)
+	}
+
+	tx, err := d.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `CREATE TEMP TABLE sandbox_keep ON COMMIT DROP AS SELECT DISTINCT unnest($1::text[]) AS user_id`, keep); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	_, err = tx.Exec(ctx, `
+		CREATE TEMP TABLE sandbox_classes ON COMMIT DROP AS
+		SELECT class_id FROM (
+			SELECT class_id FROM class_capacities WHERE tenant_id = $1
+			UNION SELECT class_id FROM class_enrollments WHERE tenant_id = $1
+			UNION SELECT class_id FROM grades WHERE tenant_id = $1
+		) classes
+		ORDER BY class_id
+		LIMIT $2`, tenantID, sandboxClassLimit)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	tables := make(map[string]int, len(sandboxCopies))
+	for _, table := range sandboxCopies {
+		result, err := tx.Exec(ctx, table.statement, tenantID, sandboxTenantID)
+		if err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to copy "+table.table, err)
+		}
+		tables[table.table] = int(result.RowsAffected())
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return tables, nil
+}
+
+// Remove deletes the rows of the sandbox from every table of the public schema with a tenant_id
+// column, the ones written in the sandbox included. It refuses tenants that are not sandboxes.
+func (d *PostgresSandboxData) Remove(ctx context.Context, sandboxTenantID string) error {
+	if !entities.IsSandboxTenant(sandboxTenantID) {
+		return appErrors.NewInfrastructureError(fmt.Sprintf("%s is not a sandbox", sandboxTenantID), nil)
+	}
+
+	tx, err := d.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT c.relname
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_attribute a ON a.attrelid = c.oid
+		WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p') AND NOT c.relispartition
+		  AND a.attname = 'tenant_id' AND NOT a.attisdropped
+		ORDER BY c.relname`)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	for _, table := range tables {
+		if _, err := tx.Exec(ctx, "DELETE FROM "+pgx.Identifier{"public", table}.Sanitize()+" WHERE tenant_id = $1", sandboxTenantID); err != nil {
+			return appErrors.NewInfrastructureError("failed to remove the sandbox rows of "+table, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/entities"
+	sandboxErrors "github.com/nahualventure/class-backend/core/app/sandbox/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTenantSandboxRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresTenantSandboxRepository(dbInstance *pgxpool.Pool) ports.TenantSandboxRepository {
+	return &PostgresTenantSandboxRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresTenantSandboxRepository) Create(ctx context.Context, sandbox *entities.TenantSandbox) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(sandbox.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	created, err := r.queries.CreateTenantSandbox(ctx, db.CreateTenantSandboxParams{
+		ID:              pgUUID,
+		TenantID:        sandbox.TenantID,
+		SandboxTenantID: sandbox.SandboxTenantID,
+		Status:          string(sandbox.Status),
+		RequestedBy:     sandbox.RequestedBy,
+		ExpiresAt:       pgtype.Timestamptz{Time: sandbox.ExpiresAt, Valid: true},
+		CreatedAt:       pgtype.Timestamptz{Time: sandbox.CreatedAt, Valid: true},
+		UpdatedAt:       pgtype.Timestamptz{Time: sandbox.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if created == 0 {
+		// Another request created a sandbox since the use case looked
+		return sandboxErrors.NewTenantSandboxAlreadyExistsError("", "")
+	}
+
+	return nil
+}
+
+func (r *PostgresTenantSandboxRepository) FindCurrent(ctx context.Context, tenantID string) (*entities.TenantSandbox, error) {
+	row, err := r.queries.GetCurrentTenantSandbox(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toTenantSandbox(row)
+}
+
+func (r *PostgresTenantSandboxRepository) Update(ctx context.Context, sandbox *entities.TenantSandbox) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(sandbox.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.UpdateTenantSandboxExpiry(ctx, db.UpdateTenantSandboxExpiryParams{
+		ID:        pgUUID,
+		ExpiresAt: pgtype.Timestamptz{Time: sandbox.ExpiresAt, Valid: true},
+		UpdatedAt: pgtype.Timestamptz{Time: sandbox.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresTenantSandboxRepository) ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.TenantSandbox, error) {
+	row, err := r.queries.ClaimNextPendingTenantSandbox(ctx, pgtype.Timestamptz{Time: time.Now().Add(-staleAfter), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toTenantSandbox(row)
+}
+
+func (r *PostgresTenantSandboxRepository) ClaimNextDue(ctx context.Context, now time.Time, staleAfter time.Duration) (*entities.TenantSandbox, error) {
+	row, err := r.queries.ClaimNextDueTenantSandbox(ctx, db.ClaimNextDueTenantSandboxParams{
+		Now:         pgtype.Timestamptz{Time: now, Valid: true},
+		StaleBefore: pgtype.Timestamptz{Time: now.Add(-staleAfter), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toTenantSandbox(row)
+}
+
+func (r *PostgresTenantSandboxRepository) MarkReady(ctx context.Context, sandbox *entities.TenantSandbox) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(sandbox.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tables, err := json.Marshal(sandbox.Tables)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize sandbox tables", err)
+	}
+
+	err = r.queries.MarkTenantSandboxReady(ctx, db.MarkTenantSandboxReadyParams{
+		ID:     pgUUID,
+		Tables: tables,
+		Roles:  int32(sandbox.Roles),
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresTenantSandboxRepository) MarkDeleted(ctx context.Context, sandboxID string) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(sandboxID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := r.queries.MarkTenantSandboxDeleted(ctx, pgUUID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresTenantSandboxRepository) MarkFailed(ctx context.Context, sandboxID string, reason string) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(sandboxID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := r.queries.MarkTenantSandboxFailed(ctx, db.MarkTenantSandboxFailedParams{ID: pgUUID, FailureReason: &reason}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresTenantSandboxRepository) ListReadyTenants(ctx context.Context) ([]string, error) {
+	tenants, err := r.queries.ListReadySandboxTenants(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return tenants, nil
+}
+
+func toTenantSandbox(row db.TenantSandbox) (*entities.TenantSandbox, error) {
+	sandbox := &entities.TenantSandbox{
+		ID:              row.ID.String(),
+		TenantID:        row.TenantID,
+		SandboxTenantID: row.SandboxTenantID,
+		Status:          entities.TenantSandboxStatus(row.Status),
+		RequestedBy:     row.RequestedBy,
+		Tables:          map[string]int{},
+		Roles:           int(row.Roles),
+		ExpiresAt:       row.ExpiresAt.Time,
+		CreatedAt:       row.CreatedAt.Time,
+		UpdatedAt:       row.UpdatedAt.Time,
+	}
+	if len(row.Tables) > 0 {
+		if err := json.Unmarshal(row.Tables, &sandbox.Tables); err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to deserialize sandbox tables", err)
+		}
+	}
+	if row.FailureReason != nil {
+		sandbox.FailureReason = *row.FailureReason
+	}
+	if row.ReadyAt.Valid {
+		readyAt := row.ReadyAt.Time
+		sandbox.ReadyAt = &readyAt
+	}
+	if row.DeletedAt.Valid {
+		deletedAt := row.DeletedAt.Time
+		sandbox.DeletedAt = &deletedAt
+	}
+	return sandbox, nil
+}
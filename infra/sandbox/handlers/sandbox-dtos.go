@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/sandbox/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type TenantSandboxResponse struct {
+	ID              string         `json:"id"`
+	SandboxTenantID string         `json:"sandbox_tenant_id" doc:"Send it as the tenant of requests to use the sandbox"`
+	Status          string         `json:"status" enum:"pending,provisioning,ready,deleting" doc:"Sandboxes can be used once ready"`
+	RequestedBy     string         `json:"requested_by"`
+	Tables          map[string]int `json:"tables" doc:"Rows copied per table"`
+	Roles           int            `json:"roles" doc:"Role assignments of staff copied"`
+	ExpiresAt       time.Time      `json:"expires_at" doc:"The sandbox and everything done in it are removed then"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ReadyAt         *time.Time     `json:"ready_at,omitempty"`
+}
+
+func NewTenantSandboxResponse(sandbox *entities.TenantSandbox, loc *time.Location) TenantSandboxResponse {
+	return TenantSandboxResponse{
+		ID:              sandbox.ID,
+		SandboxTenantID: sandbox.SandboxTenantID,
+		Status:          string(sandbox.Status),
+		RequestedBy:     sandbox.RequestedBy,
+		Tables:          sandbox.Tables,
+		Roles:           sandbox.Roles,
+		ExpiresAt:       utils.InLocation(sandbox.ExpiresAt, loc),
+		CreatedAt:       utils.InLocation(sandbox.CreatedAt, loc),
+		ReadyAt:         utils.InLocationPtr(sandbox.ReadyAt, loc),
+	}
+}
+
+type CreateTenantSandboxRequest struct {
+	Body struct {
+		LifetimeHours int `json:"lifetime_hours,omitempty" default:"168" minimum:"1" maximum:"720" doc:"Hours until the sandbox expires"`
+	}
+}
+
+type ExtendTenantSandboxRequest struct {
+	Body struct {
+		LifetimeHours int `json:"lifetime_hours" minimum:"1" maximum:"720" doc:"Hours from now until the sandbox expires"`
+	}
+}
+
+type TenantSandboxEnvelope struct {
+	Body TenantSandboxResponse
+}
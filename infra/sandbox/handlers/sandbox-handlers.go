@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	create_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/create-tenant-sandbox-use-case"
+	delete_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/delete-tenant-sandbox-use-case"
+	extend_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/extend-tenant-sandbox-use-case"
+	get_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/get-tenant-sandbox-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type SandboxHandlers struct {
+	createTenantSandboxUseCase *create_tenant_sandbox_use_case.CreateTenantSandboxUseCase
+	getTenantSandboxUseCase    *get_tenant_sandbox_use_case.GetTenantSandboxUseCase
+	extendTenantSandboxUseCase *extend_tenant_sandbox_use_case.ExtendTenantSandboxUseCase
+	deleteTenantSandboxUseCase *delete_tenant_sandbox_use_case.DeleteTenantSandboxUseCase
+}
+
+func NewSandboxHandlers(
+	createTenantSandboxUseCase *create_tenant_sandbox_use_case.CreateTenantSandboxUseCase,
+	getTenantSandboxUseCase *get_tenant_sandbox_use_case.GetTenantSandboxUseCase,
+	extendTenantSandboxUseCase *extend_tenant_sandbox_use_case.ExtendTenantSandboxUseCase,
+	deleteTenantSandboxUseCase *delete_tenant_sandbox_use_case.DeleteTenantSandboxUseCase,
+) *SandboxHandlers {
+	return &SandboxHandlers{
+		createTenantSandboxUseCase: createTenantSandboxUseCase,
+		getTenantSandboxUseCase:    getTenantSandboxUseCase,
+		extendTenantSandboxUseCase: extendTenantSandboxUseCase,
+		deleteTenantSandboxUseCase: deleteTenantSandboxUseCase,
+	}
+}
+
+func (h *SandboxHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-tenant-sandbox",
+		Method:        http.MethodPost,
+		Path:          "/sandbox",
+		Summary:       "Create a sandbox of the tenant for training",
+		Description:   "Queues a copy of the schedule, enrollments and grades of a few classes and of the calendar of the tenant under the tenant ID of the sandbox. Students and guardians appear under pseudonyms, staff keep their roles. Poll the sandbox until it is ready.",
+		Tags:          []string{"Sandbox"},
+		DefaultStatus: http.StatusAccepted,
+	}, h.CreateTenantSandbox)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-tenant-sandbox",
+		Method:      http.MethodGet,
+		Path:        "/sandbox",
+		Summary:     "Get the sandbox of the tenant",
+		Tags:        []string{"Sandbox"},
+	}, h.GetTenantSandbox)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "extend-tenant-sandbox",
+		Method:      http.MethodPatch,
+		Path:        "/sandbox",
+		Summary:     "Change when the sandbox of the tenant expires",
+		Tags:        []string{"Sandbox"},
+	}, h.ExtendTenantSandbox)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-tenant-sandbox",
+		Method:        http.MethodDelete,
+		Path:          "/sandbox",
+		Summary:       "Delete the sandbox of the tenant",
+		Description:   "Expires the sandbox now, it is removed with everything done in it shortly after.",
+		Tags:          []string{"Sandbox"},
+		DefaultStatus: http.StatusAccepted,
+	}, h.DeleteTenantSandbox)
+}
+
+func (h *SandboxHandlers) CreateTenantSandbox(ctx context.Context, input *CreateTenantSandboxRequest) (*TenantSandboxEnvelope, error) {
+	command, err := create_tenant_sandbox_use_case.NewCreateTenantSandboxCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		time.Duration(input.Body.LifetimeHours)*time.Hour,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	sandbox, err := h.createTenantSandboxUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &TenantSandboxEnvelope{Body: NewTenantSandboxResponse(sandbox, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *SandboxHandlers) GetTenantSandbox(ctx context.Context, input *struct{}) (*TenantSandboxEnvelope, error) {
+	command, err := get_tenant_sandbox_use_case.NewGetTenantSandboxCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	sandbox, err := h.getTenantSandboxUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &TenantSandboxEnvelope{Body: NewTenantSandboxResponse(sandbox, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *SandboxHandlers) ExtendTenantSandbox(ctx context.Context, input *ExtendTenantSandboxRequest) (*TenantSandboxEnvelope, error) {
+	command, err := extend_tenant_sandbox_use_case.NewExtendTenantSandboxCommand(
+		authorization.TenantIDFromContext(ctx),
+		time.Duration(input.Body.LifetimeHours)*time.Hour,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	sandbox, err := h.extendTenantSandboxUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &TenantSandboxEnvelope{Body: NewTenantSandboxResponse(sandbox, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *SandboxHandlers) DeleteTenantSandbox(ctx context.Context, input *struct{}) (*TenantSandboxEnvelope, error) {
+	command, err := delete_tenant_sandbox_use_case.NewDeleteTenantSandboxCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	sandbox, err := h.deleteTenantSandboxUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &TenantSandboxEnvelope{Body: NewTenantSandboxResponse(sandbox, utils.LocationFromContext(ctx))}, nil
+}
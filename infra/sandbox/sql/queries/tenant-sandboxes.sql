@@ -0,0 +1,62 @@
+-- name: CreateTenantSandbox :execrows
+INSERT INTO tenant_sandboxes (id, tenant_id, sandbox_tenant_id, status, requested_by, expires_at, created_at, updated_at)
+VALUES (@id, @tenant_id, @sandbox_tenant_id, @status, @requested_by, @expires_at, @created_at, @updated_at)
+ON CONFLICT (tenant_id) WHERE status NOT IN ('deleted', 'failed') DO NOTHING;
+
+-- name: GetCurrentTenantSandbox :one
+SELECT *
+FROM tenant_sandboxes
+WHERE tenant_id = @tenant_id AND status NOT IN ('deleted', 'failed');
+
+-- name: UpdateTenantSandboxExpiry :exec
+UPDATE tenant_sandboxes
+SET expires_at = @expires_at, updated_at = @updated_at
+WHERE id = @id AND status IN ('pending', 'provisioning', 'ready');
+
+-- name: ClaimNextPendingTenantSandbox :one
+UPDATE tenant_sandboxes
+SET status = 'provisioning', updated_at = NOW()
+WHERE id = (
+    SELECT s.id
+    FROM tenant_sandboxes s
+    WHERE s.status = 'pending' OR (s.status = 'provisioning' AND s.updated_at < @stale_before)
+    ORDER BY s.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: ClaimNextDueTenantSandbox :one
+UPDATE tenant_sandboxes
+SET status = 'deleting', updated_at = NOW()
+WHERE id = (
+    SELECT s.id
+    FROM tenant_sandboxes s
+    WHERE (s.status IN ('pending', 'ready') AND s.expires_at <= @now)
+       OR (s.status = 'deleting' AND s.updated_at < @stale_before)
+    ORDER BY s.expires_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkTenantSandboxReady :exec
+UPDATE tenant_sandboxes
+SET status = 'ready', tables = @tables, roles = @roles, updated_at = NOW(), ready_at = NOW()
+WHERE id = @id;
+
+-- name: MarkTenantSandboxDeleted :exec
+UPDATE tenant_sandboxes
+SET status = 'deleted', updated_at = NOW(), deleted_at = NOW()
+WHERE id = @id;
+
+-- name: MarkTenantSandboxFailed :exec
+UPDATE tenant_sandboxes
+SET status = 'failed', failure_reason = @failure_reason, updated_at = NOW(), deleted_at = NOW()
+WHERE id = @id;
+
+-- name: ListReadySandboxTenants :many
+SELECT sandbox_tenant_id
+FROM tenant_sandboxes
+WHERE status = 'ready'
+ORDER BY sandbox_tenant_id;
@@ -0,0 +1,20 @@
+-- Sandboxes of tenants, copies of a subset of their data under the tenant ID sandbox_tenant_id
+CREATE TABLE tenant_sandboxes (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    sandbox_tenant_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,           -- pending, provisioning, ready, deleting, deleted, failed
+    requested_by VARCHAR(255) NOT NULL,
+    tables JSONB NOT NULL DEFAULT '{}',    -- rows copied per table
+    roles INTEGER NOT NULL DEFAULT 0,      -- role assignments of staff copied
+    failure_reason TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ready_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- A tenant has one sandbox until its data is gone
+CREATE UNIQUE INDEX idx_tenant_sandboxes_current ON tenant_sandboxes(tenant_id) WHERE status NOT IN ('deleted', 'failed');
+CREATE INDEX idx_tenant_sandboxes_status ON tenant_sandboxes(status, expires_at);
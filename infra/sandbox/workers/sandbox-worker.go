@@ -0,0 +1,55 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	run_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/run-tenant-sandbox-use-case"
+	sync_sandbox_tenants_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/sync-sandbox-tenants-use-case"
+)
+
+// RunSandboxWorker copies the requested sandboxes and removes the expired ones every interval, it
+// returns when ctx is cancelled. Several instances can run concurrently, sandboxes are claimed with
+// SKIP LOCKED.
+func RunSandboxWorker(ctx context.Context, useCase *run_tenant_sandbox_use_case.RunTenantSandboxUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			processed, err := useCase.Execute(ctx)
+			if err != nil {
+				log.Printf("Tenant sandbox failed: %v", err)
+			}
+			if !processed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunSandboxTenantSync applies the policies to the sandboxes ready on any instance every interval,
+// until ctx is cancelled. The first sync runs right away so restarts serve the sandboxes again.
+func RunSandboxTenantSync(ctx context.Context, useCase *sync_sandbox_tenants_use_case.SyncSandboxTenantsUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := useCase.Execute(ctx); err != nil {
+			log.Printf("Failed to sync sandbox tenants: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// uniqueViolation is the SQLSTATE of a write breaking a unique constraint, the name of views here
+const uniqueViolation = "23505"
+
+type PostgresSavedViewRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresSavedViewRepository(dbInstance *pgxpool.Pool) ports.SavedViewRepository {
+	return &PostgresSavedViewRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresSavedViewRepository) Create(ctx context.Context, view *entities.SavedView) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(view.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	created, err := r.queries.CreateSavedView(ctx, db.CreateSavedViewParams{
+		ID:        pgUUID,
+		TenantID:  view.TenantID,
+		OwnerID:   view.OwnerID,
+		List:      view.List,
+		Name:      view.Name,
+		OrderBy:   view.OrderBy,
+		Filter:    view.Filter,
+		Shared:    view.Shared,
+		CreatedAt: pgtype.Timestamptz{Time: view.CreatedAt, Valid: true},
+		UpdatedAt: pgtype.Timestamptz{Time: view.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return created > 0, nil
+}
+
+func (r *PostgresSavedViewRepository) Save(ctx context.Context, view *entities.SavedView) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(view.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	err := r.queries.UpdateSavedView(ctx, db.UpdateSavedViewParams{
+		ID:        pgUUID,
+		TenantID:  view.TenantID,
+		Name:      view.Name,
+		OrderBy:   view.OrderBy,
+		Filter:    view.Filter,
+		Shared:    view.Shared,
+		UpdatedAt: pgtype.Timestamptz{Time: view.UpdatedAt, Valid: true},
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return false, nil
+	}
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return true, nil
+}
+
+func (r *PostgresSavedViewRepository) FindByID(ctx context.Context, tenantID string, viewID string) (*entities.SavedView, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(viewID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetSavedView(ctx, db.GetSavedViewParams{ID: pgUUID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toSavedView(row), nil
+}
+
+func (r *PostgresSavedViewRepository) ListVisible(ctx context.Context, tenantID string, userID string, list string) ([]*entities.SavedView, error) {
+	params := db.ListVisibleSavedViewsParams{TenantID: tenantID, UserID: userID}
+	if list != "" {
+		params.List = &list
+	}
+
+	rows, err := r.queries.ListVisibleSavedViews(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	views := make([]*entities.SavedView, 0, len(rows))
+	for _, row := range rows {
+		views = append(views, toSavedView(row))
+	}
+
+	return views, nil
+}
+
+func (r *PostgresSavedViewRepository) Delete(ctx context.Context, tenantID string, viewID string) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(viewID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := r.queries.DeleteSavedView(ctx, db.DeleteSavedViewParams{ID: pgUUID, TenantID: tenantID}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func toSavedView(row db.SavedView) *entities.SavedView {
+	return &entities.SavedView{
+		ID:        row.ID.String(),
+		TenantID:  row.TenantID,
+		OwnerID:   row.OwnerID,
+		List:      row.List,
+		Name:      row.Name,
+		OrderBy:   row.OrderBy,
+		Filter:    row.Filter,
+		Shared:    row.Shared,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+
+	get_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/get-saved-view-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+// SavedViewApplier applies the view a list request names for the user of the request, list
+// handlers use it through utils.ListQueryParams
+type SavedViewApplier struct {
+	getSavedViewUseCase *get_saved_view_use_case.GetSavedViewUseCase
+}
+
+func NewSavedViewApplier(getSavedViewUseCase *get_saved_view_use_case.GetSavedViewUseCase) utils.SavedViews {
+	return &SavedViewApplier{getSavedViewUseCase: getSavedViewUseCase}
+}
+
+// Apply returns the order and filter of the request with those of the view filling in the ones it
+// leaves empty. Views of other lists and views of other users that are not shared are not found.
+func (a *SavedViewApplier) Apply(ctx context.Context, list string, viewID string, orderBy string, filter string) (string, string, error) {
+	command, err := get_saved_view_use_case.NewGetSavedViewCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		viewID,
+		list,
+	)
+	if err != nil {
+		return "", "", err
+	}
+
+	view, err := a.getSavedViewUseCase.Execute(ctx, command)
+	if err != nil {
+		return "", "", err
+	}
+
+	orderBy, filter = view.Apply(orderBy, filter)
+	return orderBy, filter, nil
+}
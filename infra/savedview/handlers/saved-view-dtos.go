@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/savedview/domain/entities"
+)
+
+type SavedViewResponse struct {
+	ID        string    `json:"id"`
+	List      string    `json:"list"`
+	Name      string    `json:"name"`
+	OrderBy   string    `json:"order_by" doc:"Same syntax as the order_by parameter of the list"`
+	Filter    string    `json:"filter" doc:"Same syntax as the filter parameter of the list"`
+	Shared    bool      `json:"shared"`
+	OwnerID   string    `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewSavedViewResponse(view *entities.SavedView) SavedViewResponse {
+	return SavedViewResponse{
+		ID:        view.ID,
+		List:      view.List,
+		Name:      view.Name,
+		OrderBy:   view.OrderBy,
+		Filter:    view.Filter,
+		Shared:    view.Shared,
+		OwnerID:   view.OwnerID,
+		CreatedAt: view.CreatedAt,
+		UpdatedAt: view.UpdatedAt,
+	}
+}
+
+type SavedViewEnvelope struct {
+	Body SavedViewResponse
+}
+
+type ListSavedViewsRequest struct {
+	List string `query:"list" doc:"Only return the views of this list"`
+}
+
+type ListSavedViewsResponse struct {
+	Body struct {
+		Items []SavedViewResponse `json:"items"`
+	}
+}
+
+type CreateSavedViewRequest struct {
+	Body struct {
+		List    string `json:"list" doc:"List endpoint the view applies to, such as users"`
+		Name    string `json:"name" maxLength:"100"`
+		OrderBy string `json:"order_by,omitempty" maxLength:"200" doc:"Same syntax as the order_by parameter of the list"`
+		Filter  string `json:"filter,omitempty" maxLength:"1000" doc:"Same syntax as the filter parameter of the list"`
+		Shared  bool   `json:"shared,omitempty" doc:"Lists the view to every user of the tenant"`
+	}
+}
+
+type UpdateSavedViewRequest struct {
+	ViewID string `path:"viewId" format:"uuid" doc:"Saved view ID"`
+	Body   struct {
+		Name    string `json:"name" maxLength:"100"`
+		OrderBy string `json:"order_by,omitempty" maxLength:"200"`
+		Filter  string `json:"filter,omitempty" maxLength:"1000"`
+		Shared  bool   `json:"shared,omitempty"`
+	}
+}
+
+type SavedViewRequest struct {
+	ViewID string `path:"viewId" format:"uuid" doc:"Saved view ID"`
+}
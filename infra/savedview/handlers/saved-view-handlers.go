@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	create_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/create-saved-view-use-case"
+	delete_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/delete-saved-view-use-case"
+	get_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/get-saved-view-use-case"
+	list_saved_views_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/list-saved-views-use-case"
+	update_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/update-saved-view-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type SavedViewHandlers struct {
+	listSavedViewsUseCase  *list_saved_views_use_case.ListSavedViewsUseCase
+	createSavedViewUseCase *create_saved_view_use_case.CreateSavedViewUseCase
+	getSavedViewUseCase    *get_saved_view_use_case.GetSavedViewUseCase
+	updateSavedViewUseCase *update_saved_view_use_case.UpdateSavedViewUseCase
+	deleteSavedViewUseCase *delete_saved_view_use_case.DeleteSavedViewUseCase
+}
+
+func NewSavedViewHandlers(
+	listSavedViewsUseCase *list_saved_views_use_case.ListSavedViewsUseCase,
+	createSavedViewUseCase *create_saved_view_use_case.CreateSavedViewUseCase,
+	getSavedViewUseCase *get_saved_view_use_case.GetSavedViewUseCase,
+	updateSavedViewUseCase *update_saved_view_use_case.UpdateSavedViewUseCase,
+	deleteSavedViewUseCase *delete_saved_view_use_case.DeleteSavedViewUseCase,
+) *SavedViewHandlers {
+	return &SavedViewHandlers{
+		listSavedViewsUseCase:  listSavedViewsUseCase,
+		createSavedViewUseCase: createSavedViewUseCase,
+		getSavedViewUseCase:    getSavedViewUseCase,
+		updateSavedViewUseCase: updateSavedViewUseCase,
+		deleteSavedViewUseCase: deleteSavedViewUseCase,
+	}
+}
+
+func (h *SavedViewHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-saved-views",
+		Method:      http.MethodGet,
+		Path:        "/views",
+		Summary:     "List the saved views of the user and those shared in the tenant",
+		Tags:        []string{"Saved Views"},
+	}, h.ListSavedViews)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-saved-view",
+		Method:        http.MethodPost,
+		Path:          "/views",
+		Summary:       "Save the order and filter of a list as a named view",
+		Description:   "The order and filter are checked like those of a request to the list. Pass the ID of the view as the view parameter of the list to apply it.",
+		Tags:          []string{"Saved Views"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateSavedView)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-saved-view",
+		Method:      http.MethodGet,
+		Path:        "/views/{viewId}",
+		Summary:     "Get a saved view",
+		Tags:        []string{"Saved Views"},
+	}, h.GetSavedView)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-saved-view",
+		Method:      http.MethodPut,
+		Path:        "/views/{viewId}",
+		Summary:     "Update a saved view, by its owner only",
+		Tags:        []string{"Saved Views"},
+	}, h.UpdateSavedView)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-saved-view",
+		Method:        http.MethodDelete,
+		Path:          "/views/{viewId}",
+		Summary:       "Delete a saved view, by its owner only",
+		Tags:          []string{"Saved Views"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.DeleteSavedView)
+}
+
+func (h *SavedViewHandlers) ListSavedViews(ctx context.Context, input *ListSavedViewsRequest) (*ListSavedViewsResponse, error) {
+	command, err := list_saved_views_use_case.NewListSavedViewsCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.List,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	views, err := h.listSavedViewsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ListSavedViewsResponse{}
+	response.Body.Items = make([]SavedViewResponse, 0, len(views))
+	for _, view := range views {
+		response.Body.Items = append(response.Body.Items, NewSavedViewResponse(view))
+	}
+
+	return response, nil
+}
+
+func (h *SavedViewHandlers) CreateSavedView(ctx context.Context, input *CreateSavedViewRequest) (*SavedViewEnvelope, error) {
+	command, err := create_saved_view_use_case.NewCreateSavedViewCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.List,
+		input.Body.Name,
+		input.Body.OrderBy,
+		input.Body.Filter,
+		input.Body.Shared,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	view, err := h.createSavedViewUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SavedViewEnvelope{Body: NewSavedViewResponse(view)}, nil
+}
+
+func (h *SavedViewHandlers) GetSavedView(ctx context.Context, input *SavedViewRequest) (*SavedViewEnvelope, error) {
+	command, err := get_saved_view_use_case.NewGetSavedViewCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.ViewID,
+		"",
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	view, err := h.getSavedViewUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SavedViewEnvelope{Body: NewSavedViewResponse(view)}, nil
+}
+
+func (h *SavedViewHandlers) UpdateSavedView(ctx context.Context, input *UpdateSavedViewRequest) (*SavedViewEnvelope, error) {
+	command, err := update_saved_view_use_case.NewUpdateSavedViewCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.ViewID,
+		input.Body.Name,
+		input.Body.OrderBy,
+		input.Body.Filter,
+		input.Body.Shared,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	view, err := h.updateSavedViewUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SavedViewEnvelope{Body: NewSavedViewResponse(view)}, nil
+}
+
+func (h *SavedViewHandlers) DeleteSavedView(ctx context.Context, input *SavedViewRequest) (*struct{}, error) {
+	command, err := delete_saved_view_use_case.NewDeleteSavedViewCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.ViewID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.deleteSavedViewUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,26 @@
+-- name: CreateSavedView :execrows
+INSERT INTO saved_views (id, tenant_id, owner_id, list, name, order_by, filter, shared, created_at, updated_at)
+VALUES (@id, @tenant_id, @owner_id, @list, @name, @order_by, @filter, @shared, @created_at, @updated_at)
+ON CONFLICT (tenant_id, owner_id, list, name) DO NOTHING;
+
+-- name: UpdateSavedView :exec
+UPDATE saved_views
+SET name = @name, order_by = @order_by, filter = @filter, shared = @shared, updated_at = @updated_at
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: GetSavedView :one
+SELECT *
+FROM saved_views
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: ListVisibleSavedViews :many
+SELECT *
+FROM saved_views
+WHERE tenant_id = @tenant_id
+  AND (owner_id = @user_id OR shared)
+  AND (sqlc.narg('list')::text IS NULL OR list = sqlc.narg('list'))
+ORDER BY list, name, id;
+
+-- name: DeleteSavedView :exec
+DELETE FROM saved_views
+WHERE id = @id AND tenant_id = @tenant_id;
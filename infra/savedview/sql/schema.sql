@@ -0,0 +1,17 @@
+-- Orders and filters of list endpoints saved by name. Views belong to the user who saved them, shared
+-- ones are listed to every user of the tenant.
+CREATE TABLE saved_views (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    owner_id VARCHAR(255) NOT NULL,
+    list VARCHAR(50) NOT NULL,            -- users
+    name VARCHAR(100) NOT NULL,
+    order_by VARCHAR(200) NOT NULL DEFAULT '',
+    filter TEXT NOT NULL DEFAULT '',
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_saved_views_name ON saved_views(tenant_id, owner_id, list, name);
+CREATE INDEX idx_saved_views_shared ON saved_views(tenant_id, list) WHERE shared;
@@ -0,0 +1,103 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/scanning/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/scanning/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// clamdChunkSize is how much of the file goes in each INSTREAM chunk
+const clamdChunkSize = 64 * 1024
+
+// clamdTimeout bounds a scan when the context has no deadline, large videos take a while
+const clamdTimeout = 2 * time.Minute
+
+// ClamAVScanner streams files to clamd with the INSTREAM command. clamd refuses files over its
+// StreamMaxLength, which must be at least the size of the largest upload.
+type ClamAVScanner struct {
+	address string
+	dialer  net.Dialer
+}
+
+// NewClamAVScanner scans through the clamd listening at address, such as localhost:3310
+func NewClamAVScanner(address string) ports.VirusScanner {
+	return &ClamAVScanner{
+		address: address,
+		dialer:  net.Dialer{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *ClamAVScanner) Name() string {
+	return "clamav"
+}
+
+func (s *ClamAVScanner) Scan(ctx context.Context, content []byte) (*entities.ScanVerdict, error) {
+	conn, err := s.dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to connect to clamd", err)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(clamdTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to set the clamd deadline", err)
+	}
+
+	if err := instream(conn, content); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to stream the file to clamd", err)
+	}
+
+	reply, err := io.ReadAll(conn)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to read the clamd reply", err)
+	}
+	return parseClamdReply(string(bytes.TrimRight(reply, "\x00\n")))
+}
+
+// instream sends the file as length-prefixed chunks, a chunk of length 0 ends it
+func instream(conn net.Conn, content []byte) error {
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+
+	var size [4]byte
+	for start := 0; start < len(content); start += clamdChunkSize {
+		chunk := content[start:min(start+clamdChunkSize, len(content))]
+		binary.BigEndian.PutUint32(size[:], uint32(len(chunk)))
+		if _, err := conn.Write(size[:]); err != nil {
+			return err
+		}
+		if _, err := conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	binary.BigEndian.PutUint32(size[:], 0)
+	_, err := conn.Write(size[:])
+	return err
+}
+
+// parseClamdReply reads "stream: OK", "stream: <signature> FOUND" or "<reason> ERROR"
+func parseClamdReply(reply string) (*entities.ScanVerdict, error) {
+	result := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case result == "OK":
+		return &entities.ScanVerdict{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return &entities.ScanVerdict{Infected: true, Signature: strings.TrimSuffix(result, " FOUND")}, nil
+	default:
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("clamd failed to scan the file: %s", reply), nil)
+	}
+}
@@ -0,0 +1,164 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	libraryEntities "github.com/nahualventure/class-backend/core/app/library/domain/entities"
+	messagingEntities "github.com/nahualventure/class-backend/core/app/messaging/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/scanning/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/scanning/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresQuarantineRepository reads the scan status kept on resource_versions and
+// message_attachments. Results are recorded with their outbox event in one transaction, so the audit
+// log has every scan.
+type PostgresQuarantineRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresQuarantineRepository(dbInstance *pgxpool.Pool) ports.QuarantineRepository {
+	return &PostgresQuarantineRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresQuarantineRepository) ListPending(ctx context.Context, limit int) ([]*entities.QuarantinedFile, error) {
+	rows, err := r.queries.ListPendingFiles(ctx, int32(limit))
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	files := make([]*entities.QuarantinedFile, 0, len(rows))
+	for _, row := range rows {
+		file := &entities.QuarantinedFile{
+			Kind:       entities.FileKind(row.FileKind),
+			ID:         row.ID.String(),
+			TenantID:   row.TenantID,
+			FileName:   row.FileName,
+			UploadedBy: row.UploadedBy,
+			UploadedAt: row.UploadedAt.Time,
+		}
+		// Keys are built by the modules owning the files
+		if file.Kind == entities.FileKindResourceVersion {
+			version := &libraryEntities.ResourceVersion{ID: file.ID, TenantID: file.TenantID, ResourceID: row.ParentID.String()}
+			file.StorageKey = version.StorageKey()
+		} else {
+			message := &messagingEntities.Message{TenantID: file.TenantID, ConversationID: row.ParentID.String()}
+			file.StorageKey = message.AttachmentKey(file.ID)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func (r *PostgresQuarantineRepository) Record(ctx context.Context, scan *entities.FileScan) (bool, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(scan.File.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	var size int64
+	if scan.File.Kind == entities.FileKindResourceVersion {
+		row, err := qtx.SetResourceVersionScanStatus(ctx, db.SetResourceVersionScanStatusParams{
+			ScanStatus: string(scan.Status),
+			ID:         pgID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return false, nil
+			}
+			return false, appErrors.PropagateError(err)
+		}
+		if scan.Status == storage.ScanClean {
+			err = qtx.ReleaseResourceVersion(ctx, db.ReleaseResourceVersionParams{
+				Version:   row.Version,
+				UpdatedAt: pgtype.Timestamptz{Time: scan.ScannedAt, Valid: true},
+				ID:        row.ResourceID,
+			})
+			if err != nil {
+				return false, appErrors.PropagateError(err)
+			}
+		}
+		size = row.Size
+	} else {
+		size, err = qtx.SetAttachmentScanStatus(ctx, db.SetAttachmentScanStatusParams{
+			ScanStatus: string(scan.Status),
+			ID:         pgID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return false, nil
+			}
+			return false, appErrors.PropagateError(err)
+		}
+	}
+
+	// Infected files are deleted and missing ones are gone, they no longer count to the storage quota
+	if scan.Status != storage.ScanClean {
+		err = qtx.AddStorageUsage(ctx, db.AddStorageUsageParams{
+			TenantID:  scan.File.TenantID,
+			OwnerID:   scan.File.UploadedBy,
+			Bytes:     -size,
+			Files:     -1,
+			UpdatedAt: pgtype.Timestamptz{Time: scan.ScannedAt, Valid: true},
+		})
+		if err != nil {
+			return false, appErrors.PropagateError(err)
+		}
+	}
+
+	eventType, payload := events.FileScanned, map[string]any{
+		"file_kind":   string(scan.File.Kind),
+		"file_id":     scan.File.ID,
+		"file_name":   scan.File.FileName,
+		"uploaded_by": scan.File.UploadedBy,
+		"scanner":     scan.Scanner,
+	}
+	if scan.Status == storage.ScanInfected {
+		eventType = events.FileRejected
+		payload["signature"] = scan.Signature
+	} else {
+		payload["status"] = string(scan.Status)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return false, appErrors.NewInfrastructureError("failed to serialize file scan event", err)
+	}
+
+	err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   eventType,
+		AggregateID: scan.File.ID,
+		TenantID:    scan.File.TenantID,
+		Payload:     body,
+		OccurredAt:  pgtype.Timestamptz{Time: scan.ScannedAt, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return true, nil
+}
@@ -0,0 +1,29 @@
+-- name: ListPendingFiles :many
+SELECT 'resource_version'::text AS file_kind, v.id, v.tenant_id, v.resource_id AS parent_id, v.file_name,
+       v.uploaded_by, v.created_at AS uploaded_at
+FROM resource_versions v
+WHERE v.scan_status = 'pending'
+UNION ALL
+SELECT 'attachment'::text, a.id, m.tenant_id, m.conversation_id, a.file_name, m.sender_id, m.created_at
+FROM message_attachments a
+JOIN messages m ON m.id = a.message_id
+WHERE a.scan_status = 'pending'
+ORDER BY uploaded_at
+LIMIT @max_files;
+
+-- name: SetResourceVersionScanStatus :one
+UPDATE resource_versions
+SET scan_status = @scan_status
+WHERE id = @id AND scan_status = 'pending'
+RETURNING resource_id, version, size;
+
+-- name: ReleaseResourceVersion :exec
+UPDATE resources
+SET current_version = GREATEST(current_version, @version::integer), updated_at = @updated_at
+WHERE id = @id;
+
+-- name: SetAttachmentScanStatus :one
+UPDATE message_attachments
+SET scan_status = @scan_status
+WHERE id = @id AND scan_status = 'pending'
+RETURNING size_bytes;
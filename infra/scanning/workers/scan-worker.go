@@ -0,0 +1,29 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	scan_quarantined_files_use_case "github.com/nahualventure/class-backend/core/app/scanning/application/use-cases/scan-quarantined-files-use-case"
+)
+
+// RunFileScans scans the quarantined uploads every interval, it returns when ctx is cancelled
+func RunFileScans(ctx context.Context, useCase *scan_quarantined_files_use_case.ScanQuarantinedFilesUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if scanned, err := useCase.Execute(ctx); err != nil {
+			log.Printf("Failed to scan uploaded files: %v", err)
+		} else if scanned > 0 {
+			log.Printf("Scanned %d uploaded files", scanned)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
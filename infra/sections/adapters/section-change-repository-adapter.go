@@ -0,0 +1,352 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	gradingEntities "github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/sections/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/sections/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresSectionChangeRepository moves the records of a merge or split in one transaction, with the
+// seat inventory of both classes locked the way checkouts lock it
+type PostgresSectionChangeRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresSectionChangeRepository(dbInstance *pgxpool.Pool) ports.SectionChangeRepository {
+	return &PostgresSectionChangeRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresSectionChangeRepository) Apply(ctx context.Context, tenantID string, sourceClassID string, targetClassID string,
+	plan func(source *entities.Section, target *entities.Section) (*entities.SectionChange, error)) (*entities.SectionChange, error) {
+	var pgSourceID, pgTargetID pgtype.UUID
+	if err := pgSourceID.Scan(sourceClassID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := pgTargetID.Scan(targetClassID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+
+	// Locked in class ID order so a merge and a split of the same pair never wait on each other
+	classIDs := []string{sourceClassID, targetClassID}
+	slices.Sort(classIDs)
+	sections := make(map[string]*entities.Section, len(classIDs))
+	for _, classID := range classIDs {
+		section, err := lockSection(ctx, qtx, tenantID, classID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		sections[classID] = section
+	}
+
+	change, err := plan(sections[sourceClassID], sections[targetClassID])
+	if err != nil {
+		return nil, err
+	}
+
+	var pgChangeID pgtype.UUID
+	if err := pgChangeID.Scan(change.ID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	merge := change.Kind == entities.SectionChangeMerge
+	movedAt := pgtype.Timestamptz{Time: change.CreatedAt, Valid: true}
+
+	enrollments, err := qtx.MoveSectionEnrollments(ctx, db.MoveSectionEnrollmentsParams{
+		TargetClassID: pgTargetID,
+		TenantID:      tenantID,
+		SourceClassID: pgSourceID,
+		AllStudents:   merge,
+		StudentIds:    change.StudentIDs,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	change.Moved.Enrollments = len(enrollments)
+
+	grades, err := qtx.MoveSectionGrades(ctx, db.MoveSectionGradesParams{
+		TargetClassID: pgTargetID,
+		MovedAt:       movedAt,
+		TenantID:      tenantID,
+		SourceClassID: pgSourceID,
+		AllStudents:   merge,
+		StudentIds:    change.StudentIDs,
+		ActorID:       change.RequestedBy,
+		Reason:        emptyToNil(change.Reason),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	change.Moved.Grades = len(grades)
+	// Dropped grades do not count toward any average, class summaries never saw them
+	releasedCount, releasedSum := 0, 0.0
+	for _, grade := range grades {
+		if grade.Status == string(gradingEntities.GradeStatusReleased) && !grade.Dropped {
+			releasedCount++
+			releasedSum += gradingEntities.AdjustScore(grade.Score, grade.LatePenaltyPercent)
+		}
+	}
+
+	extensions, err := qtx.MoveSectionExtensions(ctx, db.MoveSectionExtensionsParams{
+		TargetClassID: pgTargetID,
+		TenantID:      tenantID,
+		SourceClassID: pgSourceID,
+		AllStudents:   merge,
+		StudentIds:    change.StudentIDs,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	change.Moved.Extensions = int(extensions)
+
+	submissions, err := qtx.MoveSectionSubmissions(ctx, db.MoveSectionSubmissionsParams{
+		TargetClassID: pgTargetID,
+		MovedAt:       movedAt,
+		TenantID:      tenantID,
+		SourceClassID: pgSourceID,
+		AllStudents:   merge,
+		StudentIds:    change.StudentIDs,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	change.Moved.Submissions = int(submissions)
+
+	var assignments int64
+	if merge {
+		assignments, err = qtx.MoveSectionDeadlines(ctx, db.MoveSectionDeadlinesParams{
+			TargetClassID: pgTargetID,
+			MovedAt:       movedAt,
+			TenantID:      tenantID,
+			SourceClassID: pgSourceID,
+		})
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		err = qtx.MoveSectionStandards(ctx, db.MoveSectionStandardsParams{
+			TargetClassID: pgTargetID,
+			TenantID:      tenantID,
+			SourceClassID: pgSourceID,
+		})
+	} else {
+		assignments, err = qtx.CopySectionDeadlines(ctx, db.CopySectionDeadlinesParams{
+			TargetClassID: pgTargetID,
+			MovedAt:       movedAt,
+			TenantID:      tenantID,
+			SourceClassID: pgSourceID,
+		})
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		err = qtx.CopySectionStandards(ctx, db.CopySectionStandardsParams{
+			TargetClassID: pgTargetID,
+			TenantID:      tenantID,
+			SourceClassID: pgSourceID,
+		})
+	}
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	change.Moved.Assignments = int(assignments)
+
+	moved, err := json.Marshal(change.Moved)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to serialize section change", err)
+	}
+	err = qtx.CreateSectionChange(ctx, db.CreateSectionChangeParams{
+		ID:            pgChangeID,
+		TenantID:      change.TenantID,
+		Kind:          string(change.Kind),
+		SourceClassID: pgSourceID,
+		TargetClassID: pgTargetID,
+		StudentIds:    change.StudentIDs,
+		Reason:        emptyToNil(change.Reason),
+		Moved:         moved,
+		RequestedBy:   change.RequestedBy,
+		CreatedAt:     movedAt,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"change_id":       change.ID,
+		"kind":            string(change.Kind),
+		"source_class_id": change.SourceClassID,
+		"target_class_id": change.TargetClassID,
+		"students":        change.StudentIDs,
+		"enrollments":     change.Moved.Enrollments,
+		"grade_count":     releasedCount,
+		"grade_sum":       releasedSum,
+		"actor_id":        change.RequestedBy,
+	})
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to serialize section change event", err)
+	}
+	err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.SectionStudentsMoved,
+		AggregateID: change.ID,
+		TenantID:    change.TenantID,
+		Payload:     payload,
+		OccurredAt:  movedAt,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return change, nil
+}
+
+// lockSection takes the capacity lock of the class when it has a seat limit and reads it once held
+func lockSection(ctx context.Context, queries *db.Queries, tenantID string, classID string, now time.Time) (*entities.Section, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	section := &entities.Section{ClassID: classID}
+	_, err := queries.LockClassCapacity(ctx, db.LockClassCapacityParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err == nil {
+		row, err := queries.GetClassCapacity(ctx, db.GetClassCapacityParams{
+			Now:      pgtype.Timestamptz{Time: now, Valid: true},
+			TenantID: tenantID,
+			ClassID:  pgClassID,
+		})
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		capacity := int(row.Capacity)
+		section.Capacity = &capacity
+		section.Held = int(row.Held)
+	}
+
+	section.Enrolled, err = queries.ListSectionEnrollees(ctx, db.ListSectionEnrolleesParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	section.Graded, err = queries.ListSectionGradedStudents(ctx, db.ListSectionGradedStudentsParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return section, nil
+}
+
+func (r *PostgresSectionChangeRepository) FindByID(ctx context.Context, tenantID string, changeID string) (*entities.SectionChange, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(changeID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetSectionChange(ctx, db.GetSectionChangeParams{TenantID: tenantID, ID: pgUUID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toSectionChange(row)
+}
+
+func (r *PostgresSectionChangeRepository) List(ctx context.Context, tenantID string, classID string,
+	page *pagination.PageRequest) (*pagination.Page[*entities.SectionChange], error) {
+	var pgClassID pgtype.UUID
+	if classID != "" {
+		if err := pgClassID.Scan(classID); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+	}
+	afterCreatedAt, afterID, err := database.TimeIDKeyset(page.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.queries.ListSectionChanges(ctx, db.ListSectionChangesParams{
+		TenantID:       tenantID,
+		ClassID:        pgClassID,
+		AfterCreatedAt: afterCreatedAt,
+		AfterID:        afterID,
+		PageLimit:      int32(page.PageSize + 1),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	changes := make([]*entities.SectionChange, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		change, err := toSectionChange(db.SectionChange{
+			ID:            row.ID,
+			TenantID:      row.TenantID,
+			Kind:          row.Kind,
+			SourceClassID: row.SourceClassID,
+			TargetClassID: row.TargetClassID,
+			StudentIds:    row.StudentIds,
+			Reason:        row.Reason,
+			Moved:         row.Moved,
+			RequestedBy:   row.RequestedBy,
+			CreatedAt:     row.CreatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return pagination.NewPage(page, changes, total, func(change *entities.SectionChange) []string {
+		return []string{pagination.TimeKey(change.CreatedAt), change.ID}
+	}), nil
+}
+
+func toSectionChange(row db.SectionChange) (*entities.SectionChange, error) {
+	reason := ""
+	if row.Reason != nil {
+		reason = *row.Reason
+	}
+	change, err := entities.NewSectionChange(row.ID.String(), row.TenantID, entities.SectionChangeKind(row.Kind),
+		row.SourceClassID.String(), row.TargetClassID.String(), row.StudentIds, reason, row.RequestedBy, row.CreatedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := json.Unmarshal(row.Moved, &change.Moved); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to deserialize section change", err)
+	}
+	return change, nil
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/sections/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type MovedRecordsResponse struct {
+	Enrollments int `json:"enrollments"`
+	Grades      int `json:"grades"`
+	Extensions  int `json:"extensions" doc:"Due date extensions of the students"`
+	Submissions int `json:"submissions" doc:"Similarity checks of the work the students turned in"`
+	Assignments int `json:"assignments" doc:"Assignment deadlines moved by merges and copied by splits, with their standards tags"`
+}
+
+type SectionChangeResponse struct {
+	ID            string               `json:"id"`
+	Kind          string               `json:"kind" enum:"merge,split"`
+	SourceClassID string               `json:"source_class_id"`
+	TargetClassID string               `json:"target_class_id"`
+	StudentIDs    []string             `json:"student_ids" doc:"Students moved to the target"`
+	Reason        string               `json:"reason,omitempty"`
+	Moved         MovedRecordsResponse `json:"moved" doc:"Records moved to the target"`
+	RequestedBy   string               `json:"requested_by"`
+	CreatedAt     time.Time            `json:"created_at"`
+}
+
+func NewSectionChangeResponse(change *entities.SectionChange, loc *time.Location) SectionChangeResponse {
+	return SectionChangeResponse{
+		ID:            change.ID,
+		Kind:          string(change.Kind),
+		SourceClassID: change.SourceClassID,
+		TargetClassID: change.TargetClassID,
+		StudentIDs:    change.StudentIDs,
+		Reason:        change.Reason,
+		Moved: MovedRecordsResponse{
+			Enrollments: change.Moved.Enrollments,
+			Grades:      change.Moved.Grades,
+			Extensions:  change.Moved.Extensions,
+			Submissions: change.Moved.Submissions,
+			Assignments: change.Moved.Assignments,
+		},
+		RequestedBy: change.RequestedBy,
+		CreatedAt:   utils.InLocation(change.CreatedAt, loc),
+	}
+}
+
+type SectionChangeEnvelope struct {
+	Body SectionChangeResponse
+}
+
+type MergeSectionsRequest struct {
+	Body struct {
+		SourceClassID string `json:"source_class_id" format:"uuid" doc:"Section merged away, it is left without students"`
+		TargetClassID string `json:"target_class_id" format:"uuid" doc:"Section the students join"`
+		Reason        string `json:"reason,omitempty" maxLength:"500"`
+	}
+}
+
+type SplitSectionRequest struct {
+	Body struct {
+		SourceClassID string   `json:"source_class_id" format:"uuid" doc:"Section the students leave"`
+		TargetClassID string   `json:"target_class_id" format:"uuid" doc:"Section the students join"`
+		StudentIDs    []string `json:"student_ids" minItems:"1" maxItems:"500" doc:"Students enrolled in the source to move"`
+		Reason        string   `json:"reason,omitempty" maxLength:"500"`
+	}
+}
+
+type ListSectionChangesRequest struct {
+	ClassID  string `query:"class_id" format:"uuid" doc:"Only the changes the class was the source or target of"`
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+}
+
+type ListSectionChangesResponse struct {
+	Body utils.ListResponseBody[SectionChangeResponse]
+}
+
+func NewListSectionChangesResponse(page *pagination.Page[*entities.SectionChange], loc *time.Location) *ListSectionChangesResponse {
+	return &ListSectionChangesResponse{Body: utils.NewListResponseBody(page, func(change *entities.SectionChange) SectionChangeResponse {
+		return NewSectionChangeResponse(change, loc)
+	})}
+}
+
+type SectionChangePathRequest struct {
+	ChangeID string `path:"changeId" format:"uuid" doc:"Section change ID"`
+}
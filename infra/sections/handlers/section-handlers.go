@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_section_change_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/get-section-change-use-case"
+	list_section_changes_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/list-section-changes-use-case"
+	merge_sections_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/merge-sections-use-case"
+	split_section_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/split-section-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type SectionHandlers struct {
+	mergeSectionsUseCase      *merge_sections_use_case.MergeSectionsUseCase
+	splitSectionUseCase       *split_section_use_case.SplitSectionUseCase
+	listSectionChangesUseCase *list_section_changes_use_case.ListSectionChangesUseCase
+	getSectionChangeUseCase   *get_section_change_use_case.GetSectionChangeUseCase
+}
+
+func NewSectionHandlers(
+	mergeSectionsUseCase *merge_sections_use_case.MergeSectionsUseCase,
+	splitSectionUseCase *split_section_use_case.SplitSectionUseCase,
+	listSectionChangesUseCase *list_section_changes_use_case.ListSectionChangesUseCase,
+	getSectionChangeUseCase *get_section_change_use_case.GetSectionChangeUseCase,
+) *SectionHandlers {
+	return &SectionHandlers{
+		mergeSectionsUseCase:      mergeSectionsUseCase,
+		splitSectionUseCase:       splitSectionUseCase,
+		listSectionChangesUseCase: listSectionChangesUseCase,
+		getSectionChangeUseCase:   getSectionChangeUseCase,
+	}
+}
+
+func (h *SectionHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "merge-sections",
+		Method:        http.MethodPost,
+		Path:          "/sections/merges",
+		Summary:       "Merge a class section into another",
+		Description:   "Moves every student of the source section to the target with their enrollments, grades, extensions and submissions, and the assignments of the source with them, all in a single transaction. Attendance stays with the source. Fails without moving anything when a student is enrolled or graded in both sections or the target has no seats left for the enrolled ones.",
+		Tags:          []string{"Sections"},
+		DefaultStatus: http.StatusCreated,
+	}, h.MergeSections)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "split-section",
+		Method:        http.MethodPost,
+		Path:          "/sections/splits",
+		Summary:       "Move some students of a class section to another",
+		Description:   "Moves the students with their enrollments, grades, extensions and submissions to the target section in a single transaction. The assignments of the source are copied to the target, both sections keep them. Every student must be enrolled in the source and have no records in the target.",
+		Tags:          []string{"Sections"},
+		DefaultStatus: http.StatusCreated,
+	}, h.SplitSection)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-section-changes",
+		Method:      http.MethodGet,
+		Path:        "/sections/changes",
+		Summary:     "List the section merges and splits of the tenant",
+		Tags:        []string{"Sections"},
+	}, h.ListSectionChanges)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-section-change",
+		Method:      http.MethodGet,
+		Path:        "/sections/changes/{changeId}",
+		Summary:     "Get a section merge or split and what it moved",
+		Tags:        []string{"Sections"},
+	}, h.GetSectionChange)
+}
+
+func (h *SectionHandlers) MergeSections(ctx context.Context, input *MergeSectionsRequest) (*SectionChangeEnvelope, error) {
+	command, err := merge_sections_use_case.NewMergeSectionsCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Body.SourceClassID,
+		input.Body.TargetClassID,
+		input.Body.Reason,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	change, err := h.mergeSectionsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SectionChangeEnvelope{Body: NewSectionChangeResponse(change, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *SectionHandlers) SplitSection(ctx context.Context, input *SplitSectionRequest) (*SectionChangeEnvelope, error) {
+	command, err := split_section_use_case.NewSplitSectionCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Body.SourceClassID,
+		input.Body.TargetClassID,
+		input.Body.StudentIDs,
+		input.Body.Reason,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	change, err := h.splitSectionUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SectionChangeEnvelope{Body: NewSectionChangeResponse(change, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *SectionHandlers) ListSectionChanges(ctx context.Context, input *ListSectionChangesRequest) (*ListSectionChangesResponse, error) {
+	command, err := list_section_changes_use_case.NewListSectionChangesCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		input.PageSize,
+		input.Cursor,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listSectionChangesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListSectionChangesResponse(page, utils.LocationFromContext(ctx)), nil
+}
+
+func (h *SectionHandlers) GetSectionChange(ctx context.Context, input *SectionChangePathRequest) (*SectionChangeEnvelope, error) {
+	command, err := get_section_change_use_case.NewGetSectionChangeCommand(authorization.TenantIDFromContext(ctx), input.ChangeID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	change, err := h.getSectionChangeUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SectionChangeEnvelope{Body: NewSectionChangeResponse(change, utils.LocationFromContext(ctx))}, nil
+}
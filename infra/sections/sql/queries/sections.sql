@@ -0,0 +1,114 @@
+-- name: ListSectionEnrollees :many
+SELECT user_id FROM class_enrollments
+WHERE tenant_id = @tenant_id AND class_id = @class_id
+ORDER BY user_id;
+
+-- Students with grades in the class, the ones no longer enrolled included
+-- name: ListSectionGradedStudents :many
+SELECT DISTINCT student_id FROM grades
+WHERE tenant_id = @tenant_id AND class_id = @class_id
+ORDER BY student_id;
+
+-- Every student of the source when all_students is set, merges move the records of students no
+-- longer enrolled too
+-- name: MoveSectionEnrollments :many
+UPDATE class_enrollments
+SET class_id = @target_class_id
+WHERE tenant_id = @tenant_id AND class_id = @source_class_id
+  AND (@all_students::boolean OR user_id = ANY(@student_ids::text[]))
+RETURNING user_id;
+
+-- Moves the grades and appends a moved event to the stream of each, in one statement so the version
+-- of the grade and its last event stay in step
+-- name: MoveSectionGrades :many
+WITH moved AS (
+    UPDATE grades g
+    SET class_id = @target_class_id, version = g.version + 1, updated_at = @moved_at
+    WHERE g.tenant_id = @tenant_id AND g.class_id = @source_class_id
+      AND (@all_students::boolean OR g.student_id = ANY(@student_ids::text[]))
+    RETURNING g.*
+), appended AS (
+    INSERT INTO grade_events (
+        tenant_id, grade_id, version, class_id, assignment_id, student_id, event_type, actor_id, reason,
+        old_score, new_score, old_status, new_status, turned_in_at, late_penalty_percent, occurred_at, dropped
+    )
+    SELECT m.tenant_id, m.id, m.version, m.class_id, m.assignment_id, m.student_id, 'moved', @actor_id::text, sqlc.narg('reason')::text,
+           m.score, m.score, m.status, m.status, m.turned_in_at, m.late_penalty_percent, @moved_at, m.dropped
+    FROM moved m
+)
+SELECT id, score, status, late_penalty_percent, dropped FROM moved;
+
+-- Extensions the student has for the same assignment of the target already stay behind
+-- name: MoveSectionExtensions :execrows
+UPDATE assignment_extensions e
+SET class_id = @target_class_id
+WHERE e.tenant_id = @tenant_id AND e.class_id = @source_class_id
+  AND (@all_students::boolean OR e.student_id = ANY(@student_ids::text[]))
+  AND NOT EXISTS (
+      SELECT 1 FROM assignment_extensions t
+      WHERE t.tenant_id = e.tenant_id AND t.class_id = @target_class_id
+        AND t.assignment_id = e.assignment_id AND t.student_id = e.student_id
+  );
+
+-- name: MoveSectionSubmissions :execrows
+UPDATE similarity_checks
+SET class_id = @target_class_id, updated_at = @moved_at
+WHERE tenant_id = @tenant_id AND class_id = @source_class_id
+  AND (@all_students::boolean OR student_id = ANY(@student_ids::text[]));
+
+-- name: MoveSectionDeadlines :execrows
+UPDATE assignment_deadlines d
+SET class_id = @target_class_id, updated_at = @moved_at
+WHERE d.tenant_id = @tenant_id AND d.class_id = @source_class_id
+  AND NOT EXISTS (
+      SELECT 1 FROM assignment_deadlines t
+      WHERE t.tenant_id = d.tenant_id AND t.class_id = @target_class_id AND t.assignment_id = d.assignment_id
+  );
+
+-- name: MoveSectionStandards :exec
+UPDATE assignment_standards s
+SET class_id = @target_class_id
+WHERE s.tenant_id = @tenant_id AND s.class_id = @source_class_id
+  AND NOT EXISTS (
+      SELECT 1 FROM assignment_standards t
+      WHERE t.tenant_id = s.tenant_id AND t.class_id = @target_class_id
+        AND t.assignment_id = s.assignment_id AND t.standard_id = s.standard_id
+  );
+
+-- name: CopySectionDeadlines :execrows
+INSERT INTO assignment_deadlines (tenant_id, class_id, assignment_id, due_at, penalty_percent_per_day, cutoff_days, updated_at)
+SELECT d.tenant_id, @target_class_id, d.assignment_id, d.due_at, d.penalty_percent_per_day, d.cutoff_days, @moved_at
+FROM assignment_deadlines d
+WHERE d.tenant_id = @tenant_id AND d.class_id = @source_class_id
+ON CONFLICT (tenant_id, class_id, assignment_id) DO NOTHING;
+
+-- name: CopySectionStandards :exec
+INSERT INTO assignment_standards (tenant_id, class_id, assignment_id, standard_id, tagged_by, tagged_at)
+SELECT s.tenant_id, @target_class_id, s.assignment_id, s.standard_id, s.tagged_by, s.tagged_at
+FROM assignment_standards s
+WHERE s.tenant_id = @tenant_id AND s.class_id = @source_class_id
+ON CONFLICT (tenant_id, class_id, assignment_id, standard_id) DO NOTHING;
+
+-- name: CreateSectionChange :exec
+INSERT INTO section_changes (id, tenant_id, kind, source_class_id, target_class_id, student_ids, reason, moved, requested_by, created_at)
+VALUES (@id, @tenant_id, @kind, @source_class_id, @target_class_id, @student_ids, @reason, @moved, @requested_by, @created_at);
+
+-- name: GetSectionChange :one
+SELECT * FROM section_changes
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- Changes the class was the source or target of when class_id is set
+-- name: ListSectionChanges :many
+SELECT id, tenant_id, kind, source_class_id, target_class_id, student_ids, reason, moved, requested_by, created_at,
+       (SELECT count(*) FROM section_changes t
+        WHERE t.tenant_id = @tenant_id
+          AND (sqlc.narg('class_id')::uuid IS NULL
+               OR t.source_class_id = sqlc.narg('class_id')::uuid OR t.target_class_id = sqlc.narg('class_id')::uuid)) AS total
+FROM section_changes
+WHERE tenant_id = @tenant_id
+  AND (sqlc.narg('class_id')::uuid IS NULL
+       OR source_class_id = sqlc.narg('class_id')::uuid OR target_class_id = sqlc.narg('class_id')::uuid)
+  AND (sqlc.narg('after_created_at')::timestamptz IS NULL
+       OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT @page_limit;
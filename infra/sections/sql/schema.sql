@@ -0,0 +1,15 @@
+-- Audit record of every merge and split of class sections, moved counts what moved to the target
+CREATE TABLE section_changes (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL,             -- merge, split
+    source_class_id UUID NOT NULL,
+    target_class_id UUID NOT NULL,
+    student_ids TEXT[] NOT NULL,
+    reason TEXT,
+    moved JSONB NOT NULL,
+    requested_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_section_changes_tenant_id_created_at ON section_changes(tenant_id, created_at);
@@ -0,0 +1,63 @@
+package adapters
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// CasbinRoleDirectory holds the roles of service accounts in Casbin next to the roles of users, so
+// the policies of a role apply to both
+type CasbinRoleDirectory struct {
+	casbinService *authorization.CasbinService
+}
+
+func NewCasbinRoleDirectory(casbinService *authorization.CasbinService) ports.RoleDirectory {
+	return &CasbinRoleDirectory{
+		casbinService: casbinService,
+	}
+}
+
+func (d *CasbinRoleDirectory) AvailableRoles() []string {
+	return d.casbinService.GetAvailableRoles()
+}
+
+func (d *CasbinRoleDirectory) Permissions(roles []string) []string {
+	var scopes []string
+	for _, role := range roles {
+		for resource, actions := range d.casbinService.GetRolePermissions(role) {
+			for _, action := range actions {
+				if scope := entities.Scope(resource, action); !slices.Contains(scopes, scope) {
+					scopes = append(scopes, scope)
+				}
+			}
+		}
+	}
+	slices.Sort(scopes)
+	return scopes
+}
+
+func (d *CasbinRoleDirectory) Roles(_ context.Context, subject string, tenantID string) ([]string, error) {
+	roles, authzErr := d.casbinService.GetUserRoles(subject, tenantID)
+	if authzErr != nil {
+		return nil, authzErr
+	}
+	return roles, nil
+}
+
+func (d *CasbinRoleDirectory) Assign(_ context.Context, subject string, role string, tenantID string) error {
+	if authzErr := d.casbinService.AssignRole(subject, role, tenantID); authzErr != nil {
+		return authzErr
+	}
+	return nil
+}
+
+func (d *CasbinRoleDirectory) Remove(_ context.Context, subject string, role string, tenantID string) error {
+	if authzErr := d.casbinService.RemoveRole(subject, role, tenantID); authzErr != nil {
+		return authzErr
+	}
+	return nil
+}
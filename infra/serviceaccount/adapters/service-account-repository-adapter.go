@@ -0,0 +1,329 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresServiceAccountRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresServiceAccountRepository(dbInstance *pgxpool.Pool) ports.ServiceAccountRepository {
+	return &PostgresServiceAccountRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresServiceAccountRepository) Create(ctx context.Context, account *entities.ServiceAccount, change entities.ServiceAccountChange) error {
+	accountID, err := pgUUID(account.ID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.CreateServiceAccount(ctx, db.CreateServiceAccountParams{
+		ID:          accountID,
+		TenantID:    account.TenantID,
+		Name:        account.Name,
+		Description: account.Description,
+		OwnerID:     account.OwnerID,
+		CreatedBy:   account.CreatedBy,
+		ClientType:  account.ClientType,
+		CreatedAt:   pgtype.Timestamptz{Time: account.CreatedAt, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: account.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := saveKeys(ctx, qtx, account.Keys); err != nil {
+		return err
+	}
+	if err := appendChange(ctx, qtx, account, change); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresServiceAccountRepository) Save(ctx context.Context, account *entities.ServiceAccount, keys []*entities.ServiceAccountKey,
+	change entities.ServiceAccountChange) error {
+	accountID, err := pgUUID(account.ID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.UpdateServiceAccount(ctx, db.UpdateServiceAccountParams{
+		Name:        account.Name,
+		Description: account.Description,
+		OwnerID:     account.OwnerID,
+		UpdatedAt:   pgtype.Timestamptz{Time: account.UpdatedAt, Valid: true},
+		DisabledAt:  timestamptz(account.DisabledAt),
+		TenantID:    account.TenantID,
+		ID:          accountID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := saveKeys(ctx, qtx, keys); err != nil {
+		return err
+	}
+	if err := appendChange(ctx, qtx, account, change); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresServiceAccountRepository) FindByID(ctx context.Context, tenantID string, serviceAccountID string) (*entities.ServiceAccount, error) {
+	accountID, err := pgUUID(serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.GetServiceAccount(ctx, db.GetServiceAccountParams{TenantID: tenantID, ID: accountID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	accounts, err := r.withKeys(ctx, tenantID, []db.ServiceAccount{row})
+	if err != nil {
+		return nil, err
+	}
+	return accounts[0], nil
+}
+
+func (r *PostgresServiceAccountRepository) List(ctx context.Context, tenantID string) ([]*entities.ServiceAccount, error) {
+	rows, err := r.queries.ListServiceAccounts(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return r.withKeys(ctx, tenantID, rows)
+}
+
+func (r *PostgresServiceAccountRepository) FindByKey(ctx context.Context, keyID string) (*entities.ServiceAccount, *entities.ServiceAccountKey, error) {
+	id, err := pgUUID(keyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyRow, err := r.queries.GetServiceAccountKey(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, nil
+		}
+		return nil, nil, appErrors.PropagateError(err)
+	}
+	row, err := r.queries.GetServiceAccount(ctx, db.GetServiceAccountParams{TenantID: keyRow.TenantID, ID: keyRow.ServiceAccountID})
+	if err != nil {
+		return nil, nil, appErrors.PropagateError(err)
+	}
+
+	account := toServiceAccount(row)
+	key := toServiceAccountKey(keyRow)
+	account.Keys = []*entities.ServiceAccountKey{key}
+	return account, key, nil
+}
+
+func (r *PostgresServiceAccountRepository) TouchKey(ctx context.Context, keyID string, usedAt time.Time) error {
+	id, err := pgUUID(keyID)
+	if err != nil {
+		return err
+	}
+
+	err = r.queries.TouchServiceAccountKey(ctx, db.TouchServiceAccountKeyParams{
+		LastUsedAt: pgtype.Timestamptz{Time: usedAt, Valid: true},
+		ID:         id,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+// withKeys converts the rows of accounts and loads the keys of all of them in one query
+func (r *PostgresServiceAccountRepository) withKeys(ctx context.Context, tenantID string, rows []db.ServiceAccount) ([]*entities.ServiceAccount, error) {
+	accounts := make([]*entities.ServiceAccount, 0, len(rows))
+	byID := make(map[string]*entities.ServiceAccount, len(rows))
+	ids := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		account := toServiceAccount(row)
+		accounts = append(accounts, account)
+		byID[account.ID] = account
+		ids = append(ids, row.ID)
+	}
+	if len(ids) == 0 {
+		return accounts, nil
+	}
+
+	keyRows, err := r.queries.ListServiceAccountKeys(ctx, db.ListServiceAccountKeysParams{TenantID: tenantID, ServiceAccountIds: ids})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	for _, keyRow := range keyRows {
+		key := toServiceAccountKey(keyRow)
+		if account, ok := byID[key.ServiceAccountID]; ok {
+			account.Keys = append(account.Keys, key)
+		}
+	}
+	return accounts, nil
+}
+
+func saveKeys(ctx context.Context, queries *db.Queries, keys []*entities.ServiceAccountKey) error {
+	for _, key := range keys {
+		id, err := pgUUID(key.ID)
+		if err != nil {
+			return err
+		}
+		accountID, err := pgUUID(key.ServiceAccountID)
+		if err != nil {
+			return err
+		}
+
+		err = queries.SaveServiceAccountKey(ctx, db.SaveServiceAccountKeyParams{
+			ID:               id,
+			ServiceAccountID: accountID,
+			TenantID:         key.TenantID,
+			SecretHash:       key.SecretHash,
+			BoundIp:          key.BoundIP,
+			Scopes:           storedScopes(key.Scopes),
+			CreatedAt:        pgtype.Timestamptz{Time: key.CreatedAt, Valid: true},
+			ExpiresAt:        timestamptz(key.ExpiresAt),
+			RevokedAt:        timestamptz(key.RevokedAt),
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+	return nil
+}
+
+// appendChange publishes the change for the audit log, labeled with the kind of its actor
+func appendChange(ctx context.Context, queries *db.Queries, account *entities.ServiceAccount, change entities.ServiceAccountChange) error {
+	event := map[string]any{
+		"service_account_id": account.ID,
+		"actor_id":           change.ActorID,
+		"actor_kind":         entities.ActorKindOf(change.ActorID),
+	}
+	if change.KeyID != "" {
+		event["key_id"] = change.KeyID
+	}
+	if change.Type != events.ServiceAccountKeyIssued && change.Type != events.ServiceAccountKeyRevoked {
+		event["name"] = account.Name
+		event["owner_id"] = account.OwnerID
+		event["roles"] = account.Roles
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize service account event", err)
+	}
+
+	err = queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   change.Type,
+		AggregateID: account.ID,
+		TenantID:    account.TenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func toServiceAccount(row db.ServiceAccount) *entities.ServiceAccount {
+	return &entities.ServiceAccount{
+		ID:          row.ID.String(),
+		TenantID:    row.TenantID,
+		Name:        row.Name,
+		Description: row.Description,
+		OwnerID:     row.OwnerID,
+		CreatedBy:   row.CreatedBy,
+		ClientType:  row.ClientType,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+		DisabledAt:  timePtr(row.DisabledAt),
+	}
+}
+
+func toServiceAccountKey(row db.ServiceAccountKey) *entities.ServiceAccountKey {
+	return &entities.ServiceAccountKey{
+		ID:               row.ID.String(),
+		ServiceAccountID: row.ServiceAccountID.String(),
+		TenantID:         row.TenantID,
+		SecretHash:       row.SecretHash,
+		BoundIP:          row.BoundIp,
+		Scopes:           row.Scopes,
+		CreatedAt:        row.CreatedAt.Time,
+		ExpiresAt:        timePtr(row.ExpiresAt),
+		RevokedAt:        timePtr(row.RevokedAt),
+		LastUsedAt:       timePtr(row.LastUsedAt),
+	}
+}
+
+// storedScopes stores keys without scopes as an empty array, the column is not nullable
+func storedScopes(scopes []string) []string {
+	if scopes == nil {
+		return []string{}
+	}
+	return scopes
+}
+
+func pgUUID(id string) (pgtype.UUID, error) {
+	var value pgtype.UUID
+	if err := value.Scan(id); err != nil {
+		return value, appErrors.PropagateError(err)
+	}
+	return value, nil
+}
+
+func timePtr(value pgtype.Timestamptz) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+func timestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
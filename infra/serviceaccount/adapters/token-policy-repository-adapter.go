@@ -0,0 +1,115 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTokenPolicyRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresTokenPolicyRepository(dbInstance *pgxpool.Pool) ports.TokenPolicyRepository {
+	return &PostgresTokenPolicyRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresTokenPolicyRepository) Find(ctx context.Context, tenantID string, clientType string) (*entities.TokenPolicy, error) {
+	row, err := r.queries.GetTokenPolicy(ctx, db.GetTokenPolicyParams{TenantID: tenantID, ClientType: clientType})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toTokenPolicy(row), nil
+}
+
+func (r *PostgresTokenPolicyRepository) List(ctx context.Context, tenantID string) ([]*entities.TokenPolicy, error) {
+	rows, err := r.queries.ListTokenPolicies(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	policies := make([]*entities.TokenPolicy, 0, len(rows))
+	for _, row := range rows {
+		policies = append(policies, toTokenPolicy(row))
+	}
+	return policies, nil
+}
+
+func (r *PostgresTokenPolicyRepository) Save(ctx context.Context, policy *entities.TokenPolicy) error {
+	payload, err := json.Marshal(map[string]any{
+		"client_type":            policy.ClientType,
+		"key_lifetime_seconds":   int64(policy.KeyLifetime / time.Second),
+		"rotation_grace_seconds": int64(policy.RotationGrace / time.Second),
+		"bind_ip":                policy.BindIP,
+		"actor_id":               policy.UpdatedBy,
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize token policy event", err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.UpsertTokenPolicy(ctx, db.UpsertTokenPolicyParams{
+		TenantID:             policy.TenantID,
+		ClientType:           policy.ClientType,
+		KeyLifetimeSeconds:   int64(policy.KeyLifetime / time.Second),
+		RotationGraceSeconds: int64(policy.RotationGrace / time.Second),
+		BindIp:               policy.BindIP,
+		UpdatedBy:            policy.UpdatedBy,
+		UpdatedAt:            pgtype.Timestamptz{Time: policy.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.TokenPolicyUpdated,
+		AggregateID: policy.ClientType,
+		TenantID:    policy.TenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: policy.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func toTokenPolicy(row db.TokenPolicy) *entities.TokenPolicy {
+	return &entities.TokenPolicy{
+		TenantID:      row.TenantID,
+		ClientType:    row.ClientType,
+		KeyLifetime:   time.Duration(row.KeyLifetimeSeconds) * time.Second,
+		RotationGrace: time.Duration(row.RotationGraceSeconds) * time.Second,
+		BindIP:        row.BindIp,
+		UpdatedBy:     row.UpdatedBy,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+}
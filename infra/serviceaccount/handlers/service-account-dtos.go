@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type ServiceAccountBodyRequest struct {
+	Name        string   `json:"name" minLength:"1" maxLength:"100"`
+	Description string   `json:"description,omitempty" maxLength:"500"`
+	Roles       []string `json:"roles" minItems:"1" doc:"Roles of the policies, held in the tenant like the roles of users"`
+}
+
+type CreateServiceAccountRequest struct {
+	Body struct {
+		ServiceAccountBodyRequest
+		OwnerID    string   `json:"owner_id,omitempty" doc:"Member of the tenant accountable for the account, the caller when omitted"`
+		ClientType string   `json:"client_type,omitempty" enum:"server,web,mobile,kiosk" default:"server" doc:"Picks the token policy the keys of the account are issued under, it cannot be changed"`
+		BoundIP    string   `json:"bound_ip,omitempty" doc:"The only address the first key works from, required when the policy binds keys"`
+		Scopes     []string `json:"scopes,omitempty" doc:"resource:action permissions the first key may use, every permission of the roles when omitted"`
+	}
+}
+
+type UpdateServiceAccountRequest struct {
+	ServiceAccountID string `path:"serviceAccountId" format:"uuid"`
+	Body             struct {
+		ServiceAccountBodyRequest
+		OwnerID string `json:"owner_id" minLength:"1" doc:"Member of the tenant accountable for the account"`
+	}
+}
+
+type ServiceAccountRequest struct {
+	ServiceAccountID string `path:"serviceAccountId" format:"uuid"`
+}
+
+type RotateServiceAccountKeyRequest struct {
+	ServiceAccountID string `path:"serviceAccountId" format:"uuid"`
+	Body             struct {
+		GraceHours *int     `json:"grace_hours,omitempty" minimum:"0" maximum:"168" doc:"How long the replaced keys keep working, the grace of the token policy when omitted and 0 to stop them at once"`
+		BoundIP    string   `json:"bound_ip,omitempty" doc:"The only address the new key works from, the address of the replaced key when omitted"`
+		Scopes     []string `json:"scopes,omitempty" doc:"resource:action permissions the new key may use, every permission of the current roles when omitted"`
+	}
+}
+
+type RevokeServiceAccountKeyRequest struct {
+	ServiceAccountID string `path:"serviceAccountId" format:"uuid"`
+	KeyID            string `path:"keyId" format:"uuid"`
+}
+
+type ServiceAccountKeyResponse struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status" enum:"active,expired,revoked" doc:"Active keys expiring after a rotation are still active"`
+	BoundIP    string     `json:"bound_ip,omitempty"`
+	Scopes     []string   `json:"scopes,omitempty" doc:"Checked with the roles of the account, keys issued before scopes have none"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" doc:"Updated at most once a minute"`
+}
+
+func NewServiceAccountKeyResponse(key *entities.ServiceAccountKey, now time.Time, loc *time.Location) ServiceAccountKeyResponse {
+	status := "active"
+	if key.RevokedAt != nil {
+		status = "revoked"
+	} else if !key.ActiveAt(now) {
+		status = "expired"
+	}
+	return ServiceAccountKeyResponse{
+		ID:         key.ID,
+		Status:     status,
+		BoundIP:    key.BoundIP,
+		Scopes:     key.Scopes,
+		CreatedAt:  utils.InLocation(key.CreatedAt, loc),
+		ExpiresAt:  utils.InLocationPtr(key.ExpiresAt, loc),
+		RevokedAt:  utils.InLocationPtr(key.RevokedAt, loc),
+		LastUsedAt: utils.InLocationPtr(key.LastUsedAt, loc),
+	}
+}
+
+type ServiceAccountResponse struct {
+	ID          string                      `json:"id"`
+	Subject     string                      `json:"subject" doc:"The user ID the account acts as, in audit entries and activity timelines"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description,omitempty"`
+	OwnerID     string                      `json:"owner_id"`
+	CreatedBy   string                      `json:"created_by"`
+	ClientType  string                      `json:"client_type"`
+	Roles       []string                    `json:"roles"`
+	Keys        []ServiceAccountKeyResponse `json:"keys" doc:"Oldest first, secrets are never shown again"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	UpdatedAt   time.Time                   `json:"updated_at"`
+	DisabledAt  *time.Time                  `json:"disabled_at,omitempty"`
+}
+
+func NewServiceAccountResponse(account *entities.ServiceAccount, loc *time.Location) ServiceAccountResponse {
+	now := time.Now()
+	response := ServiceAccountResponse{
+		ID:          account.ID,
+		Subject:     account.Subject(),
+		Name:        account.Name,
+		Description: account.Description,
+		OwnerID:     account.OwnerID,
+		CreatedBy:   account.CreatedBy,
+		ClientType:  account.ClientType,
+		Roles:       account.Roles,
+		Keys:        make([]ServiceAccountKeyResponse, 0, len(account.Keys)),
+		CreatedAt:   utils.InLocation(account.CreatedAt, loc),
+		UpdatedAt:   utils.InLocation(account.UpdatedAt, loc),
+		DisabledAt:  utils.InLocationPtr(account.DisabledAt, loc),
+	}
+	if response.Roles == nil {
+		response.Roles = []string{}
+	}
+	for _, key := range account.Keys {
+		response.Keys = append(response.Keys, NewServiceAccountKeyResponse(key, now, loc))
+	}
+	return response
+}
+
+type ServiceAccountEnvelope struct {
+	Body ServiceAccountResponse
+}
+
+type ServiceAccountsResponse struct {
+	Body struct {
+		ServiceAccounts []ServiceAccountResponse `json:"service_accounts"`
+	}
+}
+
+// IssuedKeyResponse carries the token of a new key, the only response that does
+type IssuedKeyResponse struct {
+	Body struct {
+		ServiceAccount ServiceAccountResponse `json:"service_account"`
+		KeyID          string                 `json:"key_id"`
+		Token          string                 `json:"token" doc:"Sent in the X-Service-Account-Key header. Shown once, store it now."`
+	}
+}
+
+func NewIssuedKeyResponse(account *entities.ServiceAccount, keyID string, token string, loc *time.Location) *IssuedKeyResponse {
+	response := &IssuedKeyResponse{}
+	response.Body.ServiceAccount = NewServiceAccountResponse(account, loc)
+	response.Body.KeyID = keyID
+	response.Body.Token = token
+	return response
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	create_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/create-service-account-use-case"
+	disable_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/disable-service-account-use-case"
+	get_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/get-service-account-use-case"
+	list_service_accounts_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/list-service-accounts-use-case"
+	revoke_service_account_key_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/revoke-service-account-key-use-case"
+	rotate_service_account_key_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/rotate-service-account-key-use-case"
+	update_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/update-service-account-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ServiceAccountHandlers struct {
+	createServiceAccountUseCase    *create_service_account_use_case.CreateServiceAccountUseCase
+	listServiceAccountsUseCase     *list_service_accounts_use_case.ListServiceAccountsUseCase
+	getServiceAccountUseCase       *get_service_account_use_case.GetServiceAccountUseCase
+	updateServiceAccountUseCase    *update_service_account_use_case.UpdateServiceAccountUseCase
+	rotateServiceAccountKeyUseCase *rotate_service_account_key_use_case.RotateServiceAccountKeyUseCase
+	revokeServiceAccountKeyUseCase *revoke_service_account_key_use_case.RevokeServiceAccountKeyUseCase
+	disableServiceAccountUseCase   *disable_service_account_use_case.DisableServiceAccountUseCase
+}
+
+func NewServiceAccountHandlers(
+	createServiceAccountUseCase *create_service_account_use_case.CreateServiceAccountUseCase,
+	listServiceAccountsUseCase *list_service_accounts_use_case.ListServiceAccountsUseCase,
+	getServiceAccountUseCase *get_service_account_use_case.GetServiceAccountUseCase,
+	updateServiceAccountUseCase *update_service_account_use_case.UpdateServiceAccountUseCase,
+	rotateServiceAccountKeyUseCase *rotate_service_account_key_use_case.RotateServiceAccountKeyUseCase,
+	revokeServiceAccountKeyUseCase *revoke_service_account_key_use_case.RevokeServiceAccountKeyUseCase,
+	disableServiceAccountUseCase *disable_service_account_use_case.DisableServiceAccountUseCase,
+) *ServiceAccountHandlers {
+	return &ServiceAccountHandlers{
+		createServiceAccountUseCase:    createServiceAccountUseCase,
+		listServiceAccountsUseCase:     listServiceAccountsUseCase,
+		getServiceAccountUseCase:       getServiceAccountUseCase,
+		updateServiceAccountUseCase:    updateServiceAccountUseCase,
+		rotateServiceAccountKeyUseCase: rotateServiceAccountKeyUseCase,
+		revokeServiceAccountKeyUseCase: revokeServiceAccountKeyUseCase,
+		disableServiceAccountUseCase:   disableServiceAccountUseCase,
+	}
+}
+
+func (h *ServiceAccountHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-service-account",
+		Method:        http.MethodPost,
+		Path:          "/service-accounts",
+		Summary:       "Create a service account",
+		Description:   "Creates a machine identity of the tenant with its roles and a first key. The token of the key is only in this response.",
+		Tags:          []string{"Service Accounts"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateServiceAccount)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-service-accounts",
+		Method:      http.MethodGet,
+		Path:        "/service-accounts",
+		Summary:     "List the service accounts of the tenant",
+		Tags:        []string{"Service Accounts"},
+	}, h.ListServiceAccounts)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-service-account",
+		Method:      http.MethodGet,
+		Path:        "/service-accounts/{serviceAccountId}",
+		Summary:     "Get a service account",
+		Tags:        []string{"Service Accounts"},
+	}, h.GetServiceAccount)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-service-account",
+		Method:      http.MethodPut,
+		Path:        "/service-accounts/{serviceAccountId}",
+		Summary:     "Update a service account",
+		Description: "Replaces the name, description, owner and roles of the account. The owner must hold a role in the tenant.",
+		Tags:        []string{"Service Accounts"},
+	}, h.UpdateServiceAccount)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "rotate-service-account-key",
+		Method:        http.MethodPost,
+		Path:          "/service-accounts/{serviceAccountId}/keys",
+		Summary:       "Rotate the key of a service account",
+		Description:   "Issues a new key under the current token policy of the account, the active keys keep working for the grace period so callers can roll the new one out. The token of the key is only in this response.",
+		Tags:          []string{"Service Accounts"},
+		DefaultStatus: http.StatusCreated,
+	}, h.RotateServiceAccountKey)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-service-account-key",
+		Method:      http.MethodDelete,
+		Path:        "/service-accounts/{serviceAccountId}/keys/{keyId}",
+		Summary:     "Revoke a key of a service account",
+		Tags:        []string{"Service Accounts"},
+	}, h.RevokeServiceAccountKey)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "disable-service-account",
+		Method:      http.MethodPost,
+		Path:        "/service-accounts/{serviceAccountId}/disable",
+		Summary:     "Disable a service account",
+		Description: "Revokes every key of the account and removes its roles. Disabled accounts are kept for the audit log and cannot be enabled again.",
+		Tags:        []string{"Service Accounts"},
+	}, h.DisableServiceAccount)
+}
+
+func (h *ServiceAccountHandlers) CreateServiceAccount(ctx context.Context, input *CreateServiceAccountRequest) (*IssuedKeyResponse, error) {
+	command, err := create_service_account_use_case.NewCreateServiceAccountCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Name,
+		input.Body.Description,
+		input.Body.OwnerID,
+		input.Body.Roles,
+		input.Body.ClientType,
+		input.Body.BoundIP,
+		input.Body.Scopes,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	account, token, err := h.createServiceAccountUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewIssuedKeyResponse(account, account.Keys[0].ID, token, utils.LocationFromContext(ctx)), nil
+}
+
+func (h *ServiceAccountHandlers) ListServiceAccounts(ctx context.Context, input *struct{}) (*ServiceAccountsResponse, error) {
+	command, err := list_service_accounts_use_case.NewListServiceAccountsCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	accounts, err := h.listServiceAccountsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &ServiceAccountsResponse{}
+	response.Body.ServiceAccounts = make([]ServiceAccountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		response.Body.ServiceAccounts = append(response.Body.ServiceAccounts, NewServiceAccountResponse(account, loc))
+	}
+	return response, nil
+}
+
+func (h *ServiceAccountHandlers) GetServiceAccount(ctx context.Context, input *ServiceAccountRequest) (*ServiceAccountEnvelope, error) {
+	command, err := get_service_account_use_case.NewGetServiceAccountCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ServiceAccountID,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	account, err := h.getServiceAccountUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ServiceAccountEnvelope{Body: NewServiceAccountResponse(account, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *ServiceAccountHandlers) UpdateServiceAccount(ctx context.Context, input *UpdateServiceAccountRequest) (*ServiceAccountEnvelope, error) {
+	command, err := update_service_account_use_case.NewUpdateServiceAccountCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ServiceAccountID,
+		authorization.UserIDFromContext(ctx),
+		input.Body.Name,
+		input.Body.Description,
+		input.Body.OwnerID,
+		input.Body.Roles,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	account, err := h.updateServiceAccountUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ServiceAccountEnvelope{Body: NewServiceAccountResponse(account, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *ServiceAccountHandlers) RotateServiceAccountKey(ctx context.Context, input *RotateServiceAccountKeyRequest) (*IssuedKeyResponse, error) {
+	var grace *time.Duration
+	if input.Body.GraceHours != nil {
+		hours := time.Duration(*input.Body.GraceHours) * time.Hour
+		grace = &hours
+	}
+	command, err := rotate_service_account_key_use_case.NewRotateServiceAccountKeyCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ServiceAccountID,
+		authorization.UserIDFromContext(ctx),
+		grace,
+		input.Body.BoundIP,
+		input.Body.Scopes,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	account, key, token, err := h.rotateServiceAccountKeyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewIssuedKeyResponse(account, key.ID, token, utils.LocationFromContext(ctx)), nil
+}
+
+func (h *ServiceAccountHandlers) RevokeServiceAccountKey(ctx context.Context, input *RevokeServiceAccountKeyRequest) (*ServiceAccountEnvelope, error) {
+	command, err := revoke_service_account_key_use_case.NewRevokeServiceAccountKeyCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ServiceAccountID,
+		input.KeyID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	account, err := h.revokeServiceAccountKeyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ServiceAccountEnvelope{Body: NewServiceAccountResponse(account, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *ServiceAccountHandlers) DisableServiceAccount(ctx context.Context, input *ServiceAccountRequest) (*ServiceAccountEnvelope, error) {
+	command, err := disable_service_account_use_case.NewDisableServiceAccountCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ServiceAccountID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	account, err := h.disableServiceAccountUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ServiceAccountEnvelope{Body: NewServiceAccountResponse(account, utils.LocationFromContext(ctx))}, nil
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	authenticate_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/authenticate-service-account-use-case"
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/internalauth"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ServiceAccountKeyHeader carries the token of a service account key
+const ServiceAccountKeyHeader = "X-Service-Account-Key"
+
+// NewServiceAccountMiddleware returns a Huma middleware that authenticates requests sent with a
+// service account key. The request then acts as the account in its tenant, whatever user it claims,
+// so the authorization middleware after it checks the roles of the account and the scopes of the
+// key. Requests claiming to be a service account without its key are refused, unless a peer service
+// vouched for the account with an identity token.
+func NewServiceAccountMiddleware(authenticateUseCase *authenticate_service_account_use_case.AuthenticateServiceAccountUseCase) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		token := ctx.Header(ServiceAccountKeyHeader)
+		meta := requestmeta.FromHuma(ctx)
+		if token == "" {
+			if internalauth.Verified(ctx.Context()) {
+				next(ctx)
+				return
+			}
+			if entities.IsServiceAccountSubject(meta.UserID) || entities.IsServiceAccountSubject(meta.ImpersonatorID) {
+				utils.WriteApplicationError(ctx, appErrors.NewUnauthorizedError("Service accounts authenticate with their key"))
+				return
+			}
+			next(ctx)
+			return
+		}
+
+		command, err := authenticate_service_account_use_case.NewAuthenticateServiceAccountCommand(token, meta.TenantID,
+			utils.ClientIPFromContext(ctx.Context()))
+		if err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+		account, key, err := authenticateUseCase.Execute(ctx.Context(), command)
+		if err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+
+		meta.UserID = account.Subject()
+		meta.TenantID = account.TenantID
+		// Service accounts never act for someone else and have no session
+		meta.ImpersonatorID = ""
+		meta.AuthTime, meta.AuthACR = "", ""
+		ctx = requestmeta.WithHuma(ctx, meta)
+		if len(key.Scopes) > 0 {
+			ctx = authorization.WithScopes(ctx, key.Scopes)
+		}
+		next(ctx)
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+)
+
+// tokenTypeServiceAccountKey is the token_type of service account keys, the only tokens issued
+const tokenTypeServiceAccountKey = "service_account_key"
+
+// TokenFormRequest is an RFC 7662 or RFC 7009 request, a form with the token and an optional
+// token_type_hint that is ignored
+type TokenFormRequest struct {
+	RawBody []byte `contentType:"application/x-www-form-urlencoded"`
+}
+
+type TokenIntrospectionResponse struct {
+	Body struct {
+		Active    bool   `json:"active"`
+		Scope     string `json:"scope,omitempty" doc:"resource:action permissions the token may use, space separated. Roles removed since it was issued still apply."`
+		ClientID  string `json:"client_id,omitempty" doc:"ID of the service account"`
+		Username  string `json:"username,omitempty" doc:"Name of the service account"`
+		TokenType string `json:"token_type,omitempty" enum:"service_account_key"`
+		Exp       int64  `json:"exp,omitempty" doc:"Set once the key was replaced by a rotation"`
+		Iat       int64  `json:"iat,omitempty"`
+		Sub       string `json:"sub,omitempty" doc:"The user ID the token acts as"`
+		Jti       string `json:"jti,omitempty" doc:"ID of the key"`
+		TenantID  string `json:"tenant_id,omitempty"`
+		OwnerID   string `json:"owner_id,omitempty"`
+		BoundIP   string `json:"bound_ip,omitempty" doc:"The only address the token works from, gateways must refuse it from any other"`
+	}
+}
+
+func NewTokenIntrospectionResponse(introspection *entities.TokenIntrospection) *TokenIntrospectionResponse {
+	response := &TokenIntrospectionResponse{}
+	if !introspection.Active {
+		return response
+	}
+
+	account, key := introspection.Account, introspection.Key
+	response.Body.Active = true
+	response.Body.Scope = strings.Join(introspection.Scopes, " ")
+	response.Body.ClientID = account.ID
+	response.Body.Username = account.Name
+	response.Body.TokenType = tokenTypeServiceAccountKey
+	if key.ExpiresAt != nil {
+		response.Body.Exp = key.ExpiresAt.Unix()
+	}
+	response.Body.Iat = key.CreatedAt.Unix()
+	response.Body.Sub = account.Subject()
+	response.Body.Jti = key.ID
+	response.Body.TenantID = account.TenantID
+	response.Body.OwnerID = account.OwnerID
+	response.Body.BoundIP = key.BoundIP
+	return response
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	introspect_token_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/introspect-token-use-case"
+	revoke_token_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/revoke-token-use-case"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// TokenHandlers serve OAuth2 style introspection and revocation of the tokens the API issues, so
+// gateways and sibling services can check them without sharing any secret
+type TokenHandlers struct {
+	introspectTokenUseCase *introspect_token_use_case.IntrospectTokenUseCase
+	revokeTokenUseCase     *revoke_token_use_case.RevokeTokenUseCase
+}
+
+func NewTokenHandlers(
+	introspectTokenUseCase *introspect_token_use_case.IntrospectTokenUseCase,
+	revokeTokenUseCase *revoke_token_use_case.RevokeTokenUseCase,
+) *TokenHandlers {
+	return &TokenHandlers{
+		introspectTokenUseCase: introspectTokenUseCase,
+		revokeTokenUseCase:     revokeTokenUseCase,
+	}
+}
+
+func (h *TokenHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "introspect-token",
+		Method:      http.MethodPost,
+		Path:        "/oauth/introspect",
+		Summary:     "Introspect a token",
+		Description: "Tells whether a token is active and what it acts as, following RFC 7662. Callers authenticate as themselves, usually with the key of a service account granted token:introspect, and only learn about tokens of their own tenant. Every other token is answered with active false.",
+		Tags:        []string{"Tokens"},
+	}, h.IntrospectToken)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-token",
+		Method:      http.MethodPost,
+		Path:        "/oauth/revoke",
+		Summary:     "Revoke a token",
+		Description: "Revokes a token at once, following RFC 7009. Holding the token is enough to revoke it. The answer is the same whether the token was active or not.",
+		Tags:        []string{"Tokens"},
+		// RFC 7009 answers 200, an empty body alone would make it a 204
+		DefaultStatus: http.StatusOK,
+	}, h.RevokeToken)
+}
+
+func (h *TokenHandlers) IntrospectToken(ctx context.Context, input *TokenFormRequest) (*TokenIntrospectionResponse, error) {
+	token, err := tokenFromForm(input.RawBody)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+	command, err := introspect_token_use_case.NewIntrospectTokenCommand(token, authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	introspection, err := h.introspectTokenUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewTokenIntrospectionResponse(introspection), nil
+}
+
+func (h *TokenHandlers) RevokeToken(ctx context.Context, input *TokenFormRequest) (*struct{}, error) {
+	token, err := tokenFromForm(input.RawBody)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+	command, err := revoke_token_use_case.NewRevokeTokenCommand(token)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.revokeTokenUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
+
+// tokenFromForm reads the token parameter of a form encoded body
+func tokenFromForm(body []byte) (string, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return "", appErrors.NewValidationError("Body is not a form", map[string]any{"body": err.Error()}, err)
+	}
+	return form.Get("token"), nil
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type SetTokenPolicyRequest struct {
+	ClientType string `path:"clientType" enum:"server,web,mobile,kiosk"`
+	Body       struct {
+		KeyLifetimeHours   int  `json:"key_lifetime_hours" minimum:"0" maximum:"8760" doc:"How long keys work after they are issued, 0 for keys that never expire"`
+		RotationGraceHours int  `json:"rotation_grace_hours" minimum:"0" maximum:"168" doc:"How long the keys replaced by a rotation keep working when the rotation does not say"`
+		BindIP             bool `json:"bind_ip" doc:"Every key must be bound to the address of the client it is issued for"`
+	}
+}
+
+type TokenPolicyResponse struct {
+	ClientType         string     `json:"client_type"`
+	KeyLifetimeHours   int        `json:"key_lifetime_hours"`
+	RotationGraceHours int        `json:"rotation_grace_hours"`
+	BindIP             bool       `json:"bind_ip"`
+	Default            bool       `json:"default" doc:"The tenant never set the policy of this client type"`
+	UpdatedBy          string     `json:"updated_by,omitempty"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+}
+
+func NewTokenPolicyResponse(policy *entities.TokenPolicy, loc *time.Location) TokenPolicyResponse {
+	response := TokenPolicyResponse{
+		ClientType:         policy.ClientType,
+		KeyLifetimeHours:   int(policy.KeyLifetime / time.Hour),
+		RotationGraceHours: int(policy.RotationGrace / time.Hour),
+		BindIP:             policy.BindIP,
+		Default:            policy.IsDefault(),
+		UpdatedBy:          policy.UpdatedBy,
+	}
+	if !policy.IsDefault() {
+		updatedAt := utils.InLocation(policy.UpdatedAt, loc)
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
+
+type TokenPolicyEnvelope struct {
+	Body TokenPolicyResponse
+}
+
+type TokenPoliciesResponse struct {
+	Body struct {
+		Policies []TokenPolicyResponse `json:"policies" doc:"One per client type"`
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	list_token_policies_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/list-token-policies-use-case"
+	set_token_policy_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/set-token-policy-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// TokenPolicyHandlers let tenants set how the keys of each client type of service account are issued
+type TokenPolicyHandlers struct {
+	listTokenPoliciesUseCase *list_token_policies_use_case.ListTokenPoliciesUseCase
+	setTokenPolicyUseCase    *set_token_policy_use_case.SetTokenPolicyUseCase
+}
+
+func NewTokenPolicyHandlers(
+	listTokenPoliciesUseCase *list_token_policies_use_case.ListTokenPoliciesUseCase,
+	setTokenPolicyUseCase *set_token_policy_use_case.SetTokenPolicyUseCase,
+) *TokenPolicyHandlers {
+	return &TokenPolicyHandlers{
+		listTokenPoliciesUseCase: listTokenPoliciesUseCase,
+		setTokenPolicyUseCase:    setTokenPolicyUseCase,
+	}
+}
+
+func (h *TokenPolicyHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-token-policies",
+		Method:      http.MethodGet,
+		Path:        "/token-policies",
+		Summary:     "List the token policies of the tenant",
+		Description: "Returns how the keys of every client type are issued, the default of the types the tenant did not set.",
+		Tags:        []string{"Service Accounts"},
+	}, h.ListTokenPolicies)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-token-policy",
+		Method:      http.MethodPut,
+		Path:        "/token-policies/{clientType}",
+		Summary:     "Set the token policy of a client type",
+		Description: "Sets the lifetime, rotation grace and address binding of the keys issued from now on to service accounts of the client type. Keys already issued keep their expiry and address.",
+		Tags:        []string{"Service Accounts"},
+	}, h.SetTokenPolicy)
+}
+
+func (h *TokenPolicyHandlers) ListTokenPolicies(ctx context.Context, input *struct{}) (*TokenPoliciesResponse, error) {
+	command, err := list_token_policies_use_case.NewListTokenPoliciesCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	policies, err := h.listTokenPoliciesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &TokenPoliciesResponse{}
+	response.Body.Policies = make([]TokenPolicyResponse, 0, len(policies))
+	for _, policy := range policies {
+		response.Body.Policies = append(response.Body.Policies, NewTokenPolicyResponse(policy, loc))
+	}
+	return response, nil
+}
+
+func (h *TokenPolicyHandlers) SetTokenPolicy(ctx context.Context, input *SetTokenPolicyRequest) (*TokenPolicyEnvelope, error) {
+	command, err := set_token_policy_use_case.NewSetTokenPolicyCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClientType,
+		time.Duration(input.Body.KeyLifetimeHours)*time.Hour,
+		time.Duration(input.Body.RotationGraceHours)*time.Hour,
+		input.Body.BindIP,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	policy, err := h.setTokenPolicyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &TokenPolicyEnvelope{Body: NewTokenPolicyResponse(policy, utils.LocationFromContext(ctx))}, nil
+}
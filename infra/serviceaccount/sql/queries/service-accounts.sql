@@ -0,0 +1,48 @@
+-- name: CreateServiceAccount :exec
+INSERT INTO service_accounts (id, tenant_id, name, description, owner_id, created_by, client_type, created_at, updated_at)
+VALUES (@id, @tenant_id, @name, @description, @owner_id, @created_by, @client_type, @created_at, @updated_at);
+
+-- name: UpdateServiceAccount :exec
+UPDATE service_accounts
+SET name = @name,
+    description = @description,
+    owner_id = @owner_id,
+    updated_at = @updated_at,
+    disabled_at = @disabled_at
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: GetServiceAccount :one
+SELECT *
+FROM service_accounts
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: ListServiceAccounts :many
+SELECT *
+FROM service_accounts
+WHERE tenant_id = @tenant_id
+ORDER BY created_at, id;
+
+-- name: SaveServiceAccountKey :exec
+-- Creates a key or saves its expiry and revocation, the secret, address and scopes of a key never
+-- change
+INSERT INTO service_account_keys (id, service_account_id, tenant_id, secret_hash, bound_ip, scopes, created_at, expires_at, revoked_at)
+VALUES (@id, @service_account_id, @tenant_id, @secret_hash, @bound_ip, @scopes, @created_at, @expires_at, @revoked_at)
+ON CONFLICT (id) DO UPDATE
+SET expires_at = EXCLUDED.expires_at,
+    revoked_at = EXCLUDED.revoked_at;
+
+-- name: ListServiceAccountKeys :many
+SELECT *
+FROM service_account_keys
+WHERE tenant_id = @tenant_id AND service_account_id = ANY(@service_account_ids::uuid[])
+ORDER BY created_at, id;
+
+-- name: GetServiceAccountKey :one
+SELECT *
+FROM service_account_keys
+WHERE id = @id;
+
+-- name: TouchServiceAccountKey :exec
+UPDATE service_account_keys
+SET last_used_at = @last_used_at
+WHERE id = @id;
@@ -0,0 +1,20 @@
+-- name: UpsertTokenPolicy :exec
+INSERT INTO token_policies (tenant_id, client_type, key_lifetime_seconds, rotation_grace_seconds, bind_ip, updated_by, updated_at)
+VALUES (@tenant_id, @client_type, @key_lifetime_seconds, @rotation_grace_seconds, @bind_ip, @updated_by, @updated_at)
+ON CONFLICT (tenant_id, client_type) DO UPDATE
+SET key_lifetime_seconds = EXCLUDED.key_lifetime_seconds,
+    rotation_grace_seconds = EXCLUDED.rotation_grace_seconds,
+    bind_ip = EXCLUDED.bind_ip,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetTokenPolicy :one
+SELECT *
+FROM token_policies
+WHERE tenant_id = @tenant_id AND client_type = @client_type;
+
+-- name: ListTokenPolicies :many
+SELECT *
+FROM token_policies
+WHERE tenant_id = @tenant_id
+ORDER BY client_type;
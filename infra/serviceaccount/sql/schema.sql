@@ -0,0 +1,43 @@
+-- Machine identities of a tenant, their roles are held in Casbin by the subject sa:<id>
+CREATE TABLE service_accounts (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    owner_id VARCHAR(255) NOT NULL,        -- member of the tenant accountable for the account
+    created_by VARCHAR(255) NOT NULL,
+    client_type VARCHAR(16) NOT NULL DEFAULT 'server',  -- server, web, mobile or kiosk, picks the token policy
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    disabled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_service_accounts_tenant ON service_accounts(tenant_id, created_at);
+
+-- Keys of service accounts, only the SHA-256 of the secret is stored
+CREATE TABLE service_account_keys (
+    id UUID PRIMARY KEY,
+    service_account_id UUID NOT NULL REFERENCES service_accounts(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(255) NOT NULL,
+    secret_hash VARCHAR(64) NOT NULL,
+    bound_ip VARCHAR(45) NOT NULL DEFAULT '',  -- the only address the key works from, empty for any
+    scopes TEXT[] NOT NULL DEFAULT '{}',       -- resource:action the key may use, empty for keys issued before scopes
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE,   -- set by the lifetime of the key or by a rotation
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_service_account_keys_account ON service_account_keys(service_account_id);
+
+-- How a tenant issues the keys of each client type, types without a row use the defaults
+CREATE TABLE token_policies (
+    tenant_id VARCHAR(255) NOT NULL,
+    client_type VARCHAR(16) NOT NULL,
+    key_lifetime_seconds BIGINT NOT NULL,    -- 0 for keys that never expire
+    rotation_grace_seconds BIGINT NOT NULL,
+    bind_ip BOOLEAN NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, client_type)
+);
@@ -0,0 +1,35 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/residency"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresClusterRegionStore struct {
+	queries *db.Queries
+}
+
+func NewPostgresClusterRegionStore(dbInstance *pgxpool.Pool) residency.ClusterStore {
+	return &PostgresClusterRegionStore{
+		queries: db.New(dbInstance),
+	}
+}
+
+func (s *PostgresClusterRegionStore) ClaimRegion(ctx context.Context, region string, now time.Time) (string, error) {
+	claimed, err := s.queries.ClaimClusterRegion(ctx, db.ClaimClusterRegionParams{
+		Region:    region,
+		ClaimedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return "", appErrors.PropagateError(err)
+	}
+
+	return claimed, nil
+}
@@ -0,0 +1,101 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+)
+
+// FilesystemStorage keeps files under a root directory, keys are slash separated paths below it
+type FilesystemStorage struct {
+	root string
+}
+
+func NewFilesystemStorage(root string) (storage.FileStorage, error) {
+	absolute, err := filepath.Abs(root)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := os.MkdirAll(absolute, 0o750); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &FilesystemStorage{root: absolute}, nil
+}
+
+// Put writes to a temporary file first so readers never see a partial file
+func (s *FilesystemStorage) Put(ctx context.Context, key string, content []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return appErrors.PropagateError(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (s *FilesystemStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return content, nil
+}
+
+func (s *FilesystemStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+// path resolves key below the root, keys escaping it are rejected
+func (s *FilesystemStorage) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if key == "" || !strings.HasPrefix(path, s.root+string(filepath.Separator)) {
+		return "", appErrors.NewInfrastructureError(fmt.Sprintf("invalid storage key %q", key), nil)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,57 @@
+package adapters
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/nahualventure/class-backend/core/app/shared/encryption"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// MasterKeyWrapper wraps tenant data keys with a master key read from the secret provider at
+// startup. Deployments with a KMS replace it with an adapter that keeps the master key in the KMS.
+type MasterKeyWrapper struct {
+	aead cipher.AEAD
+}
+
+// NewMasterKeyWrapper takes the master key as base64 of 32 random bytes (openssl rand -base64 32)
+func NewMasterKeyWrapper(encoded string) (encryption.KeyWrapper, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("master key must be base64 of 32 bytes")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MasterKeyWrapper{aead: aead}, nil
+}
+
+func (w *MasterKeyWrapper) Wrap(ctx context.Context, tenantID string, key []byte) ([]byte, error) {
+	nonce := make([]byte, w.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, appErrors.NewInfrastructureError("generate nonce", err)
+	}
+	return w.aead.Seal(nonce, nonce, key, []byte(tenantID)), nil
+}
+
+func (w *MasterKeyWrapper) Unwrap(ctx context.Context, tenantID string, wrapped []byte) ([]byte, error) {
+	if len(wrapped) < w.aead.NonceSize() {
+		return nil, appErrors.NewInfrastructureError("unwrap tenant key", nil)
+	}
+	nonceSize := w.aead.NonceSize()
+	key, err := w.aead.Open(nil, wrapped[:nonceSize], wrapped[nonceSize:], []byte(tenantID))
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("unwrap tenant key", err)
+	}
+	return key, nil
+}
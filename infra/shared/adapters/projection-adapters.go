@@ -0,0 +1,89 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/app/shared/projection"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresOutboxEventLog struct {
+	queries *db.Queries
+}
+
+func NewPostgresOutboxEventLog(dbInstance *pgxpool.Pool) projection.EventLog {
+	return &PostgresOutboxEventLog{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (l *PostgresOutboxEventLog) ReadAfter(ctx context.Context, position int64, limit int) ([]events.Event, error) {
+	rows, err := l.queries.ListOutboxEventsAfter(ctx, db.ListOutboxEventsAfterParams{
+		Position:  position,
+		BatchSize: int32(limit),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	batch := make([]events.Event, 0, len(rows))
+	for _, row := range rows {
+		payload := make(map[string]any)
+		if err := json.Unmarshal(row.Payload, &payload); err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to deserialize outbox payload", err)
+		}
+
+		batch = append(batch, events.Event{
+			Position:    row.Position,
+			Type:        row.EventType,
+			AggregateID: row.AggregateID,
+			TenantID:    row.TenantID,
+			Payload:     payload,
+			OccurredAt:  row.OccurredAt.Time,
+		})
+	}
+
+	return batch, nil
+}
+
+type PostgresCheckpointStore struct {
+	queries *db.Queries
+}
+
+func NewPostgresCheckpointStore(dbInstance *pgxpool.Pool) projection.CheckpointStore {
+	return &PostgresCheckpointStore{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresCheckpointStore) Load(ctx context.Context, projector string) (int64, error) {
+	position, err := s.queries.GetProjectionCheckpoint(ctx, projector)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, appErrors.PropagateError(err)
+	}
+
+	return position, nil
+}
+
+func (s *PostgresCheckpointStore) Save(ctx context.Context, projector string, position int64) error {
+	err := s.queries.UpsertProjectionCheckpoint(ctx, db.UpsertProjectionCheckpointParams{
+		ProjectionName: projector,
+		Position:       position,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
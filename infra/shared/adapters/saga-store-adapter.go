@@ -0,0 +1,101 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/saga"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSagaStore struct {
+	queries *db.Queries
+}
+
+func NewPostgresSagaStore(dbInstance *pgxpool.Pool) saga.Store {
+	return &PostgresSagaStore{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresSagaStore) Save(ctx context.Context, instance *saga.Instance, owner string, leaseUntil time.Time) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(instance.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	data, err := json.Marshal(instance.Data)
+	if err != nil {
+		return false, appErrors.NewInfrastructureError("failed to serialize saga data", err)
+	}
+
+	var failureReason *string
+	if instance.FailureReason != "" {
+		failureReason = &instance.FailureReason
+	}
+
+	affected, err := s.queries.UpsertSagaInstance(ctx, db.UpsertSagaInstanceParams{
+		ID:             pgUUID,
+		SagaName:       instance.SagaName,
+		Status:         string(instance.Status),
+		CompletedSteps: int32(instance.CompletedSteps),
+		Data:           data,
+		FailureReason:  failureReason,
+		Owner:          owner,
+		LeaseUntil:     pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+		CreatedAt:      pgtype.Timestamptz{Time: instance.CreatedAt, Valid: true},
+		UpdatedAt:      pgtype.Timestamptz{Time: instance.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return affected > 0, nil
+}
+
+func (s *PostgresSagaStore) ClaimUnfinished(ctx context.Context, owner string, now time.Time, leaseUntil time.Time) ([]*saga.Instance, error) {
+	rows, err := s.queries.ClaimUnfinishedSagaInstances(ctx, db.ClaimUnfinishedSagaInstancesParams{
+		Owner:      owner,
+		LeaseUntil: pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	// UPDATE ... RETURNING does not keep the order of the subquery
+	sort.Slice(rows, func(i, j int) bool { return rows[i].CreatedAt.Time.Before(rows[j].CreatedAt.Time) })
+
+	instances := make([]*saga.Instance, 0, len(rows))
+	for _, row := range rows {
+		data := make(map[string]any)
+		if err := json.Unmarshal(row.Data, &data); err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to deserialize saga data", err)
+		}
+
+		failureReason := ""
+		if row.FailureReason != nil {
+			failureReason = *row.FailureReason
+		}
+
+		instances = append(instances, &saga.Instance{
+			ID:             row.ID.String(),
+			SagaName:       row.SagaName,
+			Status:         saga.Status(row.Status),
+			CompletedSteps: int(row.CompletedSteps),
+			Data:           data,
+			FailureReason:  failureReason,
+			CreatedAt:      row.CreatedAt.Time,
+			UpdatedAt:      row.UpdatedAt.Time,
+		})
+	}
+
+	return instances, nil
+}
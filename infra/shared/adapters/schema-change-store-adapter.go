@@ -0,0 +1,72 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/schemachange"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSchemaChangeStore struct {
+	queries *db.Queries
+}
+
+func NewPostgresSchemaChangeStore(dbInstance *pgxpool.Pool) schemachange.Store {
+	return &PostgresSchemaChangeStore{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresSchemaChangeStore) Find(ctx context.Context, name string) (*schemachange.State, error) {
+	row, err := s.queries.GetSchemaChange(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	state := &schemachange.State{
+		Name:           row.Name,
+		Phase:          schemachange.Phase(row.Phase),
+		Cursor:         row.BackfillCursor,
+		Backfilled:     row.Backfilled,
+		Total:          row.Total,
+		PhaseChangedAt: row.PhaseChangedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}
+	if row.BackfilledAt.Valid {
+		state.BackfilledAt = &row.BackfilledAt.Time
+	}
+	return state, nil
+}
+
+func (s *PostgresSchemaChangeStore) Save(ctx context.Context, state *schemachange.State) error {
+	var backfilledAt pgtype.Timestamptz
+	if state.BackfilledAt != nil {
+		backfilledAt = pgtype.Timestamptz{Time: *state.BackfilledAt, Valid: true}
+	}
+
+	err := s.queries.SaveSchemaChange(ctx, db.SaveSchemaChangeParams{
+		Name:           state.Name,
+		Phase:          string(state.Phase),
+		BackfillCursor: state.Cursor,
+		Backfilled:     state.Backfilled,
+		Total:          state.Total,
+		PhaseChangedAt: pgtype.Timestamptz{Time: state.PhaseChangedAt, Valid: true},
+		BackfilledAt:   backfilledAt,
+		UpdatedAt:      pgtype.Timestamptz{Time: state.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSignatureStore struct {
+	queries *db.Queries
+}
+
+func NewPostgresSignatureStore(dbInstance *pgxpool.Pool) authorization.SignatureStore {
+	return &PostgresSignatureStore{
+		queries: db.New(dbInstance),
+	}
+}
+
+func (s *PostgresSignatureStore) Claim(ctx context.Context, signature string, expiresAt time.Time) (bool, error) {
+	inserted, err := s.queries.ClaimRequestSignature(ctx, db.ClaimRequestSignatureParams{
+		Signature: signature,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return inserted == 1, nil
+}
+
+func (s *PostgresSignatureStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	deleted, err := s.queries.DeleteExpiredRequestSignatures(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	return deleted, nil
+}
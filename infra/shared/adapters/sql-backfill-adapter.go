@@ -0,0 +1,52 @@
+package adapters
+
+import (
+	"context"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/schemachange"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SQLBackfill backfills a change with two statements. countSQL returns the rows to copy. batchSQL gets
+// the cursor ($1, empty before the first batch) and the batch size ($2), copies the next rows in key order
+// and returns one row with how many it copied and the key of the last one as text, for example:
+//
+//	WITH batch AS (
+//	    SELECT id FROM users WHERE $1 = '' OR id > $1::uuid ORDER BY id LIMIT $2
+//	), copied AS (
+//	    UPDATE users u SET tenant_id = ... FROM batch WHERE u.id = batch.id AND u.tenant_id IS NULL
+//	)
+//	SELECT count(*)::int, max(id)::text FROM batch
+//
+// Keys are compared by the query itself, so the cursor keeps the ordering of the key column.
+type SQLBackfill struct {
+	db       *pgxpool.Pool
+	countSQL string
+	batchSQL string
+}
+
+func NewSQLBackfill(dbInstance *pgxpool.Pool, countSQL string, batchSQL string) schemachange.Backfill {
+	return &SQLBackfill{db: dbInstance, countSQL: countSQL, batchSQL: batchSQL}
+}
+
+func (b *SQLBackfill) Total(ctx context.Context) (int64, error) {
+	var total int64
+	if err := b.db.QueryRow(ctx, b.countSQL).Scan(&total); err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	return total, nil
+}
+
+func (b *SQLBackfill) Batch(ctx context.Context, cursor string, limit int) (string, int, error) {
+	var copied int
+	var last *string
+	if err := b.db.QueryRow(ctx, b.batchSQL, cursor, limit).Scan(&copied, &last); err != nil {
+		return "", 0, appErrors.PropagateError(err)
+	}
+	if last == nil {
+		return cursor, copied, nil
+	}
+	return *last, copied, nil
+}
@@ -0,0 +1,77 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/encryption"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTenantKeyStore struct {
+	queries *db.Queries
+}
+
+func NewPostgresTenantKeyStore(dbInstance *pgxpool.Pool) encryption.TenantKeyStore {
+	return &PostgresTenantKeyStore{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresTenantKeyStore) Find(ctx context.Context, tenantID string) (*encryption.TenantKey, error) {
+	row, err := s.queries.GetTenantKey(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	key := &encryption.TenantKey{
+		TenantID:   row.TenantID,
+		WrappedKey: row.WrappedKey,
+		CreatedAt:  row.CreatedAt.Time,
+	}
+	if row.DestroyedAt.Valid {
+		key.DestroyedAt = &row.DestroyedAt.Time
+	}
+	return key, nil
+}
+
+func (s *PostgresTenantKeyStore) Create(ctx context.Context, key *encryption.TenantKey) error {
+	var destroyedAt pgtype.Timestamptz
+	if key.DestroyedAt != nil {
+		destroyedAt = pgtype.Timestamptz{Time: *key.DestroyedAt, Valid: true}
+	}
+
+	err := s.queries.CreateTenantKey(ctx, db.CreateTenantKeyParams{
+		TenantID:    key.TenantID,
+		WrappedKey:  key.WrappedKey,
+		CreatedAt:   pgtype.Timestamptz{Time: key.CreatedAt, Valid: true},
+		DestroyedAt: destroyedAt,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (s *PostgresTenantKeyStore) Destroy(ctx context.Context, tenantID string, destroyedAt time.Time) error {
+	err := s.queries.DestroyTenantKey(ctx, db.DestroyTenantKeyParams{
+		TenantID:    tenantID,
+		DestroyedAt: pgtype.Timestamptz{Time: destroyedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
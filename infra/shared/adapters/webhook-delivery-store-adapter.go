@@ -0,0 +1,130 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/webhook"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWebhookDeliveryStore struct {
+	queries *db.Queries
+}
+
+func NewPostgresWebhookDeliveryStore(dbInstance *pgxpool.Pool) webhook.Store {
+	return &PostgresWebhookDeliveryStore{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresWebhookDeliveryStore) Create(ctx context.Context, delivery *webhook.Delivery) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(delivery.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	headers, err := json.Marshal(delivery.Request.Headers)
+	if err != nil {
+		return false, appErrors.NewInfrastructureError("failed to serialize webhook headers", err)
+	}
+
+	affected, err := s.queries.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+		ID:            pgUUID,
+		Source:        delivery.Source,
+		EventID:       delivery.EventID,
+		Payload:       delivery.Request.Payload,
+		Headers:       headers,
+		Status:        string(delivery.Status),
+		NextAttemptAt: pgtype.Timestamptz{Time: delivery.NextAttemptAt, Valid: true},
+		ReceivedAt:    pgtype.Timestamptz{Time: delivery.ReceivedAt, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return affected > 0, nil
+}
+
+func (s *PostgresWebhookDeliveryStore) ClaimDue(ctx context.Context, sources []string, now time.Time, leaseUntil time.Time, limit int) ([]*webhook.Delivery, error) {
+	rows, err := s.queries.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil:    pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+		Now:           pgtype.Timestamptz{Time: now, Valid: true},
+		Sources:       sources,
+		MaxDeliveries: int32(limit),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	// UPDATE ... RETURNING does not keep the order of the subquery
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ReceivedAt.Time.Before(rows[j].ReceivedAt.Time) })
+
+	deliveries := make([]*webhook.Delivery, 0, len(rows))
+	for _, row := range rows {
+		headers := make(map[string]string)
+		if err := json.Unmarshal(row.Headers, &headers); err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to deserialize webhook headers", err)
+		}
+
+		delivery := &webhook.Delivery{
+			ID:            row.ID.String(),
+			Source:        row.Source,
+			EventID:       row.EventID,
+			Request:       webhook.Request{Payload: row.Payload, Headers: headers},
+			Status:        webhook.Status(row.Status),
+			Attempts:      int(row.Attempts),
+			NextAttemptAt: row.NextAttemptAt.Time,
+			ReceivedAt:    row.ReceivedAt.Time,
+		}
+		if row.LastError != nil {
+			delivery.LastError = *row.LastError
+		}
+		if row.ProcessedAt.Valid {
+			delivery.ProcessedAt = &row.ProcessedAt.Time
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (s *PostgresWebhookDeliveryStore) Save(ctx context.Context, delivery *webhook.Delivery) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(delivery.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	var lastError *string
+	if delivery.LastError != "" {
+		lastError = &delivery.LastError
+	}
+	var processedAt pgtype.Timestamptz
+	if delivery.ProcessedAt != nil {
+		processedAt = pgtype.Timestamptz{Time: *delivery.ProcessedAt, Valid: true}
+	}
+
+	err := s.queries.SaveWebhookDeliveryAttempt(ctx, db.SaveWebhookDeliveryAttemptParams{
+		ID:            pgUUID,
+		Status:        string(delivery.Status),
+		LastError:     lastError,
+		NextAttemptAt: pgtype.Timestamptz{Time: delivery.NextAttemptAt, Valid: true},
+		ProcessedAt:   processedAt,
+	})
+	return appErrors.PropagateError(err)
+}
+
+func (s *PostgresWebhookDeliveryStore) DeleteProcessedBefore(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := s.queries.DeleteProcessedWebhookDeliveries(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	return int(deleted), nil
+}
@@ -0,0 +1,224 @@
+package apichangelog
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/nahualventure/class-backend/infra/shared/clientversion"
+)
+
+// Version is the version of the API this binary serves. Record its surface with "api-snapshot"
+// before bumping it, the changelog of the next version is the difference with that snapshot.
+const Version = "1.0.0"
+
+// SnapshotDir is where "api-snapshot" writes the surface of a version, relative to the repository
+const SnapshotDir = "infra/shared/apichangelog/versions"
+
+// snapshots are the surfaces of the versions recorded so far
+//
+//go:embed versions
+var snapshots embed.FS
+
+// Change kinds, a change is breaking when clients built for the previous version may stop working
+const (
+	ChangeAdded      = "added"
+	ChangeRemoved    = "removed"
+	ChangeChanged    = "changed"
+	ChangeDeprecated = "deprecated"
+)
+
+// Change is a difference between two versions of an endpoint, parameter, schema, message or field
+type Change struct {
+	Kind string `json:"kind" enum:"added,removed,changed,deprecated"`
+	// Target is what changed: endpoint, parameter, schema, field, message or message_field
+	Target string `json:"target" enum:"endpoint,parameter,schema,field,message,message_field"`
+	// Name is "GET /path" for endpoints, "GET /path query limit" for parameters, the schema or
+	// message name, and "Schema.field" for fields
+	Name     string `json:"name"`
+	Detail   string `json:"detail,omitempty"`
+	Breaking bool   `json:"breaking"`
+}
+
+// Release is what changed in a version since the one before it
+type Release struct {
+	Version string `json:"version"`
+	// Previous is empty for the first version recorded, which has no changes
+	Previous string   `json:"previous,omitempty"`
+	Breaking bool     `json:"breaking"`
+	Changes  []Change `json:"changes"`
+}
+
+// Diff lists the changes from one surface to the next, endpoints first, then schemas and messages
+func Diff(from *Surface, to *Surface) []Change {
+	changes := []Change{}
+
+	before := make(map[string]Operation, len(from.Operations))
+	for _, operation := range from.Operations {
+		before[operation.Endpoint()] = operation
+	}
+	after := make(map[string]bool, len(to.Operations))
+	for _, operation := range to.Operations {
+		after[operation.Endpoint()] = true
+		previous, existed := before[operation.Endpoint()]
+		if !existed {
+			changes = append(changes, Change{Kind: ChangeAdded, Target: "endpoint", Name: operation.Endpoint(), Detail: operation.ID})
+			continue
+		}
+		if operation.Deprecated && !previous.Deprecated {
+			changes = append(changes, Change{Kind: ChangeDeprecated, Target: "endpoint", Name: operation.Endpoint(), Detail: operation.ID})
+		}
+		changes = append(changes, diffFields("parameter", operation.Endpoint()+" ", previous.Parameters, operation.Parameters)...)
+	}
+	for _, operation := range from.Operations {
+		if !after[operation.Endpoint()] {
+			changes = append(changes, Change{Kind: ChangeRemoved, Target: "endpoint", Name: operation.Endpoint(), Detail: operation.ID, Breaking: true})
+		}
+	}
+
+	changes = append(changes, diffTypes("schema", "field", from.Schemas, to.Schemas)...)
+	changes = append(changes, diffTypes("message", "message_field", from.Messages, to.Messages)...)
+	return changes
+}
+
+func diffTypes(target string, fieldTarget string, from map[string][]Field, to map[string][]Field) []Change {
+	var changes []Change
+	for _, name := range sortedNames(from, to) {
+		fields, existed := from[name]
+		newFields, exists := to[name]
+		switch {
+		case !existed:
+			changes = append(changes, Change{Kind: ChangeAdded, Target: target, Name: name})
+		case !exists:
+			changes = append(changes, Change{Kind: ChangeRemoved, Target: target, Name: name, Breaking: true})
+		default:
+			changes = append(changes, diffFields(fieldTarget, name+".", fields, newFields)...)
+		}
+	}
+	return changes
+}
+
+// diffFields compares fields by name. Removing a field, changing its type or adding a required one
+// breaks clients, adding an optional one does not.
+func diffFields(target string, prefix string, from []Field, to []Field) []Change {
+	var changes []Change
+	for _, field := range to {
+		index := slices.IndexFunc(from, func(previous Field) bool { return previous.Name == field.Name })
+		switch {
+		case index < 0:
+			detail := field.Type
+			if field.Required {
+				detail += ", required"
+			}
+			changes = append(changes, Change{Kind: ChangeAdded, Target: target, Name: prefix + field.Name, Detail: detail,
+				Breaking: field.Required && target == "parameter"})
+		case from[index].Type != field.Type:
+			changes = append(changes, Change{Kind: ChangeChanged, Target: target, Name: prefix + field.Name,
+				Detail: from[index].Type + " to " + field.Type, Breaking: true})
+		case from[index].Required != field.Required:
+			detail := "now optional"
+			if field.Required {
+				detail = "now required"
+			}
+			changes = append(changes, Change{Kind: ChangeChanged, Target: target, Name: prefix + field.Name, Detail: detail,
+				Breaking: field.Required && target == "parameter"})
+		}
+	}
+	for _, field := range from {
+		if !slices.ContainsFunc(to, func(current Field) bool { return current.Name == field.Name }) {
+			changes = append(changes, Change{Kind: ChangeRemoved, Target: target, Name: prefix + field.Name, Detail: field.Type, Breaking: true})
+		}
+	}
+	return changes
+}
+
+func sortedNames(from map[string][]Field, to map[string][]Field) []string {
+	names := make([]string, 0, len(from)+len(to))
+	for name := range from {
+		names = append(names, name)
+	}
+	for name := range to {
+		if _, seen := from[name]; !seen {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// LoadSnapshots reads the surfaces recorded in dir of fsys, ordered from the oldest version
+func LoadSnapshots(fsys fs.FS, dir string) ([]*Surface, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	var surfaces []*Surface
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		surface := &Surface{}
+		if err := json.Unmarshal(data, surface); err != nil {
+			return nil, fmt.Errorf("snapshot %s: %w", entry.Name(), err)
+		}
+		if _, err := clientversion.Parse(surface.Version); err != nil {
+			return nil, fmt.Errorf("snapshot %s: %w", entry.Name(), err)
+		}
+		surfaces = append(surfaces, surface)
+	}
+	slices.SortFunc(surfaces, func(a, b *Surface) int { return compareVersions(a.Version, b.Version) })
+	return surfaces, nil
+}
+
+// EmbeddedSnapshots reads the surfaces recorded in the binary
+func EmbeddedSnapshots() ([]*Surface, error) {
+	return LoadSnapshots(snapshots, "versions")
+}
+
+// BuildChangelog lists the releases from the newest, current being the surface served. The snapshot
+// of the current version, when recorded, is replaced by what is served so the changelog never lags
+// the binary.
+func BuildChangelog(recorded []*Surface, current *Surface) []Release {
+	surfaces := make([]*Surface, 0, len(recorded)+1)
+	for _, surface := range recorded {
+		if compareVersions(surface.Version, current.Version) < 0 {
+			surfaces = append(surfaces, surface)
+		}
+	}
+	surfaces = append(surfaces, current)
+
+	releases := make([]Release, 0, len(surfaces))
+	for i := len(surfaces) - 1; i >= 0; i-- {
+		release := Release{Version: surfaces[i].Version, Changes: []Change{}}
+		if i > 0 {
+			release.Previous = surfaces[i-1].Version
+			release.Changes = Diff(surfaces[i-1], surfaces[i])
+			release.Breaking = slices.ContainsFunc(release.Changes, func(change Change) bool { return change.Breaking })
+		}
+		releases = append(releases, release)
+	}
+	return releases
+}
+
+// compareVersions orders "<major>.<minor>.<patch>" versions, unparsable ones first
+func compareVersions(a string, b string) int {
+	versionA, errA := clientversion.Parse(a)
+	versionB, errB := clientversion.Parse(b)
+	switch {
+	case errA != nil || errB != nil:
+		return strings.Compare(a, b)
+	case versionA.Less(versionB):
+		return -1
+	case versionB.Less(versionA):
+		return 1
+	}
+	return 0
+}
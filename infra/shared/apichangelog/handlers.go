@@ -0,0 +1,104 @@
+package apichangelog
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/clientversion"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+type ChangelogRequest struct {
+	Since string `query:"since" doc:"Only list the versions released after this one, e.g. 1.2.0"`
+}
+
+type ChangelogResponse struct {
+	Body struct {
+		Current  string    `json:"current" doc:"Version of the API this instance serves"`
+		Releases []Release `json:"releases" doc:"Changes of every version, newest first"`
+	}
+}
+
+// Changelog serves the changes between the versions of the API. The surface of the current version
+// is read from the spec once every route is registered, on the first request.
+type Changelog struct {
+	api huma.API
+
+	once     sync.Once
+	releases []Release
+}
+
+func NewChangelog(api huma.API) *Changelog {
+	return &Changelog{api: api}
+}
+
+// Surface reads what the API serves now
+func (c *Changelog) Surface() *Surface {
+	return NewSurface(Version, c.api.OpenAPI(), protoregistry.GlobalFiles)
+}
+
+// Releases returns the releases from the newest, built once. Snapshots that cannot be read leave the
+// current version alone in the changelog.
+func (c *Changelog) Releases() []Release {
+	c.once.Do(func() {
+		recorded, err := EmbeddedSnapshots()
+		if err != nil {
+			log.Printf("Failed to read the API snapshots, the changelog only lists %s: %v", Version, err)
+		}
+		c.releases = BuildChangelog(recorded, c.Surface())
+	})
+	return c.releases
+}
+
+// WriteSnapshot records the surface served now as the snapshot of the current version under dir
+func (c *Changelog) WriteSnapshot(dir string) (string, error) {
+	data, err := json.MarshalIndent(c.Surface(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, Version+".json")
+	return file, os.WriteFile(file, append(data, '\n'), 0o644)
+}
+
+func (c *Changelog) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-api-changelog",
+		Method:      http.MethodGet,
+		Path:        "/api/changelog",
+		Summary:     "List the changes of every version of the API",
+		Description: "Endpoints, parameters, schema fields and proto message fields added, removed or changed by every version, compared with the version before it. Breaking changes are flagged.",
+		Tags:        []string{"API"},
+	}, c.GetChangelog)
+}
+
+func (c *Changelog) GetChangelog(ctx context.Context, input *ChangelogRequest) (*ChangelogResponse, error) {
+	releases := c.Releases()
+	if input.Since != "" {
+		since, err := clientversion.Parse(input.Since)
+		if err != nil || since.Platform != "" {
+			return nil, utils.ApplicationErrorToHumaError(appErrors.NewValidationError("Version not valid",
+				map[string]any{"since": "not <major>.<minor>.<patch>"}, nil))
+		}
+		kept := []Release{}
+		for _, release := range releases {
+			if compareVersions(release.Version, since.Number()) > 0 {
+				kept = append(kept, release)
+			}
+		}
+		releases = kept
+	}
+
+	response := &ChangelogResponse{}
+	response.Body.Current = Version
+	response.Body.Releases = releases
+	return response, nil
+}
@@ -0,0 +1,167 @@
+package apichangelog
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// ProtoPackagePrefix selects the proto packages of the API among the ones linked into the binary
+const ProtoPackagePrefix = "class."
+
+// Surface is what clients of a version of the API depend on: the endpoints with their parameters,
+// the fields of the JSON schemas and the fields of the proto messages
+type Surface struct {
+	Version    string             `json:"version"`
+	Operations []Operation        `json:"operations"`
+	Schemas    map[string][]Field `json:"schemas"`
+	Messages   map[string][]Field `json:"messages"`
+}
+
+// Operation is an endpoint, identified by its method and path
+type Operation struct {
+	ID         string  `json:"id"`
+	Method     string  `json:"method"`
+	Path       string  `json:"path"`
+	Parameters []Field `json:"parameters,omitempty"`
+	Deprecated bool    `json:"deprecated,omitempty"`
+}
+
+// Endpoint is the method and path of the operation, "GET /sandbox"
+func (o Operation) Endpoint() string {
+	return o.Method + " " + o.Path
+}
+
+// Field is a property of a schema, a field of a message or a parameter of an operation. Parameters
+// are named "<in> <name>" such as "query limit".
+type Field struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// NewSurface reads the surface of the API served with oapi and of the proto packages of files
+func NewSurface(version string, oapi *huma.OpenAPI, files *protoregistry.Files) *Surface {
+	surface := &Surface{
+		Version:  version,
+		Schemas:  map[string][]Field{},
+		Messages: map[string][]Field{},
+	}
+	addOperations(surface, oapi)
+	if oapi.Components != nil && oapi.Components.Schemas != nil {
+		for name, schema := range oapi.Components.Schemas.Map() {
+			surface.Schemas[name] = schemaFields(schema)
+		}
+	}
+	files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		if strings.HasPrefix(string(file.Package()), ProtoPackagePrefix) {
+			addMessages(surface, file.Messages())
+		}
+		return true
+	})
+	return surface
+}
+
+func addOperations(surface *Surface, oapi *huma.OpenAPI) {
+	for path, item := range oapi.Paths {
+		for method, op := range map[string]*huma.Operation{
+			http.MethodGet: item.Get, http.MethodPut: item.Put, http.MethodPost: item.Post,
+			http.MethodDelete: item.Delete, http.MethodPatch: item.Patch,
+		} {
+			if op == nil {
+				continue
+			}
+			operation := Operation{ID: op.OperationID, Method: method, Path: path, Deprecated: op.Deprecated}
+			for _, param := range op.Parameters {
+				operation.Parameters = append(operation.Parameters, Field{
+					Name:     param.In + " " + param.Name,
+					Type:     typeOf(param.Schema),
+					Required: param.Required,
+				})
+			}
+			sortFields(operation.Parameters)
+			surface.Operations = append(surface.Operations, operation)
+		}
+	}
+	slices.SortFunc(surface.Operations, func(a, b Operation) int {
+		return strings.Compare(a.Endpoint(), b.Endpoint())
+	})
+}
+
+func schemaFields(schema *huma.Schema) []Field {
+	fields := make([]Field, 0, len(schema.Properties))
+	for name, property := range schema.Properties {
+		fields = append(fields, Field{Name: name, Type: typeOf(property), Required: slices.Contains(schema.Required, name)})
+	}
+	sortFields(fields)
+	return fields
+}
+
+// typeOf describes a schema in a word or two: "string", "date-time", "[]integer", "TenantSandbox"
+func typeOf(schema *huma.Schema) string {
+	switch {
+	case schema == nil:
+		return ""
+	case schema.Ref != "":
+		return schema.Ref[strings.LastIndex(schema.Ref, "/")+1:]
+	case schema.Type == huma.TypeArray:
+		return "[]" + typeOf(schema.Items)
+	case schema.Format != "":
+		return schema.Format
+	case schema.Type == "" && len(schema.OneOf) > 0:
+		types := make([]string, 0, len(schema.OneOf))
+		for _, option := range schema.OneOf {
+			types = append(types, typeOf(option))
+		}
+		return strings.Join(types, "|")
+	}
+	return schema.Type
+}
+
+func addMessages(surface *Surface, messages protoreflect.MessageDescriptors) {
+	for i := 0; i < messages.Len(); i++ {
+		message := messages.Get(i)
+		if message.IsMapEntry() {
+			continue
+		}
+		descriptors := message.Fields()
+		fields := make([]Field, 0, descriptors.Len())
+		for j := 0; j < descriptors.Len(); j++ {
+			fields = append(fields, Field{Name: string(descriptors.Get(j).Name()), Type: protoType(descriptors.Get(j))})
+		}
+		sortFields(fields)
+		surface.Messages[string(message.FullName())] = fields
+		addMessages(surface, message.Messages())
+	}
+}
+
+// protoType describes a field with its number, renumbering a field breaks the wire format as much as
+// renaming it breaks JSON: "3 bool", "5 repeated class.common.v1.FieldViolation"
+func protoType(field protoreflect.FieldDescriptor) string {
+	kind := kindOf(field)
+	if field.IsList() {
+		kind = "repeated " + kind
+	}
+	return fmt.Sprintf("%d %s", field.Number(), kind)
+}
+
+func kindOf(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.IsMap():
+		return "map<" + kindOf(field.MapKey()) + ", " + kindOf(field.MapValue()) + ">"
+	case field.Message() != nil:
+		return string(field.Message().FullName())
+	case field.Enum() != nil:
+		return string(field.Enum().FullName())
+	}
+	return field.Kind().String()
+}
+
+func sortFields(fields []Field) {
+	slices.SortFunc(fields, func(a, b Field) int { return strings.Compare(a.Name, b.Name) })
+}
@@ -1,36 +1,87 @@
 package authorization
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"io/fs"
 	"log"
+	"slices"
+	"sync"
+	"time"
 
+	serviceAccountEntities "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
 	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
 
 	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
 	_ "github.com/lib/pq"
 )
 
+// roleAssignmentChunkSize is how many role assignments AssignRoleToSubjects writes per statement
+const roleAssignmentChunkSize = 1000
+
+// roleStore persists the role assignments, casbin_rule in production and memory in tests
+type roleStore interface {
+	persist.Adapter
+	Ping(ctx context.Context) error
+	CountPolicyRows(ctx context.Context) (int, error)
+	AddRoleAssignments(ctx context.Context, subjects []string, role string, tenantID string, chunkSize int,
+		progress func(processed int, assigned int)) (int, error)
+	CopyRoleAssignments(ctx context.Context, tenantID string, toTenantID string, excluded []string, skipPrefix string) ([]string, int, error)
+	RemoveRoleAssignmentPairs(ctx context.Context, subjects []string, roles []string, tenantID string) (int, error)
+	AddRoleAssignmentPairs(ctx context.Context, subjects []string, roles []string, tenantID string) (int, error)
+	RemoveTenantRoleAssignments(ctx context.Context, tenantID string) ([]string, error)
+}
+
 // CasbinService provides authorization functionality using Casbin
 type CasbinService struct {
-	enforcer     *casbin.Enforcer
-	adapter      *RoleOnlyPostgresAdapter
+	// mu guards the enforcer and the policies it was generated from, reloads build a new one and
+	// swap it in so a failed load keeps the last known good policies
+	mu       sync.RWMutex
+	enforcer *casbin.Enforcer
+	// loadedAt is when the enforcer was last loaded or its policies reloaded
+	loadedAt     time.Time
+	modelText    string
+	assets       fs.FS
+	policiesPath string
+	adapter      roleStore
 	policyLoader *PolicyLoader
+	access       *EndpointAccess
+	stepUp       *StepUp
+	signing      *RequestSigning
+	tenants      []string
+	// configuredTenants are the tenants of the configuration, sourceTenants the ones activated at
+	// runtime per source, e.g. sandboxes. sourcesMu orders the activations.
+	configuredTenants []string
+	sourceTenants     map[string][]string
+	sourcesMu         sync.Mutex
+	// reloadMu orders role writes and reloads, a reload started before a write would drop it
+	reloadMu sync.Mutex
+	health   *PolicyStoreHealth
+	drift    *PolicyDriftMonitor
+	// watcher propagates role changes to the other instances, nil when running a single instance
+	watcher persist.Watcher
+	// roleListeners are registered at startup, before requests are served
+	roleListeners []func(userID string)
 }
 
-func NewCasbinService(db *sql.DB, modelPath, policiesPath string, tenants []string) (*CasbinService, *appErrors.InfrastructureError) {
+// NewCasbinService reads the model and the policies at modelPath and policiesPath of assets. The
+// role assignments must load at startup, later failures fall back to the copy in memory.
+func NewCasbinService(db *sql.DB, assets fs.FS, modelPath, policiesPath string, tenants []string) (*CasbinService, *appErrors.InfrastructureError) {
 	adapter, err := NewRoleOnlyPostgresAdapter(db)
 	if err != nil {
 		return nil, err
 	}
 
-	enforcer, normalErr := casbin.NewEnforcer(modelPath, adapter)
+	modelText, normalErr := fs.ReadFile(assets, modelPath)
 	if normalErr != nil {
-		return nil, appErrors.NewInfrastructureError("failed to create Casbin enforcer", normalErr)
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("failed to read Casbin model %s", modelPath), normalErr)
 	}
 
 	policyLoader := NewPolicyLoader()
-	if err := policyLoader.LoadFromFile(policiesPath); err != nil {
+	if err := policyLoader.LoadFromFS(assets, policiesPath); err != nil {
 		return nil, err
 	}
 
@@ -38,36 +89,190 @@ func NewCasbinService(db *sql.DB, modelPath, policiesPath string, tenants []stri
 		return nil, err
 	}
 
-	if err := policyLoader.LoadPoliciesIntoEnforcer(enforcer, tenants); err != nil {
+	service, err := newCasbinService(adapter, string(modelText), policyLoader, tenants)
+	if err != nil {
 		return nil, err
 	}
+	service.assets = assets
+	service.policiesPath = policiesPath
 
-	enforcer.EnableAutoSave(true)
+	log.Printf("CasbinService initialized with %d roles for %d tenants",
+		len(policyLoader.GetRoles()), len(tenants))
+
+	return service, nil
+}
+
+func newCasbinService(adapter roleStore, modelText string, policyLoader *PolicyLoader, tenants []string) (*CasbinService, *appErrors.InfrastructureError) {
+	access, err := policyLoader.EndpointAccess()
+	if err != nil {
+		return nil, err
+	}
+	stepUp, err := policyLoader.StepUp(access)
+	if err != nil {
+		return nil, err
+	}
+	signing, err := policyLoader.Signing(access)
+	if err != nil {
+		return nil, err
+	}
 
 	service := &CasbinService{
-		enforcer:     enforcer,
+		modelText:    modelText,
 		adapter:      adapter,
 		policyLoader: policyLoader,
+		access:       access,
+		stepUp:       stepUp,
+		signing:      signing,
+		tenants:      tenants,
+		health:       NewPolicyStoreHealth(FailClosed),
+		drift:        NewPolicyDriftMonitor(),
+
+		configuredTenants: slices.Clone(tenants),
+		sourceTenants:     make(map[string][]string),
 	}
 
-	log.Printf("CasbinService initialized with %d roles for %d tenants",
-		len(policyLoader.GetRoles()), len(tenants))
-
+	enforcer, err := service.newEnforcer(policyLoader, tenants)
+	if err != nil {
+		return nil, err
+	}
+	service.enforcer = enforcer
+	service.loadedAt = time.Now()
 	return service, nil
 }
 
+// newEnforcer loads the role assignments from the database and generates the policies of loader for
+// tenants. Tenants with an invalid role assignment keep the ones of the current enforcer and are
+// reported degraded, the other tenants are loaded.
+func (c *CasbinService) newEnforcer(loader *PolicyLoader, tenants []string) (*casbin.Enforcer, *appErrors.InfrastructureError) {
+	casbinModel, err := model.NewModelFromString(c.modelText)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to parse Casbin model", err)
+	}
+
+	quarantine := &tenantQuarantine{Adapter: c.adapter, previous: c.current()}
+	enforcer, err := casbin.NewEnforcer(casbinModel, quarantine)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to create Casbin enforcer", err)
+	}
+	enforcer.SetAdapter(c.adapter)
+
+	if err := loader.LoadPoliciesIntoEnforcer(enforcer, tenants); err != nil {
+		return nil, err
+	}
+
+	enforcer.EnableAutoSave(true)
+	enforcer.EnableAutoNotifyWatcher(false)
+	c.health.MarkTenants(quarantine.failures, quarantine.previous != nil)
+	return enforcer, nil
+}
+
+func (c *CasbinService) current() *casbin.Enforcer {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.enforcer
+}
+
+// loader returns the policies the enforcer was generated from, ApplyPolicies swaps them with it
+func (c *CasbinService) loader() *PolicyLoader {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.policyLoader
+}
+
+// EndpointAccess returns the access mode of every operation, loaded with the policies
+func (c *CasbinService) EndpointAccess() *EndpointAccess {
+	return c.access
+}
+
+// StepUp returns the operations that need a recent authentication, loaded with the policies
+func (c *CasbinService) StepUp() *StepUp {
+	return c.stepUp
+}
+
+// Signing returns the operations that need a signed request, loaded with the policies
+func (c *CasbinService) Signing() *RequestSigning {
+	return c.signing
+}
+
+// SetFailureMode decides what is authorized while the role assignments cannot be loaded
+func (c *CasbinService) SetFailureMode(mode FailureMode) {
+	c.health.setMode(mode)
+}
+
+// Health reports whether authorization runs on the last known role assignments
+func (c *CasbinService) Health() *PolicyStoreHealth {
+	return c.health
+}
+
+// PolicyDrift returns the last comparison of the policies with the policy file
+func (c *CasbinService) PolicyDrift() *PolicyDriftMonitor {
+	return c.drift
+}
+
+// Admit returns the error to send back while the policy store or the role assignments of tenantID
+// are unavailable and the failure mode rejects the request, nil otherwise
+func (c *CasbinService) Admit(tenantID string, readOnly bool) error {
+	return c.health.Admit(tenantID, readOnly)
+}
+
+// RefreshPolicies loads the role assignments again and swaps them in. On failure the service keeps
+// the ones in memory and reports itself degraded until a refresh succeeds.
+func (c *CasbinService) RefreshPolicies() *appErrors.InfrastructureError {
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+
+	c.mu.RLock()
+	tenants, loader := c.tenants, c.policyLoader
+	c.mu.RUnlock()
+
+	enforcer, err := c.newEnforcer(loader, tenants)
+	if err != nil {
+		c.health.MarkFailed(err)
+		return err
+	}
+
+	c.mu.Lock()
+	c.enforcer = enforcer
+	c.loadedAt = time.Now()
+	c.mu.Unlock()
+	c.health.MarkRecovered()
+	return nil
+}
+
+// WatchPolicyStore refreshes the role assignments every interval until ctx is cancelled, and every
+// retry interval while the store is unavailable
+func (c *CasbinService) WatchPolicyStore(ctx context.Context, interval time.Duration, retry time.Duration) {
+	for {
+		wait := interval
+		if c.health.Degraded() {
+			wait = retry
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		if err := c.RefreshPolicies(); err != nil {
+			log.Printf("failed to refresh role assignments: %v", err)
+		}
+	}
+}
+
 func (c *CasbinService) CanDo(userID, resource, action, tenantID string) (bool, *appErrors.InfrastructureError) {
 	if userID == "" || resource == "" || action == "" || tenantID == "" {
 		return false, appErrors.NewInfrastructureError(
 			fmt.Sprintf("authorization parameters cannot be empty: userID=%s, resource=%s, action=%s, tenantID=%s", userID, resource, action, tenantID),
 			nil,
-		)
+		).WithSafeContext(appErrors.SafeContext{Operation: "authorization.enforce", Resource: resource})
 	}
 
-	allowed, err := c.enforcer.Enforce(userID, resource, action, tenantID)
+	allowed, err := c.current().Enforce(userID, resource, action, tenantID)
 	if err != nil {
 		log.Printf("authorization error for user %s: %v", userID, err)
-		return false, appErrors.NewInfrastructureError(fmt.Sprintf("failed to enforce authorization for user %s", userID), err)
+		return false, appErrors.NewInfrastructureError(fmt.Sprintf("failed to enforce authorization for user %s", userID), err).
+			WithSafeContext(appErrors.SafeContext{Operation: "authorization.enforce", Resource: resource})
 	}
 	return allowed, nil
 }
@@ -81,7 +286,7 @@ func (c *CasbinService) AssignRole(userID, role, tenantID string) *appErrors.Inf
 	}
 
 	// Check if role exists in available roles
-	availableRoles := c.policyLoader.GetRoles()
+	availableRoles := c.loader().GetRoles()
 	roleExists := false
 	for _, availableRole := range availableRoles {
 		if availableRole == role {
@@ -96,8 +301,11 @@ func (c *CasbinService) AssignRole(userID, role, tenantID string) *appErrors.Inf
 		)
 	}
 
-	added, err := c.enforcer.AddGroupingPolicy(userID, role, tenantID)
+	c.reloadMu.Lock()
+	added, err := c.current().AddGroupingPolicy(userID, role, tenantID)
+	c.reloadMu.Unlock()
 	if err != nil {
+		c.health.MarkFailed(err)
 		return appErrors.NewInfrastructureError(
 			fmt.Sprintf("failed to assign role %s to user %s in tenant %s", role, userID, tenantID),
 			err)
@@ -105,12 +313,166 @@ func (c *CasbinService) AssignRole(userID, role, tenantID string) *appErrors.Inf
 
 	if added {
 		log.Printf("role assigned: user=%s, role=%s, tenant=%s", userID, role, tenantID)
+		c.notifyRoleChange(userID)
 	} else {
 		log.Printf("role assignment skipped (already exists): user=%s, role=%s, tenant=%s", userID, role, tenantID)
 	}
 	return nil
 }
 
+// AssignRoleToSubjects gives role in tenantID to every subject at once: the role assignments are
+// written in one transaction, chunk by chunk with progress called after each, and loaded into the
+// enforcer once committed. It returns how many subjects did not have the role.
+func (c *CasbinService) AssignRoleToSubjects(ctx context.Context, subjects []string, role string, tenantID string,
+	progress func(processed int, assigned int)) (int, *appErrors.InfrastructureError) {
+	if role == "" || tenantID == "" {
+		return 0, appErrors.NewInfrastructureError(
+			fmt.Sprintf("role assignment parameters cannot be empty: role=%s, tenantID=%s", role, tenantID),
+			nil,
+		)
+	}
+	if !slices.Contains(c.loader().GetRoles(), role) {
+		return 0, appErrors.NewInfrastructureError(fmt.Sprintf("role %s is not available in the system", role), nil)
+	}
+
+	unique := make([]string, 0, len(subjects))
+	seen := make(map[string]bool, len(subjects))
+	for _, subject := range subjects {
+		if subject != "" && !seen[subject] {
+			seen[subject] = true
+			unique = append(unique, subject)
+		}
+	}
+
+	assigned, err := c.adapter.AddRoleAssignments(ctx, unique, role, tenantID, roleAssignmentChunkSize, progress)
+	if err != nil {
+		c.health.MarkFailed(err)
+		return 0, appErrors.NewInfrastructureError(
+			fmt.Sprintf("failed to assign role %s to %d subjects in tenant %s", role, len(unique), tenantID),
+			err)
+	}
+	log.Printf("role assigned to %d subjects (%d new): role=%s, tenant=%s", len(unique), assigned, role, tenantID)
+	if assigned > 0 {
+		c.reloadRoleAssignments(unique)
+	}
+	return assigned, nil
+}
+
+// CopyTenantRoles gives the users of tenantID their roles in toTenantID as well, except the roles
+// excluded. Service accounts are left out, their keys only work in their tenant. It returns the
+// users whose roles were copied and how many role assignments toTenantID did not have.
+func (c *CasbinService) CopyTenantRoles(ctx context.Context, tenantID string, toTenantID string, excluded []string) ([]string, int, *appErrors.InfrastructureError) {
+	if tenantID == "" || toTenantID == "" || tenantID == toTenantID {
+		return nil, 0, appErrors.NewInfrastructureError(
+			fmt.Sprintf("role copy tenants not valid: tenantID=%s, toTenantID=%s", tenantID, toTenantID),
+			nil,
+		)
+	}
+
+	subjects, copied, err := c.adapter.CopyRoleAssignments(ctx, tenantID, toTenantID, excluded, serviceAccountEntities.SubjectPrefix)
+	if err != nil {
+		c.health.MarkFailed(err)
+		return nil, 0, appErrors.NewInfrastructureError(fmt.Sprintf("failed to copy the roles of %s to %s", tenantID, toTenantID), err)
+	}
+	log.Printf("roles copied: %d role assignments of %d users from %s to %s", copied, len(subjects), tenantID, toTenantID)
+	if copied > 0 {
+		c.reloadRoleAssignments(subjects)
+	}
+	return subjects, copied, nil
+}
+
+// RemoveTenantRoles removes every role assignment of a tenant
+func (c *CasbinService) RemoveTenantRoles(ctx context.Context, tenantID string) *appErrors.InfrastructureError {
+	if tenantID == "" {
+		return appErrors.NewInfrastructureError("role removal parameters cannot be empty: tenantID is empty", nil)
+	}
+
+	subjects, err := c.adapter.RemoveTenantRoleAssignments(ctx, tenantID)
+	if err != nil {
+		c.health.MarkFailed(err)
+		return appErrors.NewInfrastructureError(fmt.Sprintf("failed to remove the roles of %s", tenantID), err)
+	}
+	log.Printf("roles removed: %d role assignments of %s", len(subjects), tenantID)
+	if len(subjects) > 0 {
+		c.reloadRoleAssignments(slices.Compact(slices.Sorted(slices.Values(subjects))))
+	}
+	return nil
+}
+
+// RevokeSubjectRoles removes role roles[i] in tenantID from subjects[i] in one statement, so either
+// every assignment is gone or none is. It returns how many assignments existed.
+func (c *CasbinService) RevokeSubjectRoles(ctx context.Context, subjects []string, roles []string, tenantID string) (int, *appErrors.InfrastructureError) {
+	if tenantID == "" || len(subjects) != len(roles) {
+		return 0, appErrors.NewInfrastructureError(
+			fmt.Sprintf("role revocation parameters not valid: tenantID=%s, %d subjects, %d roles", tenantID, len(subjects), len(roles)),
+			nil,
+		)
+	}
+	if len(subjects) == 0 {
+		return 0, nil
+	}
+
+	removed, err := c.adapter.RemoveRoleAssignmentPairs(ctx, subjects, roles, tenantID)
+	if err != nil {
+		c.health.MarkFailed(err)
+		return 0, appErrors.NewInfrastructureError(fmt.Sprintf("failed to revoke %d role assignments in tenant %s", len(subjects), tenantID), err)
+	}
+	log.Printf("roles revoked: %d role assignments in %s", removed, tenantID)
+	if removed > 0 {
+		c.reloadRoleAssignments(slices.Compact(slices.Sorted(slices.Values(subjects))))
+	}
+	return removed, nil
+}
+
+// RestoreSubjectRoles gives role roles[i] in tenantID back to subjects[i] in one statement. Roles no
+// longer in the policies are restored as well, they grant nothing until defined again. It returns
+// how many assignments did not exist.
+func (c *CasbinService) RestoreSubjectRoles(ctx context.Context, subjects []string, roles []string, tenantID string) (int, *appErrors.InfrastructureError) {
+	if tenantID == "" || len(subjects) != len(roles) {
+		return 0, appErrors.NewInfrastructureError(
+			fmt.Sprintf("role restore parameters not valid: tenantID=%s, %d subjects, %d roles", tenantID, len(subjects), len(roles)),
+			nil,
+		)
+	}
+	if len(subjects) == 0 {
+		return 0, nil
+	}
+
+	added, err := c.adapter.AddRoleAssignmentPairs(ctx, subjects, roles, tenantID)
+	if err != nil {
+		c.health.MarkFailed(err)
+		return 0, appErrors.NewInfrastructureError(fmt.Sprintf("failed to restore %d role assignments in tenant %s", len(subjects), tenantID), err)
+	}
+	log.Printf("roles restored: %d role assignments in %s", added, tenantID)
+	if added > 0 {
+		c.reloadRoleAssignments(slices.Compact(slices.Sorted(slices.Values(subjects))))
+	}
+	return added, nil
+}
+
+// reloadRoleAssignments loads the role assignments written to the store directly and tells the
+// listeners and the other instances about the users whose roles changed. The assignments are
+// committed, a failed load leaves them to the next refresh.
+func (c *CasbinService) reloadRoleAssignments(userIDs []string) {
+	if err := c.RefreshPolicies(); err != nil {
+		log.Printf("failed to load the role assignments of %d users: %v", len(userIDs), err)
+	}
+	c.notifyRoleChange(userIDs...)
+}
+
+// ReloadRoleAssignments is reloadRoleAssignments for role assignments other adapters write in
+// their own transactions, e.g. the ones a user merge moves along with the user's records
+func (c *CasbinService) ReloadRoleAssignments(userIDs []string) {
+	c.reloadRoleAssignments(userIDs)
+}
+
+// Tenants returns the tenants the policies are generated for
+func (c *CasbinService) Tenants() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.tenants)
+}
+
 func (c *CasbinService) RemoveRole(userID, role, tenantID string) *appErrors.InfrastructureError {
 	if userID == "" || role == "" || tenantID == "" {
 		return appErrors.NewInfrastructureError(
@@ -119,8 +481,11 @@ func (c *CasbinService) RemoveRole(userID, role, tenantID string) *appErrors.Inf
 		)
 	}
 
-	removed, err := c.enforcer.RemoveGroupingPolicy(userID, role, tenantID)
+	c.reloadMu.Lock()
+	removed, err := c.current().RemoveGroupingPolicy(userID, role, tenantID)
+	c.reloadMu.Unlock()
 	if err != nil {
+		c.health.MarkFailed(err)
 		return appErrors.NewInfrastructureError(
 			fmt.Sprintf("failed to remove role %s from user %s in tenant %s", role, userID, tenantID),
 			err)
@@ -128,6 +493,7 @@ func (c *CasbinService) RemoveRole(userID, role, tenantID string) *appErrors.Inf
 
 	if removed {
 		log.Printf("role removed: user=%s, role=%s, tenant=%s", userID, role, tenantID)
+		c.notifyRoleChange(userID)
 	} else {
 		log.Printf("role removal skipped (not found): user=%s, role=%s, tenant=%s", userID, role, tenantID)
 	}
@@ -142,7 +508,7 @@ func (c *CasbinService) GetUserRoles(userID, tenantID string) ([]string, *appErr
 		)
 	}
 
-	groupings, err := c.enforcer.GetGroupingPolicy()
+	groupings, err := c.current().GetGroupingPolicy()
 	if err != nil {
 		return nil, appErrors.NewInfrastructureError("failed to get grouping policies", err)
 	}
@@ -157,6 +523,71 @@ func (c *CasbinService) GetUserRoles(userID, tenantID string) ([]string, *appErr
 	return roles, nil
 }
 
+// OnRoleChange registers a listener called with the user whenever a role of the user is assigned or
+// removed through this service, caches of user identities drop the user with it
+func (c *CasbinService) OnRoleChange(listener func(userID string)) {
+	c.roleListeners = append(c.roleListeners, listener)
+}
+
+// notifyRoleChange calls the listeners for every user and tells the other instances once
+func (c *CasbinService) notifyRoleChange(userIDs ...string) {
+	for _, userID := range userIDs {
+		for _, listener := range c.roleListeners {
+			listener(userID)
+		}
+	}
+	if c.watcher != nil {
+		if err := c.watcher.Update(); err != nil {
+			log.Printf("failed to propagate role change of %d users: %v", len(userIDs), err)
+		}
+	}
+}
+
+// SetWatcher reloads role assignments whenever the watcher reports a change made by another
+// instance, and reports changes made through this service to it. Only role changes are propagated,
+// policies come from the YAML file every instance loads.
+func (c *CasbinService) SetWatcher(watcher persist.Watcher) *appErrors.InfrastructureError {
+	if err := watcher.SetUpdateCallback(func(string) { c.reloadRoles() }); err != nil {
+		return appErrors.NewInfrastructureError("failed to set Casbin watcher callback", err)
+	}
+	c.watcher = watcher
+	return nil
+}
+
+// HasWatcher reports whether role changes are propagated to other instances
+func (c *CasbinService) HasWatcher() bool {
+	return c.watcher != nil
+}
+
+func (c *CasbinService) reloadRoles() {
+	if err := c.RefreshPolicies(); err != nil {
+		log.Printf("failed to reload role assignments: %v", err)
+		return
+	}
+	log.Println("role assignments reloaded after a change on another instance")
+}
+
+// GetUserTenantRoles returns the roles of the user grouped by tenant
+func (c *CasbinService) GetUserTenantRoles(userID string) (map[string][]string, *appErrors.InfrastructureError) {
+	if userID == "" {
+		return nil, appErrors.NewInfrastructureError("tenant roles query parameters cannot be empty: userID is empty", nil)
+	}
+
+	groupings, err := c.current().GetFilteredGroupingPolicy(0, userID)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to get grouping policies", err)
+	}
+
+	roles := make(map[string][]string)
+	for _, grouping := range groupings {
+		if len(grouping) >= 3 {
+			roles[grouping[2]] = append(roles[grouping[2]], grouping[1])
+		}
+	}
+
+	return roles, nil
+}
+
 // GetUserTenantsForRole returns all tenants where user has a specific role
 func (c *CasbinService) GetUserTenantsForRole(userID, role string) ([]string, *appErrors.InfrastructureError) {
 	if userID == "" || role == "" {
@@ -166,7 +597,7 @@ func (c *CasbinService) GetUserTenantsForRole(userID, role string) ([]string, *a
 		)
 	}
 
-	groupings, err := c.enforcer.GetGroupingPolicy()
+	groupings, err := c.current().GetGroupingPolicy()
 	if err != nil {
 		return nil, appErrors.NewInfrastructureError("failed to get grouping policies", err)
 	}
@@ -181,6 +612,32 @@ func (c *CasbinService) GetUserTenantsForRole(userID, role string) ([]string, *a
 	return tenants, nil
 }
 
+// GetRoleUsers returns the users holding a role in a tenant. Service accounts holding it are left
+// out, they are not people to notify or list as members.
+func (c *CasbinService) GetRoleUsers(role, tenantID string) ([]string, *appErrors.InfrastructureError) {
+	if role == "" || tenantID == "" {
+		return nil, appErrors.NewInfrastructureError(
+			fmt.Sprintf("role users query parameters cannot be empty: role=%s, tenantID=%s", role, tenantID),
+			nil,
+		)
+	}
+
+	groupings, err := c.current().GetGroupingPolicy()
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to get grouping policies", err)
+	}
+
+	var users []string
+	for _, grouping := range groupings {
+		if len(grouping) >= 3 && grouping[1] == role && grouping[2] == tenantID &&
+			!serviceAccountEntities.IsServiceAccountSubject(grouping[0]) {
+			users = append(users, grouping[0])
+		}
+	}
+
+	return users, nil
+}
+
 func (c *CasbinService) HasRole(userID, role, tenantID string) (bool, *appErrors.InfrastructureError) {
 	if userID == "" || role == "" || tenantID == "" {
 		return false, appErrors.NewInfrastructureError(
@@ -189,7 +646,7 @@ func (c *CasbinService) HasRole(userID, role, tenantID string) (bool, *appErrors
 		)
 	}
 
-	hasRole, err := c.enforcer.HasGroupingPolicy(userID, role, tenantID)
+	hasRole, err := c.current().HasGroupingPolicy(userID, role, tenantID)
 	if err != nil {
 		return false, appErrors.NewInfrastructureError(
 			fmt.Sprintf("failed to check if user %s has role %s in tenant %s", userID, role, tenantID),
@@ -200,7 +657,13 @@ func (c *CasbinService) HasRole(userID, role, tenantID string) (bool, *appErrors
 }
 
 func (c *CasbinService) GetAvailableRoles() []string {
-	return c.policyLoader.GetRoles()
+	return c.loader().GetRoles()
+}
+
+// GetRolePermissions returns the actions of every resource the role is given in the policies, "all"
+// standing for every resource or action
+func (c *CasbinService) GetRolePermissions(role string) map[string][]string {
+	return c.loader().GetConfig().Roles[role].Permissions
 }
 
 // ReloadPolicies reloads policies from YAML for new tenants
@@ -212,27 +675,53 @@ func (c *CasbinService) ReloadPolicies(tenants []string) *appErrors.Infrastructu
 		)
 	}
 
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	err := c.policyLoader.LoadPoliciesIntoEnforcer(c.enforcer, tenants)
 	if err != nil {
 		return err
 	}
+	c.tenants = tenants
+	c.loadedAt = time.Now()
 
 	log.Printf("policies reloaded successfully for %d tenants", len(tenants))
 	return nil
 }
 
-func (c *CasbinService) PrintDebugInfo() {
-	fmt.Println("\n=== Casbin Debug Info ===")
-	if c.policyLoader != nil && c.enforcer != nil {
-		c.policyLoader.PrintLoadedPolicies(c.enforcer)
-	} else {
-		fmt.Println("Error: Service not properly initialized")
+// ActivateTenants makes the policies apply to the tenants of source, in place of the ones source
+// activated before. The tenants of the configuration and of the other sources keep theirs, the
+// policies are only reloaded when the tenants changed.
+func (c *CasbinService) ActivateTenants(source string, tenants []string) *appErrors.InfrastructureError {
+	c.sourcesMu.Lock()
+	defer c.sourcesMu.Unlock()
+
+	c.sourceTenants[source] = slices.Clone(tenants)
+
+	sources := make([]string, 0, len(c.sourceTenants))
+	for name := range c.sourceTenants {
+		sources = append(sources, name)
+	}
+	slices.Sort(sources)
+
+	active := slices.Clone(c.configuredTenants)
+	for _, name := range sources {
+		for _, tenantID := range c.sourceTenants[name] {
+			if !slices.Contains(active, tenantID) {
+				active = append(active, tenantID)
+			}
+		}
 	}
-	fmt.Println("=========================")
+	if slices.Equal(active, c.Tenants()) {
+		return nil
+	}
+	return c.ReloadPolicies(active)
 }
 
 func (c *CasbinService) GetEnforcer() *casbin.Enforcer {
-	return c.enforcer
+	return c.current()
 }
 
 func (c *CasbinService) Close() error {
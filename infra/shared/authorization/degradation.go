@@ -0,0 +1,255 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// FailureMode decides what is authorized while the policy store is unavailable
+type FailureMode string
+
+const (
+	// FailClosed rejects every authorized request until the store is back
+	FailClosed FailureMode = "closed"
+	// FailOpenReadOnly keeps authorizing reads from the last policies loaded and rejects writes, role
+	// changes made meanwhile on other instances are not seen
+	FailOpenReadOnly FailureMode = "read-only"
+)
+
+// ParseFailureMode reads AUTHZ_FAILURE_MODE, "" is FailClosed
+func ParseFailureMode(value string) (FailureMode, error) {
+	switch FailureMode(value) {
+	case "", FailClosed:
+		return FailClosed, nil
+	case FailOpenReadOnly:
+		return FailOpenReadOnly, nil
+	}
+	return "", fmt.Errorf("unknown authorization failure mode %q, expected %q or %q", value, FailClosed, FailOpenReadOnly)
+}
+
+// IsReadOnlyMethod reports whether requests of the HTTP method do not change data
+func IsReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// PolicyStoreHealth tracks whether the role assignments in memory are the last known good copy of
+// an unreachable casbin_rule table. It flips on the first failed load or write and back on the next
+// successful load, and logs both transitions for alerting. Tenants are tracked apart: an invalid role
+// assignment only degrades the tenant it belongs to.
+type PolicyStoreHealth struct {
+	mu        sync.Mutex
+	mode      FailureMode
+	degraded  bool
+	lastError error
+	failures  int64
+	// tenants are the tenants whose role assignments were left out of the last load
+	tenants map[string]TenantDegradation
+}
+
+func NewPolicyStoreHealth(mode FailureMode) *PolicyStoreHealth {
+	return &PolicyStoreHealth{mode: mode, tenants: make(map[string]TenantDegradation)}
+}
+
+// TenantDegradation is a tenant whose role assignments in the store are invalid, such as a row
+// without a role. Its requests are refused with AUTHZ_CONFIG_ERROR until the row is fixed.
+type TenantDegradation struct {
+	TenantID string    `json:"tenant_id"`
+	Error    string    `json:"error"`
+	Since    time.Time `json:"since"`
+	// LastKnown tells the role assignments the tenant had before are kept in memory, the read-only
+	// failure mode serves its reads from them. A tenant broken since the instance started has none.
+	LastKnown bool `json:"last_known"`
+}
+
+// PolicyStoreStatus is what PolicyStoreHealth knows of the store at a point in time
+type PolicyStoreStatus struct {
+	FailureMode FailureMode `json:"failure_mode"`
+	Degraded    bool        `json:"degraded"`
+	// LastError is why the store is unavailable, empty while it is not
+	LastError string `json:"last_error,omitempty"`
+	// Failures counts the failed loads and writes since the instance started
+	Failures int64 `json:"failures"`
+	// DegradedTenants are the tenants whose role assignments are invalid, sorted
+	DegradedTenants []TenantDegradation `json:"degraded_tenants,omitempty"`
+}
+
+// Status returns the current state of the store
+func (h *PolicyStoreHealth) Status() PolicyStoreStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	status := PolicyStoreStatus{FailureMode: h.mode, Degraded: h.degraded, Failures: h.failures}
+	if h.lastError != nil {
+		status.LastError = h.lastError.Error()
+	}
+	status.DegradedTenants = h.degradedTenants()
+	return status
+}
+
+// MarkFailed records a failed load or write of the policy store
+func (h *PolicyStoreHealth) MarkFailed(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures++
+	h.lastError = err
+	if !h.degraded {
+		h.degraded = true
+		log.Printf("ALERT authorization policy store unavailable, serving the last known policies in %s mode: %v", h.mode, err)
+	}
+}
+
+// MarkRecovered records a successful load of the policy store
+func (h *PolicyStoreHealth) MarkRecovered() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.degraded {
+		h.degraded = false
+		h.lastError = nil
+		log.Println("authorization policy store recovered, role assignments reloaded")
+	}
+}
+
+// MarkTenants records the tenants whose role assignments were left out of a load, with why. The ones
+// newly failing are alerted on and the ones missing from failures recovered. lastKnown tells the
+// assignments they had were kept, it is only recorded for tenants newly failing.
+func (h *PolicyStoreHealth) MarkTenants(failures map[string]error, lastKnown bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for tenantID := range h.tenants {
+		if _, failing := failures[tenantID]; !failing {
+			delete(h.tenants, tenantID)
+			log.Printf("authorization of tenant %s recovered, its role assignments loaded", tenantID)
+		}
+	}
+	for tenantID, err := range failures {
+		degradation, found := h.tenants[tenantID]
+		if !found {
+			degradation = TenantDegradation{TenantID: tenantID, Since: time.Now(), LastKnown: lastKnown}
+			log.Printf("ALERT authorization of tenant %s degraded, its requests are refused until its role assignments are fixed: %v", tenantID, err)
+		}
+		degradation.Error = err.Error()
+		h.tenants[tenantID] = degradation
+	}
+}
+
+// DegradedTenants returns the tenants whose role assignments are invalid, sorted
+func (h *PolicyStoreHealth) DegradedTenants() []TenantDegradation {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.degradedTenants()
+}
+
+// degradedTenants is DegradedTenants, h.mu held
+func (h *PolicyStoreHealth) degradedTenants() []TenantDegradation {
+	if len(h.tenants) == 0 {
+		return nil
+	}
+	tenants := make([]TenantDegradation, 0, len(h.tenants))
+	for _, degradation := range h.tenants {
+		tenants = append(tenants, degradation)
+	}
+	slices.SortFunc(tenants, func(a, b TenantDegradation) int { return strings.Compare(a.TenantID, b.TenantID) })
+	return tenants
+}
+
+// Degraded reports whether the policy store is unavailable
+func (h *PolicyStoreHealth) Degraded() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.degraded
+}
+
+// Check fails the readiness of an instance that rejects every authorized request, the read-only
+// mode keeps serving reads from the last known policies and stays ready
+func (h *PolicyStoreHealth) Check(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.degraded || h.mode == FailOpenReadOnly {
+		return nil
+	}
+	return fmt.Errorf("role assignments cannot be loaded: %w", h.lastError)
+}
+
+// CheckTenants fails while some tenants are degraded. Their number is all it tells, the status page
+// it is shown on is public.
+func (h *PolicyStoreHealth) CheckTenants(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.tenants) == 0 {
+		return nil
+	}
+	return fmt.Errorf("role assignments of %d tenants cannot be loaded", len(h.tenants))
+}
+
+// Admit returns the error to send back for a request of tenantID while the store or the tenant is
+// unavailable, nil when the request can be authorized
+func (h *PolicyStoreHealth) Admit(tenantID string, readOnly bool) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.degraded && (!readOnly || h.mode != FailOpenReadOnly) {
+		return appErrors.NewServiceUnavailableError("authorization", h.lastError)
+	}
+	degradation, found := h.tenants[tenantID]
+	if !found || (readOnly && h.mode == FailOpenReadOnly && degradation.LastKnown) {
+		return nil
+	}
+	return appErrors.NewAuthzConfigError(tenantID)
+}
+
+// setMode changes the failure mode, the state of the store is kept
+func (h *PolicyStoreHealth) setMode(mode FailureMode) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.mode = mode
+}
+
+// WriteMetrics writes the store state and the failed loads and writes in the Prometheus text format
+func (h *PolicyStoreHealth) WriteMetrics(w io.Writer) error {
+	h.mu.Lock()
+	degraded, failures := 0, h.failures
+	if h.degraded {
+		degraded = 1
+	}
+	tenants := h.degradedTenants()
+	h.mu.Unlock()
+
+	_, err := fmt.Fprintf(w, "# HELP authz_policy_store_degraded Whether authorization runs on the last known policies\n"+
+		"# TYPE authz_policy_store_degraded gauge\n"+
+		"authz_policy_store_degraded %d\n"+
+		"# HELP authz_policy_store_failures_total Failed loads and writes of role assignments\n"+
+		"# TYPE authz_policy_store_failures_total counter\n"+
+		"authz_policy_store_failures_total %d\n",
+		degraded, failures)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "# HELP authz_degraded_tenants Tenants whose role assignments cannot be loaded\n"+
+		"# TYPE authz_degraded_tenants gauge\n"+
+		"authz_degraded_tenants %d\n"+
+		"# HELP authz_tenant_degraded Whether the requests of the tenant are refused for invalid role assignments\n"+
+		"# TYPE authz_tenant_degraded gauge\n",
+		len(tenants))
+	for _, degradation := range tenants {
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "authz_tenant_degraded{tenant=%q} 1\n", degradation.TenantID)
+	}
+	return err
+}
@@ -0,0 +1,240 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// PolicyDrift compares what an instance enforces with its policy file at a point in time. Policies
+// are only read at startup, so a file changed by a deployment that did not restart the instance, or
+// policies written to the store by hand, leave the instance enforcing something else.
+type PolicyDrift struct {
+	CheckedAt time.Time `json:"checked_at"`
+	// LoadedHash and FileHash are the SHA-256 of the policy file when it was loaded and now
+	LoadedHash string `json:"loaded_hash"`
+	FileHash   string `json:"file_hash,omitempty"`
+	// FileError tells why the file cannot be read or parsed now, the rules are then compared with
+	// the file as it was loaded
+	FileError string `json:"file_error,omitempty"`
+	// MissingPolicies are in the file and not in the enforcer, ExtraPolicies the other way round
+	MissingPolicies [][]string `json:"missing_policies,omitempty"`
+	ExtraPolicies   [][]string `json:"extra_policies,omitempty"`
+	// StoreOverrides counts the policy rows of casbin_rule, which are never loaded since policies
+	// come from the file
+	StoreOverrides int    `json:"store_overrides"`
+	StoreError     string `json:"store_error,omitempty"`
+	// UnknownRoles are assigned in the store but not defined in the file
+	UnknownRoles []string `json:"unknown_roles,omitempty"`
+}
+
+// FileChanged reports whether the policy file is not the one the instance loaded
+func (d *PolicyDrift) FileChanged() bool {
+	return d.FileError != "" || d.FileHash != d.LoadedHash
+}
+
+// Drifted reports whether the instance enforces anything but its policy file
+func (d *PolicyDrift) Drifted() bool {
+	return d.FileChanged() || len(d.MissingPolicies) > 0 || len(d.ExtraPolicies) > 0 ||
+		d.StoreOverrides > 0 || len(d.UnknownRoles) > 0
+}
+
+// String describes the drift in a line, empty when there is none
+func (d *PolicyDrift) String() string {
+	var parts []string
+	switch {
+	case d.FileError != "":
+		parts = append(parts, "policy file unreadable: "+d.FileError)
+	case d.FileChanged():
+		parts = append(parts, fmt.Sprintf("policy file changed since it was loaded (%.12s, loaded %.12s)", d.FileHash, d.LoadedHash))
+	}
+	if len(d.MissingPolicies) > 0 || len(d.ExtraPolicies) > 0 {
+		parts = append(parts, fmt.Sprintf("%d policies missing and %d extra in the enforcer", len(d.MissingPolicies), len(d.ExtraPolicies)))
+	}
+	if d.StoreOverrides > 0 {
+		parts = append(parts, fmt.Sprintf("%d policy rows in casbin_rule are ignored", d.StoreOverrides))
+	}
+	if len(d.UnknownRoles) > 0 {
+		parts = append(parts, fmt.Sprintf("roles assigned but not defined: %s", strings.Join(d.UnknownRoles, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// DiffPolicies returns the rules of expected that loaded lacks and the rules of loaded that expected
+// lacks, in the order of each list
+func DiffPolicies(expected [][]string, loaded [][]string) (missing [][]string, extra [][]string) {
+	key := func(rule []string) string { return strings.Join(rule, "\x00") }
+	expectedKeys := make(map[string]bool, len(expected))
+	for _, rule := range expected {
+		expectedKeys[key(rule)] = true
+	}
+	loadedKeys := make(map[string]bool, len(loaded))
+	for _, rule := range loaded {
+		loadedKeys[key(rule)] = true
+		if !expectedKeys[key(rule)] {
+			extra = append(extra, rule)
+		}
+	}
+	for _, rule := range expected {
+		if !loadedKeys[key(rule)] {
+			missing = append(missing, rule)
+		}
+	}
+	return missing, extra
+}
+
+// UnknownRoles returns the roles of the role assignments that are not in roles, sorted
+func UnknownRoles(groupings [][]string, roles []string) []string {
+	var unknown []string
+	for _, grouping := range groupings {
+		if len(grouping) > 1 && !slices.Contains(roles, grouping[1]) && !slices.Contains(unknown, grouping[1]) {
+			unknown = append(unknown, grouping[1])
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
+
+// PolicyDriftMonitor keeps the last drift check of the instance. It logs a WARN line whenever the
+// drift found changes and another when it is gone, and exposes the last check on /metrics.
+type PolicyDriftMonitor struct {
+	mu   sync.Mutex
+	last *PolicyDrift
+}
+
+func NewPolicyDriftMonitor() *PolicyDriftMonitor {
+	return &PolicyDriftMonitor{}
+}
+
+// Record keeps drift as the last check
+func (m *PolicyDriftMonitor) Record(drift *PolicyDrift) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous := ""
+	if m.last != nil {
+		previous = m.last.String()
+	}
+	if current := drift.String(); current != previous {
+		if current != "" {
+			log.Printf("WARN authorization policies drifted from the policy file: %s", current)
+		} else {
+			log.Println("authorization policies match the policy file again")
+		}
+	}
+	m.last = drift
+}
+
+// Last returns the last check, nil before the first one
+func (m *PolicyDriftMonitor) Last() *PolicyDrift {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last
+}
+
+// WriteMetrics writes the last check in the Prometheus text format, nothing before the first one
+func (m *PolicyDriftMonitor) WriteMetrics(w io.Writer) error {
+	drift := m.Last()
+	if drift == nil {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(w, "# HELP authz_policy_drift Whether the instance enforces anything but its policy file\n"+
+		"# TYPE authz_policy_drift gauge\n"+
+		"authz_policy_drift %d\n"+
+		"# HELP authz_policy_file_changed Whether the policy file changed since the instance loaded it\n"+
+		"# TYPE authz_policy_file_changed gauge\n"+
+		"authz_policy_file_changed %d\n"+
+		"# HELP authz_policy_drift_rules Rules that differ from the policy file by kind\n"+
+		"# TYPE authz_policy_drift_rules gauge\n"+
+		"authz_policy_drift_rules{kind=\"missing\"} %d\n"+
+		"authz_policy_drift_rules{kind=\"extra\"} %d\n"+
+		"authz_policy_drift_rules{kind=\"store_override\"} %d\n"+
+		"authz_policy_drift_rules{kind=\"unknown_role\"} %d\n"+
+		"# HELP authz_policy_drift_checked_timestamp_seconds When the policies were last compared with the policy file\n"+
+		"# TYPE authz_policy_drift_checked_timestamp_seconds gauge\n"+
+		"authz_policy_drift_checked_timestamp_seconds %d\n",
+		boolGauge(drift.Drifted()), boolGauge(drift.FileChanged()),
+		len(drift.MissingPolicies), len(drift.ExtraPolicies), drift.StoreOverrides, len(drift.UnknownRoles),
+		drift.CheckedAt.Unix())
+	return err
+}
+
+func boolGauge(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// CheckPolicyDrift compares the enforcer and the store with the policy file as it is now and records
+// the result
+func (c *CasbinService) CheckPolicyDrift(ctx context.Context) *PolicyDrift {
+	loader := c.loader()
+	drift := &PolicyDrift{CheckedAt: time.Now(), LoadedHash: loader.Hash()}
+
+	file := NewPolicyLoader()
+	data, err := c.readPolicyFile()
+	if err == nil {
+		err = file.LoadFromBytes(data)
+	}
+	if err == nil {
+		drift.FileHash = file.Hash()
+	} else {
+		drift.FileError = err.Error()
+		file = loader
+	}
+
+	c.mu.RLock()
+	tenants := c.tenants
+	c.mu.RUnlock()
+	if loaded, err := c.Policies(); err != nil {
+		log.Printf("failed to read the policies to check for drift: %v", err)
+	} else {
+		drift.MissingPolicies, drift.ExtraPolicies = DiffPolicies(file.Policies(tenants), loaded.Policies)
+		drift.UnknownRoles = UnknownRoles(loaded.Groupings, file.GetRoles())
+	}
+
+	overrides, countErr := c.adapter.CountPolicyRows(ctx)
+	if countErr != nil {
+		drift.StoreError = countErr.Error()
+	}
+	drift.StoreOverrides = overrides
+
+	c.drift.Record(drift)
+	return drift
+}
+
+// readPolicyFile reads the policy file as it is now
+func (c *CasbinService) readPolicyFile() ([]byte, *appErrors.InfrastructureError) {
+	if c.assets == nil {
+		return nil, appErrors.NewInfrastructureError("policies were not loaded from a file", nil)
+	}
+	data, err := fs.ReadFile(c.assets, c.policiesPath)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("failed to read policy file %s", c.policiesPath), err)
+	}
+	return data, nil
+}
+
+// WatchPolicyDrift checks the policies against the policy file every interval until ctx is
+// cancelled
+func (c *CasbinService) WatchPolicyDrift(ctx context.Context, interval time.Duration) {
+	for {
+		c.CheckPolicyDrift(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package authorization
+
+import (
+	"fmt"
+	"sort"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// AccessMode is how the authorization middleware admits the requests of an operation
+type AccessMode string
+
+const (
+	// AccessDenied operations have no mode, they are refused
+	AccessDenied AccessMode = ""
+	// AccessPublic operations skip authorization entirely, no user or tenant is needed
+	AccessPublic AccessMode = "public"
+	// AccessAuthenticated operations need a user and tenant but no permission, any role or none
+	AccessAuthenticated AccessMode = "authenticated"
+	// AccessPermission operations need the permission EndpointMapping gives them
+	AccessPermission AccessMode = "permission"
+)
+
+// EndpointAccessConfig is the endpoints section of policies.yaml, the operations served without a
+// permission
+type EndpointAccessConfig struct {
+	Public        []string `yaml:"public"`
+	Authenticated []string `yaml:"authenticated"`
+}
+
+// EndpointAccess is the access mode of every operation, built once at startup and read-only after
+type EndpointAccess struct {
+	modes       map[string]AccessMode
+	permissions map[string]ResourceAction
+}
+
+// NewEndpointAccess combines the operations of config with the permissions of the others. An
+// operation listed twice, or listed in config and given a permission, is refused: the permission
+// would never be checked and the endpoint would be open to callers it was meant to exclude.
+func NewEndpointAccess(config EndpointAccessConfig, permissions map[string]ResourceAction) (*EndpointAccess, *appErrors.InfrastructureError) {
+	access := &EndpointAccess{
+		modes:       make(map[string]AccessMode, len(permissions)+len(config.Public)+len(config.Authenticated)),
+		permissions: permissions,
+	}
+	for operationID := range permissions {
+		access.modes[operationID] = AccessPermission
+	}
+
+	var conflicts []string
+	add := func(operationIDs []string, mode AccessMode) {
+		for _, operationID := range operationIDs {
+			switch existing := access.modes[operationID]; existing {
+			case AccessDenied:
+				access.modes[operationID] = mode
+			case AccessPermission:
+				permission := permissions[operationID]
+				conflicts = append(conflicts, fmt.Sprintf("%s is %s but requires %s:%s in EndpointMapping",
+					operationID, mode, permission.Resource, permission.Action))
+			default:
+				conflicts = append(conflicts, fmt.Sprintf("%s is both %s and %s", operationID, existing, mode))
+			}
+		}
+	}
+	add(config.Public, AccessPublic)
+	add(config.Authenticated, AccessAuthenticated)
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("invalid endpoint access: %v", conflicts), nil)
+	}
+	return access, nil
+}
+
+// Mode returns the access mode of an operation, AccessDenied when it has none
+func (a *EndpointAccess) Mode(operationID string) AccessMode {
+	return a.modes[operationID]
+}
+
+// IsPublic reports whether an operation skips authorization
+func (a *EndpointAccess) IsPublic(operationID string) bool {
+	return a.Mode(operationID) == AccessPublic
+}
+
+// Permission returns the permission an operation requires, false for operations in another mode
+func (a *EndpointAccess) Permission(operationID string) (ResourceAction, bool) {
+	if a.Mode(operationID) != AccessPermission {
+		return ResourceAction{}, false
+	}
+	return a.permissions[operationID], true
+}
+
+// Retain drops the operations served reports the instance does not serve, instances serving some
+// of the modules refuse the operations of the others like unmapped ones. It is called once at
+// startup, after the routes are registered and before any request is served.
+func (a *EndpointAccess) Retain(served func(operationID string) bool) {
+	for operationID := range a.modes {
+		if !served(operationID) {
+			delete(a.modes, operationID)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package authorization
+
+import (
+	"context"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+
+	"google.golang.org/grpc"
+)
+
+// GRPCOperation is the operation of EndpointMapping a gRPC method is authorized as
+type GRPCOperation struct {
+	OperationID string
+	// ReadOnly methods are still served while role assignments cannot be loaded in read-only mode
+	ReadOnly bool
+}
+
+// NewGRPCInterceptor admits gRPC calls like NewAuthorizationMiddleware and NewStepUpMiddleware
+// admit the requests of the operation each method maps to, keyed by full method name
+// ("/class.user.v1.UserService/ListUsers"). Methods without an operation are denied.
+func NewGRPCInterceptor(authzService *CasbinService, operations map[string]GRPCOperation) grpc.UnaryServerInterceptor {
+	access, stepUp := authzService.EndpointAccess(), authzService.StepUp()
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		meta := requestmeta.FromIncomingGRPC(ctx)
+		ctx = requestmeta.WithMeta(ctx, meta)
+
+		operation, mapped := operations[info.FullMethod]
+		mode := access.Mode(operation.OperationID)
+		switch {
+		case !mapped || mode == AccessDenied:
+			return nil, appErrors.NewForbiddenError(info.FullMethod, "unmapped")
+		case mode == AccessPublic:
+			return handler(ctx, req)
+		case mode == AccessAuthenticated:
+			if meta.UserID == "" || meta.TenantID == "" {
+				return nil, appErrors.NewUnauthorizedError("Missing user or tenant information")
+			}
+		default:
+			if err := authzService.Admit(meta.TenantID, operation.ReadOnly); err != nil {
+				return nil, err
+			}
+			permission, _ := access.Permission(operation.OperationID)
+			if err := Authorize(authzService, meta.UserID, meta.TenantID, permission); err != nil {
+				return nil, err
+			}
+		}
+
+		if stepUp.Requires(operation.OperationID) {
+			if err := stepUp.Check(meta, time.Now()); err != nil {
+				return nil, err
+			}
+		}
+
+		ctx = context.WithValue(ctx, userIDContextKey, meta.UserID)
+		ctx = context.WithValue(ctx, tenantIDContextKey, meta.TenantID)
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,151 @@
+package authorization
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// storePingTimeout bounds the check of the role store made for every summary
+const storePingTimeout = 2 * time.Second
+
+// PolicyDump is what an enforcer holds: policies are role, resource, action, tenant and role
+// assignments are subject, role, tenant
+type PolicyDump struct {
+	Policies  [][]string `json:"policies"`
+	Groupings [][]string `json:"groupings"`
+}
+
+// ForTenant returns the policies and role assignments of a tenant
+func (d *PolicyDump) ForTenant(tenantID string) *PolicyDump {
+	return &PolicyDump{
+		Policies:  rulesOfTenant(d.Policies, 3, tenantID),
+		Groupings: rulesOfTenant(d.Groupings, 2, tenantID),
+	}
+}
+
+// PoliciesPerTenant counts the policies of every tenant
+func (d *PolicyDump) PoliciesPerTenant() map[string]int {
+	return countPerTenant(d.Policies, 3)
+}
+
+// GroupingsPerTenant counts the role assignments of every tenant
+func (d *PolicyDump) GroupingsPerTenant() map[string]int {
+	return countPerTenant(d.Groupings, 2)
+}
+
+func rulesOfTenant(rules [][]string, tenantIndex int, tenantID string) [][]string {
+	matching := [][]string{}
+	for _, rule := range rules {
+		if len(rule) > tenantIndex && rule[tenantIndex] == tenantID {
+			matching = append(matching, rule)
+		}
+	}
+	return matching
+}
+
+func countPerTenant(rules [][]string, tenantIndex int) map[string]int {
+	counts := make(map[string]int)
+	for _, rule := range rules {
+		if len(rule) > tenantIndex {
+			counts[rule[tenantIndex]]++
+		}
+	}
+	return counts
+}
+
+// RoleStoreHealth is the state of the casbin_rule table as seen by the instance, Reachable is
+// checked when the health is read while the rest comes from the last loads and writes
+type RoleStoreHealth struct {
+	PolicyStoreStatus
+	Reachable bool   `json:"reachable"`
+	PingError string `json:"ping_error,omitempty"`
+}
+
+// AuthorizationSummary describes what the authorization service of an instance has loaded
+type AuthorizationSummary struct {
+	Roles              []string       `json:"roles"`
+	Tenants            []string       `json:"tenants"`
+	Policies           int            `json:"policies"`
+	PoliciesPerTenant  map[string]int `json:"policies_per_tenant"`
+	Groupings          int            `json:"groupings"`
+	GroupingsPerTenant map[string]int `json:"groupings_per_tenant"`
+	// LoadedAt is when the role assignments or the policies were last loaded
+	LoadedAt time.Time `json:"loaded_at"`
+	// Watcher tells whether role changes reach the other instances
+	Watcher bool            `json:"watcher"`
+	Store   RoleStoreHealth `json:"store"`
+	// Drift is the last comparison with the policy file, absent before the first one
+	Drift *PolicyDrift `json:"drift,omitempty"`
+	// PolicyHash is the SHA-256 of the policy file the policies were loaded from
+	PolicyHash string `json:"policy_hash"`
+}
+
+// Policies returns the policies and role assignments the enforcer holds in memory
+func (c *CasbinService) Policies() (*PolicyDump, *appErrors.InfrastructureError) {
+	return c.loader().LoadedPolicies(c.current())
+}
+
+// Summary counts what the enforcer holds and checks the role store
+func (c *CasbinService) Summary(ctx context.Context) (*AuthorizationSummary, *appErrors.InfrastructureError) {
+	dump, err := c.Policies()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	tenants, loadedAt := c.tenants, c.loadedAt
+	c.mu.RUnlock()
+
+	store := RoleStoreHealth{PolicyStoreStatus: c.health.Status(), Reachable: true}
+	pingCtx, cancel := context.WithTimeout(ctx, storePingTimeout)
+	defer cancel()
+	if pingErr := c.adapter.Ping(pingCtx); pingErr != nil {
+		store.Reachable, store.PingError = false, pingErr.Error()
+	}
+
+	return &AuthorizationSummary{
+		Roles:              c.GetAvailableRoles(),
+		Tenants:            tenants,
+		Policies:           len(dump.Policies),
+		PoliciesPerTenant:  dump.PoliciesPerTenant(),
+		Groupings:          len(dump.Groupings),
+		GroupingsPerTenant: dump.GroupingsPerTenant(),
+		LoadedAt:           loadedAt,
+		Watcher:            c.HasWatcher(),
+		Store:              store,
+		Drift:              c.drift.Last(),
+		PolicyHash:         c.loader().Hash(),
+	}, nil
+}
+
+// SummaryHandler serves the Summary of the instance that answers
+func (c *CasbinService) SummaryHandler(ctx *gin.Context) {
+	summary, err := c.Summary(ctx.Request.Context())
+	if err != nil {
+		log.Printf("Failed to summarize authorization: %v", err)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	ctx.JSON(http.StatusOK, summary)
+}
+
+// PoliciesHandler serves the policies and role assignments in memory, ?tenant= keeps the rules of
+// one tenant
+func (c *CasbinService) PoliciesHandler(ctx *gin.Context) {
+	dump, err := c.Policies()
+	if err != nil {
+		log.Printf("Failed to read authorization policies: %v", err)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	if tenantID := ctx.Query("tenant"); tenantID != "" {
+		dump = dump.ForTenant(tenantID)
+	}
+	ctx.JSON(http.StatusOK, dump)
+}
@@ -0,0 +1,201 @@
+package authorization
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+)
+
+// NewInMemoryCasbinService builds the service on role assignments kept in memory, for tests. The
+// permissions come from policyLoader, loaded from a file or declared with PolicyLoader.Grant.
+func NewInMemoryCasbinService(modelText string, policyLoader *PolicyLoader, tenants []string) (*CasbinService, *appErrors.InfrastructureError) {
+	if policyLoader.GetConfig() == nil {
+		return nil, appErrors.NewInfrastructureError("policy config not loaded", nil)
+	}
+	return newCasbinService(&memoryRoleStore{}, modelText, policyLoader, tenants)
+}
+
+// memoryRoleStore keeps the role assignments as subject, role, tenant. Like RoleOnlyPostgresAdapter
+// it ignores policies, they are generated from the policy loader on every load.
+type memoryRoleStore struct {
+	mu          sync.Mutex
+	assignments [][]string
+}
+
+func (s *memoryRoleStore) LoadPolicy(m model.Model) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, assignment := range s.assignments {
+		if err := persist.LoadPolicyArray(append([]string{"g"}, assignment...), m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryRoleStore) SavePolicy(m model.Model) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.assignments = nil
+	for _, assertion := range m["g"] {
+		for _, rule := range assertion.Policy {
+			s.assignments = append(s.assignments, slices.Clone(rule))
+		}
+	}
+	return nil
+}
+
+func (s *memoryRoleStore) AddPolicy(sec string, _ string, rule []string) error {
+	if sec != "g" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.add(rule[0], rule[1], rule[2])
+	return nil
+}
+
+func (s *memoryRoleStore) RemovePolicy(sec string, _ string, rule []string) error {
+	if sec != "g" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(func(assignment []string) bool { return slices.Equal(assignment, rule) })
+	return nil
+}
+
+func (s *memoryRoleStore) RemoveFilteredPolicy(sec string, _ string, fieldIndex int, fieldValues ...string) error {
+	if sec != "g" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(func(assignment []string) bool {
+		for i, value := range fieldValues {
+			if value != "" && (fieldIndex+i >= len(assignment) || assignment[fieldIndex+i] != value) {
+				return false
+			}
+		}
+		return true
+	})
+	return nil
+}
+
+func (s *memoryRoleStore) Ping(context.Context) error {
+	return nil
+}
+
+// CountPolicyRows is always 0, policies are never stored
+func (s *memoryRoleStore) CountPolicyRows(context.Context) (int, error) {
+	return 0, nil
+}
+
+func (s *memoryRoleStore) AddRoleAssignments(_ context.Context, subjects []string, role string, tenantID string,
+	chunkSize int, progress func(processed int, assigned int)) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	assigned := 0
+	for start := 0; start < len(subjects); start += chunkSize {
+		end := min(start+chunkSize, len(subjects))
+		for _, subject := range subjects[start:end] {
+			if s.add(subject, role, tenantID) {
+				assigned++
+			}
+		}
+		if progress != nil {
+			progress(end, assigned)
+		}
+	}
+	return assigned, nil
+}
+
+func (s *memoryRoleStore) CopyRoleAssignments(_ context.Context, tenantID string, toTenantID string, excluded []string,
+	skipPrefix string) ([]string, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var subjects []string
+	copied := 0
+	for _, assignment := range slices.Clone(s.assignments) {
+		subject, role := assignment[0], assignment[1]
+		if assignment[2] != tenantID || slices.Contains(excluded, role) || strings.HasPrefix(subject, skipPrefix) {
+			continue
+		}
+		subjects = append(subjects, subject)
+		if s.add(subject, role, toTenantID) {
+			copied++
+		}
+	}
+	slices.Sort(subjects)
+	return slices.Compact(subjects), copied, nil
+}
+
+func (s *memoryRoleStore) RemoveRoleAssignmentPairs(_ context.Context, subjects []string, roles []string, tenantID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for i, subject := range subjects {
+		removed += s.remove(func(assignment []string) bool {
+			return slices.Equal(assignment, []string{subject, roles[i], tenantID})
+		})
+	}
+	return removed, nil
+}
+
+func (s *memoryRoleStore) AddRoleAssignmentPairs(_ context.Context, subjects []string, roles []string, tenantID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := 0
+	for i, subject := range subjects {
+		if s.add(subject, roles[i], tenantID) {
+			added++
+		}
+	}
+	return added, nil
+}
+
+func (s *memoryRoleStore) RemoveTenantRoleAssignments(_ context.Context, tenantID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var subjects []string
+	for _, assignment := range s.assignments {
+		if assignment[2] == tenantID {
+			subjects = append(subjects, assignment[0])
+		}
+	}
+	s.remove(func(assignment []string) bool { return assignment[2] == tenantID })
+	return subjects, nil
+}
+
+// add reports whether the assignment is new, s.mu held
+func (s *memoryRoleStore) add(subject, role, tenantID string) bool {
+	assignment := []string{subject, role, tenantID}
+	if slices.ContainsFunc(s.assignments, func(existing []string) bool { return slices.Equal(existing, assignment) }) {
+		return false
+	}
+	s.assignments = append(s.assignments, assignment)
+	return true
+}
+
+// remove returns the number of assignments removed, s.mu held
+func (s *memoryRoleStore) remove(match func(assignment []string) bool) int {
+	before := len(s.assignments)
+	s.assignments = slices.DeleteFunc(s.assignments, match)
+	return before - len(s.assignments)
+}
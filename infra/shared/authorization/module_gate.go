@@ -0,0 +1,34 @@
+package authorization
+
+import (
+	check_module_enabled_use_case "github.com/nahualventure/class-backend/core/app/moduletoggle/application/use-cases/check-module-enabled-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// NewModuleMiddleware returns a Huma middleware that refuses the operations of modules disabled for
+// the tenant with MODULE_DISABLED, an additional check after the authorization middleware.
+// moduleOf returns the module that registered an operation, operations of no module and public ones
+// are not checked.
+func NewModuleMiddleware(moduleOf func(operationID string) (string, bool), checkModuleEnabledUseCase *check_module_enabled_use_case.CheckModuleEnabledUseCase) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		tenantID := TenantIDFromContext(ctx.Context())
+		module, ok := moduleOf(ctx.Operation().OperationID)
+		if tenantID == "" || !ok {
+			next(ctx)
+			return
+		}
+
+		command, err := check_module_enabled_use_case.NewCheckModuleEnabledCommand(tenantID, module)
+		if err == nil {
+			err = checkModuleEnabledUseCase.Execute(ctx.Context(), command)
+		}
+		if err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+
+		next(ctx)
+	}
+}
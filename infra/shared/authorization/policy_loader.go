@@ -1,8 +1,12 @@
 package authorization
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io/fs"
 	"os"
+	"slices"
 
 	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
 
@@ -12,7 +16,10 @@ import (
 
 // PolicyConfig represents the structure of the policies.yaml file
 type PolicyConfig struct {
-	Roles map[string]RoleConfig `yaml:"roles"`
+	Roles     map[string]RoleConfig `yaml:"roles"`
+	Endpoints EndpointAccessConfig  `yaml:"endpoints"`
+	StepUp    StepUpConfig          `yaml:"step_up"`
+	Signing   SigningConfig         `yaml:"signing"`
 }
 
 // RoleConfig represents a role and its permissions
@@ -23,6 +30,10 @@ type RoleConfig struct {
 // PolicyLoader handles loading and converting policies from YAML
 type PolicyLoader struct {
 	config *PolicyConfig
+	// hash is the SHA-256 of the file the config was loaded from
+	hash string
+	// tenantGrants are the policies Grant gives in some tenants only, as role, resource, action, tenant
+	tenantGrants [][]string
 }
 
 // NewPolicyLoader creates a new policy loader
@@ -38,6 +49,15 @@ func (p *PolicyLoader) LoadFromFile(filePath string) *appErrors.InfrastructureEr
 	return p.LoadFromBytes(data)
 }
 
+// LoadFromFS loads the policy file at path of fsys
+func (p *PolicyLoader) LoadFromFS(fsys fs.FS, path string) *appErrors.InfrastructureError {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return appErrors.NewInfrastructureError(fmt.Sprintf("failed to read policy file %s", path), err)
+	}
+	return p.LoadFromBytes(data)
+}
+
 // LoadFromBytes loads policy configuration from YAML bytes
 func (p *PolicyLoader) LoadFromBytes(data []byte) *appErrors.InfrastructureError {
 	config := &PolicyConfig{}
@@ -46,6 +66,8 @@ func (p *PolicyLoader) LoadFromBytes(data []byte) *appErrors.InfrastructureError
 	}
 
 	p.config = config
+	sum := sha256.Sum256(data)
+	p.hash = hex.EncodeToString(sum[:])
 	return nil
 }
 
@@ -56,41 +78,80 @@ func (p *PolicyLoader) LoadPoliciesIntoEnforcer(enforcer *casbin.Enforcer, tenan
 		return appErrors.NewInfrastructureError("policy config not loaded", nil)
 	}
 
-	// Clear existing policies (not role assignments)
-	enforcer.ClearPolicy()
+	// Clear existing policies, ClearPolicy would clear the role assignments as well
+	for _, assertion := range enforcer.GetModel()["p"] {
+		assertion.Policy = nil
+		assertion.PolicyMap = map[string]int{}
+	}
 
-	// Generate policies for each role and tenant combination
-	for roleName, roleConfig := range p.config.Roles {
-		for _, tenantID := range tenants {
-			if err := p.addRolePoliciesForTenant(enforcer, roleName, roleConfig, tenantID); err != nil {
-				return err
-			}
+	for _, policy := range p.Policies(tenants) {
+		if _, err := enforcer.AddPolicy(policy[0], policy[1], policy[2], policy[3]); err != nil {
+			return appErrors.NewInfrastructureError(fmt.Sprintf("failed to add policy %v", policy), err)
 		}
 	}
 
 	return nil
 }
 
-// addRolePoliciesForTenant adds all policies for a specific role in a specific tenant
-func (p *PolicyLoader) addRolePoliciesForTenant(enforcer *casbin.Enforcer, roleName string, roleConfig RoleConfig, tenantID string) *appErrors.InfrastructureError {
-	for resource, actions := range roleConfig.Permissions {
-		// Convert human-readable "all" to Casbin wildcard "*"
-		casbinResource := p.convertToCasbinWildcard(resource)
-
-		for _, action := range actions {
-			// Convert human-readable "all" to Casbin wildcard "*"
-			casbinAction := p.convertToCasbinWildcard(action)
+// Policies generates the policies of every role and tenant combination as role, resource, action,
+// tenant, the rules LoadPoliciesIntoEnforcer adds. Each rule is listed once, even when the file
+// repeats an action, spells it both "all" and "*", or tenants repeats a tenant.
+func (p *PolicyLoader) Policies(tenants []string) [][]string {
+	if p.config == nil {
+		return nil
+	}
 
-			// Add policy: role, resource, action, tenant
-			if _, err := enforcer.AddPolicy(roleName, casbinResource, casbinAction, tenantID); err != nil {
-				return appErrors.NewInfrastructureError(
-					fmt.Sprintf("failed to add policy [%s, %s, %s, %s]", roleName, casbinResource, casbinAction, tenantID),
-					err)
+	var policies [][]string
+	seen := make(map[[4]string]bool)
+	add := func(policy [4]string) {
+		if !seen[policy] {
+			seen[policy] = true
+			policies = append(policies, policy[:])
+		}
+	}
+	for roleName, roleConfig := range p.config.Roles {
+		for _, tenantID := range tenants {
+			for resource, actions := range roleConfig.Permissions {
+				// Convert human-readable "all" to Casbin wildcard "*"
+				casbinResource := p.convertToCasbinWildcard(resource)
+				for _, action := range actions {
+					add([4]string{roleName, casbinResource, p.convertToCasbinWildcard(action), tenantID})
+				}
 			}
 		}
 	}
+	for _, grant := range p.tenantGrants {
+		if slices.Contains(tenants, grant[3]) {
+			add([4]string(grant))
+		}
+	}
+	return policies
+}
 
-	return nil
+// Grant gives role the action on resource on top of the loaded config, in tenants only when any are
+// given. Tests declare their permissions with it instead of a policy file. "all" stands for every
+// resource or action as in the file.
+func (p *PolicyLoader) Grant(role, resource, action string, tenants ...string) {
+	if p.config == nil {
+		p.config = &PolicyConfig{}
+	}
+	if p.config.Roles == nil {
+		p.config.Roles = make(map[string]RoleConfig)
+	}
+	roleConfig := p.config.Roles[role]
+	if roleConfig.Permissions == nil {
+		roleConfig.Permissions = make(map[string][]string)
+	}
+	p.config.Roles[role] = roleConfig
+
+	if len(tenants) == 0 {
+		roleConfig.Permissions[resource] = append(roleConfig.Permissions[resource], action)
+		return
+	}
+	for _, tenantID := range tenants {
+		p.tenantGrants = append(p.tenantGrants,
+			[]string{role, p.convertToCasbinWildcard(resource), p.convertToCasbinWildcard(action), tenantID})
+	}
 }
 
 // convertToCasbinWildcard converts human-readable "all" to Casbin wildcard "*"
@@ -101,11 +162,42 @@ func (p *PolicyLoader) convertToCasbinWildcard(value string) string {
 	return value
 }
 
+// Hash returns the SHA-256 of the policy file loaded, hex encoded
+func (p *PolicyLoader) Hash() string {
+	return p.hash
+}
+
 // GetConfig returns the loaded policy configuration
 func (p *PolicyLoader) GetConfig() *PolicyConfig {
 	return p.config
 }
 
+// EndpointAccess returns the access mode of every operation: the endpoints section of the config
+// and the permissions of EndpointMapping
+func (p *PolicyLoader) EndpointAccess() (*EndpointAccess, *appErrors.InfrastructureError) {
+	if p.config == nil {
+		return nil, appErrors.NewInfrastructureError("policy config not loaded", nil)
+	}
+	return NewEndpointAccess(p.config.Endpoints, EndpointMapping)
+}
+
+// StepUp returns the operations that need a step up, checked against the access modes of access
+func (p *PolicyLoader) StepUp(access *EndpointAccess) (*StepUp, *appErrors.InfrastructureError) {
+	if p.config == nil {
+		return nil, appErrors.NewInfrastructureError("policy config not loaded", nil)
+	}
+	return NewStepUp(p.config.StepUp, access)
+}
+
+// Signing returns the operations that need a signed request, checked against the access modes of
+// access
+func (p *PolicyLoader) Signing(access *EndpointAccess) (*RequestSigning, *appErrors.InfrastructureError) {
+	if p.config == nil {
+		return nil, appErrors.NewInfrastructureError("policy config not loaded", nil)
+	}
+	return NewRequestSigning(p.config.Signing, access)
+}
+
 // GetRoles returns all defined role names
 func (p *PolicyLoader) GetRoles() []string {
 	if p.config == nil {
@@ -150,31 +242,15 @@ func (p *PolicyLoader) ValidateYAMLConfig() *appErrors.InfrastructureError {
 	return nil
 }
 
-// PrintLoadedPolicies prints all loaded policies for debugging
-func (p *PolicyLoader) PrintLoadedPolicies(enforcer *casbin.Enforcer) {
-	fmt.Println("=== Loaded Policies ===")
-
+// LoadedPolicies returns the policies and the role assignments loaded into enforcer
+func (p *PolicyLoader) LoadedPolicies(enforcer *casbin.Enforcer) (*PolicyDump, *appErrors.InfrastructureError) {
 	policies, err := enforcer.GetPolicy()
 	if err != nil {
-		fmt.Printf("error retrieving policies: %v\n", err)
-	} else if len(policies) == 0 {
-		fmt.Println("no policies found")
-	} else {
-		for _, policy := range policies {
-			fmt.Printf("Policy: %v\n", policy)
-		}
+		return nil, appErrors.NewInfrastructureError("failed to read loaded policies", err)
 	}
-
-	fmt.Println("\n=== Loaded Groupings ===")
-
 	groupings, err := enforcer.GetGroupingPolicy()
 	if err != nil {
-		fmt.Printf("error retrieving groupings: %v\n", err)
-	} else if len(groupings) == 0 {
-		fmt.Println("no groupings found")
-	} else {
-		for _, grouping := range groupings {
-			fmt.Printf("Grouping: %v\n", grouping)
-		}
+		return nil, appErrors.NewInfrastructureError("failed to read loaded role assignments", err)
 	}
+	return &PolicyDump{Policies: policies, Groupings: groupings}, nil
 }
@@ -0,0 +1,259 @@
+package authorization
+
+import (
+	"cmp"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"slices"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPreviewBody bounds the policy file posted to the preview
+const maxPreviewBody = 1 << 20
+
+// Grant is an action on a resource, "*" standing for every resource or action as in the policies
+type Grant struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+}
+
+// covers reports whether g allows everything other does
+func (g Grant) covers(other Grant) bool {
+	return (g.Resource == "*" || g.Resource == other.Resource) && (g.Action == "*" || g.Action == other.Action)
+}
+
+// GrantChange is what a role gains and loses in the tenants listed
+type GrantChange struct {
+	Role    string   `json:"role"`
+	Tenants []string `json:"tenants"`
+	Added   []Grant  `json:"added,omitempty"`
+	Removed []Grant  `json:"removed,omitempty"`
+}
+
+// PolicyPreview compares candidate policies with the ones an instance enforces, before they are
+// applied
+type PolicyPreview struct {
+	// LoadedHash and CandidateHash are the SHA-256 of the policy file loaded and of the candidate
+	LoadedHash    string `json:"loaded_hash"`
+	CandidateHash string `json:"candidate_hash"`
+	// Changes are the grants every role gains and loses, per tenant
+	Changes []GrantChange `json:"changes"`
+	// LosingAccess are the roles losing a grant in some tenant, sorted. Applying the candidate then
+	// takes a confirmation.
+	LosingAccess []string `json:"losing_access"`
+	// RestartRequired tells the endpoints, step_up or signing sections changed, they are only read at
+	// startup
+	RestartRequired bool `json:"restart_required"`
+}
+
+// NeedsConfirmation reports whether applying the candidate takes access away from a role
+func (p *PolicyPreview) NeedsConfirmation() bool {
+	return len(p.LosingAccess) > 0
+}
+
+// Confirmed reports whether the candidate can be applied with confirm, the hash of the candidate
+// previewed. The hash ties the confirmation to the revocations that were reviewed, a file changed
+// since is refused.
+func (p *PolicyPreview) Confirmed(confirm string) bool {
+	return !p.NeedsConfirmation() || confirm == p.CandidateHash
+}
+
+type roleTenant struct {
+	role   string
+	tenant string
+}
+
+// PreviewPolicies compares the candidate policies with the loaded ones, both as role, resource,
+// action, tenant. Grants are compared by what they allow, one still covered by a wildcard of the
+// role in the tenant is not lost. A role with the same changes in several tenants is listed once.
+func PreviewPolicies(loaded [][]string, candidate [][]string) *PolicyPreview {
+	loadedGrants, candidateGrants := grantsPerRole(loaded), grantsPerRole(candidate)
+	keys := make([]roleTenant, 0, len(loadedGrants)+len(candidateGrants))
+	for key := range loadedGrants {
+		keys = append(keys, key)
+	}
+	for key := range candidateGrants {
+		if _, found := loadedGrants[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b roleTenant) int {
+		return cmp.Or(cmp.Compare(a.role, b.role), cmp.Compare(a.tenant, b.tenant))
+	})
+
+	preview := &PolicyPreview{Changes: []GrantChange{}, LosingAccess: []string{}}
+	for _, key := range keys {
+		added := uncovered(candidateGrants[key], loadedGrants[key])
+		removed := uncovered(loadedGrants[key], candidateGrants[key])
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+		if len(removed) > 0 && !slices.Contains(preview.LosingAccess, key.role) {
+			preview.LosingAccess = append(preview.LosingAccess, key.role)
+		}
+
+		index := slices.IndexFunc(preview.Changes, func(change GrantChange) bool {
+			return change.Role == key.role && slices.Equal(change.Added, added) && slices.Equal(change.Removed, removed)
+		})
+		if index >= 0 {
+			preview.Changes[index].Tenants = append(preview.Changes[index].Tenants, key.tenant)
+			continue
+		}
+		preview.Changes = append(preview.Changes, GrantChange{Role: key.role, Tenants: []string{key.tenant}, Added: added, Removed: removed})
+	}
+	return preview
+}
+
+func grantsPerRole(policies [][]string) map[roleTenant][]Grant {
+	grants := make(map[roleTenant][]Grant)
+	for _, policy := range policies {
+		if len(policy) < 4 {
+			continue
+		}
+		key := roleTenant{role: policy[0], tenant: policy[3]}
+		grants[key] = append(grants[key], Grant{Resource: policy[1], Action: policy[2]})
+	}
+	return grants
+}
+
+// uncovered returns the grants of grants that none of others covers, sorted
+func uncovered(grants []Grant, others []Grant) []Grant {
+	var result []Grant
+	for _, grant := range grants {
+		covered := slices.ContainsFunc(others, func(other Grant) bool { return other.covers(grant) })
+		if !covered && !slices.Contains(result, grant) {
+			result = append(result, grant)
+		}
+	}
+	slices.SortFunc(result, func(a, b Grant) int {
+		return cmp.Or(cmp.Compare(a.Resource, b.Resource), cmp.Compare(a.Action, b.Action))
+	})
+	return result
+}
+
+// parseCandidate loads and validates candidate policies
+func parseCandidate(data []byte) (*PolicyLoader, *appErrors.InfrastructureError) {
+	candidate := NewPolicyLoader()
+	if err := candidate.LoadFromBytes(data); err != nil {
+		return nil, err
+	}
+	if err := candidate.ValidateYAMLConfig(); err != nil {
+		return nil, err
+	}
+	return candidate, nil
+}
+
+// preview compares the candidate with the policies the enforcer holds for the tenants of the instance
+func (c *CasbinService) preview(candidate *PolicyLoader) (*PolicyPreview, *appErrors.InfrastructureError) {
+	loaded, err := c.Policies()
+	if err != nil {
+		return nil, err
+	}
+	loader := c.loader()
+	preview := PreviewPolicies(loaded.Policies, candidate.Policies(c.Tenants()))
+	preview.LoadedHash, preview.CandidateHash = loader.Hash(), candidate.Hash()
+	preview.RestartRequired = !reflect.DeepEqual(candidate.GetConfig().Endpoints, loader.GetConfig().Endpoints) ||
+		!reflect.DeepEqual(candidate.GetConfig().StepUp, loader.GetConfig().StepUp) ||
+		!reflect.DeepEqual(candidate.GetConfig().Signing, loader.GetConfig().Signing)
+	return preview, nil
+}
+
+// PreviewPolicies compares the policies of a policy file with the ones the instance enforces
+func (c *CasbinService) PreviewPolicies(data []byte) (*PolicyPreview, *appErrors.InfrastructureError) {
+	candidate, err := parseCandidate(data)
+	if err != nil {
+		return nil, err
+	}
+	return c.preview(candidate)
+}
+
+// ApplyPolicies replaces the role permissions with the ones of a policy file and reports whether they
+// were applied. When a role loses access they are only applied with confirm set to the hash of the
+// file, as previewed. Files changing the endpoints, step_up or signing sections are never applied,
+// the instance reads those at startup only.
+func (c *CasbinService) ApplyPolicies(data []byte, confirm string) (*PolicyPreview, bool, *appErrors.InfrastructureError) {
+	candidate, err := parseCandidate(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+
+	preview, err := c.preview(candidate)
+	if err != nil {
+		return nil, false, err
+	}
+	if preview.RestartRequired || !preview.Confirmed(confirm) {
+		return preview, false, nil
+	}
+
+	enforcer, err := c.newEnforcer(candidate, c.Tenants())
+	if err != nil {
+		return nil, false, err
+	}
+	c.mu.Lock()
+	c.enforcer, c.policyLoader, c.loadedAt = enforcer, candidate, time.Now()
+	c.mu.Unlock()
+
+	log.Printf("policies %.12s applied in place of %.12s, %d role changes", preview.CandidateHash, preview.LoadedHash, len(preview.Changes))
+	return preview, true, nil
+}
+
+// PreviewHandler serves the preview of the policy file as it is now, or of the one posted
+func (c *CasbinService) PreviewHandler(ctx *gin.Context) {
+	data, ok := c.candidateFile(ctx)
+	if !ok {
+		return
+	}
+	preview, err := c.PreviewPolicies(data)
+	if err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, preview)
+}
+
+// ApplyHandler applies the policy file as it is now. It answers 409 with the preview when the file
+// needs a restart, or takes access away from a role and ?confirm= is not the hash of the file.
+func (c *CasbinService) ApplyHandler(ctx *gin.Context) {
+	data, err := c.readPolicyFile()
+	if err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	preview, applied, err := c.ApplyPolicies(data, ctx.Query("confirm"))
+	if err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	if !applied {
+		ctx.JSON(http.StatusConflict, preview)
+		return
+	}
+	ctx.JSON(http.StatusOK, preview)
+}
+
+// candidateFile reads the policy file posted, or the policy file of the instance on a GET
+func (c *CasbinService) candidateFile(ctx *gin.Context) ([]byte, bool) {
+	if ctx.Request.Method == http.MethodPost {
+		data, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxPreviewBody))
+		if err != nil {
+			ctx.AbortWithStatus(http.StatusBadRequest)
+			return nil, false
+		}
+		return data, true
+	}
+	data, err := c.readPolicyFile()
+	if err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return data, true
+}
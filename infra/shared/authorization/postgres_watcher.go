@@ -0,0 +1,134 @@
+package authorization
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const watcherChannel = "casbin_role_changes"
+
+// PostgresWatcher is a Casbin watcher that tells the other instances about role changes through
+// Postgres LISTEN/NOTIFY. It listens on a connection of its own, outside the pool, opened with
+// listenConfig. Notifications are sent through the pool.
+type PostgresWatcher struct {
+	pool         *pgxpool.Pool
+	listenConfig *pgx.ConnConfig
+	instanceID   string
+	mu           sync.Mutex
+	callback     func(string)
+	stop         context.CancelFunc
+}
+
+func NewPostgresWatcher(pool *pgxpool.Pool, listenConfig *pgx.ConnConfig) (*PostgresWatcher, *appErrors.InfrastructureError) {
+	ctx, stop := context.WithCancel(context.Background())
+	watcher := &PostgresWatcher{
+		pool:         pool,
+		listenConfig: listenConfig,
+		instanceID:   uuid.New().String(),
+		stop:         stop,
+	}
+
+	conn, err := watcher.listen(ctx)
+	if err != nil {
+		stop()
+		return nil, appErrors.NewInfrastructureError("failed to listen for role changes", err)
+	}
+	go watcher.run(ctx, conn)
+
+	return watcher, nil
+}
+
+// SetUpdateCallback sets the function called when another instance changed roles. Set it before
+// roles change, notifications received without a callback are dropped.
+func (w *PostgresWatcher) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.callback = callback
+	return nil
+}
+
+// Update notifies the other instances, the payload lets this instance skip its own notifications
+func (w *PostgresWatcher) Update() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := w.pool.Exec(ctx, "SELECT pg_notify($1, $2)", watcherChannel, w.instanceID)
+	return err
+}
+
+func (w *PostgresWatcher) Close() {
+	w.stop()
+}
+
+func (w *PostgresWatcher) listen(ctx context.Context) (*pgx.Conn, error) {
+	conn, err := pgx.ConnectConfig(ctx, w.listenConfig.Copy())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Exec(ctx, "LISTEN "+watcherChannel); err != nil {
+		_ = conn.Close(context.Background())
+		return nil, err
+	}
+	return conn, nil
+}
+
+// run delivers notifications until Close. After a lost connection the roles are reloaded once the
+// watcher listens again, changes made meanwhile were missed.
+func (w *PostgresWatcher) run(ctx context.Context, conn *pgx.Conn) {
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Printf("role change watcher lost its connection: %v", err)
+			_ = conn.Close(context.Background())
+			if conn = w.reconnect(ctx); conn == nil {
+				return
+			}
+			w.notify("reconnected")
+			continue
+		}
+
+		if notification.Payload != w.instanceID {
+			w.notify(notification.Payload)
+		}
+	}
+}
+
+func (w *PostgresWatcher) reconnect(ctx context.Context) *pgx.Conn {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Second):
+		}
+
+		conn, err := w.listen(ctx)
+		if err == nil {
+			return conn
+		}
+		log.Printf("role change watcher failed to reconnect: %v", err)
+	}
+}
+
+func (w *PostgresWatcher) notify(payload string) {
+	w.mu.Lock()
+	callback := w.callback
+	w.mu.Unlock()
+
+	if callback != nil {
+		callback(payload)
+	}
+}
@@ -0,0 +1,201 @@
+package authorization
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+
+	serviceAccountEntities "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// VisibilityTag on a field of a response names the permission, "<resource>:<action>", callers need to
+// see the field. The field is left empty for everybody else, so tagged fields should be omitempty.
+const VisibilityTag = "visibility"
+
+// Redactor strips the fields callers may not see from every response in one place, handlers return
+// the full responses and never check the visibility of fields themselves
+type Redactor struct {
+	authzService *CasbinService
+	// tagged caches whether a type holds fields with a visibility, most responses have none and are
+	// returned untouched
+	tagged sync.Map
+}
+
+func NewRedactor(authzService *CasbinService) *Redactor {
+	return &Redactor{authzService: authzService}
+}
+
+// Transformer is a Huma transformer that redacts the successful responses of the caller. Public
+// operations have no caller and get no tagged field. Permissions are checked once per response.
+func (r *Redactor) Transformer(ctx huma.Context, status string, v any) (any, error) {
+	if !strings.HasPrefix(status, "2") || v == nil || !r.mayHide(reflect.TypeOf(v)) {
+		return v, nil
+	}
+
+	return r.Redact(v, r.allowed(ctx.Context())), nil
+}
+
+// Visible reports whether the caller of ctx may see the fields of a visibility, for responses built
+// outside Huma such as proto messages, which carry no tags
+func (r *Redactor) Visible(ctx context.Context, permission string) bool {
+	return r.allowed(ctx)(permission)
+}
+
+// allowed decides the visibilities of the caller of ctx, checking each permission once
+func (r *Redactor) allowed(ctx context.Context) func(permission string) bool {
+	userID, tenantID := UserIDFromContext(ctx), TenantIDFromContext(ctx)
+	scopes, scoped := ScopesFromContext(ctx)
+	decisions := make(map[string]bool)
+	return func(permission string) bool {
+		allowed, decided := decisions[permission]
+		if decided {
+			return allowed
+		}
+		resource, action, ok := strings.Cut(permission, ":")
+		switch {
+		case !ok:
+			log.Printf("Field visibility %q is not <resource>:<action>, the field is hidden", permission)
+		case userID == "" || tenantID == "":
+			// Public and unauthenticated calls see no tagged field
+		case scoped && !serviceAccountEntities.ScopesAllow(scopes, resource, action):
+		default:
+			var err error
+			if allowed, err = r.authzService.CanDo(userID, resource, action, tenantID); err != nil {
+				log.Printf("Failed to check the visibility %s of a field, the field is hidden: %v", permission, err)
+			}
+		}
+		decisions[permission] = allowed
+		return allowed
+	}
+}
+
+// Redact returns v without the fields whose visibility allowed refuses. v is copied where fields are
+// removed and shared elsewhere, the value handlers returned is never changed.
+func (r *Redactor) Redact(v any, allowed func(permission string) bool) any {
+	if v == nil {
+		return nil
+	}
+	redacted, changed := r.redact(reflect.ValueOf(v), allowed)
+	if !changed {
+		return v
+	}
+	return redacted.Interface()
+}
+
+func (r *Redactor) redact(v reflect.Value, allowed func(permission string) bool) (reflect.Value, bool) {
+	if !r.mayHide(v.Type()) {
+		return v, false
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return v, false
+		}
+		inner, changed := r.redact(v.Elem(), allowed)
+		if !changed {
+			return v, false
+		}
+		if v.Kind() == reflect.Pointer {
+			out := reflect.New(v.Type().Elem())
+			out.Elem().Set(inner)
+			return out, true
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(inner)
+		return out, true
+
+	case reflect.Struct:
+		var out reflect.Value
+		set := func(i int, value reflect.Value) {
+			if !out.IsValid() {
+				out = reflect.New(v.Type()).Elem()
+				out.Set(v)
+			}
+			out.Field(i).Set(value)
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if permission := field.Tag.Get(VisibilityTag); permission != "" && !v.Field(i).IsZero() && !allowed(permission) {
+				set(i, reflect.Zero(field.Type))
+				continue
+			}
+			if inner, changed := r.redact(v.Field(i), allowed); changed {
+				set(i, inner)
+			}
+		}
+		return out, out.IsValid()
+
+	case reflect.Slice, reflect.Array:
+		var out reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			inner, changed := r.redact(v.Index(i), allowed)
+			if !changed {
+				continue
+			}
+			if !out.IsValid() {
+				if v.Kind() == reflect.Slice {
+					out = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+					reflect.Copy(out, v)
+				} else {
+					out = reflect.New(v.Type()).Elem()
+					out.Set(v)
+				}
+			}
+			out.Index(i).Set(inner)
+		}
+		return out, out.IsValid()
+
+	case reflect.Map:
+		var out reflect.Value
+		iter := v.MapRange()
+		for iter.Next() {
+			inner, changed := r.redact(iter.Value(), allowed)
+			if !changed {
+				continue
+			}
+			if !out.IsValid() {
+				out = reflect.MakeMapWithSize(v.Type(), v.Len())
+				copyIter := v.MapRange()
+				for copyIter.Next() {
+					out.SetMapIndex(copyIter.Key(), copyIter.Value())
+				}
+			}
+			out.SetMapIndex(iter.Key(), inner)
+		}
+		return out, out.IsValid()
+	}
+	return v, false
+}
+
+// mayHide reports whether values of t can hold a field with a visibility. Interfaces may hold
+// anything and are looked into.
+func (r *Redactor) mayHide(t reflect.Type) bool {
+	if cached, ok := r.tagged.Load(t); ok {
+		return cached.(bool)
+	}
+	// A type that refers to itself is not tagged through the reference, its fields decide
+	r.tagged.Store(t, false)
+
+	tagged := false
+	switch t.Kind() {
+	case reflect.Interface:
+		tagged = true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		tagged = r.mayHide(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField() && !tagged; i++ {
+			field := t.Field(i)
+			tagged = field.IsExported() && (field.Tag.Get(VisibilityTag) != "" || r.mayHide(field.Type))
+		}
+	}
+	r.tagged.Store(t, tagged)
+	return tagged
+}
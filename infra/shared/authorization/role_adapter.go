@@ -1,6 +1,7 @@
 package authorization
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -33,6 +34,161 @@ func NewRoleOnlyPostgresAdapter(db *sql.DB) (*RoleOnlyPostgresAdapter, *appError
 	}, nil
 }
 
+// Ping checks that the casbin_rule table can be read
+func (a *RoleOnlyPostgresAdapter) Ping(ctx context.Context) error {
+	var exists bool
+	return a.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM casbin_rule LIMIT 1)").Scan(&exists)
+}
+
+// CountPolicyRows counts the policy rows of casbin_rule. LoadPolicy skips them, so any row is an
+// override someone expects to apply and which does not.
+func (a *RoleOnlyPostgresAdapter) CountPolicyRows(ctx context.Context) (int, error) {
+	var count int
+	err := a.db.QueryRowContext(ctx, "SELECT count(*) FROM casbin_rule WHERE ptype LIKE 'p%'").Scan(&count)
+	return count, err
+}
+
+// AddRoleAssignments gives role in tenantID to every subject in a single transaction, chunkSize
+// subjects per statement. progress is called after every chunk with the subjects processed and the
+// assignments that did not exist yet, which are not visible to anyone until the transaction commits.
+// It returns the assignments added.
+func (a *RoleOnlyPostgresAdapter) AddRoleAssignments(ctx context.Context, subjects []string, role string, tenantID string,
+	chunkSize int, progress func(processed int, assigned int)) (int, error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	assigned := 0
+	for start := 0; start < len(subjects); start += chunkSize {
+		end := min(start+chunkSize, len(subjects))
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO casbin_rule (ptype, v0, v1, v2, v3, v4, v5)
+			SELECT 'g', subject, $2, $3, '', '', '' FROM unnest($1::text[]) AS subject
+			ON CONFLICT ON CONSTRAINT casbin_rule_unique DO NOTHING
+		`, subjects[start:end], role, tenantID)
+		if err != nil {
+			return 0, err
+		}
+		added, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		assigned += int(added)
+		if progress != nil {
+			progress(end, assigned)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return assigned, nil
+}
+
+// CopyRoleAssignments copies the role assignments of tenantID to toTenantID in one transaction, except
+// the ones of the roles excluded and of subjects starting with skipPrefix. It returns the subjects
+// whose assignments were copied, sorted, and how many assignments toTenantID did not have yet.
+func (a *RoleOnlyPostgresAdapter) CopyRoleAssignments(ctx context.Context, tenantID string, toTenantID string, excluded []string,
+	skipPrefix string) ([]string, int, error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT DISTINCT v0 FROM casbin_rule
+		WHERE ptype = 'g' AND v2 = $1 AND v1 <> ALL($2::text[]) AND NOT starts_with(v0, $3)
+		ORDER BY v0
+	`, tenantID, excluded, skipPrefix)
+	if err != nil {
+		return nil, 0, err
+	}
+	var subjects []string
+	for rows.Next() {
+		var subject string
+		if err := rows.Scan(&subject); err != nil {
+			rows.Close()
+			return nil, 0, err
+		}
+		subjects = append(subjects, subject)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO casbin_rule (ptype, v0, v1, v2, v3, v4, v5)
+		SELECT 'g', v0, v1, $4, '', '', '' FROM casbin_rule
+		WHERE ptype = 'g' AND v2 = $1 AND v1 <> ALL($2::text[]) AND NOT starts_with(v0, $3)
+		ON CONFLICT ON CONSTRAINT casbin_rule_unique DO NOTHING
+	`, tenantID, excluded, skipPrefix, toTenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+	copied, err := result.RowsAffected()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, err
+	}
+	return subjects, int(copied), nil
+}
+
+// RemoveRoleAssignmentPairs removes role roles[i] in tenantID from subjects[i], every pair in one
+// statement. It returns the assignments removed, the ones already gone are skipped.
+func (a *RoleOnlyPostgresAdapter) RemoveRoleAssignmentPairs(ctx context.Context, subjects []string, roles []string, tenantID string) (int, error) {
+	result, err := a.db.ExecContext(ctx, `
+		DELETE FROM casbin_rule r
+		USING unnest($1::text[], $2::text[]) AS pair(subject, role)
+		WHERE r.ptype = 'g' AND r.v0 = pair.subject AND r.v1 = pair.role AND r.v2 = $3
+	`, subjects, roles, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	removed, err := result.RowsAffected()
+	return int(removed), err
+}
+
+// AddRoleAssignmentPairs gives role roles[i] in tenantID to subjects[i], every pair in one statement.
+// It returns the assignments added, the ones that exist are skipped.
+func (a *RoleOnlyPostgresAdapter) AddRoleAssignmentPairs(ctx context.Context, subjects []string, roles []string, tenantID string) (int, error) {
+	result, err := a.db.ExecContext(ctx, `
+		INSERT INTO casbin_rule (ptype, v0, v1, v2, v3, v4, v5)
+		SELECT 'g', pair.subject, pair.role, $3, '', '', '' FROM unnest($1::text[], $2::text[]) AS pair(subject, role)
+		ON CONFLICT ON CONSTRAINT casbin_rule_unique DO NOTHING
+	`, subjects, roles, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	added, err := result.RowsAffected()
+	return int(added), err
+}
+
+// RemoveTenantRoleAssignments removes every role assignment of a tenant and returns the subjects that
+// had one
+func (a *RoleOnlyPostgresAdapter) RemoveTenantRoleAssignments(ctx context.Context, tenantID string) ([]string, error) {
+	rows, err := a.db.QueryContext(ctx, "DELETE FROM casbin_rule WHERE ptype = 'g' AND v2 = $1 RETURNING v0", tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subjects []string
+	for rows.Next() {
+		var subject string
+		if err := rows.Scan(&subject); err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, subject)
+	}
+	return subjects, rows.Err()
+}
+
 // LoadPolicy loads only role assignments (g records) from database
 // Policies (p records) are intentionally skipped as they are managed in memory
 func (a *RoleOnlyPostgresAdapter) LoadPolicy(model model.Model) error {
@@ -54,12 +210,14 @@ func (a *RoleOnlyPostgresAdapter) LoadPolicy(model model.Model) error {
 			return appErrors.NewInfrastructureError("failed to scan role assignment row", err)
 		}
 
-		// Build rule from non-null values
+		// Build rule keeping the position of every value up to the last one set, a row missing its role
+		// still tells its tenant and is set aside with it
 		var rule []string
 		for _, v := range []sql.NullString{v0, v1, v2, v3, v4, v5} {
-			if v.Valid && v.String != "" {
-				rule = append(rule, v.String)
-			}
+			rule = append(rule, v.String)
+		}
+		for len(rule) > 0 && rule[len(rule)-1] == "" {
+			rule = rule[:len(rule)-1]
 		}
 
 		if len(rule) > 0 {
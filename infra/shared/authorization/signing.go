@@ -0,0 +1,267 @@
+package authorization
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	"github.com/nahualventure/class-backend/proto/client"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// maxSignedBody bounds the body read to check a signature
+const maxSignedBody = 10 << 20
+
+// minSigningKeyLength is the shortest secret accepted, HMAC-SHA256 keys shorter than the hash
+// weaken it
+const minSigningKeyLength = 32
+
+// SigningConfig is the signing section of policies.yaml, the operations whose requests must be
+// signed with a signing key on top of their bearer token
+type SigningConfig struct {
+	// MaxAge is how far the time a request was signed at may be from the time of the instance
+	MaxAge     time.Duration `yaml:"max_age"`
+	Operations []string      `yaml:"operations"`
+}
+
+// RequestSigning tells which operations need a signed request, built once at startup and read-only
+// after
+type RequestSigning struct {
+	maxAge     time.Duration
+	operations map[string]bool
+}
+
+// NewRequestSigning checks the operations of config against access. Public operations have no
+// bearer token to protect and unknown ones are most likely typos, both are refused.
+func NewRequestSigning(config SigningConfig, access *EndpointAccess) (*RequestSigning, *appErrors.InfrastructureError) {
+	signing := &RequestSigning{
+		maxAge:     config.MaxAge,
+		operations: make(map[string]bool, len(config.Operations)),
+	}
+	if len(config.Operations) > 0 && config.MaxAge <= 0 {
+		return nil, appErrors.NewInfrastructureError("invalid signing: max_age must be positive", nil)
+	}
+
+	var invalid []string
+	for _, operationID := range config.Operations {
+		switch access.Mode(operationID) {
+		case AccessDenied:
+			invalid = append(invalid, operationID+" is not an operation")
+		case AccessPublic:
+			invalid = append(invalid, operationID+" is public")
+		default:
+			signing.operations[operationID] = true
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("invalid signing: %v", invalid), nil)
+	}
+	return signing, nil
+}
+
+// Requires reports whether an operation needs a signed request
+func (s *RequestSigning) Requires(operationID string) bool {
+	return s.operations[operationID]
+}
+
+// SignedRequest is what a signature is checked against, as received
+type SignedRequest struct {
+	Method     string
+	RequestURI string
+	TenantID   string
+	KeyID      string
+	Timestamp  string
+	Signature  string
+	Body       []byte
+}
+
+// signaturePruneInterval is how often the expired signatures are forgotten
+const signaturePruneInterval = time.Minute
+
+// SignatureStore records the signatures accepted by every instance until they expire, so a captured
+// request is refused whichever instance it is sent to
+type SignatureStore interface {
+	// Claim records signature until expiresAt and reports false when it was recorded already
+	Claim(ctx context.Context, signature string, expiresAt time.Time) (bool, error)
+	// DeleteExpired removes the signatures expired at now
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// SigningKeys verifies request signatures. Each signature is accepted once across the instances
+// sharing store, a request captured with its signature cannot be sent again, and not at all once
+// max_age passed. The signatures accepted by the instance are also kept in memory, a replay to the
+// same instance is refused without a query.
+type SigningKeys struct {
+	keys  map[string][]byte
+	store SignatureStore
+
+	mu sync.Mutex
+	// seen are the signatures accepted until they expire
+	seen map[string]time.Time
+}
+
+// NewSigningKeys parses kid:secret entries, signatures are not checked without any. Keys are rotated
+// by adding the new one, moving the clients to it and removing the old one. A nil store only refuses
+// the replays sent to the same instance.
+func NewSigningKeys(entries []string, store SignatureStore) (*SigningKeys, error) {
+	keys := &SigningKeys{keys: make(map[string][]byte, len(entries)), store: store, seen: make(map[string]time.Time)}
+	for _, entry := range entries {
+		id, secret, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("request signing key %q is not kid:secret", id)
+		}
+		if len(secret) < minSigningKeyLength {
+			return nil, fmt.Errorf("request signing key %q is shorter than %d bytes", id, minSigningKeyLength)
+		}
+		keys.keys[id] = []byte(secret)
+	}
+	return keys, nil
+}
+
+// Enabled reports whether signatures are checked
+func (k *SigningKeys) Enabled() bool {
+	return k != nil && len(k.keys) > 0
+}
+
+// Verify returns the error to send back when request is not signed with a key within maxAge of now,
+// or its signature was accepted before. A store that cannot be reached refuses the request, a
+// replay cannot be told apart meanwhile.
+func (k *SigningKeys) Verify(ctx context.Context, request SignedRequest, maxAge time.Duration, now time.Time) error {
+	if request.KeyID == "" || request.Timestamp == "" || request.Signature == "" {
+		return appErrors.NewSignatureRequiredError("missing")
+	}
+	secret, found := k.keys[request.KeyID]
+	if !found {
+		return appErrors.NewSignatureRequiredError("unknown key")
+	}
+	timestamp, err := strconv.ParseInt(request.Timestamp, 10, 64)
+	if err != nil {
+		return appErrors.NewSignatureRequiredError("invalid timestamp")
+	}
+	signedAt := time.Unix(timestamp, 0)
+	if age := now.Sub(signedAt); age > maxAge || age < -maxAge {
+		return appErrors.NewSignatureRequiredError("expired")
+	}
+	base := client.SignatureBase(request.Method, request.RequestURI, request.TenantID, timestamp, request.Body)
+	if !hmac.Equal([]byte(client.Signature(secret, base)), []byte(request.Signature)) {
+		return appErrors.NewSignatureRequiredError("invalid")
+	}
+
+	expiresAt := signedAt.Add(maxAge)
+	k.mu.Lock()
+	_, replayed := k.seen[request.Signature]
+	if !replayed && k.store == nil {
+		k.seen[request.Signature] = expiresAt
+	}
+	k.mu.Unlock()
+	if replayed {
+		return appErrors.NewSignatureRequiredError("replayed")
+	}
+	if k.store == nil {
+		return nil
+	}
+
+	claimed, err := k.store.Claim(ctx, request.Signature, expiresAt)
+	if err != nil {
+		return appErrors.NewServiceUnavailableError("request signing", err)
+	}
+	if !claimed {
+		return appErrors.NewSignatureRequiredError("replayed")
+	}
+	k.mu.Lock()
+	k.seen[request.Signature] = expiresAt
+	k.mu.Unlock()
+	return nil
+}
+
+// Prune forgets the signatures expired at now, in memory and in the store
+func (k *SigningKeys) Prune(ctx context.Context, now time.Time) error {
+	k.mu.Lock()
+	for signature, expiresAt := range k.seen {
+		if now.After(expiresAt) {
+			delete(k.seen, signature)
+		}
+	}
+	k.mu.Unlock()
+
+	if k.store == nil {
+		return nil
+	}
+	_, err := k.store.DeleteExpired(ctx, now)
+	return err
+}
+
+// Run prunes the expired signatures every minute until ctx is cancelled
+func (k *SigningKeys) Run(ctx context.Context) {
+	ticker := time.NewTicker(signaturePruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := k.Prune(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				log.Printf("Failed to prune expired request signatures: %v", err)
+			}
+		}
+	}
+}
+
+// humaContext names the embedded context of signedContext, whose Context method a field named
+// Context would hide
+type humaContext = huma.Context
+
+// signedContext serves the body read for the signature to the handler
+type signedContext struct {
+	humaContext
+	body io.Reader
+}
+
+func (c signedContext) BodyReader() io.Reader {
+	return c.body
+}
+
+// NewSigningMiddleware refuses the operations of the signing section with SIGNATURE_REQUIRED unless
+// they are signed with one of keys. It runs after the step up middleware, so callers are asked for
+// the permission and a recent sign in before the signature. Nothing is checked without keys.
+func NewSigningMiddleware(signing *RequestSigning, keys *SigningKeys) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if !keys.Enabled() || !signing.Requires(ctx.Operation().OperationID) {
+			next(ctx)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(ctx.BodyReader(), maxSignedBody+1))
+		if err != nil || len(body) > maxSignedBody {
+			utils.WriteApplicationError(ctx, appErrors.NewSignatureRequiredError("body too large to sign"))
+			return
+		}
+		url := ctx.URL()
+		request := SignedRequest{
+			Method:     ctx.Method(),
+			RequestURI: url.RequestURI(),
+			TenantID:   ctx.Header("X-Tenant-Id"),
+			KeyID:      ctx.Header(client.SignatureKeyHeader),
+			Timestamp:  ctx.Header(client.SignatureTimestampHeader),
+			Signature:  ctx.Header(client.SignatureHeader),
+			Body:       body,
+		}
+		if err := keys.Verify(ctx.Context(), request, signing.maxAge, time.Now()); err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+		next(signedContext{humaContext: ctx, body: bytes.NewReader(body)})
+	}
+}
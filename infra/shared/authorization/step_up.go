@@ -0,0 +1,122 @@
+package authorization
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	serviceAccountEntities "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// clockSkew is how far in the future an auth_time may be, the gateway and this instance disagree
+// about the time by a little
+const clockSkew = time.Minute
+
+// StepUpConfig is the step_up section of policies.yaml, the operations that need a recent
+// authentication of the user whatever their session
+type StepUpConfig struct {
+	// MaxAge is how long ago the user may have authenticated
+	MaxAge time.Duration `yaml:"max_age"`
+	// ACR lists the authentication classes that count, any class when empty
+	ACR        []string `yaml:"acr"`
+	Operations []string `yaml:"operations"`
+}
+
+// StepUp tells which operations need a step up and whether a request made one, built once at
+// startup and read-only after
+type StepUp struct {
+	maxAge     time.Duration
+	acr        []string
+	operations map[string]bool
+}
+
+// NewStepUp checks the operations of config against access. Public operations have no user to
+// authenticate again and unknown ones are most likely typos, both are refused.
+func NewStepUp(config StepUpConfig, access *EndpointAccess) (*StepUp, *appErrors.InfrastructureError) {
+	stepUp := &StepUp{
+		maxAge:     config.MaxAge,
+		acr:        config.ACR,
+		operations: make(map[string]bool, len(config.Operations)),
+	}
+	if len(config.Operations) > 0 && config.MaxAge <= 0 {
+		return nil, appErrors.NewInfrastructureError("invalid step up: max_age must be positive", nil)
+	}
+
+	var invalid []string
+	for _, operationID := range config.Operations {
+		switch access.Mode(operationID) {
+		case AccessDenied:
+			invalid = append(invalid, operationID+" is not an operation")
+		case AccessPublic:
+			invalid = append(invalid, operationID+" is public")
+		default:
+			stepUp.operations[operationID] = true
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return nil, appErrors.NewInfrastructureError(fmt.Sprintf("invalid step up: %v", invalid), nil)
+	}
+	return stepUp, nil
+}
+
+// Requires reports whether an operation needs a step up
+func (s *StepUp) Requires(operationID string) bool {
+	return s.operations[operationID]
+}
+
+// Check returns the error to send back when the request did not authenticate recently enough or
+// strongly enough at now. Service accounts have no session to step up and are let through, their
+// keys are what the token policies guard.
+func (s *StepUp) Check(meta requestmeta.Meta, now time.Time) error {
+	if serviceAccountEntities.IsServiceAccountSubject(meta.UserID) {
+		return nil
+	}
+	authTime, err := strconv.ParseInt(meta.AuthTime, 10, 64)
+	if err != nil {
+		return appErrors.NewStepUpRequiredError(s.maxAge, s.acr)
+	}
+	age := now.Sub(time.Unix(authTime, 0))
+	if age > s.maxAge || age < -clockSkew {
+		return appErrors.NewStepUpRequiredError(s.maxAge, s.acr)
+	}
+	if len(s.acr) > 0 && !slices.Contains(s.acr, meta.AuthACR) {
+		return appErrors.NewStepUpRequiredError(s.maxAge, s.acr)
+	}
+	return nil
+}
+
+// challenge is the WWW-Authenticate header of a step up, as in RFC 9470
+func (s *StepUp) challenge() string {
+	challenge := fmt.Sprintf(`Bearer error="insufficient_user_authentication", max_age=%d`, int(s.maxAge.Seconds()))
+	if len(s.acr) > 0 {
+		challenge += fmt.Sprintf(`, acr_values="%s"`, strings.Join(s.acr, " "))
+	}
+	return challenge
+}
+
+// NewStepUpMiddleware refuses the operations of the step_up section with STEP_UP_REQUIRED until the
+// user authenticates again. It runs after the authorization middleware, so callers without the
+// permission are told so before being asked to sign in again.
+func NewStepUpMiddleware(stepUp *StepUp) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if !stepUp.Requires(ctx.Operation().OperationID) {
+			next(ctx)
+			return
+		}
+		if err := stepUp.Check(requestmeta.FromHuma(ctx), time.Now()); err != nil {
+			ctx.SetHeader("WWW-Authenticate", stepUp.challenge())
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+		next(ctx)
+	}
+}
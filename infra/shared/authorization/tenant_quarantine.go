@@ -0,0 +1,92 @@
+package authorization
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+)
+
+// tenantQuarantine loads the role assignments of a store and sets aside the tenants with an invalid
+// one. Casbin refuses the whole load for a single row it cannot read, a row without a role in one
+// tenant would take authorization down for every tenant. The tenants set aside keep the assignments
+// previous holds for them.
+type tenantQuarantine struct {
+	persist.Adapter
+	// previous is the enforcer being replaced, nil at startup
+	previous *casbin.Enforcer
+	// failures are the tenants set aside by the last load, with the first invalid row of each
+	failures map[string]error
+}
+
+func (q *tenantQuarantine) LoadPolicy(m model.Model) error {
+	if err := q.Adapter.LoadPolicy(m); err != nil {
+		return err
+	}
+	assertion, found := m["g"]["g"]
+	if !found {
+		return nil
+	}
+
+	loaded := assertion.Policy
+	q.failures = make(map[string]error)
+	ignored := 0
+	for _, rule := range loaded {
+		if err := validateAssignment(rule); err != nil {
+			if len(rule) < 3 || rule[2] == "" {
+				ignored++
+				continue
+			}
+			if _, found := q.failures[rule[2]]; !found {
+				q.failures[rule[2]] = err
+			}
+		}
+	}
+	if ignored > 0 {
+		log.Printf("%d role assignments without a tenant ignored, they grant nothing", ignored)
+	}
+
+	// Rebuilt through AddPolicy, adapters appending to Policy directly leave PolicyMap out of date
+	assertion.Policy, assertion.PolicyMap = nil, make(map[string]int)
+	add := func(rule []string) error {
+		if exists, err := m.HasPolicy("g", "g", rule); err != nil || exists {
+			return err
+		}
+		return m.AddPolicy("g", "g", slices.Clone(rule))
+	}
+	for _, rule := range loaded {
+		if validateAssignment(rule) != nil || q.failures[rule[2]] != nil {
+			continue
+		}
+		if err := add(rule); err != nil {
+			return err
+		}
+	}
+	if q.previous == nil {
+		return nil
+	}
+	for tenantID := range q.failures {
+		kept, err := q.previous.GetFilteredGroupingPolicy(2, tenantID)
+		if err != nil {
+			return err
+		}
+		for _, rule := range kept {
+			if err := add(rule); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateAssignment checks a role assignment is a subject, a role and a tenant
+func validateAssignment(rule []string) error {
+	if len(rule) != 3 || slices.Contains(rule, "") {
+		return fmt.Errorf("invalid role assignment [%s], expected subject, role and tenant", strings.Join(rule, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+)
+
+// TTLCache is a small in-process cache for read-mostly data that may be slightly stale.
+// Entries are evicted lazily on access and when the cache reaches maxEntries.
+type TTLCache[K comparable, V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[K]entry[V]
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+func NewTTLCache[K comparable, V any](ttl time.Duration, maxEntries int) *TTLCache[K, V] {
+	return &TTLCache[K, V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[K]entry[V]),
+	}
+}
+
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if !ok || time.Now().After(cached.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+
+	return cached.value, true
+}
+
+// Lookup is Get counted as a hit or miss of the request of ctx
+func (c *TTLCache[K, V]) Lookup(ctx context.Context, key K) (V, bool) {
+	value, ok := c.Get(key)
+	cost.RecordCache(ctx, ok)
+	return value, ok
+}
+
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= c.maxEntries {
+		for k, cached := range c.entries {
+			if now.After(cached.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	// Still full: drop everything rather than tracking recency, the cache refills quickly
+	if len(c.entries) >= c.maxEntries {
+		c.entries = make(map[K]entry[V])
+	}
+
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// Delete removes a key, used when the cached data is known to have changed
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
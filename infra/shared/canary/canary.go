@@ -0,0 +1,89 @@
+package canary
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Cohort is the side of a rollout a tenant is on
+type Cohort string
+
+const (
+	// CohortStable tenants are served by the implementation in production
+	CohortStable Cohort = "stable"
+	// CohortCanary tenants are served by the candidate implementation being rolled out
+	CohortCanary Cohort = "canary"
+)
+
+// Cohorts lists the cohorts in the order they are reported
+var Cohorts = []Cohort{CohortStable, CohortCanary}
+
+// Rollout sends a share of the tenants, and the pilot tenants whatever the share, to the candidate
+// implementation of a port. A tenant keeps its cohort while the share grows, tenants are added to
+// the canary and none are taken out.
+type Rollout struct {
+	Name    string
+	Percent int
+	Pilots  []string
+}
+
+// CohortOf returns the cohort of the tenant, requests without a tenant stay on the stable side
+func (r Rollout) CohortOf(tenantID string) Cohort {
+	if tenantID == "" {
+		return CohortStable
+	}
+	if slices.Contains(r.Pilots, tenantID) {
+		return CohortCanary
+	}
+	if bucket(r.Name, tenantID) < r.Percent {
+		return CohortCanary
+	}
+	return CohortStable
+}
+
+// bucket places the tenant between 0 and 99, salted with the rollout so every rollout picks
+// different tenants
+func bucket(rollout string, tenantID string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(rollout + "/" + tenantID))
+	return int(hash.Sum32() % 100)
+}
+
+// ParseRollouts reads name=spec entries, the spec lists the share of tenants such as 10% and the
+// pilot tenants separated by |, e.g. grading-engine=10%|north|south. Rollouts without a share only
+// send their pilots.
+func ParseRollouts(entries []string) (map[string]Rollout, error) {
+	rollouts := make(map[string]Rollout, len(entries))
+	for _, entry := range entries {
+		name, spec, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("canary rollout %q is not name=percent%%|tenant", entry)
+		}
+		if _, duplicated := rollouts[name]; duplicated {
+			return nil, fmt.Errorf("canary rollout %q is listed twice", name)
+		}
+
+		rollout := Rollout{Name: name}
+		for _, part := range strings.Split(spec, "|") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			if share, isPercent := strings.CutSuffix(part, "%"); isPercent {
+				percent, err := strconv.Atoi(share)
+				if err != nil || percent < 0 || percent > 100 {
+					return nil, fmt.Errorf("canary rollout %q sends %q of the tenants, it takes 0%% to 100%%", name, part)
+				}
+				rollout.Percent = percent
+				continue
+			}
+			rollout.Pilots = append(rollout.Pilots, part)
+		}
+		rollouts[name] = rollout
+	}
+	return rollouts, nil
+}
@@ -0,0 +1,248 @@
+package canary
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Report states of the canary comparison served by /canary
+const (
+	StatusOK = "OK"
+	// StatusRegressed means a canary fails more often or answers slower than the stable side of its
+	// rollout, the rollout should be stopped before its share grows
+	StatusRegressed = "REGRESSED"
+)
+
+const (
+	// MinCalls is how many calls each cohort needs before the cohorts are compared
+	MinCalls = 100
+	// ErrorRateMargin is how much higher the error rate of a canary may be than the stable one
+	ErrorRateMargin = 0.01
+	// LatencyFactor is how many times slower than the stable one a canary may answer on average
+	LatencyFactor = 1.5
+)
+
+type cohortCounts struct {
+	calls    int64
+	errors   int64
+	duration time.Duration
+}
+
+type seriesKey struct {
+	rollout string
+	cohort  Cohort
+}
+
+// Router keeps the rollouts of the instance and counts the calls every cohort makes through their
+// switches. Counts start over when the instance restarts, comparisons across instances use the
+// canary_calls_total counters.
+type Router struct {
+	rollouts map[string]Rollout
+
+	mu       sync.Mutex
+	switched []string
+	counts   map[seriesKey]*cohortCounts
+}
+
+func NewRouter(rollouts map[string]Rollout) *Router {
+	return &Router{
+		rollouts: rollouts,
+		counts:   make(map[seriesKey]*cohortCounts),
+	}
+}
+
+// Rollout returns the rollout of the name, rollouts that are not configured send no tenant
+func (r *Router) Rollout(name string) Rollout {
+	if rollout, configured := r.rollouts[name]; configured {
+		return rollout
+	}
+	return Rollout{Name: name}
+}
+
+// Unswitched lists the configured rollouts no switch was built for, their names are likely mistyped
+func (r *Router) Unswitched() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var names []string
+	for name := range r.rollouts {
+		if !slices.Contains(r.switched, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *Router) register(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if slices.Contains(r.switched, name) {
+		return
+	}
+	r.switched = append(r.switched, name)
+	sort.Strings(r.switched)
+	for _, cohort := range Cohorts {
+		r.counts[seriesKey{name, cohort}] = &cohortCounts{}
+	}
+}
+
+func (r *Router) record(name string, cohort Cohort, duration time.Duration, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counts := r.counts[seriesKey{name, cohort}]
+	counts.calls++
+	counts.duration += duration
+	if failed {
+		counts.errors++
+	}
+}
+
+// Switch holds the stable and candidate implementations of a port and calls the one of the cohort
+// of the tenant
+type Switch[T any] struct {
+	router    *Router
+	rollout   string
+	stable    T
+	candidate T
+}
+
+func NewSwitch[T any](router *Router, rollout string, stable T, candidate T) *Switch[T] {
+	router.register(rollout)
+	return &Switch[T]{
+		router:    router,
+		rollout:   rollout,
+		stable:    stable,
+		candidate: candidate,
+	}
+}
+
+// Pick returns the implementation of the tenant without counting the call
+func (s *Switch[T]) Pick(tenantID string) (T, Cohort) {
+	cohort := s.router.Rollout(s.rollout).CohortOf(tenantID)
+	if cohort == CohortCanary {
+		return s.candidate, cohort
+	}
+	return s.stable, cohort
+}
+
+// Run calls the implementation of the tenant and counts the call, its duration and its error for
+// the cohort. Every error counts, both cohorts get their share of the mistakes of callers.
+func (s *Switch[T]) Run(tenantID string, call func(implementation T) error) error {
+	implementation, cohort := s.Pick(tenantID)
+	started := time.Now()
+	err := call(implementation)
+	s.router.record(s.rollout, cohort, time.Since(started), err != nil)
+	return err
+}
+
+// CohortStatus is what the calls of a cohort of a rollout measured on this instance
+type CohortStatus struct {
+	Cohort        Cohort  `json:"cohort"`
+	Calls         int64   `json:"calls"`
+	Errors        int64   `json:"errors"`
+	ErrorRate     float64 `json:"error_rate"`
+	MeanLatencyMs float64 `json:"mean_latency_ms"`
+}
+
+// RolloutStatus compares the cohorts of a rollout, pilots are counted and not named
+type RolloutStatus struct {
+	Name    string         `json:"name"`
+	Percent int            `json:"percent"`
+	Pilots  int            `json:"pilots"`
+	Status  string         `json:"status"`
+	Cohorts []CohortStatus `json:"cohorts"`
+}
+
+type Report struct {
+	Status   string          `json:"status"`
+	Rollouts []RolloutStatus `json:"rollouts"`
+}
+
+// Report compares the cohorts of every rollout with a switch
+func (r *Router) Report() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := Report{Status: StatusOK, Rollouts: []RolloutStatus{}}
+	for _, name := range r.switched {
+		rollout := r.Rollout(name)
+		status := RolloutStatus{Name: name, Percent: rollout.Percent, Pilots: len(rollout.Pilots), Status: StatusOK}
+		for _, cohort := range Cohorts {
+			counts := r.counts[seriesKey{name, cohort}]
+			cohortStatus := CohortStatus{Cohort: cohort, Calls: counts.calls, Errors: counts.errors}
+			if counts.calls > 0 {
+				cohortStatus.ErrorRate = float64(counts.errors) / float64(counts.calls)
+				cohortStatus.MeanLatencyMs = float64(counts.duration.Microseconds()) / 1000 / float64(counts.calls)
+			}
+			status.Cohorts = append(status.Cohorts, cohortStatus)
+		}
+		if regressed(status.Cohorts[0], status.Cohorts[1]) {
+			status.Status = StatusRegressed
+			report.Status = StatusRegressed
+		}
+		report.Rollouts = append(report.Rollouts, status)
+	}
+	return report
+}
+
+func regressed(stable CohortStatus, canary CohortStatus) bool {
+	if stable.Calls < MinCalls || canary.Calls < MinCalls {
+		return false
+	}
+	return canary.ErrorRate > stable.ErrorRate+ErrorRateMargin || canary.MeanLatencyMs > stable.MeanLatencyMs*LatencyFactor
+}
+
+// Handler serves the comparison for deploy pipelines, 503 when a canary regressed
+func (r *Router) Handler(c *gin.Context) {
+	report := r.Report()
+	code := http.StatusOK
+	if report.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, report)
+}
+
+// WriteMetrics writes the calls, errors and time spent of every cohort in the Prometheus text format
+func (r *Router) WriteMetrics(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := fmt.Fprint(w, "# HELP canary_calls_total Calls through canary switches per cohort\n"+
+		"# TYPE canary_calls_total counter\n"); err != nil {
+		return err
+	}
+	for _, name := range r.switched {
+		for _, cohort := range Cohorts {
+			counts := r.counts[seriesKey{name, cohort}]
+			if _, err := fmt.Fprintf(w, "canary_calls_total{rollout=%q,cohort=%q,outcome=\"ok\"} %d\n"+
+				"canary_calls_total{rollout=%q,cohort=%q,outcome=\"error\"} %d\n",
+				name, cohort, counts.calls-counts.errors, name, cohort, counts.errors); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := fmt.Fprint(w, "# HELP canary_call_duration_seconds_total Time spent in calls through canary switches per cohort\n"+
+		"# TYPE canary_call_duration_seconds_total counter\n"); err != nil {
+		return err
+	}
+	for _, name := range r.switched {
+		for _, cohort := range Cohorts {
+			counts := r.counts[seriesKey{name, cohort}]
+			if _, err := fmt.Fprintf(w, "canary_call_duration_seconds_total{rollout=%q,cohort=%q} %g\n", name, cohort, counts.duration.Seconds()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
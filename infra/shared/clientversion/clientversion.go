@@ -0,0 +1,194 @@
+package clientversion
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// MinimumVersionHeader tells clients of a platform with a minimum the oldest version still served,
+// apps prompt for an update before they are rejected
+const MinimumVersionHeader = "X-Min-Client-Version"
+
+// maxTrackedVersions bounds the series of the metrics, versions past it are counted as "other"
+const maxTrackedVersions = 200
+
+// Version is a client app build as sent in X-Client-Version, "<platform>/<major>.<minor>.<patch>"
+// such as "ios/4.2.0". Clients without a platform, like scripts, send the version alone.
+type Version struct {
+	Platform string
+	Major    int
+	Minor    int
+	Patch    int
+}
+
+// Parse reads a client version, missing minor and patch numbers are 0 and a pre-release or build
+// suffix is ignored: "android/5.1-beta+42" is android 5.1.0
+func Parse(value string) (Version, error) {
+	var version Version
+	numbers := strings.TrimSpace(value)
+	if platform, rest, found := strings.Cut(numbers, "/"); found {
+		version.Platform, numbers = strings.ToLower(strings.TrimSpace(platform)), rest
+	}
+	numbers = strings.TrimPrefix(numbers, "v")
+	if end := strings.IndexAny(numbers, "-+"); end >= 0 {
+		numbers = numbers[:end]
+	}
+
+	parts := strings.Split(numbers, ".")
+	if numbers == "" || len(parts) > 3 {
+		return Version{}, fmt.Errorf("client version %q is not <platform>/<major>.<minor>.<patch>", value)
+	}
+	targets := []*int{&version.Major, &version.Minor, &version.Patch}
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return Version{}, fmt.Errorf("client version %q is not <platform>/<major>.<minor>.<patch>", value)
+		}
+		*targets[i] = number
+	}
+	return version, nil
+}
+
+// Less reports whether v is an older build than other, platforms are not compared
+func (v Version) Less(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+// Number is the version without the platform, "4.2.0"
+func (v Version) Number() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// ParseMinimums reads platform=version entries such as "ios=4.2.0"
+func ParseMinimums(entries []string) (map[string]Version, error) {
+	minimums := make(map[string]Version, len(entries))
+	for _, entry := range entries {
+		platform, value, found := strings.Cut(entry, "=")
+		platform = strings.ToLower(strings.TrimSpace(platform))
+		if !found || platform == "" {
+			return nil, fmt.Errorf("minimum client version %q is not platform=version", entry)
+		}
+		version, err := Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		version.Platform = platform
+		minimums[platform] = version
+	}
+	return minimums, nil
+}
+
+// Enforcer counts the requests of every client version and rejects the platforms with a minimum
+// version when the client is older. Requests without a version or of a platform without a minimum
+// are served, browsers and integrations do not send one.
+type Enforcer struct {
+	minimums map[string]Version
+
+	mu       sync.Mutex
+	requests map[string]int64
+	rejected map[string]int64
+}
+
+func NewEnforcer(minimums map[string]Version) *Enforcer {
+	return &Enforcer{
+		minimums: minimums,
+		requests: make(map[string]int64),
+		rejected: make(map[string]int64),
+	}
+}
+
+// Enforcing reports whether any platform has a minimum version
+func (e *Enforcer) Enforcing() bool {
+	return len(e.minimums) > 0
+}
+
+// Middleware is a Huma middleware, it runs before authorization so outdated apps are told to update
+// rather than to sign in again
+func (e *Enforcer) Middleware(ctx huma.Context, next func(huma.Context)) {
+	sent := requestmeta.FromHuma(ctx).ClientVersion
+	version, err := Parse(sent)
+	if sent == "" || err != nil {
+		e.count(`platform="unknown",version="unknown"`, "")
+		next(ctx)
+		return
+	}
+
+	minimum, enforced := e.minimums[version.Platform]
+	if enforced {
+		ctx.SetHeader(MinimumVersionHeader, minimum.Number())
+	}
+	if enforced && version.Less(minimum) {
+		e.count(labels(version), version.Platform)
+		utils.WriteApplicationError(ctx, appErrors.NewUpgradeRequiredError(version.Platform, version.Number(), minimum.Number()))
+		return
+	}
+	e.count(labels(version), "")
+	next(ctx)
+}
+
+func labels(version Version) string {
+	platform := version.Platform
+	if platform == "" {
+		platform = "none"
+	}
+	return fmt.Sprintf("platform=%q,version=%q", platform, version.Number())
+}
+
+func (e *Enforcer) count(series string, rejectedPlatform string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, tracked := e.requests[series]; !tracked && len(e.requests) >= maxTrackedVersions {
+		series = `platform="other",version="other"`
+	}
+	e.requests[series]++
+	if rejectedPlatform != "" {
+		e.rejected[rejectedPlatform]++
+	}
+}
+
+// WriteMetrics writes the requests per client version and the rejected ones per platform in the
+// Prometheus text format
+func (e *Enforcer) WriteMetrics(w io.Writer) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var out strings.Builder
+	out.WriteString("# HELP http_client_requests_total Requests per client app version\n" +
+		"# TYPE http_client_requests_total counter\n")
+	for _, series := range sortedKeys(e.requests) {
+		fmt.Fprintf(&out, "http_client_requests_total{%s} %d\n", series, e.requests[series])
+	}
+	out.WriteString("# HELP http_client_upgrade_required_total Requests rejected because the client app is older than the minimum version\n" +
+		"# TYPE http_client_upgrade_required_total counter\n")
+	for _, platform := range sortedKeys(e.rejected) {
+		fmt.Fprintf(&out, "http_client_upgrade_required_total{platform=%q} %d\n", platform, e.rejected[platform])
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+func sortedKeys(counts map[string]int64) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
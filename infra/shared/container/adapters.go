@@ -0,0 +1,642 @@
+package container
+
+import (
+	"time"
+
+	accessibilityPorts "github.com/nahualventure/class-backend/core/app/accessibility/domain/ports"
+	activityPorts "github.com/nahualventure/class-backend/core/app/activity/domain/ports"
+	analyticsPorts "github.com/nahualventure/class-backend/core/app/analytics/domain/ports"
+	archivePorts "github.com/nahualventure/class-backend/core/app/archive/domain/ports"
+	attendancePorts "github.com/nahualventure/class-backend/core/app/attendance/domain/ports"
+	auditPorts "github.com/nahualventure/class-backend/core/app/audit/domain/ports"
+	backupPorts "github.com/nahualventure/class-backend/core/app/backup/domain/ports"
+	behaviorPorts "github.com/nahualventure/class-backend/core/app/behavior/domain/ports"
+	billingPorts "github.com/nahualventure/class-backend/core/app/billing/domain/ports"
+	calendarPorts "github.com/nahualventure/class-backend/core/app/calendar/domain/ports"
+	classTemplatePorts "github.com/nahualventure/class-backend/core/app/classtemplate/domain/ports"
+	customFieldPorts "github.com/nahualventure/class-backend/core/app/customfield/domain/ports"
+	dashboardPorts "github.com/nahualventure/class-backend/core/app/dashboard/domain/ports"
+	dataImportPorts "github.com/nahualventure/class-backend/core/app/dataimport/domain/ports"
+	deliverabilityEntities "github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	deliverabilityPorts "github.com/nahualventure/class-backend/core/app/deliverability/domain/ports"
+	devSandboxPorts "github.com/nahualventure/class-backend/core/app/devsandbox/domain/ports"
+	directorySyncPorts "github.com/nahualventure/class-backend/core/app/directorysync/domain/ports"
+	districtPorts "github.com/nahualventure/class-backend/core/app/district/domain/ports"
+	emailTemplatePorts "github.com/nahualventure/class-backend/core/app/emailtemplate/domain/ports"
+	enrollmentPorts "github.com/nahualventure/class-backend/core/app/enrollment/domain/ports"
+	gradingPorts "github.com/nahualventure/class-backend/core/app/grading/domain/ports"
+	jobFailurePorts "github.com/nahualventure/class-backend/core/app/jobfailure/domain/ports"
+	libraryPorts "github.com/nahualventure/class-backend/core/app/library/domain/ports"
+	linkedIdentityPorts "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/ports"
+	localizationPorts "github.com/nahualventure/class-backend/core/app/localization/domain/ports"
+	messagingPorts "github.com/nahualventure/class-backend/core/app/messaging/domain/ports"
+	moduleTogglePorts "github.com/nahualventure/class-backend/core/app/moduletoggle/domain/ports"
+	notificationPorts "github.com/nahualventure/class-backend/core/app/notification/domain/ports"
+	officeHoursPorts "github.com/nahualventure/class-backend/core/app/officehours/domain/ports"
+	offlineSyncPorts "github.com/nahualventure/class-backend/core/app/offlinesync/domain/ports"
+	operationPorts "github.com/nahualventure/class-backend/core/app/operation/domain/ports"
+	partitioningPorts "github.com/nahualventure/class-backend/core/app/partitioning/domain/ports"
+	presencePorts "github.com/nahualventure/class-backend/core/app/presence/domain/ports"
+	previewPorts "github.com/nahualventure/class-backend/core/app/preview/domain/ports"
+	printoutPorts "github.com/nahualventure/class-backend/core/app/printout/domain/ports"
+	reportPorts "github.com/nahualventure/class-backend/core/app/report/domain/ports"
+	roleAssignmentPorts "github.com/nahualventure/class-backend/core/app/roleassignment/domain/ports"
+	sandboxPorts "github.com/nahualventure/class-backend/core/app/sandbox/domain/ports"
+	savedViewPorts "github.com/nahualventure/class-backend/core/app/savedview/domain/ports"
+	scanningPorts "github.com/nahualventure/class-backend/core/app/scanning/domain/ports"
+	sectionPorts "github.com/nahualventure/class-backend/core/app/sections/domain/ports"
+	serviceAccountPorts "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/ports"
+	"github.com/nahualventure/class-backend/core/app/shared/encryption"
+	"github.com/nahualventure/class-backend/core/app/shared/projection"
+	"github.com/nahualventure/class-backend/core/app/shared/saga"
+	"github.com/nahualventure/class-backend/core/app/shared/schemachange"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"github.com/nahualventure/class-backend/core/app/shared/webhook"
+	similarityPorts "github.com/nahualventure/class-backend/core/app/similarity/domain/ports"
+	standardsPorts "github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+	statusEntities "github.com/nahualventure/class-backend/core/app/status/domain/entities"
+	statusPorts "github.com/nahualventure/class-backend/core/app/status/domain/ports"
+	storageQuotaPorts "github.com/nahualventure/class-backend/core/app/storagequota/domain/ports"
+	surveysPorts "github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+	tenantPorts "github.com/nahualventure/class-backend/core/app/tenant/domain/ports"
+	timezonePorts "github.com/nahualventure/class-backend/core/app/timezone/domain/ports"
+	transcriptPorts "github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+	userPorts "github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	userMergePorts "github.com/nahualventure/class-backend/core/app/usermerge/domain/ports"
+	warehousePorts "github.com/nahualventure/class-backend/core/app/warehouse/domain/ports"
+	widgetPorts "github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+	accessibilityAdapters "github.com/nahualventure/class-backend/infra/accessibility/adapters"
+	activityAdapters "github.com/nahualventure/class-backend/infra/activity/adapters"
+	analyticsAdapters "github.com/nahualventure/class-backend/infra/analytics/adapters"
+	archiveAdapters "github.com/nahualventure/class-backend/infra/archive/adapters"
+	attendanceAdapters "github.com/nahualventure/class-backend/infra/attendance/adapters"
+	auditAdapters "github.com/nahualventure/class-backend/infra/audit/adapters"
+	backupAdapters "github.com/nahualventure/class-backend/infra/backup/adapters"
+	behaviorAdapters "github.com/nahualventure/class-backend/infra/behavior/adapters"
+	billingAdapters "github.com/nahualventure/class-backend/infra/billing/adapters"
+	calendarAdapters "github.com/nahualventure/class-backend/infra/calendar/adapters"
+	classTemplateAdapters "github.com/nahualventure/class-backend/infra/classtemplate/adapters"
+	customFieldAdapters "github.com/nahualventure/class-backend/infra/customfield/adapters"
+	dashboardAdapters "github.com/nahualventure/class-backend/infra/dashboard/adapters"
+	dataImportAdapters "github.com/nahualventure/class-backend/infra/dataimport/adapters"
+	deliverabilityAdapters "github.com/nahualventure/class-backend/infra/deliverability/adapters"
+	devSandboxAdapters "github.com/nahualventure/class-backend/infra/devsandbox/adapters"
+	directorySyncAdapters "github.com/nahualventure/class-backend/infra/directorysync/adapters"
+	districtAdapters "github.com/nahualventure/class-backend/infra/district/adapters"
+	emailTemplateAdapters "github.com/nahualventure/class-backend/infra/emailtemplate/adapters"
+	enrollmentAdapters "github.com/nahualventure/class-backend/infra/enrollment/adapters"
+	gradingAdapters "github.com/nahualventure/class-backend/infra/grading/adapters"
+	jobFailureAdapters "github.com/nahualventure/class-backend/infra/jobfailure/adapters"
+	libraryAdapters "github.com/nahualventure/class-backend/infra/library/adapters"
+	linkedIdentityAdapters "github.com/nahualventure/class-backend/infra/linkedidentity/adapters"
+	localizationAdapters "github.com/nahualventure/class-backend/infra/localization/adapters"
+	messagingAdapters "github.com/nahualventure/class-backend/infra/messaging/adapters"
+	moduleToggleAdapters "github.com/nahualventure/class-backend/infra/moduletoggle/adapters"
+	notificationAdapters "github.com/nahualventure/class-backend/infra/notification/adapters"
+	officeHoursAdapters "github.com/nahualventure/class-backend/infra/officehours/adapters"
+	offlineSyncAdapters "github.com/nahualventure/class-backend/infra/offlinesync/adapters"
+	operationAdapters "github.com/nahualventure/class-backend/infra/operation/adapters"
+	partitioningAdapters "github.com/nahualventure/class-backend/infra/partitioning/adapters"
+	previewAdapters "github.com/nahualventure/class-backend/infra/preview/adapters"
+	printoutAdapters "github.com/nahualventure/class-backend/infra/printout/adapters"
+	reportAdapters "github.com/nahualventure/class-backend/infra/report/adapters"
+	roleAssignmentAdapters "github.com/nahualventure/class-backend/infra/roleassignment/adapters"
+	sandboxAdapters "github.com/nahualventure/class-backend/infra/sandbox/adapters"
+	savedViewAdapters "github.com/nahualventure/class-backend/infra/savedview/adapters"
+	scanningAdapters "github.com/nahualventure/class-backend/infra/scanning/adapters"
+	sectionAdapters "github.com/nahualventure/class-backend/infra/sections/adapters"
+	serviceAccountAdapters "github.com/nahualventure/class-backend/infra/serviceaccount/adapters"
+	sharedAdapters "github.com/nahualventure/class-backend/infra/shared/adapters"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+	"github.com/nahualventure/class-backend/infra/shared/slo"
+	similarityAdapters "github.com/nahualventure/class-backend/infra/similarity/adapters"
+	standardsAdapters "github.com/nahualventure/class-backend/infra/standards/adapters"
+	statusAdapters "github.com/nahualventure/class-backend/infra/status/adapters"
+	storageQuotaAdapters "github.com/nahualventure/class-backend/infra/storagequota/adapters"
+	surveysAdapters "github.com/nahualventure/class-backend/infra/surveys/adapters"
+	tenantAdapters "github.com/nahualventure/class-backend/infra/tenant/adapters"
+	timezoneAdapters "github.com/nahualventure/class-backend/infra/timezone/adapters"
+	transcriptAdapters "github.com/nahualventure/class-backend/infra/transcript/adapters"
+	userAdapters "github.com/nahualventure/class-backend/infra/user/adapters"
+	userMergeAdapters "github.com/nahualventure/class-backend/infra/usermerge/adapters"
+	warehouseAdapters "github.com/nahualventure/class-backend/infra/warehouse/adapters"
+	widgetAdapters "github.com/nahualventure/class-backend/infra/widget/adapters"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Adapters implement the ports the modules of the container depend on. Tests fill the ports they
+// exercise with fakes and leave the others nil, use cases only reach their ports when executed.
+type Adapters struct {
+	Users                userPorts.UserRepository
+	Passwords            userPorts.PasswordHasher
+	TimeZones            timezonePorts.TimeZoneRepository
+	NonInstructionalDays calendarPorts.NonInstructionalDayRepository
+	SchoolCalendar       calendarPorts.SchoolCalendarReader
+
+	Grades          gradingPorts.GradeRepository
+	GradingPolicies gradingPorts.GradingPolicyRepository
+	Deadlines       gradingPorts.DeadlineRepository
+
+	Reports reportPorts.ReportRepository
+
+	Slots           officeHoursPorts.SlotRepository
+	Appointments    officeHoursPorts.AppointmentRepository
+	AppointmentBook officeHoursPorts.AppointmentBook
+
+	Incidents             behaviorPorts.IncidentRepository
+	IncidentNotes         behaviorPorts.IncidentNoteRepository
+	GuardianNotifications behaviorPorts.GuardianNotificationRepository
+	IncidentViewers       behaviorPorts.ViewerDirectory
+
+	Resources      libraryPorts.ResourceRepository
+	LibraryViewers libraryPorts.ViewerDirectory
+
+	Forms              surveysPorts.FormRepository
+	FormResponses      surveysPorts.ResponseRepository
+	SurveyParticipants surveysPorts.ParticipantDirectory
+
+	Translations         localizationPorts.TranslationRepository
+	TranslatableEntities localizationPorts.TranslatableEntities
+
+	Conversations messagingPorts.ConversationRepository
+	// ReadReceipts saves the receipts Conversations buffers, nil when they are written right away
+	ReadReceipts          database.BackgroundWriter
+	Messages              messagingPorts.MessageRepository
+	AbuseReports          messagingPorts.AbuseReportRepository
+	MessagingParticipants messagingPorts.ParticipantDirectory
+
+	Archive archivePorts.ArchiveStore
+
+	TenantBackups backupPorts.TenantBackupRepository
+
+	AuditLog auditPorts.AuditLogRepository
+
+	ServiceAccounts serviceAccountPorts.ServiceAccountRepository
+	TokenPolicies   serviceAccountPorts.TokenPolicyRepository
+	// Roles holds the roles of service accounts in Casbin
+	Roles serviceAccountPorts.RoleDirectory
+
+	GroupRoleAssignments roleAssignmentPorts.GroupRoleAssignmentRepository
+	Groups               roleAssignmentPorts.GroupDirectory
+	// BulkRoles writes the roles of whole groups in Casbin
+	BulkRoles roleAssignmentPorts.BulkRoleAssigner
+	// MemberDeactivations are queued once confirmed, TenantRoles revokes and restores their roles
+	MemberDeactivations roleAssignmentPorts.MemberDeactivationRepository
+	TenantRoles         roleAssignmentPorts.TenantRoles
+
+	TenantSandboxes sandboxPorts.TenantSandboxRepository
+	// SandboxData copies the data of tenants into their sandboxes, the roles are copied by the
+	// sandbox worker with SandboxPolicies
+	SandboxData sandboxPorts.SandboxData
+
+	// Imports queues CSV imports, ImportPermissions checks the permission of the imported entity
+	Imports           dataImportPorts.ImportJobRepository
+	ImportTemplates   dataImportPorts.MappingTemplateRepository
+	ImportPermissions dataImportPorts.ImportPermissions
+
+	// UserMerges moves the records and roles of duplicate accounts, DuplicateCandidates are the
+	// pairs the detection worker found among the TenantMembers
+	TenantMembers       userMergePorts.MemberDirectory
+	DuplicateCandidates userMergePorts.DuplicateCandidateRepository
+	UserMerges          userMergePorts.UserMergeRepository
+
+	// LinkedIdentities are the Google and SAML accounts users sign in with besides their password,
+	// IdentityTokens verifies the tokens they are linked with and is set by main from its config
+	LinkedIdentities linkedIdentityPorts.LinkedIdentityRepository
+	IdentityTokens   linkedIdentityPorts.IdentityTokenVerifier
+
+	// Tenants are onboarded by the onboarding saga, TenantPolicies makes the roles apply to
+	// them and gives the admin accepting their invitation the role
+	Tenants           tenantPorts.TenantRepository
+	TenantInvitations tenantPorts.TenantInvitationRepository
+	TenantPolicies    tenantPorts.TenantPolicies
+
+	// DirectoryUsers are the members the roster or SCIM directory syncs, SyncConflicts the fields
+	// changed on both sides that SyncPolicies queued for an admin
+	DirectoryUsers directorySyncPorts.DirectoryUserRepository
+	SyncConflicts  directorySyncPorts.SyncConflictRepository
+	SyncPolicies   directorySyncPorts.SyncPolicyRepository
+
+	// DeliveryEvents are the deliveries, bounces and complaints the email providers report through
+	// DeliveryWebhooks, set by main for the providers it has the config of, and Suppressions the
+	// addresses they bounced or complained from
+	DeliveryEvents   deliverabilityPorts.DeliveryEventRepository
+	Suppressions     deliverabilityPorts.SuppressionRepository
+	DeliveryWebhooks map[deliverabilityEntities.EmailProvider]deliverabilityPorts.DeliveryWebhook
+
+	// Webhooks persists the webhook requests of the providers before they are processed, set by main.
+	// StartWorkers registers the sources of the modules served and runs the worker.
+	Webhooks *webhook.Receiver
+
+	// EmailTemplates and EmailBranding are how the emails senders deliver for a tenant look, test
+	// emails go to the address TestRecipients has for the caller
+	EmailTemplates emailTemplatePorts.EmailTemplateRepository
+	EmailBranding  emailTemplatePorts.EmailBrandingRepository
+	TestRecipients emailTemplatePorts.RecipientDirectory
+	TestEmailQueue emailTemplatePorts.TestEmailQueue
+
+	// DigestPreferences decide when the notifications NotificationBatcher holds are sent, daily
+	// digests go out in the zone RecipientZones has for the user
+	DigestPreferences   notificationPorts.DigestPreferenceRepository
+	RecipientZones      notificationPorts.RecipientZones
+	NotificationBatcher notificationPorts.NotificationBatcher
+
+	// StorageUsage is what the files of each user take, uploads are refused past StorageQuotas
+	StorageUsage  storageQuotaPorts.StorageUsageRepository
+	StorageQuotas storageQuotaPorts.StorageQuotaRepository
+
+	// ModuleToggles are the modules disabled per tenant, their operations are refused
+	ModuleToggles moduleTogglePorts.ModuleToggleRepository
+
+	// WidgetKeys are the publishable keys school websites embed Announcements and the calendars of
+	// ClassMeetings with
+	WidgetKeys    widgetPorts.WidgetKeyRepository
+	Announcements widgetPorts.AnnouncementRepository
+	ClassMeetings widgetPorts.ClassMeetings
+
+	// ClassMaterials are the files of the class threads reported on for accessibility
+	ClassMaterials accessibilityPorts.ClassMaterials
+
+	// JobFailures are the work of background jobs that is failing, escalated work waits to be requeued
+	JobFailures jobFailurePorts.JobFailureRepository
+
+	// CustomFields are the fields tenants define on users and classes, the values of each record are
+	// kept in EntityAttributes
+	CustomFields     customFieldPorts.CustomFieldRepository
+	EntityAttributes customFieldPorts.AttributeRepository
+
+	// SavedViews are the orders and filters of list endpoints users saved by name
+	SavedViews savedViewPorts.SavedViewRepository
+
+	// SyncChanges is the change feed offline devices sync classes from, projected from the outbox in
+	// Projections. SyncMutations are the offline grade changes applied already.
+	SyncChanges   offlineSyncPorts.ChangeFeed
+	SyncMutations offlineSyncPorts.MutationLog
+
+	// Printouts are the printouts of classes queued for the worker, PrintoutSource reads the rosters,
+	// meetings and days off they print
+	Printouts        printoutPorts.PrintoutRepository
+	PrintoutSource   printoutPorts.PrintoutSource
+	PrintoutRenderer printoutPorts.PrintoutRenderer
+
+	// OperationPermissions checks that users polling an operation may read what it produces
+	OperationPermissions operationPorts.OperationPermissions
+
+	// CheckInSessions are the kiosk check-ins opened to the sessions ClassSessions has, students of
+	// ClassRoster check in to Attendance
+	CheckInSessions attendancePorts.CheckInSessionRepository
+	ClassSessions   attendancePorts.ClassSessions
+	ClassRoster     attendancePorts.ClassRoster
+	Attendance      attendancePorts.AttendanceRepository
+
+	// Terms group the grades TranscriptSource reads into transcripts, ReportCards are generated from
+	// them with the template of ReportCardTemplates
+	Terms               transcriptPorts.TermRepository
+	ReportCardTemplates transcriptPorts.ReportCardTemplateRepository
+	ReportCards         transcriptPorts.ReportCardRepository
+	TranscriptSource    transcriptPorts.TranscriptSource
+
+	// Standards are tagged on assignments, the mastery of each is computed from the released grades
+	// EvidenceSource reads with the policy of MasteryPolicies
+	Standards           standardsPorts.StandardRepository
+	AssignmentStandards standardsPorts.AssignmentStandardRepository
+	MasteryPolicies     standardsPorts.MasteryPolicyRepository
+	EvidenceSource      standardsPorts.EvidenceSource
+
+	// ClassTemplates are saved from and instantiated into the setup ClassSetups reads and writes in the
+	// grading, enrollment and standards tables
+	ClassTemplates classTemplatePorts.ClassTemplateRepository
+	ClassSetups    classTemplatePorts.ClassSetupStore
+
+	// SectionChanges merges and splits class sections, moving the enrollment, grading and similarity
+	// records of their students
+	SectionChanges sectionPorts.SectionChangeRepository
+
+	// Authorization is the role store of the instance, enrollment checks the class roles of callers
+	// with it and presence sockets authenticate with it. Requests are recorded in the activity
+	// timeline by the access mode EndpointAccess has for their operation.
+	Authorization  *authorization.CasbinService
+	EndpointAccess *authorization.EndpointAccess
+
+	// ClassSummaries and TenantMetrics are the dashboard read models, maintained from OutboxEvents
+	// with the other Projections. Checkpoints is how far each projection got.
+	ClassSummaries dashboardPorts.ClassSummaryReader
+	TenantMetrics  dashboardPorts.MetricsReader
+	OutboxEvents   projection.EventLog
+	Checkpoints    projection.CheckpointStore
+	Projections    []projection.Projector
+
+	// Sagas keeps the state of onboardings and enrollments, SchemaChanges the phase of the
+	// expand/contract changes and Partitions creates the monthly partitions of high-volume tables
+	Sagas         saga.Store
+	SchemaChanges schemachange.Store
+	Partitions    partitioningPorts.PartitionManager
+
+	// SeatInventory holds the seats of classes against their capacity, confirmed enrollments are
+	// pushed to StudentInformationSystem, set by main from its config
+	SeatInventory            enrollmentPorts.SeatInventory
+	Schedules                enrollmentPorts.ScheduleRepository
+	Enrollments              enrollmentPorts.EnrollmentRepository
+	EnrollmentNotifications  enrollmentPorts.EnrollmentNotificationQueue
+	StudentInformationSystem enrollmentPorts.StudentInformationSystem
+
+	// Presence and EditLocks are kept in Redis when main has it, in memory otherwise
+	Presence  presencePorts.PresenceStore
+	EditLocks presencePorts.EditLockStore
+
+	// StatusNotes are the incident notes of the status page, StatusProbe measures its components and
+	// is set by main with the checks of the instance
+	StatusNotes statusPorts.StatusNoteRepository
+	StatusProbe statusPorts.HealthProbe
+
+	// Districts are kept in the cluster of their region, DistrictMetrics aggregates their schools
+	Districts       districtPorts.DistrictRepository
+	DistrictMetrics districtPorts.DistrictMetricsSource
+
+	// DeveloperSandboxes are the synthetic tenants of integrators, filled with SyntheticData
+	DeveloperSandboxes       devSandboxPorts.DeveloperSandboxRepository
+	SyntheticData            devSandboxPorts.SyntheticData
+	DeveloperSandboxPolicies devSandboxPorts.DeveloperSandboxPolicies
+	// SandboxPolicies enforces the roles of the sandboxes of TenantSandboxes once they are ready
+	SandboxPolicies sandboxPorts.SandboxPolicies
+
+	// APIUsage is the usage of every consumer, Activity the timeline of the support console
+	APIUsage analyticsPorts.UsageRepository
+	Activity activityPorts.ActivityRepository
+
+	// Subscriptions and Invoices are kept in sync with BillingProvider, set by main once billing is
+	// configured. Billing is disabled while it is nil.
+	Subscriptions   billingPorts.SubscriptionRepository
+	Invoices        billingPorts.InvoiceRepository
+	UsageMeter      billingPorts.UsageMeter
+	BillingContacts billingPorts.BillingContacts
+	BillingProvider billingPorts.BillingProvider
+
+	// SimilarityChecks are sent to SimilarityChecker, set by main once a provider is configured.
+	// Similarity checks are disabled while it is nil.
+	SimilarityChecks  similarityPorts.SimilarityCheckRepository
+	SimilarityChecker similarityPorts.SimilarityChecker
+
+	// Quarantine holds uploads until VirusScanner finds them clean, Previews and Captions are then
+	// generated with PreviewRenderer and CaptionProvider. Main sets the scanner and the renderer, and
+	// the caption provider once one is configured.
+	Quarantine      scanningPorts.QuarantineRepository
+	VirusScanner    scanningPorts.VirusScanner
+	Previews        previewPorts.PreviewRepository
+	PreviewRenderer previewPorts.PreviewRenderer
+	Captions        accessibilityPorts.CaptionRepository
+	CaptionProvider accessibilityPorts.CaptionProvider
+
+	// StorageInventory lists the files of the file storage the usage is reconciled with, set by main
+	StorageInventory storageQuotaPorts.StorageInventory
+
+	// TenantBackups are dumped by TenantDataExporter to BackupStorage, set by main, and restored by
+	// TenantDataRestorer
+	TenantDataExporter backupPorts.TenantDataExporter
+	TenantDataRestorer backupPorts.TenantDataRestorer
+	BackupStorage      storage.FileStorage
+
+	// ReportData is what reports aggregate, ReportCardRenderer prints the report cards
+	ReportData         reportPorts.ReportDataSource
+	ReportCardRenderer transcriptPorts.ReportCardRenderer
+
+	// WarehouseSource is exported to WarehouseSink past the Watermarks of each dataset, set by main
+	// once a sink is configured. Exports are disabled while it is nil.
+	WarehouseSource warehousePorts.WarehouseSource
+	Watermarks      warehousePorts.WatermarkStore
+	WarehouseSink   warehousePorts.WarehouseSink
+
+	// IncidentNotifier tells the admins about the work escalated in JobFailures, set by main with the
+	// channels it has the config of
+	IncidentNotifier jobFailurePorts.IncidentNotifier
+
+	// Files keeps library versions and message attachments
+	Files storage.FileStorage
+}
+
+// NewPostgresAdapters builds the production adapters, time zones and dashboard metrics are cached
+// for a minute. Sensitive columns are encrypted with tenantCipher, nil keeps them in plaintext. Read
+// receipts are written behind with writeBehind, a MaxPending of 0 writes them right away.
+func NewPostgresAdapters(pool *pgxpool.Pool, authzService *authorization.CasbinService, files storage.FileStorage,
+	tenantCipher *encryption.TenantCipher, writeBehind database.WriteBehindConfig) Adapters {
+	timeZones := timezoneAdapters.NewCachedTimeZoneRepository(timezoneAdapters.NewPostgresTimeZoneRepository(pool), time.Minute)
+	nonInstructionalDays := calendarAdapters.NewPostgresNonInstructionalDayRepository(pool)
+	classSummaries := dashboardAdapters.NewPostgresClassSummaryProjection(pool)
+	tenantMetrics := dashboardAdapters.NewPostgresTenantMetricsProjection(pool)
+
+	postgresConversations := messagingAdapters.NewPostgresConversationRepository(pool)
+	var conversations messagingPorts.ConversationRepository = postgresConversations
+	var readReceipts database.BackgroundWriter
+	if writeBehind.MaxPending > 0 {
+		receipts := messagingAdapters.NewWriteBehindConversationRepository(postgresConversations, writeBehind)
+		conversations, readReceipts = receipts, receipts.Writer()
+	}
+
+	return Adapters{
+		Users:                userAdapters.NewPostgresUserRepository(pool),
+		Passwords:            userAdapters.NewMultiSchemePasswordHasher(),
+		TimeZones:            timeZones,
+		NonInstructionalDays: nonInstructionalDays,
+		SchoolCalendar:       calendarAdapters.NewSchoolCalendarReader(nonInstructionalDays, timeZones),
+
+		Grades:          gradingAdapters.NewPostgresGradeRepository(pool),
+		GradingPolicies: gradingAdapters.NewPostgresGradingPolicyRepository(pool),
+		Deadlines:       gradingAdapters.NewPostgresDeadlineRepository(pool),
+
+		Reports: reportAdapters.NewPostgresReportRepository(pool),
+
+		Slots:           officeHoursAdapters.NewPostgresSlotRepository(pool),
+		Appointments:    officeHoursAdapters.NewPostgresAppointmentRepository(pool),
+		AppointmentBook: officeHoursAdapters.NewPostgresAppointmentBook(pool),
+
+		Incidents:             behaviorAdapters.NewPostgresIncidentRepository(pool),
+		IncidentNotes:         behaviorAdapters.NewPostgresIncidentNoteRepository(pool, tenantCipher),
+		GuardianNotifications: behaviorAdapters.NewPostgresGuardianNotificationRepository(pool),
+		IncidentViewers:       behaviorAdapters.NewPostgresViewerDirectory(pool, authzService),
+
+		Resources:      libraryAdapters.NewPostgresResourceRepository(pool),
+		LibraryViewers: libraryAdapters.NewCasbinViewerDirectory(authzService),
+
+		Forms:              surveysAdapters.NewPostgresFormRepository(pool),
+		FormResponses:      surveysAdapters.NewPostgresResponseRepository(pool),
+		SurveyParticipants: surveysAdapters.NewPostgresParticipantDirectory(pool, authzService),
+
+		Translations:         localizationAdapters.NewPostgresTranslationRepository(pool),
+		TranslatableEntities: localizationAdapters.NewPostgresTranslatableEntities(pool),
+
+		Conversations:         conversations,
+		ReadReceipts:          readReceipts,
+		Messages:              messagingAdapters.NewPostgresMessageRepository(pool),
+		AbuseReports:          messagingAdapters.NewPostgresAbuseReportRepository(pool),
+		MessagingParticipants: messagingAdapters.NewPostgresParticipantDirectory(pool, authzService),
+
+		Archive: archiveAdapters.NewPostgresArchiveStore(pool),
+
+		TenantBackups: backupAdapters.NewPostgresTenantBackupRepository(pool),
+
+		AuditLog: auditAdapters.NewPostgresAuditLogRepository(pool),
+
+		ServiceAccounts: serviceAccountAdapters.NewPostgresServiceAccountRepository(pool),
+		TokenPolicies:   serviceAccountAdapters.NewPostgresTokenPolicyRepository(pool),
+		Roles:           serviceAccountAdapters.NewCasbinRoleDirectory(authzService),
+
+		GroupRoleAssignments: roleAssignmentAdapters.NewPostgresGroupRoleAssignmentRepository(pool),
+		Groups:               roleAssignmentAdapters.NewPostgresGroupDirectory(pool),
+		BulkRoles:            roleAssignmentAdapters.NewCasbinBulkRoleAssigner(authzService),
+		MemberDeactivations:  roleAssignmentAdapters.NewPostgresMemberDeactivationRepository(pool),
+		TenantRoles:          roleAssignmentAdapters.NewCasbinTenantRoles(authzService),
+
+		TenantSandboxes: sandboxAdapters.NewPostgresTenantSandboxRepository(pool),
+		SandboxData:     sandboxAdapters.NewPostgresSandboxData(pool),
+
+		Imports:           dataImportAdapters.NewPostgresImportJobRepository(pool),
+		ImportTemplates:   dataImportAdapters.NewPostgresMappingTemplateRepository(pool),
+		ImportPermissions: dataImportAdapters.NewCasbinImportPermissions(authzService),
+
+		TenantMembers:       userMergeAdapters.NewPostgresMemberDirectory(pool),
+		DuplicateCandidates: userMergeAdapters.NewPostgresDuplicateCandidateRepository(pool),
+		UserMerges:          userMergeAdapters.NewPostgresUserMergeRepository(pool, authzService),
+
+		LinkedIdentities: linkedIdentityAdapters.NewPostgresLinkedIdentityRepository(pool),
+
+		Tenants:           tenantAdapters.NewPostgresTenantRepository(pool),
+		TenantInvitations: tenantAdapters.NewPostgresTenantInvitationRepository(pool),
+		TenantPolicies:    tenantAdapters.NewCasbinTenantPolicies(authzService),
+
+		DirectoryUsers: directorySyncAdapters.NewPostgresDirectoryUserRepository(pool),
+		SyncConflicts:  directorySyncAdapters.NewPostgresSyncConflictRepository(pool),
+		SyncPolicies:   directorySyncAdapters.NewPostgresSyncPolicyRepository(pool),
+
+		DeliveryEvents: deliverabilityAdapters.NewPostgresDeliveryEventRepository(pool),
+		Suppressions:   deliverabilityAdapters.NewPostgresSuppressionRepository(pool),
+
+		EmailTemplates: emailTemplateAdapters.NewPostgresEmailTemplateRepository(pool),
+		EmailBranding:  emailTemplateAdapters.NewPostgresEmailBrandingRepository(pool),
+		TestRecipients: emailTemplateAdapters.NewPostgresRecipientDirectory(pool),
+		TestEmailQueue: emailTemplateAdapters.NewPostgresTestEmailQueue(pool),
+
+		DigestPreferences:   notificationAdapters.NewPostgresDigestPreferenceRepository(pool),
+		RecipientZones:      notificationAdapters.NewPostgresRecipientZones(pool),
+		NotificationBatcher: notificationAdapters.NewPostgresNotificationBatcher(pool),
+
+		StorageUsage:  storageQuotaAdapters.NewPostgresStorageUsageRepository(pool),
+		StorageQuotas: storageQuotaAdapters.NewPostgresStorageQuotaRepository(pool),
+
+		ModuleToggles: moduleToggleAdapters.NewPostgresModuleToggleRepository(pool),
+
+		WidgetKeys:    widgetAdapters.NewPostgresWidgetKeyRepository(pool),
+		Announcements: widgetAdapters.NewPostgresAnnouncementRepository(pool),
+		ClassMeetings: widgetAdapters.NewPostgresClassMeetings(pool),
+
+		ClassMaterials: accessibilityAdapters.NewPostgresClassMaterials(pool),
+
+		JobFailures: jobFailureAdapters.NewPostgresJobFailureRepository(pool),
+
+		CustomFields:     customFieldAdapters.NewPostgresCustomFieldRepository(pool),
+		EntityAttributes: customFieldAdapters.NewPostgresAttributeRepository(pool),
+
+		SavedViews: savedViewAdapters.NewPostgresSavedViewRepository(pool),
+
+		SyncChanges:   offlineSyncAdapters.NewPostgresSyncChangeProjection(pool),
+		SyncMutations: offlineSyncAdapters.NewPostgresMutationLog(pool),
+
+		Printouts:        printoutAdapters.NewPostgresPrintoutRepository(pool),
+		PrintoutSource:   printoutAdapters.NewPostgresPrintoutSource(pool),
+		PrintoutRenderer: printoutAdapters.NewPDFPrintoutRenderer(),
+
+		OperationPermissions: operationAdapters.NewCasbinOperationPermissions(authzService),
+
+		CheckInSessions: attendanceAdapters.NewPostgresCheckInSessionRepository(pool),
+		ClassSessions:   attendanceAdapters.NewPostgresClassSessions(pool),
+		ClassRoster:     attendanceAdapters.NewPostgresClassRoster(pool),
+		Attendance:      attendanceAdapters.NewPostgresAttendanceRepository(pool),
+
+		Terms:               transcriptAdapters.NewPostgresTermRepository(pool),
+		ReportCardTemplates: transcriptAdapters.NewPostgresReportCardTemplateRepository(pool),
+		ReportCards:         transcriptAdapters.NewPostgresReportCardRepository(pool),
+		TranscriptSource:    transcriptAdapters.NewPostgresTranscriptSource(pool),
+
+		Standards:           standardsAdapters.NewPostgresStandardRepository(pool),
+		AssignmentStandards: standardsAdapters.NewPostgresAssignmentStandardRepository(pool),
+		MasteryPolicies:     standardsAdapters.NewPostgresMasteryPolicyRepository(pool),
+		EvidenceSource:      standardsAdapters.NewPostgresEvidenceSource(pool),
+
+		ClassTemplates: classTemplateAdapters.NewPostgresClassTemplateRepository(pool),
+		ClassSetups:    classTemplateAdapters.NewPostgresClassSetupStore(pool),
+
+		SectionChanges: sectionAdapters.NewPostgresSectionChangeRepository(pool),
+
+		Authorization:  authzService,
+		EndpointAccess: authzService.EndpointAccess(),
+
+		ClassSummaries: classSummaries,
+		TenantMetrics:  dashboardAdapters.NewCachedMetricsReader(tenantMetrics, time.Minute),
+		OutboxEvents:   sharedAdapters.NewPostgresOutboxEventLog(pool),
+		Checkpoints:    sharedAdapters.NewPostgresCheckpointStore(pool),
+		Projections: []projection.Projector{
+			classSummaries,
+			tenantMetrics,
+			auditAdapters.NewPostgresAuditChainProjection(pool),
+			activityAdapters.NewPostgresActivityProjection(pool),
+			offlineSyncAdapters.NewPostgresSyncChangeProjection(pool),
+		},
+
+		Sagas:         sharedAdapters.NewPostgresSagaStore(pool),
+		SchemaChanges: sharedAdapters.NewPostgresSchemaChangeStore(pool),
+		Partitions:    partitioningAdapters.NewPostgresPartitionManager(pool),
+
+		SeatInventory:           enrollmentAdapters.NewPostgresSeatInventory(pool),
+		Schedules:               enrollmentAdapters.NewPostgresScheduleRepository(pool),
+		Enrollments:             enrollmentAdapters.NewPostgresEnrollmentRepository(pool),
+		EnrollmentNotifications: enrollmentAdapters.NewPostgresEnrollmentNotificationQueue(pool),
+
+		StatusNotes: statusAdapters.NewPostgresStatusNoteRepository(pool),
+
+		Districts:       districtAdapters.NewPostgresDistrictRepository(pool),
+		DistrictMetrics: districtAdapters.NewPostgresDistrictMetricsSource(pool),
+
+		DeveloperSandboxes:       devSandboxAdapters.NewPostgresDeveloperSandboxRepository(pool),
+		SyntheticData:            devSandboxAdapters.NewPostgresSyntheticData(pool),
+		DeveloperSandboxPolicies: devSandboxAdapters.NewCasbinDeveloperSandboxPolicies(authzService),
+		SandboxPolicies:          sandboxAdapters.NewCasbinSandboxPolicies(authzService),
+
+		APIUsage: analyticsAdapters.NewPostgresUsageRepository(pool),
+		Activity: activityAdapters.NewPostgresActivityRepository(pool),
+
+		Subscriptions:   billingAdapters.NewPostgresSubscriptionRepository(pool),
+		Invoices:        billingAdapters.NewPostgresInvoiceRepository(pool),
+		UsageMeter:      billingAdapters.NewPostgresUsageMeter(pool, authzService),
+		BillingContacts: billingAdapters.NewCasbinBillingContacts(authzService),
+
+		SimilarityChecks: similarityAdapters.NewPostgresSimilarityCheckRepository(pool),
+
+		Quarantine: scanningAdapters.NewPostgresQuarantineRepository(pool),
+		Previews:   previewAdapters.NewPostgresPreviewRepository(pool),
+		Captions:   accessibilityAdapters.NewPostgresCaptionRepository(pool),
+
+		TenantDataExporter: backupAdapters.NewPostgresTenantDataExporter(pool),
+		TenantDataRestorer: backupAdapters.NewPostgresTenantDataRestorer(pool),
+
+		ReportData:         reportAdapters.NewPostgresReportDataSource(pool),
+		ReportCardRenderer: transcriptAdapters.NewPDFReportCardRenderer(),
+
+		WarehouseSource: warehouseAdapters.NewPostgresWarehouseSource(pool),
+		Watermarks:      warehouseAdapters.NewPostgresWatermarkStore(pool),
+
+		Files: files,
+	}
+}
+
+// NewStatusProbe measures the components of the status page with the checks of the instance: the
+// error budget of the API, its role store, the database and the jobs escalated for failing
+func NewStatusProbe(pool *pgxpool.Pool, authzService *authorization.CasbinService, report func() slo.Report) statusPorts.HealthProbe {
+	probe := statusAdapters.NewCheckedHealthProbe(15 * time.Second)
+	probe.Add(statusEntities.ComponentAPI, statusAdapters.SLOCheck(report))
+	probe.Add(statusEntities.ComponentAPI, statusAdapters.FailingCheck(authzService.Health().Check, statusEntities.LevelMajorOutage))
+	probe.Add(statusEntities.ComponentAPI, statusAdapters.FailingCheck(authzService.Health().CheckTenants, statusEntities.LevelPartialOutage))
+	probe.Add(statusEntities.ComponentDatabase, statusAdapters.FailingCheck(pool.Ping, statusEntities.LevelMajorOutage))
+	probe.Add(statusEntities.ComponentJobs, statusAdapters.EscalatedJobsCheck(pool))
+	return probe
+}
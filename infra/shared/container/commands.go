@@ -0,0 +1,131 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	restore_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/restore-tenant-backup-use-case"
+	set_class_template_shared_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/set-class-template-shared-use-case"
+	set_module_toggle_use_case "github.com/nahualventure/class-backend/core/app/moduletoggle/application/use-cases/set-module-toggle-use-case"
+	ensure_partitions_use_case "github.com/nahualventure/class-backend/core/app/partitioning/application/use-cases/ensure-partitions-use-case"
+	"github.com/nahualventure/class-backend/core/app/shared/schemachange"
+	onboard_tenant_use_case "github.com/nahualventure/class-backend/core/app/tenant/application/use-cases/onboard-tenant-use-case"
+)
+
+// RunCommand runs the admin command of args and logs what it did, it reports false when args is
+// not one of the commands of the modules:
+//
+//   - "ensure-partitions" creates the partitions of the coming months, migrations run it
+//   - "rebuild-projection <name>" replays the outbox into an empty read model
+//   - "schema-changes" prints the phase and backfill progress of every change
+//   - "schema-change <name> <phase>" moves a change to another phase, instances pick it up within
+//     schemachange.DefaultPhaseTTL
+//   - "onboard-tenant <tenant> <name> <admin-email>" onboards a school, its admin receives an
+//     invitation to accept
+//   - "share-class-template <tenant> <template>" shares a class template of the tenant with every
+//     tenant, "unshare-class-template" stops sharing it. Tenants cannot share templates themselves,
+//     sharing is up to platform admins.
+//   - "disable-tenant-module <tenant> <module> [reason]" disables a module for the tenant,
+//     "enable-tenant-module <tenant> <module>" enables it again. Running instances pick the toggle
+//     up within a minute.
+//   - "restore-tenant-backup <tenant> <backup> <schema>" loads a verified backup into a new schema
+//     next to the live tables, rows are copied back from there
+func (c *Container) RunCommand(ctx context.Context, args []string) (bool, error) {
+	switch {
+	case len(args) == 1 && args[0] == "ensure-partitions":
+		command, err := ensure_partitions_use_case.NewEnsurePartitionsCommand(time.Now(), ensure_partitions_use_case.DefaultMonthsAhead)
+		if err != nil {
+			return true, fmt.Errorf("ensure partitions: %w", err)
+		}
+		partitions, err := c.ensurePartitions.Execute(ctx, command)
+		if err != nil {
+			return true, fmt.Errorf("ensure partitions: %w", err)
+		}
+		log.Printf("Created %d partitions", len(partitions))
+
+	case len(args) == 2 && args[0] == "rebuild-projection":
+		if err := c.projections.Rebuild(ctx, args[1]); err != nil {
+			return true, fmt.Errorf("rebuild projection %s: %w", args[1], err)
+		}
+		log.Printf("Projection %s rebuilt", args[1])
+
+	case len(args) == 1 && args[0] == "schema-changes":
+		states, err := c.schemaChanges.States(ctx)
+		if err != nil {
+			return true, fmt.Errorf("read schema changes: %w", err)
+		}
+		for _, state := range states {
+			log.Printf("%s: %s, backfilled %.0f%% (%d of %d rows) - %s", state.Name, state.Phase,
+				state.Progress()*100, state.Backfilled, state.Total, c.schemaChanges.Description(state.Name))
+		}
+
+	case len(args) == 3 && args[0] == "schema-change":
+		if _, err := c.schemaChanges.Advance(ctx, args[1], schemachange.Phase(args[2])); err != nil {
+			return true, fmt.Errorf("move schema change %s to %s: %w", args[1], args[2], err)
+		}
+		log.Printf("Schema change %s moved to %s", args[1], args[2])
+
+	case len(args) == 4 && args[0] == "onboard-tenant":
+		command, err := onboard_tenant_use_case.NewOnboardTenantCommand(args[1], args[2], args[3])
+		if err != nil {
+			return true, fmt.Errorf("onboard tenant %s: %w", args[1], err)
+		}
+		tenant, err := c.onboardTenant.Execute(ctx, command)
+		if err != nil {
+			return true, fmt.Errorf("onboard tenant %s: %w", args[1], err)
+		}
+		log.Printf("Tenant %s onboarded, invitation sent to %s", tenant.ID, tenant.AdminEmail)
+
+	case len(args) == 3 && (args[0] == "share-class-template" || args[0] == "unshare-class-template"):
+		shared := args[0] == "share-class-template"
+		command, err := set_class_template_shared_use_case.NewSetClassTemplateSharedCommand(args[1], args[2], shared)
+		if err != nil {
+			return true, fmt.Errorf("update class template %s: %w", args[2], err)
+		}
+		useCase := set_class_template_shared_use_case.NewSetClassTemplateSharedUseCase(c.adapters.ClassTemplates)
+		if err := useCase.Execute(ctx, command); err != nil {
+			return true, fmt.Errorf("update class template %s: %w", args[2], err)
+		}
+		if shared {
+			log.Printf("Class template %s of %s shared with every tenant", args[2], args[1])
+		} else {
+			log.Printf("Class template %s of %s no longer shared", args[2], args[1])
+		}
+
+	case len(args) >= 3 && (args[0] == "disable-tenant-module" || args[0] == "enable-tenant-module"):
+		enabled := args[0] == "enable-tenant-module"
+		command, err := set_module_toggle_use_case.NewSetModuleToggleCommand(args[1], args[2], enabled, strings.Join(args[3:], " "))
+		if err != nil {
+			return true, fmt.Errorf("toggle module %s: %w", args[2], err)
+		}
+		if _, err := c.SetModuleToggle.Execute(ctx, command); err != nil {
+			return true, fmt.Errorf("toggle module %s: %w", args[2], err)
+		}
+		if enabled {
+			log.Printf("Module %s enabled for %s", args[2], args[1])
+		} else {
+			log.Printf("Module %s disabled for %s", args[2], args[1])
+		}
+
+	case len(args) == 4 && args[0] == "restore-tenant-backup":
+		command, err := restore_tenant_backup_use_case.NewRestoreTenantBackupCommand(args[1], args[2], args[3])
+		if err != nil {
+			return true, fmt.Errorf("restore backup %s: %w", args[2], err)
+		}
+		restored, err := restore_tenant_backup_use_case.NewRestoreTenantBackupUseCase(
+			c.adapters.TenantBackups, c.adapters.TenantDataRestorer, c.adapters.BackupStorage).Execute(ctx, command)
+		if err != nil {
+			return true, fmt.Errorf("restore backup %s: %w", args[2], err)
+		}
+		for table, rows := range restored {
+			log.Printf("Restored %d rows of %s into %s", rows, table, args[3])
+		}
+
+	default:
+		return false, nil
+	}
+	return true, nil
+}
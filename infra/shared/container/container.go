@@ -0,0 +1,1096 @@
+package container
+
+import (
+	"slices"
+	"time"
+
+	get_class_accessibility_report_use_case "github.com/nahualventure/class-backend/core/app/accessibility/application/use-cases/get-class-accessibility-report-use-case"
+	list_activity_use_case "github.com/nahualventure/class-backend/core/app/activity/application/use-cases/list-activity-use-case"
+	get_api_usage_use_case "github.com/nahualventure/class-backend/core/app/analytics/application/use-cases/get-api-usage-use-case"
+	analyticsEntities "github.com/nahualventure/class-backend/core/app/analytics/domain/entities"
+	list_archive_batches_use_case "github.com/nahualventure/class-backend/core/app/archive/application/use-cases/list-archive-batches-use-case"
+	restore_archive_batch_use_case "github.com/nahualventure/class-backend/core/app/archive/application/use-cases/restore-archive-batch-use-case"
+	check_in_use_case "github.com/nahualventure/class-backend/core/app/attendance/application/use-cases/check-in-use-case"
+	get_check_in_code_use_case "github.com/nahualventure/class-backend/core/app/attendance/application/use-cases/get-check-in-code-use-case"
+	open_check_in_use_case "github.com/nahualventure/class-backend/core/app/attendance/application/use-cases/open-check-in-use-case"
+	list_audit_entries_use_case "github.com/nahualventure/class-backend/core/app/audit/application/use-cases/list-audit-entries-use-case"
+	verify_audit_log_use_case "github.com/nahualventure/class-backend/core/app/audit/application/use-cases/verify-audit-log-use-case"
+	batch_signup_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/batch-signup-use-case"
+	import_users_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/import-users-use-case"
+	signup_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/signup-use-case"
+	verify_password_use_case "github.com/nahualventure/class-backend/core/app/auth/application/use-cases/verify-password-use-case"
+	get_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/get-tenant-backup-use-case"
+	list_tenant_backups_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/list-tenant-backups-use-case"
+	request_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/request-tenant-backup-use-case"
+	acknowledge_incident_notification_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/acknowledge-incident-notification-use-case"
+	add_incident_note_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/add-incident-note-use-case"
+	get_incident_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/get-incident-use-case"
+	list_incidents_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/list-incidents-use-case"
+	notify_incident_guardians_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/notify-incident-guardians-use-case"
+	report_incident_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/report-incident-use-case"
+	resolve_incident_use_case "github.com/nahualventure/class-backend/core/app/behavior/application/use-cases/resolve-incident-use-case"
+	check_feature_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/check-feature-use-case"
+	check_plan_limit_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/check-plan-limit-use-case"
+	get_subscription_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/get-subscription-use-case"
+	list_invoices_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/list-invoices-use-case"
+	billingErrors "github.com/nahualventure/class-backend/core/app/billing/domain/errors"
+	add_non_instructional_day_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/add-non-instructional-day-use-case"
+	get_school_calendar_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/get-school-calendar-use-case"
+	import_holidays_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/import-holidays-use-case"
+	list_non_instructional_days_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/list-non-instructional-days-use-case"
+	remove_non_instructional_day_use_case "github.com/nahualventure/class-backend/core/app/calendar/application/use-cases/remove-non-instructional-day-use-case"
+	delete_class_template_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/delete-class-template-use-case"
+	get_class_template_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/get-class-template-use-case"
+	instantiate_class_template_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/instantiate-class-template-use-case"
+	list_class_templates_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/list-class-templates-use-case"
+	save_class_template_use_case "github.com/nahualventure/class-backend/core/app/classtemplate/application/use-cases/save-class-template-use-case"
+	create_custom_field_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/create-custom-field-use-case"
+	delete_custom_field_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/delete-custom-field-use-case"
+	get_attributes_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/get-attributes-use-case"
+	list_custom_fields_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/list-custom-fields-use-case"
+	search_attributes_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/search-attributes-use-case"
+	set_attributes_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/set-attributes-use-case"
+	update_custom_field_use_case "github.com/nahualventure/class-backend/core/app/customfield/application/use-cases/update-custom-field-use-case"
+	get_class_summary_use_case "github.com/nahualventure/class-backend/core/app/dashboard/application/use-cases/get-class-summary-use-case"
+	get_metric_series_use_case "github.com/nahualventure/class-backend/core/app/dashboard/application/use-cases/get-metric-series-use-case"
+	delete_mapping_template_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/delete-mapping-template-use-case"
+	get_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/get-import-use-case"
+	list_imports_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/list-imports-use-case"
+	list_mapping_templates_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/list-mapping-templates-use-case"
+	preview_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/preview-import-use-case"
+	run_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/run-import-use-case"
+	save_mapping_template_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/save-mapping-template-use-case"
+	start_import_use_case "github.com/nahualventure/class-backend/core/app/dataimport/application/use-cases/start-import-use-case"
+	check_suppressions_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/check-suppressions-use-case"
+	get_deliverability_stats_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/get-deliverability-stats-use-case"
+	list_suppressions_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/list-suppressions-use-case"
+	remove_suppression_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/remove-suppression-use-case"
+	create_developer_sandbox_use_case "github.com/nahualventure/class-backend/core/app/devsandbox/application/use-cases/create-developer-sandbox-use-case"
+	get_developer_sandbox_use_case "github.com/nahualventure/class-backend/core/app/devsandbox/application/use-cases/get-developer-sandbox-use-case"
+	reset_developer_sandbox_use_case "github.com/nahualventure/class-backend/core/app/devsandbox/application/use-cases/reset-developer-sandbox-use-case"
+	get_sync_conflict_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/get-sync-conflict-use-case"
+	list_sync_conflicts_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/list-sync-conflicts-use-case"
+	list_sync_policies_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/list-sync-policies-use-case"
+	resolve_sync_conflict_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/resolve-sync-conflict-use-case"
+	set_sync_policy_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/set-sync-policy-use-case"
+	sync_directory_users_use_case "github.com/nahualventure/class-backend/core/app/directorysync/application/use-cases/sync-directory-users-use-case"
+	authenticate_district_key_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/authenticate-district-key-use-case"
+	create_district_key_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/create-district-key-use-case"
+	create_district_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/create-district-use-case"
+	get_district_metrics_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/get-district-metrics-use-case"
+	list_districts_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/list-districts-use-case"
+	revoke_district_key_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/revoke-district-key-use-case"
+	update_district_use_case "github.com/nahualventure/class-backend/core/app/district/application/use-cases/update-district-use-case"
+	get_email_branding_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/get-email-branding-use-case"
+	list_email_templates_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/list-email-templates-use-case"
+	preview_email_template_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/preview-email-template-use-case"
+	save_email_template_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/save-email-template-use-case"
+	send_test_email_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/send-test-email-use-case"
+	set_email_branding_use_case "github.com/nahualventure/class-backend/core/app/emailtemplate/application/use-cases/set-email-branding-use-case"
+	confirm_enrollment_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/confirm-enrollment-use-case"
+	enroll_student_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/enroll-student-use-case"
+	get_class_capacity_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/get-class-capacity-use-case"
+	get_class_schedule_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/get-class-schedule-use-case"
+	release_seat_hold_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/release-seat-hold-use-case"
+	reserve_seat_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/reserve-seat-use-case"
+	set_class_capacity_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/set-class-capacity-use-case"
+	set_class_schedule_use_case "github.com/nahualventure/class-backend/core/app/enrollment/application/use-cases/set-class-schedule-use-case"
+	enrollmentEntities "github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	apply_grade_adjustment_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/apply-grade-adjustment-use-case"
+	change_grade_status_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/change-grade-status-use-case"
+	get_grade_history_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/get-grade-history-use-case"
+	grant_extension_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/grant-extension-use-case"
+	list_class_grades_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/list-class-grades-use-case"
+	list_student_grades_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/list-student-grades-use-case"
+	preview_grade_adjustment_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/preview-grade-adjustment-use-case"
+	record_grade_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/record-grade-use-case"
+	set_assignment_deadline_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/set-assignment-deadline-use-case"
+	set_grading_policy_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/set-grading-policy-use-case"
+	undo_grade_adjustment_use_case "github.com/nahualventure/class-backend/core/app/grading/application/use-cases/undo-grade-adjustment-use-case"
+	list_job_failures_use_case "github.com/nahualventure/class-backend/core/app/jobfailure/application/use-cases/list-job-failures-use-case"
+	requeue_job_failure_use_case "github.com/nahualventure/class-backend/core/app/jobfailure/application/use-cases/requeue-job-failure-use-case"
+	change_resource_status_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/change-resource-status-use-case"
+	create_resource_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/create-resource-use-case"
+	download_resource_version_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/download-resource-version-use-case"
+	get_resource_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/get-resource-use-case"
+	list_resources_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/list-resources-use-case"
+	update_resource_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/update-resource-use-case"
+	upload_resource_version_use_case "github.com/nahualventure/class-backend/core/app/library/application/use-cases/upload-resource-version-use-case"
+	get_credentials_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/get-credentials-use-case"
+	get_member_credentials_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/get-member-credentials-use-case"
+	link_identity_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/link-identity-use-case"
+	remove_password_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/remove-password-use-case"
+	resolve_linked_identity_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/resolve-linked-identity-use-case"
+	unlink_identity_use_case "github.com/nahualventure/class-backend/core/app/linkedidentity/application/use-cases/unlink-identity-use-case"
+	delete_translation_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/delete-translation-use-case"
+	list_translations_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/list-translations-use-case"
+	localize_content_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/localize-content-use-case"
+	set_translation_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/set-translation-use-case"
+	messaging_service "github.com/nahualventure/class-backend/core/app/messaging/application/messaging-service"
+	check_module_enabled_use_case "github.com/nahualventure/class-backend/core/app/moduletoggle/application/use-cases/check-module-enabled-use-case"
+	list_tenant_modules_use_case "github.com/nahualventure/class-backend/core/app/moduletoggle/application/use-cases/list-tenant-modules-use-case"
+	set_module_toggle_use_case "github.com/nahualventure/class-backend/core/app/moduletoggle/application/use-cases/set-module-toggle-use-case"
+	moduleTogglePorts "github.com/nahualventure/class-backend/core/app/moduletoggle/domain/ports"
+	get_digest_preference_use_case "github.com/nahualventure/class-backend/core/app/notification/application/use-cases/get-digest-preference-use-case"
+	hold_notifications_use_case "github.com/nahualventure/class-backend/core/app/notification/application/use-cases/hold-notifications-use-case"
+	set_digest_preference_use_case "github.com/nahualventure/class-backend/core/app/notification/application/use-cases/set-digest-preference-use-case"
+	book_appointment_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/book-appointment-use-case"
+	cancel_appointment_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/cancel-appointment-use-case"
+	cancel_availability_slot_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/cancel-availability-slot-use-case"
+	create_availability_slot_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/create-availability-slot-use-case"
+	get_appointment_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/get-appointment-use-case"
+	list_appointments_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/list-appointments-use-case"
+	list_availability_slots_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/list-availability-slots-use-case"
+	list_changes_use_case "github.com/nahualventure/class-backend/core/app/offlinesync/application/use-cases/list-changes-use-case"
+	push_mutations_use_case "github.com/nahualventure/class-backend/core/app/offlinesync/application/use-cases/push-mutations-use-case"
+	get_operation_use_case "github.com/nahualventure/class-backend/core/app/operation/application/use-cases/get-operation-use-case"
+	ensure_partitions_use_case "github.com/nahualventure/class-backend/core/app/partitioning/application/use-cases/ensure-partitions-use-case"
+	acquire_edit_lock_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/acquire-edit-lock-use-case"
+	get_edit_lock_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/get-edit-lock-use-case"
+	list_online_users_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/list-online-users-use-case"
+	record_heartbeat_use_case "github.com/nahualventure/class-backend/core/app/presence/application/use-cases/record-heartbeat-use-case"
+	get_printout_use_case "github.com/nahualventure/class-backend/core/app/printout/application/use-cases/get-printout-use-case"
+	render_printout_use_case "github.com/nahualventure/class-backend/core/app/printout/application/use-cases/render-printout-use-case"
+	request_printout_use_case "github.com/nahualventure/class-backend/core/app/printout/application/use-cases/request-printout-use-case"
+	get_report_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/get-report-use-case"
+	list_reports_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/list-reports-use-case"
+	request_report_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/request-report-use-case"
+	confirm_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/confirm-member-deactivation-use-case"
+	get_group_role_assignment_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/get-group-role-assignment-use-case"
+	get_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/get-member-deactivation-use-case"
+	list_group_role_assignments_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/list-group-role-assignments-use-case"
+	preview_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/preview-member-deactivation-use-case"
+	request_group_role_assignment_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/request-group-role-assignment-use-case"
+	roll_back_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/roll-back-member-deactivation-use-case"
+	create_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/create-tenant-sandbox-use-case"
+	delete_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/delete-tenant-sandbox-use-case"
+	extend_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/extend-tenant-sandbox-use-case"
+	get_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/get-tenant-sandbox-use-case"
+	create_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/create-saved-view-use-case"
+	delete_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/delete-saved-view-use-case"
+	get_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/get-saved-view-use-case"
+	list_saved_views_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/list-saved-views-use-case"
+	update_saved_view_use_case "github.com/nahualventure/class-backend/core/app/savedview/application/use-cases/update-saved-view-use-case"
+	savedViewEntities "github.com/nahualventure/class-backend/core/app/savedview/domain/entities"
+	get_section_change_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/get-section-change-use-case"
+	list_section_changes_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/list-section-changes-use-case"
+	merge_sections_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/merge-sections-use-case"
+	split_section_use_case "github.com/nahualventure/class-backend/core/app/sections/application/use-cases/split-section-use-case"
+	authenticate_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/authenticate-service-account-use-case"
+	create_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/create-service-account-use-case"
+	disable_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/disable-service-account-use-case"
+	get_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/get-service-account-use-case"
+	introspect_token_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/introspect-token-use-case"
+	list_service_accounts_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/list-service-accounts-use-case"
+	list_token_policies_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/list-token-policies-use-case"
+	revoke_service_account_key_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/revoke-service-account-key-use-case"
+	revoke_token_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/revoke-token-use-case"
+	rotate_service_account_key_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/rotate-service-account-key-use-case"
+	set_token_policy_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/set-token-policy-use-case"
+	update_service_account_use_case "github.com/nahualventure/class-backend/core/app/serviceaccount/application/use-cases/update-service-account-use-case"
+	"github.com/nahualventure/class-backend/core/app/shared/projection"
+	"github.com/nahualventure/class-backend/core/app/shared/saga"
+	"github.com/nahualventure/class-backend/core/app/shared/schemachange"
+	get_similarity_check_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/get-similarity-check-use-case"
+	list_similarity_checks_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/list-similarity-checks-use-case"
+	request_similarity_check_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/request-similarity-check-use-case"
+	get_assignment_standards_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/get-assignment-standards-use-case"
+	get_class_mastery_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/get-class-mastery-use-case"
+	get_mastery_policy_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/get-mastery-policy-use-case"
+	get_student_mastery_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/get-student-mastery-use-case"
+	list_standards_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/list-standards-use-case"
+	save_standard_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/save-standard-use-case"
+	set_mastery_policy_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/set-mastery-policy-use-case"
+	tag_assignment_standards_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/tag-assignment-standards-use-case"
+	get_status_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/get-status-use-case"
+	list_status_notes_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/list-status-notes-use-case"
+	post_status_note_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/post-status-note-use-case"
+	resolve_status_note_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/resolve-status-note-use-case"
+	update_status_note_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/update-status-note-use-case"
+	check_storage_quota_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/check-storage-quota-use-case"
+	get_storage_usage_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/get-storage-usage-use-case"
+	list_storage_usage_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/list-storage-usage-use-case"
+	set_storage_quota_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/set-storage-quota-use-case"
+	storageQuotaErrors "github.com/nahualventure/class-backend/core/app/storagequota/domain/errors"
+	close_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/close-form-use-case"
+	create_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/create-form-use-case"
+	get_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/get-form-use-case"
+	list_assigned_forms_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/list-assigned-forms-use-case"
+	list_form_responses_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/list-form-responses-use-case"
+	list_forms_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/list-forms-use-case"
+	open_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/open-form-use-case"
+	submit_response_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/submit-response-use-case"
+	update_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/update-form-use-case"
+	accept_tenant_invitation_use_case "github.com/nahualventure/class-backend/core/app/tenant/application/use-cases/accept-tenant-invitation-use-case"
+	onboard_tenant_use_case "github.com/nahualventure/class-backend/core/app/tenant/application/use-cases/onboard-tenant-use-case"
+	get_time_zone_use_case "github.com/nahualventure/class-backend/core/app/timezone/application/use-cases/get-time-zone-use-case"
+	set_time_zone_use_case "github.com/nahualventure/class-backend/core/app/timezone/application/use-cases/set-time-zone-use-case"
+	create_term_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/create-term-use-case"
+	download_report_card_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/download-report-card-use-case"
+	get_report_card_template_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/get-report-card-template-use-case"
+	get_report_card_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/get-report-card-use-case"
+	get_transcript_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/get-transcript-use-case"
+	list_terms_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/list-terms-use-case"
+	request_report_cards_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/request-report-cards-use-case"
+	save_report_card_template_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/save-report-card-template-use-case"
+	list_users_use_case "github.com/nahualventure/class-backend/core/app/user/application/use-cases/list-users-use-case"
+	resolve_identity_use_case "github.com/nahualventure/class-backend/core/app/user/application/use-cases/resolve-identity-use-case"
+	userPorts "github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	detect_duplicate_users_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/detect-duplicate-users-use-case"
+	dismiss_duplicate_candidate_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/dismiss-duplicate-candidate-use-case"
+	get_user_merge_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/get-user-merge-use-case"
+	list_duplicate_candidates_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/list-duplicate-candidates-use-case"
+	list_user_merges_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/list-user-merges-use-case"
+	merge_users_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/merge-users-use-case"
+	undo_user_merge_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/undo-user-merge-use-case"
+	authenticate_widget_key_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/authenticate-widget-key-use-case"
+	create_widget_key_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/create-widget-key-use-case"
+	delete_announcement_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/delete-announcement-use-case"
+	get_class_calendar_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/get-class-calendar-use-case"
+	list_announcements_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/list-announcements-use-case"
+	list_widget_keys_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/list-widget-keys-use-case"
+	publish_announcement_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/publish-announcement-use-case"
+	revoke_widget_key_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/revoke-widget-key-use-case"
+	accessibilityHandlers "github.com/nahualventure/class-backend/infra/accessibility/handlers"
+	activityHandlers "github.com/nahualventure/class-backend/infra/activity/handlers"
+	analyticsHandlers "github.com/nahualventure/class-backend/infra/analytics/handlers"
+	archiveHandlers "github.com/nahualventure/class-backend/infra/archive/handlers"
+	attendanceHandlers "github.com/nahualventure/class-backend/infra/attendance/handlers"
+	auditHandlers "github.com/nahualventure/class-backend/infra/audit/handlers"
+	authHandlers "github.com/nahualventure/class-backend/infra/auth/handlers"
+	backupHandlers "github.com/nahualventure/class-backend/infra/backup/handlers"
+	behaviorHandlers "github.com/nahualventure/class-backend/infra/behavior/handlers"
+	billingAdapters "github.com/nahualventure/class-backend/infra/billing/adapters"
+	billingHandlers "github.com/nahualventure/class-backend/infra/billing/handlers"
+	calendarHandlers "github.com/nahualventure/class-backend/infra/calendar/handlers"
+	classTemplateHandlers "github.com/nahualventure/class-backend/infra/classtemplate/handlers"
+	customFieldHandlers "github.com/nahualventure/class-backend/infra/customfield/handlers"
+	dashboardHandlers "github.com/nahualventure/class-backend/infra/dashboard/handlers"
+	dataImportAdapters "github.com/nahualventure/class-backend/infra/dataimport/adapters"
+	dataImportHandlers "github.com/nahualventure/class-backend/infra/dataimport/handlers"
+	deliverabilityHandlers "github.com/nahualventure/class-backend/infra/deliverability/handlers"
+	devSandboxHandlers "github.com/nahualventure/class-backend/infra/devsandbox/handlers"
+	directorySyncHandlers "github.com/nahualventure/class-backend/infra/directorysync/handlers"
+	districtAdapters "github.com/nahualventure/class-backend/infra/district/adapters"
+	districtHandlers "github.com/nahualventure/class-backend/infra/district/handlers"
+	emailTemplateHandlers "github.com/nahualventure/class-backend/infra/emailtemplate/handlers"
+	enrollmentHandlers "github.com/nahualventure/class-backend/infra/enrollment/handlers"
+	gradingHandlers "github.com/nahualventure/class-backend/infra/grading/handlers"
+	jobFailureHandlers "github.com/nahualventure/class-backend/infra/jobfailure/handlers"
+	legacyHandlers "github.com/nahualventure/class-backend/infra/legacy/handlers"
+	libraryHandlers "github.com/nahualventure/class-backend/infra/library/handlers"
+	linkedIdentityHandlers "github.com/nahualventure/class-backend/infra/linkedidentity/handlers"
+	localizationHandlers "github.com/nahualventure/class-backend/infra/localization/handlers"
+	messagingHandlers "github.com/nahualventure/class-backend/infra/messaging/handlers"
+	moduleToggleAdapters "github.com/nahualventure/class-backend/infra/moduletoggle/adapters"
+	moduleToggleHandlers "github.com/nahualventure/class-backend/infra/moduletoggle/handlers"
+	notificationAdapters "github.com/nahualventure/class-backend/infra/notification/adapters"
+	notificationHandlers "github.com/nahualventure/class-backend/infra/notification/handlers"
+	officeHoursHandlers "github.com/nahualventure/class-backend/infra/officehours/handlers"
+	offlineSyncHandlers "github.com/nahualventure/class-backend/infra/offlinesync/handlers"
+	operationAdapters "github.com/nahualventure/class-backend/infra/operation/adapters"
+	operationHandlers "github.com/nahualventure/class-backend/infra/operation/handlers"
+	presenceHandlers "github.com/nahualventure/class-backend/infra/presence/handlers"
+	printoutHandlers "github.com/nahualventure/class-backend/infra/printout/handlers"
+	reportHandlers "github.com/nahualventure/class-backend/infra/report/handlers"
+	roleAssignmentHandlers "github.com/nahualventure/class-backend/infra/roleassignment/handlers"
+	sandboxHandlers "github.com/nahualventure/class-backend/infra/sandbox/handlers"
+	savedViewHandlers "github.com/nahualventure/class-backend/infra/savedview/handlers"
+	sectionHandlers "github.com/nahualventure/class-backend/infra/sections/handlers"
+	serviceAccountHandlers "github.com/nahualventure/class-backend/infra/serviceaccount/handlers"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/grpcserver"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+	"github.com/nahualventure/class-backend/infra/shared/residency"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	similarityHandlers "github.com/nahualventure/class-backend/infra/similarity/handlers"
+	standardsHandlers "github.com/nahualventure/class-backend/infra/standards/handlers"
+	statusHandlers "github.com/nahualventure/class-backend/infra/status/handlers"
+	storageQuotaHandlers "github.com/nahualventure/class-backend/infra/storagequota/handlers"
+	surveysHandlers "github.com/nahualventure/class-backend/infra/surveys/handlers"
+	tenantHandlers "github.com/nahualventure/class-backend/infra/tenant/handlers"
+	timezoneHandlers "github.com/nahualventure/class-backend/infra/timezone/handlers"
+	transcriptHandlers "github.com/nahualventure/class-backend/infra/transcript/handlers"
+	userAdapters "github.com/nahualventure/class-backend/infra/user/adapters"
+	userHandlers "github.com/nahualventure/class-backend/infra/user/handlers"
+	userMergeHandlers "github.com/nahualventure/class-backend/infra/usermerge/handlers"
+	widgetAdapters "github.com/nahualventure/class-backend/infra/widget/adapters"
+	widgetHandlers "github.com/nahualventure/class-backend/infra/widget/handlers"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// Module is the handlers of a module
+type Module interface {
+	RegisterRoutes(api huma.API)
+}
+
+// NamedModule is a module with the name instances select it by, modules whose handlers are split
+// in several share their name
+type NamedModule struct {
+	Name string
+	Module
+}
+
+// Config is what the modules take from the configuration of the instance besides their adapters.
+// The zero value runs the jobs of no tenant and gives the admin routes of status and districts no
+// token.
+type Config struct {
+	// Tenants are the tenants the scheduled jobs go through
+	Tenants []string
+	// Residency keeps districts in the cluster of their region, sandboxes are created by the region
+	// of unpinned tenants. Nil serves every district and sandbox from this instance.
+	Residency *residency.Router
+
+	StatusRateLimitPerMinute           int
+	StatusAdminToken                   string
+	DistrictRateLimitPerMinute         int
+	DistrictAdminToken                 string
+	DeveloperSandboxRateLimitPerMinute int
+	// AbuseThresholds flag the consumers of the API usage report
+	AbuseThresholds analyticsEntities.AbuseThresholds
+
+	// WarehousePseudonymKey replaces the identifiers of warehouse exports
+	WarehousePseudonymKey string
+	// ArchiveSchoolYearStartMonth moves prior-year records out of the hot tables, 0 disables archival
+	ArchiveSchoolYearStartMonth int
+	// BackupInterval backs up every tenant that often, 0 only runs the backups admins request
+	BackupInterval time.Duration
+}
+
+// Container is the composition root of the modules. It builds each use case once from the adapters
+// and injects them into the handlers, so swapping an adapter for a fake swaps it for every use case.
+// Modules whose adapters are not configured are left nil and not served, StartWorkers runs the
+// background work of the modules.
+type Container struct {
+	Auth         *authHandlers.AuthHandlers
+	User         *userHandlers.UserHandlers
+	Grading      *gradingHandlers.GradingHandlers
+	Report       *reportHandlers.ReportHandlers
+	Messaging    *messagingHandlers.MessagingHandlers
+	OfficeHours  *officeHoursHandlers.OfficeHoursHandlers
+	Behavior     *behaviorHandlers.BehaviorHandlers
+	Library      *libraryHandlers.LibraryHandlers
+	Surveys      *surveysHandlers.SurveysHandlers
+	Localization *localizationHandlers.LocalizationHandlers
+	TimeZone     *timezoneHandlers.TimeZoneHandlers
+	Calendar     *calendarHandlers.CalendarHandlers
+	Archive      *archiveHandlers.ArchiveHandlers
+	Backup       *backupHandlers.BackupHandlers
+	Audit        *auditHandlers.AuditHandlers
+	// ServiceAccount manages the accounts, their keys are checked by the service account middleware
+	ServiceAccount *serviceAccountHandlers.ServiceAccountHandlers
+	Token          *serviceAccountHandlers.TokenHandlers
+	TokenPolicy    *serviceAccountHandlers.TokenPolicyHandlers
+	// GroupRoleAssignment queues the roles of whole groups, the worker writes them
+	GroupRoleAssignment *roleAssignmentHandlers.GroupRoleAssignmentHandlers
+	// MemberDeactivation previews and confirms the deactivation of many members, the worker
+	// revokes their roles
+	MemberDeactivation *roleAssignmentHandlers.MemberDeactivationHandlers
+	// Sandbox queues the sandboxes of tenants, the worker provisions and removes them
+	Sandbox *sandboxHandlers.SandboxHandlers
+	// Legacy serves the routes of the old platform until their sunset
+	Legacy *legacyHandlers.LegacyHandlers
+	// DataImport previews and queues CSV imports, the worker runs them
+	DataImport *dataImportHandlers.DataImportHandlers
+	// UserMerge reviews and merges duplicate accounts, the job detects them
+	UserMerge *userMergeHandlers.UserMergeHandlers
+	// LinkedIdentity links the external identities users sign in with through the gateway
+	LinkedIdentity *linkedIdentityHandlers.LinkedIdentityHandlers
+	// Tenant accepts the invitations onboarding sends, the onboarding saga creates tenants
+	Tenant *tenantHandlers.TenantHandlers
+	// DirectorySync takes the users of the roster or SCIM directory and reviews the conflicts with
+	// local changes
+	DirectorySync *directorySyncHandlers.DirectorySyncHandlers
+	// Deliverability takes the bounces and complaints of the email providers and keeps the
+	// suppression list senders check
+	Deliverability *deliverabilityHandlers.DeliverabilityHandlers
+	// EmailTemplate brands the emails of the tenant and previews and test-sends their templates
+	EmailTemplate *emailTemplateHandlers.EmailTemplateHandlers
+	// Notification keeps how often every user is sent their notifications, the digests are held and
+	// sent by the workers
+	Notification *notificationHandlers.NotificationHandlers
+	// StorageQuota reports the bytes stored by the tenant and its users and sets their quota, the job
+	// reconciles the usage
+	StorageQuota *storageQuotaHandlers.StorageQuotaHandlers
+	// Attendance opens kiosk check-in to class sessions, students check in with the QR code it shows
+	Attendance *attendanceHandlers.AttendanceHandlers
+	// Transcript averages released grades by term and queues report cards, the worker
+	// generates them and sends their links to guardians
+	Transcript *transcriptHandlers.TranscriptHandlers
+	// Standards tags assignments with the standards of the tenant and reports the mastery of each,
+	// the alternative to averages for standards-based grading
+	Standards *standardsHandlers.StandardsHandlers
+	// ClassTemplate saves the setup of classes as templates and sets new classes up from them, platform
+	// admins share templates with every tenant from the command line
+	ClassTemplate *classTemplateHandlers.ClassTemplateHandlers
+	// Sections merges class sections and splits students off them, admins only
+	Sections *sectionHandlers.SectionHandlers
+	// ModuleToggle lists the modules enabled for the tenant, support toggles them from the command line
+	ModuleToggle *moduleToggleHandlers.ModuleToggleHandlers
+	// Widget serves the announcements and class calendars school websites embed with publishable
+	// keys, without signing in, and lets admins manage the keys and announcements
+	Widget *widgetHandlers.WidgetHandlers
+	// Accessibility reports how far the materials of classes meet accessibility mandates, the job
+	// captions videos and audio
+	Accessibility *accessibilityHandlers.AccessibilityHandlers
+	// JobFailure lists the work background jobs keep failing and requeues the escalated work, the
+	// jobs record the failures
+	JobFailure *jobFailureHandlers.JobFailureHandlers
+	// CustomField lets admins define custom fields on users and classes, the values of each record are
+	// checked against them and searchable
+	CustomField *customFieldHandlers.CustomFieldHandlers
+	// SavedView saves the orders and filters of list endpoints as named views, lists apply them by ID
+	SavedView *savedViewHandlers.SavedViewHandlers
+	// OfflineSync serves the change feeds of classes to offline devices and applies the grades they
+	// recorded offline
+	OfflineSync *offlineSyncHandlers.OfflineSyncHandlers
+	// Printout renders rosters, weekly schedules and attendance sheets of classes as PDF files, large
+	// ones are generated by the worker
+	Printout *printoutHandlers.PrintoutHandlers
+	// Operation is the status of the reports, imports, printouts and backups running asynchronously,
+	// clients poll it the same way for each
+	Operation *operationHandlers.OperationHandlers
+	// Dashboard serves the class summaries and tenant metrics projected from the outbox
+	Dashboard *dashboardHandlers.DashboardHandlers
+	// Enrollment sets the capacity and schedule of classes and enrolls students in the seats they
+	// hold, the worker releases the expired holds
+	Enrollment *enrollmentHandlers.EnrollmentHandlers
+	// Presence tracks who is online and who edits what, over HTTP and its WebSocket
+	Presence *presenceHandlers.PresenceHandlers
+	// Status serves the status page, incident notes are posted with the status admin token
+	Status *statusHandlers.StatusHandlers
+	// District manages districts with the district admin token, districts read the aggregated
+	// metrics of their schools with their own keys
+	District *districtHandlers.DistrictHandlers
+	// DevSandbox creates the synthetic tenants integrators call the API in, nil outside the region of
+	// unpinned tenants
+	DevSandbox *devSandboxHandlers.DeveloperSandboxHandlers
+	// Analytics reports the usage of every consumer for capacity planning and abuse detection
+	Analytics *analyticsHandlers.AnalyticsHandlers
+	// Activity is the timeline of the support console
+	Activity *activityHandlers.ActivityHandlers
+	// Billing serves the subscription and invoices of the tenant, nil until a billing provider is set
+	Billing *billingHandlers.BillingHandlers
+	// Similarity checks submissions with the provider, nil until one is set
+	Similarity *similarityHandlers.SimilarityHandlers
+
+	// ActivityRecorder records the permission checks of requests for the activity timeline and
+	// UsageCollector counts the requests of each consumer, the workers flush both
+	ActivityRecorder *activityHandlers.ActivityRecorder
+	UsageCollector   *analyticsHandlers.UsageCollector
+
+	// Use cases middlewares and modules outside the container share
+	GetTimeZone                *get_time_zone_use_case.GetTimeZoneUseCase
+	GetSchoolCalendar          *get_school_calendar_use_case.GetSchoolCalendarUseCase
+	AuthenticateServiceAccount *authenticate_service_account_use_case.AuthenticateServiceAccountUseCase
+	RunImport                  *run_import_use_case.RunImportUseCase
+	DetectDuplicateUsers       *detect_duplicate_users_use_case.DetectDuplicateUsersUseCase
+	CheckStorageQuota          *check_storage_quota_use_case.CheckStorageQuotaUseCase
+	CheckModuleEnabled         *check_module_enabled_use_case.CheckModuleEnabledUseCase
+	SetModuleToggle            *set_module_toggle_use_case.SetModuleToggleUseCase
+	// DisabledModules caches the disabled modules of tenants for a minute, toggles take that long to
+	// reach requests
+	DisabledModules moduleTogglePorts.ModuleToggleReader
+
+	adapters Adapters
+	config   Config
+	// sagas runs the onboarding and enrollment workflows, they register their definitions in New
+	sagas            *saga.Coordinator
+	projections      *projection.Runner
+	schemaChanges    *schemachange.Coordinator
+	ensurePartitions *ensure_partitions_use_case.EnsurePartitionsUseCase
+	onboardTenant    *onboard_tenant_use_case.OnboardTenantUseCase
+	releaseSeatHold  *release_seat_hold_use_case.ReleaseSeatHoldUseCase
+	// checkFeature and checkPlanLimit gate endpoints by the plan of the tenant, nil without billing
+	checkFeature   *check_feature_use_case.CheckFeatureUseCase
+	checkPlanLimit *check_plan_limit_use_case.CheckPlanLimitUseCase
+}
+
+func New(adapters Adapters, config Config) *Container {
+	localizeContent := localize_content_use_case.NewLocalizeContentUseCase(adapters.Translations)
+	getTimeZone := get_time_zone_use_case.NewGetTimeZoneUseCase(adapters.TimeZones)
+	importers := dataImportAdapters.NewEntityImporters(
+		adapters.Users,
+		signup_use_case.NewCreateUserUseCase(adapters.Users),
+		record_grade_use_case.NewRecordGradeUseCase(adapters.Grades),
+		add_non_instructional_day_use_case.NewAddNonInstructionalDayUseCase(adapters.NonInstructionalDays),
+		save_standard_use_case.NewSaveStandardUseCase(adapters.Standards),
+	)
+	previewImport := preview_import_use_case.NewPreviewImportUseCase(adapters.ImportTemplates, importers, adapters.ImportPermissions)
+	previewMemberDeactivation := preview_member_deactivation_use_case.NewPreviewMemberDeactivationUseCase(adapters.MemberDeactivations,
+		adapters.TenantRoles, adapters.Groups, adapters.BulkRoles)
+	disabledModules := moduleToggleAdapters.NewCachedModuleToggleReader(adapters.ModuleToggles, time.Minute)
+	// savedViewLists are the lists views can be saved for, checked with the allowlists of their endpoint
+	savedViewLists := savedViewEntities.ListDefinitions{
+		userPorts.UsersList: {OrderFields: userPorts.UserListOrderFields, FilterFields: userPorts.UserListFilterFields},
+	}
+	getSavedView := get_saved_view_use_case.NewGetSavedViewUseCase(adapters.SavedViews)
+	getSchoolCalendar := get_school_calendar_use_case.NewGetSchoolCalendarUseCase(adapters.SchoolCalendar)
+	sagas := saga.NewCoordinator(adapters.Sagas)
+	releaseSeatHold := release_seat_hold_use_case.NewReleaseSeatHoldUseCase(adapters.SeatInventory)
+	districts := adapters.Districts
+	if config.Residency != nil {
+		districts = districtAdapters.NewResidentDistrictRepository(districts, config.Residency)
+	}
+	presence := adapters.Presence
+	editLocks := adapters.EditLocks
+
+	c := &Container{
+		Auth: authHandlers.NewAuthHandlers(
+			signup_use_case.NewCreateUserUseCase(adapters.Users),
+			batch_signup_use_case.NewBatchSignupUseCase(adapters.Users),
+			verify_password_use_case.NewVerifyPasswordUseCase(adapters.Users, adapters.Passwords),
+			import_users_use_case.NewImportUsersUseCase(adapters.Users, adapters.Passwords),
+			ratelimit.NewTenantRateLimiter(5, 5),
+		),
+		User: userHandlers.NewUserHandlers(
+			list_users_use_case.NewListUsersUseCase(adapters.Users),
+			ratelimit.NewTenantRateLimiter(10, 3),
+			savedViewHandlers.NewSavedViewApplier(getSavedView),
+		),
+		Grading: gradingHandlers.NewGradingHandlers(
+			record_grade_use_case.NewRecordGradeUseCase(adapters.Grades),
+			change_grade_status_use_case.NewChangeGradeStatusUseCase(adapters.Grades, adapters.GradingPolicies, adapters.Deadlines, adapters.SchoolCalendar),
+			list_class_grades_use_case.NewListClassGradesUseCase(adapters.Grades, adapters.Deadlines, adapters.SchoolCalendar),
+			list_student_grades_use_case.NewListStudentGradesUseCase(adapters.Grades),
+			set_grading_policy_use_case.NewSetGradingPolicyUseCase(adapters.GradingPolicies),
+			set_assignment_deadline_use_case.NewSetAssignmentDeadlineUseCase(adapters.Deadlines),
+			grant_extension_use_case.NewGrantExtensionUseCase(adapters.Deadlines),
+			get_grade_history_use_case.NewGetGradeHistoryUseCase(adapters.Grades),
+			preview_grade_adjustment_use_case.NewPreviewGradeAdjustmentUseCase(adapters.Grades),
+			apply_grade_adjustment_use_case.NewApplyGradeAdjustmentUseCase(adapters.Grades),
+			undo_grade_adjustment_use_case.NewUndoGradeAdjustmentUseCase(adapters.Grades),
+		),
+		Report: reportHandlers.NewReportHandlers(
+			request_report_use_case.NewRequestReportUseCase(adapters.Reports),
+			list_reports_use_case.NewListReportsUseCase(adapters.Reports),
+			get_report_use_case.NewGetReportUseCase(adapters.Reports),
+		),
+		Messaging: messagingHandlers.NewMessagingHandlers(
+			messaging_service.NewMessagingService(adapters.Conversations, adapters.Messages, adapters.AbuseReports,
+				adapters.MessagingParticipants, adapters.Files),
+			adapters.Users,
+		),
+		OfficeHours: officeHoursHandlers.NewOfficeHoursHandlers(
+			create_availability_slot_use_case.NewCreateAvailabilitySlotUseCase(adapters.Slots),
+			cancel_availability_slot_use_case.NewCancelAvailabilitySlotUseCase(adapters.Slots),
+			list_availability_slots_use_case.NewListAvailabilitySlotsUseCase(adapters.Slots),
+			book_appointment_use_case.NewBookAppointmentUseCase(adapters.AppointmentBook),
+			cancel_appointment_use_case.NewCancelAppointmentUseCase(adapters.Appointments),
+			get_appointment_use_case.NewGetAppointmentUseCase(adapters.Appointments),
+			list_appointments_use_case.NewListAppointmentsUseCase(adapters.Appointments),
+		),
+		Behavior: behaviorHandlers.NewBehaviorHandlers(
+			report_incident_use_case.NewReportIncidentUseCase(adapters.Incidents, adapters.IncidentViewers),
+			list_incidents_use_case.NewListIncidentsUseCase(adapters.Incidents, adapters.IncidentViewers),
+			get_incident_use_case.NewGetIncidentUseCase(adapters.Incidents, adapters.IncidentNotes, adapters.GuardianNotifications, adapters.IncidentViewers),
+			add_incident_note_use_case.NewAddIncidentNoteUseCase(adapters.Incidents, adapters.IncidentNotes, adapters.IncidentViewers),
+			notify_incident_guardians_use_case.NewNotifyIncidentGuardiansUseCase(adapters.Incidents, adapters.GuardianNotifications, adapters.IncidentViewers),
+			acknowledge_incident_notification_use_case.NewAcknowledgeIncidentNotificationUseCase(adapters.GuardianNotifications),
+			resolve_incident_use_case.NewResolveIncidentUseCase(adapters.Incidents),
+		),
+		Library: libraryHandlers.NewLibraryHandlers(
+			create_resource_use_case.NewCreateResourceUseCase(adapters.Resources),
+			list_resources_use_case.NewListResourcesUseCase(adapters.Resources, adapters.LibraryViewers),
+			get_resource_use_case.NewGetResourceUseCase(adapters.Resources, adapters.LibraryViewers),
+			update_resource_use_case.NewUpdateResourceUseCase(adapters.Resources, adapters.LibraryViewers),
+			upload_resource_version_use_case.NewUploadResourceVersionUseCase(adapters.Resources, adapters.LibraryViewers, adapters.Files),
+			download_resource_version_use_case.NewDownloadResourceVersionUseCase(adapters.Resources, adapters.LibraryViewers, adapters.Files),
+			change_resource_status_use_case.NewChangeResourceStatusUseCase(adapters.Resources, adapters.LibraryViewers),
+			localizeContent,
+		),
+		Surveys: surveysHandlers.NewSurveysHandlers(
+			create_form_use_case.NewCreateFormUseCase(adapters.Forms),
+			update_form_use_case.NewUpdateFormUseCase(adapters.Forms, adapters.SurveyParticipants),
+			open_form_use_case.NewOpenFormUseCase(adapters.Forms, adapters.SurveyParticipants),
+			close_form_use_case.NewCloseFormUseCase(adapters.Forms, adapters.SurveyParticipants),
+			get_form_use_case.NewGetFormUseCase(adapters.Forms, adapters.FormResponses, adapters.SurveyParticipants),
+			list_forms_use_case.NewListFormsUseCase(adapters.Forms, adapters.SurveyParticipants),
+			list_assigned_forms_use_case.NewListAssignedFormsUseCase(adapters.Forms, adapters.FormResponses, adapters.SurveyParticipants),
+			submit_response_use_case.NewSubmitResponseUseCase(adapters.Forms, adapters.FormResponses, adapters.SurveyParticipants),
+			list_form_responses_use_case.NewListFormResponsesUseCase(adapters.Forms, adapters.FormResponses, adapters.SurveyParticipants),
+			localizeContent,
+		),
+		Localization: localizationHandlers.NewLocalizationHandlers(
+			set_translation_use_case.NewSetTranslationUseCase(adapters.Translations, adapters.TranslatableEntities),
+			delete_translation_use_case.NewDeleteTranslationUseCase(adapters.Translations),
+			list_translations_use_case.NewListTranslationsUseCase(adapters.Translations, adapters.TranslatableEntities),
+		),
+		// Saves go through the cache of the time zone middleware
+		TimeZone: timezoneHandlers.NewTimeZoneHandlers(
+			getTimeZone,
+			set_time_zone_use_case.NewSetTimeZoneUseCase(adapters.TimeZones),
+		),
+		Calendar: calendarHandlers.NewCalendarHandlers(
+			add_non_instructional_day_use_case.NewAddNonInstructionalDayUseCase(adapters.NonInstructionalDays),
+			remove_non_instructional_day_use_case.NewRemoveNonInstructionalDayUseCase(adapters.NonInstructionalDays),
+			list_non_instructional_days_use_case.NewListNonInstructionalDaysUseCase(adapters.NonInstructionalDays),
+			import_holidays_use_case.NewImportHolidaysUseCase(adapters.NonInstructionalDays),
+		),
+		Archive: archiveHandlers.NewArchiveHandlers(
+			list_archive_batches_use_case.NewListArchiveBatchesUseCase(adapters.Archive),
+			restore_archive_batch_use_case.NewRestoreArchiveBatchUseCase(adapters.Archive),
+		),
+		Backup: backupHandlers.NewBackupHandlers(
+			request_tenant_backup_use_case.NewRequestTenantBackupUseCase(adapters.TenantBackups),
+			list_tenant_backups_use_case.NewListTenantBackupsUseCase(adapters.TenantBackups),
+			get_tenant_backup_use_case.NewGetTenantBackupUseCase(adapters.TenantBackups),
+		),
+		Audit: auditHandlers.NewAuditHandlers(
+			list_audit_entries_use_case.NewListAuditEntriesUseCase(adapters.AuditLog),
+			verify_audit_log_use_case.NewVerifyAuditLogUseCase(adapters.AuditLog),
+		),
+		ServiceAccount: serviceAccountHandlers.NewServiceAccountHandlers(
+			create_service_account_use_case.NewCreateServiceAccountUseCase(adapters.ServiceAccounts, adapters.Roles, adapters.TokenPolicies),
+			list_service_accounts_use_case.NewListServiceAccountsUseCase(adapters.ServiceAccounts, adapters.Roles),
+			get_service_account_use_case.NewGetServiceAccountUseCase(adapters.ServiceAccounts, adapters.Roles),
+			update_service_account_use_case.NewUpdateServiceAccountUseCase(adapters.ServiceAccounts, adapters.Roles),
+			rotate_service_account_key_use_case.NewRotateServiceAccountKeyUseCase(adapters.ServiceAccounts, adapters.Roles, adapters.TokenPolicies),
+			revoke_service_account_key_use_case.NewRevokeServiceAccountKeyUseCase(adapters.ServiceAccounts, adapters.Roles),
+			disable_service_account_use_case.NewDisableServiceAccountUseCase(adapters.ServiceAccounts, adapters.Roles),
+		),
+		Token: serviceAccountHandlers.NewTokenHandlers(
+			introspect_token_use_case.NewIntrospectTokenUseCase(adapters.ServiceAccounts, adapters.Roles),
+			revoke_token_use_case.NewRevokeTokenUseCase(adapters.ServiceAccounts),
+		),
+		TokenPolicy: serviceAccountHandlers.NewTokenPolicyHandlers(
+			list_token_policies_use_case.NewListTokenPoliciesUseCase(adapters.TokenPolicies),
+			set_token_policy_use_case.NewSetTokenPolicyUseCase(adapters.TokenPolicies),
+		),
+		GroupRoleAssignment: roleAssignmentHandlers.NewGroupRoleAssignmentHandlers(
+			request_group_role_assignment_use_case.NewRequestGroupRoleAssignmentUseCase(adapters.GroupRoleAssignments, adapters.BulkRoles),
+			list_group_role_assignments_use_case.NewListGroupRoleAssignmentsUseCase(adapters.GroupRoleAssignments),
+			get_group_role_assignment_use_case.NewGetGroupRoleAssignmentUseCase(adapters.GroupRoleAssignments),
+		),
+		MemberDeactivation: roleAssignmentHandlers.NewMemberDeactivationHandlers(
+			previewMemberDeactivation,
+			confirm_member_deactivation_use_case.NewConfirmMemberDeactivationUseCase(adapters.MemberDeactivations, previewMemberDeactivation),
+			get_member_deactivation_use_case.NewGetMemberDeactivationUseCase(adapters.MemberDeactivations),
+			roll_back_member_deactivation_use_case.NewRollBackMemberDeactivationUseCase(adapters.MemberDeactivations, adapters.TenantRoles),
+		),
+		Sandbox: sandboxHandlers.NewSandboxHandlers(
+			create_tenant_sandbox_use_case.NewCreateTenantSandboxUseCase(adapters.TenantSandboxes),
+			get_tenant_sandbox_use_case.NewGetTenantSandboxUseCase(adapters.TenantSandboxes),
+			extend_tenant_sandbox_use_case.NewExtendTenantSandboxUseCase(adapters.TenantSandboxes),
+			delete_tenant_sandbox_use_case.NewDeleteTenantSandboxUseCase(adapters.TenantSandboxes),
+		),
+		Legacy: legacyHandlers.NewLegacyHandlers(
+			signup_use_case.NewCreateUserUseCase(adapters.Users),
+			list_users_use_case.NewListUsersUseCase(adapters.Users),
+			record_grade_use_case.NewRecordGradeUseCase(adapters.Grades),
+			list_class_grades_use_case.NewListClassGradesUseCase(adapters.Grades, adapters.Deadlines, adapters.SchoolCalendar),
+		),
+		DataImport: dataImportHandlers.NewDataImportHandlers(
+			previewImport,
+			start_import_use_case.NewStartImportUseCase(adapters.Imports, previewImport),
+			list_imports_use_case.NewListImportsUseCase(adapters.Imports),
+			get_import_use_case.NewGetImportUseCase(adapters.Imports),
+			save_mapping_template_use_case.NewSaveMappingTemplateUseCase(adapters.ImportTemplates),
+			list_mapping_templates_use_case.NewListMappingTemplatesUseCase(adapters.ImportTemplates),
+			delete_mapping_template_use_case.NewDeleteMappingTemplateUseCase(adapters.ImportTemplates),
+		),
+		UserMerge: userMergeHandlers.NewUserMergeHandlers(
+			list_duplicate_candidates_use_case.NewListDuplicateCandidatesUseCase(adapters.DuplicateCandidates),
+			dismiss_duplicate_candidate_use_case.NewDismissDuplicateCandidateUseCase(adapters.DuplicateCandidates),
+			merge_users_use_case.NewMergeUsersUseCase(adapters.UserMerges, adapters.TenantMembers),
+			list_user_merges_use_case.NewListUserMergesUseCase(adapters.UserMerges),
+			get_user_merge_use_case.NewGetUserMergeUseCase(adapters.UserMerges),
+			undo_user_merge_use_case.NewUndoUserMergeUseCase(adapters.UserMerges),
+		),
+		LinkedIdentity: linkedIdentityHandlers.NewLinkedIdentityHandlers(
+			get_credentials_use_case.NewGetCredentialsUseCase(adapters.LinkedIdentities),
+			get_member_credentials_use_case.NewGetMemberCredentialsUseCase(adapters.LinkedIdentities),
+			link_identity_use_case.NewLinkIdentityUseCase(adapters.LinkedIdentities, adapters.IdentityTokens),
+			unlink_identity_use_case.NewUnlinkIdentityUseCase(adapters.LinkedIdentities),
+			remove_password_use_case.NewRemovePasswordUseCase(adapters.LinkedIdentities),
+			resolve_linked_identity_use_case.NewResolveLinkedIdentityUseCase(adapters.LinkedIdentities),
+			adapters.Users,
+		),
+		Tenant: tenantHandlers.NewTenantHandlers(
+			accept_tenant_invitation_use_case.NewAcceptTenantInvitationUseCase(adapters.TenantInvitations, adapters.TenantPolicies, adapters.Users),
+		),
+		DirectorySync: directorySyncHandlers.NewDirectorySyncHandlers(
+			sync_directory_users_use_case.NewSyncDirectoryUsersUseCase(adapters.DirectoryUsers, adapters.SyncPolicies),
+			list_sync_policies_use_case.NewListSyncPoliciesUseCase(adapters.SyncPolicies),
+			set_sync_policy_use_case.NewSetSyncPolicyUseCase(adapters.SyncPolicies),
+			list_sync_conflicts_use_case.NewListSyncConflictsUseCase(adapters.SyncConflicts),
+			get_sync_conflict_use_case.NewGetSyncConflictUseCase(adapters.SyncConflicts),
+			resolve_sync_conflict_use_case.NewResolveSyncConflictUseCase(adapters.SyncConflicts),
+		),
+		Deliverability: deliverabilityHandlers.NewDeliverabilityHandlers(
+			adapters.Webhooks,
+			check_suppressions_use_case.NewCheckSuppressionsUseCase(adapters.Suppressions),
+			list_suppressions_use_case.NewListSuppressionsUseCase(adapters.Suppressions),
+			remove_suppression_use_case.NewRemoveSuppressionUseCase(adapters.Suppressions),
+			get_deliverability_stats_use_case.NewGetDeliverabilityStatsUseCase(adapters.DeliveryEvents),
+		),
+		EmailTemplate: emailTemplateHandlers.NewEmailTemplateHandlers(
+			list_email_templates_use_case.NewListEmailTemplatesUseCase(adapters.EmailTemplates),
+			save_email_template_use_case.NewSaveEmailTemplateUseCase(adapters.EmailTemplates),
+			preview_email_template_use_case.NewPreviewEmailTemplateUseCase(adapters.EmailTemplates, adapters.EmailBranding),
+			send_test_email_use_case.NewSendTestEmailUseCase(adapters.EmailTemplates, adapters.EmailBranding, adapters.TestRecipients, adapters.TestEmailQueue),
+			get_email_branding_use_case.NewGetEmailBrandingUseCase(adapters.EmailBranding),
+			set_email_branding_use_case.NewSetEmailBrandingUseCase(adapters.EmailBranding),
+		),
+		Notification: notificationHandlers.NewNotificationHandlers(
+			get_digest_preference_use_case.NewGetDigestPreferenceUseCase(adapters.DigestPreferences),
+			set_digest_preference_use_case.NewSetDigestPreferenceUseCase(adapters.DigestPreferences),
+		),
+		StorageQuota: storageQuotaHandlers.NewStorageQuotaHandlers(
+			get_storage_usage_use_case.NewGetStorageUsageUseCase(adapters.StorageUsage, adapters.StorageQuotas),
+			list_storage_usage_use_case.NewListStorageUsageUseCase(adapters.StorageUsage, adapters.StorageQuotas),
+			set_storage_quota_use_case.NewSetStorageQuotaUseCase(adapters.StorageQuotas),
+		),
+		Attendance: attendanceHandlers.NewAttendanceHandlers(
+			open_check_in_use_case.NewOpenCheckInUseCase(adapters.CheckInSessions, adapters.ClassSessions),
+			get_check_in_code_use_case.NewGetCheckInCodeUseCase(adapters.CheckInSessions),
+			check_in_use_case.NewCheckInUseCase(adapters.CheckInSessions, adapters.ClassRoster, adapters.Attendance),
+		),
+		Transcript: transcriptHandlers.NewTranscriptHandlers(
+			create_term_use_case.NewCreateTermUseCase(adapters.Terms),
+			list_terms_use_case.NewListTermsUseCase(adapters.Terms),
+			get_transcript_use_case.NewGetTranscriptUseCase(adapters.Terms, adapters.TranscriptSource),
+			get_report_card_template_use_case.NewGetReportCardTemplateUseCase(adapters.ReportCardTemplates),
+			save_report_card_template_use_case.NewSaveReportCardTemplateUseCase(adapters.ReportCardTemplates),
+			request_report_cards_use_case.NewRequestReportCardsUseCase(adapters.Terms, adapters.ReportCards),
+			get_report_card_use_case.NewGetReportCardUseCase(adapters.ReportCards),
+			download_report_card_use_case.NewDownloadReportCardUseCase(adapters.ReportCards),
+		),
+		Standards: standardsHandlers.NewStandardsHandlers(
+			save_standard_use_case.NewSaveStandardUseCase(adapters.Standards),
+			list_standards_use_case.NewListStandardsUseCase(adapters.Standards),
+			tag_assignment_standards_use_case.NewTagAssignmentStandardsUseCase(adapters.Standards, adapters.AssignmentStandards),
+			get_assignment_standards_use_case.NewGetAssignmentStandardsUseCase(adapters.Standards, adapters.AssignmentStandards),
+			get_mastery_policy_use_case.NewGetMasteryPolicyUseCase(adapters.MasteryPolicies),
+			set_mastery_policy_use_case.NewSetMasteryPolicyUseCase(adapters.MasteryPolicies),
+			get_class_mastery_use_case.NewGetClassMasteryUseCase(adapters.Standards, adapters.MasteryPolicies, adapters.EvidenceSource),
+			get_student_mastery_use_case.NewGetStudentMasteryUseCase(adapters.Standards, adapters.MasteryPolicies, adapters.EvidenceSource),
+		),
+		ClassTemplate: classTemplateHandlers.NewClassTemplateHandlers(
+			save_class_template_use_case.NewSaveClassTemplateUseCase(adapters.Terms, adapters.ClassSetups, adapters.ClassTemplates),
+			list_class_templates_use_case.NewListClassTemplatesUseCase(adapters.ClassTemplates),
+			get_class_template_use_case.NewGetClassTemplateUseCase(adapters.ClassTemplates),
+			delete_class_template_use_case.NewDeleteClassTemplateUseCase(adapters.ClassTemplates),
+			instantiate_class_template_use_case.NewInstantiateClassTemplateUseCase(adapters.Terms, adapters.ClassSetups, adapters.ClassTemplates),
+		),
+		Sections: sectionHandlers.NewSectionHandlers(
+			merge_sections_use_case.NewMergeSectionsUseCase(adapters.SectionChanges),
+			split_section_use_case.NewSplitSectionUseCase(adapters.SectionChanges),
+			list_section_changes_use_case.NewListSectionChangesUseCase(adapters.SectionChanges),
+			get_section_change_use_case.NewGetSectionChangeUseCase(adapters.SectionChanges),
+		),
+		ModuleToggle: moduleToggleHandlers.NewModuleToggleHandlers(
+			list_tenant_modules_use_case.NewListTenantModulesUseCase(adapters.ModuleToggles),
+		),
+		Widget: widgetHandlers.NewWidgetHandlers(
+			create_widget_key_use_case.NewCreateWidgetKeyUseCase(adapters.WidgetKeys),
+			list_widget_keys_use_case.NewListWidgetKeysUseCase(adapters.WidgetKeys),
+			revoke_widget_key_use_case.NewRevokeWidgetKeyUseCase(adapters.WidgetKeys),
+			authenticate_widget_key_use_case.NewAuthenticateWidgetKeyUseCase(
+				widgetAdapters.NewCachedWidgetKeyReader(adapters.WidgetKeys, time.Minute),
+			),
+			publish_announcement_use_case.NewPublishAnnouncementUseCase(adapters.Announcements),
+			list_announcements_use_case.NewListAnnouncementsUseCase(adapters.Announcements),
+			delete_announcement_use_case.NewDeleteAnnouncementUseCase(adapters.Announcements),
+			get_class_calendar_use_case.NewGetClassCalendarUseCase(adapters.ClassMeetings, adapters.SchoolCalendar),
+			ratelimit.NewKeyRateLimiter(),
+		),
+		Accessibility: accessibilityHandlers.NewAccessibilityHandlers(
+			get_class_accessibility_report_use_case.NewGetClassAccessibilityReportUseCase(adapters.ClassMaterials),
+		),
+		JobFailure: jobFailureHandlers.NewJobFailureHandlers(
+			list_job_failures_use_case.NewListJobFailuresUseCase(adapters.JobFailures),
+			requeue_job_failure_use_case.NewRequeueJobFailureUseCase(adapters.JobFailures),
+		),
+		CustomField: customFieldHandlers.NewCustomFieldHandlers(
+			list_custom_fields_use_case.NewListCustomFieldsUseCase(adapters.CustomFields),
+			create_custom_field_use_case.NewCreateCustomFieldUseCase(adapters.CustomFields),
+			update_custom_field_use_case.NewUpdateCustomFieldUseCase(adapters.CustomFields),
+			delete_custom_field_use_case.NewDeleteCustomFieldUseCase(adapters.CustomFields),
+			get_attributes_use_case.NewGetAttributesUseCase(adapters.EntityAttributes),
+			set_attributes_use_case.NewSetAttributesUseCase(adapters.CustomFields, adapters.EntityAttributes),
+			search_attributes_use_case.NewSearchAttributesUseCase(adapters.CustomFields, adapters.EntityAttributes),
+		),
+		SavedView: savedViewHandlers.NewSavedViewHandlers(
+			list_saved_views_use_case.NewListSavedViewsUseCase(adapters.SavedViews),
+			create_saved_view_use_case.NewCreateSavedViewUseCase(adapters.SavedViews, savedViewLists),
+			getSavedView,
+			update_saved_view_use_case.NewUpdateSavedViewUseCase(adapters.SavedViews, savedViewLists),
+			delete_saved_view_use_case.NewDeleteSavedViewUseCase(adapters.SavedViews),
+		),
+		OfflineSync: offlineSyncHandlers.NewOfflineSyncHandlers(
+			list_changes_use_case.NewListChangesUseCase(adapters.SyncChanges),
+			push_mutations_use_case.NewPushMutationsUseCase(adapters.Grades, adapters.SyncMutations),
+		),
+		Printout: printoutHandlers.NewPrintoutHandlers(
+			render_printout_use_case.NewRenderPrintoutUseCase(adapters.Printouts, adapters.PrintoutSource, adapters.PrintoutRenderer,
+				render_printout_use_case.DefaultArtifactTTL),
+			request_printout_use_case.NewRequestPrintoutUseCase(adapters.Printouts),
+			get_printout_use_case.NewGetPrintoutUseCase(adapters.Printouts),
+		),
+		Operation: operationHandlers.NewOperationHandlers(
+			get_operation_use_case.NewGetOperationUseCase(adapters.OperationPermissions,
+				operationAdapters.NewReportOperationSource(adapters.Reports),
+				operationAdapters.NewImportOperationSource(adapters.Imports),
+				operationAdapters.NewPrintoutOperationSource(adapters.Printouts),
+				operationAdapters.NewBackupOperationSource(adapters.TenantBackups),
+			),
+		),
+
+		Dashboard: dashboardHandlers.NewDashboardHandlers(
+			get_class_summary_use_case.NewGetClassSummaryUseCase(adapters.ClassSummaries),
+			get_metric_series_use_case.NewGetMetricSeriesUseCase(adapters.TenantMetrics),
+		),
+		Enrollment: enrollmentHandlers.NewEnrollmentHandlers(
+			set_class_capacity_use_case.NewSetClassCapacityUseCase(adapters.SeatInventory),
+			get_class_capacity_use_case.NewGetClassCapacityUseCase(adapters.SeatInventory),
+			set_class_schedule_use_case.NewSetClassScheduleUseCase(adapters.Schedules),
+			get_class_schedule_use_case.NewGetClassScheduleUseCase(adapters.Schedules),
+			reserve_seat_use_case.NewReserveSeatUseCase(adapters.SeatInventory, adapters.Schedules, enrollmentEntities.DefaultHoldTTL),
+			enroll_student_use_case.NewEnrollStudentUseCase(
+				sagas,
+				confirm_enrollment_use_case.NewConfirmEnrollmentUseCase(adapters.SeatInventory, adapters.Schedules),
+				adapters.Enrollments,
+				adapters.EnrollmentNotifications,
+				adapters.StudentInformationSystem,
+			),
+			releaseSeatHold,
+			getSchoolCalendar,
+			adapters.Authorization,
+		),
+		Presence: presenceHandlers.NewPresenceHandlers(
+			record_heartbeat_use_case.NewRecordHeartbeatUseCase(presence),
+			list_online_users_use_case.NewListOnlineUsersUseCase(presence),
+			acquire_edit_lock_use_case.NewAcquireEditLockUseCase(editLocks),
+			get_edit_lock_use_case.NewGetEditLockUseCase(editLocks),
+		),
+		Status: statusHandlers.NewStatusHandlers(
+			get_status_use_case.NewGetStatusUseCase(adapters.StatusProbe, adapters.StatusNotes),
+			list_status_notes_use_case.NewListStatusNotesUseCase(adapters.StatusNotes),
+			post_status_note_use_case.NewPostStatusNoteUseCase(adapters.StatusNotes),
+			update_status_note_use_case.NewUpdateStatusNoteUseCase(adapters.StatusNotes),
+			resolve_status_note_use_case.NewResolveStatusNoteUseCase(adapters.StatusNotes),
+			ratelimit.NewTenantRateLimiter(config.StatusRateLimitPerMinute, 10),
+			config.StatusAdminToken,
+		),
+		District: districtHandlers.NewDistrictHandlers(
+			create_district_use_case.NewCreateDistrictUseCase(districts),
+			list_districts_use_case.NewListDistrictsUseCase(districts),
+			update_district_use_case.NewUpdateDistrictUseCase(districts),
+			create_district_key_use_case.NewCreateDistrictKeyUseCase(districts),
+			revoke_district_key_use_case.NewRevokeDistrictKeyUseCase(districts),
+			authenticate_district_key_use_case.NewAuthenticateDistrictKeyUseCase(districts),
+			get_district_metrics_use_case.NewGetDistrictMetricsUseCase(districts, adapters.DistrictMetrics),
+			ratelimit.NewTenantRateLimiter(config.DistrictRateLimitPerMinute, 10),
+			config.DistrictAdminToken,
+		),
+		Analytics: analyticsHandlers.NewAnalyticsHandlers(
+			get_api_usage_use_case.NewGetAPIUsageUseCase(adapters.APIUsage, config.AbuseThresholds),
+		),
+		Activity: activityHandlers.NewActivityHandlers(list_activity_use_case.NewListActivityUseCase(adapters.Activity)),
+
+		ActivityRecorder: activityHandlers.NewActivityRecorder(adapters.EndpointAccess),
+		UsageCollector:   analyticsHandlers.NewUsageCollector(),
+
+		GetTimeZone:                getTimeZone,
+		GetSchoolCalendar:          getSchoolCalendar,
+		AuthenticateServiceAccount: authenticate_service_account_use_case.NewAuthenticateServiceAccountUseCase(adapters.ServiceAccounts),
+		RunImport:                  run_import_use_case.NewRunImportUseCase(adapters.Imports, importers),
+		DetectDuplicateUsers:       detect_duplicate_users_use_case.NewDetectDuplicateUsersUseCase(adapters.TenantMembers, adapters.DuplicateCandidates),
+		CheckStorageQuota:          check_storage_quota_use_case.NewCheckStorageQuotaUseCase(adapters.StorageUsage, adapters.StorageQuotas),
+		CheckModuleEnabled:         check_module_enabled_use_case.NewCheckModuleEnabledUseCase(disabledModules),
+		SetModuleToggle:            set_module_toggle_use_case.NewSetModuleToggleUseCase(adapters.ModuleToggles),
+		DisabledModules:            disabledModules,
+
+		adapters: adapters,
+		config:   config,
+		sagas:    sagas,
+		projections: projection.NewRunner(adapters.OutboxEvents, adapters.Checkpoints, append(slices.Clone(adapters.Projections),
+			// Notifications of the outbox are held for the digest of their recipient
+			notificationAdapters.NewNotificationDigestProjection(hold_notifications_use_case.NewHoldNotificationsUseCase(
+				adapters.DigestPreferences, adapters.RecipientZones, adapters.NotificationBatcher)))...),
+		// Changes in progress are registered here with the deploy that expands the schema and removed
+		// with the one that contracts it
+		schemaChanges:    schemachange.NewCoordinator(adapters.SchemaChanges),
+		ensurePartitions: ensure_partitions_use_case.NewEnsurePartitionsUseCase(adapters.Partitions),
+		onboardTenant: onboard_tenant_use_case.NewOnboardTenantUseCase(sagas, adapters.Tenants, adapters.TenantInvitations,
+			adapters.TenantPolicies, config.Tenants),
+		releaseSeatHold: releaseSeatHold,
+	}
+	c.Auth.OnSignUp(c.ActivityRecorder.RecordSignUp)
+
+	// Sandboxes get a new tenant, so the region of unpinned tenants creates them
+	if config.Residency == nil || config.Residency.ServesDefaultRegion() {
+		c.DevSandbox = devSandboxHandlers.NewDeveloperSandboxHandlers(
+			create_developer_sandbox_use_case.NewCreateDeveloperSandboxUseCase(adapters.DeveloperSandboxes, adapters.SyntheticData,
+				adapters.DeveloperSandboxPolicies, adapters.ServiceAccounts),
+			get_developer_sandbox_use_case.NewGetDeveloperSandboxUseCase(adapters.DeveloperSandboxes),
+			reset_developer_sandbox_use_case.NewResetDeveloperSandboxUseCase(adapters.DeveloperSandboxes, adapters.SyntheticData,
+				adapters.DeveloperSandboxPolicies),
+			ratelimit.NewTenantRateLimiter(config.DeveloperSandboxRateLimitPerMinute, 2),
+		)
+	}
+
+	// Plan features and limits gate endpoints once billing is configured, dunning notices go through
+	// the outbox
+	if adapters.BillingProvider != nil {
+		subscriptions := billingAdapters.NewCachedSubscriptionReader(adapters.Subscriptions, time.Minute)
+		c.checkFeature = check_feature_use_case.NewCheckFeatureUseCase(subscriptions)
+		c.checkPlanLimit = check_plan_limit_use_case.NewCheckPlanLimitUseCase(subscriptions, adapters.UsageMeter)
+		c.Billing = billingHandlers.NewBillingHandlers(
+			get_subscription_use_case.NewGetSubscriptionUseCase(adapters.Subscriptions),
+			list_invoices_use_case.NewListInvoicesUseCase(adapters.Invoices),
+			adapters.Webhooks,
+		)
+	}
+
+	if adapters.SimilarityChecker != nil {
+		c.Similarity = similarityHandlers.NewSimilarityHandlers(
+			request_similarity_check_use_case.NewRequestSimilarityCheckUseCase(adapters.SimilarityChecks),
+			list_similarity_checks_use_case.NewListSimilarityChecksUseCase(adapters.SimilarityChecks),
+			get_similarity_check_use_case.NewGetSimilarityCheckUseCase(adapters.SimilarityChecks),
+			adapters.Webhooks,
+		)
+	}
+	return c
+}
+
+// Modules lists the handlers of the container in the order their routes are registered, named
+// after the directory of their module in infra
+func (c *Container) Modules() []NamedModule {
+	return []NamedModule{
+		{"auth", c.Auth},
+		{"user", c.User},
+		{"grading", c.Grading},
+		{"report", c.Report},
+		{"messaging", c.Messaging},
+		{"officehours", c.OfficeHours},
+		{"behavior", c.Behavior},
+		{"library", c.Library},
+		{"surveys", c.Surveys},
+		{"localization", c.Localization},
+		{"timezone", c.TimeZone},
+		{"calendar", c.Calendar},
+		{"archive", c.Archive},
+		{"backup", c.Backup},
+		{"audit", c.Audit},
+		{"serviceaccount", c.ServiceAccount},
+		{"serviceaccount", c.Token},
+		{"serviceaccount", c.TokenPolicy},
+		{"roleassignment", c.GroupRoleAssignment},
+		{"roleassignment", c.MemberDeactivation},
+		{"sandbox", c.Sandbox},
+		{"legacy", c.Legacy},
+		{"dataimport", c.DataImport},
+		{"usermerge", c.UserMerge},
+		{"linkedidentity", c.LinkedIdentity},
+		{"tenant", c.Tenant},
+		{"directorysync", c.DirectorySync},
+		{"deliverability", c.Deliverability},
+		{"emailtemplate", c.EmailTemplate},
+		{"notification", c.Notification},
+		{"storagequota", c.StorageQuota},
+		{"attendance", c.Attendance},
+		{"transcript", c.Transcript},
+		{"standards", c.Standards},
+		{"classtemplate", c.ClassTemplate},
+		{"sections", c.Sections},
+		{"moduletoggle", c.ModuleToggle},
+		{"widget", c.Widget},
+		{"accessibility", c.Accessibility},
+		{"jobfailure", c.JobFailure},
+		{"customfield", c.CustomField},
+		{"savedview", c.SavedView},
+		{"offlinesync", c.OfflineSync},
+		{"printout", c.Printout},
+		{"operation", c.Operation},
+		{"dashboard", c.Dashboard},
+		{"enrollment", c.Enrollment},
+		{"presence", c.Presence},
+		{"status", c.Status},
+		{"district", c.District},
+		{"devsandbox", configured(c.DevSandbox)},
+		{"analytics", c.Analytics},
+		{"activity", c.Activity},
+		{"billing", configured(c.Billing)},
+		{"similarity", configured(c.Similarity)},
+	}
+}
+
+// configured returns the handlers of a module that may not be configured, nil when they are not
+func configured[H any, M interface {
+	*H
+	Module
+}](handlers M) Module {
+	if handlers == nil {
+		return nil
+	}
+	return handlers
+}
+
+// RegisterRoutes registers the routes of every module
+func (c *Container) RegisterRoutes(api huma.API) {
+	c.RegisterRoutesOf(api, ModuleSelection{}, nil)
+}
+
+// RegisterRoutesOf registers the routes of the configured modules selection serves, recording their
+// operations in operations when it is not nil
+func (c *Container) RegisterRoutesOf(api huma.API, selection ModuleSelection, operations *OperationModules) {
+	for _, module := range c.Modules() {
+		if module.Module != nil && selection.Serves(module.Name) {
+			operations.Register(module.Name, func() { module.RegisterRoutes(api) })
+		}
+	}
+}
+
+// UseMiddlewares adds the middlewares of the modules, after authorization: the identity of the
+// caller, resolved once per request and cached briefly across requests, the time zone responses
+// show dates in, the plan gates once billing is configured and the storage quotas uploads are
+// refused past before their file is read
+func (c *Container) UseMiddlewares(api huma.API) {
+	identities := userAdapters.NewCachedIdentityRepository(userAdapters.NewCasbinIdentityRepository(c.adapters.Users, c.adapters.Authorization), 30*time.Second)
+	c.adapters.Authorization.OnRoleChange(identities.Invalidate)
+	api.UseMiddleware(userHandlers.NewIdentityMiddleware(resolve_identity_use_case.NewResolveIdentityUseCase(identities)))
+
+	api.UseMiddleware(timezoneHandlers.NewTimeZoneMiddleware(c.GetTimeZone))
+
+	if c.checkFeature != nil {
+		for operationID := range billingHandlers.EndpointFeatures {
+			utils.AddOperationErrors(operationID, billingErrors.FeatureNotInPlanError)
+		}
+		for operationID := range billingHandlers.EndpointLimits {
+			utils.AddOperationErrors(operationID, billingErrors.PlanLimitExceededError)
+		}
+		api.UseMiddleware(billingHandlers.NewFeatureGateMiddleware(c.checkFeature))
+		api.UseMiddleware(billingHandlers.NewPlanLimitMiddleware(c.checkPlanLimit))
+	}
+
+	for operationID := range storageQuotaHandlers.UploadOperations {
+		utils.AddOperationErrors(operationID, storageQuotaErrors.StorageQuotaExceededError)
+	}
+	api.UseMiddleware(storageQuotaHandlers.NewStorageQuotaMiddleware(c.CheckStorageQuota))
+}
+
+// RegisterWebSocketsOf registers the WebSocket of presence on router when selection serves it,
+// sockets authenticate outside the middlewares of Huma
+func (c *Container) RegisterWebSocketsOf(router gin.IRoutes, selection ModuleSelection) {
+	if selection.Serves("presence") {
+		c.Presence.RegisterWebSocket(router, c.adapters.Authorization)
+	}
+}
+
+// GRPCServicesOf returns the gRPC services of the modules selection serves, responses are redacted
+// like the ones of HTTP
+func (c *Container) GRPCServicesOf(selection ModuleSelection, redactor *authorization.Redactor) []grpcserver.Service {
+	var services []grpcserver.Service
+	if selection.Serves("auth") {
+		services = append(services, authHandlers.NewAuthGRPCHandlers(c.Auth, redactor))
+	}
+	if selection.Serves("user") {
+		services = append(services,
+			userHandlers.NewUserGRPCHandlers(list_users_use_case.NewListUsersUseCase(c.adapters.Users), redactor))
+	}
+	return services
+}
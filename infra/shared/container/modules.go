@@ -0,0 +1,103 @@
+package container
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ModuleNames returns the names of every module instances can serve, sorted
+func ModuleNames() []string {
+	var names []string
+	for _, module := range (&Container{}).Modules() {
+		if !slices.Contains(names, module.Name) {
+			names = append(names, module.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ModuleSelection is the modules an instance serves. The zero value serves every module, the
+// monolith, while services split off it serve a few.
+type ModuleSelection struct {
+	names []string
+}
+
+// ParseModuleSelection selects the modules named, every module when names is empty. Unknown names
+// are refused so a typo does not leave a service without its routes.
+func ParseModuleSelection(names []string) (ModuleSelection, error) {
+	known := ModuleNames()
+	var selection ModuleSelection
+	var unknown []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "":
+		case !slices.Contains(known, name):
+			unknown = append(unknown, name)
+		case !slices.Contains(selection.names, name):
+			selection.names = append(selection.names, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return ModuleSelection{}, fmt.Errorf("unknown modules %v, modules are %v", unknown, known)
+	}
+	return selection, nil
+}
+
+// All reports whether every module is served
+func (s ModuleSelection) All() bool {
+	return len(s.names) == 0
+}
+
+// Serves reports whether the module named is served
+func (s ModuleSelection) Serves(name string) bool {
+	return s.All() || slices.Contains(s.names, name)
+}
+
+// Names returns the modules selected, nil when every module is
+func (s ModuleSelection) Names() []string {
+	return slices.Clone(s.names)
+}
+
+// OperationModules maps the operations of the API to the module that registered them. Its
+// OnAddOperation hook records the operations added while Register runs, it is read-only once the
+// routes are registered.
+type OperationModules struct {
+	byOperation map[string]string
+	registering string
+}
+
+func NewOperationModules() *OperationModules {
+	return &OperationModules{byOperation: make(map[string]string)}
+}
+
+// Register runs register, which registers the routes of module. A nil OperationModules records
+// nothing.
+func (o *OperationModules) Register(module string, register func()) {
+	if o == nil {
+		register()
+		return
+	}
+	o.registering = module
+	defer func() { o.registering = "" }()
+	register()
+}
+
+// OnAddOperation is the hook of the spec that records the operations, added before the hooks that
+// read them
+func (o *OperationModules) OnAddOperation(oapi *huma.OpenAPI, op *huma.Operation) {
+	if o.registering != "" {
+		o.byOperation[op.OperationID] = o.registering
+	}
+}
+
+// Module returns the module of the operation, false for operations registered outside Register
+func (o *OperationModules) Module(operationID string) (string, bool) {
+	module, ok := o.byOperation[operationID]
+	return module, ok
+}
@@ -0,0 +1,323 @@
+package container
+
+import (
+	"context"
+	"time"
+
+	generate_captions_use_case "github.com/nahualventure/class-backend/core/app/accessibility/application/use-cases/generate-captions-use-case"
+	record_activity_use_case "github.com/nahualventure/class-backend/core/app/activity/application/use-cases/record-activity-use-case"
+	record_api_usage_use_case "github.com/nahualventure/class-backend/core/app/analytics/application/use-cases/record-api-usage-use-case"
+	run_archival_use_case "github.com/nahualventure/class-backend/core/app/archive/application/use-cases/run-archival-use-case"
+	list_tenant_backups_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/list-tenant-backups-use-case"
+	request_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/request-tenant-backup-use-case"
+	run_tenant_backup_use_case "github.com/nahualventure/class-backend/core/app/backup/application/use-cases/run-tenant-backup-use-case"
+	receive_billing_event_use_case "github.com/nahualventure/class-backend/core/app/billing/application/use-cases/receive-billing-event-use-case"
+	receive_delivery_events_use_case "github.com/nahualventure/class-backend/core/app/deliverability/application/use-cases/receive-delivery-events-use-case"
+	deliverabilityEntities "github.com/nahualventure/class-backend/core/app/deliverability/domain/entities"
+	sync_developer_sandbox_tenants_use_case "github.com/nahualventure/class-backend/core/app/devsandbox/application/use-cases/sync-developer-sandbox-tenants-use-case"
+	find_job_failure_use_case "github.com/nahualventure/class-backend/core/app/jobfailure/application/use-cases/find-job-failure-use-case"
+	record_job_attempt_use_case "github.com/nahualventure/class-backend/core/app/jobfailure/application/use-cases/record-job-attempt-use-case"
+	release_due_digests_use_case "github.com/nahualventure/class-backend/core/app/notification/application/use-cases/release-due-digests-use-case"
+	send_appointment_reminders_use_case "github.com/nahualventure/class-backend/core/app/officehours/application/use-cases/send-appointment-reminders-use-case"
+	officeHoursEntities "github.com/nahualventure/class-backend/core/app/officehours/domain/entities"
+	ensure_partitions_use_case "github.com/nahualventure/class-backend/core/app/partitioning/application/use-cases/ensure-partitions-use-case"
+	generate_file_previews_use_case "github.com/nahualventure/class-backend/core/app/preview/application/use-cases/generate-file-previews-use-case"
+	render_printout_use_case "github.com/nahualventure/class-backend/core/app/printout/application/use-cases/render-printout-use-case"
+	generate_report_use_case "github.com/nahualventure/class-backend/core/app/report/application/use-cases/generate-report-use-case"
+	run_group_role_assignment_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/run-group-role-assignment-use-case"
+	run_member_deactivation_use_case "github.com/nahualventure/class-backend/core/app/roleassignment/application/use-cases/run-member-deactivation-use-case"
+	run_tenant_sandbox_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/run-tenant-sandbox-use-case"
+	sync_sandbox_tenants_use_case "github.com/nahualventure/class-backend/core/app/sandbox/application/use-cases/sync-sandbox-tenants-use-case"
+	scan_quarantined_files_use_case "github.com/nahualventure/class-backend/core/app/scanning/application/use-cases/scan-quarantined-files-use-case"
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+	process_similarity_checks_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/process-similarity-checks-use-case"
+	receive_similarity_result_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/receive-similarity-result-use-case"
+	reconcile_storage_usage_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/reconcile-storage-usage-use-case"
+	sync_tenant_roles_use_case "github.com/nahualventure/class-backend/core/app/tenant/application/use-cases/sync-tenant-roles-use-case"
+	generate_report_card_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/generate-report-card-use-case"
+	export_dataset_use_case "github.com/nahualventure/class-backend/core/app/warehouse/application/use-cases/export-dataset-use-case"
+	accessibilityWorkers "github.com/nahualventure/class-backend/infra/accessibility/workers"
+	activityWorkers "github.com/nahualventure/class-backend/infra/activity/workers"
+	analyticsWorkers "github.com/nahualventure/class-backend/infra/analytics/workers"
+	archiveWorkers "github.com/nahualventure/class-backend/infra/archive/workers"
+	backupWorkers "github.com/nahualventure/class-backend/infra/backup/workers"
+	billingHandlers "github.com/nahualventure/class-backend/infra/billing/handlers"
+	dataImportWorkers "github.com/nahualventure/class-backend/infra/dataimport/workers"
+	deliverabilityHandlers "github.com/nahualventure/class-backend/infra/deliverability/handlers"
+	devSandboxWorkers "github.com/nahualventure/class-backend/infra/devsandbox/workers"
+	enrollmentWorkers "github.com/nahualventure/class-backend/infra/enrollment/workers"
+	jobFailureWorkers "github.com/nahualventure/class-backend/infra/jobfailure/workers"
+	notificationWorkers "github.com/nahualventure/class-backend/infra/notification/workers"
+	officeHoursWorkers "github.com/nahualventure/class-backend/infra/officehours/workers"
+	partitioningWorkers "github.com/nahualventure/class-backend/infra/partitioning/workers"
+	previewWorkers "github.com/nahualventure/class-backend/infra/preview/workers"
+	printoutWorkers "github.com/nahualventure/class-backend/infra/printout/workers"
+	reportWorkers "github.com/nahualventure/class-backend/infra/report/workers"
+	roleAssignmentWorkers "github.com/nahualventure/class-backend/infra/roleassignment/workers"
+	sandboxWorkers "github.com/nahualventure/class-backend/infra/sandbox/workers"
+	scanningWorkers "github.com/nahualventure/class-backend/infra/scanning/workers"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+	"github.com/nahualventure/class-backend/infra/shared/ops"
+	similarityHandlers "github.com/nahualventure/class-backend/infra/similarity/handlers"
+	similarityWorkers "github.com/nahualventure/class-backend/infra/similarity/workers"
+	storageQuotaWorkers "github.com/nahualventure/class-backend/infra/storagequota/workers"
+	tenantWorkers "github.com/nahualventure/class-backend/infra/tenant/workers"
+	transcriptWorkers "github.com/nahualventure/class-backend/infra/transcript/workers"
+	userMergeWorkers "github.com/nahualventure/class-backend/infra/usermerge/workers"
+	warehouseWorkers "github.com/nahualventure/class-backend/infra/warehouse/workers"
+)
+
+// StartWorkers starts the workers every instance runs with lameDuck, which stops them once requests
+// are drained, and adds the singleton jobs to scheduler. Webhook sources are registered for the
+// modules selection serves, so their deliveries are only taken by instances that serve them.
+func (c *Container) StartWorkers(selection ModuleSelection, lameDuck *ops.LameDuck, scheduler *ops.Scheduler) {
+	adapters, config := c.adapters, c.config
+
+	if adapters.ReadReceipts != nil {
+		lameDuck.Go(adapters.ReadReceipts.Run)
+	}
+
+	// Monthly partitions of high-volume tables are created ahead of time
+	scheduler.Add("partition-maintenance", func(ctx context.Context) {
+		partitioningWorkers.RunPartitionMaintenance(ctx, c.ensurePartitions,
+			ensure_partitions_use_case.DefaultMonthsAhead, 24*time.Hour)
+	})
+	scheduler.Add("projections", func(ctx context.Context) {
+		c.projections.Run(ctx, 5*time.Second)
+	})
+	scheduler.Add("schema-change-backfills", func(ctx context.Context) {
+		c.schemaChanges.Run(ctx, time.Minute)
+	})
+
+	// Every instance enforces the roles of the onboarded tenants
+	lameDuck.Go(func(ctx context.Context) {
+		tenantWorkers.RunTenantRoleSync(ctx, sync_tenant_roles_use_case.NewSyncTenantRolesUseCase(
+			adapters.Tenants,
+			adapters.TenantPolicies,
+		), time.Minute)
+	})
+	// Every instance resumes sagas, they are leased so only the ones of a stopped instance are taken
+	// over
+	lameDuck.Go(func(ctx context.Context) {
+		c.sagas.Run(ctx, time.Minute)
+	})
+
+	// Reports, printouts too large to download right away and report cards are generated
+	// asynchronously from their tables
+	lameDuck.Go(func(ctx context.Context) {
+		reportWorkers.RunReportWorker(database.WithPoolClass(ctx, database.PoolClassReports), generate_report_use_case.NewGenerateReportUseCase(
+			adapters.Reports,
+			adapters.ReportData,
+			generate_report_use_case.DefaultArtifactTTL,
+		), 10*time.Second)
+	})
+	lameDuck.Go(func(ctx context.Context) {
+		printoutWorkers.RunPrintoutWorker(database.WithPoolClass(ctx, database.PoolClassReports), render_printout_use_case.NewRenderPrintoutUseCase(
+			adapters.Printouts,
+			adapters.PrintoutSource,
+			adapters.PrintoutRenderer,
+			render_printout_use_case.DefaultArtifactTTL,
+		), 10*time.Second)
+	})
+	lameDuck.Go(func(ctx context.Context) {
+		transcriptWorkers.RunReportCardWorker(database.WithPoolClass(ctx, database.PoolClassReports), generate_report_card_use_case.NewGenerateReportCardUseCase(
+			adapters.ReportCards,
+			adapters.Terms,
+			adapters.ReportCardTemplates,
+			adapters.TranscriptSource,
+			adapters.ReportCardRenderer,
+			generate_report_card_use_case.DefaultArtifactTTL,
+		), 10*time.Second)
+	})
+
+	// Imports are run one at a time from the data_imports table
+	lameDuck.Go(func(ctx context.Context) {
+		dataImportWorkers.RunDataImportWorker(ctx, c.RunImport, clock.System, 10*time.Second)
+	})
+	// Admins review the duplicate pairs the detection finds and merge or dismiss them
+	scheduler.Add("duplicate-user-detection", func(ctx context.Context) {
+		userMergeWorkers.RunDuplicateDetection(ctx, c.DetectDuplicateUsers, config.Tenants, 24*time.Hour)
+	})
+	// Expired seat holds stop counting against capacity right away
+	lameDuck.Go(func(ctx context.Context) {
+		enrollmentWorkers.RunSeatHoldCleanup(ctx, c.releaseSeatHold, clock.System, time.Minute)
+	})
+
+	// Work of background jobs failing entities.MaxAttempts runs in a row is escalated to the admins
+	// and skipped until it is requeued
+	jobAttempts := jobFailureWorkers.NewJobAttempts(
+		find_job_failure_use_case.NewFindJobFailureUseCase(adapters.JobFailures),
+		record_job_attempt_use_case.NewRecordJobAttemptUseCase(adapters.JobFailures, adapters.IncidentNotifier),
+	)
+	if adapters.WarehouseSink != nil {
+		scheduler.Add(warehouseWorkers.ExportJob, func(ctx context.Context) {
+			warehouseWorkers.RunWarehouseExports(database.WithPoolClass(ctx, database.PoolClassReports), export_dataset_use_case.NewExportDatasetUseCase(
+				adapters.WarehouseSource,
+				adapters.Watermarks,
+				adapters.WarehouseSink,
+				[]byte(config.WarehousePseudonymKey),
+				export_dataset_use_case.DefaultRowsPerFile,
+			), jobAttempts, config.Tenants, time.Hour)
+		})
+	}
+
+	// Every instance enforces the roles of every developer sandbox
+	lameDuck.Go(func(ctx context.Context) {
+		devSandboxWorkers.RunDeveloperSandboxTenantSync(ctx, sync_developer_sandbox_tenants_use_case.NewSyncDeveloperSandboxTenantsUseCase(
+			adapters.DeveloperSandboxes,
+			adapters.DeveloperSandboxPolicies,
+		), time.Minute)
+	})
+
+	// Office hours reminders are queued to the outbox ahead of each appointment
+	lameDuck.Go(func(ctx context.Context) {
+		officeHoursWorkers.RunAppointmentReminders(ctx,
+			send_appointment_reminders_use_case.NewSendAppointmentRemindersUseCase(adapters.Appointments, officeHoursEntities.DefaultReminderLead), time.Minute)
+	})
+	// The digest projection holds the notifications of the outbox and the job sends them once the
+	// digest of their recipient is due
+	scheduler.Add("notification-digests", func(ctx context.Context) {
+		notificationWorkers.RunDigestRelease(ctx,
+			release_due_digests_use_case.NewReleaseDueDigestsUseCase(adapters.NotificationBatcher), 30*time.Second)
+	})
+
+	// Uploads stay quarantined until the scan finds them clean, previews and captions are then
+	// generated for them. Captions stay pending until a provider is configured.
+	scheduler.Add("file-scans", func(ctx context.Context) {
+		scanningWorkers.RunFileScans(ctx, scan_quarantined_files_use_case.NewScanQuarantinedFilesUseCase(
+			adapters.Quarantine,
+			adapters.VirusScanner,
+			adapters.Files,
+		), 10*time.Second)
+	})
+	scheduler.Add("file-previews", func(ctx context.Context) {
+		previewWorkers.RunFilePreviews(ctx, generate_file_previews_use_case.NewGenerateFilePreviewsUseCase(
+			adapters.Previews,
+			adapters.PreviewRenderer,
+			adapters.Files,
+		), 30*time.Second)
+	})
+	if adapters.CaptionProvider != nil {
+		scheduler.Add("file-captions", func(ctx context.Context) {
+			accessibilityWorkers.RunCaptions(ctx, generate_captions_use_case.NewGenerateCaptionsUseCase(
+				adapters.Captions,
+				adapters.CaptionProvider,
+				adapters.Files,
+			), time.Minute)
+		})
+	}
+
+	// The recorded storage usage is rewritten from the records of the files every day and compared
+	// with the file storage to catch drift
+	scheduler.Add("storage-reconciliation", func(ctx context.Context) {
+		storageQuotaWorkers.RunStorageReconciliation(ctx,
+			reconcile_storage_usage_use_case.NewReconcileStorageUsageUseCase(adapters.StorageUsage, adapters.StorageInventory), 24*time.Hour)
+	})
+
+	// Prior-year records are moved out of the hot tables once the school year start is configured
+	if month := config.ArchiveSchoolYearStartMonth; month >= 1 && month <= 12 {
+		scheduler.Add("archival", func(ctx context.Context) {
+			archiveWorkers.RunArchival(ctx, run_archival_use_case.NewRunArchivalUseCase(
+				adapters.Archive,
+				run_archival_use_case.DefaultBatchSize,
+			), config.Tenants, month, 24*time.Hour)
+		})
+	}
+
+	// Backups go to their own storage and every backup is restored into a scratch schema before it
+	// is marked verified
+	lameDuck.Go(func(ctx context.Context) {
+		backupWorkers.RunBackupWorker(ctx, run_tenant_backup_use_case.NewRunTenantBackupUseCase(
+			adapters.TenantBackups,
+			adapters.TenantDataExporter,
+			adapters.TenantDataRestorer,
+			adapters.BackupStorage,
+		), time.Minute)
+	})
+	if config.BackupInterval > 0 {
+		scheduler.Add("tenant-backups", func(ctx context.Context) {
+			backupWorkers.RunBackupSchedule(ctx,
+				list_tenant_backups_use_case.NewListTenantBackupsUseCase(adapters.TenantBackups),
+				request_tenant_backup_use_case.NewRequestTenantBackupUseCase(adapters.TenantBackups),
+				config.Tenants, config.BackupInterval)
+		})
+	}
+
+	// Roles assigned to whole groups and revoked from confirmed member deactivations are written by
+	// the workers, the requests only queue them
+	lameDuck.Go(func(ctx context.Context) {
+		roleAssignmentWorkers.RunGroupRoleAssignmentWorker(ctx, run_group_role_assignment_use_case.NewRunGroupRoleAssignmentUseCase(
+			adapters.GroupRoleAssignments,
+			adapters.Groups,
+			adapters.BulkRoles,
+		), 5*time.Second)
+	})
+	lameDuck.Go(func(ctx context.Context) {
+		roleAssignmentWorkers.RunMemberDeactivationWorker(ctx, run_member_deactivation_use_case.NewRunMemberDeactivationUseCase(
+			adapters.MemberDeactivations,
+			adapters.TenantRoles,
+		), 5*time.Second)
+	})
+
+	// Sandboxes are provisioned and removed by the worker, every instance then enforces the roles of
+	// the sandboxes that are ready
+	lameDuck.Go(func(ctx context.Context) {
+		sandboxWorkers.RunSandboxWorker(ctx, run_tenant_sandbox_use_case.NewRunTenantSandboxUseCase(
+			adapters.TenantSandboxes,
+			adapters.SandboxData,
+			adapters.SandboxPolicies,
+		), 30*time.Second)
+	})
+	lameDuck.Go(func(ctx context.Context) {
+		sandboxWorkers.RunSandboxTenantSync(ctx, sync_sandbox_tenants_use_case.NewSyncSandboxTenantsUseCase(
+			adapters.TenantSandboxes,
+			adapters.SandboxPolicies,
+		), time.Minute)
+	})
+
+	// Every instance flushes the API usage it counted every minute and the activity it recorded every
+	// 5 seconds
+	recordAPIUsage := record_api_usage_use_case.NewRecordAPIUsageUseCase(adapters.APIUsage)
+	lameDuck.Go(func(ctx context.Context) {
+		analyticsWorkers.RunUsageFlush(ctx, c.UsageCollector, recordAPIUsage, time.Minute)
+	})
+	scheduler.Add("api-usage-retention", func(ctx context.Context) {
+		analyticsWorkers.RunUsageRetention(ctx, recordAPIUsage, clock.System, 24*time.Hour)
+	})
+	recordActivity := record_activity_use_case.NewRecordActivityUseCase(adapters.Activity)
+	lameDuck.Go(func(ctx context.Context) {
+		activityWorkers.RunActivityFlush(ctx, c.ActivityRecorder, recordActivity, 5*time.Second)
+	})
+	scheduler.Add("activity-retention", func(ctx context.Context) {
+		activityWorkers.RunActivityRetention(ctx, recordActivity, clock.System, 24*time.Hour)
+	})
+
+	if c.Billing != nil && selection.Serves("billing") {
+		adapters.Webhooks.Register(billingHandlers.NewStripeWebhookSource(adapters.BillingProvider,
+			receive_billing_event_use_case.NewReceiveBillingEventUseCase(adapters.Subscriptions, adapters.Invoices,
+				adapters.BillingProvider, adapters.BillingContacts)))
+	}
+	// Email providers report bounces and complaints to the deliverability webhooks
+	if selection.Serves("deliverability") {
+		receiveDeliveryEvents := receive_delivery_events_use_case.NewReceiveDeliveryEventsUseCase(adapters.DeliveryWebhooks,
+			adapters.DeliveryEvents)
+		for _, provider := range []deliverabilityEntities.EmailProvider{deliverabilityEntities.EmailProviderSES, deliverabilityEntities.EmailProviderSendGrid} {
+			adapters.Webhooks.Register(deliverabilityHandlers.NewDeliveryWebhookSource(provider, adapters.DeliveryWebhooks,
+				receiveDeliveryEvents))
+		}
+	}
+	if c.Similarity != nil && selection.Serves("similarity") {
+		lameDuck.Go(func(ctx context.Context) {
+			similarityWorkers.RunSimilarityWorker(ctx,
+				process_similarity_checks_use_case.NewProcessSimilarityChecksUseCase(adapters.SimilarityChecks, adapters.SimilarityChecker), 15*time.Second)
+		})
+		adapters.Webhooks.Register(similarityHandlers.NewSimilarityWebhookSource(adapters.SimilarityChecker,
+			receive_similarity_result_use_case.NewReceiveSimilarityResultUseCase(adapters.SimilarityChecks, adapters.SimilarityChecker)))
+	}
+
+	// Every instance processes the webhooks of the sources registered above, deliveries are leased so
+	// only the ones of a stopped instance are taken over
+	lameDuck.Go(func(ctx context.Context) {
+		adapters.Webhooks.Run(ctx, 5*time.Second)
+	})
+}
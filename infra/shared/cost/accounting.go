@@ -0,0 +1,185 @@
+package cost
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// Header asks for the cost of a request with the debug token as its value, the response carries the
+// cost in it. Callers without the token never get it, the cost tells how the data is laid out.
+const Header = "X-Debug-Cost"
+
+type operationCost struct {
+	requests int64
+	cost     Cost
+	external map[string]int64
+}
+
+// Accounting meters every request and keeps the totals per operation, to find the endpoints whose
+// queries, cache misses or calls to other services cost the most
+type Accounting struct {
+	token string
+
+	mu         sync.Mutex
+	operations map[string]*operationCost
+}
+
+// NewAccounting returns the cost of requests in Header to the callers bearing token, empty for none
+func NewAccounting(token string) *Accounting {
+	return &Accounting{
+		token:      token,
+		operations: make(map[string]*operationCost),
+	}
+}
+
+// Middleware is a Gin middleware that meters the request. It goes before the routes so every query
+// of the request is counted, the Huma middleware names the operation.
+func (a *Accounting) Middleware(c *gin.Context) {
+	ctx, meter := WithMeter(c.Request.Context())
+	c.Request = c.Request.WithContext(ctx)
+
+	if a.trusted(c.GetHeader(Header)) {
+		writer := &costWriter{ResponseWriter: c.Writer, meter: meter}
+		c.Writer = writer
+		c.Next()
+		// Responses without a body are written by Gin after the middlewares, past the writer
+		writer.setHeader()
+	} else {
+		c.Next()
+	}
+
+	a.record(meter)
+}
+
+func (a *Accounting) trusted(token string) bool {
+	return a.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) == 1
+}
+
+// OperationMiddleware is a Huma middleware that names the operation of the metered request
+func (a *Accounting) OperationMiddleware(ctx huma.Context, next func(huma.Context)) {
+	FromContext(ctx.Context()).SetOperation(ctx.Operation().OperationID)
+	next(ctx)
+}
+
+// record adds the cost of a request to its operation, requests outside the API such as probes are
+// left out
+func (a *Accounting) record(meter *Meter) {
+	operation, cost, external := meter.snapshot()
+	if operation == "" {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	totals, ok := a.operations[operation]
+	if !ok {
+		totals = &operationCost{external: make(map[string]int64)}
+		a.operations[operation] = totals
+	}
+	totals.requests++
+	totals.cost.DBTime += cost.DBTime
+	totals.cost.Queries += cost.Queries
+	totals.cost.CacheHits += cost.CacheHits
+	totals.cost.CacheMisses += cost.CacheMisses
+	totals.cost.ExternalCalls += cost.ExternalCalls
+	totals.cost.ExternalTime += cost.ExternalTime
+	for service, calls := range external {
+		totals.external[service] += calls
+	}
+}
+
+// WriteMetrics writes the totals per operation in the Prometheus text format
+func (a *Accounting) WriteMetrics(w io.Writer) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	operations := make([]string, 0, len(a.operations))
+	for operation := range a.operations {
+		operations = append(operations, operation)
+	}
+	slices.Sort(operations)
+
+	var out strings.Builder
+	metrics := []struct {
+		name  string
+		help  string
+		value func(totals *operationCost) string
+	}{
+		{"http_request_cost_requests_total", "Requests metered", func(t *operationCost) string { return fmt.Sprint(t.requests) }},
+		{"http_request_db_seconds_total", "Time spent in the database queries of the requests", func(t *operationCost) string { return fmt.Sprintf("%g", t.cost.DBTime.Seconds()) }},
+		{"http_request_db_queries_total", "Database queries of the requests", func(t *operationCost) string { return fmt.Sprint(t.cost.Queries) }},
+		{"http_request_external_seconds_total", "Time spent in the calls of the requests to other services", func(t *operationCost) string { return fmt.Sprintf("%g", t.cost.ExternalTime.Seconds()) }},
+	}
+	for _, metric := range metrics {
+		fmt.Fprintf(&out, "# HELP %s %s\n# TYPE %s counter\n", metric.name, metric.help, metric.name)
+		for _, operation := range operations {
+			fmt.Fprintf(&out, "%s{operation=%q} %s\n", metric.name, operation, metric.value(a.operations[operation]))
+		}
+	}
+
+	out.WriteString("# HELP http_request_cache_lookups_total Cache lookups of the requests\n# TYPE http_request_cache_lookups_total counter\n")
+	for _, operation := range operations {
+		totals := a.operations[operation]
+		fmt.Fprintf(&out, "http_request_cache_lookups_total{operation=%q,result=\"hit\"} %d\n", operation, totals.cost.CacheHits)
+		fmt.Fprintf(&out, "http_request_cache_lookups_total{operation=%q,result=\"miss\"} %d\n", operation, totals.cost.CacheMisses)
+	}
+
+	out.WriteString("# HELP http_request_external_calls_total Calls of the requests to other services\n# TYPE http_request_external_calls_total counter\n")
+	for _, operation := range operations {
+		external := a.operations[operation].external
+		services := make([]string, 0, len(external))
+		for service := range external {
+			services = append(services, service)
+		}
+		slices.Sort(services)
+		for _, service := range services {
+			fmt.Fprintf(&out, "http_request_external_calls_total{operation=%q,service=%q} %d\n", operation, service, external[service])
+		}
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// costWriter puts the cost in Header right before the response starts, the work of the handler is
+// done by then. Streamed responses leave out what they cost once the first byte is sent.
+type costWriter struct {
+	gin.ResponseWriter
+	meter *Meter
+	set   bool
+}
+
+func (w *costWriter) setHeader() {
+	if w.set || w.ResponseWriter.Written() {
+		return
+	}
+	w.set = true
+	w.Header().Set(Header, w.meter.Cost().String())
+}
+
+func (w *costWriter) WriteHeaderNow() {
+	w.setHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *costWriter) Write(data []byte) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *costWriter) WriteString(s string) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *costWriter) Flush() {
+	w.setHeader()
+	w.ResponseWriter.Flush()
+}
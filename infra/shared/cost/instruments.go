@@ -0,0 +1,69 @@
+package cost
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc"
+)
+
+// Trace wraps tracer to count the queries of requests in their meter. It goes innermost, the pool
+// only sees the acquire and release tracing of the outermost tracer.
+func Trace(tracer pgx.QueryTracer) pgx.QueryTracer {
+	return &queryTracer{QueryTracer: tracer}
+}
+
+type queryTracer struct {
+	pgx.QueryTracer
+}
+
+type queryStartKey struct{}
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx = t.QueryTracer.TraceQueryStart(ctx, conn, data)
+	if FromContext(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStartKey{}, time.Now())
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	if start, ok := ctx.Value(queryStartKey{}).(time.Time); ok {
+		RecordQuery(ctx, time.Since(start))
+	}
+	t.QueryTracer.TraceQueryEnd(ctx, conn, data)
+}
+
+// Transport counts the calls made through next, http.DefaultTransport when nil, as calls to
+// service by the request of their context
+func Transport(service string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{service: service, next: next}
+}
+
+type transport struct {
+	service string
+	next    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := t.next.RoundTrip(request)
+	RecordExternal(request.Context(), t.service, time.Since(start))
+	return response, err
+}
+
+// UnaryClientInterceptor counts the gRPC calls to service as calls of the request of their context
+func UnaryClientInterceptor(service string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		RecordExternal(ctx, service, time.Since(start))
+		return err
+	}
+}
@@ -0,0 +1,112 @@
+package cost
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Cost is what serving a request took besides its own CPU: the time and number of its database
+// queries, how its cache lookups went and the calls it made to other services
+type Cost struct {
+	DBTime        time.Duration
+	Queries       int64
+	CacheHits     int64
+	CacheMisses   int64
+	ExternalCalls int64
+	ExternalTime  time.Duration
+}
+
+// String is the value of the debug header, such as
+// "db_ms=12.4, queries=5, cache_hits=2, cache_misses=1, external_calls=1, external_ms=80.0"
+func (c Cost) String() string {
+	return fmt.Sprintf("db_ms=%.1f, queries=%d, cache_hits=%d, cache_misses=%d, external_calls=%d, external_ms=%.1f",
+		milliseconds(c.DBTime), c.Queries, c.CacheHits, c.CacheMisses, c.ExternalCalls, milliseconds(c.ExternalTime))
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// Meter adds up the cost of one request. The goroutines of the request may record into it at once.
+type Meter struct {
+	mu        sync.Mutex
+	operation string
+	cost      Cost
+	external  map[string]int64
+}
+
+type meterKey struct{}
+
+// WithMeter starts metering the work done with ctx
+func WithMeter(ctx context.Context) (context.Context, *Meter) {
+	meter := &Meter{external: make(map[string]int64)}
+	return context.WithValue(ctx, meterKey{}, meter), meter
+}
+
+// FromContext returns the meter of the request of ctx, nil for work outside a request
+func FromContext(ctx context.Context) *Meter {
+	meter, _ := ctx.Value(meterKey{}).(*Meter)
+	return meter
+}
+
+// RecordQuery counts a database query of the request of ctx
+func RecordQuery(ctx context.Context, elapsed time.Duration) {
+	FromContext(ctx).add(func(m *Meter) {
+		m.cost.Queries++
+		m.cost.DBTime += elapsed
+	})
+}
+
+// RecordCache counts a cache lookup of the request of ctx
+func RecordCache(ctx context.Context, hit bool) {
+	FromContext(ctx).add(func(m *Meter) {
+		if hit {
+			m.cost.CacheHits++
+		} else {
+			m.cost.CacheMisses++
+		}
+	})
+}
+
+// RecordExternal counts a call of the request of ctx to service, failed calls included
+func RecordExternal(ctx context.Context, service string, elapsed time.Duration) {
+	FromContext(ctx).add(func(m *Meter) {
+		m.cost.ExternalCalls++
+		m.cost.ExternalTime += elapsed
+		m.external[service]++
+	})
+}
+
+// add records into the meter, work outside a request has none and is not metered
+func (m *Meter) add(record func(m *Meter)) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	record(m)
+}
+
+// Cost returns the cost of the request so far
+func (m *Meter) Cost() Cost {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cost
+}
+
+// SetOperation names the operation the request was routed to, the metrics are kept per operation
+func (m *Meter) SetOperation(operation string) {
+	m.add(func(m *Meter) { m.operation = operation })
+}
+
+func (m *Meter) snapshot() (string, Cost, map[string]int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	external := make(map[string]int64, len(m.external))
+	for service, calls := range m.external {
+		external[service] = calls
+	}
+	return m.operation, m.cost, external
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// JobLocks keeps singleton jobs such as the projection runner to one instance at a time. A job runs
+// while its instance holds the Postgres session advisory lock named after it, on a connection of its
+// own outside the pool. Instances without the lock retry every retry interval and take over when
+// the holder stops or loses its connection.
+//
+// A nil *JobLocks runs jobs right away, single instance deployments do not need the locks.
+type JobLocks struct {
+	connConfig *pgx.ConnConfig
+	retry      time.Duration
+}
+
+func NewJobLocks(connConfig *pgx.ConnConfig, retry time.Duration) *JobLocks {
+	return &JobLocks{
+		connConfig: connConfig,
+		retry:      retry,
+	}
+}
+
+// Enabled reports whether jobs are locked
+func (l *JobLocks) Enabled() bool {
+	return l != nil
+}
+
+// Run runs job once it holds the lock of name and returns when job returns. A job whose lock
+// connection drops is cancelled and run again once the lock is held again.
+func (l *JobLocks) Run(ctx context.Context, name string, job func(ctx context.Context)) {
+	if l == nil {
+		job(ctx)
+		return
+	}
+
+	for {
+		held, lost, err := l.runLocked(ctx, name, job)
+		if err != nil {
+			log.Printf("Failed to take job lock %s: %v", name, err)
+		}
+		if held && !lost {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(l.retry):
+		}
+	}
+}
+
+// Ping opens a lock connection the way jobs do and takes and releases a throwaway lock on it
+func (l *JobLocks) Ping(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	conn, err := pgx.ConnectConfig(ctx, l.connConfig.Copy())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	var held bool
+	return conn.QueryRow(ctx, "SELECT pg_try_advisory_lock(hashtext('job-locks-ping'))").Scan(&held)
+}
+
+func (l *JobLocks) runLocked(ctx context.Context, name string, job func(ctx context.Context)) (held bool, lost bool, err error) {
+	conn, err := pgx.ConnectConfig(ctx, l.connConfig.Copy())
+	if err != nil {
+		return false, false, err
+	}
+	// Closing the session releases the lock
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", name).Scan(&held); err != nil || !held {
+		return false, false, err
+	}
+	log.Printf("Job lock %s taken, running the job on this instance", name)
+
+	// The watchdog can always report, a job that panics never reads it
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	watchdogDone := make(chan bool, 1)
+	go func() {
+		watchdogDone <- l.watch(jobCtx, conn, cancel)
+	}()
+
+	job(jobCtx)
+	cancel()
+	return true, <-watchdogDone, nil
+}
+
+// watch pings the lock connection until ctx is done and cancels the job when the ping fails, the
+// lock went with the connection. It reports whether the lock was lost.
+func (l *JobLocks) watch(ctx context.Context, conn *pgx.Conn, cancel context.CancelFunc) bool {
+	ticker := time.NewTicker(l.retry)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+
+		if err := conn.Ping(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Job lock connection lost, stopping the job: %v", err)
+			cancel()
+			return true
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// KeysetColumn is the column a sort field orders by and its Postgres type, keys travel as text in
+// cursors and are cast back to it
+type KeysetColumn struct {
+	Column string
+	Type   string
+}
+
+// CompileKeyset turns the position of a page into a parameterized SQL predicate keeping the rows
+// after it in the order of the page, tieBreaker ascending last. Directions may differ per field, so
+// the predicate is spelled out term by term instead of comparing rows. Keys become positional
+// arguments starting at $firstArg, the first page has no position and keeps every row.
+func CompileKeyset(order []pagination.OrderBy, columns map[string]KeysetColumn, tieBreaker KeysetColumn, after []string,
+	firstArg int) (string, []any, error) {
+	if after == nil {
+		return "TRUE", nil, nil
+	}
+	if len(after) != len(order)+1 {
+		return "", nil, appErrors.NewInfrastructureError("cursor keys do not match the order", nil)
+	}
+
+	keyColumns := make([]KeysetColumn, 0, len(order)+1)
+	operators := make([]string, 0, len(order)+1)
+	for _, term := range order {
+		column, ok := columns[term.Field]
+		if !ok {
+			return "", nil, appErrors.NewInfrastructureError(fmt.Sprintf("sort field %s has no keyset column", term.Field), nil)
+		}
+		keyColumns = append(keyColumns, column)
+		if term.Direction == pagination.Descending {
+			operators = append(operators, "<")
+		} else {
+			operators = append(operators, ">")
+		}
+	}
+	keyColumns = append(keyColumns, tieBreaker)
+	operators = append(operators, ">")
+
+	args := make([]any, len(after))
+	placeholders := make([]string, len(after))
+	for i, key := range after {
+		args[i] = key
+		placeholders[i] = fmt.Sprintf("$%d::text::%s", firstArg+i, keyColumns[i].Type)
+	}
+
+	// (a > $1) OR (a = $1 AND b < $2) OR (a = $1 AND b = $2 AND id > $3)
+	terms := make([]string, len(keyColumns))
+	for i := range keyColumns {
+		conditions := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, fmt.Sprintf("%s = %s", keyColumns[j].Column, placeholders[j]))
+		}
+		conditions = append(conditions, fmt.Sprintf("%s %s %s", keyColumns[i].Column, operators[i], placeholders[i]))
+		terms[i] = "(" + strings.Join(conditions, " AND ") + ")"
+	}
+
+	return "(" + strings.Join(terms, " OR ") + ")", args, nil
+}
+
+// TimeIDKeyset reads the position of the lists ordered by a timestamp and a UUID, unset on the first
+// page so the sqlc queries keep every row
+func TimeIDKeyset(after []string) (pgtype.Timestamptz, pgtype.UUID, error) {
+	if after == nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, nil
+	}
+	if len(after) != 2 {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, appErrors.NewInfrastructureError("cursor keys do not match the order", nil)
+	}
+
+	at, err := pagination.ParseTimeKey(after[0])
+	if err != nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, appErrors.NewInfrastructureError("invalid cursor time key", err)
+	}
+	var id pgtype.UUID
+	if err := id.Scan(after[1]); err != nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, appErrors.NewInfrastructureError("invalid cursor id key", err)
+	}
+	return pgtype.Timestamptz{Time: at, Valid: true}, id, nil
+}
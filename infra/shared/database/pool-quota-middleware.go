@@ -0,0 +1,23 @@
+package database
+
+import (
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// InteractiveRequests counts the connections of every request served by the router as interactive,
+// work started outside a request stays in the background class
+func InteractiveRequests(c *gin.Context) {
+	c.Request = c.Request.WithContext(WithPoolClass(c.Request.Context(), PoolClassInteractive))
+	c.Next()
+}
+
+// NewReportsMiddleware moves the requests of the operations isReport accepts to the reports class
+func NewReportsMiddleware(isReport func(operationID string) bool) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if isReport(ctx.Operation().OperationID) {
+			ctx = huma.WithContext(ctx, WithPoolClass(ctx.Context(), PoolClassReports))
+		}
+		next(ctx)
+	}
+}
@@ -0,0 +1,273 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PoolClass is the kind of work a connection of the pool is acquired for
+type PoolClass string
+
+const (
+	// PoolClassInteractive covers the requests of users, sign in and sign up among them
+	PoolClassInteractive PoolClass = "interactive"
+	// PoolClassBackground covers workers, schedulers and anything started outside a request
+	PoolClassBackground PoolClass = "background"
+	// PoolClassReports covers report generation, exports and imports, which hold connections the longest
+	PoolClassReports PoolClass = "reports"
+)
+
+// PoolClasses lists the classes in the order they are reported
+var PoolClasses = []PoolClass{PoolClassInteractive, PoolClassBackground, PoolClassReports}
+
+type PoolQuotaConfig struct {
+	// BackgroundPercent and ReportsPercent are the shares of the pool connections the class can hold
+	// at once, at least one connection. Zero lifts the limit of the class. Interactive requests have
+	// no limit, the others leave them the rest of the pool.
+	BackgroundPercent int
+	ReportsPercent    int
+	// MaxWait is how long a class at its limit waits before it takes a connection over it, which
+	// keeps work holding a connection of its class from waiting on itself
+	MaxWait time.Duration
+}
+
+type poolClassKey struct{}
+
+// WithPoolClass returns a context whose connections count against class
+func WithPoolClass(ctx context.Context, class PoolClass) context.Context {
+	return context.WithValue(ctx, poolClassKey{}, class)
+}
+
+// PoolClassOf returns the class of ctx, background when none was set
+func PoolClassOf(ctx context.Context) PoolClass {
+	if class, ok := ctx.Value(poolClassKey{}).(PoolClass); ok {
+		return class
+	}
+	return PoolClassBackground
+}
+
+// PoolQuota partitions one pgx pool between the classes of work, so a heavy report cannot take the
+// connections sign ins need. The quota is soft: no connection is reserved for a class, and a class at
+// its limit waits up to MaxWait for one of its own connections before going over the limit. It counts
+// the connections of the pool it traces, see Trace.
+type PoolQuota struct {
+	limits  map[PoolClass]int
+	slots   map[PoolClass]chan struct{}
+	maxWait time.Duration
+
+	mu        sync.Mutex
+	held      map[*pgx.Conn]acquisition
+	inUse     map[PoolClass]int
+	waits     map[PoolClass]int64
+	waited    map[PoolClass]time.Duration
+	overflows map[PoolClass]int64
+	rejected  map[PoolClass]int64
+}
+
+type acquisition struct {
+	class PoolClass
+	slot  bool
+}
+
+type acquisitionKey struct{}
+
+// NewPoolQuota sizes the limits of config for a pool of maxConns connections
+func NewPoolQuota(config PoolQuotaConfig, maxConns int32) *PoolQuota {
+	q := &PoolQuota{
+		limits:    make(map[PoolClass]int),
+		slots:     make(map[PoolClass]chan struct{}),
+		maxWait:   config.MaxWait,
+		held:      make(map[*pgx.Conn]acquisition),
+		inUse:     make(map[PoolClass]int),
+		waits:     make(map[PoolClass]int64),
+		waited:    make(map[PoolClass]time.Duration),
+		overflows: make(map[PoolClass]int64),
+		rejected:  make(map[PoolClass]int64),
+	}
+	for class, percent := range map[PoolClass]int{PoolClassBackground: config.BackgroundPercent, PoolClassReports: config.ReportsPercent} {
+		if percent <= 0 {
+			continue
+		}
+		limit := max(1, int(maxConns)*min(percent, 100)/100)
+		q.limits[class] = limit
+		q.slots[class] = make(chan struct{}, limit)
+	}
+	return q
+}
+
+// Limit returns the connections class can hold at once, 0 when it has no limit
+func (q *PoolQuota) Limit(class PoolClass) int {
+	return q.limits[class]
+}
+
+// Trace returns tracer with the acquisitions and releases of the pool counted against the quota, the
+// pool must be configured with it as its tracer
+func (q *PoolQuota) Trace(tracer pgx.QueryTracer) pgx.QueryTracer {
+	return &quotaTracer{QueryTracer: tracer, quota: q}
+}
+
+// acquire takes a slot of class, waiting up to maxWait while the class holds its limit, and goes over
+// the limit without a slot after that. It returns false when ctx ends first.
+func (q *PoolQuota) acquire(ctx context.Context, class PoolClass) (slot bool, ok bool) {
+	slots := q.slots[class]
+	if slots == nil {
+		return false, true
+	}
+	select {
+	case slots <- struct{}{}:
+		return true, true
+	default:
+	}
+
+	started := time.Now()
+	timer := time.NewTimer(q.maxWait)
+	defer timer.Stop()
+	select {
+	case slots <- struct{}{}:
+		q.recordWait(class, time.Since(started), nil)
+		return true, true
+	case <-timer.C:
+		q.recordWait(class, time.Since(started), q.overflows)
+		return false, true
+	case <-ctx.Done():
+		q.recordWait(class, time.Since(started), q.rejected)
+		return false, false
+	}
+}
+
+// recordWait counts a wait of class, and in outcome how it ended when it got no slot
+func (q *PoolQuota) recordWait(class PoolClass, waited time.Duration, outcome map[PoolClass]int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.waits[class]++
+	q.waited[class] += waited
+	if outcome != nil {
+		outcome[class]++
+	}
+}
+
+func (q *PoolQuota) hold(conn *pgx.Conn, acquired acquisition) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.held[conn] = acquired
+	q.inUse[acquired.class]++
+}
+
+// release frees the slot of conn, connections the quota did not see acquired are ignored
+func (q *PoolQuota) release(conn *pgx.Conn) {
+	q.mu.Lock()
+	acquired, ok := q.held[conn]
+	if ok {
+		delete(q.held, conn)
+		q.inUse[acquired.class]--
+	}
+	q.mu.Unlock()
+
+	if ok && acquired.slot {
+		<-q.slots[acquired.class]
+	}
+}
+
+// WriteMetrics writes the limits, the connections in use and the waits per class in the Prometheus
+// text format
+func (q *PoolQuota) WriteMetrics(w io.Writer) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, err := fmt.Fprint(w, "# HELP db_pool_class_limit Connections a class can hold at once, 0 for no limit\n"+
+		"# TYPE db_pool_class_limit gauge\n"); err != nil {
+		return err
+	}
+	for _, class := range PoolClasses {
+		if _, err := fmt.Fprintf(w, "db_pool_class_limit{class=%q} %d\n", class, q.limits[class]); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprint(w, "# HELP db_pool_class_in_use Connections held by a class\n"+
+		"# TYPE db_pool_class_in_use gauge\n"); err != nil {
+		return err
+	}
+	for _, class := range PoolClasses {
+		if _, err := fmt.Fprintf(w, "db_pool_class_in_use{class=%q} %d\n", class, q.inUse[class]); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprint(w, "# HELP db_pool_class_waits_total Acquisitions that waited because their class held its limit\n"+
+		"# TYPE db_pool_class_waits_total counter\n"); err != nil {
+		return err
+	}
+	for _, class := range PoolClasses {
+		if _, err := fmt.Fprintf(w, "db_pool_class_waits_total{class=%q} %d\n", class, q.waits[class]); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprint(w, "# HELP db_pool_class_wait_seconds_total Time spent waiting for a connection of the class\n"+
+		"# TYPE db_pool_class_wait_seconds_total counter\n"); err != nil {
+		return err
+	}
+	for _, class := range PoolClasses {
+		if _, err := fmt.Fprintf(w, "db_pool_class_wait_seconds_total{class=%q} %g\n", class, q.waited[class].Seconds()); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprint(w, "# HELP db_pool_class_overflows_total Acquisitions that went over the limit of their class after waiting\n"+
+		"# TYPE db_pool_class_overflows_total counter\n"); err != nil {
+		return err
+	}
+	for _, class := range PoolClasses {
+		if _, err := fmt.Fprintf(w, "db_pool_class_overflows_total{class=%q} %d\n", class, q.overflows[class]); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprint(w, "# HELP db_pool_class_rejected_total Acquisitions whose context ended while their class held its limit\n"+
+		"# TYPE db_pool_class_rejected_total counter\n"); err != nil {
+		return err
+	}
+	for _, class := range PoolClasses {
+		if _, err := fmt.Fprintf(w, "db_pool_class_rejected_total{class=%q} %d\n", class, q.rejected[class]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quotaTracer counts the connections of the pool against the quota and passes queries to the tracer
+// it wraps
+type quotaTracer struct {
+	pgx.QueryTracer
+	quota *PoolQuota
+}
+
+func (t *quotaTracer) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	class := PoolClassOf(ctx)
+	slot, ok := t.quota.acquire(ctx, class)
+	if !ok {
+		// ctx is done, the pool returns its error without acquiring
+		return ctx
+	}
+	return context.WithValue(ctx, acquisitionKey{}, acquisition{class: class, slot: slot})
+}
+
+func (t *quotaTracer) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, data pgxpool.TraceAcquireEndData) {
+	acquired, ok := ctx.Value(acquisitionKey{}).(acquisition)
+	if !ok {
+		return
+	}
+	if data.Err != nil || data.Conn == nil {
+		if acquired.slot {
+			<-t.quota.slots[acquired.class]
+		}
+		return
+	}
+	t.quota.hold(data.Conn, acquired)
+}
+
+func (t *quotaTracer) TraceRelease(_ *pgxpool.Pool, data pgxpool.TraceReleaseData) {
+	t.quota.release(data.Conn)
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TrackedRuntimeParams are the startup parameters PgBouncer keeps per client and sets again on every
+// server connection it hands out. Other parameters are refused at startup or leak between clients.
+var TrackedRuntimeParams = []string{"application_name", "client_encoding", "datestyle", "standard_conforming_strings", "timezone"}
+
+type PoolerConfig struct {
+	// TransactionPooling is set when DATABASE_URL reaches PgBouncer, or another pooler, in transaction
+	// mode. Each transaction may then run on a different server connection.
+	TransactionPooling bool
+	// SessionURL reaches the same database with a session of its own, for the job locks and the role
+	// change watcher. Empty uses DATABASE_URL, which has to be in session mode then.
+	SessionURL string
+}
+
+// Apply makes connConfig safe behind a transaction pooler. Statements are no longer prepared, the
+// prepared statements of a server connection are not the ones of the client, and cache_statement
+// becomes cache_describe. Modes that send a statement in two round trips and runtime parameters the
+// pooler does not track are refused.
+func (c PoolerConfig) Apply(connConfig *pgx.ConnConfig) error {
+	if !c.TransactionPooling {
+		return nil
+	}
+
+	connConfig.StatementCacheCapacity = 0
+	switch connConfig.DefaultQueryExecMode {
+	case pgx.QueryExecModeCacheStatement:
+		connConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheDescribe
+		if connConfig.DescriptionCacheCapacity == 0 {
+			return fmt.Errorf("query exec mode cache_describe needs a description cache")
+		}
+	case pgx.QueryExecModeDescribeExec:
+		return fmt.Errorf("query exec mode describe_exec runs a statement in two round trips, the pooler may send them to different servers")
+	}
+
+	for name := range connConfig.RuntimeParams {
+		if !slices.Contains(TrackedRuntimeParams, strings.ToLower(name)) {
+			return fmt.Errorf("runtime parameter %s is not tracked by transaction poolers, set it with SetLocal inside a transaction", name)
+		}
+	}
+	return nil
+}
+
+// SessionConnConfig is the configuration of the connections that need a session of their own. The
+// session URL gets the runtime parameters of poolConfig, without them it is poolConfig itself.
+func (c PoolerConfig) SessionConnConfig(poolConfig *pgx.ConnConfig) (*pgx.ConnConfig, error) {
+	if c.SessionURL == "" {
+		return poolConfig.Copy(), nil
+	}
+
+	connConfig, err := pgx.ParseConfig(c.SessionURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse session database URL: %w", err)
+	}
+	for name, value := range poolConfig.RuntimeParams {
+		if _, ok := connConfig.RuntimeParams[name]; !ok {
+			connConfig.RuntimeParams[name] = value
+		}
+	}
+	return connConfig, nil
+}
+
+// Trace wraps tracer to log, once per statement, the ones whose effect outlives their transaction.
+// Behind a transaction pooler they change the session of whichever client gets the server next.
+func (c PoolerConfig) Trace(tracer pgx.QueryTracer) pgx.QueryTracer {
+	if !c.TransactionPooling {
+		return tracer
+	}
+	return &sessionStatementTracer{QueryTracer: tracer}
+}
+
+// SetLocal sets a configuration parameter until tx ends, the way SET LOCAL does. SET takes no
+// parameters, set_config does and works in every query exec mode.
+func SetLocal(ctx context.Context, tx pgx.Tx, name, value string) error {
+	if _, err := tx.Exec(ctx, "SELECT set_config($1, $2, true)", name, value); err != nil {
+		return fmt.Errorf("failed to set %s: %w", name, err)
+	}
+	return nil
+}
+
+// SessionStatement reports whether sql changes the session beyond its transaction: SET without
+// LOCAL, RESET, LISTEN, PREPARE and session advisory locks
+func SessionStatement(sql string) bool {
+	upper := strings.ToUpper(sql)
+	words := strings.Fields(upper)
+	if len(words) == 0 {
+		return false
+	}
+	switch words[0] {
+	case "SET":
+		return len(words) > 1 && words[1] != "LOCAL" && words[1] != "TRANSACTION" && words[1] != "CONSTRAINTS"
+	case "RESET", "LISTEN", "PREPARE":
+		return true
+	}
+	return strings.Contains(upper, "PG_ADVISORY_LOCK") || strings.Contains(upper, "PG_TRY_ADVISORY_LOCK")
+}
+
+// sessionStatementTracer logs the session statements sent through the pool and passes queries to the
+// tracer it wraps
+type sessionStatementTracer struct {
+	pgx.QueryTracer
+	logged sync.Map
+}
+
+func (t *sessionStatementTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if SessionStatement(data.SQL) {
+		if _, seen := t.logged.LoadOrStore(data.SQL, struct{}{}); !seen {
+			log.Printf("Session statement sent behind a transaction pooler, its effect reaches other clients: %s", data.SQL)
+		}
+	}
+	return t.QueryTracer.TraceQueryStart(ctx, conn, data)
+}
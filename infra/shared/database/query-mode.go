@@ -0,0 +1,64 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// QueryExecModes maps the names DB_QUERY_EXEC_MODE accepts, the ones of default_query_exec_mode in
+// DATABASE_URL, to the pgx modes
+var QueryExecModes = map[string]pgx.QueryExecMode{
+	"cache_statement": pgx.QueryExecModeCacheStatement,
+	"cache_describe":  pgx.QueryExecModeCacheDescribe,
+	"describe_exec":   pgx.QueryExecModeDescribeExec,
+	"exec":            pgx.QueryExecModeExec,
+	"simple_protocol": pgx.QueryExecModeSimpleProtocol,
+}
+
+// DescribingExecModes are the modes that ask Postgres for the parameter types of a statement. The
+// others pick them from the Go types, which sends the []byte sqlc uses for jsonb columns as bytea.
+var DescribingExecModes = []string{"cache_statement", "cache_describe", "describe_exec"}
+
+type QueryModeConfig struct {
+	// ExecMode is how queries are sent, one of QueryExecModes. Empty keeps the mode of the URL,
+	// cache_statement unless set there.
+	ExecMode string
+	// StatementCacheCapacity and DescriptionCacheCapacity are the statements cache_statement prepares
+	// and the descriptions cache_describe keeps per connection. Negative keeps the capacity of the
+	// URL, 512 unless set there.
+	StatementCacheCapacity   int
+	DescriptionCacheCapacity int
+}
+
+// Apply sets the mode and cache capacities on connConfig. A mode whose cache is disabled is refused,
+// pgx would only fail on the first query.
+func (c QueryModeConfig) Apply(connConfig *pgx.ConnConfig) error {
+	if c.ExecMode != "" {
+		mode, ok := QueryExecModes[c.ExecMode]
+		if !ok {
+			return fmt.Errorf("unknown query exec mode %q", c.ExecMode)
+		}
+		connConfig.DefaultQueryExecMode = mode
+	}
+	if c.StatementCacheCapacity >= 0 {
+		connConfig.StatementCacheCapacity = c.StatementCacheCapacity
+	}
+	if c.DescriptionCacheCapacity >= 0 {
+		connConfig.DescriptionCacheCapacity = c.DescriptionCacheCapacity
+	}
+
+	switch {
+	case connConfig.DefaultQueryExecMode == pgx.QueryExecModeCacheStatement && connConfig.StatementCacheCapacity == 0:
+		return fmt.Errorf("query exec mode cache_statement needs a statement cache")
+	case connConfig.DefaultQueryExecMode == pgx.QueryExecModeCacheDescribe && connConfig.DescriptionCacheCapacity == 0:
+		return fmt.Errorf("query exec mode cache_describe needs a description cache")
+	}
+	return nil
+}
+
+// Describes reports whether mode asks Postgres for the parameter types, see DescribingExecModes
+func Describes(mode pgx.QueryExecMode) bool {
+	return slices.ContainsFunc(DescribingExecModes, func(name string) bool { return QueryExecModes[name] == mode })
+}
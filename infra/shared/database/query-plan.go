@@ -0,0 +1,96 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+)
+
+// NamedQuery is a query of the sqlc query files
+type NamedQuery struct {
+	Name string
+	Kind string // :one, :many, :exec, :execrows
+	SQL  string
+}
+
+var queryHeader = regexp.MustCompile(`(?m)^-- name: (\w+) (:\w+)\s*$`)
+
+// ParseQueryFile splits a sqlc query file into its named queries
+func ParseQueryFile(content string) []NamedQuery {
+	headers := queryHeader.FindAllStringSubmatchIndex(content, -1)
+	queries := make([]NamedQuery, 0, len(headers))
+	for i, header := range headers {
+		end := len(content)
+		if i+1 < len(headers) {
+			end = headers[i+1][0]
+		}
+		queries = append(queries, NamedQuery{
+			Name: content[header[2]:header[3]],
+			Kind: content[header[4]:header[5]],
+			SQL:  strings.TrimSpace(content[header[1]:end]),
+		})
+	}
+	return queries
+}
+
+var (
+	sqlcArg   = regexp.MustCompile(`sqlc\.n?arg\(\s*'?(\w+)'?\s*\)`)
+	namedArg  = regexp.MustCompile(`@(\w+)`)
+	lineNotes = regexp.MustCompile(`(?m)^\s*--.*$`)
+)
+
+// PositionalSQL replaces the named sqlc parameters with $n placeholders, the same name gets the same
+// placeholder, so the query can be explained by Postgres
+func PositionalSQL(sql string) string {
+	sql = lineNotes.ReplaceAllString(sql, "")
+	sql = sqlcArg.ReplaceAllString(sql, "@$1")
+
+	positions := make(map[string]int)
+	return namedArg.ReplaceAllStringFunc(sql, func(match string) string {
+		name := match[1:]
+		if _, ok := positions[name]; !ok {
+			positions[name] = len(positions) + 1
+		}
+		return fmt.Sprintf("$%d", positions[name])
+	})
+}
+
+type planNode struct {
+	NodeType     string     `json:"Node Type"`
+	RelationName string     `json:"Relation Name"`
+	Plans        []planNode `json:"Plans"`
+}
+
+// SequentialScans returns the relations of tables read by a sequential scan in an EXPLAIN (FORMAT JSON)
+// plan. Partitions count as their table, a partition is named after its table.
+func SequentialScans(plan []byte, tables []string) ([]string, error) {
+	var explained []struct {
+		Plan planNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &explained); err != nil {
+		return nil, appErrors.NewInfrastructureError("query plan is not EXPLAIN JSON output", err)
+	}
+
+	var scans []string
+	var walk func(node planNode)
+	walk = func(node planNode) {
+		if node.NodeType == "Seq Scan" {
+			for _, table := range tables {
+				if node.RelationName == table || strings.HasPrefix(node.RelationName, table+"_") {
+					scans = append(scans, node.RelationName)
+					break
+				}
+			}
+		}
+		for _, child := range node.Plans {
+			walk(child)
+		}
+	}
+	for _, e := range explained {
+		walk(e.Plan)
+	}
+	return scans, nil
+}
@@ -0,0 +1,338 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// UnnamedQuery keys the metrics of queries built by hand, only sqlc queries carry a name
+const UnnamedQuery = "unnamed"
+
+// Tenant labels of queries without a tenant, background jobs and public endpoints, and of the tenants
+// over TenantSeries
+const (
+	NoTenant     = "none"
+	OtherTenants = "other"
+)
+
+type QueryTracerConfig struct {
+	// SlowThreshold is the latency above which a query is logged as slow, zero disables the log
+	SlowThreshold time.Duration
+	// ExplainAfter is the number of slow runs after which the plan of a query is logged once, zero
+	// disables it. Plans are taken with EXPLAIN on the pool, meant for development databases.
+	ExplainAfter int
+	// TenantSeries bounds the tenants with metrics of their own, the queries of the tenants seen after
+	// them are counted under OtherTenants. Zero keeps the metrics per query only.
+	TenantSeries int
+}
+
+// QueryStats are the counters of one query, keyed by its sqlc name
+type QueryStats struct {
+	Name     string
+	Calls    int64
+	Errors   int64
+	Slow     int64
+	Total    time.Duration
+	Max      time.Duration
+	Explains int64
+}
+
+// TenantQueryStats are the counters of the queries of one tenant, the slowest query names which one
+// to look at first
+type TenantQueryStats struct {
+	Tenant      string
+	Calls       int64
+	Errors      int64
+	Slow        int64
+	Total       time.Duration
+	SlowestName string
+	Slowest     time.Duration
+}
+
+// QueryTracer is a pgx tracer that keeps latency metrics per sqlc query and per tenant, and logs slow
+// queries with their tenant. Query arguments are never logged, they hold personal data.
+type QueryTracer struct {
+	config QueryTracerConfig
+	pool   *pgxpool.Pool
+	tenant func(ctx context.Context) string
+
+	mu        sync.Mutex
+	stats     map[string]*QueryStats
+	tenants   map[string]*TenantQueryStats
+	explained map[string]bool
+}
+
+func NewQueryTracer(config QueryTracerConfig) *QueryTracer {
+	return &QueryTracer{
+		config:    config,
+		tenant:    func(context.Context) string { return "" },
+		stats:     make(map[string]*QueryStats),
+		tenants:   make(map[string]*TenantQueryStats),
+		explained: make(map[string]bool),
+	}
+}
+
+// AttributeWith sets how the tenant of a query is read from its context, the tenant is put there by
+// the authorization middleware the database package does not depend on
+func (t *QueryTracer) AttributeWith(tenant func(ctx context.Context) string) {
+	t.tenant = tenant
+}
+
+// ExplainWith sets the pool plans of repeat offenders are taken with, the pool is created after the
+// tracer it is configured with
+func (t *QueryTracer) ExplainWith(pool *pgxpool.Pool) {
+	t.pool = pool
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	name   string
+	tenant string
+	sql    string
+	args   []any
+	start  time.Time
+}
+
+type untracedKey struct{}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if ctx.Value(untracedKey{}) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStartKey{}, queryStart{
+		name:   QueryName(data.SQL),
+		tenant: t.tenant(ctx),
+		sql:    data.SQL,
+		args:   data.Args,
+		start:  time.Now(),
+	})
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	started, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	t.record(started, time.Since(started.start), data.Err)
+}
+
+func (t *QueryTracer) record(started queryStart, elapsed time.Duration, err error) {
+	slow := t.config.SlowThreshold > 0 && elapsed > t.config.SlowThreshold
+
+	t.mu.Lock()
+	stats, ok := t.stats[started.name]
+	if !ok {
+		stats = &QueryStats{Name: started.name}
+		t.stats[started.name] = stats
+	}
+	stats.Calls++
+	stats.Total += elapsed
+	stats.Max = max(stats.Max, elapsed)
+	if err != nil {
+		stats.Errors++
+	}
+	t.recordTenant(started, elapsed, err, slow)
+	explain := false
+	if slow {
+		stats.Slow++
+		explain = t.config.ExplainAfter > 0 && t.pool != nil && stats.Slow >= int64(t.config.ExplainAfter) && !t.explained[started.name]
+		if explain {
+			t.explained[started.name] = true
+			stats.Explains++
+		}
+	}
+	t.mu.Unlock()
+
+	if !slow {
+		return
+	}
+	tenant := started.tenant
+	if tenant == "" {
+		tenant = NoTenant
+	}
+	log.Printf("Slow query %s of tenant %s took %s, over the %s threshold", started.name, tenant,
+		elapsed.Round(time.Millisecond), t.config.SlowThreshold)
+	if explain {
+		go t.explain(started)
+	}
+}
+
+// recordTenant counts the query for its tenant, t.mu held. Tenants are kept until restart, the
+// series are bounded by TenantSeries rather than by expiry so a tenant does not vanish mid-incident.
+func (t *QueryTracer) recordTenant(started queryStart, elapsed time.Duration, err error, slow bool) {
+	if t.config.TenantSeries <= 0 {
+		return
+	}
+	label := started.tenant
+	if label == "" {
+		label = NoTenant
+	}
+	stats, ok := t.tenants[label]
+	if !ok && len(t.tenants) >= t.config.TenantSeries {
+		label = OtherTenants
+		stats, ok = t.tenants[label]
+	}
+	if !ok {
+		stats = &TenantQueryStats{Tenant: label}
+		t.tenants[label] = stats
+	}
+
+	stats.Calls++
+	stats.Total += elapsed
+	if err != nil {
+		stats.Errors++
+	}
+	if slow {
+		stats.Slow++
+	}
+	if elapsed > stats.Slowest {
+		stats.Slowest = elapsed
+		stats.SlowestName = started.name
+	}
+}
+
+// explain logs the plan without running the query, EXPLAIN ANALYZE would repeat writes
+func (t *QueryTracer) explain(started queryStart) {
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), untracedKey{}, true), 10*time.Second)
+	defer cancel()
+
+	rows, err := t.pool.Query(ctx, "EXPLAIN "+started.sql, started.args...)
+	if err != nil {
+		log.Printf("Failed to explain slow query %s: %v", started.name, err)
+		return
+	}
+	lines, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		log.Printf("Failed to explain slow query %s: %v", started.name, err)
+		return
+	}
+	log.Printf("Plan of repeatedly slow query %s:\n%s", started.name, strings.Join(lines, "\n"))
+}
+
+// Stats returns a copy of the counters of every query seen, sorted by name
+func (t *QueryTracer) Stats() []QueryStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make([]QueryStats, 0, len(t.stats))
+	for _, s := range t.stats {
+		stats = append(stats, *s)
+	}
+	slices.SortFunc(stats, func(a, b QueryStats) int { return strings.Compare(a.Name, b.Name) })
+	return stats
+}
+
+// TenantStats returns a copy of the counters of every tenant seen, the most time spent first
+func (t *QueryTracer) TenantStats() []TenantQueryStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make([]TenantQueryStats, 0, len(t.tenants))
+	for _, s := range t.tenants {
+		stats = append(stats, *s)
+	}
+	slices.SortFunc(stats, func(a, b TenantQueryStats) int {
+		if a.Total != b.Total {
+			return cmp.Compare(b.Total, a.Total)
+		}
+		return strings.Compare(a.Tenant, b.Tenant)
+	})
+	return stats
+}
+
+// WriteMetrics writes the counters in the Prometheus text exposition format
+func (t *QueryTracer) WriteMetrics(w io.Writer) error {
+	stats := t.Stats()
+	metrics := []struct {
+		name  string
+		kind  string
+		help  string
+		value func(QueryStats) string
+	}{
+		{"db_query_duration_seconds_sum", "counter", "Total time spent in the query", func(s QueryStats) string { return seconds(s.Total) }},
+		{"db_query_duration_seconds_count", "counter", "Number of runs of the query", func(s QueryStats) string { return fmt.Sprint(s.Calls) }},
+		{"db_query_duration_seconds_max", "gauge", "Slowest run of the query since start", func(s QueryStats) string { return seconds(s.Max) }},
+		{"db_query_errors_total", "counter", "Runs of the query that failed", func(s QueryStats) string { return fmt.Sprint(s.Errors) }},
+		{"db_query_slow_total", "counter", "Runs of the query over the slow threshold", func(s QueryStats) string { return fmt.Sprint(s.Slow) }},
+	}
+
+	for _, metric := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind); err != nil {
+			return err
+		}
+		for _, s := range stats {
+			if _, err := fmt.Fprintf(w, "%s{query=%q} %s\n", metric.name, s.Name, metric.value(s)); err != nil {
+				return err
+			}
+		}
+	}
+	return t.writeTenantMetrics(w)
+}
+
+func (t *QueryTracer) writeTenantMetrics(w io.Writer) error {
+	if t.config.TenantSeries <= 0 {
+		return nil
+	}
+	stats := t.TenantStats()
+	metrics := []struct {
+		name  string
+		kind  string
+		help  string
+		value func(TenantQueryStats) string
+	}{
+		{"db_tenant_query_duration_seconds_sum", "counter", "Total time spent in the queries of the tenant", func(s TenantQueryStats) string { return seconds(s.Total) }},
+		{"db_tenant_query_duration_seconds_count", "counter", "Number of queries of the tenant", func(s TenantQueryStats) string { return fmt.Sprint(s.Calls) }},
+		{"db_tenant_query_errors_total", "counter", "Queries of the tenant that failed", func(s TenantQueryStats) string { return fmt.Sprint(s.Errors) }},
+		{"db_tenant_query_slow_total", "counter", "Queries of the tenant over the slow threshold", func(s TenantQueryStats) string { return fmt.Sprint(s.Slow) }},
+	}
+
+	for _, metric := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind); err != nil {
+			return err
+		}
+		for _, s := range stats {
+			if _, err := fmt.Fprintf(w, "%s{tenant=%q} %s\n", metric.name, s.Tenant, metric.value(s)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := fmt.Fprint(w, "# HELP db_tenant_query_slowest_seconds Slowest query of the tenant since start\n"+
+		"# TYPE db_tenant_query_slowest_seconds gauge\n"); err != nil {
+		return err
+	}
+	for _, s := range stats {
+		if _, err := fmt.Fprintf(w, "db_tenant_query_slowest_seconds{tenant=%q,query=%q} %s\n", s.Tenant, s.SlowestName, seconds(s.Slowest)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%g", d.Seconds())
+}
+
+// QueryName is the name sqlc gives a query in the "-- name: GetUser :one" line it starts with
+func QueryName(sql string) string {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(sql), "-- name: ")
+	if !ok {
+		return UnnamedQuery
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return UnnamedQuery
+	}
+	return fields[0]
+}
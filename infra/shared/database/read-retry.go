@@ -0,0 +1,250 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Querier is the part of a pool or transaction sqlc queries run on
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type ReadRetryConfig struct {
+	// Attempts is the number of times a read runs at most, 1 disables the retries
+	Attempts int
+	// BaseDelay is the longest wait before the first retry, it doubles on each retry up to MaxDelay.
+	// Each wait is picked at random below it, so instances do not come back to a new primary at once.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// DefaultReadRetryConfig rides out a primary failover of about a second
+var DefaultReadRetryConfig = ReadRetryConfig{Attempts: 4, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+// ReadRetry runs the read queries of repositories again when they fail on a connection error, such
+// as the ones of a primary failover. Reads are idempotent, writes run once and surface their error.
+// Queries of a transaction are never retried, sqlc WithTx runs them on the transaction itself.
+type ReadRetry struct {
+	config    ReadRetryConfig
+	router    *ReplicaRouter
+	retries   atomic.Int64
+	recovered atomic.Int64
+	exhausted atomic.Int64
+}
+
+func NewReadRetry(config ReadRetryConfig) *ReadRetry {
+	return &ReadRetry{config: config}
+}
+
+// Reads is the retry repositories wrap their pool with, main configures it before creating them
+var Reads = NewReadRetry(DefaultReadRetryConfig)
+
+// Configure replaces the configuration, call it before the repositories are created
+func (r *ReadRetry) Configure(config ReadRetryConfig) {
+	r.config = config
+}
+
+// Route sends the reads of the pool of router to its replica when it is caught up, call it before the
+// repositories are created
+func (r *ReadRetry) Route(router *ReplicaRouter) {
+	r.router = router
+}
+
+// Wrap returns db with its read queries retried
+func (r *ReadRetry) Wrap(db Querier) Querier {
+	return &retryingQuerier{db: db, retry: r}
+}
+
+// WriteMetrics writes the retry counters in the Prometheus text format
+func (r *ReadRetry) WriteMetrics(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP db_read_retries_total Read queries run again after a connection error\n"+
+		"# TYPE db_read_retries_total counter\n"+
+		"db_read_retries_total %d\n"+
+		"# HELP db_read_retries_recovered_total Read queries that succeeded after a retry\n"+
+		"# TYPE db_read_retries_recovered_total counter\n"+
+		"db_read_retries_recovered_total %d\n"+
+		"# HELP db_read_retries_exhausted_total Read queries that still failed on their last attempt\n"+
+		"# TYPE db_read_retries_exhausted_total counter\n"+
+		"db_read_retries_exhausted_total %d\n",
+		r.retries.Load(), r.recovered.Load(), r.exhausted.Load())
+	return err
+}
+
+// Transient reports whether err is a connection failure a read can be retried after: the connection
+// could not be opened or was lost, or the server is shutting down, starting or in recovery
+func Transient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case strings.HasPrefix(pgErr.Code, "08"): // connection exception
+			return true
+		case pgErr.Code == "57P01", pgErr.Code == "57P02", pgErr.Code == "57P03": // admin_shutdown, crash_shutdown, cannot_connect_now
+			return true
+		case pgErr.Code == "25006": // read_only_sql_transaction, the old primary was demoted
+			return true
+		}
+		return false
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return pgconn.SafeToRetry(err) || errors.As(err, &connectErr) || errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+var (
+	readStatement = regexp.MustCompile(`(?i)^\s*(SELECT|WITH)\b`)
+	// Statements with these words write, lock rows or have side effects even when they start with SELECT
+	writingWords = regexp.MustCompile(`(?i)\b(INSERT|UPDATE|DELETE|MERGE|SHARE|NEXTVAL|SETVAL|SET_CONFIG|PG_NOTIFY|PG_ADVISORY_\w+|PG_TRY_ADVISORY_\w+)\b`)
+	sqlComment   = regexp.MustCompile(`(?m)--.*$`)
+)
+
+// ReadOnly reports whether sql only reads and can be run again
+func ReadOnly(sql string) bool {
+	sql = sqlComment.ReplaceAllString(sql, "")
+	return readStatement.MatchString(sql) && !writingWords.MatchString(sql)
+}
+
+// after runs attempt again while err, the error of the first attempt, is transient and attempts are
+// left, and returns the error of the last attempt
+func (r *ReadRetry) after(ctx context.Context, err error, attempt func() error) error {
+	if err == nil {
+		return nil
+	}
+	for retry := 1; retry < r.config.Attempts && Transient(err); retry++ {
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(r.delay(retry)):
+		}
+		r.retries.Add(1)
+		if err = attempt(); err == nil {
+			r.recovered.Add(1)
+			return nil
+		}
+	}
+	if r.config.Attempts > 1 && Transient(err) {
+		r.exhausted.Add(1)
+	}
+	return err
+}
+
+// delay picks the wait before a retry at random below the capped exponential backoff
+func (r *ReadRetry) delay(retry int) time.Duration {
+	backoff := min(r.config.BaseDelay<<(retry-1), r.config.MaxDelay)
+	if backoff <= 0 {
+		return 0
+	}
+	return rand.N(backoff)
+}
+
+type retryingQuerier struct {
+	db    Querier
+	retry *ReadRetry
+}
+
+func (q *retryingQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, sql, args...)
+}
+
+func (q *retryingQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if !ReadOnly(sql) {
+		return q.db.Query(ctx, sql, args...)
+	}
+
+	db := q.target(ctx)
+	rows, err := db.Query(ctx, sql, args...)
+	err = q.retry.after(ctx, err, func() error {
+		rows, err = db.Query(ctx, sql, args...)
+		return err
+	})
+	if err != nil {
+		return rows, err
+	}
+	return &retryingRows{Rows: rows, query: q, db: db, ctx: ctx, sql: sql, args: args}, nil
+}
+
+func (q *retryingQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if !ReadOnly(sql) {
+		return q.db.QueryRow(ctx, sql, args...)
+	}
+	return &retryingRow{query: q, db: q.target(ctx), ctx: ctx, sql: sql, args: args}
+}
+
+// target returns the querier a read-only statement runs on, the replica when the router accepts it
+func (q *retryingQuerier) target(ctx context.Context) Querier {
+	if q.retry.router == nil {
+		return q.db
+	}
+	return q.retry.router.target(ctx, q.db)
+}
+
+// retryingRow runs the query when scanned, the errors of the connection only show there
+type retryingRow struct {
+	query *retryingQuerier
+	db    Querier
+	ctx   context.Context
+	sql   string
+	args  []any
+}
+
+func (r *retryingRow) Scan(dest ...any) error {
+	scan := func() error {
+		return r.db.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+	}
+	return r.query.retry.after(r.ctx, scan(), scan)
+}
+
+// retryingRows runs the query again when the connection fails before the first row. Once a row was
+// read the error is the caller's, as it is without the retry.
+type retryingRows struct {
+	pgx.Rows
+	query *retryingQuerier
+	db    Querier
+	ctx   context.Context
+	sql   string
+	args  []any
+	read  bool
+}
+
+func (r *retryingRows) Next() bool {
+	if r.Rows.Next() {
+		r.read = true
+		return true
+	}
+	if r.read {
+		return false
+	}
+
+	// Only a failure before the first row is retried, the caller has seen nothing yet
+	r.read = true
+	found := false
+	err := r.query.retry.after(r.ctx, r.Rows.Err(), func() error {
+		rows, err := r.db.Query(r.ctx, r.sql, r.args...)
+		if err != nil {
+			return err
+		}
+		r.Rows = rows
+		found = rows.Next()
+		return rows.Err()
+	})
+	return err == nil && found
+}
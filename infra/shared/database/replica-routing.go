@@ -0,0 +1,312 @@
+package database
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConsistencyTokenHeader carries the consistency token, returned after writes and echoed by clients
+const ConsistencyTokenHeader = "X-Consistency-Token"
+
+// LSN is a position in the write-ahead log of the primary
+type LSN uint64
+
+// ParseLSN reads the text form of a pg_lsn, two hexadecimal numbers such as "16/B374D848"
+func ParseLSN(value string) (LSN, error) {
+	high, low, found := strings.Cut(value, "/")
+	if !found {
+		return 0, fmt.Errorf("invalid LSN %q", value)
+	}
+	h, err := strconv.ParseUint(high, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid LSN %q: %w", value, err)
+	}
+	l, err := strconv.ParseUint(low, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid LSN %q: %w", value, err)
+	}
+	return LSN(h<<32 | l), nil
+}
+
+func (l LSN) String() string {
+	return fmt.Sprintf("%X/%X", uint64(l)>>32, uint32(l))
+}
+
+type ReplicaConfig struct {
+	// URL is the streaming replica reads of GET requests go to, "" reads everything from the primary
+	URL string
+	// PollInterval is how often the replay position of the replica is read. A replica not polled for
+	// three intervals is left aside until it answers again.
+	PollInterval time.Duration
+	// TokenMaxAge is how long a consistency token is honored, replicas have caught up by then
+	TokenMaxAge time.Duration
+}
+
+// DefaultReplicaConfig polls the replica five times a second and honors tokens for a minute
+var DefaultReplicaConfig = ReplicaConfig{PollInterval: 200 * time.Millisecond, TokenMaxAge: time.Minute}
+
+// ConsistencyTokens signs the LSN of a write with its issue time, so clients cannot forge a position
+// and old tokens are ignored
+type ConsistencyTokens struct {
+	key    []byte
+	maxAge time.Duration
+}
+
+// NewConsistencyTokens signs tokens with key, an instance on its own key only honors its own tokens.
+// A nil key picks a random one.
+func NewConsistencyTokens(key []byte, maxAge time.Duration) *ConsistencyTokens {
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic(fmt.Sprintf("failed to generate a consistency token key: %v", err))
+		}
+	}
+	return &ConsistencyTokens{key: key, maxAge: maxAge}
+}
+
+// Issue returns the token of a write that reached lsn at now
+func (t *ConsistencyTokens) Issue(lsn LSN, now time.Time) string {
+	payload := binary.BigEndian.AppendUint64(nil, uint64(lsn))
+	payload = binary.BigEndian.AppendUint64(payload, uint64(now.Unix()))
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(t.sign(payload))
+}
+
+// Read returns the LSN of a token issued less than the maximum age before now
+func (t *ConsistencyTokens) Read(token string, now time.Time) (LSN, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return 0, fmt.Errorf("invalid consistency token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != 16 {
+		return 0, fmt.Errorf("invalid consistency token")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, t.sign(payload)) {
+		return 0, fmt.Errorf("invalid consistency token signature")
+	}
+
+	issuedAt := time.Unix(int64(binary.BigEndian.Uint64(payload[8:])), 0)
+	if now.Sub(issuedAt) > t.maxAge {
+		return 0, fmt.Errorf("consistency token expired")
+	}
+	return LSN(binary.BigEndian.Uint64(payload[:8])), nil
+}
+
+func (t *ConsistencyTokens) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, t.key)
+	// Keeps the tokens apart from list cursors signed with the same key
+	mac.Write([]byte("consistency-token\x00"))
+	mac.Write(payload)
+	return mac.Sum(nil)[:16]
+}
+
+// ReplicaRouter sends the read-only statements of GET requests to a streaming replica once it has
+// replayed the write of the consistency token the client sent, see ADR-004. Statements of other
+// requests, of transactions and of background work keep going to the primary.
+type ReplicaRouter struct {
+	primary Querier
+	replica Querier
+	tokens  *ConsistencyTokens
+	config  ReplicaConfig
+
+	mu sync.Mutex
+	// replayed is the last replay position read from the replica, at polled
+	replayed LSN
+	polled   time.Time
+
+	replicaReads atomic.Int64
+	primaryReads atomic.Int64
+	issued       atomic.Int64
+}
+
+// NewReplicaRouter routes the reads of primary, the pool repositories wrap with Reads, to replica
+func NewReplicaRouter(primary, replica Querier, tokens *ConsistencyTokens, config ReplicaConfig) *ReplicaRouter {
+	return &ReplicaRouter{primary: primary, replica: replica, tokens: tokens, config: config}
+}
+
+// Watch polls the replay position of the replica until ctx is cancelled
+func (r *ReplicaRouter) Watch(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	available := true
+	for {
+		err := r.Poll(ctx)
+		if err != nil && available && ctx.Err() == nil {
+			log.Printf("Replica unavailable, reads go to the primary: %v", err)
+		} else if err == nil && !available {
+			log.Println("Replica available again")
+		}
+		available = err == nil
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll reads the replay position of the replica once
+func (r *ReplicaRouter) Poll(ctx context.Context) error {
+	var position *string
+	if err := r.replica.QueryRow(ctx, "SELECT pg_last_wal_replay_lsn()::text").Scan(&position); err != nil {
+		return err
+	}
+	if position == nil {
+		return errors.New("the replica database is not in recovery, it is not a streaming replica")
+	}
+	lsn, err := ParseLSN(*position)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.replayed, r.polled = lsn, time.Now()
+	return nil
+}
+
+// CurrentLSN returns the position the primary has written up to
+func (r *ReplicaRouter) CurrentLSN(ctx context.Context) (LSN, error) {
+	var position string
+	if err := r.primary.QueryRow(ctx, "SELECT pg_current_wal_lsn()::text").Scan(&position); err != nil {
+		return 0, err
+	}
+	return ParseLSN(position)
+}
+
+type replicaReadKey struct{}
+
+// WithReplicaReads lets the reads of ctx go to the replica once it has replayed minLSN, 0 when the
+// client sent no token
+func WithReplicaReads(ctx context.Context, minLSN LSN) context.Context {
+	return context.WithValue(ctx, replicaReadKey{}, minLSN)
+}
+
+// target returns the querier a read-only statement on db runs on under ctx
+func (r *ReplicaRouter) target(ctx context.Context, db Querier) Querier {
+	minLSN, found := ctx.Value(replicaReadKey{}).(LSN)
+	if !found || db != r.primary {
+		return db
+	}
+
+	r.mu.Lock()
+	replayed, polled := r.replayed, r.polled
+	r.mu.Unlock()
+	if time.Since(polled) > 3*r.config.PollInterval || replayed < minLSN {
+		r.primaryReads.Add(1)
+		return db
+	}
+	r.replicaReads.Add(1)
+	return r.replica
+}
+
+// Middleware marks the reads of GET requests for the replica, behind the write of the token they
+// carry, and returns a token after the successful requests of other methods. Invalid and expired
+// tokens are ignored.
+func (r *ReplicaRouter) Middleware(c *gin.Context) {
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		var minLSN LSN
+		if token := c.GetHeader(ConsistencyTokenHeader); token != "" {
+			minLSN, _ = r.tokens.Read(token, time.Now())
+		}
+		c.Request = c.Request.WithContext(WithReplicaReads(c.Request.Context(), minLSN))
+		c.Next()
+		return
+	}
+
+	writer := &tokenWriter{ResponseWriter: c.Writer, issue: func(w gin.ResponseWriter) {
+		if w.Status() >= http.StatusBadRequest {
+			return
+		}
+		lsn, err := r.CurrentLSN(c.Request.Context())
+		if err != nil {
+			log.Printf("Failed to read the WAL position for a consistency token: %v", err)
+			return
+		}
+		w.Header().Set(ConsistencyTokenHeader, r.tokens.Issue(lsn, time.Now()))
+		r.issued.Add(1)
+	}}
+	c.Writer = writer
+	c.Next()
+	// Responses without a body are written by gin after the handlers, past the wrapper
+	writer.beforeWrite()
+}
+
+// tokenWriter issues the token right before the headers are sent
+type tokenWriter struct {
+	gin.ResponseWriter
+	issue  func(w gin.ResponseWriter)
+	issued bool
+}
+
+func (w *tokenWriter) beforeWrite() {
+	if !w.issued && !w.ResponseWriter.Written() {
+		w.issued = true
+		w.issue(w.ResponseWriter)
+	}
+}
+
+func (w *tokenWriter) WriteHeaderNow() {
+	w.beforeWrite()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *tokenWriter) Write(data []byte) (int, error) {
+	w.beforeWrite()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *tokenWriter) WriteString(s string) (int, error) {
+	w.beforeWrite()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *tokenWriter) Flush() {
+	w.beforeWrite()
+	w.ResponseWriter.Flush()
+}
+
+// WriteMetrics writes where reads went and the tokens issued in the Prometheus text format
+func (r *ReplicaRouter) WriteMetrics(w io.Writer) error {
+	r.mu.Lock()
+	polled := r.polled
+	r.mu.Unlock()
+	available := 0
+	if time.Since(polled) <= 3*r.config.PollInterval {
+		available = 1
+	}
+
+	_, err := fmt.Fprintf(w, "# HELP db_replica_available Whether the replica answered its last polls\n"+
+		"# TYPE db_replica_available gauge\n"+
+		"db_replica_available %d\n"+
+		"# HELP db_replica_reads_total Reads of GET requests served by the replica\n"+
+		"# TYPE db_replica_reads_total counter\n"+
+		"db_replica_reads_total %d\n"+
+		"# HELP db_replica_primary_reads_total Reads of GET requests sent to the primary, the replica was behind their token or unavailable\n"+
+		"# TYPE db_replica_primary_reads_total counter\n"+
+		"db_replica_primary_reads_total %d\n"+
+		"# HELP db_consistency_tokens_issued_total Consistency tokens returned after writes\n"+
+		"# TYPE db_consistency_tokens_issued_total counter\n"+
+		"db_consistency_tokens_issued_total %d\n",
+		available, r.replicaReads.Load(), r.primaryReads.Load(), r.issued.Load())
+	return err
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/filtering"
+)
+
+// CompileFilter turns a parsed filter into a parameterized SQL predicate. Field names are
+// resolved through columns, so only columns explicitly mapped by the adapter can appear in SQL;
+// every literal becomes a positional argument starting at $firstArg.
+func CompileFilter(expr filtering.Expr, columns map[string]string, firstArg int) (string, []any, error) {
+	if expr == nil {
+		return "TRUE", nil, nil
+	}
+
+	compiler := &filterCompiler{columns: columns, nextArg: firstArg}
+	predicate, err := compiler.compile(expr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return predicate, compiler.args, nil
+}
+
+type filterCompiler struct {
+	columns map[string]string
+	args    []any
+	nextArg int
+}
+
+func (c *filterCompiler) compile(expr filtering.Expr) (string, error) {
+	switch node := expr.(type) {
+	case filtering.Comparison:
+		column, ok := c.columns[node.Field]
+		if !ok {
+			return "", appErrors.NewInfrastructureError(fmt.Sprintf("filter field %s has no column mapping", node.Field), nil)
+		}
+
+		operator := string(node.Operator)
+		if node.Operator == filtering.Equal {
+			operator = "="
+		}
+
+		placeholder := fmt.Sprintf("$%d", c.nextArg)
+		c.nextArg++
+		c.args = append(c.args, node.Value)
+
+		return fmt.Sprintf("%s %s %s", column, operator, placeholder), nil
+	case filtering.Logical:
+		left, err := c.compile(node.Left)
+		if err != nil {
+			return "", err
+		}
+		right, err := c.compile(node.Right)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s %s %s)", left, strings.ToUpper(string(node.Operator)), right), nil
+	case filtering.Not:
+		inner, err := c.compile(node.Expr)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(NOT %s)", inner), nil
+	default:
+		return "", appErrors.NewInfrastructureError(fmt.Sprintf("unsupported filter node %T", expr), nil)
+	}
+}
@@ -0,0 +1,214 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+type WriteBehindConfig struct {
+	// FlushInterval is the longest a write waits in memory before it is saved
+	FlushInterval time.Duration
+	// BatchSize saves the pending writes as soon as that many are waiting
+	BatchSize int
+	// MaxPending bounds the writes kept in memory, those of failed flushes included. Writes over it are
+	// dropped, the endpoint writing them stays fast while the database is down.
+	MaxPending int
+	// Attempts is the number of flushes a write is tried in before it is dropped
+	Attempts int
+}
+
+// DefaultWriteBehindConfig loses at most a second of writes when the instance crashes
+var DefaultWriteBehindConfig = WriteBehindConfig{FlushInterval: time.Second, BatchSize: 500, MaxPending: 10000, Attempts: 3}
+
+// BackgroundWriter saves buffered writes until ctx is cancelled, with a last flush after it
+type BackgroundWriter interface {
+	Run(ctx context.Context)
+	WriteMetrics(w io.Writer) error
+}
+
+// finalWriteBehindTimeout bounds the flush on shutdown, after ctx is cancelled
+const finalWriteBehindTimeout = 5 * time.Second
+
+// WriteBehind buffers writes that can be late or lost, such as read receipts, and saves them in
+// batches in the background. Writes with the same key are merged while they wait, so a burst on the
+// same row is saved once. The writes of a crash are lost, those of a shutdown are flushed.
+type WriteBehind[K comparable, V any] struct {
+	name   string
+	config WriteBehindConfig
+	merge  func(pending V, write V) V
+	save   func(ctx context.Context, writes []V) error
+	full   chan struct{}
+
+	mu       sync.Mutex
+	pending  map[K]*pendingWrite[V]
+	queued   int64
+	merged   int64
+	saved    int64
+	dropped  int64
+	failures int64
+}
+
+type pendingWrite[V any] struct {
+	value    V
+	attempts int
+}
+
+// NewWriteBehind buffers writes saved with save, merge combines a write with the pending one of the
+// same key. name labels the metrics.
+func NewWriteBehind[K comparable, V any](name string, config WriteBehindConfig, merge func(pending V, write V) V,
+	save func(ctx context.Context, writes []V) error) *WriteBehind[K, V] {
+	return &WriteBehind[K, V]{
+		name:    name,
+		config:  config,
+		merge:   merge,
+		save:    save,
+		full:    make(chan struct{}, 1),
+		pending: make(map[K]*pendingWrite[V]),
+	}
+}
+
+// Write queues value under key and returns right away. It reports false when the buffer is full and
+// the write was dropped.
+func (w *WriteBehind[K, V]) Write(key K, value V) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if pending, ok := w.pending[key]; ok {
+		pending.value = w.merge(pending.value, value)
+		w.merged++
+		return true
+	}
+	if len(w.pending) >= w.config.MaxPending {
+		w.dropped++
+		return false
+	}
+	w.pending[key] = &pendingWrite[V]{value: value}
+	w.queued++
+	if len(w.pending) >= w.config.BatchSize {
+		select {
+		case w.full <- struct{}{}:
+		default:
+		}
+	}
+	return true
+}
+
+// Pending returns the writes waiting under the keys match accepts, readers overlay them on what is
+// saved to see their own writes
+func (w *WriteBehind[K, V]) Pending(match func(key K) bool) []V {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var values []V
+	for key, pending := range w.pending {
+		if match(key) {
+			values = append(values, pending.value)
+		}
+	}
+	return values
+}
+
+// Run flushes every flush interval and whenever a batch is full
+func (w *WriteBehind[K, V]) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), finalWriteBehindTimeout)
+			w.Flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+		case <-w.full:
+		}
+		w.Flush(ctx)
+	}
+}
+
+// Flush saves the pending writes in batches. Writes of a failed batch are kept for the next flush
+// until they run out of attempts, writes queued meanwhile under the same key are merged into them.
+func (w *WriteBehind[K, V]) Flush(ctx context.Context) {
+	w.mu.Lock()
+	drained := w.pending
+	w.pending = make(map[K]*pendingWrite[V])
+	w.mu.Unlock()
+
+	keys := make([]K, 0, len(drained))
+	for key := range drained {
+		keys = append(keys, key)
+	}
+	for start := 0; start < len(keys); start += w.config.BatchSize {
+		batch := keys[start:min(start+w.config.BatchSize, len(keys))]
+		values := make([]V, len(batch))
+		for i, key := range batch {
+			values[i] = drained[key].value
+		}
+
+		if err := w.save(ctx, values); err != nil {
+			log.Printf("Failed to save %d %s, retrying on the next flush: %v", len(batch), w.name, err)
+			w.restore(batch, drained)
+			continue
+		}
+		w.mu.Lock()
+		w.saved += int64(len(batch))
+		w.mu.Unlock()
+	}
+}
+
+func (w *WriteBehind[K, V]) restore(batch []K, drained map[K]*pendingWrite[V]) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.failures++
+	for _, key := range batch {
+		failed := drained[key]
+		failed.attempts++
+		if failed.attempts >= w.config.Attempts {
+			w.dropped++
+			continue
+		}
+		if pending, ok := w.pending[key]; ok {
+			pending.value = w.merge(failed.value, pending.value)
+			pending.attempts = failed.attempts
+			continue
+		}
+		if len(w.pending) >= w.config.MaxPending {
+			w.dropped++
+			continue
+		}
+		w.pending[key] = failed
+	}
+}
+
+// WriteMetrics writes the counters in the Prometheus text format
+func (w *WriteBehind[K, V]) WriteMetrics(out io.Writer) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := fmt.Fprintf(out, "# HELP write_behind_pending Writes waiting to be saved\n"+
+		"# TYPE write_behind_pending gauge\n"+
+		"write_behind_pending{writes=%q} %d\n"+
+		"# HELP write_behind_queued_total Writes queued, merged ones excluded\n"+
+		"# TYPE write_behind_queued_total counter\n"+
+		"write_behind_queued_total{writes=%q} %d\n"+
+		"# HELP write_behind_merged_total Writes merged into a pending write of the same key\n"+
+		"# TYPE write_behind_merged_total counter\n"+
+		"write_behind_merged_total{writes=%q} %d\n"+
+		"# HELP write_behind_saved_total Writes saved\n"+
+		"# TYPE write_behind_saved_total counter\n"+
+		"write_behind_saved_total{writes=%q} %d\n"+
+		"# HELP write_behind_dropped_total Writes lost because the buffer was full or they ran out of attempts\n"+
+		"# TYPE write_behind_dropped_total counter\n"+
+		"write_behind_dropped_total{writes=%q} %d\n"+
+		"# HELP write_behind_failed_flushes_total Batches that failed to save\n"+
+		"# TYPE write_behind_failed_flushes_total counter\n"+
+		"write_behind_failed_flushes_total{writes=%q} %d\n",
+		w.name, len(w.pending), w.name, w.queued, w.name, w.merged, w.name, w.saved, w.name, w.dropped, w.name, w.failures)
+	return err
+}
@@ -0,0 +1,166 @@
+package deprecation
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// Notice describes the deprecation of an endpoint
+type Notice struct {
+	// Since is when the endpoint was deprecated
+	Since time.Time
+	// Sunset is when the endpoint goes away, zero until it is decided
+	Sunset time.Time
+	// Successor is the path of the endpoint to move to, empty when there is none
+	Successor string
+}
+
+// Endpoints maps Huma operation IDs to their deprecation. An endpoint is removed once its sunset
+// passed and deprecated_endpoint_requests_total stopped growing, e.g.
+//
+//	"list-class-grades": {Since: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Successor: "/classes/{class_id}/gradebook"},
+var Endpoints = map[string]Notice{
+	// The routes of the old platform, kept for school integrations until the end of the school year
+	"legacy-create-user":       {Since: legacySince, Sunset: legacySunset, Successor: "/auth/signup/batch"},
+	"legacy-list-users":        {Since: legacySince, Sunset: legacySunset, Successor: "/users"},
+	"legacy-record-grade":      {Since: legacySince, Sunset: legacySunset, Successor: "/classes/{classId}/assignments/{assignmentId}/grades/{studentId}"},
+	"legacy-list-class-grades": {Since: legacySince, Sunset: legacySunset, Successor: "/classes/{classId}/grades"},
+}
+
+var (
+	legacySince  = time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	legacySunset = time.Date(2027, 7, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// Tracker announces deprecations on responses and records who still calls deprecated endpoints
+type Tracker struct {
+	notices  map[string]Notice
+	logEvery time.Duration
+
+	mu     sync.Mutex
+	calls  map[string]int64
+	last   map[string]time.Time
+	logged map[string]time.Time
+}
+
+// NewTracker logs each caller of a deprecated endpoint at most once per logEvery
+func NewTracker(notices map[string]Notice, logEvery time.Duration) *Tracker {
+	return &Tracker{
+		notices:  notices,
+		logEvery: logEvery,
+		calls:    make(map[string]int64),
+		last:     make(map[string]time.Time),
+		logged:   make(map[string]time.Time),
+	}
+}
+
+// Middleware sets the Deprecation (RFC 9745), Sunset (RFC 8594) and successor Link headers on
+// responses of deprecated endpoints. It runs first so rejected calls carry the headers too, and
+// identifies callers by the identity headers since the request is not authorized yet.
+func (t *Tracker) Middleware(ctx huma.Context, next func(huma.Context)) {
+	operationID := ctx.Operation().OperationID
+	notice, deprecated := t.notices[operationID]
+	if !deprecated {
+		next(ctx)
+		return
+	}
+
+	ctx.SetHeader("Deprecation", fmt.Sprintf("@%d", notice.Since.Unix()))
+	if !notice.Sunset.IsZero() {
+		ctx.SetHeader("Sunset", notice.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if notice.Successor != "" {
+		ctx.SetHeader("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", notice.Successor))
+	}
+
+	meta := requestmeta.FromHuma(ctx)
+	caller := fmt.Sprintf("user=%s tenant=%s ip=%s client=%s agent=%q", meta.UserID, meta.TenantID,
+		utils.ClientIPFromContext(ctx.Context()), meta.ClientVersion, ctx.Header("User-Agent"))
+	t.record(operationID, caller, time.Now())
+
+	next(ctx)
+}
+
+func (t *Tracker) record(operationID string, caller string, now time.Time) {
+	t.mu.Lock()
+	t.calls[operationID]++
+	t.last[operationID] = now
+	key := operationID + " " + caller
+	shouldLog := now.Sub(t.logged[key]) >= t.logEvery
+	if shouldLog {
+		t.logged[key] = now
+	}
+	t.mu.Unlock()
+
+	if shouldLog {
+		log.Printf("Deprecated endpoint %s called by %s", operationID, caller)
+	}
+}
+
+// DocumentOperation marks deprecated operations in the OpenAPI spec, with their sunset and
+// successor in the description
+func (t *Tracker) DocumentOperation(oapi *huma.OpenAPI, op *huma.Operation) {
+	notice, deprecated := t.notices[op.OperationID]
+	if !deprecated {
+		return
+	}
+
+	op.Deprecated = true
+	note := "Deprecated since " + notice.Since.Format(time.DateOnly)
+	if !notice.Sunset.IsZero() {
+		note += ", removed on " + notice.Sunset.Format(time.DateOnly)
+	}
+	if notice.Successor != "" {
+		note += ", use " + notice.Successor + " instead"
+	}
+	if op.Description != "" {
+		note = op.Description + "\n\n" + note
+	}
+	op.Description = note + "."
+}
+
+// WriteMetrics writes the calls of every deprecated endpoint and when it was last called in the
+// Prometheus text format, endpoints nobody called report 0
+func (t *Tracker) WriteMetrics(w io.Writer) error {
+	operationIDs := make([]string, 0, len(t.notices))
+	for operationID := range t.notices {
+		operationIDs = append(operationIDs, operationID)
+	}
+	sort.Strings(operationIDs)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, err := fmt.Fprint(w, "# HELP deprecated_endpoint_requests_total Requests to deprecated endpoints\n"+
+		"# TYPE deprecated_endpoint_requests_total counter\n"); err != nil {
+		return err
+	}
+	for _, operationID := range operationIDs {
+		if _, err := fmt.Fprintf(w, "deprecated_endpoint_requests_total{operation=%q} %d\n", operationID, t.calls[operationID]); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprint(w, "# HELP deprecated_endpoint_last_request_timestamp_seconds Last request to a deprecated endpoint\n"+
+		"# TYPE deprecated_endpoint_last_request_timestamp_seconds gauge\n"); err != nil {
+		return err
+	}
+	for _, operationID := range operationIDs {
+		if last, called := t.last[operationID]; called {
+			if _, err := fmt.Fprintf(w, "deprecated_endpoint_last_request_timestamp_seconds{operation=%q} %d\n", operationID, last.Unix()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package diagnostics
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Redacted replaces the secrets of a configuration snapshot, secrets that are not set stay empty so
+// the snapshot still tells which ones are
+const Redacted = "[REDACTED]"
+
+// secretFieldNames are the parts of field names that hold secrets, webhook URLs such as the Slack
+// ones carry their credential in the path
+var secretFieldNames = []string{"secret", "token", "password", "key", "credential", "private", "dsn", "webhook"}
+
+// RedactConfig snapshots a configuration struct as a map of its exported fields, nested structs
+// included. Values of fields named like secrets are redacted, and so are the passwords of URLs.
+func RedactConfig(config any) map[string]any {
+	value := reflect.Indirect(reflect.ValueOf(config))
+	if value.Kind() != reflect.Struct {
+		return map[string]any{}
+	}
+	return snapshotStruct(value)
+}
+
+func snapshotStruct(value reflect.Value) map[string]any {
+	snapshot := make(map[string]any, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		snapshot[field.Name] = snapshotValue(value.Field(i), isSecretField(field.Name))
+	}
+	return snapshot
+}
+
+func snapshotValue(value reflect.Value, secret bool) any {
+	if duration, ok := value.Interface().(time.Duration); ok {
+		return duration.String()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return redactString(value.String(), secret)
+	case reflect.Struct:
+		return snapshotStruct(value)
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return snapshotValue(value.Elem(), secret)
+	case reflect.Slice, reflect.Array:
+		items := make([]any, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, snapshotValue(value.Index(i), secret))
+		}
+		return items
+	case reflect.Map:
+		entries := make(map[string]any, value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			entries[fmt.Sprint(iterator.Key().Interface())] = snapshotValue(iterator.Value(), secret)
+		}
+		return entries
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil
+	default:
+		if secret {
+			return Redacted
+		}
+		return value.Interface()
+	}
+}
+
+func redactString(value string, secret bool) string {
+	if value == "" {
+		return ""
+	}
+	if secret {
+		return Redacted
+	}
+	if parsed, err := url.Parse(value); err == nil && parsed.User != nil {
+		if password, hasPassword := parsed.User.Password(); hasPassword {
+			return strings.Replace(value, ":"+password+"@", ":"+Redacted+"@", 1)
+		}
+	}
+	return value
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range secretFieldNames {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package diagnostics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+
+	"github.com/cockroachdb/errors"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// maxFrames bounds the stack frames kept per cause, the frames closest to the failure are kept
+const maxFrames = 32
+
+// StackFrame is a call frame of a cause, innermost first
+type StackFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// Cause is one error of a chain, from the application error down to the root cause
+type Cause struct {
+	Type    string       `json:"type"`
+	Code    string       `json:"code,omitempty"`
+	Message string       `json:"message,omitempty"`
+	Frames  []StackFrame `json:"frames,omitempty"`
+}
+
+// RecordedError is a failed request with the chain of its error
+type RecordedError struct {
+	OccurredAt  time.Time `json:"occurred_at"`
+	OperationID string    `json:"operation_id"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	TenantID    string    `json:"tenant_id,omitempty"`
+	Status      int       `json:"status"`
+	Chain       []Cause   `json:"chain"`
+}
+
+// ErrorRecorder keeps the last server errors of the instance in memory so on-call engineers can
+// read their cause chains and stack traces without shipping logs. Entries hold error messages,
+// which may carry user data, so the endpoint is only served with a token.
+type ErrorRecorder struct {
+	mu       sync.Mutex
+	entries  []RecordedError
+	next     int
+	capacity int
+}
+
+func NewErrorRecorder(capacity int) *ErrorRecorder {
+	return &ErrorRecorder{
+		entries:  make([]RecordedError, 0, capacity),
+		capacity: capacity,
+	}
+}
+
+// Transformer is a Huma transformer that records the 5xx errors returned by handlers, the
+// response is left unchanged
+func (r *ErrorRecorder) Transformer(ctx huma.Context, status string, v any) (any, error) {
+	statusErr, ok := v.(huma.StatusError)
+	if !ok || statusErr.GetStatus() < http.StatusInternalServerError {
+		return v, nil
+	}
+	cause := errors.UnwrapOnce(statusErr)
+	if cause == nil {
+		return v, nil
+	}
+
+	operationID := ""
+	if operation := ctx.Operation(); operation != nil {
+		operationID = operation.OperationID
+	}
+	r.Record(RecordedError{
+		OccurredAt:  time.Now().UTC(),
+		OperationID: operationID,
+		Method:      ctx.Method(),
+		Path:        ctx.URL().Path,
+		TenantID:    requestmeta.FromHuma(ctx).TenantID,
+		Status:      statusErr.GetStatus(),
+		Chain:       CauseChain(cause),
+	})
+	return v, nil
+}
+
+// Record adds an entry, overwriting the oldest one once the buffer is full
+func (r *ErrorRecorder) Record(entry RecordedError) {
+	if r.capacity <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.entries) < r.capacity {
+		r.entries = append(r.entries, entry)
+		return
+	}
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % r.capacity
+}
+
+// Recent returns up to limit entries, newest first. A limit of 0 returns every entry.
+func (r *ErrorRecorder) Recent(limit int) []RecordedError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := len(r.entries)
+	if limit <= 0 || limit > count {
+		limit = count
+	}
+	recent := make([]RecordedError, 0, limit)
+	for i := 0; i < limit; i++ {
+		// The newest entry is right before next once the buffer wrapped, last otherwise
+		recent = append(recent, r.entries[(r.next+count-1-i)%count])
+	}
+	return recent
+}
+
+// Handler serves the recent errors to requests bearing token, ?limit= bounds the entries
+func (r *ErrorRecorder) Handler(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !bearsToken(c, token) {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		c.JSON(http.StatusOK, gin.H{"errors": r.Recent(limit)})
+	}
+}
+
+// CauseChain unwraps err down to its root cause. Every cause carries its own message without the
+// messages of the causes below it, and the stack trace captured where it was created. Wrappers
+// that only add a stack trace are folded into the cause they wrap.
+func CauseChain(err error) []Cause {
+	var chain []Cause
+	var pendingFrames []StackFrame
+	for current := err; current != nil; current = errors.UnwrapOnce(current) {
+		cause := Cause{
+			Type:    fmt.Sprintf("%T", current),
+			Message: ownMessage(current),
+			Frames:  stackFrames(current),
+		}
+		appErr, isAppErr := current.(appErrors.ApplicationError)
+		if isAppErr {
+			cause.Code = appErr.GetCode()
+			cause.Message = appErr.GetMessage()
+		}
+		if cause.Message == "" && !isAppErr && errors.UnwrapOnce(current) != nil {
+			pendingFrames = cause.Frames
+			continue
+		}
+		if cause.Frames == nil {
+			cause.Frames = pendingFrames
+		}
+		pendingFrames = nil
+		chain = append(chain, cause)
+	}
+	return chain
+}
+
+// ownMessage strips the message of the wrapped error that wrappers append to theirs
+func ownMessage(err error) string {
+	message := err.Error()
+	wrapped := errors.UnwrapOnce(err)
+	if wrapped == nil {
+		return message
+	}
+	if message == wrapped.Error() {
+		return ""
+	}
+	return strings.TrimSuffix(message, ": "+wrapped.Error())
+}
+
+func stackFrames(err error) []StackFrame {
+	trace := errors.GetReportableStackTrace(err)
+	if trace == nil {
+		return nil
+	}
+
+	// Reportable traces list the outermost call first
+	frames := make([]StackFrame, 0, min(len(trace.Frames), maxFrames))
+	for i := len(trace.Frames) - 1; i >= 0 && len(frames) < maxFrames; i-- {
+		frame := trace.Frames[i]
+		function := frame.Function
+		if frame.Module != "" {
+			function = frame.Module + "." + function
+		}
+		frames = append(frames, StackFrame{Function: function, File: frame.AbsPath, Line: frame.Lineno})
+	}
+	return frames
+}
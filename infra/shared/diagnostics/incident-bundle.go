@@ -0,0 +1,250 @@
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/slo"
+)
+
+// BundleCooldown is how long an instance waits after capturing a bundle before it captures another,
+// an alert that keeps firing is described by its first bundle
+const BundleCooldown = 30 * time.Minute
+
+// BundleStore keeps the bundles, the warehouse sinks write them to S3 or a directory
+type BundleStore interface {
+	Put(ctx context.Context, key string, contentType string, content []byte) error
+}
+
+// BundleSources read the state of the instance a bundle is made of, sources left nil are skipped
+type BundleSources struct {
+	// Instance names the pod or host the bundle comes from
+	Instance string
+	Errors   *ErrorRecorder
+	// Pools returns the statistics of the connection pools
+	Pools func() any
+	// Authorization returns what the authorization service has loaded, the hash of the policy file
+	// included
+	Authorization func(ctx context.Context) (any, error)
+	// Config is the configuration of the instance with its secrets redacted, see RedactConfig
+	Config map[string]any
+}
+
+// Bundle is the state of an instance when an alert fired, for the on-call engineer to read after the
+// instance is gone
+type Bundle struct {
+	CapturedAt    time.Time       `json:"captured_at"`
+	Instance      string          `json:"instance,omitempty"`
+	Reason        string          `json:"reason"`
+	Errors        []RecordedError `json:"recent_errors"`
+	Goroutines    int             `json:"goroutines"`
+	GoroutineDump string          `json:"goroutine_dump"`
+	Pools         any             `json:"pools,omitempty"`
+	Authorization any             `json:"authorization,omitempty"`
+	// AuthorizationError is why the authorization service could not be read
+	AuthorizationError string         `json:"authorization_error,omitempty"`
+	Config             map[string]any `json:"config,omitempty"`
+}
+
+// Capture reads every source into a bundle
+func (s BundleSources) Capture(ctx context.Context, reason string, now time.Time) *Bundle {
+	bundle := &Bundle{
+		CapturedAt: now.UTC(),
+		Instance:   s.Instance,
+		Reason:     reason,
+		Errors:     []RecordedError{},
+		Goroutines: runtime.NumGoroutine(),
+		Config:     s.Config,
+	}
+	if s.Errors != nil {
+		bundle.Errors = s.Errors.Recent(0)
+	}
+
+	var dump bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&dump, 2); err != nil {
+		dump.WriteString("goroutine dump failed: " + err.Error())
+	}
+	bundle.GoroutineDump = dump.String()
+
+	if s.Pools != nil {
+		bundle.Pools = s.Pools()
+	}
+	if s.Authorization != nil {
+		authorization, err := s.Authorization(ctx)
+		if err != nil {
+			bundle.AuthorizationError = err.Error()
+		} else {
+			bundle.Authorization = authorization
+		}
+	}
+	return bundle
+}
+
+// IncidentAlert is the payload posted to the incident webhook when an alert fires
+type IncidentAlert struct {
+	Alert      string    `json:"alert"`
+	Status     string    `json:"status"`
+	Objectives []string  `json:"objectives"`
+	Instance   string    `json:"instance,omitempty"`
+	FiredAt    time.Time `json:"fired_at"`
+	// BundleURL is where the bundle of the instance was stored, BundleError why it was not
+	BundleURL   string `json:"bundle_url,omitempty"`
+	BundleError string `json:"bundle_error,omitempty"`
+}
+
+// IncidentBundler captures a bundle when the error rate of the instance spikes: a page alert on an
+// availability objective starts firing. The bundle is stored and linked in the alert, which is
+// logged and posted to the webhook when one is set.
+type IncidentBundler struct {
+	sources  BundleSources
+	store    BundleStore
+	location func(key string) string
+	webhook  string
+	client   *http.Client
+
+	mu       sync.Mutex
+	firing   []string
+	captured time.Time
+}
+
+// NewIncidentBundler stores bundles in store, location turns the key of a bundle into the link put
+// in the alert
+func NewIncidentBundler(sources BundleSources, store BundleStore, location func(key string) string, webhook string) *IncidentBundler {
+	return &IncidentBundler{
+		sources:  sources,
+		store:    store,
+		location: location,
+		webhook:  webhook,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Watch checks the objectives every interval until ctx is cancelled
+func (b *IncidentBundler) Watch(ctx context.Context, report func() slo.Report, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if alert := b.Check(ctx, report(), time.Now()); alert != nil {
+				log.Printf("ALERT error rate spike on %v (%s), incident bundle: %s", alert.Objectives, alert.Status, bundleLink(alert))
+			}
+		}
+	}
+}
+
+func bundleLink(alert *IncidentAlert) string {
+	if alert.BundleURL != "" {
+		return alert.BundleURL
+	}
+	return "not stored, " + alert.BundleError
+}
+
+// Check captures and stores a bundle when an availability objective starts firing a page alert, and
+// returns the alert it sent. Objectives that keep firing, and new ones within BundleCooldown of the
+// last bundle, send nothing.
+func (b *IncidentBundler) Check(ctx context.Context, report slo.Report, now time.Time) *IncidentAlert {
+	var firing []string
+	for _, objective := range report.Objectives {
+		if objective.Kind != slo.KindAvailability {
+			continue
+		}
+		for _, alert := range objective.Alerts {
+			if alert.Severity == slo.SeverityPage {
+				firing = append(firing, objective.Name)
+				break
+			}
+		}
+	}
+
+	b.mu.Lock()
+	started := false
+	for _, name := range firing {
+		if !slices.Contains(b.firing, name) {
+			started = true
+		}
+	}
+	b.firing = firing
+	if !started || (!b.captured.IsZero() && now.Sub(b.captured) < BundleCooldown) {
+		b.mu.Unlock()
+		return nil
+	}
+	b.captured = now
+	b.mu.Unlock()
+
+	alert := &IncidentAlert{
+		Alert:      "error_rate_spike",
+		Status:     report.Status,
+		Objectives: firing,
+		Instance:   b.sources.Instance,
+		FiredAt:    now.UTC(),
+	}
+	reason := fmt.Sprintf("page alert firing on %v", firing)
+	if url, err := b.save(ctx, b.sources.Capture(ctx, reason, now)); err != nil {
+		alert.BundleError = err.Error()
+	} else {
+		alert.BundleURL = url
+	}
+
+	if b.webhook != "" {
+		if err := b.post(ctx, alert); err != nil {
+			log.Printf("Failed to post incident alert: %v", err)
+		}
+	}
+	return alert
+}
+
+func (b *IncidentBundler) save(ctx context.Context, bundle *Bundle) (string, error) {
+	content, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	instance := bundle.Instance
+	if instance == "" {
+		instance = "instance"
+	}
+	key := fmt.Sprintf("incidents/%s/%s-%s.json", bundle.CapturedAt.Format("2006-01-02"), bundle.CapturedAt.Format("150405"), instance)
+	if err := b.store.Put(ctx, key, "application/json", content); err != nil {
+		return "", err
+	}
+	return b.location(key), nil
+}
+
+func (b *IncidentBundler) post(ctx context.Context, alert *IncidentAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, b.webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := b.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("incident webhook answered %d: %s", response.StatusCode, message)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package diagnostics
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireToken rejects requests that do not bear token with a 401, debug endpoints serve instance
+// internals and are never open
+func RequireToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !bearsToken(c, token) {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
+
+func bearsToken(c *gin.Context, token string) bool {
+	bearer, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	return found && subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1
+}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// utf8BOM makes spreadsheet tools detect the encoding of the exported file
+const utf8BOM = "\uFEFF"
+
+// CSVStream writes a cursor based list as CSV one page at a time. Each page is flushed before the
+// next one is fetched, so a slow client applies backpressure to the underlying queries.
+type CSVStream[T any] struct {
+	Filename  string
+	Header    []string
+	ToRow     func(T) []string
+	FirstPage *pagination.Page[T]
+	NextPage  func(cursor string) (*pagination.Page[T], error)
+}
+
+// Response returns the streaming response. FirstPage must be fetched by the handler beforehand so
+// errors before the first byte can still be reported with the standard error envelope.
+func (s CSVStream[T]) Response() *huma.StreamResponse {
+	return &huma.StreamResponse{
+		Body: func(ctx huma.Context) {
+			ctx.SetHeader("Content-Type", "text/csv; charset=utf-8")
+			ctx.SetHeader("Content-Disposition", ContentDisposition(s.Filename))
+			ctx.SetHeader("Cache-Control", "no-store")
+			ctx.SetStatus(http.StatusOK)
+
+			if err := s.write(ctx); err != nil {
+				// Headers are already sent, the truncated file is the only signal left for the client
+				log.Printf("csv export %s aborted: %v", s.Filename, err)
+			}
+		},
+	}
+}
+
+func (s CSVStream[T]) write(ctx huma.Context) error {
+	body := ctx.BodyWriter()
+	flusher, _ := body.(http.Flusher)
+
+	if _, err := body.Write([]byte(utf8BOM)); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(body)
+	if err := writer.Write(s.Header); err != nil {
+		return err
+	}
+
+	page := s.FirstPage
+	for {
+		for _, item := range page.Items {
+			if err := writer.Write(sanitizeRow(s.ToRow(item))); err != nil {
+				return err
+			}
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+
+		if err := ctx.Context().Err(); err != nil {
+			return err
+		}
+
+		next, err := s.NextPage(page.NextCursor)
+		if err != nil {
+			return err
+		}
+		page = next
+	}
+}
+
+// sanitizeRow neutralizes values that spreadsheet tools would evaluate as formulas
+func sanitizeRow(row []string) []string {
+	for i, value := range row {
+		if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+			row[i] = "'" + value
+		}
+	}
+	return row
+}
+
+// ContentDisposition is the header of a download named filename. Clients without RFC 6266 support
+// read the ASCII fallback, where quotes, backslashes and other characters are replaced.
+func ContentDisposition(filename string) string {
+	asciiName := strings.Map(func(r rune) rune {
+		if r > 127 || r == '"' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, filename)
+
+	return fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, asciiName, url.PathEscape(filename))
+}
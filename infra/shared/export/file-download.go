@@ -0,0 +1,133 @@
+package export
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// RangeRequest are the headers a client resumes an interrupted download with. Download requests
+// embed it.
+type RangeRequest struct {
+	Range   string `header:"Range" doc:"Single byte range to resume a download from, such as bytes=1048576-"`
+	IfRange string `header:"If-Range" doc:"ETag of the partial download, the whole file is sent when it changed since"`
+}
+
+// FileDownload serves a file whole, or the byte range a client resumes an interrupted download from.
+// Writes are paced by the download limits of the request.
+type FileDownload struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Response returns the streaming response to the range request. Ranges that cannot be parsed, more
+// than one range, and ranges of a file whose ETag changed get the whole file, as RFC 9110 allows.
+func (d FileDownload) Response(request RangeRequest) *huma.StreamResponse {
+	return &huma.StreamResponse{
+		Body: func(ctx huma.Context) {
+			size := int64(len(d.Content))
+			etag := d.etag()
+			ctx.SetHeader("Accept-Ranges", "bytes")
+			ctx.SetHeader("ETag", etag)
+
+			start, end, ranged := parseRange(request.Range, size)
+			if request.IfRange != "" && request.IfRange != etag {
+				ranged = false
+			}
+			if ranged && start >= size {
+				ctx.SetHeader("Content-Range", fmt.Sprintf("bytes */%d", size))
+				utils.WriteApplicationError(ctx, appErrors.NewRangeNotSatisfiableError(request.Range, size))
+				return
+			}
+
+			ctx.SetHeader("Content-Type", d.ContentType)
+			ctx.SetHeader("Content-Disposition", ContentDisposition(d.Filename))
+			status := http.StatusOK
+			if ranged {
+				status = http.StatusPartialContent
+				ctx.SetHeader("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+			} else {
+				start, end = 0, size-1
+			}
+			ctx.SetHeader("Content-Length", strconv.FormatInt(end-start+1, 10))
+			ctx.SetStatus(status)
+
+			body := ratelimit.DownloadWriter(ctx.Context(), ctx.BodyWriter())
+			if _, err := io.Copy(body, bytes.NewReader(d.Content[start:end+1])); err != nil {
+				// Headers are already sent, the client resumes from what it received
+				log.Printf("download %s aborted: %v", d.Filename, err)
+			}
+		},
+	}
+}
+
+// AsPreview serves the content as the PNG preview of the file, named after it
+func (d FileDownload) AsPreview() FileDownload {
+	d.Filename = strings.TrimSuffix(d.Filename, path.Ext(d.Filename)) + ".png"
+	d.ContentType = storage.PreviewContentType
+	return d
+}
+
+// AsAlternative serves the content as the captions or transcript of the file, named after it
+func (d FileDownload) AsAlternative(format storage.AlternativeFormat) FileDownload {
+	d.Filename = strings.TrimSuffix(d.Filename, path.Ext(d.Filename)) + format.Extension()
+	d.ContentType = format.ContentType()
+	return d
+}
+
+// etag identifies the content, a resumed download only gets a range of the same file
+func (d FileDownload) etag() string {
+	sum := sha256.Sum256(d.Content)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// parseRange returns the first and last byte of a single range, ranged is false when the header is
+// absent or not a single byte range. A start past the end is returned as is, it is not satisfiable.
+func parseRange(header string, size int64) (start int64, end int64, ranged bool) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, 0, false
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return 0, 0, false
+	}
+
+	if first == "" {
+		// A suffix range, the last bytes of the file
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix <= 0 {
+			return 0, 0, false
+		}
+		return max(size-suffix, 0), size - 1, size > 0
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, false
+	}
+	end = size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, false
+		}
+		end = min(end, size-1)
+	}
+	return start, end, true
+}
@@ -0,0 +1,179 @@
+package grpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+	"github.com/nahualventure/class-backend/infra/shared/internalauth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// maxAttempts is the most attempts gRPC makes of a call, higher retry policies are capped to it
+const maxAttempts = 5
+
+// Config is how peer services are found and called
+type Config struct {
+	// Peers maps services to their gRPC target, such as grades=dns:///grades.internal:8080
+	Peers map[string]string
+	// TargetTemplate is the target of services not in Peers, %s is replaced by the name of the service.
+	// Services must be listed in Peers when it is empty.
+	TargetTemplate string
+	TLS            TLSConfig
+	// Timeout is the deadline of calls made without one
+	Timeout time.Duration
+	// MaxAttempts of calls failing with Unavailable, 1 disables retries
+	MaxAttempts int
+}
+
+// Factory opens one client connection per peer service and shares it between callers. Connections
+// are opened on first use, calls made with them carry the trace and identity of the request they
+// are made for.
+type Factory struct {
+	config      Config
+	keys        *internalauth.Keys
+	credentials credentials.TransportCredentials
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// NewFactory checks the TLS files of config at once, so a service with broken certificates fails
+// on startup rather than on its first call. keys vouch for the callers, nil sends calls without an
+// identity token.
+func NewFactory(config Config, keys *internalauth.Keys) (*Factory, error) {
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	config.MaxAttempts = min(max(config.MaxAttempts, 1), maxAttempts)
+
+	transport := insecure.NewCredentials()
+	if config.TLS.Enabled() {
+		var err error
+		if transport, err = config.TLS.ClientCredentials(); err != nil {
+			return nil, err
+		}
+	}
+	return &Factory{
+		config:      config,
+		keys:        keys,
+		credentials: transport,
+		conns:       make(map[string]*grpc.ClientConn),
+	}, nil
+}
+
+// Target returns the gRPC target of service
+func (f *Factory) Target(service string) (string, error) {
+	if target, ok := f.config.Peers[service]; ok {
+		return target, nil
+	}
+	if f.config.TargetTemplate == "" {
+		return "", fmt.Errorf("no gRPC target configured for service %q", service)
+	}
+	return strings.ReplaceAll(f.config.TargetTemplate, "%s", service), nil
+}
+
+// Conn returns the client connection to service, callers wrap it in the generated client of the
+// service and never close it
+func (f *Factory) Conn(service string) (*grpc.ClientConn, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if conn, ok := f.conns[service]; ok {
+		return conn, nil
+	}
+
+	target, err := f.Target(service)
+	if err != nil {
+		return nil, err
+	}
+	serviceConfig, err := f.serviceConfig()
+	if err != nil {
+		return nil, err
+	}
+	interceptors := []grpc.UnaryClientInterceptor{f.deadline, cost.UnaryClientInterceptor("grpc:" + service)}
+	if f.keys != nil {
+		interceptors = append(interceptors, f.keys.UnaryClientInterceptor(service))
+	}
+	// Dialing does not wait for the peer, the connection is made and kept up in the background
+	conn, err := grpc.Dial(target,
+		grpc.WithTransportCredentials(f.credentials),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a connection to %s at %s: %w", service, target, err)
+	}
+	f.conns[service] = conn
+	return conn, nil
+}
+
+// Close closes every connection, on shutdown
+func (f *Factory) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var errs []error
+	for service, conn := range f.conns {
+		errs = append(errs, conn.Close())
+		delete(f.conns, service)
+	}
+	return errors.Join(errs...)
+}
+
+// deadline gives calls made without a deadline the timeout of the config, so no call waits on a
+// peer forever
+func (f *Factory) deadline(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.config.Timeout)
+		defer cancel()
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+type methodConfig struct {
+	Name        []struct{}   `json:"name"`
+	RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type serviceConfig struct {
+	LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig"`
+	MethodConfig        []methodConfig        `json:"methodConfig"`
+}
+
+// serviceConfig spreads calls over every address the target resolves to and retries the calls
+// failing with Unavailable, which the peer refused before running them. Other failures are not
+// retried, the call may have had effects.
+func (f *Factory) serviceConfig() (string, error) {
+	method := methodConfig{Name: []struct{}{{}}}
+	if f.config.MaxAttempts > 1 {
+		method.RetryPolicy = &retryPolicy{
+			MaxAttempts:          f.config.MaxAttempts,
+			InitialBackoff:       "0.1s",
+			MaxBackoff:           "1s",
+			BackoffMultiplier:    2,
+			RetryableStatusCodes: []string{"UNAVAILABLE"},
+		}
+	}
+	raw, err := json.Marshal(serviceConfig{
+		LoadBalancingConfig: []map[string]struct{}{{"round_robin": {}}},
+		MethodConfig:        []methodConfig{method},
+	})
+	return string(raw), err
+}
@@ -0,0 +1,77 @@
+package grpcclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSConfig are the files of the mutual TLS between services. Every service presents its
+// certificate and only believes peers whose certificate is signed by the CA.
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// CAFile signs the certificates of every service
+	CAFile string
+	// ServerName replaces the host of the target when checking the certificate of peers, for
+	// certificates issued to one name shared by every service
+	ServerName string
+}
+
+// Enabled reports whether mutual TLS is configured, calls are in plain text otherwise
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CAFile != ""
+}
+
+// load reads the certificate of the service and the CA, refusing half configured TLS so no service
+// falls back to plain text by mistake
+func (c TLSConfig) load() (tls.Certificate, *x509.CertPool, error) {
+	if c.CertFile == "" || c.KeyFile == "" || c.CAFile == "" {
+		return tls.Certificate{}, nil, fmt.Errorf("mutual TLS needs a certificate, its key and a CA")
+	}
+	certificate, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to load the certificate of the service: %w", err)
+	}
+	ca, err := os.ReadFile(c.CAFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to read the CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return tls.Certificate{}, nil, fmt.Errorf("no certificate found in %s", c.CAFile)
+	}
+	return certificate, pool, nil
+}
+
+// ClientCredentials are the credentials calls to peers are made with
+func (c TLSConfig) ClientCredentials() (credentials.TransportCredentials, error) {
+	certificate, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		RootCAs:      pool,
+		ServerName:   c.ServerName,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// ServerCredentials are the credentials the gRPC server accepts peers with, calls without a
+// certificate signed by the CA are refused during the handshake
+func (c TLSConfig) ServerCredentials() (credentials.TransportCredentials, error) {
+	certificate, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
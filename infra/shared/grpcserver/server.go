@@ -0,0 +1,85 @@
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/internalauth"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Service is the gRPC service of a module. Its methods are authorized as the Huma operations they
+// mirror, so both transports share the permissions of EndpointMapping and policies.yaml.
+type Service interface {
+	Register(server *grpc.Server)
+	// Operations maps the full method names of the service to their operations
+	Operations() map[string]authorization.GRPCOperation
+}
+
+// Options are how peer services reach the server, the zero value serves plain text calls without
+// identity tokens
+type Options struct {
+	// Keys verify the identity tokens of peers, calls are authorized as the user a token vouches for
+	Keys *internalauth.Keys
+	// Credentials are the mutual TLS of peers, see grpcclient.TLSConfig.ServerCredentials
+	Credentials credentials.TransportCredentials
+}
+
+// New builds the gRPC server of services. Errors leave it as statuses carrying an ErrorDetail,
+// including the ones of the authorization interceptor.
+func New(authzService *authorization.CasbinService, options Options, services ...Service) *grpc.Server {
+	operations := make(map[string]authorization.GRPCOperation)
+	for _, service := range services {
+		for method, operation := range service.Operations() {
+			operations[method] = operation
+		}
+	}
+
+	serverOptions := []grpc.ServerOption{grpc.ChainUnaryInterceptor(
+		utils.GRPCErrorInterceptor,
+		internalauth.UnaryServerInterceptor(options.Keys),
+		authorization.NewGRPCInterceptor(authzService, operations),
+	)}
+	if options.Credentials != nil {
+		serverOptions = append(serverOptions, grpc.Creds(options.Credentials))
+	}
+	server := grpc.NewServer(serverOptions...)
+	for _, service := range services {
+		service.Register(server)
+	}
+	return server
+}
+
+// Serve serves gRPC on listener until ctx is done, a lame-duck worker context, then lets the calls
+// in flight finish within gracePeriod and cancels the rest
+func Serve(ctx context.Context, server *grpc.Server, listener net.Listener, gracePeriod time.Duration) {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		log.Printf("gRPC server stopped with error: %v", err)
+		return
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(gracePeriod):
+		log.Printf("gRPC calls did not finish within %s", gracePeriod)
+		server.Stop()
+	}
+}
@@ -0,0 +1,152 @@
+package internalauth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type verifiedContextKey struct{}
+
+// Verified reports whether the identity of the request was vouched for by a peer service
+func Verified(ctx context.Context) bool {
+	verified, _ := ctx.Value(verifiedContextKey{}).(bool)
+	return verified
+}
+
+// NewMiddleware returns a Huma middleware that believes the identity token of requests sent by peer
+// services. The user, tenant and session it vouches for replace the ones of the headers, and its
+// scopes limit the request as the key of a service account would. Requests without a token are
+// served as sent unless tokens are required.
+func NewMiddleware(keys *Keys) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		token := ctx.Header(IdentityHeader)
+		if token == "" {
+			if keys.Required() {
+				utils.WriteApplicationError(ctx, appErrors.NewUnauthorizedError("Missing internal identity token"))
+				return
+			}
+			next(ctx)
+			return
+		}
+
+		claims, err := keys.Verify(token, time.Now())
+		if err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+		ctx = requestmeta.WithHuma(ctx, claims.Meta(requestmeta.FromHuma(ctx)))
+		ctx = huma.WithValue(ctx, verifiedContextKey{}, true)
+		if claims.Scopes != nil {
+			ctx = authorization.WithScopes(ctx, claims.Scopes)
+		}
+		next(ctx)
+	}
+}
+
+// UnaryServerInterceptor verifies the identity token of gRPC calls and puts the identity it vouches
+// for in the incoming metadata, where the authorization interceptor after it reads the caller.
+// Tokens carrying scopes are refused, gRPC authorizes the roles of the caller only.
+func UnaryServerInterceptor(keys *Keys) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		tokens := md.Get(strings.ToLower(IdentityHeader))
+		if len(tokens) == 0 {
+			if keys.Required() {
+				return nil, appErrors.NewUnauthorizedError("Missing internal identity token")
+			}
+			return handler(ctx, req)
+		}
+
+		claims, err := keys.Verify(tokens[0], time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if claims.Scopes != nil {
+			return nil, appErrors.NewUnauthorizedError("Scoped internal identity tokens are not accepted over gRPC")
+		}
+
+		verified := claims.Meta(requestmeta.FromIncomingGRPC(ctx))
+		md = md.Copy()
+		for name, value := range map[string]string{
+			requestmeta.UserIDHeader:         verified.UserID,
+			requestmeta.TenantIDHeader:       verified.TenantID,
+			requestmeta.ImpersonatorIDHeader: verified.ImpersonatorID,
+			requestmeta.AuthTimeHeader:       verified.AuthTime,
+			requestmeta.AuthACRHeader:        verified.AuthACR,
+		} {
+			if value == "" {
+				md.Delete(name)
+			} else {
+				md.Set(name, value)
+			}
+		}
+		ctx = metadata.NewIncomingContext(ctx, md)
+		return handler(context.WithValue(ctx, verifiedContextKey{}, true), req)
+	}
+}
+
+// outgoing returns the metadata of a call made while serving ctx and a token vouching for its
+// caller to audience. The caller is the authorized user and tenant, requests of public endpoints
+// have none and are sent without a token.
+func (k *Keys) outgoing(ctx context.Context, audience string) (requestmeta.Meta, string, error) {
+	meta := requestmeta.FromContext(ctx)
+	meta.UserID = authorization.UserIDFromContext(ctx)
+	meta.TenantID = authorization.TenantIDFromContext(ctx)
+	if meta.UserID == "" || meta.TenantID == "" || !k.Enabled() {
+		return meta, "", nil
+	}
+	scopes, _ := authorization.ScopesFromContext(ctx)
+	token, err := k.Sign(meta, scopes, audience, time.Now())
+	return meta, token, err
+}
+
+// SetHTTP writes the metadata of the request served with ctx and an identity token for audience to
+// the headers of an HTTP request to that service
+func (k *Keys) SetHTTP(ctx context.Context, header http.Header, audience string) error {
+	meta, token, err := k.outgoing(ctx, audience)
+	if err != nil {
+		return err
+	}
+	meta.SetHTTP(header)
+	if token != "" {
+		header.Set(IdentityHeader, token)
+	}
+	return nil
+}
+
+// AppendToOutgoingGRPC adds the metadata of the request served with ctx and an identity token for
+// audience to the gRPC calls made with the returned context
+func (k *Keys) AppendToOutgoingGRPC(ctx context.Context, audience string) (context.Context, error) {
+	meta, token, err := k.outgoing(ctx, audience)
+	if err != nil {
+		return ctx, err
+	}
+	ctx = meta.AppendToOutgoingGRPC(ctx)
+	if token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(IdentityHeader), token)
+	}
+	return ctx, nil
+}
+
+// UnaryClientInterceptor vouches for the caller of every call of a client connection to audience
+func (k *Keys) UnaryClientInterceptor(audience string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+		ctx, err := k.AppendToOutgoingGRPC(ctx, audience)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,247 @@
+package internalauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+)
+
+// IdentityHeader carries the identity token of calls between services, gRPC metadata uses the same
+// name in lower case
+const IdentityHeader = "X-Internal-Identity"
+
+// clockSkew is how far the clocks of two services may drift apart
+const clockSkew = 5 * time.Second
+
+// minKeyLength is the shortest secret accepted, HS256 keys shorter than the hash weaken it
+const minKeyLength = 32
+
+// Config is what a service needs to vouch for its callers and to believe its peers
+type Config struct {
+	// Service is the name the service signs tokens as, and the audience of the tokens it accepts
+	Service string
+	// Keys are kid:secret entries. Tokens are signed with the first and verified with any, so keys are
+	// rotated by adding the new one second, moving it first once every service has it, and removing
+	// the old one.
+	Keys []string
+	// Peers are the services whose tokens are accepted, every service signing with the keys when empty
+	Peers []string
+	// TTL is how long tokens are valid for, they are minted per call so seconds are enough
+	TTL time.Duration
+	// Required refuses calls without a token, for services only reached through other services
+	Required bool
+}
+
+type key struct {
+	id     string
+	secret []byte
+}
+
+// Claims are what a token vouches for: who the user is, in which tenant and how they signed in, as
+// verified by the service that issued it
+type Claims struct {
+	Issuer         string   `json:"iss"`
+	Audience       string   `json:"aud"`
+	Subject        string   `json:"sub"`
+	TenantID       string   `json:"tenant"`
+	ImpersonatorID string   `json:"imp,omitempty"`
+	AuthTime       int64    `json:"auth_time,omitempty"`
+	AuthACR        string   `json:"acr,omitempty"`
+	Scopes         []string `json:"scopes,omitempty"`
+	IssuedAt       int64    `json:"iat"`
+	ExpiresAt      int64    `json:"exp"`
+}
+
+// Meta returns the request metadata of the claims, for the middlewares after the verification
+func (c *Claims) Meta(traceMeta requestmeta.Meta) requestmeta.Meta {
+	meta := requestmeta.Meta{
+		UserID:         c.Subject,
+		TenantID:       c.TenantID,
+		ImpersonatorID: c.ImpersonatorID,
+		AuthACR:        c.AuthACR,
+		// What the caller says about the request, not who makes it, is kept as sent
+		Locale:        traceMeta.Locale,
+		TraceID:       traceMeta.TraceID,
+		ClientVersion: traceMeta.ClientVersion,
+	}
+	if c.AuthTime > 0 {
+		meta.AuthTime = strconv.FormatInt(c.AuthTime, 10)
+	}
+	return meta
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+// Keys signs and verifies identity tokens. The zero value has no keys, it signs nothing and verifies
+// nothing.
+type Keys struct {
+	service  string
+	keys     []key
+	peers    []string
+	ttl      time.Duration
+	required bool
+}
+
+// NewKeys parses the keys of config, the returned Keys are disabled when it has none
+func NewKeys(config Config) (*Keys, error) {
+	keys := &Keys{
+		service:  config.Service,
+		peers:    config.Peers,
+		ttl:      config.TTL,
+		required: config.Required,
+	}
+	if keys.ttl <= 0 {
+		keys.ttl = time.Minute
+	}
+	for _, entry := range config.Keys {
+		id, secret, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("internal identity key %q is not kid:secret", id)
+		}
+		if len(secret) < minKeyLength {
+			return nil, fmt.Errorf("internal identity key %q is shorter than %d bytes", id, minKeyLength)
+		}
+		keys.keys = append(keys.keys, key{id: id, secret: []byte(secret)})
+	}
+	if len(keys.keys) > 0 && keys.service == "" {
+		return nil, fmt.Errorf("internal identity keys need the name of the service")
+	}
+	if keys.required && len(keys.keys) == 0 {
+		return nil, fmt.Errorf("internal identity tokens are required but no key is configured")
+	}
+	return keys, nil
+}
+
+// Enabled reports whether tokens are signed and verified
+func (k *Keys) Enabled() bool {
+	return k != nil && len(k.keys) > 0
+}
+
+// Required reports whether calls without a token are refused
+func (k *Keys) Required() bool {
+	return k != nil && k.required
+}
+
+// Sign returns a token vouching for the user of meta in its tenant, for a call to audience. scopes
+// are the limits of the caller on top of its roles, nil when only roles apply.
+func (k *Keys) Sign(meta requestmeta.Meta, scopes []string, audience string, now time.Time) (string, error) {
+	if !k.Enabled() {
+		return "", fmt.Errorf("internal identity tokens are not configured")
+	}
+	claims := Claims{
+		Issuer:         k.service,
+		Audience:       audience,
+		Subject:        meta.UserID,
+		TenantID:       meta.TenantID,
+		ImpersonatorID: meta.ImpersonatorID,
+		AuthACR:        meta.AuthACR,
+		Scopes:         scopes,
+		IssuedAt:       now.Unix(),
+		ExpiresAt:      now.Add(k.ttl).Unix(),
+	}
+	if authTime, err := strconv.ParseInt(meta.AuthTime, 10, 64); err == nil {
+		claims.AuthTime = authTime
+	}
+
+	signing := k.keys[0]
+	header, err := encodeSegment(tokenHeader{Alg: "HS256", Typ: "JWT", Kid: signing.id})
+	if err != nil {
+		return "", err
+	}
+	payload, err := encodeSegment(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := header + "." + payload
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign(signing.secret, signed)), nil
+}
+
+// Verify checks that token was signed with one of the keys by a peer for this service and has not
+// expired at now. Refused tokens return an Unauthorized error.
+func (k *Keys) Verify(token string, now time.Time) (*Claims, error) {
+	invalid := func(reason string) error {
+		return appErrors.NewUnauthorizedError("Invalid internal identity token: " + reason)
+	}
+	if !k.Enabled() {
+		return nil, invalid("not accepted by this service")
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalid("malformed token")
+	}
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, invalid("malformed header")
+	}
+	if header.Alg != "HS256" {
+		return nil, invalid("unsupported algorithm")
+	}
+	index := slices.IndexFunc(k.keys, func(key key) bool { return key.id == header.Kid })
+	if index < 0 {
+		return nil, invalid("unknown signing key")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid("malformed signature")
+	}
+	if !hmac.Equal(signature, sign(k.keys[index].secret, parts[0]+"."+parts[1])) {
+		return nil, invalid("signature mismatch")
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, invalid("malformed claims")
+	}
+	if claims.Audience != k.service {
+		return nil, invalid("issued for another service")
+	}
+	if len(k.peers) > 0 && !slices.Contains(k.peers, claims.Issuer) {
+		return nil, invalid("unexpected issuer")
+	}
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, invalid("expired")
+	}
+	if time.Unix(claims.IssuedAt, 0).After(now.Add(clockSkew)) {
+		return nil, invalid("issued in the future")
+	}
+	if claims.Subject == "" || claims.TenantID == "" {
+		return nil, invalid("missing user or tenant")
+	}
+	return &claims, nil
+}
+
+func sign(secret []byte, signed string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)
+}
+
+func encodeSegment(value any) (string, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodeSegment(segment string, into any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, into)
+}
@@ -0,0 +1,126 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// selfTestTimeout bounds the checks of the self-test, they include the ones too slow for probes
+const selfTestTimeout = 10 * time.Second
+
+// Dependency is a subsystem the instance needs to serve, such as the database or Redis
+type Dependency struct {
+	Name string
+	// Check reports whether the dependency is reachable, nil when there is nothing to check
+	Check ReadinessCheck
+	// SelfTestOnly keeps the check out of the readiness probe, for checks that open connections of
+	// their own or otherwise cost too much to run on every probe
+	SelfTestOnly bool
+	// Close releases the dependency on shutdown, nil when it holds nothing
+	Close func() error
+}
+
+// Dependencies is the registry of the subsystems of the instance. Readiness and the self-test run
+// their checks and the shutdown closes them in the reverse order of registration, so a dependency
+// is closed before the ones it was built from.
+type Dependencies struct {
+	mu           sync.Mutex
+	dependencies []Dependency
+	closeOnce    sync.Once
+}
+
+func NewDependencies() *Dependencies {
+	return &Dependencies{}
+}
+
+// Register adds a dependency, dependencies are registered at startup before requests are served
+func (d *Dependencies) Register(dependency Dependency) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.dependencies = append(d.dependencies, dependency)
+}
+
+// Check runs the readiness checks concurrently, the result of each is "ok" or its error
+func (d *Dependencies) Check(ctx context.Context) map[string]string {
+	return d.run(ctx, readinessTimeout, false)
+}
+
+// SelfTest runs every check, including the self-test only ones, and writes a line per dependency
+// to out. It returns an error naming the dependencies that failed.
+func (d *Dependencies) SelfTest(ctx context.Context, out io.Writer) error {
+	results := d.run(ctx, selfTestTimeout, true)
+
+	var failed []string
+	for _, dependency := range d.registered() {
+		result, checked := results[dependency.Name]
+		switch {
+		case !checked:
+			fmt.Fprintf(out, "%-20s skipped, nothing to check\n", dependency.Name)
+		case result == "ok":
+			fmt.Fprintf(out, "%-20s ok\n", dependency.Name)
+		default:
+			fmt.Fprintf(out, "%-20s FAILED: %s\n", dependency.Name, result)
+			failed = append(failed, dependency.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d dependencies failed: %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Close closes the dependencies in the reverse order of registration and logs the ones that fail.
+// Only the first call closes them, later calls return right away.
+func (d *Dependencies) Close() {
+	d.closeOnce.Do(func() {
+		dependencies := d.registered()
+		for i := len(dependencies) - 1; i >= 0; i-- {
+			if dependencies[i].Close == nil {
+				continue
+			}
+			if err := dependencies[i].Close(); err != nil {
+				log.Printf("Failed to close %s: %v", dependencies[i].Name, err)
+			}
+		}
+	})
+}
+
+func (d *Dependencies) registered() []Dependency {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Dependency(nil), d.dependencies...)
+}
+
+func (d *Dependencies) run(ctx context.Context, timeout time.Duration, selfTest bool) map[string]string {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]string)
+	for _, dependency := range d.registered() {
+		if dependency.Check == nil || (dependency.SelfTestOnly && !selfTest) {
+			continue
+		}
+		wg.Add(1)
+		go func(dependency Dependency) {
+			defer wg.Done()
+
+			result := "ok"
+			if err := dependency.Check(ctx); err != nil {
+				result = err.Error()
+			}
+			mu.Lock()
+			results[dependency.Name] = result
+			mu.Unlock()
+		}(dependency)
+	}
+	wg.Wait()
+
+	return results
+}
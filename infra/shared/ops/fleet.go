@@ -0,0 +1,207 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Shard is a database cluster of the fleet, named after its region
+type Shard struct {
+	Name        string
+	DatabaseURL string
+}
+
+// ParseShards builds the shards from name=url pairs, in name order
+func ParseShards(urls map[string]string) []Shard {
+	shards := make([]Shard, 0, len(urls))
+	for name, url := range urls {
+		shards = append(shards, Shard{Name: name, DatabaseURL: url})
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i].Name < shards[j].Name })
+	return shards
+}
+
+// SelectShards keeps the shards named, all of them when no name is given. It fails on a name of no
+// shard, a typo would otherwise skip the shard it meant.
+func SelectShards(shards []Shard, names []string) ([]Shard, error) {
+	if len(names) == 0 {
+		return shards, nil
+	}
+	var selected []Shard
+	for _, name := range names {
+		index := slices.IndexFunc(shards, func(shard Shard) bool { return shard.Name == name })
+		if index < 0 {
+			return nil, fmt.Errorf("no shard is named %s", name)
+		}
+		selected = append(selected, shards[index])
+	}
+	return selected, nil
+}
+
+type FleetConfig struct {
+	// Concurrency is how many shards run at once, at least one
+	Concurrency int
+	// Timeout bounds the steps of one shard, 0 for no bound
+	Timeout time.Duration
+}
+
+// FleetStep is a step every shard goes through, in the order they are given
+type FleetStep struct {
+	Name string
+	Run  func(ctx context.Context, shard Shard) error
+}
+
+// Shard states of a fleet run
+const (
+	ShardPending   = "pending"
+	ShardRunning   = "running"
+	ShardSucceeded = "succeeded"
+	ShardFailed    = "failed"
+)
+
+// ShardProgress is where a shard is in a fleet run. Step is the step running, or the one that failed.
+type ShardProgress struct {
+	Shard      string
+	Status     string
+	Step       string
+	Steps      int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Err        error
+}
+
+// Fleet runs steps on every shard, a few shards at a time. A shard stops at its first failing step
+// and the others go on, so one bad shard does not hold back the fleet. Runs can be repeated for the
+// failed shards, the steps are expected to pick up where they stopped.
+type Fleet struct {
+	shards []Shard
+	config FleetConfig
+
+	mu       sync.Mutex
+	progress map[string]*ShardProgress
+}
+
+func NewFleet(shards []Shard, config FleetConfig) *Fleet {
+	progress := make(map[string]*ShardProgress, len(shards))
+	for _, shard := range shards {
+		progress[shard.Name] = &ShardProgress{Shard: shard.Name, Status: ShardPending}
+	}
+	return &Fleet{
+		shards:   shards,
+		config:   config,
+		progress: progress,
+	}
+}
+
+// Run runs the steps on every shard and returns once all of them succeeded or failed, or ctx is
+// cancelled. Shards not started by then stay pending.
+func (f *Fleet) Run(ctx context.Context, steps ...FleetStep) {
+	slots := make(chan struct{}, max(1, f.config.Concurrency))
+	var wg sync.WaitGroup
+	for _, shard := range f.shards {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(shard Shard) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			f.runShard(ctx, shard, steps)
+		}(shard)
+	}
+	wg.Wait()
+}
+
+func (f *Fleet) runShard(ctx context.Context, shard Shard, steps []FleetStep) {
+	if f.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.config.Timeout)
+		defer cancel()
+	}
+
+	f.update(shard.Name, func(progress *ShardProgress) {
+		progress.Status, progress.StartedAt = ShardRunning, time.Now()
+	})
+	for i, step := range steps {
+		f.update(shard.Name, func(progress *ShardProgress) { progress.Step = step.Name })
+		log.Printf("Shard %s: %s (%d/%d)", shard.Name, step.Name, i+1, len(steps))
+		if err := runStep(ctx, shard, step); err != nil {
+			log.Printf("Shard %s failed at %s: %v", shard.Name, step.Name, err)
+			f.update(shard.Name, func(progress *ShardProgress) {
+				progress.Status, progress.Err, progress.FinishedAt = ShardFailed, err, time.Now()
+			})
+			return
+		}
+		f.update(shard.Name, func(progress *ShardProgress) { progress.Steps++ })
+	}
+	f.update(shard.Name, func(progress *ShardProgress) {
+		progress.Status, progress.Step, progress.FinishedAt = ShardSucceeded, "", time.Now()
+	})
+}
+
+// runStep fails the shard on a panic of its step, the other shards keep running
+func runStep(ctx context.Context, shard Shard, step FleetStep) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return step.Run(ctx, shard)
+}
+
+func (f *Fleet) update(shard string, change func(progress *ShardProgress)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	change(f.progress[shard])
+}
+
+// Progress returns where every shard is, in the order of the shards
+func (f *Fleet) Progress() []ShardProgress {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	progress := make([]ShardProgress, 0, len(f.shards))
+	for _, shard := range f.shards {
+		progress = append(progress, *f.progress[shard.Name])
+	}
+	return progress
+}
+
+// Failed lists the shards that failed or were never started
+func (f *Fleet) Failed() []string {
+	var failed []string
+	for _, progress := range f.Progress() {
+		if progress.Status != ShardSucceeded {
+			failed = append(failed, progress.Shard)
+		}
+	}
+	return failed
+}
+
+// WriteReport writes a line per shard with its status, the step it stopped at and how long it took
+func (f *Fleet) WriteReport(w io.Writer) error {
+	var out strings.Builder
+	for _, progress := range f.Progress() {
+		fmt.Fprintf(&out, "%-20s %-10s", progress.Shard, progress.Status)
+		if !progress.FinishedAt.IsZero() {
+			fmt.Fprintf(&out, " %s", progress.FinishedAt.Sub(progress.StartedAt).Round(time.Millisecond))
+		}
+		if progress.Err != nil {
+			fmt.Fprintf(&out, " at %s: %v", progress.Step, progress.Err)
+		}
+		out.WriteString("\n")
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
@@ -0,0 +1,170 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ShutdownConfig tunes the lame-duck phase that starts on SIGTERM
+type ShutdownConfig struct {
+	// LameDuckDelay is how long the instance keeps serving after readiness flips false, load
+	// balancers stop routing new traffic to it meanwhile
+	LameDuckDelay time.Duration
+	// GracePeriod bounds how long in-flight requests and then background workers get to finish
+	GracePeriod time.Duration
+	// TerminationGracePeriod is the terminationGracePeriodSeconds of the pod, 0 when unknown. The
+	// orchestrator kills the process once it runs out, the phases are shortened to fit in it.
+	TerminationGracePeriod time.Duration
+}
+
+// terminationMargin is kept for the deferred cleanup after workers stop
+const terminationMargin = 2 * time.Second
+
+// Budget is the longest the shutdown takes: the lame-duck delay, then requests and workers
+func (c ShutdownConfig) Budget() time.Duration {
+	return c.LameDuckDelay + 2*c.GracePeriod
+}
+
+// Fit shortens the phases so the shutdown ends before the termination grace period. The grace
+// period is cut first, load balancers need the lame-duck delay to stop routing to the instance.
+func (c ShutdownConfig) Fit() ShutdownConfig {
+	available := c.TerminationGracePeriod - terminationMargin
+	if c.TerminationGracePeriod <= 0 || c.Budget() <= available {
+		return c
+	}
+	if available <= 0 {
+		return ShutdownConfig{TerminationGracePeriod: c.TerminationGracePeriod}
+	}
+
+	fitted := c
+	fitted.GracePeriod = (available - c.LameDuckDelay) / 2
+	if fitted.GracePeriod < 0 {
+		fitted.GracePeriod = 0
+		fitted.LameDuckDelay = available
+	}
+	return fitted
+}
+
+// LameDuck coordinates zero-downtime deploys. On SIGTERM readiness flips false, responses ask
+// clients to reconnect elsewhere, and after the lame-duck delay the listener closes while in-flight
+// requests finish. Background workers are stopped next, they leave at their next checkpoint, and
+// the dependencies are closed last.
+type LameDuck struct {
+	draining     atomic.Bool
+	dependencies *Dependencies
+	workerCtx    context.Context
+	stopWorkers  context.CancelFunc
+	workers      sync.WaitGroup
+}
+
+// NewLameDuck gates readiness on the checks of dependencies and closes them once drained
+func NewLameDuck(dependencies *Dependencies) *LameDuck {
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	return &LameDuck{
+		dependencies: dependencies,
+		workerCtx:    workerCtx,
+		stopWorkers:  stopWorkers,
+	}
+}
+
+// Draining reports whether the instance entered the lame-duck phase
+func (l *LameDuck) Draining() bool {
+	return l.draining.Load()
+}
+
+// Go runs a background worker. Its context is cancelled once requests are drained, workers return
+// when they see it between iterations; work cut short rolls back and resumes from the last
+// checkpoint on another instance.
+func (l *LameDuck) Go(worker func(ctx context.Context)) {
+	l.workers.Add(1)
+	go func() {
+		defer l.workers.Done()
+		worker(l.workerCtx)
+	}()
+}
+
+// Middleware closes keep-alive connections while draining, so clients open their next request on
+// another instance. WebSocket upgrades are refused, those connections would outlive the instance.
+func (l *LameDuck) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !l.Draining() {
+			c.Next()
+			return
+		}
+
+		c.Header("Connection", "close")
+		if c.GetHeader("Upgrade") != "" {
+			c.Header("Retry-After", "1")
+			c.AbortWithStatus(http.StatusServiceUnavailable)
+			return
+		}
+		c.Next()
+	}
+}
+
+// ListenAndServe serves HTTP until SIGTERM or an interrupt, then drains the server and the workers
+// and closes the dependencies. It returns once all are done or the grace periods ran out.
+func (l *LameDuck) ListenAndServe(server *http.Server, config ShutdownConfig) error {
+	if fitted := config.Fit(); fitted != config {
+		log.Printf("Shutdown takes up to %s, shortened to lame-duck %s and grace %s to end within the termination grace period of %s",
+			config.Budget(), fitted.LameDuckDelay, fitted.GracePeriod, config.TerminationGracePeriod)
+		config = fitted
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		l.stopWorkers()
+		return err
+	case sig := <-signals:
+		log.Printf("Received %s, entering lame-duck mode for %s", sig, config.LameDuckDelay)
+	}
+
+	l.draining.Store(true)
+	time.Sleep(config.LameDuckDelay)
+
+	log.Println("Shutting down gracefully...")
+	requestsCtx, cancel := context.WithTimeout(context.Background(), config.GracePeriod)
+	defer cancel()
+	if err := server.Shutdown(requestsCtx); err != nil {
+		log.Printf("In-flight requests did not finish within %s: %v", config.GracePeriod, err)
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("HTTP server stopped with error: %v", err)
+	}
+
+	l.stopWorkers()
+	workersDone := make(chan struct{})
+	go func() {
+		l.workers.Wait()
+		close(workersDone)
+	}()
+	select {
+	case <-workersDone:
+		log.Println("Background workers stopped")
+	case <-time.After(config.GracePeriod):
+		log.Printf("Background workers did not stop within %s", config.GracePeriod)
+	}
+
+	l.dependencies.Close()
+	log.Println("Dependencies closed")
+	return nil
+}
@@ -0,0 +1,40 @@
+package ops
+
+import (
+	"os"
+	"strings"
+)
+
+// Metadata identifies the pod through the Kubernetes downward API. The deployment maps
+// metadata.name, metadata.namespace and spec.nodeName to POD_NAME, POD_NAMESPACE and NODE_NAME.
+type Metadata struct {
+	Pod       string
+	Namespace string
+	Node      string
+}
+
+func MetadataFromEnv() Metadata {
+	return Metadata{
+		Pod:       os.Getenv("POD_NAME"),
+		Namespace: os.Getenv("POD_NAMESPACE"),
+		Node:      os.Getenv("NODE_NAME"),
+	}
+}
+
+// LogPrefix tags log lines with the pod and node they come from, "" outside Kubernetes
+func (m Metadata) LogPrefix() string {
+	var fields []string
+	if m.Namespace != "" {
+		fields = append(fields, "namespace="+m.Namespace)
+	}
+	if m.Pod != "" {
+		fields = append(fields, "pod="+m.Pod)
+	}
+	if m.Node != "" {
+		fields = append(fields, "node="+m.Node)
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.Join(fields, " ") + " "
+}
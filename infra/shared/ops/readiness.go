@@ -0,0 +1,33 @@
+package ops
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessTimeout bounds every check, probes time out after a second by default
+const readinessTimeout = 800 * time.Millisecond
+
+// ReadinessCheck reports whether a dependency needed to serve requests is reachable
+type ReadinessCheck func(ctx context.Context) error
+
+// Readiness serves the readiness probe. It fails as soon as the lame-duck phase starts and while
+// any dependency check fails, the body reports every check.
+func (l *LameDuck) Readiness(c *gin.Context) {
+	if l.Draining() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "DRAINING"})
+		return
+	}
+
+	results := l.dependencies.Check(c.Request.Context())
+	status, code := "READY", http.StatusOK
+	for _, result := range results {
+		if result != "ok" {
+			status, code = "NOT_READY", http.StatusServiceUnavailable
+		}
+	}
+	c.JSON(code, gin.H{"status": status, "checks": results})
+}
@@ -0,0 +1,60 @@
+package ops
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// ScalingFinding is a component that assumes it runs on a single instance
+type ScalingFinding struct {
+	Component string
+	Reason    string
+	// Blocking findings break correctness with several replicas, the others only degrade behavior
+	// such as serving stale cached data for up to a TTL
+	Blocking bool
+}
+
+// ScalingAudit collects the single instance assumptions of the running configuration, startup
+// refuses to run several replicas while any of them is blocking
+type ScalingAudit struct {
+	findings []ScalingFinding
+}
+
+func NewScalingAudit() *ScalingAudit {
+	return &ScalingAudit{}
+}
+
+// Require records a blocking finding unless the prerequisite is met
+func (a *ScalingAudit) Require(component string, met bool, reason string) {
+	if !met {
+		a.findings = append(a.findings, ScalingFinding{Component: component, Reason: reason, Blocking: true})
+	}
+}
+
+// Flag records state kept in memory that other instances do not see
+func (a *ScalingAudit) Flag(component string, reason string) {
+	a.findings = append(a.findings, ScalingFinding{Component: component, Reason: reason})
+}
+
+func (a *ScalingAudit) Findings() []ScalingFinding {
+	return a.findings
+}
+
+// Verify logs every finding and fails when replicas is above one and a finding is blocking
+func (a *ScalingAudit) Verify(replicas int) error {
+	var blocking []string
+	for _, finding := range a.findings {
+		level := "warning"
+		if finding.Blocking {
+			level = "blocking"
+			blocking = append(blocking, finding.Component)
+		}
+		log.Printf("Scaling audit %s: %s: %s", level, finding.Component, finding.Reason)
+	}
+
+	if replicas > 1 && len(blocking) > 0 {
+		return fmt.Errorf("cannot run %d replicas, single instance assumptions in: %s", replicas, strings.Join(blocking, ", "))
+	}
+	return nil
+}
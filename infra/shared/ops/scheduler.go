@@ -0,0 +1,127 @@
+package ops
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nahualventure/class-backend/infra/shared/database"
+)
+
+// leaderLock is the job lock the elected scheduler holds
+const leaderLock = "scheduler-leader"
+
+// A job that panics is restarted after a backoff doubling from the minimum up to the maximum, it
+// starts over from the minimum once the job ran longer than the maximum
+const (
+	minJobRestartBackoff = 10 * time.Second
+	maxJobRestartBackoff = 10 * time.Minute
+)
+
+// Scheduler runs the periodic jobs that must not run on several instances at once. Each job takes
+// its own job lock, so jobs spread over the instances. With leader election one instance takes
+// every job instead, which keeps them together for the instance that is sized for them.
+type Scheduler struct {
+	locks  *database.JobLocks
+	elect  bool
+	jobs   []scheduledJob
+	leader atomic.Bool
+
+	restarts atomic.Int64
+}
+
+type scheduledJob struct {
+	name string
+	run  func(ctx context.Context)
+}
+
+// NewScheduler runs jobs under locks, nil runs them on this instance right away. Leader election
+// needs locks.
+func NewScheduler(locks *database.JobLocks, electLeader bool) *Scheduler {
+	return &Scheduler{
+		locks: locks,
+		elect: electLeader && locks.Enabled(),
+	}
+}
+
+// Add registers a job, jobs are added before Run
+func (s *Scheduler) Add(name string, job func(ctx context.Context)) {
+	s.jobs = append(s.jobs, scheduledJob{name: name, run: job})
+}
+
+// IsLeader reports whether this instance runs the jobs, always true without leader election
+func (s *Scheduler) IsLeader() bool {
+	return !s.elect || s.leader.Load()
+}
+
+// Run runs every job until ctx is cancelled and the jobs returned
+func (s *Scheduler) Run(ctx context.Context) {
+	if !s.elect {
+		s.runJobs(ctx, func(ctx context.Context, job scheduledJob) { s.locks.Run(ctx, job.name, job.run) })
+		return
+	}
+
+	s.locks.Run(ctx, leaderLock, func(ctx context.Context) {
+		s.leader.Store(true)
+		defer s.leader.Store(false)
+		log.Printf("Elected scheduler leader, running %d jobs", len(s.jobs))
+
+		s.runJobs(ctx, func(ctx context.Context, job scheduledJob) { job.run(ctx) })
+	})
+}
+
+func (s *Scheduler) runJobs(ctx context.Context, run func(ctx context.Context, job scheduledJob)) {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func(job scheduledJob) {
+			defer wg.Done()
+			s.supervise(ctx, job, run)
+		}(job)
+	}
+	wg.Wait()
+}
+
+// supervise restarts a job that panics after a backoff, so a job failing on bad data of one shard
+// neither takes the instance down nor stops the other jobs. A job that returns is not restarted.
+func (s *Scheduler) supervise(ctx context.Context, job scheduledJob, run func(ctx context.Context, job scheduledJob)) {
+	backoff := minJobRestartBackoff
+	for {
+		started := time.Now()
+		if !runRecovered(ctx, job, run) {
+			return
+		}
+		s.restarts.Add(1)
+		if time.Since(started) > maxJobRestartBackoff {
+			backoff = minJobRestartBackoff
+		}
+		log.Printf("Restarting job %s in %s", job.name, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxJobRestartBackoff)
+	}
+}
+
+// runRecovered runs the job and reports whether it panicked
+func runRecovered(ctx context.Context, job scheduledJob, run func(ctx context.Context, job scheduledJob)) (panicked bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("Job %s panicked: %v\n%s", job.name, recovered, debug.Stack())
+			panicked = true
+		}
+	}()
+	run(ctx, job)
+	return false
+}
+
+// Restarts counts the jobs restarted after a panic since the instance started
+func (s *Scheduler) Restarts() int64 {
+	return s.restarts.Load()
+}
@@ -0,0 +1,180 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Letter pages in points, text starts one margin away from the edges
+const (
+	PageWidth    = 612
+	PageHeight   = 792
+	PageMargin   = 56
+	ContentWidth = PageWidth - 2*PageMargin
+	lineSpacing  = 16
+	// charWidth is the average width of a Helvetica character in ems, cells are cut to fit with it
+	charWidth = 0.5
+)
+
+// line is a line of text of a page, x and y in points from the bottom left corner
+type line struct {
+	x    int
+	y    int
+	bold bool
+	size int
+	text string
+}
+
+// Layout lays lines of text out top to bottom, starting a page when one is full, and writes them as
+// a plain PDF 1.4 document with the standard Helvetica fonts. No font is embedded so no PDF library
+// or asset is needed. Text outside Latin-1 is printed as question marks.
+type Layout struct {
+	pages [][]line
+	y     int
+	// header is the table row repeated at the top of the pages a table continues on
+	header *row
+}
+
+type row struct {
+	bold    bool
+	columns []int
+	cells   []string
+}
+
+func (l *Layout) advance() {
+	if len(l.pages) == 0 || l.y < PageMargin {
+		l.pages = append(l.pages, nil)
+		l.y = PageHeight - PageMargin
+		if l.header != nil && len(l.pages) > 1 {
+			l.print(*l.header)
+		}
+	}
+}
+
+// Text prints a line of text at the left margin
+func (l *Layout) Text(text string, bold bool, size int) {
+	l.advance()
+	page := len(l.pages) - 1
+	l.pages[page] = append(l.pages[page], line{x: PageMargin, y: l.y, bold: bold, size: size, text: text})
+	l.y -= lineSpacing + size - 11
+}
+
+// Row prints a table row. columns are the offsets of the cells from the left margin, each cell is cut
+// to end before the next one starts.
+func (l *Layout) Row(bold bool, columns []int, cells ...string) {
+	l.advance()
+	l.print(row{bold: bold, columns: columns, cells: cells})
+}
+
+// Header prints a table row like Row and repeats it at the top of every page the table continues on,
+// until EndTable
+func (l *Layout) Header(columns []int, cells ...string) {
+	l.Row(true, columns, cells...)
+	l.header = &row{bold: true, columns: columns, cells: cells}
+}
+
+// EndTable stops repeating the header of the table
+func (l *Layout) EndTable() {
+	l.header = nil
+}
+
+func (l *Layout) print(r row) {
+	page := len(l.pages) - 1
+	for i, cell := range r.cells {
+		if i >= len(r.columns) {
+			break
+		}
+		width := ContentWidth - r.columns[i]
+		if i+1 < len(r.columns) {
+			width = r.columns[i+1] - r.columns[i]
+		}
+		l.pages[page] = append(l.pages[page], line{x: PageMargin + r.columns[i], y: l.y, bold: r.bold, size: 11, text: fit(cell, width, 11)})
+	}
+	l.y -= lineSpacing
+}
+
+// Skip leaves a blank line
+func (l *Layout) Skip() {
+	l.y -= lineSpacing
+}
+
+// Bytes serializes the pages. Objects 1 to 4 are the catalog, the page tree and the two fonts, every
+// page then takes two objects: the page and its content stream.
+func (l *Layout) Bytes() []byte {
+	if len(l.pages) == 0 {
+		l.advance()
+	}
+
+	var out bytes.Buffer
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	kids := make([]string, 0, len(l.pages))
+	for i := range l.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+2*i))
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(l.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, lines := range l.pages {
+		var content bytes.Buffer
+		for _, line := range lines {
+			font := "F1"
+			if line.bold {
+				font = "F2"
+			}
+			fmt.Fprintf(&content, "BT /%s %d Tf %d %d Td (%s) Tj ET\n", font, line.size, line.x, line.y, escape(line.text))
+		}
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// fit cuts text that would not fit in width points, leaving room for a gap before the next cell
+func fit(text string, width int, size int) string {
+	limit := int(float64(width-6) / (charWidth * float64(size)))
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	if limit <= 3 {
+		return string(runes[:max(limit, 0)])
+	}
+	return string(runes[:limit-3]) + "..."
+}
+
+// escape escapes text for a PDF string literal, encoding it in Latin-1
+func escape(text string) string {
+	var escaped strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			escaped.WriteByte('\\')
+			escaped.WriteRune(r)
+		case r == '\t':
+			escaped.WriteByte(' ')
+		case r < 0x20 || r > 0xff:
+			escaped.WriteByte('?')
+		default:
+			escaped.WriteByte(byte(r))
+		}
+	}
+	return escaped.String()
+}
@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Shed scopes reported when a request is rejected
+const (
+	ScopeServer = "server"
+	ScopeTenant = "tenant"
+)
+
+// ConcurrencyLimiter caps the requests in flight on the instance and per tenant, so a traffic spike
+// is shed instead of queueing on the database pool. A limit of 0 disables it. Limits are per
+// instance, not cluster wide.
+type ConcurrencyLimiter struct {
+	mu           sync.Mutex
+	maxInFlight  int
+	maxPerTenant int
+	tenantLimits map[string]int
+	inFlight     int
+	perTenant    map[string]int
+	shed         map[string]int64
+}
+
+func NewConcurrencyLimiter(maxInFlight int, maxPerTenant int) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		maxInFlight:  maxInFlight,
+		maxPerTenant: maxPerTenant,
+		tenantLimits: make(map[string]int),
+		perTenant:    make(map[string]int),
+		shed:         map[string]int64{ScopeServer: 0, ScopeTenant: 0},
+	}
+}
+
+// LimitTenants overrides the per tenant limit for the tenants in limits, to hold back a tenant whose
+// load shows in the database metrics or to give a large one more room. A limit of 0 lifts the limit
+// of that tenant.
+func (l *ConcurrencyLimiter) LimitTenants(limits map[string]int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for tenantID, limit := range limits {
+		l.tenantLimits[tenantID] = limit
+	}
+}
+
+// Acquire takes a slot for a request of the tenant, "" for public endpoints. When a limit is reached
+// it returns the scope of that limit and the request must be shed, otherwise release must be called
+// once the request is done.
+func (l *ConcurrencyLimiter) Acquire(tenantID string) (release func(), shedScope string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.maxInFlight > 0 && l.inFlight >= l.maxInFlight {
+		l.shed[ScopeServer]++
+		return nil, ScopeServer
+	}
+	limit, overridden := l.tenantLimits[tenantID]
+	if !overridden {
+		limit = l.maxPerTenant
+	}
+	if tenantID != "" && limit > 0 && l.perTenant[tenantID] >= limit {
+		l.shed[ScopeTenant]++
+		return nil, ScopeTenant
+	}
+
+	l.inFlight++
+	if tenantID != "" {
+		l.perTenant[tenantID]++
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { l.release(tenantID) }) }, ""
+}
+
+func (l *ConcurrencyLimiter) release(tenantID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	if tenantID == "" {
+		return
+	}
+	if l.perTenant[tenantID]--; l.perTenant[tenantID] == 0 {
+		delete(l.perTenant, tenantID)
+	}
+}
+
+// WriteMetrics writes the requests in flight and the shed requests in the Prometheus text format
+func (l *ConcurrencyLimiter) WriteMetrics(w io.Writer) error {
+	l.mu.Lock()
+	inFlight := l.inFlight
+	shedServer, shedTenant := l.shed[ScopeServer], l.shed[ScopeTenant]
+	l.mu.Unlock()
+
+	_, err := fmt.Fprintf(w, "# HELP http_requests_in_flight Requests being served\n"+
+		"# TYPE http_requests_in_flight gauge\n"+
+		"http_requests_in_flight %d\n"+
+		"# HELP http_requests_shed_total Requests rejected because a concurrency limit was reached\n"+
+		"# TYPE http_requests_shed_total counter\n"+
+		"http_requests_shed_total{scope=%q} %d\n"+
+		"http_requests_shed_total{scope=%q} %d\n",
+		inFlight, ScopeServer, shedServer, ScopeTenant, shedTenant)
+	return err
+}
@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// shedRetryAfter is short, slots free up as soon as the requests in flight finish
+const shedRetryAfter = time.Second
+
+// NewConcurrencyMiddleware returns a Huma middleware that sheds requests over the limits of the
+// limiter with a 429 and Retry-After. It runs before the middlewares that query the database, the
+// service account and authorization ones included, so it counts requests by the tenant they claim.
+func NewConcurrencyMiddleware(limiter *ConcurrencyLimiter) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		release, shedScope := limiter.Acquire(requestmeta.FromHuma(ctx).TenantID)
+		if shedScope != "" {
+			ctx.SetHeader("Retry-After", "1")
+			utils.WriteApplicationError(ctx, appErrors.NewRateLimitedError(shedScope+"_concurrency", shedRetryAfter))
+			return
+		}
+		defer release()
+
+		next(ctx)
+	}
+}
@@ -0,0 +1,128 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+)
+
+// ScopeUser is reported when a user is over their own limit
+const ScopeUser = "user"
+
+// DownloadLimits are the soft limits of file downloads, 0 disables a limit. Downloads over the
+// concurrent limits are shed, the bandwidth limits slow the downloads in flight down instead.
+type DownloadLimits struct {
+	PerUser              int
+	PerTenant            int
+	UserBytesPerSecond   int64
+	TenantBytesPerSecond int64
+}
+
+// DownloadLimiter keeps one classroom downloading the same video from saturating the instance.
+// A tenant and each of its users share their bandwidth among their downloads in flight. Limits are
+// per instance, not cluster wide.
+type DownloadLimiter struct {
+	mu        sync.Mutex
+	limits    DownloadLimits
+	perUser   map[string]int
+	perTenant map[string]int
+	// Bandwidth buckets hold bytes, they are kept while the key has a download in flight
+	userBandwidth   map[string]*byteBucket
+	tenantBandwidth map[string]*byteBucket
+	clock           clock.Clock
+}
+
+// byteBucket holds up to a second of bandwidth. Writes take the bytes they wrote even when the
+// bucket runs short, the debt is the time the writer waits.
+type byteBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+func NewDownloadLimiter(limits DownloadLimits) *DownloadLimiter {
+	return &DownloadLimiter{
+		limits:          limits,
+		perUser:         make(map[string]int),
+		perTenant:       make(map[string]int),
+		userBandwidth:   make(map[string]*byteBucket),
+		tenantBandwidth: make(map[string]*byteBucket),
+		clock:           clock.System,
+	}
+}
+
+// WithClock changes the clock bandwidth is refilled with
+func (l *DownloadLimiter) WithClock(clock clock.Clock) *DownloadLimiter {
+	l.clock = clock
+	return l
+}
+
+// Acquire takes a download slot of the user and the tenant. When either has too many downloads in
+// flight it returns the scope of that limit and the download must be shed, otherwise release must
+// be called once the download is done.
+func (l *DownloadLimiter) Acquire(tenantID string, userID string) (release func(), shedScope string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	userKey := tenantID + "/" + userID
+	if l.limits.PerUser > 0 && l.perUser[userKey] >= l.limits.PerUser {
+		return nil, ScopeUser
+	}
+	if l.limits.PerTenant > 0 && l.perTenant[tenantID] >= l.limits.PerTenant {
+		return nil, ScopeTenant
+	}
+
+	l.perUser[userKey]++
+	l.perTenant[tenantID]++
+
+	var once sync.Once
+	return func() { once.Do(func() { l.release(tenantID, userKey) }) }, ""
+}
+
+func (l *DownloadLimiter) release(tenantID string, userKey string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.perUser[userKey]--; l.perUser[userKey] == 0 {
+		delete(l.perUser, userKey)
+		delete(l.userBandwidth, userKey)
+	}
+	if l.perTenant[tenantID]--; l.perTenant[tenantID] == 0 {
+		delete(l.perTenant, tenantID)
+		delete(l.tenantBandwidth, tenantID)
+	}
+}
+
+// Take takes the bandwidth of n bytes written for the user and returns how long the writer waits
+// before its next write, the longer of the waits of the user and the tenant
+func (l *DownloadLimiter) Take(tenantID string, userID string, n int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	userWait := take(l.userBandwidth, tenantID+"/"+userID, l.limits.UserBytesPerSecond, n, now)
+	tenantWait := take(l.tenantBandwidth, tenantID, l.limits.TenantBytesPerSecond, n, now)
+	return max(userWait, tenantWait)
+}
+
+func take(buckets map[string]*byteBucket, key string, bytesPerSecond int64, n int, now time.Time) time.Duration {
+	if bytesPerSecond <= 0 {
+		return 0
+	}
+	rate := float64(bytesPerSecond)
+
+	b, ok := buckets[key]
+	if !ok {
+		b = &byteBucket{tokens: rate, lastSeen: now}
+		buckets[key] = b
+	}
+
+	b.tokens = min(rate, b.tokens+now.Sub(b.lastSeen).Seconds()*rate)
+	b.lastSeen = now
+	b.tokens -= float64(n)
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / rate * float64(time.Second))
+}
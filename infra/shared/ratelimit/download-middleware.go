@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// DownloadOperations are the operations serving files, the download limits apply to them
+var DownloadOperations = map[string]bool{
+	"download-resource-version": true,
+	"download-attachment":       true,
+	"download-report":           true,
+}
+
+// downloadChunkSize is how much is written between two bandwidth checks
+const downloadChunkSize = 32 * 1024
+
+// downloadRetryAfter is a guess, slots free up when a download in flight finishes
+const downloadRetryAfter = 5 * time.Second
+
+type downloadContextKey struct{}
+
+// download is the slot of a download in flight, the writer of its response takes bandwidth from it
+type download struct {
+	limiter  *DownloadLimiter
+	tenantID string
+	userID   string
+}
+
+// NewDownloadMiddleware returns a Huma middleware that sheds the downloads of users and tenants
+// with too many in flight with a 429 and Retry-After. It runs after the authorization middleware
+// so downloads are counted by the verified user.
+func NewDownloadMiddleware(limiter *DownloadLimiter) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if !DownloadOperations[ctx.Operation().OperationID] {
+			next(ctx)
+			return
+		}
+
+		tenantID := authorization.TenantIDFromContext(ctx.Context())
+		userID := authorization.UserIDFromContext(ctx.Context())
+		release, shedScope := limiter.Acquire(tenantID, userID)
+		if shedScope != "" {
+			ctx.SetHeader("Retry-After", "5")
+			utils.WriteApplicationError(ctx, appErrors.NewRateLimitedError(shedScope+"_downloads", downloadRetryAfter))
+			return
+		}
+		defer release()
+
+		next(huma.WithValue(ctx, downloadContextKey{}, &download{limiter: limiter, tenantID: tenantID, userID: userID}))
+	}
+}
+
+// DownloadWriter paces writes to w with the bandwidth left to the download of the request. Requests
+// the download middleware did not take a slot for write to w directly.
+func DownloadWriter(ctx context.Context, w io.Writer) io.Writer {
+	d, ok := ctx.Value(downloadContextKey{}).(*download)
+	if !ok {
+		return w
+	}
+	return &downloadWriter{ctx: ctx, w: w, download: d}
+}
+
+type downloadWriter struct {
+	ctx      context.Context
+	w        io.Writer
+	download *download
+}
+
+// Write writes p a chunk at a time and waits after each chunk for as long as the bandwidth of the
+// user or the tenant is exhausted. It stops when the client goes away.
+func (w *downloadWriter) Write(p []byte) (int, error) {
+	flusher, _ := w.w.(http.Flusher)
+
+	written := 0
+	for written < len(p) {
+		chunk := p[written:min(written+downloadChunkSize, len(p))]
+		n, err := w.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		wait := w.download.limiter.Take(w.download.tenantID, w.download.userID, n)
+		if wait <= 0 {
+			continue
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-w.ctx.Done():
+			timer.Stop()
+			return written, w.ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return written, nil
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+)
+
+// ScopeKey is reported when a key is over the limit it was created with
+const ScopeKey = "key"
+
+// KeyRateLimiter is an in-memory token bucket per key, for keys that each carry their own limit
+// such as the publishable keys of widgets. A key can spend its whole minute at once, then gets its
+// requests back at its rate. Limits are per instance, not cluster wide.
+type KeyRateLimiter struct {
+	mu sync.Mutex
+	// byRate holds a limiter per limit in use, limits never change so a key always lands in the same
+	byRate map[int]*TenantRateLimiter
+	clock  clock.Clock
+}
+
+func NewKeyRateLimiter() *KeyRateLimiter {
+	return &KeyRateLimiter{
+		byRate: make(map[int]*TenantRateLimiter),
+		clock:  clock.System,
+	}
+}
+
+// WithClock changes the clock tokens are refilled with
+func (l *KeyRateLimiter) WithClock(clock clock.Clock) *KeyRateLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.clock = clock
+	for _, limiter := range l.byRate {
+		limiter.WithClock(clock)
+	}
+	return l
+}
+
+// Allow consumes a token of the key, which may make perMinute requests per minute. When the
+// bucket is empty it returns false and how long to wait until the next token is available.
+func (l *KeyRateLimiter) Allow(key string, perMinute int) (bool, time.Duration) {
+	l.mu.Lock()
+	limiter, ok := l.byRate[perMinute]
+	if !ok {
+		limiter = NewTenantRateLimiter(perMinute, perMinute).WithClock(l.clock)
+		l.byRate[perMinute] = limiter
+	}
+	l.mu.Unlock()
+
+	return limiter.Allow(key)
+}
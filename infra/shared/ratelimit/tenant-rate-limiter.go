@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/clock"
+)
+
+// TenantRateLimiter is an in-memory token bucket per tenant, public endpoints key it by client IP.
+// Limits are per instance, not cluster wide.
+type TenantRateLimiter struct {
+	mu         sync.Mutex
+	ratePerSec float64
+	burst      float64
+	buckets    map[string]*bucket
+	clock      clock.Clock
+	// idleAfter is how long an empty bucket takes to refill. A bucket unused for that long is full,
+	// the same as no bucket, so it is evicted at the next sweep.
+	idleAfter time.Duration
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// NewTenantRateLimiter allows perMinute requests per tenant on average with bursts up to burst
+func NewTenantRateLimiter(perMinute int, burst int) *TenantRateLimiter {
+	ratePerSec := float64(perMinute) / 60
+	return &TenantRateLimiter{
+		ratePerSec: ratePerSec,
+		burst:      float64(burst),
+		buckets:    make(map[string]*bucket),
+		clock:      clock.System,
+		idleAfter:  time.Duration(float64(burst) / ratePerSec * float64(time.Second)),
+	}
+}
+
+// WithClock changes the clock tokens are refilled with
+func (l *TenantRateLimiter) WithClock(clock clock.Clock) *TenantRateLimiter {
+	l.clock = clock
+	return l
+}
+
+// Allow consumes a token for the tenant. When the bucket is empty it returns false and how long
+// to wait until the next token is available.
+func (l *TenantRateLimiter) Allow(tenantID string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.sweep(now)
+
+	b, ok := l.buckets[tenantID]
+	if !ok {
+		b = &bucket{tokens: l.burst, lastSeen: now}
+		l.buckets[tenantID] = b
+	}
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.lastSeen).Seconds()*l.ratePerSec)
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.ratePerSec * float64(time.Second))
+		return false, wait
+	}
+
+	b.tokens--
+	return true, 0
+}
+
+// Tracked returns how many buckets are kept, the ones of tenants seen within the refill time
+func (l *TenantRateLimiter) Tracked() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.buckets)
+}
+
+// sweep evicts the idle buckets at most once per refill time, so client IPs seen once do not
+// accumulate. Each sweep walks the buckets once.
+func (l *TenantRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.idleAfter {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) >= l.idleAfter {
+			delete(l.buckets, key)
+		}
+	}
+}
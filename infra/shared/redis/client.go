@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Client is a go-redis client, commands share a pool of connections that are dialed on demand and
+// replaced after network errors
+type Client struct {
+	*goredis.Client
+}
+
+func NewClient(addr string, password string, db int) *Client {
+	client := goredis.NewClient(&goredis.Options{
+		Addr:         addr,
+		Password:     password,
+		DB:           db,
+		DialTimeout:  2 * time.Second,
+		ReadTimeout:  2 * time.Second,
+		WriteTimeout: 2 * time.Second,
+		// Requests cancelled by their deadline stop waiting for Redis
+		ContextTimeoutEnabled: true,
+		// A few connections stay open so heartbeats after a quiet period skip the dial
+		MinIdleConns: 2,
+	})
+	client.AddHook(costHook{})
+	return &Client{Client: client}
+}
+
+// costHook counts the commands of a request, and each of its pipelines, as calls to Redis in its cost
+type costHook struct{}
+
+func (costHook) DialHook(next goredis.DialHook) goredis.DialHook {
+	return next
+}
+
+func (costHook) ProcessHook(next goredis.ProcessHook) goredis.ProcessHook {
+	return func(ctx context.Context, cmd goredis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		cost.RecordExternal(ctx, "redis", time.Since(start))
+		return err
+	}
+}
+
+func (costHook) ProcessPipelineHook(next goredis.ProcessPipelineHook) goredis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []goredis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		cost.RecordExternal(ctx, "redis", time.Since(start))
+		return err
+	}
+}
+
+// Error wraps the error of a command as an infrastructure error, nil stays nil. Error replies (e.g.
+// WRONGTYPE) come from the command itself, sending it again fails the same way.
+func Error(command string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var replyErr goredis.Error
+	isReply := errors.As(err, &replyErr)
+	return appErrors.NewInfrastructureError("redis "+command+" failed", err).
+		WithSafeContext(appErrors.SafeContext{Operation: "redis." + command, Resource: "redis", Retryable: !isReply})
+}
@@ -0,0 +1,206 @@
+package requestmeta
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+)
+
+// Headers carrying the metadata of a request, gRPC metadata uses the same names in lower case
+const (
+	UserIDHeader         = "X-User-Id"
+	TenantIDHeader       = "X-Tenant-Id"
+	LocaleHeader         = "Accept-Language"
+	TraceIDHeader        = "X-Trace-Id"
+	ImpersonatorIDHeader = "X-Impersonator-Id"
+	ClientVersionHeader  = "X-Client-Version"
+	// AuthTimeHeader and AuthACRHeader are the auth_time and acr claims of the session, the Unix time
+	// the user last authenticated and how. The gateway sets them like X-User-Id.
+	AuthTimeHeader = "X-Auth-Time"
+	AuthACRHeader  = "X-Auth-Acr"
+	// TraceParentHeader is the W3C trace context, its trace ID is used when X-Trace-Id is not sent
+	TraceParentHeader = "Traceparent"
+)
+
+var traceParentPattern = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-[0-9a-f]{16}-[0-9a-f]{2}$`)
+
+// Meta is what the caller says about a request. None of it is verified, the authorization
+// middleware checks the user and tenant and authorization.UserIDFromContext returns them once
+// authorized.
+type Meta struct {
+	UserID   string
+	TenantID string
+	// Locale is the Accept-Language value as sent, utils.TranslatorFor picks the supported locale
+	Locale  string
+	TraceID string
+	// ImpersonatorID is the support user acting as UserID, empty when users act for themselves
+	ImpersonatorID string
+	ClientVersion  string
+	// AuthTime is the Unix time of the last authentication of the user, as sent
+	AuthTime string
+	AuthACR  string
+}
+
+type contextKey struct{}
+
+type field struct {
+	header string
+	value  *string
+}
+
+// fields pairs every header with its field, reading and writing metadata go through it
+func (m *Meta) fields() []field {
+	return []field{
+		{UserIDHeader, &m.UserID},
+		{TenantIDHeader, &m.TenantID},
+		{LocaleHeader, &m.Locale},
+		{TraceIDHeader, &m.TraceID},
+		{ImpersonatorIDHeader, &m.ImpersonatorID},
+		{ClientVersionHeader, &m.ClientVersion},
+		{AuthTimeHeader, &m.AuthTime},
+		{AuthACRHeader, &m.AuthACR},
+	}
+}
+
+// Impersonated reports whether a support user acts as the user of the request
+func (m Meta) Impersonated() bool {
+	return m.ImpersonatorID != "" && m.ImpersonatorID != m.UserID
+}
+
+// FromHeaders reads the metadata with get, which returns the first value of a header or ""
+func FromHeaders(get func(name string) string) Meta {
+	var meta Meta
+	for _, field := range meta.fields() {
+		*field.value = strings.TrimSpace(get(field.header))
+	}
+	if meta.TraceID == "" {
+		if match := traceParentPattern.FindStringSubmatch(strings.TrimSpace(get(TraceParentHeader))); match != nil {
+			meta.TraceID = match[1]
+		}
+	}
+	return meta
+}
+
+// FromHTTP reads the metadata of an HTTP request
+func FromHTTP(header http.Header) Meta {
+	return FromHeaders(header.Get)
+}
+
+// SetHTTP writes the metadata set in m to the headers of an outgoing HTTP request
+func (m Meta) SetHTTP(header http.Header) {
+	for _, field := range m.fields() {
+		if *field.value != "" {
+			header.Set(field.header, *field.value)
+		}
+	}
+}
+
+// FromIncomingGRPC reads the metadata of the gRPC call served with ctx
+func FromIncomingGRPC(ctx context.Context) Meta {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return FromHeaders(func(name string) string {
+		if values := md.Get(name); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	})
+}
+
+// AppendToOutgoingGRPC adds the metadata set in m to the gRPC calls made with the returned context
+func (m Meta) AppendToOutgoingGRPC(ctx context.Context) context.Context {
+	var pairs []string
+	for _, field := range m.fields() {
+		if *field.value != "" {
+			pairs = append(pairs, strings.ToLower(field.header), *field.value)
+		}
+	}
+	if len(pairs) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// WithMeta stores the metadata of the request in ctx, gRPC services call it with FromIncomingGRPC
+func WithMeta(ctx context.Context, meta Meta) context.Context {
+	if meta.TraceID == "" {
+		meta.TraceID = NewTraceID()
+	}
+	return context.WithValue(ctx, contextKey{}, meta)
+}
+
+// FromContext returns the metadata stored by the middleware, empty outside requests
+func FromContext(ctx context.Context) Meta {
+	meta, _ := ctx.Value(contextKey{}).(Meta)
+	return meta
+}
+
+// FromHuma returns the metadata stored by the middleware, read from the headers when the API is
+// served without it as in tests
+func FromHuma(ctx huma.Context) Meta {
+	if meta, ok := ctx.Context().Value(contextKey{}).(Meta); ok {
+		return meta
+	}
+	return FromHeaders(ctx.Header)
+}
+
+// WithHuma replaces the metadata of a Huma request, middlewares that authenticate the caller set the
+// user and tenant they verified for the ones after them
+func WithHuma(ctx huma.Context, meta Meta) huma.Context {
+	if meta.TraceID == "" {
+		meta.TraceID = FromHuma(ctx).TraceID
+	}
+	return huma.WithValue(ctx, contextKey{}, meta)
+}
+
+// UserID returns the user the caller claims to be, authorization.UserIDFromContext once authorized
+func UserID(ctx context.Context) string {
+	return FromContext(ctx).UserID
+}
+
+// TenantID returns the tenant the caller claims, authorization.TenantIDFromContext once authorized
+func TenantID(ctx context.Context) string {
+	return FromContext(ctx).TenantID
+}
+
+// Locale returns the Accept-Language of the request
+func Locale(ctx context.Context) string {
+	return FromContext(ctx).Locale
+}
+
+// TraceID returns the trace ID of the request, generated by the middleware when not sent
+func TraceID(ctx context.Context) string {
+	return FromContext(ctx).TraceID
+}
+
+// ImpersonatorID returns the support user acting for the user of the request, empty when none
+func ImpersonatorID(ctx context.Context) string {
+	return FromContext(ctx).ImpersonatorID
+}
+
+// ClientVersion returns the version of the client app that sent the request
+func ClientVersion(ctx context.Context) string {
+	return FromContext(ctx).ClientVersion
+}
+
+// Middleware puts the metadata in the request context of every route, Huma and Gin alike, and
+// echoes the trace ID so clients can quote it in support requests
+func Middleware(c *gin.Context) {
+	ctx := WithMeta(c.Request.Context(), FromHTTP(c.Request.Header))
+	c.Header(TraceIDHeader, TraceID(ctx))
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
+
+// NewTraceID returns a random trace ID in the W3C format, 32 lower case hex digits
+func NewTraceID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,192 @@
+package residency
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sandboxEntities "github.com/nahualventure/class-backend/core/app/sandbox/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// RegionHeader tells the client of a misdirected request the region of its tenant, gateways route
+// on it
+const RegionHeader = "X-Data-Region"
+
+type Config struct {
+	// Region is the region of the database cluster of DATABASE_URL. Empty turns residency off and
+	// every tenant is served from that cluster.
+	Region string
+	// DefaultRegion keeps the tenants Tenants does not list, every region is configured with the same
+	// one so a tenant is local to exactly one deployment
+	DefaultRegion string
+	// Tenants pins tenants to the region their data lives in, their sandboxes follow them
+	Tenants map[string]string
+	// Endpoints are the base URLs of the deployments of the regions, sent back to misdirected clients
+	Endpoints map[string]string
+}
+
+// ParseAssignments reads key=value entries such as "lycee-lyon=eu"
+func ParseAssignments(entries []string) (map[string]string, error) {
+	assignments := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		key, value, found := strings.Cut(entry, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !found || key == "" || value == "" {
+			return nil, fmt.Errorf("entry %q is not key=value", entry)
+		}
+		assignments[key] = value
+	}
+	return assignments, nil
+}
+
+// ClusterStore records the region of the database cluster, the first instance to start with a
+// region claims it for that region
+type ClusterStore interface {
+	// ClaimRegion records region for a cluster that has none and returns the region the cluster
+	// belongs to
+	ClaimRegion(ctx context.Context, region string, now time.Time) (string, error)
+}
+
+// Router maps tenants to the region of the cluster their data lives in. An instance connects to the
+// cluster of its own region only, the requests of tenants of other regions are refused before they
+// reach a repository and the client is sent to the deployment of their region.
+type Router struct {
+	config Config
+
+	mu          sync.Mutex
+	misdirected map[string]int64
+}
+
+func NewRouter(config Config) (*Router, error) {
+	if config.Region == "" && len(config.Tenants) > 0 {
+		return nil, fmt.Errorf("tenants are pinned to regions but the region of the cluster is not set")
+	}
+	if config.Region != "" && config.DefaultRegion == "" {
+		return nil, fmt.Errorf("the default region is not set, every region has to agree on it")
+	}
+	return &Router{
+		config:      config,
+		misdirected: make(map[string]int64),
+	}, nil
+}
+
+// Routing reports whether tenants are routed by region
+func (r *Router) Routing() bool {
+	return r.config.Region != ""
+}
+
+// Region is the region of the cluster the instance connects to
+func (r *Router) Region() string {
+	return r.config.Region
+}
+
+// RegionOf returns the region the data of the tenant lives in. Sandboxes of a tenant live with it.
+func (r *Router) RegionOf(tenantID string) string {
+	if !r.Routing() {
+		return ""
+	}
+	if region, pinned := r.config.Tenants[tenantID]; pinned {
+		return region
+	}
+	if parent, found := strings.CutSuffix(tenantID, sandboxEntities.SandboxSuffix); found {
+		if region, pinned := r.config.Tenants[parent]; pinned {
+			return region
+		}
+	}
+	return r.config.DefaultRegion
+}
+
+// ServesDefaultRegion reports whether the cluster of the instance keeps the tenants no region was
+// configured for, tenants created without one, like developer sandboxes, are created there
+func (r *Router) ServesDefaultRegion() bool {
+	return r.config.Region == r.config.DefaultRegion
+}
+
+// Local reports whether the data of the tenant lives in the cluster of the instance
+func (r *Router) Local(tenantID string) bool {
+	return r.RegionOf(tenantID) == r.config.Region
+}
+
+// Remote returns the tenants of tenantIDs whose data lives in another region, a query spanning
+// them cannot run on the cluster of the instance
+func (r *Router) Remote(tenantIDs []string) []string {
+	var remote []string
+	for _, tenantID := range tenantIDs {
+		if !r.Local(tenantID) {
+			remote = append(remote, tenantID)
+		}
+	}
+	return remote
+}
+
+// VerifyCluster claims the cluster for the region of the instance and fails when it already belongs
+// to another region, a DATABASE_URL of the wrong region would otherwise mix the data of both
+func (r *Router) VerifyCluster(ctx context.Context, store ClusterStore) error {
+	if !r.Routing() {
+		return nil
+	}
+	return VerifyCluster(ctx, store, r.config.Region)
+}
+
+// VerifyCluster claims the cluster of store for region and fails when it belongs to another one
+func VerifyCluster(ctx context.Context, store ClusterStore, region string) error {
+	claimed, err := store.ClaimRegion(ctx, region, time.Now())
+	if err != nil {
+		return err
+	}
+	if claimed != region {
+		return fmt.Errorf("the database cluster belongs to region %s, not %s", claimed, region)
+	}
+	return nil
+}
+
+// Middleware is a Huma middleware, it runs before authorization and the load shedding so requests
+// for tenants of other regions neither look up roles nor hold a slot. It reads the tenant the
+// request names, public operations naming one are refused too.
+func (r *Router) Middleware(ctx huma.Context, next func(huma.Context)) {
+	tenantID := requestmeta.FromHuma(ctx).TenantID
+	if tenantID == "" || r.Local(tenantID) {
+		next(ctx)
+		return
+	}
+
+	region := r.RegionOf(tenantID)
+	r.count(region)
+	ctx.SetHeader(RegionHeader, region)
+	utils.WriteApplicationError(ctx, appErrors.NewTenantInOtherRegionError(tenantID, region, r.config.Endpoints[region]))
+}
+
+func (r *Router) count(region string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.misdirected[region]++
+}
+
+// WriteMetrics writes the requests refused per region of their tenant in the Prometheus text format
+func (r *Router) WriteMetrics(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out strings.Builder
+	out.WriteString("# HELP http_misdirected_requests_total Requests refused because the data of their tenant lives in another region\n" +
+		"# TYPE http_misdirected_requests_total counter\n")
+	regions := make([]string, 0, len(r.misdirected))
+	for region := range r.misdirected {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	for _, region := range regions {
+		fmt.Fprintf(&out, "http_misdirected_requests_total{region=%q} %d\n", region, r.misdirected[region])
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
@@ -0,0 +1,90 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Files every module is registered in, relative to the repository root
+const (
+	EndpointMappingPath = "infra/shared/authorization/middleware.go"
+	PoliciesPath        = "infra/configs/policies.yaml"
+	SqlcConfigPath      = "sqlc.yaml"
+)
+
+// patchEndpointMapping maps the operations of the module at the end of EndpointMapping, reading
+// is view and creating is manage
+func (n Names) patchEndpointMapping(source []byte) ([]byte, error) {
+	start := bytes.Index(source, []byte("var EndpointMapping = map[string]ResourceAction{"))
+	if start < 0 {
+		return nil, errors.New("EndpointMapping not found")
+	}
+	end := bytes.Index(source[start:], []byte("\n}\n"))
+	if end < 0 {
+		return nil, errors.New("end of EndpointMapping not found")
+	}
+	end += start
+
+	entries := fmt.Sprintf("\n\n\t%q: {Resource: %q, Action: \"manage\"},\n\t%q: {Resource: %q, Action: \"view\"},\n\t%q: {Resource: %q, Action: \"view\"},",
+		"create-"+n.Kebab, n.Module, "get-"+n.Kebab, n.Module, "list-"+n.KebabPlural, n.Module)
+	patched := slices.Concat(source[:end], []byte(entries), source[end:])
+	formatted, err := format.Source(patched)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return formatted, nil
+}
+
+// patchPolicies grants DefaultRoles their actions on the module resource
+func (n Names) patchPolicies(source []byte) ([]byte, error) {
+	lines := strings.SplitAfter(string(source), "\n")
+	for _, role := range sortedRoles() {
+		header := slices.Index(lines, "  "+role+":\n")
+		if header < 0 || header+1 >= len(lines) || strings.TrimSpace(lines[header+1]) != "permissions:" {
+			return nil, errors.Newf("role %s not found", role)
+		}
+		// The permissions of the role end at the first line that is not indented under them
+		end := header + 2
+		for end < len(lines) && strings.HasPrefix(lines[end], "      ") {
+			end++
+		}
+		entry := fmt.Sprintf("      %s: [%s]\n", n.Module, strings.Join(DefaultRoles[role], ", "))
+		if end > 0 && !strings.HasSuffix(lines[end-1], "\n") {
+			lines[end-1] += "\n"
+		}
+		lines = slices.Insert(lines, end, entry)
+	}
+	return []byte(strings.Join(lines, "")), nil
+}
+
+// patchSqlcConfig adds the schema of the module after the last schema of the sqlc configuration
+func (n Names) patchSqlcConfig(source []byte) ([]byte, error) {
+	lines := strings.SplitAfter(string(source), "\n")
+	last := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, `- "infra/`) && strings.HasSuffix(trimmed, `/sql/schema.sql"`) {
+			last = i
+		}
+	}
+	if last < 0 {
+		return nil, errors.New("no schema listed")
+	}
+	indent := lines[last][:len(lines[last])-len(strings.TrimLeft(lines[last], " "))]
+	entry := fmt.Sprintf("%s- \"infra/%s/sql/schema.sql\"\n", indent, n.Module)
+	return []byte(strings.Join(slices.Insert(lines, last+1, entry), "")), nil
+}
+
+func sortedRoles() []string {
+	roles := make([]string, 0, len(DefaultRoles))
+	for role := range DefaultRoles {
+		roles = append(roles, role)
+	}
+	slices.Sort(roles)
+	return roles
+}
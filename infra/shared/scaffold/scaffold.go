@@ -0,0 +1,251 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+var (
+	modulePattern = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+	entityPattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// DefaultRoles are granted these actions on the resource of a new module, admins have all of them already
+var DefaultRoles = map[string][]string{
+	"department_head": {"view", "manage"},
+	"instructor":      {"view"},
+}
+
+// Spec names the module to scaffold and its first entity
+type Spec struct {
+	// Module is the directory and Casbin resource, lower case like "library" or "officehours"
+	Module string
+	// Entity is the exported name of the entity, like "FieldTrip"
+	Entity string
+}
+
+// Names are the spellings of the spec the templates use
+type Names struct {
+	Module          string // fieldtrips
+	ModuleTitle     string // Fieldtrips
+	Entity          string // FieldTrip
+	EntityVar       string // fieldTrip
+	EntityPlural    string // FieldTrips
+	EntityPluralVar string // fieldTrips
+	Kebab           string // field-trip
+	KebabPlural     string // field-trips
+	Snake           string // field_trip
+	SnakePlural     string // field_trips
+	Upper           string // FIELD_TRIP
+	Table           string // field_trips
+	Human           string // field trip
+	HumanPlural     string // field trips
+}
+
+func NewNames(spec Spec) (Names, error) {
+	if !modulePattern.MatchString(spec.Module) {
+		return Names{}, errors.Newf("module %q must be lower case letters and digits", spec.Module)
+	}
+	if !entityPattern.MatchString(spec.Entity) {
+		return Names{}, errors.Newf("entity %q must be an exported Go name", spec.Entity)
+	}
+
+	words := splitWords(spec.Entity)
+	last := words[len(words)-1]
+	pluralWords := append(append([]string{}, words[:len(words)-1]...), plural(last))
+	// Only the last word changes, an acronym keeps its case: "URL" becomes "URLs"
+	prefix, lastWord := spec.Entity[:len(spec.Entity)-len(last)], spec.Entity[len(spec.Entity)-len(last):]
+	entityPlural := prefix + strings.ToUpper(plural(last)[:1]) + plural(last)[1:]
+	if len(lastWord) > 1 && strings.ToUpper(lastWord) == lastWord {
+		entityPlural = spec.Entity + "s"
+	}
+
+	return Names{
+		Module:          spec.Module,
+		ModuleTitle:     strings.ToUpper(spec.Module[:1]) + spec.Module[1:],
+		Entity:          spec.Entity,
+		EntityVar:       lowerFirst(spec.Entity),
+		EntityPlural:    entityPlural,
+		EntityPluralVar: lowerFirst(entityPlural),
+		Kebab:           strings.Join(words, "-"),
+		KebabPlural:     strings.Join(pluralWords, "-"),
+		Snake:           strings.Join(words, "_"),
+		SnakePlural:     strings.Join(pluralWords, "_"),
+		Upper:           strings.ToUpper(strings.Join(words, "_")),
+		Table:           strings.Join(pluralWords, "_"),
+		Human:           strings.Join(words, " "),
+		HumanPlural:     strings.Join(pluralWords, " "),
+	}, nil
+}
+
+// file is a template and the path it renders to, relative to the repository root
+type file struct {
+	template string
+	path     string
+}
+
+func (n Names) files() []file {
+	core := filepath.Join("core", "app", n.Module)
+	useCases := filepath.Join(core, "application", "use-cases")
+	infra := filepath.Join("infra", n.Module)
+	return []file{
+		{"entity.go.tmpl", filepath.Join(core, "domain", "entities", n.Kebab+".go")},
+		{"errors.go.tmpl", filepath.Join(core, "domain", "errors", n.Module+"-errors.go")},
+		{"ports.go.tmpl", filepath.Join(core, "domain", "ports", n.Module+"-ports.go")},
+		{"create-command.go.tmpl", filepath.Join(useCases, "create-"+n.Kebab+"-use-case", "create-"+n.Kebab+"-command.go")},
+		{"create-use-case.go.tmpl", filepath.Join(useCases, "create-"+n.Kebab+"-use-case", "create-"+n.Kebab+"-use-case.go")},
+		{"get-command.go.tmpl", filepath.Join(useCases, "get-"+n.Kebab+"-use-case", "get-"+n.Kebab+"-command.go")},
+		{"get-use-case.go.tmpl", filepath.Join(useCases, "get-"+n.Kebab+"-use-case", "get-"+n.Kebab+"-use-case.go")},
+		{"list-command.go.tmpl", filepath.Join(useCases, "list-"+n.KebabPlural+"-use-case", "list-"+n.KebabPlural+"-command.go")},
+		{"list-use-case.go.tmpl", filepath.Join(useCases, "list-"+n.KebabPlural+"-use-case", "list-"+n.KebabPlural+"-use-case.go")},
+		{"schema.sql.tmpl", filepath.Join(infra, "sql", "schema.sql")},
+		{"queries.sql.tmpl", filepath.Join(infra, "sql", "queries", n.Module+".sql")},
+		{"adapter.go.tmpl", filepath.Join(infra, "adapters", n.Kebab+"-repository-adapter.go")},
+		{"dtos.go.tmpl", filepath.Join(infra, "handlers", n.Module+"-dtos.go")},
+		{"handlers.go.tmpl", filepath.Join(infra, "handlers", n.Module+"-handlers.go")},
+		{"service.proto.tmpl", filepath.Join("proto", n.Module, "v1", n.Module+".proto")},
+		{"use-cases_test.go.tmpl", filepath.Join("core", "tests", "app", n.Module, "application", "use-cases", n.Module+"_use_cases_test.go")},
+	}
+}
+
+// Generate writes the module into the repository at root and registers it in the endpoint mapping,
+// the role policies and the sqlc configuration. It refuses to touch a module that already exists.
+// Returns the paths written, relative to root.
+func Generate(root string, spec Spec) ([]string, error) {
+	names, err := NewNames(spec)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range []string{filepath.Join("core", "app", names.Module), filepath.Join("infra", names.Module)} {
+		if _, err := os.Stat(filepath.Join(root, dir)); err == nil {
+			return nil, errors.Newf("module %s already exists in %s", names.Module, dir)
+		}
+	}
+
+	// Render everything before writing so a template error leaves the tree untouched
+	rendered := make(map[string][]byte)
+	var written []string
+	for _, f := range names.files() {
+		content, err := render(f.template, names)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rendering %s", f.template)
+		}
+		rendered[f.path] = content
+		written = append(written, f.path)
+	}
+	patches := map[string]func([]byte) ([]byte, error){
+		EndpointMappingPath: names.patchEndpointMapping,
+		PoliciesPath:        names.patchPolicies,
+		SqlcConfigPath:      names.patchSqlcConfig,
+	}
+	for path, patch := range patches {
+		current, err := os.ReadFile(filepath.Join(root, path))
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", path)
+		}
+		if rendered[path], err = patch(current); err != nil {
+			return nil, errors.Wrapf(err, "updating %s", path)
+		}
+		written = append(written, path)
+	}
+
+	for _, path := range written {
+		target := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if err := os.WriteFile(target, rendered[path], 0o644); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return written, nil
+}
+
+// NextSteps lists what Generate leaves to the author: wiring the container and generating code
+func NextSteps(spec Spec) string {
+	names, err := NewNames(spec)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf(`Next steps:
+  1. make generate to run sqlc and buf on the new queries and proto service
+  2. wire the module in infra/shared/container:
+       Adapters:             %[6]s: %[1]sAdapters.NewPostgres%[2]sRepository(pool),
+       Container and New:    %[3]s: %[1]sHandlers.New%[3]sHandlers(
+                                 create_%[4]s_use_case.NewCreate%[2]sUseCase(adapters.%[6]s),
+                                 get_%[4]s_use_case.NewGet%[2]sUseCase(adapters.%[6]s),
+                                 list_%[5]s_use_case.NewList%[6]sUseCase(adapters.%[6]s),
+                             ),
+       Modules:              c.%[3]s,
+  3. add a migration for infra/%[1]s/sql/schema.sql
+  4. review the %[1]s permissions given to %[7]s in infra/configs/policies.yaml
+`, names.Module, names.Entity, names.ModuleTitle, names.Snake, names.SnakePlural, names.EntityPlural,
+		strings.Join(sortedRoles(), " and "))
+}
+
+func render(name string, names Names) ([]byte, error) {
+	tmpl, err := template.ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, names); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if !strings.HasSuffix(name, ".go.tmpl") {
+		return out.Bytes(), nil
+	}
+	// Templates do not align struct fields, gofmt does
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "generated Go does not parse")
+	}
+	return formatted, nil
+}
+
+// splitWords splits a Go name into lower case words, keeping acronyms together: "HTTPRoute" is
+// "http" and "route"
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		upper := unicode.IsUpper(runes[i])
+		startsWord := upper && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])))
+		if startsWord {
+			words = append(words, strings.ToLower(string(runes[start:i])))
+			start = i
+		}
+	}
+	return append(words, strings.ToLower(string(runes[start:])))
+}
+
+func plural(word string) string {
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	default:
+		return word + "s"
+	}
+}
+
+func lowerFirst(name string) string {
+	words := splitWords(name)
+	// An acronym at the start is lowered whole, "URLLink" becomes "urlLink"
+	return words[0] + name[len(words[0]):]
+}
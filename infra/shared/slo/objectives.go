@@ -0,0 +1,112 @@
+package slo
+
+import (
+	"net/http"
+	"time"
+)
+
+// Window is the period every objective is measured over, its error budget resets as it rolls
+const Window = 30 * 24 * time.Hour
+
+// EndpointClass groups endpoints that share objectives
+type EndpointClass string
+
+const (
+	ClassRead  EndpointClass = "read"
+	ClassWrite EndpointClass = "write"
+	// ClassBulk covers imports, exports and file transfers, which move many rows or bytes per request
+	ClassBulk EndpointClass = "bulk"
+)
+
+// Classes lists the classes in the order they are reported
+var Classes = []EndpointClass{ClassRead, ClassWrite, ClassBulk}
+
+// BulkOperations maps the Huma operation IDs of bulk endpoints, other endpoints are read or write
+// endpoints by their method
+var BulkOperations = map[string]bool{
+	"batch-signup":                true,
+	"import-users":                true,
+	"export-users":                true,
+	"export-form-responses":       true,
+	"import-holidays":             true,
+	"restore-archive-batch":       true,
+	"upload-resource-version":     true,
+	"download-resource-version":   true,
+	"download-report":             true,
+	"download-attachment":         true,
+	"download-appointment-invite": true,
+	"preview-import":              true,
+	"start-import":                true,
+	"download-import-errors":      true,
+	"merge-users":                 true,
+	"undo-user-merge":             true,
+	"sync-directory-users":        true,
+	"sendgrid-webhook":            true,
+}
+
+// ClassOf classifies a request by its operation and method
+func ClassOf(method string, operationID string) EndpointClass {
+	if BulkOperations[operationID] {
+		return ClassBulk
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ClassRead
+	default:
+		return ClassWrite
+	}
+}
+
+// Kind is what an objective measures
+type Kind string
+
+const (
+	// KindAvailability counts the requests that did not fail with a 5xx status
+	KindAvailability Kind = "availability"
+	// KindLatency counts the requests that did not fail and were served within the threshold
+	KindLatency Kind = "latency"
+)
+
+// Objective is the share of good requests of a class that must be met over the window
+type Objective struct {
+	Name      string
+	Class     EndpointClass
+	Kind      Kind
+	Target    float64
+	Threshold time.Duration // Latency objectives only
+}
+
+// Objectives are the service level objectives of the API
+var Objectives = []Objective{
+	{Name: "read-availability", Class: ClassRead, Kind: KindAvailability, Target: 0.999},
+	{Name: "write-availability", Class: ClassWrite, Kind: KindAvailability, Target: 0.999},
+	{Name: "bulk-availability", Class: ClassBulk, Kind: KindAvailability, Target: 0.99},
+	{Name: "read-latency", Class: ClassRead, Kind: KindLatency, Target: 0.99, Threshold: 300 * time.Millisecond},
+	{Name: "write-latency", Class: ClassWrite, Kind: KindLatency, Target: 0.99, Threshold: time.Second},
+	{Name: "bulk-latency", Class: ClassBulk, Kind: KindLatency, Target: 0.95, Threshold: 10 * time.Second},
+}
+
+// Severity tells who an alert goes to
+type Severity string
+
+const (
+	SeverityPage   Severity = "page"
+	SeverityTicket Severity = "ticket"
+)
+
+// BurnRateAlert fires when the error budget burns Factor times faster than the window allows over
+// both the long and the short window, the short one stops the alert soon after the burn stops
+type BurnRateAlert struct {
+	Severity Severity
+	Long     time.Duration
+	Short    time.Duration
+	Factor   float64
+}
+
+// BurnRateAlerts page when 2% of the budget goes in an hour or 5% in six hours, and open a ticket
+// when 10% goes in three days
+var BurnRateAlerts = []BurnRateAlert{
+	{Severity: SeverityPage, Long: time.Hour, Short: 5 * time.Minute, Factor: 14.4},
+	{Severity: SeverityPage, Long: 6 * time.Hour, Short: 30 * time.Minute, Factor: 6},
+	{Severity: SeverityTicket, Long: 72 * time.Hour, Short: 6 * time.Hour, Factor: 1},
+}
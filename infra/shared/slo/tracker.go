@@ -0,0 +1,358 @@
+package slo
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// Deploy gate states reported by /slo
+const (
+	StatusOK = "OK"
+	// StatusBurning means a page alert fires, the budget goes faster than deploys should add risk to
+	StatusBurning = "BURNING"
+	// StatusExhausted means an objective spent its whole budget over the window
+	StatusExhausted = "EXHAUSTED"
+)
+
+// bucket counts the requests of a class in one minute
+type bucket struct {
+	minute int64
+	total  int64
+	failed int64
+	slow   int64
+}
+
+type objectiveCounts struct {
+	good int64
+	bad  int64
+}
+
+// Tracker measures the objectives from the requests served by the instance. Requests are counted
+// in one minute buckets over the window, so a restart starts the window over: alerting across
+// instances uses the slo_requests_total counters, the tracker gates deploys of the instance.
+type Tracker struct {
+	objectives []Objective
+	alerts     []BurnRateAlert
+	thresholds map[EndpointClass]time.Duration
+
+	mu       sync.Mutex
+	buckets  map[EndpointClass][]bucket
+	lifetime map[string]*objectiveCounts
+}
+
+func NewTracker(objectives []Objective, alerts []BurnRateAlert) *Tracker {
+	t := &Tracker{
+		objectives: objectives,
+		alerts:     alerts,
+		thresholds: make(map[EndpointClass]time.Duration),
+		buckets:    make(map[EndpointClass][]bucket),
+		lifetime:   make(map[string]*objectiveCounts),
+	}
+	for _, objective := range objectives {
+		if objective.Kind == KindLatency {
+			t.thresholds[objective.Class] = objective.Threshold
+		}
+		t.lifetime[objective.Name] = &objectiveCounts{}
+	}
+	return t
+}
+
+// Middleware records the status and latency of every request. It runs first so requests rejected
+// by the other middlewares count too.
+func (t *Tracker) Middleware(ctx huma.Context, next func(huma.Context)) {
+	start := time.Now()
+	next(ctx)
+
+	status := ctx.Status()
+	if status == 0 {
+		status = http.StatusOK
+	}
+	t.Record(ClassOf(ctx.Method(), ctx.Operation().OperationID), status, time.Since(start), start)
+}
+
+// Record counts a request of the class served at at
+func (t *Tracker) Record(class EndpointClass, status int, latency time.Duration, at time.Time) {
+	failed := status >= http.StatusInternalServerError
+	threshold, hasThreshold := t.thresholds[class]
+	slow := !failed && hasThreshold && latency > threshold
+	minute := at.Unix() / 60
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	buckets, ok := t.buckets[class]
+	if !ok {
+		buckets = make([]bucket, int(Window/time.Minute))
+		t.buckets[class] = buckets
+	}
+	b := &buckets[minute%int64(len(buckets))]
+	if b.minute != minute {
+		*b = bucket{minute: minute}
+	}
+	b.total++
+	if failed {
+		b.failed++
+	}
+	if slow {
+		b.slow++
+	}
+
+	for _, objective := range t.objectives {
+		if objective.Class != class {
+			continue
+		}
+		counts := t.lifetime[objective.Name]
+		if failed || (objective.Kind == KindLatency && slow) {
+			counts.bad++
+		} else {
+			counts.good++
+		}
+	}
+}
+
+// BurnRate is how fast the error budget went over a window, 1 spends it exactly over Window
+type BurnRate struct {
+	Window string  `json:"window"`
+	Rate   float64 `json:"rate"`
+}
+
+// FiringAlert is a burn rate alert whose long and short windows are both over the factor
+type FiringAlert struct {
+	Severity    Severity `json:"severity"`
+	LongWindow  string   `json:"long_window"`
+	ShortWindow string   `json:"short_window"`
+	Factor      float64  `json:"factor"`
+}
+
+// ObjectiveStatus is where an objective stands over the window
+type ObjectiveStatus struct {
+	Name                 string        `json:"name"`
+	Class                EndpointClass `json:"class"`
+	Kind                 Kind          `json:"kind"`
+	Target               float64       `json:"target"`
+	ThresholdMs          int64         `json:"threshold_ms,omitempty"`
+	Good                 int64         `json:"good"`
+	Total                int64         `json:"total"`
+	SLI                  float64       `json:"sli"`
+	ErrorBudgetRemaining float64       `json:"error_budget_remaining" doc:"Share of the budget left, negative once overspent"`
+	BurnRates            []BurnRate    `json:"burn_rates"`
+	Alerts               []FiringAlert `json:"alerts"`
+}
+
+// Report is the state of every objective and the resulting deploy gate
+type Report struct {
+	Status     string            `json:"status"`
+	Window     string            `json:"window"`
+	Objectives []ObjectiveStatus `json:"objectives"`
+}
+
+// Report evaluates the objectives at now
+func (t *Tracker) Report(now time.Time) Report {
+	windows := t.windows()
+	counts := t.count(windows, now)
+
+	report := Report{Status: StatusOK, Window: windowLabel(Window)}
+	for _, objective := range t.objectives {
+		classCounts := counts[objective.Class]
+		good, total := classCounts[Window].good(objective.Kind)
+		status := ObjectiveStatus{
+			Name:                 objective.Name,
+			Class:                objective.Class,
+			Kind:                 objective.Kind,
+			Target:               objective.Target,
+			ThresholdMs:          objective.Threshold.Milliseconds(),
+			Good:                 good,
+			Total:                total,
+			SLI:                  1,
+			ErrorBudgetRemaining: 1,
+			Alerts:               []FiringAlert{},
+		}
+		if total > 0 {
+			status.SLI = float64(good) / float64(total)
+			status.ErrorBudgetRemaining = 1 - burnRate(good, total, objective.Target)
+		}
+
+		for _, window := range windows {
+			good, total := classCounts[window].good(objective.Kind)
+			status.BurnRates = append(status.BurnRates, BurnRate{Window: windowLabel(window), Rate: burnRate(good, total, objective.Target)})
+		}
+		for _, alert := range t.alerts {
+			longGood, longTotal := classCounts[alert.Long].good(objective.Kind)
+			shortGood, shortTotal := classCounts[alert.Short].good(objective.Kind)
+			if burnRate(longGood, longTotal, objective.Target) > alert.Factor && burnRate(shortGood, shortTotal, objective.Target) > alert.Factor {
+				status.Alerts = append(status.Alerts, FiringAlert{
+					Severity:    alert.Severity,
+					LongWindow:  windowLabel(alert.Long),
+					ShortWindow: windowLabel(alert.Short),
+					Factor:      alert.Factor,
+				})
+				if alert.Severity == SeverityPage && report.Status == StatusOK {
+					report.Status = StatusBurning
+				}
+			}
+		}
+		if total > 0 && status.ErrorBudgetRemaining <= 0 {
+			report.Status = StatusExhausted
+		}
+
+		report.Objectives = append(report.Objectives, status)
+	}
+	return report
+}
+
+// Handler serves the report for deploy gating, 503 unless the status is OK
+func (t *Tracker) Handler(c *gin.Context) {
+	report := t.Report(time.Now())
+	code := http.StatusOK
+	if report.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, report)
+}
+
+// WriteMetrics writes the good and bad requests of every objective, and the budget and burn rates
+// the instance computes, in the Prometheus text format
+func (t *Tracker) WriteMetrics(w io.Writer) error {
+	report := t.Report(time.Now())
+	t.mu.Lock()
+	lifetime := make(map[string]objectiveCounts, len(t.lifetime))
+	for name, counts := range t.lifetime {
+		lifetime[name] = *counts
+	}
+	t.mu.Unlock()
+
+	if _, err := fmt.Fprint(w, "# HELP slo_requests_total Requests counted by each objective\n"+
+		"# TYPE slo_requests_total counter\n"); err != nil {
+		return err
+	}
+	for _, objective := range t.objectives {
+		counts := lifetime[objective.Name]
+		if _, err := fmt.Fprintf(w, "slo_requests_total{objective=%q,outcome=\"good\"} %d\n"+
+			"slo_requests_total{objective=%q,outcome=\"bad\"} %d\n",
+			objective.Name, counts.good, objective.Name, counts.bad); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprint(w, "# HELP slo_error_budget_remaining Share of the error budget left over the window on this instance\n"+
+		"# TYPE slo_error_budget_remaining gauge\n"); err != nil {
+		return err
+	}
+	for _, status := range report.Objectives {
+		if _, err := fmt.Fprintf(w, "slo_error_budget_remaining{objective=%q} %g\n", status.Name, status.ErrorBudgetRemaining); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprint(w, "# HELP slo_burn_rate Speed the error budget goes at over a window on this instance\n"+
+		"# TYPE slo_burn_rate gauge\n"); err != nil {
+		return err
+	}
+	for _, status := range report.Objectives {
+		for _, rate := range status.BurnRates {
+			if _, err := fmt.Fprintf(w, "slo_burn_rate{objective=%q,window=%q} %g\n", status.Name, rate.Window, rate.Rate); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := fmt.Fprint(w, "# HELP slo_alert_firing Burn rate alerts firing on this instance\n"+
+		"# TYPE slo_alert_firing gauge\n"); err != nil {
+		return err
+	}
+	for _, status := range report.Objectives {
+		for _, alert := range status.Alerts {
+			if _, err := fmt.Fprintf(w, "slo_alert_firing{objective=%q,severity=%q,long_window=%q} 1\n",
+				status.Name, alert.Severity, alert.LongWindow); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type windowCounts struct {
+	total  int64
+	failed int64
+	slow   int64
+}
+
+// good returns the good and counted requests for an objective kind
+func (c windowCounts) good(kind Kind) (int64, int64) {
+	if kind == KindLatency {
+		return c.total - c.failed - c.slow, c.total - c.failed
+	}
+	return c.total - c.failed, c.total
+}
+
+// windows lists the objective window and the alert windows, shortest first
+func (t *Tracker) windows() []time.Duration {
+	unique := map[time.Duration]bool{Window: true}
+	for _, alert := range t.alerts {
+		unique[alert.Long] = true
+		unique[alert.Short] = true
+	}
+	windows := make([]time.Duration, 0, len(unique))
+	for window := range unique {
+		windows = append(windows, window)
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+	return windows
+}
+
+// count sums the buckets of every class over every window ending at now
+func (t *Tracker) count(windows []time.Duration, now time.Time) map[EndpointClass]map[time.Duration]windowCounts {
+	nowMinute := now.Unix() / 60
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	counts := make(map[EndpointClass]map[time.Duration]windowCounts, len(t.buckets))
+	for class, buckets := range t.buckets {
+		classCounts := make(map[time.Duration]windowCounts, len(windows))
+		for _, b := range buckets {
+			age := nowMinute - b.minute
+			if b.total == 0 || age < 0 {
+				continue
+			}
+			for _, window := range windows {
+				if age >= int64(window/time.Minute) {
+					continue
+				}
+				c := classCounts[window]
+				c.total += b.total
+				c.failed += b.failed
+				c.slow += b.slow
+				classCounts[window] = c
+			}
+		}
+		counts[class] = classCounts
+	}
+	return counts
+}
+
+// burnRate is the share of bad requests over the share the target allows
+func burnRate(good int64, total int64, target float64) float64 {
+	if total == 0 || target >= 1 {
+		return 0
+	}
+	return (float64(total-good) / float64(total)) / (1 - target)
+}
+
+func windowLabel(window time.Duration) string {
+	switch {
+	case window%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", int(window/(24*time.Hour)))
+	case window%time.Hour == 0:
+		return fmt.Sprintf("%dh", int(window/time.Hour))
+	default:
+		return fmt.Sprintf("%dm", int(window/time.Minute))
+	}
+}
@@ -0,0 +1,6 @@
+-- name: ClaimClusterRegion :one
+-- The no-op update returns the region of a cluster claimed before
+INSERT INTO cluster_region (singleton, region, claimed_at)
+VALUES (TRUE, @region, @claimed_at)
+ON CONFLICT (singleton) DO UPDATE SET singleton = cluster_region.singleton
+RETURNING region;
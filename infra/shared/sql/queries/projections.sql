@@ -0,0 +1,22 @@
+-- name: ListOutboxEventsAfter :many
+SELECT *
+FROM outbox_events
+WHERE position > @position
+ORDER BY position
+LIMIT @batch_size;
+
+-- name: GetProjectionCheckpoint :one
+SELECT position
+FROM projection_checkpoints
+WHERE projection_name = @projection_name;
+
+-- name: UpsertProjectionCheckpoint :exec
+INSERT INTO projection_checkpoints (projection_name, position, updated_at)
+VALUES (@projection_name, @position, NOW())
+ON CONFLICT (projection_name) DO UPDATE
+SET position = EXCLUDED.position,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: InsertOutboxEvent :exec
+INSERT INTO outbox_events (event_type, aggregate_id, tenant_id, payload, occurred_at)
+VALUES (@event_type, @aggregate_id, @tenant_id, @payload, @occurred_at);
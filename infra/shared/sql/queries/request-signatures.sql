@@ -0,0 +1,9 @@
+-- name: ClaimRequestSignature :execrows
+-- No row is inserted for a signature accepted before
+INSERT INTO request_signatures (signature, expires_at)
+VALUES (@signature, @expires_at)
+ON CONFLICT (signature) DO NOTHING;
+
+-- name: DeleteExpiredRequestSignatures :execrows
+DELETE FROM request_signatures
+WHERE expires_at < @now;
@@ -0,0 +1,28 @@
+-- Updates only apply while @owner still holds the instance, no rows are affected once another
+-- coordinator took it over
+-- name: UpsertSagaInstance :execrows
+INSERT INTO saga_instances (id, saga_name, status, completed_steps, data, failure_reason, owner, lease_until, created_at, updated_at)
+VALUES (@id, @saga_name, @status, @completed_steps, @data, @failure_reason, @owner, @lease_until, @created_at, @updated_at)
+ON CONFLICT (id) DO UPDATE
+SET status = EXCLUDED.status,
+    completed_steps = EXCLUDED.completed_steps,
+    data = EXCLUDED.data,
+    failure_reason = EXCLUDED.failure_reason,
+    lease_until = EXCLUDED.lease_until,
+    updated_at = EXCLUDED.updated_at
+WHERE saga_instances.owner = EXCLUDED.owner;
+
+-- Leases the unfinished instances whose lease expired to @owner, instances locked by a concurrent
+-- claim are skipped
+-- name: ClaimUnfinishedSagaInstances :many
+UPDATE saga_instances
+SET owner = @owner,
+    lease_until = @lease_until
+WHERE id IN (
+    SELECT id
+    FROM saga_instances
+    WHERE status IN ('running', 'compensating') AND saga_instances.lease_until < @now
+    ORDER BY created_at
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
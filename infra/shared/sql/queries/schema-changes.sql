@@ -0,0 +1,16 @@
+-- name: GetSchemaChange :one
+SELECT *
+FROM schema_changes
+WHERE name = @name;
+
+-- name: SaveSchemaChange :exec
+INSERT INTO schema_changes (name, phase, backfill_cursor, backfilled, total, phase_changed_at, backfilled_at, updated_at)
+VALUES (@name, @phase, @backfill_cursor, @backfilled, @total, @phase_changed_at, @backfilled_at, @updated_at)
+ON CONFLICT (name) DO UPDATE
+SET phase = EXCLUDED.phase,
+    backfill_cursor = EXCLUDED.backfill_cursor,
+    backfilled = EXCLUDED.backfilled,
+    total = EXCLUDED.total,
+    phase_changed_at = EXCLUDED.phase_changed_at,
+    backfilled_at = EXCLUDED.backfilled_at,
+    updated_at = EXCLUDED.updated_at;
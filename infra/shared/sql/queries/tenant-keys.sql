@@ -0,0 +1,15 @@
+-- name: GetTenantKey :one
+SELECT *
+FROM tenant_keys
+WHERE tenant_id = @tenant_id;
+
+-- name: CreateTenantKey :exec
+INSERT INTO tenant_keys (tenant_id, wrapped_key, created_at, destroyed_at)
+VALUES (@tenant_id, @wrapped_key, @created_at, @destroyed_at)
+ON CONFLICT (tenant_id) DO NOTHING;
+
+-- name: DestroyTenantKey :exec
+UPDATE tenant_keys
+SET wrapped_key = NULL,
+    destroyed_at = COALESCE(destroyed_at, @destroyed_at)
+WHERE tenant_id = @tenant_id;
@@ -0,0 +1,36 @@
+-- Deliveries whose source already has the event ID are not inserted, no rows are affected
+-- name: CreateWebhookDelivery :execrows
+INSERT INTO webhook_deliveries (id, source, event_id, payload, headers, status, attempts, next_attempt_at, received_at)
+VALUES (@id, @source, @event_id, @payload, @headers, @status, 0, @next_attempt_at, @received_at)
+ON CONFLICT (source, event_id) DO NOTHING;
+
+-- Leases the due deliveries of the sources until @lease_until and counts an attempt for each,
+-- deliveries locked by a concurrent claim are skipped
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET status = 'processing',
+    attempts = attempts + 1,
+    next_attempt_at = @lease_until
+WHERE id IN (
+    SELECT id
+    FROM webhook_deliveries
+    WHERE status IN ('pending', 'processing')
+      AND webhook_deliveries.next_attempt_at <= @now
+      AND source = ANY(@sources::varchar[])
+    ORDER BY received_at
+    LIMIT @max_deliveries
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: SaveWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET status = @status,
+    last_error = @last_error,
+    next_attempt_at = @next_attempt_at,
+    processed_at = @processed_at
+WHERE id = @id;
+
+-- name: DeleteProcessedWebhookDeliveries :execrows
+DELETE FROM webhook_deliveries
+WHERE status = 'processed' AND processed_at < @before;
@@ -0,0 +1,99 @@
+-- Saga instances for cross-module workflows
+-- Progress is persisted after every step so unfinished sagas can be resumed after a crash. An
+-- instance is leased by the coordinator running it, others only resume it once the lease expired
+CREATE TABLE saga_instances (
+    id UUID PRIMARY KEY,
+    saga_name VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,           -- running, completed, compensating, compensated, failed
+    completed_steps INTEGER NOT NULL,
+    data JSONB NOT NULL,
+    failure_reason TEXT,
+    owner VARCHAR(100) NOT NULL,
+    lease_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_saga_instances_status ON saga_instances(status);
+
+
+-- Outbox of domain events, written in the same transaction as the change that produced them.
+-- Partitioned by month of occurred_at, monthly partitions are created ahead by the partition maintenance
+-- worker and rows that land in the default partition are moved into theirs.
+CREATE TABLE outbox_events (
+    position BIGSERIAL NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (position, occurred_at)
+) PARTITION BY RANGE (occurred_at);
+
+CREATE TABLE outbox_events_default PARTITION OF outbox_events DEFAULT;
+
+-- Last outbox position applied by each projection
+CREATE TABLE projection_checkpoints (
+    projection_name VARCHAR(100) PRIMARY KEY,
+    position BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Data key of every tenant, wrapped by the master key which stays in the secret provider.
+-- Shredding a tenant drops wrapped_key and keeps the row so the tenant never gets a new key.
+CREATE TABLE tenant_keys (
+    tenant_id VARCHAR(255) PRIMARY KEY,
+    wrapped_key BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    destroyed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Phase and backfill progress of expand/contract schema changes, a change without a row is still old
+CREATE TABLE schema_changes (
+    name VARCHAR(100) PRIMARY KEY,
+    phase VARCHAR(20) NOT NULL CHECK (phase IN ('old', 'expanded', 'read_new', 'contracted')),
+    backfill_cursor TEXT NOT NULL DEFAULT '',
+    backfilled BIGINT NOT NULL DEFAULT 0,
+    total BIGINT NOT NULL DEFAULT 0,
+    phase_changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    backfilled_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Inbound webhook deliveries, persisted once their signature was verified and before they are
+-- processed. The worker claims the due ones and leases them until next_attempt_at, retried events
+-- of a source are acknowledged without a second row.
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY,
+    source VARCHAR(50) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    payload BYTEA NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL,           -- pending, processing, processed, failed
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (source, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status IN ('pending', 'processing');
+CREATE INDEX idx_webhook_deliveries_processed ON webhook_deliveries(processed_at) WHERE status = 'processed';
+
+-- Region whose data the cluster keeps, claimed by the first instance started with DATA_REGION.
+-- Instances of another region refuse to start on the cluster.
+CREATE TABLE cluster_region (
+    singleton BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (singleton),
+    region VARCHAR(50) NOT NULL,
+    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Request signatures accepted by any instance, a signature is accepted once until it expires. Expired
+-- rows are deleted every minute.
+CREATE TABLE request_signatures (
+    signature VARCHAR(128) PRIMARY KEY,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_request_signatures_expires_at ON request_signatures(expires_at);
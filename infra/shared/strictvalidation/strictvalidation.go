@@ -0,0 +1,74 @@
+package strictvalidation
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// Enable makes the API reject what it would otherwise let through without a word, so typos of
+// clients fail in staging instead of being ignored. Huma already rejects unknown body fields and
+// values of the wrong type, strict mode also rejects:
+//   - body fields in another casing, Huma reads "Email" as "email" otherwise
+//   - query parameters the operation does not declare, such as ?limt=10
+//
+// Both fail like the validation of Huma, 422 VALIDATION_ERROR with one error per field in the
+// context. Casing is checked by Huma for every API of the process.
+func Enable(api huma.API) {
+	huma.ValidateStrictCasing = true
+	api.UseMiddleware(Middleware)
+}
+
+// Middleware rejects the query parameters the operation does not declare, it runs after
+// authorization like the validation of Huma
+func Middleware(ctx huma.Context, next func(huma.Context)) {
+	operation := ctx.Operation()
+	if operation == nil {
+		next(ctx)
+		return
+	}
+
+	declared := make(map[string]bool)
+	for _, param := range operation.Parameters {
+		if param.In == "query" {
+			declared[param.Name] = true
+		}
+	}
+
+	requestURL := ctx.URL()
+	var unknown []string
+	for name := range requestURL.Query() {
+		// Deep object parameters are sent as name[key]
+		base, _, _ := strings.Cut(name, "[")
+		if !declared[name] && !declared[base] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		next(ctx)
+		return
+	}
+
+	sort.Strings(unknown)
+	details := make([]error, 0, len(unknown))
+	for _, name := range unknown {
+		details = append(details, &huma.ErrorDetail{
+			Location: "query." + name,
+			Message:  "unexpected property",
+			Value:    ctx.Query(name),
+		})
+	}
+	statusErr := utils.NewHumaError(http.StatusUnprocessableEntity, "validation failed", details...)
+
+	ctx.SetHeader("Content-Type", "application/json")
+	ctx.SetStatus(statusErr.GetStatus())
+	if err := json.NewEncoder(ctx.BodyWriter()).Encode(statusErr); err != nil {
+		log.Printf("failed to write error response: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpStatusToGRPCCode maps the HTTP status of an error code to the gRPC code of the same failure,
+// statuses not listed are Internal
+var httpStatusToGRPCCode = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnprocessableEntity: codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.Aborted,
+	http.StatusGone:                codes.FailedPrecondition,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusUpgradeRequired:     codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// ApplicationErrorToGRPCStatus converts any error into a gRPC status. The code follows the HTTP
+// status of the error code and the ErrorDetail with the code, message and context of the HTTP
+// envelope is attached as a detail.
+func ApplicationErrorToGRPCStatus(err error, acceptLanguage string) *status.Status {
+	detail := ErrorDetailToProto(err, acceptLanguage)
+	code, ok := httpStatusToGRPCCode[ErrorCodeToHTTPStatus[errors2.ErrorCode(detail.Code)]]
+	if !ok {
+		code = codes.Internal
+	}
+
+	st := status.New(code, detail.Message)
+	withDetail, detailErr := st.WithDetails(detail)
+	if detailErr != nil {
+		return st
+	}
+	return withDetail
+}
+
+// GRPCErrorInterceptor returns the errors of gRPC handlers and interceptors after it as statuses
+// with an ErrorDetail, the gRPC counterpart of ApplicationErrorToHumaError. Statuses returned by
+// gRPC itself go through unchanged.
+func GRPCErrorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	if _, isStatus := status.FromError(err); isStatus {
+		return resp, err
+	}
+	return nil, ApplicationErrorToGRPCStatus(err, requestmeta.FromIncomingGRPC(ctx).Locale).Err()
+}
@@ -1,11 +1,60 @@
 package utils
 
 import (
+	"encoding/json"
 	"errors"
+	archiveErrors "github.com/nahualventure/class-backend/core/app/archive/domain/errors"
+	attendanceErrors "github.com/nahualventure/class-backend/core/app/attendance/domain/errors"
+	backupErrors "github.com/nahualventure/class-backend/core/app/backup/domain/errors"
+	behaviorErrors "github.com/nahualventure/class-backend/core/app/behavior/domain/errors"
+	billingErrors "github.com/nahualventure/class-backend/core/app/billing/domain/errors"
+	calendarErrors "github.com/nahualventure/class-backend/core/app/calendar/domain/errors"
+	classTemplateErrors "github.com/nahualventure/class-backend/core/app/classtemplate/domain/errors"
+	customFieldErrors "github.com/nahualventure/class-backend/core/app/customfield/domain/errors"
+	dashboardErrors "github.com/nahualventure/class-backend/core/app/dashboard/domain/errors"
+	dataImportErrors "github.com/nahualventure/class-backend/core/app/dataimport/domain/errors"
+	deliverabilityErrors "github.com/nahualventure/class-backend/core/app/deliverability/domain/errors"
+	devSandboxErrors "github.com/nahualventure/class-backend/core/app/devsandbox/domain/errors"
+	directorySyncErrors "github.com/nahualventure/class-backend/core/app/directorysync/domain/errors"
+	districtErrors "github.com/nahualventure/class-backend/core/app/district/domain/errors"
+	emailTemplateErrors "github.com/nahualventure/class-backend/core/app/emailtemplate/domain/errors"
+	enrollmentErrors "github.com/nahualventure/class-backend/core/app/enrollment/domain/errors"
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	jobFailureErrors "github.com/nahualventure/class-backend/core/app/jobfailure/domain/errors"
+	libraryErrors "github.com/nahualventure/class-backend/core/app/library/domain/errors"
+	linkedIdentityErrors "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/errors"
+	localizationErrors "github.com/nahualventure/class-backend/core/app/localization/domain/errors"
+	messagingErrors "github.com/nahualventure/class-backend/core/app/messaging/domain/errors"
+	moduleToggleErrors "github.com/nahualventure/class-backend/core/app/moduletoggle/domain/errors"
+	officeHoursErrors "github.com/nahualventure/class-backend/core/app/officehours/domain/errors"
+	offlineSyncErrors "github.com/nahualventure/class-backend/core/app/offlinesync/domain/errors"
+	operationErrors "github.com/nahualventure/class-backend/core/app/operation/domain/errors"
+	presenceErrors "github.com/nahualventure/class-backend/core/app/presence/domain/errors"
+	printoutErrors "github.com/nahualventure/class-backend/core/app/printout/domain/errors"
+	reportErrors "github.com/nahualventure/class-backend/core/app/report/domain/errors"
+	roleAssignmentErrors "github.com/nahualventure/class-backend/core/app/roleassignment/domain/errors"
+	sandboxErrors "github.com/nahualventure/class-backend/core/app/sandbox/domain/errors"
+	savedViewErrors "github.com/nahualventure/class-backend/core/app/savedview/domain/errors"
+	sectionErrors "github.com/nahualventure/class-backend/core/app/sections/domain/errors"
+	serviceAccountErrors "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/errors"
 	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	coreUtils "github.com/nahualventure/class-backend/core/app/shared/utils"
+	similarityErrors "github.com/nahualventure/class-backend/core/app/similarity/domain/errors"
+	standardsErrors "github.com/nahualventure/class-backend/core/app/standards/domain/errors"
+	statusErrors "github.com/nahualventure/class-backend/core/app/status/domain/errors"
+	storageQuotaErrors "github.com/nahualventure/class-backend/core/app/storagequota/domain/errors"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	tenantErrors "github.com/nahualventure/class-backend/core/app/tenant/domain/errors"
+	transcriptErrors "github.com/nahualventure/class-backend/core/app/transcript/domain/errors"
 	userErrors "github.com/nahualventure/class-backend/core/app/user/domain/errors"
+	userMergeErrors "github.com/nahualventure/class-backend/core/app/usermerge/domain/errors"
+	widgetErrors "github.com/nahualventure/class-backend/core/app/widget/domain/errors"
+	"github.com/nahualventure/class-backend/infra/shared/requestmeta"
 	"log"
 	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
 )
 
 var ErrorCodeToHTTPStatus = map[errors2.ErrorCode]int{
@@ -14,15 +63,265 @@ var ErrorCodeToHTTPStatus = map[errors2.ErrorCode]int{
 	errors2.DomainEntityValidationError: http.StatusBadRequest,
 
 	// Authorization Errors
-	errors2.Unauthorized: http.StatusUnauthorized,
-	errors2.Forbidden:    http.StatusForbidden,
+	errors2.Unauthorized:      http.StatusUnauthorized,
+	errors2.StepUpRequired:    http.StatusUnauthorized,
+	errors2.SignatureRequired: http.StatusUnauthorized,
+	errors2.Forbidden:         http.StatusForbidden,
+	errors2.InsufficientScope: http.StatusForbidden,
+	errors2.AuthzConfigError:  http.StatusServiceUnavailable,
+
+	// Throttling Errors
+	errors2.RateLimited: http.StatusTooManyRequests,
+
+	// Client Errors
+	errors2.UpgradeRequired:         http.StatusUpgradeRequired,
+	errors2.RangeNotSatisfiable:     http.StatusRequestedRangeNotSatisfiable,
+	errors2.FileQuarantined:         http.StatusConflict,
+	errors2.PreviewNotAvailable:     http.StatusNotFound,
+	errors2.AlternativeNotAvailable: http.StatusNotFound,
+
+	// Directory Sync Errors
+	directorySyncErrors.SyncConflictNotFoundError:   http.StatusNotFound,
+	directorySyncErrors.SyncConflictNotOpenError:    http.StatusConflict,
+	directorySyncErrors.DuplicateDirectoryUserError: http.StatusConflict,
+
+	// Email Deliverability Errors
+	deliverabilityErrors.InvalidEmailWebhookError:     http.StatusUnauthorized,
+	deliverabilityErrors.SuppressionNotFoundError:     http.StatusNotFound,
+	deliverabilityErrors.SuppressionNotRemovableError: http.StatusConflict,
+
+	// Email Template Errors
+	emailTemplateErrors.InvalidEmailTemplateError:  http.StatusBadRequest,
+	emailTemplateErrors.TestRecipientNotFoundError: http.StatusNotFound,
+
+	// Tenant Errors
+	errors2.TenantKeyDestroyed:  http.StatusGone,
+	errors2.TenantInOtherRegion: http.StatusMisdirectedRequest,
 
 	// Infrastructure Errors
-	errors2.InternalError: http.StatusInternalServerError,
+	errors2.InternalError:      http.StatusInternalServerError,
+	errors2.ServiceUnavailable: http.StatusServiceUnavailable,
 
 	// User Errors
-	userErrors.EmailAlreadyExistsError: http.StatusConflict,
-	userErrors.UserNotFoundError:       http.StatusNotFound,
+	userErrors.EmailAlreadyExistsError:    http.StatusConflict,
+	userErrors.UserNotFoundError:          http.StatusNotFound,
+	userErrors.DuplicateEmailInBatchError: http.StatusConflict,
+
+	// Dashboard Errors
+	dashboardErrors.ClassSummaryNotFoundError: http.StatusNotFound,
+
+	// Enrollment Errors
+	enrollmentErrors.ClassCapacityNotFoundError: http.StatusNotFound,
+	enrollmentErrors.ClassFullError:             http.StatusConflict,
+	enrollmentErrors.AlreadyEnrolledError:       http.StatusConflict,
+	enrollmentErrors.SeatHoldNotFoundError:      http.StatusNotFound,
+	enrollmentErrors.SeatHoldExpiredError:       http.StatusGone,
+	enrollmentErrors.ScheduleConflictError:      http.StatusConflict,
+
+	// Grading Errors
+	gradingErrors.GradeNotFoundError:              http.StatusNotFound,
+	gradingErrors.GradeNotEditableError:           http.StatusConflict,
+	gradingErrors.InvalidGradeTransitionError:     http.StatusConflict,
+	gradingErrors.DeadlineNotFoundError:           http.StatusNotFound,
+	gradingErrors.InvalidExtensionError:           http.StatusBadRequest,
+	gradingErrors.GradeChangedConcurrently:        http.StatusConflict,
+	gradingErrors.GradeAdjustmentNotFoundError:    http.StatusNotFound,
+	gradingErrors.GradeAdjustmentUndoneError:      http.StatusConflict,
+	gradingErrors.GradeAdjustmentNotUndoableError: http.StatusConflict,
+
+	// Report Errors
+	reportErrors.ReportNotFoundError:          http.StatusNotFound,
+	reportErrors.ReportNotReadyError:          http.StatusConflict,
+	reportErrors.ReportExpiredError:           http.StatusGone,
+	reportErrors.UnknownReportDefinitionError: http.StatusBadRequest,
+
+	// Similarity Errors
+	similarityErrors.SimilarityCheckNotFoundError:   http.StatusNotFound,
+	similarityErrors.InvalidSimilarityCallbackError: http.StatusUnauthorized,
+
+	// Messaging Errors
+	messagingErrors.ConversationNotFoundError:       http.StatusNotFound,
+	messagingErrors.MessageNotFoundError:            http.StatusNotFound,
+	messagingErrors.AttachmentNotFoundError:         http.StatusNotFound,
+	messagingErrors.MessagingNotAllowedError:        http.StatusForbidden,
+	messagingErrors.AbuseReportNotFoundError:        http.StatusNotFound,
+	messagingErrors.AbuseReportAlreadyResolvedError: http.StatusConflict,
+
+	// Office Hours Errors
+	officeHoursErrors.SlotNotFoundError:             http.StatusNotFound,
+	officeHoursErrors.SlotOverlapError:              http.StatusConflict,
+	officeHoursErrors.SlotNotBookableError:          http.StatusConflict,
+	officeHoursErrors.SlotFullError:                 http.StatusConflict,
+	officeHoursErrors.AppointmentNotFoundError:      http.StatusNotFound,
+	officeHoursErrors.AppointmentConflictError:      http.StatusConflict,
+	officeHoursErrors.AppointmentNotActiveError:     http.StatusConflict,
+	officeHoursErrors.AppointmentAlreadyBookedError: http.StatusConflict,
+
+	// Behavior Errors
+	behaviorErrors.IncidentNotFoundError:             http.StatusNotFound,
+	behaviorErrors.IncidentAlreadyResolvedError:      http.StatusConflict,
+	behaviorErrors.GuardianNotificationNotFoundError: http.StatusNotFound,
+
+	// Library Errors
+	libraryErrors.ResourceNotFoundError:        http.StatusNotFound,
+	libraryErrors.ResourceVersionNotFoundError: http.StatusNotFound,
+	libraryErrors.ResourceNotEditableError:     http.StatusConflict,
+	libraryErrors.ResourceNotPublishableError:  http.StatusConflict,
+
+	// Surveys Errors
+	surveysErrors.FormNotFoundError:          http.StatusNotFound,
+	surveysErrors.FormNotEditableError:       http.StatusConflict,
+	surveysErrors.InvalidFormTransitionError: http.StatusConflict,
+	surveysErrors.FormNotOpenError:           http.StatusConflict,
+	surveysErrors.FormAlreadyAnsweredError:   http.StatusConflict,
+
+	// Billing Errors
+	billingErrors.InvalidBillingWebhookError:   http.StatusUnauthorized,
+	billingErrors.BillingCustomerNotFoundError: http.StatusNotFound,
+	billingErrors.FeatureNotInPlanError:        http.StatusPaymentRequired,
+	billingErrors.PlanLimitExceededError:       http.StatusPaymentRequired,
+
+	// Localization Errors
+	localizationErrors.TranslatableEntityNotFoundError: http.StatusNotFound,
+	localizationErrors.TranslationNotFoundError:        http.StatusNotFound,
+
+	// Calendar Errors
+	calendarErrors.NonInstructionalDayNotFoundError: http.StatusNotFound,
+	calendarErrors.UnsupportedHolidaySetError:       http.StatusBadRequest,
+
+	// Archive Errors
+	archiveErrors.ArchiveBatchNotFoundError:        http.StatusNotFound,
+	archiveErrors.ArchiveBatchAlreadyRestoredError: http.StatusConflict,
+
+	// Backup Errors
+	backupErrors.TenantBackupNotFoundError:    http.StatusNotFound,
+	backupErrors.TenantBackupNotVerifiedError: http.StatusConflict,
+
+	// Service Account Errors
+	serviceAccountErrors.ServiceAccountNotFoundError:    http.StatusNotFound,
+	serviceAccountErrors.ServiceAccountKeyNotFoundError: http.StatusNotFound,
+	serviceAccountErrors.ServiceAccountDisabledError:    http.StatusConflict,
+
+	// Role Assignment Errors
+	roleAssignmentErrors.GroupRoleAssignmentNotFoundError:    http.StatusNotFound,
+	roleAssignmentErrors.MemberDeactivationNotFoundError:     http.StatusNotFound,
+	roleAssignmentErrors.MemberDeactivationTokenInvalidError: http.StatusConflict,
+	roleAssignmentErrors.MemberDeactivationStaleError:        http.StatusConflict,
+	roleAssignmentErrors.MemberDeactivationNotCompletedError: http.StatusConflict,
+
+	// Sandbox Errors
+	sandboxErrors.TenantSandboxNotFoundError:      http.StatusNotFound,
+	sandboxErrors.TenantSandboxAlreadyExistsError: http.StatusConflict,
+
+	// Data Import Errors
+	dataImportErrors.ImportNotFoundError:          http.StatusNotFound,
+	dataImportErrors.ImportNotFinishedError:       http.StatusConflict,
+	dataImportErrors.UnknownImportEntityError:     http.StatusBadRequest,
+	dataImportErrors.MappingTemplateNotFoundError: http.StatusNotFound,
+
+	// User Merge Errors
+	userMergeErrors.DuplicateCandidateNotFoundError: http.StatusNotFound,
+	userMergeErrors.DuplicateCandidateNotOpenError:  http.StatusConflict,
+	userMergeErrors.UserMergeNotFoundError:          http.StatusNotFound,
+	userMergeErrors.MergeUserNotMemberError:         http.StatusNotFound,
+	userMergeErrors.UserMergeNotUndoableError:       http.StatusConflict,
+
+	// Linked Identity Errors
+	linkedIdentityErrors.LinkedIdentityNotFoundError:  http.StatusNotFound,
+	linkedIdentityErrors.IdentityAlreadyLinkedError:   http.StatusConflict,
+	linkedIdentityErrors.LastCredentialError:          http.StatusConflict,
+	linkedIdentityErrors.PasswordNotSetError:          http.StatusNotFound,
+	linkedIdentityErrors.CredentialsUserNotFoundError: http.StatusNotFound,
+	linkedIdentityErrors.InvalidIdentityTokenError:    http.StatusBadRequest,
+
+	// Tenant Errors
+	tenantErrors.TenantAlreadyExistsError:      http.StatusConflict,
+	tenantErrors.TenantInvitationNotFoundError: http.StatusNotFound,
+	tenantErrors.TenantInvitationExpiredError:  http.StatusGone,
+
+	// Storage Quota Errors
+	storageQuotaErrors.StorageQuotaExceededError: http.StatusRequestEntityTooLarge,
+
+	// Attendance Errors
+	attendanceErrors.CheckInSessionNotFoundError: http.StatusNotFound,
+	attendanceErrors.NoClassSessionError:         http.StatusConflict,
+	attendanceErrors.CheckInClosedError:          http.StatusConflict,
+	attendanceErrors.InvalidCheckInCodeError:     http.StatusBadRequest,
+	attendanceErrors.OutsideCheckInLocationError: http.StatusForbidden,
+	attendanceErrors.NotEnrolledInClassError:     http.StatusForbidden,
+
+	// Transcript Errors
+	transcriptErrors.TermNotFoundError:              http.StatusNotFound,
+	transcriptErrors.TermOverlapError:               http.StatusConflict,
+	transcriptErrors.ReportCardNotFoundError:        http.StatusNotFound,
+	transcriptErrors.ReportCardNotReadyError:        http.StatusConflict,
+	transcriptErrors.ReportCardExpiredError:         http.StatusGone,
+	transcriptErrors.InvalidDownloadLinkError:       http.StatusForbidden,
+	transcriptErrors.InvalidReportCardTemplateError: http.StatusBadRequest,
+
+	// Standards Errors
+	standardsErrors.StandardNotFoundError: http.StatusNotFound,
+
+	// Class Template Errors
+	classTemplateErrors.ClassTemplateNotFoundError: http.StatusNotFound,
+	classTemplateErrors.ClassNotEmptyError:         http.StatusConflict,
+
+	// Section Errors
+	sectionErrors.SectionChangeNotFoundError:   http.StatusNotFound,
+	sectionErrors.StudentsNotInSectionError:    http.StatusUnprocessableEntity,
+	sectionErrors.SectionStudentsConflictError: http.StatusConflict,
+	sectionErrors.SectionCapacityExceededError: http.StatusConflict,
+
+	// Module Toggle Errors
+	moduleToggleErrors.ModuleDisabledError: http.StatusForbidden,
+
+	// Widget Errors
+	widgetErrors.WidgetKeyNotFoundError:      http.StatusNotFound,
+	widgetErrors.InvalidWidgetKeyError:       http.StatusUnauthorized,
+	widgetErrors.WidgetOriginNotAllowedError: http.StatusForbidden,
+	widgetErrors.WidgetNotAllowedError:       http.StatusForbidden,
+	widgetErrors.AnnouncementNotFoundError:   http.StatusNotFound,
+
+	// Job Failure Errors
+	jobFailureErrors.JobFailureNotFoundError:     http.StatusNotFound,
+	jobFailureErrors.JobFailureNotEscalatedError: http.StatusConflict,
+
+	// Status Errors
+	statusErrors.StatusNoteNotFoundError: http.StatusNotFound,
+	statusErrors.StatusNoteResolvedError: http.StatusConflict,
+
+	// Developer Sandbox Errors
+	devSandboxErrors.DeveloperSandboxNotFoundError:     http.StatusNotFound,
+	devSandboxErrors.DeveloperSandboxLimitReachedError: http.StatusConflict,
+
+	// Custom Field Errors
+	customFieldErrors.CustomFieldNotFoundError: http.StatusNotFound,
+	customFieldErrors.CustomFieldKeyTakenError: http.StatusConflict,
+
+	// Saved View Errors
+	savedViewErrors.SavedViewNotFoundError:  http.StatusNotFound,
+	savedViewErrors.SavedViewNameTakenError: http.StatusConflict,
+
+	// Edit Lock Errors
+	presenceErrors.EditLockHeldError: http.StatusConflict,
+
+	// Offline Sync Errors
+	offlineSyncErrors.SyncConflictError: http.StatusConflict,
+
+	// Printout Errors
+	printoutErrors.PrintoutNotFoundError: http.StatusNotFound,
+	printoutErrors.PrintoutNotReadyError: http.StatusConflict,
+	printoutErrors.PrintoutExpiredError:  http.StatusGone,
+	printoutErrors.PrintoutTooLargeError: http.StatusRequestEntityTooLarge,
+
+	// District Errors
+	districtErrors.DistrictNotFoundError:      http.StatusNotFound,
+	districtErrors.DistrictKeyNotFoundError:   http.StatusNotFound,
+	districtErrors.InvalidDistrictKeyError:    http.StatusUnauthorized,
+	districtErrors.TenantInOtherDistrictError: http.StatusConflict,
+
+	// Operation Errors
+	operationErrors.OperationNotFoundError: http.StatusNotFound,
 }
 
 type HTTPErrorResponse struct {
@@ -48,7 +347,7 @@ func ApplicationErrorToHTTPResponse(err error) HTTPErrorResponse {
 			}{
 				Code:      "INTERNAL_ERROR",
 				Message:   "Internal server error",
-				Timestamp: appErr.GetOccurredAt().Format("2006-01-02T15:04:05Z07:00"),
+				Timestamp: time.Now().Format("2006-01-02T15:04:05Z07:00"),
 			},
 			Status: http.StatusInternalServerError,
 		}
@@ -88,3 +387,56 @@ func ApplicationErrorToHTTPResponse(err error) HTTPErrorResponse {
 		Status: httpStatus,
 	}
 }
+
+// HTTPStatusError lets handlers return the HTTPErrorResponse envelope as a huma.StatusError
+type HTTPStatusError struct {
+	HTTPErrorResponse
+	// cause is the application error, validation messages are translated from it once the locale
+	// of the request is known
+	cause error
+}
+
+func (e *HTTPStatusError) GetStatus() int { return e.Status }
+func (e *HTTPStatusError) Unwrap() error  { return e.cause }
+func (e *HTTPStatusError) Error() string {
+	return e.HTTPErrorResponse.Error.Code + ": " + e.HTTPErrorResponse.Error.Message
+}
+
+// ApplicationErrorToHumaError converts any error into the standard envelope for Huma handlers
+func ApplicationErrorToHumaError(err error) huma.StatusError {
+	return &HTTPStatusError{HTTPErrorResponse: ApplicationErrorToHTTPResponse(err), cause: err}
+}
+
+// WriteApplicationError writes the standard envelope directly, for middlewares that stop the chain
+func WriteApplicationError(ctx huma.Context, err error) {
+	response := ApplicationErrorToHTTPResponse(err)
+	localizeResponse(&response, err, requestmeta.FromHuma(ctx).Locale)
+
+	ctx.SetHeader("Content-Type", "application/json")
+	ctx.SetStatus(response.Status)
+	if encodeErr := json.NewEncoder(ctx.BodyWriter()).Encode(response); encodeErr != nil {
+		log.Printf("failed to write error response: %v", encodeErr)
+	}
+}
+
+// LocalizeErrors is a Huma transformer that translates the messages of validation errors returned
+// by handlers to the locale of the request
+func LocalizeErrors(ctx huma.Context, status string, v any) (any, error) {
+	statusErr, ok := v.(*HTTPStatusError)
+	if !ok || statusErr.cause == nil {
+		return v, nil
+	}
+
+	localized := *statusErr
+	localizeResponse(&localized.HTTPErrorResponse, statusErr.cause, requestmeta.FromHuma(ctx).Locale)
+	return &localized, nil
+}
+
+func localizeResponse(response *HTTPErrorResponse, err error, acceptLanguage string) {
+	if response.Error.Code != errors2.ValidationError.String() || acceptLanguage == "" {
+		return
+	}
+	if errorMap := coreUtils.LocalizeValidationError(err, acceptLanguage); errorMap != nil {
+		response.Error.Context = errorMap
+	}
+}
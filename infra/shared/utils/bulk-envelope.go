@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/bulk"
+)
+
+// ErrorDetail is the error of a single item, the same code, message and context as the standard
+// error envelope
+type ErrorDetail struct {
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Context map[string]any `json:"context,omitempty"`
+}
+
+// NewErrorDetail converts any error into the error detail, validation messages are translated to
+// the first supported locale of acceptLanguage
+func NewErrorDetail(err error, acceptLanguage string) *ErrorDetail {
+	response := ApplicationErrorToHTTPResponse(err)
+	localizeResponse(&response, err, acceptLanguage)
+
+	return &ErrorDetail{
+		Code:    response.Error.Code,
+		Message: response.Error.Message,
+		Context: response.Error.Context,
+	}
+}
+
+// BulkItemResponse is the standard outcome of one item of a batch request, embed it in the item
+// responses of batch endpoints next to the fields of the item
+type BulkItemResponse struct {
+	Index  int          `json:"index" doc:"Position of the item in the request, from 0"`
+	Status string       `json:"status" doc:"failed, or the status the endpoint gives to the items that succeeded"`
+	Error  *ErrorDetail `json:"error,omitempty" doc:"Set when the item failed, the batch itself succeeds"`
+}
+
+// NewBulkItemResponse returns the outcome of an item, its error is translated like the standard
+// error envelope
+func NewBulkItemResponse[T any](item bulk.Item[T], acceptLanguage string) BulkItemResponse {
+	response := BulkItemResponse{
+		Index:  item.Index,
+		Status: string(item.Status),
+	}
+	if item.Err != nil {
+		response.Error = NewErrorDetail(item.Err, acceptLanguage)
+	}
+	return response
+}
+
+// BulkSummary counts the items of a batch request by outcome, embed it in the response bodies of
+// batch endpoints next to their results
+type BulkSummary struct {
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+func NewBulkSummary[T any](result *bulk.Result[T]) BulkSummary {
+	return BulkSummary{
+		Succeeded: result.Succeeded(),
+		Failed:    result.Failed(),
+	}
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+type clientIPContextKey struct{}
+
+// ClientIPMiddleware puts the IP of the client in the request context. Gin only reads
+// X-Forwarded-For and X-Real-IP when the peer is one of the trusted proxies set on the router,
+// otherwise the peer address is the client.
+func ClientIPMiddleware(c *gin.Context) {
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), clientIPContextKey{}, c.ClientIP()))
+	c.Next()
+}
+
+// ClientIPFromContext returns the IP of the client, empty outside HTTP requests
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPContextKey{}).(string)
+	return ip
+}
@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"github.com/nahualventure/class-backend/core/app/shared/dataloader"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// DataLoaderMiddleware gives every request its own data loader scope, values loaded while composing a
+// response are shared by the handlers of the request and dropped with it
+func DataLoaderMiddleware(ctx huma.Context, next func(huma.Context)) {
+	next(huma.WithContext(ctx, dataloader.WithScope(ctx.Context())))
+}
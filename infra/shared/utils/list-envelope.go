@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+)
+
+// ListQueryParams binds the standard list query parameters, embed it in list endpoint inputs
+type ListQueryParams struct {
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+	OrderBy  string `query:"order_by" doc:"Comma separated sort terms, e.g. 'created_at desc,name'"`
+	Filter   string `query:"filter" maxLength:"1000" doc:"Filter expression, e.g. 'name == \"Ada\" AND created_at > \"2024-01-01\"'"`
+	View     string `query:"view" format:"uuid" doc:"Saved view to apply, its order and filter are used where the request sets none"`
+}
+
+// SavedViews applies the saved views list requests name to the order and filter of the request
+type SavedViews interface {
+	Apply(ctx context.Context, list string, viewID string, orderBy string, filter string) (string, string, error)
+}
+
+// WithView returns the order and filter of a request to the list with the saved view it names
+// applied, those of the request itself when it names none
+func (p ListQueryParams) WithView(ctx context.Context, list string, views SavedViews) (string, string, error) {
+	if p.View == "" {
+		return p.OrderBy, p.Filter, nil
+	}
+	return views.Apply(ctx, list, p.View, p.OrderBy, p.Filter)
+}
+
+// ListResponseBody is the standard envelope returned by every list endpoint
+type ListResponseBody[T any] struct {
+	Items         []T    `json:"items"`
+	NextCursor    string `json:"next_cursor,omitempty" doc:"Cursor for the next page, absent on the last page"`
+	TotalEstimate int64  `json:"total_estimate" doc:"Estimated number of items matching the request"`
+}
+
+// NewListResponseBody converts a domain page into the list envelope
+func NewListResponseBody[T any, R any](page *pagination.Page[T], mapper func(T) R) ListResponseBody[R] {
+	mapped := pagination.MapPage(page, mapper)
+
+	return ListResponseBody[R]{
+		Items:         mapped.Items,
+		NextCursor:    mapped.NextCursor,
+		TotalEstimate: mapped.TotalEstimate,
+	}
+}
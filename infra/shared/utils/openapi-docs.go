@@ -0,0 +1,218 @@
+package utils
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// exampleTimestamp keeps the generated spec stable between builds
+const exampleTimestamp = "2026-01-01T00:00:00Z"
+
+// maxExampleDepth stops examples of recursive schemas
+const maxExampleDepth = 6
+
+// NewHumaError replaces huma.NewError so the errors Huma raises itself, such as request validation
+// failures, use the same envelope as application errors
+func NewHumaError(status int, message string, errs ...error) huma.StatusError {
+	code := errors2.ValidationError
+	switch {
+	case status == http.StatusUnauthorized:
+		code = errors2.Unauthorized
+	case status == http.StatusForbidden:
+		code = errors2.Forbidden
+	case status == http.StatusTooManyRequests:
+		code = errors2.RateLimited
+	case status == http.StatusUpgradeRequired:
+		code = errors2.UpgradeRequired
+	case status == http.StatusServiceUnavailable:
+		code = errors2.ServiceUnavailable
+	case status >= http.StatusInternalServerError || status == 0:
+		code, message = errors2.InternalError, "Internal server error"
+	}
+
+	response := HTTPErrorResponse{Status: status}
+	response.Error.Code = code.String()
+	response.Error.Message = message
+	response.Error.Timestamp = time.Now().Format("2006-01-02T15:04:05Z07:00")
+
+	var details []map[string]any
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if detailer, ok := err.(huma.ErrorDetailer); ok {
+			detail := detailer.ErrorDetail()
+			details = append(details, map[string]any{"location": detail.Location, "message": detail.Message, "value": detail.Value})
+		} else {
+			details = append(details, map[string]any{"message": err.Error()})
+		}
+	}
+	if len(details) > 0 {
+		response.Error.Context = map[string]any{"errors": details}
+	}
+
+	return &HTTPStatusError{HTTPErrorResponse: response}
+}
+
+// DocumentOperation returns the OpenAPI hook that documents every operation as it is added to the
+// spec: one response per status it can fail with, the error codes of each status with an example
+// envelope apiece, and an example of the JSON request and success bodies built from their schemas.
+// Operations for which isPublic is false also fail the authorization and load shedding checks.
+func DocumentOperation(isPublic func(operationID string) bool) huma.AddOpFunc {
+	return func(oapi *huma.OpenAPI, op *huma.Operation) {
+		registry := oapi.Components.Schemas
+		envelope := registry.Schema(reflect.TypeOf(HTTPErrorResponse{}), true, "")
+
+		codes := []errors2.ErrorCode{errors2.InternalError}
+		if len(op.Parameters) > 0 || op.RequestBody != nil {
+			codes = append(codes, errors2.ValidationError)
+		}
+		if !isPublic(op.OperationID) {
+			codes = append(codes, errors2.Unauthorized, errors2.Forbidden, errors2.RateLimited, errors2.ServiceUnavailable)
+		}
+		codes = append(codes, CommonErrors...)
+		codes = append(codes, OperationErrors[op.OperationID]...)
+
+		documentErrors(op, envelope, codes)
+		documentExamples(registry, op)
+	}
+}
+
+func documentErrors(op *huma.Operation, envelope *huma.Schema, codes []errors2.ErrorCode) {
+	byStatus := make(map[int][]errors2.ErrorCode)
+	for _, code := range codes {
+		status, ok := ErrorCodeToHTTPStatus[code]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		if !containsCode(byStatus[status], code) {
+			byStatus[status] = append(byStatus[status], code)
+		}
+	}
+	// Huma validates path, query and body input before handlers run and answers with a 422
+	if containsCode(byStatus[http.StatusBadRequest], errors2.ValidationError) {
+		byStatus[http.StatusUnprocessableEntity] = []errors2.ErrorCode{errors2.ValidationError}
+	}
+
+	if op.Responses == nil {
+		op.Responses = map[string]*huma.Response{}
+	}
+	delete(op.Responses, "default")
+	for status, statusCodes := range byStatus {
+		sort.Slice(statusCodes, func(i, j int) bool { return statusCodes[i] < statusCodes[j] })
+
+		names := make([]string, 0, len(statusCodes))
+		examples := make(map[string]*huma.Example, len(statusCodes))
+		for _, code := range statusCodes {
+			names = append(names, "`"+code.String()+"`")
+			examples[code.String()] = &huma.Example{Value: errorExample(code)}
+		}
+
+		op.Responses[strconv.Itoa(status)] = &huma.Response{
+			Description: http.StatusText(status) + ", error codes: " + strings.Join(names, ", "),
+			Content: map[string]*huma.MediaType{
+				"application/json": {Schema: envelope, Examples: examples},
+			},
+		}
+	}
+}
+
+func containsCode(codes []errors2.ErrorCode, code errors2.ErrorCode) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func errorExample(code errors2.ErrorCode) HTTPErrorResponse {
+	words := strings.ToLower(strings.ReplaceAll(code.String(), "_", " "))
+
+	var example HTTPErrorResponse
+	example.Error.Code = code.String()
+	example.Error.Message = strings.ToUpper(words[:1]) + words[1:]
+	example.Error.Timestamp = exampleTimestamp
+	return example
+}
+
+func documentExamples(registry huma.Registry, op *huma.Operation) {
+	if op.RequestBody != nil {
+		injectExample(registry, op.RequestBody.Content["application/json"])
+	}
+	for status, response := range op.Responses {
+		if response != nil && strings.HasPrefix(status, "2") {
+			injectExample(registry, response.Content["application/json"])
+		}
+	}
+}
+
+// injectExample sets the example of a JSON body that has none from its schema
+func injectExample(registry huma.Registry, media *huma.MediaType) {
+	if media == nil || media.Schema == nil || media.Example != nil || len(media.Examples) > 0 {
+		return
+	}
+	media.Example = schemaExample(registry, media.Schema, 0)
+}
+
+// schemaExample builds a value matching schema from its examples, defaults and enums, falling
+// back to a placeholder of its type
+func schemaExample(registry huma.Registry, schema *huma.Schema, depth int) any {
+	if schema == nil || depth > maxExampleDepth {
+		return nil
+	}
+	if schema.Ref != "" {
+		return schemaExample(registry, registry.SchemaFromRef(schema.Ref), depth+1)
+	}
+	if len(schema.Examples) > 0 {
+		return schema.Examples[0]
+	}
+	if schema.Default != nil {
+		return schema.Default
+	}
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+
+	switch schema.Type {
+	case huma.TypeObject:
+		example := make(map[string]any, len(schema.Properties))
+		for name, property := range schema.Properties {
+			if name == "$schema" {
+				continue
+			}
+			example[name] = schemaExample(registry, property, depth+1)
+		}
+		return example
+	case huma.TypeArray:
+		return []any{schemaExample(registry, schema.Items, depth+1)}
+	case huma.TypeString:
+		switch schema.Format {
+		case "date-time":
+			return exampleTimestamp
+		case "date":
+			return exampleTimestamp[:10]
+		case "uuid":
+			return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+		case "email":
+			return "user@example.com"
+		}
+		return "string"
+	case huma.TypeInteger, huma.TypeNumber:
+		if schema.Minimum != nil {
+			return *schema.Minimum
+		}
+		return 0
+	case huma.TypeBoolean:
+		return false
+	}
+	return nil
+}
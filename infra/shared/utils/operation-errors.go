@@ -0,0 +1,281 @@
+package utils
+
+import (
+	archiveErrors "github.com/nahualventure/class-backend/core/app/archive/domain/errors"
+	attendanceErrors "github.com/nahualventure/class-backend/core/app/attendance/domain/errors"
+	backupErrors "github.com/nahualventure/class-backend/core/app/backup/domain/errors"
+	behaviorErrors "github.com/nahualventure/class-backend/core/app/behavior/domain/errors"
+	billingErrors "github.com/nahualventure/class-backend/core/app/billing/domain/errors"
+	calendarErrors "github.com/nahualventure/class-backend/core/app/calendar/domain/errors"
+	classTemplateErrors "github.com/nahualventure/class-backend/core/app/classtemplate/domain/errors"
+	customFieldErrors "github.com/nahualventure/class-backend/core/app/customfield/domain/errors"
+	dashboardErrors "github.com/nahualventure/class-backend/core/app/dashboard/domain/errors"
+	dataImportErrors "github.com/nahualventure/class-backend/core/app/dataimport/domain/errors"
+	deliverabilityErrors "github.com/nahualventure/class-backend/core/app/deliverability/domain/errors"
+	devSandboxErrors "github.com/nahualventure/class-backend/core/app/devsandbox/domain/errors"
+	directorySyncErrors "github.com/nahualventure/class-backend/core/app/directorysync/domain/errors"
+	districtErrors "github.com/nahualventure/class-backend/core/app/district/domain/errors"
+	emailTemplateErrors "github.com/nahualventure/class-backend/core/app/emailtemplate/domain/errors"
+	enrollmentErrors "github.com/nahualventure/class-backend/core/app/enrollment/domain/errors"
+	gradingErrors "github.com/nahualventure/class-backend/core/app/grading/domain/errors"
+	jobFailureErrors "github.com/nahualventure/class-backend/core/app/jobfailure/domain/errors"
+	libraryErrors "github.com/nahualventure/class-backend/core/app/library/domain/errors"
+	linkedIdentityErrors "github.com/nahualventure/class-backend/core/app/linkedidentity/domain/errors"
+	localizationErrors "github.com/nahualventure/class-backend/core/app/localization/domain/errors"
+	messagingErrors "github.com/nahualventure/class-backend/core/app/messaging/domain/errors"
+	officeHoursErrors "github.com/nahualventure/class-backend/core/app/officehours/domain/errors"
+	offlineSyncErrors "github.com/nahualventure/class-backend/core/app/offlinesync/domain/errors"
+	operationErrors "github.com/nahualventure/class-backend/core/app/operation/domain/errors"
+	presenceErrors "github.com/nahualventure/class-backend/core/app/presence/domain/errors"
+	printoutErrors "github.com/nahualventure/class-backend/core/app/printout/domain/errors"
+	reportErrors "github.com/nahualventure/class-backend/core/app/report/domain/errors"
+	roleAssignmentErrors "github.com/nahualventure/class-backend/core/app/roleassignment/domain/errors"
+	sandboxErrors "github.com/nahualventure/class-backend/core/app/sandbox/domain/errors"
+	savedViewErrors "github.com/nahualventure/class-backend/core/app/savedview/domain/errors"
+	sectionErrors "github.com/nahualventure/class-backend/core/app/sections/domain/errors"
+	serviceAccountErrors "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/errors"
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	similarityErrors "github.com/nahualventure/class-backend/core/app/similarity/domain/errors"
+	standardsErrors "github.com/nahualventure/class-backend/core/app/standards/domain/errors"
+	statusErrors "github.com/nahualventure/class-backend/core/app/status/domain/errors"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	tenantErrors "github.com/nahualventure/class-backend/core/app/tenant/domain/errors"
+	transcriptErrors "github.com/nahualventure/class-backend/core/app/transcript/domain/errors"
+	userErrors "github.com/nahualventure/class-backend/core/app/user/domain/errors"
+	userMergeErrors "github.com/nahualventure/class-backend/core/app/usermerge/domain/errors"
+	widgetErrors "github.com/nahualventure/class-backend/core/app/widget/domain/errors"
+)
+
+// OperationErrors lists the error codes, besides the ones every operation shares, that Huma
+// operations return. The OpenAPI spec documents them per endpoint.
+var OperationErrors = map[string][]errors2.ErrorCode{
+	"signup":       {userErrors.EmailAlreadyExistsError, errors2.RateLimited},
+	"batch-signup": {userErrors.EmailAlreadyExistsError, userErrors.DuplicateEmailInBatchError},
+	"import-users": {userErrors.EmailAlreadyExistsError, userErrors.DuplicateEmailInBatchError},
+	"export-users": {errors2.RateLimited},
+	"list-users":   {savedViewErrors.SavedViewNotFoundError},
+
+	"legacy-create-user":  {userErrors.EmailAlreadyExistsError},
+	"legacy-record-grade": {gradingErrors.GradeNotEditableError, gradingErrors.GradeChangedConcurrently},
+
+	"get-class-summary": {dashboardErrors.ClassSummaryNotFoundError},
+
+	"get-class-capacity": {enrollmentErrors.ClassCapacityNotFoundError},
+	"reserve-seat": {enrollmentErrors.ClassCapacityNotFoundError, enrollmentErrors.ClassFullError,
+		enrollmentErrors.AlreadyEnrolledError, enrollmentErrors.ScheduleConflictError},
+	"confirm-enrollment": {enrollmentErrors.ClassCapacityNotFoundError, enrollmentErrors.SeatHoldNotFoundError,
+		enrollmentErrors.SeatHoldExpiredError, enrollmentErrors.AlreadyEnrolledError, enrollmentErrors.ScheduleConflictError},
+	"release-seat-hold": {enrollmentErrors.SeatHoldNotFoundError},
+
+	"record-grade":      {gradingErrors.GradeNotEditableError, gradingErrors.GradeChangedConcurrently},
+	"get-grade-history": {gradingErrors.GradeNotFoundError},
+	"submit-grades":     {gradingErrors.GradeNotFoundError, gradingErrors.InvalidGradeTransitionError},
+	"approve-grades":    {gradingErrors.GradeNotFoundError, gradingErrors.InvalidGradeTransitionError},
+	"return-grades":     {gradingErrors.GradeNotFoundError, gradingErrors.InvalidGradeTransitionError},
+	"release-grades":    {gradingErrors.GradeNotFoundError, gradingErrors.InvalidGradeTransitionError},
+	"grant-extension":   {gradingErrors.DeadlineNotFoundError, gradingErrors.InvalidExtensionError},
+
+	"apply-grade-adjustment": {gradingErrors.GradeChangedConcurrently},
+	"undo-grade-adjustment": {gradingErrors.GradeAdjustmentNotFoundError, gradingErrors.GradeAdjustmentUndoneError,
+		gradingErrors.GradeAdjustmentNotUndoableError, gradingErrors.GradeChangedConcurrently},
+
+	"request-report": {reportErrors.UnknownReportDefinitionError},
+	"get-report":     {reportErrors.ReportNotFoundError, reportErrors.ReportNotReadyError, reportErrors.ReportExpiredError},
+	"download-report": {reportErrors.ReportNotFoundError, reportErrors.ReportNotReadyError, reportErrors.ReportExpiredError,
+		errors2.RateLimited, errors2.RangeNotSatisfiable},
+
+	"get-similarity-check": {similarityErrors.SimilarityCheckNotFoundError},
+	"similarity-webhook":   {similarityErrors.InvalidSimilarityCallbackError},
+
+	"send-direct-message": {messagingErrors.MessagingNotAllowedError},
+	"post-class-message":  {messagingErrors.MessagingNotAllowedError},
+	"list-conversations":  {messagingErrors.MessagingNotAllowedError},
+	"list-conversation-messages": {messagingErrors.ConversationNotFoundError,
+		messagingErrors.MessagingNotAllowedError},
+	"mark-conversation-read": {messagingErrors.ConversationNotFoundError, messagingErrors.MessagingNotAllowedError},
+	"download-attachment": {messagingErrors.ConversationNotFoundError, messagingErrors.AttachmentNotFoundError,
+		messagingErrors.MessagingNotAllowedError, errors2.FileQuarantined, errors2.PreviewNotAvailable,
+		errors2.AlternativeNotAvailable, errors2.RateLimited, errors2.RangeNotSatisfiable},
+	"report-message": {messagingErrors.ConversationNotFoundError, messagingErrors.MessageNotFoundError,
+		messagingErrors.MessagingNotAllowedError},
+	"resolve-abuse-report": {messagingErrors.AbuseReportNotFoundError, messagingErrors.AbuseReportAlreadyResolvedError,
+		messagingErrors.MessageNotFoundError},
+
+	"create-availability-slot": {officeHoursErrors.SlotOverlapError},
+	"cancel-availability-slot": {officeHoursErrors.SlotNotFoundError},
+	"book-appointment": {officeHoursErrors.SlotNotFoundError, officeHoursErrors.SlotNotBookableError,
+		officeHoursErrors.SlotFullError, officeHoursErrors.AppointmentAlreadyBookedError, officeHoursErrors.AppointmentConflictError},
+	"cancel-appointment":          {officeHoursErrors.AppointmentNotFoundError, officeHoursErrors.AppointmentNotActiveError},
+	"download-appointment-invite": {officeHoursErrors.AppointmentNotFoundError},
+
+	"get-incident":                      {behaviorErrors.IncidentNotFoundError, errors2.TenantKeyDestroyed},
+	"add-incident-note":                 {behaviorErrors.IncidentNotFoundError, errors2.TenantKeyDestroyed},
+	"notify-incident-guardians":         {behaviorErrors.IncidentNotFoundError},
+	"resolve-incident":                  {behaviorErrors.IncidentNotFoundError, behaviorErrors.IncidentAlreadyResolvedError},
+	"acknowledge-incident-notification": {behaviorErrors.GuardianNotificationNotFoundError},
+
+	"get-resource": {libraryErrors.ResourceNotFoundError},
+	"update-resource": {libraryErrors.ResourceNotFoundError, libraryErrors.ResourceNotEditableError,
+		libraryErrors.ResourceNotPublishableError},
+	"upload-resource-version": {libraryErrors.ResourceNotFoundError, libraryErrors.ResourceNotEditableError},
+	"download-resource-version": {libraryErrors.ResourceNotFoundError, libraryErrors.ResourceVersionNotFoundError,
+		errors2.FileQuarantined, errors2.PreviewNotAvailable, errors2.AlternativeNotAvailable, errors2.RateLimited,
+		errors2.RangeNotSatisfiable},
+	"publish-resource": {libraryErrors.ResourceNotFoundError, libraryErrors.ResourceNotEditableError,
+		libraryErrors.ResourceNotPublishableError},
+	"archive-resource": {libraryErrors.ResourceNotFoundError, libraryErrors.ResourceNotEditableError,
+		libraryErrors.ResourceNotPublishableError},
+
+	"get-form":              {surveysErrors.FormNotFoundError},
+	"update-form":           {surveysErrors.FormNotFoundError, surveysErrors.FormNotEditableError},
+	"open-form":             {surveysErrors.FormNotFoundError, surveysErrors.InvalidFormTransitionError},
+	"close-form":            {surveysErrors.FormNotFoundError, surveysErrors.InvalidFormTransitionError},
+	"submit-form-response":  {surveysErrors.FormNotFoundError, surveysErrors.FormNotOpenError, surveysErrors.FormAlreadyAnsweredError},
+	"list-form-responses":   {surveysErrors.FormNotFoundError},
+	"export-form-responses": {surveysErrors.FormNotFoundError},
+
+	"stripe-webhook": {billingErrors.InvalidBillingWebhookError},
+
+	"list-translations":  {localizationErrors.TranslatableEntityNotFoundError},
+	"set-translation":    {localizationErrors.TranslatableEntityNotFoundError},
+	"delete-translation": {localizationErrors.TranslationNotFoundError},
+
+	"remove-non-instructional-day": {calendarErrors.NonInstructionalDayNotFoundError},
+	"import-holidays":              {calendarErrors.UnsupportedHolidaySetError},
+
+	"restore-archive-batch": {archiveErrors.ArchiveBatchNotFoundError, archiveErrors.ArchiveBatchAlreadyRestoredError},
+
+	"get-tenant-backup": {backupErrors.TenantBackupNotFoundError},
+
+	"get-service-account":        {serviceAccountErrors.ServiceAccountNotFoundError},
+	"update-service-account":     {serviceAccountErrors.ServiceAccountNotFoundError, serviceAccountErrors.ServiceAccountDisabledError},
+	"rotate-service-account-key": {serviceAccountErrors.ServiceAccountNotFoundError, serviceAccountErrors.ServiceAccountDisabledError},
+	"revoke-service-account-key": {serviceAccountErrors.ServiceAccountNotFoundError, serviceAccountErrors.ServiceAccountKeyNotFoundError},
+	"disable-service-account":    {serviceAccountErrors.ServiceAccountNotFoundError},
+
+	"get-group-role-assignment": {roleAssignmentErrors.GroupRoleAssignmentNotFoundError},
+	"confirm-member-deactivation": {roleAssignmentErrors.MemberDeactivationNotFoundError, roleAssignmentErrors.MemberDeactivationTokenInvalidError,
+		roleAssignmentErrors.MemberDeactivationStaleError},
+	"get-member-deactivation":       {roleAssignmentErrors.MemberDeactivationNotFoundError},
+	"roll-back-member-deactivation": {roleAssignmentErrors.MemberDeactivationNotFoundError, roleAssignmentErrors.MemberDeactivationNotCompletedError},
+
+	"create-tenant-sandbox": {sandboxErrors.TenantSandboxAlreadyExistsError},
+	"get-tenant-sandbox":    {sandboxErrors.TenantSandboxNotFoundError},
+	"extend-tenant-sandbox": {sandboxErrors.TenantSandboxNotFoundError},
+	"delete-tenant-sandbox": {sandboxErrors.TenantSandboxNotFoundError},
+
+	"preview-import":          {dataImportErrors.UnknownImportEntityError, dataImportErrors.MappingTemplateNotFoundError},
+	"start-import":            {dataImportErrors.UnknownImportEntityError, dataImportErrors.MappingTemplateNotFoundError},
+	"get-import":              {dataImportErrors.ImportNotFoundError},
+	"list-import-rows":        {dataImportErrors.ImportNotFoundError},
+	"download-import-errors":  {dataImportErrors.ImportNotFoundError, dataImportErrors.ImportNotFinishedError},
+	"save-mapping-template":   {dataImportErrors.UnknownImportEntityError},
+	"delete-mapping-template": {dataImportErrors.MappingTemplateNotFoundError},
+
+	"dismiss-duplicate-users": {userMergeErrors.DuplicateCandidateNotFoundError, userMergeErrors.DuplicateCandidateNotOpenError},
+	"merge-users":             {userMergeErrors.MergeUserNotMemberError},
+	"get-user-merge":          {userMergeErrors.UserMergeNotFoundError},
+	"undo-user-merge":         {userMergeErrors.UserMergeNotFoundError, userMergeErrors.UserMergeNotUndoableError},
+
+	"link-identity":        {linkedIdentityErrors.IdentityAlreadyLinkedError, linkedIdentityErrors.InvalidIdentityTokenError},
+	"unlink-identity":      {linkedIdentityErrors.LinkedIdentityNotFoundError, linkedIdentityErrors.LastCredentialError},
+	"remove-password":      {linkedIdentityErrors.PasswordNotSetError, linkedIdentityErrors.LastCredentialError},
+	"list-user-identities": {linkedIdentityErrors.CredentialsUserNotFoundError},
+
+	"sync-directory-users":            {directorySyncErrors.DuplicateDirectoryUserError, userErrors.EmailAlreadyExistsError},
+	"get-directory-sync-conflict":     {directorySyncErrors.SyncConflictNotFoundError},
+	"resolve-directory-sync-conflict": {directorySyncErrors.SyncConflictNotFoundError, directorySyncErrors.SyncConflictNotOpenError, userErrors.EmailAlreadyExistsError},
+
+	"ses-webhook":              {deliverabilityErrors.InvalidEmailWebhookError},
+	"sendgrid-webhook":         {deliverabilityErrors.InvalidEmailWebhookError},
+	"remove-email-suppression": {deliverabilityErrors.SuppressionNotFoundError, deliverabilityErrors.SuppressionNotRemovableError},
+
+	"save-email-template":    {emailTemplateErrors.InvalidEmailTemplateError},
+	"preview-email-template": {emailTemplateErrors.InvalidEmailTemplateError},
+	"send-test-email":        {emailTemplateErrors.InvalidEmailTemplateError, emailTemplateErrors.TestRecipientNotFoundError},
+
+	"accept-tenant-invitation": {tenantErrors.TenantInvitationNotFoundError, tenantErrors.TenantInvitationExpiredError},
+
+	"open-check-in":     {attendanceErrors.NoClassSessionError},
+	"get-check-in-code": {attendanceErrors.CheckInSessionNotFoundError, attendanceErrors.CheckInClosedError},
+	"check-in": {attendanceErrors.CheckInSessionNotFoundError, attendanceErrors.CheckInClosedError, attendanceErrors.InvalidCheckInCodeError,
+		attendanceErrors.OutsideCheckInLocationError, attendanceErrors.NotEnrolledInClassError},
+
+	"create-term":               {transcriptErrors.TermOverlapError},
+	"save-report-card-template": {transcriptErrors.InvalidReportCardTemplateError},
+	"request-report-cards":      {transcriptErrors.TermNotFoundError},
+	"get-report-card":           {transcriptErrors.ReportCardNotFoundError},
+	"download-report-card": {transcriptErrors.InvalidDownloadLinkError, transcriptErrors.ReportCardNotReadyError,
+		transcriptErrors.ReportCardExpiredError, transcriptErrors.ReportCardNotFoundError},
+
+	"tag-assignment-standards": {standardsErrors.StandardNotFoundError},
+
+	"save-class-template":   {transcriptErrors.TermNotFoundError},
+	"get-class-template":    {classTemplateErrors.ClassTemplateNotFoundError},
+	"delete-class-template": {classTemplateErrors.ClassTemplateNotFoundError},
+	"instantiate-class-template": {classTemplateErrors.ClassTemplateNotFoundError, transcriptErrors.TermNotFoundError,
+		classTemplateErrors.ClassNotEmptyError},
+
+	"merge-sections":     {sectionErrors.SectionStudentsConflictError, sectionErrors.SectionCapacityExceededError},
+	"split-section":      {sectionErrors.StudentsNotInSectionError, sectionErrors.SectionStudentsConflictError, sectionErrors.SectionCapacityExceededError},
+	"get-section-change": {sectionErrors.SectionChangeNotFoundError},
+
+	"revoke-widget-key":   {widgetErrors.WidgetKeyNotFoundError},
+	"delete-announcement": {widgetErrors.AnnouncementNotFoundError},
+	"get-widget-announcements": {widgetErrors.InvalidWidgetKeyError, widgetErrors.WidgetOriginNotAllowedError, widgetErrors.WidgetNotAllowedError,
+		errors2.RateLimited},
+	"get-widget-calendar": {widgetErrors.InvalidWidgetKeyError, widgetErrors.WidgetOriginNotAllowedError, widgetErrors.WidgetNotAllowedError,
+		errors2.RateLimited},
+
+	"requeue-job-failure": {jobFailureErrors.JobFailureNotFoundError, jobFailureErrors.JobFailureNotEscalatedError},
+
+	"get-status":          {errors2.RateLimited},
+	"update-status-note":  {statusErrors.StatusNoteNotFoundError, statusErrors.StatusNoteResolvedError},
+	"resolve-status-note": {statusErrors.StatusNoteNotFoundError, statusErrors.StatusNoteResolvedError},
+
+	"create-developer-sandbox": {devSandboxErrors.DeveloperSandboxLimitReachedError, errors2.RateLimited},
+	"get-developer-sandbox":    {devSandboxErrors.DeveloperSandboxNotFoundError},
+	"reset-developer-sandbox":  {devSandboxErrors.DeveloperSandboxNotFoundError},
+
+	"create-custom-field": {customFieldErrors.CustomFieldKeyTakenError},
+	"update-custom-field": {customFieldErrors.CustomFieldNotFoundError},
+	"delete-custom-field": {customFieldErrors.CustomFieldNotFoundError},
+
+	"create-saved-view": {savedViewErrors.SavedViewNameTakenError},
+	"get-saved-view":    {savedViewErrors.SavedViewNotFoundError},
+	"update-saved-view": {savedViewErrors.SavedViewNotFoundError, savedViewErrors.SavedViewNameTakenError},
+	"delete-saved-view": {savedViewErrors.SavedViewNotFoundError},
+
+	"acquire-edit-lock": {presenceErrors.EditLockHeldError},
+
+	"push-sync-mutations": {offlineSyncErrors.SyncConflictError, gradingErrors.GradeNotEditableError, gradingErrors.GradeChangedConcurrently},
+
+	"download-class-printout": {printoutErrors.PrintoutTooLargeError, errors2.RateLimited},
+	"get-printout":            {printoutErrors.PrintoutNotFoundError},
+	"download-printout": {printoutErrors.PrintoutNotFoundError, printoutErrors.PrintoutNotReadyError, printoutErrors.PrintoutExpiredError,
+		errors2.RateLimited, errors2.RangeNotSatisfiable},
+
+	"create-district":      {districtErrors.TenantInOtherDistrictError},
+	"update-district":      {districtErrors.DistrictNotFoundError, districtErrors.TenantInOtherDistrictError},
+	"create-district-key":  {districtErrors.DistrictNotFoundError},
+	"revoke-district-key":  {districtErrors.DistrictKeyNotFoundError},
+	"get-district-metrics": {districtErrors.InvalidDistrictKeyError, errors2.RateLimited},
+
+	"get-operation": {operationErrors.OperationNotFoundError},
+}
+
+// CommonErrors lists the error codes every operation returns because of a middleware enabled by
+// the configuration, such as UPGRADE_REQUIRED once minimum client versions are set
+var CommonErrors []errors2.ErrorCode
+
+// AddCommonErrors documents more error codes of every operation, codes are added before the
+// operations are registered
+func AddCommonErrors(codes ...errors2.ErrorCode) {
+	CommonErrors = append(CommonErrors, codes...)
+}
+
+// AddOperationErrors documents more error codes of an operation, for middlewares that gate a set
+// of operations. Codes are added before the operations are registered.
+func AddOperationErrors(operationID string, codes ...errors2.ErrorCode) {
+	OperationErrors[operationID] = append(OperationErrors[operationID], codes...)
+}
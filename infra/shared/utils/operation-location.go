@@ -0,0 +1,7 @@
+package utils
+
+// OperationLocation is the path clients poll an asynchronous job at, sent in the Location header of
+// the 202 responses of the endpoints starting one
+func OperationLocation(operationID string) string {
+	return "/operations/" + operationID
+}
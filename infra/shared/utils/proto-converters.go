@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/bulk"
+	errors2 "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	coreUtils "github.com/nahualventure/class-backend/core/app/shared/utils"
+	commonv1 "github.com/nahualventure/class-backend/proto/gen/common/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PageRequestFromProto validates the list parameters of a call like ListQueryParams does for
+// HTTP requests
+func PageRequestFromProto(request *commonv1.PageRequest, allowedFields []string, defaultOrder pagination.OrderBy) (*pagination.PageRequest, error) {
+	return pagination.NewPageRequest(int(request.GetPageSize()), request.GetCursor(), request.GetOrderBy(), allowedFields, defaultOrder)
+}
+
+// PageResponseToProto returns the pagination metadata of a page, the items go in the response
+// message of each service
+func PageResponseToProto[T any](page *pagination.Page[T]) *commonv1.PageResponse {
+	return &commonv1.PageResponse{
+		NextCursor:    page.NextCursor,
+		TotalEstimate: page.TotalEstimate,
+	}
+}
+
+// ErrorDetailToProto converts any error into the error detail, with the same code, message and
+// context the HTTP envelope would carry. Validation messages are translated to the first
+// supported locale of acceptLanguage.
+func ErrorDetailToProto(err error, acceptLanguage string) *commonv1.ErrorDetail {
+	response := ApplicationErrorToHTTPResponse(err)
+	localizeResponse(&response, err, acceptLanguage)
+
+	detail := &commonv1.ErrorDetail{
+		Code:      response.Error.Code,
+		Message:   response.Error.Message,
+		Retryable: errors2.IsRetryable(err) || response.Error.Code == errors2.ServiceUnavailable.String() || response.Error.Code == errors2.RateLimited.String(),
+	}
+	if occurredAt, parseErr := time.Parse(time.RFC3339, response.Error.Timestamp); parseErr == nil {
+		detail.OccurredAt = timestamppb.New(occurredAt)
+	}
+	var appErr errors2.ApplicationError
+	isValidation := errors.As(err, &appErr) && (appErr.GetCode() == errors2.ValidationError.String() ||
+		appErr.GetCode() == errors2.DomainEntityValidationError.String())
+	for _, key := range sortedKeys(response.Error.Context) {
+		value := fmt.Sprint(response.Error.Context[key])
+		if isValidation {
+			detail.FieldViolations = append(detail.FieldViolations, &commonv1.FieldViolation{Field: key, Description: value})
+			continue
+		}
+		if detail.Metadata == nil {
+			detail.Metadata = make(map[string]string, len(response.Error.Context))
+		}
+		detail.Metadata[key] = value
+	}
+
+	// The field messages are the only translated text, they make up the message of the locale
+	if locale := coreUtils.TranslatorFor(acceptLanguage).Locale(); acceptLanguage != "" && locale != coreUtils.DefaultLocale &&
+		response.Error.Code == errors2.ValidationError.String() && len(detail.FieldViolations) > 0 {
+		descriptions := make([]string, 0, len(detail.FieldViolations))
+		for _, violation := range detail.FieldViolations {
+			descriptions = append(descriptions, violation.Description)
+		}
+		detail.LocalizedMessage = &commonv1.LocalizedMessage{Locale: locale, Message: strings.Join(descriptions, "; ")}
+	}
+	return detail
+}
+
+// BulkItemOutcomeToProto returns the index, status and error detail of an item of a batch, the
+// result messages of batch calls carry it next to the value of the item
+func BulkItemOutcomeToProto[T any](item bulk.Item[T], acceptLanguage string) *commonv1.BulkItemOutcome {
+	outcome := &commonv1.BulkItemOutcome{
+		Index:  int32(item.Index),
+		Status: string(item.Status),
+	}
+	if item.Err != nil {
+		outcome.Error = ErrorDetailToProto(item.Err, acceptLanguage)
+	}
+	return outcome
+}
+
+// BulkSummaryToProto counts the items of a batch by outcome
+func BulkSummaryToProto[T any](result *bulk.Result[T]) *commonv1.BulkSummary {
+	return &commonv1.BulkSummary{
+		Succeeded: int32(result.Succeeded()),
+		Failed:    int32(result.Failed()),
+	}
+}
+
+// AuditInfoToProto records who created and last changed a resource, updatedBy defaults to the
+// creator for resources that never changed
+func AuditInfoToProto(createdBy string, createdAt time.Time, updatedBy string, updatedAt time.Time) *commonv1.AuditInfo {
+	if strings.TrimSpace(updatedBy) == "" {
+		updatedBy = createdBy
+	}
+	if updatedAt.IsZero() {
+		updatedAt = createdAt
+	}
+	return &commonv1.AuditInfo{
+		CreatedBy: createdBy,
+		CreatedAt: timestamppb.New(createdAt),
+		UpdatedBy: updatedBy,
+		UpdatedAt: timestamppb.New(updatedAt),
+	}
+}
+
+func sortedKeys(context map[string]any) []string {
+	keys := make([]string, 0, len(context))
+	for key := range context {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
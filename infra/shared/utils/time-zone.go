@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type timeZoneContextKey string
+
+const (
+	locationContextKey       timeZoneContextKey = "timezone.location"
+	tenantLocationContextKey timeZoneContextKey = "timezone.tenant_location"
+)
+
+// locations memoizes LoadLocation, which reads the zone database on every call
+var locations sync.Map
+
+// LoadLocation loads an IANA zone once per process
+func LoadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// WithLocations puts the zone of the reader and the zone of the tenant in the request context
+func WithLocations(ctx huma.Context, loc *time.Location, tenantLoc *time.Location) huma.Context {
+	ctx = huma.WithValue(ctx, locationContextKey, loc)
+	return huma.WithValue(ctx, tenantLocationContextKey, tenantLoc)
+}
+
+// LocationFromContext returns the zone to show times in, UTC when the time zone middleware did not run
+func LocationFromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(locationContextKey).(*time.Location); ok {
+		return loc
+	}
+	return time.UTC
+}
+
+// TenantLocationFromContext returns the zone of the school, the one class schedules are kept in
+func TenantLocationFromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(tenantLocationContextKey).(*time.Location); ok {
+		return loc
+	}
+	return time.UTC
+}
+
+// InLocation shows an instant in the zone, the zero time stays zero so omitempty keeps working
+func InLocation(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.In(loc)
+}
+
+func InLocationPtr(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := InLocation(*t, loc)
+	return &local
+}
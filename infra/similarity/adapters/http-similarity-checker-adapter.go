@@ -0,0 +1,144 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/entities"
+	similarityErrors "github.com/nahualventure/class-backend/core/app/similarity/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+)
+
+type SimilarityProviderConfig struct {
+	BaseURL       string // e.g. https://api.similarity-provider.example/v1
+	APIKey        string
+	WebhookSecret string // shared secret the provider signs callbacks with
+	CallbackURL   string // public URL of the similarity-webhook endpoint
+}
+
+// HTTPSimilarityChecker talks to a REST similarity provider. Documents are posted as JSON with a
+// base64 body and results come back as callbacks signed with HMAC-SHA256 of the raw body.
+type HTTPSimilarityChecker struct {
+	config SimilarityProviderConfig
+	client *http.Client
+}
+
+func NewHTTPSimilarityChecker(config SimilarityProviderConfig) ports.SimilarityChecker {
+	return &HTTPSimilarityChecker{
+		config: config,
+		client: &http.Client{Timeout: time.Minute, Transport: cost.Transport("similarity", nil)},
+	}
+}
+
+type providerSubmissionRequest struct {
+	ExternalID  string `json:"external_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+	CallbackURL string `json:"callback_url"`
+}
+
+type providerSubmissionResponse struct {
+	ID string `json:"id"`
+}
+
+type providerCallback struct {
+	SubmissionID string   `json:"submission_id"`
+	Status       string   `json:"status"` // completed, failed
+	Score        *float64 `json:"score"`
+	ReportURL    string   `json:"report_url"`
+	Error        string   `json:"error"`
+}
+
+func (c *HTTPSimilarityChecker) Submit(ctx context.Context, check *entities.SimilarityCheck, document *entities.Document) (string, error) {
+	body, err := json.Marshal(providerSubmissionRequest{
+		ExternalID:  check.ID,
+		FileName:    document.FileName,
+		ContentType: document.ContentType,
+		Content:     document.Content,
+		CallbackURL: c.config.CallbackURL,
+	})
+	if err != nil {
+		return "", appErrors.NewInfrastructureError("failed to serialize similarity submission", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.config.BaseURL, "/")+"/submissions", bytes.NewReader(body))
+	if err != nil {
+		return "", appErrors.NewInfrastructureError("failed to build similarity request", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	// The check ID makes retries after a lost response idempotent on the provider side
+	request.Header.Set("Idempotency-Key", check.ID)
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return "", appErrors.NewInfrastructureError("failed to reach similarity provider", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return "", appErrors.NewInfrastructureError(fmt.Sprintf("similarity provider answered %d: %s", response.StatusCode, message), nil)
+	}
+
+	var submission providerSubmissionResponse
+	if err := json.NewDecoder(response.Body).Decode(&submission); err != nil {
+		return "", appErrors.NewInfrastructureError("failed to decode similarity provider response", err)
+	}
+	if submission.ID == "" {
+		return "", appErrors.NewInfrastructureError("similarity provider returned no submission id", nil)
+	}
+
+	return submission.ID, nil
+}
+
+// VerifyCallback checks the HMAC of the body and that it decodes, callbacks have no ID of their own
+func (c *HTTPSimilarityChecker) VerifyCallback(payload []byte, signature string) (string, error) {
+	mac := hmac.New(sha256.New, []byte(c.config.WebhookSecret))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	received, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || !hmac.Equal(expected, received) {
+		return "", similarityErrors.NewInvalidSimilarityCallbackError("signature mismatch")
+	}
+
+	if _, err := c.ParseCallback(payload); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+func (c *HTTPSimilarityChecker) ParseCallback(payload []byte) (*entities.SimilarityResult, error) {
+	var callback providerCallback
+	if err := json.Unmarshal(payload, &callback); err != nil {
+		return nil, similarityErrors.NewInvalidSimilarityCallbackError("malformed body")
+	}
+	if callback.SubmissionID == "" {
+		return nil, similarityErrors.NewInvalidSimilarityCallbackError("missing submission_id")
+	}
+
+	switch callback.Status {
+	case "failed":
+		return &entities.SimilarityResult{ProviderReference: callback.SubmissionID, Failed: true, FailureReason: callback.Error}, nil
+	case "completed":
+		if callback.Score == nil || *callback.Score < 0 || *callback.Score > 100 {
+			return nil, similarityErrors.NewInvalidSimilarityCallbackError("score must be between 0 and 100")
+		}
+		return &entities.SimilarityResult{ProviderReference: callback.SubmissionID, Score: *callback.Score, ReportURL: callback.ReportURL}, nil
+	default:
+		return nil, similarityErrors.NewInvalidSimilarityCallbackError(fmt.Sprintf("unknown status %q", callback.Status))
+	}
+}
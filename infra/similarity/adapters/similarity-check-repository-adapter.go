@@ -0,0 +1,300 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSimilarityCheckRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresSimilarityCheckRepository(dbInstance *pgxpool.Pool) ports.SimilarityCheckRepository {
+	return &PostgresSimilarityCheckRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresSimilarityCheckRepository) Create(ctx context.Context, check *entities.SimilarityCheck, document *entities.Document) error {
+	var pgID, pgClassID, pgAssignmentID pgtype.UUID
+	if err := pgID.Scan(check.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgClassID.Scan(check.ClassID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgAssignmentID.Scan(check.AssignmentID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.CreateSimilarityCheck(ctx, db.CreateSimilarityCheckParams{
+		ID:           pgID,
+		TenantID:     check.TenantID,
+		ClassID:      pgClassID,
+		AssignmentID: pgAssignmentID,
+		StudentID:    check.StudentID,
+		FileName:     check.FileName,
+		Status:       string(check.Status),
+		CreatedAt:    pgtype.Timestamptz{Time: check.CreatedAt, Valid: true},
+		UpdatedAt:    pgtype.Timestamptz{Time: check.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err = qtx.CreateSimilarityDocument(ctx, db.CreateSimilarityDocumentParams{
+		CheckID:     pgID,
+		ContentType: document.ContentType,
+		Content:     document.Content,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresSimilarityCheckRepository) FindByID(ctx context.Context, tenantID string, checkID string) (*entities.SimilarityCheck, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(checkID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetSimilarityCheck(ctx, db.GetSimilarityCheckParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toSimilarityCheck(row)
+}
+
+func (r *PostgresSimilarityCheckRepository) FindByProviderReference(ctx context.Context, providerReference string) (*entities.SimilarityCheck, error) {
+	row, err := r.queries.GetSimilarityCheckByProviderReference(ctx, &providerReference)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toSimilarityCheck(row)
+}
+
+func (r *PostgresSimilarityCheckRepository) ListByAssignment(ctx context.Context, tenantID string, classID string, assignmentID string) ([]*entities.SimilarityCheck, error) {
+	var pgClassID, pgAssignmentID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := pgAssignmentID.Scan(assignmentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListSimilarityChecksByAssignment(ctx, db.ListSimilarityChecksByAssignmentParams{
+		TenantID:     tenantID,
+		ClassID:      pgClassID,
+		AssignmentID: pgAssignmentID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	checks := make([]*entities.SimilarityCheck, 0, len(rows))
+	for _, row := range rows {
+		check, err := toSimilarityCheck(row)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+func (r *PostgresSimilarityCheckRepository) SaveResult(ctx context.Context, check *entities.SimilarityCheck) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(check.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	completedAt := pgtype.Timestamptz{}
+	if check.CompletedAt != nil {
+		completedAt = pgtype.Timestamptz{Time: *check.CompletedAt, Valid: true}
+	}
+
+	err := r.queries.SaveSimilarityResult(ctx, db.SaveSimilarityResultParams{
+		Status:        string(check.Status),
+		Score:         check.Score,
+		ReportUrl:     emptyToNil(check.ReportURL),
+		FailureReason: emptyToNil(check.FailureReason),
+		CompletedAt:   completedAt,
+		UpdatedAt:     pgtype.Timestamptz{Time: check.UpdatedAt, Valid: true},
+		ID:            pgID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresSimilarityCheckRepository) ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.SimilarityCheck, *entities.Document, error) {
+	row, err := r.queries.ClaimNextPendingSimilarityCheck(ctx, pgtype.Timestamptz{Time: time.Now().Add(-staleAfter), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, nil
+		}
+		return nil, nil, appErrors.PropagateError(err)
+	}
+
+	check, err := toSimilarityCheck(row)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	document, err := r.queries.GetSimilarityDocument(ctx, row.ID)
+	if err != nil {
+		return nil, nil, appErrors.PropagateError(err)
+	}
+
+	return check, &entities.Document{FileName: check.FileName, ContentType: document.ContentType, Content: document.Content}, nil
+}
+
+func (r *PostgresSimilarityCheckRepository) MarkSubmitted(ctx context.Context, checkID string, providerReference string) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(checkID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.MarkSimilarityCheckSubmitted(ctx, db.MarkSimilarityCheckSubmittedParams{ProviderReference: &providerReference, ID: pgID})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := qtx.DeleteSimilarityDocument(ctx, pgID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresSimilarityCheckRepository) MarkRetry(ctx context.Context, checkID string, reason string, retryAt time.Time) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(checkID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.MarkSimilarityCheckRetry(ctx, db.MarkSimilarityCheckRetryParams{
+		FailureReason: &reason,
+		AvailableAt:   pgtype.Timestamptz{Time: retryAt, Valid: true},
+		ID:            pgID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+// MarkFailed drops the document too, the student resubmits to get a new check
+func (r *PostgresSimilarityCheckRepository) MarkFailed(ctx context.Context, checkID string, reason string) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(checkID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	if err := qtx.MarkSimilarityCheckFailed(ctx, db.MarkSimilarityCheckFailedParams{FailureReason: &reason, ID: pgID}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := qtx.DeleteSimilarityDocument(ctx, pgID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func toSimilarityCheck(row db.SimilarityCheck) (*entities.SimilarityCheck, error) {
+	var completedAt *time.Time
+	if row.CompletedAt.Valid {
+		completedAt = &row.CompletedAt.Time
+	}
+
+	return entities.NewSimilarityCheck(
+		row.ID.String(),
+		row.TenantID,
+		row.ClassID.String(),
+		row.AssignmentID.String(),
+		row.StudentID,
+		row.FileName,
+		entities.SimilarityCheckStatus(row.Status),
+		int(row.Attempts),
+		valueOrEmpty(row.ProviderReference),
+		row.Score,
+		valueOrEmpty(row.ReportUrl),
+		valueOrEmpty(row.FailureReason),
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+		completedAt,
+	)
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
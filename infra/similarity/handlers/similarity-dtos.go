@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/entities"
+)
+
+type SimilarityCheckResponse struct {
+	ID            string     `json:"id"`
+	ClassID       string     `json:"class_id"`
+	AssignmentID  string     `json:"assignment_id"`
+	StudentID     string     `json:"student_id"`
+	FileName      string     `json:"file_name"`
+	Status        string     `json:"status" enum:"pending,submitted,completed,failed"`
+	Score         *float64   `json:"score,omitempty" doc:"Percentage of the document matching other sources"`
+	ReportURL     string     `json:"report_url,omitempty" doc:"Provider report with the matched sources"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+func NewSimilarityCheckResponse(check *entities.SimilarityCheck) SimilarityCheckResponse {
+	return SimilarityCheckResponse{
+		ID:            check.ID,
+		ClassID:       check.ClassID,
+		AssignmentID:  check.AssignmentID,
+		StudentID:     check.StudentID,
+		FileName:      check.FileName,
+		Status:        string(check.Status),
+		Score:         check.Score,
+		ReportURL:     check.ReportURL,
+		FailureReason: check.FailureReason,
+		CreatedAt:     check.CreatedAt,
+		CompletedAt:   check.CompletedAt,
+	}
+}
+
+type SimilarityCheckEnvelope struct {
+	Body SimilarityCheckResponse
+}
+
+// SubmissionReceiptResponse is what students get back, scores are only shown to staff
+type SubmissionReceiptResponse struct {
+	Body struct {
+		CheckID   string    `json:"check_id"`
+		Status    string    `json:"status" enum:"pending,submitted,completed,failed"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+}
+
+type SimilarityCheckListResponse struct {
+	Body struct {
+		Items []SimilarityCheckResponse `json:"items"`
+	}
+}
+
+type RequestSimilarityCheckRequest struct {
+	ClassID      string `path:"classId" format:"uuid"`
+	AssignmentID string `path:"assignmentId" format:"uuid"`
+	Body         struct {
+		FileName    string `json:"file_name" minLength:"1" maxLength:"255"`
+		ContentType string `json:"content_type" minLength:"1" maxLength:"100" example:"application/pdf"`
+		Content     []byte `json:"content" doc:"Base64 encoded document, at most 5 MiB"`
+	}
+}
+
+type ListSimilarityChecksRequest struct {
+	ClassID      string `path:"classId" format:"uuid"`
+	AssignmentID string `path:"assignmentId" format:"uuid"`
+}
+
+type GetSimilarityCheckRequest struct {
+	CheckID string `path:"checkId" format:"uuid"`
+}
+
+type SimilarityWebhookRequest struct {
+	Signature string `header:"X-Signature" required:"true" doc:"Hex HMAC-SHA256 of the body with the shared webhook secret"`
+	RawBody   []byte
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/nahualventure/class-backend/core/app/shared/webhook"
+	get_similarity_check_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/get-similarity-check-use-case"
+	list_similarity_checks_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/list-similarity-checks-use-case"
+	request_similarity_check_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/request-similarity-check-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type SimilarityHandlers struct {
+	requestCheckUseCase *request_similarity_check_use_case.RequestSimilarityCheckUseCase
+	listChecksUseCase   *list_similarity_checks_use_case.ListSimilarityChecksUseCase
+	getCheckUseCase     *get_similarity_check_use_case.GetSimilarityCheckUseCase
+	webhooks            *webhook.Receiver
+}
+
+func NewSimilarityHandlers(
+	requestCheckUseCase *request_similarity_check_use_case.RequestSimilarityCheckUseCase,
+	listChecksUseCase *list_similarity_checks_use_case.ListSimilarityChecksUseCase,
+	getCheckUseCase *get_similarity_check_use_case.GetSimilarityCheckUseCase,
+	webhooks *webhook.Receiver,
+) *SimilarityHandlers {
+	return &SimilarityHandlers{
+		requestCheckUseCase: requestCheckUseCase,
+		listChecksUseCase:   listChecksUseCase,
+		getCheckUseCase:     getCheckUseCase,
+		webhooks:            webhooks,
+	}
+}
+
+func (h *SimilarityHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "request-similarity-check",
+		Method:        http.MethodPost,
+		Path:          "/classes/{classId}/assignments/{assignmentId}/submissions",
+		Summary:       "Submit a document for the current student",
+		Description:   "Queues the document for a similarity check, the provider result arrives asynchronously.",
+		Tags:          []string{"Similarity"},
+		DefaultStatus: http.StatusAccepted,
+		// base64 grows the document by a third
+		MaxBodyBytes: request_similarity_check_use_case.MaxDocumentBytes*4/3 + 64*1024,
+	}, h.RequestSimilarityCheck)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-similarity-checks",
+		Method:      http.MethodGet,
+		Path:        "/classes/{classId}/assignments/{assignmentId}/similarity-checks",
+		Summary:     "List the similarity checks of an assignment",
+		Tags:        []string{"Similarity"},
+	}, h.ListSimilarityChecks)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-similarity-check",
+		Method:      http.MethodGet,
+		Path:        "/similarity-checks/{checkId}",
+		Summary:     "Get a similarity check",
+		Tags:        []string{"Similarity"},
+	}, h.GetSimilarityCheck)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "similarity-webhook",
+		Method:        http.MethodPost,
+		Path:          "/webhooks/similarity",
+		Summary:       "Receive similarity results from the provider",
+		Description:   "Public endpoint authenticated by the X-Signature header. Results are stored asynchronously, callbacks received before are acknowledged without being stored again.",
+		Tags:          []string{"Similarity"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.ReceiveSimilarityResult)
+}
+
+func (h *SimilarityHandlers) RequestSimilarityCheck(ctx context.Context, input *RequestSimilarityCheckRequest) (*SubmissionReceiptResponse, error) {
+	command, err := request_similarity_check_use_case.NewRequestSimilarityCheckCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		input.AssignmentID,
+		authorization.UserIDFromContext(ctx),
+		input.Body.FileName,
+		input.Body.ContentType,
+		input.Body.Content,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	check, err := h.requestCheckUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &SubmissionReceiptResponse{}
+	response.Body.CheckID = check.ID
+	response.Body.Status = string(check.Status)
+	response.Body.CreatedAt = check.CreatedAt
+
+	return response, nil
+}
+
+func (h *SimilarityHandlers) ListSimilarityChecks(ctx context.Context, input *ListSimilarityChecksRequest) (*SimilarityCheckListResponse, error) {
+	command, err := list_similarity_checks_use_case.NewListSimilarityChecksCommand(authorization.TenantIDFromContext(ctx), input.ClassID, input.AssignmentID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	checks, err := h.listChecksUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &SimilarityCheckListResponse{}
+	response.Body.Items = make([]SimilarityCheckResponse, 0, len(checks))
+	for _, check := range checks {
+		response.Body.Items = append(response.Body.Items, NewSimilarityCheckResponse(check))
+	}
+
+	return response, nil
+}
+
+func (h *SimilarityHandlers) GetSimilarityCheck(ctx context.Context, input *GetSimilarityCheckRequest) (*SimilarityCheckEnvelope, error) {
+	command, err := get_similarity_check_use_case.NewGetSimilarityCheckCommand(authorization.TenantIDFromContext(ctx), input.CheckID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	check, err := h.getCheckUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &SimilarityCheckEnvelope{Body: NewSimilarityCheckResponse(check)}, nil
+}
+
+func (h *SimilarityHandlers) ReceiveSimilarityResult(ctx context.Context, input *SimilarityWebhookRequest) (*struct{}, error) {
+	request := webhook.Request{Payload: input.RawBody, Headers: map[string]string{similaritySignatureHeader: input.Signature}}
+	if _, err := h.webhooks.Receive(ctx, SimilarityWebhookSource, request); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/shared/webhook"
+	receive_similarity_result_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/receive-similarity-result-use-case"
+	"github.com/nahualventure/class-backend/core/app/similarity/domain/ports"
+)
+
+// SimilarityWebhookSource names the deliveries of the callbacks of the similarity provider
+const SimilarityWebhookSource = "similarity"
+
+const similaritySignatureHeader = "X-Signature"
+
+// NewSimilarityWebhookSource verifies the callbacks of the provider when they are received, their
+// results are stored on the webhook worker
+func NewSimilarityWebhookSource(checker ports.SimilarityChecker, receiveResultUseCase *receive_similarity_result_use_case.ReceiveSimilarityResultUseCase) webhook.Source {
+	return webhook.Source{
+		Name: SimilarityWebhookSource,
+		Verify: func(_ context.Context, request webhook.Request) (string, error) {
+			return checker.VerifyCallback(request.Payload, request.Headers[similaritySignatureHeader])
+		},
+		Handle: func(ctx context.Context, request webhook.Request) error {
+			command, err := receive_similarity_result_use_case.NewReceiveSimilarityResultCommand(request.Payload)
+			if err != nil {
+				return err
+			}
+			_, err = receiveResultUseCase.Execute(ctx, command)
+			return err
+		},
+	}
+}
@@ -0,0 +1,71 @@
+-- name: CreateSimilarityCheck :exec
+INSERT INTO similarity_checks (id, tenant_id, class_id, assignment_id, student_id, file_name, status, created_at, updated_at)
+VALUES (@id, @tenant_id, @class_id, @assignment_id, @student_id, @file_name, @status, @created_at, @updated_at);
+
+-- name: CreateSimilarityDocument :exec
+INSERT INTO similarity_documents (check_id, content_type, content)
+VALUES (@check_id, @content_type, @content);
+
+-- name: GetSimilarityCheck :one
+SELECT *
+FROM similarity_checks
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: GetSimilarityCheckByProviderReference :one
+SELECT *
+FROM similarity_checks
+WHERE provider_reference = @provider_reference;
+
+-- name: ListSimilarityChecksByAssignment :many
+SELECT *
+FROM similarity_checks
+WHERE tenant_id = @tenant_id AND class_id = @class_id AND assignment_id = @assignment_id
+ORDER BY created_at DESC, id;
+
+-- name: SaveSimilarityResult :exec
+UPDATE similarity_checks
+SET status = @status,
+    score = @score,
+    report_url = @report_url,
+    failure_reason = @failure_reason,
+    completed_at = @completed_at,
+    updated_at = @updated_at
+WHERE id = @id;
+
+-- name: ClaimNextPendingSimilarityCheck :one
+UPDATE similarity_checks
+SET claimed_at = NOW(), attempts = attempts + 1, updated_at = NOW()
+WHERE id = (
+    SELECT c.id
+    FROM similarity_checks c
+    WHERE c.status = 'pending' AND c.available_at <= NOW()
+      AND (c.claimed_at IS NULL OR c.claimed_at < @stale_before)
+    ORDER BY c.available_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: GetSimilarityDocument :one
+SELECT content_type, content
+FROM similarity_documents
+WHERE check_id = @check_id;
+
+-- name: MarkSimilarityCheckSubmitted :exec
+UPDATE similarity_checks
+SET status = 'submitted', provider_reference = @provider_reference, failure_reason = NULL, claimed_at = NULL, updated_at = NOW()
+WHERE id = @id;
+
+-- name: DeleteSimilarityDocument :exec
+DELETE FROM similarity_documents
+WHERE check_id = @check_id;
+
+-- name: MarkSimilarityCheckRetry :exec
+UPDATE similarity_checks
+SET failure_reason = @failure_reason, available_at = @available_at, claimed_at = NULL, updated_at = NOW()
+WHERE id = @id;
+
+-- name: MarkSimilarityCheckFailed :exec
+UPDATE similarity_checks
+SET status = 'failed', failure_reason = @failure_reason, claimed_at = NULL, completed_at = NOW(), updated_at = NOW()
+WHERE id = @id;
@@ -0,0 +1,30 @@
+-- Similarity checks of submitted documents, results arrive from the provider through callbacks
+CREATE TABLE similarity_checks (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    assignment_id UUID NOT NULL,
+    student_id VARCHAR(255) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,           -- pending, submitted, completed, failed
+    attempts INTEGER NOT NULL DEFAULT 0,
+    provider_reference VARCHAR(255) UNIQUE,
+    score DOUBLE PRECISION,
+    report_url TEXT,
+    failure_reason TEXT,
+    available_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(), -- retries wait until then
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_similarity_checks_assignment ON similarity_checks(tenant_id, class_id, assignment_id, created_at);
+CREATE INDEX idx_similarity_checks_status ON similarity_checks(status, available_at);
+
+-- Documents waiting to be sent to the provider, deleted once the provider accepted them
+CREATE TABLE similarity_documents (
+    check_id UUID PRIMARY KEY REFERENCES similarity_checks(id) ON DELETE CASCADE,
+    content_type VARCHAR(100) NOT NULL,
+    content BYTEA NOT NULL
+);
@@ -0,0 +1,34 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	process_similarity_checks_use_case "github.com/nahualventure/class-backend/core/app/similarity/application/use-cases/process-similarity-checks-use-case"
+)
+
+// RunSimilarityWorker drains the similarity queue every interval, it returns when ctx is cancelled.
+// Several instances can run concurrently, checks are claimed with SKIP LOCKED.
+func RunSimilarityWorker(ctx context.Context, useCase *process_similarity_checks_use_case.ProcessSimilarityChecksUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			processed, err := useCase.Execute(ctx)
+			if err != nil {
+				log.Printf("Similarity check submission failed: %v", err)
+			}
+			if !processed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package adapters
+
+import (
+	"context"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresAssignmentStandardRepository replaces the tags of an assignment in one transaction, readers
+// never see it half tagged
+type PostgresAssignmentStandardRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresAssignmentStandardRepository(dbInstance *pgxpool.Pool) ports.AssignmentStandardRepository {
+	return &PostgresAssignmentStandardRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresAssignmentStandardRepository) Replace(ctx context.Context, tags *entities.AssignmentStandards) error {
+	var pgClassID, pgAssignmentID pgtype.UUID
+	if err := pgClassID.Scan(tags.ClassID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgAssignmentID.Scan(tags.AssignmentID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.DeleteAssignmentStandards(ctx, db.DeleteAssignmentStandardsParams{
+		TenantID:     tags.TenantID,
+		ClassID:      pgClassID,
+		AssignmentID: pgAssignmentID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	for _, standardID := range tags.StandardIDs {
+		var pgStandardID pgtype.UUID
+		if err := pgStandardID.Scan(standardID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+		err := qtx.InsertAssignmentStandard(ctx, db.InsertAssignmentStandardParams{
+			TenantID:     tags.TenantID,
+			ClassID:      pgClassID,
+			AssignmentID: pgAssignmentID,
+			StandardID:   pgStandardID,
+			TaggedBy:     tags.TaggedBy,
+			TaggedAt:     pgtype.Timestamptz{Time: tags.TaggedAt, Valid: true},
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresAssignmentStandardRepository) Find(ctx context.Context, tenantID string, classID string, assignmentID string) ([]string, error) {
+	var pgClassID, pgAssignmentID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := pgAssignmentID.Scan(assignmentID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := r.queries.ListAssignmentStandards(ctx, db.ListAssignmentStandardsParams{
+		TenantID:     tenantID,
+		ClassID:      pgClassID,
+		AssignmentID: pgAssignmentID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	standardIDs := make([]string, len(rows))
+	for i, row := range rows {
+		standardIDs[i] = row.String()
+	}
+
+	return standardIDs, nil
+}
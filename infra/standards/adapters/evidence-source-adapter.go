@@ -0,0 +1,85 @@
+package adapters
+
+import (
+	"context"
+
+	gradingEntities "github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresEvidenceSource reads the released grades of the grading module on tagged assignments
+type PostgresEvidenceSource struct {
+	queries *db.Queries
+}
+
+func NewPostgresEvidenceSource(dbInstance *pgxpool.Pool) ports.EvidenceSource {
+	return &PostgresEvidenceSource{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresEvidenceSource) StudentEvidence(ctx context.Context, tenantID string, studentID string) ([]entities.MasteryEvidence, error) {
+	rows, err := s.queries.ListStudentMasteryEvidence(ctx, db.ListStudentMasteryEvidenceParams{
+		TenantID:  tenantID,
+		StudentID: studentID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	evidence := make([]entities.MasteryEvidence, 0, len(rows))
+	for _, row := range rows {
+		if !row.ReleasedAt.Valid {
+			continue
+		}
+		evidence = append(evidence, entities.MasteryEvidence{
+			StandardID:   row.StandardID,
+			StudentID:    row.StudentID,
+			ClassID:      row.ClassID,
+			AssignmentID: row.AssignmentID,
+			Score:        gradingEntities.AdjustScore(row.Score, row.LatePenaltyPercent),
+			ReleasedAt:   row.ReleasedAt.Time,
+		})
+	}
+
+	return evidence, nil
+}
+
+func (s *PostgresEvidenceSource) ClassEvidence(ctx context.Context, tenantID string, classID string) ([]entities.MasteryEvidence, error) {
+	var pgClassID pgtype.UUID
+	if err := pgClassID.Scan(classID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	rows, err := s.queries.ListClassMasteryEvidence(ctx, db.ListClassMasteryEvidenceParams{
+		TenantID: tenantID,
+		ClassID:  pgClassID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	evidence := make([]entities.MasteryEvidence, 0, len(rows))
+	for _, row := range rows {
+		if !row.ReleasedAt.Valid {
+			continue
+		}
+		evidence = append(evidence, entities.MasteryEvidence{
+			StandardID:   row.StandardID,
+			StudentID:    row.StudentID,
+			ClassID:      row.ClassID,
+			AssignmentID: row.AssignmentID,
+			Score:        gradingEntities.AdjustScore(row.Score, row.LatePenaltyPercent),
+			ReleasedAt:   row.ReleasedAt.Time,
+		})
+	}
+
+	return evidence, nil
+}
@@ -0,0 +1,81 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// storedLevel is a level of the levels column
+type storedLevel struct {
+	Name     string  `json:"name"`
+	MinScore float64 `json:"min_score"`
+}
+
+type PostgresMasteryPolicyRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresMasteryPolicyRepository(dbInstance *pgxpool.Pool) ports.MasteryPolicyRepository {
+	return &PostgresMasteryPolicyRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresMasteryPolicyRepository) Find(ctx context.Context, tenantID string) (*entities.MasteryPolicy, error) {
+	row, err := r.queries.GetMasteryPolicy(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var stored []storedLevel
+	if err := json.Unmarshal(row.Levels, &stored); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to deserialize mastery levels", err)
+	}
+	levels := make([]entities.MasteryLevel, len(stored))
+	for i, level := range stored {
+		levels[i] = entities.MasteryLevel{Name: level.Name, MinScore: level.MinScore}
+	}
+
+	return entities.NewMasteryPolicy(row.TenantID, entities.MasteryMethod(row.Method), row.DecayWeight, levels, row.MasteredLevel,
+		row.UpdatedBy, row.UpdatedAt.Time)
+}
+
+func (r *PostgresMasteryPolicyRepository) Save(ctx context.Context, policy *entities.MasteryPolicy) error {
+	stored := make([]storedLevel, len(policy.Levels))
+	for i, level := range policy.Levels {
+		stored[i] = storedLevel{Name: level.Name, MinScore: level.MinScore}
+	}
+	levels, err := json.Marshal(stored)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize mastery levels", err)
+	}
+
+	err = r.queries.UpsertMasteryPolicy(ctx, db.UpsertMasteryPolicyParams{
+		TenantID:      policy.TenantID,
+		Method:        string(policy.Method),
+		DecayWeight:   policy.DecayWeight,
+		Levels:        levels,
+		MasteredLevel: policy.MasteredLevel,
+		UpdatedBy:     policy.UpdatedBy,
+		UpdatedAt:     pgtype.Timestamptz{Time: policy.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package adapters
+
+import (
+	"context"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresStandardRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresStandardRepository(dbInstance *pgxpool.Pool) ports.StandardRepository {
+	return &PostgresStandardRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresStandardRepository) Save(ctx context.Context, standard *entities.Standard) (*entities.Standard, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(standard.ID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.UpsertStandard(ctx, db.UpsertStandardParams{
+		ID:          pgID,
+		TenantID:    standard.TenantID,
+		Framework:   standard.Framework,
+		Code:        standard.Code,
+		ParentCode:  emptyToNil(standard.ParentCode),
+		Subject:     emptyToNil(standard.Subject),
+		GradeLevel:  emptyToNil(standard.GradeLevel),
+		Description: standard.Description,
+		CreatedAt:   pgtype.Timestamptz{Time: standard.CreatedAt, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: standard.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toStandard(row)
+}
+
+func (r *PostgresStandardRepository) FindByIDs(ctx context.Context, tenantID string, standardIDs []string) ([]*entities.Standard, error) {
+	ids := make([]pgtype.UUID, len(standardIDs))
+	for i, standardID := range standardIDs {
+		if err := ids[i].Scan(standardID); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+	}
+
+	rows, err := r.queries.ListStandardsByIDs(ctx, db.ListStandardsByIDsParams{TenantID: tenantID, Ids: ids})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toStandards(rows)
+}
+
+func (r *PostgresStandardRepository) List(ctx context.Context, tenantID string, framework string) ([]*entities.Standard, error) {
+	rows, err := r.queries.ListStandards(ctx, db.ListStandardsParams{TenantID: tenantID, Framework: emptyToNil(framework)})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toStandards(rows)
+}
+
+func toStandards(rows []db.Standard) ([]*entities.Standard, error) {
+	standards := make([]*entities.Standard, 0, len(rows))
+	for _, row := range rows {
+		standard, err := toStandard(row)
+		if err != nil {
+			return nil, err
+		}
+		standards = append(standards, standard)
+	}
+	return standards, nil
+}
+
+func toStandard(row db.Standard) (*entities.Standard, error) {
+	return entities.NewStandard(
+		row.ID.String(),
+		row.TenantID,
+		row.Framework,
+		row.Code,
+		valueOrEmpty(row.ParentCode),
+		valueOrEmpty(row.Subject),
+		valueOrEmpty(row.GradeLevel),
+		row.Description,
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+	)
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+)
+
+type StandardResponse struct {
+	ID          string    `json:"id"`
+	Framework   string    `json:"framework"`
+	Code        string    `json:"code"`
+	ParentCode  string    `json:"parent_code,omitempty" doc:"Code of the broader standard of the same framework"`
+	Subject     string    `json:"subject,omitempty"`
+	GradeLevel  string    `json:"grade_level,omitempty"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewStandardResponse(standard *entities.Standard) StandardResponse {
+	return StandardResponse{
+		ID:          standard.ID,
+		Framework:   standard.Framework,
+		Code:        standard.Code,
+		ParentCode:  standard.ParentCode,
+		Subject:     standard.Subject,
+		GradeLevel:  standard.GradeLevel,
+		Description: standard.Description,
+		CreatedAt:   standard.CreatedAt,
+		UpdatedAt:   standard.UpdatedAt,
+	}
+}
+
+func NewStandardResponses(standards []*entities.Standard) []StandardResponse {
+	responses := make([]StandardResponse, 0, len(standards))
+	for _, standard := range standards {
+		responses = append(responses, NewStandardResponse(standard))
+	}
+	return responses
+}
+
+type SaveStandardRequest struct {
+	Body struct {
+		Framework   string `json:"framework" maxLength:"100" doc:"Framework the standard belongs to, such as CCSS.Math"`
+		Code        string `json:"code" maxLength:"100" doc:"Code of the standard within its framework, saving an existing code updates the standard"`
+		ParentCode  string `json:"parent_code,omitempty" maxLength:"100"`
+		Subject     string `json:"subject,omitempty" maxLength:"100"`
+		GradeLevel  string `json:"grade_level,omitempty" maxLength:"20"`
+		Description string `json:"description" maxLength:"2000"`
+	}
+}
+
+type StandardEnvelope struct {
+	Body StandardResponse
+}
+
+type ListStandardsRequest struct {
+	Framework string `query:"framework" maxLength:"100" doc:"Only list the standards of this framework"`
+}
+
+type ListStandardsResponse struct {
+	Body struct {
+		Items []StandardResponse `json:"items"`
+	}
+}
+
+type GetAssignmentStandardsRequest struct {
+	ClassID      string `path:"classId" format:"uuid"`
+	AssignmentID string `path:"assignmentId" format:"uuid"`
+}
+
+type TagAssignmentStandardsRequest struct {
+	ClassID      string `path:"classId" format:"uuid"`
+	AssignmentID string `path:"assignmentId" format:"uuid"`
+	Body         struct {
+		StandardIDs []string `json:"standard_ids" maxItems:"20" uniqueItems:"true" doc:"Standards the assignment assesses, empty untags it"`
+	}
+}
+
+type AssignmentStandardsResponse struct {
+	Body struct {
+		Items []StandardResponse `json:"items"`
+	}
+}
+
+type MasteryLevelBody struct {
+	Name     string  `json:"name" maxLength:"50"`
+	MinScore float64 `json:"min_score" minimum:"0" maximum:"100" doc:"Lowest final score of the level, the first level starts at 0"`
+}
+
+type MasteryPolicyResponse struct {
+	Method        string             `json:"method"`
+	DecayWeight   float64            `json:"decay_weight"`
+	Levels        []MasteryLevelBody `json:"levels"`
+	MasteredLevel string             `json:"mastered_level"`
+	Default       bool               `json:"default" doc:"Whether the tenant never set its policy"`
+	UpdatedBy     string             `json:"updated_by,omitempty"`
+	UpdatedAt     *time.Time         `json:"updated_at,omitempty"`
+}
+
+func NewMasteryPolicyResponse(policy *entities.MasteryPolicy) MasteryPolicyResponse {
+	response := MasteryPolicyResponse{
+		Method:        string(policy.Method),
+		DecayWeight:   policy.DecayWeight,
+		Levels:        make([]MasteryLevelBody, 0, len(policy.Levels)),
+		MasteredLevel: policy.MasteredLevel,
+		Default:       policy.IsDefault(),
+	}
+	for _, level := range policy.Levels {
+		response.Levels = append(response.Levels, MasteryLevelBody{Name: level.Name, MinScore: level.MinScore})
+	}
+	if !policy.IsDefault() {
+		response.UpdatedBy = policy.UpdatedBy
+		response.UpdatedAt = &policy.UpdatedAt
+	}
+	return response
+}
+
+type SetMasteryPolicyRequest struct {
+	Body struct {
+		Method        string             `json:"method" enum:"mean,most_recent,highest,decaying_average"`
+		DecayWeight   float64            `json:"decay_weight,omitempty" doc:"Weight of each piece of evidence against the earlier ones, for decaying_average only"`
+		Levels        []MasteryLevelBody `json:"levels" minItems:"2" maxItems:"6" doc:"Levels from the lowest to the highest"`
+		MasteredLevel string             `json:"mastered_level" doc:"Lowest level counted as mastering the standard"`
+	}
+}
+
+type MasteryPolicyEnvelope struct {
+	Body MasteryPolicyResponse
+}
+
+type GetClassMasteryRequest struct {
+	ClassID string `path:"classId" format:"uuid"`
+}
+
+type GetStudentMasteryRequest struct {
+	StudentID string `path:"studentId" doc:"Student ID"`
+}
+
+type StudentMasteryResponse struct {
+	StudentID      string    `json:"student_id"`
+	Score          float64   `json:"score"`
+	Level          string    `json:"level"`
+	Mastered       bool      `json:"mastered"`
+	EvidenceCount  int       `json:"evidence_count" doc:"Released grades the level is computed from"`
+	LastEvidenceAt time.Time `json:"last_evidence_at"`
+}
+
+type LevelCountResponse struct {
+	Level    string `json:"level"`
+	Students int    `json:"students"`
+}
+
+type StandardMasteryResponse struct {
+	Standard      StandardResponse         `json:"standard"`
+	StudentCount  int                      `json:"student_count"`
+	MasteredCount int                      `json:"mastered_count"`
+	LevelCounts   []LevelCountResponse     `json:"level_counts" doc:"Students at each level of the policy, lowest first"`
+	Students      []StudentMasteryResponse `json:"students"`
+}
+
+type MasteryReportResponse struct {
+	Body struct {
+		Policy    MasteryPolicyResponse     `json:"policy"`
+		Standards []StandardMasteryResponse `json:"standards" doc:"Standards with evidence, ordered by framework then code"`
+	}
+}
+
+func NewMasteryReportResponse(report *entities.MasteryReport) *MasteryReportResponse {
+	response := &MasteryReportResponse{}
+	response.Body.Policy = NewMasteryPolicyResponse(report.Policy)
+	response.Body.Standards = make([]StandardMasteryResponse, 0, len(report.Standards))
+	for _, standard := range report.Standards {
+		levelCounts := make([]LevelCountResponse, 0, len(report.Policy.Levels))
+		for i, level := range report.Policy.Levels {
+			levelCounts = append(levelCounts, LevelCountResponse{Level: level.Name, Students: standard.LevelCounts[i]})
+		}
+		students := make([]StudentMasteryResponse, 0, len(standard.Masteries))
+		for _, mastery := range standard.Masteries {
+			students = append(students, StudentMasteryResponse{
+				StudentID:      mastery.StudentID,
+				Score:          mastery.Score,
+				Level:          mastery.Level,
+				Mastered:       mastery.Mastered,
+				EvidenceCount:  mastery.EvidenceCount,
+				LastEvidenceAt: mastery.LastEvidence,
+			})
+		}
+		response.Body.Standards = append(response.Body.Standards, StandardMasteryResponse{
+			Standard:      NewStandardResponse(standard.Standard),
+			StudentCount:  standard.StudentCount,
+			MasteredCount: standard.MasteredCount,
+			LevelCounts:   levelCounts,
+			Students:      students,
+		})
+	}
+	return response
+}
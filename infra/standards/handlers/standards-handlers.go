@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_assignment_standards_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/get-assignment-standards-use-case"
+	get_class_mastery_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/get-class-mastery-use-case"
+	get_mastery_policy_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/get-mastery-policy-use-case"
+	get_student_mastery_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/get-student-mastery-use-case"
+	list_standards_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/list-standards-use-case"
+	save_standard_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/save-standard-use-case"
+	set_mastery_policy_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/set-mastery-policy-use-case"
+	tag_assignment_standards_use_case "github.com/nahualventure/class-backend/core/app/standards/application/use-cases/tag-assignment-standards-use-case"
+	"github.com/nahualventure/class-backend/core/app/standards/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type StandardsHandlers struct {
+	saveStandardUseCase           *save_standard_use_case.SaveStandardUseCase
+	listStandardsUseCase          *list_standards_use_case.ListStandardsUseCase
+	tagAssignmentStandardsUseCase *tag_assignment_standards_use_case.TagAssignmentStandardsUseCase
+	getAssignmentStandardsUseCase *get_assignment_standards_use_case.GetAssignmentStandardsUseCase
+	getMasteryPolicyUseCase       *get_mastery_policy_use_case.GetMasteryPolicyUseCase
+	setMasteryPolicyUseCase       *set_mastery_policy_use_case.SetMasteryPolicyUseCase
+	getClassMasteryUseCase        *get_class_mastery_use_case.GetClassMasteryUseCase
+	getStudentMasteryUseCase      *get_student_mastery_use_case.GetStudentMasteryUseCase
+}
+
+func NewStandardsHandlers(
+	saveStandardUseCase *save_standard_use_case.SaveStandardUseCase,
+	listStandardsUseCase *list_standards_use_case.ListStandardsUseCase,
+	tagAssignmentStandardsUseCase *tag_assignment_standards_use_case.TagAssignmentStandardsUseCase,
+	getAssignmentStandardsUseCase *get_assignment_standards_use_case.GetAssignmentStandardsUseCase,
+	getMasteryPolicyUseCase *get_mastery_policy_use_case.GetMasteryPolicyUseCase,
+	setMasteryPolicyUseCase *set_mastery_policy_use_case.SetMasteryPolicyUseCase,
+	getClassMasteryUseCase *get_class_mastery_use_case.GetClassMasteryUseCase,
+	getStudentMasteryUseCase *get_student_mastery_use_case.GetStudentMasteryUseCase,
+) *StandardsHandlers {
+	return &StandardsHandlers{
+		saveStandardUseCase:           saveStandardUseCase,
+		listStandardsUseCase:          listStandardsUseCase,
+		tagAssignmentStandardsUseCase: tagAssignmentStandardsUseCase,
+		getAssignmentStandardsUseCase: getAssignmentStandardsUseCase,
+		getMasteryPolicyUseCase:       getMasteryPolicyUseCase,
+		setMasteryPolicyUseCase:       setMasteryPolicyUseCase,
+		getClassMasteryUseCase:        getClassMasteryUseCase,
+		getStudentMasteryUseCase:      getStudentMasteryUseCase,
+	}
+}
+
+func (h *StandardsHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "save-standard",
+		Method:      http.MethodPost,
+		Path:        "/standards",
+		Summary:     "Add or update a standard",
+		Description: "Saving the code of a standard already in the framework updates it, assignments keep their tags. Whole frameworks are imported with the standards import.",
+		Tags:        []string{"Standards"},
+	}, h.SaveStandard)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-standards",
+		Method:      http.MethodGet,
+		Path:        "/standards",
+		Summary:     "List standards of the tenant",
+		Tags:        []string{"Standards"},
+	}, h.ListStandards)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "tag-assignment-standards",
+		Method:      http.MethodPut,
+		Path:        "/classes/{classId}/assignments/{assignmentId}/standards",
+		Summary:     "Tag an assignment with standards",
+		Description: "Replaces the standards of the assignment. Its released grades count toward the mastery of the new standards right away.",
+		Tags:        []string{"Standards"},
+	}, h.TagAssignmentStandards)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-assignment-standards",
+		Method:      http.MethodGet,
+		Path:        "/classes/{classId}/assignments/{assignmentId}/standards",
+		Summary:     "List the standards of an assignment",
+		Tags:        []string{"Standards"},
+	}, h.GetAssignmentStandards)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-mastery-policy",
+		Method:      http.MethodGet,
+		Path:        "/mastery-policy",
+		Summary:     "Get the mastery policy of the tenant",
+		Tags:        []string{"Standards"},
+	}, h.GetMasteryPolicy)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-mastery-policy",
+		Method:      http.MethodPut,
+		Path:        "/mastery-policy",
+		Summary:     "Set the mastery policy of the tenant",
+		Description: "Mastery is computed when it is read, every report follows the new policy from then on.",
+		Tags:        []string{"Standards"},
+	}, h.SetMasteryPolicy)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-class-mastery",
+		Method:      http.MethodGet,
+		Path:        "/classes/{classId}/mastery",
+		Summary:     "Get the mastery of the students of a class",
+		Description: "Assesses every student on the standards of the tagged assignments of the class, from their released grades. Dropped grades are left out.",
+		Tags:        []string{"Standards"},
+	}, h.GetClassMastery)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-student-mastery",
+		Method:      http.MethodGet,
+		Path:        "/students/{studentId}/mastery",
+		Summary:     "Get the mastery of a student",
+		Description: "Assesses the student on every standard of the tagged assignments they have released grades in, across their classes.",
+		Tags:        []string{"Standards"},
+	}, h.GetStudentMastery)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-my-mastery",
+		Method:      http.MethodGet,
+		Path:        "/mastery/me",
+		Summary:     "Get the mastery of the current student",
+		Tags:        []string{"Standards"},
+	}, h.GetMyMastery)
+}
+
+func (h *StandardsHandlers) SaveStandard(ctx context.Context, input *SaveStandardRequest) (*StandardEnvelope, error) {
+	command, err := save_standard_use_case.NewSaveStandardCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Body.Framework,
+		input.Body.Code,
+		input.Body.ParentCode,
+		input.Body.Subject,
+		input.Body.GradeLevel,
+		input.Body.Description,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	standard, err := h.saveStandardUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &StandardEnvelope{Body: NewStandardResponse(standard)}, nil
+}
+
+func (h *StandardsHandlers) ListStandards(ctx context.Context, input *ListStandardsRequest) (*ListStandardsResponse, error) {
+	command, err := list_standards_use_case.NewListStandardsCommand(authorization.TenantIDFromContext(ctx), input.Framework)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	standards, err := h.listStandardsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ListStandardsResponse{}
+	response.Body.Items = NewStandardResponses(standards)
+	return response, nil
+}
+
+func (h *StandardsHandlers) TagAssignmentStandards(ctx context.Context, input *TagAssignmentStandardsRequest) (*AssignmentStandardsResponse, error) {
+	command, err := tag_assignment_standards_use_case.NewTagAssignmentStandardsCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.ClassID,
+		input.AssignmentID,
+		input.Body.StandardIDs,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	standards, err := h.tagAssignmentStandardsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &AssignmentStandardsResponse{}
+	response.Body.Items = NewStandardResponses(standards)
+	return response, nil
+}
+
+func (h *StandardsHandlers) GetAssignmentStandards(ctx context.Context, input *GetAssignmentStandardsRequest) (*AssignmentStandardsResponse, error) {
+	command, err := get_assignment_standards_use_case.NewGetAssignmentStandardsCommand(authorization.TenantIDFromContext(ctx), input.ClassID,
+		input.AssignmentID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	standards, err := h.getAssignmentStandardsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &AssignmentStandardsResponse{}
+	response.Body.Items = NewStandardResponses(standards)
+	return response, nil
+}
+
+func (h *StandardsHandlers) GetMasteryPolicy(ctx context.Context, input *struct{}) (*MasteryPolicyEnvelope, error) {
+	command, err := get_mastery_policy_use_case.NewGetMasteryPolicyCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	policy, err := h.getMasteryPolicyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &MasteryPolicyEnvelope{Body: NewMasteryPolicyResponse(policy)}, nil
+}
+
+func (h *StandardsHandlers) SetMasteryPolicy(ctx context.Context, input *SetMasteryPolicyRequest) (*MasteryPolicyEnvelope, error) {
+	levels := make([]entities.MasteryLevel, 0, len(input.Body.Levels))
+	for _, level := range input.Body.Levels {
+		levels = append(levels, entities.MasteryLevel{Name: level.Name, MinScore: level.MinScore})
+	}
+
+	command, err := set_mastery_policy_use_case.NewSetMasteryPolicyCommand(
+		authorization.TenantIDFromContext(ctx),
+		entities.MasteryMethod(input.Body.Method),
+		input.Body.DecayWeight,
+		levels,
+		input.Body.MasteredLevel,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	policy, err := h.setMasteryPolicyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &MasteryPolicyEnvelope{Body: NewMasteryPolicyResponse(policy)}, nil
+}
+
+func (h *StandardsHandlers) GetClassMastery(ctx context.Context, input *GetClassMasteryRequest) (*MasteryReportResponse, error) {
+	command, err := get_class_mastery_use_case.NewGetClassMasteryCommand(authorization.TenantIDFromContext(ctx), input.ClassID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	report, err := h.getClassMasteryUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewMasteryReportResponse(report), nil
+}
+
+func (h *StandardsHandlers) GetStudentMastery(ctx context.Context, input *GetStudentMasteryRequest) (*MasteryReportResponse, error) {
+	return h.studentMastery(ctx, input.StudentID)
+}
+
+func (h *StandardsHandlers) GetMyMastery(ctx context.Context, input *struct{}) (*MasteryReportResponse, error) {
+	return h.studentMastery(ctx, authorization.UserIDFromContext(ctx))
+}
+
+func (h *StandardsHandlers) studentMastery(ctx context.Context, studentID string) (*MasteryReportResponse, error) {
+	command, err := get_student_mastery_use_case.NewGetStudentMasteryCommand(authorization.TenantIDFromContext(ctx), studentID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	report, err := h.getStudentMasteryUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewMasteryReportResponse(report), nil
+}
@@ -0,0 +1,69 @@
+-- name: UpsertStandard :one
+INSERT INTO standards (id, tenant_id, framework, code, parent_code, subject, grade_level, description, created_at, updated_at)
+VALUES (@id, @tenant_id, @framework, @code, @parent_code, @subject, @grade_level, @description, @created_at, @updated_at)
+ON CONFLICT (tenant_id, framework, code) DO UPDATE
+SET parent_code = EXCLUDED.parent_code,
+    subject = EXCLUDED.subject,
+    grade_level = EXCLUDED.grade_level,
+    description = EXCLUDED.description,
+    updated_at = EXCLUDED.updated_at
+RETURNING id, tenant_id, framework, code, parent_code, subject, grade_level, description, created_at, updated_at;
+
+-- name: ListStandardsByIDs :many
+SELECT id, tenant_id, framework, code, parent_code, subject, grade_level, description, created_at, updated_at
+FROM standards
+WHERE tenant_id = @tenant_id AND id = ANY(@ids::uuid[])
+ORDER BY framework, code;
+
+-- name: ListStandards :many
+SELECT id, tenant_id, framework, code, parent_code, subject, grade_level, description, created_at, updated_at
+FROM standards
+WHERE tenant_id = @tenant_id
+  AND (sqlc.narg('framework')::varchar IS NULL OR framework = sqlc.narg('framework'))
+ORDER BY framework, code;
+
+-- name: DeleteAssignmentStandards :exec
+DELETE FROM assignment_standards
+WHERE tenant_id = @tenant_id AND class_id = @class_id AND assignment_id = @assignment_id;
+
+-- name: InsertAssignmentStandard :exec
+INSERT INTO assignment_standards (tenant_id, class_id, assignment_id, standard_id, tagged_by, tagged_at)
+VALUES (@tenant_id, @class_id, @assignment_id, @standard_id, @tagged_by, @tagged_at);
+
+-- name: ListAssignmentStandards :many
+SELECT standard_id
+FROM assignment_standards
+WHERE tenant_id = @tenant_id AND class_id = @class_id AND assignment_id = @assignment_id
+ORDER BY standard_id;
+
+-- name: GetMasteryPolicy :one
+SELECT tenant_id, method, decay_weight, levels, mastered_level, updated_by, updated_at
+FROM mastery_policies
+WHERE tenant_id = @tenant_id;
+
+-- name: UpsertMasteryPolicy :exec
+INSERT INTO mastery_policies (tenant_id, method, decay_weight, levels, mastered_level, updated_by, updated_at)
+VALUES (@tenant_id, @method, @decay_weight, @levels, @mastered_level, @updated_by, @updated_at)
+ON CONFLICT (tenant_id) DO UPDATE
+SET method = EXCLUDED.method,
+    decay_weight = EXCLUDED.decay_weight,
+    levels = EXCLUDED.levels,
+    mastered_level = EXCLUDED.mastered_level,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- Released grades of the student on tagged assignments, once per standard of the assignment
+-- name: ListStudentMasteryEvidence :many
+SELECT s.standard_id::text AS standard_id, g.student_id, g.class_id::text AS class_id, g.assignment_id::text AS assignment_id, g.score, g.late_penalty_percent, g.released_at
+FROM grades g
+JOIN assignment_standards s
+  ON s.tenant_id = g.tenant_id AND s.class_id = g.class_id AND s.assignment_id = g.assignment_id
+WHERE g.tenant_id = @tenant_id AND g.student_id = @student_id AND g.status = 'released' AND NOT g.dropped;
+
+-- Released grades of the class on tagged assignments, once per standard of the assignment
+-- name: ListClassMasteryEvidence :many
+SELECT s.standard_id::text AS standard_id, g.student_id, g.class_id::text AS class_id, g.assignment_id::text AS assignment_id, g.score, g.late_penalty_percent, g.released_at
+FROM grades g
+JOIN assignment_standards s
+  ON s.tenant_id = g.tenant_id AND s.class_id = g.class_id AND s.assignment_id = g.assignment_id
+WHERE g.tenant_id = @tenant_id AND g.class_id = @class_id AND g.status = 'released' AND NOT g.dropped;
@@ -0,0 +1,37 @@
+-- Learning standards of a tenant grouped in frameworks, such as the state standards. Codes identify
+-- standards within their framework, imports of a new revision update the standards in place.
+CREATE TABLE standards (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    framework VARCHAR(100) NOT NULL,
+    code VARCHAR(100) NOT NULL,
+    parent_code VARCHAR(100),              -- broader standard of the same framework
+    subject VARCHAR(100),
+    grade_level VARCHAR(20),
+    description TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (tenant_id, framework, code)
+);
+
+-- Standards each assignment assesses, the released grades of the assignment are evidence of them
+CREATE TABLE assignment_standards (
+    tenant_id VARCHAR(255) NOT NULL,
+    class_id UUID NOT NULL,
+    assignment_id UUID NOT NULL,
+    standard_id UUID NOT NULL REFERENCES standards(id) ON DELETE CASCADE,
+    tagged_by VARCHAR(255) NOT NULL,
+    tagged_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, class_id, assignment_id, standard_id)
+);
+
+-- Mastery policies tenants set, tenants without a row use the default policy
+CREATE TABLE mastery_policies (
+    tenant_id VARCHAR(255) PRIMARY KEY,
+    method VARCHAR(20) NOT NULL,           -- mean, most_recent, highest, decaying_average
+    decay_weight DOUBLE PRECISION NOT NULL,
+    levels JSONB NOT NULL,                 -- name and min_score of every level, lowest first
+    mastered_level VARCHAR(50) NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,29 @@
+package adapters
+
+import (
+	"context"
+	"log"
+
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// EscalatedJobsCheck degrades the jobs while work of any tenant is escalated, the jobs gave up on it
+// until an admin requeues it. Failing to count leaves the jobs operational, the database check
+// reports the database.
+func EscalatedJobsCheck(pool *pgxpool.Pool) ComponentCheck {
+	queries := db.New(pool)
+	return func(ctx context.Context) entities.Level {
+		escalated, err := queries.CountEscalatedJobFailures(ctx)
+		if err != nil {
+			log.Printf("Failed to count escalated job failures: %v", err)
+			return entities.LevelOperational
+		}
+		if escalated > 0 {
+			return entities.LevelDegraded
+		}
+		return entities.LevelOperational
+	}
+}
@@ -0,0 +1,101 @@
+package adapters
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/slo"
+)
+
+// checkTimeout bounds the checks of a measure, a check still running counts as a degraded component
+const checkTimeout = 2 * time.Second
+
+// ComponentCheck measures one signal of a component and returns the level it puts the component at
+type ComponentCheck func(ctx context.Context) entities.Level
+
+// CheckedHealthProbe measures every component as the worst level of its checks. A measure is kept
+// for ttl, the status page is polled by every browser that has it open and the checks reach the
+// database.
+type CheckedHealthProbe struct {
+	ttl    time.Duration
+	checks map[entities.Component][]ComponentCheck
+
+	mu         sync.Mutex
+	levels     map[entities.Component]entities.Level
+	measuredAt time.Time
+}
+
+func NewCheckedHealthProbe(ttl time.Duration) *CheckedHealthProbe {
+	return &CheckedHealthProbe{
+		ttl:    ttl,
+		checks: make(map[entities.Component][]ComponentCheck),
+	}
+}
+
+// Add registers a check of the component, checks are added at startup before requests are served
+func (p *CheckedHealthProbe) Add(component entities.Component, check ComponentCheck) {
+	p.checks[component] = append(p.checks[component], check)
+}
+
+// Measure runs the checks concurrently, or returns the last measure while it is fresh. Requests
+// arriving during a measure wait for it rather than running the checks again.
+func (p *CheckedHealthProbe) Measure(ctx context.Context) map[entities.Component]entities.Level {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.levels != nil && time.Since(p.measuredAt) < p.ttl {
+		return maps.Clone(p.levels)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	levels := make(map[entities.Component]entities.Level, len(p.checks))
+	for component, checks := range p.checks {
+		levels[component] = entities.LevelOperational
+		for _, check := range checks {
+			wg.Add(1)
+			go func(component entities.Component, check ComponentCheck) {
+				defer wg.Done()
+
+				level := check(ctx)
+				if ctx.Err() != nil {
+					level = entities.Worst(level, entities.LevelDegraded)
+				}
+				mu.Lock()
+				levels[component] = entities.Worst(levels[component], level)
+				mu.Unlock()
+			}(component, check)
+		}
+	}
+	wg.Wait()
+
+	p.levels, p.measuredAt = levels, time.Now()
+	return maps.Clone(levels)
+}
+
+// FailingCheck puts the component at level while check fails, such as a ping of the database
+func FailingCheck(check func(ctx context.Context) error, level entities.Level) ComponentCheck {
+	return func(ctx context.Context) entities.Level {
+		if err := check(ctx); err != nil {
+			return level
+		}
+		return entities.LevelOperational
+	}
+}
+
+// SLOCheck degrades the component while a page alert of the service level objectives fires, the
+// errors or latency of the requests burn the error budget too fast
+func SLOCheck(report func() slo.Report) ComponentCheck {
+	return func(ctx context.Context) entities.Level {
+		if report().Status == slo.StatusBurning {
+			return entities.LevelDegraded
+		}
+		return entities.LevelOperational
+	}
+}
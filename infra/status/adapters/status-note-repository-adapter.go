@@ -0,0 +1,119 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/status/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresStatusNoteRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresStatusNoteRepository(dbInstance *pgxpool.Pool) ports.StatusNoteRepository {
+	return &PostgresStatusNoteRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresStatusNoteRepository) FindByID(ctx context.Context, noteID string) (*entities.StatusNote, error) {
+	id, err := pgUUID(noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.GetStatusNote(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toStatusNote(row), nil
+}
+
+func (r *PostgresStatusNoteRepository) List(ctx context.Context, includeResolved bool, limit int) ([]*entities.StatusNote, error) {
+	rows, err := r.queries.ListStatusNotes(ctx, db.ListStatusNotesParams{IncludeResolved: includeResolved, MaxNotes: int32(limit)})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	notes := make([]*entities.StatusNote, 0, len(rows))
+	for _, row := range rows {
+		notes = append(notes, toStatusNote(row))
+	}
+	return notes, nil
+}
+
+func (r *PostgresStatusNoteRepository) Save(ctx context.Context, note *entities.StatusNote) error {
+	id, err := pgUUID(note.ID)
+	if err != nil {
+		return err
+	}
+
+	components := make([]string, 0, len(note.Components))
+	for _, component := range note.Components {
+		components = append(components, string(component))
+	}
+
+	err = r.queries.SaveStatusNote(ctx, db.SaveStatusNoteParams{
+		ID:         id,
+		Title:      note.Title,
+		Message:    note.Message,
+		Components: components,
+		Level:      string(note.Level),
+		CreatedAt:  pgtype.Timestamptz{Time: note.CreatedAt, Valid: true},
+		UpdatedAt:  pgtype.Timestamptz{Time: note.UpdatedAt, Valid: true},
+		ResolvedAt: timestamptz(note.ResolvedAt),
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func toStatusNote(row db.StatusNote) *entities.StatusNote {
+	components := make([]entities.Component, 0, len(row.Components))
+	for _, component := range row.Components {
+		components = append(components, entities.Component(component))
+	}
+
+	note := &entities.StatusNote{
+		ID:         row.ID.String(),
+		Title:      row.Title,
+		Message:    row.Message,
+		Components: components,
+		Level:      entities.Level(row.Level),
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+	if row.ResolvedAt.Valid {
+		note.ResolvedAt = &row.ResolvedAt.Time
+	}
+	return note
+}
+
+func pgUUID(id string) (pgtype.UUID, error) {
+	var value pgtype.UUID
+	if err := value.Scan(id); err != nil {
+		return value, appErrors.PropagateError(err)
+	}
+	return value, nil
+}
+
+func timestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/status/domain/entities"
+)
+
+type StatusAdminRequest struct {
+	Authorization string `header:"Authorization" required:"true" doc:"Bearer followed by the status admin token of the platform"`
+}
+
+type StatusNoteRequest struct {
+	StatusAdminRequest
+	NoteID string `path:"noteId" format:"uuid"`
+}
+
+type StatusNoteBody struct {
+	Title      string   `json:"title" maxLength:"200"`
+	Message    string   `json:"message" maxLength:"5000" doc:"What schools notice and what is being done, shown on the status page"`
+	Components []string `json:"components" minItems:"1" uniqueItems:"true" doc:"api, database, jobs or integrations"`
+	Level      string   `json:"level" enum:"operational,degraded,partial_outage,major_outage" doc:"The components are shown at least at this level while the note is open"`
+}
+
+type PostStatusNoteRequest struct {
+	StatusAdminRequest
+	Body StatusNoteBody
+}
+
+type UpdateStatusNoteRequest struct {
+	StatusNoteRequest
+	Body StatusNoteBody
+}
+
+type ComponentStatusResponse struct {
+	Name  string `json:"name" enum:"api,database,jobs,integrations"`
+	Level string `json:"level" enum:"operational,degraded,partial_outage,major_outage"`
+}
+
+type StatusNoteResponse struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	Message    string     `json:"message"`
+	Components []string   `json:"components"`
+	Level      string     `json:"level" enum:"operational,degraded,partial_outage,major_outage"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+func NewStatusNoteResponse(note *entities.StatusNote) StatusNoteResponse {
+	response := StatusNoteResponse{
+		ID:         note.ID,
+		Title:      note.Title,
+		Message:    note.Message,
+		Components: make([]string, 0, len(note.Components)),
+		Level:      string(note.Level),
+		CreatedAt:  note.CreatedAt,
+		UpdatedAt:  note.UpdatedAt,
+		ResolvedAt: note.ResolvedAt,
+	}
+	for _, component := range note.Components {
+		response.Components = append(response.Components, string(component))
+	}
+	return response
+}
+
+type StatusResponse struct {
+	Body struct {
+		Level      string                    `json:"level" enum:"operational,degraded,partial_outage,major_outage" doc:"Worst level of the components"`
+		Components []ComponentStatusResponse `json:"components"`
+		Incidents  []StatusNoteResponse      `json:"incidents" doc:"Incident notes not resolved yet, the latest first"`
+		CheckedAt  time.Time                 `json:"checked_at"`
+	}
+}
+
+func NewStatusResponse(status *entities.Status) *StatusResponse {
+	response := &StatusResponse{}
+	response.Body.Level = string(status.Level)
+	response.Body.Components = make([]ComponentStatusResponse, 0, len(status.Components))
+	for _, component := range status.Components {
+		response.Body.Components = append(response.Body.Components, ComponentStatusResponse{
+			Name:  string(component.Component),
+			Level: string(component.Level),
+		})
+	}
+	response.Body.Incidents = make([]StatusNoteResponse, 0, len(status.Incidents))
+	for _, note := range status.Incidents {
+		response.Body.Incidents = append(response.Body.Incidents, NewStatusNoteResponse(note))
+	}
+	response.Body.CheckedAt = status.CheckedAt
+	return response
+}
+
+type StatusNoteEnvelope struct {
+	Body StatusNoteResponse
+}
+
+type ListStatusNotesResponse struct {
+	Body struct {
+		Items []StatusNoteResponse `json:"items"`
+	}
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	get_status_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/get-status-use-case"
+	list_status_notes_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/list-status-notes-use-case"
+	post_status_note_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/post-status-note-use-case"
+	resolve_status_note_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/resolve-status-note-use-case"
+	update_status_note_use_case "github.com/nahualventure/class-backend/core/app/status/application/use-cases/update-status-note-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type StatusHandlers struct {
+	getStatusUseCase         *get_status_use_case.GetStatusUseCase
+	listStatusNotesUseCase   *list_status_notes_use_case.ListStatusNotesUseCase
+	postStatusNoteUseCase    *post_status_note_use_case.PostStatusNoteUseCase
+	updateStatusNoteUseCase  *update_status_note_use_case.UpdateStatusNoteUseCase
+	resolveStatusNoteUseCase *resolve_status_note_use_case.ResolveStatusNoteUseCase
+	// limiter allows every client IP its own rate of status requests
+	limiter *ratelimit.TenantRateLimiter
+	// adminToken authorizes the requests managing the notes, which are not served without it
+	adminToken string
+}
+
+func NewStatusHandlers(
+	getStatusUseCase *get_status_use_case.GetStatusUseCase,
+	listStatusNotesUseCase *list_status_notes_use_case.ListStatusNotesUseCase,
+	postStatusNoteUseCase *post_status_note_use_case.PostStatusNoteUseCase,
+	updateStatusNoteUseCase *update_status_note_use_case.UpdateStatusNoteUseCase,
+	resolveStatusNoteUseCase *resolve_status_note_use_case.ResolveStatusNoteUseCase,
+	limiter *ratelimit.TenantRateLimiter,
+	adminToken string,
+) *StatusHandlers {
+	return &StatusHandlers{
+		getStatusUseCase:         getStatusUseCase,
+		listStatusNotesUseCase:   listStatusNotesUseCase,
+		postStatusNoteUseCase:    postStatusNoteUseCase,
+		updateStatusNoteUseCase:  updateStatusNoteUseCase,
+		resolveStatusNoteUseCase: resolveStatusNoteUseCase,
+		limiter:                  limiter,
+		adminToken:               adminToken,
+	}
+}
+
+func (h *StatusHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-status",
+		Method:      http.MethodGet,
+		Path:        "/status",
+		Summary:     "Status of the platform",
+		Description: "Public endpoint the status page of the schools polls, rate limited per client IP. Reports the level of the API, the database, the background jobs and the integrations, and the incidents going on.",
+		Tags:        []string{"Status"},
+	}, h.GetStatus)
+
+	if h.adminToken == "" {
+		return
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-status-notes",
+		Method:      http.MethodGet,
+		Path:        "/status/notes",
+		Summary:     "List the incident notes of the status page, resolved ones included",
+		Description: "Authorized by the status admin token of the platform.",
+		Tags:        []string{"Status"},
+	}, h.ListStatusNotes)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "post-status-note",
+		Method:        http.MethodPost,
+		Path:          "/status/notes",
+		Summary:       "Post an incident note on the status page",
+		Description:   "Authorized by the status admin token of the platform. The components named are shown at least at the level of the note until it is resolved.",
+		Tags:          []string{"Status"},
+		DefaultStatus: http.StatusCreated,
+	}, h.PostStatusNote)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-status-note",
+		Method:      http.MethodPut,
+		Path:        "/status/notes/{noteId}",
+		Summary:     "Update an open incident note",
+		Description: "Authorized by the status admin token of the platform.",
+		Tags:        []string{"Status"},
+	}, h.UpdateStatusNote)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "resolve-status-note",
+		Method:      http.MethodPost,
+		Path:        "/status/notes/{noteId}/resolve",
+		Summary:     "Resolve an incident note",
+		Description: "Authorized by the status admin token of the platform. The note leaves the status page and its components go back to the level measured.",
+		Tags:        []string{"Status"},
+	}, h.ResolveStatusNote)
+}
+
+func (h *StatusHandlers) GetStatus(ctx context.Context, _ *struct{}) (*StatusResponse, error) {
+	if allowed, retryAfter := h.limiter.Allow(utils.ClientIPFromContext(ctx)); !allowed {
+		return nil, huma.ErrorWithHeaders(
+			utils.ApplicationErrorToHumaError(appErrors.NewRateLimitedError("status", retryAfter)),
+			http.Header{"Retry-After": []string{strconv.Itoa(int(retryAfter.Seconds()) + 1)}},
+		)
+	}
+
+	status, err := h.getStatusUseCase.Execute(ctx)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewStatusResponse(status), nil
+}
+
+func (h *StatusHandlers) ListStatusNotes(ctx context.Context, input *StatusAdminRequest) (*ListStatusNotesResponse, error) {
+	if err := h.authorizeAdmin(input.Authorization); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	notes, err := h.listStatusNotesUseCase.Execute(ctx)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ListStatusNotesResponse{}
+	response.Body.Items = make([]StatusNoteResponse, 0, len(notes))
+	for _, note := range notes {
+		response.Body.Items = append(response.Body.Items, NewStatusNoteResponse(note))
+	}
+	return response, nil
+}
+
+func (h *StatusHandlers) PostStatusNote(ctx context.Context, input *PostStatusNoteRequest) (*StatusNoteEnvelope, error) {
+	if err := h.authorizeAdmin(input.Authorization); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	command, err := post_status_note_use_case.NewPostStatusNoteCommand(
+		input.Body.Title,
+		input.Body.Message,
+		input.Body.Components,
+		input.Body.Level,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	note, err := h.postStatusNoteUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &StatusNoteEnvelope{Body: NewStatusNoteResponse(note)}, nil
+}
+
+func (h *StatusHandlers) UpdateStatusNote(ctx context.Context, input *UpdateStatusNoteRequest) (*StatusNoteEnvelope, error) {
+	if err := h.authorizeAdmin(input.Authorization); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	command, err := update_status_note_use_case.NewUpdateStatusNoteCommand(
+		input.NoteID,
+		input.Body.Title,
+		input.Body.Message,
+		input.Body.Components,
+		input.Body.Level,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	note, err := h.updateStatusNoteUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &StatusNoteEnvelope{Body: NewStatusNoteResponse(note)}, nil
+}
+
+func (h *StatusHandlers) ResolveStatusNote(ctx context.Context, input *StatusNoteRequest) (*StatusNoteEnvelope, error) {
+	if err := h.authorizeAdmin(input.Authorization); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	command, err := resolve_status_note_use_case.NewResolveStatusNoteCommand(input.NoteID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	note, err := h.resolveStatusNoteUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &StatusNoteEnvelope{Body: NewStatusNoteResponse(note)}, nil
+}
+
+// authorizeAdmin checks the bearer token of a request managing the notes, the notes are about the
+// whole platform so no role of a tenant grants it
+func (h *StatusHandlers) authorizeAdmin(authorization string) error {
+	bearer, found := strings.CutPrefix(authorization, "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(bearer), []byte(h.adminToken)) != 1 {
+		return appErrors.NewUnauthorizedError("Invalid status admin token")
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+-- name: SaveStatusNote :exec
+INSERT INTO status_notes (id, title, message, components, level, created_at, updated_at, resolved_at)
+VALUES (@id, @title, @message, @components, @level, @created_at, @updated_at, @resolved_at)
+ON CONFLICT (id) DO UPDATE
+SET title = EXCLUDED.title,
+    message = EXCLUDED.message,
+    components = EXCLUDED.components,
+    level = EXCLUDED.level,
+    updated_at = EXCLUDED.updated_at,
+    resolved_at = EXCLUDED.resolved_at;
+
+-- name: GetStatusNote :one
+SELECT * FROM status_notes
+WHERE id = @id;
+
+-- name: ListStatusNotes :many
+SELECT * FROM status_notes
+WHERE @include_resolved::boolean OR resolved_at IS NULL
+ORDER BY created_at DESC, id
+LIMIT @max_notes;
+
+-- name: CountEscalatedJobFailures :one
+-- Work every tenant's jobs gave up on, the jobs component of the status page is degraded while any
+SELECT COUNT(*) FROM job_failures
+WHERE status = 'escalated';
@@ -0,0 +1,14 @@
+-- Incident notes of the public status page, posted by the on-call engineers. They are about the
+-- platform, not a tenant. Open notes put the components they name at least at their level.
+CREATE TABLE status_notes (
+    id UUID PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    message TEXT NOT NULL,
+    components TEXT[] NOT NULL,              -- api, database, jobs, integrations
+    level VARCHAR(20) NOT NULL,              -- operational, degraded, partial_outage, major_outage
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE     -- open while null
+);
+
+CREATE INDEX idx_status_notes_created ON status_notes(created_at);
@@ -0,0 +1,63 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/storage"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/ports"
+)
+
+// storedPrefixes are the directories of the file storage holding the uploads of a tenant, library
+// versions and message attachments
+var storedPrefixes = []string{"library", "messages"}
+
+// FilesystemStorageInventory lists the directory of the filesystem file storage
+type FilesystemStorageInventory struct {
+	root string
+}
+
+func NewFilesystemStorageInventory(root string) (ports.StorageInventory, error) {
+	absolute, err := filepath.Abs(root)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &FilesystemStorageInventory{root: absolute}, nil
+}
+
+// Stored walks the uploads of the tenant. Previews are generated from the uploads and the temporary
+// files of writes in progress are not stored yet, neither is counted.
+func (i *FilesystemStorageInventory) Stored(ctx context.Context, tenantID string) (int64, int64, error) {
+	var bytes, files int64
+	for _, prefix := range storedPrefixes {
+		dir := filepath.Join(i.root, prefix, filepath.FromSlash(tenantID))
+		err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") || strings.HasSuffix(path, storage.PreviewKey("")) {
+				return nil
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			bytes += info.Size()
+			files++
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return 0, 0, appErrors.NewInfrastructureError("failed to list the file storage", err)
+		}
+	}
+	return bytes, files, nil
+}
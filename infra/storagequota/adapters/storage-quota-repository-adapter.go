@@ -0,0 +1,89 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresStorageQuotaRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresStorageQuotaRepository(dbInstance *pgxpool.Pool) ports.StorageQuotaRepository {
+	return &PostgresStorageQuotaRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresStorageQuotaRepository) Find(ctx context.Context, tenantID string) (*entities.StorageQuota, error) {
+	row, err := r.queries.GetStorageQuota(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return entities.NewStorageQuota(row.TenantID, row.TenantBytes, row.UserBytes, row.UpdatedBy, row.UpdatedAt.Time)
+}
+
+func (r *PostgresStorageQuotaRepository) Save(ctx context.Context, quota *entities.StorageQuota) error {
+	updatedAt := pgtype.Timestamptz{Time: quota.UpdatedAt, Valid: true}
+
+	payload, err := json.Marshal(map[string]any{
+		"tenant_bytes": quota.TenantBytes,
+		"user_bytes":   quota.UserBytes,
+		"updated_by":   quota.UpdatedBy,
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize storage quota event", err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.UpsertStorageQuota(ctx, db.UpsertStorageQuotaParams{
+		TenantID:    quota.TenantID,
+		TenantBytes: quota.TenantBytes,
+		UserBytes:   quota.UserBytes,
+		UpdatedBy:   quota.UpdatedBy,
+		UpdatedAt:   updatedAt,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.StorageQuotaSet,
+		AggregateID: quota.TenantID,
+		TenantID:    quota.TenantID,
+		Payload:     payload,
+		OccurredAt:  updatedAt,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
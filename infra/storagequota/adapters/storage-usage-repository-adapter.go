@@ -0,0 +1,168 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStorageUsageRepository reads the storage_usage ledger. The library and messaging adapters
+// add to it as they record uploads, the scanning adapter as it rejects them.
+type PostgresStorageUsageRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresStorageUsageRepository(dbInstance *pgxpool.Pool) ports.StorageUsageRepository {
+	return &PostgresStorageUsageRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresStorageUsageRepository) Usage(ctx context.Context, tenantID string, ownerID string) (*entities.StorageUsage, *entities.StorageUsage, error) {
+	row, err := r.queries.GetStorageUsageTotals(ctx, db.GetStorageUsageTotalsParams{OwnerID: ownerID, TenantID: tenantID})
+	if err != nil {
+		return nil, nil, appErrors.PropagateError(err)
+	}
+
+	tenant := &entities.StorageUsage{
+		TenantID:  tenantID,
+		Bytes:     row.TenantBytes,
+		Files:     row.TenantFiles,
+		UpdatedAt: row.TenantUpdatedAt.Time,
+	}
+	owner := &entities.StorageUsage{
+		TenantID:  tenantID,
+		OwnerID:   ownerID,
+		Bytes:     row.OwnerBytes,
+		Files:     row.OwnerFiles,
+		UpdatedAt: row.OwnerUpdatedAt.Time,
+	}
+	return tenant, owner, nil
+}
+
+func (r *PostgresStorageUsageRepository) List(ctx context.Context, tenantID string,
+	page *pagination.PageRequest) (*pagination.Page[*entities.StorageUsage], error) {
+	params := db.ListOwnerStorageUsageParams{
+		TenantID:  tenantID,
+		PageLimit: int32(page.PageSize + 1),
+	}
+	if page.After != nil {
+		if len(page.After) != 2 {
+			return nil, appErrors.NewInfrastructureError("cursor keys do not match the order", nil)
+		}
+		afterBytes, err := strconv.ParseInt(page.After[0], 10, 64)
+		if err != nil {
+			return nil, appErrors.NewInfrastructureError("invalid cursor bytes key", err)
+		}
+		params.AfterBytes, params.AfterOwnerID = &afterBytes, &page.After[1]
+	}
+
+	rows, err := r.queries.ListOwnerStorageUsage(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	usages := make([]*entities.StorageUsage, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		usages = append(usages, &entities.StorageUsage{
+			TenantID:  row.TenantID,
+			OwnerID:   row.OwnerID,
+			Bytes:     row.Bytes,
+			Files:     row.Files,
+			UpdatedAt: row.UpdatedAt.Time,
+		})
+	}
+
+	return pagination.NewPage(page, usages, total, func(usage *entities.StorageUsage) []string {
+		return []string{strconv.FormatInt(usage.Bytes, 10), usage.OwnerID}
+	}), nil
+}
+
+func (r *PostgresStorageUsageRepository) ListTenants(ctx context.Context) ([]string, error) {
+	tenants, err := r.queries.ListStorageTenants(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return tenants, nil
+}
+
+// Reconcile locks the ledger against writes until it commits, uploads recorded meanwhile add to the
+// rewritten usage instead of being counted twice or lost
+func (r *PostgresStorageUsageRepository) Reconcile(ctx context.Context, tenantID string, now time.Time) (*entities.StorageUsage, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "LOCK TABLE storage_usage IN EXCLUSIVE MODE"); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	qtx := r.queries.WithTx(tx)
+	if err := qtx.DeleteTenantStorageUsage(ctx, tenantID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	err = qtx.ReconcileTenantStorageUsage(ctx, db.ReconcileTenantStorageUsageParams{
+		TenantID:  tenantID,
+		UpdatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	row, err := qtx.GetStorageUsageTotals(ctx, db.GetStorageUsageTotalsParams{TenantID: tenantID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &entities.StorageUsage{
+		TenantID:  tenantID,
+		Bytes:     row.TenantBytes,
+		Files:     row.TenantFiles,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (r *PostgresStorageUsageRepository) RecordDrift(ctx context.Context, drift *entities.StorageDrift) error {
+	payload, err := json.Marshal(map[string]any{
+		"recorded_bytes": drift.RecordedBytes,
+		"recorded_files": drift.RecordedFiles,
+		"stored_bytes":   drift.StoredBytes,
+		"stored_files":   drift.StoredFiles,
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize storage drift event", err)
+	}
+
+	err = r.queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.StorageDriftDetected,
+		AggregateID: drift.TenantID,
+		TenantID:    drift.TenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: drift.CheckedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
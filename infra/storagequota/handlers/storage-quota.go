@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"strconv"
+
+	check_storage_quota_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/check-storage-quota-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// UploadOperations are the Huma operation IDs that store files. Files are sent base64 encoded in
+// JSON bodies, an upload stores about three quarters of the size of its request.
+var UploadOperations = map[string]bool{
+	"upload-resource-version": true,
+	"send-direct-message":     true,
+	"post-class-message":      true,
+}
+
+// NewStorageQuotaMiddleware returns a Huma middleware that refuses uploads taking the user or the
+// tenant past their storage quota before the file is read. Like the plan limits, it runs after the
+// authorization middleware.
+func NewStorageQuotaMiddleware(checkStorageQuotaUseCase *check_storage_quota_use_case.CheckStorageQuotaUseCase) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		tenantID := authorization.TenantIDFromContext(ctx.Context())
+		if tenantID == "" || !UploadOperations[ctx.Operation().OperationID] {
+			next(ctx)
+			return
+		}
+
+		length, _ := strconv.ParseInt(ctx.Header("Content-Length"), 10, 64)
+		command, err := check_storage_quota_use_case.NewCheckStorageQuotaCommand(tenantID,
+			authorization.UserIDFromContext(ctx.Context()), max(length, 0)*3/4)
+		if err == nil {
+			err = checkStorageQuotaUseCase.Execute(ctx.Context(), command)
+		}
+		if err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+
+		next(ctx)
+	}
+}
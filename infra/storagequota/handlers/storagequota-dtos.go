@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/storagequota/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type StorageQuotaResponse struct {
+	TenantBytes int64      `json:"tenant_bytes" doc:"Bytes the files of the tenant may take, 0 is no limit"`
+	UserBytes   int64      `json:"user_bytes" doc:"Bytes the files each user uploaded may take, 0 is no limit"`
+	UpdatedBy   string     `json:"updated_by,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty" doc:"Absent when the tenant never set a quota"`
+}
+
+func NewStorageQuotaResponse(quota *entities.StorageQuota) StorageQuotaResponse {
+	response := StorageQuotaResponse{
+		TenantBytes: quota.TenantBytes,
+		UserBytes:   quota.UserBytes,
+		UpdatedBy:   quota.UpdatedBy,
+	}
+	if !quota.UpdatedAt.IsZero() {
+		response.UpdatedAt = &quota.UpdatedAt
+	}
+	return response
+}
+
+type StorageUsageResponse struct {
+	OwnerID   string     `json:"owner_id,omitempty" doc:"User who uploaded the files, absent for the whole tenant"`
+	Bytes     int64      `json:"bytes"`
+	Files     int64      `json:"files"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" doc:"Absent when nothing was ever stored"`
+}
+
+func NewStorageUsageResponse(usage *entities.StorageUsage) StorageUsageResponse {
+	response := StorageUsageResponse{
+		OwnerID: usage.OwnerID,
+		Bytes:   usage.Bytes,
+		Files:   usage.Files,
+	}
+	if !usage.UpdatedAt.IsZero() {
+		response.UpdatedAt = &usage.UpdatedAt
+	}
+	return response
+}
+
+type MyStorageUsageResponse struct {
+	Usage StorageUsageResponse `json:"usage"`
+	Quota StorageQuotaResponse `json:"quota"`
+}
+
+type MyStorageUsageEnvelope struct {
+	Body MyStorageUsageResponse
+}
+
+func NewMyStorageUsageEnvelope(usage *entities.StorageUsage, quota *entities.StorageQuota) *MyStorageUsageEnvelope {
+	return &MyStorageUsageEnvelope{Body: MyStorageUsageResponse{
+		Usage: NewStorageUsageResponse(usage),
+		Quota: NewStorageQuotaResponse(quota),
+	}}
+}
+
+type GetStorageUsageRequest struct {
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of users to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as owners.next_cursor by the previous page"`
+}
+
+type StorageUsageReportResponse struct {
+	Usage  StorageUsageResponse                         `json:"usage"`
+	Quota  StorageQuotaResponse                         `json:"quota"`
+	Owners utils.ListResponseBody[StorageUsageResponse] `json:"owners" doc:"Usage of each user, largest first"`
+}
+
+type StorageUsageReportEnvelope struct {
+	Body StorageUsageReportResponse
+}
+
+func NewStorageUsageReportEnvelope(report *entities.StorageUsageReport) *StorageUsageReportEnvelope {
+	return &StorageUsageReportEnvelope{Body: StorageUsageReportResponse{
+		Usage:  NewStorageUsageResponse(report.Tenant),
+		Quota:  NewStorageQuotaResponse(report.Quota),
+		Owners: utils.NewListResponseBody(report.Owners, NewStorageUsageResponse),
+	}}
+}
+
+type SetStorageQuotaRequest struct {
+	Body struct {
+		TenantBytes int64 `json:"tenant_bytes" minimum:"0" doc:"Bytes the files of the tenant may take, 0 is no limit"`
+		UserBytes   int64 `json:"user_bytes" minimum:"0" doc:"Bytes the files each user uploaded may take, 0 is no limit"`
+	}
+}
+
+type StorageQuotaEnvelope struct {
+	Body StorageQuotaResponse
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_storage_usage_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/get-storage-usage-use-case"
+	list_storage_usage_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/list-storage-usage-use-case"
+	set_storage_quota_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/set-storage-quota-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type StorageQuotaHandlers struct {
+	getStorageUsageUseCase  *get_storage_usage_use_case.GetStorageUsageUseCase
+	listStorageUsageUseCase *list_storage_usage_use_case.ListStorageUsageUseCase
+	setStorageQuotaUseCase  *set_storage_quota_use_case.SetStorageQuotaUseCase
+}
+
+func NewStorageQuotaHandlers(
+	getStorageUsageUseCase *get_storage_usage_use_case.GetStorageUsageUseCase,
+	listStorageUsageUseCase *list_storage_usage_use_case.ListStorageUsageUseCase,
+	setStorageQuotaUseCase *set_storage_quota_use_case.SetStorageQuotaUseCase,
+) *StorageQuotaHandlers {
+	return &StorageQuotaHandlers{
+		getStorageUsageUseCase:  getStorageUsageUseCase,
+		listStorageUsageUseCase: listStorageUsageUseCase,
+		setStorageQuotaUseCase:  setStorageQuotaUseCase,
+	}
+}
+
+func (h *StorageQuotaHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-my-storage-usage",
+		Method:      http.MethodGet,
+		Path:        "/storage/usage/me",
+		Summary:     "Get the bytes stored by the current user and the storage quota",
+		Tags:        []string{"Storage"},
+	}, h.GetMyStorageUsage)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-storage-usage",
+		Method:      http.MethodGet,
+		Path:        "/storage/usage",
+		Summary:     "Get the bytes stored by the tenant and by each of its users",
+		Tags:        []string{"Storage"},
+	}, h.GetStorageUsage)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-storage-quota",
+		Method:      http.MethodPut,
+		Path:        "/storage/quota",
+		Summary:     "Set the storage quota of the tenant and of each of its users",
+		Description: "Files already stored over a lowered quota are kept, new uploads are refused.",
+		Tags:        []string{"Storage"},
+	}, h.SetStorageQuota)
+}
+
+func (h *StorageQuotaHandlers) GetMyStorageUsage(ctx context.Context, input *struct{}) (*MyStorageUsageEnvelope, error) {
+	command, err := get_storage_usage_use_case.NewGetStorageUsageCommand(authorization.TenantIDFromContext(ctx), authorization.UserIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	usage, quota, err := h.getStorageUsageUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewMyStorageUsageEnvelope(usage, quota), nil
+}
+
+func (h *StorageQuotaHandlers) GetStorageUsage(ctx context.Context, input *GetStorageUsageRequest) (*StorageUsageReportEnvelope, error) {
+	command, err := list_storage_usage_use_case.NewListStorageUsageCommand(authorization.TenantIDFromContext(ctx), input.PageSize, input.Cursor)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	report, err := h.listStorageUsageUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewStorageUsageReportEnvelope(report), nil
+}
+
+func (h *StorageQuotaHandlers) SetStorageQuota(ctx context.Context, input *SetStorageQuotaRequest) (*StorageQuotaEnvelope, error) {
+	command, err := set_storage_quota_use_case.NewSetStorageQuotaCommand(authorization.TenantIDFromContext(ctx),
+		input.Body.TenantBytes, input.Body.UserBytes, authorization.UserIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	quota, err := h.setStorageQuotaUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &StorageQuotaEnvelope{Body: NewStorageQuotaResponse(quota)}, nil
+}
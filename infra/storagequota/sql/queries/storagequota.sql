@@ -0,0 +1,74 @@
+-- name: AddStorageUsage :exec
+INSERT INTO storage_usage (tenant_id, owner_id, bytes, files, updated_at)
+VALUES (@tenant_id, @owner_id, GREATEST(@bytes::bigint, 0), GREATEST(@files::bigint, 0), @updated_at)
+ON CONFLICT (tenant_id, owner_id) DO UPDATE
+SET bytes = GREATEST(storage_usage.bytes + EXCLUDED.bytes, 0),
+    files = GREATEST(storage_usage.files + EXCLUDED.files, 0),
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetStorageUsageTotals :one
+SELECT COALESCE(SUM(bytes), 0)::BIGINT AS tenant_bytes,
+       COALESCE(SUM(files), 0)::BIGINT AS tenant_files,
+       MAX(updated_at)::timestamptz AS tenant_updated_at,
+       COALESCE(SUM(bytes) FILTER (WHERE owner_id = @owner_id), 0)::BIGINT AS owner_bytes,
+       COALESCE(SUM(files) FILTER (WHERE owner_id = @owner_id), 0)::BIGINT AS owner_files,
+       MAX(updated_at) FILTER (WHERE owner_id = @owner_id)::timestamptz AS owner_updated_at
+FROM storage_usage
+WHERE tenant_id = @tenant_id;
+
+-- name: ListOwnerStorageUsage :many
+SELECT tenant_id, owner_id, bytes, files, updated_at,
+       (SELECT count(*) FROM storage_usage t WHERE t.tenant_id = @tenant_id) AS total
+FROM storage_usage
+WHERE tenant_id = @tenant_id
+  AND (sqlc.narg('after_bytes')::bigint IS NULL
+       OR (bytes, owner_id) < (sqlc.narg('after_bytes')::bigint, sqlc.narg('after_owner_id')::varchar))
+ORDER BY bytes DESC, owner_id DESC
+LIMIT @page_limit;
+
+-- name: ListStorageTenants :many
+SELECT tenant_id FROM storage_usage
+UNION
+SELECT tenant_id FROM resource_versions
+UNION
+SELECT tenant_id FROM messages
+ORDER BY tenant_id;
+
+-- name: DeleteTenantStorageUsage :exec
+DELETE FROM storage_usage WHERE tenant_id = @tenant_id;
+
+-- ReconcileTenantStorageUsage counts the files of the tenant that are kept in the file storage, the
+-- files of archived messages included. Infected files were deleted and missing ones are gone.
+-- name: ReconcileTenantStorageUsage :exec
+INSERT INTO storage_usage (tenant_id, owner_id, bytes, files, updated_at)
+SELECT @tenant_id, owner_id, SUM(bytes)::BIGINT, count(*), @updated_at
+FROM (
+    SELECT v.uploaded_by AS owner_id, v.size AS bytes
+    FROM resource_versions v
+    WHERE v.tenant_id = @tenant_id AND v.scan_status NOT IN ('infected', 'missing')
+    UNION ALL
+    SELECT m.sender_id, a.size_bytes
+    FROM message_attachments a
+    JOIN messages m ON m.id = a.message_id
+    WHERE m.tenant_id = @tenant_id AND a.scan_status NOT IN ('infected', 'missing')
+    UNION ALL
+    SELECT r.payload->>'sender_id', (att->>'size_bytes')::bigint
+    FROM archived_records r, jsonb_array_elements(r.payload->'attachments') att
+    WHERE r.kind = 'messages' AND r.tenant_id = @tenant_id
+      AND COALESCE(att->>'scan_status', 'clean') NOT IN ('infected', 'missing')
+) files
+GROUP BY owner_id;
+
+-- name: GetStorageQuota :one
+SELECT tenant_id, tenant_bytes, user_bytes, updated_by, updated_at
+FROM storage_quotas
+WHERE tenant_id = @tenant_id;
+
+-- name: UpsertStorageQuota :exec
+INSERT INTO storage_quotas (tenant_id, tenant_bytes, user_bytes, updated_by, updated_at)
+VALUES (@tenant_id, @tenant_bytes, @user_bytes, @updated_by, @updated_at)
+ON CONFLICT (tenant_id) DO UPDATE
+SET tenant_bytes = EXCLUDED.tenant_bytes,
+    user_bytes = EXCLUDED.user_bytes,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
@@ -0,0 +1,22 @@
+-- Bytes stored by each owner of a tenant, the user who uploaded the files. Uploads add to it in the
+-- transaction that records them and files deleted by the virus scan take their bytes back, the
+-- nightly reconciliation rewrites it from the records of the files.
+CREATE TABLE storage_usage (
+    tenant_id VARCHAR(255) NOT NULL,
+    owner_id VARCHAR(255) NOT NULL,
+    bytes BIGINT NOT NULL,
+    files BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, owner_id)
+);
+
+CREATE INDEX idx_storage_usage_bytes ON storage_usage(tenant_id, bytes DESC, owner_id DESC);
+
+-- Storage quota of a tenant, zero is no limit. Tenants without a row are not limited.
+CREATE TABLE storage_quotas (
+    tenant_id VARCHAR(255) PRIMARY KEY,
+    tenant_bytes BIGINT NOT NULL,
+    user_bytes BIGINT NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,30 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	reconcile_storage_usage_use_case "github.com/nahualventure/class-backend/core/app/storagequota/application/use-cases/reconcile-storage-usage-use-case"
+)
+
+// RunStorageReconciliation reconciles the storage usage of every tenant every interval, it returns
+// when ctx is cancelled
+func RunStorageReconciliation(ctx context.Context, useCase *reconcile_storage_usage_use_case.ReconcileStorageUsageUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if drifted, err := useCase.Execute(ctx); err != nil {
+			log.Printf("Failed to reconcile storage usage: %v", err)
+		} else if drifted > 0 {
+			log.Printf("Storage of %d tenants drifted from their recorded files", drifted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
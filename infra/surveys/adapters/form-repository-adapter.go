@@ -0,0 +1,286 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// questionRecord is the stored shape of a question in the questions column
+type questionRecord struct {
+	ID       string         `json:"id"`
+	Prompt   string         `json:"prompt"`
+	Type     string         `json:"type"`
+	Required bool           `json:"required"`
+	Options  []string       `json:"options,omitempty"`
+	Branches []branchRecord `json:"branches,omitempty"`
+}
+
+type branchRecord struct {
+	Option string `json:"option"`
+	GoTo   string `json:"go_to,omitempty"`
+}
+
+type PostgresFormRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresFormRepository(dbInstance *pgxpool.Pool) ports.FormRepository {
+	return &PostgresFormRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresFormRepository) Create(ctx context.Context, form *entities.Form) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(form.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	questions, err := marshalQuestions(form.Questions)
+	if err != nil {
+		return err
+	}
+
+	err = r.queries.CreateForm(ctx, db.CreateFormParams{
+		ID:               pgID,
+		TenantID:         form.TenantID,
+		Title:            form.Title,
+		Description:      form.Description,
+		Anonymous:        form.Anonymous,
+		Status:           string(form.Status),
+		Questions:        questions,
+		AudienceRoles:    emptyIfNil(form.Audience.Roles),
+		AudienceClassIds: emptyIfNil(form.Audience.ClassIDs),
+		CreatedBy:        form.CreatedBy,
+		ClosesAt:         timestamptz(form.ClosesAt),
+		CreatedAt:        pgtype.Timestamptz{Time: form.CreatedAt, Valid: true},
+		UpdatedAt:        pgtype.Timestamptz{Time: form.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresFormRepository) Update(ctx context.Context, form *entities.Form) error {
+	return r.update(ctx, r.queries, form)
+}
+
+// Open writes the form and one distribution event per recipient, notification senders pick the
+// events up from the outbox
+func (r *PostgresFormRepository) Open(ctx context.Context, form *entities.Form, recipientIDs []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	if err := r.update(ctx, qtx, form); err != nil {
+		return err
+	}
+
+	for _, recipientID := range recipientIDs {
+		event := map[string]any{
+			"form_id":      form.ID,
+			"recipient_id": recipientID,
+			"title":        form.Title,
+		}
+		if form.ClosesAt != nil {
+			event["closes_at"] = form.ClosesAt.Format(time.RFC3339)
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return appErrors.NewInfrastructureError("failed to serialize form distribution event", err)
+		}
+
+		err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+			EventType:   events.FormDistributed,
+			AggregateID: form.ID,
+			TenantID:    form.TenantID,
+			Payload:     payload,
+			OccurredAt:  pgtype.Timestamptz{Time: *form.OpenedAt, Valid: true},
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresFormRepository) update(ctx context.Context, queries *db.Queries, form *entities.Form) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(form.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	questions, err := marshalQuestions(form.Questions)
+	if err != nil {
+		return err
+	}
+
+	updated, err := queries.UpdateForm(ctx, db.UpdateFormParams{
+		Title:            form.Title,
+		Description:      form.Description,
+		Anonymous:        form.Anonymous,
+		Status:           string(form.Status),
+		Questions:        questions,
+		AudienceRoles:    emptyIfNil(form.Audience.Roles),
+		AudienceClassIds: emptyIfNil(form.Audience.ClassIDs),
+		ClosesAt:         timestamptz(form.ClosesAt),
+		OpenedAt:         timestamptz(form.OpenedAt),
+		ClosedAt:         timestamptz(form.ClosedAt),
+		UpdatedAt:        pgtype.Timestamptz{Time: form.UpdatedAt, Valid: true},
+		ID:               pgID,
+		TenantID:         form.TenantID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if updated == 0 {
+		return surveysErrors.NewFormNotFoundError(form.ID)
+	}
+
+	return nil
+}
+
+func (r *PostgresFormRepository) FindByID(ctx context.Context, tenantID string, formID string) (*entities.Form, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(formID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetForm(ctx, db.GetFormParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toForm(row)
+}
+
+func (r *PostgresFormRepository) List(ctx context.Context, tenantID string, filter ports.FormFilter) ([]*entities.Form, error) {
+	rows, err := r.queries.ListForms(ctx, db.ListFormsParams{
+		TenantID:  tenantID,
+		Status:    emptyToNil(string(filter.Status)),
+		CreatedBy: emptyToNil(filter.CreatedBy),
+		RowLimit:  int32(filter.Limit),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	forms := make([]*entities.Form, 0, len(rows))
+	for _, row := range rows {
+		form, err := toForm(row)
+		if err != nil {
+			return nil, err
+		}
+		forms = append(forms, form)
+	}
+
+	return forms, nil
+}
+
+func marshalQuestions(questions []*entities.Question) ([]byte, error) {
+	records := make([]questionRecord, 0, len(questions))
+	for _, question := range questions {
+		record := questionRecord{
+			ID:       question.ID,
+			Prompt:   question.Prompt,
+			Type:     string(question.Type),
+			Required: question.Required,
+			Options:  question.Options,
+		}
+		for _, branch := range question.Branches {
+			record.Branches = append(record.Branches, branchRecord{Option: branch.Option, GoTo: branch.GoTo})
+		}
+		records = append(records, record)
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to serialize form questions", err)
+	}
+	return data, nil
+}
+
+func toForm(row db.Form) (*entities.Form, error) {
+	var records []questionRecord
+	if err := json.Unmarshal(row.Questions, &records); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to read form questions", err)
+	}
+
+	questions := make([]*entities.Question, 0, len(records))
+	for _, record := range records {
+		question := &entities.Question{
+			ID:       record.ID,
+			Prompt:   record.Prompt,
+			Type:     entities.QuestionType(record.Type),
+			Required: record.Required,
+			Options:  record.Options,
+		}
+		for _, branch := range record.Branches {
+			question.Branches = append(question.Branches, entities.Branch{Option: branch.Option, GoTo: branch.GoTo})
+		}
+		questions = append(questions, question)
+	}
+
+	form, err := entities.NewForm(row.ID.String(), row.TenantID, row.Title, row.Description, row.Anonymous, entities.FormStatus(row.Status),
+		questions, entities.Audience{Roles: row.AudienceRoles, ClassIDs: row.AudienceClassIds}, row.CreatedBy, timeOrNil(row.ClosesAt),
+		timeOrNil(row.OpenedAt), timeOrNil(row.ClosedAt), row.CreatedAt.Time, row.UpdatedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return form, nil
+}
+
+func timestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
+
+func timeOrNil(value pgtype.Timestamptz) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func emptyIfNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,78 @@
+package adapters
+
+import (
+	"context"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresParticipantDirectory reads roles and the survey:manage and survey:manage_all permissions
+// from casbin, and class membership from enrollments and guardian links
+type PostgresParticipantDirectory struct {
+	queries       *db.Queries
+	casbinService *authorization.CasbinService
+}
+
+func NewPostgresParticipantDirectory(dbInstance *pgxpool.Pool, casbinService *authorization.CasbinService) ports.ParticipantDirectory {
+	return &PostgresParticipantDirectory{
+		queries:       db.New(database.Reads.Wrap(dbInstance)),
+		casbinService: casbinService,
+	}
+}
+
+func (d *PostgresParticipantDirectory) Participant(ctx context.Context, tenantID string, userID string) (entities.Participant, error) {
+	roles, authzErr := d.casbinService.GetUserRoles(userID, tenantID)
+	if authzErr != nil {
+		return entities.Participant{}, authzErr
+	}
+	manage, authzErr := d.casbinService.CanDo(userID, "survey", "manage", tenantID)
+	if authzErr != nil {
+		return entities.Participant{}, authzErr
+	}
+	manageAll, authzErr := d.casbinService.CanDo(userID, "survey", "manage_all", tenantID)
+	if authzErr != nil {
+		return entities.Participant{}, authzErr
+	}
+
+	enrolled, err := d.queries.ListStudentClassIDs(ctx, db.ListStudentClassIDsParams{TenantID: tenantID, UserID: userID})
+	if err != nil {
+		return entities.Participant{}, appErrors.PropagateError(err)
+	}
+	children, err := d.queries.ListGuardianClassIDs(ctx, db.ListGuardianClassIDsParams{TenantID: tenantID, GuardianID: userID})
+	if err != nil {
+		return entities.Participant{}, appErrors.PropagateError(err)
+	}
+
+	classIDs := make([]string, 0, len(enrolled)+len(children))
+	for _, classID := range append(enrolled, children...) {
+		classIDs = append(classIDs, classID.String())
+	}
+
+	return entities.Participant{
+		UserID:       userID,
+		Roles:        roles,
+		ClassIDs:     classIDs,
+		CanManage:    manage,
+		CanManageAll: manageAll,
+	}, nil
+}
+
+func (d *PostgresParticipantDirectory) Members(ctx context.Context, tenantID string, audience entities.Audience) ([]string, error) {
+	members, err := d.queries.ListAudienceMembers(ctx, db.ListAudienceMembersParams{
+		TenantID: tenantID,
+		Roles:    emptyIfNil(audience.Roles),
+		ClassIds: emptyIfNil(audience.ClassIDs),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return members, nil
+}
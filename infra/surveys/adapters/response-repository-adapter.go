@@ -0,0 +1,164 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	surveysErrors "github.com/nahualventure/class-backend/core/app/surveys/domain/errors"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// answerRecord is the stored shape of an answer in the answers column
+type answerRecord struct {
+	QuestionID string   `json:"question_id"`
+	Values     []string `json:"values"`
+}
+
+type PostgresResponseRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresResponseRepository(dbInstance *pgxpool.Pool) ports.ResponseRepository {
+	return &PostgresResponseRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+// Submit records the participation first, its primary key rejects a second response of the user
+// even when two arrive at once
+func (r *PostgresResponseRepository) Submit(ctx context.Context, response *entities.Response, userID string) error {
+	var pgID, pgFormID pgtype.UUID
+	if err := pgID.Scan(response.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if err := pgFormID.Scan(response.FormID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	records := make([]answerRecord, 0, len(response.Answers))
+	for _, answer := range response.Answers {
+		records = append(records, answerRecord{QuestionID: answer.QuestionID, Values: answer.Values})
+	}
+	answers, err := json.Marshal(records)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize form answers", err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	inserted, err := qtx.InsertFormParticipation(ctx, db.InsertFormParticipationParams{
+		FormID:   pgFormID,
+		TenantID: response.TenantID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if inserted == 0 {
+		return surveysErrors.NewFormAlreadyAnsweredError(response.FormID)
+	}
+
+	err = qtx.CreateFormResponse(ctx, db.CreateFormResponseParams{
+		ID:           pgID,
+		TenantID:     response.TenantID,
+		FormID:       pgFormID,
+		RespondentID: emptyToNil(response.RespondentID),
+		Answers:      answers,
+		SubmittedAt:  pgtype.Timestamptz{Time: response.SubmittedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresResponseRepository) HasResponded(ctx context.Context, tenantID string, formID string, userID string) (bool, error) {
+	var pgFormID pgtype.UUID
+	if err := pgFormID.Scan(formID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	responded, err := r.queries.FormParticipationExists(ctx, db.FormParticipationExistsParams{
+		FormID:   pgFormID,
+		TenantID: tenantID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return responded, nil
+}
+
+func (r *PostgresResponseRepository) List(ctx context.Context, tenantID string, formID string,
+	page *pagination.PageRequest) (*pagination.Page[*entities.Response], error) {
+	var pgFormID pgtype.UUID
+	if err := pgFormID.Scan(formID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	afterSubmittedAt, afterID, err := database.TimeIDKeyset(page.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.queries.ListFormResponses(ctx, db.ListFormResponsesParams{
+		FormID:           pgFormID,
+		TenantID:         tenantID,
+		PageLimit:        int32(page.PageSize + 1),
+		AfterSubmittedAt: afterSubmittedAt,
+		AfterID:          afterID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	responses := make([]*entities.Response, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+
+		var records []answerRecord
+		if err := json.Unmarshal(row.Answers, &records); err != nil {
+			return nil, appErrors.NewInfrastructureError("failed to read form answers", err)
+		}
+		answers := make([]entities.Answer, 0, len(records))
+		for _, record := range records {
+			answers = append(answers, entities.Answer{QuestionID: record.QuestionID, Values: record.Values})
+		}
+
+		respondentID := ""
+		if row.RespondentID != nil {
+			respondentID = *row.RespondentID
+		}
+		response, err := entities.NewResponse(row.ID.String(), row.TenantID, row.FormID.String(), respondentID, answers,
+			row.SubmittedAt.Time)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		responses = append(responses, response)
+	}
+
+	return pagination.NewPage(page, responses, total, func(response *entities.Response) []string {
+		return []string{pagination.TimeKey(response.SubmittedAt), response.ID}
+	}), nil
+}
@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"time"
+
+	localizationEntities "github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type BranchRequest struct {
+	Option string `json:"option" minLength:"1" maxLength:"200"`
+	GoTo   string `json:"go_to,omitempty" maxLength:"50" doc:"ID of a later question, the form ends when empty"`
+}
+
+type QuestionRequest struct {
+	ID       string          `json:"id" minLength:"1" maxLength:"50" pattern:"^[A-Za-z0-9_-]+$" doc:"Key of the question within the form, used by branches and answers"`
+	Prompt   string          `json:"prompt" minLength:"1" maxLength:"500"`
+	Type     string          `json:"type" enum:"short_text,long_text,single_choice,multiple_choice,rating"`
+	Required bool            `json:"required,omitempty"`
+	Options  []string        `json:"options,omitempty" maxItems:"50" doc:"Choices of single and multiple choice questions"`
+	Branches []BranchRequest `json:"branches,omitempty" maxItems:"50" doc:"Single choice questions only, picks the next question by option"`
+}
+
+func (q QuestionRequest) toEntity() *entities.Question {
+	question := &entities.Question{
+		ID:       q.ID,
+		Prompt:   q.Prompt,
+		Type:     entities.QuestionType(q.Type),
+		Required: q.Required,
+		Options:  q.Options,
+	}
+	for _, branch := range q.Branches {
+		question.Branches = append(question.Branches, entities.Branch{Option: branch.Option, GoTo: branch.GoTo})
+	}
+	return question
+}
+
+type AudienceRequest struct {
+	Roles    []string `json:"roles,omitempty" maxItems:"10" example:"student" doc:"Respondents need one of these roles"`
+	ClassIDs []string `json:"class_ids,omitempty" maxItems:"50" doc:"Students enrolled in these classes and their guardians"`
+}
+
+type FormBodyRequest struct {
+	Title       string            `json:"title" minLength:"1" maxLength:"200"`
+	Description string            `json:"description,omitempty" maxLength:"2000"`
+	Anonymous   bool              `json:"anonymous,omitempty" doc:"Responses are stored without the respondent"`
+	Questions   []QuestionRequest `json:"questions" minItems:"1" maxItems:"100"`
+	Audience    AudienceRequest   `json:"audience,omitempty" doc:"The whole tenant when empty"`
+	ClosesAt    *time.Time        `json:"closes_at,omitempty"`
+}
+
+func (b FormBodyRequest) questions() []*entities.Question {
+	questions := make([]*entities.Question, 0, len(b.Questions))
+	for _, question := range b.Questions {
+		questions = append(questions, question.toEntity())
+	}
+	return questions
+}
+
+func (b FormBodyRequest) audience() entities.Audience {
+	return entities.Audience{Roles: b.Audience.Roles, ClassIDs: b.Audience.ClassIDs}
+}
+
+type CreateFormRequest struct {
+	Body FormBodyRequest
+}
+
+type UpdateFormRequest struct {
+	FormID string `path:"formId" format:"uuid"`
+	Body   FormBodyRequest
+}
+
+type FormRequest struct {
+	FormID string `path:"formId" format:"uuid"`
+}
+
+type GetFormRequest struct {
+	FormID         string `path:"formId" format:"uuid"`
+	AcceptLanguage string `header:"Accept-Language" doc:"Locales to show the title, description and prompts in, falls back to the content as written"`
+}
+
+type ListFormsRequest struct {
+	Status         string `query:"status" enum:"draft,open,closed"`
+	Limit          int    `query:"limit" minimum:"0" maximum:"100" doc:"Page size, 50 when omitted"`
+	AcceptLanguage string `header:"Accept-Language" doc:"Locales to show titles, descriptions and prompts in, falls back to the content as written"`
+}
+
+type ListAssignedFormsRequest struct {
+	AcceptLanguage string `header:"Accept-Language" doc:"Locales to show titles, descriptions and prompts in, falls back to the content as written"`
+}
+
+type BranchResponse struct {
+	Option string `json:"option"`
+	GoTo   string `json:"go_to,omitempty"`
+}
+
+type QuestionResponse struct {
+	ID       string           `json:"id"`
+	Prompt   string           `json:"prompt"`
+	Type     string           `json:"type"`
+	Required bool             `json:"required"`
+	Options  []string         `json:"options,omitempty"`
+	Branches []BranchResponse `json:"branches,omitempty"`
+}
+
+type AudienceResponse struct {
+	Roles    []string `json:"roles"`
+	ClassIDs []string `json:"class_ids"`
+}
+
+type FormResponse struct {
+	ID          string             `json:"id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description,omitempty"`
+	Anonymous   bool               `json:"anonymous"`
+	Status      string             `json:"status" enum:"draft,open,closed"`
+	Questions   []QuestionResponse `json:"questions"`
+	Audience    AudienceResponse   `json:"audience"`
+	CreatedBy   string             `json:"created_by"`
+	ClosesAt    *time.Time         `json:"closes_at,omitempty"`
+	OpenedAt    *time.Time         `json:"opened_at,omitempty"`
+	ClosedAt    *time.Time         `json:"closed_at,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// NewFormResponse shows when the form opens and closes in the zone of the reader
+func NewFormResponse(form *entities.Form, loc *time.Location) FormResponse {
+	response := FormResponse{
+		ID:          form.ID,
+		Title:       form.Title,
+		Description: form.Description,
+		Anonymous:   form.Anonymous,
+		Status:      string(form.Status),
+		Questions:   make([]QuestionResponse, 0, len(form.Questions)),
+		Audience:    AudienceResponse{Roles: form.Audience.Roles, ClassIDs: form.Audience.ClassIDs},
+		CreatedBy:   form.CreatedBy,
+		ClosesAt:    utils.InLocationPtr(form.ClosesAt, loc),
+		OpenedAt:    utils.InLocationPtr(form.OpenedAt, loc),
+		ClosedAt:    utils.InLocationPtr(form.ClosedAt, loc),
+		CreatedAt:   form.CreatedAt,
+		UpdatedAt:   form.UpdatedAt,
+	}
+	if response.Audience.Roles == nil {
+		response.Audience.Roles = []string{}
+	}
+	if response.Audience.ClassIDs == nil {
+		response.Audience.ClassIDs = []string{}
+	}
+	for _, question := range form.Questions {
+		item := QuestionResponse{
+			ID:       question.ID,
+			Prompt:   question.Prompt,
+			Type:     string(question.Type),
+			Required: question.Required,
+			Options:  question.Options,
+		}
+		for _, branch := range question.Branches {
+			item.Branches = append(item.Branches, BranchResponse{Option: branch.Option, GoTo: branch.GoTo})
+		}
+		response.Questions = append(response.Questions, item)
+	}
+	return response
+}
+
+// localize overlays the translated fields of the form, options stay as written since answers and
+// branches refer to them
+func (r *FormResponse) localize(fields map[string]string) {
+	if title, found := fields[localizationEntities.FieldTitle]; found {
+		r.Title = title
+	}
+	if description, found := fields[localizationEntities.FieldDescription]; found {
+		r.Description = description
+	}
+	for i := range r.Questions {
+		if prompt, found := fields[localizationEntities.PromptField(r.Questions[i].ID)]; found {
+			r.Questions[i].Prompt = prompt
+		}
+	}
+}
+
+type FormEnvelope struct {
+	Body FormResponse
+}
+
+type FormListResponse struct {
+	Body struct {
+		Items []FormResponse `json:"items"`
+	}
+}
+
+type FormViewResponse struct {
+	FormResponse
+	Responded bool `json:"responded" doc:"Whether the current user already answered"`
+}
+
+func NewFormViewResponse(view *entities.FormView, loc *time.Location) FormViewResponse {
+	return FormViewResponse{FormResponse: NewFormResponse(view.Form, loc), Responded: view.Responded}
+}
+
+type FormViewEnvelope struct {
+	Body FormViewResponse
+}
+
+type AssignedFormListResponse struct {
+	Body struct {
+		Items []FormViewResponse `json:"items"`
+	}
+}
+
+type OpenFormResponse struct {
+	Body struct {
+		FormResponse
+		RecipientCount int `json:"recipient_count" doc:"Members of the audience the form was sent to"`
+	}
+}
+
+type AnswerRequest struct {
+	QuestionID string   `json:"question_id" minLength:"1" maxLength:"50"`
+	Values     []string `json:"values" maxItems:"50" doc:"One value except for multiple choice questions, ratings go from \"1\" to \"5\""`
+}
+
+type SubmitResponseRequest struct {
+	FormID string `path:"formId" format:"uuid"`
+	Body   struct {
+		Answers []AnswerRequest `json:"answers" maxItems:"100"`
+	}
+}
+
+func (r *SubmitResponseRequest) answers() []entities.Answer {
+	answers := make([]entities.Answer, 0, len(r.Body.Answers))
+	for _, answer := range r.Body.Answers {
+		answers = append(answers, entities.Answer{QuestionID: answer.QuestionID, Values: answer.Values})
+	}
+	return answers
+}
+
+type AnswerResponse struct {
+	QuestionID string   `json:"question_id"`
+	Values     []string `json:"values"`
+}
+
+type ResponseResponse struct {
+	ID           string           `json:"id"`
+	FormID       string           `json:"form_id"`
+	RespondentID string           `json:"respondent_id,omitempty" doc:"Absent for anonymous forms"`
+	Answers      []AnswerResponse `json:"answers"`
+	SubmittedAt  time.Time        `json:"submitted_at"`
+}
+
+func NewResponseResponse(response *entities.Response) ResponseResponse {
+	answers := make([]AnswerResponse, 0, len(response.Answers))
+	for _, answer := range response.Answers {
+		answers = append(answers, AnswerResponse{QuestionID: answer.QuestionID, Values: answer.Values})
+	}
+	return ResponseResponse{
+		ID:           response.ID,
+		FormID:       response.FormID,
+		RespondentID: response.RespondentID,
+		Answers:      answers,
+		SubmittedAt:  response.SubmittedAt,
+	}
+}
+
+type ResponseEnvelope struct {
+	Body ResponseResponse
+}
+
+type ListFormResponsesRequest struct {
+	FormID   string `path:"formId" format:"uuid"`
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+}
+
+type ListFormResponsesResponse struct {
+	Body utils.ListResponseBody[ResponseResponse]
+}
+
+func NewListFormResponsesResponse(page *pagination.Page[*entities.Response]) *ListFormResponsesResponse {
+	return &ListFormResponsesResponse{Body: utils.NewListResponseBody(page, NewResponseResponse)}
+}
@@ -0,0 +1,421 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	localize_content_use_case "github.com/nahualventure/class-backend/core/app/localization/application/use-cases/localize-content-use-case"
+	localizationEntities "github.com/nahualventure/class-backend/core/app/localization/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	close_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/close-form-use-case"
+	create_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/create-form-use-case"
+	get_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/get-form-use-case"
+	list_assigned_forms_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/list-assigned-forms-use-case"
+	list_form_responses_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/list-form-responses-use-case"
+	list_forms_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/list-forms-use-case"
+	open_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/open-form-use-case"
+	submit_response_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/submit-response-use-case"
+	update_form_use_case "github.com/nahualventure/class-backend/core/app/surveys/application/use-cases/update-form-use-case"
+	"github.com/nahualventure/class-backend/core/app/surveys/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type SurveysHandlers struct {
+	createFormUseCase        *create_form_use_case.CreateFormUseCase
+	updateFormUseCase        *update_form_use_case.UpdateFormUseCase
+	openFormUseCase          *open_form_use_case.OpenFormUseCase
+	closeFormUseCase         *close_form_use_case.CloseFormUseCase
+	getFormUseCase           *get_form_use_case.GetFormUseCase
+	listFormsUseCase         *list_forms_use_case.ListFormsUseCase
+	listAssignedFormsUseCase *list_assigned_forms_use_case.ListAssignedFormsUseCase
+	submitResponseUseCase    *submit_response_use_case.SubmitResponseUseCase
+	listFormResponsesUseCase *list_form_responses_use_case.ListFormResponsesUseCase
+	localizeContentUseCase   *localize_content_use_case.LocalizeContentUseCase
+}
+
+func NewSurveysHandlers(
+	createFormUseCase *create_form_use_case.CreateFormUseCase,
+	updateFormUseCase *update_form_use_case.UpdateFormUseCase,
+	openFormUseCase *open_form_use_case.OpenFormUseCase,
+	closeFormUseCase *close_form_use_case.CloseFormUseCase,
+	getFormUseCase *get_form_use_case.GetFormUseCase,
+	listFormsUseCase *list_forms_use_case.ListFormsUseCase,
+	listAssignedFormsUseCase *list_assigned_forms_use_case.ListAssignedFormsUseCase,
+	submitResponseUseCase *submit_response_use_case.SubmitResponseUseCase,
+	listFormResponsesUseCase *list_form_responses_use_case.ListFormResponsesUseCase,
+	localizeContentUseCase *localize_content_use_case.LocalizeContentUseCase,
+) *SurveysHandlers {
+	return &SurveysHandlers{
+		createFormUseCase:        createFormUseCase,
+		updateFormUseCase:        updateFormUseCase,
+		openFormUseCase:          openFormUseCase,
+		closeFormUseCase:         closeFormUseCase,
+		getFormUseCase:           getFormUseCase,
+		listFormsUseCase:         listFormsUseCase,
+		listAssignedFormsUseCase: listAssignedFormsUseCase,
+		submitResponseUseCase:    submitResponseUseCase,
+		listFormResponsesUseCase: listFormResponsesUseCase,
+		localizeContentUseCase:   localizeContentUseCase,
+	}
+}
+
+func (h *SurveysHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-form",
+		Method:        http.MethodPost,
+		Path:          "/forms",
+		Summary:       "Create a survey form as a draft",
+		Tags:          []string{"Surveys"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateForm)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-forms",
+		Method:      http.MethodGet,
+		Path:        "/forms",
+		Summary:     "List the forms managed by the current user",
+		Tags:        []string{"Surveys"},
+	}, h.ListForms)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-assigned-forms",
+		Method:      http.MethodGet,
+		Path:        "/forms/assigned",
+		Summary:     "List the open forms sent to the current user",
+		Tags:        []string{"Surveys"},
+	}, h.ListAssignedForms)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-form",
+		Method:      http.MethodGet,
+		Path:        "/forms/{formId}",
+		Summary:     "Get a form to answer or manage it",
+		Tags:        []string{"Surveys"},
+	}, h.GetForm)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-form",
+		Method:      http.MethodPut,
+		Path:        "/forms/{formId}",
+		Summary:     "Replace the questions and audience of a draft form",
+		Tags:        []string{"Surveys"},
+	}, h.UpdateForm)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "open-form",
+		Method:      http.MethodPost,
+		Path:        "/forms/{formId}/open",
+		Summary:     "Open a draft form and send it to its audience",
+		Description: "Members of the audience are notified, the questions and audience cannot change afterwards.",
+		Tags:        []string{"Surveys"},
+	}, h.OpenForm)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "close-form",
+		Method:      http.MethodPost,
+		Path:        "/forms/{formId}/close",
+		Summary:     "Stop collecting responses",
+		Tags:        []string{"Surveys"},
+	}, h.CloseForm)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "submit-form-response",
+		Method:        http.MethodPost,
+		Path:          "/forms/{formId}/responses",
+		Summary:       "Answer a form, once per user",
+		Tags:          []string{"Surveys"},
+		DefaultStatus: http.StatusCreated,
+	}, h.SubmitResponse)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-form-responses",
+		Method:      http.MethodGet,
+		Path:        "/forms/{formId}/responses",
+		Summary:     "List the responses of a form",
+		Tags:        []string{"Surveys"},
+	}, h.ListFormResponses)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "export-form-responses",
+		Method:      http.MethodGet,
+		Path:        "/forms/{formId}/responses/export",
+		Summary:     "Export the responses of a form as CSV",
+		Tags:        []string{"Surveys"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "CSV file with one row per response and one column per question",
+				Content:     map[string]*huma.MediaType{"text/csv": {}},
+			},
+		},
+	}, h.ExportFormResponses)
+}
+
+func (h *SurveysHandlers) CreateForm(ctx context.Context, input *CreateFormRequest) (*FormEnvelope, error) {
+	command, err := create_form_use_case.NewCreateFormCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Body.Title,
+		input.Body.Description,
+		input.Body.Anonymous,
+		input.Body.questions(),
+		input.Body.audience(),
+		input.Body.ClosesAt,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	form, err := h.createFormUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &FormEnvelope{Body: NewFormResponse(form, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *SurveysHandlers) ListForms(ctx context.Context, input *ListFormsRequest) (*FormListResponse, error) {
+	command, err := list_forms_use_case.NewListFormsCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+		input.Status,
+		input.Limit,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	forms, err := h.listFormsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	formIDs := make([]string, 0, len(forms))
+	for _, form := range forms {
+		formIDs = append(formIDs, form.ID)
+	}
+	localized, err := h.localize(ctx, formIDs, input.AcceptLanguage)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &FormListResponse{}
+	loc := utils.LocationFromContext(ctx)
+	response.Body.Items = make([]FormResponse, 0, len(forms))
+	for _, form := range forms {
+		item := NewFormResponse(form, loc)
+		item.localize(localized[form.ID])
+		response.Body.Items = append(response.Body.Items, item)
+	}
+	return response, nil
+}
+
+func (h *SurveysHandlers) ListAssignedForms(ctx context.Context, input *ListAssignedFormsRequest) (*AssignedFormListResponse, error) {
+	command, err := list_assigned_forms_use_case.NewListAssignedFormsCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	views, err := h.listAssignedFormsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	formIDs := make([]string, 0, len(views))
+	for _, view := range views {
+		formIDs = append(formIDs, view.Form.ID)
+	}
+	localized, err := h.localize(ctx, formIDs, input.AcceptLanguage)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &AssignedFormListResponse{}
+	loc := utils.LocationFromContext(ctx)
+	response.Body.Items = make([]FormViewResponse, 0, len(views))
+	for _, view := range views {
+		item := NewFormViewResponse(view, loc)
+		item.localize(localized[view.Form.ID])
+		response.Body.Items = append(response.Body.Items, item)
+	}
+	return response, nil
+}
+
+func (h *SurveysHandlers) GetForm(ctx context.Context, input *GetFormRequest) (*FormViewEnvelope, error) {
+	command, err := get_form_use_case.NewGetFormCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.FormID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	view, err := h.getFormUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	localized, err := h.localize(ctx, []string{view.Form.ID}, input.AcceptLanguage)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &FormViewEnvelope{Body: NewFormViewResponse(view, utils.LocationFromContext(ctx))}
+	response.Body.localize(localized[view.Form.ID])
+	return response, nil
+}
+
+func (h *SurveysHandlers) UpdateForm(ctx context.Context, input *UpdateFormRequest) (*FormEnvelope, error) {
+	command, err := update_form_use_case.NewUpdateFormCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.FormID,
+		authorization.UserIDFromContext(ctx),
+		input.Body.Title,
+		input.Body.Description,
+		input.Body.Anonymous,
+		input.Body.questions(),
+		input.Body.audience(),
+		input.Body.ClosesAt,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	form, err := h.updateFormUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &FormEnvelope{Body: NewFormResponse(form, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *SurveysHandlers) OpenForm(ctx context.Context, input *FormRequest) (*OpenFormResponse, error) {
+	command, err := open_form_use_case.NewOpenFormCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.FormID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	form, recipients, err := h.openFormUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &OpenFormResponse{}
+	response.Body.FormResponse = NewFormResponse(form, utils.LocationFromContext(ctx))
+	response.Body.RecipientCount = recipients
+	return response, nil
+}
+
+func (h *SurveysHandlers) CloseForm(ctx context.Context, input *FormRequest) (*FormEnvelope, error) {
+	command, err := close_form_use_case.NewCloseFormCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.FormID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	form, err := h.closeFormUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &FormEnvelope{Body: NewFormResponse(form, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *SurveysHandlers) SubmitResponse(ctx context.Context, input *SubmitResponseRequest) (*ResponseEnvelope, error) {
+	command, err := submit_response_use_case.NewSubmitResponseCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.FormID,
+		authorization.UserIDFromContext(ctx),
+		input.answers(),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response, err := h.submitResponseUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ResponseEnvelope{Body: NewResponseResponse(response)}, nil
+}
+
+func (h *SurveysHandlers) ListFormResponses(ctx context.Context, input *ListFormResponsesRequest) (*ListFormResponsesResponse, error) {
+	command, err := list_form_responses_use_case.NewListFormResponsesCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.FormID,
+		authorization.UserIDFromContext(ctx),
+		input.PageSize,
+		input.Cursor,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	_, page, err := h.listFormResponsesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListFormResponsesResponse(page), nil
+}
+
+func (h *SurveysHandlers) ExportFormResponses(ctx context.Context, input *FormRequest) (*huma.StreamResponse, error) {
+	tenantID := authorization.TenantIDFromContext(ctx)
+	userID := authorization.UserIDFromContext(ctx)
+
+	var form *entities.Form
+	fetchPage := func(cursor string) (*pagination.Page[*entities.Response], error) {
+		command, err := list_form_responses_use_case.NewListFormResponsesCommand(tenantID, input.FormID, userID, pagination.MaxPageSize, cursor)
+		if err != nil {
+			return nil, err
+		}
+		fetched, page, err := h.listFormResponsesUseCase.Execute(ctx, command)
+		if err != nil {
+			return nil, err
+		}
+		form = fetched
+		return page, nil
+	}
+
+	firstPage, err := fetchPage("")
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return export.CSVStream[*entities.Response]{
+		Filename:  "form-" + form.ID + "-responses.csv",
+		Header:    form.ResultsHeader(),
+		ToRow:     form.ResultsRow,
+		FirstPage: firstPage,
+		NextPage:  fetchPage,
+	}.Response(), nil
+}
+
+// localize returns the translated fields by form ID in the locales the reader accepts
+func (h *SurveysHandlers) localize(ctx context.Context, formIDs []string, acceptLanguage string) (map[string]map[string]string, error) {
+	command, err := localize_content_use_case.NewLocalizeContentCommand(
+		authorization.TenantIDFromContext(ctx),
+		localizationEntities.EntityTypeForm,
+		formIDs,
+		acceptLanguage,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return h.localizeContentUseCase.Execute(ctx, command)
+}
@@ -0,0 +1,76 @@
+-- name: CreateForm :exec
+INSERT INTO forms (id, tenant_id, title, description, anonymous, status, questions, audience_roles, audience_class_ids,
+                   created_by, closes_at, created_at, updated_at)
+VALUES (@id, @tenant_id, @title, @description, @anonymous, @status, @questions, @audience_roles, @audience_class_ids,
+        @created_by, @closes_at, @created_at, @updated_at);
+
+-- name: UpdateForm :execrows
+UPDATE forms
+SET title = @title, description = @description, anonymous = @anonymous, status = @status, questions = @questions,
+    audience_roles = @audience_roles, audience_class_ids = @audience_class_ids, closes_at = @closes_at,
+    opened_at = @opened_at, closed_at = @closed_at, updated_at = @updated_at
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: GetForm :one
+SELECT id, tenant_id, title, description, anonymous, status, questions, audience_roles, audience_class_ids, created_by,
+       closes_at, opened_at, closed_at, created_at, updated_at
+FROM forms
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: ListForms :many
+SELECT id, tenant_id, title, description, anonymous, status, questions, audience_roles, audience_class_ids, created_by,
+       closes_at, opened_at, closed_at, created_at, updated_at
+FROM forms
+WHERE tenant_id = @tenant_id
+  AND (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('created_by')::varchar IS NULL OR created_by = sqlc.narg('created_by'))
+ORDER BY created_at DESC
+LIMIT @row_limit;
+
+-- Role holders of the tenant, narrowed to members of the classes when given: enrolled students and
+-- guardians of enrolled students
+-- name: ListAudienceMembers :many
+SELECT DISTINCT c.v0::varchar AS user_id
+FROM casbin_rule c
+WHERE c.ptype = 'g' AND c.v2 = @tenant_id::text
+  AND (cardinality(@roles::text[]) = 0 OR c.v1 = ANY(@roles::text[]))
+  AND (
+      cardinality(@class_ids::text[]) = 0
+      OR EXISTS (
+          SELECT 1 FROM class_enrollments e
+          WHERE e.tenant_id = @tenant_id::text AND e.user_id = c.v0 AND e.class_id::text = ANY(@class_ids::text[])
+      )
+      OR EXISTS (
+          SELECT 1
+          FROM guardian_links g
+          JOIN class_enrollments e ON e.tenant_id = g.tenant_id AND e.user_id = g.student_id
+          WHERE g.tenant_id = @tenant_id::text AND g.guardian_id = c.v0 AND e.class_id::text = ANY(@class_ids::text[])
+      )
+  )
+ORDER BY user_id;
+
+-- name: InsertFormParticipation :execrows
+INSERT INTO form_participations (form_id, tenant_id, user_id)
+VALUES (@form_id, @tenant_id, @user_id)
+ON CONFLICT (form_id, user_id) DO NOTHING;
+
+-- name: CreateFormResponse :exec
+INSERT INTO form_responses (id, tenant_id, form_id, respondent_id, answers, submitted_at)
+VALUES (@id, @tenant_id, @form_id, @respondent_id, @answers, @submitted_at);
+
+-- name: FormParticipationExists :one
+SELECT EXISTS (
+    SELECT 1 FROM form_participations
+    WHERE form_id = @form_id AND tenant_id = @tenant_id AND user_id = @user_id
+);
+
+-- Keyset page of the responses oldest first, the total counts every response of the form
+-- name: ListFormResponses :many
+SELECT id, tenant_id, form_id, respondent_id, answers, submitted_at,
+       (SELECT count(*) FROM form_responses t WHERE t.form_id = @form_id AND t.tenant_id = @tenant_id) AS total
+FROM form_responses
+WHERE form_id = @form_id AND tenant_id = @tenant_id
+  AND (sqlc.narg('after_submitted_at')::timestamptz IS NULL
+       OR (submitted_at, id) > (sqlc.narg('after_submitted_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY submitted_at, id
+LIMIT @page_limit;
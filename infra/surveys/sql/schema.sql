@@ -0,0 +1,42 @@
+-- Surveys and forms. Questions are stored as JSON since they are only read and written with their
+-- form, and never change once the form is opened.
+CREATE TABLE forms (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL,            -- draft, open, closed
+    questions JSONB NOT NULL,
+    audience_roles TEXT[] NOT NULL DEFAULT '{}',
+    audience_class_ids TEXT[] NOT NULL DEFAULT '{}',
+    created_by VARCHAR(255) NOT NULL,
+    closes_at TIMESTAMP WITH TIME ZONE,
+    opened_at TIMESTAMP WITH TIME ZONE,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_forms_tenant ON forms(tenant_id, status, created_at DESC);
+
+-- respondent_id is NULL for anonymous forms
+CREATE TABLE form_responses (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    form_id UUID NOT NULL REFERENCES forms(id) ON DELETE CASCADE,
+    respondent_id VARCHAR(255),
+    answers JSONB NOT NULL,
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_form_responses_form ON form_responses(form_id, submitted_at);
+
+-- Who answered each form, one response per user. Kept apart from the responses and without a
+-- timestamp so anonymous answers cannot be matched to their respondent.
+CREATE TABLE form_participations (
+    form_id UUID NOT NULL REFERENCES forms(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (form_id, user_id)
+);
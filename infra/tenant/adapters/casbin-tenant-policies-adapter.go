@@ -0,0 +1,45 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// onboardedTenantSource names the onboarded tenants among the tenants the policies are activated for
+const onboardedTenantSource = "tenants"
+
+// CasbinTenantPolicies generates the policies of the roles for onboarded tenants as for the tenants
+// of the configuration
+type CasbinTenantPolicies struct {
+	casbinService *authorization.CasbinService
+}
+
+func NewCasbinTenantPolicies(casbinService *authorization.CasbinService) ports.TenantPolicies {
+	return &CasbinTenantPolicies{
+		casbinService: casbinService,
+	}
+}
+
+// Activate reloads the policies only when the onboarded tenants changed
+func (p *CasbinTenantPolicies) Activate(_ context.Context, tenantIDs []string) error {
+	if authzErr := p.casbinService.ActivateTenants(onboardedTenantSource, tenantIDs); authzErr != nil {
+		return authzErr
+	}
+	return nil
+}
+
+func (p *CasbinTenantPolicies) AssignRole(_ context.Context, userID string, role string, tenantID string) error {
+	if authzErr := p.casbinService.AssignRole(userID, role, tenantID); authzErr != nil {
+		return authzErr
+	}
+	return nil
+}
+
+func (p *CasbinTenantPolicies) RemoveRoles(ctx context.Context, tenantID string) error {
+	if authzErr := p.casbinService.RemoveTenantRoles(ctx, tenantID); authzErr != nil {
+		return authzErr
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresTenantInvitationRepository records invitations with their outbox event in one
+// transaction, the event is only written with the row
+type PostgresTenantInvitationRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresTenantInvitationRepository(dbInstance *pgxpool.Pool) ports.TenantInvitationRepository {
+	return &PostgresTenantInvitationRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresTenantInvitationRepository) Create(ctx context.Context, invitation *entities.TenantInvitation) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(invitation.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	created, err := qtx.CreateTenantInvitation(ctx, db.CreateTenantInvitationParams{
+		ID:        pgUUID,
+		TenantID:  invitation.TenantID,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		ExpiresAt: pgtype.Timestamptz{Time: invitation.ExpiresAt, Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: invitation.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	if created == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"invitation_id": invitation.ID,
+		"email":         invitation.Email,
+		"role":          invitation.Role,
+		"expires_at":    invitation.ExpiresAt,
+	})
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize tenant invitation event", err)
+	}
+
+	err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   events.TenantAdminInvited,
+		AggregateID: invitation.ID,
+		TenantID:    invitation.TenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: invitation.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresTenantInvitationRepository) Find(ctx context.Context, invitationID string) (*entities.TenantInvitation, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(invitationID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetTenantInvitation(ctx, pgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	invitation := &entities.TenantInvitation{
+		ID:        row.ID.String(),
+		TenantID:  row.TenantID,
+		Email:     row.Email,
+		Role:      row.Role,
+		ExpiresAt: row.ExpiresAt.Time,
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.AcceptedBy != nil {
+		invitation.AcceptedBy = *row.AcceptedBy
+	}
+	if row.AcceptedAt.Valid {
+		acceptedAt := row.AcceptedAt.Time
+		invitation.AcceptedAt = &acceptedAt
+	}
+	if row.RevokedAt.Valid {
+		revokedAt := row.RevokedAt.Time
+		invitation.RevokedAt = &revokedAt
+	}
+	return invitation, nil
+}
+
+func (r *PostgresTenantInvitationRepository) Accept(ctx context.Context, invitationID string, userID string, at time.Time) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(invitationID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	accepted, err := r.queries.AcceptTenantInvitation(ctx, db.AcceptTenantInvitationParams{
+		ID:         pgUUID,
+		AcceptedBy: &userID,
+		AcceptedAt: pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return accepted > 0, nil
+}
+
+func (r *PostgresTenantInvitationRepository) Revoke(ctx context.Context, invitationID string, at time.Time) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(invitationID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.RevokeTenantInvitation(ctx, db.RevokeTenantInvitationParams{
+		ID:        pgUUID,
+		RevokedAt: pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
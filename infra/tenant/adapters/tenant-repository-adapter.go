@@ -0,0 +1,98 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTenantRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresTenantRepository(dbInstance *pgxpool.Pool) ports.TenantRepository {
+	return &PostgresTenantRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresTenantRepository) Create(ctx context.Context, tenant *entities.Tenant) (bool, error) {
+	created, err := r.queries.CreateTenant(ctx, db.CreateTenantParams{
+		ID:         tenant.ID,
+		Name:       tenant.Name,
+		AdminEmail: tenant.AdminEmail,
+		Status:     string(tenant.Status),
+		CreatedAt:  pgtype.Timestamptz{Time: tenant.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return created > 0, nil
+}
+
+func (r *PostgresTenantRepository) Find(ctx context.Context, tenantID string) (*entities.Tenant, error) {
+	row, err := r.queries.GetTenant(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	tenant := &entities.Tenant{
+		ID:          row.ID,
+		Name:        row.Name,
+		AdminEmail:  row.AdminEmail,
+		Status:      entities.TenantStatus(row.Status),
+		RolesSeeded: row.RolesSeeded,
+		CreatedAt:   row.CreatedAt.Time,
+	}
+	if row.ActivatedAt.Valid {
+		activatedAt := row.ActivatedAt.Time
+		tenant.ActivatedAt = &activatedAt
+	}
+	return tenant, nil
+}
+
+func (r *PostgresTenantRepository) Delete(ctx context.Context, tenantID string) error {
+	if err := r.queries.DeleteTenant(ctx, tenantID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresTenantRepository) SetRolesSeeded(ctx context.Context, tenantID string, seeded bool) error {
+	if err := r.queries.SetTenantRolesSeeded(ctx, db.SetTenantRolesSeededParams{ID: tenantID, RolesSeeded: seeded}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresTenantRepository) Activate(ctx context.Context, tenantID string, at time.Time) error {
+	err := r.queries.ActivateTenant(ctx, db.ActivateTenantParams{
+		ID:          tenantID,
+		ActivatedAt: pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresTenantRepository) ListSeededIDs(ctx context.Context) ([]string, error) {
+	tenantIDs, err := r.queries.ListSeededTenantIDs(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return tenantIDs, nil
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/tenant/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type TenantInvitationResponse struct {
+	ID         string     `json:"id"`
+	TenantID   string     `json:"tenant_id"`
+	Role       string     `json:"role" doc:"The role the user was given in the tenant"`
+	AcceptedBy string     `json:"accepted_by"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+}
+
+func NewTenantInvitationResponse(invitation *entities.TenantInvitation, loc *time.Location) TenantInvitationResponse {
+	return TenantInvitationResponse{
+		ID:         invitation.ID,
+		TenantID:   invitation.TenantID,
+		Role:       invitation.Role,
+		AcceptedBy: invitation.AcceptedBy,
+		AcceptedAt: utils.InLocationPtr(invitation.AcceptedAt, loc),
+	}
+}
+
+type AcceptTenantInvitationRequest struct {
+	InvitationID string `path:"invitationId" format:"uuid" doc:"The ID the invitation was delivered with"`
+}
+
+type TenantInvitationEnvelope struct {
+	Body TenantInvitationResponse
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	accept_tenant_invitation_use_case "github.com/nahualventure/class-backend/core/app/tenant/application/use-cases/accept-tenant-invitation-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type TenantHandlers struct {
+	acceptTenantInvitationUseCase *accept_tenant_invitation_use_case.AcceptTenantInvitationUseCase
+}
+
+func NewTenantHandlers(acceptTenantInvitationUseCase *accept_tenant_invitation_use_case.AcceptTenantInvitationUseCase) *TenantHandlers {
+	return &TenantHandlers{
+		acceptTenantInvitationUseCase: acceptTenantInvitationUseCase,
+	}
+}
+
+func (h *TenantHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "accept-tenant-invitation",
+		Method:      http.MethodPost,
+		Path:        "/tenant-invitations/{invitationId}/accept",
+		Summary:     "Accept an invitation to the tenant",
+		Description: "Gives the role of the invitation in the tenant to the user, whose email must be the one invited. Invitations expire after 7 days and are accepted once.",
+		Tags:        []string{"Tenants"},
+	}, h.AcceptTenantInvitation)
+}
+
+func (h *TenantHandlers) AcceptTenantInvitation(ctx context.Context, input *AcceptTenantInvitationRequest) (*TenantInvitationEnvelope, error) {
+	command, err := accept_tenant_invitation_use_case.NewAcceptTenantInvitationCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.InvitationID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	invitation, err := h.acceptTenantInvitationUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &TenantInvitationEnvelope{Body: NewTenantInvitationResponse(invitation, utils.LocationFromContext(ctx))}, nil
+}
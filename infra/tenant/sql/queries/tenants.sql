@@ -0,0 +1,51 @@
+-- name: CreateTenant :execrows
+INSERT INTO tenants (id, name, admin_email, status, created_at)
+VALUES (@id, @name, @admin_email, @status, @created_at)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: GetTenant :one
+SELECT *
+FROM tenants
+WHERE id = @id;
+
+-- name: DeleteTenant :exec
+DELETE FROM tenants
+WHERE id = @id;
+
+-- name: SetTenantRolesSeeded :exec
+UPDATE tenants
+SET roles_seeded = @roles_seeded
+WHERE id = @id;
+
+-- name: ActivateTenant :exec
+UPDATE tenants
+SET status = 'active',
+    activated_at = COALESCE(activated_at, @activated_at)
+WHERE id = @id;
+
+-- name: ListSeededTenantIDs :many
+SELECT id
+FROM tenants
+WHERE roles_seeded
+ORDER BY id;
+
+-- name: CreateTenantInvitation :execrows
+INSERT INTO tenant_invitations (id, tenant_id, email, role, expires_at, created_at)
+VALUES (@id, @tenant_id, @email, @role, @expires_at, @created_at)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: GetTenantInvitation :one
+SELECT *
+FROM tenant_invitations
+WHERE id = @id;
+
+-- name: AcceptTenantInvitation :execrows
+UPDATE tenant_invitations
+SET accepted_by = @accepted_by,
+    accepted_at = @accepted_at
+WHERE id = @id AND accepted_at IS NULL AND revoked_at IS NULL;
+
+-- name: RevokeTenantInvitation :exec
+UPDATE tenant_invitations
+SET revoked_at = COALESCE(revoked_at, @revoked_at)
+WHERE id = @id AND accepted_at IS NULL;
@@ -0,0 +1,26 @@
+-- Tenants onboarded at runtime, the tenants of the configuration have no row. The roles of the
+-- policy file apply to a tenant once its roles are seeded, every instance syncs them.
+CREATE TABLE tenants (
+    id VARCHAR(100) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    admin_email VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,           -- provisioning, active
+    roles_seeded BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    activated_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Invitations to a role in a tenant, accepted by the user with the invited email
+CREATE TABLE tenant_invitations (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(100) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    accepted_by VARCHAR(255),
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_tenant_invitations_tenant_id ON tenant_invitations(tenant_id);
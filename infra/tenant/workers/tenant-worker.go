@@ -0,0 +1,28 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	sync_tenant_roles_use_case "github.com/nahualventure/class-backend/core/app/tenant/application/use-cases/sync-tenant-roles-use-case"
+)
+
+// RunTenantRoleSync applies the policies to the tenants onboarded on any instance every interval,
+// until ctx is cancelled. The first sync runs right away so restarts serve the tenants again.
+func RunTenantRoleSync(ctx context.Context, useCase *sync_tenant_roles_use_case.SyncTenantRolesUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := useCase.Execute(ctx); err != nil {
+			log.Printf("Failed to sync tenant roles: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/timezone/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/cache"
+)
+
+// CachedTimeZoneRepository caches settings for the time zone middleware, which reads them on every
+// request. Saves through it take effect at once on this instance, other instances catch up within
+// the TTL.
+type CachedTimeZoneRepository struct {
+	next  ports.TimeZoneRepository
+	cache *cache.TTLCache[settingKey, *entities.TimeZoneSetting]
+}
+
+type settingKey struct {
+	tenantID string
+	userID   string
+}
+
+func NewCachedTimeZoneRepository(next ports.TimeZoneRepository, ttl time.Duration) ports.TimeZoneRepository {
+	return &CachedTimeZoneRepository{
+		next:  next,
+		cache: cache.NewTTLCache[settingKey, *entities.TimeZoneSetting](ttl, 50_000),
+	}
+}
+
+func (r *CachedTimeZoneRepository) FindSetting(ctx context.Context, tenantID string, userID string) (*entities.TimeZoneSetting, error) {
+	key := settingKey{tenantID: tenantID, userID: userID}
+	if setting, ok := r.cache.Lookup(ctx, key); ok {
+		return setting, nil
+	}
+
+	setting, err := r.next.FindSetting(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(key, setting)
+	return setting, nil
+}
+
+func (r *CachedTimeZoneRepository) Save(ctx context.Context, setting *entities.TimeZoneSetting) error {
+	if err := r.next.Save(ctx, setting); err != nil {
+		return err
+	}
+	r.cache.Delete(settingKey{tenantID: setting.TenantID, userID: setting.UserID})
+	return nil
+}
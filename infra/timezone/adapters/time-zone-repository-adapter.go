@@ -0,0 +1,55 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/timezone/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTimeZoneRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresTimeZoneRepository(dbInstance *pgxpool.Pool) ports.TimeZoneRepository {
+	return &PostgresTimeZoneRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresTimeZoneRepository) FindSetting(ctx context.Context, tenantID string, userID string) (*entities.TimeZoneSetting, error) {
+	row, err := r.queries.GetTimeZoneSetting(ctx, db.GetTimeZoneSettingParams{TenantID: tenantID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	setting, err := entities.NewTimeZoneSetting(row.TenantID, row.UserID, row.TimeZone, row.UpdatedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	return setting, nil
+}
+
+func (r *PostgresTimeZoneRepository) Save(ctx context.Context, setting *entities.TimeZoneSetting) error {
+	err := r.queries.UpsertTimeZoneSetting(ctx, db.UpsertTimeZoneSettingParams{
+		TenantID:  setting.TenantID,
+		UserID:    setting.UserID,
+		TimeZone:  setting.TimeZone,
+		UpdatedAt: pgtype.Timestamptz{Time: setting.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
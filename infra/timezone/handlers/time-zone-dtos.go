@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"github.com/nahualventure/class-backend/core/app/timezone/domain/entities"
+)
+
+type SetTimeZoneRequest struct {
+	Body struct {
+		TimeZone string `json:"time_zone" minLength:"1" maxLength:"64" example:"America/Guatemala" doc:"IANA time zone name"`
+	}
+}
+
+type TimeZoneResponse struct {
+	TimeZone       string `json:"time_zone" doc:"Zone due dates and schedules are shown in"`
+	UserTimeZone   string `json:"user_time_zone,omitempty" doc:"Zone picked by the user, absent when they follow the school"`
+	TenantTimeZone string `json:"tenant_time_zone" doc:"Zone of the school, class schedules are kept in its wall clock"`
+}
+
+func NewTimeZoneResponse(preferences *entities.TimeZonePreferences) TimeZoneResponse {
+	response := TimeZoneResponse{
+		TimeZone:       preferences.Effective(),
+		TenantTimeZone: preferences.TenantZone(),
+	}
+	if preferences.User != nil {
+		response.UserTimeZone = preferences.User.TimeZone
+	}
+	return response
+}
+
+type TimeZoneEnvelope struct {
+	Body TimeZoneResponse
+}
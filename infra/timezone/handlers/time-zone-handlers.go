@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	get_time_zone_use_case "github.com/nahualventure/class-backend/core/app/timezone/application/use-cases/get-time-zone-use-case"
+	set_time_zone_use_case "github.com/nahualventure/class-backend/core/app/timezone/application/use-cases/set-time-zone-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type TimeZoneHandlers struct {
+	getTimeZoneUseCase *get_time_zone_use_case.GetTimeZoneUseCase
+	setTimeZoneUseCase *set_time_zone_use_case.SetTimeZoneUseCase
+}
+
+func NewTimeZoneHandlers(
+	getTimeZoneUseCase *get_time_zone_use_case.GetTimeZoneUseCase,
+	setTimeZoneUseCase *set_time_zone_use_case.SetTimeZoneUseCase,
+) *TimeZoneHandlers {
+	return &TimeZoneHandlers{
+		getTimeZoneUseCase: getTimeZoneUseCase,
+		setTimeZoneUseCase: setTimeZoneUseCase,
+	}
+}
+
+func (h *TimeZoneHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-time-zone",
+		Method:      http.MethodGet,
+		Path:        "/preferences/time-zone",
+		Summary:     "Get the time zone responses are shown in",
+		Tags:        []string{"Time zones"},
+	}, h.GetTimeZone)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-time-zone",
+		Method:      http.MethodPut,
+		Path:        "/preferences/time-zone",
+		Summary:     "Pick the time zone of the current user",
+		Tags:        []string{"Time zones"},
+	}, h.SetTimeZone)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-tenant-time-zone",
+		Method:      http.MethodPut,
+		Path:        "/tenant/time-zone",
+		Summary:     "Set the time zone of the school",
+		Description: "Users that did not pick a zone see times in it, class schedules are kept in its wall clock.",
+		Tags:        []string{"Time zones"},
+	}, h.SetTenantTimeZone)
+}
+
+func (h *TimeZoneHandlers) GetTimeZone(ctx context.Context, input *struct{}) (*TimeZoneEnvelope, error) {
+	return h.preferences(ctx)
+}
+
+func (h *TimeZoneHandlers) SetTimeZone(ctx context.Context, input *SetTimeZoneRequest) (*TimeZoneEnvelope, error) {
+	return h.setTimeZone(ctx, authorization.UserIDFromContext(ctx), input.Body.TimeZone)
+}
+
+func (h *TimeZoneHandlers) SetTenantTimeZone(ctx context.Context, input *SetTimeZoneRequest) (*TimeZoneEnvelope, error) {
+	return h.setTimeZone(ctx, "", input.Body.TimeZone)
+}
+
+func (h *TimeZoneHandlers) setTimeZone(ctx context.Context, userID string, timeZone string) (*TimeZoneEnvelope, error) {
+	command, err := set_time_zone_use_case.NewSetTimeZoneCommand(
+		authorization.TenantIDFromContext(ctx),
+		userID,
+		timeZone,
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if _, err := h.setTimeZoneUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return h.preferences(ctx)
+}
+
+func (h *TimeZoneHandlers) preferences(ctx context.Context) (*TimeZoneEnvelope, error) {
+	command, err := get_time_zone_use_case.NewGetTimeZoneCommand(
+		authorization.TenantIDFromContext(ctx),
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	preferences, err := h.getTimeZoneUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &TimeZoneEnvelope{Body: NewTimeZoneResponse(preferences)}, nil
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"time"
+
+	get_time_zone_use_case "github.com/nahualventure/class-backend/core/app/timezone/application/use-cases/get-time-zone-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// NewTimeZoneMiddleware returns a Huma middleware that puts the zone of the user in the request
+// context, responses show due dates and schedules in it. It runs after the authorization
+// middleware, public endpoints stay in UTC.
+func NewTimeZoneMiddleware(getTimeZoneUseCase *get_time_zone_use_case.GetTimeZoneUseCase) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		tenantID := authorization.TenantIDFromContext(ctx.Context())
+		userID := authorization.UserIDFromContext(ctx.Context())
+		if tenantID == "" || userID == "" {
+			next(ctx)
+			return
+		}
+
+		command, err := get_time_zone_use_case.NewGetTimeZoneCommand(tenantID, userID)
+		if err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+		preferences, err := getTimeZoneUseCase.Execute(ctx.Context(), command)
+		if err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+
+		// Settings are validated on save, a zone missing from the database falls back to UTC
+		loc, err := utils.LoadLocation(preferences.Effective())
+		if err != nil {
+			loc = time.UTC
+		}
+		tenantLoc, err := utils.LoadLocation(preferences.TenantZone())
+		if err != nil {
+			tenantLoc = time.UTC
+		}
+
+		next(utils.WithLocations(ctx, loc, tenantLoc))
+	}
+}
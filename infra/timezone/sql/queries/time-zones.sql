@@ -0,0 +1,11 @@
+-- name: GetTimeZoneSetting :one
+SELECT tenant_id, user_id, time_zone, updated_at
+FROM time_zone_settings
+WHERE tenant_id = @tenant_id AND user_id = @user_id;
+
+-- name: UpsertTimeZoneSetting :exec
+INSERT INTO time_zone_settings (tenant_id, user_id, time_zone, updated_at)
+VALUES (@tenant_id, @user_id, @time_zone, @updated_at)
+ON CONFLICT (tenant_id, user_id) DO UPDATE SET
+    time_zone = EXCLUDED.time_zone,
+    updated_at = EXCLUDED.updated_at;
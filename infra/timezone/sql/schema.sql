@@ -0,0 +1,9 @@
+-- Time zone preferences. The row with an empty user_id is the default of the tenant, users without
+-- a row of their own see times in it.
+CREATE TABLE time_zone_settings (
+    tenant_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL DEFAULT '',
+    time_zone VARCHAR(64) NOT NULL,         -- IANA name, America/Guatemala
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, user_id)
+);
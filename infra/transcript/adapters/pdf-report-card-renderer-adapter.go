@@ -0,0 +1,46 @@
+package adapters
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/pdf"
+)
+
+// reportCardColumns are the offsets of the class, grade count and average columns
+var reportCardColumns = []int{0, pdf.ContentWidth - 160, pdf.ContentWidth - 70}
+
+// PDFReportCardRenderer writes report cards as plain PDF documents, see pdf.Layout
+type PDFReportCardRenderer struct{}
+
+func NewPDFReportCardRenderer() ports.ReportCardRenderer {
+	return &PDFReportCardRenderer{}
+}
+
+func (r *PDFReportCardRenderer) Render(ctx context.Context, document *entities.ReportCardDocument) ([]byte, error) {
+	layout := &pdf.Layout{}
+	layout.Text(document.Title, true, 18)
+	layout.Skip()
+	for _, line := range strings.Split(document.Header, "\n") {
+		layout.Text(line, false, 11)
+	}
+	layout.Skip()
+
+	layout.Row(true, reportCardColumns, "Class", "Grades", "Average")
+	for _, class := range document.Classes {
+		layout.Row(false, reportCardColumns, class.ClassID, strconv.Itoa(class.GradeCount), strconv.FormatFloat(class.Average, 'f', 2, 64))
+	}
+	if len(document.Classes) == 0 {
+		layout.Text("No grades were released during the term.", false, 11)
+	}
+	layout.Skip()
+
+	for _, line := range strings.Split(document.Footer, "\n") {
+		layout.Text(line, false, 11)
+	}
+
+	return layout.Bytes(), nil
+}
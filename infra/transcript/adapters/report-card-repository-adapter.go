@@ -0,0 +1,252 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresReportCardRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresReportCardRepository(dbInstance *pgxpool.Pool) ports.ReportCardRepository {
+	return &PostgresReportCardRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresReportCardRepository) Create(ctx context.Context, reportCards []*entities.ReportCard) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	for _, reportCard := range reportCards {
+		var pgID, pgTermID pgtype.UUID
+		if err := pgID.Scan(reportCard.ID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+		if err := pgTermID.Scan(reportCard.TermID); err != nil {
+			return appErrors.PropagateError(err)
+		}
+
+		err := qtx.CreateReportCard(ctx, db.CreateReportCardParams{
+			ID:              pgID,
+			TenantID:        reportCard.TenantID,
+			StudentID:       reportCard.StudentID,
+			TermID:          pgTermID,
+			Status:          string(reportCard.Status),
+			NotifyGuardians: reportCard.NotifyGuardians,
+			Secret:          reportCard.Secret,
+			RequestedBy:     reportCard.RequestedBy,
+			CreatedAt:       pgtype.Timestamptz{Time: reportCard.CreatedAt, Valid: true},
+			UpdatedAt:       pgtype.Timestamptz{Time: reportCard.UpdatedAt, Valid: true},
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresReportCardRepository) FindByID(ctx context.Context, tenantID string, reportCardID string) (*entities.ReportCard, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(reportCardID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetReportCard(ctx, db.GetReportCardParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toReportCard(db.ClaimNextPendingReportCardRow(row))
+}
+
+func (r *PostgresReportCardRepository) FindForDownload(ctx context.Context, reportCardID string) (*entities.ReportCard, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(reportCardID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetReportCardForDownload(ctx, pgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toReportCard(db.ClaimNextPendingReportCardRow(row))
+}
+
+func (r *PostgresReportCardRepository) FindArtifact(ctx context.Context, tenantID string, reportCardID string) (*entities.Artifact, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(reportCardID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetReportCardArtifact(ctx, db.GetReportCardArtifactParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &entities.Artifact{
+		FileName:    valueOrEmpty(row.ArtifactName),
+		ContentType: "application/pdf",
+		Content:     row.Artifact,
+	}, nil
+}
+
+func (r *PostgresReportCardRepository) ClaimNextPending(ctx context.Context, staleAfter time.Duration) (*entities.ReportCard, error) {
+	row, err := r.queries.ClaimNextPendingReportCard(ctx, pgtype.Timestamptz{Time: time.Now().Add(-staleAfter), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toReportCard(row)
+}
+
+func (r *PostgresReportCardRepository) MarkReady(ctx context.Context, reportCard *entities.ReportCard, artifact *entities.Artifact,
+	deliveries []*entities.ReportCardDelivery) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(reportCard.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	expiresAt := pgtype.Timestamptz{}
+	if reportCard.ExpiresAt != nil {
+		expiresAt = pgtype.Timestamptz{Time: *reportCard.ExpiresAt, Valid: true}
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.MarkReportCardReady(ctx, db.MarkReportCardReadyParams{
+		ArtifactName: &artifact.FileName,
+		Artifact:     artifact.Content,
+		ExpiresAt:    expiresAt,
+		ID:           pgID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	for _, delivery := range deliveries {
+		payload, err := json.Marshal(map[string]any{
+			"report_card_id":  delivery.ReportCardID,
+			"student_id":      delivery.StudentID,
+			"guardian_id":     delivery.GuardianID,
+			"term_id":         delivery.TermID,
+			"term_name":       delivery.TermName,
+			"download_url":    delivery.Link.Path,
+			"link_expires_at": delivery.Link.ExpiresAt.Format(time.RFC3339),
+		})
+		if err != nil {
+			return appErrors.NewInfrastructureError("failed to serialize report card delivery event", err)
+		}
+
+		err = qtx.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+			EventType:   events.ReportCardReady,
+			AggregateID: reportCard.ID,
+			TenantID:    reportCard.TenantID,
+			Payload:     payload,
+			OccurredAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		})
+		if err != nil {
+			return appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresReportCardRepository) MarkFailed(ctx context.Context, reportCardID string, reason string) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(reportCardID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := r.queries.MarkReportCardFailed(ctx, db.MarkReportCardFailedParams{ID: pgID, FailureReason: &reason}); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresReportCardRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	deleted, err := r.queries.DeleteExpiredReportCards(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+
+	return deleted, nil
+}
+
+// toReportCard maps any of the report card queries, they all select the same columns
+func toReportCard(row db.ClaimNextPendingReportCardRow) (*entities.ReportCard, error) {
+	var expiresAt *time.Time
+	if row.ExpiresAt.Valid {
+		expiresAt = &row.ExpiresAt.Time
+	}
+
+	return entities.NewReportCard(
+		row.ID.String(),
+		row.TenantID,
+		row.StudentID,
+		row.TermID.String(),
+		entities.ReportCardStatus(row.Status),
+		valueOrEmpty(row.FailureReason),
+		row.NotifyGuardians,
+		row.Secret,
+		row.RequestedBy,
+		expiresAt,
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+	)
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
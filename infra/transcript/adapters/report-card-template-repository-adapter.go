@@ -0,0 +1,62 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresReportCardTemplateRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresReportCardTemplateRepository(dbInstance *pgxpool.Pool) ports.ReportCardTemplateRepository {
+	return &PostgresReportCardTemplateRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresReportCardTemplateRepository) Find(ctx context.Context, tenantID string) (*entities.ReportCardTemplate, error) {
+	row, err := r.queries.GetReportCardTemplate(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	// Saved templates rendered when they were saved, they are not checked again on every read
+	return &entities.ReportCardTemplate{
+		TenantID:  row.TenantID,
+		Title:     row.Title,
+		Header:    row.Header,
+		Footer:    row.Footer,
+		UpdatedBy: row.UpdatedBy,
+		UpdatedAt: row.UpdatedAt.Time,
+	}, nil
+}
+
+func (r *PostgresReportCardTemplateRepository) Save(ctx context.Context, template *entities.ReportCardTemplate) error {
+	err := r.queries.UpsertReportCardTemplate(ctx, db.UpsertReportCardTemplateParams{
+		TenantID:  template.TenantID,
+		Title:     template.Title,
+		Header:    template.Header,
+		Footer:    template.Footer,
+		UpdatedBy: template.UpdatedBy,
+		UpdatedAt: pgtype.Timestamptz{Time: template.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
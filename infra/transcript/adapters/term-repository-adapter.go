@@ -0,0 +1,95 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTermRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresTermRepository(dbInstance *pgxpool.Pool) ports.TermRepository {
+	return &PostgresTermRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresTermRepository) Create(ctx context.Context, term *entities.Term) error {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(term.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	err := r.queries.CreateTerm(ctx, db.CreateTermParams{
+		ID:        pgID,
+		TenantID:  term.TenantID,
+		Name:      term.Name,
+		StartsOn:  pgtype.Date{Time: term.StartsOn, Valid: true},
+		EndsOn:    pgtype.Date{Time: term.EndsOn, Valid: true},
+		CreatedBy: term.CreatedBy,
+		CreatedAt: pgtype.Timestamptz{Time: term.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresTermRepository) FindByID(ctx context.Context, tenantID string, termID string) (*entities.Term, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(termID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetTerm(ctx, db.GetTermParams{ID: pgID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toTerm(row)
+}
+
+func (r *PostgresTermRepository) List(ctx context.Context, tenantID string) ([]*entities.Term, error) {
+	rows, err := r.queries.ListTerms(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	terms := make([]*entities.Term, 0, len(rows))
+	for _, row := range rows {
+		term, err := toTerm(row)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+
+	return terms, nil
+}
+
+func toTerm(row db.Term) (*entities.Term, error) {
+	return entities.NewTerm(
+		row.ID.String(),
+		row.TenantID,
+		row.Name,
+		row.StartsOn.Time,
+		row.EndsOn.Time,
+		row.CreatedBy,
+		row.CreatedAt.Time,
+	)
+}
@@ -0,0 +1,84 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	gradingEntities "github.com/nahualventure/class-backend/core/app/grading/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresTranscriptSource reads the grades, users and guardian links of the other modules
+type PostgresTranscriptSource struct {
+	queries *db.Queries
+}
+
+func NewPostgresTranscriptSource(dbInstance *pgxpool.Pool) ports.TranscriptSource {
+	return &PostgresTranscriptSource{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresTranscriptSource) ReleasedGrades(ctx context.Context, tenantID string, studentID string) ([]entities.TranscriptGrade, error) {
+	rows, err := s.queries.ListReleasedGradesForTranscript(ctx, db.ListReleasedGradesForTranscriptParams{
+		TenantID:  tenantID,
+		StudentID: studentID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	grades := make([]entities.TranscriptGrade, 0, len(rows))
+	for _, row := range rows {
+		if !row.ReleasedAt.Valid {
+			continue
+		}
+		grades = append(grades, entities.TranscriptGrade{
+			ClassID:      row.ClassID,
+			AssignmentID: row.AssignmentID,
+			FinalScore:   gradingEntities.AdjustScore(row.Score, row.LatePenaltyPercent),
+			Dropped:      row.Dropped,
+			ReleasedAt:   row.ReleasedAt.Time,
+		})
+	}
+
+	return grades, nil
+}
+
+func (s *PostgresTranscriptSource) StudentName(ctx context.Context, studentID string) (string, error) {
+	var pgID pgtype.UUID
+	if err := pgID.Scan(studentID); err != nil {
+		// Student IDs of other identity providers are not user IDs
+		return "", nil
+	}
+
+	name, err := s.queries.GetStudentName(ctx, pgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", appErrors.PropagateError(err)
+	}
+
+	return name, nil
+}
+
+func (s *PostgresTranscriptSource) GuardiansOf(ctx context.Context, tenantID string, studentID string) ([]string, error) {
+	guardianIDs, err := s.queries.ListGuardiansOfStudent(ctx, db.ListGuardiansOfStudentParams{
+		TenantID:  tenantID,
+		StudentID: studentID,
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return guardianIDs, nil
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/transcript/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+)
+
+type TermResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	StartsOn  string    `json:"starts_on" format:"date"`
+	EndsOn    string    `json:"ends_on" format:"date" doc:"Last day of the term"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewTermResponse(term *entities.Term) TermResponse {
+	return TermResponse{
+		ID:        term.ID,
+		Name:      term.Name,
+		StartsOn:  term.StartsOn.Format(time.DateOnly),
+		EndsOn:    term.EndsOn.Format(time.DateOnly),
+		CreatedBy: term.CreatedBy,
+		CreatedAt: term.CreatedAt,
+	}
+}
+
+type CreateTermRequest struct {
+	Body struct {
+		Name     string `json:"name" maxLength:"100"`
+		StartsOn string `json:"starts_on" format:"date"`
+		EndsOn   string `json:"ends_on" format:"date" doc:"Last day of the term, terms of a tenant never share a day"`
+	}
+}
+
+type TermEnvelope struct {
+	Body TermResponse
+}
+
+type ListTermsResponse struct {
+	Body struct {
+		Items []TermResponse `json:"items"`
+	}
+}
+
+type ClassRecordResponse struct {
+	ClassID    string  `json:"class_id"`
+	GradeCount int     `json:"grade_count"`
+	Average    float64 `json:"average"`
+}
+
+type TermRecordResponse struct {
+	Term              TermResponse          `json:"term"`
+	Classes           []ClassRecordResponse `json:"classes"`
+	Average           float64               `json:"average" doc:"Average of the class averages of the term"`
+	CumulativeAverage float64               `json:"cumulative_average" doc:"Average of the class averages of the term and every earlier term"`
+}
+
+type GetTranscriptRequest struct {
+	StudentID string `path:"studentId" doc:"Student ID"`
+}
+
+type TranscriptResponse struct {
+	Body struct {
+		StudentID string               `json:"student_id"`
+		Terms     []TermRecordResponse `json:"terms" doc:"Terms the student has released grades in, oldest first"`
+		Average   float64              `json:"average"`
+	}
+}
+
+func NewTranscriptResponse(transcript *entities.Transcript) *TranscriptResponse {
+	response := &TranscriptResponse{}
+	response.Body.StudentID = transcript.StudentID
+	response.Body.Average = transcript.Average
+	response.Body.Terms = make([]TermRecordResponse, 0, len(transcript.Terms))
+	for _, record := range transcript.Terms {
+		classes := make([]ClassRecordResponse, 0, len(record.Classes))
+		for _, class := range record.Classes {
+			classes = append(classes, ClassRecordResponse{
+				ClassID:    class.ClassID,
+				GradeCount: class.GradeCount,
+				Average:    class.Average,
+			})
+		}
+		response.Body.Terms = append(response.Body.Terms, TermRecordResponse{
+			Term:              NewTermResponse(record.Term),
+			Classes:           classes,
+			Average:           record.Average,
+			CumulativeAverage: record.CumulativeAverage,
+		})
+	}
+	return response
+}
+
+type ReportCardTemplateResponse struct {
+	Title     string     `json:"title"`
+	Header    string     `json:"header"`
+	Footer    string     `json:"footer"`
+	Default   bool       `json:"default" doc:"Whether the tenant never saved its template"`
+	Variables []string   `json:"variables" doc:"Variables the title, header and footer can use, as in {{.student_name}}"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+func NewReportCardTemplateResponse(template *entities.ReportCardTemplate) ReportCardTemplateResponse {
+	response := ReportCardTemplateResponse{
+		Title:     template.Title,
+		Header:    template.Header,
+		Footer:    template.Footer,
+		Default:   template.IsDefault(),
+		Variables: entities.ReportCardVariables(),
+	}
+	if !template.IsDefault() {
+		response.UpdatedBy = template.UpdatedBy
+		response.UpdatedAt = &template.UpdatedAt
+	}
+	return response
+}
+
+type SaveReportCardTemplateRequest struct {
+	Body struct {
+		Title  string `json:"title" maxLength:"255" doc:"Go template, for example Report card - {{.term_name}}"`
+		Header string `json:"header,omitempty" maxLength:"2000" doc:"Go template printed above the table of classes"`
+		Footer string `json:"footer,omitempty" maxLength:"2000" doc:"Go template printed below the table of classes"`
+	}
+}
+
+type ReportCardTemplateEnvelope struct {
+	Body ReportCardTemplateResponse
+}
+
+type ReportCardResponse struct {
+	ID              string     `json:"id"`
+	StudentID       string     `json:"student_id"`
+	TermID          string     `json:"term_id"`
+	Status          string     `json:"status" enum:"pending,running,ready,failed"`
+	FailureReason   string     `json:"failure_reason,omitempty"`
+	NotifyGuardians bool       `json:"notify_guardians"`
+	RequestedBy     string     `json:"requested_by"`
+	DownloadURL     string     `json:"download_url,omitempty" doc:"Signed path the PDF is downloaded from without signing in, set once the report card is ready"`
+	LinkExpiresAt   *time.Time `json:"link_expires_at,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty" doc:"When the PDF is deleted, set once the report card is ready"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func NewReportCardResponse(reportCard *entities.ReportCard, now time.Time) ReportCardResponse {
+	response := ReportCardResponse{
+		ID:              reportCard.ID,
+		StudentID:       reportCard.StudentID,
+		TermID:          reportCard.TermID,
+		Status:          string(reportCard.Status),
+		FailureReason:   reportCard.FailureReason,
+		NotifyGuardians: reportCard.NotifyGuardians,
+		RequestedBy:     reportCard.RequestedBy,
+		ExpiresAt:       reportCard.ExpiresAt,
+		CreatedAt:       reportCard.CreatedAt,
+		UpdatedAt:       reportCard.UpdatedAt,
+	}
+	if link := reportCard.DownloadLink(now); link != nil {
+		response.DownloadURL = link.Path
+		response.LinkExpiresAt = &link.ExpiresAt
+	}
+	return response
+}
+
+type RequestReportCardsRequest struct {
+	TermID string `path:"termId" format:"uuid" doc:"Term ID"`
+	Body   struct {
+		StudentIDs      []string `json:"student_ids" minItems:"1" maxItems:"500" uniqueItems:"true"`
+		NotifyGuardians bool     `json:"notify_guardians,omitempty" doc:"Send the download link to the guardians of each student once the report card is ready"`
+	}
+}
+
+type RequestReportCardsResponse struct {
+	Body struct {
+		Items []ReportCardResponse `json:"items"`
+	}
+}
+
+type GetReportCardRequest struct {
+	ReportCardID string `path:"reportCardId" format:"uuid" doc:"Report card ID"`
+}
+
+type ReportCardEnvelope struct {
+	Body ReportCardResponse
+}
+
+type DownloadReportCardRequest struct {
+	ReportCardID string `path:"reportCardId" format:"uuid" doc:"Report card ID"`
+	Expires      int64  `query:"expires" required:"true" doc:"Unix time the link expires at"`
+	Signature    string `query:"signature" required:"true" maxLength:"100"`
+	export.RangeRequest
+}
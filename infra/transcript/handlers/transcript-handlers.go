@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	create_term_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/create-term-use-case"
+	download_report_card_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/download-report-card-use-case"
+	get_report_card_template_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/get-report-card-template-use-case"
+	get_report_card_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/get-report-card-use-case"
+	get_transcript_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/get-transcript-use-case"
+	list_terms_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/list-terms-use-case"
+	request_report_cards_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/request-report-cards-use-case"
+	save_report_card_template_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/save-report-card-template-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type TranscriptHandlers struct {
+	createTermUseCase             *create_term_use_case.CreateTermUseCase
+	listTermsUseCase              *list_terms_use_case.ListTermsUseCase
+	getTranscriptUseCase          *get_transcript_use_case.GetTranscriptUseCase
+	getReportCardTemplateUseCase  *get_report_card_template_use_case.GetReportCardTemplateUseCase
+	saveReportCardTemplateUseCase *save_report_card_template_use_case.SaveReportCardTemplateUseCase
+	requestReportCardsUseCase     *request_report_cards_use_case.RequestReportCardsUseCase
+	getReportCardUseCase          *get_report_card_use_case.GetReportCardUseCase
+	downloadReportCardUseCase     *download_report_card_use_case.DownloadReportCardUseCase
+}
+
+func NewTranscriptHandlers(
+	createTermUseCase *create_term_use_case.CreateTermUseCase,
+	listTermsUseCase *list_terms_use_case.ListTermsUseCase,
+	getTranscriptUseCase *get_transcript_use_case.GetTranscriptUseCase,
+	getReportCardTemplateUseCase *get_report_card_template_use_case.GetReportCardTemplateUseCase,
+	saveReportCardTemplateUseCase *save_report_card_template_use_case.SaveReportCardTemplateUseCase,
+	requestReportCardsUseCase *request_report_cards_use_case.RequestReportCardsUseCase,
+	getReportCardUseCase *get_report_card_use_case.GetReportCardUseCase,
+	downloadReportCardUseCase *download_report_card_use_case.DownloadReportCardUseCase,
+) *TranscriptHandlers {
+	return &TranscriptHandlers{
+		createTermUseCase:             createTermUseCase,
+		listTermsUseCase:              listTermsUseCase,
+		getTranscriptUseCase:          getTranscriptUseCase,
+		getReportCardTemplateUseCase:  getReportCardTemplateUseCase,
+		saveReportCardTemplateUseCase: saveReportCardTemplateUseCase,
+		requestReportCardsUseCase:     requestReportCardsUseCase,
+		getReportCardUseCase:          getReportCardUseCase,
+		downloadReportCardUseCase:     downloadReportCardUseCase,
+	}
+}
+
+func (h *TranscriptHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-term",
+		Method:        http.MethodPost,
+		Path:          "/terms",
+		Summary:       "Create a term",
+		Description:   "Terms group released grades in transcripts and report cards. Terms of a tenant never share a day.",
+		Tags:          []string{"Transcripts"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateTerm)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-terms",
+		Method:      http.MethodGet,
+		Path:        "/terms",
+		Summary:     "List terms of the tenant",
+		Tags:        []string{"Transcripts"},
+	}, h.ListTerms)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-transcript",
+		Method:      http.MethodGet,
+		Path:        "/students/{studentId}/transcript",
+		Summary:     "Get the transcript of a student",
+		Description: "Averages the released grades of the student by class and term, archived grades included. Dropped grades are left out.",
+		Tags:        []string{"Transcripts"},
+	}, h.GetTranscript)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-report-card-template",
+		Method:      http.MethodGet,
+		Path:        "/report-card-template",
+		Summary:     "Get the report card template of the tenant",
+		Tags:        []string{"Transcripts"},
+	}, h.GetReportCardTemplate)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "save-report-card-template",
+		Method:      http.MethodPut,
+		Path:        "/report-card-template",
+		Summary:     "Save the report card template of the tenant",
+		Description: "The template is checked against the report card variables before it is saved. Report cards already generated are not affected.",
+		Tags:        []string{"Transcripts"},
+	}, h.SaveReportCardTemplate)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "request-report-cards",
+		Method:        http.MethodPost,
+		Path:          "/terms/{termId}/report-cards",
+		Summary:       "Request report cards for a term",
+		Description:   "Queues a PDF report card per student. Poll each report card until it is ready, then download it from its download_url.",
+		Tags:          []string{"Transcripts"},
+		DefaultStatus: http.StatusAccepted,
+	}, h.RequestReportCards)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-report-card",
+		Method:      http.MethodGet,
+		Path:        "/report-cards/{reportCardId}",
+		Summary:     "Get report card status",
+		Tags:        []string{"Transcripts"},
+	}, h.GetReportCard)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "download-report-card",
+		Method:      http.MethodGet,
+		Path:        "/report-cards/{reportCardId}/download",
+		Summary:     "Download a report card through a signed link",
+		Description: "Works without signing in, the signature of the link authorizes the download until the link expires.",
+		Tags:        []string{"Transcripts"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Report card PDF",
+				Content:     map[string]*huma.MediaType{"application/pdf": {}},
+			},
+		},
+	}, h.DownloadReportCard)
+}
+
+func (h *TranscriptHandlers) CreateTerm(ctx context.Context, input *CreateTermRequest) (*TermEnvelope, error) {
+	startsOn, err := time.Parse(time.DateOnly, input.Body.StartsOn)
+	if err != nil {
+		return nil, huma.Error400BadRequest("starts_on must be a date like 2025-08-18")
+	}
+	endsOn, err := time.Parse(time.DateOnly, input.Body.EndsOn)
+	if err != nil {
+		return nil, huma.Error400BadRequest("ends_on must be a date like 2025-12-19")
+	}
+
+	command, err := create_term_use_case.NewCreateTermCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Body.Name,
+		startsOn,
+		endsOn,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	term, err := h.createTermUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &TermEnvelope{Body: NewTermResponse(term)}, nil
+}
+
+func (h *TranscriptHandlers) ListTerms(ctx context.Context, input *struct{}) (*ListTermsResponse, error) {
+	command, err := list_terms_use_case.NewListTermsCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	terms, err := h.listTermsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	response := &ListTermsResponse{}
+	response.Body.Items = make([]TermResponse, 0, len(terms))
+	for _, term := range terms {
+		response.Body.Items = append(response.Body.Items, NewTermResponse(term))
+	}
+
+	return response, nil
+}
+
+func (h *TranscriptHandlers) GetTranscript(ctx context.Context, input *GetTranscriptRequest) (*TranscriptResponse, error) {
+	command, err := get_transcript_use_case.NewGetTranscriptCommand(authorization.TenantIDFromContext(ctx), input.StudentID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	transcript, err := h.getTranscriptUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewTranscriptResponse(transcript), nil
+}
+
+func (h *TranscriptHandlers) GetReportCardTemplate(ctx context.Context, input *struct{}) (*ReportCardTemplateEnvelope, error) {
+	command, err := get_report_card_template_use_case.NewGetReportCardTemplateCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	template, err := h.getReportCardTemplateUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ReportCardTemplateEnvelope{Body: NewReportCardTemplateResponse(template)}, nil
+}
+
+func (h *TranscriptHandlers) SaveReportCardTemplate(ctx context.Context, input *SaveReportCardTemplateRequest) (*ReportCardTemplateEnvelope, error) {
+	command, err := save_report_card_template_use_case.NewSaveReportCardTemplateCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Body.Title,
+		input.Body.Header,
+		input.Body.Footer,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	template, err := h.saveReportCardTemplateUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ReportCardTemplateEnvelope{Body: NewReportCardTemplateResponse(template)}, nil
+}
+
+func (h *TranscriptHandlers) RequestReportCards(ctx context.Context, input *RequestReportCardsRequest) (*RequestReportCardsResponse, error) {
+	command, err := request_report_cards_use_case.NewRequestReportCardsCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.TermID,
+		input.Body.StudentIDs,
+		input.Body.NotifyGuardians,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	reportCards, err := h.requestReportCardsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	now := time.Now()
+	response := &RequestReportCardsResponse{}
+	response.Body.Items = make([]ReportCardResponse, 0, len(reportCards))
+	for _, reportCard := range reportCards {
+		response.Body.Items = append(response.Body.Items, NewReportCardResponse(reportCard, now))
+	}
+
+	return response, nil
+}
+
+func (h *TranscriptHandlers) GetReportCard(ctx context.Context, input *GetReportCardRequest) (*ReportCardEnvelope, error) {
+	command, err := get_report_card_use_case.NewGetReportCardCommand(authorization.TenantIDFromContext(ctx), input.ReportCardID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	reportCard, err := h.getReportCardUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &ReportCardEnvelope{Body: NewReportCardResponse(reportCard, time.Now())}, nil
+}
+
+func (h *TranscriptHandlers) DownloadReportCard(ctx context.Context, input *DownloadReportCardRequest) (*huma.StreamResponse, error) {
+	command, err := download_report_card_use_case.NewDownloadReportCardCommand(input.ReportCardID, input.Expires, input.Signature)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	artifact, err := h.downloadReportCardUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return export.FileDownload{
+		Filename:    artifact.FileName,
+		ContentType: artifact.ContentType,
+		Content:     artifact.Content,
+	}.Response(input.RangeRequest), nil
+}
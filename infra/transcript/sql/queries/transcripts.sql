@@ -0,0 +1,104 @@
+-- name: CreateTerm :exec
+INSERT INTO terms (id, tenant_id, name, starts_on, ends_on, created_by, created_at)
+VALUES (@id, @tenant_id, @name, @starts_on, @ends_on, @created_by, @created_at);
+
+-- name: GetTerm :one
+SELECT id, tenant_id, name, starts_on, ends_on, created_by, created_at
+FROM terms
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- name: ListTerms :many
+SELECT id, tenant_id, name, starts_on, ends_on, created_by, created_at
+FROM terms
+WHERE tenant_id = @tenant_id
+ORDER BY starts_on;
+
+-- name: GetReportCardTemplate :one
+SELECT tenant_id, title, header, footer, updated_by, updated_at
+FROM report_card_templates
+WHERE tenant_id = @tenant_id;
+
+-- name: UpsertReportCardTemplate :exec
+INSERT INTO report_card_templates (tenant_id, title, header, footer, updated_by, updated_at)
+VALUES (@tenant_id, @title, @header, @footer, @updated_by, @updated_at)
+ON CONFLICT (tenant_id) DO UPDATE
+SET title = EXCLUDED.title,
+    header = EXCLUDED.header,
+    footer = EXCLUDED.footer,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: CreateReportCard :exec
+INSERT INTO report_cards (id, tenant_id, student_id, term_id, status, notify_guardians, secret, requested_by, created_at, updated_at)
+VALUES (@id, @tenant_id, @student_id, @term_id, @status, @notify_guardians, @secret, @requested_by, @created_at, @updated_at);
+
+-- name: GetReportCard :one
+SELECT id, tenant_id, student_id, term_id, status, failure_reason, notify_guardians, secret, requested_by, expires_at, created_at, updated_at
+FROM report_cards
+WHERE id = @id AND tenant_id = @tenant_id;
+
+-- Download links carry no tenant, their signature is checked against the secret instead
+-- name: GetReportCardForDownload :one
+SELECT id, tenant_id, student_id, term_id, status, failure_reason, notify_guardians, secret, requested_by, expires_at, created_at, updated_at
+FROM report_cards
+WHERE id = @id;
+
+-- name: GetReportCardArtifact :one
+SELECT artifact_name, artifact
+FROM report_cards
+WHERE id = @id AND tenant_id = @tenant_id AND status = 'ready';
+
+-- name: ClaimNextPendingReportCard :one
+UPDATE report_cards
+SET status = 'running', updated_at = NOW()
+WHERE id = (
+    SELECT r.id
+    FROM report_cards r
+    WHERE r.status = 'pending' OR (r.status = 'running' AND r.updated_at < @stale_before)
+    ORDER BY r.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, tenant_id, student_id, term_id, status, failure_reason, notify_guardians, secret, requested_by, expires_at, created_at, updated_at;
+
+-- name: MarkReportCardReady :exec
+UPDATE report_cards
+SET status = 'ready',
+    artifact_name = @artifact_name,
+    artifact = @artifact,
+    expires_at = @expires_at,
+    updated_at = NOW()
+WHERE id = @id;
+
+-- name: MarkReportCardFailed :exec
+UPDATE report_cards
+SET status = 'failed', failure_reason = @failure_reason, updated_at = NOW()
+WHERE id = @id;
+
+-- name: DeleteExpiredReportCards :execrows
+DELETE FROM report_cards
+WHERE expires_at < @now;
+
+-- Released grades of the student, the ones archived with prior school years included
+-- name: ListReleasedGradesForTranscript :many
+SELECT g.class_id::text AS class_id, g.assignment_id::text AS assignment_id, g.score, g.late_penalty_percent, g.dropped, g.released_at
+FROM grades g
+WHERE g.tenant_id = @tenant_id AND g.student_id = @student_id AND g.status = 'released'
+UNION ALL
+SELECT a.payload->>'class_id', a.payload->>'assignment_id', (a.payload->>'score')::double precision,
+       (a.payload->>'late_penalty_percent')::double precision, COALESCE((a.payload->>'dropped')::boolean, false),
+       (a.payload->>'released_at')::timestamptz
+FROM archived_records a
+WHERE a.kind = 'submissions' AND a.tenant_id = @tenant_id AND a.payload->>'student_id' = @student_id
+  AND a.payload->>'status' = 'released';
+
+-- name: GetStudentName :one
+SELECT name
+FROM users
+WHERE id = @id;
+
+-- name: ListGuardiansOfStudent :many
+SELECT guardian_id
+FROM guardian_links
+WHERE tenant_id = @tenant_id AND student_id = @student_id
+ORDER BY guardian_id;
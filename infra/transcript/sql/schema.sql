@@ -0,0 +1,45 @@
+-- Grading periods of a tenant, terms of a tenant never share a day. Transcripts group released grades
+-- by the term they were released in.
+CREATE TABLE terms (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL,                 -- last day of the term
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_terms_tenant ON terms(tenant_id, starts_on);
+
+-- Report card templates tenants customized, tenants without a row use the default template
+CREATE TABLE report_card_templates (
+    tenant_id VARCHAR(255) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    header TEXT NOT NULL,
+    footer TEXT NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Async report card requests and their generated PDF. secret signs the download links, which work
+-- without signing in.
+CREATE TABLE report_cards (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    student_id VARCHAR(255) NOT NULL,
+    term_id UUID NOT NULL REFERENCES terms(id),
+    status VARCHAR(20) NOT NULL,           -- pending, running, ready, failed
+    failure_reason TEXT,
+    notify_guardians BOOLEAN NOT NULL,
+    secret BYTEA NOT NULL,
+    requested_by VARCHAR(255) NOT NULL,
+    artifact_name VARCHAR(255),
+    artifact BYTEA,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_report_cards_status ON report_cards(status, created_at);
+CREATE INDEX idx_report_cards_expires_at ON report_cards(expires_at);
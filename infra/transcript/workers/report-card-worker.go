@@ -0,0 +1,41 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	generate_report_card_use_case "github.com/nahualventure/class-backend/core/app/transcript/application/use-cases/generate-report-card-use-case"
+)
+
+// RunReportCardWorker drains the report card queue every interval and purges expired report cards, it
+// returns when ctx is cancelled. Several instances can run concurrently, report cards are claimed with
+// SKIP LOCKED.
+func RunReportCardWorker(ctx context.Context, useCase *generate_report_card_use_case.GenerateReportCardUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			processed, err := useCase.Execute(ctx)
+			if err != nil {
+				log.Printf("Report card generation failed: %v", err)
+			}
+			if !processed {
+				break
+			}
+		}
+
+		if deleted, err := useCase.PurgeExpired(ctx); err != nil {
+			log.Printf("Failed to purge expired report cards: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Purged %d expired report cards", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/cache"
+)
+
+// CachedIdentityRepository caches identities for the identity middleware, which reads them on every
+// authenticated request. Role changes through the Casbin service and profile updates call Invalidate
+// so they take effect at once on this instance, other instances catch up within the TTL.
+type CachedIdentityRepository struct {
+	next  ports.IdentityRepository
+	cache *cache.TTLCache[string, *entities.Identity]
+}
+
+func NewCachedIdentityRepository(next ports.IdentityRepository, ttl time.Duration) *CachedIdentityRepository {
+	return &CachedIdentityRepository{
+		next:  next,
+		cache: cache.NewTTLCache[string, *entities.Identity](ttl, 50_000),
+	}
+}
+
+func (r *CachedIdentityRepository) FindIdentity(ctx context.Context, userID string) (*entities.Identity, error) {
+	if identity, ok := r.cache.Lookup(ctx, userID); ok {
+		return identity, nil
+	}
+
+	identity, err := r.next.FindIdentity(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(userID, identity)
+	return identity, nil
+}
+
+// Invalidate drops the cached identity of the user
+func (r *CachedIdentityRepository) Invalidate(userID string) {
+	r.cache.Delete(userID)
+}
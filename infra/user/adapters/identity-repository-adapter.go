@@ -0,0 +1,47 @@
+package adapters
+
+import (
+	"context"
+	"sort"
+
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+)
+
+// CasbinIdentityRepository combines the user record with the tenant roles stored in Casbin
+type CasbinIdentityRepository struct {
+	users         ports.UserReader
+	casbinService *authorization.CasbinService
+}
+
+func NewCasbinIdentityRepository(users ports.UserReader, casbinService *authorization.CasbinService) ports.IdentityRepository {
+	return &CasbinIdentityRepository{
+		users:         users,
+		casbinService: casbinService,
+	}
+}
+
+func (r *CasbinIdentityRepository) FindIdentity(ctx context.Context, userID string) (*entities.Identity, error) {
+	users, err := r.users.FindByIDs([]string{userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	tenantRoles, authzErr := r.casbinService.GetUserTenantRoles(userID)
+	if authzErr != nil {
+		return nil, authzErr
+	}
+
+	memberships := make([]entities.TenantMembership, 0, len(tenantRoles))
+	for tenantID, roles := range tenantRoles {
+		sort.Strings(roles)
+		memberships = append(memberships, entities.TenantMembership{TenantID: tenantID, Roles: roles})
+	}
+	sort.Slice(memberships, func(i, j int) bool { return memberships[i].TenantID < memberships[j].TenantID })
+
+	return &entities.Identity{User: users[0], Memberships: memberships}, nil
+}
@@ -0,0 +1,197 @@
+package adapters
+
+import (
+	"crypto/md5"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MultiSchemePasswordHasher hashes with bcrypt and also verifies the hashes of the systems users are
+// imported from: Django PBKDF2 (pbkdf2_sha256 and pbkdf2_sha1) and phpass portable hashes ($P$ and
+// $H$, WordPress and phpBB). Every hash that is not a bcrypt hash of the current cost needs a rehash.
+type MultiSchemePasswordHasher struct {
+	cost int
+}
+
+func NewMultiSchemePasswordHasher() ports.PasswordHasher {
+	return &MultiSchemePasswordHasher{cost: bcrypt.DefaultCost}
+}
+
+// hashPassword is the scheme passwords are stored with, the repository hashes new users with it
+func hashPassword(password string, cost int) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		return "", appErrors.PropagateError(err)
+	}
+	return string(hashed), nil
+}
+
+func (h *MultiSchemePasswordHasher) Hash(password string) (string, error) {
+	return hashPassword(password, h.cost)
+}
+
+func (h *MultiSchemePasswordHasher) Recognizes(hash string) bool {
+	switch {
+	case isBcrypt(hash):
+		_, err := bcrypt.Cost([]byte(hash))
+		return err == nil
+	case strings.HasPrefix(hash, "pbkdf2_"):
+		_, _, _, _, err := parseDjangoPBKDF2(hash)
+		return err == nil
+	case isPHPass(hash):
+		_, err := phpassRounds(hash)
+		return err == nil && len(hash) == phpassLength
+	}
+	return false
+}
+
+func (h *MultiSchemePasswordHasher) Verify(password string, hash string) (bool, bool, error) {
+	switch {
+	case isBcrypt(hash):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, false, nil
+		}
+		if err != nil {
+			return false, false, appErrors.PropagateError(err)
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		if err != nil {
+			return false, false, appErrors.PropagateError(err)
+		}
+		return true, cost < h.cost, nil
+	case strings.HasPrefix(hash, "pbkdf2_"):
+		matches, err := verifyDjangoPBKDF2(password, hash)
+		return matches, matches, err
+	case isPHPass(hash):
+		matches, err := verifyPHPass(password, hash)
+		return matches, matches, err
+	}
+	return false, false, appErrors.NewInfrastructureError("unsupported password hash scheme", nil)
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+// Django stores <algorithm>$<iterations>$<salt>$<base64 of the derived key>, the key is as long as
+// the digest of the algorithm
+func parseDjangoPBKDF2(encoded string) (func() hash.Hash, int, string, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 4 {
+		return nil, 0, "", nil, fmt.Errorf("malformed django pbkdf2 hash")
+	}
+
+	var digest func() hash.Hash
+	switch parts[0] {
+	case "pbkdf2_sha256":
+		digest = sha256.New
+	case "pbkdf2_sha1":
+		digest = sha1.New
+	default:
+		return nil, 0, "", nil, fmt.Errorf("unsupported django algorithm %q", parts[0])
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations < 1 {
+		return nil, 0, "", nil, fmt.Errorf("malformed django pbkdf2 iterations")
+	}
+	if parts[2] == "" {
+		return nil, 0, "", nil, fmt.Errorf("malformed django pbkdf2 salt")
+	}
+	key, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil || len(key) == 0 {
+		return nil, 0, "", nil, fmt.Errorf("malformed django pbkdf2 key")
+	}
+	return digest, iterations, parts[2], key, nil
+}
+
+func verifyDjangoPBKDF2(password string, encoded string) (bool, error) {
+	digest, iterations, salt, key, err := parseDjangoPBKDF2(encoded)
+	if err != nil {
+		return false, appErrors.NewInfrastructureError("invalid django password hash", err)
+	}
+	derived, err := pbkdf2.Key(digest, password, []byte(salt), iterations, len(key))
+	if err != nil {
+		return false, appErrors.NewInfrastructureError("failed to derive django password hash", err)
+	}
+	return subtle.ConstantTimeCompare(derived, key) == 1, nil
+}
+
+// phpass portable hashes are $P$ (or $H$ in phpBB), one character with the base 2 logarithm of the
+// rounds, 8 characters of salt and 22 of MD5 output, all in the itoa64 alphabet
+const (
+	phpassItoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	phpassLength = 34
+)
+
+func isPHPass(hash string) bool {
+	return strings.HasPrefix(hash, "$P$") || strings.HasPrefix(hash, "$H$")
+}
+
+func phpassRounds(hash string) (int, error) {
+	if len(hash) < 12 {
+		return 0, fmt.Errorf("malformed phpass hash")
+	}
+	log2 := strings.IndexByte(phpassItoa64, hash[3])
+	if log2 < 7 || log2 > 30 {
+		return 0, fmt.Errorf("malformed phpass rounds")
+	}
+	return 1 << log2, nil
+}
+
+func verifyPHPass(password string, hash string) (bool, error) {
+	rounds, err := phpassRounds(hash)
+	if err != nil || len(hash) != phpassLength {
+		return false, appErrors.NewInfrastructureError("invalid phpass password hash", err)
+	}
+
+	setting := hash[:12]
+	sum := md5.Sum([]byte(setting[4:12] + password))
+	for i := 0; i < rounds; i++ {
+		sum = md5.Sum(append(sum[:], password...))
+	}
+	computed := setting + phpassEncode64(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(hash)) == 1, nil
+}
+
+// phpassEncode64 is the encode64 of phpass, little endian groups of 3 bytes into 4 characters
+func phpassEncode64(input []byte) string {
+	var out strings.Builder
+	for i := 0; i < len(input); {
+		value := int(input[i])
+		i++
+		out.WriteByte(phpassItoa64[value&0x3f])
+		if i < len(input) {
+			value |= int(input[i]) << 8
+		}
+		out.WriteByte(phpassItoa64[(value>>6)&0x3f])
+		if i >= len(input) {
+			break
+		}
+		i++
+		if i < len(input) {
+			value |= int(input[i]) << 16
+		}
+		out.WriteByte(phpassItoa64[(value>>12)&0x3f])
+		if i >= len(input) {
+			break
+		}
+		i++
+		out.WriteByte(phpassItoa64[(value>>18)&0x3f])
+	}
+	return out.String()
+}
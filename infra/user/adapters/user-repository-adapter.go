@@ -3,10 +3,16 @@ package adapters
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+
 	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/filtering"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
 	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
 	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
-	db "github.com/nahualventure/class-backend/generated/sqlc"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -22,7 +28,7 @@ type PostgresUserRepository struct {
 func NewPostgresUserRepository(dbInstance *pgxpool.Pool) ports.UserRepository {
 	return &PostgresUserRepository{
 		db:      dbInstance,
-		queries: db.New(dbInstance),
+		queries: db.New(database.Reads.Wrap(dbInstance)),
 	}
 }
 
@@ -34,7 +40,7 @@ func (p PostgresUserRepository) Create(user *entities.User, password string) (*e
 		return nil, appErrors.PropagateError(err)
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := hashPassword(password, bcrypt.DefaultCost)
 	if err != nil {
 		return nil, appErrors.PropagateError(err)
 	}
@@ -43,7 +49,7 @@ func (p PostgresUserRepository) Create(user *entities.User, password string) (*e
 		ID:           pgUUID,
 		Name:         user.Name,
 		Email:        user.Email,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
 	})
 
 	if err != nil {
@@ -89,3 +95,261 @@ func (p PostgresUserRepository) FindByEmail(email string) (*entities.User, error
 		dbUser.UpdatedAt.Time,
 	)
 }
+
+func (p PostgresUserRepository) FindByIDs(ids []string) ([]*entities.User, error) {
+	ctx := context.Background()
+
+	// IDs of other systems, such as the placeholder identity headers, cannot be users
+	pgIDs := make([]pgtype.UUID, 0, len(ids))
+	for _, id := range ids {
+		var pgID pgtype.UUID
+		if err := pgID.Scan(id); err == nil {
+			pgIDs = append(pgIDs, pgID)
+		}
+	}
+	if len(pgIDs) == 0 {
+		return []*entities.User{}, nil
+	}
+
+	rows, err := p.queries.FindUsersByIDs(ctx, pgIDs)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	users := make([]*entities.User, 0, len(rows))
+	for _, row := range rows {
+		user, err := entities.NewUser(
+			row.ID.String(),
+			row.Name,
+			row.Email,
+			row.CreatedAt.Time,
+			row.UpdatedAt.Time,
+		)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (p PostgresUserRepository) CreateMany(users []ports.NewUserCredentials) ([]*entities.User, error) {
+	hashed := make([]ports.ImportedUserCredentials, 0, len(users))
+	for _, credentials := range users {
+		hashedPassword, err := hashPassword(credentials.Password, bcrypt.DefaultCost)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		hashed = append(hashed, ports.ImportedUserCredentials{User: credentials.User, PasswordHash: hashedPassword})
+	}
+
+	return p.ImportMany(hashed)
+}
+
+func (p PostgresUserRepository) ImportMany(users []ports.ImportedUserCredentials) ([]*entities.User, error) {
+	ctx := context.Background()
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := p.queries.WithTx(tx)
+	createdUsers := make([]*entities.User, 0, len(users))
+
+	for _, credentials := range users {
+		var pgUUID pgtype.UUID
+		if err := pgUUID.Scan(credentials.User.ID); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+
+		var externalID *string
+		if credentials.ExternalID != "" {
+			externalID = &credentials.ExternalID
+		}
+
+		dbUser, err := qtx.CreateUser(ctx, db.CreateUserParams{
+			ID:           pgUUID,
+			Name:         credentials.User.Name,
+			Email:        credentials.User.Email,
+			PasswordHash: credentials.PasswordHash,
+			ExternalID:   externalID,
+		})
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+
+		createdUser, err := entities.NewUser(
+			dbUser.ID.String(),
+			dbUser.Name,
+			dbUser.Email,
+			dbUser.CreatedAt.Time,
+			dbUser.UpdatedAt.Time,
+		)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		createdUsers = append(createdUsers, createdUser)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return createdUsers, nil
+}
+
+func (p PostgresUserRepository) FindCredentials(email string) (*entities.User, string, error) {
+	ctx := context.Background()
+	row, err := p.queries.FindCredentialsByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", nil
+		}
+		return nil, "", appErrors.PropagateError(err)
+	}
+
+	user, err := entities.NewUser(
+		row.ID.String(),
+		row.Name,
+		row.Email,
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+	)
+	if err != nil {
+		return nil, "", appErrors.PropagateError(err)
+	}
+	return user, row.PasswordHash, nil
+}
+
+func (p PostgresUserRepository) ReplacePasswordHash(userID string, oldHash string, newHash string) (bool, error) {
+	ctx := context.Background()
+
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(userID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	replaced, err := p.queries.ReplacePasswordHash(ctx, db.ReplacePasswordHashParams{
+		NewHash: newHash,
+		ID:      pgUUID,
+		OldHash: oldHash,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return replaced > 0, nil
+}
+
+func (p PostgresUserRepository) FindExistingEmails(emails []string) ([]string, error) {
+	ctx := context.Background()
+	lowered := make([]string, len(emails))
+	for i, email := range emails {
+		lowered[i] = strings.ToLower(email)
+	}
+	existing, err := p.queries.FindExistingEmails(ctx, lowered)
+
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return existing, nil
+}
+
+// userListColumns maps allowed order and filter fields to columns, identifiers cannot be parameterized
+var userListColumns = map[string]string{
+	"name":       "name",
+	"email":      "email",
+	"created_at": "created_at",
+}
+
+// userKeysetColumns are the sort fields with the types their cursor keys are cast to, id breaks ties
+var userKeysetColumns = map[string]database.KeysetColumn{
+	"name":       {Column: "name", Type: "varchar"},
+	"email":      {Column: "email", Type: "varchar"},
+	"created_at": {Column: "created_at", Type: "timestamptz"},
+}
+
+var userTieBreaker = database.KeysetColumn{Column: "id", Type: "uuid"}
+
+func (p PostgresUserRepository) List(tenantID string, page *pagination.PageRequest, filter filtering.Expr) (*pagination.Page[*entities.User], error) {
+	ctx := context.Background()
+
+	where, args, err := database.CompileFilter(filter, userListColumns, 3)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	after, afterArgs, err := database.CompileKeyset(page.OrderBy, userKeysetColumns, userTieBreaker, page.After, 3+len(args))
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	orderTerms := make([]string, 0, len(page.OrderBy)+1)
+	for _, order := range page.OrderBy {
+		column, ok := userListColumns[order.Field]
+		if !ok {
+			return nil, appErrors.NewInfrastructureError(fmt.Sprintf("unsupported user order field %s", order.Field), nil)
+		}
+		orderTerms = append(orderTerms, fmt.Sprintf("%s %s", column, strings.ToUpper(string(order.Direction))))
+	}
+	// Tie-breaker keeps pages stable when sort keys repeat
+	orderTerms = append(orderTerms, "id ASC")
+
+	// Users belong to a tenant through their role assignments. The total counts every user of the
+	// tenant matching the filter, not only the ones after the cursor.
+	const inTenant = `EXISTS (SELECT 1 FROM casbin_rule c WHERE c.ptype = 'g' AND c.v0 = users.id::text AND c.v2 = $2)`
+	query := fmt.Sprintf(`
+		SELECT id, name, email, created_at, updated_at, (SELECT count(*) FROM users WHERE %s AND %s) AS total
+		FROM users
+		WHERE %s AND %s AND %s
+		ORDER BY %s
+		LIMIT $1`, inTenant, where, inTenant, where, after, strings.Join(orderTerms, ", "))
+
+	queryArgs := append(append([]any{page.PageSize + 1, tenantID}, args...), afterArgs...)
+	rows, err := p.db.Query(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	defer rows.Close()
+
+	var total int64
+	users := make([]*entities.User, 0, page.PageSize+1)
+	for rows.Next() {
+		var dbUser db.FindByEmailRow
+		if err := rows.Scan(&dbUser.ID, &dbUser.Name, &dbUser.Email, &dbUser.CreatedAt, &dbUser.UpdatedAt, &total); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+
+		user, err := entities.NewUser(
+			dbUser.ID.String(),
+			dbUser.Name,
+			dbUser.Email,
+			dbUser.CreatedAt.Time,
+			dbUser.UpdatedAt.Time,
+		)
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return pagination.NewPage(page, users, total, func(user *entities.User) []string {
+		keys := make([]string, 0, len(page.OrderBy)+1)
+		for _, order := range page.OrderBy {
+			switch order.Field {
+			case "name":
+				keys = append(keys, user.Name)
+			case "email":
+				keys = append(keys, user.Email)
+			case "created_at":
+				keys = append(keys, pagination.TimeKey(user.CreatedAt))
+			}
+		}
+		return append(keys, user.ID)
+	}), nil
+}
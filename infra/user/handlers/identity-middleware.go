@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	serviceAccountEntities "github.com/nahualventure/class-backend/core/app/serviceaccount/domain/entities"
+	resolve_identity_use_case "github.com/nahualventure/class-backend/core/app/user/application/use-cases/resolve-identity-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// NewIdentityMiddleware returns a Huma middleware that resolves the caller once per request and puts
+// the identity in the request context, handlers read it with authorization.IdentityFromContext. It
+// runs after the authorization middleware, public endpoints and service accounts have no identity.
+func NewIdentityMiddleware(resolveIdentityUseCase *resolve_identity_use_case.ResolveIdentityUseCase) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		userID := authorization.UserIDFromContext(ctx.Context())
+		if userID == "" || serviceAccountEntities.IsServiceAccountSubject(userID) {
+			next(ctx)
+			return
+		}
+
+		command, err := resolve_identity_use_case.NewResolveIdentityCommand(userID)
+		if err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+		identity, err := resolveIdentityUseCase.Execute(ctx.Context(), command)
+		if err != nil {
+			utils.WriteApplicationError(ctx, err)
+			return
+		}
+
+		next(authorization.WithIdentity(ctx, identity))
+	}
+}
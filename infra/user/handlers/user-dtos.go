@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type UserResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty" visibility:"user:view_contact" doc:"Left out for callers without user:view_contact"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewUserResponse(user *entities.User) UserResponse {
+	return UserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+type ListUsersRequest struct {
+	utils.ListQueryParams
+}
+
+type ListUsersResponse struct {
+	Body utils.ListResponseBody[UserResponse]
+}
+
+func NewListUsersResponse(page *pagination.Page[*entities.User]) *ListUsersResponse {
+	return &ListUsersResponse{Body: utils.NewListResponseBody(page, NewUserResponse)}
+}
+
+type ExportUsersRequest struct {
+	OrderBy string `query:"order_by" doc:"Comma separated sort terms, e.g. 'created_at desc,name'"`
+	Filter  string `query:"filter" maxLength:"1000" doc:"Filter expression, same syntax as the list endpoint"`
+}
+
+func userCSVRow(user *entities.User) []string {
+	return []string{user.ID, user.Name, user.Email, user.CreatedAt.UTC().Format(time.RFC3339)}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+
+	list_users_use_case "github.com/nahualventure/class-backend/core/app/user/application/use-cases/list-users-use-case"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+	userv1 "github.com/nahualventure/class-backend/proto/gen/user/v1"
+
+	"google.golang.org/grpc"
+)
+
+// ViewContactVisibility is the visibility of the email of UserResponse
+const ViewContactVisibility = "user:view_contact"
+
+// UserGRPCHandlers serves the user operations over gRPC, errors are converted by the server
+type UserGRPCHandlers struct {
+	userv1.UnimplementedUserServiceServer
+	listUsersUseCase *list_users_use_case.ListUsersUseCase
+	redactor         *authorization.Redactor
+}
+
+func NewUserGRPCHandlers(listUsersUseCase *list_users_use_case.ListUsersUseCase, redactor *authorization.Redactor) *UserGRPCHandlers {
+	return &UserGRPCHandlers{
+		listUsersUseCase: listUsersUseCase,
+		redactor:         redactor,
+	}
+}
+
+func (h *UserGRPCHandlers) Register(server *grpc.Server) {
+	userv1.RegisterUserServiceServer(server, h)
+}
+
+func (h *UserGRPCHandlers) Operations() map[string]authorization.GRPCOperation {
+	return map[string]authorization.GRPCOperation{
+		userv1.UserService_ListUsers_FullMethodName: {OperationID: "list-users", ReadOnly: true},
+	}
+}
+
+func (h *UserGRPCHandlers) ListUsers(ctx context.Context, request *userv1.ListUsersRequest) (*userv1.ListUsersResponse, error) {
+	page, err := utils.PageRequestFromProto(request.GetPage(), ports.UserListOrderFields, list_users_use_case.DefaultOrder)
+	if err != nil {
+		return nil, err
+	}
+	command, err := list_users_use_case.NewListUsersCommandFromPage(authorization.TenantIDFromContext(ctx), page, request.GetPage().GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := h.listUsersUseCase.Execute(command)
+	if err != nil {
+		return nil, err
+	}
+
+	showEmail := h.redactor.Visible(ctx, ViewContactVisibility)
+	response := &userv1.ListUsersResponse{
+		Users: make([]*userv1.User, 0, len(users.Items)),
+		Page:  utils.PageResponseToProto(users),
+	}
+	for _, user := range users.Items {
+		response.Users = append(response.Users, NewUserProto(user, "", showEmail))
+	}
+	return response, nil
+}
+
+// NewUserProto is the proto counterpart of NewUserResponse. createdBy is only known to the call that
+// created the user, and the email is left out unless the caller may view contacts.
+func NewUserProto(user *entities.User, createdBy string, showEmail bool) *userv1.User {
+	message := &userv1.User{
+		Id:    user.ID,
+		Name:  user.Name,
+		Audit: utils.AuditInfoToProto(createdBy, user.CreatedAt, "", user.UpdatedAt),
+	}
+	if showEmail {
+		message.Email = user.Email
+	}
+	return message
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	list_users_use_case "github.com/nahualventure/class-backend/core/app/user/application/use-cases/list-users-use-case"
+	"github.com/nahualventure/class-backend/core/app/user/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/user/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/export"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type UserHandlers struct {
+	listUsersUseCase *list_users_use_case.ListUsersUseCase
+	exportLimiter    *ratelimit.TenantRateLimiter
+	// savedViews applies the view a list request names
+	savedViews utils.SavedViews
+}
+
+func NewUserHandlers(listUsersUseCase *list_users_use_case.ListUsersUseCase, exportLimiter *ratelimit.TenantRateLimiter,
+	savedViews utils.SavedViews) *UserHandlers {
+	return &UserHandlers{
+		listUsersUseCase: listUsersUseCase,
+		exportLimiter:    exportLimiter,
+		savedViews:       savedViews,
+	}
+}
+
+func (h *UserHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-users",
+		Method:      http.MethodGet,
+		Path:        "/users",
+		Summary:     "List users",
+		Tags:        []string{"Users"},
+	}, h.ListUsers)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "export-users",
+		Method:      http.MethodGet,
+		Path:        "/users/export",
+		Summary:     "Export users as CSV",
+		Tags:        []string{"Users"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "CSV file with one row per user",
+				Content:     map[string]*huma.MediaType{"text/csv": {}},
+			},
+		},
+	}, h.ExportUsers)
+}
+
+func (h *UserHandlers) ListUsers(ctx context.Context, input *ListUsersRequest) (*ListUsersResponse, error) {
+	orderBy, filter, err := input.WithView(ctx, ports.UsersList, h.savedViews)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	command, err := list_users_use_case.NewListUsersCommand(authorization.TenantIDFromContext(ctx), input.PageSize, input.Cursor, orderBy, filter)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listUsersUseCase.Execute(command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListUsersResponse(page), nil
+}
+
+func (h *UserHandlers) ExportUsers(ctx context.Context, input *ExportUsersRequest) (*huma.StreamResponse, error) {
+	if allowed, retryAfter := h.exportLimiter.Allow(authorization.TenantIDFromContext(ctx)); !allowed {
+		return nil, huma.ErrorWithHeaders(
+			utils.ApplicationErrorToHumaError(appErrors.NewRateLimitedError("export", retryAfter)),
+			http.Header{"Retry-After": []string{strconv.Itoa(int(retryAfter.Seconds()) + 1)}},
+		)
+	}
+
+	fetchPage := func(cursor string) (*pagination.Page[*entities.User], error) {
+		command, err := list_users_use_case.NewListUsersCommand(authorization.TenantIDFromContext(ctx), pagination.MaxPageSize, cursor, input.OrderBy, input.Filter)
+		if err != nil {
+			return nil, err
+		}
+		return h.listUsersUseCase.Execute(command)
+	}
+
+	firstPage, err := fetchPage("")
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return export.CSVStream[*entities.User]{
+		Filename:  "users.csv",
+		Header:    []string{"id", "name", "email", "created_at"},
+		ToRow:     userCSVRow,
+		FirstPage: firstPage,
+		NextPage:  fetchPage,
+	}.Response(), nil
+}
@@ -1,6 +1,6 @@
 -- name: CreateUser :one
-INSERT INTO users (id, name, email, password_hash)
-VALUES (@id, @name, @email, @password_hash)
+INSERT INTO users (id, name, email, password_hash, external_id)
+VALUES (@id, @name, @email, @password_hash, sqlc.narg('external_id'))
 RETURNING id, name, email, created_at, updated_at;
 
 -- name: ExistsByEmail :one  
@@ -9,4 +9,25 @@ SELECT EXISTS(SELECT 1 FROM users WHERE email = @email);
 -- name: FindByEmail :one
 SELECT id, name, email, created_at, updated_at 
 FROM users 
-WHERE email = @email;
\ No newline at end of file
+WHERE email = @email;
+
+-- Emails are compared case insensitively, the emails given must be lowercased
+-- name: FindExistingEmails :many
+SELECT email
+FROM users
+WHERE lower(email) = ANY(@emails::varchar[]);
+
+-- name: FindUsersByIDs :many
+SELECT id, name, email, created_at, updated_at
+FROM users
+WHERE id = ANY(@ids::uuid[]);
+
+-- name: FindCredentialsByEmail :one
+SELECT id, name, email, password_hash, created_at, updated_at
+FROM users
+WHERE email = @email;
+
+-- name: ReplacePasswordHash :execrows
+UPDATE users
+SET password_hash = @new_hash, updated_at = NOW()
+WHERE id = @id AND password_hash = @old_hash;
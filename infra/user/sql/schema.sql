@@ -3,11 +3,14 @@ CREATE TABLE users (
     id UUID PRIMARY KEY,
     name VARCHAR(255) NOT NULL,
     email VARCHAR(255) UNIQUE NOT NULL,
-    password_hash VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,   -- empty when the user signs in through linked identities only
+    external_id VARCHAR(255),              -- ID of the user in the system it was imported from
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
 -- Indexes for common queries
 CREATE INDEX idx_users_email ON users(email);
-CREATE INDEX idx_users_created_at ON users(created_at);
\ No newline at end of file
+CREATE INDEX idx_users_email_lower ON users(lower(email));
+CREATE INDEX idx_users_created_at ON users(created_at);
+CREATE INDEX idx_users_external_id ON users(external_id);
\ No newline at end of file
@@ -0,0 +1,171 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresDuplicateCandidateRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresDuplicateCandidateRepository(dbInstance *pgxpool.Pool) ports.DuplicateCandidateRepository {
+	return &PostgresDuplicateCandidateRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresDuplicateCandidateRepository) SaveDetected(ctx context.Context, tenantID string, candidates []*entities.DuplicateCandidate) (int, error) {
+	params := db.InsertDuplicateCandidatesParams{
+		Ids:              make([]pgtype.UUID, len(candidates)),
+		TenantID:         tenantID,
+		UserIds:          make([]string, len(candidates)),
+		DuplicateUserIds: make([]string, len(candidates)),
+		Reasons:          make([]string, len(candidates)),
+	}
+	pairs := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		if err := params.Ids[i].Scan(candidate.ID); err != nil {
+			return 0, appErrors.PropagateError(err)
+		}
+		params.UserIds[i] = candidate.UserID
+		params.DuplicateUserIds[i] = candidate.DuplicateUserID
+		params.Reasons[i] = string(candidate.Reason)
+		params.DetectedAt = pgtype.Timestamptz{Time: candidate.DetectedAt, Valid: true}
+		pairs[i] = entities.DuplicatePair{UserID: candidate.UserID, DuplicateUserID: candidate.DuplicateUserID}.Key()
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.DeleteUndetectedDuplicateCandidates(ctx, db.DeleteUndetectedDuplicateCandidatesParams{TenantID: tenantID, Pairs: pairs})
+	if err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	var added int64
+	if len(candidates) > 0 {
+		added, err = qtx.InsertDuplicateCandidates(ctx, params)
+		if err != nil {
+			return 0, appErrors.PropagateError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, appErrors.PropagateError(err)
+	}
+	return int(added), nil
+}
+
+func (r *PostgresDuplicateCandidateRepository) FindByID(ctx context.Context, tenantID string, candidateID string) (*entities.DuplicateCandidate, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(candidateID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetDuplicateCandidate(ctx, db.GetDuplicateCandidateParams{TenantID: tenantID, ID: pgUUID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toDuplicateCandidate(row)
+}
+
+func (r *PostgresDuplicateCandidateRepository) ListOpen(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.DuplicateCandidate], error) {
+	afterDetectedAt, afterID, err := database.TimeIDKeyset(page.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.queries.ListOpenDuplicateCandidates(ctx, db.ListOpenDuplicateCandidatesParams{
+		TenantID:        tenantID,
+		AfterDetectedAt: afterDetectedAt,
+		AfterID:         afterID,
+		PageLimit:       int32(page.PageSize + 1),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	candidates := make([]*entities.DuplicateCandidate, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		candidate, err := toDuplicateCandidate(db.DuplicateUserCandidate{
+			ID:              row.ID,
+			TenantID:        row.TenantID,
+			UserID:          row.UserID,
+			DuplicateUserID: row.DuplicateUserID,
+			Reason:          row.Reason,
+			Status:          row.Status,
+			DetectedAt:      row.DetectedAt,
+			ResolvedBy:      row.ResolvedBy,
+			ResolvedAt:      row.ResolvedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return pagination.NewPage(page, candidates, total, func(candidate *entities.DuplicateCandidate) []string {
+		return []string{pagination.TimeKey(candidate.DetectedAt), candidate.ID}
+	}), nil
+}
+
+func (r *PostgresDuplicateCandidateRepository) Dismiss(ctx context.Context, candidate *entities.DuplicateCandidate) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(candidate.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	dismissed, err := r.queries.DismissDuplicateCandidate(ctx, db.DismissDuplicateCandidateParams{
+		ResolvedBy: &candidate.ResolvedBy,
+		ResolvedAt: pgtype.Timestamptz{Time: *candidate.ResolvedAt, Valid: true},
+		TenantID:   candidate.TenantID,
+		ID:         pgUUID,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	return dismissed > 0, nil
+}
+
+func toDuplicateCandidate(row db.DuplicateUserCandidate) (*entities.DuplicateCandidate, error) {
+	candidate, err := entities.NewDuplicateCandidate(row.ID.String(), row.TenantID, entities.DuplicatePair{
+		UserID:          row.UserID,
+		DuplicateUserID: row.DuplicateUserID,
+		Reason:          entities.DuplicateReason(row.Reason),
+	}, row.DetectedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	candidate.Status = entities.DuplicateCandidateStatus(row.Status)
+	if row.ResolvedBy != nil {
+		candidate.ResolvedBy = *row.ResolvedBy
+	}
+	if row.ResolvedAt.Valid {
+		candidate.ResolvedAt = &row.ResolvedAt.Time
+	}
+	return candidate, nil
+}
@@ -0,0 +1,63 @@
+package adapters
+
+import (
+	"context"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresMemberDirectory reads the members of a tenant from casbin_rule joined with users, the
+// role assignments written by other instances included
+type PostgresMemberDirectory struct {
+	queries *db.Queries
+}
+
+func NewPostgresMemberDirectory(dbInstance *pgxpool.Pool) ports.MemberDirectory {
+	return &PostgresMemberDirectory{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (d *PostgresMemberDirectory) Members(ctx context.Context, tenantID string) ([]entities.Member, error) {
+	rows, err := d.queries.ListTenantMembers(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	members := make([]entities.Member, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, toMember(db.FindTenantMembersRow(row)))
+	}
+	return members, nil
+}
+
+func (d *PostgresMemberDirectory) Find(ctx context.Context, tenantID string, userIDs []string) ([]entities.Member, error) {
+	rows, err := d.queries.FindTenantMembers(ctx, db.FindTenantMembersParams{TenantID: tenantID, UserIds: userIDs})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	members := make([]entities.Member, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, toMember(row))
+	}
+	return members, nil
+}
+
+func toMember(row db.FindTenantMembersRow) entities.Member {
+	member := entities.Member{
+		UserID: row.ID.String(),
+		Name:   row.Name,
+		Email:  row.Email,
+	}
+	if row.ExternalID != nil {
+		member.ExternalID = *row.ExternalID
+	}
+	return member
+}
@@ -0,0 +1,349 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresUserMergeRepository moves the records of a merge in one transaction, the role
+// assignments are written to casbin_rule along with them and loaded by the enforcer once committed
+type PostgresUserMergeRepository struct {
+	db            *pgxpool.Pool
+	queries       *db.Queries
+	casbinService *authorization.CasbinService
+}
+
+func NewPostgresUserMergeRepository(dbInstance *pgxpool.Pool, casbinService *authorization.CasbinService) ports.UserMergeRepository {
+	return &PostgresUserMergeRepository{
+		db:            dbInstance,
+		queries:       db.New(database.Reads.Wrap(dbInstance)),
+		casbinService: casbinService,
+	}
+}
+
+func (r *PostgresUserMergeRepository) Merge(ctx context.Context, merge *entities.UserMerge) error {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(merge.ID); err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+
+	changes := entities.MergeChanges{}
+	changes.Roles, err = qtx.MoveOutRoleAssignments(ctx, db.MoveOutRoleAssignmentsParams{Subject: merge.MergedID, TenantID: merge.TenantID})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	changes.AddedRoles, err = qtx.MoveInRoleAssignments(ctx, db.MoveInRoleAssignmentsParams{
+		Subject:  merge.SurvivorID,
+		TenantID: merge.TenantID,
+		Roles:    changes.Roles,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	enrollments, err := qtx.MoveOutEnrollments(ctx, db.MoveOutEnrollmentsParams{TenantID: merge.TenantID, UserID: merge.MergedID})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	classIDs := make([]pgtype.UUID, len(enrollments))
+	enrolledAts := make([]pgtype.Timestamptz, len(enrollments))
+	for i, enrollment := range enrollments {
+		classIDs[i] = enrollment.ClassID
+		enrolledAts[i] = enrollment.EnrolledAt
+	}
+	moved, err := qtx.MoveInEnrollments(ctx, db.MoveInEnrollmentsParams{
+		TenantID:    merge.TenantID,
+		ClassIds:    classIDs,
+		UserID:      merge.SurvivorID,
+		EnrolledAts: enrolledAts,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	for _, enrollment := range enrollments {
+		entry := entities.Enrollment{ClassID: enrollment.ClassID.String(), EnrolledAt: enrollment.EnrolledAt.Time}
+		if slices.Contains(moved, enrollment.ClassID) {
+			changes.Enrollments = append(changes.Enrollments, entry)
+		} else {
+			changes.DroppedEnrollments = append(changes.DroppedEnrollments, entry)
+		}
+	}
+
+	grades, err := qtx.MoveGradesToSurvivor(ctx, db.MoveGradesToSurvivorParams{
+		SurvivorID: merge.SurvivorID,
+		TenantID:   merge.TenantID,
+		MergedID:   merge.MergedID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	kept, err := qtx.ListStudentGradeIDs(ctx, db.ListStudentGradeIDsParams{TenantID: merge.TenantID, StudentID: merge.MergedID})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	changes.Grades = uuidStrings(grades)
+	changes.KeptGrades = uuidStrings(kept)
+
+	err = qtx.SetDuplicateCandidatesStatus(ctx, db.SetDuplicateCandidatesStatusParams{
+		Status:     string(entities.DuplicateCandidateStatusMerged),
+		ResolvedBy: &merge.RequestedBy,
+		ResolvedAt: pgtype.Timestamptz{Time: merge.CreatedAt, Valid: true},
+		TenantID:   merge.TenantID,
+		FromStatus: string(entities.DuplicateCandidateStatusOpen),
+		UserA:      merge.SurvivorID,
+		UserB:      merge.MergedID,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	serialized, err := json.Marshal(changes)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize user merge changes", err)
+	}
+	err = qtx.CreateUserMerge(ctx, db.CreateUserMergeParams{
+		ID:          pgUUID,
+		TenantID:    merge.TenantID,
+		SurvivorID:  merge.SurvivorID,
+		MergedID:    merge.MergedID,
+		Status:      string(merge.Status),
+		Changes:     serialized,
+		RequestedBy: merge.RequestedBy,
+		CreatedAt:   pgtype.Timestamptz{Time: merge.CreatedAt, Valid: true},
+		UndoUntil:   pgtype.Timestamptz{Time: merge.UndoUntil, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	merge.Changes = changes
+	r.casbinService.ReloadRoleAssignments([]string{merge.SurvivorID, merge.MergedID})
+	return nil
+}
+
+func (r *PostgresUserMergeRepository) Undo(ctx context.Context, merge *entities.UserMerge) (bool, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(merge.ID); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	moved, err := classUUIDs(merge.Changes.Enrollments)
+	if err != nil {
+		return false, err
+	}
+	// The merged user gets back the dropped enrollments too, the survivor keeps its own
+	enrollments := slices.Concat(merge.Changes.Enrollments, merge.Changes.DroppedEnrollments)
+	returned, err := classUUIDs(enrollments)
+	if err != nil {
+		return false, err
+	}
+	enrolledAts := make([]pgtype.Timestamptz, len(enrollments))
+	for i, enrollment := range enrollments {
+		enrolledAts[i] = pgtype.Timestamptz{Time: enrollment.EnrolledAt, Valid: true}
+	}
+	grades := make([]pgtype.UUID, len(merge.Changes.Grades))
+	for i, gradeID := range merge.Changes.Grades {
+		if err := grades[i].Scan(gradeID); err != nil {
+			return false, appErrors.PropagateError(err)
+		}
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+
+	undone, err := qtx.UndoUserMerge(ctx, db.UndoUserMergeParams{
+		UndoneBy: &merge.UndoneBy,
+		UndoneAt: pgtype.Timestamptz{Time: *merge.UndoneAt, Valid: true},
+		TenantID: merge.TenantID,
+		ID:       pgUUID,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	if undone == 0 {
+		return false, nil
+	}
+
+	err = qtx.RemoveRoleAssignments(ctx, db.RemoveRoleAssignmentsParams{
+		Subject:  merge.SurvivorID,
+		TenantID: merge.TenantID,
+		Roles:    merge.Changes.AddedRoles,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	_, err = qtx.MoveInRoleAssignments(ctx, db.MoveInRoleAssignmentsParams{
+		Subject:  merge.MergedID,
+		TenantID: merge.TenantID,
+		Roles:    merge.Changes.Roles,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	err = qtx.RemoveEnrollments(ctx, db.RemoveEnrollmentsParams{TenantID: merge.TenantID, UserID: merge.SurvivorID, ClassIds: moved})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	_, err = qtx.MoveInEnrollments(ctx, db.MoveInEnrollmentsParams{
+		TenantID:    merge.TenantID,
+		ClassIds:    returned,
+		UserID:      merge.MergedID,
+		EnrolledAts: enrolledAts,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	err = qtx.ReturnGradesToMerged(ctx, db.ReturnGradesToMergedParams{
+		MergedID:   merge.MergedID,
+		TenantID:   merge.TenantID,
+		SurvivorID: merge.SurvivorID,
+		GradeIds:   grades,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	err = qtx.SetDuplicateCandidatesStatus(ctx, db.SetDuplicateCandidatesStatusParams{
+		Status:     string(entities.DuplicateCandidateStatusOpen),
+		TenantID:   merge.TenantID,
+		FromStatus: string(entities.DuplicateCandidateStatusMerged),
+		UserA:      merge.SurvivorID,
+		UserB:      merge.MergedID,
+	})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	r.casbinService.ReloadRoleAssignments([]string{merge.SurvivorID, merge.MergedID})
+	return true, nil
+}
+
+func (r *PostgresUserMergeRepository) FindByID(ctx context.Context, tenantID string, mergeID string) (*entities.UserMerge, error) {
+	var pgUUID pgtype.UUID
+	if err := pgUUID.Scan(mergeID); err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	row, err := r.queries.GetUserMerge(ctx, db.GetUserMergeParams{TenantID: tenantID, ID: pgUUID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return toUserMerge(row)
+}
+
+func (r *PostgresUserMergeRepository) List(ctx context.Context, tenantID string, page *pagination.PageRequest) (*pagination.Page[*entities.UserMerge], error) {
+	afterCreatedAt, afterID, err := database.TimeIDKeyset(page.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.queries.ListUserMerges(ctx, db.ListUserMergesParams{
+		TenantID:       tenantID,
+		AfterCreatedAt: afterCreatedAt,
+		AfterID:        afterID,
+		PageLimit:      int32(page.PageSize + 1),
+	})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	var total int64
+	merges := make([]*entities.UserMerge, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		merge, err := toUserMerge(db.UserMerge{
+			ID:          row.ID,
+			TenantID:    row.TenantID,
+			SurvivorID:  row.SurvivorID,
+			MergedID:    row.MergedID,
+			Status:      row.Status,
+			Changes:     row.Changes,
+			RequestedBy: row.RequestedBy,
+			CreatedAt:   row.CreatedAt,
+			UndoUntil:   row.UndoUntil,
+			UndoneBy:    row.UndoneBy,
+			UndoneAt:    row.UndoneAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		merges = append(merges, merge)
+	}
+
+	return pagination.NewPage(page, merges, total, func(merge *entities.UserMerge) []string {
+		return []string{pagination.TimeKey(merge.CreatedAt), merge.ID}
+	}), nil
+}
+
+func toUserMerge(row db.UserMerge) (*entities.UserMerge, error) {
+	merge, err := entities.NewUserMerge(row.ID.String(), row.TenantID, row.SurvivorID, row.MergedID, row.RequestedBy, row.CreatedAt.Time)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+	if err := json.Unmarshal(row.Changes, &merge.Changes); err != nil {
+		return nil, appErrors.NewInfrastructureError("failed to deserialize user merge changes", err)
+	}
+
+	merge.Status = entities.UserMergeStatus(row.Status)
+	merge.UndoUntil = row.UndoUntil.Time
+	if row.UndoneBy != nil {
+		merge.UndoneBy = *row.UndoneBy
+	}
+	if row.UndoneAt.Valid {
+		merge.UndoneAt = &row.UndoneAt.Time
+	}
+	return merge, nil
+}
+
+func classUUIDs(enrollments []entities.Enrollment) ([]pgtype.UUID, error) {
+	classIDs := make([]pgtype.UUID, len(enrollments))
+	for i, enrollment := range enrollments {
+		if err := classIDs[i].Scan(enrollment.ClassID); err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+	}
+	return classIDs, nil
+}
+
+func uuidStrings(ids []pgtype.UUID) []string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return values
+}
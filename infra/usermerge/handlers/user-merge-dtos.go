@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/shared/pagination"
+	"github.com/nahualventure/class-backend/core/app/usermerge/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type DuplicateCandidateResponse struct {
+	ID              string     `json:"id"`
+	UserID          string     `json:"user_id"`
+	DuplicateUserID string     `json:"duplicate_user_id"`
+	Reason          string     `json:"reason" enum:"same_external_id,similar_email" doc:"same_external_id when both were imported with the same ID of the former system, similar_email when they have the same name and similar emails"`
+	Status          string     `json:"status" enum:"open,dismissed,merged"`
+	DetectedAt      time.Time  `json:"detected_at"`
+	ResolvedBy      string     `json:"resolved_by,omitempty"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+}
+
+func NewDuplicateCandidateResponse(candidate *entities.DuplicateCandidate, loc *time.Location) DuplicateCandidateResponse {
+	return DuplicateCandidateResponse{
+		ID:              candidate.ID,
+		UserID:          candidate.UserID,
+		DuplicateUserID: candidate.DuplicateUserID,
+		Reason:          string(candidate.Reason),
+		Status:          string(candidate.Status),
+		DetectedAt:      utils.InLocation(candidate.DetectedAt, loc),
+		ResolvedBy:      candidate.ResolvedBy,
+		ResolvedAt:      utils.InLocationPtr(candidate.ResolvedAt, loc),
+	}
+}
+
+type DuplicateCandidateEnvelope struct {
+	Body DuplicateCandidateResponse
+}
+
+type ListDuplicateCandidatesRequest struct {
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+}
+
+type ListDuplicateCandidatesResponse struct {
+	Body utils.ListResponseBody[DuplicateCandidateResponse]
+}
+
+func NewListDuplicateCandidatesResponse(page *pagination.Page[*entities.DuplicateCandidate], loc *time.Location) *ListDuplicateCandidatesResponse {
+	return &ListDuplicateCandidatesResponse{Body: utils.NewListResponseBody(page, func(candidate *entities.DuplicateCandidate) DuplicateCandidateResponse {
+		return NewDuplicateCandidateResponse(candidate, loc)
+	})}
+}
+
+type DuplicateCandidatePathRequest struct {
+	CandidateID string `path:"candidateId" format:"uuid" doc:"Duplicate candidate ID"`
+}
+
+type EnrollmentResponse struct {
+	ClassID    string    `json:"class_id"`
+	EnrolledAt time.Time `json:"enrolled_at"`
+}
+
+type MergeChangesResponse struct {
+	Enrollments        []EnrollmentResponse `json:"enrollments" doc:"Enrollments moved to the survivor"`
+	DroppedEnrollments []EnrollmentResponse `json:"dropped_enrollments" doc:"Enrollments of the merged user in classes the survivor was enrolled in already"`
+	Grades             []string             `json:"grades" doc:"Grades moved to the survivor"`
+	KeptGrades         []string             `json:"kept_grades" doc:"Grades left with the merged user because the survivor has a grade for the same assignment"`
+	Roles              []string             `json:"roles" doc:"Roles revoked from the merged user"`
+	AddedRoles         []string             `json:"added_roles" doc:"Roles of the merged user the survivor did not hold yet"`
+}
+
+type UserMergeResponse struct {
+	ID          string               `json:"id"`
+	SurvivorID  string               `json:"survivor_id"`
+	MergedID    string               `json:"merged_id"`
+	Status      string               `json:"status" enum:"completed,undone"`
+	Changes     MergeChangesResponse `json:"changes"`
+	RequestedBy string               `json:"requested_by"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UndoUntil   time.Time            `json:"undo_until" doc:"The merge can be undone until then"`
+	UndoneBy    string               `json:"undone_by,omitempty"`
+	UndoneAt    *time.Time           `json:"undone_at,omitempty"`
+}
+
+func NewUserMergeResponse(merge *entities.UserMerge, loc *time.Location) UserMergeResponse {
+	return UserMergeResponse{
+		ID:         merge.ID,
+		SurvivorID: merge.SurvivorID,
+		MergedID:   merge.MergedID,
+		Status:     string(merge.Status),
+		Changes: MergeChangesResponse{
+			Enrollments:        newEnrollmentResponses(merge.Changes.Enrollments, loc),
+			DroppedEnrollments: newEnrollmentResponses(merge.Changes.DroppedEnrollments, loc),
+			Grades:             nonNil(merge.Changes.Grades),
+			KeptGrades:         nonNil(merge.Changes.KeptGrades),
+			Roles:              nonNil(merge.Changes.Roles),
+			AddedRoles:         nonNil(merge.Changes.AddedRoles),
+		},
+		RequestedBy: merge.RequestedBy,
+		CreatedAt:   utils.InLocation(merge.CreatedAt, loc),
+		UndoUntil:   utils.InLocation(merge.UndoUntil, loc),
+		UndoneBy:    merge.UndoneBy,
+		UndoneAt:    utils.InLocationPtr(merge.UndoneAt, loc),
+	}
+}
+
+func newEnrollmentResponses(enrollments []entities.Enrollment, loc *time.Location) []EnrollmentResponse {
+	responses := make([]EnrollmentResponse, 0, len(enrollments))
+	for _, enrollment := range enrollments {
+		responses = append(responses, EnrollmentResponse{ClassID: enrollment.ClassID, EnrolledAt: utils.InLocation(enrollment.EnrolledAt, loc)})
+	}
+	return responses
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+type UserMergeEnvelope struct {
+	Body UserMergeResponse
+}
+
+type MergeUsersRequest struct {
+	Body struct {
+		SurvivorID string `json:"survivor_id" doc:"User that keeps the records of both"`
+		MergedID   string `json:"merged_id" doc:"Duplicate account, it is left without roles in the tenant. It cannot be your own account."`
+	}
+}
+
+type ListUserMergesRequest struct {
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Opaque cursor returned as next_cursor by the previous page"`
+}
+
+type ListUserMergesResponse struct {
+	Body utils.ListResponseBody[UserMergeResponse]
+}
+
+func NewListUserMergesResponse(page *pagination.Page[*entities.UserMerge], loc *time.Location) *ListUserMergesResponse {
+	return &ListUserMergesResponse{Body: utils.NewListResponseBody(page, func(merge *entities.UserMerge) UserMergeResponse {
+		return NewUserMergeResponse(merge, loc)
+	})}
+}
+
+type UserMergePathRequest struct {
+	MergeID string `path:"mergeId" format:"uuid" doc:"User merge ID"`
+}
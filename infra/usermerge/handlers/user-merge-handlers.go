@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	dismiss_duplicate_candidate_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/dismiss-duplicate-candidate-use-case"
+	get_user_merge_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/get-user-merge-use-case"
+	list_duplicate_candidates_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/list-duplicate-candidates-use-case"
+	list_user_merges_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/list-user-merges-use-case"
+	merge_users_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/merge-users-use-case"
+	undo_user_merge_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/undo-user-merge-use-case"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type UserMergeHandlers struct {
+	listDuplicateCandidatesUseCase   *list_duplicate_candidates_use_case.ListDuplicateCandidatesUseCase
+	dismissDuplicateCandidateUseCase *dismiss_duplicate_candidate_use_case.DismissDuplicateCandidateUseCase
+	mergeUsersUseCase                *merge_users_use_case.MergeUsersUseCase
+	listUserMergesUseCase            *list_user_merges_use_case.ListUserMergesUseCase
+	getUserMergeUseCase              *get_user_merge_use_case.GetUserMergeUseCase
+	undoUserMergeUseCase             *undo_user_merge_use_case.UndoUserMergeUseCase
+}
+
+func NewUserMergeHandlers(
+	listDuplicateCandidatesUseCase *list_duplicate_candidates_use_case.ListDuplicateCandidatesUseCase,
+	dismissDuplicateCandidateUseCase *dismiss_duplicate_candidate_use_case.DismissDuplicateCandidateUseCase,
+	mergeUsersUseCase *merge_users_use_case.MergeUsersUseCase,
+	listUserMergesUseCase *list_user_merges_use_case.ListUserMergesUseCase,
+	getUserMergeUseCase *get_user_merge_use_case.GetUserMergeUseCase,
+	undoUserMergeUseCase *undo_user_merge_use_case.UndoUserMergeUseCase,
+) *UserMergeHandlers {
+	return &UserMergeHandlers{
+		listDuplicateCandidatesUseCase:   listDuplicateCandidatesUseCase,
+		dismissDuplicateCandidateUseCase: dismissDuplicateCandidateUseCase,
+		mergeUsersUseCase:                mergeUsersUseCase,
+		listUserMergesUseCase:            listUserMergesUseCase,
+		getUserMergeUseCase:              getUserMergeUseCase,
+		undoUserMergeUseCase:             undoUserMergeUseCase,
+	}
+}
+
+func (h *UserMergeHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-duplicate-users",
+		Method:      http.MethodGet,
+		Path:        "/users/duplicates",
+		Summary:     "List likely duplicate accounts",
+		Description: "Pairs of members of the tenant found by the daily detection: imported with the same external ID, or with the same name and similar emails. Only open pairs are listed, last detected first.",
+		Tags:        []string{"Users"},
+	}, h.ListDuplicateUsers)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "dismiss-duplicate-users",
+		Method:      http.MethodPost,
+		Path:        "/users/duplicates/{candidateId}/dismiss",
+		Summary:     "Dismiss a pair of likely duplicate accounts",
+		Description: "Records that the accounts belong to different people, the detection does not report them again.",
+		Tags:        []string{"Users"},
+	}, h.DismissDuplicateUsers)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "merge-users",
+		Method:        http.MethodPost,
+		Path:          "/users/merges",
+		Summary:       "Merge a duplicate account into another",
+		Description:   "Moves the enrollments, grades and role assignments of the merged user in the tenant to the survivor, all in a single transaction. Grades for assignments the survivor has a grade of stay with the merged user, which is left without roles. The merge can be undone for 7 days.",
+		Tags:          []string{"Users"},
+		DefaultStatus: http.StatusCreated,
+	}, h.MergeUsers)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-user-merges",
+		Method:      http.MethodGet,
+		Path:        "/users/merges",
+		Summary:     "List the user merges of the tenant",
+		Tags:        []string{"Users"},
+	}, h.ListUserMerges)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-user-merge",
+		Method:      http.MethodGet,
+		Path:        "/users/merges/{mergeId}",
+		Summary:     "Get a user merge and what it moved",
+		Tags:        []string{"Users"},
+	}, h.GetUserMerge)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "undo-user-merge",
+		Method:      http.MethodPost,
+		Path:        "/users/merges/{mergeId}/undo",
+		Summary:     "Undo a user merge",
+		Description: "Gives the merged user back the enrollments, grades and roles the merge moved, in a single transaction, and opens the pair of accounts again. Records the survivor got since are kept.",
+		Tags:        []string{"Users"},
+	}, h.UndoUserMerge)
+}
+
+func (h *UserMergeHandlers) ListDuplicateUsers(ctx context.Context, input *ListDuplicateCandidatesRequest) (*ListDuplicateCandidatesResponse, error) {
+	command, err := list_duplicate_candidates_use_case.NewListDuplicateCandidatesCommand(authorization.TenantIDFromContext(ctx), input.PageSize, input.Cursor)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listDuplicateCandidatesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListDuplicateCandidatesResponse(page, utils.LocationFromContext(ctx)), nil
+}
+
+func (h *UserMergeHandlers) DismissDuplicateUsers(ctx context.Context, input *DuplicateCandidatePathRequest) (*DuplicateCandidateEnvelope, error) {
+	command, err := dismiss_duplicate_candidate_use_case.NewDismissDuplicateCandidateCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.CandidateID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	candidate, err := h.dismissDuplicateCandidateUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &DuplicateCandidateEnvelope{Body: NewDuplicateCandidateResponse(candidate, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *UserMergeHandlers) MergeUsers(ctx context.Context, input *MergeUsersRequest) (*UserMergeEnvelope, error) {
+	command, err := merge_users_use_case.NewMergeUsersCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Body.SurvivorID,
+		input.Body.MergedID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	merge, err := h.mergeUsersUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &UserMergeEnvelope{Body: NewUserMergeResponse(merge, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *UserMergeHandlers) ListUserMerges(ctx context.Context, input *ListUserMergesRequest) (*ListUserMergesResponse, error) {
+	command, err := list_user_merges_use_case.NewListUserMergesCommand(authorization.TenantIDFromContext(ctx), input.PageSize, input.Cursor)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	page, err := h.listUserMergesUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewListUserMergesResponse(page, utils.LocationFromContext(ctx)), nil
+}
+
+func (h *UserMergeHandlers) GetUserMerge(ctx context.Context, input *UserMergePathRequest) (*UserMergeEnvelope, error) {
+	command, err := get_user_merge_use_case.NewGetUserMergeCommand(authorization.TenantIDFromContext(ctx), input.MergeID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	merge, err := h.getUserMergeUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &UserMergeEnvelope{Body: NewUserMergeResponse(merge, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *UserMergeHandlers) UndoUserMerge(ctx context.Context, input *UserMergePathRequest) (*UserMergeEnvelope, error) {
+	command, err := undo_user_merge_use_case.NewUndoUserMergeCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.MergeID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	merge, err := h.undoUserMergeUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &UserMergeEnvelope{Body: NewUserMergeResponse(merge, utils.LocationFromContext(ctx))}, nil
+}
@@ -0,0 +1,131 @@
+-- Users holding a role in the tenant, service accounts are not users and are skipped by the join
+-- name: ListTenantMembers :many
+SELECT DISTINCT u.id, u.name, u.email, u.external_id
+FROM casbin_rule c
+JOIN users u ON u.id::text = c.v0
+WHERE c.ptype = 'g' AND c.v2 = @tenant_id::text
+ORDER BY u.id;
+
+-- name: FindTenantMembers :many
+SELECT DISTINCT u.id, u.name, u.email, u.external_id
+FROM casbin_rule c
+JOIN users u ON u.id::text = c.v0
+WHERE c.ptype = 'g' AND c.v2 = @tenant_id::text AND c.v0 = ANY(@user_ids::text[])
+ORDER BY u.id;
+
+-- Pairs detected before keep their status and ID
+-- name: InsertDuplicateCandidates :execrows
+INSERT INTO duplicate_user_candidates (id, tenant_id, user_id, duplicate_user_id, reason, status, detected_at)
+SELECT unnest(@ids::uuid[]), @tenant_id, unnest(@user_ids::text[]), unnest(@duplicate_user_ids::text[]),
+       unnest(@reasons::text[]), 'open', @detected_at
+ON CONFLICT ON CONSTRAINT duplicate_user_candidates_pair_key DO NOTHING;
+
+-- Open candidates the last detection did not find again, pairs are user_id:duplicate_user_id
+-- name: DeleteUndetectedDuplicateCandidates :exec
+DELETE FROM duplicate_user_candidates
+WHERE tenant_id = @tenant_id AND status = 'open'
+  AND user_id || ':' || duplicate_user_id <> ALL(@pairs::text[]);
+
+-- name: GetDuplicateCandidate :one
+SELECT * FROM duplicate_user_candidates
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: ListOpenDuplicateCandidates :many
+SELECT id, tenant_id, user_id, duplicate_user_id, reason, status, detected_at, resolved_by, resolved_at,
+       (SELECT count(*) FROM duplicate_user_candidates t WHERE t.tenant_id = @tenant_id AND t.status = 'open') AS total
+FROM duplicate_user_candidates
+WHERE tenant_id = @tenant_id AND status = 'open'
+  AND (sqlc.narg('after_detected_at')::timestamptz IS NULL
+       OR (detected_at, id) < (sqlc.narg('after_detected_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY detected_at DESC, id DESC
+LIMIT @page_limit;
+
+-- name: DismissDuplicateCandidate :execrows
+UPDATE duplicate_user_candidates
+SET status = 'dismissed', resolved_by = @resolved_by, resolved_at = @resolved_at
+WHERE tenant_id = @tenant_id AND id = @id AND status = 'open';
+
+-- Candidates of the pair whatever order the users are in, merged ones are opened again on undo
+-- name: SetDuplicateCandidatesStatus :exec
+UPDATE duplicate_user_candidates
+SET status = @status, resolved_by = sqlc.narg('resolved_by'), resolved_at = sqlc.narg('resolved_at')
+WHERE tenant_id = @tenant_id AND status = @from_status
+  AND user_id = LEAST(@user_a::text, @user_b::text) AND duplicate_user_id = GREATEST(@user_a::text, @user_b::text);
+
+-- name: MoveOutRoleAssignments :many
+DELETE FROM casbin_rule
+WHERE ptype = 'g' AND v0 = @subject::text AND v2 = @tenant_id::text
+RETURNING v1::text AS role;
+
+-- Assignments the subject already holds are skipped, only the added roles are returned
+-- name: MoveInRoleAssignments :many
+INSERT INTO casbin_rule (ptype, v0, v1, v2, v3, v4, v5)
+SELECT 'g', @subject::text, role, @tenant_id::text, '', '', '' FROM unnest(@roles::text[]) AS role
+ON CONFLICT ON CONSTRAINT casbin_rule_unique DO NOTHING
+RETURNING v1::text AS role;
+
+-- name: RemoveRoleAssignments :exec
+DELETE FROM casbin_rule
+WHERE ptype = 'g' AND v0 = @subject::text AND v2 = @tenant_id::text AND v1 = ANY(@roles::text[]);
+
+-- name: MoveOutEnrollments :many
+DELETE FROM class_enrollments
+WHERE tenant_id = @tenant_id AND user_id = @user_id
+RETURNING class_id, enrolled_at;
+
+-- Classes the user is enrolled in already are skipped, only the enrolled classes are returned
+-- name: MoveInEnrollments :many
+INSERT INTO class_enrollments (tenant_id, class_id, user_id, enrolled_at)
+SELECT @tenant_id, unnest(@class_ids::uuid[]), @user_id, unnest(@enrolled_ats::timestamptz[])
+ON CONFLICT (tenant_id, class_id, user_id) DO NOTHING
+RETURNING class_id;
+
+-- name: RemoveEnrollments :exec
+DELETE FROM class_enrollments
+WHERE tenant_id = @tenant_id AND user_id = @user_id AND class_id = ANY(@class_ids::uuid[]);
+
+-- Grades of the merged user for assignments the survivor has no grade of
+-- name: MoveGradesToSurvivor :many
+UPDATE grades g
+SET student_id = @survivor_id, updated_at = NOW()
+WHERE g.tenant_id = @tenant_id AND g.student_id = @merged_id
+  AND NOT EXISTS (
+      SELECT 1 FROM grades s
+      WHERE s.tenant_id = g.tenant_id AND s.class_id = g.class_id AND s.assignment_id = g.assignment_id
+        AND s.student_id = @survivor_id
+  )
+RETURNING g.id;
+
+-- name: ListStudentGradeIDs :many
+SELECT id FROM grades
+WHERE tenant_id = @tenant_id AND student_id = @student_id
+ORDER BY id;
+
+-- Grades the survivor still has, the ones deleted since are gone
+-- name: ReturnGradesToMerged :exec
+UPDATE grades
+SET student_id = @merged_id, updated_at = NOW()
+WHERE tenant_id = @tenant_id AND student_id = @survivor_id AND id = ANY(@grade_ids::uuid[]);
+
+-- name: CreateUserMerge :exec
+INSERT INTO user_merges (id, tenant_id, survivor_id, merged_id, status, changes, requested_by, created_at, undo_until)
+VALUES (@id, @tenant_id, @survivor_id, @merged_id, @status, @changes, @requested_by, @created_at, @undo_until);
+
+-- name: UndoUserMerge :execrows
+UPDATE user_merges
+SET status = 'undone', undone_by = @undone_by, undone_at = @undone_at
+WHERE tenant_id = @tenant_id AND id = @id AND status = 'completed' AND undo_until > @undone_at;
+
+-- name: GetUserMerge :one
+SELECT * FROM user_merges
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: ListUserMerges :many
+SELECT id, tenant_id, survivor_id, merged_id, status, changes, requested_by, created_at, undo_until, undone_by, undone_at,
+       (SELECT count(*) FROM user_merges t WHERE t.tenant_id = @tenant_id) AS total
+FROM user_merges
+WHERE tenant_id = @tenant_id
+  AND (sqlc.narg('after_created_at')::timestamptz IS NULL
+       OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT @page_limit;
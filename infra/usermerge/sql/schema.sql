@@ -0,0 +1,34 @@
+-- Pairs of users of a tenant the detection job reports as likely accounts of the same person,
+-- user_id sorts before duplicate_user_id. Dismissed pairs are kept so they are not reported again.
+CREATE TABLE duplicate_user_candidates (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    duplicate_user_id VARCHAR(255) NOT NULL,
+    reason VARCHAR(50) NOT NULL,           -- same_external_id, similar_email
+    status VARCHAR(20) NOT NULL,           -- open, dismissed, merged
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_by VARCHAR(255),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT duplicate_user_candidates_pair_key UNIQUE (tenant_id, user_id, duplicate_user_id)
+);
+
+CREATE INDEX idx_duplicate_user_candidates_status ON duplicate_user_candidates(tenant_id, status, detected_at);
+
+-- Enrollments, grades and role assignments of a tenant moved from a duplicate account to the
+-- survivor. changes records what moved so the merge can be undone until undo_until.
+CREATE TABLE user_merges (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    survivor_id VARCHAR(255) NOT NULL,
+    merged_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,           -- completed, undone
+    changes JSONB NOT NULL,
+    requested_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    undo_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    undone_by VARCHAR(255),
+    undone_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_user_merges_tenant_id_created_at ON user_merges(tenant_id, created_at);
@@ -0,0 +1,41 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	detect_duplicate_users_use_case "github.com/nahualventure/class-backend/core/app/usermerge/application/use-cases/detect-duplicate-users-use-case"
+)
+
+// RunDuplicateDetection looks for duplicate accounts in every tenant on each tick until ctx is
+// cancelled
+func RunDuplicateDetection(ctx context.Context, useCase *detect_duplicate_users_use_case.DetectDuplicateUsersUseCase, tenants []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, tenantID := range tenants {
+			command, err := detect_duplicate_users_use_case.NewDetectDuplicateUsersCommand(tenantID)
+			if err != nil {
+				log.Printf("Invalid duplicate detection of %s: %v", tenantID, err)
+				continue
+			}
+
+			result, err := useCase.Execute(ctx, command)
+			if err != nil {
+				log.Printf("Duplicate detection of %s failed: %v", tenantID, err)
+				continue
+			}
+			if result.New > 0 {
+				log.Printf("Duplicate detection of %s found %d new pairs among %d members", tenantID, result.New, result.Members)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package adapters
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/warehouse/domain/ports"
+)
+
+// FilesystemSink writes exports under a local directory, typically a mounted bucket or a volume
+// synced by another tool. Objects are written to a temp file and renamed so readers never see
+// partial files.
+type FilesystemSink struct {
+	root string
+}
+
+func NewFilesystemSink(root string) ports.WarehouseSink {
+	return &FilesystemSink{root: root}
+}
+
+func (s *FilesystemSink) Put(_ context.Context, key string, _ string, content []byte) error {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return appErrors.NewInfrastructureError("failed to create warehouse export directory", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o640); err != nil {
+		return appErrors.NewInfrastructureError("failed to write warehouse export", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return appErrors.NewInfrastructureError("failed to publish warehouse export", err)
+	}
+
+	return nil
+}
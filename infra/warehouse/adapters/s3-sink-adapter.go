@@ -0,0 +1,133 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/warehouse/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/cost"
+)
+
+type S3Config struct {
+	Endpoint        string // e.g. https://s3.us-east-1.amazonaws.com or https://storage.googleapis.com
+	Region          string
+	Bucket          string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3Sink uploads exports with a SigV4 signed PUT using path-style URLs. It works with AWS S3 and
+// S3 compatible stores (GCS interoperability, MinIO), which keeps the SDKs out of the binary.
+type S3Sink struct {
+	config S3Config
+	client *http.Client
+}
+
+func NewS3Sink(config S3Config) ports.WarehouseSink {
+	return &S3Sink{
+		config: config,
+		client: &http.Client{Timeout: 5 * time.Minute, Transport: cost.Transport("warehouse", nil)},
+	}
+}
+
+func (s *S3Sink) Put(ctx context.Context, key string, contentType string, content []byte) error {
+	endpoint, err := url.Parse(s.config.Endpoint)
+	if err != nil {
+		return appErrors.NewInfrastructureError("invalid S3 endpoint", err)
+	}
+
+	objectKey := strings.TrimPrefix(strings.TrimSuffix(s.config.Prefix, "/")+"/"+key, "/")
+	canonicalURI := "/" + s3EscapePath(s.config.Bucket+"/"+objectKey)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint.Scheme+"://"+endpoint.Host+canonicalURI, bytes.NewReader(content))
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to build S3 request", err)
+	}
+	request.Header.Set("Content-Type", contentType)
+	s.sign(request, endpoint.Host, canonicalURI, content, time.Now().UTC())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to upload warehouse export", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return appErrors.NewInfrastructureError(fmt.Sprintf("S3 upload of %s failed with status %d: %s", objectKey, response.StatusCode, body), nil)
+	}
+
+	return nil
+}
+
+func (s *S3Sink) sign(request *http.Request, host string, canonicalURI string, content []byte, now time.Time) {
+	payloadHash := sha256Hex(content)
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	request.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		http.MethodPut,
+		canonicalURI,
+		"",
+		"host:" + host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", day, s.config.Region)
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), day)
+	signingKey = hmacSHA256(signingKey, s.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, hex.EncodeToString(hmacSHA256(signingKey, stringToSign))))
+}
+
+// s3EscapePath URI-encodes every path segment as SigV4 requires, keeping the slashes
+func s3EscapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		var escaped strings.Builder
+		for _, b := range []byte(segment) {
+			if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || strings.IndexByte("-_.~", b) >= 0 {
+				escaped.WriteByte(b)
+			} else {
+				fmt.Fprintf(&escaped, "%%%02X", b)
+			}
+		}
+		segments[i] = escaped.String()
+	}
+	return strings.Join(segments, "/")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
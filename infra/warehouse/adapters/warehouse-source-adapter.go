@@ -0,0 +1,139 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/warehouse/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/warehouse/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWarehouseSource struct {
+	queries *db.Queries
+}
+
+func NewPostgresWarehouseSource(dbInstance *pgxpool.Pool) ports.WarehouseSource {
+	return &PostgresWarehouseSource{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresWarehouseSource) ReadChanged(ctx context.Context, dataset string, after entities.Watermark, limit int) ([]entities.Record, error) {
+	afterTime := pgtype.Timestamptz{Time: after.UpdatedAt, Valid: true}
+
+	switch dataset {
+	case entities.UsersDataset:
+		rows, err := s.queries.ListWarehouseUsersChanged(ctx, db.ListWarehouseUsersChangedParams{
+			TenantID:  after.TenantID,
+			AfterTime: afterTime,
+			AfterKey:  after.Key,
+			BatchSize: int32(limit),
+		})
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+
+		records := make([]entities.Record, 0, len(rows))
+		for _, row := range rows {
+			records = append(records, entities.Record{
+				Key:       row.UserKey,
+				UpdatedAt: row.ChangedAt.Time,
+				Values:    []string{row.UserKey, row.EmailDomain, formatTime(row.CreatedAt.Time), formatTime(row.ChangedAt.Time)},
+			})
+		}
+		return records, nil
+
+	case entities.ClassSummariesDataset:
+		rows, err := s.queries.ListWarehouseClassSummariesChanged(ctx, db.ListWarehouseClassSummariesChangedParams{
+			TenantID:  after.TenantID,
+			AfterTime: afterTime,
+			AfterKey:  after.Key,
+			BatchSize: int32(limit),
+		})
+		if err != nil {
+			return nil, appErrors.PropagateError(err)
+		}
+
+		records := make([]entities.Record, 0, len(rows))
+		for _, row := range rows {
+			records = append(records, entities.Record{
+				Key:       row.ClassKey,
+				UpdatedAt: row.UpdatedAt.Time,
+				Values: []string{
+					row.ClassKey,
+					strconv.Itoa(int(row.RosterCount)),
+					ratio(row.GradeSum, float64(row.GradeCount)),
+					ratio(float64(row.AttendancePresent), float64(row.AttendanceTotal)),
+					formatTime(row.UpdatedAt.Time),
+				},
+			})
+		}
+		return records, nil
+	}
+
+	return nil, appErrors.NewInfrastructureError("unsupported warehouse dataset "+dataset, nil)
+}
+
+type PostgresWatermarkStore struct {
+	queries *db.Queries
+}
+
+func NewPostgresWatermarkStore(dbInstance *pgxpool.Pool) ports.WatermarkStore {
+	return &PostgresWatermarkStore{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (s *PostgresWatermarkStore) Load(ctx context.Context, tenantID string, dataset string) (*entities.Watermark, error) {
+	row, err := s.queries.GetWarehouseWatermark(ctx, db.GetWarehouseWatermarkParams{TenantID: tenantID, Dataset: dataset})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+
+	return &entities.Watermark{
+		TenantID:      row.TenantID,
+		Dataset:       row.Dataset,
+		SchemaVersion: int(row.SchemaVersion),
+		UpdatedAt:     row.HighWatermark.Time,
+		Key:           row.LastKey,
+	}, nil
+}
+
+func (s *PostgresWatermarkStore) Save(ctx context.Context, watermark *entities.Watermark) error {
+	err := s.queries.UpsertWarehouseWatermark(ctx, db.UpsertWarehouseWatermarkParams{
+		TenantID:      watermark.TenantID,
+		Dataset:       watermark.Dataset,
+		SchemaVersion: int32(watermark.SchemaVersion),
+		HighWatermark: pgtype.Timestamptz{Time: watermark.UpdatedAt, Valid: true},
+		LastKey:       watermark.Key,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// ratio formats numerator/denominator, empty when there is no data yet
+func ratio(numerator float64, denominator float64) string {
+	if denominator == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(numerator/denominator, 'f', 4, 64)
+}
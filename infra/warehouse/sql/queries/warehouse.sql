@@ -0,0 +1,37 @@
+-- name: GetWarehouseWatermark :one
+SELECT *
+FROM warehouse_watermarks
+WHERE tenant_id = @tenant_id AND dataset = @dataset;
+
+-- name: UpsertWarehouseWatermark :exec
+INSERT INTO warehouse_watermarks (tenant_id, dataset, schema_version, high_watermark, last_key, updated_at)
+VALUES (@tenant_id, @dataset, @schema_version, @high_watermark, @last_key, NOW())
+ON CONFLICT (tenant_id, dataset) DO UPDATE
+SET schema_version = EXCLUDED.schema_version,
+    high_watermark = EXCLUDED.high_watermark,
+    last_key = EXCLUDED.last_key,
+    updated_at = EXCLUDED.updated_at;
+
+-- Users belong to a tenant through their role assignments
+-- name: ListWarehouseUsersChanged :many
+SELECT u.id::text AS user_key,
+       split_part(u.email, '@', 2)::text AS email_domain,
+       u.created_at,
+       COALESCE(u.updated_at, u.created_at)::timestamptz AS changed_at
+FROM users u
+WHERE EXISTS (
+        SELECT 1 FROM casbin_rule c
+        WHERE c.ptype = 'g' AND c.v0 = u.id::text AND c.v2 = @tenant_id::text
+    )
+  AND (COALESCE(u.updated_at, u.created_at) > @after_time
+       OR (COALESCE(u.updated_at, u.created_at) = @after_time AND u.id::text > @after_key::text))
+ORDER BY COALESCE(u.updated_at, u.created_at), u.id::text
+LIMIT @batch_size;
+
+-- name: ListWarehouseClassSummariesChanged :many
+SELECT class_id::text AS class_key, roster_count, grade_sum, grade_count, attendance_present, attendance_total, updated_at
+FROM class_summaries
+WHERE tenant_id = @tenant_id
+  AND (updated_at > @after_time OR (updated_at = @after_time AND class_id::text > @after_key::text))
+ORDER BY updated_at, class_id::text
+LIMIT @batch_size;
@@ -0,0 +1,10 @@
+-- Incremental export position per tenant dataset
+CREATE TABLE warehouse_watermarks (
+    tenant_id VARCHAR(255) NOT NULL,
+    dataset VARCHAR(100) NOT NULL,
+    schema_version INTEGER NOT NULL,
+    high_watermark TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_key VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, dataset)
+);
@@ -0,0 +1,66 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"time"
+
+	export_dataset_use_case "github.com/nahualventure/class-backend/core/app/warehouse/application/use-cases/export-dataset-use-case"
+	"github.com/nahualventure/class-backend/core/app/warehouse/domain/entities"
+	jobFailureWorkers "github.com/nahualventure/class-backend/infra/jobfailure/workers"
+)
+
+// ExportJob is the name the exports are scheduled and their failures recorded with
+const ExportJob = "warehouse-exports"
+
+// RunWarehouseExports exports every dataset of every tenant on each tick until ctx is cancelled.
+// Runs are incremental, a tenant with no changes only costs one query per dataset. A dataset that
+// keeps failing is escalated and skipped until it is requeued.
+func RunWarehouseExports(ctx context.Context, useCase *export_dataset_use_case.ExportDatasetUseCase, attempts *jobFailureWorkers.JobAttempts,
+	tenants []string, interval time.Duration) {
+	datasets := make([]string, 0, len(entities.Datasets))
+	for name := range entities.Datasets {
+		datasets = append(datasets, name)
+	}
+	slices.Sort(datasets)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, tenantID := range tenants {
+			for _, dataset := range datasets {
+				command, err := export_dataset_use_case.NewExportDatasetCommand(tenantID, dataset)
+				if err != nil {
+					log.Printf("Invalid warehouse export %s/%s: %v", tenantID, dataset, err)
+					continue
+				}
+
+				var result *export_dataset_use_case.ExportDatasetResult
+				err = attempts.Run(ctx, tenantID, ExportJob, dataset, map[string]string{"dataset": dataset},
+					func(ctx context.Context) (err error) {
+						result, err = useCase.Execute(ctx, command)
+						return err
+					})
+				if errors.Is(err, jobFailureWorkers.ErrEscalated) {
+					continue
+				}
+				if err != nil {
+					log.Printf("Warehouse export %s/%s failed: %v", tenantID, dataset, err)
+					continue
+				}
+				if result.Rows > 0 {
+					log.Printf("Warehouse export %s/%s wrote %d rows in %d objects", tenantID, dataset, result.Rows, len(result.Objects))
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
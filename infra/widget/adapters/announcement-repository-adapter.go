@@ -0,0 +1,89 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresAnnouncementRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresAnnouncementRepository(dbInstance *pgxpool.Pool) ports.AnnouncementRepository {
+	return &PostgresAnnouncementRepository{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresAnnouncementRepository) Create(ctx context.Context, announcement *entities.Announcement) error {
+	id, err := pgUUID(announcement.ID)
+	if err != nil {
+		return err
+	}
+
+	err = r.queries.CreateAnnouncement(ctx, db.CreateAnnouncementParams{
+		ID:          id,
+		TenantID:    announcement.TenantID,
+		Title:       announcement.Title,
+		Body:        announcement.Body,
+		PublishedBy: announcement.PublishedBy,
+		PublishedAt: pgtype.Timestamptz{Time: announcement.PublishedAt, Valid: true},
+		ExpiresAt:   timestamptz(announcement.ExpiresAt),
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresAnnouncementRepository) List(ctx context.Context, tenantID string, currentOnly bool, now time.Time,
+	limit int) ([]*entities.Announcement, error) {
+	params := db.ListAnnouncementsParams{
+		TenantID: tenantID,
+		RowLimit: int32(limit),
+	}
+	if currentOnly {
+		params.CurrentAt = pgtype.Timestamptz{Time: now, Valid: true}
+	}
+
+	rows, err := r.queries.ListAnnouncements(ctx, params)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	announcements := make([]*entities.Announcement, 0, len(rows))
+	for _, row := range rows {
+		announcements = append(announcements, &entities.Announcement{
+			ID:          row.ID.String(),
+			TenantID:    row.TenantID,
+			Title:       row.Title,
+			Body:        row.Body,
+			PublishedBy: row.PublishedBy,
+			PublishedAt: row.PublishedAt.Time,
+			ExpiresAt:   timePtr(row.ExpiresAt),
+		})
+	}
+	return announcements, nil
+}
+
+func (r *PostgresAnnouncementRepository) Delete(ctx context.Context, tenantID string, announcementID string) (bool, error) {
+	id, err := pgUUID(announcementID)
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := r.queries.DeleteAnnouncement(ctx, db.DeleteAnnouncementParams{TenantID: tenantID, ID: id})
+	if err != nil {
+		return false, appErrors.PropagateError(err)
+	}
+	return deleted > 0, nil
+}
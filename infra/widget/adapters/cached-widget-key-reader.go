@@ -0,0 +1,39 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+	"github.com/nahualventure/class-backend/infra/shared/cache"
+)
+
+// CachedWidgetKeyReader caches the keys widget requests are made with, every visitor of a page
+// showing a widget sends one. Unknown keys are cached too, so guessing keys does not reach the
+// database. Revocations take up to the TTL to reach widgets.
+type CachedWidgetKeyReader struct {
+	next  ports.WidgetKeyReader
+	cache *cache.TTLCache[string, *entities.WidgetKey]
+}
+
+func NewCachedWidgetKeyReader(next ports.WidgetKeyReader, ttl time.Duration) ports.WidgetKeyReader {
+	return &CachedWidgetKeyReader{
+		next:  next,
+		cache: cache.NewTTLCache[string, *entities.WidgetKey](ttl, 10_000),
+	}
+}
+
+func (r *CachedWidgetKeyReader) FindByKey(ctx context.Context, key string) (*entities.WidgetKey, error) {
+	if widgetKey, ok := r.cache.Lookup(ctx, key); ok {
+		return widgetKey, nil
+	}
+
+	widgetKey, err := r.next.FindByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(key, widgetKey)
+	return widgetKey, nil
+}
@@ -0,0 +1,47 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	enrollmentEntities "github.com/nahualventure/class-backend/core/app/enrollment/domain/entities"
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresClassMeetings reads the weekly meetings the enrollment module keeps for the calendar widget
+type PostgresClassMeetings struct {
+	queries *db.Queries
+}
+
+func NewPostgresClassMeetings(dbInstance *pgxpool.Pool) ports.ClassMeetings {
+	return &PostgresClassMeetings{
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (m *PostgresClassMeetings) ListMeetings(ctx context.Context, tenantID string, classID string) ([]enrollmentEntities.MeetingTime, error) {
+	pgClassID, err := pgUUID(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.queries.ListClassMeetings(ctx, db.ListClassMeetingsParams{TenantID: tenantID, ClassID: pgClassID})
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	meetings := make([]enrollmentEntities.MeetingTime, 0, len(rows))
+	for _, row := range rows {
+		meetings = append(meetings, enrollmentEntities.MeetingTime{
+			Weekday:     time.Weekday(row.Weekday),
+			StartMinute: int(row.StartMinute),
+			EndMinute:   int(row.EndMinute),
+		})
+	}
+	return meetings, nil
+}
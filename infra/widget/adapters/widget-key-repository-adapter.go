@@ -0,0 +1,217 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	"github.com/nahualventure/class-backend/core/app/shared/events"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/ports"
+	db "github.com/nahualventure/class-backend/infra/generated/sqlc"
+	"github.com/nahualventure/class-backend/infra/shared/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWidgetKeyRepository struct {
+	db      *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewPostgresWidgetKeyRepository(dbInstance *pgxpool.Pool) ports.WidgetKeyRepository {
+	return &PostgresWidgetKeyRepository{
+		db:      dbInstance,
+		queries: db.New(database.Reads.Wrap(dbInstance)),
+	}
+}
+
+func (r *PostgresWidgetKeyRepository) Create(ctx context.Context, key *entities.WidgetKey) error {
+	id, err := pgUUID(key.ID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.CreateWidgetKey(ctx, db.CreateWidgetKeyParams{
+		ID:                 id,
+		TenantID:           key.TenantID,
+		Key:                key.Key,
+		Name:               key.Name,
+		AllowedOrigins:     key.AllowedOrigins,
+		Widgets:            key.Widgets,
+		ClassIds:           storedList(key.ClassIDs),
+		RateLimitPerMinute: int32(key.RateLimitPerMinute),
+		CreatedBy:          key.CreatedBy,
+		CreatedAt:          pgtype.Timestamptz{Time: key.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	err = appendEvent(ctx, qtx, key, events.WidgetKeyCreated, map[string]any{
+		"key_id":          key.ID,
+		"name":            key.Name,
+		"allowed_origins": key.AllowedOrigins,
+		"widgets":         key.Widgets,
+		"created_by":      key.CreatedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresWidgetKeyRepository) Find(ctx context.Context, tenantID string, keyID string) (*entities.WidgetKey, error) {
+	id, err := pgUUID(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.GetWidgetKey(ctx, db.GetWidgetKeyParams{TenantID: tenantID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toWidgetKey(row), nil
+}
+
+func (r *PostgresWidgetKeyRepository) FindByKey(ctx context.Context, key string) (*entities.WidgetKey, error) {
+	row, err := r.queries.GetWidgetKeyByKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appErrors.PropagateError(err)
+	}
+	return toWidgetKey(row), nil
+}
+
+func (r *PostgresWidgetKeyRepository) List(ctx context.Context, tenantID string) ([]*entities.WidgetKey, error) {
+	rows, err := r.queries.ListWidgetKeys(ctx, tenantID)
+	if err != nil {
+		return nil, appErrors.PropagateError(err)
+	}
+
+	keys := make([]*entities.WidgetKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, toWidgetKey(row))
+	}
+	return keys, nil
+}
+
+func (r *PostgresWidgetKeyRepository) Revoke(ctx context.Context, key *entities.WidgetKey, revokedBy string) error {
+	id, err := pgUUID(key.ID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.RevokeWidgetKey(ctx, db.RevokeWidgetKeyParams{
+		RevokedAt: timestamptz(key.RevokedAt),
+		TenantID:  key.TenantID,
+		ID:        id,
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	err = appendEvent(ctx, qtx, key, events.WidgetKeyRevoked, map[string]any{
+		"key_id":     key.ID,
+		"name":       key.Name,
+		"revoked_by": revokedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func appendEvent(ctx context.Context, queries *db.Queries, key *entities.WidgetKey, eventType string, event map[string]any) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return appErrors.NewInfrastructureError("failed to serialize widget key event", err)
+	}
+
+	err = queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType:   eventType,
+		AggregateID: key.ID,
+		TenantID:    key.TenantID,
+		Payload:     payload,
+		OccurredAt:  pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true},
+	})
+	if err != nil {
+		return appErrors.PropagateError(err)
+	}
+	return nil
+}
+
+func toWidgetKey(row db.WidgetKey) *entities.WidgetKey {
+	return &entities.WidgetKey{
+		ID:                 row.ID.String(),
+		TenantID:           row.TenantID,
+		Key:                row.Key,
+		Name:               row.Name,
+		AllowedOrigins:     row.AllowedOrigins,
+		Widgets:            row.Widgets,
+		ClassIDs:           row.ClassIds,
+		RateLimitPerMinute: int(row.RateLimitPerMinute),
+		CreatedBy:          row.CreatedBy,
+		CreatedAt:          row.CreatedAt.Time,
+		RevokedAt:          timePtr(row.RevokedAt),
+	}
+}
+
+// storedList stores nil lists as empty arrays, the columns are not nullable
+func storedList(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func pgUUID(id string) (pgtype.UUID, error) {
+	var value pgtype.UUID
+	if err := value.Scan(id); err != nil {
+		return value, appErrors.PropagateError(err)
+	}
+	return value, nil
+}
+
+func timePtr(value pgtype.Timestamptz) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+func timestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"time"
+
+	calendarEntities "github.com/nahualventure/class-backend/core/app/calendar/domain/entities"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+)
+
+type CreateWidgetKeyRequest struct {
+	Body struct {
+		Name               string   `json:"name" minLength:"1" maxLength:"100"`
+		AllowedOrigins     []string `json:"allowed_origins" minItems:"1" maxItems:"20" doc:"Websites the widgets are embedded in, such as https://www.school.edu. https://*.school.edu allows every subdomain."`
+		Widgets            []string `json:"widgets" minItems:"1" enum:"calendar,announcements"`
+		ClassIDs           []string `json:"class_ids,omitempty" maxItems:"50" doc:"Classes whose calendar the key shows, required for the calendar widget"`
+		RateLimitPerMinute int      `json:"rate_limit_per_minute,omitempty" minimum:"0" maximum:"6000" doc:"Requests per minute of every visitor of the websites together, 60 when omitted"`
+	}
+}
+
+type WidgetKeyRequest struct {
+	KeyID string `path:"keyId" format:"uuid"`
+}
+
+type WidgetKeyResponse struct {
+	ID                 string     `json:"id"`
+	Key                string     `json:"key" doc:"Publishable, embedded in the pages of the websites"`
+	Name               string     `json:"name"`
+	AllowedOrigins     []string   `json:"allowed_origins"`
+	Widgets            []string   `json:"widgets"`
+	ClassIDs           []string   `json:"class_ids"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute"`
+	Status             string     `json:"status" enum:"active,revoked"`
+	CreatedBy          string     `json:"created_by"`
+	CreatedAt          time.Time  `json:"created_at"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+}
+
+func NewWidgetKeyResponse(key *entities.WidgetKey, loc *time.Location) WidgetKeyResponse {
+	status := "active"
+	if !key.Active() {
+		status = "revoked"
+	}
+	response := WidgetKeyResponse{
+		ID:                 key.ID,
+		Key:                key.Key,
+		Name:               key.Name,
+		AllowedOrigins:     key.AllowedOrigins,
+		Widgets:            key.Widgets,
+		ClassIDs:           key.ClassIDs,
+		RateLimitPerMinute: key.RateLimitPerMinute,
+		Status:             status,
+		CreatedBy:          key.CreatedBy,
+		CreatedAt:          utils.InLocation(key.CreatedAt, loc),
+		RevokedAt:          utils.InLocationPtr(key.RevokedAt, loc),
+	}
+	if response.ClassIDs == nil {
+		response.ClassIDs = []string{}
+	}
+	return response
+}
+
+type WidgetKeyEnvelope struct {
+	Body WidgetKeyResponse
+}
+
+type ListWidgetKeysResponse struct {
+	Body struct {
+		Items []WidgetKeyResponse `json:"items"`
+	}
+}
+
+type PublishAnnouncementRequest struct {
+	Body struct {
+		Title     string     `json:"title" minLength:"1" maxLength:"200"`
+		Body      string     `json:"body" minLength:"1" maxLength:"5000" doc:"Plain text, widgets show it as written"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty" doc:"When the announcement leaves the widget, kept until deleted when omitted"`
+	}
+}
+
+type ListAnnouncementsRequest struct {
+	Limit int `query:"limit" minimum:"0" maximum:"100" doc:"Newest announcements listed, 20 when omitted"`
+}
+
+type AnnouncementRequest struct {
+	AnnouncementID string `path:"announcementId" format:"uuid"`
+}
+
+type AnnouncementResponse struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	PublishedBy string     `json:"published_by"`
+	PublishedAt time.Time  `json:"published_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+func NewAnnouncementResponse(announcement *entities.Announcement, loc *time.Location) AnnouncementResponse {
+	return AnnouncementResponse{
+		ID:          announcement.ID,
+		Title:       announcement.Title,
+		Body:        announcement.Body,
+		PublishedBy: announcement.PublishedBy,
+		PublishedAt: utils.InLocation(announcement.PublishedAt, loc),
+		ExpiresAt:   utils.InLocationPtr(announcement.ExpiresAt, loc),
+	}
+}
+
+type AnnouncementEnvelope struct {
+	Body AnnouncementResponse
+}
+
+type ListAnnouncementsResponse struct {
+	Body struct {
+		Items []AnnouncementResponse `json:"items"`
+	}
+}
+
+// WidgetRequest is what every widget request carries. Browsers send the Origin of the page with
+// cross-origin requests and its Referer with most others, requests with neither are refused.
+type WidgetRequest struct {
+	Key     string `query:"key" required:"true" maxLength:"100" doc:"Publishable key of the widgets, pk_..."`
+	Origin  string `header:"Origin"`
+	Referer string `header:"Referer"`
+}
+
+// WidgetHeaders let the page of the origin read the response. Errors are sent without them, so
+// scripts of other websites cannot tell a key works.
+type WidgetHeaders struct {
+	AllowOrigin  string `header:"Access-Control-Allow-Origin"`
+	Vary         string `header:"Vary"`
+	CacheControl string `header:"Cache-Control"`
+}
+
+func NewWidgetHeaders(origin string) WidgetHeaders {
+	return WidgetHeaders{
+		AllowOrigin:  origin,
+		Vary:         "Origin",
+		CacheControl: "public, max-age=60",
+	}
+}
+
+type WidgetAnnouncementsRequest struct {
+	WidgetRequest
+	Limit int `query:"limit" minimum:"0" maximum:"50" doc:"Newest current announcements listed, 20 when omitted"`
+}
+
+// WidgetAnnouncementResponse leaves out who published the announcement, visitors of the website
+// only see the news
+type WidgetAnnouncementResponse struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	PublishedAt time.Time  `json:"published_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+type WidgetAnnouncementsResponse struct {
+	WidgetHeaders
+	Body struct {
+		Items []WidgetAnnouncementResponse `json:"items"`
+	}
+}
+
+func NewWidgetAnnouncementsResponse(announcements []*entities.Announcement, origin string) *WidgetAnnouncementsResponse {
+	response := &WidgetAnnouncementsResponse{WidgetHeaders: NewWidgetHeaders(origin)}
+	response.Body.Items = make([]WidgetAnnouncementResponse, 0, len(announcements))
+	for _, announcement := range announcements {
+		response.Body.Items = append(response.Body.Items, WidgetAnnouncementResponse{
+			ID:          announcement.ID,
+			Title:       announcement.Title,
+			Body:        announcement.Body,
+			PublishedAt: announcement.PublishedAt,
+			ExpiresAt:   announcement.ExpiresAt,
+		})
+	}
+	return response
+}
+
+type WidgetCalendarRequest struct {
+	WidgetRequest
+	ClassID string `path:"classId" format:"uuid"`
+	Weeks   int    `query:"weeks" minimum:"0" maximum:"8" doc:"Weeks shown from today, 4 when omitted"`
+}
+
+// WidgetSessionResponse is one session of the class, sessions on a non-instructional day are shown
+// as cancelled
+type WidgetSessionResponse struct {
+	Date        string    `json:"date" format:"date" doc:"Day of the session in the zone of the school"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Cancelled   bool      `json:"cancelled"`
+	CancelledBy string    `json:"cancelled_by,omitempty" doc:"Name of the non-instructional day the session falls on"`
+}
+
+type WidgetCalendarResponse struct {
+	WidgetHeaders
+	Body struct {
+		ClassID  string                  `json:"class_id"`
+		TimeZone string                  `json:"time_zone" doc:"Zone of the school, times are given in it"`
+		Sessions []WidgetSessionResponse `json:"sessions"`
+	}
+}
+
+func NewWidgetCalendarResponse(calendar *entities.ClassCalendar, origin string) *WidgetCalendarResponse {
+	response := &WidgetCalendarResponse{WidgetHeaders: NewWidgetHeaders(origin)}
+	response.Body.ClassID = calendar.ClassID
+	response.Body.TimeZone = calendar.Location.String()
+	response.Body.Sessions = make([]WidgetSessionResponse, 0, len(calendar.Sessions))
+	for _, session := range calendar.Sessions {
+		response.Body.Sessions = append(response.Body.Sessions, WidgetSessionResponse{
+			Date:        session.StartsAt.In(calendar.Location).Format(calendarEntities.DateLayout),
+			StartsAt:    utils.InLocation(session.StartsAt, calendar.Location),
+			EndsAt:      utils.InLocation(session.EndsAt, calendar.Location),
+			Cancelled:   session.CancelledBy != "",
+			CancelledBy: session.CancelledBy,
+		})
+	}
+	return response
+}
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	appErrors "github.com/nahualventure/class-backend/core/app/shared/errors"
+	authenticate_widget_key_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/authenticate-widget-key-use-case"
+	create_widget_key_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/create-widget-key-use-case"
+	delete_announcement_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/delete-announcement-use-case"
+	get_class_calendar_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/get-class-calendar-use-case"
+	list_announcements_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/list-announcements-use-case"
+	list_widget_keys_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/list-widget-keys-use-case"
+	publish_announcement_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/publish-announcement-use-case"
+	revoke_widget_key_use_case "github.com/nahualventure/class-backend/core/app/widget/application/use-cases/revoke-widget-key-use-case"
+	"github.com/nahualventure/class-backend/core/app/widget/domain/entities"
+	"github.com/nahualventure/class-backend/infra/shared/authorization"
+	"github.com/nahualventure/class-backend/infra/shared/ratelimit"
+	"github.com/nahualventure/class-backend/infra/shared/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type WidgetHandlers struct {
+	createWidgetKeyUseCase       *create_widget_key_use_case.CreateWidgetKeyUseCase
+	listWidgetKeysUseCase        *list_widget_keys_use_case.ListWidgetKeysUseCase
+	revokeWidgetKeyUseCase       *revoke_widget_key_use_case.RevokeWidgetKeyUseCase
+	authenticateWidgetKeyUseCase *authenticate_widget_key_use_case.AuthenticateWidgetKeyUseCase
+	publishAnnouncementUseCase   *publish_announcement_use_case.PublishAnnouncementUseCase
+	listAnnouncementsUseCase     *list_announcements_use_case.ListAnnouncementsUseCase
+	deleteAnnouncementUseCase    *delete_announcement_use_case.DeleteAnnouncementUseCase
+	getClassCalendarUseCase      *get_class_calendar_use_case.GetClassCalendarUseCase
+	keyLimiter                   *ratelimit.KeyRateLimiter
+}
+
+func NewWidgetHandlers(
+	createWidgetKeyUseCase *create_widget_key_use_case.CreateWidgetKeyUseCase,
+	listWidgetKeysUseCase *list_widget_keys_use_case.ListWidgetKeysUseCase,
+	revokeWidgetKeyUseCase *revoke_widget_key_use_case.RevokeWidgetKeyUseCase,
+	authenticateWidgetKeyUseCase *authenticate_widget_key_use_case.AuthenticateWidgetKeyUseCase,
+	publishAnnouncementUseCase *publish_announcement_use_case.PublishAnnouncementUseCase,
+	listAnnouncementsUseCase *list_announcements_use_case.ListAnnouncementsUseCase,
+	deleteAnnouncementUseCase *delete_announcement_use_case.DeleteAnnouncementUseCase,
+	getClassCalendarUseCase *get_class_calendar_use_case.GetClassCalendarUseCase,
+	keyLimiter *ratelimit.KeyRateLimiter,
+) *WidgetHandlers {
+	return &WidgetHandlers{
+		createWidgetKeyUseCase:       createWidgetKeyUseCase,
+		listWidgetKeysUseCase:        listWidgetKeysUseCase,
+		revokeWidgetKeyUseCase:       revokeWidgetKeyUseCase,
+		authenticateWidgetKeyUseCase: authenticateWidgetKeyUseCase,
+		publishAnnouncementUseCase:   publishAnnouncementUseCase,
+		listAnnouncementsUseCase:     listAnnouncementsUseCase,
+		deleteAnnouncementUseCase:    deleteAnnouncementUseCase,
+		getClassCalendarUseCase:      getClassCalendarUseCase,
+		keyLimiter:                   keyLimiter,
+	}
+}
+
+func (h *WidgetHandlers) RegisterRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-widget-key",
+		Method:        http.MethodPost,
+		Path:          "/widget-keys",
+		Summary:       "Create a publishable key for embedding widgets",
+		Description:   "The key is embedded in the pages of the school websites. It only works from the origins it lists and for the widgets and classes it unlocks, within its rate limit.",
+		Tags:          []string{"Widgets"},
+		DefaultStatus: http.StatusCreated,
+	}, h.CreateWidgetKey)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-widget-keys",
+		Method:      http.MethodGet,
+		Path:        "/widget-keys",
+		Summary:     "List the widget keys of the tenant",
+		Tags:        []string{"Widgets"},
+	}, h.ListWidgetKeys)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-widget-key",
+		Method:      http.MethodDelete,
+		Path:        "/widget-keys/{keyId}",
+		Summary:     "Revoke a widget key",
+		Description: "Widgets embedded with the key stop loading within a minute.",
+		Tags:        []string{"Widgets"},
+	}, h.RevokeWidgetKey)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "publish-announcement",
+		Method:        http.MethodPost,
+		Path:          "/announcements",
+		Summary:       "Publish an announcement",
+		Description:   "The announcements widget shows it until it expires or is deleted.",
+		Tags:          []string{"Widgets"},
+		DefaultStatus: http.StatusCreated,
+	}, h.PublishAnnouncement)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-announcements",
+		Method:      http.MethodGet,
+		Path:        "/announcements",
+		Summary:     "List the announcements of the tenant, expired ones included",
+		Tags:        []string{"Widgets"},
+	}, h.ListAnnouncements)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-announcement",
+		Method:        http.MethodDelete,
+		Path:          "/announcements/{announcementId}",
+		Summary:       "Delete an announcement",
+		Tags:          []string{"Widgets"},
+		DefaultStatus: http.StatusNoContent,
+	}, h.DeleteAnnouncement)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-widget-announcements",
+		Method:      http.MethodGet,
+		Path:        "/widgets/announcements",
+		Summary:     "Announcements feed widget",
+		Description: "Works without signing in with a widget key, from the pages of its origins. Lists the current announcements, newest first.",
+		Tags:        []string{"Widgets"},
+	}, h.GetWidgetAnnouncements)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-widget-calendar",
+		Method:      http.MethodGet,
+		Path:        "/widgets/classes/{classId}/calendar",
+		Summary:     "Class calendar widget",
+		Description: "Works without signing in with a widget key, from the pages of its origins. Lists the sessions of the class from today in the zone of the school, cancelled ones included.",
+		Tags:        []string{"Widgets"},
+	}, h.GetWidgetCalendar)
+}
+
+func (h *WidgetHandlers) CreateWidgetKey(ctx context.Context, input *CreateWidgetKeyRequest) (*WidgetKeyEnvelope, error) {
+	command, err := create_widget_key_use_case.NewCreateWidgetKeyCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Body.Name,
+		input.Body.AllowedOrigins,
+		input.Body.Widgets,
+		input.Body.ClassIDs,
+		input.Body.RateLimitPerMinute,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	key, err := h.createWidgetKeyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &WidgetKeyEnvelope{Body: NewWidgetKeyResponse(key, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *WidgetHandlers) ListWidgetKeys(ctx context.Context, input *struct{}) (*ListWidgetKeysResponse, error) {
+	command, err := list_widget_keys_use_case.NewListWidgetKeysCommand(authorization.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	keys, err := h.listWidgetKeysUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &ListWidgetKeysResponse{}
+	response.Body.Items = make([]WidgetKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response.Body.Items = append(response.Body.Items, NewWidgetKeyResponse(key, loc))
+	}
+	return response, nil
+}
+
+func (h *WidgetHandlers) RevokeWidgetKey(ctx context.Context, input *WidgetKeyRequest) (*WidgetKeyEnvelope, error) {
+	command, err := revoke_widget_key_use_case.NewRevokeWidgetKeyCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.KeyID,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	key, err := h.revokeWidgetKeyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &WidgetKeyEnvelope{Body: NewWidgetKeyResponse(key, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *WidgetHandlers) PublishAnnouncement(ctx context.Context, input *PublishAnnouncementRequest) (*AnnouncementEnvelope, error) {
+	command, err := publish_announcement_use_case.NewPublishAnnouncementCommand(
+		authorization.TenantIDFromContext(ctx),
+		input.Body.Title,
+		input.Body.Body,
+		input.Body.ExpiresAt,
+		authorization.UserIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	announcement, err := h.publishAnnouncementUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return &AnnouncementEnvelope{Body: NewAnnouncementResponse(announcement, utils.LocationFromContext(ctx))}, nil
+}
+
+func (h *WidgetHandlers) ListAnnouncements(ctx context.Context, input *ListAnnouncementsRequest) (*ListAnnouncementsResponse, error) {
+	command, err := list_announcements_use_case.NewListAnnouncementsCommand(authorization.TenantIDFromContext(ctx), false, input.Limit)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	announcements, err := h.listAnnouncementsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	response := &ListAnnouncementsResponse{}
+	response.Body.Items = make([]AnnouncementResponse, 0, len(announcements))
+	for _, announcement := range announcements {
+		response.Body.Items = append(response.Body.Items, NewAnnouncementResponse(announcement, loc))
+	}
+	return response, nil
+}
+
+func (h *WidgetHandlers) DeleteAnnouncement(ctx context.Context, input *AnnouncementRequest) (*struct{}, error) {
+	command, err := delete_announcement_use_case.NewDeleteAnnouncementCommand(authorization.TenantIDFromContext(ctx), input.AnnouncementID)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	if err := h.deleteAnnouncementUseCase.Execute(ctx, command); err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return nil, nil
+}
+
+func (h *WidgetHandlers) GetWidgetAnnouncements(ctx context.Context, input *WidgetAnnouncementsRequest) (*WidgetAnnouncementsResponse, error) {
+	key, origin, err := h.authenticate(ctx, input.WidgetRequest, entities.WidgetAnnouncements, "")
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := list_announcements_use_case.NewListAnnouncementsCommand(key.TenantID, true, input.Limit)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	announcements, err := h.listAnnouncementsUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewWidgetAnnouncementsResponse(announcements, origin), nil
+}
+
+func (h *WidgetHandlers) GetWidgetCalendar(ctx context.Context, input *WidgetCalendarRequest) (*WidgetCalendarResponse, error) {
+	key, origin, err := h.authenticate(ctx, input.WidgetRequest, entities.WidgetCalendar, input.ClassID)
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := get_class_calendar_use_case.NewGetClassCalendarCommand(key.TenantID, input.ClassID, input.Weeks)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	calendar, err := h.getClassCalendarUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, utils.ApplicationErrorToHumaError(err)
+	}
+
+	return NewWidgetCalendarResponse(calendar, origin), nil
+}
+
+// authenticate checks the key of a widget request, then takes a request from its rate limit. It
+// returns the key and the origin the response is shared with.
+func (h *WidgetHandlers) authenticate(ctx context.Context, input WidgetRequest, widget string, classID string) (*entities.WidgetKey, string, error) {
+	origin := entities.RequestOrigin(input.Origin, input.Referer)
+	command, err := authenticate_widget_key_use_case.NewAuthenticateWidgetKeyCommand(input.Key, origin, widget, classID)
+	if err != nil {
+		return nil, "", utils.ApplicationErrorToHumaError(err)
+	}
+
+	key, err := h.authenticateWidgetKeyUseCase.Execute(ctx, command)
+	if err != nil {
+		return nil, "", utils.ApplicationErrorToHumaError(err)
+	}
+
+	if allowed, retryAfter := h.keyLimiter.Allow(key.ID, key.RateLimitPerMinute); !allowed {
+		return nil, "", huma.ErrorWithHeaders(
+			utils.ApplicationErrorToHumaError(appErrors.NewRateLimitedError(ratelimit.ScopeKey, retryAfter)),
+			http.Header{"Retry-After": []string{strconv.Itoa(int(retryAfter.Seconds()) + 1)}},
+		)
+	}
+	return key, origin, nil
+}
@@ -0,0 +1,14 @@
+-- name: CreateAnnouncement :exec
+INSERT INTO announcements (id, tenant_id, title, body, published_by, published_at, expires_at)
+VALUES (@id, @tenant_id, @title, @body, @published_by, @published_at, @expires_at);
+
+-- name: ListAnnouncements :many
+SELECT * FROM announcements
+WHERE tenant_id = @tenant_id
+  AND (sqlc.narg('current_at')::timestamptz IS NULL OR expires_at IS NULL OR expires_at > sqlc.narg('current_at'))
+ORDER BY published_at DESC, id
+LIMIT @row_limit;
+
+-- name: DeleteAnnouncement :execrows
+DELETE FROM announcements
+WHERE tenant_id = @tenant_id AND id = @id;
@@ -0,0 +1,21 @@
+-- name: CreateWidgetKey :exec
+INSERT INTO widget_keys (id, tenant_id, key, name, allowed_origins, widgets, class_ids, rate_limit_per_minute, created_by, created_at)
+VALUES (@id, @tenant_id, @key, @name, @allowed_origins, @widgets, @class_ids, @rate_limit_per_minute, @created_by, @created_at);
+
+-- name: GetWidgetKey :one
+SELECT * FROM widget_keys
+WHERE tenant_id = @tenant_id AND id = @id;
+
+-- name: GetWidgetKeyByKey :one
+SELECT * FROM widget_keys
+WHERE key = @key;
+
+-- name: ListWidgetKeys :many
+SELECT * FROM widget_keys
+WHERE tenant_id = @tenant_id
+ORDER BY created_at DESC, id;
+
+-- name: RevokeWidgetKey :exec
+UPDATE widget_keys
+SET revoked_at = @revoked_at
+WHERE tenant_id = @tenant_id AND id = @id AND revoked_at IS NULL;
@@ -0,0 +1,30 @@
+-- Publishable keys embedding the widgets of a tenant in the pages of its websites. The key is no
+-- secret, the origins restrict where it works.
+CREATE TABLE widget_keys (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    key VARCHAR(64) NOT NULL UNIQUE,             -- pk_<random>, looked up on every widget request
+    name VARCHAR(100) NOT NULL,
+    allowed_origins TEXT[] NOT NULL,             -- scheme://host[:port], *. allows every subdomain
+    widgets TEXT[] NOT NULL,                     -- calendar, announcements
+    class_ids TEXT[] NOT NULL DEFAULT '{}',      -- classes the calendar widget shows
+    rate_limit_per_minute INTEGER NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_widget_keys_tenant ON widget_keys(tenant_id, created_at);
+
+-- News of the school the announcements widget shows until they expire
+CREATE TABLE announcements (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    published_by VARCHAR(255) NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE      -- kept until deleted when null
+);
+
+CREATE INDEX idx_announcements_tenant ON announcements(tenant_id, published_at);
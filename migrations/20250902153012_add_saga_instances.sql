@@ -0,0 +1,14 @@
+-- Create "saga_instances" table
+CREATE TABLE "public"."saga_instances" (
+  "id" uuid NOT NULL,
+  "saga_name" character varying(100) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "completed_steps" integer NOT NULL,
+  "data" jsonb NOT NULL,
+  "failure_reason" text NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_saga_instances_status" to table: "saga_instances"
+CREATE INDEX "idx_saga_instances_status" ON "public"."saga_instances" ("status");
@@ -0,0 +1,32 @@
+-- Create "outbox_events" table
+CREATE TABLE "public"."outbox_events" (
+  "position" bigserial NOT NULL,
+  "event_type" character varying(100) NOT NULL,
+  "aggregate_id" character varying(255) NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "payload" jsonb NOT NULL,
+  "occurred_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("position")
+);
+-- Create "projection_checkpoints" table
+CREATE TABLE "public"."projection_checkpoints" (
+  "projection_name" character varying(100) NOT NULL,
+  "position" bigint NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("projection_name")
+);
+-- Create "class_summaries" table
+CREATE TABLE "public"."class_summaries" (
+  "class_id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "roster_count" integer NOT NULL DEFAULT 0,
+  "grade_sum" double precision NOT NULL DEFAULT 0,
+  "grade_count" integer NOT NULL DEFAULT 0,
+  "attendance_present" integer NOT NULL DEFAULT 0,
+  "attendance_total" integer NOT NULL DEFAULT 0,
+  "last_position" bigint NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("class_id")
+);
+-- Create index "idx_class_summaries_tenant_id" to table: "class_summaries"
+CREATE INDEX "idx_class_summaries_tenant_id" ON "public"."class_summaries" ("tenant_id");
@@ -0,0 +1,23 @@
+-- Create "reports" table
+CREATE TABLE "public"."reports" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "definition" character varying(100) NOT NULL,
+  "parameters" jsonb NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "failure_reason" text NULL,
+  "requested_by" character varying(255) NOT NULL,
+  "artifact_name" character varying(255) NULL,
+  "artifact_content_type" character varying(100) NULL,
+  "artifact" bytea NULL,
+  "expires_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_reports_expires_at" to table: "reports"
+CREATE INDEX "idx_reports_expires_at" ON "public"."reports" ("expires_at");
+-- Create index "idx_reports_status" to table: "reports"
+CREATE INDEX "idx_reports_status" ON "public"."reports" ("status");
+-- Create index "idx_reports_tenant_id_created_at" to table: "reports"
+CREATE INDEX "idx_reports_tenant_id_created_at" ON "public"."reports" ("tenant_id", "created_at");
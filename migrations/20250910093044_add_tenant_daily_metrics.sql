@@ -0,0 +1,16 @@
+-- Create "tenant_daily_active_users" table
+CREATE TABLE "public"."tenant_daily_active_users" (
+  "tenant_id" character varying(255) NOT NULL,
+  "day" date NOT NULL,
+  "user_id" character varying(255) NOT NULL,
+  PRIMARY KEY ("tenant_id", "day", "user_id")
+);
+-- Create "tenant_daily_metrics" table
+CREATE TABLE "public"."tenant_daily_metrics" (
+  "tenant_id" character varying(255) NOT NULL,
+  "day" date NOT NULL,
+  "metric" character varying(50) NOT NULL,
+  "value" bigint NOT NULL DEFAULT 0,
+  "last_position" bigint NOT NULL,
+  PRIMARY KEY ("tenant_id", "metric", "day")
+);
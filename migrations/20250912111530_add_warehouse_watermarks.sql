@@ -0,0 +1,10 @@
+-- Create "warehouse_watermarks" table
+CREATE TABLE "public"."warehouse_watermarks" (
+  "tenant_id" character varying(255) NOT NULL,
+  "dataset" character varying(100) NOT NULL,
+  "schema_version" integer NOT NULL,
+  "high_watermark" timestamptz NOT NULL,
+  "last_key" character varying(255) NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("tenant_id", "dataset")
+);
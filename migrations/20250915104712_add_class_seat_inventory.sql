@@ -0,0 +1,31 @@
+-- Create "class_capacities" table
+CREATE TABLE "public"."class_capacities" (
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "capacity" integer NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("tenant_id", "class_id"),
+  CONSTRAINT "class_capacities_capacity_check" CHECK (capacity >= 0)
+);
+-- Create "class_enrollments" table
+CREATE TABLE "public"."class_enrollments" (
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "user_id" character varying(255) NOT NULL,
+  "enrolled_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("tenant_id", "class_id", "user_id")
+);
+-- Create "seat_holds" table
+CREATE TABLE "public"."seat_holds" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "user_id" character varying(255) NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_seat_holds_class" to table: "seat_holds"
+CREATE INDEX "idx_seat_holds_class" ON "public"."seat_holds" ("tenant_id", "class_id", "expires_at");
+-- Create index "idx_seat_holds_expires_at" to table: "seat_holds"
+CREATE INDEX "idx_seat_holds_expires_at" ON "public"."seat_holds" ("expires_at");
@@ -0,0 +1,12 @@
+-- Create "class_meetings" table
+CREATE TABLE "public"."class_meetings" (
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "weekday" smallint NOT NULL,
+  "start_minute" integer NOT NULL,
+  "end_minute" integer NOT NULL,
+  CONSTRAINT "class_meetings_check" CHECK ((start_minute >= 0) AND (end_minute > start_minute) AND (end_minute <= 1440)),
+  CONSTRAINT "class_meetings_weekday_check" CHECK ((weekday >= 0) AND (weekday <= 6))
+);
+-- Create index "idx_class_meetings_class" to table: "class_meetings"
+CREATE INDEX "idx_class_meetings_class" ON "public"."class_meetings" ("tenant_id", "class_id");
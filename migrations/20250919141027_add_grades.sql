@@ -0,0 +1,29 @@
+-- Create "grades" table
+CREATE TABLE "public"."grades" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "assignment_id" uuid NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  "score" double precision NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "recorded_by" character varying(255) NOT NULL,
+  "reviewed_by" character varying(255) NULL,
+  "return_reason" text NULL,
+  "submitted_at" timestamptz NULL,
+  "released_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "grades_tenant_id_class_id_assignment_id_student_id_key" UNIQUE ("tenant_id", "class_id", "assignment_id", "student_id")
+);
+-- Create index "idx_grades_student" to table: "grades"
+CREATE INDEX "idx_grades_student" ON "public"."grades" ("tenant_id", "student_id", "status");
+-- Create "class_grading_policies" table
+CREATE TABLE "public"."class_grading_policies" (
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "requires_approval" boolean NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("tenant_id", "class_id")
+);
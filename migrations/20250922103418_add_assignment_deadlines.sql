@@ -0,0 +1,25 @@
+-- Modify "grades" table
+ALTER TABLE "public"."grades" ADD COLUMN "turned_in_at" timestamptz NULL, ADD COLUMN "late_penalty_percent" double precision NOT NULL DEFAULT 0;
+-- Create "assignment_deadlines" table
+CREATE TABLE "public"."assignment_deadlines" (
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "assignment_id" uuid NOT NULL,
+  "due_at" timestamptz NOT NULL,
+  "penalty_percent_per_day" double precision NOT NULL,
+  "cutoff_days" integer NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("tenant_id", "class_id", "assignment_id")
+);
+-- Create "assignment_extensions" table
+CREATE TABLE "public"."assignment_extensions" (
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "assignment_id" uuid NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  "due_at" timestamptz NOT NULL,
+  "granted_by" character varying(255) NOT NULL,
+  "reason" text NULL,
+  "granted_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "class_id", "assignment_id", "student_id")
+);
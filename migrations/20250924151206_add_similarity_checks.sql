@@ -0,0 +1,34 @@
+-- Create "similarity_checks" table
+CREATE TABLE "public"."similarity_checks" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "assignment_id" uuid NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  "file_name" character varying(255) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "attempts" integer NOT NULL DEFAULT 0,
+  "provider_reference" character varying(255) NULL,
+  "score" double precision NULL,
+  "report_url" text NULL,
+  "failure_reason" text NULL,
+  "available_at" timestamptz NOT NULL DEFAULT now(),
+  "claimed_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "completed_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "similarity_checks_provider_reference_key" UNIQUE ("provider_reference")
+);
+-- Create index "idx_similarity_checks_assignment" to table: "similarity_checks"
+CREATE INDEX "idx_similarity_checks_assignment" ON "public"."similarity_checks" ("tenant_id", "class_id", "assignment_id", "created_at");
+-- Create index "idx_similarity_checks_status" to table: "similarity_checks"
+CREATE INDEX "idx_similarity_checks_status" ON "public"."similarity_checks" ("status", "available_at");
+-- Create "similarity_documents" table
+CREATE TABLE "public"."similarity_documents" (
+  "check_id" uuid NOT NULL,
+  "content_type" character varying(100) NOT NULL,
+  "content" bytea NOT NULL,
+  PRIMARY KEY ("check_id"),
+  CONSTRAINT "similarity_documents_check_id_fkey" FOREIGN KEY ("check_id") REFERENCES "public"."similarity_checks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
@@ -0,0 +1,85 @@
+-- Create "conversations" table
+CREATE TABLE "public"."conversations" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "kind" character varying(20) NOT NULL,
+  "class_id" uuid NULL,
+  "conversation_key" character varying(600) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "last_message_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "conversations_tenant_id_conversation_key_key" UNIQUE ("tenant_id", "conversation_key")
+);
+-- Create index "idx_conversations_class" to table: "conversations"
+CREATE INDEX "idx_conversations_class" ON "public"."conversations" ("tenant_id", "class_id");
+-- Create "conversation_participants" table
+CREATE TABLE "public"."conversation_participants" (
+  "conversation_id" uuid NOT NULL,
+  "user_id" character varying(255) NOT NULL,
+  PRIMARY KEY ("conversation_id", "user_id"),
+  CONSTRAINT "conversation_participants_conversation_id_fkey" FOREIGN KEY ("conversation_id") REFERENCES "public"."conversations" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_conversation_participants_user" to table: "conversation_participants"
+CREATE INDEX "idx_conversation_participants_user" ON "public"."conversation_participants" ("user_id");
+-- Create "messages" table
+CREATE TABLE "public"."messages" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "conversation_id" uuid NOT NULL,
+  "sender_id" character varying(255) NOT NULL,
+  "body" text NOT NULL,
+  "hidden_at" timestamptz NULL,
+  "hidden_by" character varying(255) NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "messages_conversation_id_fkey" FOREIGN KEY ("conversation_id") REFERENCES "public"."conversations" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_messages_conversation" to table: "messages"
+CREATE INDEX "idx_messages_conversation" ON "public"."messages" ("conversation_id", "created_at");
+-- Create "message_attachments" table
+CREATE TABLE "public"."message_attachments" (
+  "id" uuid NOT NULL,
+  "message_id" uuid NOT NULL,
+  "file_name" character varying(255) NOT NULL,
+  "content_type" character varying(100) NOT NULL,
+  "size_bytes" bigint NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "message_attachments_message_id_fkey" FOREIGN KEY ("message_id") REFERENCES "public"."messages" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_message_attachments_message" to table: "message_attachments"
+CREATE INDEX "idx_message_attachments_message" ON "public"."message_attachments" ("message_id");
+-- Create "read_receipts" table
+CREATE TABLE "public"."read_receipts" (
+  "conversation_id" uuid NOT NULL,
+  "user_id" character varying(255) NOT NULL,
+  "read_at" timestamptz NOT NULL,
+  PRIMARY KEY ("conversation_id", "user_id"),
+  CONSTRAINT "read_receipts_conversation_id_fkey" FOREIGN KEY ("conversation_id") REFERENCES "public"."conversations" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create "abuse_reports" table
+CREATE TABLE "public"."abuse_reports" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "message_id" uuid NOT NULL,
+  "conversation_id" uuid NOT NULL,
+  "reporter_id" character varying(255) NOT NULL,
+  "reason" text NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "resolved_by" character varying(255) NULL,
+  "resolved_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "abuse_reports_message_id_fkey" FOREIGN KEY ("message_id") REFERENCES "public"."messages" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_abuse_reports_status" to table: "abuse_reports"
+CREATE INDEX "idx_abuse_reports_status" ON "public"."abuse_reports" ("tenant_id", "status", "created_at");
+-- Create "guardian_links" table
+CREATE TABLE "public"."guardian_links" (
+  "tenant_id" character varying(255) NOT NULL,
+  "guardian_id" character varying(255) NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("tenant_id", "guardian_id", "student_id")
+);
+-- Create index "idx_guardian_links_student" to table: "guardian_links"
+CREATE INDEX "idx_guardian_links_student" ON "public"."guardian_links" ("tenant_id", "student_id");
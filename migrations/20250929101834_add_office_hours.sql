@@ -0,0 +1,44 @@
+-- Create "availability_slots" table
+CREATE TABLE "public"."availability_slots" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "teacher_id" character varying(255) NOT NULL,
+  "starts_at" timestamptz NOT NULL,
+  "ends_at" timestamptz NOT NULL,
+  "capacity" integer NOT NULL,
+  "location" character varying(255) NULL,
+  "canceled_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "availability_slots_capacity_check" CHECK (capacity > 0),
+  CONSTRAINT "availability_slots_check" CHECK (ends_at > starts_at)
+);
+-- Create index "idx_availability_slots_teacher" to table: "availability_slots"
+CREATE INDEX "idx_availability_slots_teacher" ON "public"."availability_slots" ("tenant_id", "teacher_id", "starts_at");
+-- Create "appointments" table
+CREATE TABLE "public"."appointments" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "slot_id" uuid NOT NULL,
+  "teacher_id" character varying(255) NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  "starts_at" timestamptz NOT NULL,
+  "ends_at" timestamptz NOT NULL,
+  "location" character varying(255) NULL,
+  "topic" character varying(500) NULL,
+  "status" character varying(20) NOT NULL,
+  "reminder_sent_at" timestamptz NULL,
+  "canceled_at" timestamptz NULL,
+  "canceled_by" character varying(255) NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "appointments_slot_id_fkey" FOREIGN KEY ("slot_id") REFERENCES "public"."availability_slots" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_appointments_pending_reminders" to table: "appointments"
+CREATE INDEX "idx_appointments_pending_reminders" ON "public"."appointments" ("starts_at") WHERE (((status)::text = 'booked'::text) AND (reminder_sent_at IS NULL));
+-- Create index "idx_appointments_slot" to table: "appointments"
+CREATE INDEX "idx_appointments_slot" ON "public"."appointments" ("slot_id", "status");
+-- Create index "idx_appointments_student" to table: "appointments"
+CREATE INDEX "idx_appointments_student" ON "public"."appointments" ("tenant_id", "student_id", "starts_at");
+-- Create index "idx_appointments_teacher" to table: "appointments"
+CREATE INDEX "idx_appointments_teacher" ON "public"."appointments" ("tenant_id", "teacher_id", "starts_at");
@@ -0,0 +1,57 @@
+-- Create "incidents" table
+CREATE TABLE "public"."incidents" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "type" character varying(30) NOT NULL,
+  "severity" character varying(20) NOT NULL,
+  "summary" text NOT NULL,
+  "location" character varying(255) NULL,
+  "reported_by" character varying(255) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "resolution" text NULL,
+  "resolved_by" character varying(255) NULL,
+  "resolved_at" timestamptz NULL,
+  "occurred_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_incidents_tenant" to table: "incidents"
+CREATE INDEX "idx_incidents_tenant" ON "public"."incidents" ("tenant_id", "occurred_at" DESC);
+-- Create "incident_guardian_notifications" table
+CREATE TABLE "public"."incident_guardian_notifications" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "incident_id" uuid NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  "guardian_id" character varying(255) NOT NULL,
+  "queued_by" character varying(255) NOT NULL,
+  "queued_at" timestamptz NOT NULL,
+  "acknowledged_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "incident_guardian_notificatio_incident_id_student_id_guardi_key" UNIQUE ("incident_id", "student_id", "guardian_id"),
+  CONSTRAINT "incident_guardian_notifications_incident_id_fkey" FOREIGN KEY ("incident_id") REFERENCES "public"."incidents" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create "incident_notes" table
+CREATE TABLE "public"."incident_notes" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "incident_id" uuid NOT NULL,
+  "author_id" character varying(255) NOT NULL,
+  "body" text NOT NULL,
+  "restricted" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "incident_notes_incident_id_fkey" FOREIGN KEY ("incident_id") REFERENCES "public"."incidents" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_incident_notes_incident" to table: "incident_notes"
+CREATE INDEX "idx_incident_notes_incident" ON "public"."incident_notes" ("incident_id", "created_at");
+-- Create "incident_students" table
+CREATE TABLE "public"."incident_students" (
+  "incident_id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  PRIMARY KEY ("incident_id", "student_id"),
+  CONSTRAINT "incident_students_incident_id_fkey" FOREIGN KEY ("incident_id") REFERENCES "public"."incidents" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_incident_students_student" to table: "incident_students"
+CREATE INDEX "idx_incident_students_student" ON "public"."incident_students" ("tenant_id", "student_id");
@@ -0,0 +1,40 @@
+-- Create "resources" table
+CREATE TABLE "public"."resources" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "kind" character varying(20) NOT NULL,
+  "title" character varying(200) NOT NULL,
+  "description" text NOT NULL DEFAULT '',
+  "subject" character varying(100) NULL,
+  "grade_level" character varying(20) NULL,
+  "tags" text[] NOT NULL DEFAULT '{}',
+  "url" text NULL,
+  "status" character varying(20) NOT NULL,
+  "current_version" integer NOT NULL DEFAULT 0,
+  "owner_id" character varying(255) NOT NULL,
+  "published_by" character varying(255) NULL,
+  "published_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_resources_tags" to table: "resources"
+CREATE INDEX "idx_resources_tags" ON "public"."resources" USING GIN ("tags");
+-- Create index "idx_resources_tenant" to table: "resources"
+CREATE INDEX "idx_resources_tenant" ON "public"."resources" ("tenant_id", "status", "updated_at" DESC);
+-- Create "resource_versions" table
+CREATE TABLE "public"."resource_versions" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "resource_id" uuid NOT NULL,
+  "version" integer NOT NULL,
+  "file_name" character varying(255) NOT NULL,
+  "content_type" character varying(100) NOT NULL,
+  "size" bigint NOT NULL,
+  "checksum" character varying(64) NOT NULL,
+  "uploaded_by" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "resource_versions_resource_id_version_key" UNIQUE ("resource_id", "version"),
+  CONSTRAINT "resource_versions_resource_id_fkey" FOREIGN KEY ("resource_id") REFERENCES "public"."resources" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
@@ -0,0 +1,42 @@
+-- Create "forms" table
+CREATE TABLE "public"."forms" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "title" character varying(200) NOT NULL,
+  "description" text NOT NULL DEFAULT '',
+  "anonymous" boolean NOT NULL DEFAULT false,
+  "status" character varying(20) NOT NULL,
+  "questions" jsonb NOT NULL,
+  "audience_roles" text[] NOT NULL DEFAULT '{}',
+  "audience_class_ids" text[] NOT NULL DEFAULT '{}',
+  "created_by" character varying(255) NOT NULL,
+  "closes_at" timestamptz NULL,
+  "opened_at" timestamptz NULL,
+  "closed_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_forms_tenant" to table: "forms"
+CREATE INDEX "idx_forms_tenant" ON "public"."forms" ("tenant_id", "status", "created_at" DESC);
+-- Create "form_participations" table
+CREATE TABLE "public"."form_participations" (
+  "form_id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "user_id" character varying(255) NOT NULL,
+  PRIMARY KEY ("form_id", "user_id"),
+  CONSTRAINT "form_participations_form_id_fkey" FOREIGN KEY ("form_id") REFERENCES "public"."forms" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create "form_responses" table
+CREATE TABLE "public"."form_responses" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "form_id" uuid NOT NULL,
+  "respondent_id" character varying(255) NULL,
+  "answers" jsonb NOT NULL,
+  "submitted_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "form_responses_form_id_fkey" FOREIGN KEY ("form_id") REFERENCES "public"."forms" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_form_responses_form" to table: "form_responses"
+CREATE INDEX "idx_form_responses_form" ON "public"."form_responses" ("form_id", "submitted_at");
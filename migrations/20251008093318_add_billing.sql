@@ -0,0 +1,38 @@
+-- Create "invoices" table
+CREATE TABLE "public"."invoices" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "provider_id" character varying(255) NOT NULL,
+  "number" character varying(100) NOT NULL DEFAULT '',
+  "status" character varying(20) NOT NULL,
+  "currency" character varying(3) NOT NULL,
+  "amount_due" bigint NOT NULL,
+  "amount_paid" bigint NOT NULL,
+  "hosted_url" text NULL,
+  "period_start" timestamptz NOT NULL,
+  "period_end" timestamptz NOT NULL,
+  "attempt_count" integer NOT NULL DEFAULT 0,
+  "next_payment_attempt" timestamptz NULL,
+  "dunning_attempt" integer NOT NULL DEFAULT 0,
+  "synced_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "invoices_provider_id_key" UNIQUE ("provider_id")
+);
+-- Create index "idx_invoices_tenant" to table: "invoices"
+CREATE INDEX "idx_invoices_tenant" ON "public"."invoices" ("tenant_id", "created_at" DESC);
+-- Create "subscriptions" table
+CREATE TABLE "public"."subscriptions" (
+  "tenant_id" character varying(255) NOT NULL,
+  "plan_code" character varying(20) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "customer_id" character varying(255) NOT NULL,
+  "provider_id" character varying(255) NOT NULL,
+  "current_period_end" timestamptz NULL,
+  "cancel_at_period_end" boolean NOT NULL DEFAULT false,
+  "synced_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("tenant_id")
+);
+-- Create index "idx_subscriptions_customer" to table: "subscriptions"
+CREATE INDEX "idx_subscriptions_customer" ON "public"."subscriptions" ("customer_id");
@@ -0,0 +1,7 @@
+-- Create "api_usage" table
+CREATE TABLE "public"."api_usage" (
+  "tenant_id" character varying(255) NOT NULL,
+  "day" date NOT NULL,
+  "calls" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("tenant_id", "day")
+);
@@ -0,0 +1,11 @@
+-- Create "translations" table
+CREATE TABLE "public"."translations" (
+  "tenant_id" character varying(255) NOT NULL,
+  "entity_type" character varying(20) NOT NULL,
+  "entity_id" uuid NOT NULL,
+  "locale" character varying(35) NOT NULL,
+  "fields" jsonb NOT NULL,
+  "updated_by" character varying(255) NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "entity_type", "entity_id", "locale")
+);
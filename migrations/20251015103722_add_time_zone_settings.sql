@@ -0,0 +1,8 @@
+-- Create "time_zone_settings" table
+CREATE TABLE "public"."time_zone_settings" (
+  "tenant_id" character varying(255) NOT NULL,
+  "user_id" character varying(255) NOT NULL DEFAULT '',
+  "time_zone" character varying(64) NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "user_id")
+);
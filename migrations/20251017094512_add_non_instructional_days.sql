@@ -0,0 +1,10 @@
+-- Create "non_instructional_days" table
+CREATE TABLE "public"."non_instructional_days" (
+  "tenant_id" character varying(255) NOT NULL,
+  "day" date NOT NULL,
+  "name" character varying(200) NOT NULL,
+  "country" character varying(2) NOT NULL DEFAULT '',
+  "created_by" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "day")
+);
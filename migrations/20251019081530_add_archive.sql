@@ -0,0 +1,35 @@
+-- Create "archive_batches" table
+CREATE TABLE "public"."archive_batches" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "kind" character varying(20) NOT NULL,
+  "cutoff" timestamptz NOT NULL,
+  "record_count" integer NOT NULL,
+  "restored_count" integer NOT NULL DEFAULT 0,
+  "status" character varying(20) NOT NULL,
+  "archived_at" timestamptz NOT NULL,
+  "restored_by" character varying(255) NULL,
+  "restored_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_archive_batches_tenant" to table: "archive_batches"
+CREATE INDEX "idx_archive_batches_tenant" ON "public"."archive_batches" ("tenant_id", "archived_at");
+-- Create "archived_records" table
+CREATE TABLE "public"."archived_records" (
+  "kind" character varying(20) NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "record_id" character varying(255) NOT NULL,
+  "batch_id" uuid NOT NULL,
+  "recorded_at" timestamptz NOT NULL,
+  "payload" jsonb NOT NULL,
+  "archived_at" timestamptz NOT NULL,
+  PRIMARY KEY ("kind", "tenant_id", "record_id")
+) PARTITION BY LIST ("kind");
+-- Create index "idx_archived_records_batch" to table: "archived_records"
+CREATE INDEX "idx_archived_records_batch" ON "public"."archived_records" ("batch_id");
+-- Create "archived_submissions" table
+CREATE TABLE "public"."archived_submissions" PARTITION OF "public"."archived_records" FOR VALUES IN ('submissions');
+-- Create "archived_messages" table
+CREATE TABLE "public"."archived_messages" PARTITION OF "public"."archived_records" FOR VALUES IN ('messages');
+-- Create "archived_audit_entries" table
+CREATE TABLE "public"."archived_audit_entries" PARTITION OF "public"."archived_records" FOR VALUES IN ('audit');
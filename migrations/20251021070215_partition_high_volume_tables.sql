@@ -0,0 +1,72 @@
+-- Rename "outbox_events" table, it is replaced by a table partitioned by month of "occurred_at"
+ALTER TABLE "public"."outbox_events" RENAME TO "outbox_events_unpartitioned";
+ALTER TABLE "public"."outbox_events_unpartitioned" RENAME CONSTRAINT "outbox_events_pkey" TO "outbox_events_unpartitioned_pkey";
+-- Create "outbox_events" table
+CREATE TABLE "public"."outbox_events" (
+  "position" bigint NOT NULL DEFAULT nextval('outbox_events_position_seq'::regclass),
+  "event_type" character varying(100) NOT NULL,
+  "aggregate_id" character varying(255) NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "payload" jsonb NOT NULL,
+  "occurred_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("position", "occurred_at")
+) PARTITION BY RANGE ("occurred_at");
+-- Create "outbox_events_default" table
+CREATE TABLE "public"."outbox_events_default" PARTITION OF "public"."outbox_events" DEFAULT;
+-- Keep the positions of the outbox, projections checkpoint on them
+ALTER SEQUENCE "public"."outbox_events_position_seq" OWNED BY "public"."outbox_events"."position";
+-- Copy existing rows into the default partition, the partition maintenance worker moves them into monthly partitions
+INSERT INTO "public"."outbox_events" ("position", "event_type", "aggregate_id", "tenant_id", "payload", "occurred_at")
+SELECT "position", "event_type", "aggregate_id", "tenant_id", "payload", "occurred_at" FROM "public"."outbox_events_unpartitioned";
+-- Drop "outbox_events_unpartitioned" table
+DROP TABLE "public"."outbox_events_unpartitioned";
+
+-- Rename "tenant_daily_active_users" table, it is replaced by a table partitioned by month of "day"
+ALTER TABLE "public"."tenant_daily_active_users" RENAME TO "tenant_daily_active_users_unpartitioned";
+ALTER TABLE "public"."tenant_daily_active_users_unpartitioned" RENAME CONSTRAINT "tenant_daily_active_users_pkey" TO "tenant_daily_active_users_unpartitioned_pkey";
+-- Create "tenant_daily_active_users" table
+CREATE TABLE "public"."tenant_daily_active_users" (
+  "tenant_id" character varying(255) NOT NULL,
+  "day" date NOT NULL,
+  "user_id" character varying(255) NOT NULL,
+  PRIMARY KEY ("tenant_id", "day", "user_id")
+) PARTITION BY RANGE ("day");
+-- Create "tenant_daily_active_users_default" table
+CREATE TABLE "public"."tenant_daily_active_users_default" PARTITION OF "public"."tenant_daily_active_users" DEFAULT;
+INSERT INTO "public"."tenant_daily_active_users" ("tenant_id", "day", "user_id")
+SELECT "tenant_id", "day", "user_id" FROM "public"."tenant_daily_active_users_unpartitioned";
+-- Drop "tenant_daily_active_users_unpartitioned" table
+DROP TABLE "public"."tenant_daily_active_users_unpartitioned";
+
+-- Rename "incident_guardian_notifications" table, it is replaced by a table hash partitioned by "tenant_id"
+ALTER TABLE "public"."incident_guardian_notifications" RENAME TO "incident_guardian_notifications_unpartitioned";
+ALTER TABLE "public"."incident_guardian_notifications_unpartitioned" RENAME CONSTRAINT "incident_guardian_notifications_pkey" TO "incident_guardian_notifications_unpartitioned_pkey";
+ALTER TABLE "public"."incident_guardian_notifications_unpartitioned" RENAME CONSTRAINT "incident_guardian_notificatio_incident_id_student_id_guardi_key" TO "incident_guardian_notifications_unpartitioned_key";
+ALTER TABLE "public"."incident_guardian_notifications_unpartitioned" RENAME CONSTRAINT "incident_guardian_notifications_incident_id_fkey" TO "incident_guardian_notifications_unpartitioned_incident_id_fkey";
+-- Create "incident_guardian_notifications" table
+CREATE TABLE "public"."incident_guardian_notifications" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "incident_id" uuid NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  "guardian_id" character varying(255) NOT NULL,
+  "queued_by" character varying(255) NOT NULL,
+  "queued_at" timestamptz NOT NULL,
+  "acknowledged_at" timestamptz NULL,
+  PRIMARY KEY ("tenant_id", "id"),
+  CONSTRAINT "incident_guardian_notifications_tenant_id_incident_id_stud_key" UNIQUE ("tenant_id", "incident_id", "student_id", "guardian_id"),
+  CONSTRAINT "incident_guardian_notifications_incident_id_fkey" FOREIGN KEY ("incident_id") REFERENCES "public"."incidents" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+) PARTITION BY HASH ("tenant_id");
+-- Create "incident_guardian_notifications_0" to "incident_guardian_notifications_7" tables
+CREATE TABLE "public"."incident_guardian_notifications_0" PARTITION OF "public"."incident_guardian_notifications" FOR VALUES WITH (MODULUS 8, REMAINDER 0);
+CREATE TABLE "public"."incident_guardian_notifications_1" PARTITION OF "public"."incident_guardian_notifications" FOR VALUES WITH (MODULUS 8, REMAINDER 1);
+CREATE TABLE "public"."incident_guardian_notifications_2" PARTITION OF "public"."incident_guardian_notifications" FOR VALUES WITH (MODULUS 8, REMAINDER 2);
+CREATE TABLE "public"."incident_guardian_notifications_3" PARTITION OF "public"."incident_guardian_notifications" FOR VALUES WITH (MODULUS 8, REMAINDER 3);
+CREATE TABLE "public"."incident_guardian_notifications_4" PARTITION OF "public"."incident_guardian_notifications" FOR VALUES WITH (MODULUS 8, REMAINDER 4);
+CREATE TABLE "public"."incident_guardian_notifications_5" PARTITION OF "public"."incident_guardian_notifications" FOR VALUES WITH (MODULUS 8, REMAINDER 5);
+CREATE TABLE "public"."incident_guardian_notifications_6" PARTITION OF "public"."incident_guardian_notifications" FOR VALUES WITH (MODULUS 8, REMAINDER 6);
+CREATE TABLE "public"."incident_guardian_notifications_7" PARTITION OF "public"."incident_guardian_notifications" FOR VALUES WITH (MODULUS 8, REMAINDER 7);
+INSERT INTO "public"."incident_guardian_notifications" ("id", "tenant_id", "incident_id", "student_id", "guardian_id", "queued_by", "queued_at", "acknowledged_at")
+SELECT "id", "tenant_id", "incident_id", "student_id", "guardian_id", "queued_by", "queued_at", "acknowledged_at" FROM "public"."incident_guardian_notifications_unpartitioned";
+-- Drop "incident_guardian_notifications_unpartitioned" table
+DROP TABLE "public"."incident_guardian_notifications_unpartitioned";
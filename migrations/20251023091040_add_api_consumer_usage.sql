@@ -0,0 +1,15 @@
+-- Create "api_consumer_usage" table
+CREATE TABLE "public"."api_consumer_usage" (
+  "tenant_id" character varying(255) NOT NULL,
+  "consumer_kind" character varying(20) NOT NULL,
+  "consumer_id" character varying(255) NOT NULL,
+  "operation_id" character varying(100) NOT NULL,
+  "hour" timestamptz NOT NULL,
+  "requests" bigint NOT NULL DEFAULT 0,
+  "client_errors" bigint NOT NULL DEFAULT 0,
+  "server_errors" bigint NOT NULL DEFAULT 0,
+  "latency_ms" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("tenant_id", "hour", "consumer_kind", "consumer_id", "operation_id")
+);
+-- Create index "idx_api_consumer_usage_hour" to table: "api_consumer_usage"
+CREATE INDEX "idx_api_consumer_usage_hour" ON "public"."api_consumer_usage" ("hour");
@@ -0,0 +1,8 @@
+-- Create "tenant_keys" table
+CREATE TABLE "public"."tenant_keys" (
+  "tenant_id" character varying(255) NOT NULL,
+  "wrapped_key" bytea NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "destroyed_at" timestamptz NULL,
+  PRIMARY KEY ("tenant_id")
+);
@@ -0,0 +1,20 @@
+-- Create "tenant_backups" table
+CREATE TABLE "public"."tenant_backups" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "requested_by" character varying(255) NOT NULL,
+  "storage_key" character varying(500) NULL,
+  "tables" jsonb NOT NULL DEFAULT '{}',
+  "size_bytes" bigint NOT NULL DEFAULT 0,
+  "checksum" character varying(64) NULL,
+  "failure_reason" text NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "completed_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_tenant_backups_status" to table: "tenant_backups"
+CREATE INDEX "idx_tenant_backups_status" ON "public"."tenant_backups" ("status");
+-- Create index "idx_tenant_backups_tenant" to table: "tenant_backups"
+CREATE INDEX "idx_tenant_backups_tenant" ON "public"."tenant_backups" ("tenant_id", "created_at");
@@ -0,0 +1,13 @@
+-- Create "schema_changes" table
+CREATE TABLE "public"."schema_changes" (
+  "name" character varying(100) NOT NULL,
+  "phase" character varying(20) NOT NULL,
+  "backfill_cursor" text NOT NULL DEFAULT '',
+  "backfilled" bigint NOT NULL DEFAULT 0,
+  "total" bigint NOT NULL DEFAULT 0,
+  "phase_changed_at" timestamptz NOT NULL DEFAULT now(),
+  "backfilled_at" timestamptz NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("name"),
+  CONSTRAINT "schema_changes_phase_check" CHECK ((phase)::text = ANY ((ARRAY['old'::character varying, 'expanded'::character varying, 'read_new'::character varying, 'contracted'::character varying])::text[]))
+);
@@ -0,0 +1,27 @@
+-- Modify "grades" table
+ALTER TABLE "public"."grades" ADD COLUMN "version" integer NOT NULL DEFAULT 0;
+-- Create "grade_events" table
+CREATE TABLE "public"."grade_events" (
+  "tenant_id" character varying(255) NOT NULL,
+  "grade_id" uuid NOT NULL,
+  "version" integer NOT NULL,
+  "class_id" uuid NOT NULL,
+  "assignment_id" uuid NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  "event_type" character varying(20) NOT NULL,
+  "actor_id" character varying(255) NOT NULL,
+  "reason" text NULL,
+  "old_score" double precision NULL,
+  "new_score" double precision NOT NULL,
+  "old_status" character varying(20) NULL,
+  "new_status" character varying(20) NOT NULL,
+  "turned_in_at" timestamptz NULL,
+  "late_penalty_percent" double precision NOT NULL DEFAULT 0,
+  "occurred_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "grade_id", "version")
+);
+-- Start the history of existing grades with their current value
+INSERT INTO "public"."grade_events" ("tenant_id", "grade_id", "version", "class_id", "assignment_id", "student_id", "event_type", "actor_id", "new_score", "new_status", "turned_in_at", "late_penalty_percent", "occurred_at")
+SELECT "tenant_id", "id", 1, "class_id", "assignment_id", "student_id", 'imported', "recorded_by", "score", "status", "turned_in_at", "late_penalty_percent", "updated_at"
+FROM "public"."grades";
+UPDATE "public"."grades" SET "version" = 1;
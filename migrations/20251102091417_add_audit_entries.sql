@@ -0,0 +1,14 @@
+-- Create "audit_entries" table
+CREATE TABLE "public"."audit_entries" (
+  "tenant_id" character varying(255) NOT NULL,
+  "sequence" bigint NOT NULL,
+  "position" bigint NOT NULL,
+  "event_type" character varying(100) NOT NULL,
+  "aggregate_id" character varying(255) NOT NULL,
+  "payload" text NOT NULL,
+  "occurred_at" timestamptz NOT NULL,
+  "prev_hash" character(64) NOT NULL,
+  "hash" character(64) NOT NULL,
+  PRIMARY KEY ("tenant_id", "sequence"),
+  CONSTRAINT "audit_entries_position_key" UNIQUE ("position")
+);
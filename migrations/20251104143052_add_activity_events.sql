@@ -0,0 +1,20 @@
+-- Create "activity_events" table
+CREATE TABLE "public"."activity_events" (
+  "id" bigserial NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "user_id" character varying(255) NOT NULL DEFAULT '',
+  "category" character varying(20) NOT NULL,
+  "type" character varying(100) NOT NULL,
+  "resource" character varying(255) NOT NULL DEFAULT '',
+  "detail" jsonb NOT NULL DEFAULT '{}',
+  "position" bigint NULL,
+  "occurred_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "activity_events_position_key" UNIQUE ("position")
+);
+-- Create index "idx_activity_events_occurred_at" to table: "activity_events"
+CREATE INDEX "idx_activity_events_occurred_at" ON "public"."activity_events" ("occurred_at");
+-- Create index "idx_activity_events_tenant" to table: "activity_events"
+CREATE INDEX "idx_activity_events_tenant" ON "public"."activity_events" ("tenant_id", "occurred_at" DESC, "id" DESC);
+-- Create index "idx_activity_events_user" to table: "activity_events"
+CREATE INDEX "idx_activity_events_user" ON "public"."activity_events" ("user_id", "occurred_at" DESC, "id" DESC);
@@ -0,0 +1,30 @@
+-- Create "service_accounts" table
+CREATE TABLE "public"."service_accounts" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "name" character varying(100) NOT NULL,
+  "description" text NOT NULL DEFAULT '',
+  "owner_id" character varying(255) NOT NULL,
+  "created_by" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "disabled_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_service_accounts_tenant" to table: "service_accounts"
+CREATE INDEX "idx_service_accounts_tenant" ON "public"."service_accounts" ("tenant_id", "created_at");
+-- Create "service_account_keys" table
+CREATE TABLE "public"."service_account_keys" (
+  "id" uuid NOT NULL,
+  "service_account_id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "secret_hash" character varying(64) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "expires_at" timestamptz NULL,
+  "revoked_at" timestamptz NULL,
+  "last_used_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "service_account_keys_service_account_id_fkey" FOREIGN KEY ("service_account_id") REFERENCES "public"."service_accounts" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_service_account_keys_account" to table: "service_account_keys"
+CREATE INDEX "idx_service_account_keys_account" ON "public"."service_account_keys" ("service_account_id");
@@ -0,0 +1,15 @@
+-- Modify "service_accounts" table
+ALTER TABLE "public"."service_accounts" ADD COLUMN "client_type" character varying(16) NOT NULL DEFAULT 'server';
+-- Modify "service_account_keys" table
+ALTER TABLE "public"."service_account_keys" ADD COLUMN "bound_ip" character varying(45) NOT NULL DEFAULT '';
+-- Create "token_policies" table
+CREATE TABLE "public"."token_policies" (
+  "tenant_id" character varying(255) NOT NULL,
+  "client_type" character varying(16) NOT NULL,
+  "key_lifetime_seconds" bigint NOT NULL,
+  "rotation_grace_seconds" bigint NOT NULL,
+  "bind_ip" boolean NOT NULL,
+  "updated_by" character varying(255) NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "client_type")
+);
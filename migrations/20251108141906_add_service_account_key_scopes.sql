@@ -0,0 +1,2 @@
+-- Modify "service_account_keys" table
+ALTER TABLE "public"."service_account_keys" ADD COLUMN "scopes" text[] NOT NULL DEFAULT '{}';
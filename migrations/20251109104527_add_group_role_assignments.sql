@@ -0,0 +1,22 @@
+-- Create "group_role_assignments" table
+CREATE TABLE "group_role_assignments" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "group_type" character varying(20) NOT NULL,
+  "group_id" uuid NOT NULL,
+  "role" character varying(100) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "requested_by" character varying(255) NOT NULL,
+  "members" integer NOT NULL DEFAULT 0,
+  "processed" integer NOT NULL DEFAULT 0,
+  "assigned" integer NOT NULL DEFAULT 0,
+  "failure_reason" text NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "completed_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_group_role_assignments_tenant" to table: "group_role_assignments"
+CREATE INDEX "idx_group_role_assignments_tenant" ON "group_role_assignments" ("tenant_id", "created_at");
+-- Create index "idx_group_role_assignments_status" to table: "group_role_assignments"
+CREATE INDEX "idx_group_role_assignments_status" ON "group_role_assignments" ("status");
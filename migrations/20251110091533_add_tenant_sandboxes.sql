@@ -0,0 +1,21 @@
+-- Create "tenant_sandboxes" table
+CREATE TABLE "tenant_sandboxes" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "sandbox_tenant_id" character varying(255) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "requested_by" character varying(255) NOT NULL,
+  "tables" jsonb NOT NULL DEFAULT '{}',
+  "roles" integer NOT NULL DEFAULT 0,
+  "failure_reason" text NULL,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "ready_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_tenant_sandboxes_current" to table: "tenant_sandboxes"
+CREATE UNIQUE INDEX "idx_tenant_sandboxes_current" ON "tenant_sandboxes" ("tenant_id") WHERE ((status)::text <> ALL ((ARRAY['deleted'::character varying, 'failed'::character varying])::text[]));
+-- Create index "idx_tenant_sandboxes_status" to table: "tenant_sandboxes"
+CREATE INDEX "idx_tenant_sandboxes_status" ON "tenant_sandboxes" ("status", "expires_at");
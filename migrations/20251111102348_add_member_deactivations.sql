@@ -0,0 +1,25 @@
+-- Create "member_deactivations" table
+CREATE TABLE "member_deactivations" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "role" character varying(100) NOT NULL,
+  "class_id" uuid NULL,
+  "status" character varying(20) NOT NULL,
+  "requested_by" character varying(255) NOT NULL,
+  "subjects" jsonb NOT NULL,
+  "roles" jsonb NOT NULL DEFAULT '{}',
+  "token_hash" character varying(64) NOT NULL,
+  "token_expires_at" timestamptz NOT NULL,
+  "confirmed_by" character varying(255) NULL,
+  "confirmed_at" timestamptz NULL,
+  "revoked" jsonb NULL,
+  "failure_reason" text NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "completed_at" timestamptz NULL,
+  "rolled_back_by" character varying(255) NULL,
+  "rolled_back_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_member_deactivations_status" to table: "member_deactivations"
+CREATE INDEX "idx_member_deactivations_status" ON "member_deactivations" ("status");
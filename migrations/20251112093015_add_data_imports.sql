@@ -0,0 +1,55 @@
+-- Create "data_imports" table
+CREATE TABLE "public"."data_imports" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "entity" character varying(100) NOT NULL,
+  "file_name" character varying(255) NOT NULL,
+  "header" jsonb NOT NULL,
+  "mapping" jsonb NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "total_rows" integer NOT NULL,
+  "imported_rows" integer NOT NULL DEFAULT 0,
+  "failed_rows" integer NOT NULL DEFAULT 0,
+  "failure_reason" text NULL,
+  "requested_by" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "completed_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_data_imports_status" to table: "data_imports"
+CREATE INDEX "idx_data_imports_status" ON "public"."data_imports" ("status");
+-- Create index "idx_data_imports_tenant_id_created_at" to table: "data_imports"
+CREATE INDEX "idx_data_imports_tenant_id_created_at" ON "public"."data_imports" ("tenant_id", "created_at");
+-- Create "data_import_files" table
+CREATE TABLE "public"."data_import_files" (
+  "import_id" uuid NOT NULL,
+  "content" bytea NOT NULL,
+  PRIMARY KEY ("import_id"),
+  CONSTRAINT "data_import_files_import_id_fkey" FOREIGN KEY ("import_id") REFERENCES "public"."data_imports" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create "data_import_rows" table
+CREATE TABLE "public"."data_import_rows" (
+  "import_id" uuid NOT NULL,
+  "line" integer NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "cells" jsonb NOT NULL,
+  "error_code" character varying(100) NULL,
+  "error_message" text NULL,
+  PRIMARY KEY ("import_id", "line"),
+  CONSTRAINT "data_import_rows_import_id_fkey" FOREIGN KEY ("import_id") REFERENCES "public"."data_imports" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create "import_mapping_templates" table
+CREATE TABLE "public"."import_mapping_templates" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "entity" character varying(100) NOT NULL,
+  "name" character varying(100) NOT NULL,
+  "mapping" jsonb NOT NULL,
+  "created_by" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_import_mapping_templates_name" to table: "import_mapping_templates"
+CREATE UNIQUE INDEX "idx_import_mapping_templates_name" ON "public"."import_mapping_templates" ("tenant_id", "entity", "name");
@@ -0,0 +1,37 @@
+-- Modify "users" table
+ALTER TABLE "public"."users" ADD COLUMN "external_id" character varying(255) NULL;
+-- Create index "idx_users_external_id" to table: "users"
+CREATE INDEX "idx_users_external_id" ON "public"."users" ("external_id");
+-- Create "duplicate_user_candidates" table
+CREATE TABLE "public"."duplicate_user_candidates" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "user_id" character varying(255) NOT NULL,
+  "duplicate_user_id" character varying(255) NOT NULL,
+  "reason" character varying(50) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "detected_at" timestamptz NOT NULL DEFAULT now(),
+  "resolved_by" character varying(255) NULL,
+  "resolved_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "duplicate_user_candidates_pair_key" UNIQUE ("tenant_id", "user_id", "duplicate_user_id")
+);
+-- Create index "idx_duplicate_user_candidates_status" to table: "duplicate_user_candidates"
+CREATE INDEX "idx_duplicate_user_candidates_status" ON "public"."duplicate_user_candidates" ("tenant_id", "status", "detected_at");
+-- Create "user_merges" table
+CREATE TABLE "public"."user_merges" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "survivor_id" character varying(255) NOT NULL,
+  "merged_id" character varying(255) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "changes" jsonb NOT NULL,
+  "requested_by" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "undo_until" timestamptz NOT NULL,
+  "undone_by" character varying(255) NULL,
+  "undone_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_user_merges_tenant_id_created_at" to table: "user_merges"
+CREATE INDEX "idx_user_merges_tenant_id_created_at" ON "public"."user_merges" ("tenant_id", "created_at");
@@ -0,0 +1,15 @@
+-- Create "linked_identities" table
+CREATE TABLE "public"."linked_identities" (
+  "id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "provider" character varying(20) NOT NULL,
+  "issuer" character varying(255) NOT NULL,
+  "subject" character varying(255) NOT NULL,
+  "email" character varying(255) NULL,
+  "linked_at" timestamptz NOT NULL DEFAULT now(),
+  "last_used_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "linked_identities_subject_key" UNIQUE ("provider", "issuer", "subject")
+);
+-- Create index "idx_linked_identities_user_id" to table: "linked_identities"
+CREATE INDEX "idx_linked_identities_user_id" ON "public"."linked_identities" ("user_id", "linked_at");
@@ -0,0 +1,4 @@
+-- Modify "saga_instances" table
+ALTER TABLE "public"."saga_instances" ADD COLUMN "owner" character varying(100) NOT NULL DEFAULT '', ADD COLUMN "lease_until" timestamptz NOT NULL DEFAULT now();
+-- Modify "saga_instances" table
+ALTER TABLE "public"."saga_instances" ALTER COLUMN "owner" DROP DEFAULT, ALTER COLUMN "lease_until" DROP DEFAULT;
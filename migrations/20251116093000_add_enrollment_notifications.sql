@@ -0,0 +1,10 @@
+-- Create "enrollment_notifications" table
+CREATE TABLE "public"."enrollment_notifications" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  "kind" character varying(30) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
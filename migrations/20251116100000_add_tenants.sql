@@ -0,0 +1,27 @@
+-- Create "tenants" table
+CREATE TABLE "public"."tenants" (
+  "id" character varying(100) NOT NULL,
+  "name" character varying(255) NOT NULL,
+  "admin_email" character varying(255) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "roles_seeded" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "activated_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create "tenant_invitations" table
+CREATE TABLE "public"."tenant_invitations" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(100) NOT NULL,
+  "email" character varying(255) NOT NULL,
+  "role" character varying(100) NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "accepted_by" character varying(255) NULL,
+  "accepted_at" timestamptz NULL,
+  "revoked_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "tenant_invitations_tenant_id_fkey" FOREIGN KEY ("tenant_id") REFERENCES "public"."tenants" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_tenant_invitations_tenant_id" to table: "tenant_invitations"
+CREATE INDEX "idx_tenant_invitations_tenant_id" ON "public"."tenant_invitations" ("tenant_id");
@@ -0,0 +1,38 @@
+-- Create "directory_sync_conflicts" table
+CREATE TABLE "public"."directory_sync_conflicts" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "user_id" uuid NOT NULL,
+  "external_id" character varying(255) NOT NULL,
+  "field" character varying(20) NOT NULL,
+  "local_value" character varying(255) NOT NULL,
+  "upstream_value" character varying(255) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "detected_at" timestamptz NOT NULL,
+  "resolution" character varying(20) NULL,
+  "resolved_by" character varying(255) NULL,
+  "resolved_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_directory_sync_conflicts_open" to table: "directory_sync_conflicts"
+CREATE UNIQUE INDEX "idx_directory_sync_conflicts_open" ON "public"."directory_sync_conflicts" ("tenant_id", "user_id", "field") WHERE ((status)::text = 'open'::text);
+-- Create index "idx_directory_sync_conflicts_status" to table: "directory_sync_conflicts"
+CREATE INDEX "idx_directory_sync_conflicts_status" ON "public"."directory_sync_conflicts" ("tenant_id", "status", "detected_at");
+-- Create "directory_sync_policies" table
+CREATE TABLE "public"."directory_sync_policies" (
+  "tenant_id" character varying(255) NOT NULL,
+  "field" character varying(20) NOT NULL,
+  "on_conflict" character varying(20) NOT NULL,
+  "updated_by" character varying(255) NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "field")
+);
+-- Create "directory_synced_values" table
+CREATE TABLE "public"."directory_synced_values" (
+  "tenant_id" character varying(255) NOT NULL,
+  "user_id" uuid NOT NULL,
+  "field" character varying(20) NOT NULL,
+  "value" character varying(255) NOT NULL,
+  "synced_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "user_id", "field")
+);
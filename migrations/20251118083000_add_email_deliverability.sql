@@ -0,0 +1,29 @@
+-- Create "email_delivery_events" table
+CREATE TABLE "public"."email_delivery_events" (
+  "provider" character varying(20) NOT NULL,
+  "event_id" character varying(255) NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "email" character varying(255) NOT NULL,
+  "type" character varying(20) NOT NULL,
+  "detail" text NOT NULL,
+  "occurred_at" timestamptz NOT NULL,
+  PRIMARY KEY ("provider", "event_id", "occurred_at")
+) PARTITION BY RANGE ("occurred_at");
+-- Create index "idx_email_delivery_events_tenant" to table: "email_delivery_events"
+CREATE INDEX "idx_email_delivery_events_tenant" ON "public"."email_delivery_events" ("tenant_id", "occurred_at");
+-- Create "email_delivery_events_default" table
+CREATE TABLE "public"."email_delivery_events_default" PARTITION OF "public"."email_delivery_events" DEFAULT;
+-- Create "email_suppressions" table
+CREATE TABLE "public"."email_suppressions" (
+  "tenant_id" character varying(255) NOT NULL,
+  "email" character varying(255) NOT NULL,
+  "reason" character varying(20) NOT NULL,
+  "provider" character varying(20) NOT NULL,
+  "detail" text NOT NULL,
+  "suppressed_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "email")
+);
+-- Create index "idx_email_suppressions_email" to table: "email_suppressions"
+CREATE INDEX "idx_email_suppressions_email" ON "public"."email_suppressions" ("email");
+-- Create index "idx_email_suppressions_tenant" to table: "email_suppressions"
+CREATE INDEX "idx_email_suppressions_tenant" ON "public"."email_suppressions" ("tenant_id", "suppressed_at");
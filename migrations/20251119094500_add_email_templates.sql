@@ -0,0 +1,23 @@
+-- Create "email_brandings" table
+CREATE TABLE "public"."email_brandings" (
+  "tenant_id" character varying(255) NOT NULL,
+  "sender_name" character varying(100) NOT NULL,
+  "logo_url" character varying(2048) NOT NULL,
+  "primary_color" character varying(7) NOT NULL,
+  "footer" character varying(1000) NOT NULL,
+  "updated_by" character varying(255) NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id")
+);
+-- Create "email_templates" table
+CREATE TABLE "public"."email_templates" (
+  "tenant_id" character varying(255) NOT NULL,
+  "flow" character varying(30) NOT NULL,
+  "subject" character varying(255) NOT NULL,
+  "html_body" text NOT NULL,
+  "text_body" text NOT NULL,
+  "enabled" boolean NOT NULL,
+  "updated_by" character varying(255) NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "flow")
+);
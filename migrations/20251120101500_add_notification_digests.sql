@@ -0,0 +1,26 @@
+-- Create "digest_preferences" table
+CREATE TABLE "public"."digest_preferences" (
+  "tenant_id" character varying(255) NOT NULL,
+  "user_id" character varying(255) NOT NULL,
+  "frequency" character varying(20) NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "user_id")
+);
+-- Create "held_notifications" table
+CREATE TABLE "public"."held_notifications" (
+  "tenant_id" character varying(255) NOT NULL,
+  "recipient_id" character varying(255) NOT NULL,
+  "position" bigint NOT NULL,
+  "type" character varying(100) NOT NULL,
+  "payload" jsonb NOT NULL,
+  "frequency" character varying(20) NOT NULL,
+  "occurred_at" timestamptz NOT NULL,
+  "due_at" timestamptz NOT NULL,
+  "digest_id" uuid NULL,
+  "sent_at" timestamptz NULL,
+  PRIMARY KEY ("tenant_id", "recipient_id", "position")
+);
+-- Create index "idx_held_notifications_due" to table: "held_notifications"
+CREATE INDEX "idx_held_notifications_due" ON "public"."held_notifications" ("due_at") WHERE (sent_at IS NULL);
+-- Create index "idx_held_notifications_sent" to table: "held_notifications"
+CREATE INDEX "idx_held_notifications_sent" ON "public"."held_notifications" ("sent_at") WHERE (sent_at IS NOT NULL);
@@ -0,0 +1,12 @@
+-- Modify "message_attachments" table
+ALTER TABLE "public"."message_attachments" ADD COLUMN "scan_status" character varying(20) NOT NULL DEFAULT 'clean';
+-- Modify "message_attachments" table
+ALTER TABLE "public"."message_attachments" ALTER COLUMN "scan_status" SET DEFAULT 'pending';
+-- Create index "idx_message_attachments_pending_scan" to table: "message_attachments"
+CREATE INDEX "idx_message_attachments_pending_scan" ON "public"."message_attachments" ("message_id") WHERE ((scan_status)::text = 'pending'::text);
+-- Modify "resource_versions" table
+ALTER TABLE "public"."resource_versions" ADD COLUMN "scan_status" character varying(20) NOT NULL DEFAULT 'clean';
+-- Modify "resource_versions" table
+ALTER TABLE "public"."resource_versions" ALTER COLUMN "scan_status" SET DEFAULT 'pending';
+-- Create index "idx_resource_versions_pending_scan" to table: "resource_versions"
+CREATE INDEX "idx_resource_versions_pending_scan" ON "public"."resource_versions" ("created_at") WHERE ((scan_status)::text = 'pending'::text);
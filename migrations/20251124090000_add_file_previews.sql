@@ -0,0 +1,8 @@
+-- Modify "message_attachments" table
+ALTER TABLE "public"."message_attachments" ADD COLUMN "preview_status" character varying(20) NOT NULL DEFAULT 'pending';
+-- Create index "idx_message_attachments_pending_preview" to table: "message_attachments"
+CREATE INDEX "idx_message_attachments_pending_preview" ON "public"."message_attachments" ("message_id") WHERE ((preview_status)::text = 'pending'::text);
+-- Modify "resource_versions" table
+ALTER TABLE "public"."resource_versions" ADD COLUMN "preview_status" character varying(20) NOT NULL DEFAULT 'pending';
+-- Create index "idx_resource_versions_pending_preview" to table: "resource_versions"
+CREATE INDEX "idx_resource_versions_pending_preview" ON "public"."resource_versions" ("created_at") WHERE ((preview_status)::text = 'pending'::text);
@@ -0,0 +1,38 @@
+-- Create "storage_quotas" table
+CREATE TABLE "public"."storage_quotas" (
+  "tenant_id" character varying(255) NOT NULL,
+  "tenant_bytes" bigint NOT NULL,
+  "user_bytes" bigint NOT NULL,
+  "updated_by" character varying(255) NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id")
+);
+-- Create "storage_usage" table
+CREATE TABLE "public"."storage_usage" (
+  "tenant_id" character varying(255) NOT NULL,
+  "owner_id" character varying(255) NOT NULL,
+  "bytes" bigint NOT NULL,
+  "files" bigint NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "owner_id")
+);
+-- Create index "idx_storage_usage_bytes" to table: "storage_usage"
+CREATE INDEX "idx_storage_usage_bytes" ON "public"."storage_usage" ("tenant_id", "bytes" DESC, "owner_id" DESC);
+-- Backfill "storage_usage" from the files already stored
+INSERT INTO "public"."storage_usage" ("tenant_id", "owner_id", "bytes", "files", "updated_at")
+SELECT tenant_id, owner_id, SUM(bytes), count(*), NOW()
+FROM (
+  SELECT v.tenant_id, v.uploaded_by AS owner_id, v.size AS bytes
+  FROM "public"."resource_versions" v
+  WHERE v.scan_status NOT IN ('infected', 'missing')
+  UNION ALL
+  SELECT m.tenant_id, m.sender_id, a.size_bytes
+  FROM "public"."message_attachments" a
+  JOIN "public"."messages" m ON m.id = a.message_id
+  WHERE a.scan_status NOT IN ('infected', 'missing')
+  UNION ALL
+  SELECT r.tenant_id, r.payload->>'sender_id', (att->>'size_bytes')::bigint
+  FROM "public"."archived_records" r, jsonb_array_elements(r.payload->'attachments') att
+  WHERE r.kind = 'messages' AND COALESCE(att->>'scan_status', 'clean') NOT IN ('infected', 'missing')
+) files
+GROUP BY tenant_id, owner_id;
@@ -0,0 +1,30 @@
+-- Create "check_in_sessions" table
+CREATE TABLE "public"."check_in_sessions" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "starts_at" timestamptz NOT NULL,
+  "ends_at" timestamptz NOT NULL,
+  "secret" bytea NOT NULL,
+  "fence_latitude" double precision NULL,
+  "fence_longitude" double precision NULL,
+  "fence_radius_meters" integer NULL,
+  "opened_by" character varying(255) NOT NULL,
+  "opened_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "check_in_sessions_tenant_id_class_id_starts_at_key" UNIQUE ("tenant_id", "class_id", "starts_at")
+);
+-- Create "attendance_records" table
+CREATE TABLE "public"."attendance_records" (
+  "session_id" uuid NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "present" boolean NOT NULL,
+  "method" character varying(20) NOT NULL,
+  "recorded_at" timestamptz NOT NULL,
+  PRIMARY KEY ("session_id", "student_id"),
+  CONSTRAINT "attendance_records_session_id_fkey" FOREIGN KEY ("session_id") REFERENCES "public"."check_in_sessions" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_attendance_records_class" to table: "attendance_records"
+CREATE INDEX "idx_attendance_records_class" ON "public"."attendance_records" ("tenant_id", "class_id", "recorded_at");
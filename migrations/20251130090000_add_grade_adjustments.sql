@@ -0,0 +1,6 @@
+-- Modify "grades" table
+ALTER TABLE "public"."grades" ADD COLUMN "dropped" boolean NOT NULL DEFAULT false;
+-- Modify "grade_events" table
+ALTER TABLE "public"."grade_events" ADD COLUMN "dropped" boolean NOT NULL DEFAULT false, ADD COLUMN "adjustment_id" uuid NULL;
+-- Create index "idx_grade_events_adjustment" to table: "grade_events"
+CREATE INDEX "idx_grade_events_adjustment" ON "public"."grade_events" ("tenant_id", "adjustment_id") WHERE (adjustment_id IS NOT NULL);
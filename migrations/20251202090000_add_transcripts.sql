@@ -0,0 +1,48 @@
+-- Create index "idx_archived_submissions_student" to table: "archived_submissions"
+CREATE INDEX "idx_archived_submissions_student" ON "public"."archived_submissions" ("tenant_id", ((payload ->> 'student_id'::text)));
+-- Create "terms" table
+CREATE TABLE "public"."terms" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "name" character varying(100) NOT NULL,
+  "starts_on" date NOT NULL,
+  "ends_on" date NOT NULL,
+  "created_by" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_terms_tenant" to table: "terms"
+CREATE INDEX "idx_terms_tenant" ON "public"."terms" ("tenant_id", "starts_on");
+-- Create "report_card_templates" table
+CREATE TABLE "public"."report_card_templates" (
+  "tenant_id" character varying(255) NOT NULL,
+  "title" character varying(255) NOT NULL,
+  "header" text NOT NULL,
+  "footer" text NOT NULL,
+  "updated_by" character varying(255) NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id")
+);
+-- Create "report_cards" table
+CREATE TABLE "public"."report_cards" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "student_id" character varying(255) NOT NULL,
+  "term_id" uuid NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "failure_reason" text NULL,
+  "notify_guardians" boolean NOT NULL,
+  "secret" bytea NOT NULL,
+  "requested_by" character varying(255) NOT NULL,
+  "artifact_name" character varying(255) NULL,
+  "artifact" bytea NULL,
+  "expires_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "report_cards_term_id_fkey" FOREIGN KEY ("term_id") REFERENCES "public"."terms" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "idx_report_cards_expires_at" to table: "report_cards"
+CREATE INDEX "idx_report_cards_expires_at" ON "public"."report_cards" ("expires_at");
+-- Create index "idx_report_cards_status" to table: "report_cards"
+CREATE INDEX "idx_report_cards_status" ON "public"."report_cards" ("status", "created_at");
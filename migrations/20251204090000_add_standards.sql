@@ -0,0 +1,37 @@
+-- Create "standards" table
+CREATE TABLE "public"."standards" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "framework" character varying(100) NOT NULL,
+  "code" character varying(100) NOT NULL,
+  "parent_code" character varying(100) NULL,
+  "subject" character varying(100) NULL,
+  "grade_level" character varying(20) NULL,
+  "description" text NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "standards_tenant_id_framework_code_key" UNIQUE ("tenant_id", "framework", "code")
+);
+-- Create "assignment_standards" table
+CREATE TABLE "public"."assignment_standards" (
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "assignment_id" uuid NOT NULL,
+  "standard_id" uuid NOT NULL,
+  "tagged_by" character varying(255) NOT NULL,
+  "tagged_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "class_id", "assignment_id", "standard_id"),
+  CONSTRAINT "assignment_standards_standard_id_fkey" FOREIGN KEY ("standard_id") REFERENCES "public"."standards" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create "mastery_policies" table
+CREATE TABLE "public"."mastery_policies" (
+  "tenant_id" character varying(255) NOT NULL,
+  "method" character varying(20) NOT NULL,
+  "decay_weight" double precision NOT NULL,
+  "levels" jsonb NOT NULL,
+  "mastered_level" character varying(50) NOT NULL,
+  "updated_by" character varying(255) NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id")
+);
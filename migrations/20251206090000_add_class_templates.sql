@@ -0,0 +1,16 @@
+-- Create "class_templates" table
+CREATE TABLE "public"."class_templates" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "name" character varying(200) NOT NULL,
+  "description" text NULL,
+  "setup" jsonb NOT NULL,
+  "shared" boolean NOT NULL DEFAULT false,
+  "created_by" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_class_templates_shared" to table: "class_templates"
+CREATE INDEX "idx_class_templates_shared" ON "public"."class_templates" ("created_at") WHERE shared;
+-- Create index "idx_class_templates_tenant" to table: "class_templates"
+CREATE INDEX "idx_class_templates_tenant" ON "public"."class_templates" ("tenant_id", "created_at");
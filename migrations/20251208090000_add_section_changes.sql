@@ -0,0 +1,16 @@
+-- Create "section_changes" table
+CREATE TABLE "public"."section_changes" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "kind" character varying(20) NOT NULL,
+  "source_class_id" uuid NOT NULL,
+  "target_class_id" uuid NOT NULL,
+  "student_ids" text[] NOT NULL,
+  "reason" text NULL,
+  "moved" jsonb NOT NULL,
+  "requested_by" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_section_changes_tenant_id_created_at" to table: "section_changes"
+CREATE INDEX "idx_section_changes_tenant_id_created_at" ON "public"."section_changes" ("tenant_id", "created_at");
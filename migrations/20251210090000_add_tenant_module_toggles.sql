@@ -0,0 +1,9 @@
+-- Create "tenant_module_toggles" table
+CREATE TABLE "public"."tenant_module_toggles" (
+  "tenant_id" character varying(255) NOT NULL,
+  "module" character varying(64) NOT NULL,
+  "enabled" boolean NOT NULL,
+  "reason" character varying(500) NOT NULL DEFAULT '',
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "module")
+);
@@ -0,0 +1,31 @@
+-- Create "announcements" table
+CREATE TABLE "public"."announcements" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "title" character varying(200) NOT NULL,
+  "body" text NOT NULL,
+  "published_by" character varying(255) NOT NULL,
+  "published_at" timestamptz NOT NULL,
+  "expires_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_announcements_tenant" to table: "announcements"
+CREATE INDEX "idx_announcements_tenant" ON "public"."announcements" ("tenant_id", "published_at");
+-- Create "widget_keys" table
+CREATE TABLE "public"."widget_keys" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "key" character varying(64) NOT NULL,
+  "name" character varying(100) NOT NULL,
+  "allowed_origins" text[] NOT NULL,
+  "widgets" text[] NOT NULL,
+  "class_ids" text[] NOT NULL DEFAULT '{}',
+  "rate_limit_per_minute" integer NOT NULL,
+  "created_by" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "revoked_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "widget_keys_key_key" UNIQUE ("key")
+);
+-- Create index "idx_widget_keys_tenant" to table: "widget_keys"
+CREATE INDEX "idx_widget_keys_tenant" ON "public"."widget_keys" ("tenant_id", "created_at");
@@ -0,0 +1,12 @@
+-- Modify "message_attachments" table
+ALTER TABLE "public"."message_attachments" ADD COLUMN "alt_text" text NOT NULL DEFAULT '', ADD COLUMN "caption_status" character varying(20) NOT NULL DEFAULT 'not_needed';
+-- Caption the videos and audio uploaded before captions were generated
+UPDATE "public"."message_attachments" SET "caption_status" = 'pending' WHERE lower("content_type") LIKE 'video/%' OR lower("content_type") LIKE 'audio/%';
+-- Create index "idx_message_attachments_pending_captions" to table: "message_attachments"
+CREATE INDEX "idx_message_attachments_pending_captions" ON "public"."message_attachments" ("message_id") WHERE ((caption_status)::text = 'pending'::text);
+-- Modify "resource_versions" table
+ALTER TABLE "public"."resource_versions" ADD COLUMN "alt_text" text NOT NULL DEFAULT '', ADD COLUMN "caption_status" character varying(20) NOT NULL DEFAULT 'not_needed';
+-- Caption the videos and audio uploaded before captions were generated
+UPDATE "public"."resource_versions" SET "caption_status" = 'pending' WHERE lower("content_type") LIKE 'video/%' OR lower("content_type") LIKE 'audio/%';
+-- Create index "idx_resource_versions_pending_captions" to table: "resource_versions"
+CREATE INDEX "idx_resource_versions_pending_captions" ON "public"."resource_versions" ("created_at") WHERE ((caption_status)::text = 'pending'::text);
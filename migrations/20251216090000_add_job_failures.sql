@@ -0,0 +1,20 @@
+-- Create "job_failures" table
+CREATE TABLE "public"."job_failures" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "job" character varying(100) NOT NULL,
+  "subject" character varying(255) NOT NULL,
+  "payload" jsonb NOT NULL DEFAULT '{}',
+  "status" character varying(20) NOT NULL,
+  "attempts" integer NOT NULL,
+  "last_error" text NOT NULL,
+  "first_failed_at" timestamptz NOT NULL,
+  "last_failed_at" timestamptz NOT NULL,
+  "escalated_at" timestamptz NULL,
+  "requeued_at" timestamptz NULL,
+  "requeued_by" character varying(255) NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "job_failures_tenant_id_job_subject_key" UNIQUE ("tenant_id", "job", "subject")
+);
+-- Create index "idx_job_failures_tenant" to table: "job_failures"
+CREATE INDEX "idx_job_failures_tenant" ON "public"."job_failures" ("tenant_id", "last_failed_at");
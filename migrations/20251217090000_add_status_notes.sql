@@ -0,0 +1,14 @@
+-- Create "status_notes" table
+CREATE TABLE "public"."status_notes" (
+  "id" uuid NOT NULL,
+  "title" character varying(200) NOT NULL,
+  "message" text NOT NULL,
+  "components" text[] NOT NULL,
+  "level" character varying(20) NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  "resolved_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_status_notes_created" to table: "status_notes"
+CREATE INDEX "idx_status_notes_created" ON "public"."status_notes" ("created_at");
@@ -0,0 +1,16 @@
+-- Create "developer_sandboxes" table
+CREATE TABLE "public"."developer_sandboxes" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "name" character varying(100) NOT NULL,
+  "email" character varying(255) NOT NULL,
+  "service_account_id" uuid NOT NULL,
+  "tables" jsonb NOT NULL DEFAULT '{}',
+  "reset_count" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL,
+  "reset_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "developer_sandboxes_tenant_id_key" UNIQUE ("tenant_id")
+);
+-- Create index "idx_developer_sandboxes_email" to table: "developer_sandboxes"
+CREATE INDEX "idx_developer_sandboxes_email" ON "public"."developer_sandboxes" ("email");
@@ -0,0 +1,20 @@
+-- Create "webhook_deliveries" table
+CREATE TABLE "public"."webhook_deliveries" (
+  "id" uuid NOT NULL,
+  "source" character varying(50) NOT NULL,
+  "event_id" character varying(255) NOT NULL,
+  "payload" bytea NOT NULL,
+  "headers" jsonb NOT NULL DEFAULT '{}',
+  "status" character varying(20) NOT NULL,
+  "attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text NULL,
+  "next_attempt_at" timestamptz NOT NULL,
+  "received_at" timestamptz NOT NULL DEFAULT now(),
+  "processed_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "webhook_deliveries_source_event_id_key" UNIQUE ("source", "event_id")
+);
+-- Create index "idx_webhook_deliveries_due" to table: "webhook_deliveries"
+CREATE INDEX "idx_webhook_deliveries_due" ON "public"."webhook_deliveries" ("next_attempt_at") WHERE ((status)::text = ANY ((ARRAY['pending'::character varying, 'processing'::character varying])::text[]));
+-- Create index "idx_webhook_deliveries_processed" to table: "webhook_deliveries"
+CREATE INDEX "idx_webhook_deliveries_processed" ON "public"."webhook_deliveries" ("processed_at") WHERE ((status)::text = 'processed'::text);
@@ -0,0 +1,30 @@
+-- Create "custom_fields" table
+CREATE TABLE "public"."custom_fields" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "entity" character varying(20) NOT NULL,
+  "key" character varying(64) NOT NULL,
+  "label" character varying(200) NOT NULL,
+  "type" character varying(20) NOT NULL,
+  "options" text[] NOT NULL DEFAULT '{}',
+  "required" boolean NOT NULL DEFAULT false,
+  "created_by" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_custom_fields_key" to table: "custom_fields"
+CREATE UNIQUE INDEX "idx_custom_fields_key" ON "public"."custom_fields" ("tenant_id", "entity", "key");
+-- Create "entity_attributes" table
+CREATE TABLE "public"."entity_attributes" (
+  "tenant_id" character varying(255) NOT NULL,
+  "entity" character varying(20) NOT NULL,
+  "entity_id" uuid NOT NULL,
+  "attributes" jsonb NOT NULL DEFAULT '{}',
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "entity", "entity_id")
+);
+-- Create index "idx_entity_attributes_attributes" to table: "entity_attributes"
+CREATE INDEX "idx_entity_attributes_attributes" ON "public"."entity_attributes" USING GIN ("attributes" jsonb_path_ops);
+-- Create index "idx_entity_attributes_updated_at" to table: "entity_attributes"
+CREATE INDEX "idx_entity_attributes_updated_at" ON "public"."entity_attributes" ("tenant_id", "entity", "updated_at", "entity_id");
@@ -0,0 +1,18 @@
+-- Create "saved_views" table
+CREATE TABLE "public"."saved_views" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "owner_id" character varying(255) NOT NULL,
+  "list" character varying(50) NOT NULL,
+  "name" character varying(100) NOT NULL,
+  "order_by" character varying(200) NOT NULL DEFAULT '',
+  "filter" text NOT NULL DEFAULT '',
+  "shared" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_saved_views_name" to table: "saved_views"
+CREATE UNIQUE INDEX "idx_saved_views_name" ON "public"."saved_views" ("tenant_id", "owner_id", "list", "name");
+-- Create index "idx_saved_views_shared" to table: "saved_views"
+CREATE INDEX "idx_saved_views_shared" ON "public"."saved_views" ("tenant_id", "list") WHERE shared;
@@ -0,0 +1,29 @@
+-- Create "sync_change_sequence" sequence
+CREATE SEQUENCE "public"."sync_change_sequence";
+-- Create "sync_changes" table
+CREATE TABLE "public"."sync_changes" (
+  "tenant_id" character varying(255) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "entity_type" character varying(20) NOT NULL,
+  "entity_id" character varying(255) NOT NULL,
+  "sequence" bigint NOT NULL DEFAULT nextval('sync_change_sequence'::regclass),
+  "position" bigint NOT NULL,
+  "deleted" boolean NOT NULL DEFAULT false,
+  "data" jsonb NOT NULL DEFAULT '{}',
+  "changed_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "class_id", "entity_type", "entity_id")
+);
+-- Create index "idx_sync_changes_feed" to table: "sync_changes"
+CREATE INDEX "idx_sync_changes_feed" ON "public"."sync_changes" ("tenant_id", "class_id", "sequence");
+-- Create "sync_mutations" table
+CREATE TABLE "public"."sync_mutations" (
+  "tenant_id" character varying(255) NOT NULL,
+  "client_mutation_id" uuid NOT NULL,
+  "class_id" uuid NOT NULL,
+  "grade_id" uuid NOT NULL,
+  "version" integer NOT NULL,
+  "score" double precision NOT NULL,
+  "applied_by" character varying(255) NOT NULL,
+  "applied_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant_id", "client_mutation_id")
+);
@@ -0,0 +1,22 @@
+-- Create "printouts" table
+CREATE TABLE "public"."printouts" (
+  "id" uuid NOT NULL,
+  "tenant_id" character varying(255) NOT NULL,
+  "kind" character varying(30) NOT NULL,
+  "class_id" uuid NOT NULL,
+  "week_of" date NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "failure_reason" text NULL,
+  "requested_by" character varying(255) NOT NULL,
+  "artifact_name" character varying(255) NULL,
+  "artifact_content_type" character varying(100) NULL,
+  "artifact" bytea NULL,
+  "expires_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_printouts_expires_at" to table: "printouts"
+CREATE INDEX "idx_printouts_expires_at" ON "public"."printouts" ("expires_at");
+-- Create index "idx_printouts_status" to table: "printouts"
+CREATE INDEX "idx_printouts_status" ON "public"."printouts" ("status", "created_at");
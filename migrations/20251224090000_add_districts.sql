@@ -0,0 +1,31 @@
+-- Create "districts" table
+CREATE TABLE "public"."districts" (
+  "id" uuid NOT NULL,
+  "name" character varying(200) NOT NULL,
+  "min_cohort_size" integer NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "districts_min_cohort_size_check" CHECK (min_cohort_size >= 5)
+);
+-- Create "district_keys" table
+CREATE TABLE "public"."district_keys" (
+  "id" uuid NOT NULL,
+  "district_id" uuid NOT NULL,
+  "name" character varying(100) NOT NULL,
+  "key_hash" character varying(64) NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "revoked_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "district_keys_key_hash_key" UNIQUE ("key_hash"),
+  CONSTRAINT "district_keys_district_id_fkey" FOREIGN KEY ("district_id") REFERENCES "public"."districts" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create "district_tenants" table
+CREATE TABLE "public"."district_tenants" (
+  "tenant_id" character varying(100) NOT NULL,
+  "district_id" uuid NOT NULL,
+  PRIMARY KEY ("tenant_id"),
+  CONSTRAINT "district_tenants_district_id_fkey" FOREIGN KEY ("district_id") REFERENCES "public"."districts" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_district_tenants_district" to table: "district_tenants"
+CREATE INDEX "idx_district_tenants_district" ON "public"."district_tenants" ("district_id");
@@ -0,0 +1,8 @@
+-- Create "cluster_region" table
+CREATE TABLE "public"."cluster_region" (
+  "singleton" boolean NOT NULL DEFAULT true,
+  "region" character varying(50) NOT NULL,
+  "claimed_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("singleton"),
+  CONSTRAINT "cluster_region_singleton_check" CHECK (singleton)
+);
@@ -0,0 +1,8 @@
+-- Create "request_signatures" table
+CREATE TABLE "public"."request_signatures" (
+  "signature" character varying(128) NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  PRIMARY KEY ("signature")
+);
+-- Create index "idx_request_signatures_expires_at" to table: "request_signatures"
+CREATE INDEX "idx_request_signatures_expires_at" ON "public"."request_signatures" ("expires_at");
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Operation statuses of GET /operations/{operationId}
+const (
+	OperationPending   = "pending"
+	OperationRunning   = "running"
+	OperationSucceeded = "succeeded"
+	OperationFailed    = "failed"
+)
+
+// DefaultPollInterval is how long Wait waits between polls when the server does not say
+const DefaultPollInterval = 2 * time.Second
+
+// Operation is a report, import, printout or backup the REST API runs in the background. Operations
+// are not served over gRPC, the client polls them over HTTP.
+type Operation struct {
+	ID       string           `json:"id"`
+	Kind     string           `json:"kind"`
+	Status   string           `json:"status"`
+	Progress int              `json:"progress"`
+	Done     bool             `json:"done"`
+	Result   *OperationResult `json:"result,omitempty"`
+	Error    *APIError        `json:"error,omitempty"`
+}
+
+// OperationResult points to what a succeeded operation produced, paths are relative to the API
+type OperationResult struct {
+	Resource     string         `json:"resource"`
+	DownloadPath string         `json:"download_path,omitempty"`
+	Details      map[string]any `json:"details,omitempty"`
+}
+
+// APIError is the error envelope of the REST API, also the error of a failed operation
+type APIError struct {
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Context map[string]any `json:"context,omitempty"`
+	// Status is the HTTP status of the response, 0 for the error of an operation
+	Status int `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// OperationsClient polls operations over HTTP
+type OperationsClient struct {
+	baseURL    string
+	httpClient *http.Client
+	header     http.Header
+	// PollInterval is how long Wait waits between polls when a response has no Retry-After
+	PollInterval time.Duration
+}
+
+// NewOperationsClient polls the API at baseURL, such as https://api.example.com. header is sent
+// with every request, with the Authorization and X-Tenant-Id of the caller.
+func NewOperationsClient(baseURL string, httpClient *http.Client, header http.Header) *OperationsClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &OperationsClient{
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		httpClient:   httpClient,
+		header:       header,
+		PollInterval: DefaultPollInterval,
+	}
+}
+
+// OperationID reads the operation of a 202 response from its Location header
+func OperationID(response *http.Response) (string, bool) {
+	location := response.Header.Get("Location")
+	id, found := strings.CutPrefix(location, "/operations/")
+	if response.StatusCode != http.StatusAccepted || !found || id == "" {
+		return "", false
+	}
+	return id, true
+}
+
+// Get returns the operation as it is now
+func (c *OperationsClient) Get(ctx context.Context, operationID string) (*Operation, error) {
+	operation, _, err := c.get(ctx, operationID)
+	return operation, err
+}
+
+// Wait polls the operation until it is done and returns it, or fails after timeout, 0 waiting as
+// long as ctx allows. A failed operation is returned along with its error as an *APIError, one not
+// done in time along with the error of ctx.
+func (c *OperationsClient) Wait(ctx context.Context, operationID string, timeout time.Duration) (*Operation, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var last *Operation
+	for {
+		operation, retryAfter, err := c.get(ctx, operationID)
+		if err != nil {
+			if ctx.Err() != nil && last != nil {
+				return last, c.notDone(last, ctx.Err())
+			}
+			return last, err
+		}
+		if operation.Done {
+			if operation.Status == OperationFailed && operation.Error != nil {
+				return operation, operation.Error
+			}
+			return operation, nil
+		}
+		last = operation
+
+		timer := time.NewTimer(retryAfter)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return operation, c.notDone(operation, ctx.Err())
+		}
+	}
+}
+
+func (c *OperationsClient) notDone(operation *Operation, err error) error {
+	return fmt.Errorf("operation %s is still %s: %w", operation.ID, operation.Status, err)
+}
+
+func (c *OperationsClient) get(ctx context.Context, operationID string) (*Operation, time.Duration, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/operations/"+url.PathEscape(operationID), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	for name, values := range c.header {
+		request.Header[name] = values
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		var envelope struct {
+			Error *APIError `json:"error"`
+		}
+		if err := json.NewDecoder(response.Body).Decode(&envelope); err != nil || envelope.Error == nil {
+			return nil, 0, fmt.Errorf("getting operation %s: %s", operationID, response.Status)
+		}
+		envelope.Error.Status = response.StatusCode
+		return nil, 0, envelope.Error
+	}
+
+	var operation Operation
+	if err := json.NewDecoder(response.Body).Decode(&operation); err != nil {
+		return nil, 0, fmt.Errorf("reading operation %s: %w", operationID, err)
+	}
+	return &operation, c.retryAfter(response), nil
+}
+
+// retryAfter reads the seconds of the Retry-After header, the poll interval when there is none
+func (c *OperationsClient) retryAfter(response *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if c.PollInterval > 0 {
+		return c.PollInterval
+	}
+	return DefaultPollInterval
+}
+
+// IsNotFound reports whether err is the error of an unknown operation
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
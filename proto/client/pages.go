@@ -0,0 +1,110 @@
+// Package client makes the services of the proto module easier to call from Go: iterators over the
+// pages of list calls, and waiting for the operations the REST API runs in the background.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	commonv1 "github.com/nahualventure/class-backend/proto/gen/common/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrCursorRepeated stops a list whose server returned the cursor it was sent, it would never end
+var ErrCursorRepeated = errors.New("the next page has the cursor of the current one")
+
+// PageFunc fetches the page of a list page asks for, with the cursor of the page before
+type PageFunc[T any] func(ctx context.Context, page *commonv1.PageRequest) ([]T, *commonv1.PageResponse, error)
+
+// All iterates over every item of a list, fetching the next page when the loop reaches it. Listing
+// starts at the cursor of page, nil for the first page, and page is left as it is. The loop ends
+// after the last page, when ctx is done or on the first error, which is yielded with a zero item.
+func All[T any](ctx context.Context, page *commonv1.PageRequest, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		next := &commonv1.PageRequest{}
+		if page != nil {
+			next = proto.Clone(page).(*commonv1.PageRequest)
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			items, response, err := fetch(ctx, next)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			cursor := response.GetNextCursor()
+			if cursor == "" {
+				return
+			}
+			if cursor == next.GetCursor() {
+				yield(zero, ErrCursorRepeated)
+				return
+			}
+			next.Cursor = cursor
+		}
+	}
+}
+
+// Collect gathers the items of a list, at most limit of them when limit is positive
+func Collect[T any](items iter.Seq2[T, error], limit int) ([]T, error) {
+	var collected []T
+	for item, err := range items {
+		if err != nil {
+			return collected, err
+		}
+		collected = append(collected, item)
+		if limit > 0 && len(collected) == limit {
+			break
+		}
+	}
+	return collected, nil
+}
+
+// Stream fetches the pages of a list ahead of the caller, so the next page is on its way while the
+// items of the current one are handled. Up to buffer items wait on the channel, in the order of the
+// list. The channel is closed after the last item, on the first error or when ctx is done, then wait
+// returns the error. Callers that stop reading early cancel ctx, the fetching would block otherwise.
+func Stream[T any](ctx context.Context, page *commonv1.PageRequest, fetch PageFunc[T], buffer int) (items <-chan T, wait func() error) {
+	out := make(chan T, max(0, buffer))
+	done := make(chan struct{})
+	var streamErr error
+
+	go func() {
+		defer close(done)
+		defer close(out)
+		for item, err := range All(ctx, page, fetch) {
+			if err != nil {
+				streamErr = err
+				return
+			}
+			select {
+			case out <- item:
+			case <-ctx.Done():
+				streamErr = ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return out, func() error {
+		<-done
+		if streamErr != nil {
+			return fmt.Errorf("streaming the list: %w", streamErr)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	commonv1 "github.com/nahualventure/class-backend/proto/gen/common/v1"
+	userv1 "github.com/nahualventure/class-backend/proto/gen/user/v1"
+
+	"google.golang.org/grpc"
+)
+
+// UserPages fetches the pages of UserService.ListUsers
+func UserPages(users userv1.UserServiceClient, opts ...grpc.CallOption) PageFunc[*userv1.User] {
+	return func(ctx context.Context, page *commonv1.PageRequest) ([]*userv1.User, *commonv1.PageResponse, error) {
+		response, err := users.ListUsers(ctx, &userv1.ListUsersRequest{Page: page}, opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		return response.GetUsers(), response.GetPage(), nil
+	}
+}
+
+// ListUsers iterates over every user of the tenant the call is made for, see All
+func ListUsers(ctx context.Context, users userv1.UserServiceClient, page *commonv1.PageRequest,
+	opts ...grpc.CallOption) iter.Seq2[*userv1.User, error] {
+	return All(ctx, page, UserPages(users, opts...))
+}
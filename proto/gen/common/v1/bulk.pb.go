@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: common/v1/bulk.proto
+
+package commonv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BulkItemOutcome is the outcome of one item of a batch call, the result messages of batch calls
+// carry it next to the value of the item
+type BulkItemOutcome struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Position of the item in the request, from 0
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// "failed", or the status the call gives to the items that succeeded as in the JSON of the REST API
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Set when failed, the batch itself succeeds
+	Error *ErrorDetail `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *BulkItemOutcome) Reset() {
+	*x = BulkItemOutcome{}
+	mi := &file_common_v1_bulk_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkItemOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkItemOutcome) ProtoMessage() {}
+
+func (x *BulkItemOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_bulk_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkItemOutcome.ProtoReflect.Descriptor instead.
+func (*BulkItemOutcome) Descriptor() ([]byte, []int) {
+	return file_common_v1_bulk_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BulkItemOutcome) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BulkItemOutcome) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BulkItemOutcome) GetError() *ErrorDetail {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// BulkSummary counts the items of a batch call by outcome
+type BulkSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Succeeded int32 `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed    int32 `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+}
+
+func (x *BulkSummary) Reset() {
+	*x = BulkSummary{}
+	mi := &file_common_v1_bulk_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkSummary) ProtoMessage() {}
+
+func (x *BulkSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_bulk_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkSummary.ProtoReflect.Descriptor instead.
+func (*BulkSummary) Descriptor() ([]byte, []int) {
+	return file_common_v1_bulk_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BulkSummary) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BulkSummary) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+var File_common_v1_bulk_proto protoreflect.FileDescriptor
+
+var file_common_v1_bulk_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x75, 0x6c, 0x6b,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x16, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f,
+	0x76, 0x31, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x73, 0x0a, 0x0f, 0x42, 0x75, 0x6c, 0x6b, 0x49, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x63, 0x6f,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x32, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0x43, 0x0a, 0x0b, 0x42, 0x75, 0x6c, 0x6b, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x61, 0x68, 0x75, 0x61, 0x6c, 0x76, 0x65,
+	0x6e, 0x74, 0x75, 0x72, 0x65, 0x2f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x2d, 0x62, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_common_v1_bulk_proto_rawDescOnce sync.Once
+	file_common_v1_bulk_proto_rawDescData = file_common_v1_bulk_proto_rawDesc
+)
+
+func file_common_v1_bulk_proto_rawDescGZIP() []byte {
+	file_common_v1_bulk_proto_rawDescOnce.Do(func() {
+		file_common_v1_bulk_proto_rawDescData = protoimpl.X.CompressGZIP(file_common_v1_bulk_proto_rawDescData)
+	})
+	return file_common_v1_bulk_proto_rawDescData
+}
+
+var file_common_v1_bulk_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_common_v1_bulk_proto_goTypes = []any{
+	(*BulkItemOutcome)(nil), // 0: class.common.v1.BulkItemOutcome
+	(*BulkSummary)(nil),     // 1: class.common.v1.BulkSummary
+	(*ErrorDetail)(nil),     // 2: class.common.v1.ErrorDetail
+}
+var file_common_v1_bulk_proto_depIdxs = []int32{
+	2, // 0: class.common.v1.BulkItemOutcome.error:type_name -> class.common.v1.ErrorDetail
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_common_v1_bulk_proto_init() }
+func file_common_v1_bulk_proto_init() {
+	if File_common_v1_bulk_proto != nil {
+		return
+	}
+	file_common_v1_errors_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_common_v1_bulk_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_v1_bulk_proto_goTypes,
+		DependencyIndexes: file_common_v1_bulk_proto_depIdxs,
+		MessageInfos:      file_common_v1_bulk_proto_msgTypes,
+	}.Build()
+	File_common_v1_bulk_proto = out.File
+	file_common_v1_bulk_proto_rawDesc = nil
+	file_common_v1_bulk_proto_goTypes = nil
+	file_common_v1_bulk_proto_depIdxs = nil
+}
@@ -0,0 +1,16 @@
+module github.com/nahualventure/class-backend/proto
+
+go 1.24.6
+
+require (
+	google.golang.org/grpc v1.56.3
+	google.golang.org/protobuf v1.35.1
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.28.0 // indirect
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
+)
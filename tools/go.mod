@@ -0,0 +1,3 @@
+module github.com/nahualventure/class-backend/tools
+
+go 1.24.6
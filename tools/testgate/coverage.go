@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// threshold is a line of thresholds.txt, the minimum coverage of the packages matching pattern
+type threshold struct {
+	pattern string
+	minimum float64
+}
+
+// packageCoverage counts the statements of a package of core/app and the ones the tests ran
+type packageCoverage struct {
+	pkg        string
+	statements int
+	covered    int
+}
+
+func (c packageCoverage) percent() float64 {
+	if c.statements == 0 {
+		return 100
+	}
+	return 100 * float64(c.covered) / float64(c.statements)
+}
+
+// checkCoverage runs the tests of core/tests/app and reports whether every package of core/app
+// reaches its threshold
+func checkCoverage(root string, thresholdsPath string, verbose bool) (bool, error) {
+	thresholds, err := readThresholds(thresholdsPath)
+	if err != nil {
+		return false, err
+	}
+
+	profile, err := os.CreateTemp("", "testgate-*.out")
+	if err != nil {
+		return false, err
+	}
+	profile.Close()
+	defer os.Remove(profile.Name())
+
+	fmt.Println("testgate: measuring the coverage of core/app")
+	cmd := exec.Command("go", "test", "-count=1", "-coverpkg="+coreAppPackage+"/...", "-coverprofile="+profile.Name(), "./app/...")
+	cmd.Dir = filepath.Join(root, "core", "tests")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return false, fmt.Errorf("tests failed, coverage is not checked:\n%s", output)
+	}
+
+	coverage, err := readProfile(profile.Name())
+	if err != nil {
+		return false, err
+	}
+
+	ok := true
+	for _, c := range coverage {
+		minimum := minimumCoverage(thresholds, c.pkg)
+		below := c.percent() < minimum
+		if below {
+			ok = false
+		}
+		if below || verbose {
+			status := "ok"
+			if below {
+				status = "FAIL"
+			}
+			fmt.Printf("%-4s %5.1f%% (minimum %.0f%%) %s\n", status, c.percent(), minimum, c.pkg)
+		}
+	}
+	if ok {
+		fmt.Printf("testgate: coverage of %d packages passed\n", len(coverage))
+	}
+	return ok, nil
+}
+
+// readThresholds reads "pattern minimum" lines, # starts a comment
+func readThresholds(file string) ([]threshold, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var thresholds []threshold
+	for number, line := range strings.Split(string(content), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected a pattern and a minimum", file, number+1)
+		}
+		minimum, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: minimum %q is not a number", file, number+1, fields[1])
+		}
+		if _, err := path.Match(fields[0], ""); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid pattern %q", file, number+1, fields[0])
+		}
+		thresholds = append(thresholds, threshold{pattern: fields[0], minimum: minimum})
+	}
+	return thresholds, nil
+}
+
+// minimumCoverage is the minimum of the first pattern matching pkg, relative to core/app, and of
+// the default pattern when none does
+func minimumCoverage(thresholds []threshold, pkg string) float64 {
+	for _, t := range thresholds {
+		if matched, _ := path.Match(t.pattern, pkg); matched {
+			return t.minimum
+		}
+	}
+	for _, t := range thresholds {
+		if t.pattern == "default" {
+			return t.minimum
+		}
+	}
+	return 0
+}
+
+// readProfile sums a cover profile per package. With -coverpkg every test binary reports every
+// block, a block counts as covered when any of them ran it.
+func readProfile(file string) ([]packageCoverage, error) {
+	profile, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer profile.Close()
+
+	type block struct {
+		statements int
+		covered    bool
+	}
+	blocks := make(map[string]block)
+	scanner := bufio.NewScanner(profile)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "mode:") || line == "" {
+			continue
+		}
+		// file.go:12.2,14.3 2 1
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected cover profile line %q", line)
+		}
+		statements, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected cover profile line %q", line)
+		}
+		existing := blocks[fields[0]]
+		blocks[fields[0]] = block{statements: statements, covered: existing.covered || fields[2] != "0"}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	packages := make(map[string]*packageCoverage)
+	for position, b := range blocks {
+		file, _, _ := strings.Cut(position, ":")
+		pkg := strings.TrimPrefix(path.Dir(file), coreAppPackage+"/")
+		c, ok := packages[pkg]
+		if !ok {
+			c = &packageCoverage{pkg: pkg}
+			packages[pkg] = c
+		}
+		c.statements += b.statements
+		if b.covered {
+			c.covered += b.statements
+		}
+	}
+
+	coverage := make([]packageCoverage, 0, len(packages))
+	for _, c := range packages {
+		coverage = append(coverage, *c)
+	}
+	slices.SortFunc(coverage, func(a, b packageCoverage) int { return strings.Compare(a.pkg, b.pkg) })
+	return coverage, nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestReadThresholds(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []threshold
+		err     string
+	}{
+		{
+			name:    "patterns in order, comments and blank lines skipped",
+			content: "# minimums\n\n*/domain/entities   55\nshared/*  60.5 # helpers\ndefault 40\n",
+			want:    []threshold{{"*/domain/entities", 55}, {"shared/*", 60.5}, {"default", 40}},
+		},
+		{name: "empty file", content: "# nothing yet\n", want: nil},
+		{name: "missing minimum", content: "*/domain/entities\n", err: ":1: expected a pattern and a minimum"},
+		{name: "extra field", content: "\nshared/* 60 70\n", err: ":2: expected a pattern and a minimum"},
+		{name: "minimum not a number", content: "shared/* sixty\n", err: `:1: minimum "sixty" is not a number`},
+		{name: "invalid pattern", content: "shared/[ 60\n", err: `:1: invalid pattern "shared/["`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thresholds, err := readThresholds(writeFile(t, tt.content))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(thresholds, tt.want) {
+				t.Errorf("thresholds = %v, want %v", thresholds, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadThresholds_RepositoryFile(t *testing.T) {
+	thresholds, err := readThresholds("thresholds.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range []string{"grades/domain/entities", "audit/application/use-cases/list-audit-entries-use-case", "shared/pagination", "messaging/application/messaging-service"} {
+		if minimum := minimumCoverage(thresholds, pkg); minimum <= 0 {
+			t.Errorf("minimum of %s = %v, every package needs a minimum", pkg, minimum)
+		}
+	}
+}
+
+func TestMinimumCoverage(t *testing.T) {
+	thresholds := []threshold{
+		{"audit/application/use-cases/*", 30},
+		{"*/application/use-cases/*", 50},
+		{"*/domain/entities", 55},
+		{"default", 40},
+	}
+	tests := []struct {
+		pkg  string
+		want float64
+	}{
+		{"audit/application/use-cases/list-audit-entries-use-case", 30},
+		{"grades/application/use-cases/record-grade-use-case", 50},
+		{"grades/domain/entities", 55},
+		{"grades/domain/entities/nested", 40},
+		{"shared/pagination", 40},
+	}
+	for _, tt := range tests {
+		if got := minimumCoverage(thresholds, tt.pkg); got != tt.want {
+			t.Errorf("minimumCoverage(%s) = %v, want %v", tt.pkg, got, tt.want)
+		}
+	}
+
+	if got := minimumCoverage(thresholds[:1], "shared/pagination"); got != 0 {
+		t.Errorf("minimum without a default = %v, want 0", got)
+	}
+}
+
+func TestReadProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		want    []packageCoverage
+		err     string
+	}{
+		{
+			name: "blocks of every test binary merged",
+			profile: "mode: set\n" +
+				coreAppPackage + "/grades/domain/entities/grade.go:10.2,12.3 2 0\n" +
+				coreAppPackage + "/grades/domain/entities/grade.go:14.2,15.3 1 0\n" +
+				coreAppPackage + "/shared/clock/clock.go:5.2,6.3 4 1\n" +
+				// Another test binary ran the first block of grade.go
+				coreAppPackage + "/grades/domain/entities/grade.go:10.2,12.3 2 3\n" +
+				// and a third did not, the block stays covered
+				coreAppPackage + "/grades/domain/entities/grade.go:10.2,12.3 2 0\n",
+			want: []packageCoverage{
+				{pkg: "grades/domain/entities", statements: 3, covered: 2},
+				{pkg: "shared/clock", statements: 4, covered: 4},
+			},
+		},
+		{name: "mode only", profile: "mode: atomic\n", want: []packageCoverage{}},
+		{name: "truncated line", profile: "mode: set\n" + coreAppPackage + "/shared/clock/clock.go:5.2,6.3 4\n", err: "unexpected cover profile line"},
+		{name: "statements not a number", profile: "mode: set\n" + coreAppPackage + "/shared/clock/clock.go:5.2,6.3 x 1\n", err: "unexpected cover profile line"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coverage, err := readProfile(writeFile(t, tt.profile))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(coverage, tt.want) {
+				t.Errorf("coverage = %+v, want %+v", coverage, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageCoverage_Percent(t *testing.T) {
+	tests := []struct {
+		coverage packageCoverage
+		want     float64
+	}{
+		{packageCoverage{statements: 4, covered: 3}, 75},
+		{packageCoverage{statements: 4, covered: 0}, 0},
+		// A package of declarations only has nothing to cover
+		{packageCoverage{statements: 0, covered: 0}, 100},
+	}
+	for _, tt := range tests {
+		if got := tt.coverage.percent(); got != tt.want {
+			t.Errorf("percent of %+v = %v, want %v", tt.coverage, got, tt.want)
+		}
+	}
+}
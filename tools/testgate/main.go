@@ -0,0 +1,81 @@
+// Command testgate runs the tests of core/app with the quality gates of the QA process, without make:
+//
+//	go run ./tools/testgate                  # coverage and mutation gates
+//	go run ./tools/testgate -skip-mutation   # coverage only, seconds instead of minutes
+//
+// Coverage is measured per package of core/app with the tests of core/tests/app and compared with
+// thresholds.txt. Mutation testing runs go-mutesting on the domain entities and use cases, a mutant
+// is killed when the tests of its module fail.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// coreAppPackage is the import path coverage is measured for
+const coreAppPackage = "github.com/nahualventure/class-backend/core/app"
+
+func main() {
+	log.SetFlags(0)
+
+	// go-mutesting runs this command for every mutant, see runMutant
+	if os.Getenv("MUTATE_CHANGED") != "" {
+		os.Exit(runMutant())
+	}
+
+	thresholds := flag.String("thresholds", "tools/testgate/thresholds.txt", "coverage thresholds file, relative to the repository root")
+	verbose := flag.Bool("v", false, "list the coverage of every package, not only the failing ones")
+	skipMutation := flag.Bool("skip-mutation", false, "only check the coverage")
+	mutesting := flag.String("mutesting", "go-mutesting", "go-mutesting binary")
+	minScore := flag.Float64("mutation-score", 0.6, "minimum share of mutants killed per package")
+	mutate := flag.String("mutate", "*/domain/entities,*/application/use-cases/*", "packages of core/app to mutate, comma separated patterns")
+	flag.Parse()
+
+	root, err := repositoryRoot()
+	if err != nil {
+		log.Fatalf("testgate: %v", err)
+	}
+
+	failed := false
+	if ok, err := checkCoverage(root, filepath.Join(root, *thresholds), *verbose); err != nil {
+		log.Fatalf("testgate: %v", err)
+	} else if !ok {
+		failed = true
+	}
+
+	if !*skipMutation {
+		if ok, err := checkMutations(root, *mutesting, splitPatterns(*mutate), *minScore); err != nil {
+			log.Fatalf("testgate: %v", err)
+		} else if !ok {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+	fmt.Println("testgate: every gate passed")
+}
+
+// repositoryRoot is the closest directory above the working directory holding go.work
+func repositoryRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.work")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.work not found, run testgate inside the repository")
+		}
+		dir = parent
+	}
+}
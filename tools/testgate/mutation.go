@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Exit codes go-mutesting expects from the command testing a mutant
+const (
+	mutantKilled  = 0
+	mutantAlive   = 1
+	mutantSkipped = 2
+)
+
+var mutationScorePattern = regexp.MustCompile(`The mutation score is ([0-9.]+) \((\d+) passed, (\d+) failed, (\d+) duplicated, (\d+) skipped, total is (\d+)\)`)
+
+// checkMutations runs go-mutesting on every package of core/app matching patterns and reports
+// whether each one kills at least minScore of its mutants
+func checkMutations(root string, mutesting string, patterns []string, minScore float64) (bool, error) {
+	binary, err := exec.LookPath(mutesting)
+	if err != nil {
+		return false, fmt.Errorf("%s not found, install it with go install github.com/avito-tech/go-mutesting/cmd/go-mutesting@latest or pass -skip-mutation", mutesting)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return false, err
+	}
+
+	packages, err := mutationPackages(filepath.Join(root, "core", "app"), patterns)
+	if err != nil {
+		return false, err
+	}
+
+	ok := true
+	for _, pkg := range packages {
+		fmt.Printf("testgate: mutating core/app/%s\n", pkg)
+		cmd := exec.Command(binary, "--exec", self, "--exec-timeout", "120", "./app/"+pkg)
+		cmd.Dir = filepath.Join(root, "core")
+		output, _ := cmd.CombinedOutput()
+
+		score, mutants, err := parseMutationScore(output)
+		if err != nil {
+			return false, fmt.Errorf("go-mutesting on %s: %w\n%s", pkg, err, output)
+		}
+		if mutants == 0 {
+			continue
+		}
+		if score < minScore {
+			ok = false
+			fmt.Printf("FAIL %.2f (minimum %.2f) of %d mutants killed in %s\n%s", score, minScore, mutants, pkg, survivors(output))
+		}
+	}
+	if ok {
+		fmt.Printf("testgate: mutation score of %d packages passed\n", len(packages))
+	}
+	return ok, nil
+}
+
+// mutationPackages lists the packages under appDir matching patterns, relative to it
+func mutationPackages(appDir string, patterns []string) ([]string, error) {
+	var packages []string
+	err := filepath.WalkDir(appDir, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return err
+		}
+		pkg, err := filepath.Rel(appDir, dir)
+		if err != nil {
+			return err
+		}
+		pkg = filepath.ToSlash(pkg)
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, pkg); matched {
+				packages = append(packages, pkg)
+				break
+			}
+		}
+		return nil
+	})
+	return packages, err
+}
+
+func parseMutationScore(output []byte) (float64, int, error) {
+	match := mutationScorePattern.FindSubmatch(output)
+	if match == nil {
+		return 0, 0, fmt.Errorf("no mutation score in the output")
+	}
+	score, err := strconv.ParseFloat(string(match[1]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	passed, _ := strconv.Atoi(string(match[2]))
+	failed, _ := strconv.Atoi(string(match[3]))
+	return score, passed + failed, nil
+}
+
+// survivors keeps the lines go-mutesting prints for the mutants the tests did not kill
+func survivors(output []byte) string {
+	var lines []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "FAIL ") {
+			lines = append(lines, "  "+line)
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// runMutant tests the mutant go-mutesting wrote to MUTATE_CHANGED. The tests of core/app live in
+// core/tests/app, in the directory of the module, so the mutant replaces the original file while
+// they run. It returns the exit code go-mutesting reads the result from.
+func runMutant() int {
+	original, changed := os.Getenv("MUTATE_ORIGINAL"), os.Getenv("MUTATE_CHANGED")
+	source, err := os.ReadFile(original)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "testgate: %v\n", err)
+		return mutantSkipped
+	}
+	mutant, err := os.ReadFile(changed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "testgate: %v\n", err)
+		return mutantSkipped
+	}
+
+	root, err := repositoryRoot()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "testgate: %v\n", err)
+		return mutantSkipped
+	}
+	module, _, _ := strings.Cut(strings.TrimPrefix(os.Getenv("MUTATE_PACKAGE"), coreAppPackage+"/"), "/")
+	testDir := filepath.Join(root, "core", "tests", "app", module)
+	if _, err := os.Stat(testDir); err != nil {
+		// Nothing tests the module, the mutant survives
+		return mutantAlive
+	}
+
+	if err := os.WriteFile(original, mutant, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "testgate: %v\n", err)
+		return mutantSkipped
+	}
+	defer os.WriteFile(original, source, 0o644)
+
+	timeout := os.Getenv("MUTATE_TIMEOUT")
+	if timeout == "" {
+		timeout = "120"
+	}
+	cmd := exec.Command("go", "test", "-count=1", "-timeout", timeout+"s", "./app/"+module+"/...")
+	cmd.Dir = filepath.Join(root, "core", "tests")
+	output, err := cmd.CombinedOutput()
+	switch {
+	case err == nil:
+		return mutantAlive
+	case bytes.Contains(output, []byte("[build failed]")) || bytes.Contains(output, []byte("[setup failed]")):
+		// The mutant does not compile, go-mutesting counts it as skipped
+		return mutantSkipped
+	default:
+		return mutantKilled
+	}
+}
+
+func splitPatterns(patterns string) []string {
+	var split []string
+	for _, pattern := range strings.Split(patterns, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			split = append(split, pattern)
+		}
+	}
+	return split
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseMutationScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		score   float64
+		mutants int
+		err     bool
+	}{
+		{
+			name: "killed and survived mutants counted",
+			output: "PASS \"/tmp/go-mutesting/grade.go.0\" with checksum 1a2b\n" +
+				"FAIL \"/tmp/go-mutesting/grade.go.1\" with checksum 3c4d\n" +
+				"The mutation score is 0.750000 (6 passed, 2 failed, 1 duplicated, 3 skipped, total is 12)\n",
+			score:   0.75,
+			mutants: 8,
+		},
+		{
+			name:    "no mutant",
+			output:  "The mutation score is 0.000000 (0 passed, 0 failed, 0 duplicated, 0 skipped, total is 0)\n",
+			score:   0,
+			mutants: 0,
+		},
+		{name: "go-mutesting failed", output: "cannot load package ./app/grades/domain/entities\n", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, mutants, err := parseMutationScore([]byte(tt.output))
+			if tt.err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if score != tt.score || mutants != tt.mutants {
+				t.Errorf("score, mutants = %v, %d, want %v, %d", score, mutants, tt.score, tt.mutants)
+			}
+		})
+	}
+}
+
+func TestSurvivors(t *testing.T) {
+	output := "PASS \"grade.go.0\" with checksum 1a2b\nFAIL \"grade.go.1\" with checksum 3c4d\nFAIL \"grade.go.2\" with checksum 5e6f\nThe mutation score is 0.333333\n"
+	want := "  FAIL \"grade.go.1\" with checksum 3c4d\n  FAIL \"grade.go.2\" with checksum 5e6f\n"
+	if got := survivors([]byte(output)); got != want {
+		t.Errorf("survivors = %q, want %q", got, want)
+	}
+	if got := survivors([]byte("PASS \"grade.go.0\"\n")); got != "" {
+		t.Errorf("survivors without failures = %q, want none", got)
+	}
+}
+
+func TestMutationPackages(t *testing.T) {
+	appDir := t.TempDir()
+	for _, dir := range []string{
+		"grades/domain/entities",
+		"grades/domain/errors",
+		"grades/application/use-cases/record-grade-use-case",
+		"grades/application/use-cases/record-grade-use-case/internal",
+		"shared/pagination",
+	} {
+		if err := os.MkdirAll(filepath.Join(appDir, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	packages, err := mutationPackages(appDir, []string{"*/domain/entities", "*/application/use-cases/*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"grades/application/use-cases/record-grade-use-case", "grades/domain/entities"}
+	if !reflect.DeepEqual(packages, want) {
+		t.Errorf("packages = %v, want %v", packages, want)
+	}
+}
+
+func TestSplitPatterns(t *testing.T) {
+	tests := []struct {
+		patterns string
+		want     []string
+	}{
+		{"*/domain/entities,*/application/use-cases/*", []string{"*/domain/entities", "*/application/use-cases/*"}},
+		{" shared/* , ,grades/* ", []string{"shared/*", "grades/*"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := splitPatterns(tt.patterns); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitPatterns(%q) = %v, want %v", tt.patterns, got, tt.want)
+		}
+	}
+}